@@ -3,19 +3,34 @@ package main
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/signal"
 	"runtime"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
 	"Krafti_Vibe/internal/auth"
 	"Krafti_Vibe/internal/config"
+	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/infrastructure/cache"
 	"Krafti_Vibe/internal/infrastructure/database"
+	"Krafti_Vibe/internal/infrastructure/payments"
+	"Krafti_Vibe/internal/infrastructure/storage"
 	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/pkg/chaos"
 	"Krafti_Vibe/internal/pkg/health"
+	"Krafti_Vibe/internal/pkg/httpcache"
+	"Krafti_Vibe/internal/pkg/lifecycle"
 	"Krafti_Vibe/internal/pkg/logger"
+	"Krafti_Vibe/internal/pkg/metrics"
+	"Krafti_Vibe/internal/pkg/panics"
+	"Krafti_Vibe/internal/pkg/profiling"
+	"Krafti_Vibe/internal/pkg/secrets"
+	"Krafti_Vibe/internal/pkg/startup"
+	"Krafti_Vibe/internal/pkg/tracing"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/router"
 	"Krafti_Vibe/internal/service"
@@ -24,9 +39,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
-	fiberLogger "github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
-	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -41,7 +54,8 @@ import (
 // @description Get your access token from the Zitadel authentication endpoint and include it in the Authorization header.
 // @description
 // @description ## Rate Limiting
-// @description API requests are rate limited per tenant/IP. Default: 100 requests per second.
+// @description Each API key, or IP address without one, may make 100 requests per second by default, and each tenant the requests per minute of its subscription plan.
+// @description Throttled requests are answered 429 with a Retry-After header; X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset report the limit applied.
 // @description
 // @description ## Multi-tenancy
 // @description Most endpoints require a valid tenant context. Include tenant ID in request headers or URL parameters.
@@ -160,6 +174,95 @@ func run() error {
 		zap.String("arch", runtime.GOARCH),
 	)
 
+	// ============================================================================
+	// Startup
+	// ============================================================================
+
+	// Until the API listens, the health probes are answered on its address, readiness
+	// reporting the service as starting. Dependencies unavailable at boot are retried
+	// with backoff, so one briefly down delays startup rather than failing it.
+	healthChecker := health.NewHealthChecker(
+		&health.DatabaseChecker{},
+	)
+	healthChecker.SetCheckTimeout(cfg.Health.CheckTimeout)
+	healthChecker.SetStarting("resolving secrets")
+
+	startupProbes, err := health.ServeStartup(cfg.ServerAddr(), healthChecker)
+	if err != nil {
+		return fmt.Errorf("failed to serve startup probes: %w", err)
+	}
+	defer startupProbes.Close(context.Background())
+
+	startupCtx, stopStartup := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopStartup()
+	backoff := startup.Backoff{
+		Attempts: cfg.Server.StartupAttempts,
+		Initial:  cfg.Server.StartupBackoff,
+		Max:      cfg.Server.StartupMaxBackoff,
+	}
+	// Redis and Zitadel are optional in development, where waiting for them only slows
+	// down local runs
+	optionalBackoff := backoff
+	if cfg.IsDevelopment() {
+		optionalBackoff.Attempts = 1
+	}
+
+	// ============================================================================
+	// Secrets
+	// ============================================================================
+
+	// Settings may reference a secret manager rather than hold credentials. The
+	// configuration as loaded keeps the references, so reloads compare like with like
+	// and the effective configuration endpoint never shows the secrets.
+	loaded := *cfg
+	resolver := cfg.Secrets.Resolver()
+	if err := startup.Connect(startupCtx, "secrets", backoff, zapLogger, func(ctx context.Context) error {
+		return cfg.ResolveSecrets(ctx, resolver)
+	}); err != nil {
+		return fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
+	// Passwords held by a secret manager are resolved for each new connection, so
+	// rotating them takes effect within the cache TTL without a restart
+	var redisPassword func(ctx context.Context) (string, error)
+	if secrets.IsReference(loaded.Database.Password) {
+		database.SetPasswordSource(resolver.Source(loaded.Database.Password))
+	}
+	if secrets.IsReference(loaded.Redis.Password) {
+		redisPassword = resolver.Source(loaded.Redis.Password)
+	}
+
+	// ============================================================================
+	// Tracing
+	// ============================================================================
+
+	if cfg.Tracing.Enabled {
+		zapLogger.Info("initializing tracing",
+			zap.String("endpoint", cfg.Tracing.Endpoint),
+			zap.Float64("sample_ratio", cfg.Tracing.SampleRatio),
+		)
+
+		shutdownTracing, err := tracing.Initialize(context.Background(), tracing.Config{
+			Endpoint:       cfg.Tracing.Endpoint,
+			Headers:        cfg.Tracing.Headers,
+			ServiceName:    cfg.Tracing.ServiceName,
+			ServiceVersion: Version,
+			Environment:    cfg.Environment,
+			SampleRatio:    cfg.Tracing.SampleRatio,
+		}, zapLogger)
+		if err != nil {
+			return fmt.Errorf("failed to initialize tracing: %w", err)
+		}
+		defer func() {
+			// Spans still buffered are exported before exiting
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				zapLogger.Error("error flushing traces", zap.Error(err))
+			}
+		}()
+	}
+
 	// ============================================================================
 	// Database
 	// ============================================================================
@@ -170,7 +273,14 @@ func run() error {
 		zap.String("database", cfg.Database.DBName),
 	)
 
-	if err := database.Initialize(cfg, zapLogger); err != nil {
+	if err := database.ConfigureFieldEncryption(cfg, zapLogger); err != nil {
+		return fmt.Errorf("failed to configure field encryption: %w", err)
+	}
+
+	healthChecker.SetStarting("connecting to the database")
+	if err := startup.Connect(startupCtx, "database", backoff, zapLogger, func(ctx context.Context) error {
+		return database.Initialize(cfg, zapLogger)
+	}); err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 	defer func() {
@@ -182,6 +292,7 @@ func run() error {
 	zapLogger.Info("database connection established")
 
 	// Run database migrations
+	healthChecker.SetStarting("running database migrations")
 	if err := runMigrations(db, zapLogger, cfg); err != nil {
 		zapLogger.Error("database migrations failed", zap.Error(err))
 		// Don't fail startup on migration errors in production
@@ -200,7 +311,7 @@ func run() error {
 		zap.String("port", cfg.Redis.Port),
 	)
 
-	redisCache, err := cache.NewRedisClient(cache.RedisConfig{
+	redisConfig := cache.RedisConfig{
 		Host:            cfg.Redis.Host,
 		Port:            cfg.Redis.Port,
 		Password:        cfg.Redis.Password,
@@ -214,7 +325,16 @@ func run() error {
 		PoolTimeout:     cfg.Redis.PoolTimeout,
 		ConnMaxIdleTime: cfg.Redis.ConnMaxIdleTime,
 		KeyPrefix:       "kraftivibe",
-	}, zapLogger)
+		PasswordSource:  redisPassword,
+	}
+
+	healthChecker.SetStarting("connecting to Redis")
+	var redisCache *cache.RedisClient
+	err = startup.Connect(startupCtx, "redis", optionalBackoff, zapLogger, func(ctx context.Context) error {
+		var err error
+		redisCache, err = cache.NewRedisClient(redisConfig, zapLogger)
+		return err
+	})
 	if err != nil {
 		zapLogger.Error("failed to initialize redis cache", zap.Error(err))
 		// Continue without cache in development
@@ -224,6 +344,12 @@ func run() error {
 			return fmt.Errorf("redis cache required in production: %w", err)
 		}
 	} else {
+		if cfg.Tracing.Enabled {
+			redisCache.GetClient().AddHook(tracing.NewRedisHook())
+		}
+		if cfg.Chaos.Enabled {
+			redisCache.GetClient().AddHook(chaos.NewRedisHook())
+		}
 		defer func() {
 			zapLogger.Info("closing redis connection")
 			if err := redisCache.Close(); err != nil {
@@ -233,6 +359,19 @@ func run() error {
 		zapLogger.Info("redis cache initialized")
 	}
 
+	// ============================================================================
+	// File Storage
+	// ============================================================================
+
+	// Generated files are optional; features that need them report storage as unavailable
+	var objectStore storage.ObjectStore
+	if localStore, err := storage.NewLocalStore(cfg.App.StorageDir); err != nil {
+		zapLogger.Error("failed to initialize file storage", zap.String("dir", cfg.App.StorageDir), zap.Error(err))
+	} else {
+		objectStore = localStore
+		zapLogger.Info("file storage initialized", zap.String("dir", cfg.App.StorageDir))
+	}
+
 	// ============================================================================
 	// Fiber Application
 	// ============================================================================
@@ -268,17 +407,35 @@ func run() error {
 
 	zapLogger.Info("configuring middleware")
 
+	// Prometheus metrics: request rate, errors and duration by route, the database
+	// connection pool, and what the router records of repositories, caches, queues and
+	// bookings and payments
+	var promMetrics *metrics.PrometheusMetrics
+	if cfg.App.EnableMetrics {
+		promMetrics = metrics.NewPrometheusMetrics("krafti_vibe", zapLogger)
+		database.Pool().OnSample(func(sample database.PoolSample) {
+			promMetrics.UpdateDBPoolStats(sample.Stats, sample.NewWaits, sample.NewWaitDuration, sample.Saturated)
+		})
+	}
+
+	// Recovered panics are counted against each route's error budget; the first of
+	// each kind and those exhausting a budget are reported to the error tracker
+	panicReporter, err := newPanicReporter(cfg)
+	if err != nil {
+		return err
+	}
+	panicTracker := panics.NewTracker(panics.Config{
+		Budget:   cfg.Panics.Budget,
+		Window:   cfg.Panics.BudgetWindow,
+		Reporter: panicReporter,
+		Logger:   zapLogger,
+	})
+
 	// Recovery middleware - must be first to catch panics
-	app.Use(recover.New(recover.Config{
-		EnableStackTrace: cfg.IsDevelopment(),
-		StackTraceHandler: func(c *fiber.Ctx, e any) {
-			zapLogger.Error("panic recovered",
-				zap.Any("panic", e),
-				zap.String("path", c.Path()),
-				zap.String("method", c.Method()),
-				zap.String("ip", c.IP()),
-			)
-		},
+	app.Use(middleware.RecoveryWithConfig(middleware.RecoveryConfig{
+		Logger:  zapLogger,
+		Tracker: panicTracker,
+		Metrics: promMetrics,
 	}))
 
 	// Request ID middleware - for request tracing
@@ -290,13 +447,9 @@ func run() error {
 		},
 	}))
 
-	// Logger middleware - for request logging
-	if cfg.IsDevelopment() {
-		app.Use(fiberLogger.New(fiberLogger.Config{
-			Format:     "[${time}] ${status} - ${latency} ${method} ${path}\n",
-			TimeFormat: "15:04:05",
-			TimeZone:   "Local",
-		}))
+	// Tracing middleware - a span per request, continuing the caller's trace
+	if cfg.Tracing.Enabled {
+		app.Use(middleware.Tracing(middleware.DefaultTracingConfig()))
 	}
 
 	// Compression middleware - for response compression
@@ -304,6 +457,19 @@ func run() error {
 		Level: compress.LevelBestSpeed, // LevelBestSpeed for performance, LevelBestCompression for size
 	}))
 
+	// Access log middleware - a structured, redacted entry per request, after
+	// compression so bodies are captured as written
+	var accessLogger *middleware.AccessLogger
+	if cfg.AccessLog.Enabled {
+		accessLogConfig := middleware.DefaultAccessLogConfig(zapLogger)
+		accessLogConfig.SampleRate = cfg.AccessLog.SampleRate
+		accessLogConfig.RouteSampleRates = cfg.AccessLog.RouteSampleRates
+		accessLogConfig.CaptureBodies = cfg.AccessLog.CaptureBodies
+		accessLogConfig.MaxBodyBytes = cfg.AccessLog.MaxBodyBytes
+		accessLogger = middleware.NewAccessLogger(accessLogConfig)
+		app.Use(accessLogger.Handler())
+	}
+
 	// Profiling endpoints (development and staging only)
 	if !cfg.IsProduction() {
 		zapLogger.Info("enabling pprof profiling endpoints")
@@ -316,9 +482,20 @@ func run() error {
 
 	zapLogger.Info("setting up health check endpoints")
 
-	healthChecker := health.NewHealthChecker(
-		&health.DatabaseChecker{},
-	)
+	// Redis and Zitadel are shared by every instance, so their failures degrade the
+	// service rather than take instances out of rotation
+	if redisCache != nil {
+		healthChecker.Register(health.NonCritical(health.WithLatencyBudget(
+			&health.RedisChecker{Client: redisCache}, cfg.Health.LatencyBudget)))
+	}
+	if cfg.Zitadel.Domain != "" {
+		issuer := cfg.Zitadel.Domain
+		if !strings.HasPrefix(issuer, "http://") && !strings.HasPrefix(issuer, "https://") {
+			issuer = "https://" + issuer
+		}
+		healthChecker.Register(health.NonCritical(health.WithLatencyBudget(
+			&health.OIDCDiscoveryChecker{Issuer: issuer}, cfg.Health.LatencyBudget)))
+	}
 
 	// Liveness probe - simple check if server is running
 	app.Get("/health/live", health.LivenessHandler())
@@ -329,6 +506,39 @@ func run() error {
 	// Combined health check with detailed information
 	app.Get("/health", health.Handler(healthChecker))
 
+	// Metrics endpoint and per-route request metrics
+	if promMetrics != nil {
+		app.Get("/metrics", promMetrics.Handler())
+		app.Use(middleware.MetricsMiddlewareWithConfig(promMetrics, middleware.DefaultMetricsConfig()))
+	}
+
+	// Load shedding - caps the API requests served at once by route class, so bursts
+	// of analytics and exports can't slow down booking creation
+	if cfg.LoadShedding.Enabled {
+		app.Use("/api", middleware.NewLoadShedder(loadSheddingConfig(cfg, promMetrics, zapLogger)).Handler())
+	}
+
+	// Continuous profiling, and latency budgets capturing profiles of slow routes
+	profiler := newProfiler(cfg, objectStore, promMetrics, zapLogger)
+	if cfg.Profiling.DefaultLatencyBudget > 0 || len(cfg.Profiling.LatencyBudgets) > 0 {
+		app.Use("/api", middleware.LatencyBudget(middleware.LatencyBudgetConfig{
+			Budgets:  cfg.Profiling.LatencyBudgets,
+			Default:  cfg.Profiling.DefaultLatencyBudget,
+			Profiler: profiler,
+			Metrics:  promMetrics,
+			Logger:   zapLogger,
+		}))
+	}
+
+	// Fault injection - delays, fails or drops the dependencies of requests matching
+	// the rules platform super admins add, to exercise failure handling before release
+	var faultInjector *chaos.Injector
+	if cfg.Chaos.Enabled {
+		zapLogger.Warn("fault injection enabled")
+		faultInjector = chaos.NewInjector()
+		app.Use("/api", middleware.Chaos(middleware.DefaultChaosConfig(faultInjector, zapLogger)))
+	}
+
 	// Version endpoint
 	app.Get("/version", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -358,8 +568,12 @@ func run() error {
 		)
 
 		// Initialize Zitadel authentication
-		var err error
-		zitadelAuth, err = auth.NewZitadelAuth(cfg)
+		healthChecker.SetStarting("connecting to Zitadel")
+		err := startup.Connect(startupCtx, "zitadel", optionalBackoff, zapLogger, func(ctx context.Context) error {
+			var err error
+			zitadelAuth, err = auth.NewZitadelAuth(cfg)
+			return err
+		})
 		if err != nil {
 			if cfg.Environment == "development" {
 				zapLogger.Warn("failed to initialize Zitadel authentication in development mode, continuing without auth",
@@ -411,23 +625,127 @@ func run() error {
 	corsConfig := &middleware.CORSConfig{
 		AllowedOrigins:   cfg.App.CORSOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"},
+		AllowedHeaders:   []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "traceparent", "tracestate"},
 		AllowCredentials: true,
-		ExposedHeaders:   []string{"X-Request-ID"},
+		ExposedHeaders:   []string{"X-Request-ID", middleware.TraceIDHeader},
 		MaxAge:           3600,
 		Logger:           zapLogger,
 	}
 
+	paymentsConfig := payments.Config{
+		DefaultProvider:            cfg.Payments.DefaultProvider,
+		StripeSecretKey:            cfg.Payments.StripeSecretKey,
+		StripeWebhookSecret:        cfg.Payments.StripeWebhookSecret,
+		StripeBillingWebhookSecret: cfg.Payments.StripeBillingWebhookSecret,
+		PayPalClientID:             cfg.Payments.PayPalClientID,
+		PayPalClientSecret:         cfg.Payments.PayPalClientSecret,
+		PayPalWebhookID:            cfg.Payments.PayPalWebhookID,
+		PayPalSandbox:              cfg.Payments.PayPalSandbox,
+		PaystackSecretKey:          cfg.Payments.PaystackSecretKey,
+		MPesa: payments.MPesaConfig{
+			ConsumerKey:    cfg.Payments.MPesaConsumerKey,
+			ConsumerSecret: cfg.Payments.MPesaConsumerSecret,
+			ShortCode:      cfg.Payments.MPesaShortCode,
+			Passkey:        cfg.Payments.MPesaPasskey,
+			CallbackURL:    cfg.Payments.MPesaCallbackURL,
+			CallbackToken:  cfg.Payments.MPesaCallbackToken,
+			Sandbox:        cfg.Payments.MPesaSandbox,
+		},
+		CircuitBreaker: payments.BreakerConfig{
+			FailureThreshold: cfg.Payments.BreakerFailureThreshold,
+			OpenDuration:     cfg.Payments.BreakerOpenDuration,
+		},
+	}
+
+	paymentProviders := payments.NewRegistryFromConfig(paymentsConfig)
+	healthChecker.Register(health.NonCritical(&health.PaymentProvidersChecker{Registry: paymentProviders}))
+
+	// API rate limits: per IP address, per service account or machine client, and per
+	// tenant by subscription plan
+	var rateLimitConfig *middleware.TenantRateLimitConfig
+	if cfg.App.RateLimitEnabled {
+		rateLimitConfig = &middleware.TenantRateLimitConfig{
+			Logger:       zapLogger,
+			PlanLimits:   planRateLimits(cfg.App.RateLimitPlans),
+			Window:       time.Minute,
+			ClientLimit:  cfg.App.RateLimitRPS,
+			ClientWindow: time.Second,
+			KeyLimit:     cfg.App.RateLimitKeyRPM,
+			KeyWindow:    time.Minute,
+		}
+	}
+
+	// Responses of hot read routes are shared by every instance through Redis
+	var responseCache *httpcache.Cache
+	if cfg.ResponseCache.Enabled && redisCache != nil {
+		responseCache = httpcache.New(httpcache.Config{
+			Store:        redisCache,
+			TTL:          cfg.ResponseCache.TTL,
+			MaxAge:       cfg.ResponseCache.MaxAge,
+			MaxBodyBytes: cfg.ResponseCache.MaxBodyBytes,
+			Metrics:      promMetrics,
+			Logger:       zapLogger,
+		})
+	}
+
+	// The server and the background jobs and workers the router starts run until
+	// shutdown, which stops them together
+	subsystems := lifecycle.New(zapLogger)
+
+	// The log level, rate limits, access log sampling and feature toggles are reloaded
+	// from the reload file while the server runs
+	var settingsSource config.Source
+	if cfg.App.ReloadFile != "" {
+		settingsSource = config.FileSource(cfg.App.ReloadFile)
+	}
+	settings := config.NewWatcher(&loaded, settingsSource, cfg.App.ReloadInterval, zapLogger)
+
 	// Initialize router with all dependencies
+	healthChecker.SetStarting("setting up routes")
 	routerConfig := &router.Config{
-		DB:                db,
-		Logger:            fiberLogger,
-		ZitadelAuthZ:      nil, // Will be set below if zitadelAuth is not nil
-		ZitadelMiddleware: zitadelMiddleware,
-		Cache:             redisCache,
-		ZapLogger:         zapLogger,
-		CORSConfig:        corsConfig,
-		WebhookSecret:     "",
+		DB:                 db,
+		Lifecycle:          subsystems,
+		Panics:             panicTracker,
+		ResponseCache:      responseCache,
+		Chaos:              faultInjector,
+		Settings:           settings,
+		Logger:             fiberLogger,
+		ZitadelAuthZ:       nil, // Will be set below if zitadelAuth is not nil
+		ZitadelMiddleware:  zitadelMiddleware,
+		Cache:              redisCache,
+		ZapLogger:          zapLogger,
+		CORSConfig:         corsConfig,
+		WebhookSecret:      "",
+		BaseDomain:         cfg.App.BaseDomain,
+		CalendarFeedSecret: cfg.App.CalendarFeedSecret,
+		Storage:            objectStore,
+		DownloadURLSecret:  cfg.App.DownloadURLSecret,
+		Payments:           paymentProviders,
+		Billing:            payments.NewBillingFromConfig(paymentsConfig),
+		QueryTimeouts: repository.QueryTimeouts{
+			Default:            cfg.Database.QueryTimeout,
+			Operations:         map[string]time.Duration{repository.OperationAnalytics: cfg.Database.AnalyticsQueryTimeout},
+			SlowQueryThreshold: cfg.Database.SlowQueryThreshold,
+		},
+		TrashRetention: cfg.App.TrashRetention,
+		ArchiveAfterMonths: map[string]int{
+			"bookings": cfg.App.ArchiveBookingsAfterMonths,
+			"payments": cfg.App.ArchivePaymentsAfterMonths,
+		},
+		RateLimit:     rateLimitConfig,
+		Metrics:       promMetrics,
+		TokenLifetime: cfg.Zitadel.TokenLifetime,
+		Health:        healthChecker,
+		HealthBacklogs: map[string]health.BacklogThresholds{
+			router.OutboxQueue: {
+				Degraded:  int64(cfg.Health.OutboxBacklogDegraded),
+				Unhealthy: int64(cfg.Health.OutboxBacklogUnhealthy),
+			},
+			router.WebhooksQueue: {
+				Degraded:  int64(cfg.Health.WebhookBacklogDegraded),
+				Unhealthy: int64(cfg.Health.WebhookBacklogUnhealthy),
+			},
+		},
 	}
 
 	// Set ZitadelAuthZ only if zitadelAuth was successfully initialized
@@ -435,6 +753,15 @@ func run() error {
 		routerConfig.ZitadelAuthZ = zitadelAuth.AuthZ
 	}
 
+	// Self-serve onboarding invites tenant owners, and service accounts get their machine
+	// users, through Zitadel's user API
+	if users := auth.NewZitadelUsers(cfg); users != nil {
+		routerConfig.Identity = users
+		routerConfig.MachineIdentity = users
+	} else {
+		zapLogger.Warn("ZITADEL_SERVICE_TOKEN not set, self-serve onboarding and service accounts disabled")
+	}
+
 	apiRouter := router.New(app, routerConfig)
 	if err := apiRouter.Setup(); err != nil {
 		return fmt.Errorf("failed to setup routes: %w", err)
@@ -442,15 +769,32 @@ func run() error {
 
 	zapLogger.Info("API routes configured")
 
+	settings.OnChange(func(updated config.Runtime) {
+		if err := logger.SetLevel(updated.LogLevel); err != nil {
+			zapLogger.Error("failed to change log level", zap.Error(err))
+		}
+		if limiter := apiRouter.RateLimiter(); limiter != nil {
+			limiter.SetLimits(updated.RateLimitRPS, planRateLimits(updated.RateLimitPlans))
+		}
+		if accessLogger != nil {
+			accessLogger.SetSampling(updated.AccessLogSampleRate, updated.AccessLogRouteSampleRates)
+		}
+	})
+	if profiler != nil && cfg.Profiling.Interval > 0 {
+		subsystems.Go("profiler", profiler.Run)
+	}
+	if settingsSource != nil {
+		subsystems.Go("config_watcher", settings.Run)
+		zapLogger.Info("configuration reloading enabled",
+			zap.String("file", cfg.App.ReloadFile),
+			zap.Duration("interval", cfg.App.ReloadInterval),
+		)
+	}
+
 	// 404 handler
 	app.Use(func(c *fiber.Ctx) error {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":      "route not found",
-			"code":       "NOT_FOUND",
-			"path":       c.Path(),
-			"method":     c.Method(),
-			"request_id": c.Locals("request_id"),
-		})
+		return middleware.SendError(c, fiber.StatusNotFound, "ROUTE_NOT_FOUND",
+			fmt.Sprintf("No route matches %s %s", c.Method(), c.Path()))
 	})
 
 	// ============================================================================
@@ -468,13 +812,32 @@ func run() error {
 		printBanner(cfg, serverAddr)
 	}
 
-	// Start server in goroutine
-	serverErr := make(chan error, 1)
-	go func() {
+	// Dependencies are connected; the API takes over the probes' address
+	stopStartup()
+	if err := startupProbes.Close(context.Background()); err != nil {
+		zapLogger.Warn("failed to stop startup probes", zap.Error(err))
+	}
+	healthChecker.Started()
+
+	subsystems.Go("http_server", func(ctx context.Context) error {
 		if err := app.Listen(serverAddr); err != nil {
-			serverErr <- fmt.Errorf("server failed to start: %w", err)
+			return fmt.Errorf("server failed to start: %w", err)
 		}
-	}()
+		return nil
+	})
+
+	// Stop hooks run last registered first: readiness fails for the drain delay so load
+	// balancers stop routing here, then the server finishes the requests in flight, and
+	// only then are background jobs cancelled and awaited
+	subsystems.OnStop("http_server", app.ShutdownWithContext)
+	subsystems.OnStop("readiness", func(ctx context.Context) error {
+		healthChecker.Drain()
+		select {
+		case <-time.After(cfg.Server.DrainDelay):
+		case <-ctx.Done():
+		}
+		return nil
+	})
 
 	zapLogger.Info("server started successfully")
 
@@ -482,14 +845,13 @@ func run() error {
 	// Graceful Shutdown
 	// ============================================================================
 
-	// Wait for interrupt signal or server error
+	// Wait for interrupt signal or a failed subsystem
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 
 	select {
-	case err := <-serverErr:
-		zapLogger.Error("server error occurred", zap.Error(err))
-		return err
+	case <-subsystems.Done():
+		zapLogger.Error("subsystem failed, shutting down")
 	case sig := <-quit:
 		zapLogger.Info("shutdown signal received",
 			zap.String("signal", sig.String()),
@@ -499,15 +861,16 @@ func run() error {
 	// Graceful shutdown with timeout
 	zapLogger.Info("initiating graceful shutdown",
 		zap.Duration("timeout", cfg.Server.ShutdownTimeout),
+		zap.Duration("drain_delay", cfg.Server.DrainDelay),
 	)
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
-	// Shutdown server
-	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
-		zapLogger.Error("server forced to shutdown", zap.Error(err))
-		return fmt.Errorf("server shutdown failed: %w", err)
+	// Drain the server, then stop background jobs and workers
+	if err := subsystems.Shutdown(shutdownCtx); err != nil {
+		zapLogger.Error("graceful shutdown incomplete", zap.Error(err))
+		return fmt.Errorf("shutdown failed: %w", err)
 	}
 
 	zapLogger.Info("server gracefully stopped")
@@ -516,18 +879,106 @@ func run() error {
 	return nil
 }
 
+// planRateLimits returns the requests per minute each subscription plan allows, the
+// configured limits overriding the defaults
+func planRateLimits(configured map[string]int) map[models.SubscriptionPlan]int {
+	limits := middleware.DefaultPlanRateLimits()
+	for plan, limit := range configured {
+		limits[models.SubscriptionPlan(plan)] = limit
+	}
+	return limits
+}
+
+// loadSheddingConfig returns the load shedder's configuration, the configured limits
+// and routes merged over the defaults
+func loadSheddingConfig(cfg *config.Config, m *metrics.PrometheusMetrics, logger *zap.Logger) middleware.LoadSheddingConfig {
+	shedding := middleware.DefaultLoadSheddingConfig(logger)
+	for class, limit := range cfg.LoadShedding.Limits {
+		shedding.Classes[class] = middleware.RouteClassLimit{Limit: limit}
+	}
+	for class, limit := range shedding.Classes {
+		limit.Adaptive = slices.Contains(cfg.LoadShedding.AdaptiveClasses, class)
+		shedding.Classes[class] = limit
+	}
+	maps.Copy(shedding.Routes, cfg.LoadShedding.Routes)
+	shedding.QueueTimeout = cfg.LoadShedding.QueueTimeout
+	shedding.LatencyTarget = cfg.LoadShedding.LatencyTarget
+	shedding.RetryAfter = cfg.LoadShedding.RetryAfter
+	shedding.Metrics = m
+	return shedding
+}
+
+// newProfiler returns the profiler capturing profiles for Pyroscope, or file storage
+// when Pyroscope is not configured, or nil when profiling is disabled or has nowhere
+// to send profiles
+func newProfiler(cfg *config.Config, store storage.ObjectStore, m *metrics.PrometheusMetrics, logger *zap.Logger) *profiling.Profiler {
+	if !cfg.Profiling.Enabled {
+		return nil
+	}
+
+	var uploader profiling.Uploader
+	switch {
+	case cfg.Profiling.PyroscopeURL != "":
+		uploader = &profiling.PyroscopeUploader{
+			ServerURL: cfg.Profiling.PyroscopeURL,
+			AppName:   cfg.Profiling.PyroscopeApp,
+			AuthToken: cfg.Profiling.PyroscopeToken,
+		}
+	case store != nil:
+		uploader = &profiling.StoreUploader{Store: store}
+	default:
+		logger.Warn("profiling enabled without Pyroscope or file storage; profiles are not captured")
+		return nil
+	}
+
+	logger.Info("profiling enabled",
+		zap.Duration("interval", cfg.Profiling.Interval),
+		zap.Bool("pyroscope", cfg.Profiling.PyroscopeURL != ""),
+	)
+	return profiling.New(profiling.Config{
+		Uploader:    uploader,
+		Interval:    cfg.Profiling.Interval,
+		CPUDuration: cfg.Profiling.CPUDuration,
+		Cooldown:    cfg.Profiling.Cooldown,
+		Labels: map[string]string{
+			"env":     cfg.Environment,
+			"version": Version,
+		},
+		Metrics: m,
+		Logger:  logger,
+	})
+}
+
+// newPanicReporter returns the error tracker recovered panics are reported to, Sentry
+// or Rollbar, or nil when neither is configured
+func newPanicReporter(cfg *config.Config) (panics.Reporter, error) {
+	switch {
+	case cfg.Panics.SentryDSN != "":
+		reporter, err := panics.NewSentryReporter(cfg.Panics.SentryDSN, cfg.Panics.SentryEnvironment, Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure panic reporting: %w", err)
+		}
+		return reporter, nil
+	case cfg.Panics.RollbarAccessToken != "":
+		return &panics.RollbarReporter{
+			AccessToken: cfg.Panics.RollbarAccessToken,
+			Environment: cfg.Environment,
+		}, nil
+	}
+	return nil, nil
+}
+
 // runMigrations runs database migrations
 func runMigrations(db *gorm.DB, logger *zap.Logger, cfg *config.Config) error {
 	logger.Info("checking database migrations")
 
 	// Configure migration settings based on environment
 	migrationConfig := database.MigrationConfig{
-		AutoMigrate:    true,
-		SeedData:       cfg.IsDevelopment(), // Only seed in development
-		Force:          false,
-		DryRun:         false,
-		Logger:         logger,
-		SkipExtensions: false,
+		ApplyMigrations: true,
+		SeedData:        cfg.IsDevelopment(), // Only seed in development
+		Force:           false,
+		DryRun:          false,
+		Logger:          logger,
 	}
 
 	// Run migrations
@@ -535,18 +986,6 @@ func runMigrations(db *gorm.DB, logger *zap.Logger, cfg *config.Config) error {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
-	// Log migration status
-	migrations, err := database.GetMigrationStatus(db)
-	if err != nil {
-		logger.Warn("failed to get migration status", zap.Error(err))
-	} else if len(migrations) > 0 {
-		logger.Info("migration status",
-			zap.Int("total_migrations", len(migrations)),
-			zap.String("last_migration", migrations[0].Version),
-			zap.Time("last_applied", migrations[0].AppliedAt),
-		)
-	}
-
 	logger.Info("database migrations completed")
 	return nil
 }
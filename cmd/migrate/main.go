@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"Krafti_Vibe/internal/config"
 	"Krafti_Vibe/internal/infrastructure/database"
@@ -16,14 +19,33 @@ import (
 func main() {
 	// Define command-line flags
 	var (
-		action         = flag.String("action", "up", "Migration action: up, down, status, seed")
-		dryRun         = flag.Bool("dry-run", false, "Perform a dry run without applying changes")
-		force          = flag.Bool("force", false, "Force migration even if risky")
-		skipSeed       = flag.Bool("skip-seed", false, "Skip data seeding")
-		skipExtensions = flag.Bool("skip-extensions", false, "Skip PostgreSQL extension creation")
+		action   = flag.String("action", "up", "Migration action: up, down, status, baseline, create, seed, encrypt")
+		dryRun   = flag.Bool("dry-run", false, "Perform a dry run without applying changes")
+		force    = flag.Bool("force", false, "Run even if applied migrations no longer match their files")
+		skipSeed = flag.Bool("skip-seed", false, "Skip data seeding")
+		steps    = flag.Int("steps", 1, "Number of migrations to reverse with down")
+		name     = flag.String("name", "", "Name of the migration to create, e.g. add_booking_notes")
+		dir      = flag.String("dir", "internal/infrastructure/database/migrations", "Migrations directory used by create")
+		profile  = flag.String("profile", "default", "Seed profile: default, demo, e2e, load-test")
+		tenants  = flag.Int("tenants", 0, "Number of businesses the seed profile generates, 0 for the profile's own")
 	)
 	flag.Parse()
 
+	// Creating migration files needs neither configuration nor a database
+	if *action == "create" {
+		if err := createMigration(*dir, *name); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create migration: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	seedProfile, err := database.ParseSeedProfile(*profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -45,6 +67,15 @@ func main() {
 		zap.Bool("force", *force),
 	)
 
+	// Settings may reference a secret manager rather than hold credentials
+	if err := cfg.ResolveSecrets(context.Background(), cfg.Secrets.Resolver()); err != nil {
+		zapLogger.Fatal("failed to resolve secrets", zap.Error(err))
+	}
+
+	if err := database.ConfigureFieldEncryption(cfg, zapLogger); err != nil {
+		zapLogger.Fatal("failed to configure field encryption", zap.Error(err))
+	}
+
 	// Initialize database
 	if err := database.Initialize(cfg, zapLogger); err != nil {
 		zapLogger.Fatal("failed to initialize database", zap.Error(err))
@@ -52,83 +83,103 @@ func main() {
 	defer database.Close()
 
 	db := database.DB()
+	migrationConfig := database.MigrationConfig{
+		ApplyMigrations: true,
+		SeedData:        !*skipSeed && cfg.IsDevelopment(),
+		SeedProfile:     seedProfile,
+		SeedTenants:     *tenants,
+		Force:           *force,
+		DryRun:          *dryRun,
+		Logger:          zapLogger,
+	}
 
 	// Execute migration action
 	switch *action {
 	case "up":
-		if err := migrateUp(db, zapLogger, cfg, *dryRun, *force, *skipSeed, *skipExtensions); err != nil {
+		if err := migrateUp(db, zapLogger, migrationConfig); err != nil {
 			zapLogger.Fatal("migration up failed", zap.Error(err))
 		}
 
 	case "down":
-		if err := migrateDown(db, zapLogger); err != nil {
+		if err := migrateDown(db, zapLogger, migrationConfig, *steps); err != nil {
 			zapLogger.Fatal("migration down failed", zap.Error(err))
 		}
 
 	case "status":
-		if err := migrationStatus(db, zapLogger); err != nil {
+		if err := migrationStatus(db, zapLogger, migrationConfig); err != nil {
 			zapLogger.Fatal("failed to get migration status", zap.Error(err))
 		}
 
+	case "baseline":
+		if err := migrateBaseline(db, zapLogger, migrationConfig); err != nil {
+			zapLogger.Fatal("baseline failed", zap.Error(err))
+		}
+
 	case "seed":
-		if err := seedData(db, zapLogger); err != nil {
+		if err := seedData(db, zapLogger, migrationConfig); err != nil {
 			zapLogger.Fatal("data seeding failed", zap.Error(err))
 		}
 
+	case "encrypt":
+		if err := encryptFields(db, zapLogger, *dryRun); err != nil {
+			zapLogger.Fatal("field encryption failed", zap.Error(err))
+		}
+
 	default:
 		zapLogger.Fatal("unknown action",
 			zap.String("action", *action),
-			zap.String("valid_actions", "up, down, status, seed"),
+			zap.String("valid_actions", "up, down, status, baseline, create, seed, encrypt"),
 		)
 	}
 
 	zapLogger.Info("migration tool completed successfully")
 }
 
-// migrateUp runs database migrations
-func migrateUp(db *gorm.DB, logger *zap.Logger, cfg *config.Config, dryRun, force, skipSeed, skipExtensions bool) error {
+// migrateUp applies pending migrations
+func migrateUp(db *gorm.DB, logger *zap.Logger, migrationConfig database.MigrationConfig) error {
 	logger.Info("running migrations up")
 
-	migrationConfig := database.MigrationConfig{
-		AutoMigrate:    true,
-		SeedData:       !skipSeed && cfg.IsDevelopment(),
-		Force:          force,
-		DryRun:         dryRun,
-		Logger:         logger,
-		SkipExtensions: skipExtensions,
+	if migrationConfig.DryRun {
+		migrator, err := database.NewMigrator(db, migrationConfig)
+		if err != nil {
+			return err
+		}
+		plan, err := migrator.PlanUp(context.Background())
+		if err != nil {
+			return fmt.Errorf("migration dry-run failed: %w", err)
+		}
+		printPlan(plan)
+		return nil
 	}
 
 	if err := database.RunMigrations(db, migrationConfig); err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
-	// Show migration status
-	migrations, err := database.GetMigrationStatus(db)
+	return migrationStatus(db, logger, migrationConfig)
+}
+
+// migrateDown reverses the last applied migrations by running their down SQL
+func migrateDown(db *gorm.DB, logger *zap.Logger, migrationConfig database.MigrationConfig, steps int) error {
+	logger.Warn("rolling back migrations", zap.Int("steps", steps))
+
+	migrator, err := database.NewMigrator(db, migrationConfig)
 	if err != nil {
-		logger.Warn("failed to get migration status", zap.Error(err))
-	} else {
-		logger.Info("migration completed",
-			zap.Int("total_migrations", len(migrations)),
-		)
-		if len(migrations) > 0 {
-			logger.Info("last migration",
-				zap.String("version", migrations[0].Version),
-				zap.Time("applied_at", migrations[0].AppliedAt),
-			)
-		}
+		return err
 	}
 
-	return nil
-}
-
-// migrateDown rolls back the last migration
-func migrateDown(db *gorm.DB, logger *zap.Logger) error {
-	logger.Warn("rolling back last migration")
+	if migrationConfig.DryRun {
+		plan, err := migrator.PlanDown(context.Background(), steps)
+		if err != nil {
+			return fmt.Errorf("rollback dry-run failed: %w", err)
+		}
+		printPlan(plan)
+		return nil
+	}
 
 	// Show confirmation warning
-	fmt.Println("\n⚠️  WARNING: You are about to rollback the last migration!")
-	fmt.Println("This will delete the migration record but NOT automatically reverse schema changes.")
-	fmt.Println("Manual schema rollback may be required.")
+	fmt.Printf("\n⚠️  WARNING: You are about to reverse the last %d migration(s)!\n", steps)
+	fmt.Println("Their down SQL will run and may drop tables, columns and the data in them.")
 	fmt.Print("\nType 'yes' to confirm: ")
 
 	var confirmation string
@@ -139,43 +190,76 @@ func migrateDown(db *gorm.DB, logger *zap.Logger) error {
 		return nil
 	}
 
-	if err := database.RollbackLastMigration(db, logger); err != nil {
+	reverted, err := migrator.Down(context.Background(), steps)
+	if err != nil {
 		return fmt.Errorf("rollback failed: %w", err)
 	}
 
-	logger.Info("rollback completed")
+	for _, migration := range reverted {
+		fmt.Printf("↩️  %04d %s\n", migration.Version, migration.Name)
+	}
+	logger.Info("rollback completed", zap.Int("reverted", len(reverted)))
+	return nil
+}
+
+// migrateBaseline marks an AutoMigrate-created database as being on the baseline
+// migration
+func migrateBaseline(db *gorm.DB, logger *zap.Logger, migrationConfig database.MigrationConfig) error {
+	migrator, err := database.NewMigrator(db, migrationConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := migrator.Baseline(context.Background()); err != nil {
+		return err
+	}
+
+	logger.Info("baseline recorded")
 	return nil
 }
 
 // migrationStatus shows the current migration status
-func migrationStatus(db *gorm.DB, logger *zap.Logger) error {
+func migrationStatus(db *gorm.DB, logger *zap.Logger, migrationConfig database.MigrationConfig) error {
 	logger.Info("fetching migration status")
 
-	migrations, err := database.GetMigrationStatus(db)
+	migrator, err := database.NewMigrator(db, migrationConfig)
 	if err != nil {
-		return fmt.Errorf("failed to get migration status: %w", err)
+		return err
 	}
 
-	if len(migrations) == 0 {
-		fmt.Println("\n📊 Migration Status:")
-		fmt.Println("No migrations have been applied yet.")
-		return nil
+	statuses, err := migrator.Status(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
 	}
 
 	fmt.Println("\n📊 Migration Status:")
-	fmt.Printf("Total migrations applied: %d\n\n", len(migrations))
+	if len(statuses) == 0 {
+		fmt.Println("No migrations found.")
+		return nil
+	}
 
 	// Print table header
-	fmt.Printf("%-5s | %-35s | %-50s | %s\n", "ID", "Version", "Description", "Applied At")
-	fmt.Println("------|-------------------------------------|-----------------------------------------------------|-------------------------")
+	fmt.Printf("%-7s | %-45s | %-10s | %s\n", "Version", "Name", "State", "Applied At")
+	fmt.Println("--------|-----------------------------------------------|------------|-------------------------")
 
 	// Print migrations
-	for _, migration := range migrations {
-		fmt.Printf("%-5d | %-35s | %-50s | %s\n",
-			migration.ID,
-			migration.Version,
-			truncate(migration.Description, 50),
-			migration.AppliedAt.Format("2006-01-02 15:04:05"),
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if status.Modified {
+			state = "modified"
+		}
+		if status.Missing {
+			state = "missing"
+		}
+		fmt.Printf("%-7d | %-45s | %-10s | %s\n",
+			status.Version,
+			truncate(status.Name, 45),
+			state,
+			appliedAt,
 		)
 	}
 
@@ -183,17 +267,79 @@ func migrationStatus(db *gorm.DB, logger *zap.Logger) error {
 	return nil
 }
 
-// seedData seeds initial data
-func seedData(db *gorm.DB, logger *zap.Logger) error {
-	logger.Info("seeding initial data")
+// printPlan prints the statements a migration run would execute and the schema
+// changes they made when rehearsed
+func printPlan(plan *database.MigrationPlan) {
+	fmt.Printf("\n📋 Migration plan (%s):\n", plan.Direction)
+	if len(plan.Steps) == 0 {
+		fmt.Println("Nothing to do, the database is up to date.")
+		return
+	}
+
+	for _, step := range plan.Steps {
+		fmt.Printf("\n-- %04d %s (%d statements)\n", step.Version, step.Name, len(step.Statements))
+		if !step.Transactional {
+			fmt.Println("-- runs outside a transaction and could not be rehearsed")
+		}
+		for _, statement := range step.Statements {
+			fmt.Printf("%s;\n", statement)
+		}
+	}
+
+	fmt.Println("\n🔎 Schema changes:")
+	if len(plan.Changes) == 0 {
+		fmt.Println("None.")
+	}
+	for _, change := range plan.Changes {
+		fmt.Println("  " + change.String())
+	}
+	if !plan.Simulated {
+		fmt.Println("⚠️  The rehearsal stopped at a migration that cannot run in a transaction; later changes are not shown.")
+	}
+	fmt.Println("\nDry run: nothing was applied.")
+}
+
+// createMigration writes empty up and down files for the next migration version
+func createMigration(dir, name string) error {
+	if name == "" {
+		return fmt.Errorf("a migration name is required")
+	}
+
+	migrations, err := database.LoadMigrations(os.DirFS(dir), ".")
+	if err != nil {
+		return err
+	}
+	var version int64 = 1
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", version, name, direction))
+		content := fmt.Sprintf("-- %s: %s\n", strings.ToUpper(direction), name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return err
+		}
+		fmt.Println("📝 Created", path)
+	}
+
+	// Make sure the name is one LoadMigrations accepts
+	_, err = database.LoadMigrations(os.DirFS(dir), ".")
+	return err
+}
+
+// seedData seeds the system settings and the chosen profile's data
+func seedData(db *gorm.DB, logger *zap.Logger, config database.MigrationConfig) error {
+	logger.Info("seeding initial data", zap.String("profile", string(config.SeedProfile)))
 
 	migrationConfig := database.MigrationConfig{
-		AutoMigrate:    false,
-		SeedData:       true,
-		Force:          false,
-		DryRun:         false,
-		Logger:         logger,
-		SkipExtensions: true,
+		ApplyMigrations: false,
+		SeedData:        true,
+		SeedProfile:     config.SeedProfile,
+		SeedTenants:     config.SeedTenants,
+		Force:           false,
+		DryRun:          false,
+		Logger:          logger,
 	}
 
 	if err := database.RunMigrations(db, migrationConfig); err != nil {
@@ -204,6 +350,23 @@ func seedData(db *gorm.DB, logger *zap.Logger) error {
 	return nil
 }
 
+// encryptFields encrypts sensitive payment fields written before field encryption was
+// enabled, and re-encrypts fields under rotated keys with the current key
+func encryptFields(db *gorm.DB, logger *zap.Logger, dryRun bool) error {
+	logger.Info("encrypting payment fields", zap.Bool("dry_run", dryRun))
+
+	count, err := database.EncryptPaymentFields(db, logger, dryRun)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🔐 Payments encrypted: %d\n", count)
+	if dryRun {
+		fmt.Println("Dry run: no rows were changed.")
+	}
+	return nil
+}
+
 // truncate truncates a string to the specified length
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
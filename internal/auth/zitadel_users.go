@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/config"
+)
+
+// ErrUserExists is returned when Zitadel already has a user with the email
+var ErrUserExists = errors.New("zitadel user already exists")
+
+// maxResponseBytes bounds Zitadel responses read into memory
+const maxResponseBytes = 1 << 20
+
+// ZitadelUsers manages users through Zitadel's user API, acting as a service user
+// authenticated with a personal access token
+type ZitadelUsers struct {
+	baseURL string
+	token   string
+	appName string
+	client  *http.Client
+}
+
+// NewZitadelUsers creates a client of Zitadel's user API; it is nil when no service
+// user token is configured
+func NewZitadelUsers(cfg *config.Config) *ZitadelUsers {
+	if cfg.Zitadel.Domain == "" || cfg.Zitadel.ServiceToken == "" {
+		return nil
+	}
+
+	baseURL := strings.TrimSuffix(cfg.Zitadel.Domain, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "https://" + baseURL
+	}
+	return &ZitadelUsers{
+		baseURL: baseURL,
+		token:   cfg.Zitadel.ServiceToken,
+		appName: cfg.App.Name,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// CreateUser adds a human user without a password and returns its Zitadel ID. The
+// user sets a password by accepting the invitation SendInvite sends.
+func (z *ZitadelUsers) CreateUser(ctx context.Context, email, firstName, lastName string) (string, error) {
+	body := map[string]any{
+		"username": email,
+		"profile": map[string]any{
+			"givenName":  firstName,
+			"familyName": lastName,
+		},
+		"email": map[string]any{
+			"email": email,
+		},
+	}
+
+	var out struct {
+		UserID string `json:"userId"`
+	}
+	if err := z.do(ctx, http.MethodPost, "/v2/users/human", body, &out); err != nil {
+		return "", fmt.Errorf("create zitadel user: %w", err)
+	}
+	return out.UserID, nil
+}
+
+// SendInvite emails the user a code to accept the invitation with, by setting a
+// password or passkey
+func (z *ZitadelUsers) SendInvite(ctx context.Context, userID string) error {
+	body := map[string]any{
+		"sendCode": map[string]any{
+			"applicationName": z.appName,
+		},
+	}
+	if err := z.do(ctx, http.MethodPost, "/v2/users/"+url.PathEscape(userID)+"/invite_code", body, nil); err != nil {
+		return fmt.Errorf("send zitadel invite: %w", err)
+	}
+	return nil
+}
+
+// DeleteUser removes a user, such as one created for an onboarding that failed or the
+// machine user of a revoked service account
+func (z *ZitadelUsers) DeleteUser(ctx context.Context, userID string) error {
+	if err := z.do(ctx, http.MethodDelete, "/v2/users/"+url.PathEscape(userID), nil, nil); err != nil {
+		return fmt.Errorf("delete zitadel user: %w", err)
+	}
+	return nil
+}
+
+// CreateMachineUser adds a machine user, which signs in with client credentials rather
+// than interactively, and returns its Zitadel ID
+func (z *ZitadelUsers) CreateMachineUser(ctx context.Context, username, name, description string) (string, error) {
+	body := map[string]any{
+		"userName":        username,
+		"name":            name,
+		"description":     description,
+		"accessTokenType": "ACCESS_TOKEN_TYPE_BEARER",
+	}
+
+	var out struct {
+		UserID string `json:"userId"`
+	}
+	if err := z.do(ctx, http.MethodPost, "/management/v1/users/machine", body, &out); err != nil {
+		return "", fmt.Errorf("create zitadel machine user: %w", err)
+	}
+	return out.UserID, nil
+}
+
+// GenerateClientSecret gives a machine user a new client secret, replacing any it had,
+// and returns its client ID along with the secret. The secret can't be retrieved again.
+func (z *ZitadelUsers) GenerateClientSecret(ctx context.Context, userID string) (string, string, error) {
+	var out struct {
+		ClientID     string `json:"clientId"`
+		ClientSecret string `json:"clientSecret"`
+	}
+	if err := z.do(ctx, http.MethodPut, "/management/v1/users/"+url.PathEscape(userID)+"/secret", map[string]any{}, &out); err != nil {
+		return "", "", fmt.Errorf("generate zitadel client secret: %w", err)
+	}
+	return out.ClientID, out.ClientSecret, nil
+}
+
+// do sends a request to the user API and decodes a JSON response into out
+func (z *ZitadelUsers) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, z.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+z.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := z.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return ErrUserExists
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &apiErr)
+		return fmt.Errorf("zitadel returned %d: %s", resp.StatusCode, apiErr.Message)
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
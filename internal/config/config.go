@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -27,6 +28,36 @@ type Config struct {
 	// Application settings
 	App AppConfig
 
+	// Payment provider credentials
+	Payments PaymentsConfig
+
+	// OpenTelemetry tracing
+	Tracing TracingConfig
+
+	// Request logging
+	AccessLog AccessLogConfig
+
+	// Readiness checks
+	Health HealthConfig
+
+	// Secret managers settings may reference
+	Secrets SecretsConfig
+
+	// Panics recovered while serving requests
+	Panics PanicsConfig
+
+	// Concurrency limits by route class
+	LoadShedding LoadSheddingConfig
+
+	// Caching of hot read responses
+	ResponseCache ResponseCacheConfig
+
+	// Continuous profiling and route latency budgets
+	Profiling ProfilingConfig
+
+	// Fault injection outside production
+	Chaos ChaosConfig
+
 	// Environment
 	Environment string
 }
@@ -48,7 +79,13 @@ type ServerConfig struct {
 	ReadTimeout     time.Duration
 	WriteTimeout    time.Duration
 	IdleTimeout     time.Duration
-	ShutdownTimeout time.Duration
+	ShutdownTimeout time.Duration // How long in-flight requests and background jobs have to finish
+	DrainDelay      time.Duration // How long readiness fails before the server stops accepting requests
+
+	// Dependencies unavailable at boot are retried with exponential backoff
+	StartupAttempts   int
+	StartupBackoff    time.Duration // Delay before the second attempt, doubling after each
+	StartupMaxBackoff time.Duration
 }
 
 // DatabaseConfig holds database connection configuration
@@ -64,6 +101,17 @@ type DatabaseConfig struct {
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
+
+	// Pool sizing and monitoring
+	MaxOpenConnsLimit     int           // Ceiling the pool grows to while saturated; at or below MaxOpenConns the pool is fixed
+	PoolMonitorInterval   time.Duration // How often pool stats are sampled
+	PoolSaturationPercent int           // Share of the pool in use at which it counts as saturated
+
+	// Query time limits
+	QueryTimeout          time.Duration // Budget of a repository operation
+	AnalyticsQueryTimeout time.Duration // Budget of reporting and statistics queries
+	SlowQueryThreshold    time.Duration // Statements slower than this are logged
+	StatementTimeout      time.Duration // Server-side limit on any single statement
 }
 
 // RedisConfig holds Redis connection configuration
@@ -86,6 +134,14 @@ type RedisConfig struct {
 type ZitadelConfig struct {
 	Domain  string
 	KeyPath string
+
+	// Longest lifetime of the access tokens Zitadel issues, for which revocations of
+	// the tokens of users and tenants are kept
+	TokenLifetime time.Duration
+
+	// Personal access token of a service user allowed to manage users, with which
+	// owners of tenants onboarding themselves are invited; empty disables onboarding
+	ServiceToken string
 }
 
 // AppConfig holds application-specific configuration
@@ -95,9 +151,194 @@ type AppConfig struct {
 	LogLevel       string
 	CORSOrigins    []string
 	EnableMetrics  bool
-	EnableTracing  bool
 	RateLimitRPS   int
 	RequestTimeout time.Duration
+
+	// API rate limiting, counted in Redis sliding windows. Each IP address may make
+	// RateLimitRPS requests per second, each service account or machine client
+	// RateLimitKeyRPM requests per minute, and each tenant the requests per minute of
+	// its subscription plan in RateLimitPlans, such as "free=600"; plans not listed
+	// keep their default.
+	RateLimitEnabled bool
+	RateLimitKeyRPM  int
+	RateLimitPlans   map[string]int
+
+	// Domain tenants are served under by subdomain, such as acme.kraftivibe.com.
+	// Requests to other hosts are served for the tenant with that verified custom
+	// domain, if any.
+	BaseDomain string
+
+	// Secret used to sign calendar subscription feed URLs
+	CalendarFeedSecret string
+
+	// Directory where generated files such as booking exports are stored
+	StorageDir string
+
+	// Secret used to sign file download URLs
+	DownloadURLSecret string
+
+	// How long deleted bookings, services and projects stay restorable before they
+	// are purged
+	TrashRetention time.Duration
+
+	// Whole months of bookings and payments kept besides the current one before older
+	// months are archived to file storage; zero keeps every month
+	ArchiveBookingsAfterMonths int
+	ArchivePaymentsAfterMonths int
+
+	// Base64 32-byte AES keys encrypting sensitive columns such as provider payment
+	// IDs. Previous keys only decrypt, so keys can be rotated; typically injected from
+	// the secret manager or KMS.
+	FieldEncryptionKey          string
+	FieldEncryptionPreviousKeys []string
+
+	// Feature toggles by name, such as "new_checkout=true", which can be switched
+	// while the application runs
+	Features map[string]bool
+
+	// Dotenv file whose settings override the environment and are reloaded while the
+	// application runs, such as a mounted config map; empty disables reloading. Only
+	// the log level, rate limits, access log sampling and feature toggles take effect
+	// without a restart.
+	ReloadFile     string
+	ReloadInterval time.Duration
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration. Spans of requests, queries,
+// Redis commands and outgoing webhooks are exported to an OTLP collector over HTTP.
+type TracingConfig struct {
+	Enabled     bool
+	Endpoint    string            // Collector URL, such as http://localhost:4318
+	Headers     map[string]string // Sent with every export, such as an API key
+	ServiceName string
+	SampleRatio float64 // Share of traces started here that are kept; traces started upstream follow the caller's decision
+}
+
+// AccessLogConfig holds request logging configuration. Failed requests are always
+// logged; successful ones are sampled.
+type AccessLogConfig struct {
+	Enabled          bool
+	SampleRate       float64            // Share of successful requests logged
+	RouteSampleRates map[string]float64 // Sample rates of routes under a path prefix, overriding SampleRate
+	CaptureBodies    bool               // Log JSON request and response bodies, redacted
+	MaxBodyBytes     int                // Bodies larger than this are logged by size only
+}
+
+// HealthConfig holds readiness check configuration. Backlog thresholds of zero are
+// disabled.
+type HealthConfig struct {
+	CheckTimeout            time.Duration // How long each check may take
+	LatencyBudget           time.Duration // Redis and Zitadel answering slower than this degrade the service
+	OutboxBacklogDegraded   int           // Pending domain events that degrade the service
+	OutboxBacklogUnhealthy  int           // Pending domain events that take the instance out of rotation
+	WebhookBacklogDegraded  int           // Undelivered webhooks that degrade the service
+	WebhookBacklogUnhealthy int           // Undelivered webhooks that take the instance out of rotation
+}
+
+// SecretsConfig holds the secret managers other settings may reference instead of
+// holding credentials, as vault:<path>#<key> or awssm:<secret id>#<key>
+type SecretsConfig struct {
+	VaultAddr      string
+	VaultToken     string
+	VaultNamespace string // Optional: Vault Enterprise namespace
+
+	AWSRegion          string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string // Optional: set with temporary credentials
+
+	// How long a fetched secret is used before it is fetched again, which bounds how
+	// long new database and Redis connections use a rotated password
+	CacheTTL time.Duration
+}
+
+// PanicsConfig holds the error budget of panics recovered while serving requests, and
+// the error tracker the first of each kind and those exhausting a route's budget are
+// reported to
+type PanicsConfig struct {
+	Budget       int           // Panics a route may have within the window before it is reported as exhausted
+	BudgetWindow time.Duration // Window the budget and repeated panics are counted over
+
+	SentryDSN          string // Optional: report to Sentry
+	SentryEnvironment  string
+	RollbarAccessToken string // Optional: report to Rollbar
+}
+
+// LoadSheddingConfig holds the concurrency limits of route classes. Limits and routes
+// are merged over the defaults, in which POST /api/v1/bookings is critical and
+// reports, exports, analytics and statistics are expensive.
+type LoadSheddingConfig struct {
+	Enabled         bool
+	Limits          map[string]int    // Requests served at once, by class
+	AdaptiveClasses []string          // Classes shrunk while critical requests are slower than LatencyTarget
+	Routes          map[string]string // Classes by route: a path prefix, optionally after a method, or a path segment
+	QueueTimeout    time.Duration     // How long requests over their class's limit wait before they are shed
+	LatencyTarget   time.Duration     // Zero disables adaptation
+	RetryAfter      time.Duration     // When shed requests are told to retry
+}
+
+// ResponseCacheConfig holds settings for caching the responses of hot read routes, such
+// as the service catalog and availability, in Redis. Writes through the API drop the
+// responses they make stale; TTL bounds how long others go unnoticed.
+type ResponseCacheConfig struct {
+	Enabled      bool
+	TTL          time.Duration
+	MaxAge       time.Duration // How long clients may reuse a response without revalidating it
+	MaxBodyBytes int           // Larger responses are not cached
+}
+
+// ProfilingConfig holds settings for capturing CPU and heap profiles in production.
+// Profiles are sent to Pyroscope when it is configured, else kept in file storage.
+// Requests slower than their route's latency budget are counted, and trigger a capture
+// labeled with the route when profiling is enabled.
+type ProfilingConfig struct {
+	Enabled        bool
+	Interval       time.Duration // How often profiles are captured; zero captures only for slow routes
+	CPUDuration    time.Duration // How long CPU profiles sample for
+	Cooldown       time.Duration // Least time between captures triggered by the same route
+	PyroscopeURL   string        // Optional: send profiles to Pyroscope
+	PyroscopeApp   string
+	PyroscopeToken string
+
+	DefaultLatencyBudget time.Duration            // Budget of routes without one; zero leaves them unbudgeted
+	LatencyBudgets       map[string]time.Duration // Budgets by route pattern, optionally after a method
+}
+
+// ChaosConfig holds settings for fault injection. When enabled, platform super admins
+// can add rules injecting latency, errors and dependency failures into requests
+// through the admin API. It is refused in production.
+type ChaosConfig struct {
+	Enabled bool
+}
+
+// PaymentsConfig holds platform credentials for the payment providers.
+// Providers without credentials are unavailable to tenants.
+type PaymentsConfig struct {
+	DefaultProvider string // Used by tenants that have not chosen a provider
+
+	StripeSecretKey            string
+	StripeWebhookSecret        string
+	StripeBillingWebhookSecret string // Tenant subscription billing events
+
+	PayPalClientID     string
+	PayPalClientSecret string
+	PayPalWebhookID    string
+	PayPalSandbox      bool
+
+	PaystackSecretKey string
+
+	MPesaConsumerKey    string
+	MPesaConsumerSecret string
+	MPesaShortCode      string
+	MPesaPasskey        string
+	MPesaCallbackURL    string
+	MPesaCallbackToken  string
+	MPesaSandbox        bool
+
+	// Circuit breakers: consecutive provider outages before payments fail over, and
+	// how long a failing provider is skipped
+	BreakerFailureThreshold int
+	BreakerOpenDuration     time.Duration
 }
 
 var (
@@ -109,56 +350,203 @@ func Load() (*Config, error) {
 	// Load .env file first (if exists)
 	_ = godotenv.Load()
 
+	// Settings of the reload file override the environment from the start
+	lookup := os.Getenv
+	if path := os.Getenv("CONFIG_RELOAD_FILE"); path != "" {
+		values, err := FileSource(path).Read(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		lookup = overlay(values)
+	}
+
+	cfg, err := load(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	globalConfig = cfg
+	return cfg, nil
+}
+
+// load builds and validates the configuration from the values lookup returns
+func load(lookup func(key string) string) (*Config, error) {
+	e := env(lookup)
 	cfg := &Config{
-		Environment: getEnv("ENV", "development"),
+		Environment: e.getEnv("ENV", "development"),
 		Server: ServerConfig{
-			Host:            getEnv("SERVER_HOST", "0.0.0.0"),
-			Port:            getEnv("PORT", "3000"),
-			ReadTimeout:     getDurationEnv("SERVER_READ_TIMEOUT", 15*time.Second),
-			WriteTimeout:    getDurationEnv("SERVER_WRITE_TIMEOUT", 15*time.Second),
-			IdleTimeout:     getDurationEnv("SERVER_IDLE_TIMEOUT", 120*time.Second),
-			ShutdownTimeout: getDurationEnv("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
+			Host:            e.getEnv("SERVER_HOST", "0.0.0.0"),
+			Port:            e.getEnv("PORT", "3000"),
+			ReadTimeout:     e.getDurationEnv("SERVER_READ_TIMEOUT", 15*time.Second),
+			WriteTimeout:    e.getDurationEnv("SERVER_WRITE_TIMEOUT", 15*time.Second),
+			IdleTimeout:     e.getDurationEnv("SERVER_IDLE_TIMEOUT", 120*time.Second),
+			ShutdownTimeout: e.getDurationEnv("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
+			DrainDelay:      e.getDurationEnv("SERVER_DRAIN_DELAY", 0),
+
+			StartupAttempts:   e.getIntEnv("SERVER_STARTUP_ATTEMPTS", 8),
+			StartupBackoff:    e.getDurationEnv("SERVER_STARTUP_BACKOFF", time.Second),
+			StartupMaxBackoff: e.getDurationEnv("SERVER_STARTUP_MAX_BACKOFF", 30*time.Second),
 		},
 		Database: DatabaseConfig{
-			Host:            getEnv("DB_HOST", "localhost"),
-			Port:            getEnv("DB_PORT", "5432"),
-			User:            getEnv("DB_USER", "postgres"),
-			Password:        getEnv("DB_PASSWORD", ""),
-			DBName:          getEnv("DB_NAME", "krafti_vibe"),
-			SSLMode:         getEnv("DB_SSLMODE", "disable"),
-			SSLHost:         getEnv("DB_SSL_HOST", ""), // Optional: for SSL cert verification with IP addresses
-			MaxOpenConns:    getIntEnv("DB_MAX_OPEN_CONNS", 25),
-			MaxIdleConns:    getIntEnv("DB_MAX_IDLE_CONNS", 5),
-			ConnMaxLifetime: getDurationEnv("DB_CONN_MAX_LIFETIME", 5*time.Minute),
-			ConnMaxIdleTime: getDurationEnv("DB_CONN_MAX_IDLE_TIME", 10*time.Minute),
+			Host:            e.getEnv("DB_HOST", "localhost"),
+			Port:            e.getEnv("DB_PORT", "5432"),
+			User:            e.getEnv("DB_USER", "postgres"),
+			Password:        e.getEnv("DB_PASSWORD", ""),
+			DBName:          e.getEnv("DB_NAME", "krafti_vibe"),
+			SSLMode:         e.getEnv("DB_SSLMODE", "disable"),
+			SSLHost:         e.getEnv("DB_SSL_HOST", ""), // Optional: for SSL cert verification with IP addresses
+			MaxOpenConns:    e.getIntEnv("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    e.getIntEnv("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime: e.getDurationEnv("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+			ConnMaxIdleTime: e.getDurationEnv("DB_CONN_MAX_IDLE_TIME", 10*time.Minute),
+
+			MaxOpenConnsLimit:     e.getIntEnv("DB_MAX_OPEN_CONNS_LIMIT", 0),
+			PoolMonitorInterval:   e.getDurationEnv("DB_POOL_MONITOR_INTERVAL", 15*time.Second),
+			PoolSaturationPercent: e.getIntEnv("DB_POOL_SATURATION_PERCENT", 90),
+
+			QueryTimeout:          e.getDurationEnv("DB_QUERY_TIMEOUT", 5*time.Second),
+			AnalyticsQueryTimeout: e.getDurationEnv("DB_ANALYTICS_QUERY_TIMEOUT", 30*time.Second),
+			SlowQueryThreshold:    e.getDurationEnv("DB_SLOW_QUERY_THRESHOLD", 500*time.Millisecond),
+			StatementTimeout:      e.getDurationEnv("DB_STATEMENT_TIMEOUT", time.Minute),
 		},
 		Redis: RedisConfig{
-			Host:            getEnv("REDIS_HOST", "localhost"),
-			Port:            getEnv("REDIS_PORT", "6379"),
-			Password:        getEnv("REDIS_PASSWORD", ""),
-			DB:              getIntEnv("REDIS_DB", 0),
-			MaxRetries:      getIntEnv("REDIS_MAX_RETRIES", 3),
-			PoolSize:        getIntEnv("REDIS_POOL_SIZE", 10),
-			MinIdleConns:    getIntEnv("REDIS_MIN_IDLE_CONNS", 5),
-			DialTimeout:     getDurationEnv("REDIS_DIAL_TIMEOUT", 5*time.Second),
-			ReadTimeout:     getDurationEnv("REDIS_READ_TIMEOUT", 3*time.Second),
-			WriteTimeout:    getDurationEnv("REDIS_WRITE_TIMEOUT", 3*time.Second),
-			PoolTimeout:     getDurationEnv("REDIS_POOL_TIMEOUT", 4*time.Second),
-			ConnMaxIdleTime: getDurationEnv("REDIS_CONN_MAX_IDLE_TIME", 30*time.Minute),
+			Host:            e.getEnv("REDIS_HOST", "localhost"),
+			Port:            e.getEnv("REDIS_PORT", "6379"),
+			Password:        e.getEnv("REDIS_PASSWORD", ""),
+			DB:              e.getIntEnv("REDIS_DB", 0),
+			MaxRetries:      e.getIntEnv("REDIS_MAX_RETRIES", 3),
+			PoolSize:        e.getIntEnv("REDIS_POOL_SIZE", 10),
+			MinIdleConns:    e.getIntEnv("REDIS_MIN_IDLE_CONNS", 5),
+			DialTimeout:     e.getDurationEnv("REDIS_DIAL_TIMEOUT", 5*time.Second),
+			ReadTimeout:     e.getDurationEnv("REDIS_READ_TIMEOUT", 3*time.Second),
+			WriteTimeout:    e.getDurationEnv("REDIS_WRITE_TIMEOUT", 3*time.Second),
+			PoolTimeout:     e.getDurationEnv("REDIS_POOL_TIMEOUT", 4*time.Second),
+			ConnMaxIdleTime: e.getDurationEnv("REDIS_CONN_MAX_IDLE_TIME", 30*time.Minute),
 		},
 		Zitadel: ZitadelConfig{
-			Domain:  getEnv("ZITADEL_DOMAIN", ""),
-			KeyPath: getEnv("ZITADEL_KEY_PATH", ""),
+			Domain:  e.getEnv("ZITADEL_DOMAIN", ""),
+			KeyPath: e.getEnv("ZITADEL_KEY_PATH", ""),
+
+			TokenLifetime: e.getDurationEnv("ZITADEL_TOKEN_LIFETIME", 12*time.Hour),
+			ServiceToken:  e.getEnv("ZITADEL_SERVICE_TOKEN", ""),
 		},
 		App: AppConfig{
-			Name:           getEnv("APP_NAME", "Krafti Vibe API"),
-			Version:        getEnv("APP_VERSION", "1.0.0"),
-			LogLevel:       getEnv("LOG_LEVEL", "info"),
-			CORSOrigins:    getStringSliceEnv("CORS_ORIGINS", []string{"*"}),
-			EnableMetrics:  getBoolEnv("ENABLE_METRICS", true),
-			EnableTracing:  getBoolEnv("ENABLE_TRACING", false),
-			RateLimitRPS:   getIntEnv("RATE_LIMIT_RPS", 100),
-			RequestTimeout: getDurationEnv("REQUEST_TIMEOUT", 30*time.Second),
+			Name:           e.getEnv("APP_NAME", "Krafti Vibe API"),
+			Version:        e.getEnv("APP_VERSION", "1.0.0"),
+			LogLevel:       e.getEnv("LOG_LEVEL", "info"),
+			CORSOrigins:    e.getStringSliceEnv("CORS_ORIGINS", []string{"*"}),
+			EnableMetrics:  e.getBoolEnv("ENABLE_METRICS", true),
+			RateLimitRPS:   e.getIntEnv("RATE_LIMIT_RPS", 100),
+			RequestTimeout: e.getDurationEnv("REQUEST_TIMEOUT", 30*time.Second),
+
+			RateLimitEnabled: e.getBoolEnv("RATE_LIMIT_ENABLED", true),
+			RateLimitKeyRPM:  e.getIntEnv("RATE_LIMIT_KEY_RPM", 1200),
+			RateLimitPlans:   e.getIntMapEnv("RATE_LIMIT_PLANS"),
+
+			BaseDomain:         e.getEnv("APP_BASE_DOMAIN", "kraftivibe.com"),
+			CalendarFeedSecret: e.getEnv("CALENDAR_FEED_SECRET", ""),
+			StorageDir:         e.getEnv("STORAGE_DIR", "./storage"),
+			DownloadURLSecret:  e.getEnv("DOWNLOAD_URL_SECRET", ""),
+			TrashRetention:     e.getDurationEnv("TRASH_RETENTION", 30*24*time.Hour),
+
+			ArchiveBookingsAfterMonths: e.getIntEnv("ARCHIVE_BOOKINGS_AFTER_MONTHS", 0),
+			ArchivePaymentsAfterMonths: e.getIntEnv("ARCHIVE_PAYMENTS_AFTER_MONTHS", 0),
+
+			FieldEncryptionKey:          e.getEnv("FIELD_ENCRYPTION_KEY", ""),
+			FieldEncryptionPreviousKeys: e.getStringSliceEnv("FIELD_ENCRYPTION_PREVIOUS_KEYS", nil),
+
+			Features: e.getBoolMapEnv("FEATURE_FLAGS"),
+
+			ReloadFile:     e.getEnv("CONFIG_RELOAD_FILE", ""),
+			ReloadInterval: e.getDurationEnv("CONFIG_RELOAD_INTERVAL", 30*time.Second),
+		},
+		Payments: PaymentsConfig{
+			DefaultProvider:            e.getEnv("PAYMENT_DEFAULT_PROVIDER", "stripe"),
+			StripeSecretKey:            e.getEnv("STRIPE_SECRET_KEY", ""),
+			StripeWebhookSecret:        e.getEnv("STRIPE_WEBHOOK_SECRET", ""),
+			StripeBillingWebhookSecret: e.getEnv("STRIPE_BILLING_WEBHOOK_SECRET", ""),
+			PayPalClientID:             e.getEnv("PAYPAL_CLIENT_ID", ""),
+			PayPalClientSecret:         e.getEnv("PAYPAL_SECRET", ""),
+			PayPalWebhookID:            e.getEnv("PAYPAL_WEBHOOK_ID", ""),
+			PayPalSandbox:              e.getEnv("PAYPAL_MODE", "sandbox") != "live",
+			PaystackSecretKey:          e.getEnv("PAYSTACK_SECRET_KEY", ""),
+			MPesaConsumerKey:           e.getEnv("MPESA_CONSUMER_KEY", ""),
+			MPesaConsumerSecret:        e.getEnv("MPESA_CONSUMER_SECRET", ""),
+			MPesaShortCode:             e.getEnv("MPESA_SHORTCODE", ""),
+			MPesaPasskey:               e.getEnv("MPESA_PASSKEY", ""),
+			MPesaCallbackURL:           e.getEnv("MPESA_CALLBACK_URL", ""),
+			MPesaCallbackToken:         e.getEnv("MPESA_CALLBACK_TOKEN", ""),
+			MPesaSandbox:               e.getEnv("MPESA_ENVIRONMENT", "sandbox") != "production",
+			BreakerFailureThreshold:    e.getIntEnv("PAYMENT_BREAKER_FAILURE_THRESHOLD", 5),
+			BreakerOpenDuration:        e.getDurationEnv("PAYMENT_BREAKER_OPEN_DURATION", 30*time.Second),
+		},
+		Tracing: TracingConfig{
+			Enabled:     e.getBoolEnv("ENABLE_TRACING", false),
+			Endpoint:    e.getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318"),
+			Headers:     e.getStringMapEnv("OTEL_EXPORTER_OTLP_HEADERS"),
+			ServiceName: e.getEnv("OTEL_SERVICE_NAME", "krafti-vibe-api"),
+			SampleRatio: e.getFloatEnv("OTEL_TRACES_SAMPLE_RATIO", 1),
+		},
+		Health: HealthConfig{
+			CheckTimeout:            e.getDurationEnv("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+			LatencyBudget:           e.getDurationEnv("HEALTH_LATENCY_BUDGET", 500*time.Millisecond),
+			OutboxBacklogDegraded:   e.getIntEnv("HEALTH_OUTBOX_BACKLOG_DEGRADED", 1000),
+			OutboxBacklogUnhealthy:  e.getIntEnv("HEALTH_OUTBOX_BACKLOG_UNHEALTHY", 0),
+			WebhookBacklogDegraded:  e.getIntEnv("HEALTH_WEBHOOK_BACKLOG_DEGRADED", 5000),
+			WebhookBacklogUnhealthy: e.getIntEnv("HEALTH_WEBHOOK_BACKLOG_UNHEALTHY", 0),
+		},
+		AccessLog: AccessLogConfig{
+			Enabled:          e.getBoolEnv("ACCESS_LOG_ENABLED", true),
+			SampleRate:       e.getFloatEnv("ACCESS_LOG_SAMPLE_RATE", 1),
+			RouteSampleRates: e.getFloatMapEnv("ACCESS_LOG_ROUTE_SAMPLE_RATES"),
+			CaptureBodies:    e.getBoolEnv("ACCESS_LOG_CAPTURE_BODIES", e.getEnv("ENV", "development") == "development"),
+			MaxBodyBytes:     e.getIntEnv("ACCESS_LOG_MAX_BODY_BYTES", 4096),
+		},
+		Secrets: SecretsConfig{
+			VaultAddr:          e.getEnv("VAULT_ADDR", ""),
+			VaultToken:         e.getEnv("VAULT_TOKEN", ""),
+			VaultNamespace:     e.getEnv("VAULT_NAMESPACE", ""),
+			AWSRegion:          e.getEnv("AWS_REGION", ""),
+			AWSAccessKeyID:     e.getEnv("AWS_ACCESS_KEY_ID", ""),
+			AWSSecretAccessKey: e.getEnv("AWS_SECRET_ACCESS_KEY", ""),
+			AWSSessionToken:    e.getEnv("AWS_SESSION_TOKEN", ""),
+			CacheTTL:           e.getDurationEnv("SECRETS_CACHE_TTL", 5*time.Minute),
+		},
+		LoadShedding: LoadSheddingConfig{
+			Enabled:         e.getBoolEnv("LOAD_SHEDDING_ENABLED", true),
+			Limits:          e.getIntMapEnv("LOAD_SHEDDING_LIMITS"),
+			AdaptiveClasses: e.getStringSliceEnv("LOAD_SHEDDING_ADAPTIVE_CLASSES", []string{"expensive"}),
+			Routes:          e.getStringMapEnv("LOAD_SHEDDING_ROUTES"),
+			QueueTimeout:    e.getDurationEnv("LOAD_SHEDDING_QUEUE_TIMEOUT", 250*time.Millisecond),
+			LatencyTarget:   e.getDurationEnv("LOAD_SHEDDING_LATENCY_TARGET", 500*time.Millisecond),
+			RetryAfter:      e.getDurationEnv("LOAD_SHEDDING_RETRY_AFTER", 2*time.Second),
+		},
+		ResponseCache: ResponseCacheConfig{
+			Enabled:      e.getBoolEnv("RESPONSE_CACHE_ENABLED", true),
+			TTL:          e.getDurationEnv("RESPONSE_CACHE_TTL", time.Minute),
+			MaxAge:       e.getDurationEnv("RESPONSE_CACHE_MAX_AGE", 0),
+			MaxBodyBytes: e.getIntEnv("RESPONSE_CACHE_MAX_BODY_BYTES", 256<<10),
+		},
+		Profiling: ProfilingConfig{
+			Enabled:              e.getBoolEnv("PROFILING_ENABLED", false),
+			Interval:             e.getDurationEnv("PROFILING_INTERVAL", 10*time.Minute),
+			CPUDuration:          e.getDurationEnv("PROFILING_CPU_DURATION", 10*time.Second),
+			Cooldown:             e.getDurationEnv("PROFILING_COOLDOWN", 5*time.Minute),
+			PyroscopeURL:         e.getEnv("PYROSCOPE_SERVER_URL", ""),
+			PyroscopeApp:         e.getEnv("PYROSCOPE_APP_NAME", "krafti-vibe"),
+			PyroscopeToken:       e.getEnv("PYROSCOPE_AUTH_TOKEN", ""),
+			DefaultLatencyBudget: e.getDurationEnv("LATENCY_BUDGET_DEFAULT", 2*time.Second),
+			LatencyBudgets:       e.getDurationMapEnv("LATENCY_BUDGETS"),
+		},
+		Chaos: ChaosConfig{
+			Enabled: e.getBoolEnv("CHAOS_ENABLED", false),
+		},
+		Panics: PanicsConfig{
+			Budget:             e.getIntEnv("PANIC_BUDGET", 5),
+			BudgetWindow:       e.getDurationEnv("PANIC_BUDGET_WINDOW", time.Hour),
+			SentryDSN:          e.getEnv("SENTRY_DSN", ""),
+			SentryEnvironment:  e.getEnv("SENTRY_ENVIRONMENT", e.getEnv("ENV", "development")),
+			RollbarAccessToken: e.getEnv("ROLLBAR_ACCESS_TOKEN", ""),
 		},
 	}
 
@@ -167,7 +555,6 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
-	globalConfig = cfg
 	return cfg, nil
 }
 
@@ -187,6 +574,15 @@ func (c *Config) Validate() error {
 	if c.Database.DBName == "" {
 		return fmt.Errorf("database name is required")
 	}
+	if c.Database.MaxOpenConns < 1 {
+		return fmt.Errorf("DB_MAX_OPEN_CONNS must be at least 1")
+	}
+	if c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		return fmt.Errorf("DB_MAX_IDLE_CONNS (%d) cannot exceed DB_MAX_OPEN_CONNS (%d)", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	}
+	if c.Database.PoolSaturationPercent < 1 || c.Database.PoolSaturationPercent > 100 {
+		return fmt.Errorf("DB_POOL_SATURATION_PERCENT must be between 1 and 100")
+	}
 
 	// Validate log level
 	validLogLevels := map[string]bool{
@@ -198,6 +594,80 @@ func (c *Config) Validate() error {
 	if !validLogLevels[strings.ToLower(c.App.LogLevel)] {
 		return fmt.Errorf("invalid log level: %s (must be: debug, info, warn, error)", c.App.LogLevel)
 	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("OTEL_TRACES_SAMPLE_RATIO must be between 0 and 1")
+	}
+	if c.Server.DrainDelay < 0 || c.Server.DrainDelay >= c.Server.ShutdownTimeout {
+		return fmt.Errorf("SERVER_DRAIN_DELAY must be shorter than SERVER_SHUTDOWN_TIMEOUT")
+	}
+	if c.Server.StartupAttempts <= 0 {
+		return fmt.Errorf("SERVER_STARTUP_ATTEMPTS must be positive")
+	}
+	if c.Server.StartupBackoff < 0 || c.Server.StartupMaxBackoff < c.Server.StartupBackoff {
+		return fmt.Errorf("SERVER_STARTUP_BACKOFF must not be negative nor above SERVER_STARTUP_MAX_BACKOFF")
+	}
+	if c.App.RateLimitKeyRPM < 0 {
+		return fmt.Errorf("RATE_LIMIT_KEY_RPM cannot be negative")
+	}
+	for plan, limit := range c.App.RateLimitPlans {
+		if limit < 0 {
+			return fmt.Errorf("RATE_LIMIT_PLANS: limit of %s cannot be negative", plan)
+		}
+	}
+	if c.App.ReloadFile != "" && c.App.ReloadInterval <= 0 {
+		return fmt.Errorf("CONFIG_RELOAD_INTERVAL must be positive")
+	}
+	for class, limit := range c.LoadShedding.Limits {
+		if limit <= 0 {
+			return fmt.Errorf("LOAD_SHEDDING_LIMITS must be positive, got %d for %s", limit, class)
+		}
+	}
+	if c.LoadShedding.QueueTimeout < 0 || c.LoadShedding.LatencyTarget < 0 {
+		return fmt.Errorf("LOAD_SHEDDING_QUEUE_TIMEOUT and LOAD_SHEDDING_LATENCY_TARGET must not be negative")
+	}
+	if c.ResponseCache.TTL <= 0 || c.ResponseCache.MaxBodyBytes <= 0 {
+		return fmt.Errorf("RESPONSE_CACHE_TTL and RESPONSE_CACHE_MAX_BODY_BYTES must be positive")
+	}
+	if c.ResponseCache.MaxAge < 0 {
+		return fmt.Errorf("RESPONSE_CACHE_MAX_AGE must not be negative")
+	}
+	if c.Profiling.Interval < 0 || c.Profiling.CPUDuration <= 0 || c.Profiling.Cooldown < 0 {
+		return fmt.Errorf("PROFILING_CPU_DURATION must be positive, and PROFILING_INTERVAL and PROFILING_COOLDOWN must not be negative")
+	}
+	if c.Profiling.Interval > 0 && c.Profiling.Interval <= c.Profiling.CPUDuration {
+		return fmt.Errorf("PROFILING_INTERVAL must be longer than PROFILING_CPU_DURATION")
+	}
+	if c.Profiling.DefaultLatencyBudget < 0 {
+		return fmt.Errorf("LATENCY_BUDGET_DEFAULT must not be negative")
+	}
+	for route, budget := range c.Profiling.LatencyBudgets {
+		if budget <= 0 {
+			return fmt.Errorf("LATENCY_BUDGETS must be positive, got %s for %s", budget, route)
+		}
+	}
+	if c.Chaos.Enabled && c.IsProduction() {
+		return fmt.Errorf("CHAOS_ENABLED must not be set in production")
+	}
+	if c.Panics.Budget <= 0 {
+		return fmt.Errorf("PANIC_BUDGET must be positive")
+	}
+	if c.Panics.BudgetWindow <= 0 {
+		return fmt.Errorf("PANIC_BUDGET_WINDOW must be positive")
+	}
+	if c.Health.CheckTimeout <= 0 {
+		return fmt.Errorf("HEALTH_CHECK_TIMEOUT must be positive")
+	}
+	if c.AccessLog.SampleRate < 0 || c.AccessLog.SampleRate > 1 {
+		return fmt.Errorf("ACCESS_LOG_SAMPLE_RATE must be between 0 and 1")
+	}
+	for prefix, rate := range c.AccessLog.RouteSampleRates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("ACCESS_LOG_ROUTE_SAMPLE_RATES: rate of %s must be between 0 and 1", prefix)
+		}
+	}
+	if c.App.ArchiveBookingsAfterMonths < 0 || c.App.ArchivePaymentsAfterMonths < 0 {
+		return fmt.Errorf("ARCHIVE_BOOKINGS_AFTER_MONTHS and ARCHIVE_PAYMENTS_AFTER_MONTHS cannot be negative")
+	}
 
 	return nil
 }
@@ -244,15 +714,18 @@ func (c *Config) ServerAddr() string {
 
 // Helper functions
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+// env looks up configuration values, such as from the process environment
+type env func(key string) string
+
+func (e env) getEnv(key, defaultValue string) string {
+	if value := e(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
-func getIntEnv(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
+func (e env) getIntEnv(key string, defaultValue int) int {
+	if value := e(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
 			return intValue
 		}
@@ -260,8 +733,8 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
-func getBoolEnv(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
+func (e env) getBoolEnv(key string, defaultValue bool) bool {
+	if value := e(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
 			return boolValue
 		}
@@ -269,8 +742,17 @@ func getBoolEnv(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
-func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
+func (e env) getFloatEnv(key string, defaultValue float64) float64 {
+	if value := e(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func (e env) getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := e(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
 			return duration
 		}
@@ -282,8 +764,81 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
-func getStringSliceEnv(key string, defaultValue []string) []string {
-	if value := os.Getenv(key); value != "" {
+// getIntMapEnv parses comma separated name=value pairs, skipping malformed ones
+func (e env) getIntMapEnv(key string) map[string]int {
+	values := make(map[string]int)
+	for _, pair := range e.getStringSliceEnv(key, nil) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if intValue, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			values[strings.TrimSpace(name)] = intValue
+		}
+	}
+	return values
+}
+
+// getFloatMapEnv parses comma separated name=value pairs, skipping malformed ones
+func (e env) getFloatMapEnv(key string) map[string]float64 {
+	values := make(map[string]float64)
+	for _, pair := range e.getStringSliceEnv(key, nil) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if floatValue, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			values[strings.TrimSpace(name)] = floatValue
+		}
+	}
+	return values
+}
+
+// getBoolMapEnv parses comma separated name=value pairs, skipping malformed ones
+func (e env) getBoolMapEnv(key string) map[string]bool {
+	values := make(map[string]bool)
+	for _, pair := range e.getStringSliceEnv(key, nil) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if boolValue, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			values[strings.TrimSpace(name)] = boolValue
+		}
+	}
+	return values
+}
+
+// getDurationMapEnv parses comma separated name=duration pairs, skipping malformed ones
+func (e env) getDurationMapEnv(key string) map[string]time.Duration {
+	values := make(map[string]time.Duration)
+	for _, pair := range e.getStringSliceEnv(key, nil) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if duration, err := time.ParseDuration(strings.TrimSpace(value)); err == nil {
+			values[strings.TrimSpace(name)] = duration
+		}
+	}
+	return values
+}
+
+// getStringMapEnv parses comma separated name=value pairs, skipping malformed ones
+func (e env) getStringMapEnv(key string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range e.getStringSliceEnv(key, nil) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return values
+}
+
+func (e env) getStringSliceEnv(key string, defaultValue []string) []string {
+	if value := e(key); value != "" {
 		if value == "*" {
 			return []string{"*"}
 		}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// redactedPlaceholder replaces secrets in the redacted configuration
+const redactedPlaceholder = "[REDACTED]"
+
+// Redacted returns the configuration as shown to platform staff: its sections as
+// nested maps, durations as text, and every set secret masked
+func (c *Config) Redacted() map[string]any {
+	return redactStruct(reflect.ValueOf(*c))
+}
+
+// redactStruct describes a struct's fields, masking those holding secrets
+func redactStruct(v reflect.Value) map[string]any {
+	fields := make(map[string]any, v.NumField())
+	for i := range v.NumField() {
+		name := v.Type().Field(i).Name
+		field := v.Field(i)
+		switch {
+		case isSecretField(name):
+			if !field.IsZero() {
+				fields[name] = redactedPlaceholder
+			} else {
+				fields[name] = ""
+			}
+		case field.Kind() == reflect.Struct:
+			fields[name] = redactStruct(field)
+		case field.Type() == reflect.TypeOf(time.Duration(0)):
+			fields[name] = time.Duration(field.Int()).String()
+		default:
+			fields[name] = field.Interface()
+		}
+	}
+	return fields
+}
+
+// isSecretField reports whether a field holds credentials, such as passwords, API keys,
+// tokens, DSNs and the headers sent to collectors. Paths to key files aren't secrets.
+func isSecretField(name string) bool {
+	lower := strings.ToLower(name)
+	for _, word := range []string{"password", "secret", "token", "passkey"} {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return strings.HasSuffix(lower, "key") || strings.HasSuffix(lower, "keys") || strings.HasSuffix(lower, "dsn") || lower == "headers"
+}
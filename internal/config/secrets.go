@@ -0,0 +1,88 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"Krafti_Vibe/internal/pkg/secrets"
+)
+
+// Resolver returns a resolver of references to the configured secret managers
+func (s SecretsConfig) Resolver() *secrets.Resolver {
+	resolver := secrets.NewResolver(s.CacheTTL)
+	if s.VaultAddr != "" {
+		resolver.Register(secrets.SchemeVault, &secrets.VaultProvider{
+			Addr:      s.VaultAddr,
+			Token:     s.VaultToken,
+			Namespace: s.VaultNamespace,
+		})
+	}
+	if s.AWSRegion != "" {
+		resolver.Register(secrets.SchemeAWS, &secrets.AWSSecretsManager{
+			Region: s.AWSRegion,
+			Credentials: secrets.AWSCredentials{
+				AccessKeyID:     s.AWSAccessKeyID,
+				SecretAccessKey: s.AWSSecretAccessKey,
+				SessionToken:    s.AWSSessionToken,
+			},
+		})
+	}
+	return resolver
+}
+
+// SecretResolver resolves settings referencing a secret manager, returning plain
+// values as they are
+type SecretResolver interface {
+	Resolve(ctx context.Context, value string) (string, error)
+}
+
+// ResolveSecrets replaces every setting that references a secret manager with the
+// value it references
+func (c *Config) ResolveSecrets(ctx context.Context, resolver SecretResolver) error {
+	return resolveStruct(ctx, resolver, reflect.ValueOf(c).Elem(), "")
+}
+
+// resolveStruct resolves the string settings of a section, including those in lists
+// and maps, which are replaced rather than changed in place so copies of the
+// configuration keep their references
+func resolveStruct(ctx context.Context, resolver SecretResolver, v reflect.Value, prefix string) error {
+	for i := range v.NumField() {
+		field := v.Field(i)
+		name := prefix + v.Type().Field(i).Name
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			if err := resolveStruct(ctx, resolver, field, name+"."); err != nil {
+				return err
+			}
+		case field.Kind() == reflect.String:
+			value, err := resolver.Resolve(ctx, field.String())
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			field.SetString(value)
+		case field.Type() == reflect.TypeOf([]string(nil)) && field.Len() > 0:
+			values := make([]string, field.Len())
+			for j := range values {
+				value, err := resolver.Resolve(ctx, field.Index(j).String())
+				if err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				values[j] = value
+			}
+			field.Set(reflect.ValueOf(values))
+		case field.Type() == reflect.TypeOf(map[string]string(nil)) && field.Len() > 0:
+			values := make(map[string]string, field.Len())
+			for key, value := range field.Interface().(map[string]string) {
+				resolved, err := resolver.Resolve(ctx, value)
+				if err != nil {
+					return fmt.Errorf("%s[%s]: %w", name, key, err)
+				}
+				values[key] = resolved
+			}
+			field.Set(reflect.ValueOf(values))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"Krafti_Vibe/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prefixResolver resolves values starting with "vault:" to their uppercased path
+type prefixResolver struct{}
+
+func (prefixResolver) Resolve(ctx context.Context, value string) (string, error) {
+	path, ok := strings.CutPrefix(value, "vault:")
+	if !ok {
+		return value, nil
+	}
+	if path == "missing" {
+		return "", errors.New("secret not found")
+	}
+	return strings.ToUpper(path), nil
+}
+
+func TestResolveSecrets(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "vault:db_password")
+	t.Setenv("DB_USER", "kraftivibe")
+	t.Setenv("FIELD_ENCRYPTION_PREVIOUS_KEYS", "vault:old_key,plain_key")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "api-key=vault:otlp_key")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	loaded := *cfg
+
+	require.NoError(t, cfg.ResolveSecrets(context.Background(), prefixResolver{}))
+
+	assert.Equal(t, "DB_PASSWORD", cfg.Database.Password)
+	assert.Equal(t, "kraftivibe", cfg.Database.User)
+	assert.Equal(t, []string{"OLD_KEY", "plain_key"}, cfg.App.FieldEncryptionPreviousKeys)
+	assert.Equal(t, map[string]string{"api-key": "OTLP_KEY"}, cfg.Tracing.Headers)
+
+	// The configuration as loaded keeps its references
+	assert.Equal(t, "vault:db_password", loaded.Database.Password)
+	assert.Equal(t, []string{"vault:old_key", "plain_key"}, loaded.App.FieldEncryptionPreviousKeys)
+	assert.Equal(t, map[string]string{"api-key": "vault:otlp_key"}, loaded.Tracing.Headers)
+}
+
+func TestResolveSecretsNamesFailingSetting(t *testing.T) {
+	t.Setenv("REDIS_PASSWORD", "vault:missing")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	err = cfg.ResolveSecrets(context.Background(), prefixResolver{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Redis.Password")
+}
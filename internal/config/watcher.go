@@ -0,0 +1,239 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+)
+
+// Runtime holds the settings that take effect while the application runs. Every other
+// setting is read once at startup.
+type Runtime struct {
+	LogLevel                  string
+	RateLimitRPS              int
+	RateLimitPlans            map[string]int
+	AccessLogSampleRate       float64
+	AccessLogRouteSampleRates map[string]float64
+	Features                  map[string]bool
+}
+
+// Runtime returns the configuration's settings that take effect while the application runs
+func (c *Config) Runtime() Runtime {
+	return Runtime{
+		LogLevel:                  c.App.LogLevel,
+		RateLimitRPS:              c.App.RateLimitRPS,
+		RateLimitPlans:            c.App.RateLimitPlans,
+		AccessLogSampleRate:       c.AccessLog.SampleRate,
+		AccessLogRouteSampleRates: c.AccessLog.RouteSampleRates,
+		Features:                  c.App.Features,
+	}
+}
+
+// withRuntime returns a copy of the configuration with the given runtime settings
+func (c *Config) withRuntime(runtime Runtime) *Config {
+	cfg := *c
+	cfg.App.LogLevel = runtime.LogLevel
+	cfg.App.RateLimitRPS = runtime.RateLimitRPS
+	cfg.App.RateLimitPlans = runtime.RateLimitPlans
+	cfg.AccessLog.SampleRate = runtime.AccessLogSampleRate
+	cfg.AccessLog.RouteSampleRates = runtime.AccessLogRouteSampleRates
+	cfg.App.Features = runtime.Features
+	return &cfg
+}
+
+// Source provides settings that override the environment, such as a file or a
+// key/value store
+type Source interface {
+	Read(ctx context.Context) (map[string]string, error)
+}
+
+// FileSource reads settings from a dotenv file
+type FileSource string
+
+// Read parses the file
+func (f FileSource) Read(ctx context.Context) (map[string]string, error) {
+	return godotenv.Read(string(f))
+}
+
+// overlay looks values up in values first, then in the process environment
+func overlay(values map[string]string) func(key string) string {
+	return func(key string) string {
+		if value, ok := values[key]; ok {
+			return value
+		}
+		return os.Getenv(key)
+	}
+}
+
+// ReloadStatus describes the outcome of the latest reload
+type ReloadStatus struct {
+	Enabled        bool      `json:"enabled"`
+	AppliedAt      time.Time `json:"applied_at"`
+	CheckedAt      time.Time `json:"checked_at"`
+	Error          string    `json:"error,omitempty"`           // Why the latest settings were rejected
+	PendingRestart []string  `json:"pending_restart,omitempty"` // Sections changed since startup that take effect on restart
+}
+
+// Watcher holds the effective configuration and reloads it from a source. A reload
+// builds and validates the whole configuration as at startup; when it is valid, its
+// runtime settings are swapped in atomically and subscribers notified, and when it
+// is not, the current configuration stays in effect.
+type Watcher struct {
+	source   Source // Nil when reloading is disabled
+	interval time.Duration
+	logger   *zap.Logger
+	started  *Config
+	current  atomic.Pointer[Config]
+
+	mu          sync.Mutex // Serializes reloads, and guards the fields below
+	last        map[string]string
+	status      ReloadStatus
+	subscribers []func(Runtime)
+}
+
+// NewWatcher creates a watcher of the configuration loaded at startup, reloading it
+// from source every interval; a nil source disables reloading
+func NewWatcher(cfg *Config, source Source, interval time.Duration, logger *zap.Logger) *Watcher {
+	w := &Watcher{
+		source:   source,
+		interval: interval,
+		logger:   logger,
+		started:  cfg,
+		status: ReloadStatus{
+			Enabled:   source != nil,
+			AppliedAt: time.Now(),
+		},
+	}
+	w.current.Store(cfg)
+	return w
+}
+
+// Current returns the effective configuration
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Enabled reports whether a feature toggle is on
+func (w *Watcher) Enabled(feature string) bool {
+	return w.Current().App.Features[feature]
+}
+
+// Status returns the outcome of the latest reload
+func (w *Watcher) Status() ReloadStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+// OnChange registers a function called with the runtime settings whenever a reload
+// changes them
+func (w *Watcher) OnChange(fn func(Runtime)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload reads the source and applies its runtime settings when they are valid. A
+// source whose settings are unchanged since the last reload is left alone.
+func (w *Watcher) Reload(ctx context.Context) error {
+	if w.source == nil {
+		return fmt.Errorf("configuration reloading is disabled")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.status.CheckedAt = time.Now()
+	values, err := w.source.Read(ctx)
+	if err != nil {
+		w.status.Error = err.Error()
+		w.last = nil
+		w.logger.Error("failed to read configuration, keeping current settings", zap.Error(err))
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+	if w.last != nil && maps.Equal(values, w.last) {
+		return nil
+	}
+	w.last = values
+
+	next, err := load(overlay(values))
+	if err != nil {
+		w.status.Error = err.Error()
+		w.logger.Error("rejected configuration reload, keeping current settings", zap.Error(err))
+		return err
+	}
+
+	previous := w.Current()
+	runtime := next.Runtime()
+	w.current.Store(previous.withRuntime(runtime))
+	w.status.AppliedAt = w.status.CheckedAt
+	w.status.Error = ""
+	w.status.PendingRestart = changedSections(w.started.withRuntime(runtime), next)
+	if len(w.status.PendingRestart) > 0 {
+		w.logger.Warn("configuration changed that takes effect on restart",
+			zap.Strings("sections", w.status.PendingRestart),
+		)
+	}
+
+	if reflect.DeepEqual(previous.Runtime(), runtime) {
+		return nil
+	}
+	w.logger.Info("configuration reloaded",
+		zap.String("log_level", runtime.LogLevel),
+		zap.Int("rate_limit_rps", runtime.RateLimitRPS),
+		zap.Float64("access_log_sample_rate", runtime.AccessLogSampleRate),
+	)
+	for _, fn := range w.subscribers {
+		fn(runtime)
+	}
+	return nil
+}
+
+// Run reloads the configuration every interval, and whenever the process receives
+// SIGHUP, until the context is cancelled
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.source == nil {
+		<-ctx.Done()
+		return nil
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hangup:
+		case <-ticker.C:
+		}
+		// Failures are kept in the status and logged; the current settings stay in effect
+		_ = w.Reload(ctx)
+	}
+}
+
+// changedSections returns the names of the configuration's sections that differ
+func changedSections(a, b *Config) []string {
+	var changed []string
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for i := range va.NumField() {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			changed = append(changed, va.Type().Field(i).Name)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,80 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"Krafti_Vibe/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func writeSettings(t *testing.T, path, contents string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+}
+
+func TestWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.env")
+	writeSettings(t, path, "LOG_LEVEL=info\n")
+	t.Setenv("CONFIG_RELOAD_FILE", path)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	settings := config.NewWatcher(cfg, config.FileSource(path), cfg.App.ReloadInterval, zap.NewNop())
+	var notified []config.Runtime
+	settings.OnChange(func(runtime config.Runtime) {
+		notified = append(notified, runtime)
+	})
+
+	t.Run("applies runtime settings", func(t *testing.T) {
+		writeSettings(t, path, "LOG_LEVEL=debug\nRATE_LIMIT_RPS=5\nFEATURE_FLAGS=new_checkout=true\n")
+		require.NoError(t, settings.Reload(context.Background()))
+
+		assert.Equal(t, "debug", settings.Current().App.LogLevel)
+		assert.Equal(t, 5, settings.Current().App.RateLimitRPS)
+		assert.True(t, settings.Enabled("new_checkout"))
+		require.Len(t, notified, 1)
+		assert.Equal(t, "debug", notified[0].LogLevel)
+		assert.Empty(t, settings.Status().PendingRestart)
+	})
+
+	t.Run("rejects invalid settings", func(t *testing.T) {
+		writeSettings(t, path, "LOG_LEVEL=verbose\n")
+		require.Error(t, settings.Reload(context.Background()))
+
+		assert.Equal(t, "debug", settings.Current().App.LogLevel)
+		assert.Contains(t, settings.Status().Error, "invalid log level")
+		assert.Len(t, notified, 1)
+	})
+
+	t.Run("defers other settings to a restart", func(t *testing.T) {
+		writeSettings(t, path, "LOG_LEVEL=debug\nRATE_LIMIT_RPS=5\nFEATURE_FLAGS=new_checkout=true\nDB_MAX_OPEN_CONNS=50\n")
+		require.NoError(t, settings.Reload(context.Background()))
+
+		assert.Equal(t, cfg.Database.MaxOpenConns, settings.Current().Database.MaxOpenConns)
+		assert.Equal(t, []string{"Database"}, settings.Status().PendingRestart)
+		assert.Empty(t, settings.Status().Error)
+	})
+}
+
+func TestRedacted(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "hunter2")
+	t.Setenv("STRIPE_SECRET_KEY", "sk_live_123")
+	t.Setenv("ZITADEL_KEY_PATH", "/etc/zitadel/key.json")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	redacted := cfg.Redacted()
+	assert.Equal(t, "[REDACTED]", redacted["Database"].(map[string]any)["Password"])
+	assert.Equal(t, "[REDACTED]", redacted["Payments"].(map[string]any)["StripeSecretKey"])
+	assert.Equal(t, "", redacted["Payments"].(map[string]any)["PayPalClientSecret"])
+	assert.Equal(t, "/etc/zitadel/key.json", redacted["Zitadel"].(map[string]any)["KeyPath"])
+	assert.Equal(t, "30s", redacted["Server"].(map[string]any)["ShutdownTimeout"])
+}
@@ -0,0 +1,271 @@
+// Package authz decides what the users acting on the platform may do.
+//
+// Every user has a base role (models.UserRole) that grants a fixed set of actions,
+// either across their tenant or only on the resources that belong to them, such as a
+// customer's own bookings. Tenant admins can create custom roles granting further
+// actions across the tenant and assign them to their staff.
+package authz
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// Action is something an actor does to a kind of resource, named "<resource>.<verb>"
+type Action string
+
+const (
+	ActionBookingRead   Action = "bookings.read"
+	ActionBookingCreate Action = "bookings.create"
+	ActionBookingUpdate Action = "bookings.update" // Edits, reschedules and status changes
+	ActionBookingCancel Action = "bookings.cancel"
+	ActionBookingDelete Action = "bookings.delete"
+
+	ActionPaymentRead   Action = "payments.read"
+	ActionPaymentCreate Action = "payments.create" // Charging and recording payments
+	ActionPaymentRefund Action = "payments.refund"
+
+	ActionProjectRead   Action = "projects.read"
+	ActionProjectCreate Action = "projects.create"
+	ActionProjectUpdate Action = "projects.update"
+	ActionProjectDelete Action = "projects.delete"
+
+	ActionAuditRead Action = "audit_logs.read" // Querying and exporting the audit log
+
+	ActionRoleManage Action = "roles.manage"
+)
+
+// actions lists every action, in the order they are presented to tenant admins
+var actions = []Action{
+	ActionBookingRead, ActionBookingCreate, ActionBookingUpdate, ActionBookingCancel, ActionBookingDelete,
+	ActionPaymentRead, ActionPaymentCreate, ActionPaymentRefund,
+	ActionProjectRead, ActionProjectCreate, ActionProjectUpdate, ActionProjectDelete,
+	ActionAuditRead,
+	ActionRoleManage,
+}
+
+// Actions returns every action
+func Actions() []Action {
+	return slices.Clone(actions)
+}
+
+// IsValid checks if the action is a known action
+func (a Action) IsValid() bool {
+	return slices.Contains(actions, a)
+}
+
+// IsGrantable checks if custom roles may grant the action. Managing roles stays with
+// tenant owners and admins, as whoever may manage them could grant themselves anything.
+func (a Action) IsGrantable() bool {
+	return a.IsValid() && a != ActionRoleManage
+}
+
+// IsRead checks if the action only reads
+func (a Action) IsRead() bool {
+	return strings.HasSuffix(string(a), ".read")
+}
+
+// Resource is what an action is taken on
+type Resource struct {
+	TenantID uuid.UUID
+	// Owners are the users the resource belongs to, such as a booking's customer and
+	// artisan. Actions a role may only take on its own resources need the actor among them.
+	Owners []uuid.UUID
+}
+
+// Tenant is a resource of the tenant as a whole, such as its list of bookings or roles
+func Tenant(tenantID uuid.UUID) Resource {
+	return Resource{TenantID: tenantID}
+}
+
+// Booking is a booking as a resource, owned by its customer and artisan
+func Booking(booking *models.Booking) Resource {
+	return Resource{TenantID: booking.TenantID, Owners: []uuid.UUID{booking.CustomerID, booking.ArtisanID}}
+}
+
+// Payment is a payment as a resource, owned by its customer and the artisan paid
+func Payment(payment *models.Payment) Resource {
+	resource := Resource{TenantID: payment.TenantID, Owners: []uuid.UUID{payment.CustomerID}}
+	if payment.ArtisanID != nil {
+		resource.Owners = append(resource.Owners, *payment.ArtisanID)
+	}
+	return resource
+}
+
+// Project is a project as a resource, owned by the users of its artisan and customer
+// profiles. The project refers to the profiles rather than their users, so they need to
+// be loaded for ownership to be known.
+func Project(project *models.Project) Resource {
+	resource := Resource{TenantID: project.TenantID}
+	if project.Artisan != nil {
+		resource.Owners = append(resource.Owners, project.Artisan.UserID)
+	}
+	if project.Customer != nil {
+		resource.Owners = append(resource.Owners, project.Customer.UserID)
+	}
+	return resource
+}
+
+// Actor is the user or service account taking an action
+type Actor struct {
+	UserID         uuid.UUID // The service account's ID for service accounts
+	TenantID       uuid.UUID // Nil for platform users
+	Role           models.UserRole
+	Platform       bool
+	ServiceAccount bool
+
+	grantsOnce sync.Once
+	grants     []Action
+	grantsErr  error
+}
+
+// NewActor returns the actor for a user
+func NewActor(user *models.User) *Actor {
+	actor := &Actor{
+		UserID:   user.ID,
+		Role:     user.Role,
+		Platform: user.IsPlatformUser,
+	}
+	if user.TenantID != nil {
+		actor.TenantID = *user.TenantID
+	}
+	return actor
+}
+
+// NewServiceAccountActor returns the actor for a tenant's service account, which has no
+// role and may only take the actions of its scopes
+func NewServiceAccountActor(account *models.ServiceAccount) *Actor {
+	actor := &Actor{
+		UserID:         account.ID,
+		TenantID:       account.TenantID,
+		ServiceAccount: true,
+	}
+	grants := make([]Action, len(account.Scopes))
+	for i, scope := range account.Scopes {
+		grants[i] = Action(scope)
+	}
+	actor.grantsOnce.Do(func() {
+		actor.grants = grants
+	})
+	return actor
+}
+
+// NeedsGrants checks if the actor's custom roles could change a decision: platform
+// users and tenant owners and admins may already do anything in their scope
+func (a *Actor) NeedsGrants() bool {
+	return !a.Platform && a.Role != models.UserRoleTenantOwner && a.Role != models.UserRoleTenantAdmin
+}
+
+// Grants returns the actions the actor's custom roles grant, loading them with load
+// the first time they are needed; an actor lives for a single request, so they are
+// not reloaded
+func (a *Actor) Grants(load func() ([]Action, error)) ([]Action, error) {
+	a.grantsOnce.Do(func() {
+		a.grants, a.grantsErr = load()
+	})
+	return a.grants, a.grantsErr
+}
+
+// scope is how far a role's default action reaches
+type scope int
+
+const (
+	scopeOwn    scope = iota + 1 // Resources the actor owns
+	scopeTenant                  // Every resource of the actor's tenant
+)
+
+// roleDefaults are the actions each tenant role may take without a custom role.
+// Tenant owners and admins may take every action in their tenant.
+var roleDefaults = map[models.UserRole]map[Action]scope{
+	models.UserRoleArtisan: {
+		ActionBookingRead:   scopeOwn,
+		ActionBookingUpdate: scopeOwn,
+		ActionBookingCancel: scopeOwn,
+		ActionPaymentRead:   scopeOwn,
+		ActionProjectRead:   scopeOwn,
+		ActionProjectCreate: scopeOwn,
+		ActionProjectUpdate: scopeOwn,
+	},
+	models.UserRoleTeamMember: {
+		ActionBookingRead: scopeTenant,
+		ActionProjectRead: scopeTenant,
+	},
+	models.UserRoleCustomer: {
+		ActionBookingRead:   scopeOwn,
+		ActionBookingCreate: scopeOwn,
+		ActionBookingCancel: scopeOwn,
+		ActionPaymentRead:   scopeOwn,
+		ActionPaymentCreate: scopeOwn,
+		ActionProjectRead:   scopeOwn,
+	},
+}
+
+// Can decides whether the actor may take the action on the resource, with the actions
+// their custom roles grant across their tenant
+func Can(actor *Actor, grants []Action, action Action, resource Resource) bool {
+	if actor == nil {
+		return false
+	}
+
+	if actor.Platform {
+		switch actor.Role {
+		case models.UserRolePlatformSuperAdmin, models.UserRolePlatformAdmin:
+			return true
+		case models.UserRolePlatformSupport:
+			return action.IsRead()
+		}
+		return false
+	}
+
+	if actor.TenantID == uuid.Nil || actor.TenantID != resource.TenantID {
+		return false
+	}
+	if actor.Role == models.UserRoleTenantOwner || actor.Role == models.UserRoleTenantAdmin {
+		return true
+	}
+	if slices.Contains(grants, action) {
+		return true
+	}
+
+	switch roleDefaults[actor.Role][action] {
+	case scopeTenant:
+		return true
+	case scopeOwn:
+		return slices.Contains(resource.Owners, actor.UserID)
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// ContextKey is the key the actor is stored under. Fiber locals set with it are
+// visible through c.Context(), which handlers pass to services.
+var ContextKey any = contextKey{}
+
+// WithActor returns a context carrying the actor
+func WithActor(ctx context.Context, actor *Actor) context.Context {
+	return context.WithValue(ctx, ContextKey, actor)
+}
+
+// WithoutActor returns a context in which the platform acts on its own, for work done
+// on the actor's behalf that they are entitled to without being allowed to do it
+// directly, such as the refund a cancellation policy grants
+func WithoutActor(ctx context.Context) context.Context {
+	return WithActor(ctx, nil)
+}
+
+// ActorFromContext returns the actor of the context, if any. Work the platform does on
+// its own, such as background jobs, has none.
+func ActorFromContext(ctx context.Context) (*Actor, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	actor, ok := ctx.Value(ContextKey).(*Actor)
+	return actor, ok && actor != nil
+}
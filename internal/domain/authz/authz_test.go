@@ -0,0 +1,145 @@
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tenantActor(tenantID uuid.UUID, role models.UserRole) *authz.Actor {
+	user := &models.User{TenantID: &tenantID, Role: role}
+	user.ID = uuid.New()
+	return authz.NewActor(user)
+}
+
+func TestCanPlatformUsers(t *testing.T) {
+	booking := authz.Tenant(uuid.New())
+
+	admin := authz.NewActor(&models.User{Role: models.UserRolePlatformAdmin, IsPlatformUser: true})
+	assert.True(t, authz.Can(admin, nil, authz.ActionBookingDelete, booking))
+	assert.True(t, authz.Can(admin, nil, authz.ActionRoleManage, booking))
+
+	support := authz.NewActor(&models.User{Role: models.UserRolePlatformSupport, IsPlatformUser: true})
+	assert.True(t, authz.Can(support, nil, authz.ActionBookingRead, booking))
+	assert.False(t, authz.Can(support, nil, authz.ActionPaymentRefund, booking))
+
+	assert.False(t, authz.Can(nil, nil, authz.ActionBookingRead, booking))
+}
+
+func TestCanTenantRoles(t *testing.T) {
+	tenantID := uuid.New()
+
+	owner := tenantActor(tenantID, models.UserRoleTenantOwner)
+	assert.True(t, authz.Can(owner, nil, authz.ActionPaymentRefund, authz.Tenant(tenantID)))
+	assert.False(t, authz.Can(owner, nil, authz.ActionBookingRead, authz.Tenant(uuid.New())), "other tenants are off limits")
+
+	customer := tenantActor(tenantID, models.UserRoleCustomer)
+	artisan := tenantActor(tenantID, models.UserRoleArtisan)
+	booking := &models.Booking{TenantID: tenantID, CustomerID: customer.UserID, ArtisanID: artisan.UserID}
+	other := &models.Booking{TenantID: tenantID, CustomerID: uuid.New(), ArtisanID: uuid.New()}
+
+	assert.True(t, authz.Can(customer, nil, authz.ActionBookingCancel, authz.Booking(booking)))
+	assert.False(t, authz.Can(customer, nil, authz.ActionBookingUpdate, authz.Booking(booking)))
+	assert.False(t, authz.Can(customer, nil, authz.ActionBookingRead, authz.Booking(other)))
+
+	assert.True(t, authz.Can(artisan, nil, authz.ActionBookingUpdate, authz.Booking(booking)))
+	assert.False(t, authz.Can(artisan, nil, authz.ActionBookingUpdate, authz.Booking(other)))
+	assert.False(t, authz.Can(artisan, nil, authz.ActionBookingDelete, authz.Booking(booking)))
+
+	project := &models.Project{TenantID: tenantID}
+	assert.False(t, authz.Can(customer, nil, authz.ActionProjectRead, authz.Project(project)), "owners are only known once the profiles are loaded")
+	project.Customer = &models.Customer{UserID: customer.UserID}
+	assert.True(t, authz.Can(customer, nil, authz.ActionProjectRead, authz.Project(project)))
+	assert.False(t, authz.Can(customer, nil, authz.ActionProjectUpdate, authz.Project(project)))
+
+	staff := tenantActor(tenantID, models.UserRoleTeamMember)
+	assert.True(t, authz.Can(staff, nil, authz.ActionBookingRead, authz.Booking(other)))
+	assert.False(t, authz.Can(staff, nil, authz.ActionBookingUpdate, authz.Booking(other)))
+}
+
+func TestCanCustomRoleGrants(t *testing.T) {
+	tenantID := uuid.New()
+	staff := tenantActor(tenantID, models.UserRoleTeamMember)
+	payment := &models.Payment{TenantID: tenantID, CustomerID: uuid.New()}
+
+	grants := []authz.Action{authz.ActionPaymentRefund}
+	assert.True(t, authz.Can(staff, grants, authz.ActionPaymentRefund, authz.Payment(payment)))
+	assert.False(t, authz.Can(staff, grants, authz.ActionPaymentCreate, authz.Payment(payment)))
+
+	payment.TenantID = uuid.New()
+	assert.False(t, authz.Can(staff, grants, authz.ActionPaymentRefund, authz.Payment(payment)), "grants only apply in the actor's tenant")
+}
+
+func TestActorGrantsLoadOnce(t *testing.T) {
+	actor := tenantActor(uuid.New(), models.UserRoleTeamMember)
+	require.True(t, actor.NeedsGrants())
+
+	loads := 0
+	load := func() ([]authz.Action, error) {
+		loads++
+		return []authz.Action{authz.ActionProjectUpdate}, nil
+	}
+	for range 3 {
+		grants, err := actor.Grants(load)
+		require.NoError(t, err)
+		assert.Equal(t, []authz.Action{authz.ActionProjectUpdate}, grants)
+	}
+	assert.Equal(t, 1, loads)
+
+	failing := tenantActor(uuid.New(), models.UserRoleCustomer)
+	_, err := failing.Grants(func() ([]authz.Action, error) { return nil, errors.New("boom") })
+	assert.Error(t, err)
+
+	assert.False(t, tenantActor(uuid.New(), models.UserRoleTenantAdmin).NeedsGrants())
+}
+
+func TestServiceAccountActor(t *testing.T) {
+	tenantID := uuid.New()
+	account := &models.ServiceAccount{TenantID: tenantID, Scopes: models.StringArray{string(authz.ActionBookingRead)}}
+	account.ID = uuid.New()
+
+	actor := authz.NewServiceAccountActor(account)
+	require.True(t, actor.NeedsGrants())
+
+	grants, err := actor.Grants(func() ([]authz.Action, error) {
+		t.Fatal("a service account's grants are its scopes, not loaded")
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	booking := &models.Booking{TenantID: tenantID, CustomerID: uuid.New(), ArtisanID: uuid.New()}
+	assert.True(t, authz.Can(actor, grants, authz.ActionBookingRead, authz.Booking(booking)))
+	assert.False(t, authz.Can(actor, grants, authz.ActionBookingCancel, authz.Booking(booking)))
+	assert.False(t, authz.Can(actor, grants, authz.ActionPaymentRead, authz.Tenant(tenantID)))
+
+	booking.TenantID = uuid.New()
+	assert.False(t, authz.Can(actor, grants, authz.ActionBookingRead, authz.Booking(booking)), "scopes only apply in the account's tenant")
+}
+
+func TestActorContext(t *testing.T) {
+	_, ok := authz.ActorFromContext(context.Background())
+	assert.False(t, ok)
+
+	actor := tenantActor(uuid.New(), models.UserRoleArtisan)
+	got, ok := authz.ActorFromContext(authz.WithActor(context.Background(), actor))
+	require.True(t, ok)
+	assert.Same(t, actor, got)
+}
+
+func TestActions(t *testing.T) {
+	for _, action := range authz.Actions() {
+		assert.True(t, action.IsValid(), action)
+	}
+	assert.False(t, authz.Action("bookings.explode").IsValid())
+	assert.True(t, authz.ActionBookingUpdate.IsGrantable())
+	assert.False(t, authz.ActionRoleManage.IsGrantable())
+	assert.True(t, authz.ActionPaymentRead.IsRead())
+	assert.False(t, authz.ActionPaymentRefund.IsRead())
+}
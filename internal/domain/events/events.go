@@ -0,0 +1,175 @@
+// Package events defines the domain events published when bookings, payments and
+// projects change, and the registry modules subscribe to them through.
+//
+// Events are written to the outbox (models.OutboxEvent) in the transaction that makes
+// the change, then handed to every subscribed consumer by the dispatcher. Delivery is
+// at least once: a consumer may see an event again after a failure, so consumers must
+// be idempotent.
+package events
+
+import (
+	"slices"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// Type identifies a kind of domain event
+type Type string
+
+const (
+	TypeBookingCreated       Type = "booking.created"
+	TypeBookingStatusChanged Type = "booking.status_changed"
+	TypeBookingRescheduled   Type = "booking.rescheduled"
+	TypePaymentSucceeded     Type = "payment.succeeded"
+	TypePaymentFailed        Type = "payment.failed"
+	TypePaymentRefunded      Type = "payment.refunded"
+	TypeProjectStatusChanged Type = "project.status_changed"
+)
+
+// Aggregate types, the kind of record an event is about
+const (
+	AggregateBooking = "booking"
+	AggregatePayment = "payment"
+	AggregateProject = "project"
+)
+
+// Event is the typed payload of a domain event
+type Event interface {
+	// EventType returns the type the event is published as
+	EventType() Type
+
+	// AggregateID returns the ID of the record the event is about
+	AggregateID() uuid.UUID
+}
+
+// BookingCreated is published when a booking is made
+type BookingCreated struct {
+	BookingID  uuid.UUID            `json:"booking_id"`
+	CustomerID uuid.UUID            `json:"customer_id"`
+	ArtisanID  uuid.UUID            `json:"artisan_id"`
+	ServiceID  uuid.UUID            `json:"service_id"`
+	Status     models.BookingStatus `json:"status"`
+	StartTime  time.Time            `json:"start_time"`
+	EndTime    time.Time            `json:"end_time"`
+	TotalPrice float64              `json:"total_price"`
+	Currency   string               `json:"currency"`
+}
+
+func (e BookingCreated) EventType() Type        { return TypeBookingCreated }
+func (e BookingCreated) AggregateID() uuid.UUID { return e.BookingID }
+
+// BookingStatusChanged is published when a booking moves to another status
+type BookingStatusChanged struct {
+	BookingID  uuid.UUID            `json:"booking_id"`
+	CustomerID uuid.UUID            `json:"customer_id"`
+	ArtisanID  uuid.UUID            `json:"artisan_id"`
+	From       models.BookingStatus `json:"from"`
+	To         models.BookingStatus `json:"to"`
+	StartTime  time.Time            `json:"start_time"`
+	Note       string               `json:"note,omitempty"`
+}
+
+func (e BookingStatusChanged) EventType() Type        { return TypeBookingStatusChanged }
+func (e BookingStatusChanged) AggregateID() uuid.UUID { return e.BookingID }
+
+// BookingRescheduled is published when a booking's start or end time moves
+type BookingRescheduled struct {
+	BookingID     uuid.UUID `json:"booking_id"`
+	CustomerID    uuid.UUID `json:"customer_id"`
+	ArtisanID     uuid.UUID `json:"artisan_id"`
+	PreviousStart time.Time `json:"previous_start"`
+	PreviousEnd   time.Time `json:"previous_end"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	Note          string    `json:"note,omitempty"`
+}
+
+func (e BookingRescheduled) EventType() Type        { return TypeBookingRescheduled }
+func (e BookingRescheduled) AggregateID() uuid.UUID { return e.BookingID }
+
+// PaymentSucceeded is published when a payment is paid
+type PaymentSucceeded struct {
+	PaymentID   uuid.UUID          `json:"payment_id"`
+	BookingID   uuid.UUID          `json:"booking_id"`
+	CustomerID  uuid.UUID          `json:"customer_id"`
+	Type        models.PaymentType `json:"type"`
+	Amount      float64            `json:"amount"`
+	Currency    string             `json:"currency"`
+	ProcessedAt time.Time          `json:"processed_at"`
+}
+
+func (e PaymentSucceeded) EventType() Type        { return TypePaymentSucceeded }
+func (e PaymentSucceeded) AggregateID() uuid.UUID { return e.PaymentID }
+
+// PaymentFailed is published when a payment fails
+type PaymentFailed struct {
+	PaymentID  uuid.UUID          `json:"payment_id"`
+	BookingID  uuid.UUID          `json:"booking_id"`
+	CustomerID uuid.UUID          `json:"customer_id"`
+	Type       models.PaymentType `json:"type"`
+	Amount     float64            `json:"amount"`
+	Currency   string             `json:"currency"`
+	Reason     string             `json:"reason,omitempty"`
+}
+
+func (e PaymentFailed) EventType() Type        { return TypePaymentFailed }
+func (e PaymentFailed) AggregateID() uuid.UUID { return e.PaymentID }
+
+// PaymentRefunded is published for every refund of a payment, full or partial
+type PaymentRefunded struct {
+	PaymentID     uuid.UUID `json:"payment_id"`
+	BookingID     uuid.UUID `json:"booking_id"`
+	CustomerID    uuid.UUID `json:"customer_id"`
+	Amount        float64   `json:"amount"`         // This refund
+	RefundedTotal float64   `json:"refunded_total"` // Every refund of the payment so far
+	Currency      string    `json:"currency"`
+	FullyRefunded bool      `json:"fully_refunded"`
+	Reason        string    `json:"reason,omitempty"`
+	ProcessedAt   time.Time `json:"processed_at"` // When the refunded payment was paid
+}
+
+func (e PaymentRefunded) EventType() Type        { return TypePaymentRefunded }
+func (e PaymentRefunded) AggregateID() uuid.UUID { return e.PaymentID }
+
+// ProjectStatusChanged is published when a project moves to another status
+type ProjectStatusChanged struct {
+	ProjectID  uuid.UUID            `json:"project_id"`
+	ArtisanID  uuid.UUID            `json:"artisan_id"`
+	CustomerID *uuid.UUID           `json:"customer_id,omitempty"`
+	From       models.ProjectStatus `json:"from"`
+	To         models.ProjectStatus `json:"to"`
+	Reason     string               `json:"reason,omitempty"`
+}
+
+func (e ProjectStatusChanged) EventType() Type        { return TypeProjectStatusChanged }
+func (e ProjectStatusChanged) AggregateID() uuid.UUID { return e.ProjectID }
+
+// aggregateTypes maps every event type to the kind of record it is about
+var aggregateTypes = map[Type]string{
+	TypeBookingCreated:       AggregateBooking,
+	TypeBookingStatusChanged: AggregateBooking,
+	TypeBookingRescheduled:   AggregateBooking,
+	TypePaymentSucceeded:     AggregatePayment,
+	TypePaymentFailed:        AggregatePayment,
+	TypePaymentRefunded:      AggregatePayment,
+	TypeProjectStatusChanged: AggregateProject,
+}
+
+// Types returns every event type, sorted
+func Types() []Type {
+	types := make([]Type, 0, len(aggregateTypes))
+	for t := range aggregateTypes {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// IsValid checks if the type is a known event type
+func (t Type) IsValid() bool {
+	_, ok := aggregateTypes[t]
+	return ok
+}
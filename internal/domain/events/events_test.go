@@ -0,0 +1,140 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/events"
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingChanges(t *testing.T) {
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	before := &models.Booking{
+		Status:    models.BookingStatusPending,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	}
+	before.ID = uuid.New()
+
+	created := events.BookingChanges(nil, before, "")
+	require.Len(t, created, 1)
+	assert.Equal(t, events.TypeBookingCreated, created[0].EventType())
+	assert.Equal(t, before.ID, created[0].AggregateID())
+
+	after := *before
+	assert.Empty(t, events.BookingChanges(before, &after, ""))
+
+	after.Status = models.BookingStatusConfirmed
+	after.StartTime = start.Add(24 * time.Hour)
+	after.EndTime = after.StartTime.Add(time.Hour)
+	changes := events.BookingChanges(before, &after, "moved")
+	require.Len(t, changes, 2)
+	assert.Equal(t, events.BookingStatusChanged{
+		BookingID: before.ID,
+		From:      models.BookingStatusPending,
+		To:        models.BookingStatusConfirmed,
+		StartTime: after.StartTime,
+		Note:      "moved",
+	}, changes[0])
+	rescheduled, ok := changes[1].(events.BookingRescheduled)
+	require.True(t, ok)
+	assert.Equal(t, start, rescheduled.PreviousStart)
+	assert.Equal(t, after.StartTime, rescheduled.StartTime)
+}
+
+func TestPaymentChanges(t *testing.T) {
+	processed := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	before := &models.Payment{Amount: 100, Status: models.PaymentStatusPending}
+	before.ID = uuid.New()
+
+	after := *before
+	after.Status = models.PaymentStatusPaid
+	after.ProcessedAt = &processed
+	changes := events.PaymentChanges(before, &after)
+	require.Len(t, changes, 1)
+	assert.Equal(t, events.TypePaymentSucceeded, changes[0].EventType())
+	assert.Empty(t, events.PaymentChanges(&after, &after), "a payment already paid does not succeed again")
+
+	refunded := after
+	require.NoError(t, refunded.ProcessRefund(40, "damaged"))
+	changes = events.PaymentChanges(&after, &refunded)
+	require.Len(t, changes, 1)
+	refund, ok := changes[0].(events.PaymentRefunded)
+	require.True(t, ok)
+	assert.Equal(t, 40.0, refund.Amount)
+	assert.False(t, refund.FullyRefunded)
+	assert.Equal(t, processed, refund.ProcessedAt)
+
+	failed := *before
+	failed.MarkAsFailed("declined")
+	changes = events.PaymentChanges(before, &failed)
+	require.Len(t, changes, 1)
+	assert.Equal(t, events.PaymentFailed{PaymentID: before.ID, Amount: 100, Reason: "declined"}, changes[0])
+}
+
+func TestNewOutboxEvent(t *testing.T) {
+	tenantID := uuid.New()
+	event := events.ProjectStatusChange(&models.Project{Status: models.ProjectStatusPlanned}, models.ProjectStatusInProgress, "")
+
+	outbox, err := events.NewOutboxEvent(tenantID, event)
+	require.NoError(t, err)
+	assert.Equal(t, "project.status_changed", outbox.EventType)
+	assert.Equal(t, events.AggregateProject, outbox.AggregateType)
+	assert.Equal(t, &tenantID, outbox.TenantID)
+	assert.Equal(t, models.OutboxStatusPending, outbox.Status)
+	assert.JSONEq(t, `{"project_id":"00000000-0000-0000-0000-000000000000","artisan_id":"00000000-0000-0000-0000-000000000000","from":"planned","to":"in_progress"}`, string(outbox.Payload))
+
+	platform, err := events.NewOutboxEvent(uuid.Nil, event)
+	require.NoError(t, err)
+	assert.Nil(t, platform.TenantID)
+}
+
+func TestRegistry(t *testing.T) {
+	registry := events.NewRegistry()
+	payment := events.PaymentSucceeded{PaymentID: uuid.New(), Amount: 25, Currency: "KES"}
+	outbox, err := events.NewOutboxEvent(uuid.New(), payment)
+	require.NoError(t, err)
+
+	var received []events.PaymentSucceeded
+	events.On(registry, "analytics", func(ctx context.Context, envelope *events.Envelope, event events.PaymentSucceeded) error {
+		received = append(received, event)
+		return nil
+	})
+	registry.Subscribe("webhooks", events.TypePaymentSucceeded, func(ctx context.Context, envelope *events.Envelope) error {
+		return nil
+	})
+
+	subscriptions := registry.Subscriptions(events.TypePaymentSucceeded)
+	require.Len(t, subscriptions, 2)
+	assert.Equal(t, "analytics", subscriptions[0].Consumer)
+	assert.Equal(t, "webhooks", subscriptions[1].Consumer)
+	assert.Empty(t, registry.Subscriptions(events.TypePaymentFailed))
+
+	envelope := events.NewEnvelope(outbox)
+	assert.Equal(t, 1, envelope.Attempt)
+	require.NoError(t, subscriptions[0].Handler(context.Background(), envelope))
+	require.Len(t, received, 1)
+	assert.Equal(t, payment.PaymentID, received[0].PaymentID)
+	assert.Equal(t, 25.0, received[0].Amount)
+
+	envelope.Payload = []byte(`{"amount":"many"}`)
+	assert.Error(t, subscriptions[0].Handler(context.Background(), envelope), "an undecodable payload fails the delivery")
+
+	assert.Panics(t, func() {
+		registry.Subscribe("analytics", events.TypePaymentSucceeded, func(ctx context.Context, envelope *events.Envelope) error { return nil })
+	})
+}
+
+func TestTypes(t *testing.T) {
+	types := events.Types()
+	assert.Len(t, types, 7)
+	assert.Contains(t, types, events.TypePaymentRefunded)
+	assert.True(t, events.TypeBookingCreated.IsValid())
+	assert.False(t, events.Type("booking.deleted").IsValid())
+}
@@ -0,0 +1,154 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// NewOutboxEvent builds the outbox row that publishes an event of a tenant's
+func NewOutboxEvent(tenantID uuid.UUID, event Event) (*models.OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+
+	now := time.Now()
+	outbox := &models.OutboxEvent{
+		EventType:     string(event.EventType()),
+		AggregateType: aggregateTypes[event.EventType()],
+		AggregateID:   event.AggregateID(),
+		Payload:       payload,
+		Status:        models.OutboxStatusPending,
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}
+	if tenantID != uuid.Nil {
+		outbox.TenantID = &tenantID
+	}
+	return outbox, nil
+}
+
+// Outbox builds the outbox rows of several events of a tenant's
+func Outbox(tenantID uuid.UUID, events ...Event) ([]*models.OutboxEvent, error) {
+	rows := make([]*models.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		row, err := NewOutboxEvent(tenantID, event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// BookingChanges returns the events of a booking going from before to after. A nil
+// before is the creation of the booking.
+func BookingChanges(before, after *models.Booking, note string) []Event {
+	if before == nil {
+		return []Event{BookingCreated{
+			BookingID:  after.ID,
+			CustomerID: after.CustomerID,
+			ArtisanID:  after.ArtisanID,
+			ServiceID:  after.ServiceID,
+			Status:     after.Status,
+			StartTime:  after.StartTime,
+			EndTime:    after.EndTime,
+			TotalPrice: after.TotalPrice,
+			Currency:   after.Currency,
+		}}
+	}
+
+	var changes []Event
+	if before.Status != after.Status {
+		changes = append(changes, BookingStatusChanged{
+			BookingID:  after.ID,
+			CustomerID: after.CustomerID,
+			ArtisanID:  after.ArtisanID,
+			From:       before.Status,
+			To:         after.Status,
+			StartTime:  after.StartTime,
+			Note:       note,
+		})
+	}
+	if !before.StartTime.Equal(after.StartTime) || !before.EndTime.Equal(after.EndTime) {
+		changes = append(changes, BookingRescheduled{
+			BookingID:     after.ID,
+			CustomerID:    after.CustomerID,
+			ArtisanID:     after.ArtisanID,
+			PreviousStart: before.StartTime,
+			PreviousEnd:   before.EndTime,
+			StartTime:     after.StartTime,
+			EndTime:       after.EndTime,
+			Note:          note,
+		})
+	}
+	return changes
+}
+
+// PaymentChanges returns the events of a payment going from before to after: it
+// succeeding, failing or being refunded. Only before's status and refunded amount are
+// read.
+func PaymentChanges(before, after *models.Payment) []Event {
+	var changes []Event
+	if after.Status == models.PaymentStatusPaid && before.Status != models.PaymentStatusPaid {
+		changes = append(changes, PaymentSucceeded{
+			PaymentID:   after.ID,
+			BookingID:   after.BookingID,
+			CustomerID:  after.CustomerID,
+			Type:        after.Type,
+			Amount:      after.Amount,
+			Currency:    after.Currency,
+			ProcessedAt: processedAt(after),
+		})
+	}
+	if after.Status == models.PaymentStatusFailed && before.Status != models.PaymentStatusFailed {
+		changes = append(changes, PaymentFailed{
+			PaymentID:  after.ID,
+			BookingID:  after.BookingID,
+			CustomerID: after.CustomerID,
+			Type:       after.Type,
+			Amount:     after.Amount,
+			Currency:   after.Currency,
+			Reason:     after.FailureReason,
+		})
+	}
+	if after.RefundedAmount > before.RefundedAmount {
+		changes = append(changes, PaymentRefunded{
+			PaymentID:     after.ID,
+			BookingID:     after.BookingID,
+			CustomerID:    after.CustomerID,
+			Amount:        after.RefundedAmount - before.RefundedAmount,
+			RefundedTotal: after.RefundedAmount,
+			Currency:      after.Currency,
+			FullyRefunded: after.Status == models.PaymentStatusRefunded,
+			Reason:        after.RefundReason,
+			ProcessedAt:   processedAt(after),
+		})
+	}
+	return changes
+}
+
+// ProjectStatusChange returns the event of a project moving to another status
+func ProjectStatusChange(project *models.Project, to models.ProjectStatus, reason string) Event {
+	return ProjectStatusChanged{
+		ProjectID:  project.ID,
+		ArtisanID:  project.ArtisanID,
+		CustomerID: project.CustomerID,
+		From:       project.Status,
+		To:         to,
+		Reason:     reason,
+	}
+}
+
+// processedAt returns when a payment was processed, or now when that was not recorded
+func processedAt(payment *models.Payment) time.Time {
+	if payment.ProcessedAt != nil {
+		return *payment.ProcessedAt
+	}
+	return time.Now()
+}
@@ -0,0 +1,94 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// Envelope is an event as handed to consumers
+type Envelope struct {
+	ID          uuid.UUID // Outbox row ID, the same on every delivery of the event
+	Type        Type
+	TenantID    *uuid.UUID
+	AggregateID uuid.UUID
+	OccurredAt  time.Time
+	Attempt     int // 1 on the first delivery
+	Payload     json.RawMessage
+}
+
+// NewEnvelope wraps an outbox row for its next delivery
+func NewEnvelope(row *models.OutboxEvent) *Envelope {
+	return &Envelope{
+		ID:          row.ID,
+		Type:        Type(row.EventType),
+		TenantID:    row.TenantID,
+		AggregateID: row.AggregateID,
+		OccurredAt:  row.OccurredAt,
+		Attempt:     row.Attempts + 1,
+		Payload:     row.Payload,
+	}
+}
+
+// Handler reacts to an event. An error has the event delivered to the consumer again
+// later.
+type Handler func(ctx context.Context, envelope *Envelope) error
+
+// Subscription is a consumer's handler of one event type
+type Subscription struct {
+	Consumer string
+	Handler  Handler
+}
+
+// Registry holds the consumers subscribed to each event type. Consumers are
+// registered at startup, before the dispatcher runs.
+type Registry struct {
+	mu            sync.RWMutex
+	subscriptions map[Type][]Subscription
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{subscriptions: make(map[Type][]Subscription)}
+}
+
+// Subscribe registers a consumer's handler of an event type. The consumer name tells
+// apart whose handler failed, so an event is retried only for the consumers that
+// failed it; subscribing the same consumer to a type twice panics.
+func (r *Registry) Subscribe(consumer string, eventType Type, handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, subscription := range r.subscriptions[eventType] {
+		if subscription.Consumer == consumer {
+			panic(fmt.Sprintf("events: %s is already subscribed to %s", consumer, eventType))
+		}
+	}
+	r.subscriptions[eventType] = append(r.subscriptions[eventType], Subscription{Consumer: consumer, Handler: handler})
+}
+
+// Subscriptions returns the handlers of an event type in the order they subscribed
+func (r *Registry) Subscriptions(eventType Type) []Subscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Subscription(nil), r.subscriptions[eventType]...)
+}
+
+// On subscribes a consumer to the events of type E, decoding their payload for it
+func On[E Event](r *Registry, consumer string, handle func(ctx context.Context, envelope *Envelope, event E) error) {
+	var zero E
+	r.Subscribe(consumer, zero.EventType(), func(ctx context.Context, envelope *Envelope) error {
+		var event E
+		if err := json.Unmarshal(envelope.Payload, &event); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", envelope.Type, err)
+		}
+		return handle(ctx, envelope, event)
+	})
+}
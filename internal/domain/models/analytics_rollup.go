@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsDailyRollup is one UTC day of a tenant's bookings and payments for one
+// artisan and service, kept so dashboards read pre-aggregated rows instead of scanning
+// bookings and payments. Bookings count on the day they start and payments on the day
+// they were processed; a payment takes the artisan and service of its booking.
+type AnalyticsDailyRollup struct {
+	BaseModel
+
+	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;uniqueIndex:idx_analytics_rollup_key,priority:1"`
+	ArtisanID uuid.UUID `json:"artisan_id" gorm:"type:uuid;not null;uniqueIndex:idx_analytics_rollup_key,priority:2;index"`
+	ServiceID uuid.UUID `json:"service_id" gorm:"type:uuid;not null;uniqueIndex:idx_analytics_rollup_key,priority:3;index"`
+	Day       time.Time `json:"day" gorm:"type:date;not null;uniqueIndex:idx_analytics_rollup_key,priority:4;index"`
+
+	// Bookings starting on the day
+	BookingCount   int64   `json:"booking_count" gorm:"not null;default:0"`
+	CompletedCount int64   `json:"completed_count" gorm:"not null;default:0"`
+	CancelledCount int64   `json:"cancelled_count" gorm:"not null;default:0"`
+	BookingRevenue float64 `json:"booking_revenue" gorm:"type:decimal(12,2);not null;default:0"` // Total price of the completed ones
+
+	// Payments processed on the day
+	PaymentCount   int64   `json:"payment_count" gorm:"not null;default:0"`
+	PaymentRevenue float64 `json:"payment_revenue" gorm:"type:decimal(12,2);not null;default:0"` // Amount of the paid ones
+
+	RefreshedAt time.Time `json:"refreshed_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for AnalyticsDailyRollup
+func (AnalyticsDailyRollup) TableName() string {
+	return "analytics_daily_rollups"
+}
+
+// AnalyticsRollupState records how far a tenant's rollups are up to date
+type AnalyticsRollupState struct {
+	BaseModel
+
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;uniqueIndex"`
+
+	// RefreshedAt is when the rollups were last brought up to date: changes to bookings
+	// and payments made before it are included
+	RefreshedAt time.Time `json:"refreshed_at" gorm:"not null"`
+
+	// RebuiltAt is when every rollup of the tenant was last recomputed, which also
+	// corrects days an incremental refresh can't see change, such as the day a
+	// rescheduled booking moved away from
+	RebuiltAt time.Time `json:"rebuilt_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for AnalyticsRollupState
+func (AnalyticsRollupState) TableName() string {
+	return "analytics_rollup_states"
+}
@@ -33,18 +33,26 @@ type Artisan struct {
 	Certifications CertificationArray `json:"certifications,omitempty" gorm:"type:jsonb"`
 	Portfolio      PortfolioArray     `json:"portfolio,omitempty" gorm:"type:jsonb"`
 
+	// Badges earned by approved verification documents (see ArtisanBadges)
+	IsVerified bool `json:"is_verified" gorm:"default:false;index"`
+	IsInsured  bool `json:"is_insured" gorm:"default:false"`
+
 	// Ratings & Reviews
 	Rating        float64 `json:"rating" gorm:"type:decimal(3,2);default:0"`
 	ReviewCount   int     `json:"review_count" gorm:"default:0"`
 	TotalBookings int     `json:"total_bookings" gorm:"default:0"`
 
+	// Customers who favorited the artisan, kept as favorites are added and removed
+	FavoriteCount int `json:"favorite_count" gorm:"default:0"`
+
 	// Availability
 	IsAvailable      bool   `json:"is_available" gorm:"default:true;index:idx_artisan_tenant_status"`
 	AvailabilityNote string `json:"availability_note,omitempty" gorm:"size:500"`
 
 	// Commission & Payment
-	CommissionRate   float64 `json:"commission_rate" gorm:"type:decimal(5,2);default:0"` // Percentage
-	PaymentAccountID string  `json:"payment_account_id,omitempty" gorm:"size:255"`
+	CommissionRate   float64     `json:"commission_rate" gorm:"type:decimal(5,2);default:0"` // Percentage
+	Tier             ArtisanTier `json:"tier" gorm:"type:varchar(20);default:'standard'"`    // Selects tenant commission rules
+	PaymentAccountID string      `json:"payment_account_id,omitempty" gorm:"size:255"`
 
 	// Settings
 	AutoAcceptBookings   bool `json:"auto_accept_bookings" gorm:"default:false"`
@@ -52,10 +60,18 @@ type Artisan struct {
 	MaxAdvanceBooking    int  `json:"max_advance_booking" gorm:"default:90"` // days
 	SimultaneousBookings int  `json:"simultaneous_bookings" gorm:"default:1"`
 
+	// CalendarTokenVersion is signed into the artisan's calendar feed token; bumping it
+	// revokes subscription URLs shared so far
+	CalendarTokenVersion int `json:"-" gorm:"not null;default:1"`
+
 	// Location
 	Location      Location `json:"location,omitempty" gorm:"type:jsonb"`
 	ServiceRadius int      `json:"service_radius" gorm:"default:0"` // km, 0 = no travel
 
+	// PostGIS point of the location's coordinates for distance searches, maintained by
+	// Postgres; null while the location has no coordinates
+	GeoPoint string `json:"-" gorm:"->:false;type:geography(Point,4326) GENERATED ALWAYS AS (CASE WHEN (location->>'latitude')::float8 <> 0 OR (location->>'longitude')::float8 <> 0 THEN ST_SetSRID(ST_MakePoint((location->>'longitude')::float8, (location->>'latitude')::float8), 4326)::geography END) STORED;index:idx_artisan_geo_point,type:gist"`
+
 	// Metadata
 	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb"`
 
@@ -100,6 +116,12 @@ type Location struct {
 	Longitude  float64 `json:"longitude" validate:"longitude"`
 }
 
+// HasCoordinates reports whether the location has been placed on the map. The zero
+// coordinates, off the coast of West Africa, stand for a location without them.
+func (l *Location) HasCoordinates() bool {
+	return l != nil && (l.Latitude != 0 || l.Longitude != 0)
+}
+
 // Scan and Value methods for JSONB types
 func (c *Certification) Scan(value interface{}) error {
 	bytes, ok := value.([]byte)
@@ -7,14 +7,18 @@ import (
 type AuditAction string
 
 const (
-	AuditActionCreate AuditAction = "create"
-	AuditActionUpdate AuditAction = "update"
-	AuditActionDelete AuditAction = "delete"
-	AuditActionLogin  AuditAction = "login"
-	AuditActionLogout AuditAction = "logout"
-	AuditActionExport AuditAction = "export"
+	AuditActionCreate   AuditAction = "create"
+	AuditActionUpdate   AuditAction = "update"
+	AuditActionDelete   AuditAction = "delete"
+	AuditActionLogin    AuditAction = "login"
+	AuditActionLogout   AuditAction = "logout"
+	AuditActionExport   AuditAction = "export"
+	AuditActionOverride AuditAction = "override"
+	AuditActionErase    AuditAction = "erase"
 )
 
+// AuditLog is an entry of the append-only audit trail: the database refuses to update
+// or delete entries once written
 type AuditLog struct {
 	BaseModel
 
@@ -39,6 +43,7 @@ type AuditLog struct {
 	// Request Info
 	IPAddress string `json:"ip_address" gorm:"size:45"`
 	UserAgent string `json:"user_agent" gorm:"size:500"`
+	RequestID string `json:"request_id,omitempty" gorm:"size:100;index"`
 
 	// Metadata
 	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb"`
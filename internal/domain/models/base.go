@@ -12,11 +12,11 @@ import (
 
 // BaseModel provides common fields and behavior for all entities
 type BaseModel struct {
-	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
-	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime;index"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
-	Version   int        `json:"version" gorm:"default:0;not null"` // Optimistic locking
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime;index"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"` // Set by a soft delete, which hides the row from queries
+	Version   int            `json:"version" gorm:"default:0;not null"` // Optimistic locking
 }
 
 // BeforeCreate hook to ensure UUID is generated and version is initialized
@@ -38,7 +38,7 @@ func (b *BaseModel) BeforeUpdate(tx *gorm.DB) error {
 
 // IsDeleted checks if the entity is soft deleted
 func (b *BaseModel) IsDeleted() bool {
-	return b.DeletedAt != nil && !b.DeletedAt.IsZero()
+	return b.DeletedAt.Valid
 }
 
 // GetAge returns the age of the entity in seconds
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,7 @@ const (
 	BookingStatusCompleted  BookingStatus = "completed"
 	BookingStatusCancelled  BookingStatus = "cancelled"
 	BookingStatusNoShow     BookingStatus = "no_show"
+	BookingStatusRefunded   BookingStatus = "refunded"
 )
 
 type Booking struct {
@@ -28,6 +30,9 @@ type Booking struct {
 	CustomerID uuid.UUID `json:"customer_id" gorm:"type:uuid;not null;index:idx_booking_customer_status" validate:"required"`
 	ServiceID  uuid.UUID `json:"service_id" gorm:"type:uuid;not null;index" validate:"required"`
 
+	// Project the visit belongs to, such as a site visit or installation appointment
+	ProjectID *uuid.UUID `json:"project_id,omitempty" gorm:"type:uuid;index"`
+
 	// Timing
 	StartTime time.Time `json:"start_time" gorm:"not null;index:idx_booking_tenant_date;index:idx_booking_artisan_status" validate:"required"`
 	EndTime   time.Time `json:"end_time" gorm:"not null" validate:"required,gtfield=StartTime"`
@@ -44,12 +49,38 @@ type Booking struct {
 	DepositPaid float64 `json:"deposit_paid" gorm:"type:decimal(10,2);default:0"`
 	Currency    string  `json:"currency" gorm:"size:3;default:'USD'"`
 
+	// Service price version ListPrice was taken from
+	PriceVersionID *uuid.UUID `json:"price_version_id,omitempty" gorm:"type:uuid;index"`
+
+	// Service price before dynamic pricing, and the pricing rules that took it to
+	// BasePrice (see ApplyPricingRules)
+	ListPrice          float64            `json:"list_price" gorm:"type:decimal(10,2);default:0"`
+	PricingAdjustments PricingAdjustments `json:"pricing_adjustments,omitempty" gorm:"type:jsonb"`
+
+	// Promo code discount, taken off BasePrice + AddonsPrice before tax
+	PromoCodeID    *uuid.UUID `json:"promo_code_id,omitempty" gorm:"type:uuid;index"`
+	PromoCode      string     `json:"promo_code,omitempty" gorm:"size:50"`
+	DiscountAmount float64    `json:"discount_amount" gorm:"type:decimal(10,2);default:0"`
+
+	// Tax charged on the discounted subtotal; TotalPrice includes the exclusive part (see ApplyTax)
+	TaxAmount float64  `json:"tax_amount" gorm:"type:decimal(10,2);default:0"`
+	TaxLines  TaxLines `json:"tax_lines,omitempty" gorm:"type:jsonb"`
+
 	// Details
 	Notes          string      `json:"notes,omitempty" gorm:"type:text"`
 	CustomerNotes  string      `json:"customer_notes,omitempty" gorm:"type:text"`
 	InternalNotes  string      `json:"internal_notes,omitempty" gorm:"type:text"`
 	SelectedAddons []uuid.UUID `json:"selected_addons,omitempty" gorm:"type:uuid[]"`
 
+	// Free-form labels for staff views, normalized by NormalizeBookingTags
+	Tags StringArray `json:"tags,omitempty" gorm:"type:jsonb;index:idx_booking_tags,type:gin"`
+
+	// Priced addon lines; SelectedAddons mirrors their IDs
+	Addons BookingAddons `json:"addons,omitempty" gorm:"type:jsonb"`
+
+	// Customer answers to the service's intake form, keyed by field key
+	IntakeAnswers JSONB `json:"intake_answers,omitempty" gorm:"type:jsonb"`
+
 	// Location (for mobile services)
 	ServiceLocation *Location `json:"service_location,omitempty" gorm:"type:jsonb"`
 
@@ -67,28 +98,55 @@ type Booking struct {
 	PaymentIntentID string `json:"payment_intent_id,omitempty" gorm:"size:255"`
 	RefundID        string `json:"refund_id,omitempty" gorm:"size:255"`
 
+	// Refund owed by the cancellation, saved with it and issued afterwards (see RefundStatus)
+	RefundStatus        RefundStatus `json:"refund_status,omitempty" gorm:"type:varchar(20);index"`
+	RefundAmount        float64      `json:"refund_amount,omitempty" gorm:"type:decimal(10,2);default:0"`
+	RefundIssued        float64      `json:"refund_issued,omitempty" gorm:"type:decimal(10,2);default:0"`
+	RefundFailureReason string       `json:"refund_failure_reason,omitempty" gorm:"type:text"`
+
+	// Payment Schedule: deposit up front, balance charged to the saved payment method before the start
+	PaymentMethodID      string        `json:"-" gorm:"size:255"` // Falls back to the customer's default payment method
+	BalanceDueHours      int           `json:"balance_due_hours,omitempty" gorm:"default:0"`
+	BalanceDueAt         *time.Time    `json:"balance_due_at,omitempty" gorm:"index"`
+	BalanceStatus        BalanceStatus `json:"balance_status,omitempty" gorm:"type:varchar(20);index"`
+	BalanceAttempts      int           `json:"balance_attempts,omitempty" gorm:"default:0"`
+	BalanceFailureReason string        `json:"balance_failure_reason,omitempty" gorm:"type:text"`
+
 	// Recurrence
 	IsRecurring       bool       `json:"is_recurring" gorm:"default:false"`
 	RecurrencePattern string     `json:"recurrence_pattern,omitempty" gorm:"size:50"` // weekly, biweekly, monthly
 	ParentBookingID   *uuid.UUID `json:"parent_booking_id,omitempty" gorm:"type:uuid;index"`
 	RecurrenceEndDate *time.Time `json:"recurrence_end_date,omitempty"`
 
+	// Series exceptions: the root keeps the list so re-expansion leaves skipped and
+	// moved dates alone; an occurrence keeps the slot it was generated for
+	RecurrenceExceptions RecurrenceExceptions `json:"recurrence_exceptions,omitempty" gorm:"type:jsonb"`
+	OriginalStartTime    *time.Time           `json:"original_start_time,omitempty"`
+	DetachedFromSeries   bool                 `json:"detached_from_series" gorm:"default:false"` // Series-wide updates skip it
+
 	// Reminders
 	ReminderSent24h bool `json:"reminder_sent_24h" gorm:"default:false"`
 	ReminderSent1h  bool `json:"reminder_sent_1h" gorm:"default:false"`
 
+	// Review request sent to the customer after completion (see ReviewModerationPolicy)
+	ReviewRequestedAt *time.Time `json:"review_requested_at,omitempty" gorm:"index"`
+
 	// Metadata
 	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb"`
 
+	// Full-text search document of the notes, maintained by Postgres
+	SearchVector string `json:"-" gorm:"->:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(notes, '') || ' ' || coalesce(customer_notes, '')), 'B') || setweight(to_tsvector('simple', coalesce(internal_notes, '')), 'C')) STORED;index:idx_booking_search,type:gin"`
+
 	// Relationships
-	Tenant        *Tenant   `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
-	Artisan       *User     `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
-	Customer      *User     `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
-	Service       *Service  `json:"service,omitempty" gorm:"foreignKey:ServiceID"`
-	Payments      []Payment `json:"payments,omitempty" gorm:"foreignKey:BookingID"`
-	Review        *Review   `json:"review,omitempty" gorm:"foreignKey:BookingID"`
-	ParentBooking *Booking  `json:"parent_booking,omitempty" gorm:"foreignKey:ParentBookingID"`
-	ChildBookings []Booking `json:"child_bookings,omitempty" gorm:"foreignKey:ParentBookingID"`
+	Tenant        *Tenant              `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+	Artisan       *User                `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
+	Customer      *User                `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	Service       *Service             `json:"service,omitempty" gorm:"foreignKey:ServiceID"`
+	Payments      []Payment            `json:"payments,omitempty" gorm:"foreignKey:BookingID"`
+	Review        *Review              `json:"review,omitempty" gorm:"foreignKey:BookingID"`
+	Conversation  *BookingConversation `json:"conversation,omitempty" gorm:"foreignKey:BookingID"`
+	ParentBooking *Booking             `json:"parent_booking,omitempty" gorm:"foreignKey:ParentBookingID"`
+	ChildBookings []Booking            `json:"child_bookings,omitempty" gorm:"foreignKey:ParentBookingID"`
 }
 
 // Business Methods
@@ -105,6 +163,9 @@ func (b *Booking) IsInProgress() bool {
 	return now.After(b.StartTime) && now.Before(b.EndTime) && b.Status == BookingStatusInProgress
 }
 
+// CalculateRefundAmount applies fixed refund tiers to the total price.
+//
+// Deprecated: use CancellationPolicy.EvaluateCancellation, which honours tenant policies.
 func (b *Booking) CalculateRefundAmount() float64 {
 	hoursUntil := time.Until(b.StartTime).Hours()
 
@@ -123,3 +184,52 @@ func (b *Booking) CalculateRefundAmount() float64 {
 func (b *Booking) RequiresDeposit() bool {
 	return b.DepositPaid > 0
 }
+
+// AmountPaid returns how much the customer has paid towards the booking so far
+func (b *Booking) AmountPaid() float64 {
+	if b.PaymentStatus == PaymentStatusPaid {
+		return b.TotalPrice
+	}
+	return b.DepositPaid
+}
+
+// Subtotal returns the booking's price after any discount and before any tax added on top
+func (b *Booking) Subtotal() float64 {
+	return roundMoney(math.Max(b.BasePrice+b.AddonsPrice-b.DiscountAmount, 0))
+}
+
+// ApplyDiscount records a promo code discount; ApplyTax must follow to reprice the total
+func (b *Booking) ApplyDiscount(promo *PromoCode, amount float64) {
+	b.PromoCodeID = &promo.ID
+	b.PromoCode = promo.Code
+	b.DiscountAmount = roundMoney(amount)
+}
+
+// ApplyTax records the tax charged on the booking and sets TotalPrice to the subtotal
+// plus the tax added on top of it
+func (b *Booking) ApplyTax(lines TaxLines) {
+	b.TaxLines = lines
+	b.TaxAmount = lines.Total()
+	b.TotalPrice = roundMoney(b.Subtotal() + lines.Exclusive())
+}
+
+// TaxShare returns the tax contained in a payment of amount towards the booking
+func (b *Booking) TaxShare(amount float64) float64 {
+	if b.TaxAmount <= 0 || b.TotalPrice <= 0 {
+		return 0
+	}
+	return roundMoney(math.Min(amount, b.TotalPrice) * b.TaxAmount / b.TotalPrice)
+}
+
+// DiscountShare returns the part of the booking's discount covered by a payment of amount
+func (b *Booking) DiscountShare(amount float64) float64 {
+	if b.DiscountAmount <= 0 || b.TotalPrice <= 0 {
+		return 0
+	}
+	return roundMoney(math.Min(amount, b.TotalPrice) * b.DiscountAmount / b.TotalPrice)
+}
+
+// HasPaymentTowardsTotal reports whether a deposit or full payment has been recorded
+func (b *Booking) HasPaymentTowardsTotal() bool {
+	return b.DepositPaid > 0 || b.PaymentStatus == PaymentStatusPaid
+}
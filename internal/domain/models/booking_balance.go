@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// BalanceStatus tracks automatic collection of the amount left after the deposit
+type BalanceStatus string
+
+const (
+	BalanceStatusScheduled  BalanceStatus = "scheduled"  // Waiting for the due time
+	BalanceStatusProcessing BalanceStatus = "processing" // Claimed by the collection worker
+	BalanceStatusCharged    BalanceStatus = "charged"    // Submitted to the saved payment method
+	BalanceStatusDeclined   BalanceStatus = "declined"   // The provider declined a submitted charge; awaiting dunning
+	BalanceStatusFailed     BalanceStatus = "failed"     // Every attempt failed; collect manually
+)
+
+// DefaultBalanceRetryDays is the dunning schedule used when a tenant has not set one:
+// failed balance charges are retried 1, 3 and 7 days after the first failure
+var DefaultBalanceRetryDays = []int{1, 3, 7}
+
+// DunningStage is how far a failed balance has escalated, and sets what the customer is told
+type DunningStage string
+
+const (
+	DunningStageReminder    DunningStage = "reminder"     // First failure; a retry is scheduled
+	DunningStageWarning     DunningStage = "warning"      // Repeated failure; more than one retry remains
+	DunningStageFinalNotice DunningStage = "final_notice" // Only the last retry remains
+	DunningStageFailed      DunningStage = "failed"       // Retries are exhausted; the booking is flagged
+	DunningStageCancelled   DunningStage = "cancelled"    // Retries are exhausted and the booking was cancelled
+)
+
+// NextBalanceRetry returns when to retry a balance after failedAttempts failed charges,
+// following retryDays counted from the first failure, and the stage the customer has
+// reached. ok is false once every retry has been used.
+func NextBalanceRetry(retryDays []int, failedAttempts int, now time.Time) (next time.Time, stage DunningStage, ok bool) {
+	if len(retryDays) == 0 {
+		retryDays = DefaultBalanceRetryDays
+	}
+	if failedAttempts < 1 {
+		failedAttempts = 1
+	}
+	if failedAttempts > len(retryDays) {
+		return time.Time{}, DunningStageFailed, false
+	}
+
+	// The previous retry ran on its own schedule day, so wait out the gap to the next one
+	days := retryDays[failedAttempts-1]
+	if failedAttempts > 1 && days > retryDays[failedAttempts-2] {
+		days -= retryDays[failedAttempts-2]
+	}
+
+	switch {
+	case failedAttempts == len(retryDays):
+		stage = DunningStageFinalNotice
+	case failedAttempts == 1:
+		stage = DunningStageReminder
+	default:
+		stage = DunningStageWarning
+	}
+	return now.AddDate(0, 0, days), stage, true
+}
+
+// BalanceDue is the amount still owed after the deposit
+func (b *Booking) BalanceDue() float64 {
+	return roundMoney(b.TotalPrice - b.DepositPaid)
+}
+
+// ScheduleBalance schedules the balance to be charged hoursBefore the start.
+// A due time already in the past is brought forward to now.
+func (b *Booking) ScheduleBalance(hoursBefore int, now time.Time) {
+	b.BalanceDueHours = hoursBefore
+	b.BalanceStatus = BalanceStatusScheduled
+	b.BalanceAttempts = 0
+	b.BalanceFailureReason = ""
+	b.rescheduleBalance(now)
+}
+
+// RescheduleBalance moves a pending balance charge after the booking's start time changed
+func (b *Booking) RescheduleBalance(now time.Time) {
+	if b.BalanceStatus == BalanceStatusScheduled {
+		b.rescheduleBalance(now)
+	}
+}
+
+func (b *Booking) rescheduleBalance(now time.Time) {
+	dueAt := b.StartTime.Add(-time.Duration(b.BalanceDueHours) * time.Hour)
+	if dueAt.Before(now) {
+		dueAt = now
+	}
+	b.BalanceDueAt = &dueAt
+}
@@ -0,0 +1,80 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingScheduleBalance(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	booking := &models.Booking{
+		StartTime:   now.Add(72 * time.Hour),
+		TotalPrice:  120,
+		DepositPaid: 30,
+	}
+
+	booking.ScheduleBalance(24, now)
+	assert.Equal(t, models.BalanceStatusScheduled, booking.BalanceStatus)
+	require.NotNil(t, booking.BalanceDueAt)
+	assert.Equal(t, now.Add(48*time.Hour), *booking.BalanceDueAt)
+	assert.Equal(t, 90.0, booking.BalanceDue())
+
+	// Moving the booking closer than the lead time makes the balance due immediately
+	booking.StartTime = now.Add(6 * time.Hour)
+	booking.RescheduleBalance(now)
+	assert.Equal(t, now, *booking.BalanceDueAt)
+}
+
+func TestBookingRescheduleBalanceIgnoresSettledBalances(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	dueAt := now.Add(-time.Hour)
+	booking := &models.Booking{
+		StartTime:     now.Add(48 * time.Hour),
+		BalanceStatus: models.BalanceStatusCharged,
+		BalanceDueAt:  &dueAt,
+	}
+
+	booking.RescheduleBalance(now)
+	assert.Equal(t, dueAt, *booking.BalanceDueAt)
+}
+
+func TestNextBalanceRetryFollowsDefaultSchedule(t *testing.T) {
+	firstFailure := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	next, stage, ok := models.NextBalanceRetry(nil, 1, firstFailure)
+	require.True(t, ok)
+	assert.Equal(t, models.DunningStageReminder, stage)
+	assert.Equal(t, firstFailure.AddDate(0, 0, 1), next)
+
+	// Retries land 3 and 7 days after the first failure
+	next, stage, ok = models.NextBalanceRetry(nil, 2, next)
+	require.True(t, ok)
+	assert.Equal(t, models.DunningStageWarning, stage)
+	assert.Equal(t, firstFailure.AddDate(0, 0, 3), next)
+
+	next, stage, ok = models.NextBalanceRetry(nil, 3, next)
+	require.True(t, ok)
+	assert.Equal(t, models.DunningStageFinalNotice, stage)
+	assert.Equal(t, firstFailure.AddDate(0, 0, 7), next)
+
+	_, stage, ok = models.NextBalanceRetry(nil, 4, next)
+	assert.False(t, ok)
+	assert.Equal(t, models.DunningStageFailed, stage)
+}
+
+func TestNextBalanceRetryUsesTenantSchedule(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	next, stage, ok := models.NextBalanceRetry([]int{2}, 1, now)
+	require.True(t, ok)
+	assert.Equal(t, models.DunningStageFinalNotice, stage)
+	assert.Equal(t, now.AddDate(0, 0, 2), next)
+
+	_, _, ok = models.NextBalanceRetry([]int{2}, 2, now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingConversation is the chat thread between the customer and artisan of a booking.
+// Messages belong to it through Message.BookingID; the conversation keeps per-participant counters.
+type BookingConversation struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+
+	// One conversation per booking
+	BookingID uuid.UUID `json:"booking_id" gorm:"type:uuid;not null;uniqueIndex"`
+
+	// Participants
+	CustomerID uuid.UUID `json:"customer_id" gorm:"type:uuid;not null;index"`
+	ArtisanID  uuid.UUID `json:"artisan_id" gorm:"type:uuid;not null;index"`
+
+	// Activity
+	LastMessageAt      *time.Time `json:"last_message_at,omitempty"`
+	LastMessagePreview string     `json:"last_message_preview,omitempty" gorm:"size:255"`
+	MessageCount       int        `json:"message_count" gorm:"default:0"`
+
+	// Unread counters per participant
+	CustomerUnreadCount int `json:"customer_unread_count" gorm:"default:0"`
+	ArtisanUnreadCount  int `json:"artisan_unread_count" gorm:"default:0"`
+
+	// Relationships
+	Booking  *Booking `json:"booking,omitempty" gorm:"foreignKey:BookingID"`
+	Customer *User    `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	Artisan  *User    `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
+}
+
+// NewBookingConversation creates the conversation for a booking
+func NewBookingConversation(booking *Booking) *BookingConversation {
+	return &BookingConversation{
+		TenantID:   booking.TenantID,
+		BookingID:  booking.ID,
+		CustomerID: booking.CustomerID,
+		ArtisanID:  booking.ArtisanID,
+	}
+}
+
+// Business Methods
+func (c *BookingConversation) IsParticipant(userID uuid.UUID) bool {
+	return userID == c.CustomerID || userID == c.ArtisanID
+}
+
+// OtherParticipant returns the counterpart of the given participant
+func (c *BookingConversation) OtherParticipant(userID uuid.UUID) uuid.UUID {
+	if userID == c.CustomerID {
+		return c.ArtisanID
+	}
+	return c.CustomerID
+}
+
+// UnreadCountFor returns the number of unread messages for a participant
+func (c *BookingConversation) UnreadCountFor(userID uuid.UUID) int {
+	switch userID {
+	case c.CustomerID:
+		return c.CustomerUnreadCount
+	case c.ArtisanID:
+		return c.ArtisanUnreadCount
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,35 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingConversation_Participants(t *testing.T) {
+	booking := &models.Booking{
+		TenantID:   uuid.New(),
+		CustomerID: uuid.New(),
+		ArtisanID:  uuid.New(),
+	}
+	booking.ID = uuid.New()
+
+	conversation := models.NewBookingConversation(booking)
+	conversation.CustomerUnreadCount = 2
+	conversation.ArtisanUnreadCount = 5
+
+	assert.Equal(t, booking.ID, conversation.BookingID)
+	assert.True(t, conversation.IsParticipant(booking.CustomerID))
+	assert.True(t, conversation.IsParticipant(booking.ArtisanID))
+	assert.False(t, conversation.IsParticipant(uuid.New()))
+
+	assert.Equal(t, booking.ArtisanID, conversation.OtherParticipant(booking.CustomerID))
+	assert.Equal(t, booking.CustomerID, conversation.OtherParticipant(booking.ArtisanID))
+
+	assert.Equal(t, 2, conversation.UnreadCountFor(booking.CustomerID))
+	assert.Equal(t, 5, conversation.UnreadCountFor(booking.ArtisanID))
+	assert.Equal(t, 0, conversation.UnreadCountFor(uuid.New()))
+}
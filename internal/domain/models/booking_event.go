@@ -0,0 +1,150 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingEventSource identifies what initiated a booking change
+type BookingEventSource string
+
+const (
+	BookingEventSourceAPI    BookingEventSource = "api"    // Customer or artisan through the API
+	BookingEventSourceAdmin  BookingEventSource = "admin"  // Tenant owner/admin or platform staff
+	BookingEventSourceSystem BookingEventSource = "system" // Background jobs and internal calls
+	BookingEventSourceImport BookingEventSource = "import" // Bulk imports
+)
+
+type BookingEventType string
+
+const (
+	BookingEventCreated        BookingEventType = "created"
+	BookingEventStatusChanged  BookingEventType = "status_changed"
+	BookingEventRescheduled    BookingEventType = "rescheduled"
+	BookingEventPriceChanged   BookingEventType = "price_changed"
+	BookingEventPaymentChanged BookingEventType = "payment_changed"
+	BookingEventUpdated        BookingEventType = "updated"
+)
+
+// BookingFieldChange holds the before and after value of a single field
+type BookingFieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// BookingFieldChanges is a custom type for handling field changes in JSONB
+type BookingFieldChanges map[string]BookingFieldChange
+
+// BookingEvent is an entry in a booking's change log
+type BookingEvent struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	BookingID uuid.UUID `json:"booking_id" gorm:"type:uuid;not null;index:idx_booking_event_booking_created"`
+
+	// Actor (nil for system changes)
+	ActorID    *uuid.UUID `json:"actor_id,omitempty" gorm:"type:uuid;index"`
+	ActorEmail string     `json:"actor_email,omitempty" gorm:"size:255"`
+	ActorRole  UserRole   `json:"actor_role,omitempty" gorm:"type:varchar(50)"`
+
+	// Change
+	Source    BookingEventSource  `json:"source" gorm:"type:varchar(20);not null"`
+	EventType BookingEventType    `json:"event_type" gorm:"type:varchar(50);not null;index"`
+	Changes   BookingFieldChanges `json:"changes" gorm:"type:jsonb"`
+	Note      string              `json:"note,omitempty" gorm:"type:text"`
+
+	// Relationships
+	Booking *Booking `json:"booking,omitempty" gorm:"foreignKey:BookingID"`
+	Actor   *User    `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+}
+
+// DiffBookings returns the audited fields that differ between two versions of a booking.
+// Only status, scheduling, pricing, payment, artisan assignment and the linked project
+// are tracked.
+func DiffBookings(before, after *Booking) BookingFieldChanges {
+	changes := make(BookingFieldChanges)
+	track := func(field string, from, to any) {
+		if from != to {
+			changes[field] = BookingFieldChange{From: from, To: to}
+		}
+	}
+
+	track("status", string(before.Status), string(after.Status))
+	track("start_time", formatEventTime(before.StartTime), formatEventTime(after.StartTime))
+	track("end_time", formatEventTime(before.EndTime), formatEventTime(after.EndTime))
+	track("duration", before.Duration, after.Duration)
+	track("base_price", before.BasePrice, after.BasePrice)
+	track("addons_price", before.AddonsPrice, after.AddonsPrice)
+	track("total_price", before.TotalPrice, after.TotalPrice)
+	track("payment_status", string(before.PaymentStatus), string(after.PaymentStatus))
+	track("deposit_paid", before.DepositPaid, after.DepositPaid)
+	track("artisan_id", before.ArtisanID.String(), after.ArtisanID.String())
+	track("project_id", formatEventID(before.ProjectID), formatEventID(after.ProjectID))
+
+	return changes
+}
+
+// EventType classifies the changes by their most significant field
+func (c BookingFieldChanges) EventType() BookingEventType {
+	has := func(fields ...string) bool {
+		for _, f := range fields {
+			if _, ok := c[f]; ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case has("status"):
+		return BookingEventStatusChanged
+	case has("start_time", "end_time", "duration"):
+		return BookingEventRescheduled
+	case has("base_price", "addons_price", "total_price"):
+		return BookingEventPriceChanged
+	case has("payment_status", "deposit_paid"):
+		return BookingEventPaymentChanged
+	default:
+		return BookingEventUpdated
+	}
+}
+
+func formatEventTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatEventID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func (c *BookingFieldChanges) Scan(value interface{}) error {
+	if value == nil {
+		*c = BookingFieldChanges{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+func (c BookingFieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return json.Marshal(map[string]BookingFieldChange{})
+	}
+	return json.Marshal(c)
+}
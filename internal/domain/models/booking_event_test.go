@@ -0,0 +1,64 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffBookings(t *testing.T) {
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	before := &models.Booking{
+		Status:     models.BookingStatusPending,
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+		Duration:   60,
+		TotalPrice: 100,
+	}
+
+	after := *before
+	assert.Empty(t, models.DiffBookings(before, &after))
+
+	after.StartTime = start.Add(24 * time.Hour)
+	after.EndTime = after.StartTime.Add(time.Hour)
+	changes := models.DiffBookings(before, &after)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "2025-06-01T09:00:00Z", changes["start_time"].From)
+	assert.Equal(t, "2025-06-02T09:00:00Z", changes["start_time"].To)
+	assert.Equal(t, models.BookingEventRescheduled, changes.EventType())
+
+	after.Status = models.BookingStatusConfirmed
+	after.TotalPrice = 120
+	changes = models.DiffBookings(before, &after)
+	assert.Equal(t, models.BookingEventStatusChanged, changes.EventType())
+	assert.Equal(t, models.BookingFieldChange{From: 100.0, To: 120.0}, changes["total_price"])
+
+	projectID := uuid.New()
+	linked := *before
+	linked.ProjectID = &projectID
+	changes = models.DiffBookings(before, &linked)
+	assert.Equal(t, models.BookingFieldChange{From: "", To: projectID.String()}, changes["project_id"])
+	assert.Equal(t, models.BookingEventUpdated, changes.EventType())
+}
+
+func TestBookingFieldChanges_EventType(t *testing.T) {
+	tests := []struct {
+		field string
+		want  models.BookingEventType
+	}{
+		{"status", models.BookingEventStatusChanged},
+		{"duration", models.BookingEventRescheduled},
+		{"addons_price", models.BookingEventPriceChanged},
+		{"deposit_paid", models.BookingEventPaymentChanged},
+		{"artisan_id", models.BookingEventUpdated},
+	}
+
+	for _, tc := range tests {
+		changes := models.BookingFieldChanges{tc.field: {}}
+		assert.Equal(t, tc.want, changes.EventType(), tc.field)
+	}
+}
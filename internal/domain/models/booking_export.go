@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingExportStatus string
+
+const (
+	BookingExportStatusPending    BookingExportStatus = "pending"
+	BookingExportStatusProcessing BookingExportStatus = "processing"
+	BookingExportStatusCompleted  BookingExportStatus = "completed"
+	BookingExportStatusFailed     BookingExportStatus = "failed"
+)
+
+type BookingExportFormat string
+
+const (
+	BookingExportFormatCSV  BookingExportFormat = "csv"
+	BookingExportFormatXLSX BookingExportFormat = "xlsx"
+)
+
+// IsValid reports whether the format is supported
+func (f BookingExportFormat) IsValid() bool {
+	return f == BookingExportFormatCSV || f == BookingExportFormatXLSX
+}
+
+// ContentType returns the MIME type of files in this format
+func (f BookingExportFormat) ContentType() string {
+	if f == BookingExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+// BookingExport tracks a bookings export file generated for download
+type BookingExport struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+
+	// Requested By
+	RequestedByID uuid.UUID `json:"requested_by_id" gorm:"type:uuid;not null;index" validate:"required"`
+
+	// Export Definition
+	Format  BookingExportFormat `json:"format" gorm:"type:varchar(10);not null;default:'csv'"`
+	Filters JSONB               `json:"filters,omitempty" gorm:"type:jsonb"` // The BookingFilter the export was requested with
+
+	// Processing
+	Status       BookingExportStatus `json:"status" gorm:"type:varchar(50);not null;default:'pending';index"`
+	Async        bool                `json:"async" gorm:"default:false"` // Generated in the background; the requester is emailed when ready
+	StartedAt    *time.Time          `json:"started_at,omitempty"`
+	CompletedAt  *time.Time          `json:"completed_at,omitempty"`
+	ErrorMessage string              `json:"error_message,omitempty" gorm:"type:text"`
+
+	// Result
+	RowCount  int        `json:"row_count" gorm:"default:0"`
+	FileKey   string     `json:"-" gorm:"size:512"`
+	FileSize  int64      `json:"file_size" gorm:"default:0"` // bytes
+	ExpiresAt *time.Time `json:"expires_at,omitempty" gorm:"index"`
+
+	// Relationships
+	Tenant      *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+	RequestedBy *User   `json:"requested_by,omitempty" gorm:"foreignKey:RequestedByID"`
+}
+
+// Business Methods
+func (e *BookingExport) IsFinished() bool {
+	return e.Status == BookingExportStatusCompleted || e.Status == BookingExportStatusFailed
+}
+
+// IsDownloadable reports whether the generated file can still be downloaded
+func (e *BookingExport) IsDownloadable() bool {
+	return e.Status == BookingExportStatusCompleted && e.FileKey != "" &&
+		(e.ExpiresAt == nil || time.Now().Before(*e.ExpiresAt))
+}
+
+// FileName returns the name offered to the browser when downloading the export
+func (e *BookingExport) FileName() string {
+	return "bookings-" + e.CreatedAt.UTC().Format("20060102-150405") + "." + string(e.Format)
+}
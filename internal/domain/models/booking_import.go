@@ -0,0 +1,99 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingImportStatus string
+
+const (
+	BookingImportStatusPending    BookingImportStatus = "pending"
+	BookingImportStatusProcessing BookingImportStatus = "processing"
+	BookingImportStatusCompleted  BookingImportStatus = "completed"
+	BookingImportStatusFailed     BookingImportStatus = "failed"
+)
+
+// MaxBookingImportRowErrors caps the validation report stored on an import
+const MaxBookingImportRowErrors = 1000
+
+// BookingImportRowError describes why a single CSV row was rejected
+type BookingImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// BookingImportRowErrorArray is a custom type for handling []BookingImportRowError in JSONB
+type BookingImportRowErrorArray []BookingImportRowError
+
+// BookingImport tracks a bulk booking import and its row-level validation report
+type BookingImport struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+
+	// Uploaded By
+	UploadedByID uuid.UUID `json:"uploaded_by_id" gorm:"type:uuid;not null;index" validate:"required"`
+
+	// File Details
+	FileName string `json:"file_name" gorm:"not null;size:255" validate:"required"`
+	FileSize int64  `json:"file_size" gorm:"not null"` // bytes
+
+	// Processing
+	Status       BookingImportStatus `json:"status" gorm:"type:varchar(50);not null;default:'pending';index"`
+	DryRun       bool                `json:"dry_run" gorm:"default:false"`
+	Timezone     string              `json:"timezone" gorm:"size:64;default:'UTC'"`
+	StartedAt    *time.Time          `json:"started_at,omitempty"`
+	CompletedAt  *time.Time          `json:"completed_at,omitempty"`
+	ErrorMessage string              `json:"error_message,omitempty" gorm:"type:text"`
+
+	// Results
+	TotalRows    int                        `json:"total_rows" gorm:"default:0"`
+	ValidRows    int                        `json:"valid_rows" gorm:"default:0"`
+	ImportedRows int                        `json:"imported_rows" gorm:"default:0"`
+	FailedRows   int                        `json:"failed_rows" gorm:"default:0"`
+	RowErrors    BookingImportRowErrorArray `json:"row_errors,omitempty" gorm:"type:jsonb"`
+
+	// Relationships
+	Tenant     *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+	UploadedBy *User   `json:"uploaded_by,omitempty" gorm:"foreignKey:UploadedByID"`
+}
+
+// Business Methods
+func (i *BookingImport) IsFinished() bool {
+	return i.Status == BookingImportStatusCompleted || i.Status == BookingImportStatusFailed
+}
+
+// AddRowError records a rejected row, keeping at most MaxBookingImportRowErrors entries
+func (i *BookingImport) AddRowError(rowErr BookingImportRowError) {
+	if len(i.RowErrors) < MaxBookingImportRowErrors {
+		i.RowErrors = append(i.RowErrors, rowErr)
+	}
+}
+
+func (a *BookingImportRowErrorArray) Scan(value interface{}) error {
+	if value == nil {
+		*a = BookingImportRowErrorArray{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, a)
+}
+
+func (a BookingImportRowErrorArray) Value() (driver.Value, error) {
+	if a == nil {
+		return json.Marshal([]BookingImportRowError{})
+	}
+	return json.Marshal(a)
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurrenceExceptionType describes how one occurrence departs from its series
+type RecurrenceExceptionType string
+
+const (
+	RecurrenceExceptionSkipped RecurrenceExceptionType = "skipped" // Occurrence cancelled; the date stays empty
+	RecurrenceExceptionMoved   RecurrenceExceptionType = "moved"   // Occurrence rescheduled and detached from series edits
+)
+
+// RecurrenceException records a single occurrence detached from its series, keyed by
+// the start time the series originally generated for it
+type RecurrenceException struct {
+	OriginalStart time.Time               `json:"original_start"`
+	Type          RecurrenceExceptionType `json:"type"`
+	BookingID     uuid.UUID               `json:"booking_id"`
+	NewStart      *time.Time              `json:"new_start,omitempty"` // Moved occurrences only
+	Reason        string                  `json:"reason,omitempty"`
+	CreatedBy     *uuid.UUID              `json:"created_by,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+}
+
+// RecurrenceExceptions is a custom type for handling []RecurrenceException in JSONB
+type RecurrenceExceptions []RecurrenceException
+
+// Find returns the exception recorded for the occurrence originally starting at start
+func (e RecurrenceExceptions) Find(start time.Time) (RecurrenceException, bool) {
+	for _, exception := range e {
+		if exception.OriginalStart.Equal(start) {
+			return exception, true
+		}
+	}
+	return RecurrenceException{}, false
+}
+
+func (e *RecurrenceExceptions) Scan(value interface{}) error {
+	if value == nil {
+		*e = RecurrenceExceptions{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, e)
+}
+
+func (e RecurrenceExceptions) Value() (driver.Value, error) {
+	if e == nil {
+		return json.Marshal([]RecurrenceException{})
+	}
+	return json.Marshal(e)
+}
+
+// SeriesID returns the ID of the booking that roots the recurring series
+func (b *Booking) SeriesID() uuid.UUID {
+	if b.ParentBookingID != nil {
+		return *b.ParentBookingID
+	}
+	return b.ID
+}
+
+// IsSeriesOccurrence reports whether the booking belongs to a recurring series
+func (b *Booking) IsSeriesOccurrence() bool {
+	return b.IsRecurring || b.ParentBookingID != nil
+}
+
+// OccurrenceStart returns the start time the series generated for this occurrence,
+// which stays fixed when the occurrence is moved
+func (b *Booking) OccurrenceStart() time.Time {
+	if b.OriginalStartTime != nil {
+		return *b.OriginalStartTime
+	}
+	return b.StartTime
+}
+
+// AddRecurrenceException records an exception on the series root, replacing any earlier
+// exception for the same occurrence (e.g. a moved occurrence that is later skipped)
+func (b *Booking) AddRecurrenceException(exception RecurrenceException) {
+	for i, existing := range b.RecurrenceExceptions {
+		if existing.OriginalStart.Equal(exception.OriginalStart) {
+			b.RecurrenceExceptions[i] = exception
+			return
+		}
+	}
+	b.RecurrenceExceptions = append(b.RecurrenceExceptions, exception)
+}
@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingAddRecurrenceException(t *testing.T) {
+	start := time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC)
+	root := &models.Booking{IsRecurring: true}
+	root.ID = uuid.New()
+
+	moved := start.Add(2 * time.Hour)
+	root.AddRecurrenceException(models.RecurrenceException{OriginalStart: start, Type: models.RecurrenceExceptionMoved, NewStart: &moved})
+	root.AddRecurrenceException(models.RecurrenceException{OriginalStart: start.AddDate(0, 0, 7), Type: models.RecurrenceExceptionSkipped})
+
+	// Skipping the moved occurrence replaces its exception
+	root.AddRecurrenceException(models.RecurrenceException{OriginalStart: start.In(time.FixedZone("CET", 3600)), Type: models.RecurrenceExceptionSkipped})
+	require.Len(t, root.RecurrenceExceptions, 2)
+
+	exception, ok := root.RecurrenceExceptions.Find(start)
+	require.True(t, ok)
+	assert.Equal(t, models.RecurrenceExceptionSkipped, exception.Type)
+
+	_, ok = root.RecurrenceExceptions.Find(start.AddDate(0, 0, 14))
+	assert.False(t, ok)
+}
+
+func TestBookingOccurrenceStart(t *testing.T) {
+	start := time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC)
+	parentID := uuid.New()
+	occurrence := &models.Booking{StartTime: start, ParentBookingID: &parentID}
+
+	assert.Equal(t, parentID, occurrence.SeriesID())
+	assert.True(t, occurrence.IsSeriesOccurrence())
+	assert.Equal(t, start, occurrence.OccurrenceStart())
+
+	occurrence.OriginalStartTime = &start
+	occurrence.StartTime = start.Add(24 * time.Hour)
+	assert.Equal(t, start, occurrence.OccurrenceStart())
+}
@@ -0,0 +1,39 @@
+package models
+
+import "math"
+
+// RefundStatus tracks the refund a cancellation is owed under the tenant's cancellation policy
+type RefundStatus string
+
+const (
+	RefundStatusPending  RefundStatus = "pending"  // The cancellation is saved; the refund is not issued yet
+	RefundStatusRefunded RefundStatus = "refunded" // Issued in full
+	RefundStatusFailed   RefundStatus = "failed"   // Part or all of it could not be issued; awaiting a retry
+)
+
+// RefundOutstanding is the part of the cancellation refund not issued yet
+func (b *Booking) RefundOutstanding() float64 {
+	return roundMoney(math.Max(b.RefundAmount-b.RefundIssued, 0))
+}
+
+// RecordRefundAttempt adds the amount an attempt to issue the cancellation refund
+// returned to the customer. The refund is done once nothing is outstanding, and failed
+// with the attempt's error, or the shortfall, otherwise.
+func (b *Booking) RecordRefundAttempt(issued float64, err error) {
+	b.RefundIssued = roundMoney(b.RefundIssued + issued)
+	switch {
+	case err != nil:
+		b.RefundStatus = RefundStatusFailed
+		b.RefundFailureReason = err.Error()
+	case b.RefundOutstanding() > 0:
+		b.RefundStatus = RefundStatusFailed
+		b.RefundFailureReason = "refundable payments cover less than the refund owed"
+	default:
+		if b.RefundIssued >= b.AmountPaid() {
+			b.PaymentStatus = PaymentStatusRefunded
+		} else {
+			b.PaymentStatus = PaymentStatusPartialRefund
+		}
+		b.RefundStatus = RefundStatusRefunded
+	}
+}
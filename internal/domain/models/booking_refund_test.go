@@ -0,0 +1,50 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingRecordRefundAttempt(t *testing.T) {
+	booking := &models.Booking{
+		TotalPrice:    100,
+		PaymentStatus: models.PaymentStatusPaid,
+		RefundStatus:  models.RefundStatusPending,
+		RefundAmount:  100,
+	}
+
+	// A failure part way through keeps what was issued and leaves the rest outstanding
+	booking.RecordRefundAttempt(40, errors.New("provider unavailable"))
+	assert.Equal(t, models.RefundStatusFailed, booking.RefundStatus)
+	assert.Equal(t, "provider unavailable", booking.RefundFailureReason)
+	assert.Equal(t, 60.0, booking.RefundOutstanding())
+	assert.Equal(t, models.PaymentStatusPaid, booking.PaymentStatus)
+
+	// The retry issues the rest
+	booking.RecordRefundAttempt(60, nil)
+	assert.Equal(t, models.RefundStatusRefunded, booking.RefundStatus)
+	assert.Zero(t, booking.RefundOutstanding())
+	assert.Equal(t, models.PaymentStatusRefunded, booking.PaymentStatus)
+}
+
+func TestBookingRecordRefundAttemptShortfall(t *testing.T) {
+	booking := &models.Booking{
+		TotalPrice:    100,
+		DepositPaid:   50,
+		PaymentStatus: models.PaymentStatusPending,
+		RefundStatus:  models.RefundStatusPending,
+		RefundAmount:  50,
+	}
+
+	booking.RecordRefundAttempt(30, nil)
+	assert.Equal(t, models.RefundStatusFailed, booking.RefundStatus)
+	assert.Equal(t, 20.0, booking.RefundOutstanding())
+
+	booking.RecordRefundAttempt(20, nil)
+	assert.Equal(t, models.RefundStatusRefunded, booking.RefundStatus)
+	assert.Equal(t, models.PaymentStatusRefunded, booking.PaymentStatus)
+}
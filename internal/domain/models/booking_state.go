@@ -0,0 +1,121 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+)
+
+// BookingTransitionPolicy holds tenant-configurable rules for booking status changes
+type BookingTransitionPolicy struct {
+	// RequireDepositBeforeConfirm blocks pending → confirmed until a deposit or full payment is recorded
+	RequireDepositBeforeConfirm bool `json:"require_deposit_before_confirm"`
+	// AllowRefundAfterCompletion enables the completed → refunded transition
+	AllowRefundAfterCompletion bool `json:"allow_refund_after_completion"`
+	// AllowCancelInProgress permits cancelling a booking that has already started
+	AllowCancelInProgress bool `json:"allow_cancel_in_progress"`
+}
+
+// DefaultBookingTransitionPolicy returns the policy applied when a tenant has not configured one
+func DefaultBookingTransitionPolicy() BookingTransitionPolicy {
+	return BookingTransitionPolicy{
+		RequireDepositBeforeConfirm: false,
+		AllowRefundAfterCompletion:  false,
+		AllowCancelInProgress:       true,
+	}
+}
+
+// TransitionError describes a rejected booking status transition
+type TransitionError struct {
+	From   BookingStatus
+	To     BookingStatus
+	Reason string
+}
+
+func (e *TransitionError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("cannot transition from %s to %s: %s", e.From, e.To, e.Reason)
+	}
+	return fmt.Sprintf("cannot transition from %s to %s", e.From, e.To)
+}
+
+// baseBookingTransitions lists transitions allowed regardless of tenant policy
+var baseBookingTransitions = map[BookingStatus][]BookingStatus{
+	BookingStatusPending: {
+		BookingStatusConfirmed,
+		BookingStatusCancelled,
+	},
+	BookingStatusConfirmed: {
+		BookingStatusInProgress,
+		BookingStatusCancelled,
+		BookingStatusNoShow,
+	},
+	BookingStatusInProgress: {
+		BookingStatusCompleted,
+	},
+	BookingStatusCompleted: {}, // Final state unless refunds are enabled
+	BookingStatusCancelled: {}, // Final state
+	BookingStatusNoShow:    {}, // Final state
+	BookingStatusRefunded:  {}, // Final state
+}
+
+// BookingStateMachine validates booking status transitions under a tenant policy
+type BookingStateMachine struct {
+	transitions map[BookingStatus][]BookingStatus
+	policy      BookingTransitionPolicy
+}
+
+// NewBookingStateMachine builds the transition table for the given policy
+func NewBookingStateMachine(policy BookingTransitionPolicy) *BookingStateMachine {
+	transitions := make(map[BookingStatus][]BookingStatus, len(baseBookingTransitions))
+	for from, to := range baseBookingTransitions {
+		transitions[from] = slices.Clone(to)
+	}
+
+	if policy.AllowCancelInProgress {
+		transitions[BookingStatusInProgress] = append(transitions[BookingStatusInProgress], BookingStatusCancelled)
+	}
+	if policy.AllowRefundAfterCompletion {
+		transitions[BookingStatusCompleted] = append(transitions[BookingStatusCompleted], BookingStatusRefunded)
+	}
+
+	return &BookingStateMachine{
+		transitions: transitions,
+		policy:      policy,
+	}
+}
+
+// Policy returns the policy the state machine was built with
+func (m *BookingStateMachine) Policy() BookingTransitionPolicy {
+	return m.policy
+}
+
+// AllowedTransitions returns the statuses reachable from the given status
+func (m *BookingStateMachine) AllowedTransitions(from BookingStatus) []BookingStatus {
+	return slices.Clone(m.transitions[from])
+}
+
+// CanTransition reports whether the transition is permitted by the table, ignoring guards
+func (m *BookingStateMachine) CanTransition(from, to BookingStatus) bool {
+	return slices.Contains(m.transitions[from], to)
+}
+
+// Validate checks that the booking may move to the target status, including policy guards
+func (m *BookingStateMachine) Validate(booking *Booking, to BookingStatus) error {
+	from := booking.Status
+	if !m.CanTransition(from, to) {
+		return &TransitionError{From: from, To: to}
+	}
+
+	switch to {
+	case BookingStatusConfirmed:
+		if m.policy.RequireDepositBeforeConfirm && !booking.HasPaymentTowardsTotal() {
+			return &TransitionError{From: from, To: to, Reason: "a deposit is required before confirmation"}
+		}
+	case BookingStatusRefunded:
+		if booking.PaymentStatus != PaymentStatusPaid && booking.PaymentStatus != PaymentStatusPartialRefund {
+			return &TransitionError{From: from, To: to, Reason: "booking has no captured payment to refund"}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingStateMachine_DefaultPolicy(t *testing.T) {
+	sm := models.NewBookingStateMachine(models.DefaultBookingTransitionPolicy())
+
+	valid := []struct{ from, to models.BookingStatus }{
+		{models.BookingStatusPending, models.BookingStatusConfirmed},
+		{models.BookingStatusPending, models.BookingStatusCancelled},
+		{models.BookingStatusConfirmed, models.BookingStatusInProgress},
+		{models.BookingStatusConfirmed, models.BookingStatusNoShow},
+		{models.BookingStatusInProgress, models.BookingStatusCompleted},
+		{models.BookingStatusInProgress, models.BookingStatusCancelled},
+	}
+	for _, tc := range valid {
+		booking := &models.Booking{Status: tc.from}
+		assert.NoError(t, sm.Validate(booking, tc.to), "%s -> %s", tc.from, tc.to)
+	}
+
+	invalid := []struct{ from, to models.BookingStatus }{
+		{models.BookingStatusPending, models.BookingStatusCompleted},
+		{models.BookingStatusCompleted, models.BookingStatusRefunded},
+		{models.BookingStatusCancelled, models.BookingStatusConfirmed},
+		{models.BookingStatusNoShow, models.BookingStatusConfirmed},
+	}
+	for _, tc := range invalid {
+		booking := &models.Booking{Status: tc.from}
+		err := sm.Validate(booking, tc.to)
+		var transitionErr *models.TransitionError
+		assert.ErrorAs(t, err, &transitionErr, "%s -> %s", tc.from, tc.to)
+	}
+}
+
+func TestBookingStateMachine_RequireDepositBeforeConfirm(t *testing.T) {
+	sm := models.NewBookingStateMachine(models.BookingTransitionPolicy{RequireDepositBeforeConfirm: true})
+
+	assert.Error(t, sm.Validate(&models.Booking{Status: models.BookingStatusPending}, models.BookingStatusConfirmed))
+	assert.NoError(t, sm.Validate(&models.Booking{Status: models.BookingStatusPending, DepositPaid: 25}, models.BookingStatusConfirmed))
+	assert.NoError(t, sm.Validate(&models.Booking{Status: models.BookingStatusPending, PaymentStatus: models.PaymentStatusPaid}, models.BookingStatusConfirmed))
+}
+
+func TestBookingStateMachine_AllowRefundAfterCompletion(t *testing.T) {
+	sm := models.NewBookingStateMachine(models.BookingTransitionPolicy{AllowRefundAfterCompletion: true})
+
+	assert.Contains(t, sm.AllowedTransitions(models.BookingStatusCompleted), models.BookingStatusRefunded)
+	assert.False(t, sm.CanTransition(models.BookingStatusInProgress, models.BookingStatusCancelled))
+
+	paid := &models.Booking{Status: models.BookingStatusCompleted, PaymentStatus: models.PaymentStatusPaid}
+	assert.NoError(t, sm.Validate(paid, models.BookingStatusRefunded))
+
+	unpaid := &models.Booking{Status: models.BookingStatusCompleted, PaymentStatus: models.PaymentStatusPending}
+	assert.Error(t, sm.Validate(unpaid, models.BookingStatusRefunded))
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxBookingTags is the number of tags a booking can carry
+	MaxBookingTags = 20
+	// MaxBookingTagLength is the longest tag accepted, in characters
+	MaxBookingTagLength = 50
+)
+
+// NormalizeBookingTags trims, lowercases and de-duplicates tags, keeping their order,
+// so "VIP" and " vip" filter as the same label
+func NormalizeBookingTags(tags []string) (StringArray, error) {
+	normalized := make(StringArray, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if len([]rune(tag)) > MaxBookingTagLength {
+			return nil, fmt.Errorf("tag %q exceeds %d characters", tag, MaxBookingTagLength)
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxBookingTags {
+		return nil, fmt.Errorf("a booking can have at most %d tags", MaxBookingTags)
+	}
+	return normalized, nil
+}
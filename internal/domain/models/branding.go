@@ -0,0 +1,247 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PlatformName and PlatformEmailAddress brand what tenants haven't branded
+	// themselves, and send the emails of tenants without a verified sender domain
+	PlatformName         = "Krafti Vibe"
+	PlatformEmailAddress = "notifications@kraftivibe.com"
+	// DefaultBrandColor is the primary color of tenants that haven't chosen one
+	DefaultBrandColor = "#3B82F6"
+	// MaxEmailFooterLength caps the custom footer of notification emails
+	MaxEmailFooterLength = 1000
+)
+
+// BrandingAsset is an image a tenant uploads to brand its pages and emails
+type BrandingAsset string
+
+const (
+	BrandingAssetLogo      BrandingAsset = "logo"
+	BrandingAssetLogoDark  BrandingAsset = "logo_dark" // For dark mode
+	BrandingAssetFavicon   BrandingAsset = "favicon"
+	BrandingAssetEmailLogo BrandingAsset = "email_logo" // Header of notification emails
+)
+
+// IsValid checks if the asset is one of the supported assets
+func (a BrandingAsset) IsValid() bool {
+	switch a {
+	case BrandingAssetLogo, BrandingAssetLogoDark, BrandingAssetFavicon, BrandingAssetEmailLogo:
+		return true
+	}
+	return false
+}
+
+// AssetURL returns the URL of the white label's asset
+func (w *WhiteLabel) AssetURL(asset BrandingAsset) string {
+	switch asset {
+	case BrandingAssetLogo:
+		return w.LogoURL
+	case BrandingAssetLogoDark:
+		return w.LogoDarkURL
+	case BrandingAssetFavicon:
+		return w.FaviconURL
+	case BrandingAssetEmailLogo:
+		return w.EmailSettings.LogoURL
+	}
+	return ""
+}
+
+// SetAssetURL points the white label's asset at url
+func (w *WhiteLabel) SetAssetURL(asset BrandingAsset, url string) {
+	switch asset {
+	case BrandingAssetLogo:
+		w.LogoURL = url
+	case BrandingAssetLogoDark:
+		w.LogoDarkURL = url
+	case BrandingAssetFavicon:
+		w.FaviconURL = url
+	case BrandingAssetEmailLogo:
+		w.EmailSettings.LogoURL = url
+	}
+}
+
+// NormalizeEmailDomain lowercases an email sender domain and drops its trailing dot,
+// checking it is a domain name of at least two labels
+func NormalizeEmailDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if len(domain) > 253 || !strings.Contains(domain, ".") {
+		return "", errors.New("email domain must be a domain name such as mail.example.com")
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return "", errors.New("email domain must be a domain name such as mail.example.com")
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return "", errors.New("email domain must be a domain name such as mail.example.com")
+			}
+		}
+	}
+	return domain, nil
+}
+
+// SetEmailSenderDomain starts sending from domain once it publishes the DKIM public
+// key of selector, undoing the verification of any previous domain or key
+func (w *WhiteLabel) SetEmailSenderDomain(domain, selector, publicKey, privateKey string) {
+	w.EmailSenderDomain = domain
+	w.DKIMSelector = selector
+	w.DKIMPublicKey = publicKey
+	w.DKIMPrivateKey = privateKey
+	w.EmailDomainVerifiedAt = nil
+}
+
+// ClearEmailSenderDomain goes back to sending from the platform's address
+func (w *WhiteLabel) ClearEmailSenderDomain() {
+	w.SetEmailSenderDomain("", "", "", "")
+}
+
+// EmailDomainVerified reports whether emails can be sent from the sender domain
+func (w *WhiteLabel) EmailDomainVerified() bool {
+	return w.EmailSenderDomain != "" && w.DKIMPublicKey != "" && w.EmailDomainVerifiedAt != nil
+}
+
+// MarkEmailDomainVerified records that the sender domain publishes its DKIM key
+func (w *WhiteLabel) MarkEmailDomainVerified(now time.Time) {
+	w.EmailDomainVerifiedAt = &now
+}
+
+// DKIMRecordName is the name of the TXT record publishing the DKIM key
+func (w *WhiteLabel) DKIMRecordName() string {
+	return w.DKIMSelector + "._domainkey." + w.EmailSenderDomain
+}
+
+// DKIMRecordValue is the value of the TXT record publishing the DKIM key
+func (w *WhiteLabel) DKIMRecordValue() string {
+	return "v=DKIM1; k=rsa; p=" + w.DKIMPublicKey
+}
+
+// MatchesDKIMRecord reports whether a TXT record publishes the DKIM key. Tags may come
+// in any order and the key may be broken up by whitespace, as DNS providers split
+// long values.
+func (w *WhiteLabel) MatchesDKIMRecord(record string) bool {
+	if w.DKIMPublicKey == "" {
+		return false
+	}
+	for _, tag := range strings.Split(record, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(tag), "=")
+		if ok && strings.TrimSpace(name) == "p" {
+			return strings.Join(strings.Fields(value), "") == w.DKIMPublicKey
+		}
+	}
+	return false
+}
+
+// Branding is how a tenant's booking pages and notification emails look: its active
+// white label where it set one, its own name, logo and color otherwise, and the
+// platform's for the rest
+type Branding struct {
+	TenantID          uuid.UUID
+	Name              string
+	LogoURL           string
+	LogoDarkURL       string
+	FaviconURL        string
+	PrimaryColor      string
+	SecondaryColor    string
+	AccentColor       string
+	FontFamily        string
+	SupportEmail      string
+	SupportPhone      string
+	SupportURL        string
+	TermsOfServiceURL string
+	PrivacyPolicyURL  string
+	FooterText        string // Custom footer of notification emails
+	ShowPoweredBy     bool
+	Email             EmailIdentity
+}
+
+// EmailIdentity is who a tenant's notification emails come from and how they look
+type EmailIdentity struct {
+	FromName        string
+	FromAddress     string
+	ReplyTo         string
+	DKIMDomain      string // Domain the emails are signed for; empty when sent from the platform's
+	DKIMSelector    string
+	LogoURL         string
+	HeaderColor     string
+	ButtonColor     string
+	ButtonTextColor string
+}
+
+// ResolveBranding works out a tenant's branding from its settings and white label,
+// which may be nil. Hiding the powered-by line takes the white labeling feature, and
+// emails are only sent from the tenant's address once its domain is verified.
+func ResolveBranding(tenant *Tenant, whitelabel *WhiteLabel) Branding {
+	branding := Branding{
+		TenantID:      tenant.ID,
+		Name:          firstNonBlank(tenant.BusinessName, tenant.Name, PlatformName),
+		LogoURL:       tenant.LogoURL,
+		PrimaryColor:  firstNonBlank(tenant.PrimaryColor, DefaultBrandColor),
+		SupportEmail:  tenant.BusinessEmail,
+		SupportPhone:  tenant.BusinessPhone,
+		FooterText:    tenant.Settings.CustomEmailFooter,
+		ShowPoweredBy: tenant.Settings.ShowPoweredBy || !tenant.Features.WhiteLabeling,
+	}
+	branding.Email = EmailIdentity{
+		FromName:    branding.Name,
+		FromAddress: PlatformEmailAddress,
+	}
+
+	if whitelabel != nil && whitelabel.IsActive {
+		branding.Name = firstNonBlank(whitelabel.CompanyName, branding.Name)
+		branding.LogoURL = firstNonBlank(whitelabel.LogoURL, branding.LogoURL)
+		branding.LogoDarkURL = whitelabel.LogoDarkURL
+		branding.FaviconURL = whitelabel.FaviconURL
+		branding.PrimaryColor = firstNonBlank(whitelabel.PrimaryColor, branding.PrimaryColor)
+		branding.SecondaryColor = whitelabel.SecondaryColor
+		branding.AccentColor = whitelabel.AccentColor
+		branding.FontFamily = whitelabel.FontFamily
+		branding.SupportEmail = firstNonBlank(whitelabel.SupportEmail, branding.SupportEmail)
+		branding.SupportPhone = firstNonBlank(whitelabel.SupportPhone, branding.SupportPhone)
+		branding.SupportURL = whitelabel.SupportURL
+		branding.TermsOfServiceURL = whitelabel.TermsOfServiceURL
+		branding.PrivacyPolicyURL = whitelabel.PrivacyPolicyURL
+		branding.FooterText = firstNonBlank(whitelabel.EmailSettings.FooterText, branding.FooterText)
+		if whitelabel.HidePoweredBy && tenant.Features.WhiteLabeling {
+			branding.ShowPoweredBy = false
+		}
+
+		settings := whitelabel.EmailSettings
+		branding.Email.FromName = firstNonBlank(settings.FromName, branding.Name)
+		branding.Email.ReplyTo = settings.ReplyToEmail
+		branding.Email.LogoURL = settings.LogoURL
+		branding.Email.HeaderColor = settings.HeaderColor
+		branding.Email.ButtonColor = settings.ButtonColor
+		branding.Email.ButtonTextColor = settings.ButtonTextColor
+		if _, domain, ok := strings.Cut(settings.FromEmail, "@"); ok && whitelabel.EmailDomainVerified() &&
+			strings.EqualFold(domain, whitelabel.EmailSenderDomain) {
+			branding.Email.FromAddress = settings.FromEmail
+			branding.Email.DKIMDomain = whitelabel.EmailSenderDomain
+			branding.Email.DKIMSelector = whitelabel.DKIMSelector
+		}
+	}
+
+	// Replies to the platform's address go to the tenant
+	branding.Email.ReplyTo = firstNonBlank(branding.Email.ReplyTo, branding.SupportEmail)
+	branding.Email.LogoURL = firstNonBlank(branding.Email.LogoURL, branding.LogoURL)
+	branding.Email.HeaderColor = firstNonBlank(branding.Email.HeaderColor, branding.PrimaryColor)
+	branding.Email.ButtonColor = firstNonBlank(branding.Email.ButtonColor, branding.PrimaryColor)
+	branding.Email.ButtonTextColor = firstNonBlank(branding.Email.ButtonTextColor, "#FFFFFF")
+	return branding
+}
+
+// firstNonBlank returns the first value that isn't blank
+func firstNonBlank(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
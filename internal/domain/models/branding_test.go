@@ -0,0 +1,128 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeEmailDomain(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		want   string
+		valid  bool
+	}{
+		{"domain", "mail.example.com", "mail.example.com", true},
+		{"mixed case and trailing dot", " Example.COM. ", "example.com", true},
+		{"single label", "localhost", "", false},
+		{"empty label", "mail..example.com", "", false},
+		{"leading hyphen", "-mail.example.com", "", false},
+		{"underscore", "mail_1.example.com", "", false},
+		{"address", "hello@example.com", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain, err := models.NormalizeEmailDomain(tt.domain)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, domain)
+		})
+	}
+}
+
+func TestWhiteLabel_DKIMRecord(t *testing.T) {
+	whitelabel := &models.WhiteLabel{}
+	whitelabel.SetEmailSenderDomain("example.com", "kv202506", "MIIBIjANBg", "private")
+
+	assert.Equal(t, "kv202506._domainkey.example.com", whitelabel.DKIMRecordName())
+	assert.Equal(t, "v=DKIM1; k=rsa; p=MIIBIjANBg", whitelabel.DKIMRecordValue())
+	assert.True(t, whitelabel.MatchesDKIMRecord(whitelabel.DKIMRecordValue()))
+	assert.True(t, whitelabel.MatchesDKIMRecord("k=rsa;v=DKIM1;p=MIIB IjANBg"), "tag order and split keys")
+	assert.False(t, whitelabel.MatchesDKIMRecord("v=DKIM1; k=rsa; p=other"))
+	assert.False(t, whitelabel.MatchesDKIMRecord("v=spf1 -all"))
+
+	assert.False(t, whitelabel.EmailDomainVerified())
+	whitelabel.MarkEmailDomainVerified(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	assert.True(t, whitelabel.EmailDomainVerified())
+
+	// A new key has to be published again
+	whitelabel.SetEmailSenderDomain("example.com", "kv202507", "MIIBother", "private")
+	assert.False(t, whitelabel.EmailDomainVerified())
+}
+
+func TestResolveBranding(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tenant := &models.Tenant{
+		Name:          "acme",
+		BusinessName:  "Acme Plumbing",
+		BusinessEmail: "office@acme.test",
+		LogoURL:       "https://cdn.test/acme.png",
+	}
+
+	t.Run("tenant only", func(t *testing.T) {
+		branding := models.ResolveBranding(tenant, nil)
+		assert.Equal(t, "Acme Plumbing", branding.Name)
+		assert.Equal(t, models.DefaultBrandColor, branding.PrimaryColor)
+		assert.True(t, branding.ShowPoweredBy)
+		assert.Equal(t, "Acme Plumbing", branding.Email.FromName)
+		assert.Equal(t, models.PlatformEmailAddress, branding.Email.FromAddress)
+		assert.Equal(t, "office@acme.test", branding.Email.ReplyTo)
+		assert.Equal(t, "https://cdn.test/acme.png", branding.Email.LogoURL)
+		assert.Empty(t, branding.Email.DKIMDomain)
+	})
+
+	whitelabel := &models.WhiteLabel{
+		CompanyName:   "Acme",
+		PrimaryColor:  "#112233",
+		HidePoweredBy: true,
+		IsActive:      true,
+		EmailSettings: models.EmailBranding{
+			FromName:   "Acme Bookings",
+			FromEmail:  "bookings@acme.test",
+			FooterText: "Acme Plumbing, 1 Main St",
+		},
+	}
+	whitelabel.SetEmailSenderDomain("acme.test", "kv202506", "MIIBIjANBg", "private")
+
+	t.Run("unverified sender domain", func(t *testing.T) {
+		branding := models.ResolveBranding(tenant, whitelabel)
+		assert.Equal(t, "Acme", branding.Name)
+		assert.Equal(t, "#112233", branding.Email.ButtonColor)
+		assert.Equal(t, "Acme Plumbing, 1 Main St", branding.FooterText)
+		assert.True(t, branding.ShowPoweredBy, "hiding it takes white labeling")
+		assert.Equal(t, "Acme Bookings", branding.Email.FromName)
+		assert.Equal(t, models.PlatformEmailAddress, branding.Email.FromAddress)
+	})
+
+	t.Run("verified sender domain", func(t *testing.T) {
+		verified := *whitelabel
+		verified.MarkEmailDomainVerified(now)
+		whiteLabeled := *tenant
+		whiteLabeled.Features.WhiteLabeling = true
+
+		branding := models.ResolveBranding(&whiteLabeled, &verified)
+		assert.False(t, branding.ShowPoweredBy)
+		assert.Equal(t, "bookings@acme.test", branding.Email.FromAddress)
+		assert.Equal(t, "acme.test", branding.Email.DKIMDomain)
+		assert.Equal(t, "kv202506", branding.Email.DKIMSelector)
+
+		verified.EmailSettings.FromEmail = "bookings@elsewhere.test"
+		assert.Equal(t, models.PlatformEmailAddress, models.ResolveBranding(&whiteLabeled, &verified).Email.FromAddress,
+			"only the verified domain is sent from")
+	})
+
+	t.Run("inactive white label", func(t *testing.T) {
+		inactive := *whitelabel
+		inactive.IsActive = false
+		assert.Equal(t, "Acme Plumbing", models.ResolveBranding(tenant, &inactive).Name)
+	})
+}
@@ -0,0 +1,292 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CampaignStatus is where a campaign is in its send workflow
+type CampaignStatus string
+
+const (
+	CampaignStatusDraft     CampaignStatus = "draft"     // Being written; not sent
+	CampaignStatusScheduled CampaignStatus = "scheduled" // Sent once its scheduled time comes
+	CampaignStatusSending   CampaignStatus = "sending"   // Recipients resolved; being sent in throttled batches
+	CampaignStatusSent      CampaignStatus = "sent"      // Every recipient was sent to or skipped
+	CampaignStatusCancelled CampaignStatus = "cancelled" // Stopped; recipients not sent to yet are skipped
+)
+
+// IsValid checks if the campaign status is valid
+func (s CampaignStatus) IsValid() bool {
+	switch s {
+	case CampaignStatusDraft, CampaignStatusScheduled, CampaignStatusSending, CampaignStatusSent, CampaignStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CampaignRecipientStatus is whether a campaign reached one of its recipients
+type CampaignRecipientStatus string
+
+const (
+	CampaignRecipientStatusPending CampaignRecipientStatus = "pending" // Waiting for its batch
+	CampaignRecipientStatusSent    CampaignRecipientStatus = "sent"    // Handed to the notification subsystem
+	CampaignRecipientStatusSkipped CampaignRecipientStatus = "skipped" // Not sent, see SkipReason
+	CampaignRecipientStatusFailed  CampaignRecipientStatus = "failed"  // The notification could not be created
+)
+
+// Reasons a campaign recipient was skipped
+const (
+	CampaignSkipNoConsent = "no_consent" // Not opted in to marketing on any of the campaign's channels
+	CampaignSkipNoContact = "no_contact" // No email address or phone number for the channels allowed
+	CampaignSkipCancelled = "cancelled"  // The campaign was cancelled first
+)
+
+const (
+	// DefaultCampaignSendRate is how many recipients a campaign is sent to per minute
+	// unless the tenant throttles it differently
+	DefaultCampaignSendRate = 100
+	// MaxCampaignSendRate is the fastest a campaign can be sent, per minute
+	MaxCampaignSendRate = 1000
+	// MaxCampaignSMSLength is the longest campaign body sent by SMS, about three segments
+	MaxCampaignSMSLength = 480
+)
+
+// CampaignChannels are the channels a campaign can be sent on
+var CampaignChannels = []NotificationChannel{NotificationChannelEmail, NotificationChannelSMS}
+
+// CampaignAudience selects the customers a campaign is sent to: those in the customer
+// audience who, when booking criteria are set, booked one of the services or categories
+// within the period. "Everyone who booked hair braiding last quarter" is the braiding
+// service with last quarter's dates. Cancelled and refunded bookings don't count.
+type CampaignAudience struct {
+	CustomerAudience
+
+	ServiceIDs        []uuid.UUID       `json:"service_ids,omitempty"`
+	ServiceCategories []ServiceCategory `json:"service_categories,omitempty"`
+	BookedFrom        *time.Time        `json:"booked_from,omitempty"` // Bookings starting at or after
+	BookedTo          *time.Time        `json:"booked_to,omitempty"`   // Bookings starting before
+}
+
+// Validate checks the customer audience and the booking period
+func (a *CampaignAudience) Validate() error {
+	if err := a.CustomerAudience.Validate(); err != nil {
+		return err
+	}
+	for _, category := range a.ServiceCategories {
+		if strings.TrimSpace(string(category)) == "" {
+			return errors.New("service categories cannot be empty")
+		}
+	}
+	if a.BookedFrom != nil && a.BookedTo != nil && !a.BookedFrom.Before(*a.BookedTo) {
+		return errors.New("booked_from must be before booked_to")
+	}
+	return nil
+}
+
+// HasBookingCriteria reports whether the audience is limited by booking history
+func (a CampaignAudience) HasBookingCriteria() bool {
+	return len(a.ServiceIDs) > 0 || len(a.ServiceCategories) > 0 || a.BookedFrom != nil || a.BookedTo != nil
+}
+
+// CategoryNames returns the service categories as strings, for queries
+func (a CampaignAudience) CategoryNames() []string {
+	names := make([]string, len(a.ServiceCategories))
+	for i, category := range a.ServiceCategories {
+		names[i] = string(category)
+	}
+	return names
+}
+
+func (a *CampaignAudience) Scan(value interface{}) error {
+	if value == nil {
+		*a = CampaignAudience{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, a)
+}
+
+func (a CampaignAudience) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// CampaignTemplateData is what a campaign's placeholders are filled in with for one
+// recipient
+type CampaignTemplateData struct {
+	FirstName  string
+	LastName   string
+	TenantName string
+}
+
+// CampaignPlaceholders lists the placeholders campaign subjects and bodies may use
+var CampaignPlaceholders = []string{"first_name", "last_name", "full_name", "tenant_name"}
+
+var campaignPlaceholderPattern = regexp.MustCompile(`{{\s*([a-zA-Z0-9_]*)\s*}}`)
+
+// ValidateCampaignTemplate checks a campaign subject or body only uses known placeholders
+func ValidateCampaignTemplate(template string) error {
+	for _, match := range campaignPlaceholderPattern.FindAllStringSubmatch(template, -1) {
+		if !slices.Contains(CampaignPlaceholders, match[1]) {
+			return fmt.Errorf("unknown placeholder %s; use one of {{%s}}", match[0], strings.Join(CampaignPlaceholders, "}}, {{"))
+		}
+	}
+	return nil
+}
+
+// RenderCampaignTemplate fills a campaign subject or body in for one recipient.
+// Placeholders are written {{first_name}}, with optional spaces inside the braces.
+func RenderCampaignTemplate(template string, data CampaignTemplateData) string {
+	values := map[string]string{
+		"first_name":  data.FirstName,
+		"last_name":   data.LastName,
+		"full_name":   strings.TrimSpace(data.FirstName + " " + data.LastName),
+		"tenant_name": data.TenantName,
+	}
+	return campaignPlaceholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		key := campaignPlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		if value, ok := values[key]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// Campaign is a marketing message a tenant broadcasts to a customer audience by email
+// or SMS. It is sent through the notification subsystem as marketing, so only customers
+// who opted in are reached, at most SendRate recipients a minute.
+type Campaign struct {
+	BaseModel
+
+	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	CreatedBy uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+	Name      string    `json:"name" gorm:"not null;size:255" validate:"required,max=255"`
+
+	// Targeting
+	Audience CampaignAudience `json:"audience" gorm:"type:jsonb;not null"`
+
+	// Content: the subject is the email subject and notification title; SMS only carry
+	// the body. Both may use CampaignPlaceholders.
+	Channels []NotificationChannel `json:"channels" gorm:"type:text[];not null" validate:"required,min=1"`
+	Subject  string                `json:"subject" gorm:"not null;size:255" validate:"required,max=255"`
+	Body     string                `json:"body" gorm:"type:text;not null" validate:"required"`
+
+	// Scheduling & throttling
+	Status      CampaignStatus `json:"status" gorm:"type:varchar(20);not null;default:'draft';index:idx_campaign_status_schedule"`
+	ScheduledAt *time.Time     `json:"scheduled_at,omitempty" gorm:"index:idx_campaign_status_schedule"`
+	SendRate    int            `json:"send_rate" gorm:"not null;default:100"` // Recipients per minute
+	StartedAt   *time.Time     `json:"started_at,omitempty"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+	CancelledAt *time.Time     `json:"cancelled_at,omitempty"`
+
+	// Metrics
+	RecipientCount int `json:"recipient_count" gorm:"default:0"`
+	SentCount      int `json:"sent_count" gorm:"default:0"`
+	SkippedCount   int `json:"skipped_count" gorm:"default:0"`
+	FailedCount    int `json:"failed_count" gorm:"default:0"`
+	OpenCount      int `json:"open_count" gorm:"default:0"`
+}
+
+// TableName specifies the table name for Campaign
+func (Campaign) TableName() string {
+	return "campaigns"
+}
+
+// Validate checks the campaign's channels, content, audience and send rate
+func (c *Campaign) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return errors.New("name is required")
+	}
+	if len(c.Channels) == 0 {
+		return errors.New("at least one channel is required")
+	}
+	for _, channel := range c.Channels {
+		if !slices.Contains(CampaignChannels, channel) {
+			return fmt.Errorf("campaigns are sent by email or sms, not %q", channel)
+		}
+	}
+	if strings.TrimSpace(c.Subject) == "" || strings.TrimSpace(c.Body) == "" {
+		return errors.New("subject and body are required")
+	}
+	if err := ValidateCampaignTemplate(c.Subject); err != nil {
+		return fmt.Errorf("subject: %w", err)
+	}
+	if err := ValidateCampaignTemplate(c.Body); err != nil {
+		return fmt.Errorf("body: %w", err)
+	}
+	if slices.Contains(c.Channels, NotificationChannelSMS) && len([]rune(c.Body)) > MaxCampaignSMSLength {
+		return fmt.Errorf("body exceeds %d characters, the longest sent by SMS", MaxCampaignSMSLength)
+	}
+	if c.SendRate < 1 || c.SendRate > MaxCampaignSendRate {
+		return fmt.Errorf("send_rate must be between 1 and %d recipients a minute", MaxCampaignSendRate)
+	}
+	return c.Audience.Validate()
+}
+
+// IsEditable reports whether the campaign can still be changed, before it starts sending
+func (c *Campaign) IsEditable() bool {
+	return c.Status == CampaignStatusDraft || c.Status == CampaignStatusScheduled
+}
+
+// CanCancel reports whether the campaign can still be stopped
+func (c *Campaign) CanCancel() bool {
+	return c.IsEditable() || c.Status == CampaignStatusSending
+}
+
+// IsDue reports whether a scheduled campaign's time has come
+func (c *Campaign) IsDue(now time.Time) bool {
+	return c.Status == CampaignStatusScheduled && c.ScheduledAt != nil && !c.ScheduledAt.After(now)
+}
+
+// OpenRate returns the share of sent emails that were opened, from 0 to 1. Opens are
+// tracked by a pixel, so they are a lower bound: many mail clients block images.
+func (c *Campaign) OpenRate() float64 {
+	if c.SentCount == 0 {
+		return 0
+	}
+	return float64(c.OpenCount) / float64(c.SentCount)
+}
+
+// Render fills the campaign's subject and body in for one recipient
+func (c *Campaign) Render(data CampaignTemplateData) (subject, body string) {
+	return RenderCampaignTemplate(c.Subject, data), RenderCampaignTemplate(c.Body, data)
+}
+
+// CampaignRecipient is a customer a campaign is sent to, resolved from its audience
+// when it starts sending. The open token identifies the recipient in the tracking pixel
+// of the email.
+type CampaignRecipient struct {
+	BaseModel
+
+	TenantID   uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	CampaignID uuid.UUID `json:"campaign_id" gorm:"type:uuid;not null;uniqueIndex:idx_campaign_recipient;index:idx_campaign_recipient_status"`
+	CustomerID uuid.UUID `json:"customer_id" gorm:"type:uuid;not null;uniqueIndex:idx_campaign_recipient"` // User ID
+
+	Status         CampaignRecipientStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_campaign_recipient_status"`
+	SkipReason     string                  `json:"skip_reason,omitempty" gorm:"size:50"`
+	NotificationID *uuid.UUID              `json:"notification_id,omitempty" gorm:"type:uuid"`
+	OpenToken      string                  `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	SentAt         *time.Time              `json:"sent_at,omitempty"`
+	OpenedAt       *time.Time              `json:"opened_at,omitempty"`
+
+	// Relationships
+	Customer *User `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+}
+
+// TableName specifies the table name for CampaignRecipient
+func (CampaignRecipient) TableName() string {
+	return "campaign_recipients"
+}
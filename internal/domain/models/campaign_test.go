@@ -0,0 +1,108 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validCampaign() models.Campaign {
+	return models.Campaign{
+		Name:     "Braiding regulars",
+		Channels: []models.NotificationChannel{models.NotificationChannelEmail},
+		Subject:  "{{first_name}}, time for fresh braids?",
+		Body:     "Hi {{ first_name }}, {{tenant_name}} has new slots this week.",
+		SendRate: models.DefaultCampaignSendRate,
+	}
+}
+
+func TestCampaignValidate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.AddDate(0, -3, 0)
+
+	tests := []struct {
+		name    string
+		modify  func(c *models.Campaign)
+		wantErr string
+	}{
+		{"valid", func(c *models.Campaign) {}, ""},
+		{"no channels", func(c *models.Campaign) { c.Channels = nil }, "channel"},
+		{"push is not a campaign channel", func(c *models.Campaign) {
+			c.Channels = []models.NotificationChannel{models.NotificationChannelPush}
+		}, "email or sms"},
+		{"unknown placeholder", func(c *models.Campaign) { c.Body = "Hi {{nickname}}" }, "unknown placeholder"},
+		{"long sms", func(c *models.Campaign) {
+			c.Channels = []models.NotificationChannel{models.NotificationChannelSMS}
+			c.Body = strings.Repeat("a", models.MaxCampaignSMSLength+1)
+		}, "SMS"},
+		{"send rate too high", func(c *models.Campaign) { c.SendRate = models.MaxCampaignSendRate + 1 }, "send_rate"},
+		{"unknown segment", func(c *models.Campaign) {
+			c.Audience.Segments = []models.CustomerSegment{"whales"}
+		}, "segment"},
+		{"inverted booking period", func(c *models.Campaign) {
+			c.Audience.BookedFrom, c.Audience.BookedTo = &now, &earlier
+		}, "booked_from"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaign := validCampaign()
+			tt.modify(&campaign)
+			err := campaign.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCampaignRender(t *testing.T) {
+	campaign := validCampaign()
+	campaign.Body += " {{full_name}}, {{last_name}}"
+
+	subject, body := campaign.Render(models.CampaignTemplateData{FirstName: "Ama", LastName: "Mensah", TenantName: "Kente Studio"})
+
+	assert.Equal(t, "Ama, time for fresh braids?", subject)
+	assert.Equal(t, "Hi Ama, Kente Studio has new slots this week. Ama Mensah, Mensah", body)
+	assert.Equal(t, "Hi ", models.RenderCampaignTemplate("Hi {{full_name}}", models.CampaignTemplateData{}))
+}
+
+func TestCampaignAudienceBookingCriteria(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, models.CampaignAudience{CustomerAudience: models.CustomerAudience{Tags: []string{"braids"}}}.HasBookingCriteria())
+	assert.True(t, models.CampaignAudience{BookedFrom: &from}.HasBookingCriteria())
+	assert.True(t, models.CampaignAudience{ServiceCategories: []models.ServiceCategory{models.ServiceCategoryHairBeauty}}.HasBookingCriteria())
+}
+
+func TestCampaignIsDue(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		campaign models.Campaign
+		want     bool
+	}{
+		{"scheduled in the past", models.Campaign{Status: models.CampaignStatusScheduled, ScheduledAt: &now}, true},
+		{"scheduled later", models.Campaign{Status: models.CampaignStatusScheduled, ScheduledAt: &later}, false},
+		{"draft", models.Campaign{Status: models.CampaignStatusDraft, ScheduledAt: &now}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.campaign.IsDue(now))
+		})
+	}
+}
+
+func TestCampaignOpenRate(t *testing.T) {
+	assert.Zero(t, (&models.Campaign{}).OpenRate())
+	assert.InDelta(t, 0.25, (&models.Campaign{SentCount: 8, OpenCount: 2}).OpenRate(), 0.0001)
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Defaults of the policy settings a new policy leaves out
+const (
+	DefaultFreeCancellationHours = 24
+	DefaultNoShowFeePercentage   = 100
+)
+
+// RefundTier grants RefundPercentage when cancelling at least MinHoursBefore the start
+type RefundTier struct {
+	MinHoursBefore   float64 `json:"min_hours_before" validate:"min=0"`
+	RefundPercentage float64 `json:"refund_percentage" validate:"min=0,max=100"`
+}
+
+// RefundTierArray is a custom type for handling []RefundTier in JSONB
+type RefundTierArray []RefundTier
+
+// CancellationPolicy defines how refunds and fees are computed when a booking is cancelled.
+// A policy with a nil ServiceID is the tenant-wide default; a service-specific policy overrides it.
+type CancellationPolicy struct {
+	BaseModel
+
+	TenantID  uuid.UUID  `json:"tenant_id" gorm:"type:uuid;not null;index:idx_cancellation_policy_tenant_service"`
+	ServiceID *uuid.UUID `json:"service_id,omitempty" gorm:"type:uuid;index:idx_cancellation_policy_tenant_service"`
+
+	Name        string `json:"name" gorm:"not null;size:255" validate:"required,min=2,max=255"`
+	Description string `json:"description,omitempty" gorm:"type:text"`
+
+	// Cancelling at least this many hours before the start is always fully refunded.
+	// The settings have no column defaults, as GORM would write them over zero values.
+	FreeCancellationHours float64 `json:"free_cancellation_hours" gorm:"type:decimal(8,2)"`
+
+	// Refund tiers applied inside the free cancellation window
+	RefundTiers RefundTierArray `json:"refund_tiers" gorm:"type:jsonb"`
+
+	// Fees
+	CancellationFeeFixed float64 `json:"cancellation_fee_fixed" gorm:"type:decimal(10,2);default:0"`
+	NoShowFeePercentage  float64 `json:"no_show_fee_percentage" gorm:"type:decimal(5,2)"`
+
+	IsActive bool `json:"is_active" gorm:"index"`
+
+	// Relationships
+	Tenant  *Tenant  `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+	Service *Service `json:"service,omitempty" gorm:"foreignKey:ServiceID"`
+}
+
+// CancellationBreakdown is the computed outcome of cancelling a booking under a policy
+type CancellationBreakdown struct {
+	HoursBeforeStart float64     `json:"hours_before_start"`
+	AmountPaid       float64     `json:"amount_paid"`
+	RefundPercentage float64     `json:"refund_percentage"`
+	RefundAmount     float64     `json:"refund_amount"`
+	CancellationFee  float64     `json:"cancellation_fee"`
+	NoShowFee        float64     `json:"no_show_fee"`
+	WithinFreeWindow bool        `json:"within_free_window"`
+	AppliedTier      *RefundTier `json:"applied_tier,omitempty"`
+}
+
+// CancellationPolicyFromSettings derives a policy from legacy tenant settings
+func CancellationPolicyFromSettings(tenantID uuid.UUID, settings TenantSettings) *CancellationPolicy {
+	return &CancellationPolicy{
+		TenantID:              tenantID,
+		Name:                  "Tenant default",
+		FreeCancellationHours: float64(settings.FullRefundHours),
+		RefundTiers: RefundTierArray{
+			{MinHoursBefore: float64(settings.PartialRefundHours), RefundPercentage: settings.PartialRefundPercentage},
+		},
+		NoShowFeePercentage: settings.NoShowFeePercentage,
+		IsActive:            true,
+	}
+}
+
+// Validate checks the policy values are consistent
+func (p *CancellationPolicy) Validate() error {
+	if p.FreeCancellationHours < 0 {
+		return errors.New("free cancellation hours cannot be negative")
+	}
+	if p.CancellationFeeFixed < 0 {
+		return errors.New("cancellation fee cannot be negative")
+	}
+	if p.NoShowFeePercentage < 0 || p.NoShowFeePercentage > 100 {
+		return errors.New("no-show fee percentage must be between 0 and 100")
+	}
+	for _, tier := range p.RefundTiers {
+		if tier.MinHoursBefore < 0 {
+			return errors.New("refund tier hours cannot be negative")
+		}
+		if tier.RefundPercentage < 0 || tier.RefundPercentage > 100 {
+			return errors.New("refund tier percentage must be between 0 and 100")
+		}
+		if tier.MinHoursBefore > p.FreeCancellationHours {
+			return errors.New("refund tiers must fall inside the free cancellation window")
+		}
+	}
+	return nil
+}
+
+// EvaluateCancellation computes the refund for cancelling the booking at the given time.
+// Refunds are based on what the customer has actually paid.
+func (p *CancellationPolicy) EvaluateCancellation(booking *Booking, at time.Time) CancellationBreakdown {
+	hours := booking.StartTime.Sub(at).Hours()
+	paid := booking.AmountPaid()
+
+	result := CancellationBreakdown{
+		HoursBeforeStart: math.Round(hours*100) / 100,
+		AmountPaid:       paid,
+	}
+
+	if hours >= p.FreeCancellationHours {
+		result.WithinFreeWindow = true
+		result.RefundPercentage = 100
+	} else {
+		result.CancellationFee = p.CancellationFeeFixed
+		if tier := p.matchTier(hours); tier != nil {
+			result.AppliedTier = tier
+			result.RefundPercentage = tier.RefundPercentage
+		}
+	}
+
+	refund := paid*result.RefundPercentage/100 - result.CancellationFee
+	result.RefundAmount = roundMoney(math.Max(0, refund))
+	return result
+}
+
+// EvaluateNoShow computes the fee charged when the customer does not show up
+func (p *CancellationPolicy) EvaluateNoShow(booking *Booking) CancellationBreakdown {
+	return CancellationBreakdown{
+		AmountPaid: booking.AmountPaid(),
+		NoShowFee:  roundMoney(booking.TotalPrice * p.NoShowFeePercentage / 100),
+	}
+}
+
+// matchTier returns the tier with the highest threshold that has been met
+func (p *CancellationPolicy) matchTier(hours float64) *RefundTier {
+	tiers := make([]RefundTier, len(p.RefundTiers))
+	copy(tiers, p.RefundTiers)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinHoursBefore > tiers[j].MinHoursBefore })
+
+	for i := range tiers {
+		if hours >= tiers[i].MinHoursBefore {
+			return &tiers[i]
+		}
+	}
+	return nil
+}
+
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func (r *RefundTierArray) Scan(value interface{}) error {
+	if value == nil {
+		*r = RefundTierArray{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, r)
+}
+
+func (r RefundTierArray) Value() (driver.Value, error) {
+	if r == nil {
+		return json.Marshal([]RefundTier{})
+	}
+	return json.Marshal(r)
+}
@@ -0,0 +1,104 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCancellationPolicy() *models.CancellationPolicy {
+	return &models.CancellationPolicy{
+		Name:                  "Standard",
+		FreeCancellationHours: 48,
+		RefundTiers: models.RefundTierArray{
+			{MinHoursBefore: 4, RefundPercentage: 25},
+			{MinHoursBefore: 24, RefundPercentage: 50},
+		},
+		CancellationFeeFixed: 5,
+		NoShowFeePercentage:  80,
+	}
+}
+
+func TestCancellationPolicy_EvaluateCancellation(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := testCancellationPolicy()
+
+	booking := &models.Booking{
+		TotalPrice:    200,
+		PaymentStatus: models.PaymentStatusPaid,
+	}
+
+	tests := []struct {
+		name       string
+		hoursAhead float64
+		percentage float64
+		fee        float64
+		refund     float64
+		free       bool
+	}{
+		{"free window", 72, 100, 0, 200, true},
+		{"upper tier", 30, 50, 5, 95, false},
+		{"lower tier", 6, 25, 5, 45, false},
+		{"no tier", 2, 0, 5, 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			booking.StartTime = now.Add(time.Duration(tc.hoursAhead * float64(time.Hour)))
+			result := policy.EvaluateCancellation(booking, now)
+
+			assert.Equal(t, tc.free, result.WithinFreeWindow)
+			assert.Equal(t, tc.percentage, result.RefundPercentage)
+			assert.Equal(t, tc.fee, result.CancellationFee)
+			assert.Equal(t, tc.refund, result.RefundAmount)
+		})
+	}
+}
+
+func TestCancellationPolicy_RefundsOnlyWhatWasPaid(t *testing.T) {
+	now := time.Now()
+	policy := testCancellationPolicy()
+
+	booking := &models.Booking{
+		StartTime:     now.Add(72 * time.Hour),
+		TotalPrice:    200,
+		DepositPaid:   40,
+		PaymentStatus: models.PaymentStatusPending,
+	}
+
+	result := policy.EvaluateCancellation(booking, now)
+	assert.Equal(t, 40.0, result.AmountPaid)
+	assert.Equal(t, 40.0, result.RefundAmount)
+}
+
+func TestCancellationPolicy_EvaluateNoShow(t *testing.T) {
+	policy := testCancellationPolicy()
+	booking := &models.Booking{TotalPrice: 150}
+
+	result := policy.EvaluateNoShow(booking)
+	assert.Equal(t, 120.0, result.NoShowFee)
+}
+
+func TestCancellationPolicy_Validate(t *testing.T) {
+	assert.NoError(t, testCancellationPolicy().Validate())
+
+	policy := testCancellationPolicy()
+	policy.RefundTiers = append(policy.RefundTiers, models.RefundTier{MinHoursBefore: 96, RefundPercentage: 75})
+	assert.Error(t, policy.Validate())
+
+	policy = testCancellationPolicy()
+	policy.NoShowFeePercentage = 120
+	assert.Error(t, policy.Validate())
+}
+
+func TestCancellationPolicyFromSettings(t *testing.T) {
+	settings := models.GetDefaultTenantSettings()
+	policy := models.CancellationPolicyFromSettings(uuid.New(), settings)
+
+	assert.Equal(t, float64(settings.FullRefundHours), policy.FreeCancellationHours)
+	assert.NoError(t, policy.Validate())
+}
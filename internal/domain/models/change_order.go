@@ -0,0 +1,218 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChangeOrderStatus string
+
+const (
+	ChangeOrderStatusDraft     ChangeOrderStatus = "draft"   // Being prepared; not visible to the customer
+	ChangeOrderStatusPending   ChangeOrderStatus = "pending" // Awaiting the customer's approval
+	ChangeOrderStatusApproved  ChangeOrderStatus = "approved"
+	ChangeOrderStatusRejected  ChangeOrderStatus = "rejected"
+	ChangeOrderStatusCancelled ChangeOrderStatus = "cancelled"
+)
+
+type ChangeOrderAction string
+
+const (
+	ChangeOrderActionCreated   ChangeOrderAction = "created"
+	ChangeOrderActionUpdated   ChangeOrderAction = "updated"
+	ChangeOrderActionSubmitted ChangeOrderAction = "submitted"
+	ChangeOrderActionApproved  ChangeOrderAction = "approved"
+	ChangeOrderActionRejected  ChangeOrderAction = "rejected"
+	ChangeOrderActionCancelled ChangeOrderAction = "cancelled"
+)
+
+// ChangeOrder is a formal change to a project's scope with its effect on price and
+// schedule. Once the customer approves it, the project budget and due date move by
+// the deltas; the values before and after are kept on the change order.
+type ChangeOrder struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ProjectID uuid.UUID `json:"project_id" gorm:"type:uuid;not null;uniqueIndex:idx_change_order_project_number"`
+	Number    int       `json:"number" gorm:"not null;uniqueIndex:idx_change_order_project_number"` // Sequential within the project
+
+	// Core details
+	Title       string            `json:"title" gorm:"not null;size:255"`
+	Description string            `json:"description,omitempty" gorm:"type:text"`
+	Reason      string            `json:"reason,omitempty" gorm:"type:text"`
+	Status      ChangeOrderStatus `json:"status" gorm:"type:varchar(16);not null;default:'draft';index"`
+
+	// Impact
+	PriceDelta        float64 `json:"price_delta" gorm:"type:decimal(12,2);default:0"` // Negative for reductions
+	ScheduleDeltaDays int     `json:"schedule_delta_days" gorm:"default:0"`            // Negative to pull the due date in
+	Currency          string  `json:"currency" gorm:"size:3;default:'USD'"`
+
+	// Workflow
+	RequestedByID   *uuid.UUID `json:"requested_by_id,omitempty" gorm:"type:uuid"`
+	SubmittedAt     *time.Time `json:"submitted_at,omitempty" gorm:"type:timestamptz"`
+	RespondedByID   *uuid.UUID `json:"responded_by_id,omitempty" gorm:"type:uuid"`
+	RespondedAt     *time.Time `json:"responded_at,omitempty" gorm:"type:timestamptz"`
+	RejectionReason string     `json:"rejection_reason,omitempty" gorm:"type:text"`
+
+	// Adjustment applied to the project on approval
+	BudgetBefore  float64    `json:"budget_before,omitempty" gorm:"type:decimal(12,2);default:0"`
+	BudgetAfter   float64    `json:"budget_after,omitempty" gorm:"type:decimal(12,2);default:0"`
+	DueDateBefore *time.Time `json:"due_date_before,omitempty" gorm:"type:timestamptz"`
+	DueDateAfter  *time.Time `json:"due_date_after,omitempty" gorm:"type:timestamptz"`
+
+	// Metadata
+	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb"`
+
+	// Relationships
+	Project *Project           `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+	Events  []ChangeOrderEvent `json:"events,omitempty" gorm:"foreignKey:ChangeOrderID"`
+}
+
+// TableName specifies the table name
+func (ChangeOrder) TableName() string {
+	return "change_orders"
+}
+
+// ChangeOrderEvent is an entry in a change order's history
+type ChangeOrderEvent struct {
+	BaseModel
+
+	TenantID      uuid.UUID         `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ChangeOrderID uuid.UUID         `json:"change_order_id" gorm:"type:uuid;not null;index"`
+	ActorID       *uuid.UUID        `json:"actor_id,omitempty" gorm:"type:uuid;index"` // Nil for system changes
+	Action        ChangeOrderAction `json:"action" gorm:"type:varchar(16);not null"`
+	FromStatus    ChangeOrderStatus `json:"from_status,omitempty" gorm:"type:varchar(16)"`
+	ToStatus      ChangeOrderStatus `json:"to_status" gorm:"type:varchar(16);not null"`
+	Note          string            `json:"note,omitempty" gorm:"type:text"`
+
+	// The change order's impact when the event happened
+	PriceDelta        float64 `json:"price_delta" gorm:"type:decimal(12,2);default:0"`
+	ScheduleDeltaDays int     `json:"schedule_delta_days" gorm:"default:0"`
+}
+
+// TableName specifies the table name
+func (ChangeOrderEvent) TableName() string {
+	return "change_order_events"
+}
+
+// Validate checks the change order's details
+func (c *ChangeOrder) Validate() error {
+	if c.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if c.ProjectID == uuid.Nil {
+		return fmt.Errorf("project_id is required")
+	}
+	return nil
+}
+
+// CanEdit reports whether the change order can still be edited
+func (c *ChangeOrder) CanEdit() bool {
+	return c.Status == ChangeOrderStatusDraft
+}
+
+// Submit sends a draft change order to the customer for approval
+func (c *ChangeOrder) Submit(now time.Time, project *Project) error {
+	if c.Status != ChangeOrderStatusDraft {
+		return fmt.Errorf("only draft change orders can be submitted")
+	}
+	if err := c.checkApplicable(project); err != nil {
+		return err
+	}
+	c.Status = ChangeOrderStatusPending
+	c.SubmittedAt = &now
+	return nil
+}
+
+// Approve records the customer's approval. ApplyTo then adjusts the project.
+func (c *ChangeOrder) Approve(now time.Time, userID uuid.UUID) error {
+	if c.Status != ChangeOrderStatusPending {
+		return fmt.Errorf("only change orders awaiting approval can be approved")
+	}
+	c.Status = ChangeOrderStatusApproved
+	c.RespondedAt = &now
+	c.RespondedByID = &userID
+	return nil
+}
+
+// Reject records the customer's rejection with an optional reason
+func (c *ChangeOrder) Reject(now time.Time, userID uuid.UUID, reason string) error {
+	if c.Status != ChangeOrderStatusPending {
+		return fmt.Errorf("only change orders awaiting approval can be rejected")
+	}
+	c.Status = ChangeOrderStatusRejected
+	c.RespondedAt = &now
+	c.RespondedByID = &userID
+	c.RejectionReason = reason
+	return nil
+}
+
+// Cancel withdraws a change order the customer has not answered yet
+func (c *ChangeOrder) Cancel() error {
+	if c.Status != ChangeOrderStatusDraft && c.Status != ChangeOrderStatusPending {
+		return fmt.Errorf("only draft or pending change orders can be cancelled")
+	}
+	c.Status = ChangeOrderStatusCancelled
+	return nil
+}
+
+// ApplyTo moves the project's budget and due date by the change order's deltas and
+// records the values before and after on the change order
+func (c *ChangeOrder) ApplyTo(project *Project) error {
+	if err := c.checkApplicable(project); err != nil {
+		return err
+	}
+
+	c.BudgetBefore = project.BudgetAmount
+	c.BudgetAfter = roundMoney(project.BudgetAmount + c.PriceDelta)
+	project.BudgetAmount = c.BudgetAfter
+
+	c.DueDateBefore, c.DueDateAfter = project.DueDate, project.DueDate
+	if c.ScheduleDeltaDays != 0 {
+		due := project.DueDate.AddDate(0, 0, c.ScheduleDeltaDays)
+		c.DueDateAfter = &due
+		project.DueDate = &due
+	}
+	return nil
+}
+
+// checkApplicable checks the change order can still be applied to the project
+func (c *ChangeOrder) checkApplicable(project *Project) error {
+	if project == nil || project.ID != c.ProjectID {
+		return fmt.Errorf("change order does not belong to the project")
+	}
+	if project.Status == ProjectStatusCompleted || project.Status == ProjectStatusCancelled {
+		return fmt.Errorf("the project is %s and can no longer change", project.Status)
+	}
+	if project.BudgetAmount+c.PriceDelta < 0 {
+		return fmt.Errorf("the price reduction exceeds the project budget")
+	}
+	if c.ScheduleDeltaDays != 0 {
+		if project.DueDate == nil {
+			return fmt.Errorf("the project has no due date to move")
+		}
+		due := project.DueDate.AddDate(0, 0, c.ScheduleDeltaDays)
+		if project.StartDate != nil && due.Before(*project.StartDate) {
+			return fmt.Errorf("the schedule change would move the due date before the start date")
+		}
+	}
+	return nil
+}
+
+// NewEvent records an action on the change order that moved it from the given status
+func (c *ChangeOrder) NewEvent(action ChangeOrderAction, from ChangeOrderStatus, actorID *uuid.UUID, note string) *ChangeOrderEvent {
+	return &ChangeOrderEvent{
+		TenantID:          c.TenantID,
+		ChangeOrderID:     c.ID,
+		ActorID:           actorID,
+		Action:            action,
+		FromStatus:        from,
+		ToStatus:          c.Status,
+		Note:              note,
+		PriceDelta:        c.PriceDelta,
+		ScheduleDeltaDays: c.ScheduleDeltaDays,
+	}
+}
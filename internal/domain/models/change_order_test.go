@@ -0,0 +1,133 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChangeOrder() (*models.ChangeOrder, *models.Project) {
+	start := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	due := start.AddDate(0, 0, 30)
+	project := &models.Project{
+		TenantID:     uuid.New(),
+		Status:       models.ProjectStatusInProgress,
+		BudgetAmount: 10000,
+		StartDate:    &start,
+		DueDate:      &due,
+	}
+	project.ID = uuid.New()
+
+	changeOrder := &models.ChangeOrder{
+		TenantID:          project.TenantID,
+		ProjectID:         project.ID,
+		Title:             "Add skylight",
+		Status:            models.ChangeOrderStatusDraft,
+		PriceDelta:        1250.555,
+		ScheduleDeltaDays: 5,
+	}
+	changeOrder.ID = uuid.New()
+	return changeOrder, project
+}
+
+func TestChangeOrder_Lifecycle(t *testing.T) {
+	now := time.Date(2025, 4, 10, 12, 0, 0, 0, time.UTC)
+	customerUserID := uuid.New()
+
+	t.Run("submit then approve", func(t *testing.T) {
+		changeOrder, project := newTestChangeOrder()
+		require.NoError(t, changeOrder.Submit(now, project))
+		assert.Equal(t, models.ChangeOrderStatusPending, changeOrder.Status)
+		assert.Equal(t, now, *changeOrder.SubmittedAt)
+		assert.False(t, changeOrder.CanEdit())
+		assert.Error(t, changeOrder.Submit(now, project), "already submitted")
+
+		require.NoError(t, changeOrder.Approve(now, customerUserID))
+		assert.Equal(t, models.ChangeOrderStatusApproved, changeOrder.Status)
+		assert.Equal(t, customerUserID, *changeOrder.RespondedByID)
+		assert.Error(t, changeOrder.Reject(now, customerUserID, "too late"))
+		assert.Error(t, changeOrder.Cancel())
+	})
+
+	t.Run("drafts cannot be answered", func(t *testing.T) {
+		changeOrder, _ := newTestChangeOrder()
+		assert.True(t, changeOrder.CanEdit())
+		assert.Error(t, changeOrder.Approve(now, customerUserID))
+		assert.Error(t, changeOrder.Reject(now, customerUserID, ""))
+	})
+
+	t.Run("reject keeps the reason", func(t *testing.T) {
+		changeOrder, project := newTestChangeOrder()
+		require.NoError(t, changeOrder.Submit(now, project))
+		require.NoError(t, changeOrder.Reject(now, customerUserID, "over budget"))
+		assert.Equal(t, models.ChangeOrderStatusRejected, changeOrder.Status)
+		assert.Equal(t, "over budget", changeOrder.RejectionReason)
+	})
+
+	t.Run("pending change orders can be cancelled", func(t *testing.T) {
+		changeOrder, project := newTestChangeOrder()
+		require.NoError(t, changeOrder.Submit(now, project))
+		require.NoError(t, changeOrder.Cancel())
+		assert.Equal(t, models.ChangeOrderStatusCancelled, changeOrder.Status)
+	})
+
+	t.Run("events record the transition", func(t *testing.T) {
+		changeOrder, project := newTestChangeOrder()
+		require.NoError(t, changeOrder.Submit(now, project))
+		event := changeOrder.NewEvent(models.ChangeOrderActionSubmitted, models.ChangeOrderStatusDraft, &customerUserID, "")
+		assert.Equal(t, changeOrder.ID, event.ChangeOrderID)
+		assert.Equal(t, models.ChangeOrderStatusDraft, event.FromStatus)
+		assert.Equal(t, models.ChangeOrderStatusPending, event.ToStatus)
+		assert.Equal(t, 5, event.ScheduleDeltaDays)
+	})
+}
+
+func TestChangeOrder_ApplyTo(t *testing.T) {
+	changeOrder, project := newTestChangeOrder()
+	due := *project.DueDate
+
+	require.NoError(t, changeOrder.ApplyTo(project))
+
+	assert.Equal(t, 10000.0, changeOrder.BudgetBefore)
+	assert.Equal(t, 11250.56, changeOrder.BudgetAfter)
+	assert.Equal(t, 11250.56, project.BudgetAmount)
+	assert.Equal(t, due, *changeOrder.DueDateBefore)
+	assert.Equal(t, due.AddDate(0, 0, 5), *changeOrder.DueDateAfter)
+	assert.Equal(t, due.AddDate(0, 0, 5), *project.DueDate)
+
+	t.Run("price only change keeps the due date", func(t *testing.T) {
+		changeOrder, project := newTestChangeOrder()
+		project.DueDate = nil
+		changeOrder.ScheduleDeltaDays = 0
+		changeOrder.PriceDelta = -500
+
+		require.NoError(t, changeOrder.ApplyTo(project))
+		assert.Equal(t, 9500.0, project.BudgetAmount)
+		assert.Nil(t, project.DueDate)
+		assert.Nil(t, changeOrder.DueDateAfter)
+	})
+
+	cases := map[string]func(*models.ChangeOrder, *models.Project){
+		"other project":         func(_ *models.ChangeOrder, p *models.Project) { p.ID = uuid.New() },
+		"completed project":     func(_ *models.ChangeOrder, p *models.Project) { p.Status = models.ProjectStatusCompleted },
+		"cancelled project":     func(_ *models.ChangeOrder, p *models.Project) { p.Status = models.ProjectStatusCancelled },
+		"negative budget":       func(c *models.ChangeOrder, _ *models.Project) { c.PriceDelta = -10001 },
+		"no due date to move":   func(_ *models.ChangeOrder, p *models.Project) { p.DueDate = nil },
+		"due before start date": func(c *models.ChangeOrder, _ *models.Project) { c.ScheduleDeltaDays = -31 },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			changeOrder, project := newTestChangeOrder()
+			mutate(changeOrder, project)
+			budget := project.BudgetAmount
+			assert.Error(t, changeOrder.ApplyTo(project))
+			assert.Error(t, changeOrder.Submit(time.Now(), project))
+			assert.Equal(t, budget, project.BudgetAmount, "project is untouched")
+		})
+	}
+}
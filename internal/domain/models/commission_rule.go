@@ -0,0 +1,153 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArtisanTier grades a tenant's artisans, e.g. for commission rules
+type ArtisanTier string
+
+const (
+	ArtisanTierStandard ArtisanTier = "standard"
+	ArtisanTierSilver   ArtisanTier = "silver"
+	ArtisanTierGold     ArtisanTier = "gold"
+	ArtisanTierPlatinum ArtisanTier = "platinum"
+)
+
+// IsValid checks if the tier is one of the supported tiers
+func (t ArtisanTier) IsValid() bool {
+	switch t {
+	case ArtisanTierStandard, ArtisanTierSilver, ArtisanTierGold, ArtisanTierPlatinum:
+		return true
+	}
+	return false
+}
+
+// CommissionRule is a commission rate a tenant takes on payments. Empty conditions match
+// anything, so a rule with none set is the tenant's default rate. A rule with a
+// MinMonthlyVolume applies once the artisan has been paid at least that much in the
+// calendar month, and a rule with StartsAt or EndsAt only during that promotion.
+type CommissionRule struct {
+	BaseModel
+
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+
+	Name             string          `json:"name" gorm:"not null;size:100" validate:"required,max=100"` // Recorded on the payments it applies to
+	ServiceCategory  ServiceCategory `json:"service_category,omitempty" gorm:"type:varchar(50)"`
+	ArtisanTier      ArtisanTier     `json:"artisan_tier,omitempty" gorm:"type:varchar(20)"`
+	MinMonthlyVolume float64         `json:"min_monthly_volume" gorm:"type:decimal(12,2);default:0"`
+	StartsAt         *time.Time      `json:"starts_at,omitempty"`
+	EndsAt           *time.Time      `json:"ends_at,omitempty"`
+	Rate             float64         `json:"rate" gorm:"type:decimal(5,2);not null"` // Percentage
+
+	IsActive bool `json:"is_active" gorm:"default:true;index"`
+
+	// Relationships
+	Tenant *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+}
+
+// TableName specifies the table name for CommissionRule
+func (CommissionRule) TableName() string {
+	return "commission_rules"
+}
+
+// Validate checks the rule values are consistent
+func (r *CommissionRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("rule name is required")
+	}
+	if r.Rate < 0 || r.Rate > 100 {
+		return errors.New("commission rate must be between 0 and 100")
+	}
+	if r.ArtisanTier != "" && !r.ArtisanTier.IsValid() {
+		return errors.New("invalid artisan tier")
+	}
+	if r.MinMonthlyVolume < 0 {
+		return errors.New("minimum monthly volume cannot be negative")
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return errors.New("promotion must end after it starts")
+	}
+	return nil
+}
+
+// IsPromotional reports whether the rule only applies during a limited period
+func (r *CommissionRule) IsPromotional() bool {
+	return r.StartsAt != nil || r.EndsAt != nil
+}
+
+// CommissionContext is what decides the commission on a payment
+type CommissionContext struct {
+	ServiceCategory ServiceCategory
+	ArtisanTier     ArtisanTier
+	MonthlyVolume   float64 // Paid to the artisan so far this calendar month
+	At              time.Time
+}
+
+// Matches reports whether the rule applies to a payment in the given context
+func (r *CommissionRule) Matches(c CommissionContext) bool {
+	if !r.IsActive {
+		return false
+	}
+	if r.ServiceCategory != "" && r.ServiceCategory != c.ServiceCategory {
+		return false
+	}
+	if r.ArtisanTier != "" {
+		tier := c.ArtisanTier
+		if tier == "" {
+			tier = ArtisanTierStandard
+		}
+		if r.ArtisanTier != tier {
+			return false
+		}
+	}
+	if r.MinMonthlyVolume > 0 && c.MonthlyVolume < r.MinMonthlyVolume {
+		return false
+	}
+	if r.StartsAt != nil && c.At.Before(*r.StartsAt) {
+		return false
+	}
+	if r.EndsAt != nil && !c.At.Before(*r.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// specificity ranks matching rules: a promotion beats an artisan tier rule, which beats
+// a service category rule, which beats a volume tier, which beats the default
+func (r *CommissionRule) specificity() int {
+	score := 0
+	if r.IsPromotional() {
+		score += 8
+	}
+	if r.ArtisanTier != "" {
+		score += 4
+	}
+	if r.ServiceCategory != "" {
+		score += 2
+	}
+	if r.MinMonthlyVolume > 0 {
+		score++
+	}
+	return score
+}
+
+// ResolveCommissionRule returns the most specific rule that applies, or nil when none
+// does. Between equally specific rules the highest volume tier reached wins.
+func ResolveCommissionRule(rules []*CommissionRule, c CommissionContext) *CommissionRule {
+	var best *CommissionRule
+	for _, rule := range rules {
+		if !rule.Matches(c) {
+			continue
+		}
+		if best == nil || rule.specificity() > best.specificity() ||
+			(rule.specificity() == best.specificity() && rule.MinMonthlyVolume > best.MinMonthlyVolume) {
+			best = rule
+		}
+	}
+	return best
+}
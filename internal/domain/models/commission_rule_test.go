@@ -0,0 +1,95 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCommissionRules(now time.Time) []*models.CommissionRule {
+	promoStart := now.Add(-24 * time.Hour)
+	promoEnd := now.Add(24 * time.Hour)
+	expired := now.Add(-time.Hour)
+
+	return []*models.CommissionRule{
+		{Name: "Default", Rate: 15, IsActive: true},
+		{Name: "Volume 5k", MinMonthlyVolume: 5000, Rate: 12, IsActive: true},
+		{Name: "Volume 20k", MinMonthlyVolume: 20000, Rate: 10, IsActive: true},
+		{Name: "Plumbing", ServiceCategory: models.ServiceCategoryPlumbing, Rate: 18, IsActive: true},
+		{Name: "Gold", ArtisanTier: models.ArtisanTierGold, Rate: 8, IsActive: true},
+		{Name: "Launch week", StartsAt: &promoStart, EndsAt: &promoEnd, ServiceCategory: models.ServiceCategoryPainting, Rate: 5, IsActive: true},
+		{Name: "Old promo", EndsAt: &expired, Rate: 1, IsActive: true},
+		{Name: "Retired", ArtisanTier: models.ArtisanTierSilver, Rate: 2, IsActive: false},
+	}
+}
+
+func TestResolveCommissionRule(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	rules := testCommissionRules(now)
+
+	tests := []struct {
+		name string
+		ctx  models.CommissionContext
+		rule string
+	}{
+		{"default rate", models.CommissionContext{ServiceCategory: models.ServiceCategoryCarpentry, At: now}, "Default"},
+		{"volume tier reached", models.CommissionContext{MonthlyVolume: 7500, At: now}, "Volume 5k"},
+		{"highest volume tier wins", models.CommissionContext{MonthlyVolume: 25000, At: now}, "Volume 20k"},
+		{"category beats volume tier", models.CommissionContext{ServiceCategory: models.ServiceCategoryPlumbing, MonthlyVolume: 25000, At: now}, "Plumbing"},
+		{"artisan tier beats category", models.CommissionContext{ServiceCategory: models.ServiceCategoryPlumbing, ArtisanTier: models.ArtisanTierGold, At: now}, "Gold"},
+		{"promotion beats everything", models.CommissionContext{ServiceCategory: models.ServiceCategoryPainting, ArtisanTier: models.ArtisanTierGold, At: now}, "Launch week"},
+		{"promotion outside its period", models.CommissionContext{ServiceCategory: models.ServiceCategoryPainting, At: now.Add(48 * time.Hour)}, "Default"},
+		{"inactive rules are ignored", models.CommissionContext{ArtisanTier: models.ArtisanTierSilver, At: now}, "Default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := models.ResolveCommissionRule(rules, tt.ctx)
+			require.NotNil(t, rule)
+			assert.Equal(t, tt.rule, rule.Name)
+		})
+	}
+
+	t.Run("artisans without a tier are standard", func(t *testing.T) {
+		standard := []*models.CommissionRule{{Name: "Standard", ArtisanTier: models.ArtisanTierStandard, Rate: 20, IsActive: true}}
+		rule := models.ResolveCommissionRule(standard, models.CommissionContext{At: now})
+		require.NotNil(t, rule)
+		assert.Equal(t, 20.0, rule.Rate)
+	})
+
+	t.Run("no matching rule", func(t *testing.T) {
+		assert.Nil(t, models.ResolveCommissionRule(rules[1:3], models.CommissionContext{At: now}))
+	})
+}
+
+func TestCommissionRule_Validate(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(-time.Hour)
+
+	assert.NoError(t, (&models.CommissionRule{Name: "Default", Rate: 10}).Validate())
+	assert.Error(t, (&models.CommissionRule{Name: "", Rate: 10}).Validate())
+	assert.Error(t, (&models.CommissionRule{Name: "Too high", Rate: 101}).Validate())
+	assert.Error(t, (&models.CommissionRule{Name: "Tier", ArtisanTier: "diamond", Rate: 10}).Validate())
+	assert.Error(t, (&models.CommissionRule{Name: "Backwards", StartsAt: &start, EndsAt: &end, Rate: 10}).Validate())
+}
+
+func TestPayment_ApplyCommissionRule(t *testing.T) {
+	rule := &models.CommissionRule{Name: "Gold", Rate: 8}
+	payment := &models.Payment{Amount: 110, TaxAmount: 10, CommissionRate: 15}
+
+	payment.ApplyCommissionRule(rule)
+	payment.CalculateCommission()
+	assert.Equal(t, 8.0, payment.CommissionRate)
+	assert.Equal(t, "Gold", payment.CommissionRuleName)
+	require.NotNil(t, payment.CommissionRuleID)
+	assert.InDelta(t, 8.0, payment.PlatformAmount, 0.001)
+	assert.InDelta(t, 92.0, payment.ArtisanAmount, 0.001)
+
+	// A manual rate is not attributed to the rule
+	require.NoError(t, payment.SetCommissionRate(12))
+	assert.Nil(t, payment.CommissionRuleID)
+	assert.Empty(t, payment.CommissionRuleName)
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommunicationCategory separates the messages users must opt in to from those sent
+// unless they opt out
+type CommunicationCategory string
+
+const (
+	CommunicationCategoryTransactional CommunicationCategory = "transactional" // Bookings, payments, account
+	CommunicationCategoryMarketing     CommunicationCategory = "marketing"     // Promotions and newsletters
+	CommunicationCategoryAll           CommunicationCategory = "all"           // Both, for unsubscribe links
+)
+
+// CommunicationPreferences are a user's choices of what they are sent on each channel.
+// Transactional messages are sent unless the user opts out, marketing only once they
+// opt in. In-app notifications are always delivered.
+type CommunicationPreferences struct {
+	BaseModel
+
+	UserID uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+
+	// Transactional messages, on by default. The columns default to true in the
+	// database; they carry no gorm default so that opting out is saved on create.
+	EmailTransactional bool `json:"email_transactional" gorm:"not null"`
+	SMSTransactional   bool `json:"sms_transactional" gorm:"not null"`
+	PushTransactional  bool `json:"push_transactional" gorm:"not null"`
+
+	// Marketing, off until the user opts in
+	EmailMarketing bool `json:"email_marketing" gorm:"not null;default:false"`
+	SMSMarketing   bool `json:"sms_marketing" gorm:"not null;default:false"`
+	PushMarketing  bool `json:"push_marketing" gorm:"not null;default:false"`
+
+	// When the user last opted in to marketing on any channel
+	MarketingConsentAt *time.Time `json:"marketing_consent_at,omitempty"`
+
+	// Opaque token of the unsubscribe links in messages sent to the user, which work
+	// without signing in
+	UnsubscribeToken string `json:"-" gorm:"size:64;not null;uniqueIndex"`
+
+	// Relationships
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// DefaultCommunicationPreferences returns the preferences of a user who hasn't chosen
+// any: transactional messages on every channel and no marketing
+func DefaultCommunicationPreferences(userID uuid.UUID) *CommunicationPreferences {
+	return &CommunicationPreferences{
+		UserID:             userID,
+		EmailTransactional: true,
+		SMSTransactional:   true,
+		PushTransactional:  true,
+	}
+}
+
+// Allows reports whether messages of the category may be sent on the channel
+func (p *CommunicationPreferences) Allows(channel NotificationChannel, category CommunicationCategory) bool {
+	if channel == NotificationChannelInApp {
+		return true
+	}
+
+	marketing := category == CommunicationCategoryMarketing
+	switch channel {
+	case NotificationChannelEmail:
+		return (marketing && p.EmailMarketing) || (!marketing && p.EmailTransactional)
+	case NotificationChannelSMS:
+		return (marketing && p.SMSMarketing) || (!marketing && p.SMSTransactional)
+	case NotificationChannelPush:
+		return (marketing && p.PushMarketing) || (!marketing && p.PushTransactional)
+	}
+	return false
+}
+
+// Set opts in to or out of messages of the category on the channel; the all category
+// sets both
+func (p *CommunicationPreferences) Set(channel NotificationChannel, category CommunicationCategory, enabled bool) {
+	transactional := category != CommunicationCategoryMarketing
+	marketing := category != CommunicationCategoryTransactional
+
+	switch channel {
+	case NotificationChannelEmail:
+		if transactional {
+			p.EmailTransactional = enabled
+		}
+		if marketing {
+			p.EmailMarketing = enabled
+		}
+	case NotificationChannelSMS:
+		if transactional {
+			p.SMSTransactional = enabled
+		}
+		if marketing {
+			p.SMSMarketing = enabled
+		}
+	case NotificationChannelPush:
+		if transactional {
+			p.PushTransactional = enabled
+		}
+		if marketing {
+			p.PushMarketing = enabled
+		}
+	}
+}
+
+// HasMarketing reports whether the user receives marketing on any channel
+func (p *CommunicationPreferences) HasMarketing() bool {
+	return p.EmailMarketing || p.SMSMarketing || p.PushMarketing
+}
+
+// IsValid reports whether c is a known category
+func (c CommunicationCategory) IsValid() bool {
+	switch c {
+	case CommunicationCategoryTransactional, CommunicationCategoryMarketing, CommunicationCategoryAll:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommunicationPreferences_Allows(t *testing.T) {
+	preferences := models.DefaultCommunicationPreferences(uuid.New())
+
+	// Transactional messages are sent until the user opts out, marketing once they opt in
+	assert.True(t, preferences.Allows(models.NotificationChannelEmail, models.CommunicationCategoryTransactional))
+	assert.False(t, preferences.Allows(models.NotificationChannelEmail, models.CommunicationCategoryMarketing))
+	assert.False(t, preferences.HasMarketing())
+
+	preferences.Set(models.NotificationChannelSMS, models.CommunicationCategoryMarketing, true)
+	assert.True(t, preferences.Allows(models.NotificationChannelSMS, models.CommunicationCategoryMarketing))
+	assert.False(t, preferences.Allows(models.NotificationChannelEmail, models.CommunicationCategoryMarketing))
+	assert.True(t, preferences.HasMarketing())
+
+	// Unsubscribing from everything on a channel leaves the other channels alone
+	preferences.Set(models.NotificationChannelSMS, models.CommunicationCategoryAll, false)
+	assert.False(t, preferences.Allows(models.NotificationChannelSMS, models.CommunicationCategoryTransactional))
+	assert.False(t, preferences.HasMarketing())
+	assert.True(t, preferences.Allows(models.NotificationChannelPush, models.CommunicationCategoryTransactional))
+
+	// In-app notifications are always delivered
+	preferences.Set(models.NotificationChannelEmail, models.CommunicationCategoryAll, false)
+	assert.True(t, preferences.Allows(models.NotificationChannelInApp, models.CommunicationCategoryMarketing))
+}
+
+func TestNotificationType_Category(t *testing.T) {
+	assert.Equal(t, models.CommunicationCategoryMarketing, models.NotificationTypeMarketing.Category())
+	assert.Equal(t, models.CommunicationCategoryTransactional, models.NotificationTypeBookingReminder.Category())
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -22,6 +24,12 @@ type Customer struct {
 	CancelledBookings int `json:"cancelled_bookings" gorm:"default:0"`
 	CompletedBookings int `json:"completed_bookings" gorm:"default:0"`
 
+	// Segmentation: segments are recomputed nightly from completed bookings, tags are
+	// set by the tenant (see CustomerAudience)
+	Segments    StringArray `json:"segments,omitempty" gorm:"type:jsonb;index:idx_customer_segments,type:gin"`
+	Tags        StringArray `json:"tags,omitempty" gorm:"type:jsonb;index:idx_customer_tags,type:gin"`
+	SegmentedAt *time.Time  `json:"segmented_at,omitempty" gorm:"index"`
+
 	// Payment
 	DefaultPaymentMethodID string `json:"default_payment_method_id,omitempty" gorm:"size:255"`
 
@@ -0,0 +1,304 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustomerRequestStatus tracks a request for quotes from posting to award
+type CustomerRequestStatus string
+
+const (
+	CustomerRequestStatusOpen    CustomerRequestStatus = "open"    // Taking bids
+	CustomerRequestStatusAwarded CustomerRequestStatus = "awarded" // A bid was accepted and converted
+	CustomerRequestStatusClosed  CustomerRequestStatus = "closed"  // Withdrawn by the customer
+	CustomerRequestStatusExpired CustomerRequestStatus = "expired" // No bid accepted before ExpiresAt
+)
+
+// IsValid checks if the status is one of the supported statuses
+func (s CustomerRequestStatus) IsValid() bool {
+	switch s {
+	case CustomerRequestStatusOpen, CustomerRequestStatusAwarded, CustomerRequestStatusClosed, CustomerRequestStatusExpired:
+		return true
+	}
+	return false
+}
+
+// CustomerRequestBidStatus tracks an artisan's bid on a request
+type CustomerRequestBidStatus string
+
+const (
+	CustomerRequestBidStatusSubmitted CustomerRequestBidStatus = "submitted"
+	CustomerRequestBidStatusWithdrawn CustomerRequestBidStatus = "withdrawn" // By the artisan
+	CustomerRequestBidStatusAccepted  CustomerRequestBidStatus = "accepted"
+	CustomerRequestBidStatusRejected  CustomerRequestBidStatus = "rejected" // Another bid was accepted or the request closed
+)
+
+const (
+	// DefaultCustomerRequestOpenFor is how long a request takes bids unless the customer
+	// says otherwise, and MaxCustomerRequestOpenFor the longest it can
+	DefaultCustomerRequestOpenFor = 14 * 24 * time.Hour
+	MaxCustomerRequestOpenFor     = 60 * 24 * time.Hour
+	// MaxCustomerRequestPhotos is the number of photos a request can show
+	MaxCustomerRequestPhotos = 10
+)
+
+// CustomerRequest is a customer's description of a job that fits none of the fixed
+// services, posted for quotes. It is broadcast to the artisans whose skills match it as
+// leads; each of them can bid, and the bid the customer accepts becomes a booking or a
+// project.
+type CustomerRequest struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID   uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index:idx_customer_request_tenant_status"`
+	CustomerID uuid.UUID `json:"customer_id" gorm:"type:uuid;not null;index"` // Customer profile ID
+
+	// Job
+	Title         string          `json:"title" gorm:"not null;size:255"`
+	Description   string          `json:"description" gorm:"type:text;not null"`
+	Category      ServiceCategory `json:"category,omitempty" gorm:"type:varchar(50);index"`
+	PhotoURLs     StringArray     `json:"photo_urls,omitempty" gorm:"type:jsonb"`
+	BudgetMin     float64         `json:"budget_min" gorm:"type:decimal(12,2);default:0"`
+	BudgetMax     float64         `json:"budget_max" gorm:"type:decimal(12,2);default:0"` // 0 for no limit
+	Currency      string          `json:"currency" gorm:"size:3;default:'USD'"`
+	Location      *Location       `json:"location,omitempty" gorm:"type:jsonb"` // Where the job is
+	PreferredDate *time.Time      `json:"preferred_date,omitempty" gorm:"type:timestamptz"`
+
+	// Status
+	Status    CustomerRequestStatus `json:"status" gorm:"type:varchar(20);not null;default:'open';index:idx_customer_request_tenant_status"`
+	ExpiresAt time.Time             `json:"expires_at" gorm:"type:timestamptz;not null;index"`
+	LeadCount int                   `json:"lead_count" gorm:"default:0"`
+	BidCount  int                   `json:"bid_count" gorm:"default:0"` // Submitted bids
+
+	// Award
+	AwardedBidID *uuid.UUID `json:"awarded_bid_id,omitempty" gorm:"type:uuid"`
+	AwardedAt    *time.Time `json:"awarded_at,omitempty" gorm:"type:timestamptz"`
+	ProjectID    *uuid.UUID `json:"project_id,omitempty" gorm:"type:uuid;index"`
+	BookingID    *uuid.UUID `json:"booking_id,omitempty" gorm:"type:uuid;index"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty" gorm:"type:timestamptz"`
+
+	// Relationships
+	Customer *Customer `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+}
+
+// TableName specifies the table name for CustomerRequest
+func (CustomerRequest) TableName() string {
+	return "customer_requests"
+}
+
+// Validate checks the request's job, budget and photos
+func (r *CustomerRequest) Validate() error {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Description = strings.TrimSpace(r.Description)
+	if r.Title == "" || len([]rune(r.Title)) > 255 {
+		return errors.New("title is required and at most 255 characters")
+	}
+	if r.Description == "" || len([]rune(r.Description)) > 5000 {
+		return errors.New("description is required and at most 5000 characters")
+	}
+	if r.BudgetMin < 0 || r.BudgetMax < 0 {
+		return errors.New("budget cannot be negative")
+	}
+	if r.BudgetMax > 0 && r.BudgetMax < r.BudgetMin {
+		return errors.New("budget_max cannot be below budget_min")
+	}
+	if len(r.Currency) != 3 {
+		return errors.New("currency must be a 3-letter code")
+	}
+	if len(r.PhotoURLs) > MaxCustomerRequestPhotos {
+		return fmt.Errorf("a request can have at most %d photos", MaxCustomerRequestPhotos)
+	}
+	for _, photo := range r.PhotoURLs {
+		u, err := url.Parse(photo)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("photo %q must be an http(s) URL", photo)
+		}
+	}
+	if l := r.Location; l != nil && (l.Latitude < -90 || l.Latitude > 90 || l.Longitude < -180 || l.Longitude > 180) {
+		return errors.New("location must have a latitude between -90 and 90 and a longitude between -180 and 180")
+	}
+	if r.ExpiresAt.IsZero() {
+		return errors.New("expires_at is required")
+	}
+	return nil
+}
+
+// IsOpen reports whether the request still takes bids
+func (r *CustomerRequest) IsOpen(now time.Time) bool {
+	return r.Status == CustomerRequestStatusOpen && now.Before(r.ExpiresAt)
+}
+
+// Award records the customer's acceptance of a submitted bid on the open request
+func (r *CustomerRequest) Award(bid *CustomerRequestBid, now time.Time) error {
+	if !r.IsOpen(now) {
+		return fmt.Errorf("request is %s and can no longer be awarded", r.DisplayStatus(now))
+	}
+	if bid.RequestID != r.ID {
+		return errors.New("bid is not for this request")
+	}
+	if bid.Status != CustomerRequestBidStatusSubmitted {
+		return fmt.Errorf("bid is %s and cannot be accepted", bid.Status)
+	}
+
+	r.Status = CustomerRequestStatusAwarded
+	r.AwardedBidID = &bid.ID
+	r.AwardedAt = &now
+	bid.Status = CustomerRequestBidStatusAccepted
+	return nil
+}
+
+// DisplayStatus reports an open request past its expiry as expired before the job
+// catches up with it
+func (r *CustomerRequest) DisplayStatus(now time.Time) CustomerRequestStatus {
+	if r.Status == CustomerRequestStatusOpen && !now.Before(r.ExpiresAt) {
+		return CustomerRequestStatusExpired
+	}
+	return r.Status
+}
+
+// CustomerRequestLead is a request broadcast to an artisan whose skills match it. Only
+// artisans with a lead see the request and can bid on it.
+type CustomerRequestLead struct {
+	BaseModel
+
+	TenantID   uuid.UUID  `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	RequestID  uuid.UUID  `json:"request_id" gorm:"type:uuid;not null;uniqueIndex:idx_customer_request_lead"`
+	ArtisanID  uuid.UUID  `json:"artisan_id" gorm:"type:uuid;not null;uniqueIndex:idx_customer_request_lead;index"` // Artisan profile ID
+	MatchScore float64    `json:"match_score" gorm:"type:decimal(4,3);default:0"`                                   // See ScoreArtisanMatch
+	ViewedAt   *time.Time `json:"viewed_at,omitempty" gorm:"type:timestamptz"`
+
+	// Relationships
+	Request *CustomerRequest `json:"request,omitempty" gorm:"foreignKey:RequestID"`
+	Artisan *Artisan         `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
+}
+
+// TableName specifies the table name for CustomerRequestLead
+func (CustomerRequestLead) TableName() string {
+	return "customer_request_leads"
+}
+
+// CustomerRequestBid is an artisan's priced offer for a request. A bid naming one of the
+// artisan's services and a start time becomes a booking when accepted; any other bid
+// becomes a project.
+type CustomerRequestBid struct {
+	BaseModel
+
+	TenantID  uuid.UUID                `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	RequestID uuid.UUID                `json:"request_id" gorm:"type:uuid;not null;uniqueIndex:idx_customer_request_bid"`
+	ArtisanID uuid.UUID                `json:"artisan_id" gorm:"type:uuid;not null;uniqueIndex:idx_customer_request_bid;index"` // Artisan profile ID
+	Status    CustomerRequestBidStatus `json:"status" gorm:"type:varchar(20);not null;default:'submitted'"`
+
+	// Offer
+	Amount        float64 `json:"amount" gorm:"type:decimal(12,2);not null"`
+	Currency      string  `json:"currency" gorm:"size:3;default:'USD'"`
+	Message       string  `json:"message,omitempty" gorm:"type:text"`
+	EstimatedDays int     `json:"estimated_days,omitempty" gorm:"default:0"` // Length of a project; 0 when unknown
+
+	// Schedule, for a bid that books one of the artisan's services
+	ServiceID         *uuid.UUID `json:"service_id,omitempty" gorm:"type:uuid"`
+	ProposedStartTime *time.Time `json:"proposed_start_time,omitempty" gorm:"type:timestamptz"`
+	DurationMinutes   int        `json:"duration_minutes,omitempty" gorm:"default:0"`
+
+	// Relationships
+	Artisan *Artisan `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
+}
+
+// TableName specifies the table name for CustomerRequestBid
+func (CustomerRequestBid) TableName() string {
+	return "customer_request_bids"
+}
+
+// Validate checks the bid's offer and, for a booking, its schedule
+func (b *CustomerRequestBid) Validate() error {
+	if b.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	if len(b.Currency) != 3 {
+		return errors.New("currency must be a 3-letter code")
+	}
+	if len([]rune(b.Message)) > 2000 {
+		return errors.New("message cannot exceed 2000 characters")
+	}
+	if b.EstimatedDays < 0 || b.EstimatedDays > 730 {
+		return errors.New("estimated_days must be between 0 and 730")
+	}
+	if b.ServiceID == nil {
+		if b.DurationMinutes != 0 {
+			return errors.New("duration_minutes needs a service_id")
+		}
+		return nil
+	}
+	if b.ProposedStartTime == nil {
+		return errors.New("a bid for a service needs a proposed_start_time")
+	}
+	if b.DurationMinutes < 15 || b.DurationMinutes > 480 {
+		return errors.New("duration_minutes must be between 15 and 480")
+	}
+	return nil
+}
+
+// ConvertsToBooking reports whether the bid becomes a booking rather than a project
+// when accepted
+func (b *CustomerRequestBid) ConvertsToBooking() bool {
+	return b.ServiceID != nil && b.ProposedStartTime != nil
+}
+
+// ToBooking builds the confirmed booking an accepted bid for a service turns into.
+// Bookings name the artisan and customer by their user IDs.
+func (b *CustomerRequestBid) ToBooking(request *CustomerRequest, artisanUserID, customerUserID uuid.UUID) *Booking {
+	price := roundMoney(b.Amount)
+	return &Booking{
+		TenantID:        request.TenantID,
+		ArtisanID:       artisanUserID,
+		CustomerID:      customerUserID,
+		ServiceID:       *b.ServiceID,
+		StartTime:       *b.ProposedStartTime,
+		EndTime:         b.ProposedStartTime.Add(time.Duration(b.DurationMinutes) * time.Minute),
+		Duration:        b.DurationMinutes,
+		Status:          BookingStatusConfirmed,
+		PaymentStatus:   PaymentStatusPending,
+		BasePrice:       price,
+		ListPrice:       price,
+		TotalPrice:      price,
+		Currency:        b.Currency,
+		CustomerNotes:   request.Description,
+		Notes:           b.Message,
+		ServiceLocation: request.Location,
+		Metadata: JSONB{
+			"customer_request_id": request.ID.String(),
+			"bid_id":              b.ID.String(),
+		},
+	}
+}
+
+// ToProject builds the planned project an accepted bid turns into, starting on the
+// request's preferred date and lasting the bid's estimated days when they are known
+func (b *CustomerRequestBid) ToProject(request *CustomerRequest) *Project {
+	project := &Project{
+		TenantID:     request.TenantID,
+		ArtisanID:    b.ArtisanID,
+		CustomerID:   &request.CustomerID,
+		Title:        request.Title,
+		Description:  request.Description,
+		Status:       ProjectStatusPlanned,
+		Priority:     ProjectPriorityMedium,
+		StartDate:    request.PreferredDate,
+		BudgetAmount: roundMoney(b.Amount),
+		Currency:     b.Currency,
+		Metadata: JSONB{
+			"customer_request_id": request.ID.String(),
+			"bid_id":              b.ID.String(),
+		},
+	}
+	if request.PreferredDate != nil && b.EstimatedDays > 0 {
+		due := request.PreferredDate.AddDate(0, 0, b.EstimatedDays)
+		project.DueDate = &due
+	}
+	return project
+}
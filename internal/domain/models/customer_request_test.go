@@ -0,0 +1,177 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validCustomerRequest(now time.Time) models.CustomerRequest {
+	request := models.CustomerRequest{
+		TenantID:    uuid.New(),
+		CustomerID:  uuid.New(),
+		Title:       "Rebuild garden wall",
+		Description: "About 6m of collapsed brick wall along the driveway needs rebuilding.",
+		Category:    models.ServiceCategoryMasonry,
+		PhotoURLs:   models.StringArray{"https://cdn.example.com/wall.jpg"},
+		BudgetMin:   500,
+		BudgetMax:   1200,
+		Currency:    "USD",
+		Status:      models.CustomerRequestStatusOpen,
+		ExpiresAt:   now.Add(models.DefaultCustomerRequestOpenFor),
+	}
+	request.ID = uuid.New()
+	return request
+}
+
+func TestCustomerRequestValidate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		modify  func(r *models.CustomerRequest)
+		wantErr string
+	}{
+		{"valid", func(r *models.CustomerRequest) {}, ""},
+		{"no title", func(r *models.CustomerRequest) { r.Title = "  " }, "title"},
+		{"no description", func(r *models.CustomerRequest) { r.Description = "" }, "description"},
+		{"inverted budget", func(r *models.CustomerRequest) { r.BudgetMin, r.BudgetMax = 900, 300 }, "budget_max"},
+		{"open-ended budget", func(r *models.CustomerRequest) { r.BudgetMax = 0 }, ""},
+		{"photo is not a URL", func(r *models.CustomerRequest) { r.PhotoURLs = models.StringArray{"wall.jpg"} }, "http(s) URL"},
+		{"too many photos", func(r *models.CustomerRequest) {
+			r.PhotoURLs = make(models.StringArray, models.MaxCustomerRequestPhotos+1)
+		}, "photos"},
+		{"location off the map", func(r *models.CustomerRequest) {
+			r.Location = &models.Location{Latitude: 91}
+		}, "latitude"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := validCustomerRequest(now)
+			tt.modify(&request)
+			err := request.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCustomerRequestAward(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newBid := func(request *models.CustomerRequest) *models.CustomerRequestBid {
+		bid := &models.CustomerRequestBid{RequestID: request.ID, Status: models.CustomerRequestBidStatusSubmitted}
+		bid.ID = uuid.New()
+		return bid
+	}
+
+	t.Run("accepts a submitted bid", func(t *testing.T) {
+		request := validCustomerRequest(now)
+		bid := newBid(&request)
+		require.NoError(t, request.Award(bid, now))
+		assert.Equal(t, models.CustomerRequestStatusAwarded, request.Status)
+		assert.Equal(t, &bid.ID, request.AwardedBidID)
+		assert.Equal(t, models.CustomerRequestBidStatusAccepted, bid.Status)
+	})
+
+	t.Run("expired request", func(t *testing.T) {
+		request := validCustomerRequest(now)
+		err := request.Award(newBid(&request), request.ExpiresAt)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expired")
+	})
+
+	t.Run("withdrawn bid", func(t *testing.T) {
+		request := validCustomerRequest(now)
+		bid := newBid(&request)
+		bid.Status = models.CustomerRequestBidStatusWithdrawn
+		assert.Error(t, request.Award(bid, now))
+	})
+
+	t.Run("bid for another request", func(t *testing.T) {
+		request := validCustomerRequest(now)
+		bid := newBid(&request)
+		bid.RequestID = uuid.New()
+		assert.Error(t, request.Award(bid, now))
+	})
+}
+
+func TestCustomerRequestBidValidate(t *testing.T) {
+	start := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+	serviceID := uuid.New()
+
+	tests := []struct {
+		name    string
+		bid     models.CustomerRequestBid
+		wantErr string
+	}{
+		{"project bid", models.CustomerRequestBid{Amount: 950, Currency: "USD", EstimatedDays: 3}, ""},
+		{"booking bid", models.CustomerRequestBid{Amount: 120, Currency: "USD", ServiceID: &serviceID, ProposedStartTime: &start, DurationMinutes: 90}, ""},
+		{"no amount", models.CustomerRequestBid{Currency: "USD"}, "amount"},
+		{"service without a start", models.CustomerRequestBid{Amount: 120, Currency: "USD", ServiceID: &serviceID, DurationMinutes: 90}, "proposed_start_time"},
+		{"service without a duration", models.CustomerRequestBid{Amount: 120, Currency: "USD", ServiceID: &serviceID, ProposedStartTime: &start}, "duration_minutes"},
+		{"duration without a service", models.CustomerRequestBid{Amount: 120, Currency: "USD", DurationMinutes: 60}, "service_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bid.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCustomerRequestBidConversion(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	preferred := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	request := validCustomerRequest(now)
+	request.PreferredDate = &preferred
+	request.Location = &models.Location{City: "Accra", Latitude: 5.6, Longitude: -0.19}
+
+	t.Run("bid for a service becomes a confirmed booking", func(t *testing.T) {
+		start := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+		serviceID := uuid.New()
+		bid := &models.CustomerRequestBid{
+			ArtisanID: uuid.New(), Amount: 120.005, Currency: "USD",
+			ServiceID: &serviceID, ProposedStartTime: &start, DurationMinutes: 90,
+		}
+		require.True(t, bid.ConvertsToBooking())
+
+		artisanUserID, customerUserID := uuid.New(), uuid.New()
+		booking := bid.ToBooking(&request, artisanUserID, customerUserID)
+		assert.Equal(t, artisanUserID, booking.ArtisanID)
+		assert.Equal(t, customerUserID, booking.CustomerID)
+		assert.Equal(t, serviceID, booking.ServiceID)
+		assert.Equal(t, start.Add(90*time.Minute), booking.EndTime)
+		assert.Equal(t, models.BookingStatusConfirmed, booking.Status)
+		assert.Equal(t, 120.01, booking.TotalPrice)
+		assert.Equal(t, request.Location, booking.ServiceLocation)
+	})
+
+	t.Run("other bids become a project", func(t *testing.T) {
+		bid := &models.CustomerRequestBid{ArtisanID: uuid.New(), Amount: 950, Currency: "USD", EstimatedDays: 3}
+		require.False(t, bid.ConvertsToBooking())
+
+		project := bid.ToProject(&request)
+		assert.Equal(t, bid.ArtisanID, project.ArtisanID)
+		assert.Equal(t, &request.CustomerID, project.CustomerID)
+		assert.Equal(t, request.Title, project.Title)
+		assert.Equal(t, 950.0, project.BudgetAmount)
+		assert.Equal(t, models.ProjectStatusPlanned, project.Status)
+		require.NotNil(t, project.DueDate)
+		assert.Equal(t, preferred.AddDate(0, 0, 3), *project.DueDate)
+	})
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CustomerSegment is a group a customer falls in by their booking history, computed
+// nightly (see ComputeCustomerSegments)
+type CustomerSegment string
+
+const (
+	CustomerSegmentNew    CustomerSegment = "new"     // Joined recently and has not come back yet
+	CustomerSegmentRepeat CustomerSegment = "repeat"  // Completed several bookings
+	CustomerSegmentVIP    CustomerSegment = "vip"     // Spent at least the VIP threshold
+	CustomerSegmentAtRisk CustomerSegment = "at_risk" // Booked before but not for a while
+)
+
+// CustomerSegments lists the segments in the order they are reported
+var CustomerSegments = []CustomerSegment{
+	CustomerSegmentNew,
+	CustomerSegmentRepeat,
+	CustomerSegmentVIP,
+	CustomerSegmentAtRisk,
+}
+
+// IsValid checks if the segment is one of the computed segments
+func (s CustomerSegment) IsValid() bool {
+	return slices.Contains(CustomerSegments, s)
+}
+
+const (
+	// MaxCustomerTags is the number of manual tags a customer can carry
+	MaxCustomerTags = 20
+	// MaxCustomerTagLength is the longest customer tag accepted, in characters
+	MaxCustomerTagLength = 50
+)
+
+// CustomerSegmentRules holds the tenant-configurable thresholds customers are segmented by
+type CustomerSegmentRules struct {
+	NewWithinDays     int     `json:"new_within_days" validate:"min=1"`     // Customers who joined this recently are new
+	RepeatMinBookings int     `json:"repeat_min_bookings" validate:"min=2"` // Completed bookings that make a repeat customer
+	VIPMinSpent       float64 `json:"vip_min_spent" validate:"gt=0"`        // Spend on completed bookings that makes a VIP
+	AtRiskAfterDays   int     `json:"at_risk_after_days" validate:"min=1"`  // Days since the last booking before a customer is at risk
+}
+
+// DefaultCustomerSegmentRules returns the rules applied when a tenant has not configured any
+func DefaultCustomerSegmentRules() CustomerSegmentRules {
+	return CustomerSegmentRules{
+		NewWithinDays:     30,
+		RepeatMinBookings: 2,
+		VIPMinSpent:       1000,
+		AtRiskAfterDays:   90,
+	}
+}
+
+// Validate checks the thresholds are usable
+func (r CustomerSegmentRules) Validate() error {
+	if r.NewWithinDays < 1 || r.AtRiskAfterDays < 1 {
+		return fmt.Errorf("new_within_days and at_risk_after_days must be at least 1")
+	}
+	if r.RepeatMinBookings < 2 {
+		return fmt.Errorf("repeat_min_bookings must be at least 2")
+	}
+	if r.VIPMinSpent <= 0 {
+		return fmt.Errorf("vip_min_spent must be positive")
+	}
+	return nil
+}
+
+// CustomerActivity is what a customer's segments are computed from
+type CustomerActivity struct {
+	JoinedAt          time.Time
+	CompletedBookings int
+	TotalSpent        float64    // On completed bookings
+	LastBookingAt     *time.Time // Start of the latest completed booking; nil without one
+}
+
+// ComputeCustomerSegments returns the segments a customer's activity places them in at
+// now, in CustomerSegments order. A customer can be in several, such as a repeat VIP.
+func ComputeCustomerSegments(activity CustomerActivity, rules CustomerSegmentRules, now time.Time) StringArray {
+	const day = 24 * time.Hour
+
+	segments := StringArray{}
+	if activity.CompletedBookings < rules.RepeatMinBookings && now.Sub(activity.JoinedAt) < time.Duration(rules.NewWithinDays)*day {
+		segments = append(segments, string(CustomerSegmentNew))
+	}
+	if activity.CompletedBookings >= rules.RepeatMinBookings {
+		segments = append(segments, string(CustomerSegmentRepeat))
+	}
+	if activity.TotalSpent >= rules.VIPMinSpent {
+		segments = append(segments, string(CustomerSegmentVIP))
+	}
+	if activity.LastBookingAt != nil && now.Sub(*activity.LastBookingAt) >= time.Duration(rules.AtRiskAfterDays)*day {
+		segments = append(segments, string(CustomerSegmentAtRisk))
+	}
+	return segments
+}
+
+// NormalizeCustomerTags trims, lowercases and de-duplicates tags, keeping their order
+func NormalizeCustomerTags(tags []string) (StringArray, error) {
+	normalized := make(StringArray, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if len([]rune(tag)) > MaxCustomerTagLength {
+			return nil, fmt.Errorf("tag %q exceeds %d characters", tag, MaxCustomerTagLength)
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxCustomerTags {
+		return nil, fmt.Errorf("a customer can have at most %d tags", MaxCustomerTags)
+	}
+	return normalized, nil
+}
+
+// CustomerAudience selects customers by segment and tag, as list filters and campaign
+// targeting do. A customer matches when they are in any of the segments and carry every
+// tag; an empty audience matches everyone.
+type CustomerAudience struct {
+	Segments []CustomerSegment `json:"segments,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+}
+
+// Validate checks the segments are known and normalizes the tags
+func (a *CustomerAudience) Validate() error {
+	for _, segment := range a.Segments {
+		if !segment.IsValid() {
+			return fmt.Errorf("unknown customer segment %q", segment)
+		}
+	}
+	tags, err := NormalizeCustomerTags(a.Tags)
+	if err != nil {
+		return err
+	}
+	a.Tags = tags
+	return nil
+}
+
+// IsEmpty reports whether the audience selects every customer
+func (a CustomerAudience) IsEmpty() bool {
+	return len(a.Segments) == 0 && len(a.Tags) == 0
+}
+
+// SegmentNames returns the segments as strings, for queries
+func (a CustomerAudience) SegmentNames() []string {
+	names := make([]string, len(a.Segments))
+	for i, segment := range a.Segments {
+		names[i] = string(segment)
+	}
+	return names
+}
+
+// Matches reports whether the customer is in the audience
+func (a CustomerAudience) Matches(customer *Customer) bool {
+	if len(a.Segments) > 0 && !slices.ContainsFunc(a.Segments, func(s CustomerSegment) bool {
+		return slices.Contains(customer.Segments, string(s))
+	}) {
+		return false
+	}
+	for _, tag := range a.Tags {
+		if !slices.Contains(customer.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeCustomerSegments(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	rules := models.DefaultCustomerSegmentRules()
+	lastWeek := now.AddDate(0, 0, -7)
+	lastYear := now.AddDate(-1, 0, 0)
+
+	tests := []struct {
+		name     string
+		activity models.CustomerActivity
+		want     models.StringArray
+	}{
+		{"just joined", models.CustomerActivity{JoinedAt: lastWeek}, models.StringArray{"new"}},
+		{"one booking still new", models.CustomerActivity{JoinedAt: lastWeek, CompletedBookings: 1, TotalSpent: 80, LastBookingAt: &lastWeek}, models.StringArray{"new"}},
+		{"repeat", models.CustomerActivity{JoinedAt: lastYear, CompletedBookings: 3, TotalSpent: 300, LastBookingAt: &lastWeek}, models.StringArray{"repeat"}},
+		{"repeat VIP", models.CustomerActivity{JoinedAt: lastYear, CompletedBookings: 5, TotalSpent: 1500, LastBookingAt: &lastWeek}, models.StringArray{"repeat", "vip"}},
+		{"lapsed VIP", models.CustomerActivity{JoinedAt: lastYear, CompletedBookings: 1, TotalSpent: 1200, LastBookingAt: &lastYear}, models.StringArray{"vip", "at_risk"}},
+		{"never booked", models.CustomerActivity{JoinedAt: lastYear}, models.StringArray{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, models.ComputeCustomerSegments(tt.activity, rules, now))
+		})
+	}
+
+	t.Run("tenant thresholds", func(t *testing.T) {
+		strict := models.CustomerSegmentRules{NewWithinDays: 3, RepeatMinBookings: 5, VIPMinSpent: 5000, AtRiskAfterDays: 5}
+		require.NoError(t, strict.Validate())
+
+		activity := models.CustomerActivity{JoinedAt: lastWeek, CompletedBookings: 3, TotalSpent: 1500, LastBookingAt: &lastWeek}
+		assert.Equal(t, models.StringArray{"at_risk"}, models.ComputeCustomerSegments(activity, strict, now))
+	})
+}
+
+func TestCustomerAudience(t *testing.T) {
+	vip := &models.Customer{Segments: models.StringArray{"repeat", "vip"}, Tags: models.StringArray{"wholesale", "accra"}}
+	newcomer := &models.Customer{Segments: models.StringArray{"new"}}
+
+	t.Run("validate normalizes tags", func(t *testing.T) {
+		audience := models.CustomerAudience{Segments: []models.CustomerSegment{models.CustomerSegmentVIP}, Tags: []string{" Wholesale ", "wholesale"}}
+		require.NoError(t, audience.Validate())
+		assert.Equal(t, []string{"wholesale"}, audience.Tags)
+
+		unknown := models.CustomerAudience{Segments: []models.CustomerSegment{"whale"}}
+		assert.Error(t, unknown.Validate())
+	})
+
+	t.Run("matches", func(t *testing.T) {
+		assert.True(t, models.CustomerAudience{}.Matches(newcomer), "an empty audience matches everyone")
+
+		anySegment := models.CustomerAudience{Segments: []models.CustomerSegment{models.CustomerSegmentNew, models.CustomerSegmentVIP}}
+		assert.True(t, anySegment.Matches(vip))
+		assert.True(t, anySegment.Matches(newcomer))
+
+		everyTag := models.CustomerAudience{Segments: []models.CustomerSegment{models.CustomerSegmentVIP}, Tags: []string{"wholesale", "accra"}}
+		assert.True(t, everyTag.Matches(vip))
+		assert.False(t, everyTag.Matches(newcomer))
+
+		missingTag := models.CustomerAudience{Tags: []string{"wholesale", "kumasi"}}
+		assert.False(t, missingTag.Matches(vip))
+	})
+}
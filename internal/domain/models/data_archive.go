@@ -0,0 +1,31 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DataArchive records one month of a partitioned table exported to cold storage. The
+// export is gzipped JSON lines, one row per line; once recorded, the month's partition
+// is dropped from the database.
+type DataArchive struct {
+	BaseModel
+
+	SourceTable string    `json:"source_table" gorm:"size:63;not null;uniqueIndex:idx_data_archive_period,priority:1"`
+	PeriodStart time.Time `json:"period_start" gorm:"not null;uniqueIndex:idx_data_archive_period,priority:2"` // First instant of the month, UTC
+	PeriodEnd   time.Time `json:"period_end" gorm:"not null"`                                                  // First instant of the next month
+	ObjectKey   string    `json:"object_key" gorm:"size:500;not null"`
+	RowCount    int64     `json:"row_count" gorm:"not null;default:0"`
+	SizeBytes   int64     `json:"size_bytes" gorm:"not null;default:0"`
+	ArchivedAt  time.Time `json:"archived_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for DataArchive
+func (DataArchive) TableName() string {
+	return "data_archives"
+}
+
+// DataArchiveKey returns the object key a table's month is exported under
+func DataArchiveKey(table string, periodStart time.Time) string {
+	return fmt.Sprintf("archive/%s/%s.jsonl.gz", table, periodStart.UTC().Format("2006-01"))
+}
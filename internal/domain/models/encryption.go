@@ -0,0 +1,167 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"Krafti_Vibe/internal/pkg/fieldcrypt"
+	"Krafti_Vibe/internal/pkg/redact"
+
+	"gorm.io/gorm/schema"
+)
+
+// Sensitive columns are encrypted at rest by these serializers, with the cipher set by
+// fieldcrypt.SetDefault. Without one, values are written in plaintext.
+//
+//   - encrypted_lookup: a string encrypted deterministically, so it can still be found
+//     with fieldcrypt's LookupValues
+//   - encrypted_metadata: a JSONB object whose sensitive keys (see redact.IsSensitiveKey)
+//     have their values encrypted; other keys stay queryable
+func init() {
+	schema.RegisterSerializer("encrypted_lookup", encryptedLookupSerializer{})
+	schema.RegisterSerializer("encrypted_metadata", encryptedMetadataSerializer{})
+}
+
+type encryptedLookupSerializer struct{}
+
+func (encryptedLookupSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("unsupported value %T for encrypted column %s", dbValue, field.DBName)
+	}
+
+	plaintext, err := fieldcrypt.Default().Decrypt(value)
+	if err != nil {
+		return fmt.Errorf("decrypt %s: %w", field.DBName, err)
+	}
+	field.ReflectValueOf(ctx, dst).SetString(plaintext)
+	return nil
+}
+
+func (encryptedLookupSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	value, _ := fieldValue.(string)
+	if c := fieldcrypt.Default(); c != nil && !fieldcrypt.IsEncrypted(value) {
+		return c.EncryptDeterministic(value), nil
+	}
+	return value, nil
+}
+
+type encryptedMetadataSerializer struct{}
+
+func (encryptedMetadataSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	var data []byte
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported value %T for encrypted column %s", dbValue, field.DBName)
+	}
+
+	metadata := JSONB{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &metadata); err != nil {
+			return err
+		}
+	}
+	if err := decryptMetadata(fieldcrypt.Default(), metadata); err != nil {
+		return fmt.Errorf("decrypt %s: %w", field.DBName, err)
+	}
+	field.ReflectValueOf(ctx, dst).Set(reflect.ValueOf(metadata))
+	return nil
+}
+
+func (encryptedMetadataSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	metadata, _ := fieldValue.(JSONB)
+	if metadata == nil {
+		return nil, nil
+	}
+
+	if c := fieldcrypt.Default(); c != nil {
+		encrypted, err := encryptMetadata(c, metadata)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt %s: %w", field.DBName, err)
+		}
+		metadata = encrypted
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// encryptMetadata returns a copy of m with the values of sensitive keys replaced by
+// the ciphertext of their JSON encoding, descending into nested objects
+func encryptMetadata(c *fieldcrypt.Cipher, m map[string]any) (JSONB, error) {
+	result := make(JSONB, len(m))
+	for key, value := range m {
+		if s, ok := value.(string); ok && fieldcrypt.IsEncrypted(s) {
+			result[key] = s
+			continue
+		}
+		if redact.IsSensitiveKey(key) {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			if result[key], err = c.Encrypt(string(data)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if nested, ok := asMap(value); ok {
+			encrypted, err := encryptMetadata(c, nested)
+			if err != nil {
+				return nil, err
+			}
+			value = map[string]any(encrypted)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// decryptMetadata restores the encrypted values of m in place
+func decryptMetadata(c *fieldcrypt.Cipher, m map[string]any) error {
+	for key, value := range m {
+		if s, ok := value.(string); ok && fieldcrypt.IsEncrypted(s) {
+			plaintext, err := c.Decrypt(s)
+			if err != nil {
+				return err
+			}
+			var decoded any
+			if err := json.Unmarshal([]byte(plaintext), &decoded); err != nil {
+				return err
+			}
+			m[key] = decoded
+			continue
+		}
+		if nested, ok := asMap(value); ok {
+			if err := decryptMetadata(c, nested); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func asMap(value any) (map[string]any, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		return v, true
+	case JSONB:
+		return v, true
+	}
+	return nil, false
+}
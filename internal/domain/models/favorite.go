@@ -0,0 +1,90 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FavoriteKind is what a customer favorited
+type FavoriteKind string
+
+const (
+	FavoriteKindArtisan FavoriteKind = "artisan"
+	FavoriteKindService FavoriteKind = "service"
+)
+
+// IsValid checks if the kind is one of the supported favorite kinds
+func (k FavoriteKind) IsValid() bool {
+	return k == FavoriteKindArtisan || k == FavoriteKindService
+}
+
+// FavoriteNotifyCooldown is how long a customer who favorited an artisan hears nothing
+// more about them after being notified, so an artisan adding many slots sends one notice
+const FavoriteNotifyCooldown = 24 * time.Hour
+
+// Favorite is an artisan or a service a customer saved to come back to. Exactly one of
+// ArtisanID and ServiceID is set.
+type Favorite struct {
+	BaseModel
+
+	TenantID   uuid.UUID  `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	CustomerID uuid.UUID  `json:"customer_id" gorm:"type:uuid;not null;uniqueIndex:idx_favorite_customer_artisan;uniqueIndex:idx_favorite_customer_service"` // User ID
+	ArtisanID  *uuid.UUID `json:"artisan_id,omitempty" gorm:"type:uuid;uniqueIndex:idx_favorite_customer_artisan;index"`                                     // Artisan profile ID
+	ServiceID  *uuid.UUID `json:"service_id,omitempty" gorm:"type:uuid;uniqueIndex:idx_favorite_customer_service;index"`
+
+	// Notifications: customers can ask to hear when a favorited artisan opens new
+	// availability or publishes a new service
+	Notify     bool       `json:"notify" gorm:"default:false"`
+	NotifiedAt *time.Time `json:"notified_at,omitempty"`
+
+	// Relationships
+	Artisan *Artisan `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
+	Service *Service `json:"service,omitempty" gorm:"foreignKey:ServiceID"`
+}
+
+// TableName specifies the table name for Favorite
+func (Favorite) TableName() string {
+	return "favorites"
+}
+
+// Kind returns whether the favorite is an artisan or a service
+func (f *Favorite) Kind() FavoriteKind {
+	if f.ArtisanID != nil {
+		return FavoriteKindArtisan
+	}
+	return FavoriteKindService
+}
+
+// TargetID returns the ID of the favorited artisan profile or service
+func (f *Favorite) TargetID() uuid.UUID {
+	if f.ArtisanID != nil {
+		return *f.ArtisanID
+	}
+	if f.ServiceID != nil {
+		return *f.ServiceID
+	}
+	return uuid.Nil
+}
+
+// Validate checks the favorite has exactly one target, and only artisan favorites
+// ask for notifications
+func (f *Favorite) Validate() error {
+	if (f.ArtisanID == nil) == (f.ServiceID == nil) {
+		return errors.New("a favorite is either an artisan or a service")
+	}
+	if f.Notify && f.ArtisanID == nil {
+		return errors.New("notifications are only available for favorite artisans")
+	}
+	return nil
+}
+
+// ShouldNotify reports whether the customer wants to hear about the favorited artisan
+// and was not notified within the cooldown
+func (f *Favorite) ShouldNotify(now time.Time) bool {
+	if !f.Notify || f.ArtisanID == nil {
+		return false
+	}
+	return f.NotifiedAt == nil || now.Sub(*f.NotifiedAt) >= FavoriteNotifyCooldown
+}
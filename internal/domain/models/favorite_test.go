@@ -0,0 +1,67 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavoriteValidate(t *testing.T) {
+	artisanID := uuid.New()
+	serviceID := uuid.New()
+
+	tests := []struct {
+		name     string
+		favorite models.Favorite
+		wantErr  bool
+	}{
+		{"artisan", models.Favorite{ArtisanID: &artisanID, Notify: true}, false},
+		{"service", models.Favorite{ServiceID: &serviceID}, false},
+		{"no target", models.Favorite{}, true},
+		{"both targets", models.Favorite{ArtisanID: &artisanID, ServiceID: &serviceID}, true},
+		{"notify on a service", models.Favorite{ServiceID: &serviceID, Notify: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.favorite.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	service := models.Favorite{ServiceID: &serviceID}
+	assert.Equal(t, models.FavoriteKindService, service.Kind())
+	assert.Equal(t, serviceID, service.TargetID())
+}
+
+func TestFavoriteShouldNotify(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	artisanID := uuid.New()
+	serviceID := uuid.New()
+	earlierToday := now.Add(-2 * time.Hour)
+	yesterday := now.Add(-models.FavoriteNotifyCooldown)
+
+	tests := []struct {
+		name     string
+		favorite models.Favorite
+		want     bool
+	}{
+		{"never notified", models.Favorite{ArtisanID: &artisanID, Notify: true}, true},
+		{"notified within the cooldown", models.Favorite{ArtisanID: &artisanID, Notify: true, NotifiedAt: &earlierToday}, false},
+		{"cooldown passed", models.Favorite{ArtisanID: &artisanID, Notify: true, NotifiedAt: &yesterday}, true},
+		{"did not ask", models.Favorite{ArtisanID: &artisanID}, false},
+		{"services never notify", models.Favorite{ServiceID: &serviceID, Notify: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.favorite.ShouldNotify(now))
+		})
+	}
+}
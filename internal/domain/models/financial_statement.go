@@ -0,0 +1,157 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatementKind says whose finances a statement covers
+type StatementKind string
+
+const (
+	StatementKindArtisan StatementKind = "artisan" // An artisan's earnings
+	StatementKindTenant  StatementKind = "tenant"  // A tenant's revenue and commission
+)
+
+// IsValid reports whether the kind is supported
+func (k StatementKind) IsValid() bool {
+	return k == StatementKindArtisan || k == StatementKindTenant
+}
+
+// StatementFormat is a file format statements are rendered in
+type StatementFormat string
+
+const (
+	StatementFormatCSV StatementFormat = "csv"
+	StatementFormatPDF StatementFormat = "pdf"
+)
+
+// IsValid reports whether the format is supported
+func (f StatementFormat) IsValid() bool {
+	return f == StatementFormatCSV || f == StatementFormatPDF
+}
+
+// ContentType returns the MIME type of files in this format
+func (f StatementFormat) ContentType() string {
+	if f == StatementFormatPDF {
+		return "application/pdf"
+	}
+	return "text/csv"
+}
+
+// StatementTotal sums a statement's payments in one currency
+type StatementTotal struct {
+	Currency   string  `json:"currency"`
+	Payments   int     `json:"payments"`
+	Gross      float64 `json:"gross"`      // Collected from customers
+	Refunded   float64 `json:"refunded"`   // Returned to customers
+	Tax        float64 `json:"tax"`        // Kept by the tenant for the tax authority
+	Commission float64 `json:"commission"` // Platform share
+	Earnings   float64 `json:"earnings"`   // Artisan share
+}
+
+// StatementTotals is stored as a JSONB array with one entry per currency
+type StatementTotals []StatementTotal
+
+// Add counts a settled payment in the total for its currency
+func (t *StatementTotals) Add(p *Payment) {
+	var total *StatementTotal
+	for i := range *t {
+		if (*t)[i].Currency == p.Currency {
+			total = &(*t)[i]
+			break
+		}
+	}
+	if total == nil {
+		*t = append(*t, StatementTotal{Currency: p.Currency})
+		total = &(*t)[len(*t)-1]
+	}
+
+	total.Payments++
+	total.Gross = roundMoney(total.Gross + p.Amount)
+	total.Refunded = roundMoney(total.Refunded + p.RefundedAmount)
+	total.Tax = roundMoney(total.Tax + p.TaxAmount)
+	total.Commission = roundMoney(total.Commission + p.PlatformAmount)
+	total.Earnings = roundMoney(total.Earnings + p.ArtisanAmount)
+}
+
+func (t *StatementTotals) Scan(value interface{}) error {
+	if value == nil {
+		*t = StatementTotals{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, t)
+}
+
+func (t StatementTotals) Value() (driver.Value, error) {
+	if t == nil {
+		return json.Marshal([]StatementTotal{})
+	}
+	return json.Marshal(t)
+}
+
+// FinancialStatement is a monthly statement of settled payments, rendered as CSV and PDF
+// files in object storage. An artisan statement's subject is the artisan's user ID; a
+// tenant statement's is the tenant ID. Regenerating a month replaces its statements.
+type FinancialStatement struct {
+	BaseModel
+
+	TenantID  uuid.UUID     `json:"tenant_id" gorm:"type:uuid;not null;uniqueIndex:idx_statement_subject_period"`
+	Kind      StatementKind `json:"kind" gorm:"type:varchar(20);not null;uniqueIndex:idx_statement_subject_period"`
+	SubjectID uuid.UUID     `json:"subject_id" gorm:"type:uuid;not null;uniqueIndex:idx_statement_subject_period;index"`
+
+	// Period covered, the first day of the month up to the first day of the next
+	PeriodStart time.Time `json:"period_start" gorm:"type:date;not null;uniqueIndex:idx_statement_subject_period"`
+	PeriodEnd   time.Time `json:"period_end" gorm:"type:date;not null"`
+
+	Totals      StatementTotals `json:"totals" gorm:"type:jsonb"`
+	GeneratedAt time.Time       `json:"generated_at" gorm:"not null"`
+
+	// Stored files
+	CSVKey  string `json:"-" gorm:"size:512"`
+	CSVSize int64  `json:"csv_size" gorm:"default:0"` // bytes
+	PDFKey  string `json:"-" gorm:"size:512"`
+	PDFSize int64  `json:"pdf_size" gorm:"default:0"` // bytes
+
+	// Relationships
+	Tenant *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+}
+
+// TableName specifies the table name for FinancialStatement
+func (FinancialStatement) TableName() string {
+	return "financial_statements"
+}
+
+// StatementPeriod returns the calendar month containing t, in UTC
+func StatementPeriod(t time.Time) (start, end time.Time) {
+	t = t.UTC()
+	start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// FileKey returns the stored file of the statement in a format
+func (s *FinancialStatement) FileKey(format StatementFormat) string {
+	if format == StatementFormatPDF {
+		return s.PDFKey
+	}
+	return s.CSVKey
+}
+
+// FileName returns the name offered to the browser when downloading the statement
+func (s *FinancialStatement) FileName(format StatementFormat) string {
+	name := "revenue-statement-"
+	if s.Kind == StatementKindArtisan {
+		name = "earnings-statement-"
+	}
+	return name + s.PeriodStart.Format("2006-01") + "." + string(format)
+}
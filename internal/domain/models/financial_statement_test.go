@@ -0,0 +1,51 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementTotalsAddGroupsByCurrency(t *testing.T) {
+	totals := models.StatementTotals{}
+	totals.Add(&models.Payment{Currency: "USD", Amount: 100, TaxAmount: 8, PlatformAmount: 15, ArtisanAmount: 77})
+	totals.Add(&models.Payment{Currency: "EUR", Amount: 50, PlatformAmount: 5, ArtisanAmount: 45})
+	totals.Add(&models.Payment{Currency: "USD", Amount: 20.1, RefundedAmount: 20.1, PlatformAmount: 3.02, ArtisanAmount: 17.08})
+
+	require.Len(t, totals, 2)
+	assert.Equal(t, models.StatementTotal{
+		Currency: "USD", Payments: 2, Gross: 120.1, Refunded: 20.1, Tax: 8, Commission: 18.02, Earnings: 94.08,
+	}, totals[0])
+	assert.Equal(t, "EUR", totals[1].Currency)
+	assert.Equal(t, 1, totals[1].Payments)
+}
+
+func TestStatementPeriodCoversCalendarMonth(t *testing.T) {
+	start, end := models.StatementPeriod(time.Date(2025, 12, 31, 23, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), end)
+
+	// Months are UTC months whatever the caller's zone
+	start, _ = models.StatementPeriod(time.Date(2026, 2, 1, 0, 30, 0, 0, time.FixedZone("CET", 3600)))
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), start)
+}
+
+func TestFinancialStatementFiles(t *testing.T) {
+	statement := &models.FinancialStatement{
+		Kind:        models.StatementKindArtisan,
+		PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		CSVKey:      "statements/a.csv",
+		PDFKey:      "statements/a.pdf",
+	}
+
+	assert.Equal(t, "statements/a.pdf", statement.FileKey(models.StatementFormatPDF))
+	assert.Equal(t, "statements/a.csv", statement.FileKey(models.StatementFormatCSV))
+	assert.Equal(t, "earnings-statement-2026-03.pdf", statement.FileName(models.StatementFormatPDF))
+
+	statement.Kind = models.StatementKindTenant
+	assert.Equal(t, "revenue-statement-2026-03.csv", statement.FileName(models.StatementFormatCSV))
+}
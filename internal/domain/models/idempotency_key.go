@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyKey stores the outcome of a request sent with an Idempotency-Key header,
+// so a retried request gets the original response instead of running twice
+type IdempotencyKey struct {
+	BaseModel
+
+	// Key is a hash of the client's key and the caller, method and path it was sent with
+	Key         string `json:"key" gorm:"size:64;not null;uniqueIndex"`
+	Fingerprint string `json:"fingerprint" gorm:"size:64;not null"` // SHA-256 of the request body
+
+	// Response, set once the first request has finished
+	Completed    bool   `json:"completed" gorm:"default:false"`
+	StatusCode   int    `json:"status_code,omitempty"`
+	ContentType  string `json:"content_type,omitempty" gorm:"size:100"`
+	ResponseBody []byte `json:"response_body,omitempty" gorm:"type:bytea"`
+
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName specifies the table name for IdempotencyKey
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// IsExpired checks if the key can no longer be replayed
+func (k *IdempotencyKey) IsExpired() bool {
+	return time.Now().After(k.ExpiresAt)
+}
@@ -0,0 +1,315 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IntakeFieldType is the kind of answer an intake question expects
+type IntakeFieldType string
+
+const (
+	IntakeFieldText        IntakeFieldType = "text"
+	IntakeFieldTextarea    IntakeFieldType = "textarea"
+	IntakeFieldNumber      IntakeFieldType = "number"
+	IntakeFieldBoolean     IntakeFieldType = "boolean"
+	IntakeFieldSelect      IntakeFieldType = "select"
+	IntakeFieldMultiSelect IntakeFieldType = "multi_select"
+	IntakeFieldDate        IntakeFieldType = "date"
+)
+
+// Intake form limits
+const (
+	MaxIntakeFields             = 50
+	defaultIntakeTextLength     = 1000
+	defaultIntakeTextareaLength = 5000
+	intakeDateLayout            = "2006-01-02"
+)
+
+var intakeFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// IntakeField is a single question of a service's pre-appointment questionnaire
+type IntakeField struct {
+	Key       string          `json:"key"`
+	Label     string          `json:"label"`
+	Type      IntakeFieldType `json:"type"`
+	Required  bool            `json:"required"`
+	HelpText  string          `json:"help_text,omitempty"`
+	Options   []string        `json:"options,omitempty"`    // select and multi_select
+	MaxLength int             `json:"max_length,omitempty"` // text and textarea
+	Min       *float64        `json:"min,omitempty"`        // number
+	Max       *float64        `json:"max,omitempty"`        // number
+}
+
+// IntakeForm is the questionnaire customers answer when booking a service
+type IntakeForm struct {
+	Fields []IntakeField `json:"fields"`
+}
+
+// IntakeAnswerErrors maps field keys to the reason their answer was rejected
+type IntakeAnswerErrors map[string]string
+
+func (e IntakeAnswerErrors) Error() string {
+	keys := make([]string, 0, len(e))
+	for key := range e {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+": "+e[key])
+	}
+	return "invalid intake answers: " + strings.Join(parts, "; ")
+}
+
+// Validate checks the form definition itself
+func (f *IntakeForm) Validate() error {
+	if len(f.Fields) > MaxIntakeFields {
+		return fmt.Errorf("intake form cannot have more than %d fields", MaxIntakeFields)
+	}
+
+	seen := make(map[string]bool, len(f.Fields))
+	for i, field := range f.Fields {
+		if !intakeFieldKeyPattern.MatchString(field.Key) {
+			return fmt.Errorf("field %d: key must be lowercase letters, digits and underscores, starting with a letter", i+1)
+		}
+		if seen[field.Key] {
+			return fmt.Errorf("field %q: duplicate key", field.Key)
+		}
+		seen[field.Key] = true
+
+		if strings.TrimSpace(field.Label) == "" {
+			return fmt.Errorf("field %q: label is required", field.Key)
+		}
+
+		switch field.Type {
+		case IntakeFieldText, IntakeFieldTextarea:
+			if field.MaxLength < 0 {
+				return fmt.Errorf("field %q: max_length cannot be negative", field.Key)
+			}
+		case IntakeFieldNumber:
+			if field.Min != nil && field.Max != nil && *field.Min > *field.Max {
+				return fmt.Errorf("field %q: min cannot be greater than max", field.Key)
+			}
+		case IntakeFieldSelect, IntakeFieldMultiSelect:
+			if len(field.Options) == 0 {
+				return fmt.Errorf("field %q: options are required", field.Key)
+			}
+		case IntakeFieldBoolean, IntakeFieldDate:
+		default:
+			return fmt.Errorf("field %q: invalid type %q", field.Key, field.Type)
+		}
+	}
+
+	return nil
+}
+
+// ValidateAnswers checks the answers against the form and returns them normalised:
+// unknown keys are rejected, empty optional answers are dropped and numbers, dates
+// and selections are stored in a canonical form. A nil form accepts no answers.
+func (f *IntakeForm) ValidateAnswers(answers JSONB) (JSONB, error) {
+	problems := IntakeAnswerErrors{}
+	normalised := JSONB{}
+
+	var fields []IntakeField
+	if f != nil {
+		fields = f.Fields
+	}
+
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.Key] = true
+
+		value, present := answers[field.Key]
+		if !present || isEmptyIntakeAnswer(value) {
+			if field.Required {
+				problems[field.Key] = "answer is required"
+			}
+			continue
+		}
+
+		clean, err := field.normalise(value)
+		if err != nil {
+			problems[field.Key] = err.Error()
+			continue
+		}
+		normalised[field.Key] = clean
+	}
+
+	for key := range answers {
+		if !known[key] {
+			problems[key] = "unknown question"
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	if len(normalised) == 0 {
+		return nil, nil
+	}
+	return normalised, nil
+}
+
+// Summary renders the answers as "Label: value" lines in form order
+func (f *IntakeForm) Summary(answers JSONB) []string {
+	if f == nil || len(answers) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(answers))
+	for _, field := range f.Fields {
+		value, ok := answers[field.Key]
+		if !ok {
+			continue
+		}
+		lines = append(lines, field.Label+": "+formatIntakeAnswer(value))
+	}
+	return lines
+}
+
+// normalise validates a single answer and converts it to its stored form
+func (field IntakeField) normalise(value any) (any, error) {
+	switch field.Type {
+	case IntakeFieldText, IntakeFieldTextarea:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("must be text")
+		}
+		s = strings.TrimSpace(s)
+		if limit := field.maxLength(); len([]rune(s)) > limit {
+			return nil, fmt.Errorf("must be %d characters or less", limit)
+		}
+		return s, nil
+
+	case IntakeFieldNumber:
+		n, ok := value.(float64)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, errors.New("must be a number")
+		}
+		if field.Min != nil && n < *field.Min {
+			return nil, fmt.Errorf("must be at least %g", *field.Min)
+		}
+		if field.Max != nil && n > *field.Max {
+			return nil, fmt.Errorf("must be at most %g", *field.Max)
+		}
+		return n, nil
+
+	case IntakeFieldBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, errors.New("must be true or false")
+		}
+		return b, nil
+
+	case IntakeFieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("must be a date (YYYY-MM-DD)")
+		}
+		d, err := time.Parse(intakeDateLayout, strings.TrimSpace(s))
+		if err != nil {
+			return nil, errors.New("must be a date (YYYY-MM-DD)")
+		}
+		return d.Format(intakeDateLayout), nil
+
+	case IntakeFieldSelect:
+		s, ok := value.(string)
+		if !ok || !slices.Contains(field.Options, s) {
+			return nil, errors.New("must be one of the listed options")
+		}
+		return s, nil
+
+	case IntakeFieldMultiSelect:
+		items, ok := value.([]any)
+		if !ok {
+			return nil, errors.New("must be a list of options")
+		}
+		selected := make([]string, 0, len(items))
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok || !slices.Contains(field.Options, s) {
+				return nil, errors.New("must only contain listed options")
+			}
+			if !slices.Contains(selected, s) {
+				selected = append(selected, s)
+			}
+		}
+		return selected, nil
+	}
+
+	return nil, fmt.Errorf("unsupported field type %q", field.Type)
+}
+
+func (field IntakeField) maxLength() int {
+	if field.MaxLength > 0 {
+		return field.MaxLength
+	}
+	if field.Type == IntakeFieldTextarea {
+		return defaultIntakeTextareaLength
+	}
+	return defaultIntakeTextLength
+}
+
+func isEmptyIntakeAnswer(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+func formatIntakeAnswer(value any) string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case float64:
+		return fmt.Sprintf("%g", v)
+	case []string:
+		return strings.Join(v, ", ")
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(value)
+}
+
+func (f *IntakeForm) Scan(value interface{}) error {
+	if value == nil {
+		*f = IntakeForm{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, f)
+}
+
+func (f IntakeForm) Value() (driver.Value, error) {
+	if f.Fields == nil {
+		f.Fields = []IntakeField{}
+	}
+	return json.Marshal(f)
+}
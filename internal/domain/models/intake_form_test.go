@@ -0,0 +1,109 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIntakeForm() *models.IntakeForm {
+	maxPets := 5.0
+	return &models.IntakeForm{
+		Fields: []models.IntakeField{
+			{Key: "hair_length", Label: "Hair length", Type: models.IntakeFieldSelect, Required: true, Options: []string{"short", "medium", "long"}},
+			{Key: "allergies", Label: "Allergies", Type: models.IntakeFieldText, MaxLength: 20},
+			{Key: "pets", Label: "Number of pets", Type: models.IntakeFieldNumber, Max: &maxPets},
+			{Key: "first_visit", Label: "First visit", Type: models.IntakeFieldBoolean},
+			{Key: "treatments", Label: "Treatments", Type: models.IntakeFieldMultiSelect, Options: []string{"wash", "colour", "cut"}},
+			{Key: "last_colour", Label: "Last coloured", Type: models.IntakeFieldDate},
+		},
+	}
+}
+
+func TestIntakeForm_Validate(t *testing.T) {
+	assert.NoError(t, testIntakeForm().Validate())
+
+	tests := []struct {
+		name  string
+		field models.IntakeField
+	}{
+		{"bad key", models.IntakeField{Key: "Hair Length", Label: "Hair", Type: models.IntakeFieldText}},
+		{"missing label", models.IntakeField{Key: "hair", Type: models.IntakeFieldText}},
+		{"unknown type", models.IntakeField{Key: "hair", Label: "Hair", Type: "colour"}},
+		{"select without options", models.IntakeField{Key: "hair", Label: "Hair", Type: models.IntakeFieldSelect}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &models.IntakeForm{Fields: []models.IntakeField{tt.field}}
+			assert.Error(t, form.Validate())
+		})
+	}
+
+	duplicate := &models.IntakeForm{Fields: []models.IntakeField{
+		{Key: "hair", Label: "Hair", Type: models.IntakeFieldText},
+		{Key: "hair", Label: "Hair again", Type: models.IntakeFieldText},
+	}}
+	assert.ErrorContains(t, duplicate.Validate(), "duplicate key")
+}
+
+func TestIntakeForm_ValidateAnswers(t *testing.T) {
+	form := testIntakeForm()
+
+	answers, err := form.ValidateAnswers(models.JSONB{
+		"hair_length": "long",
+		"allergies":   "  none  ",
+		"pets":        2.0,
+		"first_visit": true,
+		"treatments":  []any{"wash", "cut", "wash"},
+		"last_colour": "2025-03-01",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "none", answers["allergies"])
+	assert.Equal(t, []string{"wash", "cut"}, answers["treatments"])
+
+	_, err = form.ValidateAnswers(models.JSONB{
+		"allergies":   "a very long list of allergies",
+		"pets":        9.0,
+		"treatments":  []any{"perm"},
+		"last_colour": "yesterday",
+		"favourite":   "blue",
+	})
+	var problems models.IntakeAnswerErrors
+	require.ErrorAs(t, err, &problems)
+	assert.Equal(t, "answer is required", problems["hair_length"])
+	assert.Contains(t, problems, "allergies")
+	assert.Contains(t, problems, "pets")
+	assert.Contains(t, problems, "treatments")
+	assert.Contains(t, problems, "last_colour")
+	assert.Equal(t, "unknown question", problems["favourite"])
+
+	// Empty optional answers are dropped
+	answers, err = form.ValidateAnswers(models.JSONB{"hair_length": "short", "allergies": ""})
+	require.NoError(t, err)
+	assert.Equal(t, models.JSONB{"hair_length": "short"}, answers)
+}
+
+func TestIntakeForm_NilFormRejectsAnswers(t *testing.T) {
+	var form *models.IntakeForm
+
+	answers, err := form.ValidateAnswers(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, answers)
+
+	_, err = form.ValidateAnswers(models.JSONB{"anything": "x"})
+	assert.Error(t, err)
+}
+
+func TestIntakeForm_Summary(t *testing.T) {
+	form := testIntakeForm()
+
+	lines := form.Summary(models.JSONB{
+		"first_visit": false,
+		"hair_length": "long",
+		"treatments":  []string{"wash", "cut"},
+	})
+	assert.Equal(t, []string{"Hair length: long", "First visit: No", "Treatments: wash, cut"}, lines)
+}
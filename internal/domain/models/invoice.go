@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,10 +25,10 @@ type Invoice struct {
 	BaseModel
 
 	// Multi-tenancy
-	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_invoice_tenant_number"`
 
-	// Invoice Number
-	InvoiceNumber string `json:"invoice_number" gorm:"uniqueIndex;not null;size:50" validate:"required"`
+	// Invoice Number, sequential per tenant (see InvoiceSequence)
+	InvoiceNumber string `json:"invoice_number" gorm:"uniqueIndex:idx_invoice_tenant_number;not null;size:50" validate:"required"`
 
 	// References
 	BookingID  *uuid.UUID `json:"booking_id,omitempty" gorm:"type:uuid;index"`
@@ -50,7 +51,10 @@ type Invoice struct {
 	Status InvoiceStatus `json:"status" gorm:"type:varchar(50);not null;default:'draft'" validate:"required"`
 
 	// Line Items
-	LineItems []InvoiceLineItem `json:"line_items" gorm:"type:jsonb"`
+	LineItems InvoiceLineItems `json:"line_items" gorm:"type:jsonb"`
+
+	// Tax Lines; TaxAmount is the sum of those added on top of the line items
+	TaxLines TaxLines `json:"tax_lines,omitempty" gorm:"type:jsonb"`
 
 	// Notes
 	Notes           string `json:"notes,omitempty" gorm:"type:text"`
@@ -75,17 +79,46 @@ type InvoiceLineItem struct {
 	TotalPrice  float64 `json:"total_price" validate:"required,min=0"`
 }
 
-// Scan and Value methods for InvoiceLineItem slice
-func (ili *InvoiceLineItem) Scan(value interface{}) error {
+// InvoiceLineItems is stored as a JSON array
+type InvoiceLineItems []InvoiceLineItem
+
+func (l *InvoiceLineItems) Scan(value interface{}) error {
+	if value == nil {
+		*l = InvoiceLineItems{}
+		return nil
+	}
+
 	bytes, ok := value.([]byte)
 	if !ok {
 		return errors.New("type assertion to []byte failed")
 	}
-	return json.Unmarshal(bytes, &ili)
+
+	return json.Unmarshal(bytes, l)
+}
+
+func (l InvoiceLineItems) Value() (driver.Value, error) {
+	if l == nil {
+		return json.Marshal([]InvoiceLineItem{})
+	}
+	return json.Marshal(l)
+}
+
+// InvoiceSequence holds the last invoice number issued by a tenant. It is incremented
+// in the transaction that creates the invoice, so numbers have no gaps or duplicates.
+type InvoiceSequence struct {
+	TenantID   uuid.UUID `json:"tenant_id" gorm:"type:uuid;primaryKey"`
+	LastNumber int64     `json:"last_number" gorm:"not null;default:0"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for InvoiceSequence
+func (InvoiceSequence) TableName() string {
+	return "invoice_sequences"
 }
 
-func (ili InvoiceLineItem) Value() (driver.Value, error) {
-	return json.Marshal(ili)
+// FormatInvoiceNumber renders a tenant's nth invoice number
+func FormatInvoiceNumber(n int64) string {
+	return fmt.Sprintf("INV-%06d", n)
 }
 
 // Business Methods
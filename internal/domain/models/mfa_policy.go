@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// DefaultStepUpMaxAge is how recent a multi-factor sign-in must be for sensitive
+// actions when a tenant doesn't set it
+const DefaultStepUpMaxAge = 10 * time.Minute
+
+// MFAPolicy holds a tenant's requirements for multi-factor authentication
+type MFAPolicy struct {
+	// RequireForAdmins refuses owners and admins signed in without a second factor
+	RequireForAdmins bool `json:"require_for_admins"`
+	// RequireForArtisans refuses artisans and team members signed in without a second factor
+	RequireForArtisans bool `json:"require_for_artisans"`
+	// StepUpSensitiveActions requires a recent multi-factor sign-in for refunds and payouts
+	StepUpSensitiveActions bool `json:"step_up_sensitive_actions"`
+	// StepUpMaxAgeMinutes is how recent that sign-in must be; 0 uses DefaultStepUpMaxAge
+	StepUpMaxAgeMinutes int `json:"step_up_max_age_minutes,omitempty" validate:"min=0,max=1440"`
+}
+
+// Requires reports whether users with the role must sign in with a second factor
+func (p MFAPolicy) Requires(role UserRole) bool {
+	switch role {
+	case UserRoleTenantOwner, UserRoleTenantAdmin:
+		return p.RequireForAdmins
+	case UserRoleArtisan, UserRoleTeamMember:
+		return p.RequireForArtisans
+	}
+	return false
+}
+
+// StepUpMaxAge returns how recent a multi-factor sign-in must be for sensitive actions
+func (p MFAPolicy) StepUpMaxAge() time.Duration {
+	if p.StepUpMaxAgeMinutes <= 0 {
+		return DefaultStepUpMaxAge
+	}
+	return time.Duration(p.StepUpMaxAgeMinutes) * time.Minute
+}
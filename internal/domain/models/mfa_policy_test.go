@@ -0,0 +1,31 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMFAPolicy_Requires(t *testing.T) {
+	policy := models.MFAPolicy{RequireForAdmins: true}
+	assert.True(t, policy.Requires(models.UserRoleTenantOwner))
+	assert.True(t, policy.Requires(models.UserRoleTenantAdmin))
+	assert.False(t, policy.Requires(models.UserRoleArtisan))
+
+	policy.RequireForArtisans = true
+	assert.True(t, policy.Requires(models.UserRoleTeamMember))
+
+	// Customers are never held to it
+	assert.False(t, policy.Requires(models.UserRoleCustomer))
+}
+
+func TestMFAPolicy_StepUpMaxAge(t *testing.T) {
+	assert.Equal(t, models.DefaultStepUpMaxAge, models.MFAPolicy{}.StepUpMaxAge())
+	assert.Equal(t, 5*time.Minute, models.MFAPolicy{StepUpMaxAgeMinutes: 5}.StepUpMaxAge())
+
+	var settings models.TenantSettings
+	assert.Equal(t, models.MFAPolicy{}, settings.GetMFAPolicy())
+}
@@ -14,12 +14,32 @@ const (
 	NotificationTypeBookingCancelled NotificationType = "booking_cancelled"
 	NotificationTypeBookingReminder  NotificationType = "booking_reminder"
 	NotificationTypeBookingCompleted NotificationType = "booking_completed"
+	NotificationTypeBookingNoShow    NotificationType = "booking_no_show"
 	NotificationTypePaymentReceived  NotificationType = "payment_received"
+	NotificationTypePaymentFailed    NotificationType = "payment_failed"
 	NotificationTypeReviewReceived   NotificationType = "review_received"
+	NotificationTypeReviewRequest    NotificationType = "review_request"
 	NotificationTypeMessageReceived  NotificationType = "message_received"
+	NotificationTypeExportReady      NotificationType = "export_ready"
+	NotificationTypeProjectAtRisk    NotificationType = "project_at_risk"
+	NotificationTypeProjectOverdue   NotificationType = "project_overdue"
+	NotificationTypeVerification     NotificationType = "verification"
+	NotificationTypeFavoriteUpdate   NotificationType = "favorite_update"
+	NotificationTypeNewLead          NotificationType = "new_lead"   // A customer request matching the artisan's skills
+	NotificationTypeBidUpdate        NotificationType = "bid_update" // A bid received, accepted or rejected
 	NotificationTypeSystem           NotificationType = "system"
+	NotificationTypeMarketing        NotificationType = "marketing"
 )
 
+// Category returns whether notifications of the type are marketing, which users must
+// opt in to, or transactional
+func (t NotificationType) Category() CommunicationCategory {
+	if t == NotificationTypeMarketing {
+		return CommunicationCategoryMarketing
+	}
+	return CommunicationCategoryTransactional
+}
+
 type NotificationChannel string
 
 const (
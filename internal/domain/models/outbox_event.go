@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxStatus is the delivery state of an outbox event
+type OutboxStatus string
+
+const (
+	OutboxStatusPending    OutboxStatus = "pending"    // Awaiting its first or a retried delivery
+	OutboxStatusDispatched OutboxStatus = "dispatched" // Every consumer handled it
+	OutboxStatusFailed     OutboxStatus = "failed"     // A consumer still failed it after the last attempt
+)
+
+// OutboxEvent is a domain event written in the transaction of the change it reports
+// and delivered to the subscribed consumers afterwards (see package events). Events
+// that consumers failed are retried with backoff, for those consumers only.
+type OutboxEvent struct {
+	BaseModel
+
+	// Multi-tenancy; nil for platform events
+	TenantID *uuid.UUID `json:"tenant_id,omitempty" gorm:"type:uuid;index"`
+
+	// Event
+	EventType     string          `json:"event_type" gorm:"size:100;not null;index"`
+	AggregateType string          `json:"aggregate_type" gorm:"size:50;not null"`
+	AggregateID   uuid.UUID       `json:"aggregate_id" gorm:"type:uuid;not null;index"`
+	Payload       json.RawMessage `json:"payload" gorm:"type:jsonb;serializer:json;not null"`
+	OccurredAt    time.Time       `json:"occurred_at" gorm:"not null"`
+
+	// Delivery
+	Status          OutboxStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_outbox_due,priority:1"`
+	Attempts        int          `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt   time.Time    `json:"next_attempt_at" gorm:"not null;index:idx_outbox_due,priority:2"`
+	FailedConsumers StringArray  `json:"failed_consumers,omitempty" gorm:"type:jsonb"` // Retried for these consumers only; empty delivers to all
+	LastError       string       `json:"last_error,omitempty" gorm:"type:text"`
+	DispatchedAt    *time.Time   `json:"dispatched_at,omitempty" gorm:"index"`
+}
+
+// TableName specifies the table name for OutboxEvent
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
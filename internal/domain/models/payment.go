@@ -6,6 +6,8 @@ import (
 	"slices"
 	"time"
 
+	"Krafti_Vibe/internal/pkg/redact"
+
 	"github.com/google/uuid"
 )
 
@@ -18,8 +20,27 @@ const (
 	PaymentMethodWallet   PaymentMethod = "wallet"
 	PaymentMethodPayStack PaymentMethod = "paystack"
 	PaymentMethodStripe   PaymentMethod = "stripe"
+	PaymentMethodPayPal   PaymentMethod = "paypal"
+	PaymentMethodMobile   PaymentMethod = "mobile_money" // M-Pesa and other mobile money wallets
 )
 
+// PaymentMethodForProvider returns the method recorded for payments taken through
+// a payment provider; mobile money providers share one method
+func PaymentMethodForProvider(provider string) PaymentMethod {
+	switch provider {
+	case "mpesa":
+		return PaymentMethodMobile
+	case "stripe":
+		return PaymentMethodStripe
+	case "paystack":
+		return PaymentMethodPayStack
+	case "paypal":
+		return PaymentMethodPayPal
+	default:
+		return PaymentMethodCard
+	}
+}
+
 type PaymentType string
 
 const (
@@ -27,6 +48,8 @@ const (
 	PaymentTypeFull    PaymentType = "full"
 	PaymentTypeRefund  PaymentType = "refund"
 	PaymentTypeTip     PaymentType = "tip"
+	PaymentTypeNoShow  PaymentType = "no_show_fee"
+	PaymentTypeBalance PaymentType = "balance" // Remainder after the deposit
 )
 
 // PaymentStatus represents the status of a payment
@@ -42,6 +65,16 @@ const (
 	PaymentStatusPartialRefund PaymentStatus = "partial_refund"
 )
 
+// EscrowStatus tracks the artisan's share of a payment held under the tenant's escrow
+// hold period. Payments taken without a hold period have no escrow status.
+type EscrowStatus string
+
+const (
+	EscrowStatusHeld     EscrowStatus = "held"     // Waiting for completion plus the hold period, or customer confirmation
+	EscrowStatusReleased EscrowStatus = "released" // Free to be paid out
+	EscrowStatusWithheld EscrowStatus = "withheld" // Frozen by an admin, e.g. during a dispute
+)
+
 type Payment struct {
 	BaseModel
 
@@ -61,14 +94,37 @@ type Payment struct {
 	Status   PaymentStatus `json:"status" gorm:"type:varchar(50);not null" validate:"required"`
 
 	// External References
-	ProviderPaymentID string `json:"provider_payment_id,omitempty" gorm:"size:255;index"` // Stripe, PayPal ID
+	ProviderPaymentID string `json:"provider_payment_id,omitempty" gorm:"size:255;index;serializer:encrypted_lookup"` // Stripe, PayPal ID; encrypted at rest
 	ProviderName      string `json:"provider_name,omitempty" gorm:"size:50"`
 
-	// Commission Split
+	// Promo code discount already taken off Amount; the share of the booking's discount
+	// this payment covers
+	PromoCodeID    *uuid.UUID `json:"promo_code_id,omitempty" gorm:"type:uuid;index"`
+	DiscountAmount float64    `json:"discount_amount" gorm:"type:decimal(10,2);default:0"`
+
+	// Commission Split, taken on the amount net of TaxAmount, which the tenant keeps
+	// to remit to the tax authority
+	TaxAmount      float64 `json:"tax_amount" gorm:"type:decimal(10,2);default:0"`
 	ArtisanAmount  float64 `json:"artisan_amount" gorm:"type:decimal(10,2);default:0"`
 	PlatformAmount float64 `json:"platform_amount" gorm:"type:decimal(10,2);default:0"`
 	CommissionRate float64 `json:"commission_rate" gorm:"type:decimal(5,2);default:0"`
 
+	// The tenant commission rule CommissionRate came from, kept by name too since rules
+	// change; empty when the tenant default or a manual rate applied
+	CommissionRuleID   *uuid.UUID `json:"commission_rule_id,omitempty" gorm:"type:uuid;index"`
+	CommissionRuleName string     `json:"commission_rule_name,omitempty" gorm:"size:100"`
+
+	// Settlement: the payout that carries ArtisanAmount to the artisan
+	PayoutID *uuid.UUID `json:"payout_id,omitempty" gorm:"type:uuid;index"`
+
+	// Escrow: ArtisanAmount is held until the booking has been completed for
+	// EscrowHoldDays or the customer confirms the work. Held and withheld payments are
+	// not paid out.
+	EscrowStatus     EscrowStatus `json:"escrow_status,omitempty" gorm:"type:varchar(20);index"`
+	EscrowHoldDays   int          `json:"escrow_hold_days,omitempty" gorm:"default:0"`
+	EscrowReleasedAt *time.Time   `json:"escrow_released_at,omitempty"`
+	EscrowNote       string       `json:"escrow_note,omitempty" gorm:"type:text"` // Why it was released early or withheld
+
 	// Processing
 	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
 	FailureReason string     `json:"failure_reason,omitempty" gorm:"type:text"`
@@ -78,8 +134,8 @@ type Payment struct {
 	RefundedAt     *time.Time `json:"refunded_at,omitempty"`
 	RefundReason   string     `json:"refund_reason,omitempty" gorm:"type:text"`
 
-	// Metadata
-	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb"`
+	// Metadata; values of sensitive keys such as receipt numbers are encrypted at rest
+	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb;serializer:encrypted_metadata"`
 
 	// Relationships
 	Booking  *Booking `json:"booking,omitempty" gorm:"foreignKey:BookingID"`
@@ -195,31 +251,113 @@ func (p *Payment) ProcessRefund(amount float64, reason string) error {
 	return nil
 }
 
-// CalculateCommission calculates platform and artisan amounts based on commission rate
+// CalculateCommission splits the amount net of tax between platform and artisan by commission rate
 func (p *Payment) CalculateCommission() {
+	net := p.Amount - p.TaxAmount
 	if p.CommissionRate > 0 {
-		p.PlatformAmount = p.Amount * (p.CommissionRate / 100.0)
-		p.ArtisanAmount = p.Amount - p.PlatformAmount
+		p.PlatformAmount = net * (p.CommissionRate / 100.0)
+		p.ArtisanAmount = net - p.PlatformAmount
 	} else {
-		p.ArtisanAmount = p.Amount
+		p.ArtisanAmount = net
 		p.PlatformAmount = 0
 	}
 }
 
-// SetCommissionRate sets the commission rate and recalculates the split
+// ApplyBookingDiscount records the share of the booking's promo code discount that a
+// payment towards its price covers
+func (p *Payment) ApplyBookingDiscount(booking *Booking) {
+	switch p.Type {
+	case PaymentTypeDeposit, PaymentTypeFull, PaymentTypeBalance:
+		p.PromoCodeID = booking.PromoCodeID
+		p.DiscountAmount = booking.DiscountShare(p.Amount)
+	default:
+		p.PromoCodeID = nil
+		p.DiscountAmount = 0
+	}
+}
+
+// ApplyBookingTax sets the tax contained in a payment towards the booking's price.
+// Tips and fees are not part of the price and carry no tax.
+func (p *Payment) ApplyBookingTax(booking *Booking) {
+	switch p.Type {
+	case PaymentTypeDeposit, PaymentTypeFull, PaymentTypeBalance:
+		p.TaxAmount = booking.TaxShare(p.Amount)
+	default:
+		p.TaxAmount = 0
+	}
+}
+
+// ApplyCommissionRule takes the commission rate from a tenant rule and records which
+// rule it was; CalculateCommission then splits the payment
+func (p *Payment) ApplyCommissionRule(rule *CommissionRule) {
+	p.CommissionRate = rule.Rate
+	p.CommissionRuleID = &rule.ID
+	p.CommissionRuleName = rule.Name
+}
+
+// SetCommissionRate sets a manual commission rate and recalculates the split
 func (p *Payment) SetCommissionRate(rate float64) error {
 	if rate < 0 || rate > 100 {
 		return fmt.Errorf("commission rate must be between 0 and 100")
 	}
 	p.CommissionRate = rate
+	p.CommissionRuleID = nil
+	p.CommissionRuleName = ""
 	p.CalculateCommission()
 	return nil
 }
 
+// HoldInEscrow holds the artisan's share of the payment for holdDays after the booking
+// is completed. Refunds are never held.
+func (p *Payment) HoldInEscrow(holdDays int) {
+	if holdDays <= 0 || p.Type == PaymentTypeRefund {
+		return
+	}
+	p.EscrowStatus = EscrowStatusHeld
+	p.EscrowHoldDays = holdDays
+}
+
+// IsHeldInEscrow reports whether the artisan's share cannot be paid out yet
+func (p *Payment) IsHeldInEscrow() bool {
+	return p.EscrowStatus == EscrowStatusHeld || p.EscrowStatus == EscrowStatusWithheld
+}
+
+// ReleaseEscrow frees a held or withheld payment for payout
+func (p *Payment) ReleaseEscrow(note string) error {
+	if !p.IsHeldInEscrow() {
+		return fmt.Errorf("payment is not held in escrow")
+	}
+	now := time.Now()
+	p.EscrowStatus = EscrowStatusReleased
+	p.EscrowReleasedAt = &now
+	p.EscrowNote = note
+	return nil
+}
+
+// WithholdEscrow freezes the artisan's share of a payment until it is released by hand.
+// Payments already in a payout cannot be withheld.
+func (p *Payment) WithholdEscrow(reason string) error {
+	if reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if p.PayoutID != nil {
+		return fmt.Errorf("payment has already been paid out")
+	}
+	if p.EscrowStatus == EscrowStatusWithheld {
+		return fmt.Errorf("payment is already withheld")
+	}
+	p.EscrowStatus = EscrowStatusWithheld
+	p.EscrowReleasedAt = nil
+	p.EscrowNote = reason
+	return nil
+}
+
 // IsThirdPartyPayment checks if payment was made through a third-party provider
 func (p *Payment) IsThirdPartyPayment() bool {
 	return p.Method == PaymentMethodPayStack ||
 		p.Method == PaymentMethodStripe ||
+		p.Method == PaymentMethodPayPal ||
+		p.Method == PaymentMethodMobile ||
 		p.ProviderPaymentID != ""
 }
 
@@ -303,6 +441,10 @@ func (p *Payment) GetMethodLabel() string {
 		return "Paystack"
 	case PaymentMethodStripe:
 		return "Stripe"
+	case PaymentMethodPayPal:
+		return "PayPal"
+	case PaymentMethodMobile:
+		return "Mobile Money"
 	default:
 		return string(p.Method)
 	}
@@ -334,6 +476,7 @@ func (p *Payment) Validate() error {
 	validMethods := []PaymentMethod{
 		PaymentMethodCard, PaymentMethodCash, PaymentMethodBank,
 		PaymentMethodWallet, PaymentMethodPayStack, PaymentMethodStripe,
+		PaymentMethodPayPal, PaymentMethodMobile,
 	}
 	if !slices.Contains(validMethods, p.Method) {
 		return fmt.Errorf("invalid payment method: %s", p.Method)
@@ -341,7 +484,8 @@ func (p *Payment) Validate() error {
 
 	// Validate type
 	validTypes := []PaymentType{
-		PaymentTypeDeposit, PaymentTypeFull, PaymentTypeRefund, PaymentTypeTip,
+		PaymentTypeDeposit, PaymentTypeFull, PaymentTypeRefund, PaymentTypeTip, PaymentTypeNoShow,
+		PaymentTypeBalance,
 	}
 
 	if !slices.Contains(validTypes, p.Type) {
@@ -386,6 +530,12 @@ func (p *Payment) Clone() *Payment {
 	return &clone
 }
 
+// RedactedMetadata returns a copy of the metadata with payment and personal data, such
+// as receipt numbers, replaced; safe for API responses and logs
+func (p *Payment) RedactedMetadata() JSONB {
+	return redact.Map(p.Metadata)
+}
+
 // GetTransactionReference generates a unique transaction reference
 func (p *Payment) GetTransactionReference() string {
 	if p.ProviderPaymentID != "" {
@@ -0,0 +1,60 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldInEscrow(t *testing.T) {
+	payment := &models.Payment{Type: models.PaymentTypeFull}
+	payment.HoldInEscrow(0)
+	assert.Empty(t, payment.EscrowStatus, "no hold period leaves the payment unheld")
+
+	payment.HoldInEscrow(7)
+	assert.Equal(t, models.EscrowStatusHeld, payment.EscrowStatus)
+	assert.Equal(t, 7, payment.EscrowHoldDays)
+	assert.True(t, payment.IsHeldInEscrow())
+
+	refund := &models.Payment{Type: models.PaymentTypeRefund}
+	refund.HoldInEscrow(7)
+	assert.Empty(t, refund.EscrowStatus)
+}
+
+func TestEscrowWithholdAndRelease(t *testing.T) {
+	payment := &models.Payment{Type: models.PaymentTypeFull}
+	payment.HoldInEscrow(3)
+
+	require.Error(t, payment.WithholdEscrow(""), "a reason is required")
+	require.NoError(t, payment.WithholdEscrow("customer disputes the work"))
+	assert.Equal(t, models.EscrowStatusWithheld, payment.EscrowStatus)
+	assert.True(t, payment.IsHeldInEscrow())
+	require.Error(t, payment.WithholdEscrow("again"))
+
+	require.NoError(t, payment.ReleaseEscrow("dispute resolved"))
+	assert.Equal(t, models.EscrowStatusReleased, payment.EscrowStatus)
+	assert.NotNil(t, payment.EscrowReleasedAt)
+	assert.False(t, payment.IsHeldInEscrow())
+	require.Error(t, payment.ReleaseEscrow(""), "a released payment cannot be released again")
+
+	// Released but not yet paid out can still be frozen
+	require.NoError(t, payment.WithholdEscrow("chargeback"))
+	assert.Nil(t, payment.EscrowReleasedAt)
+
+	payoutID := uuid.New()
+	paidOut := &models.Payment{Type: models.PaymentTypeFull, PayoutID: &payoutID}
+	assert.Error(t, paidOut.WithholdEscrow("too late"))
+}
+
+func TestRedactedMetadata(t *testing.T) {
+	payment := &models.Payment{Metadata: models.JSONB{"receipt_number": "QK12345", "provider_status": "succeeded"}}
+
+	redacted := payment.RedactedMetadata()
+	assert.Equal(t, "[REDACTED]", redacted["receipt_number"])
+	assert.Equal(t, "succeeded", redacted["provider_status"])
+	assert.Equal(t, "QK12345", payment.Metadata["receipt_number"])
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentWebhookOutcome records what was done with a provider event
+type PaymentWebhookOutcome string
+
+const (
+	PaymentWebhookApplied PaymentWebhookOutcome = "applied" // The payment was updated
+	PaymentWebhookIgnored PaymentWebhookOutcome = "ignored" // No matching payment, or nothing changed
+)
+
+// PaymentWebhookEvent is an inbound event received from a payment provider.
+// Providers deliver events at least once, so the (provider, event_id) pair is
+// unique and a redelivered event is acknowledged without being applied twice.
+type PaymentWebhookEvent struct {
+	BaseModel
+
+	Provider  string `json:"provider" gorm:"type:varchar(50);not null;uniqueIndex:idx_payment_webhook_event"`
+	EventID   string `json:"event_id" gorm:"size:255;not null;uniqueIndex:idx_payment_webhook_event"`
+	EventType string `json:"event_type" gorm:"size:100"`
+
+	// Set when the event matched one of our payments
+	TenantID  *uuid.UUID `json:"tenant_id,omitempty" gorm:"type:uuid;index"`
+	PaymentID *uuid.UUID `json:"payment_id,omitempty" gorm:"type:uuid;index"`
+
+	Outcome     PaymentWebhookOutcome `json:"outcome" gorm:"type:varchar(20);not null"`
+	ReceivedAt  time.Time             `json:"received_at" gorm:"not null"`
+	OccurredAt  *time.Time            `json:"occurred_at,omitempty"`
+	ProviderRef string                `json:"provider_ref,omitempty" gorm:"size:255"` // Provider payment ID from the event
+}
+
+// TableName keeps inbound provider events apart from the outbound webhook_events deliveries
+func (PaymentWebhookEvent) TableName() string {
+	return "payment_webhook_events"
+}
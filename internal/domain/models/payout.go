@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayoutMethod is how artisan earnings are sent
+type PayoutMethod string
+
+const (
+	PayoutMethodStripeConnect PayoutMethod = "stripe_connect" // Transfer to the artisan's connected Stripe account
+	PayoutMethodBankTransfer  PayoutMethod = "bank_transfer"  // Paid by the tenant from a bank file export
+)
+
+// IsValid checks if the payout method is supported
+func (m PayoutMethod) IsValid() bool {
+	return m == PayoutMethodStripeConnect || m == PayoutMethodBankTransfer
+}
+
+// PayoutStatus tracks a payout or batch through payment
+type PayoutStatus string
+
+const (
+	PayoutStatusPending    PayoutStatus = "pending"    // Created, not yet sent
+	PayoutStatusProcessing PayoutStatus = "processing" // Sent to the provider or exported to a bank file
+	PayoutStatusPaid       PayoutStatus = "paid"
+	PayoutStatusFailed     PayoutStatus = "failed"
+)
+
+// PayoutBatch groups the payouts created in one run for a tenant
+type PayoutBatch struct {
+	BaseModel
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+
+	Method      PayoutMethod `json:"method" gorm:"type:varchar(30);not null"`
+	Status      PayoutStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	Currency    string       `json:"currency" gorm:"size:3;not null"`
+	PeriodEnd   time.Time    `json:"period_end" gorm:"not null"` // Payments processed up to this time are included
+	PayoutCount int          `json:"payout_count" gorm:"default:0"`
+	TotalAmount float64      `json:"total_amount" gorm:"type:decimal(12,2);default:0"`
+	FailedCount int          `json:"failed_count" gorm:"default:0"`
+	CreatedBy   *uuid.UUID   `json:"created_by,omitempty" gorm:"type:uuid"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+
+	Payouts []*Payout `json:"payouts,omitempty" gorm:"foreignKey:BatchID"`
+}
+
+// TableName specifies the table name for PayoutBatch
+func (PayoutBatch) TableName() string {
+	return "payout_batches"
+}
+
+// Payout is one artisan's settled earnings in one currency. The payments it covers
+// point at it through Payment.PayoutID.
+type Payout struct {
+	BaseModel
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	BatchID  uuid.UUID `json:"batch_id" gorm:"type:uuid;not null;index"`
+
+	// ArtisanID is the artisan's user ID, as on payments and bookings
+	ArtisanID uuid.UUID `json:"artisan_id" gorm:"type:uuid;not null;index"`
+
+	Amount       float64      `json:"amount" gorm:"type:decimal(12,2);not null"`
+	Currency     string       `json:"currency" gorm:"size:3;not null"`
+	PaymentCount int          `json:"payment_count" gorm:"default:0"`
+	Method       PayoutMethod `json:"method" gorm:"type:varchar(30);not null"`
+	Status       PayoutStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+
+	// Destination is the connected account or bank reference the payout was sent to
+	Destination        string     `json:"destination,omitempty" gorm:"size:255"`
+	ProviderTransferID string     `json:"provider_transfer_id,omitempty" gorm:"size:255"`
+	FailureReason      string     `json:"failure_reason,omitempty" gorm:"type:text"`
+	PaidAt             *time.Time `json:"paid_at,omitempty"`
+
+	Artisan *User `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
+}
+
+// TableName specifies the table name for Payout
+func (Payout) TableName() string {
+	return "payouts"
+}
+
+// MarkAsPaid records that the payout reached the artisan
+func (p *Payout) MarkAsPaid(transferID string) {
+	now := time.Now()
+	p.Status = PayoutStatusPaid
+	p.PaidAt = &now
+	if transferID != "" {
+		p.ProviderTransferID = transferID
+	}
+}
+
+// MarkAsFailed records why the payout could not be sent
+func (p *Payout) MarkAsFailed(reason string) {
+	p.Status = PayoutStatusFailed
+	p.FailureReason = reason
+}
@@ -0,0 +1,109 @@
+package models
+
+// PlanLimit names a metered limit enforced per tenant
+type PlanLimit string
+
+const (
+	PlanLimitBookingsPerMonth PlanLimit = "bookings_per_month" // Bookings created in the calendar month
+	PlanLimitArtisanSeats     PlanLimit = "artisan_seats"      // Artisan profiles in the tenant, and artisans invited
+)
+
+// Label returns the limit as shown to tenants
+func (l PlanLimit) Label() string {
+	switch l {
+	case PlanLimitBookingsPerMonth:
+		return "bookings per month"
+	case PlanLimitArtisanSeats:
+		return "artisan seats"
+	default:
+		return string(l)
+	}
+}
+
+// DefaultGracePeriodDays is how long a tenant keeps access after a failed renewal
+const DefaultGracePeriodDays = 7
+
+// Plan is a platform plan tenants subscribe to. Paid plans are billed through Stripe
+// Billing with the plan's price IDs; limits and features are copied onto the tenant's
+// Subscription when it moves to the plan.
+type Plan struct {
+	BaseModel
+
+	Code        SubscriptionPlan `json:"code" gorm:"type:varchar(50);uniqueIndex;not null"`
+	Name        string           `json:"name" gorm:"size:100;not null"`
+	Description string           `json:"description,omitempty" gorm:"type:text"`
+
+	// Pricing
+	MonthlyPrice float64 `json:"monthly_price" gorm:"type:decimal(10,2);not null;default:0"`
+	YearlyPrice  float64 `json:"yearly_price" gorm:"type:decimal(10,2);not null;default:0"`
+	Currency     string  `json:"currency" gorm:"size:3;default:'USD'"`
+
+	// Stripe Billing prices; a paid plan needs one for each interval it is sold on
+	StripeMonthlyPriceID string `json:"-" gorm:"size:255"`
+	StripeYearlyPriceID  string `json:"-" gorm:"size:255"`
+
+	// Limits, -1 for unlimited
+	MaxCustomers        int `json:"max_customers" gorm:"not null;default:0"`
+	MaxProjects         int `json:"max_projects" gorm:"not null;default:0"`
+	MaxStorageGB        int `json:"max_storage_gb" gorm:"not null;default:0"`
+	MaxArtisanSeats     int `json:"max_artisan_seats" gorm:"not null;default:1"`
+	MaxServicesListed   int `json:"max_services_listed" gorm:"not null;default:0"`
+	MaxBookingsPerMonth int `json:"max_bookings_per_month" gorm:"not null;default:0"`
+
+	Features SubscriptionFeatures `json:"features" gorm:"type:jsonb"`
+
+	TrialDays       int  `json:"trial_days" gorm:"default:0"`
+	GracePeriodDays int  `json:"grace_period_days" gorm:"default:7"`
+	IsActive        bool `json:"is_active" gorm:"default:true;index"`
+	SortOrder       int  `json:"sort_order" gorm:"default:0"`
+}
+
+// TableName specifies the table name for Plan
+func (Plan) TableName() string {
+	return "plans"
+}
+
+// IsFree reports whether the plan costs nothing on any interval
+func (p *Plan) IsFree() bool {
+	return p.MonthlyPrice <= 0 && p.YearlyPrice <= 0
+}
+
+// Price returns the plan's price for a billing interval
+func (p *Plan) Price(interval BillingInterval) float64 {
+	if interval == BillingYearly {
+		return p.YearlyPrice
+	}
+	return p.MonthlyPrice
+}
+
+// StripePriceID returns the Stripe price billed for an interval
+func (p *Plan) StripePriceID(interval BillingInterval) string {
+	if interval == BillingYearly {
+		return p.StripeYearlyPriceID
+	}
+	return p.StripeMonthlyPriceID
+}
+
+// ApplyTo moves a subscription onto the plan, replacing its price, limits and features
+func (p *Plan) ApplyTo(sub *Subscription) {
+	sub.Plan = p.Code
+	sub.Amount = p.Price(sub.BillingInterval)
+	if p.Currency != "" {
+		sub.Currency = p.Currency
+	}
+	sub.MaxCustomers = p.MaxCustomers
+	sub.MaxProjects = p.MaxProjects
+	sub.MaxStorageGB = p.MaxStorageGB
+	sub.MaxTeamMembers = p.MaxArtisanSeats
+	sub.MaxServicesListed = p.MaxServicesListed
+	sub.MaxBookingsPerMonth = p.MaxBookingsPerMonth
+	sub.Features = p.Features
+}
+
+// GraceDays returns the plan's grace period, falling back to the platform default
+func (p *Plan) GraceDays() int {
+	if p.GracePeriodDays > 0 {
+		return p.GracePeriodDays
+	}
+	return DefaultGracePeriodDays
+}
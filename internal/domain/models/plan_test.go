@@ -0,0 +1,64 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan_ApplyTo(t *testing.T) {
+	plan := &models.Plan{
+		Code:                models.PlanStarter,
+		MonthlyPrice:        29,
+		YearlyPrice:         290,
+		Currency:            "EUR",
+		MaxArtisanSeats:     3,
+		MaxBookingsPerMonth: 100,
+		StripeYearlyPriceID: "price_starter_yearly",
+	}
+	sub := &models.Subscription{Plan: models.PlanFree, BillingInterval: models.BillingYearly, MaxTeamMembers: 1}
+
+	plan.ApplyTo(sub)
+	assert.Equal(t, models.PlanStarter, sub.Plan)
+	assert.Equal(t, float64(290), sub.Amount)
+	assert.Equal(t, "EUR", sub.Currency)
+	assert.Equal(t, 3, sub.Limit(models.PlanLimitArtisanSeats))
+	assert.Equal(t, 100, sub.Limit(models.PlanLimitBookingsPerMonth))
+	assert.Equal(t, "price_starter_yearly", plan.StripePriceID(models.BillingYearly))
+	assert.Empty(t, plan.StripePriceID(models.BillingMonthly))
+	assert.Equal(t, models.DefaultGracePeriodDays, plan.GraceDays())
+}
+
+func TestSubscription_AllowsAnother(t *testing.T) {
+	sub := &models.Subscription{MaxTeamMembers: 2, MaxBookingsPerMonth: -1}
+
+	assert.True(t, sub.AllowsAnother(models.PlanLimitArtisanSeats, 1))
+	assert.False(t, sub.AllowsAnother(models.PlanLimitArtisanSeats, 2))
+	assert.True(t, sub.AllowsAnother(models.PlanLimitBookingsPerMonth, 10_000), "-1 is unlimited")
+}
+
+func TestSubscription_GracePeriod(t *testing.T) {
+	now := time.Now()
+	sub := &models.Subscription{Status: models.SubStatusActive, FailedPayments: 1}
+
+	sub.StartGracePeriod(now, 7)
+	assert.Equal(t, models.SubStatusPastDue, sub.Status)
+	assert.True(t, sub.InGracePeriod(now))
+	assert.True(t, sub.HasAccess(now.Add(6*24*time.Hour)))
+	assert.False(t, sub.HasAccess(now.Add(8*24*time.Hour)), "access ends with the grace period")
+
+	graceEnds := *sub.GraceEndsAt
+	sub.StartGracePeriod(now.Add(3*24*time.Hour), 7)
+	assert.Equal(t, graceEnds, *sub.GraceEndsAt, "retries do not extend the grace period")
+
+	sub.EndGracePeriod()
+	assert.Equal(t, models.SubStatusActive, sub.Status)
+	assert.Nil(t, sub.GraceEndsAt)
+	assert.Zero(t, sub.FailedPayments)
+
+	sub.Status = models.SubStatusSuspended
+	assert.False(t, sub.HasAccess(now))
+}
@@ -0,0 +1,321 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PricingRuleKind is what a pricing rule responds to
+type PricingRuleKind string
+
+const (
+	PricingRuleKindPeak       PricingRuleKind = "peak"        // Weekday and hour windows in demand
+	PricingRuleKindOffPeak    PricingRuleKind = "off_peak"    // Weekday and hour windows to fill
+	PricingRuleKindLastMinute PricingRuleKind = "last_minute" // Bookings starting soon after they are made
+	PricingRuleKindSeasonal   PricingRuleKind = "seasonal"    // Bookings starting within a date range
+)
+
+// pricingRuleKinds is the order matching rules are applied in
+var pricingRuleKinds = []PricingRuleKind{
+	PricingRuleKindSeasonal,
+	PricingRuleKindPeak,
+	PricingRuleKindOffPeak,
+	PricingRuleKindLastMinute,
+}
+
+// IsValid checks if the kind is one of the supported kinds
+func (k PricingRuleKind) IsValid() bool {
+	switch k {
+	case PricingRuleKindPeak, PricingRuleKindOffPeak, PricingRuleKindLastMinute, PricingRuleKindSeasonal:
+		return true
+	}
+	return false
+}
+
+// maxPricingMultiplier bounds how far a rule can raise a price
+const maxPricingMultiplier = 5
+
+// PricingRule multiplies a service's price when a booking is made. Peak and off-peak
+// rules match bookings starting on their Weekdays between StartHour and EndHour, in
+// the rule's Timezone; a window with EndHour before StartHour runs past midnight.
+// Last-minute rules match bookings starting within LeadHours of being made, and
+// seasonal rules bookings starting between StartsAt and EndsAt, which limit the other
+// kinds too. Empty scopes match anything; a rule scoped to an artisan overrides the
+// tenant's rules of the same kind for that artisan (see ApplyPricingRules).
+type PricingRule struct {
+	BaseModel
+
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+
+	Name string          `json:"name" gorm:"not null;size:100" validate:"required,max=100"` // Recorded on the bookings it applies to
+	Kind PricingRuleKind `json:"kind" gorm:"type:varchar(20);not null;index"`
+
+	// Scope
+	ServiceID       *uuid.UUID      `json:"service_id,omitempty" gorm:"type:uuid;index"`
+	ServiceCategory ServiceCategory `json:"service_category,omitempty" gorm:"type:varchar(50)"`
+	ArtisanID       *uuid.UUID      `json:"artisan_id,omitempty" gorm:"type:uuid;index"`
+
+	// Conditions
+	Weekdays  Weekdays   `json:"weekdays,omitempty" gorm:"type:jsonb"` // 0=Sunday; empty is every day
+	StartHour int        `json:"start_hour" gorm:"default:0"`
+	EndHour   int        `json:"end_hour" gorm:"default:0"`
+	Timezone  string     `json:"timezone" gorm:"size:50;default:'UTC'"`
+	LeadHours int        `json:"lead_hours" gorm:"default:0"`
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+
+	Multiplier float64 `json:"multiplier" gorm:"type:decimal(6,4);not null"` // 1.2 adds 20%, 0.9 takes 10% off
+
+	IsActive bool `json:"is_active" gorm:"default:true;index"`
+
+	// Relationships
+	Tenant *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+}
+
+// TableName specifies the table name for PricingRule
+func (PricingRule) TableName() string {
+	return "pricing_rules"
+}
+
+// Validate checks the rule values are consistent with its kind
+func (r *PricingRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("rule name is required")
+	}
+	if !r.Kind.IsValid() {
+		return errors.New("invalid pricing rule kind")
+	}
+	if r.Multiplier <= 0 || r.Multiplier > maxPricingMultiplier {
+		return errors.New("multiplier must be above 0 and at most 5")
+	}
+	for _, day := range r.Weekdays {
+		if day < 0 || day > 6 {
+			return errors.New("weekdays must be between 0 (Sunday) and 6 (Saturday)")
+		}
+	}
+	if r.StartHour < 0 || r.StartHour > 23 || r.EndHour < 0 || r.EndHour > 24 {
+		return errors.New("hours must be between 0 and 24")
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return errors.New("invalid timezone")
+	}
+	if r.LeadHours < 0 {
+		return errors.New("lead hours cannot be negative")
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return errors.New("period must end after it starts")
+	}
+
+	switch r.Kind {
+	case PricingRuleKindPeak:
+		if r.Multiplier < 1 {
+			return errors.New("peak rules cannot lower prices")
+		}
+	case PricingRuleKindOffPeak, PricingRuleKindLastMinute:
+		if r.Multiplier > 1 {
+			return errors.New("off-peak and last-minute rules cannot raise prices")
+		}
+	}
+	switch r.Kind {
+	case PricingRuleKindPeak, PricingRuleKindOffPeak:
+		if len(r.Weekdays) == 0 && !r.hasHours() {
+			return errors.New("peak and off-peak rules need weekdays or hours")
+		}
+	case PricingRuleKindLastMinute:
+		if r.LeadHours == 0 {
+			return errors.New("last-minute rules need lead hours")
+		}
+	case PricingRuleKindSeasonal:
+		if r.StartsAt == nil || r.EndsAt == nil {
+			return errors.New("seasonal rules need a start and an end")
+		}
+	}
+	return nil
+}
+
+// hasHours reports whether the rule is limited to a window of hours
+func (r *PricingRule) hasHours() bool {
+	return r.StartHour != r.EndHour
+}
+
+// PricingContext is what decides the price of a booking
+type PricingContext struct {
+	ServiceID       uuid.UUID
+	ServiceCategory ServiceCategory
+	ArtisanID       uuid.UUID
+	StartTime       time.Time
+	BookedAt        time.Time
+}
+
+// Matches reports whether the rule applies to a booking in the given context
+func (r *PricingRule) Matches(c PricingContext) bool {
+	if !r.IsActive {
+		return false
+	}
+	if r.ServiceID != nil && *r.ServiceID != c.ServiceID {
+		return false
+	}
+	if r.ServiceCategory != "" && r.ServiceCategory != c.ServiceCategory {
+		return false
+	}
+	if r.ArtisanID != nil && *r.ArtisanID != c.ArtisanID {
+		return false
+	}
+	if r.StartsAt != nil && c.StartTime.Before(*r.StartsAt) {
+		return false
+	}
+	if r.EndsAt != nil && !c.StartTime.Before(*r.EndsAt) {
+		return false
+	}
+	if r.LeadHours > 0 && (c.StartTime.Before(c.BookedAt) || c.StartTime.Sub(c.BookedAt) > time.Duration(r.LeadHours)*time.Hour) {
+		return false
+	}
+
+	start := c.StartTime
+	if loc, err := time.LoadLocation(r.Timezone); err == nil {
+		start = start.In(loc)
+	}
+	if len(r.Weekdays) > 0 && !r.Weekdays.Contains(int(start.Weekday())) {
+		return false
+	}
+	if r.hasHours() {
+		hour := start.Hour()
+		if r.StartHour < r.EndHour {
+			return hour >= r.StartHour && hour < r.EndHour
+		}
+		return hour >= r.StartHour || hour < r.EndHour
+	}
+	return true
+}
+
+// specificity ranks matching rules of a kind: an artisan override beats a service rule,
+// which beats a service category rule, which beats the tenant-wide rule
+func (r *PricingRule) specificity() int {
+	score := 0
+	if r.ArtisanID != nil {
+		score += 4
+	}
+	if r.ServiceID != nil {
+		score += 2
+	}
+	if r.ServiceCategory != "" {
+		score++
+	}
+	return score
+}
+
+// ApplyPricingRules prices a booking at price from the rules. Of each kind's matching
+// rules the most specific applies, or the first between equally specific ones, so an
+// artisan override with a multiplier of 1 switches off the tenant's rule for that
+// artisan. The kinds then compound, seasonal first and last-minute last. It returns
+// the adjusted price and the adjustments made, which is empty when none applied.
+func ApplyPricingRules(rules []*PricingRule, c PricingContext, price float64) (float64, PricingAdjustments) {
+	best := make(map[PricingRuleKind]*PricingRule)
+	for _, rule := range rules {
+		if !rule.Matches(c) {
+			continue
+		}
+		if current, ok := best[rule.Kind]; !ok || rule.specificity() > current.specificity() {
+			best[rule.Kind] = rule
+		}
+	}
+
+	var adjustments PricingAdjustments
+	for _, kind := range pricingRuleKinds {
+		rule, ok := best[kind]
+		if !ok || rule.Multiplier == 1 {
+			continue
+		}
+		adjusted := roundMoney(price * rule.Multiplier)
+		adjustments = append(adjustments, PricingAdjustment{
+			RuleID:     rule.ID,
+			Name:       rule.Name,
+			Kind:       rule.Kind,
+			Multiplier: rule.Multiplier,
+			Amount:     roundMoney(adjusted - price),
+		})
+		price = adjusted
+	}
+	return price, adjustments
+}
+
+// Weekdays is a list of days of the week, 0=Sunday, stored as a JSON array
+type Weekdays []int
+
+// Contains reports whether day is in the list
+func (w Weekdays) Contains(day int) bool {
+	for _, d := range w {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Weekdays) Scan(value interface{}) error {
+	if value == nil {
+		*w = Weekdays{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, w)
+}
+
+func (w Weekdays) Value() (driver.Value, error) {
+	if w == nil {
+		return json.Marshal([]int{})
+	}
+	return json.Marshal(w)
+}
+
+// PricingAdjustment is one pricing rule applied to a booking's price
+type PricingAdjustment struct {
+	RuleID     uuid.UUID       `json:"rule_id"`
+	Name       string          `json:"name"`
+	Kind       PricingRuleKind `json:"kind"`
+	Multiplier float64         `json:"multiplier"`
+	Amount     float64         `json:"amount"` // Added to the price, negative for discounts
+}
+
+// PricingAdjustments is stored as a JSON array
+type PricingAdjustments []PricingAdjustment
+
+// Total returns the sum of the adjustments
+func (p PricingAdjustments) Total() float64 {
+	var total float64
+	for _, adjustment := range p {
+		total += adjustment.Amount
+	}
+	return roundMoney(total)
+}
+
+func (p *PricingAdjustments) Scan(value interface{}) error {
+	if value == nil {
+		*p = PricingAdjustments{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, p)
+}
+
+func (p PricingAdjustments) Value() (driver.Value, error) {
+	if p == nil {
+		return json.Marshal([]PricingAdjustment{})
+	}
+	return json.Marshal(p)
+}
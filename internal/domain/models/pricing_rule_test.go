@@ -0,0 +1,95 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPricingRules(t *testing.T) {
+	artisanID := uuid.New()
+	summerStart := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	summerEnd := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	rules := []*models.PricingRule{
+		{Name: "Weekend evenings", Kind: models.PricingRuleKindPeak, Weekdays: models.Weekdays{0, 6}, StartHour: 17, EndHour: 21, Timezone: "UTC", Multiplier: 1.2, IsActive: true},
+		{Name: "Weekday mornings", Kind: models.PricingRuleKindOffPeak, Weekdays: models.Weekdays{1, 2, 3, 4, 5}, StartHour: 6, EndHour: 10, Timezone: "UTC", Multiplier: 0.9, IsActive: true},
+		{Name: "Last minute", Kind: models.PricingRuleKindLastMinute, LeadHours: 24, Multiplier: 0.8, IsActive: true},
+		{Name: "Summer", Kind: models.PricingRuleKindSeasonal, StartsAt: &summerStart, EndsAt: &summerEnd, Multiplier: 1.1, IsActive: true},
+		{Name: "Retired", Kind: models.PricingRuleKindPeak, Multiplier: 3, IsActive: false},
+	}
+	saturdayEvening := time.Date(2025, 6, 14, 18, 0, 0, 0, time.UTC)
+	mondayMorning := time.Date(2025, 10, 6, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		ctx         models.PricingContext
+		price       float64
+		adjustments []string
+	}{
+		{"peak in season", models.PricingContext{StartTime: saturdayEvening, BookedAt: saturdayEvening.AddDate(0, 0, -7)}, 132, []string{"Summer", "Weekend evenings"}},
+		{"off-peak out of season", models.PricingContext{StartTime: mondayMorning, BookedAt: mondayMorning.AddDate(0, 0, -7)}, 90, []string{"Weekday mornings"}},
+		{"last minute compounds", models.PricingContext{StartTime: mondayMorning, BookedAt: mondayMorning.Add(-2 * time.Hour)}, 72, []string{"Weekday mornings", "Last minute"}},
+		{"no rule applies", models.PricingContext{StartTime: mondayMorning.Add(4 * time.Hour), BookedAt: mondayMorning.AddDate(0, 0, -7)}, 100, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, adjustments := models.ApplyPricingRules(rules, tt.ctx, 100)
+			assert.Equal(t, tt.price, price)
+			var names []string
+			for _, adjustment := range adjustments {
+				names = append(names, adjustment.Name)
+			}
+			assert.Equal(t, tt.adjustments, names)
+			assert.InDelta(t, tt.price-100, adjustments.Total(), 0.001)
+		})
+	}
+
+	t.Run("artisan rules override the tenant's", func(t *testing.T) {
+		override := &models.PricingRule{Name: "No peak for Sam", Kind: models.PricingRuleKindPeak, ArtisanID: &artisanID, Weekdays: models.Weekdays{6}, Timezone: "UTC", Multiplier: 1, IsActive: true}
+		withOverride := append([]*models.PricingRule{override}, rules...)
+		ctx := models.PricingContext{ArtisanID: artisanID, StartTime: saturdayEvening, BookedAt: saturdayEvening.AddDate(0, 0, -7)}
+
+		price, adjustments := models.ApplyPricingRules(withOverride, ctx, 100)
+		assert.Equal(t, 110.0, price)
+		require.Len(t, adjustments, 1)
+		assert.Equal(t, "Summer", adjustments[0].Name)
+
+		ctx.ArtisanID = uuid.New()
+		price, _ = models.ApplyPricingRules(withOverride, ctx, 100)
+		assert.Equal(t, 132.0, price)
+	})
+
+	t.Run("hours are in the rule's timezone", func(t *testing.T) {
+		night := []*models.PricingRule{{Name: "Late night", Kind: models.PricingRuleKindPeak, StartHour: 22, EndHour: 6, Timezone: "America/New_York", Multiplier: 1.5, IsActive: true}}
+		// 03:00 UTC is 23:00 the previous evening in New York
+		price, _ := models.ApplyPricingRules(night, models.PricingContext{StartTime: time.Date(2025, 10, 7, 3, 0, 0, 0, time.UTC)}, 100)
+		assert.Equal(t, 150.0, price)
+		price, _ = models.ApplyPricingRules(night, models.PricingContext{StartTime: time.Date(2025, 10, 7, 12, 0, 0, 0, time.UTC)}, 100)
+		assert.Equal(t, 100.0, price)
+	})
+}
+
+func TestPricingRule_Validate(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, 0)
+
+	assert.NoError(t, (&models.PricingRule{Name: "Peak", Kind: models.PricingRuleKindPeak, Weekdays: models.Weekdays{6}, Multiplier: 1.2}).Validate())
+	assert.NoError(t, (&models.PricingRule{Name: "Summer", Kind: models.PricingRuleKindSeasonal, StartsAt: &start, EndsAt: &end, Multiplier: 0.9}).Validate())
+	assert.Error(t, (&models.PricingRule{Name: "", Kind: models.PricingRuleKindPeak, Weekdays: models.Weekdays{6}, Multiplier: 1.2}).Validate())
+	assert.Error(t, (&models.PricingRule{Name: "Kind", Kind: "surge", Multiplier: 1.2}).Validate())
+	assert.Error(t, (&models.PricingRule{Name: "Peak", Kind: models.PricingRuleKindPeak, Multiplier: 1.2}).Validate(), "peak rules need a window")
+	assert.Error(t, (&models.PricingRule{Name: "Peak", Kind: models.PricingRuleKindPeak, Weekdays: models.Weekdays{6}, Multiplier: 0.8}).Validate(), "peak rules cannot discount")
+	assert.Error(t, (&models.PricingRule{Name: "Peak", Kind: models.PricingRuleKindPeak, Weekdays: models.Weekdays{7}, Multiplier: 1.2}).Validate())
+	assert.Error(t, (&models.PricingRule{Name: "Peak", Kind: models.PricingRuleKindPeak, Weekdays: models.Weekdays{6}, Timezone: "Mars/Olympus", Multiplier: 1.2}).Validate())
+	assert.Error(t, (&models.PricingRule{Name: "Soon", Kind: models.PricingRuleKindLastMinute, Multiplier: 0.8}).Validate(), "last-minute rules need lead hours")
+	assert.Error(t, (&models.PricingRule{Name: "Soon", Kind: models.PricingRuleKindLastMinute, LeadHours: 24, Multiplier: 1.5}).Validate())
+	assert.Error(t, (&models.PricingRule{Name: "Summer", Kind: models.PricingRuleKindSeasonal, StartsAt: &start, Multiplier: 1.1}).Validate())
+	assert.Error(t, (&models.PricingRule{Name: "Summer", Kind: models.PricingRuleKindSeasonal, StartsAt: &end, EndsAt: &start, Multiplier: 1.1}).Validate())
+	assert.Error(t, (&models.PricingRule{Name: "Huge", Kind: models.PricingRuleKindSeasonal, StartsAt: &start, EndsAt: &end, Multiplier: 6}).Validate())
+}
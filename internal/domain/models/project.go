@@ -48,6 +48,7 @@ type Project struct {
 	// Budget
 	BudgetAmount float64 `json:"budget_amount,omitempty" gorm:"type:decimal(12,2);default:0"`
 	Currency     string  `json:"currency,omitempty" gorm:"size:3;default:'USD'"`
+	HourlyRate   float64 `json:"hourly_rate,omitempty" gorm:"type:decimal(10,2);default:0"` // Default rate for billable time entries
 
 	// Progress snapshot (denormalized for quick dashboard reads)
 	ProgressPercent    int `json:"progress_percent" gorm:"default:0"` // 0-100
@@ -56,10 +57,22 @@ type Project struct {
 	TasksOverdue       int `json:"tasks_overdue" gorm:"default:0"`
 	ActiveBlockedTasks int `json:"active_blocked_tasks" gorm:"default:0"`
 
+	// Hours snapshot, rolled up from task estimates and time entries
+	EstimatedHours float64 `json:"estimated_hours" gorm:"type:decimal(10,2);default:0"`
+	ActualHours    float64 `json:"actual_hours" gorm:"type:decimal(10,2);default:0"`
+
+	// Health snapshot, refreshed by the health recalculation job
+	HealthScore     *int       `json:"health_score,omitempty"`
+	RiskLevel       string     `json:"risk_level,omitempty" gorm:"type:varchar(16);index"`
+	HealthCheckedAt *time.Time `json:"health_checked_at,omitempty" gorm:"index"`
+
 	// Tags and metadata
 	Tags     []string `json:"tags,omitempty" gorm:"type:text[]"`
 	Metadata JSONB    `json:"metadata,omitempty" gorm:"type:jsonb"`
 
+	// Full-text search document of the title and description, maintained by Postgres
+	SearchVector string `json:"-" gorm:"->:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(title, '')), 'A') || setweight(to_tsvector('simple', coalesce(description, '')), 'B')) STORED;index:idx_project_search,type:gin"`
+
 	// Relationships
 	Tenant     *Tenant            `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
 	Artisan    *Artisan           `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Project risk levels reported by the health check
+const (
+	ProjectRiskLow    = "low"
+	ProjectRiskMedium = "medium"
+	ProjectRiskHigh   = "high"
+)
+
+// ProjectHealthSnapshot is a project's health score at a point in time, recorded by
+// the health recalculation job for trend charts
+type ProjectHealthSnapshot struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ProjectID uuid.UUID `json:"project_id" gorm:"type:uuid;not null;index:idx_project_health_project_recorded"`
+
+	// Health
+	HealthScore       int    `json:"health_score"` // 0-100
+	RiskLevel         string `json:"risk_level" gorm:"type:varchar(16);not null;index"`
+	IsOnTrack         bool   `json:"is_on_track"`
+	IsOverdue         bool   `json:"is_overdue"`
+	IsOverBudget      bool   `json:"is_over_budget"`
+	BlockedTasksCount int    `json:"blocked_tasks_count"`
+	OverdueTasksCount int    `json:"overdue_tasks_count"`
+	ScheduleDaysLate  int    `json:"schedule_days_late"`
+	ProgressPercent   int    `json:"progress_percent"`
+
+	RecordedAt time.Time `json:"recorded_at" gorm:"type:timestamptz;not null;index:idx_project_health_project_recorded"`
+}
+
+// TableName specifies the table name
+func (ProjectHealthSnapshot) TableName() string {
+	return "project_health_snapshots"
+}
+
+// BecameHighRisk reports whether the project dropped to high risk since the previous
+// snapshot, or is high risk on its first one
+func (s *ProjectHealthSnapshot) BecameHighRisk(previous *ProjectHealthSnapshot) bool {
+	return s.RiskLevel == ProjectRiskHigh && (previous == nil || previous.RiskLevel != ProjectRiskHigh)
+}
+
+// BecameOverdue reports whether the project went past its due date since the previous
+// snapshot, or is overdue on its first one
+func (s *ProjectHealthSnapshot) BecameOverdue(previous *ProjectHealthSnapshot) bool {
+	return s.IsOverdue && (previous == nil || !previous.IsOverdue)
+}
@@ -0,0 +1,37 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectHealthSnapshot_Alerts(t *testing.T) {
+	high := &models.ProjectHealthSnapshot{RiskLevel: models.ProjectRiskHigh, IsOverdue: true}
+	medium := &models.ProjectHealthSnapshot{RiskLevel: models.ProjectRiskMedium}
+
+	t.Run("first snapshot alerts", func(t *testing.T) {
+		assert.True(t, high.BecameHighRisk(nil))
+		assert.True(t, high.BecameOverdue(nil))
+		assert.False(t, medium.BecameHighRisk(nil))
+		assert.False(t, medium.BecameOverdue(nil))
+	})
+
+	t.Run("dropping to high risk alerts", func(t *testing.T) {
+		assert.True(t, high.BecameHighRisk(medium))
+		assert.True(t, high.BecameOverdue(medium))
+	})
+
+	t.Run("staying high risk does not alert again", func(t *testing.T) {
+		again := &models.ProjectHealthSnapshot{RiskLevel: models.ProjectRiskHigh, IsOverdue: true}
+		assert.False(t, again.BecameHighRisk(high))
+		assert.False(t, again.BecameOverdue(high))
+	})
+
+	t.Run("recovering does not alert", func(t *testing.T) {
+		assert.False(t, medium.BecameHighRisk(high))
+		assert.False(t, medium.BecameOverdue(high))
+	})
+}
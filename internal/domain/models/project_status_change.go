@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// ProjectStatusChange records a project moving from one status to another. The
+// project timeline shows these alongside updates, milestones and task completions.
+type ProjectStatusChange struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ProjectID uuid.UUID `json:"project_id" gorm:"type:uuid;not null;index"`
+
+	// Transition
+	FromStatus ProjectStatus `json:"from_status" gorm:"type:varchar(32);not null"`
+	ToStatus   ProjectStatus `json:"to_status" gorm:"type:varchar(32);not null"`
+	Reason     string        `json:"reason,omitempty" gorm:"type:text"`
+
+	// Relationships
+	Project *Project `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+}
+
+// TableName specifies the table name
+func (ProjectStatusChange) TableName() string {
+	return "project_status_changes"
+}
@@ -0,0 +1,335 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectTemplate is a reusable project plan (kitchen remodel, custom furniture) with
+// predefined milestones and tasks. Their timing is stored as day offsets so a project
+// can be created from the template on any start date.
+type ProjectTemplate struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index:idx_project_template_tenant_name"`
+
+	// Core details
+	Name        string `json:"name" gorm:"not null;size:255;index:idx_project_template_tenant_name"`
+	Description string `json:"description,omitempty" gorm:"type:text"`
+	Category    string `json:"category,omitempty" gorm:"size:100;index"`
+	IsActive    bool   `json:"is_active" gorm:"default:true;index"`
+
+	// Project defaults
+	DefaultDurationDays int             `json:"default_duration_days" gorm:"default:0"` // 0 derives the due date from the plan
+	DefaultBudget       float64         `json:"default_budget" gorm:"type:decimal(12,2);default:0"`
+	Currency            string          `json:"currency" gorm:"size:3;default:'USD'"`
+	HourlyRate          float64         `json:"hourly_rate" gorm:"type:decimal(10,2);default:0"`
+	Priority            ProjectPriority `json:"priority" gorm:"type:varchar(16);not null;default:'medium'"`
+	Tags                []string        `json:"tags,omitempty" gorm:"type:text[]"`
+
+	// Plan
+	Milestones TemplateMilestones `json:"milestones" gorm:"type:jsonb"`
+	Tasks      TemplateTasks      `json:"tasks" gorm:"type:jsonb"`
+
+	// Usage
+	UsageCount int        `json:"usage_count" gorm:"default:0"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" gorm:"type:timestamptz"`
+
+	// Metadata
+	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb"`
+}
+
+// TableName specifies the table name
+func (ProjectTemplate) TableName() string {
+	return "project_templates"
+}
+
+// TemplateMilestone is a milestone of a project template. Key identifies it within the
+// template so tasks can refer to it.
+type TemplateMilestone struct {
+	Key                string   `json:"key"`
+	Title              string   `json:"title"`
+	Description        string   `json:"description,omitempty"`
+	OffsetDays         int      `json:"offset_days"`   // Days after the project start
+	DurationDays       int      `json:"duration_days"` // Days from its start to its due date
+	PaymentPercentage  float64  `json:"payment_percentage,omitempty"`
+	IsPaymentMilestone bool     `json:"is_payment_milestone,omitempty"`
+	RequiresApproval   bool     `json:"requires_approval,omitempty"`
+	Deliverables       []string `json:"deliverables,omitempty"`
+}
+
+// TemplateTask is a task of a project template. DependsOn lists the keys of tasks in
+// the same template it waits on.
+type TemplateTask struct {
+	Key            string       `json:"key"`
+	Title          string       `json:"title"`
+	Description    string       `json:"description,omitempty"`
+	Priority       TaskPriority `json:"priority,omitempty"`
+	MilestoneKey   string       `json:"milestone_key,omitempty"`
+	OffsetDays     int          `json:"offset_days"`
+	DurationDays   int          `json:"duration_days"`
+	EstimatedHours float64      `json:"estimated_hours,omitempty"`
+	EstimatedCost  float64      `json:"estimated_cost,omitempty"`
+	DependsOn      []string     `json:"depends_on,omitempty"`
+	Checklist      []string     `json:"checklist,omitempty"`
+}
+
+// TemplateMilestones is stored as a JSONB array
+type TemplateMilestones []TemplateMilestone
+
+func (m *TemplateMilestones) Scan(value interface{}) error {
+	if value == nil {
+		*m = TemplateMilestones{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, m)
+}
+
+func (m TemplateMilestones) Value() (driver.Value, error) {
+	if m == nil {
+		return json.Marshal([]TemplateMilestone{})
+	}
+	return json.Marshal(m)
+}
+
+// TemplateTasks is stored as a JSONB array
+type TemplateTasks []TemplateTask
+
+func (t *TemplateTasks) Scan(value interface{}) error {
+	if value == nil {
+		*t = TemplateTasks{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, t)
+}
+
+func (t TemplateTasks) Value() (driver.Value, error) {
+	if t == nil {
+		return json.Marshal([]TemplateTask{})
+	}
+	return json.Marshal(t)
+}
+
+// Validate checks that keys are unique, references resolve, task dependencies have no
+// cycles and offsets, durations and amounts are not negative
+func (t *ProjectTemplate) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if t.DefaultDurationDays < 0 || t.DefaultBudget < 0 || t.HourlyRate < 0 {
+		return fmt.Errorf("default duration, budget and hourly rate cannot be negative")
+	}
+
+	milestones := make(map[string]bool, len(t.Milestones))
+	paymentTotal := 0.0
+	for i, milestone := range t.Milestones {
+		if milestone.Key == "" || milestone.Title == "" {
+			return fmt.Errorf("milestone %d needs a key and a title", i+1)
+		}
+		if milestones[milestone.Key] {
+			return fmt.Errorf("milestone key %q is used more than once", milestone.Key)
+		}
+		milestones[milestone.Key] = true
+		if milestone.OffsetDays < 0 || milestone.DurationDays < 0 {
+			return fmt.Errorf("milestone %q cannot have a negative offset or duration", milestone.Key)
+		}
+		if milestone.PaymentPercentage < 0 || milestone.PaymentPercentage > 100 {
+			return fmt.Errorf("milestone %q payment percentage must be between 0 and 100", milestone.Key)
+		}
+		paymentTotal += milestone.PaymentPercentage
+	}
+	if paymentTotal > 100 {
+		return fmt.Errorf("milestone payment percentages add up to more than 100")
+	}
+
+	tasks := make(map[string]uuid.UUID, len(t.Tasks))
+	for i, task := range t.Tasks {
+		if task.Key == "" || task.Title == "" {
+			return fmt.Errorf("task %d needs a key and a title", i+1)
+		}
+		if _, ok := tasks[task.Key]; ok {
+			return fmt.Errorf("task key %q is used more than once", task.Key)
+		}
+		tasks[task.Key] = uuid.New()
+		if task.MilestoneKey != "" && !milestones[task.MilestoneKey] {
+			return fmt.Errorf("task %q refers to unknown milestone %q", task.Key, task.MilestoneKey)
+		}
+		if task.OffsetDays < 0 || task.DurationDays < 0 || task.EstimatedHours < 0 || task.EstimatedCost < 0 {
+			return fmt.Errorf("task %q cannot have a negative offset, duration or estimate", task.Key)
+		}
+		switch task.Priority {
+		case "", TaskPriorityLow, TaskPriorityMedium, TaskPriorityHigh, TaskPriorityCritical:
+		default:
+			return fmt.Errorf("task %q has unknown priority %q", task.Key, task.Priority)
+		}
+	}
+
+	// Check dependencies against stand-in IDs so the task graph rules apply as-is
+	var deps []*TaskDependency
+	for _, task := range t.Tasks {
+		for _, key := range task.DependsOn {
+			dependsOn, ok := tasks[key]
+			if !ok {
+				return fmt.Errorf("task %q depends on unknown task %q", task.Key, key)
+			}
+			if DependencyCreatesCycle(deps, tasks[task.Key], dependsOn) {
+				return fmt.Errorf("task %q depending on %q creates a dependency cycle", task.Key, key)
+			}
+			deps = append(deps, &TaskDependency{TaskID: tasks[task.Key], DependsOnTaskID: dependsOn})
+		}
+	}
+	return nil
+}
+
+// PlannedDurationDays is the number of days from the project start to the latest
+// milestone or task due date
+func (t *ProjectTemplate) PlannedDurationDays() int {
+	days := 0
+	for _, milestone := range t.Milestones {
+		days = max(days, milestone.OffsetDays+milestone.DurationDays)
+	}
+	for _, task := range t.Tasks {
+		days = max(days, task.OffsetDays+task.DurationDays)
+	}
+	return days
+}
+
+// TemplateProject is everything a template instantiates, ready to be saved together
+type TemplateProject struct {
+	Project      *Project
+	Milestones   []*ProjectMilestone
+	Tasks        []*ProjectTask
+	Dependencies []*TaskDependency
+}
+
+// Instantiate builds a planned project from the template with every date offset from
+// start. IDs are assigned up front so tasks can point at their milestones and at each
+// other; tasks with dependencies start blocked. The project is due DefaultDurationDays
+// after start, or when the plan ends if that is later. A zero budget uses the template's
+// default.
+func (t *ProjectTemplate) Instantiate(artisanID uuid.UUID, customerID *uuid.UUID, start time.Time, budget float64) *TemplateProject {
+	if budget <= 0 {
+		budget = t.DefaultBudget
+	}
+	due := start.AddDate(0, 0, max(t.DefaultDurationDays, t.PlannedDurationDays()))
+
+	project := &Project{
+		TenantID:     t.TenantID,
+		ArtisanID:    artisanID,
+		CustomerID:   customerID,
+		Title:        t.Name,
+		Description:  t.Description,
+		Status:       ProjectStatusPlanned,
+		Priority:     t.Priority,
+		StartDate:    &start,
+		DueDate:      &due,
+		BudgetAmount: budget,
+		Currency:     t.Currency,
+		HourlyRate:   t.HourlyRate,
+		TasksTotal:   len(t.Tasks),
+		Tags:         append([]string(nil), t.Tags...),
+		Metadata: JSONB{
+			"template_id": t.ID.String(),
+		},
+	}
+	if project.Priority == "" {
+		project.Priority = ProjectPriorityMedium
+	}
+	project.ID = uuid.New()
+
+	result := &TemplateProject{Project: project}
+
+	milestoneIDs := make(map[string]uuid.UUID, len(t.Milestones))
+	for i, tm := range t.Milestones {
+		milestoneStart := start.AddDate(0, 0, tm.OffsetDays)
+		milestoneDue := milestoneStart.AddDate(0, 0, tm.DurationDays)
+		milestone := &ProjectMilestone{
+			TenantID:           t.TenantID,
+			ProjectID:          project.ID,
+			Title:              tm.Title,
+			Description:        tm.Description,
+			Status:             MilestoneStatusPending,
+			OrderIndex:         i,
+			StartDate:          &milestoneStart,
+			DueDate:            &milestoneDue,
+			PaymentPercentage:  tm.PaymentPercentage,
+			PaymentAmount:      roundMoney(budget * tm.PaymentPercentage / 100),
+			IsPaymentMilestone: tm.IsPaymentMilestone,
+			RequiresApproval:   tm.RequiresApproval,
+			Deliverables:       append([]string(nil), tm.Deliverables...),
+		}
+		milestone.ID = uuid.New()
+		milestoneIDs[tm.Key] = milestone.ID
+		result.Milestones = append(result.Milestones, milestone)
+	}
+
+	taskIDs := make(map[string]uuid.UUID, len(t.Tasks))
+	for _, tt := range t.Tasks {
+		taskIDs[tt.Key] = uuid.New()
+	}
+	for i, tt := range t.Tasks {
+		taskStart := start.AddDate(0, 0, tt.OffsetDays)
+		taskDue := taskStart.AddDate(0, 0, tt.DurationDays)
+		task := &ProjectTask{
+			TenantID:       t.TenantID,
+			ProjectID:      project.ID,
+			Title:          tt.Title,
+			Description:    tt.Description,
+			Status:         TaskStatusTodo,
+			Priority:       tt.Priority,
+			OrderIndex:     i,
+			StartDate:      &taskStart,
+			DueDate:        &taskDue,
+			EstimatedHours: tt.EstimatedHours,
+			EstimatedCost:  tt.EstimatedCost,
+		}
+		task.ID = taskIDs[tt.Key]
+		if task.Priority == "" {
+			task.Priority = TaskPriorityMedium
+		}
+		if id, ok := milestoneIDs[tt.MilestoneKey]; ok {
+			task.MilestoneID = &id
+		}
+		for j, title := range tt.Checklist {
+			task.Checklist = append(task.Checklist, ChecklistItem{ID: strconv.Itoa(j + 1), Title: title, Order: j})
+		}
+		for _, key := range tt.DependsOn {
+			dependsOn := taskIDs[key]
+			task.DependsOn = append(task.DependsOn, dependsOn)
+			result.Dependencies = append(result.Dependencies, &TaskDependency{
+				TenantID:        t.TenantID,
+				ProjectID:       project.ID,
+				TaskID:          task.ID,
+				DependsOnTaskID: dependsOn,
+			})
+		}
+		// Nothing is done yet, so every dependency blocks
+		if task.ApplyDependencyBlockers(append([]uuid.UUID(nil), task.DependsOn...)) {
+			project.ActiveBlockedTasks++
+		}
+
+		project.EstimatedHours += tt.EstimatedHours
+		result.Tasks = append(result.Tasks, task)
+	}
+	project.EstimatedHours = roundMoney(project.EstimatedHours)
+	return result
+}
@@ -0,0 +1,127 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProjectTemplate() *models.ProjectTemplate {
+	template := &models.ProjectTemplate{
+		TenantID:      uuid.New(),
+		Name:          "Kitchen remodel",
+		IsActive:      true,
+		DefaultBudget: 12000,
+		Currency:      "USD",
+		Priority:      models.ProjectPriorityHigh,
+		Milestones: models.TemplateMilestones{
+			{Key: "prep", Title: "Preparation", OffsetDays: 0, DurationDays: 5, PaymentPercentage: 30, IsPaymentMilestone: true},
+			{Key: "install", Title: "Installation", OffsetDays: 5, DurationDays: 10, PaymentPercentage: 70, IsPaymentMilestone: true},
+		},
+		Tasks: models.TemplateTasks{
+			{Key: "survey", Title: "Site survey", MilestoneKey: "prep", DurationDays: 1, EstimatedHours: 4},
+			{Key: "demo", Title: "Demolition", MilestoneKey: "prep", OffsetDays: 2, DurationDays: 3, EstimatedHours: 16, DependsOn: []string{"survey"}},
+			{Key: "cabinets", Title: "Fit cabinets", MilestoneKey: "install", OffsetDays: 5, DurationDays: 7, EstimatedHours: 24.5,
+				DependsOn: []string{"demo"}, Checklist: []string{"Level base units", "Hang wall units"}},
+			{Key: "clean", Title: "Final clean", OffsetDays: 14, DurationDays: 2, Priority: models.TaskPriorityLow},
+		},
+	}
+	template.ID = uuid.New()
+	return template
+}
+
+func TestProjectTemplate_Validate(t *testing.T) {
+	assert.NoError(t, newTestProjectTemplate().Validate())
+
+	cases := map[string]func(*models.ProjectTemplate){
+		"missing name":        func(tpl *models.ProjectTemplate) { tpl.Name = "" },
+		"duplicate task key":  func(tpl *models.ProjectTemplate) { tpl.Tasks[1].Key = "survey" },
+		"unknown milestone":   func(tpl *models.ProjectTemplate) { tpl.Tasks[0].MilestoneKey = "finish" },
+		"unknown dependency":  func(tpl *models.ProjectTemplate) { tpl.Tasks[1].DependsOn = []string{"paint"} },
+		"dependency cycle":    func(tpl *models.ProjectTemplate) { tpl.Tasks[0].DependsOn = []string{"cabinets"} },
+		"negative offset":     func(tpl *models.ProjectTemplate) { tpl.Tasks[3].OffsetDays = -1 },
+		"payments over 100%":  func(tpl *models.ProjectTemplate) { tpl.Milestones[0].PaymentPercentage = 40 },
+		"unknown priority":    func(tpl *models.ProjectTemplate) { tpl.Tasks[3].Priority = "urgent" },
+		"duplicate milestone": func(tpl *models.ProjectTemplate) { tpl.Milestones[1].Key = "prep" },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			template := newTestProjectTemplate()
+			mutate(template)
+			assert.Error(t, template.Validate())
+		})
+	}
+}
+
+func TestProjectTemplate_Instantiate(t *testing.T) {
+	template := newTestProjectTemplate()
+	require.NoError(t, template.Validate())
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	artisanID, customerID := uuid.New(), uuid.New()
+
+	instance := template.Instantiate(artisanID, &customerID, start, 0)
+
+	project := instance.Project
+	assert.Equal(t, template.TenantID, project.TenantID)
+	assert.Equal(t, artisanID, project.ArtisanID)
+	assert.Equal(t, &customerID, project.CustomerID)
+	assert.Equal(t, models.ProjectStatusPlanned, project.Status)
+	assert.Equal(t, models.ProjectPriorityHigh, project.Priority)
+	assert.Equal(t, 12000.0, project.BudgetAmount, "defaults to the template budget")
+	assert.Equal(t, start, *project.StartDate)
+	assert.Equal(t, start.AddDate(0, 0, 16), *project.DueDate, "the plan ends with the final clean")
+	assert.Equal(t, 4, project.TasksTotal)
+	assert.Equal(t, 44.5, project.EstimatedHours)
+	assert.Equal(t, 2, project.ActiveBlockedTasks)
+	assert.Equal(t, template.ID.String(), project.Metadata["template_id"])
+
+	require.Len(t, instance.Milestones, 2)
+	install := instance.Milestones[1]
+	assert.Equal(t, project.ID, install.ProjectID)
+	assert.Equal(t, start.AddDate(0, 0, 5), *install.StartDate)
+	assert.Equal(t, start.AddDate(0, 0, 15), *install.DueDate)
+	assert.Equal(t, 8400.0, install.PaymentAmount)
+
+	require.Len(t, instance.Tasks, 4)
+	survey, demo, cabinets, clean := instance.Tasks[0], instance.Tasks[1], instance.Tasks[2], instance.Tasks[3]
+	assert.Equal(t, start.AddDate(0, 0, 2), *demo.StartDate)
+	assert.Equal(t, start.AddDate(0, 0, 5), *demo.DueDate)
+	assert.Equal(t, instance.Milestones[0].ID, *survey.MilestoneID)
+	assert.Equal(t, install.ID, *cabinets.MilestoneID)
+	assert.Nil(t, clean.MilestoneID)
+	assert.Equal(t, models.TaskPriorityLow, clean.Priority)
+	assert.Equal(t, models.TaskPriorityMedium, survey.Priority)
+	require.Len(t, cabinets.Checklist, 2)
+	assert.Equal(t, "Hang wall units", cabinets.Checklist[1].Title)
+
+	assert.Equal(t, models.TaskStatusTodo, survey.Status)
+	assert.Equal(t, models.TaskStatusBlocked, demo.Status)
+	assert.Equal(t, models.DependencyBlockReason, demo.BlockReason)
+	assert.Equal(t, []uuid.UUID{survey.ID}, demo.DependsOn)
+	assert.Equal(t, []uuid.UUID{demo.ID}, cabinets.BlockedBy)
+
+	require.Len(t, instance.Dependencies, 2)
+	assert.Equal(t, cabinets.ID, instance.Dependencies[1].TaskID)
+	assert.Equal(t, demo.ID, instance.Dependencies[1].DependsOnTaskID)
+	assert.Equal(t, project.ID, instance.Dependencies[1].ProjectID)
+
+	t.Run("default duration extends the due date", func(t *testing.T) {
+		template.DefaultDurationDays = 30
+		instance := template.Instantiate(artisanID, nil, start, 15000)
+		assert.Equal(t, start.AddDate(0, 0, 30), *instance.Project.DueDate)
+		assert.Equal(t, 15000.0, instance.Project.BudgetAmount)
+		assert.Equal(t, 4500.0, instance.Milestones[0].PaymentAmount)
+		assert.Nil(t, instance.Project.CustomerID)
+	})
+
+	t.Run("instances do not share IDs", func(t *testing.T) {
+		again := template.Instantiate(artisanID, nil, start, 0)
+		assert.NotEqual(t, project.ID, again.Project.ID)
+		assert.NotEqual(t, survey.ID, again.Tasks[0].ID)
+	})
+}
@@ -95,3 +95,25 @@ func (pc *PromoCode) CalculateDiscount(amount float64) float64 {
 
 	return discount
 }
+
+// PromoCodeRedemption records a promo code used on a booking. It backs the per-user
+// limit and the code's usage statistics.
+type PromoCodeRedemption struct {
+	BaseModel
+
+	PromoCodeID uuid.UUID `json:"promo_code_id" gorm:"type:uuid;not null;index"`
+	TenantID    uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	BookingID   uuid.UUID `json:"booking_id" gorm:"type:uuid;not null;uniqueIndex"`
+
+	OrderAmount    float64 `json:"order_amount" gorm:"type:decimal(10,2);not null"` // Before the discount
+	DiscountAmount float64 `json:"discount_amount" gorm:"type:decimal(10,2);not null"`
+
+	// Relationships
+	PromoCode *PromoCode `json:"promo_code,omitempty" gorm:"foreignKey:PromoCodeID"`
+}
+
+// TableName specifies the table name for PromoCodeRedemption
+func (PromoCodeRedemption) TableName() string {
+	return "promo_code_redemptions"
+}
@@ -0,0 +1,45 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBooking_ApplyDiscount(t *testing.T) {
+	promo := &models.PromoCode{
+		BaseModel: models.BaseModel{ID: uuid.New()},
+		Code:      "SPRING10",
+		Type:      models.DiscountTypePercentage,
+		Value:     10,
+		StartsAt:  time.Now().Add(-time.Hour),
+		IsActive:  true,
+	}
+	vat := &models.TaxRule{Name: "VAT", Rate: 20, IsActive: true}
+
+	booking := &models.Booking{BasePrice: 80, AddonsPrice: 20}
+	booking.ApplyDiscount(promo, promo.CalculateDiscount(booking.Subtotal()))
+	booking.ApplyTax(models.TaxLines{vat.Apply(booking.Subtotal(), false)})
+
+	assert.Equal(t, "SPRING10", booking.PromoCode)
+	assert.InDelta(t, 10, booking.DiscountAmount, 0.001)
+	assert.InDelta(t, 90, booking.Subtotal(), 0.001)
+	assert.InDelta(t, 18, booking.TaxAmount, 0.001)
+	assert.InDelta(t, 108, booking.TotalPrice, 0.001)
+
+	t.Run("payment records its share of the discount", func(t *testing.T) {
+		deposit := &models.Payment{Amount: 54, Type: models.PaymentTypeDeposit}
+		deposit.ApplyBookingDiscount(booking)
+		assert.Equal(t, booking.PromoCodeID, deposit.PromoCodeID)
+		assert.InDelta(t, 5, deposit.DiscountAmount, 0.001)
+
+		tip := &models.Payment{Amount: 10, Type: models.PaymentTypeTip}
+		tip.ApplyBookingDiscount(booking)
+		assert.Nil(t, tip.PromoCodeID)
+		assert.Zero(t, tip.DiscountAmount)
+	})
+}
@@ -0,0 +1,295 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuoteStatus string
+
+const (
+	QuoteStatusDraft    QuoteStatus = "draft"    // Being prepared; not visible to the customer
+	QuoteStatusSent     QuoteStatus = "sent"     // Awaiting the customer's answer
+	QuoteStatusAccepted QuoteStatus = "accepted" // Converted into a project
+	QuoteStatusDeclined QuoteStatus = "declined"
+	QuoteStatusExpired  QuoteStatus = "expired" // Not answered before ValidUntil
+)
+
+// Quote is an artisan's priced proposal to a customer, made before a project exists.
+// Each time a quote is sent its content is kept as a QuoteRevision, so the customer's
+// answer can be traced to exactly what they were shown. Revising a sent, declined or
+// expired quote starts a new revision.
+type Quote struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID   uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index:idx_quote_tenant_status"`
+	ArtisanID  uuid.UUID `json:"artisan_id" gorm:"type:uuid;not null;index"`
+	CustomerID uuid.UUID `json:"customer_id" gorm:"type:uuid;not null;index"`
+
+	// Core details
+	Title       string      `json:"title" gorm:"not null;size:255"`
+	Description string      `json:"description,omitempty" gorm:"type:text"`
+	Status      QuoteStatus `json:"status" gorm:"type:varchar(16);not null;default:'draft';index:idx_quote_tenant_status"`
+	Revision    int         `json:"revision" gorm:"not null;default:1"`
+
+	// Pricing
+	LineItems      InvoiceLineItems `json:"line_items" gorm:"type:jsonb"`
+	SubtotalAmount float64          `json:"subtotal_amount" gorm:"type:decimal(12,2);default:0"`
+	DiscountAmount float64          `json:"discount_amount" gorm:"type:decimal(12,2);default:0"`
+	TaxAmount      float64          `json:"tax_amount" gorm:"type:decimal(12,2);default:0"`
+	TotalAmount    float64          `json:"total_amount" gorm:"type:decimal(12,2);default:0"`
+	Currency       string           `json:"currency" gorm:"size:3;default:'USD'"`
+	DepositPercent float64          `json:"deposit_percent" gorm:"type:decimal(5,2);default:0"` // Invoiced on acceptance; 0 for none
+
+	// Timing
+	ValidUntil         time.Time  `json:"valid_until" gorm:"type:timestamptz;not null;index"`
+	EstimatedStartDate *time.Time `json:"estimated_start_date,omitempty" gorm:"type:timestamptz"`
+	EstimatedDueDate   *time.Time `json:"estimated_due_date,omitempty" gorm:"type:timestamptz"`
+
+	// Terms
+	Notes           string `json:"notes,omitempty" gorm:"type:text"`
+	TermsConditions string `json:"terms_conditions,omitempty" gorm:"type:text"`
+
+	// Customer response
+	SentAt           *time.Time `json:"sent_at,omitempty" gorm:"type:timestamptz"`
+	RespondedAt      *time.Time `json:"responded_at,omitempty" gorm:"type:timestamptz"`
+	AcceptedRevision int        `json:"accepted_revision,omitempty" gorm:"default:0"`
+	DeclineReason    string     `json:"decline_reason,omitempty" gorm:"type:text"`
+
+	// Conversion
+	ProjectID        *uuid.UUID `json:"project_id,omitempty" gorm:"type:uuid;index"`
+	DepositInvoiceID *uuid.UUID `json:"deposit_invoice_id,omitempty" gorm:"type:uuid"`
+
+	// Metadata
+	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb"`
+
+	// Relationships
+	Artisan   *Artisan        `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
+	Customer  *Customer       `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	Project   *Project        `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+	Revisions []QuoteRevision `json:"revisions,omitempty" gorm:"foreignKey:QuoteID"`
+}
+
+// TableName specifies the table name
+func (Quote) TableName() string {
+	return "quotes"
+}
+
+// QuoteRevision is the content of a quote as it was sent to the customer
+type QuoteRevision struct {
+	BaseModel
+
+	QuoteID  uuid.UUID `json:"quote_id" gorm:"type:uuid;not null;uniqueIndex:idx_quote_revision"`
+	Revision int       `json:"revision" gorm:"not null;uniqueIndex:idx_quote_revision"`
+
+	Title           string           `json:"title" gorm:"not null;size:255"`
+	Description     string           `json:"description,omitempty" gorm:"type:text"`
+	LineItems       InvoiceLineItems `json:"line_items" gorm:"type:jsonb"`
+	SubtotalAmount  float64          `json:"subtotal_amount" gorm:"type:decimal(12,2);default:0"`
+	DiscountAmount  float64          `json:"discount_amount" gorm:"type:decimal(12,2);default:0"`
+	TaxAmount       float64          `json:"tax_amount" gorm:"type:decimal(12,2);default:0"`
+	TotalAmount     float64          `json:"total_amount" gorm:"type:decimal(12,2);default:0"`
+	Currency        string           `json:"currency" gorm:"size:3"`
+	DepositPercent  float64          `json:"deposit_percent" gorm:"type:decimal(5,2);default:0"`
+	ValidUntil      time.Time        `json:"valid_until" gorm:"type:timestamptz;not null"`
+	Notes           string           `json:"notes,omitempty" gorm:"type:text"`
+	TermsConditions string           `json:"terms_conditions,omitempty" gorm:"type:text"`
+	SentAt          time.Time        `json:"sent_at" gorm:"type:timestamptz;not null"`
+}
+
+// TableName specifies the table name
+func (QuoteRevision) TableName() string {
+	return "quote_revisions"
+}
+
+// Recalculate prices each line item as quantity times unit price and totals the quote
+func (q *Quote) Recalculate() {
+	q.SubtotalAmount = 0
+	for i := range q.LineItems {
+		item := &q.LineItems[i]
+		item.TotalPrice = roundMoney(float64(item.Quantity) * item.UnitPrice)
+		q.SubtotalAmount += item.TotalPrice
+	}
+	q.SubtotalAmount = roundMoney(q.SubtotalAmount)
+	q.TotalAmount = roundMoney(q.SubtotalAmount - q.DiscountAmount + q.TaxAmount)
+}
+
+// DepositAmount returns the deposit invoiced when the quote is accepted
+func (q *Quote) DepositAmount() float64 {
+	return roundMoney(q.TotalAmount * q.DepositPercent / 100)
+}
+
+// Validate checks the quote's pricing and dates
+func (q *Quote) Validate() error {
+	if q.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if len(q.LineItems) == 0 {
+		return fmt.Errorf("a quote needs at least one line item")
+	}
+	for i, item := range q.LineItems {
+		if item.Description == "" {
+			return fmt.Errorf("line item %d needs a description", i+1)
+		}
+		if item.Quantity < 1 {
+			return fmt.Errorf("line item %d needs a quantity of at least 1", i+1)
+		}
+		if item.UnitPrice < 0 {
+			return fmt.Errorf("line item %d cannot have a negative price", i+1)
+		}
+	}
+	if q.DiscountAmount < 0 || q.TaxAmount < 0 {
+		return fmt.Errorf("discount and tax cannot be negative")
+	}
+	if q.DiscountAmount > q.SubtotalAmount {
+		return fmt.Errorf("discount cannot exceed the subtotal")
+	}
+	if q.DepositPercent < 0 || q.DepositPercent > 100 {
+		return fmt.Errorf("deposit_percent must be between 0 and 100")
+	}
+	if len(q.Currency) != 3 {
+		return fmt.Errorf("currency must be a 3-letter code")
+	}
+	if q.ValidUntil.IsZero() {
+		return fmt.Errorf("valid_until is required")
+	}
+	if q.EstimatedStartDate != nil && q.EstimatedDueDate != nil && q.EstimatedDueDate.Before(*q.EstimatedStartDate) {
+		return fmt.Errorf("estimated_due_date must be after estimated_start_date")
+	}
+	return nil
+}
+
+// IsExpired reports whether a sent quote was not answered in time
+func (q *Quote) IsExpired(now time.Time) bool {
+	return q.Status == QuoteStatusSent && now.After(q.ValidUntil)
+}
+
+// Revise prepares the quote for edits. A quote the customer has seen moves back to
+// draft as a new revision; accepted quotes cannot change.
+func (q *Quote) Revise() error {
+	switch q.Status {
+	case QuoteStatusDraft:
+		return nil
+	case QuoteStatusAccepted:
+		return fmt.Errorf("an accepted quote cannot be changed")
+	}
+	q.Revision++
+	q.Status = QuoteStatusDraft
+	q.SentAt = nil
+	q.RespondedAt = nil
+	q.DeclineReason = ""
+	return nil
+}
+
+// Send marks a draft quote as sent and returns the revision to keep in its history
+func (q *Quote) Send(now time.Time) (*QuoteRevision, error) {
+	if q.Status != QuoteStatusDraft {
+		return nil, fmt.Errorf("only draft quotes can be sent")
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	if !q.ValidUntil.After(now) {
+		return nil, fmt.Errorf("valid_until must be in the future")
+	}
+
+	q.Status = QuoteStatusSent
+	q.SentAt = &now
+	return &QuoteRevision{
+		QuoteID:         q.ID,
+		Revision:        q.Revision,
+		Title:           q.Title,
+		Description:     q.Description,
+		LineItems:       append(InvoiceLineItems(nil), q.LineItems...),
+		SubtotalAmount:  q.SubtotalAmount,
+		DiscountAmount:  q.DiscountAmount,
+		TaxAmount:       q.TaxAmount,
+		TotalAmount:     q.TotalAmount,
+		Currency:        q.Currency,
+		DepositPercent:  q.DepositPercent,
+		ValidUntil:      q.ValidUntil,
+		Notes:           q.Notes,
+		TermsConditions: q.TermsConditions,
+		SentAt:          now,
+	}, nil
+}
+
+// canRespond checks that the customer can still answer the quote, and that they are
+// answering the revision they were shown when one is given
+func (q *Quote) canRespond(now time.Time, revision int) error {
+	if q.Status != QuoteStatusSent {
+		return fmt.Errorf("quote is %s and can no longer be answered", q.Status)
+	}
+	if q.IsExpired(now) {
+		return fmt.Errorf("quote expired on %s", q.ValidUntil.Format("2006-01-02"))
+	}
+	if revision != 0 && revision != q.Revision {
+		return fmt.Errorf("quote has been revised; review revision %d", q.Revision)
+	}
+	return nil
+}
+
+// Accept records the customer's acceptance of the current revision. revision, when
+// not zero, is the revision the customer was shown.
+func (q *Quote) Accept(now time.Time, revision int) error {
+	if err := q.canRespond(now, revision); err != nil {
+		return err
+	}
+	q.Status = QuoteStatusAccepted
+	q.RespondedAt = &now
+	q.AcceptedRevision = q.Revision
+	return nil
+}
+
+// Decline records the customer's refusal
+func (q *Quote) Decline(now time.Time, revision int, reason string) error {
+	if err := q.canRespond(now, revision); err != nil {
+		return err
+	}
+	q.Status = QuoteStatusDeclined
+	q.RespondedAt = &now
+	q.DeclineReason = reason
+	return nil
+}
+
+// ToProject builds the project an accepted quote turns into, with one task per line item
+func (q *Quote) ToProject() (*Project, []*ProjectTask) {
+	project := &Project{
+		TenantID:     q.TenantID,
+		ArtisanID:    q.ArtisanID,
+		CustomerID:   &q.CustomerID,
+		Title:        q.Title,
+		Description:  q.Description,
+		Status:       ProjectStatusPlanned,
+		Priority:     ProjectPriorityMedium,
+		StartDate:    q.EstimatedStartDate,
+		DueDate:      q.EstimatedDueDate,
+		BudgetAmount: q.TotalAmount,
+		Currency:     q.Currency,
+		TasksTotal:   len(q.LineItems),
+		Metadata: JSONB{
+			"quote_id":       q.ID.String(),
+			"quote_revision": q.Revision,
+		},
+	}
+
+	tasks := make([]*ProjectTask, 0, len(q.LineItems))
+	for i, item := range q.LineItems {
+		title := item.Description
+		if runes := []rune(title); len(runes) > 255 {
+			title = string(runes[:255])
+		}
+		tasks = append(tasks, &ProjectTask{
+			TenantID:      q.TenantID,
+			Title:         title,
+			Status:        TaskStatusTodo,
+			Priority:      TaskPriorityMedium,
+			OrderIndex:    i,
+			EstimatedCost: item.TotalPrice,
+		})
+	}
+	return project, tasks
+}
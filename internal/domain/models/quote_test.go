@@ -0,0 +1,177 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQuote(now time.Time) *models.Quote {
+	quote := &models.Quote{
+		TenantID:   uuid.New(),
+		ArtisanID:  uuid.New(),
+		CustomerID: uuid.New(),
+		Title:      "Kitchen refit",
+		Status:     models.QuoteStatusDraft,
+		Revision:   1,
+		LineItems: models.InvoiceLineItems{
+			{Description: "Cabinets", Quantity: 4, UnitPrice: 250},
+			{Description: "Fitting", Quantity: 1, UnitPrice: 400.5},
+		},
+		DiscountAmount: 100,
+		TaxAmount:      130,
+		Currency:       "USD",
+		DepositPercent: 25,
+		ValidUntil:     now.Add(14 * 24 * time.Hour),
+	}
+	quote.ID = uuid.New()
+	quote.Recalculate()
+	return quote
+}
+
+func TestQuote_Recalculate(t *testing.T) {
+	quote := newTestQuote(time.Now())
+
+	assert.Equal(t, 1000.0, quote.LineItems[0].TotalPrice)
+	assert.Equal(t, 1400.5, quote.SubtotalAmount)
+	assert.Equal(t, 1430.5, quote.TotalAmount)
+	assert.Equal(t, 357.63, quote.DepositAmount())
+}
+
+func TestQuote_Validate(t *testing.T) {
+	now := time.Now()
+	assert.NoError(t, newTestQuote(now).Validate())
+
+	noItems := newTestQuote(now)
+	noItems.LineItems = nil
+	noItems.Recalculate()
+	assert.Error(t, noItems.Validate())
+
+	zeroQuantity := newTestQuote(now)
+	zeroQuantity.LineItems[1].Quantity = 0
+	assert.Error(t, zeroQuantity.Validate())
+
+	bigDiscount := newTestQuote(now)
+	bigDiscount.DiscountAmount = 5000
+	assert.Error(t, bigDiscount.Validate())
+
+	badDeposit := newTestQuote(now)
+	badDeposit.DepositPercent = 120
+	assert.Error(t, badDeposit.Validate())
+
+	backwards := newTestQuote(now)
+	start, due := now.Add(48*time.Hour), now.Add(24*time.Hour)
+	backwards.EstimatedStartDate, backwards.EstimatedDueDate = &start, &due
+	assert.Error(t, backwards.Validate())
+}
+
+func TestQuote_Revisions(t *testing.T) {
+	now := time.Now()
+	quote := newTestQuote(now)
+
+	first, err := quote.Send(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Revision)
+	assert.Equal(t, models.QuoteStatusSent, quote.Status)
+
+	_, err = quote.Send(now)
+	assert.Error(t, err, "sent quotes must be revised before sending again")
+
+	require.NoError(t, quote.Revise())
+	assert.Equal(t, models.QuoteStatusDraft, quote.Status)
+	assert.Nil(t, quote.SentAt)
+	quote.LineItems[0].UnitPrice = 200
+	quote.Recalculate()
+
+	second, err := quote.Send(now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Revision)
+	assert.Equal(t, 1000.0, first.LineItems[0].TotalPrice, "earlier revisions keep what was sent")
+	assert.Equal(t, 800.0, second.LineItems[0].TotalPrice)
+
+	t.Run("cannot send an already expired quote", func(t *testing.T) {
+		stale := newTestQuote(now)
+		stale.ValidUntil = now.Add(-time.Hour)
+		_, err := stale.Send(now)
+		assert.Error(t, err)
+	})
+}
+
+func TestQuote_Respond(t *testing.T) {
+	now := time.Now()
+	sent := func() *models.Quote {
+		quote := newTestQuote(now)
+		_, err := quote.Send(now)
+		require.NoError(t, err)
+		return quote
+	}
+
+	t.Run("accepts the current revision", func(t *testing.T) {
+		quote := sent()
+		require.NoError(t, quote.Accept(now, 1))
+		assert.Equal(t, models.QuoteStatusAccepted, quote.Status)
+		assert.Equal(t, 1, quote.AcceptedRevision)
+		assert.Error(t, quote.Revise(), "accepted quotes are final")
+		assert.Error(t, quote.Decline(now, 0, ""))
+	})
+
+	t.Run("rejects a stale revision", func(t *testing.T) {
+		quote := sent()
+		require.NoError(t, quote.Revise())
+		_, err := quote.Send(now)
+		require.NoError(t, err)
+		assert.Error(t, quote.Accept(now, 1))
+		assert.NoError(t, quote.Accept(now, 2))
+	})
+
+	t.Run("rejects answers after expiry", func(t *testing.T) {
+		quote := sent()
+		later := quote.ValidUntil.Add(time.Minute)
+		assert.True(t, quote.IsExpired(later))
+		assert.Error(t, quote.Accept(later, 0))
+		assert.Error(t, quote.Decline(later, 0, "too late"))
+	})
+
+	t.Run("drafts cannot be answered", func(t *testing.T) {
+		assert.Error(t, newTestQuote(now).Accept(now, 0))
+	})
+
+	t.Run("declines with a reason", func(t *testing.T) {
+		quote := sent()
+		require.NoError(t, quote.Decline(now, 0, "over budget"))
+		assert.Equal(t, models.QuoteStatusDeclined, quote.Status)
+		assert.Equal(t, "over budget", quote.DeclineReason)
+		require.NoError(t, quote.Revise(), "declined quotes can be revised")
+		assert.Equal(t, 2, quote.Revision)
+		assert.Empty(t, quote.DeclineReason)
+	})
+}
+
+func TestQuote_ToProject(t *testing.T) {
+	quote := newTestQuote(time.Now())
+	start := time.Now().Add(7 * 24 * time.Hour)
+	quote.EstimatedStartDate = &start
+
+	project, tasks := quote.ToProject()
+
+	assert.Equal(t, quote.TenantID, project.TenantID)
+	assert.Equal(t, quote.ArtisanID, project.ArtisanID)
+	require.NotNil(t, project.CustomerID)
+	assert.Equal(t, quote.CustomerID, *project.CustomerID)
+	assert.Equal(t, quote.TotalAmount, project.BudgetAmount)
+	assert.Equal(t, &start, project.StartDate)
+	assert.Equal(t, models.ProjectStatusPlanned, project.Status)
+	assert.Equal(t, quote.ID.String(), project.Metadata["quote_id"])
+	assert.Equal(t, 2, project.TasksTotal)
+
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "Cabinets", tasks[0].Title)
+	assert.Equal(t, 1000.0, tasks[0].EstimatedCost)
+	assert.Equal(t, 1, tasks[1].OrderIndex)
+	assert.Equal(t, quote.TenantID, tasks[1].TenantID)
+}
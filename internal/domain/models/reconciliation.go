@@ -0,0 +1,101 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReconciliationStatus is the outcome of a reconciliation run
+type ReconciliationStatus string
+
+const (
+	ReconciliationCompleted ReconciliationStatus = "completed"
+	ReconciliationFailed    ReconciliationStatus = "failed" // The provider could not be read; retried on the next run
+)
+
+// DiscrepancyKind classifies a mismatch between our payments and the provider's books
+type DiscrepancyKind string
+
+const (
+	DiscrepancyMissingLocally    DiscrepancyKind = "missing_locally"     // The provider collected money we have no payment for
+	DiscrepancyMissingAtProvider DiscrepancyKind = "missing_at_provider" // A payment we recorded as paid never reached the balance
+	DiscrepancyAmountMismatch    DiscrepancyKind = "amount_mismatch"     // Both sides have the payment with different amounts
+	DiscrepancyStatusMismatch    DiscrepancyKind = "status_mismatch"     // The provider collected a payment we do not show as paid
+)
+
+// ReconciliationDiscrepancy is one mismatch found by a reconciliation run
+type ReconciliationDiscrepancy struct {
+	Kind                  DiscrepancyKind `json:"kind"`
+	TenantID              *uuid.UUID      `json:"tenant_id,omitempty"`
+	PaymentID             *uuid.UUID      `json:"payment_id,omitempty"`
+	ProviderTransactionID string          `json:"provider_transaction_id,omitempty"`
+	ProviderPaymentID     string          `json:"provider_payment_id,omitempty"`
+	LocalAmount           float64         `json:"local_amount,omitempty"`
+	LocalStatus           PaymentStatus   `json:"local_status,omitempty"`
+	ProviderAmount        float64         `json:"provider_amount,omitempty"`
+	Currency              string          `json:"currency,omitempty"`
+}
+
+// ReconciliationDiscrepancies is stored as a JSONB array on the report
+type ReconciliationDiscrepancies []ReconciliationDiscrepancy
+
+func (d *ReconciliationDiscrepancies) Scan(value interface{}) error {
+	if value == nil {
+		*d = ReconciliationDiscrepancies{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, d)
+}
+
+func (d ReconciliationDiscrepancies) Value() (driver.Value, error) {
+	if d == nil {
+		return json.Marshal([]ReconciliationDiscrepancy{})
+	}
+	return json.Marshal(d)
+}
+
+// ReconciliationReport records how one day's payments through one provider matched the
+// provider's balance transactions. Re-running a day replaces its report.
+type ReconciliationReport struct {
+	BaseModel
+
+	Date     time.Time            `json:"date" gorm:"type:date;not null;uniqueIndex:idx_reconciliation_date_provider"`
+	Provider string               `json:"provider" gorm:"size:50;not null;uniqueIndex:idx_reconciliation_date_provider"`
+	Status   ReconciliationStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	Error    string               `json:"error,omitempty" gorm:"type:text"`
+	RunAt    time.Time            `json:"run_at" gorm:"not null"`
+
+	ProviderTransactions int `json:"provider_transactions" gorm:"default:0"` // Charges on the provider balance
+	LocalPayments        int `json:"local_payments" gorm:"default:0"`        // Payments we recorded as paid that day
+	MatchedCount         int `json:"matched_count" gorm:"default:0"`
+	DiscrepancyCount     int `json:"discrepancy_count" gorm:"default:0"`
+
+	Discrepancies ReconciliationDiscrepancies `json:"discrepancies" gorm:"type:jsonb"`
+}
+
+// TableName specifies the table name for ReconciliationReport
+func (ReconciliationReport) TableName() string {
+	return "reconciliation_reports"
+}
+
+// AddDiscrepancy records a mismatch on the report
+func (r *ReconciliationReport) AddDiscrepancy(d ReconciliationDiscrepancy) {
+	r.Discrepancies = append(r.Discrepancies, d)
+	r.DiscrepancyCount = len(r.Discrepancies)
+}
+
+// Fail marks the run as failed, keeping the error for operators
+func (r *ReconciliationReport) Fail(err error) {
+	r.Status = ReconciliationFailed
+	r.Error = err.Error()
+}
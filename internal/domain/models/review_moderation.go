@@ -0,0 +1,299 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// ReviewModerationStatus is where a review is in moderation, derived from whether it is
+// published and whether a moderator has decided on it
+type ReviewModerationStatus string
+
+const (
+	ReviewModerationPending   ReviewModerationStatus = "pending"   // Held for a moderator
+	ReviewModerationPublished ReviewModerationStatus = "published" // Public, automatically or by a moderator
+	ReviewModerationRejected  ReviewModerationStatus = "rejected"  // Hidden by a moderator
+)
+
+// ReviewHoldReason is why a new review waits for a moderator instead of publishing
+type ReviewHoldReason string
+
+const (
+	ReviewHoldApprovalRequired ReviewHoldReason = "approval_required" // The tenant approves every review
+	ReviewHoldLowRating        ReviewHoldReason = "low_rating"        // Rated below the auto-publish minimum
+	ReviewHoldProfanity        ReviewHoldReason = "profanity"         // Contains profanity
+	ReviewHoldPhotos           ReviewHoldReason = "photos"            // Has photos
+)
+
+// MaxBlockedWords is the number of words a tenant can add to the profanity filter
+const MaxBlockedWords = 100
+
+// ReviewModerationPolicy holds the tenant-configurable rules deciding which new reviews
+// publish straight away, and when customers are asked to leave one
+type ReviewModerationPolicy struct {
+	AutoPublishMinRating int      `json:"auto_publish_min_rating" validate:"min=1,max=5"` // Lower-rated reviews wait for a moderator
+	HoldProfanity        bool     `json:"hold_profanity"`                                 // Reviews with profanity wait for a moderator; it is masked either way
+	HoldWithPhotos       bool     `json:"hold_with_photos"`                               // Reviews with photos wait for a moderator
+	BlockedWords         []string `json:"blocked_words,omitempty" validate:"max=100"`     // Filtered on top of the built-in profanity list
+	RequestAfterHours    int      `json:"request_after_hours" validate:"min=0,max=720"`   // Hours after completion customers are asked for a review; 0 turns requests off
+}
+
+// DefaultReviewModerationPolicy returns the policy applied when a tenant has not
+// configured one: everything publishes except reviews with profanity, and customers are
+// asked for a review a day after completion
+func DefaultReviewModerationPolicy() ReviewModerationPolicy {
+	return ReviewModerationPolicy{
+		AutoPublishMinRating: 1,
+		HoldProfanity:        true,
+		RequestAfterHours:    24,
+	}
+}
+
+// Validate checks the policy is usable and normalizes the blocked words
+func (p *ReviewModerationPolicy) Validate() error {
+	if p.AutoPublishMinRating < 1 || p.AutoPublishMinRating > 5 {
+		return fmt.Errorf("auto_publish_min_rating must be between 1 and 5")
+	}
+	if p.RequestAfterHours < 0 || p.RequestAfterHours > 720 {
+		return fmt.Errorf("request_after_hours must be between 0 and 720")
+	}
+
+	words := make([]string, 0, len(p.BlockedWords))
+	for _, word := range p.BlockedWords {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" {
+			continue
+		}
+		if profanityWordPattern.FindString(word) != word {
+			return fmt.Errorf("blocked word %q must be a single word", word)
+		}
+		words = append(words, word)
+	}
+	if len(words) > MaxBlockedWords {
+		return fmt.Errorf("at most %d blocked words are allowed", MaxBlockedWords)
+	}
+	p.BlockedWords = words
+	return nil
+}
+
+// HoldReason returns why a new review must wait for a moderator, or "" when it can
+// publish straight away. The review's text must have been run through MaskProfanity.
+func (p ReviewModerationPolicy) HoldReason(review *Review) ReviewHoldReason {
+	switch {
+	case review.Rating < p.AutoPublishMinRating:
+		return ReviewHoldLowRating
+	case review.ContainsProfanity && p.HoldProfanity:
+		return ReviewHoldProfanity
+	case len(review.PhotoURLs) > 0 && p.HoldWithPhotos:
+		return ReviewHoldPhotos
+	}
+	return ""
+}
+
+// profanityWordPattern splits text into the words the profanity filter checks
+var profanityWordPattern = regexp.MustCompile(`[\p{L}\p{N}']+`)
+
+// profaneWords is the built-in profanity list, matched against whole words
+var profaneWords = map[string]struct{}{
+	"arse": {}, "arsehole": {}, "ass": {}, "asshole": {}, "assholes": {},
+	"bastard": {}, "bastards": {}, "bitch": {}, "bitches": {}, "bitching": {},
+	"bollocks": {}, "bullshit": {}, "cock": {}, "cocks": {}, "cunt": {}, "cunts": {},
+	"dick": {}, "dickhead": {}, "dicks": {}, "fuck": {}, "fucked": {}, "fucker": {},
+	"fuckers": {}, "fucking": {}, "fucks": {}, "motherfucker": {}, "motherfuckers": {},
+	"prick": {}, "pricks": {}, "shit": {}, "shite": {}, "shits": {}, "shitty": {},
+	"twat": {}, "twats": {}, "wanker": {}, "wankers": {},
+}
+
+// MaskProfanity replaces every profane word in text with asterisks, checking the
+// built-in list and the extra words, and reports whether any were found
+func MaskProfanity(text string, extraWords []string) (string, bool) {
+	found := false
+	masked := profanityWordPattern.ReplaceAllStringFunc(text, func(word string) string {
+		lower := strings.ToLower(word)
+		if _, ok := profaneWords[lower]; !ok && !containsFold(extraWords, lower) {
+			return word
+		}
+		found = true
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	})
+	return masked, found
+}
+
+// containsFold reports whether words holds word, ignoring case
+func containsFold(words []string, word string) bool {
+	for _, w := range words {
+		if strings.EqualFold(w, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// ModerationStatus returns where the review is in moderation
+func (r *Review) ModerationStatus() ReviewModerationStatus {
+	switch {
+	case r.IsPublished:
+		return ReviewModerationPublished
+	case r.ModeratedAt != nil:
+		return ReviewModerationRejected
+	}
+	return ReviewModerationPending
+}
+
+// ApplyModeration masks profanity in the review's text and publishes it or holds it
+// for a moderator under the policy, replacing any earlier moderator decision. Tenants
+// requiring approval hold every review.
+func (r *Review) ApplyModeration(policy ReviewModerationPolicy, requireApproval bool) {
+	title, titleProfane := MaskProfanity(r.Title, policy.BlockedWords)
+	comment, commentProfane := MaskProfanity(r.Comment, policy.BlockedWords)
+	r.Title, r.Comment = title, comment
+	r.ContainsProfanity = titleProfane || commentProfane
+
+	r.HoldReason = policy.HoldReason(r)
+	if requireApproval {
+		r.HoldReason = ReviewHoldApprovalRequired
+	}
+	r.IsPublished = r.HoldReason == ""
+	r.RejectionReason = ""
+	r.ModeratedAt = nil
+	r.ModeratedBy = nil
+}
+
+// Approve publishes a held or rejected review
+func (r *Review) Approve(moderatorID uuid.UUID, now time.Time) error {
+	if r.IsPublished {
+		return errors.New("review is already published")
+	}
+	r.IsPublished = true
+	r.HoldReason = ""
+	r.RejectionReason = ""
+	r.ModeratedAt = &now
+	r.ModeratedBy = &moderatorID
+	return nil
+}
+
+// Reject hides a held or published review, recording why
+func (r *Review) Reject(moderatorID uuid.UUID, reason string, now time.Time) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("a rejection reason is required")
+	}
+	if r.ModerationStatus() == ReviewModerationRejected {
+		return errors.New("review is already rejected")
+	}
+	r.IsPublished = false
+	r.HoldReason = ""
+	r.RejectionReason = reason
+	r.ModeratedAt = &now
+	r.ModeratedBy = &moderatorID
+	return nil
+}
+
+// ReviewReplyAuthorRole is who wrote a reply in a review's thread
+type ReviewReplyAuthorRole string
+
+const (
+	ReviewReplyAuthorArtisan  ReviewReplyAuthorRole = "artisan"  // The reviewed artisan
+	ReviewReplyAuthorCustomer ReviewReplyAuthorRole = "customer" // The reviewer
+)
+
+// ReviewReply is a message in the thread under a review. The reviewed artisan opens
+// the thread; the reviewer and the artisan can then reply to each other.
+type ReviewReply struct {
+	BaseModel
+
+	TenantID   uuid.UUID             `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ReviewID   uuid.UUID             `json:"review_id" gorm:"type:uuid;not null;index"`
+	AuthorID   uuid.UUID             `json:"author_id" gorm:"type:uuid;not null"`
+	AuthorRole ReviewReplyAuthorRole `json:"author_role" gorm:"type:varchar(20);not null"`
+
+	// Body with profanity masked
+	Body              string `json:"body" gorm:"type:text;not null"`
+	ContainsProfanity bool   `json:"contains_profanity" gorm:"default:false"`
+
+	// Relationships
+	Author *User `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+}
+
+// TableName specifies the table name for ReviewReply
+func (ReviewReply) TableName() string {
+	return "review_replies"
+}
+
+// ReviewFlagKind is what a flag on a review asks moderators to look at
+type ReviewFlagKind string
+
+const (
+	ReviewFlagKindReport  ReviewFlagKind = "report"  // Someone reports the review as inappropriate
+	ReviewFlagKindDispute ReviewFlagKind = "dispute" // The reviewed artisan contests the review
+)
+
+// IsValid checks if the kind is one of the supported flag kinds
+func (k ReviewFlagKind) IsValid() bool {
+	return k == ReviewFlagKindReport || k == ReviewFlagKindDispute
+}
+
+// ReviewFlagStatus is where a flag is in moderation
+type ReviewFlagStatus string
+
+const (
+	ReviewFlagStatusOpen      ReviewFlagStatus = "open"
+	ReviewFlagStatusUpheld    ReviewFlagStatus = "upheld"    // The review was rejected
+	ReviewFlagStatusDismissed ReviewFlagStatus = "dismissed" // The review stands
+)
+
+// IsValid checks if the status is one of the supported statuses
+func (s ReviewFlagStatus) IsValid() bool {
+	switch s {
+	case ReviewFlagStatusOpen, ReviewFlagStatusUpheld, ReviewFlagStatusDismissed:
+		return true
+	}
+	return false
+}
+
+// ReviewFlag is a report or dispute raised against a review for tenant owners and
+// admins to resolve. A review is flagged while any of its flags is open.
+type ReviewFlag struct {
+	BaseModel
+
+	TenantID   uuid.UUID      `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ReviewID   uuid.UUID      `json:"review_id" gorm:"type:uuid;not null;index"`
+	ReporterID uuid.UUID      `json:"reporter_id" gorm:"type:uuid;not null"`
+	Kind       ReviewFlagKind `json:"kind" gorm:"type:varchar(20);not null"`
+	Reason     string         `json:"reason" gorm:"type:text;not null"`
+
+	// Resolution
+	Status       ReviewFlagStatus `json:"status" gorm:"type:varchar(20);not null;default:'open';index"`
+	ResolvedByID *uuid.UUID       `json:"resolved_by_id,omitempty" gorm:"type:uuid"`
+	ResolvedAt   *time.Time       `json:"resolved_at,omitempty"`
+	Resolution   string           `json:"resolution,omitempty" gorm:"type:text"`
+
+	// Relationships
+	Review *Review `json:"review,omitempty" gorm:"foreignKey:ReviewID"`
+}
+
+// TableName specifies the table name for ReviewFlag
+func (ReviewFlag) TableName() string {
+	return "review_flags"
+}
+
+// Resolve closes an open flag, upholding it when the review is to be rejected
+func (f *ReviewFlag) Resolve(moderatorID uuid.UUID, uphold bool, note string, now time.Time) error {
+	if f.Status != ReviewFlagStatusOpen {
+		return errors.New("only open flags can be resolved")
+	}
+	f.Status = ReviewFlagStatusDismissed
+	if uphold {
+		f.Status = ReviewFlagStatusUpheld
+	}
+	f.ResolvedByID = &moderatorID
+	f.ResolvedAt = &now
+	f.Resolution = strings.TrimSpace(note)
+	return nil
+}
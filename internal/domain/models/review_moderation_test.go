@@ -0,0 +1,112 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskProfanity(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		extra   []string
+		want    string
+		profane bool
+	}{
+		{"clean", "Great work, would book again", nil, "Great work, would book again", false},
+		{"masks whole words in any case", "What a SHIT job, fucking late", nil, "What a **** job, ******* late", true},
+		{"leaves words containing profanity", "Classic assessment of the cocktail bar", nil, "Classic assessment of the cocktail bar", false},
+		{"tenant words", "Total cowboy builder", []string{"cowboy"}, "Total ****** builder", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			masked, profane := models.MaskProfanity(tt.text, tt.extra)
+			assert.Equal(t, tt.want, masked)
+			assert.Equal(t, tt.profane, profane)
+		})
+	}
+}
+
+func TestReviewModerationPolicy(t *testing.T) {
+	t.Run("validate normalizes blocked words", func(t *testing.T) {
+		policy := models.DefaultReviewModerationPolicy()
+		policy.BlockedWords = []string{" Cowboy ", ""}
+		require.NoError(t, policy.Validate())
+		assert.Equal(t, []string{"cowboy"}, policy.BlockedWords)
+
+		policy.BlockedWords = []string{"two words"}
+		assert.Error(t, policy.Validate())
+
+		policy = models.ReviewModerationPolicy{AutoPublishMinRating: 0}
+		assert.Error(t, policy.Validate())
+	})
+
+	t.Run("apply moderation", func(t *testing.T) {
+		lenient := models.DefaultReviewModerationPolicy()
+		strict := models.ReviewModerationPolicy{AutoPublishMinRating: 4, HoldProfanity: true, HoldWithPhotos: true}
+
+		tests := []struct {
+			name            string
+			review          models.Review
+			policy          models.ReviewModerationPolicy
+			requireApproval bool
+			want            models.ReviewHoldReason
+		}{
+			{"publishes by default", models.Review{Rating: 2, Comment: "Late and messy"}, lenient, false, ""},
+			{"holds profanity", models.Review{Rating: 5, Comment: "Bloody shit hot work"}, lenient, false, models.ReviewHoldProfanity},
+			{"holds low ratings", models.Review{Rating: 3}, strict, false, models.ReviewHoldLowRating},
+			{"holds photos", models.Review{Rating: 5, PhotoURLs: []string{"https://cdn.example.com/1.jpg"}}, strict, false, models.ReviewHoldPhotos},
+			{"tenant approves everything", models.Review{Rating: 5}, lenient, true, models.ReviewHoldApprovalRequired},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				review := tt.review
+				review.ApplyModeration(tt.policy, tt.requireApproval)
+				assert.Equal(t, tt.want, review.HoldReason)
+				assert.Equal(t, tt.want == "", review.IsPublished)
+				assert.NotContains(t, review.Comment, "shit")
+			})
+		}
+	})
+}
+
+func TestReviewModerationDecisions(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	moderatorID := uuid.New()
+
+	review := &models.Review{Rating: 1}
+	review.ApplyModeration(models.ReviewModerationPolicy{AutoPublishMinRating: 3}, false)
+	assert.Equal(t, models.ReviewModerationPending, review.ModerationStatus())
+
+	assert.Error(t, review.Reject(moderatorID, " ", now), "rejection needs a reason")
+	require.NoError(t, review.Reject(moderatorID, "Names a competitor", now))
+	assert.Equal(t, models.ReviewModerationRejected, review.ModerationStatus())
+	assert.Error(t, review.Reject(moderatorID, "Again", now))
+
+	require.NoError(t, review.Approve(moderatorID, now))
+	assert.Equal(t, models.ReviewModerationPublished, review.ModerationStatus())
+	assert.Empty(t, review.RejectionReason)
+	assert.Error(t, review.Approve(moderatorID, now), "already published")
+}
+
+func TestReviewFlagResolve(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	moderatorID := uuid.New()
+
+	flag := &models.ReviewFlag{Kind: models.ReviewFlagKindDispute, Status: models.ReviewFlagStatusOpen}
+	require.NoError(t, flag.Resolve(moderatorID, false, " Review matches the booking ", now))
+	assert.Equal(t, models.ReviewFlagStatusDismissed, flag.Status)
+	assert.Equal(t, "Review matches the booking", flag.Resolution)
+	assert.Equal(t, moderatorID, *flag.ResolvedByID)
+	assert.Error(t, flag.Resolve(moderatorID, true, "", now), "resolved flags cannot be resolved again")
+
+	upheld := &models.ReviewFlag{Kind: models.ReviewFlagKindReport, Status: models.ReviewFlagStatusOpen}
+	require.NoError(t, upheld.Resolve(moderatorID, true, "", now))
+	assert.Equal(t, models.ReviewFlagStatusUpheld, upheld.Status)
+}
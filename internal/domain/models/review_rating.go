@@ -46,6 +46,12 @@ type Review struct {
 	ModeratedAt   *time.Time `json:"moderated_at,omitempty"`
 	ModeratedBy   *uuid.UUID `json:"moderated_by,omitempty"`
 
+	// Automatic moderation (see ReviewModerationPolicy): why a review waits for a
+	// moderator, why a moderator rejected it, and whether profanity was masked
+	HoldReason        ReviewHoldReason `json:"hold_reason,omitempty" gorm:"type:varchar(30)"`
+	RejectionReason   string           `json:"rejection_reason,omitempty" gorm:"type:text"`
+	ContainsProfanity bool             `json:"contains_profanity" gorm:"default:false"`
+
 	// Helpful Votes
 	HelpfulCount    int `json:"helpful_count" gorm:"default:0"`
 	NotHelpfulCount int `json:"not_helpful_count" gorm:"default:0"`
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedFilterResource is the list a saved filter applies to
+type SavedFilterResource string
+
+const (
+	SavedFilterResourceBookings SavedFilterResource = "bookings"
+)
+
+// SavedFilterDateRange is a date window resolved when the filter is used,
+// so a view like "this week" keeps moving with the calendar
+type SavedFilterDateRange string
+
+const (
+	SavedFilterDateRangeToday      SavedFilterDateRange = "today"
+	SavedFilterDateRangeTomorrow   SavedFilterDateRange = "tomorrow"
+	SavedFilterDateRangeThisWeek   SavedFilterDateRange = "this_week"
+	SavedFilterDateRangeNextWeek   SavedFilterDateRange = "next_week"
+	SavedFilterDateRangeThisMonth  SavedFilterDateRange = "this_month"
+	SavedFilterDateRangeNext7Days  SavedFilterDateRange = "next_7_days"
+	SavedFilterDateRangeLast30Days SavedFilterDateRange = "last_30_days"
+)
+
+// SavedFilter is a named list view a user can come back to
+type SavedFilter struct {
+	BaseModel
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index:idx_saved_filter_owner"`
+	UserID   uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index:idx_saved_filter_owner"`
+
+	Name     string              `json:"name" gorm:"size:100;not null" validate:"required,max=100"`
+	Resource SavedFilterResource `json:"resource" gorm:"type:varchar(50);not null;default:'bookings';index:idx_saved_filter_owner"`
+
+	// Criteria holds the stored filter fields, e.g. statuses, tags and artisan IDs
+	Criteria JSONB `json:"criteria" gorm:"type:jsonb"`
+
+	// Relative date window applied on top of Criteria, in TimeZone (UTC when empty)
+	DateRange SavedFilterDateRange `json:"date_range,omitempty" gorm:"type:varchar(20)"`
+	TimeZone  string               `json:"time_zone,omitempty" gorm:"size:64"`
+}
+
+// IsValid reports whether the date range is a known preset
+func (r SavedFilterDateRange) IsValid() bool {
+	switch r {
+	case SavedFilterDateRangeToday, SavedFilterDateRangeTomorrow, SavedFilterDateRangeThisWeek,
+		SavedFilterDateRangeNextWeek, SavedFilterDateRangeThisMonth, SavedFilterDateRangeNext7Days,
+		SavedFilterDateRangeLast30Days:
+		return true
+	}
+	return false
+}
+
+// Bounds resolves the range against now in loc. Weeks start on Monday; the end is exclusive.
+func (r SavedFilterDateRange) Bounds(now time.Time, loc *time.Location) (from, to time.Time, ok bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+
+	switch r {
+	case SavedFilterDateRangeToday:
+		return today, today.AddDate(0, 0, 1), true
+	case SavedFilterDateRangeTomorrow:
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), true
+	case SavedFilterDateRangeThisWeek:
+		return weekStart, weekStart.AddDate(0, 0, 7), true
+	case SavedFilterDateRangeNextWeek:
+		return weekStart.AddDate(0, 0, 7), weekStart.AddDate(0, 0, 14), true
+	case SavedFilterDateRangeThisMonth:
+		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+		return monthStart, monthStart.AddDate(0, 1, 0), true
+	case SavedFilterDateRangeNext7Days:
+		return now, now.AddDate(0, 0, 7), true
+	case SavedFilterDateRangeLast30Days:
+		return now.AddDate(0, 0, -30), now, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// Location returns the filter's time zone, falling back to UTC when unset or unknown
+func (f *SavedFilter) Location() *time.Location {
+	if f.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(f.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,70 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeBookingTags(t *testing.T) {
+	tags, err := models.NormalizeBookingTags([]string{" VIP ", "vip", "", "Repeat Client"})
+	require.NoError(t, err)
+	assert.Equal(t, models.StringArray{"vip", "repeat client"}, tags)
+
+	_, err = models.NormalizeBookingTags([]string{strings.Repeat("x", models.MaxBookingTagLength+1)})
+	assert.Error(t, err)
+
+	tooMany := make([]string, models.MaxBookingTags+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("t", i+1)
+	}
+	_, err = models.NormalizeBookingTags(tooMany)
+	assert.Error(t, err)
+}
+
+func TestSavedFilterDateRangeBounds(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, 6, 11, 15, 30, 0, 0, time.UTC)
+
+	from, to, ok := models.SavedFilterDateRangeThisWeek.Bounds(now, time.UTC)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), to)
+
+	from, to, ok = models.SavedFilterDateRangeToday.Bounds(now, time.UTC)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC), to)
+
+	from, to, ok = models.SavedFilterDateRangeThisMonth.Bounds(now, time.UTC)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), to)
+
+	// Sunday belongs to the week that started the previous Monday
+	sunday := time.Date(2025, 6, 15, 8, 0, 0, 0, time.UTC)
+	from, _, _ = models.SavedFilterDateRangeThisWeek.Bounds(sunday, time.UTC)
+	assert.Equal(t, time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), from)
+
+	_, _, ok = models.SavedFilterDateRange("someday").Bounds(now, time.UTC)
+	assert.False(t, ok)
+}
+
+func TestSavedFilterDateRangeBoundsUsesTimeZone(t *testing.T) {
+	filter := &models.SavedFilter{TimeZone: "America/New_York"}
+	loc := filter.Location()
+	require.NotEqual(t, time.UTC, loc)
+
+	// 02:00 UTC is still the previous evening in New York
+	now := time.Date(2025, 6, 11, 2, 0, 0, 0, time.UTC)
+	from, _, ok := models.SavedFilterDateRangeToday.Bounds(now, loc)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 6, 10, 0, 0, 0, 0, loc), from)
+
+	assert.Equal(t, time.UTC, (&models.SavedFilter{TimeZone: "Nowhere/Special"}).Location())
+}
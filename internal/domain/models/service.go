@@ -1,6 +1,12 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -70,6 +76,24 @@ const (
 	ServiceCategoryOther        ServiceCategory = "other"
 )
 
+// ServiceStatus is where a service is in the catalog's publishing workflow
+type ServiceStatus string
+
+const (
+	ServiceStatusDraft     ServiceStatus = "draft"     // Being prepared; hidden from customers and not bookable
+	ServiceStatusPublished ServiceStatus = "published" // Listed and bookable
+	ServiceStatusArchived  ServiceStatus = "archived"  // Withdrawn; kept for the bookings that reference it
+)
+
+// IsValid checks if the service status is valid
+func (s ServiceStatus) IsValid() bool {
+	switch s {
+	case ServiceStatusDraft, ServiceStatusPublished, ServiceStatusArchived:
+		return true
+	}
+	return false
+}
+
 type Service struct {
 	BaseModel
 
@@ -83,26 +107,54 @@ type Service struct {
 	Category    ServiceCategory `json:"category" gorm:"type:varchar(50);not null;index:idx_service_tenant_category" validate:"required"`
 
 	// Pricing
-	Price         float64 `json:"price" gorm:"type:decimal(10,2);not null" validate:"required,min=0"`
-	Currency      string  `json:"currency" gorm:"size:3;default:'USD'" validate:"required,len=3"`
-	DepositAmount float64 `json:"deposit_amount,omitempty" gorm:"type:decimal(10,2);default:0"`
+	Price         float64     `json:"price" gorm:"type:decimal(10,2);not null" validate:"required,min=0"`
+	Currency      string      `json:"currency" gorm:"size:3;default:'USD'" validate:"required,len=3"`
+	DepositAmount float64     `json:"deposit_amount,omitempty" gorm:"type:decimal(10,2);default:0"`
+	TaxCategory   TaxCategory `json:"tax_category" gorm:"type:varchar(50);default:'standard'"` // Selects the tenant's tax rule
 
 	// Duration
 	DurationMinutes int `json:"duration_minutes" gorm:"not null" validate:"required,min=5"`
 	BufferMinutes   int `json:"buffer_minutes" gorm:"default:0" validate:"min=0"` // Cleanup time
 
+	// Publishing: a draft service, or the pending changes of a published one, go live at
+	// PublishAt when it is set
+	Status         ServiceStatus   `json:"status" gorm:"type:varchar(20);not null;default:'published';index"`
+	PublishAt      *time.Time      `json:"publish_at,omitempty" gorm:"index"`
+	PublishedAt    *time.Time      `json:"published_at,omitempty"`
+	PendingChanges *ServiceChanges `json:"pending_changes,omitempty" gorm:"type:jsonb"`
+
+	// Price version in effect, recorded whenever the published price changes
+	PriceVersionID *uuid.UUID `json:"price_version_id,omitempty" gorm:"type:uuid"`
+
 	// Availability
 	IsActive       bool   `json:"is_active" gorm:"default:true"`
 	MaxBookingsDay int    `json:"max_bookings_day" gorm:"default:0"` // 0 = unlimited
 	ImageURL       string `json:"image_url,omitempty" gorm:"size:500"`
 
+	// Mobile services are performed at the customer's location, which must lie within
+	// the artisan's service areas
+	IsMobile bool `json:"is_mobile" gorm:"default:false"`
+
+	// Ratings & Reviews, recalculated from the published reviews whenever one changes
+	Rating      float64 `json:"rating" gorm:"type:decimal(3,2);default:0"`
+	ReviewCount int     `json:"review_count" gorm:"default:0"`
+
+	// Customers who favorited the service, kept as favorites are added and removed
+	FavoriteCount int `json:"favorite_count" gorm:"default:0"`
+
 	// Requirements
 	RequiresDeposit bool     `json:"requires_deposit" gorm:"default:false"`
 	Tags            []string `json:"tags,omitempty" gorm:"type:text[]"`
 
+	// Pre-appointment questionnaire answered when booking (nil = none)
+	IntakeForm *IntakeForm `json:"intake_form,omitempty" gorm:"type:jsonb"`
+
 	// Metadata
 	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb"`
 
+	// Full-text search document of the name and description, maintained by Postgres
+	SearchVector string `json:"-" gorm:"->:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(name, '')), 'A') || setweight(to_tsvector('simple', coalesce(description, '')), 'B')) STORED;index:idx_service_search,type:gin"`
+
 	// Relationships
 	Tenant   *Tenant        `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
 	Artisan  *User          `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
@@ -121,3 +173,117 @@ func (s *Service) GetDepositPercentage() float64 {
 	}
 	return (s.DepositAmount / s.Price) * 100
 }
+
+// IsBookable reports whether customers can book the service
+func (s *Service) IsBookable() bool {
+	return s.Status != ServiceStatusDraft && s.Status != ServiceStatusArchived
+}
+
+// ServiceChanges are edits to a published service held back until they are published;
+// nil fields are left unchanged
+type ServiceChanges struct {
+	Name            *string          `json:"name,omitempty"`
+	Description     *string          `json:"description,omitempty"`
+	Category        *ServiceCategory `json:"category,omitempty"`
+	Price           *float64         `json:"price,omitempty"`
+	Currency        *string          `json:"currency,omitempty"`
+	DepositAmount   *float64         `json:"deposit_amount,omitempty"`
+	RequiresDeposit *bool            `json:"requires_deposit,omitempty"`
+	TaxCategory     *TaxCategory     `json:"tax_category,omitempty"`
+	DurationMinutes *int             `json:"duration_minutes,omitempty"`
+	BufferMinutes   *int             `json:"buffer_minutes,omitempty"`
+	ImageURL        *string          `json:"image_url,omitempty"`
+	Tags            []string         `json:"tags,omitempty"`
+}
+
+// Validate checks the changes are within the bounds a service allows
+func (c *ServiceChanges) Validate() error {
+	if c.Name != nil && (len(*c.Name) < 2 || len(*c.Name) > 255) {
+		return errors.New("name must be between 2 and 255 characters")
+	}
+	if c.Price != nil && *c.Price < 0 {
+		return errors.New("price cannot be negative")
+	}
+	if c.Currency != nil && len(*c.Currency) != 3 {
+		return errors.New("currency must be a 3-letter code")
+	}
+	if c.DepositAmount != nil && *c.DepositAmount < 0 {
+		return errors.New("deposit amount cannot be negative")
+	}
+	if c.TaxCategory != nil && !c.TaxCategory.IsValid() {
+		return errors.New("invalid tax category")
+	}
+	if c.DurationMinutes != nil && *c.DurationMinutes < 5 {
+		return errors.New("duration must be at least 5 minutes")
+	}
+	if c.BufferMinutes != nil && *c.BufferMinutes < 0 {
+		return errors.New("buffer cannot be negative")
+	}
+	return nil
+}
+
+// Apply makes the changes to the service, returning the JSON names of the fields whose
+// values changed
+func (c *ServiceChanges) Apply(s *Service) []string {
+	var changed []string
+	set := func(name string, differs bool, apply func()) {
+		if differs {
+			apply()
+			changed = append(changed, name)
+		}
+	}
+	if c.Name != nil {
+		set("name", *c.Name != s.Name, func() { s.Name = *c.Name })
+	}
+	if c.Description != nil {
+		set("description", *c.Description != s.Description, func() { s.Description = *c.Description })
+	}
+	if c.Category != nil {
+		set("category", *c.Category != s.Category, func() { s.Category = *c.Category })
+	}
+	if c.Price != nil {
+		set("price", *c.Price != s.Price, func() { s.Price = *c.Price })
+	}
+	if c.Currency != nil {
+		set("currency", *c.Currency != s.Currency, func() { s.Currency = *c.Currency })
+	}
+	if c.DepositAmount != nil {
+		set("deposit_amount", *c.DepositAmount != s.DepositAmount, func() { s.DepositAmount = *c.DepositAmount })
+	}
+	if c.RequiresDeposit != nil {
+		set("requires_deposit", *c.RequiresDeposit != s.RequiresDeposit, func() { s.RequiresDeposit = *c.RequiresDeposit })
+	}
+	if c.TaxCategory != nil {
+		set("tax_category", *c.TaxCategory != s.TaxCategory, func() { s.TaxCategory = *c.TaxCategory })
+	}
+	if c.DurationMinutes != nil {
+		set("duration_minutes", *c.DurationMinutes != s.DurationMinutes, func() { s.DurationMinutes = *c.DurationMinutes })
+	}
+	if c.BufferMinutes != nil {
+		set("buffer_minutes", *c.BufferMinutes != s.BufferMinutes, func() { s.BufferMinutes = *c.BufferMinutes })
+	}
+	if c.ImageURL != nil {
+		set("image_url", *c.ImageURL != s.ImageURL, func() { s.ImageURL = *c.ImageURL })
+	}
+	if c.Tags != nil {
+		set("tags", !slices.Equal(c.Tags, s.Tags), func() { s.Tags = c.Tags })
+	}
+	return changed
+}
+
+func (c *ServiceChanges) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+func (c ServiceChanges) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
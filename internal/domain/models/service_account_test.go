@@ -0,0 +1,37 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceAccount_CanAccess(t *testing.T) {
+	account := &models.ServiceAccount{Scopes: models.StringArray{"bookings.read", "payments.refund"}}
+
+	// Read-only scopes reach reads of their resource only
+	assert.True(t, account.CanAccess("bookings", false))
+	assert.False(t, account.CanAccess("bookings", true))
+
+	// Any other scope reaches writes, the exact action being authorized by the service
+	assert.True(t, account.CanAccess("payments", true))
+	assert.False(t, account.CanAccess("payments", false))
+
+	assert.False(t, account.CanAccess("projects", false))
+	assert.False(t, account.CanAccess("", false))
+	assert.False(t, account.CanAccess("bookings_archive", false), "resources are matched whole")
+}
+
+func TestServiceAccount_Validate(t *testing.T) {
+	account := &models.ServiceAccount{Name: "Zapier", Scopes: models.StringArray{"bookings.read"}}
+	assert.NoError(t, account.Validate())
+
+	account.Scopes = nil
+	assert.Error(t, account.Validate())
+
+	account.Scopes = models.StringArray{"bookings.read"}
+	account.Name = "  "
+	assert.Error(t, account.Validate())
+}
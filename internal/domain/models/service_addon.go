@@ -1,6 +1,20 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type AddonPricingType string
+
+const (
+	AddonPricingFixed      AddonPricingType = "fixed"      // Price is charged per unit
+	AddonPricingPercentage AddonPricingType = "percentage" // Price is a percentage of the service price, per unit
+)
 
 type ServiceAddon struct {
 	BaseModel
@@ -9,9 +23,99 @@ type ServiceAddon struct {
 	Name            string  `json:"name" gorm:"not null;size:255" validate:"required"`
 	Description     string  `json:"description,omitempty" gorm:"type:text"`
 	Price           float64 `json:"price" gorm:"type:decimal(10,2);not null" validate:"required,min=0"`
-	DurationMinutes int     `json:"duration_minutes" gorm:"default:0"`
+	DurationMinutes int     `json:"duration_minutes" gorm:"default:0"` // Added to the booking per unit
 	IsActive        bool    `json:"is_active" gorm:"default:true"`
 
+	// Pricing Rules
+	PricingType AddonPricingType `json:"pricing_type" gorm:"type:varchar(20);not null;default:'fixed'"`
+	MaxQuantity int              `json:"max_quantity" gorm:"default:0"` // 0 means unlimited
+
 	// Relationships
 	Services []Service `json:"services,omitempty" gorm:"many2many:service_addon_relations"`
 }
+
+// BookingAddon is an addon line on a booking, priced when the booking was made
+type BookingAddon struct {
+	AddonID         uuid.UUID        `json:"addon_id"`
+	Name            string           `json:"name"`
+	Quantity        int              `json:"quantity"`
+	PricingType     AddonPricingType `json:"pricing_type"`
+	UnitPrice       float64          `json:"unit_price"`
+	Price           float64          `json:"price"`            // UnitPrice x Quantity
+	DurationMinutes int              `json:"duration_minutes"` // Total extension for all units
+}
+
+// BookingAddons is a custom type for handling []BookingAddon in JSONB
+type BookingAddons []BookingAddon
+
+// Quote prices quantity units of the addon for a service costing basePrice
+func (a *ServiceAddon) Quote(quantity int, basePrice float64) (BookingAddon, error) {
+	if !a.IsActive {
+		return BookingAddon{}, fmt.Errorf("addon %q is not available", a.Name)
+	}
+	if quantity < 1 {
+		return BookingAddon{}, fmt.Errorf("addon %q quantity must be at least 1", a.Name)
+	}
+	if a.MaxQuantity > 0 && quantity > a.MaxQuantity {
+		return BookingAddon{}, fmt.Errorf("addon %q allows at most %d", a.Name, a.MaxQuantity)
+	}
+
+	unitPrice := a.Price
+	if a.PricingType == AddonPricingPercentage {
+		unitPrice = roundMoney(basePrice * a.Price / 100)
+	}
+
+	pricingType := a.PricingType
+	if pricingType == "" {
+		pricingType = AddonPricingFixed
+	}
+
+	return BookingAddon{
+		AddonID:         a.ID,
+		Name:            a.Name,
+		Quantity:        quantity,
+		PricingType:     pricingType,
+		UnitPrice:       unitPrice,
+		Price:           roundMoney(unitPrice * float64(quantity)),
+		DurationMinutes: a.DurationMinutes * quantity,
+	}, nil
+}
+
+// Totals returns the combined price and duration extension of the addons
+func (b BookingAddons) Totals() (price float64, minutes int) {
+	for _, addon := range b {
+		price += addon.Price
+		minutes += addon.DurationMinutes
+	}
+	return roundMoney(price), minutes
+}
+
+// IDs returns the addon IDs in order
+func (b BookingAddons) IDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(b))
+	for i, addon := range b {
+		ids[i] = addon.AddonID
+	}
+	return ids
+}
+
+func (b *BookingAddons) Scan(value interface{}) error {
+	if value == nil {
+		*b = BookingAddons{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, b)
+}
+
+func (b BookingAddons) Value() (driver.Value, error) {
+	if b == nil {
+		return json.Marshal([]BookingAddon{})
+	}
+	return json.Marshal(b)
+}
@@ -0,0 +1,56 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceAddonQuote(t *testing.T) {
+	fixed := &models.ServiceAddon{Name: "Beard trim", Price: 7.5, DurationMinutes: 10, IsActive: true}
+	line, err := fixed.Quote(2, 40)
+	require.NoError(t, err)
+	assert.Equal(t, models.AddonPricingFixed, line.PricingType)
+	assert.Equal(t, 7.5, line.UnitPrice)
+	assert.Equal(t, 15.0, line.Price)
+	assert.Equal(t, 20, line.DurationMinutes)
+
+	percentage := &models.ServiceAddon{Name: "Express", Price: 12.5, PricingType: models.AddonPricingPercentage, IsActive: true}
+	line, err = percentage.Quote(1, 45)
+	require.NoError(t, err)
+	assert.Equal(t, 5.63, line.UnitPrice)
+	assert.Equal(t, 5.63, line.Price)
+	assert.Zero(t, line.DurationMinutes)
+}
+
+func TestServiceAddonQuoteRejectsInvalidSelections(t *testing.T) {
+	addon := &models.ServiceAddon{Name: "Nail art", Price: 5, MaxQuantity: 3, IsActive: true}
+
+	_, err := addon.Quote(0, 20)
+	assert.Error(t, err)
+
+	_, err = addon.Quote(4, 20)
+	assert.Error(t, err)
+
+	addon.IsActive = false
+	_, err = addon.Quote(1, 20)
+	assert.Error(t, err)
+}
+
+func TestBookingAddonsTotals(t *testing.T) {
+	addons := models.BookingAddons{
+		{Price: 15, DurationMinutes: 20},
+		{Price: 5.63},
+	}
+
+	price, minutes := addons.Totals()
+	assert.Equal(t, 20.63, price)
+	assert.Equal(t, 20, minutes)
+
+	price, minutes = models.BookingAddons(nil).Totals()
+	assert.Zero(t, price)
+	assert.Zero(t, minutes)
+}
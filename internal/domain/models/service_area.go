@@ -0,0 +1,93 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// maxServiceAreaVertices caps the positions of a service area's rings
+const maxServiceAreaVertices = 1000
+
+// ServiceArea is a region an artisan travels to for mobile services. While an artisan
+// has active service areas, customers outside all of them can't book the artisan's
+// mobile services.
+type ServiceArea struct {
+	BaseModel
+
+	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ArtisanID uuid.UUID `json:"artisan_id" gorm:"type:uuid;not null;index"`
+
+	Name     string     `json:"name" gorm:"size:100;not null"`
+	Boundary GeoPolygon `json:"boundary" gorm:"type:jsonb;not null"`
+	IsActive bool       `json:"is_active" gorm:"not null"`
+
+	// PostGIS polygon of the boundary for containment checks, maintained by Postgres
+	Area string `json:"-" gorm:"->:false;type:geography(Polygon,4326) GENERATED ALWAYS AS (ST_GeomFromGeoJSON(boundary)::geography) STORED;index:idx_service_area_area,type:gist"`
+}
+
+// TableName specifies the table name for ServiceArea
+func (ServiceArea) TableName() string {
+	return "service_areas"
+}
+
+// GeoPolygon is a GeoJSON polygon: an outer ring of [longitude, latitude] positions
+// followed by the rings of any holes, each ring ending where it starts
+type GeoPolygon struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// NewGeoPolygon creates a polygon without holes from its outer ring, closing the ring
+// when it doesn't end where it starts
+func NewGeoPolygon(ring ...[2]float64) GeoPolygon {
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		ring = append(ring, ring[0])
+	}
+	return GeoPolygon{Type: "Polygon", Coordinates: [][][2]float64{ring}}
+}
+
+// Validate checks the polygon is a GeoJSON polygon PostGIS can store
+func (p GeoPolygon) Validate() error {
+	if p.Type != "Polygon" {
+		return errors.New("boundary must be a GeoJSON Polygon")
+	}
+	if len(p.Coordinates) == 0 {
+		return errors.New("boundary must have an outer ring")
+	}
+
+	vertices := 0
+	for i, ring := range p.Coordinates {
+		if len(ring) < 4 {
+			return fmt.Errorf("ring %d must have at least 4 positions", i)
+		}
+		if ring[0] != ring[len(ring)-1] {
+			return fmt.Errorf("ring %d must end where it starts", i)
+		}
+		for _, position := range ring {
+			if position[0] < -180 || position[0] > 180 || position[1] < -90 || position[1] > 90 {
+				return fmt.Errorf("ring %d has a position outside longitude -180..180 or latitude -90..90", i)
+			}
+		}
+		vertices += len(ring)
+	}
+	if vertices > maxServiceAreaVertices {
+		return fmt.Errorf("boundary cannot have more than %d positions", maxServiceAreaVertices)
+	}
+	return nil
+}
+
+func (p *GeoPolygon) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+func (p GeoPolygon) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
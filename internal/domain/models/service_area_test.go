@@ -0,0 +1,42 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoPolygon_Validate(t *testing.T) {
+	square := models.NewGeoPolygon([2]float64{-0.2, 5.5}, [2]float64{-0.1, 5.5}, [2]float64{-0.1, 5.6}, [2]float64{-0.2, 5.6})
+	assert.NoError(t, square.Validate())
+	assert.Len(t, square.Coordinates[0], 5, "ring is closed")
+
+	tests := []struct {
+		name    string
+		polygon models.GeoPolygon
+		wantErr string
+	}{
+		{"wrong type", models.GeoPolygon{Type: "Point", Coordinates: square.Coordinates}, "GeoJSON Polygon"},
+		{"no rings", models.GeoPolygon{Type: "Polygon"}, "outer ring"},
+		{"too few positions", models.NewGeoPolygon([2]float64{0, 1}, [2]float64{1, 1}), "at least 4 positions"},
+		{"open ring", models.GeoPolygon{Type: "Polygon", Coordinates: [][][2]float64{{{0, 1}, {1, 1}, {1, 2}, {0, 2}}}}, "end where it starts"},
+		{"out of range", models.NewGeoPolygon([2]float64{0, 1}, [2]float64{1, 91}, [2]float64{2, 1}), "outside longitude"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.polygon.Validate()
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLocation_HasCoordinates(t *testing.T) {
+	var unset *models.Location
+	assert.False(t, unset.HasCoordinates())
+	assert.False(t, (&models.Location{City: "Accra"}).HasCoordinates())
+	assert.True(t, (&models.Location{Latitude: 5.6, Longitude: -0.19}).HasCoordinates())
+}
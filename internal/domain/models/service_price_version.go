@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServicePriceVersion is a service's price as published over a period. Versions are
+// never changed once superseded, so bookings referencing one keep the price they were
+// made at however the catalog changes later.
+type ServicePriceVersion struct {
+	BaseModel
+	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ServiceID uuid.UUID `json:"service_id" gorm:"type:uuid;not null;uniqueIndex:idx_service_price_version_number"`
+	Number    int       `json:"number" gorm:"not null;uniqueIndex:idx_service_price_version_number"` // 1 for the first published price
+
+	Price         float64     `json:"price" gorm:"type:decimal(10,2);not null"`
+	Currency      string      `json:"currency" gorm:"size:3;not null"`
+	DepositAmount float64     `json:"deposit_amount" gorm:"type:decimal(10,2);default:0"`
+	TaxCategory   TaxCategory `json:"tax_category" gorm:"type:varchar(50);default:'standard'"`
+
+	// Period the price was in effect; EffectiveTo is nil for the current version
+	EffectiveFrom time.Time  `json:"effective_from" gorm:"not null"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+}
+
+// TableName specifies the table name
+func (ServicePriceVersion) TableName() string {
+	return "service_price_versions"
+}
+
+// Matches reports whether the version holds the service's current pricing
+func (v *ServicePriceVersion) Matches(s *Service) bool {
+	return v.Price == s.Price &&
+		v.Currency == s.Currency &&
+		v.DepositAmount == s.DepositAmount &&
+		v.TaxCategory == s.TaxCategory
+}
@@ -0,0 +1,56 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceChangesApply(t *testing.T) {
+	service := &models.Service{Name: "Haircut", Price: 30, Currency: "USD", DurationMinutes: 30, Tags: []string{"hair"}}
+	name, price, duration := "Haircut", 35.0, 45
+	changes := &models.ServiceChanges{Name: &name, Price: &price, DurationMinutes: &duration, Tags: []string{"hair", "men"}}
+
+	changed := changes.Apply(service)
+	assert.Equal(t, []string{"price", "duration_minutes", "tags"}, changed, "unchanged values aren't reported")
+	assert.Equal(t, 35.0, service.Price)
+	assert.Equal(t, 45, service.DurationMinutes)
+	assert.Equal(t, []string{"hair", "men"}, service.Tags)
+	assert.Equal(t, "USD", service.Currency)
+
+	assert.Empty(t, changes.Apply(service))
+}
+
+func TestServiceChangesValidate(t *testing.T) {
+	negative, shortDuration, currency := -1.0, 2, "US"
+	category := models.TaxCategory("luxury")
+	for name, changes := range map[string]models.ServiceChanges{
+		"negative price":   {Price: &negative},
+		"negative deposit": {DepositAmount: &negative},
+		"short duration":   {DurationMinutes: &shortDuration},
+		"invalid currency": {Currency: &currency},
+		"unknown tax":      {TaxCategory: &category},
+	} {
+		assert.Error(t, changes.Validate(), name)
+	}
+
+	price := 10.0
+	assert.NoError(t, (&models.ServiceChanges{Price: &price}).Validate())
+}
+
+func TestServiceIsBookable(t *testing.T) {
+	assert.True(t, (&models.Service{Status: models.ServiceStatusPublished}).IsBookable())
+	assert.False(t, (&models.Service{Status: models.ServiceStatusDraft}).IsBookable())
+	assert.False(t, (&models.Service{Status: models.ServiceStatusArchived}).IsBookable())
+}
+
+func TestServicePriceVersionMatches(t *testing.T) {
+	service := &models.Service{Price: 30, Currency: "USD", DepositAmount: 5, TaxCategory: models.TaxCategoryStandard}
+	version := &models.ServicePriceVersion{Price: 30, Currency: "USD", DepositAmount: 5, TaxCategory: models.TaxCategoryStandard}
+	assert.True(t, version.Matches(service))
+
+	service.DepositAmount = 10
+	assert.False(t, version.Matches(service))
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// SkillProficiency is how well an artisan masters a skill
+type SkillProficiency string
+
+const (
+	SkillProficiencyBeginner     SkillProficiency = "beginner"
+	SkillProficiencyIntermediate SkillProficiency = "intermediate"
+	SkillProficiencyAdvanced     SkillProficiency = "advanced"
+	SkillProficiencyExpert       SkillProficiency = "expert"
+)
+
+// IsValid checks if the proficiency is one of the supported levels
+func (p SkillProficiency) IsValid() bool {
+	switch p {
+	case SkillProficiencyBeginner, SkillProficiencyIntermediate, SkillProficiencyAdvanced, SkillProficiencyExpert:
+		return true
+	}
+	return false
+}
+
+// Weight returns how much the proficiency counts towards matching a skill, from 0 to 1
+func (p SkillProficiency) Weight() float64 {
+	switch p {
+	case SkillProficiencyExpert:
+		return 1
+	case SkillProficiencyAdvanced:
+		return 0.8
+	case SkillProficiencyIntermediate:
+		return 0.6
+	case SkillProficiencyBeginner:
+		return 0.4
+	}
+	return 0
+}
+
+const (
+	// MaxSkillKeywords is the number of keywords a skill can be matched by
+	MaxSkillKeywords = 20
+	// MaxArtisanSkills is the number of skills an artisan can be tagged with
+	MaxArtisanSkills = 30
+)
+
+// Skill is an entry of a tenant's skill taxonomy, under one of the service categories:
+// hair braiding under hair & beauty, say. Service requests are matched to skills by
+// their name and keywords.
+type Skill struct {
+	BaseModel
+
+	TenantID    uuid.UUID       `json:"tenant_id" gorm:"type:uuid;not null;uniqueIndex:idx_skill_tenant_slug"`
+	Category    ServiceCategory `json:"category" gorm:"type:varchar(50);not null;index"`
+	Name        string          `json:"name" gorm:"not null;size:100" validate:"required,max=100"`
+	Slug        string          `json:"slug" gorm:"not null;size:100;uniqueIndex:idx_skill_tenant_slug,where:deleted_at IS NULL"`
+	Description string          `json:"description,omitempty" gorm:"type:text"`
+	Keywords    StringArray     `json:"keywords,omitempty" gorm:"type:jsonb"` // Other words customers use for it, such as "cornrows"
+	IsActive    bool            `json:"is_active" gorm:"default:true"`
+}
+
+// TableName specifies the table name for Skill
+func (Skill) TableName() string {
+	return "skills"
+}
+
+// Validate checks the skill has a name and category, derives its slug and normalizes
+// its keywords
+func (s *Skill) Validate() error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" || len([]rune(s.Name)) > 100 {
+		return errors.New("name is required and at most 100 characters")
+	}
+	if strings.TrimSpace(string(s.Category)) == "" {
+		return errors.New("category is required")
+	}
+
+	s.Slug = SkillSlug(s.Name)
+	if s.Slug == "" {
+		return errors.New("name must contain letters or digits")
+	}
+
+	keywords := make(StringArray, 0, len(s.Keywords))
+	seen := map[string]bool{}
+	for _, keyword := range s.Keywords {
+		keyword = strings.Join(skillTerms(keyword), " ")
+		if keyword == "" || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+		keywords = append(keywords, keyword)
+	}
+	if len(keywords) > MaxSkillKeywords {
+		return fmt.Errorf("a skill can have at most %d keywords", MaxSkillKeywords)
+	}
+	s.Keywords = keywords
+	return nil
+}
+
+// SkillSlug returns the URL-safe identifier of a skill name: "Hair Braiding" is
+// hair-braiding
+func SkillSlug(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
+
+// ArtisanSkill tags an artisan with a skill of the tenant's taxonomy at a proficiency
+type ArtisanSkill struct {
+	BaseModel
+
+	TenantID        uuid.UUID        `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ArtisanID       uuid.UUID        `json:"artisan_id" gorm:"type:uuid;not null;uniqueIndex:idx_artisan_skill"` // Artisan profile ID
+	SkillID         uuid.UUID        `json:"skill_id" gorm:"type:uuid;not null;uniqueIndex:idx_artisan_skill;index"`
+	Proficiency     SkillProficiency `json:"proficiency" gorm:"type:varchar(20);not null"`
+	YearsExperience int              `json:"years_experience" gorm:"default:0"`
+
+	// Relationships
+	Skill *Skill `json:"skill,omitempty" gorm:"foreignKey:SkillID"`
+}
+
+// TableName specifies the table name for ArtisanSkill
+func (ArtisanSkill) TableName() string {
+	return "artisan_skills"
+}
+
+// Validate checks the proficiency and experience
+func (s *ArtisanSkill) Validate() error {
+	if s.SkillID == uuid.Nil {
+		return errors.New("skill_id is required")
+	}
+	if !s.Proficiency.IsValid() {
+		return fmt.Errorf("proficiency must be beginner, intermediate, advanced or expert")
+	}
+	if s.YearsExperience < 0 || s.YearsExperience > 80 {
+		return errors.New("years_experience must be between 0 and 80")
+	}
+	return nil
+}
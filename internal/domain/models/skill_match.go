@@ -0,0 +1,169 @@
+package models
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Weights of the parts of an artisan's match score. Without a location to match near,
+// proximity is left out and the other weights share its place.
+const (
+	MatchWeightSkills       = 0.45
+	MatchWeightRating       = 0.25
+	MatchWeightProximity    = 0.15
+	MatchWeightAvailability = 0.15
+)
+
+const (
+	// matchRatingPrior and matchRatingPriorReviews pull the ratings of artisans with few
+	// reviews towards an average one, so a single 5-star review doesn't top the ranking
+	matchRatingPrior        = 3.0
+	matchRatingPriorReviews = 5
+	// Relevance of a skill mentioned by its name, and by one of its keywords
+	skillNameRelevance    = 1.0
+	skillKeywordRelevance = 0.8
+)
+
+// SkillMatch is a skill a service request mentions
+type SkillMatch struct {
+	Skill     *Skill
+	Relevance float64 // 1 when mentioned by name, less by a keyword
+}
+
+// MatchSkills returns the active skills a service request description mentions by their
+// name or a keyword, the most relevant first. Words are compared case-insensitively and
+// ignoring plural s's, so "box braids" matches the keyword "box braid".
+func MatchSkills(description string, skills []*Skill) []SkillMatch {
+	words := skillTerms(description)
+
+	var matches []SkillMatch
+	for _, skill := range skills {
+		if !skill.IsActive {
+			continue
+		}
+		relevance := 0.0
+		if containsTerms(words, skillTerms(skill.Name)) {
+			relevance = skillNameRelevance
+		} else if slices.ContainsFunc(skill.Keywords, func(keyword string) bool {
+			return containsTerms(words, skillTerms(keyword))
+		}) {
+			relevance = skillKeywordRelevance
+		}
+		if relevance > 0 {
+			matches = append(matches, SkillMatch{Skill: skill, Relevance: relevance})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b SkillMatch) int {
+		if a.Relevance != b.Relevance {
+			if a.Relevance > b.Relevance {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Skill.Name, b.Skill.Name)
+	})
+	return matches
+}
+
+// SkillCoverage returns how well an artisan with the proficiencies covers the matched
+// skills, from 0 to 1: the relevance of each skill they have, weighted by their
+// proficiency in it, over the relevance of all of them
+func SkillCoverage(matches []SkillMatch, proficiencies map[uuid.UUID]SkillProficiency) float64 {
+	total, covered := 0.0, 0.0
+	for _, match := range matches {
+		total += match.Relevance
+		if proficiency, ok := proficiencies[match.Skill.ID]; ok {
+			covered += match.Relevance * proficiency.Weight()
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return covered / total
+}
+
+// ArtisanMatchFactors are what an artisan's match to a service request is scored on
+type ArtisanMatchFactors struct {
+	SkillCoverage float64 // See SkillCoverage
+	Rating        float64
+	ReviewCount   int
+	DistanceKm    *float64 // From the request's location; nil when either has none
+	RadiusKm      float64  // Of the request's location; 0 without one
+	IsAvailable   bool     // Taking bookings
+	HasOpenings   bool     // Has regular availability, on the requested date when there is one
+}
+
+// ArtisanMatchScore is an artisan's match to a service request, each part from 0 to 1
+type ArtisanMatchScore struct {
+	Total        float64
+	Skills       float64
+	Rating       float64
+	Proximity    *float64 // Nil when the request has no location
+	Availability float64
+}
+
+// ScoreArtisanMatch scores an artisan's match to a service request from its factors.
+// Artisans without coordinates score no proximity when the request has a location.
+func ScoreArtisanMatch(f ArtisanMatchFactors) ArtisanMatchScore {
+	score := ArtisanMatchScore{
+		Skills: roundScore(f.SkillCoverage),
+		Rating: roundScore((f.Rating*float64(f.ReviewCount) + matchRatingPrior*matchRatingPriorReviews) /
+			float64(f.ReviewCount+matchRatingPriorReviews) / 5),
+	}
+	switch {
+	case f.IsAvailable && f.HasOpenings:
+		score.Availability = 1
+	case f.IsAvailable:
+		score.Availability = 0.5
+	}
+
+	total := MatchWeightSkills*score.Skills + MatchWeightRating*score.Rating + MatchWeightAvailability*score.Availability
+	weights := MatchWeightSkills + MatchWeightRating + MatchWeightAvailability
+	if f.RadiusKm > 0 {
+		proximity := 0.0
+		if f.DistanceKm != nil {
+			proximity = roundScore(math.Max(0, 1-*f.DistanceKm/f.RadiusKm))
+		}
+		score.Proximity = &proximity
+		total += MatchWeightProximity * proximity
+		weights += MatchWeightProximity
+	}
+	score.Total = roundScore(total / weights)
+	return score
+}
+
+// roundScore rounds a score to three decimals
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
+
+// skillTerms splits text into lowercase words, dropping the plural s of longer ones
+func skillTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			words[i] = strings.TrimSuffix(word, "s")
+		}
+	}
+	return words
+}
+
+// containsTerms reports whether the words contain the terms next to each other
+func containsTerms(words, terms []string) bool {
+	if len(terms) == 0 || len(terms) > len(words) {
+		return false
+	}
+	for i := 0; i+len(terms) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(terms)], terms) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkillValidate(t *testing.T) {
+	skill := models.Skill{
+		Name:     "  Hair Braiding ",
+		Category: models.ServiceCategoryHairBeauty,
+		Keywords: models.StringArray{"Box Braids", "cornrows", "box braid", " "},
+	}
+	require.NoError(t, skill.Validate())
+	assert.Equal(t, "Hair Braiding", skill.Name)
+	assert.Equal(t, "hair-braiding", skill.Slug)
+	assert.Equal(t, models.StringArray{"box braid", "cornrow"}, skill.Keywords)
+
+	assert.Error(t, (&models.Skill{Name: "Braiding"}).Validate(), "category is required")
+	assert.Error(t, (&models.Skill{Name: "--", Category: models.ServiceCategoryHairBeauty}).Validate())
+}
+
+func TestArtisanSkillValidate(t *testing.T) {
+	skillID := uuid.New()
+
+	assert.NoError(t, (&models.ArtisanSkill{SkillID: skillID, Proficiency: models.SkillProficiencyExpert, YearsExperience: 12}).Validate())
+	assert.Error(t, (&models.ArtisanSkill{Proficiency: models.SkillProficiencyExpert}).Validate())
+	assert.Error(t, (&models.ArtisanSkill{SkillID: skillID, Proficiency: "master"}).Validate())
+	assert.Error(t, (&models.ArtisanSkill{SkillID: skillID, Proficiency: models.SkillProficiencyBeginner, YearsExperience: -1}).Validate())
+}
+
+func TestMatchSkills(t *testing.T) {
+	braiding := &models.Skill{Name: "Hair Braiding", Keywords: models.StringArray{"box braid", "cornrow"}, IsActive: true}
+	braiding.ID = uuid.New()
+	locs := &models.Skill{Name: "Locs", Keywords: models.StringArray{"retwist"}, IsActive: true}
+	locs.ID = uuid.New()
+	nails := &models.Skill{Name: "Gel Nails", IsActive: false}
+	nails.ID = uuid.New()
+	skills := []*models.Skill{locs, braiding, nails}
+
+	tests := []struct {
+		name        string
+		description string
+		want        []string
+	}{
+		{"by name", "Looking for hair braiding this Saturday", []string{"Hair Braiding"}},
+		{"by plural keyword", "I need Box Braids and a retwist of my locs", []string{"Locs", "Hair Braiding"}},
+		{"words must be next to each other", "a box of braids", nil},
+		{"inactive skills are not matched", "gel nails please", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, match := range models.MatchSkills(tt.description, skills) {
+				names = append(names, match.Skill.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestSkillCoverage(t *testing.T) {
+	braiding := &models.Skill{Name: "Hair Braiding"}
+	braiding.ID = uuid.New()
+	locs := &models.Skill{Name: "Locs"}
+	locs.ID = uuid.New()
+	matches := []models.SkillMatch{{Skill: braiding, Relevance: 1}, {Skill: locs, Relevance: 0.8}}
+
+	assert.Equal(t, 0.0, models.SkillCoverage(nil, nil))
+	assert.InDelta(t, 1.0, models.SkillCoverage(matches, map[uuid.UUID]models.SkillProficiency{
+		braiding.ID: models.SkillProficiencyExpert,
+		locs.ID:     models.SkillProficiencyExpert,
+	}), 1e-9)
+	assert.InDelta(t, 0.8*0.6/1.8, models.SkillCoverage(matches, map[uuid.UUID]models.SkillProficiency{
+		locs.ID: models.SkillProficiencyIntermediate,
+	}), 1e-9)
+}
+
+func TestScoreArtisanMatch(t *testing.T) {
+	near, far := 2.5, 40.0
+
+	t.Run("without a location proximity is left out", func(t *testing.T) {
+		score := models.ScoreArtisanMatch(models.ArtisanMatchFactors{
+			SkillCoverage: 1, Rating: 5, ReviewCount: 45, IsAvailable: true, HasOpenings: true,
+		})
+		assert.Nil(t, score.Proximity)
+		assert.Equal(t, 0.96, score.Rating)
+		assert.Equal(t, 1.0, score.Availability)
+		assert.Equal(t, 0.988, score.Total)
+	})
+
+	t.Run("few reviews are pulled towards the prior", func(t *testing.T) {
+		score := models.ScoreArtisanMatch(models.ArtisanMatchFactors{Rating: 5, ReviewCount: 1})
+		assert.Equal(t, 0.667, score.Rating)
+	})
+
+	t.Run("proximity within the radius", func(t *testing.T) {
+		score := models.ScoreArtisanMatch(models.ArtisanMatchFactors{
+			SkillCoverage: 0.8, DistanceKm: &near, RadiusKm: 25, IsAvailable: true,
+		})
+		require.NotNil(t, score.Proximity)
+		assert.Equal(t, 0.9, *score.Proximity)
+		assert.Equal(t, 0.5, score.Availability)
+		assert.Equal(t, 0.36+0.25*0.6+0.15*0.9+0.15*0.5, score.Total)
+	})
+
+	t.Run("outside the radius or without coordinates", func(t *testing.T) {
+		score := models.ScoreArtisanMatch(models.ArtisanMatchFactors{DistanceKm: &far, RadiusKm: 25})
+		require.NotNil(t, score.Proximity)
+		assert.Equal(t, 0.0, *score.Proximity)
+
+		score = models.ScoreArtisanMatch(models.ArtisanMatchFactors{RadiusKm: 25})
+		require.NotNil(t, score.Proximity)
+		assert.Equal(t, 0.0, *score.Proximity)
+	})
+}
@@ -59,12 +59,16 @@ type Subscription struct {
 	CanceledAt         *time.Time `json:"canceled_at,omitempty"`
 	CancelAtPeriodEnd  bool       `json:"cancel_at_period_end" gorm:"default:false"`
 
+	// Grace period after a failed renewal; the tenant keeps access until it ends, then
+	// the subscription is suspended
+	GraceEndsAt *time.Time `json:"grace_ends_at,omitempty" gorm:"index"`
+
 	// Payment Gateway References
 	StripeSubscriptionID string `json:"stripe_subscription_id,omitempty" gorm:"uniqueIndex;size:255"`
 	StripeCustomerID     string `json:"stripe_customer_id,omitempty" gorm:"size:255"`
 	PaymentMethodID      string `json:"payment_method_id,omitempty" gorm:"size:255"`
 
-	// Usage & Limits (enforced based on plan)
+	// Usage & Limits (enforced based on plan). MaxTeamMembers is the number of artisan seats.
 	MaxCustomers        int `json:"max_customers" gorm:"not null;default:10"`
 	MaxProjects         int `json:"max_projects" gorm:"not null;default:5"`
 	MaxStorageGB        int `json:"max_storage_gb" gorm:"not null;default:1"`
@@ -200,6 +204,63 @@ func (s *Subscription) CanCreateProject() bool {
 	return s.CurrentProjects < s.MaxProjects
 }
 
+// InGracePeriod reports whether a past-due subscription is still inside its grace period
+func (s *Subscription) InGracePeriod(now time.Time) bool {
+	return s.Status == SubStatusPastDue && s.GraceEndsAt != nil && now.Before(*s.GraceEndsAt)
+}
+
+// HasAccess reports whether the tenant is in good standing: active, trialing, past due
+// within the grace period, or canceled with time left in the paid period
+func (s *Subscription) HasAccess(now time.Time) bool {
+	switch s.Status {
+	case SubStatusActive, SubStatusTrialing:
+		return true
+	case SubStatusPastDue:
+		return s.GraceEndsAt == nil || now.Before(*s.GraceEndsAt)
+	case SubStatusCanceled:
+		return now.Before(s.CurrentPeriodEnd)
+	default:
+		return false
+	}
+}
+
+// StartGracePeriod marks the subscription past due after a failed renewal. The grace
+// period starts with the first failure; later retries do not extend it.
+func (s *Subscription) StartGracePeriod(now time.Time, days int) {
+	s.Status = SubStatusPastDue
+	if s.GraceEndsAt == nil {
+		graceEnd := now.AddDate(0, 0, days)
+		s.GraceEndsAt = &graceEnd
+	}
+}
+
+// EndGracePeriod restores a subscription whose overdue invoice has been paid
+func (s *Subscription) EndGracePeriod() {
+	if s.Status == SubStatusPastDue || s.Status == SubStatusSuspended {
+		s.Status = SubStatusActive
+	}
+	s.GraceEndsAt = nil
+	s.FailedPayments = 0
+}
+
+// Limit returns the subscription's allowance for a metered limit; -1 means unlimited
+func (s *Subscription) Limit(limit PlanLimit) int {
+	switch limit {
+	case PlanLimitBookingsPerMonth:
+		return s.MaxBookingsPerMonth
+	case PlanLimitArtisanSeats:
+		return s.MaxTeamMembers
+	default:
+		return -1
+	}
+}
+
+// AllowsAnother reports whether one more unit of limit fits when used are already taken
+func (s *Subscription) AllowsAnother(limit PlanLimit, used int64) bool {
+	allowed := s.Limit(limit)
+	return allowed < 0 || used < int64(allowed)
+}
+
 func (s *Subscription) HasFeature(feature string) bool {
 	// Use reflection or switch case to check feature
 	// For now, returning true as example
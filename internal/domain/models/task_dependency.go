@@ -0,0 +1,226 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DependencyBlockReason is the block reason of tasks blocked by unfinished
+// dependencies. Only tasks blocked for this reason are unblocked automatically.
+const DependencyBlockReason = "Waiting on dependencies"
+
+// ScheduleHoursPerDay is the working hours in a scheduled day, used to turn estimated
+// hours into projected calendar dates
+const ScheduleHoursPerDay = 8
+
+// TaskDependency records that a task cannot start before another task of the same
+// project is done. ProjectTask.DependsOn mirrors these rows for API responses.
+type TaskDependency struct {
+	BaseModel
+
+	TenantID        uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ProjectID       uuid.UUID `json:"project_id" gorm:"type:uuid;not null;index"`
+	TaskID          uuid.UUID `json:"task_id" gorm:"type:uuid;not null;uniqueIndex:idx_task_dependency"`
+	DependsOnTaskID uuid.UUID `json:"depends_on_task_id" gorm:"type:uuid;not null;uniqueIndex:idx_task_dependency;index"`
+
+	// Relationships
+	Task      *ProjectTask `json:"task,omitempty" gorm:"foreignKey:TaskID"`
+	DependsOn *ProjectTask `json:"depends_on,omitempty" gorm:"foreignKey:DependsOnTaskID"`
+}
+
+// TableName specifies the table name
+func (TaskDependency) TableName() string {
+	return "task_dependencies"
+}
+
+// DependencyCreatesCycle reports whether making taskID depend on dependsOnID would
+// close a cycle in the existing dependencies, including a task depending on itself
+func DependencyCreatesCycle(deps []*TaskDependency, taskID, dependsOnID uuid.UUID) bool {
+	if taskID == dependsOnID {
+		return true
+	}
+
+	prerequisites := make(map[uuid.UUID][]uuid.UUID, len(deps))
+	for _, dep := range deps {
+		prerequisites[dep.TaskID] = append(prerequisites[dep.TaskID], dep.DependsOnTaskID)
+	}
+
+	// A cycle closes if taskID is already a (transitive) prerequisite of dependsOnID
+	visited := map[uuid.UUID]bool{}
+	stack := []uuid.UUID{dependsOnID}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == taskID {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		stack = append(stack, prerequisites[current]...)
+	}
+	return false
+}
+
+// ApplyDependencyBlockers records the unfinished tasks this task waits on and moves it
+// in or out of the blocked status accordingly. Done tasks and tasks blocked for another
+// reason keep their status. It reports whether anything changed.
+func (t *ProjectTask) ApplyDependencyBlockers(blockers []uuid.UUID) bool {
+	changed := !sameIDs(t.BlockedBy, blockers)
+	t.BlockedBy = blockers
+
+	switch {
+	case t.Status == TaskStatusDone:
+	case len(blockers) > 0 && t.Status != TaskStatusBlocked:
+		t.Status = TaskStatusBlocked
+		t.BlockReason = DependencyBlockReason
+		changed = true
+	case len(blockers) == 0 && t.Status == TaskStatusBlocked && t.BlockReason == DependencyBlockReason:
+		t.Status = TaskStatusTodo
+		t.BlockReason = ""
+		changed = true
+	}
+	return changed
+}
+
+func sameIDs(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[uuid.UUID]int, len(a))
+	for _, id := range a {
+		seen[id]++
+	}
+	for _, id := range b {
+		if seen[id] == 0 {
+			return false
+		}
+		seen[id]--
+	}
+	return true
+}
+
+// TaskSchedule is a task's place in the project schedule. Offsets are working hours
+// from the schedule start.
+type TaskSchedule struct {
+	TaskID         uuid.UUID
+	Title          string
+	Status         TaskStatus
+	RemainingHours float64
+	EarliestStart  float64
+	EarliestFinish float64
+	LatestStart    float64
+	LatestFinish   float64
+	SlackHours     float64 // How long the task can slip without delaying the project
+	Critical       bool
+	StartDate      time.Time // Projected
+	FinishDate     time.Time // Projected
+}
+
+// ProjectSchedule is the critical path schedule of a project's remaining work
+type ProjectSchedule struct {
+	Start            time.Time
+	TotalHours       float64     // Length of the critical path
+	ProjectedFinish  time.Time   // Earliest date all remaining work can be done
+	CriticalPath     []uuid.UUID // Zero-slack tasks in the order they have to be done
+	UnestimatedTasks int         // Open tasks without an estimate, scheduled as taking no time
+	Tasks            []*TaskSchedule
+}
+
+// ScheduleProject runs the critical path method over a project's tasks. Each open task
+// takes its estimated hours minus the hours already tracked; done tasks take no time.
+// Work starts at start, ScheduleHoursPerDay hours a day.
+func ScheduleProject(tasks []*ProjectTask, deps []*TaskDependency, start time.Time) (*ProjectSchedule, error) {
+	byID := make(map[uuid.UUID]*TaskSchedule, len(tasks))
+	schedule := &ProjectSchedule{Start: start, Tasks: make([]*TaskSchedule, 0, len(tasks))}
+	for _, task := range tasks {
+		entry := &TaskSchedule{TaskID: task.ID, Title: task.Title, Status: task.Status}
+		if !task.IsCompleted() {
+			entry.RemainingHours = roundMoney(max(task.EstimatedHours-task.TrackedHours, 0))
+			if task.EstimatedHours <= 0 {
+				schedule.UnestimatedTasks++
+			}
+		}
+		byID[task.ID] = entry
+		schedule.Tasks = append(schedule.Tasks, entry)
+	}
+
+	prerequisites := map[uuid.UUID][]uuid.UUID{}
+	dependents := map[uuid.UUID][]uuid.UUID{}
+	for _, dep := range deps {
+		if byID[dep.TaskID] == nil || byID[dep.DependsOnTaskID] == nil {
+			continue
+		}
+		prerequisites[dep.TaskID] = append(prerequisites[dep.TaskID], dep.DependsOnTaskID)
+		dependents[dep.DependsOnTaskID] = append(dependents[dep.DependsOnTaskID], dep.TaskID)
+	}
+
+	// Topological order (Kahn), keeping the tasks' own order among ready tasks
+	pending := make(map[uuid.UUID]int, len(byID))
+	var ready []uuid.UUID
+	for _, entry := range schedule.Tasks {
+		pending[entry.TaskID] = len(prerequisites[entry.TaskID])
+		if pending[entry.TaskID] == 0 {
+			ready = append(ready, entry.TaskID)
+		}
+	}
+	order := make([]uuid.UUID, 0, len(byID))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+		for _, next := range dependents[id] {
+			if pending[next]--; pending[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+	if len(order) != len(byID) {
+		return nil, fmt.Errorf("task dependencies contain a cycle")
+	}
+
+	// Forward pass: earliest start and finish
+	for _, id := range order {
+		entry := byID[id]
+		for _, pre := range prerequisites[id] {
+			entry.EarliestStart = max(entry.EarliestStart, byID[pre].EarliestFinish)
+		}
+		entry.EarliestFinish = entry.EarliestStart + entry.RemainingHours
+		schedule.TotalHours = max(schedule.TotalHours, entry.EarliestFinish)
+	}
+
+	// Backward pass: latest start and finish that keep the project on time
+	for i := len(order) - 1; i >= 0; i-- {
+		entry := byID[order[i]]
+		entry.LatestFinish = schedule.TotalHours
+		for _, next := range dependents[entry.TaskID] {
+			entry.LatestFinish = min(entry.LatestFinish, byID[next].LatestStart)
+		}
+		entry.LatestStart = entry.LatestFinish - entry.RemainingHours
+		entry.SlackHours = roundMoney(entry.LatestStart - entry.EarliestStart)
+		entry.Critical = entry.SlackHours == 0 && entry.RemainingHours > 0
+		entry.StartDate = ScheduleDate(start, entry.EarliestStart)
+		entry.FinishDate = ScheduleDate(start, entry.EarliestFinish)
+	}
+
+	for _, id := range order {
+		if byID[id].Critical {
+			schedule.CriticalPath = append(schedule.CriticalPath, id)
+		}
+	}
+	sort.SliceStable(schedule.CriticalPath, func(i, j int) bool {
+		return byID[schedule.CriticalPath[i]].EarliestStart < byID[schedule.CriticalPath[j]].EarliestStart
+	})
+	schedule.ProjectedFinish = ScheduleDate(start, schedule.TotalHours)
+	return schedule, nil
+}
+
+// ScheduleDate turns an offset in working hours into a calendar date
+func ScheduleDate(start time.Time, hours float64) time.Time {
+	days := hours / ScheduleHoursPerDay
+	return start.Add(time.Duration(days * float64(24*time.Hour)))
+}
@@ -0,0 +1,113 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dependency(task, dependsOn uuid.UUID) *models.TaskDependency {
+	return &models.TaskDependency{TaskID: task, DependsOnTaskID: dependsOn}
+}
+
+func TestDependencyCreatesCycle(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	// c waits on b, b waits on a
+	deps := []*models.TaskDependency{dependency(b, a), dependency(c, b)}
+
+	assert.True(t, models.DependencyCreatesCycle(deps, a, a), "self dependency")
+	assert.True(t, models.DependencyCreatesCycle(deps, a, b), "direct cycle")
+	assert.True(t, models.DependencyCreatesCycle(deps, a, c), "transitive cycle")
+	assert.False(t, models.DependencyCreatesCycle(deps, c, a), "redundant edge is not a cycle")
+	assert.False(t, models.DependencyCreatesCycle(deps, d, c))
+	assert.False(t, models.DependencyCreatesCycle(nil, a, b))
+}
+
+func TestProjectTask_ApplyDependencyBlockers(t *testing.T) {
+	blocker := uuid.New()
+
+	t.Run("blocks open tasks", func(t *testing.T) {
+		task := &models.ProjectTask{Status: models.TaskStatusInProgress}
+		assert.True(t, task.ApplyDependencyBlockers([]uuid.UUID{blocker}))
+		assert.Equal(t, models.TaskStatusBlocked, task.Status)
+		assert.Equal(t, models.DependencyBlockReason, task.BlockReason)
+		assert.False(t, task.ApplyDependencyBlockers([]uuid.UUID{blocker}), "no change the second time")
+	})
+
+	t.Run("unblocks once dependencies are done", func(t *testing.T) {
+		task := &models.ProjectTask{Status: models.TaskStatusBlocked, BlockReason: models.DependencyBlockReason, BlockedBy: []uuid.UUID{blocker}}
+		assert.True(t, task.ApplyDependencyBlockers(nil))
+		assert.Equal(t, models.TaskStatusTodo, task.Status)
+		assert.Empty(t, task.BlockReason)
+		assert.Empty(t, task.BlockedBy)
+	})
+
+	t.Run("keeps manual blocks", func(t *testing.T) {
+		task := &models.ProjectTask{Status: models.TaskStatusBlocked, BlockReason: "Waiting on materials"}
+		assert.False(t, task.ApplyDependencyBlockers(nil))
+		assert.Equal(t, models.TaskStatusBlocked, task.Status)
+	})
+
+	t.Run("leaves done tasks done", func(t *testing.T) {
+		task := &models.ProjectTask{Status: models.TaskStatusDone}
+		task.ApplyDependencyBlockers([]uuid.UUID{blocker})
+		assert.Equal(t, models.TaskStatusDone, task.Status)
+	})
+}
+
+func TestScheduleProject(t *testing.T) {
+	start := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+	task := func(title string, estimated, tracked float64, status models.TaskStatus) *models.ProjectTask {
+		task := &models.ProjectTask{Title: title, EstimatedHours: estimated, TrackedHours: tracked, Status: status}
+		task.ID = uuid.New()
+		return task
+	}
+
+	//  demolition(8, 2 tracked) -> plumbing(16) -> tiling(8)
+	//                           -> electrics(4) ---^
+	survey := task("Survey", 4, 4, models.TaskStatusDone)
+	demolition := task("Demolition", 8, 2, models.TaskStatusInProgress)
+	plumbing := task("Plumbing", 16, 0, models.TaskStatusBlocked)
+	electrics := task("Electrics", 4, 0, models.TaskStatusBlocked)
+	tiling := task("Tiling", 8, 0, models.TaskStatusBlocked)
+	snagging := task("Snagging", 0, 0, models.TaskStatusTodo)
+
+	tasks := []*models.ProjectTask{survey, demolition, plumbing, electrics, tiling, snagging}
+	deps := []*models.TaskDependency{
+		dependency(demolition.ID, survey.ID),
+		dependency(plumbing.ID, demolition.ID),
+		dependency(electrics.ID, demolition.ID),
+		dependency(tiling.ID, plumbing.ID),
+		dependency(tiling.ID, electrics.ID),
+	}
+
+	schedule, err := models.ScheduleProject(tasks, deps, start)
+	require.NoError(t, err)
+
+	assert.Equal(t, 30.0, schedule.TotalHours)
+	assert.Equal(t, []uuid.UUID{demolition.ID, plumbing.ID, tiling.ID}, schedule.CriticalPath)
+	assert.Equal(t, 1, schedule.UnestimatedTasks)
+	assert.Equal(t, start.Add(90*time.Hour), schedule.ProjectedFinish, "30 working hours is 3.75 days")
+
+	byID := map[uuid.UUID]*models.TaskSchedule{}
+	for _, entry := range schedule.Tasks {
+		byID[entry.TaskID] = entry
+	}
+	assert.Equal(t, 6.0, byID[demolition.ID].RemainingHours)
+	assert.Equal(t, 6.0, byID[electrics.ID].EarliestStart)
+	assert.Equal(t, 12.0, byID[electrics.ID].SlackHours)
+	assert.False(t, byID[electrics.ID].Critical)
+	assert.Equal(t, 22.0, byID[tiling.ID].EarliestStart)
+	assert.Equal(t, start.Add(66*time.Hour), byID[tiling.ID].StartDate)
+	assert.False(t, byID[survey.ID].Critical, "done tasks take no time")
+
+	t.Run("rejects cycles", func(t *testing.T) {
+		_, err := models.ScheduleProject(tasks, append(deps, dependency(survey.ID, tiling.ID)), start)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// TaxCategory classifies services for tax purposes
+type TaxCategory string
+
+const (
+	TaxCategoryStandard TaxCategory = "standard"
+	TaxCategoryReduced  TaxCategory = "reduced"
+	TaxCategoryZero     TaxCategory = "zero"
+	TaxCategoryExempt   TaxCategory = "exempt" // Never taxed, whatever the rules say
+)
+
+// IsValid checks if the tax category is one of the supported categories
+func (c TaxCategory) IsValid() bool {
+	switch c {
+	case TaxCategoryStandard, TaxCategoryReduced, TaxCategoryZero, TaxCategoryExempt:
+		return true
+	}
+	return false
+}
+
+// TaxRule is a VAT/GST rate charged by a tenant. Empty Category, Country and Region
+// match anything, so a rule with none of them set is the tenant's default rate and
+// more specific rules override it.
+type TaxRule struct {
+	BaseModel
+
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+
+	Name     string      `json:"name" gorm:"not null;size:100" validate:"required,max=100"` // Printed on invoices, e.g. VAT
+	Category TaxCategory `json:"category,omitempty" gorm:"type:varchar(50)"`
+	Country  string      `json:"country,omitempty" gorm:"size:2"`        // ISO 3166-1 alpha-2
+	Region   string      `json:"region,omitempty" gorm:"size:100"`       // State or province within Country
+	Rate     float64     `json:"rate" gorm:"type:decimal(6,3);not null"` // Percentage
+
+	IsActive bool `json:"is_active" gorm:"default:true;index"`
+
+	// Relationships
+	Tenant *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+}
+
+// TableName specifies the table name for TaxRule
+func (TaxRule) TableName() string {
+	return "tax_rules"
+}
+
+// TaxRuleFromSettings derives the default rule from legacy tenant settings; nil when
+// the tenant charges no tax
+func TaxRuleFromSettings(tenantID uuid.UUID, settings TenantSettings) *TaxRule {
+	if settings.TaxRate <= 0 {
+		return nil
+	}
+
+	name := settings.TaxName
+	if name == "" {
+		name = "Tax"
+	}
+	return &TaxRule{
+		TenantID: tenantID,
+		Name:     name,
+		Rate:     settings.TaxRate,
+		IsActive: true,
+	}
+}
+
+// Validate checks the rule values are consistent
+func (r *TaxRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("tax name is required")
+	}
+	if r.Rate < 0 || r.Rate > 100 {
+		return errors.New("tax rate must be between 0 and 100")
+	}
+	if r.Category != "" && !r.Category.IsValid() {
+		return errors.New("invalid tax category")
+	}
+	if r.Country != "" && len(r.Country) != 2 {
+		return errors.New("country must be a two-letter ISO code")
+	}
+	if r.Region != "" && r.Country == "" {
+		return errors.New("a regional rule needs a country")
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to a service of the category supplied in
+// the given place
+func (r *TaxRule) Matches(category TaxCategory, country, region string) bool {
+	if !r.IsActive {
+		return false
+	}
+	if r.Category != "" && r.Category != category {
+		return false
+	}
+	if r.Country != "" && !strings.EqualFold(r.Country, country) {
+		return false
+	}
+	if r.Region != "" && !strings.EqualFold(r.Region, region) {
+		return false
+	}
+	return true
+}
+
+// specificity ranks matching rules: a regional rule beats a national one, which beats
+// the default, and between rules for the same place a category rule wins
+func (r *TaxRule) specificity() int {
+	score := 0
+	if r.Region != "" {
+		score += 4
+	}
+	if r.Country != "" {
+		score += 2
+	}
+	if r.Category != "" {
+		score++
+	}
+	return score
+}
+
+// ResolveTaxRule returns the most specific rule that applies, or nil when none does
+// or the category is exempt
+func ResolveTaxRule(rules []*TaxRule, category TaxCategory, country, region string) *TaxRule {
+	if category == TaxCategoryExempt {
+		return nil
+	}
+
+	var best *TaxRule
+	for _, rule := range rules {
+		if !rule.Matches(category, country, region) {
+			continue
+		}
+		if best == nil || rule.specificity() > best.specificity() {
+			best = rule
+		}
+	}
+	return best
+}
+
+// Apply computes the rule's tax on amount. With inclusive pricing amount already
+// contains the tax, which is then the share of amount above the taxable base.
+func (r *TaxRule) Apply(amount float64, inclusive bool) TaxLine {
+	line := TaxLine{
+		Name:          r.Name,
+		Rate:          r.Rate,
+		TaxableAmount: roundMoney(amount),
+		Inclusive:     inclusive,
+	}
+	if inclusive {
+		line.TaxableAmount = roundMoney(amount / (1 + r.Rate/100))
+		line.Amount = roundMoney(amount - line.TaxableAmount)
+	} else {
+		line.Amount = roundMoney(amount * r.Rate / 100)
+	}
+	return line
+}
+
+// TaxLine is one tax charged on a booking or invoice
+type TaxLine struct {
+	Name          string  `json:"name"`
+	Rate          float64 `json:"rate"` // Percentage
+	TaxableAmount float64 `json:"taxable_amount"`
+	Amount        float64 `json:"amount"`
+	Inclusive     bool    `json:"inclusive,omitempty"` // Already included in the prices it was charged on
+}
+
+// TaxLines is stored as a JSON array
+type TaxLines []TaxLine
+
+// Total returns all tax charged, whether included in prices or added on top
+func (t TaxLines) Total() float64 {
+	var total float64
+	for _, line := range t {
+		total += line.Amount
+	}
+	return roundMoney(total)
+}
+
+// Exclusive returns the tax added on top of prices
+func (t TaxLines) Exclusive() float64 {
+	var total float64
+	for _, line := range t {
+		if !line.Inclusive {
+			total += line.Amount
+		}
+	}
+	return roundMoney(total)
+}
+
+func (t *TaxLines) Scan(value interface{}) error {
+	if value == nil {
+		*t = TaxLines{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, t)
+}
+
+func (t TaxLines) Value() (driver.Value, error) {
+	if t == nil {
+		return json.Marshal([]TaxLine{})
+	}
+	return json.Marshal(t)
+}
@@ -0,0 +1,127 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTaxRules() []*models.TaxRule {
+	return []*models.TaxRule{
+		{Name: "Tax", Rate: 10, IsActive: true},
+		{Name: "VAT", Country: "GB", Rate: 20, IsActive: true},
+		{Name: "VAT", Country: "GB", Category: models.TaxCategoryReduced, Rate: 5, IsActive: true},
+		{Name: "GST", Country: "CA", Rate: 5, IsActive: true},
+		{Name: "HST", Country: "CA", Region: "Ontario", Rate: 13, IsActive: true},
+		{Name: "Old VAT", Country: "DE", Rate: 16, IsActive: false},
+	}
+}
+
+func TestResolveTaxRule(t *testing.T) {
+	rules := testTaxRules()
+
+	tests := []struct {
+		name     string
+		category models.TaxCategory
+		country  string
+		region   string
+		rate     float64
+	}{
+		{"default rate", models.TaxCategoryStandard, "US", "", 10},
+		{"national rate", models.TaxCategoryStandard, "gb", "", 20},
+		{"category beats country default", models.TaxCategoryReduced, "GB", "", 5},
+		{"regional override", models.TaxCategoryStandard, "CA", "ontario", 13},
+		{"other region uses national rate", models.TaxCategoryStandard, "CA", "Quebec", 5},
+		{"inactive rules are ignored", models.TaxCategoryStandard, "DE", "", 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := models.ResolveTaxRule(rules, tt.category, tt.country, tt.region)
+			require.NotNil(t, rule)
+			assert.Equal(t, tt.rate, rule.Rate)
+		})
+	}
+
+	t.Run("exempt is never taxed", func(t *testing.T) {
+		assert.Nil(t, models.ResolveTaxRule(rules, models.TaxCategoryExempt, "GB", ""))
+	})
+
+	t.Run("no matching rule", func(t *testing.T) {
+		assert.Nil(t, models.ResolveTaxRule(rules[1:], models.TaxCategoryStandard, "US", ""))
+	})
+}
+
+func TestTaxRule_Apply(t *testing.T) {
+	rule := &models.TaxRule{Name: "VAT", Rate: 20, IsActive: true}
+
+	exclusive := rule.Apply(100, false)
+	assert.InDelta(t, 100, exclusive.TaxableAmount, 0.001)
+	assert.InDelta(t, 20, exclusive.Amount, 0.001)
+
+	inclusive := rule.Apply(120, true)
+	assert.InDelta(t, 100, inclusive.TaxableAmount, 0.001)
+	assert.InDelta(t, 20, inclusive.Amount, 0.001)
+
+	lines := models.TaxLines{exclusive, inclusive}
+	assert.InDelta(t, 40, lines.Total(), 0.001)
+	assert.InDelta(t, 20, lines.Exclusive(), 0.001)
+}
+
+func TestTaxRule_Validate(t *testing.T) {
+	assert.NoError(t, (&models.TaxRule{Name: "VAT", Country: "GB", Rate: 20}).Validate())
+	assert.Error(t, (&models.TaxRule{Name: "VAT", Rate: 120}).Validate())
+	assert.Error(t, (&models.TaxRule{Name: "VAT", Country: "GBR", Rate: 20}).Validate())
+	assert.Error(t, (&models.TaxRule{Name: "HST", Region: "Ontario", Rate: 13}).Validate())
+	assert.Error(t, (&models.TaxRule{Name: "VAT", Category: "luxury", Rate: 20}).Validate())
+}
+
+func TestBooking_ApplyTax(t *testing.T) {
+	rule := &models.TaxRule{Name: "VAT", Rate: 20, IsActive: true}
+
+	t.Run("exclusive tax is added to the total", func(t *testing.T) {
+		booking := &models.Booking{BasePrice: 80, AddonsPrice: 20}
+		booking.ApplyTax(models.TaxLines{rule.Apply(booking.Subtotal(), false)})
+
+		assert.InDelta(t, 20, booking.TaxAmount, 0.001)
+		assert.InDelta(t, 120, booking.TotalPrice, 0.001)
+		assert.InDelta(t, 10, booking.TaxShare(60), 0.001)
+	})
+
+	t.Run("inclusive tax leaves the total unchanged", func(t *testing.T) {
+		booking := &models.Booking{BasePrice: 100, AddonsPrice: 20}
+		booking.ApplyTax(models.TaxLines{rule.Apply(booking.Subtotal(), true)})
+
+		assert.InDelta(t, 20, booking.TaxAmount, 0.001)
+		assert.InDelta(t, 120, booking.TotalPrice, 0.001)
+	})
+
+	t.Run("untaxed booking", func(t *testing.T) {
+		booking := &models.Booking{BasePrice: 100}
+		booking.ApplyTax(nil)
+
+		assert.InDelta(t, 100, booking.TotalPrice, 0.001)
+		assert.Zero(t, booking.TaxShare(50))
+	})
+}
+
+func TestPayment_CommissionNetOfTax(t *testing.T) {
+	booking := &models.Booking{BasePrice: 100}
+	booking.ApplyTax(models.TaxLines{(&models.TaxRule{Name: "VAT", Rate: 20, IsActive: true}).Apply(100, false)})
+
+	payment := &models.Payment{Amount: 120, Type: models.PaymentTypeFull, CommissionRate: 10}
+	payment.ApplyBookingTax(booking)
+	payment.CalculateCommission()
+
+	assert.InDelta(t, 20, payment.TaxAmount, 0.001)
+	assert.InDelta(t, 10, payment.PlatformAmount, 0.001)
+	assert.InDelta(t, 90, payment.ArtisanAmount, 0.001)
+
+	tip := &models.Payment{Amount: 15, Type: models.PaymentTypeTip, CommissionRate: 10}
+	tip.ApplyBookingTax(booking)
+	tip.CalculateCommission()
+	assert.Zero(t, tip.TaxAmount)
+	assert.InDelta(t, 1.5, tip.PlatformAmount, 0.001)
+}
@@ -28,6 +28,9 @@ const (
 	TenantStatusSuspended TenantStatus = "suspended"
 	TenantStatusCancelled TenantStatus = "cancelled"
 	TenantStatusTrial     TenantStatus = "trial"
+	// TenantStatusClosed is a tenant offboarded for good: its users are signed out and
+	// refused, and its data kept only until retention runs out
+	TenantStatusClosed TenantStatus = "closed"
 )
 
 type Tenant struct {
@@ -43,7 +46,12 @@ type Tenant struct {
 	// Basic Info
 	Name      string `json:"name" gorm:"not null;size:255" validate:"required,min=2,max=255"`
 	Subdomain string `json:"subdomain" gorm:"uniqueIndex;not null;size:63" validate:"required,alphanum,min=3,max=63"`
-	Domain    string `json:"domain,omitempty" gorm:"size:255" validate:"omitempty,fqdn"`
+	Domain    string `json:"domain,omitempty" gorm:"size:255;index" validate:"omitempty,fqdn"`
+
+	// Custom domain ownership, proven with a TXT record carrying the token. Requests
+	// are only served for the custom domain once it is verified.
+	DomainVerificationToken string     `json:"-" gorm:"size:64"`
+	DomainVerifiedAt        *time.Time `json:"domain_verified_at,omitempty"`
 
 	// Business Details
 	BusinessName  string `json:"business_name" gorm:"size:255"`
@@ -94,6 +102,9 @@ type TenantSettings struct {
 	RequireDepositBooking    bool    `json:"require_deposit_booking"`
 	DefaultDepositPercentage float64 `json:"default_deposit_percentage" validate:"min=0,max=100"`
 
+	// Status transition rules (nil means DefaultBookingTransitionPolicy)
+	BookingTransitions *BookingTransitionPolicy `json:"booking_transitions,omitempty"`
+
 	// Cancellation Policy
 	CancellationPolicy      string  `json:"cancellation_policy"` // flexible, moderate, strict
 	FullRefundHours         int     `json:"full_refund_hours" validate:"min=0"`
@@ -101,18 +112,44 @@ type TenantSettings struct {
 	PartialRefundPercentage float64 `json:"partial_refund_percentage" validate:"min=0,max=100"`
 	NoShowFeePercentage     float64 `json:"no_show_fee_percentage" validate:"min=0,max=100"`
 
+	// No-show automation
+	AutoMarkNoShow     bool `json:"auto_mark_no_show"`                      // Mark confirmed bookings as no-show once the grace period passes
+	NoShowGraceMinutes int  `json:"no_show_grace_minutes" validate:"min=0"` // Default: 30
+	ChargeNoShowFee    bool `json:"charge_no_show_fee"`                     // Charge the policy's no-show fee when auto-marking
+
 	// Payment Settings
-	DefaultCurrency        string   `json:"default_currency" validate:"len=3"` // USD, EUR, GBP, GHS
-	AcceptedPaymentMethods []string `json:"accepted_payment_methods"`          // card, cash, bank_transfer
-	AutoChargeOnCompletion bool     `json:"auto_charge_on_completion"`
-	EnableTipping          bool     `json:"enable_tipping"`
-	DefaultTipPercentages  []int    `json:"default_tip_percentages"` // [10, 15, 20]
+	DefaultCurrency         string   `json:"default_currency" validate:"len=3"` // USD, EUR, GBP, GHS
+	AcceptedPaymentMethods  []string `json:"accepted_payment_methods"`          // card, cash, bank_transfer
+	AutoChargeOnCompletion  bool     `json:"auto_charge_on_completion"`
+	EnableTipping           bool     `json:"enable_tipping"`
+	DefaultTipPercentages   []int    `json:"default_tip_percentages"`             // [10, 15, 20]
+	PaymentProvider         string   `json:"payment_provider,omitempty"`          // stripe, paypal, paystack, mpesa; empty uses the platform default
+	FallbackPaymentProvider string   `json:"fallback_payment_provider,omitempty"` // Used when the payment provider is down; empty disables failover
+
+	// Balance collection for deposit bookings
+	AutoCollectBalance    bool `json:"auto_collect_balance"`                      // Charge the remainder to the saved payment method
+	BalanceDueHoursBefore int  `json:"balance_due_hours_before" validate:"min=0"` // Default: 24
+
+	// Dunning for failed balance charges
+	BalanceRetryDays       []int `json:"balance_retry_days,omitempty" validate:"omitempty,max=10,dive,min=1"` // Days after the first failure to retry; default 1, 3, 7
+	CancelOnBalanceFailure bool  `json:"cancel_on_balance_failure"`                                           // Cancel upcoming bookings once every retry failed
+
+	// Escrow: days after a booking is completed before the artisan's share of its
+	// payments can be paid out, unless the customer confirms the work sooner; 0 pays out
+	// as soon as payments are settled
+	EscrowHoldDays int `json:"escrow_hold_days" validate:"min=0,max=90"`
 
 	// Commission & Pricing
 	PlatformCommissionRate float64 `json:"platform_commission_rate" validate:"min=0,max=100"`
-	TaxRate                float64 `json:"tax_rate" validate:"min=0,max=100"`
+	TaxRate                float64 `json:"tax_rate" validate:"min=0,max=100"` // Default rate when no tax rules are configured
 	IncludeTaxInPrice      bool    `json:"include_tax_in_price"`
 
+	// Tax: the name printed on invoices (VAT, GST) and the home jurisdiction, used for
+	// bookings without a service location
+	TaxName    string `json:"tax_name,omitempty"`
+	TaxCountry string `json:"tax_country,omitempty" validate:"omitempty,len=2"`
+	TaxRegion  string `json:"tax_region,omitempty"`
+
 	// Notification Settings
 	EmailNotificationsEnabled bool  `json:"email_notifications_enabled"`
 	SMSNotificationsEnabled   bool  `json:"sms_notifications_enabled"`
@@ -135,6 +172,12 @@ type TenantSettings struct {
 	EnableCustomerReviews    bool `json:"enable_customer_reviews"`
 	ReviewsRequireApproval   bool `json:"reviews_require_approval"`
 
+	// Review auto-publish rules and review requests (nil means DefaultReviewModerationPolicy)
+	ReviewModeration *ReviewModerationPolicy `json:"review_moderation,omitempty"`
+
+	// Customer segmentation thresholds (nil means DefaultCustomerSegmentRules)
+	CustomerSegments *CustomerSegmentRules `json:"customer_segments,omitempty"`
+
 	// Team & Staff
 	AllowTeamMemberBooking bool `json:"allow_team_member_booking"`
 	RequireTaskAssignment  bool `json:"require_task_assignment"`
@@ -153,6 +196,9 @@ type TenantSettings struct {
 	EnableOverbooking       bool `json:"enable_overbooking"`
 	OverbookingPercentage   int  `json:"overbooking_percentage" validate:"min=0,max=100"`
 
+	// Multi-factor authentication requirements (nil requires none)
+	MFA *MFAPolicy `json:"mfa,omitempty"`
+
 	// Privacy & Compliance
 	RequireTermsAcceptance bool `json:"require_terms_acceptance"`
 	RequirePrivacyConsent  bool `json:"require_privacy_consent"`
@@ -391,6 +437,7 @@ func (tf TenantFeatures) Value() (driver.Value, error) {
 
 // GetDefaultTenantSettings returns sensible defaults for a new tenant
 func GetDefaultTenantSettings() TenantSettings {
+	transitions := DefaultBookingTransitionPolicy()
 	return TenantSettings{
 		// Booking defaults
 		BookingApprovalRequired:  false,
@@ -400,6 +447,7 @@ func GetDefaultTenantSettings() TenantSettings {
 		AllowRecurringBookings:   true,
 		RequireDepositBooking:    false,
 		DefaultDepositPercentage: 20.0,
+		BookingTransitions:       &transitions,
 
 		// Cancellation policy
 		CancellationPolicy:      "moderate",
@@ -408,6 +456,11 @@ func GetDefaultTenantSettings() TenantSettings {
 		PartialRefundPercentage: 50.0,
 		NoShowFeePercentage:     100.0,
 
+		// No-show automation
+		AutoMarkNoShow:     true,
+		NoShowGraceMinutes: 30,
+		ChargeNoShowFee:    false,
+
 		// Payment defaults
 		DefaultCurrency:        "USD",
 		AcceptedPaymentMethods: []string{"card", "cash"},
@@ -415,6 +468,11 @@ func GetDefaultTenantSettings() TenantSettings {
 		EnableTipping:          true,
 		DefaultTipPercentages:  []int{10, 15, 20},
 
+		// Balance collection
+		AutoCollectBalance:    false,
+		BalanceDueHoursBefore: 24,
+		BalanceRetryDays:      []int{1, 3, 7},
+
 		// Commission & pricing
 		PlatformCommissionRate: 10.0,
 		TaxRate:                0.0,
@@ -500,6 +558,38 @@ func (ts *TenantSettings) GetCancellationRefundPercentage(hoursUntilBooking floa
 	return 0.0
 }
 
+// GetBookingTransitionPolicy returns the tenant's transition policy or the default one
+func (ts *TenantSettings) GetBookingTransitionPolicy() BookingTransitionPolicy {
+	if ts.BookingTransitions == nil {
+		return DefaultBookingTransitionPolicy()
+	}
+	return *ts.BookingTransitions
+}
+
+// GetCustomerSegmentRules returns the tenant's segmentation thresholds or the default ones
+func (ts *TenantSettings) GetCustomerSegmentRules() CustomerSegmentRules {
+	if ts.CustomerSegments == nil {
+		return DefaultCustomerSegmentRules()
+	}
+	return *ts.CustomerSegments
+}
+
+// GetReviewModerationPolicy returns the tenant's review moderation policy or the default one
+func (ts *TenantSettings) GetReviewModerationPolicy() ReviewModerationPolicy {
+	if ts.ReviewModeration == nil {
+		return DefaultReviewModerationPolicy()
+	}
+	return *ts.ReviewModeration
+}
+
+// GetMFAPolicy returns the tenant's multi-factor authentication requirements
+func (ts *TenantSettings) GetMFAPolicy() MFAPolicy {
+	if ts.MFA == nil {
+		return MFAPolicy{}
+	}
+	return *ts.MFA
+}
+
 func (ts *TenantSettings) SupportsPaymentMethod(method string) bool {
 	return slices.Contains(ts.AcceptedPaymentMethods, method)
 }
@@ -554,6 +644,15 @@ func (t *Tenant) TrialExpired() bool {
 	return t.TrialEndsAt != nil && time.Now().After(*t.TrialEndsAt)
 }
 
+// EffectiveStatus is the status access is decided by: a trial past its end is
+// suspended even before the trial expiry job has caught up with it
+func (t *Tenant) EffectiveStatus() TenantStatus {
+	if t.Status == TenantStatusTrial && t.TrialExpired() {
+		return TenantStatusSuspended
+	}
+	return t.Status
+}
+
 func (t *Tenant) CanAddUser() bool {
 	return t.CurrentUsers < t.MaxUsers
 }
@@ -563,6 +662,7 @@ func (t *Tenant) CanTransitionTo(newStatus TenantStatus) error {
 	validTransitions := map[TenantStatus][]TenantStatus{
 		TenantStatusTrial: {
 			TenantStatusActive,
+			TenantStatusSuspended, // Trial ended without a subscription
 			TenantStatusCancelled,
 		},
 		TenantStatusActive: {
@@ -573,7 +673,10 @@ func (t *Tenant) CanTransitionTo(newStatus TenantStatus) error {
 			TenantStatusActive,
 			TenantStatusCancelled,
 		},
-		TenantStatusCancelled: {}, // Terminal state
+		TenantStatusCancelled: {
+			TenantStatusClosed,
+		},
+		TenantStatusClosed: {}, // Terminal state
 	}
 
 	allowed := validTransitions[t.Status]
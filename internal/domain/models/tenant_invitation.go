@@ -7,6 +7,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// InvitableRoles are the staff roles tenant admins invite people to. Owners are not
+// invited, and customers sign up themselves.
+var InvitableRoles = []UserRole{UserRoleTenantAdmin, UserRoleArtisan, UserRoleTeamMember}
+
 type TenantInvitation struct {
 	BaseModel
 	TenantID   uuid.UUID  `json:"tenant_id" gorm:"type:uuid;not null;index"`
@@ -29,6 +33,11 @@ func (ti *TenantInvitation) IsAccepted() bool {
 	return ti.AcceptedAt != nil
 }
 
+// IsPending reports whether the invitation can still be accepted
+func (ti *TenantInvitation) IsPending() bool {
+	return !ti.IsAccepted() && !ti.IsExpired()
+}
+
 type TenantUsageTracking struct {
 	BaseModel
 	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;uniqueIndex:idx_tenant_usage_date"`
@@ -65,13 +74,15 @@ func (tut *TenantUsageTracking) IncrementAPICall() error {
 
 type DataExportRequest struct {
 	BaseModel
-	TenantID    uuid.UUID  `json:"tenant_id" gorm:"type:uuid;not null;index"`
-	RequestedBy uuid.UUID  `json:"requested_by" gorm:"type:uuid;not null"`
-	ExportType  string     `json:"export_type" gorm:"size:50;not null"` // full, partial, gdpr
-	Status      string     `json:"status" gorm:"size:50;not null;default:'pending'"`
-	FileURL     string     `json:"file_url,omitempty" gorm:"size:500"`
-	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	TenantID    uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	RequestedBy uuid.UUID `json:"requested_by" gorm:"type:uuid;not null"`
+	ExportType  string    `json:"export_type" gorm:"size:50;not null"` // full, partial, gdpr
+	// SubjectUserID is the user whose personal data a gdpr export holds
+	SubjectUserID *uuid.UUID `json:"subject_user_id,omitempty" gorm:"type:uuid;index"`
+	Status        string     `json:"status" gorm:"size:50;not null;default:'pending'"`
+	FileURL       string     `json:"file_url,omitempty" gorm:"size:500"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
 
 	Tenant    *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
 	Requester *User   `json:"requester,omitempty" gorm:"foreignKey:RequestedBy"`
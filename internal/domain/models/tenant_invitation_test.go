@@ -0,0 +1,24 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantInvitation_IsPending(t *testing.T) {
+	now := time.Now()
+
+	invitation := &models.TenantInvitation{ExpiresAt: now.Add(time.Hour)}
+	assert.True(t, invitation.IsPending())
+
+	invitation.AcceptedAt = &now
+	assert.False(t, invitation.IsPending())
+
+	// Expired invitations no longer hold a seat
+	expired := &models.TenantInvitation{ExpiresAt: now.Add(-time.Hour)}
+	assert.False(t, expired.IsPending())
+}
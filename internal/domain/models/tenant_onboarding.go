@@ -0,0 +1,73 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OnboardingStep is a step of the self-serve onboarding wizard
+type OnboardingStep string
+
+const (
+	OnboardingStepTenant       OnboardingStep = "tenant"        // Name and subdomain chosen
+	OnboardingStepOwnerInvite  OnboardingStep = "owner_invite"  // Owner invited to sign in
+	OnboardingStepPreferences  OnboardingStep = "preferences"   // Currency, timezone and branding
+	OnboardingStepServices     OnboardingStep = "services"      // Services offered, priced in the currency
+	OnboardingStepWorkingHours OnboardingStep = "working_hours" // Business hours
+)
+
+// OnboardingSteps are the wizard's steps in the order they are taken
+var OnboardingSteps = []OnboardingStep{
+	OnboardingStepTenant,
+	OnboardingStepOwnerInvite,
+	OnboardingStepPreferences,
+	OnboardingStepServices,
+	OnboardingStepWorkingHours,
+}
+
+// TenantOnboarding tracks a tenant's progress through the self-serve onboarding
+// wizard, so it can be resumed where it was left
+type TenantOnboarding struct {
+	BaseModel
+
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;uniqueIndex"`
+
+	CompletedSteps StringArray `json:"completed_steps" gorm:"type:jsonb"`
+	CompletedAt    *time.Time  `json:"completed_at,omitempty"` // Set once every step is completed
+
+	// Relationships
+	Tenant *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+}
+
+// TableName specifies the table name for TenantOnboarding
+func (TenantOnboarding) TableName() string {
+	return "tenant_onboardings"
+}
+
+// HasCompleted reports whether the step was completed
+func (o *TenantOnboarding) HasCompleted(step OnboardingStep) bool {
+	return slices.Contains(o.CompletedSteps, string(step))
+}
+
+// Complete records the step as completed, and the onboarding once every step is
+func (o *TenantOnboarding) Complete(step OnboardingStep) {
+	if !o.HasCompleted(step) {
+		o.CompletedSteps = append(o.CompletedSteps, string(step))
+	}
+	if o.CompletedAt == nil && o.NextStep() == "" {
+		now := time.Now()
+		o.CompletedAt = &now
+	}
+}
+
+// NextStep returns the first step not completed yet, or "" when onboarding is complete
+func (o *TenantOnboarding) NextStep() OnboardingStep {
+	for _, step := range OnboardingSteps {
+		if !o.HasCompleted(step) {
+			return step
+		}
+	}
+	return ""
+}
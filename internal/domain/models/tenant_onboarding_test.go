@@ -0,0 +1,28 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantOnboarding_Progress(t *testing.T) {
+	onboarding := &models.TenantOnboarding{}
+	assert.Equal(t, models.OnboardingStepTenant, onboarding.NextStep())
+
+	onboarding.Complete(models.OnboardingStepTenant)
+	onboarding.Complete(models.OnboardingStepOwnerInvite)
+	// Steps may be taken out of order; the next step is the first one left
+	onboarding.Complete(models.OnboardingStepWorkingHours)
+	onboarding.Complete(models.OnboardingStepWorkingHours)
+	assert.Equal(t, models.OnboardingStepPreferences, onboarding.NextStep())
+	assert.Len(t, onboarding.CompletedSteps, 3)
+	assert.Nil(t, onboarding.CompletedAt)
+
+	onboarding.Complete(models.OnboardingStepPreferences)
+	onboarding.Complete(models.OnboardingStepServices)
+	assert.Equal(t, models.OnboardingStep(""), onboarding.NextStep())
+	assert.NotNil(t, onboarding.CompletedAt)
+}
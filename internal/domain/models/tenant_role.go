@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// TenantRole is a custom role a tenant admin defines for their staff. It grants its
+// permissions across the tenant, on top of what the user's base role allows.
+type TenantRole struct {
+	BaseModel
+
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;uniqueIndex:idx_tenant_role_name"`
+
+	Name        string      `json:"name" gorm:"size:100;not null;uniqueIndex:idx_tenant_role_name" validate:"required,max=100"`
+	Description string      `json:"description,omitempty" gorm:"size:255" validate:"max=255"`
+	Permissions StringArray `json:"permissions" gorm:"type:jsonb"` // Actions granted, such as "bookings.update"
+
+	// Relationships
+	Tenant      *Tenant                 `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+	Assignments []*TenantRoleAssignment `json:"assignments,omitempty" gorm:"foreignKey:RoleID"`
+}
+
+// TableName specifies the table name for TenantRole
+func (TenantRole) TableName() string {
+	return "tenant_roles"
+}
+
+// Validate checks the role values are consistent
+func (r *TenantRole) Validate() error {
+	name := strings.TrimSpace(r.Name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if len(name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if len(r.Description) > 255 {
+		return errors.New("description must be at most 255 characters")
+	}
+	return nil
+}
+
+// TenantRoleAssignment gives a user of the tenant a custom role
+type TenantRoleAssignment struct {
+	BaseModel
+
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	RoleID   uuid.UUID `json:"role_id" gorm:"type:uuid;not null;uniqueIndex:idx_tenant_role_assignment"`
+	UserID   uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_tenant_role_assignment;index"`
+
+	// Relationships
+	Role *TenantRole `json:"role,omitempty" gorm:"foreignKey:RoleID"`
+	User *User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName specifies the table name for TenantRoleAssignment
+func (TenantRoleAssignment) TableName() string {
+	return "tenant_role_assignments"
+}
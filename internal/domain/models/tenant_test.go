@@ -0,0 +1,48 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenant_CanTransitionTo(t *testing.T) {
+	valid := []struct{ from, to models.TenantStatus }{
+		{models.TenantStatusTrial, models.TenantStatusActive},
+		{models.TenantStatusTrial, models.TenantStatusSuspended},
+		{models.TenantStatusActive, models.TenantStatusSuspended},
+		{models.TenantStatusSuspended, models.TenantStatusActive},
+		{models.TenantStatusSuspended, models.TenantStatusCancelled},
+		{models.TenantStatusCancelled, models.TenantStatusClosed},
+	}
+	for _, tc := range valid {
+		tenant := &models.Tenant{Status: tc.from}
+		assert.NoError(t, tenant.CanTransitionTo(tc.to), "%s -> %s", tc.from, tc.to)
+	}
+
+	invalid := []struct{ from, to models.TenantStatus }{
+		{models.TenantStatusTrial, models.TenantStatusClosed},
+		{models.TenantStatusActive, models.TenantStatusClosed},
+		{models.TenantStatusCancelled, models.TenantStatusActive},
+		{models.TenantStatusClosed, models.TenantStatusActive},
+		{models.TenantStatusClosed, models.TenantStatusCancelled},
+	}
+	for _, tc := range invalid {
+		tenant := &models.Tenant{Status: tc.from}
+		assert.Error(t, tenant.CanTransitionTo(tc.to), "%s -> %s", tc.from, tc.to)
+	}
+}
+
+func TestTenant_EffectiveStatus(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	assert.Equal(t, models.TenantStatusSuspended, (&models.Tenant{Status: models.TenantStatusTrial, TrialEndsAt: &past}).EffectiveStatus())
+	assert.Equal(t, models.TenantStatusTrial, (&models.Tenant{Status: models.TenantStatusTrial, TrialEndsAt: &future}).EffectiveStatus())
+	assert.Equal(t, models.TenantStatusTrial, (&models.Tenant{Status: models.TenantStatusTrial}).EffectiveStatus())
+	// Only trials lapse; other statuses are kept by their own end dates
+	assert.Equal(t, models.TenantStatusActive, (&models.Tenant{Status: models.TenantStatusActive, TrialEndsAt: &past}).EffectiveStatus())
+}
@@ -0,0 +1,197 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeEntrySource string
+
+const (
+	TimeEntrySourceTimer  TimeEntrySource = "timer"  // Started and stopped live
+	TimeEntrySourceManual TimeEntrySource = "manual" // Logged after the fact
+)
+
+// MaxTimeEntryDuration bounds a single entry; a timer left running longer is stopped
+// at the limit and can be corrected by hand
+const MaxTimeEntryDuration = 24 * time.Hour
+
+// TimeEntry records time a user spent on a project task. A running timer has no
+// EndedAt; stopped and manual entries carry their duration, which rolls up into the
+// task's tracked hours and the project's actual hours.
+type TimeEntry struct {
+	BaseModel
+
+	// Multi-tenancy
+	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index:idx_time_entry_tenant_project"`
+	ProjectID uuid.UUID `json:"project_id" gorm:"type:uuid;not null;index:idx_time_entry_tenant_project"`
+	TaskID    uuid.UUID `json:"task_id" gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index:idx_time_entry_user_ended;uniqueIndex:idx_time_entry_user_running,where:ended_at IS NULL"` // One running timer per user
+
+	// Core details
+	Description string          `json:"description,omitempty" gorm:"type:text"`
+	Source      TimeEntrySource `json:"source" gorm:"type:varchar(16);not null;default:'manual'"`
+
+	// Timing
+	StartedAt       time.Time  `json:"started_at" gorm:"type:timestamptz;not null;index"`
+	EndedAt         *time.Time `json:"ended_at,omitempty" gorm:"type:timestamptz;index:idx_time_entry_user_ended"` // Nil while the timer runs
+	DurationMinutes int        `json:"duration_minutes" gorm:"default:0"`
+
+	// Billing
+	Billable   bool       `json:"billable" gorm:"not null;default:false"`
+	HourlyRate float64    `json:"hourly_rate" gorm:"type:decimal(10,2);default:0"`
+	Currency   string     `json:"currency,omitempty" gorm:"size:3;default:'USD'"`
+	InvoiceID  *uuid.UUID `json:"invoice_id,omitempty" gorm:"type:uuid;index"` // Set once billed
+	InvoicedAt *time.Time `json:"invoiced_at,omitempty" gorm:"type:timestamptz"`
+
+	// Relationships
+	Project *Project     `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+	Task    *ProjectTask `json:"task,omitempty" gorm:"foreignKey:TaskID"`
+	User    *User        `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName specifies the table name
+func (TimeEntry) TableName() string {
+	return "time_entries"
+}
+
+// IsRunning reports whether the entry is a timer that has not been stopped
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// IsInvoiced reports whether the entry has been billed
+func (e *TimeEntry) IsInvoiced() bool {
+	return e.InvoiceID != nil
+}
+
+// SetPeriod sets the entry's start and end and derives its duration in whole minutes
+func (e *TimeEntry) SetPeriod(startedAt, endedAt time.Time) error {
+	if !endedAt.After(startedAt) {
+		return fmt.Errorf("ended_at must be after started_at")
+	}
+	if endedAt.Sub(startedAt) > MaxTimeEntryDuration {
+		return fmt.Errorf("a time entry cannot be longer than %s", MaxTimeEntryDuration)
+	}
+	e.StartedAt = startedAt
+	e.EndedAt = &endedAt
+	e.DurationMinutes = int(math.Round(endedAt.Sub(startedAt).Minutes()))
+	return nil
+}
+
+// Stop ends a running timer at the given time, capped at MaxTimeEntryDuration
+func (e *TimeEntry) Stop(at time.Time) error {
+	if !e.IsRunning() {
+		return fmt.Errorf("timer is not running")
+	}
+	if limit := e.StartedAt.Add(MaxTimeEntryDuration); at.After(limit) {
+		at = limit
+	}
+	return e.SetPeriod(e.StartedAt, at)
+}
+
+// Hours returns the entry's duration in hours
+func (e *TimeEntry) Hours() float64 {
+	return float64(e.DurationMinutes) / 60
+}
+
+// Amount returns what the entry bills at its hourly rate, rounded to cents; zero for
+// non-billable entries
+func (e *TimeEntry) Amount() float64 {
+	if !e.Billable {
+		return 0
+	}
+	return roundMoney(e.Hours() * e.HourlyRate)
+}
+
+// Validate checks the entry's references, source and timing
+func (e *TimeEntry) Validate() error {
+	if e.TenantID == uuid.Nil || e.ProjectID == uuid.Nil || e.TaskID == uuid.Nil || e.UserID == uuid.Nil {
+		return fmt.Errorf("tenant, project, task and user are required")
+	}
+	if e.Source != TimeEntrySourceTimer && e.Source != TimeEntrySourceManual {
+		return fmt.Errorf("invalid source %q", e.Source)
+	}
+	if e.StartedAt.IsZero() {
+		return fmt.Errorf("started_at is required")
+	}
+	if e.Source == TimeEntrySourceManual && e.EndedAt == nil {
+		return fmt.Errorf("manual entries need an end time")
+	}
+	if e.EndedAt != nil && !e.EndedAt.After(e.StartedAt) {
+		return fmt.Errorf("ended_at must be after started_at")
+	}
+	if e.DurationMinutes < 0 || time.Duration(e.DurationMinutes)*time.Minute > MaxTimeEntryDuration {
+		return fmt.Errorf("duration must be between 0 and %s", MaxTimeEntryDuration)
+	}
+	if e.HourlyRate < 0 {
+		return fmt.Errorf("hourly_rate cannot be negative")
+	}
+	return nil
+}
+
+// BillableLineItems groups billable entries into invoice line items, one per task and
+// hourly rate, in the order the tasks were first worked on. Running, non-billable and
+// already invoiced entries are skipped. taskTitles names the tasks in descriptions.
+func BillableLineItems(entries []*TimeEntry, taskTitles map[uuid.UUID]string) InvoiceLineItems {
+	type group struct {
+		taskID  uuid.UUID
+		rate    float64
+		minutes int
+		first   time.Time
+	}
+	type groupKey struct {
+		taskID uuid.UUID
+		rate   float64
+	}
+
+	groups := map[groupKey]*group{}
+	for _, e := range entries {
+		if e.IsRunning() || !e.Billable || e.IsInvoiced() {
+			continue
+		}
+		key := groupKey{e.TaskID, e.HourlyRate}
+		g, ok := groups[key]
+		if !ok {
+			g = &group{taskID: e.TaskID, rate: e.HourlyRate, first: e.StartedAt}
+			groups[key] = g
+		}
+		g.minutes += e.DurationMinutes
+		if e.StartedAt.Before(g.first) {
+			g.first = e.StartedAt
+		}
+	}
+
+	ordered := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		ordered = append(ordered, g)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if !ordered[i].first.Equal(ordered[j].first) {
+			return ordered[i].first.Before(ordered[j].first)
+		}
+		return ordered[i].rate < ordered[j].rate
+	})
+
+	items := make(InvoiceLineItems, 0, len(ordered))
+	for _, g := range ordered {
+		title := taskTitles[g.taskID]
+		if title == "" {
+			title = "Task " + g.taskID.String()[:8]
+		}
+		hours := float64(g.minutes) / 60
+		amount := roundMoney(hours * g.rate)
+		// Quantities are whole units, so the hours go in the description
+		items = append(items, InvoiceLineItem{
+			Description: fmt.Sprintf("%s: %.2f h @ %.2f/h", title, hours, g.rate),
+			Quantity:    1,
+			UnitPrice:   amount,
+			TotalPrice:  amount,
+		})
+	}
+	return items
+}
@@ -0,0 +1,119 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeEntry_Stop(t *testing.T) {
+	start := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC)
+
+	t.Run("rounds to whole minutes", func(t *testing.T) {
+		entry := &models.TimeEntry{StartedAt: start, Billable: true, HourlyRate: 40}
+		require.True(t, entry.IsRunning())
+
+		require.NoError(t, entry.Stop(start.Add(90*time.Minute+40*time.Second)))
+		assert.False(t, entry.IsRunning())
+		assert.Equal(t, 91, entry.DurationMinutes)
+		assert.InDelta(t, 60.67, entry.Amount(), 0.001)
+	})
+
+	t.Run("caps forgotten timers", func(t *testing.T) {
+		entry := &models.TimeEntry{StartedAt: start}
+		require.NoError(t, entry.Stop(start.Add(72*time.Hour)))
+		assert.Equal(t, start.Add(models.MaxTimeEntryDuration), *entry.EndedAt)
+		assert.Equal(t, 24*60, entry.DurationMinutes)
+	})
+
+	t.Run("only running timers stop", func(t *testing.T) {
+		entry := &models.TimeEntry{}
+		require.NoError(t, entry.SetPeriod(start, start.Add(time.Hour)))
+		assert.Error(t, entry.Stop(start.Add(2*time.Hour)))
+	})
+
+	t.Run("non-billable entries bill nothing", func(t *testing.T) {
+		entry := &models.TimeEntry{HourlyRate: 40}
+		require.NoError(t, entry.SetPeriod(start, start.Add(time.Hour)))
+		assert.Zero(t, entry.Amount())
+	})
+}
+
+func TestTimeEntry_Validate(t *testing.T) {
+	start := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC)
+	valid := func() *models.TimeEntry {
+		entry := &models.TimeEntry{
+			TenantID:  uuid.New(),
+			ProjectID: uuid.New(),
+			TaskID:    uuid.New(),
+			UserID:    uuid.New(),
+			Source:    models.TimeEntrySourceManual,
+		}
+		require.NoError(t, entry.SetPeriod(start, start.Add(time.Hour)))
+		return entry
+	}
+
+	assert.NoError(t, valid().Validate())
+
+	running := valid()
+	running.Source, running.EndedAt = models.TimeEntrySourceTimer, nil
+	assert.NoError(t, running.Validate())
+
+	noEnd := valid()
+	noEnd.EndedAt = nil
+	assert.Error(t, noEnd.Validate(), "manual entries need an end")
+
+	noTask := valid()
+	noTask.TaskID = uuid.Nil
+	assert.Error(t, noTask.Validate())
+
+	negativeRate := valid()
+	negativeRate.HourlyRate = -1
+	assert.Error(t, negativeRate.Validate())
+
+	assert.Error(t, valid().SetPeriod(start, start), "empty period")
+	assert.Error(t, valid().SetPeriod(start, start.Add(25*time.Hour)), "longer than a day")
+}
+
+func TestBillableLineItems(t *testing.T) {
+	start := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC)
+	framing, wiring := uuid.New(), uuid.New()
+	invoiceID := uuid.New()
+
+	entry := func(task uuid.UUID, offset, minutes int, rate float64) *models.TimeEntry {
+		e := &models.TimeEntry{TaskID: task, Billable: true, HourlyRate: rate}
+		from := start.Add(time.Duration(offset) * time.Hour)
+		require.NoError(t, e.SetPeriod(from, from.Add(time.Duration(minutes)*time.Minute)))
+		return e
+	}
+
+	nonBillable := entry(framing, 3, 60, 40)
+	nonBillable.Billable = false
+	invoiced := entry(framing, 4, 60, 40)
+	invoiced.InvoiceID = &invoiceID
+	running := &models.TimeEntry{TaskID: framing, Billable: true, HourlyRate: 40, StartedAt: start}
+
+	items := models.BillableLineItems([]*models.TimeEntry{
+		entry(wiring, 2, 45, 60),
+		entry(framing, 0, 90, 40),
+		entry(framing, 1, 30, 40),
+		entry(framing, 5, 60, 55), // Overtime rate gets its own line
+		nonBillable,
+		invoiced,
+		running,
+	}, map[uuid.UUID]string{framing: "Framing", wiring: "Wiring"})
+
+	require.Len(t, items, 3)
+	assert.Equal(t, "Framing: 2.00 h @ 40.00/h", items[0].Description)
+	assert.Equal(t, 80.0, items[0].TotalPrice)
+	assert.Equal(t, 1, items[0].Quantity)
+	assert.Equal(t, "Wiring: 0.75 h @ 60.00/h", items[1].Description)
+	assert.Equal(t, 45.0, items[1].TotalPrice)
+	assert.Equal(t, "Framing: 1.00 h @ 55.00/h", items[2].Description)
+	assert.Equal(t, 55.0, items[2].UnitPrice)
+}
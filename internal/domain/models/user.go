@@ -73,6 +73,7 @@ type User struct {
 	TwoFactorSecret     string     `json:"-" gorm:"size:255"`
 	BackupCodes         []string   `json:"-" gorm:"type:text[]"`
 	LastLoginAt         *time.Time `json:"last_login_at,omitempty"`
+	LastMFAAt           *time.Time `json:"last_mfa_at,omitempty"` // Last seen signed in with a second factor
 	LastPasswordResetAt *time.Time `json:"last_password_reset_at,omitempty"`
 	PasswordChangedAt   *time.Time `json:"password_changed_at,omitempty"`
 	MustChangePassword  bool       `json:"must_change_password" gorm:"default:false"`
@@ -95,6 +96,7 @@ type User struct {
 	DataRetentionDays       int        `json:"data_retention_days" gorm:"default:730"`
 	MarkedForDeletion       bool       `json:"marked_for_deletion" gorm:"default:false;index"`
 	DeletionScheduledAt     *time.Time `json:"deletion_scheduled_at,omitempty"`
+	ErasedAt                *time.Time `json:"erased_at,omitempty"` // Personal data anonymized
 
 	// Profiles
 	ArtisanProfile  *Artisan  `json:"artisan_profile,omitempty" gorm:"foreignKey:UserID"`
@@ -103,6 +105,9 @@ type User struct {
 	// Metadata
 	Metadata JSONB `json:"metadata,omitempty" gorm:"type:jsonb"`
 
+	// Full-text search document of the name and contact details, maintained by Postgres
+	SearchVector string `json:"-" gorm:"->:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(first_name, '') || ' ' || coalesce(last_name, '')), 'A') || setweight(to_tsvector('simple', coalesce(email, '') || ' ' || coalesce(phone_number, '')), 'B')) STORED;index:idx_user_search,type:gin"`
+
 	// Relationships
 	Tenant           *Tenant         `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
 	OwnedTenants     []Tenant        `json:"owned_tenants,omitempty" gorm:"foreignKey:OwnerID"`
@@ -152,6 +157,11 @@ func (u *User) IsLocked() bool {
 	return u.LockedUntil != nil && time.Now().Before(*u.LockedUntil)
 }
 
+// IsErased reports whether the user's personal data has been anonymized
+func (u *User) IsErased() bool {
+	return u.ErasedAt != nil
+}
+
 func (u *User) RequiresMFA() bool {
 	return u.MFAEnabled || u.TwoFactorEnabled
 }
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VerificationDocumentType is what a verification document proves
+type VerificationDocumentType string
+
+const (
+	VerificationDocumentTypeID        VerificationDocumentType = "id"        // Government-issued identity document
+	VerificationDocumentTypeLicense   VerificationDocumentType = "license"   // Trade or professional license
+	VerificationDocumentTypeInsurance VerificationDocumentType = "insurance" // Liability insurance certificate
+)
+
+// IsValid checks if the type is one of the supported document types
+func (t VerificationDocumentType) IsValid() bool {
+	switch t {
+	case VerificationDocumentTypeID, VerificationDocumentTypeLicense, VerificationDocumentTypeInsurance:
+		return true
+	}
+	return false
+}
+
+// Label returns how the document type is named to people
+func (t VerificationDocumentType) Label() string {
+	switch t {
+	case VerificationDocumentTypeID:
+		return "identity document"
+	case VerificationDocumentTypeInsurance:
+		return "insurance certificate"
+	}
+	return string(t)
+}
+
+// RequiresExpiry reports whether documents of the type must be approved with an
+// expiry date; licenses and insurance lapse, identity documents may not
+func (t VerificationDocumentType) RequiresExpiry() bool {
+	return t == VerificationDocumentTypeLicense || t == VerificationDocumentTypeInsurance
+}
+
+// VerificationStatus is where a verification document is in review
+type VerificationStatus string
+
+const (
+	VerificationStatusPending  VerificationStatus = "pending"
+	VerificationStatusApproved VerificationStatus = "approved"
+	VerificationStatusRejected VerificationStatus = "rejected"
+	VerificationStatusExpired  VerificationStatus = "expired"
+)
+
+// IsValid checks if the status is one of the supported statuses
+func (s VerificationStatus) IsValid() bool {
+	switch s {
+	case VerificationStatusPending, VerificationStatusApproved, VerificationStatusRejected, VerificationStatusExpired:
+		return true
+	}
+	return false
+}
+
+// VerificationDocument is a document an artisan uploads to be verified. Tenant owners
+// and admins review pending documents; an approved document counts towards the
+// artisan's badges (see ArtisanBadges) until it expires.
+type VerificationDocument struct {
+	BaseModel
+
+	TenantID  uuid.UUID                `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	ArtisanID uuid.UUID                `json:"artisan_id" gorm:"type:uuid;not null;index"`
+	Type      VerificationDocumentType `json:"type" gorm:"type:varchar(20);not null"`
+
+	// Document details given by the artisan
+	Reference string `json:"reference,omitempty" gorm:"size:100"` // License or policy number
+	Issuer    string `json:"issuer,omitempty" gorm:"size:200"`
+
+	// Stored file
+	FileKey     string `json:"-" gorm:"size:500;not null"`
+	FileName    string `json:"file_name" gorm:"size:255;not null"`
+	ContentType string `json:"content_type" gorm:"size:100"`
+	FileSize    int64  `json:"file_size"`
+
+	// Review
+	Status          VerificationStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	ExpiresAt       *time.Time         `json:"expires_at,omitempty" gorm:"index"`
+	ReviewedByID    *uuid.UUID         `json:"reviewed_by_id,omitempty" gorm:"type:uuid"`
+	ReviewedAt      *time.Time         `json:"reviewed_at,omitempty"`
+	RejectionReason string             `json:"rejection_reason,omitempty" gorm:"type:text"`
+
+	// Relationships
+	Artisan *Artisan `json:"artisan,omitempty" gorm:"foreignKey:ArtisanID"`
+}
+
+// TableName specifies the table name for VerificationDocument
+func (VerificationDocument) TableName() string {
+	return "verification_documents"
+}
+
+// IsCurrent reports whether the document is approved and not yet expired at now
+func (d *VerificationDocument) IsCurrent(now time.Time) bool {
+	return d.Status == VerificationStatusApproved && (d.ExpiresAt == nil || now.Before(*d.ExpiresAt))
+}
+
+// Approve accepts a pending document, valid until expiresAt
+func (d *VerificationDocument) Approve(reviewerID uuid.UUID, expiresAt *time.Time, now time.Time) error {
+	if d.Status != VerificationStatusPending {
+		return errors.New("only pending documents can be approved")
+	}
+	if expiresAt == nil && d.Type.RequiresExpiry() {
+		return errors.New("licenses and insurance need an expiry date")
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return errors.New("expiry date must be in the future")
+	}
+
+	d.Status = VerificationStatusApproved
+	d.ExpiresAt = expiresAt
+	d.RejectionReason = ""
+	d.review(reviewerID, now)
+	return nil
+}
+
+// Reject turns down a pending document, telling the artisan why
+func (d *VerificationDocument) Reject(reviewerID uuid.UUID, reason string, now time.Time) error {
+	if d.Status != VerificationStatusPending {
+		return errors.New("only pending documents can be rejected")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("a rejection reason is required")
+	}
+
+	d.Status = VerificationStatusRejected
+	d.RejectionReason = reason
+	d.review(reviewerID, now)
+	return nil
+}
+
+// Expire marks an approved document whose expiry date has passed as expired
+func (d *VerificationDocument) Expire(now time.Time) bool {
+	if d.Status != VerificationStatusApproved || d.IsCurrent(now) {
+		return false
+	}
+	d.Status = VerificationStatusExpired
+	return true
+}
+
+func (d *VerificationDocument) review(reviewerID uuid.UUID, now time.Time) {
+	d.ReviewedByID = &reviewerID
+	d.ReviewedAt = &now
+}
+
+// Badges are what an artisan's current documents prove, shown on their profile
+type Badges struct {
+	Verified bool // A current identity document
+	Insured  bool // A current insurance certificate
+}
+
+// ArtisanBadges returns the badges an artisan's documents earn at now
+func ArtisanBadges(documents []*VerificationDocument, now time.Time) Badges {
+	var badges Badges
+	for _, document := range documents {
+		if !document.IsCurrent(now) {
+			continue
+		}
+		switch document.Type {
+		case VerificationDocumentTypeID:
+			badges.Verified = true
+		case VerificationDocumentTypeInsurance:
+			badges.Insured = true
+		}
+	}
+	return badges
+}
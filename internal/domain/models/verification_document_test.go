@@ -0,0 +1,85 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerificationDocumentReview(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	reviewerID := uuid.New()
+	nextYear := now.AddDate(1, 0, 0)
+
+	t.Run("licenses need an expiry date", func(t *testing.T) {
+		document := &models.VerificationDocument{Type: models.VerificationDocumentTypeLicense, Status: models.VerificationStatusPending}
+		assert.Error(t, document.Approve(reviewerID, nil, now))
+
+		past := now.Add(-time.Hour)
+		assert.Error(t, document.Approve(reviewerID, &past, now))
+
+		require.NoError(t, document.Approve(reviewerID, &nextYear, now))
+		assert.Equal(t, models.VerificationStatusApproved, document.Status)
+		assert.Equal(t, reviewerID, *document.ReviewedByID)
+		assert.True(t, document.IsCurrent(now))
+	})
+
+	t.Run("identity documents may not expire", func(t *testing.T) {
+		document := &models.VerificationDocument{Type: models.VerificationDocumentTypeID, Status: models.VerificationStatusPending}
+		require.NoError(t, document.Approve(reviewerID, nil, now))
+		assert.True(t, document.IsCurrent(now.AddDate(10, 0, 0)))
+	})
+
+	t.Run("rejection needs a reason", func(t *testing.T) {
+		document := &models.VerificationDocument{Type: models.VerificationDocumentTypeInsurance, Status: models.VerificationStatusPending}
+		assert.Error(t, document.Reject(reviewerID, "  ", now))
+
+		require.NoError(t, document.Reject(reviewerID, "Certificate is illegible", now))
+		assert.Equal(t, models.VerificationStatusRejected, document.Status)
+		assert.Error(t, document.Approve(reviewerID, &nextYear, now), "reviewed documents cannot be reviewed again")
+	})
+
+	t.Run("expire only lapsed approvals", func(t *testing.T) {
+		document := &models.VerificationDocument{Type: models.VerificationDocumentTypeInsurance, Status: models.VerificationStatusPending}
+		require.NoError(t, document.Approve(reviewerID, &nextYear, now))
+
+		assert.False(t, document.Expire(now))
+		assert.True(t, document.Expire(nextYear))
+		assert.Equal(t, models.VerificationStatusExpired, document.Status)
+		assert.False(t, document.Expire(nextYear), "already expired")
+	})
+}
+
+func TestArtisanBadges(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	lastMonth := now.AddDate(0, -1, 0)
+	nextMonth := now.AddDate(0, 1, 0)
+
+	identity := &models.VerificationDocument{Type: models.VerificationDocumentTypeID, Status: models.VerificationStatusApproved}
+	license := &models.VerificationDocument{Type: models.VerificationDocumentTypeLicense, Status: models.VerificationStatusApproved, ExpiresAt: &nextMonth}
+	insurance := &models.VerificationDocument{Type: models.VerificationDocumentTypeInsurance, Status: models.VerificationStatusApproved, ExpiresAt: &nextMonth}
+	lapsedInsurance := &models.VerificationDocument{Type: models.VerificationDocumentTypeInsurance, Status: models.VerificationStatusApproved, ExpiresAt: &lastMonth}
+	pendingIdentity := &models.VerificationDocument{Type: models.VerificationDocumentTypeID, Status: models.VerificationStatusPending}
+
+	tests := []struct {
+		name      string
+		documents []*models.VerificationDocument
+		want      models.Badges
+	}{
+		{"none", nil, models.Badges{}},
+		{"verified and insured", []*models.VerificationDocument{identity, insurance}, models.Badges{Verified: true, Insured: true}},
+		{"licenses earn no badge", []*models.VerificationDocument{license}, models.Badges{}},
+		{"lapsed insurance", []*models.VerificationDocument{identity, lapsedInsurance}, models.Badges{Verified: true}},
+		{"pending documents", []*models.VerificationDocument{pendingIdentity}, models.Badges{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, models.ArtisanBadges(tt.documents, now))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeliveryAttempt logs one attempt at delivering a webhook event, so tenants can
+// see why an endpoint failed without the platform's logs
+type WebhookDeliveryAttempt struct {
+	BaseModel
+
+	TenantID       uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	WebhookEventID uuid.UUID `json:"webhook_event_id" gorm:"type:uuid;not null;index"`
+
+	Attempt      int       `json:"attempt" gorm:"not null"`
+	AttemptedAt  time.Time `json:"attempted_at" gorm:"not null"`
+	DurationMs   int64     `json:"duration_ms"`
+	Succeeded    bool      `json:"succeeded" gorm:"default:false"`
+	ResponseCode int       `json:"response_code,omitempty"`
+	ResponseBody string    `json:"response_body,omitempty" gorm:"type:text"` // Truncated
+	Error        string    `json:"error,omitempty" gorm:"type:text"`
+}
+
+// TableName specifies the table name for WebhookDeliveryAttempt
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
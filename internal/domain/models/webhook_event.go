@@ -15,6 +15,8 @@ const (
 	WebhookEventPaymentReceived  WebhookEventType = "payment.received"
 	WebhookEventReviewCreated    WebhookEventType = "review.created"
 	WebhookEventUserCreated      WebhookEventType = "user.created"
+	WebhookEventProjectHighRisk  WebhookEventType = "project.high_risk"
+	WebhookEventProjectOverdue   WebhookEventType = "project.overdue"
 )
 
 type WebhookEvent struct {
@@ -23,6 +25,11 @@ type WebhookEvent struct {
 	// Multi-tenancy
 	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
 
+	// Subscription the event is delivered to and the domain event it reports. Both are
+	// unset for events queued to the tenant's legacy webhook URL, which are not signed.
+	SubscriptionID *uuid.UUID `json:"subscription_id,omitempty" gorm:"type:uuid;uniqueIndex:idx_webhook_subscription_event"`
+	DomainEventID  *uuid.UUID `json:"domain_event_id,omitempty" gorm:"type:uuid;uniqueIndex:idx_webhook_subscription_event"`
+
 	// Event Details
 	EventType WebhookEventType `json:"event_type" gorm:"type:varchar(100);not null;index" validate:"required"`
 	Payload   JSONB            `json:"payload" gorm:"type:jsonb;not null" validate:"required"`
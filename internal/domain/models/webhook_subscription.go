@@ -0,0 +1,57 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription is an endpoint of a tenant's system that receives the domain
+// events it subscribed to. Deliveries are signed with the subscription's secret so the
+// receiver can check they came from the platform.
+type WebhookSubscription struct {
+	BaseModel
+
+	TenantID uuid.UUID `json:"tenant_id" gorm:"type:uuid;not null;index"`
+
+	URL         string      `json:"url" gorm:"size:500;not null" validate:"required,url,max=500"`
+	Description string      `json:"description,omitempty" gorm:"size:255" validate:"max=255"`
+	Secret      string      `json:"-" gorm:"type:text;not null;serializer:encrypted_lookup"` // Encrypted at rest
+	EventTypes  StringArray `json:"event_types" gorm:"type:jsonb"`                           // Empty receives every event
+
+	IsActive bool `json:"is_active" gorm:"default:true;index"`
+
+	// Relationships
+	Tenant *Tenant `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+}
+
+// TableName specifies the table name for WebhookSubscription
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Validate checks the subscription values are consistent
+func (s *WebhookSubscription) Validate() error {
+	u, err := url.Parse(strings.TrimSpace(s.URL))
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if len(s.URL) > 500 {
+		return errors.New("url must be at most 500 characters")
+	}
+	if len(s.Description) > 255 {
+		return errors.New("description must be at most 255 characters")
+	}
+	if s.Secret == "" {
+		return errors.New("secret is required")
+	}
+	return nil
+}
+
+// Receives checks if the subscription is sent events of the given type
+func (s *WebhookSubscription) Receives(eventType string) bool {
+	return s.IsActive && (len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, eventType))
+}
@@ -0,0 +1,48 @@
+package models_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSubscription_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		valid bool
+	}{
+		{"https endpoint", "https://example.com/hooks", true},
+		{"http endpoint", "http://localhost:8080/hooks", true},
+		{"relative", "/hooks", false},
+		{"other scheme", "ftp://example.com/hooks", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := &models.WebhookSubscription{URL: tt.url, Secret: "whsec_test"}
+			if tt.valid {
+				assert.NoError(t, subscription.Validate())
+			} else {
+				assert.Error(t, subscription.Validate())
+			}
+		})
+	}
+
+	assert.Error(t, (&models.WebhookSubscription{URL: "https://example.com"}).Validate(), "a secret is required")
+}
+
+func TestWebhookSubscription_Receives(t *testing.T) {
+	all := &models.WebhookSubscription{IsActive: true}
+	assert.True(t, all.Receives("booking.created"))
+
+	payments := &models.WebhookSubscription{IsActive: true, EventTypes: models.StringArray{"payment.succeeded"}}
+	assert.True(t, payments.Receives("payment.succeeded"))
+	assert.False(t, payments.Receives("booking.created"))
+
+	disabled := &models.WebhookSubscription{}
+	assert.False(t, disabled.Receives("booking.created"))
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -74,6 +75,14 @@ type WhiteLabel struct {
 	// Email Branding
 	EmailSettings EmailBranding `json:"email_settings" gorm:"type:jsonb"`
 
+	// Email Sender Domain, which emails are only sent from once it publishes the DKIM
+	// key they are signed with
+	EmailSenderDomain     string     `json:"email_sender_domain,omitempty" gorm:"size:255"`
+	DKIMSelector          string     `json:"dkim_selector,omitempty" gorm:"size:63"`
+	DKIMPublicKey         string     `json:"-" gorm:"type:text"`
+	DKIMPrivateKey        string     `json:"-" gorm:"type:text;serializer:encrypted_lookup"` // Encrypted at rest
+	EmailDomainVerifiedAt *time.Time `json:"email_domain_verified_at,omitempty"`
+
 	// Advanced Customization
 	CustomCSS       string     `json:"custom_css,omitempty" gorm:"type:text"`
 	CustomJS        string     `json:"custom_js,omitempty" gorm:"type:text"`
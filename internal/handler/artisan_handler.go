@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"Krafti_Vibe/internal/service"
 	"Krafti_Vibe/internal/service/dto"
@@ -342,17 +344,20 @@ func (h *ArtisanHandler) GetTopRatedArtisans(c *fiber.Ctx) error {
 
 // FindNearbyArtisans godoc
 // @Summary Find nearby artisans
-// @Description Find artisans near a specific location
+// @Description Find artisans located within a radius of a point, or inside a bounding box such as a map's viewport, the nearest to the point first
 // @Tags artisans
 // @Produce json
 // @Param tenant_id query string true "Tenant ID"
 // @Param latitude query number true "Latitude"
 // @Param longitude query number true "Longitude"
-// @Param radius query int false "Radius in km" default(10)
-// @Success 200 {object} SuccessResponse
+// @Param radius query number false "Radius in km, ignored with bounds" default(10)
+// @Param bounds query string false "Bounding box as south,west,north,east"
+// @Param available_only query bool false "Only artisans taking bookings" default(false)
+// @Param limit query int false "Maximum number of artisans, at most 100" default(20)
+// @Success 200 {array} dto.NearbyArtisanResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /artisans/nearby [get]
+// @Router /artisans/nearby [post]
 func (h *ArtisanHandler) FindNearbyArtisans(c *fiber.Ctx) error {
 	tenantID, err := uuid.Parse(c.Query("tenant_id"))
 	if err != nil {
@@ -376,9 +381,22 @@ func (h *ArtisanHandler) FindNearbyArtisans(c *fiber.Ctx) error {
 		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_LONGITUDE", "Invalid longitude value", err)
 	}
 
-	radiusKm := getIntQuery(c, "radius", 10)
+	req := &dto.NearbyArtisansRequest{
+		TenantID:      tenantID,
+		Latitude:      latitude,
+		Longitude:     longitude,
+		RadiusKm:      getFloatQuery(c, "radius", 10),
+		AvailableOnly: getBoolQuery(c, "available_only", false),
+		Limit:         getIntQuery(c, "limit", 20),
+	}
+	if bounds := c.Query("bounds"); bounds != "" {
+		req.Bounds, err = parseGeoBounds(bounds)
+		if err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_BOUNDS", "Bounds must be south,west,north,east", err)
+		}
+	}
 
-	artisans, err := h.artisanService.FindNearbyArtisans(c.Context(), tenantID, latitude, longitude, radiusKm)
+	artisans, err := h.artisanService.FindNearbyArtisans(c.Context(), req)
 	if err != nil {
 		return HandleServiceError(c, err)
 	}
@@ -386,6 +404,24 @@ func (h *ArtisanHandler) FindNearbyArtisans(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, artisans)
 }
 
+// parseGeoBounds parses a bounding box given as south,west,north,east
+func parseGeoBounds(value string) (*dto.GeoBounds, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("expected 4 numbers, got %d", len(parts))
+	}
+
+	var edges [4]float64
+	for i, part := range parts {
+		edge, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		edges[i] = edge
+	}
+	return &dto.GeoBounds{South: edges[0], West: edges[1], North: edges[2], East: edges[3]}, nil
+}
+
 // ============================================================================
 // Availability Management
 // ============================================================================
@@ -420,6 +456,47 @@ func (h *ArtisanHandler) UpdateAvailability(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, nil, "Availability updated successfully")
 }
 
+// UpdateTier godoc
+// @Summary Update artisan tier
+// @Description Move an artisan to another tier, which selects the tenant's commission rules for their payments
+// @Tags artisans
+// @Accept json
+// @Produce json
+// @Param id path string true "Artisan ID"
+// @Param tier body dto.UpdateArtisanTierRequest true "Tier"
+// @Success 200 {object} dto.ArtisanResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /artisans/{id}/tier [put]
+func (h *ArtisanHandler) UpdateTier(c *fiber.Ctx) error {
+	artisanID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid artisan ID", err)
+	}
+
+	var req dto.UpdateArtisanTierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	existing, err := h.artisanService.GetArtisan(c.Context(), artisanID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, existing.TenantID); err != nil {
+		return err
+	}
+
+	artisan, err := h.artisanService.UpdateTier(c.Context(), artisanID, req.Tier)
+	if err != nil {
+		LogHandlerError(c, "update_artisan_tier", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, artisan, "Artisan tier updated successfully")
+}
+
 // BatchUpdateAvailability godoc
 // @Summary Batch update availability
 // @Description Update availability for multiple artisans
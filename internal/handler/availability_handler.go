@@ -41,10 +41,7 @@ func (h *AvailabilityHandler) CreateAvailability(c *fiber.Ctx) error {
 
 	var req dto.CreateAvailabilitySlotRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	availability, err := h.service.CreateAvailability(c.Context(), authCtx.TenantID, &req)
@@ -71,10 +68,7 @@ func (h *AvailabilityHandler) GetAvailability(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid availability ID",
-			"code":  "INVALID_AVAILABILITY_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_AVAILABILITY_ID", "invalid availability ID", nil)
 	}
 
 	availability, err := h.service.GetAvailability(c.Context(), id, authCtx.TenantID)
@@ -103,18 +97,12 @@ func (h *AvailabilityHandler) UpdateAvailability(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid availability ID",
-			"code":  "INVALID_AVAILABILITY_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_AVAILABILITY_ID", "invalid availability ID", nil)
 	}
 
 	var req dto.UpdateAvailabilitySlotRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	availability, err := h.service.UpdateAvailability(c.Context(), id, authCtx.TenantID, &req)
@@ -141,10 +129,7 @@ func (h *AvailabilityHandler) DeleteAvailability(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid availability ID",
-			"code":  "INVALID_AVAILABILITY_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_AVAILABILITY_ID", "invalid availability ID", nil)
 	}
 
 	if err := h.service.DeleteAvailability(c.Context(), id, authCtx.TenantID); err != nil {
@@ -177,18 +162,12 @@ func (h *AvailabilityHandler) ListAvailabilities(c *fiber.Ctx) error {
 
 	artisanIDStr := c.Query("artisan_id")
 	if artisanIDStr == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "artisan_id is required",
-			"code":  "MISSING_ARTISAN_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_ARTISAN_ID", "artisan_id is required", nil)
 	}
 
 	artisanID, err := uuid.Parse(artisanIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid artisan_id",
-			"code":  "INVALID_ARTISAN_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ARTISAN_ID", "invalid artisan_id", nil)
 	}
 
 	page := c.QueryInt("page", 1)
@@ -252,10 +231,7 @@ func (h *AvailabilityHandler) ListByType(c *fiber.Ctx) error {
 
 	artisanID, err := uuid.Parse(c.Params("artisan_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid artisan_id",
-			"code":  "INVALID_ARTISAN_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ARTISAN_ID", "invalid artisan_id", nil)
 	}
 
 	availType := models.AvailabilityType(c.Params("type"))
@@ -286,18 +262,12 @@ func (h *AvailabilityHandler) GetByDayOfWeek(c *fiber.Ctx) error {
 
 	artisanID, err := uuid.Parse(c.Params("artisan_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid artisan_id",
-			"code":  "INVALID_ARTISAN_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ARTISAN_ID", "invalid artisan_id", nil)
 	}
 
 	dayOfWeek, err := c.ParamsInt("day")
 	if err != nil || dayOfWeek < 0 || dayOfWeek > 6 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid day of week (must be 0-6)",
-			"code":  "INVALID_DAY_OF_WEEK",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DAY_OF_WEEK", "invalid day of week (must be 0-6)", nil)
 	}
 
 	availabilities, err := h.service.GetByDayOfWeek(c.Context(), artisanID, dayOfWeek, authCtx.TenantID)
@@ -324,10 +294,7 @@ func (h *AvailabilityHandler) GetWeeklySchedule(c *fiber.Ctx) error {
 
 	artisanID, err := uuid.Parse(c.Params("artisan_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid artisan_id",
-			"code":  "INVALID_ARTISAN_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ARTISAN_ID", "invalid artisan_id", nil)
 	}
 
 	// Parse week start date or use current week
@@ -335,10 +302,7 @@ func (h *AvailabilityHandler) GetWeeklySchedule(c *fiber.Ctx) error {
 	if weekStartStr := c.Query("week_start"); weekStartStr != "" {
 		weekStart, err = time.Parse("2006-01-02", weekStartStr)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid week_start date format (use YYYY-MM-DD)",
-				"code":  "INVALID_DATE_FORMAT",
-			})
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DATE_FORMAT", "invalid week_start date format (use YYYY-MM-DD)", nil)
 		}
 	} else {
 		// Default to start of current week (Sunday)
@@ -371,10 +335,7 @@ func (h *AvailabilityHandler) CheckAvailability(c *fiber.Ctx) error {
 
 	var req dto.CheckAvailabilitySlotRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	result, err := h.service.CheckAvailability(c.Context(), &req, authCtx.TenantID)
@@ -402,10 +363,7 @@ func (h *AvailabilityHandler) BulkCreateAvailability(c *fiber.Ctx) error {
 
 	var req dto.BulkCreateAvailabilitySlotRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	availabilities, err := h.service.BulkCreateAvailability(c.Context(), authCtx.TenantID, &req)
@@ -432,10 +390,7 @@ func (h *AvailabilityHandler) DeleteByType(c *fiber.Ctx) error {
 
 	artisanID, err := uuid.Parse(c.Params("artisan_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid artisan_id",
-			"code":  "INVALID_ARTISAN_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ARTISAN_ID", "invalid artisan_id", nil)
 	}
 
 	availType := models.AvailabilityType(c.Params("type"))
@@ -0,0 +1,342 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// BookingExportHandler handles HTTP requests for booking exports
+type BookingExportHandler struct {
+	exportService service.BookingExportService
+}
+
+// NewBookingExportHandler creates a new booking export handler
+func NewBookingExportHandler(exportService service.BookingExportService) *BookingExportHandler {
+	if exportService == nil {
+		panic("booking export service cannot be nil")
+	}
+	return &BookingExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportBookings godoc
+// @Summary Export bookings
+// @Description Export every booking matching the filters as CSV or XLSX. List filters take comma-separated values; dates accept RFC3339 or YYYY-MM-DD. Small exports complete immediately with a signed download URL; large exports or async=true return 202, are generated in the background and the requester is emailed when the file is ready.
+// @Tags bookings
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "File format: csv or xlsx" default(csv)
+// @Param async query bool false "Force background generation"
+// @Param artisan_ids query string false "Comma-separated artisan IDs"
+// @Param customer_ids query string false "Comma-separated customer IDs"
+// @Param service_ids query string false "Comma-separated service IDs"
+// @Param statuses query string false "Comma-separated booking statuses"
+// @Param payment_statuses query string false "Comma-separated payment statuses"
+// @Param start_date query string false "Bookings starting at or after"
+// @Param end_date query string false "Bookings starting at or before"
+// @Param min_duration query int false "Minimum duration in minutes"
+// @Param max_duration query int false "Maximum duration in minutes"
+// @Param min_amount query number false "Minimum total price"
+// @Param max_amount query number false "Maximum total price"
+// @Param has_deposit query bool false "Only bookings with (or without) a deposit"
+// @Param is_recurring query bool false "Only recurring (or one-off) bookings"
+// @Param has_photos query bool false "Only bookings with (or without) photos"
+// @Param has_location query bool false "Only bookings with (or without) a service location"
+// @Param created_after query string false "Created at or after"
+// @Param created_before query string false "Created at or before"
+// @Param updated_after query string false "Updated at or after"
+// @Param updated_before query string false "Updated at or before"
+// @Param tags query string false "Comma-separated tags; bookings must carry all of them"
+// @Param any_tags query string false "Comma-separated tags; bookings must carry at least one"
+// @Param customer_segments query string false "Comma-separated customer segments (new, repeat, vip, at_risk); the customer must be in at least one"
+// @Param customer_tags query string false "Comma-separated customer tags; the customer must carry all of them"
+// @Param search query string false "Search booking notes"
+// @Success 200 {object} dto.BookingExportResponse
+// @Success 202 {object} dto.BookingExportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bookings/export [get]
+func (h *BookingExportHandler) ExportBookings(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseBookingExportFilter(c)
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER", err.Error(), nil)
+	}
+
+	req := &dto.BookingExportRequest{
+		TenantID:    authCtx.TenantID,
+		RequestedBy: authCtx.UserID,
+		BaseURL:     c.BaseURL(),
+		Format:      models.BookingExportFormat(strings.ToLower(c.Query("format"))),
+		Async:       getBoolQuery(c, "async", false),
+		Filter:      filter,
+	}
+
+	result, err := h.exportService.ExportBookings(c.Context(), req)
+	if err != nil {
+		LogHandlerError(c, "export_bookings", err)
+		return HandleServiceError(c, err)
+	}
+
+	SetNoCacheHeaders(c)
+	if result.Status != models.BookingExportStatusCompleted && result.Status != models.BookingExportStatusFailed {
+		c.Set(fiber.HeaderLocation, c.BaseURL()+"/api/v1/bookings/exports/"+result.ID.String())
+		return c.Status(fiber.StatusAccepted).JSON(SuccessResponse{
+			Success: true,
+			Message: "Export accepted; you will be emailed when the file is ready",
+			Data:    result,
+		})
+	}
+
+	return NewSuccessResponse(c, result)
+}
+
+// GetExport godoc
+// @Summary Get booking export
+// @Description Get the status of a booking export and, once completed, a fresh signed download URL
+// @Tags bookings
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Export ID"
+// @Success 200 {object} dto.BookingExportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bookings/exports/{id} [get]
+func (h *BookingExportHandler) GetExport(c *fiber.Ctx) error {
+	exportID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.exportService.GetExport(c.Context(), exportID, tenantID, c.BaseURL())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetNoCacheHeaders(c)
+	return NewSuccessResponse(c, result)
+}
+
+// DownloadExport godoc
+// @Summary Download booking export
+// @Description Download a generated export file. Authenticated by the signed URL returned from the export endpoints.
+// @Tags bookings
+// @Produce octet-stream
+// @Param id path string true "Export ID"
+// @Param expires query int true "Link expiry (Unix seconds)"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bookings/exports/{id}/download [get]
+func (h *BookingExportHandler) DownloadExport(c *fiber.Ctx) error {
+	exportID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	signature := c.Query("signature")
+	if err != nil || signature == "" {
+		return NewErrorResponse(c, fiber.StatusForbidden, "INVALID_DOWNLOAD_LINK", "Invalid download link", nil)
+	}
+
+	download, err := h.exportService.OpenDownload(c.Context(), exportID, expires, signature)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetNoCacheHeaders(c)
+	c.Set(fiber.HeaderContentType, download.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, download.FileName))
+	// Fiber closes the stream once the response has been written
+	return c.SendStream(download.Content, int(download.Size))
+}
+
+// parseBookingExportFilter reads every BookingFilter criterion from the query string
+func parseBookingExportFilter(c *fiber.Ctx) (dto.BookingFilter, error) {
+	var (
+		filter dto.BookingFilter
+		err    error
+	)
+
+	if filter.ArtisanIDs, err = parseUUIDListQuery(c, "artisan_ids"); err != nil {
+		return filter, err
+	}
+	if filter.CustomerIDs, err = parseUUIDListQuery(c, "customer_ids"); err != nil {
+		return filter, err
+	}
+	if filter.ServiceIDs, err = parseUUIDListQuery(c, "service_ids"); err != nil {
+		return filter, err
+	}
+
+	validStatuses := []string{"pending", "confirmed", "in_progress", "completed", "cancelled", "no_show", "refunded"}
+	for _, status := range splitListQuery(c, "statuses") {
+		if ValidateEnum("statuses", status, validStatuses) != nil {
+			return filter, fmt.Errorf("invalid statuses: %q is not a booking status", status)
+		}
+		filter.Statuses = append(filter.Statuses, models.BookingStatus(status))
+	}
+
+	validPaymentStatuses := []string{"pending", "processing", "paid", "failed", "cancelled", "refunded", "partial_refund"}
+	for _, status := range splitListQuery(c, "payment_statuses") {
+		if ValidateEnum("payment_statuses", status, validPaymentStatuses) != nil {
+			return filter, fmt.Errorf("invalid payment_statuses: %q is not a payment status", status)
+		}
+		filter.PaymentStatuses = append(filter.PaymentStatuses, models.PaymentStatus(status))
+	}
+
+	times := []struct {
+		key    string
+		target **time.Time
+	}{
+		{"start_date", &filter.StartDate},
+		{"end_date", &filter.EndDate},
+		{"created_after", &filter.CreatedAfter},
+		{"created_before", &filter.CreatedBefore},
+		{"updated_after", &filter.UpdatedAfter},
+		{"updated_before", &filter.UpdatedBefore},
+	}
+	for _, t := range times {
+		if *t.target, err = parseTimeQuery(c, t.key); err != nil {
+			return filter, err
+		}
+	}
+
+	if filter.MinDuration, err = parseOptionalIntQuery(c, "min_duration"); err != nil {
+		return filter, err
+	}
+	if filter.MaxDuration, err = parseOptionalIntQuery(c, "max_duration"); err != nil {
+		return filter, err
+	}
+	if filter.MinAmount, err = parseOptionalFloatQuery(c, "min_amount"); err != nil {
+		return filter, err
+	}
+	if filter.MaxAmount, err = parseOptionalFloatQuery(c, "max_amount"); err != nil {
+		return filter, err
+	}
+
+	flags := []struct {
+		key    string
+		target **bool
+	}{
+		{"has_deposit", &filter.HasDeposit},
+		{"is_recurring", &filter.IsRecurring},
+		{"has_photos", &filter.HasPhotos},
+		{"has_location", &filter.HasLocation},
+	}
+	for _, f := range flags {
+		if *f.target, err = parseOptionalBoolQuery(c, f.key); err != nil {
+			return filter, err
+		}
+	}
+
+	filter.Tags = splitListQuery(c, "tags")
+	filter.AnyTags = splitListQuery(c, "any_tags")
+	filter.CustomerSegments = parseCustomerSegmentsQuery(c, "customer_segments")
+	filter.CustomerTags = splitListQuery(c, "customer_tags")
+	filter.SearchQuery = strings.TrimSpace(c.Query("search"))
+	return filter, nil
+}
+
+// splitListQuery splits a comma-separated query parameter, dropping empty entries
+func splitListQuery(c *fiber.Ctx, key string) []string {
+	var values []string
+	for _, value := range strings.Split(c.Query(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseCustomerSegmentsQuery splits a comma-separated list of customer segments; the
+// filter validates them
+func parseCustomerSegmentsQuery(c *fiber.Ctx, key string) []models.CustomerSegment {
+	var segments []models.CustomerSegment
+	for _, value := range splitListQuery(c, key) {
+		segments = append(segments, models.CustomerSegment(value))
+	}
+	return segments
+}
+
+func parseUUIDListQuery(c *fiber.Ctx, key string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, value := range splitListQuery(c, key) {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %q is not a valid ID", key, value)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseTimeQuery accepts RFC3339 timestamps or YYYY-MM-DD dates (midnight UTC)
+func parseTimeQuery(c *fiber.Ctx, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid %s: use RFC3339 or YYYY-MM-DD", key)
+}
+
+func parseOptionalIntQuery(c *fiber.Ctx, key string) (*int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: must be an integer", key)
+	}
+	return &n, nil
+}
+
+func parseOptionalFloatQuery(c *fiber.Ctx, key string) (*float64, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: must be a number", key)
+	}
+	return &f, nil
+}
+
+func parseOptionalBoolQuery(c *fiber.Ctx, key string) (*bool, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: must be true or false", key)
+	}
+	return &b, nil
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
@@ -35,7 +36,7 @@ func NewBookingHandler(bookingService service.BookingService) *BookingHandler {
 
 // CreateBooking godoc
 // @Summary Create a new booking
-// @Description Create a new booking with enterprise-grade validation and security
+// @Description Create a new booking with enterprise-grade validation and security. Tenant owners and admins can set override_availability to double-book a conflicting slot; the conflict is recorded in the booking metadata and the audit log.
 // @Tags bookings
 // @Accept json
 // @Produce json
@@ -46,6 +47,7 @@ func NewBookingHandler(bookingService service.BookingService) *BookingHandler {
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /bookings [post]
 func (h *BookingHandler) CreateBooking(c *fiber.Ctx) error {
@@ -170,6 +172,86 @@ func (h *BookingHandler) GetBooking(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, booking)
 }
 
+// LinkBookingProject godoc
+// @Summary Link booking to project
+// @Description Link a booking, such as a site visit or installation appointment, to a project of the same customer. A null project_id unlinks it. The change is recorded in the booking's history.
+// @Tags bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Param link body dto.LinkBookingProjectRequest true "Project to link"
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /bookings/{id}/project [put]
+func (h *BookingHandler) LinkBookingProject(c *fiber.Ctx) error {
+	bookingID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.LinkBookingProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	booking, err := h.bookingService.LinkBookingProject(c.Context(), tenantID, bookingID, &req)
+	if err != nil {
+		LogHandlerError(c, "link_booking_project", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, booking, "Booking project updated")
+}
+
+// GetBookingHistory godoc
+// @Summary Get booking history
+// @Description Get the ordered change log of a booking with actor, before/after values and source (api, admin, system, import)
+// @Tags bookings
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {array} dto.BookingEventResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bookings/{id}/history [get]
+func (h *BookingHandler) GetBookingHistory(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	bookingID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	booking, err := h.bookingService.GetBooking(c.Context(), bookingID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if booking.TenantID != authCtx.TenantID {
+		return NewForbiddenResponse(c, "You don't have access to this booking")
+	}
+
+	history, err := h.bookingService.GetBookingHistory(c.Context(), bookingID)
+	if err != nil {
+		LogHandlerError(c, "get_booking_history", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, history)
+}
+
 // UpdateBooking godoc
 // @Summary Update booking
 // @Description Update booking information
@@ -232,10 +314,17 @@ func (h *BookingHandler) DeleteBooking(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param page query int false "Page number (min: 1)" default(1)
 // @Param page_size query int false "Page size (min: 1, max: 100)" default(20)
+// @Param cursor query string false "Keyset cursor from a previous response (next_cursor); overrides page"
 // @Param tenant_id query string false "Filter by tenant ID (must match authenticated tenant)"
 // @Param artisan_id query string false "Filter by artisan ID"
 // @Param customer_id query string false "Filter by customer ID"
+// @Param project_id query string false "Filter by linked project ID"
 // @Param status query string false "Filter by status (pending, confirmed, in_progress, completed, cancelled)"
+// @Param tags query string false "Comma-separated tags; bookings must carry all of them"
+// @Param any_tags query string false "Comma-separated tags; bookings must carry at least one"
+// @Param customer_segments query string false "Comma-separated customer segments (new, repeat, vip, at_risk); the customer must be in at least one"
+// @Param customer_tags query string false "Comma-separated customer tags; the customer must carry all of them"
+// @Param filter_id query string false "Apply one of your saved filters; other query parameters take precedence"
 // @Param sort_by query string false "Sort field" default(created_at)
 // @Param sort_order query string false "Sort order (asc, desc)" default(desc)
 // @Success 200 {object} dto.BookingListResponse
@@ -262,9 +351,15 @@ func (h *BookingHandler) ListBookings(c *fiber.Ctx) error {
 		getIntQuery(c, "page_size", 20),
 	)
 
+	cursor, ok := ParseCursorQuery(c)
+	if !ok {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CURSOR", "Invalid pagination cursor", nil)
+	}
+
 	filter := dto.BookingFilter{
 		Page:     page,
 		PageSize: pageSize,
+		Cursor:   cursor,
 	}
 
 	// Set tenant ID for tenant isolation (if user belongs to a tenant)
@@ -312,10 +407,20 @@ func (h *BookingHandler) ListBookings(c *fiber.Ctx) error {
 		filter.CustomerIDs = []uuid.UUID{customerID}
 	}
 
+	// Parse project ID if provided
+	if projectIDStr := c.Query("project_id"); projectIDStr != "" {
+		projectID, err := uuid.Parse(projectIDStr)
+		if err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROJECT_ID",
+				"Invalid project ID format", err)
+		}
+		filter.ProjectIDs = []uuid.UUID{projectID}
+	}
+
 	// Parse and validate status filter
 	if statusStr := c.Query("status"); statusStr != "" {
 		// Validate status is one of the allowed values
-		validStatuses := []string{"pending", "confirmed", "in_progress", "completed", "cancelled", "no_show"}
+		validStatuses := []string{"pending", "confirmed", "in_progress", "completed", "cancelled", "no_show", "refunded"}
 		statusErr := ValidateEnum("status", statusStr, validStatuses)
 		if statusErr != nil {
 			return NewValidationErrorResponse(c, []ValidationError{*statusErr})
@@ -325,6 +430,22 @@ func (h *BookingHandler) ListBookings(c *fiber.Ctx) error {
 		filter.Statuses = []models.BookingStatus{status}
 	}
 
+	filter.Tags = splitListQuery(c, "tags")
+	filter.AnyTags = splitListQuery(c, "any_tags")
+	filter.CustomerSegments = parseCustomerSegmentsQuery(c, "customer_segments")
+	filter.CustomerTags = splitListQuery(c, "customer_tags")
+
+	// Resolve a saved filter; the service checks it belongs to the user
+	if filterIDStr := c.Query("filter_id"); filterIDStr != "" {
+		filterID, err := uuid.Parse(filterIDStr)
+		if err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER_ID",
+				"Invalid filter ID format", err)
+		}
+		filter.SavedFilterID = &filterID
+		filter.UserID = authCtx.UserID
+	}
+
 	// Extract sort parameters
 	sortBy, sortOrder := ExtractSortParams(c, []string{"created_at", "scheduled_at", "updated_at", "status"})
 
@@ -547,6 +668,31 @@ func (h *BookingHandler) CancelBooking(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, booking, "Booking cancelled successfully")
 }
 
+// RetryCancellationRefund godoc
+// @Summary Retry cancellation refund
+// @Description Issue the part of a cancelled booking's refund that failed
+// @Tags bookings
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bookings/{id}/retry-refund [post]
+func (h *BookingHandler) RetryCancellationRefund(c *fiber.Ctx) error {
+	bookingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid booking ID", err)
+	}
+
+	booking, err := h.bookingService.RetryCancellationRefund(c.Context(), bookingID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, booking, "Cancellation refund issued")
+}
+
 // MarkAsNoShow godoc
 // @Summary Mark booking as no-show
 // @Description Mark a booking as no-show
@@ -601,6 +747,84 @@ func (h *BookingHandler) RescheduleBooking(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, booking, "Booking rescheduled successfully")
 }
 
+// SkipOccurrence godoc
+// @Summary Skip recurring occurrence
+// @Description Cancel one occurrence of a recurring series while keeping the rest. The skipped date is recorded on the series so it is not recreated.
+// @Tags bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Occurrence booking ID"
+// @Param skip body dto.SkipOccurrenceRequest true "Skip data"
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /bookings/{id}/skip-occurrence [post]
+func (h *BookingHandler) SkipOccurrence(c *fiber.Ctx) error {
+	bookingID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.SkipOccurrenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.SkippedBy = authCtx.UserID
+
+	booking, err := h.bookingService.SkipOccurrence(c.Context(), bookingID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, booking, "Occurrence skipped successfully")
+}
+
+// MoveOccurrence godoc
+// @Summary Move recurring occurrence
+// @Description Reschedule one occurrence of a recurring series. The occurrence is detached so series-wide updates no longer change it; the other occurrences keep their schedule.
+// @Tags bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Occurrence booking ID"
+// @Param move body dto.RescheduleBookingRequest true "New time"
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /bookings/{id}/move-occurrence [post]
+func (h *BookingHandler) MoveOccurrence(c *fiber.Ctx) error {
+	bookingID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.MoveOccurrenceRequest
+	if err := c.BodyParser(&req.RescheduleBookingRequest); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.MovedBy = authCtx.UserID
+
+	booking, err := h.bookingService.MoveOccurrence(c.Context(), bookingID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, booking, "Occurrence moved successfully")
+}
+
 // ============================================================================
 // Scheduling & Availability
 // ============================================================================
@@ -959,52 +1183,101 @@ func (h *BookingHandler) GetArtisanBookingStats(c *fiber.Ctx) error {
 
 // BulkConfirm godoc
 // @Summary Bulk confirm bookings
-// @Description Confirm multiple bookings at once
+// @Description Confirm multiple bookings at once. Each booking is validated separately; valid ones are confirmed even if others fail.
 // @Tags bookings
 // @Accept json
 // @Produce json
 // @Param bulk body BulkBookingRequest true "Booking IDs"
-// @Success 200 {object} SuccessResponse
+// @Success 200 {object} dto.BulkBookingResult
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /bookings/bulk/confirm [post]
 func (h *BookingHandler) BulkConfirm(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
 	var req BulkBookingRequest
 	if err := c.BodyParser(&req); err != nil {
 		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
 	}
 
-	bookings, err := h.bookingService.BulkConfirm(c.Context(), req.BookingIDs)
+	result, err := h.bookingService.BulkConfirm(c.Context(), tenantID, req.BookingIDs)
 	if err != nil {
 		return HandleServiceError(c, err)
 	}
 
-	return NewSuccessResponse(c, bookings, "Bookings confirmed successfully")
+	return NewSuccessResponse(c, result, bulkResultMessage("confirmed", result))
 }
 
 // BulkCancel godoc
 // @Summary Bulk cancel bookings
-// @Description Cancel multiple bookings at once
+// @Description Cancel multiple bookings at once. Each booking is validated separately; valid ones are cancelled even if others fail.
 // @Tags bookings
 // @Accept json
 // @Produce json
 // @Param bulk body BulkCancelRequest true "Booking IDs and reason"
-// @Success 200 {object} SuccessResponse
+// @Success 200 {object} dto.BulkBookingResult
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /bookings/bulk/cancel [post]
 func (h *BookingHandler) BulkCancel(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
 	var req BulkCancelRequest
 	if err := c.BodyParser(&req); err != nil {
 		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
 	}
 
-	bookings, err := h.bookingService.BulkCancel(c.Context(), req.BookingIDs, req.Reason)
+	result, err := h.bookingService.BulkCancel(c.Context(), tenantID, req.BookingIDs, req.Reason)
 	if err != nil {
 		return HandleServiceError(c, err)
 	}
 
-	return NewSuccessResponse(c, bookings, "Bookings cancelled successfully")
+	return NewSuccessResponse(c, result, bulkResultMessage("cancelled", result))
+}
+
+// BulkUpdateStatus godoc
+// @Summary Bulk update booking status
+// @Description Move multiple bookings to a status at once. Each booking is checked against the tenant's transition rules; valid ones are updated even if others fail.
+// @Tags bookings
+// @Accept json
+// @Produce json
+// @Param bulk body BulkStatusRequest true "Booking IDs and target status"
+// @Success 200 {object} dto.BulkBookingResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bookings/bulk/status [post]
+func (h *BookingHandler) BulkUpdateStatus(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req BulkStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	validStatuses := []string{"pending", "confirmed", "in_progress", "completed", "cancelled", "no_show", "refunded"}
+	if err := ValidateEnum("status", string(req.Status), validStatuses); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_STATUS", "Invalid booking status", nil)
+	}
+
+	result, err := h.bookingService.BulkUpdateStatus(c.Context(), tenantID, req.BookingIDs, req.Status)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result, bulkResultMessage("updated", result))
+}
+
+// bulkResultMessage summarises a partially successful bulk operation
+func bulkResultMessage(verb string, result *dto.BulkBookingResult) string {
+	return fmt.Sprintf("%d bookings %s, %d failed", len(result.Succeeded), verb, len(result.Failed))
 }
 
 // ============================================================================
@@ -1023,3 +1296,8 @@ type BulkCancelRequest struct {
 	BookingIDs []uuid.UUID `json:"booking_ids"`
 	Reason     string      `json:"reason"`
 }
+
+type BulkStatusRequest struct {
+	BookingIDs []uuid.UUID          `json:"booking_ids"`
+	Status     models.BookingStatus `json:"status"`
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"path/filepath"
+	"strings"
+
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxBookingImportSize caps uploaded import files; it must stay within the server body limit
+const maxBookingImportSize = 10 * 1024 * 1024
+
+// BookingImportHandler handles HTTP requests for bulk booking imports
+type BookingImportHandler struct {
+	importService service.BookingImportService
+}
+
+// NewBookingImportHandler creates a new booking import handler
+func NewBookingImportHandler(importService service.BookingImportService) *BookingImportHandler {
+	if importService == nil {
+		panic("booking import service cannot be nil")
+	}
+	return &BookingImportHandler{
+		importService: importService,
+	}
+}
+
+// ImportBookings godoc
+// @Summary Import bookings from CSV
+// @Description Upload a CSV of bookings. Required columns: customer_email, artisan_email, start_time and service_id or service_name. Optional: end_time, duration, status, payment_status, base_price, total_price, deposit_paid, currency, notes, internal_notes. Small files are processed immediately; large files or async=true return 202 and are processed in the background.
+// @Tags bookings
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file"
+// @Param dry_run query bool false "Validate only without saving"
+// @Param async query bool false "Force background processing"
+// @Param timezone query string false "IANA timezone for times without an offset (default UTC)"
+// @Success 200 {object} dto.BookingImportResponse
+// @Success 202 {object} dto.BookingImportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bookings/import [post]
+func (h *BookingImportHandler) ImportBookings(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_FILE", "A CSV file is required in the 'file' field", err)
+	}
+	if fileHeader.Size > maxBookingImportSize {
+		return NewErrorResponse(c, fiber.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "Import files are limited to 10MB", nil)
+	}
+	if ext := strings.ToLower(filepath.Ext(fileHeader.Filename)); ext != ".csv" && ext != ".txt" {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "UNSUPPORTED_FILE_TYPE", "Only CSV files are supported; export spreadsheets as CSV first", nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILE", "Failed to read uploaded file", err)
+	}
+	defer file.Close()
+
+	req := &dto.BookingImportRequest{
+		TenantID:   authCtx.TenantID,
+		UploadedBy: authCtx.UserID,
+		FileName:   filepath.Base(fileHeader.Filename),
+		FileSize:   fileHeader.Size,
+		DryRun:     getBoolQuery(c, "dry_run", false),
+		Async:      getBoolQuery(c, "async", false),
+		Timezone:   c.Query("timezone"),
+	}
+
+	result, err := h.importService.ImportBookings(c.Context(), req, file)
+	if err != nil {
+		LogHandlerError(c, "import_bookings", err)
+		return HandleServiceError(c, err)
+	}
+
+	if !isImportFinished(result) {
+		c.Set(fiber.HeaderLocation, c.BaseURL()+"/api/v1/bookings/imports/"+result.ID.String())
+		return c.Status(fiber.StatusAccepted).JSON(SuccessResponse{
+			Success: true,
+			Message: "Import accepted for background processing",
+			Data:    result,
+		})
+	}
+
+	return NewSuccessResponse(c, result)
+}
+
+// ListImports godoc
+// @Summary List booking imports
+// @Description List the current tenant's booking imports, newest first
+// @Tags bookings
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.BookingImportListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bookings/imports [get]
+func (h *BookingImportHandler) ListImports(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	page, pageSize := ParsePagination(c)
+	imports, err := h.importService.ListImports(c.Context(), tenantID, page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, imports)
+}
+
+// GetImport godoc
+// @Summary Get booking import
+// @Description Get the status and row-level validation report of a booking import
+// @Tags bookings
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Import ID"
+// @Success 200 {object} dto.BookingImportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bookings/imports/{id} [get]
+func (h *BookingImportHandler) GetImport(c *fiber.Ctx) error {
+	importID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.importService.GetImport(c.Context(), importID, tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result)
+}
+
+func isImportFinished(result *dto.BookingImportResponse) bool {
+	return result.CompletedAt != nil
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// maxBrandingAssetSize caps uploaded logos and favicons
+const maxBrandingAssetSize = 2 * 1024 * 1024
+
+// BrandingHandler handles HTTP requests for tenant branding
+type BrandingHandler struct {
+	brandingService service.BrandingService
+}
+
+// NewBrandingHandler creates a new branding handler
+func NewBrandingHandler(brandingService service.BrandingService) *BrandingHandler {
+	if brandingService == nil {
+		panic("branding service cannot be nil")
+	}
+	return &BrandingHandler{
+		brandingService: brandingService,
+	}
+}
+
+// ============================================================================
+// Public
+// ============================================================================
+
+// GetBranding godoc
+// @Summary Get tenant branding
+// @Description Get the name, logos, colors and support contacts public booking pages are branded with, for the tenant served at the request's host or the one given
+// @Tags branding
+// @Produce json
+// @Param tenant_id query string false "Tenant ID, when not served at the tenant's own host"
+// @Success 200 {object} dto.BrandingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /branding [get]
+func (h *BrandingHandler) GetBranding(c *fiber.Ctx) error {
+	tenantID, ok := middleware.HostTenant(c)
+	if !ok {
+		var err error
+		if tenantID, err = uuid.Parse(c.Query("tenant_id")); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_TENANT_ID", "A valid tenant_id is required", err)
+		}
+	}
+
+	branding, err := h.brandingService.GetBranding(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetCacheHeaders(c, 300, false)
+	return NewSuccessResponse(c, branding)
+}
+
+// GetAsset godoc
+// @Summary Get branding image
+// @Description Get a logo or favicon a tenant uploaded. Uploads get new names, so they can be cached for good.
+// @Tags branding
+// @Produce image/png,image/jpeg,image/webp,image/x-icon
+// @Param tenant_id path string true "Tenant ID"
+// @Param file path string true "File name"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /branding/assets/{tenant_id}/{file} [get]
+func (h *BrandingHandler) GetAsset(c *fiber.Ctx) error {
+	tenantID, err := ParseUUIDParam(c, "tenant_id")
+	if err != nil {
+		return err
+	}
+
+	asset, err := h.brandingService.OpenAsset(c.Context(), tenantID, c.Params("file"))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetCacheHeaders(c, 365*24*60*60, false)
+	c.Set(fiber.HeaderContentType, asset.ContentType)
+	c.Set("X-Content-Type-Options", "nosniff")
+	// Fiber closes the stream once the response has been written
+	return c.SendStream(asset.Content)
+}
+
+// ============================================================================
+// Settings
+// ============================================================================
+
+// GetSettings godoc
+// @Summary Get branding settings
+// @Description Get the current tenant's branding as booking pages and notification emails show it, and the DKIM record of its email sender domain
+// @Tags branding
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.BrandingSettingsResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /branding/settings [get]
+func (h *BrandingHandler) GetSettings(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	settings, err := h.brandingService.GetSettings(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, settings)
+}
+
+// UpdateSettings godoc
+// @Summary Update branding settings
+// @Description Update the current tenant's name, colors, support contacts, email sender name and notification email footer. Takes the custom branding feature; hiding the powered-by line takes white labeling.
+// @Tags branding
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param settings body dto.UpdateBrandingSettingsRequest true "Branding settings"
+// @Success 200 {object} dto.BrandingSettingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /branding/settings [put]
+func (h *BrandingHandler) UpdateSettings(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateBrandingSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	settings, err := h.brandingService.UpdateSettings(c.Context(), tenantID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_branding_settings", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, settings, "Branding updated")
+}
+
+// UploadAsset godoc
+// @Summary Upload branding image
+// @Description Upload a PNG, JPEG, WebP or ICO image of up to 2MB as the current tenant's logo, dark mode logo, favicon or email logo, replacing the previous one
+// @Tags branding
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Image file"
+// @Param asset formData string true "Asset: logo, logo_dark, favicon or email_logo"
+// @Success 200 {object} dto.BrandingSettingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Router /branding/assets [post]
+func (h *BrandingHandler) UploadAsset(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_FILE", "An image is required in the 'file' field", err)
+	}
+	if fileHeader.Size > maxBrandingAssetSize {
+		return NewErrorResponse(c, fiber.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "Branding images are limited to 2MB", nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILE", "Failed to read uploaded file", err)
+	}
+	defer file.Close()
+
+	req := &dto.UploadBrandingAssetRequest{
+		TenantID: tenantID,
+		Asset:    models.BrandingAsset(c.FormValue("asset")),
+		FileSize: fileHeader.Size,
+	}
+
+	settings, err := h.brandingService.UploadAsset(c.Context(), req, file)
+	if err != nil {
+		LogHandlerError(c, "upload_branding_asset", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, settings, "Image uploaded")
+}
+
+// ============================================================================
+// Email Sender Domain
+// ============================================================================
+
+// GetEmailDomain godoc
+// @Summary Get email sender domain
+// @Description Get the DKIM TXT record the current tenant's email sender domain is to publish, and whether it was found
+// @Tags branding
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.DomainVerificationResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /branding/email-domain [get]
+func (h *BrandingHandler) GetEmailDomain(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	domain, err := h.brandingService.GetEmailDomain(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, domain)
+}
+
+// SetEmailDomain godoc
+// @Summary Set email sender domain
+// @Description Set the domain the current tenant's notification emails are sent from, generating a new DKIM key. Emails come from the platform's address until the domain publishes the returned TXT record and is verified.
+// @Tags branding
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param domain body dto.SetEmailDomainRequest true "Email sender domain"
+// @Success 200 {object} dto.DomainVerificationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /branding/email-domain [put]
+func (h *BrandingHandler) SetEmailDomain(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.SetEmailDomainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	domain, err := h.brandingService.SetEmailDomain(c.Context(), tenantID, &req)
+	if err != nil {
+		LogHandlerError(c, "set_email_domain", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, domain, "Publish the DKIM record, then verify the domain")
+}
+
+// VerifyEmailDomain godoc
+// @Summary Verify email sender domain
+// @Description Look up the DKIM TXT record of the current tenant's email sender domain, and send its emails from the domain once the record is found
+// @Tags branding
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.DomainVerificationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /branding/email-domain/verify [post]
+func (h *BrandingHandler) VerifyEmailDomain(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	domain, err := h.brandingService.VerifyEmailDomain(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, domain, "Email sender domain verified")
+}
+
+// RemoveEmailDomain godoc
+// @Summary Remove email sender domain
+// @Description Go back to sending the current tenant's notification emails from the platform's address
+// @Tags branding
+// @Security BearerAuth
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /branding/email-domain [delete]
+func (h *BrandingHandler) RemoveEmailDomain(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.brandingService.RemoveEmailDomain(c.Context(), tenantID); err != nil {
+		LogHandlerError(c, "remove_email_domain", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/pkg/ical"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CalendarHandler handles HTTP requests for calendar exports
+type CalendarHandler struct {
+	calendarService service.CalendarService
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(calendarService service.CalendarService) *CalendarHandler {
+	if calendarService == nil {
+		panic("calendar service cannot be nil")
+	}
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// GetArtisanFeedInfo godoc
+// @Summary Get artisan calendar subscription
+// @Description Get the signed iCalendar subscription URL for an artisan's bookings
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Success 200 {object} dto.CalendarFeedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /artisans/{id}/calendar [get]
+func (h *CalendarHandler) GetArtisanFeedInfo(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	info, err := h.calendarService.GetArtisanFeedInfo(c.Context(), artisanID, c.BaseURL())
+	if err != nil {
+		LogHandlerError(c, "get_artisan_calendar_feed_info", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, info)
+}
+
+// RotateArtisanFeedToken godoc
+// @Summary Rotate artisan calendar subscription
+// @Description Revoke the artisan's iCalendar subscription URL and return a new one, for when the URL was shared by mistake
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Success 200 {object} dto.CalendarFeedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /artisans/{id}/calendar/rotate [post]
+func (h *CalendarHandler) RotateArtisanFeedToken(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	info, err := h.calendarService.RotateArtisanFeedToken(c.Context(), artisanID, c.BaseURL())
+	if err != nil {
+		LogHandlerError(c, "rotate_artisan_calendar_feed_token", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, info)
+}
+
+// GetArtisanFeed godoc
+// @Summary Artisan calendar feed
+// @Description iCalendar feed of an artisan's bookings for Google Calendar, Outlook and other clients. Authenticated by the signed token in the query string.
+// @Tags calendar
+// @Produce text/calendar
+// @Param id path string true "Artisan ID"
+// @Param token query string true "Signed feed token"
+// @Success 200 {string} string "iCalendar document"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /artisans/{id}/calendar.ics [get]
+func (h *CalendarHandler) GetArtisanFeed(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	token := c.Query("token")
+	if token == "" {
+		return NewUnauthorizedResponse(c, "Calendar token is required")
+	}
+
+	feed, err := h.calendarService.GetArtisanFeed(c.Context(), artisanID, token)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetCacheHeaders(c, 300, true)
+	c.Set(fiber.HeaderContentType, ical.ContentType)
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="bookings.ics"`)
+	return c.Send(feed)
+}
@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// trackingPixel is a transparent 1x1 GIF, served for campaign email opens
+var trackingPixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// CampaignHandler handles HTTP requests for marketing campaigns
+type CampaignHandler struct {
+	campaignService service.CampaignService
+}
+
+// NewCampaignHandler creates a new campaign handler
+func NewCampaignHandler(campaignService service.CampaignService) *CampaignHandler {
+	if campaignService == nil {
+		panic("campaign service cannot be nil")
+	}
+	return &CampaignHandler{
+		campaignService: campaignService,
+	}
+}
+
+// CreateCampaign godoc
+// @Summary Create campaign
+// @Description Create a draft marketing campaign sent by email or SMS to a customer audience: segments, tags, and customers who booked given services or categories within a period. Subject and body may use the {{first_name}}, {{last_name}}, {{full_name}} and {{tenant_name}} placeholders.
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign body dto.CreateCampaignRequest true "Campaign data"
+// @Success 201 {object} dto.CampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /campaigns [post]
+func (h *CampaignHandler) CreateCampaign(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateCampaignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	campaign, err := h.campaignService.CreateCampaign(c.Context(), authCtx.TenantID, authCtx.UserID, &req)
+	if err != nil {
+		LogHandlerError(c, "create_campaign", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, campaign, "Campaign created successfully")
+}
+
+// ListCampaigns godoc
+// @Summary List campaigns
+// @Description List the tenant's campaigns with their delivery and open metrics, newest first
+// @Tags campaigns
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Only campaigns in this status" Enums(draft, scheduled, sending, sent, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.CampaignListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /campaigns [get]
+func (h *CampaignHandler) ListCampaigns(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	page, pageSize := ParsePagination(c)
+	status := models.CampaignStatus(c.Query("status"))
+
+	campaigns, err := h.campaignService.ListCampaigns(c.Context(), authCtx.TenantID, status, page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, campaigns)
+}
+
+// GetCampaign godoc
+// @Summary Get campaign
+// @Description Get a campaign with its delivery and open metrics
+// @Tags campaigns
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} dto.CampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /campaigns/{id} [get]
+func (h *CampaignHandler) GetCampaign(c *fiber.Ctx) error {
+	id, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	campaign, err := h.campaignService.GetCampaign(c.Context(), authCtx.TenantID, id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, campaign)
+}
+
+// UpdateCampaign godoc
+// @Summary Update campaign
+// @Description Change a draft or scheduled campaign; a scheduled campaign stays scheduled
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Param campaign body dto.UpdateCampaignRequest true "Campaign changes"
+// @Success 200 {object} dto.CampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /campaigns/{id} [put]
+func (h *CampaignHandler) UpdateCampaign(c *fiber.Ctx) error {
+	id, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateCampaignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	campaign, err := h.campaignService.UpdateCampaign(c.Context(), authCtx.TenantID, id, &req)
+	if err != nil {
+		LogHandlerError(c, "update_campaign", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, campaign, "Campaign updated successfully")
+}
+
+// DeleteCampaign godoc
+// @Summary Delete campaign
+// @Description Delete a campaign that is not scheduled or being sent
+// @Tags campaigns
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /campaigns/{id} [delete]
+func (h *CampaignHandler) DeleteCampaign(c *fiber.Ctx) error {
+	id, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.campaignService.DeleteCampaign(c.Context(), authCtx.TenantID, id); err != nil {
+		LogHandlerError(c, "delete_campaign", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// ScheduleCampaign godoc
+// @Summary Schedule campaign
+// @Description Schedule a draft campaign, or reschedule a scheduled one, to be sent at a time or right away. It is sent through the notification subsystem as marketing, only to customers who opted in, at most its send rate a minute.
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Param schedule body dto.ScheduleCampaignRequest false "When to send"
+// @Success 200 {object} dto.CampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /campaigns/{id}/schedule [post]
+func (h *CampaignHandler) ScheduleCampaign(c *fiber.Ctx) error {
+	id, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ScheduleCampaignRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		}
+	}
+
+	campaign, err := h.campaignService.ScheduleCampaign(c.Context(), authCtx.TenantID, id, &req)
+	if err != nil {
+		LogHandlerError(c, "schedule_campaign", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, campaign, "Campaign scheduled successfully")
+}
+
+// CancelCampaign godoc
+// @Summary Cancel campaign
+// @Description Stop a campaign that has not finished; recipients not sent to yet are skipped
+// @Tags campaigns
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} dto.CampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /campaigns/{id}/cancel [post]
+func (h *CampaignHandler) CancelCampaign(c *fiber.Ctx) error {
+	id, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	campaign, err := h.campaignService.CancelCampaign(c.Context(), authCtx.TenantID, id)
+	if err != nil {
+		LogHandlerError(c, "cancel_campaign", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, campaign, "Campaign cancelled successfully")
+}
+
+// PreviewCampaign godoc
+// @Summary Preview campaign
+// @Description Render a campaign as the current user would receive it, with how many customers it would reach and how long sending would take
+// @Tags campaigns
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} dto.CampaignPreviewResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /campaigns/{id}/preview [get]
+func (h *CampaignHandler) PreviewCampaign(c *fiber.Ctx) error {
+	id, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	preview, err := h.campaignService.PreviewCampaign(c.Context(), authCtx.TenantID, authCtx.UserID, id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, preview)
+}
+
+// CountAudience godoc
+// @Summary Count campaign audience
+// @Description Count the customers an audience selects, while writing a campaign
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param audience body dto.CampaignAudienceCountRequest true "Audience"
+// @Success 200 {object} dto.CampaignAudienceCountResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /campaigns/audience/count [post]
+func (h *CampaignHandler) CountAudience(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CampaignAudienceCountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	count, err := h.campaignService.CountAudience(c.Context(), authCtx.TenantID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, count)
+}
+
+// ListRecipients godoc
+// @Summary List campaign recipients
+// @Description List a campaign's recipients with whether each was sent to, skipped (and why) or failed, and when they opened it
+// @Tags campaigns
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Campaign ID"
+// @Param status query string false "Only recipients in this status" Enums(pending, sent, skipped, failed)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.CampaignRecipientListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /campaigns/{id}/recipients [get]
+func (h *CampaignHandler) ListRecipients(c *fiber.Ctx) error {
+	id, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	page, pageSize := ParsePagination(c)
+	status := models.CampaignRecipientStatus(c.Query("status"))
+
+	recipients, err := h.campaignService.ListRecipients(c.Context(), authCtx.TenantID, id, status, page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, recipients)
+}
+
+// TrackOpen godoc
+// @Summary Track campaign open
+// @Description Tracking pixel of campaign emails, recording the first time the recipient opened one. Public: the token in the path identifies the recipient. Always answers with a transparent GIF.
+// @Tags campaigns
+// @Produce image/gif
+// @Param token path string true "Open tracking token"
+// @Success 200 {file} binary
+// @Router /campaigns/opens/{token} [get]
+func (h *CampaignHandler) TrackOpen(c *fiber.Ctx) error {
+	if err := h.campaignService.RecordOpen(c.Context(), c.Params("token")); err != nil {
+		LogHandlerError(c, "track_campaign_open", err)
+	}
+
+	SetNoCacheHeaders(c)
+	c.Set(fiber.HeaderContentType, "image/gif")
+	return c.Send(trackingPixel)
+}
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CancellationPolicyHandler handles HTTP requests for cancellation policies
+type CancellationPolicyHandler struct {
+	policyService service.CancellationPolicyService
+}
+
+// NewCancellationPolicyHandler creates a new cancellation policy handler
+func NewCancellationPolicyHandler(policyService service.CancellationPolicyService) *CancellationPolicyHandler {
+	if policyService == nil {
+		panic("cancellation policy service cannot be nil")
+	}
+	return &CancellationPolicyHandler{
+		policyService: policyService,
+	}
+}
+
+// CreatePolicy godoc
+// @Summary Create cancellation policy
+// @Description Create a tenant-wide or service-specific cancellation policy
+// @Tags cancellation-policies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param policy body dto.CreateCancellationPolicyRequest true "Policy data"
+// @Success 201 {object} dto.CancellationPolicyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /cancellation-policies [post]
+func (h *CancellationPolicyHandler) CreatePolicy(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateCancellationPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = authCtx.TenantID
+
+	policy, err := h.policyService.CreatePolicy(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_cancellation_policy", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, policy, "Cancellation policy created successfully")
+}
+
+// ListPolicies godoc
+// @Summary List cancellation policies
+// @Description List the cancellation policies of the current tenant
+// @Tags cancellation-policies
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.CancellationPolicyResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /cancellation-policies [get]
+func (h *CancellationPolicyHandler) ListPolicies(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	policies, err := h.policyService.ListPolicies(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, policies)
+}
+
+// GetPolicy godoc
+// @Summary Get cancellation policy
+// @Description Get a cancellation policy by ID
+// @Tags cancellation-policies
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Policy ID"
+// @Success 200 {object} dto.CancellationPolicyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /cancellation-policies/{id} [get]
+func (h *CancellationPolicyHandler) GetPolicy(c *fiber.Ctx) error {
+	policyID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	policy, err := h.policyService.GetPolicy(c.Context(), policyID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, policy.TenantID); err != nil {
+		return err
+	}
+
+	return NewSuccessResponse(c, policy)
+}
+
+// UpdatePolicy godoc
+// @Summary Update cancellation policy
+// @Description Update a cancellation policy
+// @Tags cancellation-policies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Policy ID"
+// @Param policy body dto.UpdateCancellationPolicyRequest true "Policy data"
+// @Success 200 {object} dto.CancellationPolicyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /cancellation-policies/{id} [put]
+func (h *CancellationPolicyHandler) UpdatePolicy(c *fiber.Ctx) error {
+	policyID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateCancellationPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	existing, err := h.policyService.GetPolicy(c.Context(), policyID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, existing.TenantID); err != nil {
+		return err
+	}
+
+	policy, err := h.policyService.UpdatePolicy(c.Context(), policyID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_cancellation_policy", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, policy, "Cancellation policy updated successfully")
+}
+
+// DeletePolicy godoc
+// @Summary Delete cancellation policy
+// @Description Delete a cancellation policy
+// @Tags cancellation-policies
+// @Security BearerAuth
+// @Param id path string true "Policy ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /cancellation-policies/{id} [delete]
+func (h *CancellationPolicyHandler) DeletePolicy(c *fiber.Ctx) error {
+	policyID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	existing, err := h.policyService.GetPolicy(c.Context(), policyID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, existing.TenantID); err != nil {
+		return err
+	}
+
+	if err := h.policyService.DeletePolicy(c.Context(), policyID); err != nil {
+		LogHandlerError(c, "delete_cancellation_policy", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// QuoteCancellation godoc
+// @Summary Preview cancellation
+// @Description Show the refund and fees that would apply if the booking were cancelled now
+// @Tags bookings
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} dto.CancellationQuoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bookings/{id}/cancellation-quote [get]
+func (h *CancellationPolicyHandler) QuoteCancellation(c *fiber.Ctx) error {
+	if _, err := GetAuthContext(c); err != nil {
+		return err
+	}
+
+	bookingID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	quote, err := h.policyService.QuoteCancellation(c.Context(), bookingID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetNoCacheHeaders(c)
+	return NewSuccessResponse(c, quote)
+}
@@ -0,0 +1,429 @@
+package handler
+
+import (
+	"fmt"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ChangeOrderHandler handles HTTP requests for project change orders
+type ChangeOrderHandler struct {
+	changeOrderService service.ChangeOrderService
+}
+
+// NewChangeOrderHandler creates a new change order handler
+func NewChangeOrderHandler(changeOrderService service.ChangeOrderService) *ChangeOrderHandler {
+	if changeOrderService == nil {
+		panic("change order service cannot be nil")
+	}
+	return &ChangeOrderHandler{
+		changeOrderService: changeOrderService,
+	}
+}
+
+// ============================================================================
+// Staff
+// ============================================================================
+
+// CreateChangeOrder godoc
+// @Summary Create change order
+// @Description Draft a change order for a project with its price and schedule deltas. The customer does not see it until it is submitted.
+// @Tags change-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param change_order body dto.CreateChangeOrderRequest true "Change order data"
+// @Success 201 {object} dto.ChangeOrderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /change-orders [post]
+func (h *ChangeOrderHandler) CreateChangeOrder(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateChangeOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	changeOrder, err := h.changeOrderService.CreateChangeOrder(c.Context(), authCtx.TenantID, authCtx.UserID, &req)
+	if err != nil {
+		LogHandlerError(c, "create_change_order", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, changeOrder, "Change order created successfully")
+}
+
+// ListChangeOrders godoc
+// @Summary List change orders
+// @Description List the tenant's change orders, newest first
+// @Tags change-orders
+// @Produce json
+// @Security BearerAuth
+// @Param project_id query string false "Project ID"
+// @Param status query string false "Comma-separated statuses (draft, pending, approved, rejected, cancelled)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.ChangeOrderListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /change-orders [get]
+func (h *ChangeOrderHandler) ListChangeOrders(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseChangeOrderFilter(c)
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER", err.Error(), err)
+	}
+	filter.TenantID = tenantID
+
+	changeOrders, err := h.changeOrderService.ListChangeOrders(c.Context(), filter)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, changeOrders)
+}
+
+// GetChangeOrder godoc
+// @Summary Get change order
+// @Description Get a change order by ID
+// @Tags change-orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change order ID"
+// @Success 200 {object} dto.ChangeOrderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /change-orders/{id} [get]
+func (h *ChangeOrderHandler) GetChangeOrder(c *fiber.Ctx) error {
+	changeOrderID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	changeOrder, err := h.changeOrderService.GetChangeOrder(c.Context(), tenantID, changeOrderID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, changeOrder)
+}
+
+// UpdateChangeOrder godoc
+// @Summary Update change order
+// @Description Edit a draft change order
+// @Tags change-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change order ID"
+// @Param change_order body dto.UpdateChangeOrderRequest true "Change order updates"
+// @Success 200 {object} dto.ChangeOrderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /change-orders/{id} [put]
+func (h *ChangeOrderHandler) UpdateChangeOrder(c *fiber.Ctx) error {
+	changeOrderID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateChangeOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	changeOrder, err := h.changeOrderService.UpdateChangeOrder(c.Context(), authCtx.TenantID, authCtx.UserID, changeOrderID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_change_order", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, changeOrder, "Change order updated successfully")
+}
+
+// SubmitChangeOrder godoc
+// @Summary Submit change order
+// @Description Send a draft change order to the project's customer for approval
+// @Tags change-orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change order ID"
+// @Success 200 {object} dto.ChangeOrderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /change-orders/{id}/submit [post]
+func (h *ChangeOrderHandler) SubmitChangeOrder(c *fiber.Ctx) error {
+	changeOrderID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	changeOrder, err := h.changeOrderService.SubmitChangeOrder(c.Context(), authCtx.TenantID, authCtx.UserID, changeOrderID)
+	if err != nil {
+		LogHandlerError(c, "submit_change_order", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, changeOrder, "Change order submitted")
+}
+
+// CancelChangeOrder godoc
+// @Summary Cancel change order
+// @Description Withdraw a draft or pending change order
+// @Tags change-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change order ID"
+// @Param cancellation body dto.CancelChangeOrderRequest false "Cancellation note"
+// @Success 200 {object} dto.ChangeOrderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /change-orders/{id}/cancel [post]
+func (h *ChangeOrderHandler) CancelChangeOrder(c *fiber.Ctx) error {
+	changeOrderID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CancelChangeOrderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		}
+	}
+
+	changeOrder, err := h.changeOrderService.CancelChangeOrder(c.Context(), authCtx.TenantID, authCtx.UserID, changeOrderID, &req)
+	if err != nil {
+		LogHandlerError(c, "cancel_change_order", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, changeOrder, "Change order cancelled")
+}
+
+// GetChangeOrderHistory godoc
+// @Summary Get change order history
+// @Description List everything that happened to a change order, oldest first
+// @Tags change-orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change order ID"
+// @Success 200 {array} dto.ChangeOrderEventResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /change-orders/{id}/history [get]
+func (h *ChangeOrderHandler) GetChangeOrderHistory(c *fiber.Ctx) error {
+	changeOrderID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	events, err := h.changeOrderService.GetChangeOrderHistory(c.Context(), tenantID, changeOrderID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, events)
+}
+
+// ============================================================================
+// Customers
+// ============================================================================
+
+// ListReceivedChangeOrders godoc
+// @Summary List my change orders
+// @Description List the change orders submitted on the current customer's projects, newest first
+// @Tags change-orders
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.ChangeOrderListResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /change-orders/received [get]
+func (h *ChangeOrderHandler) ListReceivedChangeOrders(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	page, pageSize := ParsePagination(c)
+	changeOrders, err := h.changeOrderService.ListCustomerChangeOrders(c.Context(), authCtx.TenantID, authCtx.UserID, page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, changeOrders)
+}
+
+// GetReceivedChangeOrder godoc
+// @Summary Get my change order
+// @Description Get a change order submitted on one of the current customer's projects
+// @Tags change-orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change order ID"
+// @Success 200 {object} dto.ChangeOrderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /change-orders/received/{id} [get]
+func (h *ChangeOrderHandler) GetReceivedChangeOrder(c *fiber.Ctx) error {
+	changeOrderID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	changeOrder, err := h.changeOrderService.GetCustomerChangeOrder(c.Context(), authCtx.TenantID, authCtx.UserID, changeOrderID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, changeOrder)
+}
+
+// ApproveChangeOrder godoc
+// @Summary Approve change order
+// @Description Approve a change order on one of the current customer's projects. The project budget and due date move by the change order's deltas.
+// @Tags change-orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change order ID"
+// @Success 200 {object} dto.ChangeOrderApprovalResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /change-orders/{id}/approve [post]
+func (h *ChangeOrderHandler) ApproveChangeOrder(c *fiber.Ctx) error {
+	changeOrderID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.changeOrderService.ApproveChangeOrder(c.Context(), authCtx.TenantID, authCtx.UserID, changeOrderID)
+	if err != nil {
+		LogHandlerError(c, "approve_change_order", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result, "Change order approved")
+}
+
+// RejectChangeOrder godoc
+// @Summary Reject change order
+// @Description Reject a change order on one of the current customer's projects. The project is not changed.
+// @Tags change-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change order ID"
+// @Param rejection body dto.RejectChangeOrderRequest false "Rejection reason"
+// @Success 200 {object} dto.ChangeOrderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /change-orders/{id}/reject [post]
+func (h *ChangeOrderHandler) RejectChangeOrder(c *fiber.Ctx) error {
+	changeOrderID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.RejectChangeOrderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		}
+	}
+
+	changeOrder, err := h.changeOrderService.RejectChangeOrder(c.Context(), authCtx.TenantID, authCtx.UserID, changeOrderID, &req)
+	if err != nil {
+		LogHandlerError(c, "reject_change_order", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, changeOrder, "Change order rejected")
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+func parseChangeOrderFilter(c *fiber.Ctx) (dto.ChangeOrderFilter, error) {
+	var filter dto.ChangeOrderFilter
+
+	filter.Page, filter.PageSize = ParsePagination(c)
+	if value := c.Query("project_id"); value != "" {
+		projectID, err := uuid.Parse(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid project_id: %q is not a valid ID", value)
+		}
+		filter.ProjectID = &projectID
+	}
+	for _, value := range splitListQuery(c, "status") {
+		status := models.ChangeOrderStatus(value)
+		switch status {
+		case models.ChangeOrderStatusDraft, models.ChangeOrderStatusPending, models.ChangeOrderStatusApproved,
+			models.ChangeOrderStatusRejected, models.ChangeOrderStatusCancelled:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return filter, fmt.Errorf("invalid status: %q", value)
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/pkg/chaos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ChaosHandler handles HTTP requests managing the fault injection rules
+type ChaosHandler struct {
+	injector *chaos.Injector
+}
+
+// NewChaosHandler creates a new chaos handler
+func NewChaosHandler(injector *chaos.Injector) *ChaosHandler {
+	return &ChaosHandler{
+		injector: injector,
+	}
+}
+
+// ListRules godoc
+// @Summary List fault injection rules
+// @Description List the rules injecting latency, errors and dependency failures into requests on this instance. Available outside production only. Platform super admins only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} chaos.Rule
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/chaos/rules [get]
+func (h *ChaosHandler) ListRules(c *fiber.Ctx) error {
+	return NewSuccessResponse(c, h.injector.Rules())
+}
+
+// CreateRule godoc
+// @Summary Add a fault injection rule
+// @Description Inject latency, an error status or failures of the database, Redis or payment providers into a percentage of the requests matching a route. Platform super admins only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body chaos.Rule true "Rule"
+// @Success 201 {object} chaos.Rule
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/chaos/rules [post]
+func (h *ChaosHandler) CreateRule(c *fiber.Ctx) error {
+	var req chaos.Rule
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	rule, err := h.injector.Add(req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, rule, "Fault injection rule added")
+}
+
+// DeleteRule godoc
+// @Summary Remove a fault injection rule
+// @Description Stop injecting the faults of a rule. Platform super admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/chaos/rules/{id} [delete]
+func (h *ChaosHandler) DeleteRule(c *fiber.Ctx) error {
+	if !h.injector.Remove(c.Params("id")) {
+		return NewNotFoundResponse(c, "Fault injection rule")
+	}
+	return NewNoContentResponse(c)
+}
+
+// ClearRules godoc
+// @Summary Remove every fault injection rule
+// @Description Stop injecting faults on this instance. Platform super admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/chaos/rules [delete]
+func (h *ChaosHandler) ClearRules(c *fiber.Ctx) error {
+	h.injector.Clear()
+	return NewNoContentResponse(c)
+}
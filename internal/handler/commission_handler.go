@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CommissionHandler handles HTTP requests for commission rules
+type CommissionHandler struct {
+	commissionService service.CommissionService
+}
+
+// NewCommissionHandler creates a new commission handler
+func NewCommissionHandler(commissionService service.CommissionService) *CommissionHandler {
+	if commissionService == nil {
+		panic("commission service cannot be nil")
+	}
+	return &CommissionHandler{
+		commissionService: commissionService,
+	}
+}
+
+// CreateCommissionRule godoc
+// @Summary Create commission rule
+// @Description Create a default, service category, artisan tier, volume or promotional commission rate for the current tenant
+// @Tags commission-rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body dto.CreateCommissionRuleRequest true "Commission rule data"
+// @Success 201 {object} dto.CommissionRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /commission-rules [post]
+func (h *CommissionHandler) CreateCommissionRule(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateCommissionRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = authCtx.TenantID
+
+	rule, err := h.commissionService.CreateCommissionRule(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_commission_rule", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, rule, "Commission rule created successfully")
+}
+
+// ListCommissionRules godoc
+// @Summary List commission rules
+// @Description List the commission rules of the current tenant
+// @Tags commission-rules
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.CommissionRuleResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /commission-rules [get]
+func (h *CommissionHandler) ListCommissionRules(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	rules, err := h.commissionService.ListCommissionRules(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, rules)
+}
+
+// GetCommissionRule godoc
+// @Summary Get commission rule
+// @Description Get a commission rule by ID
+// @Tags commission-rules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Commission rule ID"
+// @Success 200 {object} dto.CommissionRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /commission-rules/{id} [get]
+func (h *CommissionHandler) GetCommissionRule(c *fiber.Ctx) error {
+	ruleID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rule, err := h.commissionService.GetCommissionRule(c.Context(), ruleID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, rule.TenantID); err != nil {
+		return err
+	}
+
+	return NewSuccessResponse(c, rule)
+}
+
+// UpdateCommissionRule godoc
+// @Summary Update commission rule
+// @Description Update a commission rule; payments already taken keep the rate they were split with
+// @Tags commission-rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Commission rule ID"
+// @Param rule body dto.UpdateCommissionRuleRequest true "Commission rule data"
+// @Success 200 {object} dto.CommissionRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /commission-rules/{id} [put]
+func (h *CommissionHandler) UpdateCommissionRule(c *fiber.Ctx) error {
+	ruleID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateCommissionRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	existing, err := h.commissionService.GetCommissionRule(c.Context(), ruleID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, existing.TenantID); err != nil {
+		return err
+	}
+
+	rule, err := h.commissionService.UpdateCommissionRule(c.Context(), ruleID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_commission_rule", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, rule, "Commission rule updated successfully")
+}
+
+// DeleteCommissionRule godoc
+// @Summary Delete commission rule
+// @Description Delete a commission rule
+// @Tags commission-rules
+// @Security BearerAuth
+// @Param id path string true "Commission rule ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /commission-rules/{id} [delete]
+func (h *CommissionHandler) DeleteCommissionRule(c *fiber.Ctx) error {
+	ruleID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	existing, err := h.commissionService.GetCommissionRule(c.Context(), ruleID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, existing.TenantID); err != nil {
+		return err
+	}
+
+	if err := h.commissionService.DeleteCommissionRule(c.Context(), ruleID); err != nil {
+		LogHandlerError(c, "delete_commission_rule", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CommunicationPreferenceHandler handles HTTP requests for users' choices of the
+// transactional messages and marketing they are sent, and the unsubscribe links in them
+type CommunicationPreferenceHandler struct {
+	preferenceService service.CommunicationPreferenceService
+}
+
+// NewCommunicationPreferenceHandler creates a new communication preference handler
+func NewCommunicationPreferenceHandler(preferenceService service.CommunicationPreferenceService) *CommunicationPreferenceHandler {
+	return &CommunicationPreferenceHandler{
+		preferenceService: preferenceService,
+	}
+}
+
+// GetPreferences godoc
+// @Summary Get communication preferences
+// @Description Get the transactional messages and marketing the user is sent by email, SMS and push. Users who haven't chosen get transactional messages and no marketing.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.CommunicationPreferencesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/communication-preferences [get]
+func (h *CommunicationPreferenceHandler) GetPreferences(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	preferences, err := h.preferenceService.GetPreferences(c.Context(), userID, authCtx.UserID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, preferences)
+}
+
+// UpdatePreferences godoc
+// @Summary Update communication preferences
+// @Description Opt in to or out of transactional messages and marketing per channel; omitted fields are left as they are. Opting in to marketing records the user's marketing consent, and opting out of it everywhere withdraws it.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body dto.UpdateCommunicationPreferencesRequest true "Preferences to change"
+// @Success 200 {object} dto.CommunicationPreferencesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "The user's personal data has been erased"
+// @Router /users/{id}/communication-preferences [put]
+func (h *CommunicationPreferenceHandler) UpdatePreferences(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+	}
+
+	var req dto.UpdateCommunicationPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	preferences, err := h.preferenceService.UpdatePreferences(c.Context(), userID, authCtx.UserID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, preferences, "Communication preferences updated")
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe
+// @Description Follow the unsubscribe link of a message, opting its recipient out of messages of the category on the channel without signing in. POST serves one-click unsubscribing from mail clients (RFC 8058).
+// @Tags notifications
+// @Produce json
+// @Param token path string true "Unsubscribe token"
+// @Param channel query string false "Channel: email, sms or push" default(email)
+// @Param category query string false "Category: marketing, transactional or all" default(marketing)
+// @Success 200 {object} dto.UnsubscribeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /notifications/unsubscribe/{token} [get]
+// @Router /notifications/unsubscribe/{token} [post]
+func (h *CommunicationPreferenceHandler) Unsubscribe(c *fiber.Ctx) error {
+	channel := models.NotificationChannel(c.Query("channel", string(models.NotificationChannelEmail)))
+	category := models.CommunicationCategory(c.Query("category", string(models.CommunicationCategoryMarketing)))
+
+	result, err := h.preferenceService.Unsubscribe(c.Context(), c.Params("token"), channel, category)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result, "You have been unsubscribed")
+}
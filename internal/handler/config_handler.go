@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ConfigHandler handles HTTP requests inspecting the running configuration
+type ConfigHandler struct {
+	settings *config.Watcher
+}
+
+// NewConfigHandler creates a new config handler
+func NewConfigHandler(settings *config.Watcher) *ConfigHandler {
+	return &ConfigHandler{
+		settings: settings,
+	}
+}
+
+// EffectiveConfigResponse is the configuration in effect and how it was last reloaded
+type EffectiveConfigResponse struct {
+	Config map[string]any      `json:"config"`
+	Reload config.ReloadStatus `json:"reload"`
+}
+
+// GetEffectiveConfig godoc
+// @Summary Get the effective configuration
+// @Description Get the configuration this instance runs with, secrets masked, including settings reloaded since startup and changes waiting for a restart. Platform super admins only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} EffectiveConfigResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/config [get]
+func (h *ConfigHandler) GetEffectiveConfig(c *fiber.Ctx) error {
+	return NewSuccessResponse(c, EffectiveConfigResponse{
+		Config: h.settings.Current().Redacted(),
+		Reload: h.settings.Status(),
+	})
+}
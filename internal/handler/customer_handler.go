@@ -162,7 +162,10 @@ func (h *CustomerHandler) DeleteCustomer(c *fiber.Ctx) error {
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(20)
 // @Param tenant_id query string false "Filter by tenant ID"
+// @Param segments query string false "Comma-separated segments (new, repeat, vip, at_risk); customers must be in at least one"
+// @Param tags query string false "Comma-separated tags; customers must carry all of them"
 // @Success 200 {object} dto.CustomerListResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /customers [get]
 func (h *CustomerHandler) ListCustomers(c *fiber.Ctx) error {
@@ -172,6 +175,8 @@ func (h *CustomerHandler) ListCustomers(c *fiber.Ctx) error {
 	filter := dto.CustomerFilter{
 		Page:     getIntQuery(c, "page", 1),
 		PageSize: getIntQuery(c, "page_size", 20),
+		Segments: parseCustomerSegmentsQuery(c, "segments"),
+		Tags:     splitListQuery(c, "tags"),
 	}
 
 	// Use tenant ID from auth context (for tenant isolation)
@@ -579,9 +584,75 @@ func (h *CustomerHandler) GetCustomerStats(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, stats)
 }
 
+// UpdateCustomerTags godoc
+// @Summary Update customer tags
+// @Description Replace a customer's manual tags, which filter customer and booking lists and target campaigns alongside segments
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Param tags body dto.UpdateCustomerTagsRequest true "Tags"
+// @Success 200 {object} dto.CustomerResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /customers/{id}/tags [put]
+func (h *CustomerHandler) UpdateCustomerTags(c *fiber.Ctx) error {
+	customerID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateCustomerTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	customer, err := h.customerService.UpdateCustomerTags(c.Context(), tenantID, customerID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, customer, "Customer tags updated successfully")
+}
+
+// RecalculateCustomerSegments godoc
+// @Summary Recalculate customer segments
+// @Description Recompute the authenticated tenant's customer segments now instead of at the nightly run, as after changing the segmentation thresholds
+// @Tags customers
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.CustomerSegmentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /customers/segments/recalculate [post]
+func (h *CustomerHandler) RecalculateCustomerSegments(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.customerService.RecalculateSegments(c.Context(), tenantID); err != nil {
+		LogHandlerError(c, "recalculate_customer_segments", err)
+		return HandleServiceError(c, err)
+	}
+
+	segments, err := h.customerService.GetCustomerSegments(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, segments, "Customer segments recalculated")
+}
+
 // GetCustomerSegments godoc
 // @Summary Get customer segments
-// @Description Get customer segmentation data for the authenticated tenant
+// @Description Get how many of the authenticated tenant's customers are in each segment (new, repeat, vip, at_risk) and what they spent, as of the last nightly segmentation
 // @Tags customers
 // @Produce json
 // @Success 200 {object} SuccessResponse
@@ -0,0 +1,416 @@
+package handler
+
+import (
+	"fmt"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CustomerRequestHandler handles HTTP requests for the request-for-quote marketplace
+type CustomerRequestHandler struct {
+	customerRequestService service.CustomerRequestService
+}
+
+// NewCustomerRequestHandler creates a new customer request handler
+func NewCustomerRequestHandler(customerRequestService service.CustomerRequestService) *CustomerRequestHandler {
+	if customerRequestService == nil {
+		panic("customer request service cannot be nil")
+	}
+	return &CustomerRequestHandler{
+		customerRequestService: customerRequestService,
+	}
+}
+
+// ============================================================================
+// Customers
+// ============================================================================
+
+// CreateRequest godoc
+// @Summary Post request for quotes
+// @Description Post a job for quotes. It is broadcast as a lead to the artisans best matching it by skills, rating, proximity and availability.
+// @Tags customer-requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateCustomerRequestRequest true "Request data"
+// @Success 201 {object} dto.CustomerRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /customer-requests [post]
+func (h *CustomerRequestHandler) CreateRequest(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateCustomerRequestRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	request, err := h.customerRequestService.CreateRequest(c.Context(), authCtx.TenantID, authCtx.UserID, &req)
+	if err != nil {
+		LogHandlerError(c, "create_customer_request", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, request, "Request posted")
+}
+
+// ListMyRequests godoc
+// @Summary List my requests
+// @Description List the current customer's requests for quotes, newest first
+// @Tags customer-requests
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Comma-separated statuses (open, awarded, closed, expired)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.CustomerRequestListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /customer-requests/mine [get]
+func (h *CustomerRequestHandler) ListMyRequests(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := parseCustomerRequestStatuses(c)
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER", err.Error(), err)
+	}
+
+	page, pageSize := ParsePagination(c)
+	requests, err := h.customerRequestService.ListMyRequests(c.Context(), authCtx.TenantID, authCtx.UserID, statuses, page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, requests)
+}
+
+// GetMyRequest godoc
+// @Summary Get my request
+// @Description Get one of the current customer's requests with its bids, the cheapest first, and a comparison of them
+// @Tags customer-requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Success 200 {object} dto.CustomerRequestDetailResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /customer-requests/mine/{id} [get]
+func (h *CustomerRequestHandler) GetMyRequest(c *fiber.Ctx) error {
+	requestID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	request, err := h.customerRequestService.GetMyRequest(c.Context(), authCtx.TenantID, authCtx.UserID, requestID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, request)
+}
+
+// AcceptBid godoc
+// @Summary Accept bid
+// @Description Accept a bid on the current customer's open request. A bid for one of the artisan's services at a proposed time becomes a confirmed booking, any other a planned project. The other bids are rejected.
+// @Tags customer-requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Param bid_id path string true "Bid ID"
+// @Success 200 {object} dto.CustomerRequestAwardResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /customer-requests/mine/{id}/bids/{bid_id}/accept [post]
+func (h *CustomerRequestHandler) AcceptBid(c *fiber.Ctx) error {
+	requestID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	bidID, err := ParseUUIDParam(c, "bid_id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.customerRequestService.AcceptBid(c.Context(), authCtx.TenantID, authCtx.UserID, requestID, bidID)
+	if err != nil {
+		LogHandlerError(c, "accept_bid", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result, "Bid accepted")
+}
+
+// CloseRequest godoc
+// @Summary Close my request
+// @Description Withdraw the current customer's open request; its bids are rejected
+// @Tags customer-requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Success 200 {object} dto.CustomerRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /customer-requests/mine/{id}/close [post]
+func (h *CustomerRequestHandler) CloseRequest(c *fiber.Ctx) error {
+	requestID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	request, err := h.customerRequestService.CloseRequest(c.Context(), authCtx.TenantID, authCtx.UserID, requestID)
+	if err != nil {
+		LogHandlerError(c, "close_customer_request", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, request, "Request closed")
+}
+
+// ============================================================================
+// Artisans
+// ============================================================================
+
+// ListLeads godoc
+// @Summary List my leads
+// @Description List the requests broadcast to the current artisan, newest first
+// @Tags customer-requests
+// @Produce json
+// @Security BearerAuth
+// @Param open query bool false "Only requests still taking bids"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.LeadListResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /customer-requests/leads [get]
+func (h *CustomerRequestHandler) ListLeads(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	page, pageSize := ParsePagination(c)
+	leads, err := h.customerRequestService.ListLeads(c.Context(), authCtx.TenantID, authCtx.UserID, getBoolQuery(c, "open", false), page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, leads)
+}
+
+// GetLead godoc
+// @Summary Get my lead
+// @Description Get a request broadcast to the current artisan with their bid on it
+// @Tags customer-requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Success 200 {object} dto.LeadResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /customer-requests/leads/{id} [get]
+func (h *CustomerRequestHandler) GetLead(c *fiber.Ctx) error {
+	requestID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	lead, err := h.customerRequestService.GetLead(c.Context(), authCtx.TenantID, authCtx.UserID, requestID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, lead)
+}
+
+// SubmitBid godoc
+// @Summary Submit bid
+// @Description Make or change the current artisan's bid on an open request broadcast to them
+// @Tags customer-requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Param bid body dto.SubmitBidRequest true "Bid data"
+// @Success 200 {object} dto.CustomerRequestBidResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /customer-requests/leads/{id}/bid [put]
+func (h *CustomerRequestHandler) SubmitBid(c *fiber.Ctx) error {
+	requestID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.SubmitBidRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	bid, err := h.customerRequestService.SubmitBid(c.Context(), authCtx.TenantID, authCtx.UserID, requestID, &req)
+	if err != nil {
+		LogHandlerError(c, "submit_bid", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, bid, "Bid submitted")
+}
+
+// WithdrawBid godoc
+// @Summary Withdraw bid
+// @Description Withdraw the current artisan's submitted bid on a request
+// @Tags customer-requests
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /customer-requests/leads/{id}/bid [delete]
+func (h *CustomerRequestHandler) WithdrawBid(c *fiber.Ctx) error {
+	requestID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.customerRequestService.WithdrawBid(c.Context(), authCtx.TenantID, authCtx.UserID, requestID); err != nil {
+		LogHandlerError(c, "withdraw_bid", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// ============================================================================
+// Staff
+// ============================================================================
+
+// ListRequests godoc
+// @Summary List requests
+// @Description List the tenant's requests for quotes, newest first
+// @Tags customer-requests
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id query string false "Customer ID"
+// @Param status query string false "Comma-separated statuses (open, awarded, closed, expired)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.CustomerRequestListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /customer-requests [get]
+func (h *CustomerRequestHandler) ListRequests(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	filter := dto.CustomerRequestFilter{TenantID: tenantID}
+	filter.Page, filter.PageSize = ParsePagination(c)
+	if value := c.Query("customer_id"); value != "" {
+		customerID, err := uuid.Parse(value)
+		if err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER", fmt.Sprintf("invalid customer_id: %q is not a valid ID", value), err)
+		}
+		filter.CustomerID = &customerID
+	}
+	if filter.Statuses, err = parseCustomerRequestStatuses(c); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER", err.Error(), err)
+	}
+
+	requests, err := h.customerRequestService.ListRequests(c.Context(), filter)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, requests)
+}
+
+// GetRequest godoc
+// @Summary Get request
+// @Description Get a request for quotes of the tenant with its bids
+// @Tags customer-requests
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Request ID"
+// @Success 200 {object} dto.CustomerRequestDetailResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /customer-requests/{id} [get]
+func (h *CustomerRequestHandler) GetRequest(c *fiber.Ctx) error {
+	requestID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	request, err := h.customerRequestService.GetRequest(c.Context(), tenantID, requestID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, request)
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+func parseCustomerRequestStatuses(c *fiber.Ctx) ([]models.CustomerRequestStatus, error) {
+	var statuses []models.CustomerRequestStatus
+	for _, value := range splitListQuery(c, "status") {
+		status := models.CustomerRequestStatus(value)
+		if !status.IsValid() {
+			return nil, fmt.Errorf("invalid status: %q", value)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
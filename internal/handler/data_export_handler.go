@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"fmt"
+	"strconv"
+
 	"Krafti_Vibe/internal/service"
 	"Krafti_Vibe/internal/service/dto"
 
@@ -82,11 +85,12 @@ func (h *DataExportHandler) GetDataExportStatus(c *fiber.Ctx) error {
 		return NewErrorResponse(c, fiber.StatusBadRequest, "TENANT_REQUIRED", "Tenant ID is required", err)
 	}
 
-	export, err := h.exportService.GetDataExportStatus(c.Context(), exportID, tenantID)
+	export, err := h.exportService.GetDataExportStatus(c.Context(), exportID, tenantID, c.BaseURL())
 	if err != nil {
 		return HandleServiceError(c, err)
 	}
 
+	SetNoCacheHeaders(c)
 	return NewSuccessResponse(c, export)
 }
 
@@ -123,14 +127,51 @@ func (h *DataExportHandler) ListDataExports(c *fiber.Ctx) error {
 		Status:   statusPtr,
 	}
 
-	exports, err := h.exportService.ListDataExports(c.Context(), tenantID, filter)
+	exports, err := h.exportService.ListDataExports(c.Context(), tenantID, filter, c.BaseURL())
 	if err != nil {
 		return HandleServiceError(c, err)
 	}
 
+	SetNoCacheHeaders(c)
 	return NewSuccessResponse(c, exports)
 }
 
+// DownloadDataExport godoc
+// @Summary Download data export
+// @Description Download a generated export as a zip archive holding the tenant record as tenant.json and each table's rows as JSON lines. Authenticated by the signed URL returned as file_url from the export endpoints.
+// @Tags data-exports
+// @Produce application/zip
+// @Param id path string true "Export ID"
+// @Param expires query int true "Link expiry (Unix seconds)"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /data-exports/{id}/download [get]
+func (h *DataExportHandler) DownloadDataExport(c *fiber.Ctx) error {
+	exportID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	signature := c.Query("signature")
+	if err != nil || signature == "" {
+		return NewErrorResponse(c, fiber.StatusForbidden, "INVALID_DOWNLOAD_LINK", "Invalid download link", nil)
+	}
+
+	download, err := h.exportService.OpenDownload(c.Context(), exportID, expires, signature)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetNoCacheHeaders(c)
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, download.FileName))
+	// Fiber closes the stream once the response has been written
+	return c.SendStream(download.Content)
+}
+
 // CancelDataExport godoc
 // @Summary Cancel data export
 // @Description Cancel a pending or processing data export request
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// EscrowHandler handles HTTP requests for escrowed artisan earnings
+type EscrowHandler struct {
+	escrowService service.EscrowService
+}
+
+// NewEscrowHandler creates a new escrow handler
+func NewEscrowHandler(escrowService service.EscrowService) *EscrowHandler {
+	if escrowService == nil {
+		panic("escrow service cannot be nil")
+	}
+	return &EscrowHandler{
+		escrowService: escrowService,
+	}
+}
+
+// ConfirmCompletion godoc
+// @Summary Confirm booking completion
+// @Description Confirm as the customer that a completed booking's work is done, releasing the artisan's share of its payments held in escrow before the tenant's hold period ends. Withheld payments stay frozen.
+// @Tags bookings
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Booking ID"
+// @Success 200 {object} dto.EscrowReleaseResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bookings/{id}/confirm-completion [post]
+func (h *EscrowHandler) ConfirmCompletion(c *fiber.Ctx) error {
+	bookingID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.escrowService.ConfirmCompletion(c.Context(), authCtx.TenantID, bookingID, authCtx.UserID)
+	if err != nil {
+		LogHandlerError(c, "confirm_booking_completion", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result, "Booking completion confirmed")
+}
+
+// ReleaseEscrow godoc
+// @Summary Release escrowed payment
+// @Description Release the artisan's share of a held or withheld payment so the next payout batch includes it
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment ID"
+// @Param request body dto.EscrowActionRequest false "Release note"
+// @Success 200 {object} dto.PaymentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse "A recent multi-factor sign-in is required (step-up)"
+// @Failure 404 {object} ErrorResponse
+// @Router /payments/{id}/escrow/release [post]
+func (h *EscrowHandler) ReleaseEscrow(c *fiber.Ctx) error {
+	paymentID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.EscrowActionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		}
+	}
+
+	payment, err := h.escrowService.ReleasePayment(c.Context(), tenantID, paymentID, &req)
+	if err != nil {
+		LogHandlerError(c, "release_escrow", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, payment, "Payment released from escrow")
+}
+
+// WithholdEscrow godoc
+// @Summary Withhold payment from payout
+// @Description Freeze the artisan's share of a paid payment, e.g. during a dispute, until it is released by hand. Payments already in a payout cannot be withheld.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment ID"
+// @Param request body dto.EscrowActionRequest true "Reason for withholding"
+// @Success 200 {object} dto.PaymentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /payments/{id}/escrow/withhold [post]
+func (h *EscrowHandler) WithholdEscrow(c *fiber.Ctx) error {
+	paymentID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.EscrowActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	payment, err := h.escrowService.WithholdPayment(c.Context(), tenantID, paymentID, &req)
+	if err != nil {
+		LogHandlerError(c, "withhold_escrow", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, payment, "Payment withheld from payout")
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FavoriteHandler handles HTTP requests for customer favorites
+type FavoriteHandler struct {
+	favoriteService service.FavoriteService
+}
+
+// NewFavoriteHandler creates a new favorite handler
+func NewFavoriteHandler(favoriteService service.FavoriteService) *FavoriteHandler {
+	if favoriteService == nil {
+		panic("favorite service cannot be nil")
+	}
+	return &FavoriteHandler{
+		favoriteService: favoriteService,
+	}
+}
+
+// AddFavorite godoc
+// @Summary Add favorite
+// @Description Save an artisan or a service to the current user's favorites. Adding one already saved updates notify; with notify, the user hears when a favorite artisan opens new availability or publishes a new service.
+// @Tags favorites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param favorite body dto.AddFavoriteRequest true "Favorite data"
+// @Success 201 {object} dto.FavoriteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites [post]
+func (h *FavoriteHandler) AddFavorite(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.AddFavoriteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	favorite, err := h.favoriteService.AddFavorite(c.Context(), authCtx.TenantID, authCtx.UserID, &req)
+	if err != nil {
+		LogHandlerError(c, "add_favorite", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, favorite, "Favorite saved successfully")
+}
+
+// ListFavorites godoc
+// @Summary List favorites
+// @Description List the current user's favorite artisans and services, newest first
+// @Tags favorites
+// @Produce json
+// @Security BearerAuth
+// @Param kind query string false "Only artisans or only services" Enums(artisan, service)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.FavoriteListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites [get]
+func (h *FavoriteHandler) ListFavorites(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	page, pageSize := ParsePagination(c)
+	kind := models.FavoriteKind(c.Query("kind"))
+
+	favorites, err := h.favoriteService.ListFavorites(c.Context(), authCtx.TenantID, authCtx.UserID, kind, page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, favorites)
+}
+
+// RemoveFavorite godoc
+// @Summary Remove favorite
+// @Description Remove an artisan or a service from the current user's favorites
+// @Tags favorites
+// @Security BearerAuth
+// @Param kind path string true "Favorite kind" Enums(artisan, service)
+// @Param target_id path string true "Artisan or service ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /favorites/{kind}/{target_id} [delete]
+func (h *FavoriteHandler) RemoveFavorite(c *fiber.Ctx) error {
+	targetID, err := ParseUUIDParam(c, "target_id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	kind := models.FavoriteKind(c.Params("kind"))
+	if err := h.favoriteService.RemoveFavorite(c.Context(), authCtx.TenantID, authCtx.UserID, kind, targetID); err != nil {
+		LogHandlerError(c, "remove_favorite", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
@@ -4,7 +4,6 @@ import (
 	"strconv"
 
 	"Krafti_Vibe/internal/domain/models"
-	"Krafti_Vibe/internal/pkg/errors"
 	"Krafti_Vibe/internal/service"
 	"Krafti_Vibe/internal/service/dto"
 
@@ -49,10 +48,7 @@ func (h *FileUploadHandler) UploadFile(c *fiber.Ctx) error {
 	// Parse request body
 	var req dto.UploadFileRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	// Upload file
@@ -83,10 +79,7 @@ func (h *FileUploadHandler) GetFile(c *fiber.Ctx) error {
 
 	fileID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid file ID",
-			"code":  "INVALID_FILE_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILE_ID", "invalid file ID", nil)
 	}
 
 	file, err := h.fileService.GetFile(c.Context(), fileID, tenantID)
@@ -118,18 +111,12 @@ func (h *FileUploadHandler) UpdateFile(c *fiber.Ctx) error {
 
 	fileID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid file ID",
-			"code":  "INVALID_FILE_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILE_ID", "invalid file ID", nil)
 	}
 
 	var req dto.UpdateFileRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	file, err := h.fileService.UpdateFile(c.Context(), fileID, tenantID, &req)
@@ -158,10 +145,7 @@ func (h *FileUploadHandler) DeleteFile(c *fiber.Ctx) error {
 
 	fileID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid file ID",
-			"code":  "INVALID_FILE_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILE_ID", "invalid file ID", nil)
 	}
 
 	if err := h.fileService.DeleteFile(c.Context(), fileID, tenantID); err != nil {
@@ -264,10 +248,7 @@ func (h *FileUploadHandler) ListFilesByUploader(c *fiber.Ctx) error {
 
 	uploaderID, err := uuid.Parse(c.Params("uploader_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid uploader ID",
-			"code":  "INVALID_UPLOADER_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UPLOADER_ID", "invalid uploader ID", nil)
 	}
 
 	files, err := h.fileService.ListFilesByUploader(c.Context(), uploaderID, tenantID)
@@ -298,10 +279,7 @@ func (h *FileUploadHandler) ListFilesByEntity(c *fiber.Ctx) error {
 	entityType := c.Params("entity_type")
 	entityID, err := uuid.Parse(c.Params("entity_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid entity ID",
-			"code":  "INVALID_ENTITY_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ENTITY_ID", "invalid entity ID", nil)
 	}
 
 	files, err := h.fileService.ListFilesByEntity(c.Context(), entityType, entityID, tenantID)
@@ -358,10 +336,7 @@ func (h *FileUploadHandler) SearchFiles(c *fiber.Ctx) error {
 
 	query := c.Query("q")
 	if query == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "search query is required",
-			"code":  "MISSING_QUERY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_QUERY", "search query is required", nil)
 	}
 
 	page, _ := strconv.Atoi(c.Query("page", "1"))
@@ -439,16 +414,6 @@ func (h *FileUploadHandler) CleanupOrphanedFiles(c *fiber.Ctx) error {
 	days, _ := strconv.Atoi(c.Query("days", "30"))
 
 	if err := h.fileService.CleanupOrphanedFiles(c.Context(), days); err != nil {
-		if errors.IsValidationError(err) {
-			var appErr *errors.AppError
-			if e, ok := err.(*errors.AppError); ok {
-				appErr = e
-			}
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": appErr.Message,
-				"code":  appErr.Code,
-			})
-		}
 		return HandleServiceError(c, err)
 	}
 
@@ -469,10 +434,7 @@ func (h *FileUploadHandler) CleanupOrphanedFiles(c *fiber.Ctx) error {
 func (h *FileUploadHandler) UpdateFileAccess(c *fiber.Ctx) error {
 	fileID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid file ID",
-			"code":  "INVALID_FILE_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILE_ID", "invalid file ID", nil)
 	}
 
 	if err := h.fileService.UpdateFileAccess(c.Context(), fileID); err != nil {
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/repository"
 	"slices"
 	"strconv"
 	"strings"
@@ -307,3 +308,18 @@ func ParsePagination(c *fiber.Ctx) (page int, pageSize int) {
 	pageSize = getIntQuery(c, "page_size", 20)
 	return ValidatePagination(page, pageSize)
 }
+
+// ParseCursorQuery retrieves the optional keyset pagination cursor from the query.
+// It returns false when a cursor was supplied but is malformed.
+func ParseCursorQuery(c *fiber.Ctx) (string, bool) {
+	cursor := c.Query("cursor")
+	if cursor == "" {
+		return "", true
+	}
+
+	if _, err := repository.DecodeCursor(cursor); err != nil {
+		return "", false
+	}
+
+	return cursor, true
+}
@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"fmt"
+
 	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/pkg/pdf"
 	"Krafti_Vibe/internal/service"
 	"Krafti_Vibe/internal/service/dto"
 
@@ -55,6 +58,26 @@ func (h *InvoiceHandler) GetInvoice(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, invoice)
 }
 
+// DownloadInvoicePDF renders an invoice as a PDF download
+func (h *InvoiceHandler) DownloadInvoicePDF(c *fiber.Ctx) error {
+	authCtx := middleware.MustGetAuthContext(c)
+
+	invoiceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid invoice ID", err)
+	}
+
+	file, err := h.invoiceService.RenderInvoicePDF(c.Context(), invoiceID, authCtx.TenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetNoCacheHeaders(c)
+	c.Set(fiber.HeaderContentType, pdf.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	return c.Send(file.Content)
+}
+
 // UpdateInvoice updates an invoice
 func (h *InvoiceHandler) UpdateInvoice(c *fiber.Ctx) error {
 	authCtx := middleware.MustGetAuthContext(c)
@@ -110,6 +133,23 @@ func (h *InvoiceHandler) GetBookingInvoice(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, invoices)
 }
 
+// GenerateBookingInvoice issues the invoice for a booking, or returns the one it has
+func (h *InvoiceHandler) GenerateBookingInvoice(c *fiber.Ctx) error {
+	authCtx := middleware.MustGetAuthContext(c)
+
+	bookingID, err := uuid.Parse(c.Params("booking_id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid booking ID", err)
+	}
+
+	invoice, err := h.invoiceService.GenerateBookingInvoice(c.Context(), authCtx.TenantID, bookingID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, invoice)
+}
+
 // GetCustomerInvoices retrieves invoices for a customer
 func (h *InvoiceHandler) GetCustomerInvoices(c *fiber.Ctx) error {
 	authCtx := middleware.MustGetAuthContext(c)
@@ -103,3 +103,61 @@ func (h *MessageHandler) GetUnreadCount(c *fiber.Ctx) error {
 
 	return NewSuccessResponse(c, map[string]any{"count": count})
 }
+
+// PostBookingMessage posts a message to a booking's chat thread
+func (h *MessageHandler) PostBookingMessage(c *fiber.Ctx) error {
+	authCtx := middleware.MustGetAuthContext(c)
+
+	bookingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid booking ID", err)
+	}
+
+	var req dto.BookingMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	message, err := h.messageService.PostBookingMessage(c.Context(), bookingID, authCtx.UserID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, message, "Message sent successfully")
+}
+
+// ListBookingMessages retrieves a booking's chat thread
+func (h *MessageHandler) ListBookingMessages(c *fiber.Ctx) error {
+	authCtx := middleware.MustGetAuthContext(c)
+
+	bookingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid booking ID", err)
+	}
+
+	page := getIntQuery(c, "page", 1)
+	pageSize := getIntQuery(c, "page_size", 50)
+
+	conversation, err := h.messageService.ListBookingMessages(c.Context(), bookingID, authCtx.UserID, page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, conversation)
+}
+
+// MarkBookingConversationRead marks a booking's chat thread as read for the current user
+func (h *MessageHandler) MarkBookingConversationRead(c *fiber.Ctx) error {
+	authCtx := middleware.MustGetAuthContext(c)
+
+	bookingID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid booking ID", err)
+	}
+
+	if err := h.messageService.MarkBookingConversationRead(c.Context(), bookingID, authCtx.UserID); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, nil, "Conversation marked as read")
+}
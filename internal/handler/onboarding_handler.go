@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// OnboardingHandler handles HTTP requests for self-serve tenant onboarding
+type OnboardingHandler struct {
+	onboardingService service.OnboardingService
+}
+
+// NewOnboardingHandler creates a new onboarding handler
+func NewOnboardingHandler(onboardingService service.OnboardingService) *OnboardingHandler {
+	if onboardingService == nil {
+		panic("onboarding service cannot be nil")
+	}
+	return &OnboardingHandler{
+		onboardingService: onboardingService,
+	}
+}
+
+// StartOnboarding godoc
+// @Summary Start onboarding
+// @Description Create an organization on a 14-day trial with the chosen subdomain, and email its owner an invite to sign in. The owner completes the remaining onboarding steps once signed in.
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Param onboarding body dto.StartOnboardingRequest true "Organization and owner"
+// @Success 201 {object} dto.OnboardingProgressResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Subdomain taken or email already registered"
+// @Failure 503 {object} ErrorResponse "Onboarding not configured"
+// @Router /onboarding [post]
+func (h *OnboardingHandler) StartOnboarding(c *fiber.Ctx) error {
+	var req dto.StartOnboardingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	progress, err := h.onboardingService.StartOnboarding(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "start_onboarding", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, progress, "Organization created; check your email to sign in")
+}
+
+// ResendOwnerInvite godoc
+// @Summary Resend owner invite
+// @Description Email the owner of an onboarding organization a new invite, until they have signed in
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Param invite body dto.ResendOwnerInviteRequest true "Organization subdomain"
+// @Success 200 {object} dto.OnboardingProgressResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Owner already signed in"
+// @Router /onboarding/resend-invite [post]
+func (h *OnboardingHandler) ResendOwnerInvite(c *fiber.Ctx) error {
+	var req dto.ResendOwnerInviteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	progress, err := h.onboardingService.ResendOwnerInvite(c.Context(), &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, progress, "Invite sent")
+}
+
+// GetProgress godoc
+// @Summary Get onboarding progress
+// @Description Get the onboarding steps of the current organization, which are completed, and the step the wizard resumes at
+// @Tags onboarding
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.OnboardingProgressResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "Organization was not onboarded"
+// @Router /onboarding/progress [get]
+func (h *OnboardingHandler) GetProgress(c *fiber.Ctx) error {
+	tenantID, err := onboardingTenant(c)
+	if err != nil {
+		return err
+	}
+
+	progress, err := h.onboardingService.GetProgress(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, progress)
+}
+
+// SetPreferences godoc
+// @Summary Set onboarding preferences
+// @Description Set the currency, timezone and language of the current organization, and its logo and brand color
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param preferences body dto.OnboardingPreferencesRequest true "Preferences"
+// @Success 200 {object} dto.OnboardingProgressResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /onboarding/preferences [put]
+func (h *OnboardingHandler) SetPreferences(c *fiber.Ctx) error {
+	tenantID, err := onboardingTenant(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.OnboardingPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	progress, err := h.onboardingService.SetPreferences(c.Context(), tenantID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, progress, "Preferences saved")
+}
+
+// SetupServices godoc
+// @Summary Set up onboarding services
+// @Description Seed a default service for each category chosen, unless the organization offers services already, and add the services listed. Services are priced in the organization's currency.
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param services body dto.OnboardingServicesRequest true "Categories and services"
+// @Success 200 {object} dto.OnboardingProgressResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /onboarding/services [post]
+func (h *OnboardingHandler) SetupServices(c *fiber.Ctx) error {
+	tenantID, err := onboardingTenant(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.OnboardingServicesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	progress, err := h.onboardingService.SetupServices(c.Context(), tenantID, &req)
+	if err != nil {
+		LogHandlerError(c, "setup_onboarding_services", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, progress, "Services set up")
+}
+
+// SetWorkingHours godoc
+// @Summary Set onboarding working hours
+// @Description Set the business hours of the current organization by weekday; days left out are closed
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param hours body dto.OnboardingWorkingHoursRequest true "Business hours"
+// @Success 200 {object} dto.OnboardingProgressResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /onboarding/working-hours [put]
+func (h *OnboardingHandler) SetWorkingHours(c *fiber.Ctx) error {
+	tenantID, err := onboardingTenant(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.OnboardingWorkingHoursRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	progress, err := h.onboardingService.SetWorkingHours(c.Context(), tenantID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, progress, "Working hours saved")
+}
+
+// onboardingTenant returns the tenant of the signed-in owner or admin, which is the
+// user's own tenant: owners sign in to Zitadel's default organization before any is
+// set up for their tenant
+func onboardingTenant(c *fiber.Ctx) (uuid.UUID, error) {
+	tenantID := middleware.RequestTenant(c)
+	if tenantID == uuid.Nil {
+		return uuid.Nil, NewErrorResponse(c, fiber.StatusForbidden, "NO_TENANT", "No organization to onboard", nil)
+	}
+	return tenantID, nil
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/pkg/panics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PanicHandler handles HTTP requests inspecting the panics recovered while serving requests
+type PanicHandler struct {
+	tracker *panics.Tracker
+}
+
+// NewPanicHandler creates a new panic handler
+func NewPanicHandler(tracker *panics.Tracker) *PanicHandler {
+	return &PanicHandler{
+		tracker: tracker,
+	}
+}
+
+// GetPanicReport godoc
+// @Summary Get recovered panics and error budgets
+// @Description Get the panics this instance recovered within the error budget window, grouped by stack fingerprint, and how much of each route's budget is left. Platform super admins only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} panics.Report
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/panics [get]
+func (h *PanicHandler) GetPanicReport(c *fiber.Ctx) error {
+	return NewSuccessResponse(c, h.tracker.Report())
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"net/http"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/payments"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service"
 	"Krafti_Vibe/internal/service/dto"
@@ -35,6 +37,7 @@ func NewPaymentHandler(paymentService service.PaymentService) *PaymentHandler {
 // @Accept json
 // @Produce json
 // @Param payment body dto.CreatePaymentRequest true "Payment creation data"
+// @Param Idempotency-Key header string false "Idempotency key for safe retries"
 // @Success 201 {object} dto.PaymentResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -77,6 +80,144 @@ func (h *PaymentHandler) GetPayment(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, payment)
 }
 
+// InitiatePayment godoc
+// @Summary Start a provider payment
+// @Description Start a payment for a booking with the tenant's payment provider (Stripe, PayPal, Paystack or M-Pesa). Complete it client-side with client_secret, or send the customer to redirect_url.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param payment body dto.InitiatePaymentRequest true "Payment data"
+// @Param Idempotency-Key header string false "Idempotency key for safe retries"
+// @Success 201 {object} dto.PaymentIntentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /payments/intents [post]
+func (h *PaymentHandler) InitiatePayment(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.InitiatePaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = tenantID
+
+	intent, err := h.paymentService.InitiatePayment(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "initiate_payment", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, intent, "Payment initiated successfully")
+}
+
+// CapturePayment godoc
+// @Summary Capture a provider payment
+// @Description Settle an authorized or customer-approved payment with the provider that started it
+// @Tags payments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment ID"
+// @Success 200 {object} dto.PaymentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /payments/{id}/capture [post]
+func (h *PaymentHandler) CapturePayment(c *fiber.Ctx) error {
+	paymentID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	payment, err := h.paymentService.CapturePayment(c.Context(), tenantID, paymentID)
+	if err != nil {
+		LogHandlerError(c, "capture_payment", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, payment, "Payment captured successfully")
+}
+
+// GetProviderHealth godoc
+// @Summary Payment provider health
+// @Description Get the circuit breaker state of each configured payment provider. Providers with an open circuit are skipped when starting payments, in favour of a tenant's fallback provider.
+// @Tags payments
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} payments.ProviderHealth
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/payment-providers/health [get]
+func (h *PaymentHandler) GetProviderHealth(c *fiber.Ctx) error {
+	SetNoCacheHeaders(c)
+	return NewSuccessResponse(c, h.paymentService.GetProviderHealth(c.Context()))
+}
+
+// PaymentWebhook godoc
+// @Summary Payment provider webhook
+// @Description Receive a signed event from a payment provider (stripe, paypal or paystack). Redelivered events are acknowledged without being applied twice.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /webhooks/payments/{provider} [post]
+func (h *PaymentHandler) PaymentWebhook(c *fiber.Ctx) error {
+	headers := http.Header{}
+	for key, values := range c.GetReqHeaders() {
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
+
+	if err := h.paymentService.HandleProviderCallback(c.Context(), c.Params("provider"), c.Body(), headers); err != nil {
+		LogHandlerError(c, "payment_webhook", err)
+		return HandleServiceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"received": true})
+}
+
+// MPesaCallback godoc
+// @Summary M-Pesa STK push callback
+// @Description Receive the result of an M-Pesa STK push. Safaricom does not sign callbacks, so the secret token in the path authenticates the request.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param token path string true "Callback token"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /webhooks/payments/mpesa/{token} [post]
+func (h *PaymentHandler) MPesaCallback(c *fiber.Ctx) error {
+	headers := http.Header{}
+	headers.Set(payments.CallbackTokenHeader, c.Params("token"))
+
+	if err := h.paymentService.HandleProviderCallback(c.Context(), payments.ProviderMPesa, c.Body(), headers); err != nil {
+		LogHandlerError(c, "mpesa_callback", err)
+		return HandleServiceError(c, err)
+	}
+
+	// Safaricom expects this acknowledgement shape
+	return c.JSON(fiber.Map{"ResultCode": 0, "ResultDesc": "Accepted"})
+}
+
 // GetPaymentsByBooking godoc
 // @Summary Get payments by booking
 // @Description Get all payments for a specific booking
@@ -109,6 +250,7 @@ func (h *PaymentHandler) GetPaymentsByBooking(c *fiber.Ctx) error {
 // @Param customer_id path string true "Customer ID"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(20)
+// @Param cursor query string false "Keyset cursor from a previous response (next_cursor); overrides page"
 // @Success 200 {object} dto.PaymentListResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -119,9 +261,15 @@ func (h *PaymentHandler) GetPaymentsByCustomer(c *fiber.Ctx) error {
 		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid customer ID", err)
 	}
 
+	cursor, ok := ParseCursorQuery(c)
+	if !ok {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CURSOR", "Invalid pagination cursor", nil)
+	}
+
 	pagination := repository.PaginationParams{
 		Page:     getIntQuery(c, "page", 1),
 		PageSize: getIntQuery(c, "page_size", 20),
+		Cursor:   cursor,
 	}
 
 	payments, err := h.paymentService.GetPaymentsByCustomer(c.Context(), customerID, pagination)
@@ -140,6 +288,7 @@ func (h *PaymentHandler) GetPaymentsByCustomer(c *fiber.Ctx) error {
 // @Param artisan_id path string true "Artisan ID"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(20)
+// @Param cursor query string false "Keyset cursor from a previous response (next_cursor); overrides page"
 // @Success 200 {object} dto.PaymentListResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -150,9 +299,15 @@ func (h *PaymentHandler) GetPaymentsByArtisan(c *fiber.Ctx) error {
 		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid artisan ID", err)
 	}
 
+	cursor, ok := ParseCursorQuery(c)
+	if !ok {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CURSOR", "Invalid pagination cursor", nil)
+	}
+
 	pagination := repository.PaginationParams{
 		Page:     getIntQuery(c, "page", 1),
 		PageSize: getIntQuery(c, "page_size", 20),
+		Cursor:   cursor,
 	}
 
 	payments, err := h.paymentService.GetPaymentsByArtisan(c.Context(), artisanID, pagination)
@@ -171,6 +326,7 @@ func (h *PaymentHandler) GetPaymentsByArtisan(c *fiber.Ctx) error {
 // @Param tenant_id query string true "Tenant ID"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(20)
+// @Param cursor query string false "Keyset cursor from a previous response (next_cursor); overrides page"
 // @Success 200 {object} dto.PaymentListResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -181,9 +337,15 @@ func (h *PaymentHandler) GetPaymentsByTenant(c *fiber.Ctx) error {
 		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_TENANT_ID", "Invalid tenant ID", err)
 	}
 
+	cursor, ok := ParseCursorQuery(c)
+	if !ok {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CURSOR", "Invalid pagination cursor", nil)
+	}
+
 	pagination := repository.PaginationParams{
 		Page:     getIntQuery(c, "page", 1),
 		PageSize: getIntQuery(c, "page_size", 20),
+		Cursor:   cursor,
 	}
 
 	payments, err := h.paymentService.GetPaymentsByTenant(c.Context(), tenantID, pagination)
@@ -305,6 +467,7 @@ func (h *PaymentHandler) GetPendingPayments(c *fiber.Ctx) error {
 // @Param refund body RefundRequest true "Refund data"
 // @Success 200 {object} dto.PaymentResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse "A recent multi-factor sign-in is required (step-up)"
 // @Failure 500 {object} ErrorResponse
 // @Router /payments/{id}/refund [post]
 func (h *PaymentHandler) ProcessRefund(c *fiber.Ctx) error {
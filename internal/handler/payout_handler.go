@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PayoutHandler handles HTTP requests for artisan payouts
+type PayoutHandler struct {
+	payoutService service.PayoutService
+}
+
+// NewPayoutHandler creates a new payout handler
+func NewPayoutHandler(payoutService service.PayoutService) *PayoutHandler {
+	return &PayoutHandler{
+		payoutService: payoutService,
+	}
+}
+
+// ============================================================================
+// Payout Batches
+// ============================================================================
+
+// CreatePayoutBatch godoc
+// @Summary Create a payout batch
+// @Description Pay artisans their unsettled earnings in one currency. Stripe Connect payouts are transferred immediately; bank transfer payouts are exported as a bank file and confirmed with mark-paid.
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param batch body dto.CreatePayoutBatchRequest true "Payout batch options"
+// @Success 201 {object} dto.PayoutBatchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse "A recent multi-factor sign-in is required (step-up)"
+// @Failure 503 {object} ErrorResponse
+// @Router /payouts/batches [post]
+func (h *PayoutHandler) CreatePayoutBatch(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreatePayoutBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = authCtx.TenantID
+	req.CreatedBy = authCtx.UserID
+
+	batch, err := h.payoutService.CreatePayoutBatch(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_payout_batch", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, batch, "Payout batch created successfully")
+}
+
+// ListPayoutBatches godoc
+// @Summary List payout batches
+// @Description List the tenant's payout batches, newest first
+// @Tags payouts
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.PayoutBatchListResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /payouts/batches [get]
+func (h *PayoutHandler) ListPayoutBatches(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	pagination := repository.PaginationParams{
+		Page:     getIntQuery(c, "page", 1),
+		PageSize: getIntQuery(c, "page_size", 20),
+	}
+
+	batches, err := h.payoutService.ListPayoutBatches(c.Context(), tenantID, pagination)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, batches)
+}
+
+// GetPayoutBatch godoc
+// @Summary Get a payout batch
+// @Description Get a payout batch with its payouts
+// @Tags payouts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Success 200 {object} dto.PayoutBatchResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /payouts/batches/{id} [get]
+func (h *PayoutHandler) GetPayoutBatch(c *fiber.Ctx) error {
+	batchID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	batch, err := h.payoutService.GetPayoutBatch(c.Context(), tenantID, batchID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, batch)
+}
+
+// DownloadBankFile godoc
+// @Summary Download a payout bank file
+// @Description Download a bank transfer batch as CSV, one row per payout still to be paid. The reference column is the payment reference to give the bank.
+// @Tags payouts
+// @Produce text/csv
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse "A recent multi-factor sign-in is required (step-up)"
+// @Failure 404 {object} ErrorResponse
+// @Router /payouts/batches/{id}/bank-file [get]
+func (h *PayoutHandler) DownloadBankFile(c *fiber.Ctx) error {
+	batchID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	file, err := h.payoutService.ExportBankFile(c.Context(), tenantID, batchID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetNoCacheHeaders(c)
+	c.Set(fiber.HeaderContentType, "text/csv")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	return c.Send(file.Content)
+}
+
+// MarkBatchPaid godoc
+// @Summary Mark a bank transfer batch paid
+// @Description Confirm that the tenant has paid every payout still in processing in a bank transfer batch
+// @Tags payouts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Batch ID"
+// @Success 200 {object} dto.PayoutBatchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse "A recent multi-factor sign-in is required (step-up)"
+// @Failure 404 {object} ErrorResponse
+// @Router /payouts/batches/{id}/mark-paid [post]
+func (h *PayoutHandler) MarkBatchPaid(c *fiber.Ctx) error {
+	batchID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	batch, err := h.payoutService.MarkBatchPaid(c.Context(), tenantID, batchID)
+	if err != nil {
+		LogHandlerError(c, "mark_payout_batch_paid", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, batch, "Payout batch marked as paid")
+}
+
+// MarkPayoutFailed godoc
+// @Summary Mark a payout failed
+// @Description Record that the bank rejected a payout. Its earnings are released to the next batch.
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payout ID"
+// @Param request body MarkFailedRequest true "Failure reason"
+// @Success 200 {object} dto.PayoutResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /payouts/{id}/mark-failed [post]
+func (h *PayoutHandler) MarkPayoutFailed(c *fiber.Ctx) error {
+	payoutID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req MarkFailedRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	payout, err := h.payoutService.MarkPayoutFailed(c.Context(), tenantID, payoutID, req.Reason)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, payout, "Payout marked as failed")
+}
+
+// ============================================================================
+// Artisan Payout History
+// ============================================================================
+
+// GetArtisanPayouts godoc
+// @Summary Get artisan payouts
+// @Description Get an artisan's payout history, newest first
+// @Tags payouts
+// @Produce json
+// @Security BearerAuth
+// @Param artisan_id path string true "Artisan user ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.PayoutListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /payouts/artisan/{artisan_id} [get]
+func (h *PayoutHandler) GetArtisanPayouts(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "artisan_id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	pagination := repository.PaginationParams{
+		Page:     getIntQuery(c, "page", 1),
+		PageSize: getIntQuery(c, "page_size", 20),
+	}
+
+	payouts, err := h.payoutService.GetArtisanPayouts(c.Context(), tenantID, artisanID, pagination)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, payouts)
+}
+
+// GetArtisanPayoutSummary godoc
+// @Summary Get artisan payout summary
+// @Description Get an artisan's earnings still to be paid out and total paid out in a currency
+// @Tags payouts
+// @Produce json
+// @Security BearerAuth
+// @Param artisan_id path string true "Artisan user ID"
+// @Param currency query string false "Currency code" default(USD)
+// @Success 200 {object} dto.ArtisanPayoutSummaryResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /payouts/artisan/{artisan_id}/summary [get]
+func (h *PayoutHandler) GetArtisanPayoutSummary(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "artisan_id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	currency := strings.ToUpper(c.Query("currency", "USD"))
+
+	summary, err := h.payoutService.GetArtisanPayoutSummary(c.Context(), tenantID, artisanID, currency)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, summary)
+}
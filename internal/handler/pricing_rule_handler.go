@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PricingRuleHandler handles HTTP requests for pricing rules
+type PricingRuleHandler struct {
+	pricingService service.PricingService
+}
+
+// NewPricingRuleHandler creates a new commission handler
+func NewPricingRuleHandler(pricingService service.PricingService) *PricingRuleHandler {
+	if pricingService == nil {
+		panic("pricing service cannot be nil")
+	}
+	return &PricingRuleHandler{
+		pricingService: pricingService,
+	}
+}
+
+// CreatePricingRule godoc
+// @Summary Create pricing rule
+// @Description Create a peak, off-peak, last-minute or seasonal price multiplier for the current tenant, optionally limited to a service, a service category or an artisan, whose rules override the tenant's rules of the same kind
+// @Tags pricing-rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body dto.CreatePricingRuleRequest true "Pricing rule data"
+// @Success 201 {object} dto.PricingRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pricing-rules [post]
+func (h *PricingRuleHandler) CreatePricingRule(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreatePricingRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = authCtx.TenantID
+
+	rule, err := h.pricingService.CreatePricingRule(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_pricing_rule", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, rule, "Pricing rule created successfully")
+}
+
+// ListPricingRules godoc
+// @Summary List pricing rules
+// @Description List the pricing rules of the current tenant
+// @Tags pricing-rules
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.PricingRuleResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pricing-rules [get]
+func (h *PricingRuleHandler) ListPricingRules(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	rules, err := h.pricingService.ListPricingRules(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, rules)
+}
+
+// GetPricingRule godoc
+// @Summary Get pricing rule
+// @Description Get a pricing rule by ID
+// @Tags pricing-rules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pricing rule ID"
+// @Success 200 {object} dto.PricingRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /pricing-rules/{id} [get]
+func (h *PricingRuleHandler) GetPricingRule(c *fiber.Ctx) error {
+	ruleID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rule, err := h.pricingService.GetPricingRule(c.Context(), ruleID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, rule.TenantID); err != nil {
+		return err
+	}
+
+	return NewSuccessResponse(c, rule)
+}
+
+// UpdatePricingRule godoc
+// @Summary Update pricing rule
+// @Description Update a pricing rule; bookings already made keep the prices they were made with
+// @Tags pricing-rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pricing rule ID"
+// @Param rule body dto.UpdatePricingRuleRequest true "Pricing rule data"
+// @Success 200 {object} dto.PricingRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /pricing-rules/{id} [put]
+func (h *PricingRuleHandler) UpdatePricingRule(c *fiber.Ctx) error {
+	ruleID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdatePricingRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	existing, err := h.pricingService.GetPricingRule(c.Context(), ruleID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, existing.TenantID); err != nil {
+		return err
+	}
+
+	rule, err := h.pricingService.UpdatePricingRule(c.Context(), ruleID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_pricing_rule", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, rule, "Pricing rule updated successfully")
+}
+
+// DeletePricingRule godoc
+// @Summary Delete pricing rule
+// @Description Delete a pricing rule
+// @Tags pricing-rules
+// @Security BearerAuth
+// @Param id path string true "Pricing rule ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /pricing-rules/{id} [delete]
+func (h *PricingRuleHandler) DeletePricingRule(c *fiber.Ctx) error {
+	ruleID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	existing, err := h.pricingService.GetPricingRule(c.Context(), ruleID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, existing.TenantID); err != nil {
+		return err
+	}
+
+	if err := h.pricingService.DeletePricingRule(c.Context(), ruleID); err != nil {
+		LogHandlerError(c, "delete_pricing_rule", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
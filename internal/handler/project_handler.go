@@ -555,12 +555,39 @@ func (h *ProjectHandler) GetProjectHealth(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, health)
 }
 
+// GetProjectSchedule godoc
+// @Summary Get project schedule
+// @Description Get the critical path schedule of the project's remaining work: each task's earliest and latest start and finish, its slack, and the projected finish date. Tasks take their estimated hours minus tracked hours, 8 working hours a day.
+// @Tags projects
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {object} dto.ProjectScheduleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /projects/{id}/schedule [get]
+func (h *ProjectHandler) GetProjectSchedule(c *fiber.Ctx) error {
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid project ID", err)
+	}
+
+	schedule, err := h.projectService.GetProjectSchedule(c.Context(), projectID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, schedule)
+}
+
 // GetProjectTimeline godoc
 // @Summary Get project timeline
-// @Description Get project timeline
+// @Description Get the project's updates, status changes, milestone and task completions and changes to linked bookings merged into one timeline, most recent first
 // @Tags projects
 // @Produce json
 // @Param id path string true "Project ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
 // @Success 200 {object} dto.ProjectTimelineResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -571,7 +598,8 @@ func (h *ProjectHandler) GetProjectTimeline(c *fiber.Ctx) error {
 		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid project ID", err)
 	}
 
-	timeline, err := h.projectService.GetProjectTimeline(c.Context(), projectID)
+	page, pageSize := ParsePagination(c)
+	timeline, err := h.projectService.GetProjectTimeline(c.Context(), projectID, page, pageSize)
 	if err != nil {
 		return HandleServiceError(c, err)
 	}
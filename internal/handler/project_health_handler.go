@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ProjectHealthHandler handles HTTP requests for project health trends
+type ProjectHealthHandler struct {
+	projectHealthService service.ProjectHealthService
+}
+
+// NewProjectHealthHandler creates a new project health handler
+func NewProjectHealthHandler(projectHealthService service.ProjectHealthService) *ProjectHealthHandler {
+	if projectHealthService == nil {
+		panic("project health service cannot be nil")
+	}
+	return &ProjectHealthHandler{
+		projectHealthService: projectHealthService,
+	}
+}
+
+// GetProjectHealthHistory godoc
+// @Summary Get project health history
+// @Description Get the health scores recorded for a project by the health recalculation job, oldest first, for trend charts
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param days query int false "Days of history (max 365)" default(30)
+// @Success 200 {object} dto.ProjectHealthHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /projects/{id}/health/history [get]
+func (h *ProjectHealthHandler) GetProjectHealthHistory(c *fiber.Ctx) error {
+	projectID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	history, err := h.projectHealthService.GetHealthHistory(c.Context(), tenantID, projectID, c.QueryInt("days", 0))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, history)
+}
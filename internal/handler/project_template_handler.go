@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ProjectTemplateHandler handles HTTP requests for project templates
+type ProjectTemplateHandler struct {
+	templateService service.ProjectTemplateService
+}
+
+// NewProjectTemplateHandler creates a new project template handler
+func NewProjectTemplateHandler(templateService service.ProjectTemplateService) *ProjectTemplateHandler {
+	if templateService == nil {
+		panic("project template service cannot be nil")
+	}
+	return &ProjectTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// CreateProjectTemplate godoc
+// @Summary Create project template
+// @Description Create a reusable project plan. Milestone and task timing is given in days from the project start; tasks refer to milestones and other tasks by key.
+// @Tags project-templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param template body dto.CreateProjectTemplateRequest true "Template data"
+// @Success 201 {object} dto.ProjectTemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /project-templates [post]
+func (h *ProjectTemplateHandler) CreateProjectTemplate(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateProjectTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	template, err := h.templateService.CreateTemplate(c.Context(), tenantID, &req)
+	if err != nil {
+		LogHandlerError(c, "create_project_template", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, template, "Project template created successfully")
+}
+
+// ListProjectTemplates godoc
+// @Summary List project templates
+// @Description List the tenant's project templates by name
+// @Tags project-templates
+// @Produce json
+// @Security BearerAuth
+// @Param category query string false "Category"
+// @Param is_active query bool false "Only active or inactive templates"
+// @Param search query string false "Search in name and description"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.ProjectTemplateListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /project-templates [get]
+func (h *ProjectTemplateHandler) ListProjectTemplates(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	isActive, err := parseOptionalBoolQuery(c, "is_active")
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER", err.Error(), err)
+	}
+
+	page, pageSize := ParsePagination(c)
+	templates, err := h.templateService.ListTemplates(c.Context(), dto.ProjectTemplateFilter{
+		TenantID: tenantID,
+		Category: c.Query("category"),
+		IsActive: isActive,
+		Search:   c.Query("search"),
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, templates)
+}
+
+// GetProjectTemplate godoc
+// @Summary Get project template
+// @Description Get a project template by ID
+// @Tags project-templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 200 {object} dto.ProjectTemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /project-templates/{id} [get]
+func (h *ProjectTemplateHandler) GetProjectTemplate(c *fiber.Ctx) error {
+	templateID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.templateService.GetTemplate(c.Context(), tenantID, templateID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, template)
+}
+
+// UpdateProjectTemplate godoc
+// @Summary Update project template
+// @Description Edit a project template. Milestones and tasks, when given, replace the whole plan. Projects already created from the template are not changed.
+// @Tags project-templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param template body dto.UpdateProjectTemplateRequest true "Fields to change"
+// @Success 200 {object} dto.ProjectTemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /project-templates/{id} [put]
+func (h *ProjectTemplateHandler) UpdateProjectTemplate(c *fiber.Ctx) error {
+	templateID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateProjectTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	template, err := h.templateService.UpdateTemplate(c.Context(), tenantID, templateID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_project_template", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, template, "Project template updated successfully")
+}
+
+// DeleteProjectTemplate godoc
+// @Summary Delete project template
+// @Description Delete a project template. Projects created from it are kept.
+// @Tags project-templates
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /project-templates/{id} [delete]
+func (h *ProjectTemplateHandler) DeleteProjectTemplate(c *fiber.Ctx) error {
+	templateID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.templateService.DeleteTemplate(c.Context(), tenantID, templateID); err != nil {
+		LogHandlerError(c, "delete_project_template", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// CreateProjectFromTemplate godoc
+// @Summary Create project from template
+// @Description Create a project with the template's milestones, tasks and task dependencies. Every date is offset from the given start date; tasks with dependencies start blocked.
+// @Tags project-templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param project body dto.CreateProjectFromTemplateRequest true "Project data"
+// @Success 201 {object} dto.TemplateProjectResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /project-templates/{id}/projects [post]
+func (h *ProjectTemplateHandler) CreateProjectFromTemplate(c *fiber.Ctx) error {
+	templateID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateProjectFromTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	project, err := h.templateService.CreateProjectFromTemplate(c.Context(), tenantID, templateID, &req)
+	if err != nil {
+		LogHandlerError(c, "create_project_from_template", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, project, "Project created from template")
+}
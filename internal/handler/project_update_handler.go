@@ -39,10 +39,7 @@ func (h *ProjectUpdateHandler) CreateProjectUpdate(c *fiber.Ctx) error {
 
 	var req dto.CreateProjectUpdateRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	// Set user ID from auth context
@@ -72,10 +69,7 @@ func (h *ProjectUpdateHandler) GetProjectUpdate(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid update ID",
-			"code":  "INVALID_UPDATE_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UPDATE_ID", "invalid update ID", nil)
 	}
 
 	update, err := h.service.GetProjectUpdate(c.Context(), id, authCtx.TenantID)
@@ -104,18 +98,12 @@ func (h *ProjectUpdateHandler) UpdateProjectUpdate(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid update ID",
-			"code":  "INVALID_UPDATE_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UPDATE_ID", "invalid update ID", nil)
 	}
 
 	var req dto.UpdateProjectUpdateRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	update, err := h.service.UpdateProjectUpdate(c.Context(), id, authCtx.TenantID, &req)
@@ -142,10 +130,7 @@ func (h *ProjectUpdateHandler) DeleteProjectUpdate(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid update ID",
-			"code":  "INVALID_UPDATE_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UPDATE_ID", "invalid update ID", nil)
 	}
 
 	if err := h.service.DeleteProjectUpdate(c.Context(), id, authCtx.TenantID); err != nil {
@@ -171,18 +156,12 @@ func (h *ProjectUpdateHandler) DeleteProjectUpdate(c *fiber.Ctx) error {
 func (h *ProjectUpdateHandler) ListProjectUpdates(c *fiber.Ctx) error {
 	projectIDStr := c.Query("project_id")
 	if projectIDStr == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "project_id is required",
-			"code":  "MISSING_PROJECT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_PROJECT_ID", "project_id is required", nil)
 	}
 
 	projectID, err := uuid.Parse(projectIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid project_id",
-			"code":  "INVALID_PROJECT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROJECT_ID", "invalid project_id", nil)
 	}
 
 	page := c.QueryInt("page", 1)
@@ -232,10 +211,7 @@ func (h *ProjectUpdateHandler) ListByType(c *fiber.Ctx) error {
 
 	projectID, err := uuid.Parse(c.Params("project_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid project_id",
-			"code":  "INVALID_PROJECT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROJECT_ID", "invalid project_id", nil)
 	}
 
 	updateType := models.UpdateType(c.Params("type"))
@@ -267,10 +243,7 @@ func (h *ProjectUpdateHandler) ListCustomerVisible(c *fiber.Ctx) error {
 
 	projectID, err := uuid.Parse(c.Params("project_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid project_id",
-			"code":  "INVALID_PROJECT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROJECT_ID", "invalid project_id", nil)
 	}
 
 	page := c.QueryInt("page", 1)
@@ -300,10 +273,7 @@ func (h *ProjectUpdateHandler) GetLatestUpdate(c *fiber.Ctx) error {
 
 	projectID, err := uuid.Parse(c.Params("project_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid project_id",
-			"code":  "INVALID_PROJECT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROJECT_ID", "invalid project_id", nil)
 	}
 
 	update, err := h.service.GetLatestUpdate(c.Context(), projectID, authCtx.TenantID)
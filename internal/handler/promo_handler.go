@@ -44,10 +44,7 @@ func (h *PromoCodeHandler) CreatePromoCode(c *fiber.Ctx) error {
 
 	var req dto.CreatePromoCodeRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	tenantID := &authCtx.TenantID
@@ -72,10 +69,7 @@ func (h *PromoCodeHandler) CreatePromoCode(c *fiber.Ctx) error {
 func (h *PromoCodeHandler) GetPromoCode(c *fiber.Ctx) error {
 	promoID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid promo code ID",
-			"code":  "INVALID_PROMO_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROMO_ID", "invalid promo code ID", nil)
 	}
 
 	promo, err := h.promoService.GetPromoCode(c.Context(), promoID)
@@ -99,10 +93,7 @@ func (h *PromoCodeHandler) GetPromoCode(c *fiber.Ctx) error {
 func (h *PromoCodeHandler) GetPromoCodeByCode(c *fiber.Ctx) error {
 	code := c.Params("code")
 	if code == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "promo code is required",
-			"code":  "MISSING_CODE",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_CODE", "promo code is required", nil)
 	}
 
 	promo, err := h.promoService.GetPromoCodeByCode(c.Context(), code)
@@ -128,18 +119,12 @@ func (h *PromoCodeHandler) GetPromoCodeByCode(c *fiber.Ctx) error {
 func (h *PromoCodeHandler) UpdatePromoCode(c *fiber.Ctx) error {
 	promoID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid promo code ID",
-			"code":  "INVALID_PROMO_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROMO_ID", "invalid promo code ID", nil)
 	}
 
 	var req dto.UpdatePromoCodeRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	promo, err := h.promoService.UpdatePromoCode(c.Context(), promoID, &req)
@@ -162,10 +147,7 @@ func (h *PromoCodeHandler) UpdatePromoCode(c *fiber.Ctx) error {
 func (h *PromoCodeHandler) DeletePromoCode(c *fiber.Ctx) error {
 	promoID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid promo code ID",
-			"code":  "INVALID_PROMO_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROMO_ID", "invalid promo code ID", nil)
 	}
 
 	if err := h.promoService.DeletePromoCode(c.Context(), promoID); err != nil {
@@ -323,10 +305,7 @@ func (h *PromoCodeHandler) SearchPromoCodes(c *fiber.Ctx) error {
 
 	query := c.Query("q")
 	if query == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "search query is required",
-			"code":  "MISSING_QUERY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_QUERY", "search query is required", nil)
 	}
 
 	page, _ := strconv.Atoi(c.Query("page", "1"))
@@ -359,10 +338,7 @@ func (h *PromoCodeHandler) ValidatePromoCode(c *fiber.Ctx) error {
 
 	var req dto.ValidatePromoCodeRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	tenantID := &authCtx.TenantID
@@ -398,10 +374,7 @@ func (h *PromoCodeHandler) ApplyPromoCode(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	tenantID := &authCtx.TenantID
@@ -432,10 +405,7 @@ func (h *PromoCodeHandler) ApplyPromoCode(c *fiber.Ctx) error {
 func (h *PromoCodeHandler) ActivatePromoCode(c *fiber.Ctx) error {
 	promoID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid promo code ID",
-			"code":  "INVALID_PROMO_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROMO_ID", "invalid promo code ID", nil)
 	}
 
 	if err := h.promoService.ActivatePromoCode(c.Context(), promoID); err != nil {
@@ -458,10 +428,7 @@ func (h *PromoCodeHandler) ActivatePromoCode(c *fiber.Ctx) error {
 func (h *PromoCodeHandler) DeactivatePromoCode(c *fiber.Ctx) error {
 	promoID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid promo code ID",
-			"code":  "INVALID_PROMO_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROMO_ID", "invalid promo code ID", nil)
 	}
 
 	if err := h.promoService.DeactivatePromoCode(c.Context(), promoID); err != nil {
@@ -489,10 +456,7 @@ func (h *PromoCodeHandler) BulkActivate(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	if err := h.promoService.BulkActivate(c.Context(), body.IDs); err != nil {
@@ -521,10 +485,7 @@ func (h *PromoCodeHandler) BulkDeactivate(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	if err := h.promoService.BulkDeactivate(c.Context(), body.IDs); err != nil {
@@ -553,10 +514,7 @@ func (h *PromoCodeHandler) BulkDelete(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	if err := h.promoService.BulkDelete(c.Context(), body.IDs); err != nil {
@@ -581,10 +539,7 @@ func (h *PromoCodeHandler) BulkDelete(c *fiber.Ctx) error {
 func (h *PromoCodeHandler) GetValidPromoCodesForService(c *fiber.Ctx) error {
 	serviceID, err := uuid.Parse(c.Params("service_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid service ID",
-			"code":  "INVALID_SERVICE_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_SERVICE_ID", "invalid service ID", nil)
 	}
 
 	promos, err := h.promoService.GetValidPromoCodesForService(c.Context(), serviceID)
@@ -607,10 +562,7 @@ func (h *PromoCodeHandler) GetValidPromoCodesForService(c *fiber.Ctx) error {
 func (h *PromoCodeHandler) GetValidPromoCodesForArtisan(c *fiber.Ctx) error {
 	artisanID, err := uuid.Parse(c.Params("artisan_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid artisan ID",
-			"code":  "INVALID_ARTISAN_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ARTISAN_ID", "invalid artisan ID", nil)
 	}
 
 	promos, err := h.promoService.GetValidPromoCodesForArtisan(c.Context(), artisanID)
@@ -633,10 +585,7 @@ func (h *PromoCodeHandler) GetValidPromoCodesForArtisan(c *fiber.Ctx) error {
 func (h *PromoCodeHandler) GetPromoCodeStats(c *fiber.Ctx) error {
 	promoID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid promo code ID",
-			"code":  "INVALID_PROMO_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_PROMO_ID", "invalid promo code ID", nil)
 	}
 
 	stats, err := h.promoService.GetPromoCodeStats(c.Context(), promoID)
@@ -671,10 +620,7 @@ func (h *PromoCodeHandler) GetTopPerformingPromoCodes(c *fiber.Ctx) error {
 	if startDateStr := c.Query("start_date"); startDateStr != "" {
 		startDate, err = time.Parse("2006-01-02", startDateStr)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid start date format, use YYYY-MM-DD",
-				"code":  "INVALID_DATE_FORMAT",
-			})
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DATE_FORMAT", "invalid start date format, use YYYY-MM-DD", nil)
 		}
 	} else {
 		startDate = time.Now().AddDate(0, -1, 0) // Default: 1 month ago
@@ -683,10 +629,7 @@ func (h *PromoCodeHandler) GetTopPerformingPromoCodes(c *fiber.Ctx) error {
 	if endDateStr := c.Query("end_date"); endDateStr != "" {
 		endDate, err = time.Parse("2006-01-02", endDateStr)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid end date format, use YYYY-MM-DD",
-				"code":  "INVALID_DATE_FORMAT",
-			})
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DATE_FORMAT", "invalid end date format, use YYYY-MM-DD", nil)
 		}
 	} else {
 		endDate = time.Now()
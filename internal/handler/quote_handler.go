@@ -0,0 +1,435 @@
+package handler
+
+import (
+	"fmt"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// QuoteHandler handles HTTP requests for quotes
+type QuoteHandler struct {
+	quoteService service.QuoteService
+}
+
+// NewQuoteHandler creates a new quote handler
+func NewQuoteHandler(quoteService service.QuoteService) *QuoteHandler {
+	if quoteService == nil {
+		panic("quote service cannot be nil")
+	}
+	return &QuoteHandler{
+		quoteService: quoteService,
+	}
+}
+
+// ============================================================================
+// Staff
+// ============================================================================
+
+// CreateQuote godoc
+// @Summary Create quote
+// @Description Draft a quote from an artisan to a customer. Line totals, subtotal and total are computed.
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param quote body dto.CreateQuoteRequest true "Quote data"
+// @Success 201 {object} dto.QuoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /quotes [post]
+func (h *QuoteHandler) CreateQuote(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateQuoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	quote, err := h.quoteService.CreateQuote(c.Context(), tenantID, &req)
+	if err != nil {
+		LogHandlerError(c, "create_quote", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, quote, "Quote created successfully")
+}
+
+// ListQuotes godoc
+// @Summary List quotes
+// @Description List the tenant's quotes, newest first
+// @Tags quotes
+// @Produce json
+// @Security BearerAuth
+// @Param artisan_id query string false "Artisan ID"
+// @Param customer_id query string false "Customer ID"
+// @Param status query string false "Comma-separated statuses (draft, sent, accepted, declined, expired)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.QuoteListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /quotes [get]
+func (h *QuoteHandler) ListQuotes(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseQuoteFilter(c)
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER", err.Error(), err)
+	}
+	filter.TenantID = tenantID
+
+	quotes, err := h.quoteService.ListQuotes(c.Context(), filter)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, quotes)
+}
+
+// GetQuote godoc
+// @Summary Get quote
+// @Description Get a quote by ID
+// @Tags quotes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Quote ID"
+// @Success 200 {object} dto.QuoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /quotes/{id} [get]
+func (h *QuoteHandler) GetQuote(c *fiber.Ctx) error {
+	quoteID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	quote, err := h.quoteService.GetQuote(c.Context(), tenantID, quoteID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, quote)
+}
+
+// UpdateQuote godoc
+// @Summary Update quote
+// @Description Edit a quote. Editing a sent, declined or expired quote starts a new draft revision that has to be sent again. Accepted quotes cannot be changed.
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Quote ID"
+// @Param quote body dto.UpdateQuoteRequest true "Fields to change"
+// @Success 200 {object} dto.QuoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /quotes/{id} [put]
+func (h *QuoteHandler) UpdateQuote(c *fiber.Ctx) error {
+	quoteID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateQuoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	quote, err := h.quoteService.UpdateQuote(c.Context(), tenantID, quoteID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_quote", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, quote, "Quote updated successfully")
+}
+
+// DeleteQuote godoc
+// @Summary Delete quote
+// @Description Delete a quote that was never sent
+// @Tags quotes
+// @Security BearerAuth
+// @Param id path string true "Quote ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /quotes/{id} [delete]
+func (h *QuoteHandler) DeleteQuote(c *fiber.Ctx) error {
+	quoteID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.quoteService.DeleteQuote(c.Context(), tenantID, quoteID); err != nil {
+		LogHandlerError(c, "delete_quote", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// SendQuote godoc
+// @Summary Send quote
+// @Description Send a draft quote to the customer. The sent content is kept in the quote's revision history.
+// @Tags quotes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Quote ID"
+// @Success 200 {object} dto.QuoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /quotes/{id}/send [post]
+func (h *QuoteHandler) SendQuote(c *fiber.Ctx) error {
+	quoteID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	quote, err := h.quoteService.SendQuote(c.Context(), tenantID, quoteID)
+	if err != nil {
+		LogHandlerError(c, "send_quote", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, quote, "Quote sent")
+}
+
+// ListQuoteRevisions godoc
+// @Summary List quote revisions
+// @Description List the revisions of a quote that were sent to the customer, oldest first
+// @Tags quotes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Quote ID"
+// @Success 200 {array} dto.QuoteRevisionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /quotes/{id}/revisions [get]
+func (h *QuoteHandler) ListQuoteRevisions(c *fiber.Ctx) error {
+	quoteID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.quoteService.ListRevisions(c.Context(), tenantID, quoteID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, revisions)
+}
+
+// ============================================================================
+// Customers
+// ============================================================================
+
+// ListReceivedQuotes godoc
+// @Summary List my quotes
+// @Description List the quotes sent to the current customer, newest first
+// @Tags quotes
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.QuoteListResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /quotes/received [get]
+func (h *QuoteHandler) ListReceivedQuotes(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	page, pageSize := ParsePagination(c)
+	quotes, err := h.quoteService.ListCustomerQuotes(c.Context(), authCtx.TenantID, authCtx.UserID, page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, quotes)
+}
+
+// GetReceivedQuote godoc
+// @Summary Get my quote
+// @Description Get a quote sent to the current customer
+// @Tags quotes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Quote ID"
+// @Success 200 {object} dto.QuoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /quotes/received/{id} [get]
+func (h *QuoteHandler) GetReceivedQuote(c *fiber.Ctx) error {
+	quoteID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	quote, err := h.quoteService.GetCustomerQuote(c.Context(), authCtx.TenantID, authCtx.UserID, quoteID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, quote)
+}
+
+// AcceptQuote godoc
+// @Summary Accept quote
+// @Description Accept a quote sent to the current customer. The quote becomes a project with one task per line item, and a draft deposit invoice is raised when the quote asks for a deposit.
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Quote ID"
+// @Param acceptance body dto.AcceptQuoteRequest false "Revision reviewed"
+// @Success 200 {object} dto.QuoteAcceptanceResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /quotes/{id}/accept [post]
+func (h *QuoteHandler) AcceptQuote(c *fiber.Ctx) error {
+	quoteID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.AcceptQuoteRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		}
+	}
+
+	result, err := h.quoteService.AcceptQuote(c.Context(), authCtx.TenantID, authCtx.UserID, quoteID, &req)
+	if err != nil {
+		LogHandlerError(c, "accept_quote", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result, "Quote accepted")
+}
+
+// DeclineQuote godoc
+// @Summary Decline quote
+// @Description Decline a quote sent to the current customer
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Quote ID"
+// @Param decline body dto.DeclineQuoteRequest false "Revision reviewed and reason"
+// @Success 200 {object} dto.QuoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /quotes/{id}/decline [post]
+func (h *QuoteHandler) DeclineQuote(c *fiber.Ctx) error {
+	quoteID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.DeclineQuoteRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		}
+	}
+
+	quote, err := h.quoteService.DeclineQuote(c.Context(), authCtx.TenantID, authCtx.UserID, quoteID, &req)
+	if err != nil {
+		LogHandlerError(c, "decline_quote", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, quote, "Quote declined")
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+func parseQuoteFilter(c *fiber.Ctx) (dto.QuoteFilter, error) {
+	var filter dto.QuoteFilter
+
+	filter.Page, filter.PageSize = ParsePagination(c)
+	for _, id := range []struct {
+		key    string
+		target **uuid.UUID
+	}{
+		{"artisan_id", &filter.ArtisanID},
+		{"customer_id", &filter.CustomerID},
+	} {
+		if value := c.Query(id.key); value != "" {
+			parsed, err := uuid.Parse(value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s: %q is not a valid ID", id.key, value)
+			}
+			*id.target = &parsed
+		}
+	}
+	for _, value := range splitListQuery(c, "status") {
+		status := models.QuoteStatus(value)
+		switch status {
+		case models.QuoteStatusDraft, models.QuoteStatusSent, models.QuoteStatusAccepted,
+			models.QuoteStatusDeclined, models.QuoteStatusExpired:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return filter, fmt.Errorf("invalid status: %q", value)
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"time"
+
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReconciliationHandler handles HTTP requests for payment reconciliation reports
+type ReconciliationHandler struct {
+	reconciliationService service.ReconciliationService
+}
+
+// NewReconciliationHandler creates a new reconciliation handler
+func NewReconciliationHandler(reconciliationService service.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// GetReconciliation godoc
+// @Summary Get a day's payment reconciliation
+// @Description Get the reconciliation reports for a UTC day, one per payment provider, with every payment that did not match the provider's balance transactions. Platform staff only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param date path string true "Day (YYYY-MM-DD)"
+// @Success 200 {object} dto.ReconciliationDayResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/reconciliation/{date} [get]
+func (h *ReconciliationHandler) GetReconciliation(c *fiber.Ctx) error {
+	date, err := time.Parse(time.DateOnly, c.Params("date"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DATE", "Date must be formatted YYYY-MM-DD", err)
+	}
+
+	report, err := h.reconciliationService.GetReconciliation(c.Context(), date)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, report)
+}
+
+// RunReconciliation godoc
+// @Summary Re-run a day's payment reconciliation
+// @Description Reconcile a past UTC day again, replacing its reports. Platform staff only.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param date path string true "Day (YYYY-MM-DD)"
+// @Success 200 {object} dto.ReconciliationDayResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/reconciliation/{date}/run [post]
+func (h *ReconciliationHandler) RunReconciliation(c *fiber.Ctx) error {
+	date, err := time.Parse(time.DateOnly, c.Params("date"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DATE", "Date must be formatted YYYY-MM-DD", err)
+	}
+
+	report, err := h.reconciliationService.Reconcile(c.Context(), date)
+	if err != nil {
+		LogHandlerError(c, "run_reconciliation", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, report, "Reconciliation completed")
+}
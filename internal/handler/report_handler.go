@@ -44,10 +44,7 @@ func (h *ReportHandler) CreateReport(c *fiber.Ctx) error {
 
 	var req dto.CreateReportRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	report, err := h.reportService.CreateReport(c.Context(), authCtx.TenantID, authCtx.UserID, &req)
@@ -76,10 +73,7 @@ func (h *ReportHandler) GetReport(c *fiber.Ctx) error {
 
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	report, err := h.reportService.GetReport(c.Context(), reportID, authCtx.UserID)
@@ -110,18 +104,12 @@ func (h *ReportHandler) UpdateReport(c *fiber.Ctx) error {
 
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	var req dto.UpdateReportRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	report, err := h.reportService.UpdateReport(c.Context(), reportID, authCtx.UserID, &req)
@@ -149,10 +137,7 @@ func (h *ReportHandler) DeleteReport(c *fiber.Ctx) error {
 
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	if err := h.reportService.DeleteReport(c.Context(), reportID, authCtx.UserID); err != nil {
@@ -297,10 +282,7 @@ func (h *ReportHandler) GetFailedReports(c *fiber.Ctx) error {
 func (h *ReportHandler) MarkAsGenerating(c *fiber.Ctx) error {
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	if err := h.reportService.MarkAsGenerating(c.Context(), reportID); err != nil {
@@ -326,10 +308,7 @@ func (h *ReportHandler) MarkAsGenerating(c *fiber.Ctx) error {
 func (h *ReportHandler) MarkAsCompleted(c *fiber.Ctx) error {
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	var body struct {
@@ -337,10 +316,7 @@ func (h *ReportHandler) MarkAsCompleted(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	if err := h.reportService.MarkAsCompleted(c.Context(), reportID, body.FileURL); err != nil {
@@ -366,10 +342,7 @@ func (h *ReportHandler) MarkAsCompleted(c *fiber.Ctx) error {
 func (h *ReportHandler) MarkAsFailed(c *fiber.Ctx) error {
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	var body struct {
@@ -377,10 +350,7 @@ func (h *ReportHandler) MarkAsFailed(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	if err := h.reportService.MarkAsFailed(c.Context(), reportID, body.ErrorMessage); err != nil {
@@ -408,10 +378,7 @@ func (h *ReportHandler) RetryFailedReport(c *fiber.Ctx) error {
 
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	if err := h.reportService.RetryFailedReport(c.Context(), reportID, authCtx.UserID); err != nil {
@@ -439,10 +406,7 @@ func (h *ReportHandler) EnableSchedule(c *fiber.Ctx) error {
 
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	if err := h.reportService.EnableSchedule(c.Context(), reportID, authCtx.UserID); err != nil {
@@ -470,10 +434,7 @@ func (h *ReportHandler) DisableSchedule(c *fiber.Ctx) error {
 
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	if err := h.reportService.DisableSchedule(c.Context(), reportID, authCtx.UserID); err != nil {
@@ -504,10 +465,7 @@ func (h *ReportHandler) UpdateScheduleCron(c *fiber.Ctx) error {
 
 	reportID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid report ID",
-			"code":  "INVALID_REPORT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REPORT_ID", "invalid report ID", nil)
 	}
 
 	var body struct {
@@ -515,10 +473,7 @@ func (h *ReportHandler) UpdateScheduleCron(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	if err := h.reportService.UpdateScheduleCron(c.Context(), reportID, authCtx.UserID, body.CronExpression); err != nil {
@@ -697,10 +652,7 @@ func (h *ReportHandler) SearchReports(c *fiber.Ctx) error {
 
 	query := c.Query("q")
 	if query == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "search query is required",
-			"code":  "MISSING_QUERY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_QUERY", "search query is required", nil)
 	}
 
 	page, _ := strconv.Atoi(c.Query("page", "1"))
@@ -4,10 +4,13 @@ import (
 	"errors"
 	"time"
 
+	"Krafti_Vibe/internal/middleware"
 	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/tracing"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // SuccessResponse represents a successful API response
@@ -17,54 +20,25 @@ type SuccessResponse struct {
 	Data    any    `json:"data,omitempty"`
 }
 
-// ErrorResponse represents an error API response
-type ErrorResponse struct {
-	Success   bool   `json:"success"`
-	Error     string `json:"error"`
-	Code      string `json:"code"`
-	Message   string `json:"message"`
-	RequestID string `json:"request_id,omitempty"`
-}
+// ErrorResponse is the body of every error response: an RFC 7807 problem detail,
+// served as application/problem+json
+type ErrorResponse = pkgErrors.Problem
 
 // ValidationError represents a validation error with field details
-type ValidationError struct {
-	Field   string `json:"field"`
-	Message string `json:"message"`
-}
+type ValidationError = pkgErrors.FieldError
 
-// ValidationErrorResponse represents validation errors
-type ValidationErrorResponse struct {
-	Success   bool              `json:"success"`
-	Error     string            `json:"error"`
-	Code      string            `json:"code"`
-	Message   string            `json:"message"`
-	Errors    []ValidationError `json:"errors,omitempty"`
-	RequestID string            `json:"request_id,omitempty"`
-}
-
-// NewErrorResponse creates a new error response
+// NewErrorResponse creates a new error response. The message is the problem's detail;
+// err is only there for the caller's logs and is not reported, except for the fields
+// a validation error lists.
 func NewErrorResponse(c *fiber.Ctx, status int, code, message string, err error) error {
-	response := ErrorResponse{
-		Success: false,
-		Error:   message,
-		Code:    code,
-		Message: message,
-	}
+	problem := pkgErrors.NewProblem(status, pkgErrors.ErrorCode(code), message)
 
-	// Add request ID if available
-	if reqID := c.Locals("request_id"); reqID != nil {
-		if id, ok := reqID.(string); ok {
-			response.RequestID = id
-		}
-	}
-
-	// Log error if provided
-	if err != nil {
-		// You could add logging here
-		response.Message = err.Error()
+	var appErr *pkgErrors.AppError
+	if errors.As(err, &appErr) {
+		problem.Errors = appErr.Fields
 	}
 
-	return c.Status(status).JSON(response)
+	return middleware.SendProblem(c, problem)
 }
 
 // HandleServiceError handles errors from the service layer
@@ -73,34 +47,7 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 		return nil
 	}
 
-	// Get request ID
-	var requestID string
-	if reqID := c.Locals("request_id"); reqID != nil {
-		if id, ok := reqID.(string); ok {
-			requestID = id
-		}
-	}
-
-	// Handle AppError type
-	var appErr *pkgErrors.AppError
-	if errors.As(err, &appErr) {
-		return c.Status(appErr.HTTPStatus).JSON(ErrorResponse{
-			Success:   false,
-			Error:     appErr.Message,
-			Code:      string(appErr.Code),
-			Message:   appErr.Error(),
-			RequestID: requestID,
-		})
-	}
-
-	// Generic error
-	return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-		Success:   false,
-		Error:     "Internal Server Error",
-		Code:      "INTERNAL_ERROR",
-		Message:   err.Error(),
-		RequestID: requestID,
-	})
+	return middleware.SendProblem(c, pkgErrors.ToProblem(err))
 }
 
 // NewSuccessResponse creates a new success response
@@ -173,96 +120,36 @@ func NewPaginatedResponse(c *fiber.Ctx, data any, pagination *Pagination, messag
 
 // NewValidationErrorResponse creates a validation error response
 func NewValidationErrorResponse(c *fiber.Ctx, validationErrors []ValidationError) error {
-	requestID := getRequestID(c)
-
-	response := ValidationErrorResponse{
-		Success:   false,
-		Error:     "Validation failed",
-		Code:      "VALIDATION_ERROR",
-		Message:   "One or more fields failed validation",
-		Errors:    validationErrors,
-		RequestID: requestID,
-	}
-
-	return c.Status(fiber.StatusBadRequest).JSON(response)
+	return middleware.SendValidationError(c, fiber.StatusBadRequest, "One or more fields failed validation", validationErrors)
 }
 
 // NewUnauthorizedResponse creates an unauthorized error response
 func NewUnauthorizedResponse(c *fiber.Ctx, message string) error {
-	requestID := getRequestID(c)
-
-	response := ErrorResponse{
-		Success:   false,
-		Error:     "Unauthorized",
-		Code:      "UNAUTHORIZED",
-		Message:   message,
-		RequestID: requestID,
-	}
-
-	return c.Status(fiber.StatusUnauthorized).JSON(response)
+	return middleware.SendError(c, fiber.StatusUnauthorized, pkgErrors.ErrCodeUnauthorized, message)
 }
 
 // NewForbiddenResponse creates a forbidden error response
 func NewForbiddenResponse(c *fiber.Ctx, message string) error {
-	requestID := getRequestID(c)
-
-	response := ErrorResponse{
-		Success:   false,
-		Error:     "Forbidden",
-		Code:      "FORBIDDEN",
-		Message:   message,
-		RequestID: requestID,
-	}
-
-	return c.Status(fiber.StatusForbidden).JSON(response)
+	return middleware.SendError(c, fiber.StatusForbidden, pkgErrors.ErrCodeForbidden, message)
 }
 
 // NewNotFoundResponse creates a not found error response
 func NewNotFoundResponse(c *fiber.Ctx, resource string) error {
-	requestID := getRequestID(c)
-
-	response := ErrorResponse{
-		Success:   false,
-		Error:     "Not Found",
-		Code:      "NOT_FOUND",
-		Message:   resource + " not found",
-		RequestID: requestID,
-	}
-
-	return c.Status(fiber.StatusNotFound).JSON(response)
+	return middleware.SendError(c, fiber.StatusNotFound, pkgErrors.ErrCodeNotFound, resource+" not found")
 }
 
 // NewConflictResponse creates a conflict error response
 func NewConflictResponse(c *fiber.Ctx, message string) error {
-	requestID := getRequestID(c)
-
-	response := ErrorResponse{
-		Success:   false,
-		Error:     "Conflict",
-		Code:      "CONFLICT",
-		Message:   message,
-		RequestID: requestID,
-	}
-
-	return c.Status(fiber.StatusConflict).JSON(response)
+	return middleware.SendError(c, fiber.StatusConflict, pkgErrors.ErrCodeConflict, message)
 }
 
 // NewRateLimitResponse creates a rate limit error response
 func NewRateLimitResponse(c *fiber.Ctx) error {
-	requestID := getRequestID(c)
-
-	response := ErrorResponse{
-		Success:   false,
-		Error:     "Too Many Requests",
-		Code:      "RATE_LIMIT_EXCEEDED",
-		Message:   "Rate limit exceeded. Please try again later.",
-		RequestID: requestID,
-	}
-
 	// Add Retry-After header
 	c.Set("Retry-After", "60")
 
-	return c.Status(fiber.StatusTooManyRequests).JSON(response)
+	return middleware.SendError(c, fiber.StatusTooManyRequests, pkgErrors.ErrCodeTooManyRequests,
+		"Rate limit exceeded. Please try again later.")
 }
 
 // NewAcceptedResponse creates an accepted response (202)
@@ -297,6 +184,14 @@ func getRequestID(c *fiber.Ctx) string {
 	return ""
 }
 
+// getTraceID returns the ID of the request's trace, or "" when it is not traced
+func getTraceID(c *fiber.Ctx) string {
+	if span, ok := c.Locals(tracing.SpanKey).(trace.Span); ok && span.SpanContext().IsValid() {
+		return span.SpanContext().TraceID().String()
+	}
+	return ""
+}
+
 // LogHandlerError logs handler errors with context
 func LogHandlerError(c *fiber.Ctx, operation string, err error) {
 	log.Errorw("Handler error",
@@ -305,6 +200,7 @@ func LogHandlerError(c *fiber.Ctx, operation string, err error) {
 		"path", c.Path(),
 		"method", c.Method(),
 		"request_id", getRequestID(c),
+		"trace_id", getTraceID(c),
 		"timestamp", time.Now().UTC(),
 	)
 }
@@ -316,6 +212,7 @@ func LogHandlerInfo(c *fiber.Ctx, operation string, details map[string]interface
 		"path", c.Path(),
 		"method", c.Method(),
 		"request_id", getRequestID(c),
+		"trace_id", getTraceID(c),
 		"timestamp", time.Now().UTC(),
 	}
 
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/middleware"
 	"Krafti_Vibe/internal/service"
 	"Krafti_Vibe/internal/service/dto"
@@ -254,3 +255,225 @@ func (h *ReviewHandler) MarkHelpful(c *fiber.Ctx) error {
 
 	return NewSuccessResponse(c, nil, "Marked as helpful")
 }
+
+// ReplyToReview godoc
+// @Summary Reply to review
+// @Description Add a reply to the thread under a published review. The reviewed artisan opens the thread; the reviewer can reply once they have. Profanity is masked.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Param reply body dto.CreateReviewReplyRequest true "Reply"
+// @Success 201 {object} dto.ReviewReplyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /reviews/{id}/replies [post]
+func (h *ReviewHandler) ReplyToReview(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid review ID", err)
+	}
+
+	var req dto.CreateReviewReplyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	reply, err := h.reviewService.ReplyToReview(c.Context(), reviewID, authCtx.UserID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, reply, "Reply added successfully")
+}
+
+// GetReviewReplies godoc
+// @Summary Get review replies
+// @Description Get the thread under a review, oldest reply first
+// @Tags reviews
+// @Produce json
+// @Param id path string true "Review ID"
+// @Success 200 {array} dto.ReviewReplyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /reviews/{id}/replies [get]
+func (h *ReviewHandler) GetReviewReplies(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid review ID", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	replies, err := h.reviewService.GetReviewReplies(c.Context(), reviewID, authCtx.UserID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, replies)
+}
+
+// GetModerationQueue godoc
+// @Summary Get review moderation queue
+// @Description Get the current tenant's reviews held for a moderator under its moderation policy, oldest first
+// @Tags reviews
+// @Produce json
+// @Success 200 {array} dto.ReviewDetailResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /reviews/moderation/queue [get]
+func (h *ReviewHandler) GetModerationQueue(c *fiber.Ctx) error {
+	authCtx := middleware.MustGetAuthContext(c)
+	reviews, err := h.reviewService.GetPendingModeration(c.Context(), authCtx.TenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, reviews)
+}
+
+// PublishReview godoc
+// @Summary Publish review
+// @Description Publish a review held for moderation or rejected earlier
+// @Tags reviews
+// @Produce json
+// @Param id path string true "Review ID"
+// @Success 200 {object} dto.ReviewDetailResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /reviews/{id}/publish [post]
+func (h *ReviewHandler) PublishReview(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid review ID", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	review, err := h.reviewService.PublishReview(c.Context(), authCtx.TenantID, reviewID, authCtx.UserID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, review, "Review published")
+}
+
+// RejectReview godoc
+// @Summary Reject review
+// @Description Hide a held or published review, recording why
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Param rejection body dto.RejectReviewRequest true "Rejection reason"
+// @Success 200 {object} dto.ReviewDetailResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /reviews/{id}/reject [post]
+func (h *ReviewHandler) RejectReview(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid review ID", err)
+	}
+
+	var req dto.RejectReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	review, err := h.reviewService.RejectReview(c.Context(), authCtx.TenantID, reviewID, authCtx.UserID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, review, "Review rejected")
+}
+
+// FlagReview godoc
+// @Summary Flag review
+// @Description Report a review as inappropriate, or, as the reviewed artisan, dispute it. Moderators resolve flags; the review stays as it is until then.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Param flag body dto.FlagReviewRequest true "Flag kind and reason"
+// @Success 201 {object} dto.ReviewFlagResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /reviews/{id}/flags [post]
+func (h *ReviewHandler) FlagReview(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid review ID", err)
+	}
+
+	var req dto.FlagReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	flag, err := h.reviewService.FlagReview(c.Context(), authCtx.TenantID, reviewID, authCtx.UserID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, flag, "Review flagged for moderation")
+}
+
+// ListReviewFlags godoc
+// @Summary List review flags
+// @Description List the current tenant's review reports and disputes in a status, oldest first. Open flags, the default, form the queue.
+// @Tags reviews
+// @Produce json
+// @Param status query string false "Status: open, upheld or dismissed" default(open)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.ReviewFlagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /reviews/moderation/flags [get]
+func (h *ReviewHandler) ListReviewFlags(c *fiber.Ctx) error {
+	authCtx := middleware.MustGetAuthContext(c)
+	page, pageSize := ParsePagination(c)
+	flags, err := h.reviewService.ListFlags(c.Context(), authCtx.TenantID, models.ReviewFlagStatus(c.Query("status")), page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, flags)
+}
+
+// ResolveReviewFlag godoc
+// @Summary Resolve review flag
+// @Description Close an open report or dispute. Upholding it rejects the review; dismissing it leaves the review as it is.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param flag_id path string true "Flag ID"
+// @Param resolution body dto.ResolveReviewFlagRequest true "Decision"
+// @Success 200 {object} dto.ReviewFlagResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /reviews/moderation/flags/{flag_id}/resolve [post]
+func (h *ReviewHandler) ResolveReviewFlag(c *fiber.Ctx) error {
+	flagID, err := uuid.Parse(c.Params("flag_id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid flag ID", err)
+	}
+
+	var req dto.ResolveReviewFlagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	flag, err := h.reviewService.ResolveFlag(c.Context(), authCtx.TenantID, flagID, authCtx.UserID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, flag, "Flag resolved")
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SavedFilterHandler handles HTTP requests for saved booking filters
+type SavedFilterHandler struct {
+	filterService service.SavedFilterService
+}
+
+// NewSavedFilterHandler creates a new saved filter handler
+func NewSavedFilterHandler(filterService service.SavedFilterService) *SavedFilterHandler {
+	if filterService == nil {
+		panic("saved filter service cannot be nil")
+	}
+	return &SavedFilterHandler{
+		filterService: filterService,
+	}
+}
+
+// CreateFilter godoc
+// @Summary Save booking filter
+// @Description Save a bookings list view for the current user. Criteria use the booking filter fields; a date range such as this_week is resolved each time the filter is used. Apply it with GET /bookings?filter_id=...
+// @Tags bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param filter body dto.CreateSavedFilterRequest true "Filter data"
+// @Success 201 {object} dto.SavedFilterResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bookings/filters [post]
+func (h *SavedFilterHandler) CreateFilter(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateSavedFilterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = authCtx.TenantID
+	req.UserID = authCtx.UserID
+
+	filter, err := h.filterService.CreateFilter(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_saved_filter", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, filter, "Filter saved successfully")
+}
+
+// ListFilters godoc
+// @Summary List saved booking filters
+// @Description List the current user's saved booking filters
+// @Tags bookings
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.SavedFilterResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bookings/filters [get]
+func (h *SavedFilterHandler) ListFilters(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	filters, err := h.filterService.ListFilters(c.Context(), authCtx.TenantID, authCtx.UserID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, filters)
+}
+
+// GetFilter godoc
+// @Summary Get saved booking filter
+// @Description Get one of the current user's saved booking filters
+// @Tags bookings
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Filter ID"
+// @Success 200 {object} dto.SavedFilterResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bookings/filters/{id} [get]
+func (h *SavedFilterHandler) GetFilter(c *fiber.Ctx) error {
+	filterID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := h.filterService.GetFilter(c.Context(), authCtx.TenantID, authCtx.UserID, filterID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, filter)
+}
+
+// UpdateFilter godoc
+// @Summary Update saved booking filter
+// @Description Rename a saved booking filter or replace its criteria
+// @Tags bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Filter ID"
+// @Param filter body dto.UpdateSavedFilterRequest true "Filter data"
+// @Success 200 {object} dto.SavedFilterResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /bookings/filters/{id} [put]
+func (h *SavedFilterHandler) UpdateFilter(c *fiber.Ctx) error {
+	filterID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateSavedFilterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	filter, err := h.filterService.UpdateFilter(c.Context(), authCtx.TenantID, authCtx.UserID, filterID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_saved_filter", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, filter, "Filter updated successfully")
+}
+
+// DeleteFilter godoc
+// @Summary Delete saved booking filter
+// @Description Delete one of the current user's saved booking filters
+// @Tags bookings
+// @Security BearerAuth
+// @Param id path string true "Filter ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bookings/filters/{id} [delete]
+func (h *SavedFilterHandler) DeleteFilter(c *fiber.Ctx) error {
+	filterID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.filterService.DeleteFilter(c.Context(), authCtx.TenantID, authCtx.UserID, filterID); err != nil {
+		LogHandlerError(c, "delete_saved_filter", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
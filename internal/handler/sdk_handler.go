@@ -36,10 +36,7 @@ func (h *SDKHandler) CreateSDKClient(c *fiber.Ctx) error {
 
 	var req dto.CreateSDKClientRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	client, err := h.sdkService.CreateClient(c.Context(), authCtx.TenantID, &req)
@@ -59,10 +56,7 @@ func (h *SDKHandler) GetSDKClient(c *fiber.Ctx) error {
 
 	clientID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid client ID",
-			"code":  "INVALID_CLIENT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CLIENT_ID", "invalid client ID", nil)
 	}
 
 	client, err := h.sdkService.GetClient(c.Context(), clientID, authCtx.TenantID)
@@ -82,18 +76,12 @@ func (h *SDKHandler) UpdateSDKClient(c *fiber.Ctx) error {
 
 	clientID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid client ID",
-			"code":  "INVALID_CLIENT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CLIENT_ID", "invalid client ID", nil)
 	}
 
 	var req dto.UpdateSDKClientRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	client, err := h.sdkService.UpdateClient(c.Context(), clientID, authCtx.TenantID, &req)
@@ -113,10 +101,7 @@ func (h *SDKHandler) DeleteSDKClient(c *fiber.Ctx) error {
 
 	clientID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid client ID",
-			"code":  "INVALID_CLIENT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CLIENT_ID", "invalid client ID", nil)
 	}
 
 	if err := h.sdkService.DeleteClient(c.Context(), clientID, authCtx.TenantID); err != nil {
@@ -177,10 +162,7 @@ func (h *SDKHandler) CreateSDKKey(c *fiber.Ctx) error {
 
 	var req dto.CreateSDKKeyRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	keyWithSecret, err := h.sdkService.CreateKey(c.Context(), authCtx.TenantID, authCtx.UserID, &req)
@@ -200,10 +182,7 @@ func (h *SDKHandler) GetSDKKey(c *fiber.Ctx) error {
 
 	keyID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid key ID",
-			"code":  "INVALID_KEY_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_KEY_ID", "invalid key ID", nil)
 	}
 
 	key, err := h.sdkService.GetKey(c.Context(), keyID, authCtx.TenantID)
@@ -223,18 +202,12 @@ func (h *SDKHandler) UpdateSDKKey(c *fiber.Ctx) error {
 
 	keyID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid key ID",
-			"code":  "INVALID_KEY_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_KEY_ID", "invalid key ID", nil)
 	}
 
 	var req dto.UpdateSDKKeyRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	key, err := h.sdkService.UpdateKey(c.Context(), keyID, authCtx.TenantID, &req)
@@ -254,18 +227,12 @@ func (h *SDKHandler) RevokeSDKKey(c *fiber.Ctx) error {
 
 	keyID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid key ID",
-			"code":  "INVALID_KEY_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_KEY_ID", "invalid key ID", nil)
 	}
 
 	var req dto.RevokeSDKKeyRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	if err := h.sdkService.RevokeKey(c.Context(), keyID, authCtx.TenantID, authCtx.UserID, &req); err != nil {
@@ -287,18 +254,12 @@ func (h *SDKHandler) RotateSDKKey(c *fiber.Ctx) error {
 
 	keyID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid key ID",
-			"code":  "INVALID_KEY_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_KEY_ID", "invalid key ID", nil)
 	}
 
 	var req dto.RotateSDKKeyRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	newKey, err := h.sdkService.RotateKey(c.Context(), keyID, authCtx.TenantID, authCtx.UserID, &req)
@@ -325,10 +286,7 @@ func (h *SDKHandler) ListSDKKeys(c *fiber.Ctx) error {
 	if clientIDStr := c.Query("client_id"); clientIDStr != "" {
 		clientID, err := uuid.Parse(clientIDStr)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid client_id",
-				"code":  "INVALID_CLIENT_ID",
-			})
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CLIENT_ID", "invalid client_id", nil)
 		}
 		filter.ClientID = &clientID
 	}
@@ -360,17 +318,11 @@ func (h *SDKHandler) ValidateSDKKey(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	if req.APIKey == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "api_key is required",
-			"code":  "MISSING_API_KEY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_API_KEY", "api_key is required", nil)
 	}
 
 	key, err := h.sdkService.ValidateKey(c.Context(), req.APIKey)
@@ -394,10 +346,7 @@ func (h *SDKHandler) ValidateSDKKey(c *fiber.Ctx) error {
 func (h *SDKHandler) TrackSDKUsage(c *fiber.Ctx) error {
 	var usage models.SDKUsage
 	if err := c.BodyParser(&usage); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	// Set timestamp if not provided
@@ -428,10 +377,7 @@ func (h *SDKHandler) ListSDKUsage(c *fiber.Ctx) error {
 	if clientIDStr := c.Query("client_id"); clientIDStr != "" {
 		clientID, err := uuid.Parse(clientIDStr)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid client_id",
-				"code":  "INVALID_CLIENT_ID",
-			})
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CLIENT_ID", "invalid client_id", nil)
 		}
 		filter.ClientID = &clientID
 	}
@@ -440,10 +386,7 @@ func (h *SDKHandler) ListSDKUsage(c *fiber.Ctx) error {
 	if keyIDStr := c.Query("key_id"); keyIDStr != "" {
 		keyID, err := uuid.Parse(keyIDStr)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "invalid key_id",
-				"code":  "INVALID_KEY_ID",
-			})
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_KEY_ID", "invalid key_id", nil)
 		}
 		filter.KeyID = &keyID
 	}
@@ -486,18 +429,12 @@ func (h *SDKHandler) GetSDKUsageStats(c *fiber.Ctx) error {
 
 	clientIDStr := c.Query("client_id")
 	if clientIDStr == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "client_id is required",
-			"code":  "MISSING_CLIENT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_CLIENT_ID", "client_id is required", nil)
 	}
 
 	clientID, err := uuid.Parse(clientIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid client_id",
-			"code":  "INVALID_CLIENT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_CLIENT_ID", "invalid client_id", nil)
 	}
 
 	// Parse date range
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"strings"
+
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SearchHandler handles HTTP requests for searching a tenant's records
+type SearchHandler struct {
+	searchService service.SearchService
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(searchService service.SearchService) *SearchHandler {
+	if searchService == nil {
+		panic("search service cannot be nil")
+	}
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// Search godoc
+// @Summary Search
+// @Description Search the current tenant's bookings, customers, services and projects by customer names and contact details, service names, booking notes and project titles. Partly typed words match, and every word must match.
+// @Tags search
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search text, at least 2 characters"
+// @Param types query string false "Comma-separated types to search: booking, customer, service, project"
+// @Param limit query int false "Maximum number of hits, at most 50" default(20)
+// @Success 200 {object} dto.SearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	req := &dto.SearchRequest{
+		TenantID: tenantID,
+		Query:    c.Query("q"),
+		Limit:    getIntQuery(c, "limit", 20),
+	}
+	for t := range strings.SplitSeq(c.Query("types"), ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			req.Types = append(req.Types, dto.SearchType(t))
+		}
+	}
+
+	result, err := h.searchService.Search(c.Context(), req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result)
+}
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"time"
+
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ServiceAccountHandler handles HTTP requests for the service accounts a tenant's
+// integrations sign in as
+type ServiceAccountHandler struct {
+	accountService service.ServiceAccountService
+}
+
+// NewServiceAccountHandler creates a new service account handler
+func NewServiceAccountHandler(accountService service.ServiceAccountService) *ServiceAccountHandler {
+	if accountService == nil {
+		panic("service account service cannot be nil")
+	}
+	return &ServiceAccountHandler{
+		accountService: accountService,
+	}
+}
+
+// ListScopes godoc
+// @Summary List service account scopes
+// @Description List the permissions service accounts can be granted, such as "bookings.read"
+// @Tags service-accounts
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} string
+// @Failure 401 {object} ErrorResponse
+// @Router /service-accounts/scopes [get]
+func (h *ServiceAccountHandler) ListScopes(c *fiber.Ctx) error {
+	return NewSuccessResponse(c, h.accountService.ListScopes())
+}
+
+// CreateServiceAccount godoc
+// @Summary Create service account
+// @Description Create a service account for an integration, with a Zitadel machine user it signs in as using the client credentials grant. The client secret is only returned now.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param account body dto.CreateServiceAccountRequest true "Service account data"
+// @Success 201 {object} dto.ServiceAccountCredentialsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse "Zitadel machine users are not configured"
+// @Router /service-accounts [post]
+func (h *ServiceAccountHandler) CreateServiceAccount(c *fiber.Ctx) error {
+	var req dto.CreateServiceAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = middleware.RequestTenant(c)
+	req.CreatedByID = middleware.MustGetAuthContext(c).UserID
+	if user, ok := middleware.GetDatabaseUser(c); ok {
+		req.CreatedByID = user.ID
+	}
+
+	credentials, err := h.accountService.CreateServiceAccount(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_service_account", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, credentials, "Service account created; store the client secret now, it won't be shown again")
+}
+
+// ListServiceAccounts godoc
+// @Summary List service accounts
+// @Description List the service accounts of the current tenant, revoked ones included
+// @Tags service-accounts
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.ServiceAccountResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /service-accounts [get]
+func (h *ServiceAccountHandler) ListServiceAccounts(c *fiber.Ctx) error {
+	accounts, err := h.accountService.ListServiceAccounts(c.Context(), middleware.RequestTenant(c))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, accounts)
+}
+
+// GetServiceAccount godoc
+// @Summary Get service account
+// @Description Get a service account by ID
+// @Tags service-accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Service account ID"
+// @Success 200 {object} dto.ServiceAccountResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /service-accounts/{id} [get]
+func (h *ServiceAccountHandler) GetServiceAccount(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UUID", "Invalid id format", err)
+	}
+
+	account, err := h.accountService.GetServiceAccount(c.Context(), id)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, account)
+}
+
+// UpdateServiceAccount godoc
+// @Summary Update service account
+// @Description Update a service account's name, description or scopes; new scopes apply to its requests within a minute
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Service account ID"
+// @Param account body dto.UpdateServiceAccountRequest true "Service account data"
+// @Success 200 {object} dto.ServiceAccountResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /service-accounts/{id} [put]
+func (h *ServiceAccountHandler) UpdateServiceAccount(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UUID", "Invalid id format", err)
+	}
+
+	var req dto.UpdateServiceAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	account, err := h.accountService.UpdateServiceAccount(c.Context(), id, &req)
+	if err != nil {
+		LogHandlerError(c, "update_service_account", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, account, "Service account updated successfully")
+}
+
+// RotateSecret godoc
+// @Summary Rotate service account secret
+// @Description Give a service account a new client secret; the previous one stops working at once. The secret is only returned now.
+// @Tags service-accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Service account ID"
+// @Success 200 {object} dto.ServiceAccountCredentialsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "The service account has been revoked"
+// @Router /service-accounts/{id}/rotate-secret [post]
+func (h *ServiceAccountHandler) RotateSecret(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UUID", "Invalid id format", err)
+	}
+
+	credentials, err := h.accountService.RotateSecret(c.Context(), id)
+	if err != nil {
+		LogHandlerError(c, "rotate_service_account_secret", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, credentials, "Client secret rotated; store it now, it won't be shown again")
+}
+
+// RevokeServiceAccount godoc
+// @Summary Revoke service account
+// @Description Revoke a service account for good and remove its machine user; its requests are refused within a minute. Its usage is kept.
+// @Tags service-accounts
+// @Security BearerAuth
+// @Param id path string true "Service account ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "The service account has already been revoked"
+// @Router /service-accounts/{id} [delete]
+func (h *ServiceAccountHandler) RevokeServiceAccount(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UUID", "Invalid id format", err)
+	}
+
+	if err := h.accountService.RevokeServiceAccount(c.Context(), id); err != nil {
+		LogHandlerError(c, "revoke_service_account", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// GetUsage godoc
+// @Summary Get service account usage
+// @Description Get a service account's requests by day and by kind of resource, counting those refused for lack of scope and those that failed
+// @Tags service-accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Service account ID"
+// @Param from query string false "First day (YYYY-MM-DD), 30 days before to by default"
+// @Param to query string false "Last day (YYYY-MM-DD), today by default"
+// @Success 200 {object} dto.ServiceAccountUsageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /service-accounts/{id}/usage [get]
+func (h *ServiceAccountHandler) GetUsage(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UUID", "Invalid id format", err)
+	}
+
+	var from, to time.Time
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse(time.DateOnly, value); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DATE", "from must be a date (YYYY-MM-DD)", err)
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse(time.DateOnly, value); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DATE", "to must be a date (YYYY-MM-DD)", err)
+		}
+	}
+
+	usage, err := h.accountService.GetUsage(c.Context(), id, from, to)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, usage)
+}
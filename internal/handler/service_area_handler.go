@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ServiceAreaHandler handles HTTP requests for artisans' service areas
+type ServiceAreaHandler struct {
+	areaService service.ServiceAreaService
+}
+
+// NewServiceAreaHandler creates a new service area handler
+func NewServiceAreaHandler(areaService service.ServiceAreaService) *ServiceAreaHandler {
+	if areaService == nil {
+		panic("service area service cannot be nil")
+	}
+	return &ServiceAreaHandler{
+		areaService: areaService,
+	}
+}
+
+// ListServiceAreas godoc
+// @Summary List artisan service areas
+// @Description List the regions an artisan travels to for mobile services
+// @Tags artisans
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Success 200 {array} dto.ServiceAreaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /artisans/{id}/service-areas [get]
+func (h *ServiceAreaHandler) ListServiceAreas(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	areas, err := h.areaService.ListServiceAreas(c.Context(), tenantID, artisanID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, areas)
+}
+
+// CreateServiceArea godoc
+// @Summary Add artisan service area
+// @Description Add a region an artisan travels to. Once an artisan has active service areas, their mobile services can only be booked for locations inside one of them.
+// @Tags artisans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Param area body dto.CreateServiceAreaRequest true "Service area with a GeoJSON Polygon boundary"
+// @Success 201 {object} dto.ServiceAreaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /artisans/{id}/service-areas [post]
+func (h *ServiceAreaHandler) CreateServiceArea(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateServiceAreaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = tenantID
+	req.ArtisanID = artisanID
+
+	area, err := h.areaService.CreateServiceArea(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_service_area", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, area, "Service area created successfully")
+}
+
+// UpdateServiceArea godoc
+// @Summary Update artisan service area
+// @Description Rename a service area, replace its boundary, or switch it on or off
+// @Tags artisans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Param area_id path string true "Service area ID"
+// @Param area body dto.UpdateServiceAreaRequest true "Service area data"
+// @Success 200 {object} dto.ServiceAreaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /artisans/{id}/service-areas/{area_id} [put]
+func (h *ServiceAreaHandler) UpdateServiceArea(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	areaID, err := ParseUUIDParam(c, "area_id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateServiceAreaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	area, err := h.areaService.UpdateServiceArea(c.Context(), tenantID, artisanID, areaID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_service_area", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, area, "Service area updated successfully")
+}
+
+// DeleteServiceArea godoc
+// @Summary Delete artisan service area
+// @Description Delete one of an artisan's service areas
+// @Tags artisans
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Param area_id path string true "Service area ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /artisans/{id}/service-areas/{area_id} [delete]
+func (h *ServiceAreaHandler) DeleteServiceArea(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	areaID, err := ParseUUIDParam(c, "area_id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.areaService.DeleteServiceArea(c.Context(), tenantID, artisanID, areaID); err != nil {
+		LogHandlerError(c, "delete_service_area", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
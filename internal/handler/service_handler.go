@@ -272,3 +272,156 @@ func (h *ServiceHandler) GetPopularServices(c *fiber.Ctx) error {
 
 	return NewSuccessResponse(c, services)
 }
+
+// SaveServiceDraft godoc
+// @Summary Save pending changes to a service
+// @Description Replace the pending changes of a published service, which customers don't see until they are published, optionally scheduling their publication
+// @Tags services
+// @Accept json
+// @Produce json
+// @Param id path string true "Service ID"
+// @Param draft body dto.SaveServiceDraftRequest true "Pending changes"
+// @Success 200 {object} dto.ServiceResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /services/{id}/draft [put]
+func (h *ServiceHandler) SaveServiceDraft(c *fiber.Ctx) error {
+	serviceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid service ID", err)
+	}
+
+	var req dto.SaveServiceDraftRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	svc, err := h.serviceService.SaveDraft(c.Context(), serviceID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, svc, "Draft saved successfully")
+}
+
+// DiscardServiceDraft godoc
+// @Summary Discard pending changes to a service
+// @Description Drop the pending changes of a service and their scheduled publication
+// @Tags services
+// @Param id path string true "Service ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /services/{id}/draft [delete]
+func (h *ServiceHandler) DiscardServiceDraft(c *fiber.Ctx) error {
+	serviceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid service ID", err)
+	}
+
+	if err := h.serviceService.DiscardDraft(c.Context(), serviceID); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// PreviewService godoc
+// @Summary Preview a service before publishing
+// @Description Show the service as publishing its draft would leave it, next to the version customers currently see, with the fields that change
+// @Tags services
+// @Produce json
+// @Param id path string true "Service ID"
+// @Success 200 {object} dto.ServicePreviewResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /services/{id}/preview [get]
+func (h *ServiceHandler) PreviewService(c *fiber.Ctx) error {
+	serviceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid service ID", err)
+	}
+
+	preview, err := h.serviceService.PreviewService(c.Context(), serviceID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, preview)
+}
+
+// PublishService godoc
+// @Summary Publish a service
+// @Description Publish a draft or archived service, or the pending changes of a published one, now or at publish_at. A changed price takes effect as a new price version; existing bookings keep theirs.
+// @Tags services
+// @Accept json
+// @Produce json
+// @Param id path string true "Service ID"
+// @Param publication body dto.PublishServiceRequest false "Publication time"
+// @Success 200 {object} dto.ServiceResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /services/{id}/publish [post]
+func (h *ServiceHandler) PublishService(c *fiber.Ctx) error {
+	serviceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid service ID", err)
+	}
+
+	var req dto.PublishServiceRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		}
+	}
+
+	svc, err := h.serviceService.PublishService(c.Context(), serviceID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, svc, "Service published successfully")
+}
+
+// ArchiveService godoc
+// @Summary Archive a service
+// @Description Withdraw a service from the catalog; bookings referencing it are kept
+// @Tags services
+// @Param id path string true "Service ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /services/{id}/archive [post]
+func (h *ServiceHandler) ArchiveService(c *fiber.Ctx) error {
+	serviceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid service ID", err)
+	}
+
+	if err := h.serviceService.ArchiveService(c.Context(), serviceID); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, nil, "Service archived successfully")
+}
+
+// GetServicePriceVersions godoc
+// @Summary List a service's price versions
+// @Description List the prices a service was published at and when each was in effect, newest first
+// @Tags services
+// @Produce json
+// @Param id path string true "Service ID"
+// @Success 200 {array} dto.ServicePriceVersionResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /services/{id}/price-versions [get]
+func (h *ServiceHandler) GetServicePriceVersions(c *fiber.Ctx) error {
+	serviceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid service ID", err)
+	}
+
+	versions, err := h.serviceService.GetPriceVersions(c.Context(), serviceID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, versions)
+}
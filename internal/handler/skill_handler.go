@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SkillHandler handles HTTP requests for the skill taxonomy, artisans' skills and
+// matching service requests to artisans
+type SkillHandler struct {
+	skillService service.SkillService
+}
+
+// NewSkillHandler creates a new skill handler
+func NewSkillHandler(skillService service.SkillService) *SkillHandler {
+	if skillService == nil {
+		panic("skill service cannot be nil")
+	}
+	return &SkillHandler{
+		skillService: skillService,
+	}
+}
+
+// ListSkills godoc
+// @Summary List skills
+// @Description List the tenant's skill taxonomy by category and name
+// @Tags skills
+// @Produce json
+// @Security BearerAuth
+// @Param category query string false "Only skills of the service category"
+// @Param active_only query bool false "Only active skills" default(false)
+// @Success 200 {array} dto.SkillResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /skills [get]
+func (h *SkillHandler) ListSkills(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	skills, err := h.skillService.ListSkills(c.Context(), tenantID,
+		models.ServiceCategory(c.Query("category")), getBoolQuery(c, "active_only", false))
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, skills)
+}
+
+// CreateSkill godoc
+// @Summary Create skill
+// @Description Add a skill to the tenant's taxonomy under a service category. Service requests are matched to skills by their name and keywords.
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param skill body dto.CreateSkillRequest true "Skill data"
+// @Success 201 {object} dto.SkillResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /skills [post]
+func (h *SkillHandler) CreateSkill(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateSkillRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = tenantID
+
+	skill, err := h.skillService.CreateSkill(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_skill", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, skill, "Skill created successfully")
+}
+
+// UpdateSkill godoc
+// @Summary Update skill
+// @Description Rename, recategorize, rekeyword or switch a skill on or off
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Skill ID"
+// @Param skill body dto.UpdateSkillRequest true "Skill data"
+// @Success 200 {object} dto.SkillResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /skills/{id} [put]
+func (h *SkillHandler) UpdateSkill(c *fiber.Ctx) error {
+	skillID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateSkillRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	skill, err := h.skillService.UpdateSkill(c.Context(), tenantID, skillID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_skill", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, skill, "Skill updated successfully")
+}
+
+// DeleteSkill godoc
+// @Summary Delete skill
+// @Description Delete a skill from the tenant's taxonomy; artisans tagged with it no longer show or match it
+// @Tags skills
+// @Security BearerAuth
+// @Param id path string true "Skill ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /skills/{id} [delete]
+func (h *SkillHandler) DeleteSkill(c *fiber.Ctx) error {
+	skillID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.skillService.DeleteSkill(c.Context(), tenantID, skillID); err != nil {
+		LogHandlerError(c, "delete_skill", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// ListArtisanSkills godoc
+// @Summary List artisan skills
+// @Description List the skills an artisan is tagged with and their proficiency in each
+// @Tags artisans
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Success 200 {array} dto.ArtisanSkillResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /artisans/{id}/skills [get]
+func (h *SkillHandler) ListArtisanSkills(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	skills, err := h.skillService.ListArtisanSkills(c.Context(), tenantID, artisanID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, skills)
+}
+
+// SetArtisanSkills godoc
+// @Summary Set artisan skills
+// @Description Replace the skills an artisan is tagged with, each an active skill of the tenant's taxonomy with a proficiency
+// @Tags artisans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Param skills body dto.SetArtisanSkillsRequest true "The artisan's skills"
+// @Success 200 {array} dto.ArtisanSkillResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /artisans/{id}/skills [put]
+func (h *SkillHandler) SetArtisanSkills(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.SetArtisanSkillsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	skills, err := h.skillService.SetArtisanSkills(c.Context(), tenantID, artisanID, &req)
+	if err != nil {
+		LogHandlerError(c, "set_artisan_skills", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, skills, "Artisan skills updated successfully")
+}
+
+// MatchArtisans godoc
+// @Summary Match artisans to a service request
+// @Description Find the skills a service request's description mentions and rank the artisans with them by skill proficiency, rating, proximity to the request's location and availability on its date
+// @Tags artisans
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MatchArtisansRequest true "Service request"
+// @Success 200 {object} dto.MatchArtisansResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /artisans/match [post]
+func (h *SkillHandler) MatchArtisans(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.MatchArtisansRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = tenantID
+
+	matches, err := h.skillService.MatchArtisans(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "match_artisans", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, matches)
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// StatementHandler handles HTTP requests for financial statements
+type StatementHandler struct {
+	statementService service.StatementService
+}
+
+// NewStatementHandler creates a new statement handler
+func NewStatementHandler(statementService service.StatementService) *StatementHandler {
+	if statementService == nil {
+		panic("statement service cannot be nil")
+	}
+	return &StatementHandler{
+		statementService: statementService,
+	}
+}
+
+// ListStatements godoc
+// @Summary List financial statements
+// @Description List the monthly revenue and artisan earnings statements of the current tenant, newest month first
+// @Tags statements
+// @Produce json
+// @Security BearerAuth
+// @Param kind query string false "Statement kind: artisan or tenant"
+// @Param period query string false "Month (YYYY-MM)"
+// @Success 200 {array} dto.FinancialStatementResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /statements [get]
+func (h *StatementHandler) ListStatements(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	statements, err := h.statementService.ListStatements(c.Context(), &dto.StatementListRequest{
+		TenantID: tenantID,
+		Kind:     models.StatementKind(strings.ToLower(c.Query("kind"))),
+		Period:   c.Query("period"),
+	})
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, statements)
+}
+
+// GenerateStatements godoc
+// @Summary Generate financial statements
+// @Description Generate the current tenant's revenue statement and every artisan's earnings statement for a month that has ended, replacing existing ones. Statements are also generated automatically early each month.
+// @Tags statements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.GenerateStatementsRequest true "Month to generate"
+// @Success 200 {object} dto.StatementRunResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /statements/generate [post]
+func (h *StatementHandler) GenerateStatements(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.GenerateStatementsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = tenantID
+
+	result, err := h.statementService.GenerateStatements(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "generate_statements", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result, "Statements generated")
+}
+
+// DownloadStatement godoc
+// @Summary Download financial statement
+// @Description Download any statement of the current tenant as CSV or PDF
+// @Tags statements
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Statement ID"
+// @Param format query string false "File format: csv or pdf" default(pdf)
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /statements/{id}/download [get]
+func (h *StatementHandler) DownloadStatement(c *fiber.Ctx) error {
+	statementID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	return h.download(c, statementID, nil)
+}
+
+// ListArtisanStatements godoc
+// @Summary List artisan earnings statements
+// @Description List an artisan's monthly earnings statements. Artisans can list their own; tenant owners and admins any artisan's.
+// @Tags statements
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan user ID"
+// @Param period query string false "Month (YYYY-MM)"
+// @Success 200 {array} dto.FinancialStatementResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /statements/artisans/{id} [get]
+func (h *StatementHandler) ListArtisanStatements(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	statements, err := h.statementService.ListStatements(c.Context(), &dto.StatementListRequest{
+		TenantID:  tenantID,
+		Kind:      models.StatementKindArtisan,
+		SubjectID: &artisanID,
+		Period:    c.Query("period"),
+	})
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, statements)
+}
+
+// DownloadArtisanStatement godoc
+// @Summary Download artisan earnings statement
+// @Description Download one of an artisan's earnings statements as CSV or PDF
+// @Tags statements
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Artisan user ID"
+// @Param statement_id path string true "Statement ID"
+// @Param format query string false "File format: csv or pdf" default(pdf)
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /statements/artisans/{id}/{statement_id}/download [get]
+func (h *StatementHandler) DownloadArtisanStatement(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	statementID, err := ParseUUIDParam(c, "statement_id")
+	if err != nil {
+		return err
+	}
+
+	return h.download(c, statementID, &artisanID)
+}
+
+// download streams a statement file of the current tenant
+func (h *StatementHandler) download(c *fiber.Ctx, statementID uuid.UUID, artisanID *uuid.UUID) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	format := models.StatementFormat(strings.ToLower(c.Query("format", string(models.StatementFormatPDF))))
+	download, err := h.statementService.OpenStatement(c.Context(), tenantID, statementID, artisanID, format)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetNoCacheHeaders(c)
+	c.Set(fiber.HeaderContentType, download.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, download.FileName))
+	// Fiber closes the stream once the response has been written
+	return c.SendStream(download.Content, int(download.Size))
+}
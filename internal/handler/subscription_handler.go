@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"net/http"
+
+	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/service"
 	"Krafti_Vibe/internal/service/dto"
 
@@ -107,3 +110,85 @@ func (h *SubscriptionHandler) ListSubscriptions(c *fiber.Ctx) error {
 
 	return NewSuccessResponse(c, subscriptions)
 }
+
+// ListPlans returns the plans tenants can subscribe to
+func (h *SubscriptionHandler) ListPlans(c *fiber.Ctx) error {
+	plans, err := h.subscriptionService.GetPlanComparison(c.Context())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, plans)
+}
+
+// UpsertPlan updates a plan's prices, Stripe price IDs and limits (platform staff only)
+func (h *SubscriptionHandler) UpsertPlan(c *fiber.Ctx) error {
+	var req dto.UpsertPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	plan, err := h.subscriptionService.UpsertPlan(c.Context(), models.SubscriptionPlan(c.Params("code")), &req)
+	if err != nil {
+		LogHandlerError(c, "upsert_plan", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, plan, "Plan updated successfully")
+}
+
+// Subscribe moves the caller's tenant onto a plan
+func (h *SubscriptionHandler) Subscribe(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil || tenantID == uuid.Nil {
+		return NewErrorResponse(c, fiber.StatusForbidden, "TENANT_REQUIRED", "Subscribing requires a tenant", err)
+	}
+
+	var req dto.SubscribeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	result, err := h.subscriptionService.Subscribe(c.Context(), tenantID, &req)
+	if err != nil {
+		LogHandlerError(c, "subscribe", err)
+		return HandleServiceError(c, err)
+	}
+
+	if result.RequiresPayment {
+		return NewSuccessResponse(c, result, "Confirm the first payment to start the plan")
+	}
+	return NewSuccessResponse(c, result, "Subscription updated successfully")
+}
+
+// GetUsage returns the caller's tenant usage against its plan limits
+func (h *SubscriptionHandler) GetUsage(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil || tenantID == uuid.Nil {
+		return NewErrorResponse(c, fiber.StatusForbidden, "TENANT_REQUIRED", "Usage requires a tenant", err)
+	}
+
+	usage, err := h.subscriptionService.GetUsage(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, usage)
+}
+
+// BillingWebhook receives signed Stripe Billing events for tenant subscriptions
+func (h *SubscriptionHandler) BillingWebhook(c *fiber.Ctx) error {
+	headers := http.Header{}
+	for key, values := range c.GetReqHeaders() {
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
+
+	if err := h.subscriptionService.HandleBillingWebhook(c.Context(), c.Body(), headers); err != nil {
+		LogHandlerError(c, "billing_webhook", err)
+		return HandleServiceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"received": true})
+}
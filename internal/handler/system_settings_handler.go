@@ -38,10 +38,7 @@ func (h *SystemSettingsHandler) CreateSetting(c *fiber.Ctx) error {
 
 	var req dto.CreateSettingRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	setting, err := h.service.CreateSetting(c.Context(), authCtx.UserID, &req)
@@ -63,10 +60,7 @@ func (h *SystemSettingsHandler) CreateSetting(c *fiber.Ctx) error {
 func (h *SystemSettingsHandler) GetSetting(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid setting ID",
-			"code":  "INVALID_SETTING_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_SETTING_ID", "invalid setting ID", nil)
 	}
 
 	setting, err := h.service.GetSetting(c.Context(), id)
@@ -88,10 +82,7 @@ func (h *SystemSettingsHandler) GetSetting(c *fiber.Ctx) error {
 func (h *SystemSettingsHandler) GetSettingByKey(c *fiber.Ctx) error {
 	key := c.Params("key")
 	if key == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "setting key is required",
-			"code":  "MISSING_KEY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_KEY", "setting key is required", nil)
 	}
 
 	setting, err := h.service.GetSettingByKey(c.Context(), key)
@@ -120,18 +111,12 @@ func (h *SystemSettingsHandler) UpdateSetting(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid setting ID",
-			"code":  "INVALID_SETTING_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_SETTING_ID", "invalid setting ID", nil)
 	}
 
 	var req dto.UpdateSettingRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	setting, err := h.service.UpdateSetting(c.Context(), id, authCtx.UserID, &req)
@@ -158,10 +143,7 @@ func (h *SystemSettingsHandler) DeleteSetting(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid setting ID",
-			"code":  "INVALID_SETTING_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_SETTING_ID", "invalid setting ID", nil)
 	}
 
 	if err := h.service.DeleteSetting(c.Context(), id, authCtx.UserID); err != nil {
@@ -225,10 +207,7 @@ func (h *SystemSettingsHandler) ListSettings(c *fiber.Ctx) error {
 func (h *SystemSettingsHandler) GetSettingsByCategory(c *fiber.Ctx) error {
 	category := c.Params("category")
 	if category == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "category is required",
-			"code":  "MISSING_CATEGORY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_CATEGORY", "category is required", nil)
 	}
 
 	settings, err := h.service.GetByCategory(c.Context(), category)
@@ -249,10 +228,7 @@ func (h *SystemSettingsHandler) GetSettingsByCategory(c *fiber.Ctx) error {
 func (h *SystemSettingsHandler) GetSettingsByGroup(c *fiber.Ctx) error {
 	group := c.Params("group")
 	if group == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "group is required",
-			"code":  "MISSING_GROUP",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_GROUP", "group is required", nil)
 	}
 
 	settings, err := h.service.GetByGroup(c.Context(), group)
@@ -280,10 +256,7 @@ func (h *SystemSettingsHandler) BulkSetSettings(c *fiber.Ctx) error {
 
 	var req dto.BulkSetSettingsRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	err = h.service.BulkSetSettings(c.Context(), &req, authCtx.UserID)
@@ -313,10 +286,7 @@ func (h *SystemSettingsHandler) BulkDeleteSettings(c *fiber.Ctx) error {
 
 	var keys []string
 	if err := c.BodyParser(&keys); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	err = h.service.BulkDeleteSettings(c.Context(), keys, authCtx.UserID)
@@ -371,10 +341,7 @@ func (h *SystemSettingsHandler) GetPrivateSettings(c *fiber.Ctx) error {
 func (h *SystemSettingsHandler) SearchSettings(c *fiber.Ctx) error {
 	query := c.Query("q")
 	if query == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "search query is required",
-			"code":  "MISSING_QUERY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_QUERY", "search query is required", nil)
 	}
 
 	page := c.QueryInt("page", 1)
@@ -449,10 +416,7 @@ func (h *SystemSettingsHandler) DeleteSettingByKey(c *fiber.Ctx) error {
 
 	key := c.Params("key")
 	if key == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "setting key is required",
-			"code":  "MISSING_KEY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_KEY", "setting key is required", nil)
 	}
 
 	if err := h.service.DeleteSettingByKey(c.Context(), key, authCtx.UserID); err != nil {
@@ -112,3 +112,59 @@ func (h *TaskHandler) CompleteTask(c *fiber.Ctx) error {
 
 	return NewSuccessResponse(c, nil, "Task completed successfully")
 }
+
+// GetTaskDependencies lists the tasks a task waits on and the tasks waiting on it
+func (h *TaskHandler) GetTaskDependencies(c *fiber.Ctx) error {
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid task ID", err)
+	}
+
+	deps, err := h.taskService.GetDependencies(c.Context(), taskID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, deps)
+}
+
+// AddTaskDependency makes a task wait on another task of the same project
+func (h *TaskHandler) AddTaskDependency(c *fiber.Ctx) error {
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid task ID", err)
+	}
+
+	var req dto.AddTaskDependencyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	deps, err := h.taskService.AddDependency(c.Context(), taskID, &req)
+	if err != nil {
+		LogHandlerError(c, "add_task_dependency", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, deps, "Dependency added successfully")
+}
+
+// RemoveTaskDependency stops a task waiting on another task
+func (h *TaskHandler) RemoveTaskDependency(c *fiber.Ctx) error {
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid task ID", err)
+	}
+	dependsOnID, err := uuid.Parse(c.Params("depends_on_id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid dependency task ID", err)
+	}
+
+	deps, err := h.taskService.RemoveDependency(c.Context(), taskID, dependsOnID)
+	if err != nil {
+		LogHandlerError(c, "remove_task_dependency", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, deps, "Dependency removed successfully")
+}
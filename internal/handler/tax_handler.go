@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TaxHandler handles HTTP requests for tax rules and tax quotes
+type TaxHandler struct {
+	taxService service.TaxService
+}
+
+// NewTaxHandler creates a new tax handler
+func NewTaxHandler(taxService service.TaxService) *TaxHandler {
+	if taxService == nil {
+		panic("tax service cannot be nil")
+	}
+	return &TaxHandler{
+		taxService: taxService,
+	}
+}
+
+// CreateTaxRule godoc
+// @Summary Create tax rule
+// @Description Create a default, per-category or regional VAT/GST rate for the current tenant
+// @Tags tax-rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body dto.CreateTaxRuleRequest true "Tax rule data"
+// @Success 201 {object} dto.TaxRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tax-rules [post]
+func (h *TaxHandler) CreateTaxRule(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateTaxRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = authCtx.TenantID
+
+	rule, err := h.taxService.CreateTaxRule(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_tax_rule", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, rule, "Tax rule created successfully")
+}
+
+// ListTaxRules godoc
+// @Summary List tax rules
+// @Description List the tax rules of the current tenant
+// @Tags tax-rules
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.TaxRuleResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tax-rules [get]
+func (h *TaxHandler) ListTaxRules(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	rules, err := h.taxService.ListTaxRules(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, rules)
+}
+
+// GetTaxRule godoc
+// @Summary Get tax rule
+// @Description Get a tax rule by ID
+// @Tags tax-rules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tax rule ID"
+// @Success 200 {object} dto.TaxRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /tax-rules/{id} [get]
+func (h *TaxHandler) GetTaxRule(c *fiber.Ctx) error {
+	ruleID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rule, err := h.taxService.GetTaxRule(c.Context(), ruleID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, rule.TenantID); err != nil {
+		return err
+	}
+
+	return NewSuccessResponse(c, rule)
+}
+
+// UpdateTaxRule godoc
+// @Summary Update tax rule
+// @Description Update a tax rule; bookings already priced keep the tax they were charged
+// @Tags tax-rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tax rule ID"
+// @Param rule body dto.UpdateTaxRuleRequest true "Tax rule data"
+// @Success 200 {object} dto.TaxRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /tax-rules/{id} [put]
+func (h *TaxHandler) UpdateTaxRule(c *fiber.Ctx) error {
+	ruleID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateTaxRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	existing, err := h.taxService.GetTaxRule(c.Context(), ruleID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, existing.TenantID); err != nil {
+		return err
+	}
+
+	rule, err := h.taxService.UpdateTaxRule(c.Context(), ruleID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_tax_rule", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, rule, "Tax rule updated successfully")
+}
+
+// DeleteTaxRule godoc
+// @Summary Delete tax rule
+// @Description Delete a tax rule
+// @Tags tax-rules
+// @Security BearerAuth
+// @Param id path string true "Tax rule ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /tax-rules/{id} [delete]
+func (h *TaxHandler) DeleteTaxRule(c *fiber.Ctx) error {
+	ruleID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	existing, err := h.taxService.GetTaxRule(c.Context(), ruleID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+	if err := CheckTenantAccess(c, existing.TenantID); err != nil {
+		return err
+	}
+
+	if err := h.taxService.DeleteTaxRule(c.Context(), ruleID); err != nil {
+		LogHandlerError(c, "delete_tax_rule", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// QuoteTax godoc
+// @Summary Preview tax
+// @Description Show the tax the current tenant charges on an amount for a service or tax category
+// @Tags tax-rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param quote body dto.TaxQuoteRequest true "Amount and place of supply"
+// @Success 200 {object} dto.TaxQuoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tax-rules/quote [post]
+func (h *TaxHandler) QuoteTax(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.TaxQuoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = authCtx.TenantID
+
+	quote, err := h.taxService.QuoteTax(c.Context(), &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, quote)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// TenantDomainHandler handles HTTP requests for verifying tenants' custom domains
+type TenantDomainHandler struct {
+	domainService service.TenantDomainService
+}
+
+// NewTenantDomainHandler creates a new tenant domain handler
+func NewTenantDomainHandler(domainService service.TenantDomainService) *TenantDomainHandler {
+	if domainService == nil {
+		panic("tenant domain service cannot be nil")
+	}
+	return &TenantDomainHandler{
+		domainService: domainService,
+	}
+}
+
+// GetDomainVerification godoc
+// @Summary Get custom domain verification record
+// @Description Get the TXT record to add to the DNS of the current organization's custom domain, proving the organization owns it. The organization is served at the domain once verified.
+// @Tags tenants
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.DomainVerificationResponse
+// @Failure 400 {object} ErrorResponse "No custom domain set"
+// @Failure 401 {object} ErrorResponse
+// @Router /tenants/domain-verification [get]
+func (h *TenantDomainHandler) GetDomainVerification(c *fiber.Ctx) error {
+	tenantID := middleware.RequestTenant(c)
+	if tenantID == uuid.Nil {
+		return NewErrorResponse(c, fiber.StatusForbidden, "NO_TENANT", "No organization to verify a domain for", nil)
+	}
+
+	verification, err := h.domainService.GetDomainVerification(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, verification)
+}
+
+// VerifyDomain godoc
+// @Summary Verify custom domain
+// @Description Look up the verification TXT record of the current organization's custom domain, and serve the organization at the domain once it is found
+// @Tags tenants
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.DomainVerificationResponse
+// @Failure 400 {object} ErrorResponse "No custom domain set"
+// @Failure 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "Verification record not found"
+// @Router /tenants/domain-verification/verify [post]
+func (h *TenantDomainHandler) VerifyDomain(c *fiber.Ctx) error {
+	tenantID := middleware.RequestTenant(c)
+	if tenantID == uuid.Nil {
+		return NewErrorResponse(c, fiber.StatusForbidden, "NO_TENANT", "No organization to verify a domain for", nil)
+	}
+
+	verification, err := h.domainService.VerifyDomain(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, verification, "Domain verified")
+}
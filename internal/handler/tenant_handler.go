@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/service"
 	"Krafti_Vibe/internal/service/dto"
@@ -327,6 +329,37 @@ func (h *TenantHandler) CancelTenant(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, nil, "Tenant cancelled successfully")
 }
 
+// ChangeTenantStatus godoc
+// @Summary Change tenant status
+// @Description Move a tenant through its lifecycle, recording the reason. Suspended tenants keep read-only access and may still settle their subscription and export their data; cancelled tenants are read-only while offboarding and get a full data export; closed tenants lose all access. Trials become active or suspended, and only cancelled tenants can be closed.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param status body dto.ChangeTenantStatusRequest true "New status and reason"
+// @Success 200 {object} dto.TenantResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tenants/{id}/status [post]
+func (h *TenantHandler) ChangeTenantStatus(c *fiber.Ctx) error {
+	tenantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid tenant ID", err)
+	}
+
+	var req dto.ChangeTenantStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	tenant, err := h.tenantService.ChangeTenantStatus(c.Context(), tenantID, &req)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, tenant, "Tenant status changed successfully")
+}
+
 // ============================================================================
 // Plan and Settings Management
 // ============================================================================
@@ -370,7 +403,7 @@ func (h *TenantHandler) UpdateTenantPlan(c *fiber.Ctx) error {
 // @Param id path string true "Tenant ID"
 // @Param settings body dto.UpdateTenantSettingsRequest true "Settings data"
 // @Success 200 {object} SuccessResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse "Invalid payment provider or MFA policy"
 // @Failure 500 {object} ErrorResponse
 // @Router /tenants/{id}/settings [put]
 func (h *TenantHandler) UpdateTenantSettings(c *fiber.Ctx) error {
@@ -385,6 +418,10 @@ func (h *TenantHandler) UpdateTenantSettings(c *fiber.Ctx) error {
 	}
 
 	if err := h.tenantService.UpdateTenantSettings(c.Context(), tenantID, &req); err != nil {
+		if errors.Is(err, service.ErrInvalidPaymentProvider) || errors.Is(err, service.ErrInvalidMFAPolicy) ||
+			errors.Is(err, service.ErrInvalidCustomerSegmentRules) || errors.Is(err, service.ErrInvalidReviewModerationPolicy) {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_SETTINGS", err.Error(), err)
+		}
 		return HandleServiceError(c, err)
 	}
 
@@ -551,6 +588,30 @@ func (h *TenantHandler) GetTenantHealth(c *fiber.Ctx) error {
 	return NewSuccessResponse(c, health)
 }
 
+// GetMFACompliance godoc
+// @Summary Get MFA compliance
+// @Description Report the tenant's active staff never seen signed in with a second factor, marking those its MFA policy requires it of
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} dto.MFAComplianceResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tenants/{id}/mfa-compliance [get]
+func (h *TenantHandler) GetMFACompliance(c *fiber.Ctx) error {
+	tenantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid tenant ID", err)
+	}
+
+	report, err := h.tenantService.GetMFACompliance(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, report)
+}
+
 // GetTenantLimits godoc
 // @Summary Get tenant limits
 // @Description Get tenant plan limits and usage
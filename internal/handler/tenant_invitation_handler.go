@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"Krafti_Vibe/internal/middleware"
 	"Krafti_Vibe/internal/service"
 	"Krafti_Vibe/internal/service/dto"
 
@@ -29,6 +30,8 @@ func NewTenantInvitationHandler(service service.TenantInvitationService) *Tenant
 // @Success 201 {object} dto.InvitationResponse
 // @Failure 400 {object} handler.ErrorResponse
 // @Failure 401 {object} handler.ErrorResponse
+// @Failure 402 {object} handler.ErrorResponse "No artisan seats left on the plan"
+// @Failure 403 {object} handler.ErrorResponse
 // @Router /api/v1/invitations [post]
 func (h *TenantInvitationHandler) CreateInvitation(c *fiber.Ctx) error {
 	authCtx, err := GetAuthContext(c)
@@ -38,19 +41,13 @@ func (h *TenantInvitationHandler) CreateInvitation(c *fiber.Ctx) error {
 
 	var req dto.CreateInvitationRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	// Validate and sanitize
 	req.Sanitize()
 	if err := req.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-			"code":  "VALIDATION_ERROR",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 	}
 
 	invitation, err := h.service.CreateInvitation(c.Context(), &req, authCtx.TenantID, authCtx.UserID)
@@ -72,10 +69,7 @@ func (h *TenantInvitationHandler) CreateInvitation(c *fiber.Ctx) error {
 func (h *TenantInvitationHandler) GetInvitation(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid invitation ID",
-			"code":  "INVALID_INVITATION_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_INVITATION_ID", "invalid invitation ID", nil)
 	}
 
 	invitation, err := h.service.GetInvitation(c.Context(), id)
@@ -97,10 +91,7 @@ func (h *TenantInvitationHandler) GetInvitation(c *fiber.Ctx) error {
 func (h *TenantInvitationHandler) GetInvitationByToken(c *fiber.Ctx) error {
 	token := c.Params("token")
 	if token == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invitation token is required",
-			"code":  "MISSING_TOKEN",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_TOKEN", "invitation token is required", nil)
 	}
 
 	invitation, err := h.service.GetInvitationByToken(c.Context(), token)
@@ -166,6 +157,29 @@ func (h *TenantInvitationHandler) GetPendingInvitations(c *fiber.Ctx) error {
 	return c.JSON(invitations)
 }
 
+// GetTeam retrieves the tenant's staff and pending invitations
+// @Summary Get team
+// @Description Get the organization's staff, the invitations still open, and the seats they take
+// @Tags Tenant Invitations
+// @Produce json
+// @Success 200 {object} dto.TeamResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/v1/invitations/team [get]
+func (h *TenantInvitationHandler) GetTeam(c *fiber.Ctx) error {
+	tenantID := middleware.RequestTenant(c)
+	if tenantID == uuid.Nil {
+		return NewErrorResponse(c, fiber.StatusForbidden, "NO_TENANT", "No organization to get the team of", nil)
+	}
+
+	team, err := h.service.GetTeam(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return c.JSON(team)
+}
+
 // GetInvitationsByEmail retrieves invitations for a specific email
 // @Summary Get invitations by email
 // @Tags Tenant Invitations
@@ -176,10 +190,7 @@ func (h *TenantInvitationHandler) GetPendingInvitations(c *fiber.Ctx) error {
 func (h *TenantInvitationHandler) GetInvitationsByEmail(c *fiber.Ctx) error {
 	email := c.Query("email")
 	if email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "email is required",
-			"code":  "MISSING_EMAIL",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_EMAIL", "email is required", nil)
 	}
 
 	invitations, err := h.service.GetInvitationsByEmail(c.Context(), email)
@@ -202,17 +213,11 @@ func (h *TenantInvitationHandler) GetInvitationsByEmail(c *fiber.Ctx) error {
 func (h *TenantInvitationHandler) AcceptInvitation(c *fiber.Ctx) error {
 	var req dto.AcceptInvitationRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	if err := req.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-			"code":  "VALIDATION_ERROR",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "VALIDATION_ERROR", err.Error(), nil)
 	}
 
 	response, err := h.service.AcceptInvitation(c.Context(), &req)
@@ -239,10 +244,7 @@ func (h *TenantInvitationHandler) RevokeInvitation(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid invitation ID",
-			"code":  "INVALID_INVITATION_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_INVITATION_ID", "invalid invitation ID", nil)
 	}
 
 	if err := h.service.RevokeInvitation(c.Context(), id, authCtx.TenantID); err != nil {
@@ -270,10 +272,7 @@ func (h *TenantInvitationHandler) ResendInvitation(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid invitation ID",
-			"code":  "INVALID_INVITATION_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_INVITATION_ID", "invalid invitation ID", nil)
 	}
 
 	invitation, err := h.service.ResendInvitation(c.Context(), id, authCtx.TenantID)
@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// TenantRoleHandler handles HTTP requests for a tenant's custom roles
+type TenantRoleHandler struct {
+	roleService service.TenantRoleService
+}
+
+// NewTenantRoleHandler creates a new tenant role handler
+func NewTenantRoleHandler(roleService service.TenantRoleService) *TenantRoleHandler {
+	if roleService == nil {
+		panic("tenant role service cannot be nil")
+	}
+	return &TenantRoleHandler{
+		roleService: roleService,
+	}
+}
+
+// ListPermissions godoc
+// @Summary List grantable permissions
+// @Description List the permissions custom roles can grant
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} string
+// @Failure 401 {object} ErrorResponse
+// @Router /roles/permissions [get]
+func (h *TenantRoleHandler) ListPermissions(c *fiber.Ctx) error {
+	return NewSuccessResponse(c, h.roleService.ListPermissions())
+}
+
+// CreateRole godoc
+// @Summary Create role
+// @Description Create a custom role granting permissions across the current tenant
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param role body dto.CreateTenantRoleRequest true "Role data"
+// @Success 201 {object} dto.TenantRoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /roles [post]
+func (h *TenantRoleHandler) CreateRole(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateTenantRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = authCtx.TenantID
+
+	role, err := h.roleService.CreateRole(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_tenant_role", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, role, "Role created successfully")
+}
+
+// ListRoles godoc
+// @Summary List roles
+// @Description List the custom roles of the current tenant
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.TenantRoleResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /roles [get]
+func (h *TenantRoleHandler) ListRoles(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	roles, err := h.roleService.ListRoles(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, roles)
+}
+
+// GetRole godoc
+// @Summary Get role
+// @Description Get a custom role by ID
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Success 200 {object} dto.TenantRoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /roles/{id} [get]
+func (h *TenantRoleHandler) GetRole(c *fiber.Ctx) error {
+	role, err := h.authorizedRole(c)
+	if role == nil {
+		return err
+	}
+
+	return NewSuccessResponse(c, role)
+}
+
+// UpdateRole godoc
+// @Summary Update role
+// @Description Update a custom role's name, description or permissions; its users get the new permissions from their next request
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Param role body dto.UpdateTenantRoleRequest true "Role data"
+// @Success 200 {object} dto.TenantRoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /roles/{id} [put]
+func (h *TenantRoleHandler) UpdateRole(c *fiber.Ctx) error {
+	var req dto.UpdateTenantRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	existing, err := h.authorizedRole(c)
+	if existing == nil {
+		return err
+	}
+
+	role, err := h.roleService.UpdateRole(c.Context(), existing.ID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_tenant_role", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, role, "Role updated successfully")
+}
+
+// DeleteRole godoc
+// @Summary Delete role
+// @Description Delete a custom role, taking it away from every user it was assigned to
+// @Tags roles
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /roles/{id} [delete]
+func (h *TenantRoleHandler) DeleteRole(c *fiber.Ctx) error {
+	existing, err := h.authorizedRole(c)
+	if existing == nil {
+		return err
+	}
+
+	if err := h.roleService.DeleteRole(c.Context(), existing.ID); err != nil {
+		LogHandlerError(c, "delete_tenant_role", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// ListAssignments godoc
+// @Summary List role assignments
+// @Description List the users a custom role is assigned to
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Success 200 {array} dto.TenantRoleAssignmentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /roles/{id}/users [get]
+func (h *TenantRoleHandler) ListAssignments(c *fiber.Ctx) error {
+	role, err := h.authorizedRole(c)
+	if role == nil {
+		return err
+	}
+
+	assignments, err := h.roleService.ListAssignments(c.Context(), role.ID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, assignments)
+}
+
+// AssignRole godoc
+// @Summary Assign role
+// @Description Assign a custom role to a user of the current tenant
+// @Tags roles
+// @Accept json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Param assignment body dto.AssignTenantRoleRequest true "User to assign"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /roles/{id}/users [post]
+func (h *TenantRoleHandler) AssignRole(c *fiber.Ctx) error {
+	var req dto.AssignTenantRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	if req.UserID == uuid.Nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "user_id is required", nil)
+	}
+
+	role, err := h.authorizedRole(c)
+	if role == nil {
+		return err
+	}
+
+	if err := h.roleService.AssignRole(c.Context(), role.ID, req.UserID); err != nil {
+		LogHandlerError(c, "assign_tenant_role", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// UnassignRole godoc
+// @Summary Unassign role
+// @Description Take a custom role away from a user
+// @Tags roles
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Param userId path string true "User ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /roles/{id}/users/{userId} [delete]
+func (h *TenantRoleHandler) UnassignRole(c *fiber.Ctx) error {
+	role, err := h.authorizedRole(c)
+	if role == nil {
+		return err
+	}
+
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UUID", "Invalid userId format", err)
+	}
+
+	if err := h.roleService.UnassignRole(c.Context(), role.ID, userID); err != nil {
+		LogHandlerError(c, "unassign_tenant_role", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// authorizedRole loads the role named by the id parameter, checking it belongs to the
+// caller's tenant. It returns nil once it has sent the error response, with the error
+// the handler is to return.
+func (h *TenantRoleHandler) authorizedRole(c *fiber.Ctx) (*dto.TenantRoleResponse, error) {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UUID", "Invalid id format", err)
+	}
+
+	role, err := h.roleService.GetRole(c.Context(), roleID)
+	if err != nil {
+		return nil, HandleServiceError(c, err)
+	}
+	if authCtx := MustGetAuthContext(c); authCtx == nil || authCtx.TenantID != role.TenantID {
+		return nil, NewForbiddenResponse(c, "You don't have access to this resource")
+	}
+
+	return role, nil
+}
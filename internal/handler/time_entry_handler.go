@@ -0,0 +1,500 @@
+package handler
+
+import (
+	"fmt"
+
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// TimeEntryHandler handles HTTP requests for project time tracking
+type TimeEntryHandler struct {
+	timeTrackingService service.TimeTrackingService
+}
+
+// NewTimeEntryHandler creates a new time entry handler
+func NewTimeEntryHandler(timeTrackingService service.TimeTrackingService) *TimeEntryHandler {
+	if timeTrackingService == nil {
+		panic("time tracking service cannot be nil")
+	}
+	return &TimeEntryHandler{
+		timeTrackingService: timeTrackingService,
+	}
+}
+
+// ============================================================================
+// Timers
+// ============================================================================
+
+// StartTimer godoc
+// @Summary Start a timer
+// @Description Start timing work on a project task. A timer the user already has running is stopped first.
+// @Tags time-entries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param timer body dto.StartTimerRequest true "Task to time"
+// @Success 201 {object} dto.TimeEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /time-entries/timer/start [post]
+func (h *TimeEntryHandler) StartTimer(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.StartTimerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	entry, err := h.timeTrackingService.StartTimer(c.Context(), authCtx.TenantID, authCtx.UserID, &req)
+	if err != nil {
+		LogHandlerError(c, "start_timer", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, entry, "Timer started")
+}
+
+// StopTimer godoc
+// @Summary Stop the running timer
+// @Description Stop the current user's running timer and add its time to the task
+// @Tags time-entries
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.TimeEntryResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /time-entries/timer/stop [post]
+func (h *TimeEntryHandler) StopTimer(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	entry, err := h.timeTrackingService.StopTimer(c.Context(), authCtx.TenantID, authCtx.UserID)
+	if err != nil {
+		LogHandlerError(c, "stop_timer", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, entry, "Timer stopped")
+}
+
+// GetRunningTimer godoc
+// @Summary Get the running timer
+// @Description Get the current user's running timer
+// @Tags time-entries
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.TimeEntryResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /time-entries/timer [get]
+func (h *TimeEntryHandler) GetRunningTimer(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	entry, err := h.timeTrackingService.GetRunningTimer(c.Context(), authCtx.TenantID, authCtx.UserID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, entry)
+}
+
+// ============================================================================
+// Entries
+// ============================================================================
+
+// CreateTimeEntry godoc
+// @Summary Log time
+// @Description Log time spent on a project task after the fact
+// @Tags time-entries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entry body dto.CreateTimeEntryRequest true "Time entry data"
+// @Success 201 {object} dto.TimeEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /time-entries [post]
+func (h *TimeEntryHandler) CreateTimeEntry(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateTimeEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	entry, err := h.timeTrackingService.CreateTimeEntry(c.Context(), authCtx.TenantID, authCtx.UserID, &req)
+	if err != nil {
+		LogHandlerError(c, "create_time_entry", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, entry, "Time entry logged successfully")
+}
+
+// ListMyTimeEntries godoc
+// @Summary List my time entries
+// @Description List the current user's time entries, most recent first
+// @Tags time-entries
+// @Produce json
+// @Security BearerAuth
+// @Param project_id query string false "Project ID"
+// @Param task_id query string false "Task ID"
+// @Param billable query bool false "Only billable or non-billable entries"
+// @Param invoiced query bool false "Only invoiced or uninvoiced entries"
+// @Param from query string false "Started at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Started before (RFC3339 or YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.TimeEntryListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /time-entries [get]
+func (h *TimeEntryHandler) ListMyTimeEntries(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseTimeEntryFilter(c)
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER", err.Error(), err)
+	}
+	filter.TenantID = authCtx.TenantID
+	filter.UserID = &authCtx.UserID
+
+	entries, err := h.timeTrackingService.ListTimeEntries(c.Context(), filter)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, entries)
+}
+
+// GetTimeEntry godoc
+// @Summary Get time entry
+// @Description Get a time entry by ID
+// @Tags time-entries
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Time entry ID"
+// @Success 200 {object} dto.TimeEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /time-entries/{id} [get]
+func (h *TimeEntryHandler) GetTimeEntry(c *fiber.Ctx) error {
+	entryID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	entry, err := h.timeTrackingService.GetTimeEntry(c.Context(), tenantID, entryID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, entry)
+}
+
+// UpdateTimeEntry godoc
+// @Summary Update time entry
+// @Description Correct one of the current user's time entries. Invoiced entries cannot be changed.
+// @Tags time-entries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Time entry ID"
+// @Param entry body dto.UpdateTimeEntryRequest true "Fields to change"
+// @Success 200 {object} dto.TimeEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /time-entries/{id} [put]
+func (h *TimeEntryHandler) UpdateTimeEntry(c *fiber.Ctx) error {
+	entryID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateTimeEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	entry, err := h.timeTrackingService.UpdateTimeEntry(c.Context(), authCtx.TenantID, authCtx.UserID, entryID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_time_entry", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, entry, "Time entry updated successfully")
+}
+
+// DeleteTimeEntry godoc
+// @Summary Delete time entry
+// @Description Delete one of the current user's time entries. Invoiced entries cannot be deleted.
+// @Tags time-entries
+// @Security BearerAuth
+// @Param id path string true "Time entry ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /time-entries/{id} [delete]
+func (h *TimeEntryHandler) DeleteTimeEntry(c *fiber.Ctx) error {
+	entryID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.timeTrackingService.DeleteTimeEntry(c.Context(), authCtx.TenantID, authCtx.UserID, entryID); err != nil {
+		LogHandlerError(c, "delete_time_entry", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// ============================================================================
+// Project Hours and Billing
+// ============================================================================
+
+// ListProjectTimeEntries godoc
+// @Summary List project time entries
+// @Description List everyone's time entries on a project, most recent first
+// @Tags time-entries
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param task_id query string false "Task ID"
+// @Param user_id query string false "User ID"
+// @Param billable query bool false "Only billable or non-billable entries"
+// @Param invoiced query bool false "Only invoiced or uninvoiced entries"
+// @Param from query string false "Started at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Started before (RFC3339 or YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.TimeEntryListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /projects/{id}/time-entries [get]
+func (h *TimeEntryHandler) ListProjectTimeEntries(c *fiber.Ctx) error {
+	projectID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseTimeEntryFilter(c)
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILTER", err.Error(), err)
+	}
+	if filter.UserID, err = ParseUUIDQuery(c, "user_id"); err != nil {
+		return err
+	}
+	filter.TenantID = tenantID
+	filter.ProjectID = &projectID
+
+	entries, err := h.timeTrackingService.ListTimeEntries(c.Context(), filter)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, entries)
+}
+
+// GetProjectHours godoc
+// @Summary Get project hours
+// @Description Compare a project's tracked hours with its estimates, in total and per task, with billable and uninvoiced hours
+// @Tags time-entries
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} dto.ProjectHoursResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /projects/{id}/hours [get]
+func (h *TimeEntryHandler) GetProjectHours(c *fiber.Ctx) error {
+	projectID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	hours, err := h.timeTrackingService.GetProjectHours(c.Context(), tenantID, projectID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, hours)
+}
+
+// ExportBillableHours godoc
+// @Summary Export billable hours
+// @Description Export a project's uninvoiced billable hours, as invoice line items (one per task and rate) or as CSV with one row per entry
+// @Tags time-entries
+// @Produce json,text/csv
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param from query string false "Entries started at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Entries started before (RFC3339 or YYYY-MM-DD)"
+// @Param format query string false "json or csv" default(json)
+// @Success 200 {object} dto.BillableHoursExport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /projects/{id}/billable-hours [get]
+func (h *TimeEntryHandler) ExportBillableHours(c *fiber.Ctx) error {
+	projectID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DATE", err.Error(), err)
+	}
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_DATE", err.Error(), err)
+	}
+
+	switch format := c.Query("format", "json"); format {
+	case "json":
+		export, err := h.timeTrackingService.ExportBillableHours(c.Context(), tenantID, projectID, from, to)
+		if err != nil {
+			return HandleServiceError(c, err)
+		}
+		return NewSuccessResponse(c, export)
+	case "csv":
+		content, err := h.timeTrackingService.ExportBillableHoursCSV(c.Context(), tenantID, projectID, from, to)
+		if err != nil {
+			return HandleServiceError(c, err)
+		}
+		SetNoCacheHeaders(c)
+		c.Set(fiber.HeaderContentType, "text/csv")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="billable-hours-%s.csv"`, projectID))
+		return c.Send(content)
+	default:
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FORMAT", "format must be json or csv", nil)
+	}
+}
+
+// InvoiceBillableHours godoc
+// @Summary Invoice billable hours
+// @Description Bill a project's uninvoiced billable hours to its customer on a draft invoice, one line item per task and rate. The entries are marked as invoiced.
+// @Tags time-entries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param invoice body dto.InvoiceTimeEntriesRequest false "Period and invoice details"
+// @Success 201 {object} dto.InvoiceResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /projects/{id}/billable-hours/invoice [post]
+func (h *TimeEntryHandler) InvoiceBillableHours(c *fiber.Ctx) error {
+	projectID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.InvoiceTimeEntriesRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		}
+	}
+
+	invoice, err := h.timeTrackingService.InvoiceBillableHours(c.Context(), tenantID, projectID, &req)
+	if err != nil {
+		LogHandlerError(c, "invoice_billable_hours", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, invoice, "Billable hours invoiced successfully")
+}
+
+// parseTimeEntryFilter reads the shared time entry filters from the query string
+func parseTimeEntryFilter(c *fiber.Ctx) (dto.TimeEntryFilter, error) {
+	var (
+		filter dto.TimeEntryFilter
+		err    error
+	)
+
+	filter.Page, filter.PageSize = ParsePagination(c)
+	for _, id := range []struct {
+		key    string
+		target **uuid.UUID
+	}{
+		{"project_id", &filter.ProjectID},
+		{"task_id", &filter.TaskID},
+	} {
+		if value := c.Query(id.key); value != "" {
+			parsed, err := uuid.Parse(value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s: %q is not a valid ID", id.key, value)
+			}
+			*id.target = &parsed
+		}
+	}
+	if filter.Billable, err = parseOptionalBoolQuery(c, "billable"); err != nil {
+		return filter, err
+	}
+	if filter.Invoiced, err = parseOptionalBoolQuery(c, "invoiced"); err != nil {
+		return filter, err
+	}
+	if filter.From, err = parseTimeQuery(c, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTimeQuery(c, "to"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"strings"
+
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrashHandler handles HTTP requests for deleted bookings, services and projects
+type TrashHandler struct {
+	trashService service.TrashService
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(trashService service.TrashService) *TrashHandler {
+	if trashService == nil {
+		panic("trash service cannot be nil")
+	}
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// ListDeleted godoc
+// @Summary List deleted records
+// @Description List the current tenant's deleted bookings, services or projects that can still be restored, the most recently deleted first
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Param kind path string true "Record kind: bookings, services or projects"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.TrashListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /trash/{kind} [get]
+func (h *TrashHandler) ListDeleted(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.trashService.ListDeleted(c.Context(), &dto.TrashListRequest{
+		TenantID: tenantID,
+		Kind:     dto.TrashKind(strings.ToLower(c.Params("kind"))),
+		Page:     getIntQuery(c, "page", 1),
+		PageSize: getIntQuery(c, "page_size", 20),
+	})
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, result)
+}
+
+// Restore godoc
+// @Summary Restore deleted record
+// @Description Restore one of the current tenant's deleted bookings, services or projects. A booking whose time slot has been booked since is not restored.
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Param kind path string true "Record kind: bookings, services or projects"
+// @Param id path string true "Record ID"
+// @Success 200 {object} dto.TrashItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /trash/{kind}/{id}/restore [post]
+func (h *TrashHandler) Restore(c *fiber.Ctx) error {
+	id, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	item, err := h.trashService.Restore(c.Context(), tenantID, dto.TrashKind(strings.ToLower(c.Params("kind"))), id)
+	if err != nil {
+		LogHandlerError(c, "restore_deleted", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, item, "Restored")
+}
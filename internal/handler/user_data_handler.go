@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// UserDataHandler handles HTTP requests for users' rights over their personal data:
+// exporting it and having it erased (GDPR)
+type UserDataHandler struct {
+	exportService  service.DataExportService
+	erasureService service.UserErasureService
+}
+
+// NewUserDataHandler creates a new user data handler
+func NewUserDataHandler(exportService service.DataExportService, erasureService service.UserErasureService) *UserDataHandler {
+	return &UserDataHandler{
+		exportService:  exportService,
+		erasureService: erasureService,
+	}
+}
+
+// RequestDataExport godoc
+// @Summary Request personal data export
+// @Description Request a machine-readable archive of the user's personal data: their account and profiles, and the bookings, payments, messages and reviews naming them, as JSON lines. The archive is generated in the background; its download link appears in the user's data exports once ready.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 201 {object} dto.DataExportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "An export is already in progress"
+// @Router /users/{id}/data-export [post]
+func (h *UserDataHandler) RequestDataExport(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	export, err := h.exportService.RequestUserDataExport(c.Context(), userID, authCtx.UserID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, export, "Data export requested")
+}
+
+// ListDataExports godoc
+// @Summary List personal data exports
+// @Description List the exports of the user's personal data, newest first, with download links for those ready
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {array} dto.DataExportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/data-exports [get]
+func (h *UserDataHandler) ListDataExports(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	exports, err := h.exportService.ListUserDataExports(c.Context(), userID, authCtx.UserID, c.BaseURL())
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, exports)
+}
+
+// GetErasure godoc
+// @Summary Get personal data erasure
+// @Description Get whether the user's personal data is scheduled to be erased, or has been
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.ErasureResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/erasure [get]
+func (h *UserDataHandler) GetErasure(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	erasure, err := h.erasureService.GetErasure(c.Context(), userID, authCtx.UserID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, erasure)
+}
+
+// RequestErasure godoc
+// @Summary Request personal data erasure
+// @Description Schedule the erasure of the user's personal data after a 30 day grace period, during which it can be cancelled. The user is then anonymized, keeping the bookings and payments referencing them for financial records.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.ErasureResponse
+// @Failure 400 {object} ErrorResponse "Organization owners can't be erased"
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Already erased"
+// @Router /users/{id}/erasure [post]
+func (h *UserDataHandler) RequestErasure(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	erasure, err := h.erasureService.RequestErasure(c.Context(), userID, authCtx.UserID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, erasure, "Erasure scheduled")
+}
+
+// CancelErasure godoc
+// @Summary Cancel personal data erasure
+// @Description Cancel a scheduled erasure of the user's personal data during its grace period
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.ErasureResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Already erased"
+// @Router /users/{id}/erasure [delete]
+func (h *UserDataHandler) CancelErasure(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	erasure, err := h.erasureService.CancelErasure(c.Context(), userID, authCtx.UserID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, erasure, "Erasure cancelled")
+}
@@ -524,6 +524,33 @@ func (h *UserHandler) UnlockUser(c *fiber.Ctx) error {
 	})
 }
 
+// RevokeSessions godoc
+// @Summary Force logout user
+// @Description Sign a user out of every session by revoking the access tokens issued to them so far. They have to sign in again.
+// @Tags users
+// @Param id path string true "User ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/{id}/force-logout [post]
+func (h *UserHandler) RevokeSessions(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_ID", "Invalid user ID", err)
+	}
+
+	authCtx := middleware.MustGetAuthContext(c)
+	if err := h.userService.RevokeSessions(c.Context(), userID, authCtx.UserID); err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return c.JSON(SuccessResponse{
+		Success: true,
+		Message: "User sessions revoked successfully",
+	})
+}
+
 // GetLockedUsers godoc
 // @Summary Get locked users
 // @Description Get all locked user accounts
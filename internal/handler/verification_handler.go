@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"fmt"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxVerificationDocumentSize caps uploaded verification documents; it must stay within
+// the server body limit
+const maxVerificationDocumentSize = 10 * 1024 * 1024
+
+// VerificationHandler handles HTTP requests for artisan verification documents
+type VerificationHandler struct {
+	verificationService service.VerificationService
+}
+
+// NewVerificationHandler creates a new verification handler
+func NewVerificationHandler(verificationService service.VerificationService) *VerificationHandler {
+	if verificationService == nil {
+		panic("verification service cannot be nil")
+	}
+	return &VerificationHandler{
+		verificationService: verificationService,
+	}
+}
+
+// UploadDocument godoc
+// @Summary Upload verification document
+// @Description Upload an identity document, license or insurance certificate as a PDF, JPEG, PNG or WebP file of up to 10MB for review. Artisans upload their own; tenant owners and admins anyone's.
+// @Tags artisans
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Param file formData file true "Document file"
+// @Param type formData string true "Document type: id, license or insurance"
+// @Param reference formData string false "License or policy number"
+// @Param issuer formData string false "Issuing authority or insurer"
+// @Success 201 {object} dto.VerificationDocumentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Router /artisans/{id}/verification/documents [post]
+func (h *VerificationHandler) UploadDocument(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_FILE", "A document is required in the 'file' field", err)
+	}
+	if fileHeader.Size > maxVerificationDocumentSize {
+		return NewErrorResponse(c, fiber.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "Verification documents are limited to 10MB", nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_FILE", "Failed to read uploaded file", err)
+	}
+	defer file.Close()
+
+	req := &dto.UploadVerificationDocumentRequest{
+		TenantID:  tenantID,
+		ArtisanID: artisanID,
+		Type:      models.VerificationDocumentType(c.FormValue("type")),
+		Reference: c.FormValue("reference"),
+		Issuer:    c.FormValue("issuer"),
+		FileName:  fileHeader.Filename,
+		FileSize:  fileHeader.Size,
+	}
+
+	document, err := h.verificationService.UploadDocument(c.Context(), req, file)
+	if err != nil {
+		LogHandlerError(c, "upload_verification_document", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, document, "Document uploaded for review")
+}
+
+// GetArtisanVerification godoc
+// @Summary Get artisan verification
+// @Description Get an artisan's verified and insured badges and the documents behind them. Artisans can see their own; tenant owners and admins anyone's.
+// @Tags artisans
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Artisan ID"
+// @Success 200 {object} dto.ArtisanVerificationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /artisans/{id}/verification [get]
+func (h *VerificationHandler) GetArtisanVerification(c *fiber.Ctx) error {
+	artisanID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	verification, err := h.verificationService.GetArtisanVerification(c.Context(), tenantID, artisanID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, verification)
+}
+
+// ListDocuments godoc
+// @Summary List verification documents
+// @Description List the current tenant's verification documents in a status, oldest first. Pending documents, the default, form the review queue.
+// @Tags verification
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status: pending, approved, rejected or expired" default(pending)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.VerificationDocumentListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /verification-documents [get]
+func (h *VerificationHandler) ListDocuments(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	page, pageSize := ParsePagination(c)
+	documents, err := h.verificationService.ListDocuments(c.Context(), tenantID, models.VerificationStatus(c.Query("status")), page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, documents)
+}
+
+// DownloadDocument godoc
+// @Summary Download verification document
+// @Description Download the file of a verification document. Artisans can download their own; tenant owners and admins anyone's.
+// @Tags verification
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Document ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /verification-documents/{id}/file [get]
+func (h *VerificationHandler) DownloadDocument(c *fiber.Ctx) error {
+	documentID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	download, err := h.verificationService.OpenDocument(c.Context(), tenantID, documentID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	SetNoCacheHeaders(c)
+	c.Set(fiber.HeaderContentType, download.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, download.FileName))
+	// Fiber closes the stream once the response has been written
+	return c.SendStream(download.Content, int(download.Size))
+}
+
+// ApproveDocument godoc
+// @Summary Approve verification document
+// @Description Approve a pending document until its expiry date, required for licenses and insurance, awarding the badges it earns
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Document ID"
+// @Param approval body dto.ApproveVerificationDocumentRequest false "Expiry date"
+// @Success 200 {object} dto.VerificationDocumentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /verification-documents/{id}/approve [post]
+func (h *VerificationHandler) ApproveDocument(c *fiber.Ctx) error {
+	documentID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ApproveVerificationDocumentRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+		}
+	}
+
+	document, err := h.verificationService.ApproveDocument(c.Context(), tenantID, documentID, &req)
+	if err != nil {
+		LogHandlerError(c, "approve_verification_document", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, document, "Document approved")
+}
+
+// RejectDocument godoc
+// @Summary Reject verification document
+// @Description Reject a pending document, telling the artisan why
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Document ID"
+// @Param rejection body dto.RejectVerificationDocumentRequest true "Rejection reason"
+// @Success 200 {object} dto.VerificationDocumentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /verification-documents/{id}/reject [post]
+func (h *VerificationHandler) RejectDocument(c *fiber.Ctx) error {
+	documentID, err := ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.RejectVerificationDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	document, err := h.verificationService.RejectDocument(c.Context(), tenantID, documentID, &req)
+	if err != nil {
+		LogHandlerError(c, "reject_verification_document", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, document, "Document rejected")
+}
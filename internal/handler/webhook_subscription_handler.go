@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// WebhookSubscriptionHandler handles HTTP requests for tenant webhook subscriptions
+type WebhookSubscriptionHandler struct {
+	subscriptionService service.WebhookSubscriptionService
+}
+
+// NewWebhookSubscriptionHandler creates a new webhook subscription handler
+func NewWebhookSubscriptionHandler(subscriptionService service.WebhookSubscriptionService) *WebhookSubscriptionHandler {
+	if subscriptionService == nil {
+		panic("webhook subscription service cannot be nil")
+	}
+	return &WebhookSubscriptionHandler{
+		subscriptionService: subscriptionService,
+	}
+}
+
+// CreateSubscription godoc
+// @Summary Create webhook subscription
+// @Description Subscribe an endpoint of the current tenant's systems to domain events. The signing secret is only returned in this response.
+// @Tags webhook-subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param subscription body dto.CreateWebhookSubscriptionRequest true "Webhook subscription data"
+// @Success 201 {object} dto.WebhookSubscriptionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhook-subscriptions [post]
+func (h *WebhookSubscriptionHandler) CreateSubscription(c *fiber.Ctx) error {
+	authCtx, err := GetAuthContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateWebhookSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+	req.TenantID = authCtx.TenantID
+
+	subscription, err := h.subscriptionService.CreateSubscription(c.Context(), &req)
+	if err != nil {
+		LogHandlerError(c, "create_webhook_subscription", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewCreatedResponse(c, subscription, "Webhook subscription created successfully")
+}
+
+// ListSubscriptions godoc
+// @Summary List webhook subscriptions
+// @Description List the webhook subscriptions of the current tenant
+// @Tags webhook-subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.WebhookSubscriptionResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhook-subscriptions [get]
+func (h *WebhookSubscriptionHandler) ListSubscriptions(c *fiber.Ctx) error {
+	tenantID, err := GetTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	subscriptions, err := h.subscriptionService.ListSubscriptions(c.Context(), tenantID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, subscriptions)
+}
+
+// GetSubscription godoc
+// @Summary Get webhook subscription
+// @Description Get a webhook subscription by ID
+// @Tags webhook-subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook subscription ID"
+// @Success 200 {object} dto.WebhookSubscriptionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /webhook-subscriptions/{id} [get]
+func (h *WebhookSubscriptionHandler) GetSubscription(c *fiber.Ctx) error {
+	subscription, err := h.authorizedSubscription(c)
+	if subscription == nil {
+		return err
+	}
+
+	return NewSuccessResponse(c, subscription)
+}
+
+// UpdateSubscription godoc
+// @Summary Update webhook subscription
+// @Description Update a webhook subscription's URL, description, event types or whether it is active
+// @Tags webhook-subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook subscription ID"
+// @Param subscription body dto.UpdateWebhookSubscriptionRequest true "Webhook subscription data"
+// @Success 200 {object} dto.WebhookSubscriptionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /webhook-subscriptions/{id} [put]
+func (h *WebhookSubscriptionHandler) UpdateSubscription(c *fiber.Ctx) error {
+	var req dto.UpdateWebhookSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", err)
+	}
+
+	existing, err := h.authorizedSubscription(c)
+	if existing == nil {
+		return err
+	}
+
+	subscription, err := h.subscriptionService.UpdateSubscription(c.Context(), existing.ID, &req)
+	if err != nil {
+		LogHandlerError(c, "update_webhook_subscription", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, subscription, "Webhook subscription updated successfully")
+}
+
+// DeleteSubscription godoc
+// @Summary Delete webhook subscription
+// @Description Delete a webhook subscription; its delivery logs are kept
+// @Tags webhook-subscriptions
+// @Security BearerAuth
+// @Param id path string true "Webhook subscription ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /webhook-subscriptions/{id} [delete]
+func (h *WebhookSubscriptionHandler) DeleteSubscription(c *fiber.Ctx) error {
+	existing, err := h.authorizedSubscription(c)
+	if existing == nil {
+		return err
+	}
+
+	if err := h.subscriptionService.DeleteSubscription(c.Context(), existing.ID); err != nil {
+		LogHandlerError(c, "delete_webhook_subscription", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewNoContentResponse(c)
+}
+
+// RotateSecret godoc
+// @Summary Rotate webhook signing secret
+// @Description Replace a webhook subscription's signing secret. The new secret is only returned in this response and signs every delivery attempted afterwards.
+// @Tags webhook-subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook subscription ID"
+// @Success 200 {object} dto.WebhookSubscriptionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /webhook-subscriptions/{id}/rotate-secret [post]
+func (h *WebhookSubscriptionHandler) RotateSecret(c *fiber.Ctx) error {
+	existing, err := h.authorizedSubscription(c)
+	if existing == nil {
+		return err
+	}
+
+	subscription, err := h.subscriptionService.RotateSecret(c.Context(), existing.ID)
+	if err != nil {
+		LogHandlerError(c, "rotate_webhook_secret", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, subscription, "Webhook secret rotated successfully")
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Description List the deliveries of a webhook subscription, most recent first
+// @Tags webhook-subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook subscription ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} dto.WebhookSubscriptionDeliveryListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /webhook-subscriptions/{id}/deliveries [get]
+func (h *WebhookSubscriptionHandler) ListDeliveries(c *fiber.Ctx) error {
+	subscription, err := h.authorizedSubscription(c)
+	if subscription == nil {
+		return err
+	}
+
+	page, pageSize := ParsePagination(c)
+	deliveries, err := h.subscriptionService.ListDeliveries(c.Context(), subscription.ID, page, pageSize)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, deliveries)
+}
+
+// GetDelivery godoc
+// @Summary Get webhook delivery
+// @Description Get a delivery of a webhook subscription with the log of its attempts
+// @Tags webhook-subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook subscription ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} dto.WebhookSubscriptionDeliveryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /webhook-subscriptions/{id}/deliveries/{deliveryId} [get]
+func (h *WebhookSubscriptionHandler) GetDelivery(c *fiber.Ctx) error {
+	subscription, deliveryID, err := h.authorizedDelivery(c)
+	if subscription == nil {
+		return err
+	}
+
+	delivery, err := h.subscriptionService.GetDelivery(c.Context(), subscription.ID, deliveryID)
+	if err != nil {
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, delivery)
+}
+
+// Redeliver godoc
+// @Summary Redeliver webhook
+// @Description Send a delivery of a webhook subscription again now, whether it succeeded or failed
+// @Tags webhook-subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook subscription ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} dto.WebhookDeliveryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /webhook-subscriptions/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookSubscriptionHandler) Redeliver(c *fiber.Ctx) error {
+	subscription, deliveryID, err := h.authorizedDelivery(c)
+	if subscription == nil {
+		return err
+	}
+
+	response, err := h.subscriptionService.Redeliver(c.Context(), subscription.ID, deliveryID)
+	if err != nil {
+		LogHandlerError(c, "redeliver_webhook", err)
+		return HandleServiceError(c, err)
+	}
+
+	return NewSuccessResponse(c, response)
+}
+
+// authorizedSubscription loads the subscription named by the id parameter, checking it
+// belongs to the caller's tenant. It returns nil once it has sent the error response,
+// with the error the handler is to return.
+func (h *WebhookSubscriptionHandler) authorizedSubscription(c *fiber.Ctx) (*dto.WebhookSubscriptionResponse, error) {
+	subscriptionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UUID", "Invalid id format", err)
+	}
+
+	subscription, err := h.subscriptionService.GetSubscription(c.Context(), subscriptionID)
+	if err != nil {
+		return nil, HandleServiceError(c, err)
+	}
+	if authCtx := MustGetAuthContext(c); authCtx == nil || authCtx.TenantID != subscription.TenantID {
+		return nil, NewForbiddenResponse(c, "You don't have access to this resource")
+	}
+
+	return subscription, nil
+}
+
+// authorizedDelivery is authorizedSubscription, also parsing the deliveryId parameter
+func (h *WebhookSubscriptionHandler) authorizedDelivery(c *fiber.Ctx) (*dto.WebhookSubscriptionResponse, uuid.UUID, error) {
+	subscription, err := h.authorizedSubscription(c)
+	if subscription == nil {
+		return nil, uuid.Nil, err
+	}
+
+	deliveryID, err := uuid.Parse(c.Params("deliveryId"))
+	if err != nil {
+		return nil, uuid.Nil, NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_UUID", "Invalid deliveryId format", err)
+	}
+
+	return subscription, deliveryID, nil
+}
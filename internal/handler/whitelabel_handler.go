@@ -38,10 +38,7 @@ func (h *WhiteLabelHandler) CreateWhiteLabel(c *fiber.Ctx) error {
 
 	var req dto.CreateWhiteLabelRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	whitelabel, err := h.service.CreateWhiteLabel(c.Context(), authCtx.TenantID, &req)
@@ -68,10 +65,7 @@ func (h *WhiteLabelHandler) GetWhiteLabel(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid whitelabel ID",
-			"code":  "INVALID_WHITELABEL_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_WHITELABEL_ID", "invalid whitelabel ID", nil)
 	}
 
 	whitelabel, err := h.service.GetWhiteLabel(c.Context(), id, authCtx.TenantID)
@@ -114,18 +108,12 @@ func (h *WhiteLabelHandler) GetMyWhiteLabel(c *fiber.Ctx) error {
 func (h *WhiteLabelHandler) GetPublicWhiteLabel(c *fiber.Ctx) error {
 	tenantIDStr := c.Query("tenant_id")
 	if tenantIDStr == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "tenant_id is required",
-			"code":  "MISSING_TENANT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_TENANT_ID", "tenant_id is required", nil)
 	}
 
 	tenantID, err := uuid.Parse(tenantIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid tenant_id",
-			"code":  "INVALID_TENANT_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_TENANT_ID", "invalid tenant_id", nil)
 	}
 
 	whitelabel, err := h.service.GetPublicWhiteLabel(c.Context(), tenantID)
@@ -147,10 +135,7 @@ func (h *WhiteLabelHandler) GetPublicWhiteLabel(c *fiber.Ctx) error {
 func (h *WhiteLabelHandler) GetPublicWhiteLabelByDomain(c *fiber.Ctx) error {
 	domain := c.Query("domain")
 	if domain == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "domain is required",
-			"code":  "MISSING_DOMAIN",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_DOMAIN", "domain is required", nil)
 	}
 
 	whitelabel, err := h.service.GetPublicWhiteLabelByDomain(c.Context(), domain)
@@ -179,18 +164,12 @@ func (h *WhiteLabelHandler) UpdateWhiteLabel(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid whitelabel ID",
-			"code":  "INVALID_WHITELABEL_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_WHITELABEL_ID", "invalid whitelabel ID", nil)
 	}
 
 	var req dto.UpdateWhiteLabelRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	whitelabel, err := h.service.UpdateWhiteLabel(c.Context(), id, authCtx.TenantID, &req)
@@ -217,10 +196,7 @@ func (h *WhiteLabelHandler) DeleteWhiteLabel(c *fiber.Ctx) error {
 
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid whitelabel ID",
-			"code":  "INVALID_WHITELABEL_ID",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_WHITELABEL_ID", "invalid whitelabel ID", nil)
 	}
 
 	if err := h.service.DeleteWhiteLabel(c.Context(), id, authCtx.TenantID); err != nil {
@@ -249,10 +225,7 @@ func (h *WhiteLabelHandler) UpdateColorScheme(c *fiber.Ctx) error {
 
 	var req dto.UpdateColorSchemeRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	whitelabel, err := h.service.UpdateColorScheme(c.Context(), authCtx.TenantID, &req)
@@ -280,10 +253,7 @@ func (h *WhiteLabelHandler) UpdateBranding(c *fiber.Ctx) error {
 
 	var req dto.UpdateBrandingRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	whitelabel, err := h.service.UpdateBranding(c.Context(), authCtx.TenantID, &req)
@@ -311,10 +281,7 @@ func (h *WhiteLabelHandler) UpdateDomain(c *fiber.Ctx) error {
 
 	var req dto.UpdateDomainRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-			"code":  "INVALID_REQUEST_BODY",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "invalid request body", nil)
 	}
 
 	whitelabel, err := h.service.UpdateDomain(c.Context(), authCtx.TenantID, &req)
@@ -385,10 +352,7 @@ func (h *WhiteLabelHandler) CheckDomainAvailability(c *fiber.Ctx) error {
 
 	domain := c.Query("domain")
 	if domain == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "domain is required",
-			"code":  "MISSING_DOMAIN",
-		})
+		return NewErrorResponse(c, fiber.StatusBadRequest, "MISSING_DOMAIN", "domain is required", nil)
 	}
 
 	available, err := h.service.CheckDomainAvailability(c.Context(), domain, authCtx.TenantID)
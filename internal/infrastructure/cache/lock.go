@@ -0,0 +1,223 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// Lock errors
+var (
+	ErrLockNotAcquired = errors.New("cache: lock is held by another owner")
+	ErrLockLost        = errors.New("cache: lock expired or was taken over")
+)
+
+// lockRetryInterval is how often a waiting caller retries a held lock
+const lockRetryInterval = 50 * time.Millisecond
+
+// acquireScript takes the lock if it is free and hands out the next fencing token
+var acquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0
+`)
+
+// refreshScript extends the lock only while the caller still owns it
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript deletes the lock only while the caller still owns it
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Lock is a distributed lock on a named work item, held by one owner until it is
+// released or its TTL passes without a refresh.
+//
+// Every acquisition gets a fencing token greater than any handed out before for the
+// same name. A holder that may have lost the lock while paused, e.g. during a long GC
+// or network partition, can pass the token to the resource it writes, which rejects
+// tokens older than the newest it has seen.
+type Lock struct {
+	client *RedisClient
+	key    string
+	owner  string
+	token  int64
+}
+
+// lockKeys returns the lock's key and its fencing counter. The braces keep both on
+// one Redis Cluster slot, which the scripts require.
+func (r *RedisClient) lockKeys(name string) (string, string) {
+	key := r.makeKey("lock:{" + name + "}")
+	return key, key + ":fence"
+}
+
+// Lock takes the named lock for ttl, or returns ErrLockNotAcquired when another owner
+// holds it
+func (r *RedisClient) Lock(ctx context.Context, name string, ttl time.Duration) (*Lock, error) {
+	key, fenceKey := r.lockKeys(name)
+
+	owner, err := newLockOwner()
+	if err != nil {
+		return nil, err
+	}
+	token, err := acquireScript.Run(ctx, r.client, []string{key, fenceKey}, owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		r.logger.Error("failed to acquire lock",
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if token == 0 {
+		return nil, ErrLockNotAcquired
+	}
+
+	return &Lock{client: r, key: key, owner: owner, token: token}, nil
+}
+
+// WaitLock is Lock, retrying for up to wait while another owner holds the lock
+func (r *RedisClient) WaitLock(ctx context.Context, name string, ttl, wait time.Duration) (*Lock, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		lock, err := r.Lock(ctx, name, ttl)
+		if !errors.Is(err, ErrLockNotAcquired) || time.Now().Add(lockRetryInterval).After(deadline) {
+			return lock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// WithLock runs fn while holding the named lock, waiting up to wait for it. It reports
+// false without running fn when the lock stays held by another owner.
+//
+// The lock is refreshed while fn runs, so ttl only bounds how long it outlives an
+// instance that dies holding it. Should the lock be lost anyway, the context passed to
+// fn is cancelled.
+func (r *RedisClient) WithLock(ctx context.Context, name string, ttl, wait time.Duration, fn func(ctx context.Context, token int64) error) (bool, error) {
+	lock, err := r.WaitLock(ctx, name, ttl, wait)
+	if errors.Is(err, ErrLockNotAcquired) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	held, cancel := context.WithCancel(ctx)
+	refreshed := make(chan struct{})
+	go func() {
+		defer close(refreshed)
+		lock.keepAlive(held, ttl)
+		cancel()
+	}()
+	defer func() {
+		cancel()
+		<-refreshed
+		// Release even when the caller's context is done, so the lock doesn't sit
+		// out its TTL
+		releaseCtx, cancelRelease := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancelRelease()
+		if err := lock.Release(releaseCtx); err != nil && !errors.Is(err, ErrLockLost) {
+			r.logger.Warn("failed to release lock",
+				zap.String("key", lock.key),
+				zap.Error(err),
+			)
+		}
+	}()
+
+	return true, fn(held, lock.token)
+}
+
+// Token returns the lock's fencing token
+func (l *Lock) Token() int64 {
+	return l.token
+}
+
+// Refresh extends the lock to ttl from now, or returns ErrLockLost when it is no
+// longer held by this owner
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	ok, err := refreshScript.Run(ctx, l.client.client, []string{l.key}, l.owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if ok == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+// Release frees the lock, or returns ErrLockLost when it is no longer held by this
+// owner, in which case it is left to its new owner
+func (l *Lock) Release(ctx context.Context) error {
+	ok, err := releaseScript.Run(ctx, l.client.client, []string{l.key}, l.owner).Int64()
+	if err != nil {
+		return err
+	}
+	if ok == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+// keepAlive refreshes the lock every third of its TTL until ctx is done. It returns
+// early once the lock is lost or can't be refreshed before it would expire.
+func (l *Lock) keepAlive(ctx context.Context, ttl time.Duration) {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	expires := time.Now().Add(ttl)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := l.Refresh(ctx, ttl)
+			switch {
+			case err == nil:
+				expires = time.Now().Add(ttl)
+			case errors.Is(err, ErrLockLost):
+				l.client.logger.Warn("lock lost while held", zap.String("key", l.key))
+				return
+			case ctx.Err() != nil:
+				return
+			default:
+				// A failed refresh is retried on the next tick while the lock may still
+				// be held
+				if time.Now().Add(ttl / 3).After(expires) {
+					l.client.logger.Warn("lock could not be refreshed before expiring",
+						zap.String("key", l.key),
+						zap.Error(err),
+					)
+					return
+				}
+			}
+		}
+	}
+}
+
+// newLockOwner returns a random value identifying one acquisition of a lock
+func newLockOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
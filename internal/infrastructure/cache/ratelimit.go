@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// slidingWindowScript counts a request against a sliding window log kept in a sorted
+// set scored by arrival time. Redis' clock is used so every API instance agrees on
+// the window. It returns whether the request was counted, how many more the window
+// has room for, and the milliseconds until the oldest counted request leaves it.
+var slidingWindowScript = redis.NewScript(`
+local now = redis.call("TIME")
+local nowMs = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", nowMs - window)
+local count = redis.call("ZCARD", KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", KEYS[1], nowMs, ARGV[3])
+	redis.call("PEXPIRE", KEYS[1], window)
+	count = count + 1
+	allowed = 1
+end
+
+local reset = window
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - nowMs
+end
+return {allowed, limit - count, reset}
+`)
+
+// RateLimitResult is the outcome of counting a request against a sliding window limit
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is how long until the oldest request counted leaves the window,
+	// freeing room for another
+	ResetAfter time.Duration
+}
+
+// AllowRequest counts a request against the limit requests per window shared by
+// every caller using key. Requests over the limit are not counted, so a throttled
+// caller regains room as its earlier requests age out of the window.
+//
+// The window keeps one sorted set member per request counted, so memory grows with
+// the limit rather than the traffic.
+func (r *RedisClient) AllowRequest(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	redisKey := r.makeKey("ratelimit:sliding:" + key)
+
+	values, err := slidingWindowScript.Run(ctx, r.client, []string{redisKey}, window.Milliseconds(), limit, rand.Text()).Int64Slice()
+	if err != nil {
+		r.logger.Error("failed to check rate limit",
+			zap.String("key", redisKey),
+			zap.Error(err),
+		)
+		return RateLimitResult{}, err
+	}
+
+	return RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      limit,
+		Remaining:  int(max(values[1], 0)),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -32,24 +33,49 @@ type RedisConfig struct {
 	PoolTimeout     time.Duration
 	ConnMaxIdleTime time.Duration
 	KeyPrefix       string
+
+	// Optional: returns the password for each new connection, so a password rotated in
+	// a secret manager is used without a restart. Password is used when it fails.
+	PasswordSource func(ctx context.Context) (string, error)
 }
 
 // NewRedisClient creates a new Redis client
 func NewRedisClient(config RedisConfig, logger *zap.Logger) (*RedisClient, error) {
 	addr := fmt.Sprintf("%s:%s", config.Host, config.Port)
 
+	var credentials func() (string, string)
+	if config.PasswordSource != nil {
+		var mu sync.Mutex
+		password := config.Password
+		credentials = func() (string, string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			mu.Lock()
+			defer mu.Unlock()
+			current, err := config.PasswordSource(ctx)
+			if err != nil {
+				logger.Warn("failed to resolve Redis password, using the last known", zap.Error(err))
+				return "", password
+			}
+			password = current
+			return "", password
+		}
+	}
+
 	client := redis.NewClient(&redis.Options{
-		Addr:            addr,
-		Password:        config.Password,
-		DB:              config.DB,
-		MaxRetries:      config.MaxRetries,
-		PoolSize:        config.PoolSize,
-		MinIdleConns:    config.MinIdleConns,
-		DialTimeout:     config.DialTimeout,
-		ReadTimeout:     config.ReadTimeout,
-		WriteTimeout:    config.WriteTimeout,
-		PoolTimeout:     config.PoolTimeout,
-		ConnMaxIdleTime: config.ConnMaxIdleTime,
+		CredentialsProvider: credentials,
+		Addr:                addr,
+		Password:            config.Password,
+		DB:                  config.DB,
+		MaxRetries:          config.MaxRetries,
+		PoolSize:            config.PoolSize,
+		MinIdleConns:        config.MinIdleConns,
+		DialTimeout:         config.DialTimeout,
+		ReadTimeout:         config.ReadTimeout,
+		WriteTimeout:        config.WriteTimeout,
+		PoolTimeout:         config.PoolTimeout,
+		ConnMaxIdleTime:     config.ConnMaxIdleTime,
 	})
 
 	// Test connection
@@ -57,6 +83,7 @@ func NewRedisClient(config RedisConfig, logger *zap.Logger) (*RedisClient, error
 	defer cancel()
 
 	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
@@ -550,6 +577,25 @@ func (r *RedisClient) Flush(ctx context.Context) error {
 	return nil
 }
 
+// Publish posts a message to a prefixed pub/sub channel
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	err := r.client.Publish(ctx, r.makeKey(channel), message).Err()
+	if err != nil {
+		r.logger.Error("failed to publish message", zap.String("channel", channel), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Subscribe subscribes to prefixed pub/sub channels. The caller must close the subscription.
+func (r *RedisClient) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	keys := make([]string, len(channels))
+	for i, channel := range channels {
+		keys[i] = r.makeKey(channel)
+	}
+	return r.client.Subscribe(ctx, keys...)
+}
+
 // Ping checks if the Redis server is responsive
 func (r *RedisClient) Ping(ctx context.Context) error {
 	return r.client.Ping(ctx).Err()
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// denyAll is the cutoff of a subject whose tokens are all refused, whenever issued
+const denyAll = math.MaxInt64
+
+// raiseCutoffScript moves a subject's revocation cutoff forward, never back, so a
+// revocation can't lift a standing denial. A TTL of 0 keeps the cutoff until released.
+var raiseCutoffScript = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+local cutoff = tonumber(ARGV[1])
+if cutoff <= current then
+	return 0
+end
+if tonumber(ARGV[2]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+else
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+// UserTokens is the subject of the tokens issued to a user
+func UserTokens(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// TenantTokens is the subject of the tokens issued to a tenant's users
+func TenantTokens(tenantID uuid.UUID) string {
+	return "tenant:" + tenantID.String()
+}
+
+// TokenRevocations is a deny list of access tokens kept in Redis, so every API instance
+// refuses a token as soon as it is revoked rather than when it expires.
+//
+// Tokens are revoked by subject, such as a user or a tenant, and by when they were
+// issued: revoking a subject refuses the tokens issued to it so far, while new sign-ins
+// work. Denying a subject refuses its tokens until it is released, for users and
+// tenants that are suspended.
+type TokenRevocations struct {
+	client *RedisClient
+	// tokenLifetime is the longest an access token is valid, after which revoking it
+	// no longer needs remembering
+	tokenLifetime time.Duration
+}
+
+// NewTokenRevocations creates a deny list for access tokens valid for at most tokenLifetime
+func NewTokenRevocations(client *RedisClient, tokenLifetime time.Duration) *TokenRevocations {
+	return &TokenRevocations{
+		client:        client,
+		tokenLifetime: tokenLifetime,
+	}
+}
+
+// Revoke refuses the tokens issued to subject until now
+func (t *TokenRevocations) Revoke(ctx context.Context, subject string) error {
+	return t.raiseCutoff(ctx, subject, time.Now().UnixMilli(), t.tokenLifetime)
+}
+
+// Deny refuses every token of subject, including those issued later, until Release
+func (t *TokenRevocations) Deny(ctx context.Context, subject string) error {
+	return t.raiseCutoff(ctx, subject, denyAll, 0)
+}
+
+// Release lifts a denial of subject. Tokens issued before stay refused, so sessions
+// from before a suspension have to sign in again.
+func (t *TokenRevocations) Release(ctx context.Context, subject string) error {
+	key := t.key(subject)
+	if err := t.client.client.Set(ctx, key, time.Now().UnixMilli(), t.tokenLifetime).Err(); err != nil {
+		t.client.logger.Error("failed to release token revocation",
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// RevokedBefore returns the latest cutoff of the subjects: their tokens issued before it
+// are refused. It is the zero time when none has tokens revoked, and after every time
+// when one is denied.
+func (t *TokenRevocations) RevokedBefore(ctx context.Context, subjects ...string) (time.Time, error) {
+	if len(subjects) == 0 {
+		return time.Time{}, nil
+	}
+
+	keys := make([]string, len(subjects))
+	for i, subject := range subjects {
+		keys[i] = t.key(subject)
+	}
+	values, err := t.client.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		t.client.logger.Error("failed to check token revocations",
+			zap.Strings("keys", keys),
+			zap.Error(err),
+		)
+		return time.Time{}, err
+	}
+
+	var latest int64
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if cutoff, err := strconv.ParseInt(s, 10, 64); err == nil {
+			latest = max(latest, cutoff)
+		}
+	}
+	switch latest {
+	case 0:
+		return time.Time{}, nil
+	case denyAll:
+		// Beyond any time a token can be issued at
+		return time.Unix(1<<62, 0), nil
+	}
+	return time.UnixMilli(latest), nil
+}
+
+// raiseCutoff refuses the tokens of subject issued before cutoff, in Unix milliseconds
+func (t *TokenRevocations) raiseCutoff(ctx context.Context, subject string, cutoff int64, ttl time.Duration) error {
+	key := t.key(subject)
+	if err := raiseCutoffScript.Run(ctx, t.client.client, []string{key}, cutoff, ttl.Milliseconds()).Err(); err != nil {
+		t.client.logger.Error("failed to revoke tokens",
+			zap.String("key", key),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (t *TokenRevocations) key(subject string) string {
+	return t.client.makeKey("revoked_tokens:" + subject)
+}
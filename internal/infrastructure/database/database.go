@@ -3,9 +3,12 @@ package database
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"Krafti_Vibe/internal/config"
+	"Krafti_Vibe/internal/pkg/chaos"
+	"Krafti_Vibe/internal/pkg/tracing"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/stdlib"
@@ -17,8 +20,20 @@ import (
 
 var (
 	db *gorm.DB
+
+	poolMonitor     *PoolMonitor
+	stopPoolMonitor context.CancelFunc
+
+	passwordSource func(ctx context.Context) (string, error)
 )
 
+// SetPasswordSource sets a function returning the database password for each new
+// connection, so a password rotated in a secret manager is used without a restart.
+// It must be called before Initialize.
+func SetPasswordSource(source func(ctx context.Context) (string, error)) {
+	passwordSource = source
+}
+
 // DB returns the global database instance
 func DB() *gorm.DB {
 	if db == nil {
@@ -31,15 +46,19 @@ func DB() *gorm.DB {
 func Initialize(cfg *config.Config, zapLogger *zap.Logger) error {
 	gormLogger := NewGormLogger(zapLogger, cfg.IsDevelopment())
 
+	// DSNs carry the password, so only where they point is logged
 	dsn := cfg.DatabaseURL()
 	if dsn != "" {
-		zapLogger.Info("using DATABASEURL", zap.String("dsn", dsn))
-	} else if dsn = cfg.DatabaseDSN(); dsn == "" {
-		zapLogger.Info("using DSN", zap.String("dsn", dsn))
+		zapLogger.Info("using DATABASE_URL")
+	} else {
+		dsn = cfg.DatabaseDSN()
+		zapLogger.Info("using DSN",
+			zap.String("host", cfg.Database.Host),
+			zap.String("port", cfg.Database.Port),
+			zap.String("dbname", cfg.Database.DBName),
+		)
 	}
 
-	zapLogger.Info("using DSN", zap.String("dsn", dsn))
-
 	// Parse DSN (DO NOT CONNECT HERE)
 	pgxCfg, err := pgx.ParseConfig(dsn)
 	if err != nil {
@@ -48,8 +67,24 @@ func Initialize(cfg *config.Config, zapLogger *zap.Logger) error {
 
 	pgxCfg.ConnectTimeout = 10 * time.Second
 
-	// ONE connection pool only
-	sqlDB := stdlib.OpenDB(*pgxCfg)
+	// Postgres cancels any statement running past the limit, even if the client is gone
+	if cfg.Database.StatementTimeout > 0 {
+		pgxCfg.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.Database.StatementTimeout.Milliseconds(), 10)
+	}
+
+	// ONE connection pool only. Connections scoped to a tenant are cleared before reuse.
+	options := []stdlib.OptionOpenDB{stdlib.OptionResetSession(ResetTenantSession)}
+	if passwordSource != nil {
+		options = append(options, stdlib.OptionBeforeConnect(func(ctx context.Context, cc *pgx.ConnConfig) error {
+			password, err := passwordSource(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to resolve database password: %w", err)
+			}
+			cc.Password = password
+			return nil
+		}))
+	}
+	sqlDB := stdlib.OpenDB(*pgxCfg, options...)
 
 	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
 	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
@@ -66,26 +101,69 @@ func Initialize(cfg *config.Config, zapLogger *zap.Logger) error {
 	})
 
 	if err != nil {
+		// The pool is closed so a retried Initialize doesn't leak it
+		sqlDB.Close()
+		db = nil
 		return fmt.Errorf("failed to connect using GORM: %w", err)
 	}
 
+	// Statements run within traced requests and jobs are recorded as spans
+	if cfg.Tracing.Enabled {
+		if err := db.Use(tracing.NewGormPlugin()); err != nil {
+			return fmt.Errorf("failed to register tracing: %w", err)
+		}
+	}
+
+	// Requests can drop the database to exercise failure handling outside production
+	if cfg.Chaos.Enabled {
+		if err := db.Use(chaos.NewGormPlugin()); err != nil {
+			return fmt.Errorf("failed to register fault injection: %w", err)
+		}
+	}
+
+	// Row-level security policies limit tenant-scoped requests to their tenant's rows
+	if err := RegisterTenantIsolation(db); err != nil {
+		return fmt.Errorf("failed to register tenant isolation: %w", err)
+	}
+
 	// ping
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
+		db = nil
 		return fmt.Errorf("DB ping failed: %w", err)
 	}
 
+	// Watch the pool for waiting requests and saturation, resizing it if allowed to
+	poolMonitor = NewPoolMonitor(sqlDB, PoolConfig{
+		MaxOpenConns:      cfg.Database.MaxOpenConns,
+		MaxOpenConnsLimit: cfg.Database.MaxOpenConnsLimit,
+		Interval:          cfg.Database.PoolMonitorInterval,
+		SaturationPercent: cfg.Database.PoolSaturationPercent,
+	}, zapLogger)
+	var monitorCtx context.Context
+	monitorCtx, stopPoolMonitor = context.WithCancel(context.Background())
+	go poolMonitor.Run(monitorCtx)
+
 	zapLogger.Info("Neon database connection established!")
 	return nil
 }
 
+// Pool returns the connection pool monitor, or nil before Initialize
+func Pool() *PoolMonitor {
+	return poolMonitor
+}
+
 // Close closes the database connection
 func Close() error {
 	if db == nil {
 		return nil
 	}
+	if stopPoolMonitor != nil {
+		stopPoolMonitor()
+	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -110,12 +188,10 @@ func HealthCheck(ctx context.Context) error {
 		return fmt.Errorf("database ping failed: %w", err)
 	}
 
-	// Check connection pool stats
-	stats := sqlDB.Stats()
-	if stats.OpenConnections >= stats.MaxOpenConnections {
-		return fmt.Errorf("database connection pool exhausted")
+	// A saturated pool still serves requests, only slower
+	if poolMonitor != nil {
+		return poolMonitor.Check()
 	}
-
 	return nil
 }
 
@@ -141,6 +217,7 @@ func Stats() (map[string]any, error) {
 		"max_idle_closed":      stats.MaxIdleClosed,
 		"max_idle_time_closed": stats.MaxIdleTimeClosed,
 		"max_lifetime_closed":  stats.MaxLifetimeClosed,
+		"saturated":            poolMonitor != nil && poolMonitor.Last().Saturated,
 	}, nil
 }
 
@@ -184,12 +261,13 @@ func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (stri
 	sql, rows := fc()
 
 	if err != nil {
-		l.zapLogger.Error("database query failed",
+		fields := []zap.Field{
 			zap.Error(err),
 			zap.String("sql", sql),
 			zap.Int64("rows", rows),
 			zap.Duration("elapsed", elapsed),
-		)
+		}
+		l.zapLogger.Error("database query failed", append(fields, tracing.Fields(ctx)...)...)
 	} else if l.debug {
 		l.zapLogger.Debug("database query",
 			zap.String("sql", sql),
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"fmt"
+
+	"Krafti_Vibe/internal/config"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/fieldcrypt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// encryptBatchSize bounds the payments rewritten per transaction by EncryptPaymentFields
+const encryptBatchSize = 500
+
+// ConfigureFieldEncryption sets the cipher for encrypted model fields from the field
+// encryption keys. Without a key sensitive fields are stored in plaintext.
+func ConfigureFieldEncryption(cfg *config.Config, logger *zap.Logger) error {
+	if cfg.App.FieldEncryptionKey == "" {
+		logger.Warn("field encryption disabled; sensitive payment fields are stored in plaintext")
+		return nil
+	}
+
+	cipher, err := fieldcrypt.New(cfg.App.FieldEncryptionKey, cfg.App.FieldEncryptionPreviousKeys...)
+	if err != nil {
+		return err
+	}
+	fieldcrypt.SetDefault(cipher)
+	logger.Info("field encryption enabled", zap.Int("previous_keys", len(cfg.App.FieldEncryptionPreviousKeys)))
+	return nil
+}
+
+// EncryptPaymentFields rewrites every payment's provider payment ID and metadata with
+// the current key, encrypting rows stored before field encryption was enabled and
+// moving rows off rotated keys. Rows are locked while they are rewritten, so it is
+// safe to run repeatedly and alongside the API. With dryRun it only counts the rows.
+func EncryptPaymentFields(db *gorm.DB, logger *zap.Logger, dryRun bool) (int, error) {
+	if fieldcrypt.Default() == nil {
+		return 0, fmt.Errorf("field encryption is not configured")
+	}
+
+	total := 0
+	lastID := uuid.Nil
+	for {
+		var batch []*models.Payment
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&models.Payment{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id", "provider_payment_id", "metadata").
+				Where("id > ?", lastID).
+				Order("id ASC").
+				Limit(encryptBatchSize).
+				Find(&batch).Error; err != nil {
+				return err
+			}
+			if dryRun {
+				return nil
+			}
+			for _, payment := range batch {
+				// UpdateColumns leaves updated_at and the optimistic lock version alone
+				if err := tx.Model(payment).
+					Select("provider_payment_id", "metadata").
+					UpdateColumns(payment).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return total, fmt.Errorf("failed to encrypt payments: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		lastID = batch[len(batch)-1].ID
+		total += len(batch)
+		logger.Info("payments encrypted", zap.Int("total", total), zap.Bool("dry_run", dryRun))
+	}
+
+	return total, nil
+}
@@ -0,0 +1,62 @@
+-- Drops every table created by the baseline. Extensions are left in place because
+-- other schemas in the database may use them.
+
+DROP TABLE IF EXISTS "service_addon_relations";
+DROP TABLE IF EXISTS "tenant_admins";
+DROP TABLE IF EXISTS "white_labels";
+DROP TABLE IF EXISTS "idempotency_keys";
+DROP TABLE IF EXISTS "api_keys";
+DROP TABLE IF EXISTS "audit_logs";
+DROP TABLE IF EXISTS "webhook_events";
+DROP TABLE IF EXISTS "data_export_requests";
+DROP TABLE IF EXISTS "tenant_usage_trackings";
+DROP TABLE IF EXISTS "tenant_invitations";
+DROP TABLE IF EXISTS "system_settings";
+DROP TABLE IF EXISTS "reports";
+DROP TABLE IF EXISTS "analytics_events";
+DROP TABLE IF EXISTS "reviews";
+DROP TABLE IF EXISTS "file_uploads";
+DROP TABLE IF EXISTS "email_templates";
+DROP TABLE IF EXISTS "notifications";
+DROP TABLE IF EXISTS "messages";
+DROP TABLE IF EXISTS "subscriptions";
+DROP TABLE IF EXISTS "plans";
+DROP TABLE IF EXISTS "promo_code_redemptions";
+DROP TABLE IF EXISTS "promo_codes";
+DROP TABLE IF EXISTS "commission_rules";
+DROP TABLE IF EXISTS "tax_rules";
+DROP TABLE IF EXISTS "invoice_sequences";
+DROP TABLE IF EXISTS "invoices";
+DROP TABLE IF EXISTS "financial_statements";
+DROP TABLE IF EXISTS "reconciliation_reports";
+DROP TABLE IF EXISTS "payouts";
+DROP TABLE IF EXISTS "payout_batches";
+DROP TABLE IF EXISTS "payment_webhook_events";
+DROP TABLE IF EXISTS "payments";
+DROP TABLE IF EXISTS "change_order_events";
+DROP TABLE IF EXISTS "change_orders";
+DROP TABLE IF EXISTS "project_templates";
+DROP TABLE IF EXISTS "quote_revisions";
+DROP TABLE IF EXISTS "quotes";
+DROP TABLE IF EXISTS "time_entries";
+DROP TABLE IF EXISTS "project_updates";
+DROP TABLE IF EXISTS "project_health_snapshots";
+DROP TABLE IF EXISTS "project_status_changes";
+DROP TABLE IF EXISTS "task_dependencies";
+DROP TABLE IF EXISTS "project_tasks";
+DROP TABLE IF EXISTS "project_milestones";
+DROP TABLE IF EXISTS "projects";
+DROP TABLE IF EXISTS "booking_conversations";
+DROP TABLE IF EXISTS "booking_events";
+DROP TABLE IF EXISTS "saved_filters";
+DROP TABLE IF EXISTS "booking_exports";
+DROP TABLE IF EXISTS "booking_imports";
+DROP TABLE IF EXISTS "cancellation_policies";
+DROP TABLE IF EXISTS "bookings";
+DROP TABLE IF EXISTS "availabilities";
+DROP TABLE IF EXISTS "service_addons";
+DROP TABLE IF EXISTS "services";
+DROP TABLE IF EXISTS "customers";
+DROP TABLE IF EXISTS "artisans";
+DROP TABLE IF EXISTS "users";
+DROP TABLE IF EXISTS "tenants";
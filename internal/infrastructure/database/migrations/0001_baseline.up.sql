@@ -0,0 +1,1827 @@
+-- Baseline schema: every table as of the move from GORM AutoMigrate to versioned
+-- migrations. Foreign keys are not declared, matching the AutoMigrate schema.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+CREATE EXTENSION IF NOT EXISTS "btree_gin";
+CREATE EXTENSION IF NOT EXISTS "btree_gist";
+CREATE EXTENSION IF NOT EXISTS "pgcrypto";
+
+CREATE TABLE "tenants" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "owner_id" uuid NOT NULL,
+    "name" varchar(255) NOT NULL,
+    "subdomain" varchar(63) NOT NULL,
+    "domain" varchar(255),
+    "business_name" varchar(255),
+    "business_email" varchar(255),
+    "business_phone" varchar(20),
+    "tax_id" varchar(50),
+    "plan" varchar(50) NOT NULL DEFAULT 'solo',
+    "status" varchar(50) NOT NULL DEFAULT 'trial',
+    "trial_ends_at" timestamptz,
+    "subscription_id" varchar(255),
+    "billing_customer_id" varchar(255),
+    "settings" jsonb,
+    "features" jsonb,
+    "integrations" jsonb,
+    "logo_url" varchar(500),
+    "primary_color" varchar(7),
+    "max_users" bigint DEFAULT 10,
+    "max_artisans" bigint DEFAULT 5,
+    "max_storage" bigint DEFAULT 1073741824,
+    "current_users" bigint DEFAULT 0,
+    "storage_used" bigint DEFAULT 0,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tenants_subdomain" ON "tenants" ("subdomain");
+CREATE INDEX IF NOT EXISTS "idx_tenants_owner_id" ON "tenants" ("owner_id");
+CREATE INDEX IF NOT EXISTS "idx_tenants_deleted_at" ON "tenants" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_tenants_updated_at" ON "tenants" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_tenants_created_at" ON "tenants" ("created_at");
+
+CREATE TABLE "users" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid,
+    "is_platform_user" boolean DEFAULT false,
+    "email" varchar(255) NOT NULL,
+    "password_hash" varchar(255),
+    "zitadel_user_id" varchar(255),
+    "auth_provider" varchar(20) DEFAULT 'zitadel',
+    "migration_status" varchar(20) DEFAULT 'completed',
+    "migrated_at" timestamptz,
+    "first_name" varchar(100) NOT NULL,
+    "last_name" varchar(100) NOT NULL,
+    "phone_number" varchar(20),
+    "avatar_url" varchar(500),
+    "role" varchar(50) NOT NULL,
+    "status" varchar(50) NOT NULL DEFAULT 'pending',
+    "timezone" varchar(50) DEFAULT 'UTC',
+    "language" varchar(10) DEFAULT 'en',
+    "email_verified" boolean DEFAULT false,
+    "phone_verified" boolean DEFAULT false,
+    "mfa_enabled" boolean DEFAULT false,
+    "mfa_secret" varchar(255),
+    "two_factor_enabled" boolean DEFAULT false,
+    "two_factor_secret" varchar(255),
+    "backup_codes" text[],
+    "last_login_at" timestamptz,
+    "last_password_reset_at" timestamptz,
+    "password_changed_at" timestamptz,
+    "must_change_password" boolean DEFAULT false,
+    "failed_login_attempts" bigint DEFAULT 0,
+    "last_failed_login_at" timestamptz,
+    "locked_until" timestamptz,
+    "account_locked_reason" varchar(255),
+    "session_token" varchar(255),
+    "session_expires_at" timestamptz,
+    "refresh_tokens" text[],
+    "terms_accepted_at" timestamptz,
+    "terms_version" varchar(50),
+    "privacy_policy_accepted_at" timestamptz,
+    "data_processing_consent" boolean DEFAULT false,
+    "marketing_consent" boolean DEFAULT false,
+    "data_retention_days" bigint DEFAULT 730,
+    "marked_for_deletion" boolean DEFAULT false,
+    "deletion_scheduled_at" timestamptz,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_marked_for_deletion" ON "users" ("marked_for_deletion");
+CREATE INDEX IF NOT EXISTS "idx_users_session_token" ON "users" ("session_token");
+CREATE INDEX IF NOT EXISTS "idx_users_role" ON "users" ("role");
+CREATE INDEX IF NOT EXISTS "idx_users_migration_status" ON "users" ("migration_status");
+CREATE INDEX IF NOT EXISTS "idx_users_auth_provider" ON "users" ("auth_provider");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_zitadel_user_id" ON "users" ("zitadel_user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_email" ON "users" ("email");
+CREATE INDEX IF NOT EXISTS "idx_users_is_platform_user" ON "users" ("is_platform_user");
+CREATE INDEX IF NOT EXISTS "idx_user_tenant_email" ON "users" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_users_updated_at" ON "users" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_users_created_at" ON "users" ("created_at");
+
+CREATE TABLE "artisans" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "user_id" uuid NOT NULL,
+    "tenant_id" uuid NOT NULL,
+    "bio" text,
+    "specialization" jsonb,
+    "years_experience" bigint DEFAULT 0,
+    "certifications" jsonb,
+    "portfolio" jsonb,
+    "rating" decimal(3,2) DEFAULT 0,
+    "review_count" bigint DEFAULT 0,
+    "total_bookings" bigint DEFAULT 0,
+    "is_available" boolean DEFAULT true,
+    "availability_note" varchar(500),
+    "commission_rate" decimal(5,2) DEFAULT 0,
+    "tier" varchar(20) DEFAULT 'standard',
+    "payment_account_id" varchar(255),
+    "auto_accept_bookings" boolean DEFAULT false,
+    "booking_lead_time" bigint DEFAULT 60,
+    "max_advance_booking" bigint DEFAULT 90,
+    "simultaneous_bookings" bigint DEFAULT 1,
+    "location" jsonb,
+    "service_radius" bigint DEFAULT 0,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_artisan_tenant_status" ON "artisans" ("tenant_id","is_available");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_artisans_user_id" ON "artisans" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_artisans_deleted_at" ON "artisans" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_artisans_updated_at" ON "artisans" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_artisans_created_at" ON "artisans" ("created_at");
+
+CREATE TABLE "customers" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "user_id" uuid NOT NULL,
+    "tenant_id" uuid NOT NULL,
+    "preferred_artisans" uuid[],
+    "notes" text,
+    "loyalty_points" bigint DEFAULT 0,
+    "total_spent" decimal(10,2) DEFAULT 0,
+    "total_bookings" bigint DEFAULT 0,
+    "cancelled_bookings" bigint DEFAULT 0,
+    "completed_bookings" bigint DEFAULT 0,
+    "default_payment_method_id" varchar(255),
+    "primary_location" jsonb,
+    "email_notifications" boolean DEFAULT true,
+    "sms_notifications" boolean DEFAULT true,
+    "push_notifications" boolean DEFAULT true,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_customer_tenant" ON "customers" ("tenant_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_customers_user_id" ON "customers" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_customers_deleted_at" ON "customers" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_customers_updated_at" ON "customers" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_customers_created_at" ON "customers" ("created_at");
+
+CREATE TABLE "services" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "artisan_id" uuid,
+    "name" varchar(255) NOT NULL,
+    "description" text,
+    "category" varchar(50) NOT NULL,
+    "price" decimal(10,2) NOT NULL,
+    "currency" varchar(3) DEFAULT 'USD',
+    "deposit_amount" decimal(10,2) DEFAULT 0,
+    "tax_category" varchar(50) DEFAULT 'standard',
+    "duration_minutes" bigint NOT NULL,
+    "buffer_minutes" bigint DEFAULT 0,
+    "is_active" boolean DEFAULT true,
+    "max_bookings_day" bigint DEFAULT 0,
+    "image_url" varchar(500),
+    "requires_deposit" boolean DEFAULT false,
+    "tags" text[],
+    "intake_form" jsonb,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_services_artisan_id" ON "services" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_service_tenant_category" ON "services" ("tenant_id","category");
+CREATE INDEX IF NOT EXISTS "idx_services_deleted_at" ON "services" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_services_updated_at" ON "services" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_services_created_at" ON "services" ("created_at");
+
+CREATE TABLE "service_addons" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "name" varchar(255) NOT NULL,
+    "description" text,
+    "price" decimal(10,2) NOT NULL,
+    "duration_minutes" bigint DEFAULT 0,
+    "is_active" boolean DEFAULT true,
+    "pricing_type" varchar(20) NOT NULL DEFAULT 'fixed',
+    "max_quantity" bigint DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_service_addons_tenant_id" ON "service_addons" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_service_addons_deleted_at" ON "service_addons" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_service_addons_updated_at" ON "service_addons" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_service_addons_created_at" ON "service_addons" ("created_at");
+
+CREATE TABLE "availabilities" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "day_of_week" bigint,
+    "date" timestamptz,
+    "start_time" timestamptz NOT NULL,
+    "end_time" timestamptz NOT NULL,
+    "is_recurring" boolean DEFAULT false,
+    "recur_until" timestamptz,
+    "notes" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_availabilities_day_of_week" CHECK (day_of_week >= 0 AND day_of_week <= 6)
+);
+CREATE INDEX IF NOT EXISTS "idx_availabilities_artisan_id" ON "availabilities" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_availabilities_tenant_id" ON "availabilities" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_availabilities_deleted_at" ON "availabilities" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_availabilities_updated_at" ON "availabilities" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_availabilities_created_at" ON "availabilities" ("created_at");
+
+CREATE TABLE "bookings" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "customer_id" uuid NOT NULL,
+    "service_id" uuid NOT NULL,
+    "project_id" uuid,
+    "start_time" timestamptz NOT NULL,
+    "end_time" timestamptz NOT NULL,
+    "duration" bigint NOT NULL,
+    "status" varchar(50) NOT NULL DEFAULT 'pending',
+    "payment_status" varchar(50) NOT NULL DEFAULT 'pending',
+    "base_price" decimal(10,2) NOT NULL,
+    "addons_price" decimal(10,2) DEFAULT 0,
+    "total_price" decimal(10,2) NOT NULL,
+    "deposit_paid" decimal(10,2) DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "promo_code_id" uuid,
+    "promo_code" varchar(50),
+    "discount_amount" decimal(10,2) DEFAULT 0,
+    "tax_amount" decimal(10,2) DEFAULT 0,
+    "tax_lines" jsonb,
+    "notes" text,
+    "customer_notes" text,
+    "internal_notes" text,
+    "selected_addons" uuid[],
+    "tags" jsonb,
+    "addons" jsonb,
+    "intake_answers" jsonb,
+    "service_location" jsonb,
+    "cancelled_at" timestamptz,
+    "cancelled_by" text,
+    "cancellation_reason" text,
+    "completed_at" timestamptz,
+    "before_photo_urls" text[],
+    "after_photo_urls" text[],
+    "payment_intent_id" varchar(255),
+    "refund_id" varchar(255),
+    "payment_method_id" varchar(255),
+    "balance_due_hours" bigint DEFAULT 0,
+    "balance_due_at" timestamptz,
+    "balance_status" varchar(20),
+    "balance_attempts" bigint DEFAULT 0,
+    "balance_failure_reason" text,
+    "is_recurring" boolean DEFAULT false,
+    "recurrence_pattern" varchar(50),
+    "parent_booking_id" uuid,
+    "recurrence_end_date" timestamptz,
+    "recurrence_exceptions" jsonb,
+    "original_start_time" timestamptz,
+    "detached_from_series" boolean DEFAULT false,
+    "reminder_sent24h" boolean DEFAULT false,
+    "reminder_sent1h" boolean DEFAULT false,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_bookings_parent_booking_id" ON "bookings" ("parent_booking_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_balance_status" ON "bookings" ("balance_status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_balance_due_at" ON "bookings" ("balance_due_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_tags" ON "bookings" USING gin("tags");
+CREATE INDEX IF NOT EXISTS "idx_bookings_promo_code_id" ON "bookings" ("promo_code_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_payment_status" ON "bookings" ("payment_status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_project_id" ON "bookings" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_service_id" ON "bookings" ("service_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_customer_status" ON "bookings" ("customer_id","status");
+CREATE INDEX IF NOT EXISTS "idx_booking_artisan_status" ON "bookings" ("artisan_id","start_time","status");
+CREATE INDEX IF NOT EXISTS "idx_booking_tenant_artisan" ON "bookings" ("tenant_id","artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_tenant_date" ON "bookings" ("tenant_id","start_time");
+CREATE INDEX IF NOT EXISTS "idx_bookings_deleted_at" ON "bookings" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_bookings_updated_at" ON "bookings" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_bookings_created_at" ON "bookings" ("created_at");
+
+CREATE TABLE "cancellation_policies" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "service_id" uuid,
+    "name" varchar(255) NOT NULL,
+    "description" text,
+    "free_cancellation_hours" decimal(8,2) DEFAULT 24,
+    "refund_tiers" jsonb,
+    "cancellation_fee_fixed" decimal(10,2) DEFAULT 0,
+    "no_show_fee_percentage" decimal(5,2) DEFAULT 100,
+    "is_active" boolean DEFAULT true,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_cancellation_policies_is_active" ON "cancellation_policies" ("is_active");
+CREATE INDEX IF NOT EXISTS "idx_cancellation_policy_tenant_service" ON "cancellation_policies" ("tenant_id","service_id");
+CREATE INDEX IF NOT EXISTS "idx_cancellation_policies_deleted_at" ON "cancellation_policies" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_cancellation_policies_updated_at" ON "cancellation_policies" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_cancellation_policies_created_at" ON "cancellation_policies" ("created_at");
+
+CREATE TABLE "booking_imports" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "uploaded_by_id" uuid NOT NULL,
+    "file_name" varchar(255) NOT NULL,
+    "file_size" bigint NOT NULL,
+    "status" varchar(50) NOT NULL DEFAULT 'pending',
+    "dry_run" boolean DEFAULT false,
+    "timezone" varchar(64) DEFAULT 'UTC',
+    "started_at" timestamptz,
+    "completed_at" timestamptz,
+    "error_message" text,
+    "total_rows" bigint DEFAULT 0,
+    "valid_rows" bigint DEFAULT 0,
+    "imported_rows" bigint DEFAULT 0,
+    "failed_rows" bigint DEFAULT 0,
+    "row_errors" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_booking_imports_status" ON "booking_imports" ("status");
+CREATE INDEX IF NOT EXISTS "idx_booking_imports_uploaded_by_id" ON "booking_imports" ("uploaded_by_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_imports_tenant_id" ON "booking_imports" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_imports_deleted_at" ON "booking_imports" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_imports_updated_at" ON "booking_imports" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_imports_created_at" ON "booking_imports" ("created_at");
+
+CREATE TABLE "booking_exports" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "requested_by_id" uuid NOT NULL,
+    "format" varchar(10) NOT NULL DEFAULT 'csv',
+    "filters" jsonb,
+    "status" varchar(50) NOT NULL DEFAULT 'pending',
+    "async" boolean DEFAULT false,
+    "started_at" timestamptz,
+    "completed_at" timestamptz,
+    "error_message" text,
+    "row_count" bigint DEFAULT 0,
+    "file_key" varchar(512),
+    "file_size" bigint DEFAULT 0,
+    "expires_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_booking_exports_expires_at" ON "booking_exports" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_exports_status" ON "booking_exports" ("status");
+CREATE INDEX IF NOT EXISTS "idx_booking_exports_requested_by_id" ON "booking_exports" ("requested_by_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_exports_tenant_id" ON "booking_exports" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_exports_deleted_at" ON "booking_exports" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_exports_updated_at" ON "booking_exports" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_exports_created_at" ON "booking_exports" ("created_at");
+
+CREATE TABLE "saved_filters" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "resource" varchar(50) NOT NULL DEFAULT 'bookings',
+    "criteria" jsonb,
+    "date_range" varchar(20),
+    "time_zone" varchar(64),
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_saved_filter_owner" ON "saved_filters" ("tenant_id","user_id","resource");
+CREATE INDEX IF NOT EXISTS "idx_saved_filters_deleted_at" ON "saved_filters" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_saved_filters_updated_at" ON "saved_filters" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_saved_filters_created_at" ON "saved_filters" ("created_at");
+
+CREATE TABLE "booking_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "booking_id" uuid NOT NULL,
+    "actor_id" uuid,
+    "actor_email" varchar(255),
+    "actor_role" varchar(50),
+    "source" varchar(20) NOT NULL,
+    "event_type" varchar(50) NOT NULL,
+    "changes" jsonb,
+    "note" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_booking_events_event_type" ON "booking_events" ("event_type");
+CREATE INDEX IF NOT EXISTS "idx_booking_events_actor_id" ON "booking_events" ("actor_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_event_booking_created" ON "booking_events" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_events_tenant_id" ON "booking_events" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_events_deleted_at" ON "booking_events" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_events_updated_at" ON "booking_events" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_events_created_at" ON "booking_events" ("created_at");
+
+CREATE TABLE "booking_conversations" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "booking_id" uuid NOT NULL,
+    "customer_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "last_message_at" timestamptz,
+    "last_message_preview" varchar(255),
+    "message_count" bigint DEFAULT 0,
+    "customer_unread_count" bigint DEFAULT 0,
+    "artisan_unread_count" bigint DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_booking_conversations_artisan_id" ON "booking_conversations" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_conversations_customer_id" ON "booking_conversations" ("customer_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_booking_conversations_booking_id" ON "booking_conversations" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_conversations_tenant_id" ON "booking_conversations" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_conversations_deleted_at" ON "booking_conversations" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_conversations_updated_at" ON "booking_conversations" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_conversations_created_at" ON "booking_conversations" ("created_at");
+
+CREATE TABLE "projects" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "customer_id" uuid,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "status" varchar(32) NOT NULL DEFAULT 'planned',
+    "priority" varchar(16) NOT NULL DEFAULT 'medium',
+    "start_date" timestamptz,
+    "due_date" timestamptz,
+    "completed_at" timestamptz,
+    "budget_amount" decimal(12,2) DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "hourly_rate" decimal(10,2) DEFAULT 0,
+    "progress_percent" bigint DEFAULT 0,
+    "tasks_total" bigint DEFAULT 0,
+    "tasks_completed" bigint DEFAULT 0,
+    "tasks_overdue" bigint DEFAULT 0,
+    "active_blocked_tasks" bigint DEFAULT 0,
+    "estimated_hours" decimal(10,2) DEFAULT 0,
+    "actual_hours" decimal(10,2) DEFAULT 0,
+    "health_score" bigint,
+    "risk_level" varchar(16),
+    "health_checked_at" timestamptz,
+    "tags" text[],
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_projects_health_checked_at" ON "projects" ("health_checked_at");
+CREATE INDEX IF NOT EXISTS "idx_projects_risk_level" ON "projects" ("risk_level");
+CREATE INDEX IF NOT EXISTS "idx_projects_priority" ON "projects" ("priority");
+CREATE INDEX IF NOT EXISTS "idx_projects_status" ON "projects" ("status");
+CREATE INDEX IF NOT EXISTS "idx_projects_customer_id" ON "projects" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_project_tenant_artisan" ON "projects" ("tenant_id","artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_projects_deleted_at" ON "projects" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_projects_updated_at" ON "projects" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_projects_created_at" ON "projects" ("created_at");
+
+CREATE TABLE "project_milestones" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "project_id" uuid NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "status" varchar(32) NOT NULL DEFAULT 'pending',
+    "order_index" bigint DEFAULT 0,
+    "start_date" timestamptz,
+    "due_date" timestamptz,
+    "completed_at" timestamptz,
+    "payment_amount" decimal(12,2) DEFAULT 0,
+    "payment_percentage" decimal(5,2) DEFAULT 0,
+    "is_payment_milestone" boolean DEFAULT false,
+    "payment_received" boolean DEFAULT false,
+    "payment_received_at" timestamptz,
+    "deliverables" text[],
+    "attachment_urls" text[],
+    "completion_proof" text[],
+    "requires_approval" boolean DEFAULT false,
+    "approved_by_customer" boolean DEFAULT false,
+    "approved_at" timestamptz,
+    "rejection_reason" text,
+    "artisan_notes" text,
+    "customer_notes" text,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_milestones_project_id" ON "project_milestones" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_project_milestones_tenant_id" ON "project_milestones" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_project_milestones_deleted_at" ON "project_milestones" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_project_milestones_updated_at" ON "project_milestones" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_project_milestones_created_at" ON "project_milestones" ("created_at");
+
+CREATE TABLE "project_tasks" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "project_id" uuid NOT NULL,
+    "milestone_id" uuid,
+    "assigned_to_id" uuid,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "status" varchar(32) NOT NULL DEFAULT 'todo',
+    "priority" varchar(16) NOT NULL DEFAULT 'medium',
+    "order_index" bigint DEFAULT 0,
+    "start_date" timestamptz,
+    "due_date" timestamptz,
+    "completed_at" timestamptz,
+    "estimated_hours" decimal(8,2) DEFAULT 0,
+    "tracked_hours" decimal(8,2) DEFAULT 0,
+    "estimated_cost" decimal(10,2) DEFAULT 0,
+    "actual_cost" decimal(10,2) DEFAULT 0,
+    "depends_on" uuid[],
+    "blocked_by" uuid[],
+    "block_reason" text,
+    "attachment_urls" text[],
+    "checklist" jsonb,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_tasks_priority" ON "project_tasks" ("priority");
+CREATE INDEX IF NOT EXISTS "idx_task_assigned_status" ON "project_tasks" ("assigned_to_id","status");
+CREATE INDEX IF NOT EXISTS "idx_project_tasks_milestone_id" ON "project_tasks" ("milestone_id");
+CREATE INDEX IF NOT EXISTS "idx_project_tasks_project_id" ON "project_tasks" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_project_tasks_tenant_id" ON "project_tasks" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_project_tasks_deleted_at" ON "project_tasks" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_project_tasks_updated_at" ON "project_tasks" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_project_tasks_created_at" ON "project_tasks" ("created_at");
+
+CREATE TABLE "task_dependencies" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "project_id" uuid NOT NULL,
+    "task_id" uuid NOT NULL,
+    "depends_on_task_id" uuid NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_task_dependencies_depends_on_task_id" ON "task_dependencies" ("depends_on_task_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_task_dependency" ON "task_dependencies" ("task_id","depends_on_task_id");
+CREATE INDEX IF NOT EXISTS "idx_task_dependencies_project_id" ON "task_dependencies" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_task_dependencies_tenant_id" ON "task_dependencies" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_task_dependencies_deleted_at" ON "task_dependencies" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_task_dependencies_updated_at" ON "task_dependencies" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_task_dependencies_created_at" ON "task_dependencies" ("created_at");
+
+CREATE TABLE "project_status_changes" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "project_id" uuid NOT NULL,
+    "from_status" varchar(32) NOT NULL,
+    "to_status" varchar(32) NOT NULL,
+    "reason" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_status_changes_project_id" ON "project_status_changes" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_project_status_changes_tenant_id" ON "project_status_changes" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_project_status_changes_deleted_at" ON "project_status_changes" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_project_status_changes_updated_at" ON "project_status_changes" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_project_status_changes_created_at" ON "project_status_changes" ("created_at");
+
+CREATE TABLE "project_health_snapshots" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "project_id" uuid NOT NULL,
+    "health_score" bigint,
+    "risk_level" varchar(16) NOT NULL,
+    "is_on_track" boolean,
+    "is_overdue" boolean,
+    "is_over_budget" boolean,
+    "blocked_tasks_count" bigint,
+    "overdue_tasks_count" bigint,
+    "schedule_days_late" bigint,
+    "progress_percent" bigint,
+    "recorded_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_health_snapshots_risk_level" ON "project_health_snapshots" ("risk_level");
+CREATE INDEX IF NOT EXISTS "idx_project_health_project_recorded" ON "project_health_snapshots" ("project_id","recorded_at");
+CREATE INDEX IF NOT EXISTS "idx_project_health_snapshots_tenant_id" ON "project_health_snapshots" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_project_health_snapshots_deleted_at" ON "project_health_snapshots" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_project_health_snapshots_updated_at" ON "project_health_snapshots" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_project_health_snapshots_created_at" ON "project_health_snapshots" ("created_at");
+
+CREATE TABLE "project_updates" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "project_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "type" varchar(32) NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "visible_to_customer" boolean DEFAULT true,
+    "attachment_urls" text[],
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_updates_user_id" ON "project_updates" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_project_updates_project_id" ON "project_updates" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_project_updates_tenant_id" ON "project_updates" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_project_updates_deleted_at" ON "project_updates" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_project_updates_updated_at" ON "project_updates" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_project_updates_created_at" ON "project_updates" ("created_at");
+
+CREATE TABLE "time_entries" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "project_id" uuid NOT NULL,
+    "task_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "description" text,
+    "source" varchar(16) NOT NULL DEFAULT 'manual',
+    "started_at" timestamptz NOT NULL,
+    "ended_at" timestamptz,
+    "duration_minutes" bigint DEFAULT 0,
+    "billable" boolean NOT NULL DEFAULT false,
+    "hourly_rate" decimal(10,2) DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "invoice_id" uuid,
+    "invoiced_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_time_entries_invoice_id" ON "time_entries" ("invoice_id");
+CREATE INDEX IF NOT EXISTS "idx_time_entries_started_at" ON "time_entries" ("started_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_time_entry_user_running" ON "time_entries" ("user_id") WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_time_entry_user_ended" ON "time_entries" ("user_id","ended_at");
+CREATE INDEX IF NOT EXISTS "idx_time_entries_task_id" ON "time_entries" ("task_id");
+CREATE INDEX IF NOT EXISTS "idx_time_entry_tenant_project" ON "time_entries" ("tenant_id","project_id");
+CREATE INDEX IF NOT EXISTS "idx_time_entries_deleted_at" ON "time_entries" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_time_entries_updated_at" ON "time_entries" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_time_entries_created_at" ON "time_entries" ("created_at");
+
+CREATE TABLE "quotes" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "customer_id" uuid NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "status" varchar(16) NOT NULL DEFAULT 'draft',
+    "revision" bigint NOT NULL DEFAULT 1,
+    "line_items" jsonb,
+    "subtotal_amount" decimal(12,2) DEFAULT 0,
+    "discount_amount" decimal(12,2) DEFAULT 0,
+    "tax_amount" decimal(12,2) DEFAULT 0,
+    "total_amount" decimal(12,2) DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "deposit_percent" decimal(5,2) DEFAULT 0,
+    "valid_until" timestamptz NOT NULL,
+    "estimated_start_date" timestamptz,
+    "estimated_due_date" timestamptz,
+    "notes" text,
+    "terms_conditions" text,
+    "sent_at" timestamptz,
+    "responded_at" timestamptz,
+    "accepted_revision" bigint DEFAULT 0,
+    "decline_reason" text,
+    "project_id" uuid,
+    "deposit_invoice_id" uuid,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_quotes_project_id" ON "quotes" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_quotes_valid_until" ON "quotes" ("valid_until");
+CREATE INDEX IF NOT EXISTS "idx_quotes_customer_id" ON "quotes" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_quotes_artisan_id" ON "quotes" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_quote_tenant_status" ON "quotes" ("tenant_id","status");
+CREATE INDEX IF NOT EXISTS "idx_quotes_deleted_at" ON "quotes" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_quotes_updated_at" ON "quotes" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_quotes_created_at" ON "quotes" ("created_at");
+
+CREATE TABLE "quote_revisions" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "quote_id" uuid NOT NULL,
+    "revision" bigint NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "line_items" jsonb,
+    "subtotal_amount" decimal(12,2) DEFAULT 0,
+    "discount_amount" decimal(12,2) DEFAULT 0,
+    "tax_amount" decimal(12,2) DEFAULT 0,
+    "total_amount" decimal(12,2) DEFAULT 0,
+    "currency" varchar(3),
+    "deposit_percent" decimal(5,2) DEFAULT 0,
+    "valid_until" timestamptz NOT NULL,
+    "notes" text,
+    "terms_conditions" text,
+    "sent_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_quote_revision" ON "quote_revisions" ("quote_id","revision");
+CREATE INDEX IF NOT EXISTS "idx_quote_revisions_deleted_at" ON "quote_revisions" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_quote_revisions_updated_at" ON "quote_revisions" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_quote_revisions_created_at" ON "quote_revisions" ("created_at");
+
+CREATE TABLE "project_templates" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "name" varchar(255) NOT NULL,
+    "description" text,
+    "category" varchar(100),
+    "is_active" boolean DEFAULT true,
+    "default_duration_days" bigint DEFAULT 0,
+    "default_budget" decimal(12,2) DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "hourly_rate" decimal(10,2) DEFAULT 0,
+    "priority" varchar(16) NOT NULL DEFAULT 'medium',
+    "tags" text[],
+    "milestones" jsonb,
+    "tasks" jsonb,
+    "usage_count" bigint DEFAULT 0,
+    "last_used_at" timestamptz,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_templates_is_active" ON "project_templates" ("is_active");
+CREATE INDEX IF NOT EXISTS "idx_project_templates_category" ON "project_templates" ("category");
+CREATE INDEX IF NOT EXISTS "idx_project_template_tenant_name" ON "project_templates" ("tenant_id","name");
+CREATE INDEX IF NOT EXISTS "idx_project_templates_deleted_at" ON "project_templates" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_project_templates_updated_at" ON "project_templates" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_project_templates_created_at" ON "project_templates" ("created_at");
+
+CREATE TABLE "change_orders" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "project_id" uuid NOT NULL,
+    "number" bigint NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "reason" text,
+    "status" varchar(16) NOT NULL DEFAULT 'draft',
+    "price_delta" decimal(12,2) DEFAULT 0,
+    "schedule_delta_days" bigint DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "requested_by_id" uuid,
+    "submitted_at" timestamptz,
+    "responded_by_id" uuid,
+    "responded_at" timestamptz,
+    "rejection_reason" text,
+    "budget_before" decimal(12,2) DEFAULT 0,
+    "budget_after" decimal(12,2) DEFAULT 0,
+    "due_date_before" timestamptz,
+    "due_date_after" timestamptz,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_change_orders_status" ON "change_orders" ("status");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_change_order_project_number" ON "change_orders" ("project_id","number");
+CREATE INDEX IF NOT EXISTS "idx_change_orders_tenant_id" ON "change_orders" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_change_orders_deleted_at" ON "change_orders" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_change_orders_updated_at" ON "change_orders" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_change_orders_created_at" ON "change_orders" ("created_at");
+
+CREATE TABLE "change_order_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "change_order_id" uuid NOT NULL,
+    "actor_id" uuid,
+    "action" varchar(16) NOT NULL,
+    "from_status" varchar(16),
+    "to_status" varchar(16) NOT NULL,
+    "note" text,
+    "price_delta" decimal(12,2) DEFAULT 0,
+    "schedule_delta_days" bigint DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_change_order_events_actor_id" ON "change_order_events" ("actor_id");
+CREATE INDEX IF NOT EXISTS "idx_change_order_events_change_order_id" ON "change_order_events" ("change_order_id");
+CREATE INDEX IF NOT EXISTS "idx_change_order_events_tenant_id" ON "change_order_events" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_change_order_events_deleted_at" ON "change_order_events" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_change_order_events_updated_at" ON "change_order_events" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_change_order_events_created_at" ON "change_order_events" ("created_at");
+
+CREATE TABLE "payments" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "booking_id" uuid NOT NULL,
+    "customer_id" uuid NOT NULL,
+    "artisan_id" uuid,
+    "amount" decimal(10,2) NOT NULL,
+    "currency" varchar(3) DEFAULT 'USD',
+    "method" varchar(50) NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "status" varchar(50) NOT NULL,
+    "provider_payment_id" varchar(255),
+    "provider_name" varchar(50),
+    "promo_code_id" uuid,
+    "discount_amount" decimal(10,2) DEFAULT 0,
+    "tax_amount" decimal(10,2) DEFAULT 0,
+    "artisan_amount" decimal(10,2) DEFAULT 0,
+    "platform_amount" decimal(10,2) DEFAULT 0,
+    "commission_rate" decimal(5,2) DEFAULT 0,
+    "commission_rule_id" uuid,
+    "commission_rule_name" varchar(100),
+    "payout_id" uuid,
+    "escrow_status" varchar(20),
+    "escrow_hold_days" bigint DEFAULT 0,
+    "escrow_released_at" timestamptz,
+    "escrow_note" text,
+    "processed_at" timestamptz,
+    "failure_reason" text,
+    "refunded_amount" decimal(10,2) DEFAULT 0,
+    "refunded_at" timestamptz,
+    "refund_reason" text,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_payments_escrow_status" ON "payments" ("escrow_status");
+CREATE INDEX IF NOT EXISTS "idx_payments_payout_id" ON "payments" ("payout_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_commission_rule_id" ON "payments" ("commission_rule_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_promo_code_id" ON "payments" ("promo_code_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_provider_payment_id" ON "payments" ("provider_payment_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_artisan_id" ON "payments" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_customer_id" ON "payments" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_booking_id" ON "payments" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_tenant_id" ON "payments" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_deleted_at" ON "payments" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_updated_at" ON "payments" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_created_at" ON "payments" ("created_at");
+
+CREATE TABLE "payment_webhook_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "provider" varchar(50) NOT NULL,
+    "event_id" varchar(255) NOT NULL,
+    "event_type" varchar(100),
+    "tenant_id" uuid,
+    "payment_id" uuid,
+    "outcome" varchar(20) NOT NULL,
+    "received_at" timestamptz NOT NULL,
+    "occurred_at" timestamptz,
+    "provider_ref" varchar(255),
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_payment_webhook_events_payment_id" ON "payment_webhook_events" ("payment_id");
+CREATE INDEX IF NOT EXISTS "idx_payment_webhook_events_tenant_id" ON "payment_webhook_events" ("tenant_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_payment_webhook_event" ON "payment_webhook_events" ("provider","event_id");
+CREATE INDEX IF NOT EXISTS "idx_payment_webhook_events_deleted_at" ON "payment_webhook_events" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_payment_webhook_events_updated_at" ON "payment_webhook_events" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_payment_webhook_events_created_at" ON "payment_webhook_events" ("created_at");
+
+CREATE TABLE "payout_batches" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "method" varchar(30) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "period_end" timestamptz NOT NULL,
+    "payout_count" bigint DEFAULT 0,
+    "total_amount" decimal(12,2) DEFAULT 0,
+    "failed_count" bigint DEFAULT 0,
+    "created_by" uuid,
+    "completed_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_payout_batches_status" ON "payout_batches" ("status");
+CREATE INDEX IF NOT EXISTS "idx_payout_batches_tenant_id" ON "payout_batches" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_payout_batches_deleted_at" ON "payout_batches" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_payout_batches_updated_at" ON "payout_batches" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_payout_batches_created_at" ON "payout_batches" ("created_at");
+
+CREATE TABLE "payouts" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "batch_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "amount" decimal(12,2) NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "payment_count" bigint DEFAULT 0,
+    "method" varchar(30) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "destination" varchar(255),
+    "provider_transfer_id" varchar(255),
+    "failure_reason" text,
+    "paid_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_payouts_status" ON "payouts" ("status");
+CREATE INDEX IF NOT EXISTS "idx_payouts_artisan_id" ON "payouts" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_payouts_batch_id" ON "payouts" ("batch_id");
+CREATE INDEX IF NOT EXISTS "idx_payouts_tenant_id" ON "payouts" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_payouts_deleted_at" ON "payouts" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_payouts_updated_at" ON "payouts" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_payouts_created_at" ON "payouts" ("created_at");
+
+CREATE TABLE "reconciliation_reports" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "date" date NOT NULL,
+    "provider" varchar(50) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "error" text,
+    "run_at" timestamptz NOT NULL,
+    "provider_transactions" bigint DEFAULT 0,
+    "local_payments" bigint DEFAULT 0,
+    "matched_count" bigint DEFAULT 0,
+    "discrepancy_count" bigint DEFAULT 0,
+    "discrepancies" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_reconciliation_reports_status" ON "reconciliation_reports" ("status");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_reconciliation_date_provider" ON "reconciliation_reports" ("date","provider");
+CREATE INDEX IF NOT EXISTS "idx_reconciliation_reports_deleted_at" ON "reconciliation_reports" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_reconciliation_reports_updated_at" ON "reconciliation_reports" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_reconciliation_reports_created_at" ON "reconciliation_reports" ("created_at");
+
+CREATE TABLE "financial_statements" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "kind" varchar(20) NOT NULL,
+    "subject_id" uuid NOT NULL,
+    "period_start" date NOT NULL,
+    "period_end" date NOT NULL,
+    "totals" jsonb,
+    "generated_at" timestamptz NOT NULL,
+    "csv_key" varchar(512),
+    "csv_size" bigint DEFAULT 0,
+    "pdf_key" varchar(512),
+    "pdf_size" bigint DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_financial_statements_subject_id" ON "financial_statements" ("subject_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_statement_subject_period" ON "financial_statements" ("tenant_id","kind","subject_id","period_start");
+CREATE INDEX IF NOT EXISTS "idx_financial_statements_deleted_at" ON "financial_statements" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_financial_statements_updated_at" ON "financial_statements" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_financial_statements_created_at" ON "financial_statements" ("created_at");
+
+CREATE TABLE "invoices" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "invoice_number" varchar(50) NOT NULL,
+    "booking_id" uuid,
+    "customer_id" uuid NOT NULL,
+    "issue_date" timestamptz NOT NULL,
+    "due_date" timestamptz NOT NULL,
+    "paid_at" timestamptz,
+    "subtotal_amount" decimal(10,2) NOT NULL,
+    "tax_amount" decimal(10,2) DEFAULT 0,
+    "discount_amount" decimal(10,2) DEFAULT 0,
+    "total_amount" decimal(10,2) NOT NULL,
+    "paid_amount" decimal(10,2) DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "status" varchar(50) NOT NULL DEFAULT 'draft',
+    "line_items" jsonb,
+    "tax_lines" jsonb,
+    "notes" text,
+    "terms_conditions" text,
+    "pdf_file_url" varchar(500),
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_invoices_customer_id" ON "invoices" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_invoices_booking_id" ON "invoices" ("booking_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_invoice_tenant_number" ON "invoices" ("tenant_id","invoice_number");
+CREATE INDEX IF NOT EXISTS "idx_invoices_tenant_id" ON "invoices" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_invoices_deleted_at" ON "invoices" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_invoices_updated_at" ON "invoices" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_invoices_created_at" ON "invoices" ("created_at");
+
+CREATE TABLE "invoice_sequences" (
+    "tenant_id" uuid,
+    "last_number" bigint NOT NULL DEFAULT 0,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("tenant_id")
+);
+
+CREATE TABLE "tax_rules" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "category" varchar(50),
+    "country" varchar(2),
+    "region" varchar(100),
+    "rate" decimal(6,3) NOT NULL,
+    "is_active" boolean DEFAULT true,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_tax_rules_is_active" ON "tax_rules" ("is_active");
+CREATE INDEX IF NOT EXISTS "idx_tax_rules_tenant_id" ON "tax_rules" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_tax_rules_deleted_at" ON "tax_rules" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_tax_rules_updated_at" ON "tax_rules" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_tax_rules_created_at" ON "tax_rules" ("created_at");
+
+CREATE TABLE "commission_rules" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "service_category" varchar(50),
+    "artisan_tier" varchar(20),
+    "min_monthly_volume" decimal(12,2) DEFAULT 0,
+    "starts_at" timestamptz,
+    "ends_at" timestamptz,
+    "rate" decimal(5,2) NOT NULL,
+    "is_active" boolean DEFAULT true,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_commission_rules_is_active" ON "commission_rules" ("is_active");
+CREATE INDEX IF NOT EXISTS "idx_commission_rules_tenant_id" ON "commission_rules" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_commission_rules_deleted_at" ON "commission_rules" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_commission_rules_updated_at" ON "commission_rules" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_commission_rules_created_at" ON "commission_rules" ("created_at");
+
+CREATE TABLE "promo_codes" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid,
+    "code" varchar(50) NOT NULL,
+    "description" text,
+    "type" varchar(50) NOT NULL,
+    "value" decimal(10,2) NOT NULL,
+    "max_discount" decimal(10,2),
+    "min_order_amount" decimal(10,2),
+    "starts_at" timestamptz NOT NULL,
+    "expires_at" timestamptz,
+    "max_uses" bigint,
+    "used_count" bigint DEFAULT 0,
+    "max_uses_per_user" bigint,
+    "applicable_services" uuid[],
+    "applicable_artisans" uuid[],
+    "is_active" boolean DEFAULT true,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_promo_codes_code" ON "promo_codes" ("code");
+CREATE INDEX IF NOT EXISTS "idx_promo_codes_tenant_id" ON "promo_codes" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_promo_codes_deleted_at" ON "promo_codes" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_promo_codes_updated_at" ON "promo_codes" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_promo_codes_created_at" ON "promo_codes" ("created_at");
+
+CREATE TABLE "promo_code_redemptions" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "promo_code_id" uuid NOT NULL,
+    "tenant_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "booking_id" uuid NOT NULL,
+    "order_amount" decimal(10,2) NOT NULL,
+    "discount_amount" decimal(10,2) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_promo_code_redemptions_booking_id" ON "promo_code_redemptions" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_promo_code_redemptions_user_id" ON "promo_code_redemptions" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_promo_code_redemptions_tenant_id" ON "promo_code_redemptions" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_promo_code_redemptions_promo_code_id" ON "promo_code_redemptions" ("promo_code_id");
+CREATE INDEX IF NOT EXISTS "idx_promo_code_redemptions_deleted_at" ON "promo_code_redemptions" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_promo_code_redemptions_updated_at" ON "promo_code_redemptions" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_promo_code_redemptions_created_at" ON "promo_code_redemptions" ("created_at");
+
+CREATE TABLE "plans" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "code" varchar(50) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "description" text,
+    "monthly_price" decimal(10,2) NOT NULL DEFAULT 0,
+    "yearly_price" decimal(10,2) NOT NULL DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "stripe_monthly_price_id" varchar(255),
+    "stripe_yearly_price_id" varchar(255),
+    "max_customers" bigint NOT NULL DEFAULT 0,
+    "max_projects" bigint NOT NULL DEFAULT 0,
+    "max_storage_gb" bigint NOT NULL DEFAULT 0,
+    "max_artisan_seats" bigint NOT NULL DEFAULT 1,
+    "max_services_listed" bigint NOT NULL DEFAULT 0,
+    "max_bookings_per_month" bigint NOT NULL DEFAULT 0,
+    "features" jsonb,
+    "trial_days" bigint DEFAULT 0,
+    "grace_period_days" bigint DEFAULT 7,
+    "is_active" boolean DEFAULT true,
+    "sort_order" bigint DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_plans_is_active" ON "plans" ("is_active");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_plans_code" ON "plans" ("code");
+CREATE INDEX IF NOT EXISTS "idx_plans_deleted_at" ON "plans" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_plans_updated_at" ON "plans" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_plans_created_at" ON "plans" ("created_at");
+
+CREATE TABLE "subscriptions" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "plan" varchar(50) NOT NULL DEFAULT 'free',
+    "status" varchar(50) NOT NULL DEFAULT 'trialing',
+    "billing_interval" varchar(20) NOT NULL DEFAULT 'monthly',
+    "amount" decimal(10,2) NOT NULL DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "discount_percent" decimal(5,2) DEFAULT 0,
+    "trial_ends_at" timestamptz,
+    "current_period_start" timestamptz NOT NULL,
+    "current_period_end" timestamptz NOT NULL,
+    "canceled_at" timestamptz,
+    "cancel_at_period_end" boolean DEFAULT false,
+    "grace_ends_at" timestamptz,
+    "stripe_subscription_id" varchar(255),
+    "stripe_customer_id" varchar(255),
+    "payment_method_id" varchar(255),
+    "max_customers" bigint NOT NULL DEFAULT 10,
+    "max_projects" bigint NOT NULL DEFAULT 5,
+    "max_storage_gb" bigint NOT NULL DEFAULT 1,
+    "max_team_members" bigint NOT NULL DEFAULT 1,
+    "max_services_listed" bigint NOT NULL DEFAULT 5,
+    "max_bookings_per_month" bigint NOT NULL DEFAULT 50,
+    "current_storage_gb" bigint DEFAULT 0,
+    "current_customers" bigint DEFAULT 0,
+    "current_projects" bigint DEFAULT 0,
+    "features" jsonb,
+    "next_billing_date" timestamptz,
+    "last_payment_date" timestamptz,
+    "last_payment_amount" decimal(10,2) DEFAULT 0,
+    "failed_payments" bigint DEFAULT 0,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_subscriptions_stripe_subscription_id" ON "subscriptions" ("stripe_subscription_id");
+CREATE INDEX IF NOT EXISTS "idx_subscriptions_grace_ends_at" ON "subscriptions" ("grace_ends_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_subscriptions_tenant_id" ON "subscriptions" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_subscriptions_deleted_at" ON "subscriptions" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_subscriptions_updated_at" ON "subscriptions" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_subscriptions_created_at" ON "subscriptions" ("created_at");
+
+CREATE TABLE "messages" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "sender_id" uuid NOT NULL,
+    "receiver_id" uuid NOT NULL,
+    "booking_id" uuid,
+    "type" varchar(50) NOT NULL DEFAULT 'text',
+    "content" text NOT NULL,
+    "file_url" varchar(500),
+    "status" varchar(50) NOT NULL DEFAULT 'sent',
+    "read_at" timestamptz,
+    "parent_message_id" uuid,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_messages_parent_message_id" ON "messages" ("parent_message_id");
+CREATE INDEX IF NOT EXISTS "idx_messages_booking_id" ON "messages" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_messages_receiver_id" ON "messages" ("receiver_id");
+CREATE INDEX IF NOT EXISTS "idx_messages_sender_id" ON "messages" ("sender_id");
+CREATE INDEX IF NOT EXISTS "idx_messages_tenant_id" ON "messages" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_messages_deleted_at" ON "messages" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_messages_updated_at" ON "messages" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_messages_created_at" ON "messages" ("created_at");
+
+CREATE TABLE "notifications" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "message" text NOT NULL,
+    "channels" text[] NOT NULL,
+    "sent_via_in_app" boolean DEFAULT false,
+    "sent_via_email" boolean DEFAULT false,
+    "sent_via_sms" boolean DEFAULT false,
+    "sent_via_push" boolean DEFAULT false,
+    "is_read" boolean DEFAULT false,
+    "read_at" timestamptz,
+    "action_url" varchar(500),
+    "action_text" varchar(100),
+    "related_entity_type" varchar(50),
+    "related_entity_id" uuid,
+    "priority" bigint DEFAULT 5,
+    "expires_at" timestamptz,
+    "metadata" jsonb,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_notifications_priority" CHECK (priority >= 1 AND priority <= 10)
+);
+CREATE INDEX IF NOT EXISTS "idx_notifications_is_read" ON "notifications" ("is_read");
+CREATE INDEX IF NOT EXISTS "idx_notifications_type" ON "notifications" ("type");
+CREATE INDEX IF NOT EXISTS "idx_notifications_user_id" ON "notifications" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_notifications_tenant_id" ON "notifications" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_notifications_deleted_at" ON "notifications" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_notifications_updated_at" ON "notifications" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_notifications_created_at" ON "notifications" ("created_at");
+
+CREATE TABLE "email_templates" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "name" varchar(255) NOT NULL,
+    "subject" varchar(255) NOT NULL,
+    "description" text,
+    "html_body" text NOT NULL,
+    "text_body" text,
+    "variables" text[],
+    "category" varchar(100),
+    "is_active" boolean DEFAULT true,
+    "language" varchar(10) DEFAULT 'en',
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_email_templates_category" ON "email_templates" ("category");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_email_templates_name" ON "email_templates" ("name");
+CREATE INDEX IF NOT EXISTS "idx_email_templates_deleted_at" ON "email_templates" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_email_templates_updated_at" ON "email_templates" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_email_templates_created_at" ON "email_templates" ("created_at");
+
+CREATE TABLE "file_uploads" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "uploaded_by_id" uuid NOT NULL,
+    "file_name" varchar(255) NOT NULL,
+    "file_type" varchar(50) NOT NULL,
+    "mime_type" varchar(100),
+    "file_size" bigint NOT NULL,
+    "file_path" varchar(500) NOT NULL,
+    "file_url" varchar(500) NOT NULL,
+    "thumbnail_url" varchar(500),
+    "related_entity_type" varchar(50),
+    "related_entity_id" uuid,
+    "storage_provider" varchar(50) DEFAULT 's3',
+    "storage_bucket" varchar(255),
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_file_uploads_related_entity_id" ON "file_uploads" ("related_entity_id");
+CREATE INDEX IF NOT EXISTS "idx_file_uploads_uploaded_by_id" ON "file_uploads" ("uploaded_by_id");
+CREATE INDEX IF NOT EXISTS "idx_file_uploads_tenant_id" ON "file_uploads" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_file_uploads_deleted_at" ON "file_uploads" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_file_uploads_updated_at" ON "file_uploads" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_file_uploads_created_at" ON "file_uploads" ("created_at");
+
+CREATE TABLE "reviews" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "booking_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "customer_id" uuid NOT NULL,
+    "service_id" uuid NOT NULL,
+    "rating" bigint NOT NULL,
+    "title" varchar(255),
+    "comment" text,
+    "quality_rating" bigint,
+    "professionalism_rating" bigint,
+    "value_rating" bigint,
+    "timeliness_rating" bigint,
+    "photo_urls" text[],
+    "response_text" text,
+    "responsed_at" timestamptz,
+    "responsed_by" text,
+    "is_published" boolean DEFAULT true,
+    "is_flagged" boolean DEFAULT false,
+    "flagged_reason" text,
+    "moderated_at" timestamptz,
+    "moderated_by" text,
+    "helpful_count" bigint DEFAULT 0,
+    "not_helpful_count" bigint DEFAULT 0,
+    "metadata" jsonb,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_reviews_value_rating" CHECK (value_rating >= 1 AND value_rating <= 5),
+    CONSTRAINT "chk_reviews_rating" CHECK (rating >= 1 AND rating <= 5),
+    CONSTRAINT "chk_reviews_quality_rating" CHECK (quality_rating >= 1 AND quality_rating <= 5),
+    CONSTRAINT "chk_reviews_timeliness_rating" CHECK (timeliness_rating >= 1 AND timeliness_rating <= 5),
+    CONSTRAINT "chk_reviews_professionalism_rating" CHECK (professionalism_rating >= 1 AND professionalism_rating <= 5)
+);
+CREATE INDEX IF NOT EXISTS "idx_reviews_service_id" ON "reviews" ("service_id");
+CREATE INDEX IF NOT EXISTS "idx_reviews_customer_id" ON "reviews" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_review_artisan_rating" ON "reviews" ("artisan_id","rating");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_reviews_booking_id" ON "reviews" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_review_tenant_artisan" ON "reviews" ("tenant_id","artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_reviews_deleted_at" ON "reviews" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_reviews_updated_at" ON "reviews" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_reviews_created_at" ON "reviews" ("created_at");
+
+CREATE TABLE "analytics_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid,
+    "user_id" uuid,
+    "session_id" varchar(255),
+    "anonymous_id" varchar(255),
+    "event_type" varchar(100) NOT NULL,
+    "event_name" varchar(255) NOT NULL,
+    "properties" jsonb,
+    "page_url" varchar(500),
+    "page_title" varchar(255),
+    "referrer" varchar(500),
+    "user_agent" varchar(500),
+    "ip_address" varchar(45),
+    "country" varchar(2),
+    "city" varchar(100),
+    "device_type" varchar(50),
+    "browser" varchar(50),
+    "os" varchar(50),
+    "event_timestamp" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_analytics_events_event_timestamp" ON "analytics_events" ("event_timestamp");
+CREATE INDEX IF NOT EXISTS "idx_analytics_events_event_type" ON "analytics_events" ("event_type");
+CREATE INDEX IF NOT EXISTS "idx_analytics_events_anonymous_id" ON "analytics_events" ("anonymous_id");
+CREATE INDEX IF NOT EXISTS "idx_analytics_events_session_id" ON "analytics_events" ("session_id");
+CREATE INDEX IF NOT EXISTS "idx_analytics_events_user_id" ON "analytics_events" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_analytics_events_tenant_id" ON "analytics_events" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_analytics_events_deleted_at" ON "analytics_events" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_analytics_events_updated_at" ON "analytics_events" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_analytics_events_created_at" ON "analytics_events" ("created_at");
+
+CREATE TABLE "reports" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "name" varchar(255) NOT NULL,
+    "description" text,
+    "status" varchar(50) NOT NULL DEFAULT 'pending',
+    "start_date" timestamptz NOT NULL,
+    "end_date" timestamptz NOT NULL,
+    "filters" jsonb,
+    "file_url" varchar(500),
+    "file_format" varchar(10) DEFAULT 'pdf',
+    "generated_at" timestamptz,
+    "error_message" text,
+    "requested_by_id" uuid NOT NULL,
+    "is_scheduled" boolean DEFAULT false,
+    "schedule_cron" varchar(100),
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_reports_type" ON "reports" ("type");
+CREATE INDEX IF NOT EXISTS "idx_reports_tenant_id" ON "reports" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_reports_deleted_at" ON "reports" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_reports_updated_at" ON "reports" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_reports_created_at" ON "reports" ("created_at");
+
+CREATE TABLE "system_settings" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "key" varchar(255) NOT NULL,
+    "value" text NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "description" text,
+    "category" varchar(100),
+    "group" varchar(100),
+    "validation_rules" jsonb,
+    "is_public" boolean DEFAULT false,
+    "is_encrypted" boolean DEFAULT false,
+    "last_modified_by" uuid,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_system_settings_category" ON "system_settings" ("category");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_system_settings_key" ON "system_settings" ("key");
+CREATE INDEX IF NOT EXISTS "idx_system_settings_deleted_at" ON "system_settings" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_system_settings_updated_at" ON "system_settings" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_system_settings_created_at" ON "system_settings" ("created_at");
+
+CREATE TABLE "tenant_invitations" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "email" varchar(255) NOT NULL,
+    "role" varchar(50) NOT NULL,
+    "token" varchar(255) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "accepted_at" timestamptz,
+    "invited_by" uuid NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tenant_invitations_token" ON "tenant_invitations" ("token");
+CREATE INDEX IF NOT EXISTS "idx_tenant_invitations_email" ON "tenant_invitations" ("email");
+CREATE INDEX IF NOT EXISTS "idx_tenant_invitations_tenant_id" ON "tenant_invitations" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_tenant_invitations_deleted_at" ON "tenant_invitations" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_invitations_updated_at" ON "tenant_invitations" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_invitations_created_at" ON "tenant_invitations" ("created_at");
+
+CREATE TABLE "tenant_usage_trackings" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "date" timestamptz NOT NULL,
+    "api_calls_count" bigint DEFAULT 0,
+    "api_calls_limit" bigint DEFAULT 10000,
+    "storage_used_gb" bigint DEFAULT 0,
+    "bandwidth_used_gb" bigint DEFAULT 0,
+    "bookings_created" bigint DEFAULT 0,
+    "projects_created" bigint DEFAULT 0,
+    "sms_sent" bigint DEFAULT 0,
+    "emails_sent" bigint DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_tenant_usage_trackings_date" ON "tenant_usage_trackings" ("date");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tenant_usage_date" ON "tenant_usage_trackings" ("tenant_id","date");
+CREATE INDEX IF NOT EXISTS "idx_tenant_usage_trackings_deleted_at" ON "tenant_usage_trackings" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_usage_trackings_updated_at" ON "tenant_usage_trackings" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_usage_trackings_created_at" ON "tenant_usage_trackings" ("created_at");
+
+CREATE TABLE "data_export_requests" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "requested_by" uuid NOT NULL,
+    "export_type" varchar(50) NOT NULL,
+    "status" varchar(50) NOT NULL DEFAULT 'pending',
+    "file_url" varchar(500),
+    "expires_at" timestamptz,
+    "completed_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_data_export_requests_tenant_id" ON "data_export_requests" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_data_export_requests_deleted_at" ON "data_export_requests" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_data_export_requests_updated_at" ON "data_export_requests" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_data_export_requests_created_at" ON "data_export_requests" ("created_at");
+
+CREATE TABLE "webhook_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "event_type" varchar(100) NOT NULL,
+    "payload" jsonb NOT NULL,
+    "webhook_url" varchar(500) NOT NULL,
+    "attempt_count" bigint DEFAULT 0,
+    "max_attempts" bigint DEFAULT 3,
+    "next_retry_at" timestamptz,
+    "last_attempted_at" timestamptz,
+    "delivered" boolean DEFAULT false,
+    "delivered_at" timestamptz,
+    "failure_reason" text,
+    "response_code" bigint,
+    "response_body" text,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_delivered" ON "webhook_events" ("delivered");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_event_type" ON "webhook_events" ("event_type");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_tenant_id" ON "webhook_events" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_deleted_at" ON "webhook_events" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_updated_at" ON "webhook_events" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_created_at" ON "webhook_events" ("created_at");
+
+CREATE TABLE "audit_logs" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid,
+    "user_id" uuid,
+    "user_email" varchar(255),
+    "user_role" varchar(50),
+    "action" varchar(50) NOT NULL,
+    "entity_type" varchar(50) NOT NULL,
+    "entity_id" uuid NOT NULL,
+    "description" text,
+    "old_values" jsonb,
+    "new_values" jsonb,
+    "ip_address" varchar(45),
+    "user_agent" varchar(500),
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_entity_id" ON "audit_logs" ("entity_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_entity_type" ON "audit_logs" ("entity_type");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_action" ON "audit_logs" ("action");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_user_role" ON "audit_logs" ("user_role");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_user_email" ON "audit_logs" ("user_email");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_user_id" ON "audit_logs" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_tenant_id" ON "audit_logs" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_deleted_at" ON "audit_logs" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_updated_at" ON "audit_logs" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_created_at" ON "audit_logs" ("created_at");
+
+CREATE TABLE "api_keys" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "created_by_id" uuid NOT NULL,
+    "name" varchar(255) NOT NULL,
+    "description" text,
+    "key_hash" varchar(255) NOT NULL,
+    "key_prefix" varchar(10),
+    "scopes" text[] NOT NULL,
+    "status" varchar(50) NOT NULL DEFAULT 'active',
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "usage_count" bigint DEFAULT 0,
+    "rate_limit_per_hour" bigint DEFAULT 1000,
+    "rate_limit_per_day" bigint DEFAULT 10000,
+    "allowed_ips" text[],
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_keys_key_hash" ON "api_keys" ("key_hash");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_tenant_id" ON "api_keys" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_deleted_at" ON "api_keys" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_updated_at" ON "api_keys" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_created_at" ON "api_keys" ("created_at");
+
+CREATE TABLE "idempotency_keys" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "key" varchar(64) NOT NULL,
+    "fingerprint" varchar(64) NOT NULL,
+    "completed" boolean DEFAULT false,
+    "status_code" bigint,
+    "content_type" varchar(100),
+    "response_body" bytea,
+    "expires_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_idempotency_keys_expires_at" ON "idempotency_keys" ("expires_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_idempotency_keys_key" ON "idempotency_keys" ("key");
+CREATE INDEX IF NOT EXISTS "idx_idempotency_keys_deleted_at" ON "idempotency_keys" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_idempotency_keys_updated_at" ON "idempotency_keys" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_idempotency_keys_created_at" ON "idempotency_keys" ("created_at");
+
+CREATE TABLE "white_labels" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "custom_domain" varchar(255),
+    "custom_domain_enabled" boolean DEFAULT false,
+    "ssl_enabled" boolean DEFAULT true,
+    "logo_url" varchar(500),
+    "logo_dark_url" varchar(500),
+    "favicon_url" varchar(500),
+    "apple_touch_icon" varchar(500),
+    "splash_screen_url" varchar(500),
+    "primary_color" varchar(7) DEFAULT '#3B82F6',
+    "secondary_color" varchar(7) DEFAULT '#10B981',
+    "accent_color" varchar(7) DEFAULT '#F59E0B',
+    "background_color" varchar(7) DEFAULT '#FFFFFF',
+    "surface_color" varchar(7) DEFAULT '#F9FAFB',
+    "text_color" varchar(7) DEFAULT '#111827',
+    "text_secondary_color" varchar(7) DEFAULT '#6B7280',
+    "error_color" varchar(7) DEFAULT '#EF4444',
+    "warning_color" varchar(7) DEFAULT '#F59E0B',
+    "success_color" varchar(7) DEFAULT '#10B981',
+    "info_color" varchar(7) DEFAULT '#3B82F6',
+    "font_family" varchar(255) DEFAULT 'Inter, system-ui, sans-serif',
+    "heading_font_family" varchar(255),
+    "font_size" varchar(10) DEFAULT '16px',
+    "font_weight" varchar(10) DEFAULT '400',
+    "theme" jsonb,
+    "dark_mode_enabled" boolean DEFAULT false,
+    "company_name" varchar(255),
+    "company_description" text,
+    "company_tagline" varchar(255),
+    "company_address" text,
+    "company_phone" varchar(20),
+    "company_email" varchar(255),
+    "support_email" varchar(255),
+    "support_phone" varchar(20),
+    "support_url" varchar(500),
+    "terms_of_service_url" varchar(500),
+    "privacy_policy_url" varchar(500),
+    "cookie_policy_url" varchar(500),
+    "acceptable_use_url" varchar(500),
+    "refund_policy_url" varchar(500),
+    "social_links" jsonb,
+    "email_settings" jsonb,
+    "custom_css" text,
+    "custom_js" text,
+    "custom_head" text,
+    "custom_meta_tags" jsonb,
+    "custom_analytics" jsonb,
+    "default_language" varchar(10) DEFAULT 'en',
+    "supported_locales" text[],
+    "timezone" varchar(50) DEFAULT 'UTC',
+    "date_format" varchar(50) DEFAULT 'YYYY-MM-DD',
+    "time_format" varchar(50) DEFAULT 'HH:mm',
+    "currency" varchar(3) DEFAULT 'USD',
+    "seo_settings" jsonb,
+    "ui_settings" jsonb,
+    "copyright_text" varchar(255),
+    "powered_by_text" varchar(255),
+    "hide_powered_by" boolean DEFAULT false,
+    "is_active" boolean DEFAULT true,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_white_labels_custom_domain" ON "white_labels" ("custom_domain");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_white_labels_tenant_id" ON "white_labels" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_white_labels_deleted_at" ON "white_labels" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_white_labels_updated_at" ON "white_labels" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_white_labels_created_at" ON "white_labels" ("created_at");
+
+CREATE TABLE "tenant_admins" (
+    "tenant_id" uuid DEFAULT gen_random_uuid(),
+    "user_id" uuid DEFAULT gen_random_uuid(),
+    PRIMARY KEY ("tenant_id","user_id")
+);
+
+CREATE TABLE "service_addon_relations" (
+    "service_addon_id" uuid DEFAULT gen_random_uuid(),
+    "service_id" uuid DEFAULT gen_random_uuid(),
+    PRIMARY KEY ("service_addon_id","service_id")
+);
+
+-- Query indexes
+CREATE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+CREATE INDEX IF NOT EXISTS "idx_users_tenant_email" ON "users" ("tenant_id","email");
+CREATE INDEX IF NOT EXISTS "idx_users_status" ON "users" ("status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_tenant" ON "bookings" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_customer" ON "bookings" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_artisan" ON "bookings" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_status" ON "bookings" ("status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_start_time" ON "bookings" ("start_time");
+CREATE INDEX IF NOT EXISTS "idx_services_tenant" ON "services" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_services_is_active" ON "services" ("is_active");
+CREATE INDEX IF NOT EXISTS "idx_services_category" ON "services" ("category");
+CREATE INDEX IF NOT EXISTS "idx_payments_tenant" ON "payments" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_booking" ON "payments" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_status" ON "payments" ("status");
+CREATE INDEX IF NOT EXISTS "idx_payments_method" ON "payments" ("method");
+CREATE INDEX IF NOT EXISTS "idx_projects_tenant" ON "projects" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_projects_customer" ON "projects" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_projects_artisan" ON "projects" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_notifications_user" ON "notifications" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_notifications_read" ON "notifications" ("read_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_tenant" ON "webhook_events" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivered" ON "webhook_events" ("delivered");
+CREATE INDEX IF NOT EXISTS "idx_webhook_next_retry" ON "webhook_events" ("next_retry_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_tenant" ON "audit_logs" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_user" ON "audit_logs" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_action" ON "audit_logs" ("action");
+CREATE INDEX IF NOT EXISTS "idx_audit_created" ON "audit_logs" ("created_at");
+
+-- Full-text search indexes
+CREATE INDEX IF NOT EXISTS "idx_services_name_fts" ON "services" USING gin (to_tsvector('english', "name"));
+CREATE INDEX IF NOT EXISTS "idx_services_description_fts" ON "services" USING gin (to_tsvector('english', "description"));
+CREATE INDEX IF NOT EXISTS "idx_artisans_bio_fts" ON "artisans" USING gin (to_tsvector('english', "bio"));
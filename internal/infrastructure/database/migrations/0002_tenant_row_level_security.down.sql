@@ -0,0 +1,173 @@
+DROP POLICY IF EXISTS tenant_isolation ON "white_labels";
+ALTER TABLE "white_labels" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "white_labels" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "api_keys";
+ALTER TABLE "api_keys" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "api_keys" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "webhook_events";
+ALTER TABLE "webhook_events" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "webhook_events" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "data_export_requests";
+ALTER TABLE "data_export_requests" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "data_export_requests" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "tenant_usage_trackings";
+ALTER TABLE "tenant_usage_trackings" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "tenant_usage_trackings" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "reports";
+ALTER TABLE "reports" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "reports" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "reviews";
+ALTER TABLE "reviews" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "reviews" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "file_uploads";
+ALTER TABLE "file_uploads" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "file_uploads" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "notifications";
+ALTER TABLE "notifications" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "notifications" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "messages";
+ALTER TABLE "messages" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "messages" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "subscriptions";
+ALTER TABLE "subscriptions" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "subscriptions" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "promo_code_redemptions";
+ALTER TABLE "promo_code_redemptions" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "promo_code_redemptions" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "commission_rules";
+ALTER TABLE "commission_rules" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "commission_rules" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "tax_rules";
+ALTER TABLE "tax_rules" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "tax_rules" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "invoices";
+ALTER TABLE "invoices" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "invoices" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "financial_statements";
+ALTER TABLE "financial_statements" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "financial_statements" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "payouts";
+ALTER TABLE "payouts" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "payouts" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "payout_batches";
+ALTER TABLE "payout_batches" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "payout_batches" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "payments";
+ALTER TABLE "payments" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "payments" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "change_order_events";
+ALTER TABLE "change_order_events" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "change_order_events" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "change_orders";
+ALTER TABLE "change_orders" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "change_orders" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "project_templates";
+ALTER TABLE "project_templates" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "project_templates" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "quotes";
+ALTER TABLE "quotes" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "quotes" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "time_entries";
+ALTER TABLE "time_entries" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "time_entries" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "project_updates";
+ALTER TABLE "project_updates" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "project_updates" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "project_health_snapshots";
+ALTER TABLE "project_health_snapshots" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "project_health_snapshots" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "project_status_changes";
+ALTER TABLE "project_status_changes" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "project_status_changes" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "task_dependencies";
+ALTER TABLE "task_dependencies" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "task_dependencies" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "project_tasks";
+ALTER TABLE "project_tasks" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "project_tasks" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "project_milestones";
+ALTER TABLE "project_milestones" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "project_milestones" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "projects";
+ALTER TABLE "projects" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "projects" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "booking_conversations";
+ALTER TABLE "booking_conversations" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "booking_conversations" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "booking_events";
+ALTER TABLE "booking_events" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "booking_events" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "saved_filters";
+ALTER TABLE "saved_filters" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "saved_filters" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "booking_exports";
+ALTER TABLE "booking_exports" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "booking_exports" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "booking_imports";
+ALTER TABLE "booking_imports" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "booking_imports" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "cancellation_policies";
+ALTER TABLE "cancellation_policies" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "cancellation_policies" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "bookings";
+ALTER TABLE "bookings" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "bookings" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "availabilities";
+ALTER TABLE "availabilities" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "availabilities" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "service_addons";
+ALTER TABLE "service_addons" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "service_addons" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "services";
+ALTER TABLE "services" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "services" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "customers";
+ALTER TABLE "customers" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "customers" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON "artisans";
+ALTER TABLE "artisans" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "artisans" DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION IF EXISTS app_current_tenant();
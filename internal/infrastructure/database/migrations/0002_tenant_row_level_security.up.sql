@@ -0,0 +1,271 @@
+-- Row-level security on every table whose rows all belong to a tenant. Requests
+-- scoped to a tenant set app.tenant_id and then only see and write that tenant's
+-- rows; with it unset (migrations, background jobs, platform operations) every row
+-- stays visible. FORCE applies the policies to the table owner as well; superusers
+-- and roles with BYPASSRLS still skip them, so the API must not connect as one.
+--
+-- Tables with a nullable tenant_id (users, audit logs, global promo codes and the
+-- like) and tenant_invitations, which invitees read before joining the tenant, are
+-- left out.
+
+CREATE OR REPLACE FUNCTION app_current_tenant() RETURNS uuid
+    LANGUAGE sql STABLE
+    AS $$ SELECT NULLIF(current_setting('app.tenant_id', true), '')::uuid $$;
+
+ALTER TABLE "artisans" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "artisans" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "artisans"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "customers" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "customers" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "customers"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "services" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "services" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "services"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "service_addons" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "service_addons" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "service_addons"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "availabilities" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "availabilities" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "availabilities"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "bookings" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "bookings" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "bookings"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "cancellation_policies" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "cancellation_policies" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "cancellation_policies"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "booking_imports" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "booking_imports" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "booking_imports"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "booking_exports" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "booking_exports" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "booking_exports"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "saved_filters" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "saved_filters" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "saved_filters"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "booking_events" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "booking_events" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "booking_events"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "booking_conversations" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "booking_conversations" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "booking_conversations"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "projects" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "projects" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "projects"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "project_milestones" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "project_milestones" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "project_milestones"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "project_tasks" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "project_tasks" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "project_tasks"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "task_dependencies" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "task_dependencies" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "task_dependencies"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "project_status_changes" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "project_status_changes" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "project_status_changes"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "project_health_snapshots" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "project_health_snapshots" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "project_health_snapshots"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "project_updates" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "project_updates" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "project_updates"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "time_entries" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "time_entries" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "time_entries"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "quotes" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "quotes" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "quotes"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "project_templates" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "project_templates" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "project_templates"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "change_orders" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "change_orders" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "change_orders"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "change_order_events" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "change_order_events" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "change_order_events"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "payments" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "payments" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "payments"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "payout_batches" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "payout_batches" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "payout_batches"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "payouts" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "payouts" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "payouts"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "financial_statements" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "financial_statements" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "financial_statements"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "invoices" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "invoices" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "invoices"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "tax_rules" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "tax_rules" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "tax_rules"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "commission_rules" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "commission_rules" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "commission_rules"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "promo_code_redemptions" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "promo_code_redemptions" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "promo_code_redemptions"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "subscriptions" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "subscriptions" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "subscriptions"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "messages" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "messages" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "messages"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "notifications" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "notifications" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "notifications"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "file_uploads" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "file_uploads" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "file_uploads"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "reviews" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "reviews" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "reviews"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "reports" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "reports" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "reports"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "tenant_usage_trackings" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "tenant_usage_trackings" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "tenant_usage_trackings"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "data_export_requests" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "data_export_requests" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "data_export_requests"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "webhook_events" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "webhook_events" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "webhook_events"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "api_keys" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "api_keys" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "api_keys"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "white_labels" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "white_labels" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "white_labels"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
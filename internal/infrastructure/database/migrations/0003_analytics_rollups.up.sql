@@ -0,0 +1,58 @@
+-- Daily rollups of bookings and payments per tenant, artisan and service, which the
+-- dashboards read instead of aggregating the source tables on every request, and
+-- how far each tenant's rollups are up to date.
+
+CREATE TABLE "analytics_daily_rollups" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "service_id" uuid NOT NULL,
+    "day" date NOT NULL,
+    "booking_count" bigint NOT NULL DEFAULT 0,
+    "completed_count" bigint NOT NULL DEFAULT 0,
+    "cancelled_count" bigint NOT NULL DEFAULT 0,
+    "booking_revenue" decimal(12,2) NOT NULL DEFAULT 0,
+    "payment_count" bigint NOT NULL DEFAULT 0,
+    "payment_revenue" decimal(12,2) NOT NULL DEFAULT 0,
+    "refreshed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_analytics_rollup_key" ON "analytics_daily_rollups" ("tenant_id","artisan_id","service_id","day");
+CREATE INDEX IF NOT EXISTS "idx_analytics_daily_rollups_artisan_id" ON "analytics_daily_rollups" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_analytics_daily_rollups_service_id" ON "analytics_daily_rollups" ("service_id");
+CREATE INDEX IF NOT EXISTS "idx_analytics_daily_rollups_day" ON "analytics_daily_rollups" ("day");
+CREATE INDEX IF NOT EXISTS "idx_analytics_daily_rollups_deleted_at" ON "analytics_daily_rollups" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_analytics_daily_rollups_updated_at" ON "analytics_daily_rollups" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_analytics_daily_rollups_created_at" ON "analytics_daily_rollups" ("created_at");
+
+CREATE TABLE "analytics_rollup_states" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "refreshed_at" timestamptz NOT NULL,
+    "rebuilt_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_analytics_rollup_states_tenant_id" ON "analytics_rollup_states" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_analytics_rollup_states_deleted_at" ON "analytics_rollup_states" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_analytics_rollup_states_updated_at" ON "analytics_rollup_states" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_analytics_rollup_states_created_at" ON "analytics_rollup_states" ("created_at");
+
+ALTER TABLE "analytics_daily_rollups" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "analytics_daily_rollups" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "analytics_daily_rollups"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "analytics_rollup_states" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "analytics_rollup_states" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "analytics_rollup_states"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
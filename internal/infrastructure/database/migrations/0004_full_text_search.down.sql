@@ -0,0 +1,4 @@
+ALTER TABLE "projects" DROP COLUMN IF EXISTS "search_vector";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "search_vector";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "search_vector";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "search_vector";
@@ -0,0 +1,16 @@
+-- Full-text search documents of users, bookings, services and projects, generated by
+-- Postgres from the searchable columns so they can't fall out of step with them.
+-- The 'simple' configuration neither stems nor drops stop words, so names and text in
+-- any language match as typed.
+
+ALTER TABLE "users" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(first_name, '') || ' ' || coalesce(last_name, '')), 'A') || setweight(to_tsvector('simple', coalesce(email, '') || ' ' || coalesce(phone_number, '')), 'B')) STORED;
+CREATE INDEX IF NOT EXISTS "idx_user_search" ON "users" USING gin("search_vector");
+
+ALTER TABLE "bookings" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(notes, '') || ' ' || coalesce(customer_notes, '')), 'B') || setweight(to_tsvector('simple', coalesce(internal_notes, '')), 'C')) STORED;
+CREATE INDEX IF NOT EXISTS "idx_booking_search" ON "bookings" USING gin("search_vector");
+
+ALTER TABLE "services" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(name, '')), 'A') || setweight(to_tsvector('simple', coalesce(description, '')), 'B')) STORED;
+CREATE INDEX IF NOT EXISTS "idx_service_search" ON "services" USING gin("search_vector");
+
+ALTER TABLE "projects" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(title, '')), 'A') || setweight(to_tsvector('simple', coalesce(description, '')), 'B')) STORED;
+CREATE INDEX IF NOT EXISTS "idx_project_search" ON "projects" USING gin("search_vector");
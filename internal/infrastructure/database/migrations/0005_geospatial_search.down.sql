@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "service_areas";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "is_mobile";
+ALTER TABLE "artisans" DROP COLUMN IF EXISTS "geo_point";
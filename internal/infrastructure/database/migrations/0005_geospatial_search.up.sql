@@ -0,0 +1,38 @@
+-- PostGIS points of artisan locations for distance searches, the mobile flag of
+-- services, and the service areas that limit where customers can book an artisan's
+-- mobile services. The points and areas are generated by Postgres from the JSON
+-- location and boundary, so they can't fall out of step with them.
+
+CREATE EXTENSION IF NOT EXISTS "postgis";
+
+ALTER TABLE "artisans" ADD COLUMN "geo_point" geography(Point,4326) GENERATED ALWAYS AS (CASE WHEN (location->>'latitude')::float8 <> 0 OR (location->>'longitude')::float8 <> 0 THEN ST_SetSRID(ST_MakePoint((location->>'longitude')::float8, (location->>'latitude')::float8), 4326)::geography END) STORED;
+CREATE INDEX IF NOT EXISTS "idx_artisan_geo_point" ON "artisans" USING gist("geo_point");
+
+ALTER TABLE "services" ADD COLUMN "is_mobile" boolean DEFAULT false;
+
+CREATE TABLE "service_areas" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "boundary" jsonb NOT NULL,
+    "is_active" boolean NOT NULL,
+    "area" geography(Polygon,4326) GENERATED ALWAYS AS (ST_GeomFromGeoJSON(boundary)::geography) STORED,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_service_areas_tenant_id" ON "service_areas" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_service_areas_artisan_id" ON "service_areas" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_service_area_area" ON "service_areas" USING gist("area");
+CREATE INDEX IF NOT EXISTS "idx_service_areas_deleted_at" ON "service_areas" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_service_areas_updated_at" ON "service_areas" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_service_areas_created_at" ON "service_areas" ("created_at");
+
+ALTER TABLE "service_areas" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "service_areas" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "service_areas"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
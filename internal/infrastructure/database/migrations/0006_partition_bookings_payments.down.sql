@@ -0,0 +1,78 @@
+-- Rows of archived months are not brought back; restore them from their exports.
+
+DROP TABLE IF EXISTS "data_archives";
+
+-- Payments
+
+ALTER TABLE "payments" RENAME TO "payments_partitioned";
+CREATE TABLE "payments" (LIKE "payments_partitioned" INCLUDING DEFAULTS);
+ALTER TABLE "payments" ALTER COLUMN "created_at" DROP DEFAULT;
+ALTER TABLE "payments" ALTER COLUMN "created_at" DROP NOT NULL;
+INSERT INTO "payments" SELECT * FROM "payments_partitioned";
+DROP TABLE "payments_partitioned";
+
+ALTER TABLE "payments" ADD PRIMARY KEY ("id");
+CREATE INDEX IF NOT EXISTS "idx_payments_escrow_status" ON "payments" ("escrow_status");
+CREATE INDEX IF NOT EXISTS "idx_payments_payout_id" ON "payments" ("payout_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_commission_rule_id" ON "payments" ("commission_rule_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_promo_code_id" ON "payments" ("promo_code_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_provider_payment_id" ON "payments" ("provider_payment_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_artisan_id" ON "payments" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_customer_id" ON "payments" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_booking_id" ON "payments" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_tenant_id" ON "payments" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_deleted_at" ON "payments" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_updated_at" ON "payments" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_created_at" ON "payments" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_tenant" ON "payments" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_booking" ON "payments" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_status" ON "payments" ("status");
+CREATE INDEX IF NOT EXISTS "idx_payments_method" ON "payments" ("method");
+
+ALTER TABLE "payments" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "payments" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "payments"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+-- Bookings
+
+ALTER TABLE "bookings" RENAME TO "bookings_partitioned";
+ALTER TABLE "bookings_partitioned" DROP COLUMN "search_vector";
+CREATE TABLE "bookings" (LIKE "bookings_partitioned" INCLUDING DEFAULTS);
+ALTER TABLE "bookings" ALTER COLUMN "created_at" DROP DEFAULT;
+ALTER TABLE "bookings" ALTER COLUMN "created_at" DROP NOT NULL;
+INSERT INTO "bookings" SELECT * FROM "bookings_partitioned";
+DROP TABLE "bookings_partitioned";
+
+ALTER TABLE "bookings" ADD PRIMARY KEY ("id");
+ALTER TABLE "bookings" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(notes, '') || ' ' || coalesce(customer_notes, '')), 'B') || setweight(to_tsvector('simple', coalesce(internal_notes, '')), 'C')) STORED;
+CREATE INDEX IF NOT EXISTS "idx_bookings_parent_booking_id" ON "bookings" ("parent_booking_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_balance_status" ON "bookings" ("balance_status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_balance_due_at" ON "bookings" ("balance_due_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_tags" ON "bookings" USING gin("tags");
+CREATE INDEX IF NOT EXISTS "idx_bookings_promo_code_id" ON "bookings" ("promo_code_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_payment_status" ON "bookings" ("payment_status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_project_id" ON "bookings" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_service_id" ON "bookings" ("service_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_customer_status" ON "bookings" ("customer_id","status");
+CREATE INDEX IF NOT EXISTS "idx_booking_artisan_status" ON "bookings" ("artisan_id","start_time","status");
+CREATE INDEX IF NOT EXISTS "idx_booking_tenant_artisan" ON "bookings" ("tenant_id","artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_tenant_date" ON "bookings" ("tenant_id","start_time");
+CREATE INDEX IF NOT EXISTS "idx_bookings_deleted_at" ON "bookings" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_bookings_updated_at" ON "bookings" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_bookings_created_at" ON "bookings" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_bookings_tenant" ON "bookings" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_customer" ON "bookings" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_artisan" ON "bookings" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_status" ON "bookings" ("status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_start_time" ON "bookings" ("start_time");
+CREATE INDEX IF NOT EXISTS "idx_booking_search" ON "bookings" USING gin("search_vector");
+
+ALTER TABLE "bookings" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "bookings" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "bookings"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+DROP FUNCTION IF EXISTS create_monthly_partition(text, date);
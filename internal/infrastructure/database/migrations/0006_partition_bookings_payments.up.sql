@@ -0,0 +1,154 @@
+-- Monthly range partitions of bookings and payments by created_at, so old months can
+-- be exported to cold storage and dropped whole instead of deleted row by row, and
+-- the manifest of the months archived that way.
+--
+-- The primary keys become (id, created_at), as every unique index of a partitioned
+-- table has to include the partition key; ids stay UUIDs, so they remain unique.
+-- Partitions are named <table>_pYYYY_MM and cover a UTC month. The partition
+-- maintenance job creates them ahead of time; rows outside every month land in the
+-- <table>_default partition.
+--
+-- Row-level security applies to the partitioned tables, which is all the application
+-- queries; the partitions themselves are only touched by maintenance and archival.
+
+CREATE OR REPLACE FUNCTION create_monthly_partition(parent text, month_start date) RETURNS text
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    first_day date := date_trunc('month', month_start)::date;
+    partition_name text := format('%s_p%s', parent, to_char(first_day, 'YYYY_MM'));
+BEGIN
+    EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)',
+        partition_name, parent,
+        first_day::timestamp AT TIME ZONE 'UTC',
+        (first_day + interval '1 month')::timestamp AT TIME ZONE 'UTC');
+    RETURN partition_name;
+END
+$$;
+
+-- Bookings
+
+UPDATE "bookings" SET "created_at" = COALESCE("updated_at", now()) WHERE "created_at" IS NULL;
+ALTER TABLE "bookings" RENAME TO "bookings_unpartitioned";
+ALTER TABLE "bookings_unpartitioned" DROP COLUMN "search_vector";
+
+CREATE TABLE "bookings" (LIKE "bookings_unpartitioned" INCLUDING DEFAULTS) PARTITION BY RANGE ("created_at");
+ALTER TABLE "bookings" ALTER COLUMN "created_at" SET DEFAULT now();
+ALTER TABLE "bookings" ALTER COLUMN "created_at" SET NOT NULL;
+ALTER TABLE "bookings" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(notes, '') || ' ' || coalesce(customer_notes, '')), 'B') || setweight(to_tsvector('simple', coalesce(internal_notes, '')), 'C')) STORED;
+CREATE TABLE "bookings_default" PARTITION OF "bookings" DEFAULT;
+
+DO $$
+DECLARE
+    month_start date;
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(created_at), now()) AT TIME ZONE 'UTC')::date INTO month_start FROM "bookings_unpartitioned";
+    WHILE month_start <= (now() AT TIME ZONE 'UTC')::date + interval '3 months' LOOP
+        PERFORM create_monthly_partition('bookings', month_start);
+        month_start := month_start + interval '1 month';
+    END LOOP;
+END
+$$;
+
+INSERT INTO "bookings" SELECT * FROM "bookings_unpartitioned";
+DROP TABLE "bookings_unpartitioned";
+
+ALTER TABLE "bookings" ADD PRIMARY KEY ("id", "created_at");
+CREATE INDEX IF NOT EXISTS "idx_bookings_parent_booking_id" ON "bookings" ("parent_booking_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_balance_status" ON "bookings" ("balance_status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_balance_due_at" ON "bookings" ("balance_due_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_tags" ON "bookings" USING gin("tags");
+CREATE INDEX IF NOT EXISTS "idx_bookings_promo_code_id" ON "bookings" ("promo_code_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_payment_status" ON "bookings" ("payment_status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_project_id" ON "bookings" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_service_id" ON "bookings" ("service_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_customer_status" ON "bookings" ("customer_id","status");
+CREATE INDEX IF NOT EXISTS "idx_booking_artisan_status" ON "bookings" ("artisan_id","start_time","status");
+CREATE INDEX IF NOT EXISTS "idx_booking_tenant_artisan" ON "bookings" ("tenant_id","artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_tenant_date" ON "bookings" ("tenant_id","start_time");
+CREATE INDEX IF NOT EXISTS "idx_bookings_deleted_at" ON "bookings" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_bookings_updated_at" ON "bookings" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_bookings_created_at" ON "bookings" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_bookings_tenant" ON "bookings" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_customer" ON "bookings" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_artisan" ON "bookings" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_status" ON "bookings" ("status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_start_time" ON "bookings" ("start_time");
+CREATE INDEX IF NOT EXISTS "idx_booking_search" ON "bookings" USING gin("search_vector");
+
+ALTER TABLE "bookings" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "bookings" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "bookings"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+-- Payments
+
+UPDATE "payments" SET "created_at" = COALESCE("updated_at", now()) WHERE "created_at" IS NULL;
+ALTER TABLE "payments" RENAME TO "payments_unpartitioned";
+
+CREATE TABLE "payments" (LIKE "payments_unpartitioned" INCLUDING DEFAULTS) PARTITION BY RANGE ("created_at");
+ALTER TABLE "payments" ALTER COLUMN "created_at" SET DEFAULT now();
+ALTER TABLE "payments" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE TABLE "payments_default" PARTITION OF "payments" DEFAULT;
+
+DO $$
+DECLARE
+    month_start date;
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(created_at), now()) AT TIME ZONE 'UTC')::date INTO month_start FROM "payments_unpartitioned";
+    WHILE month_start <= (now() AT TIME ZONE 'UTC')::date + interval '3 months' LOOP
+        PERFORM create_monthly_partition('payments', month_start);
+        month_start := month_start + interval '1 month';
+    END LOOP;
+END
+$$;
+
+INSERT INTO "payments" SELECT * FROM "payments_unpartitioned";
+DROP TABLE "payments_unpartitioned";
+
+ALTER TABLE "payments" ADD PRIMARY KEY ("id", "created_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_escrow_status" ON "payments" ("escrow_status");
+CREATE INDEX IF NOT EXISTS "idx_payments_payout_id" ON "payments" ("payout_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_commission_rule_id" ON "payments" ("commission_rule_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_promo_code_id" ON "payments" ("promo_code_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_provider_payment_id" ON "payments" ("provider_payment_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_artisan_id" ON "payments" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_customer_id" ON "payments" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_booking_id" ON "payments" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_tenant_id" ON "payments" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_deleted_at" ON "payments" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_updated_at" ON "payments" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_created_at" ON "payments" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_tenant" ON "payments" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_booking" ON "payments" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_status" ON "payments" ("status");
+CREATE INDEX IF NOT EXISTS "idx_payments_method" ON "payments" ("method");
+
+ALTER TABLE "payments" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "payments" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "payments"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+-- Archive manifest
+
+CREATE TABLE "data_archives" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "source_table" varchar(63) NOT NULL,
+    "period_start" timestamptz NOT NULL,
+    "period_end" timestamptz NOT NULL,
+    "object_key" varchar(500) NOT NULL,
+    "row_count" bigint NOT NULL DEFAULT 0,
+    "size_bytes" bigint NOT NULL DEFAULT 0,
+    "archived_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_data_archive_period" ON "data_archives" ("source_table","period_start");
+CREATE INDEX IF NOT EXISTS "idx_data_archives_deleted_at" ON "data_archives" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_data_archives_updated_at" ON "data_archives" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_data_archives_created_at" ON "data_archives" ("created_at");
@@ -0,0 +1,32 @@
+-- Transactional outbox of domain events: each event is written in the transaction of
+-- the change it reports and delivered to the subscribed consumers by the dispatcher.
+-- Platform events carry no tenant, so the table is left out of row-level security.
+
+CREATE TABLE "outbox_events" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid,
+    "event_type" varchar(100) NOT NULL,
+    "aggregate_type" varchar(50) NOT NULL,
+    "aggregate_id" uuid NOT NULL,
+    "payload" jsonb NOT NULL,
+    "occurred_at" timestamptz NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'pending',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "failed_consumers" jsonb,
+    "last_error" text,
+    "dispatched_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_due" ON "outbox_events" ("status","next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_tenant_id" ON "outbox_events" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_event_type" ON "outbox_events" ("event_type");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_aggregate_id" ON "outbox_events" ("aggregate_id");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_dispatched_at" ON "outbox_events" ("dispatched_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_deleted_at" ON "outbox_events" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_updated_at" ON "outbox_events" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_created_at" ON "outbox_events" ("created_at");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "webhook_delivery_attempts";
+DROP INDEX IF EXISTS "idx_webhook_subscription_event";
+ALTER TABLE "webhook_events" DROP COLUMN IF EXISTS "domain_event_id";
+ALTER TABLE "webhook_events" DROP COLUMN IF EXISTS "subscription_id";
+DROP TABLE IF EXISTS "webhook_subscriptions";
@@ -0,0 +1,62 @@
+-- Tenant-configured webhook endpoints. Domain events are fanned out to the matching
+-- subscriptions as webhook_events, at most once per subscription and event, and every
+-- delivery attempt is logged for the tenant to troubleshoot their endpoint.
+
+CREATE TABLE "webhook_subscriptions" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "url" varchar(500) NOT NULL,
+    "description" varchar(255),
+    "secret" text NOT NULL,
+    "event_types" jsonb,
+    "is_active" boolean DEFAULT true,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_tenant_id" ON "webhook_subscriptions" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_is_active" ON "webhook_subscriptions" ("is_active");
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_deleted_at" ON "webhook_subscriptions" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_updated_at" ON "webhook_subscriptions" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscriptions_created_at" ON "webhook_subscriptions" ("created_at");
+
+ALTER TABLE "webhook_events" ADD COLUMN "subscription_id" uuid;
+ALTER TABLE "webhook_events" ADD COLUMN "domain_event_id" uuid;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_webhook_subscription_event" ON "webhook_events" ("subscription_id","domain_event_id");
+
+CREATE TABLE "webhook_delivery_attempts" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "webhook_event_id" uuid NOT NULL,
+    "attempt" bigint NOT NULL,
+    "attempted_at" timestamptz NOT NULL,
+    "duration_ms" bigint,
+    "succeeded" boolean DEFAULT false,
+    "response_code" bigint,
+    "response_body" text,
+    "error" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_tenant_id" ON "webhook_delivery_attempts" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_webhook_event_id" ON "webhook_delivery_attempts" ("webhook_event_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_deleted_at" ON "webhook_delivery_attempts" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_updated_at" ON "webhook_delivery_attempts" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_created_at" ON "webhook_delivery_attempts" ("created_at");
+
+ALTER TABLE "webhook_subscriptions" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "webhook_subscriptions" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "webhook_subscriptions"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "webhook_delivery_attempts" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "webhook_delivery_attempts" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "webhook_delivery_attempts"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "tenant_role_assignments";
+DROP TABLE IF EXISTS "tenant_roles";
@@ -0,0 +1,49 @@
+-- Custom roles tenant admins define for their staff, and the users they are assigned
+-- to. A role's permissions are authorization actions granted across the tenant.
+
+CREATE TABLE "tenant_roles" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "description" varchar(255),
+    "permissions" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tenant_role_name" ON "tenant_roles" ("tenant_id","name");
+CREATE INDEX IF NOT EXISTS "idx_tenant_roles_deleted_at" ON "tenant_roles" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_roles_updated_at" ON "tenant_roles" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_roles_created_at" ON "tenant_roles" ("created_at");
+
+CREATE TABLE "tenant_role_assignments" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "role_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tenant_role_assignment" ON "tenant_role_assignments" ("role_id","user_id");
+CREATE INDEX IF NOT EXISTS "idx_tenant_role_assignments_tenant_id" ON "tenant_role_assignments" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_tenant_role_assignments_user_id" ON "tenant_role_assignments" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_tenant_role_assignments_deleted_at" ON "tenant_role_assignments" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_role_assignments_updated_at" ON "tenant_role_assignments" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_role_assignments_created_at" ON "tenant_role_assignments" ("created_at");
+
+ALTER TABLE "tenant_roles" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "tenant_roles" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "tenant_roles"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "tenant_role_assignments" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "tenant_role_assignments" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "tenant_role_assignments"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
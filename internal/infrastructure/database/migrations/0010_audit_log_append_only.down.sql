@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS audit_logs_no_truncate ON "audit_logs";
+DROP TRIGGER IF EXISTS audit_logs_append_only ON "audit_logs";
+DROP FUNCTION IF EXISTS audit_logs_append_only();
+DROP INDEX IF EXISTS "idx_audit_log_tenant_created";
+DROP INDEX IF EXISTS "idx_audit_logs_request_id";
+ALTER TABLE "audit_logs" DROP COLUMN IF EXISTS "request_id";
@@ -0,0 +1,22 @@
+-- Audit log entries are written once and never changed: updates, deletes and truncation
+-- of audit_logs are refused, so the trail holds up for compliance reviews. Entries
+-- record the request that made the change so it can be traced across logs.
+
+ALTER TABLE "audit_logs" ADD COLUMN IF NOT EXISTS "request_id" varchar(100);
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_request_id" ON "audit_logs" ("request_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_log_tenant_created" ON "audit_logs" ("tenant_id","created_at");
+
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only: % is not allowed', TG_OP;
+END;
+$$;
+
+CREATE TRIGGER audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON "audit_logs"
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+CREATE TRIGGER audit_logs_no_truncate
+    BEFORE TRUNCATE ON "audit_logs"
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only();
@@ -0,0 +1,24 @@
+-- Progress of tenants through the self-serve onboarding wizard, so the wizard can be
+-- resumed at the first step not completed yet.
+
+CREATE TABLE "tenant_onboardings" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "completed_steps" jsonb,
+    "completed_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tenant_onboardings_tenant_id" ON "tenant_onboardings" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_tenant_onboardings_deleted_at" ON "tenant_onboardings" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_onboardings_updated_at" ON "tenant_onboardings" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_tenant_onboardings_created_at" ON "tenant_onboardings" ("created_at");
+
+ALTER TABLE "tenant_onboardings" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "tenant_onboardings" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "tenant_onboardings"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
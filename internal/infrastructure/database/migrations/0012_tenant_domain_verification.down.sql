@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_tenants_domain";
+ALTER TABLE "tenants" DROP COLUMN IF EXISTS "domain_verified_at";
+ALTER TABLE "tenants" DROP COLUMN IF EXISTS "domain_verification_token";
@@ -0,0 +1,7 @@
+-- Tenants serve requests at their custom domain once they prove they own it with a
+-- TXT record carrying their verification token. Hosts are resolved to tenants on every
+-- request, so custom domains are indexed like subdomains.
+
+ALTER TABLE "tenants" ADD COLUMN IF NOT EXISTS "domain_verification_token" varchar(64);
+ALTER TABLE "tenants" ADD COLUMN IF NOT EXISTS "domain_verified_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_tenants_domain" ON "tenants" ("domain");
@@ -0,0 +1,3 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "erased_at";
+DROP INDEX IF EXISTS "idx_data_export_requests_subject_user_id";
+ALTER TABLE "data_export_requests" DROP COLUMN IF EXISTS "subject_user_id";
@@ -0,0 +1,7 @@
+-- Users export their personal data, which gdpr exports gather across tenant tables by
+-- the user they name, and have it erased after a grace period: the user is anonymized
+-- in place so the bookings and payments referencing them are kept.
+
+ALTER TABLE "data_export_requests" ADD COLUMN IF NOT EXISTS "subject_user_id" uuid;
+CREATE INDEX IF NOT EXISTS "idx_data_export_requests_subject_user_id" ON "data_export_requests" ("subject_user_id");
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "erased_at" timestamptz;
@@ -0,0 +1,48 @@
+-- Users' choices of the transactional messages and marketing they are sent on each
+-- channel, enforced when notifications are dispatched. Preferences belong to the user
+-- rather than a tenant, like users, so the table has no row-level security.
+--
+-- Customers keep the notification toggles of their profile as transactional
+-- preferences, and users who consented to marketing keep receiving it by email.
+
+CREATE TABLE "communication_preferences" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "user_id" uuid NOT NULL,
+    "email_transactional" boolean NOT NULL DEFAULT true,
+    "sms_transactional" boolean NOT NULL DEFAULT true,
+    "push_transactional" boolean NOT NULL DEFAULT true,
+    "email_marketing" boolean NOT NULL DEFAULT false,
+    "sms_marketing" boolean NOT NULL DEFAULT false,
+    "push_marketing" boolean NOT NULL DEFAULT false,
+    "marketing_consent_at" timestamptz,
+    "unsubscribe_token" varchar(64) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_communication_preferences_user_id" ON "communication_preferences" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_communication_preferences_unsubscribe_token" ON "communication_preferences" ("unsubscribe_token");
+CREATE INDEX IF NOT EXISTS "idx_communication_preferences_deleted_at" ON "communication_preferences" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_communication_preferences_updated_at" ON "communication_preferences" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_communication_preferences_created_at" ON "communication_preferences" ("created_at");
+
+INSERT INTO "communication_preferences" (
+    "created_at", "updated_at", "user_id",
+    "email_transactional", "sms_transactional", "push_transactional",
+    "email_marketing", "marketing_consent_at", "unsubscribe_token"
+)
+SELECT DISTINCT ON (u."id")
+    now(), now(), u."id",
+    COALESCE(c."email_notifications", true),
+    COALESCE(c."sms_notifications", true),
+    COALESCE(c."push_notifications", true),
+    u."marketing_consent",
+    CASE WHEN u."marketing_consent" THEN now() END,
+    replace(gen_random_uuid()::text, '-', '') || replace(gen_random_uuid()::text, '-', '')
+FROM "users" u
+LEFT JOIN "customers" c ON c."user_id" = u."id" AND c."deleted_at" IS NULL
+WHERE u."deleted_at" IS NULL
+  AND (c."id" IS NOT NULL OR u."marketing_consent")
+ORDER BY u."id", c."updated_at" DESC;
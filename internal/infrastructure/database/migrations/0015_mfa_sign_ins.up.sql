@@ -0,0 +1,5 @@
+-- When users were last seen signed in with a second factor, for reporting on accounts
+-- not complying with their tenant's MFA policy. The policy itself is kept in the
+-- tenant's settings.
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "last_mfa_at" timestamptz;
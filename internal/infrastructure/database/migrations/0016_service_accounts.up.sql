@@ -0,0 +1,62 @@
+-- Service accounts tenants create for their integrations, signing in as Zitadel
+-- machine users and confined to the actions of their scopes, and the daily counts of
+-- the requests each makes per kind of resource for usage dashboards.
+
+CREATE TABLE "service_accounts" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "created_by_id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "description" varchar(255),
+    "scopes" jsonb,
+    "status" varchar(20) NOT NULL DEFAULT 'active',
+    "zitadel_user_id" varchar(255),
+    "client_id" varchar(255),
+    "secret_rotated_at" timestamptz,
+    "last_used_at" timestamptz,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_service_account_name" ON "service_accounts" ("tenant_id","name");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_service_accounts_zitadel_user_id" ON "service_accounts" ("zitadel_user_id");
+CREATE INDEX IF NOT EXISTS "idx_service_accounts_deleted_at" ON "service_accounts" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_service_accounts_updated_at" ON "service_accounts" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_service_accounts_created_at" ON "service_accounts" ("created_at");
+
+CREATE TABLE "service_account_usage" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "service_account_id" uuid NOT NULL,
+    "day" date NOT NULL,
+    "resource" varchar(50) NOT NULL,
+    "requests" bigint NOT NULL DEFAULT 0,
+    "denied" bigint NOT NULL DEFAULT 0,
+    "client_errors" bigint NOT NULL DEFAULT 0,
+    "server_errors" bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_service_account_usage_day" ON "service_account_usage" ("service_account_id","day","resource");
+CREATE INDEX IF NOT EXISTS "idx_service_account_usage_tenant_id" ON "service_account_usage" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_service_account_usage_deleted_at" ON "service_account_usage" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_service_account_usage_updated_at" ON "service_account_usage" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_service_account_usage_created_at" ON "service_account_usage" ("created_at");
+
+ALTER TABLE "service_accounts" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "service_accounts" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "service_accounts"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "service_account_usage" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "service_account_usage" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "service_account_usage"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS "idx_bookings_price_version_id";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "price_version_id";
+
+DROP TABLE IF EXISTS "service_price_versions";
+
+DROP INDEX IF EXISTS "idx_services_publish_at";
+DROP INDEX IF EXISTS "idx_services_status";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "price_version_id";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "pending_changes";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "published_at";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "publish_at";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "status";
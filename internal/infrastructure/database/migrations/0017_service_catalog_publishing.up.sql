@@ -0,0 +1,61 @@
+-- Draft, published and archived states of catalog services, with pending changes to
+-- published services and scheduled publication, and the versions of each service's
+-- published price that bookings reference, so a price change never rewrites history.
+--
+-- Existing services are published, each starting from a first price version of its
+-- current price.
+
+ALTER TABLE "services" ADD COLUMN "status" varchar(20) NOT NULL DEFAULT 'published';
+ALTER TABLE "services" ADD COLUMN "publish_at" timestamptz;
+ALTER TABLE "services" ADD COLUMN "published_at" timestamptz;
+ALTER TABLE "services" ADD COLUMN "pending_changes" jsonb;
+ALTER TABLE "services" ADD COLUMN "price_version_id" uuid;
+CREATE INDEX IF NOT EXISTS "idx_services_status" ON "services" ("status");
+CREATE INDEX IF NOT EXISTS "idx_services_publish_at" ON "services" ("publish_at");
+
+CREATE TABLE "service_price_versions" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "service_id" uuid NOT NULL,
+    "number" bigint NOT NULL,
+    "price" decimal(10,2) NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "deposit_amount" decimal(10,2) DEFAULT 0,
+    "tax_category" varchar(50) DEFAULT 'standard',
+    "effective_from" timestamptz NOT NULL,
+    "effective_to" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_service_price_version_number" ON "service_price_versions" ("service_id","number");
+CREATE INDEX IF NOT EXISTS "idx_service_price_versions_tenant_id" ON "service_price_versions" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_service_price_versions_deleted_at" ON "service_price_versions" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_service_price_versions_updated_at" ON "service_price_versions" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_service_price_versions_created_at" ON "service_price_versions" ("created_at");
+
+ALTER TABLE "service_price_versions" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "service_price_versions" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "service_price_versions"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+INSERT INTO "service_price_versions" (
+    "created_at", "updated_at", "version", "tenant_id", "service_id", "number",
+    "price", "currency", "deposit_amount", "tax_category", "effective_from"
+)
+SELECT now(), now(), 1, "tenant_id", "id", 1,
+    "price", COALESCE("currency", 'USD'), COALESCE("deposit_amount", 0), COALESCE("tax_category", 'standard'),
+    COALESCE("created_at", now())
+FROM "services"
+WHERE "deleted_at" IS NULL;
+
+UPDATE "services" s
+SET "price_version_id" = v."id", "published_at" = COALESCE(s."created_at", now())
+FROM "service_price_versions" v
+WHERE v."service_id" = s."id";
+
+ALTER TABLE "bookings" ADD COLUMN "price_version_id" uuid;
+CREATE INDEX IF NOT EXISTS "idx_bookings_price_version_id" ON "bookings" ("price_version_id");
@@ -0,0 +1,4 @@
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "pricing_adjustments";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "list_price";
+
+DROP TABLE IF EXISTS "pricing_rules";
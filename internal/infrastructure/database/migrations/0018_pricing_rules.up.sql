@@ -0,0 +1,48 @@
+-- Dynamic pricing rules multiplying a service's price for peak and off-peak hours,
+-- last-minute bookings and seasons, tenant-wide or per service, category or artisan,
+-- and the adjustments they made recorded on each booking beside the service's list
+-- price.
+--
+-- Existing bookings were priced at the list price.
+
+CREATE TABLE "pricing_rules" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "kind" varchar(20) NOT NULL,
+    "service_id" uuid,
+    "service_category" varchar(50),
+    "artisan_id" uuid,
+    "weekdays" jsonb,
+    "start_hour" bigint DEFAULT 0,
+    "end_hour" bigint DEFAULT 0,
+    "timezone" varchar(50) DEFAULT 'UTC',
+    "lead_hours" bigint DEFAULT 0,
+    "starts_at" timestamptz,
+    "ends_at" timestamptz,
+    "multiplier" decimal(6,4) NOT NULL,
+    "is_active" boolean DEFAULT true,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_tenant_id" ON "pricing_rules" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_kind" ON "pricing_rules" ("kind");
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_service_id" ON "pricing_rules" ("service_id");
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_artisan_id" ON "pricing_rules" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_is_active" ON "pricing_rules" ("is_active");
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_deleted_at" ON "pricing_rules" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_updated_at" ON "pricing_rules" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_pricing_rules_created_at" ON "pricing_rules" ("created_at");
+
+ALTER TABLE "pricing_rules" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "pricing_rules" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "pricing_rules"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "bookings" ADD COLUMN "list_price" decimal(10,2) DEFAULT 0;
+ALTER TABLE "bookings" ADD COLUMN "pricing_adjustments" jsonb;
+UPDATE "bookings" SET "list_price" = "base_price";
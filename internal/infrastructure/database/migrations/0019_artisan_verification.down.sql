@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_artisans_is_verified";
+ALTER TABLE "artisans" DROP COLUMN IF EXISTS "is_insured";
+ALTER TABLE "artisans" DROP COLUMN IF EXISTS "is_verified";
+
+DROP TABLE IF EXISTS "verification_documents";
@@ -0,0 +1,45 @@
+-- Identity documents, licenses and insurance certificates artisans upload for tenant
+-- owners and admins to review, and the verified and insured badges current approved
+-- documents earn on artisan profiles.
+--
+-- Existing artisans start without badges.
+
+CREATE TABLE "verification_documents" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "type" varchar(20) NOT NULL,
+    "reference" varchar(100),
+    "issuer" varchar(200),
+    "file_key" varchar(500) NOT NULL,
+    "file_name" varchar(255) NOT NULL,
+    "content_type" varchar(100),
+    "file_size" bigint,
+    "status" varchar(20) NOT NULL DEFAULT 'pending',
+    "expires_at" timestamptz,
+    "reviewed_by_id" uuid,
+    "reviewed_at" timestamptz,
+    "rejection_reason" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_verification_documents_tenant_id" ON "verification_documents" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_verification_documents_artisan_id" ON "verification_documents" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_verification_documents_status" ON "verification_documents" ("status");
+CREATE INDEX IF NOT EXISTS "idx_verification_documents_expires_at" ON "verification_documents" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_verification_documents_deleted_at" ON "verification_documents" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_verification_documents_updated_at" ON "verification_documents" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_verification_documents_created_at" ON "verification_documents" ("created_at");
+
+ALTER TABLE "verification_documents" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "verification_documents" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "verification_documents"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "artisans" ADD COLUMN "is_verified" boolean DEFAULT false;
+ALTER TABLE "artisans" ADD COLUMN "is_insured" boolean DEFAULT false;
+CREATE INDEX IF NOT EXISTS "idx_artisans_is_verified" ON "artisans" ("is_verified");
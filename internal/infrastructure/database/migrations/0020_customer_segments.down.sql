@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "idx_customers_segmented_at";
+DROP INDEX IF EXISTS "idx_customer_tags";
+DROP INDEX IF EXISTS "idx_customer_segments";
+ALTER TABLE "customers" DROP COLUMN IF EXISTS "segmented_at";
+ALTER TABLE "customers" DROP COLUMN IF EXISTS "tags";
+ALTER TABLE "customers" DROP COLUMN IF EXISTS "segments";
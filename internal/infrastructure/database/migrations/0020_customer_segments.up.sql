@@ -0,0 +1,11 @@
+-- Customer segments (new, repeat, vip, at_risk) recomputed nightly from completed
+-- bookings, and manual tags, both filtering customer and booking lists.
+--
+-- Existing customers are segmented by the first run of the segmentation job.
+
+ALTER TABLE "customers" ADD COLUMN "segments" jsonb;
+ALTER TABLE "customers" ADD COLUMN "tags" jsonb;
+ALTER TABLE "customers" ADD COLUMN "segmented_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_customer_segments" ON "customers" USING gin("segments");
+CREATE INDEX IF NOT EXISTS "idx_customer_tags" ON "customers" USING gin("tags");
+CREATE INDEX IF NOT EXISTS "idx_customers_segmented_at" ON "customers" ("segmented_at");
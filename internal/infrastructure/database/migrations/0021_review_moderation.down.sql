@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "idx_bookings_review_requested_at";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "review_requested_at";
+
+DROP TABLE IF EXISTS "review_flags";
+DROP TABLE IF EXISTS "review_replies";
+
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "contains_profanity";
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "rejection_reason";
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "hold_reason";
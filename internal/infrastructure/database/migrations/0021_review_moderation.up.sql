@@ -0,0 +1,70 @@
+-- Review moderation: tenant-configurable auto-publish rules hold some reviews for a
+-- moderator, profanity is masked, artisans and reviewers reply to each other in a thread
+-- under a review, and reviews can be reported or disputed. Customers are asked for a
+-- review a while after their booking completes.
+--
+-- Existing reviews keep their published state; existing artisan responses are not
+-- copied into threads.
+
+ALTER TABLE "reviews" ADD COLUMN "hold_reason" varchar(30);
+ALTER TABLE "reviews" ADD COLUMN "rejection_reason" text;
+ALTER TABLE "reviews" ADD COLUMN "contains_profanity" boolean DEFAULT false;
+
+CREATE TABLE "review_replies" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "review_id" uuid NOT NULL,
+    "author_id" uuid NOT NULL,
+    "author_role" varchar(20) NOT NULL,
+    "body" text NOT NULL,
+    "contains_profanity" boolean DEFAULT false,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_review_replies_tenant_id" ON "review_replies" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_review_replies_review_id" ON "review_replies" ("review_id");
+CREATE INDEX IF NOT EXISTS "idx_review_replies_deleted_at" ON "review_replies" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_review_replies_updated_at" ON "review_replies" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_review_replies_created_at" ON "review_replies" ("created_at");
+
+ALTER TABLE "review_replies" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "review_replies" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "review_replies"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+CREATE TABLE "review_flags" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "review_id" uuid NOT NULL,
+    "reporter_id" uuid NOT NULL,
+    "kind" varchar(20) NOT NULL,
+    "reason" text NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'open',
+    "resolved_by_id" uuid,
+    "resolved_at" timestamptz,
+    "resolution" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_review_flags_tenant_id" ON "review_flags" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_review_flags_review_id" ON "review_flags" ("review_id");
+CREATE INDEX IF NOT EXISTS "idx_review_flags_status" ON "review_flags" ("status");
+CREATE INDEX IF NOT EXISTS "idx_review_flags_deleted_at" ON "review_flags" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_review_flags_updated_at" ON "review_flags" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_review_flags_created_at" ON "review_flags" ("created_at");
+
+ALTER TABLE "review_flags" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "review_flags" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "review_flags"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "bookings" ADD COLUMN "review_requested_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_bookings_review_requested_at" ON "bookings" ("review_requested_at");
@@ -0,0 +1,2 @@
+ALTER TABLE "services" DROP COLUMN IF EXISTS "review_count";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "rating";
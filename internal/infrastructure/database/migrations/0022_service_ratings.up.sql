@@ -0,0 +1,28 @@
+-- Ratings kept on artisans and services: the average and count of their published
+-- reviews, recalculated in the same transaction as every review change.
+--
+-- Artisans already carry the columns but were never kept up to date, so both tables
+-- are backfilled from the existing published reviews.
+
+ALTER TABLE "services" ADD COLUMN "rating" decimal(3,2) DEFAULT 0;
+ALTER TABLE "services" ADD COLUMN "review_count" bigint DEFAULT 0;
+
+UPDATE "artisans" a SET "rating" = COALESCE(agg.rating, 0), "review_count" = COALESCE(agg.review_count, 0)
+FROM "artisans" x
+LEFT JOIN (
+    SELECT "artisan_id", ROUND(AVG("rating"), 2) AS rating, COUNT(*) AS review_count
+    FROM "reviews"
+    WHERE "is_published" = true AND "deleted_at" IS NULL
+    GROUP BY "artisan_id"
+) agg ON agg.artisan_id = x."user_id"
+WHERE a."id" = x."id";
+
+UPDATE "services" s SET "rating" = COALESCE(agg.rating, 0), "review_count" = COALESCE(agg.review_count, 0)
+FROM "services" x
+LEFT JOIN (
+    SELECT "service_id", ROUND(AVG("rating"), 2) AS rating, COUNT(*) AS review_count
+    FROM "reviews"
+    WHERE "is_published" = true AND "deleted_at" IS NULL
+    GROUP BY "service_id"
+) agg ON agg.service_id = x."id"
+WHERE s."id" = x."id";
@@ -0,0 +1,4 @@
+ALTER TABLE "services" DROP COLUMN IF EXISTS "favorite_count";
+ALTER TABLE "artisans" DROP COLUMN IF EXISTS "favorite_count";
+
+DROP TABLE IF EXISTS "favorites";
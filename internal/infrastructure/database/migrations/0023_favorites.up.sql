@@ -0,0 +1,35 @@
+-- Customer favorites: artisans and services saved to come back to, counted on the
+-- favorited artisan or service. Customers can ask to be notified when a favorite
+-- artisan opens new availability or publishes a new service.
+
+CREATE TABLE "favorites" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "customer_id" uuid NOT NULL,
+    "artisan_id" uuid,
+    "service_id" uuid,
+    "notify" boolean DEFAULT false,
+    "notified_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_favorites_tenant_id" ON "favorites" ("tenant_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_favorite_customer_artisan" ON "favorites" ("customer_id", "artisan_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_favorite_customer_service" ON "favorites" ("customer_id", "service_id");
+CREATE INDEX IF NOT EXISTS "idx_favorites_artisan_id" ON "favorites" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_favorites_service_id" ON "favorites" ("service_id");
+CREATE INDEX IF NOT EXISTS "idx_favorites_deleted_at" ON "favorites" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_favorites_updated_at" ON "favorites" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_favorites_created_at" ON "favorites" ("created_at");
+
+ALTER TABLE "favorites" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "favorites" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "favorites"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "artisans" ADD COLUMN "favorite_count" bigint DEFAULT 0;
+ALTER TABLE "services" ADD COLUMN "favorite_count" bigint DEFAULT 0;
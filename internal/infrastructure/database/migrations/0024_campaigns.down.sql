@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "campaign_recipients";
+DROP TABLE IF EXISTS "campaigns";
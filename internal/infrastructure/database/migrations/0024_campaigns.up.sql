@@ -0,0 +1,72 @@
+-- Marketing campaigns: messages a tenant broadcasts by email or SMS to a customer
+-- audience, sent as marketing through the notification subsystem at a throttled rate,
+-- with a row per recipient recording delivery and email opens.
+
+CREATE TABLE "campaigns" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "created_by" uuid NOT NULL,
+    "name" varchar(255) NOT NULL,
+    "audience" jsonb NOT NULL,
+    "channels" text[] NOT NULL,
+    "subject" varchar(255) NOT NULL,
+    "body" text NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'draft',
+    "scheduled_at" timestamptz,
+    "send_rate" bigint NOT NULL DEFAULT 100,
+    "started_at" timestamptz,
+    "completed_at" timestamptz,
+    "cancelled_at" timestamptz,
+    "recipient_count" bigint DEFAULT 0,
+    "sent_count" bigint DEFAULT 0,
+    "skipped_count" bigint DEFAULT 0,
+    "failed_count" bigint DEFAULT 0,
+    "open_count" bigint DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_campaigns_tenant_id" ON "campaigns" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_campaign_status_schedule" ON "campaigns" ("status", "scheduled_at");
+CREATE INDEX IF NOT EXISTS "idx_campaigns_deleted_at" ON "campaigns" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_campaigns_updated_at" ON "campaigns" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_campaigns_created_at" ON "campaigns" ("created_at");
+
+CREATE TABLE "campaign_recipients" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "campaign_id" uuid NOT NULL,
+    "customer_id" uuid NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'pending',
+    "skip_reason" varchar(50),
+    "notification_id" uuid,
+    "open_token" varchar(64) NOT NULL,
+    "sent_at" timestamptz,
+    "opened_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_campaign_recipients_tenant_id" ON "campaign_recipients" ("tenant_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_campaign_recipient" ON "campaign_recipients" ("campaign_id", "customer_id");
+CREATE INDEX IF NOT EXISTS "idx_campaign_recipient_status" ON "campaign_recipients" ("campaign_id", "status");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_campaign_recipients_open_token" ON "campaign_recipients" ("open_token");
+CREATE INDEX IF NOT EXISTS "idx_campaign_recipients_deleted_at" ON "campaign_recipients" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_campaign_recipients_updated_at" ON "campaign_recipients" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_campaign_recipients_created_at" ON "campaign_recipients" ("created_at");
+
+ALTER TABLE "campaigns" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "campaigns" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "campaigns"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "campaign_recipients" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "campaign_recipients" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "campaign_recipients"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
@@ -0,0 +1,56 @@
+-- Skills taxonomy: each tenant's skills under the service categories, with keywords
+-- service requests are matched by, and the skills artisans are tagged with at a
+-- proficiency. A deleted skill's slug can be reused.
+
+CREATE TABLE "skills" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "category" varchar(50) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "slug" varchar(100) NOT NULL,
+    "description" text,
+    "keywords" jsonb,
+    "is_active" boolean DEFAULT true,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_skill_tenant_slug" ON "skills" ("tenant_id", "slug") WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_skills_category" ON "skills" ("category");
+CREATE INDEX IF NOT EXISTS "idx_skills_deleted_at" ON "skills" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_skills_updated_at" ON "skills" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_skills_created_at" ON "skills" ("created_at");
+
+CREATE TABLE "artisan_skills" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "skill_id" uuid NOT NULL,
+    "proficiency" varchar(20) NOT NULL,
+    "years_experience" bigint DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_artisan_skills_tenant_id" ON "artisan_skills" ("tenant_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_artisan_skill" ON "artisan_skills" ("artisan_id", "skill_id");
+CREATE INDEX IF NOT EXISTS "idx_artisan_skills_skill_id" ON "artisan_skills" ("skill_id");
+CREATE INDEX IF NOT EXISTS "idx_artisan_skills_deleted_at" ON "artisan_skills" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_artisan_skills_updated_at" ON "artisan_skills" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_artisan_skills_created_at" ON "artisan_skills" ("created_at");
+
+ALTER TABLE "skills" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "skills" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "skills"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "artisan_skills" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "artisan_skills" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "artisan_skills"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "customer_request_bids";
+DROP TABLE IF EXISTS "customer_request_leads";
+DROP TABLE IF EXISTS "customer_requests";
@@ -0,0 +1,105 @@
+-- Request-for-quote marketplace: customers' requests for quotes, the leads they are
+-- broadcast as to the artisans matching them, and the artisans' bids. An accepted bid
+-- becomes a booking or a project, which the request links to.
+
+CREATE TABLE "customer_requests" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "customer_id" uuid NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "description" text NOT NULL,
+    "category" varchar(50),
+    "photo_urls" jsonb,
+    "budget_min" decimal(12,2) DEFAULT 0,
+    "budget_max" decimal(12,2) DEFAULT 0,
+    "currency" varchar(3) DEFAULT 'USD',
+    "location" jsonb,
+    "preferred_date" timestamptz,
+    "status" varchar(20) NOT NULL DEFAULT 'open',
+    "expires_at" timestamptz NOT NULL,
+    "lead_count" bigint DEFAULT 0,
+    "bid_count" bigint DEFAULT 0,
+    "awarded_bid_id" uuid,
+    "awarded_at" timestamptz,
+    "project_id" uuid,
+    "booking_id" uuid,
+    "closed_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_customer_request_tenant_status" ON "customer_requests" ("tenant_id", "status");
+CREATE INDEX IF NOT EXISTS "idx_customer_requests_customer_id" ON "customer_requests" ("customer_id");
+CREATE INDEX IF NOT EXISTS "idx_customer_requests_category" ON "customer_requests" ("category");
+CREATE INDEX IF NOT EXISTS "idx_customer_requests_expires_at" ON "customer_requests" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_customer_requests_project_id" ON "customer_requests" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_customer_requests_booking_id" ON "customer_requests" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_customer_requests_deleted_at" ON "customer_requests" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_customer_requests_updated_at" ON "customer_requests" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_customer_requests_created_at" ON "customer_requests" ("created_at");
+
+CREATE TABLE "customer_request_leads" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "request_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "match_score" decimal(4,3) DEFAULT 0,
+    "viewed_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_customer_request_leads_tenant_id" ON "customer_request_leads" ("tenant_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_customer_request_lead" ON "customer_request_leads" ("request_id", "artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_customer_request_leads_artisan_id" ON "customer_request_leads" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_customer_request_leads_deleted_at" ON "customer_request_leads" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_customer_request_leads_updated_at" ON "customer_request_leads" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_customer_request_leads_created_at" ON "customer_request_leads" ("created_at");
+
+CREATE TABLE "customer_request_bids" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 0,
+    "tenant_id" uuid NOT NULL,
+    "request_id" uuid NOT NULL,
+    "artisan_id" uuid NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'submitted',
+    "amount" decimal(12,2) NOT NULL,
+    "currency" varchar(3) DEFAULT 'USD',
+    "message" text,
+    "estimated_days" bigint DEFAULT 0,
+    "service_id" uuid,
+    "proposed_start_time" timestamptz,
+    "duration_minutes" bigint DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_customer_request_bids_tenant_id" ON "customer_request_bids" ("tenant_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_customer_request_bid" ON "customer_request_bids" ("request_id", "artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_customer_request_bids_artisan_id" ON "customer_request_bids" ("artisan_id");
+CREATE INDEX IF NOT EXISTS "idx_customer_request_bids_deleted_at" ON "customer_request_bids" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_customer_request_bids_updated_at" ON "customer_request_bids" ("updated_at");
+CREATE INDEX IF NOT EXISTS "idx_customer_request_bids_created_at" ON "customer_request_bids" ("created_at");
+
+ALTER TABLE "customer_requests" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "customer_requests" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "customer_requests"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "customer_request_leads" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "customer_request_leads" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "customer_request_leads"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
+
+ALTER TABLE "customer_request_bids" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "customer_request_bids" FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON "customer_request_bids"
+    USING (app_current_tenant() IS NULL OR tenant_id = app_current_tenant())
+    WITH CHECK (app_current_tenant() IS NULL OR tenant_id = app_current_tenant());
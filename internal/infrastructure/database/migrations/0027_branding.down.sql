@@ -0,0 +1,5 @@
+ALTER TABLE "white_labels" DROP COLUMN IF EXISTS "email_domain_verified_at";
+ALTER TABLE "white_labels" DROP COLUMN IF EXISTS "dkim_private_key";
+ALTER TABLE "white_labels" DROP COLUMN IF EXISTS "dkim_public_key";
+ALTER TABLE "white_labels" DROP COLUMN IF EXISTS "dkim_selector";
+ALTER TABLE "white_labels" DROP COLUMN IF EXISTS "email_sender_domain";
@@ -0,0 +1,10 @@
+-- The domain a tenant's notification emails are sent from and the DKIM key they are
+-- signed with, whose public half the domain publishes in a TXT record. Emails are only
+-- sent from the domain once the record is verified. The private key is encrypted by
+-- the application.
+
+ALTER TABLE "white_labels" ADD COLUMN "email_sender_domain" varchar(255);
+ALTER TABLE "white_labels" ADD COLUMN "dkim_selector" varchar(63);
+ALTER TABLE "white_labels" ADD COLUMN "dkim_public_key" text;
+ALTER TABLE "white_labels" ADD COLUMN "dkim_private_key" text;
+ALTER TABLE "white_labels" ADD COLUMN "email_domain_verified_at" timestamptz;
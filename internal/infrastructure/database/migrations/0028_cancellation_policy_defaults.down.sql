@@ -0,0 +1,3 @@
+ALTER TABLE "cancellation_policies" ALTER COLUMN "is_active" SET DEFAULT true;
+ALTER TABLE "cancellation_policies" ALTER COLUMN "no_show_fee_percentage" SET DEFAULT 100;
+ALTER TABLE "cancellation_policies" ALTER COLUMN "free_cancellation_hours" SET DEFAULT 24;
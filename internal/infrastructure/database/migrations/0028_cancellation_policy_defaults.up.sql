@@ -0,0 +1,7 @@
+-- Cancellation policy settings lose their column defaults: the application fills in
+-- the settings a new policy leaves out, and GORM would otherwise write the defaults
+-- over a 0% no-show fee, zero free cancellation hours or an inactive policy.
+
+ALTER TABLE "cancellation_policies" ALTER COLUMN "free_cancellation_hours" DROP DEFAULT;
+ALTER TABLE "cancellation_policies" ALTER COLUMN "no_show_fee_percentage" DROP DEFAULT;
+ALTER TABLE "cancellation_policies" ALTER COLUMN "is_active" DROP DEFAULT;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_bookings_refund_status";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "refund_failure_reason";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "refund_issued";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "refund_amount";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "refund_status";
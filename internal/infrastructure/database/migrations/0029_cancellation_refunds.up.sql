@@ -0,0 +1,10 @@
+-- Cancellation refunds: the refund a cancellation is owed is saved with the cancellation
+-- and issued afterwards, so a refund that fails is recorded and can be retried.
+--
+-- Existing cancellations have no refund recorded.
+
+ALTER TABLE "bookings" ADD COLUMN "refund_status" varchar(20);
+ALTER TABLE "bookings" ADD COLUMN "refund_amount" decimal(10,2) DEFAULT 0;
+ALTER TABLE "bookings" ADD COLUMN "refund_issued" decimal(10,2) DEFAULT 0;
+ALTER TABLE "bookings" ADD COLUMN "refund_failure_reason" text;
+CREATE INDEX IF NOT EXISTS "idx_bookings_refund_status" ON "bookings" ("refund_status");
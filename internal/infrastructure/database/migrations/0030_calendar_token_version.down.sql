@@ -0,0 +1 @@
+ALTER TABLE "artisans" DROP COLUMN IF EXISTS "calendar_token_version";
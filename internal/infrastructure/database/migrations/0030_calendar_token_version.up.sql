@@ -0,0 +1,7 @@
+-- Calendar feed token versions: an artisan's feed token signs the version, so rotating
+-- it revokes subscription URLs that were shared.
+--
+-- Existing artisans start at version 1; their current feed URLs stop working and are
+-- replaced by the ones GET /artisans/:id/calendar now returns.
+
+ALTER TABLE "artisans" ADD COLUMN "calendar_token_version" integer NOT NULL DEFAULT 1;
@@ -0,0 +1,634 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// MigrationsDir is where migration files live, relative to this package
+const MigrationsDir = "migrations"
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// migrationFilePattern matches migration files such as 0002_link_bookings.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// noTransactionDirective on the first line of a migration file runs it outside a
+// transaction, which statements such as CREATE INDEX CONCURRENTLY require
+const noTransactionDirective = "-- migrate:no-transaction"
+
+// migrationLockKey is the advisory lock that keeps two migrators from running at once
+const migrationLockKey int64 = 827_301_323
+
+// legacyMigrationTable is where AutoMigrate runs were recorded before versioned migrations
+const legacyMigrationTable = "schema_migrations"
+
+// Migration is a versioned schema change with the SQL that applies and reverses it
+type Migration struct {
+	Version  int64
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string // SHA-256 of the up and down SQL
+}
+
+// SchemaVersion records an applied migration
+type SchemaVersion struct {
+	Version     int64
+	Name        string
+	Checksum    string
+	AppliedAt   time.Time
+	ExecutionMs int64
+}
+
+// TableName specifies the table name for SchemaVersion
+func (SchemaVersion) TableName() string {
+	return "schema_versions"
+}
+
+// MigrationStatus describes a migration and whether it has been applied
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Modified  bool // Applied, but the file has changed since
+	Missing   bool // Applied, but the file is no longer shipped
+}
+
+// MigrationPlan is what an up or down run would do
+type MigrationPlan struct {
+	Direction string // "up" or "down"
+	Steps     []PlannedMigration
+	Changes   []SchemaChange // Schema changes the rehearsal made
+	Simulated bool           // False if a step could not be rehearsed, so Changes stop short of it
+}
+
+// PlannedMigration is a migration in a plan with its SQL split into statements
+type PlannedMigration struct {
+	Migration
+	Statements    []string
+	Transactional bool
+}
+
+// Migrator applies and reverses the embedded migrations, recording each one in
+// schema_versions with the checksum of its files
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	logger     *zap.Logger
+	force      bool
+	dryRun     bool
+}
+
+// NewMigrator creates a migrator for the embedded migrations. Force lets it run even
+// though applied migrations have been edited or removed since; DryRun makes Baseline
+// only report what it would record. PlanUp and PlanDown are the dry runs of Up and Down.
+func NewMigrator(db *gorm.DB, config MigrationConfig) (*Migrator, error) {
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required for migrations")
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	migrations, err := EmbeddedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         sqlDB,
+		migrations: migrations,
+		logger:     config.Logger,
+		force:      config.Force,
+		dryRun:     config.DryRun,
+	}, nil
+}
+
+// EmbeddedMigrations returns the migrations built into the binary, ordered by version
+func EmbeddedMigrations() ([]Migration, error) {
+	return LoadMigrations(embeddedMigrations, MigrationsDir)
+}
+
+// LoadMigrations reads the up and down migration files in dir, ordered by version
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	downs := make(map[int64]bool)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file %s must be named <version>_<name>.up.sql or <version>_<name>.down.sql", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration file %s has an invalid version", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			if migration.UpSQL != "" {
+				return nil, fmt.Errorf("migration version %d has more than one up file", version)
+			}
+			migration.UpSQL = string(content)
+		} else {
+			if downs[version] {
+				return nil, fmt.Errorf("migration version %d has more than one down file", version)
+			}
+			downs[version] = true
+			migration.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version, migration := range byVersion {
+		if strings.TrimSpace(migration.UpSQL) == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up SQL", version, migration.Name)
+		}
+		if !downs[version] {
+			return nil, fmt.Errorf("migration %d_%s has no down file", version, migration.Name)
+		}
+		migration.Checksum = migrationChecksum(migration.UpSQL, migration.DownSQL)
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// migrationChecksum hashes both directions so an edited rollback is noticed too
+func migrationChecksum(upSQL, downSQL string) string {
+	hash := sha256.New()
+	hash.Write([]byte(upSQL))
+	hash.Write([]byte{0})
+	hash.Write([]byte(downSQL))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Migrations returns the known migrations, oldest first
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up applies every pending migration in version order, each in its own transaction
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		pending, err := m.pendingUp(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range pending {
+			m.logger.Info("applying migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+			start := time.Now()
+			if err := m.run(ctx, conn, migration.UpSQL, func(exec execer) error {
+				_, err := exec.ExecContext(ctx,
+					"INSERT INTO schema_versions (version, name, checksum, applied_at, execution_ms) VALUES ($1, $2, $3, $4, $5)",
+					migration.Version, migration.Name, migration.Checksum, time.Now().UTC(), time.Since(start).Milliseconds(),
+				)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverses the last steps applied migrations, newest first
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		pending, err := m.pendingDown(ctx, conn, steps)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range pending {
+			m.logger.Warn("reversing migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+			if err := m.run(ctx, conn, migration.DownSQL, func(exec execer) error {
+				_, err := exec.ExecContext(ctx, "DELETE FROM schema_versions WHERE version = $1", migration.Version)
+				return err
+			}); err != nil {
+				return fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// PlanUp shows what Up would do: the statements of each pending migration and the
+// schema changes they make. The migrations run inside a transaction that is rolled
+// back, so the plan also proves they apply cleanly; tables they alter stay locked
+// while it runs.
+func (m *Migrator) PlanUp(ctx context.Context) (*MigrationPlan, error) {
+	var plan *MigrationPlan
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		pending, err := m.pendingUp(ctx, conn)
+		if err != nil {
+			return err
+		}
+		plan, err = m.simulate(ctx, conn, "up", pending)
+		return err
+	})
+	return plan, err
+}
+
+// PlanDown shows what Down would do, simulated the same way as PlanUp
+func (m *Migrator) PlanDown(ctx context.Context, steps int) (*MigrationPlan, error) {
+	var plan *MigrationPlan
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		pending, err := m.pendingDown(ctx, conn, steps)
+		if err != nil {
+			return err
+		}
+		plan, err = m.simulate(ctx, conn, "down", pending)
+		return err
+	})
+	return plan, err
+}
+
+// pendingUp returns the migrations Up would apply, in order
+func (m *Migrator) pendingUp(ctx context.Context, conn *sql.Conn) ([]Migration, error) {
+	versions, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		legacy, err := tableExists(ctx, conn, legacyMigrationTable)
+		if err != nil {
+			return nil, err
+		}
+		if legacy {
+			return nil, fmt.Errorf("the database was created by AutoMigrate and has no migration history; run the baseline action once before migrating")
+		}
+	}
+	if err := m.verify(versions); err != nil {
+		return nil, err
+	}
+
+	var latest int64
+	for version := range versions {
+		latest = max(latest, version)
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := versions[migration.Version]; ok {
+			continue
+		}
+		if migration.Version < latest {
+			return nil, fmt.Errorf("migration %d_%s is older than the applied version %d; renumber it after the latest migration", migration.Version, migration.Name, latest)
+		}
+		pending = append(pending, migration)
+	}
+	return pending, nil
+}
+
+// pendingDown returns the migrations Down would reverse, newest first
+func (m *Migrator) pendingDown(ctx context.Context, conn *sql.Conn, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1")
+	}
+
+	versions, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verify(versions); err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+	applied := make([]int64, 0, len(versions))
+	for version := range versions {
+		applied = append(applied, version)
+	}
+	sort.Slice(applied, func(i, j int) bool { return applied[i] > applied[j] })
+
+	var pending []Migration
+	for _, version := range applied[:min(steps, len(applied))] {
+		migration, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("migration %d is applied but its files are missing, so it cannot be reversed", version)
+		}
+		pending = append(pending, migration)
+	}
+	return pending, nil
+}
+
+// simulate runs the migrations in a transaction that is always rolled back and diffs
+// the schema before and after
+func (m *Migrator) simulate(ctx context.Context, conn *sql.Conn, direction string, migrations []Migration) (*MigrationPlan, error) {
+	plan := &MigrationPlan{Direction: direction, Simulated: true}
+	for _, migration := range migrations {
+		statements := migration.UpSQL
+		if direction == "down" {
+			statements = migration.DownSQL
+		}
+		plan.Steps = append(plan.Steps, PlannedMigration{
+			Migration:     migration,
+			Statements:    SplitStatements(statements),
+			Transactional: !strings.HasPrefix(strings.TrimSpace(statements), noTransactionDirective),
+		})
+	}
+	if len(plan.Steps) == 0 {
+		return plan, nil
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotSchema(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range plan.Steps {
+		// Statements that refuse to run in a transaction cannot be rehearsed, and the
+		// migrations after them may depend on them
+		if !step.Transactional {
+			plan.Simulated = false
+			break
+		}
+		statements := step.UpSQL
+		if direction == "down" {
+			statements = step.DownSQL
+		}
+		if _, err := tx.ExecContext(ctx, statements); err != nil {
+			return nil, fmt.Errorf("migration %d_%s would fail: %w", step.Version, step.Name, err)
+		}
+	}
+	after, err := snapshotSchema(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	plan.Changes = DiffSchemas(before, after)
+
+	return plan, nil
+}
+
+// Status lists every known or applied migration, oldest first
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	versions := map[int64]SchemaVersion{}
+	exists, err := tableExists(ctx, conn, SchemaVersion{}.TableName())
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if versions, err = m.appliedVersions(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := versions[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Modified = record.Checksum != migration.Checksum
+			delete(versions, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range versions {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// Baseline records the first migration as applied without running it, for databases
+// whose schema AutoMigrate created before versioned migrations existed. The database
+// must be on the schema of the last AutoMigrate release.
+func (m *Migrator) Baseline(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return fmt.Errorf("there are no migrations to baseline")
+	}
+	baseline := m.migrations[0]
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(versions) > 0 {
+			return fmt.Errorf("the database already has a migration history")
+		}
+		exists, err := tableExists(ctx, conn, "tenants")
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("the database has no schema to baseline; run the up action instead")
+		}
+
+		if m.dryRun {
+			m.logger.Info("dry-run: baseline would be recorded", zap.Int64("version", baseline.Version))
+			return nil
+		}
+
+		// The legacy table only listed AutoMigrate runs, which the baseline now stands for
+		return m.run(ctx, conn, "DROP TABLE IF EXISTS "+legacyMigrationTable, func(exec execer) error {
+			_, err := exec.ExecContext(ctx,
+				"INSERT INTO schema_versions (version, name, checksum, applied_at, execution_ms) VALUES ($1, $2, $3, $4, 0)",
+				baseline.Version, baseline.Name, baseline.Checksum, time.Now().UTC(),
+			)
+			return err
+		})
+	})
+}
+
+// verify checks that applied migrations still match their files
+func (m *Migrator) verify(versions map[int64]SchemaVersion) error {
+	checksums := make(map[int64]string, len(m.migrations))
+	for _, migration := range m.migrations {
+		checksums[migration.Version] = migration.Checksum
+	}
+
+	for version, record := range versions {
+		checksum, ok := checksums[version]
+		var problem string
+		switch {
+		case !ok:
+			problem = "is applied but no longer shipped"
+		case checksum != record.Checksum:
+			problem = "has been modified since it was applied"
+		default:
+			continue
+		}
+
+		if !m.force {
+			return fmt.Errorf("migration %d_%s %s; restore the original files or run with force", version, record.Name, problem)
+		}
+		m.logger.Warn("migration verification failed, continuing because force is set",
+			zap.Int64("version", version),
+			zap.String("name", record.Name),
+			zap.String("problem", problem),
+		)
+	}
+	return nil
+}
+
+// execer is satisfied by both *sql.Conn and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// run executes migration SQL and the schema_versions bookkeeping together, in one
+// transaction unless the SQL opts out
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, statements string, record func(execer) error) error {
+	if strings.HasPrefix(strings.TrimSpace(statements), noTransactionDirective) {
+		if _, err := conn.ExecContext(ctx, statements); err != nil {
+			return err
+		}
+		return record(conn)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, statements); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := record(tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+	return tx.Commit()
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock, with
+// the schema_versions table in place
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Migrations may run longer than the statement_timeout the application connects with
+	if _, err := conn.ExecContext(ctx, "SET statement_timeout = 0"); err != nil {
+		return fmt.Errorf("failed to lift statement timeout: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "RESET statement_timeout"); err != nil {
+			m.logger.Warn("failed to restore statement timeout", zap.Error(err))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			m.logger.Warn("failed to release migration lock", zap.Error(err))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_versions (
+		version bigint PRIMARY KEY,
+		name varchar(255) NOT NULL,
+		checksum varchar(64) NOT NULL,
+		applied_at timestamptz NOT NULL,
+		execution_ms bigint NOT NULL DEFAULT 0
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_versions table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions loads the schema_versions records by version
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]SchemaVersion, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at, execution_ms FROM schema_versions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]SchemaVersion)
+	for rows.Next() {
+		var record SchemaVersion
+		if err := rows.Scan(&record.Version, &record.Name, &record.Checksum, &record.AppliedAt, &record.ExecutionMs); err != nil {
+			return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+		versions[record.Version] = record
+	}
+	return versions, rows.Err()
+}
+
+// tableExists reports whether a table exists in the current schema
+func tableExists(ctx context.Context, conn *sql.Conn, table string) (bool, error) {
+	var exists bool
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check for table %s: %w", table, err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,75 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"Krafti_Vibe/internal/infrastructure/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	files := fstest.MapFS{
+		"m/0002_add_notes.up.sql":   {Data: []byte("ALTER TABLE bookings ADD COLUMN notes text;")},
+		"m/0002_add_notes.down.sql": {Data: []byte("ALTER TABLE bookings DROP COLUMN notes;")},
+		"m/0001_baseline.up.sql":    {Data: []byte("CREATE TABLE bookings (id uuid);")},
+		"m/0001_baseline.down.sql":  {Data: []byte("DROP TABLE bookings;")},
+		"m/README.md":               {Data: []byte("not a migration")},
+	}
+
+	migrations, err := database.LoadMigrations(files, "m")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "baseline", migrations[0].Name)
+	assert.Equal(t, "add_notes", migrations[1].Name)
+	assert.Equal(t, "ALTER TABLE bookings DROP COLUMN notes;", migrations[1].DownSQL)
+	assert.Len(t, migrations[0].Checksum, 64)
+
+	t.Run("checksum covers the down SQL", func(t *testing.T) {
+		files["m/0002_add_notes.down.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE bookings DROP COLUMN IF EXISTS notes;")}
+		edited, err := database.LoadMigrations(files, "m")
+		require.NoError(t, err)
+		assert.Equal(t, migrations[0].Checksum, edited[0].Checksum)
+		assert.NotEqual(t, migrations[1].Checksum, edited[1].Checksum)
+	})
+
+	cases := map[string]fstest.MapFS{
+		"missing down": {
+			"m/0001_baseline.up.sql": {Data: []byte("CREATE TABLE bookings (id uuid);")},
+		},
+		"bad name": {
+			"m/baseline.up.sql":   {Data: []byte("CREATE TABLE bookings (id uuid);")},
+			"m/baseline.down.sql": {Data: []byte("DROP TABLE bookings;")},
+		},
+		"version reused": {
+			"m/0001_baseline.up.sql":   {Data: []byte("CREATE TABLE bookings (id uuid);")},
+			"m/0001_baseline.down.sql": {Data: []byte("DROP TABLE bookings;")},
+			"m/0001_other.up.sql":      {Data: []byte("SELECT 1;")},
+		},
+		"empty up": {
+			"m/0001_baseline.up.sql":   {Data: []byte("  \n")},
+			"m/0001_baseline.down.sql": {Data: []byte("DROP TABLE bookings;")},
+		},
+	}
+	for name, files := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := database.LoadMigrations(files, "m")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestShippedMigrations(t *testing.T) {
+	migrations, err := database.LoadMigrations(os.DirFS("."), database.MigrationsDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, "baseline", migrations[0].Name)
+
+	for i, migration := range migrations {
+		assert.Equal(t, int64(i+1), migration.Version, "migration versions must be consecutive")
+	}
+}
@@ -0,0 +1,184 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrPoolSaturated reports that the connection pool is all but used up, so requests
+// are, or soon will be, waiting for connections
+var ErrPoolSaturated = errors.New("database connection pool saturated")
+
+// poolShrinkAfter is how many quiet samples in a row it takes to shrink a grown pool
+const poolShrinkAfter = 4
+
+// PoolConfig sizes the connection pool and sets how it is watched
+type PoolConfig struct {
+	MaxOpenConns      int           // Size the pool starts at and shrinks back to
+	MaxOpenConnsLimit int           // Size the pool may grow to while saturated; at or below MaxOpenConns the pool is fixed
+	Interval          time.Duration // How often stats are sampled
+	SaturationPercent int           // Share of the pool in use at which it is saturated
+}
+
+// PoolSample is what the monitor saw at one sample
+type PoolSample struct {
+	Stats           sql.DBStats
+	NewWaits        int64         // Requests that waited for a connection since the last sample
+	NewWaitDuration time.Duration // Time they spent waiting
+	Saturated       bool
+}
+
+// PoolStatsSource reads a pool's stats and resizes it; *sql.DB is one
+type PoolStatsSource interface {
+	Stats() sql.DBStats
+	SetMaxOpenConns(n int)
+}
+
+// PoolMonitor samples connection pool stats, logs when requests wait for connections,
+// reports saturation for health checks and, when allowed to, grows the pool while it
+// is saturated and shrinks it back once quiet
+type PoolMonitor struct {
+	pool   PoolStatsSource
+	config PoolConfig
+	logger *zap.Logger
+
+	mu        sync.RWMutex
+	last      PoolSample
+	size      int
+	quiet     int
+	observers []func(PoolSample)
+}
+
+// NewPoolMonitor creates a monitor of pool
+func NewPoolMonitor(pool PoolStatsSource, config PoolConfig, logger *zap.Logger) *PoolMonitor {
+	if config.Interval <= 0 {
+		config.Interval = 15 * time.Second
+	}
+	if config.SaturationPercent <= 0 || config.SaturationPercent > 100 {
+		config.SaturationPercent = 90
+	}
+	return &PoolMonitor{
+		pool:   pool,
+		config: config,
+		logger: logger,
+		size:   config.MaxOpenConns,
+	}
+}
+
+// OnSample registers a function called with every sample, e.g. to export metrics
+func (m *PoolMonitor) OnSample(observer func(PoolSample)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observers = append(m.observers, observer)
+}
+
+// Run samples the pool until ctx is done
+func (m *PoolMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	m.Sample()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Sample()
+		}
+	}
+}
+
+// Sample reads the pool's stats, logs exhaustion, resizes the pool if needed and
+// hands the sample to the observers
+func (m *PoolMonitor) Sample() PoolSample {
+	stats := m.pool.Stats()
+
+	m.mu.Lock()
+	sample := PoolSample{
+		Stats:           stats,
+		NewWaits:        max(0, stats.WaitCount-m.last.Stats.WaitCount),
+		NewWaitDuration: max(0, stats.WaitDuration-m.last.Stats.WaitDuration),
+	}
+	sample.Saturated = sample.NewWaits > 0 || m.inUsePercent(stats) >= m.config.SaturationPercent
+	m.last = sample
+	resized := m.resize(sample)
+	observers := m.observers
+	m.mu.Unlock()
+
+	if sample.NewWaits > 0 {
+		m.logger.Warn("database connection pool exhausted, requests waited for a connection",
+			zap.Int64("waits", sample.NewWaits),
+			zap.Duration("wait_duration", sample.NewWaitDuration),
+			zap.Int("in_use", stats.InUse),
+			zap.Int("max_open", stats.MaxOpenConnections),
+		)
+	}
+	if resized != 0 {
+		m.logger.Info("database connection pool resized",
+			zap.Int("from", stats.MaxOpenConnections),
+			zap.Int("to", resized),
+		)
+	}
+
+	for _, observe := range observers {
+		observe(sample)
+	}
+	return sample
+}
+
+// Last returns the latest sample
+func (m *PoolMonitor) Last() PoolSample {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last
+}
+
+// Check fails with ErrPoolSaturated while the latest sample found the pool saturated
+func (m *PoolMonitor) Check() error {
+	if m.Last().Saturated {
+		return ErrPoolSaturated
+	}
+	return nil
+}
+
+// resize grows a saturated pool by a quarter, up to the limit, and shrinks a grown
+// pool by a quarter, down to its configured size, after it has been quiet for a while.
+// It returns the new size, or 0 when the size is unchanged.
+func (m *PoolMonitor) resize(sample PoolSample) int {
+	if m.config.MaxOpenConnsLimit <= m.config.MaxOpenConns {
+		return 0
+	}
+
+	size := m.size
+	if sample.Saturated {
+		m.quiet = 0
+		size = min(m.config.MaxOpenConnsLimit, m.size+max(1, m.size/4))
+	} else if m.size > m.config.MaxOpenConns && m.inUsePercent(sample.Stats) < m.config.SaturationPercent/2 {
+		m.quiet++
+		if m.quiet >= poolShrinkAfter {
+			m.quiet = 0
+			size = max(m.config.MaxOpenConns, m.size-max(1, m.size/4))
+		}
+	} else {
+		m.quiet = 0
+	}
+
+	if size == m.size {
+		return 0
+	}
+	m.size = size
+	m.pool.SetMaxOpenConns(size)
+	return size
+}
+
+func (m *PoolMonitor) inUsePercent(stats sql.DBStats) int {
+	if stats.MaxOpenConnections <= 0 {
+		return 0 // Unlimited pools never saturate
+	}
+	return stats.InUse * 100 / stats.MaxOpenConnections
+}
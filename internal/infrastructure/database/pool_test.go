@@ -0,0 +1,87 @@
+package database_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/infrastructure/database"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakePool is a pool whose stats the test sets
+type fakePool struct {
+	stats sql.DBStats
+}
+
+func (p *fakePool) Stats() sql.DBStats {
+	return p.stats
+}
+
+func (p *fakePool) SetMaxOpenConns(n int) {
+	p.stats.MaxOpenConnections = n
+}
+
+func TestPoolMonitor_Saturation(t *testing.T) {
+	pool := &fakePool{stats: sql.DBStats{MaxOpenConnections: 10, InUse: 3}}
+	monitor := database.NewPoolMonitor(pool, database.PoolConfig{MaxOpenConns: 10, SaturationPercent: 90}, zap.NewNop())
+
+	var samples []database.PoolSample
+	monitor.OnSample(func(sample database.PoolSample) { samples = append(samples, sample) })
+
+	assert.False(t, monitor.Sample().Saturated)
+	assert.NoError(t, monitor.Check())
+
+	pool.stats.InUse = 9
+	assert.True(t, monitor.Sample().Saturated, "90% in use")
+	assert.ErrorIs(t, monitor.Check(), database.ErrPoolSaturated)
+
+	// Waiting requests saturate the pool however little of it is in use now
+	pool.stats.InUse = 2
+	pool.stats.WaitCount = 4
+	pool.stats.WaitDuration = 2 * time.Second
+	sample := monitor.Sample()
+	assert.True(t, sample.Saturated)
+	assert.Equal(t, int64(4), sample.NewWaits)
+	assert.Equal(t, 2*time.Second, sample.NewWaitDuration)
+
+	// Only waits since the previous sample count
+	sample = monitor.Sample()
+	assert.False(t, sample.Saturated)
+	assert.Zero(t, sample.NewWaits)
+
+	assert.Len(t, samples, 4)
+	assert.Equal(t, 10, pool.stats.MaxOpenConnections, "a fixed pool is never resized")
+}
+
+func TestPoolMonitor_AdaptiveSizing(t *testing.T) {
+	pool := &fakePool{stats: sql.DBStats{MaxOpenConnections: 20, InUse: 20}}
+	monitor := database.NewPoolMonitor(pool, database.PoolConfig{MaxOpenConns: 20, MaxOpenConnsLimit: 30, SaturationPercent: 90}, zap.NewNop())
+
+	monitor.Sample()
+	assert.Equal(t, 25, pool.stats.MaxOpenConnections, "grows by a quarter")
+
+	pool.stats.InUse = 25
+	monitor.Sample()
+	assert.Equal(t, 30, pool.stats.MaxOpenConnections, "up to the limit")
+
+	pool.stats.InUse = 30
+	monitor.Sample()
+	assert.Equal(t, 30, pool.stats.MaxOpenConnections)
+
+	// Shrinks back only after staying quiet
+	pool.stats.InUse = 2
+	for range 3 {
+		monitor.Sample()
+	}
+	assert.Equal(t, 30, pool.stats.MaxOpenConnections)
+	monitor.Sample()
+	assert.Equal(t, 23, pool.stats.MaxOpenConnections)
+
+	for range 8 {
+		monitor.Sample()
+	}
+	assert.Equal(t, 20, pool.stats.MaxOpenConnections, "never below the configured size")
+}
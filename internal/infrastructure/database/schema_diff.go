@@ -0,0 +1,327 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaSnapshot is the shape of the current schema: its tables, columns, indexes,
+// constraints and row-level security policies
+type SchemaSnapshot struct {
+	Tables      map[string]map[string]ColumnDefinition // Table -> column -> definition
+	Indexes     map[string]string                      // Index -> CREATE INDEX statement
+	Constraints map[string]string                      // "table.constraint" -> definition
+	Policies    map[string]string                      // "table.policy" -> expressions
+}
+
+// ColumnDefinition describes a column as Postgres reports it
+type ColumnDefinition struct {
+	Type    string
+	NotNull bool
+	Default string
+}
+
+// String renders the column the way it would appear in CREATE TABLE
+func (c ColumnDefinition) String() string {
+	definition := c.Type
+	if c.NotNull {
+		definition += " NOT NULL"
+	}
+	if c.Default != "" {
+		definition += " DEFAULT " + c.Default
+	}
+	return definition
+}
+
+// SchemaChange is one difference between two schema snapshots
+type SchemaChange struct {
+	Action string // "add", "drop" or "alter"
+	Kind   string // "table", "column", "index", "constraint" or "policy"
+	Name   string
+	Detail string
+}
+
+// String renders the change as a line of a diff
+func (c SchemaChange) String() string {
+	marker := map[string]string{"add": "+", "drop": "-", "alter": "~"}[c.Action]
+	line := fmt.Sprintf("%s %s %s", marker, c.Kind, c.Name)
+	if c.Detail != "" {
+		line += ": " + c.Detail
+	}
+	return line
+}
+
+// queryer is satisfied by *sql.DB, *sql.Conn and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// snapshotSchema reads the tables, indexes, constraints and policies of the current
+// schema
+func snapshotSchema(ctx context.Context, db queryer) (*SchemaSnapshot, error) {
+	snapshot := &SchemaSnapshot{
+		Tables:      make(map[string]map[string]ColumnDefinition),
+		Indexes:     make(map[string]string),
+		Constraints: make(map[string]string),
+		Policies:    make(map[string]string),
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT cls.relname, att.attname, format_type(att.atttypid, att.atttypmod), att.attnotnull,
+			COALESCE(pg_get_expr(def.adbin, def.adrelid), '')
+		FROM pg_attribute att
+		JOIN pg_class cls ON cls.oid = att.attrelid
+		JOIN pg_namespace ns ON ns.oid = cls.relnamespace
+		LEFT JOIN pg_attrdef def ON def.adrelid = att.attrelid AND def.adnum = att.attnum
+		WHERE ns.nspname = current_schema() AND cls.relkind IN ('r', 'p')
+			AND att.attnum > 0 AND NOT att.attisdropped`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	for rows.Next() {
+		var table, column string
+		var definition ColumnDefinition
+		if err := rows.Scan(&table, &column, &definition.Type, &definition.NotNull, &definition.Default); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to read columns: %w", err)
+		}
+		if snapshot.Tables[table] == nil {
+			snapshot.Tables[table] = make(map[string]ColumnDefinition)
+		}
+		snapshot.Tables[table][column] = definition
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	if err := scanPairs(ctx, db, snapshot.Indexes,
+		"SELECT indexname, indexdef FROM pg_indexes WHERE schemaname = current_schema()"); err != nil {
+		return nil, fmt.Errorf("failed to read indexes: %w", err)
+	}
+	if err := scanPairs(ctx, db, snapshot.Constraints, `
+		SELECT cls.relname || '.' || con.conname, pg_get_constraintdef(con.oid)
+		FROM pg_constraint con
+		JOIN pg_class cls ON cls.oid = con.conrelid
+		JOIN pg_namespace ns ON ns.oid = con.connamespace
+		WHERE ns.nspname = current_schema()`); err != nil {
+		return nil, fmt.Errorf("failed to read constraints: %w", err)
+	}
+	if err := scanPairs(ctx, db, snapshot.Policies, `
+		SELECT tablename || '.' || policyname,
+			'USING (' || COALESCE(qual, '') || ') WITH CHECK (' || COALESCE(with_check, '') || ')'
+		FROM pg_policies
+		WHERE schemaname = current_schema()`); err != nil {
+		return nil, fmt.Errorf("failed to read policies: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// scanPairs reads a two-column query into a map
+func scanPairs(ctx context.Context, db queryer, into map[string]string, query string) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return err
+		}
+		into[key] = value
+	}
+	return rows.Err()
+}
+
+// DiffSchemas lists what changed between two snapshots. New or dropped tables are
+// reported once rather than column by column, and the indexes, constraints and
+// policies that go with them are left out.
+func DiffSchemas(before, after *SchemaSnapshot) []SchemaChange {
+	var changes []SchemaChange
+
+	for _, table := range unionKeys(before.Tables, after.Tables) {
+		oldColumns, hadTable := before.Tables[table]
+		newColumns, hasTable := after.Tables[table]
+		switch {
+		case !hadTable:
+			changes = append(changes, SchemaChange{Action: "add", Kind: "table", Name: table, Detail: fmt.Sprintf("%d columns", len(newColumns))})
+		case !hasTable:
+			changes = append(changes, SchemaChange{Action: "drop", Kind: "table", Name: table})
+		default:
+			for _, column := range unionKeys(oldColumns, newColumns) {
+				oldColumn, hadColumn := oldColumns[column]
+				newColumn, hasColumn := newColumns[column]
+				name := table + "." + column
+				switch {
+				case !hadColumn:
+					changes = append(changes, SchemaChange{Action: "add", Kind: "column", Name: name, Detail: newColumn.String()})
+				case !hasColumn:
+					changes = append(changes, SchemaChange{Action: "drop", Kind: "column", Name: name})
+				case oldColumn != newColumn:
+					changes = append(changes, SchemaChange{Action: "alter", Kind: "column", Name: name, Detail: oldColumn.String() + " -> " + newColumn.String()})
+				}
+			}
+		}
+	}
+
+	// Indexes, constraints and policies of a table that only one side has come and go
+	// with it
+	tableOnOneSide := func(table string) bool {
+		_, hadTable := before.Tables[table]
+		_, hasTable := after.Tables[table]
+		return hadTable != hasTable
+	}
+	changes = append(changes, diffDefinitions("index", before.Indexes, after.Indexes, func(name, definition string) bool {
+		return tableOnOneSide(indexTable(definition))
+	})...)
+	onTableOnOneSide := func(name, definition string) bool {
+		return tableOnOneSide(strings.SplitN(name, ".", 2)[0])
+	}
+	changes = append(changes, diffDefinitions("constraint", before.Constraints, after.Constraints, onTableOnOneSide)...)
+	changes = append(changes, diffDefinitions("policy", before.Policies, after.Policies, onTableOnOneSide)...)
+
+	return changes
+}
+
+// diffDefinitions compares named definitions, skipping the ones skip reports
+func diffDefinitions(kind string, before, after map[string]string, skip func(name, definition string) bool) []SchemaChange {
+	var changes []SchemaChange
+	for _, name := range unionKeys(before, after) {
+		oldDefinition, had := before[name]
+		newDefinition, has := after[name]
+		switch {
+		case !had:
+			if !skip(name, newDefinition) {
+				changes = append(changes, SchemaChange{Action: "add", Kind: kind, Name: name, Detail: newDefinition})
+			}
+		case !has:
+			if !skip(name, oldDefinition) {
+				changes = append(changes, SchemaChange{Action: "drop", Kind: kind, Name: name})
+			}
+		case oldDefinition != newDefinition:
+			changes = append(changes, SchemaChange{Action: "alter", Kind: kind, Name: name, Detail: oldDefinition + " -> " + newDefinition})
+		}
+	}
+	return changes
+}
+
+// indexTable extracts the table from a CREATE INDEX statement as pg_indexes reports it
+func indexTable(definition string) string {
+	_, rest, found := strings.Cut(definition, " ON ")
+	if !found {
+		return ""
+	}
+	rest = strings.TrimPrefix(rest, "ONLY ")
+	table, _, _ := strings.Cut(rest, " ")
+	if _, name, qualified := strings.Cut(table, "."); qualified {
+		table = name
+	}
+	return strings.Trim(table, `"`)
+}
+
+// unionKeys returns the keys of both maps, sorted
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	keys := make([]string, 0, len(a)+len(b))
+	for _, m := range []map[string]V{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SplitStatements splits migration SQL into its statements, without the trailing
+// semicolons. Semicolons inside quotes, comments and dollar-quoted bodies do not end
+// a statement, and pieces holding only comments are dropped.
+func SplitStatements(script string) []string {
+	var statements []string
+	start := 0
+	hasCode := false
+
+	flush := func(end int) {
+		if hasCode {
+			statements = append(statements, strings.TrimSpace(script[start:end]))
+		}
+		hasCode = false
+	}
+
+	for i := 0; i < len(script); i++ {
+		switch ch := script[i]; {
+		case ch == '-' && strings.HasPrefix(script[i:], "--"):
+			if end := strings.IndexByte(script[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(script)
+			}
+			if !hasCode {
+				start = i
+			}
+		case ch == '/' && strings.HasPrefix(script[i:], "/*"):
+			if end := strings.Index(script[i+2:], "*/"); end >= 0 {
+				i += end + 3
+			} else {
+				i = len(script)
+			}
+			if !hasCode {
+				start = i + 1
+			}
+		case ch == '\'' || ch == '"':
+			hasCode = true
+			// A doubled quote is an escaped quote and simply reopens the literal
+			if end := strings.IndexByte(script[i+1:], ch); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(script)
+			}
+		case ch == '$':
+			hasCode = true
+			if tag := dollarQuoteTag(script[i:]); tag != "" {
+				if end := strings.Index(script[i+len(tag):], tag); end >= 0 {
+					i += len(tag) + end + len(tag) - 1
+				} else {
+					i = len(script)
+				}
+			}
+		case ch == ';':
+			flush(i)
+			start = i + 1
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			if !hasCode {
+				start = i + 1
+			}
+		default:
+			hasCode = true
+		}
+	}
+	flush(len(script))
+
+	return statements
+}
+
+// dollarQuoteTag returns the opening tag of a dollar-quoted string, such as $$ or
+// $body$, at the start of s
+func dollarQuoteTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch == '$':
+			return s[:i+1]
+		case ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || i > 1 && ch >= '0' && ch <= '9':
+			continue
+		default:
+			return ""
+		}
+	}
+	return ""
+}
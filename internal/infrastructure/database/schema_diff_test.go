@@ -0,0 +1,89 @@
+package database_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/infrastructure/database"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- Adds booking notes
+ALTER TABLE bookings ADD COLUMN notes text DEFAULT 'a;b';
+/* keep; this */ UPDATE bookings SET notes = 'it''s; fine' WHERE "odd;name" IS NULL;
+
+CREATE FUNCTION touch() RETURNS trigger AS $body$
+BEGIN
+	NEW.updated_at = now();
+	RETURN NEW;
+END;
+$body$ LANGUAGE plpgsql;
+-- trailing comment only;
+`
+
+	statements := database.SplitStatements(script)
+
+	assert.Equal(t, []string{
+		"ALTER TABLE bookings ADD COLUMN notes text DEFAULT 'a;b'",
+		`UPDATE bookings SET notes = 'it''s; fine' WHERE "odd;name" IS NULL`,
+		"CREATE FUNCTION touch() RETURNS trigger AS $body$\nBEGIN\n\tNEW.updated_at = now();\n\tRETURN NEW;\nEND;\n$body$ LANGUAGE plpgsql",
+	}, statements)
+	assert.Empty(t, database.SplitStatements("-- nothing here\n/* at all */\n"))
+	assert.Equal(t, []string{"SELECT $1"}, database.SplitStatements("SELECT $1"))
+}
+
+func TestDiffSchemas(t *testing.T) {
+	before := &database.SchemaSnapshot{
+		Tables: map[string]map[string]database.ColumnDefinition{
+			"bookings": {
+				"id":     {Type: "uuid", NotNull: true},
+				"status": {Type: "character varying(50)"},
+				"legacy": {Type: "text"},
+			},
+			"old_reports": {"id": {Type: "uuid", NotNull: true}},
+		},
+		Indexes: map[string]string{
+			"idx_bookings_status": `CREATE INDEX idx_bookings_status ON public.bookings USING btree (status)`,
+			"idx_old_reports_id":  `CREATE INDEX idx_old_reports_id ON public.old_reports USING btree (id)`,
+		},
+		Constraints: map[string]string{"old_reports.old_reports_pkey": "PRIMARY KEY (id)"},
+	}
+	after := &database.SchemaSnapshot{
+		Tables: map[string]map[string]database.ColumnDefinition{
+			"bookings": {
+				"id":     {Type: "uuid", NotNull: true},
+				"status": {Type: "character varying(50)", NotNull: true, Default: "'pending'::character varying"},
+				"notes":  {Type: "text"},
+			},
+			"booking_notes": {"id": {Type: "uuid", NotNull: true}, "body": {Type: "text"}},
+		},
+		Indexes: map[string]string{
+			"idx_bookings_notes":  `CREATE INDEX idx_bookings_notes ON public.bookings USING btree (notes)`,
+			"idx_booking_notes":   `CREATE INDEX idx_booking_notes ON public.booking_notes USING btree (id)`,
+			"idx_bookings_status": `CREATE INDEX idx_bookings_status ON public.bookings USING btree (status, id)`,
+		},
+		Constraints: map[string]string{"booking_notes.booking_notes_pkey": "PRIMARY KEY (id)"},
+		Policies: map[string]string{
+			"bookings.tenant_isolation":      "USING ((tenant_id = app_current_tenant())) WITH CHECK ()",
+			"booking_notes.tenant_isolation": "USING ((tenant_id = app_current_tenant())) WITH CHECK ()",
+		},
+	}
+
+	var lines []string
+	for _, change := range database.DiffSchemas(before, after) {
+		lines = append(lines, change.String())
+	}
+
+	assert.Equal(t, []string{
+		"+ table booking_notes: 2 columns",
+		"- column bookings.legacy",
+		"+ column bookings.notes: text",
+		"~ column bookings.status: character varying(50) -> character varying(50) NOT NULL DEFAULT 'pending'::character varying",
+		"- table old_reports",
+		"+ index idx_bookings_notes: CREATE INDEX idx_bookings_notes ON public.bookings USING btree (notes)",
+		"~ index idx_bookings_status: CREATE INDEX idx_bookings_status ON public.bookings USING btree (status) -> CREATE INDEX idx_bookings_status ON public.bookings USING btree (status, id)",
+		"+ policy bookings.tenant_isolation: USING ((tenant_id = app_current_tenant())) WITH CHECK ()",
+	}, lines)
+	assert.Empty(t, database.DiffSchemas(after, after))
+}
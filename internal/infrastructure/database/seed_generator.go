@@ -0,0 +1,476 @@
+package database
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/faker"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// seedServiceTemplate is a kind of service businesses offer, priced in US dollars
+type seedServiceTemplate struct {
+	Category    models.ServiceCategory
+	Names       []string
+	MedianPrice float64
+	Minutes     int
+}
+
+var seedServiceTemplates = []seedServiceTemplate{
+	{models.ServiceCategoryPlumbing, []string{"Leak Repair", "Toilet Installation", "Water Heater Service", "Drain Unblocking"}, 45, 90},
+	{models.ServiceCategoryElectrical, []string{"Socket Installation", "Lighting Fit-out", "Wiring Inspection", "Fuse Box Upgrade"}, 60, 120},
+	{models.ServiceCategoryCarpentry, []string{"Door Fitting", "Custom Shelving", "Kitchen Cabinet Repair"}, 90, 180},
+	{models.ServiceCategoryPainting, []string{"Room Painting", "Exterior Painting", "Wall Touch-up"}, 150, 240},
+	{models.ServiceCategoryTiling, []string{"Bathroom Tiling", "Floor Tiling", "Tile Regrouting"}, 120, 240},
+	{models.ServiceCategoryHVAC, []string{"AC Servicing", "AC Installation", "Ventilation Check"}, 70, 120},
+	{models.ServiceCategoryCleaning, []string{"Home Deep Clean", "Office Cleaning", "Post-construction Clean"}, 40, 180},
+	{models.ServiceCategoryApplianceRepair, []string{"Fridge Repair", "Washing Machine Repair", "Cooker Repair"}, 35, 60},
+	{models.ServiceCategoryHairBeauty, []string{"Braiding", "Haircut", "Manicure and Pedicure", "Bridal Makeup"}, 25, 90},
+	{models.ServiceCategoryTailoring, []string{"Dress Alterations", "Custom Suit", "Kente Outfit"}, 40, 60},
+	{models.ServiceCategoryMechanic, []string{"Engine Diagnostics", "Brake Service", "Oil Change"}, 50, 90},
+	{models.ServiceCategoryPhoneRepair, []string{"Screen Replacement", "Battery Replacement", "Charging Port Repair"}, 30, 45},
+}
+
+// Hours of the working day bookings start at, from 8am, and how popular each is
+var seedHourWeights = []float64{4, 8, 10, 9, 6, 7, 8, 7, 5, 3}
+
+// seedBusiness is one generated business and everything it owns
+type seedBusiness struct {
+	Tenant    *models.Tenant
+	Users     []*models.User
+	Artisans  []*models.Artisan
+	Customers []*models.Customer
+	Services  []*models.Service
+	Bookings  []*models.Booking
+	Payments  []*models.Payment
+}
+
+// seedGenerator generates the businesses of a seed spec
+type seedGenerator struct {
+	spec  SeedSpec
+	now   time.Time
+	today time.Time
+}
+
+func newSeedGenerator(spec SeedSpec, now time.Time) *seedGenerator {
+	return &seedGenerator{
+		spec:  spec,
+		now:   now,
+		today: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// business generates the nth business. Each business draws from its own random
+// source, so it comes out the same however many businesses are generated.
+func (g *seedGenerator) business(n int) *seedBusiness {
+	fake := faker.New(g.spec.Seed*1_000_003 + uint64(n))
+	city := fake.City()
+	subdomain := fmt.Sprintf("%s%d", g.spec.SubdomainPrefix, n)
+	domain := subdomain + ".example.com"
+
+	size := 1.0
+	if g.spec.SizeAlpha > 0 {
+		size = min(fake.Pareto(1, g.spec.SizeAlpha), g.spec.MaxSize)
+	}
+	artisanCount := scaleCount(g.spec.ArtisansPerTenant, size)
+
+	b := &seedBusiness{}
+	createdAt := g.today.AddDate(0, 0, -g.spec.HistoryDays-fake.IntRange(1, 60))
+
+	owner := g.user(fake, nil, models.UserRoleTenantOwner, city, createdAt)
+	if g.spec.FixedIdentities {
+		owner.Email = "owner@" + domain
+	} else {
+		owner.Email = fake.Email(owner.FirstName, owner.LastName, domain, 0)
+	}
+	b.Users = append(b.Users, owner)
+
+	name := fake.CompanyName()
+	if g.spec.FixedIdentities {
+		name = fmt.Sprintf("E2E Business %d", n)
+	}
+	settings := models.GetDefaultTenantSettings()
+	settings.DefaultCurrency = city.Currency
+	settings.DefaultTimezone = city.Timezone
+	settings.AcceptedPaymentMethods = []string{"card", "cash", "mobile_money"}
+
+	b.Tenant = &models.Tenant{
+		BaseModel:     seedBaseModel(fake, createdAt),
+		OwnerID:       owner.ID,
+		Name:          name,
+		Subdomain:     subdomain,
+		BusinessName:  name,
+		BusinessEmail: "hello@" + domain,
+		BusinessPhone: fake.Phone(city),
+		Plan:          seedPlan(artisanCount),
+		Status:        models.TenantStatusActive,
+		Settings:      settings,
+		MaxUsers:      max(10, artisanCount*(2+g.spec.CustomersPerArtisan)),
+		MaxArtisans:   max(5, artisanCount),
+	}
+	if fake.Chance(g.spec.InactiveShare) {
+		b.Tenant.Status = faker.Pick(fake, []models.TenantStatus{models.TenantStatusTrial, models.TenantStatusSuspended})
+	}
+	tenantID := b.Tenant.ID
+	owner.TenantID = &tenantID
+
+	// Artisans and the services they offer, mostly in one trade each
+	for i := 1; i <= artisanCount; i++ {
+		user := g.user(fake, &tenantID, models.UserRoleArtisan, city, createdAt)
+		user.Email = g.email(fake, user, "artisan", i, domain)
+		b.Users = append(b.Users, user)
+
+		lat, lng := fake.Near(city, 15)
+		trade := faker.Pick(fake, seedServiceTemplates)
+		artisan := &models.Artisan{
+			BaseModel:       seedBaseModel(fake, createdAt),
+			UserID:          user.ID,
+			TenantID:        tenantID,
+			Specialization:  models.StringArray{string(trade.Category)},
+			YearsExperience: fake.IntRange(1, 25),
+			IsAvailable:     true,
+			Tier:            models.ArtisanTierStandard,
+			Location: models.Location{
+				Address:   fake.StreetAddress(),
+				City:      city.Name,
+				State:     city.Region,
+				Country:   city.Country,
+				Latitude:  lat,
+				Longitude: lng,
+			},
+			ServiceRadius: faker.Pick(fake, []int{0, 10, 20, 30}),
+		}
+		b.Artisans = append(b.Artisans, artisan)
+
+		for s := 0; s < g.spec.ServicesPerArtisan; s++ {
+			template := trade
+			if s > 0 && fake.Chance(0.25) {
+				template = faker.Pick(fake, seedServiceTemplates)
+			}
+			b.Services = append(b.Services, g.service(fake, template, tenantID, artisan.ID, city, createdAt))
+		}
+	}
+
+	// Customers, a few of whom come back far more often than the rest
+	customerCount := artisanCount * g.spec.CustomersPerArtisan
+	customerWeights := make([]float64, customerCount)
+	for i := 1; i <= customerCount; i++ {
+		user := g.user(fake, &tenantID, models.UserRoleCustomer, city, createdAt)
+		user.Email = g.email(fake, user, "customer", i, domain)
+		b.Users = append(b.Users, user)
+
+		lat, lng := fake.Near(city, 20)
+		b.Customers = append(b.Customers, &models.Customer{
+			BaseModel: seedBaseModel(fake, createdAt),
+			UserID:    user.ID,
+			TenantID:  tenantID,
+			PrimaryLocation: models.Location{
+				Address:   fake.StreetAddress(),
+				City:      city.Name,
+				State:     city.Region,
+				Country:   city.Country,
+				Latitude:  lat,
+				Longitude: lng,
+			},
+			EmailNotifications: true,
+			SMSNotifications:   true,
+			PushNotifications:  true,
+		})
+		customerWeights[i-1] = 1 / float64(i)
+	}
+
+	for i, artisan := range b.Artisans {
+		services := b.Services[i*g.spec.ServicesPerArtisan : (i+1)*g.spec.ServicesPerArtisan]
+		g.bookings(fake, b, artisan, services, customerWeights, city)
+	}
+
+	return b
+}
+
+// bookings generates an artisan's bookings, mostly on weekdays in working hours and
+// never overlapping, with payments for the completed and refunded ones
+func (g *seedGenerator) bookings(fake *faker.Faker, b *seedBusiness, artisan *models.Artisan, services []*models.Service, customerWeights []float64, city faker.City) {
+	loc, err := time.LoadLocation(city.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	now := g.now
+
+	count := scaleCount(g.spec.BookingsPerArtisan, 0.5+fake.Float64())
+	taken := make(map[time.Time]bool)
+	for range count {
+		day := g.today.AddDate(0, 0, fake.IntRange(-g.spec.HistoryDays, g.spec.FutureDays-1))
+		if day.Weekday() == time.Sunday && !fake.Chance(0.1) {
+			continue
+		}
+
+		service := faker.Pick(fake, services)
+		start := time.Date(day.Year(), day.Month(), day.Day(), 8+fake.Weighted(seedHourWeights), 0, 0, 0, loc).UTC()
+		end := start.Add(time.Duration(service.DurationMinutes) * time.Minute)
+		if seedSlotTaken(taken, start, end) {
+			continue
+		}
+
+		customer := b.Customers[fake.Weighted(customerWeights)]
+		booking := &models.Booking{
+			BaseModel:     seedBaseModel(fake, minTime(start.AddDate(0, 0, -fake.IntRange(1, 14)), now)),
+			TenantID:      b.Tenant.ID,
+			ArtisanID:     artisan.ID,
+			CustomerID:    customer.UserID,
+			ServiceID:     service.ID,
+			StartTime:     start,
+			EndTime:       end,
+			Duration:      service.DurationMinutes,
+			BasePrice:     service.Price,
+			TotalPrice:    service.Price,
+			Currency:      service.Currency,
+			PaymentStatus: models.PaymentStatusPending,
+		}
+		if fake.Chance(0.3) {
+			booking.CustomerNotes = fake.Sentence(fake.IntRange(4, 10))
+		}
+		booking.UpdatedAt = booking.CreatedAt
+
+		if end.Before(now) {
+			statuses := []models.BookingStatus{models.BookingStatusCompleted, models.BookingStatusCancelled, models.BookingStatusNoShow, models.BookingStatusRefunded}
+			booking.Status = statuses[fake.Weighted([]float64{80, 11, 5, 4})]
+		} else {
+			statuses := []models.BookingStatus{models.BookingStatusConfirmed, models.BookingStatusPending, models.BookingStatusCancelled}
+			booking.Status = statuses[fake.Weighted([]float64{55, 40, 5})]
+		}
+
+		customer.TotalBookings++
+		artisan.TotalBookings++
+		switch booking.Status {
+		case models.BookingStatusCompleted, models.BookingStatusRefunded:
+			booking.CompletedAt = &end
+			booking.UpdatedAt = end
+			payment := g.payment(fake, booking, artisan)
+			if booking.Status == models.BookingStatusRefunded {
+				payment.Status = models.PaymentStatusRefunded
+				payment.RefundedAmount = payment.Amount
+			} else {
+				customer.CompletedBookings++
+				customer.TotalSpent += payment.Amount
+				customer.LoyaltyPoints += int(payment.Amount / city.PerUSD)
+			}
+			booking.PaymentStatus = payment.Status
+			b.Payments = append(b.Payments, payment)
+		case models.BookingStatusCancelled:
+			cancelledAt := minTime(start.Add(-time.Duration(fake.IntRange(1, 72))*time.Hour), now)
+			if cancelledAt.Before(booking.CreatedAt) {
+				cancelledAt = booking.CreatedAt
+			}
+			booking.CancelledAt = &cancelledAt
+			booking.CancellationReason = faker.Pick(fake, []string{"Schedule conflict", "Found another provider", "No longer needed", "Price too high"})
+			booking.PaymentStatus = models.PaymentStatusCancelled
+			booking.UpdatedAt = cancelledAt
+			customer.CancelledBookings++
+		}
+
+		b.Bookings = append(b.Bookings, booking)
+	}
+}
+
+// payment generates the payment of a completed booking
+func (g *seedGenerator) payment(fake *faker.Faker, booking *models.Booking, artisan *models.Artisan) *models.Payment {
+	methods := []models.PaymentMethod{models.PaymentMethodMobile, models.PaymentMethodCard, models.PaymentMethodCash, models.PaymentMethodBank}
+	processedAt := *booking.CompletedAt
+	commissionRate := 10.0
+	platformAmount := math.Round(booking.TotalPrice*commissionRate) / 100
+
+	return &models.Payment{
+		BaseModel:      seedBaseModel(fake, processedAt),
+		TenantID:       booking.TenantID,
+		BookingID:      booking.ID,
+		CustomerID:     booking.CustomerID,
+		ArtisanID:      &artisan.ID,
+		Amount:         booking.TotalPrice,
+		Currency:       booking.Currency,
+		Method:         methods[fake.Weighted([]float64{40, 30, 20, 10})],
+		Type:           models.PaymentTypeFull,
+		Status:         models.PaymentStatusPaid,
+		PlatformAmount: platformAmount,
+		ArtisanAmount:  booking.TotalPrice - platformAmount,
+		CommissionRate: commissionRate,
+		ProcessedAt:    &processedAt,
+	}
+}
+
+// service generates a service of a template, priced in the city's currency
+func (g *seedGenerator) service(fake *faker.Faker, template seedServiceTemplate, tenantID, artisanID uuid.UUID, city faker.City, createdAt time.Time) *models.Service {
+	price := fake.LogNormal(template.MedianPrice, 0.35) * city.PerUSD
+	// Round to a price a business would charge
+	step := math.Pow(10, math.Max(0, math.Floor(math.Log10(price))-1))
+	price = math.Max(step, math.Round(price/step)*step)
+
+	minutes := int(math.Round(fake.LogNormal(float64(template.Minutes), 0.2)/15)) * 15
+	return &models.Service{
+		BaseModel:       seedBaseModel(fake, createdAt),
+		TenantID:        tenantID,
+		ArtisanID:       &artisanID,
+		Name:            faker.Pick(fake, template.Names),
+		Description:     fake.Sentence(fake.IntRange(6, 14)),
+		Category:        template.Category,
+		Price:           price,
+		Currency:        city.Currency,
+		DurationMinutes: max(15, minutes),
+		IsActive:        true,
+		IsMobile:        fake.Chance(0.4),
+	}
+}
+
+// user generates a person of a role, active since createdAt
+func (g *seedGenerator) user(fake *faker.Faker, tenantID *uuid.UUID, role models.UserRole, city faker.City, createdAt time.Time) *models.User {
+	base := seedBaseModel(fake, createdAt)
+	return &models.User{
+		BaseModel:     base,
+		TenantID:      tenantID,
+		ZitadelUserID: "seed-" + base.ID.String(),
+		FirstName:     fake.FirstName(),
+		LastName:      fake.LastName(),
+		PhoneNumber:   fake.Phone(city),
+		Role:          role,
+		Status:        models.UserStatusActive,
+		EmailVerified: true,
+		Timezone:      city.Timezone,
+		Language:      "en",
+	}
+}
+
+// email addresses the nth user of a kind, predictably when the spec asks for it
+func (g *seedGenerator) email(fake *faker.Faker, user *models.User, kind string, n int, domain string) string {
+	if g.spec.FixedIdentities {
+		return fmt.Sprintf("%s%d@%s", kind, n, domain)
+	}
+	return fake.Email(user.FirstName, user.LastName, domain, n)
+}
+
+// seedBaseModel fills in what the BaseModel hook would, as seeding skips hooks
+func seedBaseModel(fake *faker.Faker, createdAt time.Time) models.BaseModel {
+	return models.BaseModel{
+		ID:        fake.UUID(),
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Version:   1,
+	}
+}
+
+// seedPlan picks the plan a business of a size would be on
+func seedPlan(artisans int) models.TenantPlan {
+	switch {
+	case artisans <= 1:
+		return models.TenantPlanSolo
+	case artisans <= 10:
+		return models.TenantPlanSmall
+	case artisans <= 50:
+		return models.TenantPlanCorporation
+	default:
+		return models.TenantPlanEnterprise
+	}
+}
+
+// seedSlotTaken reports whether any half hour from start to end is taken, taking them
+// when none is
+func seedSlotTaken(taken map[time.Time]bool, start, end time.Time) bool {
+	for t := start; t.Before(end); t = t.Add(30 * time.Minute) {
+		if taken[t] {
+			return true
+		}
+	}
+	for t := start; t.Before(end); t = t.Add(30 * time.Minute) {
+		taken[t] = true
+	}
+	return false
+}
+
+func scaleCount(count int, scale float64) int {
+	return max(1, int(math.Round(float64(count)*scale)))
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// seedProfileData generates a profile's businesses, one transaction per business. A
+// profile whose first business exists is taken to be seeded already.
+func seedProfileData(db *gorm.DB, logger *zap.Logger, profile SeedProfile, spec SeedSpec) error {
+	firstSubdomain := spec.SubdomainPrefix + "1"
+	var count int64
+	if err := db.Model(&models.Tenant{}).Where("subdomain = ?", firstSubdomain).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check existing data: %w", err)
+	}
+	if count > 0 {
+		logger.Info("seed profile already loaded, skipping", zap.String("profile", string(profile)))
+		return nil
+	}
+
+	logger.Info("seeding profile", zap.String("profile", string(profile)), zap.Int("tenants", spec.Tenants))
+	started := time.Now()
+	generator := newSeedGenerator(spec, started.UTC())
+	// Rows are complete and consistent as generated; the model hooks would look up
+	// owners one row at a time
+	session := db.Session(&gorm.Session{SkipHooks: true, CreateBatchSize: spec.BatchSize})
+
+	var users, bookings, payments int
+	for n := 1; n <= spec.Tenants; n++ {
+		b := generator.business(n)
+		err := session.Transaction(func(tx *gorm.DB) error {
+			if err := insertSeedRows(tx, b.Users); err != nil {
+				return err
+			}
+			if err := tx.Create(b.Tenant).Error; err != nil {
+				return err
+			}
+			if err := insertSeedRows(tx, b.Artisans); err != nil {
+				return err
+			}
+			if err := insertSeedRows(tx, b.Customers); err != nil {
+				return err
+			}
+			if err := insertSeedRows(tx, b.Services); err != nil {
+				return err
+			}
+			if err := insertSeedRows(tx, b.Bookings); err != nil {
+				return err
+			}
+			return insertSeedRows(tx, b.Payments)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to seed tenant %s: %w", b.Tenant.Subdomain, err)
+		}
+
+		users += len(b.Users)
+		bookings += len(b.Bookings)
+		payments += len(b.Payments)
+		if n%100 == 0 {
+			logger.Info("seeding progress", zap.Int("tenants", n), zap.Int("bookings", bookings))
+		}
+	}
+
+	logger.Info("seed profile loaded",
+		zap.String("profile", string(profile)),
+		zap.Int("tenants", spec.Tenants),
+		zap.Int("users", users),
+		zap.Int("bookings", bookings),
+		zap.Int("payments", payments),
+		zap.Duration("took", time.Since(started)),
+	)
+	return nil
+}
+
+// insertSeedRows inserts rows in batches, skipping an empty slice GORM would reject
+func insertSeedRows[T any](tx *gorm.DB, rows []*T) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(rows).Error
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeedProfile(t *testing.T) {
+	profile, err := ParseSeedProfile("")
+	require.NoError(t, err)
+	assert.Equal(t, SeedProfileDefault, profile)
+
+	profile, err = ParseSeedProfile("Load-Test")
+	require.NoError(t, err)
+	assert.Equal(t, SeedProfileLoadTest, profile)
+
+	_, err = ParseSeedProfile("production")
+	assert.ErrorContains(t, err, "default, demo, e2e, load-test")
+
+	_, ok := SeedProfileDefault.Spec()
+	assert.False(t, ok, "the default profile only seeds system settings")
+}
+
+func TestSeedGenerator_SameBusinessEveryRun(t *testing.T) {
+	spec, _ := SeedProfileDemo.Spec()
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	a := newSeedGenerator(spec, now).business(2)
+	b := newSeedGenerator(spec, now).business(2)
+	assert.Equal(t, a.Tenant.ID, b.Tenant.ID)
+	assert.Equal(t, a.Tenant.Name, b.Tenant.Name)
+	require.Len(t, b.Bookings, len(a.Bookings))
+	for i := range a.Bookings {
+		assert.Equal(t, a.Bookings[i].ID, b.Bookings[i].ID)
+		assert.Equal(t, a.Bookings[i].StartTime, b.Bookings[i].StartTime)
+	}
+
+	assert.NotEqual(t, a.Tenant.ID, newSeedGenerator(spec, now).business(3).Tenant.ID)
+}
+
+func TestSeedGenerator_BusinessIsConsistent(t *testing.T) {
+	spec, _ := SeedProfileLoadTest.Spec()
+	now := time.Now().UTC()
+	b := newSeedGenerator(spec, now).business(7)
+
+	assert.Equal(t, "loadtest7", b.Tenant.Subdomain)
+	assert.Equal(t, b.Users[0].ID, b.Tenant.OwnerID)
+	assert.Equal(t, models.UserRoleTenantOwner, b.Users[0].Role)
+
+	emails := map[string]bool{}
+	for _, user := range b.Users {
+		require.NotNil(t, user.TenantID)
+		assert.Equal(t, b.Tenant.ID, *user.TenantID)
+		assert.False(t, emails[user.Email], "duplicate email %s", user.Email)
+		emails[user.Email] = true
+	}
+
+	artisans := map[uuid.UUID]bool{}
+	for _, artisan := range b.Artisans {
+		artisans[artisan.ID] = true
+	}
+	customers := map[uuid.UUID]bool{}
+	for _, customer := range b.Customers {
+		customers[customer.UserID] = true
+	}
+	services := map[uuid.UUID]bool{}
+	for _, service := range b.Services {
+		services[service.ID] = true
+		assert.Greater(t, service.Price, 0.0)
+		assert.GreaterOrEqual(t, service.DurationMinutes, 15)
+	}
+
+	require.NotEmpty(t, b.Bookings)
+	payments := map[uuid.UUID]*models.Payment{}
+	for _, payment := range b.Payments {
+		payments[payment.BookingID] = payment
+	}
+	for _, booking := range b.Bookings {
+		assert.True(t, artisans[booking.ArtisanID])
+		assert.True(t, customers[booking.CustomerID])
+		assert.True(t, services[booking.ServiceID])
+		assert.True(t, booking.EndTime.After(booking.StartTime))
+		assert.False(t, booking.CreatedAt.After(now))
+
+		payment, paid := payments[booking.ID]
+		switch booking.Status {
+		case models.BookingStatusCompleted:
+			require.True(t, paid)
+			assert.Equal(t, models.PaymentStatusPaid, payment.Status)
+			assert.Equal(t, booking.TotalPrice, payment.Amount)
+			assert.False(t, booking.EndTime.After(now))
+		case models.BookingStatusRefunded:
+			require.True(t, paid)
+			assert.Equal(t, models.PaymentStatusRefunded, payment.Status)
+		default:
+			assert.False(t, paid, "a %s booking has no payment", booking.Status)
+		}
+	}
+
+	// No artisan is booked twice at once
+	for i, x := range b.Bookings {
+		for _, y := range b.Bookings[i+1:] {
+			if x.ArtisanID == y.ArtisanID {
+				assert.False(t, x.StartTime.Before(y.EndTime) && y.StartTime.Before(x.EndTime), "bookings %s and %s overlap", x.ID, y.ID)
+			}
+		}
+	}
+}
+
+func TestSeedGenerator_E2EIdentitiesAreFixed(t *testing.T) {
+	spec, _ := SeedProfileE2E.Spec()
+	b := newSeedGenerator(spec, time.Now()).business(1)
+
+	assert.Equal(t, "e2e1", b.Tenant.Subdomain)
+	assert.Equal(t, "E2E Business 1", b.Tenant.Name)
+	assert.Equal(t, models.TenantStatusActive, b.Tenant.Status)
+
+	var emails []string
+	for _, user := range b.Users {
+		emails = append(emails, user.Email)
+	}
+	assert.Equal(t, []string{
+		"owner@e2e1.example.com",
+		"artisan1@e2e1.example.com",
+		"artisan2@e2e1.example.com",
+		"customer1@e2e1.example.com",
+		"customer2@e2e1.example.com",
+		"customer3@e2e1.example.com",
+		"customer4@e2e1.example.com",
+	}, emails)
+}
+
+func TestSeedGenerator_BusinessSizesVary(t *testing.T) {
+	spec, _ := SeedProfileLoadTest.Spec()
+	generator := newSeedGenerator(spec, time.Now())
+
+	sizes := make([]int, 200)
+	plans := map[models.TenantPlan]int{}
+	for i := range sizes {
+		b := generator.business(i + 1)
+		sizes[i] = len(b.Artisans)
+		plans[b.Tenant.Plan]++
+	}
+	slices.Sort(sizes)
+	assert.LessOrEqual(t, sizes[len(sizes)/2], 2, "most businesses are one or two artisans")
+	assert.GreaterOrEqual(t, sizes[len(sizes)-1], 15, "a few are much larger")
+	assert.Positive(t, plans[models.TenantPlanSolo])
+	assert.Positive(t, plans[models.TenantPlanCorporation])
+}
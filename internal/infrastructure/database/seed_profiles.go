@@ -0,0 +1,115 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SeedProfile names a dataset the seeder can load
+type SeedProfile string
+
+const (
+	// SeedProfileDefault seeds the system settings every environment needs
+	SeedProfileDefault SeedProfile = "default"
+	// SeedProfileDemo adds a few realistic businesses to click through in development
+	SeedProfileDemo SeedProfile = "demo"
+	// SeedProfileE2E adds small businesses with fixed subdomains and emails that
+	// end-to-end tests can rely on: tenants e2e1 and e2e2, each owned by
+	// owner@e2eN.example.com with artisanM@ and customerM@ users
+	SeedProfileE2E SeedProfile = "e2e"
+	// SeedProfileLoadTest adds thousands of businesses with a realistic spread of
+	// sizes and a year of bookings and payments, for performance testing
+	SeedProfileLoadTest SeedProfile = "load-test"
+)
+
+// SeedProfiles lists the profiles in the order they are documented
+var SeedProfiles = []SeedProfile{SeedProfileDefault, SeedProfileDemo, SeedProfileE2E, SeedProfileLoadTest}
+
+// ParseSeedProfile parses a profile name, treating an empty name as the default
+// profile
+func ParseSeedProfile(name string) (SeedProfile, error) {
+	if name == "" {
+		return SeedProfileDefault, nil
+	}
+	profile := SeedProfile(strings.ToLower(name))
+	if _, ok := seedSpecs[profile]; !ok && profile != SeedProfileDefault {
+		names := make([]string, len(SeedProfiles))
+		for i, p := range SeedProfiles {
+			names[i] = string(p)
+		}
+		return "", fmt.Errorf("unknown seed profile %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return profile, nil
+}
+
+// SeedSpec describes the businesses a profile generates. Counts are for the smallest
+// business; with a SizeAlpha, business sizes follow a power law above them so most are
+// small and a few are many times larger, as on a real marketplace.
+type SeedSpec struct {
+	Tenants             int     // Businesses to create
+	SizeAlpha           float64 // Shape of the business size power law, 0 for equal sizes
+	MaxSize             float64 // Cap on how many times the smallest a business can be
+	ArtisansPerTenant   int
+	ServicesPerArtisan  int
+	CustomersPerArtisan int
+	BookingsPerArtisan  int     // Over the whole history and future window
+	HistoryDays         int     // How far back bookings go
+	FutureDays          int     // How far ahead bookings go
+	FixedIdentities     bool    // Predictable emails and names for tests to log in with
+	Seed                uint64  // Random seed, so a profile always generates the same data
+	SubdomainPrefix     string  // Tenants are <prefix>1, <prefix>2, ...
+	InactiveShare       float64 // Share of businesses on trial or suspended
+	BatchSize           int     // Rows per insert statement
+}
+
+var seedSpecs = map[SeedProfile]SeedSpec{
+	SeedProfileDemo: {
+		Tenants:             3,
+		SizeAlpha:           1.2,
+		MaxSize:             4,
+		ArtisansPerTenant:   3,
+		ServicesPerArtisan:  3,
+		CustomersPerArtisan: 8,
+		BookingsPerArtisan:  40,
+		HistoryDays:         90,
+		FutureDays:          30,
+		Seed:                1,
+		SubdomainPrefix:     "demo",
+		BatchSize:           200,
+	},
+	SeedProfileE2E: {
+		Tenants:             2,
+		ArtisansPerTenant:   2,
+		ServicesPerArtisan:  2,
+		CustomersPerArtisan: 2,
+		BookingsPerArtisan:  6,
+		HistoryDays:         14,
+		FutureDays:          14,
+		FixedIdentities:     true,
+		Seed:                1,
+		SubdomainPrefix:     "e2e",
+		BatchSize:           100,
+	},
+	SeedProfileLoadTest: {
+		Tenants:             2000,
+		SizeAlpha:           1.3,
+		MaxSize:             60,
+		ArtisansPerTenant:   1,
+		ServicesPerArtisan:  3,
+		CustomersPerArtisan: 15,
+		BookingsPerArtisan:  120,
+		HistoryDays:         365,
+		FutureDays:          60,
+		Seed:                1,
+		SubdomainPrefix:     "loadtest",
+		InactiveShare:       0.15,
+		BatchSize:           1000,
+	},
+}
+
+// Spec returns the spec of a profile that generates businesses. The default profile
+// has none.
+func (p SeedProfile) Spec() (SeedSpec, bool) {
+	spec, ok := seedSpecs[p]
+	return spec, ok
+}
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Krafti_Vibe/internal/pkg/tenancy"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+// TenantSetting is the session setting the row-level security policies compare
+// tenant_id with. While it is empty every row is visible, which is how background
+// jobs and platform operations work across tenants.
+const TenantSetting = "app.tenant_id"
+
+const (
+	tenantScopedConnKey = "tenant_isolation:conn"   // Statement instance key for a pinned connection
+	tenantScopedFlag    = "tenant_isolation:scoped" // pgconn custom data flag for connections left scoped
+)
+
+// tenantScopedConn is a connection pinned for one statement and the pool it replaced
+type tenantScopedConn struct {
+	conn     *sql.Conn
+	original gorm.ConnPool
+}
+
+// RegisterTenantIsolation installs callbacks that scope every statement whose context
+// carries a tenant (see the tenancy package) to that tenant's rows. Inside a
+// transaction the tenant is set for the rest of the transaction; otherwise the
+// statement runs on a connection pinned and scoped just for it.
+func RegisterTenantIsolation(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	if err := callbacks.Create().After("gorm:begin_transaction").Register("tenant_isolation:scope", scopeToTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:commit_or_rollback_transaction").Register("tenant_isolation:release", releaseTenantConn); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:begin_transaction").Register("tenant_isolation:scope", scopeToTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:commit_or_rollback_transaction").Register("tenant_isolation:release", releaseTenantConn); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:begin_transaction").Register("tenant_isolation:scope", scopeToTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:commit_or_rollback_transaction").Register("tenant_isolation:release", releaseTenantConn); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenant_isolation:scope", scopeToTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register("tenant_isolation:release", releaseTenantConn); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("tenant_isolation:scope", scopeToTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().After("gorm:raw").Register("tenant_isolation:release", releaseTenantConn); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenant_isolation:scope", scopeToTenant); err != nil {
+		return err
+	}
+	// The caller still reads the rows, so the connection goes back once they are closed
+	return callbacks.Row().After("gorm:row").Register("tenant_isolation:release", releaseTenantConnAfterRows)
+}
+
+// scopeToTenant sets the tenant setting for the statement
+func scopeToTenant(db *gorm.DB) {
+	if db.Error != nil || db.DryRun {
+		return
+	}
+	ctx := db.Statement.Context
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if _, inTx := db.Statement.ConnPool.(gorm.TxCommitter); inTx {
+		// Local to the transaction, so it ends with it
+		if _, err := db.Statement.ConnPool.ExecContext(ctx, "SELECT set_config($1, $2, true)", TenantSetting, tenantID.String()); err != nil {
+			db.AddError(fmt.Errorf("failed to scope transaction to tenant: %w", err))
+		}
+		return
+	}
+	if _, pinned := db.Statement.ConnPool.(*sql.Conn); pinned {
+		// Nested in a statement that already pinned and scoped this connection
+		return
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		db.AddError(fmt.Errorf("failed to scope statement to tenant: %w", err))
+		return
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		db.AddError(fmt.Errorf("failed to scope statement to tenant: %w", err))
+		return
+	}
+
+	// Flag the connection first so the pool clears it even if the statement fails
+	conn.Raw(func(driverConn any) error {
+		if pgxConn, ok := driverConn.(*stdlib.Conn); ok {
+			pgxConn.Conn().PgConn().CustomData()[tenantScopedFlag] = true
+		}
+		return nil
+	})
+	if _, err := conn.ExecContext(ctx, "SELECT set_config($1, $2, false)", TenantSetting, tenantID.String()); err != nil {
+		conn.Close()
+		db.AddError(fmt.Errorf("failed to scope statement to tenant: %w", err))
+		return
+	}
+
+	db.InstanceSet(tenantScopedConnKey, &tenantScopedConn{conn: conn, original: db.Statement.ConnPool})
+	db.Statement.ConnPool = conn
+}
+
+// releaseTenantConn clears the tenant from a pinned connection and returns it to the pool
+func releaseTenantConn(db *gorm.DB) {
+	scoped, ok := pinnedTenantConn(db)
+	if !ok {
+		return
+	}
+
+	if _, err := scoped.conn.ExecContext(context.Background(), "SELECT set_config($1, '', false)", TenantSetting); err == nil {
+		scoped.conn.Raw(func(driverConn any) error {
+			if pgxConn, ok := driverConn.(*stdlib.Conn); ok {
+				delete(pgxConn.Conn().PgConn().CustomData(), tenantScopedFlag)
+			}
+			return nil
+		})
+	}
+	scoped.conn.Close()
+}
+
+// releaseTenantConnAfterRows returns a pinned connection to the pool once the caller
+// has closed its rows. ResetTenantSession clears the tenant before it is reused.
+func releaseTenantConnAfterRows(db *gorm.DB) {
+	scoped, ok := pinnedTenantConn(db)
+	if !ok {
+		return
+	}
+
+	// Close waits for open rows to be closed
+	go scoped.conn.Close()
+}
+
+// pinnedTenantConn takes back the connection scopeToTenant pinned for the statement
+func pinnedTenantConn(db *gorm.DB) (*tenantScopedConn, bool) {
+	value, ok := db.InstanceGet(tenantScopedConnKey)
+	if !ok {
+		return nil, false
+	}
+	scoped, ok := value.(*tenantScopedConn)
+	if !ok || scoped == nil {
+		return nil, false
+	}
+
+	db.InstanceSet(tenantScopedConnKey, (*tenantScopedConn)(nil))
+	db.Statement.ConnPool = scoped.original
+	return scoped, true
+}
+
+// ResetTenantSession clears the tenant from a connection that was returned to the
+// pool while still scoped, before it is handed out again. Pass it to stdlib.OpenDB
+// with stdlib.OptionResetSession.
+func ResetTenantSession(ctx context.Context, conn *pgx.Conn) error {
+	data := conn.PgConn().CustomData()
+	if _, ok := data[tenantScopedFlag]; !ok {
+		return nil
+	}
+
+	if _, err := conn.Exec(ctx, "SELECT set_config($1, '', false)", TenantSetting); err != nil {
+		return err
+	}
+	delete(data, tenantScopedFlag)
+	return nil
+}
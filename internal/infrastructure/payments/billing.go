@@ -0,0 +1,382 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BillingStatus is the provider-neutral state of a recurring subscription
+type BillingStatus string
+
+const (
+	BillingIncomplete BillingStatus = "incomplete" // Waiting for the first payment
+	BillingTrialing   BillingStatus = "trialing"
+	BillingActive     BillingStatus = "active"
+	BillingPastDue    BillingStatus = "past_due" // A renewal failed; the provider is retrying
+	BillingCanceled   BillingStatus = "canceled"
+)
+
+// BillingEventType is the provider-neutral kind of a billing webhook
+type BillingEventType string
+
+const (
+	BillingEventInvoicePaid          BillingEventType = "invoice_paid"
+	BillingEventInvoiceFailed        BillingEventType = "invoice_payment_failed"
+	BillingEventSubscriptionUpdated  BillingEventType = "subscription_updated"
+	BillingEventSubscriptionCanceled BillingEventType = "subscription_canceled"
+)
+
+// Billing drives the recurring subscriptions that bill tenants for their platform plan.
+// Unlike Provider, which collects payments on a tenant's behalf, Billing charges the
+// tenant itself on the platform's own account.
+type Billing interface {
+	// CreateCustomer registers a tenant as a billing customer and returns its ID
+	CreateCustomer(ctx context.Context, req BillingCustomerRequest) (string, error)
+
+	// CreateSubscription starts billing a customer for a price
+	CreateSubscription(ctx context.Context, req BillingSubscriptionRequest) (*BillingSubscription, error)
+
+	// ChangePrice moves a subscription to another price, prorating the difference
+	ChangePrice(ctx context.Context, subscriptionID, priceID string) (*BillingSubscription, error)
+
+	// CancelSubscription stops billing, now or when the paid period ends
+	CancelSubscription(ctx context.Context, subscriptionID string, atPeriodEnd bool) (*BillingSubscription, error)
+
+	// VerifyWebhook checks the signature of a billing webhook delivery and parses the event
+	VerifyWebhook(ctx context.Context, payload []byte, headers http.Header) (*BillingEvent, error)
+}
+
+// BillingCustomerRequest describes a tenant to bill
+type BillingCustomerRequest struct {
+	Email     string
+	Name      string
+	Reference string // Our tenant ID
+}
+
+// BillingSubscriptionRequest describes a subscription to start
+type BillingSubscriptionRequest struct {
+	CustomerID      string
+	PriceID         string
+	TrialDays       int
+	PaymentMethodID string // Optional; otherwise the first invoice is confirmed client-side
+	Reference       string // Our tenant ID; echoed back in webhooks
+}
+
+// BillingSubscription is the provider's view of a subscription
+type BillingSubscription struct {
+	ID                 string
+	CustomerID         string
+	Status             BillingStatus
+	PriceID            string
+	Reference          string
+	CurrentPeriodStart time.Time
+	CurrentPeriodEnd   time.Time
+	CancelAtPeriodEnd  bool
+	TrialEndsAt        *time.Time
+	ClientSecret       string // Confirms the first payment client-side while Status is incomplete
+}
+
+// BillingEvent is a verified, provider-neutral billing webhook notification
+type BillingEvent struct {
+	ID             string
+	Type           BillingEventType // Empty for events billing does not act on
+	CustomerID     string
+	SubscriptionID string
+	Subscription   *BillingSubscription // Set for subscription events
+	AmountPaid     float64              // Set for invoice events
+	Currency       string
+	AttemptCount   int // Failed collection attempts on the invoice so far
+	OccurredAt     time.Time
+}
+
+// NewBillingFromConfig returns Stripe Billing when Stripe is configured, else nil
+func NewBillingFromConfig(cfg Config) Billing {
+	if cfg.StripeSecretKey == "" {
+		return nil
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return NewStripeBilling(cfg.StripeSecretKey, cfg.StripeBillingWebhookSecret, client)
+}
+
+// StripeBilling drives the Stripe Billing subscriptions API. Billing webhooks go to
+// their own endpoint, so they are signed with a separate secret.
+type StripeBilling struct {
+	api           *StripeProvider
+	webhookSecret string
+}
+
+// NewStripeBilling creates a Stripe Billing driver
+func NewStripeBilling(secretKey, webhookSecret string, client *http.Client) *StripeBilling {
+	return &StripeBilling{
+		api:           NewStripeProvider(secretKey, "", client),
+		webhookSecret: webhookSecret,
+	}
+}
+
+type stripeSubscription struct {
+	ID                 string `json:"id"`
+	Customer           string `json:"customer"`
+	Status             string `json:"status"`
+	CurrentPeriodStart int64  `json:"current_period_start"`
+	CurrentPeriodEnd   int64  `json:"current_period_end"`
+	CancelAtPeriodEnd  bool   `json:"cancel_at_period_end"`
+	TrialEnd           int64  `json:"trial_end"`
+	Items              struct {
+		Data []struct {
+			ID                 string `json:"id"`
+			CurrentPeriodStart int64  `json:"current_period_start"`
+			CurrentPeriodEnd   int64  `json:"current_period_end"`
+			Price              struct {
+				ID string `json:"id"`
+			} `json:"price"`
+		} `json:"data"`
+	} `json:"items"`
+	LatestInvoice json.RawMessage   `json:"latest_invoice"` // ID, or the invoice when expanded
+	Metadata      map[string]string `json:"metadata"`
+}
+
+type stripeInvoice struct {
+	ID           string `json:"id"`
+	Customer     string `json:"customer"`
+	Subscription string `json:"subscription"`
+	AmountPaid   int64  `json:"amount_paid"`
+	Currency     string `json:"currency"`
+	AttemptCount int    `json:"attempt_count"`
+	Parent       *struct {
+		SubscriptionDetails *struct {
+			Subscription string `json:"subscription"`
+		} `json:"subscription_details"`
+	} `json:"parent"`
+}
+
+// CreateCustomer creates a Stripe customer; the tenant ID makes retries idempotent
+func (b *StripeBilling) CreateCustomer(ctx context.Context, req BillingCustomerRequest) (string, error) {
+	form := url.Values{}
+	if req.Email != "" {
+		form.Set("email", req.Email)
+	}
+	if req.Name != "" {
+		form.Set("name", req.Name)
+	}
+	if req.Reference != "" {
+		form.Set("metadata[tenant_id]", req.Reference)
+	}
+
+	var customer struct {
+		ID string `json:"id"`
+	}
+	if err := b.api.post(ctx, "/v1/customers", form, req.Reference, &customer); err != nil {
+		return "", err
+	}
+	return customer.ID, nil
+}
+
+// CreateSubscription creates a Stripe subscription. Without a saved payment method the
+// subscription starts incomplete and the result carries the client secret for the
+// first invoice.
+func (b *StripeBilling) CreateSubscription(ctx context.Context, req BillingSubscriptionRequest) (*BillingSubscription, error) {
+	form := url.Values{}
+	form.Set("customer", req.CustomerID)
+	form.Set("items[0][price]", req.PriceID)
+	form.Set("payment_behavior", "default_incomplete")
+	form.Set("payment_settings[save_default_payment_method]", "on_subscription")
+	form.Add("expand[]", "latest_invoice.payment_intent")
+	if req.TrialDays > 0 {
+		form.Set("trial_period_days", strconv.Itoa(req.TrialDays))
+	}
+	if req.PaymentMethodID != "" {
+		form.Set("default_payment_method", req.PaymentMethodID)
+	}
+	if req.Reference != "" {
+		form.Set("metadata[tenant_id]", req.Reference)
+	}
+
+	var sub stripeSubscription
+	if err := b.api.post(ctx, "/v1/subscriptions", form, "", &sub); err != nil {
+		return nil, err
+	}
+	return sub.toBillingSubscription(), nil
+}
+
+// ChangePrice swaps the subscription's only item to priceID with prorations, and
+// withdraws any pending cancellation
+func (b *StripeBilling) ChangePrice(ctx context.Context, subscriptionID, priceID string) (*BillingSubscription, error) {
+	path := "/v1/subscriptions/" + url.PathEscape(subscriptionID)
+
+	var current stripeSubscription
+	if err := b.api.send(ctx, http.MethodGet, path, url.Values{}, "", &current); err != nil {
+		return nil, err
+	}
+	if len(current.Items.Data) == 0 {
+		return nil, &ProviderError{Provider: ProviderStripe, Message: "subscription has no items"}
+	}
+
+	form := url.Values{}
+	form.Set("items[0][id]", current.Items.Data[0].ID)
+	form.Set("items[0][price]", priceID)
+	form.Set("proration_behavior", "create_prorations")
+	form.Set("cancel_at_period_end", "false")
+
+	var sub stripeSubscription
+	if err := b.api.post(ctx, path, form, "", &sub); err != nil {
+		return nil, err
+	}
+	return sub.toBillingSubscription(), nil
+}
+
+// CancelSubscription cancels immediately, or flags the subscription to end with its period
+func (b *StripeBilling) CancelSubscription(ctx context.Context, subscriptionID string, atPeriodEnd bool) (*BillingSubscription, error) {
+	path := "/v1/subscriptions/" + url.PathEscape(subscriptionID)
+
+	var sub stripeSubscription
+	var err error
+	if atPeriodEnd {
+		form := url.Values{}
+		form.Set("cancel_at_period_end", "true")
+		err = b.api.post(ctx, path, form, "", &sub)
+	} else {
+		err = b.api.send(ctx, http.MethodDelete, path, url.Values{}, "", &sub)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sub.toBillingSubscription(), nil
+}
+
+// VerifyWebhook checks the Stripe-Signature header and parses invoice and subscription events
+func (b *StripeBilling) VerifyWebhook(ctx context.Context, payload []byte, headers http.Header) (*BillingEvent, error) {
+	if b.webhookSecret == "" {
+		return nil, fmt.Errorf("%w: stripe billing webhook secret", ErrProviderNotConfigured)
+	}
+	if err := verifyStripeSignature(b.webhookSecret, payload, headers.Get("Stripe-Signature"), b.api.now()); err != nil {
+		return nil, err
+	}
+
+	var event struct {
+		ID      string `json:"id"`
+		Type    string `json:"type"`
+		Created int64  `json:"created"`
+		Data    struct {
+			Object json.RawMessage `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode stripe event: %w", err)
+	}
+
+	result := &BillingEvent{ID: event.ID, OccurredAt: time.Unix(event.Created, 0)}
+	switch event.Type {
+	case "invoice.paid", "invoice.payment_failed":
+		var invoice stripeInvoice
+		if err := json.Unmarshal(event.Data.Object, &invoice); err != nil {
+			return nil, fmt.Errorf("decode stripe invoice: %w", err)
+		}
+		result.Type = BillingEventInvoicePaid
+		if event.Type == "invoice.payment_failed" {
+			result.Type = BillingEventInvoiceFailed
+		}
+		result.CustomerID = invoice.Customer
+		result.SubscriptionID = invoice.subscriptionID()
+		result.AmountPaid = fromMinorUnits(invoice.AmountPaid, invoice.Currency)
+		result.Currency = strings.ToUpper(invoice.Currency)
+		result.AttemptCount = invoice.AttemptCount
+	case "customer.subscription.created", "customer.subscription.updated", "customer.subscription.deleted":
+		var sub stripeSubscription
+		if err := json.Unmarshal(event.Data.Object, &sub); err != nil {
+			return nil, fmt.Errorf("decode stripe subscription: %w", err)
+		}
+		result.Type = BillingEventSubscriptionUpdated
+		if event.Type == "customer.subscription.deleted" {
+			result.Type = BillingEventSubscriptionCanceled
+		}
+		result.Subscription = sub.toBillingSubscription()
+		result.CustomerID = sub.Customer
+		result.SubscriptionID = sub.ID
+	}
+	return result, nil
+}
+
+func (i stripeInvoice) subscriptionID() string {
+	if i.Subscription != "" {
+		return i.Subscription
+	}
+	// Newer API versions move the subscription under the invoice's parent
+	if i.Parent != nil && i.Parent.SubscriptionDetails != nil {
+		return i.Parent.SubscriptionDetails.Subscription
+	}
+	return ""
+}
+
+func (s stripeSubscription) toBillingSubscription() *BillingSubscription {
+	sub := &BillingSubscription{
+		ID:                s.ID,
+		CustomerID:        s.Customer,
+		Reference:         s.Metadata["tenant_id"],
+		CancelAtPeriodEnd: s.CancelAtPeriodEnd,
+		ClientSecret:      s.clientSecret(),
+	}
+
+	switch s.Status {
+	case "active":
+		sub.Status = BillingActive
+	case "trialing":
+		sub.Status = BillingTrialing
+	case "past_due", "unpaid", "paused":
+		sub.Status = BillingPastDue
+	case "canceled", "incomplete_expired":
+		sub.Status = BillingCanceled
+	default:
+		sub.Status = BillingIncomplete
+	}
+
+	// Newer API versions report billing periods per item
+	start, end := s.CurrentPeriodStart, s.CurrentPeriodEnd
+	if len(s.Items.Data) > 0 {
+		item := s.Items.Data[0]
+		sub.PriceID = item.Price.ID
+		if start == 0 {
+			start, end = item.CurrentPeriodStart, item.CurrentPeriodEnd
+		}
+	}
+	if start > 0 {
+		sub.CurrentPeriodStart = time.Unix(start, 0)
+	}
+	if end > 0 {
+		sub.CurrentPeriodEnd = time.Unix(end, 0)
+	}
+	if s.TrialEnd > 0 {
+		trialEnd := time.Unix(s.TrialEnd, 0)
+		sub.TrialEndsAt = &trialEnd
+	}
+	return sub
+}
+
+// clientSecret returns the secret of the expanded latest invoice's payment
+func (s stripeSubscription) clientSecret() string {
+	if !bytes.HasPrefix(bytes.TrimSpace(s.LatestInvoice), []byte("{")) {
+		return ""
+	}
+	var invoice struct {
+		PaymentIntent json.RawMessage `json:"payment_intent"`
+	}
+	if err := json.Unmarshal(s.LatestInvoice, &invoice); err != nil {
+		return ""
+	}
+	var intent struct {
+		ClientSecret string `json:"client_secret"`
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(invoice.PaymentIntent), []byte("{")) {
+		_ = json.Unmarshal(invoice.PaymentIntent, &intent)
+	}
+	return intent.ClientSecret
+}
@@ -0,0 +1,146 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for a provider that has failed repeatedly and is being
+// given time to recover
+var ErrCircuitOpen = errors.New("payments: provider circuit open")
+
+// CircuitState is the state of a provider's circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // Healthy; requests go through
+	CircuitOpen     CircuitState = "open"      // Failing; requests are refused until the open period ends
+	CircuitHalfOpen CircuitState = "half_open" // Open period over; the next request decides
+)
+
+// BreakerConfig tunes the per-provider circuit breakers
+type BreakerConfig struct {
+	FailureThreshold int           // Consecutive outages that open the circuit; default 5
+	OpenDuration     time.Duration // How long an open circuit refuses requests; default 30s
+}
+
+func (c BreakerConfig) withDefaults() BreakerConfig {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = 5
+	}
+	if c.OpenDuration <= 0 {
+		c.OpenDuration = 30 * time.Second
+	}
+	return c
+}
+
+// HealthChecker is implemented by providers that can be probed without moving money,
+// used by the health check job to notice outages and recoveries between payments
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+}
+
+// ProviderHealth is a snapshot of a provider's circuit breaker
+type ProviderHealth struct {
+	Provider            string       `json:"provider"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	LastError           string       `json:"last_error,omitempty"`
+	LastFailureAt       *time.Time   `json:"last_failure_at,omitempty"`
+	LastSuccessAt       *time.Time   `json:"last_success_at,omitempty"`
+	OpenUntil           *time.Time   `json:"open_until,omitempty"`
+}
+
+// IsOutage reports whether err means the provider could not serve the request, as
+// opposed to answering it: transport failures, timeouts, rate limiting and 5xx
+// responses. Declines and other client errors show the provider is up.
+func IsOutage(err error) bool {
+	if err == nil || errors.Is(err, ErrUnsupported) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var perr *ProviderError
+	if errors.As(err, &perr) {
+		return perr.StatusCode >= 500 || perr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// circuitBreaker tracks one provider's consecutive outages
+type circuitBreaker struct {
+	mu  sync.Mutex
+	cfg BreakerConfig
+	now func() time.Time
+
+	state         CircuitState
+	failures      int
+	openedAt      time.Time
+	lastError     string
+	lastFailureAt time.Time
+	lastSuccessAt time.Time
+}
+
+func newCircuitBreaker(cfg BreakerConfig) *circuitBreaker {
+	return &circuitBreaker{cfg: cfg.withDefaults(), now: time.Now, state: CircuitClosed}
+}
+
+// allow reports whether a request may be sent. An open circuit turns half-open once
+// its open period is over, letting requests through until one succeeds or fails.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && !b.now().Before(b.openedAt.Add(b.cfg.OpenDuration)) {
+		b.state = CircuitHalfOpen
+	}
+	return b.state != CircuitOpen
+}
+
+// record feeds the outcome of a request to the breaker
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !IsOutage(err) {
+		b.state = CircuitClosed
+		b.failures = 0
+		b.lastSuccessAt = now
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	b.lastFailureAt = now
+	if b.state == CircuitHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.state = CircuitOpen
+		b.openedAt = now
+	}
+}
+
+func (b *circuitBreaker) snapshot(name string) ProviderHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health := ProviderHealth{
+		Provider:            name,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+	if !b.lastFailureAt.IsZero() {
+		t := b.lastFailureAt
+		health.LastFailureAt = &t
+	}
+	if !b.lastSuccessAt.IsZero() {
+		t := b.lastSuccessAt
+		health.LastSuccessAt = &t
+	}
+	if b.state == CircuitOpen {
+		t := b.openedAt.Add(b.cfg.OpenDuration)
+		health.OpenUntil = &t
+	}
+	return health
+}
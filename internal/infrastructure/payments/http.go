@@ -0,0 +1,90 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+
+	"Krafti_Vibe/internal/pkg/chaos"
+)
+
+// maxResponseBytes bounds provider responses read into memory
+const maxResponseBytes = 1 << 20
+
+// zeroDecimalCurrencies have no minor unit; amounts are sent as whole numbers
+var zeroDecimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true,
+	"KRW": true, "MGA": true, "PYG": true, "RWF": true, "UGX": true, "VND": true,
+	"VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+// toMinorUnits converts a major-unit amount to the integer minor units processors expect
+func toMinorUnits(amount float64, currency string) int64 {
+	if zeroDecimalCurrencies[strings.ToUpper(currency)] {
+		return int64(math.Round(amount))
+	}
+	return int64(math.Round(amount * 100))
+}
+
+// fromMinorUnits converts integer minor units back to a major-unit amount
+func fromMinorUnits(amount int64, currency string) float64 {
+	if zeroDecimalCurrencies[strings.ToUpper(currency)] {
+		return float64(amount)
+	}
+	return float64(amount) / 100
+}
+
+// doRequest sends the request and decodes a JSON response into out. Non-2xx
+// responses are returned as errors built by parseErr from the body. Requests whose
+// context drops payments fail as a transport failure would.
+func doRequest(ctx context.Context, client *http.Client, req *http.Request, out any, parseErr func(status int, body []byte) error) error {
+	if err := chaos.Check(ctx, chaos.DependencyPayments); err != nil {
+		return err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return parseErr(resp.StatusCode, body)
+	}
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// newJSONRequest builds a request with a JSON body
+func newJSONRequest(method, url string, body any) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
@@ -0,0 +1,338 @@
+package payments
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mpesaPendingCode is returned by the STK query while the customer has not yet responded
+const mpesaPendingCode = "500.001.1001"
+
+// mpesaZone is East Africa Time, in which Daraja expects request timestamps
+var mpesaZone = time.FixedZone("EAT", 3*60*60)
+
+// MPesaConfig holds the Daraja API credentials for an M-Pesa paybill or till
+type MPesaConfig struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	ShortCode      string // Paybill or till number receiving the payment
+	Passkey        string // Lipa Na M-Pesa Online passkey
+	CallbackURL    string // Public base URL of the callback endpoint; the callback token is appended
+	CallbackToken  string // Secret path segment authenticating callbacks, which M-Pesa does not sign
+	Sandbox        bool
+}
+
+// MPesaProvider drives Safaricom's Daraja API using Lipa Na M-Pesa Online (STK push):
+// the customer confirms the payment on their phone and M-Pesa posts the result to
+// the callback URL. Payments are identified by the CheckoutRequestID.
+type MPesaProvider struct {
+	cfg     MPesaConfig
+	baseURL string
+	client  *http.Client
+	now     func() time.Time
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// NewMPesaProvider creates an M-Pesa driver
+func NewMPesaProvider(cfg MPesaConfig, client *http.Client) *MPesaProvider {
+	baseURL := "https://api.safaricom.co.ke"
+	if cfg.Sandbox {
+		baseURL = "https://sandbox.safaricom.co.ke"
+	}
+	return &MPesaProvider{
+		cfg:     cfg,
+		baseURL: baseURL,
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+// Name returns "mpesa"
+func (p *MPesaProvider) Name() string { return ProviderMPesa }
+
+// CreateIntent sends an STK push prompt to the customer's phone. M-Pesa charges whole
+// Kenyan shillings and settles immediately, so manual capture is not supported.
+func (p *MPesaProvider) CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error) {
+	if req.ManualCapture {
+		return nil, fmt.Errorf("%w: mpesa manual capture", ErrUnsupported)
+	}
+	if !strings.EqualFold(req.Currency, "KES") {
+		return nil, &ProviderError{Provider: ProviderMPesa, StatusCode: http.StatusBadRequest, Message: "M-Pesa only accepts KES"}
+	}
+	phone, err := NormalizeMSISDN(req.PhoneNumber)
+	if err != nil {
+		return nil, &ProviderError{Provider: ProviderMPesa, StatusCode: http.StatusBadRequest, Message: err.Error()}
+	}
+	amount := int64(math.Ceil(req.Amount))
+	if amount < 1 {
+		return nil, &ProviderError{Provider: ProviderMPesa, StatusCode: http.StatusBadRequest, Message: "amount must be at least 1 KES"}
+	}
+
+	timestamp, password := p.password()
+	body := map[string]any{
+		"BusinessShortCode": p.cfg.ShortCode,
+		"Password":          password,
+		"Timestamp":         timestamp,
+		"TransactionType":   "CustomerPayBillOnline",
+		"Amount":            amount,
+		"PartyA":            phone,
+		"PartyB":            p.cfg.ShortCode,
+		"PhoneNumber":       phone,
+		"CallBackURL":       strings.TrimRight(p.cfg.CallbackURL, "/") + "/" + p.cfg.CallbackToken,
+		"AccountReference":  truncate(req.Reference, 12),
+		"TransactionDesc":   truncate(firstNonEmpty(req.Description, "Payment"), 13),
+	}
+
+	var resp struct {
+		CheckoutRequestID   string `json:"CheckoutRequestID"`
+		ResponseCode        string `json:"ResponseCode"`
+		ResponseDescription string `json:"ResponseDescription"`
+	}
+	if err := p.call(ctx, "/mpesa/stkpush/v1/processrequest", body, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ResponseCode != "0" {
+		return nil, &ProviderError{Provider: ProviderMPesa, StatusCode: http.StatusBadRequest, Code: resp.ResponseCode, Message: resp.ResponseDescription}
+	}
+
+	return &Intent{
+		ProviderPaymentID: resp.CheckoutRequestID,
+		Status:            IntentProcessing, // Awaiting the customer's PIN on their phone
+		Amount:            float64(amount),
+		Currency:          "KES",
+	}, nil
+}
+
+// Capture reports the STK push result; M-Pesa settles as soon as the customer confirms
+func (p *MPesaProvider) Capture(ctx context.Context, providerPaymentID string, amount float64, currency string) (*Intent, error) {
+	return p.Status(ctx, providerPaymentID)
+}
+
+// Refund is not supported; M-Pesa reversals are issued from the M-Pesa business portal
+func (p *MPesaProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	return nil, fmt.Errorf("%w: mpesa refunds", ErrUnsupported)
+}
+
+// Status queries the result of an STK push
+func (p *MPesaProvider) Status(ctx context.Context, providerPaymentID string) (*Intent, error) {
+	timestamp, password := p.password()
+	body := map[string]any{
+		"BusinessShortCode": p.cfg.ShortCode,
+		"Password":          password,
+		"Timestamp":         timestamp,
+		"CheckoutRequestID": providerPaymentID,
+	}
+
+	var resp struct {
+		ResultCode string `json:"ResultCode"`
+		ResultDesc string `json:"ResultDesc"`
+	}
+	if err := p.call(ctx, "/mpesa/stkpushquery/v1/query", body, &resp); err != nil {
+		var perr *ProviderError
+		if errors.As(err, &perr) && perr.Code == mpesaPendingCode {
+			return &Intent{ProviderPaymentID: providerPaymentID, Status: IntentProcessing, Currency: "KES"}, nil
+		}
+		return nil, err
+	}
+
+	intent := &Intent{ProviderPaymentID: providerPaymentID, Currency: "KES"}
+	intent.Status, intent.FailureReason = mpesaResultStatus(resp.ResultCode, resp.ResultDesc)
+	return intent, nil
+}
+
+// VerifyWebhook authenticates an STK push callback by the token in its URL, which the
+// HTTP layer passes in the CallbackTokenHeader, and parses the result
+func (p *MPesaProvider) VerifyWebhook(ctx context.Context, payload []byte, headers http.Header) (*WebhookEvent, error) {
+	if p.cfg.CallbackToken == "" {
+		return nil, fmt.Errorf("%w: mpesa callback token", ErrProviderNotConfigured)
+	}
+	if subtle.ConstantTimeCompare([]byte(headers.Get(CallbackTokenHeader)), []byte(p.cfg.CallbackToken)) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	var callback struct {
+		Body struct {
+			StkCallback struct {
+				CheckoutRequestID string `json:"CheckoutRequestID"`
+				ResultCode        int    `json:"ResultCode"`
+				ResultDesc        string `json:"ResultDesc"`
+				CallbackMetadata  struct {
+					Item []struct {
+						Name  string          `json:"Name"`
+						Value json.RawMessage `json:"Value"`
+					} `json:"Item"`
+				} `json:"CallbackMetadata"`
+			} `json:"stkCallback"`
+		} `json:"Body"`
+	}
+	if err := json.Unmarshal(payload, &callback); err != nil {
+		return nil, fmt.Errorf("decode mpesa callback: %w", err)
+	}
+	result := callback.Body.StkCallback
+	if result.CheckoutRequestID == "" {
+		return nil, fmt.Errorf("decode mpesa callback: missing CheckoutRequestID")
+	}
+
+	// Each STK push produces exactly one callback, so the checkout ID identifies the event
+	event := &WebhookEvent{
+		ID:                result.CheckoutRequestID,
+		Type:              "stk_callback",
+		ProviderPaymentID: result.CheckoutRequestID,
+		Currency:          "KES",
+		OccurredAt:        p.now(),
+	}
+	event.Status, event.FailureReason = mpesaResultStatus(fmt.Sprint(result.ResultCode), result.ResultDesc)
+
+	for _, item := range result.CallbackMetadata.Item {
+		switch item.Name {
+		case "Amount":
+			_ = json.Unmarshal(item.Value, &event.Amount)
+		case "MpesaReceiptNumber":
+			_ = json.Unmarshal(item.Value, &event.ReceiptNumber)
+		case "TransactionDate":
+			var date json.Number
+			if json.Unmarshal(item.Value, &date) == nil {
+				if t, err := time.ParseInLocation("20060102150405", date.String(), mpesaZone); err == nil {
+					event.OccurredAt = t
+				}
+			}
+		}
+	}
+	return event, nil
+}
+
+// password returns the request timestamp and the matching STK password,
+// base64(shortcode + passkey + timestamp)
+func (p *MPesaProvider) password() (string, string) {
+	timestamp := p.now().In(mpesaZone).Format("20060102150405")
+	return timestamp, base64.StdEncoding.EncodeToString([]byte(p.cfg.ShortCode + p.cfg.Passkey + timestamp))
+}
+
+// Ping checks that Daraja is reachable and accepts the credentials by fetching a fresh
+// access token
+func (p *MPesaProvider) Ping(ctx context.Context) error {
+	p.mu.Lock()
+	p.tokenExpiry = time.Time{}
+	p.mu.Unlock()
+
+	_, err := p.token(ctx)
+	return err
+}
+
+// token returns a cached OAuth access token, fetching a new one shortly before expiry
+func (p *MPesaProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.accessToken != "" && p.now().Before(p.tokenExpiry) {
+		return p.accessToken, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, p.baseURL+"/oauth/v1/generate?grant_type=client_credentials", nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(p.cfg.ConsumerKey, p.cfg.ConsumerSecret)
+
+	var token struct {
+		AccessToken string      `json:"access_token"`
+		ExpiresIn   json.Number `json:"expires_in"` // Daraja sends a quoted number
+	}
+	if err := doRequest(ctx, p.client, req, &token, p.parseError); err != nil {
+		return "", err
+	}
+	seconds, _ := token.ExpiresIn.Int64()
+
+	p.accessToken = token.AccessToken
+	p.tokenExpiry = p.now().Add(time.Duration(seconds)*time.Second - time.Minute)
+	return p.accessToken, nil
+}
+
+func (p *MPesaProvider) call(ctx context.Context, path string, body any, out any) error {
+	token, err := p.token(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := newJSONRequest(http.MethodPost, p.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return doRequest(ctx, p.client, req, out, p.parseError)
+}
+
+func (p *MPesaProvider) parseError(status int, body []byte) error {
+	var payload struct {
+		ErrorCode    string `json:"errorCode"`
+		ErrorMessage string `json:"errorMessage"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	message := payload.ErrorMessage
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return &ProviderError{Provider: ProviderMPesa, StatusCode: status, Code: payload.ErrorCode, Message: message}
+}
+
+// mpesaResultStatus maps an STK result code to an intent status and failure reason
+func mpesaResultStatus(code, description string) (IntentStatus, string) {
+	switch code {
+	case "0":
+		return IntentSucceeded, ""
+	case "1032": // Request cancelled by the customer
+		return IntentCanceled, description
+	default: // 1 insufficient balance, 1037 phone unreachable, 2001 wrong PIN, ...
+		return IntentFailed, description
+	}
+}
+
+// NormalizeMSISDN converts a Kenyan phone number (0712..., +254712..., 254712...)
+// to the 2547XXXXXXXX / 2541XXXXXXXX form M-Pesa expects
+func NormalizeMSISDN(phone string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+
+	switch {
+	case strings.HasPrefix(digits, "254"):
+	case strings.HasPrefix(digits, "0"):
+		digits = "254" + digits[1:]
+	case len(digits) == 9:
+		digits = "254" + digits
+	}
+	if len(digits) != 12 || !strings.HasPrefix(digits, "254") || (digits[3] != '7' && digits[3] != '1') {
+		return "", fmt.Errorf("invalid M-Pesa phone number %q", phone)
+	}
+	return digits, nil
+}
+
+func truncate(s string, max int) string {
+	if len(s) > max {
+		return s[:max]
+	}
+	return s
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
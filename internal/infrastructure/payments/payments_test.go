@@ -0,0 +1,534 @@
+package payments_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/infrastructure/payments"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redirectTransport sends every request to the test server regardless of host
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func testClient(t *testing.T, handler http.HandlerFunc) *http.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return &http.Client{Transport: redirectTransport{target: target}}
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	registry := payments.NewRegistryFromConfig(payments.Config{
+		DefaultProvider:   "Stripe",
+		StripeSecretKey:   "sk_test",
+		PaystackSecretKey: "sk_paystack",
+	})
+
+	assert.Equal(t, []string{payments.ProviderPaystack, payments.ProviderStripe}, registry.Names())
+
+	provider, err := registry.Resolve("")
+	require.NoError(t, err)
+	assert.Equal(t, payments.ProviderStripe, provider.Name())
+
+	provider, err = registry.Resolve("paystack")
+	require.NoError(t, err)
+	assert.Equal(t, payments.ProviderPaystack, provider.Name())
+
+	_, err = registry.Resolve(payments.ProviderPayPal)
+	assert.ErrorIs(t, err, payments.ErrProviderNotConfigured)
+
+	var empty *payments.Registry
+	_, err = empty.Resolve("")
+	assert.ErrorIs(t, err, payments.ErrProviderNotConfigured)
+}
+
+func TestRegistry_RouteFailsOver(t *testing.T) {
+	registry := payments.NewRegistryFromConfig(payments.Config{
+		DefaultProvider:   payments.ProviderStripe,
+		StripeSecretKey:   "sk_test",
+		PaystackSecretKey: "sk_paystack",
+		CircuitBreaker:    payments.BreakerConfig{FailureThreshold: 2, OpenDuration: 50 * time.Millisecond},
+	})
+
+	route, err := registry.Route("", payments.ProviderPaystack)
+	require.NoError(t, err)
+	require.Len(t, route, 2)
+	assert.Equal(t, payments.ProviderStripe, route[0].Name())
+	assert.Equal(t, payments.ProviderPaystack, route[1].Name())
+
+	// Declines show the provider is up and never open the circuit
+	declined := &payments.ProviderError{Provider: payments.ProviderStripe, StatusCode: http.StatusPaymentRequired}
+	for range 3 {
+		registry.RecordResult(payments.ProviderStripe, declined)
+	}
+	assert.True(t, registry.Available(payments.ProviderStripe))
+
+	outage := &payments.ProviderError{Provider: payments.ProviderStripe, StatusCode: http.StatusBadGateway}
+	registry.RecordResult(payments.ProviderStripe, outage)
+	registry.RecordResult(payments.ProviderStripe, outage)
+	assert.False(t, registry.Available(payments.ProviderStripe))
+
+	route, err = registry.Route("", payments.ProviderPaystack)
+	require.NoError(t, err)
+	require.Len(t, route, 1)
+	assert.Equal(t, payments.ProviderPaystack, route[0].Name())
+
+	_, err = registry.Route("", "")
+	assert.ErrorIs(t, err, payments.ErrCircuitOpen)
+
+	health := registry.Health()
+	require.Len(t, health, 2)
+	assert.Equal(t, payments.ProviderStripe, health[1].Provider)
+	assert.Equal(t, payments.CircuitOpen, health[1].State)
+	assert.Equal(t, 2, health[1].ConsecutiveFailures)
+	assert.NotNil(t, health[1].OpenUntil)
+
+	// Half-open after the open period; one more outage reopens it, a success closes it
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, registry.Available(payments.ProviderStripe))
+	registry.RecordResult(payments.ProviderStripe, outage)
+	assert.False(t, registry.Available(payments.ProviderStripe))
+
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, registry.Available(payments.ProviderStripe))
+	registry.RecordResult(payments.ProviderStripe, nil)
+	assert.Equal(t, payments.CircuitClosed, registry.Health()[1].State)
+}
+
+func TestRegistry_CheckHealth(t *testing.T) {
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/balance", r.URL.Path)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	registry := payments.NewRegistryFromConfig(payments.Config{
+		StripeSecretKey: "sk_test",
+		HTTPClient:      client,
+		CircuitBreaker:  payments.BreakerConfig{FailureThreshold: 1},
+	})
+
+	health := registry.CheckHealth(context.Background())
+	require.Len(t, health, 1)
+	assert.Equal(t, payments.CircuitOpen, health[0].State)
+	assert.NotEmpty(t, health[0].LastError)
+}
+
+func TestIsOutage(t *testing.T) {
+	assert.False(t, payments.IsOutage(nil))
+	assert.False(t, payments.IsOutage(payments.ErrUnsupported))
+	assert.False(t, payments.IsOutage(context.Canceled))
+	assert.False(t, payments.IsOutage(&payments.ProviderError{StatusCode: http.StatusBadRequest}))
+	assert.True(t, payments.IsOutage(&payments.ProviderError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, payments.IsOutage(&payments.ProviderError{StatusCode: http.StatusInternalServerError}))
+	assert.True(t, payments.IsOutage(context.DeadlineExceeded))
+}
+
+func TestStripeProvider_CreateIntent(t *testing.T) {
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/payment_intents", r.URL.Path)
+		user, _, _ := r.BasicAuth()
+		assert.Equal(t, "sk_test", user)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "1250", r.PostForm.Get("amount"))
+		assert.Equal(t, "eur", r.PostForm.Get("currency"))
+		assert.Equal(t, "manual", r.PostForm.Get("capture_method"))
+		assert.Equal(t, "pay_1", r.PostForm.Get("metadata[reference]"))
+		assert.NotEmpty(t, r.Header.Get("Idempotency-Key"))
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id": "pi_1", "status": "requires_payment_method", "amount": 1250, "currency": "eur", "client_secret": "pi_1_secret",
+		})
+	})
+	provider := payments.NewStripeProvider("sk_test", "whsec", client)
+
+	intent, err := provider.CreateIntent(context.Background(), payments.IntentRequest{
+		Amount: 12.5, Currency: "EUR", Reference: "pay_1", ManualCapture: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pi_1", intent.ProviderPaymentID)
+	assert.Equal(t, payments.IntentRequiresAction, intent.Status)
+	assert.Equal(t, 12.5, intent.Amount)
+	assert.Equal(t, "EUR", intent.Currency)
+	assert.Equal(t, "pi_1_secret", intent.ClientSecret)
+}
+
+func TestStripeProvider_ErrorResponse(t *testing.T) {
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		_, _ = w.Write([]byte(`{"error":{"code":"card_declined","message":"Your card was declined."}}`))
+	})
+	provider := payments.NewStripeProvider("sk_test", "whsec", client)
+
+	_, err := provider.Refund(context.Background(), payments.RefundRequest{ProviderPaymentID: "pi_1", Amount: 5, Currency: "USD"})
+	var providerErr *payments.ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.Equal(t, http.StatusPaymentRequired, providerErr.StatusCode)
+	assert.Equal(t, "card_declined", providerErr.Code)
+}
+
+func TestStripeProvider_Transfer(t *testing.T) {
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/transfers", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "4200", r.PostForm.Get("amount"))
+		assert.Equal(t, "usd", r.PostForm.Get("currency"))
+		assert.Equal(t, "acct_1", r.PostForm.Get("destination"))
+		assert.Equal(t, "payout_1", r.PostForm.Get("transfer_group"))
+		assert.Equal(t, "/v1/transfers:payout_1", r.Header.Get("Idempotency-Key"))
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "tr_1", "reversed": false})
+	})
+	provider := payments.NewStripeProvider("sk_test", "whsec", client)
+
+	transfer, err := provider.Transfer(context.Background(), payments.TransferRequest{
+		Destination: "acct_1", Amount: 42, Currency: "USD", Reference: "payout_1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tr_1", transfer.ProviderTransferID)
+	assert.Equal(t, payments.IntentSucceeded, transfer.Status)
+}
+
+func TestStripeProvider_VerifyWebhook(t *testing.T) {
+	provider := payments.NewStripeProvider("sk_test", "whsec_test", http.DefaultClient)
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","created":1700000000,` +
+		`"data":{"object":{"id":"pi_1","status":"succeeded","amount":1000,"currency":"jpy","metadata":{"reference":"pay_1"}}}}`)
+
+	sign := func(ts int64, secret string) http.Header {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(strconv.FormatInt(ts, 10) + "."))
+		mac.Write(payload)
+		headers := http.Header{}
+		headers.Set("Stripe-Signature", "t="+strconv.FormatInt(ts, 10)+",v1="+hex.EncodeToString(mac.Sum(nil)))
+		return headers
+	}
+
+	event, err := provider.VerifyWebhook(context.Background(), payload, sign(time.Now().Unix(), "whsec_test"))
+	require.NoError(t, err)
+	assert.Equal(t, "evt_1", event.ID)
+	assert.Equal(t, "pi_1", event.ProviderPaymentID)
+	assert.Equal(t, "pay_1", event.Reference)
+	assert.Equal(t, payments.IntentSucceeded, event.Status)
+	assert.Equal(t, float64(1000), event.Amount, "JPY is zero-decimal")
+
+	_, err = provider.VerifyWebhook(context.Background(), payload, sign(time.Now().Unix(), "wrong"))
+	assert.ErrorIs(t, err, payments.ErrInvalidSignature)
+
+	_, err = provider.VerifyWebhook(context.Background(), payload, sign(time.Now().Add(-time.Hour).Unix(), "whsec_test"))
+	assert.ErrorIs(t, err, payments.ErrInvalidSignature, "stale timestamps are rejected")
+}
+
+func TestPaystackProvider_CreateIntent(t *testing.T) {
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/transaction/initialize", r.URL.Path)
+		assert.Equal(t, "Bearer sk_paystack", r.Header.Get("Authorization"))
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.EqualValues(t, 500000, body["amount"])
+		assert.Equal(t, "NGN", body["currency"])
+		assert.Equal(t, "pay_1", body["reference"])
+
+		_, _ = w.Write([]byte(`{"status":true,"message":"ok","data":{"authorization_url":"https://checkout.paystack.com/abc","reference":"pay_1"}}`))
+	})
+	provider := payments.NewPaystackProvider("sk_paystack", client)
+
+	intent, err := provider.CreateIntent(context.Background(), payments.IntentRequest{
+		Amount: 5000, Currency: "ngn", Reference: "pay_1", CustomerEmail: "ada@example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pay_1", intent.ProviderPaymentID)
+	assert.Equal(t, payments.IntentRequiresAction, intent.Status)
+	assert.Equal(t, "https://checkout.paystack.com/abc", intent.RedirectURL)
+
+	_, err = provider.CreateIntent(context.Background(), payments.IntentRequest{
+		Amount: 5000, Currency: "NGN", Reference: "pay_2", CustomerEmail: "ada@example.com", ManualCapture: true,
+	})
+	assert.ErrorIs(t, err, payments.ErrUnsupported)
+}
+
+func TestPaystackProvider_VerifyWebhook(t *testing.T) {
+	provider := payments.NewPaystackProvider("sk_paystack", http.DefaultClient)
+	payload := []byte(`{"event":"charge.success","data":{"id":42,"status":"success","reference":"pay_1","amount":250050,"currency":"GHS"}}`)
+
+	mac := hmac.New(sha512.New, []byte("sk_paystack"))
+	mac.Write(payload)
+	headers := http.Header{}
+	headers.Set("X-Paystack-Signature", hex.EncodeToString(mac.Sum(nil)))
+
+	event, err := provider.VerifyWebhook(context.Background(), payload, headers)
+	require.NoError(t, err)
+	assert.Equal(t, "charge.success:42", event.ID)
+	assert.Equal(t, "pay_1", event.ProviderPaymentID)
+	assert.Equal(t, payments.IntentSucceeded, event.Status)
+	assert.Equal(t, 2500.5, event.Amount)
+
+	headers.Set("X-Paystack-Signature", hex.EncodeToString([]byte("forged")))
+	_, err = provider.VerifyWebhook(context.Background(), payload, headers)
+	assert.ErrorIs(t, err, payments.ErrInvalidSignature)
+}
+
+func TestPayPalProvider_CreateIntent(t *testing.T) {
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/oauth2/token":
+			user, pass, _ := r.BasicAuth()
+			assert.Equal(t, "client", user)
+			assert.Equal(t, "secret", pass)
+			_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+		case "/v2/checkout/orders":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			var body struct {
+				Intent        string `json:"intent"`
+				PurchaseUnits []struct {
+					Amount struct {
+						CurrencyCode string `json:"currency_code"`
+						Value        string `json:"value"`
+					} `json:"amount"`
+				} `json:"purchase_units"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "CAPTURE", body.Intent)
+			require.Len(t, body.PurchaseUnits, 1)
+			assert.Equal(t, "19.90", body.PurchaseUnits[0].Amount.Value)
+
+			_, _ = w.Write([]byte(`{"id":"ORDER1","intent":"CAPTURE","status":"CREATED",` +
+				`"purchase_units":[{"amount":{"currency_code":"EUR","value":"19.90"}}],` +
+				`"links":[{"rel":"approve","href":"https://www.paypal.com/checkoutnow?token=ORDER1"}]}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+	provider := payments.NewPayPalProvider("client", "secret", "hook", true, client)
+
+	intent, err := provider.CreateIntent(context.Background(), payments.IntentRequest{Amount: 19.9, Currency: "EUR", Reference: "pay_1"})
+	require.NoError(t, err)
+	assert.Equal(t, "ORDER1", intent.ProviderPaymentID)
+	assert.Equal(t, payments.IntentRequiresAction, intent.Status)
+	assert.Equal(t, "https://www.paypal.com/checkoutnow?token=ORDER1", intent.RedirectURL)
+	assert.Equal(t, 19.9, intent.Amount)
+}
+
+func TestNormalizeMSISDN(t *testing.T) {
+	for input, want := range map[string]string{
+		"0712345678":       "254712345678",
+		"+254 712 345 678": "254712345678",
+		"254112345678":     "254112345678",
+		"712345678":        "254712345678",
+	} {
+		got, err := payments.NormalizeMSISDN(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "12345", "0812345678", "+44 7700 900123"} {
+		_, err := payments.NormalizeMSISDN(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestMPesaProvider_STKPush(t *testing.T) {
+	pending := true
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/v1/generate":
+			user, pass, _ := r.BasicAuth()
+			assert.Equal(t, "key", user)
+			assert.Equal(t, "secret", pass)
+			_, _ = w.Write([]byte(`{"access_token":"token","expires_in":"3599"}`))
+		case "/mpesa/stkpush/v1/processrequest":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.EqualValues(t, 1501, body["Amount"], "amounts are rounded up to whole shillings")
+			assert.Equal(t, "254712345678", body["PhoneNumber"])
+			assert.Equal(t, "174379", body["PartyB"])
+			assert.Equal(t, "https://api.example.com/callbacks/mpesa/cb-token", body["CallBackURL"])
+			assert.NotEmpty(t, body["Password"])
+			_, _ = w.Write([]byte(`{"MerchantRequestID":"m-1","CheckoutRequestID":"ws_CO_1","ResponseCode":"0","ResponseDescription":"Success"}`))
+		case "/mpesa/stkpushquery/v1/query":
+			if pending {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"errorCode":"500.001.1001","errorMessage":"The transaction is being processed"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ResponseCode":"0","ResultCode":"1032","ResultDesc":"Request cancelled by user"}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+	provider := payments.NewMPesaProvider(payments.MPesaConfig{
+		ConsumerKey: "key", ConsumerSecret: "secret", ShortCode: "174379", Passkey: "passkey",
+		CallbackURL: "https://api.example.com/callbacks/mpesa/", CallbackToken: "cb-token", Sandbox: true,
+	}, client)
+
+	intent, err := provider.CreateIntent(context.Background(), payments.IntentRequest{
+		Amount: 1500.2, Currency: "KES", Reference: "pay_1", PhoneNumber: "0712 345 678",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ws_CO_1", intent.ProviderPaymentID)
+	assert.Equal(t, payments.IntentProcessing, intent.Status)
+
+	status, err := provider.Status(context.Background(), "ws_CO_1")
+	require.NoError(t, err)
+	assert.Equal(t, payments.IntentProcessing, status.Status, "a pending push is still processing")
+
+	pending = false
+	status, err = provider.Status(context.Background(), "ws_CO_1")
+	require.NoError(t, err)
+	assert.Equal(t, payments.IntentCanceled, status.Status)
+
+	_, err = provider.CreateIntent(context.Background(), payments.IntentRequest{Amount: 10, Currency: "USD", PhoneNumber: "0712345678"})
+	var providerErr *payments.ProviderError
+	assert.ErrorAs(t, err, &providerErr)
+
+	_, err = provider.Refund(context.Background(), payments.RefundRequest{ProviderPaymentID: "ws_CO_1", Amount: 10, Currency: "KES"})
+	assert.ErrorIs(t, err, payments.ErrUnsupported)
+}
+
+func TestMPesaProvider_VerifyWebhook(t *testing.T) {
+	provider := payments.NewMPesaProvider(payments.MPesaConfig{
+		ConsumerKey: "key", ConsumerSecret: "secret", ShortCode: "174379", Passkey: "passkey", CallbackToken: "cb-token",
+	}, http.DefaultClient)
+	payload := []byte(`{"Body":{"stkCallback":{"MerchantRequestID":"m-1","CheckoutRequestID":"ws_CO_1","ResultCode":0,` +
+		`"ResultDesc":"The service request is processed successfully.","CallbackMetadata":{"Item":[` +
+		`{"Name":"Amount","Value":1501.00},{"Name":"MpesaReceiptNumber","Value":"NLJ7RT61SV"},` +
+		`{"Name":"TransactionDate","Value":20240119102115},{"Name":"PhoneNumber","Value":254712345678}]}}}}`)
+
+	headers := http.Header{}
+	headers.Set(payments.CallbackTokenHeader, "cb-token")
+	event, err := provider.VerifyWebhook(context.Background(), payload, headers)
+	require.NoError(t, err)
+	assert.Equal(t, "ws_CO_1", event.ProviderPaymentID)
+	assert.Equal(t, payments.IntentSucceeded, event.Status)
+	assert.Equal(t, float64(1501), event.Amount)
+	assert.Equal(t, "NLJ7RT61SV", event.ReceiptNumber)
+	assert.Equal(t, time.Date(2024, 1, 19, 7, 21, 15, 0, time.UTC), event.OccurredAt.UTC())
+
+	headers.Set(payments.CallbackTokenHeader, "guess")
+	_, err = provider.VerifyWebhook(context.Background(), payload, headers)
+	assert.ErrorIs(t, err, payments.ErrInvalidSignature)
+}
+
+func TestStripeBilling_CreateSubscription(t *testing.T) {
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/subscriptions", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "cus_1", r.PostForm.Get("customer"))
+		assert.Equal(t, "price_pro", r.PostForm.Get("items[0][price]"))
+		assert.Equal(t, "default_incomplete", r.PostForm.Get("payment_behavior"))
+		assert.Equal(t, "tenant_1", r.PostForm.Get("metadata[tenant_id]"))
+		assert.Empty(t, r.PostForm.Get("trial_period_days"))
+
+		_, _ = w.Write([]byte(`{"id":"sub_1","customer":"cus_1","status":"incomplete","metadata":{"tenant_id":"tenant_1"},` +
+			`"items":{"data":[{"id":"si_1","current_period_start":1700000000,"current_period_end":1702592000,"price":{"id":"price_pro"}}]},` +
+			`"latest_invoice":{"id":"in_1","payment_intent":{"id":"pi_1","client_secret":"pi_1_secret"}}}`))
+	})
+	billing := payments.NewStripeBilling("sk_test", "whsec", client)
+
+	sub, err := billing.CreateSubscription(context.Background(), payments.BillingSubscriptionRequest{
+		CustomerID: "cus_1", PriceID: "price_pro", Reference: "tenant_1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sub_1", sub.ID)
+	assert.Equal(t, payments.BillingIncomplete, sub.Status)
+	assert.Equal(t, "price_pro", sub.PriceID)
+	assert.Equal(t, "tenant_1", sub.Reference)
+	assert.Equal(t, "pi_1_secret", sub.ClientSecret)
+	assert.Equal(t, int64(1702592000), sub.CurrentPeriodEnd.Unix(), "period is read from the item on newer API versions")
+}
+
+func TestStripeBilling_VerifyWebhook(t *testing.T) {
+	billing := payments.NewStripeBilling("sk_test", "whsec_billing", http.DefaultClient)
+	sign := func(payload []byte, secret string) http.Header {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(ts + "."))
+		mac.Write(payload)
+		headers := http.Header{}
+		headers.Set("Stripe-Signature", "t="+ts+",v1="+hex.EncodeToString(mac.Sum(nil)))
+		return headers
+	}
+
+	failed := []byte(`{"id":"evt_1","type":"invoice.payment_failed","created":1700000000,"data":{"object":` +
+		`{"id":"in_1","customer":"cus_1","amount_paid":0,"currency":"usd","attempt_count":2,` +
+		`"parent":{"subscription_details":{"subscription":"sub_1"}}}}}`)
+	event, err := billing.VerifyWebhook(context.Background(), failed, sign(failed, "whsec_billing"))
+	require.NoError(t, err)
+	assert.Equal(t, payments.BillingEventInvoiceFailed, event.Type)
+	assert.Equal(t, "sub_1", event.SubscriptionID)
+	assert.Equal(t, "cus_1", event.CustomerID)
+	assert.Equal(t, 2, event.AttemptCount)
+
+	deleted := []byte(`{"id":"evt_2","type":"customer.subscription.deleted","created":1700000000,"data":{"object":` +
+		`{"id":"sub_1","customer":"cus_1","status":"canceled","items":{"data":[{"id":"si_1","price":{"id":"price_pro"}}]}}}}`)
+	event, err = billing.VerifyWebhook(context.Background(), deleted, sign(deleted, "whsec_billing"))
+	require.NoError(t, err)
+	assert.Equal(t, payments.BillingEventSubscriptionCanceled, event.Type)
+	require.NotNil(t, event.Subscription)
+	assert.Equal(t, payments.BillingCanceled, event.Subscription.Status)
+
+	ignored := []byte(`{"id":"evt_3","type":"customer.created","created":1700000000,"data":{"object":{"id":"cus_1"}}}`)
+	event, err = billing.VerifyWebhook(context.Background(), ignored, sign(ignored, "whsec_billing"))
+	require.NoError(t, err)
+	assert.Empty(t, event.Type)
+
+	_, err = billing.VerifyWebhook(context.Background(), failed, sign(failed, "whsec_payments"))
+	assert.ErrorIs(t, err, payments.ErrInvalidSignature)
+}
+
+func TestStripeProvider_ListBalanceTransactions(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v1/balance_transactions", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, strconv.FormatInt(from.Unix(), 10), query.Get("created[gte]"))
+		assert.Equal(t, "data.source", query.Get("expand[]"))
+		calls++
+
+		if query.Get("starting_after") == "" {
+			_, _ = w.Write([]byte(`{"has_more":true,"data":[{"id":"txn_1","type":"charge","amount":2500,"fee":103,"currency":"usd",` +
+				`"created":1740800000,"source":{"id":"ch_1","payment_intent":"pi_1"}}]}`))
+			return
+		}
+		assert.Equal(t, "txn_1", query.Get("starting_after"))
+		_, _ = w.Write([]byte(`{"has_more":false,"data":[{"id":"txn_2","type":"payment_refund","amount":-500,"fee":0,"currency":"usd",` +
+			`"created":1740800100,"source":{"id":"re_1","payment_intent":"pi_1"}},` +
+			`{"id":"txn_3","type":"payout","amount":-10000,"fee":0,"currency":"usd","created":1740800200,"source":"po_1"}]}`))
+	})
+	provider := payments.NewStripeProvider("sk_test", "whsec", client)
+
+	txns, err := provider.ListBalanceTransactions(context.Background(), from, from.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "pages are followed until has_more is false")
+	require.Len(t, txns, 3)
+	assert.Equal(t, payments.BalanceCharge, txns[0].Type)
+	assert.Equal(t, "pi_1", txns[0].ProviderPaymentID)
+	assert.Equal(t, 25.0, txns[0].Amount)
+	assert.Equal(t, 1.03, txns[0].Fee)
+	assert.Equal(t, "USD", txns[0].Currency)
+	assert.Equal(t, payments.BalanceRefund, txns[1].Type)
+	assert.Equal(t, "payout", txns[2].Type)
+	assert.Empty(t, txns[2].ProviderPaymentID)
+}
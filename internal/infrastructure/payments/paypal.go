@@ -0,0 +1,409 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PayPalProvider drives the PayPal Orders v2 API
+type PayPalProvider struct {
+	clientID     string
+	clientSecret string
+	webhookID    string
+	baseURL      string
+	client       *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// NewPayPalProvider creates a PayPal driver; sandbox selects the PayPal sandbox environment
+func NewPayPalProvider(clientID, clientSecret, webhookID string, sandbox bool, client *http.Client) *PayPalProvider {
+	baseURL := "https://api-m.paypal.com"
+	if sandbox {
+		baseURL = "https://api-m.sandbox.paypal.com"
+	}
+	return &PayPalProvider{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		webhookID:    webhookID,
+		baseURL:      baseURL,
+		client:       client,
+	}
+}
+
+// Name returns "paypal"
+func (p *PayPalProvider) Name() string { return ProviderPayPal }
+
+type paypalAmount struct {
+	CurrencyCode string `json:"currency_code"`
+	Value        string `json:"value"`
+}
+
+type paypalOrder struct {
+	ID            string `json:"id"`
+	Intent        string `json:"intent"`
+	Status        string `json:"status"`
+	PurchaseUnits []struct {
+		ReferenceID string        `json:"reference_id"`
+		CustomID    string        `json:"custom_id"`
+		Amount      *paypalAmount `json:"amount"`
+		Payments    struct {
+			Captures []struct {
+				ID     string       `json:"id"`
+				Status string       `json:"status"`
+				Amount paypalAmount `json:"amount"`
+			} `json:"captures"`
+			Authorizations []struct {
+				ID     string       `json:"id"`
+				Status string       `json:"status"`
+				Amount paypalAmount `json:"amount"`
+			} `json:"authorizations"`
+		} `json:"payments"`
+	} `json:"purchase_units"`
+	Links []struct {
+		Href string `json:"href"`
+		Rel  string `json:"rel"`
+	} `json:"links"`
+}
+
+// CreateIntent creates a checkout order; the customer approves it at the returned redirect URL
+func (p *PayPalProvider) CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error) {
+	intent := "CAPTURE"
+	if req.ManualCapture {
+		intent = "AUTHORIZE"
+	}
+
+	unit := map[string]any{
+		"reference_id": req.Reference,
+		"custom_id":    req.Reference,
+		"amount":       paypalAmount{CurrencyCode: strings.ToUpper(req.Currency), Value: formatPayPalAmount(req.Amount, req.Currency)},
+	}
+	if req.Description != "" {
+		unit["description"] = req.Description
+	}
+	body := map[string]any{
+		"intent":         intent,
+		"purchase_units": []any{unit},
+	}
+	if req.ReturnURL != "" || req.CancelURL != "" {
+		body["application_context"] = map[string]string{
+			"return_url": req.ReturnURL,
+			"cancel_url": req.CancelURL,
+		}
+	}
+
+	var order paypalOrder
+	if err := p.call(ctx, http.MethodPost, "/v2/checkout/orders", body, req.Reference, &order); err != nil {
+		return nil, err
+	}
+	return order.toIntent(), nil
+}
+
+// Capture settles an order. A CAPTURE order is captured once the customer approves it;
+// an AUTHORIZE order is authorized on approval and its authorization captured here.
+// PayPal captures the approved amount; partial captures are not supported.
+func (p *PayPalProvider) Capture(ctx context.Context, providerPaymentID string, amount float64, currency string) (*Intent, error) {
+	orderPath := "/v2/checkout/orders/" + url.PathEscape(providerPaymentID)
+
+	var order paypalOrder
+	if err := p.call(ctx, http.MethodGet, orderPath, nil, "", &order); err != nil {
+		return nil, err
+	}
+	if order.Status != "APPROVED" && order.authorizationID() == "" {
+		return order.toIntent(), nil
+	}
+
+	if order.Intent == "AUTHORIZE" {
+		if order.authorizationID() == "" {
+			if err := p.call(ctx, http.MethodPost, orderPath+"/authorize", map[string]any{}, providerPaymentID, &order); err != nil {
+				return nil, err
+			}
+		}
+		if authorizationID := order.authorizationID(); authorizationID != "" {
+			if err := p.call(ctx, http.MethodPost, "/v2/payments/authorizations/"+url.PathEscape(authorizationID)+"/capture", map[string]any{}, providerPaymentID, nil); err != nil {
+				return nil, err
+			}
+		}
+	} else if err := p.call(ctx, http.MethodPost, orderPath+"/capture", map[string]any{}, providerPaymentID, nil); err != nil {
+		return nil, err
+	}
+
+	// Re-read the order so the result reflects the capture
+	if err := p.call(ctx, http.MethodGet, orderPath, nil, "", &order); err != nil {
+		return nil, err
+	}
+	return order.toIntent(), nil
+}
+
+// Status retrieves the order
+func (p *PayPalProvider) Status(ctx context.Context, providerPaymentID string) (*Intent, error) {
+	var order paypalOrder
+	if err := p.call(ctx, http.MethodGet, "/v2/checkout/orders/"+url.PathEscape(providerPaymentID), nil, "", &order); err != nil {
+		return nil, err
+	}
+	return order.toIntent(), nil
+}
+
+// Refund refunds the capture belonging to the order
+func (p *PayPalProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	var order paypalOrder
+	if err := p.call(ctx, http.MethodGet, "/v2/checkout/orders/"+url.PathEscape(req.ProviderPaymentID), nil, "", &order); err != nil {
+		return nil, err
+	}
+	captureID := order.captureID()
+	if captureID == "" {
+		return nil, &ProviderError{Provider: ProviderPayPal, StatusCode: http.StatusConflict, Message: "order has no completed capture to refund"}
+	}
+
+	body := map[string]any{}
+	if req.Amount > 0 {
+		body["amount"] = paypalAmount{CurrencyCode: strings.ToUpper(req.Currency), Value: formatPayPalAmount(req.Amount, req.Currency)}
+	}
+	if req.Reason != "" {
+		body["note_to_payer"] = req.Reason
+	}
+
+	var refund struct {
+		ID     string        `json:"id"`
+		Status string        `json:"status"`
+		Amount *paypalAmount `json:"amount"`
+	}
+	if err := p.call(ctx, http.MethodPost, "/v2/payments/captures/"+url.PathEscape(captureID)+"/refund", body, req.Reference, &refund); err != nil {
+		return nil, err
+	}
+
+	result := &Refund{ProviderRefundID: refund.ID, Status: IntentProcessing, Amount: req.Amount}
+	switch refund.Status {
+	case "COMPLETED":
+		result.Status = IntentSucceeded
+	case "FAILED":
+		result.Status = IntentFailed
+	case "CANCELLED":
+		result.Status = IntentCanceled
+	}
+	if refund.Amount != nil {
+		result.Amount, _ = strconv.ParseFloat(refund.Amount.Value, 64)
+	}
+	return result, nil
+}
+
+// VerifyWebhook asks PayPal to verify the transmission signature of a webhook delivery
+func (p *PayPalProvider) VerifyWebhook(ctx context.Context, payload []byte, headers http.Header) (*WebhookEvent, error) {
+	if p.webhookID == "" {
+		return nil, fmt.Errorf("%w: paypal webhook id", ErrProviderNotConfigured)
+	}
+	if headers.Get("Paypal-Transmission-Sig") == "" {
+		return nil, ErrInvalidSignature
+	}
+
+	verification := map[string]any{
+		"auth_algo":         headers.Get("Paypal-Auth-Algo"),
+		"cert_url":          headers.Get("Paypal-Cert-Url"),
+		"transmission_id":   headers.Get("Paypal-Transmission-Id"),
+		"transmission_sig":  headers.Get("Paypal-Transmission-Sig"),
+		"transmission_time": headers.Get("Paypal-Transmission-Time"),
+		"webhook_id":        p.webhookID,
+		"webhook_event":     json.RawMessage(payload),
+	}
+	var verified struct {
+		VerificationStatus string `json:"verification_status"`
+	}
+	if err := p.call(ctx, http.MethodPost, "/v1/notifications/verify-webhook-signature", verification, "", &verified); err != nil {
+		return nil, err
+	}
+	if verified.VerificationStatus != "SUCCESS" {
+		return nil, ErrInvalidSignature
+	}
+
+	var event struct {
+		ID         string    `json:"id"`
+		EventType  string    `json:"event_type"`
+		CreateTime time.Time `json:"create_time"`
+		Resource   struct {
+			ID                string        `json:"id"`
+			Status            string        `json:"status"`
+			CustomID          string        `json:"custom_id"`
+			Amount            *paypalAmount `json:"amount"`
+			SupplementaryData struct {
+				RelatedIDs struct {
+					OrderID string `json:"order_id"`
+				} `json:"related_ids"`
+			} `json:"supplementary_data"`
+		} `json:"resource"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode paypal event: %w", err)
+	}
+
+	result := &WebhookEvent{
+		ID:         event.ID,
+		Type:       event.EventType,
+		Reference:  event.Resource.CustomID,
+		OccurredAt: event.CreateTime,
+	}
+	// Capture and authorization events carry the order ID in supplementary data;
+	// we store the order ID as the provider payment ID
+	result.ProviderPaymentID = event.Resource.SupplementaryData.RelatedIDs.OrderID
+	if result.ProviderPaymentID == "" && strings.HasPrefix(event.EventType, "CHECKOUT.ORDER.") {
+		result.ProviderPaymentID = event.Resource.ID
+	}
+	if event.Resource.Amount != nil {
+		result.Amount, _ = strconv.ParseFloat(event.Resource.Amount.Value, 64)
+		result.Currency = event.Resource.Amount.CurrencyCode
+	}
+	switch event.EventType {
+	case "PAYMENT.CAPTURE.COMPLETED":
+		result.Status = IntentSucceeded
+	case "PAYMENT.CAPTURE.PENDING":
+		result.Status = IntentProcessing
+	case "PAYMENT.CAPTURE.DENIED", "PAYMENT.CAPTURE.DECLINED":
+		result.Status = IntentFailed
+	case "PAYMENT.AUTHORIZATION.CREATED":
+		result.Status = IntentAuthorized
+	case "PAYMENT.AUTHORIZATION.VOIDED", "CHECKOUT.ORDER.VOIDED":
+		result.Status = IntentCanceled
+	case "CHECKOUT.ORDER.APPROVED":
+		result.Status = IntentRequiresAction
+	}
+	return result, nil
+}
+
+// Ping checks that PayPal is reachable and accepts the credentials by fetching a fresh
+// access token
+func (p *PayPalProvider) Ping(ctx context.Context) error {
+	p.mu.Lock()
+	p.tokenExpiry = time.Time{}
+	p.mu.Unlock()
+
+	_, err := p.token(ctx)
+	return err
+}
+
+// token returns a cached OAuth access token, fetching a new one shortly before expiry
+func (p *PayPalProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.accessToken != "" && time.Now().Before(p.tokenExpiry) {
+		return p.accessToken, nil
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.baseURL+"/v1/oauth2/token", strings.NewReader("grant_type=client_credentials"))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(p.clientID, p.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := doRequest(ctx, p.client, req, &token, p.parseError); err != nil {
+		return "", err
+	}
+
+	p.accessToken = token.AccessToken
+	p.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return p.accessToken, nil
+}
+
+func (p *PayPalProvider) call(ctx context.Context, method, path string, body any, requestID string, out any) error {
+	token, err := p.token(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := newJSONRequest(method, p.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", path+":"+requestID)
+	}
+	return doRequest(ctx, p.client, req, out, p.parseError)
+}
+
+func (p *PayPalProvider) parseError(status int, body []byte) error {
+	var payload struct {
+		Name             string `json:"name"`
+		Message          string `json:"message"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	code, message := payload.Name, payload.Message
+	if code == "" {
+		code, message = payload.Error, payload.ErrorDescription
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return &ProviderError{Provider: ProviderPayPal, StatusCode: status, Code: code, Message: message}
+}
+
+func (o paypalOrder) captureID() string {
+	for _, unit := range o.PurchaseUnits {
+		for _, capture := range unit.Payments.Captures {
+			if capture.Status == "COMPLETED" || capture.Status == "PARTIALLY_REFUNDED" {
+				return capture.ID
+			}
+		}
+	}
+	return ""
+}
+
+func (o paypalOrder) authorizationID() string {
+	for _, unit := range o.PurchaseUnits {
+		for _, authorization := range unit.Payments.Authorizations {
+			if authorization.Status == "CREATED" {
+				return authorization.ID
+			}
+		}
+	}
+	return ""
+}
+
+func (o paypalOrder) toIntent() *Intent {
+	intent := &Intent{ProviderPaymentID: o.ID, Status: IntentRequiresAction}
+	if len(o.PurchaseUnits) > 0 && o.PurchaseUnits[0].Amount != nil {
+		intent.Amount, _ = strconv.ParseFloat(o.PurchaseUnits[0].Amount.Value, 64)
+		intent.Currency = o.PurchaseUnits[0].Amount.CurrencyCode
+	}
+	for _, link := range o.Links {
+		if link.Rel == "approve" || link.Rel == "payer-action" {
+			intent.RedirectURL = link.Href
+		}
+	}
+
+	switch o.Status {
+	case "COMPLETED":
+		intent.Status = IntentSucceeded
+		if o.captureID() == "" && o.authorizationID() != "" {
+			intent.Status = IntentAuthorized
+		}
+	case "APPROVED":
+		intent.Status = IntentRequiresAction
+	case "VOIDED":
+		intent.Status = IntentCanceled
+	}
+	return intent
+}
+
+// formatPayPalAmount renders an amount as the decimal string PayPal expects
+func formatPayPalAmount(amount float64, currency string) string {
+	if zeroDecimalCurrencies[strings.ToUpper(currency)] {
+		return strconv.FormatInt(toMinorUnits(amount, currency), 10)
+	}
+	return strconv.FormatFloat(float64(toMinorUnits(amount, currency))/100, 'f', 2, 64)
+}
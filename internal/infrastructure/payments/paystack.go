@@ -0,0 +1,262 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaystackProvider drives the Paystack Transactions API. Paystack identifies a
+// transaction by the reference we supply, so the provider payment ID is our reference.
+type PaystackProvider struct {
+	secretKey string
+	baseURL   string
+	client    *http.Client
+}
+
+// NewPaystackProvider creates a Paystack driver
+func NewPaystackProvider(secretKey string, client *http.Client) *PaystackProvider {
+	return &PaystackProvider{
+		secretKey: secretKey,
+		baseURL:   "https://api.paystack.co",
+		client:    client,
+	}
+}
+
+// Name returns "paystack"
+func (p *PaystackProvider) Name() string { return ProviderPaystack }
+
+type paystackTransaction struct {
+	ID              int64  `json:"id"`
+	Status          string `json:"status"`
+	Reference       string `json:"reference"`
+	Amount          int64  `json:"amount"`
+	Currency        string `json:"currency"`
+	GatewayResponse string `json:"gateway_response"`
+}
+
+// CreateIntent initializes a hosted checkout, or charges a saved authorization code
+// when PaymentMethodID is set. Paystack has no separate authorize step, so manual
+// capture is not supported.
+func (p *PaystackProvider) CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error) {
+	if req.ManualCapture {
+		return nil, fmt.Errorf("%w: paystack manual capture", ErrUnsupported)
+	}
+	if req.CustomerEmail == "" {
+		return nil, &ProviderError{Provider: ProviderPaystack, StatusCode: http.StatusBadRequest, Message: "customer email is required"}
+	}
+
+	metadata := map[string]string{}
+	for key, value := range req.Metadata {
+		metadata[key] = value
+	}
+	if req.CancelURL != "" {
+		metadata["cancel_action"] = req.CancelURL
+	}
+	body := map[string]any{
+		"email":     req.CustomerEmail,
+		"amount":    toMinorUnits(req.Amount, req.Currency),
+		"currency":  strings.ToUpper(req.Currency),
+		"reference": req.Reference,
+		"metadata":  metadata,
+	}
+
+	if req.PaymentMethodID != "" {
+		body["authorization_code"] = req.PaymentMethodID
+		var transaction paystackTransaction
+		if err := p.call(ctx, http.MethodPost, "/transaction/charge_authorization", body, &transaction); err != nil {
+			return nil, err
+		}
+		return transaction.toIntent(), nil
+	}
+
+	if req.ReturnURL != "" {
+		body["callback_url"] = req.ReturnURL
+	}
+	var initialized struct {
+		AuthorizationURL string `json:"authorization_url"`
+		Reference        string `json:"reference"`
+	}
+	if err := p.call(ctx, http.MethodPost, "/transaction/initialize", body, &initialized); err != nil {
+		return nil, err
+	}
+	return &Intent{
+		ProviderPaymentID: initialized.Reference,
+		Status:            IntentRequiresAction,
+		Amount:            req.Amount,
+		Currency:          strings.ToUpper(req.Currency),
+		RedirectURL:       initialized.AuthorizationURL,
+	}, nil
+}
+
+// Capture verifies the transaction; Paystack settles a payment as soon as the
+// customer completes checkout, so there is nothing further to capture
+func (p *PaystackProvider) Capture(ctx context.Context, providerPaymentID string, amount float64, currency string) (*Intent, error) {
+	return p.Status(ctx, providerPaymentID)
+}
+
+// Status verifies the transaction with the given reference
+func (p *PaystackProvider) Status(ctx context.Context, providerPaymentID string) (*Intent, error) {
+	var transaction paystackTransaction
+	if err := p.call(ctx, http.MethodGet, "/transaction/verify/"+url.PathEscape(providerPaymentID), nil, &transaction); err != nil {
+		return nil, err
+	}
+	return transaction.toIntent(), nil
+}
+
+// Refund refunds all or part of a transaction
+func (p *PaystackProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	body := map[string]any{"transaction": req.ProviderPaymentID}
+	if req.Amount > 0 {
+		body["amount"] = toMinorUnits(req.Amount, req.Currency)
+	}
+	if req.Reason != "" {
+		body["merchant_note"] = req.Reason
+	}
+
+	var refund struct {
+		ID       int64  `json:"id"`
+		Status   string `json:"status"`
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	if err := p.call(ctx, http.MethodPost, "/refund", body, &refund); err != nil {
+		return nil, err
+	}
+
+	status := IntentProcessing
+	switch refund.Status {
+	case "processed":
+		status = IntentSucceeded
+	case "failed":
+		status = IntentFailed
+	}
+	return &Refund{
+		ProviderRefundID: strconv.FormatInt(refund.ID, 10),
+		Status:           status,
+		Amount:           fromMinorUnits(refund.Amount, refund.Currency),
+	}, nil
+}
+
+// VerifyWebhook checks the x-paystack-signature header (HMAC-SHA512 of the body keyed by the secret key)
+func (p *PaystackProvider) VerifyWebhook(ctx context.Context, payload []byte, headers http.Header) (*WebhookEvent, error) {
+	signature, err := hex.DecodeString(headers.Get("X-Paystack-Signature"))
+	if err != nil || len(signature) == 0 {
+		return nil, ErrInvalidSignature
+	}
+	mac := hmac.New(sha512.New, []byte(p.secretKey))
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event struct {
+		Event string `json:"event"`
+		Data  struct {
+			paystackTransaction
+			PaidAt    *time.Time `json:"paid_at"`
+			CreatedAt *time.Time `json:"created_at"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode paystack event: %w", err)
+	}
+
+	// Paystack events carry no event ID; the event name plus transaction ID is unique
+	result := &WebhookEvent{
+		ID:   event.Event + ":" + strconv.FormatInt(event.Data.ID, 10),
+		Type: event.Event,
+	}
+	switch {
+	case event.Data.PaidAt != nil:
+		result.OccurredAt = *event.Data.PaidAt
+	case event.Data.CreatedAt != nil:
+		result.OccurredAt = *event.Data.CreatedAt
+	}
+	if strings.HasPrefix(event.Event, "charge.") {
+		intent := event.Data.toIntent()
+		result.ProviderPaymentID = intent.ProviderPaymentID
+		result.Reference = event.Data.Reference
+		result.Status = intent.Status
+		result.Amount = intent.Amount
+		result.Currency = intent.Currency
+		result.FailureReason = intent.FailureReason
+	}
+	return result, nil
+}
+
+// Ping checks that Paystack is reachable and accepts the secret key by reading the balance
+func (p *PaystackProvider) Ping(ctx context.Context) error {
+	return p.call(ctx, http.MethodGet, "/balance", nil, nil)
+}
+
+func (p *PaystackProvider) call(ctx context.Context, method, path string, body any, out any) error {
+	req, err := newJSONRequest(method, p.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.secretKey)
+
+	// Paystack wraps every response in {status, message, data}
+	var envelope struct {
+		Status  bool            `json:"status"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := doRequest(ctx, p.client, req, &envelope, p.parseError); err != nil {
+		return err
+	}
+	if !envelope.Status {
+		return &ProviderError{Provider: ProviderPaystack, StatusCode: http.StatusOK, Message: envelope.Message}
+	}
+	if out == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Data, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func (p *PaystackProvider) parseError(status int, body []byte) error {
+	var payload struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	message := payload.Message
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return &ProviderError{Provider: ProviderPaystack, StatusCode: status, Code: payload.Code, Message: message}
+}
+
+func (t paystackTransaction) toIntent() *Intent {
+	intent := &Intent{
+		ProviderPaymentID: t.Reference,
+		Amount:            fromMinorUnits(t.Amount, t.Currency),
+		Currency:          strings.ToUpper(t.Currency),
+	}
+	switch t.Status {
+	case "success":
+		intent.Status = IntentSucceeded
+	case "failed", "reversed":
+		intent.Status = IntentFailed
+		intent.FailureReason = t.GatewayResponse
+	case "abandoned":
+		intent.Status = IntentCanceled
+	case "ongoing", "pending", "processing", "queued":
+		intent.Status = IntentProcessing
+	default:
+		intent.Status = IntentRequiresAction
+	}
+	return intent
+}
@@ -0,0 +1,372 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Common provider errors
+var (
+	ErrProviderNotConfigured = errors.New("payments: provider not configured")
+	ErrInvalidSignature      = errors.New("payments: invalid webhook signature")
+	ErrUnsupported           = errors.New("payments: operation not supported by provider")
+)
+
+// Provider names, also stored on Payment.ProviderName
+const (
+	ProviderStripe   = "stripe"
+	ProviderPayPal   = "paypal"
+	ProviderPaystack = "paystack"
+	ProviderMPesa    = "mpesa"
+)
+
+// CallbackTokenHeader carries the secret token from a callback URL to VerifyWebhook,
+// for providers that authenticate callbacks by URL rather than by signature (M-Pesa)
+const CallbackTokenHeader = "X-Callback-Token"
+
+// IsKnownProvider reports whether name is a provider this package has a driver for
+func IsKnownProvider(name string) bool {
+	switch strings.ToLower(name) {
+	case ProviderStripe, ProviderPayPal, ProviderPaystack, ProviderMPesa:
+		return true
+	}
+	return false
+}
+
+// IntentStatus is the provider-neutral state of a payment intent
+type IntentStatus string
+
+const (
+	IntentRequiresAction IntentStatus = "requires_action" // Customer must approve (redirect, 3DS, checkout page)
+	IntentAuthorized     IntentStatus = "authorized"      // Funds held; Capture settles them
+	IntentProcessing     IntentStatus = "processing"
+	IntentSucceeded      IntentStatus = "succeeded"
+	IntentFailed         IntentStatus = "failed"
+	IntentCanceled       IntentStatus = "canceled"
+)
+
+// Provider is a payment processor driver. Amounts are in major units (e.g. 12.50);
+// drivers convert to whatever the processor expects.
+type Provider interface {
+	// Name returns the provider name stored on payments
+	Name() string
+
+	// CreateIntent starts a payment. With PaymentMethodID set the saved method is charged
+	// off-session; otherwise the result carries a client secret or redirect URL.
+	CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error)
+
+	// Capture settles an authorized or customer-approved payment
+	Capture(ctx context.Context, providerPaymentID string, amount float64, currency string) (*Intent, error)
+
+	// Refund returns all or part of a settled payment
+	Refund(ctx context.Context, req RefundRequest) (*Refund, error)
+
+	// VerifyWebhook checks the signature of a webhook delivery and parses the event
+	VerifyWebhook(ctx context.Context, payload []byte, headers http.Header) (*WebhookEvent, error)
+}
+
+// StatusChecker is implemented by providers that can report a payment's current state,
+// used to reconcile payments whose webhook or callback never arrived
+type StatusChecker interface {
+	Status(ctx context.Context, providerPaymentID string) (*Intent, error)
+}
+
+// Transferer is implemented by providers that can move funds to a connected
+// account, used to pay artisans their earnings
+type Transferer interface {
+	Transfer(ctx context.Context, req TransferRequest) (*Transfer, error)
+}
+
+// BalanceLister is implemented by providers that can list the movements on the
+// platform's balance, used to reconcile recorded payments against the provider's books
+type BalanceLister interface {
+	ListBalanceTransactions(ctx context.Context, from, to time.Time) ([]BalanceTransaction, error)
+}
+
+// Balance transaction types
+const (
+	BalanceCharge = "charge" // Money collected from a customer
+	BalanceRefund = "refund" // Money returned to a customer
+)
+
+// BalanceTransaction is a movement on the provider balance
+type BalanceTransaction struct {
+	ID                string
+	Type              string // BalanceCharge, BalanceRefund or the provider's own type
+	ProviderPaymentID string // The payment the movement belongs to, when it has one
+	Amount            float64
+	Fee               float64
+	Currency          string
+	CreatedAt         time.Time
+}
+
+// IntentRequest describes a payment to start
+type IntentRequest struct {
+	Amount          float64
+	Currency        string
+	Reference       string // Our payment ID; echoed back in webhooks
+	Description     string
+	CustomerEmail   string
+	PhoneNumber     string // Mobile money payer (M-Pesa)
+	PaymentMethodID string // Saved method to charge off-session (Paystack: authorization code)
+	ManualCapture   bool   // Authorize only; settle later with Capture
+	ReturnURL       string // Where redirect-based checkouts send the customer back
+	CancelURL       string
+	Metadata        map[string]string
+}
+
+// Intent is the provider's view of a payment
+type Intent struct {
+	ProviderPaymentID string
+	Status            IntentStatus
+	Amount            float64
+	Currency          string
+	ClientSecret      string // For client-side confirmation (Stripe)
+	RedirectURL       string // For hosted checkout pages (PayPal, Paystack)
+	FailureReason     string
+	ReceiptNumber     string // Processor receipt shown to the customer (M-Pesa)
+}
+
+// RefundRequest describes a refund of a settled payment
+type RefundRequest struct {
+	ProviderPaymentID string
+	Amount            float64
+	Currency          string
+	Reason            string
+	Reference         string // Our refund reference, used by providers that deduplicate
+}
+
+// Refund is the provider's view of a refund
+type Refund struct {
+	ProviderRefundID string
+	Status           IntentStatus
+	Amount           float64
+}
+
+// TransferRequest describes funds to send to a connected account
+type TransferRequest struct {
+	Destination string // Connected account ID
+	Amount      float64
+	Currency    string
+	Reference   string // Our payout ID, used as the idempotency key
+	Description string
+}
+
+// Transfer is the provider's view of a transfer
+type Transfer struct {
+	ProviderTransferID string
+	Status             IntentStatus
+}
+
+// WebhookEvent is a verified, provider-neutral webhook notification
+type WebhookEvent struct {
+	ID                string // Provider event ID, unique per provider
+	Type              string // Provider event type, e.g. payment_intent.succeeded
+	ProviderPaymentID string
+	Reference         string // Our payment ID when the provider echoes it
+	Status            IntentStatus
+	Amount            float64
+	Currency          string
+	FailureReason     string
+	ReceiptNumber     string
+	OccurredAt        time.Time
+}
+
+// Registry holds the configured providers and resolves the one a tenant uses. Each
+// provider has a circuit breaker fed by the calls callers report with RecordResult
+// and by CheckHealth.
+type Registry struct {
+	providers       map[string]Provider
+	breakers        map[string]*circuitBreaker
+	defaultProvider string
+}
+
+// NewRegistry creates a registry. defaultProvider is used by tenants that have not chosen one.
+func NewRegistry(defaultProvider string, providers ...Provider) *Registry {
+	return newRegistry(defaultProvider, BreakerConfig{}, providers...)
+}
+
+func newRegistry(defaultProvider string, breaker BreakerConfig, providers ...Provider) *Registry {
+	r := &Registry{
+		providers:       make(map[string]Provider, len(providers)),
+		breakers:        make(map[string]*circuitBreaker, len(providers)),
+		defaultProvider: strings.ToLower(defaultProvider),
+	}
+	for _, p := range providers {
+		if p != nil {
+			r.providers[p.Name()] = p
+			r.breakers[p.Name()] = newCircuitBreaker(breaker)
+		}
+	}
+	return r
+}
+
+// Get returns the named provider
+func (r *Registry) Get(name string) (Provider, error) {
+	if r == nil {
+		return nil, ErrProviderNotConfigured
+	}
+	p, ok := r.providers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, name)
+	}
+	return p, nil
+}
+
+// Resolve returns the tenant's chosen provider, falling back to the platform default
+func (r *Registry) Resolve(tenantChoice string) (Provider, error) {
+	if tenantChoice != "" {
+		return r.Get(tenantChoice)
+	}
+	if r == nil || r.defaultProvider == "" {
+		return nil, ErrProviderNotConfigured
+	}
+	return r.Get(r.defaultProvider)
+}
+
+// Route returns the providers to try for a tenant's payment, in order: the tenant's
+// choice or the platform default, then the tenant's fallback. Providers whose circuit
+// is open are skipped; ErrCircuitOpen is returned when that leaves none.
+func (r *Registry) Route(tenantChoice, fallback string) ([]Provider, error) {
+	primary, err := r.Resolve(tenantChoice)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []Provider{primary}
+	if fallback != "" && !strings.EqualFold(fallback, primary.Name()) {
+		if p, err := r.Get(fallback); err == nil {
+			candidates = append(candidates, p)
+		}
+	}
+
+	routed := make([]Provider, 0, len(candidates))
+	for _, p := range candidates {
+		if r.Available(p.Name()) {
+			routed = append(routed, p)
+		}
+	}
+	if len(routed) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, primary.Name())
+	}
+	return routed, nil
+}
+
+// Available reports whether the named provider's circuit lets requests through
+func (r *Registry) Available(name string) bool {
+	if r == nil {
+		return false
+	}
+	b, ok := r.breakers[strings.ToLower(name)]
+	return ok && b.allow()
+}
+
+// RecordResult reports the outcome of a call to the named provider to its circuit
+// breaker. Only outages (see IsOutage) count as failures.
+func (r *Registry) RecordResult(name string, err error) {
+	if r == nil {
+		return
+	}
+	if b, ok := r.breakers[strings.ToLower(name)]; ok {
+		b.record(err)
+	}
+}
+
+// Health reports the circuit state of every configured provider
+func (r *Registry) Health() []ProviderHealth {
+	names := r.Names()
+	health := make([]ProviderHealth, len(names))
+	for i, name := range names {
+		health[i] = r.breakers[name].snapshot(name)
+	}
+	return health
+}
+
+// CheckHealth probes every provider that supports it and records the results, then
+// reports the circuit state of every configured provider
+func (r *Registry) CheckHealth(ctx context.Context) []ProviderHealth {
+	for _, name := range r.Names() {
+		if checker, ok := r.providers[name].(HealthChecker); ok {
+			r.RecordResult(name, checker.Ping(ctx))
+		}
+	}
+	return r.Health()
+}
+
+// Names lists the configured providers in alphabetical order
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Config holds platform credentials for each provider; providers without credentials are skipped
+type Config struct {
+	DefaultProvider string
+
+	StripeSecretKey            string
+	StripeWebhookSecret        string
+	StripeBillingWebhookSecret string // Signs the platform's own subscription billing events
+
+	PayPalClientID     string
+	PayPalClientSecret string
+	PayPalWebhookID    string
+	PayPalSandbox      bool
+
+	PaystackSecretKey string
+
+	MPesa MPesaConfig
+
+	HTTPClient *http.Client // Optional; defaults to a client with a 30s timeout
+
+	CircuitBreaker BreakerConfig // Optional; zero values use the defaults
+}
+
+// NewRegistryFromConfig builds a registry with every provider that has credentials
+func NewRegistryFromConfig(cfg Config) *Registry {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	var providers []Provider
+	if cfg.StripeSecretKey != "" {
+		providers = append(providers, NewStripeProvider(cfg.StripeSecretKey, cfg.StripeWebhookSecret, client))
+	}
+	if cfg.PayPalClientID != "" && cfg.PayPalClientSecret != "" {
+		providers = append(providers, NewPayPalProvider(cfg.PayPalClientID, cfg.PayPalClientSecret, cfg.PayPalWebhookID, cfg.PayPalSandbox, client))
+	}
+	if cfg.PaystackSecretKey != "" {
+		providers = append(providers, NewPaystackProvider(cfg.PaystackSecretKey, client))
+	}
+	if cfg.MPesa.ConsumerKey != "" && cfg.MPesa.ConsumerSecret != "" && cfg.MPesa.ShortCode != "" && cfg.MPesa.Passkey != "" {
+		providers = append(providers, NewMPesaProvider(cfg.MPesa, client))
+	}
+	return newRegistry(cfg.DefaultProvider, cfg.CircuitBreaker, providers...)
+}
+
+// ProviderError is a failure reported by the payment processor
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *ProviderError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s: %s (%s)", e.Provider, e.Message, e.Code)
+	}
+	return fmt.Sprintf("%s: %s", e.Provider, e.Message)
+}
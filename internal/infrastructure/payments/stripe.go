@@ -0,0 +1,407 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stripeSignatureTolerance is how old a signed webhook timestamp may be
+const stripeSignatureTolerance = 5 * time.Minute
+
+// StripeProvider drives the Stripe PaymentIntents API
+type StripeProvider struct {
+	secretKey     string
+	webhookSecret string
+	baseURL       string
+	client        *http.Client
+	now           func() time.Time
+}
+
+// NewStripeProvider creates a Stripe driver
+func NewStripeProvider(secretKey, webhookSecret string, client *http.Client) *StripeProvider {
+	return &StripeProvider{
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		baseURL:       "https://api.stripe.com",
+		client:        client,
+		now:           time.Now,
+	}
+}
+
+// Name returns "stripe"
+func (p *StripeProvider) Name() string { return ProviderStripe }
+
+type stripePaymentIntent struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	Amount           int64  `json:"amount"`
+	Currency         string `json:"currency"`
+	ClientSecret     string `json:"client_secret"`
+	LastPaymentError *struct {
+		Message string `json:"message"`
+	} `json:"last_payment_error"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// CreateIntent creates and, for saved methods, confirms a PaymentIntent
+func (p *StripeProvider) CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(toMinorUnits(req.Amount, req.Currency), 10))
+	form.Set("currency", strings.ToLower(req.Currency))
+	if req.Description != "" {
+		form.Set("description", req.Description)
+	}
+	if req.CustomerEmail != "" {
+		form.Set("receipt_email", req.CustomerEmail)
+	}
+	if req.ManualCapture {
+		form.Set("capture_method", "manual")
+	}
+	if req.Reference != "" {
+		form.Set("metadata[reference]", req.Reference)
+	}
+	for key, value := range req.Metadata {
+		form.Set("metadata["+key+"]", value)
+	}
+	if req.PaymentMethodID != "" {
+		form.Set("payment_method", req.PaymentMethodID)
+		form.Set("confirm", "true")
+		form.Set("off_session", "true")
+	} else {
+		form.Set("automatic_payment_methods[enabled]", "true")
+	}
+
+	var intent stripePaymentIntent
+	if err := p.post(ctx, "/v1/payment_intents", form, req.Reference, &intent); err != nil {
+		return nil, err
+	}
+	return intent.toIntent(), nil
+}
+
+// Capture captures an authorized PaymentIntent
+func (p *StripeProvider) Capture(ctx context.Context, providerPaymentID string, amount float64, currency string) (*Intent, error) {
+	form := url.Values{}
+	if amount > 0 {
+		form.Set("amount_to_capture", strconv.FormatInt(toMinorUnits(amount, currency), 10))
+	}
+
+	var intent stripePaymentIntent
+	if err := p.post(ctx, "/v1/payment_intents/"+url.PathEscape(providerPaymentID)+"/capture", form, "", &intent); err != nil {
+		return nil, err
+	}
+	return intent.toIntent(), nil
+}
+
+// Status retrieves a PaymentIntent
+func (p *StripeProvider) Status(ctx context.Context, providerPaymentID string) (*Intent, error) {
+	req, err := http.NewRequest(http.MethodGet, p.baseURL+"/v1/payment_intents/"+url.PathEscape(providerPaymentID), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(p.secretKey, "")
+
+	var intent stripePaymentIntent
+	if err := doRequest(ctx, p.client, req, &intent, p.parseError); err != nil {
+		return nil, err
+	}
+	return intent.toIntent(), nil
+}
+
+// Refund refunds a PaymentIntent
+func (p *StripeProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	form := url.Values{}
+	form.Set("payment_intent", req.ProviderPaymentID)
+	if req.Amount > 0 {
+		form.Set("amount", strconv.FormatInt(toMinorUnits(req.Amount, req.Currency), 10))
+	}
+	if req.Reason != "" {
+		form.Set("metadata[reason]", req.Reason)
+	}
+
+	var refund struct {
+		ID       string `json:"id"`
+		Status   string `json:"status"`
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	if err := p.post(ctx, "/v1/refunds", form, req.Reference, &refund); err != nil {
+		return nil, err
+	}
+
+	status := IntentProcessing
+	switch refund.Status {
+	case "succeeded":
+		status = IntentSucceeded
+	case "failed":
+		status = IntentFailed
+	case "canceled":
+		status = IntentCanceled
+	}
+	return &Refund{ProviderRefundID: refund.ID, Status: status, Amount: fromMinorUnits(refund.Amount, refund.Currency)}, nil
+}
+
+// Transfer moves funds from the platform balance to a connected account
+func (p *StripeProvider) Transfer(ctx context.Context, req TransferRequest) (*Transfer, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(toMinorUnits(req.Amount, req.Currency), 10))
+	form.Set("currency", strings.ToLower(req.Currency))
+	form.Set("destination", req.Destination)
+	if req.Description != "" {
+		form.Set("description", req.Description)
+	}
+	if req.Reference != "" {
+		form.Set("transfer_group", req.Reference)
+		form.Set("metadata[reference]", req.Reference)
+	}
+
+	var transfer struct {
+		ID       string `json:"id"`
+		Reversed bool   `json:"reversed"`
+	}
+	if err := p.post(ctx, "/v1/transfers", form, req.Reference, &transfer); err != nil {
+		return nil, err
+	}
+
+	// Transfers between Stripe balances settle immediately
+	status := IntentSucceeded
+	if transfer.Reversed {
+		status = IntentCanceled
+	}
+	return &Transfer{ProviderTransferID: transfer.ID, Status: status}, nil
+}
+
+// VerifyWebhook checks the Stripe-Signature header (HMAC-SHA256 over "timestamp.payload")
+func (p *StripeProvider) VerifyWebhook(ctx context.Context, payload []byte, headers http.Header) (*WebhookEvent, error) {
+	if p.webhookSecret == "" {
+		return nil, fmt.Errorf("%w: stripe webhook secret", ErrProviderNotConfigured)
+	}
+
+	if err := verifyStripeSignature(p.webhookSecret, payload, headers.Get("Stripe-Signature"), p.now()); err != nil {
+		return nil, err
+	}
+
+	var event struct {
+		ID      string `json:"id"`
+		Type    string `json:"type"`
+		Created int64  `json:"created"`
+		Data    struct {
+			Object json.RawMessage `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode stripe event: %w", err)
+	}
+
+	result := &WebhookEvent{ID: event.ID, Type: event.Type, OccurredAt: time.Unix(event.Created, 0)}
+	if strings.HasPrefix(event.Type, "payment_intent.") {
+		var intent stripePaymentIntent
+		if err := json.Unmarshal(event.Data.Object, &intent); err != nil {
+			return nil, fmt.Errorf("decode stripe payment intent: %w", err)
+		}
+		converted := intent.toIntent()
+		result.ProviderPaymentID = converted.ProviderPaymentID
+		result.Reference = intent.Metadata["reference"]
+		result.Status = converted.Status
+		result.Amount = converted.Amount
+		result.Currency = converted.Currency
+		result.FailureReason = converted.FailureReason
+	}
+	return result, nil
+}
+
+// verifyStripeSignature checks a Stripe-Signature header: an HMAC-SHA256 over
+// "timestamp.payload" signed less than stripeSignatureTolerance ago
+func verifyStripeSignature(secret string, payload []byte, header string, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		if sig, err := hex.DecodeString(signature); err == nil && hmac.Equal(sig, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// ListBalanceTransactions lists balance movements created in [from, to), paging
+// through every result. Charges and refunds name the PaymentIntent they belong to.
+func (p *StripeProvider) ListBalanceTransactions(ctx context.Context, from, to time.Time) ([]BalanceTransaction, error) {
+	var transactions []BalanceTransaction
+	startingAfter := ""
+	for {
+		query := url.Values{}
+		query.Set("created[gte]", strconv.FormatInt(from.Unix(), 10))
+		query.Set("created[lt]", strconv.FormatInt(to.Unix(), 10))
+		query.Set("limit", "100")
+		query.Add("expand[]", "data.source")
+		if startingAfter != "" {
+			query.Set("starting_after", startingAfter)
+		}
+
+		var page struct {
+			Data    []stripeBalanceTransaction `json:"data"`
+			HasMore bool                       `json:"has_more"`
+		}
+		if err := p.send(ctx, http.MethodGet, "/v1/balance_transactions", query, "", &page); err != nil {
+			return nil, err
+		}
+		for _, txn := range page.Data {
+			transactions = append(transactions, txn.toBalanceTransaction())
+		}
+		if !page.HasMore || len(page.Data) == 0 {
+			return transactions, nil
+		}
+		startingAfter = page.Data[len(page.Data)-1].ID
+	}
+}
+
+// Ping checks that Stripe is reachable and accepts the secret key by reading the balance
+func (p *StripeProvider) Ping(ctx context.Context) error {
+	return p.send(ctx, http.MethodGet, "/v1/balance", nil, "", nil)
+}
+
+func (p *StripeProvider) post(ctx context.Context, path string, form url.Values, idempotencyKey string, out any) error {
+	return p.send(ctx, http.MethodPost, path, form, idempotencyKey, out)
+}
+
+func (p *StripeProvider) send(ctx context.Context, method, path string, form url.Values, idempotencyKey string, out any) error {
+	// Reads and deletes take their parameters in the query string
+	var body io.Reader
+	if method == http.MethodGet || method == http.MethodDelete {
+		if len(form) > 0 {
+			path += "?" + form.Encode()
+		}
+	} else {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, p.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.secretKey, "")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", path+":"+idempotencyKey)
+	}
+	return doRequest(ctx, p.client, req, out, p.parseError)
+}
+
+func (p *StripeProvider) parseError(status int, body []byte) error {
+	var payload struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	message := payload.Error.Message
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return &ProviderError{Provider: ProviderStripe, StatusCode: status, Code: payload.Error.Code, Message: message}
+}
+
+func (i stripePaymentIntent) toIntent() *Intent {
+	intent := &Intent{
+		ProviderPaymentID: i.ID,
+		Amount:            fromMinorUnits(i.Amount, i.Currency),
+		Currency:          strings.ToUpper(i.Currency),
+		ClientSecret:      i.ClientSecret,
+	}
+	switch i.Status {
+	case "succeeded":
+		intent.Status = IntentSucceeded
+	case "requires_capture":
+		intent.Status = IntentAuthorized
+	case "processing":
+		intent.Status = IntentProcessing
+	case "canceled":
+		intent.Status = IntentCanceled
+	case "requires_payment_method":
+		// A confirmed intent falls back here when the charge is declined
+		if i.LastPaymentError != nil {
+			intent.Status = IntentFailed
+		} else {
+			intent.Status = IntentRequiresAction
+		}
+	default:
+		intent.Status = IntentRequiresAction
+	}
+	if i.LastPaymentError != nil {
+		intent.FailureReason = i.LastPaymentError.Message
+	}
+	return intent
+}
+
+type stripeBalanceTransaction struct {
+	ID       string          `json:"id"`
+	Type     string          `json:"type"`
+	Amount   int64           `json:"amount"`
+	Fee      int64           `json:"fee"`
+	Currency string          `json:"currency"`
+	Created  int64           `json:"created"`
+	Source   json.RawMessage `json:"source"` // ID, or the charge or refund when expanded
+}
+
+func (t stripeBalanceTransaction) toBalanceTransaction() BalanceTransaction {
+	txn := BalanceTransaction{
+		ID:        t.ID,
+		Type:      t.Type,
+		Amount:    fromMinorUnits(t.Amount, t.Currency),
+		Fee:       fromMinorUnits(t.Fee, t.Currency),
+		Currency:  strings.ToUpper(t.Currency),
+		CreatedAt: time.Unix(t.Created, 0),
+	}
+	switch t.Type {
+	case "charge", "payment":
+		txn.Type = BalanceCharge
+	case "refund", "payment_refund":
+		txn.Type = BalanceRefund
+	}
+
+	var source struct {
+		PaymentIntent string `json:"payment_intent"`
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(t.Source), []byte("{")) && json.Unmarshal(t.Source, &source) == nil {
+		txn.ProviderPaymentID = source.PaymentIntent
+	}
+	return txn
+}
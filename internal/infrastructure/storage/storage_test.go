@@ -0,0 +1,60 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/infrastructure/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	size, err := store.Put(ctx, "exports/tenant/file.csv", "text/csv", strings.NewReader("a,b\n1,2\n"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, size)
+
+	r, err := store.Open(ctx, "exports/tenant/file.csv")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, r.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "a,b\n1,2\n", string(content))
+
+	require.NoError(t, store.Delete(ctx, "exports/tenant/file.csv"))
+	require.NoError(t, store.Delete(ctx, "exports/tenant/file.csv"))
+
+	_, err = store.Open(ctx, "exports/tenant/file.csv")
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	_, err = store.Put(ctx, "../escape.csv", "text/csv", strings.NewReader("x"))
+	assert.ErrorIs(t, err, storage.ErrInvalidKey)
+}
+
+func TestURLSigner(t *testing.T) {
+	signer := storage.NewURLSigner("secret")
+	expires := time.Now().Add(time.Hour)
+
+	sig, err := signer.Sign("exports/a.csv", expires)
+	require.NoError(t, err)
+
+	assert.NoError(t, signer.Verify("exports/a.csv", expires.Unix(), sig))
+	assert.ErrorIs(t, signer.Verify("exports/b.csv", expires.Unix(), sig), storage.ErrInvalidSignature)
+	assert.ErrorIs(t, signer.Verify("exports/a.csv", expires.Unix()+1, sig), storage.ErrInvalidSignature)
+
+	past := time.Now().Add(-time.Minute)
+	sig, err = signer.Sign("exports/a.csv", past)
+	require.NoError(t, err)
+	assert.ErrorIs(t, signer.Verify("exports/a.csv", past.Unix(), sig), storage.ErrSignatureExpired)
+
+	_, err = storage.NewURLSigner("").Sign("exports/a.csv", expires)
+	assert.ErrorIs(t, err, storage.ErrMissingSecret)
+}
@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/redact"
+	"Krafti_Vibe/internal/pkg/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AccessLogConfig holds configuration for the access log middleware
+type AccessLogConfig struct {
+	Logger *zap.Logger
+
+	// SampleRate is the share of successful requests logged; failed requests are
+	// always logged
+	SampleRate float64
+
+	// RouteSampleRates overrides SampleRate for routes under a path prefix, the longest
+	// matching prefix winning
+	RouteSampleRates map[string]float64
+
+	// CaptureBodies logs JSON request and response bodies, with sensitive fields and
+	// personal data redacted
+	CaptureBodies bool
+
+	// MaxBodyBytes is the size above which bodies are logged by size only
+	MaxBodyBytes int
+
+	// SkipPaths contains paths that are never logged, such as probes
+	SkipPaths []string
+}
+
+// DefaultAccessLogConfig returns default access log configuration
+func DefaultAccessLogConfig(logger *zap.Logger) AccessLogConfig {
+	return AccessLogConfig{
+		Logger:       logger,
+		SampleRate:   1,
+		MaxBodyBytes: 4096,
+		SkipPaths: []string{
+			"/health",
+			"/metrics",
+			"/debug/pprof",
+		},
+	}
+}
+
+// accessLogSampling holds the sample rates of an AccessLogger, which can change while
+// it runs
+type accessLogSampling struct {
+	rate       float64
+	routeRates map[string]float64
+}
+
+// AccessLogger writes a structured log entry per request, with its route, status,
+// latency, caller and trace
+type AccessLogger struct {
+	config   AccessLogConfig
+	sampling atomic.Pointer[accessLogSampling]
+}
+
+// NewAccessLogger creates a new access logger
+func NewAccessLogger(config AccessLogConfig) *AccessLogger {
+	l := &AccessLogger{config: config}
+	l.SetSampling(config.SampleRate, config.RouteSampleRates)
+	return l
+}
+
+// SetSampling changes the share of successful requests logged, overall and by route
+// prefix, such as when the configuration is reloaded
+func (l *AccessLogger) SetSampling(rate float64, routeRates map[string]float64) {
+	l.sampling.Store(&accessLogSampling{rate: rate, routeRates: routeRates})
+}
+
+// AccessLog returns a middleware writing a structured log entry per request
+func AccessLog(config AccessLogConfig) fiber.Handler {
+	return NewAccessLogger(config).Handler()
+}
+
+// Handler returns the middleware writing the entries. Errors are answered by the error
+// handler here rather than after every middleware returned, so the entry records the
+// response actually sent.
+func (l *AccessLogger) Handler() fiber.Handler {
+	config := l.config
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		for _, skipPath := range config.SkipPaths {
+			if strings.HasPrefix(path, skipPath) {
+				return c.Next()
+			}
+		}
+
+		start := time.Now()
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		latency := time.Since(start)
+
+		status := c.Response().StatusCode()
+		route := routeLabel(c)
+		if status < fiber.StatusBadRequest && !l.sampling.Load().sampled(route) {
+			return nil
+		}
+
+		// Streamed responses, such as exports, are neither measured nor captured, which
+		// would mean reading them into memory
+		streamed := c.Response().IsBodyStream()
+
+		fields := []zap.Field{
+			zap.String("method", c.Method()),
+			zap.String("route", route),
+			zap.String("path", path),
+			zap.Int("status", status),
+			zap.Duration("latency", latency),
+			zap.Int("bytes_in", len(c.Request().Body())),
+			zap.String("ip", c.IP()),
+			zap.String("user_agent", c.Get(fiber.HeaderUserAgent)),
+		}
+		if !streamed {
+			fields = append(fields, zap.Int("bytes_out", len(c.Response().Body())))
+		}
+		if query := string(c.Request().URI().QueryString()); query != "" {
+			fields = append(fields, zap.String("query", redactQuery(query)))
+		}
+		if requestID, ok := c.Locals("request_id").(string); ok {
+			fields = append(fields, zap.String("request_id", requestID))
+		}
+		if authCtx, ok := GetAuthContext(c); ok {
+			fields = append(fields, zap.String("user_id", authCtx.UserID.String()))
+			if authCtx.TenantID != uuid.Nil {
+				fields = append(fields, zap.String("tenant_id", authCtx.TenantID.String()))
+			}
+		}
+		fields = append(fields, tracing.Fields(c.UserContext())...)
+
+		if config.CaptureBodies {
+			if body, ok := config.captureBody(string(c.Request().Header.ContentType()), c.Request().Body()); ok {
+				fields = append(fields, zap.Any("request_body", body))
+			}
+			if !streamed {
+				if body, ok := config.captureBody(string(c.Response().Header.ContentType()), c.Response().Body()); ok {
+					fields = append(fields, zap.Any("response_body", body))
+				}
+			}
+		}
+
+		level := zapcore.InfoLevel
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = zapcore.ErrorLevel
+		case status >= fiber.StatusBadRequest:
+			level = zapcore.WarnLevel
+		}
+		config.Logger.Log(level, "request", fields...)
+
+		return nil
+	}
+}
+
+// sampled decides whether a successful request to a route is logged
+func (s *accessLogSampling) sampled(route string) bool {
+	rate, matched := s.rate, 0
+	for prefix, prefixRate := range s.routeRates {
+		if strings.HasPrefix(route, prefix) && len(prefix) > matched {
+			rate, matched = prefixRate, len(prefix)
+		}
+	}
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
+// captureBody returns a JSON body as logged, with sensitive fields and personal data
+// redacted. Other content types, such as uploads and exports, are not captured.
+func (config AccessLogConfig) captureBody(contentType string, body []byte) (any, bool) {
+	if len(body) == 0 || !strings.Contains(contentType, "json") {
+		return nil, false
+	}
+	if len(body) > config.MaxBodyBytes {
+		return fmt.Sprintf("[%d bytes]", len(body)), true
+	}
+
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return "[invalid JSON]", true
+	}
+	return redact.Value(decoded), true
+}
+
+// redactQuery masks the values of sensitive query parameters, and personal data in the
+// others, which are logged unescaped
+func redactQuery(query string) string {
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		name, value, _ := strings.Cut(param, "=")
+		if redact.IsSensitiveKey(name) {
+			params[i] = name + "=" + redact.Placeholder
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		params[i] = name + "=" + redact.String(value)
+	}
+	return strings.Join(params, "&")
+}
@@ -4,6 +4,8 @@ import (
 	"slices"
 	"time"
 
+	"Krafti_Vibe/internal/pkg/audit"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -130,6 +132,35 @@ func AuditLog(config AuditLogConfig) fiber.Handler {
 	}
 }
 
+// AuditRequests marks requests for the audit trail, which records the changes and
+// actions services take while serving them with the request's IP address and ID. By
+// default mutating requests are marked; routes whose reads are audited themselves, such
+// as exports, pass the methods to mark. It goes after the request ID middleware.
+func AuditRequests(methods ...string) fiber.Handler {
+	if len(methods) == 0 {
+		methods = []string{fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete}
+	}
+
+	return func(c *fiber.Ctx) error {
+		if !slices.Contains(methods, c.Method()) {
+			return c.Next()
+		}
+
+		requestID, _ := c.Locals("request_id").(string)
+		if requestID == "" {
+			requestID = c.Get("X-Request-ID")
+		}
+		c.Locals(audit.ContextKey, &audit.Request{
+			ID:        requestID,
+			Method:    c.Method(),
+			Path:      c.Path(),
+			IPAddress: c.IP(),
+			UserAgent: c.Get(fiber.HeaderUserAgent),
+		})
+		return c.Next()
+	}
+}
+
 // AuditAction logs a specific action for audit trail
 func AuditAction(logger *zap.Logger, c *fiber.Ctx, action string, resource string, details map[string]any) {
 	fields := []zap.Field{
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/chaos"
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// ChaosConfig holds configuration for the fault injection middleware
+type ChaosConfig struct {
+	Injector *chaos.Injector
+
+	// SkipPaths contains paths faults are never injected into, such as the admin API
+	// managing the rules, so an experiment can always be ended
+	SkipPaths []string
+
+	Logger *zap.Logger
+}
+
+// DefaultChaosConfig returns default fault injection configuration
+func DefaultChaosConfig(injector *chaos.Injector, logger *zap.Logger) ChaosConfig {
+	return ChaosConfig{
+		Injector: injector,
+		SkipPaths: []string{
+			"/api/v1/admin/chaos",
+		},
+		Logger: logger,
+	}
+}
+
+// Chaos creates a middleware injecting the faults of the injector's rules into the
+// requests they match: it delays them, answers them with an error status, or has the
+// dependencies they drop fail while they are handled. Requests with injected faults
+// carry an X-Chaos-Injected header naming the rules. It must never run in production.
+func Chaos(config ChaosConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		for _, skipPath := range config.SkipPaths {
+			if path == skipPath || strings.HasPrefix(path, skipPath+"/") {
+				return c.Next()
+			}
+		}
+
+		injection := config.Injector.Inject(c.Method(), path)
+		if injection.Empty() {
+			return c.Next()
+		}
+		c.Set("X-Chaos-Injected", strings.Join(injection.Rules, ","))
+		config.Logger.Debug("injecting faults",
+			zap.String("path", path),
+			zap.Strings("rules", injection.Rules),
+			zap.Duration("latency", injection.Latency),
+			zap.Int("error_status", injection.ErrorStatus),
+			zap.Strings("drop", injection.Drop),
+		)
+
+		if injection.Latency > 0 {
+			time.Sleep(injection.Latency)
+		}
+		if injection.ErrorStatus != 0 {
+			return SendProblem(c, pkgErrors.NewProblem(injection.ErrorStatus, "", "Fault injected by a chaos rule"))
+		}
+		if len(injection.Drop) > 0 {
+			c.Locals(chaos.ContextKey, injection.Drop)
+			c.SetUserContext(chaos.WithDropped(c.UserContext(), injection.Drop...))
+		}
+		return c.Next()
+	}
+}
@@ -161,10 +161,7 @@ func CORSMiddleware(config CORSConfig) fiber.Handler {
 					zap.String("path", c.Path()),
 				)
 			}
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":   "cors_origin_not_allowed",
-				"message": "Origin not allowed by CORS policy",
-			})
+			return SendError(c, fiber.StatusForbidden, "CORS_ORIGIN_NOT_ALLOWED", "Origin not allowed by CORS policy")
 		}
 
 		// Set Access-Control-Allow-Origin
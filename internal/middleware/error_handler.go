@@ -1,37 +1,36 @@
 package middleware
 
 import (
+	"Krafti_Vibe/internal/pkg/tracing"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
-// ErrorHandler creates a custom error handler for Fiber
+// ErrorHandler creates a custom error handler for Fiber, answering every error a
+// handler returns with its application/problem+json problem
 func ErrorHandler(logger *zap.Logger) fiber.ErrorHandler {
 	return func(c *fiber.Ctx, err error) error {
-		// Default error code
-		code := fiber.StatusInternalServerError
-
-		// Retrieve the custom status code if it's a fiber.Error
-		if e, ok := err.(*fiber.Error); ok {
-			code = e.Code
-		}
+		problem := ProblemFromError(err)
 
-		// Log the error
-		logger.Error("request error",
+		// Log the error, with the request's trace when it is traced
+		fields := []zap.Field{
 			zap.Error(err),
 			zap.String("path", c.Path()),
 			zap.String("method", c.Method()),
-			zap.Int("status", code),
+			zap.Int("status", problem.Status),
+			zap.String("code", string(problem.Code)),
 			zap.String("ip", c.IP()),
-		)
+		}
+		fields = append(fields, tracing.Fields(c.UserContext())...)
+		// Client errors are recorded by the access log; only server errors need the
+		// error itself at hand
+		if problem.Status >= fiber.StatusInternalServerError {
+			logger.Error("request error", fields...)
+		} else {
+			logger.Debug("request error", fields...)
+		}
 
-		// Send error response
-		return c.Status(code).JSON(fiber.Map{
-			"success": false,
-			"error": fiber.Map{
-				"code":    code,
-				"message": err.Error(),
-			},
-		})
+		return SendProblem(c, problem)
 	}
 }
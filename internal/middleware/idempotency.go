@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the client's idempotency key
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from a stored result
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyStore persists idempotency keys and the responses recorded for them
+type IdempotencyStore interface {
+	// Reserve claims record.Key for a new request. If the key is already taken the
+	// stored record is returned with reserved false; it is nil if the key vanished meanwhile.
+	Reserve(ctx context.Context, record *models.IdempotencyKey) (existing *models.IdempotencyKey, reserved bool, err error)
+	// Complete stores the response for a reserved key
+	Complete(ctx context.Context, record *models.IdempotencyKey) error
+	// Release frees a reserved key so the request can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyConfig holds configuration for the idempotency middleware
+type IdempotencyConfig struct {
+	// Store keeps keys and responses (Redis or Postgres)
+	Store IdempotencyStore
+	// TTL is how long a response is replayed for
+	TTL time.Duration
+	// Logger for store failures
+	Logger *zap.Logger
+}
+
+// DefaultIdempotencyConfig returns default idempotency configuration
+func DefaultIdempotencyConfig(store IdempotencyStore, logger *zap.Logger) IdempotencyConfig {
+	return IdempotencyConfig{
+		Store:  store,
+		TTL:    24 * time.Hour,
+		Logger: logger,
+	}
+}
+
+// Idempotency returns a middleware that runs a request sent with an Idempotency-Key
+// header once and replays its response for retries with the same key. Keys are scoped
+// to the caller, method and path; reusing a key with a different body is rejected.
+// Requests without the header pass through. Server errors release the key so the
+// client can retry.
+func Idempotency(config IdempotencyConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		clientKey := c.Get(IdempotencyKeyHeader)
+		if clientKey == "" {
+			return c.Next()
+		}
+		if len(clientKey) > maxIdempotencyKeyLength {
+			return idempotencyError(c, fiber.StatusBadRequest, "INVALID_IDEMPOTENCY_KEY", "Idempotency-Key must be at most 255 characters")
+		}
+
+		ctx := c.UserContext()
+		record := &models.IdempotencyKey{
+			Key:         idempotencyScope(c, clientKey),
+			Fingerprint: hashHex(c.Body()),
+			ExpiresAt:   time.Now().Add(config.TTL),
+		}
+
+		existing, reserved, err := config.Store.Reserve(ctx, record)
+		if err != nil {
+			// Fail open: a store outage should not take bookings and payments down
+			config.Logger.Error("idempotency store unavailable", zap.String("path", c.Path()), zap.Error(err))
+			return c.Next()
+		}
+		if !reserved {
+			switch {
+			case existing != nil && existing.Fingerprint != record.Fingerprint:
+				return idempotencyError(c, fiber.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used with a different request")
+			case existing == nil || !existing.Completed:
+				return idempotencyError(c, fiber.StatusConflict, "IDEMPOTENCY_KEY_IN_PROGRESS", "A request with this Idempotency-Key is still being processed")
+			}
+			c.Set(IdempotentReplayedHeader, "true")
+			if existing.ContentType != "" {
+				c.Set(fiber.HeaderContentType, existing.ContentType)
+			}
+			return c.Status(existing.StatusCode).Send(existing.ResponseBody)
+		}
+
+		if err := c.Next(); err != nil {
+			releaseIdempotencyKey(ctx, config, record.Key)
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			releaseIdempotencyKey(ctx, config, record.Key)
+			return nil
+		}
+
+		record.Completed = true
+		record.StatusCode = status
+		record.ContentType = string(c.Response().Header.ContentType())
+		record.ResponseBody = append([]byte(nil), c.Response().Body()...)
+		if err := config.Store.Complete(ctx, record); err != nil {
+			config.Logger.Error("failed to store idempotent response", zap.String("path", c.Path()), zap.Error(err))
+		}
+		return nil
+	}
+}
+
+// idempotencyScope ties a client key to the caller, method and path so keys
+// from different users or endpoints never collide
+func idempotencyScope(c *fiber.Ctx, clientKey string) string {
+	caller := "ip:" + c.IP()
+	if authCtx, ok := GetAuthContext(c); ok {
+		caller = "user:" + authCtx.TenantID.String() + ":" + authCtx.UserID.String()
+	}
+	return hashHex([]byte(caller + "\n" + c.Method() + " " + c.Path() + "\n" + clientKey))
+}
+
+func releaseIdempotencyKey(ctx context.Context, config IdempotencyConfig, key string) {
+	if err := config.Store.Release(ctx, key); err != nil {
+		config.Logger.Error("failed to release idempotency key", zap.Error(err))
+	}
+}
+
+func idempotencyError(c *fiber.Ctx, status int, code, message string) error {
+	return SendError(c, status, pkgErrors.ErrorCode(code), message)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// CacheIdempotencyStore keeps idempotency keys in Redis
+type CacheIdempotencyStore struct {
+	cache cache.Cache
+}
+
+// NewCacheIdempotencyStore creates a Redis-backed idempotency store
+func NewCacheIdempotencyStore(c cache.Cache) *CacheIdempotencyStore {
+	return &CacheIdempotencyStore{cache: c}
+}
+
+// Reserve claims the key with SETNX
+func (s *CacheIdempotencyStore) Reserve(ctx context.Context, record *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, false, err
+	}
+
+	reserved, err := s.cache.SetNX(ctx, s.cacheKey(record.Key), data, time.Until(record.ExpiresAt))
+	if err != nil || reserved {
+		return nil, reserved, err
+	}
+
+	var existing models.IdempotencyKey
+	if err := s.cache.GetJSON(ctx, s.cacheKey(record.Key), &existing); err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return &existing, false, nil
+}
+
+// Complete overwrites the reservation with the response, keeping its expiry
+func (s *CacheIdempotencyStore) Complete(ctx context.Context, record *models.IdempotencyKey) error {
+	return s.cache.SetJSON(ctx, s.cacheKey(record.Key), record, time.Until(record.ExpiresAt))
+}
+
+// Release deletes the key
+func (s *CacheIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.cache.Delete(ctx, s.cacheKey(key))
+}
+
+func (s *CacheIdempotencyStore) cacheKey(key string) string {
+	return "idempotency:" + key
+}
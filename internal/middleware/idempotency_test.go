@@ -0,0 +1,193 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryIdempotencyStore keeps keys in memory, failing every call with err when set
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]models.IdempotencyKey
+	err     error
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: map[string]models.IdempotencyKey{}}
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, record *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, false, s.err
+	}
+	if existing, ok := s.records[record.Key]; ok {
+		return &existing, false, nil
+	}
+	s.records[record.Key] = *record
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, record *models.IdempotencyKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.records[record.Key] = *record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	delete(s.records, key)
+	return nil
+}
+
+// newIdempotentApp serves POST /bookings behind the idempotency middleware with handler
+func newIdempotentApp(store middleware.IdempotencyStore, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Post("/bookings", middleware.Idempotency(middleware.DefaultIdempotencyConfig(store, zap.NewNop())), handler)
+	return app
+}
+
+func postBooking(t *testing.T, app *fiber.App, key, body string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, "/bookings", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	return resp
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	calls := 0
+	app := newIdempotentApp(newMemoryIdempotencyStore(), func(c *fiber.Ctx) error {
+		calls++
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"booking": calls})
+	})
+
+	first := postBooking(t, app, "key-1", `{"service":"haircut"}`)
+	assert.Equal(t, fiber.StatusCreated, first.StatusCode)
+	assert.Empty(t, first.Header.Get(middleware.IdempotentReplayedHeader))
+	firstBody := readBody(t, first)
+
+	replay := postBooking(t, app, "key-1", `{"service":"haircut"}`)
+	assert.Equal(t, fiber.StatusCreated, replay.StatusCode)
+	assert.Equal(t, "true", replay.Header.Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, fiber.MIMEApplicationJSON, replay.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, firstBody, readBody(t, replay))
+	assert.Equal(t, 1, calls, "the retry is not run again")
+
+	other := postBooking(t, app, "key-2", `{"service":"haircut"}`)
+	assert.Equal(t, fiber.StatusCreated, other.StatusCode)
+	assert.Equal(t, 2, calls, "another key is a new request")
+}
+
+func TestIdempotency_RejectsKeyReusedWithDifferentBody(t *testing.T) {
+	calls := 0
+	app := newIdempotentApp(newMemoryIdempotencyStore(), func(c *fiber.Ctx) error {
+		calls++
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	assert.Equal(t, fiber.StatusCreated, postBooking(t, app, "key-1", `{"service":"haircut"}`).StatusCode)
+
+	reused := postBooking(t, app, "key-1", `{"service":"shave"}`)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, reused.StatusCode)
+	assert.Contains(t, readBody(t, reused), "IDEMPOTENCY_KEY_REUSED")
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_RejectsRequestWhileKeyIsReserved(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	calls := 0
+	app := newIdempotentApp(newMemoryIdempotencyStore(), func(c *fiber.Ctx) error {
+		calls++
+		close(started)
+		<-release
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	done := make(chan int)
+	go func() {
+		req := httptest.NewRequest(fiber.MethodPost, "/bookings", strings.NewReader(`{"service":"haircut"}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			done <- 0
+			return
+		}
+		done <- resp.StatusCode
+	}()
+	<-started
+
+	concurrent := postBooking(t, app, "key-1", `{"service":"haircut"}`)
+	assert.Equal(t, fiber.StatusConflict, concurrent.StatusCode)
+	assert.Contains(t, readBody(t, concurrent), "IDEMPOTENCY_KEY_IN_PROGRESS")
+
+	close(release)
+	assert.Equal(t, fiber.StatusCreated, <-done)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_ReleasesKeyAfterServerError(t *testing.T) {
+	calls := 0
+	app := newIdempotentApp(newMemoryIdempotencyStore(), func(c *fiber.Ctx) error {
+		calls++
+		if calls == 1 {
+			return c.SendStatus(fiber.StatusServiceUnavailable)
+		}
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	assert.Equal(t, fiber.StatusServiceUnavailable, postBooking(t, app, "key-1", `{"service":"haircut"}`).StatusCode)
+
+	retry := postBooking(t, app, "key-1", `{"service":"haircut"}`)
+	assert.Equal(t, fiber.StatusCreated, retry.StatusCode)
+	assert.Empty(t, retry.Header.Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, 2, calls, "the retry runs again")
+}
+
+func TestIdempotency_FailsOpenWhenStoreIsUnavailable(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	store.err = errors.New("connection refused")
+
+	calls := 0
+	app := newIdempotentApp(store, func(c *fiber.Ctx) error {
+		calls++
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	assert.Equal(t, fiber.StatusCreated, postBooking(t, app, "key-1", `{"service":"haircut"}`).StatusCode)
+	assert.Equal(t, fiber.StatusCreated, postBooking(t, app, "key-1", `{"service":"haircut"}`).StatusCode)
+	assert.Equal(t, 2, calls, "requests are served without idempotency")
+}
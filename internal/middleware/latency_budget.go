@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"time"
+
+	"Krafti_Vibe/internal/pkg/metrics"
+	"Krafti_Vibe/internal/pkg/profiling"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// LatencyBudgetConfig holds configuration for the latency budget middleware
+type LatencyBudgetConfig struct {
+	// Budgets holds the latency budgets of routes, keyed by route pattern, such as
+	// /api/v1/bookings/:id, optionally preceded by a method, such as
+	// POST /api/v1/bookings. Budgets with a method win over those without.
+	Budgets map[string]time.Duration
+
+	// Default is the budget of routes without one; zero leaves them unbudgeted
+	Default time.Duration
+
+	// Profiler captures profiles labeled with the route when a request exceeds its
+	// budget. Optional.
+	Profiler *profiling.Profiler
+
+	// Metrics counts requests over budget by route. Optional.
+	Metrics *metrics.PrometheusMetrics
+
+	Logger *zap.Logger
+}
+
+// LatencyBudget creates a middleware holding requests to their route's latency budget.
+// Requests exceeding it are counted and trigger a profile capture labeled with the
+// route, at most once per route within the profiler's cooldown, so slow endpoints can
+// be profiled in production without having anyone reproduce them.
+func LatencyBudget(config LatencyBudgetConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		latency := time.Since(start)
+
+		method, route := c.Method(), routeLabel(c)
+		budget, ok := config.Budgets[method+" "+route]
+		if !ok {
+			budget, ok = config.Budgets[route]
+		}
+		if !ok {
+			budget = config.Default
+		}
+		if budget <= 0 || latency <= budget {
+			return err
+		}
+
+		if config.Metrics != nil {
+			config.Metrics.RecordHTTPBudgetExceeded(method, route)
+		}
+		if config.Profiler != nil && config.Profiler.Trigger(method, route, latency) {
+			config.Logger.Warn("request exceeded latency budget; capturing profiles",
+				zap.String("method", method),
+				zap.String("route", route),
+				zap.Duration("latency", latency),
+				zap.Duration("budget", budget),
+			)
+		}
+		return err
+	}
+}
@@ -0,0 +1,383 @@
+package middleware
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/metrics"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Route classes of the load shedder
+const (
+	// RouteClassCritical holds latency sensitive routes, such as booking creation,
+	// whose latency adaptive classes make room for
+	RouteClassCritical = "critical"
+	// RouteClassDefault is every route not otherwise classified
+	RouteClassDefault = "default"
+	// RouteClassExpensive holds routes doing heavy work, such as analytics and exports
+	RouteClassExpensive = "expensive"
+)
+
+// adjustInterval is how often adaptive limits are adjusted
+const adjustInterval = time.Second
+
+// RouteClassLimit is how many requests of a route class are served at once
+type RouteClassLimit struct {
+	Limit int
+
+	// Adaptive classes have their limit shrunk while critical requests are slower than
+	// the latency target, and grown back to Limit once they are not
+	Adaptive bool
+}
+
+// LoadSheddingConfig holds configuration for the load shedding middleware
+type LoadSheddingConfig struct {
+	// Classes holds the concurrency limit of each route class
+	Classes map[string]RouteClassLimit
+
+	// Routes assigns routes to classes. A route is a path prefix, such as
+	// /api/v1/reports, optionally preceded by a method, such as POST /api/v1/bookings,
+	// or a path segment matched anywhere in the path, such as stats. Routes with a
+	// method win over those without, then longer prefixes over shorter ones, then
+	// prefixes over segments.
+	Routes map[string]string
+
+	// QueueTimeout is how long a request waits for a slot of its class before it is
+	// shed; at most as many requests as the class's limit wait at once
+	QueueTimeout time.Duration
+
+	// LatencyTarget is the average latency of critical requests above which adaptive
+	// classes are shrunk. Zero disables adaptation.
+	LatencyTarget time.Duration
+
+	// RetryAfter is when shed requests are told to retry
+	RetryAfter time.Duration
+
+	// SkipPaths contains paths that are never limited, such as long-lived websockets
+	SkipPaths []string
+
+	// Metrics records limits, requests in flight and shed requests when set
+	Metrics *metrics.PrometheusMetrics
+
+	Logger *zap.Logger
+}
+
+// DefaultLoadSheddingConfig returns default load shedding configuration, in which
+// booking writes are critical and reports, exports, analytics and statistics expensive
+func DefaultLoadSheddingConfig(logger *zap.Logger) LoadSheddingConfig {
+	return LoadSheddingConfig{
+		Classes: map[string]RouteClassLimit{
+			RouteClassCritical:  {Limit: 200},
+			RouteClassDefault:   {Limit: 100},
+			RouteClassExpensive: {Limit: 8, Adaptive: true},
+		},
+		Routes: map[string]string{
+			"POST /api/v1/bookings": RouteClassCritical,
+			"/api/v1/reports":       RouteClassExpensive,
+			"/api/v1/data-exports":  RouteClassExpensive,
+			"/api/v1/search":        RouteClassExpensive,
+			"analytics":             RouteClassExpensive,
+			"export":                RouteClassExpensive,
+			"exports":               RouteClassExpensive,
+			"stats":                 RouteClassExpensive,
+			"registration-stats":    RouteClassExpensive,
+		},
+		QueueTimeout:  250 * time.Millisecond,
+		LatencyTarget: 500 * time.Millisecond,
+		RetryAfter:    2 * time.Second,
+		SkipPaths: []string{
+			"/api/v1/ws",
+		},
+		Logger: logger,
+	}
+}
+
+// routeRule assigns the requests it matches to a class
+type routeRule struct {
+	method  string // Empty for any
+	prefix  string // Empty for segment rules
+	segment string
+	class   string
+}
+
+// matches reports whether the rule matches a request
+func (r routeRule) matches(method, path string) bool {
+	if r.method != "" && r.method != method {
+		return false
+	}
+	if r.prefix != "" {
+		return path == r.prefix || strings.HasPrefix(path, strings.TrimSuffix(r.prefix, "/")+"/")
+	}
+	for segment := range strings.SplitSeq(path, "/") {
+		if segment == r.segment {
+			return true
+		}
+	}
+	return false
+}
+
+// classLimiter serves a route class's requests up to its limit at once, queueing the
+// rest in arrival order
+type classLimiter struct {
+	name     string
+	max      int
+	adaptive bool
+
+	mu       sync.Mutex
+	limit    int
+	inFlight int
+	waiters  []chan struct{}
+}
+
+// acquire takes a slot, waiting up to timeout for one. It returns why the request was
+// shed when it gets none.
+func (l *classLimiter) acquire(timeout time.Duration) (bool, string) {
+	l.mu.Lock()
+	if l.inFlight < l.limit && len(l.waiters) == 0 {
+		l.inFlight++
+		l.mu.Unlock()
+		return true, ""
+	}
+	if len(l.waiters) >= l.limit || timeout <= 0 {
+		l.mu.Unlock()
+		return false, "queue_full"
+	}
+	granted := make(chan struct{})
+	l.waiters = append(l.waiters, granted)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-granted:
+		return true, ""
+	case <-timer.C:
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if i := slices.Index(l.waiters, granted); i >= 0 {
+		l.waiters = slices.Delete(l.waiters, i, i+1)
+		return false, "queue_timeout"
+	}
+	// The slot was granted as the wait timed out
+	return true, ""
+}
+
+// release frees a slot, handing it to the request waiting longest
+func (l *classLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	l.grant()
+}
+
+// setLimit changes the limit, letting waiting requests in when it grows
+func (l *classLimiter) setLimit(limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+	l.grant()
+}
+
+// grant hands free slots to waiting requests; l.mu must be held
+func (l *classLimiter) grant() {
+	for len(l.waiters) > 0 && l.inFlight < l.limit {
+		close(l.waiters[0])
+		l.waiters = l.waiters[1:]
+		l.inFlight++
+	}
+}
+
+// stats returns the limit and the requests in flight
+func (l *classLimiter) stats() (limit, inFlight int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit, l.inFlight
+}
+
+// LoadShedder caps the requests served at once by route class, so a burst of
+// expensive requests can't starve cheap ones. Requests over their class's limit
+// queue briefly and are answered 503 with Retry-After when no slot frees up in
+// time. While critical requests, such as booking creation, run slower than the
+// latency target, adaptive classes are shrunk to make room for them.
+type LoadShedder struct {
+	config  LoadSheddingConfig
+	rules   []routeRule
+	classes map[string]*classLimiter
+
+	mu            sync.Mutex // Guards the latency average and adjustment
+	latency       time.Duration
+	latencySample bool // Whether a critical request completed since the last adjustment
+	lastAdjust    time.Time
+}
+
+// NewLoadShedder creates a new load shedder
+func NewLoadShedder(config LoadSheddingConfig) *LoadShedder {
+	s := &LoadShedder{
+		config:     config,
+		classes:    make(map[string]*classLimiter),
+		lastAdjust: time.Now(),
+	}
+	for name, class := range config.Classes {
+		s.classes[name] = &classLimiter{name: name, max: class.Limit, limit: class.Limit, adaptive: class.Adaptive}
+	}
+	if _, ok := s.classes[RouteClassDefault]; !ok {
+		s.classes[RouteClassDefault] = &classLimiter{name: RouteClassDefault, max: 100, limit: 100}
+	}
+
+	for route, class := range config.Routes {
+		if _, ok := s.classes[class]; !ok {
+			config.Logger.Warn("route assigned to unknown class; using the default class",
+				zap.String("route", route),
+				zap.String("class", class),
+			)
+			class = RouteClassDefault
+		}
+		rule := routeRule{class: class}
+		if method, prefix, ok := strings.Cut(route, " "); ok {
+			rule.method, route = strings.ToUpper(method), strings.TrimSpace(prefix)
+		}
+		if strings.HasPrefix(route, "/") {
+			rule.prefix = route
+		} else {
+			rule.segment = route
+		}
+		s.rules = append(s.rules, rule)
+	}
+	slices.SortFunc(s.rules, func(a, b routeRule) int {
+		if (a.method != "") != (b.method != "") {
+			return cmp.Compare(b.method, a.method) // A method sorts before none
+		}
+		if a.prefix != "" || b.prefix != "" {
+			return cmp.Compare(len(b.prefix), len(a.prefix))
+		}
+		return cmp.Compare(a.segment, b.segment)
+	})
+	return s
+}
+
+// classify returns the class of a request
+func (s *LoadShedder) classify(method, path string) *classLimiter {
+	for _, rule := range s.rules {
+		if rule.matches(method, path) {
+			return s.classes[rule.class]
+		}
+	}
+	return s.classes[RouteClassDefault]
+}
+
+// Handler returns the middleware
+func (s *LoadShedder) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		for _, skipPath := range s.config.SkipPaths {
+			if path == skipPath || strings.HasPrefix(path, skipPath+"/") {
+				return c.Next()
+			}
+		}
+
+		class := s.classify(c.Method(), path)
+		if ok, reason := class.acquire(s.config.QueueTimeout); !ok {
+			return s.shed(c, class, reason)
+		}
+		s.recordStats(class)
+
+		start := time.Now()
+		defer func() {
+			class.release()
+			s.recordStats(class)
+			s.observe(class, time.Since(start))
+		}()
+		return c.Next()
+	}
+}
+
+// shed answers 503 with Retry-After
+func (s *LoadShedder) shed(c *fiber.Ctx, class *classLimiter, reason string) error {
+	if s.config.Metrics != nil {
+		s.config.Metrics.RecordHTTPShed(class.name, reason)
+	}
+	s.config.Logger.Debug("request shed",
+		zap.String("class", class.name),
+		zap.String("reason", reason),
+		zap.String("path", c.Path()),
+	)
+
+	retryAfter := max(int64((s.config.RetryAfter+time.Second-1)/time.Second), 1)
+	c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+	return SendProblem(c, pkgErrors.NewProblem(fiber.StatusServiceUnavailable, pkgErrors.ErrCodeServiceUnavailable,
+		"The server is busy. Please try again later.").With("retry_after", retryAfter))
+}
+
+// recordStats records a class's limit and requests in flight
+func (s *LoadShedder) recordStats(class *classLimiter) {
+	if s.config.Metrics == nil {
+		return
+	}
+	limit, inFlight := class.stats()
+	s.config.Metrics.SetHTTPConcurrency(class.name, limit, inFlight)
+}
+
+// observe averages the latency of critical requests, adjusting the adaptive classes'
+// limits at most every adjustInterval
+func (s *LoadShedder) observe(class *classLimiter, latency time.Duration) {
+	if s.config.LatencyTarget <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	if class.name == RouteClassCritical {
+		if s.latencySample {
+			s.latency = (s.latency*4 + latency) / 5
+		} else {
+			s.latency = latency
+		}
+		s.latencySample = true
+	}
+	now := time.Now()
+	if now.Sub(s.lastAdjust) < adjustInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastAdjust = now
+	// Without critical requests since the last adjustment there is nothing to protect
+	overloaded := s.latencySample && s.latency > s.config.LatencyTarget
+	average := s.latency
+	s.latencySample = false
+	s.mu.Unlock()
+
+	for _, limiter := range s.classes {
+		if !limiter.adaptive {
+			continue
+		}
+		current, _ := limiter.stats()
+		next := current
+		if overloaded {
+			next = max(current*3/4, 1) // Back off quickly
+		} else {
+			next = min(current+1, limiter.max) // Recover slowly
+		}
+		if next == current {
+			continue
+		}
+		limiter.setLimit(next)
+		s.recordStats(limiter)
+		if overloaded {
+			s.config.Logger.Warn("critical requests over latency target; shrinking concurrency limit",
+				zap.String("class", limiter.name),
+				zap.Int("limit", next),
+				zap.Duration("latency", average),
+				zap.Duration("target", s.config.LatencyTarget),
+			)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/i18n"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// localeResolverKey is the local the locale resolver is stored under
+const localeResolverKey = "locale_resolver"
+
+// TenantLanguageResolver returns the language a tenant's users are served in by default
+type TenantLanguageResolver interface {
+	TenantLanguage(ctx context.Context, tenantID uuid.UUID) (string, error)
+}
+
+// LocaleConfig holds the configuration of the locale resolver
+type LocaleConfig struct {
+	// Languages resolves the default language of tenants
+	Languages TenantLanguageResolver
+
+	// Logger for failures to resolve a language
+	Logger *zap.Logger
+
+	// CacheTTL is how long a tenant's language is remembered
+	CacheTTL time.Duration
+}
+
+// DefaultLocaleConfig returns the default locale resolver configuration
+func DefaultLocaleConfig(languages TenantLanguageResolver, logger *zap.Logger) LocaleConfig {
+	return LocaleConfig{
+		Languages: languages,
+		Logger:    logger,
+		CacheTTL:  5 * time.Minute,
+	}
+}
+
+// cachedLanguage is a tenant's language as resolved at some point
+type cachedLanguage struct {
+	language string
+	expires  time.Time
+}
+
+// LocaleResolver works out the languages a request's error responses are written in.
+// Failures to resolve a tenant's language are logged and leave it out.
+type LocaleResolver struct {
+	config    LocaleConfig
+	languages sync.Map // uuid.UUID -> cachedLanguage
+}
+
+// NewLocaleResolver creates a new locale resolver
+func NewLocaleResolver(config LocaleConfig) *LocaleResolver {
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = DefaultLocaleConfig(nil, nil).CacheTTL
+	}
+
+	return &LocaleResolver{
+		config: config,
+	}
+}
+
+// Handler makes the resolver available to the request. The locale is only worked out
+// once a response needs it, by which time the user is authenticated.
+func (r *LocaleResolver) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(localeResolverKey, r)
+		return c.Next()
+	}
+}
+
+// tenantLanguage returns the tenant's language, resolving it at most once per CacheTTL
+func (r *LocaleResolver) tenantLanguage(ctx context.Context, tenantID uuid.UUID) string {
+	now := time.Now()
+	if cached, ok := r.languages.Load(tenantID); ok && now.Before(cached.(cachedLanguage).expires) {
+		return cached.(cachedLanguage).language
+	}
+
+	language, err := r.config.Languages.TenantLanguage(ctx, tenantID)
+	if err != nil {
+		r.config.Logger.Warn("failed to resolve tenant language",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err),
+		)
+		return ""
+	}
+	r.languages.Store(tenantID, cachedLanguage{language: language, expires: now.Add(r.config.CacheTTL)})
+	return language
+}
+
+// RequestLocalizer returns the localizer of a request: the languages its
+// Accept-Language header asks for, then its user's preferred language, then its
+// tenant's default language, then English
+func RequestLocalizer(c *fiber.Ctx) i18n.Localizer {
+	locales := i18n.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage))
+	if user, ok := c.Locals("db_user").(*models.User); ok && user != nil {
+		locales = append(locales, user.Language)
+	}
+
+	if resolver, ok := c.Locals(localeResolverKey).(*LocaleResolver); ok && resolver.config.Languages != nil {
+		tenantID, ok := HostTenant(c)
+		if !ok {
+			tenantID = RequestTenant(c)
+		}
+		if tenantID != uuid.Nil {
+			locales = append(locales, resolver.tenantLanguage(c.UserContext(), tenantID))
+		}
+	}
+
+	return i18n.New(locales...)
+}
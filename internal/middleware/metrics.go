@@ -30,14 +30,13 @@ func MetricsMiddleware(m *metrics.PrometheusMetrics) fiber.Handler {
 		duration := time.Since(start)
 
 		// Get response details
-		statusCode := c.Response().StatusCode()
+		statusCode := responseStatus(c, err)
 		responseSize := len(c.Response().Body())
 		method := c.Method()
-		path := c.Path()
 
-		// Normalize path to reduce cardinality
-		// Remove IDs and dynamic segments
-		normalizedPath := normalizePath(path)
+		// Label requests by the route they matched rather than their path, whose IDs
+		// would make every request a series of its own
+		normalizedPath := routeLabel(c)
 
 		// Record HTTP metrics
 		m.RecordHTTPRequest(method, normalizedPath, statusCode, duration, requestSize, responseSize)
@@ -59,16 +58,24 @@ func MetricsMiddleware(m *metrics.PrometheusMetrics) fiber.Handler {
 	}
 }
 
-// normalizePath normalizes the path to reduce metric cardinality
-// Replaces UUIDs and numeric IDs with placeholders
-func normalizePath(path string) string {
-	// Common path patterns to normalize
-	// For production, consider using a more sophisticated path normalizer
-	// or registering known routes
+// routeLabel returns the route a request matched, such as "/api/v1/bookings/:id", once
+// it has been handled. Requests answered by a middleware are labelled with the prefix
+// it is mounted at, and those no route matched with "unmatched".
+func routeLabel(c *fiber.Ctx) string {
+	route := c.Route()
+	if route.Method == "USE" && c.Response().StatusCode() == fiber.StatusNotFound {
+		return "unmatched"
+	}
+	return route.Path
+}
 
-	// Simple implementation - return as-is for now
-	// In production, you'd want to replace IDs with :id placeholder
-	return path
+// responseStatus returns the status a request is answered with once handled, counting
+// errors returned to the error handler, which answers after every middleware returned
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err != nil {
+		return ProblemFromError(err).Status
+	}
+	return c.Response().StatusCode()
 }
 
 // getErrorType categorizes HTTP errors
@@ -336,7 +343,7 @@ func MetricsMiddlewareWithConfig(m *metrics.PrometheusMetrics, config MetricsCon
 		err := c.Next()
 
 		duration := time.Since(start)
-		statusCode := c.Response().StatusCode()
+		statusCode := responseStatus(c, err)
 		method := c.Method()
 
 		var responseSize int
@@ -346,7 +353,7 @@ func MetricsMiddlewareWithConfig(m *metrics.PrometheusMetrics, config MetricsCon
 
 		normalizedPath := path
 		if config.PathNormalization {
-			normalizedPath = normalizePath(path)
+			normalizedPath = routeLabel(c)
 		}
 
 		m.RecordHTTPRequest(method, normalizedPath, statusCode, duration, requestSize, responseSize)
@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// mfaAuthMethod is the authentication method reference (RFC 8176) Zitadel puts in the
+// tokens of users who signed in with a second factor
+const mfaAuthMethod = "mfa"
+
+// MFAPolicyResolver returns the multi-factor authentication requirements of a tenant
+type MFAPolicyResolver interface {
+	TenantMFAPolicy(ctx context.Context, tenantID uuid.UUID) (models.MFAPolicy, error)
+}
+
+// MFARecorder records when users were seen signed in with a second factor
+type MFARecorder interface {
+	RecordMFA(ctx context.Context, userID uuid.UUID, at time.Time) error
+}
+
+// MFAPolicyConfig holds the configuration of the MFA policy guard
+type MFAPolicyConfig struct {
+	// Policies resolves the policy of authenticated tenants
+	Policies MFAPolicyResolver
+
+	// Recorder keeps when users last signed in with a second factor, for compliance
+	// reports; nil records nothing
+	Recorder MFARecorder
+
+	// Logger for failures to resolve a policy or record a sign-in
+	Logger *zap.Logger
+
+	// CacheTTL is how long a tenant's policy is remembered, so policy changes take up
+	// to this long to be enforced
+	CacheTTL time.Duration
+
+	// RecordInterval is how often a user's multi-factor sign-ins are recorded at most
+	RecordInterval time.Duration
+}
+
+// DefaultMFAPolicyConfig returns the default MFA policy guard configuration
+func DefaultMFAPolicyConfig(policies MFAPolicyResolver, recorder MFARecorder, logger *zap.Logger) MFAPolicyConfig {
+	return MFAPolicyConfig{
+		Policies:       policies,
+		Recorder:       recorder,
+		Logger:         logger,
+		CacheTTL:       30 * time.Second,
+		RecordInterval: time.Hour,
+	}
+}
+
+// cachedMFAPolicy is a tenant's policy as resolved at some point
+type cachedMFAPolicy struct {
+	policy  models.MFAPolicy
+	expires time.Time
+}
+
+// MFAPolicyGuard holds authenticated requests to their tenant's multi-factor
+// authentication policy: users in the roles it covers are refused unless their token
+// shows a second factor, and sensitive actions can require a recent multi-factor
+// sign-in (step-up). Platform users and M2M tokens are not held to it.
+//
+// Failures to resolve a policy are logged and let the request through, as with tenant
+// statuses.
+type MFAPolicyGuard struct {
+	config   MFAPolicyConfig
+	policies sync.Map // uuid.UUID -> cachedMFAPolicy
+	recorded sync.Map // uuid.UUID -> time.Time
+}
+
+// NewMFAPolicyGuard creates a new MFA policy guard
+func NewMFAPolicyGuard(config MFAPolicyConfig) *MFAPolicyGuard {
+	defaults := DefaultMFAPolicyConfig(nil, nil, nil)
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = defaults.CacheTTL
+	}
+	if config.RecordInterval <= 0 {
+		config.RecordInterval = defaults.RecordInterval
+	}
+
+	return &MFAPolicyGuard{
+		config: config,
+	}
+}
+
+// Check holds an authenticated request to its tenant's MFA policy, recording the
+// multi-factor sign-ins it sees. It returns false once it has answered 403, with the
+// error the caller is to return.
+func (g *MFAPolicyGuard) Check(c *fiber.Ctx) (bool, error) {
+	user, authCtx, ok := g.subject(c)
+	if !ok {
+		return true, nil
+	}
+
+	if hasMFA(authCtx) {
+		g.record(c.UserContext(), user.ID)
+		return true, nil
+	}
+
+	policy, ok := g.tenantPolicy(c.UserContext(), *user.TenantID)
+	if !ok || !policy.Requires(user.Role) {
+		return true, nil
+	}
+	return false, SendError(c, fiber.StatusForbidden, "MFA_REQUIRED", "Your organization requires multi-factor authentication; sign in again with a second factor")
+}
+
+// RequireStepUp returns middleware for sensitive actions, such as refunds and payouts,
+// which tenants may require a recent multi-factor sign-in for. Requests without one
+// are answered 401 with a step-up challenge (RFC 9470), telling the client to have the
+// user sign in again with a second factor.
+func (g *MFAPolicyGuard) RequireStepUp() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, authCtx, ok := g.subject(c)
+		if !ok {
+			return c.Next()
+		}
+
+		policy, ok := g.tenantPolicy(c.UserContext(), *user.TenantID)
+		if !ok || !policy.StepUpSensitiveActions {
+			return c.Next()
+		}
+
+		maxAge := policy.StepUpMaxAge()
+		if hasMFA(authCtx) {
+			authTime := authCtx.IntrospectCtx.AuthTime.AsTime()
+			if !authTime.IsZero() && time.Since(authTime) <= maxAge {
+				return c.Next()
+			}
+		}
+
+		c.Set(fiber.HeaderWWWAuthenticate, fmt.Sprintf(
+			`Bearer error="insufficient_user_authentication", error_description="A recent multi-factor sign-in is required", max_age=%d`,
+			int(maxAge.Seconds()),
+		))
+		return SendProblem(c, pkgErrors.NewProblem(fiber.StatusUnauthorized, "MFA_STEP_UP_REQUIRED",
+			"Sign in again with a second factor to continue").With("max_age", int(maxAge.Seconds())))
+	}
+}
+
+// subject returns the tenant user an authenticated request is made by, if it is held
+// to a tenant's policy
+func (g *MFAPolicyGuard) subject(c *fiber.Ctx) (*models.User, *AuthContext, bool) {
+	authCtx, ok := GetAuthContext(c)
+	if !ok || authCtx.IsM2M {
+		return nil, nil, false
+	}
+	user, ok := c.Locals("db_user").(*models.User)
+	if !ok || user == nil || user.IsPlatformUser || user.TenantID == nil {
+		return nil, nil, false
+	}
+	return user, authCtx, true
+}
+
+// tenantPolicy returns the tenant's policy, resolving it at most once per CacheTTL.
+// It returns false when the policy can't be resolved.
+func (g *MFAPolicyGuard) tenantPolicy(ctx context.Context, tenantID uuid.UUID) (models.MFAPolicy, bool) {
+	now := time.Now()
+	if cached, ok := g.policies.Load(tenantID); ok && now.Before(cached.(cachedMFAPolicy).expires) {
+		return cached.(cachedMFAPolicy).policy, true
+	}
+
+	policy, err := g.config.Policies.TenantMFAPolicy(ctx, tenantID)
+	if err != nil {
+		g.config.Logger.Warn("failed to resolve tenant MFA policy; allowing request",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err),
+		)
+		return models.MFAPolicy{}, false
+	}
+	g.policies.Store(tenantID, cachedMFAPolicy{policy: policy, expires: now.Add(g.config.CacheTTL)})
+	return policy, true
+}
+
+// record keeps that the user was seen signed in with a second factor, at most once per
+// RecordInterval
+func (g *MFAPolicyGuard) record(ctx context.Context, userID uuid.UUID) {
+	if g.config.Recorder == nil {
+		return
+	}
+
+	now := time.Now()
+	if last, ok := g.recorded.Load(userID); ok && now.Sub(last.(time.Time)) < g.config.RecordInterval {
+		return
+	}
+	if err := g.config.Recorder.RecordMFA(ctx, userID, now); err != nil {
+		g.config.Logger.Warn("failed to record MFA sign-in",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	g.recorded.Store(userID, now)
+}
+
+// hasMFA reports whether the token was issued for a sign-in with a second factor
+func hasMFA(authCtx *AuthContext) bool {
+	if authCtx.IntrospectCtx == nil {
+		return false
+	}
+	return slices.Contains(authCtx.IntrospectCtx.AuthenticationMethodsReferences, mfaAuthMethod)
+}
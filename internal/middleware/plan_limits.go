@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"Krafti_Vibe/internal/domain/models"
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PlanEnforcer decides whether a tenant's subscription plan covers a request
+type PlanEnforcer interface {
+	// EnforcePlan returns a 402 AppError wrapping a PlanLimitError when the tenant's
+	// subscription is not in good standing or limit has no room left
+	EnforcePlan(ctx context.Context, tenantID uuid.UUID, limit models.PlanLimit) error
+}
+
+// RequirePlan returns a middleware that refuses a request with 402 Payment Required when
+// the tenant's plan does not cover it. An empty limit only checks the subscription is
+// in good standing. Requests without a tenant, such as platform staff, pass through.
+// Failures to evaluate the plan are logged and let the request through, so a billing
+// outage never blocks tenants from working.
+func RequirePlan(enforcer PlanEnforcer, limit models.PlanLimit, logger *zap.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authCtx, ok := GetAuthContext(c)
+		if !ok || authCtx.TenantID == uuid.Nil {
+			return c.Next()
+		}
+
+		err := enforcer.EnforcePlan(c.UserContext(), authCtx.TenantID, limit)
+		if err == nil {
+			return c.Next()
+		}
+
+		var planErr *pkgErrors.PlanLimitError
+		if errors.As(err, &planErr) {
+			return SendProblem(c, pkgErrors.ToProblem(err))
+		}
+
+		logger.Warn("plan enforcement failed; allowing request",
+			zap.String("tenant_id", authCtx.TenantID.String()),
+			zap.String("limit", string(limit)),
+			zap.Error(err),
+		)
+		return c.Next()
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SendProblem writes a problem as the application/problem+json response, filling in
+// the request it is about so it can be correlated with the logs and traces, and
+// translating it for the request's locale
+func SendProblem(c *fiber.Ctx, p *pkgErrors.Problem) error {
+	localizer := RequestLocalizer(c)
+	p.Localize(localizer)
+	c.Set(fiber.HeaderContentLanguage, localizer.Locale())
+	c.Vary(fiber.HeaderAcceptLanguage)
+
+	p.Instance = c.Path()
+	if requestID, ok := c.Locals("request_id").(string); ok && requestID != "" {
+		p.RequestID = requestID
+	} else {
+		p.RequestID = c.Get(fiber.HeaderXRequestID)
+	}
+	if spanContext := trace.SpanContextFromContext(c.UserContext()); spanContext.IsValid() {
+		p.TraceID = spanContext.TraceID().String()
+	}
+
+	return c.Status(p.Status).JSON(p, pkgErrors.ProblemContentType)
+}
+
+// SendError writes a problem of the given status and code
+func SendError(c *fiber.Ctx, status int, code pkgErrors.ErrorCode, detail string) error {
+	return SendProblem(c, pkgErrors.NewProblem(status, code, detail))
+}
+
+// SendValidationError writes a validation problem listing each field that failed
+func SendValidationError(c *fiber.Ctx, status int, detail string, fields []pkgErrors.FieldError) error {
+	p := pkgErrors.NewProblem(status, pkgErrors.ErrCodeValidation, detail).WithDetailKey("validation.failed", nil)
+	p.Errors = fields
+	return SendProblem(c, p)
+}
+
+// ProblemFromError maps an error returned by a handler to its problem. Errors raised by
+// Fiber carry only a status, whose standard code is used.
+func ProblemFromError(err error) *pkgErrors.Problem {
+	var e *fiber.Error
+	if errors.As(err, &e) {
+		return pkgErrors.NewProblem(e.Code, "", e.Message)
+	}
+	return pkgErrors.ToProblem(err)
+}
@@ -1,33 +1,82 @@
 package middleware
 
 import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
 	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/metrics"
+	"Krafti_Vibe/internal/pkg/panics"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// RecoveryConfig holds configuration for the recovery middleware
+type RecoveryConfig struct {
+	Logger *zap.Logger
+
+	// Tracker fingerprints panics, counts them against each route's error budget and
+	// reports recurring ones. Optional.
+	Tracker *panics.Tracker
+
+	// Metrics counts panics by route. Optional.
+	Metrics *metrics.PrometheusMetrics
+}
+
 // Recovery creates a recovery middleware with structured logging
 func Recovery(logger *zap.Logger) fiber.Handler {
+	return RecoveryWithConfig(RecoveryConfig{Logger: logger})
+}
+
+// RecoveryWithConfig creates a recovery middleware answering a request that panics
+// with a 500, so the panic is isolated to it. Each panic is logged with its
+// fingerprint, counted by route and recorded with the tracker.
+func RecoveryWithConfig(config RecoveryConfig) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		defer func() {
 			if r := recover(); r != nil {
+				stack := debug.Stack()
+				p := panics.Panic{
+					Value:      fmt.Sprint(r),
+					Stack:      stack,
+					Method:     c.Method(),
+					Route:      routeLabel(c),
+					OccurredAt: time.Now(),
+				}
+				if requestID, ok := c.Locals("request_id").(string); ok {
+					p.RequestID = requestID
+				}
+				if tenantID, ok := GetTenantID(c); ok && tenantID != uuid.Nil {
+					p.TenantID = tenantID.String()
+				}
+
+				if config.Tracker != nil {
+					p = config.Tracker.Record(p)
+				} else {
+					p.Fingerprint = panics.Fingerprint(stack)
+				}
+				if config.Metrics != nil {
+					config.Metrics.RecordHTTPPanic(p.Method, p.Route)
+				}
+
 				// Log the panic
-				logger.Error("panic recovered",
-					zap.Any("panic", r),
+				config.Logger.Error("panic recovered",
+					zap.String("panic", p.Value),
+					zap.String("fingerprint", p.Fingerprint),
 					zap.String("path", c.Path()),
-					zap.String("method", c.Method()),
-					zap.String("request_id", c.Get("X-Request-ID", "unknown")),
-					zap.Stack("stack"),
+					zap.String("route", p.Route),
+					zap.String("method", p.Method),
+					zap.String("request_id", p.RequestID),
+					zap.Int("occurrences", p.Count),
+					zap.Bool("budget_exhausted", p.BudgetExhausted),
+					zap.ByteString("stack", stack),
 				)
 
 				// Return error response
-				appErr := errors.NewInternalError("internal server error", nil)
-				c.Status(appErr.HTTPStatus).JSON(fiber.Map{
-					"error":   appErr.Message,
-					"code":    appErr.Code,
-					"details": "An unexpected error occurred",
-				})
+				_ = SendError(c, fiber.StatusInternalServerError, errors.ErrCodeInternal, "An unexpected error occurred")
 			}
 		}()
 
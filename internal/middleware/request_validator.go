@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"encoding/json"
-	"fmt"
+
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/i18n"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -23,12 +25,9 @@ func DefaultValidatorConfig(logger *zap.Logger) ValidatorConfig {
 	}
 }
 
-// ValidationError represents a validation error
-type ValidationError struct {
-	Field   string `json:"field"`
-	Tag     string `json:"tag"`
-	Message string `json:"message"`
-}
+// ValidationError represents a validation error, its code being the validation tag
+// that failed
+type ValidationError = pkgErrors.FieldError
 
 // ValidateRequest validates the request body against a struct
 func ValidateRequest(c *fiber.Ctx, v interface{}, config ValidatorConfig) error {
@@ -39,100 +38,43 @@ func ValidateRequest(c *fiber.Ctx, v interface{}, config ValidatorConfig) error
 			zap.String("path", c.Path()),
 		)
 
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error": fiber.Map{
-				"code":    "INVALID_REQUEST_BODY",
-				"message": "Invalid request body format",
-				"details": err.Error(),
-			},
-		})
+		return SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST_BODY", "Invalid request body format")
 	}
 
 	// Validate struct
 	if err := config.Validator.Struct(v); err != nil {
 		validationErrors, ok := err.(validator.ValidationErrors)
 		if !ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "VALIDATION_ERROR",
-					"message": "Request validation failed",
-					"details": err.Error(),
-				},
-			})
+			return SendError(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed")
 		}
 
 		// Build detailed validation errors
-		var errors []ValidationError
-		for _, fieldErr := range validationErrors {
-			errors = append(errors, ValidationError{
-				Field:   fieldErr.Field(),
-				Tag:     fieldErr.Tag(),
-				Message: getValidationMessage(fieldErr),
-			})
-		}
+		errors := fieldErrors(validationErrors)
 
 		config.Logger.Debug("request validation failed",
 			zap.String("path", c.Path()),
 			zap.Int("error_count", len(errors)),
 		)
 
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
-			"success": false,
-			"error": fiber.Map{
-				"code":    "VALIDATION_ERROR",
-				"message": "Request validation failed",
-				"details": errors,
-			},
-		})
+		return SendValidationError(c, fiber.StatusUnprocessableEntity, "Request validation failed", errors)
 	}
 
 	return nil
 }
 
-// getValidationMessage returns a human-readable validation error message
-func getValidationMessage(fieldErr validator.FieldError) string {
-	field := fieldErr.Field()
-	tag := fieldErr.Tag()
-	param := fieldErr.Param()
-
-	switch tag {
-	case "required":
-		return fmt.Sprintf("%s is required", field)
-	case "email":
-		return fmt.Sprintf("%s must be a valid email address", field)
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters", field, param)
-	case "max":
-		return fmt.Sprintf("%s must be at most %s characters", field, param)
-	case "len":
-		return fmt.Sprintf("%s must be exactly %s characters", field, param)
-	case "gt":
-		return fmt.Sprintf("%s must be greater than %s", field, param)
-	case "gte":
-		return fmt.Sprintf("%s must be greater than or equal to %s", field, param)
-	case "lt":
-		return fmt.Sprintf("%s must be less than %s", field, param)
-	case "lte":
-		return fmt.Sprintf("%s must be less than or equal to %s", field, param)
-	case "uuid":
-		return fmt.Sprintf("%s must be a valid UUID", field)
-	case "url":
-		return fmt.Sprintf("%s must be a valid URL", field)
-	case "oneof":
-		return fmt.Sprintf("%s must be one of: %s", field, param)
-	case "alpha":
-		return fmt.Sprintf("%s must contain only alphabetic characters", field)
-	case "alphanum":
-		return fmt.Sprintf("%s must contain only alphanumeric characters", field)
-	case "numeric":
-		return fmt.Sprintf("%s must be a number", field)
-	case "boolean":
-		return fmt.Sprintf("%s must be a boolean", field)
-	default:
-		return fmt.Sprintf("%s failed validation: %s", field, tag)
+// fieldErrors describes each field that failed validation, in English until the
+// response is translated
+func fieldErrors(validationErrors validator.ValidationErrors) []ValidationError {
+	errors := make([]ValidationError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		errors = append(errors, ValidationError{
+			Field:   fieldErr.Field(),
+			Code:    fieldErr.Tag(),
+			Message: pkgErrors.ValidationMessage(i18n.New(), fieldErr.Field(), fieldErr.Tag(), fieldErr.Param()),
+			Param:   fieldErr.Param(),
+		})
 	}
+	return errors
 }
 
 // ValidateJSON validates JSON against a struct without consuming the request body
@@ -140,13 +82,7 @@ func ValidateJSON(c *fiber.Ctx, v interface{}, config ValidatorConfig) error {
 	// Get body bytes
 	body := c.Body()
 	if len(body) == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error": fiber.Map{
-				"code":    "EMPTY_REQUEST_BODY",
-				"message": "Request body is required",
-			},
-		})
+		return SendError(c, fiber.StatusBadRequest, "EMPTY_REQUEST_BODY", "Request body is required")
 	}
 
 	// Unmarshal JSON
@@ -156,48 +92,20 @@ func ValidateJSON(c *fiber.Ctx, v interface{}, config ValidatorConfig) error {
 			zap.String("path", c.Path()),
 		)
 
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error": fiber.Map{
-				"code":    "INVALID_JSON",
-				"message": "Invalid JSON format",
-				"details": err.Error(),
-			},
-		})
+		return SendError(c, fiber.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
 	}
 
 	// Validate struct
 	if err := config.Validator.Struct(v); err != nil {
 		validationErrors, ok := err.(validator.ValidationErrors)
 		if !ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "VALIDATION_ERROR",
-					"message": "Request validation failed",
-					"details": err.Error(),
-				},
-			})
+			return SendError(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Request validation failed")
 		}
 
 		// Build detailed validation errors
-		var errors []ValidationError
-		for _, fieldErr := range validationErrors {
-			errors = append(errors, ValidationError{
-				Field:   fieldErr.Field(),
-				Tag:     fieldErr.Tag(),
-				Message: getValidationMessage(fieldErr),
-			})
-		}
+		errors := fieldErrors(validationErrors)
 
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
-			"success": false,
-			"error": fiber.Map{
-				"code":    "VALIDATION_ERROR",
-				"message": "Request validation failed",
-				"details": errors,
-			},
-		})
+		return SendValidationError(c, fiber.StatusUnprocessableEntity, "Request validation failed", errors)
 	}
 
 	return nil
@@ -212,48 +120,20 @@ func ValidateQuery(c *fiber.Ctx, v interface{}, config ValidatorConfig) error {
 			zap.String("path", c.Path()),
 		)
 
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"error": fiber.Map{
-				"code":    "INVALID_QUERY_PARAMETERS",
-				"message": "Invalid query parameters",
-				"details": err.Error(),
-			},
-		})
+		return SendError(c, fiber.StatusBadRequest, "INVALID_QUERY_PARAMETERS", "Invalid query parameters")
 	}
 
 	// Validate struct
 	if err := config.Validator.Struct(v); err != nil {
 		validationErrors, ok := err.(validator.ValidationErrors)
 		if !ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "VALIDATION_ERROR",
-					"message": "Query validation failed",
-					"details": err.Error(),
-				},
-			})
+			return SendError(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Query validation failed")
 		}
 
 		// Build detailed validation errors
-		var errors []ValidationError
-		for _, fieldErr := range validationErrors {
-			errors = append(errors, ValidationError{
-				Field:   fieldErr.Field(),
-				Tag:     fieldErr.Tag(),
-				Message: getValidationMessage(fieldErr),
-			})
-		}
+		errors := fieldErrors(validationErrors)
 
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
-			"success": false,
-			"error": fiber.Map{
-				"code":    "VALIDATION_ERROR",
-				"message": "Query validation failed",
-				"details": errors,
-			},
-		})
+		return SendValidationError(c, fiber.StatusUnprocessableEntity, "Query validation failed", errors)
 	}
 
 	return nil
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/httpcache"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ResponseCache serves a read route's successful responses from the shared response
+// cache under tag, keyed by the caller's tenant, path and query, and answers
+// conditional requests whose ETag or Last-Modified still match with 304. It must
+// follow authentication: responses are cached by tenant, and requests without one
+// are served as usual. The services writing the data shown invalidate the tag.
+func ResponseCache(responses *httpcache.Cache, tag string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+			return c.Next()
+		}
+		authCtx, ok := GetAuthContext(c)
+		if !ok || authCtx.TenantID == uuid.Nil {
+			return c.Next()
+		}
+
+		// The query is sorted so the same request in another order shares the response
+		args := fiber.AcquireArgs()
+		defer fiber.ReleaseArgs(args)
+		c.Request().URI().QueryArgs().CopyTo(args)
+		args.Sort(bytes.Compare)
+		request := c.Path() + "?" + args.String()
+
+		if entry := responses.Get(c.UserContext(), tag, authCtx.TenantID, request); entry != nil {
+			c.Set("X-Cache", "HIT")
+			return sendCachedResponse(c, responses, entry)
+		}
+		c.Set("X-Cache", "MISS")
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if c.Response().StatusCode() != fiber.StatusOK || !responses.Cacheable(c.Response().Body()) {
+			return nil
+		}
+
+		entry := &httpcache.Entry{
+			Status:       fiber.StatusOK,
+			ContentType:  string(c.Response().Header.ContentType()),
+			Body:         append([]byte(nil), c.Response().Body()...),
+			LastModified: time.Now().UTC().Truncate(time.Second),
+		}
+		entry.ETag = httpcache.ETag(entry.Body)
+		responses.Put(c.UserContext(), tag, authCtx.TenantID, request, entry)
+		return sendCachedResponse(c, responses, entry)
+	}
+}
+
+// sendCachedResponse answers with a cached response, or 304 when the client's copy is
+// still current
+func sendCachedResponse(c *fiber.Ctx, responses *httpcache.Cache, entry *httpcache.Entry) error {
+	c.Set(fiber.HeaderETag, entry.ETag)
+	c.Set(fiber.HeaderLastModified, entry.LastModified.Format(http.TimeFormat))
+	if maxAge := int(responses.MaxAge().Seconds()); maxAge > 0 {
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", maxAge))
+	} else {
+		c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	}
+
+	if c.Fresh() {
+		c.Response().ResetBody()
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	c.Set(fiber.HeaderContentType, entry.ContentType)
+	return c.Status(entry.Status).Send(entry.Body)
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -91,22 +93,17 @@ func (rf *ResponseFormatter) NoContent() error {
 	return rf.c.SendStatus(fiber.StatusNoContent)
 }
 
-// Error sends an error response
+// Error sends an error response as a problem; details listing the fields that failed
+// validation are reported as its errors, other details are reported under "details"
 func (rf *ResponseFormatter) Error(statusCode int, code string, message string, details any) error {
-	response := StandardResponse{
-		Success: false,
-		Error: &ErrorInfo{
-			Code:    code,
-			Message: message,
-			Details: details,
-		},
-	}
-
-	if rf.config.IncludeRequestID {
-		response.RequestID = rf.c.Get("X-Request-ID")
+	problem := pkgErrors.NewProblem(statusCode, pkgErrors.ErrorCode(code), message)
+	if fields, ok := details.([]ValidationError); ok {
+		problem.Errors = fields
+	} else if details != nil {
+		problem.With("details", details)
 	}
 
-	return rf.c.Status(statusCode).JSON(response)
+	return SendProblem(rf.c, problem)
 }
 
 // BadRequest sends a 400 Bad Request response
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"Krafti_Vibe/internal/domain/authz"
 	"Krafti_Vibe/internal/domain/models"
 	"slices"
 
@@ -33,24 +34,12 @@ func (m *ZitadelAuthMiddleware) RequireAnyPlatformRole() fiber.Handler {
 		// (auth should have already been done by group-level middleware)
 		dbUser := c.Locals("db_user")
 		if dbUser == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "FORBIDDEN",
-					"message": "Platform access required",
-				},
-			})
+			return SendError(c, fiber.StatusForbidden, "FORBIDDEN", "Platform access required")
 		}
 
 		user, ok := dbUser.(*models.User)
 		if !ok || !user.IsPlatformUser {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "FORBIDDEN",
-					"message": "Platform access required",
-				},
-			})
+			return SendError(c, fiber.StatusForbidden, "FORBIDDEN", "Platform access required")
 		}
 
 		return c.Next()
@@ -86,29 +75,58 @@ func RequireArtisanOrTeamMember() fiber.Handler {
 	return requireAnyDatabaseRole(models.UserRoleArtisan, models.UserRoleTeamMember)
 }
 
+// RequireTenantStaff allows any tenant staff: owner, admin, artisan or team member
+func RequireTenantStaff() fiber.Handler {
+	return requireAnyDatabaseRole(
+		models.UserRoleTenantOwner,
+		models.UserRoleTenantAdmin,
+		models.UserRoleArtisan,
+		models.UserRoleTeamMember,
+	)
+}
+
+// serviceAccountScopeKey marks a request made by a service account holding the scope
+// its route lets service accounts in with
+const serviceAccountScopeKey = "service_account_scope"
+
+// AllowServiceAccountScope lets service accounts holding the scope through the role
+// checks that follow it on a route. Only routes whose services authorize the action
+// against the account's scopes may use it; role checks refuse service accounts on
+// every other route, as their scopes are only checked per kind of resource before.
+func AllowServiceAccountScope(scope authz.Action) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if account, ok := GetServiceAccount(c); ok && slices.Contains(account.Scopes, string(scope)) {
+			c.Locals(serviceAccountScopeKey, scope)
+		}
+		return c.Next()
+	}
+}
+
+// requireServiceAccountScope passes a service account's request on when its route let
+// it in with one of its scopes, answering 403 otherwise
+func requireServiceAccountScope(c *fiber.Ctx) error {
+	if _, ok := c.Locals(serviceAccountScopeKey).(authz.Action); ok {
+		return c.Next()
+	}
+	return SendError(c, fiber.StatusForbidden, "SCOPE_NOT_GRANTED", "The service account's scopes do not allow this request")
+}
+
 // Helper function to require a specific database role
 func requireDatabaseRole(role models.UserRole) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		// Service accounts have no role; they only pass where their route lets them
+		if _, ok := GetServiceAccount(c); ok {
+			return requireServiceAccountScope(c)
+		}
+
 		dbUser := c.Locals("db_user")
 		if dbUser == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "FORBIDDEN",
-					"message": "User not synchronized",
-				},
-			})
+			return SendError(c, fiber.StatusForbidden, "FORBIDDEN", "User not synchronized")
 		}
 
 		user, ok := dbUser.(*models.User)
 		if !ok || user == nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "INTERNAL_ERROR",
-					"message": "Invalid user context",
-				},
-			})
+			return SendError(c, fiber.StatusInternalServerError, "INTERNAL_ERROR", "Invalid user context")
 		}
 
 		// Platform users have access to everything
@@ -118,13 +136,7 @@ func requireDatabaseRole(role models.UserRole) fiber.Handler {
 
 		// Check if user has the required role
 		if user.Role != role {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "INSUFFICIENT_PERMISSIONS",
-					"message": "You do not have permission to access this resource",
-				},
-			})
+			return SendError(c, fiber.StatusForbidden, "INSUFFICIENT_PERMISSIONS", "You do not have permission to access this resource")
 		}
 
 		return c.Next()
@@ -134,26 +146,19 @@ func requireDatabaseRole(role models.UserRole) fiber.Handler {
 // Helper function to require any of the specified database roles
 func requireAnyDatabaseRole(roles ...models.UserRole) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		// Service accounts have no role; they only pass where their route lets them
+		if _, ok := GetServiceAccount(c); ok {
+			return requireServiceAccountScope(c)
+		}
+
 		dbUser := c.Locals("db_user")
 		if dbUser == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "FORBIDDEN",
-					"message": "User not synchronized",
-				},
-			})
+			return SendError(c, fiber.StatusForbidden, "FORBIDDEN", "User not synchronized")
 		}
 
 		user, ok := dbUser.(*models.User)
 		if !ok || user == nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "INTERNAL_ERROR",
-					"message": "Invalid user context",
-				},
-			})
+			return SendError(c, fiber.StatusInternalServerError, "INTERNAL_ERROR", "Invalid user context")
 		}
 
 		// Platform users have access to everything
@@ -163,13 +168,7 @@ func requireAnyDatabaseRole(roles ...models.UserRole) fiber.Handler {
 
 		// Check if user has any of the required roles
 		if !slices.Contains(roles, user.Role) {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "INSUFFICIENT_PERMISSIONS",
-					"message": "You do not have permission to access this resource",
-				},
-			})
+			return SendError(c, fiber.StatusForbidden, "INSUFFICIENT_PERMISSIONS", "You do not have permission to access this resource")
 		}
 
 		return c.Next()
@@ -181,24 +180,12 @@ func RequireSelfOrAdmin() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		dbUser := c.Locals("db_user")
 		if dbUser == nil {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "FORBIDDEN",
-					"message": "User not synchronized",
-				},
-			})
+			return SendError(c, fiber.StatusForbidden, "FORBIDDEN", "User not synchronized")
 		}
 
 		user, ok := dbUser.(*models.User)
 		if !ok || user == nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "INTERNAL_ERROR",
-					"message": "Invalid user context",
-				},
-			})
+			return SendError(c, fiber.StatusInternalServerError, "INTERNAL_ERROR", "Invalid user context")
 		}
 
 		// Platform users can access any user's data
@@ -215,13 +202,7 @@ func RequireSelfOrAdmin() fiber.Handler {
 		// Regular users can only access their own data
 		userID := c.Params("id")
 		if userID != user.ID.String() {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "FORBIDDEN",
-					"message": "You can only access your own data",
-				},
-			})
+			return SendError(c, fiber.StatusForbidden, "FORBIDDEN", "You can only access your own data")
 		}
 
 		return c.Next()
@@ -0,0 +1,59 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleChecksOfServiceAccounts(t *testing.T) {
+	account := &models.ServiceAccount{
+		TenantID: uuid.New(),
+		Scopes:   models.StringArray{string(authz.ActionPaymentCreate)},
+		Status:   models.ServiceAccountStatusActive,
+	}
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(middleware.ServiceAccountKey, account)
+		return c.Next()
+	})
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Post("/payments/:id/mark-paid", middleware.RequireTenantOwnerOrAdmin(), ok)
+	app.Post("/payments/:id/capture",
+		middleware.AllowServiceAccountScope(authz.ActionPaymentCreate),
+		middleware.RequireTenantOwnerOrAdmin(),
+		ok,
+	)
+	app.Post("/payments/:id/refund",
+		middleware.AllowServiceAccountScope(authz.ActionPaymentRefund),
+		middleware.RequireTenantOwnerOrAdmin(),
+		ok,
+	)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{name: "route without a scope", path: "/payments/1/mark-paid", status: fiber.StatusForbidden},
+		{name: "route letting the account's scope in", path: "/payments/1/capture", status: fiber.StatusOK},
+		{name: "route letting another scope in", path: "/payments/1/refund", status: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, tt.path, nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
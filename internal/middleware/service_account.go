@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// ServiceAccountKey is the key the service account making a request is stored under
+// in Fiber locals
+const ServiceAccountKey = "service_account"
+
+// ServiceAccountResolver tells which Zitadel users are tenants' service accounts, and
+// counts their requests
+type ServiceAccountResolver interface {
+	// ServiceAccountBySubject returns the service account signing in as the Zitadel
+	// user, or nil when the user is not one
+	ServiceAccountBySubject(ctx context.Context, zitadelUserID string) (*models.ServiceAccount, error)
+
+	// RecordServiceAccountUsage adds the counts of usage to the account's usage
+	RecordServiceAccountUsage(ctx context.Context, usage *models.ServiceAccountUsage, at time.Time) error
+}
+
+// ServiceAccountConfig holds the configuration of the service account guard
+type ServiceAccountConfig struct {
+	// Accounts resolves the service accounts tokens are issued to
+	Accounts ServiceAccountResolver
+
+	// Logger for failures to resolve accounts or record their usage
+	Logger *zap.Logger
+
+	// CacheTTL is how long a subject's account, or that it has none, is remembered, so
+	// scope changes and revocations take up to this long to be enforced
+	CacheTTL time.Duration
+}
+
+// DefaultServiceAccountConfig returns the default service account guard configuration
+func DefaultServiceAccountConfig(accounts ServiceAccountResolver, logger *zap.Logger) ServiceAccountConfig {
+	return ServiceAccountConfig{
+		Accounts: accounts,
+		Logger:   logger,
+		CacheTTL: 30 * time.Second,
+	}
+}
+
+// cachedServiceAccount is a subject's service account as resolved at some point; nil
+// when the subject is not one
+type cachedServiceAccount struct {
+	account *models.ServiceAccount
+	expires time.Time
+}
+
+// ServiceAccountGuard confines requests made by tenants' service accounts to the kinds
+// of resources their scopes reach, such as read-only access to bookings, and counts
+// their requests for the tenant's usage dashboards. The exact actions taken are
+// authorized again by the services, against the account's scopes; role checks refuse
+// the accounts except on routes letting a scope in with AllowServiceAccountScope.
+type ServiceAccountGuard struct {
+	config   ServiceAccountConfig
+	accounts sync.Map // string -> cachedServiceAccount
+}
+
+// NewServiceAccountGuard creates a new service account guard
+func NewServiceAccountGuard(config ServiceAccountConfig) *ServiceAccountGuard {
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = DefaultServiceAccountConfig(nil, nil).CacheTTL
+	}
+
+	return &ServiceAccountGuard{
+		config: config,
+	}
+}
+
+// errServiceAccountRevoked is returned for subjects whose service account was revoked
+var errServiceAccountRevoked = errors.New("service account revoked")
+
+// Resolve returns the service account a token's subject signs in as, or nil when the
+// subject is not one. Revoked accounts are reported with errServiceAccountRevoked;
+// failures to resolve are logged and treated as no account, leaving the subject to be
+// authorized as a user.
+func (g *ServiceAccountGuard) Resolve(ctx context.Context, subject string) (*models.ServiceAccount, error) {
+	now := time.Now()
+	account, cached := g.cached(subject, now)
+	if !cached {
+		var err error
+		account, err = g.config.Accounts.ServiceAccountBySubject(ctx, subject)
+		if err != nil {
+			g.config.Logger.Warn("failed to resolve service account", zap.String("subject", subject), zap.Error(err))
+			return nil, nil
+		}
+		g.accounts.Store(subject, cachedServiceAccount{account: account, expires: now.Add(g.config.CacheTTL)})
+	}
+
+	if account != nil && !account.IsActive() {
+		return nil, errServiceAccountRevoked
+	}
+	return account, nil
+}
+
+// Serve passes a service account's request on when its scopes reach the resource
+// requested, answering 403 otherwise, and records it in the account's usage
+func (g *ServiceAccountGuard) Serve(c *fiber.Ctx, account *models.ServiceAccount) error {
+	resource := requestResource(c.Path())
+	usage := &models.ServiceAccountUsage{
+		TenantID:         account.TenantID,
+		ServiceAccountID: account.ID,
+		Day:              time.Now().UTC().Truncate(24 * time.Hour),
+		Resource:         resource,
+		Requests:         1,
+	}
+
+	if !account.CanAccess(resource, !isReadOnlyMethod(c.Method())) {
+		usage.Denied = 1
+		g.record(c.UserContext(), usage)
+		return SendError(c, fiber.StatusForbidden, "SCOPE_NOT_GRANTED", "The service account's scopes do not allow this request")
+	}
+
+	err := c.Next()
+
+	status := c.Response().StatusCode()
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		status = fiberErr.Code
+	} else if err != nil {
+		status = fiber.StatusInternalServerError
+	}
+	switch {
+	case status == fiber.StatusForbidden:
+		usage.Denied = 1
+	case status >= 500:
+		usage.ServerErrors = 1
+	case status >= 400:
+		usage.ClientErrors = 1
+	}
+	g.record(c.UserContext(), usage)
+
+	return err
+}
+
+// cached returns the subject's account if it was resolved within CacheTTL
+func (g *ServiceAccountGuard) cached(subject string, now time.Time) (*models.ServiceAccount, bool) {
+	value, ok := g.accounts.Load(subject)
+	if !ok || !now.Before(value.(cachedServiceAccount).expires) {
+		return nil, false
+	}
+	return value.(cachedServiceAccount).account, true
+}
+
+// record adds a request to the account's usage, logging failures
+func (g *ServiceAccountGuard) record(ctx context.Context, usage *models.ServiceAccountUsage) {
+	if err := g.config.Accounts.RecordServiceAccountUsage(ctx, usage, time.Now()); err != nil {
+		g.config.Logger.Warn("failed to record service account usage",
+			zap.String("service_account_id", usage.ServiceAccountID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// requestResource returns the kind of resource an API path is for, named as in
+// authorization actions: "/api/v1/audit-logs/..." is for "audit_logs"
+func requestResource(path string) string {
+	path = strings.TrimPrefix(path, "/api/v1/")
+	resource, _, _ := strings.Cut(path, "/")
+	return strings.ReplaceAll(resource, "-", "_")
+}
+
+// GetServiceAccount returns the service account making the request, if it is made by one
+func GetServiceAccount(c *fiber.Ctx) (*models.ServiceAccount, bool) {
+	account, ok := c.Locals(ServiceAccountKey).(*models.ServiceAccount)
+	return account, ok && account != nil
+}
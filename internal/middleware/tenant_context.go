@@ -3,6 +3,8 @@ package middleware
 import (
 	"strings"
 
+	"Krafti_Vibe/internal/pkg/tenancy"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -60,13 +62,7 @@ func TenantContext(config TenantContextConfig) fiber.Handler {
 					zap.Error(err),
 				)
 				if config.Required {
-					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-						"success": false,
-						"error": fiber.Map{
-							"code":    "INVALID_TENANT_ID",
-							"message": "Invalid tenant ID format",
-						},
-					})
+					return SendError(c, fiber.StatusBadRequest, "INVALID_TENANT_ID", "Invalid tenant ID format")
 				}
 			} else {
 				tenantID = parsed
@@ -75,18 +71,13 @@ func TenantContext(config TenantContextConfig) fiber.Handler {
 
 		// If tenant is required but not found
 		if config.Required && tenantID == uuid.Nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "MISSING_TENANT_ID",
-					"message": "Tenant ID is required",
-				},
-			})
+			return SendError(c, fiber.StatusBadRequest, "MISSING_TENANT_ID", "Tenant ID is required")
 		}
 
 		// Store tenant ID in context
 		if tenantID != uuid.Nil {
 			c.Locals("tenant_id", tenantID)
+			c.Locals(tenancy.ContextKey, tenantID)
 
 			// Also check if db_user exists and verify tenant match (for security)
 			if dbUser, ok := GetDatabaseUser(c); ok {
@@ -99,13 +90,7 @@ func TenantContext(config TenantContextConfig) fiber.Handler {
 							zap.String("user_tenant", dbUser.TenantID.String()),
 							zap.String("user_id", dbUser.ID.String()),
 						)
-						return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-							"success": false,
-							"error": fiber.Map{
-								"code":    "TENANT_ACCESS_DENIED",
-								"message": "Access denied to this tenant",
-							},
-						})
+						return SendError(c, fiber.StatusForbidden, "TENANT_ACCESS_DENIED", "Access denied to this tenant")
 					}
 				}
 			}
@@ -138,13 +123,7 @@ func MustGetTenantID(c *fiber.Ctx) uuid.UUID {
 func RequireTenantContext() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		if _, ok := GetTenantID(c); !ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "MISSING_TENANT_CONTEXT",
-					"message": "Tenant context is required for this operation",
-				},
-			})
+			return SendError(c, fiber.StatusBadRequest, "MISSING_TENANT_CONTEXT", "Tenant context is required for this operation")
 		}
 		return c.Next()
 	}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/tenancy"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// hostTenantKey is the local the tenant served at the request's host is stored under
+const hostTenantKey = "host_tenant_id"
+
+// TenantHostResolver resolves the tenant served at a host
+type TenantHostResolver interface {
+	// TenantByHost returns the tenant served at host. tenantHost reports whether the
+	// host is a tenant's at all; tenant hosts no tenant has return uuid.Nil and true.
+	TenantByHost(ctx context.Context, host string) (tenantID uuid.UUID, tenantHost bool, err error)
+}
+
+// ResolveTenantHost serves requests made to a tenant's subdomain, such as
+// acme.kraftivibe.com, or to its verified custom domain for that tenant: the tenant is
+// stored in the request context as TenantContext does, which scopes database access
+// to its rows. Requests to tenant subdomains no tenant has are answered 404.
+//
+// Failures to resolve a host are logged and let the request through without a tenant,
+// as with rate limits.
+func ResolveTenantHost(resolver TenantHostResolver, logger *zap.Logger) fiber.Handler {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return func(c *fiber.Ctx) error {
+		tenantID, tenantHost, err := resolver.TenantByHost(c.UserContext(), c.Hostname())
+		if err != nil {
+			logger.Warn("failed to resolve tenant host; continuing without tenant",
+				zap.String("host", c.Hostname()),
+				zap.Error(err),
+			)
+			return c.Next()
+		}
+
+		if tenantID == uuid.Nil {
+			if tenantHost {
+				return SendError(c, fiber.StatusNotFound, "TENANT_NOT_FOUND", "No organization is served at this address")
+			}
+			return c.Next()
+		}
+
+		c.Locals(hostTenantKey, tenantID)
+		c.Locals("tenant_id", tenantID)
+		c.Locals(tenancy.ContextKey, tenantID)
+		return c.Next()
+	}
+}
+
+// HostTenant returns the tenant served at the request's host, if any
+func HostTenant(c *fiber.Ctx) (uuid.UUID, bool) {
+	tenantID, ok := c.Locals(hostTenantKey).(uuid.UUID)
+	return tenantID, ok && tenantID != uuid.Nil
+}
+
+// checkHostTenant holds an authenticated user to the tenant served at the host they
+// made the request to, which authContext takes when its token carries no tenant.
+// Users of other tenants are refused; platform users may act for any tenant, and
+// users of none, such as customers, may book with it. It returns false once it has
+// answered 403, with the error the caller is to return.
+func checkHostTenant(c *fiber.Ctx, authContext *AuthContext) (bool, error) {
+	hostTenant, ok := HostTenant(c)
+	if !ok {
+		return true, nil
+	}
+
+	userTenant := authContext.TenantID
+	if user, ok := c.Locals("db_user").(*models.User); ok && user != nil {
+		if user.IsPlatformUser {
+			return true, nil
+		}
+		userTenant = uuid.Nil
+		if user.TenantID != nil {
+			userTenant = *user.TenantID
+		}
+	}
+
+	if userTenant != uuid.Nil && userTenant != hostTenant {
+		return false, SendError(c, fiber.StatusForbidden, "TENANT_ACCESS_DENIED", "Access denied to this tenant")
+	}
+
+	if userTenant == hostTenant && authContext.TenantID == uuid.Nil {
+		authContext.TenantID = hostTenant
+	}
+	return true, nil
+}
@@ -0,0 +1,313 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/metrics"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SlidingWindowStore counts requests in sliding windows shared by every API instance
+type SlidingWindowStore interface {
+	AllowRequest(ctx context.Context, key string, limit int, window time.Duration) (cache.RateLimitResult, error)
+}
+
+// RateLimitPlanResolver returns the subscription plan whose rate limit applies to a tenant
+type RateLimitPlanResolver interface {
+	RateLimitPlan(ctx context.Context, tenantID uuid.UUID) (models.SubscriptionPlan, error)
+}
+
+// TenantRateLimitConfig holds the configuration of the sliding window rate limiter
+type TenantRateLimitConfig struct {
+	// Store counts requests; typically the Redis client
+	Store SlidingWindowStore
+
+	// Plans resolves the subscription plan of authenticated tenants
+	Plans RateLimitPlanResolver
+
+	// Metrics counts throttled requests when set
+	Metrics *metrics.PrometheusMetrics
+
+	// Logger for rate limit events
+	Logger *zap.Logger
+
+	// PlanLimits is how many requests each subscription plan allows a tenant per
+	// Window. Plans not listed get the free plan's limit.
+	PlanLimits map[models.SubscriptionPlan]int
+	Window     time.Duration
+
+	// ClientLimit is how many requests each IP address may make per ClientWindow,
+	// whether authenticated or not
+	ClientLimit  int
+	ClientWindow time.Duration
+
+	// KeyLimit is how many requests each service account, or OAuth client of another
+	// machine token, may make per KeyWindow once authenticated. It is counted apart
+	// from the limit of the tenant the credential belongs to.
+	KeyLimit  int
+	KeyWindow time.Duration
+
+	// PlanCacheTTL is how long a tenant's plan is remembered, so plan changes take
+	// up to this long to change its limit
+	PlanCacheTTL time.Duration
+}
+
+// DefaultPlanRateLimits returns the requests per minute each subscription plan allows
+func DefaultPlanRateLimits() map[models.SubscriptionPlan]int {
+	return map[models.SubscriptionPlan]int{
+		models.PlanFree:       600,
+		models.PlanStarter:    1200,
+		models.PlanPro:        3000,
+		models.PlanBusiness:   6000,
+		models.PlanEnterprise: 15000,
+	}
+}
+
+// DefaultTenantRateLimitConfig returns the default sliding window rate limit configuration
+func DefaultTenantRateLimitConfig(store SlidingWindowStore, plans RateLimitPlanResolver, logger *zap.Logger) TenantRateLimitConfig {
+	return TenantRateLimitConfig{
+		Store:        store,
+		Plans:        plans,
+		Logger:       logger,
+		PlanLimits:   DefaultPlanRateLimits(),
+		Window:       1 * time.Minute,
+		ClientLimit:  100,
+		ClientWindow: 1 * time.Second,
+		KeyLimit:     1200,
+		KeyWindow:    1 * time.Minute,
+		PlanCacheTTL: 1 * time.Minute,
+	}
+}
+
+// rateLimitTarget is a caller a limit is counted against
+type rateLimitTarget struct {
+	scope string // tenant, api_key or ip
+	id    string
+	tier  string
+	limit int
+	// window is how long requests count against the limit
+	window time.Duration
+}
+
+// cachedPlan is a tenant's plan as resolved at some point
+type cachedPlan struct {
+	plan    models.SubscriptionPlan
+	expires time.Time
+}
+
+// TenantRateLimiter limits requests with sliding windows kept in Redis, so the limits
+// hold across every API instance. Each IP address is held to a client limit, and once
+// authenticated each API key, that is service account or machine client, to a key
+// limit and each tenant to the limit of its subscription plan.
+//
+// Redis or plan lookup failures are logged and let the request through, so an outage
+// of either never blocks tenants from working.
+type TenantRateLimiter struct {
+	config TenantRateLimitConfig
+	limits atomic.Pointer[rateLimits]
+	plans  sync.Map // uuid.UUID -> cachedPlan
+}
+
+// rateLimits are the limits a TenantRateLimiter enforces, which can change while it runs
+type rateLimits struct {
+	client int
+	plans  map[models.SubscriptionPlan]int
+}
+
+// NewTenantRateLimiter creates a new sliding window rate limiter
+func NewTenantRateLimiter(config TenantRateLimitConfig) *TenantRateLimiter {
+	defaults := DefaultTenantRateLimitConfig(nil, nil, nil)
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.PlanLimits == nil {
+		config.PlanLimits = defaults.PlanLimits
+	}
+	if config.Window <= 0 {
+		config.Window = defaults.Window
+	}
+	if config.ClientLimit <= 0 {
+		config.ClientLimit = defaults.ClientLimit
+	}
+	if config.ClientWindow <= 0 {
+		config.ClientWindow = defaults.ClientWindow
+	}
+	if config.KeyLimit <= 0 {
+		config.KeyLimit = defaults.KeyLimit
+	}
+	if config.KeyWindow <= 0 {
+		config.KeyWindow = defaults.KeyWindow
+	}
+	if config.PlanCacheTTL <= 0 {
+		config.PlanCacheTTL = defaults.PlanCacheTTL
+	}
+
+	limiter := &TenantRateLimiter{
+		config: config,
+	}
+	limiter.limits.Store(&rateLimits{client: config.ClientLimit, plans: config.PlanLimits})
+	return limiter
+}
+
+// SetLimits changes the client limit and the limits of subscription plans, such as when
+// the configuration is reloaded. They apply from the next request; a limit left zero or
+// nil is kept.
+func (l *TenantRateLimiter) SetLimits(clientLimit int, planLimits map[models.SubscriptionPlan]int) {
+	current := l.limits.Load()
+	if clientLimit <= 0 {
+		clientLimit = current.client
+	}
+	if planLimits == nil {
+		planLimits = current.plans
+	}
+	l.limits.Store(&rateLimits{client: clientLimit, plans: planLimits})
+}
+
+// Handler returns middleware holding every request to the client limit of its IP
+// address. It goes before authentication, so floods are refused before tokens are
+// introspected; nothing a client sends unauthenticated, such as an API key header,
+// picks its bucket, or sending a new one on each request would escape the limit.
+func (l *TenantRateLimiter) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		target := rateLimitTarget{
+			scope:  "ip",
+			id:     c.IP(),
+			tier:   "client",
+			limit:  l.limits.Load().client,
+			window: l.config.ClientWindow,
+		}
+
+		if ok, err := l.allow(c, target); !ok {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// Limit holds an authenticated request to the key limit of the service account or
+// machine client making it, then to the limit of its tenant's subscription plan. It
+// returns false once it has answered 429, with the error the caller is to return;
+// requests without a tenant, such as platform staff, have no plan limit.
+func (l *TenantRateLimiter) Limit(c *fiber.Ctx) (bool, error) {
+	authCtx, ok := GetAuthContext(c)
+	if !ok {
+		return true, nil
+	}
+
+	if key, ok := apiKeyOf(c, authCtx); ok {
+		allowed, err := l.allow(c, rateLimitTarget{
+			scope:  "api_key",
+			id:     key,
+			tier:   "key",
+			limit:  l.config.KeyLimit,
+			window: l.config.KeyWindow,
+		})
+		if !allowed {
+			return false, err
+		}
+	}
+
+	if authCtx.TenantID == uuid.Nil {
+		return true, nil
+	}
+
+	plan, err := l.tenantPlan(c.UserContext(), authCtx.TenantID)
+	if err != nil {
+		l.config.Logger.Warn("failed to resolve tenant plan for rate limiting; allowing request",
+			zap.String("tenant_id", authCtx.TenantID.String()),
+			zap.Error(err),
+		)
+		return true, nil
+	}
+
+	planLimits := l.limits.Load().plans
+	limit, ok := planLimits[plan]
+	if !ok {
+		limit = planLimits[models.PlanFree]
+	}
+	if limit <= 0 {
+		return true, nil
+	}
+
+	return l.allow(c, rateLimitTarget{
+		scope:  "tenant",
+		id:     authCtx.TenantID.String(),
+		tier:   string(plan),
+		limit:  limit,
+		window: l.config.Window,
+	})
+}
+
+// allow counts the request against target, setting the rate limit headers, and
+// answers 429 with Retry-After when target has no room left
+func (l *TenantRateLimiter) allow(c *fiber.Ctx, target rateLimitTarget) (bool, error) {
+	result, err := l.config.Store.AllowRequest(c.UserContext(), target.scope+":"+target.id, target.limit, target.window)
+	if err != nil {
+		l.config.Logger.Warn("rate limit check failed; allowing request",
+			zap.String("scope", target.scope),
+			zap.Error(err),
+		)
+		return true, nil
+	}
+
+	resetSeconds := int64((result.ResetAfter + time.Second - 1) / time.Second)
+	c.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix()+resetSeconds, 10))
+	c.Set("X-RateLimit-Window", target.window.String())
+	if result.Allowed {
+		return true, nil
+	}
+
+	if l.config.Metrics != nil {
+		l.config.Metrics.RecordHTTPRateLimited(target.scope, target.tier)
+	}
+	l.config.Logger.Debug("rate limit exceeded",
+		zap.String("scope", target.scope),
+		zap.String("id", target.id),
+		zap.String("tier", target.tier),
+		zap.String("path", c.Path()),
+	)
+
+	retryAfter := max(resetSeconds, 1)
+	c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+	return false, SendProblem(c, pkgErrors.NewProblem(fiber.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED",
+		"Too many requests. Please try again later.").With("retry_after", retryAfter))
+}
+
+// apiKeyOf returns the credential an authenticated machine request was made with: its
+// service account, or the OAuth client of another machine token. Users have none.
+func apiKeyOf(c *fiber.Ctx, authCtx *AuthContext) (string, bool) {
+	if account, ok := GetServiceAccount(c); ok {
+		return "service_account:" + account.ID.String(), true
+	}
+	if authCtx.IsM2M && authCtx.IntrospectCtx != nil && authCtx.IntrospectCtx.ClientID != "" {
+		return "client:" + authCtx.IntrospectCtx.ClientID, true
+	}
+	return "", false
+}
+
+// tenantPlan returns the tenant's plan, resolving it at most once per PlanCacheTTL
+func (l *TenantRateLimiter) tenantPlan(ctx context.Context, tenantID uuid.UUID) (models.SubscriptionPlan, error) {
+	now := time.Now()
+	if cached, ok := l.plans.Load(tenantID); ok && now.Before(cached.(cachedPlan).expires) {
+		return cached.(cachedPlan).plan, nil
+	}
+
+	plan, err := l.config.Plans.RateLimitPlan(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	l.plans.Store(tenantID, cachedPlan{plan: plan, expires: now.Add(l.config.PlanCacheTTL)})
+	return plan, nil
+}
@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
+	"Krafti_Vibe/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingStore allows each key its limit of requests, never freeing room
+type countingStore struct {
+	counts map[string]int
+}
+
+func (s *countingStore) AllowRequest(ctx context.Context, key string, limit int, window time.Duration) (cache.RateLimitResult, error) {
+	s.counts[key]++
+	remaining := max(limit-s.counts[key], 0)
+	return cache.RateLimitResult{Allowed: s.counts[key] <= limit, Limit: limit, Remaining: remaining, ResetAfter: window}, nil
+}
+
+// freePlans puts every tenant on the free plan
+type freePlans struct{}
+
+func (freePlans) RateLimitPlan(ctx context.Context, tenantID uuid.UUID) (models.SubscriptionPlan, error) {
+	return models.PlanFree, nil
+}
+
+func TestTenantRateLimiter_KeyLimit(t *testing.T) {
+	tenantID := uuid.New()
+	first := &models.ServiceAccount{TenantID: tenantID}
+	first.ID = uuid.New()
+	second := &models.ServiceAccount{TenantID: tenantID}
+	second.ID = uuid.New()
+	accounts := map[string]*models.ServiceAccount{"first": first, "second": second}
+
+	store := &countingStore{counts: map[string]int{}}
+	limiter := middleware.NewTenantRateLimiter(middleware.TenantRateLimitConfig{
+		Store:      store,
+		Plans:      freePlans{},
+		PlanLimits: map[models.SubscriptionPlan]int{models.PlanFree: 10},
+		KeyLimit:   2,
+	})
+
+	app := fiber.New()
+	app.Get("/:account", func(c *fiber.Ctx) error {
+		c.Locals(middleware.AuthContextKey, &middleware.AuthContext{TenantID: tenantID})
+		if account, ok := accounts[c.Params("account")]; ok {
+			c.Locals(middleware.ServiceAccountKey, account)
+		}
+		if ok, err := limiter.Limit(c); !ok {
+			return err
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	get := func(path string) int {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, get("/first"))
+	assert.Equal(t, fiber.StatusOK, get("/first"))
+	assert.Equal(t, fiber.StatusTooManyRequests, get("/first"), "the first account has used its key limit")
+	assert.Equal(t, fiber.StatusOK, get("/second"), "each account has its own key limit")
+	assert.Equal(t, fiber.StatusOK, get("/user"), "users have no key limit")
+
+	assert.Equal(t, 4, store.counts["tenant:"+tenantID.String()], "key limits are counted apart from the tenant's")
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TenantStatusResolver returns the status a tenant's access is decided by
+type TenantStatusResolver interface {
+	TenantStatus(ctx context.Context, tenantID uuid.UUID) (models.TenantStatus, error)
+}
+
+// TenantStatusConfig holds the configuration of the tenant status guard
+type TenantStatusConfig struct {
+	// Statuses resolves the status of authenticated tenants
+	Statuses TenantStatusResolver
+
+	// Logger for failures to resolve a status
+	Logger *zap.Logger
+
+	// CacheTTL is how long a tenant's status is remembered, so status changes take up
+	// to this long to be enforced
+	CacheTTL time.Duration
+
+	// ReadOnlyExemptPaths are path prefixes where suspended and cancelled tenants may
+	// still make changes, so they can settle their subscription and export their data
+	ReadOnlyExemptPaths []string
+}
+
+// DefaultTenantStatusConfig returns the default tenant status guard configuration
+func DefaultTenantStatusConfig(statuses TenantStatusResolver, logger *zap.Logger) TenantStatusConfig {
+	return TenantStatusConfig{
+		Statuses: statuses,
+		Logger:   logger,
+		CacheTTL: 30 * time.Second,
+		ReadOnlyExemptPaths: []string{
+			"/api/v1/subscriptions",
+			"/api/v1/data-exports",
+		},
+	}
+}
+
+// cachedStatus is a tenant's status as resolved at some point
+type cachedStatus struct {
+	status  models.TenantStatus
+	expires time.Time
+}
+
+// TenantStatusGuard holds authenticated requests to what their tenant's lifecycle
+// status allows: suspended tenants, including trials past their end, and cancelled
+// ones being offboarded are read-only, and closed tenants are refused altogether.
+// Platform users are not held to it.
+//
+// Failures to resolve a status are logged and let the request through, as with rate
+// limits.
+type TenantStatusGuard struct {
+	config   TenantStatusConfig
+	statuses sync.Map // uuid.UUID -> cachedStatus
+}
+
+// NewTenantStatusGuard creates a new tenant status guard
+func NewTenantStatusGuard(config TenantStatusConfig) *TenantStatusGuard {
+	defaults := DefaultTenantStatusConfig(nil, nil)
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = defaults.CacheTTL
+	}
+	if config.ReadOnlyExemptPaths == nil {
+		config.ReadOnlyExemptPaths = defaults.ReadOnlyExemptPaths
+	}
+
+	return &TenantStatusGuard{
+		config: config,
+	}
+}
+
+// Check holds an authenticated request to its tenant's status. It returns false once
+// it has answered 403, with the error the caller is to return.
+func (g *TenantStatusGuard) Check(c *fiber.Ctx) (bool, error) {
+	tenantID := RequestTenant(c)
+	if tenantID == uuid.Nil {
+		return true, nil
+	}
+
+	status, err := g.tenantStatus(c.UserContext(), tenantID)
+	if err != nil {
+		g.config.Logger.Warn("failed to resolve tenant status; allowing request",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err),
+		)
+		return true, nil
+	}
+
+	switch status {
+	case models.TenantStatusClosed:
+		return false, tenantStatusError(c, "TENANT_CLOSED", "This organization has been closed")
+	case models.TenantStatusSuspended, models.TenantStatusCancelled:
+		if isReadOnlyMethod(c.Method()) || g.isExempt(c.Path()) {
+			return true, nil
+		}
+		if status == models.TenantStatusCancelled {
+			return false, tenantStatusError(c, "TENANT_CANCELLED", "This organization is cancelled and can no longer be changed")
+		}
+		return false, tenantStatusError(c, "TENANT_SUSPENDED", "This organization is suspended and read-only until it is reactivated")
+	}
+	return true, nil
+}
+
+// tenantStatus returns the tenant's status, resolving it at most once per CacheTTL
+func (g *TenantStatusGuard) tenantStatus(ctx context.Context, tenantID uuid.UUID) (models.TenantStatus, error) {
+	now := time.Now()
+	if cached, ok := g.statuses.Load(tenantID); ok && now.Before(cached.(cachedStatus).expires) {
+		return cached.(cachedStatus).status, nil
+	}
+
+	status, err := g.config.Statuses.TenantStatus(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	g.statuses.Store(tenantID, cachedStatus{status: status, expires: now.Add(g.config.CacheTTL)})
+	return status, nil
+}
+
+func (g *TenantStatusGuard) isExempt(path string) bool {
+	for _, prefix := range g.config.ReadOnlyExemptPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestTenant returns the tenant an authenticated request acts for: its user's
+// tenant, else its token's organization. It is nil for platform users.
+func RequestTenant(c *fiber.Ctx) uuid.UUID {
+	if user, ok := c.Locals("db_user").(*models.User); ok && user != nil {
+		if user.IsPlatformUser {
+			return uuid.Nil
+		}
+		if user.TenantID != nil {
+			return *user.TenantID
+		}
+	}
+	if authCtx, ok := GetAuthContext(c); ok {
+		return authCtx.TenantID
+	}
+	return uuid.Nil
+}
+
+// isReadOnlyMethod reports whether a method only reads
+func isReadOnlyMethod(method string) bool {
+	return method == fiber.MethodGet || method == fiber.MethodHead || method == fiber.MethodOptions
+}
+
+func tenantStatusError(c *fiber.Ctx, code, message string) error {
+	return SendError(c, fiber.StatusForbidden, pkgErrors.ErrorCode(code), message)
+}
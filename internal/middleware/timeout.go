@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -26,6 +28,7 @@ func DefaultTimeoutConfig(logger *zap.Logger) TimeoutConfig {
 		SkipPaths: []string{
 			"/api/v1/ws",
 			"/api/v1/websocket",
+			"/api/v1/stream",
 			"/health/live",
 			"/health/ready",
 			"/debug/pprof",
@@ -73,14 +76,8 @@ func Timeout(config TimeoutConfig) fiber.Handler {
 				zap.String("request_id", c.Get("X-Request-ID")),
 			)
 
-			return c.Status(fiber.StatusRequestTimeout).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "REQUEST_TIMEOUT",
-					"message": "Request processing timeout exceeded",
-					"timeout": config.Timeout.String(),
-				},
-			})
+			return SendProblem(c, pkgErrors.NewProblem(fiber.StatusRequestTimeout, pkgErrors.ErrCodeRequestTimeout,
+				"Request processing timeout exceeded").With("timeout", config.Timeout.String()))
 		}
 	}
 }
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"Krafti_Vibe/internal/pkg/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDHeader is the response header carrying the ID of the request's trace, for
+// clients to quote when reporting problems
+const TraceIDHeader = "X-Trace-ID"
+
+// TracingConfig holds configuration for the tracing middleware
+type TracingConfig struct {
+	// SkipPaths contains paths that are not traced, such as probes scraped every few
+	// seconds
+	SkipPaths []string
+}
+
+// DefaultTracingConfig returns default tracing configuration
+func DefaultTracingConfig() TracingConfig {
+	return TracingConfig{
+		SkipPaths: []string{
+			"/health",
+			"/metrics",
+			"/debug/pprof",
+		},
+	}
+}
+
+// Tracing returns a middleware that records a span for each request, continuing the
+// caller's trace when the request carries W3C trace context. The span is set as the
+// request's user context and stored in locals under tracing.SpanKey, so the queries,
+// Redis commands and webhooks it leads to are recorded within it.
+func Tracing(config TracingConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		for _, skipPath := range config.SkipPaths {
+			if strings.HasPrefix(path, skipPath) {
+				return c.Next()
+			}
+		}
+
+		carrier := propagation.HeaderCarrier{}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			carrier.Set(string(key), string(value))
+		})
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), carrier)
+
+		ctx, span := tracing.Tracer().Start(ctx, c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(path),
+				semconv.URLScheme(c.Protocol()),
+				semconv.ServerAddress(c.Hostname()),
+				semconv.ClientAddress(c.IP()),
+				semconv.UserAgentOriginal(c.Get(fiber.HeaderUserAgent)),
+			),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+		c.Locals(tracing.SpanKey, span)
+		if span.SpanContext().IsValid() {
+			c.Set(TraceIDHeader, span.SpanContext().TraceID().String())
+		}
+		if requestID, ok := c.Locals("request_id").(string); ok {
+			span.SetAttributes(semconv.HTTPRequestHeader("x-request-id", requestID))
+		}
+
+		err := c.Next()
+
+		status := responseStatus(c, err)
+
+		// Spans are named after the route matched, which is only known once routed
+		route := routeLabel(c)
+		span.SetName(fmt.Sprintf("%s %s", c.Method(), route))
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+		if err != nil {
+			span.RecordError(err)
+		}
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+
+		return err
+	}
+}
@@ -3,12 +3,19 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"time"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
+	"Krafti_Vibe/internal/pkg/tenancy"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/zitadel/zitadel-go/v3/pkg/authorization"
 	"github.com/zitadel/zitadel-go/v3/pkg/authorization/oauth"
 	zitadelhttp "github.com/zitadel/zitadel-go/v3/pkg/http/middleware"
+	"go.uber.org/zap"
 )
 
 // AuthContextKey is the key used to store authentication context in Fiber locals
@@ -35,10 +42,23 @@ type UserSyncer interface {
 	GetOrSyncUser(ctx context.Context, zitadelUserID string, authCtx *oauth.IntrospectionContext) (interface{}, error)
 }
 
+// TokenRevocationList tells which access tokens have been revoked before they expire
+type TokenRevocationList interface {
+	// RevokedBefore returns the latest time before which tokens of any of the subjects
+	// were revoked, or the zero time when none were
+	RevokedBefore(ctx context.Context, subjects ...string) (time.Time, error)
+}
+
 // ZitadelAuthMiddleware wraps the official Zitadel HTTP middleware for Fiber
 type ZitadelAuthMiddleware struct {
-	mw         *zitadelhttp.Interceptor[*oauth.IntrospectionContext]
-	userSyncer UserSyncer
+	mw          *zitadelhttp.Interceptor[*oauth.IntrospectionContext]
+	userSyncer  UserSyncer
+	limiter     *TenantRateLimiter
+	revocations TokenRevocationList
+	statusGuard *TenantStatusGuard
+	mfaGuard    *MFAPolicyGuard
+	accounts    *ServiceAccountGuard
+	logger      *zap.Logger
 }
 
 // NewZitadelAuthMiddleware creates a new Zitadel authentication middleware using the official package
@@ -52,6 +72,40 @@ func NewZitadelAuthMiddleware(
 	}
 }
 
+// LimitTenants holds authenticated requests to their tenant's rate limit
+func (m *ZitadelAuthMiddleware) LimitTenants(limiter *TenantRateLimiter) {
+	m.limiter = limiter
+}
+
+// CheckRevocations refuses tokens revoked before they expire, such as those of users
+// deactivated, changing role or logged out by an admin, and of closed tenants.
+// Failures to check are logged and let the request through, as with rate limits.
+func (m *ZitadelAuthMiddleware) CheckRevocations(revocations TokenRevocationList, logger *zap.Logger) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	m.revocations = revocations
+	m.logger = logger
+}
+
+// GuardTenantStatus holds authenticated requests to what their tenant's lifecycle
+// status allows, such as read-only access while suspended
+func (m *ZitadelAuthMiddleware) GuardTenantStatus(guard *TenantStatusGuard) {
+	m.statusGuard = guard
+}
+
+// EnforceMFA holds authenticated requests to their tenant's multi-factor
+// authentication policy
+func (m *ZitadelAuthMiddleware) EnforceMFA(guard *MFAPolicyGuard) {
+	m.mfaGuard = guard
+}
+
+// ConfineServiceAccounts recognizes the tokens of tenants' service accounts, which act
+// for their tenant without a user, and confines them to their scopes
+func (m *ZitadelAuthMiddleware) ConfineServiceAccounts(guard *ServiceAccountGuard) {
+	m.accounts = guard
+}
+
 // RequireAuth creates a Fiber handler that requires authentication using official Zitadel middleware
 func (m *ZitadelAuthMiddleware) RequireAuth(opts ...authorization.CheckOption) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -70,13 +124,7 @@ func (m *ZitadelAuthMiddleware) RequireAuth(opts ...authorization.CheckOption) f
 			nil,
 		)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "INTERNAL_ERROR",
-					"message": "Failed to process request",
-				},
-			})
+			return SendError(c, fiber.StatusInternalServerError, "INTERNAL_ERROR", "Failed to process request")
 		}
 
 		// Copy headers from Fiber to http.Request
@@ -104,13 +152,7 @@ func (m *ZitadelAuthMiddleware) RequireAuth(opts ...authorization.CheckOption) f
 		default:
 			// Auth failed
 			if authFailed || rw.statusCode == http.StatusUnauthorized || rw.statusCode == http.StatusForbidden {
-				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-					"success": false,
-					"error": fiber.Map{
-						"code":    "INVALID_TOKEN",
-						"message": "Invalid or expired token",
-					},
-				})
+				return SendError(c, fiber.StatusUnauthorized, "INVALID_TOKEN", "Invalid or expired token")
 			}
 		}
 
@@ -121,25 +163,13 @@ func (m *ZitadelAuthMiddleware) RequireAuth(opts ...authorization.CheckOption) f
 		}
 
 		if authCtx == nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "INVALID_TOKEN",
-					"message": "Invalid or expired token",
-				},
-			})
+			return SendError(c, fiber.StatusUnauthorized, "INVALID_TOKEN", "Invalid or expired token")
 		}
 
 		// Extract user information
 		userIDStr := authCtx.UserID()
 		if userIDStr == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"error": fiber.Map{
-					"code":    "INVALID_TOKEN",
-					"message": "Invalid token: no user ID",
-				},
-			})
+			return SendError(c, fiber.StatusUnauthorized, "INVALID_TOKEN", "Invalid token: no user ID")
 		}
 
 		// Parse user ID as UUID
@@ -155,9 +185,21 @@ func (m *ZitadelAuthMiddleware) RequireAuth(opts ...authorization.CheckOption) f
 			tenantID = uuid.Nil
 		}
 
+		// Service accounts act for the tenant they belong to, and are not synced as users
+		var account *models.ServiceAccount
+		if m.accounts != nil {
+			account, err = m.accounts.Resolve(c.UserContext(), userIDStr)
+			if err != nil {
+				return SendError(c, fiber.StatusUnauthorized, "TOKEN_REVOKED", "Service account has been revoked")
+			}
+			if account != nil {
+				tenantID = account.TenantID
+			}
+		}
+
 		// Sync user from Zitadel to database (if syncer is configured)
 		var dbUser interface{}
-		if m.userSyncer != nil {
+		if m.userSyncer != nil && account == nil {
 			var err error
 			dbUser, err = m.userSyncer.GetOrSyncUser(c.Context(), userIDStr, authCtx)
 			if err != nil {
@@ -192,15 +234,91 @@ func (m *ZitadelAuthMiddleware) RequireAuth(opts ...authorization.CheckOption) f
 		// Store auth context in Fiber locals
 		c.Locals(AuthContextKey, authContext)
 
-		// Store database user if available
+		// Scope the request's database access to the tenant's rows
+		if tenantID != uuid.Nil {
+			c.Locals(tenancy.ContextKey, tenantID)
+		}
+
+		// Store database user if available, and the actor services authorize against
 		if dbUser != nil {
 			c.Locals("db_user", dbUser)
+			if user, ok := dbUser.(*models.User); ok && user != nil {
+				c.Locals(authz.ContextKey, authz.NewActor(user))
+			}
+		}
+
+		if account != nil {
+			c.Locals(ServiceAccountKey, account)
+			c.Locals(authz.ContextKey, authz.NewServiceAccountActor(account))
 		}
 
+		// Hold users to the tenant whose subdomain or custom domain they came in on
+		if ok, err := checkHostTenant(c, authContext); !ok {
+			return err
+		}
+
+		if m.revocations != nil && m.isRevoked(c, authCtx, tenantID, dbUser) {
+			return SendError(c, fiber.StatusUnauthorized, "TOKEN_REVOKED", "Session has been revoked, please sign in again")
+		}
+
+		if m.statusGuard != nil {
+			if ok, err := m.statusGuard.Check(c); !ok {
+				return err
+			}
+		}
+
+		if m.mfaGuard != nil {
+			if ok, err := m.mfaGuard.Check(c); !ok {
+				return err
+			}
+		}
+
+		if m.limiter != nil {
+			if ok, err := m.limiter.Limit(c); !ok {
+				return err
+			}
+		}
+
+		if account != nil {
+			return m.accounts.Serve(c, account)
+		}
 		return c.Next()
 	}
 }
 
+// isRevoked checks whether the token was revoked, for its user or its tenant
+func (m *ZitadelAuthMiddleware) isRevoked(c *fiber.Ctx, authCtx *oauth.IntrospectionContext, tenantID uuid.UUID, dbUser interface{}) bool {
+	var subjects []string
+	if tenantID != uuid.Nil {
+		subjects = append(subjects, cache.TenantTokens(tenantID))
+	}
+	if user, ok := dbUser.(*models.User); ok && user != nil {
+		subjects = append(subjects, cache.UserTokens(user.ID))
+		if user.TenantID != nil && *user.TenantID != tenantID {
+			subjects = append(subjects, cache.TenantTokens(*user.TenantID))
+		}
+	}
+	if len(subjects) == 0 {
+		return false
+	}
+
+	revokedBefore, err := m.revocations.RevokedBefore(c.UserContext(), subjects...)
+	if err != nil {
+		m.logger.Warn("failed to check token revocations; allowing request", zap.Error(err))
+		return false
+	}
+	// Tokens without an issue time are taken as issued before any revocation
+	return issuedBeforeRevocation(authCtx.IssuedAt.AsTime(), revokedBefore)
+}
+
+// issuedBeforeRevocation checks whether a token issued at issuedAt falls under a
+// revocation cutoff. Issue times are whole seconds, so the cutoff is compared in seconds
+// too; a token issued later in the second of the revocation, such as one refreshed
+// straight after a role change, would otherwise be refused for its whole lifetime.
+func issuedBeforeRevocation(issuedAt, cutoff time.Time) bool {
+	return !cutoff.IsZero() && issuedAt.Before(cutoff.Truncate(time.Second))
+}
+
 // RequireRole creates a middleware that requires specific roles
 func (m *ZitadelAuthMiddleware) RequireRole(roles ...string) fiber.Handler {
 	var opts []authorization.CheckOption
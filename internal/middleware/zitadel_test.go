@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuedBeforeRevocation(t *testing.T) {
+	revokedAt := time.Date(2026, 3, 14, 9, 30, 12, 700*int(time.Millisecond), time.UTC)
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		cutoff   time.Time
+		revoked  bool
+	}{
+		{name: "issued a second before the revocation", issuedAt: revokedAt.Add(-time.Second).Truncate(time.Second), cutoff: revokedAt, revoked: true},
+		{name: "issued in the same second as the revocation", issuedAt: revokedAt.Truncate(time.Second), cutoff: revokedAt, revoked: false},
+		{name: "issued after the revocation", issuedAt: revokedAt.Add(time.Second).Truncate(time.Second), cutoff: revokedAt, revoked: false},
+		{name: "never revoked", issuedAt: revokedAt.Truncate(time.Second), cutoff: time.Time{}, revoked: false},
+		{name: "denied until released", issuedAt: revokedAt.Add(time.Hour).Truncate(time.Second), cutoff: time.Unix(1<<62, 0), revoked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.revoked, issuedBeforeRevocation(tt.issuedAt, tt.cutoff))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package audit carries the API request a change is made in through its context, and
+// works out what the change altered, for the audit trail
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// Request describes the API request changes are made in
+type Request struct {
+	ID        string
+	Method    string
+	Path      string
+	IPAddress string
+	UserAgent string
+}
+
+type contextKey struct{}
+
+// ContextKey is the key the request is stored under. Fiber locals set with it are
+// visible through c.Context(), which handlers pass to services.
+var ContextKey any = contextKey{}
+
+// WithRequest returns a context recording changes as made in the request
+func WithRequest(ctx context.Context, request *Request) context.Context {
+	return context.WithValue(ctx, ContextKey, request)
+}
+
+// FromContext returns the request changes in the context are made in, if any
+func FromContext(ctx context.Context) (*Request, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	request, ok := ctx.Value(ContextKey).(*Request)
+	return request, ok && request != nil
+}
+
+// Diff returns the fields whose values differ between before and after, with their old
+// and new values. Values are compared in their JSON form, so a value read back from the
+// database equals the one written. Ignored fields, such as timestamps maintained on
+// every write, are left out. Both maps are nil when nothing changed.
+func Diff(before, after map[string]any, ignored ...string) (map[string]any, map[string]any) {
+	skip := make(map[string]bool, len(ignored))
+	for _, field := range ignored {
+		skip[field] = true
+	}
+
+	var oldValues, newValues map[string]any
+	record := func(field string, oldValue, newValue any) {
+		if oldValues == nil {
+			oldValues, newValues = make(map[string]any), make(map[string]any)
+		}
+		oldValues[field] = oldValue
+		newValues[field] = newValue
+	}
+
+	for field, newValue := range after {
+		if skip[field] {
+			continue
+		}
+		oldValue := before[field]
+		if !equal(oldValue, newValue) {
+			record(field, oldValue, newValue)
+		}
+	}
+	for field, oldValue := range before {
+		if _, ok := after[field]; !ok && !skip[field] && !equal(oldValue, nil) {
+			record(field, oldValue, nil)
+		}
+	}
+	return oldValues, newValues
+}
+
+// IsEmpty reports whether a value holds nothing: null, an empty string, list or object
+func IsEmpty(value any) bool {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	switch string(data) {
+	case "null", `""`, "[]", "{}":
+		return true
+	}
+	return false
+}
+
+// equal reports whether two values have the same JSON form
+func equal(a, b any) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	if aErr != nil || bErr != nil {
+		return false
+	}
+	return bytes.Equal(aJSON, bJSON)
+}
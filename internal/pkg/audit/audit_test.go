@@ -0,0 +1,63 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/audit"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	_, ok := audit.FromContext(context.Background())
+	assert.False(t, ok)
+
+	request := &audit.Request{ID: "req-1", IPAddress: "203.0.113.7"}
+	got, ok := audit.FromContext(audit.WithRequest(context.Background(), request))
+	assert.True(t, ok)
+	assert.Equal(t, request, got)
+}
+
+func TestDiff(t *testing.T) {
+	customerID := uuid.New()
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	before := map[string]any{
+		"status":      "pending",
+		"customer_id": customerID,
+		"start_time":  start,
+		"notes":       "ring twice",
+		"updated_at":  start,
+	}
+	after := map[string]any{
+		"status":      "confirmed",
+		"customer_id": customerID,
+		"start_time":  start.Format(time.RFC3339Nano),
+		"updated_at":  start.Add(time.Hour),
+	}
+
+	oldValues, newValues := audit.Diff(before, after, "updated_at")
+	assert.Equal(t, map[string]any{"status": "pending", "notes": "ring twice"}, oldValues)
+	assert.Equal(t, map[string]any{"status": "confirmed", "notes": nil}, newValues)
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	values := map[string]any{"status": "pending", "amount": 12.5}
+
+	oldValues, newValues := audit.Diff(values, map[string]any{"status": "pending", "amount": 12.5})
+	assert.Nil(t, oldValues)
+	assert.Nil(t, newValues)
+}
+
+func TestIsEmpty(t *testing.T) {
+	var noID *uuid.UUID
+	for _, value := range []any{nil, "", noID, []string{}, map[string]any{}} {
+		assert.True(t, audit.IsEmpty(value), "%#v", value)
+	}
+	for _, value := range []any{0, false, "x", uuid.New(), []string{"a"}} {
+		assert.False(t, audit.IsEmpty(value), "%#v", value)
+	}
+}
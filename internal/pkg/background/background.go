@@ -0,0 +1,119 @@
+// Package background runs work that outlives the request starting it, such as large
+// imports and notification delivery, under the application's lifecycle, so shutdown
+// waits for it rather than dropping it mid-way.
+package background
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// ContextKey is the key the pool is stored under. Fiber locals set with it are visible
+// through c.Context(), which handlers pass to services.
+var ContextKey any = contextKey{}
+
+// Pool runs background work with the context of the subsystem running it.
+//
+// Run it as a lifecycle subsystem and register Drain as its stop hook: work then gets
+// until the shutdown deadline to finish, and is cancelled after that.
+type Pool struct {
+	logger *zap.Logger
+
+	mu       sync.Mutex
+	ctx      context.Context // Set while the pool is running
+	draining bool
+	wg       sync.WaitGroup
+}
+
+// New creates a new Pool
+func New(logger *zap.Logger) *Pool {
+	return &Pool{logger: logger}
+}
+
+// Run accepts work until ctx is cancelled, handing ctx to the work it runs, then waits
+// for that work to return
+func (p *Pool) Run(ctx context.Context) error {
+	p.mu.Lock()
+	p.ctx = ctx
+	p.mu.Unlock()
+
+	<-ctx.Done()
+
+	p.mu.Lock()
+	p.draining = true
+	p.mu.Unlock()
+	p.wg.Wait()
+	return nil
+}
+
+// Drain stops accepting work and waits for the work running to return, or for ctx to
+// be done
+func (p *Pool) Drain(ctx context.Context) error {
+	p.mu.Lock()
+	p.draining = true
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Go runs work in its own goroutine with the pool's context. It returns false, without
+// running work, when the pool isn't running or is draining.
+func (p *Pool) Go(name string, work func(ctx context.Context)) bool {
+	p.mu.Lock()
+	if p.ctx == nil || p.draining {
+		p.mu.Unlock()
+		return false
+	}
+	ctx := p.ctx
+	p.wg.Add(1)
+	p.mu.Unlock()
+
+	go func() {
+		defer p.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				p.logger.Error("background task panicked", zap.String("task", name), zap.Any("panic", r))
+			}
+		}()
+		work(ctx)
+	}()
+	return true
+}
+
+// WithPool returns a context whose background work runs in the pool
+func WithPool(ctx context.Context, pool *Pool) context.Context {
+	return context.WithValue(ctx, ContextKey, pool)
+}
+
+// FromContext returns the pool background work started with the context runs in, if any
+func FromContext(ctx context.Context) (*Pool, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	pool, ok := ctx.Value(ContextKey).(*Pool)
+	return pool, ok && pool != nil
+}
+
+// Go runs work in the pool of ctx. Without a running pool, as in scheduled jobs, work
+// runs before Go returns, with ctx.
+func Go(ctx context.Context, name string, work func(ctx context.Context)) {
+	if pool, ok := FromContext(ctx); ok && pool.Go(name, work) {
+		return
+	}
+	work(ctx)
+}
@@ -0,0 +1,109 @@
+package background_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/background"
+	"Krafti_Vibe/internal/pkg/lifecycle"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// startPool runs a pool under a lifecycle manager the way the router does
+func startPool(t *testing.T) (*lifecycle.Manager, *background.Pool) {
+	t.Helper()
+	m := lifecycle.New(zap.NewNop())
+	pool := background.New(zap.NewNop())
+	m.Go("background_tasks", pool.Run)
+	m.OnStop("background_tasks", pool.Drain)
+
+	require.Eventually(t, func() bool {
+		return pool.Go("probe", func(ctx context.Context) {})
+	}, time.Second, time.Millisecond)
+	return m, pool
+}
+
+func TestShutdownWaitsForRunningWork(t *testing.T) {
+	m, pool := startPool(t)
+
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	require.True(t, pool.Go("import", func(ctx context.Context) {
+		<-release
+		close(finished)
+	}))
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, m.Shutdown(ctx))
+
+	select {
+	case <-finished:
+	default:
+		t.Fatal("shutdown returned before the work finished")
+	}
+	assert.False(t, pool.Go("late", func(ctx context.Context) {}), "work is refused once draining")
+}
+
+func TestShutdownCancelsWorkPastTheDeadline(t *testing.T) {
+	m, pool := startPool(t)
+
+	cancelled := make(chan struct{})
+	require.True(t, pool.Go("export", func(ctx context.Context) {
+		<-ctx.Done()
+		close(cancelled)
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_ = m.Shutdown(ctx)
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("work was not cancelled at shutdown")
+	}
+}
+
+func TestGoRunsInlineWithoutPool(t *testing.T) {
+	ran := false
+	background.Go(context.Background(), "notification_delivery", func(ctx context.Context) {
+		ran = true
+	})
+	assert.True(t, ran)
+}
+
+func TestGoUsesPoolOfContext(t *testing.T) {
+	m, pool := startPool(t)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = m.Shutdown(ctx)
+	}()
+
+	type requestKey struct{}
+	requestCtx, cancelRequest := context.WithCancel(context.WithValue(background.WithPool(context.Background(), pool), requestKey{}, "request"))
+
+	done := make(chan context.Context, 1)
+	background.Go(requestCtx, "notification_delivery", func(ctx context.Context) {
+		done <- ctx
+	})
+	cancelRequest()
+
+	select {
+	case ctx := <-done:
+		assert.NoError(t, ctx.Err(), "work outlives the request")
+		assert.Nil(t, ctx.Value(requestKey{}))
+	case <-time.After(time.Second):
+		t.Fatal("work did not run")
+	}
+}
@@ -0,0 +1,218 @@
+// Package chaos injects faults into requests outside production: added latency, error
+// responses and failures of the dependencies a request uses, such as the database,
+// Redis or payment providers, so retries, circuit breakers and fallbacks can be
+// exercised before a release. Faults are described by rules matching routes, each
+// applying to a percentage of the requests it matches.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// Dependencies whose failure can be injected
+const (
+	DependencyDatabase = "database"
+	DependencyRedis    = "redis"
+	DependencyPayments = "payments"
+)
+
+// Dependencies lists the dependencies whose failure can be injected
+var Dependencies = []string{DependencyDatabase, DependencyRedis, DependencyPayments}
+
+// maxLatency bounds the latency a rule may add
+const maxLatency = time.Minute
+
+// ErrInjected is returned by dependencies whose failure was injected
+var ErrInjected = errors.New("chaos: injected dependency failure")
+
+type contextKey struct{}
+
+// ContextKey is the key the dependencies a request drops are stored under. Fiber
+// locals set with it are visible through c.Context(), which handlers pass to services.
+var ContextKey any = contextKey{}
+
+// WithDropped returns a context in which the given dependencies fail
+func WithDropped(ctx context.Context, dependencies ...string) context.Context {
+	return context.WithValue(ctx, ContextKey, dependencies)
+}
+
+// Check returns ErrInjected when the context drops the dependency
+func Check(ctx context.Context, dependency string) error {
+	if ctx == nil {
+		return nil
+	}
+	if dropped, ok := ctx.Value(ContextKey).([]string); ok && slices.Contains(dropped, dependency) {
+		return fmt.Errorf("%w: %s", ErrInjected, dependency)
+	}
+	return nil
+}
+
+// Rule injects faults into a percentage of the requests matching a route
+type Rule struct {
+	ID string `json:"id"`
+
+	// Method limits the rule to requests with the method; empty matches any
+	Method string `json:"method,omitempty"`
+
+	// Route is the path prefix of the requests the rule matches, such as /api/v1/bookings
+	Route string `json:"route"`
+
+	// Percentage is the share of matching requests faults are injected into, above 0
+	// and up to 100
+	Percentage float64 `json:"percentage"`
+
+	// LatencyMs is added before the request is handled, in milliseconds
+	LatencyMs int64 `json:"latency_ms,omitempty"`
+
+	// ErrorStatus answers the request with the status, 4xx or 5xx, instead of handling it
+	ErrorStatus int `json:"error_status,omitempty"`
+
+	// Drop lists the dependencies that fail while the request is handled
+	Drop []string `json:"drop,omitempty"`
+
+	// ExpiresAt removes the rule once passed, so a forgotten experiment ends on its own
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// matches reports whether the rule matches a request
+func (r Rule) matches(method, path string, now time.Time) bool {
+	if r.ExpiresAt != nil && !now.Before(*r.ExpiresAt) {
+		return false
+	}
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+	return path == r.Route || strings.HasPrefix(path, strings.TrimSuffix(r.Route, "/")+"/")
+}
+
+// validate checks the rule describes a fault it can inject
+func (r Rule) validate(now time.Time) error {
+	switch {
+	case !strings.HasPrefix(r.Route, "/"):
+		return pkgErrors.NewValidationError("route must be a path starting with /")
+	case r.Percentage <= 0 || r.Percentage > 100:
+		return pkgErrors.NewValidationError("percentage must be above 0 and at most 100")
+	case r.LatencyMs < 0 || r.LatencyMs > maxLatency.Milliseconds():
+		return pkgErrors.NewValidationError(fmt.Sprintf("latency_ms must be between 0 and %d", maxLatency.Milliseconds()))
+	case r.ErrorStatus != 0 && (r.ErrorStatus < 400 || r.ErrorStatus > 599):
+		return pkgErrors.NewValidationError("error_status must be a 4xx or 5xx status")
+	case r.LatencyMs == 0 && r.ErrorStatus == 0 && len(r.Drop) == 0:
+		return pkgErrors.NewValidationError("a rule must inject latency, an error status or dropped dependencies")
+	case r.ExpiresAt != nil && !r.ExpiresAt.After(now):
+		return pkgErrors.NewValidationError("expires_at must be in the future")
+	}
+	for _, dependency := range r.Drop {
+		if !slices.Contains(Dependencies, dependency) {
+			return pkgErrors.NewValidationError(fmt.Sprintf("unknown dependency %q; expected one of %s", dependency, strings.Join(Dependencies, ", ")))
+		}
+	}
+	return nil
+}
+
+// Injection is the faults injected into a request
+type Injection struct {
+	Rules       []string // IDs of the rules injecting faults
+	Latency     time.Duration
+	ErrorStatus int
+	Drop        []string
+}
+
+// Empty reports whether no fault is injected
+func (i Injection) Empty() bool {
+	return len(i.Rules) == 0
+}
+
+// Injector holds the rules faults are injected by. It is safe for concurrent use.
+type Injector struct {
+	mu    sync.RWMutex
+	rules []Rule
+
+	// roll returns a number in [0, 100) deciding whether a rule applies
+	roll func() float64
+}
+
+// NewInjector creates an injector without rules
+func NewInjector() *Injector {
+	return &Injector{roll: func() float64 { return rand.Float64() * 100 }}
+}
+
+// Add adds a rule, returning it with its ID
+func (i *Injector) Add(rule Rule) (Rule, error) {
+	now := time.Now()
+	rule.Method = strings.ToUpper(rule.Method)
+	if err := rule.validate(now); err != nil {
+		return Rule{}, err
+	}
+	rule.ID = uuid.NewString()
+	rule.CreatedAt = now
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = append(i.rules, rule)
+	return rule, nil
+}
+
+// Remove removes a rule, reporting whether it existed
+func (i *Injector) Remove(id string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	n := len(i.rules)
+	i.rules = slices.DeleteFunc(i.rules, func(rule Rule) bool { return rule.ID == id })
+	return len(i.rules) < n
+}
+
+// Clear removes every rule
+func (i *Injector) Clear() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = nil
+}
+
+// Rules returns the rules that have not expired, oldest first
+func (i *Injector) Rules() []Rule {
+	now := time.Now()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = slices.DeleteFunc(i.rules, func(rule Rule) bool {
+		return rule.ExpiresAt != nil && !now.Before(*rule.ExpiresAt)
+	})
+	return slices.Clone(i.rules)
+}
+
+// Inject returns the faults to inject into a request. Each matching rule applies by its
+// percentage; the latencies of those applying add up, and the first error status wins.
+func (i *Injector) Inject(method, path string) Injection {
+	now := time.Now()
+	var injection Injection
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	for _, rule := range i.rules {
+		if !rule.matches(method, path, now) || i.roll() >= rule.Percentage {
+			continue
+		}
+		injection.Rules = append(injection.Rules, rule.ID)
+		injection.Latency += time.Duration(rule.LatencyMs) * time.Millisecond
+		if injection.ErrorStatus == 0 {
+			injection.ErrorStatus = rule.ErrorStatus
+		}
+		for _, dependency := range rule.Drop {
+			if !slices.Contains(injection.Drop, dependency) {
+				injection.Drop = append(injection.Drop, dependency)
+			}
+		}
+	}
+	return injection
+}
@@ -0,0 +1,76 @@
+package chaos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/chaos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectorInjectsMatchingRules(t *testing.T) {
+	injector := chaos.NewInjector()
+	slow, err := injector.Add(chaos.Rule{Route: "/api/v1/bookings", Percentage: 100, LatencyMs: 200})
+	require.NoError(t, err)
+	failing, err := injector.Add(chaos.Rule{Method: "post", Route: "/api/v1/bookings", Percentage: 100, ErrorStatus: 503, Drop: []string{chaos.DependencyRedis}})
+	require.NoError(t, err)
+	assert.Equal(t, "POST", failing.Method)
+
+	injection := injector.Inject("POST", "/api/v1/bookings")
+	assert.Equal(t, []string{slow.ID, failing.ID}, injection.Rules)
+	assert.Equal(t, 200*time.Millisecond, injection.Latency)
+	assert.Equal(t, 503, injection.ErrorStatus)
+	assert.Equal(t, []string{chaos.DependencyRedis}, injection.Drop)
+
+	injection = injector.Inject("GET", "/api/v1/bookings/123")
+	assert.Equal(t, []string{slow.ID}, injection.Rules, "rules match the paths below their route")
+
+	assert.True(t, injector.Inject("GET", "/api/v1/bookingsx").Empty())
+	assert.True(t, injector.Inject("GET", "/api/v1/services").Empty())
+
+	assert.True(t, injector.Remove(slow.ID))
+	assert.False(t, injector.Remove(slow.ID))
+	assert.True(t, injector.Inject("GET", "/api/v1/bookings").Empty())
+
+	injector.Clear()
+	assert.Empty(t, injector.Rules())
+}
+
+func TestInjectorDropsExpiredRules(t *testing.T) {
+	injector := chaos.NewInjector()
+	expiresAt := time.Now().Add(50 * time.Millisecond)
+	_, err := injector.Add(chaos.Rule{Route: "/", Percentage: 100, ErrorStatus: 500, ExpiresAt: &expiresAt})
+	require.NoError(t, err)
+	assert.False(t, injector.Inject("GET", "/api/v1/services").Empty())
+
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, injector.Inject("GET", "/api/v1/services").Empty())
+	assert.Empty(t, injector.Rules())
+}
+
+func TestInjectorRejectsInvalidRules(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	for name, rule := range map[string]chaos.Rule{
+		"relative route":     {Route: "api", Percentage: 50, ErrorStatus: 500},
+		"no percentage":      {Route: "/", ErrorStatus: 500},
+		"percentage over":    {Route: "/", Percentage: 150, ErrorStatus: 500},
+		"no fault":           {Route: "/", Percentage: 50},
+		"success status":     {Route: "/", Percentage: 50, ErrorStatus: 200},
+		"excessive latency":  {Route: "/", Percentage: 50, LatencyMs: int64(2 * time.Minute / time.Millisecond)},
+		"unknown dependency": {Route: "/", Percentage: 50, Drop: []string{"smtp"}},
+		"already expired":    {Route: "/", Percentage: 50, ErrorStatus: 500, ExpiresAt: &past},
+	} {
+		_, err := chaos.NewInjector().Add(rule)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := chaos.WithDropped(context.Background(), chaos.DependencyDatabase)
+	assert.ErrorIs(t, chaos.Check(ctx, chaos.DependencyDatabase), chaos.ErrInjected)
+	assert.NoError(t, chaos.Check(ctx, chaos.DependencyRedis))
+	assert.NoError(t, chaos.Check(context.Background(), chaos.DependencyDatabase))
+}
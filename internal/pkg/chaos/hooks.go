@@ -0,0 +1,93 @@
+package chaos
+
+import (
+	"context"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// GormPlugin fails the statements of requests that drop the database
+type GormPlugin struct{}
+
+// NewGormPlugin creates the GORM fault injection plugin, registered with db.Use
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+// Name returns the plugin's name
+func (p *GormPlugin) Name() string {
+	return "chaos"
+}
+
+// Initialize registers the plugin's callback before each kind of statement
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	processors := []struct {
+		operation string
+		before    interface {
+			Register(name string, fn func(*gorm.DB)) error
+		}
+	}{
+		{"create", callbacks.Create().Before("gorm:create")},
+		{"query", callbacks.Query().Before("gorm:query")},
+		{"update", callbacks.Update().Before("gorm:update")},
+		{"delete", callbacks.Delete().Before("gorm:delete")},
+		{"row", callbacks.Row().Before("gorm:row")},
+		{"raw", callbacks.Raw().Before("gorm:raw")},
+	}
+
+	for _, processor := range processors {
+		if err := processor.before.Register("chaos:before_"+processor.operation, failStatement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// failStatement fails a statement before it runs when its request drops the database
+func failStatement(db *gorm.DB) {
+	if err := Check(db.Statement.Context, DependencyDatabase); err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// RedisHook fails the commands of requests that drop Redis
+type RedisHook struct{}
+
+// NewRedisHook creates the Redis fault injection hook, added with client.AddHook
+func NewRedisHook() *RedisHook {
+	return &RedisHook{}
+}
+
+// DialHook leaves dialing alone
+func (h *RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook fails a command
+func (h *RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := Check(ctx, DependencyRedis); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		return next(ctx, cmd)
+	}
+}
+
+// ProcessPipelineHook fails a pipeline
+func (h *RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := Check(ctx, DependencyRedis); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+		return next(ctx, cmds)
+	}
+}
@@ -19,6 +19,13 @@ const (
 	ErrCodeConflict        ErrorCode = "CONFLICT"
 	ErrCodeTooManyRequests ErrorCode = "TOO_MANY_REQUESTS"
 
+	// Protocol errors
+	ErrCodeMethodNotAllowed     ErrorCode = "METHOD_NOT_ALLOWED"
+	ErrCodeRequestTimeout       ErrorCode = "REQUEST_TIMEOUT"
+	ErrCodePayloadTooLarge      ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrCodeUnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	ErrCodeServiceUnavailable   ErrorCode = "SERVICE_UNAVAILABLE"
+
 	// Repository errors
 	ErrCodeDuplicate     ErrorCode = "DUPLICATE"
 	ErrCodeDatabaseError ErrorCode = "DATABASE_ERROR"
@@ -27,6 +34,10 @@ const (
 	// Business logic errors
 	ErrCodeValidation ErrorCode = "VALIDATION_ERROR"
 	ErrCodeBusiness   ErrorCode = "BUSINESS_ERROR"
+
+	// Billing errors
+	ErrCodePlanLimitReached     ErrorCode = "PLAN_LIMIT_REACHED"
+	ErrCodeSubscriptionInactive ErrorCode = "SUBSCRIPTION_INACTIVE"
 )
 
 // Standard errors
@@ -43,11 +54,17 @@ var (
 
 // AppError represents an application error with structured information
 type AppError struct {
-	Code       ErrorCode `json:"code"`
-	Message    string    `json:"message"`
-	Details    string    `json:"details,omitempty"`
-	HTTPStatus int       `json:"-"`
-	Err        error     `json:"-"`
+	Code       ErrorCode    `json:"code"`
+	Message    string       `json:"message"`
+	Details    string       `json:"details,omitempty"`
+	Fields     []FieldError `json:"fields,omitempty"`
+	HTTPStatus int          `json:"-"`
+	Err        error        `json:"-"`
+
+	// Key names the catalog message the error's message is the English text of, so it
+	// can be reported in the client's language; Args are its placeholders' values
+	Key  string         `json:"-"`
+	Args map[string]any `json:"-"`
 }
 
 // Error implements the error interface
@@ -120,8 +137,50 @@ func NewRepositoryError(code, message string, err error) *RepositoryError {
 	}
 }
 
+// FieldConflict describes a submitted field whose stored value differs from the submitted one
+type FieldConflict struct {
+	Field     string `json:"field"`
+	Current   any    `json:"current"`
+	Requested any    `json:"requested"`
+}
+
+// VersionConflictError reports an update rejected by optimistic locking
+type VersionConflictError struct {
+	Resource        string          `json:"resource"`
+	ExpectedVersion int             `json:"expected_version"`
+	CurrentVersion  int             `json:"current_version"`
+	Fields          []FieldConflict `json:"conflicting_fields"`
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s version conflict: expected %d, current %d", e.Resource, e.ExpectedVersion, e.CurrentVersion)
+}
+
+// PlanLimitError describes a request refused by the tenant's subscription plan
+type PlanLimitError struct {
+	Plan    string `json:"plan"`
+	Status  string `json:"status"`
+	Limit   string `json:"limit,omitempty"` // Empty when the subscription itself is not in good standing
+	Used    int64  `json:"used"`
+	Allowed int    `json:"allowed"`
+}
+
+func (e *PlanLimitError) Error() string {
+	if e.Limit == "" {
+		return fmt.Sprintf("%s subscription is %s", e.Plan, e.Status)
+	}
+	return fmt.Sprintf("%s plan limit reached: %s %d/%d", e.Plan, e.Limit, e.Used, e.Allowed)
+}
+
 // Helper functions to create common errors
 
+// WithKey names the catalog message the error's message is the English text of
+func (e *AppError) WithKey(key string, args map[string]any) *AppError {
+	e.Key = key
+	e.Args = args
+	return e
+}
+
 // NewNotFoundError creates a not found error
 func NewNotFoundError(resource string) *AppError {
 	return NewAppError(ErrCodeNotFound, fmt.Sprintf("%s not found", resource), http.StatusNotFound)
@@ -135,7 +194,7 @@ func NewInvalidInputError(message string) *AppError {
 // NewUnauthorizedError creates an unauthorized error
 func NewUnauthorizedError(message string) *AppError {
 	if message == "" {
-		message = "unauthorized access"
+		return NewAppError(ErrCodeUnauthorized, "unauthorized access", http.StatusUnauthorized).WithKey("errors.unauthorized", nil)
 	}
 	return NewAppError(ErrCodeUnauthorized, message, http.StatusUnauthorized)
 }
@@ -143,7 +202,7 @@ func NewUnauthorizedError(message string) *AppError {
 // NewForbiddenError creates a forbidden error
 func NewForbiddenError(message string) *AppError {
 	if message == "" {
-		message = "forbidden access"
+		return NewAppError(ErrCodeForbidden, "forbidden access", http.StatusForbidden).WithKey("errors.forbidden", nil)
 	}
 	return NewAppError(ErrCodeForbidden, message, http.StatusForbidden)
 }
@@ -153,10 +212,35 @@ func NewConflictError(message string) *AppError {
 	return NewAppError(ErrCodeConflict, message, http.StatusConflict)
 }
 
+// NewVersionConflictError creates a conflict error carrying the details clients need to merge
+func NewVersionConflictError(resource string, expectedVersion, currentVersion int, fields []FieldConflict) *AppError {
+	return &AppError{
+		Code:       ErrCodeConflict,
+		Message:    fmt.Sprintf("%s was modified by another user", resource),
+		HTTPStatus: http.StatusConflict,
+		Err: &VersionConflictError{
+			Resource:        resource,
+			ExpectedVersion: expectedVersion,
+			CurrentVersion:  currentVersion,
+			Fields:          fields,
+		},
+	}
+}
+
+// NewPaymentRequiredError creates a 402 error for a request the tenant's plan does not cover
+func NewPaymentRequiredError(code ErrorCode, message string, detail *PlanLimitError) *AppError {
+	return &AppError{
+		Code:       code,
+		Message:    message,
+		HTTPStatus: http.StatusPaymentRequired,
+		Err:        detail,
+	}
+}
+
 // NewInternalError creates an internal server error
 func NewInternalError(message string, err error) *AppError {
 	if message == "" {
-		message = "internal server error"
+		return NewAppErrorWithErr(ErrCodeInternal, "internal server error", http.StatusInternalServerError, err).WithKey("errors.internal", nil)
 	}
 	return NewAppErrorWithErr(ErrCodeInternal, message, http.StatusInternalServerError, err)
 }
@@ -166,6 +250,16 @@ func NewValidationError(message string) *AppError {
 	return NewAppError(ErrCodeValidation, message, http.StatusBadRequest)
 }
 
+// NewFieldValidationError creates a validation error listing each field that failed
+func NewFieldValidationError(message string, fields ...FieldError) *AppError {
+	return &AppError{
+		Code:       ErrCodeValidation,
+		Message:    message,
+		Fields:     fields,
+		HTTPStatus: http.StatusBadRequest,
+	}
+}
+
 // NewServiceError creates a service error
 func NewServiceError(code, message string, err error) *AppError {
 	return NewAppErrorWithErr(ErrorCode(code), message, http.StatusInternalServerError, err)
@@ -174,7 +268,7 @@ func NewServiceError(code, message string, err error) *AppError {
 // NewTooManyRequestsError creates a rate limit error
 func NewTooManyRequestsError(message string) *AppError {
 	if message == "" {
-		message = "too many requests"
+		return NewAppError(ErrCodeTooManyRequests, "too many requests", http.StatusTooManyRequests).WithKey("errors.too_many_requests", nil)
 	}
 	return NewAppError(ErrCodeTooManyRequests, message, http.StatusTooManyRequests)
 }
@@ -220,6 +314,40 @@ func IsDuplicate(err error) bool {
 	return false
 }
 
+// IsConflict checks if error is a conflict error, including optimistic locking failures
+func IsConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrConflict) {
+		return true
+	}
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code == ErrCodeConflict
+	}
+	var repoErr *RepositoryError
+	if errors.As(err, &repoErr) {
+		return repoErr.Code == "CONFLICT"
+	}
+	return false
+}
+
+// IsForbidden checks if error is a forbidden error
+func IsForbidden(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrForbidden) {
+		return true
+	}
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code == ErrCodeForbidden
+	}
+	return false
+}
+
 // IsValidationError checks if error is a validation error
 func IsValidationError(err error) bool {
 	if err == nil {
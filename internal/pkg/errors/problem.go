@@ -0,0 +1,292 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"Krafti_Vibe/internal/pkg/i18n"
+
+	"gorm.io/gorm"
+)
+
+// ProblemContentType is the media type of error responses, as defined by RFC 7807
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBase prefixes the type URI of every problem, which ends with its code in
+// kebab case, e.g. https://api.kraftivibe.com/problems/not-found
+const ProblemTypeBase = "https://api.kraftivibe.com/problems/"
+
+// FieldError describes a request field that failed validation
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"` // The rule that failed, e.g. required or max
+	Message string `json:"message"`
+	Param   string `json:"-"` // The rule's parameter, e.g. the length of max
+}
+
+// Problem is an RFC 7807 problem detail, the body of every API error response. Code is
+// the stable, machine-readable identifier clients should branch on; Title and Detail
+// are for humans and may change.
+type Problem struct {
+	Type      string                `json:"type"`
+	Title     string                `json:"title"`
+	Status    int                   `json:"status"`
+	Detail    string                `json:"detail,omitempty"`
+	Instance  string                `json:"instance,omitempty"`
+	Code      ErrorCode             `json:"code"`
+	RequestID string                `json:"request_id,omitempty"`
+	TraceID   string                `json:"trace_id,omitempty"`
+	Errors    []FieldError          `json:"errors,omitempty"`
+	Conflict  *VersionConflictError `json:"conflict,omitempty"`
+	Plan      *PlanLimitError       `json:"plan,omitempty"`
+
+	// Extensions holds further members specific to the problem's type, such as how long
+	// to wait before retrying
+	Extensions map[string]any `json:"-"`
+
+	// detailKey names the catalog message the detail is the English text of
+	detailKey  string
+	detailArgs map[string]any
+}
+
+// MarshalJSON writes the problem's extension members alongside its standard ones
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	data, err := json.Marshal((*problem)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]json.RawMessage, len(p.Extensions))
+	for name, value := range p.Extensions {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		members[name] = raw
+	}
+	// Standard members take precedence over extensions of the same name
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	return json.Marshal(members)
+}
+
+// With sets an extension member of the problem
+func (p *Problem) With(name string, value any) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any)
+	}
+	p.Extensions[name] = value
+	return p
+}
+
+// WithDetailKey names the catalog message the problem's detail is the English text of,
+// so Localize can translate it
+func (p *Problem) WithDetailKey(key string, args map[string]any) *Problem {
+	p.detailKey = key
+	p.detailArgs = args
+	return p
+}
+
+// Localize translates the problem for the localizer's locales: its title, its detail
+// where it names a catalog message, and the messages of its field errors that are the
+// catalog's English ones. Messages the catalogs don't have are left in English, as
+// is everything when the localizer prefers English.
+func (p *Problem) Localize(localizer i18n.Localizer) {
+	if localizer.Locale() == i18n.DefaultLocale {
+		return
+	}
+
+	if title, ok := localizer.Lookup("status."+strconv.Itoa(p.Status), nil); ok {
+		p.Title = title
+	}
+	if p.detailKey != "" {
+		if detail, ok := localizer.Lookup(p.detailKey, p.detailArgs); ok {
+			p.Detail = detail
+		}
+	}
+
+	// The errors may be shared with the AppError the problem was made from
+	p.Errors = slices.Clone(p.Errors)
+	english := i18n.New()
+	for i, field := range p.Errors {
+		if field.Code != "" && field.Message == ValidationMessage(english, field.Field, field.Code, field.Param) {
+			p.Errors[i].Message = ValidationMessage(localizer, field.Field, field.Code, field.Param)
+		}
+	}
+}
+
+// ValidationMessage returns the message of a field that failed a validation rule, such
+// as required or max, with the rule's parameter
+func ValidationMessage(localizer i18n.Localizer, field, rule, param string) string {
+	args := i18n.Args{"field": field, "param": param, "tag": rule}
+	if message, ok := localizer.Lookup("validation."+rule, args); ok {
+		return message
+	}
+	return localizer.T("validation.default", args)
+}
+
+// NewProblem creates a problem of the given status and code
+func NewProblem(status int, code ErrorCode, detail string) *Problem {
+	if code == "" {
+		code = CodeForStatus(status)
+	}
+	return &Problem{
+		Type:   ProblemType(code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// ProblemType returns the type URI of problems with the given code
+func ProblemType(code ErrorCode) string {
+	return ProblemTypeBase + strings.ToLower(strings.ReplaceAll(string(code), "_", "-"))
+}
+
+// CodeForStatus returns the code of errors that carry nothing but an HTTP status, such
+// as those raised by Fiber itself
+func CodeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrCodeInvalidInput
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeForbidden
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrCodeMethodNotAllowed
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return ErrCodeRequestTimeout
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return ErrCodePayloadTooLarge
+	case http.StatusUnsupportedMediaType:
+		return ErrCodeUnsupportedMediaType
+	case http.StatusUnprocessableEntity:
+		return ErrCodeValidation
+	case http.StatusTooManyRequests:
+		return ErrCodeTooManyRequests
+	case http.StatusServiceUnavailable:
+		return ErrCodeServiceUnavailable
+	}
+	if status >= 500 {
+		return ErrCodeInternal
+	}
+	return ErrCodeInvalidInput
+}
+
+// ToProblem maps an error returned by a service or repository to the problem reported
+// to the client. The detail is the message the error was created with, never the error
+// it wraps, so internal details don't leak; server errors are correlated with the logs
+// by request and trace ID instead.
+func ToProblem(err error) *Problem {
+	var conflictErr *VersionConflictError
+	if errors.As(err, &conflictErr) {
+		p := NewProblem(http.StatusConflict, ErrCodeConflict, conflictErr.Resource+" was modified by another user")
+		p.Conflict = conflictErr
+		return p
+	}
+
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		// Services wrap whatever a repository returned as a server error; when the
+		// repository was reporting a client error, that is the one clients need
+		if appErr.HTTPStatus >= 500 && appErr.Err != nil {
+			if wrapped := ToProblem(appErr.Err); wrapped.Status < 500 {
+				return wrapped
+			}
+		}
+
+		status := appErr.HTTPStatus
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		p := NewProblem(status, appErr.Code, appErr.Message)
+		p.Errors = appErr.Fields
+		if appErr.Key != "" {
+			p.WithDetailKey(appErr.Key, appErr.Args)
+		}
+		var planErr *PlanLimitError
+		if errors.As(err, &planErr) {
+			p.Plan = planErr
+		}
+		return p
+	}
+
+	if code, status, ok := classify(err); ok {
+		if status >= 500 {
+			return NewProblem(status, code, "")
+		}
+		var repoErr *RepositoryError
+		if errors.As(err, &repoErr) {
+			return NewProblem(status, code, repoErr.Message)
+		}
+		return NewProblem(status, code, err.Error())
+	}
+
+	return NewProblem(http.StatusInternalServerError, ErrCodeInternal, "")
+}
+
+// classify returns the code and status of errors that are not AppErrors: sentinel
+// errors, repository errors and timeouts
+func classify(err error) (ErrorCode, int, bool) {
+	var repoErr *RepositoryError
+	if errors.As(err, &repoErr) {
+		code, status := classifyRepositoryCode(repoErr.Code)
+		return code, status, true
+	}
+
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrCodeNotFound, http.StatusNotFound, true
+	case errors.Is(err, ErrDuplicate), errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrCodeDuplicate, http.StatusConflict, true
+	case errors.Is(err, ErrConflict):
+		return ErrCodeConflict, http.StatusConflict, true
+	case errors.Is(err, ErrInvalidInput):
+		return ErrCodeInvalidInput, http.StatusBadRequest, true
+	case errors.Is(err, ErrUnauthorized):
+		return ErrCodeUnauthorized, http.StatusUnauthorized, true
+	case errors.Is(err, ErrForbidden):
+		return ErrCodeForbidden, http.StatusForbidden, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrCodeRequestTimeout, http.StatusGatewayTimeout, true
+	case errors.Is(err, ErrDatabaseError):
+		return ErrCodeDatabaseError, http.StatusInternalServerError, true
+	case errors.Is(err, ErrCacheError):
+		return ErrCodeCacheError, http.StatusInternalServerError, true
+	}
+	return "", 0, false
+}
+
+// classifyRepositoryCode maps the code of a RepositoryError to the code and status of
+// the problem it causes. Failed queries are server errors whatever the operation.
+func classifyRepositoryCode(code string) (ErrorCode, int) {
+	switch {
+	case code == "NOT_FOUND" || strings.HasSuffix(code, "_NOT_FOUND"):
+		return ErrCodeNotFound, http.StatusNotFound
+	case code == "DUPLICATE":
+		return ErrCodeDuplicate, http.StatusConflict
+	case code == "CONFLICT":
+		return ErrCodeConflict, http.StatusConflict
+	case code == "TENANT_MISMATCH":
+		return ErrCodeForbidden, http.StatusForbidden
+	case code == "INVALID_INPUT" || code == "VALIDATION_FAILED":
+		return ErrCodeInvalidInput, http.StatusBadRequest
+	case strings.HasPrefix(code, "INVALID_"), strings.HasPrefix(code, "INSUFFICIENT_"),
+		strings.HasPrefix(code, "ALREADY_"), strings.HasPrefix(code, "NOT_") && code != "NOT_IMPLEMENTED":
+		return ErrorCode(code), http.StatusUnprocessableEntity
+	}
+	return ErrCodeDatabaseError, http.StatusInternalServerError
+}
@@ -0,0 +1,173 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestToProblem(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   pkgErrors.ErrorCode
+		detail string
+	}{
+		{
+			name:   "app error keeps its status, code and message",
+			err:    pkgErrors.NewValidationError("start time cannot be in the past"),
+			status: http.StatusBadRequest,
+			code:   pkgErrors.ErrCodeValidation,
+			detail: "start time cannot be in the past",
+		},
+		{
+			name:   "wrapped error is not reported",
+			err:    pkgErrors.NewInternalError("failed to create booking", fmt.Errorf("pq: connection refused")),
+			status: http.StatusInternalServerError,
+			code:   pkgErrors.ErrCodeInternal,
+			detail: "failed to create booking",
+		},
+		{
+			name:   "repository not found wrapped by a service",
+			err:    pkgErrors.NewServiceError("GET_FAILED", "failed to get booking", pkgErrors.NewRepositoryError("NOT_FOUND", "booking not found", gorm.ErrRecordNotFound)),
+			status: http.StatusNotFound,
+			code:   pkgErrors.ErrCodeNotFound,
+			detail: "booking not found",
+		},
+		{
+			name:   "failed query",
+			err:    pkgErrors.NewRepositoryError("FIND_FAILED", "failed to find booking", fmt.Errorf("pq: syntax error")),
+			status: http.StatusInternalServerError,
+			code:   pkgErrors.ErrCodeDatabaseError,
+		},
+		{
+			name:   "repository business rule",
+			err:    pkgErrors.NewRepositoryError("INSUFFICIENT_POINTS", "not enough points", nil),
+			status: http.StatusUnprocessableEntity,
+			code:   "INSUFFICIENT_POINTS",
+			detail: "not enough points",
+		},
+		{
+			name:   "sentinel",
+			err:    fmt.Errorf("loading tenant: %w", pkgErrors.ErrForbidden),
+			status: http.StatusForbidden,
+			code:   pkgErrors.ErrCodeForbidden,
+			detail: "loading tenant: forbidden access",
+		},
+		{
+			name:   "unknown error",
+			err:    fmt.Errorf("validation error: name is required"),
+			status: http.StatusInternalServerError,
+			code:   pkgErrors.ErrCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := pkgErrors.ToProblem(tt.err)
+			assert.Equal(t, tt.status, p.Status)
+			assert.Equal(t, tt.code, p.Code)
+			assert.Equal(t, tt.detail, p.Detail)
+			assert.Equal(t, http.StatusText(tt.status), p.Title)
+			assert.Equal(t, pkgErrors.ProblemType(tt.code), p.Type)
+		})
+	}
+}
+
+func TestToProblemDetails(t *testing.T) {
+	t.Run("version conflict", func(t *testing.T) {
+		p := pkgErrors.ToProblem(pkgErrors.NewVersionConflictError("booking", 2, 3, nil))
+		assert.Equal(t, http.StatusConflict, p.Status)
+		require.NotNil(t, p.Conflict)
+		assert.Equal(t, 3, p.Conflict.CurrentVersion)
+	})
+
+	t.Run("plan limit", func(t *testing.T) {
+		detail := &pkgErrors.PlanLimitError{Plan: "free", Limit: "artisans", Used: 3, Allowed: 3}
+		p := pkgErrors.ToProblem(pkgErrors.NewPaymentRequiredError(pkgErrors.ErrCodePlanLimitReached, "no seats left", detail))
+		assert.Equal(t, http.StatusPaymentRequired, p.Status)
+		assert.Equal(t, detail, p.Plan)
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		p := pkgErrors.ToProblem(pkgErrors.NewFieldValidationError("invalid booking",
+			pkgErrors.FieldError{Field: "duration", Code: "max", Message: "duration must be at most 480 minutes"},
+		))
+		require.Len(t, p.Errors, 1)
+		assert.Equal(t, "duration", p.Errors[0].Field)
+	})
+}
+
+func TestProblemMarshalJSON(t *testing.T) {
+	p := pkgErrors.NewProblem(http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "slow down").
+		With("retry_after", 30).
+		With("status", "ignored")
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(data, &body))
+	assert.Equal(t, "https://api.kraftivibe.com/problems/rate-limit-exceeded", body["type"])
+	assert.Equal(t, "Too Many Requests", body["title"])
+	assert.Equal(t, float64(429), body["status"])
+	assert.Equal(t, "RATE_LIMIT_EXCEEDED", body["code"])
+	assert.Equal(t, float64(30), body["retry_after"])
+	assert.NotContains(t, body, "errors")
+}
+
+func TestProblemLocalize(t *testing.T) {
+	newProblem := func() *pkgErrors.Problem {
+		p := pkgErrors.ToProblem(pkgErrors.NewFieldValidationError("invalid booking",
+			pkgErrors.FieldError{Field: "Email", Code: "required", Message: "Email is required"},
+			pkgErrors.FieldError{Field: "Notes", Code: "max", Message: "Notes must be at most 500 characters", Param: "500"},
+			pkgErrors.FieldError{Field: "Duration", Code: "max", Message: "duration must be at most 480 minutes", Param: "480"},
+		))
+		return p.WithDetailKey("validation.failed", nil)
+	}
+
+	t.Run("translated", func(t *testing.T) {
+		p := newProblem()
+		p.Localize(i18n.New("es-MX"))
+		assert.Equal(t, "Solicitud incorrecta", p.Title)
+		assert.Equal(t, "Uno o más campos no superaron la validación", p.Detail)
+		assert.Equal(t, "Email es obligatorio", p.Errors[0].Message)
+		assert.Equal(t, "Notes debe tener como máximo 500 caracteres", p.Errors[1].Message)
+		assert.Equal(t, "duration must be at most 480 minutes", p.Errors[2].Message, "custom messages are kept")
+	})
+
+	t.Run("english is left as created", func(t *testing.T) {
+		p := newProblem()
+		p.Localize(i18n.New("de", "en"))
+		assert.Equal(t, "Bad Request", p.Title)
+		assert.Equal(t, "invalid booking", p.Detail)
+		assert.Equal(t, "Email is required", p.Errors[0].Message)
+	})
+
+	t.Run("default messages of constructors", func(t *testing.T) {
+		p := pkgErrors.ToProblem(pkgErrors.NewForbiddenError(""))
+		p.Localize(i18n.New("fr"))
+		assert.Equal(t, "Interdit", p.Title)
+		assert.Equal(t, "accès interdit", p.Detail)
+
+		p = pkgErrors.ToProblem(pkgErrors.NewForbiddenError("only owners can do that"))
+		p.Localize(i18n.New("fr"))
+		assert.Equal(t, "only owners can do that", p.Detail)
+	})
+
+	t.Run("errors of the app error are not changed", func(t *testing.T) {
+		appErr := pkgErrors.NewFieldValidationError("invalid",
+			pkgErrors.FieldError{Field: "Email", Code: "required", Message: "Email is required"})
+		pkgErrors.ToProblem(appErr).Localize(i18n.New("es"))
+		assert.Equal(t, "Email is required", appErr.Fields[0].Message)
+	})
+}
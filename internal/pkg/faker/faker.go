@@ -0,0 +1,227 @@
+// Package faker generates realistic fake people, businesses and places for seeding
+// databases. A Faker built from the same seed always produces the same data, so seeded
+// datasets can be reproduced exactly.
+package faker
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// City is a place fake people and businesses are located in
+type City struct {
+	Name      string
+	Region    string
+	Country   string // ISO 3166-1 alpha-2 code
+	Currency  string // ISO 4217 code
+	Timezone  string
+	DialCode  string
+	PerUSD    float64 // Units of the currency a US dollar buys, for pricing in it
+	Latitude  float64
+	Longitude float64
+}
+
+var cities = []City{
+	{"Accra", "Greater Accra", "GH", "GHS", "Africa/Accra", "+233", 12, 5.6037, -0.1870},
+	{"Kumasi", "Ashanti", "GH", "GHS", "Africa/Accra", "+233", 12, 6.6885, -1.6244},
+	{"Takoradi", "Western", "GH", "GHS", "Africa/Accra", "+233", 12, 4.8845, -1.7554},
+	{"Lagos", "Lagos", "NG", "NGN", "Africa/Lagos", "+234", 1500, 6.5244, 3.3792},
+	{"Abuja", "FCT", "NG", "NGN", "Africa/Lagos", "+234", 1500, 9.0765, 7.3986},
+	{"Ibadan", "Oyo", "NG", "NGN", "Africa/Lagos", "+234", 1500, 7.3775, 3.9470},
+	{"Nairobi", "Nairobi", "KE", "KES", "Africa/Nairobi", "+254", 130, -1.2921, 36.8219},
+	{"Mombasa", "Mombasa", "KE", "KES", "Africa/Nairobi", "+254", 130, -4.0435, 39.6682},
+	{"Kampala", "Central", "UG", "UGX", "Africa/Kampala", "+256", 3700, 0.3476, 32.5825},
+	{"Johannesburg", "Gauteng", "ZA", "ZAR", "Africa/Johannesburg", "+27", 18, -26.2041, 28.0473},
+	{"Cape Town", "Western Cape", "ZA", "ZAR", "Africa/Johannesburg", "+27", 18, -33.9249, 18.4241},
+	{"London", "England", "GB", "GBP", "Europe/London", "+44", 0.8, 51.5072, -0.1276},
+	{"New York", "New York", "US", "USD", "America/New_York", "+1", 1, 40.7128, -74.0060},
+}
+
+var firstNames = []string{
+	"Kwame", "Ama", "Kofi", "Akosua", "Yaw", "Abena", "Kojo", "Efua", "Kwabena", "Adwoa",
+	"Chinedu", "Ngozi", "Emeka", "Chioma", "Tunde", "Funmilayo", "Ifeanyi", "Aisha", "Musa", "Zainab",
+	"Wanjiru", "Otieno", "Achieng", "Kamau", "Njeri", "Mwangi", "Amani", "Baraka", "Thabo", "Lerato",
+	"Sipho", "Naledi", "James", "Mary", "David", "Sarah", "Michael", "Grace", "Daniel", "Esther",
+}
+
+var lastNames = []string{
+	"Mensah", "Owusu", "Boateng", "Asante", "Osei", "Agyeman", "Appiah", "Darko", "Addo", "Quaye",
+	"Okafor", "Adeyemi", "Okonkwo", "Balogun", "Eze", "Nwosu", "Bello", "Abubakar", "Ogunleye", "Obi",
+	"Kariuki", "Odhiambo", "Mutua", "Wambui", "Kiprono", "Ndlovu", "Dlamini", "Nkosi", "Mokoena", "Khumalo",
+	"Smith", "Johnson", "Brown", "Williams", "Taylor",
+}
+
+var businessWords = []string{
+	"Golden", "Royal", "Prime", "Unity", "Heritage", "Sunrise", "Crown", "Harmony", "Victory", "Summit",
+	"Star", "Eagle", "Grace", "Pioneer", "Trust", "Ebony", "Zenith", "Savanna", "Coastal", "Metro",
+}
+
+var businessSuffixes = []string{
+	"Works", "Crafts", "Services", "Artisans", "Studio", "Collective", "Workshop", "Solutions", "Hands", "Guild",
+}
+
+var streets = []string{
+	"Independence Avenue", "Liberation Road", "Oxford Street", "Ring Road", "Spintex Road", "Awolowo Road",
+	"Allen Avenue", "Moi Avenue", "Kenyatta Avenue", "Kimathi Street", "Main Street", "Market Street",
+	"Church Road", "Station Road", "High Street", "Nelson Mandela Drive",
+}
+
+var words = []string{
+	"please", "bring", "tools", "gate", "code", "call", "arrival", "parking", "available", "upstairs",
+	"kitchen", "bathroom", "garden", "front", "door", "leaking", "repair", "replace", "install", "urgent",
+	"weekend", "morning", "afternoon", "quote", "materials", "measure", "colour", "finish", "before", "after",
+}
+
+// Faker generates fake data from its own random source
+type Faker struct {
+	rng *rand.Rand
+}
+
+// New creates a Faker whose data is determined by seed
+func New(seed uint64) *Faker {
+	return &Faker{rng: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))}
+}
+
+// IntN returns a number in [0, n)
+func (f *Faker) IntN(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return f.rng.IntN(n)
+}
+
+// IntRange returns a number in [lo, hi]
+func (f *Faker) IntRange(lo, hi int) int {
+	if hi <= lo {
+		return lo
+	}
+	return lo + f.rng.IntN(hi-lo+1)
+}
+
+// Float64 returns a number in [0, 1)
+func (f *Faker) Float64() float64 {
+	return f.rng.Float64()
+}
+
+// Chance reports true with probability p
+func (f *Faker) Chance(p float64) bool {
+	return f.rng.Float64() < p
+}
+
+// Weighted picks an index with probability proportional to its weight
+func (f *Faker) Weighted(weights []float64) int {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	r := f.rng.Float64() * total
+	for i, w := range weights {
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+	return len(weights) - 1
+}
+
+// LogNormal returns a positive number around median, spread by sigma. Prices and
+// durations follow it: most are near the median with a long tail of larger ones.
+func (f *Faker) LogNormal(median, sigma float64) float64 {
+	return median * math.Exp(sigma*f.rng.NormFloat64())
+}
+
+// Pareto returns a number of at least minimum from a power law with shape alpha. A
+// few draws are many times the minimum, as with the sizes of businesses.
+func (f *Faker) Pareto(minimum, alpha float64) float64 {
+	return minimum / math.Pow(1-f.rng.Float64(), 1/alpha)
+}
+
+// UUID returns a version 4 UUID drawn from the Faker's random source
+func (f *Faker) UUID() uuid.UUID {
+	var id uuid.UUID
+	for i := 0; i < len(id); i += 8 {
+		v := f.rng.Uint64()
+		for j := range 8 {
+			id[i+j] = byte(v >> (8 * j))
+		}
+	}
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id
+}
+
+// Pick returns a random element of items
+func Pick[T any](f *Faker, items []T) T {
+	return items[f.IntN(len(items))]
+}
+
+// FirstName returns a given name
+func (f *Faker) FirstName() string {
+	return Pick(f, firstNames)
+}
+
+// LastName returns a family name
+func (f *Faker) LastName() string {
+	return Pick(f, lastNames)
+}
+
+// CompanyName returns a business name such as "Golden Crafts"
+func (f *Faker) CompanyName() string {
+	return Pick(f, businessWords) + " " + Pick(f, businessSuffixes)
+}
+
+// City returns a city
+func (f *Faker) City() City {
+	return Pick(f, cities)
+}
+
+// Email returns an address made of a person's names at domain, with n keeping
+// people who share names apart
+func (f *Faker) Email(firstName, lastName, domain string, n int) string {
+	return fmt.Sprintf("%s.%s%d@%s", slug(firstName), slug(lastName), n, domain)
+}
+
+// Phone returns a mobile number in the city's country
+func (f *Faker) Phone(city City) string {
+	return fmt.Sprintf("%s%d%08d", city.DialCode, f.IntRange(2, 9), f.IntN(100000000))
+}
+
+// StreetAddress returns a house number and street
+func (f *Faker) StreetAddress() string {
+	return fmt.Sprintf("%d %s", f.IntRange(1, 250), Pick(f, streets))
+}
+
+// Near returns coordinates within about radiusKm of the city centre
+func (f *Faker) Near(city City, radiusKm float64) (lat, lng float64) {
+	// A degree of latitude is about 111 km; one of longitude shrinks towards the poles
+	distance := radiusKm * math.Sqrt(f.rng.Float64())
+	bearing := 2 * math.Pi * f.rng.Float64()
+	lat = city.Latitude + distance*math.Cos(bearing)/111
+	lng = city.Longitude + distance*math.Sin(bearing)/(111*math.Cos(city.Latitude*math.Pi/180))
+	return lat, lng
+}
+
+// Sentence returns a short note of n words
+func (f *Faker) Sentence(n int) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = Pick(f, words)
+	}
+	sentence := strings.Join(parts, " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+// slug lowercases a name and drops anything but letters and digits
+func slug(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
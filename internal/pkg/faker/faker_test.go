@@ -0,0 +1,71 @@
+package faker_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"Krafti_Vibe/internal/pkg/faker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaker_SameSeedSameData(t *testing.T) {
+	a, b := faker.New(42), faker.New(42)
+	for range 20 {
+		assert.Equal(t, a.FirstName(), b.FirstName())
+		assert.Equal(t, a.UUID(), b.UUID())
+		assert.Equal(t, a.LogNormal(100, 0.5), b.LogNormal(100, 0.5))
+	}
+
+	assert.NotEqual(t, faker.New(1).UUID(), faker.New(2).UUID())
+}
+
+func TestFaker_UUIDIsVersion4(t *testing.T) {
+	id := faker.New(7).UUID()
+	assert.Equal(t, 4, int(id.Version()))
+	assert.Equal(t, "RFC4122", id.Variant().String())
+}
+
+func TestFaker_Distributions(t *testing.T) {
+	f := faker.New(3)
+
+	draws := make([]float64, 10000)
+	for i := range draws {
+		draws[i] = f.Pareto(1, 1.5)
+	}
+	slices.Sort(draws)
+	assert.GreaterOrEqual(t, draws[0], 1.0)
+	assert.Less(t, draws[len(draws)/2], 2.0, "half the draws are near the minimum")
+	assert.Greater(t, draws[len(draws)-1], 20.0, "a few draws are far above it")
+
+	for i := range draws {
+		draws[i] = f.LogNormal(100, 0.4)
+	}
+	slices.Sort(draws)
+	assert.InDelta(t, 100, draws[len(draws)/2], 5, "the median")
+
+	counts := make([]int, 3)
+	for range 10000 {
+		counts[f.Weighted([]float64{1, 0, 3})]++
+	}
+	assert.Zero(t, counts[1])
+	assert.InDelta(t, 3, float64(counts[2])/float64(counts[0]), 0.3)
+}
+
+func TestFaker_Near(t *testing.T) {
+	f := faker.New(5)
+	city := f.City()
+	for range 100 {
+		lat, lng := f.Near(city, 10)
+		// Within 10 km, allowing for the flat-earth approximation
+		assert.Less(t, math.Abs(lat-city.Latitude), 0.1)
+		assert.Less(t, math.Abs(lng-city.Longitude)*math.Cos(city.Latitude*math.Pi/180), 0.1)
+	}
+}
+
+func TestFaker_Email(t *testing.T) {
+	f := faker.New(1)
+	assert.Equal(t, "ama.mensah3@demo1.example.com", f.Email("Ama", "Mensah", "demo1.example.com", 3))
+	assert.Equal(t, "sipho.odhiambo1@example.com", f.Email("Sipho", "O'Dhiambo", "example.com", 1))
+}
@@ -0,0 +1,187 @@
+// Package fieldcrypt encrypts individual database columns with AES-256-GCM.
+//
+// Ciphertexts are self-describing strings, "enc:v1:<key id>:<base64>", so encrypted
+// and not yet encrypted values can live side by side while existing rows are migrated,
+// and old keys keep decrypting after a rotation.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	prefix  = "enc:v1:"
+	keySize = 32
+)
+
+var (
+	// ErrNoCipher is returned when an encrypted value is read without a configured cipher
+	ErrNoCipher = errors.New("fieldcrypt: no encryption key configured")
+	// ErrUnknownKey is returned for values encrypted with a key the cipher does not hold
+	ErrUnknownKey = errors.New("fieldcrypt: value encrypted with an unknown key")
+	// ErrMalformed is returned for values that look encrypted but cannot be decoded
+	ErrMalformed = errors.New("fieldcrypt: malformed ciphertext")
+)
+
+type key struct {
+	id       string
+	aead     cipher.AEAD
+	nonceKey []byte // Derives deterministic nonces
+}
+
+// Cipher encrypts with its primary key and decrypts with any of its keys
+type Cipher struct {
+	keys []key
+}
+
+// New creates a cipher from base64-encoded 32-byte keys. The primary key encrypts;
+// previous keys only decrypt values written before a rotation.
+func New(primary string, previous ...string) (*Cipher, error) {
+	c := &Cipher{}
+	for i, encoded := range append([]string{primary}, previous...) {
+		encoded = strings.TrimSpace(encoded)
+		if encoded == "" {
+			if i == 0 {
+				return nil, errors.New("fieldcrypt: primary key is required")
+			}
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("fieldcrypt: key %d is not valid base64: %w", i, err)
+		}
+		if len(raw) != keySize {
+			return nil, fmt.Errorf("fieldcrypt: key %d must be %d bytes, got %d", i, keySize, len(raw))
+		}
+		k, err := newKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		c.keys = append(c.keys, k)
+	}
+	return c, nil
+}
+
+func newKey(raw []byte) (key, error) {
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return key{}, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return key{}, err
+	}
+	sum := sha256.Sum256(raw)
+	nonceKey := hmac.New(sha256.New, raw)
+	nonceKey.Write([]byte("fieldcrypt nonce"))
+	return key{id: hex.EncodeToString(sum[:4]), aead: aead, nonceKey: nonceKey.Sum(nil)}, nil
+}
+
+// Encrypt encrypts plaintext with a random nonce. Empty strings stay empty.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	k := c.keys[0]
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return seal(k, nonce, plaintext), nil
+}
+
+// EncryptDeterministic encrypts plaintext so that equal plaintexts give equal
+// ciphertexts, for columns that are looked up by value. It reveals which rows share
+// a value and nothing else. Empty strings stay empty.
+func (c *Cipher) EncryptDeterministic(plaintext string) string {
+	if plaintext == "" {
+		return ""
+	}
+	return sealDeterministic(c.keys[0], plaintext)
+}
+
+// LookupValues returns every stored form of a deterministically encrypted value: its
+// ciphertext under each key, and the plaintext itself for rows not yet migrated
+func (c *Cipher) LookupValues(plaintext string) []string {
+	values := []string{plaintext}
+	if c == nil || plaintext == "" {
+		return values
+	}
+	for _, k := range c.keys {
+		values = append(values, sealDeterministic(k, plaintext))
+	}
+	return values
+}
+
+// Decrypt decrypts a value written by Encrypt or EncryptDeterministic. Values that are
+// not encrypted are returned unchanged.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", ErrNoCipher
+	}
+
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return "", ErrMalformed
+	}
+	for _, k := range c.keys {
+		if k.id != id {
+			continue
+		}
+		data, err := base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil || len(data) < k.aead.NonceSize() {
+			return "", ErrMalformed
+		}
+		nonce, sealed := data[:k.aead.NonceSize()], data[k.aead.NonceSize():]
+		plaintext, err := k.aead.Open(nil, nonce, sealed, []byte(k.id))
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+		return string(plaintext), nil
+	}
+	return "", ErrUnknownKey
+}
+
+// IsEncrypted reports whether value is a fieldcrypt ciphertext
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+func sealDeterministic(k key, plaintext string) string {
+	mac := hmac.New(sha256.New, k.nonceKey)
+	mac.Write([]byte(plaintext))
+	return seal(k, mac.Sum(nil)[:k.aead.NonceSize()], plaintext)
+}
+
+func seal(k key, nonce []byte, plaintext string) string {
+	// The key ID is authenticated so a value cannot be relabelled to another key
+	sealed := k.aead.Seal(nonce, nonce, []byte(plaintext), []byte(k.id))
+	return prefix + k.id + ":" + base64.RawURLEncoding.EncodeToString(sealed)
+}
+
+// defaultCipher is used by the GORM serializers of encrypted model fields
+var defaultCipher atomic.Pointer[Cipher]
+
+// SetDefault sets the cipher used for encrypted model fields. Until it is set, values
+// are written in plaintext and encrypted values cannot be read.
+func SetDefault(c *Cipher) {
+	defaultCipher.Store(c)
+}
+
+// Default returns the cipher set with SetDefault, or nil
+func Default() *Cipher {
+	return defaultCipher.Load()
+}
@@ -0,0 +1,102 @@
+package fieldcrypt_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"Krafti_Vibe/internal/pkg/fieldcrypt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T) string {
+	raw := make([]byte, 32)
+	_, err := rand.Read(raw)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	c, err := fieldcrypt.New(newKey(t))
+	require.NoError(t, err)
+
+	first, err := c.Encrypt("254712345678")
+	require.NoError(t, err)
+	second, err := c.Encrypt("254712345678")
+	require.NoError(t, err)
+	assert.True(t, fieldcrypt.IsEncrypted(first))
+	assert.NotContains(t, first, "254712345678")
+	assert.NotEqual(t, first, second, "random nonces give distinct ciphertexts")
+
+	plaintext, err := c.Decrypt(first)
+	require.NoError(t, err)
+	assert.Equal(t, "254712345678", plaintext)
+
+	empty, err := c.Encrypt("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	// Values written before encryption was enabled read back unchanged
+	plaintext, err = c.Decrypt("pi_legacy")
+	require.NoError(t, err)
+	assert.Equal(t, "pi_legacy", plaintext)
+}
+
+func TestEncryptDeterministic(t *testing.T) {
+	c, err := fieldcrypt.New(newKey(t))
+	require.NoError(t, err)
+
+	ciphertext := c.EncryptDeterministic("pi_123")
+	assert.Equal(t, ciphertext, c.EncryptDeterministic("pi_123"))
+	assert.NotEqual(t, ciphertext, c.EncryptDeterministic("pi_124"))
+	assert.Empty(t, c.EncryptDeterministic(""))
+
+	plaintext, err := c.Decrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "pi_123", plaintext)
+
+	assert.Contains(t, c.LookupValues("pi_123"), ciphertext)
+	assert.Contains(t, c.LookupValues("pi_123"), "pi_123")
+
+	var none *fieldcrypt.Cipher
+	assert.Equal(t, []string{"pi_123"}, none.LookupValues("pi_123"))
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey, newKey := newKey(t), newKey(t)
+	before, err := fieldcrypt.New(oldKey)
+	require.NoError(t, err)
+	after, err := fieldcrypt.New(newKey, oldKey)
+	require.NoError(t, err)
+
+	ciphertext := before.EncryptDeterministic("pi_123")
+	plaintext, err := after.Decrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "pi_123", plaintext)
+	assert.Contains(t, after.LookupValues("pi_123"), ciphertext, "rows under the old key are still found")
+	assert.NotEqual(t, ciphertext, after.EncryptDeterministic("pi_123"))
+
+	_, err = before.Decrypt(after.EncryptDeterministic("pi_123"))
+	assert.ErrorIs(t, err, fieldcrypt.ErrUnknownKey)
+}
+
+func TestDecryptErrors(t *testing.T) {
+	c, err := fieldcrypt.New(newKey(t))
+	require.NoError(t, err)
+	ciphertext, err := c.Encrypt("secret")
+	require.NoError(t, err)
+
+	var none *fieldcrypt.Cipher
+	_, err = none.Decrypt(ciphertext)
+	assert.ErrorIs(t, err, fieldcrypt.ErrNoCipher)
+
+	_, err = c.Decrypt(ciphertext[:len(ciphertext)-4] + "AAAA")
+	assert.ErrorIs(t, err, fieldcrypt.ErrMalformed)
+
+	_, err = fieldcrypt.New("")
+	assert.Error(t, err)
+	_, err = fieldcrypt.New(base64.StdEncoding.EncodeToString([]byte("too short")))
+	assert.Error(t, err)
+}
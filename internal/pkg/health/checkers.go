@@ -0,0 +1,181 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/infrastructure/payments"
+)
+
+// Dependencies every instance shares, such as the identity provider, are checked as
+// non-critical: failing readiness for them would take every instance out of rotation
+// at once without sending traffic anywhere better, so they degrade the service instead.
+
+// nonCritical reports a checker's failures as degrading the service
+type nonCritical struct {
+	Checker
+}
+
+// NonCritical wraps a checker whose failures degrade the service rather than make it
+// unhealthy
+func NonCritical(checker Checker) Checker {
+	return &nonCritical{Checker: checker}
+}
+
+func (c *nonCritical) Check(ctx context.Context) error {
+	if err := c.Checker.Check(ctx); err != nil && !IsDegraded(err) {
+		return Degraded(err)
+	}
+	return nil
+}
+
+// LatencyBudget passes the wrapped checker's budget through
+func (c *nonCritical) LatencyBudget() time.Duration {
+	if budgeted, ok := c.Checker.(interface{ LatencyBudget() time.Duration }); ok {
+		return budgeted.LatencyBudget()
+	}
+	return 0
+}
+
+// budgeted reports a check that succeeds slower than its budget as degrading the service
+type budgeted struct {
+	Checker
+	budget time.Duration
+}
+
+// WithLatencyBudget wraps a checker so that succeeding slower than budget degrades the
+// service
+func WithLatencyBudget(checker Checker, budget time.Duration) Checker {
+	return &budgeted{Checker: checker, budget: budget}
+}
+
+// LatencyBudget returns how long the check may take before the service is degraded
+func (c *budgeted) LatencyBudget() time.Duration {
+	return c.budget
+}
+
+// Pinger is implemented by clients that can check their connection, such as Redis
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// RedisChecker checks the Redis connection used for caching, rate limits and locks
+type RedisChecker struct {
+	Client Pinger
+}
+
+func (c *RedisChecker) Name() string {
+	return "redis"
+}
+
+// Check pings Redis
+func (c *RedisChecker) Check(ctx context.Context) error {
+	return c.Client.Ping(ctx)
+}
+
+// OIDCDiscoveryChecker checks that an OpenID Connect provider such as Zitadel serves
+// its discovery document, without which tokens can't be verified
+type OIDCDiscoveryChecker struct {
+	Issuer string // Such as https://auth.example.com
+	Client *http.Client
+}
+
+func (c *OIDCDiscoveryChecker) Name() string {
+	return "zitadel"
+}
+
+// Check fetches the discovery document and checks it is the issuer's
+func (c *OIDCDiscoveryChecker) Check(ctx context.Context) error {
+	issuer := strings.TrimSuffix(c.Issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return err
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("discovery document returned %s", resp.Status)
+	}
+	var discovery struct {
+		Issuer string `json:"issuer"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return fmt.Errorf("invalid discovery document: %w", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return fmt.Errorf("discovery document is for issuer %q", discovery.Issuer)
+	}
+	return nil
+}
+
+// PaymentProvidersChecker reports the payment providers whose circuit breaker is open.
+// It reads the breakers rather than calling the providers, which are probed by the
+// provider health job and by the payments themselves, so probes never reach them.
+type PaymentProvidersChecker struct {
+	Registry *payments.Registry
+}
+
+func (c *PaymentProvidersChecker) Name() string {
+	return "payment_providers"
+}
+
+// Check fails when any provider's circuit is open
+func (c *PaymentProvidersChecker) Check(ctx context.Context) error {
+	var unavailable []string
+	for _, provider := range c.Registry.Health() {
+		if provider.State == payments.CircuitOpen {
+			unavailable = append(unavailable, provider.Provider)
+		}
+	}
+	if len(unavailable) > 0 {
+		return fmt.Errorf("circuit open for %s", strings.Join(unavailable, ", "))
+	}
+	return nil
+}
+
+// BacklogThresholds are the depths from which a queue's backlog degrades the service
+// or makes it unhealthy; zero disables a threshold
+type BacklogThresholds struct {
+	Degraded  int64
+	Unhealthy int64
+}
+
+// BacklogChecker checks the depth of a queue background jobs work through, such as
+// the outbox of domain events
+type BacklogChecker struct {
+	Queue      string
+	Count      func(ctx context.Context) (int64, error)
+	Thresholds BacklogThresholds
+}
+
+func (c *BacklogChecker) Name() string {
+	return "queue_" + c.Queue
+}
+
+// Check counts the queue's backlog against its thresholds
+func (c *BacklogChecker) Check(ctx context.Context) error {
+	depth, err := c.Count(ctx)
+	if err != nil {
+		return err
+	}
+	if c.Thresholds.Unhealthy > 0 && depth >= c.Thresholds.Unhealthy {
+		return fmt.Errorf("%d pending, at least %d is unhealthy", depth, c.Thresholds.Unhealthy)
+	}
+	if c.Thresholds.Degraded > 0 && depth >= c.Thresholds.Degraded {
+		return Degraded(fmt.Errorf("%d pending, at least %d is degraded", depth, c.Thresholds.Degraded))
+	}
+	return nil
+}
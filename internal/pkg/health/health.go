@@ -3,6 +3,11 @@ package health
 import (
 	"Krafti_Vibe/internal/infrastructure/database"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,6 +20,7 @@ const (
 	StatusHealthy   HealthStatus = "healthy"
 	StatusUnhealthy HealthStatus = "unhealthy"
 	StatusDegraded  HealthStatus = "degraded"
+	StatusStarting  HealthStatus = "starting"
 )
 
 // HealthResponse represents the health check response
@@ -37,44 +43,129 @@ type Checker interface {
 	Name() string
 }
 
+// degradedError marks a check failure that leaves the service working, but worse
+type degradedError struct {
+	err error
+}
+
+func (e *degradedError) Error() string { return e.err.Error() }
+func (e *degradedError) Unwrap() error { return e.err }
+
+// Degraded wraps a checker's error to report the service as degraded rather than
+// unhealthy
+func Degraded(err error) error {
+	return &degradedError{err: err}
+}
+
+// IsDegraded reports whether a checker's error only degrades the service
+func IsDegraded(err error) bool {
+	var degraded *degradedError
+	return errors.As(err, &degraded)
+}
+
+// DefaultCheckTimeout bounds each check, so one hanging dependency is reported as such
+// rather than failing the whole probe
+const DefaultCheckTimeout = 2 * time.Second
+
 // HealthChecker manages health checks
 type HealthChecker struct {
-	checkers []Checker
+	mu           sync.RWMutex
+	checkers     []Checker
+	checkTimeout time.Duration
+	draining     atomic.Bool
+	starting     atomic.Pointer[string] // What startup is waiting for, until Started
 }
 
 // NewHealthChecker creates a new health checker
 func NewHealthChecker(checkers ...Checker) *HealthChecker {
 	return &HealthChecker{
-		checkers: checkers,
+		checkers:     checkers,
+		checkTimeout: DefaultCheckTimeout,
 	}
 }
 
 // Register adds a new health checker
 func (h *HealthChecker) Register(checker Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	h.checkers = append(h.checkers, checker)
 }
 
-// Check performs all health checks
-func (h *HealthChecker) Check(ctx context.Context) HealthResponse {
-	checks := make(map[string]CheckResult)
-	overallStatus := StatusHealthy
+// SetCheckTimeout changes how long each check may take
+func (h *HealthChecker) SetCheckTimeout(timeout time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checkTimeout = timeout
+}
 
-	for _, checker := range h.checkers {
-		start := time.Now()
-		err := checker.Check(ctx)
-		duration := time.Since(start)
+// Drain reports the service as unhealthy from now on, so load balancers stop sending
+// requests to an instance that is shutting down while it finishes those in flight
+func (h *HealthChecker) Drain() {
+	h.draining.Store(true)
+}
+
+// SetStarting reports the service as starting until Started, waiting for stage, such
+// as connecting to a dependency
+func (h *HealthChecker) SetStarting(stage string) {
+	h.starting.Store(&stage)
+}
+
+// Started ends the starting state, so checks run from now on
+func (h *HealthChecker) Started() {
+	h.starting.Store(nil)
+}
+
+// Check performs all health checks concurrently, each within the check timeout. A
+// check that succeeds slower than its latency budget degrades the service.
+func (h *HealthChecker) Check(ctx context.Context) HealthResponse {
+	if h.draining.Load() {
+		return HealthResponse{
+			Status:    StatusUnhealthy,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Checks: map[string]CheckResult{
+				"shutdown": {Status: StatusUnhealthy, Message: "draining in-flight requests"},
+			},
+		}
+	}
 
-		result := CheckResult{
-			Status:       StatusHealthy,
-			ResponseTime: duration.String(),
+	if stage := h.starting.Load(); stage != nil {
+		return HealthResponse{
+			Status:    StatusStarting,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Checks: map[string]CheckResult{
+				"startup": {Status: StatusStarting, Message: *stage},
+			},
 		}
+	}
 
-		if err != nil {
-			result.Status = StatusUnhealthy
-			result.Message = err.Error()
+	h.mu.RLock()
+	checkers := slices.Clone(h.checkers)
+	timeout := h.checkTimeout
+	h.mu.RUnlock()
+
+	results := make([]CheckResult, len(checkers))
+	var wg sync.WaitGroup
+	for i, checker := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, checker, timeout)
+		}()
+	}
+	wg.Wait()
+
+	checks := make(map[string]CheckResult, len(checkers))
+	overallStatus := StatusHealthy
+	for i, checker := range checkers {
+		result := results[i]
+		switch result.Status {
+		case StatusDegraded:
+			if overallStatus == StatusHealthy {
+				overallStatus = StatusDegraded
+			}
+		case StatusUnhealthy:
 			overallStatus = StatusUnhealthy
 		}
-
 		checks[checker.Name()] = result
 	}
 
@@ -85,6 +176,41 @@ func (h *HealthChecker) Check(ctx context.Context) HealthResponse {
 	}
 }
 
+// runCheck runs one check within the timeout and grades its outcome
+func runCheck(ctx context.Context, checker Checker, timeout time.Duration) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Check(ctx)
+	duration := time.Since(start)
+
+	result := CheckResult{
+		Status:       StatusHealthy,
+		ResponseTime: duration.String(),
+	}
+
+	switch {
+	case err == nil:
+		if budgeted, ok := checker.(interface{ LatencyBudget() time.Duration }); ok {
+			if budget := budgeted.LatencyBudget(); budget > 0 && duration > budget {
+				result.Status = StatusDegraded
+				result.Message = fmt.Sprintf("responded in %s, over its %s budget", duration.Round(time.Millisecond), budget)
+			}
+		}
+	case IsDegraded(err):
+		result.Status = StatusDegraded
+		result.Message = err.Error()
+	default:
+		result.Status = StatusUnhealthy
+		result.Message = err.Error()
+	}
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.Message = fmt.Sprintf("timed out after %s", timeout)
+	}
+	return result
+}
+
 // DatabaseChecker checks database health
 type DatabaseChecker struct{}
 
@@ -92,8 +218,13 @@ func (c *DatabaseChecker) Name() string {
 	return "database"
 }
 
+// Check pings the database. A saturated connection pool degrades the service.
 func (c *DatabaseChecker) Check(ctx context.Context) error {
-	return database.HealthCheck(ctx)
+	err := database.HealthCheck(ctx)
+	if errors.Is(err, database.ErrPoolSaturated) {
+		return Degraded(err)
+	}
+	return err
 }
 
 // Handler returns a Fiber handler for health checks
@@ -105,7 +236,7 @@ func Handler(healthChecker *HealthChecker) fiber.Handler {
 		response := healthChecker.Check(ctx)
 
 		statusCode := fiber.StatusOK
-		if response.Status == StatusUnhealthy {
+		if response.Status == StatusUnhealthy || response.Status == StatusStarting {
 			statusCode = fiber.StatusServiceUnavailable
 		}
 
@@ -121,8 +252,9 @@ func ReadinessHandler(healthChecker *HealthChecker) fiber.Handler {
 
 		response := healthChecker.Check(ctx)
 
-		// Readiness requires all checks to pass
-		if response.Status != StatusHealthy {
+		// An unhealthy check takes the instance out of rotation, as does starting; a
+		// degraded one keeps it serving while reporting its state so it can be alerted on
+		if response.Status == StatusUnhealthy || response.Status == StatusStarting {
 			return c.Status(fiber.StatusServiceUnavailable).JSON(response)
 		}
 
@@ -0,0 +1,176 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/health"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubChecker struct {
+	name string
+	err  error
+}
+
+func (c *stubChecker) Name() string                    { return c.name }
+func (c *stubChecker) Check(ctx context.Context) error { return c.err }
+
+func TestHealthChecker_DegradedVersusUnhealthy(t *testing.T) {
+	degraded := &stubChecker{name: "database", err: health.Degraded(errors.New("pool saturated"))}
+
+	response := health.NewHealthChecker(&stubChecker{name: "cache"}, degraded).Check(context.Background())
+	assert.Equal(t, health.StatusDegraded, response.Status)
+	assert.Equal(t, health.StatusDegraded, response.Checks["database"].Status)
+	assert.Equal(t, "pool saturated", response.Checks["database"].Message)
+
+	response = health.NewHealthChecker(&stubChecker{name: "cache", err: errors.New("down")}, degraded).Check(context.Background())
+	assert.Equal(t, health.StatusUnhealthy, response.Status, "unhealthy outranks degraded")
+}
+
+func TestReadinessHandler(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err    error
+		status int
+	}{
+		"healthy":   {nil, fiber.StatusOK},
+		"degraded":  {health.Degraded(errors.New("pool saturated")), fiber.StatusOK},
+		"unhealthy": {errors.New("down"), fiber.StatusServiceUnavailable},
+	} {
+		t.Run(name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/health/ready", health.ReadinessHandler(health.NewHealthChecker(&stubChecker{name: "database", err: tc.err})))
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/health/ready", nil))
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, resp.StatusCode)
+		})
+	}
+}
+
+type slowChecker struct {
+	stubChecker
+	delay time.Duration
+}
+
+func (c *slowChecker) Check(ctx context.Context) error {
+	select {
+	case <-time.After(c.delay):
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestHealthChecker_TimeoutAndLatencyBudget(t *testing.T) {
+	checker := health.NewHealthChecker(
+		&slowChecker{stubChecker: stubChecker{name: "database"}, delay: time.Second},
+		health.WithLatencyBudget(&slowChecker{stubChecker: stubChecker{name: "redis"}, delay: 20 * time.Millisecond}, time.Millisecond),
+	)
+	checker.SetCheckTimeout(100 * time.Millisecond)
+
+	response := checker.Check(context.Background())
+	assert.Equal(t, health.StatusUnhealthy, response.Checks["database"].Status)
+	assert.Contains(t, response.Checks["database"].Message, "timed out")
+	assert.Equal(t, health.StatusDegraded, response.Checks["redis"].Status)
+}
+
+func TestNonCritical(t *testing.T) {
+	response := health.NewHealthChecker(health.NonCritical(&stubChecker{name: "zitadel", err: errors.New("unreachable")})).Check(context.Background())
+	assert.Equal(t, health.StatusDegraded, response.Status)
+	assert.Equal(t, "unreachable", response.Checks["zitadel"].Message)
+}
+
+func TestBacklogChecker(t *testing.T) {
+	thresholds := health.BacklogThresholds{Degraded: 100, Unhealthy: 1000}
+	for name, tc := range map[string]struct {
+		depth  int64
+		status health.HealthStatus
+	}{
+		"below thresholds": {99, health.StatusHealthy},
+		"degraded":         {100, health.StatusDegraded},
+		"unhealthy":        {1000, health.StatusUnhealthy},
+	} {
+		t.Run(name, func(t *testing.T) {
+			checker := &health.BacklogChecker{
+				Queue:      "domain_events",
+				Count:      func(ctx context.Context) (int64, error) { return tc.depth, nil },
+				Thresholds: thresholds,
+			}
+			response := health.NewHealthChecker(checker).Check(context.Background())
+			assert.Equal(t, tc.status, response.Checks["queue_domain_events"].Status)
+		})
+	}
+}
+
+func TestOIDCDiscoveryChecker(t *testing.T) {
+	var issuer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issuer":"` + issuer + `"}`))
+	}))
+	defer server.Close()
+
+	issuer = server.URL
+	checker := &health.OIDCDiscoveryChecker{Issuer: server.URL + "/", Client: server.Client()}
+	assert.NoError(t, checker.Check(context.Background()))
+
+	issuer = "https://other.example.com"
+	assert.Error(t, checker.Check(context.Background()))
+}
+
+func TestHealthChecker_Starting(t *testing.T) {
+	checker := health.NewHealthChecker(&stubChecker{name: "database"})
+	checker.SetStarting("connecting to the database")
+
+	response := checker.Check(context.Background())
+	assert.Equal(t, health.StatusStarting, response.Status)
+	assert.Equal(t, "connecting to the database", response.Checks["startup"].Message)
+
+	app := fiber.New()
+	app.Get("/health/ready", health.ReadinessHandler(checker))
+	resp, err := app.Test(httptest.NewRequest("GET", "/health/ready", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+
+	checker.Started()
+	resp, err = app.Test(httptest.NewRequest("GET", "/health/ready", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestServeStartup(t *testing.T) {
+	checker := health.NewHealthChecker()
+	checker.SetStarting("resolving secrets")
+	server, err := health.ServeStartup("127.0.0.1:0", checker)
+	require.NoError(t, err)
+	defer server.Close(context.Background())
+
+	for path, status := range map[string]int{
+		"/health/live":  http.StatusOK,
+		"/health/ready": http.StatusServiceUnavailable,
+		"/health":       http.StatusServiceUnavailable,
+	} {
+		resp, err := http.Get("http://" + server.Addr() + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, status, resp.StatusCode, path)
+	}
+
+	checker.Started()
+	resp, err := http.Get("http://" + server.Addr() + "/health/ready")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
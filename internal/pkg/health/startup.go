@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// StartupServer answers the health probes on the server's address while it connects to
+// its dependencies, before the API listens there: liveness succeeds, so a slow start
+// isn't mistaken for a hung process, and readiness reports the service as starting.
+type StartupServer struct {
+	server *http.Server
+	addr   net.Addr
+}
+
+// ServeStartup starts answering the health probes on addr with the checker's state
+func ServeStartup(addr string, healthChecker *HealthChecker) (*StartupServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health/live", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"status":    "alive",
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		})
+	})
+	ready := func(w http.ResponseWriter, r *http.Request) {
+		response := healthChecker.Check(r.Context())
+		status := http.StatusOK
+		if response.Status == StatusUnhealthy || response.Status == StatusStarting {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, response)
+	}
+	mux.HandleFunc("GET /health/ready", ready)
+	mux.HandleFunc("GET /health", ready)
+
+	s := &StartupServer{
+		server: &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second},
+		addr:   listener.Addr(),
+	}
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+	return s, nil
+}
+
+// Addr returns the address the probes are answered on
+func (s *StartupServer) Addr() string {
+	return s.addr.String()
+}
+
+// Close stops answering the probes, freeing the address for the API
+func (s *StartupServer) Close(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
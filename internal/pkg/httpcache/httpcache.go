@@ -0,0 +1,189 @@
+// Package httpcache keeps API responses in a store shared by every instance, keyed by
+// tenant and request, and drops them when writes make them stale.
+package httpcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"Krafti_Vibe/internal/infrastructure/cache"
+	"Krafti_Vibe/internal/pkg/metrics"
+	"Krafti_Vibe/internal/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Tags group cached responses by the data they show, so a write drops every response
+// it makes stale
+const (
+	TagServices     = "services"
+	TagAvailability = "availability"
+)
+
+// keyPrefix prefixes the keys of cached responses
+const keyPrefix = "httpcache"
+
+// DefaultTTL is how long responses are cached
+const DefaultTTL = time.Minute
+
+// DefaultMaxBodyBytes is the size of the largest response cached
+const DefaultMaxBodyBytes = 256 << 10
+
+// Store holds cached responses; typically the Redis client
+type Store interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value any, ttl time.Duration) error
+	DeletePattern(ctx context.Context, pattern string) error
+}
+
+// Invalidator drops cached responses made stale by a write
+type Invalidator interface {
+	// Invalidate drops the responses with the given tags cached for the tenant the
+	// context is scoped to, or for every tenant when it is scoped to none
+	Invalidate(ctx context.Context, tags ...string)
+}
+
+// Entry is a cached response
+type Entry struct {
+	Status       int       `json:"status"`
+	ContentType  string    `json:"content_type"`
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// Config holds configuration for a Cache
+type Config struct {
+	Store Store
+
+	// TTL bounds how long a response is served after a write that wasn't invalidated,
+	// such as one made directly in the database
+	TTL time.Duration
+
+	// MaxAge is how long clients may reuse a response without revalidating it. Zero
+	// has them revalidate every time, which costs a 304 when nothing changed.
+	MaxAge time.Duration
+
+	// MaxBodyBytes is the size of the largest response cached
+	MaxBodyBytes int
+
+	// Metrics records hits and misses when set
+	Metrics *metrics.PrometheusMetrics
+
+	Logger *zap.Logger
+}
+
+// Cache keeps responses by tag, tenant and request. Store failures are logged and
+// treated as misses, so an outage of the store never fails requests. A nil Cache
+// caches nothing.
+type Cache struct {
+	config Config
+}
+
+// New creates a new Cache
+func New(config Config) *Cache {
+	if config.TTL <= 0 {
+		config.TTL = DefaultTTL
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	return &Cache{config: config}
+}
+
+// MaxAge returns how long clients may reuse a response without revalidating it
+func (c *Cache) MaxAge() time.Duration {
+	return c.config.MaxAge
+}
+
+// Cacheable reports whether a response body is small enough to cache
+func (c *Cache) Cacheable(body []byte) bool {
+	return len(body) <= c.config.MaxBodyBytes
+}
+
+// key returns the key of a request's response; request identifies the request, such
+// as its path and query
+func key(tag string, tenantID uuid.UUID, request string) string {
+	sum := sha256.Sum256([]byte(request))
+	return keyPrefix + ":" + tag + ":" + tenantID.String() + ":" + hex.EncodeToString(sum[:16])
+}
+
+// Get returns the response cached for a tenant's request, or nil when there is none
+func (c *Cache) Get(ctx context.Context, tag string, tenantID uuid.UUID, request string) *Entry {
+	value, err := c.config.Store.Get(ctx, key(tag, tenantID, request))
+	if err != nil {
+		if !errors.Is(err, cache.ErrCacheMiss) {
+			c.config.Logger.Warn("failed to read cached response", zap.String("tag", tag), zap.Error(err))
+		}
+		c.record(tag, false)
+		return nil
+	}
+
+	var entry Entry
+	if err := json.Unmarshal([]byte(value), &entry); err != nil {
+		c.config.Logger.Warn("discarding malformed cached response", zap.String("tag", tag), zap.Error(err))
+		c.record(tag, false)
+		return nil
+	}
+	c.record(tag, true)
+	return &entry
+}
+
+// Put caches a tenant's response to a request
+func (c *Cache) Put(ctx context.Context, tag string, tenantID uuid.UUID, request string, entry *Entry) {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := c.config.Store.Set(ctx, key(tag, tenantID, request), value, c.config.TTL); err != nil {
+		c.config.Logger.Warn("failed to cache response", zap.String("tag", tag), zap.Error(err))
+	}
+}
+
+// Invalidate drops the responses with the given tags cached for the tenant the
+// context is scoped to, or for every tenant when it is scoped to none
+func (c *Cache) Invalidate(ctx context.Context, tags ...string) {
+	if c == nil {
+		return
+	}
+
+	tenant := "*"
+	if tenantID, ok := tenancy.FromContext(ctx); ok {
+		tenant = tenantID.String()
+	}
+	for _, tag := range tags {
+		if err := c.config.Store.DeletePattern(ctx, keyPrefix+":"+tag+":"+tenant+":*"); err != nil {
+			c.config.Logger.Error("failed to invalidate cached responses",
+				zap.String("tag", tag),
+				zap.String("tenant_id", tenant),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// record records a lookup
+func (c *Cache) record(tag string, hit bool) {
+	if c.config.Metrics == nil {
+		return
+	}
+	if hit {
+		c.config.Metrics.RecordCacheHit("http_" + tag)
+	} else {
+		c.config.Metrics.RecordCacheMiss("http_" + tag)
+	}
+}
+
+// ETag returns a strong entity tag of a response body
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
@@ -0,0 +1,112 @@
+package httpcache_test
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/infrastructure/cache"
+	"Krafti_Vibe/internal/pkg/httpcache"
+	"Krafti_Vibe/internal/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore is an in-memory Store
+type memoryStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{values: make(map[string]string)}
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[key]
+	if !ok {
+		return "", cache.ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (s *memoryStore) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = fmt.Sprintf("%s", value)
+	return nil
+}
+
+func (s *memoryStore) DeletePattern(ctx context.Context, pattern string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.values {
+		if ok, _ := path.Match(pattern, key); ok {
+			delete(s.values, key)
+		}
+	}
+	return nil
+}
+
+func entry(body string) *httpcache.Entry {
+	return &httpcache.Entry{
+		Status:       200,
+		ContentType:  "application/json",
+		Body:         []byte(body),
+		ETag:         httpcache.ETag([]byte(body)),
+		LastModified: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	responses := httpcache.New(httpcache.Config{Store: newMemoryStore()})
+	tenantID := uuid.New()
+
+	assert.Nil(t, responses.Get(ctx, httpcache.TagServices, tenantID, "/api/v1/services/search?q=tile"))
+
+	responses.Put(ctx, httpcache.TagServices, tenantID, "/api/v1/services/search?q=tile", entry(`{"data":[]}`))
+	got := responses.Get(ctx, httpcache.TagServices, tenantID, "/api/v1/services/search?q=tile")
+	require.NotNil(t, got)
+	assert.Equal(t, entry(`{"data":[]}`), got)
+
+	assert.Nil(t, responses.Get(ctx, httpcache.TagServices, uuid.New(), "/api/v1/services/search?q=tile"), "responses are cached by tenant")
+	assert.Nil(t, responses.Get(ctx, httpcache.TagAvailability, tenantID, "/api/v1/services/search?q=tile"), "responses are cached by tag")
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	responses := httpcache.New(httpcache.Config{Store: newMemoryStore()})
+	first, second := uuid.New(), uuid.New()
+	for _, tenantID := range []uuid.UUID{first, second} {
+		responses.Put(ctx, httpcache.TagServices, tenantID, "/api/v1/services/popular", entry(`[]`))
+		responses.Put(ctx, httpcache.TagAvailability, tenantID, "/api/v1/availability", entry(`[]`))
+	}
+
+	// A write scoped to a tenant drops only that tenant's responses with the tag
+	responses.Invalidate(tenancy.WithTenant(ctx, first), httpcache.TagServices)
+	assert.Nil(t, responses.Get(ctx, httpcache.TagServices, first, "/api/v1/services/popular"))
+	assert.NotNil(t, responses.Get(ctx, httpcache.TagServices, second, "/api/v1/services/popular"))
+	assert.NotNil(t, responses.Get(ctx, httpcache.TagAvailability, first, "/api/v1/availability"))
+
+	// An unscoped write drops every tenant's
+	responses.Invalidate(ctx, httpcache.TagAvailability)
+	assert.Nil(t, responses.Get(ctx, httpcache.TagAvailability, first, "/api/v1/availability"))
+	assert.Nil(t, responses.Get(ctx, httpcache.TagAvailability, second, "/api/v1/availability"))
+
+	var disabled *httpcache.Cache
+	assert.NotPanics(t, func() { disabled.Invalidate(ctx, httpcache.TagServices) })
+}
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, httpcache.ETag([]byte("a")), httpcache.ETag([]byte("a")))
+	assert.NotEqual(t, httpcache.ETag([]byte("a")), httpcache.ETag([]byte("b")))
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, httpcache.ETag([]byte("a")))
+}
@@ -0,0 +1,207 @@
+// Package i18n translates API messages and notification content. Each locale has a
+// catalog of messages keyed by dotted names, with {name} placeholders for arguments;
+// a Localizer looks messages up through a chain of locales, ending with English, so a
+// message missing from a catalog falls back to the next locale that has it.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is the locale every chain ends with, whose catalog has every message
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs holds the messages of each supported locale
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: failed to read catalogs: %v", err))
+	}
+
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: failed to read catalog %s: %v", entry.Name(), err))
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	if _, ok := loaded[DefaultLocale]; !ok {
+		panic("i18n: missing catalog of the default locale")
+	}
+	return loaded
+}
+
+// Args are the values of a message's placeholders
+type Args map[string]any
+
+// Supported returns the locales there are catalogs of, sorted
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// IsSupported reports whether there is a catalog of the locale
+func IsSupported(locale string) bool {
+	_, ok := catalogs[Normalize(locale)]
+	return ok
+}
+
+// Normalize lowercases a language tag and separates its subtags with hyphens, so
+// pt_BR and pt-br are the same locale
+func Normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language header, most
+// preferred first. Tags with a zero quality and the wildcard are left out.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weighted
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = Normalize(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for param := range strings.SplitSeq(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.TrimSpace(name) == "q" {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			tags = append(tags, weighted{tag: tag, quality: quality})
+		}
+	}
+
+	// Tags of equal quality keep the order they were listed in
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.tag
+	}
+	return result
+}
+
+// Localizer looks messages up through a chain of supported locales
+type Localizer struct {
+	locales []string
+}
+
+// New creates a localizer looking messages up in the given locales, most preferred
+// first. Each locale is followed by its base language, so es-MX falls back to es;
+// unsupported locales and blanks are skipped, and the chain ends with the default
+// locale.
+func New(locales ...string) Localizer {
+	var chain []string
+	add := func(locale string) {
+		if _, ok := catalogs[locale]; ok && !slices.Contains(chain, locale) {
+			chain = append(chain, locale)
+		}
+	}
+	for _, locale := range locales {
+		locale = Normalize(locale)
+		add(locale)
+		if base, _, ok := strings.Cut(locale, "-"); ok {
+			add(base)
+		}
+	}
+	add(DefaultLocale)
+	return Localizer{locales: chain}
+}
+
+// Locale returns the most preferred supported locale of the chain
+func (l Localizer) Locale() string {
+	if len(l.locales) == 0 {
+		return DefaultLocale
+	}
+	return l.locales[0]
+}
+
+// Locales returns the chain messages are looked up through
+func (l Localizer) Locales() []string {
+	if len(l.locales) == 0 {
+		return []string{DefaultLocale}
+	}
+	return slices.Clone(l.locales)
+}
+
+// Lookup returns the message of the first locale of the chain that has it, with its
+// placeholders replaced by args
+func (l Localizer) Lookup(key string, args Args) (string, bool) {
+	for _, locale := range l.Locales() {
+		if message, ok := catalogs[locale][key]; ok {
+			return l.format(message, args), true
+		}
+	}
+	return "", false
+}
+
+// T returns the message of the key, or the key itself when no catalog has it
+func (l Localizer) T(key string, args Args) string {
+	if message, ok := l.Lookup(key, args); ok {
+		return message
+	}
+	return key
+}
+
+// FormatTime formats a time as the locale writes a date and time
+func (l Localizer) FormatTime(t time.Time) string {
+	return t.Format(l.T("format.datetime", nil))
+}
+
+// format replaces the placeholders of a message; times are written as the locale
+// writes them and placeholders without an argument are left as they are
+func (l Localizer) format(message string, args Args) string {
+	if len(args) == 0 || !strings.Contains(message, "{") {
+		return message
+	}
+
+	replacements := make([]string, 0, 2*len(args))
+	for name, value := range args {
+		var text string
+		switch v := value.(type) {
+		case time.Time:
+			text = l.FormatTime(v)
+		case *time.Time:
+			if v != nil {
+				text = l.FormatTime(*v)
+			}
+		default:
+			text = fmt.Sprint(v)
+		}
+		replacements = append(replacements, "{"+name+"}", text)
+	}
+	return strings.NewReplacer(replacements...).Replace(message)
+}
@@ -0,0 +1,87 @@
+package i18n_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "", want: []string{}},
+		{header: "fr-CA", want: []string{"fr-ca"}},
+		{header: "en;q=0.5, es-MX, fr;q=0.8", want: []string{"es-mx", "fr", "en"}},
+		{header: "de, *;q=0.5, es;q=0", want: []string{"de"}},
+		{header: "pt_BR ; q=0.9 , it", want: []string{"it", "pt-br"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, i18n.ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		locales []string
+		want    []string
+	}{
+		{name: "none", want: []string{"en"}},
+		{name: "region falls back to its language", locales: []string{"es-MX"}, want: []string{"es", "en"}},
+		{name: "unsupported are skipped", locales: []string{"de", "", "fr_CA", "es"}, want: []string{"fr", "es", "en"}},
+		{name: "duplicates", locales: []string{"en", "fr", "EN"}, want: []string{"en", "fr"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, i18n.New(tt.locales...).Locales())
+		})
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+	start := time.Date(2025, 6, 1, 14, 30, 0, 0, time.UTC)
+	args := i18n.Args{"ref": "a1b2c3d4", "start": start}
+
+	english := i18n.New()
+	assert.Equal(t, "Reminder: Your booking is scheduled for Jun 1, 2025 at 2:30 PM", english.T("notifications.booking_reminder.message", args))
+
+	spanish := i18n.New("es")
+	assert.Equal(t, "Recordatorio: tu reserva está programada para el 01/06/2025 14:30", spanish.T("notifications.booking_reminder.message", args))
+	assert.Equal(t, "Nueva reserva creada", spanish.T("notifications.booking_created.title", nil))
+
+	_, ok := spanish.Lookup("status.418", nil)
+	assert.False(t, ok)
+	assert.Equal(t, "missing.key", spanish.T("missing.key", nil), "unknown keys come back as they are")
+	assert.Equal(t, "{field} is required", english.T("validation.required", i18n.Args{"param": "x"}), "placeholders without an argument are kept")
+}
+
+func TestCatalogsHaveEveryMessage(t *testing.T) {
+	load := func(locale string) map[string]string {
+		data, err := os.ReadFile(filepath.Join("locales", locale+".json"))
+		require.NoError(t, err)
+		var messages map[string]string
+		require.NoError(t, json.Unmarshal(data, &messages))
+		return messages
+	}
+
+	english := load(i18n.DefaultLocale)
+	for _, locale := range i18n.Supported() {
+		messages := load(locale)
+		for key := range english {
+			assert.Contains(t, messages, key, "%s is missing from the %s catalog", key, locale)
+		}
+	}
+}
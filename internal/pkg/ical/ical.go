@@ -0,0 +1,207 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ContentType is the MIME type for iCalendar payloads
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	lineBreak     = "\r\n"
+	maxLineLength = 75
+	dateTimeUTC   = "20060102T150405Z"
+)
+
+// Method is the iTIP method of a calendar object
+type Method string
+
+const (
+	MethodPublish Method = "PUBLISH"
+	MethodRequest Method = "REQUEST"
+	MethodCancel  Method = "CANCEL"
+)
+
+// EventStatus is the VEVENT STATUS property
+type EventStatus string
+
+const (
+	EventStatusTentative EventStatus = "TENTATIVE"
+	EventStatusConfirmed EventStatus = "CONFIRMED"
+	EventStatusCancelled EventStatus = "CANCELLED"
+)
+
+// Attendee represents an ATTENDEE or ORGANIZER of an event
+type Attendee struct {
+	Name  string
+	Email string
+}
+
+// Event represents a single VEVENT
+type Event struct {
+	UID         string
+	Sequence    int
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	Created     time.Time
+	Modified    time.Time
+	Status      EventStatus
+	Organizer   *Attendee
+	Attendees   []Attendee
+}
+
+// Calendar represents a VCALENDAR containing events
+type Calendar struct {
+	ProductID string
+	Name      string
+	Method    Method
+	Events    []Event
+}
+
+// NewCalendar creates a new calendar with the given product ID and display name
+func NewCalendar(productID, name string) *Calendar {
+	return &Calendar{
+		ProductID: productID,
+		Name:      name,
+		Method:    MethodPublish,
+	}
+}
+
+// AddEvent appends an event to the calendar
+func (c *Calendar) AddEvent(event Event) {
+	c.Events = append(c.Events, event)
+}
+
+// Bytes encodes the calendar as an RFC 5545 document
+func (c *Calendar) Bytes() []byte {
+	w := &writer{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", c.ProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		w.line("METHOD", string(c.Method))
+	}
+	if c.Name != "" {
+		w.text("X-WR-CALNAME", c.Name)
+	}
+
+	stamp := time.Now().UTC()
+	for _, event := range c.Events {
+		w.event(event, stamp)
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// String encodes the calendar as a string
+func (c *Calendar) String() string {
+	return string(c.Bytes())
+}
+
+// ============================================================================
+// Encoding Helpers
+// ============================================================================
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+func (w *writer) event(e Event, stamp time.Time) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", e.UID)
+	w.line("DTSTAMP", formatTime(stamp))
+	w.line("DTSTART", formatTime(e.Start))
+	w.line("DTEND", formatTime(e.End))
+	w.line("SEQUENCE", fmt.Sprintf("%d", e.Sequence))
+	if !e.Created.IsZero() {
+		w.line("CREATED", formatTime(e.Created))
+	}
+	if !e.Modified.IsZero() {
+		w.line("LAST-MODIFIED", formatTime(e.Modified))
+	}
+	w.text("SUMMARY", e.Summary)
+	if e.Description != "" {
+		w.text("DESCRIPTION", e.Description)
+	}
+	if e.Location != "" {
+		w.text("LOCATION", e.Location)
+	}
+	if e.URL != "" {
+		w.line("URL", e.URL)
+	}
+	if e.Status != "" {
+		w.line("STATUS", string(e.Status))
+	}
+	if e.Organizer != nil && e.Organizer.Email != "" {
+		w.line("ORGANIZER"+nameParam(e.Organizer.Name), "mailto:"+e.Organizer.Email)
+	}
+	for _, a := range e.Attendees {
+		if a.Email == "" {
+			continue
+		}
+		w.line("ATTENDEE"+nameParam(a.Name), "mailto:"+a.Email)
+	}
+	w.line("END", "VEVENT")
+}
+
+// text writes a property whose value is TEXT and must be escaped
+func (w *writer) text(name, value string) {
+	w.line(name, EscapeText(value))
+}
+
+// line writes a content line, folding it at 75 octets
+func (w *writer) line(name, value string) {
+	content := name + ":" + value
+	limit := maxLineLength
+	for len(content) > limit {
+		cut := limit
+		// Never split a multi-byte UTF-8 sequence
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString(lineBreak)
+		w.buf.WriteString(" ")
+		content = content[cut:]
+		// Continuation lines start with a space that counts towards the limit
+		limit = maxLineLength - 1
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString(lineBreak)
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func nameParam(name string) string {
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf(";CN=\"%s\"", strings.ReplaceAll(name, "\"", "'"))
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeUTC)
+}
+
+// EscapeText escapes a TEXT value as described in RFC 5545 section 3.3.11
+func EscapeText(s string) string {
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\r\n", "\\n",
+		"\n", "\\n",
+	)
+	return replacer.Replace(s)
+}
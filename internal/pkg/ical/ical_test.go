@@ -0,0 +1,57 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/ical"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarBytes(t *testing.T) {
+	start := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+
+	cal := ical.NewCalendar("-//Test//EN", "Bookings")
+	cal.AddEvent(ical.Event{
+		UID:         "booking-1@test",
+		Summary:     "Haircut, wash; style",
+		Description: strings.Repeat("long description ", 10),
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Status:      ical.EventStatusConfirmed,
+		Attendees:   []ical.Attendee{{Name: "Jane Doe", Email: "jane@example.com"}},
+	})
+
+	out := cal.String()
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "DTSTART:20250314T093000Z\r\n")
+	assert.Contains(t, out, "DTEND:20250314T103000Z\r\n")
+	assert.Contains(t, out, `SUMMARY:Haircut\, wash\; style`)
+	assert.Contains(t, out, `ATTENDEE;CN="Jane Doe":mailto:jane@example.com`)
+
+	for _, line := range strings.Split(out, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "line not folded: %q", line)
+	}
+}
+
+func TestFeedSigner(t *testing.T) {
+	signer := ical.NewFeedSigner("secret")
+	id := uuid.New()
+
+	token, err := signer.Sign(id, 1)
+	require.NoError(t, err)
+
+	assert.NoError(t, signer.Verify(id, 1, token))
+	assert.ErrorIs(t, signer.Verify(uuid.New(), 1, token), ical.ErrInvalidToken)
+	assert.ErrorIs(t, signer.Verify(id, 2, token), ical.ErrInvalidToken, "rotating the version revokes the token")
+	assert.ErrorIs(t, ical.NewFeedSigner("other").Verify(id, 1, token), ical.ErrInvalidToken)
+
+	_, err = ical.NewFeedSigner("").Sign(id, 1)
+	assert.ErrorIs(t, err, ical.ErrMissingSecret)
+}
@@ -0,0 +1,129 @@
+// Package lifecycle runs the application's long-lived subsystems, such as the HTTP
+// server and the background job scheduler, and stops them together.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// hook stops a subsystem at shutdown
+type hook struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Manager runs subsystems until shutdown and drains them within its deadline.
+//
+// A subsystem started with Go runs until its context is cancelled. One that fails
+// cancels the others and closes Done, so the application shuts down rather than limp
+// along without it. Subsystems that aren't stopped by cancellation, such as the HTTP
+// server, register a stop hook with OnStop instead.
+type Manager struct {
+	logger *zap.Logger
+	group  *errgroup.Group
+	ctx    context.Context // Cancelled at shutdown, or when a subsystem fails
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	hooks   []hook
+	running map[string]int
+}
+
+// New creates a new Manager
+func New(logger *zap.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	group, ctx := errgroup.WithContext(ctx)
+	return &Manager{
+		logger:  logger,
+		group:   group,
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]int),
+	}
+}
+
+// Go runs a subsystem in its own goroutine until its context is cancelled. An error it
+// returns before then fails the application.
+func (m *Manager) Go(name string, run func(ctx context.Context) error) {
+	m.mu.Lock()
+	m.running[name]++
+	m.mu.Unlock()
+
+	m.group.Go(func() error {
+		defer func() {
+			m.mu.Lock()
+			if m.running[name]--; m.running[name] == 0 {
+				delete(m.running, name)
+			}
+			m.mu.Unlock()
+		}()
+
+		err := run(m.ctx)
+		if err != nil && m.ctx.Err() == nil {
+			m.logger.Error("subsystem failed", zap.String("subsystem", name), zap.Error(err))
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		m.logger.Debug("subsystem stopped", zap.String("subsystem", name))
+		return nil
+	})
+}
+
+// OnStop registers a function stopping a subsystem at shutdown. Hooks run one at a
+// time in the reverse order of registration, so a subsystem registered after those it
+// depends on stops before them.
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, stop: stop})
+}
+
+// Done is closed when a subsystem fails or shutdown begins
+func (m *Manager) Done() <-chan struct{} {
+	return m.ctx.Done()
+}
+
+// Shutdown runs the stop hooks, then cancels the subsystems and waits for them to
+// return, all within the context's deadline. It reports the first subsystem that
+// failed along with any hook that did, and the subsystems still running if the
+// deadline passed.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	hooks := slices.Clone(m.hooks)
+	m.mu.Unlock()
+
+	var errs []error
+	for _, h := range slices.Backward(hooks) {
+		m.logger.Info("stopping subsystem", zap.String("subsystem", h.name))
+		if err := h.stop(ctx); err != nil {
+			m.logger.Error("failed to stop subsystem", zap.String("subsystem", h.name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("stopping %s: %w", h.name, err))
+		}
+	}
+
+	m.cancel()
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- m.group.Wait()
+	}()
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			errs = append([]error{err}, errs...)
+		}
+	case <-ctx.Done():
+		m.mu.Lock()
+		running := slices.Sorted(maps.Keys(m.running))
+		m.mu.Unlock()
+		errs = append(errs, fmt.Errorf("%v still running: %w", running, ctx.Err()))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/lifecycle"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestShutdownStopsHooksInReverseThenSubsystems(t *testing.T) {
+	m := lifecycle.New(zap.NewNop())
+
+	var order []string
+	m.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		order = append(order, "worker")
+		return ctx.Err()
+	})
+	m.OnStop("server", func(ctx context.Context) error {
+		order = append(order, "server")
+		return nil
+	})
+	m.OnStop("readiness", func(ctx context.Context) error {
+		order = append(order, "readiness")
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, m.Shutdown(ctx))
+	assert.Equal(t, []string{"readiness", "server", "worker"}, order)
+}
+
+func TestFailedSubsystemTriggersShutdown(t *testing.T) {
+	m := lifecycle.New(zap.NewNop())
+
+	m.Go("server", func(ctx context.Context) error {
+		return errors.New("address already in use")
+	})
+	m.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("failure did not close Done")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := m.Shutdown(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server: address already in use")
+}
+
+func TestShutdownDeadlineReportsRunningSubsystems(t *testing.T) {
+	m := lifecycle.New(zap.NewNop())
+
+	release := make(chan struct{})
+	defer close(release)
+	m.Go("scheduler", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := m.Shutdown(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "[scheduler] still running")
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 
+	"Krafti_Vibe/internal/pkg/tracing"
+
 	"github.com/gofiber/fiber/v2/log"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -12,6 +14,9 @@ import (
 
 var (
 	globalLogger *zap.Logger
+
+	// globalLevel is the level of the global logger, which can change while it runs
+	globalLevel = zap.NewAtomicLevel()
 )
 
 // Initialize initializes the global logger
@@ -53,10 +58,11 @@ func Initialize(level string, environment string) (*zap.Logger, error) {
 	}
 
 	// Create core
+	globalLevel.SetLevel(zapLevel)
 	core := zapcore.NewCore(
 		encoder,
 		zapcore.AddSync(os.Stdout),
-		zapLevel,
+		globalLevel,
 	)
 
 	// Create logger with options
@@ -75,6 +81,17 @@ func Initialize(level string, environment string) (*zap.Logger, error) {
 	return logger, nil
 }
 
+// SetLevel changes the level of the global logger, such as when the configuration is
+// reloaded
+func SetLevel(name string) error {
+	zapLevel, err := zapcore.ParseLevel(name)
+	if err != nil {
+		return err
+	}
+	globalLevel.SetLevel(zapLevel)
+	return nil
+}
+
 // Get returns the global logger instance
 func Get() *zap.Logger {
 	if globalLogger == nil {
@@ -219,9 +236,12 @@ func (l *FiberLogger) Tracew(msg string, keysAndValues ...interface{}) {
 	l.logger.Sugar().Debugw(msg, keysAndValues...)
 }
 
-// WithContext returns a logger with context (zap doesn't use context in the same way, so we just return the same logger)
+// WithContext returns a logger adding the IDs of the context's trace and span to its
+// entries, or the same logger when the context is not traced
 func (l *FiberLogger) WithContext(ctx context.Context) log.CommonLogger {
-	// Zap doesn't use context in the same way as some other loggers
-	// In a more advanced implementation, you could extract trace IDs from context
-	return l
+	fields := tracing.Fields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return &FiberLogger{logger: l.logger.With(fields...)}
 }
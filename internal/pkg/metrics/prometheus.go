@@ -1,7 +1,9 @@
 package metrics
 
 import (
+	"database/sql"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -21,6 +23,13 @@ type PrometheusMetrics struct {
 	HTTPResponseSize     *prometheus.SummaryVec
 	HTTPRequestsInFlight prometheus.Gauge
 	HTTPErrorsTotal      *prometheus.CounterVec
+	HTTPRateLimited      *prometheus.CounterVec
+	HTTPPanicsTotal      *prometheus.CounterVec
+	HTTPRequestsShed     *prometheus.CounterVec
+	HTTPConcurrencyLimit *prometheus.GaugeVec
+	HTTPRequestsActive   *prometheus.GaugeVec
+	HTTPBudgetExceeded   *prometheus.CounterVec
+	ProfilesCaptured     *prometheus.CounterVec
 
 	// Database metrics
 	DBQueriesTotal      *prometheus.CounterVec
@@ -28,8 +37,14 @@ type PrometheusMetrics struct {
 	DBConnectionsActive prometheus.Gauge
 	DBConnectionsIdle   prometheus.Gauge
 	DBConnectionsMax    prometheus.Gauge
+	DBConnectionsOpen   prometheus.Gauge
+	DBConnectionWaits   prometheus.Counter
+	DBConnectionWaited  prometheus.Counter
+	DBPoolSaturated     prometheus.Gauge
 	DBTransactionsTotal *prometheus.CounterVec
 	DBErrorsTotal       *prometheus.CounterVec
+	DBSlowQueriesTotal  *prometheus.CounterVec
+	DBQueriesOverBudget *prometheus.CounterVec
 
 	// Cache metrics
 	CacheHitsTotal         *prometheus.CounterVec
@@ -37,6 +52,7 @@ type PrometheusMetrics struct {
 	CacheOperationDuration *prometheus.HistogramVec
 	CacheSize              prometheus.Gauge
 	CacheEvictions         *prometheus.CounterVec
+	CacheHitRatio          *prometheus.GaugeVec
 
 	// Business metrics
 	BookingsTotal        *prometheus.CounterVec
@@ -70,6 +86,7 @@ type PrometheusMetrics struct {
 	JobDuration  *prometheus.HistogramVec
 	JobFailures  *prometheus.CounterVec
 	JobsQueued   prometheus.Gauge
+	QueueDepth   *prometheus.GaugeVec
 
 	// System metrics
 	GoroutinesCount prometheus.Gauge
@@ -80,6 +97,15 @@ type PrometheusMetrics struct {
 
 	registry *prometheus.Registry
 	logger   *zap.Logger
+
+	// Lookups of each cache type since startup, from which its hit ratio is kept
+	cacheLookupsMu sync.Mutex
+	cacheLookups   map[string]*cacheLookups
+}
+
+// cacheLookups counts the hits and misses of a cache type
+type cacheLookups struct {
+	hits, misses uint64
 }
 
 // NewPrometheusMetrics creates and registers all Prometheus metrics
@@ -87,8 +113,9 @@ func NewPrometheusMetrics(namespace string, logger *zap.Logger) *PrometheusMetri
 	registry := prometheus.NewRegistry()
 
 	pm := &PrometheusMetrics{
-		registry: registry,
-		logger:   logger,
+		registry:     registry,
+		logger:       logger,
+		cacheLookups: make(map[string]*cacheLookups),
 
 		// HTTP metrics
 		HTTPRequestsTotal: promauto.With(registry).NewCounterVec(
@@ -141,6 +168,68 @@ func NewPrometheusMetrics(namespace string, logger *zap.Logger) *PrometheusMetri
 			},
 			[]string{"method", "path", "error_type"},
 		),
+		HTTPRateLimited: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "http_rate_limited_total",
+				Help:      "Total number of HTTP requests refused with 429 by the rate limiter",
+			},
+			[]string{"scope", "tier"},
+		),
+
+		HTTPPanicsTotal: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "http_panics_total",
+				Help:      "Total number of panics recovered while serving HTTP requests",
+			},
+			[]string{"method", "path"},
+		),
+
+		HTTPRequestsShed: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "http_requests_shed_total",
+				Help:      "Total number of HTTP requests refused with 503 by the load shedder",
+			},
+			[]string{"class", "reason"},
+		),
+
+		HTTPConcurrencyLimit: promauto.With(registry).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "http_concurrency_limit",
+				Help:      "Number of HTTP requests of a route class served at once",
+			},
+			[]string{"class"},
+		),
+
+		HTTPRequestsActive: promauto.With(registry).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "http_requests_active",
+				Help:      "Number of HTTP requests of a route class being served",
+			},
+			[]string{"class"},
+		),
+
+		HTTPBudgetExceeded: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "http_latency_budget_exceeded_total",
+				Help:      "Total number of HTTP requests slower than their route's latency budget",
+			},
+			[]string{"method", "path"},
+		),
+
+		ProfilesCaptured: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "profiles_captured_total",
+				Help:      "Total number of CPU and heap profiles captured and uploaded",
+			},
+			[]string{"kind", "reason", "status"},
+		),
 
 		// Database metrics
 		DBQueriesTotal: promauto.With(registry).NewCounterVec(
@@ -181,6 +270,34 @@ func NewPrometheusMetrics(namespace string, logger *zap.Logger) *PrometheusMetri
 				Help:      "Maximum number of database connections",
 			},
 		),
+		DBConnectionsOpen: promauto.With(registry).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "db_connections_open",
+				Help:      "Current number of open database connections, in use or idle",
+			},
+		),
+		DBConnectionWaits: promauto.With(registry).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "db_connection_waits_total",
+				Help:      "Total number of times a request waited for a free database connection",
+			},
+		),
+		DBConnectionWaited: promauto.With(registry).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "db_connection_wait_seconds_total",
+				Help:      "Total time requests spent waiting for a free database connection",
+			},
+		),
+		DBPoolSaturated: promauto.With(registry).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "db_pool_saturated",
+				Help:      "Whether the database connection pool is saturated (1) or not (0)",
+			},
+		),
 		DBTransactionsTotal: promauto.With(registry).NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: namespace,
@@ -197,6 +314,22 @@ func NewPrometheusMetrics(namespace string, logger *zap.Logger) *PrometheusMetri
 			},
 			[]string{"operation", "error_type"},
 		),
+		DBSlowQueriesTotal: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "db_slow_queries_total",
+				Help:      "Total number of database queries slower than the slow query threshold",
+			},
+			[]string{"operation", "table"},
+		),
+		DBQueriesOverBudget: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "db_queries_over_budget_total",
+				Help:      "Total number of database queries that ran past their time budget",
+			},
+			[]string{"operation", "table"},
+		),
 
 		// Cache metrics
 		CacheHitsTotal: promauto.With(registry).NewCounterVec(
@@ -239,6 +372,14 @@ func NewPrometheusMetrics(namespace string, logger *zap.Logger) *PrometheusMetri
 			},
 			[]string{"reason"},
 		),
+		CacheHitRatio: promauto.With(registry).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "cache_hit_ratio",
+				Help:      "Share of cache lookups served from the cache since startup",
+			},
+			[]string{"cache_type"},
+		),
 
 		// Business metrics
 		BookingsTotal: promauto.With(registry).NewCounterVec(
@@ -430,6 +571,14 @@ func NewPrometheusMetrics(namespace string, logger *zap.Logger) *PrometheusMetri
 				Help:      "Current number of jobs in queue",
 			},
 		),
+		QueueDepth: promauto.With(registry).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "queue_depth",
+				Help:      "Current number of items awaiting processing, by queue",
+			},
+			[]string{"queue"},
+		),
 
 		// System metrics
 		GoroutinesCount: promauto.With(registry).NewGauge(
@@ -507,6 +656,40 @@ func (pm *PrometheusMetrics) RecordHTTPError(method, path, errorType string) {
 	pm.HTTPErrorsTotal.WithLabelValues(method, path, errorType).Inc()
 }
 
+// RecordHTTPRateLimited records a request refused by the rate limiter; scope is what
+// the caller was identified by, such as tenant or ip, and tier the limit applied
+func (pm *PrometheusMetrics) RecordHTTPRateLimited(scope, tier string) {
+	pm.HTTPRateLimited.WithLabelValues(scope, tier).Inc()
+}
+
+// RecordHTTPPanic records a panic recovered while serving a request to a route
+func (pm *PrometheusMetrics) RecordHTTPPanic(method, path string) {
+	pm.HTTPPanicsTotal.WithLabelValues(method, path).Inc()
+}
+
+// RecordHTTPShed records a request refused by the load shedder; reason is why it got
+// no slot, queue_full or queue_timeout
+func (pm *PrometheusMetrics) RecordHTTPShed(class, reason string) {
+	pm.HTTPRequestsShed.WithLabelValues(class, reason).Inc()
+}
+
+// SetHTTPConcurrency records a route class's concurrency limit and requests being served
+func (pm *PrometheusMetrics) SetHTTPConcurrency(class string, limit, active int) {
+	pm.HTTPConcurrencyLimit.WithLabelValues(class).Set(float64(limit))
+	pm.HTTPRequestsActive.WithLabelValues(class).Set(float64(active))
+}
+
+// RecordHTTPBudgetExceeded records a request slower than its route's latency budget
+func (pm *PrometheusMetrics) RecordHTTPBudgetExceeded(method, path string) {
+	pm.HTTPBudgetExceeded.WithLabelValues(method, path).Inc()
+}
+
+// RecordProfileCaptured records a profile captured for reason, continuous or
+// latency_budget; status is whether it was uploaded, success or error
+func (pm *PrometheusMetrics) RecordProfileCaptured(kind, reason, status string) {
+	pm.ProfilesCaptured.WithLabelValues(kind, reason, status).Inc()
+}
+
 // IncHTTPRequestsInFlight increments in-flight requests
 func (pm *PrometheusMetrics) IncHTTPRequestsInFlight() {
 	pm.HTTPRequestsInFlight.Inc()
@@ -528,6 +711,16 @@ func (pm *PrometheusMetrics) RecordDBError(operation, errorType string) {
 	pm.DBErrorsTotal.WithLabelValues(operation, errorType).Inc()
 }
 
+// RecordDBSlowQuery records a query slower than the slow query threshold
+func (pm *PrometheusMetrics) RecordDBSlowQuery(operation, table string) {
+	pm.DBSlowQueriesTotal.WithLabelValues(operation, table).Inc()
+}
+
+// RecordDBQueryOverBudget records a query that ran past its time budget
+func (pm *PrometheusMetrics) RecordDBQueryOverBudget(operation, table string) {
+	pm.DBQueriesOverBudget.WithLabelValues(operation, table).Inc()
+}
+
 // UpdateDBConnectionStats updates database connection pool statistics
 func (pm *PrometheusMetrics) UpdateDBConnectionStats(active, idle, max int) {
 	pm.DBConnectionsActive.Set(float64(active))
@@ -535,14 +728,48 @@ func (pm *PrometheusMetrics) UpdateDBConnectionStats(active, idle, max int) {
 	pm.DBConnectionsMax.Set(float64(max))
 }
 
+// UpdateDBPoolStats records a sample of the database connection pool, with the waits
+// for a connection since the previous sample
+func (pm *PrometheusMetrics) UpdateDBPoolStats(stats sql.DBStats, newWaits int64, newWaitDuration time.Duration, saturated bool) {
+	pm.UpdateDBConnectionStats(stats.InUse, stats.Idle, stats.MaxOpenConnections)
+	pm.DBConnectionsOpen.Set(float64(stats.OpenConnections))
+	pm.DBConnectionWaits.Add(float64(newWaits))
+	pm.DBConnectionWaited.Add(newWaitDuration.Seconds())
+	if saturated {
+		pm.DBPoolSaturated.Set(1)
+	} else {
+		pm.DBPoolSaturated.Set(0)
+	}
+}
+
 // RecordCacheHit records a cache hit
 func (pm *PrometheusMetrics) RecordCacheHit(cacheType string) {
 	pm.CacheHitsTotal.WithLabelValues(cacheType).Inc()
+	pm.recordCacheLookup(cacheType, true)
 }
 
 // RecordCacheMiss records a cache miss
 func (pm *PrometheusMetrics) RecordCacheMiss(cacheType string) {
 	pm.CacheMissesTotal.WithLabelValues(cacheType).Inc()
+	pm.recordCacheLookup(cacheType, false)
+}
+
+// recordCacheLookup counts a lookup and updates the cache type's hit ratio
+func (pm *PrometheusMetrics) recordCacheLookup(cacheType string, hit bool) {
+	pm.cacheLookupsMu.Lock()
+	defer pm.cacheLookupsMu.Unlock()
+
+	lookups, ok := pm.cacheLookups[cacheType]
+	if !ok {
+		lookups = &cacheLookups{}
+		pm.cacheLookups[cacheType] = lookups
+	}
+	if hit {
+		lookups.hits++
+	} else {
+		lookups.misses++
+	}
+	pm.CacheHitRatio.WithLabelValues(cacheType).Set(float64(lookups.hits) / float64(lookups.hits+lookups.misses))
 }
 
 // RecordCacheOperation records cache operation duration
@@ -608,6 +835,12 @@ func (pm *PrometheusMetrics) RecordJobFailure(jobType, reason string) {
 	pm.JobFailures.WithLabelValues(jobType, reason).Inc()
 }
 
+// SetQueueDepth records how many items a queue holds, such as domain events awaiting
+// delivery
+func (pm *PrometheusMetrics) SetQueueDepth(queue string, depth int64) {
+	pm.QueueDepth.WithLabelValues(queue).Set(float64(depth))
+}
+
 // UpdateTenantsTotal updates total tenants gauge
 func (pm *PrometheusMetrics) UpdateTenantsTotal(count float64) {
 	pm.TenantsTotal.Set(count)
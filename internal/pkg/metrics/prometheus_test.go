@@ -0,0 +1,34 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestCacheHitRatio(t *testing.T) {
+	m := metrics.NewPrometheusMetrics("test", zap.NewNop())
+
+	m.RecordCacheHit("bookings")
+	m.RecordCacheHit("bookings")
+	m.RecordCacheHit("bookings")
+	m.RecordCacheMiss("bookings")
+	m.RecordCacheMiss("services")
+
+	assert.InDelta(t, 0.75, testutil.ToFloat64(m.CacheHitRatio.WithLabelValues("bookings")), 1e-9)
+	assert.InDelta(t, 0, testutil.ToFloat64(m.CacheHitRatio.WithLabelValues("services")), 1e-9)
+	assert.InDelta(t, 3, testutil.ToFloat64(m.CacheHitsTotal.WithLabelValues("bookings")), 1e-9)
+}
+
+func TestSetQueueDepth(t *testing.T) {
+	m := metrics.NewPrometheusMetrics("test", zap.NewNop())
+
+	m.SetQueueDepth("domain_events", 12)
+	m.SetQueueDepth("domain_events", 4)
+
+	assert.InDelta(t, 4, testutil.ToFloat64(m.QueueDepth.WithLabelValues("domain_events")), 1e-9)
+}
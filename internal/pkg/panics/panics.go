@@ -0,0 +1,295 @@
+// Package panics tracks panics recovered while serving requests: each is fingerprinted
+// by its stack, counted against an error budget per route, and reported to an error
+// tracker such as Sentry or Rollbar, so recurring panics page someone rather than
+// disappear into the logs.
+package panics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Defaults of the error budget
+const (
+	DefaultBudget = 5
+	DefaultWindow = time.Hour
+)
+
+// maxTracked bounds the fingerprints and routes kept before those outside the window
+// are dropped
+const maxTracked = 1000
+
+// reportTimeout bounds how long a report to the error tracker may take
+const reportTimeout = 10 * time.Second
+
+// Panic is a panic recovered while serving a request
+type Panic struct {
+	Value       string // The recovered value, formatted
+	Stack       []byte // Stack of the goroutine that panicked, as formatted by runtime/debug.Stack
+	Fingerprint string
+	Method      string
+	Route       string // The route pattern matched, such as /api/v1/bookings/:id
+	RequestID   string
+	TenantID    string
+	OccurredAt  time.Time
+
+	// Set by the tracker
+	Count           int  // Panics with the fingerprint within the window, including this one
+	BudgetExhausted bool // Whether the route has had more panics within the window than its budget
+}
+
+// Reporter sends panics to an error tracker
+type Reporter interface {
+	Report(ctx context.Context, p Panic) error
+}
+
+// ReporterFunc adapts a function to a Reporter
+type ReporterFunc func(ctx context.Context, p Panic) error
+
+// Report calls f
+func (f ReporterFunc) Report(ctx context.Context, p Panic) error {
+	return f(ctx, p)
+}
+
+// Fingerprint identifies a panic by the functions on its stack below the panic, so the
+// same panic has the same fingerprint whatever its arguments, goroutine or build.
+func Fingerprint(stack []byte) string {
+	var functions []string
+	scanner := bufio.NewScanner(bytes.NewReader(stack))
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Frames are a function call followed by an indented file and line; the
+		// goroutine header and the files are skipped
+		if line == "" || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "goroutine ") {
+			continue
+		}
+		function, _, _ := strings.Cut(line, " in goroutine ") // "created by" frames name their parent
+		if i := strings.LastIndex(function, "("); i > 0 {
+			function = function[:i]
+		}
+		// Frames above the panic are those recovering it
+		if function == "panic" {
+			functions = functions[:0]
+			continue
+		}
+		functions = append(functions, function)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(functions, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// FingerprintSummary describes the panics with a fingerprint
+type FingerprintSummary struct {
+	Fingerprint string    `json:"fingerprint"`
+	Value       string    `json:"value"` // Of the latest panic
+	Method      string    `json:"method"`
+	Route       string    `json:"route"`
+	Count       int       `json:"count"` // Within the window
+	Total       int       `json:"total"` // Since first seen
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// RouteBudget describes a route's error budget within the window
+type RouteBudget struct {
+	Method    string `json:"method"`
+	Route     string `json:"route"`
+	Panics    int    `json:"panics"`
+	Remaining int    `json:"remaining"`
+	Exhausted bool   `json:"exhausted"`
+}
+
+// Report describes the panics tracked within the window, the most frequent first
+type Report struct {
+	Budget       int                  `json:"budget"`
+	Window       string               `json:"window"`
+	Fingerprints []FingerprintSummary `json:"fingerprints"`
+	Routes       []RouteBudget        `json:"routes"`
+}
+
+// Config holds configuration for a Tracker
+type Config struct {
+	// Budget is the panics a route may have within Window before its budget is
+	// exhausted and it is reported as such
+	Budget int
+	Window time.Duration
+
+	// Reporter receives the first panic with each fingerprint within the window, and
+	// the panic exhausting a route's budget. Optional.
+	Reporter Reporter
+
+	Logger *zap.Logger
+}
+
+// fingerprintStats counts the panics with a fingerprint
+type fingerprintStats struct {
+	summary     FingerprintSummary
+	windowStart time.Time
+}
+
+// routeStats counts the panics of a route
+type routeStats struct {
+	method, route string
+	panics        int
+	windowStart   time.Time
+}
+
+// Tracker counts panics by fingerprint and route within a fixed window, reporting
+// each fingerprint once per window rather than on every occurrence
+type Tracker struct {
+	config Config
+
+	mu           sync.Mutex
+	fingerprints map[string]*fingerprintStats
+	routes       map[string]*routeStats
+}
+
+// NewTracker creates a new tracker
+func NewTracker(config Config) *Tracker {
+	if config.Budget <= 0 {
+		config.Budget = DefaultBudget
+	}
+	if config.Window <= 0 {
+		config.Window = DefaultWindow
+	}
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	return &Tracker{
+		config:       config,
+		fingerprints: make(map[string]*fingerprintStats),
+		routes:       make(map[string]*routeStats),
+	}
+}
+
+// Record counts a panic, fingerprinting it if needed, and reports it when it is the
+// first with its fingerprint within the window or exhausts its route's budget. It
+// returns the panic with its counts; reports are sent in the background.
+func (t *Tracker) Record(p Panic) Panic {
+	if p.Fingerprint == "" {
+		p.Fingerprint = Fingerprint(p.Stack)
+	}
+	if p.OccurredAt.IsZero() {
+		p.OccurredAt = time.Now()
+	}
+
+	t.mu.Lock()
+	if len(t.fingerprints) > maxTracked || len(t.routes) > maxTracked {
+		t.prune(p.OccurredAt)
+	}
+
+	fp, ok := t.fingerprints[p.Fingerprint]
+	if !ok {
+		fp = &fingerprintStats{summary: FingerprintSummary{Fingerprint: p.Fingerprint, FirstSeen: p.OccurredAt}}
+		t.fingerprints[p.Fingerprint] = fp
+	}
+	if p.OccurredAt.Sub(fp.windowStart) >= t.config.Window {
+		fp.windowStart = p.OccurredAt
+		fp.summary.Count = 0
+	}
+	fp.summary.Count++
+	fp.summary.Total++
+	fp.summary.Value = p.Value
+	fp.summary.Method = p.Method
+	fp.summary.Route = p.Route
+	fp.summary.LastSeen = p.OccurredAt
+
+	routeKey := p.Method + " " + p.Route
+	route, ok := t.routes[routeKey]
+	if !ok {
+		route = &routeStats{method: p.Method, route: p.Route}
+		t.routes[routeKey] = route
+	}
+	if p.OccurredAt.Sub(route.windowStart) >= t.config.Window {
+		route.windowStart = p.OccurredAt
+		route.panics = 0
+	}
+	route.panics++
+
+	p.Count = fp.summary.Count
+	p.BudgetExhausted = route.panics > t.config.Budget
+	report := p.Count == 1 || route.panics == t.config.Budget+1
+	t.mu.Unlock()
+
+	if report && t.config.Reporter != nil {
+		go t.report(p)
+	}
+	return p
+}
+
+// report sends a panic to the error tracker
+func (t *Tracker) report(p Panic) {
+	ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+	defer cancel()
+	if err := t.config.Reporter.Report(ctx, p); err != nil {
+		t.config.Logger.Error("failed to report panic",
+			zap.String("fingerprint", p.Fingerprint),
+			zap.Error(err),
+		)
+	}
+}
+
+// prune drops the fingerprints and routes without panics within the window
+func (t *Tracker) prune(now time.Time) {
+	maps.DeleteFunc(t.fingerprints, func(_ string, fp *fingerprintStats) bool {
+		return now.Sub(fp.windowStart) >= t.config.Window
+	})
+	maps.DeleteFunc(t.routes, func(_ string, route *routeStats) bool {
+		return now.Sub(route.windowStart) >= t.config.Window
+	})
+}
+
+// Report describes the panics within the window and the routes' error budgets
+func (t *Tracker) Report() Report {
+	now := time.Now()
+	report := Report{
+		Budget:       t.config.Budget,
+		Window:       t.config.Window.String(),
+		Fingerprints: []FingerprintSummary{},
+		Routes:       []RouteBudget{},
+	}
+
+	t.mu.Lock()
+	for _, fp := range t.fingerprints {
+		if now.Sub(fp.windowStart) < t.config.Window {
+			report.Fingerprints = append(report.Fingerprints, fp.summary)
+		}
+	}
+	for _, route := range t.routes {
+		if now.Sub(route.windowStart) < t.config.Window {
+			report.Routes = append(report.Routes, RouteBudget{
+				Method:    route.method,
+				Route:     route.route,
+				Panics:    route.panics,
+				Remaining: max(t.config.Budget-route.panics, 0),
+				Exhausted: route.panics > t.config.Budget,
+			})
+		}
+	}
+	t.mu.Unlock()
+
+	slices.SortFunc(report.Fingerprints, func(a, b FingerprintSummary) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Fingerprint, b.Fingerprint)
+	})
+	slices.SortFunc(report.Routes, func(a, b RouteBudget) int {
+		if a.Panics != b.Panics {
+			return b.Panics - a.Panics
+		}
+		return strings.Compare(a.Method+" "+a.Route, b.Method+" "+b.Route)
+	})
+	return report
+}
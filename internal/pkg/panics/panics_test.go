@@ -0,0 +1,175 @@
+package panics_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/panics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stackOf returns the stack recovering the panic fn raises
+func stackOf(fn func()) (stack []byte) {
+	defer func() {
+		if recover() != nil {
+			stack = debug.Stack()
+		}
+	}()
+	fn()
+	return nil
+}
+
+func indexOutOfRange(i int) {
+	values := []int{1, 2, 3}
+	_ = values[i]
+}
+
+func indexPanicStack(i int) []byte {
+	return stackOf(func() { indexOutOfRange(i) })
+}
+
+func nilMap() {
+	var m map[string]int
+	m["key"] = 1
+}
+
+func TestFingerprint(t *testing.T) {
+	first := panics.Fingerprint(indexPanicStack(5))
+	again := panics.Fingerprint(indexPanicStack(7))
+	other := panics.Fingerprint(stackOf(nilMap))
+
+	var inGoroutine string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		inGoroutine = panics.Fingerprint(indexPanicStack(9))
+	}()
+	<-done
+
+	assert.Len(t, first, 16)
+	assert.Equal(t, first, again, "arguments don't change the fingerprint")
+	assert.NotEqual(t, first, other)
+	assert.NotEqual(t, first, inGoroutine, "the callers below the panic are part of the fingerprint")
+}
+
+// recorder collects the panics reported
+type recorder struct {
+	mu       sync.Mutex
+	reported []panics.Panic
+}
+
+func (r *recorder) Report(ctx context.Context, p panics.Panic) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reported = append(r.reported, p)
+	return nil
+}
+
+func (r *recorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.reported)
+}
+
+func TestTrackerReportsOncePerFingerprintAndOnExhaustedBudget(t *testing.T) {
+	reporter := &recorder{}
+	tracker := panics.NewTracker(panics.Config{Budget: 3, Window: time.Hour, Reporter: reporter})
+
+	var last panics.Panic
+	for range 4 {
+		last = tracker.Record(panics.Panic{Fingerprint: "abc", Value: "boom", Method: "GET", Route: "/bookings/:id"})
+	}
+	tracker.Record(panics.Panic{Fingerprint: "def", Value: "oops", Method: "POST", Route: "/bookings"})
+
+	assert.Equal(t, 4, last.Count)
+	assert.True(t, last.BudgetExhausted)
+
+	// The first of each fingerprint, and the panic exhausting the budget
+	require.Eventually(t, func() bool { return reporter.count() == 3 }, time.Second, 5*time.Millisecond)
+
+	report := tracker.Report()
+	require.Len(t, report.Fingerprints, 2)
+	assert.Equal(t, "abc", report.Fingerprints[0].Fingerprint)
+	assert.Equal(t, 4, report.Fingerprints[0].Count)
+	require.Len(t, report.Routes, 2)
+	assert.Equal(t, panics.RouteBudget{Method: "GET", Route: "/bookings/:id", Panics: 4, Remaining: 0, Exhausted: true}, report.Routes[0])
+	assert.Equal(t, panics.RouteBudget{Method: "POST", Route: "/bookings", Panics: 1, Remaining: 2}, report.Routes[1])
+}
+
+func TestTrackerWindowResetsCounts(t *testing.T) {
+	tracker := panics.NewTracker(panics.Config{Budget: 1, Window: time.Minute})
+
+	start := time.Now()
+	tracker.Record(panics.Panic{Fingerprint: "abc", Route: "/", OccurredAt: start})
+	p := tracker.Record(panics.Panic{Fingerprint: "abc", Route: "/", OccurredAt: start.Add(time.Second)})
+	assert.Equal(t, 2, p.Count)
+	assert.True(t, p.BudgetExhausted)
+
+	p = tracker.Record(panics.Panic{Fingerprint: "abc", Route: "/", OccurredAt: start.Add(2 * time.Minute)})
+	assert.Equal(t, 1, p.Count)
+	assert.False(t, p.BudgetExhausted)
+}
+
+func TestSentryReporter(t *testing.T) {
+	var event map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/42/store/", r.URL.Path)
+		assert.Contains(t, r.Header.Get("X-Sentry-Auth"), "sentry_key=public")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "://", "://public@", 1) + "/42"
+	reporter, err := panics.NewSentryReporter(dsn, "production", "v1.2.3")
+	require.NoError(t, err)
+
+	require.NoError(t, reporter.Report(context.Background(), panics.Panic{
+		Fingerprint:     "abc",
+		Value:           "boom",
+		Method:          "GET",
+		Route:           "/bookings/:id",
+		BudgetExhausted: true,
+		OccurredAt:      time.Now(),
+	}))
+	assert.Equal(t, []any{"abc"}, event["fingerprint"])
+	assert.Equal(t, "fatal", event["level"])
+	assert.Equal(t, "GET /bookings/:id", event["transaction"])
+
+	_, err = panics.NewSentryReporter("https://sentry.io/42", "", "")
+	assert.Error(t, err, "a DSN without a key is rejected")
+}
+
+func TestRollbarReporter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Rollbar-Access-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var item struct {
+			Data struct {
+				Fingerprint string `json:"fingerprint"`
+				Level       string `json:"level"`
+			} `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&item))
+		assert.Equal(t, "abc", item.Data.Fingerprint)
+		assert.Equal(t, "error", item.Data.Level)
+	}))
+	defer server.Close()
+
+	p := panics.Panic{Fingerprint: "abc", Value: "boom", OccurredAt: time.Now()}
+	reporter := &panics.RollbarReporter{AccessToken: "token", Endpoint: server.URL}
+	require.NoError(t, reporter.Report(context.Background(), p))
+
+	reporter.AccessToken = "wrong"
+	assert.ErrorContains(t, reporter.Report(context.Background(), p), "401")
+}
@@ -0,0 +1,162 @@
+package panics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// level is the severity a panic is reported with; panics exhausting their route's
+// budget are fatal so they page
+func level(p Panic) string {
+	if p.BudgetExhausted {
+		return "fatal"
+	}
+	return "error"
+}
+
+// title summarizes a panic for an error tracker's issue list
+func title(p Panic) string {
+	return fmt.Sprintf("panic in %s %s: %s", p.Method, p.Route, p.Value)
+}
+
+// SentryReporter reports panics to Sentry through its store endpoint, grouped by
+// fingerprint
+type SentryReporter struct {
+	storeURL    string
+	key         string
+	Environment string
+	Release     string
+	Client      *http.Client
+}
+
+// NewSentryReporter creates a reporter for the project of a Sentry DSN, such as
+// https://<key>@o0.ingest.sentry.io/<project>
+func NewSentryReporter(dsn, environment, release string) (*SentryReporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sentry DSN: %w", err)
+	}
+	project := strings.Trim(u.Path, "/")
+	if u.User == nil || u.User.Username() == "" || project == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: expected <scheme>://<key>@<host>/<project>")
+	}
+	return &SentryReporter{
+		storeURL:    fmt.Sprintf("%s://%s/api/%s/store/", u.Scheme, u.Host, project),
+		key:         u.User.Username(),
+		Environment: environment,
+		Release:     release,
+	}, nil
+}
+
+// Report sends the panic as an event
+func (r *SentryReporter) Report(ctx context.Context, p Panic) error {
+	event := map[string]any{
+		"event_id":    strings.ReplaceAll(uuid.NewString(), "-", ""),
+		"timestamp":   p.OccurredAt.UTC().Format(time.RFC3339),
+		"level":       level(p),
+		"platform":    "go",
+		"logger":      "panic",
+		"environment": r.Environment,
+		"release":     r.Release,
+		"transaction": p.Method + " " + p.Route,
+		"fingerprint": []string{p.Fingerprint},
+		"message":     map[string]string{"formatted": title(p)},
+		"exception": map[string]any{
+			"values": []map[string]any{{"type": "panic", "value": p.Value}},
+		},
+		"tags": map[string]string{
+			"route":      p.Route,
+			"method":     p.Method,
+			"tenant_id":  p.TenantID,
+			"request_id": p.RequestID,
+		},
+		"extra": map[string]any{
+			"count":            p.Count,
+			"budget_exhausted": p.BudgetExhausted,
+			"stack":            string(p.Stack),
+		},
+	}
+	header := fmt.Sprintf("Sentry sentry_version=7, sentry_client=kraftivibe/1.0, sentry_key=%s", r.key)
+	return post(ctx, r.Client, r.storeURL, map[string]string{"X-Sentry-Auth": header}, event)
+}
+
+// DefaultRollbarEndpoint is Rollbar's item endpoint
+const DefaultRollbarEndpoint = "https://api.rollbar.com/api/1/item/"
+
+// RollbarReporter reports panics to Rollbar as items, grouped by fingerprint
+type RollbarReporter struct {
+	AccessToken string // A project access token with post_server_item scope
+	Environment string
+	Endpoint    string // Optional: overrides DefaultRollbarEndpoint
+	Client      *http.Client
+}
+
+// Report sends the panic as an item
+func (r *RollbarReporter) Report(ctx context.Context, p Panic) error {
+	endpoint := r.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultRollbarEndpoint
+	}
+	item := map[string]any{
+		"data": map[string]any{
+			"environment": r.Environment,
+			"level":       level(p),
+			"timestamp":   p.OccurredAt.Unix(),
+			"platform":    "go",
+			"language":    "go",
+			"title":       title(p),
+			"fingerprint": p.Fingerprint,
+			"context":     p.Method + " " + p.Route,
+			"body": map[string]any{
+				"message": map[string]string{"body": title(p) + "\n\n" + string(p.Stack)},
+			},
+			"custom": map[string]any{
+				"tenant_id":        p.TenantID,
+				"request_id":       p.RequestID,
+				"count":            p.Count,
+				"budget_exhausted": p.BudgetExhausted,
+			},
+		},
+	}
+	return post(ctx, r.Client, endpoint, map[string]string{"X-Rollbar-Access-Token": r.AccessToken}, item)
+}
+
+// post sends a JSON body to an error tracker
+func post(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("error tracker returned %s: %s", resp.Status, message)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+// Package pdf writes simple A4 documents of text and rules using the standard
+// Helvetica fonts, which every PDF reader provides, so no fonts are embedded.
+// Coordinates are in points measured from the top-left corner of the page.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// ContentType is the MIME type of a PDF document
+const ContentType = "application/pdf"
+
+// Font selects one of the standard fonts
+type Font int
+
+const (
+	Regular Font = iota // Helvetica
+	Bold                // Helvetica-Bold
+)
+
+func (f Font) resource() string {
+	if f == Bold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// Document is a PDF under construction
+type Document struct {
+	title string
+	pages []*Page
+}
+
+// New starts an empty document; title is shown by PDF readers in the window title
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// Page is one page of drawing operations
+type Page struct {
+	content bytes.Buffer
+}
+
+// AddPage appends a blank page and returns it
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// Text draws s with its baseline starting at (x, y)
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font.resource(), num(size), num(x), num(PageHeight-y), escape(s))
+}
+
+// TextRight draws s so that it ends at x
+func (p *Page) TextRight(x, y float64, font Font, size float64, s string) {
+	p.Text(x-TextWidth(font, size, s), y, font, size, s)
+}
+
+// Line draws a straight rule of the given width
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// FillRect fills a rectangle whose top-left corner is (x, y) with a gray level
+// between 0 (black) and 1 (white)
+func (p *Page) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "q %s g %s %s %s %s re f Q\n",
+		num(gray), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	_, _ = d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+// WriteTo renders the document to w. A document without pages gets one blank page.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*Page{{}}
+	}
+
+	// Objects 1-5 are the catalog, page tree, info and two fonts; each page then
+	// takes a page object and a content stream
+	const (
+		catalogObj = 1
+		pagesObj   = 2
+		infoObj    = 3
+		firstPage  = 6
+	)
+	objects := make([]string, firstPage-1+2*len(pages))
+
+	kids := make([]string, len(pages))
+	for i, page := range pages {
+		pageObj := firstPage + 2*i
+		kids[i] = fmt.Sprintf("%d 0 R", pageObj)
+		objects[pageObj-1] = fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents %d 0 R >>",
+			pagesObj, num(PageWidth), num(PageHeight), pageObj+1)
+		objects[pageObj] = fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String())
+	}
+	objects[catalogObj-1] = fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj)
+	objects[pagesObj-1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+	objects[infoObj-1] = fmt.Sprintf("<< /Title (%s) /Producer (Krafti Vibe) >>", escape(d.title))
+	objects[3] = "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>"
+	objects[4] = "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>"
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1, catalogObj, infoObj, xref)
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// TextWidth returns the width of s in points
+func TextWidth(font Font, size float64, s string) float64 {
+	widths := &helveticaWidths
+	if font == Bold {
+		widths = &helveticaBoldWidths
+	}
+
+	var units int
+	for _, b := range encode(s) {
+		if b >= 32 && b <= 126 {
+			units += widths[b-32]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// Truncate shortens s with an ellipsis so that it fits within width
+func Truncate(font Font, size float64, s string, width float64) string {
+	if TextWidth(font, size, s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if candidate := strings.TrimRight(string(runes), " ") + "..."; TextWidth(font, size, candidate) <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Wrap breaks s into lines no wider than width, splitting at spaces. Explicit
+// newlines are kept, and a word longer than a line is truncated.
+func Wrap(font Font, size float64, s string, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if TextWidth(font, size, candidate) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = Truncate(font, size, word, width)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// encode maps s to WinAnsiEncoding; characters it cannot represent become '?'
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		case r == '€':
+			out = append(out, 0x80)
+		case r == '‘', r == '’':
+			out = append(out, '\'')
+		case r == '“', r == '”':
+			out = append(out, '"')
+		case r == '–', r == '—':
+			out = append(out, '-')
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// escape encodes s as the body of a PDF literal string
+func escape(s string) string {
+	var b strings.Builder
+	for _, c := range encode(s) {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// num formats a coordinate with at most two decimals
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// Glyph widths for characters 32-126 in thousandths of the font size (Adobe font metrics)
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space - /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0-9
+	278, 278, 584, 584, 584, 556, 1015, // : - @
+	667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A-M
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N-Z
+	278, 278, 278, 469, 556, 333, // [ - `
+	556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a-m
+	556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n-z
+	334, 260, 334, 584, // { - ~
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278, // space - /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0-9
+	333, 333, 584, 584, 584, 611, 975, // : - @
+	722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, // A-M
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N-Z
+	333, 278, 333, 584, 556, 333, // [ - `
+	556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, // a-m
+	611, 611, 611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, // n-z
+	389, 280, 389, 584, // { - ~
+}
@@ -0,0 +1,59 @@
+package pdf_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"Krafti_Vibe/internal/pkg/pdf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument(t *testing.T) {
+	doc := pdf.New("Invoice (INV-000001)")
+	page := doc.AddPage()
+	page.Text(50, 60, pdf.Bold, 20, "INVOICE")
+	page.TextRight(545, 60, pdf.Regular, 10, `Total: €12.50 (paid) \ ok`)
+	page.Line(50, 70, 545, 70, 0.5)
+	page.FillRect(50, 80, 495, 20, 0.9)
+	doc.AddPage().Text(50, 60, pdf.Regular, 10, "日本")
+
+	out := doc.Bytes()
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+
+	content := string(out)
+	assert.Contains(t, content, "/Title (Invoice \\(INV-000001\\))")
+	assert.Contains(t, content, "/Count 2")
+	assert.Contains(t, content, "BT /F2 20 Tf 50 781.89 Td (INVOICE) Tj ET")
+	assert.Contains(t, content, "(Total: \x8012.50 \\(paid\\) \\\\ ok) Tj")
+	assert.Contains(t, content, "(??) Tj")
+
+	// Every xref entry points at the start of its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(content)
+	require.Len(t, startxref, 2)
+	xrefAt, err := strconv.Atoi(startxref[1])
+	require.NoError(t, err)
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(content[xrefAt:], -1)
+	require.Len(t, entries, 9)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[1])
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(strconv.Itoa(i+1)+" 0 obj")), "object %d", i+1)
+	}
+}
+
+func TestTextLayout(t *testing.T) {
+	assert.InDelta(t, 27.8, pdf.TextWidth(pdf.Regular, 10, "00000"), 0.001)
+	assert.Greater(t, pdf.TextWidth(pdf.Bold, 10, "Invoice"), pdf.TextWidth(pdf.Regular, 10, "Invoice"))
+
+	truncated := pdf.Truncate(pdf.Regular, 10, "Deep tissue massage with hot stones", 80)
+	assert.LessOrEqual(t, pdf.TextWidth(pdf.Regular, 10, truncated), 80.0)
+	assert.Regexp(t, `^Deep tissue.*\.\.\.$`, truncated)
+
+	lines := pdf.Wrap(pdf.Regular, 10, "Payment is due within fourteen days.\nThank you!", 100)
+	assert.Equal(t, []string{"Payment is due within", "fourteen days.", "Thank you!"}, lines)
+}
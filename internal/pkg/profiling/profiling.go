@@ -0,0 +1,226 @@
+// Package profiling captures CPU and heap profiles of the running server and uploads
+// them to object storage or Pyroscope: continuously at an interval, so regressions can
+// be compared over time, and on demand when a route exceeds its latency budget, so a
+// slow endpoint leaves a profile labeled with its route behind.
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"runtime/pprof"
+	"strconv"
+	"sync"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/metrics"
+
+	"go.uber.org/zap"
+)
+
+// Kinds of profiles
+const (
+	KindCPU  = "cpu"
+	KindHeap = "heap"
+)
+
+// Reasons profiles are captured for
+const (
+	ReasonContinuous    = "continuous"
+	ReasonLatencyBudget = "latency_budget"
+)
+
+// Defaults of the profiler
+const (
+	DefaultCPUDuration = 10 * time.Second
+	DefaultCooldown    = 5 * time.Minute
+)
+
+// uploadTimeout bounds how long an upload may take
+const uploadTimeout = 30 * time.Second
+
+// Profile is a captured profile
+type Profile struct {
+	Kind string
+	Data []byte // Gzipped protobuf, as written by runtime/pprof
+
+	// Labels describe the profile: always its reason, and for captures triggered by a
+	// slow request its method, route and latency
+	Labels map[string]string
+
+	Start time.Time
+	End   time.Time // Equals Start for heap profiles, which are snapshots
+}
+
+// Uploader stores captured profiles
+type Uploader interface {
+	Upload(ctx context.Context, p Profile) error
+}
+
+// Config holds configuration for a Profiler
+type Config struct {
+	Uploader Uploader
+
+	// Interval is how often profiles are captured by Run
+	Interval time.Duration
+
+	// CPUDuration is how long CPU profiles sample for
+	CPUDuration time.Duration
+
+	// Cooldown is the least time between captures triggered by the same route, so a
+	// route that is slow under load doesn't have every request profiled
+	Cooldown time.Duration
+
+	// Labels are added to every profile, such as the environment and version
+	Labels map[string]string
+
+	// Metrics records captured profiles when set
+	Metrics *metrics.PrometheusMetrics
+
+	Logger *zap.Logger
+}
+
+// Profiler captures profiles and uploads them. Only one capture runs at a time, as the
+// runtime samples a single CPU profile at once; captures requested meanwhile are skipped.
+type Profiler struct {
+	config Config
+
+	capturing sync.Mutex // Held while a capture runs
+
+	mu        sync.Mutex
+	triggered map[string]time.Time // When each route last triggered a capture
+}
+
+// New creates a new Profiler
+func New(config Config) *Profiler {
+	if config.CPUDuration <= 0 {
+		config.CPUDuration = DefaultCPUDuration
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = DefaultCooldown
+	}
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	return &Profiler{config: config, triggered: make(map[string]time.Time)}
+}
+
+// Run captures profiles every interval until ctx is done
+func (p *Profiler) Run(ctx context.Context) error {
+	if p.config.Interval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			p.Capture(ctx, map[string]string{"reason": ReasonContinuous})
+		}
+	}
+}
+
+// Trigger captures profiles in the background, labeled with a slow request's method,
+// route and latency, unless the route triggered a capture within the cooldown or a
+// capture is running. It reports whether a capture was started.
+func (p *Profiler) Trigger(method, route string, latency time.Duration) bool {
+	now := time.Now()
+	key := method + " " + route
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if last, ok := p.triggered[key]; ok && now.Sub(last) < p.config.Cooldown {
+		return false
+	}
+	if !p.capturing.TryLock() {
+		return false
+	}
+	p.triggered[key] = now
+
+	go func() {
+		defer p.capturing.Unlock()
+		p.capture(context.Background(), map[string]string{
+			"reason":     ReasonLatencyBudget,
+			"method":     method,
+			"route":      route,
+			"latency_ms": strconv.FormatInt(latency.Milliseconds(), 10),
+		})
+	}()
+	return true
+}
+
+// Capture captures a CPU profile over the CPU duration and a heap profile, and uploads
+// both with labels. It is skipped when another capture is running.
+func (p *Profiler) Capture(ctx context.Context, labels map[string]string) {
+	if !p.capturing.TryLock() {
+		p.config.Logger.Debug("profile capture already running; skipping", zap.Any("labels", labels))
+		return
+	}
+	defer p.capturing.Unlock()
+	p.capture(ctx, labels)
+}
+
+// capture captures and uploads profiles; p.capturing must be held
+func (p *Profiler) capture(ctx context.Context, labels map[string]string) {
+	profileLabels := maps.Clone(p.config.Labels)
+	if profileLabels == nil {
+		profileLabels = make(map[string]string)
+	}
+	maps.Copy(profileLabels, labels)
+
+	if cpu, err := p.captureCPU(ctx); err != nil {
+		// The CPU profile is in use, such as by the pprof endpoints
+		p.config.Logger.Warn("failed to capture CPU profile", zap.Error(err))
+	} else {
+		cpu.Labels = profileLabels
+		p.upload(cpu)
+	}
+
+	var heap bytes.Buffer
+	now := time.Now()
+	if err := pprof.Lookup("heap").WriteTo(&heap, 0); err != nil {
+		p.config.Logger.Warn("failed to capture heap profile", zap.Error(err))
+		return
+	}
+	p.upload(Profile{Kind: KindHeap, Data: heap.Bytes(), Labels: profileLabels, Start: now, End: now})
+}
+
+// captureCPU samples a CPU profile over the CPU duration, or until ctx is done
+func (p *Profiler) captureCPU(ctx context.Context) (Profile, error) {
+	var cpu bytes.Buffer
+	start := time.Now()
+	if err := pprof.StartCPUProfile(&cpu); err != nil {
+		return Profile{}, err
+	}
+
+	timer := time.NewTimer(p.config.CPUDuration)
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+	}
+	pprof.StopCPUProfile()
+	return Profile{Kind: KindCPU, Data: cpu.Bytes(), Start: start, End: time.Now()}, nil
+}
+
+// upload uploads a profile, logging failures
+func (p *Profiler) upload(profile Profile) {
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+
+	status := "success"
+	if err := p.config.Uploader.Upload(ctx, profile); err != nil {
+		status = "error"
+		p.config.Logger.Error("failed to upload profile",
+			zap.String("kind", profile.Kind),
+			zap.Any("labels", profile.Labels),
+			zap.Error(err),
+		)
+	}
+	if p.config.Metrics != nil {
+		p.config.Metrics.RecordProfileCaptured(profile.Kind, profile.Labels["reason"], status)
+	}
+}
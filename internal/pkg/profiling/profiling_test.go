@@ -0,0 +1,113 @@
+package profiling_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/infrastructure/storage"
+	"Krafti_Vibe/internal/pkg/profiling"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder collects the profiles uploaded
+type recorder struct {
+	mu       sync.Mutex
+	uploaded []profiling.Profile
+}
+
+func (r *recorder) Upload(ctx context.Context, p profiling.Profile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.uploaded = append(r.uploaded, p)
+	return nil
+}
+
+func (r *recorder) profiles() []profiling.Profile {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]profiling.Profile(nil), r.uploaded...)
+}
+
+func TestProfilerTriggerCapturesLabeledProfilesOncePerCooldown(t *testing.T) {
+	uploader := &recorder{}
+	profiler := profiling.New(profiling.Config{
+		Uploader:    uploader,
+		CPUDuration: 20 * time.Millisecond,
+		Cooldown:    time.Hour,
+		Labels:      map[string]string{"env": "test"},
+	})
+
+	assert.True(t, profiler.Trigger("GET", "/api/v1/bookings/:id", 3*time.Second))
+	assert.False(t, profiler.Trigger("GET", "/api/v1/services", time.Second), "a capture is already running")
+	require.Eventually(t, func() bool { return len(uploader.profiles()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, profiler.Trigger("GET", "/api/v1/bookings/:id", 3*time.Second), "the route is cooling down")
+
+	profiles := uploader.profiles()
+	assert.Equal(t, profiling.KindCPU, profiles[0].Kind)
+	assert.Equal(t, profiling.KindHeap, profiles[1].Kind)
+	for _, p := range profiles {
+		assert.NotEmpty(t, p.Data)
+		assert.Equal(t, map[string]string{
+			"env":        "test",
+			"reason":     profiling.ReasonLatencyBudget,
+			"method":     "GET",
+			"route":      "/api/v1/bookings/:id",
+			"latency_ms": "3000",
+		}, p.Labels)
+	}
+}
+
+func TestStoreUploader(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	start := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	uploader := &profiling.StoreUploader{Store: store}
+	require.NoError(t, uploader.Upload(context.Background(), profiling.Profile{
+		Kind:   profiling.KindCPU,
+		Data:   []byte("profile"),
+		Labels: map[string]string{"reason": profiling.ReasonLatencyBudget, "method": "GET", "route": "/api/v1/bookings/:id"},
+		Start:  start,
+		End:    start.Add(10 * time.Second),
+	}))
+
+	reader, err := store.Open(context.Background(), "profiles/2026-03-04/050607-cpu-latency_budget-get-api_v1_bookings_id.pb.gz")
+	require.NoError(t, err)
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "profile", string(data))
+}
+
+func TestPyroscopeUploader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ingest", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "krafti-vibe{reason=latency_budget,route=/api/v1/bookings/:id}", r.URL.Query().Get("name"))
+		assert.Equal(t, "pprof", r.URL.Query().Get("format"))
+
+		file, _, err := r.FormFile("profile")
+		if !assert.NoError(t, err) {
+			return
+		}
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, "profile", string(data))
+	}))
+	defer server.Close()
+
+	uploader := &profiling.PyroscopeUploader{ServerURL: server.URL, AppName: "krafti-vibe", AuthToken: "token"}
+	require.NoError(t, uploader.Upload(context.Background(), profiling.Profile{
+		Kind:   profiling.KindCPU,
+		Data:   []byte("profile"),
+		Labels: map[string]string{"reason": profiling.ReasonLatencyBudget, "route": "/api/v1/bookings/:id"},
+		Start:  time.Now().Add(-10 * time.Second),
+		End:    time.Now(),
+	}))
+}
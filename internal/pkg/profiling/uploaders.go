@@ -0,0 +1,121 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"Krafti_Vibe/internal/infrastructure/storage"
+)
+
+// StoreUploader keeps profiles in object storage, under
+// <prefix>/<date>/<time>-<kind>-<reason>[-<method>-<route>].pb.gz
+type StoreUploader struct {
+	Store  storage.ObjectStore
+	Prefix string // Defaults to profiles
+}
+
+// Upload stores the profile
+func (u *StoreUploader) Upload(ctx context.Context, p Profile) error {
+	prefix := u.Prefix
+	if prefix == "" {
+		prefix = "profiles"
+	}
+
+	name := []string{p.Start.UTC().Format("150405"), p.Kind, p.Labels["reason"]}
+	if route := p.Labels["route"]; route != "" {
+		name = append(name, strings.ToLower(p.Labels["method"]), slug(route))
+	}
+	key := fmt.Sprintf("%s/%s/%s.pb.gz", strings.Trim(prefix, "/"), p.Start.UTC().Format("2006-01-02"), strings.Join(name, "-"))
+
+	_, err := u.Store.Put(ctx, key, "application/octet-stream", bytes.NewReader(p.Data))
+	return err
+}
+
+// slug makes a route usable in an object key, such as api_v1_bookings_id for
+// /api/v1/bookings/:id
+func slug(route string) string {
+	return strings.Trim(strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, strings.ReplaceAll(route, "/:", "/")), "_")
+}
+
+// PyroscopeUploader sends profiles to a Pyroscope server's ingest endpoint, tagged
+// with their labels
+type PyroscopeUploader struct {
+	ServerURL string
+	AppName   string
+	AuthToken string // Optional: sent as a bearer token
+	Client    *http.Client
+}
+
+// Upload sends the profile
+func (u *PyroscopeUploader) Upload(ctx context.Context, p Profile) error {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("profile", "profile.pprof")
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(p.Data); err != nil {
+		return err
+	}
+	if err := form.Close(); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("name", u.AppName+"{"+pyroscopeLabels(p.Labels)+"}")
+	query.Set("from", strconv.FormatInt(p.Start.Unix(), 10))
+	query.Set("until", strconv.FormatInt(max(p.End.Unix(), p.Start.Unix()+1), 10))
+	query.Set("format", "pprof")
+	query.Set("spyName", "gospy")
+
+	endpoint := strings.TrimSuffix(u.ServerURL, "/") + "/ingest?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	if u.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+u.AuthToken)
+	}
+
+	client := u.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("pyroscope returned %s: %s", resp.Status, message)
+	}
+	return nil
+}
+
+// pyroscopeLabels formats labels as Pyroscope tags, sorted by name, replacing the
+// characters its tag syntax reserves
+func pyroscopeLabels(labels map[string]string) string {
+	clean := strings.NewReplacer(",", "_", "{", "_", "}", "_", "=", "_")
+	var tags []string
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		tags = append(tags, clean.Replace(name)+"="+clean.Replace(labels[name]))
+	}
+	return strings.Join(tags, ",")
+}
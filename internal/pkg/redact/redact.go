@@ -0,0 +1,173 @@
+// Package redact hides payment and personal data in logs and API responses
+package redact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Placeholder replaces redacted values
+const Placeholder = "[REDACTED]"
+
+// sensitiveWords mark a metadata key as holding payment or personal data when they
+// appear as one of its words, e.g. "phone_number" or "cardLast4"
+var sensitiveWords = map[string]bool{
+	"account":       true,
+	"authorization": true,
+	"bank":          true,
+	"card":          true,
+	"cvc":           true,
+	"cvv":           true,
+	"email":         true,
+	"iban":          true,
+	"msisdn":        true,
+	"pan":           true,
+	"password":      true,
+	"phone":         true,
+	"pin":           true,
+	"receipt":       true,
+	"secret":        true,
+	"token":         true,
+}
+
+// IsSensitiveKey reports whether a metadata key holds payment or personal data
+func IsSensitiveKey(key string) bool {
+	for _, word := range words(key) {
+		if sensitiveWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// words splits a key on separators and camelCase boundaries, lowercased
+func words(key string) []string {
+	var result []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			result = append(result, strings.ToLower(current.String()))
+			current.Reset()
+		}
+	}
+	for i, r := range key {
+		switch {
+		case r == '_' || r == '-' || r == '.' || r == ' ':
+			flush()
+		case r >= 'A' && r <= 'Z' && i > 0 && key[i-1] >= 'a' && key[i-1] <= 'z':
+			flush()
+			current.WriteRune(r)
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return result
+}
+
+// Map returns a copy of m with the values of sensitive keys replaced, descending into
+// nested maps. A nil map stays nil.
+func Map(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]any, len(m))
+	for key, value := range m {
+		switch {
+		case IsSensitiveKey(key):
+			result[key] = Placeholder
+		default:
+			if nested, ok := value.(map[string]any); ok {
+				value = Map(nested)
+			}
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// Value returns a copy of a decoded JSON value with the values of sensitive keys
+// replaced and personal data masked in the strings left, descending into objects and
+// arrays
+func Value(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for key, nested := range value {
+			if IsSensitiveKey(key) {
+				result[key] = Placeholder
+				continue
+			}
+			result[key] = Value(nested)
+		}
+		return result
+	case []any:
+		result := make([]any, len(value))
+		for i, nested := range value {
+			result[i] = Value(nested)
+		}
+		return result
+	case string:
+		return String(value)
+	default:
+		return v
+	}
+}
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	jwtPattern    = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/\-]+=*`)
+	digitsPattern = regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`)
+)
+
+// String masks the personal data and credentials found in free text: email addresses,
+// bearer tokens and JWTs, and card numbers
+func String(s string) string {
+	s = bearerPattern.ReplaceAllString(s, "$1 "+Placeholder)
+	s = jwtPattern.ReplaceAllString(s, Placeholder)
+	s = emailPattern.ReplaceAllString(s, Placeholder)
+	return digitsPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if isCardNumber(match) {
+			return Placeholder
+		}
+		return match
+	})
+}
+
+// isCardNumber reports whether a run of digits, possibly grouped by spaces or dashes,
+// passes the Luhn check card numbers carry
+func isCardNumber(s string) bool {
+	sum, count := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			continue
+		}
+		digit := int(s[i] - '0')
+		if count%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		count++
+	}
+	return count >= 13 && count <= 19 && sum%10 == 0
+}
+
+// ID masks an identifier such as a provider payment ID for logs, keeping its prefix
+// and last four characters so it can still be told apart, e.g. "pi_…x9Qz"
+func ID(id string) string {
+	if len(id) <= 8 {
+		if id == "" {
+			return ""
+		}
+		return "…"
+	}
+	prefix := ""
+	if i := strings.IndexByte(id, '_'); i > 0 && i <= 6 {
+		prefix = id[:i+1]
+	}
+	return prefix + "…" + id[len(id)-4:]
+}
@@ -0,0 +1,63 @@
+package redact_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/pkg/redact"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	for _, key := range []string{"receipt_number", "phoneNumber", "card-last4", "customer.email", "Authorization"} {
+		assert.True(t, redact.IsSensitiveKey(key), key)
+	}
+	for _, key := range []string{"provider_status", "reconciled", "company", "spinner", "failed_over_from"} {
+		assert.False(t, redact.IsSensitiveKey(key), key)
+	}
+}
+
+func TestMap(t *testing.T) {
+	original := map[string]any{
+		"receipt_number":  "QK12345",
+		"provider_status": "succeeded",
+		"payer":           map[string]any{"email": "a@example.com", "country": "KE"},
+	}
+	redacted := redact.Map(original)
+
+	assert.Equal(t, redact.Placeholder, redacted["receipt_number"])
+	assert.Equal(t, "succeeded", redacted["provider_status"])
+	assert.Equal(t, map[string]any{"email": redact.Placeholder, "country": "KE"}, redacted["payer"])
+	assert.Equal(t, "QK12345", original["receipt_number"], "the original is left alone")
+	assert.Nil(t, redact.Map(nil))
+}
+
+func TestID(t *testing.T) {
+	assert.Equal(t, "pi_…x9Qz", redact.ID("pi_3NkQ2bL8x9Qz"))
+	assert.Equal(t, "…WXYZ", redact.ID("5O190127TN364715TWXYZ"))
+	assert.Equal(t, "…", redact.ID("short"))
+	assert.Empty(t, redact.ID(""))
+}
+
+func TestValue(t *testing.T) {
+	redacted := redact.Value(map[string]any{
+		"access_token": "abc",
+		"notes":        "call me at jane@example.com",
+		"items":        []any{map[string]any{"card_number": "4242424242424242", "quantity": float64(2)}},
+	})
+
+	assert.Equal(t, map[string]any{
+		"access_token": redact.Placeholder,
+		"notes":        "call me at " + redact.Placeholder,
+		"items":        []any{map[string]any{"card_number": redact.Placeholder, "quantity": float64(2)}},
+	}, redacted)
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "paid with "+redact.Placeholder, redact.String("paid with 4242 4242 4242 4242"))
+	assert.Equal(t, "Bearer "+redact.Placeholder, redact.String("Bearer abc.def-123"))
+	assert.Equal(t, "token="+redact.Placeholder, redact.String("token=eyJhbGciOi.eyJzdWIiOi.c2lnbmF0dXJl"))
+	assert.Equal(t, "to "+redact.Placeholder, redact.String("to jane.doe+work@example.co.ke"))
+	// Long numbers that are not card numbers, such as order references, are kept
+	assert.Equal(t, "order 1234567890123456", redact.String("order 1234567890123456"))
+}
@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// JobFunc is the work performed on every run of a job
+type JobFunc func(ctx context.Context) error
+
+// job is a named task executed on a fixed interval
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+}
+
+// Locker holds a lock shared by every instance while fn runs. It reports false without
+// running fn when another instance holds the lock for longer than wait.
+type Locker interface {
+	WithLock(ctx context.Context, name string, ttl, wait time.Duration, fn func(ctx context.Context, token int64) error) (bool, error)
+}
+
+// jobLockTTL is how long a job's lock outlives an instance that dies running it; the
+// lock is refreshed while the job runs
+const jobLockTTL = 30 * time.Second
+
+// Scheduler runs background jobs on fixed intervals until it is stopped.
+// A job never overlaps with itself: the next run starts one interval after
+// the previous one finished. With a Locker this holds across instances too,
+// and a run is skipped while another instance is running the job.
+type Scheduler struct {
+	jobs   []job
+	logger log.AllLogger
+	locker Locker
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a new Scheduler
+func New(logger log.AllLogger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// UseLocker makes every job run on one instance at a time; it must be called before Start
+func (s *Scheduler) UseLocker(locker Locker) {
+	s.locker = locker
+}
+
+// Register adds a job; it must be called before Start
+func (s *Scheduler) Register(name string, interval time.Duration, run JobFunc) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start launches every registered job in its own goroutine
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.launch(ctx)
+}
+
+// Run runs every registered job until the context is cancelled, then waits for the
+// runs in progress to return
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.launch(ctx)
+	<-ctx.Done()
+	s.wg.Wait()
+	return nil
+}
+
+// launch starts the loop of every registered job
+func (s *Scheduler) launch(ctx context.Context) {
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+// loop runs a job every interval until the context is cancelled
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(j.interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			s.runOnce(ctx, j)
+			timer.Reset(j.interval)
+		}
+	}
+}
+
+// runOnce executes a single run, recovering from panics so one bad run does not stop the job
+func (s *Scheduler) runOnce(ctx context.Context, j job) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("scheduled job panicked", "job", j.name, "panic", r)
+		}
+	}()
+
+	started := time.Now()
+	ran := true
+	var err error
+	if s.locker != nil {
+		ran, err = s.locker.WithLock(ctx, "job:"+j.name, jobLockTTL, 0, func(ctx context.Context, _ int64) error {
+			return j.run(ctx)
+		})
+	} else {
+		err = j.run(ctx)
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("scheduled job failed", "job", j.name, "error", err)
+		}
+		return
+	}
+	if !ran {
+		s.logger.Debug("scheduled job skipped, running on another instance", "job", j.name)
+		return
+	}
+	s.logger.Debug("scheduled job completed", "job", j.name, "duration", time.Since(started))
+}
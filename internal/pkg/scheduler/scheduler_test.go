@@ -0,0 +1,125 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/scheduler"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerRunsJobsUntilStopped(t *testing.T) {
+	s := scheduler.New(log.DefaultLogger())
+
+	var runs, failures atomic.Int32
+	s.Register("counter", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+	s.Register("failing", 5*time.Millisecond, func(ctx context.Context) error {
+		failures.Add(1)
+		return errors.New("boom")
+	})
+	s.Register("panicking", 5*time.Millisecond, func(ctx context.Context) error {
+		panic("boom")
+	})
+
+	s.Start()
+	assert.Eventually(t, func() bool {
+		return runs.Load() >= 3 && failures.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+	s.Stop()
+
+	stopped := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load(), "jobs must not run after Stop")
+}
+
+func TestSchedulerRunWaitsForRunningJobs(t *testing.T) {
+	s := scheduler.New(log.DefaultLogger())
+
+	var started, finished atomic.Bool
+	s.Register("slow", time.Millisecond, func(ctx context.Context) error {
+		if started.Swap(true) {
+			return nil
+		}
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		finished.Store(true)
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	returned := make(chan struct{})
+	go func() {
+		_ = s.Run(ctx)
+		close(returned)
+	}()
+
+	assert.Eventually(t, started.Load, time.Second, time.Millisecond)
+	cancel()
+	<-returned
+	assert.True(t, finished.Load(), "Run must wait for the job in progress")
+}
+
+func TestSchedulerStopWithoutStart(t *testing.T) {
+	s := scheduler.New(log.DefaultLogger())
+	assert.NotPanics(t, s.Stop)
+}
+
+// sharedLocker stands in for a lock shared by several instances
+type sharedLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+func (l *sharedLocker) WithLock(ctx context.Context, name string, ttl, wait time.Duration, fn func(ctx context.Context, token int64) error) (bool, error) {
+	l.mu.Lock()
+	if l.held[name] {
+		l.mu.Unlock()
+		return false, nil
+	}
+	l.held[name] = true
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		delete(l.held, name)
+		l.mu.Unlock()
+	}()
+	return true, fn(ctx, 1)
+}
+
+func TestSchedulerRunsJobOnOneInstanceAtATime(t *testing.T) {
+	locker := &sharedLocker{held: make(map[string]bool)}
+
+	var running, overlaps, runs atomic.Int32
+	job := func(ctx context.Context) error {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		defer running.Add(-1)
+		runs.Add(1)
+		time.Sleep(3 * time.Millisecond)
+		return nil
+	}
+
+	instances := []*scheduler.Scheduler{scheduler.New(log.DefaultLogger()), scheduler.New(log.DefaultLogger())}
+	for _, s := range instances {
+		s.UseLocker(locker)
+		s.Register("exclusive", time.Millisecond, job)
+		s.Start()
+	}
+	assert.Eventually(t, func() bool { return runs.Load() >= 5 }, time.Second, time.Millisecond)
+	for _, s := range instances {
+		s.Stop()
+	}
+
+	assert.Zero(t, overlaps.Load())
+}
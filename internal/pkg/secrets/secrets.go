@@ -0,0 +1,147 @@
+// Package secrets resolves configuration values held by a secret manager, such as
+// Vault or AWS Secrets Manager, rather than set in the environment.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schemes of references to the supported secret managers
+const (
+	SchemeVault = "vault" // vault:kv/data/kraftivibe#db_password
+	SchemeAWS   = "awssm" // awssm:kraftivibe/production#db_password
+)
+
+// DefaultCacheTTL is how long a fetched secret is used before it is fetched again
+const DefaultCacheTTL = 5 * time.Minute
+
+// Provider fetches secrets from a secret manager. A secret holds one or more named
+// values; a secret holding a single unnamed value returns it under the empty name.
+type Provider interface {
+	Fetch(ctx context.Context, path string) (map[string]string, error)
+}
+
+// Reference points to a value held by a secret manager, written
+// <scheme>:<path>#<key>. The key may be omitted for secrets holding a single value.
+type Reference struct {
+	Scheme string
+	Path   string
+	Key    string
+}
+
+// ParseReference parses a configuration value referencing a secret. It reports false
+// for plain values.
+func ParseReference(value string) (Reference, bool) {
+	scheme, rest, ok := strings.Cut(value, ":")
+	if !ok || (scheme != SchemeVault && scheme != SchemeAWS) || rest == "" {
+		return Reference{}, false
+	}
+	path, key, _ := strings.Cut(rest, "#")
+	return Reference{Scheme: scheme, Path: path, Key: key}, true
+}
+
+// IsReference reports whether a configuration value references a secret
+func IsReference(value string) bool {
+	_, ok := ParseReference(value)
+	return ok
+}
+
+// cachedSecret is a secret as fetched at some point
+type cachedSecret struct {
+	values  map[string]string
+	fetched time.Time
+}
+
+// Resolver resolves references to secrets through the providers registered for their
+// schemes. Secrets are cached for a TTL and fetched again on the first use after it,
+// so rotated values are picked up without a restart; when fetching fails, the value
+// cached last keeps being used.
+type Resolver struct {
+	ttl       time.Duration
+	providers map[string]Provider
+
+	mu    sync.Mutex
+	cache map[string]cachedSecret // By scheme and path
+}
+
+// NewResolver creates a new resolver caching secrets for ttl
+func NewResolver(ttl time.Duration) *Resolver {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Resolver{
+		ttl:       ttl,
+		providers: make(map[string]Provider),
+		cache:     make(map[string]cachedSecret),
+	}
+}
+
+// Register sets the provider of a scheme; it must be called before resolving
+func (r *Resolver) Register(scheme string, provider Provider) {
+	r.providers[scheme] = provider
+}
+
+// Resolve returns the value a configuration value references, or the value itself
+// when it is plain
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	ref, ok := ParseReference(value)
+	if !ok {
+		return value, nil
+	}
+
+	values, err := r.fetch(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	if ref.Key == "" && len(values) == 1 {
+		for _, v := range values {
+			return v, nil
+		}
+	}
+	v, ok := values[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %q", ref.Scheme+":"+ref.Path, ref.Key)
+	}
+	return v, nil
+}
+
+// fetch returns the values of the referenced secret, from the cache while it is fresh
+func (r *Resolver) fetch(ctx context.Context, ref Reference) (map[string]string, error) {
+	provider, ok := r.providers[ref.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("no secret manager configured for %s references", ref.Scheme)
+	}
+
+	cacheKey := ref.Scheme + ":" + ref.Path
+	r.mu.Lock()
+	cached, found := r.cache[cacheKey]
+	r.mu.Unlock()
+	if found && time.Since(cached.fetched) < r.ttl {
+		return cached.values, nil
+	}
+
+	values, err := provider.Fetch(ctx, ref.Path)
+	if err != nil {
+		if found {
+			return cached.values, nil
+		}
+		return nil, fmt.Errorf("failed to fetch secret %s: %w", cacheKey, err)
+	}
+
+	r.mu.Lock()
+	r.cache[cacheKey] = cachedSecret{values: values, fetched: time.Now()}
+	r.mu.Unlock()
+	return values, nil
+}
+
+// Source returns a function resolving a configuration value on every call, for
+// credentials fetched anew for each connection
+func (r *Resolver) Source(value string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		return r.Resolve(ctx, value)
+	}
+}
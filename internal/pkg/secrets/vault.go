@@ -0,0 +1,78 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// VaultProvider reads secrets from HashiCorp Vault's key/value engine with a token,
+// such as one an agent sidecar keeps renewed. Paths are those of the HTTP API, e.g.
+// kv/data/kraftivibe for version 2 of the engine.
+type VaultProvider struct {
+	Addr      string // Such as https://vault.example.com:8200
+	Token     string
+	Namespace string // Optional: Vault Enterprise namespace
+	Client    *http.Client
+}
+
+// Fetch reads the secret at path
+func (p *VaultProvider) Fetch(ctx context.Context, path string) (map[string]string, error) {
+	url := strings.TrimSuffix(p.Addr, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.Token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned %s", resp.Status)
+	}
+
+	// Version 2 of the engine nests the values under data.data, version 1 under data
+	var body struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid vault response: %w", err)
+	}
+	data := body.Data
+	if nested, ok := data["data"]; ok {
+		if _, versioned := data["metadata"]; versioned {
+			data = nil
+			if err := json.Unmarshal(nested, &data); err != nil {
+				return nil, fmt.Errorf("invalid vault secret: %w", err)
+			}
+		}
+	}
+	return stringValues(data), nil
+}
+
+// stringValues returns the values of a JSON object as text, strings unquoted
+func stringValues(object map[string]json.RawMessage) map[string]string {
+	values := make(map[string]string, len(object))
+	for key, raw := range object {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			values[key] = s
+			continue
+		}
+		values[key] = string(raw)
+	}
+	return values
+}
@@ -0,0 +1,79 @@
+// Package startup connects the server to its external dependencies at boot. Each
+// connection is retried with exponential backoff and jitter, so a dependency that is
+// briefly unavailable, as during a rolling deploy or a database failover, delays
+// startup rather than failing it.
+package startup
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Backoff bounds how a dependency is retried
+type Backoff struct {
+	Attempts int           // Tries before giving up, including the first
+	Initial  time.Duration // Delay before the second try, doubling after each
+	Max      time.Duration // Longest delay
+}
+
+// DefaultBackoff returns the default backoff, retrying for about two minutes
+func DefaultBackoff() Backoff {
+	return Backoff{
+		Attempts: 8,
+		Initial:  time.Second,
+		Max:      30 * time.Second,
+	}
+}
+
+// Delay returns how long to wait after a failed attempt, numbered from 1. It is
+// randomized between half the backoff and all of it, so instances started together
+// don't retry in lockstep.
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := b.Initial
+	for i := 1; i < attempt && delay < b.Max; i++ {
+		delay *= 2
+	}
+	delay = min(delay, b.Max)
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// Connect calls connect until it succeeds, the attempts run out or ctx is done,
+// returning the last failure
+func Connect(ctx context.Context, name string, backoff Backoff, logger *zap.Logger, connect func(ctx context.Context) error) error {
+	attempts := max(backoff.Attempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := connect(ctx)
+		if err == nil {
+			if attempt > 1 {
+				logger.Info("dependency connected", zap.String("dependency", name), zap.Int("attempt", attempt))
+			}
+			return nil
+		}
+		if attempt == attempts {
+			return fmt.Errorf("%s unavailable after %d attempts: %w", name, attempts, err)
+		}
+
+		delay := backoff.Delay(attempt)
+		logger.Warn("dependency unavailable; retrying",
+			zap.String("dependency", name),
+			zap.Int("attempt", attempt),
+			zap.Int("attempts", attempts),
+			zap.Duration("retry_in", delay),
+			zap.Error(err),
+		)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s unavailable: %w", name, err)
+		case <-timer.C:
+		}
+	}
+}
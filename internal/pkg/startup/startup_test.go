@@ -0,0 +1,61 @@
+package startup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/startup"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var backoff = startup.Backoff{Attempts: 3, Initial: time.Millisecond, Max: 2 * time.Millisecond}
+
+func TestConnectRetries(t *testing.T) {
+	calls := 0
+	err := startup.Connect(context.Background(), "database", backoff, zap.NewNop(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestConnectGivesUp(t *testing.T) {
+	refused := errors.New("connection refused")
+	calls := 0
+	err := startup.Connect(context.Background(), "redis", backoff, zap.NewNop(), func(ctx context.Context) error {
+		calls++
+		return refused
+	})
+	assert.ErrorIs(t, err, refused)
+	assert.Contains(t, err.Error(), "redis unavailable after 3 attempts")
+	assert.Equal(t, 3, calls)
+}
+
+func TestConnectStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := startup.Connect(ctx, "zitadel", startup.Backoff{Attempts: 5, Initial: time.Hour, Max: time.Hour}, zap.NewNop(), func(ctx context.Context) error {
+		calls++
+		cancel()
+		return errors.New("connection refused")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := startup.Backoff{Initial: time.Second, Max: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		delay := b.Delay(attempt)
+		assert.GreaterOrEqual(t, delay, want/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, delay, want, "attempt %d", attempt)
+	}
+}
@@ -0,0 +1,29 @@
+// Package tenancy carries the tenant a request acts for through its context, so the
+// database layer can limit queries to that tenant's rows
+package tenancy
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// ContextKey is the key the tenant ID is stored under. Fiber locals set with it are
+// visible through c.Context(), which handlers pass to services.
+var ContextKey any = contextKey{}
+
+// WithTenant returns a context scoped to the tenant
+func WithTenant(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, ContextKey, tenantID)
+}
+
+// FromContext returns the tenant the context is scoped to, if any
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	if ctx == nil {
+		return uuid.Nil, false
+	}
+	tenantID, ok := ctx.Value(ContextKey).(uuid.UUID)
+	return tenantID, ok && tenantID != uuid.Nil
+}
@@ -0,0 +1,24 @@
+package tenancy_test
+
+import (
+	"context"
+	"testing"
+
+	"Krafti_Vibe/internal/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	_, ok := tenancy.FromContext(context.Background())
+	assert.False(t, ok)
+
+	tenantID := uuid.New()
+	got, ok := tenancy.FromContext(tenancy.WithTenant(context.Background(), tenantID))
+	assert.True(t, ok)
+	assert.Equal(t, tenantID, got)
+
+	_, ok = tenancy.FromContext(tenancy.WithTenant(context.Background(), uuid.Nil))
+	assert.False(t, ok, "a nil tenant does not scope the context")
+}
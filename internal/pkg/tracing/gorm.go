@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// gormSpanKey is the key a statement's span is kept under between its callbacks
+const gormSpanKey = "otel:span"
+
+// GormPlugin records a span for each statement GORM runs within a traced request or
+// job. Statements outside any trace, such as migrations, are not recorded.
+type GormPlugin struct{}
+
+// NewGormPlugin creates the GORM tracing plugin, registered with db.Use
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+// Name returns the plugin's name
+func (p *GormPlugin) Name() string {
+	return "otel"
+}
+
+// Initialize registers the plugin's callbacks around each kind of statement
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	processors := []struct {
+		operation string
+		before    interface {
+			Register(name string, fn func(*gorm.DB)) error
+		}
+		after interface {
+			Register(name string, fn func(*gorm.DB)) error
+		}
+	}{
+		{"create", callbacks.Create().Before("gorm:create"), callbacks.Create().After("gorm:create")},
+		{"query", callbacks.Query().Before("gorm:query"), callbacks.Query().After("gorm:query")},
+		{"update", callbacks.Update().Before("gorm:update"), callbacks.Update().After("gorm:update")},
+		{"delete", callbacks.Delete().Before("gorm:delete"), callbacks.Delete().After("gorm:delete")},
+		{"row", callbacks.Row().Before("gorm:row"), callbacks.Row().After("gorm:row")},
+		{"raw", callbacks.Raw().Before("gorm:raw"), callbacks.Raw().After("gorm:raw")},
+	}
+
+	for _, processor := range processors {
+		if err := processor.before.Register("otel:before_"+processor.operation, startStatement(processor.operation)); err != nil {
+			return err
+		}
+		if err := processor.after.Register("otel:after_"+processor.operation, endStatement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startStatement starts the span of a statement when its context is traced
+func startStatement(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := Context(db.Statement.Context)
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			return
+		}
+
+		ctx, span := Tracer().Start(ctx, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemNamePostgreSQL,
+				semconv.DBOperationName(operation),
+			),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+// endStatement ends the span of a statement with its outcome. Records not found are
+// an answer rather than a failure.
+func endStatement(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+	}
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.response.rows_affected", db.RowsAffected),
+	)
+
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	End(span, err)
+}
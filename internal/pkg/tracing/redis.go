@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook records a span for each Redis command or pipeline sent within a traced
+// request or job. Only command names are recorded, never their arguments, which may
+// hold cached personal data.
+type RedisHook struct{}
+
+// NewRedisHook creates the Redis tracing hook, added with client.AddHook
+func NewRedisHook() *RedisHook {
+	return &RedisHook{}
+}
+
+// DialHook leaves dialing untraced
+func (h *RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook traces a command
+func (h *RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span, ok := startRedis(ctx, "redis."+cmd.Name(), attribute.String("db.operation.name", cmd.Name()))
+		if !ok {
+			return next(ctx, cmd)
+		}
+
+		err := next(ctx, cmd)
+		endRedis(span, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook traces a pipeline as one span
+func (h *RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span, ok := startRedis(ctx, "redis.pipeline",
+			attribute.String("db.operation.name", "pipeline"),
+			attribute.Int("db.operation.batch.size", len(cmds)),
+		)
+		if !ok {
+			return next(ctx, cmds)
+		}
+
+		err := next(ctx, cmds)
+		endRedis(span, err)
+		return err
+	}
+}
+
+// startRedis starts the span of a command when its context is traced
+func startRedis(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span, bool) {
+	ctx = Context(ctx)
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil, false
+	}
+
+	attrs = append(attrs, semconv.DBSystemNameRedis)
+	ctx, span := Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return ctx, span, true
+}
+
+// endRedis ends the span of a command. Missing keys are an answer rather than a failure.
+func endRedis(span trace.Span, err error) {
+	if errors.Is(err, redis.Nil) {
+		err = nil
+	}
+	End(span, err)
+}
@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InstrumentationName names the tracer spans of this application are started with
+const InstrumentationName = "Krafti_Vibe"
+
+// SpanKey is the key the span of a request is stored under in Fiber locals, so that it
+// is visible through c.Context(), which handlers pass to services. Context lifts it
+// into a context OpenTelemetry recognizes.
+const SpanKey = "otel_span"
+
+// Config holds the configuration of trace export
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP collector, such as http://localhost:4318
+	Endpoint string
+
+	// Headers are sent with every export, such as a collector API key
+	Headers map[string]string
+
+	ServiceName    string
+	ServiceVersion string
+	Environment    string
+
+	// SampleRatio is the share of traces started here that are kept. Traces continued
+	// from a caller are kept when the caller kept them.
+	SampleRatio float64
+}
+
+// Initialize installs a tracer provider exporting spans to the OTLP collector, and W3C
+// trace context propagation. The returned function flushes and stops the export.
+func Initialize(ctx context.Context, config Config, logger *zap.Logger) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpointURL(config.Endpoint),
+		otlptracehttp.WithHeaders(config.Headers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+		semconv.ServiceVersion(config.ServiceVersion),
+		semconv.DeploymentEnvironmentName(config.Environment),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn("tracing error", zap.Error(err))
+	}))
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of this application. Until Initialize is called spans are
+// not recorded.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Context returns ctx carrying the span of the request it belongs to. Contexts handlers
+// get from c.Context() only hold it under SpanKey.
+func Context(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	if span, ok := ctx.Value(SpanKey).(trace.Span); ok {
+		return trace.ContextWithSpan(ctx, span)
+	}
+	return ctx
+}
+
+// Start starts a span that is a child of the context's span, such as the request
+// handled
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(Context(ctx), name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if any, on the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Fields returns the zap fields identifying the context's trace and span, for logs to
+// be correlated with traces; none when the context is not traced
+func Fields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(Context(ctx))
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", spanContext.TraceID().String()),
+		zap.String("span_id", spanContext.SpanID().String()),
+	}
+}
@@ -0,0 +1,58 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Krafti_Vibe/internal/pkg/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestStartContinuesSpanStoredUnderSpanKey(t *testing.T) {
+	recorder := recordSpans(t)
+
+	_, request := tracing.Tracer().Start(context.Background(), "GET /bookings")
+	// Handlers pass c.Context(), where the request's span is only a value under SpanKey
+	handlerCtx := context.WithValue(context.Background(), tracing.SpanKey, request)
+
+	_, span := tracing.Start(handlerCtx, "booking.create")
+	tracing.End(span, errors.New("slot taken"))
+	request.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "booking.create", spans[0].Name())
+	assert.Equal(t, request.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	assert.Equal(t, request.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestFields(t *testing.T) {
+	recordSpans(t)
+
+	assert.Empty(t, tracing.Fields(context.Background()))
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "job")
+	defer span.End()
+
+	fields := tracing.Fields(ctx)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "trace_id", fields[0].Key)
+	assert.Equal(t, span.SpanContext().TraceID().String(), fields[0].String)
+	assert.Equal(t, "span_id", fields[1].Key)
+}
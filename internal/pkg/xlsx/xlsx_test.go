@@ -0,0 +1,50 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/xlsx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := xlsx.NewWriter(&buf, "Bookings: 2025/Q1")
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow("id", "total", "paid", "start"))
+	require.NoError(t, w.WriteRow("a<b>&c", 12.5, true, time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)))
+	require.NoError(t, w.WriteRow(nil, 3))
+	require.NoError(t, w.Close())
+	assert.ErrorIs(t, w.WriteRow("late"), xlsx.ErrClosed)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	require.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files["xl/workbook.xml"], `name="Bookings 2025Q1"`)
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">a&lt;b&gt;&amp;c</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2"><v>12.5</v></c>`)
+	assert.Contains(t, sheet, `<c r="C2" t="b"><v>1</v></c>`)
+	assert.Contains(t, sheet, `2025-03-14T09:30:00Z`)
+	assert.Contains(t, sheet, `<row r="3"><c r="B3"><v>3</v></c></row>`)
+	assert.Contains(t, sheet, `</sheetData></worksheet>`)
+}
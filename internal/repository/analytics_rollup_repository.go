@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AnalyticsRollupRepository maintains the daily rollups of bookings and payments that
+// dashboards read (see models.AnalyticsDailyRollup)
+type AnalyticsRollupRepository interface {
+	// GetState returns how far a tenant's rollups are up to date, or nil when they were
+	// never built
+	GetState(ctx context.Context, tenantID uuid.UUID) (*models.AnalyticsRollupState, error)
+
+	// ListStates returns the state of every tenant whose rollups were built
+	ListStates(ctx context.Context) ([]*models.AnalyticsRollupState, error)
+
+	// FindTenantsToRebuild returns the tenants whose rollups were not rebuilt since before,
+	// including those never built
+	FindTenantsToRebuild(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+
+	// FindChangedDays returns the UTC days, per tenant, whose bookings or payments changed
+	// since the given time
+	FindChangedDays(ctx context.Context, since time.Time) (map[uuid.UUID][]time.Time, error)
+
+	// Rebuild recomputes every rollup of a tenant and records it as rebuilt and refreshed
+	// at. Rollups of days before the archive horizon, whose bookings and payments may
+	// have been archived, are kept as they are.
+	Rebuild(ctx context.Context, tenantID uuid.UUID, at time.Time) error
+
+	// RefreshDays recomputes a tenant's rollups of the given UTC days
+	RefreshDays(ctx context.Context, tenantID uuid.UUID, days []time.Time, at time.Time) error
+
+	// MarkRefreshed records every built tenant's rollups as refreshed at the given time
+	MarkRefreshed(ctx context.Context, at time.Time) error
+}
+
+// analyticsRollupRepository implements AnalyticsRollupRepository
+type analyticsRollupRepository struct {
+	db       *gorm.DB
+	logger   log.AllLogger
+	timeouts QueryTimeouts
+}
+
+// NewAnalyticsRollupRepository creates a new AnalyticsRollupRepository instance
+func NewAnalyticsRollupRepository(db *gorm.DB, config ...RepositoryConfig) AnalyticsRollupRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return &analyticsRollupRepository{
+		db:       db,
+		logger:   cfg.Logger,
+		timeouts: cfg.QueryTimeouts,
+	}
+}
+
+// rollupActivitySQL aggregates a tenant's bookings and payments per artisan, service and
+// UTC day into the rollup columns. The format verbs take extra conditions on the
+// bookings and on the payments, which must start with AND.
+const rollupActivitySQL = `
+SELECT tenant_id, artisan_id, service_id, day,
+	SUM(booking_count), SUM(completed_count), SUM(cancelled_count), SUM(booking_revenue),
+	SUM(payment_count), SUM(payment_revenue),
+	@at, @at, @at, 1
+FROM (
+	SELECT tenant_id, artisan_id, service_id, DATE(start_time AT TIME ZONE 'UTC') AS day,
+		COUNT(*) AS booking_count,
+		COUNT(*) FILTER (WHERE status = @completed) AS completed_count,
+		COUNT(*) FILTER (WHERE status = @cancelled) AS cancelled_count,
+		COALESCE(SUM(total_price) FILTER (WHERE status = @completed), 0) AS booking_revenue,
+		0 AS payment_count,
+		0 AS payment_revenue
+	FROM bookings
+	WHERE tenant_id = @tenant AND deleted_at IS NULL %s
+	GROUP BY 1, 2, 3, 4
+	UNION ALL
+	SELECT p.tenant_id, b.artisan_id, b.service_id, DATE(p.processed_at AT TIME ZONE 'UTC') AS day,
+		0, 0, 0, 0,
+		COUNT(*),
+		SUM(p.amount)
+	FROM payments p
+	JOIN bookings b ON b.id = p.booking_id
+	WHERE p.tenant_id = @tenant AND p.status = @paid AND p.deleted_at IS NULL AND p.processed_at IS NOT NULL %s
+	GROUP BY 1, 2, 3, 4
+) activity
+GROUP BY tenant_id, artisan_id, service_id, day`
+
+const rollupInsertSQL = `
+INSERT INTO analytics_daily_rollups (tenant_id, artisan_id, service_id, day,
+	booking_count, completed_count, cancelled_count, booking_revenue,
+	payment_count, payment_revenue,
+	refreshed_at, created_at, updated_at, version)`
+
+// GetState retrieves a tenant's rollup state
+func (r *analyticsRollupRepository) GetState(ctx context.Context, tenantID uuid.UUID) (*models.AnalyticsRollupState, error) {
+	state, err := findRollupState(ctx, r.db, tenantID)
+	if err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find analytics rollup state", err)
+	}
+	return state, nil
+}
+
+// ListStates retrieves every tenant's rollup state
+func (r *analyticsRollupRepository) ListStates(ctx context.Context) ([]*models.AnalyticsRollupState, error) {
+	var states []*models.AnalyticsRollupState
+	if err := r.db.WithContext(ctx).Order("refreshed_at ASC").Find(&states).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to list analytics rollup states", err)
+	}
+	return states, nil
+}
+
+// FindTenantsToRebuild retrieves active tenants with stale or missing rollups, the
+// longest waiting first
+func (r *analyticsRollupRepository) FindTenantsToRebuild(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	var tenantIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Table("tenants").
+		Select("tenants.id").
+		Joins("LEFT JOIN analytics_rollup_states s ON s.tenant_id = tenants.id AND s.deleted_at IS NULL").
+		Where("tenants.deleted_at IS NULL AND (s.id IS NULL OR s.rebuilt_at < ?)", before).
+		Order("s.rebuilt_at ASC NULLS FIRST").
+		Pluck("tenants.id", &tenantIDs).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find tenants to rebuild analytics for", err)
+	}
+	return tenantIDs, nil
+}
+
+// FindChangedDays retrieves the days touched by bookings and payments written since the
+// given time, soft-deleted ones included. A rescheduled booking touches the day it
+// started on before as well.
+func (r *analyticsRollupRepository) FindChangedDays(ctx context.Context, since time.Time) (map[uuid.UUID][]time.Time, error) {
+	ctx, cancel := r.timeouts.WithTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
+	var rows []struct {
+		TenantID uuid.UUID
+		Day      time.Time
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT tenant_id, DATE(start_time AT TIME ZONE 'UTC') AS day
+		FROM bookings
+		WHERE updated_at >= @since OR deleted_at >= @since
+		UNION
+		SELECT tenant_id, DATE(original_start_time AT TIME ZONE 'UTC')
+		FROM bookings
+		WHERE original_start_time IS NOT NULL AND (updated_at >= @since OR deleted_at >= @since)
+		UNION
+		SELECT tenant_id, DATE(processed_at AT TIME ZONE 'UTC')
+		FROM payments
+		WHERE processed_at IS NOT NULL AND (updated_at >= @since OR deleted_at >= @since)`,
+		map[string]any{"since": since}).
+		Scan(&rows).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find changed analytics days", err)
+	}
+
+	changed := make(map[uuid.UUID][]time.Time)
+	for _, row := range rows {
+		changed[row.TenantID] = append(changed[row.TenantID], row.Day)
+	}
+	return changed, nil
+}
+
+// Rebuild replaces a tenant's rollups from the archive horizon on in one transaction
+func (r *analyticsRollupRepository) Rebuild(ctx context.Context, tenantID uuid.UUID, at time.Time) error {
+	ctx, cancel := r.timeouts.WithTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		horizon, err := findArchiveHorizon(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		args := rollupArgs(tenantID, at, nil)
+		remove := tx.Unscoped().Where("tenant_id = ?", tenantID)
+		bookingsFrom, paymentsFrom := "", ""
+		if horizon != nil {
+			args["from"] = *horizon
+			remove = remove.Where("day >= ?", horizon.UTC().Format(time.DateOnly))
+			bookingsFrom, paymentsFrom = "AND start_time >= @from", "AND p.processed_at >= @from"
+		}
+
+		if err := remove.Delete(&models.AnalyticsDailyRollup{}).Error; err != nil {
+			return err
+		}
+		insert := rollupInsertSQL + fmt.Sprintf(rollupActivitySQL, bookingsFrom, paymentsFrom)
+		if err := tx.Exec(insert, args).Error; err != nil {
+			return err
+		}
+
+		state := &models.AnalyticsRollupState{TenantID: tenantID, RefreshedAt: at, RebuiltAt: at}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tenant_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"refreshed_at", "rebuilt_at", "updated_at"}),
+		}).Create(state).Error
+	})
+	if err != nil {
+		r.logger.Error("failed to rebuild analytics rollups", "tenant_id", tenantID, "error", err)
+		return errors.NewRepositoryError("REBUILD_FAILED", "failed to rebuild analytics rollups", err)
+	}
+	return nil
+}
+
+// RefreshDays replaces a tenant's rollups of the given days in one transaction
+func (r *analyticsRollupRepository) RefreshDays(ctx context.Context, tenantID uuid.UUID, days []time.Time, at time.Time) error {
+	if len(days) == 0 {
+		return nil
+	}
+
+	ctx, cancel := r.timeouts.WithTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
+	dates := make([]string, len(days))
+	for i, day := range days {
+		dates[i] = day.Format(time.DateOnly)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("tenant_id = ? AND day IN ?", tenantID, dates).
+			Delete(&models.AnalyticsDailyRollup{}).Error; err != nil {
+			return err
+		}
+		insert := rollupInsertSQL + fmt.Sprintf(rollupActivitySQL,
+			"AND DATE(start_time AT TIME ZONE 'UTC') IN @days",
+			"AND DATE(p.processed_at AT TIME ZONE 'UTC') IN @days")
+		return tx.Exec(insert, rollupArgs(tenantID, at, dates)).Error
+	})
+	if err != nil {
+		r.logger.Error("failed to refresh analytics rollups", "tenant_id", tenantID, "days", len(days), "error", err)
+		return errors.NewRepositoryError("REFRESH_FAILED", "failed to refresh analytics rollups", err)
+	}
+	return nil
+}
+
+// MarkRefreshed moves every state refreshed before the given time up to it
+func (r *analyticsRollupRepository) MarkRefreshed(ctx context.Context, at time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Model(&models.AnalyticsRollupState{}).
+		Where("refreshed_at < ?", at).
+		Updates(map[string]any{"refreshed_at": at, "updated_at": time.Now()}).Error; err != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to mark analytics rollups refreshed", err)
+	}
+	return nil
+}
+
+// rollupArgs returns the named arguments of rollupActivitySQL
+func rollupArgs(tenantID uuid.UUID, at time.Time, days []string) map[string]any {
+	return map[string]any{
+		"tenant":    tenantID,
+		"at":        at,
+		"days":      days,
+		"completed": models.BookingStatusCompleted,
+		"cancelled": models.BookingStatusCancelled,
+		"paid":      models.PaymentStatusPaid,
+	}
+}
+
+// findRollupState returns a tenant's rollup state, or nil when its rollups were never
+// built and figures have to be aggregated from the source tables
+func findRollupState(ctx context.Context, db *gorm.DB, tenantID uuid.UUID) (*models.AnalyticsRollupState, error) {
+	var states []*models.AnalyticsRollupState
+	if err := db.WithContext(ctx).Where("tenant_id = ?", tenantID).Limit(1).Find(&states).Error; err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return nil, nil
+	}
+	return states[0], nil
+}
@@ -0,0 +1,128 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyticsRollupRepository(t *testing.T) {
+	tdb, bookingRepo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	cfg := testutil.DefaultRepositoryConfig()
+	rollups := repository.NewAnalyticsRollupRepository(tdb.DB, cfg)
+	payments := repository.NewPaymentRepository(tdb.DB, cfg)
+
+	now := time.Now().UTC()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day(), 10, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+	book := func(status models.BookingStatus, price float64, start time.Time) *models.Booking {
+		booking := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID, func(b *models.Booking) {
+			b.Status = status
+			b.TotalPrice = price
+			b.StartTime = start
+			b.EndTime = start.Add(time.Hour)
+		})
+		require.NoError(t, bookingRepo.Create(ctx, booking))
+		return booking
+	}
+	completed := book(models.BookingStatusCompleted, 120, yesterday)
+	book(models.BookingStatusCancelled, 80, yesterday)
+	paidAt := yesterday.Add(2 * time.Hour)
+	require.NoError(t, payments.Create(ctx, testutil.CreateTestPayment(tenantID, completed.ID, customerID, func(p *models.Payment) {
+		p.Amount = 120
+		p.Status = models.PaymentStatusPaid
+		p.ProcessedAt = &paidAt
+	})))
+
+	live, err := bookingRepo.GetBookingTrends(ctx, tenantID, 7)
+	require.NoError(t, err)
+	require.Len(t, live, 1)
+	assert.Nil(t, live[0].AsOf, "trends are aggregated live until the rollups are built")
+
+	state, err := rollups.GetState(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	builtAt := time.Now()
+	require.NoError(t, rollups.Rebuild(ctx, tenantID, builtAt))
+
+	t.Run("trends are read from the rollups", func(t *testing.T) {
+		trends, err := bookingRepo.GetBookingTrends(ctx, tenantID, 7)
+		require.NoError(t, err)
+		require.Len(t, trends, 1)
+		assert.Equal(t, live[0].BookingCount, trends[0].BookingCount)
+		assert.Equal(t, int64(2), trends[0].BookingCount)
+		assert.Equal(t, int64(1), trends[0].CompletedCount)
+		assert.Equal(t, 120.0, trends[0].Revenue)
+		require.NotNil(t, trends[0].AsOf)
+		assert.WithinDuration(t, builtAt, *trends[0].AsOf, time.Second)
+	})
+
+	t.Run("revenue is read from the rollups", func(t *testing.T) {
+		revenue, err := payments.GetRevenueByPeriod(ctx, tenantID, yesterday.AddDate(0, 0, -1), now, "day")
+		require.NoError(t, err)
+		require.Len(t, revenue, 1)
+		assert.Equal(t, 120.0, revenue[0].Revenue)
+		assert.Equal(t, int64(1), revenue[0].TransactionCount)
+		assert.NotNil(t, revenue[0].AsOf)
+	})
+
+	t.Run("changed days are refreshed", func(t *testing.T) {
+		since := time.Now()
+		book(models.BookingStatusCompleted, 30, yesterday)
+
+		changed, err := rollups.FindChangedDays(ctx, since)
+		require.NoError(t, err)
+		require.Len(t, changed[tenantID], 1)
+
+		refreshedAt := time.Now()
+		require.NoError(t, rollups.RefreshDays(ctx, tenantID, changed[tenantID], refreshedAt))
+		require.NoError(t, rollups.MarkRefreshed(ctx, refreshedAt))
+
+		trends, err := bookingRepo.GetBookingTrends(ctx, tenantID, 7)
+		require.NoError(t, err)
+		require.Len(t, trends, 1)
+		assert.Equal(t, int64(3), trends[0].BookingCount)
+		assert.Equal(t, 150.0, trends[0].Revenue)
+		assert.WithinDuration(t, refreshedAt, *trends[0].AsOf, time.Second)
+	})
+
+	t.Run("a rebuild keeps the rollups of archived days", func(t *testing.T) {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repository.NewArchiveRepository(tdb.DB, cfg).CreateArchive(ctx, &models.DataArchive{
+			SourceTable: "bookings",
+			PeriodStart: today.AddDate(0, -1, 0),
+			PeriodEnd:   today,
+			ObjectKey:   "archive/bookings/test.jsonl.gz",
+			ArchivedAt:  time.Now(),
+		}))
+		require.NoError(t, tdb.DB.Exec("DELETE FROM bookings WHERE tenant_id = ?", tenantID).Error)
+
+		require.NoError(t, rollups.Rebuild(ctx, tenantID, time.Now()))
+
+		trends, err := bookingRepo.GetBookingTrends(ctx, tenantID, 7)
+		require.NoError(t, err)
+		require.Len(t, trends, 1)
+		assert.Equal(t, int64(3), trends[0].BookingCount, "yesterday is before the horizon")
+	})
+
+	t.Run("rebuilt tenants are not due again the same day", func(t *testing.T) {
+		due, err := rollups.FindTenantsToRebuild(ctx, builtAt.Add(-time.Minute))
+		require.NoError(t, err)
+		assert.NotContains(t, due, tenantID)
+
+		due, err = rollups.FindTenantsToRebuild(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Contains(t, due, tenantID)
+	})
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArchivedTables are the tables partitioned by month of created_at, whose old months
+// are archived to cold storage
+var ArchivedTables = []string{"bookings", "payments"}
+
+// Partition is one monthly partition of a partitioned table
+type Partition struct {
+	Name  string
+	Start time.Time // First instant of the month, UTC
+	End   time.Time // First instant of the next month
+}
+
+// ArchiveRepository maintains the monthly partitions of bookings and payments and the
+// manifest of the months exported to cold storage (see models.DataArchive)
+type ArchiveRepository interface {
+	// IsPartitioned reports whether a table is partitioned. Tables created from the
+	// models rather than the migrations, as in tests, are not.
+	IsPartitioned(ctx context.Context, table string) (bool, error)
+
+	// ListPartitions returns a table's monthly partitions, the oldest first
+	ListPartitions(ctx context.Context, table string) ([]Partition, error)
+
+	// EnsurePartitions creates a table's monthly partitions from the month of from
+	// through the month of to, leaving existing ones alone
+	EnsurePartitions(ctx context.Context, table string, from, to time.Time) error
+
+	// CountDefaultRows counts a table's rows that fell outside every monthly partition
+	CountDefaultRows(ctx context.Context, table string) (int64, error)
+
+	// StreamPartition calls fn with every row of a partition as a JSON object and
+	// returns how many rows there were
+	StreamPartition(ctx context.Context, partition string, fn func(row []byte) error) (int64, error)
+
+	// DropPartition detaches a partition from its table and drops it with its rows
+	DropPartition(ctx context.Context, table, partition string) error
+
+	// FindArchive returns the archive of a table's month, or nil when it was not archived
+	FindArchive(ctx context.Context, table string, periodStart time.Time) (*models.DataArchive, error)
+
+	// CreateArchive records an exported month
+	CreateArchive(ctx context.Context, archive *models.DataArchive) error
+
+	// ListArchives returns a table's archived months, the oldest first
+	ListArchives(ctx context.Context, table string) ([]*models.DataArchive, error)
+}
+
+// archiveRepository implements ArchiveRepository
+type archiveRepository struct {
+	db       *gorm.DB
+	logger   log.AllLogger
+	timeouts QueryTimeouts
+}
+
+// NewArchiveRepository creates a new ArchiveRepository instance
+func NewArchiveRepository(db *gorm.DB, config ...RepositoryConfig) ArchiveRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return &archiveRepository{
+		db:       db,
+		logger:   cfg.Logger,
+		timeouts: cfg.QueryTimeouts,
+	}
+}
+
+// partitionNamePattern matches the names create_monthly_partition gives partitions
+var partitionNamePattern = regexp.MustCompile(`^(.+)_p(\d{4})_(\d{2})$`)
+
+// IsPartitioned looks the table up in the catalog of partitioned tables
+func (r *archiveRepository) IsPartitioned(ctx context.Context, table string) (bool, error) {
+	var partitioned bool
+	if err := r.db.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = to_regclass(?))", table).
+		Scan(&partitioned).Error; err != nil {
+		return false, errors.NewRepositoryError("FIND_FAILED", "failed to check whether "+table+" is partitioned", err)
+	}
+	return partitioned, nil
+}
+
+// ListPartitions reads the table's partitions from the catalog and keeps the monthly
+// ones, whose month is in their name
+func (r *archiveRepository) ListPartitions(ctx context.Context, table string) ([]Partition, error) {
+	var names []string
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT child.relname
+		FROM pg_inherits inh
+		JOIN pg_class child ON child.oid = inh.inhrelid
+		WHERE inh.inhparent = to_regclass(?)`, table).
+		Scan(&names).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to list partitions of "+table, err)
+	}
+
+	partitions := make([]Partition, 0, len(names))
+	for _, name := range names {
+		start, ok := parsePartitionMonth(table, name)
+		if !ok {
+			continue
+		}
+		partitions = append(partitions, Partition{Name: name, Start: start, End: start.AddDate(0, 1, 0)})
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i].Start.Before(partitions[j].Start) })
+	return partitions, nil
+}
+
+// EnsurePartitions creates the partitions one month at a time, each in its own
+// statement, so a month that can't be created doesn't hold back the others
+func (r *archiveRepository) EnsurePartitions(ctx context.Context, table string, from, to time.Time) error {
+	var errs []error
+	for month := monthStart(from); !month.After(to); month = month.AddDate(0, 1, 0) {
+		if err := r.db.WithContext(ctx).
+			Exec("SELECT create_monthly_partition(?, ?::date)", table, month.Format(time.DateOnly)).Error; err != nil {
+			r.logger.Error("failed to create partition", "table", table, "month", month.Format("2006-01"), "error", err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewRepositoryError("CREATE_FAILED", "failed to create partitions of "+table, stdErrors.Join(errs...))
+	}
+	return nil
+}
+
+// CountDefaultRows counts the rows of the table's default partition
+func (r *archiveRepository) CountDefaultRows(ctx context.Context, table string) (int64, error) {
+	ctx, cancel := r.timeouts.WithTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Raw("SELECT COUNT(*) FROM ?", clause.Table{Name: table + "_default"}).
+		Scan(&count).Error; err != nil {
+		return 0, errors.NewRepositoryError("COUNT_FAILED", "failed to count rows of the default partition of "+table, err)
+	}
+	return count, nil
+}
+
+// StreamPartition reads the partition row by row in creation order. The read is not
+// bounded by a query timeout, as a month of a large table takes a while to export.
+func (r *archiveRepository) StreamPartition(ctx context.Context, partition string, fn func(row []byte) error) (int64, error) {
+	rows, err := r.db.WithContext(ctx).
+		Raw("SELECT row_to_json(t)::text FROM ? t ORDER BY t.created_at, t.id", clause.Table{Name: partition}).
+		Rows()
+	if err != nil {
+		return 0, errors.NewRepositoryError("FIND_FAILED", "failed to read partition "+partition, err)
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return count, errors.NewRepositoryError("FIND_FAILED", "failed to read partition "+partition, err)
+		}
+		if err := fn(row); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, errors.NewRepositoryError("FIND_FAILED", "failed to read partition "+partition, err)
+	}
+	return count, nil
+}
+
+// DropPartition detaches and drops the partition in one transaction, refusing names
+// that aren't monthly partitions of the table
+func (r *archiveRepository) DropPartition(ctx context.Context, table, partition string) error {
+	if _, ok := parsePartitionMonth(table, partition); !ok {
+		return errors.NewRepositoryError("INVALID_PARTITION", fmt.Sprintf("%s is not a monthly partition of %s", partition, table), nil)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("ALTER TABLE ? DETACH PARTITION ?", clause.Table{Name: table}, clause.Table{Name: partition}).Error; err != nil {
+			return err
+		}
+		return tx.Exec("DROP TABLE ?", clause.Table{Name: partition}).Error
+	})
+	if err != nil {
+		r.logger.Error("failed to drop partition", "table", table, "partition", partition, "error", err)
+		return errors.NewRepositoryError("DELETE_FAILED", "failed to drop partition "+partition, err)
+	}
+	return nil
+}
+
+// FindArchive retrieves the archive of a table's month
+func (r *archiveRepository) FindArchive(ctx context.Context, table string, periodStart time.Time) (*models.DataArchive, error) {
+	var archives []*models.DataArchive
+	if err := r.db.WithContext(ctx).
+		Where("source_table = ? AND period_start = ?", table, periodStart).
+		Limit(1).
+		Find(&archives).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find data archive", err)
+	}
+	if len(archives) == 0 {
+		return nil, nil
+	}
+	return archives[0], nil
+}
+
+// CreateArchive inserts a manifest entry
+func (r *archiveRepository) CreateArchive(ctx context.Context, archive *models.DataArchive) error {
+	if err := r.db.WithContext(ctx).Create(archive).Error; err != nil {
+		return errors.NewRepositoryError("CREATE_FAILED", "failed to record data archive", err)
+	}
+	return nil
+}
+
+// ListArchives retrieves a table's manifest entries
+func (r *archiveRepository) ListArchives(ctx context.Context, table string) ([]*models.DataArchive, error) {
+	var archives []*models.DataArchive
+	if err := r.db.WithContext(ctx).
+		Where("source_table = ?", table).
+		Order("period_start ASC").
+		Find(&archives).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to list data archives", err)
+	}
+	return archives, nil
+}
+
+// findArchiveHorizon returns the end of the latest archived month of bookings or
+// payments, before which their rows may be gone from the database, or nil when
+// nothing was archived
+func findArchiveHorizon(ctx context.Context, db *gorm.DB) (*time.Time, error) {
+	var horizon *time.Time
+	if err := db.WithContext(ctx).
+		Model(&models.DataArchive{}).
+		Where("source_table IN ?", ArchivedTables).
+		Select("MAX(period_end)").
+		Scan(&horizon).Error; err != nil {
+		return nil, err
+	}
+	return horizon, nil
+}
+
+// parsePartitionMonth returns the month a partition of the table covers, if its name
+// is that of a monthly partition of the table
+func parsePartitionMonth(table, partition string) (time.Time, bool) {
+	match := partitionNamePattern.FindStringSubmatch(partition)
+	if match == nil || match[1] != table {
+		return time.Time{}, false
+	}
+	start, err := time.Parse("2006_01", match[2]+"_"+match[3])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+// monthStart returns the first instant of t's month in UTC
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,159 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/database"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// partitionTestSchema applies the partitioning migration to the test schema, after the
+// row-level security it recreates the policies of
+func partitionTestSchema(t *testing.T, tdb *testutil.TestDB) {
+	migrations, err := database.EmbeddedMigrations()
+	require.NoError(t, err)
+	sqlDB, err := tdb.DB.DB()
+	require.NoError(t, err)
+
+	// The manifest table is created by the migration
+	require.NoError(t, tdb.DB.Exec(`DROP TABLE "data_archives"`).Error)
+	for _, migration := range migrations {
+		if migration.Name == "tenant_row_level_security" || migration.Name == "partition_bookings_payments" {
+			_, err := sqlDB.ExecContext(context.Background(), migration.UpSQL)
+			require.NoError(t, err, migration.Name)
+		}
+	}
+}
+
+func TestArchiveRepository(t *testing.T) {
+	tdb, bookingRepo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	archives := repository.NewArchiveRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	oldMonth := thisMonth.AddDate(0, -14, 0)
+
+	book := func(createdAt time.Time) *models.Booking {
+		booking := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID, func(b *models.Booking) {
+			b.CreatedAt = createdAt
+			b.StartTime = createdAt.Add(24 * time.Hour)
+			b.EndTime = b.StartTime.Add(time.Hour)
+		})
+		require.NoError(t, bookingRepo.Create(ctx, booking))
+		return booking
+	}
+	old := book(oldMonth.Add(36 * time.Hour))
+	book(oldMonth.Add(72 * time.Hour))
+	recent := book(now)
+
+	partitioned, err := archives.IsPartitioned(ctx, "bookings")
+	require.NoError(t, err)
+	assert.False(t, partitioned, "tables created from the models are not partitioned")
+
+	partitionTestSchema(t, tdb)
+
+	partitioned, err = archives.IsPartitioned(ctx, "bookings")
+	require.NoError(t, err)
+	assert.True(t, partitioned)
+
+	t.Run("rows are moved into monthly partitions", func(t *testing.T) {
+		partitions, err := archives.ListPartitions(ctx, "bookings")
+		require.NoError(t, err)
+		require.NotEmpty(t, partitions)
+		assert.Equal(t, "bookings_p"+oldMonth.Format("2006_01"), partitions[0].Name)
+		assert.Equal(t, oldMonth, partitions[0].Start)
+		assert.Equal(t, oldMonth.AddDate(0, 1, 0), partitions[0].End)
+		assert.Equal(t, thisMonth.AddDate(0, 3, 0), partitions[len(partitions)-1].Start, "three months are created ahead")
+
+		found, err := bookingRepo.GetByID(ctx, recent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, recent.ID, found.ID)
+
+		stray, err := archives.CountDefaultRows(ctx, "bookings")
+		require.NoError(t, err)
+		assert.Zero(t, stray)
+	})
+
+	t.Run("partitions are created ahead idempotently", func(t *testing.T) {
+		require.NoError(t, archives.EnsurePartitions(ctx, "bookings", now, now.AddDate(0, 5, 0)))
+		require.NoError(t, archives.EnsurePartitions(ctx, "bookings", now, now.AddDate(0, 5, 0)))
+
+		partitions, err := archives.ListPartitions(ctx, "bookings")
+		require.NoError(t, err)
+		assert.Equal(t, thisMonth.AddDate(0, 5, 0), partitions[len(partitions)-1].Start)
+	})
+
+	t.Run("a partition streams as JSON rows and drops", func(t *testing.T) {
+		partition := "bookings_p" + oldMonth.Format("2006_01")
+
+		var ids []uuid.UUID
+		count, err := archives.StreamPartition(ctx, partition, func(row []byte) error {
+			var booking struct {
+				ID uuid.UUID `json:"id"`
+			}
+			if err := json.Unmarshal(row, &booking); err != nil {
+				return err
+			}
+			ids = append(ids, booking.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		require.Len(t, ids, 2)
+		assert.Equal(t, old.ID, ids[0], "rows stream in creation order")
+
+		assert.Error(t, archives.DropPartition(ctx, "payments", partition), "only the table's own partitions are dropped")
+		require.NoError(t, archives.DropPartition(ctx, "bookings", partition))
+
+		_, err = bookingRepo.GetByID(ctx, old.ID)
+		assert.Error(t, err)
+		_, err = bookingRepo.GetByID(ctx, recent.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("archives are recorded once per month", func(t *testing.T) {
+		archive := &models.DataArchive{
+			SourceTable: "bookings",
+			PeriodStart: oldMonth,
+			PeriodEnd:   oldMonth.AddDate(0, 1, 0),
+			ObjectKey:   models.DataArchiveKey("bookings", oldMonth),
+			RowCount:    2,
+			ArchivedAt:  now,
+		}
+		require.NoError(t, archives.CreateArchive(ctx, archive))
+		assert.Equal(t, "archive/bookings/"+oldMonth.Format("2006-01")+".jsonl.gz", archive.ObjectKey)
+
+		found, err := archives.FindArchive(ctx, "bookings", oldMonth)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, int64(2), found.RowCount)
+
+		missing, err := archives.FindArchive(ctx, "payments", oldMonth)
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+
+		assert.Error(t, archives.CreateArchive(ctx, &models.DataArchive{
+			SourceTable: "bookings",
+			PeriodStart: oldMonth,
+			PeriodEnd:   oldMonth.AddDate(0, 1, 0),
+			ObjectKey:   "elsewhere",
+			ArchivedAt:  now,
+		}))
+
+		listed, err := archives.ListArchives(ctx, "bookings")
+		require.NoError(t, err)
+		assert.Len(t, listed, 1)
+	})
+}
@@ -34,14 +34,24 @@ type ArtisanRepository interface {
 	// UpdateRating updates an artisan's rating
 	UpdateRating(ctx context.Context, artisanID uuid.UUID, newRating float64, reviewCount int) error
 
+	// UpdateBadges sets the badges an artisan's verification documents earn
+	UpdateBadges(ctx context.Context, artisanID uuid.UUID, badges models.Badges) error
+
 	// UpdateAvailability updates availability status
 	UpdateAvailability(ctx context.Context, artisanID uuid.UUID, isAvailable bool, note string) error
 
+	// RotateCalendarToken bumps the version signed into the artisan's calendar feed
+	// token and returns the new version
+	RotateCalendarToken(ctx context.Context, artisanID uuid.UUID) (int, error)
+
 	// Search searches artisans by name, bio, or specialization
 	Search(ctx context.Context, tenantID uuid.UUID, query string, pagination PaginationParams) ([]*models.Artisan, PaginationResult, error)
 
-	// FindNearby finds artisans near a location (requires PostGIS)
-	FindNearby(ctx context.Context, tenantID uuid.UUID, latitude, longitude float64, radiusKm int) ([]*models.Artisan, error)
+	// FindNearby finds artisans located around a point, the nearest first
+	FindNearby(ctx context.Context, tenantID uuid.UUID, query NearbyArtisanQuery) ([]*NearbyArtisan, error)
+
+	// ServesLocation reports whether an artisan travels to a point for mobile services
+	ServesLocation(ctx context.Context, artisanID uuid.UUID, latitude, longitude float64) (bool, error)
 
 	// IncrementBookingCount increments total bookings counter
 	IncrementBookingCount(ctx context.Context, artisanID uuid.UUID) error
@@ -56,6 +66,30 @@ type ArtisanRepository interface {
 	CountByTenant(ctx context.Context, tenantID uuid.UUID) (int64, error)
 }
 
+// NearbyArtisanQuery selects artisans by the distance of their location from a point
+type NearbyArtisanQuery struct {
+	Latitude      float64
+	Longitude     float64
+	RadiusKm      float64    // Maximum distance from the point, unless Bounds is set
+	Bounds        *GeoBounds // Box to search instead, such as a map's viewport
+	AvailableOnly bool
+	Limit         int
+}
+
+// GeoBounds is a box of latitudes and longitudes, West of East
+type GeoBounds struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// NearbyArtisan is an artisan found by FindNearby
+type NearbyArtisan struct {
+	Artisan    *models.Artisan
+	DistanceKm float64 // From the searched point
+}
+
 // artisanRepository implements ArtisanRepository
 type artisanRepository struct {
 	BaseRepository[models.Artisan]
@@ -261,6 +295,33 @@ func (r *artisanRepository) UpdateRating(ctx context.Context, artisanID uuid.UUI
 	return nil
 }
 
+// UpdateBadges updates the verification badges
+func (r *artisanRepository) UpdateBadges(ctx context.Context, artisanID uuid.UUID, badges models.Badges) error {
+	if artisanID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "artisan_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&models.Artisan{}).
+		Where("id = ?", artisanID).
+		Updates(map[string]any{
+			"is_verified": badges.Verified,
+			"is_insured":  badges.Insured,
+		})
+
+	if result.Error != nil {
+		r.logger.Error("failed to update artisan badges", "artisan_id", artisanID, "error", result.Error)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update badges", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewRepositoryError("NOT_FOUND", "artisan not found", errors.ErrNotFound)
+	}
+
+	// Badges show on cached profiles
+	return r.InvalidateCache(ctx, artisanID)
+}
+
 // UpdateAvailability updates availability status
 func (r *artisanRepository) UpdateAvailability(ctx context.Context, artisanID uuid.UUID, isAvailable bool, note string) error {
 	if artisanID == uuid.Nil {
@@ -287,6 +348,35 @@ func (r *artisanRepository) UpdateAvailability(ctx context.Context, artisanID uu
 	return nil
 }
 
+// RotateCalendarToken increments the artisan's calendar token version in one statement,
+// so concurrent rotations each get a new version
+func (r *artisanRepository) RotateCalendarToken(ctx context.Context, artisanID uuid.UUID) (int, error) {
+	if artisanID == uuid.Nil {
+		return 0, errors.NewRepositoryError("INVALID_INPUT", "artisan_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var version int
+	result := r.db.WithContext(ctx).
+		Raw(`UPDATE artisans SET calendar_token_version = calendar_token_version + 1, updated_at = NOW()
+			WHERE id = ? AND deleted_at IS NULL RETURNING calendar_token_version`, artisanID).
+		Scan(&version)
+
+	if result.Error != nil {
+		r.logger.Error("failed to rotate calendar token", "artisan_id", artisanID, "error", result.Error)
+		return 0, errors.NewRepositoryError("UPDATE_FAILED", "failed to rotate calendar token", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return 0, errors.NewRepositoryError("NOT_FOUND", "artisan not found", errors.ErrNotFound)
+	}
+
+	// The version is read through cached profiles
+	if err := r.InvalidateCache(ctx, artisanID); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
 // Search searches artisans by name, bio, or specialization
 func (r *artisanRepository) Search(ctx context.Context, tenantID uuid.UUID, query string, pagination PaginationParams) ([]*models.Artisan, PaginationResult, error) {
 	if tenantID == uuid.Nil {
@@ -333,39 +423,94 @@ func (r *artisanRepository) Search(ctx context.Context, tenantID uuid.UUID, quer
 	return artisans, paginationResult, nil
 }
 
-// FindNearby finds artisans near a location (simplified without PostGIS)
-func (r *artisanRepository) FindNearby(ctx context.Context, tenantID uuid.UUID, latitude, longitude float64, radiusKm int) ([]*models.Artisan, error) {
+// FindNearby finds the artisans whose location lies within the radius of the point or
+// inside the bounds, by the PostGIS point kept of their location. Artisans without
+// coordinates are never found.
+func (r *artisanRepository) FindNearby(ctx context.Context, tenantID uuid.UUID, query NearbyArtisanQuery) ([]*NearbyArtisan, error) {
 	if tenantID == uuid.Nil {
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
 	}
+	if query.Limit <= 0 {
+		query.Limit = 50
+	}
+
+	point := gorm.Expr("ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography", query.Longitude, query.Latitude)
+	db := r.db.WithContext(ctx).
+		Model(&models.Artisan{}).
+		Select("id, ST_Distance(geo_point, ?) / 1000 AS distance_km", point).
+		Where("tenant_id = ? AND geo_point IS NOT NULL", tenantID)
+	if b := query.Bounds; b != nil {
+		db = db.Where("ST_Intersects(geo_point::geometry, ST_MakeEnvelope(?, ?, ?, ?, 4326))", b.West, b.South, b.East, b.North)
+	} else {
+		db = db.Where("ST_DWithin(geo_point, ?, ?)", point, query.RadiusKm*1000)
+	}
+	if query.AvailableOnly {
+		db = db.Where("is_available = ?", true)
+	}
+
+	var distances []struct {
+		ID         uuid.UUID
+		DistanceKm float64
+	}
+	if err := db.Order("distance_km ASC").Limit(query.Limit).Scan(&distances).Error; err != nil {
+		r.logger.Error("failed to find nearby artisans", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find nearby artisans", err)
+	}
+	if len(distances) == 0 {
+		return []*NearbyArtisan{}, nil
+	}
 
-	// Simplified implementation without PostGIS
-	// In production, use ST_DWithin for proper geospatial queries
+	ids := make([]uuid.UUID, len(distances))
+	for i, d := range distances {
+		ids[i] = d.ID
+	}
 	var artisans []*models.Artisan
 	if err := r.db.WithContext(ctx).
 		Preload("User").
-		Where("tenant_id = ? AND is_available = ? AND service_radius >= ?", tenantID, true, 0).
-		Order("rating DESC").
-		Limit(50).
+		Where("id IN ?", ids).
 		Find(&artisans).Error; err != nil {
-		r.logger.Error("failed to find nearby artisans", "tenant_id", tenantID, "error", err)
+		r.logger.Error("failed to load nearby artisans", "tenant_id", tenantID, "error", err)
 		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find nearby artisans", err)
 	}
+	byID := make(map[uuid.UUID]*models.Artisan, len(artisans))
+	for _, artisan := range artisans {
+		byID[artisan.ID] = artisan
+	}
 
-	// TODO: Implement proper distance calculation with PostGIS:
-	// SELECT *, ST_Distance(
-	//   ST_MakePoint(location->>'longitude'::float, location->>'latitude'::float)::geography,
-	//   ST_MakePoint(?, ?)::geography
-	// ) AS distance
-	// FROM artisans
-	// WHERE ST_DWithin(
-	//   ST_MakePoint(location->>'longitude'::float, location->>'latitude'::float)::geography,
-	//   ST_MakePoint(?, ?)::geography,
-	//   ? * 1000
-	// )
-	// ORDER BY distance ASC
+	nearby := make([]*NearbyArtisan, 0, len(distances))
+	for _, d := range distances {
+		if artisan, ok := byID[d.ID]; ok {
+			nearby = append(nearby, &NearbyArtisan{Artisan: artisan, DistanceKm: d.DistanceKm})
+		}
+	}
+	return nearby, nil
+}
 
-	return artisans, nil
+// ServesLocation decides by the artisan's active service areas when they have any: the
+// point must lie in one of them. Otherwise an artisan with a located service radius
+// travels within it, and one without travels anywhere.
+func (r *artisanRepository) ServesLocation(ctx context.Context, artisanID uuid.UUID, latitude, longitude float64) (bool, error) {
+	var serves []bool
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT CASE
+			WHEN EXISTS (SELECT 1 FROM service_areas sa WHERE sa.artisan_id = a.id AND sa.is_active AND sa.deleted_at IS NULL)
+				THEN EXISTS (SELECT 1 FROM service_areas sa WHERE sa.artisan_id = a.id AND sa.is_active AND sa.deleted_at IS NULL AND ST_Covers(sa.area, p.point))
+			WHEN a.geo_point IS NOT NULL AND a.service_radius > 0
+				THEN ST_DWithin(a.geo_point, p.point, a.service_radius * 1000)
+			ELSE TRUE
+		END
+		FROM artisans a, (SELECT ST_SetSRID(ST_MakePoint(@lon, @lat), 4326)::geography AS point) p
+		WHERE a.id = @artisan AND a.deleted_at IS NULL`,
+		map[string]any{"artisan": artisanID, "lat": latitude, "lon": longitude}).
+		Scan(&serves).Error; err != nil {
+		r.logger.Error("failed to check artisan service area", "artisan_id", artisanID, "error", err)
+		return false, errors.NewRepositoryError("FIND_FAILED", "failed to check artisan service area", err)
+	}
+	if len(serves) == 0 {
+		return false, errors.NewRepositoryError("NOT_FOUND", "artisan not found", errors.ErrNotFound)
+	}
+
+	return serves[0], nil
 }
 
 // IncrementBookingCount increments total bookings counter
@@ -0,0 +1,152 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// createLocatedArtisan creates an artisan of the tenant located at the coordinates
+func createLocatedArtisan(t *testing.T, db *gorm.DB, tenantID uuid.UUID, email string, latitude, longitude float64, overrides ...func(*models.Artisan)) *models.Artisan {
+	user := testutil.CreateTestUser(&tenantID, func(u *models.User) {
+		u.Email = email
+		u.Role = models.UserRoleArtisan
+	})
+	require.NoError(t, db.Create(user).Error)
+
+	artisan := testutil.CreateTestArtisan(user.ID, tenantID, func(a *models.Artisan) {
+		a.Location = models.Location{City: "Accra", Latitude: latitude, Longitude: longitude}
+	})
+	for _, override := range overrides {
+		override(artisan)
+	}
+	require.NoError(t, db.Create(artisan).Error)
+	return artisan
+}
+
+func TestArtisanRepository_FindNearby(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Close()
+
+	repo := repository.NewArtisanRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	ctx := context.Background()
+
+	_, tenant := testutil.CreateTestTenantWithOwner(tdb.DB)
+
+	// Osu, about 3km from the searched point; Tema, about 27km; Kumasi, about 200km
+	osu := createLocatedArtisan(t, tdb.DB, tenant.ID, "osu@example.com", 5.556, -0.182)
+	tema := createLocatedArtisan(t, tdb.DB, tenant.ID, "tema@example.com", 5.669, 0.017)
+	kumasi := createLocatedArtisan(t, tdb.DB, tenant.ID, "kumasi@example.com", 6.688, -1.624)
+	createLocatedArtisan(t, tdb.DB, tenant.ID, "unplaced@example.com", 0, 0)
+
+	const latitude, longitude = 5.570, -0.205
+
+	t.Run("within radius, nearest first", func(t *testing.T) {
+		nearby, err := repo.FindNearby(ctx, tenant.ID, repository.NearbyArtisanQuery{Latitude: latitude, Longitude: longitude, RadiusKm: 50})
+		require.NoError(t, err)
+		require.Len(t, nearby, 2)
+		assert.Equal(t, osu.ID, nearby[0].Artisan.ID)
+		assert.Equal(t, tema.ID, nearby[1].Artisan.ID)
+		assert.InDelta(t, 3, nearby[0].DistanceKm, 1)
+		assert.InDelta(t, 27, nearby[1].DistanceKm, 1)
+		assert.NotNil(t, nearby[0].Artisan.User)
+	})
+
+	t.Run("bounding box instead of radius", func(t *testing.T) {
+		nearby, err := repo.FindNearby(ctx, tenant.ID, repository.NearbyArtisanQuery{
+			Latitude:  latitude,
+			Longitude: longitude,
+			RadiusKm:  1,
+			Bounds:    &repository.GeoBounds{South: 5.0, West: -2.0, North: 7.0, East: -0.1},
+		})
+		require.NoError(t, err)
+		require.Len(t, nearby, 2)
+		assert.Equal(t, osu.ID, nearby[0].Artisan.ID)
+		assert.Equal(t, kumasi.ID, nearby[1].Artisan.ID)
+	})
+
+	t.Run("available only", func(t *testing.T) {
+		require.NoError(t, repo.UpdateAvailability(ctx, osu.ID, false, "On leave"))
+
+		nearby, err := repo.FindNearby(ctx, tenant.ID, repository.NearbyArtisanQuery{Latitude: latitude, Longitude: longitude, RadiusKm: 50, AvailableOnly: true})
+		require.NoError(t, err)
+		require.Len(t, nearby, 1)
+		assert.Equal(t, tema.ID, nearby[0].Artisan.ID)
+	})
+
+	t.Run("other tenants' artisans are not found", func(t *testing.T) {
+		nearby, err := repo.FindNearby(ctx, uuid.New(), repository.NearbyArtisanQuery{Latitude: latitude, Longitude: longitude, RadiusKm: 500})
+		require.NoError(t, err)
+		assert.Empty(t, nearby)
+	})
+}
+
+func TestArtisanRepository_ServesLocation(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Close()
+
+	repo := repository.NewArtisanRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	areas := repository.NewServiceAreaRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	ctx := context.Background()
+
+	_, tenant := testutil.CreateTestTenantWithOwner(tdb.DB)
+
+	anywhere := createLocatedArtisan(t, tdb.DB, tenant.ID, "anywhere@example.com", 0, 0)
+	radius := createLocatedArtisan(t, tdb.DB, tenant.ID, "radius@example.com", 5.556, -0.182, func(a *models.Artisan) {
+		a.ServiceRadius = 10
+	})
+	zoned := createLocatedArtisan(t, tdb.DB, tenant.ID, "zoned@example.com", 5.556, -0.182, func(a *models.Artisan) {
+		a.ServiceRadius = 500
+	})
+
+	// Central Accra
+	area := &models.ServiceArea{
+		TenantID:  tenant.ID,
+		ArtisanID: zoned.ID,
+		Name:      "Central Accra",
+		Boundary:  models.NewGeoPolygon([2]float64{-0.25, 5.52}, [2]float64{-0.15, 5.52}, [2]float64{-0.15, 5.62}, [2]float64{-0.25, 5.62}),
+		IsActive:  true,
+	}
+	require.NoError(t, areas.Create(ctx, area))
+
+	tests := []struct {
+		name      string
+		artisanID uuid.UUID
+		lat, lon  float64
+		want      bool
+	}{
+		{"no area or radius travels anywhere", anywhere.ID, 6.688, -1.624, true},
+		{"within radius", radius.ID, 5.600, -0.190, true},
+		{"beyond radius", radius.ID, 5.669, 0.017, false},
+		{"inside service area", zoned.ID, 5.570, -0.205, true},
+		{"outside service area though within radius", zoned.ID, 5.669, 0.017, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serves, err := repo.ServesLocation(ctx, tt.artisanID, tt.lat, tt.lon)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, serves)
+		})
+	}
+
+	t.Run("inactive areas fall back to the radius", func(t *testing.T) {
+		require.NoError(t, areas.SetActive(ctx, area.ID, false))
+
+		serves, err := repo.ServesLocation(ctx, zoned.ID, 5.669, 0.017)
+		require.NoError(t, err)
+		assert.True(t, serves)
+	})
+
+	t.Run("unknown artisan", func(t *testing.T) {
+		_, err := repo.ServesLocation(ctx, uuid.New(), 5.570, -0.205)
+		assert.Error(t, err)
+	})
+}
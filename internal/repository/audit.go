@@ -12,11 +12,13 @@ import (
 	"gorm.io/gorm"
 )
 
-// AuditLogger interface for audit logging
+// AuditLogger interface for audit logging. Repositories configured with one report
+// every entity they create, update or delete; values are keyed by JSON field name and
+// leave out related entities.
 type AuditLogger interface {
 	LogCreate(ctx context.Context, entityType string, entityID uuid.UUID, entity any)
 	LogUpdate(ctx context.Context, entityType string, entityID uuid.UUID, oldValues, newValues map[string]any)
-	LogDelete(ctx context.Context, entityType string, entityID uuid.UUID)
+	LogDelete(ctx context.Context, entityType string, entityID uuid.UUID, oldValues map[string]any)
 	LogAction(ctx context.Context, action models.AuditAction, entityType string, entityID uuid.UUID, description string, metadata map[string]any)
 }
 
@@ -52,8 +54,8 @@ func (l *DatabaseAuditLogger) LogUpdate(ctx context.Context, entityType string,
 }
 
 // LogDelete logs a delete action
-func (l *DatabaseAuditLogger) LogDelete(ctx context.Context, entityType string, entityID uuid.UUID) {
-	l.logAction(ctx, models.AuditActionDelete, entityType, entityID, fmt.Sprintf("Deleted %s", entityType), nil, nil, nil)
+func (l *DatabaseAuditLogger) LogDelete(ctx context.Context, entityType string, entityID uuid.UUID, oldValues map[string]any) {
+	l.logAction(ctx, models.AuditActionDelete, entityType, entityID, fmt.Sprintf("Deleted %s", entityType), nil, nil, oldValues)
 }
 
 // LogAction logs a custom action
@@ -155,7 +157,8 @@ func (l *NoOpAuditLogger) LogCreate(ctx context.Context, entityType string, enti
 }
 func (l *NoOpAuditLogger) LogUpdate(ctx context.Context, entityType string, entityID uuid.UUID, oldValues, newValues map[string]any) {
 }
-func (l *NoOpAuditLogger) LogDelete(ctx context.Context, entityType string, entityID uuid.UUID) {}
+func (l *NoOpAuditLogger) LogDelete(ctx context.Context, entityType string, entityID uuid.UUID, oldValues map[string]any) {
+}
 func (l *NoOpAuditLogger) LogAction(ctx context.Context, action models.AuditAction, entityType string, entityID uuid.UUID, description string, metadata map[string]any) {
 }
 
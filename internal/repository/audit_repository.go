@@ -16,6 +16,9 @@ import (
 type AuditLogRepository interface {
 	BaseRepository[models.AuditLog]
 
+	// List retrieves a tenant's audit logs matching the filter, newest first
+	List(ctx context.Context, filter AuditLogFilter, pagination PaginationParams) ([]*models.AuditLog, PaginationResult, error)
+
 	// FindByTenant retrieves audit logs for a tenant
 	FindByTenant(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.AuditLog, PaginationResult, error)
 
@@ -46,9 +49,6 @@ type AuditLogRepository interface {
 	// GetSystemActivity retrieves system-wide activity summary
 	GetSystemActivity(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (map[string]any, error)
 
-	// CleanupOldLogs removes audit logs older than specified duration
-	CleanupOldLogs(ctx context.Context, retentionDays int) error
-
 	// CountByAction counts logs by action type
 	CountByAction(ctx context.Context, tenantID uuid.UUID, action models.AuditAction) (int64, error)
 
@@ -59,6 +59,18 @@ type AuditLogRepository interface {
 	FindByIPAddress(ctx context.Context, tenantID uuid.UUID, ipAddress string, pagination PaginationParams) ([]*models.AuditLog, PaginationResult, error)
 }
 
+// AuditLogFilter narrows audit log listings
+type AuditLogFilter struct {
+	TenantID   uuid.UUID
+	EntityType string
+	EntityID   *uuid.UUID
+	UserID     *uuid.UUID
+	Action     models.AuditAction
+	RequestID  string
+	From       *time.Time // Recorded at or after
+	To         *time.Time // Recorded before
+}
+
 // auditLogRepository implements AuditLogRepository
 type auditLogRepository struct {
 	BaseRepository[models.AuditLog]
@@ -82,6 +94,56 @@ func NewAuditLogRepository(db *gorm.DB, config ...RepositoryConfig) AuditLogRepo
 	}
 }
 
+// List retrieves a tenant's audit logs matching the filter, newest first
+func (r *auditLogRepository) List(ctx context.Context, filter AuditLogFilter, pagination PaginationParams) ([]*models.AuditLog, PaginationResult, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).Model(&models.AuditLog{}).Where("tenant_id = ?", filter.TenantID)
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		r.logger.Error("failed to count audit logs", "tenant_id", filter.TenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count audit logs", err)
+	}
+
+	var logs []*models.AuditLog
+	if err := query.
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("created_at DESC, id DESC").
+		Find(&logs).Error; err != nil {
+		r.logger.Error("failed to list audit logs", "tenant_id", filter.TenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to list audit logs", err)
+	}
+
+	return logs, CalculatePagination(pagination, totalItems), nil
+}
+
 // FindByTenant retrieves audit logs for a tenant
 func (r *auditLogRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.AuditLog, PaginationResult, error) {
 	if tenantID == uuid.Nil {
@@ -418,27 +480,6 @@ func (r *auditLogRepository) GetSystemActivity(ctx context.Context, tenantID uui
 	return activity, nil
 }
 
-// CleanupOldLogs removes audit logs older than specified duration
-func (r *auditLogRepository) CleanupOldLogs(ctx context.Context, retentionDays int) error {
-	if retentionDays <= 0 {
-		retentionDays = 90 // Default to 90 days
-	}
-
-	cutoffDate := time.Now().AddDate(0, 0, -retentionDays)
-
-	result := r.db.WithContext(ctx).
-		Where("created_at < ?", cutoffDate).
-		Delete(&models.AuditLog{})
-
-	if result.Error != nil {
-		r.logger.Error("failed to cleanup old audit logs", "error", result.Error)
-		return errors.NewRepositoryError("DELETE_FAILED", "failed to cleanup old audit logs", result.Error)
-	}
-
-	r.logger.Info("cleaned up old audit logs", "count", result.RowsAffected, "retention_days", retentionDays)
-	return nil
-}
-
 // CountByAction counts logs by action type
 func (r *auditLogRepository) CountByAction(ctx context.Context, tenantID uuid.UUID, action models.AuditAction) (int64, error) {
 	if tenantID == uuid.Nil {
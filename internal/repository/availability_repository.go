@@ -64,7 +64,7 @@ func (r *availabilityRepository) Update(ctx context.Context, availability *model
 }
 
 func (r *availabilityRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.Availability{}, "id = ?", id).Error
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.Availability{}, "id = ?", id).Error
 }
 
 func (r *availabilityRepository) ListByArtisan(ctx context.Context, artisanID uuid.UUID, page, pageSize int) ([]*models.Availability, int64, error) {
@@ -189,7 +189,7 @@ func (r *availabilityRepository) BulkCreate(ctx context.Context, availabilities
 }
 
 func (r *availabilityRepository) DeleteByArtisanAndType(ctx context.Context, artisanID uuid.UUID, availabilityType models.AvailabilityType) error {
-	return r.db.WithContext(ctx).
+	return r.db.WithContext(ctx).Unscoped().
 		Where("artisan_id = ? AND type = ?", artisanID, availabilityType).
 		Delete(&models.Availability{}).Error
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BaseRepository defines the interface for base repository operations
@@ -28,6 +29,10 @@ type BaseRepository[T any] interface {
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) error
 
+	// Trash operations
+	FindDeleted(ctx context.Context, filters map[string]any, pagination PaginationParams) ([]*T, PaginationResult, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+
 	// Query operations
 	Find(ctx context.Context, filters map[string]any) ([]*T, error)
 	FindWithPagination(ctx context.Context, filters map[string]any, pagination PaginationParams) ([]*T, PaginationResult, error)
@@ -36,11 +41,16 @@ type BaseRepository[T any] interface {
 
 	// Cache operations
 	InvalidateCache(ctx context.Context, id uuid.UUID) error
-	InvalidateCachePattern(ctx context.Context, pattern string) error
+	InvalidateCacheMany(ctx context.Context, ids []uuid.UUID) error
+	RepositoryCache() *RepositoryCache
 
 	// Transaction support
 	WithTransaction(ctx context.Context, fn func(*gorm.DB) error) error
 	GetDB() *gorm.DB
+
+	// WithQueryTimeout bounds ctx by the configured budget of an operation, such as
+	// OperationAnalytics for reporting queries
+	WithQueryTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc)
 }
 
 // baseRepository implements BaseRepository interface
@@ -49,18 +59,19 @@ type baseRepository[T any] struct {
 	logger      log.AllLogger
 	tableName   string
 	auditLogger AuditLogger
-	cache       Cache
+	cache       *RepositoryCache
 	metrics     MetricsCollector
-	cacheTTL    time.Duration
+	timeouts    QueryTimeouts
 }
 
 // RepositoryConfig holds configuration for repository
 type RepositoryConfig struct {
-	Logger      log.AllLogger
-	AuditLogger AuditLogger
-	Cache       Cache
-	Metrics     MetricsCollector
-	CacheTTL    time.Duration // Default cache TTL
+	Logger        log.AllLogger
+	AuditLogger   AuditLogger
+	Cache         Cache
+	Metrics       MetricsCollector
+	CacheTTL      time.Duration // Default cache TTL
+	QueryTimeouts QueryTimeouts // Zero leaves operations unbounded
 }
 
 // Cache interface for caching operations
@@ -78,6 +89,8 @@ type MetricsCollector interface {
 	RecordCacheHit(table string)
 	RecordCacheMiss(table string)
 	RecordQueryCount(table string, count int64)
+	RecordSlowQuery(operation string, table string, duration time.Duration)
+	RecordQueryOverBudget(operation string, table string)
 }
 
 // DefaultCacheAdapter adapts our Redis client to the Cache interface
@@ -141,6 +154,14 @@ func (m *DefaultMetricsCollector) RecordQueryCount(table string, count int64) {
 	// For now, we'll just log it
 }
 
+func (m *DefaultMetricsCollector) RecordSlowQuery(operation string, table string, duration time.Duration) {
+	m.prometheus.RecordDBSlowQuery(operation, table)
+}
+
+func (m *DefaultMetricsCollector) RecordQueryOverBudget(operation string, table string) {
+	m.prometheus.RecordDBQueryOverBudget(operation, table)
+}
+
 // NewBaseRepository creates a new base repository
 func NewBaseRepository[T any](db *gorm.DB, config ...RepositoryConfig) BaseRepository[T] {
 	var cfg RepositoryConfig
@@ -162,14 +183,17 @@ func NewBaseRepository[T any](db *gorm.DB, config ...RepositoryConfig) BaseRepos
 		logger:      cfg.Logger,
 		tableName:   tableName,
 		auditLogger: cfg.AuditLogger,
-		cache:       cfg.Cache,
+		cache:       NewRepositoryCache(tableName, cfg),
 		metrics:     cfg.Metrics,
-		cacheTTL:    cfg.CacheTTL,
+		timeouts:    cfg.QueryTimeouts,
 	}
 }
 
 // Create creates a new entity
 func (r *baseRepository[T]) Create(ctx context.Context, entity *T) error {
+	ctx, cancel := r.WithQueryTimeout(ctx, "create")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -196,16 +220,14 @@ func (r *baseRepository[T]) Create(ctx context.Context, entity *T) error {
 		return errors.NewRepositoryError("CREATE_FAILED", "failed to create entity", err)
 	}
 
-	// Invalidate list cache after creation
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("list:*"))
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("count:*"))
-	}
+	// Queries that may now include the entity are stale
+	tenantID, _ := getEntityTenantID(entity)
+	r.cache.Invalidate(ctx, tenantID, getEntityID(entity))
 
 	// Log audit trail
 	if r.auditLogger != nil {
 		if id := getEntityID(entity); id != uuid.Nil {
-			r.auditLogger.LogCreate(ctx, r.tableName, id, entity)
+			r.auditLogger.LogCreate(ctx, r.tableName, id, entityToMap(entity))
 		}
 	}
 
@@ -215,6 +237,9 @@ func (r *baseRepository[T]) Create(ctx context.Context, entity *T) error {
 
 // CreateBatch creates multiple entities in a batch
 func (r *baseRepository[T]) CreateBatch(ctx context.Context, entities []*T) error {
+	ctx, cancel := r.WithQueryTimeout(ctx, "create_batch")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -236,10 +261,14 @@ func (r *baseRepository[T]) CreateBatch(ctx context.Context, entities []*T) erro
 		return errors.NewRepositoryError("CREATE_BATCH_FAILED", "failed to create batch", err)
 	}
 
-	// Invalidate list cache after batch creation
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("list:*"))
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("count:*"))
+	// Queries of every tenant in the batch are stale
+	byTenant := make(map[uuid.UUID][]uuid.UUID)
+	for _, entity := range entities {
+		tenantID, _ := getEntityTenantID(entity)
+		byTenant[tenantID] = append(byTenant[tenantID], getEntityID(entity))
+	}
+	for tenantID, ids := range byTenant {
+		r.cache.Invalidate(ctx, tenantID, ids...)
 	}
 
 	r.logger.Debug("batch created", "table", r.tableName, "count", len(entities))
@@ -248,6 +277,9 @@ func (r *baseRepository[T]) CreateBatch(ctx context.Context, entities []*T) erro
 
 // GetByID retrieves an entity by ID with caching
 func (r *baseRepository[T]) GetByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, "get_by_id")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -259,25 +291,11 @@ func (r *baseRepository[T]) GetByID(ctx context.Context, id uuid.UUID) (*T, erro
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	// Try to get from cache first
-	cacheKey := r.getCacheKey("id", id.String())
-	if r.cache != nil {
-		var entity T
-		if err := r.cache.GetJSON(ctx, cacheKey, &entity); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit(r.tableName)
-			}
-			r.logger.Debug("cache hit", "table", r.tableName, "id", id)
-			return &entity, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss(r.tableName)
-		}
-	}
-
-	// Cache miss - fetch from database
 	var entity T
-	if err := r.db.WithContext(ctx).First(&entity, "id = ?", id).Error; err != nil {
+	err := r.cache.Entity(ctx, id, &entity, func() error {
+		return r.db.WithContext(ctx).First(&entity, "id = ?", id).Error
+	})
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewRepositoryError("NOT_FOUND", "entity not found", errors.ErrNotFound)
 		}
@@ -290,18 +308,14 @@ func (r *baseRepository[T]) GetByID(ctx context.Context, id uuid.UUID) (*T, erro
 		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get entity", err)
 	}
 
-	// Store in cache
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, entity, r.cacheTTL); err != nil {
-			r.logger.Warn("failed to cache entity", "table", r.tableName, "id", id, "error", err)
-		}
-	}
-
 	return &entity, nil
 }
 
 // GetByIDWithTenant retrieves an entity by ID with tenant isolation
 func (r *baseRepository[T]) GetByIDWithTenant(ctx context.Context, id uuid.UUID, tenantID *uuid.UUID) (*T, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, "get_by_id_tenant")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -313,37 +327,23 @@ func (r *baseRepository[T]) GetByIDWithTenant(ctx context.Context, id uuid.UUID,
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	// Build cache key with tenant
-	var cacheKey string
-	if tenantID != nil && *tenantID != uuid.Nil {
-		cacheKey = r.getCacheKey("id:tenant", id.String(), tenantID.String())
-	} else {
-		cacheKey = r.getCacheKey("id", id.String())
-	}
+	var entity T
+	err := r.cache.Entity(ctx, id, &entity, func() error {
+		query := r.db.WithContext(ctx).Where("id = ?", id)
 
-	// Try to get from cache first
-	if r.cache != nil {
-		var entity T
-		if err := r.cache.GetJSON(ctx, cacheKey, &entity); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit(r.tableName)
-			}
-			return &entity, nil
+		// Apply tenant isolation if tenantID is provided
+		if tenantID != nil && *tenantID != uuid.Nil {
+			query = query.Where("tenant_id = ?", *tenantID)
 		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss(r.tableName)
+		return query.First(&entity).Error
+	})
+	if err == nil && tenantID != nil && *tenantID != uuid.Nil {
+		// The cached entity is shared by every tenant filter
+		if owner, ok := getEntityTenantID(&entity); ok && owner != *tenantID {
+			err = gorm.ErrRecordNotFound
 		}
 	}
-
-	var entity T
-	query := r.db.WithContext(ctx).Where("id = ?", id)
-
-	// Apply tenant isolation if tenantID is provided
-	if tenantID != nil && *tenantID != uuid.Nil {
-		query = query.Where("tenant_id = ?", *tenantID)
-	}
-
-	if err := query.First(&entity).Error; err != nil {
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewRepositoryError("NOT_FOUND", "entity not found", errors.ErrNotFound)
 		}
@@ -356,18 +356,14 @@ func (r *baseRepository[T]) GetByIDWithTenant(ctx context.Context, id uuid.UUID,
 		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get entity", err)
 	}
 
-	// Store in cache
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, entity, r.cacheTTL); err != nil {
-			r.logger.Warn("failed to cache entity", "table", r.tableName, "id", id, "error", err)
-		}
-	}
-
 	return &entity, nil
 }
 
 // Update updates an entity with optimistic locking support
 func (r *baseRepository[T]) Update(ctx context.Context, entity *T) error {
+	ctx, cancel := r.WithQueryTimeout(ctx, "update")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -432,11 +428,8 @@ func (r *baseRepository[T]) Update(ctx context.Context, entity *T) error {
 	}
 
 	// Invalidate cache for this entity
-	if r.cache != nil {
-		r.InvalidateCache(ctx, id)
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("list:*"))
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("count:*"))
-	}
+	tenantID, _ := getEntityTenantID(entity)
+	r.cache.Invalidate(ctx, tenantID, id)
 
 	// Log audit trail
 	if r.auditLogger != nil {
@@ -450,6 +443,9 @@ func (r *baseRepository[T]) Update(ctx context.Context, entity *T) error {
 
 // Delete permanently deletes an entity
 func (r *baseRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := r.WithQueryTimeout(ctx, "delete")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -461,8 +457,13 @@ func (r *baseRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
 		return errors.NewRepositoryError("INVALID_INPUT", "id cannot be nil", errors.ErrInvalidInput)
 	}
 
+	// Audited deletes return the deleted row so the audit trail has its last values
 	var entity T
-	result := r.db.WithContext(ctx).Unscoped().Delete(&entity, "id = ?", id)
+	query := r.db.WithContext(ctx).Unscoped()
+	if r.auditLogger != nil {
+		query = query.Clauses(clause.Returning{})
+	}
+	result := query.Delete(&entity, "id = ?", id)
 	if result.Error != nil {
 		r.logger.Error("failed to delete entity", "table", r.tableName, "id", id, "error", result.Error)
 
@@ -478,15 +479,11 @@ func (r *baseRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.InvalidateCache(ctx, id)
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("list:*"))
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("count:*"))
-	}
+	r.cache.Invalidate(ctx, uuid.Nil, id)
 
 	// Log audit trail
 	if r.auditLogger != nil {
-		r.auditLogger.LogDelete(ctx, r.tableName, id)
+		r.auditLogger.LogDelete(ctx, r.tableName, id, entityToMap(&entity))
 	}
 
 	r.logger.Debug("entity deleted", "table", r.tableName, "id", id)
@@ -495,6 +492,9 @@ func (r *baseRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
 
 // SoftDelete soft deletes an entity
 func (r *baseRepository[T]) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := r.WithQueryTimeout(ctx, "soft_delete")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -507,7 +507,11 @@ func (r *baseRepository[T]) SoftDelete(ctx context.Context, id uuid.UUID) error
 	}
 
 	var entity T
-	result := r.db.WithContext(ctx).Delete(&entity, "id = ?", id)
+	query := r.db.WithContext(ctx)
+	if r.auditLogger != nil {
+		query = query.Clauses(clause.Returning{})
+	}
+	result := query.Delete(&entity, "id = ?", id)
 	if result.Error != nil {
 		r.logger.Error("failed to soft delete entity", "table", r.tableName, "id", id, "error", result.Error)
 
@@ -523,15 +527,11 @@ func (r *baseRepository[T]) SoftDelete(ctx context.Context, id uuid.UUID) error
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.InvalidateCache(ctx, id)
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("list:*"))
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("count:*"))
-	}
+	r.cache.Invalidate(ctx, uuid.Nil, id)
 
 	// Log audit trail
 	if r.auditLogger != nil {
-		r.auditLogger.LogDelete(ctx, r.tableName, id)
+		r.auditLogger.LogDelete(ctx, r.tableName, id, entityToMap(&entity))
 	}
 
 	r.logger.Debug("entity soft deleted", "table", r.tableName, "id", id)
@@ -540,6 +540,9 @@ func (r *baseRepository[T]) SoftDelete(ctx context.Context, id uuid.UUID) error
 
 // Restore restores a soft-deleted entity
 func (r *baseRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := r.WithQueryTimeout(ctx, "restore")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -552,7 +555,7 @@ func (r *baseRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
 	}
 
 	var entity T
-	result := r.db.WithContext(ctx).Unscoped().Model(&entity).Where("id = ?", id).Update("deleted_at", nil)
+	result := r.db.WithContext(ctx).Unscoped().Model(&entity).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
 	if result.Error != nil {
 		r.logger.Error("failed to restore entity", "table", r.tableName, "id", id, "error", result.Error)
 
@@ -568,48 +571,105 @@ func (r *baseRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.InvalidateCache(ctx, id)
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("list:*"))
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("count:*"))
-	}
+	r.cache.Invalidate(ctx, uuid.Nil, id)
 
 	r.logger.Debug("entity restored", "table", r.tableName, "id", id)
 	return nil
 }
 
-// Find finds entities matching filters with caching
-func (r *baseRepository[T]) Find(ctx context.Context, filters map[string]any) ([]*T, error) {
+// FindDeleted finds soft-deleted entities matching filters, the most recently deleted
+// first. The trash changes with every delete, so it is not cached.
+func (r *baseRepository[T]) FindDeleted(ctx context.Context, filters map[string]any, pagination PaginationParams) ([]*T, PaginationResult, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, "find_deleted")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
-			r.metrics.RecordOperation("find", r.tableName, time.Since(start), nil)
+			r.metrics.RecordOperation("find_deleted", r.tableName, time.Since(start), nil)
 		}
 	}()
 
-	// Build cache key from filters
-	cacheKey := r.getCacheKeyFromFilters("list", filters)
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).Unscoped().Model(new(T)).Where("deleted_at IS NOT NULL")
+	query = applyFilters(query, filters)
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		r.logger.Error("failed to count deleted entities", "table", r.tableName, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count deleted entities", err)
+	}
+
+	var entities []*T
+	if err := query.Order("deleted_at DESC").
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Find(&entities).Error; err != nil {
+		r.logger.Error("failed to find deleted entities", "table", r.tableName, "error", err)
 
-	// Try cache first
-	if r.cache != nil {
-		var entities []*T
-		if err := r.cache.GetJSON(ctx, cacheKey, &entities); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit(r.tableName)
-				r.metrics.RecordQueryCount(r.tableName, int64(len(entities)))
-			}
-			return entities, nil
+		if r.metrics != nil {
+			r.metrics.RecordOperation("find_deleted", r.tableName, time.Since(start), err)
+		}
+
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find deleted entities", err)
+	}
+
+	return entities, CalculatePagination(pagination, totalItems), nil
+}
+
+// PurgeDeleted permanently deletes entities soft-deleted before the given time,
+// returning how many were deleted
+func (r *baseRepository[T]) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, "purge_deleted")
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		if r.metrics != nil {
+			r.metrics.RecordOperation("purge_deleted", r.tableName, time.Since(start), nil)
 		}
+	}()
+
+	var entity T
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&entity)
+	if result.Error != nil {
+		r.logger.Error("failed to purge deleted entities", "table", r.tableName, "error", result.Error)
+
 		if r.metrics != nil {
-			r.metrics.RecordCacheMiss(r.tableName)
+			r.metrics.RecordOperation("purge_deleted", r.tableName, time.Since(start), result.Error)
 		}
+
+		return 0, errors.NewRepositoryError("PURGE_FAILED", "failed to purge deleted entities", result.Error)
 	}
 
-	query := r.db.WithContext(ctx)
-	query = applyFilters(query, filters)
+	if result.RowsAffected > 0 {
+		r.logger.Info("purged deleted entities", "table", r.tableName, "count", result.RowsAffected)
+	}
+	return result.RowsAffected, nil
+}
+
+// Find finds entities matching filters with caching
+func (r *baseRepository[T]) Find(ctx context.Context, filters map[string]any) ([]*T, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, "find")
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		if r.metrics != nil {
+			r.metrics.RecordOperation("find", r.tableName, time.Since(start), nil)
+		}
+	}()
 
 	var entities []*T
-	if err := query.Find(&entities).Error; err != nil {
+	err := r.cache.Query(ctx, filterTenantID(filters), "find", &entities, func() error {
+		query := r.db.WithContext(ctx)
+		query = applyFilters(query, filters)
+		return query.Find(&entities).Error
+	}, filters)
+	if err != nil {
 		r.logger.Error("failed to find entities", "table", r.tableName, "error", err)
 
 		if r.metrics != nil {
@@ -619,13 +679,6 @@ func (r *baseRepository[T]) Find(ctx context.Context, filters map[string]any) ([
 		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find entities", err)
 	}
 
-	// Cache results
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, entities, r.cacheTTL); err != nil {
-			r.logger.Warn("failed to cache results", "table", r.tableName, "error", err)
-		}
-	}
-
 	if r.metrics != nil {
 		r.metrics.RecordQueryCount(r.tableName, int64(len(entities)))
 	}
@@ -635,6 +688,9 @@ func (r *baseRepository[T]) Find(ctx context.Context, filters map[string]any) ([
 
 // FindWithPagination finds entities with pagination and caching
 func (r *baseRepository[T]) FindWithPagination(ctx context.Context, filters map[string]any, pagination PaginationParams) ([]*T, PaginationResult, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, "find_paginated")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -644,81 +700,55 @@ func (r *baseRepository[T]) FindWithPagination(ctx context.Context, filters map[
 
 	pagination.Validate()
 
-	// Build cache key
-	cacheKey := r.getCacheKeyFromFiltersPagination("list:page", filters, pagination)
-
-	// Try cache first
 	type cachedResult struct {
 		Entities   []*T
 		Pagination PaginationResult
 	}
 
-	if r.cache != nil {
-		var cached cachedResult
-		if err := r.cache.GetJSON(ctx, cacheKey, &cached); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit(r.tableName)
-				r.metrics.RecordQueryCount(r.tableName, int64(len(cached.Entities)))
-			}
-			return cached.Entities, cached.Pagination, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss(r.tableName)
-		}
-	}
-
-	// Build query with filters
-	query := r.db.WithContext(ctx)
-	query = applyFilters(query, filters)
+	var result cachedResult
+	err := r.cache.Query(ctx, filterTenantID(filters), "find_paginated", &result, func() error {
+		// Build query with filters
+		query := r.db.WithContext(ctx)
+		query = applyFilters(query, filters)
 
-	// Count total items
-	var totalItems int64
-	if err := query.Model(new(T)).Count(&totalItems).Error; err != nil {
-		r.logger.Error("failed to count entities", "table", r.tableName, "error", err)
-
-		if r.metrics != nil {
-			r.metrics.RecordOperation("find_paginated", r.tableName, time.Since(start), err)
+		// Count total items
+		var totalItems int64
+		if err := query.Model(new(T)).Count(&totalItems).Error; err != nil {
+			return errors.NewRepositoryError("COUNT_FAILED", "failed to count entities", err)
 		}
 
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count entities", err)
-	}
+		// Apply pagination
+		query = query.Offset(pagination.Offset()).Limit(pagination.Limit())
 
-	// Apply pagination
-	query = query.Offset(pagination.Offset()).Limit(pagination.Limit())
+		if err := query.Find(&result.Entities).Error; err != nil {
+			return errors.NewRepositoryError("FIND_FAILED", "failed to find entities", err)
+		}
 
-	var entities []*T
-	if err := query.Find(&entities).Error; err != nil {
+		result.Pagination = CalculatePagination(pagination, totalItems)
+		return nil
+	}, filters, pagination.Page, pagination.PageSize)
+	if err != nil {
 		r.logger.Error("failed to find entities with pagination", "table", r.tableName, "error", err)
 
 		if r.metrics != nil {
 			r.metrics.RecordOperation("find_paginated", r.tableName, time.Since(start), err)
 		}
 
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find entities", err)
-	}
-
-	paginationResult := CalculatePagination(pagination, totalItems)
-
-	// Cache results
-	if r.cache != nil {
-		cached := cachedResult{
-			Entities:   entities,
-			Pagination: paginationResult,
-		}
-		if err := r.cache.SetJSON(ctx, cacheKey, cached, r.cacheTTL); err != nil {
-			r.logger.Warn("failed to cache paginated results", "table", r.tableName, "error", err)
-		}
+		return nil, PaginationResult{}, err
 	}
 
 	if r.metrics != nil {
-		r.metrics.RecordQueryCount(r.tableName, totalItems)
+		r.metrics.RecordQueryCount(r.tableName, result.Pagination.TotalItems)
 	}
 
-	return entities, paginationResult, nil
+	return result.Entities, result.Pagination, nil
 }
 
 // Count counts entities matching filters with caching
 func (r *baseRepository[T]) Count(ctx context.Context, filters map[string]any) (int64, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, "count")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -726,28 +756,13 @@ func (r *baseRepository[T]) Count(ctx context.Context, filters map[string]any) (
 		}
 	}()
 
-	// Build cache key
-	cacheKey := r.getCacheKeyFromFilters("count", filters)
-
-	// Try cache first
-	if r.cache != nil {
-		var count int64
-		if err := r.cache.GetJSON(ctx, cacheKey, &count); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit(r.tableName)
-			}
-			return count, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss(r.tableName)
-		}
-	}
-
-	query := r.db.WithContext(ctx).Model(new(T))
-	query = applyFilters(query, filters)
-
 	var count int64
-	if err := query.Count(&count).Error; err != nil {
+	err := r.cache.Query(ctx, filterTenantID(filters), "count", &count, func() error {
+		query := r.db.WithContext(ctx).Model(new(T))
+		query = applyFilters(query, filters)
+		return query.Count(&count).Error
+	}, filters)
+	if err != nil {
 		r.logger.Error("failed to count entities", "table", r.tableName, "error", err)
 
 		if r.metrics != nil {
@@ -757,18 +772,14 @@ func (r *baseRepository[T]) Count(ctx context.Context, filters map[string]any) (
 		return 0, errors.NewRepositoryError("COUNT_FAILED", "failed to count entities", err)
 	}
 
-	// Cache count
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, count, r.cacheTTL); err != nil {
-			r.logger.Warn("failed to cache count", "table", r.tableName, "error", err)
-		}
-	}
-
 	return count, nil
 }
 
 // Exists checks if an entity exists with caching
 func (r *baseRepository[T]) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, "exists")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -780,23 +791,16 @@ func (r *baseRepository[T]) Exists(ctx context.Context, id uuid.UUID) (bool, err
 		return false, nil
 	}
 
-	// Try to get from cache (which will tell us if it exists)
-	cacheKey := r.getCacheKey("exists", id.String())
-	if r.cache != nil {
-		var exists bool
-		if err := r.cache.GetJSON(ctx, cacheKey, &exists); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit(r.tableName)
-			}
-			return exists, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss(r.tableName)
+	var exists bool
+	err := r.cache.Query(ctx, uuid.Nil, "exists", &exists, func() error {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(new(T)).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
 		}
-	}
-
-	var count int64
-	if err := r.db.WithContext(ctx).Model(new(T)).Where("id = ?", id).Count(&count).Error; err != nil {
+		exists = count > 0
+		return nil
+	}, id)
+	if err != nil {
 		r.logger.Error("failed to check existence", "table", r.tableName, "id", id, "error", err)
 
 		if r.metrics != nil {
@@ -806,57 +810,39 @@ func (r *baseRepository[T]) Exists(ctx context.Context, id uuid.UUID) (bool, err
 		return false, errors.NewRepositoryError("EXISTS_CHECK_FAILED", "failed to check existence", err)
 	}
 
-	exists := count > 0
-
-	// Cache existence check
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, exists, r.cacheTTL); err != nil {
-			r.logger.Warn("failed to cache exists check", "table", r.tableName, "error", err)
-		}
-	}
-
 	return exists, nil
 }
 
-// InvalidateCache invalidates cache for a specific entity
+// InvalidateCache invalidates the cached entity and the queries of its table. The
+// queries of the tenant the context is scoped to are dropped, or of every tenant when
+// it is not scoped.
 func (r *baseRepository[T]) InvalidateCache(ctx context.Context, id uuid.UUID) error {
-	if r.cache == nil {
-		return nil
-	}
-
-	// Delete all cache keys related to this entity
-	patterns := []string{
-		r.getCacheKey("id", id.String()),
-		r.getCacheKey("id:tenant", id.String(), "*"),
-		r.getCacheKey("exists", id.String()),
-	}
-
-	for _, pattern := range patterns {
-		if err := r.cache.DeletePattern(ctx, pattern); err != nil {
-			r.logger.Warn("failed to invalidate cache", "pattern", pattern, "error", err)
-		}
-	}
-
+	r.cache.Invalidate(ctx, uuid.Nil, id)
 	return nil
 }
 
-// InvalidateCachePattern invalidates cache based on a pattern
-func (r *baseRepository[T]) InvalidateCachePattern(ctx context.Context, pattern string) error {
-	if r.cache == nil {
+// InvalidateCacheMany invalidates cache for a set of entities, e.g. after a bulk update.
+// The cost does not grow with the number of entities: exact keys are deleted in one call
+// and the queries of the table are dropped by replacing their generation.
+func (r *baseRepository[T]) InvalidateCacheMany(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
 		return nil
 	}
-
-	fullPattern := r.getCacheKeyPattern(pattern)
-	if err := r.cache.DeletePattern(ctx, fullPattern); err != nil {
-		r.logger.Warn("failed to invalidate cache pattern", "pattern", fullPattern, "error", err)
-		return err
-	}
-
+	r.cache.Invalidate(ctx, uuid.Nil, ids...)
 	return nil
 }
 
+// RepositoryCache returns the cache of the repository's table, for queries of the
+// concrete repository to share its key scheme and invalidation
+func (r *baseRepository[T]) RepositoryCache() *RepositoryCache {
+	return r.cache
+}
+
 // WithTransaction executes a function within a transaction
 func (r *baseRepository[T]) WithTransaction(ctx context.Context, fn func(*gorm.DB) error) error {
+	ctx, cancel := r.WithQueryTimeout(ctx, "transaction")
+	defer cancel()
+
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -867,43 +853,16 @@ func (r *baseRepository[T]) WithTransaction(ctx context.Context, fn func(*gorm.D
 	return r.db.WithContext(ctx).Transaction(fn)
 }
 
+// WithQueryTimeout bounds ctx by the configured budget of an operation
+func (r *baseRepository[T]) WithQueryTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	return r.timeouts.WithTimeout(ctx, operation)
+}
+
 // GetDB returns the underlying GORM database instance
 func (r *baseRepository[T]) GetDB() *gorm.DB {
 	return r.db
 }
 
-// Cache key helpers
-
-func (r *baseRepository[T]) getCacheKey(prefix string, parts ...string) string {
-	allParts := append([]string{"repo", r.tableName, prefix}, parts...)
-	return strings.Join(allParts, ":")
-}
-
-func (r *baseRepository[T]) getCacheKeyPattern(pattern string) string {
-	return fmt.Sprintf("repo:%s:%s", r.tableName, pattern)
-}
-
-func (r *baseRepository[T]) getCacheKeyFromFilters(prefix string, filters map[string]any) string {
-	if len(filters) == 0 {
-		return r.getCacheKey(prefix, "all")
-	}
-
-	// Create a deterministic key from filters
-	var parts []string
-	for k, v := range filters {
-		parts = append(parts, fmt.Sprintf("%s=%v", k, v))
-	}
-
-	// Join all filter parts into a single string
-	filterStr := strings.Join(parts, ":")
-	return r.getCacheKey(prefix, filterStr)
-}
-
-func (r *baseRepository[T]) getCacheKeyFromFiltersPagination(prefix string, filters map[string]any, pagination PaginationParams) string {
-	filterKey := r.getCacheKeyFromFilters(prefix, filters)
-	return fmt.Sprintf("%s:page=%d:size=%d", filterKey, pagination.Page, pagination.PageSize)
-}
-
 // Helper functions
 
 func getTableName(entity any) string {
@@ -948,6 +907,23 @@ func getEntityVersion(entity any) int {
 	return 0
 }
 
+// filterTenantID returns the tenant filters are limited to, if any
+func filterTenantID(filters map[string]any) uuid.UUID {
+	switch tenantID := filters["tenant_id"].(type) {
+	case uuid.UUID:
+		return tenantID
+	case *uuid.UUID:
+		if tenantID != nil {
+			return *tenantID
+		}
+	case string:
+		if parsed, err := uuid.Parse(tenantID); err == nil {
+			return parsed
+		}
+	}
+	return uuid.Nil
+}
+
 func applyFilters(query *gorm.DB, filters map[string]any) *gorm.DB {
 	for key, value := range filters {
 		if value != nil {
@@ -957,6 +933,8 @@ func applyFilters(query *gorm.DB, filters map[string]any) *gorm.DB {
 	return query
 }
 
+// entityToMap returns an entity's fields keyed by JSON name, leaving out related
+// entities, which may or may not be loaded
 func entityToMap(entity any) map[string]any {
 	result := make(map[string]any)
 	v := reflect.ValueOf(entity)
@@ -976,7 +954,7 @@ func entityToMap(entity any) map[string]any {
 
 		// Get JSON tag or use field name
 		jsonTag := field.Tag.Get("json")
-		if jsonTag == "" || jsonTag == "-" {
+		if jsonTag == "" || jsonTag == "-" || isRelation(field.Type) {
 			continue
 		}
 
@@ -993,6 +971,19 @@ func entityToMap(entity any) map[string]any {
 	return result
 }
 
+// isRelation reports whether a field holds related entities: models embedding
+// BaseModel, or pointers and slices of them
+func isRelation(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	field, ok := t.FieldByName("BaseModel")
+	return ok && field.Anonymous
+}
+
 func toSnakeCase(s string) string {
 	var result []rune
 	for i, r := range s {
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BookingConversationRepository defines the interface for booking chat thread operations
+type BookingConversationRepository interface {
+	BaseRepository[models.BookingConversation]
+
+	// Query Operations
+	GetByBookingID(ctx context.Context, bookingID uuid.UUID) (*models.BookingConversation, error)
+	FindByBookingIDs(ctx context.Context, bookingIDs []uuid.UUID) ([]*models.BookingConversation, error)
+
+	// GetOrCreate returns the booking's conversation, creating it on first use
+	GetOrCreate(ctx context.Context, booking *models.Booking) (*models.BookingConversation, error)
+
+	// Counters
+	RecordMessage(ctx context.Context, conversationID, recipientID uuid.UUID, preview string, at time.Time) error
+	ResetUnread(ctx context.Context, conversationID, userID uuid.UUID) error
+}
+
+// bookingConversationRepository implements BookingConversationRepository
+type bookingConversationRepository struct {
+	BaseRepository[models.BookingConversation]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewBookingConversationRepository creates a new BookingConversationRepository instance
+func NewBookingConversationRepository(db *gorm.DB, config ...RepositoryConfig) BookingConversationRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.BookingConversation](db, cfg)
+
+	return &bookingConversationRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// GetByBookingID retrieves the conversation of a booking
+func (r *bookingConversationRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) (*models.BookingConversation, error) {
+	if bookingID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "booking_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var conversation models.BookingConversation
+	if err := r.db.WithContext(ctx).Where("booking_id = ?", bookingID).First(&conversation).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "booking conversation not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to get booking conversation", "booking_id", bookingID, "error", err)
+		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get booking conversation", err)
+	}
+
+	return &conversation, nil
+}
+
+// FindByBookingIDs retrieves the conversations of several bookings in one query
+func (r *bookingConversationRepository) FindByBookingIDs(ctx context.Context, bookingIDs []uuid.UUID) ([]*models.BookingConversation, error) {
+	if len(bookingIDs) == 0 {
+		return []*models.BookingConversation{}, nil
+	}
+
+	var conversations []*models.BookingConversation
+	if err := r.db.WithContext(ctx).Where("booking_id IN ?", bookingIDs).Find(&conversations).Error; err != nil {
+		r.logger.Error("failed to find booking conversations", "count", len(bookingIDs), "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find booking conversations", err)
+	}
+
+	return conversations, nil
+}
+
+// GetOrCreate returns the booking's conversation, creating it if it does not exist yet.
+// Concurrent first messages are resolved by the unique booking_id index.
+func (r *bookingConversationRepository) GetOrCreate(ctx context.Context, booking *models.Booking) (*models.BookingConversation, error) {
+	conversation := models.NewBookingConversation(booking)
+	conversation.ID = uuid.New()
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "booking_id"}}, DoNothing: true}).
+		Create(conversation).Error; err != nil {
+		r.logger.Error("failed to create booking conversation", "booking_id", booking.ID, "error", err)
+		return nil, errors.NewRepositoryError("CREATE_FAILED", "failed to create booking conversation", err)
+	}
+
+	return r.GetByBookingID(ctx, booking.ID)
+}
+
+// RecordMessage bumps the activity fields and the recipient's unread counter atomically
+func (r *bookingConversationRepository) RecordMessage(ctx context.Context, conversationID, recipientID uuid.UUID, preview string, at time.Time) error {
+	updates := map[string]any{
+		"last_message_at":      at,
+		"last_message_preview": preview,
+		"message_count":        gorm.Expr("message_count + 1"),
+		"customer_unread_count": gorm.Expr(
+			"CASE WHEN customer_id = ? THEN customer_unread_count + 1 ELSE customer_unread_count END", recipientID),
+		"artisan_unread_count": gorm.Expr(
+			"CASE WHEN artisan_id = ? THEN artisan_unread_count + 1 ELSE artisan_unread_count END", recipientID),
+		"updated_at": time.Now(),
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.BookingConversation{}).
+		Where("id = ?", conversationID).
+		Updates(updates).Error; err != nil {
+		r.logger.Error("failed to record conversation message", "conversation_id", conversationID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update booking conversation", err)
+	}
+
+	r.InvalidateCache(ctx, conversationID)
+	return nil
+}
+
+// ResetUnread clears the participant's unread counter
+func (r *bookingConversationRepository) ResetUnread(ctx context.Context, conversationID, userID uuid.UUID) error {
+	updates := map[string]any{
+		"customer_unread_count": gorm.Expr("CASE WHEN customer_id = ? THEN 0 ELSE customer_unread_count END", userID),
+		"artisan_unread_count":  gorm.Expr("CASE WHEN artisan_id = ? THEN 0 ELSE artisan_unread_count END", userID),
+		"updated_at":            time.Now(),
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.BookingConversation{}).
+		Where("id = ?", conversationID).
+		Updates(updates).Error; err != nil {
+		r.logger.Error("failed to reset unread counter", "conversation_id", conversationID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update booking conversation", err)
+	}
+
+	r.InvalidateCache(ctx, conversationID)
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"context"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BookingEventRepository defines the interface for booking change log operations
+type BookingEventRepository interface {
+	BaseRepository[models.BookingEvent]
+
+	// Query Operations
+	FindByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*models.BookingEvent, error)
+}
+
+// bookingEventRepository implements BookingEventRepository
+type bookingEventRepository struct {
+	BaseRepository[models.BookingEvent]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewBookingEventRepository creates a new BookingEventRepository instance
+func NewBookingEventRepository(db *gorm.DB, config ...RepositoryConfig) BookingEventRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.BookingEvent](db, cfg)
+
+	return &bookingEventRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByBookingID retrieves a booking's change log in chronological order
+func (r *bookingEventRepository) FindByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*models.BookingEvent, error) {
+	if bookingID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "booking_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var events []*models.BookingEvent
+	if err := r.db.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("created_at ASC, id ASC").
+		Find(&events).Error; err != nil {
+		r.logger.Error("failed to find booking events", "booking_id", bookingID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find booking events", err)
+	}
+
+	return events, nil
+}
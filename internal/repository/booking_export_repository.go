@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+// BookingExportRepository defines the interface for booking export repository operations
+type BookingExportRepository interface {
+	BaseRepository[models.BookingExport]
+
+	// Maintenance Operations
+	FindExpired(ctx context.Context, before time.Time, limit int) ([]*models.BookingExport, error)
+	FailUnfinished(ctx context.Context, createdBefore time.Time, message string) (int64, error)
+}
+
+// bookingExportRepository implements BookingExportRepository
+type bookingExportRepository struct {
+	BaseRepository[models.BookingExport]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewBookingExportRepository creates a new BookingExportRepository instance
+func NewBookingExportRepository(db *gorm.DB, config ...RepositoryConfig) BookingExportRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.BookingExport](db, cfg)
+
+	return &bookingExportRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindExpired retrieves exports whose files expired before the given time and are still stored
+func (r *bookingExportRepository) FindExpired(ctx context.Context, before time.Time, limit int) ([]*models.BookingExport, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	var exports []*models.BookingExport
+	if err := r.db.WithContext(ctx).
+		Where("expires_at < ? AND file_key <> ''", before).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&exports).Error; err != nil {
+		r.logger.Error("failed to find expired booking exports", "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find expired booking exports", err)
+	}
+
+	return exports, nil
+}
+
+// FailUnfinished marks exports created before the given time that are still pending or
+// processing as failed, returning how many there were
+func (r *bookingExportRepository) FailUnfinished(ctx context.Context, createdBefore time.Time, message string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.BookingExport{}).
+		Where("status IN ? AND created_at < ?",
+			[]models.BookingExportStatus{models.BookingExportStatusPending, models.BookingExportStatusProcessing}, createdBefore).
+		Updates(map[string]any{
+			"status":        models.BookingExportStatusFailed,
+			"error_message": message,
+			"completed_at":  time.Now(),
+		})
+	if result.Error != nil {
+		r.logger.Error("failed to fail unfinished booking exports", "error", result.Error)
+		return 0, errors.NewRepositoryError("UPDATE_FAILED", "failed to fail unfinished booking exports", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
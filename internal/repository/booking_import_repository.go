@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BookingImportRepository defines the interface for booking import repository operations
+type BookingImportRepository interface {
+	BaseRepository[models.BookingImport]
+
+	// Query Operations
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.BookingImport, PaginationResult, error)
+
+	// Maintenance Operations
+	FailUnfinished(ctx context.Context, createdBefore time.Time, message string) (int64, error)
+}
+
+// bookingImportRepository implements BookingImportRepository
+type bookingImportRepository struct {
+	BaseRepository[models.BookingImport]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewBookingImportRepository creates a new BookingImportRepository instance
+func NewBookingImportRepository(db *gorm.DB, config ...RepositoryConfig) BookingImportRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.BookingImport](db, cfg)
+
+	return &bookingImportRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenantID retrieves a tenant's imports, newest first. Row errors are omitted from the list.
+func (r *bookingImportRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.BookingImport, PaginationResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	var totalItems int64
+	if err := r.db.WithContext(ctx).Model(&models.BookingImport{}).
+		Where("tenant_id = ?", tenantID).
+		Count(&totalItems).Error; err != nil {
+		r.logger.Error("failed to count booking imports", "tenant_id", tenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count booking imports", err)
+	}
+
+	var imports []*models.BookingImport
+	if err := r.db.WithContext(ctx).
+		Omit("row_errors").
+		Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").
+		Limit(pagination.Limit()).
+		Offset(pagination.Offset()).
+		Find(&imports).Error; err != nil {
+		r.logger.Error("failed to find booking imports", "tenant_id", tenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find booking imports", err)
+	}
+
+	return imports, CalculatePagination(pagination, totalItems), nil
+}
+
+// FailUnfinished marks imports created before the given time that are still pending or
+// processing as failed, returning how many there were
+func (r *bookingImportRepository) FailUnfinished(ctx context.Context, createdBefore time.Time, message string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.BookingImport{}).
+		Where("status IN ? AND created_at < ?",
+			[]models.BookingImportStatus{models.BookingImportStatusPending, models.BookingImportStatusProcessing}, createdBefore).
+		Updates(map[string]any{
+			"status":        models.BookingImportStatusFailed,
+			"error_message": message,
+			"completed_at":  time.Now(),
+		})
+	if result.Error != nil {
+		r.logger.Error("failed to fail unfinished booking imports", "error", result.Error)
+		return 0, errors.NewRepositoryError("UPDATE_FAILED", "failed to fail unfinished booking imports", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -5,6 +5,7 @@ import (
 	"Krafti_Vibe/internal/pkg/errors"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/google/uuid"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BookingRepository interface {
@@ -23,6 +25,10 @@ type BookingRepository interface {
 	GetByServiceID(ctx context.Context, serviceID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error)
 	GetByTenantID(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error)
 
+	// Relations
+	GetByIDWithRelations(ctx context.Context, id uuid.UUID, relations ...string) (*models.Booking, error)
+	LoadRelations(ctx context.Context, bookings []*models.Booking, relations ...string) error
+
 	// Status Operations
 	UpdateStatus(ctx context.Context, bookingID uuid.UUID, status models.BookingStatus) error
 	ConfirmBooking(ctx context.Context, bookingID uuid.UUID) error
@@ -62,6 +68,10 @@ type BookingRepository interface {
 	RecordDepositPayment(ctx context.Context, bookingID uuid.UUID, amount float64) error
 	UpdatePaymentIntent(ctx context.Context, bookingID uuid.UUID, paymentIntentID string) error
 	GetUnpaidBookings(ctx context.Context, tenantID uuid.UUID) ([]*models.Booking, error)
+	GetBookingsWithBalanceDue(ctx context.Context, before time.Time, limit int) ([]*models.Booking, error)
+	ClaimBalanceCollection(ctx context.Context, bookingID uuid.UUID) (bool, error)
+	RecordBalanceAttempt(ctx context.Context, bookingID uuid.UUID, status models.BalanceStatus, nextDueAt *time.Time, failureReason string) error
+	UpdateBalanceStatus(ctx context.Context, bookingID uuid.UUID, status models.BalanceStatus, nextDueAt *time.Time, failureReason string) error
 
 	// Recurrence Operations
 	CreateRecurringBookings(ctx context.Context, parentBooking *models.Booking, occurrences int) ([]*models.Booking, error)
@@ -76,6 +86,12 @@ type BookingRepository interface {
 	MarkReminderSent(ctx context.Context, bookingID uuid.UUID, reminderType string) error
 	GetBookingsNeedingReminders(ctx context.Context, hoursAhead int) ([]*models.Booking, error)
 
+	// Review Request Operations: completed bookings without a review whose customer has
+	// not been asked for one yet
+	FindTenantsAwaitingReviewRequests(ctx context.Context, completedSince time.Time) ([]uuid.UUID, error)
+	FindAwaitingReviewRequest(ctx context.Context, tenantID uuid.UUID, completedSince, completedBefore time.Time, limit int) ([]*models.Booking, error)
+	MarkReviewRequested(ctx context.Context, bookingID uuid.UUID, requestedAt time.Time) error
+
 	// Analytics & Reporting
 	GetBookingStats(ctx context.Context, tenantID uuid.UUID) (BookingStats, error)
 	GetBookingsByPeriod(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time, groupBy string) ([]BookingPeriodData, error)
@@ -86,14 +102,15 @@ type BookingRepository interface {
 	GetUtilizationRate(ctx context.Context, artisanID uuid.UUID, startDate, endDate time.Time) (float64, error)
 	GetCancellationRate(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (float64, error)
 	GetNoShowRate(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (float64, error)
+	CountCreatedSince(ctx context.Context, tenantID uuid.UUID, since time.Time) (int64, error)
 
 	// Search & Filter
 	Search(ctx context.Context, query string, tenantID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error)
 	FindByFilters(ctx context.Context, filters BookingFilters, pagination PaginationParams) ([]*models.Booking, PaginationResult, error)
 
 	// Bulk Operations
-	BulkConfirm(ctx context.Context, bookingIDs []uuid.UUID) error
-	BulkCancel(ctx context.Context, bookingIDs []uuid.UUID, reason string) error
+	FindByIDs(ctx context.Context, tenantID uuid.UUID, bookingIDs []uuid.UUID) ([]*models.Booking, error)
+	BulkUpdateStatus(ctx context.Context, expectedVersions map[uuid.UUID]int, status models.BookingStatus, reason string) ([]uuid.UUID, error)
 	BulkUpdatePaymentStatus(ctx context.Context, bookingIDs []uuid.UUID, status models.PaymentStatus) error
 }
 
@@ -149,14 +166,16 @@ type ServiceBookingCount struct {
 	Count        int64     `json:"count"`
 	Revenue      float64   `json:"revenue"`
 	AverageValue float64   `json:"average_value"`
+	Rating       float64   `json:"rating"` // The service's average published review rating
 }
 
 // BookingTrend represents booking trends over time
 type BookingTrend struct {
-	Date           time.Time `json:"date"`
-	BookingCount   int64     `json:"booking_count"`
-	CompletedCount int64     `json:"completed_count"`
-	Revenue        float64   `json:"revenue"`
+	Date           time.Time  `json:"date"`
+	BookingCount   int64      `json:"booking_count"`
+	CompletedCount int64      `json:"completed_count"`
+	Revenue        float64    `json:"revenue"`
+	AsOf           *time.Time `json:"as_of,omitempty"` // When the rollup it was read from was refreshed; nil when aggregated live
 }
 
 // BookingFilters for advanced filtering
@@ -165,6 +184,7 @@ type BookingFilters struct {
 	ArtisanIDs      []uuid.UUID            `json:"artisan_ids"`
 	CustomerIDs     []uuid.UUID            `json:"customer_ids"`
 	ServiceIDs      []uuid.UUID            `json:"service_ids"`
+	ProjectIDs      []uuid.UUID            `json:"project_ids"`
 	Statuses        []models.BookingStatus `json:"statuses"`
 	PaymentStatuses []models.PaymentStatus `json:"payment_statuses"`
 	StartDateFrom   *time.Time             `json:"start_date_from"`
@@ -172,6 +192,50 @@ type BookingFilters struct {
 	MinPrice        *float64               `json:"min_price"`
 	MaxPrice        *float64               `json:"max_price"`
 	IsRecurring     *bool                  `json:"is_recurring"`
+	MinDuration     *int                   `json:"min_duration"`
+	MaxDuration     *int                   `json:"max_duration"`
+	HasDeposit      *bool                  `json:"has_deposit"`
+	HasPhotos       *bool                  `json:"has_photos"`
+	HasLocation     *bool                  `json:"has_location"`
+	CreatedAfter    *time.Time             `json:"created_after"`
+	CreatedBefore   *time.Time             `json:"created_before"`
+	UpdatedAfter    *time.Time             `json:"updated_after"`
+	UpdatedBefore   *time.Time             `json:"updated_before"`
+	Tags            []string               `json:"tags"`     // Match bookings carrying all of these tags
+	AnyTags         []string               `json:"any_tags"` // Match bookings carrying any of these tags
+	SearchQuery     string                 `json:"search_query"`
+
+	// Customers whose bookings match, by segment and tag
+	CustomerAudience models.CustomerAudience `json:"customer_audience"`
+
+	// Relations loaded with the bookings besides the customer, artisan and service
+	IncludeRelations []string `json:"include_relations"`
+}
+
+// Relations of a booking that can be loaded with it, by the names clients request them
+const (
+	BookingRelationArtisan      = "artisan"
+	BookingRelationCustomer     = "customer"
+	BookingRelationService      = "service"
+	BookingRelationPayments     = "payments"
+	BookingRelationReview       = "review"
+	BookingRelationConversation = "conversation"
+	BookingRelationTenant       = "tenant"
+	BookingRelationParent       = "parent"
+	BookingRelationChildren     = "children"
+)
+
+// bookingPreloads maps the relation names to the associations preloaded for them
+var bookingPreloads = map[string]string{
+	BookingRelationArtisan:      "Artisan",
+	BookingRelationCustomer:     "Customer",
+	BookingRelationService:      "Service",
+	BookingRelationPayments:     "Payments",
+	BookingRelationReview:       "Review",
+	BookingRelationConversation: "Conversation",
+	BookingRelationTenant:       "Tenant",
+	BookingRelationParent:       "ParentBooking",
+	BookingRelationChildren:     "ChildBookings",
 }
 
 type bookingRepository struct {
@@ -203,113 +267,201 @@ func NewBookingRepository(db *gorm.DB, config ...RepositoryConfig) BookingReposi
 	}
 }
 
+// bookingCursorKey returns the keyset position of a booking for cursor pagination
+func bookingCursorKey(b *models.Booking) (time.Time, uuid.UUID) {
+	return b.StartTime, b.ID
+}
+
 //------------------------------------------------------------
 // Core Operations
 //------------------------------------------------------------
 
 func (r *bookingRepository) GetByArtisanID(ctx context.Context, artisanID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error) {
-	pagination.Validate()
-
-	var totalItems int64
 	query := r.db.WithContext(ctx).Model(&models.Booking{}).Where("artisan_id = ?", artisanID)
 
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count bookings", err)
-	}
-
-	var bookings []*models.Booking
-	if err := query.
-		Preload("Customer").
-		Preload("Service").
-		Preload("Payments").
-		Offset(pagination.Offset()).
-		Limit(pagination.Limit()).
-		Order("start_time DESC").
-		Find(&bookings).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find bookings", err)
-	}
-
-	paginationResult := CalculatePagination(pagination, totalItems)
-	return bookings, paginationResult, nil
+	return paginateByKey(query, pagination, "start_time", bookingCursorKey,
+		func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Customer").
+				Preload("Service").
+				Preload("Payments")
+		})
 }
 
 func (r *bookingRepository) GetByCustomerID(ctx context.Context, customerID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error) {
-	pagination.Validate()
-
-	var totalItems int64
 	query := r.db.WithContext(ctx).Model(&models.Booking{}).Where("customer_id = ?", customerID)
 
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count bookings", err)
-	}
+	return paginateByKey(query, pagination, "start_time", bookingCursorKey,
+		func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Artisan").
+				Preload("Service").
+				Preload("Payments")
+		})
+}
 
-	var bookings []*models.Booking
-	if err := query.
-		Preload("Artisan").
-		Preload("Service").
-		Preload("Payments").
-		Offset(pagination.Offset()).
-		Limit(pagination.Limit()).
-		Order("start_time DESC").
-		Find(&bookings).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find bookings", err)
-	}
+func (r *bookingRepository) GetByServiceID(ctx context.Context, serviceID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error) {
+	query := r.db.WithContext(ctx).Model(&models.Booking{}).Where("service_id = ?", serviceID)
 
-	paginationResult := CalculatePagination(pagination, totalItems)
-	return bookings, paginationResult, nil
+	return paginateByKey(query, pagination, "start_time", bookingCursorKey,
+		func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Customer").
+				Preload("Artisan").
+				Preload("Payments")
+		})
 }
 
-func (r *bookingRepository) GetByServiceID(ctx context.Context, serviceID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error) {
-	pagination.Validate()
+func (r *bookingRepository) GetByTenantID(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error) {
+	query := r.db.WithContext(ctx).Model(&models.Booking{}).Where("tenant_id = ?", tenantID)
 
-	var totalItems int64
-	query := r.db.WithContext(ctx).Model(&models.Booking{}).Where("service_id = ?", serviceID)
+	return paginateByKey(query, pagination, "start_time", bookingCursorKey,
+		func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Customer").
+				Preload("Artisan").
+				Preload("Service").
+				Preload("Payments")
+		})
+}
 
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count bookings", err)
+//------------------------------------------------------------
+// Relations
+//------------------------------------------------------------
+
+// GetByIDWithRelations retrieves a booking with the named relations, each preloaded in
+// one query
+func (r *bookingRepository) GetByIDWithRelations(ctx context.Context, id uuid.UUID, relations ...string) (*models.Booking, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	var bookings []*models.Booking
-	if err := query.
-		Preload("Customer").
-		Preload("Artisan").
-		Preload("Payments").
-		Offset(pagination.Offset()).
-		Limit(pagination.Limit()).
-		Order("start_time DESC").
-		Find(&bookings).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find bookings", err)
+	var booking models.Booking
+	if err := preloadBookingRelations(r.db.WithContext(ctx), relations).First(&booking, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "booking not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to get booking with relations", "booking_id", id, "error", err)
+		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get booking", err)
 	}
 
-	paginationResult := CalculatePagination(pagination, totalItems)
-	return bookings, paginationResult, nil
+	return &booking, nil
 }
 
-func (r *bookingRepository) GetByTenantID(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error) {
-	pagination.Validate()
+// LoadRelations fills in the named relations of bookings already loaded. Relations
+// every booking has loaded are skipped, and each of the others takes one query for all
+// the bookings, however many there are.
+func (r *bookingRepository) LoadRelations(ctx context.Context, bookings []*models.Booking, relations ...string) error {
+	var missing []string
+	for _, relation := range relations {
+		if _, ok := bookingPreloads[relation]; !ok || slices.Contains(missing, relation) {
+			continue
+		}
+		if slices.ContainsFunc(bookings, func(b *models.Booking) bool { return !bookingRelationLoaded(b, relation) }) {
+			missing = append(missing, relation)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
 
-	var totalItems int64
-	query := r.db.WithContext(ctx).Model(&models.Booking{}).Where("tenant_id = ?", tenantID)
+	ids := make([]uuid.UUID, len(bookings))
+	for i, booking := range bookings {
+		ids[i] = booking.ID
+	}
 
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count bookings", err)
+	var loaded []*models.Booking
+	if err := preloadBookingRelations(r.db.WithContext(ctx), missing).
+		Where("id IN ?", ids).
+		Find(&loaded).Error; err != nil {
+		r.logger.Error("failed to load booking relations", "count", len(ids), "relations", missing, "error", err)
+		return errors.NewRepositoryError("FIND_FAILED", "failed to load booking relations", err)
 	}
 
-	var bookings []*models.Booking
-	if err := query.
-		Preload("Customer").
-		Preload("Artisan").
-		Preload("Service").
-		Preload("Payments").
-		Offset(pagination.Offset()).
-		Limit(pagination.Limit()).
-		Order("start_time DESC").
-		Find(&bookings).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find bookings", err)
+	byID := make(map[uuid.UUID]*models.Booking, len(loaded))
+	for _, booking := range loaded {
+		byID[booking.ID] = booking
+	}
+	for _, booking := range bookings {
+		if source, ok := byID[booking.ID]; ok {
+			copyBookingRelations(booking, source, missing)
+		}
 	}
+	return nil
+}
 
-	paginationResult := CalculatePagination(pagination, totalItems)
-	return bookings, paginationResult, nil
+// preloadBookingRelations preloads the named relations, ignoring unknown names.
+// Payments come newest first and child bookings in the order they start.
+func preloadBookingRelations(db *gorm.DB, relations []string) *gorm.DB {
+	for _, relation := range relations {
+		switch relation {
+		case BookingRelationPayments:
+			db = db.Preload("Payments", func(db *gorm.DB) *gorm.DB { return db.Order("created_at DESC") })
+		case BookingRelationChildren:
+			db = db.Preload("ChildBookings", func(db *gorm.DB) *gorm.DB { return db.Order("start_time ASC") })
+		default:
+			if association, ok := bookingPreloads[relation]; ok {
+				db = db.Preload(association)
+			}
+		}
+	}
+	return db
+}
+
+// withoutBookingRelations returns relations without the given ones
+func withoutBookingRelations(relations []string, exclude ...string) []string {
+	return slices.DeleteFunc(slices.Clone(relations), func(relation string) bool {
+		return slices.Contains(exclude, relation)
+	})
+}
+
+// bookingRelationLoaded reports whether a booking has a relation loaded. A relation
+// that may be absent, such as a review not written yet, can't be told apart from one
+// not loaded and is reported as not loaded.
+func bookingRelationLoaded(b *models.Booking, relation string) bool {
+	switch relation {
+	case BookingRelationArtisan:
+		return b.Artisan != nil
+	case BookingRelationCustomer:
+		return b.Customer != nil
+	case BookingRelationService:
+		return b.Service != nil
+	case BookingRelationPayments:
+		return b.Payments != nil
+	case BookingRelationReview:
+		return b.Review != nil
+	case BookingRelationConversation:
+		return b.Conversation != nil
+	case BookingRelationTenant:
+		return b.Tenant != nil
+	case BookingRelationParent:
+		return b.ParentBookingID == nil || b.ParentBooking != nil
+	case BookingRelationChildren:
+		return b.ChildBookings != nil
+	}
+	return true
+}
+
+// copyBookingRelations sets the named relations of dst to those loaded on src
+func copyBookingRelations(dst, src *models.Booking, relations []string) {
+	for _, relation := range relations {
+		switch relation {
+		case BookingRelationArtisan:
+			dst.Artisan = src.Artisan
+		case BookingRelationCustomer:
+			dst.Customer = src.Customer
+		case BookingRelationService:
+			dst.Service = src.Service
+		case BookingRelationPayments:
+			dst.Payments = src.Payments
+		case BookingRelationReview:
+			dst.Review = src.Review
+		case BookingRelationConversation:
+			dst.Conversation = src.Conversation
+		case BookingRelationTenant:
+			dst.Tenant = src.Tenant
+		case BookingRelationParent:
+			dst.ParentBooking = src.ParentBooking
+		case BookingRelationChildren:
+			dst.ChildBookings = src.ChildBookings
+		}
+	}
 }
 
 //------------------------------------------------------------
@@ -320,7 +472,10 @@ func (r *bookingRepository) UpdateStatus(ctx context.Context, bookingID uuid.UUI
 	result := r.db.WithContext(ctx).
 		Model(&models.Booking{}).
 		Where("id = ?", bookingID).
-		Update("status", status)
+		Updates(map[string]any{
+			"status":  status,
+			"version": gorm.Expr("version + 1"),
+		})
 
 	if result.Error != nil {
 		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update status", result.Error)
@@ -350,6 +505,7 @@ func (r *bookingRepository) CompleteBooking(ctx context.Context, bookingID uuid.
 		Updates(map[string]any{
 			"status":       models.BookingStatusCompleted,
 			"completed_at": &now,
+			"version":      gorm.Expr("version + 1"),
 		})
 
 	if result.Error != nil {
@@ -374,6 +530,7 @@ func (r *bookingRepository) CancelBooking(ctx context.Context, bookingID uuid.UU
 			"cancelled_at":        &now,
 			"cancelled_by":        &cancelledBy,
 			"cancellation_reason": reason,
+			"version":             gorm.Expr("version + 1"),
 		})
 
 	if result.Error != nil {
@@ -739,7 +896,10 @@ func (r *bookingRepository) UpdatePaymentStatus(ctx context.Context, bookingID u
 	result := r.db.WithContext(ctx).
 		Model(&models.Booking{}).
 		Where("id = ?", bookingID).
-		Update("payment_status", status)
+		Updates(map[string]any{
+			"payment_status": status,
+			"version":        gorm.Expr("version + 1"),
+		})
 
 	if result.Error != nil {
 		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update payment status", result.Error)
@@ -771,7 +931,10 @@ func (r *bookingRepository) RecordDepositPayment(ctx context.Context, bookingID
 	result := r.db.WithContext(ctx).
 		Model(&models.Booking{}).
 		Where("id = ?", bookingID).
-		Update("deposit_paid", newDeposit)
+		Updates(map[string]any{
+			"deposit_paid": newDeposit,
+			"version":      gorm.Expr("version + 1"),
+		})
 
 	if result.Error != nil {
 		return errors.NewRepositoryError("UPDATE_FAILED", "failed to record deposit", result.Error)
@@ -785,7 +948,10 @@ func (r *bookingRepository) UpdatePaymentIntent(ctx context.Context, bookingID u
 	result := r.db.WithContext(ctx).
 		Model(&models.Booking{}).
 		Where("id = ?", bookingID).
-		Update("payment_intent_id", paymentIntentID)
+		Updates(map[string]any{
+			"payment_intent_id": paymentIntentID,
+			"version":           gorm.Expr("version + 1"),
+		})
 
 	if result.Error != nil {
 		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update payment intent", result.Error)
@@ -814,6 +980,90 @@ func (r *bookingRepository) GetUnpaidBookings(ctx context.Context, tenantID uuid
 	return bookings, nil
 }
 
+// GetBookingsWithBalanceDue returns bookings, across tenants, whose scheduled balance charge
+// is due or whose submitted charge the provider declined. Retries may run after the
+// appointment, so bookings in progress or completed are included.
+func (r *bookingRepository) GetBookingsWithBalanceDue(ctx context.Context, before time.Time, limit int) ([]*models.Booking, error) {
+	var bookings []*models.Booking
+	if err := r.db.WithContext(ctx).
+		Where("((balance_status = ? AND balance_due_at <= ?) OR balance_status = ?) AND status IN ?",
+			models.BalanceStatusScheduled, before, models.BalanceStatusDeclined,
+			[]models.BookingStatus{
+				models.BookingStatusPending,
+				models.BookingStatusConfirmed,
+				models.BookingStatusInProgress,
+				models.BookingStatusCompleted,
+			}).
+		Order("balance_due_at ASC").
+		Limit(limit).
+		Find(&bookings).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find bookings with balance due", err)
+	}
+
+	return bookings, nil
+}
+
+// ClaimBalanceCollection moves a scheduled or declined balance to processing. It returns
+// false when the balance is in neither state, e.g. because another worker claimed it first.
+func (r *bookingRepository) ClaimBalanceCollection(ctx context.Context, bookingID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("id = ? AND balance_status IN ?", bookingID,
+			[]models.BalanceStatus{models.BalanceStatusScheduled, models.BalanceStatusDeclined}).
+		Updates(map[string]any{
+			"balance_status": models.BalanceStatusProcessing,
+			"version":        gorm.Expr("version + 1"),
+		})
+
+	if result.Error != nil {
+		return false, errors.NewRepositoryError("UPDATE_FAILED", "failed to claim balance collection", result.Error)
+	}
+
+	r.InvalidateCache(ctx, bookingID)
+	return result.RowsAffected > 0, nil
+}
+
+// RecordBalanceAttempt stores the outcome of a balance charge and counts the attempt
+func (r *bookingRepository) RecordBalanceAttempt(ctx context.Context, bookingID uuid.UUID, status models.BalanceStatus, nextDueAt *time.Time, failureReason string) error {
+	return r.updateBalance(ctx, bookingID, status, nextDueAt, failureReason, true)
+}
+
+// UpdateBalanceStatus moves the balance to a new status without counting an attempt, for
+// outcomes of a charge that was already counted when it was submitted
+func (r *bookingRepository) UpdateBalanceStatus(ctx context.Context, bookingID uuid.UUID, status models.BalanceStatus, nextDueAt *time.Time, failureReason string) error {
+	return r.updateBalance(ctx, bookingID, status, nextDueAt, failureReason, false)
+}
+
+func (r *bookingRepository) updateBalance(ctx context.Context, bookingID uuid.UUID, status models.BalanceStatus, nextDueAt *time.Time, failureReason string, countAttempt bool) error {
+	updates := map[string]any{
+		"balance_status":         status,
+		"balance_failure_reason": failureReason,
+		"version":                gorm.Expr("version + 1"),
+	}
+	if countAttempt {
+		updates["balance_attempts"] = gorm.Expr("balance_attempts + 1")
+	}
+	if nextDueAt != nil {
+		updates["balance_due_at"] = *nextDueAt
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("id = ?", bookingID).
+		Updates(updates)
+
+	if result.Error != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to record balance attempt", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewRepositoryError("NOT_FOUND", "booking not found", errors.ErrNotFound)
+	}
+
+	r.InvalidateCache(ctx, bookingID)
+	return nil
+}
+
 //------------------------------------------------------------
 // Recurrence Operations
 //------------------------------------------------------------
@@ -858,6 +1108,7 @@ func (r *bookingRepository) CreateRecurringBookings(ctx context.Context, parentB
 			IsRecurring:       true,
 			RecurrencePattern: parentBooking.RecurrencePattern,
 			ParentBookingID:   &parentBooking.ID,
+			ProjectID:         parentBooking.ProjectID,
 		}
 
 		if err := r.Create(ctx, booking); err != nil {
@@ -899,6 +1150,7 @@ func (r *bookingRepository) CancelRecurringSeries(ctx context.Context, parentBoo
 			"status":              models.BookingStatusCancelled,
 			"cancelled_at":        &now,
 			"cancellation_reason": reason,
+			"version":             gorm.Expr("version + 1"),
 		})
 
 	if result.Error != nil {
@@ -1001,93 +1253,147 @@ func (r *bookingRepository) GetBookingsNeedingReminders(ctx context.Context, hou
 	return bookings, nil
 }
 
+// awaitingReviewRequest scopes a query to completed bookings since completedSince that
+// have no review and whose customer has not been asked for one
+func (r *bookingRepository) awaitingReviewRequest(ctx context.Context, completedSince time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("status = ? AND completed_at >= ? AND review_requested_at IS NULL", models.BookingStatusCompleted, completedSince).
+		Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.booking_id = bookings.id AND reviews.deleted_at IS NULL)")
+}
+
+func (r *bookingRepository) FindTenantsAwaitingReviewRequests(ctx context.Context, completedSince time.Time) ([]uuid.UUID, error) {
+	var tenantIDs []uuid.UUID
+	if err := r.awaitingReviewRequest(ctx, completedSince).
+		Distinct("tenant_id").
+		Pluck("tenant_id", &tenantIDs).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find tenants awaiting review requests", err)
+	}
+
+	return tenantIDs, nil
+}
+
+func (r *bookingRepository) FindAwaitingReviewRequest(ctx context.Context, tenantID uuid.UUID, completedSince, completedBefore time.Time, limit int) ([]*models.Booking, error) {
+	var bookings []*models.Booking
+	if err := r.awaitingReviewRequest(ctx, completedSince).
+		Preload("Artisan").
+		Preload("Service").
+		Where("tenant_id = ? AND completed_at <= ?", tenantID, completedBefore).
+		Order("completed_at ASC").
+		Limit(limit).
+		Find(&bookings).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find bookings awaiting review requests", err)
+	}
+
+	return bookings, nil
+}
+
+func (r *bookingRepository) MarkReviewRequested(ctx context.Context, bookingID uuid.UUID, requestedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Booking{}).
+		Where("id = ?", bookingID).
+		Update("review_requested_at", requestedAt)
+
+	if result.Error != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to mark review requested", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewRepositoryError("NOT_FOUND", "booking not found", errors.ErrNotFound)
+	}
+
+	return nil
+}
+
 //------------------------------------------------------------
 // Analytics & Reporting
 //------------------------------------------------------------
 
 func (r *bookingRepository) GetBookingStats(ctx context.Context, tenantID uuid.UUID) (BookingStats, error) {
-	stats := BookingStats{
-		ByStatus: make(map[models.BookingStatus]int64),
-	}
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
 
 	now := time.Now()
 	thisMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 	lastMonthStart := thisMonthStart.AddDate(0, -1, 0)
-	lastMonthEnd := thisMonthStart
 
-	if err := r.db.WithContext(ctx).Model(&models.Booking{}).
-		Where("tenant_id = ?", tenantID).
-		Count(&stats.TotalBookings).Error; err != nil {
-		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to get total bookings", err)
+	var stats BookingStats
+	err := r.RepositoryCache().WithTTL(statsCacheTTL).Query(ctx, tenantID, "stats", &stats, func() error {
+		var err error
+		stats, err = r.bookingStats(ctx, tenantID, thisMonthStart, lastMonthStart)
+		return err
+	}, thisMonthStart)
+	if err != nil {
+		return BookingStats{}, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to get booking stats", err)
 	}
 
-	statuses := []models.BookingStatus{
-		models.BookingStatusPending,
-		models.BookingStatusConfirmed,
-		models.BookingStatusCompleted,
-		models.BookingStatusCancelled,
-		models.BookingStatusNoShow,
-	}
+	return stats, nil
+}
 
-	for _, status := range statuses {
-		var count int64
-		if err := r.db.WithContext(ctx).Model(&models.Booking{}).
-			Where("tenant_id = ? AND status = ?", tenantID, status).
-			Count(&count).Error; err == nil {
-			stats.ByStatus[status] = count
-		}
+// bookingStats aggregates a tenant's bookings per status in a single pass
+func (r *bookingRepository) bookingStats(ctx context.Context, tenantID uuid.UUID, thisMonthStart, lastMonthStart time.Time) (BookingStats, error) {
+	var rows []struct {
+		Status           models.BookingStatus
+		Count            int64
+		Revenue          float64
+		ThisMonth        int64
+		LastMonth        int64
+		ThisMonthRevenue float64
+		LastMonthRevenue float64
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Booking{}).
+		Select(`status,
+			COUNT(*) AS count,
+			COALESCE(SUM(total_price), 0) AS revenue,
+			COUNT(*) FILTER (WHERE start_time >= ?) AS this_month,
+			COUNT(*) FILTER (WHERE start_time >= ? AND start_time < ?) AS last_month,
+			COALESCE(SUM(total_price) FILTER (WHERE start_time >= ?), 0) AS this_month_revenue,
+			COALESCE(SUM(total_price) FILTER (WHERE start_time >= ? AND start_time < ?), 0) AS last_month_revenue`,
+			thisMonthStart,
+			lastMonthStart, thisMonthStart,
+			thisMonthStart,
+			lastMonthStart, thisMonthStart).
+		Where("tenant_id = ?", tenantID).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return BookingStats{}, err
+	}
 
-		switch status {
+	stats := BookingStats{
+		ByStatus: map[models.BookingStatus]int64{
+			models.BookingStatusPending:   0,
+			models.BookingStatusConfirmed: 0,
+			models.BookingStatusCompleted: 0,
+			models.BookingStatusCancelled: 0,
+			models.BookingStatusNoShow:    0,
+		},
+	}
+	for _, row := range rows {
+		stats.ByStatus[row.Status] = row.Count
+		stats.TotalBookings += row.Count
+		stats.ThisMonthBookings += row.ThisMonth
+		stats.LastMonthBookings += row.LastMonth
+
+		switch row.Status {
 		case models.BookingStatusPending:
-			stats.PendingBookings = count
+			stats.PendingBookings = row.Count
 		case models.BookingStatusConfirmed:
-			stats.ConfirmedBookings = count
+			stats.ConfirmedBookings = row.Count
 		case models.BookingStatusCompleted:
-			stats.CompletedBookings = count
+			// Only completed bookings count as revenue
+			stats.CompletedBookings = row.Count
+			stats.TotalRevenue = row.Revenue
+			stats.ThisMonthRevenue = row.ThisMonthRevenue
+			stats.LastMonthRevenue = row.LastMonthRevenue
 		case models.BookingStatusCancelled:
-			stats.CancelledBookings = count
+			stats.CancelledBookings = row.Count
 		case models.BookingStatusNoShow:
-			stats.NoShowBookings = count
+			stats.NoShowBookings = row.Count
 		}
 	}
 
-	var totalRevenue float64
-	if err := r.db.WithContext(ctx).Model(&models.Booking{}).
-		Select("COALESCE(SUM(total_price), 0)").
-		Where("tenant_id = ? AND status = ?", tenantID, models.BookingStatusCompleted).
-		Scan(&totalRevenue).Error; err == nil {
-		stats.TotalRevenue = totalRevenue
-	}
-
 	if stats.TotalBookings > 0 {
-		stats.AverageBookingValue = totalRevenue / float64(stats.TotalBookings)
-	}
-
-	if err := r.db.WithContext(ctx).Model(&models.Booking{}).
-		Where("tenant_id = ? AND start_time >= ?", tenantID, thisMonthStart).
-		Count(&stats.ThisMonthBookings).Error; err == nil {
-	}
-
-	if err := r.db.WithContext(ctx).Model(&models.Booking{}).
-		Where("tenant_id = ? AND start_time >= ? AND start_time < ?", tenantID, lastMonthStart, lastMonthEnd).
-		Count(&stats.LastMonthBookings).Error; err == nil {
-	}
-
-	var thisMonthRevenue float64
-	if err := r.db.WithContext(ctx).Model(&models.Booking{}).
-		Select("COALESCE(SUM(total_price), 0)").
-		Where("tenant_id = ? AND status = ? AND start_time >= ?", tenantID, models.BookingStatusCompleted, thisMonthStart).
-		Scan(&thisMonthRevenue).Error; err == nil {
-		stats.ThisMonthRevenue = thisMonthRevenue
-	}
-
-	var lastMonthRevenue float64
-	if err := r.db.WithContext(ctx).Model(&models.Booking{}).
-		Select("COALESCE(SUM(total_price), 0)").
-		Where("tenant_id = ? AND status = ? AND start_time >= ? AND start_time < ?",
-			tenantID, models.BookingStatusCompleted, lastMonthStart, lastMonthEnd).
-		Scan(&lastMonthRevenue).Error; err == nil {
-		stats.LastMonthRevenue = lastMonthRevenue
+		stats.AverageBookingValue = stats.TotalRevenue / float64(stats.TotalBookings)
 	}
 
 	return stats, nil
@@ -1139,6 +1445,9 @@ func (r *bookingRepository) GetBookingsByPeriod(ctx context.Context, tenantID uu
 }
 
 func (r *bookingRepository) GetArtisanBookingStats(ctx context.Context, artisanID uuid.UUID, startDate, endDate time.Time) (ArtisanBookingStats, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	stats := ArtisanBookingStats{
 		ArtisanID: artisanID,
 		StartDate: startDate,
@@ -1146,28 +1455,6 @@ func (r *bookingRepository) GetArtisanBookingStats(ctx context.Context, artisanI
 		ByStatus:  make(map[models.BookingStatus]int64),
 	}
 
-	query := r.db.WithContext(ctx).Model(&models.Booking{}).
-		Where("artisan_id = ? AND start_time >= ? AND start_time <= ?", artisanID, startDate, endDate)
-
-	if err := query.Count(&stats.TotalBookings).Error; err != nil {
-		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to get total bookings", err)
-	}
-
-	if err := query.Where("status = ?", models.BookingStatusCompleted).Count(&stats.CompletedBookings).Error; err != nil {
-		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to get completed bookings", err)
-	}
-
-	var revenue float64
-	if err := query.Select("COALESCE(SUM(total_price), 0)").
-		Where("status = ?", models.BookingStatusCompleted).
-		Scan(&revenue).Error; err == nil {
-		stats.TotalRevenue = revenue
-	}
-
-	if stats.CompletedBookings > 0 {
-		stats.AverageBookingValue = revenue / float64(stats.CompletedBookings)
-	}
-
 	statuses := []models.BookingStatus{
 		models.BookingStatusPending,
 		models.BookingStatusConfirmed,
@@ -1175,14 +1462,37 @@ func (r *bookingRepository) GetArtisanBookingStats(ctx context.Context, artisanI
 		models.BookingStatusCancelled,
 		models.BookingStatusNoShow,
 	}
-
 	for _, status := range statuses {
-		var count int64
-		if err := query.Where("status = ?", status).Count(&count).Error; err == nil {
-			stats.ByStatus[status] = count
+		stats.ByStatus[status] = 0
+	}
+
+	// One pass over the artisan's bookings, grouped by status
+	var rows []struct {
+		Status  models.BookingStatus
+		Count   int64
+		Revenue float64
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Booking{}).
+		Select("status, COUNT(*) AS count, COALESCE(SUM(total_price), 0) AS revenue").
+		Where("artisan_id = ? AND start_time >= ? AND start_time <= ?", artisanID, startDate, endDate).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to get artisan booking stats", err)
+	}
+
+	for _, row := range rows {
+		stats.ByStatus[row.Status] = row.Count
+		stats.TotalBookings += row.Count
+		if row.Status == models.BookingStatusCompleted {
+			stats.CompletedBookings = row.Count
+			stats.TotalRevenue = row.Revenue
 		}
 	}
 
+	if stats.CompletedBookings > 0 {
+		stats.AverageBookingValue = stats.TotalRevenue / float64(stats.CompletedBookings)
+	}
+
 	if stats.TotalBookings > 0 {
 		stats.UtilizationRate = float64(stats.CompletedBookings) / float64(stats.TotalBookings) * 100
 	}
@@ -1199,11 +1509,12 @@ func (r *bookingRepository) GetPopularServices(ctx context.Context, tenantID uui
 		s.name AS service_name,
 		COUNT(b.id) AS count,
 		COALESCE(SUM(CASE WHEN b.status = 'completed' THEN b.total_price ELSE 0 END), 0) AS revenue,
-		COALESCE(AVG(CASE WHEN b.status = 'completed' THEN b.total_price END), 0) AS average_value
+		COALESCE(AVG(CASE WHEN b.status = 'completed' THEN b.total_price END), 0) AS average_value,
+		s.rating
 	FROM bookings b
 	INNER JOIN services s ON b.service_id = s.id
 	WHERE b.tenant_id = ? AND b.start_time >= ? AND b.start_time <= ?
-	GROUP BY s.id, s.name
+	GROUP BY s.id, s.name, s.rating
 	ORDER BY count DESC
 	LIMIT ?
 `
@@ -1216,7 +1527,7 @@ func (r *bookingRepository) GetPopularServices(ctx context.Context, tenantID uui
 
 	for rows.Next() {
 		var data ServiceBookingCount
-		if err := rows.Scan(&data.ServiceID, &data.ServiceName, &data.Count, &data.Revenue, &data.AverageValue); err != nil {
+		if err := rows.Scan(&data.ServiceID, &data.ServiceName, &data.Count, &data.Revenue, &data.AverageValue, &data.Rating); err != nil {
 			continue
 		}
 		results = append(results, data)
@@ -1225,11 +1536,41 @@ func (r *bookingRepository) GetPopularServices(ctx context.Context, tenantID uui
 	return results, nil
 }
 
+// GetBookingTrends returns a tenant's bookings per day. Once the tenant's analytics
+// rollups are built they are read from there, each day stamped with when the rollups
+// were last refreshed; until then the days are aggregated from the bookings.
 func (r *bookingRepository) GetBookingTrends(ctx context.Context, tenantID uuid.UUID, days int) ([]BookingTrend, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	var results []BookingTrend
 
 	startDate := time.Now().AddDate(0, 0, -days)
 
+	state, err := findRollupState(ctx, r.db, tenantID)
+	if err != nil {
+		return nil, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to get booking trends", err)
+	}
+	if state != nil {
+		if err := r.db.WithContext(ctx).
+			Model(&models.AnalyticsDailyRollup{}).
+			Select(`day AS date,
+				SUM(booking_count) AS booking_count,
+				SUM(completed_count) AS completed_count,
+				SUM(booking_revenue) AS revenue`).
+			Where("tenant_id = ? AND day >= ?", tenantID, startDate.UTC().Format(time.DateOnly)).
+			Group("day").
+			Having("SUM(booking_count) > 0").
+			Order("day ASC").
+			Scan(&results).Error; err != nil {
+			return nil, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to get booking trends", err)
+		}
+		for i := range results {
+			results[i].AsOf = &state.RefreshedAt
+		}
+		return results, nil
+	}
+
 	query := `
 	SELECT
 		DATE(start_time) AS date,
@@ -1346,6 +1687,18 @@ func (r *bookingRepository) GetNoShowRate(ctx context.Context, tenantID uuid.UUI
 	return float64(noShow) / float64(total) * 100, nil
 }
 
+// CountCreatedSince counts the tenant's bookings created at or after since, whatever
+// their status; plan limits meter bookings as they are made
+func (r *bookingRepository) CountCreatedSince(ctx context.Context, tenantID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Booking{}).
+		Where("tenant_id = ? AND created_at >= ?", tenantID, since).
+		Count(&count).Error; err != nil {
+		return 0, errors.NewRepositoryError("COUNT_FAILED", "failed to count bookings", err)
+	}
+	return count, nil
+}
+
 //------------------------------------------------------------
 // Search & Filter
 //------------------------------------------------------------
@@ -1353,11 +1706,9 @@ func (r *bookingRepository) GetNoShowRate(ctx context.Context, tenantID uuid.UUI
 func (r *bookingRepository) Search(ctx context.Context, query string, tenantID uuid.UUID, pagination PaginationParams) ([]*models.Booking, PaginationResult, error) {
 	pagination.Validate()
 
-	like := fmt.Sprintf("%%%s%%", strings.TrimSpace(query))
-
 	countQuery := r.db.WithContext(ctx).Model(&models.Booking{}).Where("tenant_id = ?", tenantID)
-	if query != "" {
-		countQuery = countQuery.Where("notes ILIKE ? OR customer_notes ILIKE ? OR internal_notes ILIKE ?", like, like, like)
+	if strings.TrimSpace(query) != "" {
+		countQuery = matchBookingSearch(countQuery, query)
 	}
 
 	var totalItems int64
@@ -1366,8 +1717,8 @@ func (r *bookingRepository) Search(ctx context.Context, query string, tenantID u
 	}
 
 	dataQuery := r.db.WithContext(ctx).Model(&models.Booking{}).Where("tenant_id = ?", tenantID)
-	if query != "" {
-		dataQuery = dataQuery.Where("notes ILIKE ? OR customer_notes ILIKE ? OR internal_notes ILIKE ?", like, like, like)
+	if strings.TrimSpace(query) != "" {
+		dataQuery = matchBookingSearch(dataQuery, query)
 	}
 
 	var bookings []*models.Booking
@@ -1388,81 +1739,89 @@ func (r *bookingRepository) Search(ctx context.Context, query string, tenantID u
 
 func (r *bookingRepository) FindByFilters(ctx context.Context, filters BookingFilters, pagination PaginationParams) ([]*models.Booking, PaginationResult, error) {
 	// Allow platform admins to query bookings across all tenants (tenant_id can be nil)
-	pagination.Validate()
-
-	query := r.db.WithContext(ctx).Model(&models.Booking{})
-	query = r.applyBookingFilters(query, filters)
-
-	var totalItems int64
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count bookings", err)
-	}
-
-	var bookings []*models.Booking
-	if err := r.applyBookingFilters(r.db.WithContext(ctx).Model(&models.Booking{}), filters).
-		Preload("Customer").
-		Preload("Artisan").
-		Preload("Service").
-		Offset(pagination.Offset()).
-		Limit(pagination.Limit()).
-		Order("start_time DESC").
-		Find(&bookings).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to apply filters", err)
-	}
-
-	paginationResult := CalculatePagination(pagination, totalItems)
-	return bookings, paginationResult, nil
+	query := r.applyBookingFilters(r.db.WithContext(ctx).Model(&models.Booking{}), filters)
+
+	return paginateByKey(query, pagination, "start_time", bookingCursorKey,
+		func(db *gorm.DB) *gorm.DB {
+			db = db.Preload("Customer").
+				Preload("Artisan").
+				Preload("Service")
+			return preloadBookingRelations(db, withoutBookingRelations(filters.IncludeRelations,
+				BookingRelationCustomer, BookingRelationArtisan, BookingRelationService))
+		})
 }
 
 //------------------------------------------------------------
 // Bulk Operations
 //------------------------------------------------------------
 
-func (r *bookingRepository) BulkConfirm(ctx context.Context, bookingIDs []uuid.UUID) error {
+// FindByIDs loads the tenant's bookings with the given IDs in a single query.
+// IDs that do not exist or belong to another tenant are left out.
+func (r *bookingRepository) FindByIDs(ctx context.Context, tenantID uuid.UUID, bookingIDs []uuid.UUID) ([]*models.Booking, error) {
 	if len(bookingIDs) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	result := r.db.WithContext(ctx).
-		Model(&models.Booking{}).
-		Where("id IN ?", bookingIDs).
-		Update("status", models.BookingStatusConfirmed)
-
-	if result.Error != nil {
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to bulk confirm bookings", result.Error)
-	}
-
-	for _, id := range bookingIDs {
-		r.InvalidateCache(ctx, id)
+	var bookings []*models.Booking
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id IN ?", tenantID, bookingIDs).
+		Find(&bookings).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find bookings", err)
 	}
 
-	return nil
+	return bookings, nil
 }
 
-func (r *bookingRepository) BulkCancel(ctx context.Context, bookingIDs []uuid.UUID, reason string) error {
-	if len(bookingIDs) == 0 {
-		return nil
+// BulkUpdateStatus moves bookings to status in a single UPDATE. Each booking is only
+// updated while it is still at the version the caller validated, so rows changed
+// concurrently are skipped; the IDs that were actually updated are returned.
+// Completed and cancelled bookings get their timestamps, refunded ones their payment status.
+func (r *bookingRepository) BulkUpdateStatus(ctx context.Context, expectedVersions map[uuid.UUID]int, status models.BookingStatus, reason string) ([]uuid.UUID, error) {
+	if len(expectedVersions) == 0 {
+		return nil, nil
+	}
+
+	pairs := make([][]any, 0, len(expectedVersions))
+	for id, version := range expectedVersions {
+		pairs = append(pairs, []any{id, version})
 	}
 
 	now := time.Now()
+	updates := map[string]any{
+		"status":     status,
+		"updated_at": now,
+		"version":    gorm.Expr("version + 1"),
+	}
+	switch status {
+	case models.BookingStatusCompleted:
+		updates["completed_at"] = now
+	case models.BookingStatusCancelled:
+		updates["cancelled_at"] = now
+		if reason != "" {
+			updates["cancellation_reason"] = reason
+		}
+	case models.BookingStatusRefunded:
+		updates["payment_status"] = models.PaymentStatusRefunded
+	}
+
+	var updated []models.Booking
 	result := r.db.WithContext(ctx).
-		Model(&models.Booking{}).
-		Where("id IN ?", bookingIDs).
-		Updates(map[string]any{
-			"status":              models.BookingStatusCancelled,
-			"cancelled_at":        &now,
-			"cancellation_reason": reason,
-		})
+		Model(&updated).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("(id, version) IN ?", pairs).
+		Updates(updates)
 
 	if result.Error != nil {
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to bulk cancel bookings", result.Error)
+		return nil, errors.NewRepositoryError("UPDATE_FAILED", "failed to bulk update booking status", result.Error)
 	}
 
-	for _, id := range bookingIDs {
-		r.InvalidateCache(ctx, id)
+	ids := make([]uuid.UUID, len(updated))
+	for i, booking := range updated {
+		ids[i] = booking.ID
 	}
 
-	return nil
+	r.InvalidateCacheMany(ctx, ids)
+	return ids, nil
 }
 
 func (r *bookingRepository) BulkUpdatePaymentStatus(ctx context.Context, bookingIDs []uuid.UUID, status models.PaymentStatus) error {
@@ -1473,15 +1832,16 @@ func (r *bookingRepository) BulkUpdatePaymentStatus(ctx context.Context, booking
 	result := r.db.WithContext(ctx).
 		Model(&models.Booking{}).
 		Where("id IN ?", bookingIDs).
-		Update("payment_status", status)
+		Updates(map[string]any{
+			"payment_status": status,
+			"version":        gorm.Expr("version + 1"),
+		})
 
 	if result.Error != nil {
 		return errors.NewRepositoryError("UPDATE_FAILED", "failed to bulk update payment status", result.Error)
 	}
 
-	for _, id := range bookingIDs {
-		r.InvalidateCache(ctx, id)
-	}
+	r.InvalidateCacheMany(ctx, bookingIDs)
 
 	return nil
 }
@@ -1518,6 +1878,10 @@ func (r *bookingRepository) applyBookingFilters(query *gorm.DB, filters BookingF
 		query = query.Where("service_id IN ?", filters.ServiceIDs)
 	}
 
+	if len(filters.ProjectIDs) > 0 {
+		query = query.Where("project_id IN ?", filters.ProjectIDs)
+	}
+
 	if len(filters.Statuses) > 0 {
 		query = query.Where("status IN ?", filters.Statuses)
 	}
@@ -1546,5 +1910,84 @@ func (r *bookingRepository) applyBookingFilters(query *gorm.DB, filters BookingF
 		query = query.Where("is_recurring = ?", *filters.IsRecurring)
 	}
 
+	if filters.MinDuration != nil {
+		query = query.Where("duration >= ?", *filters.MinDuration)
+	}
+
+	if filters.MaxDuration != nil {
+		query = query.Where("duration <= ?", *filters.MaxDuration)
+	}
+
+	if filters.HasDeposit != nil {
+		if *filters.HasDeposit {
+			query = query.Where("deposit_paid > 0")
+		} else {
+			query = query.Where("COALESCE(deposit_paid, 0) = 0")
+		}
+	}
+
+	if filters.HasPhotos != nil {
+		hasPhotos := "(COALESCE(cardinality(before_photo_urls), 0) > 0 OR COALESCE(cardinality(after_photo_urls), 0) > 0)"
+		if *filters.HasPhotos {
+			query = query.Where(hasPhotos)
+		} else {
+			query = query.Where("NOT " + hasPhotos)
+		}
+	}
+
+	if filters.HasLocation != nil {
+		if *filters.HasLocation {
+			query = query.Where("service_location IS NOT NULL")
+		} else {
+			query = query.Where("service_location IS NULL")
+		}
+	}
+
+	if filters.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filters.CreatedAfter)
+	}
+
+	if filters.CreatedBefore != nil {
+		query = query.Where("created_at <= ?", *filters.CreatedBefore)
+	}
+
+	if filters.UpdatedAfter != nil {
+		query = query.Where("updated_at >= ?", *filters.UpdatedAfter)
+	}
+
+	if filters.UpdatedBefore != nil {
+		query = query.Where("updated_at <= ?", *filters.UpdatedBefore)
+	}
+
+	// Tags are stored as a JSONB array; containment uses the GIN index on tags
+	if len(filters.Tags) > 0 {
+		query = query.Where("tags @> ?::jsonb", models.StringArray(filters.Tags))
+	}
+
+	if len(filters.AnyTags) > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(tags) AS tag WHERE tag IN ?)", filters.AnyTags)
+	}
+
+	// Bookings reference the customer's user
+	if !filters.CustomerAudience.IsEmpty() {
+		customers := applyCustomerAudience(r.db.Model(&models.Customer{}).Select("user_id"), filters.CustomerAudience)
+		query = query.Where("customer_id IN (?)", customers)
+	}
+
+	if strings.TrimSpace(filters.SearchQuery) != "" {
+		query = matchBookingSearch(query, filters.SearchQuery)
+	}
+
 	return query
 }
+
+// matchBookingSearch narrows query to bookings whose notes, or whose customer's name
+// and contact details, match the words of text. Text without words matches nothing.
+func matchBookingSearch(query *gorm.DB, text string) *gorm.DB {
+	tsQuery := searchTSQuery(text)
+	if tsQuery == "" {
+		return query.Where("FALSE")
+	}
+	return query.Where("(bookings.search_vector @@ to_tsquery('simple', @q) OR EXISTS (SELECT 1 FROM users u WHERE u.id = bookings.customer_id AND u.search_vector @@ to_tsquery('simple', @q)))",
+		map[string]any{"q": tsQuery})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func setupBookingTest(t *testing.T) (*testutil.TestDB, repository.BookingRepository, uuid.UUID, uuid.UUID, uuid.UUID, uuid.UUID) {
@@ -117,6 +118,27 @@ func TestBookingRepository_GetByArtisan(t *testing.T) {
 		assert.Len(t, bookings, 2)
 		assert.Equal(t, int64(2), pagination.TotalItems)
 	})
+
+	t.Run("walk bookings by artisan with cursor", func(t *testing.T) {
+		first, page1, err := repo.GetByArtisanID(ctx, artisanID, repository.PaginationParams{
+			Page:     1,
+			PageSize: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+		require.True(t, page1.HasMore)
+		require.NotEmpty(t, page1.NextCursor)
+
+		second, page2, err := repo.GetByArtisanID(ctx, artisanID, repository.PaginationParams{
+			PageSize: 1,
+			Cursor:   page1.NextCursor,
+		})
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.NotEqual(t, first[0].ID, second[0].ID)
+		assert.False(t, page2.HasMore)
+		assert.Empty(t, page2.NextCursor)
+	})
 }
 
 func TestBookingRepository_UpdateStatus(t *testing.T) {
@@ -149,6 +171,97 @@ func TestBookingRepository_UpdateStatus(t *testing.T) {
 	})
 }
 
+func TestBookingRepository_BulkUpdateStatus(t *testing.T) {
+	tdb, repo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+
+	current := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID)
+	require.NoError(t, repo.Create(ctx, current))
+	stale := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID, func(b *models.Booking) {
+		b.StartTime = b.StartTime.Add(2 * time.Hour)
+		b.EndTime = b.EndTime.Add(2 * time.Hour)
+	})
+	require.NoError(t, repo.Create(ctx, stale))
+
+	t.Run("find bookings by IDs within the tenant", func(t *testing.T) {
+		found, err := repo.FindByIDs(ctx, tenantID, []uuid.UUID{current.ID, stale.ID, uuid.New()})
+		require.NoError(t, err)
+		assert.Len(t, found, 2)
+
+		found, err = repo.FindByIDs(ctx, uuid.New(), []uuid.UUID{current.ID})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("skip bookings whose version changed", func(t *testing.T) {
+		updated, err := repo.BulkUpdateStatus(ctx, map[uuid.UUID]int{
+			current.ID: current.Version,
+			stale.ID:   stale.Version + 1,
+		}, models.BookingStatusCancelled, "Closed for the day")
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{current.ID}, updated)
+
+		cancelled, err := repo.GetByID(ctx, current.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.BookingStatusCancelled, cancelled.Status)
+		assert.Equal(t, "Closed for the day", cancelled.CancellationReason)
+		assert.NotNil(t, cancelled.CancelledAt)
+
+		untouched, err := repo.GetByID(ctx, stale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, stale.Status, untouched.Status)
+	})
+}
+
+func TestBookingRepository_FindByFiltersTags(t *testing.T) {
+	tdb, repo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+
+	vip := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID, func(b *models.Booking) {
+		b.Tags = models.StringArray{"vip", "wedding"}
+	})
+	require.NoError(t, repo.Create(ctx, vip))
+	regular := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID, func(b *models.Booking) {
+		b.StartTime = b.StartTime.Add(2 * time.Hour)
+		b.EndTime = b.EndTime.Add(2 * time.Hour)
+		b.Tags = models.StringArray{"wedding"}
+	})
+	require.NoError(t, repo.Create(ctx, regular))
+
+	page := repository.PaginationParams{Page: 1, PageSize: 10}
+
+	t.Run("match all tags", func(t *testing.T) {
+		bookings, _, err := repo.FindByFilters(ctx, repository.BookingFilters{
+			TenantID: tenantID,
+			Tags:     []string{"vip", "wedding"},
+		}, page)
+		require.NoError(t, err)
+		require.Len(t, bookings, 1)
+		assert.Equal(t, vip.ID, bookings[0].ID)
+	})
+
+	t.Run("match any tag", func(t *testing.T) {
+		bookings, _, err := repo.FindByFilters(ctx, repository.BookingFilters{
+			TenantID: tenantID,
+			AnyTags:  []string{"vip", "corporate"},
+		}, page)
+		require.NoError(t, err)
+		require.Len(t, bookings, 1)
+		assert.Equal(t, vip.ID, bookings[0].ID)
+
+		bookings, _, err = repo.FindByFilters(ctx, repository.BookingFilters{
+			TenantID: tenantID,
+			AnyTags:  []string{"wedding"},
+		}, page)
+		require.NoError(t, err)
+		assert.Len(t, bookings, 2)
+	})
+}
+
 func TestBookingRepository_GetUpcomingBookings(t *testing.T) {
 	tdb, repo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
 	defer tdb.Close()
@@ -222,3 +335,212 @@ func TestBookingRepository_GetByDateRange(t *testing.T) {
 		assert.True(t, b2.StartTime.After(b1.StartTime))
 	})
 }
+
+func TestBookingRepository_GetArtisanBookingStats(t *testing.T) {
+	tdb, repo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	bookings := []struct {
+		status models.BookingStatus
+		price  float64
+		start  time.Time
+	}{
+		{models.BookingStatusCompleted, 100, now.Add(-72 * time.Hour)},
+		{models.BookingStatusCompleted, 300, now.Add(-48 * time.Hour)},
+		{models.BookingStatusCancelled, 200, now.Add(-24 * time.Hour)},
+		{models.BookingStatusPending, 400, now.Add(24 * time.Hour)},
+		{models.BookingStatusCompleted, 900, now.Add(-30 * 24 * time.Hour)}, // Outside the period
+	}
+	for _, b := range bookings {
+		booking := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID, func(bk *models.Booking) {
+			bk.Status = b.status
+			bk.TotalPrice = b.price
+			bk.StartTime = b.start
+			bk.EndTime = b.start.Add(2 * time.Hour)
+		})
+		require.NoError(t, repo.Create(ctx, booking))
+	}
+
+	stats, err := repo.GetArtisanBookingStats(ctx, artisanID, now.Add(-7*24*time.Hour), now.Add(7*24*time.Hour))
+	require.NoError(t, err)
+
+	// Each figure counts the whole period, not what earlier figures filtered down to
+	assert.Equal(t, int64(4), stats.TotalBookings)
+	assert.Equal(t, int64(2), stats.CompletedBookings)
+	assert.Equal(t, 400.0, stats.TotalRevenue)
+	assert.Equal(t, 200.0, stats.AverageBookingValue)
+	assert.Equal(t, 50.0, stats.UtilizationRate)
+	assert.Equal(t, map[models.BookingStatus]int64{
+		models.BookingStatusPending:   1,
+		models.BookingStatusConfirmed: 0,
+		models.BookingStatusCompleted: 2,
+		models.BookingStatusCancelled: 1,
+		models.BookingStatusNoShow:    0,
+	}, stats.ByStatus)
+}
+
+func TestBookingRepository_GetBookingStats(t *testing.T) {
+	tdb, repo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 12, 0, 0, 0, now.Location())
+	lastMonth := thisMonth.AddDate(0, 0, -2)
+
+	create := func(status models.BookingStatus, price float64, start time.Time) {
+		booking := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID, func(bk *models.Booking) {
+			bk.Status = status
+			bk.TotalPrice = price
+			bk.StartTime = start
+			bk.EndTime = start.Add(time.Hour)
+		})
+		require.NoError(t, repo.Create(ctx, booking))
+	}
+	create(models.BookingStatusCompleted, 100, thisMonth)
+	create(models.BookingStatusCompleted, 50, lastMonth)
+	create(models.BookingStatusPending, 70, thisMonth)
+	create(models.BookingStatusCancelled, 30, lastMonth)
+
+	stats, err := repo.GetBookingStats(ctx, tenantID)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(4), stats.TotalBookings)
+	assert.Equal(t, int64(2), stats.CompletedBookings)
+	assert.Equal(t, int64(1), stats.PendingBookings)
+	assert.Equal(t, int64(1), stats.CancelledBookings)
+	assert.Equal(t, int64(0), stats.ByStatus[models.BookingStatusNoShow])
+	assert.Equal(t, 150.0, stats.TotalRevenue)
+	assert.Equal(t, 37.5, stats.AverageBookingValue)
+	assert.Equal(t, int64(2), stats.ThisMonthBookings)
+	assert.Equal(t, int64(2), stats.LastMonthBookings)
+	assert.Equal(t, 100.0, stats.ThisMonthRevenue)
+	assert.Equal(t, 50.0, stats.LastMonthRevenue)
+
+	t.Run("a new booking replaces the cached stats", func(t *testing.T) {
+		create(models.BookingStatusConfirmed, 20, thisMonth)
+
+		stats, err := repo.GetBookingStats(ctx, tenantID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), stats.TotalBookings)
+		assert.Equal(t, int64(1), stats.ConfirmedBookings)
+	})
+}
+
+func TestBookingRepository_Trash(t *testing.T) {
+	tdb, repo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+
+	booking := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID)
+	require.NoError(t, repo.Create(ctx, booking))
+	require.NoError(t, repo.SoftDelete(ctx, booking.ID))
+
+	t.Run("soft deleted booking is hidden but listed as deleted", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, booking.ID)
+		assert.Error(t, err)
+
+		deleted, page, err := repo.FindDeleted(ctx, map[string]any{"tenant_id": tenantID}, repository.PaginationParams{Page: 1, PageSize: 20})
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		assert.Equal(t, booking.ID, deleted[0].ID)
+		assert.True(t, deleted[0].IsDeleted())
+		assert.Equal(t, int64(1), page.TotalItems)
+	})
+
+	t.Run("restore brings the booking back", func(t *testing.T) {
+		require.NoError(t, repo.Restore(ctx, booking.ID))
+
+		found, err := repo.GetByID(ctx, booking.ID)
+		require.NoError(t, err)
+		assert.False(t, found.IsDeleted())
+
+		// Only deleted bookings can be restored
+		assert.Error(t, repo.Restore(ctx, booking.ID))
+	})
+
+	t.Run("purge removes bookings deleted before the cutoff", func(t *testing.T) {
+		require.NoError(t, repo.SoftDelete(ctx, booking.ID))
+
+		purged, err := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), purged)
+
+		purged, err = repo.PurgeDeleted(ctx, time.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+
+		deleted, _, err := repo.FindDeleted(ctx, map[string]any{"tenant_id": tenantID}, repository.PaginationParams{Page: 1, PageSize: 20})
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+}
+
+func TestBookingRepository_LoadRelations(t *testing.T) {
+	tdb, repo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	payments := repository.NewPaymentRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+
+	var bookings []*models.Booking
+	for i := 0; i < 5; i++ {
+		booking := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID, func(b *models.Booking) {
+			b.StartTime = time.Now().Add(time.Duration(i+1) * 24 * time.Hour)
+			b.EndTime = b.StartTime.Add(time.Hour)
+		})
+		require.NoError(t, repo.Create(ctx, booking))
+		require.NoError(t, payments.Create(ctx, testutil.CreateTestPayment(tenantID, booking.ID, customerID)))
+		bookings = append(bookings, &models.Booking{BaseModel: booking.BaseModel, CustomerID: customerID})
+	}
+
+	queries := 0
+	require.NoError(t, tdb.DB.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		queries++
+	}))
+	defer tdb.DB.Callback().Query().Remove("test:count_queries")
+
+	t.Run("relations are loaded in one query each for all bookings", func(t *testing.T) {
+		queries = 0
+		require.NoError(t, repo.LoadRelations(ctx, bookings, repository.BookingRelationCustomer, repository.BookingRelationPayments))
+
+		// The bookings, then their customers and their payments
+		assert.Equal(t, 3, queries)
+		for _, booking := range bookings {
+			require.NotNil(t, booking.Customer)
+			assert.Equal(t, customerID, booking.Customer.ID)
+			assert.Len(t, booking.Payments, 1)
+		}
+	})
+
+	t.Run("loaded relations are not loaded again", func(t *testing.T) {
+		queries = 0
+		require.NoError(t, repo.LoadRelations(ctx, bookings, repository.BookingRelationCustomer, repository.BookingRelationPayments))
+		assert.Zero(t, queries)
+	})
+
+	t.Run("filters preload the included relations", func(t *testing.T) {
+		found, _, err := repo.FindByFilters(ctx, repository.BookingFilters{
+			TenantID:         tenantID,
+			IncludeRelations: []string{repository.BookingRelationPayments},
+		}, repository.PaginationParams{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		require.Len(t, found, 5)
+		for _, booking := range found {
+			assert.Len(t, booking.Payments, 1)
+		}
+	})
+
+	t.Run("get by ID preloads the relations", func(t *testing.T) {
+		found, err := repo.GetByIDWithRelations(ctx, bookings[0].ID, repository.BookingRelationPayments)
+		require.NoError(t, err)
+		assert.Len(t, found.Payments, 1)
+
+		_, err = repo.GetByIDWithRelations(ctx, uuid.New())
+		assert.Error(t, err)
+	})
+}
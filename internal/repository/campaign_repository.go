@@ -0,0 +1,469 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// campaignRecipientBatchSize caps the recipients inserted per statement when a
+// campaign starts sending
+const campaignRecipientBatchSize = 500
+
+// CampaignRepository defines the interface for marketing campaign operations
+type CampaignRepository interface {
+	BaseRepository[models.Campaign]
+
+	// FindByTenant returns the tenant's campaigns, newest first, optionally only those
+	// in one status
+	FindByTenant(ctx context.Context, tenantID uuid.UUID, status models.CampaignStatus, pagination PaginationParams) ([]*models.Campaign, PaginationResult, error)
+
+	// CountAudience returns how many of the tenant's customers the audience selects
+	CountAudience(ctx context.Context, tenantID uuid.UUID, audience models.CampaignAudience) (int64, error)
+
+	// FindAudienceUserIDs returns the user IDs of the tenant's customers the audience selects
+	FindAudienceUserIDs(ctx context.Context, tenantID uuid.UUID, audience models.CampaignAudience) ([]uuid.UUID, error)
+
+	// FindDue returns scheduled campaigns whose time has come, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*models.Campaign, error)
+
+	// FindSending returns the campaigns being sent
+	FindSending(ctx context.Context) ([]*models.Campaign, error)
+
+	// Start moves a scheduled campaign to sending with its recipients, reporting false
+	// when it is no longer scheduled, such as when it was cancelled
+	Start(ctx context.Context, campaign *models.Campaign, recipients []*models.CampaignRecipient, startedAt time.Time) (bool, error)
+
+	// FindPendingRecipients returns the next recipients of a campaign to send to, with their user
+	FindPendingRecipients(ctx context.Context, campaignID uuid.UUID, limit int) ([]*models.CampaignRecipient, error)
+
+	// SaveRecipientResults records how sending to the recipients went and adds them to
+	// the campaign's metrics
+	SaveRecipientResults(ctx context.Context, campaignID uuid.UUID, recipients []*models.CampaignRecipient) error
+
+	// Complete marks a sending campaign with no pending recipients left as sent,
+	// reporting whether it did
+	Complete(ctx context.Context, campaignID uuid.UUID, completedAt time.Time) (bool, error)
+
+	// Cancel stops a campaign that has not finished, skipping its pending recipients
+	Cancel(ctx context.Context, campaign *models.Campaign, cancelledAt time.Time) error
+
+	// FindRecipients returns a page of a campaign's recipients, optionally only those
+	// in one status
+	FindRecipients(ctx context.Context, campaignID uuid.UUID, status models.CampaignRecipientStatus, pagination PaginationParams) ([]*models.CampaignRecipient, PaginationResult, error)
+
+	// RecordOpen records the first open of the email sent to the recipient with the
+	// token, reporting whether it was the first
+	RecordOpen(ctx context.Context, token string, openedAt time.Time) (bool, error)
+}
+
+// campaignRepository implements CampaignRepository
+type campaignRepository struct {
+	BaseRepository[models.Campaign]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewCampaignRepository creates a new CampaignRepository instance
+func NewCampaignRepository(db *gorm.DB, config ...RepositoryConfig) CampaignRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.Campaign](db, cfg)
+
+	return &campaignRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenant retrieves a page of the tenant's campaigns
+func (r *campaignRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, status models.CampaignStatus, pagination PaginationParams) ([]*models.Campaign, PaginationResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).
+		Model(&models.Campaign{}).
+		Where("tenant_id = ?", tenantID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		r.logger.Error("failed to count campaigns", "tenant_id", tenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count campaigns", err)
+	}
+
+	var campaigns []*models.Campaign
+	if err := query.
+		Order("created_at DESC").
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Find(&campaigns).Error; err != nil {
+		r.logger.Error("failed to find campaigns", "tenant_id", tenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find campaigns", err)
+	}
+
+	return campaigns, CalculatePagination(pagination, totalItems), nil
+}
+
+// audienceQuery selects the tenant's customers in the audience
+func (r *campaignRepository) audienceQuery(ctx context.Context, tenantID uuid.UUID, audience models.CampaignAudience) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&models.Customer{}).
+		Where("customers.tenant_id = ?", tenantID)
+	query = applyCustomerAudience(query, audience.CustomerAudience)
+
+	if !audience.HasBookingCriteria() {
+		return query
+	}
+
+	// Booking customer IDs are user IDs
+	bookings := r.db.Table("bookings").
+		Select("1").
+		Where("bookings.customer_id = customers.user_id AND bookings.tenant_id = customers.tenant_id").
+		Where("bookings.deleted_at IS NULL").
+		Where("bookings.status NOT IN ?", []models.BookingStatus{models.BookingStatusCancelled, models.BookingStatusRefunded})
+	if len(audience.ServiceIDs) > 0 {
+		bookings = bookings.Where("bookings.service_id IN ?", audience.ServiceIDs)
+	}
+	if len(audience.ServiceCategories) > 0 {
+		bookings = bookings.Where("bookings.service_id IN (?)",
+			r.db.Table("services").Select("id").Where("category IN ?", audience.CategoryNames()))
+	}
+	if audience.BookedFrom != nil {
+		bookings = bookings.Where("bookings.start_time >= ?", *audience.BookedFrom)
+	}
+	if audience.BookedTo != nil {
+		bookings = bookings.Where("bookings.start_time < ?", *audience.BookedTo)
+	}
+
+	return query.Where("EXISTS (?)", bookings)
+}
+
+// CountAudience counts the customers the audience selects
+func (r *campaignRepository) CountAudience(ctx context.Context, tenantID uuid.UUID, audience models.CampaignAudience) (int64, error) {
+	var count int64
+	if err := r.audienceQuery(ctx, tenantID, audience).Count(&count).Error; err != nil {
+		r.logger.Error("failed to count campaign audience", "tenant_id", tenantID, "error", err)
+		return 0, errors.NewRepositoryError("COUNT_FAILED", "failed to count campaign audience", err)
+	}
+
+	return count, nil
+}
+
+// FindAudienceUserIDs retrieves the user IDs of the customers the audience selects
+func (r *campaignRepository) FindAudienceUserIDs(ctx context.Context, tenantID uuid.UUID, audience models.CampaignAudience) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	if err := r.audienceQuery(ctx, tenantID, audience).
+		Order("customers.created_at").
+		Pluck("customers.user_id", &userIDs).Error; err != nil {
+		r.logger.Error("failed to find campaign audience", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find campaign audience", err)
+	}
+
+	return userIDs, nil
+}
+
+// FindDue retrieves scheduled campaigns due to start sending
+func (r *campaignRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*models.Campaign, error) {
+	var campaigns []*models.Campaign
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND scheduled_at <= ?", models.CampaignStatusScheduled, now).
+		Order("scheduled_at").
+		Limit(limit).
+		Find(&campaigns).Error; err != nil {
+		r.logger.Error("failed to find due campaigns", "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find due campaigns", err)
+	}
+
+	return campaigns, nil
+}
+
+// FindSending retrieves the campaigns being sent, in the order they started
+func (r *campaignRepository) FindSending(ctx context.Context) ([]*models.Campaign, error) {
+	var campaigns []*models.Campaign
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", models.CampaignStatusSending).
+		Order("started_at").
+		Find(&campaigns).Error; err != nil {
+		r.logger.Error("failed to find sending campaigns", "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find sending campaigns", err)
+	}
+
+	return campaigns, nil
+}
+
+// Start claims the scheduled campaign and inserts its recipients in one transaction, so
+// a campaign cancelled meanwhile or started by another run sends nothing
+func (r *campaignRepository) Start(ctx context.Context, campaign *models.Campaign, recipients []*models.CampaignRecipient, startedAt time.Time) (bool, error) {
+	started := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Campaign{}).
+			Where("id = ? AND status = ?", campaign.ID, models.CampaignStatusScheduled).
+			Updates(map[string]any{
+				"status":     models.CampaignStatusSending,
+				"started_at": startedAt,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		inserted := int64(0)
+		if len(recipients) > 0 {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(recipients, campaignRecipientBatchSize)
+			if result.Error != nil {
+				return result.Error
+			}
+			inserted = result.RowsAffected
+		}
+
+		if err := tx.Model(&models.Campaign{}).
+			Where("id = ?", campaign.ID).
+			UpdateColumn("recipient_count", inserted).Error; err != nil {
+			return err
+		}
+
+		campaign.Status = models.CampaignStatusSending
+		campaign.StartedAt = &startedAt
+		campaign.RecipientCount = int(inserted)
+		started = true
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed to start campaign", "campaign_id", campaign.ID, "error", err)
+		return false, errors.NewRepositoryError("UPDATE_FAILED", "failed to start campaign", err)
+	}
+
+	r.RepositoryCache().Invalidate(ctx, campaign.TenantID, campaign.ID)
+	return started, nil
+}
+
+// FindPendingRecipients retrieves the campaign's recipients waiting to be sent to
+func (r *campaignRepository) FindPendingRecipients(ctx context.Context, campaignID uuid.UUID, limit int) ([]*models.CampaignRecipient, error) {
+	var recipients []*models.CampaignRecipient
+	if err := r.db.WithContext(ctx).
+		Preload("Customer").
+		Where("campaign_id = ? AND status = ?", campaignID, models.CampaignRecipientStatusPending).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&recipients).Error; err != nil {
+		r.logger.Error("failed to find pending campaign recipients", "campaign_id", campaignID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find pending campaign recipients", err)
+	}
+
+	return recipients, nil
+}
+
+// SaveRecipientResults updates the recipients that are still pending and adds the
+// outcomes to the campaign's counters in one transaction. Recipients skipped by a
+// cancellation meanwhile keep that outcome.
+func (r *campaignRepository) SaveRecipientResults(ctx context.Context, campaignID uuid.UUID, recipients []*models.CampaignRecipient) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		counts := map[models.CampaignRecipientStatus]int{}
+		for _, recipient := range recipients {
+			result := tx.Model(&models.CampaignRecipient{}).
+				Where("id = ? AND status = ?", recipient.ID, models.CampaignRecipientStatusPending).
+				Updates(map[string]any{
+					"status":          recipient.Status,
+					"skip_reason":     recipient.SkipReason,
+					"notification_id": recipient.NotificationID,
+					"sent_at":         recipient.SentAt,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				counts[recipient.Status]++
+			}
+		}
+
+		return tx.Model(&models.Campaign{}).
+			Where("id = ?", campaignID).
+			UpdateColumns(map[string]any{
+				"sent_count":    gorm.Expr("sent_count + ?", counts[models.CampaignRecipientStatusSent]),
+				"skipped_count": gorm.Expr("skipped_count + ?", counts[models.CampaignRecipientStatusSkipped]),
+				"failed_count":  gorm.Expr("failed_count + ?", counts[models.CampaignRecipientStatusFailed]),
+				"version":       gorm.Expr("version + 1"),
+			}).Error
+	})
+	if err != nil {
+		r.logger.Error("failed to save campaign recipient results", "campaign_id", campaignID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to save campaign recipient results", err)
+	}
+
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, campaignID)
+	return nil
+}
+
+// Complete marks the campaign sent once none of its recipients are pending
+func (r *campaignRepository) Complete(ctx context.Context, campaignID uuid.UUID, completedAt time.Time) (bool, error) {
+	pending := r.db.Model(&models.CampaignRecipient{}).
+		Select("1").
+		Where("campaign_id = campaigns.id AND status = ?", models.CampaignRecipientStatusPending)
+
+	result := r.db.WithContext(ctx).
+		Model(&models.Campaign{}).
+		Where("id = ? AND status = ?", campaignID, models.CampaignStatusSending).
+		Where("NOT EXISTS (?)", pending).
+		Updates(map[string]any{
+			"status":       models.CampaignStatusSent,
+			"completed_at": completedAt,
+			"version":      gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		r.logger.Error("failed to complete campaign", "campaign_id", campaignID, "error", result.Error)
+		return false, errors.NewRepositoryError("UPDATE_FAILED", "failed to complete campaign", result.Error)
+	}
+
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, campaignID)
+	return result.RowsAffected > 0, nil
+}
+
+// Cancel cancels the campaign and skips its pending recipients in one transaction
+func (r *campaignRepository) Cancel(ctx context.Context, campaign *models.Campaign, cancelledAt time.Time) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Campaign{}).
+			Where("id = ? AND status IN ?", campaign.ID, []models.CampaignStatus{
+				models.CampaignStatusDraft, models.CampaignStatusScheduled, models.CampaignStatusSending,
+			}).
+			Updates(map[string]any{
+				"status":       models.CampaignStatusCancelled,
+				"cancelled_at": cancelledAt,
+				"version":      gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrNotFound
+		}
+
+		skipped := tx.Model(&models.CampaignRecipient{}).
+			Where("campaign_id = ? AND status = ?", campaign.ID, models.CampaignRecipientStatusPending).
+			Updates(map[string]any{
+				"status":      models.CampaignRecipientStatusSkipped,
+				"skip_reason": models.CampaignSkipCancelled,
+			})
+		if skipped.Error != nil {
+			return skipped.Error
+		}
+
+		if err := tx.Model(&models.Campaign{}).
+			Where("id = ?", campaign.ID).
+			UpdateColumn("skipped_count", gorm.Expr("skipped_count + ?", skipped.RowsAffected)).Error; err != nil {
+			return err
+		}
+
+		campaign.Status = models.CampaignStatusCancelled
+		campaign.CancelledAt = &cancelledAt
+		campaign.SkippedCount += int(skipped.RowsAffected)
+		return nil
+	})
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.NewRepositoryError("NOT_FOUND", "campaign not found or already finished", err)
+		}
+		r.logger.Error("failed to cancel campaign", "campaign_id", campaign.ID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to cancel campaign", err)
+	}
+
+	r.RepositoryCache().Invalidate(ctx, campaign.TenantID, campaign.ID)
+	return nil
+}
+
+// FindRecipients retrieves a page of the campaign's recipients with their user
+func (r *campaignRepository) FindRecipients(ctx context.Context, campaignID uuid.UUID, status models.CampaignRecipientStatus, pagination PaginationParams) ([]*models.CampaignRecipient, PaginationResult, error) {
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).
+		Model(&models.CampaignRecipient{}).
+		Where("campaign_id = ?", campaignID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		r.logger.Error("failed to count campaign recipients", "campaign_id", campaignID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count campaign recipients", err)
+	}
+
+	var recipients []*models.CampaignRecipient
+	if err := query.
+		Preload("Customer").
+		Order("created_at, id").
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Find(&recipients).Error; err != nil {
+		r.logger.Error("failed to find campaign recipients", "campaign_id", campaignID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find campaign recipients", err)
+	}
+
+	return recipients, CalculatePagination(pagination, totalItems), nil
+}
+
+// RecordOpen sets the recipient's open time and counts the open on the campaign in one
+// transaction; later opens of the same email are not counted
+func (r *campaignRepository) RecordOpen(ctx context.Context, token string, openedAt time.Time) (bool, error) {
+	opened, campaignID := false, uuid.Nil
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var recipient models.CampaignRecipient
+		if err := tx.Where("open_token = ?", token).First(&recipient).Error; err != nil {
+			return err
+		}
+		if recipient.OpenedAt != nil || recipient.Status != models.CampaignRecipientStatusSent {
+			return nil
+		}
+
+		result := tx.Model(&models.CampaignRecipient{}).
+			Where("id = ? AND opened_at IS NULL", recipient.ID).
+			Update("opened_at", openedAt)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		opened = true
+		campaignID = recipient.CampaignID
+		return tx.Model(&models.Campaign{}).
+			Where("id = ?", recipient.CampaignID).
+			UpdateColumns(map[string]any{
+				"open_count": gorm.Expr("open_count + 1"),
+				"version":    gorm.Expr("version + 1"),
+			}).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, errors.NewRepositoryError("NOT_FOUND", "campaign recipient not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to record campaign open", "error", err)
+		return false, errors.NewRepositoryError("UPDATE_FAILED", "failed to record campaign open", err)
+	}
+
+	if opened {
+		r.RepositoryCache().Invalidate(ctx, uuid.Nil, campaignID)
+	}
+	return opened, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CancellationPolicyRepository defines the interface for cancellation policy repository operations
+type CancellationPolicyRepository interface {
+	BaseRepository[models.CancellationPolicy]
+
+	// Query Operations
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.CancellationPolicy, error)
+
+	// FindEffective returns the active service-specific policy, falling back to the
+	// tenant-wide policy. Returns a NOT_FOUND error when the tenant has neither.
+	FindEffective(ctx context.Context, tenantID uuid.UUID, serviceID uuid.UUID) (*models.CancellationPolicy, error)
+
+	// Update saves every setting of the policy, including zero fees and deactivation,
+	// if it still has the version it was loaded with
+	Update(ctx context.Context, policy *models.CancellationPolicy) error
+}
+
+// cancellationPolicyRepository implements CancellationPolicyRepository
+type cancellationPolicyRepository struct {
+	BaseRepository[models.CancellationPolicy]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewCancellationPolicyRepository creates a new CancellationPolicyRepository instance
+func NewCancellationPolicyRepository(db *gorm.DB, config ...RepositoryConfig) CancellationPolicyRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.CancellationPolicy](db, cfg)
+
+	return &cancellationPolicyRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenantID retrieves all cancellation policies for a tenant
+func (r *cancellationPolicyRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.CancellationPolicy, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var policies []*models.CancellationPolicy
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("service_id NULLS FIRST, created_at ASC").
+		Find(&policies).Error; err != nil {
+		r.logger.Error("failed to find cancellation policies", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find cancellation policies", err)
+	}
+
+	return policies, nil
+}
+
+// FindEffective retrieves the policy that applies to bookings of the given service
+func (r *cancellationPolicyRepository) FindEffective(ctx context.Context, tenantID uuid.UUID, serviceID uuid.UUID) (*models.CancellationPolicy, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var policy models.CancellationPolicy
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND is_active = ?", tenantID, true).
+		Where("service_id = ? OR service_id IS NULL", serviceID).
+		// Service-specific policies take precedence over the tenant default
+		Order("service_id NULLS LAST, updated_at DESC").
+		First(&policy).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "cancellation policy not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to find effective cancellation policy", "tenant_id", tenantID, "service_id", serviceID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find cancellation policy", err)
+	}
+
+	return &policy, nil
+}
+
+// Update saves the policy with every column selected, as the base Update skips zero
+// values such as a 0% no-show fee or an inactive policy
+func (r *cancellationPolicyRepository) Update(ctx context.Context, policy *models.CancellationPolicy) error {
+	if policy == nil || policy.ID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "policy id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	loadedVersion := policy.Version
+	policy.Version++
+	policy.UpdatedAt = time.Now()
+
+	result := r.db.WithContext(ctx).
+		Model(&models.CancellationPolicy{}).
+		Where("id = ? AND version = ?", policy.ID, loadedVersion).
+		Select("*").
+		Omit("id", "created_at", "deleted_at").
+		Updates(policy)
+	if result.Error != nil {
+		policy.Version = loadedVersion
+		r.logger.Error("failed to update cancellation policy", "policy_id", policy.ID, "error", result.Error)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update cancellation policy", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		policy.Version = loadedVersion
+		return errors.NewRepositoryError("CONFLICT", "cancellation policy was modified by another process", errors.ErrConflict)
+	}
+
+	r.RepositoryCache().Invalidate(ctx, policy.TenantID, policy.ID)
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancellationPolicyRepository_ZeroSettings(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Close()
+
+	repo := repository.NewCancellationPolicyRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	t.Run("a 0% no-show fee and no free cancellation window are stored", func(t *testing.T) {
+		policy := &models.CancellationPolicy{
+			TenantID:              tenantID,
+			Name:                  "Lenient",
+			FreeCancellationHours: 0,
+			NoShowFeePercentage:   0,
+			IsActive:              true,
+		}
+		require.NoError(t, repo.Create(ctx, policy))
+
+		found, err := repo.GetByID(ctx, policy.ID)
+		require.NoError(t, err)
+		assert.Zero(t, found.FreeCancellationHours)
+		assert.Zero(t, found.NoShowFeePercentage)
+	})
+
+	t.Run("a deactivated policy no longer applies", func(t *testing.T) {
+		effective, err := repo.FindEffective(ctx, tenantID, uuid.New())
+		require.NoError(t, err)
+
+		effective.IsActive = false
+		require.NoError(t, repo.Update(ctx, effective))
+
+		_, err = repo.FindEffective(ctx, tenantID, uuid.New())
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+
+	t.Run("an inactive policy is created inactive", func(t *testing.T) {
+		policy := &models.CancellationPolicy{
+			TenantID:            tenantID,
+			Name:                "Draft",
+			NoShowFeePercentage: 50,
+		}
+		require.NoError(t, repo.Create(ctx, policy))
+
+		found, err := repo.GetByID(ctx, policy.ID)
+		require.NoError(t, err)
+		assert.False(t, found.IsActive)
+	})
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChangeOrderRepository defines the interface for change order repository operations
+type ChangeOrderRepository interface {
+	BaseRepository[models.ChangeOrder]
+
+	// List returns change orders matching the filter, newest first
+	List(ctx context.Context, filter ChangeOrderFilter, pagination PaginationParams) ([]*models.ChangeOrder, PaginationResult, error)
+
+	// FindEvents returns a change order's history, oldest first
+	FindEvents(ctx context.Context, changeOrderID uuid.UUID) ([]*models.ChangeOrderEvent, error)
+
+	// CreateWithEvent numbers and saves a new change order together with its first
+	// history event
+	CreateWithEvent(ctx context.Context, changeOrder *models.ChangeOrder, event *models.ChangeOrderEvent) error
+
+	// SaveWithEvent saves a change order and a history event. It fails with a conflict if
+	// the change order left loadedStatus or was edited in the meantime.
+	SaveWithEvent(ctx context.Context, changeOrder *models.ChangeOrder, loadedStatus models.ChangeOrderStatus, event *models.ChangeOrderEvent) error
+
+	// Apply saves an approved change order and moves the project's budget and due date
+	// by its deltas in one transaction, with the project row locked
+	Apply(ctx context.Context, changeOrder *models.ChangeOrder, loadedStatus models.ChangeOrderStatus, event *models.ChangeOrderEvent) (*models.Project, error)
+}
+
+// ChangeOrderFilter narrows change order listings
+type ChangeOrderFilter struct {
+	TenantID   uuid.UUID
+	ProjectID  *uuid.UUID
+	CustomerID *uuid.UUID // Change orders on the customer's projects
+	Statuses   []models.ChangeOrderStatus
+	Submitted  bool // Only change orders that were ever sent to the customer
+}
+
+// changeOrderRepository implements ChangeOrderRepository
+type changeOrderRepository struct {
+	BaseRepository[models.ChangeOrder]
+	db     *gorm.DB
+	logger log.AllLogger
+	cache  Cache
+}
+
+// NewChangeOrderRepository creates a new ChangeOrderRepository instance
+func NewChangeOrderRepository(db *gorm.DB, config ...RepositoryConfig) ChangeOrderRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.ChangeOrder](db, cfg)
+
+	return &changeOrderRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+		cache:          cfg.Cache,
+	}
+}
+
+// List retrieves change orders matching the filter
+func (r *changeOrderRepository) List(ctx context.Context, filter ChangeOrderFilter, pagination PaginationParams) ([]*models.ChangeOrder, PaginationResult, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).Model(&models.ChangeOrder{}).Where("change_orders.tenant_id = ?", filter.TenantID)
+	if filter.ProjectID != nil {
+		query = query.Where("change_orders.project_id = ?", *filter.ProjectID)
+	}
+	if filter.CustomerID != nil {
+		query = query.
+			Joins("JOIN projects ON projects.id = change_orders.project_id").
+			Where("projects.customer_id = ?", *filter.CustomerID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("change_orders.status IN ?", filter.Statuses)
+	}
+	if filter.Submitted {
+		query = query.Where("change_orders.submitted_at IS NOT NULL")
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count change orders", err)
+	}
+
+	var changeOrders []*models.ChangeOrder
+	if err := query.
+		Select("change_orders.*").
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("change_orders.created_at DESC, change_orders.id DESC").
+		Find(&changeOrders).Error; err != nil {
+		r.logger.Error("failed to list change orders", "tenant_id", filter.TenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to list change orders", err)
+	}
+
+	return changeOrders, CalculatePagination(pagination, totalItems), nil
+}
+
+// FindEvents retrieves a change order's history
+func (r *changeOrderRepository) FindEvents(ctx context.Context, changeOrderID uuid.UUID) ([]*models.ChangeOrderEvent, error) {
+	if changeOrderID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "change_order_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var events []*models.ChangeOrderEvent
+	if err := r.db.WithContext(ctx).
+		Where("change_order_id = ?", changeOrderID).
+		Order("created_at ASC, id ASC").
+		Find(&events).Error; err != nil {
+		r.logger.Error("failed to find change order events", "change_order_id", changeOrderID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find change order events", err)
+	}
+
+	return events, nil
+}
+
+// CreateWithEvent creates a change order with the next number in its project
+func (r *changeOrderRepository) CreateWithEvent(ctx context.Context, changeOrder *models.ChangeOrder, event *models.ChangeOrderEvent) error {
+	if changeOrder == nil || event == nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "change order and event are required", errors.ErrInvalidInput)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the project so concurrent change orders get distinct numbers
+		if _, err := lockProject(tx, changeOrder.ProjectID); err != nil {
+			return err
+		}
+
+		var last int
+		if err := tx.Model(&models.ChangeOrder{}).
+			Where("project_id = ?", changeOrder.ProjectID).
+			Select("COALESCE(MAX(number), 0)").
+			Scan(&last).Error; err != nil {
+			return errors.NewRepositoryError("GENERATION_FAILED", "failed to number change order", err)
+		}
+		changeOrder.Number = last + 1
+
+		if changeOrder.ID == uuid.Nil {
+			changeOrder.ID = uuid.New()
+		}
+		if err := tx.Create(changeOrder).Error; err != nil {
+			r.logger.Error("failed to create change order", "project_id", changeOrder.ProjectID, "error", err)
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to create change order", err)
+		}
+
+		event.ChangeOrderID = changeOrder.ID
+		return r.createEvent(tx, event)
+	})
+}
+
+// SaveWithEvent saves a change order if it is unchanged since it was loaded
+func (r *changeOrderRepository) SaveWithEvent(ctx context.Context, changeOrder *models.ChangeOrder, loadedStatus models.ChangeOrderStatus, event *models.ChangeOrderEvent) error {
+	if changeOrder == nil || event == nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "change order and event are required", errors.ErrInvalidInput)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.saveIfUnchanged(tx, changeOrder, loadedStatus); err != nil {
+			return err
+		}
+		return r.createEvent(tx, event)
+	})
+}
+
+// Apply adjusts the project for an approved change order
+func (r *changeOrderRepository) Apply(ctx context.Context, changeOrder *models.ChangeOrder, loadedStatus models.ChangeOrderStatus, event *models.ChangeOrderEvent) (*models.Project, error) {
+	if changeOrder == nil || event == nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "change order and event are required", errors.ErrInvalidInput)
+	}
+
+	var project *models.Project
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if project, err = lockProject(tx, changeOrder.ProjectID); err != nil {
+			return err
+		}
+
+		// Apply against the locked row so concurrent edits to the budget are not lost
+		if err := changeOrder.ApplyTo(project); err != nil {
+			return errors.NewRepositoryError("INVALID_INPUT", err.Error(), errors.ErrInvalidInput)
+		}
+		if err := tx.Model(&models.Project{}).
+			Where("id = ?", project.ID).
+			Updates(map[string]any{
+				"budget_amount": project.BudgetAmount,
+				"due_date":      project.DueDate,
+				"version":       gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			r.logger.Error("failed to apply change order to project", "change_order_id", changeOrder.ID, "error", err)
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to adjust project", err)
+		}
+		project.Version++
+
+		if err := r.saveIfUnchanged(tx, changeOrder, loadedStatus); err != nil {
+			return err
+		}
+		return r.createEvent(tx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	r.RepositoryCache().InvalidateTable(ctx, "projects", project.TenantID, project.ID)
+	return project, nil
+}
+
+// lockProject loads a project with its row locked until the transaction ends
+func lockProject(tx *gorm.DB, projectID uuid.UUID) (*models.Project, error) {
+	var project models.Project
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, "id = ?", projectID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "project not found", errors.ErrNotFound)
+		}
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to lock project", err)
+	}
+	return &project, nil
+}
+
+func (r *changeOrderRepository) createEvent(tx *gorm.DB, event *models.ChangeOrderEvent) error {
+	if err := tx.Create(event).Error; err != nil {
+		r.logger.Error("failed to record change order event", "change_order_id", event.ChangeOrderID, "action", event.Action, "error", err)
+		return errors.NewRepositoryError("CREATE_FAILED", "failed to record change order event", err)
+	}
+	return nil
+}
+
+// saveIfUnchanged saves the change order only if it still has the status and version
+// it was loaded with, so concurrent answers and edits cannot both win
+func (r *changeOrderRepository) saveIfUnchanged(tx *gorm.DB, changeOrder *models.ChangeOrder, loadedStatus models.ChangeOrderStatus) error {
+	loadedVersion := changeOrder.Version
+	changeOrder.Version++
+	changeOrder.UpdatedAt = time.Now()
+
+	result := tx.Model(&models.ChangeOrder{}).
+		Where("id = ? AND version = ? AND status = ?", changeOrder.ID, loadedVersion, loadedStatus).
+		Select("*").
+		Omit("id", "created_at").
+		Updates(changeOrder)
+	if result.Error != nil {
+		changeOrder.Version = loadedVersion
+		r.logger.Error("failed to save change order", "change_order_id", changeOrder.ID, "error", result.Error)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to save change order", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		changeOrder.Version = loadedVersion
+		return errors.NewRepositoryError("CONFLICT", "change order was changed by another request", errors.ErrConflict)
+	}
+	return nil
+}
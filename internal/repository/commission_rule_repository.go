@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CommissionRuleRepository defines the interface for commission rule repository operations
+type CommissionRuleRepository interface {
+	BaseRepository[models.CommissionRule]
+
+	// FindByTenantID returns all of a tenant's commission rules
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.CommissionRule, error)
+
+	// FindActive returns the tenant's active commission rules; models.ResolveCommissionRule
+	// picks the one that applies
+	FindActive(ctx context.Context, tenantID uuid.UUID) ([]*models.CommissionRule, error)
+}
+
+// commissionRuleRepository implements CommissionRuleRepository
+type commissionRuleRepository struct {
+	BaseRepository[models.CommissionRule]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewCommissionRuleRepository creates a new CommissionRuleRepository instance
+func NewCommissionRuleRepository(db *gorm.DB, config ...RepositoryConfig) CommissionRuleRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.CommissionRule](db, cfg)
+
+	return &commissionRuleRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenantID retrieves all commission rules for a tenant
+func (r *commissionRuleRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.CommissionRule, error) {
+	return r.find(ctx, tenantID, false)
+}
+
+// FindActive retrieves the active commission rules for a tenant
+func (r *commissionRuleRepository) FindActive(ctx context.Context, tenantID uuid.UUID) ([]*models.CommissionRule, error) {
+	return r.find(ctx, tenantID, true)
+}
+
+func (r *commissionRuleRepository) find(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]*models.CommissionRule, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var rules []*models.CommissionRule
+	if err := query.
+		Order("service_category ASC, artisan_tier ASC, min_monthly_volume ASC, created_at ASC").
+		Find(&rules).Error; err != nil {
+		r.logger.Error("failed to find commission rules", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find commission rules", err)
+	}
+
+	return rules, nil
+}
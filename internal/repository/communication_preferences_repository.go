@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	stdErrors "errors"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CommunicationPreferencesRepository defines the interface for communication preferences repository operations
+type CommunicationPreferencesRepository interface {
+	BaseRepository[models.CommunicationPreferences]
+
+	// FindByUserID returns a user's communication preferences
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*models.CommunicationPreferences, error)
+
+	// FindByUnsubscribeToken returns the communication preferences an unsubscribe link is for
+	FindByUnsubscribeToken(ctx context.Context, token string) (*models.CommunicationPreferences, error)
+}
+
+// communicationPreferencesRepository implements CommunicationPreferencesRepository
+type communicationPreferencesRepository struct {
+	BaseRepository[models.CommunicationPreferences]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewCommunicationPreferencesRepository creates a new CommunicationPreferencesRepository instance
+func NewCommunicationPreferencesRepository(db *gorm.DB, config ...RepositoryConfig) CommunicationPreferencesRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.CommunicationPreferences](db, cfg)
+
+	return &communicationPreferencesRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByUserID retrieves the communication preferences of a user
+func (r *communicationPreferencesRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*models.CommunicationPreferences, error) {
+	if userID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "user_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var preferences models.CommunicationPreferences
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&preferences).Error; err != nil {
+		if stdErrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "communication preferences not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to find communication preferences", "user_id", userID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find communication preferences", err)
+	}
+
+	return &preferences, nil
+}
+
+// FindByUnsubscribeToken retrieves the communication preferences with the unsubscribe token
+func (r *communicationPreferencesRepository) FindByUnsubscribeToken(ctx context.Context, token string) (*models.CommunicationPreferences, error) {
+	if token == "" {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "token cannot be empty", errors.ErrInvalidInput)
+	}
+
+	var preferences models.CommunicationPreferences
+	if err := r.db.WithContext(ctx).Where("unsubscribe_token = ?", token).First(&preferences).Error; err != nil {
+		if stdErrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "communication preferences not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to find communication preferences by unsubscribe token", "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find communication preferences", err)
+	}
+
+	return &preferences, nil
+}
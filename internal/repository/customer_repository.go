@@ -70,6 +70,19 @@ type CustomerRepository interface {
 	GetHighValueCustomers(ctx context.Context, tenantID uuid.UUID, minSpent float64) ([]*models.Customer, error)
 	GetAtRiskCustomers(ctx context.Context, tenantID uuid.UUID, inactiveDays int, minPreviousBookings int) ([]*models.Customer, error)
 
+	// FindTenantsToSegment returns the tenants with customers not segmented since before
+	FindTenantsToSegment(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+	// GetSegmentActivity returns the activity each of the tenant's customers is segmented
+	// by, aggregated from their completed bookings
+	GetSegmentActivity(ctx context.Context, tenantID uuid.UUID) ([]CustomerSegmentActivity, error)
+	// SetSegments records the customers' computed segments
+	SetSegments(ctx context.Context, customerIDs []uuid.UUID, segments models.StringArray, segmentedAt time.Time) error
+	// GetSegmentTotals returns how many of the tenant's customers are in each segment and
+	// what they spent
+	GetSegmentTotals(ctx context.Context, tenantID uuid.UUID) ([]CustomerSegmentTotals, error)
+	// UpdateTags replaces a customer's manual tags
+	UpdateTags(ctx context.Context, customerID uuid.UUID, tags models.StringArray) error
+
 	// Search & Filter
 	Search(ctx context.Context, query string, tenantID uuid.UUID, pagination PaginationParams) ([]*models.Customer, PaginationResult, error)
 	FindByFilters(ctx context.Context, filters CustomerFilters, pagination PaginationParams) ([]*models.Customer, PaginationResult, error)
@@ -107,6 +120,22 @@ type CustomerAnalytics struct {
 	ByLoyaltyTier        map[string]int64 `json:"by_loyalty_tier"`
 }
 
+// CustomerSegmentActivity is a customer's activity as segmentation sees it
+type CustomerSegmentActivity struct {
+	CustomerID        uuid.UUID
+	JoinedAt          time.Time
+	CompletedBookings int
+	TotalSpent        float64
+	LastBookingAt     *time.Time
+}
+
+// CustomerSegmentTotals sums up the customers in a segment
+type CustomerSegmentTotals struct {
+	Segment    string  `json:"segment"`
+	Customers  int64   `json:"customers"`
+	TotalSpent float64 `json:"total_spent"`
+}
+
 // CustomerFilters for advanced filtering
 type CustomerFilters struct {
 	TenantID            uuid.UUID   `json:"tenant_id"`
@@ -124,6 +153,9 @@ type CustomerFilters struct {
 	SMSNotifications    *bool       `json:"sms_notifications"`
 	CreatedAfter        *time.Time  `json:"created_after"`
 	CreatedBefore       *time.Time  `json:"created_before"`
+
+	// Customers in the audience, by segment and tag
+	Audience models.CustomerAudience `json:"audience"`
 }
 
 type customerRepository struct {
@@ -920,6 +952,95 @@ func (r *customerRepository) GetAtRiskCustomers(ctx context.Context, tenantID uu
 	return customers, nil
 }
 
+func (r *customerRepository) FindTenantsToSegment(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	var tenantIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&models.Customer{}).
+		Where("segmented_at IS NULL OR segmented_at < ?", before).
+		Distinct("tenant_id").
+		Pluck("tenant_id", &tenantIDs).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find tenants to segment", err)
+	}
+
+	return tenantIDs, nil
+}
+
+func (r *customerRepository) GetSegmentActivity(ctx context.Context, tenantID uuid.UUID) ([]CustomerSegmentActivity, error) {
+	// Bookings reference the customer's user
+	var activity []CustomerSegmentActivity
+	err := r.db.WithContext(ctx).
+		Model(&models.Customer{}).
+		Select(`customers.id AS customer_id, customers.created_at AS joined_at,
+			COUNT(bookings.id) AS completed_bookings,
+			COALESCE(SUM(bookings.total_price), 0) AS total_spent,
+			MAX(bookings.start_time) AS last_booking_at`).
+		Joins("LEFT JOIN bookings ON bookings.customer_id = customers.user_id AND bookings.tenant_id = customers.tenant_id AND bookings.status = ? AND bookings.deleted_at IS NULL", models.BookingStatusCompleted).
+		Where("customers.tenant_id = ?", tenantID).
+		Group("customers.id, customers.created_at").
+		Scan(&activity).Error
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "failed to aggregate customer activity", err)
+	}
+
+	return activity, nil
+}
+
+func (r *customerRepository) SetSegments(ctx context.Context, customerIDs []uuid.UUID, segments models.StringArray, segmentedAt time.Time) error {
+	if len(customerIDs) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&models.Customer{}).
+		Where("id IN ?", customerIDs).
+		Updates(map[string]any{
+			"segments":     segments,
+			"segmented_at": segmentedAt,
+		}).Error; err != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update customer segments", err)
+	}
+
+	for _, customerID := range customerIDs {
+		r.InvalidateCache(ctx, customerID)
+	}
+	return nil
+}
+
+func (r *customerRepository) GetSegmentTotals(ctx context.Context, tenantID uuid.UUID) ([]CustomerSegmentTotals, error) {
+	var totals []CustomerSegmentTotals
+	err := r.db.WithContext(ctx).
+		Table("customers, jsonb_array_elements_text(customers.segments) AS segment").
+		Select("segment, COUNT(*) AS customers, COALESCE(SUM(customers.total_spent), 0) AS total_spent").
+		Where("customers.tenant_id = ? AND customers.deleted_at IS NULL", tenantID).
+		Group("segment").
+		Scan(&totals).Error
+
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "failed to total customer segments", err)
+	}
+
+	return totals, nil
+}
+
+func (r *customerRepository) UpdateTags(ctx context.Context, customerID uuid.UUID, tags models.StringArray) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Customer{}).
+		Where("id = ?", customerID).
+		Update("tags", tags)
+
+	if result.Error != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update customer tags", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewRepositoryError("NOT_FOUND", "customer not found", errors.ErrNotFound)
+	}
+
+	r.InvalidateCache(ctx, customerID)
+	return nil
+}
+
 //------------------------------------------------------------
 // Search & Filter
 //------------------------------------------------------------
@@ -1138,18 +1259,19 @@ func (r *customerRepository) applyCustomerFilters(query *gorm.DB, filters Custom
 		query = query.Where("created_at <= ?", *filters.CreatedBefore)
 	}
 
-	return query
+	return applyCustomerAudience(query, filters.Audience)
 }
 
-func (r *customerRepository) InvalidateCache(ctx context.Context, customerID uuid.UUID) error {
-	if r.cache != nil {
-		cacheKey := fmt.Sprintf("customer:%s", customerID.String())
-		if err := r.cache.Delete(ctx, cacheKey); err != nil {
-			if r.logger != nil {
-				r.logger.Warnf("Failed to invalidate cache for customer %s: %v", customerID, err)
-			}
-			return err
-		}
+// applyCustomerAudience restricts a customers query to the audience. Segments and tags
+// are stored as JSONB arrays; containment uses their GIN indexes.
+func applyCustomerAudience(query *gorm.DB, audience models.CustomerAudience) *gorm.DB {
+	if len(audience.Segments) > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM jsonb_array_elements_text(segments) AS segment WHERE segment IN ?)", audience.SegmentNames())
 	}
-	return nil
+
+	if len(audience.Tags) > 0 {
+		query = query.Where("tags @> ?::jsonb", models.StringArray(audience.Tags))
+	}
+
+	return query
 }
@@ -0,0 +1,467 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerRequestRepository defines the interface for request-for-quote operations:
+// customer requests, the leads they are broadcast as and the bids artisans make
+type CustomerRequestRepository interface {
+	BaseRepository[models.CustomerRequest]
+
+	// List returns a tenant's requests matching the filter, newest first
+	List(ctx context.Context, filter CustomerRequestFilter, pagination PaginationParams) ([]*models.CustomerRequest, PaginationResult, error)
+
+	// CreateWithLeads creates a request together with the leads it is broadcast as
+	CreateWithLeads(ctx context.Context, request *models.CustomerRequest, leads []*models.CustomerRequestLead) error
+
+	// FindLead returns an artisan's lead for a request
+	FindLead(ctx context.Context, requestID, artisanID uuid.UUID) (*models.CustomerRequestLead, error)
+
+	// FindArtisanLeads returns a page of an artisan's leads with their request, newest
+	// first, optionally only those of requests still open
+	FindArtisanLeads(ctx context.Context, tenantID, artisanID uuid.UUID, openOnly bool, pagination PaginationParams) ([]*models.CustomerRequestLead, PaginationResult, error)
+
+	// MarkLeadViewed records when the artisan first opened the lead
+	MarkLeadViewed(ctx context.Context, leadID uuid.UUID, viewedAt time.Time) error
+
+	// FindBids returns a request's bids other than withdrawn ones with their artisan,
+	// the cheapest first
+	FindBids(ctx context.Context, requestID uuid.UUID) ([]*models.CustomerRequestBid, error)
+
+	// FindBid returns an artisan's bid on a request
+	FindBid(ctx context.Context, requestID, artisanID uuid.UUID) (*models.CustomerRequestBid, error)
+
+	// SaveBid creates or updates a submitted bid while its request is open. It fails with
+	// a conflict when the request was awarded, closed or expired meanwhile.
+	SaveBid(ctx context.Context, bid *models.CustomerRequestBid) error
+
+	// WithdrawBid withdraws a submitted bid
+	WithdrawBid(ctx context.Context, bid *models.CustomerRequestBid) error
+
+	// Award saves an awarded request and its accepted bid together with the project or
+	// booking the bid became, rejecting the other bids, in one transaction. It fails with
+	// a conflict if the request or bid changed in the meantime.
+	Award(ctx context.Context, request *models.CustomerRequest, bid *models.CustomerRequestBid, project *models.Project, booking *models.Booking) error
+
+	// Close closes an open request and rejects its bids
+	Close(ctx context.Context, request *models.CustomerRequest, closedAt time.Time) error
+
+	// ExpireOpen marks open requests whose bidding ended before now as expired and
+	// rejects their bids
+	ExpireOpen(ctx context.Context, now time.Time) (int64, error)
+}
+
+// CustomerRequestFilter narrows customer request listings
+type CustomerRequestFilter struct {
+	TenantID   uuid.UUID
+	CustomerID *uuid.UUID
+	Statuses   []models.CustomerRequestStatus
+}
+
+// customerRequestRepository implements CustomerRequestRepository
+type customerRequestRepository struct {
+	BaseRepository[models.CustomerRequest]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewCustomerRequestRepository creates a new CustomerRequestRepository instance
+func NewCustomerRequestRepository(db *gorm.DB, config ...RepositoryConfig) CustomerRequestRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.CustomerRequest](db, cfg)
+
+	return &customerRequestRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// List retrieves requests matching the filter
+func (r *customerRequestRepository) List(ctx context.Context, filter CustomerRequestFilter, pagination PaginationParams) ([]*models.CustomerRequest, PaginationResult, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).Model(&models.CustomerRequest{}).Where("tenant_id = ?", filter.TenantID)
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count customer requests", err)
+	}
+
+	var requests []*models.CustomerRequest
+	if err := query.
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("created_at DESC, id DESC").
+		Find(&requests).Error; err != nil {
+		r.logger.Error("failed to list customer requests", "tenant_id", filter.TenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to list customer requests", err)
+	}
+
+	return requests, CalculatePagination(pagination, totalItems), nil
+}
+
+// CreateWithLeads creates the request and its leads in one transaction
+func (r *customerRequestRepository) CreateWithLeads(ctx context.Context, request *models.CustomerRequest, leads []*models.CustomerRequestLead) error {
+	request.LeadCount = len(leads)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(request).Error; err != nil {
+			return err
+		}
+		for _, lead := range leads {
+			lead.TenantID = request.TenantID
+			lead.RequestID = request.ID
+		}
+		if len(leads) == 0 {
+			return nil
+		}
+		return tx.Create(&leads).Error
+	})
+	if err != nil {
+		r.logger.Error("failed to create customer request", "tenant_id", request.TenantID, "error", err)
+		return errors.NewRepositoryError("CREATE_FAILED", "failed to create customer request", err)
+	}
+
+	return nil
+}
+
+// FindLead retrieves the artisan's lead for the request
+func (r *customerRequestRepository) FindLead(ctx context.Context, requestID, artisanID uuid.UUID) (*models.CustomerRequestLead, error) {
+	var lead models.CustomerRequestLead
+	if err := r.db.WithContext(ctx).
+		Where("request_id = ? AND artisan_id = ?", requestID, artisanID).
+		First(&lead).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "lead not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to find lead", "request_id", requestID, "artisan_id", artisanID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find lead", err)
+	}
+
+	return &lead, nil
+}
+
+// FindArtisanLeads retrieves a page of the artisan's leads
+func (r *customerRequestRepository) FindArtisanLeads(ctx context.Context, tenantID, artisanID uuid.UUID, openOnly bool, pagination PaginationParams) ([]*models.CustomerRequestLead, PaginationResult, error) {
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).
+		Model(&models.CustomerRequestLead{}).
+		Joins("Request").
+		Where("customer_request_leads.tenant_id = ? AND customer_request_leads.artisan_id = ?", tenantID, artisanID)
+	if openOnly {
+		query = query.Where(`"Request"."status" = ?`, models.CustomerRequestStatusOpen)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count leads", err)
+	}
+
+	var leads []*models.CustomerRequestLead
+	if err := query.
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("customer_request_leads.created_at DESC, customer_request_leads.id DESC").
+		Find(&leads).Error; err != nil {
+		r.logger.Error("failed to find leads", "artisan_id", artisanID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find leads", err)
+	}
+
+	return leads, CalculatePagination(pagination, totalItems), nil
+}
+
+// MarkLeadViewed sets the lead's view time unless it was viewed before
+func (r *customerRequestRepository) MarkLeadViewed(ctx context.Context, leadID uuid.UUID, viewedAt time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Model(&models.CustomerRequestLead{}).
+		Where("id = ? AND viewed_at IS NULL", leadID).
+		Update("viewed_at", viewedAt).Error; err != nil {
+		r.logger.Error("failed to mark lead viewed", "lead_id", leadID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to mark lead viewed", err)
+	}
+
+	return nil
+}
+
+// FindBids retrieves the request's standing and answered bids
+func (r *customerRequestRepository) FindBids(ctx context.Context, requestID uuid.UUID) ([]*models.CustomerRequestBid, error) {
+	var bids []*models.CustomerRequestBid
+	if err := r.db.WithContext(ctx).
+		Preload("Artisan.User").
+		Where("request_id = ? AND status <> ?", requestID, models.CustomerRequestBidStatusWithdrawn).
+		Order("amount ASC, created_at ASC").
+		Find(&bids).Error; err != nil {
+		r.logger.Error("failed to find bids", "request_id", requestID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find bids", err)
+	}
+
+	return bids, nil
+}
+
+// FindBid retrieves the artisan's bid on the request
+func (r *customerRequestRepository) FindBid(ctx context.Context, requestID, artisanID uuid.UUID) (*models.CustomerRequestBid, error) {
+	var bid models.CustomerRequestBid
+	if err := r.db.WithContext(ctx).
+		Where("request_id = ? AND artisan_id = ?", requestID, artisanID).
+		First(&bid).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "bid not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to find bid", "request_id", requestID, "artisan_id", artisanID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find bid", err)
+	}
+
+	return &bid, nil
+}
+
+// SaveBid writes the bid and recounts the request's bids in one transaction; the
+// recount only touches an open request, so a bid on one that just closed is rolled back
+func (r *customerRequestRepository) SaveBid(ctx context.Context, bid *models.CustomerRequestBid) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bid.Status = models.CustomerRequestBidStatusSubmitted
+		if err := tx.Save(bid).Error; err != nil {
+			return err
+		}
+		return r.recountBids(tx, bid.RequestID, true)
+	})
+	if err != nil {
+		if err == errors.ErrConflict {
+			return errors.NewRepositoryError("CONFLICT", "request is no longer open", errors.ErrConflict)
+		}
+		r.logger.Error("failed to save bid", "request_id", bid.RequestID, "artisan_id", bid.ArtisanID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to save bid", err)
+	}
+
+	r.RepositoryCache().Invalidate(ctx, bid.TenantID, bid.RequestID)
+	return nil
+}
+
+// WithdrawBid withdraws the bid and recounts the request's bids in one transaction
+func (r *customerRequestRepository) WithdrawBid(ctx context.Context, bid *models.CustomerRequestBid) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.CustomerRequestBid{}).
+			Where("id = ? AND status = ?", bid.ID, models.CustomerRequestBidStatusSubmitted).
+			Updates(map[string]any{
+				"status":  models.CustomerRequestBidStatusWithdrawn,
+				"version": gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrConflict
+		}
+		return r.recountBids(tx, bid.RequestID, false)
+	})
+	if err != nil {
+		if err == errors.ErrConflict {
+			return errors.NewRepositoryError("CONFLICT", "bid is no longer submitted", errors.ErrConflict)
+		}
+		r.logger.Error("failed to withdraw bid", "bid_id", bid.ID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to withdraw bid", err)
+	}
+
+	bid.Status = models.CustomerRequestBidStatusWithdrawn
+	r.RepositoryCache().Invalidate(ctx, bid.TenantID, bid.RequestID)
+	return nil
+}
+
+// recountBids sets the request's bid count to its bids other than withdrawn ones,
+// failing with ErrConflict when openOnly is set and the request is not open
+func (r *customerRequestRepository) recountBids(tx *gorm.DB, requestID uuid.UUID, openOnly bool) error {
+	count := tx.Model(&models.CustomerRequestBid{}).
+		Select("COUNT(*)").
+		Where("request_id = ? AND status <> ?", requestID, models.CustomerRequestBidStatusWithdrawn)
+
+	query := tx.Model(&models.CustomerRequest{}).Where("id = ?", requestID)
+	if openOnly {
+		query = query.Where("status = ?", models.CustomerRequestStatusOpen)
+	}
+	result := query.UpdateColumns(map[string]any{
+		"bid_count": gorm.Expr("(?)", count),
+		"version":   gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrConflict
+	}
+	return nil
+}
+
+// Award converts the accepted bid and records the award in one transaction
+func (r *customerRequestRepository) Award(ctx context.Context, request *models.CustomerRequest, bid *models.CustomerRequestBid, project *models.Project, booking *models.Booking) error {
+	if project == nil && booking == nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "a project or booking is required", errors.ErrInvalidInput)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if project != nil {
+			if err := tx.Create(project).Error; err != nil {
+				return err
+			}
+			request.ProjectID = &project.ID
+		}
+		if booking != nil {
+			if err := tx.Create(booking).Error; err != nil {
+				return err
+			}
+			request.BookingID = &booking.ID
+		}
+
+		result := tx.Model(&models.CustomerRequest{}).
+			Where("id = ? AND status = ? AND version = ?", request.ID, models.CustomerRequestStatusOpen, request.Version).
+			Updates(map[string]any{
+				"status":         request.Status,
+				"awarded_bid_id": request.AwardedBidID,
+				"awarded_at":     request.AwardedAt,
+				"project_id":     request.ProjectID,
+				"booking_id":     request.BookingID,
+				"version":        gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrConflict
+		}
+
+		result = tx.Model(&models.CustomerRequestBid{}).
+			Where("id = ? AND status = ? AND version = ?", bid.ID, models.CustomerRequestBidStatusSubmitted, bid.Version).
+			Updates(map[string]any{
+				"status":  bid.Status,
+				"version": gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrConflict
+		}
+
+		return r.rejectBids(tx, []uuid.UUID{request.ID})
+	})
+	if err != nil {
+		if err == errors.ErrConflict {
+			return errors.NewRepositoryError("CONFLICT", "request or bid was changed by another request", errors.ErrConflict)
+		}
+		r.logger.Error("failed to award customer request", "request_id", request.ID, "bid_id", bid.ID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to award customer request", err)
+	}
+
+	request.Version++
+	bid.Version++
+	r.RepositoryCache().Invalidate(ctx, request.TenantID, request.ID)
+	if project != nil {
+		r.RepositoryCache().InvalidateTable(ctx, "projects", project.TenantID, project.ID)
+	}
+	if booking != nil {
+		r.RepositoryCache().InvalidateTable(ctx, "bookings", booking.TenantID, booking.ID)
+	}
+	return nil
+}
+
+// Close closes the request and rejects its submitted bids in one transaction
+func (r *customerRequestRepository) Close(ctx context.Context, request *models.CustomerRequest, closedAt time.Time) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.CustomerRequest{}).
+			Where("id = ? AND status = ?", request.ID, models.CustomerRequestStatusOpen).
+			Updates(map[string]any{
+				"status":    models.CustomerRequestStatusClosed,
+				"closed_at": closedAt,
+				"version":   gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrConflict
+		}
+
+		return r.rejectBids(tx, []uuid.UUID{request.ID})
+	})
+	if err != nil {
+		if err == errors.ErrConflict {
+			return errors.NewRepositoryError("CONFLICT", "request is no longer open", errors.ErrConflict)
+		}
+		r.logger.Error("failed to close customer request", "request_id", request.ID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to close customer request", err)
+	}
+
+	request.Status = models.CustomerRequestStatusClosed
+	request.ClosedAt = &closedAt
+	r.RepositoryCache().Invalidate(ctx, request.TenantID, request.ID)
+	return nil
+}
+
+// ExpireOpen expires open requests past their bidding and rejects their bids in one
+// transaction
+func (r *customerRequestRepository) ExpireOpen(ctx context.Context, now time.Time) (int64, error) {
+	var expired int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Model(&models.CustomerRequest{}).
+			Where("status = ? AND expires_at <= ?", models.CustomerRequestStatusOpen, now).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		result := tx.Model(&models.CustomerRequest{}).
+			Where("id IN ? AND status = ?", ids, models.CustomerRequestStatusOpen).
+			UpdateColumns(map[string]any{
+				"status":     models.CustomerRequestStatusExpired,
+				"updated_at": now,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		expired = result.RowsAffected
+
+		return r.rejectBids(tx, ids)
+	})
+	if err != nil {
+		r.logger.Error("failed to expire customer requests", "error", err)
+		return 0, errors.NewRepositoryError("UPDATE_FAILED", "failed to expire customer requests", err)
+	}
+
+	return expired, nil
+}
+
+// rejectBids rejects the submitted bids of the requests
+func (r *customerRequestRepository) rejectBids(tx *gorm.DB, requestIDs []uuid.UUID) error {
+	return tx.Model(&models.CustomerRequestBid{}).
+		Where("request_id IN ? AND status = ?", requestIDs, models.CustomerRequestBidStatusSubmitted).
+		Updates(map[string]any{
+			"status":  models.CustomerRequestBidStatusRejected,
+			"version": gorm.Expr("version + 1"),
+		}).Error
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FavoriteRepository defines the interface for customer favorite operations
+type FavoriteRepository interface {
+	BaseRepository[models.Favorite]
+
+	// Add saves a favorite and counts it on the favorited artisan or service
+	Add(ctx context.Context, favorite *models.Favorite) error
+
+	// Remove deletes a favorite and uncounts it from the favorited artisan or service
+	Remove(ctx context.Context, favorite *models.Favorite) error
+
+	// FindByTarget returns the customer's favorite of an artisan profile or service
+	FindByTarget(ctx context.Context, customerID uuid.UUID, kind models.FavoriteKind, targetID uuid.UUID) (*models.Favorite, error)
+
+	// FindByCustomer returns the customer's favorites in the tenant, newest first,
+	// optionally only those of one kind
+	FindByCustomer(ctx context.Context, tenantID, customerID uuid.UUID, kind models.FavoriteKind, pagination PaginationParams) ([]*models.Favorite, PaginationResult, error)
+
+	// FindToNotify returns the favorites of an artisan profile asking for notifications
+	// whose customer was last notified before the cutoff, or never
+	FindToNotify(ctx context.Context, artisanID uuid.UUID, notifiedBefore time.Time) ([]*models.Favorite, error)
+
+	// MarkNotified records when the favorites' customers were notified
+	MarkNotified(ctx context.Context, favoriteIDs []uuid.UUID, notifiedAt time.Time) error
+}
+
+// favoriteRepository implements FavoriteRepository
+type favoriteRepository struct {
+	BaseRepository[models.Favorite]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewFavoriteRepository creates a new FavoriteRepository instance
+func NewFavoriteRepository(db *gorm.DB, config ...RepositoryConfig) FavoriteRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.Favorite](db, cfg)
+
+	return &favoriteRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// Add creates the favorite and increments the target's favorite count in one
+// transaction. A favorite the customer already has is reported as a duplicate.
+func (r *favoriteRepository) Add(ctx context.Context, favorite *models.Favorite) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(favorite)
+		if result.Error != nil {
+			r.logger.Error("failed to create favorite", "customer_id", favorite.CustomerID, "error", result.Error)
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to create favorite", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewRepositoryError("DUPLICATE", "favorite already exists", errors.ErrDuplicate)
+		}
+		return adjustFavoriteCount(tx, favorite, 1)
+	})
+}
+
+// Remove hard-deletes the favorite, so it can be added again, and decrements the
+// target's favorite count in one transaction
+func (r *favoriteRepository) Remove(ctx context.Context, favorite *models.Favorite) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Delete(&models.Favorite{}, "id = ?", favorite.ID)
+		if result.Error != nil {
+			r.logger.Error("failed to delete favorite", "favorite_id", favorite.ID, "error", result.Error)
+			return errors.NewRepositoryError("DELETE_FAILED", "failed to delete favorite", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewRepositoryError("NOT_FOUND", "favorite not found", errors.ErrNotFound)
+		}
+		return adjustFavoriteCount(tx, favorite, -1)
+	})
+}
+
+// adjustFavoriteCount moves the favorite count of the favorite's artisan or service by delta
+func adjustFavoriteCount(tx *gorm.DB, favorite *models.Favorite, delta int) error {
+	var model any = &models.Service{}
+	if favorite.Kind() == models.FavoriteKindArtisan {
+		model = &models.Artisan{}
+	}
+
+	if err := tx.Model(model).
+		Where("id = ?", favorite.TargetID()).
+		UpdateColumn("favorite_count", gorm.Expr("GREATEST(favorite_count + ?, 0)", delta)).Error; err != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update favorite count", err)
+	}
+	return nil
+}
+
+// FindByTarget finds the customer's favorite of an artisan profile or service
+func (r *favoriteRepository) FindByTarget(ctx context.Context, customerID uuid.UUID, kind models.FavoriteKind, targetID uuid.UUID) (*models.Favorite, error) {
+	column := "service_id"
+	if kind == models.FavoriteKindArtisan {
+		column = "artisan_id"
+	}
+
+	var favorite models.Favorite
+	if err := r.db.WithContext(ctx).
+		Where("customer_id = ? AND "+column+" = ?", customerID, targetID).
+		First(&favorite).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "favorite not found", errors.ErrNotFound)
+		}
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find favorite", err)
+	}
+
+	return &favorite, nil
+}
+
+// FindByCustomer retrieves a page of the customer's favorites with their artisan or service
+func (r *favoriteRepository) FindByCustomer(ctx context.Context, tenantID, customerID uuid.UUID, kind models.FavoriteKind, pagination PaginationParams) ([]*models.Favorite, PaginationResult, error) {
+	if tenantID == uuid.Nil || customerID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id and customer_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).
+		Model(&models.Favorite{}).
+		Where("tenant_id = ? AND customer_id = ?", tenantID, customerID)
+	switch kind {
+	case models.FavoriteKindArtisan:
+		query = query.Where("artisan_id IS NOT NULL")
+	case models.FavoriteKindService:
+		query = query.Where("service_id IS NOT NULL")
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		r.logger.Error("failed to count favorites", "customer_id", customerID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count favorites", err)
+	}
+
+	var favorites []*models.Favorite
+	if err := query.
+		Preload("Artisan.User").
+		Preload("Service").
+		Order("created_at DESC").
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Find(&favorites).Error; err != nil {
+		r.logger.Error("failed to find favorites", "customer_id", customerID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find favorites", err)
+	}
+
+	return favorites, CalculatePagination(pagination, totalItems), nil
+}
+
+// FindToNotify retrieves the artisan favorites due a notification
+func (r *favoriteRepository) FindToNotify(ctx context.Context, artisanID uuid.UUID, notifiedBefore time.Time) ([]*models.Favorite, error) {
+	var favorites []*models.Favorite
+	if err := r.db.WithContext(ctx).
+		Where("artisan_id = ? AND notify = ?", artisanID, true).
+		Where("notified_at IS NULL OR notified_at < ?", notifiedBefore).
+		Find(&favorites).Error; err != nil {
+		r.logger.Error("failed to find favorites to notify", "artisan_id", artisanID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find favorites to notify", err)
+	}
+
+	return favorites, nil
+}
+
+// MarkNotified sets the notification time of the favorites
+func (r *favoriteRepository) MarkNotified(ctx context.Context, favoriteIDs []uuid.UUID, notifiedAt time.Time) error {
+	if len(favoriteIDs) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&models.Favorite{}).
+		Where("id IN ?", favoriteIDs).
+		Update("notified_at", notifiedAt).Error; err != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to mark favorites notified", err)
+	}
+
+	return nil
+}
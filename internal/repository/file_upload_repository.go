@@ -254,7 +254,7 @@ func (r *fileUploadRepository) GetFileStats(ctx context.Context, tenantID uuid.U
 func (r *fileUploadRepository) CleanupOrphanedFiles(ctx context.Context, olderThan time.Duration) error {
 	cutoffDate := time.Now().Add(-olderThan)
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("related_entity_id IS NULL AND created_at < ?", cutoffDate).
 		Delete(&models.FileUpload{})
 
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatementFilter narrows a tenant's financial statements
+type StatementFilter struct {
+	Kind        models.StatementKind
+	SubjectID   *uuid.UUID
+	PeriodStart *time.Time
+}
+
+// FinancialStatementRepository defines the interface for monthly financial statements
+type FinancialStatementRepository interface {
+	BaseRepository[models.FinancialStatement]
+
+	// FindByTenant returns a tenant's statements, newest period first
+	FindByTenant(ctx context.Context, tenantID uuid.UUID, filter StatementFilter) ([]*models.FinancialStatement, error)
+
+	// HasTenantStatement reports whether the tenant's revenue statement for a period exists
+	HasTenantStatement(ctx context.Context, tenantID uuid.UUID, periodStart time.Time) (bool, error)
+
+	// Save stores a statement, replacing an earlier one for the same subject and period
+	Save(ctx context.Context, statement *models.FinancialStatement) error
+}
+
+// financialStatementRepository implements FinancialStatementRepository
+type financialStatementRepository struct {
+	BaseRepository[models.FinancialStatement]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewFinancialStatementRepository creates a new FinancialStatementRepository instance
+func NewFinancialStatementRepository(db *gorm.DB, config ...RepositoryConfig) FinancialStatementRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.FinancialStatement](db, cfg)
+
+	return &financialStatementRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenant retrieves a tenant's statements matching the filter
+func (r *financialStatementRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, filter StatementFilter) ([]*models.FinancialStatement, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.SubjectID != nil {
+		query = query.Where("subject_id = ?", *filter.SubjectID)
+	}
+	if filter.PeriodStart != nil {
+		query = query.Where("period_start = ?", filter.PeriodStart.Format(time.DateOnly))
+	}
+
+	var statements []*models.FinancialStatement
+	if err := query.Order("period_start DESC, kind DESC, subject_id ASC").Find(&statements).Error; err != nil {
+		r.logger.Error("failed to find financial statements", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find financial statements", err)
+	}
+
+	return statements, nil
+}
+
+// HasTenantStatement checks whether a period's revenue statement was generated
+func (r *financialStatementRepository) HasTenantStatement(ctx context.Context, tenantID uuid.UUID, periodStart time.Time) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.FinancialStatement{}).
+		Where("tenant_id = ? AND kind = ? AND period_start = ?", tenantID, models.StatementKindTenant, periodStart.Format(time.DateOnly)).
+		Count(&count).Error; err != nil {
+		return false, errors.NewRepositoryError("COUNT_FAILED", "failed to check financial statement", err)
+	}
+	return count > 0, nil
+}
+
+// Save replaces the subject's statement for the period in one transaction
+func (r *financialStatementRepository) Save(ctx context.Context, statement *models.FinancialStatement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.FinancialStatement
+		err := tx.Where("tenant_id = ? AND kind = ? AND subject_id = ? AND period_start = ?",
+			statement.TenantID, statement.Kind, statement.SubjectID, statement.PeriodStart.Format(time.DateOnly)).
+			First(&existing).Error
+		switch {
+		case err == nil:
+			statement.ID = existing.ID
+			statement.CreatedAt = existing.CreatedAt
+			return tx.Save(statement).Error
+		case err == gorm.ErrRecordNotFound:
+			return tx.Create(statement).Error
+		default:
+			return err
+		}
+	})
+	if err != nil {
+		r.logger.Error("failed to save financial statement", "tenant_id", statement.TenantID, "subject_id", statement.SubjectID, "error", err)
+		return errors.NewRepositoryError("SAVE_FAILED", "failed to save financial statement", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKeyRepository defines the interface for idempotency key repository operations.
+// It satisfies middleware.IdempotencyStore, backing idempotency keys with Postgres when
+// Redis is not configured.
+type IdempotencyKeyRepository interface {
+	BaseRepository[models.IdempotencyKey]
+
+	Reserve(ctx context.Context, record *models.IdempotencyKey) (*models.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, record *models.IdempotencyKey) error
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// idempotencyKeyRepository implements IdempotencyKeyRepository
+type idempotencyKeyRepository struct {
+	BaseRepository[models.IdempotencyKey]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewIdempotencyKeyRepository creates a new IdempotencyKeyRepository instance
+func NewIdempotencyKeyRepository(db *gorm.DB, config ...RepositoryConfig) IdempotencyKeyRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.IdempotencyKey](db, cfg)
+
+	return &idempotencyKeyRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// Reserve inserts the key unless a live record already holds it, in which case that
+// record is returned. An expired record is replaced.
+func (r *idempotencyKeyRepository) Reserve(ctx context.Context, record *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	var existing *models.IdempotencyKey
+	reserved := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("key = ? AND expires_at <= ?", record.Key, time.Now()).
+			Delete(&models.IdempotencyKey{}).Error; err != nil {
+			return errors.NewRepositoryError("DELETE_FAILED", "failed to delete expired idempotency key", err)
+		}
+
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoNothing: true,
+		}).Create(record)
+		if result.Error != nil {
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to reserve idempotency key", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			reserved = true
+			return nil
+		}
+
+		var found models.IdempotencyKey
+		if err := tx.Where("key = ?", record.Key).First(&found).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return errors.NewRepositoryError("GET_FAILED", "failed to get idempotency key", err)
+		}
+		existing = &found
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return existing, reserved, nil
+}
+
+// Complete stores the response recorded for a reserved key
+func (r *idempotencyKeyRepository) Complete(ctx context.Context, record *models.IdempotencyKey) error {
+	if err := r.db.WithContext(ctx).
+		Model(&models.IdempotencyKey{}).
+		Where("key = ?", record.Key).
+		Updates(map[string]any{
+			"completed":     true,
+			"status_code":   record.StatusCode,
+			"content_type":  record.ContentType,
+			"response_body": record.ResponseBody,
+			"updated_at":    time.Now(),
+		}).Error; err != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to complete idempotency key", err)
+	}
+	return nil
+}
+
+// Release deletes a reserved key so the request can be retried
+func (r *idempotencyKeyRepository) Release(ctx context.Context, key string) error {
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("key = ?", key).
+		Delete(&models.IdempotencyKey{}).Error; err != nil {
+		return errors.NewRepositoryError("DELETE_FAILED", "failed to release idempotency key", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes keys that can no longer be replayed
+func (r *idempotencyKeyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Unscoped().
+		Where("expires_at <= ?", time.Now()).
+		Delete(&models.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, errors.NewRepositoryError("DELETE_FAILED", "failed to delete expired idempotency keys", result.Error)
+	}
+	return result.RowsAffected, nil
+}
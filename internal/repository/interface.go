@@ -14,38 +14,76 @@ type Repositories struct {
 	Tenant TenantRepository
 
 	// Business Operations
-	Booking      BookingRepository
-	Service      ServiceRepository
-	ServiceAddon ServiceAddonRepository
-	Payment      PaymentRepository
-	Invoice      InvoiceRepository
-	PromoCode    PromoCodeRepository
+	Booking        BookingRepository
+	Service        ServiceRepository
+	ServiceAddon   ServiceAddonRepository
+	ServicePrice   ServicePriceVersionRepository
+	Payment        PaymentRepository
+	Payout         PayoutRepository
+	Reconciliation ReconciliationRepository
+	Statement      FinancialStatementRepository
+	Invoice        InvoiceRepository
+	TaxRule        TaxRuleRepository
+	CommissionRule CommissionRuleRepository
+	PricingRule    PricingRuleRepository
+	PromoCode      PromoCodeRepository
+
+	// Booking Management
+	CancellationPolicy  CancellationPolicyRepository
+	BookingImport       BookingImportRepository
+	BookingExport       BookingExportRepository
+	SavedFilter         SavedFilterRepository
+	BookingEvent        BookingEventRepository
+	BookingConversation BookingConversationRepository
 
 	// Project Management
 	Project          ProjectRepository
 	ProjectMilestone ProjectMilestoneRepository
 	ProjectTask      ProjectTaskRepository
 	ProjectUpdate    ProjectUpdateRepository
+	TimeEntry        TimeEntryRepository
+	Quote            QuoteRepository
+	ProjectTemplate  ProjectTemplateRepository
+	ChangeOrder      ChangeOrderRepository
+	CustomerRequest  CustomerRequestRepository
 
 	// User Management
 	Artisan      ArtisanRepository
 	Customer     CustomerRepository
 	Review       *ReviewRepository
+	ReviewReply  ReviewReplyRepository
+	ReviewFlag   ReviewFlagRepository
 	Availability AvailabilityRepository
+	ServiceArea  ServiceAreaRepository
+	Verification VerificationDocumentRepository
+	Favorite     FavoriteRepository
+	Skill        SkillRepository
 
 	// Communication & Files
-	Message      MessageRepository
-	FileUpload   FileUploadRepository
-	Notification NotificationRepository
+	Message                  MessageRepository
+	FileUpload               FileUploadRepository
+	Notification             NotificationRepository
+	CommunicationPreferences CommunicationPreferencesRepository
+	Campaign                 CampaignRepository
 
 	// Analytics & Administration
 	Report              ReportRepository
+	Plan                PlanRepository
 	Subscription        SubscriptionRepository
 	SystemSetting       SystemSettingRepository
 	TenantInvitation    TenantInvitationRepository
 	TenantUsageTracking TenantUsageTrackingRepository
 	DataExport          DataExportRequestRepository
 	WebhookEvent        WebhookEventRepository
+	WebhookSubscription WebhookSubscriptionRepository
+	TenantRole          TenantRoleRepository
+	TenantOnboarding    TenantOnboardingRepository
+	AuditLog            AuditLogRepository
+	IdempotencyKey      IdempotencyKeyRepository
+	AnalyticsRollup     AnalyticsRollupRepository
+	Search              SearchRepository
+	Archive             ArchiveRepository
+	Outbox              OutboxRepository
 
 	// Branding & Customization
 	WhiteLabel WhiteLabelRepository
@@ -54,6 +92,12 @@ type Repositories struct {
 	SDKClient SDKClientRepository
 	SDKKey    SDKKeyRepository
 	SDKUsage  SDKUsageRepository
+
+	// Integrations
+	ServiceAccount ServiceAccountRepository
+
+	db     *gorm.DB
+	config RepositoryConfig
 }
 
 // NewRepositories creates a new instance of all repositories with the given database connection.
@@ -77,38 +121,76 @@ func NewRepositories(db *gorm.DB, config ...RepositoryConfig) *Repositories {
 		Tenant: NewTenantRepository(db, cfg),
 
 		// Business Operations
-		Booking:      NewBookingRepository(db, cfg),
-		Service:      NewServiceRepository(db, cfg),
-		ServiceAddon: NewServiceAddonRepository(db, cfg),
-		Payment:      NewPaymentRepository(db, cfg),
-		Invoice:      NewInvoiceRepository(db, cfg),
-		PromoCode:    NewPromoCodeRepository(db, cfg),
+		Booking:        NewBookingRepository(db, cfg),
+		Service:        NewServiceRepository(db, cfg),
+		ServiceAddon:   NewServiceAddonRepository(db, cfg),
+		ServicePrice:   NewServicePriceVersionRepository(db, cfg),
+		Payment:        NewPaymentRepository(db, cfg),
+		Payout:         NewPayoutRepository(db, cfg),
+		Reconciliation: NewReconciliationRepository(db, cfg),
+		Statement:      NewFinancialStatementRepository(db, cfg),
+		Invoice:        NewInvoiceRepository(db, cfg),
+		TaxRule:        NewTaxRuleRepository(db, cfg),
+		CommissionRule: NewCommissionRuleRepository(db, cfg),
+		PricingRule:    NewPricingRuleRepository(db, cfg),
+		PromoCode:      NewPromoCodeRepository(db, cfg),
+
+		// Booking Management
+		CancellationPolicy:  NewCancellationPolicyRepository(db, cfg),
+		BookingImport:       NewBookingImportRepository(db, cfg),
+		BookingExport:       NewBookingExportRepository(db, cfg),
+		SavedFilter:         NewSavedFilterRepository(db, cfg),
+		BookingEvent:        NewBookingEventRepository(db, cfg),
+		BookingConversation: NewBookingConversationRepository(db, cfg),
 
 		// Project Management
 		Project:          NewProjectRepository(db, cfg),
 		ProjectMilestone: NewProjectMilestoneRepository(db, cfg),
 		ProjectTask:      NewProjectTaskRepository(db, cfg),
 		ProjectUpdate:    NewProjectUpdateRepository(db, cfg),
+		TimeEntry:        NewTimeEntryRepository(db, cfg),
+		Quote:            NewQuoteRepository(db, cfg),
+		ProjectTemplate:  NewProjectTemplateRepository(db, cfg),
+		ChangeOrder:      NewChangeOrderRepository(db, cfg),
+		CustomerRequest:  NewCustomerRequestRepository(db, cfg),
 
 		// User Management
 		Artisan:      NewArtisanRepository(db, cfg),
 		Customer:     NewCustomerRepository(db, cfg),
 		Review:       NewReviewRepository(db, cfg.Logger),
+		ReviewReply:  NewReviewReplyRepository(db, cfg),
+		ReviewFlag:   NewReviewFlagRepository(db, cfg),
 		Availability: NewAvailabilityRepository(db),
+		ServiceArea:  NewServiceAreaRepository(db, cfg),
+		Verification: NewVerificationDocumentRepository(db, cfg),
+		Favorite:     NewFavoriteRepository(db, cfg),
+		Skill:        NewSkillRepository(db, cfg),
 
 		// Communication & Files
-		Message:      NewMessageRepository(db, cfg),
-		FileUpload:   NewFileUploadRepository(db, cfg),
-		Notification: NewNotificationRepository(db, cfg),
+		Message:                  NewMessageRepository(db, cfg),
+		FileUpload:               NewFileUploadRepository(db, cfg),
+		Notification:             NewNotificationRepository(db, cfg),
+		CommunicationPreferences: NewCommunicationPreferencesRepository(db, cfg),
+		Campaign:                 NewCampaignRepository(db, cfg),
 
 		// Analytics & Administration
 		Report:              NewReportRepository(db, cfg),
+		Plan:                NewPlanRepository(db, cfg),
 		Subscription:        NewSubscriptionRepository(db, cfg),
 		SystemSetting:       NewSystemSettingRepository(db, nil, cfg),
 		TenantInvitation:    NewTenantInvitationRepository(db, cfg),
 		TenantUsageTracking: NewTenantUsageTrackingRepository(db, cfg),
 		DataExport:          NewDataExportRequestRepository(db, cfg),
 		WebhookEvent:        NewWebhookEventRepository(db, cfg),
+		WebhookSubscription: NewWebhookSubscriptionRepository(db, cfg),
+		TenantRole:          NewTenantRoleRepository(db, cfg),
+		TenantOnboarding:    NewTenantOnboardingRepository(db, cfg),
+		AuditLog:            NewAuditLogRepository(db, cfg),
+		IdempotencyKey:      NewIdempotencyKeyRepository(db, cfg),
+		AnalyticsRollup:     NewAnalyticsRollupRepository(db, cfg),
+		Search:              NewSearchRepository(db, cfg),
+		Archive:             NewArchiveRepository(db, cfg),
+		Outbox:              NewOutboxRepository(db, cfg),
 
 		// Branding & Customization
 		WhiteLabel: NewWhiteLabelRepository(db),
@@ -117,9 +199,24 @@ func NewRepositories(db *gorm.DB, config ...RepositoryConfig) *Repositories {
 		SDKClient: NewSDKClientRepository(db),
 		SDKKey:    NewSDKKeyRepository(db),
 		SDKUsage:  NewSDKUsageRepository(db),
+
+		// Integrations
+		ServiceAccount: NewServiceAccountRepository(db, cfg),
+
+		db:     db,
+		config: cfg,
 	}
 }
 
+// Transaction runs fn with repositories bound to one database transaction, committed
+// when fn returns nil and rolled back otherwise. Writes that must not happen without
+// each other, such as a change and the outbox events reporting it, go through it.
+func (r *Repositories) Transaction(ctx context.Context, fn func(tx *Repositories) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx, r.config))
+	})
+}
+
 // UnitOfWork provides transactional operations across multiple repositories.
 // It ensures that a series of repository operations either all succeed or all fail together.
 type UnitOfWork struct {
@@ -13,6 +13,7 @@ import (
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // InvoiceRepository defines the interface for invoice repository operations
@@ -20,11 +21,12 @@ type InvoiceRepository interface {
 	BaseRepository[models.Invoice]
 
 	// Core Operations
-	GetByInvoiceNumber(ctx context.Context, invoiceNumber string) (*models.Invoice, error)
+	GetByInvoiceNumber(ctx context.Context, tenantID uuid.UUID, invoiceNumber string) (*models.Invoice, error)
 	GetByCustomerID(ctx context.Context, customerID uuid.UUID, pagination PaginationParams) ([]*models.Invoice, PaginationResult, error)
 	GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*models.Invoice, error)
 	GetByTenantID(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.Invoice, PaginationResult, error)
 	GenerateInvoiceNumber(ctx context.Context, tenantID uuid.UUID) (string, error)
+	CreateNumbered(ctx context.Context, invoice *models.Invoice) error
 
 	// Status Operations
 	MarkAsPaid(ctx context.Context, invoiceID uuid.UUID, paidAt time.Time) error
@@ -47,6 +49,7 @@ type InvoiceRepository interface {
 
 	// Payment Operations
 	RecordPayment(ctx context.Context, invoiceID uuid.UUID, amount float64, paidAt time.Time) error
+	ApplyPayment(ctx context.Context, invoiceID, paymentID uuid.UUID, amount float64, paidAt time.Time) (bool, error)
 	GetTotalReceivables(ctx context.Context, tenantID uuid.UUID) (float64, error)
 	GetTotalOverdue(ctx context.Context, tenantID uuid.UUID) (float64, error)
 	GetCustomerBalance(ctx context.Context, customerID uuid.UUID) (float64, error)
@@ -194,8 +197,8 @@ func NewInvoiceRepository(db *gorm.DB, config ...RepositoryConfig) InvoiceReposi
 // CORE & STATUS IMPLEMENTATIONS (from previous turn, included for completeness)
 // ------------------------------------------------------------------------------------------------
 
-// GetByInvoiceNumber retrieves an invoice by invoice number
-func (r *invoiceRepository) GetByInvoiceNumber(ctx context.Context, invoiceNumber string) (*models.Invoice, error) {
+// GetByInvoiceNumber retrieves a tenant's invoice by invoice number
+func (r *invoiceRepository) GetByInvoiceNumber(ctx context.Context, tenantID uuid.UUID, invoiceNumber string) (*models.Invoice, error) {
 	start := time.Now()
 	defer func() {
 		if r.metrics != nil {
@@ -207,27 +210,15 @@ func (r *invoiceRepository) GetByInvoiceNumber(ctx context.Context, invoiceNumbe
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "invoice number cannot be empty", errors.ErrInvalidInput)
 	}
 
-	// Try cache first
-	cacheKey := r.getCacheKey("number", invoiceNumber)
-	if r.cache != nil {
-		var invoice models.Invoice
-		if err := r.cache.GetJSON(ctx, cacheKey, &invoice); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit("invoices")
-			}
-			return &invoice, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss("invoices")
-		}
-	}
-
 	var invoice models.Invoice
-	if err := r.db.WithContext(ctx).
-		Preload("Customer").
-		Preload("Booking").
-		Where("invoice_number = ?", invoiceNumber).
-		First(&invoice).Error; err != nil {
+	err := r.RepositoryCache().Query(ctx, tenantID, "by_number", &invoice, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Customer").
+			Preload("Booking").
+			Where("tenant_id = ? AND invoice_number = ?", tenantID, invoiceNumber).
+			First(&invoice).Error
+	}, invoiceNumber)
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewRepositoryError("NOT_FOUND", "invoice not found", errors.ErrNotFound)
 		}
@@ -235,13 +226,6 @@ func (r *invoiceRepository) GetByInvoiceNumber(ctx context.Context, invoiceNumbe
 		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get invoice", err)
 	}
 
-	// Cache the result
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, invoice, 5*time.Minute); err != nil {
-			r.logger.Warn("failed to cache invoice", "invoice_number", invoiceNumber, "error", err)
-		}
-	}
-
 	return &invoice, nil
 }
 
@@ -312,22 +296,50 @@ func (r *invoiceRepository) GetByTenantID(ctx context.Context, tenantID uuid.UUI
 	return invoices, paginationResult, nil
 }
 
-// GenerateInvoiceNumber generates a unique invoice number
+// GenerateInvoiceNumber reserves the tenant's next invoice number. A reserved number
+// that is never used leaves a gap, so prefer CreateNumbered.
 func (r *invoiceRepository) GenerateInvoiceNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
-	now := time.Now()
-	prefix := fmt.Sprintf("INV-%d%02d-", now.Year(), now.Month())
-
-	// Get the count of invoices for this month
-	var count int64
-	if err := r.db.WithContext(ctx).
-		Model(&models.Invoice{}).
-		Where("tenant_id = ? AND invoice_number LIKE ?", tenantID, prefix+"%").
-		Count(&count).Error; err != nil {
+	n, err := nextInvoiceNumber(r.db.WithContext(ctx), tenantID)
+	if err != nil {
 		return "", errors.NewRepositoryError("GENERATION_FAILED", "failed to generate invoice number", err)
 	}
+	return models.FormatInvoiceNumber(n), nil
+}
+
+// CreateNumbered assigns the tenant's next invoice number and creates the invoice in
+// one transaction, so a failed insert does not use up a number
+func (r *invoiceRepository) CreateNumbered(ctx context.Context, invoice *models.Invoice) error {
+	if invoice == nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "invoice cannot be nil", errors.ErrInvalidInput)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		n, err := nextInvoiceNumber(tx, invoice.TenantID)
+		if err != nil {
+			return errors.NewRepositoryError("GENERATION_FAILED", "failed to generate invoice number", err)
+		}
+		invoice.InvoiceNumber = models.FormatInvoiceNumber(n)
+
+		if err := tx.Create(invoice).Error; err != nil {
+			r.logger.Error("failed to create invoice", "tenant_id", invoice.TenantID, "error", err)
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to create invoice", err)
+		}
+		return nil
+	})
+}
 
-	invoiceNumber := fmt.Sprintf("%s%04d", prefix, count+1)
-	return invoiceNumber, nil
+// nextInvoiceNumber increments the tenant's invoice sequence. The row stays locked
+// until tx ends, which serializes concurrent invoice creation per tenant.
+func nextInvoiceNumber(tx *gorm.DB, tenantID uuid.UUID) (int64, error) {
+	var n int64
+	err := tx.Raw(`
+		INSERT INTO invoice_sequences (tenant_id, last_number, updated_at)
+		VALUES (?, 1, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET last_number = invoice_sequences.last_number + 1, updated_at = NOW()
+		RETURNING last_number`, tenantID).
+		Scan(&n).Error
+	return n, err
 }
 
 // MarkAsPaid marks an invoice as paid
@@ -346,7 +358,7 @@ func (r *invoiceRepository) MarkAsPaid(ctx context.Context, invoiceID uuid.UUID,
 	}
 
 	// Invalidate cache
-	r.invalidateInvoiceCache(ctx, invoiceID, invoice.InvoiceNumber)
+	r.invalidateInvoiceCache(ctx, invoice)
 
 	r.logger.Info("invoice marked as paid", "invoice_id", invoiceID, "invoice_number", invoice.InvoiceNumber)
 	return nil
@@ -382,7 +394,7 @@ func (r *invoiceRepository) MarkAsPartiallyPaid(ctx context.Context, invoiceID u
 	}
 
 	// Invalidate cache
-	r.invalidateInvoiceCache(ctx, invoiceID, invoice.InvoiceNumber)
+	r.invalidateInvoiceCache(ctx, invoice)
 
 	r.logger.Info("invoice marked as partially paid", "invoice_id", invoiceID, "amount", paidAmount)
 	return nil
@@ -651,10 +663,84 @@ func (r *invoiceRepository) RecordPayment(ctx context.Context, invoiceID uuid.UU
 		return errors.NewRepositoryError("UPDATE_FAILED", "failed to record payment", err)
 	}
 
-	r.invalidateInvoiceCache(ctx, invoice.ID, invoice.InvoiceNumber)
+	r.invalidateInvoiceCache(ctx, &invoice)
 	return nil
 }
 
+// ApplyPayment records a provider payment against an invoice once. It returns false
+// when the payment was already applied, so redelivered confirmations are harmless.
+func (r *invoiceRepository) ApplyPayment(ctx context.Context, invoiceID, paymentID uuid.UUID, amount float64, paidAt time.Time) (bool, error) {
+	if amount <= 0 {
+		return false, errors.NewRepositoryError("INVALID_INPUT", "amount must be greater than zero", errors.ErrInvalidInput)
+	}
+
+	var invoice models.Invoice
+	applied := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&invoice, "id = ?", invoiceID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewRepositoryError("NOT_FOUND", "invoice not found", errors.ErrNotFound)
+			}
+			return errors.NewRepositoryError("GET_FAILED", "failed to load invoice", err)
+		}
+
+		paymentIDs := appliedPaymentIDs(invoice.Metadata)
+		for _, id := range paymentIDs {
+			if id == paymentID.String() {
+				return nil
+			}
+		}
+
+		if invoice.Metadata == nil {
+			invoice.Metadata = models.JSONB{}
+		}
+		invoice.Metadata["payment_ids"] = append(paymentIDs, paymentID.String())
+
+		invoice.PaidAmount += amount
+		if invoice.PaidAmount >= invoice.TotalAmount {
+			invoice.PaidAmount = invoice.TotalAmount
+			invoice.Status = models.InvoiceStatusPaid
+			if paidAt.IsZero() {
+				paidAt = time.Now()
+			}
+			invoice.PaidAt = &paidAt
+		} else {
+			invoice.Status = models.InvoiceStatusPartial
+		}
+
+		if err := tx.Save(&invoice).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to record payment", err)
+		}
+		applied = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if applied {
+		r.invalidateInvoiceCache(ctx, &invoice)
+	}
+	return applied, nil
+}
+
+// appliedPaymentIDs reads the payment IDs ApplyPayment keeps in invoice metadata
+func appliedPaymentIDs(metadata models.JSONB) []string {
+	var ids []string
+	switch v := metadata["payment_ids"].(type) {
+	case []string:
+		ids = append(ids, v...)
+	case []any:
+		for _, id := range v {
+			if s, ok := id.(string); ok {
+				ids = append(ids, s)
+			}
+		}
+	}
+	return ids
+}
+
 // GetTotalReceivables calculates outstanding receivables
 func (r *invoiceRepository) GetTotalReceivables(ctx context.Context, tenantID uuid.UUID) (float64, error) {
 	var total float64
@@ -740,7 +826,7 @@ func (r *invoiceRepository) UpdateLineItems(ctx context.Context, invoiceID uuid.
 		return errors.NewRepositoryError("NOT_FOUND", "invoice not found", errors.ErrNotFound)
 	}
 
-	r.invalidateInvoiceCache(ctx, invoice.ID, invoice.InvoiceNumber)
+	r.invalidateInvoiceCache(ctx, &invoice)
 	return nil
 }
 
@@ -771,7 +857,7 @@ func (r *invoiceRepository) RecalculateAmounts(ctx context.Context, invoiceID uu
 		return errors.NewRepositoryError("UPDATE_FAILED", "failed to recalculate invoice", result.Error)
 	}
 
-	r.invalidateInvoiceCache(ctx, invoice.ID, invoice.InvoiceNumber)
+	r.invalidateInvoiceCache(ctx, &invoice)
 	return nil
 }
 
@@ -808,6 +894,9 @@ func (r *invoiceRepository) GetInvoicesWithoutPDF(ctx context.Context, tenantID
 
 // GetInvoiceStats aggregates stats for a tenant
 func (r *invoiceRepository) GetInvoiceStats(ctx context.Context, tenantID uuid.UUID) (InvoiceStats, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	stats := InvoiceStats{
 		ByStatus: make(map[models.InvoiceStatus]int64),
 	}
@@ -887,6 +976,9 @@ func (r *invoiceRepository) GetInvoiceStats(ctx context.Context, tenantID uuid.U
 
 // GetRevenueByPeriod groups revenue by a period granularity
 func (r *invoiceRepository) GetRevenueByPeriod(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time, groupBy string) ([]InvoiceRevenueData, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	if endDate.IsZero() {
 		endDate = time.Now()
 	}
@@ -920,6 +1012,9 @@ func (r *invoiceRepository) GetRevenueByPeriod(ctx context.Context, tenantID uui
 
 // GetCustomerInvoiceSummary returns aggregates for a customer
 func (r *invoiceRepository) GetCustomerInvoiceSummary(ctx context.Context, customerID uuid.UUID) (CustomerInvoiceSummary, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	summary := CustomerInvoiceSummary{
 		CustomerID: customerID,
 	}
@@ -975,6 +1070,9 @@ func (r *invoiceRepository) GetAverageDaysToPay(ctx context.Context, tenantID uu
 
 // GetTopCustomersByRevenue returns top revenue customers
 func (r *invoiceRepository) GetTopCustomersByRevenue(ctx context.Context, tenantID uuid.UUID, limit int, startDate, endDate time.Time) ([]CustomerRevenueData, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	if limit <= 0 {
 		limit = 5
 	}
@@ -1007,6 +1105,9 @@ func (r *invoiceRepository) GetTopCustomersByRevenue(ctx context.Context, tenant
 
 // GetInvoiceAgingReport builds an aging summary
 func (r *invoiceRepository) GetInvoiceAgingReport(ctx context.Context, tenantID uuid.UUID) (InvoiceAgingReport, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	report := InvoiceAgingReport{}
 
 	type agingRow struct {
@@ -1191,31 +1292,8 @@ func (r *invoiceRepository) BulkUpdateDueDate(ctx context.Context, invoiceIDs []
 }
 
 // Helper Methods
-func (r *invoiceRepository) getCacheKey(prefix string, parts ...string) string {
-	allParts := append([]string{"repo", "invoices", prefix}, parts...)
-	return strings.Join(allParts, ":")
-}
-
-func (r *invoiceRepository) getCacheKeyPattern(pattern string) string {
-	return fmt.Sprintf("repo:invoices:%s", pattern)
-}
-
-func (r *invoiceRepository) invalidateInvoiceCache(ctx context.Context, invoiceID uuid.UUID, invoiceNumber string) {
-	if r.cache == nil {
-		return
-	}
-
-	keys := []string{r.getCacheKey("id", invoiceID.String())}
-	if invoiceNumber != "" {
-		keys = append(keys, r.getCacheKey("number", invoiceNumber))
-	}
-
-	if err := r.cache.Delete(ctx, keys...); err != nil && r.logger != nil {
-		r.logger.Warn("failed to delete invoice cache keys", "error", err)
-	}
-
-	_ = r.cache.DeletePattern(ctx, r.getCacheKeyPattern("list:*"))
-	_ = r.cache.DeletePattern(ctx, r.getCacheKeyPattern("count:*"))
+func (r *invoiceRepository) invalidateInvoiceCache(ctx context.Context, invoice *models.Invoice) {
+	r.RepositoryCache().Invalidate(ctx, invoice.TenantID, invoice.ID)
 }
 
 func (r *invoiceRepository) applyDateRange(query *gorm.DB, column string, startDate, endDate time.Time) *gorm.DB {
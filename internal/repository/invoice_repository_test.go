@@ -0,0 +1,109 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceRepository_CreateNumbered(t *testing.T) {
+	tdb, _, booking := setupPaymentTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	repo := repository.NewInvoiceRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+
+	t.Run("numbers are sequential per tenant", func(t *testing.T) {
+		const count = 5
+
+		var wg sync.WaitGroup
+		invoices := make([]*models.Invoice, count)
+		errs := make([]error, count)
+		for i := range invoices {
+			invoices[i] = testutil.CreateTestInvoice(booking.TenantID, booking.CustomerID, booking.ID)
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = repo.CreateNumbered(ctx, invoices[i])
+			}(i)
+		}
+		wg.Wait()
+
+		numbers := make(map[string]bool)
+		for i, invoice := range invoices {
+			require.NoError(t, errs[i])
+			numbers[invoice.InvoiceNumber] = true
+		}
+		for n := int64(1); n <= count; n++ {
+			assert.True(t, numbers[models.FormatInvoiceNumber(n)], "missing %s", models.FormatInvoiceNumber(n))
+		}
+	})
+
+	t.Run("each tenant starts at one", func(t *testing.T) {
+		tenant := testutil.CreateTestTenant()
+		require.NoError(t, tdb.DB.Create(tenant).Error)
+
+		invoice := testutil.CreateTestInvoice(tenant.ID, booking.CustomerID, booking.ID)
+		require.NoError(t, repo.CreateNumbered(ctx, invoice))
+		assert.Equal(t, models.FormatInvoiceNumber(1), invoice.InvoiceNumber)
+
+		found, err := repo.GetByInvoiceNumber(ctx, tenant.ID, invoice.InvoiceNumber)
+		require.NoError(t, err)
+		assert.Equal(t, invoice.ID, found.ID)
+	})
+}
+
+func TestInvoiceRepository_ApplyPayment(t *testing.T) {
+	tdb, _, booking := setupPaymentTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	repo := repository.NewInvoiceRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+
+	invoice := testutil.CreateTestInvoice(booking.TenantID, booking.CustomerID, booking.ID)
+	require.NoError(t, repo.CreateNumbered(ctx, invoice))
+
+	deposit := uuid.New()
+
+	t.Run("partial payment", func(t *testing.T) {
+		applied, err := repo.ApplyPayment(ctx, invoice.ID, deposit, 140, time.Now())
+		require.NoError(t, err)
+		assert.True(t, applied)
+
+		saved, err := repo.GetByID(ctx, invoice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.InvoiceStatusPartial, saved.Status)
+		assert.InDelta(t, 140, saved.PaidAmount, 0.001)
+	})
+
+	t.Run("redelivered payment is applied once", func(t *testing.T) {
+		applied, err := repo.ApplyPayment(ctx, invoice.ID, deposit, 140, time.Now())
+		require.NoError(t, err)
+		assert.False(t, applied)
+
+		saved, err := repo.GetByID(ctx, invoice.ID)
+		require.NoError(t, err)
+		assert.InDelta(t, 140, saved.PaidAmount, 0.001)
+	})
+
+	t.Run("balance settles the invoice", func(t *testing.T) {
+		applied, err := repo.ApplyPayment(ctx, invoice.ID, uuid.New(), 400, time.Now())
+		require.NoError(t, err)
+		assert.True(t, applied)
+
+		saved, err := repo.GetByID(ctx, invoice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.InvoiceStatusPaid, saved.Status)
+		assert.InDelta(t, 540, saved.PaidAmount, 0.001)
+		assert.NotNil(t, saved.PaidAt)
+	})
+}
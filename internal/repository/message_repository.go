@@ -37,6 +37,7 @@ type MessageRepository interface {
 	MarkAsRead(ctx context.Context, messageID uuid.UUID) error
 	MarkMultipleAsRead(ctx context.Context, messageIDs []uuid.UUID) error
 	MarkConversationAsRead(ctx context.Context, receiverID, senderID uuid.UUID) error
+	MarkBookingMessagesAsRead(ctx context.Context, bookingID, receiverID uuid.UUID) error
 	UpdateMessageStatus(ctx context.Context, messageID uuid.UUID, status models.MessageStatus) error
 
 	// Analytics & Statistics
@@ -628,9 +629,7 @@ func (r *messageRepository) MarkAsRead(ctx context.Context, messageID uuid.UUID)
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:messages:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, messageID)
 
 	return nil
 }
@@ -656,9 +655,7 @@ func (r *messageRepository) MarkMultipleAsRead(ctx context.Context, messageIDs [
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:messages:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, messageIDs...)
 
 	r.logger.Debug("marked messages as read", "count", result.RowsAffected)
 	return nil
@@ -686,14 +683,40 @@ func (r *messageRepository) MarkConversationAsRead(ctx context.Context, receiver
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:messages:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	r.logger.Debug("marked conversation as read", "count", result.RowsAffected)
 	return nil
 }
 
+// MarkBookingMessagesAsRead marks all of a booking's messages to the receiver as read
+func (r *messageRepository) MarkBookingMessagesAsRead(ctx context.Context, bookingID, receiverID uuid.UUID) error {
+	if bookingID == uuid.Nil || receiverID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "booking_id and receiver_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&models.Message{}).
+		Where("booking_id = ? AND receiver_id = ? AND status IN (?, ?)",
+			bookingID, receiverID, models.MessageStatusSent, models.MessageStatusDelivered).
+		Updates(map[string]interface{}{
+			"status":  models.MessageStatusRead,
+			"read_at": now,
+		})
+
+	if result.Error != nil {
+		r.logger.Error("failed to mark booking messages as read", "booking_id", bookingID, "receiver_id", receiverID, "error", result.Error)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to mark booking messages as read", result.Error)
+	}
+
+	// Invalidate cache
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
+
+	r.logger.Debug("marked booking messages as read", "count", result.RowsAffected)
+	return nil
+}
+
 // UpdateMessageStatus updates the status of a message
 func (r *messageRepository) UpdateMessageStatus(ctx context.Context, messageID uuid.UUID, status models.MessageStatus) error {
 	if messageID == uuid.Nil {
@@ -723,9 +746,7 @@ func (r *messageRepository) UpdateMessageStatus(ctx context.Context, messageID u
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:messages:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, messageID)
 
 	return nil
 }
@@ -1094,9 +1115,7 @@ func (r *messageRepository) BulkMarkAsDelivered(ctx context.Context, receiverID
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:messages:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	r.logger.Debug("marked messages as delivered", "count", result.RowsAffected)
 	return nil
@@ -1108,7 +1127,7 @@ func (r *messageRepository) DeleteConversation(ctx context.Context, userID1, use
 		return errors.NewRepositoryError("INVALID_INPUT", "user IDs cannot be nil", errors.ErrInvalidInput)
 	}
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("(sender_id = ? AND receiver_id = ?) OR (sender_id = ? AND receiver_id = ?)",
 			userID1, userID2, userID2, userID1).
 		Delete(&models.Message{})
@@ -1119,9 +1138,7 @@ func (r *messageRepository) DeleteConversation(ctx context.Context, userID1, use
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:messages:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	r.logger.Info("deleted conversation", "count", result.RowsAffected)
 	return nil
@@ -1135,7 +1152,7 @@ func (r *messageRepository) DeleteMessagesOlderThan(ctx context.Context, tenantI
 
 	cutoffDate := time.Now().Add(-duration)
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("tenant_id = ? AND created_at < ?", tenantID, cutoffDate).
 		Delete(&models.Message{})
 
@@ -1145,9 +1162,7 @@ func (r *messageRepository) DeleteMessagesOlderThan(ctx context.Context, tenantI
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:messages:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, tenantID)
 
 	r.logger.Info("deleted old messages", "tenant_id", tenantID, "count", result.RowsAffected, "older_than", duration)
 	return nil
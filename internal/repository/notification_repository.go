@@ -309,7 +309,7 @@ func (r *notificationRepository) DeleteRead(ctx context.Context, userID uuid.UUI
 
 	cutoffDate := time.Now().AddDate(0, 0, -olderThanDays)
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("user_id = ? AND is_read = ? AND created_at < ?", userID, true, cutoffDate).
 		Delete(&models.Notification{})
 
@@ -330,7 +330,7 @@ func (r *notificationRepository) DeleteOld(ctx context.Context, olderThanDays in
 
 	cutoffDate := time.Now().AddDate(0, 0, -olderThanDays)
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("created_at < ?", cutoffDate).
 		Delete(&models.Notification{})
 
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxRepository stores the domain events awaiting delivery to their consumers (see
+// package events). Events are appended in the transaction of the change they report,
+// through a repository bound to it with Repositories.Transaction.
+type OutboxRepository interface {
+	// Append adds events to the outbox
+	Append(ctx context.Context, events ...*models.OutboxEvent) error
+
+	// FindDue returns pending events whose next attempt is due, in the order they occurred
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*models.OutboxEvent, error)
+
+	// MarkDispatched records an event as handled by every consumer
+	MarkDispatched(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// RecordFailure saves the delivery state of an event some consumer failed: its
+	// status, attempts, next attempt, failed consumers and last error
+	RecordFailure(ctx context.Context, event *models.OutboxEvent) error
+
+	// PurgeDispatched deletes events dispatched before the given time, returning how
+	// many it deleted
+	PurgeDispatched(ctx context.Context, before time.Time) (int64, error)
+
+	// CountPending returns how many events await delivery, due or not
+	CountPending(ctx context.Context) (int64, error)
+}
+
+// outboxRepository implements OutboxRepository
+type outboxRepository struct {
+	db       *gorm.DB
+	logger   log.AllLogger
+	timeouts QueryTimeouts
+}
+
+// NewOutboxRepository creates a new OutboxRepository instance
+func NewOutboxRepository(db *gorm.DB, config ...RepositoryConfig) OutboxRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return &outboxRepository{
+		db:       db,
+		logger:   cfg.Logger,
+		timeouts: cfg.QueryTimeouts,
+	}
+}
+
+// Append inserts the events in one statement
+func (r *outboxRepository) Append(ctx context.Context, events ...*models.OutboxEvent) error {
+	ctx, cancel := r.timeouts.WithTimeout(ctx, "create")
+	defer cancel()
+
+	if err := appendOutbox(r.db.WithContext(ctx), events); err != nil {
+		return errors.NewRepositoryError("CREATE_FAILED", "failed to append outbox events", err)
+	}
+	return nil
+}
+
+// FindDue retrieves due events through the status and next attempt index
+func (r *outboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*models.OutboxEvent, error) {
+	ctx, cancel := r.timeouts.WithTimeout(ctx, "find")
+	defer cancel()
+
+	var events []*models.OutboxEvent
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", models.OutboxStatusPending, now).
+		Order("occurred_at ASC, id ASC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find due outbox events", err)
+	}
+	return events, nil
+}
+
+// MarkDispatched sets the event dispatched and clears what its failures left behind
+func (r *outboxRepository) MarkDispatched(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, cancel := r.timeouts.WithTimeout(ctx, "update")
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":           models.OutboxStatusDispatched,
+			"attempts":         gorm.Expr("attempts + 1"),
+			"failed_consumers": models.StringArray{},
+			"last_error":       "",
+			"dispatched_at":    at,
+			"updated_at":       time.Now(),
+		}).Error; err != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to mark outbox event dispatched", err)
+	}
+	return nil
+}
+
+// RecordFailure updates the delivery columns of the event
+func (r *outboxRepository) RecordFailure(ctx context.Context, event *models.OutboxEvent) error {
+	ctx, cancel := r.timeouts.WithTimeout(ctx, "update")
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Where("id = ?", event.ID).
+		Updates(map[string]any{
+			"status":           event.Status,
+			"attempts":         event.Attempts,
+			"next_attempt_at":  event.NextAttemptAt,
+			"failed_consumers": event.FailedConsumers,
+			"last_error":       event.LastError,
+			"updated_at":       time.Now(),
+		}).Error; err != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to record outbox event failure", err)
+	}
+	return nil
+}
+
+// PurgeDispatched hard deletes the events, which are of no use once delivered
+func (r *outboxRepository) PurgeDispatched(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := r.timeouts.WithTimeout(ctx, "delete")
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("status = ? AND dispatched_at < ?", models.OutboxStatusDispatched, before).
+		Delete(&models.OutboxEvent{})
+	if result.Error != nil {
+		return 0, errors.NewRepositoryError("DELETE_FAILED", "failed to purge dispatched outbox events", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// CountPending counts pending events through the status and next attempt index
+func (r *outboxRepository) CountPending(ctx context.Context) (int64, error) {
+	ctx, cancel := r.timeouts.WithTimeout(ctx, "count")
+	defer cancel()
+
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Where("status = ?", models.OutboxStatusPending).
+		Count(&count).Error; err != nil {
+		return 0, errors.NewRepositoryError("COUNT_FAILED", "failed to count pending outbox events", err)
+	}
+	return count, nil
+}
+
+// appendOutbox inserts outbox events with the given handle, letting repositories
+// publish events in the transaction of their own writes
+func appendOutbox(tx *gorm.DB, events []*models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Create(events).Error
+}
@@ -0,0 +1,111 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/events"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	repo := repository.NewOutboxRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	tenantID := uuid.New()
+
+	newEvent := func(t *testing.T, occurredAt time.Time) *models.OutboxEvent {
+		event, err := events.NewOutboxEvent(tenantID, events.PaymentSucceeded{PaymentID: uuid.New(), Amount: 10})
+		require.NoError(t, err)
+		event.OccurredAt = occurredAt
+		event.NextAttemptAt = occurredAt
+		return event
+	}
+
+	now := time.Now()
+	first := newEvent(t, now.Add(-2*time.Minute))
+	second := newEvent(t, now.Add(-time.Minute))
+	later := newEvent(t, now.Add(time.Hour))
+	require.NoError(t, repo.Append(ctx, second, first, later))
+
+	t.Run("due events are found in the order they occurred", func(t *testing.T) {
+		due, err := repo.FindDue(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+		assert.Equal(t, first.ID, due[0].ID)
+		assert.Equal(t, second.ID, due[1].ID)
+		assert.JSONEq(t, string(first.Payload), string(due[0].Payload))
+	})
+
+	t.Run("pending events are counted whether due or not", func(t *testing.T) {
+		pending, err := repo.CountPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), pending)
+	})
+
+	t.Run("a failed event waits for its next attempt", func(t *testing.T) {
+		first.Attempts = 1
+		first.NextAttemptAt = now.Add(time.Minute)
+		first.FailedConsumers = models.StringArray{"webhooks"}
+		first.LastError = "webhooks: unreachable"
+		require.NoError(t, repo.RecordFailure(ctx, first))
+
+		due, err := repo.FindDue(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, second.ID, due[0].ID)
+
+		due, err = repo.FindDue(ctx, now.Add(2*time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+		assert.Equal(t, models.StringArray{"webhooks"}, due[0].FailedConsumers)
+		assert.Equal(t, 1, due[0].Attempts)
+	})
+
+	t.Run("dispatched events are purged after the retention", func(t *testing.T) {
+		require.NoError(t, repo.MarkDispatched(ctx, first.ID, now.Add(-time.Hour)))
+		require.NoError(t, repo.MarkDispatched(ctx, second.ID, now))
+
+		due, err := repo.FindDue(ctx, now.Add(2*time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+
+		var dispatched models.OutboxEvent
+		require.NoError(t, tdb.DB.First(&dispatched, "id = ?", first.ID).Error)
+		assert.Equal(t, models.OutboxStatusDispatched, dispatched.Status)
+		assert.Equal(t, 2, dispatched.Attempts)
+		assert.Empty(t, dispatched.FailedConsumers)
+
+		purged, err := repo.PurgeDispatched(ctx, now.Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+	})
+}
+
+func TestOutboxRepository_PaymentEvents(t *testing.T) {
+	tdb, payments, booking := setupPaymentTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	payment := testutil.CreateTestPayment(booking.TenantID, booking.ID, booking.CustomerID)
+	require.NoError(t, payments.Create(ctx, payment))
+	require.NoError(t, payments.MarkAsPaid(ctx, payment.ID, ""))
+	require.NoError(t, payments.MarkAsPaid(ctx, payment.ID, ""))
+	require.NoError(t, payments.CreateRefund(ctx, payment.ID, 1, "goodwill"))
+
+	var published []*models.OutboxEvent
+	require.NoError(t, tdb.DB.Where("aggregate_id = ?", payment.ID).Order("occurred_at").Find(&published).Error)
+	require.Len(t, published, 2, "a payment marked paid twice succeeds once")
+	assert.Equal(t, string(events.TypePaymentSucceeded), published[0].EventType)
+	assert.Equal(t, string(events.TypePaymentRefunded), published[1].EventType)
+	assert.Equal(t, &booking.TenantID, published[1].TenantID)
+}
@@ -1,23 +1,72 @@
 package repository
 
 import (
+	"encoding/base64"
+	"fmt"
 	"math"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // PaginationParams represents pagination parameters
 type PaginationParams struct {
-	Page     int `json:"page"`      // 1-indexed page number
-	PageSize int `json:"page_size"` // Number of items per page
+	Page     int    `json:"page"`             // 1-indexed page number
+	PageSize int    `json:"page_size"`        // Number of items per page
+	Cursor   string `json:"cursor,omitempty"` // Opaque keyset cursor; when set, Page is ignored
 }
 
 // PaginationResult represents paginated results
 type PaginationResult struct {
-	Page       int   `json:"page"`
-	PageSize   int   `json:"page_size"`
-	TotalPages int   `json:"total_pages"`
-	TotalItems int64 `json:"total_items"`
-	HasNext    bool  `json:"has_next"`
-	HasPrev    bool  `json:"has_prev"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	TotalPages int    `json:"total_pages"`
+	TotalItems int64  `json:"total_items"`
+	HasNext    bool   `json:"has_next"`
+	HasPrev    bool   `json:"has_prev"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Cursor is a keyset position: the sort key and ID of the last row returned
+type Cursor struct {
+	SortKey time.Time
+	ID      uuid.UUID
+}
+
+// EncodeCursor encodes a keyset position into an opaque URL-safe string
+func EncodeCursor(sortKey time.Time, id uuid.UUID) string {
+	raw := sortKey.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor decodes a cursor produced by EncodeCursor
+func DecodeCursor(cursor string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("malformed cursor: %w", err)
+	}
+
+	sortKey, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return Cursor{}, fmt.Errorf("malformed cursor")
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, sortKey)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("malformed cursor time: %w", err)
+	}
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("malformed cursor id: %w", err)
+	}
+
+	return Cursor{SortKey: t, ID: uid}, nil
 }
 
 // DefaultPaginationParams returns default pagination parameters
@@ -35,6 +84,11 @@ func (p *PaginationParams) Validate() {
 	p.PageSize = min(p.PageSize, 100)
 }
 
+// IsCursor reports whether keyset pagination was requested
+func (p *PaginationParams) IsCursor() bool {
+	return p.Cursor != ""
+}
+
 // Offset calculates the database offset
 func (p *PaginationParams) Offset() int {
 	return (p.Page - 1) * p.PageSize
@@ -61,5 +115,75 @@ func CalculatePagination(params PaginationParams, totalItems int64) PaginationRe
 		TotalItems: totalItems,
 		HasNext:    params.Page < totalPages,
 		HasPrev:    params.Page > 1,
+		HasMore:    params.Page < totalPages,
+	}
+}
+
+// paginateByKey runs query ordered by sortColumn DESC, id DESC. When params carries a
+// cursor it seeks past that position (keyset pagination, no COUNT); otherwise it falls
+// back to offset pagination. Either way a NextCursor is returned while more rows remain,
+// so clients can switch to cursors after the first page. preload is applied to the
+// row query only, never to the count.
+func paginateByKey[T any](
+	query *gorm.DB,
+	params PaginationParams,
+	sortColumn string,
+	key func(*T) (time.Time, uuid.UUID),
+	preload func(*gorm.DB) *gorm.DB,
+) ([]*T, PaginationResult, error) {
+	params.Validate()
+
+	order := fmt.Sprintf("%s DESC, id DESC", sortColumn)
+	if preload == nil {
+		preload = func(db *gorm.DB) *gorm.DB { return db }
+	}
+
+	if params.IsCursor() {
+		cursor, err := DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_CURSOR", "invalid pagination cursor", errors.ErrInvalidInput)
+		}
+
+		var items []*T
+		if err := preload(query.Session(&gorm.Session{})).
+			Where(fmt.Sprintf("(%s, id) < (?, ?)", sortColumn), cursor.SortKey, cursor.ID).
+			Order(order).
+			Limit(params.PageSize + 1).
+			Find(&items).Error; err != nil {
+			return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find records", err)
+		}
+
+		result := PaginationResult{
+			PageSize: params.PageSize,
+			HasPrev:  true,
+		}
+		if len(items) > params.PageSize {
+			items = items[:params.PageSize]
+			result.HasMore = true
+			result.HasNext = true
+			result.NextCursor = EncodeCursor(key(items[len(items)-1]))
+		}
+		return items, result, nil
+	}
+
+	var totalItems int64
+	if err := query.Session(&gorm.Session{}).Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count records", err)
+	}
+
+	var items []*T
+	if err := preload(query.Session(&gorm.Session{})).
+		Offset(params.Offset()).
+		Limit(params.Limit()).
+		Order(order).
+		Find(&items).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find records", err)
+	}
+
+	result := CalculatePagination(params, totalItems)
+	if result.HasNext && len(items) > 0 {
+		result.HasMore = true
+		result.NextCursor = EncodeCursor(key(items[len(items)-1]))
 	}
+	return items, result, nil
 }
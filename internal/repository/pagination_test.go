@@ -0,0 +1,29 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 10, 30, 15, 123456789, time.FixedZone("WAT", 3600))
+	id := uuid.New()
+
+	cursor, err := repository.DecodeCursor(repository.EncodeCursor(ts, id))
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(cursor.SortKey))
+	assert.Equal(t, id, cursor.ID)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, c := range []string{"not base64!", "bm8tc2VwYXJhdG9y", "MjAyNXxub3QtYS11dWlk"} {
+		_, err := repository.DecodeCursor(c)
+		assert.Error(t, err, c)
+	}
+}
@@ -6,12 +6,16 @@ import (
 	"strings"
 	"time"
 
+	"Krafti_Vibe/internal/domain/events"
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/fieldcrypt"
+	"Krafti_Vibe/internal/pkg/redact"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PaymentRepository defines the interface for payment repository operations
@@ -44,6 +48,7 @@ type PaymentRepository interface {
 	// Commission & Financial Operations
 	CalculateCommissionSplit(ctx context.Context, paymentID uuid.UUID, commissionRate float64) error
 	GetArtisanEarnings(ctx context.Context, artisanID uuid.UUID, startDate, endDate time.Time) (float64, error)
+	GetArtisanVolume(ctx context.Context, artisanID uuid.UUID, startDate, endDate time.Time) (float64, error)
 	GetPlatformRevenue(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (float64, error)
 	GetUnpaidArtisanEarnings(ctx context.Context, artisanID uuid.UUID) (float64, error)
 	GetArtisanPaymentHistory(ctx context.Context, artisanID uuid.UUID, pagination PaginationParams) ([]*models.Payment, PaginationResult, error)
@@ -74,11 +79,24 @@ type PaymentRepository interface {
 	GetByProvider(ctx context.Context, providerName string, tenantID uuid.UUID, pagination PaginationParams) ([]*models.Payment, PaginationResult, error)
 	GetProviderStats(ctx context.Context, tenantID uuid.UUID) (map[string]ProviderStats, error)
 	GetFailedPaymentsByProvider(ctx context.Context, providerName string, tenantID uuid.UUID) ([]*models.Payment, error)
+	FindAwaitingProvider(ctx context.Context, createdAfter, createdBefore time.Time, limit int) ([]*models.Payment, error)
+	ApplyProviderResult(ctx context.Context, payment *models.Payment, event *models.PaymentWebhookEvent) (bool, error)
 
 	// Reconciliation
 	GetUnreconciledPayments(ctx context.Context, tenantID uuid.UUID) ([]*models.Payment, error)
 	MarkAsReconciled(ctx context.Context, paymentIDs []uuid.UUID) error
 	GetPaymentsForReconciliation(ctx context.Context, tenantID uuid.UUID, date time.Time) ([]*models.Payment, error)
+	FindByProviderPaymentIDs(ctx context.Context, providerName string, providerPaymentIDs []string) ([]*models.Payment, error)
+	FindSettledByProvider(ctx context.Context, providerName string, from, to time.Time) ([]*models.Payment, error)
+
+	// Statements
+	FindSettledByTenant(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]*models.Payment, error)
+	FindTenantsWithSettledPayments(ctx context.Context, from, to time.Time) ([]uuid.UUID, error)
+
+	// Escrow
+	UpdateEscrow(ctx context.Context, paymentID uuid.UUID, mutate func(*models.Payment) error) (*models.Payment, error)
+	ReleaseDueEscrow(ctx context.Context, now time.Time) (int64, error)
+	ReleaseBookingEscrow(ctx context.Context, bookingID uuid.UUID, note string) (int64, error)
 
 	// Bulk Operations
 	BulkMarkAsPaid(ctx context.Context, paymentIDs []uuid.UUID) error
@@ -118,9 +136,10 @@ type PaymentStats struct {
 
 // RevenueData represents revenue data for a specific period
 type RevenueData struct {
-	Period           time.Time `json:"period"`
-	Revenue          float64   `json:"revenue"`
-	TransactionCount int64     `json:"transaction_count"`
+	Period           time.Time  `json:"period"`
+	Revenue          float64    `json:"revenue"`
+	TransactionCount int64      `json:"transaction_count"`
+	AsOf             *time.Time `json:"as_of,omitempty"` // When the rollups it was summed from were refreshed; nil when aggregated live
 }
 
 // CustomerPaymentSummary represents payment summary for a customer
@@ -238,6 +257,11 @@ func NewPaymentRepository(db *gorm.DB, config ...RepositoryConfig) PaymentReposi
 	}
 }
 
+// paymentCursorKey returns the keyset position of a payment for cursor pagination
+func paymentCursorKey(p *models.Payment) (time.Time, uuid.UUID) {
+	return p.CreatedAt, p.ID
+}
+
 // GetByBookingID retrieves all payments for a booking
 func (r *paymentRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*models.Payment, error) {
 	start := time.Now()
@@ -251,96 +275,44 @@ func (r *paymentRepository) GetByBookingID(ctx context.Context, bookingID uuid.U
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "booking ID cannot be nil", errors.ErrInvalidInput)
 	}
 
-	// try cache
-	cacheKey := r.getCacheKey("booking", bookingID.String())
-	if r.cache != nil {
-		var payments []*models.Payment
-		if err := r.cache.GetJSON(ctx, cacheKey, &payments); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit("payments")
-			}
-			return payments, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss("payments")
-		}
-	}
-
 	var payments []*models.Payment
-	if err := r.db.WithContext(ctx).
-		Preload("Customer").
-		Preload("Artisan").
-		Preload("Booking").
-		Where("booking_id = ?", bookingID).
-		Order("created_at DESC").
-		Find(&payments).Error; err != nil {
+	err := r.RepositoryCache().Query(ctx, uuid.Nil, "by_booking", &payments, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Customer").
+			Preload("Artisan").
+			Preload("Booking").
+			Where("booking_id = ?", bookingID).
+			Order("created_at DESC").
+			Find(&payments).Error
+	}, bookingID)
+	if err != nil {
 		r.logger.Error("failed to get payments by booking ID", "booking_id", bookingID, "error", err)
 		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get payments", err)
 	}
 
-	// Cache the result
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, payments, 3*time.Minute); err != nil {
-			r.logger.Warn("failed to cache payments", "booking_id", bookingID, "error", err)
-		}
-	}
-
 	return payments, nil
-
 }
 
 // GetByCustomerID retrieves all payments for a customer
 func (r *paymentRepository) GetByCustomerID(ctx context.Context, customerID uuid.UUID, pagination PaginationParams) ([]*models.Payment, PaginationResult, error) {
-	pagination.Validate()
-
-	var totalItems int64
 	query := r.db.WithContext(ctx).Model(&models.Payment{}).Where("customer_id = ?", customerID)
 
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count payments", err)
-	}
-
-	var payments []*models.Payment
-	if err := query.
-		Preload("Booking").
-		Preload("Artisan").
-		Offset(pagination.Offset()).
-		Limit(pagination.Limit()).
-		Order("created_at DESC").
-		Find(&payments).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find payments", err)
-	}
-
-	PaginationResult := CalculatePagination(pagination, totalItems)
-	return payments, PaginationResult, nil
+	return paginateByKey(query, pagination, "created_at", paymentCursorKey,
+		func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Booking").
+				Preload("Artisan")
+		})
 }
 
 // GetByArtisanID retrieves all payments for an artisan
 func (r *paymentRepository) GetByArtisanID(ctx context.Context, artisanID uuid.UUID, pagination PaginationParams) ([]*models.Payment, PaginationResult, error) {
-	pagination.Validate()
-
-	var totalItems int64
-	query := r.db.WithContext(ctx).
-		Model(&models.Payment{}).
-		Where("artisan_id = ?", artisanID)
-
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count payments", err)
-	}
-
-	var payments []*models.Payment
-	if err := query.
-		Preload("Customer").
-		Preload("Booking").
-		Offset(pagination.Offset()).
-		Limit(pagination.Limit()).
-		Order("created_at DESC").
-		Find(&payments).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find payments", err)
-	}
+	query := r.db.WithContext(ctx).Model(&models.Payment{}).Where("artisan_id = ?", artisanID)
 
-	PaginationResult := CalculatePagination(pagination, totalItems)
-	return payments, PaginationResult, nil
+	return paginateByKey(query, pagination, "created_at", paymentCursorKey,
+		func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Customer").
+				Preload("Booking")
+		})
 }
 
 // GetByProviderPaymentID retrieves a payment by provider payment ID
@@ -349,131 +321,88 @@ func (r *paymentRepository) GetByProviderPaymentID(ctx context.Context, provider
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "provider payment ID cannot be empty", errors.ErrInvalidInput)
 	}
 
-	// Try cache first
-	cacheKey := r.getCacheKey("provider", providerPaymentID)
-	if r.cache != nil {
-		var payment models.Payment
-		if err := r.cache.GetJSON(ctx, cacheKey, &payment); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit("payments")
-			}
-			return &payment, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss("payments")
-		}
-	}
-
 	var payment models.Payment
-	if err := r.db.WithContext(ctx).
-		Preload("Customer").
-		Preload("Artisan").
-		Preload("Booking").
-		Where("provider_payment_id = ?", providerPaymentID).
-		First(&payment).Error; err != nil {
+	err := r.RepositoryCache().Query(ctx, uuid.Nil, "by_provider_payment_id", &payment, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Customer").
+			Preload("Artisan").
+			Preload("Booking").
+			Where("provider_payment_id IN ?", fieldcrypt.Default().LookupValues(providerPaymentID)).
+			First(&payment).Error
+	}, providerPaymentID)
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewRepositoryError("NOT_FOUND", "payment not found", errors.ErrNotFound)
 		}
 		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get payment", err)
 	}
 
-	// Cache the result
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, payment, 3*time.Minute); err != nil {
-			r.logger.Warn("failed to cache payment", "provider_payment_id", providerPaymentID, "error", err)
-		}
-	}
-
 	return &payment, nil
 }
 
 // GetByTenantID retrieves all payments for a tenant
 func (r *paymentRepository) GetByTenantID(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.Payment, PaginationResult, error) {
-	pagination.Validate()
-
-	var totalItems int64
 	query := r.db.WithContext(ctx).Model(&models.Payment{}).Where("tenant_id = ?", tenantID)
 
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count payments", err)
-	}
-
-	var payments []*models.Payment
-	if err := query.
-		Preload("Customer").
-		Preload("Artisan").
-		Preload("Booking").
-		Offset(pagination.Offset()).
-		Limit(pagination.Limit()).
-		Order("created_at DESC").
-		Find(&payments).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find payments", err)
-	}
-
-	paginationResult := CalculatePagination(pagination, totalItems)
-	return payments, paginationResult, nil
+	return paginateByKey(query, pagination, "created_at", paymentCursorKey,
+		func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Customer").
+				Preload("Artisan").
+				Preload("Booking")
+		})
 }
 
-// MarkAsPaid retrievies marked as paid payments
+// MarkAsPaid marks a payment as paid, recording the provider's payment ID when given
 func (r *paymentRepository) MarkAsPaid(ctx context.Context, paymentID uuid.UUID, providerPaymentID string) error {
-	payment, err := r.GetByID(ctx, paymentID)
+	payment, err := r.updateLocked(ctx, paymentID, func(p *models.Payment) error {
+		p.MarkAsPaid()
+		if providerPaymentID != "" {
+			p.ProviderPaymentID = providerPaymentID
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	var payments models.Payment
-	payments.MarkAsPaid()
-	if providerPaymentID != "" {
-		payments.ProviderPaymentID = providerPaymentID
-	}
 
-	if err := r.Update(ctx, payment); err != nil {
-		return err
-	}
+	r.invalidatePaymentCache(ctx, payment.TenantID, paymentID)
 
-	// Invalidate cache
-	r.invalidatePaymentCache(ctx, paymentID, payments.BookingID)
-
-	r.logger.Info("payment marked as paid", "payment_id", paymentID, "provider_payment_id", providerPaymentID)
+	r.logger.Info("payment marked as paid", "payment_id", paymentID, "provider_payment_id", redact.ID(providerPaymentID))
 	return nil
 }
+
+// MarkAsFailed marks a payment as failed with a reason
 func (r *paymentRepository) MarkAsFailed(ctx context.Context, paymentID uuid.UUID, reason string) error {
-	payment, err := r.GetByID(ctx, paymentID)
+	payment, err := r.updateLocked(ctx, paymentID, func(p *models.Payment) error {
+		p.MarkAsFailed(reason)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	var payments models.Payment
-	payments.MarkAsFailed(reason)
-
-	if err := r.Update(ctx, payment); err != nil {
-		return err
-	}
-
-	// Invalidate cache
-	r.invalidatePaymentCache(ctx, paymentID, payments.BookingID)
+	r.invalidatePaymentCache(ctx, payment.TenantID, paymentID)
 
 	r.logger.Info("payment marked as failed", "payment_id", paymentID, "reason", reason)
 	return nil
 }
+
+// MarkAsCanceled marks a payment as cancelled
 func (r *paymentRepository) MarkAsCanceled(ctx context.Context, paymentID uuid.UUID) error {
-	payment, err := r.GetByID(ctx, paymentID)
+	payment, err := r.updateLocked(ctx, paymentID, func(p *models.Payment) error {
+		p.MarkAsCancelled()
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	var payments models.Payment
-	payments.MarkAsCancelled()
-
-	if err := r.Update(ctx, payment); err != nil {
-		return err
-	}
-
-	// Invalidate cache
-	r.invalidatePaymentCache(ctx, paymentID, payments.BookingID)
+	r.invalidatePaymentCache(ctx, payment.TenantID, paymentID)
 
 	r.logger.Info("payment marked as canceled", "payment_id", paymentID)
 	return nil
 }
+
 func (r *paymentRepository) MarkAsProcessing(ctx context.Context, paymentID uuid.UUID) error {
 	result := r.db.WithContext(ctx).
 		Model(&models.Payment{}).
@@ -570,27 +499,25 @@ func (r *paymentRepository) GetSuccessfulPayments(ctx context.Context, tenantID
 	return payments, paginationResult, nil
 }
 
+// CreateRefund records a full or partial refund against a payment. The refundable
+// amount is checked against the locked row, so concurrent refunds cannot exceed it.
 func (r *paymentRepository) CreateRefund(ctx context.Context, paymentID uuid.UUID, amount float64, reason string) error {
-	payment, err := r.GetByID(ctx, paymentID)
+	payment, err := r.updateLocked(ctx, paymentID, func(p *models.Payment) error {
+		if err := p.ProcessRefund(amount, reason); err != nil {
+			return errors.NewRepositoryError("REFUND_FAILED", err.Error(), errors.ErrInvalidInput)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	var payments models.Payment
-	if err := payments.ProcessRefund(amount, reason); err != nil {
-		return errors.NewRepositoryError("REFUND_FAILED", err.Error(), errors.ErrInvalidInput)
-	}
-
-	if err := r.Update(ctx, payment); err != nil {
-		return err
-	}
-
-	// Invalidate cache
-	r.invalidatePaymentCache(ctx, paymentID, payments.BookingID)
+	r.invalidatePaymentCache(ctx, payment.TenantID, paymentID)
 
 	r.logger.Info("refund created", "payment_id", paymentID, "amount", amount, "reason", reason)
 	return nil
 }
+
 func (r *paymentRepository) PartialRefund(ctx context.Context, paymentID uuid.UUID, amount float64, reason string) error {
 	return r.CreateRefund(ctx, paymentID, amount, reason)
 }
@@ -677,7 +604,7 @@ func (r *paymentRepository) CalculateCommissionSplit(ctx context.Context, paymen
 	}
 
 	// Invalidate cache
-	r.invalidatePaymentCache(ctx, paymentID, payments.BookingID)
+	r.invalidatePaymentCache(ctx, payment.TenantID, paymentID)
 
 	r.logger.Info("commission split calculated", "payment_id", paymentID, "rate", commissionRate)
 	return nil
@@ -694,6 +621,20 @@ func (r *paymentRepository) GetArtisanEarnings(ctx context.Context, artisanID uu
 	}
 	return totalEarnings, nil
 }
+
+// GetArtisanVolume sums what customers paid for an artisan's work in a period, net of refunds
+func (r *paymentRepository) GetArtisanVolume(ctx context.Context, artisanID uuid.UUID, startDate, endDate time.Time) (float64, error) {
+	var volume float64
+	if err := r.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Select("COALESCE(SUM(amount - refunded_amount), 0)").
+		Where("artisan_id = ? AND status IN ? AND processed_at BETWEEN ? AND ?",
+			artisanID, []models.PaymentStatus{models.PaymentStatusPaid, models.PaymentStatusPartialRefund}, startDate, endDate).
+		Scan(&volume).Error; err != nil {
+		return 0, errors.NewRepositoryError("CALCULATION_FAILED", "failed to calculate artisan volume", err)
+	}
+	return volume, nil
+}
 func (r *paymentRepository) GetPlatformRevenue(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (float64, error) {
 	var totalRevenue float64
 	if err := r.db.WithContext(ctx).
@@ -711,7 +652,7 @@ func (r *paymentRepository) GetUnpaidArtisanEarnings(ctx context.Context, artisa
 	if err := r.db.WithContext(ctx).
 		Model(&models.Payment{}).
 		Select("COALESCE(SUM(artisan_amount), 0)").
-		Where("artisan_id = ? AND status = ? AND (metadata->>'paid_to_artisan')::boolean IS NOT TRUE",
+		Where("artisan_id = ? AND status = ? AND payout_id IS NULL AND (metadata->>'paid_to_artisan')::boolean IS NOT TRUE",
 			artisanID, models.PaymentStatusPaid).
 		Scan(&totalEarnings).Error; err != nil {
 		return 0, errors.NewRepositoryError("CALCULATION_FAILED", "failed to calculate unpaid earnings", err)
@@ -722,6 +663,9 @@ func (r *paymentRepository) GetArtisanPaymentHistory(ctx context.Context, artisa
 	return r.GetByArtisanID(ctx, artisanID, pagination)
 }
 func (r *paymentRepository) GetCommissionBreakdown(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (CommissionBreakdown, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	var breakdown CommissionBreakdown
 
 	// Total paid
@@ -786,6 +730,9 @@ func (r *paymentRepository) GetByPaymentMethod(ctx context.Context, method model
 	return payments, paginationResult, nil
 }
 func (r *paymentRepository) GetPaymentMethodStats(ctx context.Context, tenantID uuid.UUID) (map[models.PaymentMethod]int64, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	var results []struct {
 		Method models.PaymentMethod
 		Count  int64
@@ -833,6 +780,9 @@ func (r *paymentRepository) GetPreferredPaymentMethods(ctx context.Context, cust
 }
 
 func (r *paymentRepository) GetPaymentStats(ctx context.Context, tenantID uuid.UUID) (PaymentStats, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	var stats PaymentStats
 
 	// Total payments
@@ -904,19 +854,49 @@ func (r *paymentRepository) GetPaymentStats(ctx context.Context, tenantID uuid.U
 
 	return stats, nil
 }
+
+// GetRevenueByPeriod returns a tenant's paid revenue per period. Once the tenant's
+// analytics rollups are built it is summed from their UTC days, each period stamped
+// with when the rollups were last refreshed; until then it is aggregated from the
+// payments.
 func (r *paymentRepository) GetRevenueByPeriod(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time, groupBy string) ([]RevenueData, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	var results []RevenueData
 
-	var dateFormat string
+	var dateFormat, rollupPeriod string
 	switch groupBy {
 	case "day":
-		dateFormat = "DATE(processed_at)"
+		dateFormat, rollupPeriod = "DATE(processed_at)", "day"
 	case "week":
-		dateFormat = "DATE_TRUNC('week', processed_at)"
+		dateFormat, rollupPeriod = "DATE_TRUNC('week', processed_at)", "DATE_TRUNC('week', day)"
 	case "month":
-		dateFormat = "DATE_TRUNC('month', processed_at)"
+		dateFormat, rollupPeriod = "DATE_TRUNC('month', processed_at)", "DATE_TRUNC('month', day)"
 	default:
-		dateFormat = "DATE(processed_at)"
+		dateFormat, rollupPeriod = "DATE(processed_at)", "day"
+	}
+
+	state, err := findRollupState(ctx, r.db, tenantID)
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "failed to get revenue by period", err)
+	}
+	if state != nil {
+		if err := r.db.WithContext(ctx).
+			Model(&models.AnalyticsDailyRollup{}).
+			Select(rollupPeriod+" AS period, SUM(payment_revenue) AS revenue, SUM(payment_count) AS transaction_count").
+			Where("tenant_id = ? AND day BETWEEN ? AND ?",
+				tenantID, startDate.UTC().Format(time.DateOnly), endDate.UTC().Format(time.DateOnly)).
+			Group("period").
+			Having("SUM(payment_count) > 0").
+			Order("period ASC").
+			Scan(&results).Error; err != nil {
+			return nil, errors.NewRepositoryError("QUERY_FAILED", "failed to get revenue by period", err)
+		}
+		for i := range results {
+			results[i].AsOf = &state.RefreshedAt
+		}
+		return results, nil
 	}
 
 	query := fmt.Sprintf(`
@@ -936,6 +916,7 @@ func (r *paymentRepository) GetRevenueByPeriod(ctx context.Context, tenantID uui
 
 	return results, nil
 }
+
 func (r *paymentRepository) GetDailyRevenue(ctx context.Context, tenantID uuid.UUID, date time.Time) (float64, error) {
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
@@ -991,6 +972,9 @@ func (r *paymentRepository) GetYearlyRevenue(ctx context.Context, tenantID uuid.
 
 // GetCustomerPaymentSummary retrieves payment summary for a customer
 func (r *paymentRepository) GetCustomerPaymentSummary(ctx context.Context, customerID uuid.UUID) (CustomerPaymentSummary, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	var summary CustomerPaymentSummary
 	summary.CustomerID = customerID
 
@@ -1124,8 +1108,9 @@ func (r *paymentRepository) Search(ctx context.Context, query string, tenantID u
 		Model(&models.Payment{}).
 		Joins("LEFT JOIN users AS customers ON customers.id = payments.customer_id").
 		Where("payments.tenant_id = ?", tenantID).
-		Where("LOWER(customers.first_name) LIKE ? OR LOWER(customers.last_name) LIKE ? OR LOWER(payments.provider_payment_id) LIKE ?",
-			searchPattern, searchPattern, searchPattern)
+		// Provider payment IDs are encrypted, so they only match exactly
+		Where("LOWER(customers.first_name) LIKE ? OR LOWER(customers.last_name) LIKE ? OR payments.provider_payment_id IN ?",
+			searchPattern, searchPattern, fieldcrypt.Default().LookupValues(strings.TrimSpace(query)))
 
 	var totalItems int64
 	if err := dbQuery.Count(&totalItems).Error; err != nil {
@@ -1150,8 +1135,6 @@ func (r *paymentRepository) Search(ctx context.Context, query string, tenantID u
 
 // FindByFilters retrieves payments using advanced filters
 func (r *paymentRepository) FindByFilters(ctx context.Context, filters PaymentFilters, pagination PaginationParams) ([]*models.Payment, PaginationResult, error) {
-	pagination.Validate()
-
 	query := r.db.WithContext(ctx).Model(&models.Payment{})
 
 	// Apply filters
@@ -1207,27 +1190,12 @@ func (r *paymentRepository) FindByFilters(ctx context.Context, filters PaymentFi
 		query = query.Where("provider_name = ?", *filters.ProviderName)
 	}
 
-	// Count total
-	var totalItems int64
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count payments", err)
-	}
-
-	// Find payments
-	var payments []*models.Payment
-	if err := query.
-		Preload("Customer").
-		Preload("Artisan").
-		Preload("Booking").
-		Offset(pagination.Offset()).
-		Limit(pagination.Limit()).
-		Order("created_at DESC").
-		Find(&payments).Error; err != nil {
-		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find payments", err)
-	}
-
-	paginationResult := CalculatePagination(pagination, totalItems)
-	return payments, paginationResult, nil
+	return paginateByKey(query, pagination, "created_at", paymentCursorKey,
+		func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Customer").
+				Preload("Artisan").
+				Preload("Booking")
+		})
 }
 
 // GetRecentPayments retrieves recent payments within specified hours
@@ -1290,6 +1258,9 @@ func (r *paymentRepository) GetByProvider(ctx context.Context, providerName stri
 
 // GetProviderStats retrieves statistics by provider
 func (r *paymentRepository) GetProviderStats(ctx context.Context, tenantID uuid.UUID) (map[string]ProviderStats, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	var results []struct {
 		ProviderName      string
 		TotalTransactions int64
@@ -1350,6 +1321,120 @@ func (r *paymentRepository) GetFailedPaymentsByProvider(ctx context.Context, pro
 	return payments, nil
 }
 
+// FindAwaitingProvider retrieves pending or processing payments started with a payment
+// provider and created in the given window, oldest first. Used to poll providers for
+// results whose webhook or callback never arrived.
+func (r *paymentRepository) FindAwaitingProvider(ctx context.Context, createdAfter, createdBefore time.Time, limit int) ([]*models.Payment, error) {
+	var payments []*models.Payment
+	if err := r.db.WithContext(ctx).
+		Where("status IN ? AND provider_name <> '' AND provider_payment_id <> ''",
+			[]models.PaymentStatus{models.PaymentStatusPending, models.PaymentStatusProcessing}).
+		Where("created_at >= ? AND created_at < ?", createdAfter, createdBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&payments).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find payments awaiting provider", err)
+	}
+	return payments, nil
+}
+
+// ApplyProviderResult saves a payment updated from a provider result and carries the
+// outcome onto its booking in one transaction, which also publishes the payment
+// succeeding or failing. When event is set it is recorded first; an event already seen
+// for the provider is a redelivery, so nothing is written and false is returned.
+// Either argument may be nil.
+func (r *paymentRepository) ApplyProviderResult(ctx context.Context, payment *models.Payment, event *models.PaymentWebhookEvent) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if event != nil {
+			result := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "provider"}, {Name: "event_id"}},
+				DoNothing: true,
+			}).Create(event)
+			if result.Error != nil {
+				return errors.NewRepositoryError("CREATE_FAILED", "failed to record payment webhook event", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return errors.NewRepositoryError("DUPLICATE", "payment webhook event already processed", errors.ErrDuplicate)
+			}
+		}
+		if payment == nil {
+			return nil
+		}
+
+		var before models.Payment
+		if err := tx.Select("status", "refunded_amount").First(&before, "id = ?", payment.ID).Error; err != nil {
+			return errors.NewRepositoryError("GET_FAILED", "failed to get payment", err)
+		}
+
+		result := tx.Model(payment).
+			Where("id = ? AND version = ?", payment.ID, payment.Version).
+			Updates(payment)
+		if result.Error != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to update payment", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewRepositoryError("CONFLICT", "payment was modified by another process", errors.ErrConflict)
+		}
+
+		if err := publishPaymentChanges(tx, &before, payment); err != nil {
+			return err
+		}
+
+		updates := bookingPaymentUpdates(payment)
+		if updates == nil {
+			return nil
+		}
+		query := tx.Model(&models.Booking{}).Where("id = ?", payment.BookingID)
+		if payment.Status == models.PaymentStatusFailed {
+			// A failed retry must not undo a booking that is already paid
+			query = query.Where("payment_status <> ?", models.PaymentStatusPaid)
+		}
+		if err := query.Updates(updates).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to update booking payment status", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.IsDuplicate(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if payment != nil {
+		r.invalidatePaymentCache(ctx, payment.TenantID, payment.ID)
+	}
+	return true, nil
+}
+
+// bookingPaymentUpdates returns the booking columns a settled or failed payment changes,
+// or nil when the payment does not affect its booking
+func bookingPaymentUpdates(payment *models.Payment) map[string]any {
+	updates := map[string]any{
+		"updated_at": time.Now(),
+		"version":    gorm.Expr("version + 1"),
+	}
+
+	switch {
+	case payment.Status == models.PaymentStatusPaid && payment.Type == models.PaymentTypeDeposit:
+		updates["deposit_paid"] = gorm.Expr("deposit_paid + ?", payment.Amount)
+	case payment.Status == models.PaymentStatusPaid && (payment.Type == models.PaymentTypeFull || payment.Type == models.PaymentTypeBalance):
+		updates["payment_status"] = models.PaymentStatusPaid
+	case payment.Status == models.PaymentStatusFailed && payment.Type != models.PaymentTypeTip && payment.Type != models.PaymentTypeNoShow:
+		updates["payment_status"] = models.PaymentStatusFailed
+		if payment.Type == models.PaymentTypeBalance {
+			// Hand a declined automatic balance charge back to the collection worker's dunning
+			updates["balance_status"] = gorm.Expr("CASE WHEN balance_status = ? THEN ? ELSE balance_status END",
+				models.BalanceStatusCharged, models.BalanceStatusDeclined)
+			updates["balance_failure_reason"] = gorm.Expr("CASE WHEN balance_status = ? THEN ? ELSE balance_failure_reason END",
+				models.BalanceStatusCharged, payment.FailureReason)
+		}
+	default:
+		return nil
+	}
+	return updates
+}
+
 // GetUnreconciledPayments retrieves payments that need reconciliation
 func (r *paymentRepository) GetUnreconciledPayments(ctx context.Context, tenantID uuid.UUID) ([]*models.Payment, error) {
 	var payments []*models.Payment
@@ -1404,6 +1489,167 @@ func (r *paymentRepository) GetPaymentsForReconciliation(ctx context.Context, te
 	return payments, nil
 }
 
+// FindByProviderPaymentIDs retrieves payments across tenants by their provider references
+func (r *paymentRepository) FindByProviderPaymentIDs(ctx context.Context, providerName string, providerPaymentIDs []string) ([]*models.Payment, error) {
+	if len(providerPaymentIDs) == 0 {
+		return nil, nil
+	}
+
+	lookup := make([]string, 0, len(providerPaymentIDs))
+	for _, id := range providerPaymentIDs {
+		lookup = append(lookup, fieldcrypt.Default().LookupValues(id)...)
+	}
+
+	var payments []*models.Payment
+	if err := r.db.WithContext(ctx).
+		Where("provider_name = ? AND provider_payment_id IN ?", providerName, lookup).
+		Find(&payments).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find payments by provider reference", err)
+	}
+	return payments, nil
+}
+
+// FindSettledByProvider retrieves payments across tenants that a provider settled in
+// [from, to), including those refunded since
+func (r *paymentRepository) FindSettledByProvider(ctx context.Context, providerName string, from, to time.Time) ([]*models.Payment, error) {
+	var payments []*models.Payment
+	if err := r.db.WithContext(ctx).
+		Where("provider_name = ? AND status IN ? AND processed_at >= ? AND processed_at < ?",
+			providerName,
+			[]models.PaymentStatus{models.PaymentStatusPaid, models.PaymentStatusPartialRefund, models.PaymentStatusRefunded},
+			from, to).
+		Order("processed_at ASC").
+		Find(&payments).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find settled payments", err)
+	}
+	return payments, nil
+}
+
+// FindSettledByTenant retrieves a tenant's payments settled in [from, to), including those
+// refunded since, with their bookings
+func (r *paymentRepository) FindSettledByTenant(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]*models.Payment, error) {
+	var payments []*models.Payment
+	if err := r.db.WithContext(ctx).
+		Preload("Booking").
+		Where("tenant_id = ? AND status IN ? AND processed_at >= ? AND processed_at < ?",
+			tenantID,
+			[]models.PaymentStatus{models.PaymentStatusPaid, models.PaymentStatusPartialRefund, models.PaymentStatusRefunded},
+			from, to).
+		Order("processed_at ASC").
+		Find(&payments).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find settled payments", err)
+	}
+	return payments, nil
+}
+
+// FindTenantsWithSettledPayments lists the tenants with payments settled in [from, to)
+func (r *paymentRepository) FindTenantsWithSettledPayments(ctx context.Context, from, to time.Time) ([]uuid.UUID, error) {
+	var tenantIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Distinct("tenant_id").
+		Where("status IN ? AND processed_at >= ? AND processed_at < ?",
+			[]models.PaymentStatus{models.PaymentStatusPaid, models.PaymentStatusPartialRefund, models.PaymentStatusRefunded},
+			from, to).
+		Pluck("tenant_id", &tenantIDs).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find tenants with settled payments", err)
+	}
+	return tenantIDs, nil
+}
+
+// UpdateEscrow changes a payment's escrow state under a row lock, so a release cannot
+// race a payout claiming the payment
+func (r *paymentRepository) UpdateEscrow(ctx context.Context, paymentID uuid.UUID, mutate func(*models.Payment) error) (*models.Payment, error) {
+	var payment models.Payment
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payment, "id = ?", paymentID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewRepositoryError("NOT_FOUND", "payment not found", errors.ErrNotFound)
+			}
+			return errors.NewRepositoryError("GET_FAILED", "failed to get payment", err)
+		}
+
+		if err := mutate(&payment); err != nil {
+			return err
+		}
+
+		// Written as a map so a cleared release time is saved
+		if err := tx.Model(&models.Payment{}).
+			Where("id = ?", payment.ID).
+			Updates(map[string]any{
+				"escrow_status":      payment.EscrowStatus,
+				"escrow_released_at": payment.EscrowReleasedAt,
+				"escrow_note":        payment.EscrowNote,
+				"updated_at":         time.Now(),
+				"version":            gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to update payment escrow", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidatePaymentCache(ctx, payment.TenantID, payment.ID)
+	return &payment, nil
+}
+
+// ReleaseDueEscrow releases every held payment whose booking was completed at least the
+// payment's hold period before now. Withheld payments stay frozen.
+func (r *paymentRepository) ReleaseDueEscrow(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("escrow_status = ?", models.EscrowStatusHeld).
+		Where(`EXISTS (SELECT 1 FROM bookings WHERE bookings.id = payments.booking_id
+			AND bookings.status = ? AND bookings.completed_at IS NOT NULL
+			AND bookings.completed_at + make_interval(days => payments.escrow_hold_days) <= ?)`,
+			models.BookingStatusCompleted, now).
+		Updates(map[string]any{
+			"escrow_status":      models.EscrowStatusReleased,
+			"escrow_released_at": now,
+			"updated_at":         now,
+			"version":            gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return 0, errors.NewRepositoryError("UPDATE_FAILED", "failed to release escrow", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ReleaseBookingEscrow releases the held payments of a booking, leaving withheld ones frozen
+func (r *paymentRepository) ReleaseBookingEscrow(ctx context.Context, bookingID uuid.UUID, note string) (int64, error) {
+	var paymentIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("booking_id = ? AND escrow_status = ?", bookingID, models.EscrowStatusHeld).
+		Pluck("id", &paymentIDs).Error; err != nil {
+		return 0, errors.NewRepositoryError("FIND_FAILED", "failed to find held payments", err)
+	}
+	if len(paymentIDs) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("id IN ? AND escrow_status = ?", paymentIDs, models.EscrowStatusHeld).
+		Updates(map[string]any{
+			"escrow_status":      models.EscrowStatusReleased,
+			"escrow_released_at": now,
+			"escrow_note":        note,
+			"updated_at":         now,
+			"version":            gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return 0, errors.NewRepositoryError("UPDATE_FAILED", "failed to release booking escrow", result.Error)
+	}
+	for _, paymentID := range paymentIDs {
+		r.invalidatePaymentCache(ctx, uuid.Nil, paymentID)
+	}
+	return result.RowsAffected, nil
+}
+
 // BulkMarkAsPaid marks multiple payments as paid
 func (r *paymentRepository) BulkMarkAsPaid(ctx context.Context, paymentIDs []uuid.UUID) error {
 	if len(paymentIDs) == 0 {
@@ -1455,23 +1701,50 @@ func (r *paymentRepository) BulkMarkAsFailed(ctx context.Context, paymentIDs []u
 
 // Helper methods
 
-func (r *paymentRepository) getCacheKey(prefix string, parts ...string) string {
-	allParts := append([]string{"repo", "payments", prefix}, parts...)
-	return strings.Join(allParts, ":")
-}
+// updateLocked loads a payment with a row lock, applies mutate to it and saves it in one
+// transaction, so concurrent status changes and refunds cannot overwrite each other.
+// The events of the change are published in the same transaction. The saved payment
+// is returned.
+func (r *paymentRepository) updateLocked(ctx context.Context, paymentID uuid.UUID, mutate func(*models.Payment) error) (*models.Payment, error) {
+	var payment models.Payment
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payment, "id = ?", paymentID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewRepositoryError("NOT_FOUND", "payment not found", errors.ErrNotFound)
+			}
+			return errors.NewRepositoryError("GET_FAILED", "failed to get payment", err)
+		}
 
-func (r *paymentRepository) invalidatePaymentCache(ctx context.Context, paymentID, bookingID uuid.UUID) {
-	if r.cache == nil {
-		return
-	}
+		before := payment
+		if err := mutate(&payment); err != nil {
+			return err
+		}
 
-	patterns := []string{
-		r.getCacheKey("id", paymentID.String()),
-		r.getCacheKey("booking", bookingID.String()),
-		r.getCacheKey("provider", "*"),
+		if err := tx.Model(&payment).Updates(&payment).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to update payment", err)
+		}
+		return publishPaymentChanges(tx, &before, &payment)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &payment, nil
+}
 
-	for _, pattern := range patterns {
-		r.cache.DeletePattern(ctx, pattern)
+// publishPaymentChanges appends the events of a payment going from before to after to
+// the outbox of the transaction tx
+func publishPaymentChanges(tx *gorm.DB, before, after *models.Payment) error {
+	outbox, err := events.Outbox(after.TenantID, events.PaymentChanges(before, after)...)
+	if err != nil {
+		return errors.NewRepositoryError("CREATE_FAILED", "failed to build payment events", err)
 	}
+	if err := appendOutbox(tx, outbox); err != nil {
+		return errors.NewRepositoryError("CREATE_FAILED", "failed to publish payment events", err)
+	}
+	return nil
+}
+
+// invalidatePaymentCache drops a payment and the cached lookups that may include it
+func (r *paymentRepository) invalidatePaymentCache(ctx context.Context, tenantID, paymentID uuid.UUID) {
+	r.RepositoryCache().Invalidate(ctx, tenantID, paymentID)
 }
@@ -0,0 +1,168 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPaymentTest(t *testing.T) (*testutil.TestDB, repository.PaymentRepository, *models.Booking) {
+	tdb, bookingRepo, tenantID, customerID, artisanID, serviceID := setupBookingTest(t)
+
+	booking := testutil.CreateTestBooking(tenantID, customerID, artisanID, serviceID)
+	require.NoError(t, bookingRepo.Create(context.Background(), booking))
+
+	return tdb, repository.NewPaymentRepository(tdb.DB, testutil.DefaultRepositoryConfig()), booking
+}
+
+func TestPaymentRepository_ApplyProviderResult(t *testing.T) {
+	tdb, repo, booking := setupPaymentTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+
+	payment := testutil.CreateTestPayment(booking.TenantID, booking.ID, booking.CustomerID, func(p *models.Payment) {
+		p.ProviderName = "stripe"
+		p.ProviderPaymentID = "pi_123"
+	})
+	require.NoError(t, repo.Create(ctx, payment))
+
+	newEvent := func() *models.PaymentWebhookEvent {
+		return &models.PaymentWebhookEvent{
+			Provider:   "stripe",
+			EventID:    "evt_1",
+			EventType:  "payment_intent.succeeded",
+			PaymentID:  &payment.ID,
+			Outcome:    models.PaymentWebhookApplied,
+			ReceivedAt: time.Now(),
+		}
+	}
+
+	t.Run("apply event to payment and booking", func(t *testing.T) {
+		payment.MarkAsPaid()
+		applied, err := repo.ApplyProviderResult(ctx, payment, newEvent())
+		require.NoError(t, err)
+		assert.True(t, applied)
+
+		saved, err := repo.GetByID(ctx, payment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PaymentStatusPaid, saved.Status)
+
+		var updated models.Booking
+		require.NoError(t, tdb.DB.First(&updated, "id = ?", booking.ID).Error)
+		assert.Equal(t, models.PaymentStatusPaid, updated.PaymentStatus)
+	})
+
+	t.Run("skip redelivered event", func(t *testing.T) {
+		current, err := repo.GetByID(ctx, payment.ID)
+		require.NoError(t, err)
+		current.MarkAsFailed("late failure")
+
+		applied, err := repo.ApplyProviderResult(ctx, current, newEvent())
+		require.NoError(t, err)
+		assert.False(t, applied)
+
+		saved, err := repo.GetByID(ctx, payment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PaymentStatusPaid, saved.Status)
+	})
+
+	t.Run("reject stale payment version", func(t *testing.T) {
+		stale, err := repo.GetByID(ctx, payment.ID)
+		require.NoError(t, err)
+		stale.Version--
+
+		_, err = repo.ApplyProviderResult(ctx, stale, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestPaymentRepository_StatusChanges(t *testing.T) {
+	tdb, repo, booking := setupPaymentTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+
+	create := func(t *testing.T) *models.Payment {
+		payment := testutil.CreateTestPayment(booking.TenantID, booking.ID, booking.CustomerID)
+		require.NoError(t, repo.Create(ctx, payment))
+		return payment
+	}
+
+	t.Run("mark as paid", func(t *testing.T) {
+		payment := create(t)
+		require.NoError(t, repo.MarkAsPaid(ctx, payment.ID, "pi_paid"))
+
+		saved, err := repo.GetByID(ctx, payment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PaymentStatusPaid, saved.Status)
+		assert.Equal(t, "pi_paid", saved.ProviderPaymentID)
+		assert.NotNil(t, saved.ProcessedAt)
+		assert.Equal(t, payment.Version+1, saved.Version)
+	})
+
+	t.Run("mark as failed", func(t *testing.T) {
+		payment := create(t)
+		require.NoError(t, repo.MarkAsFailed(ctx, payment.ID, "card declined"))
+
+		saved, err := repo.GetByID(ctx, payment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PaymentStatusFailed, saved.Status)
+		assert.Equal(t, "card declined", saved.FailureReason)
+	})
+
+	t.Run("mark as canceled", func(t *testing.T) {
+		payment := create(t)
+		require.NoError(t, repo.MarkAsCanceled(ctx, payment.ID))
+
+		saved, err := repo.GetByID(ctx, payment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PaymentStatusCancelled, saved.Status)
+	})
+
+	t.Run("missing payment", func(t *testing.T) {
+		err := repo.MarkAsPaid(ctx, uuid.New(), "")
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
+
+func TestPaymentRepository_CreateRefund(t *testing.T) {
+	tdb, repo, booking := setupPaymentTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+
+	payment := testutil.CreateTestPayment(booking.TenantID, booking.ID, booking.CustomerID, func(p *models.Payment) {
+		p.Status = models.PaymentStatusPaid
+	})
+	require.NoError(t, repo.Create(ctx, payment))
+
+	t.Run("reject refund above the paid amount", func(t *testing.T) {
+		err := repo.CreateRefund(ctx, payment.ID, payment.Amount+1, "too much")
+		assert.Error(t, err)
+
+		saved, err := repo.GetByID(ctx, payment.ID)
+		require.NoError(t, err)
+		assert.Zero(t, saved.RefundedAmount)
+	})
+
+	t.Run("record partial refund", func(t *testing.T) {
+		require.NoError(t, repo.CreateRefund(ctx, payment.ID, 200, "missed step"))
+
+		saved, err := repo.GetByID(ctx, payment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.PaymentStatusPartialRefund, saved.Status)
+		assert.Equal(t, 200.0, saved.RefundedAmount)
+		assert.Equal(t, "missed step", saved.RefundReason)
+		assert.NotNil(t, saved.RefundedAt)
+	})
+}
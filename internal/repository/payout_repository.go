@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PayoutRepository defines the interface for payout and payout batch repository operations
+type PayoutRepository interface {
+	BaseRepository[models.Payout]
+
+	// Batch Operations
+	CreatePayoutBatch(ctx context.Context, batch *models.PayoutBatch, minimumAmount float64) ([]*models.Payout, error)
+	GetBatch(ctx context.Context, tenantID, batchID uuid.UUID) (*models.PayoutBatch, error)
+	ListBatches(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.PayoutBatch, PaginationResult, error)
+	UpdateBatchStatus(ctx context.Context, batch *models.PayoutBatch) error
+
+	// Payout Operations
+	SettlePayout(ctx context.Context, payout *models.Payout) error
+	ListByArtisan(ctx context.Context, tenantID, artisanID uuid.UUID, pagination PaginationParams) ([]*models.Payout, PaginationResult, error)
+	GetPaidTotal(ctx context.Context, tenantID, artisanID uuid.UUID, currency string) (float64, error)
+	GetUnsettledTotal(ctx context.Context, tenantID, artisanID uuid.UUID, currency string) (float64, error)
+	GetHeldTotal(ctx context.Context, tenantID, artisanID uuid.UUID, currency string) (float64, error)
+}
+
+// payoutRepository implements PayoutRepository
+type payoutRepository struct {
+	BaseRepository[models.Payout]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewPayoutRepository creates a new PayoutRepository instance
+func NewPayoutRepository(db *gorm.DB, config ...RepositoryConfig) PayoutRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.Payout](db, cfg)
+
+	return &payoutRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// heldEscrowStatuses are the escrow states whose earnings cannot be paid out
+var heldEscrowStatuses = []models.EscrowStatus{models.EscrowStatusHeld, models.EscrowStatusWithheld}
+
+// notHeldInEscrow excludes payments held back by escrow; payments without a hold period
+// have no escrow status
+const notHeldInEscrow = "(escrow_status IS NULL OR escrow_status NOT IN ?)"
+
+// unsettledEarning is a paid payment whose artisan share has not been paid out
+type unsettledEarning struct {
+	ID            uuid.UUID
+	ArtisanID     uuid.UUID
+	ArtisanAmount float64
+}
+
+// CreatePayoutBatch claims the tenant's unsettled artisan earnings in batch.Currency processed
+// up to batch.PeriodEnd, creates one pending payout per artisan owed at least
+// minimumAmount, and links the covered payments to their payout. Payments locked by a
+// concurrent run are skipped. Nothing is created, and no payouts are returned, when
+// no artisan is owed enough.
+func (r *payoutRepository) CreatePayoutBatch(ctx context.Context, batch *models.PayoutBatch, minimumAmount float64) ([]*models.Payout, error) {
+	var payouts []*models.Payout
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var earnings []unsettledEarning
+		if err := tx.Model(&models.Payment{}).
+			Select("id, artisan_id, artisan_amount").
+			Where("tenant_id = ? AND status = ? AND currency = ?", batch.TenantID, models.PaymentStatusPaid, batch.Currency).
+			Where("artisan_id IS NOT NULL AND payout_id IS NULL AND artisan_amount > 0").
+			Where("processed_at <= ?", batch.PeriodEnd).
+			Where("(metadata->>'paid_to_artisan')::boolean IS NOT TRUE").
+			Where(notHeldInEscrow, heldEscrowStatuses).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&earnings).Error; err != nil {
+			return errors.NewRepositoryError("FIND_FAILED", "failed to find unsettled earnings", err)
+		}
+
+		byArtisan := map[uuid.UUID][]unsettledEarning{}
+		for _, earning := range earnings {
+			byArtisan[earning.ArtisanID] = append(byArtisan[earning.ArtisanID], earning)
+		}
+
+		paymentIDs := map[uuid.UUID][]uuid.UUID{}
+		for artisanID, owed := range byArtisan {
+			payout := &models.Payout{
+				BaseModel: models.BaseModel{ID: uuid.New()},
+				TenantID:  batch.TenantID,
+				ArtisanID: artisanID,
+				Currency:  batch.Currency,
+				Method:    batch.Method,
+				Status:    models.PayoutStatusPending,
+			}
+			for _, earning := range owed {
+				payout.Amount += earning.ArtisanAmount
+				payout.PaymentCount++
+				paymentIDs[payout.ID] = append(paymentIDs[payout.ID], earning.ID)
+			}
+			if payout.Amount < minimumAmount {
+				continue
+			}
+			payouts = append(payouts, payout)
+		}
+		if len(payouts) == 0 {
+			return nil
+		}
+		sort.Slice(payouts, func(i, j int) bool { return payouts[i].ArtisanID.String() < payouts[j].ArtisanID.String() })
+
+		batch.Status = models.PayoutStatusPending
+		batch.PayoutCount = len(payouts)
+		batch.TotalAmount = 0
+		for _, payout := range payouts {
+			batch.TotalAmount += payout.Amount
+		}
+		if err := tx.Create(batch).Error; err != nil {
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to create payout batch", err)
+		}
+
+		for _, payout := range payouts {
+			payout.BatchID = batch.ID
+		}
+		if err := tx.Create(&payouts).Error; err != nil {
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to create payouts", err)
+		}
+
+		for _, payout := range payouts {
+			if err := tx.Model(&models.Payment{}).
+				Where("id IN ?", paymentIDs[payout.ID]).
+				Updates(map[string]any{
+					"payout_id":  payout.ID,
+					"updated_at": time.Now(),
+					"version":    gorm.Expr("version + 1"),
+				}).Error; err != nil {
+				return errors.NewRepositoryError("UPDATE_FAILED", "failed to link payments to payout", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payouts, nil
+}
+
+// GetBatch retrieves a tenant's payout batch with its payouts
+func (r *payoutRepository) GetBatch(ctx context.Context, tenantID, batchID uuid.UUID) (*models.PayoutBatch, error) {
+	var batch models.PayoutBatch
+	if err := r.db.WithContext(ctx).
+		Preload("Payouts", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Payouts.Artisan").
+		Where("id = ? AND tenant_id = ?", batchID, tenantID).
+		First(&batch).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "payout batch not found", errors.ErrNotFound)
+		}
+		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get payout batch", err)
+	}
+	return &batch, nil
+}
+
+// ListBatches lists a tenant's payout batches, newest first
+func (r *payoutRepository) ListBatches(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.PayoutBatch, PaginationResult, error) {
+	pagination.Validate()
+
+	var totalItems int64
+	query := r.db.WithContext(ctx).Model(&models.PayoutBatch{}).Where("tenant_id = ?", tenantID)
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count payout batches", err)
+	}
+
+	var batches []*models.PayoutBatch
+	if err := query.
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("created_at DESC").
+		Find(&batches).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find payout batches", err)
+	}
+
+	return batches, CalculatePagination(pagination, totalItems), nil
+}
+
+// UpdateBatchStatus saves a batch's status, failure count and completion time
+func (r *payoutRepository) UpdateBatchStatus(ctx context.Context, batch *models.PayoutBatch) error {
+	if err := r.db.WithContext(ctx).
+		Model(&models.PayoutBatch{}).
+		Where("id = ?", batch.ID).
+		Updates(map[string]any{
+			"status":       batch.Status,
+			"failed_count": batch.FailedCount,
+			"completed_at": batch.CompletedAt,
+			"updated_at":   time.Now(),
+			"version":      gorm.Expr("version + 1"),
+		}).Error; err != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update payout batch", err)
+	}
+	return nil
+}
+
+// SettlePayout saves the outcome of sending a payout. A failed payout releases its
+// payments so their earnings are picked up by the next batch.
+func (r *payoutRepository) SettlePayout(ctx context.Context, payout *models.Payout) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Payout{}).
+			Where("id = ?", payout.ID).
+			Updates(map[string]any{
+				"status":               payout.Status,
+				"destination":          payout.Destination,
+				"provider_transfer_id": payout.ProviderTransferID,
+				"failure_reason":       payout.FailureReason,
+				"paid_at":              payout.PaidAt,
+				"updated_at":           time.Now(),
+				"version":              gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to update payout", err)
+		}
+
+		if payout.Status != models.PayoutStatusFailed {
+			return nil
+		}
+		if err := tx.Model(&models.Payment{}).
+			Where("payout_id = ?", payout.ID).
+			Updates(map[string]any{
+				"payout_id":  nil,
+				"updated_at": time.Now(),
+				"version":    gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to release payout payments", err)
+		}
+		return nil
+	})
+}
+
+// ListByArtisan lists an artisan's payouts in the tenant, newest first
+func (r *payoutRepository) ListByArtisan(ctx context.Context, tenantID, artisanID uuid.UUID, pagination PaginationParams) ([]*models.Payout, PaginationResult, error) {
+	pagination.Validate()
+
+	var totalItems int64
+	query := r.db.WithContext(ctx).Model(&models.Payout{}).
+		Where("tenant_id = ? AND artisan_id = ?", tenantID, artisanID)
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count payouts", err)
+	}
+
+	var payouts []*models.Payout
+	if err := query.
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("created_at DESC").
+		Find(&payouts).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find payouts", err)
+	}
+
+	return payouts, CalculatePagination(pagination, totalItems), nil
+}
+
+// GetPaidTotal sums an artisan's paid payouts in a currency
+func (r *payoutRepository) GetPaidTotal(ctx context.Context, tenantID, artisanID uuid.UUID, currency string) (float64, error) {
+	var total float64
+	if err := r.db.WithContext(ctx).
+		Model(&models.Payout{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("tenant_id = ? AND artisan_id = ? AND currency = ? AND status = ?",
+			tenantID, artisanID, currency, models.PayoutStatusPaid).
+		Scan(&total).Error; err != nil {
+		return 0, errors.NewRepositoryError("CALCULATION_FAILED", "failed to calculate paid payouts", err)
+	}
+	return total, nil
+}
+
+// GetUnsettledTotal sums an artisan's earnings in a currency that the next batch would pay out
+func (r *payoutRepository) GetUnsettledTotal(ctx context.Context, tenantID, artisanID uuid.UUID, currency string) (float64, error) {
+	var total float64
+	if err := r.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Select("COALESCE(SUM(artisan_amount), 0)").
+		Where("tenant_id = ? AND artisan_id = ? AND currency = ? AND status = ?",
+			tenantID, artisanID, currency, models.PaymentStatusPaid).
+		Where("payout_id IS NULL AND (metadata->>'paid_to_artisan')::boolean IS NOT TRUE").
+		Where(notHeldInEscrow, heldEscrowStatuses).
+		Scan(&total).Error; err != nil {
+		return 0, errors.NewRepositoryError("CALCULATION_FAILED", "failed to calculate unsettled earnings", err)
+	}
+	return total, nil
+}
+
+// GetHeldTotal sums an artisan's earnings in a currency held or withheld in escrow
+func (r *payoutRepository) GetHeldTotal(ctx context.Context, tenantID, artisanID uuid.UUID, currency string) (float64, error) {
+	var total float64
+	if err := r.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Select("COALESCE(SUM(artisan_amount), 0)").
+		Where("tenant_id = ? AND artisan_id = ? AND currency = ? AND status = ?",
+			tenantID, artisanID, currency, models.PaymentStatusPaid).
+		Where("payout_id IS NULL AND escrow_status IN ?", heldEscrowStatuses).
+		Scan(&total).Error; err != nil {
+		return 0, errors.NewRepositoryError("CALCULATION_FAILED", "failed to calculate held earnings", err)
+	}
+	return total, nil
+}
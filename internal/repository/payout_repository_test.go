@@ -0,0 +1,107 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayoutRepository_CreatePayoutBatch(t *testing.T) {
+	tdb, paymentRepo, booking := setupPaymentTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	repo := repository.NewPayoutRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+
+	processedAt := time.Now().Add(-time.Hour)
+	createEarning := func(t *testing.T, amount float64, overrides ...func(*models.Payment)) *models.Payment {
+		payment := testutil.CreateTestPayment(booking.TenantID, booking.ID, booking.CustomerID, func(p *models.Payment) {
+			p.ArtisanID = &booking.ArtisanID
+			p.Status = models.PaymentStatusPaid
+			p.ArtisanAmount = amount
+			p.ProcessedAt = &processedAt
+		})
+		for _, override := range overrides {
+			override(payment)
+		}
+		require.NoError(t, paymentRepo.Create(ctx, payment))
+		return payment
+	}
+
+	first := createEarning(t, 80)
+	second := createEarning(t, 40)
+	createEarning(t, 25, func(p *models.Payment) { p.Currency = "EUR" })
+	createEarning(t, 60, func(p *models.Payment) { p.Status = models.PaymentStatusPending })
+
+	newBatch := func() *models.PayoutBatch {
+		return &models.PayoutBatch{
+			TenantID:  booking.TenantID,
+			Method:    models.PayoutMethodBankTransfer,
+			Currency:  "USD",
+			PeriodEnd: time.Now(),
+		}
+	}
+
+	t.Run("skip artisans below the minimum", func(t *testing.T) {
+		payouts, err := repo.CreatePayoutBatch(ctx, newBatch(), 500)
+		require.NoError(t, err)
+		assert.Empty(t, payouts)
+	})
+
+	batch := newBatch()
+
+	t.Run("group unsettled earnings per artisan", func(t *testing.T) {
+		payouts, err := repo.CreatePayoutBatch(ctx, batch, 0)
+		require.NoError(t, err)
+		require.Len(t, payouts, 1)
+
+		payout := payouts[0]
+		assert.Equal(t, booking.ArtisanID, payout.ArtisanID)
+		assert.InDelta(t, 120, payout.Amount, 0.001)
+		assert.Equal(t, 2, payout.PaymentCount)
+		assert.Equal(t, batch.ID, payout.BatchID)
+		assert.InDelta(t, 120, batch.TotalAmount, 0.001)
+
+		for _, payment := range []*models.Payment{first, second} {
+			saved, err := paymentRepo.GetByID(ctx, payment.ID)
+			require.NoError(t, err)
+			require.NotNil(t, saved.PayoutID)
+			assert.Equal(t, payout.ID, *saved.PayoutID)
+		}
+
+		total, err := repo.GetUnsettledTotal(ctx, booking.TenantID, booking.ArtisanID, "USD")
+		require.NoError(t, err)
+		assert.Zero(t, total)
+	})
+
+	t.Run("claim each earning once", func(t *testing.T) {
+		payouts, err := repo.CreatePayoutBatch(ctx, newBatch(), 0)
+		require.NoError(t, err)
+		assert.Empty(t, payouts)
+	})
+
+	t.Run("failed payout releases its earnings", func(t *testing.T) {
+		saved, err := repo.GetBatch(ctx, booking.TenantID, batch.ID)
+		require.NoError(t, err)
+		require.Len(t, saved.Payouts, 1)
+
+		payout := saved.Payouts[0]
+		payout.MarkAsFailed("account closed")
+		require.NoError(t, repo.SettlePayout(ctx, payout))
+
+		total, err := repo.GetUnsettledTotal(ctx, booking.TenantID, booking.ArtisanID, "USD")
+		require.NoError(t, err)
+		assert.InDelta(t, 120, total, 0.001)
+
+		paid, err := repo.GetPaidTotal(ctx, booking.TenantID, booking.ArtisanID, "USD")
+		require.NoError(t, err)
+		assert.Zero(t, paid)
+	})
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+// PlanRepository defines the interface for platform plan repository operations
+type PlanRepository interface {
+	BaseRepository[models.Plan]
+
+	// GetByCode retrieves a plan by its code
+	GetByCode(ctx context.Context, code models.SubscriptionPlan) (*models.Plan, error)
+
+	// List returns every plan in display order; activeOnly hides retired plans
+	List(ctx context.Context, activeOnly bool) ([]*models.Plan, error)
+}
+
+// planRepository implements PlanRepository
+type planRepository struct {
+	BaseRepository[models.Plan]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewPlanRepository creates a new PlanRepository instance
+func NewPlanRepository(db *gorm.DB, config ...RepositoryConfig) PlanRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.Plan](db, cfg)
+
+	return &planRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// GetByCode retrieves a plan by its code
+func (r *planRepository) GetByCode(ctx context.Context, code models.SubscriptionPlan) (*models.Plan, error) {
+	if code == "" {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "plan code cannot be empty", errors.ErrInvalidInput)
+	}
+
+	var plan models.Plan
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&plan).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "plan not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to get plan", "code", code, "error", err)
+		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get plan", err)
+	}
+
+	return &plan, nil
+}
+
+// List retrieves plans ordered for display
+func (r *planRepository) List(ctx context.Context, activeOnly bool) ([]*models.Plan, error) {
+	query := r.db.WithContext(ctx)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var plans []*models.Plan
+	if err := query.Order("sort_order ASC, monthly_price ASC").Find(&plans).Error; err != nil {
+		r.logger.Error("failed to list plans", "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to list plans", err)
+	}
+
+	return plans, nil
+}
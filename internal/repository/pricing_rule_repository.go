@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PricingRuleRepository defines the interface for pricing rule repository operations
+type PricingRuleRepository interface {
+	BaseRepository[models.PricingRule]
+
+	// FindByTenantID returns all of a tenant's pricing rules
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.PricingRule, error)
+
+	// FindActive returns the tenant's active pricing rules; models.ApplyPricingRules
+	// picks those that apply
+	FindActive(ctx context.Context, tenantID uuid.UUID) ([]*models.PricingRule, error)
+}
+
+// pricingRuleRepository implements PricingRuleRepository
+type pricingRuleRepository struct {
+	BaseRepository[models.PricingRule]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewPricingRuleRepository creates a new PricingRuleRepository instance
+func NewPricingRuleRepository(db *gorm.DB, config ...RepositoryConfig) PricingRuleRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.PricingRule](db, cfg)
+
+	return &pricingRuleRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenantID retrieves all pricing rules for a tenant
+func (r *pricingRuleRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.PricingRule, error) {
+	return r.find(ctx, tenantID, false)
+}
+
+// FindActive retrieves the active pricing rules for a tenant
+func (r *pricingRuleRepository) FindActive(ctx context.Context, tenantID uuid.UUID) ([]*models.PricingRule, error) {
+	return r.find(ctx, tenantID, true)
+}
+
+func (r *pricingRuleRepository) find(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]*models.PricingRule, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var rules []*models.PricingRule
+	if err := query.
+		Order("kind ASC, created_at ASC").
+		Find(&rules).Error; err != nil {
+		r.logger.Error("failed to find pricing rules", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find pricing rules", err)
+	}
+
+	return rules, nil
+}
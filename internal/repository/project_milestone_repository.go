@@ -269,9 +269,7 @@ func (r *projectMilestoneRepository) UpdateStatus(ctx context.Context, milestone
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_milestones:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, milestoneID)
 
 	return nil
 }
@@ -310,9 +308,7 @@ func (r *projectMilestoneRepository) StartMilestone(ctx context.Context, milesto
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_milestones:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, milestoneID)
 
 	return nil
 }
@@ -344,9 +340,7 @@ func (r *projectMilestoneRepository) CompleteMilestone(ctx context.Context, mile
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_milestones:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, milestoneID)
 
 	return nil
 }
@@ -372,9 +366,7 @@ func (r *projectMilestoneRepository) CancelMilestone(ctx context.Context, milest
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_milestones:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, milestoneID)
 
 	return nil
 }
@@ -434,9 +426,7 @@ func (r *projectMilestoneRepository) ApproveMilestone(ctx context.Context, miles
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_milestones:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, milestoneID)
 
 	return nil
 }
@@ -468,9 +458,7 @@ func (r *projectMilestoneRepository) RejectMilestone(ctx context.Context, milest
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_milestones:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, milestoneID)
 
 	return nil
 }
@@ -502,9 +490,7 @@ func (r *projectMilestoneRepository) MarkPaymentReceived(ctx context.Context, mi
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_milestones:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, milestoneID)
 
 	return nil
 }
@@ -714,9 +700,7 @@ func (r *projectMilestoneRepository) ReorderMilestones(ctx context.Context, proj
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_milestones:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	r.logger.Info("reordered milestones", "project_id", projectID, "count", len(milestoneOrders))
 	return nil
@@ -2,14 +2,18 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"time"
 
+	"Krafti_Vibe/internal/domain/events"
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProjectRepository defines the interface for project repository operations
@@ -46,9 +50,14 @@ type ProjectRepository interface {
 	GetProjectStats(ctx context.Context, tenantID uuid.UUID) (ProjectStats, error)
 	GetArtisanProjectStats(ctx context.Context, artisanID uuid.UUID) (ArtisanProjectStats, error)
 	GetCustomerProjectStats(ctx context.Context, customerID uuid.UUID) (CustomerProjectStats, error)
-	GetProjectTimeline(ctx context.Context, projectID uuid.UUID) ([]TimelineEvent, error)
+	GetProjectTimeline(ctx context.Context, projectID uuid.UUID, pagination PaginationParams) ([]TimelineEvent, PaginationResult, error)
 	GetProjectHealth(ctx context.Context, projectID uuid.UUID) (ProjectHealth, error)
 
+	// Health Tracking
+	FindDueForHealthCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*models.Project, error)
+	RecordHealthSnapshot(ctx context.Context, project *models.Project, snapshot *models.ProjectHealthSnapshot) (*models.ProjectHealthSnapshot, error)
+	GetHealthHistory(ctx context.Context, projectID uuid.UUID, since time.Time) ([]*models.ProjectHealthSnapshot, error)
+
 	// Bulk Operations
 	BulkUpdateStatus(ctx context.Context, projectIDs []uuid.UUID, status models.ProjectStatus) error
 	BulkAssignArtisan(ctx context.Context, projectIDs []uuid.UUID, artisanID uuid.UUID) error
@@ -97,6 +106,16 @@ type CustomerProjectStats struct {
 	AverageProjectCost float64 `json:"average_project_cost"`
 }
 
+// Timeline event sources
+const (
+	TimelineSourceProject      = "project"       // The project itself (creation)
+	TimelineSourceUpdate       = "update"        // A posted project update
+	TimelineSourceStatusChange = "status_change" // A project status transition
+	TimelineSourceMilestone    = "milestone"     // A completed milestone
+	TimelineSourceTask         = "task"          // A completed task
+	TimelineSourceBooking      = "booking"       // A change to a booking linked to the project
+)
+
 // TimelineEvent represents a project timeline event
 type TimelineEvent struct {
 	Date        time.Time  `json:"date"`
@@ -105,20 +124,25 @@ type TimelineEvent struct {
 	Description string     `json:"description"`
 	UserID      *uuid.UUID `json:"user_id,omitempty"`
 	UserName    string     `json:"user_name,omitempty"`
+	Source      string     `json:"source"`    // One of the TimelineSource constants
+	EntityID    uuid.UUID  `json:"entity_id"` // ID of the project, update, status change, milestone, task or booking event
 }
 
 // ProjectHealth represents project health metrics
 type ProjectHealth struct {
-	ProjectID          uuid.UUID `json:"project_id"`
-	HealthScore        int       `json:"health_score"` // 0-100
-	IsOnTrack          bool      `json:"is_on_track"`
-	IsOverBudget       bool      `json:"is_over_budget"`
-	IsOverdue          bool      `json:"is_overdue"`
-	BlockedTasksCount  int       `json:"blocked_tasks_count"`
-	OverdueTasksCount  int       `json:"overdue_tasks_count"`
-	CompletionVelocity float64   `json:"completion_velocity"` // tasks per day
-	RiskLevel          string    `json:"risk_level"`          // low, medium, high
-	Recommendations    []string  `json:"recommendations"`
+	ProjectID          uuid.UUID  `json:"project_id"`
+	HealthScore        int        `json:"health_score"` // 0-100
+	IsOnTrack          bool       `json:"is_on_track"`
+	IsOverBudget       bool       `json:"is_over_budget"`
+	IsOverdue          bool       `json:"is_overdue"`
+	BlockedTasksCount  int        `json:"blocked_tasks_count"`
+	OverdueTasksCount  int        `json:"overdue_tasks_count"`
+	CompletionVelocity float64    `json:"completion_velocity"` // tasks per day
+	CriticalPathHours  float64    `json:"critical_path_hours"` // remaining work on the critical path
+	ProjectedFinish    *time.Time `json:"projected_finish,omitempty"`
+	ScheduleDaysLate   int        `json:"schedule_days_late"` // days the projected finish is past the due date
+	RiskLevel          string     `json:"risk_level"`         // low, medium, high
+	Recommendations    []string   `json:"recommendations"`
 }
 
 // ArtisanDashboard represents artisan dashboard data
@@ -559,9 +583,7 @@ func (r *projectRepository) UpdateProgress(ctx context.Context, projectID uuid.U
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:projects:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, projectID)
 
 	return nil
 }
@@ -629,41 +651,13 @@ func (r *projectRepository) StartProject(ctx context.Context, projectID uuid.UUI
 		return errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	var project models.Project
-	if err := r.db.WithContext(ctx).First(&project, projectID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.NewRepositoryError("NOT_FOUND", "project not found", errors.ErrNotFound)
+	return r.transitionProjectStatus(ctx, projectID, models.ProjectStatusInProgress, "", func(project *models.Project, updates map[string]any) error {
+		// Set start date if not already set
+		if project.StartDate == nil {
+			updates["start_date"] = time.Now()
 		}
-		return errors.NewRepositoryError("FIND_FAILED", "failed to find project", err)
-	}
-
-	if project.Status == models.ProjectStatusInProgress {
-		return nil // Already in progress
-	}
-
-	now := time.Now()
-	updates := map[string]interface{}{
-		"status": models.ProjectStatusInProgress,
-	}
-
-	// Set start date if not already set
-	if project.StartDate == nil {
-		updates["start_date"] = now
-	}
-
-	if err := r.db.WithContext(ctx).
-		Model(&project).
-		Updates(updates).Error; err != nil {
-		r.logger.Error("failed to start project", "project_id", projectID, "error", err)
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to start project", err)
-	}
-
-	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:projects:*")
-	}
-
-	return nil
+		return nil
+	})
 }
 
 // PauseProject transitions project to on_hold status
@@ -672,20 +666,7 @@ func (r *projectRepository) PauseProject(ctx context.Context, projectID uuid.UUI
 		return errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	if err := r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("id = ?", projectID).
-		Update("status", models.ProjectStatusOnHold).Error; err != nil {
-		r.logger.Error("failed to pause project", "project_id", projectID, "error", err)
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to pause project", err)
-	}
-
-	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:projects:*")
-	}
-
-	return nil
+	return r.transitionProjectStatus(ctx, projectID, models.ProjectStatusOnHold, "", nil)
 }
 
 // CompleteProject transitions project to completed status
@@ -694,27 +675,11 @@ func (r *projectRepository) CompleteProject(ctx context.Context, projectID uuid.
 		return errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	now := time.Now()
-	updates := map[string]interface{}{
-		"status":           models.ProjectStatusCompleted,
-		"completed_at":     now,
-		"progress_percent": 100,
-	}
-
-	if err := r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("id = ?", projectID).
-		Updates(updates).Error; err != nil {
-		r.logger.Error("failed to complete project", "project_id", projectID, "error", err)
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to complete project", err)
-	}
-
-	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:projects:*")
-	}
-
-	return nil
+	return r.transitionProjectStatus(ctx, projectID, models.ProjectStatusCompleted, "", func(project *models.Project, updates map[string]any) error {
+		updates["completed_at"] = time.Now()
+		updates["progress_percent"] = 100
+		return nil
+	})
 }
 
 // CancelProject transitions project to cancelled status
@@ -723,14 +688,9 @@ func (r *projectRepository) CancelProject(ctx context.Context, projectID uuid.UU
 		return errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	updates := map[string]interface{}{
-		"status": models.ProjectStatusCancelled,
-	}
-
-	// Store cancellation reason in metadata
-	if reason != "" {
-		var project models.Project
-		if err := r.db.WithContext(ctx).First(&project, projectID).Error; err == nil {
+	return r.transitionProjectStatus(ctx, projectID, models.ProjectStatusCancelled, reason, func(project *models.Project, updates map[string]any) error {
+		// Store cancellation reason in metadata
+		if reason != "" {
 			metadata := project.Metadata
 			if metadata == nil {
 				metadata = make(models.JSONB)
@@ -739,22 +699,8 @@ func (r *projectRepository) CancelProject(ctx context.Context, projectID uuid.UU
 			metadata["cancelled_at"] = time.Now()
 			updates["metadata"] = metadata
 		}
-	}
-
-	if err := r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("id = ?", projectID).
-		Updates(updates).Error; err != nil {
-		r.logger.Error("failed to cancel project", "project_id", projectID, "error", err)
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to cancel project", err)
-	}
-
-	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:projects:*")
-	}
-
-	return nil
+		return nil
+	})
 }
 
 // ResumeProject resumes a paused project
@@ -763,155 +709,189 @@ func (r *projectRepository) ResumeProject(ctx context.Context, projectID uuid.UU
 		return errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	var project models.Project
-	if err := r.db.WithContext(ctx).First(&project, projectID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.NewRepositoryError("NOT_FOUND", "project not found", errors.ErrNotFound)
+	return r.transitionProjectStatus(ctx, projectID, models.ProjectStatusInProgress, "", func(project *models.Project, updates map[string]any) error {
+		if project.Status != models.ProjectStatusOnHold {
+			return errors.NewRepositoryError("INVALID_STATUS", "can only resume projects on hold", errors.ErrInvalidInput)
 		}
-		return errors.NewRepositoryError("FIND_FAILED", "failed to find project", err)
-	}
+		return nil
+	})
+}
 
-	if project.Status != models.ProjectStatusOnHold {
-		return errors.NewRepositoryError("INVALID_STATUS", "can only resume projects on hold", errors.ErrInvalidInput)
+// transitionProjectStatus moves a single project to a new status
+func (r *projectRepository) transitionProjectStatus(ctx context.Context, projectID uuid.UUID, to models.ProjectStatus, reason string, apply func(project *models.Project, updates map[string]any) error) error {
+	found, err := r.transitionStatus(ctx, []uuid.UUID{projectID}, to, reason, apply)
+	if err != nil {
+		r.logger.Error("failed to change project status", "project_id", projectID, "status", to, "error", err)
+		return err
 	}
+	if found == 0 {
+		return errors.NewRepositoryError("NOT_FOUND", "project not found", errors.ErrNotFound)
+	}
+	return nil
+}
 
-	if err := r.db.WithContext(ctx).
-		Model(&project).
-		Update("status", models.ProjectStatusInProgress).Error; err != nil {
-		r.logger.Error("failed to resume project", "project_id", projectID, "error", err)
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to resume project", err)
+// transitionStatus moves projects to a new status in one transaction and records a
+// ProjectStatusChange for each project that changed, publishing the change in the same
+// transaction. apply, if given, is called for every project before it is updated and
+// may add columns to the update or reject the transition; projects already in the
+// status are then left alone. It returns how many of the projects exist.
+func (r *projectRepository) transitionStatus(ctx context.Context, projectIDs []uuid.UUID, to models.ProjectStatus, reason string, apply func(project *models.Project, updates map[string]any) error) (int, error) {
+	var found int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var projects []*models.Project
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", projectIDs).
+			Order("id").
+			Find(&projects).Error; err != nil {
+			return errors.NewRepositoryError("FIND_FAILED", "failed to find projects", err)
+		}
+		found = len(projects)
+
+		for _, project := range projects {
+			updates := map[string]any{
+				"status":  to,
+				"version": gorm.Expr("version + 1"),
+			}
+			if apply != nil {
+				if err := apply(project, updates); err != nil {
+					return err
+				}
+			}
+			if project.Status == to {
+				continue
+			}
+
+			if err := tx.Model(&models.Project{}).
+				Where("id = ?", project.ID).
+				Updates(updates).Error; err != nil {
+				return errors.NewRepositoryError("UPDATE_FAILED", "failed to update project status", err)
+			}
+			if err := tx.Create(&models.ProjectStatusChange{
+				TenantID:   project.TenantID,
+				ProjectID:  project.ID,
+				FromStatus: project.Status,
+				ToStatus:   to,
+				Reason:     reason,
+			}).Error; err != nil {
+				return errors.NewRepositoryError("CREATE_FAILED", "failed to record project status change", err)
+			}
+
+			event, err := events.NewOutboxEvent(project.TenantID, events.ProjectStatusChange(project, to, reason))
+			if err != nil {
+				return errors.NewRepositoryError("CREATE_FAILED", "failed to build project status event", err)
+			}
+			if err := appendOutbox(tx, []*models.OutboxEvent{event}); err != nil {
+				return errors.NewRepositoryError("CREATE_FAILED", "failed to publish project status change", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:projects:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, projectIDs...)
 
-	return nil
+	return found, nil
 }
 
 // GetProjectStats retrieves comprehensive project statistics for a tenant
 func (r *projectRepository) GetProjectStats(ctx context.Context, tenantID uuid.UUID) (ProjectStats, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	if tenantID == uuid.Nil {
 		return ProjectStats{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	stats := ProjectStats{
-		ByStatus:   make(map[models.ProjectStatus]int64),
-		ByPriority: make(map[models.ProjectPriority]int64),
+	var stats ProjectStats
+	err := r.RepositoryCache().WithTTL(statsCacheTTL).Query(ctx, tenantID, "stats", &stats, func() error {
+		var err error
+		stats, err = r.projectStats(ctx, tenantID)
+		return err
+	})
+	if err != nil {
+		return ProjectStats{}, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to get project stats", err)
 	}
 
-	// Total projects
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("tenant_id = ?", tenantID).
-		Count(&stats.TotalProjects)
-
-	// Active projects (planned or in progress)
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("tenant_id = ? AND status IN (?)",
-			tenantID, []models.ProjectStatus{models.ProjectStatusPlanned, models.ProjectStatusInProgress}).
-		Count(&stats.ActiveProjects)
-
-	// Completed projects
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("tenant_id = ? AND status = ?", tenantID, models.ProjectStatusCompleted).
-		Count(&stats.CompletedProjects)
-
-	// On hold projects
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("tenant_id = ? AND status = ?", tenantID, models.ProjectStatusOnHold).
-		Count(&stats.OnHoldProjects)
-
-	// Cancelled projects
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("tenant_id = ? AND status = ?", tenantID, models.ProjectStatusCancelled).
-		Count(&stats.CancelledProjects)
-
-	// Overdue projects
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("tenant_id = ? AND status NOT IN (?, ?) AND due_date < ? AND due_date IS NOT NULL",
-			tenantID, models.ProjectStatusCompleted, models.ProjectStatusCancelled, time.Now()).
-		Count(&stats.OverdueProjects)
-
-	// Projects by status
-	type StatusCount struct {
-		Status models.ProjectStatus
-		Count  int64
-	}
-	var statusCounts []StatusCount
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Select("status, COUNT(*) as count").
-		Where("tenant_id = ?", tenantID).
-		Group("status").
-		Scan(&statusCounts)
+	return stats, nil
+}
 
-	for _, sc := range statusCounts {
-		stats.ByStatus[sc.Status] = sc.Count
+// projectStats aggregates a tenant's projects with one query for the counts by status
+// and priority and one for the figures across them
+func (r *projectRepository) projectStats(ctx context.Context, tenantID uuid.UUID) (ProjectStats, error) {
+	stats := ProjectStats{
+		ByStatus:   make(map[models.ProjectStatus]int64),
+		ByPriority: make(map[models.ProjectPriority]int64),
 	}
 
-	// Projects by priority
-	type PriorityCount struct {
+	var groups []struct {
+		Status   models.ProjectStatus
 		Priority models.ProjectPriority
 		Count    int64
 	}
-	var priorityCounts []PriorityCount
-	r.db.WithContext(ctx).
+	if err := r.db.WithContext(ctx).
 		Model(&models.Project{}).
-		Select("priority, COUNT(*) as count").
+		Select("status, priority, COUNT(*) AS count").
 		Where("tenant_id = ?", tenantID).
-		Group("priority").
-		Scan(&priorityCounts)
-
-	for _, pc := range priorityCounts {
-		stats.ByPriority[pc.Priority] = pc.Count
+		Group("status, priority").
+		Scan(&groups).Error; err != nil {
+		return stats, err
 	}
 
-	// Average progress
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("tenant_id = ? AND status NOT IN (?)",
-			tenantID, []models.ProjectStatus{models.ProjectStatusCompleted, models.ProjectStatusCancelled}).
-		Select("AVG(progress_percent)").
-		Scan(&stats.AverageProgress)
+	for _, group := range groups {
+		stats.ByStatus[group.Status] += group.Count
+		stats.ByPriority[group.Priority] += group.Count
+		stats.TotalProjects += group.Count
+	}
+	stats.ActiveProjects = stats.ByStatus[models.ProjectStatusPlanned] + stats.ByStatus[models.ProjectStatusInProgress]
+	stats.CompletedProjects = stats.ByStatus[models.ProjectStatusCompleted]
+	stats.OnHoldProjects = stats.ByStatus[models.ProjectStatusOnHold]
+	stats.CancelledProjects = stats.ByStatus[models.ProjectStatusCancelled]
 
-	// Total budget
-	r.db.WithContext(ctx).
+	var totals struct {
+		OverdueProjects        int64
+		AverageProgress        float64
+		TotalBudget            float64
+		OnTimeProjects         int64
+		AverageTasksPerProject float64
+	}
+	closed := []models.ProjectStatus{models.ProjectStatusCompleted, models.ProjectStatusCancelled}
+	if err := r.db.WithContext(ctx).
 		Model(&models.Project{}).
+		Select(`COUNT(*) FILTER (WHERE status NOT IN ? AND due_date < ?) AS overdue_projects,
+			COALESCE(AVG(progress_percent) FILTER (WHERE status NOT IN ?), 0) AS average_progress,
+			COALESCE(SUM(budget_amount), 0) AS total_budget,
+			COUNT(*) FILTER (WHERE status = ? AND completed_at <= due_date) AS on_time_projects,
+			COALESCE(AVG(tasks_total) FILTER (WHERE tasks_total > 0), 0) AS average_tasks_per_project`,
+			closed, time.Now(),
+			closed,
+			models.ProjectStatusCompleted).
 		Where("tenant_id = ?", tenantID).
-		Select("SUM(budget_amount)").
-		Scan(&stats.TotalBudget)
+		Scan(&totals).Error; err != nil {
+		return stats, err
+	}
 
-	// On-time projects (completed before or on due date)
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("tenant_id = ? AND status = ? AND completed_at <= due_date AND due_date IS NOT NULL",
-			tenantID, models.ProjectStatusCompleted).
-		Count(&stats.OnTimeProjects)
+	stats.OverdueProjects = totals.OverdueProjects
+	stats.AverageProgress = totals.AverageProgress
+	stats.TotalBudget = totals.TotalBudget
+	stats.OnTimeProjects = totals.OnTimeProjects
+	stats.AverageTasksPerProject = totals.AverageTasksPerProject
 
 	// Completion rate
 	if stats.TotalProjects > 0 {
 		stats.CompletionRate = (float64(stats.CompletedProjects) / float64(stats.TotalProjects)) * 100
 	}
 
-	// Average tasks per project
-	r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("tenant_id = ? AND tasks_total > 0", tenantID).
-		Select("AVG(tasks_total)").
-		Scan(&stats.AverageTasksPerProject)
-
 	return stats, nil
 }
 
 // GetArtisanProjectStats retrieves artisan-specific project statistics
 func (r *projectRepository) GetArtisanProjectStats(ctx context.Context, artisanID uuid.UUID) (ArtisanProjectStats, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	if artisanID == uuid.Nil {
 		return ArtisanProjectStats{}, errors.NewRepositoryError("INVALID_INPUT", "artisan_id cannot be nil", errors.ErrInvalidInput)
 	}
@@ -984,6 +964,9 @@ func (r *projectRepository) GetArtisanProjectStats(ctx context.Context, artisanI
 
 // GetCustomerProjectStats retrieves customer-specific project statistics
 func (r *projectRepository) GetCustomerProjectStats(ctx context.Context, customerID uuid.UUID) (CustomerProjectStats, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	if customerID == uuid.Nil {
 		return CustomerProjectStats{}, errors.NewRepositoryError("INVALID_INPUT", "customer_id cannot be nil", errors.ErrInvalidInput)
 	}
@@ -1024,59 +1007,85 @@ func (r *projectRepository) GetCustomerProjectStats(ctx context.Context, custome
 	return stats, nil
 }
 
-// GetProjectTimeline retrieves timeline events for a project
-func (r *projectRepository) GetProjectTimeline(ctx context.Context, projectID uuid.UUID) ([]TimelineEvent, error) {
+// projectTimelineQuery merges every event source of a project into one result set.
+// Being raw SQL, it leaves soft-deleted rows out itself.
+const projectTimelineQuery = `
+SELECT p.created_at AS date, 'project_created' AS type, 'Project Created' AS title,
+	p.title AS description, NULL::uuid AS user_id, '' AS user_name,
+	'project' AS source, p.id AS entity_id
+FROM projects p
+WHERE p.id = @project_id AND p.deleted_at IS NULL
+UNION ALL
+SELECT u.created_at, u.type, u.title, COALESCE(u.description, ''), u.user_id,
+	COALESCE(NULLIF(TRIM(usr.first_name || ' ' || usr.last_name), ''), 'System'),
+	'update', u.id
+FROM project_updates u
+LEFT JOIN users usr ON usr.id = u.user_id
+WHERE u.project_id = @project_id AND u.deleted_at IS NULL
+UNION ALL
+SELECT c.created_at, 'status_changed', 'Status Changed: ' || c.from_status || ' to ' || c.to_status,
+	COALESCE(c.reason, ''), NULL::uuid, '', 'status_change', c.id
+FROM project_status_changes c
+WHERE c.project_id = @project_id AND c.deleted_at IS NULL
+UNION ALL
+SELECT m.completed_at, 'milestone_completed', 'Milestone Completed: ' || m.title,
+	COALESCE(m.description, ''), NULL::uuid, '', 'milestone', m.id
+FROM project_milestones m
+WHERE m.project_id = @project_id AND m.deleted_at IS NULL AND m.completed_at IS NOT NULL
+UNION ALL
+SELECT t.completed_at, 'task_completed', 'Task Completed: ' || t.title, '', t.assigned_to_id,
+	COALESCE(TRIM(usr.first_name || ' ' || usr.last_name), ''), 'task', t.id
+FROM project_tasks t
+LEFT JOIN users usr ON usr.id = t.assigned_to_id
+WHERE t.project_id = @project_id AND t.deleted_at IS NULL AND t.status = 'done' AND t.completed_at IS NOT NULL
+UNION ALL
+SELECT e.created_at, 'booking_' || e.event_type,
+	CASE e.event_type
+		WHEN 'created' THEN 'Booking Scheduled'
+		WHEN 'status_changed' THEN 'Booking Status Changed'
+		WHEN 'rescheduled' THEN 'Booking Rescheduled'
+		ELSE 'Booking Updated'
+	END || ': ' || to_char(b.start_time AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:MI') || ' UTC',
+	COALESCE(e.note, ''), e.actor_id,
+	COALESCE(NULLIF(TRIM(usr.first_name || ' ' || usr.last_name), ''), 'System'),
+	'booking', e.id
+FROM booking_events e
+JOIN bookings b ON b.id = e.booking_id
+LEFT JOIN users usr ON usr.id = e.actor_id
+WHERE b.project_id = @project_id AND b.deleted_at IS NULL AND e.deleted_at IS NULL`
+
+// GetProjectTimeline retrieves a page of a project's updates, status changes,
+// milestone and task completions and linked booking changes, most recent first
+func (r *projectRepository) GetProjectTimeline(ctx context.Context, projectID uuid.UUID, pagination PaginationParams) ([]TimelineEvent, PaginationResult, error) {
 	if projectID == uuid.Nil {
-		return nil, errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	var events []TimelineEvent
+	pagination.Validate()
+	args := map[string]any{"project_id": projectID}
 
-	// Get project updates
-	var updates []models.ProjectUpdate
+	var totalItems int64
 	if err := r.db.WithContext(ctx).
-		Preload("User").
-		Where("project_id = ?", projectID).
-		Order("created_at DESC").
-		Limit(50).
-		Find(&updates).Error; err == nil {
-		for _, update := range updates {
-			userName := "System"
-			if update.User != nil {
-				userName = update.User.FirstName + " " + update.User.LastName
-			}
-
-			events = append(events, TimelineEvent{
-				Date:        update.CreatedAt,
-				Type:        string(update.Type),
-				Title:       update.Title,
-				Description: update.Description,
-				UserID:      &update.UserID,
-				UserName:    userName,
-			})
-		}
+		Raw("SELECT COUNT(*) FROM ("+projectTimelineQuery+") AS events", args).
+		Scan(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count timeline events", err)
 	}
 
-	// Get milestone completions
-	var milestones []models.ProjectMilestone
+	args["limit"] = pagination.Limit()
+	args["offset"] = pagination.Offset()
+
+	// Ties are broken by source and ID so pages never overlap
+	var events []TimelineEvent
 	if err := r.db.WithContext(ctx).
-		Where("project_id = ? AND completed_at IS NOT NULL", projectID).
-		Order("completed_at DESC").
-		Find(&milestones).Error; err == nil {
-		for _, milestone := range milestones {
-			events = append(events, TimelineEvent{
-				Date:        *milestone.CompletedAt,
-				Type:        "milestone_completed",
-				Title:       "Milestone Completed: " + milestone.Title,
-				Description: milestone.Description,
-			})
-		}
+		Raw("SELECT * FROM ("+projectTimelineQuery+`) AS events
+			ORDER BY date DESC, source ASC, entity_id ASC
+			LIMIT @limit OFFSET @offset`, args).
+		Scan(&events).Error; err != nil {
+		r.logger.Error("failed to get project timeline", "project_id", projectID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to get project timeline", err)
 	}
 
-	// Sort events by date (most recent first)
-	// In production, use sort.Slice
-
-	return events, nil
+	return events, CalculatePagination(pagination, totalItems), nil
 }
 
 // GetProjectHealth calculates project health metrics
@@ -1096,7 +1105,7 @@ func (r *projectRepository) GetProjectHealth(ctx context.Context, projectID uuid
 	health := ProjectHealth{
 		ProjectID:       projectID,
 		IsOnTrack:       true,
-		RiskLevel:       "low",
+		RiskLevel:       models.ProjectRiskLow,
 		Recommendations: []string{},
 	}
 
@@ -1105,7 +1114,7 @@ func (r *projectRepository) GetProjectHealth(ctx context.Context, projectID uuid
 		project.Status != models.ProjectStatusCompleted {
 		health.IsOverdue = true
 		health.IsOnTrack = false
-		health.RiskLevel = "high"
+		health.RiskLevel = models.ProjectRiskHigh
 		health.Recommendations = append(health.Recommendations, "Project is overdue. Consider reallocating resources.")
 	}
 
@@ -1113,8 +1122,8 @@ func (r *projectRepository) GetProjectHealth(ctx context.Context, projectID uuid
 	health.BlockedTasksCount = project.ActiveBlockedTasks
 	if health.BlockedTasksCount > 0 {
 		health.IsOnTrack = false
-		if health.RiskLevel != "high" {
-			health.RiskLevel = "medium"
+		if health.RiskLevel != models.ProjectRiskHigh {
+			health.RiskLevel = models.ProjectRiskMedium
 		}
 		health.Recommendations = append(health.Recommendations, "Resolve blocked tasks to improve velocity.")
 	}
@@ -1123,7 +1132,7 @@ func (r *projectRepository) GetProjectHealth(ctx context.Context, projectID uuid
 	health.OverdueTasksCount = project.TasksOverdue
 	if health.OverdueTasksCount > 3 {
 		health.IsOnTrack = false
-		health.RiskLevel = "high"
+		health.RiskLevel = models.ProjectRiskHigh
 		health.Recommendations = append(health.Recommendations, "Multiple overdue tasks detected. Review task assignments.")
 	}
 
@@ -1166,6 +1175,24 @@ func (r *projectRepository) GetProjectHealth(ctx context.Context, projectID uuid
 	// Adjust for overdue tasks
 	healthScore -= health.OverdueTasksCount * 5
 
+	// Check the critical path against the due date
+	if project.Status != models.ProjectStatusCompleted && project.Status != models.ProjectStatusCancelled {
+		schedule, err := loadProjectSchedule(r.db.WithContext(ctx), &project, time.Now())
+		if err != nil {
+			r.logger.Warn("failed to schedule project for health check", "project_id", projectID, "error", err)
+		} else {
+			health.CriticalPathHours = schedule.TotalHours
+			health.ProjectedFinish = &schedule.ProjectedFinish
+			if project.DueDate != nil && schedule.ProjectedFinish.After(*project.DueDate) {
+				health.ScheduleDaysLate = int(math.Ceil(schedule.ProjectedFinish.Sub(*project.DueDate).Hours() / 24))
+				health.IsOnTrack = false
+				healthScore -= min(health.ScheduleDaysLate*2, 30)
+				health.Recommendations = append(health.Recommendations, fmt.Sprintf(
+					"Critical path finishes %d day(s) after the due date. Shorten or parallelize critical tasks.", health.ScheduleDaysLate))
+			}
+		}
+	}
+
 	// Ensure health score is in range
 	healthScore = max(0, min(healthScore, 100))
 
@@ -1173,38 +1200,126 @@ func (r *projectRepository) GetProjectHealth(ctx context.Context, projectID uuid
 
 	// Set risk level based on health score
 	if healthScore >= 80 {
-		health.RiskLevel = "low"
+		health.RiskLevel = models.ProjectRiskLow
 	} else if healthScore >= 60 {
-		health.RiskLevel = "medium"
+		health.RiskLevel = models.ProjectRiskMedium
 	} else {
-		health.RiskLevel = "high"
+		health.RiskLevel = models.ProjectRiskHigh
 	}
 
 	return health, nil
 }
 
-// BulkUpdateStatus updates status for multiple projects
-func (r *projectRepository) BulkUpdateStatus(ctx context.Context, projectIDs []uuid.UUID, status models.ProjectStatus) error {
-	if len(projectIDs) == 0 {
-		return nil
+// FindDueForHealthCheck returns active projects whose health was never checked or
+// was last checked before the given time, least recently checked first
+func (r *projectRepository) FindDueForHealthCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*models.Project, error) {
+	var projects []*models.Project
+	if err := r.db.WithContext(ctx).
+		Where("status IN ?", []models.ProjectStatus{
+			models.ProjectStatusPlanned, models.ProjectStatusInProgress, models.ProjectStatusOnHold,
+		}).
+		Where("health_checked_at IS NULL OR health_checked_at < ?", checkedBefore).
+		Order("health_checked_at ASC NULLS FIRST, id ASC").
+		Limit(limit).
+		Find(&projects).Error; err != nil {
+		r.logger.Error("failed to find projects due for health check", "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find projects due for health check", err)
 	}
 
-	result := r.db.WithContext(ctx).
-		Model(&models.Project{}).
-		Where("id IN ?", projectIDs).
-		Update("status", status)
+	return projects, nil
+}
 
-	if result.Error != nil {
-		r.logger.Error("failed to bulk update project status", "count", len(projectIDs), "error", result.Error)
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to bulk update status", result.Error)
+// RecordHealthSnapshot stores a health snapshot and copies its score onto the project.
+// It returns the project's previous snapshot, or nil on its first. It fails with a
+// conflict if the project's health was recorded since the project was loaded, so
+// concurrent runs record and alert once.
+func (r *projectRepository) RecordHealthSnapshot(ctx context.Context, project *models.Project, snapshot *models.ProjectHealthSnapshot) (*models.ProjectHealthSnapshot, error) {
+	if project == nil || snapshot == nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "project and snapshot are required", errors.ErrInvalidInput)
+	}
+
+	var previous *models.ProjectHealthSnapshot
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.Project{}).Where("id = ?", project.ID)
+		if project.HealthCheckedAt == nil {
+			query = query.Where("health_checked_at IS NULL")
+		} else {
+			query = query.Where("health_checked_at = ?", *project.HealthCheckedAt)
+		}
+		result := query.Updates(map[string]any{
+			"health_score":      snapshot.HealthScore,
+			"risk_level":        snapshot.RiskLevel,
+			"health_checked_at": snapshot.RecordedAt,
+		})
+		if result.Error != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to update project health", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewRepositoryError("CONFLICT", "project health was recorded by another run", errors.ErrConflict)
+		}
+
+		var last models.ProjectHealthSnapshot
+		err := tx.Where("project_id = ?", project.ID).Order("recorded_at DESC").First(&last).Error
+		switch {
+		case err == nil:
+			previous = &last
+		case err != gorm.ErrRecordNotFound:
+			return errors.NewRepositoryError("FIND_FAILED", "failed to find previous health snapshot", err)
+		}
+
+		if err := tx.Create(snapshot).Error; err != nil {
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to record health snapshot", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.IsConflict(err) {
+			r.logger.Error("failed to record project health", "project_id", project.ID, "error", err)
+		}
+		return nil, err
 	}
 
+	project.HealthScore = &snapshot.HealthScore
+	project.RiskLevel = snapshot.RiskLevel
+	project.HealthCheckedAt = &snapshot.RecordedAt
+
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:projects:*")
+	r.RepositoryCache().Invalidate(ctx, project.TenantID, project.ID)
+	return previous, nil
+}
+
+// GetHealthHistory returns a project's health snapshots recorded since the given time,
+// oldest first
+func (r *projectRepository) GetHealthHistory(ctx context.Context, projectID uuid.UUID, since time.Time) ([]*models.ProjectHealthSnapshot, error) {
+	if projectID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	r.logger.Info("bulk updated project status", "count", result.RowsAffected, "status", status)
+	var snapshots []*models.ProjectHealthSnapshot
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND recorded_at >= ?", projectID, since).
+		Order("recorded_at ASC").
+		Find(&snapshots).Error; err != nil {
+		r.logger.Error("failed to get project health history", "project_id", projectID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to get project health history", err)
+	}
+
+	return snapshots, nil
+}
+
+// BulkUpdateStatus updates status for multiple projects
+func (r *projectRepository) BulkUpdateStatus(ctx context.Context, projectIDs []uuid.UUID, status models.ProjectStatus) error {
+	if len(projectIDs) == 0 {
+		return nil
+	}
+
+	found, err := r.transitionStatus(ctx, projectIDs, status, "", nil)
+	if err != nil {
+		r.logger.Error("failed to bulk update project status", "count", len(projectIDs), "error", err)
+		return err
+	}
+
+	r.logger.Info("bulk updated project status", "count", found, "status", status)
 	return nil
 }
 
@@ -1220,7 +1335,10 @@ func (r *projectRepository) BulkAssignArtisan(ctx context.Context, projectIDs []
 	result := r.db.WithContext(ctx).
 		Model(&models.Project{}).
 		Where("id IN ?", projectIDs).
-		Update("artisan_id", artisanID)
+		Updates(map[string]any{
+			"artisan_id": artisanID,
+			"version":    gorm.Expr("version + 1"),
+		})
 
 	if result.Error != nil {
 		r.logger.Error("failed to bulk assign artisan", "count", len(projectIDs), "error", result.Error)
@@ -1228,9 +1346,7 @@ func (r *projectRepository) BulkAssignArtisan(ctx context.Context, projectIDs []
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:projects:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, projectIDs...)
 
 	r.logger.Info("bulk assigned artisan to projects", "count", result.RowsAffected, "artisan_id", artisanID)
 	return nil
@@ -1244,7 +1360,7 @@ func (r *projectRepository) ArchiveCompletedProjects(ctx context.Context, tenant
 
 	cutoffDate := time.Now().Add(-olderThan)
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("tenant_id = ? AND status = ? AND completed_at < ?",
 			tenantID, models.ProjectStatusCompleted, cutoffDate).
 		Delete(&models.Project{})
@@ -1255,9 +1371,7 @@ func (r *projectRepository) ArchiveCompletedProjects(ctx context.Context, tenant
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:projects:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, tenantID)
 
 	r.logger.Info("archived completed projects", "tenant_id", tenantID, "count", result.RowsAffected, "older_than", olderThan)
 	return nil
@@ -1442,6 +1556,8 @@ func (r *projectRepository) GetTenantDashboard(ctx context.Context, tenantID uui
 				Description: projectTitle + ": " + update.Description,
 				UserID:      &update.UserID,
 				UserName:    userName,
+				Source:      TimelineSourceUpdate,
+				EntityID:    update.ID,
 			})
 		}
 	}
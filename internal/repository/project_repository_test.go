@@ -0,0 +1,80 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRepository_GetProjectTimeline(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Close()
+
+	repo := repository.NewProjectRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	ctx := context.Background()
+
+	_, tenant := testutil.CreateTestTenantWithOwner(tdb.DB)
+	artisanUser := testutil.CreateTestUser(&tenant.ID, func(u *models.User) {
+		u.Email = "artisan@example.com"
+		u.Role = models.UserRoleArtisan
+	})
+	require.NoError(t, tdb.DB.Create(artisanUser).Error)
+	artisan := testutil.CreateTestArtisan(artisanUser.ID, tenant.ID)
+	require.NoError(t, tdb.DB.Create(artisan).Error)
+
+	project := &models.Project{
+		TenantID:  tenant.ID,
+		ArtisanID: artisan.ID,
+		Title:     "Custom Dining Table",
+		Status:    models.ProjectStatusInProgress,
+	}
+	require.NoError(t, tdb.DB.Create(project).Error)
+
+	completedAt := time.Now().UTC()
+	kept := &models.ProjectTask{
+		TenantID:    tenant.ID,
+		ProjectID:   project.ID,
+		Title:       "Cut the legs",
+		Status:      models.TaskStatusDone,
+		CompletedAt: &completedAt,
+	}
+	deleted := &models.ProjectTask{
+		TenantID:    tenant.ID,
+		ProjectID:   project.ID,
+		Title:       "Sand the top",
+		Status:      models.TaskStatusDone,
+		CompletedAt: &completedAt,
+	}
+	require.NoError(t, tdb.DB.Create(kept).Error)
+	require.NoError(t, tdb.DB.Create(deleted).Error)
+	require.NoError(t, tdb.DB.Delete(deleted).Error)
+
+	t.Run("soft-deleted tasks are left out", func(t *testing.T) {
+		events, page, err := repo.GetProjectTimeline(ctx, project.ID, repository.PaginationParams{Page: 1, PageSize: 20})
+		require.NoError(t, err)
+
+		// The project's creation and the remaining task's completion
+		assert.Equal(t, int64(2), page.TotalItems)
+		require.Len(t, events, 2)
+		for _, event := range events {
+			assert.NotEqual(t, deleted.ID, event.EntityID)
+		}
+	})
+
+	t.Run("soft-deleted projects have no timeline", func(t *testing.T) {
+		require.NoError(t, tdb.DB.Delete(kept).Error)
+		require.NoError(t, tdb.DB.Delete(project).Error)
+
+		events, page, err := repo.GetProjectTimeline(ctx, project.ID, repository.PaginationParams{Page: 1, PageSize: 20})
+		require.NoError(t, err)
+		assert.Zero(t, page.TotalItems)
+		assert.Empty(t, events)
+	})
+}
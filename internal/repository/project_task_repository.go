@@ -43,8 +43,13 @@ type ProjectTaskRepository interface {
 	// Dependencies
 	AddDependency(ctx context.Context, taskID, dependsOnTaskID uuid.UUID) error
 	RemoveDependency(ctx context.Context, taskID, dependsOnTaskID uuid.UUID) error
+	DetachDependencies(ctx context.Context, taskID uuid.UUID) error
+	SyncDependencyStatus(ctx context.Context, taskIDs ...uuid.UUID) error
 	GetDependencies(ctx context.Context, taskID uuid.UUID) ([]*models.ProjectTask, error)
+	GetDependents(ctx context.Context, taskID uuid.UUID) ([]*models.ProjectTask, error)
 	GetBlockedBy(ctx context.Context, taskID uuid.UUID) ([]*models.ProjectTask, error)
+	FindProjectDependencies(ctx context.Context, projectID uuid.UUID) ([]*models.TaskDependency, error)
+	GetProjectSchedule(ctx context.Context, project *models.Project, now time.Time) (*models.ProjectSchedule, error)
 
 	// Checklist Management
 	UpdateChecklist(ctx context.Context, taskID uuid.UUID, checklist []models.ChecklistItem) error
@@ -311,18 +316,14 @@ func (r *projectTaskRepository) UpdateTaskStatus(ctx context.Context, taskID uui
 		return errors.NewRepositoryError("NOT_FOUND", "task not found", errors.ErrNotFound)
 	}
 
-	// Update project progress
-	var task models.ProjectTask
-	if err := r.db.WithContext(ctx).First(&task, taskID).Error; err == nil {
-		// This would call the project repository's UpdateProgress method
-		// For now, we'll just invalidate cache
+	// Unblock or re-block the tasks waiting on this one
+	if err := r.SyncDependencyStatus(ctx, taskID); err != nil {
+		return err
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-		r.cache.DeletePattern(ctx, "repo:projects:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
+	r.RepositoryCache().InvalidateTable(ctx, "projects", uuid.Nil)
 
 	return nil
 }
@@ -358,9 +359,7 @@ func (r *projectTaskRepository) BlockTask(ctx context.Context, taskID uuid.UUID,
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
 
 	return nil
 }
@@ -391,9 +390,7 @@ func (r *projectTaskRepository) UnblockTask(ctx context.Context, taskID uuid.UUI
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
 
 	return nil
 }
@@ -422,9 +419,7 @@ func (r *projectTaskRepository) AssignTask(ctx context.Context, taskID uuid.UUID
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
 
 	return nil
 }
@@ -450,9 +445,7 @@ func (r *projectTaskRepository) UnassignTask(ctx context.Context, taskID uuid.UU
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
 
 	return nil
 }
@@ -478,9 +471,7 @@ func (r *projectTaskRepository) UpdateTrackedHours(ctx context.Context, taskID u
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
 
 	return nil
 }
@@ -506,9 +497,7 @@ func (r *projectTaskRepository) UpdateActualCost(ctx context.Context, taskID uui
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
 
 	return nil
 }
@@ -538,9 +527,7 @@ func (r *projectTaskRepository) LogTimeEntry(ctx context.Context, taskID uuid.UU
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
 
 	return nil
 }
@@ -574,72 +561,222 @@ func (r *projectTaskRepository) GetTaskTimeTracking(ctx context.Context, taskID
 	return summary, nil
 }
 
-// AddDependency adds a dependency relationship between tasks
+// AddDependency makes a task wait on another task of the same project. Dependency
+// changes are serialized per project so concurrent additions cannot close a cycle; a
+// dependency that would close one fails with a conflict.
 func (r *projectTaskRepository) AddDependency(ctx context.Context, taskID, dependsOnTaskID uuid.UUID) error {
 	if taskID == uuid.Nil || dependsOnTaskID == uuid.Nil {
 		return errors.NewRepositoryError("INVALID_INPUT", "task IDs cannot be nil", errors.ErrInvalidInput)
 	}
 
-	// Get current task
-	var task models.ProjectTask
-	if err := r.db.WithContext(ctx).First(&task, taskID).Error; err != nil {
-		return errors.NewRepositoryError("NOT_FOUND", "task not found", errors.ErrNotFound)
-	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var tasks []*models.ProjectTask
+		if err := tx.Where("id IN ?", []uuid.UUID{taskID, dependsOnTaskID}).Find(&tasks).Error; err != nil {
+			return errors.NewRepositoryError("FIND_FAILED", "failed to find tasks", err)
+		}
+		var task, prerequisite *models.ProjectTask
+		for _, t := range tasks {
+			switch t.ID {
+			case taskID:
+				task = t
+			case dependsOnTaskID:
+				prerequisite = t
+			}
+		}
+		if task == nil || prerequisite == nil {
+			return errors.NewRepositoryError("NOT_FOUND", "task not found", errors.ErrNotFound)
+		}
+		if task.ProjectID != prerequisite.ProjectID {
+			return errors.NewRepositoryError("INVALID_INPUT", "tasks belong to different projects", errors.ErrInvalidInput)
+		}
 
-	// Add to depends_on array if not already present
-	dependsOn := task.DependsOn
-	for _, id := range dependsOn {
-		if id == dependsOnTaskID {
-			return nil // Already exists
+		if err := tx.Exec("SELECT id FROM projects WHERE id = ? FOR UPDATE", task.ProjectID).Error; err != nil {
+			return errors.NewRepositoryError("LOCK_FAILED", "failed to lock project", err)
 		}
-	}
-	dependsOn = append(dependsOn, dependsOnTaskID)
 
-	if err := r.db.WithContext(ctx).
-		Model(&task).
-		Update("depends_on", dependsOn).Error; err != nil {
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to add dependency", err)
+		var deps []*models.TaskDependency
+		if err := tx.Where("project_id = ?", task.ProjectID).Find(&deps).Error; err != nil {
+			return errors.NewRepositoryError("FIND_FAILED", "failed to find task dependencies", err)
+		}
+		for _, dep := range deps {
+			if dep.TaskID == taskID && dep.DependsOnTaskID == dependsOnTaskID {
+				return nil // Already exists
+			}
+		}
+		if models.DependencyCreatesCycle(deps, taskID, dependsOnTaskID) {
+			return errors.NewRepositoryError("CONFLICT", "dependency would create a cycle", errors.ErrConflict)
+		}
+
+		if err := tx.Create(&models.TaskDependency{
+			TenantID:        task.TenantID,
+			ProjectID:       task.ProjectID,
+			TaskID:          taskID,
+			DependsOnTaskID: dependsOnTaskID,
+		}).Error; err != nil {
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to add dependency", err)
+		}
+		if err := tx.Model(&models.ProjectTask{}).
+			Where("id = ?", taskID).
+			Update("depends_on", append(task.DependsOn, dependsOnTaskID)).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to add dependency", err)
+		}
+
+		return r.syncDependencyBlocks(tx, []uuid.UUID{taskID})
+	})
+	if err != nil {
+		r.logger.Error("failed to add task dependency", "task_id", taskID, "depends_on", dependsOnTaskID, "error", err)
+		return err
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID, dependsOnTaskID)
 
 	return nil
 }
 
-// RemoveDependency removes a dependency relationship
+// RemoveDependency removes a dependency relationship and unblocks the task if it no
+// longer waits on anything
 func (r *projectTaskRepository) RemoveDependency(ctx context.Context, taskID, dependsOnTaskID uuid.UUID) error {
 	if taskID == uuid.Nil || dependsOnTaskID == uuid.Nil {
 		return errors.NewRepositoryError("INVALID_INPUT", "task IDs cannot be nil", errors.ErrInvalidInput)
 	}
 
-	// Get current task
-	var task models.ProjectTask
-	if err := r.db.WithContext(ctx).First(&task, taskID).Error; err != nil {
-		return errors.NewRepositoryError("NOT_FOUND", "task not found", errors.ErrNotFound)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("task_id = ? AND depends_on_task_id = ?", taskID, dependsOnTaskID).
+			Delete(&models.TaskDependency{})
+		if result.Error != nil {
+			return errors.NewRepositoryError("DELETE_FAILED", "failed to remove dependency", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewRepositoryError("NOT_FOUND", "dependency not found", errors.ErrNotFound)
+		}
+		if err := tx.Model(&models.ProjectTask{}).
+			Where("id = ?", taskID).
+			Update("depends_on", gorm.Expr("array_remove(depends_on, ?)", dependsOnTaskID)).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to remove dependency", err)
+		}
+
+		return r.syncDependencyBlocks(tx, []uuid.UUID{taskID})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID, dependsOnTaskID)
+
+	return nil
+}
+
+// DetachDependencies removes a task from the dependency graph, unblocking the tasks
+// that waited on it
+func (r *projectTaskRepository) DetachDependencies(ctx context.Context, taskID uuid.UUID) error {
+	if taskID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "task_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	// Remove from depends_on array
-	var newDependsOn []uuid.UUID
-	for _, id := range task.DependsOn {
-		if id != dependsOnTaskID {
-			newDependsOn = append(newDependsOn, id)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var dependents []uuid.UUID
+		if err := tx.Model(&models.TaskDependency{}).
+			Where("depends_on_task_id = ?", taskID).
+			Pluck("task_id", &dependents).Error; err != nil {
+			return errors.NewRepositoryError("FIND_FAILED", "failed to find dependent tasks", err)
 		}
+
+		if err := tx.Unscoped().Where("task_id = ? OR depends_on_task_id = ?", taskID, taskID).
+			Delete(&models.TaskDependency{}).Error; err != nil {
+			return errors.NewRepositoryError("DELETE_FAILED", "failed to remove dependencies", err)
+		}
+		if len(dependents) == 0 {
+			return nil
+		}
+		if err := tx.Model(&models.ProjectTask{}).
+			Where("id IN ?", dependents).
+			Update("depends_on", gorm.Expr("array_remove(depends_on, ?)", taskID)).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to remove dependencies", err)
+		}
+
+		return r.syncDependencyBlocks(tx, dependents)
+	})
+	if err != nil {
+		r.logger.Error("failed to detach task dependencies", "task_id", taskID, "error", err)
+		return err
 	}
 
-	if err := r.db.WithContext(ctx).
-		Model(&task).
-		Update("depends_on", newDependsOn).Error; err != nil {
-		return errors.NewRepositoryError("UPDATE_FAILED", "failed to remove dependency", err)
+	// Invalidate cache
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
+
+	return nil
+}
+
+// SyncDependencyStatus re-evaluates the blocked status of a task and of the tasks that
+// depend on it, after its status changed
+func (r *projectTaskRepository) SyncDependencyStatus(ctx context.Context, taskIDs ...uuid.UUID) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var dependents []uuid.UUID
+		if err := tx.Model(&models.TaskDependency{}).
+			Where("depends_on_task_id IN ?", taskIDs).
+			Pluck("task_id", &dependents).Error; err != nil {
+			return errors.NewRepositoryError("FIND_FAILED", "failed to find dependent tasks", err)
+		}
+
+		return r.syncDependencyBlocks(tx, append(dependents, taskIDs...))
+	})
+	if err != nil {
+		r.logger.Error("failed to sync task dependency status", "task_ids", taskIDs, "error", err)
+		return err
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
+
+	return nil
+}
+
+// syncDependencyBlocks recomputes which unfinished tasks each of the given tasks waits
+// on and blocks or unblocks them to match
+func (r *projectTaskRepository) syncDependencyBlocks(tx *gorm.DB, taskIDs []uuid.UUID) error {
+	var tasks []*models.ProjectTask
+	if err := tx.Where("id IN ?", taskIDs).Find(&tasks).Error; err != nil {
+		return errors.NewRepositoryError("FIND_FAILED", "failed to find tasks", err)
 	}
 
+	var rows []struct {
+		TaskID          uuid.UUID
+		DependsOnTaskID uuid.UUID
+	}
+	if err := tx.Table("task_dependencies AS d").
+		Select("d.task_id, d.depends_on_task_id").
+		Joins("JOIN project_tasks p ON p.id = d.depends_on_task_id").
+		Where("d.task_id IN ? AND p.status <> ?", taskIDs, models.TaskStatusDone).
+		Scan(&rows).Error; err != nil {
+		return errors.NewRepositoryError("FIND_FAILED", "failed to find blocking tasks", err)
+	}
+	blockers := make(map[uuid.UUID][]uuid.UUID, len(tasks))
+	for _, row := range rows {
+		blockers[row.TaskID] = append(blockers[row.TaskID], row.DependsOnTaskID)
+	}
+
+	for _, task := range tasks {
+		if !task.ApplyDependencyBlockers(blockers[task.ID]) {
+			continue
+		}
+		if err := tx.Model(&models.ProjectTask{}).
+			Where("id = ?", task.ID).
+			UpdateColumns(map[string]any{
+				"blocked_by":   task.BlockedBy,
+				"status":       task.Status,
+				"block_reason": task.BlockReason,
+				"updated_at":   time.Now(),
+				"version":      gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to update blocked status", err)
+		}
+	}
 	return nil
 }
 
@@ -649,18 +786,11 @@ func (r *projectTaskRepository) GetDependencies(ctx context.Context, taskID uuid
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "task_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	var task models.ProjectTask
-	if err := r.db.WithContext(ctx).First(&task, taskID).Error; err != nil {
-		return nil, errors.NewRepositoryError("NOT_FOUND", "task not found", errors.ErrNotFound)
-	}
-
-	if len(task.DependsOn) == 0 {
-		return []*models.ProjectTask{}, nil
-	}
-
 	var dependencies []*models.ProjectTask
 	if err := r.db.WithContext(ctx).
-		Where("id IN ?", task.DependsOn).
+		Joins("JOIN task_dependencies d ON d.depends_on_task_id = project_tasks.id").
+		Where("d.task_id = ?", taskID).
+		Order("project_tasks.order_index ASC").
 		Find(&dependencies).Error; err != nil {
 		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find dependencies", err)
 	}
@@ -668,24 +798,35 @@ func (r *projectTaskRepository) GetDependencies(ctx context.Context, taskID uuid
 	return dependencies, nil
 }
 
-// GetBlockedBy retrieves all tasks that block a task
-func (r *projectTaskRepository) GetBlockedBy(ctx context.Context, taskID uuid.UUID) ([]*models.ProjectTask, error) {
+// GetDependents retrieves all tasks that depend on a task
+func (r *projectTaskRepository) GetDependents(ctx context.Context, taskID uuid.UUID) ([]*models.ProjectTask, error) {
 	if taskID == uuid.Nil {
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "task_id cannot be nil", errors.ErrInvalidInput)
 	}
 
-	var task models.ProjectTask
-	if err := r.db.WithContext(ctx).First(&task, taskID).Error; err != nil {
-		return nil, errors.NewRepositoryError("NOT_FOUND", "task not found", errors.ErrNotFound)
+	var dependents []*models.ProjectTask
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN task_dependencies d ON d.task_id = project_tasks.id").
+		Where("d.depends_on_task_id = ?", taskID).
+		Order("project_tasks.order_index ASC").
+		Find(&dependents).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find dependent tasks", err)
 	}
 
-	if len(task.BlockedBy) == 0 {
-		return []*models.ProjectTask{}, nil
+	return dependents, nil
+}
+
+// GetBlockedBy retrieves the unfinished tasks a task is waiting on
+func (r *projectTaskRepository) GetBlockedBy(ctx context.Context, taskID uuid.UUID) ([]*models.ProjectTask, error) {
+	if taskID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "task_id cannot be nil", errors.ErrInvalidInput)
 	}
 
 	var blockers []*models.ProjectTask
 	if err := r.db.WithContext(ctx).
-		Where("id IN ?", task.BlockedBy).
+		Joins("JOIN task_dependencies d ON d.depends_on_task_id = project_tasks.id").
+		Where("d.task_id = ? AND project_tasks.status <> ?", taskID, models.TaskStatusDone).
+		Order("project_tasks.order_index ASC").
 		Find(&blockers).Error; err != nil {
 		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find blocking tasks", err)
 	}
@@ -693,6 +834,55 @@ func (r *projectTaskRepository) GetBlockedBy(ctx context.Context, taskID uuid.UU
 	return blockers, nil
 }
 
+// FindProjectDependencies retrieves all dependencies between a project's tasks
+func (r *projectTaskRepository) FindProjectDependencies(ctx context.Context, projectID uuid.UUID) ([]*models.TaskDependency, error) {
+	if projectID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var deps []*models.TaskDependency
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Find(&deps).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find task dependencies", err)
+	}
+
+	return deps, nil
+}
+
+// GetProjectSchedule calculates the critical path schedule of a project's remaining work
+func (r *projectTaskRepository) GetProjectSchedule(ctx context.Context, project *models.Project, now time.Time) (*models.ProjectSchedule, error) {
+	if project == nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "project cannot be nil", errors.ErrInvalidInput)
+	}
+	return loadProjectSchedule(r.db.WithContext(ctx), project, now)
+}
+
+// loadProjectSchedule schedules a project's tasks from now, or from the project start
+// if it has not started yet
+func loadProjectSchedule(db *gorm.DB, project *models.Project, now time.Time) (*models.ProjectSchedule, error) {
+	var tasks []*models.ProjectTask
+	if err := db.Where("project_id = ?", project.ID).
+		Order("order_index ASC, created_at ASC").
+		Find(&tasks).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find project tasks", err)
+	}
+	var deps []*models.TaskDependency
+	if err := db.Where("project_id = ?", project.ID).Find(&deps).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find task dependencies", err)
+	}
+
+	start := now
+	if project.StartDate != nil && project.StartDate.After(now) {
+		start = *project.StartDate
+	}
+	schedule, err := models.ScheduleProject(tasks, deps, start)
+	if err != nil {
+		return nil, errors.NewRepositoryError("SCHEDULE_FAILED", "failed to schedule project", err)
+	}
+	return schedule, nil
+}
+
 // UpdateChecklist updates the entire checklist for a task
 func (r *projectTaskRepository) UpdateChecklist(ctx context.Context, taskID uuid.UUID, checklist []models.ChecklistItem) error {
 	if taskID == uuid.Nil {
@@ -714,9 +904,7 @@ func (r *projectTaskRepository) UpdateChecklist(ctx context.Context, taskID uuid
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
 
 	return nil
 }
@@ -753,9 +941,7 @@ func (r *projectTaskRepository) CompleteChecklistItem(ctx context.Context, taskI
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskID)
 
 	return nil
 }
@@ -986,12 +1172,15 @@ func (r *projectTaskRepository) BulkUpdateStatus(ctx context.Context, taskIDs []
 		return errors.NewRepositoryError("UPDATE_FAILED", "failed to bulk update task status", result.Error)
 	}
 
-	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-		r.cache.DeletePattern(ctx, "repo:projects:*")
+	// Unblock or re-block the tasks waiting on these
+	if err := r.SyncDependencyStatus(ctx, taskIDs...); err != nil {
+		return err
 	}
 
+	// Invalidate cache
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskIDs...)
+	r.RepositoryCache().InvalidateTable(ctx, "projects", uuid.Nil)
+
 	return nil
 }
 
@@ -1015,9 +1204,7 @@ func (r *projectTaskRepository) BulkAssign(ctx context.Context, taskIDs []uuid.U
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, taskIDs...)
 
 	return nil
 }
@@ -1053,9 +1240,7 @@ func (r *projectTaskRepository) ReorderTasks(ctx context.Context, projectID uuid
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:project_tasks:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	return nil
 }
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProjectTemplateRepository defines the interface for project template repository operations
+type ProjectTemplateRepository interface {
+	BaseRepository[models.ProjectTemplate]
+
+	// List returns a tenant's templates matching the filter, by name
+	List(ctx context.Context, filter ProjectTemplateFilter, pagination PaginationParams) ([]*models.ProjectTemplate, PaginationResult, error)
+
+	// CreateProject saves a project instantiated from a template with its milestones,
+	// tasks and task dependencies in one transaction, and counts the template's use
+	CreateProject(ctx context.Context, template *models.ProjectTemplate, instance *models.TemplateProject) error
+}
+
+// ProjectTemplateFilter narrows project template listings
+type ProjectTemplateFilter struct {
+	TenantID uuid.UUID
+	Category string
+	IsActive *bool
+	Search   string // Matches the name or description
+}
+
+// projectTemplateRepository implements ProjectTemplateRepository
+type projectTemplateRepository struct {
+	BaseRepository[models.ProjectTemplate]
+	db     *gorm.DB
+	logger log.AllLogger
+	cache  Cache
+}
+
+// NewProjectTemplateRepository creates a new ProjectTemplateRepository instance
+func NewProjectTemplateRepository(db *gorm.DB, config ...RepositoryConfig) ProjectTemplateRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.ProjectTemplate](db, cfg)
+
+	return &projectTemplateRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+		cache:          cfg.Cache,
+	}
+}
+
+// List retrieves project templates matching the filter
+func (r *projectTemplateRepository) List(ctx context.Context, filter ProjectTemplateFilter, pagination PaginationParams) ([]*models.ProjectTemplate, PaginationResult, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).Model(&models.ProjectTemplate{}).Where("tenant_id = ?", filter.TenantID)
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.Search != "" {
+		pattern := "%" + filter.Search + "%"
+		query = query.Where("name ILIKE ? OR description ILIKE ?", pattern, pattern)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count project templates", err)
+	}
+
+	var templates []*models.ProjectTemplate
+	if err := query.
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("name ASC, id ASC").
+		Find(&templates).Error; err != nil {
+		r.logger.Error("failed to list project templates", "tenant_id", filter.TenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to list project templates", err)
+	}
+
+	return templates, CalculatePagination(pagination, totalItems), nil
+}
+
+// CreateProject creates everything a template instantiated
+func (r *projectTemplateRepository) CreateProject(ctx context.Context, template *models.ProjectTemplate, instance *models.TemplateProject) error {
+	if template == nil || instance == nil || instance.Project == nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "template and project are required", errors.ErrInvalidInput)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(instance.Project).Error; err != nil {
+			r.logger.Error("failed to create project from template", "template_id", template.ID, "error", err)
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to create project", err)
+		}
+		if len(instance.Milestones) > 0 {
+			if err := tx.Create(&instance.Milestones).Error; err != nil {
+				r.logger.Error("failed to create project milestones from template", "template_id", template.ID, "error", err)
+				return errors.NewRepositoryError("CREATE_FAILED", "failed to create project milestones", err)
+			}
+		}
+		if len(instance.Tasks) > 0 {
+			if err := tx.Create(&instance.Tasks).Error; err != nil {
+				r.logger.Error("failed to create project tasks from template", "template_id", template.ID, "error", err)
+				return errors.NewRepositoryError("CREATE_FAILED", "failed to create project tasks", err)
+			}
+		}
+		if len(instance.Dependencies) > 0 {
+			if err := tx.Create(&instance.Dependencies).Error; err != nil {
+				r.logger.Error("failed to create task dependencies from template", "template_id", template.ID, "error", err)
+				return errors.NewRepositoryError("CREATE_FAILED", "failed to create task dependencies", err)
+			}
+		}
+
+		now := time.Now()
+		if err := tx.Model(&models.ProjectTemplate{}).
+			Where("id = ?", template.ID).
+			UpdateColumns(map[string]interface{}{
+				"usage_count":  gorm.Expr("usage_count + 1"),
+				"last_used_at": now,
+			}).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to record template usage", err)
+		}
+		template.UsageCount++
+		template.LastUsedAt = &now
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	taskIDs := make([]uuid.UUID, len(instance.Tasks))
+	for i, task := range instance.Tasks {
+		taskIDs[i] = task.ID
+	}
+	r.RepositoryCache().InvalidateTable(ctx, "projects", instance.Project.TenantID, instance.Project.ID)
+	r.RepositoryCache().InvalidateTable(ctx, "project_tasks", instance.Project.TenantID, taskIDs...)
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PromoCodeRepository interface {
@@ -29,6 +30,12 @@ type PromoCodeRepository interface {
 	GetUserUsageCount(ctx context.Context, promoCodeID, userID uuid.UUID) (int, error)
 	CanUserUsePromoCode(ctx context.Context, promoCodeID, userID uuid.UUID) (bool, error)
 
+	// Redeem records the code's use on a booking, enforcing the total and per-user limits
+	// under a row lock so concurrent bookings cannot exceed them
+	Redeem(ctx context.Context, redemption *models.PromoCodeRedemption) error
+	// ReleaseRedemption gives back the use recorded for a booking, if any
+	ReleaseRedemption(ctx context.Context, bookingID uuid.UUID) error
+
 	// Status Operations
 	Activate(ctx context.Context, promoCodeID uuid.UUID) error
 	Deactivate(ctx context.Context, promoCodeID uuid.UUID) error
@@ -266,9 +273,15 @@ func (r *promoCodeRepository) IncrementUserUsage(ctx context.Context, promoCodeI
 }
 
 func (r *promoCodeRepository) GetUserUsageCount(ctx context.Context, promoCodeID, userID uuid.UUID) (int, error) {
-	// This would query a promo_code_usage table
-	// Placeholder implementation
-	return 0, nil
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.PromoCodeRedemption{}).
+		Where("promo_code_id = ? AND user_id = ?", promoCodeID, userID).
+		Count(&count).Error; err != nil {
+		return 0, errors.NewRepositoryError("COUNT_FAILED", "failed to count promo code redemptions", err)
+	}
+
+	return int(count), nil
 }
 
 func (r *promoCodeRepository) CanUserUsePromoCode(ctx context.Context, promoCodeID, userID uuid.UUID) (bool, error) {
@@ -289,6 +302,83 @@ func (r *promoCodeRepository) CanUserUsePromoCode(ctx context.Context, promoCode
 	return usageCount < promoCode.MaxUsesPerUser, nil
 }
 
+func (r *promoCodeRepository) Redeem(ctx context.Context, redemption *models.PromoCodeRedemption) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var promoCode models.PromoCode
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&promoCode, "id = ?", redemption.PromoCodeID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.NewRepositoryError("NOT_FOUND", "promo code not found", errors.ErrNotFound)
+			}
+			return errors.NewRepositoryError("GET_FAILED", "failed to load promo code", err)
+		}
+
+		if !promoCode.IsValid() {
+			return errors.NewRepositoryError("INVALID_PROMO", "promo code is no longer valid", errors.ErrInvalidInput)
+		}
+
+		if promoCode.MaxUsesPerUser > 0 {
+			var used int64
+			if err := tx.Model(&models.PromoCodeRedemption{}).
+				Where("promo_code_id = ? AND user_id = ?", promoCode.ID, redemption.UserID).
+				Count(&used).Error; err != nil {
+				return errors.NewRepositoryError("COUNT_FAILED", "failed to count promo code redemptions", err)
+			}
+			if int(used) >= promoCode.MaxUsesPerUser {
+				return errors.NewRepositoryError("INVALID_PROMO", "promo code already used the maximum number of times", errors.ErrInvalidInput)
+			}
+		}
+
+		if err := tx.Create(redemption).Error; err != nil {
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to record promo code redemption", err)
+		}
+
+		if err := tx.Model(&promoCode).
+			UpdateColumn("used_count", gorm.Expr("used_count + ?", 1)).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to increment usage", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.InvalidateCache(ctx, redemption.PromoCodeID)
+	return nil
+}
+
+func (r *promoCodeRepository) ReleaseRedemption(ctx context.Context, bookingID uuid.UUID) error {
+	var redemption models.PromoCodeRedemption
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&redemption, "booking_id = ?", bookingID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return errors.NewRepositoryError("GET_FAILED", "failed to load promo code redemption", err)
+		}
+
+		if err := tx.Unscoped().Delete(&redemption).Error; err != nil {
+			return errors.NewRepositoryError("DELETE_FAILED", "failed to release promo code redemption", err)
+		}
+
+		if err := tx.Model(&models.PromoCode{}).
+			Where("id = ?", redemption.PromoCodeID).
+			UpdateColumn("used_count", gorm.Expr("GREATEST(used_count - 1, 0)")).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to decrement usage", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if redemption.PromoCodeID != uuid.Nil {
+		r.InvalidateCache(ctx, redemption.PromoCodeID)
+	}
+	return nil
+}
+
 //------------------------------------------------------------
 // Status Operations
 //------------------------------------------------------------
@@ -470,12 +560,26 @@ func (r *promoCodeRepository) GetPromoCodeStats(ctx context.Context, promoCodeID
 		stats.DaysUntilExpiry = -1 // no expiry
 	}
 
-	// Additional stats would come from bookings/payments tables
-	// Placeholder values
-	stats.UniqueUsers = promoCode.UsedCount
-	stats.TotalDiscount = 0
-	stats.AverageDiscount = 0
-	stats.TotalRevenue = 0
+	var usage struct {
+		UniqueUsers   int
+		TotalDiscount float64
+		TotalRevenue  float64
+		Redemptions   int
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.PromoCodeRedemption{}).
+		Select("COUNT(DISTINCT user_id) AS unique_users, COALESCE(SUM(discount_amount), 0) AS total_discount, COALESCE(SUM(order_amount - discount_amount), 0) AS total_revenue, COUNT(*) AS redemptions").
+		Where("promo_code_id = ?", promoCodeID).
+		Scan(&usage).Error; err != nil {
+		return PromoCodeStats{}, errors.NewRepositoryError("QUERY_FAILED", "failed to aggregate promo code redemptions", err)
+	}
+
+	stats.UniqueUsers = usage.UniqueUsers
+	stats.TotalDiscount = usage.TotalDiscount
+	stats.TotalRevenue = usage.TotalRevenue
+	if usage.Redemptions > 0 {
+		stats.AverageDiscount = usage.TotalDiscount / float64(usage.Redemptions)
+	}
 	stats.ConversionRate = 0
 
 	return stats, nil
@@ -613,7 +717,7 @@ func (r *promoCodeRepository) BulkDelete(ctx context.Context, promoCodeIDs []uui
 		return nil
 	}
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("id IN ?", promoCodeIDs).
 		Delete(&models.PromoCode{})
 
@@ -674,16 +778,3 @@ func (r *promoCodeRepository) applyPromoCodeFilters(query *gorm.DB, filters Prom
 
 	return query
 }
-
-func (r *promoCodeRepository) InvalidateCache(ctx context.Context, promoCodeID uuid.UUID) error {
-	if r.cache != nil {
-		cacheKey := fmt.Sprintf("promo_code:%s", promoCodeID.String())
-		if err := r.cache.Delete(ctx, cacheKey); err != nil {
-			if r.logger != nil {
-				r.logger.Warnf("Failed to invalidate cache for promo code %s: %v", promoCodeID, err)
-			}
-			return err
-		}
-	}
-	return nil
-}
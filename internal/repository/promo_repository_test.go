@@ -0,0 +1,87 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromoCodeRepository_Redeem(t *testing.T) {
+	tdb, _, booking := setupPaymentTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	repo := repository.NewPromoCodeRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+
+	promo := &models.PromoCode{
+		TenantID:       &booking.TenantID,
+		Code:           "SPRING10",
+		Type:           models.DiscountTypePercentage,
+		Value:          10,
+		StartsAt:       time.Now().Add(-time.Hour),
+		MaxUses:        2,
+		MaxUsesPerUser: 1,
+		IsActive:       true,
+	}
+	require.NoError(t, repo.Create(ctx, promo))
+
+	redemption := func(userID uuid.UUID) *models.PromoCodeRedemption {
+		return &models.PromoCodeRedemption{
+			PromoCodeID:    promo.ID,
+			TenantID:       booking.TenantID,
+			UserID:         userID,
+			BookingID:      uuid.New(),
+			OrderAmount:    100,
+			DiscountAmount: 10,
+		}
+	}
+
+	first := redemption(booking.CustomerID)
+	require.NoError(t, repo.Redeem(ctx, first))
+
+	t.Run("per-user limit", func(t *testing.T) {
+		assert.Error(t, repo.Redeem(ctx, redemption(booking.CustomerID)))
+
+		canUse, err := repo.CanUserUsePromoCode(ctx, promo.ID, booking.CustomerID)
+		require.NoError(t, err)
+		assert.False(t, canUse)
+	})
+
+	t.Run("total limit", func(t *testing.T) {
+		require.NoError(t, repo.Redeem(ctx, redemption(uuid.New())))
+		assert.Error(t, repo.Redeem(ctx, redemption(uuid.New())))
+
+		saved, err := repo.GetByID(ctx, promo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, saved.UsedCount)
+	})
+
+	t.Run("release gives the use back", func(t *testing.T) {
+		require.NoError(t, repo.ReleaseRedemption(ctx, first.BookingID))
+		require.NoError(t, repo.ReleaseRedemption(ctx, first.BookingID))
+
+		saved, err := repo.GetByID(ctx, promo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, saved.UsedCount)
+
+		canUse, err := repo.CanUserUsePromoCode(ctx, promo.ID, booking.CustomerID)
+		require.NoError(t, err)
+		assert.True(t, canUse)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		stats, err := repo.GetPromoCodeStats(ctx, promo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.UniqueUsers)
+		assert.InDelta(t, 10, stats.TotalDiscount, 0.001)
+		assert.InDelta(t, 90, stats.TotalRevenue, 0.001)
+	})
+}
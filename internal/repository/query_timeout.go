@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// OperationAnalytics is the operation of reporting and statistics queries, which are
+// given a longer budget than ordinary reads and writes
+const OperationAnalytics = "analytics"
+
+// QueryTimeouts bounds how long repository operations may run
+type QueryTimeouts struct {
+	Default            time.Duration            // Budget of operations without their own
+	Operations         map[string]time.Duration // Budget per operation, e.g. "find" or OperationAnalytics
+	SlowQueryThreshold time.Duration            // Statements slower than this are logged
+}
+
+// Budget returns how long an operation may run, or zero when it is unbounded
+func (t QueryTimeouts) Budget(operation string) time.Duration {
+	if budget, ok := t.Operations[operation]; ok {
+		return budget
+	}
+	return t.Default
+}
+
+// queryOperationKey is the context key of the operation a statement belongs to
+type queryOperationKey struct{}
+
+// queryOperation is the repository operation a statement runs for and its budget
+type queryOperation struct {
+	name   string
+	budget time.Duration
+}
+
+// WithTimeout bounds ctx by the operation's budget. A deadline the caller already set
+// is kept when it is sooner. The operation is recorded in the context so slow
+// statements can be attributed to it.
+func (t QueryTimeouts) WithTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	budget := t.Budget(operation)
+	ctx = context.WithValue(ctx, queryOperationKey{}, queryOperation{name: operation, budget: budget})
+	if budget <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, budget)
+}
+
+// operationFromContext returns the operation recorded by WithTimeout
+func operationFromContext(ctx context.Context) (queryOperation, bool) {
+	if ctx == nil {
+		return queryOperation{}, false
+	}
+	operation, ok := ctx.Value(queryOperationKey{}).(queryOperation)
+	return operation, ok
+}
+
+const (
+	queryStartKey  = "query_monitor:start"
+	queryBudgetKey = "query_monitor:budget"
+)
+
+// statementBudget is the default budget given to one statement and the context it replaced
+type statementBudget struct {
+	original context.Context
+	cancel   context.CancelFunc
+}
+
+// queryMonitor times statements, bounds the ones no repository operation bounded and
+// reports the ones that are slow or over budget
+type queryMonitor struct {
+	timeouts QueryTimeouts
+	logger   log.AllLogger
+	metrics  MetricsCollector
+}
+
+// RegisterQueryMonitor installs callbacks on db that log statements slower than the
+// slow query threshold and record the ones that ran past their operation's budget.
+// Statements issued outside a repository operation get the default budget. Register
+// it once per database handle.
+func RegisterQueryMonitor(db *gorm.DB, timeouts QueryTimeouts, logger log.AllLogger, metrics MetricsCollector) error {
+	monitor := &queryMonitor{timeouts: timeouts, logger: logger, metrics: metrics}
+	callbacks := db.Callback()
+
+	// Writes open their transaction with the statement's context, so the budget is
+	// applied before it begins and released once it ends
+	if err := callbacks.Create().Before("gorm:begin_transaction").Register("query_monitor:start", monitor.start(true)); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:commit_or_rollback_transaction").Register("query_monitor:finish", monitor.finish); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:begin_transaction").Register("query_monitor:start", monitor.start(true)); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:commit_or_rollback_transaction").Register("query_monitor:finish", monitor.finish); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:begin_transaction").Register("query_monitor:start", monitor.start(true)); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:commit_or_rollback_transaction").Register("query_monitor:finish", monitor.finish); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("query_monitor:start", monitor.start(true)); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register("query_monitor:finish", monitor.finish); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("query_monitor:start", monitor.start(true)); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().After("gorm:raw").Register("query_monitor:finish", monitor.finish); err != nil {
+		return err
+	}
+	// The caller reads the rows after the callbacks return, so a deadline of our own
+	// would cancel them; only the time to the first row is measured
+	if err := callbacks.Row().Before("gorm:row").Register("query_monitor:start", monitor.start(false)); err != nil {
+		return err
+	}
+	return callbacks.Row().After("gorm:row").Register("query_monitor:finish", monitor.finish)
+}
+
+// start records when the statement began and, when bound is set, gives a statement
+// that no operation bounded the default budget
+func (m *queryMonitor) start(bound bool) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.DryRun {
+			return
+		}
+		db.InstanceSet(queryStartKey, time.Now())
+
+		ctx := db.Statement.Context
+		if _, ok := operationFromContext(ctx); ok || !bound || m.timeouts.Default <= 0 {
+			return
+		}
+		if _, ok := ctx.Deadline(); ok {
+			return
+		}
+		bounded, cancel := m.timeouts.WithTimeout(ctx, "")
+		db.InstanceSet(queryBudgetKey, &statementBudget{original: ctx, cancel: cancel})
+		db.Statement.Context = bounded
+	}
+}
+
+// finish releases the statement's own budget and reports it if it was slow or over budget
+func (m *queryMonitor) finish(db *gorm.DB) {
+	value, ok := db.InstanceGet(queryStartKey)
+	if !ok {
+		return
+	}
+	started, ok := value.(time.Time)
+	if !ok || started.IsZero() {
+		return
+	}
+	db.InstanceSet(queryStartKey, time.Time{})
+	elapsed := time.Since(started)
+
+	operation, _ := operationFromContext(db.Statement.Context)
+	if value, ok := db.InstanceGet(queryBudgetKey); ok {
+		// A chained query may run again on the same statement, so it gets its context back
+		if budget, ok := value.(*statementBudget); ok && budget != nil {
+			budget.cancel()
+			db.Statement.Context = budget.original
+			db.InstanceSet(queryBudgetKey, (*statementBudget)(nil))
+		}
+	}
+	name := operation.name
+	if name == "" {
+		name = "statement"
+	}
+	table := db.Statement.Table
+
+	if m.timeouts.SlowQueryThreshold > 0 && elapsed >= m.timeouts.SlowQueryThreshold {
+		if m.logger != nil {
+			m.logger.Warn("slow query", "operation", name, "table", table, "duration", elapsed, "sql", db.Statement.SQL.String())
+		}
+		if m.metrics != nil {
+			m.metrics.RecordSlowQuery(name, table, elapsed)
+		}
+	}
+
+	if (operation.budget > 0 && elapsed > operation.budget) || isTimeoutError(db.Error) {
+		if m.logger != nil {
+			m.logger.Warn("query exceeded its time budget", "operation", name, "table", table, "duration", elapsed, "budget", operation.budget, "error", db.Error)
+		}
+		if m.metrics != nil {
+			m.metrics.RecordQueryOverBudget(name, table)
+		}
+	}
+}
+
+// isTimeoutError reports whether a statement was cancelled for running too long, by
+// its context or by the server's statement_timeout
+func isTimeoutError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "57014" // query_canceled
+}
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTimeouts(t *testing.T) {
+	timeouts := repository.QueryTimeouts{
+		Default:    5 * time.Second,
+		Operations: map[string]time.Duration{repository.OperationAnalytics: 30 * time.Second},
+	}
+
+	assert.Equal(t, 5*time.Second, timeouts.Budget("find"))
+	assert.Equal(t, 30*time.Second, timeouts.Budget(repository.OperationAnalytics))
+
+	t.Run("bounds the context by the operation's budget", func(t *testing.T) {
+		ctx, cancel := timeouts.WithTimeout(context.Background(), repository.OperationAnalytics)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(30*time.Second), deadline, time.Second)
+	})
+
+	t.Run("keeps a sooner deadline", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(), time.Second)
+		defer cancelParent()
+		parentDeadline, _ := parent.Deadline()
+
+		ctx, cancel := timeouts.WithTimeout(parent, "find")
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.Equal(t, parentDeadline, deadline)
+	})
+
+	t.Run("leaves operations unbounded without a budget", func(t *testing.T) {
+		ctx, cancel := repository.QueryTimeouts{}.WithTimeout(context.Background(), "find")
+		defer cancel()
+
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+	})
+}
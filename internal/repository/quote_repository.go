@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuoteRepository defines the interface for quote repository operations
+type QuoteRepository interface {
+	BaseRepository[models.Quote]
+
+	// List returns a tenant's quotes matching the filter, newest first
+	List(ctx context.Context, filter QuoteFilter, pagination PaginationParams) ([]*models.Quote, PaginationResult, error)
+
+	// Send saves a quote that was just sent together with its revision
+	Send(ctx context.Context, quote *models.Quote, revision *models.QuoteRevision) error
+
+	// FindRevisions returns a quote's sent revisions, oldest first
+	FindRevisions(ctx context.Context, quoteID uuid.UUID) ([]*models.QuoteRevision, error)
+
+	// Convert saves an accepted quote together with the project and tasks it became and
+	// its optional deposit invoice, in one transaction. It fails with a conflict if the
+	// quote was answered or revised in the meantime.
+	Convert(ctx context.Context, quote *models.Quote, project *models.Project, tasks []*models.ProjectTask, deposit *models.Invoice) error
+
+	// ExpireSent marks sent quotes whose validity ended before now as expired
+	ExpireSent(ctx context.Context, now time.Time) (int64, error)
+}
+
+// QuoteFilter narrows quote listings
+type QuoteFilter struct {
+	TenantID   uuid.UUID
+	ArtisanID  *uuid.UUID
+	CustomerID *uuid.UUID
+	Statuses   []models.QuoteStatus
+}
+
+// quoteRepository implements QuoteRepository
+type quoteRepository struct {
+	BaseRepository[models.Quote]
+	db     *gorm.DB
+	logger log.AllLogger
+	cache  Cache
+}
+
+// NewQuoteRepository creates a new QuoteRepository instance
+func NewQuoteRepository(db *gorm.DB, config ...RepositoryConfig) QuoteRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.Quote](db, cfg)
+
+	return &quoteRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+		cache:          cfg.Cache,
+	}
+}
+
+// List retrieves quotes matching the filter
+func (r *quoteRepository) List(ctx context.Context, filter QuoteFilter, pagination PaginationParams) ([]*models.Quote, PaginationResult, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).Model(&models.Quote{}).Where("tenant_id = ?", filter.TenantID)
+	if filter.ArtisanID != nil {
+		query = query.Where("artisan_id = ?", *filter.ArtisanID)
+	}
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count quotes", err)
+	}
+
+	var quotes []*models.Quote
+	if err := query.
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("created_at DESC, id DESC").
+		Find(&quotes).Error; err != nil {
+		r.logger.Error("failed to list quotes", "tenant_id", filter.TenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to list quotes", err)
+	}
+
+	return quotes, CalculatePagination(pagination, totalItems), nil
+}
+
+// Send saves the sent quote and records its revision
+func (r *quoteRepository) Send(ctx context.Context, quote *models.Quote, revision *models.QuoteRevision) error {
+	if quote == nil || revision == nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "quote and revision are required", errors.ErrInvalidInput)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.saveIfUnchanged(tx, quote, models.QuoteStatusDraft); err != nil {
+			return err
+		}
+		if err := tx.Create(revision).Error; err != nil {
+			r.logger.Error("failed to record quote revision", "quote_id", quote.ID, "revision", revision.Revision, "error", err)
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to record quote revision", err)
+		}
+		return nil
+	})
+}
+
+// FindRevisions retrieves a quote's revision history
+func (r *quoteRepository) FindRevisions(ctx context.Context, quoteID uuid.UUID) ([]*models.QuoteRevision, error) {
+	if quoteID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "quote_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var revisions []*models.QuoteRevision
+	if err := r.db.WithContext(ctx).
+		Where("quote_id = ?", quoteID).
+		Order("revision ASC").
+		Find(&revisions).Error; err != nil {
+		r.logger.Error("failed to find quote revisions", "quote_id", quoteID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find quote revisions", err)
+	}
+
+	return revisions, nil
+}
+
+// Convert creates the project, tasks and deposit invoice of an accepted quote
+func (r *quoteRepository) Convert(ctx context.Context, quote *models.Quote, project *models.Project, tasks []*models.ProjectTask, deposit *models.Invoice) error {
+	if quote == nil || project == nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "quote and project are required", errors.ErrInvalidInput)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(project).Error; err != nil {
+			r.logger.Error("failed to create project from quote", "quote_id", quote.ID, "error", err)
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to create project", err)
+		}
+		for _, task := range tasks {
+			task.ProjectID = project.ID
+		}
+		if len(tasks) > 0 {
+			if err := tx.Create(&tasks).Error; err != nil {
+				r.logger.Error("failed to create project tasks from quote", "quote_id", quote.ID, "error", err)
+				return errors.NewRepositoryError("CREATE_FAILED", "failed to create project tasks", err)
+			}
+		}
+		quote.ProjectID = &project.ID
+
+		if deposit != nil {
+			n, err := nextInvoiceNumber(tx, deposit.TenantID)
+			if err != nil {
+				return errors.NewRepositoryError("GENERATION_FAILED", "failed to generate invoice number", err)
+			}
+			deposit.InvoiceNumber = models.FormatInvoiceNumber(n)
+			if err := tx.Create(deposit).Error; err != nil {
+				r.logger.Error("failed to create deposit invoice", "quote_id", quote.ID, "error", err)
+				return errors.NewRepositoryError("CREATE_FAILED", "failed to create deposit invoice", err)
+			}
+			quote.DepositInvoiceID = &deposit.ID
+		}
+
+		return r.saveIfUnchanged(tx, quote, models.QuoteStatusSent)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	taskIDs := make([]uuid.UUID, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+	r.RepositoryCache().InvalidateTable(ctx, "projects", project.TenantID, project.ID)
+	r.RepositoryCache().InvalidateTable(ctx, "project_tasks", project.TenantID, taskIDs...)
+	if deposit != nil {
+		r.RepositoryCache().InvalidateTable(ctx, "invoices", deposit.TenantID, deposit.ID)
+	}
+	return nil
+}
+
+// saveIfUnchanged saves the quote only if it still has the status and version it was
+// loaded with, so concurrent answers and revisions cannot both win
+func (r *quoteRepository) saveIfUnchanged(tx *gorm.DB, quote *models.Quote, loadedStatus models.QuoteStatus) error {
+	loadedVersion := quote.Version
+	quote.Version++
+	quote.UpdatedAt = time.Now()
+
+	result := tx.Model(&models.Quote{}).
+		Where("id = ? AND version = ? AND status = ?", quote.ID, loadedVersion, loadedStatus).
+		Select("*").
+		Omit("id", "created_at").
+		Updates(quote)
+	if result.Error != nil {
+		quote.Version = loadedVersion
+		r.logger.Error("failed to save quote", "quote_id", quote.ID, "error", result.Error)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to save quote", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		quote.Version = loadedVersion
+		return errors.NewRepositoryError("CONFLICT", "quote was changed by another request", errors.ErrConflict)
+	}
+	return nil
+}
+
+// ExpireSent expires unanswered quotes
+func (r *quoteRepository) ExpireSent(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Quote{}).
+		Where("status = ? AND valid_until < ?", models.QuoteStatusSent, now).
+		UpdateColumns(map[string]any{
+			"status":     models.QuoteStatusExpired,
+			"updated_at": now,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		r.logger.Error("failed to expire quotes", "error", result.Error)
+		return 0, errors.NewRepositoryError("UPDATE_FAILED", "failed to expire quotes", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+// ReconciliationRepository defines the interface for payment reconciliation reports
+type ReconciliationRepository interface {
+	BaseRepository[models.ReconciliationReport]
+
+	// ListByDate returns the reports for a day, one per provider
+	ListByDate(ctx context.Context, date time.Time) ([]*models.ReconciliationReport, error)
+
+	// Save stores a report, replacing any earlier run for the same day and provider
+	Save(ctx context.Context, report *models.ReconciliationReport) error
+}
+
+// reconciliationRepository implements ReconciliationRepository
+type reconciliationRepository struct {
+	BaseRepository[models.ReconciliationReport]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewReconciliationRepository creates a new ReconciliationRepository instance
+func NewReconciliationRepository(db *gorm.DB, config ...RepositoryConfig) ReconciliationRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.ReconciliationReport](db, cfg)
+
+	return &reconciliationRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// ListByDate retrieves a day's reports ordered by provider
+func (r *reconciliationRepository) ListByDate(ctx context.Context, date time.Time) ([]*models.ReconciliationReport, error) {
+	var reports []*models.ReconciliationReport
+	if err := r.db.WithContext(ctx).
+		Where("date = ?", date.Format(time.DateOnly)).
+		Order("provider ASC").
+		Find(&reports).Error; err != nil {
+		r.logger.Error("failed to list reconciliation reports", "date", date, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to list reconciliation reports", err)
+	}
+	return reports, nil
+}
+
+// Save replaces the day's report for the provider in one transaction
+func (r *reconciliationRepository) Save(ctx context.Context, report *models.ReconciliationReport) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.ReconciliationReport
+		err := tx.Where("date = ? AND provider = ?", report.Date.Format(time.DateOnly), report.Provider).First(&existing).Error
+		switch {
+		case err == nil:
+			report.ID = existing.ID
+			report.CreatedAt = existing.CreatedAt
+			return tx.Save(report).Error
+		case err == gorm.ErrRecordNotFound:
+			return tx.Create(report).Error
+		default:
+			return err
+		}
+	})
+	if err != nil {
+		r.logger.Error("failed to save reconciliation report", "date", report.Date, "provider", report.Provider, "error", err)
+		return errors.NewRepositoryError("SAVE_FAILED", "failed to save reconciliation report", err)
+	}
+	return nil
+}
@@ -393,9 +393,7 @@ func (r *reportRepository) UpdateStatus(ctx context.Context, reportID uuid.UUID,
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportID)
 
 	return nil
 }
@@ -421,9 +419,7 @@ func (r *reportRepository) MarkAsGenerating(ctx context.Context, reportID uuid.U
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportID)
 
 	return nil
 }
@@ -456,9 +452,7 @@ func (r *reportRepository) MarkAsCompleted(ctx context.Context, reportID uuid.UU
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportID)
 
 	r.logger.Info("report completed", "report_id", reportID, "file_url", fileURL)
 	return nil
@@ -490,9 +484,7 @@ func (r *reportRepository) MarkAsFailed(ctx context.Context, reportID uuid.UUID,
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportID)
 
 	r.logger.Warn("report failed", "report_id", reportID, "error", errorMessage)
 	return nil
@@ -539,9 +531,7 @@ func (r *reportRepository) UpdateNextScheduledRun(ctx context.Context, reportID
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportID)
 
 	return nil
 }
@@ -566,9 +556,7 @@ func (r *reportRepository) EnableSchedule(ctx context.Context, reportID uuid.UUI
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportID)
 
 	return nil
 }
@@ -593,9 +581,7 @@ func (r *reportRepository) DisableSchedule(ctx context.Context, reportID uuid.UU
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportID)
 
 	return nil
 }
@@ -646,9 +632,7 @@ func (r *reportRepository) RetryFailedReport(ctx context.Context, reportID uuid.
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportID)
 
 	r.logger.Info("report queued for retry", "report_id", reportID)
 	return nil
@@ -656,6 +640,9 @@ func (r *reportRepository) RetryFailedReport(ctx context.Context, reportID uuid.
 
 // GetReportStats retrieves comprehensive report statistics for a tenant
 func (r *reportRepository) GetReportStats(ctx context.Context, tenantID uuid.UUID) (ReportStats, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	if tenantID == uuid.Nil {
 		return ReportStats{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
 	}
@@ -791,6 +778,9 @@ func (r *reportRepository) GetReportStats(ctx context.Context, tenantID uuid.UUI
 
 // GetReportTypeUsage retrieves report type usage over time
 func (r *reportRepository) GetReportTypeUsage(ctx context.Context, tenantID uuid.UUID, days int) ([]ReportTypeUsage, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	if tenantID == uuid.Nil {
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
 	}
@@ -823,6 +813,9 @@ func (r *reportRepository) GetReportTypeUsage(ctx context.Context, tenantID uuid
 
 // GetUserReportActivity retrieves report activity for a user
 func (r *reportRepository) GetUserReportActivity(ctx context.Context, userID uuid.UUID) (UserReportActivity, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	if userID == uuid.Nil {
 		return UserReportActivity{}, errors.NewRepositoryError("INVALID_INPUT", "user_id cannot be nil", errors.ErrInvalidInput)
 	}
@@ -901,6 +894,9 @@ func (r *reportRepository) GetUserReportActivity(ctx context.Context, userID uui
 
 // GetReportGenerationMetrics retrieves report generation metrics
 func (r *reportRepository) GetReportGenerationMetrics(ctx context.Context, tenantID uuid.UUID, days int) (ReportGenerationMetrics, error) {
+	ctx, cancel := r.WithQueryTimeout(ctx, OperationAnalytics)
+	defer cancel()
+
 	if tenantID == uuid.Nil {
 		return ReportGenerationMetrics{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
 	}
@@ -1005,7 +1001,7 @@ func (r *reportRepository) DeleteOldReports(ctx context.Context, tenantID uuid.U
 
 	cutoffDate := time.Now().Add(-olderThan)
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("tenant_id = ? AND created_at < ?", tenantID, cutoffDate).
 		Delete(&models.Report{})
 
@@ -1015,9 +1011,7 @@ func (r *reportRepository) DeleteOldReports(ctx context.Context, tenantID uuid.U
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, tenantID)
 
 	r.logger.Info("deleted old reports", "tenant_id", tenantID, "count", result.RowsAffected, "older_than", olderThan)
 	return nil
@@ -1031,7 +1025,7 @@ func (r *reportRepository) DeleteFailedReports(ctx context.Context, tenantID uui
 
 	cutoffDate := time.Now().Add(-olderThan)
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("tenant_id = ? AND status = ? AND created_at < ?",
 			tenantID, models.ReportStatusFailed, cutoffDate).
 		Delete(&models.Report{})
@@ -1042,9 +1036,7 @@ func (r *reportRepository) DeleteFailedReports(ctx context.Context, tenantID uui
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, tenantID)
 
 	r.logger.Info("deleted failed reports", "tenant_id", tenantID, "count", result.RowsAffected)
 	return nil
@@ -1058,7 +1050,7 @@ func (r *reportRepository) ArchiveCompletedReports(ctx context.Context, tenantID
 
 	cutoffDate := time.Now().Add(-olderThan)
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("tenant_id = ? AND status = ? AND created_at < ?",
 			tenantID, models.ReportStatusCompleted, cutoffDate).
 		Delete(&models.Report{})
@@ -1069,9 +1061,7 @@ func (r *reportRepository) ArchiveCompletedReports(ctx context.Context, tenantID
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, tenantID)
 
 	r.logger.Info("archived completed reports", "tenant_id", tenantID, "count", result.RowsAffected, "older_than", olderThan)
 	return nil
@@ -1094,9 +1084,7 @@ func (r *reportRepository) BulkUpdateStatus(ctx context.Context, reportIDs []uui
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportIDs...)
 
 	r.logger.Info("bulk updated report status", "count", result.RowsAffected, "status", status)
 	return nil
@@ -1108,7 +1096,7 @@ func (r *reportRepository) BulkDelete(ctx context.Context, reportIDs []uuid.UUID
 		return nil
 	}
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("id IN ?", reportIDs).
 		Delete(&models.Report{})
 
@@ -1118,9 +1106,7 @@ func (r *reportRepository) BulkDelete(ctx context.Context, reportIDs []uuid.UUID
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:reports:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, reportIDs...)
 
 	r.logger.Info("bulk deleted reports", "count", result.RowsAffected)
 	return nil
@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/tenancy"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// cacheKeyVersion is part of every key, so a release that changes what is cached
+// starts from an empty cache instead of reading entries of the previous shape
+const cacheKeyVersion = "v2"
+
+// statsCacheTTL is how long dashboard statistics are cached. Writes drop them sooner;
+// the TTL bounds how stale the parts measured against the clock, like overdue counts,
+// can get.
+const statsCacheTTL = time.Minute
+
+// RepositoryCache caches one table's entities and query results under a single key
+// scheme:
+//
+//	repo:v2:{table}:id:{id}:{generation}              an entity
+//	repo:v2:{table}:q:{name}:{generations}:{args}     a query result
+//	repo:v2:{table}:gen:{tag}                         the generation of a tag
+//
+// An entity is deleted when a write names it. A write that changes rows it cannot
+// name, such as an update by filter, replaces the table's entities generation instead,
+// which retires every cached entity of the table at once.
+//
+// Query results are never deleted one by one. Each result is keyed by the current
+// generation of the tags it depends on, and a write replaces those generations so the
+// old results can no longer be reached and expire on their own. A query scoped to a
+// tenant depends on that tenant's tag and on the table's epoch; any other query
+// depends on the table-wide tag, which every write replaces.
+//
+// A nil *RepositoryCache, or one without a backing cache, caches nothing.
+type RepositoryCache struct {
+	table   string
+	cache   Cache
+	metrics MetricsCollector
+	logger  log.AllLogger
+	ttl     time.Duration
+}
+
+const (
+	cacheTagAll      = "all"      // Every write
+	cacheTagEpoch    = "epoch"    // Writes whose tenant is unknown
+	cacheTagEntities = "entities" // Writes whose rows are unknown
+)
+
+// NewRepositoryCache creates the cache of a table
+func NewRepositoryCache(table string, config RepositoryConfig) *RepositoryCache {
+	ttl := config.CacheTTL
+	if ttl == 0 {
+		ttl = 5 * time.Minute
+	}
+	return &RepositoryCache{
+		table:   table,
+		cache:   config.Cache,
+		metrics: config.Metrics,
+		logger:  config.Logger,
+		ttl:     ttl,
+	}
+}
+
+// WithTTL returns the cache with entries kept for ttl instead, for results that depend
+// on the clock as well as on the rows, such as statistics relative to now
+func (c *RepositoryCache) WithTTL(ttl time.Duration) *RepositoryCache {
+	if c == nil {
+		return nil
+	}
+	scoped := *c
+	scoped.ttl = ttl
+	return &scoped
+}
+
+func (c *RepositoryCache) enabled() bool {
+	return c != nil && c.cache != nil
+}
+
+// Entity returns a cached entity in dest, or runs load to fill dest and caches it.
+// An entity of another tenant than the one the context is scoped to is treated as a
+// miss, so the database's row-level security decides whether it is visible.
+func (c *RepositoryCache) Entity(ctx context.Context, id uuid.UUID, dest any, load func() error) error {
+	if !c.enabled() {
+		return load()
+	}
+
+	generation, err := c.generation(ctx, c.table, cacheTagEntities)
+	if err != nil {
+		c.warn("failed to read cache generation", "table", c.table, "tag", cacheTagEntities, "error", err)
+		return load()
+	}
+
+	key := entityCacheKey(c.table, id, generation)
+	if err := c.cache.GetJSON(ctx, key, dest); err == nil {
+		tenantID, scoped := tenancy.FromContext(ctx)
+		entityTenant, owned := getEntityTenantID(dest)
+		if !scoped || !owned || entityTenant == tenantID {
+			c.recordHit()
+			return nil
+		}
+		// Don't let the other tenant's fields survive a load that finds nothing
+		value := reflect.ValueOf(dest).Elem()
+		value.Set(reflect.Zero(value.Type()))
+	}
+	c.recordMiss()
+
+	if err := load(); err != nil {
+		return err
+	}
+	if err := c.cache.SetJSON(ctx, key, dest, c.ttl); err != nil {
+		c.warn("failed to cache entity", "table", c.table, "id", id, "error", err)
+	}
+	return nil
+}
+
+// Query returns a cached query result in dest, or runs load to fill dest and caches
+// it. A non-nil tenantID marks the query as reading only that tenant's rows, so
+// writes of other tenants leave it cached. The args identify the query's parameters.
+func (c *RepositoryCache) Query(ctx context.Context, tenantID uuid.UUID, name string, dest any, load func() error, args ...any) error {
+	if !c.enabled() {
+		return load()
+	}
+
+	// Row-level security limits what a tenant-scoped context reads, so a result is
+	// only shared between contexts scoped alike
+	viewer := "-"
+	if scoped, ok := tenancy.FromContext(ctx); ok {
+		if tenantID != uuid.Nil && tenantID != scoped {
+			return load()
+		}
+		viewer = scoped.String()
+	}
+
+	tags := []string{cacheTagAll}
+	if tenantID != uuid.Nil {
+		tags = []string{cacheTagEpoch, "tenant:" + tenantID.String()}
+	}
+	generations := make([]string, len(tags))
+	for i, tag := range tags {
+		generation, err := c.generation(ctx, c.table, tag)
+		if err != nil {
+			c.warn("failed to read cache generation", "table", c.table, "tag", tag, "error", err)
+			return load()
+		}
+		generations[i] = generation
+	}
+
+	key := fmt.Sprintf("repo:%s:%s:q:%s:%s:%s", cacheKeyVersion, c.table, name, strings.Join(generations, "."), queryArgsDigest(viewer, args))
+	if err := c.cache.GetJSON(ctx, key, dest); err == nil {
+		c.recordHit()
+		return nil
+	}
+	c.recordMiss()
+
+	if err := load(); err != nil {
+		return err
+	}
+	if err := c.cache.SetJSON(ctx, key, dest, c.ttl); err != nil {
+		c.warn("failed to cache query", "table", c.table, "query", name, "error", err)
+	}
+	return nil
+}
+
+// Invalidate drops the given entities of the table and the cached queries that may
+// include them. tenantID is the tenant that owns them; when it is nil the tenant the
+// context is scoped to is assumed, and failing that every tenant's queries are dropped.
+// Without ids every cached entity of the table is dropped, for writes that change rows
+// they cannot name.
+func (c *RepositoryCache) Invalidate(ctx context.Context, tenantID uuid.UUID, ids ...uuid.UUID) {
+	c.InvalidateTable(ctx, c.table, tenantID, ids...)
+}
+
+// InvalidateTable is Invalidate for another table whose cached data a write of this
+// one changes, such as a project whose progress follows its tasks
+func (c *RepositoryCache) InvalidateTable(ctx context.Context, table string, tenantID uuid.UUID, ids ...uuid.UUID) {
+	if !c.enabled() {
+		return
+	}
+
+	if tenantID == uuid.Nil {
+		tenantID, _ = tenancy.FromContext(ctx)
+	}
+	tag := cacheTagEpoch
+	if tenantID != uuid.Nil {
+		tag = "tenant:" + tenantID.String()
+	}
+
+	keys := make([]string, 0, len(ids)+3)
+	keys = append(keys, generationCacheKey(table, cacheTagAll), generationCacheKey(table, tag))
+	if len(ids) == 0 {
+		keys = append(keys, generationCacheKey(table, cacheTagEntities))
+	} else {
+		generation, err := c.generation(ctx, table, cacheTagEntities)
+		if err != nil {
+			// The entities can't be named, so they are retired with their generation
+			keys = append(keys, generationCacheKey(table, cacheTagEntities))
+		}
+		for _, id := range ids {
+			if err == nil {
+				keys = append(keys, entityCacheKey(table, id, generation))
+			}
+		}
+	}
+	if err := c.cache.Delete(ctx, keys...); err != nil {
+		c.warn("failed to invalidate cache", "table", table, "count", len(ids), "error", err)
+	}
+}
+
+// generation returns the current generation of a table's tag, starting a new one when
+// the tag has none. Generations are started from the clock so a replaced one is not
+// reused.
+func (c *RepositoryCache) generation(ctx context.Context, table, tag string) (string, error) {
+	key := generationCacheKey(table, tag)
+
+	var generation int64
+	if err := c.cache.GetJSON(ctx, key, &generation); err == nil && generation != 0 {
+		return fmt.Sprintf("%x", generation), nil
+	}
+
+	generation = time.Now().UnixNano()
+	if err := c.cache.SetJSON(ctx, key, generation, 0); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", generation), nil
+}
+
+func (c *RepositoryCache) recordHit() {
+	if c.metrics != nil {
+		c.metrics.RecordCacheHit(c.table)
+	}
+}
+
+func (c *RepositoryCache) recordMiss() {
+	if c.metrics != nil {
+		c.metrics.RecordCacheMiss(c.table)
+	}
+}
+
+func (c *RepositoryCache) warn(msg string, keysAndValues ...any) {
+	if c.logger != nil {
+		c.logger.Warn(append([]any{msg}, keysAndValues...)...)
+	}
+}
+
+func entityCacheKey(table string, id uuid.UUID, generation string) string {
+	return fmt.Sprintf("repo:%s:%s:id:%s:%s", cacheKeyVersion, table, id, generation)
+}
+
+func generationCacheKey(table, tag string) string {
+	return fmt.Sprintf("repo:%s:%s:gen:%s", cacheKeyVersion, table, tag)
+}
+
+// queryArgsDigest condenses query parameters into a key segment. Maps are written in
+// key order so equal parameters always produce the same key.
+func queryArgsDigest(viewer string, args []any) string {
+	var b strings.Builder
+	b.WriteString(viewer)
+	for _, arg := range args {
+		b.WriteByte('|')
+		value := reflect.ValueOf(arg)
+		if value.Kind() == reflect.Map {
+			keys := value.MapKeys()
+			sorted := make([]string, len(keys))
+			for i, key := range keys {
+				sorted[i] = fmt.Sprintf("%v=%v", key.Interface(), value.MapIndex(key).Interface())
+			}
+			sort.Strings(sorted)
+			b.WriteString(strings.Join(sorted, "&"))
+			continue
+		}
+		fmt.Fprintf(&b, "%v", arg)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// getEntityTenantID returns the tenant of an entity with a TenantID field
+func getEntityTenantID(entity any) (uuid.UUID, bool) {
+	v := reflect.ValueOf(entity)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return uuid.Nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return uuid.Nil, false
+	}
+
+	field := v.FieldByName("TenantID")
+	if !field.IsValid() || !field.CanInterface() {
+		return uuid.Nil, false
+	}
+	switch tenantID := field.Interface().(type) {
+	case uuid.UUID:
+		return tenantID, tenantID != uuid.Nil
+	case *uuid.UUID:
+		if tenantID != nil && *tenantID != uuid.Nil {
+			return *tenantID, true
+		}
+	}
+	return uuid.Nil, false
+}
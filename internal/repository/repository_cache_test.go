@@ -0,0 +1,119 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"Krafti_Vibe/internal/pkg/tenancy"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedEntity struct {
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	Name     string
+}
+
+func TestRepositoryCache(t *testing.T) {
+	ctx := context.Background()
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	newCache := func() *repository.RepositoryCache {
+		return repository.NewRepositoryCache("things", repository.RepositoryConfig{Cache: testutil.NewMockCache()})
+	}
+
+	// query runs a cached query and reports whether it had to load
+	query := func(c *repository.RepositoryCache, ctx context.Context, tenantID uuid.UUID, arg string) bool {
+		loaded := false
+		var count int
+		require.NoError(t, c.Query(ctx, tenantID, "count", &count, func() error {
+			loaded = true
+			count = 1
+			return nil
+		}, arg))
+		assert.Equal(t, 1, count)
+		return loaded
+	}
+
+	t.Run("caches query results by their parameters", func(t *testing.T) {
+		c := newCache()
+		assert.True(t, query(c, ctx, tenantA, "x"))
+		assert.False(t, query(c, ctx, tenantA, "x"))
+		assert.True(t, query(c, ctx, tenantA, "y"))
+	})
+
+	t.Run("a write drops only its tenant's queries", func(t *testing.T) {
+		c := newCache()
+		query(c, ctx, tenantA, "x")
+		query(c, ctx, tenantB, "x")
+		query(c, ctx, uuid.Nil, "x")
+
+		c.Invalidate(ctx, tenantA, uuid.New())
+
+		assert.True(t, query(c, ctx, tenantA, "x"))
+		assert.False(t, query(c, ctx, tenantB, "x"))
+		assert.True(t, query(c, ctx, uuid.Nil, "x"), "queries across tenants follow every write")
+	})
+
+	t.Run("a write of an unknown tenant drops every tenant's queries", func(t *testing.T) {
+		c := newCache()
+		query(c, ctx, tenantA, "x")
+		query(c, ctx, tenantB, "x")
+
+		c.Invalidate(ctx, uuid.Nil, uuid.New())
+
+		assert.True(t, query(c, ctx, tenantA, "x"))
+		assert.True(t, query(c, ctx, tenantB, "x"))
+	})
+
+	t.Run("results are not shared between tenant scopes", func(t *testing.T) {
+		c := newCache()
+		ctxA := tenancy.WithTenant(ctx, tenantA)
+		query(c, ctx, uuid.Nil, "x")
+
+		assert.True(t, query(c, ctxA, uuid.Nil, "x"))
+		assert.True(t, query(c, ctxA, tenantB, "x"), "another tenant's query is not cached")
+		assert.True(t, query(c, ctxA, tenantB, "x"))
+	})
+
+	t.Run("entities are cached until they are written", func(t *testing.T) {
+		c := newCache()
+		entity := cachedEntity{ID: uuid.New(), TenantID: tenantA, Name: "first"}
+		loads := 0
+		get := func(ctx context.Context) (cachedEntity, error) {
+			var dest cachedEntity
+			err := c.Entity(ctx, entity.ID, &dest, func() error {
+				loads++
+				dest = entity
+				return nil
+			})
+			return dest, err
+		}
+
+		got, err := get(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entity, got)
+		_, _ = get(ctx)
+		assert.Equal(t, 1, loads)
+
+		entity.Name = "second"
+		c.Invalidate(ctx, tenantA, entity.ID)
+		got, _ = get(ctx)
+		assert.Equal(t, "second", got.Name)
+		assert.Equal(t, 2, loads)
+
+		entity.Name = "third"
+		c.Invalidate(ctx, tenantA)
+		got, _ = get(ctx)
+		assert.Equal(t, "third", got.Name, "a write of unnamed rows drops the entities")
+		assert.Equal(t, 3, loads)
+
+		_, _ = get(tenancy.WithTenant(ctx, tenantB))
+		assert.Equal(t, 4, loads, "another tenant's entity is left to the database")
+	})
+}
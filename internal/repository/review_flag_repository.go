@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewFlagRepository defines the interface for review report and dispute operations
+type ReviewFlagRepository interface {
+	BaseRepository[models.ReviewFlag]
+
+	// FindByStatus returns the tenant's flags in a status, oldest first so open flags
+	// are resolved in the order they were raised
+	FindByStatus(ctx context.Context, tenantID uuid.UUID, status models.ReviewFlagStatus, pagination PaginationParams) ([]*models.ReviewFlag, PaginationResult, error)
+
+	// CountOpen returns how many of a review's flags are open
+	CountOpen(ctx context.Context, reviewID uuid.UUID) (int64, error)
+
+	// HasOpenFlag reports whether the reporter already has an open flag on the review
+	HasOpenFlag(ctx context.Context, reviewID, reporterID uuid.UUID) (bool, error)
+}
+
+// reviewFlagRepository implements ReviewFlagRepository
+type reviewFlagRepository struct {
+	BaseRepository[models.ReviewFlag]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewReviewFlagRepository creates a new ReviewFlagRepository instance
+func NewReviewFlagRepository(db *gorm.DB, config ...RepositoryConfig) ReviewFlagRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.ReviewFlag](db, cfg)
+
+	return &reviewFlagRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByStatus retrieves a page of the tenant's review flags in a status
+func (r *reviewFlagRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status models.ReviewFlagStatus, pagination PaginationParams) ([]*models.ReviewFlag, PaginationResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).
+		Model(&models.ReviewFlag{}).
+		Where("tenant_id = ? AND status = ?", tenantID, status)
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		r.logger.Error("failed to count review flags", "tenant_id", tenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count review flags", err)
+	}
+
+	var flags []*models.ReviewFlag
+	if err := query.
+		Preload("Review").
+		Order("created_at ASC").
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Find(&flags).Error; err != nil {
+		r.logger.Error("failed to find review flags", "tenant_id", tenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find review flags", err)
+	}
+
+	return flags, CalculatePagination(pagination, totalItems), nil
+}
+
+// CountOpen counts the open flags on a review
+func (r *reviewFlagRepository) CountOpen(ctx context.Context, reviewID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.ReviewFlag{}).
+		Where("review_id = ? AND status = ?", reviewID, models.ReviewFlagStatusOpen).
+		Count(&count).Error; err != nil {
+		return 0, errors.NewRepositoryError("COUNT_FAILED", "failed to count open review flags", err)
+	}
+
+	return count, nil
+}
+
+// HasOpenFlag checks for an open flag on a review by the reporter
+func (r *reviewFlagRepository) HasOpenFlag(ctx context.Context, reviewID, reporterID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.ReviewFlag{}).
+		Where("review_id = ? AND reporter_id = ? AND status = ?", reviewID, reporterID, models.ReviewFlagStatusOpen).
+		Count(&count).Error; err != nil {
+		return false, errors.NewRepositoryError("COUNT_FAILED", "failed to check for an open review flag", err)
+	}
+
+	return count > 0, nil
+}
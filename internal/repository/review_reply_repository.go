@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewReplyRepository defines the interface for review thread operations
+type ReviewReplyRepository interface {
+	BaseRepository[models.ReviewReply]
+
+	// FindByReview returns the thread under a review, oldest first
+	FindByReview(ctx context.Context, reviewID uuid.UUID) ([]*models.ReviewReply, error)
+}
+
+// reviewReplyRepository implements ReviewReplyRepository
+type reviewReplyRepository struct {
+	BaseRepository[models.ReviewReply]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewReviewReplyRepository creates a new ReviewReplyRepository instance
+func NewReviewReplyRepository(db *gorm.DB, config ...RepositoryConfig) ReviewReplyRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.ReviewReply](db, cfg)
+
+	return &reviewReplyRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByReview retrieves the replies to a review
+func (r *reviewReplyRepository) FindByReview(ctx context.Context, reviewID uuid.UUID) ([]*models.ReviewReply, error) {
+	if reviewID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "review_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var replies []*models.ReviewReply
+	if err := r.db.WithContext(ctx).
+		Preload("Author").
+		Where("review_id = ?", reviewID).
+		Order("created_at ASC").
+		Find(&replies).Error; err != nil {
+		r.logger.Error("failed to find review replies", "review_id", reviewID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find review replies", err)
+	}
+
+	return replies, nil
+}
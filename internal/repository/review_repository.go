@@ -145,6 +145,89 @@ func (r *ReviewRepository) GetRatingDistribution(ctx context.Context, artisanID
 	return distribution, nil
 }
 
+// RecalculateRatings recomputes the rating and review count kept on the reviewed artisan
+// (by user ID) and service from their published reviews. Both rows are locked first, so
+// recalculations racing in other transactions count each other's reviews once they
+// commit.
+func (r *ReviewRepository) RecalculateRatings(ctx context.Context, artisanID, serviceID uuid.UUID) error {
+	db := r.db.WithContext(ctx)
+
+	if err := db.Exec(`SELECT 1 FROM artisans WHERE user_id = ? FOR UPDATE`, artisanID).Error; err != nil {
+		return errs.NewRepositoryError("UPDATE_FAILED", "failed to lock artisan rating", err)
+	}
+	if err := db.Exec(`SELECT 1 FROM services WHERE id = ? FOR UPDATE`, serviceID).Error; err != nil {
+		return errs.NewRepositoryError("UPDATE_FAILED", "failed to lock service rating", err)
+	}
+
+	if err := db.Exec(`
+	UPDATE artisans SET rating = COALESCE(agg.rating, 0), review_count = agg.review_count
+	FROM (
+		SELECT ROUND(AVG(rating), 2) AS rating, COUNT(*) AS review_count
+		FROM reviews
+		WHERE artisan_id = ? AND is_published = true AND deleted_at IS NULL
+	) agg
+	WHERE artisans.user_id = ?
+`, artisanID, artisanID).Error; err != nil {
+		r.logger.Errorf("failed to recalculate rating for artisan %s: %v", artisanID, err)
+		return errs.NewRepositoryError("UPDATE_FAILED", "failed to recalculate artisan rating", err)
+	}
+
+	if err := db.Exec(`
+	UPDATE services SET rating = COALESCE(agg.rating, 0), review_count = agg.review_count
+	FROM (
+		SELECT ROUND(AVG(rating), 2) AS rating, COUNT(*) AS review_count
+		FROM reviews
+		WHERE service_id = ? AND is_published = true AND deleted_at IS NULL
+	) agg
+	WHERE services.id = ?
+`, serviceID, serviceID).Error; err != nil {
+		r.logger.Errorf("failed to recalculate rating for service %s: %v", serviceID, err)
+		return errs.NewRepositoryError("UPDATE_FAILED", "failed to recalculate service rating", err)
+	}
+
+	return nil
+}
+
+// ReconcileRatings recomputes every artisan's and service's rating and review count that
+// has drifted from their published reviews, returning how many rows were corrected
+func (r *ReviewRepository) ReconcileRatings(ctx context.Context) (int64, error) {
+	db := r.db.WithContext(ctx)
+
+	artisans := db.Exec(`
+	UPDATE artisans a SET rating = COALESCE(agg.rating, 0), review_count = COALESCE(agg.review_count, 0)
+	FROM artisans x
+	LEFT JOIN (
+		SELECT artisan_id, ROUND(AVG(rating), 2) AS rating, COUNT(*) AS review_count
+		FROM reviews
+		WHERE is_published = true AND deleted_at IS NULL
+		GROUP BY artisan_id
+	) agg ON agg.artisan_id = x.user_id
+	WHERE a.id = x.id
+		AND (a.rating IS DISTINCT FROM COALESCE(agg.rating, 0) OR a.review_count IS DISTINCT FROM COALESCE(agg.review_count, 0))
+`)
+	if artisans.Error != nil {
+		return 0, errs.NewRepositoryError("UPDATE_FAILED", "failed to reconcile artisan ratings", artisans.Error)
+	}
+
+	services := db.Exec(`
+	UPDATE services s SET rating = COALESCE(agg.rating, 0), review_count = COALESCE(agg.review_count, 0)
+	FROM services x
+	LEFT JOIN (
+		SELECT service_id, ROUND(AVG(rating), 2) AS rating, COUNT(*) AS review_count
+		FROM reviews
+		WHERE is_published = true AND deleted_at IS NULL
+		GROUP BY service_id
+	) agg ON agg.service_id = x.id
+	WHERE s.id = x.id
+		AND (s.rating IS DISTINCT FROM COALESCE(agg.rating, 0) OR s.review_count IS DISTINCT FROM COALESCE(agg.review_count, 0))
+`)
+	if services.Error != nil {
+		return 0, errs.NewRepositoryError("UPDATE_FAILED", "failed to reconcile service ratings", services.Error)
+	}
+
+	return artisans.RowsAffected + services.RowsAffected, nil
+}
+
 // FindPendingModeration retrieves reviews pending moderation
 func (r *ReviewRepository) FindPendingModeration(ctx context.Context, tenantID uuid.UUID) ([]models.Review, error) {
 	var reviews []models.Review
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"context"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedFilterRepository defines the interface for saved filter repository operations
+type SavedFilterRepository interface {
+	BaseRepository[models.SavedFilter]
+
+	// Query Operations
+	FindByUser(ctx context.Context, tenantID, userID uuid.UUID, resource models.SavedFilterResource) ([]*models.SavedFilter, error)
+	GetForUser(ctx context.Context, tenantID, userID, id uuid.UUID) (*models.SavedFilter, error)
+	ExistsByName(ctx context.Context, tenantID, userID uuid.UUID, resource models.SavedFilterResource, name string, excludeID uuid.UUID) (bool, error)
+}
+
+// savedFilterRepository implements SavedFilterRepository
+type savedFilterRepository struct {
+	BaseRepository[models.SavedFilter]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewSavedFilterRepository creates a new SavedFilterRepository instance
+func NewSavedFilterRepository(db *gorm.DB, config ...RepositoryConfig) SavedFilterRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.SavedFilter](db, cfg)
+
+	return &savedFilterRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByUser lists a user's saved filters for a resource, ordered by name
+func (r *savedFilterRepository) FindByUser(ctx context.Context, tenantID, userID uuid.UUID, resource models.SavedFilterResource) ([]*models.SavedFilter, error) {
+	var filters []*models.SavedFilter
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND user_id = ? AND resource = ?", tenantID, userID, resource).
+		Order("name ASC").
+		Find(&filters).Error; err != nil {
+		r.logger.Error("failed to find saved filters", "user_id", userID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find saved filters", err)
+	}
+
+	return filters, nil
+}
+
+// GetForUser retrieves a saved filter owned by the user in the tenant
+func (r *savedFilterRepository) GetForUser(ctx context.Context, tenantID, userID, id uuid.UUID) (*models.SavedFilter, error) {
+	var filter models.SavedFilter
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ? AND user_id = ?", id, tenantID, userID).
+		First(&filter).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "saved filter not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to get saved filter", "filter_id", id, "error", err)
+		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get saved filter", err)
+	}
+
+	return &filter, nil
+}
+
+// ExistsByName reports whether the user already has a filter with the name, ignoring case
+func (r *savedFilterRepository) ExistsByName(ctx context.Context, tenantID, userID uuid.UUID, resource models.SavedFilterResource, name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&models.SavedFilter{}).
+		Where("tenant_id = ? AND user_id = ? AND resource = ? AND LOWER(name) = LOWER(?)", tenantID, userID, resource, name)
+	if excludeID != uuid.Nil {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		r.logger.Error("failed to check saved filter name", "user_id", userID, "error", err)
+		return false, errors.NewRepositoryError("COUNT_FAILED", "failed to check saved filter name", err)
+	}
+
+	return count > 0, nil
+}
@@ -115,7 +115,7 @@ func (r *sdkClientRepository) Update(ctx context.Context, client *models.SDKClie
 }
 
 func (r *sdkClientRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.SDKClient{}, id).Error
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.SDKClient{}, id).Error
 }
 
 func (r *sdkClientRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID, page, pageSize int) ([]*models.SDKClient, int64, error) {
@@ -264,7 +264,7 @@ func (r *sdkKeyRepository) Update(ctx context.Context, key *models.SDKKey) error
 }
 
 func (r *sdkKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.SDKKey{}, id).Error
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.SDKKey{}, id).Error
 }
 
 func (r *sdkKeyRepository) ListByClient(ctx context.Context, clientID uuid.UUID, page, pageSize int) ([]*models.SDKKey, int64, error) {
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SearchKind names the kind of record a search hit is
+type SearchKind string
+
+const (
+	SearchKindBooking  SearchKind = "booking"
+	SearchKindCustomer SearchKind = "customer"
+	SearchKindService  SearchKind = "service"
+	SearchKindProject  SearchKind = "project"
+)
+
+// IsValid checks if the search kind is valid
+func (k SearchKind) IsValid() bool {
+	_, ok := searchKindSQL[k]
+	return ok
+}
+
+// maxSearchTerms caps the words of a query that are matched
+const maxSearchTerms = 8
+
+// SearchHit is one record matching a search
+type SearchHit struct {
+	Kind     SearchKind `json:"kind"`
+	ID       uuid.UUID  `json:"id"`
+	Title    string     `json:"title"`
+	Subtitle string     `json:"subtitle"`
+	Date     *time.Time `json:"date,omitempty"` // When a booking starts or a project is due
+	Rank     float64    `json:"rank"`
+}
+
+// SearchRepository searches a tenant's records by the full-text search documents
+// Postgres keeps of users, bookings, services and projects
+type SearchRepository interface {
+	// Search finds a tenant's records of the given kinds, or of every kind when none are
+	// given, that match every word of query, the best matches first
+	Search(ctx context.Context, tenantID uuid.UUID, query string, kinds []SearchKind, limit int) ([]SearchHit, error)
+}
+
+// searchRepository implements SearchRepository
+type searchRepository struct {
+	db       *gorm.DB
+	logger   log.AllLogger
+	timeouts QueryTimeouts
+}
+
+// NewSearchRepository creates a new SearchRepository instance
+func NewSearchRepository(db *gorm.DB, config ...RepositoryConfig) SearchRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return &searchRepository{
+		db:       db,
+		logger:   cfg.Logger,
+		timeouts: cfg.QueryTimeouts,
+	}
+}
+
+// searchKindSQL selects the hits of each kind. Customers match by their user's name and
+// contact details, and bookings by their notes or their customer's name.
+var searchKindSQL = map[SearchKind]string{
+	SearchKindCustomer: `
+		SELECT 'customer' AS kind, c.id, u.first_name || ' ' || u.last_name AS title, u.email AS subtitle,
+			CAST(NULL AS timestamptz) AS date, ts_rank(u.search_vector, q) AS rank
+		FROM customers c
+		JOIN users u ON u.id = c.user_id AND u.deleted_at IS NULL,
+			to_tsquery('simple', @query) q
+		WHERE c.tenant_id = @tenant AND c.deleted_at IS NULL AND u.search_vector @@ q`,
+	SearchKindBooking: `
+		SELECT 'booking' AS kind, b.id, COALESCE(s.name, '') AS title, u.first_name || ' ' || u.last_name AS subtitle,
+			b.start_time AS date, ts_rank(b.search_vector, q) + ts_rank(u.search_vector, q) AS rank
+		FROM bookings b
+		JOIN users u ON u.id = b.customer_id
+		LEFT JOIN services s ON s.id = b.service_id,
+			to_tsquery('simple', @query) q
+		WHERE b.tenant_id = @tenant AND b.deleted_at IS NULL
+			AND (b.search_vector @@ q OR u.search_vector @@ q)`,
+	SearchKindService: `
+		SELECT 'service' AS kind, s.id, s.name AS title, s.category AS subtitle,
+			CAST(NULL AS timestamptz) AS date, ts_rank(s.search_vector, q) AS rank
+		FROM services s, to_tsquery('simple', @query) q
+		WHERE s.tenant_id = @tenant AND s.deleted_at IS NULL AND s.search_vector @@ q`,
+	SearchKindProject: `
+		SELECT 'project' AS kind, p.id, p.title, p.status AS subtitle,
+			p.due_date AS date, ts_rank(p.search_vector, q) AS rank
+		FROM projects p, to_tsquery('simple', @query) q
+		WHERE p.tenant_id = @tenant AND p.deleted_at IS NULL AND p.search_vector @@ q`,
+}
+
+// Search runs one query across the requested kinds so the hits are ranked together
+func (r *searchRepository) Search(ctx context.Context, tenantID uuid.UUID, query string, kinds []SearchKind, limit int) ([]SearchHit, error) {
+	tsQuery := searchTSQuery(query)
+	if tsQuery == "" {
+		return []SearchHit{}, nil
+	}
+
+	ctx, cancel := r.timeouts.WithTimeout(ctx, "search")
+	defer cancel()
+
+	var parts []string
+	for _, kind := range []SearchKind{SearchKindCustomer, SearchKindBooking, SearchKindService, SearchKindProject} {
+		if len(kinds) == 0 || slices.Contains(kinds, kind) {
+			parts = append(parts, searchKindSQL[kind])
+		}
+	}
+	if len(parts) == 0 {
+		return []SearchHit{}, nil
+	}
+
+	hits := []SearchHit{}
+	if err := r.db.WithContext(ctx).Raw(
+		"SELECT * FROM ("+strings.Join(parts, "\n\t\tUNION ALL")+"\n\t) hits ORDER BY rank DESC, title ASC LIMIT @limit",
+		map[string]any{"tenant": tenantID, "query": tsQuery, "limit": limit}).
+		Scan(&hits).Error; err != nil {
+		r.logger.Error("failed to search", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("SEARCH_FAILED", "failed to search", err)
+	}
+
+	return hits, nil
+}
+
+// searchTSQuery turns free text into a tsquery matching records with a word starting
+// with each word of the text, so partly typed names match. Anything but letters and
+// digits separates words, so input can't inject tsquery operators. An empty result
+// means the text has no words to search for.
+func searchTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	if len(words) > maxSearchTerms {
+		words = words[:maxSearchTerms]
+	}
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
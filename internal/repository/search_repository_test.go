@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepository_Search(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Close()
+
+	repo := repository.NewSearchRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	ctx := context.Background()
+
+	_, tenant := testutil.CreateTestTenantWithOwner(tdb.DB)
+
+	customerUser := testutil.CreateTestUser(&tenant.ID, func(u *models.User) {
+		u.Email = "margaret@example.com"
+		u.FirstName = "Margaret"
+		u.LastName = "Okafor"
+	})
+	require.NoError(t, tdb.DB.Create(customerUser).Error)
+	customer := testutil.CreateTestCustomer(customerUser.ID, tenant.ID)
+	require.NoError(t, tdb.DB.Create(customer).Error)
+
+	artisanUser := testutil.CreateTestUser(&tenant.ID, func(u *models.User) {
+		u.Email = "artisan@example.com"
+		u.Role = models.UserRoleArtisan
+	})
+	require.NoError(t, tdb.DB.Create(artisanUser).Error)
+	artisan := testutil.CreateTestArtisan(artisanUser.ID, tenant.ID)
+	require.NoError(t, tdb.DB.Create(artisan).Error)
+
+	service := testutil.CreateTestService(tenant.ID, artisan.ID, func(s *models.Service) {
+		s.Name = "Oak Dining Table"
+	})
+	require.NoError(t, tdb.DB.Create(service).Error)
+
+	booking := testutil.CreateTestBooking(tenant.ID, customerUser.ID, artisan.ID, service.ID, func(b *models.Booking) {
+		b.Notes = "Walnut finish with brass handles"
+	})
+	require.NoError(t, tdb.DB.Create(booking).Error)
+
+	kindsOf := func(hits []repository.SearchHit) map[repository.SearchKind]uuid.UUID {
+		kinds := make(map[repository.SearchKind]uuid.UUID)
+		for _, hit := range hits {
+			kinds[hit.Kind] = hit.ID
+		}
+		return kinds
+	}
+
+	t.Run("customer name prefix finds the customer and their bookings", func(t *testing.T) {
+		hits, err := repo.Search(ctx, tenant.ID, "marg oka", nil, 20)
+		require.NoError(t, err)
+		kinds := kindsOf(hits)
+		assert.Equal(t, customer.ID, kinds[repository.SearchKindCustomer])
+		assert.Equal(t, booking.ID, kinds[repository.SearchKindBooking])
+	})
+
+	t.Run("service name", func(t *testing.T) {
+		hits, err := repo.Search(ctx, tenant.ID, "dining", []repository.SearchKind{repository.SearchKindService}, 20)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, service.ID, hits[0].ID)
+		assert.Equal(t, "Oak Dining Table", hits[0].Title)
+	})
+
+	t.Run("booking notes", func(t *testing.T) {
+		hits, err := repo.Search(ctx, tenant.ID, "brass", nil, 20)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, repository.SearchKindBooking, hits[0].Kind)
+		assert.Equal(t, booking.ID, hits[0].ID)
+	})
+
+	t.Run("tsquery operators are treated as separators", func(t *testing.T) {
+		hits, err := repo.Search(ctx, tenant.ID, "brass & !(walnut | ':*", nil, 20)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, booking.ID, hits[0].ID)
+
+		hits, err = repo.Search(ctx, tenant.ID, "&|!", nil, 20)
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("other tenants see nothing", func(t *testing.T) {
+		hits, err := repo.Search(ctx, uuid.New(), "margaret", nil, 20)
+		require.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+}
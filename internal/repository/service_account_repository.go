@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	stdErrors "errors"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ServiceAccountRepository defines the interface for service account repository operations
+type ServiceAccountRepository interface {
+	BaseRepository[models.ServiceAccount]
+
+	// FindByTenantID returns all of a tenant's service accounts, revoked ones included
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.ServiceAccount, error)
+
+	// FindByZitadelUserID returns the service account signing in as a Zitadel machine user
+	FindByZitadelUserID(ctx context.Context, zitadelUserID string) (*models.ServiceAccount, error)
+
+	// RecordUsage adds the counts of usage to the account's usage of its day and
+	// resource, and marks the account used at the time given
+	RecordUsage(ctx context.Context, usage *models.ServiceAccountUsage, at time.Time) error
+
+	// FindUsage returns the account's usage on the days from from to to, inclusive
+	FindUsage(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*models.ServiceAccountUsage, error)
+}
+
+// serviceAccountRepository implements ServiceAccountRepository
+type serviceAccountRepository struct {
+	BaseRepository[models.ServiceAccount]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewServiceAccountRepository creates a new ServiceAccountRepository instance
+func NewServiceAccountRepository(db *gorm.DB, config ...RepositoryConfig) ServiceAccountRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.ServiceAccount](db, cfg)
+
+	return &serviceAccountRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenantID retrieves all service accounts of a tenant, by name
+func (r *serviceAccountRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.ServiceAccount, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var accounts []*models.ServiceAccount
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Find(&accounts).Error; err != nil {
+		r.logger.Error("failed to find service accounts", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find service accounts", err)
+	}
+
+	return accounts, nil
+}
+
+// FindByZitadelUserID retrieves the service account of a Zitadel machine user
+func (r *serviceAccountRepository) FindByZitadelUserID(ctx context.Context, zitadelUserID string) (*models.ServiceAccount, error) {
+	if zitadelUserID == "" {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "zitadel_user_id cannot be empty", errors.ErrInvalidInput)
+	}
+
+	var account models.ServiceAccount
+	if err := r.db.WithContext(ctx).Where("zitadel_user_id = ?", zitadelUserID).First(&account).Error; err != nil {
+		if stdErrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "service account not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to find service account by zitadel user", "zitadel_user_id", zitadelUserID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find service account", err)
+	}
+
+	return &account, nil
+}
+
+// RecordUsage upserts the day's usage row, adding to its counts, and updates the
+// account's last use in one transaction
+func (r *serviceAccountRepository) RecordUsage(ctx context.Context, usage *models.ServiceAccountUsage, at time.Time) error {
+	if usage == nil || usage.ServiceAccountID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "service_account_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "service_account_id"}, {Name: "day"}, {Name: "resource"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"requests":      gorm.Expr("service_account_usage.requests + EXCLUDED.requests"),
+				"denied":        gorm.Expr("service_account_usage.denied + EXCLUDED.denied"),
+				"client_errors": gorm.Expr("service_account_usage.client_errors + EXCLUDED.client_errors"),
+				"server_errors": gorm.Expr("service_account_usage.server_errors + EXCLUDED.server_errors"),
+				"updated_at":    at,
+			}),
+		}).Create(usage).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.ServiceAccount{}).
+			Where("id = ?", usage.ServiceAccountID).
+			UpdateColumn("last_used_at", at).Error
+	})
+	if err != nil {
+		r.logger.Error("failed to record service account usage", "service_account_id", usage.ServiceAccountID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to record service account usage", err)
+	}
+	return nil
+}
+
+// FindUsage retrieves an account's usage rows on the days in range, by day
+func (r *serviceAccountRepository) FindUsage(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*models.ServiceAccountUsage, error) {
+	if accountID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "service_account_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var usage []*models.ServiceAccountUsage
+	if err := r.db.WithContext(ctx).
+		Where("service_account_id = ?", accountID).
+		Where("day BETWEEN ? AND ?", from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Order("day ASC, resource ASC").
+		Find(&usage).Error; err != nil {
+		r.logger.Error("failed to find service account usage", "service_account_id", accountID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find service account usage", err)
+	}
+
+	return usage, nil
+}
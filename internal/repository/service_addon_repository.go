@@ -235,9 +235,7 @@ func (r *serviceAddonRepository) ActivateAddon(ctx context.Context, addonID uuid
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:service_addons:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, addonID)
 
 	return nil
 }
@@ -263,9 +261,7 @@ func (r *serviceAddonRepository) DeactivateAddon(ctx context.Context, addonID uu
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:service_addons:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, addonID)
 
 	return nil
 }
@@ -294,9 +290,7 @@ func (r *serviceAddonRepository) UpdatePrice(ctx context.Context, addonID uuid.U
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:service_addons:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, addonID)
 
 	r.logger.Info("addon price updated", "addon_id", addonID, "new_price", newPrice)
 	return nil
@@ -329,9 +323,7 @@ func (r *serviceAddonRepository) BulkUpdatePrices(ctx context.Context, addonIDs
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:service_addons:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, addonIDs...)
 
 	r.logger.Info("bulk updated addon prices", "count", result.RowsAffected, "adjustment", priceAdjustment, "is_percentage", isPercentage)
 	return nil
@@ -470,9 +462,7 @@ func (r *serviceAddonRepository) BulkActivate(ctx context.Context, addonIDs []uu
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:service_addons:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, addonIDs...)
 
 	r.logger.Info("bulk activated addons", "count", result.RowsAffected)
 	return nil
@@ -495,9 +485,7 @@ func (r *serviceAddonRepository) BulkDeactivate(ctx context.Context, addonIDs []
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:service_addons:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, addonIDs...)
 
 	r.logger.Info("bulk deactivated addons", "count", result.RowsAffected)
 	return nil
@@ -509,7 +497,7 @@ func (r *serviceAddonRepository) BulkDelete(ctx context.Context, addonIDs []uuid
 		return nil
 	}
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("id IN ?", addonIDs).
 		Delete(&models.ServiceAddon{})
 
@@ -519,9 +507,7 @@ func (r *serviceAddonRepository) BulkDelete(ctx context.Context, addonIDs []uuid
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:service_addons:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, addonIDs...)
 
 	r.logger.Info("bulk deleted addons", "count", result.RowsAffected)
 	return nil
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ServiceAreaRepository defines the interface for service area repository operations
+type ServiceAreaRepository interface {
+	BaseRepository[models.ServiceArea]
+
+	// FindByArtisan lists an artisan's service areas, ordered by name
+	FindByArtisan(ctx context.Context, tenantID, artisanID uuid.UUID) ([]*models.ServiceArea, error)
+
+	// SetActive switches whether a service area limits where the artisan travels
+	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+}
+
+// serviceAreaRepository implements ServiceAreaRepository
+type serviceAreaRepository struct {
+	BaseRepository[models.ServiceArea]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewServiceAreaRepository creates a new ServiceAreaRepository instance
+func NewServiceAreaRepository(db *gorm.DB, config ...RepositoryConfig) ServiceAreaRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.ServiceArea](db, cfg)
+
+	return &serviceAreaRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByArtisan lists an artisan's service areas, ordered by name
+func (r *serviceAreaRepository) FindByArtisan(ctx context.Context, tenantID, artisanID uuid.UUID) ([]*models.ServiceArea, error) {
+	var areas []*models.ServiceArea
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND artisan_id = ?", tenantID, artisanID).
+		Order("name ASC").
+		Find(&areas).Error; err != nil {
+		r.logger.Error("failed to find service areas", "artisan_id", artisanID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find service areas", err)
+	}
+
+	return areas, nil
+}
+
+// SetActive switches whether a service area limits where the artisan travels
+func (r *serviceAreaRepository) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.ServiceArea{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"is_active": active,
+			"version":   gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		r.logger.Error("failed to update service area", "area_id", id, "error", result.Error)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to update service area", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewRepositoryError("NOT_FOUND", "service area not found", errors.ErrNotFound)
+	}
+
+	r.InvalidateCache(ctx, id)
+	return nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	stdErrors "errors"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ServicePriceVersionRepository defines the interface for service price version operations
+type ServicePriceVersionRepository interface {
+	BaseRepository[models.ServicePriceVersion]
+
+	// FindByServiceID returns a service's price versions, newest first
+	FindByServiceID(ctx context.Context, serviceID uuid.UUID) ([]*models.ServicePriceVersion, error)
+
+	// Record makes the service's pricing the version in effect from at: the latest
+	// version is returned as is when it matches, and is otherwise closed at at and
+	// followed by a new version
+	Record(ctx context.Context, service *models.Service, at time.Time) (*models.ServicePriceVersion, error)
+}
+
+// servicePriceVersionRepository implements ServicePriceVersionRepository
+type servicePriceVersionRepository struct {
+	BaseRepository[models.ServicePriceVersion]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewServicePriceVersionRepository creates a new ServicePriceVersionRepository instance
+func NewServicePriceVersionRepository(db *gorm.DB, config ...RepositoryConfig) ServicePriceVersionRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.ServicePriceVersion](db, cfg)
+
+	return &servicePriceVersionRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByServiceID retrieves all price versions of a service, by number descending
+func (r *servicePriceVersionRepository) FindByServiceID(ctx context.Context, serviceID uuid.UUID) ([]*models.ServicePriceVersion, error) {
+	if serviceID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "service_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var versions []*models.ServicePriceVersion
+	if err := r.db.WithContext(ctx).
+		Where("service_id = ?", serviceID).
+		Order("number DESC").
+		Find(&versions).Error; err != nil {
+		r.logger.Error("failed to find service price versions", "service_id", serviceID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find service price versions", err)
+	}
+
+	return versions, nil
+}
+
+// Record locks the service's latest version and, unless it matches, closes it and
+// creates the next one in the same transaction
+func (r *servicePriceVersionRepository) Record(ctx context.Context, service *models.Service, at time.Time) (*models.ServicePriceVersion, error) {
+	if service == nil || service.ID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "service_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var version *models.ServicePriceVersion
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest models.ServicePriceVersion
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("service_id = ?", service.ID).
+			Order("number DESC").
+			First(&latest).Error
+		number := 1
+		switch {
+		case err == nil:
+			if latest.Matches(service) {
+				version = &latest
+				return nil
+			}
+			if err := tx.Model(&latest).UpdateColumn("effective_to", at).Error; err != nil {
+				return err
+			}
+			number = latest.Number + 1
+		case !stdErrors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		version = &models.ServicePriceVersion{
+			TenantID:      service.TenantID,
+			ServiceID:     service.ID,
+			Number:        number,
+			Price:         service.Price,
+			Currency:      service.Currency,
+			DepositAmount: service.DepositAmount,
+			TaxCategory:   service.TaxCategory,
+			EffectiveFrom: at,
+		}
+		return tx.Create(version).Error
+	})
+	if err != nil {
+		r.logger.Error("failed to record service price version", "service_id", service.ID, "error", err)
+		return nil, errors.NewRepositoryError("CREATE_FAILED", "failed to record service price version", err)
+	}
+
+	return version, nil
+}
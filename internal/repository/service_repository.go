@@ -61,6 +61,9 @@ type ServiceRepository interface {
 	// Advanced Filtering
 	FindByFilters(ctx context.Context, tenantID uuid.UUID, filters types.ServiceFilters, pagination PaginationParams) ([]*models.Service, PaginationResult, error)
 	GetCategoriesWithCount(ctx context.Context, tenantID uuid.UUID) ([]types.CategoryCount, error)
+
+	// Publishing
+	FindDueForPublication(ctx context.Context, now time.Time, limit int) ([]*models.Service, error)
 }
 
 // serviceRepository implements ServiceRepository
@@ -183,7 +186,7 @@ func (r *serviceRepository) FindActiveServices(ctx context.Context, tenantID uui
 	var totalItems int64
 	if err := r.db.WithContext(ctx).
 		Model(&models.Service{}).
-		Where("tenant_id = ? AND is_active = ?", tenantID, true).
+		Where("tenant_id = ? AND is_active = ? AND status = ?", tenantID, true, models.ServiceStatusPublished).
 		Count(&totalItems).Error; err != nil {
 		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count active services", err)
 	}
@@ -192,7 +195,7 @@ func (r *serviceRepository) FindActiveServices(ctx context.Context, tenantID uui
 	if err := r.db.WithContext(ctx).
 		Preload("Artisan").
 		Preload("Addons").
-		Where("tenant_id = ? AND is_active = ?", tenantID, true).
+		Where("tenant_id = ? AND is_active = ? AND status = ?", tenantID, true, models.ServiceStatusPublished).
 		Offset(pagination.Offset()).
 		Limit(pagination.Limit()).
 		Order("category ASC, name ASC").
@@ -340,7 +343,7 @@ func (r *serviceRepository) FindPopularServices(ctx context.Context, tenantID uu
 		Preload("Artisan").
 		Preload("Addons").
 		Joins("LEFT JOIN bookings ON bookings.service_id = services.id").
-		Where("services.tenant_id = ? AND services.is_active = ?", tenantID, true).
+		Where("services.tenant_id = ? AND services.is_active = ? AND services.status = ?", tenantID, true, models.ServiceStatusPublished).
 		Group("services.id").
 		Order("COUNT(bookings.id) DESC").
 		Limit(limit).
@@ -376,7 +379,7 @@ func (r *serviceRepository) FindRecommendedServices(ctx context.Context, tenantI
 	query := r.db.WithContext(ctx).
 		Preload("Artisan").
 		Preload("Addons").
-		Where("tenant_id = ? AND is_active = ?", tenantID, true)
+		Where("tenant_id = ? AND is_active = ? AND status = ?", tenantID, true, models.ServiceStatusPublished)
 
 	if len(bookedCategories) > 0 {
 		query = query.Where("category IN ?", bookedCategories)
@@ -424,9 +427,7 @@ func (r *serviceRepository) UpdateAvailability(ctx context.Context, serviceID uu
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceID)
 
 	return nil
 }
@@ -455,9 +456,7 @@ func (r *serviceRepository) UpdatePrice(ctx context.Context, serviceID uuid.UUID
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceID)
 
 	r.logger.Info("service price updated", "service_id", serviceID, "new_price", newPrice)
 	return nil
@@ -492,9 +491,7 @@ func (r *serviceRepository) UpdateDeposit(ctx context.Context, serviceID uuid.UU
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceID)
 
 	return nil
 }
@@ -526,9 +523,7 @@ func (r *serviceRepository) BulkUpdatePrices(ctx context.Context, serviceIDs []u
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceIDs...)
 
 	r.logger.Info("bulk updated service prices", "count", result.RowsAffected, "adjustment", priceAdjustment, "is_percentage", isPercentage)
 	return nil
@@ -563,9 +558,7 @@ func (r *serviceRepository) AddServiceAddon(ctx context.Context, serviceID, addo
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceID)
 
 	r.logger.Info("added service addon", "service_id", serviceID, "addon_id", addonID)
 	return nil
@@ -599,9 +592,7 @@ func (r *serviceRepository) RemoveServiceAddon(ctx context.Context, serviceID, a
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceID)
 
 	r.logger.Info("removed service addon", "service_id", serviceID, "addon_id", addonID)
 	return nil
@@ -848,20 +839,9 @@ func (r *serviceRepository) GetServicePerformance(ctx context.Context, serviceID
 		Select("COALESCE(SUM(total_price), 0)").
 		Scan(&perf.TotalRevenue)
 
-	// Average rating
-	r.db.WithContext(ctx).
-		Model(&models.Review{}).
-		Joins("JOIN bookings ON bookings.id = reviews.booking_id").
-		Where("bookings.service_id = ?", serviceID).
-		Select("AVG(rating)").
-		Scan(&perf.AverageRating)
-
-	// Review count
-	r.db.WithContext(ctx).
-		Model(&models.Review{}).
-		Joins("JOIN bookings ON bookings.id = reviews.booking_id").
-		Where("bookings.service_id = ?", serviceID).
-		Count(&perf.ReviewCount)
+	// Rating, kept on the service as reviews change
+	perf.AverageRating = service.Rating
+	perf.ReviewCount = int64(service.ReviewCount)
 
 	// Completion rate
 	if perf.TotalBookings > 0 {
@@ -970,9 +950,7 @@ func (r *serviceRepository) BulkActivate(ctx context.Context, serviceIDs []uuid.
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceIDs...)
 
 	r.logger.Info("bulk activated services", "count", result.RowsAffected)
 	return nil
@@ -995,9 +973,7 @@ func (r *serviceRepository) BulkDeactivate(ctx context.Context, serviceIDs []uui
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceIDs...)
 
 	r.logger.Info("bulk deactivated services", "count", result.RowsAffected)
 	return nil
@@ -1020,9 +996,7 @@ func (r *serviceRepository) BulkUpdateCategory(ctx context.Context, serviceIDs [
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceIDs...)
 
 	r.logger.Info("bulk updated service category", "count", result.RowsAffected, "category", category)
 	return nil
@@ -1044,9 +1018,7 @@ func (r *serviceRepository) BulkDelete(ctx context.Context, serviceIDs []uuid.UU
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:services:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, serviceIDs...)
 
 	r.logger.Info("bulk deleted services", "count", result.RowsAffected)
 	return nil
@@ -1087,6 +1059,10 @@ func (r *serviceRepository) FindByFilters(ctx context.Context, tenantID uuid.UUI
 		query = query.Where("is_active = ?", *filters.IsActive)
 	}
 
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+
 	if filters.ArtisanID != nil {
 		query = query.Where("artisan_id = ?", *filters.ArtisanID)
 	}
@@ -1141,3 +1117,24 @@ func (r *serviceRepository) GetCategoriesWithCount(ctx context.Context, tenantID
 
 	return counts, nil
 }
+
+// FindDueForPublication retrieves draft services and services with pending changes
+// whose publish time has passed, earliest first
+func (r *serviceRepository) FindDueForPublication(ctx context.Context, now time.Time, limit int) ([]*models.Service, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	var services []*models.Service
+	if err := r.db.WithContext(ctx).
+		Where("publish_at <= ?", now).
+		Where("status = ? OR pending_changes IS NOT NULL", models.ServiceStatusDraft).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&services).Error; err != nil {
+		r.logger.Error("failed to find services due for publication", "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find services due for publication", err)
+	}
+
+	return services, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SkillRepository defines the interface for skill taxonomy and artisan skill operations
+type SkillRepository interface {
+	BaseRepository[models.Skill]
+
+	// FindByTenant lists the tenant's skills by category and name, optionally only one
+	// category's or the active ones
+	FindByTenant(ctx context.Context, tenantID uuid.UUID, category models.ServiceCategory, activeOnly bool) ([]*models.Skill, error)
+
+	// FindArtisanSkills lists an artisan's skills with the taxonomy entry of each
+	FindArtisanSkills(ctx context.Context, artisanID uuid.UUID) ([]*models.ArtisanSkill, error)
+
+	// ReplaceArtisanSkills replaces all of an artisan's skills with the given ones
+	ReplaceArtisanSkills(ctx context.Context, tenantID, artisanID uuid.UUID, skills []*models.ArtisanSkill) error
+
+	// FindMatchCandidates returns the tenant's artisans with any of the skills, those
+	// with the most of them and the best rated first
+	FindMatchCandidates(ctx context.Context, tenantID uuid.UUID, query SkillMatchQuery) ([]*SkillMatchCandidate, error)
+}
+
+// SkillMatchQuery selects the artisans a service request may be matched to
+type SkillMatchQuery struct {
+	SkillIDs  []uuid.UUID
+	Latitude  *float64   // With Longitude, the request's location
+	Longitude *float64   //
+	RadiusKm  float64    // Artisans located further from the request's location are left out
+	Date      *time.Time // Day the service is wanted on, if any
+	Limit     int
+}
+
+// SkillMatchCandidate is an artisan found by FindMatchCandidates
+type SkillMatchCandidate struct {
+	Artisan     *models.Artisan
+	Skills      []*models.ArtisanSkill // Among the searched ones
+	DistanceKm  *float64               // From the request's location; nil when either has none
+	HasOpenings bool                   // Has regular availability, on the date when there is one
+}
+
+// skillRepository implements SkillRepository
+type skillRepository struct {
+	BaseRepository[models.Skill]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewSkillRepository creates a new SkillRepository instance
+func NewSkillRepository(db *gorm.DB, config ...RepositoryConfig) SkillRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.Skill](db, cfg)
+
+	return &skillRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenant lists the tenant's skills
+func (r *skillRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, category models.ServiceCategory, activeOnly bool) ([]*models.Skill, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var skills []*models.Skill
+	if err := query.Order("category ASC, name ASC").Find(&skills).Error; err != nil {
+		r.logger.Error("failed to find skills", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find skills", err)
+	}
+
+	return skills, nil
+}
+
+// FindArtisanSkills lists an artisan's skills, ordered by the skill's name. Tags of
+// deleted skills are left out.
+func (r *skillRepository) FindArtisanSkills(ctx context.Context, artisanID uuid.UUID) ([]*models.ArtisanSkill, error) {
+	var skills []*models.ArtisanSkill
+	if err := r.db.WithContext(ctx).
+		InnerJoins("Skill").
+		Where("artisan_skills.artisan_id = ?", artisanID).
+		Order(`"Skill"."name" ASC`).
+		Find(&skills).Error; err != nil {
+		r.logger.Error("failed to find artisan skills", "artisan_id", artisanID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find artisan skills", err)
+	}
+
+	return skills, nil
+}
+
+// ReplaceArtisanSkills deletes the artisan's skills and inserts the new ones in one
+// transaction. The old rows are removed for good so the same skills can be tagged again.
+func (r *skillRepository) ReplaceArtisanSkills(ctx context.Context, tenantID, artisanID uuid.UUID, skills []*models.ArtisanSkill) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("tenant_id = ? AND artisan_id = ?", tenantID, artisanID).
+			Delete(&models.ArtisanSkill{}).Error; err != nil {
+			return err
+		}
+		if len(skills) == 0 {
+			return nil
+		}
+		return tx.Create(&skills).Error
+	})
+	if err != nil {
+		r.logger.Error("failed to replace artisan skills", "artisan_id", artisanID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to replace artisan skills", err)
+	}
+
+	return nil
+}
+
+// FindMatchCandidates finds the artisans tagged with the skills, within the radius of
+// the request's location when it has one. Artisans without coordinates are kept, as
+// where they work can't be told.
+func (r *skillRepository) FindMatchCandidates(ctx context.Context, tenantID uuid.UUID, query SkillMatchQuery) ([]*SkillMatchCandidate, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+	if len(query.SkillIDs) == 0 {
+		return []*SkillMatchCandidate{}, nil
+	}
+	if query.Limit <= 0 {
+		query.Limit = 50
+	}
+
+	skills := r.db.Model(&models.ArtisanSkill{}).
+		Where("artisan_skills.artisan_id = artisans.id AND artisan_skills.skill_id IN ?", query.SkillIDs)
+
+	// Regular availability on the date's weekday that isn't taken by time off, or any
+	// regular availability without a date
+	openings := r.db.Model(&models.Availability{}).
+		Select("1").
+		Where("availabilities.artisan_id = artisans.id AND availabilities.type = ?", models.AvailabilityTypeRegular)
+	if query.Date != nil {
+		day := time.Date(query.Date.Year(), query.Date.Month(), query.Date.Day(), 0, 0, 0, 0, query.Date.Location())
+		timeOff := r.db.Model(&models.Availability{}).
+			Select("1").
+			Where("availabilities.artisan_id = artisans.id AND availabilities.type = ?", models.AvailabilityTypeTimeOff).
+			Where("availabilities.start_time < ? AND availabilities.end_time > ?", day.AddDate(0, 0, 1), day)
+		openings = openings.
+			Where("availabilities.day_of_week IS NULL OR availabilities.day_of_week = ?", int(day.Weekday())).
+			Where("availabilities.date IS NULL OR availabilities.date::date = ?::date", day).
+			Where("availabilities.recur_until IS NULL OR availabilities.recur_until >= ?", day).
+			Where("NOT EXISTS (?)", timeOff)
+	}
+
+	db := r.db.WithContext(ctx).
+		Model(&models.Artisan{}).
+		Where("artisans.tenant_id = ?", tenantID).
+		Where("EXISTS (?)", skills.Session(&gorm.Session{}).Select("1"))
+	if query.Latitude != nil && query.Longitude != nil {
+		point := gorm.Expr("ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography", *query.Longitude, *query.Latitude)
+		db = db.
+			Select("artisans.id, ST_Distance(artisans.geo_point, ?) / 1000 AS distance_km, EXISTS (?) AS has_openings", point, openings).
+			Where("artisans.geo_point IS NULL OR ST_DWithin(artisans.geo_point, ?, ?)", point, query.RadiusKm*1000)
+	} else {
+		db = db.Select("artisans.id, NULL AS distance_km, EXISTS (?) AS has_openings", openings)
+	}
+
+	var rows []struct {
+		ID          uuid.UUID
+		DistanceKm  *float64
+		HasOpenings bool
+	}
+	if err := db.
+		Order(gorm.Expr("(?) DESC", skills.Session(&gorm.Session{}).Select("COUNT(*)"))).
+		Order("artisans.rating DESC").
+		Limit(query.Limit).
+		Scan(&rows).Error; err != nil {
+		r.logger.Error("failed to find skill match candidates", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find skill match candidates", err)
+	}
+	if len(rows) == 0 {
+		return []*SkillMatchCandidate{}, nil
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var artisans []*models.Artisan
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("id IN ?", ids).
+		Find(&artisans).Error; err != nil {
+		r.logger.Error("failed to load skill match candidates", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find skill match candidates", err)
+	}
+	var artisanSkills []*models.ArtisanSkill
+	if err := r.db.WithContext(ctx).
+		Where("artisan_id IN ? AND skill_id IN ?", ids, query.SkillIDs).
+		Find(&artisanSkills).Error; err != nil {
+		r.logger.Error("failed to load skill match candidates' skills", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find skill match candidates", err)
+	}
+
+	byID := make(map[uuid.UUID]*SkillMatchCandidate, len(artisans))
+	for _, artisan := range artisans {
+		byID[artisan.ID] = &SkillMatchCandidate{Artisan: artisan}
+	}
+	for _, skill := range artisanSkills {
+		if candidate, ok := byID[skill.ArtisanID]; ok {
+			candidate.Skills = append(candidate.Skills, skill)
+		}
+	}
+
+	candidates := make([]*SkillMatchCandidate, 0, len(rows))
+	for _, row := range rows {
+		if candidate, ok := byID[row.ID]; ok {
+			candidate.DistanceKm = row.DistanceKm
+			candidate.HasOpenings = row.HasOpenings
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
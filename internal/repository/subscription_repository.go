@@ -49,6 +49,8 @@ type SubscriptionRepository interface {
 	UpdateBillingCycle(ctx context.Context, tenantID uuid.UUID, nextBillingDate time.Time) error
 	GetSubscriptionsDueForBilling(ctx context.Context, beforeDate time.Time) ([]*models.Subscription, error)
 	GetSubscriptionsWithFailedPayments(ctx context.Context, minFailures int) ([]*models.Subscription, error)
+	GetGracePeriodExpired(ctx context.Context, now time.Time) ([]*models.Subscription, error)
+	SaveBillingState(ctx context.Context, sub *models.Subscription) error
 
 	// Feature Management
 	UpdateFeatures(ctx context.Context, tenantID uuid.UUID, features models.SubscriptionFeatures) error
@@ -163,26 +165,14 @@ func (r *subscriptionRepository) GetByTenantID(ctx context.Context, tenantID uui
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant ID cannot be nil", errors.ErrInvalidInput)
 	}
 
-	// Try cache first
-	cacheKey := r.getCacheKey("tenant", tenantID.String())
-	if r.cache != nil {
-		var subscription models.Subscription
-		if err := r.cache.GetJSON(ctx, cacheKey, &subscription); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit("subscriptions")
-			}
-			return &subscription, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss("subscriptions")
-		}
-	}
-
 	var subscription models.Subscription
-	if err := r.db.WithContext(ctx).
-		Preload("Tenant").
-		Where("tenant_id = ?", tenantID).
-		First(&subscription).Error; err != nil {
+	err := r.RepositoryCache().Query(ctx, tenantID, "by_tenant", &subscription, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Tenant").
+			Where("tenant_id = ?", tenantID).
+			First(&subscription).Error
+	})
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewRepositoryError("NOT_FOUND", "subscription not found", errors.ErrNotFound)
 		}
@@ -192,13 +182,6 @@ func (r *subscriptionRepository) GetByTenantID(ctx context.Context, tenantID uui
 		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get subscription", err)
 	}
 
-	// Cache the result
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, subscription, 10*time.Minute); err != nil && r.logger != nil {
-			r.logger.Warn("failed to cache subscription", "tenant_id", tenantID, "error", err)
-		}
-	}
-
 	return &subscription, nil
 }
 
@@ -780,6 +763,42 @@ func (r *subscriptionRepository) GetSubscriptionsWithFailedPayments(ctx context.
 	return subs, nil
 }
 
+// GetGracePeriodExpired returns past-due subscriptions whose grace period ended before now
+func (r *subscriptionRepository) GetGracePeriodExpired(ctx context.Context, now time.Time) ([]*models.Subscription, error) {
+	start := time.Now()
+	defer func() {
+		r.recordMetric("get_grace_expired", time.Since(start), nil)
+	}()
+
+	var subs []*models.Subscription
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND grace_ends_at IS NOT NULL AND grace_ends_at <= ?", models.SubStatusPastDue, now).
+		Find(&subs).Error; err != nil {
+		if r.logger != nil {
+			r.logger.Error("failed to get grace-expired subs", "error", err)
+		}
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "failed to query expired grace periods", err)
+	}
+
+	return subs, nil
+}
+
+// SaveBillingState saves a subscription updated from the billing provider and drops its
+// cached copy, so plan enforcement sees the change immediately
+func (r *subscriptionRepository) SaveBillingState(ctx context.Context, sub *models.Subscription) error {
+	start := time.Now()
+	defer func() {
+		r.recordMetric("save_billing_state", time.Since(start), nil)
+	}()
+
+	if err := r.Update(ctx, sub); err != nil {
+		return err
+	}
+
+	r.invalidateTenantCache(ctx, sub.TenantID)
+	return nil
+}
+
 // UpdateFeatures updates feature flags for a subscription
 func (r *subscriptionRepository) UpdateFeatures(ctx context.Context, tenantID uuid.UUID, features models.SubscriptionFeatures) error {
 	start := time.Now()
@@ -812,27 +831,13 @@ func (r *subscriptionRepository) HasFeature(ctx context.Context, tenantID uuid.U
 		r.recordMetric("has_feature", time.Since(start), nil)
 	}()
 
-	cacheKey := r.getCacheKey("feature", tenantID.String(), feature)
-	if r.cache != nil {
-		var has bool
-		if err := r.cache.GetJSON(ctx, cacheKey, &has); err == nil {
-			r.recordCacheHit()
-			return has, nil
-		}
-		r.recordCacheMiss()
-	}
-
+	// The subscription is cached, so the check needs no cache of its own
 	sub, err := r.GetByTenantID(ctx, tenantID)
 	if err != nil {
 		return false, err
 	}
 
-	has := r.checkFeature(sub.Features, feature)
-	if r.cache != nil {
-		_ = r.cache.SetJSON(ctx, cacheKey, has, 5*time.Minute)
-	}
-
-	return has, nil
+	return r.checkFeature(sub.Features, feature), nil
 }
 
 // GetEnabledFeatures returns all enabled feature names
@@ -1282,11 +1287,10 @@ func (r *subscriptionRepository) SearchSubscriptions(ctx context.Context, query
 	return subs, result, nil
 }
 
-// Helper: invalidate tenant cache
+// Helper: invalidate tenant cache. Writes by tenant don't know the subscription's ID,
+// so its cached entity is dropped along with the tenant's queries.
 func (r *subscriptionRepository) invalidateTenantCache(ctx context.Context, tenantID uuid.UUID) {
-	if r.cache != nil {
-		_ = r.cache.Delete(ctx, r.getCacheKey("tenant", tenantID.String()))
-	}
+	r.RepositoryCache().Invalidate(ctx, tenantID)
 }
 
 // Helper: record metric
@@ -1296,19 +1300,6 @@ func (r *subscriptionRepository) recordMetric(op string, duration time.Duration,
 	}
 }
 
-// Helper: record cache hit/miss
-func (r *subscriptionRepository) recordCacheHit() {
-	if r.metrics != nil {
-		r.metrics.RecordCacheHit("subscriptions")
-	}
-}
-
-func (r *subscriptionRepository) recordCacheMiss() {
-	if r.metrics != nil {
-		r.metrics.RecordCacheMiss("subscriptions")
-	}
-}
-
 // Helper: check feature by name (case-insensitive)
 func (r *subscriptionRepository) checkFeature(features models.SubscriptionFeatures, feature string) bool {
 	v := reflect.ValueOf(features)
@@ -1342,11 +1333,6 @@ func (r *subscriptionRepository) getEnabledFeatureList(features models.Subscript
 }
 
 // Helper methods
-func (r *subscriptionRepository) getCacheKey(prefix string, parts ...string) string {
-	allParts := append([]string{"repo", "subscriptions", prefix}, parts...)
-	return strings.Join(allParts, ":")
-}
-
 func calculatePercentage(current, max int) float64 {
 	if max == -1 || max == 0 {
 		return 0.0
@@ -1367,10 +1353,7 @@ func (r *subscriptionRepository) ResetFailedPayments(ctx context.Context, tenant
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		cacheKey := r.getCacheKey("tenant", tenantID.String())
-		r.cache.Delete(ctx, cacheKey)
-	}
+	r.invalidateTenantCache(ctx, tenantID)
 
 	return nil
 }
@@ -6,7 +6,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2/log"
@@ -175,25 +174,15 @@ func (r *systemSettingRepository) GetByKey(ctx context.Context, key string) (*mo
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "key cannot be empty", errors.ErrInvalidInput)
 	}
 
-	cacheKey := r.getCacheKey("key", key)
-	if r.cache != nil {
-		var setting models.SystemSetting
-		if err := r.cache.GetJSON(ctx, cacheKey, &setting); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit("system_settings")
-			}
-			return &setting, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss("system_settings")
-		}
-	}
-
+	// Settings are cached as stored, so encrypted values never reach the cache in clear
 	var setting models.SystemSetting
-	if err := r.db.WithContext(ctx).
-		Preload("ModifiedBy").
-		Where("key = ?", key).
-		First(&setting).Error; err != nil {
+	err := r.RepositoryCache().Query(ctx, uuid.Nil, "by_key", &setting, func() error {
+		return r.db.WithContext(ctx).
+			Preload("ModifiedBy").
+			Where("key = ?", key).
+			First(&setting).Error
+	}, key)
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewRepositoryError("NOT_FOUND", fmt.Sprintf("setting not found: %s", key), errors.ErrNotFound)
 		}
@@ -214,12 +203,6 @@ func (r *systemSettingRepository) GetByKey(ctx context.Context, key string) (*mo
 		setting.Value = decrypted
 	}
 
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, setting, 15*time.Minute); err != nil && r.logger != nil {
-			r.logger.Warn("failed to cache setting", "key", key, "error", err)
-		}
-	}
-
 	return &setting, nil
 }
 
@@ -302,15 +285,7 @@ func (r *systemSettingRepository) EncryptSetting(ctx context.Context, key string
 	setting.Value = encrypted
 	setting.IsEncrypted = true
 
-	if err := r.Update(ctx, setting); err != nil {
-		return err
-	}
-
-	if r.cache != nil {
-		r.cache.Delete(ctx, r.getCacheKey("key", key))
-	}
-
-	return nil
+	return r.Update(ctx, setting)
 }
 
 func (r *systemSettingRepository) DecryptSetting(ctx context.Context, key string) error {
@@ -339,15 +314,7 @@ func (r *systemSettingRepository) DecryptSetting(ctx context.Context, key string
 	setting.IsEncrypted = false
 
 	// FIXED: Pass pointer to pointer
-	if err := r.Update(ctx, setting); err != nil {
-		return err
-	}
-
-	if r.cache != nil {
-		r.cache.Delete(ctx, r.getCacheKey("key", key))
-	}
-
-	return nil
+	return r.Update(ctx, setting)
 }
 
 func (r *systemSettingRepository) TogglePublic(ctx context.Context, key string, isPublic bool) error {
@@ -359,15 +326,7 @@ func (r *systemSettingRepository) TogglePublic(ctx context.Context, key string,
 	setting.IsPublic = isPublic
 
 	// FIXED: Pass pointer to pointer
-	if err := r.Update(ctx, setting); err != nil {
-		return err
-	}
-
-	if r.cache != nil {
-		r.cache.Delete(ctx, r.getCacheKey("key", key))
-	}
-
-	return nil
+	return r.Update(ctx, setting)
 }
 
 func (r *systemSettingRepository) GetSettingHistory(ctx context.Context, key string, limit int) ([]SettingHistory, error) {
@@ -690,9 +649,7 @@ func (r *systemSettingRepository) RestoreSettings(ctx context.Context, backup []
 		return err
 	}
 
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("*"))
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	if r.logger != nil {
 		r.logger.Info("settings restored from backup", "count", len(settings))
@@ -700,46 +657,25 @@ func (r *systemSettingRepository) RestoreSettings(ctx context.Context, backup []
 	return nil
 }
 
+// RefreshCache reloads a setting into the cache. Cached settings are invalidated as a
+// table, so every other cached setting is reloaded on its next read.
 func (r *systemSettingRepository) RefreshCache(ctx context.Context, key string) error {
-	if r.cache == nil {
-		return nil
-	}
-
-	cacheKey := r.getCacheKey("key", key)
-	if err := r.cache.Delete(ctx, cacheKey); err != nil && r.logger != nil {
-		r.logger.Warn("failed to delete cache key", "key", cacheKey, "error", err)
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	_, err := r.GetByKey(ctx, key)
 	return err
 }
 
+// RefreshCategoryCache reloads a category into the cache, like RefreshCache
 func (r *systemSettingRepository) RefreshCategoryCache(ctx context.Context, category string) error {
-	if r.cache == nil {
-		return nil
-	}
-
-	cacheKey := r.getCacheKey("category", category)
-	if err := r.cache.Delete(ctx, cacheKey); err != nil && r.logger != nil {
-		r.logger.Warn("failed to delete category cache", "category", category, "error", err)
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	_, err := r.GetByCategory(ctx, category)
 	return err
 }
 
 func (r *systemSettingRepository) ClearSettingsCache(ctx context.Context) error {
-	if r.cache == nil {
-		return nil
-	}
-
-	pattern := r.getCacheKeyPattern("*")
-	if err := r.cache.DeletePattern(ctx, pattern); err != nil {
-		if r.logger != nil {
-			r.logger.Error("failed to clear settings cache", "error", err)
-		}
-		return errors.NewRepositoryError("CACHE_CLEAR_FAILED", "failed to clear cache", err)
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	if r.logger != nil {
 		r.logger.Info("settings cache cleared")
@@ -747,16 +683,6 @@ func (r *systemSettingRepository) ClearSettingsCache(ctx context.Context) error
 	return nil
 }
 
-// Helper methods
-func (r *systemSettingRepository) getCacheKey(prefix string, parts ...string) string {
-	allParts := append([]string{"repo", "system_settings", prefix}, parts...)
-	return strings.Join(allParts, ":")
-}
-
-func (r *systemSettingRepository) getCacheKeyPattern(pattern string) string {
-	return fmt.Sprintf("repo:system_settings:%s", pattern)
-}
-
 func (r *systemSettingRepository) SetSetting(ctx context.Context, key, value string, settingType models.SettingType) error {
 	if key == "" {
 		return errors.NewRepositoryError("INVALID_INPUT", "key cannot be empty", errors.ErrInvalidInput)
@@ -784,11 +710,6 @@ func (r *systemSettingRepository) SetSetting(ctx context.Context, key, value str
 		}
 	}
 
-	if r.cache != nil {
-		r.cache.Delete(ctx, r.getCacheKey("key", key))
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("category:*"))
-	}
-
 	return nil
 }
 
@@ -797,7 +718,7 @@ func (r *systemSettingRepository) DeleteByKey(ctx context.Context, key string) e
 		return errors.NewRepositoryError("INVALID_INPUT", "key cannot be empty", errors.ErrInvalidInput)
 	}
 
-	result := r.db.WithContext(ctx).Where("key = ?", key).Delete(&models.SystemSetting{})
+	result := r.db.WithContext(ctx).Unscoped().Where("key = ?", key).Delete(&models.SystemSetting{})
 	if result.Error != nil {
 		if r.logger != nil {
 			r.logger.Error("failed to delete setting", "key", key, "error", result.Error)
@@ -809,10 +730,7 @@ func (r *systemSettingRepository) DeleteByKey(ctx context.Context, key string) e
 		return errors.NewRepositoryError("NOT_FOUND", "setting not found", errors.ErrNotFound)
 	}
 
-	if r.cache != nil {
-		r.cache.Delete(ctx, r.getCacheKey("key", key))
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("*"))
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	if r.logger != nil {
 		r.logger.Info("setting deleted", "key", key)
@@ -905,26 +823,15 @@ func (r *systemSettingRepository) GetByCategory(ctx context.Context, category st
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "category cannot be empty", errors.ErrInvalidInput)
 	}
 
-	cacheKey := r.getCacheKey("category", category)
-	if r.cache != nil {
-		var settings []*models.SystemSetting
-		if err := r.cache.GetJSON(ctx, cacheKey, &settings); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit("system_settings")
-			}
-			return settings, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss("system_settings")
-		}
-	}
-
 	var settings []*models.SystemSetting
-	if err := r.db.WithContext(ctx).
-		Preload("ModifiedBy").
-		Where("category = ?", category).
-		Order("key ASC").
-		Find(&settings).Error; err != nil {
+	err := r.RepositoryCache().Query(ctx, uuid.Nil, "by_category", &settings, func() error {
+		return r.db.WithContext(ctx).
+			Preload("ModifiedBy").
+			Where("category = ?", category).
+			Order("key ASC").
+			Find(&settings).Error
+	}, category)
+	if err != nil {
 		if r.logger != nil {
 			r.logger.Error("failed to get settings by category", "category", category, "error", err)
 		}
@@ -944,12 +851,6 @@ func (r *systemSettingRepository) GetByCategory(ctx context.Context, category st
 		}
 	}
 
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, settings, 15*time.Minute); err != nil && r.logger != nil {
-			r.logger.Warn("failed to cache category settings", "category", category, "error", err)
-		}
-	}
-
 	return settings, nil
 }
 
@@ -1102,7 +1003,7 @@ func (r *systemSettingRepository) BulkDeleteByKeys(ctx context.Context, keys []s
 		return nil
 	}
 
-	result := r.db.WithContext(ctx).Where("key IN ?", keys).Delete(&models.SystemSetting{})
+	result := r.db.WithContext(ctx).Unscoped().Where("key IN ?", keys).Delete(&models.SystemSetting{})
 	if result.Error != nil {
 		if r.logger != nil {
 			r.logger.Error("failed to bulk delete settings", "count", len(keys), "error", result.Error)
@@ -1110,9 +1011,7 @@ func (r *systemSettingRepository) BulkDeleteByKeys(ctx context.Context, keys []s
 		return errors.NewRepositoryError("DELETE_FAILED", "failed to bulk delete settings", result.Error)
 	}
 
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, r.getCacheKeyPattern("*"))
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	if r.logger != nil {
 		r.logger.Info("bulk deleted settings", "count", result.RowsAffected)
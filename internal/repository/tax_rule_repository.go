@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaxRuleRepository defines the interface for tax rule repository operations
+type TaxRuleRepository interface {
+	BaseRepository[models.TaxRule]
+
+	// FindByTenantID returns all of a tenant's tax rules, defaults first
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.TaxRule, error)
+
+	// FindActive returns the tenant's active tax rules; models.ResolveTaxRule picks the
+	// one that applies
+	FindActive(ctx context.Context, tenantID uuid.UUID) ([]*models.TaxRule, error)
+}
+
+// taxRuleRepository implements TaxRuleRepository
+type taxRuleRepository struct {
+	BaseRepository[models.TaxRule]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewTaxRuleRepository creates a new TaxRuleRepository instance
+func NewTaxRuleRepository(db *gorm.DB, config ...RepositoryConfig) TaxRuleRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.TaxRule](db, cfg)
+
+	return &taxRuleRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenantID retrieves all tax rules for a tenant
+func (r *taxRuleRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.TaxRule, error) {
+	return r.find(ctx, tenantID, false)
+}
+
+// FindActive retrieves the active tax rules for a tenant
+func (r *taxRuleRepository) FindActive(ctx context.Context, tenantID uuid.UUID) ([]*models.TaxRule, error) {
+	return r.find(ctx, tenantID, true)
+}
+
+func (r *taxRuleRepository) find(ctx context.Context, tenantID uuid.UUID, activeOnly bool) ([]*models.TaxRule, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var rules []*models.TaxRule
+	if err := query.
+		Order("country ASC, region ASC, category ASC, created_at ASC").
+		Find(&rules).Error; err != nil {
+		r.logger.Error("failed to find tax rules", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find tax rules", err)
+	}
+
+	return rules, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
@@ -10,6 +11,7 @@ import (
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ============================================================================
@@ -26,6 +28,7 @@ type TenantInvitationRepository interface {
 	FindByTenantAndEmail(ctx context.Context, tenantID uuid.UUID, email string) ([]*models.TenantInvitation, error)
 	FindByEmail(ctx context.Context, email string) ([]*models.TenantInvitation, error)
 	FindPendingByTenant(ctx context.Context, tenantID uuid.UUID) ([]*models.TenantInvitation, error)
+	CountPendingByTenantAndRole(ctx context.Context, tenantID uuid.UUID, role models.UserRole) (int64, error)
 	FindExpiredInvitations(ctx context.Context) ([]*models.TenantInvitation, error)
 
 	// Bulk Operations
@@ -73,6 +76,7 @@ type DataExportRequestRepository interface {
 	// Query Operations
 	FindByTenant(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.DataExportRequest, PaginationResult, error)
 	FindPendingByTenant(ctx context.Context, tenantID uuid.UUID) ([]*models.DataExportRequest, error)
+	FindBySubjectUser(ctx context.Context, userID uuid.UUID) ([]*models.DataExportRequest, error)
 	FindByStatus(ctx context.Context, status string, pagination PaginationParams) ([]*models.DataExportRequest, PaginationResult, error)
 	FindExpiredExports(ctx context.Context) ([]*models.DataExportRequest, error)
 
@@ -82,9 +86,28 @@ type DataExportRequestRepository interface {
 	SetError(ctx context.Context, id uuid.UUID, errorMessage string) error
 	MarkCompleted(ctx context.Context, id uuid.UUID, fileURL string, expiresAt time.Time) error
 
+	// FailProcessing marks exports still processing that were last updated before the
+	// given time as failed, returning how many there were
+	FailProcessing(ctx context.Context, updatedBefore time.Time) (int64, error)
+
 	// Cleanup Operations
 	DeleteExpiredExports(ctx context.Context) (int64, error)
 	DeleteByTenant(ctx context.Context, tenantID uuid.UUID) error
+
+	// Tenant Data
+
+	// ListTenantTables returns the tables with a tenant_id column, leaving out the
+	// partitions of partitioned tables
+	ListTenantTables(ctx context.Context) ([]string, error)
+
+	// StreamTenantRows calls fn with every row of a tenant in a table as a JSON object
+	// without the columns in omit, and returns how many rows there were
+	StreamTenantRows(ctx context.Context, table string, tenantID uuid.UUID, omit []string, fn func(row []byte) error) (int64, error)
+
+	// StreamUserRows calls fn with every row of a table naming the user in any of the
+	// columns as a JSON object without the columns in omit, and returns how many rows
+	// there were
+	StreamUserRows(ctx context.Context, table string, columns []string, userID uuid.UUID, omit []string, fn func(row []byte) error) (int64, error)
 }
 
 // ============================================================================
@@ -202,6 +225,19 @@ func (r *tenantInvitationRepository) FindPendingByTenant(ctx context.Context, te
 	return invitations, nil
 }
 
+// CountPendingByTenantAndRole counts the invitations to a role still open in a tenant
+func (r *tenantInvitationRepository) CountPendingByTenantAndRole(ctx context.Context, tenantID uuid.UUID, role models.UserRole) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.TenantInvitation{}).
+		Where("tenant_id = ? AND role = ? AND accepted_at IS NULL AND expires_at > ?", tenantID, role, time.Now()).
+		Count(&count).Error; err != nil {
+		return 0, errors.NewRepositoryError("COUNT_FAILED", "failed to count pending invitations", err)
+	}
+
+	return count, nil
+}
+
 // FindExpiredInvitations retrieves all expired invitations
 func (r *tenantInvitationRepository) FindExpiredInvitations(ctx context.Context) ([]*models.TenantInvitation, error) {
 	var invitations []*models.TenantInvitation
@@ -216,7 +252,7 @@ func (r *tenantInvitationRepository) FindExpiredInvitations(ctx context.Context)
 
 // DeleteExpiredInvitations deletes all expired invitations
 func (r *tenantInvitationRepository) DeleteExpiredInvitations(ctx context.Context) (int64, error) {
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("accepted_at IS NULL AND expires_at < ?", time.Now()).
 		Delete(&models.TenantInvitation{})
 
@@ -229,7 +265,7 @@ func (r *tenantInvitationRepository) DeleteExpiredInvitations(ctx context.Contex
 
 // DeleteByTenant deletes all invitations for a tenant
 func (r *tenantInvitationRepository) DeleteByTenant(ctx context.Context, tenantID uuid.UUID) error {
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("tenant_id = ?", tenantID).
 		Delete(&models.TenantInvitation{})
 
@@ -451,7 +487,7 @@ func (r *tenantUsageTrackingRepository) UpdateBandwidthUsage(ctx context.Context
 
 // DeleteOldRecords deletes records older than specified time
 func (r *tenantUsageTrackingRepository) DeleteOldRecords(ctx context.Context, olderThan time.Time) (int64, error) {
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("date < ?", olderThan).
 		Delete(&models.TenantUsageTracking{})
 
@@ -528,6 +564,8 @@ func (r *dataExportRequestRepository) FindPendingByTenant(ctx context.Context, t
 	var exports []*models.DataExportRequest
 	if err := r.db.WithContext(ctx).
 		Where("tenant_id = ? AND status IN ?", tenantID, []string{"pending", "processing"}).
+		// Exports of a user's personal data don't hold up the tenant's
+		Where("subject_user_id IS NULL").
 		Order("created_at DESC").
 		Find(&exports).Error; err != nil {
 		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find pending exports", err)
@@ -536,6 +574,19 @@ func (r *dataExportRequestRepository) FindPendingByTenant(ctx context.Context, t
 	return exports, nil
 }
 
+// FindBySubjectUser retrieves the exports of a user's personal data, newest first
+func (r *dataExportRequestRepository) FindBySubjectUser(ctx context.Context, userID uuid.UUID) ([]*models.DataExportRequest, error) {
+	var exports []*models.DataExportRequest
+	if err := r.db.WithContext(ctx).
+		Where("subject_user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&exports).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find user exports", err)
+	}
+
+	return exports, nil
+}
+
 // FindByStatus retrieves exports by status
 func (r *dataExportRequestRepository) FindByStatus(ctx context.Context, status string, pagination PaginationParams) ([]*models.DataExportRequest, PaginationResult, error) {
 	pagination.Validate()
@@ -653,9 +704,24 @@ func (r *dataExportRequestRepository) MarkCompleted(ctx context.Context, id uuid
 	return nil
 }
 
+// FailProcessing marks exports still processing that were last updated before the given
+// time as failed
+func (r *dataExportRequestRepository) FailProcessing(ctx context.Context, updatedBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.DataExportRequest{}).
+		Where("status = 'processing' AND updated_at < ?", updatedBefore).
+		Update("status", "failed")
+
+	if result.Error != nil {
+		return 0, errors.NewRepositoryError("UPDATE_FAILED", "failed to fail processing exports", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
 // DeleteExpiredExports deletes expired export records and their files
 func (r *dataExportRequestRepository) DeleteExpiredExports(ctx context.Context) (int64, error) {
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("status = 'completed' AND expires_at < ?", time.Now()).
 		Delete(&models.DataExportRequest{})
 
@@ -668,7 +734,7 @@ func (r *dataExportRequestRepository) DeleteExpiredExports(ctx context.Context)
 
 // DeleteByTenant deletes all export requests for a tenant
 func (r *dataExportRequestRepository) DeleteByTenant(ctx context.Context, tenantID uuid.UUID) error {
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("tenant_id = ?", tenantID).
 		Delete(&models.DataExportRequest{})
 
@@ -678,3 +744,80 @@ func (r *dataExportRequestRepository) DeleteByTenant(ctx context.Context, tenant
 
 	return nil
 }
+
+// ListTenantTables reads the tables with a tenant_id column from the catalog
+func (r *dataExportRequestRepository) ListTenantTables(ctx context.Context) ([]string, error) {
+	var tables []string
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_attribute a ON a.attrelid = c.oid AND a.attname = 'tenant_id' AND NOT a.attisdropped
+		WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+		ORDER BY c.relname`).
+		Scan(&tables).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to list tenant tables", err)
+	}
+	return tables, nil
+}
+
+// StreamTenantRows reads the tenant's rows of the table, soft-deleted ones included
+func (r *dataExportRequestRepository) StreamTenantRows(ctx context.Context, table string, tenantID uuid.UUID, omit []string, fn func(row []byte) error) (int64, error) {
+	rows, err := r.db.WithContext(ctx).
+		Raw("SELECT (to_jsonb(t) - ARRAY[?]::text[])::text FROM ? t WHERE t.tenant_id = ?", omit, clause.Table{Name: table}, tenantID).
+		Rows()
+	if err != nil {
+		return 0, errors.NewRepositoryError("FIND_FAILED", "failed to read "+table, err)
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return count, errors.NewRepositoryError("FIND_FAILED", "failed to read "+table, err)
+		}
+		if err := fn(row); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, errors.NewRepositoryError("FIND_FAILED", "failed to read "+table, err)
+	}
+	return count, nil
+}
+
+// StreamUserRows reads the rows of the table naming the user, soft-deleted ones included
+func (r *dataExportRequestRepository) StreamUserRows(ctx context.Context, table string, columns []string, userID uuid.UUID, omit []string, fn func(row []byte) error) (int64, error) {
+	conditions := make([]string, 0, len(columns))
+	vars := []any{omit, clause.Table{Name: table}}
+	for _, column := range columns {
+		conditions = append(conditions, "? = ?")
+		vars = append(vars, clause.Column{Table: "t", Name: column}, userID)
+	}
+
+	rows, err := r.db.WithContext(ctx).
+		Raw("SELECT (to_jsonb(t) - ARRAY[?]::text[])::text FROM ? t WHERE "+strings.Join(conditions, " OR "), vars...).
+		Rows()
+	if err != nil {
+		return 0, errors.NewRepositoryError("FIND_FAILED", "failed to read "+table, err)
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return count, errors.NewRepositoryError("FIND_FAILED", "failed to read "+table, err)
+		}
+		if err := fn(row); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, errors.NewRepositoryError("FIND_FAILED", "failed to read "+table, err)
+	}
+	return count, nil
+}
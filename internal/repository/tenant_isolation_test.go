@@ -0,0 +1,158 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/database"
+	"Krafti_Vibe/internal/pkg/tenancy"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupTenantIsolationTest enables row-level security on the test schema and returns a
+// connection as an ordinary role, since the container's superuser bypasses the policies.
+// Each tenant gets one service.
+func setupTenantIsolationTest(t *testing.T) (*testutil.TestDB, *gorm.DB, *models.Service, *models.Service) {
+	tdb := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	migrations, err := database.EmbeddedMigrations()
+	require.NoError(t, err)
+	sqlDB, err := tdb.DB.DB()
+	require.NoError(t, err)
+	for _, migration := range migrations {
+		if migration.Name == "tenant_row_level_security" {
+			_, err := sqlDB.ExecContext(ctx, migration.UpSQL)
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tdb.DB.Exec(`CREATE ROLE app_tenant LOGIN PASSWORD 'app_tenant'`).Error)
+	require.NoError(t, tdb.DB.Exec(`GRANT USAGE ON SCHEMA public TO app_tenant`).Error)
+	require.NoError(t, tdb.DB.Exec(`GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO app_tenant`).Error)
+
+	connStr, err := tdb.Container.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	config, err := pgx.ParseConfig(strings.Replace(connStr, "testuser:testpass@", "app_tenant:app_tenant@", 1))
+	require.NoError(t, err)
+	appSQL := stdlib.OpenDB(*config, stdlib.OptionResetSession(database.ResetTenantSession))
+	// A single connection shows a tenant never outlives its statement
+	appSQL.SetMaxOpenConns(1)
+	t.Cleanup(func() { appSQL.Close() })
+
+	appDB, err := gorm.Open(postgres.New(postgres.Config{Conn: appSQL}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, database.RegisterTenantIsolation(appDB))
+
+	_, tenantA := testutil.CreateTestTenantWithOwner(tdb.DB)
+	ownerB := testutil.CreateTestOwner(func(u *models.User) { u.Email = "owner-b@example.com" })
+	require.NoError(t, tdb.DB.Create(ownerB).Error)
+	tenantB := testutil.CreateTestTenant(func(tn *models.Tenant) {
+		tn.OwnerID = ownerB.ID
+		tn.Subdomain = "tenant-b"
+	})
+	require.NoError(t, tdb.DB.Create(tenantB).Error)
+
+	services := make([]*models.Service, 0, 2)
+	for i, tenantID := range []uuid.UUID{tenantA.ID, tenantB.ID} {
+		artisanUser := testutil.CreateTestUser(&tenantID, func(u *models.User) {
+			u.Email = []string{"artisan-a@example.com", "artisan-b@example.com"}[i]
+			u.Role = models.UserRoleArtisan
+		})
+		require.NoError(t, tdb.DB.Create(artisanUser).Error)
+		artisan := testutil.CreateTestArtisan(artisanUser.ID, tenantID)
+		require.NoError(t, tdb.DB.Create(artisan).Error)
+		service := testutil.CreateTestService(tenantID, artisan.ID)
+		require.NoError(t, tdb.DB.Create(service).Error)
+		services = append(services, service)
+	}
+
+	return tdb, appDB, services[0], services[1]
+}
+
+func TestTenantIsolation(t *testing.T) {
+	tdb, appDB, serviceA, serviceB := setupTenantIsolationTest(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	ctxA := tenancy.WithTenant(ctx, serviceA.TenantID)
+	repo := repository.NewServiceRepository(appDB, testutil.DefaultRepositoryConfig())
+
+	t.Run("reads only see the tenant's rows", func(t *testing.T) {
+		var services []models.Service
+		require.NoError(t, appDB.WithContext(ctxA).Find(&services).Error)
+		require.Len(t, services, 1)
+		assert.Equal(t, serviceA.ID, services[0].ID)
+
+		var count int64
+		require.NoError(t, appDB.WithContext(ctxA).Raw("SELECT count(*) FROM services").Scan(&count).Error)
+		assert.Equal(t, int64(1), count)
+
+		rows, err := appDB.WithContext(ctxA).Model(&models.Service{}).Select("id").Rows()
+		require.NoError(t, err)
+		var ids []uuid.UUID
+		for rows.Next() {
+			var id uuid.UUID
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		require.NoError(t, rows.Close())
+		assert.Equal(t, []uuid.UUID{serviceA.ID}, ids)
+	})
+
+	t.Run("another tenant's row cannot be fetched by id", func(t *testing.T) {
+		_, err := repo.GetByID(ctxA, serviceB.ID)
+		assert.Error(t, err)
+
+		found, err := repo.GetByID(ctxA, serviceA.ID)
+		require.NoError(t, err)
+		assert.Equal(t, serviceA.ID, found.ID)
+	})
+
+	t.Run("writes cannot reach another tenant", func(t *testing.T) {
+		foreign := testutil.CreateTestService(serviceB.TenantID, *serviceB.ArtisanID)
+		assert.Error(t, appDB.WithContext(ctxA).Create(foreign).Error)
+
+		result := appDB.WithContext(ctxA).Model(&models.Service{}).Where("id = ?", serviceB.ID).Update("name", "Hijacked")
+		require.NoError(t, result.Error)
+		assert.Zero(t, result.RowsAffected)
+
+		result = appDB.WithContext(ctxA).Model(&models.Service{}).Where("id = ?", serviceA.ID).Update("tenant_id", serviceB.TenantID)
+		assert.Error(t, result.Error)
+	})
+
+	t.Run("transactions are scoped to the tenant", func(t *testing.T) {
+		err := appDB.WithContext(ctxA).Transaction(func(tx *gorm.DB) error {
+			var count int64
+			if err := tx.Model(&models.Service{}).Count(&count).Error; err != nil {
+				return err
+			}
+			assert.Equal(t, int64(1), count)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("the tenant does not leak to unscoped statements", func(t *testing.T) {
+		var count int64
+		require.NoError(t, appDB.WithContext(ctx).Model(&models.Service{}).Count(&count).Error)
+		assert.Equal(t, int64(2), count)
+
+		var unchanged models.Service
+		require.NoError(t, appDB.WithContext(ctx).First(&unchanged, "id = ?", serviceB.ID).Error)
+		assert.Equal(t, serviceB.Name, unchanged.Name)
+	})
+}
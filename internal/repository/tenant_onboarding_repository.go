@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	stdErrors "errors"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TenantOnboardingRepository defines the interface for onboarding progress repository operations
+type TenantOnboardingRepository interface {
+	BaseRepository[models.TenantOnboarding]
+
+	// FindByTenantID returns a tenant's onboarding progress
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID) (*models.TenantOnboarding, error)
+}
+
+// tenantOnboardingRepository implements TenantOnboardingRepository
+type tenantOnboardingRepository struct {
+	BaseRepository[models.TenantOnboarding]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewTenantOnboardingRepository creates a new TenantOnboardingRepository instance
+func NewTenantOnboardingRepository(db *gorm.DB, config ...RepositoryConfig) TenantOnboardingRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.TenantOnboarding](db, cfg)
+
+	return &tenantOnboardingRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenantID retrieves the onboarding progress of a tenant
+func (r *tenantOnboardingRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) (*models.TenantOnboarding, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var onboarding models.TenantOnboarding
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&onboarding).Error; err != nil {
+		if stdErrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "onboarding not found", errors.ErrNotFound)
+		}
+		r.logger.Error("failed to find tenant onboarding", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find tenant onboarding", err)
+	}
+
+	return &onboarding, nil
+}
@@ -28,9 +28,10 @@ type TenantRepository interface {
 	FindExpiringTrials(ctx context.Context, days int) ([]*models.Tenant, error)
 
 	// Status Management
-	ActivateTenant(ctx context.Context, tenantID uuid.UUID) error
+	ActivateTenant(ctx context.Context, tenantID uuid.UUID, reason string) error
 	SuspendTenant(ctx context.Context, tenantID uuid.UUID, reason string) error
 	CancelTenant(ctx context.Context, tenantID uuid.UUID, reason string) error
+	CloseTenant(ctx context.Context, tenantID uuid.UUID, reason string) error
 	ConvertTrialToActive(ctx context.Context, tenantID uuid.UUID, plan models.TenantPlan) error
 	UpdateStatus(ctx context.Context, tenantID uuid.UUID, status models.TenantStatus) error
 
@@ -347,7 +348,7 @@ func (r *tenantRepository) FindExpiringTrials(ctx context.Context, days int) ([]
 }
 
 // ActivateTenant activates a tenant
-func (r *tenantRepository) ActivateTenant(ctx context.Context, tenantID uuid.UUID) error {
+func (r *tenantRepository) ActivateTenant(ctx context.Context, tenantID uuid.UUID, reason string) error {
 	if tenantID == uuid.Nil {
 		return errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
 	}
@@ -365,9 +366,24 @@ func (r *tenantRepository) ActivateTenant(ctx context.Context, tenantID uuid.UUI
 		return errors.NewRepositoryError("INVALID_STATUS", err.Error(), errors.ErrInvalidInput)
 	}
 
+	updates := map[string]interface{}{
+		"status": models.TenantStatusActive,
+	}
+
+	// Store activation reason in metadata
+	if reason != "" {
+		metadata := tenant.Metadata
+		if metadata == nil {
+			metadata = make(models.JSONB)
+		}
+		metadata["activation_reason"] = reason
+		metadata["activated_at"] = time.Now()
+		updates["metadata"] = metadata
+	}
+
 	result := r.db.WithContext(ctx).
 		Model(&tenant).
-		Update("status", models.TenantStatusActive)
+		Updates(updates)
 
 	if result.Error != nil {
 		r.logger.Error("failed to activate tenant", "tenant_id", tenantID, "error", result.Error)
@@ -375,9 +391,7 @@ func (r *tenantRepository) ActivateTenant(ctx context.Context, tenantID uuid.UUI
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	r.logger.Info("tenant activated", "tenant_id", tenantID)
 	return nil
@@ -427,9 +441,7 @@ func (r *tenantRepository) SuspendTenant(ctx context.Context, tenantID uuid.UUID
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	r.logger.Warn("tenant suspended", "tenant_id", tenantID, "reason", reason)
 	return nil
@@ -479,14 +491,62 @@ func (r *tenantRepository) CancelTenant(ctx context.Context, tenantID uuid.UUID,
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	r.logger.Warn("tenant cancelled", "tenant_id", tenantID, "reason", reason)
 	return nil
 }
 
+// CloseTenant closes a cancelled tenant for good
+func (r *tenantRepository) CloseTenant(ctx context.Context, tenantID uuid.UUID, reason string) error {
+	if tenantID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var tenant models.Tenant
+	if err := r.db.WithContext(ctx).First(&tenant, tenantID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewRepositoryError("NOT_FOUND", "tenant not found", errors.ErrNotFound)
+		}
+		return errors.NewRepositoryError("FIND_FAILED", "failed to find tenant", err)
+	}
+
+	// Validate status transition
+	if err := tenant.CanTransitionTo(models.TenantStatusClosed); err != nil {
+		return errors.NewRepositoryError("INVALID_STATUS", err.Error(), errors.ErrInvalidInput)
+	}
+
+	updates := map[string]interface{}{
+		"status": models.TenantStatusClosed,
+	}
+
+	// Store closure reason in metadata
+	if reason != "" {
+		metadata := tenant.Metadata
+		if metadata == nil {
+			metadata = make(models.JSONB)
+		}
+		metadata["closure_reason"] = reason
+		metadata["closed_at"] = time.Now()
+		updates["metadata"] = metadata
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&tenant).
+		Updates(updates)
+
+	if result.Error != nil {
+		r.logger.Error("failed to close tenant", "tenant_id", tenantID, "error", result.Error)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to close tenant", result.Error)
+	}
+
+	// Invalidate cache
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
+
+	r.logger.Warn("tenant closed", "tenant_id", tenantID, "reason", reason)
+	return nil
+}
+
 // ConvertTrialToActive converts a trial tenant to active with a plan
 func (r *tenantRepository) ConvertTrialToActive(ctx context.Context, tenantID uuid.UUID, plan models.TenantPlan) error {
 	if tenantID == uuid.Nil {
@@ -520,9 +580,7 @@ func (r *tenantRepository) ConvertTrialToActive(ctx context.Context, tenantID uu
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	r.logger.Info("trial converted to active", "tenant_id", tenantID, "plan", plan)
 	return nil
@@ -549,9 +607,7 @@ func (r *tenantRepository) UpdateStatus(ctx context.Context, tenantID uuid.UUID,
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	return nil
 }
@@ -599,9 +655,7 @@ func (r *tenantRepository) UpgradePlan(ctx context.Context, tenantID uuid.UUID,
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	r.logger.Info("plan upgraded", "tenant_id", tenantID, "new_plan", newPlan)
 	return nil
@@ -639,9 +693,7 @@ func (r *tenantRepository) UpdatePlanLimits(ctx context.Context, tenantID uuid.U
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	return nil
 }
@@ -667,9 +719,7 @@ func (r *tenantRepository) UpdateSettings(ctx context.Context, tenantID uuid.UUI
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	return nil
 }
@@ -695,9 +745,7 @@ func (r *tenantRepository) UpdateFeatures(ctx context.Context, tenantID uuid.UUI
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	return nil
 }
@@ -725,9 +773,7 @@ func (r *tenantRepository) EnableFeature(ctx context.Context, tenantID uuid.UUID
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	return nil
 }
@@ -758,9 +804,7 @@ func (r *tenantRepository) IncrementUserCount(ctx context.Context, tenantID uuid
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	return nil
 }
@@ -782,9 +826,7 @@ func (r *tenantRepository) DecrementUserCount(ctx context.Context, tenantID uuid
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	return nil
 }
@@ -810,9 +852,7 @@ func (r *tenantRepository) UpdateStorageUsed(ctx context.Context, tenantID uuid.
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	return nil
 }
@@ -873,9 +913,7 @@ func (r *tenantRepository) AddAdmin(ctx context.Context, tenantID, userID uuid.U
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	r.logger.Info("admin added", "tenant_id", tenantID, "user_id", userID)
 	return nil
@@ -903,9 +941,7 @@ func (r *tenantRepository) RemoveAdmin(ctx context.Context, tenantID, userID uui
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantID)
 
 	r.logger.Info("admin removed", "tenant_id", tenantID, "user_id", userID)
 	return nil
@@ -1373,9 +1409,7 @@ func (r *tenantRepository) BulkUpdateStatus(ctx context.Context, tenantIDs []uui
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantIDs...)
 
 	r.logger.Info("bulk updated tenant status", "count", result.RowsAffected, "status", status)
 	return nil
@@ -1402,9 +1436,7 @@ func (r *tenantRepository) BulkSuspend(ctx context.Context, tenantIDs []uuid.UUI
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, tenantIDs...)
 
 	r.logger.Warn("bulk suspended tenants", "count", result.RowsAffected, "reason", reason)
 	return nil
@@ -1434,7 +1466,7 @@ func (r *tenantRepository) DeleteInactiveTenants(ctx context.Context, inactiveDa
 		return nil
 	}
 
-	result := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("id IN ?", inactiveIDs).
 		Delete(&models.Tenant{})
 
@@ -1444,9 +1476,7 @@ func (r *tenantRepository) DeleteInactiveTenants(ctx context.Context, inactiveDa
 	}
 
 	// Invalidate cache
-	if r.cache != nil {
-		r.cache.DeletePattern(ctx, "repo:tenants:*")
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil)
 
 	r.logger.Info("deleted inactive tenants", "count", result.RowsAffected, "inactive_days", inactiveDays)
 	return nil
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	stdErrors "errors"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TenantRoleRepository defines the interface for custom tenant role repository operations
+type TenantRoleRepository interface {
+	BaseRepository[models.TenantRole]
+
+	// FindByTenantID returns all of a tenant's custom roles
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.TenantRole, error)
+
+	// DeleteRole permanently deletes a role along with its assignments
+	DeleteRole(ctx context.Context, roleID uuid.UUID) error
+
+	// Assign gives a user a role; assigning a role the user already has does nothing
+	Assign(ctx context.Context, assignment *models.TenantRoleAssignment) error
+
+	// Unassign takes a role away from a user
+	Unassign(ctx context.Context, roleID, userID uuid.UUID) error
+
+	// FindAssignments returns a role's assignments, with their users
+	FindAssignments(ctx context.Context, roleID uuid.UUID) ([]*models.TenantRoleAssignment, error)
+
+	// FindRolesForUser returns the roles assigned to a user in a tenant
+	FindRolesForUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*models.TenantRole, error)
+}
+
+// tenantRoleRepository implements TenantRoleRepository
+type tenantRoleRepository struct {
+	BaseRepository[models.TenantRole]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewTenantRoleRepository creates a new TenantRoleRepository instance
+func NewTenantRoleRepository(db *gorm.DB, config ...RepositoryConfig) TenantRoleRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.TenantRole](db, cfg)
+
+	return &tenantRoleRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenantID retrieves all custom roles for a tenant, by name
+func (r *tenantRoleRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.TenantRole, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var roles []*models.TenantRole
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Find(&roles).Error; err != nil {
+		r.logger.Error("failed to find tenant roles", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find tenant roles", err)
+	}
+
+	return roles, nil
+}
+
+// DeleteRole deletes the role and its assignments in one transaction. Both are removed
+// for good, so the role's name can be used again.
+func (r *tenantRoleRepository) DeleteRole(ctx context.Context, roleID uuid.UUID) error {
+	if roleID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "role_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("role_id = ?", roleID).Delete(&models.TenantRoleAssignment{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Delete(&models.TenantRole{}, "id = ?", roleID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrNotFound
+		}
+		return nil
+	})
+	if stdErrors.Is(err, errors.ErrNotFound) {
+		return errors.NewRepositoryError("NOT_FOUND", "tenant role not found", errors.ErrNotFound)
+	}
+	if err != nil {
+		r.logger.Error("failed to delete tenant role", "role_id", roleID, "error", err)
+		return errors.NewRepositoryError("DELETE_FAILED", "failed to delete tenant role", err)
+	}
+
+	r.InvalidateCache(ctx, roleID)
+	return nil
+}
+
+// Assign creates the assignment unless the user already has the role
+func (r *tenantRoleRepository) Assign(ctx context.Context, assignment *models.TenantRoleAssignment) error {
+	if assignment == nil || assignment.TenantID == uuid.Nil || assignment.RoleID == uuid.Nil || assignment.UserID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "tenant_id, role_id and user_id are required", errors.ErrInvalidInput)
+	}
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "role_id"}, {Name: "user_id"}},
+			DoNothing: true,
+		}).
+		Create(assignment).Error; err != nil {
+		r.logger.Error("failed to assign tenant role", "role_id", assignment.RoleID, "user_id", assignment.UserID, "error", err)
+		return errors.NewRepositoryError("CREATE_FAILED", "failed to assign tenant role", err)
+	}
+
+	return nil
+}
+
+// Unassign deletes the user's assignment to the role
+func (r *tenantRoleRepository) Unassign(ctx context.Context, roleID, userID uuid.UUID) error {
+	if roleID == uuid.Nil || userID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "role_id and user_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	result := r.db.WithContext(ctx).
+		Unscoped().
+		Where("role_id = ? AND user_id = ?", roleID, userID).
+		Delete(&models.TenantRoleAssignment{})
+	if result.Error != nil {
+		r.logger.Error("failed to unassign tenant role", "role_id", roleID, "user_id", userID, "error", result.Error)
+		return errors.NewRepositoryError("DELETE_FAILED", "failed to unassign tenant role", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewRepositoryError("NOT_FOUND", "tenant role assignment not found", errors.ErrNotFound)
+	}
+
+	return nil
+}
+
+// FindAssignments retrieves a role's assignments, oldest first
+func (r *tenantRoleRepository) FindAssignments(ctx context.Context, roleID uuid.UUID) ([]*models.TenantRoleAssignment, error) {
+	if roleID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "role_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var assignments []*models.TenantRoleAssignment
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("role_id = ?", roleID).
+		Order("created_at ASC").
+		Find(&assignments).Error; err != nil {
+		r.logger.Error("failed to find tenant role assignments", "role_id", roleID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find tenant role assignments", err)
+	}
+
+	return assignments, nil
+}
+
+// FindRolesForUser retrieves the tenant's roles the user is assigned, by name
+func (r *tenantRoleRepository) FindRolesForUser(ctx context.Context, tenantID, userID uuid.UUID) ([]*models.TenantRole, error) {
+	if tenantID == uuid.Nil || userID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id and user_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var roles []*models.TenantRole
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN tenant_role_assignments ON tenant_role_assignments.role_id = tenant_roles.id AND tenant_role_assignments.deleted_at IS NULL").
+		Where("tenant_roles.tenant_id = ? AND tenant_role_assignments.user_id = ?", tenantID, userID).
+		Order("tenant_roles.name ASC").
+		Find(&roles).Error; err != nil {
+		r.logger.Error("failed to find tenant roles for user", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find tenant roles for user", err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,90 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantRoleRepository(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	roles := repository.NewTenantRoleRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	newRole := func(name string, permissions ...string) *models.TenantRole {
+		role := &models.TenantRole{TenantID: tenantID, Name: name, Permissions: permissions}
+		require.NoError(t, roles.Create(ctx, role))
+		return role
+	}
+
+	refunds := newRole("Refunds", "payments.read", "payments.refund")
+	dispatch := newRole("Dispatch", "bookings.update")
+	newRole("Unused", "projects.read")
+
+	t.Run("role names are unique per tenant", func(t *testing.T) {
+		err := roles.Create(ctx, &models.TenantRole{TenantID: tenantID, Name: "Refunds"})
+		assert.True(t, errors.IsDuplicate(err))
+
+		require.NoError(t, roles.Create(ctx, &models.TenantRole{TenantID: uuid.New(), Name: "Refunds"}))
+	})
+
+	t.Run("assigned roles are found for the user", func(t *testing.T) {
+		for _, role := range []*models.TenantRole{refunds, dispatch, refunds} {
+			require.NoError(t, roles.Assign(ctx, &models.TenantRoleAssignment{TenantID: tenantID, RoleID: role.ID, UserID: userID}))
+		}
+
+		found, err := roles.FindRolesForUser(ctx, tenantID, userID)
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, dispatch.ID, found[0].ID)
+		assert.Equal(t, refunds.ID, found[1].ID)
+		assert.Equal(t, models.StringArray{"payments.read", "payments.refund"}, found[1].Permissions)
+
+		assignments, err := roles.FindAssignments(ctx, refunds.ID)
+		require.NoError(t, err)
+		assert.Len(t, assignments, 1, "assigning a role twice is a no-op")
+
+		found, err = roles.FindRolesForUser(ctx, uuid.New(), userID)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("unassigning removes the role from the user", func(t *testing.T) {
+		require.NoError(t, roles.Unassign(ctx, dispatch.ID, userID))
+		assert.True(t, errors.IsNotFound(roles.Unassign(ctx, dispatch.ID, userID)))
+
+		found, err := roles.FindRolesForUser(ctx, tenantID, userID)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, refunds.ID, found[0].ID)
+
+		require.NoError(t, roles.Assign(ctx, &models.TenantRoleAssignment{TenantID: tenantID, RoleID: dispatch.ID, UserID: userID}), "a role can be assigned again")
+	})
+
+	t.Run("deleting a role deletes its assignments and frees its name", func(t *testing.T) {
+		require.NoError(t, roles.DeleteRole(ctx, refunds.ID))
+		assert.True(t, errors.IsNotFound(roles.DeleteRole(ctx, refunds.ID)))
+
+		assignments, err := roles.FindAssignments(ctx, refunds.ID)
+		require.NoError(t, err)
+		assert.Empty(t, assignments)
+
+		newRole("Refunds")
+
+		all, err := roles.FindByTenantID(ctx, tenantID)
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
+}
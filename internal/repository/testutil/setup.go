@@ -2,8 +2,11 @@ package testutil
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"path"
+	"sync"
 	"testing"
 	"time"
 
@@ -33,7 +36,7 @@ func NewTestDB(t *testing.T) *TestDB {
 
 	// Start PostgreSQL container
 	pgContainer, err := postgrescontainer.RunContainer(ctx,
-		testcontainers.WithImage("postgres:16-alpine"),
+		testcontainers.WithImage("postgis/postgis:16-3.4-alpine"),
 		postgrescontainer.WithDatabase("testdb"),
 		postgrescontainer.WithUsername("testuser"),
 		postgrescontainer.WithPassword("testpass"),
@@ -116,37 +119,88 @@ func (tdb *TestDB) Cleanup() error {
 // AutoMigrate runs migrations for all models
 // PostgreSQL supports CHECK constraints properly, so we can use standard AutoMigrate
 func AutoMigrate(db *gorm.DB) error {
+	// Artisan locations and service areas have PostGIS columns
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS "postgis"`).Error; err != nil {
+		return err
+	}
+
 	return db.AutoMigrate(
 		&models.User{},
 		&models.Tenant{},
 		&models.TenantInvitation{},
 		&models.Artisan{},
+		&models.VerificationDocument{},
 		&models.Customer{},
+		&models.CommunicationPreferences{},
 		&models.Service{},
 		&models.ServiceAddon{},
+		&models.ServicePriceVersion{},
+		&models.ServiceArea{},
+		&models.Skill{},
+		&models.ArtisanSkill{},
 		&models.Availability{},
 		&models.Booking{},
 		&models.Project{},
 		&models.ProjectMilestone{},
 		&models.ProjectTask{},
+		&models.TaskDependency{},
+		&models.ProjectStatusChange{},
+		&models.ProjectHealthSnapshot{},
 		&models.ProjectUpdate{},
+		&models.TimeEntry{},
+		&models.Quote{},
+		&models.QuoteRevision{},
+		&models.CustomerRequest{},
+		&models.CustomerRequestLead{},
+		&models.CustomerRequestBid{},
+		&models.ProjectTemplate{},
+		&models.ChangeOrder{},
+		&models.ChangeOrderEvent{},
 		&models.Review{},
+		&models.ReviewReply{},
+		&models.ReviewFlag{},
+		&models.Favorite{},
 		&models.Invoice{},
+		&models.InvoiceSequence{},
+		&models.TaxRule{},
+		&models.CommissionRule{},
+		&models.PricingRule{},
 		&models.Payment{},
+		&models.PaymentWebhookEvent{},
+		&models.PayoutBatch{},
+		&models.Payout{},
+		&models.ReconciliationReport{},
+		&models.FinancialStatement{},
 		&models.Message{},
 		&models.Notification{},
+		&models.Campaign{},
+		&models.CampaignRecipient{},
 		&models.EmailTemplate{},
 		&models.FileUpload{},
+		&models.Plan{},
 		&models.Subscription{},
 		&models.PromoCode{},
+		&models.PromoCodeRedemption{},
 		&models.Report{},
 		&models.AnalyticsEvent{},
+		&models.AnalyticsDailyRollup{},
+		&models.AnalyticsRollupState{},
+		&models.DataArchive{},
+		&models.OutboxEvent{},
+		&models.WebhookSubscription{},
+		&models.WebhookDeliveryAttempt{},
 		&models.WebhookEvent{},
+		&models.TenantRole{},
+		&models.TenantRoleAssignment{},
+		&models.ServiceAccount{},
+		&models.ServiceAccountUsage{},
+		&models.TenantOnboarding{},
 		&models.AuditLog{},
 		&models.SystemSetting{},
 		&models.TenantUsageTracking{},
 		&models.DataExportRequest{},
 		&models.APIKey{},
+		&models.IdempotencyKey{},
 		&models.SDKClient{},
 		&models.SDKKey{},
 		&models.SDKUsage{},
@@ -154,41 +208,44 @@ func AutoMigrate(db *gorm.DB) error {
 	)
 }
 
-// MockCache is a mock implementation of repository.Cache
+// MockCache is a mock implementation of repository.Cache. Values are stored as JSON,
+// like the Redis cache stores them, so cached entities come back as copies.
 type MockCache struct {
-	data map[string]any
+	mu   sync.Mutex
+	data map[string][]byte
 }
 
 // NewMockCache creates a new mock cache
 func NewMockCache() *MockCache {
 	return &MockCache{
-		data: make(map[string]any),
+		data: make(map[string][]byte),
 	}
 }
 
 func (m *MockCache) GetJSON(ctx context.Context, key string, dest any) error {
+	m.mu.Lock()
 	val, exists := m.data[key]
+	m.mu.Unlock()
 	if !exists {
 		return fmt.Errorf("key not found")
 	}
-	// Simple type assertion for testing
-	switch v := dest.(type) {
-	case *string:
-		*v = val.(string)
-	case *int:
-		*v = val.(int)
-	default:
-		// For complex types, you'd need proper JSON marshaling
-	}
-	return nil
+	return json.Unmarshal(val, dest)
 }
 
 func (m *MockCache) SetJSON(ctx context.Context, key string, value any, ttl time.Duration) error {
-	m.data[key] = value
+	val, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = val
 	return nil
 }
 
 func (m *MockCache) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for _, key := range keys {
 		delete(m.data, key)
 	}
@@ -196,14 +253,19 @@ func (m *MockCache) Delete(ctx context.Context, keys ...string) error {
 }
 
 func (m *MockCache) DeletePattern(ctx context.Context, pattern string) error {
-	// Simple pattern matching for testing
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for key := range m.data {
-		delete(m.data, key)
+		if ok, _ := path.Match(pattern, key); ok {
+			delete(m.data, key)
+		}
 	}
 	return nil
 }
 
 func (m *MockCache) Exists(ctx context.Context, keys ...string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	count := int64(0)
 	for _, key := range keys {
 		if _, exists := m.data[key]; exists {
@@ -232,6 +294,12 @@ func (m *MockMetrics) RecordCacheMiss(table string) {
 func (m *MockMetrics) RecordQueryCount(table string, count int64) {
 }
 
+func (m *MockMetrics) RecordSlowQuery(operation string, table string, duration time.Duration) {
+}
+
+func (m *MockMetrics) RecordQueryOverBudget(operation string, table string) {
+}
+
 // MockAuditLogger is a mock implementation of repository.AuditLogger
 type MockAuditLogger struct {
 	logs []AuditEntry
@@ -272,11 +340,12 @@ func (m *MockAuditLogger) LogUpdate(ctx context.Context, entityType string, enti
 	})
 }
 
-func (m *MockAuditLogger) LogDelete(ctx context.Context, entityType string, entityID uuid.UUID) {
+func (m *MockAuditLogger) LogDelete(ctx context.Context, entityType string, entityID uuid.UUID, oldValues map[string]any) {
 	m.logs = append(m.logs, AuditEntry{
 		Action:    "delete",
 		Entity:    entityType,
 		EntityID:  entityID,
+		Changes:   oldValues,
 		Timestamp: time.Now().UTC(),
 	})
 }
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TimeEntryRepository defines the interface for time entry repository operations
+type TimeEntryRepository interface {
+	BaseRepository[models.TimeEntry]
+
+	// FindRunning returns the user's running timer, or nil when none is running
+	FindRunning(ctx context.Context, userID uuid.UUID) (*models.TimeEntry, error)
+
+	// List returns a tenant's time entries matching the filter, most recent first
+	List(ctx context.Context, filter TimeEntryFilter, pagination PaginationParams) ([]*models.TimeEntry, PaginationResult, error)
+
+	// FindBillable returns a project's stopped, billable entries that have not been
+	// invoiced, started within [from, to) when given, oldest first
+	FindBillable(ctx context.Context, projectID uuid.UUID, from, to *time.Time) ([]*models.TimeEntry, error)
+
+	// SummarizeByTask totals a project's stopped entries per task
+	SummarizeByTask(ctx context.Context, projectID uuid.UUID) ([]TaskTimeSummary, error)
+
+	// RecalculateHours recomputes a task's tracked hours from its time entries, then its
+	// project's estimated and actual hours from the project's tasks
+	RecalculateHours(ctx context.Context, taskID uuid.UUID) error
+
+	// InvoiceEntries numbers and creates the invoice and marks the entries as billed by
+	// it, in one transaction. It fails if any entry was invoiced in the meantime.
+	InvoiceEntries(ctx context.Context, invoice *models.Invoice, entryIDs []uuid.UUID) error
+}
+
+// TimeEntryFilter narrows time entry listings
+type TimeEntryFilter struct {
+	TenantID  uuid.UUID
+	ProjectID *uuid.UUID
+	TaskID    *uuid.UUID
+	UserID    *uuid.UUID
+	Billable  *bool
+	Invoiced  *bool
+	From      *time.Time // Started at or after
+	To        *time.Time // Started before
+}
+
+// TaskTimeSummary totals a task's stopped time entries
+type TaskTimeSummary struct {
+	TaskID            uuid.UUID `json:"task_id"`
+	TotalMinutes      int64     `json:"total_minutes"`
+	BillableMinutes   int64     `json:"billable_minutes"`
+	UninvoicedMinutes int64     `json:"uninvoiced_minutes"` // Billable and not yet invoiced
+	BillableAmount    float64   `json:"billable_amount"`
+}
+
+// timeEntryRepository implements TimeEntryRepository
+type timeEntryRepository struct {
+	BaseRepository[models.TimeEntry]
+	db     *gorm.DB
+	logger log.AllLogger
+	cache  Cache
+}
+
+// NewTimeEntryRepository creates a new TimeEntryRepository instance
+func NewTimeEntryRepository(db *gorm.DB, config ...RepositoryConfig) TimeEntryRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.TimeEntry](db, cfg)
+
+	return &timeEntryRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+		cache:          cfg.Cache,
+	}
+}
+
+// FindRunning retrieves the user's running timer
+func (r *timeEntryRepository) FindRunning(ctx context.Context, userID uuid.UUID) (*models.TimeEntry, error) {
+	if userID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "user_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var entries []*models.TimeEntry
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND ended_at IS NULL", userID).
+		Limit(1).
+		Find(&entries).Error; err != nil {
+		r.logger.Error("failed to find running timer", "user_id", userID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find running timer", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+// List retrieves time entries matching the filter
+func (r *timeEntryRepository) List(ctx context.Context, filter TimeEntryFilter, pagination PaginationParams) ([]*models.TimeEntry, PaginationResult, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).Model(&models.TimeEntry{}).Where("tenant_id = ?", filter.TenantID)
+	if filter.ProjectID != nil {
+		query = query.Where("project_id = ?", *filter.ProjectID)
+	}
+	if filter.TaskID != nil {
+		query = query.Where("task_id = ?", *filter.TaskID)
+	}
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Billable != nil {
+		query = query.Where("billable = ?", *filter.Billable)
+	}
+	if filter.Invoiced != nil {
+		if *filter.Invoiced {
+			query = query.Where("invoice_id IS NOT NULL")
+		} else {
+			query = query.Where("invoice_id IS NULL")
+		}
+	}
+	if filter.From != nil {
+		query = query.Where("started_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("started_at < ?", *filter.To)
+	}
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count time entries", err)
+	}
+
+	var entries []*models.TimeEntry
+	if err := query.
+		Preload("Task").
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("started_at DESC, id DESC").
+		Find(&entries).Error; err != nil {
+		r.logger.Error("failed to list time entries", "tenant_id", filter.TenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to list time entries", err)
+	}
+
+	return entries, CalculatePagination(pagination, totalItems), nil
+}
+
+// FindBillable retrieves a project's uninvoiced billable entries
+func (r *timeEntryRepository) FindBillable(ctx context.Context, projectID uuid.UUID, from, to *time.Time) ([]*models.TimeEntry, error) {
+	if projectID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	query := r.db.WithContext(ctx).
+		Where("project_id = ? AND billable = ? AND invoice_id IS NULL AND ended_at IS NOT NULL", projectID, true)
+	if from != nil {
+		query = query.Where("started_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("started_at < ?", *to)
+	}
+
+	var entries []*models.TimeEntry
+	if err := query.Order("started_at ASC, id ASC").Find(&entries).Error; err != nil {
+		r.logger.Error("failed to find billable time entries", "project_id", projectID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find billable time entries", err)
+	}
+
+	return entries, nil
+}
+
+// SummarizeByTask totals a project's stopped entries per task
+func (r *timeEntryRepository) SummarizeByTask(ctx context.Context, projectID uuid.UUID) ([]TaskTimeSummary, error) {
+	if projectID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "project_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var summaries []TaskTimeSummary
+	if err := r.db.WithContext(ctx).
+		Model(&models.TimeEntry{}).
+		Select(`task_id,
+			COALESCE(SUM(duration_minutes), 0) AS total_minutes,
+			COALESCE(SUM(duration_minutes) FILTER (WHERE billable), 0) AS billable_minutes,
+			COALESCE(SUM(duration_minutes) FILTER (WHERE billable AND invoice_id IS NULL), 0) AS uninvoiced_minutes,
+			COALESCE(ROUND(SUM(duration_minutes * hourly_rate / 60.0) FILTER (WHERE billable), 2), 0) AS billable_amount`).
+		Where("project_id = ? AND ended_at IS NOT NULL", projectID).
+		Group("task_id").
+		Scan(&summaries).Error; err != nil {
+		r.logger.Error("failed to summarize time entries", "project_id", projectID, "error", err)
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "failed to summarize time entries", err)
+	}
+
+	return summaries, nil
+}
+
+// RecalculateHours rolls a task's time entries up into the task and its project
+func (r *timeEntryRepository) RecalculateHours(ctx context.Context, taskID uuid.UUID) error {
+	if taskID == uuid.Nil {
+		return errors.NewRepositoryError("INVALID_INPUT", "task_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			UPDATE project_tasks SET tracked_hours = (
+				SELECT COALESCE(ROUND(SUM(duration_minutes) / 60.0, 2), 0)
+				FROM time_entries
+				WHERE task_id = project_tasks.id AND ended_at IS NOT NULL
+			), updated_at = NOW()
+			WHERE id = ?`, taskID).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE projects SET
+				estimated_hours = totals.estimated,
+				actual_hours = totals.tracked,
+				updated_at = NOW()
+			FROM (
+				SELECT COALESCE(SUM(estimated_hours), 0) AS estimated, COALESCE(SUM(tracked_hours), 0) AS tracked
+				FROM project_tasks
+				WHERE project_id = (SELECT project_id FROM project_tasks WHERE id = ?) AND deleted_at IS NULL
+			) AS totals
+			WHERE projects.id = (SELECT project_id FROM project_tasks WHERE id = ?)`, taskID, taskID).Error
+	})
+	if err != nil {
+		r.logger.Error("failed to recalculate hours", "task_id", taskID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to recalculate hours", err)
+	}
+
+	// Invalidate cache
+	r.RepositoryCache().InvalidateTable(ctx, "project_tasks", uuid.Nil, taskID)
+	r.RepositoryCache().InvalidateTable(ctx, "projects", uuid.Nil)
+
+	return nil
+}
+
+// InvoiceEntries creates the invoice and links the entries to it
+func (r *timeEntryRepository) InvoiceEntries(ctx context.Context, invoice *models.Invoice, entryIDs []uuid.UUID) error {
+	if invoice == nil || len(entryIDs) == 0 {
+		return errors.NewRepositoryError("INVALID_INPUT", "invoice and entries are required", errors.ErrInvalidInput)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked []uuid.UUID
+		if err := tx.Model(&models.TimeEntry{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND invoice_id IS NULL", entryIDs).
+			Pluck("id", &locked).Error; err != nil {
+			return errors.NewRepositoryError("FIND_FAILED", "failed to lock time entries", err)
+		}
+		if len(locked) != len(entryIDs) {
+			return errors.NewRepositoryError("CONFLICT", "some time entries were already invoiced", errors.ErrConflict)
+		}
+
+		n, err := nextInvoiceNumber(tx, invoice.TenantID)
+		if err != nil {
+			return errors.NewRepositoryError("GENERATION_FAILED", "failed to generate invoice number", err)
+		}
+		invoice.InvoiceNumber = models.FormatInvoiceNumber(n)
+
+		if err := tx.Create(invoice).Error; err != nil {
+			r.logger.Error("failed to create invoice", "tenant_id", invoice.TenantID, "error", err)
+			return errors.NewRepositoryError("CREATE_FAILED", "failed to create invoice", err)
+		}
+
+		if err := tx.Model(&models.TimeEntry{}).
+			Where("id IN ?", entryIDs).
+			UpdateColumns(map[string]any{
+				"invoice_id":  invoice.ID,
+				"invoiced_at": invoice.IssueDate,
+				"updated_at":  time.Now(),
+			}).Error; err != nil {
+			r.logger.Error("failed to mark time entries invoiced", "invoice_id", invoice.ID, "error", err)
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to mark time entries invoiced", err)
+		}
+		return nil
+	})
+}
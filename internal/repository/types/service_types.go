@@ -86,6 +86,7 @@ type ServiceFilters struct {
 	MinDuration     *int                     `json:"min_duration"`
 	MaxDuration     *int                     `json:"max_duration"`
 	IsActive        *bool                    `json:"is_active"`
+	Status          models.ServiceStatus     `json:"status"`
 	ArtisanID       *uuid.UUID               `json:"artisan_id"`
 	Tags            []string                 `json:"tags"`
 	RequiresDeposit *bool                    `json:"requires_deposit"`
@@ -47,6 +47,7 @@ type UserRepository interface {
 
 	// Security & Authentication
 	RecordLogin(ctx context.Context, userID uuid.UUID) error
+	RecordMFA(ctx context.Context, userID uuid.UUID, at time.Time) error
 	RecordFailedLogin(ctx context.Context, userID uuid.UUID) error
 	UnlockUser(ctx context.Context, userID uuid.UUID) error
 	GetLockedUsers(ctx context.Context) ([]*models.User, error)
@@ -62,6 +63,7 @@ type UserRepository interface {
 	MarkForDeletion(ctx context.Context, userID uuid.UUID, scheduledDate time.Time) error
 	GetUsersMarkedForDeletion(ctx context.Context) ([]*models.User, error)
 	PermanentlyDeleteUser(ctx context.Context, userID uuid.UUID) error
+	EraseUser(ctx context.Context, userID uuid.UUID, erasedAt time.Time) error
 
 	// Profile Management
 	UpdateProfile(ctx context.Context, userID uuid.UUID, updates map[string]interface{}) error
@@ -177,28 +179,17 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 
 	email = strings.ToLower(strings.TrimSpace(email))
 
-	// Try cache first
-	cacheKey := r.getCacheKey("email", email)
-	if r.cache != nil {
-		var user models.User
-		if err := r.cache.GetJSON(ctx, cacheKey, &user); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit("users")
-			}
-			return &user, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss("users")
-		}
-	}
-
+	// Sign-in looks users up before any tenant is known, so the lookup is not scoped
 	var user models.User
-	if err := r.db.WithContext(ctx).
-		Preload("Tenant").
-		Preload("ArtisanProfile").
-		Preload("CustomerProfile").
-		Where("LOWER(email) = ?", email).
-		First(&user).Error; err != nil {
+	err := r.RepositoryCache().Query(ctx, uuid.Nil, "by_email", &user, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Tenant").
+			Preload("ArtisanProfile").
+			Preload("CustomerProfile").
+			Where("LOWER(email) = ?", email).
+			First(&user).Error
+	}, email)
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewRepositoryError("NOT_FOUND", "user not found", errors.ErrNotFound)
 		}
@@ -206,13 +197,6 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get user", err)
 	}
 
-	// Cache the result
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, user, 5*time.Minute); err != nil {
-			r.logger.Warn("failed to cache user", "email", email, "error", err)
-		}
-	}
-
 	return &user, nil
 }
 
@@ -222,28 +206,16 @@ func (r *userRepository) GetByZitadelID(ctx context.Context, zitadelID string) (
 		return nil, errors.NewRepositoryError("INVALID_INPUT", "zitadel user ID cannot be empty", errors.ErrInvalidInput)
 	}
 
-	// try cache first
-	cacheKey := r.getCacheKey("zitadel", zitadelID)
-	if r.cache != nil {
-		var user models.User
-		if err := r.cache.GetJSON(ctx, cacheKey, user); err == nil {
-			if r.metrics != nil {
-				r.metrics.RecordCacheHit("users")
-			}
-			return &user, nil
-		}
-		if r.metrics != nil {
-			r.metrics.RecordCacheMiss("users")
-		}
-	}
-
 	var user models.User
-	if err := r.db.WithContext(ctx).
-		Preload("Tenant").
-		Preload("ArtisanProfile").
-		Preload("CustomerProfile").
-		Where("zitadel_user_id = ?", zitadelID).
-		First(&user).Error; err != nil {
+	err := r.RepositoryCache().Query(ctx, uuid.Nil, "by_zitadel_id", &user, func() error {
+		return r.db.WithContext(ctx).
+			Preload("Tenant").
+			Preload("ArtisanProfile").
+			Preload("CustomerProfile").
+			Where("zitadel_user_id = ?", zitadelID).
+			First(&user).Error
+	}, zitadelID)
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.NewRepositoryError("NOT_FOUND", "user not found", errors.ErrNotFound)
 		}
@@ -251,13 +223,6 @@ func (r *userRepository) GetByZitadelID(ctx context.Context, zitadelID string) (
 		return nil, errors.NewRepositoryError("GET_FAILED", "failed to get user", err)
 	}
 
-	// Cache the result
-	if r.cache != nil {
-		if err := r.cache.SetJSON(ctx, cacheKey, user, 5*time.Minute); err != nil {
-			r.logger.Warn("failed to cache user", "zitadelID", zitadelID, "error", err)
-		}
-	}
-
 	return &user, nil
 }
 
@@ -637,6 +602,23 @@ func (r *userRepository) RecordLogin(ctx context.Context, userID uuid.UUID) erro
 	return nil
 }
 
+// RecordMFA records that the user was seen signed in with a second factor
+func (r *userRepository) RecordMFA(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("last_mfa_at", at)
+
+	if result.Error != nil {
+		return errors.NewRepositoryError("UPDATE_FAILED", "failed to record MFA sign-in", result.Error)
+	}
+
+	// Invalidate cache
+	r.invalidateUserCache(ctx, userID)
+
+	return nil
+}
+
 // RecordFailedLogin records a failed login attempt
 func (r *userRepository) RecordFailedLogin(ctx context.Context, userID uuid.UUID) error {
 	var user *models.User
@@ -936,12 +918,12 @@ func (r *userRepository) PermanentlyDeleteUser(ctx context.Context, userID uuid.
 		// Delete related records first
 
 		// Delete artisan profile if exists
-		if err := tx.Where("user_id = ?", userID).Delete(&models.Artisan{}).Error; err != nil {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.Artisan{}).Error; err != nil {
 			r.logger.Error("failed to delete artisan profile", "user_id", userID, "error", err)
 		}
 
 		// Delete customer profile if exists
-		if err := tx.Where("user_id = ?", userID).Delete(&models.Customer{}).Error; err != nil {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.Customer{}).Error; err != nil {
 			r.logger.Error("failed to delete customer profile", "user_id", userID, "error", err)
 		}
 
@@ -958,6 +940,76 @@ func (r *userRepository) PermanentlyDeleteUser(ctx context.Context, userID uuid.
 	})
 }
 
+// EraseUser anonymizes a user in place (GDPR right to erasure): their account, profiles,
+// the content of the messages they sent and of their reviews, and the notes and
+// locations of their bookings. The rows themselves are kept, so bookings, payments and
+// ratings referencing the user stay intact.
+func (r *userRepository) EraseUser(ctx context.Context, userID uuid.UUID, erasedAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"email":               "erased-" + userID.String() + "@erased.invalid",
+				"first_name":          "Erased",
+				"last_name":           "User",
+				"phone_number":        "",
+				"avatar_url":          "",
+				"password_hash":       "",
+				"zitadel_user_id":     gorm.Expr("NULL"),
+				"mfa_enabled":         false,
+				"mfa_secret":          "",
+				"two_factor_enabled":  false,
+				"two_factor_secret":   "",
+				"backup_codes":        gorm.Expr("NULL"),
+				"session_token":       "",
+				"refresh_tokens":      gorm.Expr("NULL"),
+				"metadata":            gorm.Expr("NULL"),
+				"status":              models.UserStatusInactive,
+				"marketing_consent":   false,
+				"marked_for_deletion": false,
+				"erased_at":           erasedAt,
+			})
+		if result.Error != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", "failed to erase user", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewRepositoryError("NOT_FOUND", "user not found", errors.ErrNotFound)
+		}
+
+		erasures := []struct {
+			model   any
+			where   string
+			updates map[string]interface{}
+		}{
+			{&models.Customer{}, "user_id = ?", map[string]interface{}{
+				"notes": "", "primary_location": gorm.Expr("NULL"), "default_payment_method_id": "", "metadata": gorm.Expr("NULL"),
+			}},
+			{&models.Artisan{}, "user_id = ?", map[string]interface{}{
+				"bio": "", "availability_note": "", "metadata": gorm.Expr("NULL"),
+			}},
+			{&models.Message{}, "sender_id = ?", map[string]interface{}{
+				"content": "[erased]", "file_url": "", "metadata": gorm.Expr("NULL"),
+			}},
+			{&models.Review{}, "customer_id = ?", map[string]interface{}{
+				"title": "", "comment": "",
+			}},
+			{&models.Booking{}, "customer_id = ?", map[string]interface{}{
+				"customer_notes": "", "intake_answers": gorm.Expr("NULL"), "service_location": gorm.Expr("NULL"),
+			}},
+		}
+		for _, erasure := range erasures {
+			if err := tx.Unscoped().Model(erasure.model).Where(erasure.where, userID).Updates(erasure.updates).Error; err != nil {
+				return errors.NewRepositoryError("UPDATE_FAILED", "failed to erase user data", err)
+			}
+		}
+
+		r.invalidateUserCache(ctx, userID)
+
+		r.logger.Info("user erased", "user_id", userID)
+		return nil
+	})
+}
+
 // UpdateProfile updates user profile fields
 func (r *userRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, updates map[string]interface{}) error {
 	if len(updates) == 0 {
@@ -1403,23 +1455,6 @@ func (r *userRepository) GetUserGrowth(ctx context.Context, tenantID *uuid.UUID,
 
 // Helper methods
 
-func (r *userRepository) getCacheKey(prefix string, parts ...string) string {
-	allParts := append([]string{"repo", "users", prefix}, parts...)
-	return strings.Join(allParts, ":")
-}
-
 func (r *userRepository) invalidateUserCache(ctx context.Context, userID uuid.UUID) {
-	if r.cache == nil {
-		return
-	}
-
-	patterns := []string{
-		r.getCacheKey("id", userID.String()),
-		r.getCacheKey("email", "*"),
-		r.getCacheKey("logto", "*"),
-	}
-
-	for _, pattern := range patterns {
-		r.cache.DeletePattern(ctx, pattern)
-	}
+	r.RepositoryCache().Invalidate(ctx, uuid.Nil, userID)
 }
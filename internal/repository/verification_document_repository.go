@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VerificationDocumentRepository defines the interface for artisan verification document operations
+type VerificationDocumentRepository interface {
+	BaseRepository[models.VerificationDocument]
+
+	// FindByArtisan returns an artisan's documents, newest first
+	FindByArtisan(ctx context.Context, tenantID, artisanID uuid.UUID) ([]*models.VerificationDocument, error)
+
+	// FindByStatus returns the tenant's documents in a status, oldest first so the
+	// review queue is worked in order of upload
+	FindByStatus(ctx context.Context, tenantID uuid.UUID, status models.VerificationStatus, pagination PaginationParams) ([]*models.VerificationDocument, PaginationResult, error)
+
+	// FindExpired returns approved documents of every tenant whose expiry date is not
+	// after now
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*models.VerificationDocument, error)
+}
+
+// verificationDocumentRepository implements VerificationDocumentRepository
+type verificationDocumentRepository struct {
+	BaseRepository[models.VerificationDocument]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewVerificationDocumentRepository creates a new VerificationDocumentRepository instance
+func NewVerificationDocumentRepository(db *gorm.DB, config ...RepositoryConfig) VerificationDocumentRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.VerificationDocument](db, cfg)
+
+	return &verificationDocumentRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByArtisan retrieves all verification documents of an artisan
+func (r *verificationDocumentRepository) FindByArtisan(ctx context.Context, tenantID, artisanID uuid.UUID) ([]*models.VerificationDocument, error) {
+	if tenantID == uuid.Nil || artisanID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id and artisan_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var documents []*models.VerificationDocument
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND artisan_id = ?", tenantID, artisanID).
+		Order("created_at DESC").
+		Find(&documents).Error; err != nil {
+		r.logger.Error("failed to find verification documents", "artisan_id", artisanID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find verification documents", err)
+	}
+
+	return documents, nil
+}
+
+// FindByStatus retrieves a page of the tenant's verification documents in a status
+func (r *verificationDocumentRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status models.VerificationStatus, pagination PaginationParams) ([]*models.VerificationDocument, PaginationResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	pagination.Validate()
+
+	query := r.db.WithContext(ctx).
+		Model(&models.VerificationDocument{}).
+		Where("tenant_id = ? AND status = ?", tenantID, status)
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		r.logger.Error("failed to count verification documents", "tenant_id", tenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count verification documents", err)
+	}
+
+	var documents []*models.VerificationDocument
+	if err := query.
+		Preload("Artisan.User").
+		Order("created_at ASC").
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Find(&documents).Error; err != nil {
+		r.logger.Error("failed to find verification documents", "tenant_id", tenantID, "error", err)
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find verification documents", err)
+	}
+
+	return documents, CalculatePagination(pagination, totalItems), nil
+}
+
+// FindExpired retrieves approved verification documents past their expiry date
+func (r *verificationDocumentRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*models.VerificationDocument, error) {
+	var documents []*models.VerificationDocument
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", models.VerificationStatusApproved, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&documents).Error; err != nil {
+		r.logger.Error("failed to find expired verification documents", "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find expired verification documents", err)
+	}
+
+	return documents, nil
+}
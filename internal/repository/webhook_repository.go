@@ -4,12 +4,12 @@ import (
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WebhookEventRepository interface {
@@ -18,6 +18,15 @@ type WebhookEventRepository interface {
 	// Core Operations
 	GetByTenantID(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.WebhookEvent, PaginationResult, error)
 	GetByEventType(ctx context.Context, tenantID uuid.UUID, eventType models.WebhookEventType, pagination PaginationParams) ([]*models.WebhookEvent, PaginationResult, error)
+	GetBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, pagination PaginationParams) ([]*models.WebhookEvent, PaginationResult, error)
+
+	// Subscription Operations
+	// CreateForSubscriptions queues events fanned out to webhook subscriptions, skipping
+	// those already queued for the same subscription and domain event; it returns how
+	// many it queued
+	CreateForSubscriptions(ctx context.Context, webhooks []*models.WebhookEvent) (int64, error)
+	RecordAttempt(ctx context.Context, attempt *models.WebhookDeliveryAttempt) error
+	GetAttempts(ctx context.Context, webhookID uuid.UUID) ([]*models.WebhookDeliveryAttempt, error)
 
 	// Delivery Operations
 	MarkAsDelivered(ctx context.Context, webhookID uuid.UUID, responseCode int, responseBody string) error
@@ -31,6 +40,8 @@ type WebhookEventRepository interface {
 	GetFailedWebhooks(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.WebhookEvent, PaginationResult, error)
 	GetWebhooksForRetry(ctx context.Context, limit int) ([]*models.WebhookEvent, error)
 	ResetForRetry(ctx context.Context, webhookID uuid.UUID) error
+	// CountPending counts the events still to be delivered, due or waiting to retry
+	CountPending(ctx context.Context) (int64, error)
 
 	// Query Operations
 	GetDeliveredWebhooks(ctx context.Context, tenantID uuid.UUID, pagination PaginationParams) ([]*models.WebhookEvent, PaginationResult, error)
@@ -164,6 +175,69 @@ func (r *webhookEventRepository) GetByEventType(ctx context.Context, tenantID uu
 	return webhooks, paginationResult, nil
 }
 
+func (r *webhookEventRepository) GetBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, pagination PaginationParams) ([]*models.WebhookEvent, PaginationResult, error) {
+	pagination.Validate()
+
+	var totalItems int64
+	query := r.db.WithContext(ctx).Model(&models.WebhookEvent{}).Where("subscription_id = ?", subscriptionID)
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("COUNT_FAILED", "failed to count webhook events", err)
+	}
+
+	var webhooks []*models.WebhookEvent
+	if err := query.
+		Offset(pagination.Offset()).
+		Limit(pagination.Limit()).
+		Order("created_at DESC").
+		Find(&webhooks).Error; err != nil {
+		return nil, PaginationResult{}, errors.NewRepositoryError("FIND_FAILED", "failed to find webhook events", err)
+	}
+
+	paginationResult := CalculatePagination(pagination, totalItems)
+	return webhooks, paginationResult, nil
+}
+
+//------------------------------------------------------------
+// Subscription Operations
+//------------------------------------------------------------
+
+func (r *webhookEventRepository) CreateForSubscriptions(ctx context.Context, webhooks []*models.WebhookEvent) (int64, error) {
+	if len(webhooks) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "domain_event_id"}},
+			DoNothing: true,
+		}).
+		Create(&webhooks)
+	if result.Error != nil {
+		return 0, errors.NewRepositoryError("CREATE_FAILED", "failed to queue webhook events", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *webhookEventRepository) RecordAttempt(ctx context.Context, attempt *models.WebhookDeliveryAttempt) error {
+	if err := r.db.WithContext(ctx).Create(attempt).Error; err != nil {
+		return errors.NewRepositoryError("CREATE_FAILED", "failed to record webhook delivery attempt", err)
+	}
+	return nil
+}
+
+func (r *webhookEventRepository) GetAttempts(ctx context.Context, webhookID uuid.UUID) ([]*models.WebhookDeliveryAttempt, error) {
+	var attempts []*models.WebhookDeliveryAttempt
+	if err := r.db.WithContext(ctx).
+		Where("webhook_event_id = ?", webhookID).
+		Order("attempted_at ASC").
+		Find(&attempts).Error; err != nil {
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find webhook delivery attempts", err)
+	}
+	return attempts, nil
+}
+
 //------------------------------------------------------------
 // Delivery Operations
 //------------------------------------------------------------
@@ -335,6 +409,17 @@ func (r *webhookEventRepository) GetWebhooksForRetry(ctx context.Context, limit
 	return r.GetPendingRetries(ctx, limit)
 }
 
+func (r *webhookEventRepository) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.WebhookEvent{}).
+		Where("delivered = ? AND attempt_count < max_attempts", false).
+		Count(&count).Error; err != nil {
+		return 0, errors.NewRepositoryError("COUNT_FAILED", "failed to count pending webhooks", err)
+	}
+	return count, nil
+}
+
 func (r *webhookEventRepository) ResetForRetry(ctx context.Context, webhookID uuid.UUID) error {
 	result := r.db.WithContext(ctx).
 		Model(&models.WebhookEvent{}).
@@ -446,42 +531,32 @@ func (r *webhookEventRepository) GetWebhookStats(ctx context.Context, tenantID u
 		ByStatus:    make(map[string]int64),
 	}
 
-	query := r.db.WithContext(ctx).Model(&models.WebhookEvent{}).Where("tenant_id = ?", tenantID)
-	if !startDate.IsZero() {
-		query = query.Where("created_at >= ?", startDate)
-	}
-	if !endDate.IsZero() {
-		query = query.Where("created_at <= ?", endDate)
-	}
-
-	if err := query.Count(&stats.TotalWebhooks).Error; err != nil {
-		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to count total webhooks", err)
-	}
-
-	if err := query.Where("delivered = ?", true).Count(&stats.DeliveredWebhooks).Error; err != nil {
-		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to count delivered webhooks", err)
-	}
-
-	if err := query.Where("delivered = ? AND attempt_count >= max_attempts", false).
-		Count(&stats.FailedWebhooks).Error; err != nil {
-		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to count failed webhooks", err)
-	}
-
-	if err := query.Where("delivered = ? AND attempt_count < max_attempts", false).
-		Count(&stats.PendingWebhooks).Error; err != nil {
-		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to count pending webhooks", err)
-	}
+	var totals struct {
+		Total           int64
+		Delivered       int64
+		Failed          int64
+		Pending         int64
+		AverageAttempts float64
+	}
+	if err := r.statsQuery(ctx, tenantID, startDate, endDate).
+		Select(`COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE delivered) AS delivered,
+			COUNT(*) FILTER (WHERE NOT delivered AND attempt_count >= max_attempts) AS failed,
+			COUNT(*) FILTER (WHERE NOT delivered AND attempt_count < max_attempts) AS pending,
+			COALESCE(AVG(attempt_count), 0) AS average_attempts`).
+		Scan(&totals).Error; err != nil {
+		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to count webhooks", err)
+	}
+	stats.TotalWebhooks = totals.Total
+	stats.DeliveredWebhooks = totals.Delivered
+	stats.FailedWebhooks = totals.Failed
+	stats.PendingWebhooks = totals.Pending
+	stats.AverageAttempts = totals.AverageAttempts
 
 	if stats.TotalWebhooks > 0 {
 		stats.DeliveryRate = float64(stats.DeliveredWebhooks) / float64(stats.TotalWebhooks) * 100
 	}
 
-	var avgAttempts float64
-	if err := query.Select("COALESCE(AVG(attempt_count), 0)").
-		Scan(&avgAttempts).Error; err == nil {
-		stats.AverageAttempts = avgAttempts
-	}
-
 	eventTypes := []models.WebhookEventType{
 		models.WebhookEventBookingCreated,
 		models.WebhookEventBookingUpdated,
@@ -490,12 +565,22 @@ func (r *webhookEventRepository) GetWebhookStats(ctx context.Context, tenantID u
 		models.WebhookEventReviewCreated,
 		models.WebhookEventUserCreated,
 	}
-
 	for _, eventType := range eventTypes {
-		var count int64
-		if err := query.Where("event_type = ?", eventType).Count(&count).Error; err == nil {
-			stats.ByEventType[eventType] = count
-		}
+		stats.ByEventType[eventType] = 0
+	}
+
+	var byEventType []struct {
+		EventType models.WebhookEventType
+		Count     int64
+	}
+	if err := r.statsQuery(ctx, tenantID, startDate, endDate).
+		Select("event_type, COUNT(*) AS count").
+		Group("event_type").
+		Scan(&byEventType).Error; err != nil {
+		return stats, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to count webhooks by event type", err)
+	}
+	for _, row := range byEventType {
+		stats.ByEventType[row.EventType] = row.Count
 	}
 
 	stats.ByStatus["delivered"] = stats.DeliveredWebhooks
@@ -506,28 +591,34 @@ func (r *webhookEventRepository) GetWebhookStats(ctx context.Context, tenantID u
 }
 
 func (r *webhookEventRepository) GetDeliveryRate(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (float64, error) {
-	query := r.db.WithContext(ctx).Model(&models.WebhookEvent{}).Where("tenant_id = ?", tenantID)
-	if !startDate.IsZero() {
-		query = query.Where("created_at >= ?", startDate)
+	var counts struct {
+		Total     int64
+		Delivered int64
 	}
-	if !endDate.IsZero() {
-		query = query.Where("created_at <= ?", endDate)
-	}
-
-	var total, delivered int64
-	if err := query.Count(&total).Error; err != nil {
-		return 0, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to count total webhooks", err)
+	if err := r.statsQuery(ctx, tenantID, startDate, endDate).
+		Select("COUNT(*) AS total, COUNT(*) FILTER (WHERE delivered) AS delivered").
+		Scan(&counts).Error; err != nil {
+		return 0, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to count webhooks", err)
 	}
 
-	if total == 0 {
+	if counts.Total == 0 {
 		return 0, nil
 	}
+	return float64(counts.Delivered) / float64(counts.Total) * 100, nil
+}
 
-	if err := query.Where("delivered = ?", true).Count(&delivered).Error; err != nil {
-		return 0, errors.NewRepositoryError("AGGREGATION_FAILED", "failed to count delivered webhooks", err)
+// statsQuery starts a fresh query over a tenant's webhook events in a period. Every
+// aggregate builds its own, since conditions added to a shared query carry over to
+// the next statement run from it.
+func (r *webhookEventRepository) statsQuery(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.WebhookEvent{}).Where("tenant_id = ?", tenantID)
+	if !startDate.IsZero() {
+		query = query.Where("created_at >= ?", startDate)
 	}
-
-	return float64(delivered) / float64(total) * 100, nil
+	if !endDate.IsZero() {
+		query = query.Where("created_at <= ?", endDate)
+	}
+	return query
 }
 
 func (r *webhookEventRepository) GetAverageDeliveryTime(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (time.Duration, error) {
@@ -622,39 +713,52 @@ func (r *webhookEventRepository) GetFailureReasons(ctx context.Context, tenantID
 //------------------------------------------------------------
 
 func (r *webhookEventRepository) DeleteOldWebhooks(ctx context.Context, olderThan time.Time) (int64, error) {
-	result := r.db.WithContext(ctx).
-		Where("created_at < ?", olderThan).
-		Delete(&models.WebhookEvent{})
-
-	if result.Error != nil {
-		return 0, errors.NewRepositoryError("DELETE_FAILED", "failed to delete old webhooks", result.Error)
+	deleted, err := r.deleteWebhooks(ctx, "created_at < ?", olderThan)
+	if err != nil {
+		return 0, errors.NewRepositoryError("DELETE_FAILED", "failed to delete old webhooks", err)
 	}
 
-	return result.RowsAffected, nil
+	return deleted, nil
 }
 
 func (r *webhookEventRepository) DeleteDeliveredWebhooks(ctx context.Context, olderThan time.Time) (int64, error) {
-	result := r.db.WithContext(ctx).
-		Where("delivered = ? AND delivered_at < ?", true, olderThan).
-		Delete(&models.WebhookEvent{})
-
-	if result.Error != nil {
-		return 0, errors.NewRepositoryError("DELETE_FAILED", "failed to delete delivered webhooks", result.Error)
+	deleted, err := r.deleteWebhooks(ctx, "delivered = ? AND delivered_at < ?", true, olderThan)
+	if err != nil {
+		return 0, errors.NewRepositoryError("DELETE_FAILED", "failed to delete delivered webhooks", err)
 	}
 
-	return result.RowsAffected, nil
+	return deleted, nil
 }
 
 func (r *webhookEventRepository) PurgeFailedWebhooks(ctx context.Context, maxAttempts int, olderThan time.Time) (int64, error) {
-	result := r.db.WithContext(ctx).
-		Where("delivered = ? AND attempt_count >= ? AND created_at < ?", false, maxAttempts, olderThan).
-		Delete(&models.WebhookEvent{})
-
-	if result.Error != nil {
-		return 0, errors.NewRepositoryError("DELETE_FAILED", "failed to purge failed webhooks", result.Error)
+	deleted, err := r.deleteWebhooks(ctx, "delivered = ? AND attempt_count >= ? AND created_at < ?", false, maxAttempts, olderThan)
+	if err != nil {
+		return 0, errors.NewRepositoryError("DELETE_FAILED", "failed to purge failed webhooks", err)
 	}
 
-	return result.RowsAffected, nil
+	return deleted, nil
+}
+
+// deleteWebhooks permanently deletes the webhook events matching the condition along
+// with their delivery attempts
+func (r *webhookEventRepository) deleteWebhooks(ctx context.Context, condition string, args ...any) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		matching := tx.Unscoped().Model(&models.WebhookEvent{}).Select("id").Where(condition, args...)
+		if err := tx.Unscoped().
+			Where("webhook_event_id IN (?)", matching).
+			Delete(&models.WebhookDeliveryAttempt{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where(condition, args...).Delete(&models.WebhookEvent{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		return nil
+	})
+	return deleted, err
 }
 
 //------------------------------------------------------------
@@ -730,16 +834,3 @@ func (r *webhookEventRepository) applyWebhookFilters(query *gorm.DB, filters Web
 
 	return query
 }
-
-func (r *webhookEventRepository) InvalidateCache(ctx context.Context, webhookID uuid.UUID) error {
-	if r.cache != nil {
-		cacheKey := fmt.Sprintf("webhook:%s", webhookID.String())
-		if err := r.cache.Delete(ctx, cacheKey); err != nil {
-			if r.logger != nil {
-				r.logger.Warnf("Failed to invalidate cache for webhook %s: %v", webhookID, err)
-			}
-			return err
-		}
-	}
-	return nil
-}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription repository operations
+type WebhookSubscriptionRepository interface {
+	BaseRepository[models.WebhookSubscription]
+
+	// FindByTenantID returns all of a tenant's webhook subscriptions
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.WebhookSubscription, error)
+
+	// FindActiveForEvent returns the tenant's active subscriptions that receive events of
+	// the given type
+	FindActiveForEvent(ctx context.Context, tenantID uuid.UUID, eventType string) ([]*models.WebhookSubscription, error)
+}
+
+// webhookSubscriptionRepository implements WebhookSubscriptionRepository
+type webhookSubscriptionRepository struct {
+	BaseRepository[models.WebhookSubscription]
+	db     *gorm.DB
+	logger log.AllLogger
+}
+
+// NewWebhookSubscriptionRepository creates a new WebhookSubscriptionRepository instance
+func NewWebhookSubscriptionRepository(db *gorm.DB, config ...RepositoryConfig) WebhookSubscriptionRepository {
+	var cfg RepositoryConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	baseRepo := NewBaseRepository[models.WebhookSubscription](db, cfg)
+
+	return &webhookSubscriptionRepository{
+		BaseRepository: baseRepo,
+		db:             db,
+		logger:         cfg.Logger,
+	}
+}
+
+// FindByTenantID retrieves all webhook subscriptions for a tenant
+func (r *webhookSubscriptionRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*models.WebhookSubscription, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	var subscriptions []*models.WebhookSubscription
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at ASC").
+		Find(&subscriptions).Error; err != nil {
+		r.logger.Error("failed to find webhook subscriptions", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find webhook subscriptions", err)
+	}
+
+	return subscriptions, nil
+}
+
+// FindActiveForEvent retrieves the active subscriptions with no event types, which
+// receive every event, or with the given one among them
+func (r *webhookSubscriptionRepository) FindActiveForEvent(ctx context.Context, tenantID uuid.UUID, eventType string) ([]*models.WebhookSubscription, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "tenant_id cannot be nil", errors.ErrInvalidInput)
+	}
+
+	eventTypes, err := json.Marshal([]string{eventType})
+	if err != nil {
+		return nil, errors.NewRepositoryError("INVALID_INPUT", "invalid event type", err)
+	}
+
+	var subscriptions []*models.WebhookSubscription
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND is_active = ?", tenantID, true).
+		Where("jsonb_array_length(COALESCE(event_types, '[]'::jsonb)) = 0 OR event_types @> ?::jsonb", string(eventTypes)).
+		Order("created_at ASC").
+		Find(&subscriptions).Error; err != nil {
+		r.logger.Error("failed to find webhook subscriptions for event", "tenant_id", tenantID, "event_type", eventType, "error", err)
+		return nil, errors.NewRepositoryError("FIND_FAILED", "failed to find webhook subscriptions", err)
+	}
+
+	return subscriptions, nil
+}
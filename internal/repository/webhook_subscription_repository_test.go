@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/repository/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSubscriptionRepository(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Close()
+
+	ctx := context.Background()
+	subscriptions := repository.NewWebhookSubscriptionRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	webhooks := repository.NewWebhookEventRepository(tdb.DB, testutil.DefaultRepositoryConfig())
+	tenantID := uuid.New()
+
+	newSubscription := func(eventTypes models.StringArray, active bool) *models.WebhookSubscription {
+		subscription := &models.WebhookSubscription{
+			TenantID:   tenantID,
+			URL:        "https://example.com/hooks",
+			Secret:     "whsec_test",
+			EventTypes: eventTypes,
+			IsActive:   true,
+		}
+		require.NoError(t, subscriptions.Create(ctx, subscription))
+		if !active {
+			subscription.IsActive = false
+			require.NoError(t, subscriptions.Update(ctx, subscription))
+		}
+		return subscription
+	}
+
+	everything := newSubscription(nil, true)
+	payments := newSubscription(models.StringArray{"payment.succeeded", "payment.refunded"}, true)
+	newSubscription(nil, false)
+
+	t.Run("active subscriptions receiving the event type are found", func(t *testing.T) {
+		found, err := subscriptions.FindActiveForEvent(ctx, tenantID, "payment.succeeded")
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, everything.ID, found[0].ID)
+		assert.Equal(t, payments.ID, found[1].ID)
+		assert.Equal(t, "whsec_test", found[0].Secret)
+
+		found, err = subscriptions.FindActiveForEvent(ctx, tenantID, "booking.created")
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, everything.ID, found[0].ID)
+
+		all, err := subscriptions.FindByTenantID(ctx, tenantID)
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
+
+	t.Run("an event is queued once per subscription", func(t *testing.T) {
+		domainEventID := uuid.New()
+		queue := func() int64 {
+			queued, err := webhooks.CreateForSubscriptions(ctx, []*models.WebhookEvent{
+				{
+					TenantID:       tenantID,
+					SubscriptionID: &everything.ID,
+					DomainEventID:  &domainEventID,
+					EventType:      "payment.succeeded",
+					Payload:        models.JSONB{"id": domainEventID},
+					WebhookURL:     everything.URL,
+					MaxAttempts:    8,
+				},
+			})
+			require.NoError(t, err)
+			return queued
+		}
+		assert.Equal(t, int64(1), queue())
+		assert.Equal(t, int64(0), queue(), "the event bus handing the event over again queues nothing")
+
+		deliveries, pagination, err := webhooks.GetBySubscriptionID(ctx, everything.ID, repository.PaginationParams{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, int64(1), pagination.TotalItems)
+
+		delivery := deliveries[0]
+		require.NoError(t, webhooks.RecordAttempt(ctx, &models.WebhookDeliveryAttempt{
+			TenantID:       tenantID,
+			WebhookEventID: delivery.ID,
+			Attempt:        1,
+			AttemptedAt:    time.Now(),
+			ResponseCode:   500,
+			Error:          "webhook returned non-success status: 500",
+		}))
+		attempts, err := webhooks.GetAttempts(ctx, delivery.ID)
+		require.NoError(t, err)
+		require.Len(t, attempts, 1)
+		assert.Equal(t, 500, attempts[0].ResponseCode)
+
+		deleted, err := webhooks.DeleteOldWebhooks(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+		attempts, err = webhooks.GetAttempts(ctx, delivery.ID)
+		require.NoError(t, err)
+		assert.Empty(t, attempts, "attempts are deleted with their delivery")
+	})
+}
@@ -83,7 +83,7 @@ func (r *whiteLabelRepository) Update(ctx context.Context, whitelabel *models.Wh
 }
 
 func (r *whiteLabelRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.WhiteLabel{}, id).Error
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.WhiteLabel{}, id).Error
 }
 
 func (r *whiteLabelRepository) Activate(ctx context.Context, id uuid.UUID) error {
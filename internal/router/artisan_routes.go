@@ -1,31 +1,25 @@
 package router
 
 import (
+	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/handler"
 	"Krafti_Vibe/internal/middleware"
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 )
 
 func (r *Router) setupArtisanRoutes(api fiber.Router) {
 	// Initialize service and handler
 	artisanService := service.NewArtisanService(r.repos, r.config.Logger)
 	artisanHandler := handler.NewArtisanHandler(artisanService)
+	areaHandler := handler.NewServiceAreaHandler(service.NewServiceAreaService(r.repos, r.config.Logger))
+	verificationHandler := handler.NewVerificationHandler(service.NewVerificationService(r.repos, r.config.Logger, r.config.Storage))
+	skillHandler := handler.NewSkillHandler(service.NewSkillService(r.repos, r.config.Logger))
 
 	// Create artisans group
 	artisans := api.Group("/artisans")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		artisans.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Auth middleware configuration
 	artisans.Use(r.RequireAuth())
 
@@ -36,6 +30,7 @@ func (r *Router) setupArtisanRoutes(api fiber.Router) {
 	// Create artisan - tenant owner/admin can create artisans
 	artisans.Post("/",
 		middleware.RequireTenantOwnerOrAdmin(),
+		r.RequirePlan(models.PlanLimitArtisanSeats),
 		artisanHandler.CreateArtisan,
 	)
 
@@ -50,6 +45,12 @@ func (r *Router) setupArtisanRoutes(api fiber.Router) {
 		artisanHandler.UpdateArtisan,
 	)
 
+	// Update tier - tenant owner/admin only, since the tier selects commission rules
+	artisans.Put("/:id/tier",
+		middleware.RequireTenantOwnerOrAdmin(),
+		artisanHandler.UpdateTier,
+	)
+
 	// Delete artisan - tenant owner/admin only
 	artisans.Delete("/:id",
 		middleware.RequireTenantOwnerOrAdmin(),
@@ -115,6 +116,65 @@ func (r *Router) setupArtisanRoutes(api fiber.Router) {
 		artisanHandler.BatchUpdateAvailability,
 	)
 
+	// ============================================================================
+	// Service Areas
+	// ============================================================================
+
+	// List service areas - any authenticated user, to see where an artisan travels
+	artisans.Get("/:id/service-areas",
+		areaHandler.ListServiceAreas,
+	)
+
+	// Manage service areas - the artisan (self) or tenant owner/admin
+	artisans.Post("/:id/service-areas",
+		middleware.RequireTenantStaff(),
+		areaHandler.CreateServiceArea,
+	)
+	artisans.Put("/:id/service-areas/:area_id",
+		middleware.RequireTenantStaff(),
+		areaHandler.UpdateServiceArea,
+	)
+	artisans.Delete("/:id/service-areas/:area_id",
+		middleware.RequireTenantStaff(),
+		areaHandler.DeleteServiceArea,
+	)
+
+	// ============================================================================
+	// Skills & Matching
+	// ============================================================================
+
+	// List skills - any authenticated user, to see what an artisan does
+	artisans.Get("/:id/skills",
+		skillHandler.ListArtisanSkills,
+	)
+
+	// Set skills - the artisan (self) or tenant owner/admin
+	artisans.Put("/:id/skills",
+		middleware.RequireTenantStaff(),
+		skillHandler.SetArtisanSkills,
+	)
+
+	// Match artisans to a service request - any authenticated user
+	artisans.Post("/match",
+		skillHandler.MatchArtisans,
+	)
+
+	// ============================================================================
+	// Verification
+	// ============================================================================
+
+	// Get badges and verification documents - the artisan (self) or tenant owner/admin
+	artisans.Get("/:id/verification",
+		middleware.RequireTenantStaff(),
+		verificationHandler.GetArtisanVerification,
+	)
+
+	// Upload verification document - the artisan (self) or tenant owner/admin
+	artisans.Post("/:id/verification/documents",
+		middleware.RequireTenantStaff(),
+		verificationHandler.UploadDocument,
+	)
+
 	// ============================================================================
 	// Statistics & Analytics
 	// ============================================================================
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupAuditLogRoutes sets up the routes a tenant's audit trail is queried and
+// exported with. Tenant owners and admins may read it, as may staff whose custom
+// roles grant audit_logs.read.
+func (r *Router) setupAuditLogRoutes(api fiber.Router) {
+	// Initialize service and handler
+	auditLogService := service.NewAuditLogService(r.repos, r.config.Logger)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogService)
+
+	// Create audit logs group
+	auditLogs := api.Group("/audit-logs")
+
+	// Auth middleware configuration
+	auditLogs.Use(r.RequireAuth())
+
+	auditLogs.Get("", auditLogHandler.ListAuditLogs)
+	// Exports are recorded in the audit trail themselves
+	auditLogs.Get("/export", middleware.AuditRequests(fiber.MethodGet), auditLogHandler.ExportAuditLogs)
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/pkg/httpcache"
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
@@ -10,7 +11,7 @@ import (
 // setupAvailabilityRoutes sets up availability routes
 func (r *Router) setupAvailabilityRoutes(api fiber.Router) {
 	// Initialize availability service
-	availabilityService := service.NewAvailabilityService(r.repos, r.config.Logger)
+	availabilityService := service.NewAvailabilityService(r.repos, r.config.ResponseCache, r.config.Logger)
 
 	// Initialize availability handler
 	availabilityHandler := handler.NewAvailabilityHandler(availabilityService)
@@ -33,12 +34,14 @@ func (r *Router) setupAvailabilityRoutes(api fiber.Router) {
 	// List availabilities (with filters)
 	availability.Get("",
 		r.RequireAuth(),
+		r.CacheResponses(httpcache.TagAvailability),
 		availabilityHandler.ListAvailabilities,
 	)
 
 	// Get availability by ID
 	availability.Get("/:id",
 		r.RequireAuth(),
+		r.CacheResponses(httpcache.TagAvailability),
 		availabilityHandler.GetAvailability,
 	)
 
@@ -81,18 +84,21 @@ func (r *Router) setupAvailabilityRoutes(api fiber.Router) {
 	// Get weekly schedule for artisan
 	availability.Get("/artisan/:artisan_id/weekly",
 		r.RequireAuth(),
+		r.CacheResponses(httpcache.TagAvailability),
 		availabilityHandler.GetWeeklySchedule,
 	)
 
 	// Get availability by day of week
 	availability.Get("/artisan/:artisan_id/day/:day",
 		r.RequireAuth(),
+		r.CacheResponses(httpcache.TagAvailability),
 		availabilityHandler.GetByDayOfWeek,
 	)
 
 	// Get availability by type
 	availability.Get("/artisan/:artisan_id/type/:type",
 		r.RequireAuth(),
+		r.CacheResponses(httpcache.TagAvailability),
 		availabilityHandler.ListByType,
 	)
 
@@ -1,44 +1,110 @@
 package router
 
 import (
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/handler"
 	"Krafti_Vibe/internal/middleware"
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 )
 
 func (r *Router) setupBookingRoutes(api fiber.Router) {
 	// Initialize dependent services
 	customerService := service.NewCustomerService(r.repos, r.config.Logger)
-	paymentService := service.NewPaymentService(r.repos, r.config.Logger)
+	paymentService := service.NewPaymentService(r.repos, r.config.Logger, r.config.Payments)
 
 	// Initialize booking service with dependencies
-	bookingService := service.NewBookingService(r.repos, r.config.Logger, customerService, paymentService)
+	bookingService := service.NewBookingService(r.repos, r.config.Logger, customerService, paymentService, r.wsBroker, r.locks)
 	bookingHandler := handler.NewBookingHandler(bookingService)
+	policyHandler := handler.NewCancellationPolicyHandler(service.NewCancellationPolicyService(r.repos, r.config.Logger))
+	importHandler := handler.NewBookingImportHandler(service.NewBookingImportService(r.repos, r.config.Logger, bookingService))
+	exportHandler := handler.NewBookingExportHandler(service.NewBookingExportService(r.repos, r.config.Logger, r.config.Storage, r.config.DownloadURLSecret))
+	messageHandler := handler.NewMessageHandler(service.NewMessageService(r.repos, r.config.Logger))
+	filterHandler := handler.NewSavedFilterHandler(service.NewSavedFilterService(r.repos, r.config.Logger))
+	escrowHandler := handler.NewEscrowHandler(service.NewEscrowService(r.repos, r.config.Logger))
+
+	// Export download - public, authenticated by signed URL. Registered ahead of the
+	// bookings group so its auth middleware does not run for this route.
+	api.Get("/bookings/exports/:id/download",
+		exportHandler.DownloadExport,
+	)
 
 	// Create bookings group
 	bookings := api.Group("/bookings")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		bookings.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Auth middleware configuration
 	bookings.Use(r.RequireAuth())
 
+	// ============================================================================
+	// Bulk Import & Export (registered before /:id so "imports" is not parsed as an ID)
+	// ============================================================================
+
+	// Import bookings from CSV - tenant owner/admin only
+	bookings.Post("/import",
+		middleware.RequireTenantOwnerOrAdmin(),
+		r.RequirePlan(models.PlanLimitBookingsPerMonth),
+		importHandler.ImportBookings,
+	)
+
+	// List imports - tenant owner/admin only
+	bookings.Get("/imports",
+		middleware.RequireTenantOwnerOrAdmin(),
+		importHandler.ListImports,
+	)
+
+	// Get import status and validation report - tenant owner/admin only
+	bookings.Get("/imports/:id",
+		middleware.RequireTenantOwnerOrAdmin(),
+		importHandler.GetImport,
+	)
+
+	// Export bookings to CSV/XLSX - tenant owner/admin only
+	bookings.Get("/export",
+		middleware.RequireTenantOwnerOrAdmin(),
+		exportHandler.ExportBookings,
+	)
+
+	// Get export status and download link - tenant owner/admin only
+	bookings.Get("/exports/:id",
+		middleware.RequireTenantOwnerOrAdmin(),
+		exportHandler.GetExport,
+	)
+
+	// ============================================================================
+	// Saved Filters (registered before /:id) - tenant staff, scoped to the current user
+	// ============================================================================
+
+	bookings.Get("/filters",
+		middleware.RequireTenantStaff(),
+		filterHandler.ListFilters,
+	)
+	bookings.Post("/filters",
+		middleware.RequireTenantStaff(),
+		filterHandler.CreateFilter,
+	)
+	bookings.Get("/filters/:id",
+		middleware.RequireTenantStaff(),
+		filterHandler.GetFilter,
+	)
+	bookings.Put("/filters/:id",
+		middleware.RequireTenantStaff(),
+		filterHandler.UpdateFilter,
+	)
+	bookings.Delete("/filters/:id",
+		middleware.RequireTenantStaff(),
+		filterHandler.DeleteFilter,
+	)
+
 	// ============================================================================
 	// Core Booking Operations
 	// ============================================================================
 
 	// Create booking - any authenticated user can create a booking
 	bookings.Post("/",
+		r.Idempotency(),
+		r.RequirePlan(models.PlanLimitBookingsPerMonth),
 		bookingHandler.CreateBooking,
 	)
 
@@ -47,13 +113,38 @@ func (r *Router) setupBookingRoutes(api fiber.Router) {
 		bookingHandler.GetBooking,
 	)
 
+	// Get booking change log - tenant staff only, or service accounts with bookings.read
+	bookings.Get("/:id/history",
+		middleware.AllowServiceAccountScope(authz.ActionBookingRead),
+		middleware.RequireTenantStaff(),
+		bookingHandler.GetBookingHistory,
+	)
+
+	// Link booking to a project - tenant staff only
+	bookings.Put("/:id/project",
+		middleware.RequireTenantStaff(),
+		bookingHandler.LinkBookingProject,
+	)
+
+	// Booking chat thread - the booking's customer and artisan only (enforced in service)
+	bookings.Get("/:id/messages",
+		messageHandler.ListBookingMessages,
+	)
+	bookings.Post("/:id/messages",
+		messageHandler.PostBookingMessage,
+	)
+	bookings.Post("/:id/messages/read",
+		messageHandler.MarkBookingConversationRead,
+	)
+
 	// Update booking - owner (customer/artisan) or tenant owner/admin
 	bookings.Put("/:id",
 		bookingHandler.UpdateBooking,
 	)
 
-	// Delete booking - tenant owner/admin only
+	// Delete booking - tenant owner/admin only, or service accounts with bookings.delete
 	bookings.Delete("/:id",
+		middleware.AllowServiceAccountScope(authz.ActionBookingDelete),
 		middleware.RequireTenantOwnerOrAdmin(),
 		bookingHandler.DeleteBooking,
 	)
@@ -119,11 +210,27 @@ func (r *Router) setupBookingRoutes(api fiber.Router) {
 		bookingHandler.CompleteBooking,
 	)
 
+	// Confirm the work is done, releasing escrowed payments early - customer only
+	bookings.Post("/:id/confirm-completion",
+		escrowHandler.ConfirmCompletion,
+	)
+
+	// Preview cancellation refund and fees - customer, artisan, or tenant owner/admin
+	bookings.Get("/:id/cancellation-quote",
+		policyHandler.QuoteCancellation,
+	)
+
 	// Cancel booking - customer, artisan, or tenant owner/admin
 	bookings.Post("/:id/cancel",
 		bookingHandler.CancelBooking,
 	)
 
+	// Retry a failed cancellation refund - tenant owner/admin only
+	bookings.Post("/:id/retry-refund",
+		middleware.RequireTenantOwnerOrAdmin(),
+		bookingHandler.RetryCancellationRefund,
+	)
+
 	// Mark as no-show - artisan or tenant owner/admin
 	bookings.Post("/:id/no-show",
 		middleware.RequireArtisanOrTeamMember(),
@@ -135,6 +242,14 @@ func (r *Router) setupBookingRoutes(api fiber.Router) {
 		bookingHandler.RescheduleBooking,
 	)
 
+	// Skip or move a single occurrence of a recurring series - customer or artisan
+	bookings.Post("/:id/skip-occurrence",
+		bookingHandler.SkipOccurrence,
+	)
+	bookings.Post("/:id/move-occurrence",
+		bookingHandler.MoveOccurrence,
+	)
+
 	// ============================================================================
 	// Scheduling & Availability
 	// ============================================================================
@@ -212,4 +327,10 @@ func (r *Router) setupBookingRoutes(api fiber.Router) {
 		middleware.RequireTenantOwnerOrAdmin(),
 		bookingHandler.BulkCancel,
 	)
+
+	// Bulk update booking status - tenant owner/admin only
+	bookings.Post("/bulk/status",
+		middleware.RequireTenantOwnerOrAdmin(),
+		bookingHandler.BulkUpdateStatus,
+	)
 }
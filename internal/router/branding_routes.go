@@ -0,0 +1,43 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupBrandingRoutes sets up tenant branding routes. Public booking pages read the
+// branding of the tenant they serve; owners and admins manage it.
+func (r *Router) setupBrandingRoutes(api fiber.Router) {
+	// Initialize service and handler
+	brandingService := service.NewBrandingService(r.repos, r.config.Logger, r.config.Storage, nil)
+	brandingHandler := handler.NewBrandingHandler(brandingService)
+
+	// Create branding group
+	branding := api.Group("/branding")
+
+	// ============================================================================
+	// Public Routes (no auth required)
+	// ============================================================================
+
+	branding.Get("", brandingHandler.GetBranding)
+	branding.Get("/assets/:tenant_id/:file", brandingHandler.GetAsset)
+
+	// ============================================================================
+	// Settings - owners and admins
+	// ============================================================================
+
+	branding.Use(r.RequireAuth(), middleware.RequireTenantOwnerOrAdmin())
+
+	branding.Get("/settings", brandingHandler.GetSettings)
+	branding.Put("/settings", brandingHandler.UpdateSettings)
+	branding.Post("/assets", brandingHandler.UploadAsset)
+
+	// Email sender domain
+	branding.Get("/email-domain", brandingHandler.GetEmailDomain)
+	branding.Put("/email-domain", brandingHandler.SetEmailDomain)
+	branding.Delete("/email-domain", brandingHandler.RemoveEmailDomain)
+	branding.Post("/email-domain/verify", brandingHandler.VerifyEmailDomain)
+}
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupCalendarRoutes must run before setupArtisanRoutes so the token-authenticated
+// feed is matched ahead of the artisans group auth middleware.
+func (r *Router) setupCalendarRoutes(api fiber.Router) {
+	// Initialize service and handler
+	calendarService := service.NewCalendarService(r.repos, r.config.Logger, r.config.CalendarFeedSecret)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+
+	// ============================================================================
+	// Calendar Subscription Feeds
+	// ============================================================================
+
+	// iCalendar feed - public, authenticated by signed token (polled by calendar clients)
+	api.Get("/artisans/:id/calendar.ics",
+		calendarHandler.GetArtisanFeed,
+	)
+
+	// Get subscription URL - artisan (self) or tenant owner/admin, checked by the service
+	api.Get("/artisans/:id/calendar",
+		r.RequireAuth(),
+		middleware.RequireTenantStaff(),
+		calendarHandler.GetArtisanFeedInfo,
+	)
+
+	// Rotate subscription URL, revoking the old one - same callers as above
+	api.Post("/artisans/:id/calendar/rotate",
+		r.RequireAuth(),
+		middleware.RequireTenantStaff(),
+		calendarHandler.RotateArtisanFeedToken,
+	)
+}
@@ -0,0 +1,38 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupCampaignRoutes sets up the routes of the tenant's marketing campaigns
+func (r *Router) setupCampaignRoutes(api fiber.Router) {
+	// Initialize service and handler
+	campaignService := service.NewCampaignService(r.repos, r.config.Logger)
+	campaignHandler := handler.NewCampaignHandler(campaignService)
+
+	// Create campaigns group
+	campaigns := api.Group("/campaigns")
+
+	// Open tracking pixel - public, identified by the token in the link (loaded by mail
+	// clients showing the email)
+	campaigns.Get("/opens/:token", campaignHandler.TrackOpen)
+
+	// Campaigns are managed by tenant owners and admins
+	campaigns.Use(r.RequireAuth())
+	campaigns.Use(middleware.RequireTenantOwnerOrAdmin())
+
+	campaigns.Post("", campaignHandler.CreateCampaign)
+	campaigns.Get("", campaignHandler.ListCampaigns)
+	campaigns.Post("/audience/count", campaignHandler.CountAudience)
+	campaigns.Get("/:id", campaignHandler.GetCampaign)
+	campaigns.Put("/:id", campaignHandler.UpdateCampaign)
+	campaigns.Delete("/:id", campaignHandler.DeleteCampaign)
+	campaigns.Post("/:id/schedule", campaignHandler.ScheduleCampaign)
+	campaigns.Post("/:id/cancel", campaignHandler.CancelCampaign)
+	campaigns.Get("/:id/preview", campaignHandler.PreviewCampaign)
+	campaigns.Get("/:id/recipients", campaignHandler.ListRecipients)
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupCancellationPolicyRoutes sets up cancellation policy management routes
+func (r *Router) setupCancellationPolicyRoutes(api fiber.Router) {
+	// Initialize service and handler
+	policyService := service.NewCancellationPolicyService(r.repos, r.config.Logger)
+	policyHandler := handler.NewCancellationPolicyHandler(policyService)
+
+	// Create cancellation policies group
+	policies := api.Group("/cancellation-policies")
+
+	// Auth middleware configuration
+	policies.Use(r.RequireAuth())
+	policies.Use(middleware.RequireTenantOwnerOrAdmin())
+
+	// ============================================================================
+	// Core CRUD Operations
+	// ============================================================================
+
+	policies.Post("", policyHandler.CreatePolicy)
+	policies.Get("", policyHandler.ListPolicies)
+	policies.Get("/:id", policyHandler.GetPolicy)
+	policies.Put("/:id", policyHandler.UpdatePolicy)
+	policies.Delete("/:id", policyHandler.DeletePolicy)
+}
@@ -0,0 +1,76 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupChangeOrderRoutes sets up project change order routes. Staff draft and submit
+// change orders; the project's customer approves or rejects them.
+func (r *Router) setupChangeOrderRoutes(api fiber.Router) {
+	// Initialize service and handler
+	changeOrderService := service.NewChangeOrderService(r.repos, r.config.Logger)
+	changeOrderHandler := handler.NewChangeOrderHandler(changeOrderService)
+
+	// Create change orders group
+	changeOrders := api.Group("/change-orders")
+
+	// Auth middleware configuration
+	changeOrders.Use(r.RequireAuth())
+
+	// ============================================================================
+	// Customer - change orders on the current customer's projects (before /:id)
+	// ============================================================================
+
+	changeOrders.Get("/received", changeOrderHandler.ListReceivedChangeOrders)
+	changeOrders.Get("/received/:id", changeOrderHandler.GetReceivedChangeOrder)
+
+	// ============================================================================
+	// Staff - drafting and submitting
+	// ============================================================================
+
+	changeOrders.Post("",
+		middleware.RequireTenantStaff(),
+		changeOrderHandler.CreateChangeOrder,
+	)
+
+	changeOrders.Get("",
+		middleware.RequireTenantStaff(),
+		changeOrderHandler.ListChangeOrders,
+	)
+
+	changeOrders.Get("/:id",
+		middleware.RequireTenantStaff(),
+		changeOrderHandler.GetChangeOrder,
+	)
+
+	changeOrders.Put("/:id",
+		middleware.RequireTenantStaff(),
+		changeOrderHandler.UpdateChangeOrder,
+	)
+
+	changeOrders.Post("/:id/submit",
+		middleware.RequireTenantStaff(),
+		changeOrderHandler.SubmitChangeOrder,
+	)
+
+	changeOrders.Post("/:id/cancel",
+		middleware.RequireTenantStaff(),
+		changeOrderHandler.CancelChangeOrder,
+	)
+
+	changeOrders.Get("/:id/history",
+		middleware.RequireTenantStaff(),
+		changeOrderHandler.GetChangeOrderHistory,
+	)
+
+	// ============================================================================
+	// Customer - answering (the service checks the project is theirs)
+	// ============================================================================
+
+	changeOrders.Post("/:id/approve", changeOrderHandler.ApproveChangeOrder)
+	changeOrders.Post("/:id/reject", changeOrderHandler.RejectChangeOrder)
+}
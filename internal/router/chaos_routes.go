@@ -0,0 +1,26 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupChaosRoutes lets platform super admins manage the rules injecting faults into
+// requests, when fault injection is enabled
+func (r *Router) setupChaosRoutes(api fiber.Router) {
+	if r.config.Chaos == nil {
+		return
+	}
+
+	chaosHandler := handler.NewChaosHandler(r.config.Chaos)
+
+	admin := api.Group("/admin/chaos")
+	admin.Use(r.RequireAuth())
+	admin.Use(r.zitadelMW.RequireRole("platform_super_admin"))
+
+	admin.Get("/rules", chaosHandler.ListRules)
+	admin.Post("/rules", chaosHandler.CreateRule)
+	admin.Delete("/rules", chaosHandler.ClearRules)
+	admin.Delete("/rules/:id", chaosHandler.DeleteRule)
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupCommissionRoutes sets up commission rule management routes
+func (r *Router) setupCommissionRoutes(api fiber.Router) {
+	// Initialize service and handler
+	commissionService := service.NewCommissionService(r.repos, r.config.Logger)
+	commissionHandler := handler.NewCommissionHandler(commissionService)
+
+	// Create commission rules group
+	rules := api.Group("/commission-rules")
+
+	// Auth middleware configuration
+	rules.Use(r.RequireAuth())
+	rules.Use(middleware.RequireTenantOwnerOrAdmin())
+
+	// ============================================================================
+	// Core CRUD Operations
+	// ============================================================================
+
+	rules.Post("", commissionHandler.CreateCommissionRule)
+	rules.Get("", commissionHandler.ListCommissionRules)
+	rules.Get("/:id", commissionHandler.GetCommissionRule)
+	rules.Put("/:id", commissionHandler.UpdateCommissionRule)
+	rules.Delete("/:id", commissionHandler.DeleteCommissionRule)
+}
@@ -0,0 +1,21 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupConfigRoutes exposes the running configuration to platform super admins
+func (r *Router) setupConfigRoutes(api fiber.Router) {
+	if r.config.Settings == nil {
+		return
+	}
+
+	configHandler := handler.NewConfigHandler(r.config.Settings)
+
+	admin := api.Group("/admin/config")
+	admin.Use(r.RequireAuth())
+
+	admin.Get("", r.zitadelMW.RequireRole("platform_super_admin"), configHandler.GetEffectiveConfig)
+}
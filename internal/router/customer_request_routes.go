@@ -0,0 +1,71 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupCustomerRequestRoutes sets up the request-for-quote marketplace routes. Customers
+// post requests and accept bids; the artisans a request was broadcast to bid on it.
+func (r *Router) setupCustomerRequestRoutes(api fiber.Router) {
+	// Initialize service and handler
+	customerRequestService := service.NewCustomerRequestService(r.repos, r.config.Logger)
+	customerRequestHandler := handler.NewCustomerRequestHandler(customerRequestService)
+
+	// Create customer requests group
+	requests := api.Group("/customer-requests")
+
+	// Auth middleware configuration
+	requests.Use(r.RequireAuth())
+
+	// ============================================================================
+	// Customer - the current customer's requests (before /:id)
+	// ============================================================================
+
+	requests.Post("", customerRequestHandler.CreateRequest)
+	requests.Get("/mine", customerRequestHandler.ListMyRequests)
+	requests.Get("/mine/:id", customerRequestHandler.GetMyRequest)
+	requests.Post("/mine/:id/bids/:bid_id/accept", customerRequestHandler.AcceptBid)
+	requests.Post("/mine/:id/close", customerRequestHandler.CloseRequest)
+
+	// ============================================================================
+	// Artisan - leads and bids (the service checks the request was broadcast to them)
+	// ============================================================================
+
+	requests.Get("/leads",
+		middleware.RequireArtisanOrTeamMember(),
+		customerRequestHandler.ListLeads,
+	)
+
+	requests.Get("/leads/:id",
+		middleware.RequireArtisanOrTeamMember(),
+		customerRequestHandler.GetLead,
+	)
+
+	requests.Put("/leads/:id/bid",
+		middleware.RequireArtisanOrTeamMember(),
+		customerRequestHandler.SubmitBid,
+	)
+
+	requests.Delete("/leads/:id/bid",
+		middleware.RequireArtisanOrTeamMember(),
+		customerRequestHandler.WithdrawBid,
+	)
+
+	// ============================================================================
+	// Tenant owner/admin - oversight
+	// ============================================================================
+
+	requests.Get("",
+		middleware.RequireTenantOwnerOrAdmin(),
+		customerRequestHandler.ListRequests,
+	)
+
+	requests.Get("/:id",
+		middleware.RequireTenantOwnerOrAdmin(),
+		customerRequestHandler.GetRequest,
+	)
+}
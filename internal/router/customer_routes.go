@@ -6,7 +6,6 @@ import (
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 )
 
 func (r *Router) setupCustomerRoutes(api fiber.Router) {
@@ -17,15 +16,6 @@ func (r *Router) setupCustomerRoutes(api fiber.Router) {
 	// Create customers group
 	customers := api.Group("/customers")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		customers.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Auth middleware configuration
 	customers.Use(r.RequireAuth())
 
@@ -78,6 +68,12 @@ func (r *Router) setupCustomerRoutes(api fiber.Router) {
 		customerHandler.GetCustomerSegments,
 	)
 
+	// Recalculate customer segments - tenant owner/admin only (must be before /:id)
+	customers.Post("/segments/recalculate",
+		middleware.RequireTenantOwnerOrAdmin(),
+		customerHandler.RecalculateCustomerSegments,
+	)
+
 	// Get customer by ID - self or tenant owner/admin
 	customers.Get("/:id",
 		middleware.RequireSelfOrAdmin(),
@@ -144,6 +140,12 @@ func (r *Router) setupCustomerRoutes(api fiber.Router) {
 	// Analytics & Segmentation
 	// ============================================================================
 
+	// Update manual tags - tenant owner/admin only
+	customers.Put("/:id/tags",
+		middleware.RequireTenantOwnerOrAdmin(),
+		customerHandler.UpdateCustomerTags,
+	)
+
 	// Get customer statistics - self or tenant owner/admin
 	customers.Get("/:id/stats",
 		middleware.RequireSelfOrAdmin(),
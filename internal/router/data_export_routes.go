@@ -15,20 +15,17 @@ func (r *Router) setupDataExportRoutes(api fiber.Router) {
 	if zapLogger == nil {
 		zapLogger = zap.NewNop()
 	}
-	exportService := service.NewDataExportService(r.repos, zapLogger, service.DataExportServiceConfig{})
+	exportService := service.NewDataExportService(r.repos, zapLogger, service.DataExportServiceConfig{
+		Store:         r.config.Storage,
+		SigningSecret: r.config.DownloadURLSecret,
+	})
 	exportHandler := handler.NewDataExportHandler(exportService)
 
 	// Create data-exports group
 	exports := api.Group("/data-exports")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		exports.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
+	// Export download - public, authenticated by signed URL
+	exports.Get("/:id/download", exportHandler.DownloadDataExport)
 
 	// ============================================================================
 	// Core Export Operations
@@ -0,0 +1,53 @@
+package router
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/events"
+	"Krafti_Vibe/internal/service"
+)
+
+// Consumers of domain events. A consumer's name identifies it in the outbox, so that a
+// failed event is retried for it alone; renaming one drops its pending retries.
+const (
+	analyticsConsumer = "analytics"
+	webhooksConsumer  = "webhooks"
+)
+
+// setupEvents subscribes the modules that react to booking, payment and project changes
+// to the domain events the event_dispatch job delivers
+func (r *Router) setupEvents() {
+	analyticsRollupService := service.NewAnalyticsRollupService(r.repos, r.config.Logger)
+	webhookService := service.NewWebhookRepository(r.repos, r.config.Logger)
+	webhookSubscriptionService := service.NewWebhookSubscriptionService(r.repos, r.config.Logger, webhookService)
+
+	// Keep the dashboards' rollups of the affected days current without waiting for
+	// the periodic refresh
+	refresh := func(ctx context.Context, envelope *events.Envelope, days ...time.Time) error {
+		if envelope.TenantID == nil {
+			return nil
+		}
+		return analyticsRollupService.RefreshTenantDays(ctx, *envelope.TenantID, days...)
+	}
+	events.On(r.events, analyticsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.BookingCreated) error {
+		return refresh(ctx, envelope, event.StartTime)
+	})
+	events.On(r.events, analyticsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.BookingStatusChanged) error {
+		return refresh(ctx, envelope, event.StartTime)
+	})
+	events.On(r.events, analyticsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.BookingRescheduled) error {
+		return refresh(ctx, envelope, event.PreviousStart, event.StartTime)
+	})
+	events.On(r.events, analyticsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.PaymentSucceeded) error {
+		return refresh(ctx, envelope, event.ProcessedAt)
+	})
+	events.On(r.events, analyticsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.PaymentRefunded) error {
+		return refresh(ctx, envelope, event.ProcessedAt)
+	})
+
+	// Queue every event for the tenants' webhook subscriptions that receive it
+	for _, eventType := range events.Types() {
+		r.events.Subscribe(webhooksConsumer, eventType, webhookSubscriptionService.QueueDeliveries)
+	}
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupFavoriteRoutes sets up the routes of the current user's favorite artisans and services
+func (r *Router) setupFavoriteRoutes(api fiber.Router) {
+	// Initialize service and handler
+	favoriteService := service.NewFavoriteService(r.repos, r.config.Logger)
+	favoriteHandler := handler.NewFavoriteHandler(favoriteService)
+
+	// Create favorites group
+	favorites := api.Group("/favorites")
+
+	// Auth middleware configuration
+	favorites.Use(r.RequireAuth())
+
+	favorites.Get("", favoriteHandler.ListFavorites)
+	favorites.Post("", favoriteHandler.AddFavorite)
+	favorites.Delete("/:kind/:target_id", favoriteHandler.RemoveFavorite)
+}
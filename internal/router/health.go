@@ -0,0 +1,25 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/pkg/health"
+)
+
+// setupHealth adds the backlogs of the outbox and webhook queues to the readiness
+// checks. A backlog is shared by every instance, so only thresholds set to take
+// instances out of rotation do so.
+func (r *Router) setupHealth() {
+	if r.config.Health == nil {
+		return
+	}
+
+	r.config.Health.Register(&health.BacklogChecker{
+		Queue:      OutboxQueue,
+		Count:      r.repos.Outbox.CountPending,
+		Thresholds: r.config.HealthBacklogs[OutboxQueue],
+	})
+	r.config.Health.Register(&health.BacklogChecker{
+		Queue:      WebhooksQueue,
+		Count:      r.repos.WebhookEvent.CountPending,
+		Thresholds: r.config.HealthBacklogs[WebhooksQueue],
+	})
+}
@@ -6,7 +6,6 @@ import (
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 )
 
 func (r *Router) setupInvoiceRoutes(api fiber.Router) {
@@ -17,15 +16,6 @@ func (r *Router) setupInvoiceRoutes(api fiber.Router) {
 	// Create invoices group
 	invoices := api.Group("/invoices")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		invoices.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Auth middleware configuration
 	invoices.Use(r.RequireAuth())
 
@@ -39,6 +29,11 @@ func (r *Router) setupInvoiceRoutes(api fiber.Router) {
 		invoiceHandler.CreateInvoice,
 	)
 
+	// Download invoice as PDF - registered before /:id, which would also match
+	invoices.Get("/:id.pdf",
+		invoiceHandler.DownloadInvoicePDF,
+	)
+
 	// Get invoice by ID - customer (owner) or artisan or tenant owner/admin
 	invoices.Get("/:id",
 		invoiceHandler.GetInvoice,
@@ -80,6 +75,12 @@ func (r *Router) setupInvoiceRoutes(api fiber.Router) {
 		invoiceHandler.GetBookingInvoice,
 	)
 
+	// Issue the invoice for a booking - artisan or tenant owner/admin
+	invoices.Post("/booking/:booking_id/generate",
+		middleware.RequireArtisanOrTeamMember(),
+		invoiceHandler.GenerateBookingInvoice,
+	)
+
 	// Get invoices by customer - customer (self) or tenant owner/admin
 	invoices.Get("/customer/:customer_id",
 		middleware.RequireSelfOrAdmin(),
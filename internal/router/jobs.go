@@ -0,0 +1,302 @@
+package router
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/service"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// noShowJobInterval is how often confirmed bookings are checked for no-shows
+	noShowJobInterval = 5 * time.Minute
+	// exportPurgeJobInterval is how often expired export files are deleted
+	exportPurgeJobInterval = time.Hour
+	// balanceCollectionJobInterval is how often due booking balances are charged
+	balanceCollectionJobInterval = 10 * time.Minute
+	// paymentSyncJobInterval is how often payments awaiting a provider result are polled
+	paymentSyncJobInterval = 2 * time.Minute
+	// providerHealthJobInterval is how often payment providers are probed, so open
+	// circuits close again once a provider recovers
+	providerHealthJobInterval = time.Minute
+	// idempotencyPurgeJobInterval is how often expired idempotency keys are deleted
+	idempotencyPurgeJobInterval = time.Hour
+	// gracePeriodJobInterval is how often tenants past their billing grace period are suspended
+	gracePeriodJobInterval = time.Hour
+	// reconciliationJobInterval is how often yesterday's payments are checked for a
+	// completed reconciliation; each day is reconciled once, after midnight UTC
+	reconciliationJobInterval = time.Hour
+	// statementJobInterval is how often last month's financial statements are checked
+	// for; each tenant's month is generated once, early in the following month
+	statementJobInterval = time.Hour
+	// escrowReleaseJobInterval is how often held payments past their hold period are released
+	escrowReleaseJobInterval = 15 * time.Minute
+	// quoteExpiryJobInterval is how often sent quotes past their validity are expired
+	quoteExpiryJobInterval = time.Hour
+	// servicePublicationJobInterval is how often draft services and pending service
+	// changes scheduled for publication are published
+	servicePublicationJobInterval = time.Minute
+	// projectHealthJobInterval is how often projects with a stale health score are
+	// recalculated
+	projectHealthJobInterval = 30 * time.Minute
+	// webhookDeliveryJobInterval is how often queued webhook events are delivered
+	webhookDeliveryJobInterval = time.Minute
+	// webhookDeliveryBatchSize caps the webhook events delivered per run
+	webhookDeliveryBatchSize = 100
+	// analyticsRefreshJobInterval is how often analytics rollups catch up with changed
+	// bookings and payments, which bounds how stale dashboards get
+	analyticsRefreshJobInterval = 10 * time.Minute
+	// analyticsRebuildJobInterval is how often tenants are checked for a rebuild of their
+	// analytics rollups; each tenant is rebuilt once a day, after midnight UTC
+	analyticsRebuildJobInterval = time.Hour
+	// trashPurgeJobInterval is how often deleted records past their retention are purged
+	trashPurgeJobInterval = time.Hour
+	// partitionMaintenanceJobInterval is how often the coming months' partitions of
+	// bookings and payments are created
+	partitionMaintenanceJobInterval = 6 * time.Hour
+	// archivalJobInterval is how often months of bookings and payments past their
+	// retention are archived; each month is archived once, early in the following one
+	archivalJobInterval = 6 * time.Hour
+	// eventDispatchJobInterval is how often domain events in the outbox are delivered to
+	// their consumers, which bounds how late consumers hear of a change
+	eventDispatchJobInterval = 5 * time.Second
+	// outboxPurgeJobInterval is how often delivered domain events past their retention
+	// are deleted
+	outboxPurgeJobInterval = time.Hour
+	// trialExpiryJobInterval is how often tenants whose trial ended are suspended
+	trialExpiryJobInterval = time.Hour
+	// dataExportJobInterval is how often pending tenant data exports are generated
+	dataExportJobInterval = time.Minute
+	// userErasureJobInterval is how often users whose erasure grace period ended are
+	// anonymized
+	userErasureJobInterval = time.Hour
+	// verificationExpiryJobInterval is how often approved verification documents past
+	// their expiry date are expired, revoking the badges they earned
+	verificationExpiryJobInterval = time.Hour
+	// customerSegmentationJobInterval is how often tenants are checked for customers to
+	// segment; each tenant is segmented once a day after midnight UTC, and again when new
+	// customers join
+	customerSegmentationJobInterval = time.Hour
+	// reviewRequestJobInterval is how often customers of completed bookings are checked
+	// for a review request due under their tenant's moderation policy
+	reviewRequestJobInterval = 15 * time.Minute
+	// ratingReconciliationJobInterval is how often artisan and service ratings are checked
+	// against their published reviews; reviews keep them current, so this only catches drift
+	ratingReconciliationJobInterval = 6 * time.Hour
+	// campaignSendJobInterval is how often due campaigns are started and sending ones sent
+	// their next batch; campaigns' send rates are per run, so per minute
+	campaignSendJobInterval = time.Minute
+	// customerRequestExpiryJobInterval is how often open customer requests past their
+	// bidding period are expired
+	customerRequestExpiryJobInterval = time.Hour
+	// interruptedJobRecoveryTimeout bounds failing the imports and exports a stopped
+	// server left unfinished at startup
+	interruptedJobRecoveryTimeout = 30 * time.Second
+)
+
+// setupJobs registers the background jobs run by the scheduler
+func (r *Router) setupJobs() {
+	paymentService := service.NewPaymentService(r.repos, r.config.Logger, r.config.Payments)
+	bookingService := service.NewBookingService(r.repos, r.config.Logger, service.NewCustomerService(r.repos, r.config.Logger), paymentService, r.wsBroker, r.locks)
+	notificationService := service.NewNotificationService(r.repos, r.config.Logger)
+	noShowService := service.NewNoShowService(r.repos, r.config.Logger, bookingService, paymentService, notificationService)
+
+	r.scheduler.Register("no_show_automation", noShowJobInterval, func(ctx context.Context) error {
+		_, err := noShowService.ProcessNoShows(ctx)
+		return err
+	})
+
+	balanceService := service.NewBalanceCollectionService(r.repos, r.config.Logger, paymentService, notificationService, bookingService)
+	r.scheduler.Register("balance_collection", balanceCollectionJobInterval, func(ctx context.Context) error {
+		_, err := balanceService.CollectDueBalances(ctx)
+		return err
+	})
+
+	r.scheduler.Register("payment_status_sync", paymentSyncJobInterval, func(ctx context.Context) error {
+		_, err := paymentService.SyncPendingPayments(ctx)
+		return err
+	})
+
+	r.scheduler.Register("payment_provider_health", providerHealthJobInterval, func(ctx context.Context) error {
+		paymentService.CheckProviderHealth(ctx)
+		return nil
+	})
+
+	exportService := service.NewBookingExportService(r.repos, r.config.Logger, r.config.Storage, r.config.DownloadURLSecret)
+	r.scheduler.Register("booking_export_purge", exportPurgeJobInterval, func(ctx context.Context) error {
+		_, err := exportService.PurgeExpiredExports(ctx)
+		return err
+	})
+
+	r.scheduler.Register("idempotency_key_purge", idempotencyPurgeJobInterval, func(ctx context.Context) error {
+		_, err := r.repos.IdempotencyKey.DeleteExpired(ctx)
+		return err
+	})
+
+	subscriptionService := service.NewSubscriptionService(r.repos, paymentService, r.config.Logger, r.config.Billing)
+	r.scheduler.Register("subscription_grace_expiry", gracePeriodJobInterval, func(ctx context.Context) error {
+		_, err := subscriptionService.ProcessGracePeriods(ctx)
+		return err
+	})
+
+	reconciliationService := service.NewReconciliationService(r.repos, r.config.Logger, r.config.Payments)
+	r.scheduler.Register("payment_reconciliation", reconciliationJobInterval, func(ctx context.Context) error {
+		_, err := reconciliationService.ReconcilePrevious(ctx)
+		return err
+	})
+
+	statementService := service.NewStatementService(r.repos, r.config.Logger, r.config.Storage)
+	r.scheduler.Register("financial_statements", statementJobInterval, func(ctx context.Context) error {
+		_, err := statementService.GeneratePreviousMonth(ctx)
+		return err
+	})
+
+	escrowService := service.NewEscrowService(r.repos, r.config.Logger)
+	r.scheduler.Register("escrow_release", escrowReleaseJobInterval, func(ctx context.Context) error {
+		_, err := escrowService.ReleaseDueEscrow(ctx)
+		return err
+	})
+
+	quoteService := service.NewQuoteService(r.repos, r.config.Logger)
+	r.scheduler.Register("quote_expiry", quoteExpiryJobInterval, func(ctx context.Context) error {
+		_, err := quoteService.ExpireQuotes(ctx)
+		return err
+	})
+
+	catalogService := service.NewServiceService(r.repos.Service, r.repos.ServicePrice, r.repos.Tenant, r.repos.User, service.NewFavoriteService(r.repos, r.config.Logger), r.config.ResponseCache, r.config.Logger)
+	r.scheduler.Register("service_publication", servicePublicationJobInterval, func(ctx context.Context) error {
+		_, err := catalogService.PublishDue(ctx)
+		return err
+	})
+
+	webhookService := service.NewWebhookRepository(r.repos, r.config.Logger)
+	r.scheduler.Register("webhook_delivery", webhookDeliveryJobInterval, func(ctx context.Context) error {
+		_, err := webhookService.ProcessPendingWebhooks(ctx, webhookDeliveryBatchSize)
+		return err
+	})
+
+	projectHealthService := service.NewProjectHealthService(r.repos, r.config.Logger, notificationService, webhookService)
+	r.scheduler.Register("project_health", projectHealthJobInterval, func(ctx context.Context) error {
+		_, err := projectHealthService.RecalculateHealth(ctx)
+		return err
+	})
+
+	analyticsRollupService := service.NewAnalyticsRollupService(r.repos, r.config.Logger)
+	r.scheduler.Register("analytics_rollup_refresh", analyticsRefreshJobInterval, func(ctx context.Context) error {
+		_, err := analyticsRollupService.RefreshChanged(ctx)
+		return err
+	})
+	r.scheduler.Register("analytics_rollup_rebuild", analyticsRebuildJobInterval, func(ctx context.Context) error {
+		_, err := analyticsRollupService.RebuildDue(ctx)
+		return err
+	})
+
+	trashService := service.NewTrashService(r.repos, r.config.Logger, r.config.TrashRetention)
+	r.scheduler.Register("trash_purge", trashPurgeJobInterval, func(ctx context.Context) error {
+		_, err := trashService.PurgeExpired(ctx)
+		return err
+	})
+
+	eventDispatcher := service.NewEventDispatcher(r.repos, r.config.Logger, r.events)
+	r.scheduler.Register("event_dispatch", eventDispatchJobInterval, func(ctx context.Context) error {
+		_, err := eventDispatcher.DispatchDue(ctx)
+		return err
+	})
+	r.scheduler.Register("outbox_purge", outboxPurgeJobInterval, func(ctx context.Context) error {
+		_, err := eventDispatcher.PurgeDispatched(ctx)
+		return err
+	})
+
+	archiveService := service.NewArchiveService(r.repos, r.config.Logger, r.config.Storage, r.config.ArchiveAfterMonths)
+	r.scheduler.Register("partition_maintenance", partitionMaintenanceJobInterval, archiveService.MaintainPartitions)
+	r.scheduler.Register("data_archival", archivalJobInterval, func(ctx context.Context) error {
+		_, err := archiveService.ArchiveExpired(ctx)
+		return err
+	})
+
+	zapLogger := r.config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	tenantService := service.NewTenantService(r.repos, zapLogger, r.revoker)
+	r.scheduler.Register("tenant_trial_expiry", trialExpiryJobInterval, func(ctx context.Context) error {
+		_, err := tenantService.ExpireTrials(ctx)
+		return err
+	})
+
+	dataExportService := service.NewDataExportService(r.repos, zapLogger, service.DataExportServiceConfig{
+		Store:         r.config.Storage,
+		SigningSecret: r.config.DownloadURLSecret,
+	})
+	r.scheduler.Register("data_export_processing", dataExportJobInterval, func(ctx context.Context) error {
+		_, err := dataExportService.ProcessPendingExports(ctx)
+		return err
+	})
+	r.scheduler.Register("data_export_purge", exportPurgeJobInterval, func(ctx context.Context) error {
+		_, err := dataExportService.DeleteExpiredExports(ctx)
+		return err
+	})
+
+	erasureService := service.NewUserErasureService(r.repos, r.config.Logger, r.revoker, r.config.Identity)
+	r.scheduler.Register("user_erasure", userErasureJobInterval, func(ctx context.Context) error {
+		_, err := erasureService.ProcessDueErasures(ctx)
+		return err
+	})
+
+	verificationService := service.NewVerificationService(r.repos, r.config.Logger, r.config.Storage)
+	r.scheduler.Register("verification_expiry", verificationExpiryJobInterval, func(ctx context.Context) error {
+		_, err := verificationService.ExpireDocuments(ctx)
+		return err
+	})
+
+	customerService := service.NewCustomerService(r.repos, r.config.Logger)
+	r.scheduler.Register("customer_segmentation", customerSegmentationJobInterval, func(ctx context.Context) error {
+		_, err := customerService.SegmentDue(ctx)
+		return err
+	})
+
+	reviewService := service.NewReviewService(r.repos, r.config.Logger)
+	r.scheduler.Register("review_requests", reviewRequestJobInterval, func(ctx context.Context) error {
+		_, err := reviewService.SendReviewRequests(ctx)
+		return err
+	})
+	r.scheduler.Register("rating_reconciliation", ratingReconciliationJobInterval, func(ctx context.Context) error {
+		_, err := reviewService.ReconcileRatings(ctx)
+		return err
+	})
+
+	campaignService := service.NewCampaignService(r.repos, r.config.Logger)
+	r.scheduler.Register("campaign_sends", campaignSendJobInterval, func(ctx context.Context) error {
+		_, err := campaignService.ProcessCampaigns(ctx)
+		return err
+	})
+
+	customerRequestService := service.NewCustomerRequestService(r.repos, r.config.Logger)
+	r.scheduler.Register("customer_request_expiry", customerRequestExpiryJobInterval, func(ctx context.Context) error {
+		_, err := customerRequestService.ExpireRequests(ctx)
+		return err
+	})
+
+	// Nothing resumes the imports and exports a stopped server left unfinished
+	importService := service.NewBookingImportService(r.repos, r.config.Logger, bookingService)
+	r.failInterruptedJobs(
+		importService.FailInterruptedImports,
+		exportService.FailInterruptedExports,
+		dataExportService.FailInterruptedExports,
+	)
+}
+
+// failInterruptedJobs marks the jobs each function finds left unfinished as failed, so
+// their requesters see they have to start them again
+func (r *Router) failInterruptedJobs(fails ...func(ctx context.Context) (int64, error)) {
+	ctx, cancel := context.WithTimeout(context.Background(), interruptedJobRecoveryTimeout)
+	defer cancel()
+
+	for _, fail := range fails {
+		if _, err := fail(ctx); err != nil {
+			r.config.Logger.Error("failed to fail interrupted jobs", "error", err)
+		}
+	}
+}
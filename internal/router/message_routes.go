@@ -2,11 +2,9 @@ package router
 
 import (
 	"Krafti_Vibe/internal/handler"
-	"Krafti_Vibe/internal/middleware"
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 )
 
 func (r *Router) setupMessageRoutes(api fiber.Router) {
@@ -17,15 +15,6 @@ func (r *Router) setupMessageRoutes(api fiber.Router) {
 	// Create messages group
 	messages := api.Group("/messages")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		messages.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Auth middleware configuration
 
 	// ============================================================================
@@ -0,0 +1,99 @@
+package router
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/events"
+	"Krafti_Vibe/internal/domain/models"
+)
+
+// metricsConsumer is the consumer of domain events counting bookings and payments
+const metricsConsumer = "metrics"
+
+// queueSampleInterval is how often the depths of the outbox and webhook queues are
+// sampled
+const queueSampleInterval = 30 * time.Second
+
+// Queues whose depth is exported and checked, as named in the queue label
+const (
+	OutboxQueue   = "domain_events"
+	WebhooksQueue = "webhook_deliveries"
+)
+
+// setupMetrics counts the bookings and payments domain events report, and samples the
+// depth of the queues background jobs work through, for the Prometheus endpoint
+func (r *Router) setupMetrics() {
+	m := r.config.Metrics
+	if m == nil {
+		return
+	}
+
+	// Counters are incremented once per event: the consumer never fails, so events
+	// are not redelivered to it
+	events.On(r.events, metricsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.BookingCreated) error {
+		m.RecordBooking(string(event.Status), envelopeTenant(envelope))
+		return nil
+	})
+	events.On(r.events, metricsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.BookingStatusChanged) error {
+		m.RecordBookingStatusChange(string(event.From), string(event.To), envelopeTenant(envelope))
+		return nil
+	})
+	events.On(r.events, metricsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.PaymentSucceeded) error {
+		m.RecordPayment(string(models.PaymentStatusPaid), string(event.Type), envelopeTenant(envelope), event.Amount, event.Currency)
+		return nil
+	})
+	events.On(r.events, metricsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.PaymentFailed) error {
+		m.RecordPayment(string(models.PaymentStatusFailed), string(event.Type), envelopeTenant(envelope), event.Amount, event.Currency)
+		return nil
+	})
+	events.On(r.events, metricsConsumer, func(ctx context.Context, envelope *events.Envelope, event events.PaymentRefunded) error {
+		m.RecordPayment(string(models.PaymentStatusRefunded), string(models.PaymentTypeRefund), envelopeTenant(envelope), event.Amount, event.Currency)
+		return nil
+	})
+
+	// Every instance samples the queues, so the depths don't go stale when the jobs
+	// draining them run elsewhere
+	r.config.Lifecycle.Go("queue_sampler", func(ctx context.Context) error {
+		ticker := time.NewTicker(queueSampleInterval)
+		defer ticker.Stop()
+
+		for {
+			r.sampleQueues(ctx)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// sampleQueues records the depths of the outbox and webhook queues, leaving a depth
+// that can't be counted at its last value
+func (r *Router) sampleQueues(ctx context.Context) {
+	if depth, err := r.repos.Outbox.CountPending(ctx); err != nil {
+		if ctx.Err() == nil {
+			r.config.Logger.Warn("failed to sample queue depth", "queue", OutboxQueue, "error", err)
+		}
+	} else {
+		r.config.Metrics.SetQueueDepth(OutboxQueue, depth)
+	}
+
+	if depth, err := r.repos.WebhookEvent.CountPending(ctx); err != nil {
+		if ctx.Err() == nil {
+			r.config.Logger.Warn("failed to sample queue depth", "queue", WebhooksQueue, "error", err)
+		}
+	} else {
+		r.config.Metrics.SetQueueDepth(WebhooksQueue, depth)
+	}
+}
+
+// envelopeTenant returns the tenant an event belongs to as a label value, "" for
+// platform events
+func envelopeTenant(envelope *events.Envelope) string {
+	if envelope.TenantID == nil {
+		return ""
+	}
+	return envelope.TenantID.String()
+}
@@ -6,25 +6,22 @@ import (
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 )
 
 func (r *Router) setupNotificationRoutes(api fiber.Router) {
 	// Initialize service and handler
 	notificationService := service.NewNotificationService(r.repos, r.config.Logger)
 	notificationHandler := handler.NewNotificationHandler(notificationService)
+	preferenceService := service.NewCommunicationPreferenceService(r.repos, r.config.Logger)
+	preferenceHandler := handler.NewCommunicationPreferenceHandler(preferenceService)
 
 	// Create notifications group
 	notifications := api.Group("/notifications")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		notifications.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
+	// Unsubscribe links - public, authenticated by the token in the link (followed from
+	// messages, or posted by mail clients for one-click unsubscribing)
+	notifications.Get("/unsubscribe/:token", preferenceHandler.Unsubscribe)
+	notifications.Post("/unsubscribe/:token", preferenceHandler.Unsubscribe)
 
 	// Auth middleware configuration
 
@@ -0,0 +1,36 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupOnboardingRoutes sets up the self-serve onboarding wizard routes
+func (r *Router) setupOnboardingRoutes(api fiber.Router) {
+	// Initialize service and handler
+	onboardingService := service.NewOnboardingService(r.repos, r.config.Logger, r.config.Identity)
+	onboardingHandler := handler.NewOnboardingHandler(onboardingService)
+
+	// Create onboarding group
+	onboarding := api.Group("/onboarding")
+
+	// ============================================================================
+	// Public Routes - signing up happens before the owner has an account
+	// ============================================================================
+
+	onboarding.Post("", onboardingHandler.StartOnboarding)
+	onboarding.Post("/resend-invite", onboardingHandler.ResendOwnerInvite)
+
+	// ============================================================================
+	// Wizard Steps - taken by the invited owner once signed in
+	// ============================================================================
+
+	steps := onboarding.Group("", r.RequireAuth(), middleware.RequireTenantOwnerOrAdmin())
+	steps.Get("/progress", onboardingHandler.GetProgress)
+	steps.Put("/preferences", onboardingHandler.SetPreferences)
+	steps.Post("/services", onboardingHandler.SetupServices)
+	steps.Put("/working-hours", onboardingHandler.SetWorkingHours)
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupPanicRoutes exposes the panics recovered while serving requests, and each
+// route's error budget, to platform super admins
+func (r *Router) setupPanicRoutes(api fiber.Router) {
+	if r.config.Panics == nil {
+		return
+	}
+
+	panicHandler := handler.NewPanicHandler(r.config.Panics)
+
+	admin := api.Group("/admin/panics")
+	admin.Use(r.RequireAuth())
+
+	admin.Get("", r.zitadelMW.RequireRole("platform_super_admin"), panicHandler.GetPanicReport)
+}
@@ -1,31 +1,35 @@
 package router
 
 import (
+	"Krafti_Vibe/internal/domain/authz"
 	"Krafti_Vibe/internal/handler"
 	"Krafti_Vibe/internal/middleware"
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 )
 
 func (r *Router) setupPaymentRoutes(api fiber.Router) {
 	// Initialize service and handler
-	paymentService := service.NewPaymentService(r.repos, r.config.Logger)
+	paymentService := service.NewPaymentService(r.repos, r.config.Logger, r.config.Payments)
 	paymentHandler := handler.NewPaymentHandler(paymentService)
+	escrowHandler := handler.NewEscrowHandler(service.NewEscrowService(r.repos, r.config.Logger))
+
+	// Provider webhooks are unauthenticated; the service verifies each provider's
+	// signature, or for M-Pesa the secret token in the path
+	api.Post("/webhooks/payments/mpesa/:token", paymentHandler.MPesaCallback)
+	api.Post("/webhooks/payments/:provider", paymentHandler.PaymentWebhook)
+
+	// Circuit breaker state of each provider - platform staff only
+	api.Get("/admin/payment-providers/health",
+		r.RequireAuth(),
+		r.zitadelMW.RequireAnyPlatformRole(),
+		paymentHandler.GetProviderHealth,
+	)
 
 	// Create payments group
 	payments := api.Group("/payments")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		payments.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Auth middleware configuration
 	payments.Use(r.RequireAuth())
 
@@ -35,9 +39,36 @@ func (r *Router) setupPaymentRoutes(api fiber.Router) {
 
 	// Create payment - customer when paying for booking
 	payments.Post("/",
+		r.Idempotency(),
 		paymentHandler.CreatePayment,
 	)
 
+	// Start a payment with the tenant's provider - customer when paying for booking
+	payments.Post("/intents",
+		r.Idempotency(),
+		paymentHandler.InitiatePayment,
+	)
+
+	// Capture an authorized or approved provider payment - tenant owner/admin only, or
+	// service accounts with payments.create
+	payments.Post("/:id/capture",
+		middleware.AllowServiceAccountScope(authz.ActionPaymentCreate),
+		middleware.RequireTenantOwnerOrAdmin(),
+		paymentHandler.CapturePayment,
+	)
+
+	// Release or withhold the artisan's share held in escrow - tenant owner/admin only;
+	// releasing pays out, so it takes a recent MFA sign-in if the tenant requires it
+	payments.Post("/:id/escrow/release",
+		middleware.RequireTenantOwnerOrAdmin(),
+		r.RequireStepUp(),
+		escrowHandler.ReleaseEscrow,
+	)
+	payments.Post("/:id/escrow/withhold",
+		middleware.RequireTenantOwnerOrAdmin(),
+		escrowHandler.WithholdEscrow,
+	)
+
 	// Get payment by ID - owner (customer/artisan) or tenant owner/admin
 	payments.Get("/:id",
 		paymentHandler.GetPayment,
@@ -102,9 +133,12 @@ func (r *Router) setupPaymentRoutes(api fiber.Router) {
 	// Refunds
 	// ============================================================================
 
-	// Process refund - tenant owner/admin only
+	// Process refund - tenant owner/admin only, with a recent MFA sign-in if the tenant
+	// requires it, or service accounts with payments.refund
 	payments.Post("/:id/refund",
+		middleware.AllowServiceAccountScope(authz.ActionPaymentRefund),
 		middleware.RequireTenantOwnerOrAdmin(),
+		r.RequireStepUp(),
 		paymentHandler.ProcessRefund,
 	)
 
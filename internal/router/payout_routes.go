@@ -0,0 +1,80 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (r *Router) setupPayoutRoutes(api fiber.Router) {
+	// Initialize service and handler
+	payoutService := service.NewPayoutService(r.repos, r.config.Logger, r.config.Payments)
+	payoutHandler := handler.NewPayoutHandler(payoutService)
+
+	// Create payouts group
+	payouts := api.Group("/payouts")
+
+	// Auth middleware configuration
+	payouts.Use(r.RequireAuth())
+
+	// ============================================================================
+	// Payout Batches - tenant owner/admin only; moving money takes a recent MFA sign-in
+	// if the tenant requires it
+	// ============================================================================
+
+	// Pay out unsettled artisan earnings
+	payouts.Post("/batches",
+		middleware.RequireTenantOwnerOrAdmin(),
+		r.RequireStepUp(),
+		r.Idempotency(),
+		payoutHandler.CreatePayoutBatch,
+	)
+
+	// List payout batches
+	payouts.Get("/batches",
+		middleware.RequireTenantOwnerOrAdmin(),
+		payoutHandler.ListPayoutBatches,
+	)
+
+	// Get a payout batch with its payouts
+	payouts.Get("/batches/:id",
+		middleware.RequireTenantOwnerOrAdmin(),
+		payoutHandler.GetPayoutBatch,
+	)
+
+	// Download a bank transfer batch as CSV
+	payouts.Get("/batches/:id/bank-file",
+		middleware.RequireTenantOwnerOrAdmin(),
+		r.RequireStepUp(),
+		payoutHandler.DownloadBankFile,
+	)
+
+	// Confirm a bank transfer batch was paid
+	payouts.Post("/batches/:id/mark-paid",
+		middleware.RequireTenantOwnerOrAdmin(),
+		r.RequireStepUp(),
+		payoutHandler.MarkBatchPaid,
+	)
+
+	// Record a rejected bank transfer payout
+	payouts.Post("/:id/mark-failed",
+		middleware.RequireTenantOwnerOrAdmin(),
+		payoutHandler.MarkPayoutFailed,
+	)
+
+	// ============================================================================
+	// Artisan Payout History - artisan (self) or tenant owner/admin
+	// ============================================================================
+
+	payouts.Get("/artisan/:artisan_id",
+		middleware.RequireArtisanOrTeamMember(),
+		payoutHandler.GetArtisanPayouts,
+	)
+
+	payouts.Get("/artisan/:artisan_id/summary",
+		middleware.RequireArtisanOrTeamMember(),
+		payoutHandler.GetArtisanPayoutSummary,
+	)
+}
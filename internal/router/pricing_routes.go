@@ -0,0 +1,33 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupPricingRoutes sets up dynamic pricing rule management routes
+func (r *Router) setupPricingRoutes(api fiber.Router) {
+	// Initialize service and handler
+	pricingService := service.NewPricingService(r.repos, r.config.Logger)
+	pricingHandler := handler.NewPricingRuleHandler(pricingService)
+
+	// Create pricing rules group
+	rules := api.Group("/pricing-rules")
+
+	// Auth middleware configuration
+	rules.Use(r.RequireAuth())
+	rules.Use(middleware.RequireTenantOwnerOrAdmin())
+
+	// ============================================================================
+	// Core CRUD Operations
+	// ============================================================================
+
+	rules.Post("", pricingHandler.CreatePricingRule)
+	rules.Get("", pricingHandler.ListPricingRules)
+	rules.Get("/:id", pricingHandler.GetPricingRule)
+	rules.Put("/:id", pricingHandler.UpdatePricingRule)
+	rules.Delete("/:id", pricingHandler.DeletePricingRule)
+}
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"Krafti_Vibe/internal/domain/authz"
 	"Krafti_Vibe/internal/handler"
 	"Krafti_Vibe/internal/middleware"
 	"Krafti_Vibe/internal/service"
@@ -12,6 +13,13 @@ func (r *Router) setupProjectRoutes(api fiber.Router) {
 	// Initialize service
 	projectService := service.NewProjectService(r.repos, r.config.Logger)
 	projectHandler := handler.NewProjectHandler(projectService)
+	timeTrackingService := service.NewTimeTrackingService(r.repos, r.config.Logger)
+	timeEntryHandler := handler.NewTimeEntryHandler(timeTrackingService)
+	projectHealthService := service.NewProjectHealthService(r.repos, r.config.Logger,
+		service.NewNotificationService(r.repos, r.config.Logger),
+		service.NewWebhookRepository(r.repos, r.config.Logger),
+	)
+	projectHealthHandler := handler.NewProjectHealthHandler(projectHealthService)
 
 	// Create project routes
 	projects := api.Group("/projects")
@@ -38,14 +46,17 @@ func (r *Router) setupProjectRoutes(api fiber.Router) {
 		projectHandler.GetProjectWithDetails,
 	)
 
-	// Update project - artisan (assigned) or tenant owner/admin
+	// Update project - artisan (assigned) or tenant owner/admin, or service accounts with
+	// projects.update
 	projects.Put("/:id",
+		middleware.AllowServiceAccountScope(authz.ActionProjectUpdate),
 		middleware.RequireArtisanOrTeamMember(),
 		projectHandler.UpdateProject,
 	)
 
-	// Delete project - tenant owner/admin only
+	// Delete project - tenant owner/admin only, or service accounts with projects.delete
 	projects.Delete("/:id",
+		middleware.AllowServiceAccountScope(authz.ActionProjectDelete),
 		middleware.RequireTenantOwnerOrAdmin(),
 		projectHandler.DeleteProject,
 	)
@@ -93,26 +104,34 @@ func (r *Router) setupProjectRoutes(api fiber.Router) {
 	// Status Management
 	// ============================================================================
 
-	// Start project - artisan (assigned) or tenant owner/admin
+	// Start project - artisan (assigned) or tenant owner/admin, or service accounts with
+	// projects.update
 	projects.Post("/:id/start",
+		middleware.AllowServiceAccountScope(authz.ActionProjectUpdate),
 		middleware.RequireArtisanOrTeamMember(),
 		projectHandler.StartProject,
 	)
 
-	// Pause project - artisan (assigned) or tenant owner/admin
+	// Pause project - artisan (assigned) or tenant owner/admin, or service accounts with
+	// projects.update
 	projects.Post("/:id/pause",
+		middleware.AllowServiceAccountScope(authz.ActionProjectUpdate),
 		middleware.RequireArtisanOrTeamMember(),
 		projectHandler.PauseProject,
 	)
 
-	// Resume project - artisan (assigned) or tenant owner/admin
+	// Resume project - artisan (assigned) or tenant owner/admin, or service accounts with
+	// projects.update
 	projects.Post("/:id/resume",
+		middleware.AllowServiceAccountScope(authz.ActionProjectUpdate),
 		middleware.RequireArtisanOrTeamMember(),
 		projectHandler.ResumeProject,
 	)
 
-	// Complete project - artisan (assigned) or tenant owner/admin
+	// Complete project - artisan (assigned) or tenant owner/admin, or service accounts with
+	// projects.update
 	projects.Post("/:id/complete",
+		middleware.AllowServiceAccountScope(authz.ActionProjectUpdate),
 		middleware.RequireArtisanOrTeamMember(),
 		projectHandler.CompleteProject,
 	)
@@ -143,11 +162,48 @@ func (r *Router) setupProjectRoutes(api fiber.Router) {
 		projectHandler.GetProjectHealth,
 	)
 
+	// Get recorded project health scores for trend charts - owner (artisan/customer) or tenant owner/admin
+	projects.Get("/:id/health/history",
+		projectHealthHandler.GetProjectHealthHistory,
+	)
+
+	// Get project schedule (critical path) - owner (artisan/customer) or tenant owner/admin
+	projects.Get("/:id/schedule",
+		projectHandler.GetProjectSchedule,
+	)
+
 	// Get project timeline - owner (artisan/customer) or tenant owner/admin
 	projects.Get("/:id/timeline",
 		projectHandler.GetProjectTimeline,
 	)
 
+	// ============================================================================
+	// Time Tracking
+	// ============================================================================
+
+	// List project time entries - artisan (assigned) or tenant owner/admin
+	projects.Get("/:id/time-entries",
+		middleware.RequireArtisanOrTeamMember(),
+		timeEntryHandler.ListProjectTimeEntries,
+	)
+
+	// Get actual vs estimated hours - owner (artisan/customer) or tenant owner/admin
+	projects.Get("/:id/hours",
+		timeEntryHandler.GetProjectHours,
+	)
+
+	// Export uninvoiced billable hours - artisan (assigned) or tenant owner/admin
+	projects.Get("/:id/billable-hours",
+		middleware.RequireArtisanOrTeamMember(),
+		timeEntryHandler.ExportBillableHours,
+	)
+
+	// Invoice billable hours to the customer - artisan (assigned) or tenant owner/admin
+	projects.Post("/:id/billable-hours/invoice",
+		middleware.RequireArtisanOrTeamMember(),
+		timeEntryHandler.InvoiceBillableHours,
+	)
+
 	// ============================================================================
 	// Dashboard
 	// ============================================================================
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupProjectTemplateRoutes sets up project template routes. Templates are managed by
+// the tenant's staff, who also start projects from them.
+func (r *Router) setupProjectTemplateRoutes(api fiber.Router) {
+	// Initialize service and handler
+	templateService := service.NewProjectTemplateService(r.repos, r.config.Logger)
+	templateHandler := handler.NewProjectTemplateHandler(templateService)
+
+	// Create project templates group
+	templates := api.Group("/project-templates")
+
+	// Auth middleware configuration
+	templates.Use(r.RequireAuth())
+	templates.Use(middleware.RequireTenantStaff())
+
+	// ============================================================================
+	// Template management
+	// ============================================================================
+
+	templates.Post("", templateHandler.CreateProjectTemplate)
+	templates.Get("", templateHandler.ListProjectTemplates)
+	templates.Get("/:id", templateHandler.GetProjectTemplate)
+	templates.Put("/:id", templateHandler.UpdateProjectTemplate)
+	templates.Delete("/:id", templateHandler.DeleteProjectTemplate)
+
+	// ============================================================================
+	// Instantiation
+	// ============================================================================
+
+	templates.Post("/:id/projects", templateHandler.CreateProjectFromTemplate)
+}
@@ -0,0 +1,76 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupQuoteRoutes sets up quote routes. Staff draft, revise and send quotes; the
+// customer a quote was sent to accepts or declines it.
+func (r *Router) setupQuoteRoutes(api fiber.Router) {
+	// Initialize service and handler
+	quoteService := service.NewQuoteService(r.repos, r.config.Logger)
+	quoteHandler := handler.NewQuoteHandler(quoteService)
+
+	// Create quotes group
+	quotes := api.Group("/quotes")
+
+	// Auth middleware configuration
+	quotes.Use(r.RequireAuth())
+
+	// ============================================================================
+	// Customer - quotes sent to the current customer (before /:id)
+	// ============================================================================
+
+	quotes.Get("/received", quoteHandler.ListReceivedQuotes)
+	quotes.Get("/received/:id", quoteHandler.GetReceivedQuote)
+
+	// ============================================================================
+	// Staff - drafting and sending
+	// ============================================================================
+
+	quotes.Post("",
+		middleware.RequireTenantStaff(),
+		quoteHandler.CreateQuote,
+	)
+
+	quotes.Get("",
+		middleware.RequireTenantStaff(),
+		quoteHandler.ListQuotes,
+	)
+
+	quotes.Get("/:id",
+		middleware.RequireTenantStaff(),
+		quoteHandler.GetQuote,
+	)
+
+	quotes.Put("/:id",
+		middleware.RequireTenantStaff(),
+		quoteHandler.UpdateQuote,
+	)
+
+	quotes.Delete("/:id",
+		middleware.RequireTenantStaff(),
+		quoteHandler.DeleteQuote,
+	)
+
+	quotes.Post("/:id/send",
+		middleware.RequireTenantStaff(),
+		quoteHandler.SendQuote,
+	)
+
+	quotes.Get("/:id/revisions",
+		middleware.RequireTenantStaff(),
+		quoteHandler.ListQuoteRevisions,
+	)
+
+	// ============================================================================
+	// Customer - answering (the service checks the quote was sent to them)
+	// ============================================================================
+
+	quotes.Post("/:id/accept", quoteHandler.AcceptQuote)
+	quotes.Post("/:id/decline", quoteHandler.DeclineQuote)
+}
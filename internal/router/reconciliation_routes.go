@@ -0,0 +1,25 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (r *Router) setupReconciliationRoutes(api fiber.Router) {
+	// Initialize service and handler
+	reconciliationService := service.NewReconciliationService(r.repos, r.config.Logger, r.config.Payments)
+	reconciliationHandler := handler.NewReconciliationHandler(reconciliationService)
+
+	// Create reconciliation group - platform staff only
+	reconciliation := api.Group("/admin/reconciliation")
+	reconciliation.Use(r.RequireAuth())
+	reconciliation.Use(r.zitadelMW.RequireAnyPlatformRole())
+
+	// Get a day's reconciliation reports
+	reconciliation.Get("/:date", reconciliationHandler.GetReconciliation)
+
+	// Re-run a day's reconciliation
+	reconciliation.Post("/:date/run", reconciliationHandler.RunReconciliation)
+}
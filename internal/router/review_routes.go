@@ -52,9 +52,33 @@ func (r *Router) setupReviewRoutes(api fiber.Router) {
 	// Respond to review - artisan (reviewed) or tenant owner/admin
 	reviews.Post("/:id/respond", middleware.RequireArtisanOrTeamMember(), reviewHandler.RespondToReview)
 
+	// Reply in the review's thread - reviewed artisan, or the reviewer once the artisan has responded
+	reviews.Post("/:id/replies", reviewHandler.ReplyToReview)
+
+	// Get the review's thread - any authenticated user with access to the review
+	reviews.Get("/:id/replies", reviewHandler.GetReviewReplies)
+
 	// Mark review as helpful - any authenticated user
 	reviews.Post("/:id/helpful", reviewHandler.MarkHelpful)
 
+	// Report a review, or dispute it as the reviewed artisan - any authenticated user
+	reviews.Post("/:id/flags", reviewHandler.FlagReview)
+
+	// ============================================================================
+	// Moderation
+	// ============================================================================
+
+	// Reviews held under the tenant's moderation policy - tenant owner/admin
+	reviews.Get("/moderation/queue", middleware.RequireTenantOwnerOrAdmin(), reviewHandler.GetModerationQueue)
+
+	// Publish or reject a review - tenant owner/admin
+	reviews.Post("/:id/publish", middleware.RequireTenantOwnerOrAdmin(), reviewHandler.PublishReview)
+	reviews.Post("/:id/reject", middleware.RequireTenantOwnerOrAdmin(), reviewHandler.RejectReview)
+
+	// Reports and disputes - tenant owner/admin
+	reviews.Get("/moderation/flags", middleware.RequireTenantOwnerOrAdmin(), reviewHandler.ListReviewFlags)
+	reviews.Post("/moderation/flags/:flag_id/resolve", middleware.RequireTenantOwnerOrAdmin(), reviewHandler.ResolveReviewFlag)
+
 	// ============================================================================
 	// Analytics & Statistics
 	// ============================================================================
@@ -1,9 +1,27 @@
 package router
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/config"
+	"Krafti_Vibe/internal/domain/events"
+	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/infrastructure/cache"
+	"Krafti_Vibe/internal/infrastructure/payments"
+	"Krafti_Vibe/internal/infrastructure/storage"
 	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/pkg/background"
+	"Krafti_Vibe/internal/pkg/chaos"
+	"Krafti_Vibe/internal/pkg/health"
+	"Krafti_Vibe/internal/pkg/httpcache"
+	"Krafti_Vibe/internal/pkg/lifecycle"
+	"Krafti_Vibe/internal/pkg/metrics"
+	"Krafti_Vibe/internal/pkg/panics"
+	"Krafti_Vibe/internal/pkg/scheduler"
 	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service"
 	ws "Krafti_Vibe/internal/websocket"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,16 +33,41 @@ import (
 	"gorm.io/gorm"
 )
 
+// defaultTokenLifetime is Zitadel's default access token lifetime, for which token
+// revocations are kept unless configured otherwise
+const defaultTokenLifetime = 12 * time.Hour
+
 // Config holds the router configuration
 type Config struct {
-	DB                *gorm.DB
-	Logger            log.AllLogger
-	ZitadelAuthZ      *authorization.Authorizer[*oauth.IntrospectionContext]
-	ZitadelMiddleware *middleware.ZitadelAuthMiddleware
-	Cache             cache.Cache            // Optional: for rate limiting
-	ZapLogger         *zap.Logger            // Optional: for rate limiting (zap structured logging)
-	CORSConfig        *middleware.CORSConfig // Optional: for CORS
-	WebhookSecret     string                 // Webhook signing secret
+	DB                 *gorm.DB
+	Logger             log.AllLogger
+	Lifecycle          *lifecycle.Manager // Runs background jobs and workers until shutdown
+	ZitadelAuthZ       *authorization.Authorizer[*oauth.IntrospectionContext]
+	ZitadelMiddleware  *middleware.ZitadelAuthMiddleware
+	Cache              cache.Cache                         // Optional: for rate limiting
+	ZapLogger          *zap.Logger                         // Optional: for rate limiting (zap structured logging)
+	CORSConfig         *middleware.CORSConfig              // Optional: for CORS
+	WebhookSecret      string                              // Webhook signing secret
+	CalendarFeedSecret string                              // Calendar feed token signing secret
+	Storage            storage.ObjectStore                 // Optional: for generated files such as exports
+	DownloadURLSecret  string                              // File download URL signing secret
+	Payments           *payments.Registry                  // Optional: payment providers available to tenants
+	Billing            payments.Billing                    // Optional: bills tenants for their platform plan
+	QueryTimeouts      repository.QueryTimeouts            // Optional: time budgets of repository operations
+	TrashRetention     time.Duration                       // Optional: how long deleted records stay restorable
+	ArchiveAfterMonths map[string]int                      // Optional: whole months of bookings and payments kept before archival, by table
+	RateLimit          *middleware.TenantRateLimitConfig   // Optional: API rate limits, enforced when Redis is configured
+	Metrics            *metrics.PrometheusMetrics          // Optional: records repository, cache, queue and domain metrics
+	TokenLifetime      time.Duration                       // Optional: longest lifetime of access tokens, for how long revocations are kept
+	Identity           service.IdentityProvider            // Optional: creates and invites Zitadel users; self-serve onboarding is unavailable without it
+	MachineIdentity    service.MachineIdentityProvider     // Optional: creates the Zitadel machine users of service accounts; they can't be created without it
+	BaseDomain         string                              // Domain tenant subdomains are served under
+	Health             *health.HealthChecker               // Optional: readiness checks the depths of the queues are added to
+	HealthBacklogs     map[string]health.BacklogThresholds // Optional: backlog thresholds of the queues, by queue
+	Settings           *config.Watcher                     // Optional: the running configuration, shown to platform super admins
+	Panics             *panics.Tracker                     // Optional: panics recovered while serving requests, shown to platform super admins
+	ResponseCache      *httpcache.Cache                    // Optional: caches responses of hot read routes, such as the service catalog and availability
+	Chaos              *chaos.Injector                     // Optional: fault injection rules, managed by platform super admins outside production
 }
 
 // Router handles all application routes
@@ -35,6 +78,14 @@ type Router struct {
 	zitadelMW *middleware.ZitadelAuthMiddleware
 	wsHub     *ws.Hub
 	wsHandler *ws.Handler
+	wsBroker  *ws.Broker
+	scheduler *scheduler.Scheduler
+	tasks     *background.Pool
+	locks     service.ScheduleLocker // Nil without Redis
+	revoker   service.TokenRevoker   // Nil without Redis
+	events    *events.Registry
+	mfaGuard  *middleware.MFAPolicyGuard    // Nil without Zitadel
+	limiter   *middleware.TenantRateLimiter // Nil without rate limiting
 }
 
 // New creates a new router instance
@@ -43,35 +94,127 @@ func New(app *fiber.App, config *Config) *Router {
 	hub := ws.NewHub()
 	handler := ws.NewHandler(hub)
 
+	// Fan realtime events out through Redis so every API instance receives them, and
+	// keep background jobs and slot holds to one instance at a time
+	var pubsub ws.PubSub
+	var locks service.ScheduleLocker
+	var revocations *cache.TokenRevocations
+	jobs := scheduler.New(config.Logger)
+	zapLogger := config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	if redisClient, ok := config.Cache.(*cache.RedisClient); ok && redisClient != nil {
+		pubsub = redisClient
+		locks = redisClient
+		jobs.UseLocker(redisClient)
+
+		tokenLifetime := config.TokenLifetime
+		if tokenLifetime <= 0 {
+			tokenLifetime = defaultTokenLifetime
+		}
+		revocations = cache.NewTokenRevocations(redisClient, tokenLifetime)
+	}
+
+	// Refuse revoked tokens, such as those of deactivated users and suspended tenants
+	var revoker service.TokenRevoker
+	if revocations != nil {
+		revoker = revocations
+		if config.ZitadelMiddleware != nil {
+			config.ZitadelMiddleware.CheckRevocations(revocations, config.ZapLogger)
+		}
+	}
+
 	return &Router{
 		app:       app,
 		config:    config,
 		zitadelMW: config.ZitadelMiddleware,
 		wsHub:     hub,
 		wsHandler: handler,
+		wsBroker:  ws.NewBroker(hub, pubsub, config.Logger),
+		scheduler: jobs,
+		tasks:     background.New(zapLogger),
+		locks:     locks,
+		revoker:   revoker,
+		events:    events.NewRegistry(),
 	}
 }
 
 // Setup initializes all routes
 func (r *Router) Setup() error {
 	// Initialize repositories
-	r.repos = repository.NewRepositories(r.config.DB, repository.RepositoryConfig{
-		Logger: r.config.Logger,
-	})
+	repoConfig := repository.RepositoryConfig{
+		Logger:        r.config.Logger,
+		QueryTimeouts: r.config.QueryTimeouts,
+	}
+	if redisClient, ok := r.config.Cache.(*cache.RedisClient); ok && redisClient != nil {
+		repoConfig.Cache = repository.NewDefaultCacheAdapter(redisClient)
+	}
+	if r.config.Metrics != nil {
+		repoConfig.Metrics = repository.NewDefaultMetricsCollector(r.config.Metrics)
+	}
+	// Record the changes services make while serving mutating requests in the audit trail
+	repoConfig.AuditLogger = service.NewAuditTrail(repository.NewAuditLogRepository(r.config.DB, repoConfig), r.config.Logger)
+	r.repos = repository.NewRepositories(r.config.DB, repoConfig)
+
+	// Log slow queries and bound statements issued outside a repository operation
+	if err := repository.RegisterQueryMonitor(r.config.DB, r.config.QueryTimeouts, r.config.Logger, nil); err != nil {
+		return fmt.Errorf("failed to register query monitor: %w", err)
+	}
 
 	// Start WebSocket hub
 	go r.wsHub.Run()
+	r.config.Lifecycle.Go("realtime_broker", func(ctx context.Context) error {
+		r.wsBroker.Run(ctx)
+		return nil
+	})
 	r.config.Logger.Info("WebSocket hub started")
 
+	// Run the work requests leave behind, such as large imports and notification
+	// delivery, until shutdown, which waits for it once the server has stopped
+	r.config.Lifecycle.Go("background_tasks", r.tasks.Run)
+	r.config.Lifecycle.OnStop("background_tasks", r.tasks.Drain)
+	r.app.Use(func(c *fiber.Ctx) error {
+		c.Locals(background.ContextKey, r.tasks)
+		return c.Next()
+	})
+
 	// Apply global CORS middleware if configured
 	if r.config.CORSConfig != nil {
 		r.app.Use(middleware.CORSMiddleware(*r.config.CORSConfig))
 		r.config.Logger.Info("CORS middleware enabled")
 	}
 
+	// Write error responses in the language of the request, its user or its tenant
+	r.setupLocales()
+
+	// Serve requests to tenant subdomains and custom domains for their tenant
+	r.setupTenantHosts()
+
+	// Apply rate limits ahead of every API route
+	r.setupRateLimiting()
+
+	// Hold authenticated requests to their tenant's lifecycle status and MFA policy
+	r.setupTenantStatusGuard()
+	r.setupMFAPolicy()
+
+	// Confine the service accounts of tenants' integrations to their scopes
+	r.setupServiceAccounts()
+
+	// Mark mutating requests for the audit trail
+	r.app.Use(middleware.AuditRequests())
+
 	// Setup API routes
 	r.setupAPIRoutes()
 
+	// Subscribe consumers to domain events and start background jobs, which deliver them
+	r.setupEvents()
+	r.setupMetrics()
+	r.setupHealth()
+	r.setupJobs()
+	r.config.Lifecycle.Go("scheduler", r.scheduler.Run)
+	r.config.Logger.Info("background jobs started")
+
 	return nil
 }
 
@@ -100,28 +243,56 @@ func (r *Router) setupAPIRoutes() {
 
 	// Setup feature routes
 	r.setupUserRoutes(api)
+	r.setupCalendarRoutes(api)
 	r.setupArtisanRoutes(api)
 	r.setupCustomerRoutes(api)
 	r.setupBookingRoutes(api)
+	r.setupCancellationPolicyRoutes(api)
+	r.setupTaxRoutes(api)
+	r.setupCommissionRoutes(api)
+	r.setupPricingRoutes(api)
+	r.setupVerificationRoutes(api)
 	r.setupInvoiceRoutes(api)
 	r.setupPaymentRoutes(api)
+	r.setupPayoutRoutes(api)
+	r.setupReconciliationRoutes(api)
+	r.setupConfigRoutes(api)
+	r.setupPanicRoutes(api)
+	r.setupChaosRoutes(api)
+	r.setupStatementRoutes(api)
 	r.setupSubscriptionRoutes(api)
 	r.setupMessageRoutes(api)
 	r.setupNotificationRoutes(api)
 	r.setupDataExportRoutes(api)
 	r.setupTenantUsageRoutes(api)
 	r.setupTenantRoutes(api)
+	r.setupOnboardingRoutes(api)
+	r.setupTenantRoleRoutes(api)
+	r.setupServiceAccountRoutes(api)
+	r.setupAuditLogRoutes(api)
 	r.setupMilestoneRoutes(api)
 	r.setupTaskRoutes(api)
 	r.setupServiceRoutes(api)
 	r.setupProjectRoutes(api)
+	r.setupTimeEntryRoutes(api)
+	r.setupQuoteRoutes(api)
+	r.setupProjectTemplateRoutes(api)
+	r.setupChangeOrderRoutes(api)
 	r.setupReviewRoutes(api)
+	r.setupFavoriteRoutes(api)
+	r.setupCampaignRoutes(api)
+	r.setupSkillRoutes(api)
+	r.setupCustomerRequestRoutes(api)
+	r.setupBrandingRoutes(api)
+	r.setupTrashRoutes(api)
+	r.setupSearchRoutes(api)
 
 	// Setup WebSocket routes
 	r.setupWebSocketRoutes(api, r.wsHandler)
 
 	// Setup Webhook routes
 	r.setupWebhookRoutes(api)
+	r.setupWebhookSubscriptionRoutes(api)
 
 	// Setup File Upload routes
 	r.setupFileUploadRoutes(api)
@@ -166,3 +337,164 @@ func (r *Router) RequireAuth(opts ...authorization.CheckOption) fiber.Handler {
 	}
 	return r.zitadelMW.RequireAuth(opts...)
 }
+
+// Idempotency returns middleware that replays the first response for requests retried
+// with the same Idempotency-Key. Keys are kept in Redis when it is configured, else Postgres.
+func (r *Router) Idempotency() fiber.Handler {
+	var store middleware.IdempotencyStore = r.repos.IdempotencyKey
+	if r.config.Cache != nil {
+		store = middleware.NewCacheIdempotencyStore(r.config.Cache)
+	}
+
+	zapLogger := r.config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	return middleware.Idempotency(middleware.DefaultIdempotencyConfig(store, zapLogger))
+}
+
+// setupRateLimiting holds every request to the rate limit of its IP address,
+// and authenticated requests to the limits of their API key and their tenant's plan. Limits are counted in Redis
+// so they hold across instances; without it requests are not limited.
+func (r *Router) setupRateLimiting() {
+	redisClient, ok := r.config.Cache.(*cache.RedisClient)
+	if r.config.RateLimit == nil || !ok || redisClient == nil {
+		return
+	}
+
+	paymentService := service.NewPaymentService(r.repos, r.config.Logger, r.config.Payments)
+	config := *r.config.RateLimit
+	config.Store = redisClient
+	config.Plans = service.NewSubscriptionService(r.repos, paymentService, r.config.Logger, r.config.Billing)
+	config.Metrics = r.config.Metrics
+	if config.Logger == nil {
+		config.Logger = r.config.ZapLogger
+	}
+
+	r.limiter = middleware.NewTenantRateLimiter(config)
+	r.app.Use(r.limiter.Handler())
+	if r.zitadelMW != nil {
+		r.zitadelMW.LimitTenants(r.limiter)
+	}
+	r.config.Logger.Info("rate limiting enabled")
+}
+
+// RateLimiter returns the rate limiter set up by Setup, whose limits can be changed
+// while it runs; nil when rate limiting is disabled
+func (r *Router) RateLimiter() *middleware.TenantRateLimiter {
+	return r.limiter
+}
+
+// setupTenantHosts resolves the tenant of every request made to a tenant's subdomain or
+// verified custom domain, and holds authenticated users to it
+func (r *Router) setupTenantHosts() {
+	zapLogger := r.config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	r.app.Use(middleware.ResolveTenantHost(r.newTenantDomainService(), zapLogger))
+}
+
+// newTenantDomainService creates the service resolving hosts to tenants, caching them
+// in Redis when configured
+func (r *Router) newTenantDomainService() service.TenantDomainService {
+	var hostCache cache.Cache
+	if redisClient, ok := r.config.Cache.(*cache.RedisClient); ok && redisClient != nil {
+		hostCache = redisClient
+	}
+	return service.NewTenantDomainService(r.repos, r.config.Logger, hostCache, r.config.BaseDomain, nil)
+}
+
+// setupLocales resolves the languages error responses are written in, from the
+// Accept-Language header, the user's preferred language and the tenant's default one
+func (r *Router) setupLocales() {
+	zapLogger := r.config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	tenantService := service.NewTenantService(r.repos, zapLogger, r.revoker)
+	r.app.Use(middleware.NewLocaleResolver(middleware.DefaultLocaleConfig(tenantService, zapLogger)).Handler())
+}
+
+// setupTenantStatusGuard makes suspended and cancelled tenants read-only and refuses
+// closed ones, once their users are authenticated
+func (r *Router) setupTenantStatusGuard() {
+	if r.zitadelMW == nil {
+		return
+	}
+
+	zapLogger := r.config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	tenantService := service.NewTenantService(r.repos, zapLogger, r.revoker)
+	r.zitadelMW.GuardTenantStatus(middleware.NewTenantStatusGuard(middleware.DefaultTenantStatusConfig(tenantService, zapLogger)))
+}
+
+// setupMFAPolicy refuses staff signed in without a second factor when their tenant
+// requires one of their role, and records the multi-factor sign-ins it sees
+func (r *Router) setupMFAPolicy() {
+	if r.zitadelMW == nil {
+		return
+	}
+
+	zapLogger := r.config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	tenantService := service.NewTenantService(r.repos, zapLogger, r.revoker)
+	r.mfaGuard = middleware.NewMFAPolicyGuard(middleware.DefaultMFAPolicyConfig(tenantService, r.repos.User, zapLogger))
+	r.zitadelMW.EnforceMFA(r.mfaGuard)
+}
+
+// setupServiceAccounts recognizes the tokens of tenants' service accounts, confining
+// them to the resources their scopes reach and counting their requests
+func (r *Router) setupServiceAccounts() {
+	if r.zitadelMW == nil {
+		return
+	}
+
+	zapLogger := r.config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	accountService := service.NewServiceAccountService(r.repos, r.config.Logger, r.config.MachineIdentity)
+	r.zitadelMW.ConfineServiceAccounts(middleware.NewServiceAccountGuard(middleware.DefaultServiceAccountConfig(accountService, zapLogger)))
+}
+
+// RequireStepUp returns middleware for sensitive actions, such as refunds and payouts,
+// answering 401 with a step-up challenge when the tenant requires a recent
+// multi-factor sign-in for them and the token has none. It is a no-op without Zitadel.
+func (r *Router) RequireStepUp() fiber.Handler {
+	if r.mfaGuard == nil {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+	return r.mfaGuard.RequireStepUp()
+}
+
+// CacheResponses returns middleware serving a read route's responses from the response
+// cache under tag, or a no-op handler when responses are not cached. It must follow
+// RequireAuth.
+func (r *Router) CacheResponses(tag string) fiber.Handler {
+	if r.config.ResponseCache == nil {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+	return middleware.ResponseCache(r.config.ResponseCache, tag)
+}
+
+// RequirePlan returns middleware that answers 402 when the tenant's subscription plan
+// has no room left for limit, or when the subscription is not in good standing
+func (r *Router) RequirePlan(limit models.PlanLimit) fiber.Handler {
+	paymentService := service.NewPaymentService(r.repos, r.config.Logger, r.config.Payments)
+	subscriptionService := service.NewSubscriptionService(r.repos, paymentService, r.config.Logger, r.config.Billing)
+
+	zapLogger := r.config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	return middleware.RequirePlan(subscriptionService, limit, zapLogger)
+}
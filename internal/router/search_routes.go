@@ -0,0 +1,23 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupSearchRoutes sets up the route searching across a tenant's records
+func (r *Router) setupSearchRoutes(api fiber.Router) {
+	// Initialize service and handler
+	searchService := service.NewSearchService(r.repos, r.config.Logger)
+	searchHandler := handler.NewSearchHandler(searchService)
+
+	// Create search group - tenant staff only
+	search := api.Group("/search")
+	search.Use(r.RequireAuth())
+	search.Use(middleware.RequireTenantStaff())
+
+	search.Get("/", searchHandler.Search)
+}
@@ -0,0 +1,42 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupServiceAccountRoutes sets up the routes tenant admins manage the service
+// accounts of their integrations with
+func (r *Router) setupServiceAccountRoutes(api fiber.Router) {
+	// Initialize service and handler
+	accountService := service.NewServiceAccountService(r.repos, r.config.Logger, r.config.MachineIdentity)
+	accountHandler := handler.NewServiceAccountHandler(accountService)
+
+	// Create service accounts group
+	accounts := api.Group("/service-accounts")
+
+	// Auth middleware configuration
+	accounts.Use(r.RequireAuth())
+	accounts.Use(middleware.RequireTenantOwnerOrAdmin())
+
+	// ============================================================================
+	// Core CRUD Operations
+	// ============================================================================
+
+	accounts.Get("/scopes", accountHandler.ListScopes)
+	accounts.Post("", accountHandler.CreateServiceAccount)
+	accounts.Get("", accountHandler.ListServiceAccounts)
+	accounts.Get("/:id", accountHandler.GetServiceAccount)
+	accounts.Put("/:id", accountHandler.UpdateServiceAccount)
+	accounts.Delete("/:id", accountHandler.RevokeServiceAccount)
+
+	// ============================================================================
+	// Credentials & Usage
+	// ============================================================================
+
+	accounts.Post("/:id/rotate-secret", accountHandler.RotateSecret)
+	accounts.Get("/:id/usage", accountHandler.GetUsage)
+}
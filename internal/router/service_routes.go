@@ -2,6 +2,7 @@ package router
 
 import (
 	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/pkg/httpcache"
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,7 +10,7 @@ import (
 
 func (r *Router) setupServiceRoutes(api fiber.Router) {
 	// Initialize service
-	serviceService := service.NewServiceService(r.repos.Service, r.repos.Tenant, r.repos.User, r.config.Logger)
+	serviceService := service.NewServiceService(r.repos.Service, r.repos.ServicePrice, r.repos.Tenant, r.repos.User, service.NewFavoriteService(r.repos, r.config.Logger), r.config.ResponseCache, r.config.Logger)
 	serviceHandler := handler.NewServiceHandler(serviceService)
 
 	// Create service catalog routes
@@ -30,6 +31,7 @@ func (r *Router) setupServiceRoutes(api fiber.Router) {
 	// Get service by ID (read-only)
 	services.Get("/:id",
 		r.RequireAuth(),
+		r.CacheResponses(httpcache.TagServices),
 		serviceHandler.GetServiceByID,
 	)
 
@@ -58,6 +60,7 @@ func (r *Router) setupServiceRoutes(api fiber.Router) {
 	// Search services
 	services.Get("/search",
 		r.RequireAuth(),
+		r.CacheResponses(httpcache.TagServices),
 		serviceHandler.SearchServices,
 	)
 
@@ -77,6 +80,44 @@ func (r *Router) setupServiceRoutes(api fiber.Router) {
 		serviceHandler.DeactivateService,
 	)
 
+	// ============================================================================
+	// Publishing Workflow
+	// ============================================================================
+
+	// Save or discard pending changes to a published service
+	services.Put("/:id/draft",
+		r.RequireAuth(),
+		serviceHandler.SaveServiceDraft,
+	)
+	services.Delete("/:id/draft",
+		r.RequireAuth(),
+		serviceHandler.DiscardServiceDraft,
+	)
+
+	// Preview the service as publishing would leave it
+	services.Get("/:id/preview",
+		r.RequireAuth(),
+		serviceHandler.PreviewService,
+	)
+
+	// Publish now or schedule publication
+	services.Post("/:id/publish",
+		r.RequireAuth(),
+		serviceHandler.PublishService,
+	)
+
+	// Archive service
+	services.Post("/:id/archive",
+		r.RequireAuth(),
+		serviceHandler.ArchiveService,
+	)
+
+	// Price history referenced by bookings
+	services.Get("/:id/price-versions",
+		r.RequireAuth(),
+		serviceHandler.GetServicePriceVersions,
+	)
+
 	// ============================================================================
 	// Analytics & Statistics
 	// ============================================================================
@@ -90,6 +131,7 @@ func (r *Router) setupServiceRoutes(api fiber.Router) {
 	// Get popular services
 	services.Get("/popular",
 		r.RequireAuth(),
+		r.CacheResponses(httpcache.TagServices),
 		serviceHandler.GetPopularServices,
 	)
 }
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupSkillRoutes sets up the routes of the tenant's skill taxonomy. Artisans' skills
+// and matching are under the artisan routes.
+func (r *Router) setupSkillRoutes(api fiber.Router) {
+	// Initialize service and handler
+	skillService := service.NewSkillService(r.repos, r.config.Logger)
+	skillHandler := handler.NewSkillHandler(skillService)
+
+	// Create skills group
+	skills := api.Group("/skills")
+
+	// Auth middleware configuration
+	skills.Use(r.RequireAuth())
+
+	// List skills - any authenticated user, to pick skills and describe requests
+	skills.Get("", skillHandler.ListSkills)
+
+	// Manage the taxonomy - tenant owner/admin only
+	skills.Post("",
+		middleware.RequireTenantOwnerOrAdmin(),
+		skillHandler.CreateSkill,
+	)
+	skills.Put("/:id",
+		middleware.RequireTenantOwnerOrAdmin(),
+		skillHandler.UpdateSkill,
+	)
+	skills.Delete("/:id",
+		middleware.RequireTenantOwnerOrAdmin(),
+		skillHandler.DeleteSkill,
+	)
+}
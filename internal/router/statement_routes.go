@@ -0,0 +1,45 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupStatementRoutes sets up financial statement routes
+func (r *Router) setupStatementRoutes(api fiber.Router) {
+	// Initialize service and handler
+	statementService := service.NewStatementService(r.repos, r.config.Logger, r.config.Storage)
+	statementHandler := handler.NewStatementHandler(statementService)
+
+	// Create statements group
+	statements := api.Group("/statements")
+	statements.Use(r.RequireAuth())
+
+	// List, generate and download any statement - tenant owner/admin only
+	statements.Get("",
+		middleware.RequireTenantOwnerOrAdmin(),
+		statementHandler.ListStatements,
+	)
+	statements.Post("/generate",
+		middleware.RequireTenantOwnerOrAdmin(),
+		statementHandler.GenerateStatements,
+	)
+
+	// An artisan's earnings statements - self (artisan) or tenant owner/admin
+	statements.Get("/artisans/:id",
+		middleware.RequireSelfOrAdmin(),
+		statementHandler.ListArtisanStatements,
+	)
+	statements.Get("/artisans/:id/:statement_id/download",
+		middleware.RequireSelfOrAdmin(),
+		statementHandler.DownloadArtisanStatement,
+	)
+
+	statements.Get("/:id/download",
+		middleware.RequireTenantOwnerOrAdmin(),
+		statementHandler.DownloadStatement,
+	)
+}
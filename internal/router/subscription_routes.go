@@ -6,31 +6,54 @@ import (
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 )
 
 func (r *Router) setupSubscriptionRoutes(api fiber.Router) {
 	// Initialize dependent services
-	paymentService := service.NewPaymentService(r.repos, r.config.Logger)
+	paymentService := service.NewPaymentService(r.repos, r.config.Logger, r.config.Payments)
 
 	// Initialize subscription service with dependencies
-	subscriptionService := service.NewSubscriptionService(r.repos, paymentService, r.config.Logger)
+	subscriptionService := service.NewSubscriptionService(r.repos, paymentService, r.config.Logger, r.config.Billing)
 	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService)
 
+	// Stripe Billing webhooks are unauthenticated; the service verifies the signature
+	api.Post("/webhooks/billing/stripe", subscriptionHandler.BillingWebhook)
+
 	// Create subscriptions group
 	subscriptions := api.Group("/subscriptions")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		subscriptions.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Auth middleware configuration
 
+	// ============================================================================
+	// Plans & Billing
+	// ============================================================================
+
+	// Plan catalogue (authenticated)
+	subscriptions.Get("/plans",
+		r.RequireAuth(),
+		subscriptionHandler.ListPlans,
+	)
+
+	// Edit a plan's prices and limits (platform staff only)
+	subscriptions.Put("/plans/:code",
+		r.RequireAuth(),
+		r.zitadelMW.RequireAnyPlatformRole(),
+		subscriptionHandler.UpsertPlan,
+	)
+
+	// Move the caller's tenant onto a plan (tenant owners and admins)
+	subscriptions.Post("/subscribe",
+		r.RequireAuth(),
+		middleware.RequireTenantOwnerOrAdmin(),
+		subscriptionHandler.Subscribe,
+	)
+
+	// Usage against the tenant's plan limits (authenticated)
+	subscriptions.Get("/usage",
+		r.RequireAuth(),
+		subscriptionHandler.GetUsage,
+	)
+
 	// ============================================================================
 	// Core Subscription Operations
 	// ============================================================================
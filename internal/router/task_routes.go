@@ -2,11 +2,9 @@ package router
 
 import (
 	"Krafti_Vibe/internal/handler"
-	"Krafti_Vibe/internal/middleware"
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
-	"go.uber.org/zap"
 )
 
 func (r *Router) setupTaskRoutes(api fiber.Router) {
@@ -17,15 +15,6 @@ func (r *Router) setupTaskRoutes(api fiber.Router) {
 	// Create tasks group
 	tasks := api.Group("/tasks")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		tasks.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Auth middleware configuration
 
 	// ============================================================================
@@ -66,6 +55,28 @@ func (r *Router) setupTaskRoutes(api fiber.Router) {
 		taskHandler.CompleteTask,
 	)
 
+	// ============================================================================
+	// Dependencies
+	// ============================================================================
+
+	// Get task dependencies (authenticated, requires task:read scope)
+	tasks.Get("/:id/dependencies",
+		r.RequireAuth(),
+		taskHandler.GetTaskDependencies,
+	)
+
+	// Add task dependency (authenticated, requires task:write scope)
+	tasks.Post("/:id/dependencies",
+		r.RequireAuth(),
+		taskHandler.AddTaskDependency,
+	)
+
+	// Remove task dependency (authenticated, requires task:write scope)
+	tasks.Delete("/:id/dependencies/:depends_on_id",
+		r.RequireAuth(),
+		taskHandler.RemoveTaskDependency,
+	)
+
 	// ============================================================================
 	// Related Resource Queries
 	// ============================================================================
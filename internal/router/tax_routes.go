@@ -0,0 +1,38 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupTaxRoutes sets up tax rule management and tax quote routes
+func (r *Router) setupTaxRoutes(api fiber.Router) {
+	// Initialize service and handler
+	taxService := service.NewTaxService(r.repos, r.config.Logger)
+	taxHandler := handler.NewTaxHandler(taxService)
+
+	// Create tax rules group
+	rules := api.Group("/tax-rules")
+
+	// Auth middleware configuration
+	rules.Use(r.RequireAuth())
+
+	// ============================================================================
+	// Quotes (any tenant user, e.g. to show prices at checkout)
+	// ============================================================================
+
+	rules.Post("/quote", taxHandler.QuoteTax)
+
+	// ============================================================================
+	// Core CRUD Operations
+	// ============================================================================
+
+	rules.Post("", middleware.RequireTenantOwnerOrAdmin(), taxHandler.CreateTaxRule)
+	rules.Get("", middleware.RequireTenantOwnerOrAdmin(), taxHandler.ListTaxRules)
+	rules.Get("/:id", middleware.RequireTenantOwnerOrAdmin(), taxHandler.GetTaxRule)
+	rules.Put("/:id", middleware.RequireTenantOwnerOrAdmin(), taxHandler.UpdateTaxRule)
+	rules.Delete("/:id", middleware.RequireTenantOwnerOrAdmin(), taxHandler.DeleteTaxRule)
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
 	"Krafti_Vibe/internal/service"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,8 +10,10 @@ import (
 
 // setupTenantInvitationRoutes sets up tenant invitation routes
 func (r *Router) setupTenantInvitationRoutes(api fiber.Router) {
-	// Initialize tenant invitation service
-	invitationService := service.NewTenantInvitationService(r.repos, r.config.ZapLogger)
+	// Initialize tenant invitation service, holding artisan invitations to the plan's seats
+	paymentService := service.NewPaymentService(r.repos, r.config.Logger, r.config.Payments)
+	subscriptionService := service.NewSubscriptionService(r.repos, paymentService, r.config.Logger, r.config.Billing)
+	invitationService := service.NewTenantInvitationService(r.repos, r.config.ZapLogger, subscriptionService, r.config.Identity)
 
 	// Initialize tenant invitation handler
 	invitationHandler := handler.NewTenantInvitationHandler(invitationService)
@@ -18,7 +21,8 @@ func (r *Router) setupTenantInvitationRoutes(api fiber.Router) {
 	// Tenant invitation routes group
 	invitations := api.Group("/invitations")
 
-	// Auth middleware configuration
+	// Staff are invited and managed by the tenant's owner and admins
+	requireAdmin := middleware.RequireTenantOwnerOrAdmin()
 
 	// ============================================================================
 	// Core Invitation Operations
@@ -27,21 +31,17 @@ func (r *Router) setupTenantInvitationRoutes(api fiber.Router) {
 	// Create invitation
 	invitations.Post("",
 		r.RequireAuth(),
+		requireAdmin,
 		invitationHandler.CreateInvitation,
 	)
 
 	// List invitations
 	invitations.Get("",
 		r.RequireAuth(),
+		requireAdmin,
 		invitationHandler.ListInvitations,
 	)
 
-	// Get invitation by ID
-	invitations.Get("/:id",
-		r.RequireAuth(),
-		invitationHandler.GetInvitation,
-	)
-
 	// ============================================================================
 	// Token-Based Operations
 	// ============================================================================
@@ -58,6 +58,7 @@ func (r *Router) setupTenantInvitationRoutes(api fiber.Router) {
 	// Get pending invitations
 	invitations.Get("/pending",
 		r.RequireAuth(),
+		requireAdmin,
 		invitationHandler.GetPendingInvitations,
 	)
 
@@ -67,6 +68,20 @@ func (r *Router) setupTenantInvitationRoutes(api fiber.Router) {
 		invitationHandler.GetInvitationsByEmail,
 	)
 
+	// Get the tenant's team and seats
+	invitations.Get("/team",
+		r.RequireAuth(),
+		requireAdmin,
+		invitationHandler.GetTeam,
+	)
+
+	// Get invitation by ID, after the static routes it would shadow
+	invitations.Get("/:id",
+		r.RequireAuth(),
+		requireAdmin,
+		invitationHandler.GetInvitation,
+	)
+
 	// ============================================================================
 	// Invitation Actions
 	// ============================================================================
@@ -79,12 +94,14 @@ func (r *Router) setupTenantInvitationRoutes(api fiber.Router) {
 	// Revoke invitation
 	invitations.Post("/:id/revoke",
 		r.RequireAuth(),
+		requireAdmin,
 		invitationHandler.RevokeInvitation,
 	)
 
 	// Resend invitation
 	invitations.Post("/:id/resend",
 		r.RequireAuth(),
+		requireAdmin,
 		invitationHandler.ResendInvitation,
 	)
 
@@ -95,6 +112,7 @@ func (r *Router) setupTenantInvitationRoutes(api fiber.Router) {
 	// Delete expired invitations
 	invitations.Delete("/cleanup/expired",
 		r.RequireAuth(),
+		requireAdmin,
 		invitationHandler.DeleteExpiredInvitations,
 	)
 }
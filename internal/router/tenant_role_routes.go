@@ -0,0 +1,42 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupTenantRoleRoutes sets up the routes tenant admins manage their custom roles with
+func (r *Router) setupTenantRoleRoutes(api fiber.Router) {
+	// Initialize service and handler
+	roleService := service.NewTenantRoleService(r.repos, r.config.Logger)
+	roleHandler := handler.NewTenantRoleHandler(roleService)
+
+	// Create roles group
+	roles := api.Group("/roles")
+
+	// Auth middleware configuration
+	roles.Use(r.RequireAuth())
+	roles.Use(middleware.RequireTenantOwnerOrAdmin())
+
+	// ============================================================================
+	// Core CRUD Operations
+	// ============================================================================
+
+	roles.Get("/permissions", roleHandler.ListPermissions)
+	roles.Post("", roleHandler.CreateRole)
+	roles.Get("", roleHandler.ListRoles)
+	roles.Get("/:id", roleHandler.GetRole)
+	roles.Put("/:id", roleHandler.UpdateRole)
+	roles.Delete("/:id", roleHandler.DeleteRole)
+
+	// ============================================================================
+	// Assignments
+	// ============================================================================
+
+	roles.Get("/:id/users", roleHandler.ListAssignments)
+	roles.Post("/:id/users", roleHandler.AssignRole)
+	roles.Delete("/:id/users/:userId", roleHandler.UnassignRole)
+}
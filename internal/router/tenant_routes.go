@@ -15,21 +15,13 @@ func (r *Router) setupTenantRoutes(api fiber.Router) {
 	if zapLogger == nil {
 		zapLogger = zap.NewNop()
 	}
-	tenantService := service.NewTenantService(r.repos, zapLogger)
+	tenantService := service.NewTenantService(r.repos, zapLogger, r.revoker)
 	tenantHandler := handler.NewTenantHandler(tenantService)
+	domainHandler := handler.NewTenantDomainHandler(r.newTenantDomainService())
 
 	// Create tenants group
 	tenants := api.Group("/tenants")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		tenants.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Auth middleware configuration
 	tenants.Use(r.RequireAuth())
 
@@ -78,6 +70,16 @@ func (r *Router) setupTenantRoutes(api fiber.Router) {
 		tenantHandler.CheckDomainAvailability,
 	)
 
+	// Custom domain verification of the caller's tenant - tenant owner/admin only (must be before /:id)
+	tenants.Get("/domain-verification",
+		middleware.RequireTenantOwnerOrAdmin(),
+		domainHandler.GetDomainVerification,
+	)
+	tenants.Post("/domain-verification/verify",
+		middleware.RequireTenantOwnerOrAdmin(),
+		domainHandler.VerifyDomain,
+	)
+
 	// Get expired trials - platform admin only (must be before /:id)
 	tenants.Get("/trials/expired",
 		r.zitadelMW.RequireAnyPlatformRole(),
@@ -128,6 +130,12 @@ func (r *Router) setupTenantRoutes(api fiber.Router) {
 		tenantHandler.CancelTenant,
 	)
 
+	// Move tenant through its lifecycle with a reason - platform admin only
+	tenants.Post("/:id/status",
+		r.zitadelMW.RequireAnyPlatformRole(),
+		tenantHandler.ChangeTenantStatus,
+	)
+
 	// ============================================================================
 	// Tenant Settings & Configuration
 	// ============================================================================
@@ -178,6 +186,12 @@ func (r *Router) setupTenantRoutes(api fiber.Router) {
 		tenantHandler.GetTenantLimits,
 	)
 
+	// Get staff not complying with the MFA policy - tenant owner/admin only
+	tenants.Get("/:id/mfa-compliance",
+		middleware.RequireTenantOwnerOrAdmin(),
+		tenantHandler.GetMFACompliance,
+	)
+
 	// ============================================================================
 	// Trial Management
 	// ============================================================================
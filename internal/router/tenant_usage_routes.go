@@ -25,15 +25,6 @@ func (r *Router) setupTenantUsageRoutes(api fiber.Router) {
 	// Create tenants usage group
 	tenantsUsage := api.Group("/tenants/:tenant_id/usage")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		tenantsUsage.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Daily usage (authenticated, requires usage:read scope)
 	tenantsUsage.Get("/daily",
 		r.RequireAuth(),
@@ -95,15 +86,6 @@ func (r *Router) setupTenantUsageRoutes(api fiber.Router) {
 	// Create usage admin group
 	usage := api.Group("/usage")
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger = zap.NewNop()
-		}
-		usage.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
-
 	// Delete old usage records (platform admin only)
 	usage.Delete("/cleanup",
 		r.RequireAuth(),
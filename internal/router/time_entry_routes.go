@@ -0,0 +1,40 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupTimeEntryRoutes sets up the current user's timers and time entries. Project
+// hours and billable hours live under /projects (see setupProjectRoutes).
+func (r *Router) setupTimeEntryRoutes(api fiber.Router) {
+	// Initialize service and handler
+	timeTrackingService := service.NewTimeTrackingService(r.repos, r.config.Logger)
+	timeEntryHandler := handler.NewTimeEntryHandler(timeTrackingService)
+
+	// Create time entries group
+	entries := api.Group("/time-entries")
+
+	// Auth middleware configuration
+	entries.Use(r.RequireAuth())
+
+	// ============================================================================
+	// Timers
+	// ============================================================================
+
+	entries.Get("/timer", timeEntryHandler.GetRunningTimer)
+	entries.Post("/timer/start", timeEntryHandler.StartTimer)
+	entries.Post("/timer/stop", timeEntryHandler.StopTimer)
+
+	// ============================================================================
+	// Entries - the person who logged an entry edits it
+	// ============================================================================
+
+	entries.Post("", timeEntryHandler.CreateTimeEntry)
+	entries.Get("", timeEntryHandler.ListMyTimeEntries)
+	entries.Get("/:id", timeEntryHandler.GetTimeEntry)
+	entries.Put("/:id", timeEntryHandler.UpdateTimeEntry)
+	entries.Delete("/:id", timeEntryHandler.DeleteTimeEntry)
+}
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupTrashRoutes sets up the routes of deleted bookings, services and projects
+func (r *Router) setupTrashRoutes(api fiber.Router) {
+	// Initialize service and handler
+	trashService := service.NewTrashService(r.repos, r.config.Logger, r.config.TrashRetention)
+	trashHandler := handler.NewTrashHandler(trashService)
+
+	// Create trash group - tenant owner/admin only
+	trash := api.Group("/trash")
+	trash.Use(r.RequireAuth())
+	trash.Use(middleware.RequireTenantOwnerOrAdmin())
+
+	trash.Get("/:kind", trashHandler.ListDeleted)
+	trash.Post("/:kind/:id/restore", trashHandler.Restore)
+}
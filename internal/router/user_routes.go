@@ -13,25 +13,29 @@ import (
 // This function is called by the main router setup
 func (r *Router) setupUserRoutes(api fiber.Router) {
 	// Initialize user service with repositories and logger
-	userService := service.NewUserService(r.repos, r.config.Logger)
+	userService := service.NewUserService(r.repos, r.config.Logger, r.revoker)
 
 	// Initialize user handler
 	userHandler := handler.NewUserHandler(userService)
 
-	// Create users group
-	users := api.Group("/users")
+	// Personal data export and erasure (GDPR)
+	zapLogger := r.config.ZapLogger
+	if zapLogger == nil {
+		zapLogger = zap.NewNop()
+	}
+	exportService := service.NewDataExportService(r.repos, zapLogger, service.DataExportServiceConfig{
+		Store:         r.config.Storage,
+		SigningSecret: r.config.DownloadURLSecret,
+	})
+	erasureService := service.NewUserErasureService(r.repos, r.config.Logger, r.revoker, r.config.Identity)
+	userDataHandler := handler.NewUserDataHandler(exportService, erasureService)
 
-	// Apply rate limiting if cache is available
-	if r.config.Cache != nil {
-		// Use provided zap logger or create a default one
-		zapLogger := r.config.ZapLogger
-		if zapLogger == nil {
-			zapLogger, _ = zap.NewProduction()
-		}
+	// Communication preferences
+	preferenceService := service.NewCommunicationPreferenceService(r.repos, r.config.Logger)
+	preferenceHandler := handler.NewCommunicationPreferenceHandler(preferenceService)
 
-		// Apply global rate limit to all user routes
-		users.Use(middleware.RateLimitWithHeaders(middleware.DefaultRateLimitConfig(r.config.Cache, zapLogger)))
-	}
+	// Create users group
+	users := api.Group("/users")
 
 	// Public routes (no authentication required)
 	users.Post("/password-reset", userHandler.ResetPassword)
@@ -66,6 +70,7 @@ func (r *Router) setupUserRoutes(api fiber.Router) {
 	users.Post("/:id/verify-email", middleware.RequireTenantOwnerOrAdmin(), userHandler.VerifyEmail)
 	users.Post("/:id/verify-phone", middleware.RequireTenantOwnerOrAdmin(), userHandler.VerifyPhone)
 	users.Post("/:id/unlock", middleware.RequireTenantOwnerOrAdmin(), userHandler.UnlockUser)
+	users.Post("/:id/force-logout", middleware.RequireTenantOwnerOrAdmin(), userHandler.RevokeSessions)
 
 	// MFA Management - self or admin
 	users.Post("/:id/mfa/setup", middleware.RequireSelfOrAdmin(), userHandler.SetupMFA)
@@ -84,13 +89,20 @@ func (r *Router) setupUserRoutes(api fiber.Router) {
 	users.Put("/:id/profile", middleware.RequireSelfOrAdmin(), userHandler.UpdateProfile)
 	users.Put("/:id/avatar", middleware.RequireSelfOrAdmin(), userHandler.UpdateAvatar)
 	users.Put("/:id/preferences", middleware.RequireSelfOrAdmin(), userHandler.UpdatePreferences)
+	users.Get("/:id/communication-preferences", middleware.RequireSelfOrAdmin(), preferenceHandler.GetPreferences)
+	users.Put("/:id/communication-preferences", middleware.RequireSelfOrAdmin(), preferenceHandler.UpdatePreferences)
 
-	// Compliance & GDPR - self for acceptance, admin for deletion
+	// Compliance & GDPR - self for acceptance, export and erasure, admin for deletion
 	users.Post("/:id/accept-terms", middleware.RequireSelfOrAdmin(), userHandler.AcceptTerms)
 	users.Post("/:id/accept-privacy", middleware.RequireSelfOrAdmin(), userHandler.AcceptPrivacyPolicy)
 	users.Put("/:id/consent", middleware.RequireSelfOrAdmin(), userHandler.UpdateConsent)
 	users.Post("/:id/mark-for-deletion", middleware.RequireTenantOwnerOrAdmin(), userHandler.MarkForDeletion)
 	users.Delete("/:id/permanent", middleware.RequireTenantOwnerOrAdmin(), userHandler.PermanentlyDeleteUser)
+	users.Post("/:id/data-export", middleware.RequireSelfOrAdmin(), userDataHandler.RequestDataExport)
+	users.Get("/:id/data-exports", middleware.RequireSelfOrAdmin(), userDataHandler.ListDataExports)
+	users.Get("/:id/erasure", middleware.RequireSelfOrAdmin(), userDataHandler.GetErasure)
+	users.Post("/:id/erasure", middleware.RequireSelfOrAdmin(), userDataHandler.RequestErasure)
+	users.Delete("/:id/erasure", middleware.RequireSelfOrAdmin(), userDataHandler.CancelErasure)
 
 	r.config.Logger.Info("user routes registered successfully with authentication")
 }
@@ -122,6 +134,7 @@ func UserRoutesInfo() map[string][]string {
 			"POST /api/v1/users/:id/verify-email",
 			"POST /api/v1/users/:id/verify-phone",
 			"POST /api/v1/users/:id/unlock",
+			"POST /api/v1/users/:id/force-logout",
 		},
 		"MFA Management": {
 			"POST /api/v1/users/:id/mfa/setup",
@@ -140,6 +153,8 @@ func UserRoutesInfo() map[string][]string {
 			"PUT /api/v1/users/:id/profile",
 			"PUT /api/v1/users/:id/avatar",
 			"PUT /api/v1/users/:id/preferences",
+			"GET /api/v1/users/:id/communication-preferences",
+			"PUT /api/v1/users/:id/communication-preferences",
 		},
 		"Compliance & GDPR": {
 			"POST   /api/v1/users/:id/accept-terms",
@@ -147,6 +162,11 @@ func UserRoutesInfo() map[string][]string {
 			"PUT    /api/v1/users/:id/consent",
 			"POST   /api/v1/users/:id/mark-for-deletion",
 			"DELETE /api/v1/users/:id/permanent",
+			"POST   /api/v1/users/:id/data-export",
+			"GET    /api/v1/users/:id/data-exports",
+			"GET    /api/v1/users/:id/erasure",
+			"POST   /api/v1/users/:id/erasure",
+			"DELETE /api/v1/users/:id/erasure",
 		},
 		"Analytics": {
 			"GET /api/v1/users/stats",
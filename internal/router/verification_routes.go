@@ -0,0 +1,46 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupVerificationRoutes sets up the artisan verification document review routes;
+// uploads live under /artisans/:id/verification
+func (r *Router) setupVerificationRoutes(api fiber.Router) {
+	// Initialize service and handler
+	verificationService := service.NewVerificationService(r.repos, r.config.Logger, r.config.Storage)
+	verificationHandler := handler.NewVerificationHandler(verificationService)
+
+	// Create verification documents group
+	documents := api.Group("/verification-documents")
+
+	// Auth middleware configuration
+	documents.Use(r.RequireAuth())
+
+	// Download document - the artisan (self) or tenant owner/admin
+	documents.Get("/:id/file",
+		middleware.RequireTenantStaff(),
+		verificationHandler.DownloadDocument,
+	)
+
+	// ============================================================================
+	// Review Queue - tenant owner/admin only
+	// ============================================================================
+
+	documents.Get("",
+		middleware.RequireTenantOwnerOrAdmin(),
+		verificationHandler.ListDocuments,
+	)
+	documents.Post("/:id/approve",
+		middleware.RequireTenantOwnerOrAdmin(),
+		verificationHandler.ApproveDocument,
+	)
+	documents.Post("/:id/reject",
+		middleware.RequireTenantOwnerOrAdmin(),
+		verificationHandler.RejectDocument,
+	)
+}
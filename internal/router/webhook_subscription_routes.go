@@ -0,0 +1,44 @@
+package router
+
+import (
+	"Krafti_Vibe/internal/handler"
+	"Krafti_Vibe/internal/middleware"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupWebhookSubscriptionRoutes sets up the routes tenants manage their webhook
+// endpoints and inspect their deliveries with
+func (r *Router) setupWebhookSubscriptionRoutes(api fiber.Router) {
+	// Initialize services and handler
+	webhookService := service.NewWebhookRepository(r.repos, r.config.Logger)
+	subscriptionService := service.NewWebhookSubscriptionService(r.repos, r.config.Logger, webhookService)
+	subscriptionHandler := handler.NewWebhookSubscriptionHandler(subscriptionService)
+
+	// Create webhook subscriptions group
+	subscriptions := api.Group("/webhook-subscriptions")
+
+	// Auth middleware configuration
+	subscriptions.Use(r.RequireAuth())
+	subscriptions.Use(middleware.RequireTenantOwnerOrAdmin())
+
+	// ============================================================================
+	// Core CRUD Operations
+	// ============================================================================
+
+	subscriptions.Post("", subscriptionHandler.CreateSubscription)
+	subscriptions.Get("", subscriptionHandler.ListSubscriptions)
+	subscriptions.Get("/:id", subscriptionHandler.GetSubscription)
+	subscriptions.Put("/:id", subscriptionHandler.UpdateSubscription)
+	subscriptions.Delete("/:id", subscriptionHandler.DeleteSubscription)
+	subscriptions.Post("/:id/rotate-secret", subscriptionHandler.RotateSecret)
+
+	// ============================================================================
+	// Deliveries
+	// ============================================================================
+
+	subscriptions.Get("/:id/deliveries", subscriptionHandler.ListDeliveries)
+	subscriptions.Get("/:id/deliveries/:deliveryId", subscriptionHandler.GetDelivery)
+	subscriptions.Post("/:id/deliveries/:deliveryId/redeliver", subscriptionHandler.Redeliver)
+}
@@ -36,6 +36,16 @@ func (r *Router) setupWebSocketRoutes(api fiber.Router, wsHandler *ws.Handler) {
 		}),
 	)
 
+	// ============================================================================
+	// Server-Sent Events Stream
+	// ============================================================================
+
+	// Realtime event stream for clients that cannot use WebSockets (authenticated)
+	api.Get("/stream",
+		r.RequireAuth(),
+		wsHandler.Stream,
+	)
+
 	// ============================================================================
 	// WebSocket Management API (HTTP endpoints)
 	// ============================================================================
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// rollupRefreshOverlap is how far before the last refresh changes are looked for again.
+// A write is stamped when it is made but only visible once committed, so a transaction
+// that commits during a refresh would otherwise fall between two runs.
+const rollupRefreshOverlap = time.Minute
+
+// AnalyticsRollupService keeps the daily analytics rollups that dashboards read up to
+// date with bookings and payments
+type AnalyticsRollupService interface {
+	// RefreshChanged recomputes the days whose bookings or payments changed since the
+	// rollups were last refreshed, returning how many tenant days it recomputed
+	RefreshChanged(ctx context.Context) (int, error)
+
+	// RebuildDue recomputes every rollup of the tenants not rebuilt yet today (UTC),
+	// returning how many tenants it rebuilt
+	RebuildDue(ctx context.Context) (int, error)
+
+	// RefreshTenantDays recomputes a tenant's rollups of the UTC days of the given
+	// times, when the tenant's rollups are built
+	RefreshTenantDays(ctx context.Context, tenantID uuid.UUID, times ...time.Time) error
+}
+
+// analyticsRollupService implements AnalyticsRollupService
+type analyticsRollupService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewAnalyticsRollupService creates a new AnalyticsRollupService instance
+func NewAnalyticsRollupService(repos *repository.Repositories, logger log.AllLogger) AnalyticsRollupService {
+	return &analyticsRollupService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// RefreshChanged recomputes changed days of tenants whose rollups are built. Tenants
+// without rollups are left to RebuildDue, which builds them whole. The refresh is
+// recorded only once every tenant's changes are in, so a failed run is repeated.
+func (s *analyticsRollupService) RefreshChanged(ctx context.Context) (int, error) {
+	states, err := s.repos.AnalyticsRollup.ListStates(ctx)
+	if err != nil {
+		return 0, errors.NewServiceError("QUERY_FAILED", "failed to list analytics rollup states", err)
+	}
+	if len(states) == 0 {
+		return 0, nil
+	}
+
+	started := time.Now()
+	since := states[0].RefreshedAt // The states come least recently refreshed first
+	built := make(map[uuid.UUID]bool, len(states))
+	for _, state := range states {
+		built[state.TenantID] = true
+	}
+
+	changed, err := s.repos.AnalyticsRollup.FindChangedDays(ctx, since.Add(-rollupRefreshOverlap))
+	if err != nil {
+		return 0, errors.NewServiceError("QUERY_FAILED", "failed to find changed analytics days", err)
+	}
+
+	refreshed, tenants := 0, 0
+	for tenantID, days := range changed {
+		if !built[tenantID] {
+			continue
+		}
+		if err := s.repos.AnalyticsRollup.RefreshDays(ctx, tenantID, days, started); err != nil {
+			return refreshed, errors.NewServiceError("REFRESH_FAILED", "failed to refresh analytics rollups", err)
+		}
+		refreshed += len(days)
+		tenants++
+	}
+
+	if err := s.repos.AnalyticsRollup.MarkRefreshed(ctx, started); err != nil {
+		return refreshed, errors.NewServiceError("UPDATE_FAILED", "failed to record analytics refresh", err)
+	}
+
+	if refreshed > 0 {
+		s.logger.Info("analytics rollups refreshed", "days", refreshed, "tenants", tenants)
+	}
+	return refreshed, nil
+}
+
+// RebuildDue rebuilds tenants one at a time, so a tenant that fails is retried on the
+// next run without holding back the others
+func (s *analyticsRollupService) RebuildDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	tenantIDs, err := s.repos.AnalyticsRollup.FindTenantsToRebuild(ctx, today)
+	if err != nil {
+		return 0, errors.NewServiceError("QUERY_FAILED", "failed to find tenants to rebuild analytics for", err)
+	}
+
+	rebuilt := 0
+	for _, tenantID := range tenantIDs {
+		if err := ctx.Err(); err != nil {
+			return rebuilt, err
+		}
+		if err := s.repos.AnalyticsRollup.Rebuild(ctx, tenantID, time.Now()); err != nil {
+			s.logger.Error("failed to rebuild analytics rollups", "tenant_id", tenantID, "error", err)
+			continue
+		}
+		rebuilt++
+	}
+
+	if len(tenantIDs) > 0 {
+		s.logger.Info("analytics rollups rebuilt", "tenants", rebuilt, "failed", len(tenantIDs)-rebuilt)
+	}
+	return rebuilt, nil
+}
+
+// RefreshTenantDays refreshes the days right away, for consumers of booking and payment
+// events; RefreshChanged still catches up with whatever they miss. Tenants without
+// rollups are left to RebuildDue.
+func (s *analyticsRollupService) RefreshTenantDays(ctx context.Context, tenantID uuid.UUID, times ...time.Time) error {
+	state, err := s.repos.AnalyticsRollup.GetState(ctx, tenantID)
+	if err != nil {
+		return errors.NewServiceError("QUERY_FAILED", "failed to get analytics rollup state", err)
+	}
+	if state == nil {
+		return nil
+	}
+
+	days := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		if t.IsZero() {
+			continue
+		}
+		t = t.UTC()
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		if !slices.ContainsFunc(days, day.Equal) {
+			days = append(days, day)
+		}
+	}
+
+	if err := s.repos.AnalyticsRollup.RefreshDays(ctx, tenantID, days, time.Now()); err != nil {
+		return errors.NewServiceError("REFRESH_FAILED", "failed to refresh analytics rollups", err)
+	}
+	return nil
+}
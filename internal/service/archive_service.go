@@ -0,0 +1,250 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/storage"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// partitionMonthsAhead is how many months past the current one get their partitions
+// created in advance, so a missed maintenance run never sends rows to the default
+// partition
+const partitionMonthsAhead = 3
+
+// ArchiveService keeps the monthly partitions of bookings and payments created ahead
+// of time and moves months past their retention to cold storage
+type ArchiveService interface {
+	// MaintainPartitions creates the partitions of the current and coming months of
+	// every partitioned table and warns about rows outside every month
+	MaintainPartitions(ctx context.Context) error
+
+	// ArchiveExpired exports every month older than its table's retention to the object
+	// store, records it and drops its partition, returning how many months were archived
+	ArchiveExpired(ctx context.Context) (int, error)
+}
+
+// archiveService implements ArchiveService
+type archiveService struct {
+	repos     *repository.Repositories
+	logger    log.AllLogger
+	store     storage.ObjectStore
+	retention map[string]int
+}
+
+// NewArchiveService creates a new ArchiveService instance. Retention is the number of
+// whole months, besides the current one, each table keeps; tables missing from it or
+// kept for zero months are never archived, and without a store nothing is.
+func NewArchiveService(repos *repository.Repositories, logger log.AllLogger, store storage.ObjectStore, retention map[string]int) ArchiveService {
+	return &archiveService{
+		repos:     repos,
+		logger:    logger,
+		store:     store,
+		retention: retention,
+	}
+}
+
+// MaintainPartitions ensures the partitions table by table, carrying on past a table
+// that fails
+func (s *archiveService) MaintainPartitions(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	var errs []error
+	for _, table := range repository.ArchivedTables {
+		partitioned, err := s.repos.Archive.IsPartitioned(ctx, table)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !partitioned {
+			continue
+		}
+
+		if err := s.repos.Archive.EnsurePartitions(ctx, table, now, now.AddDate(0, partitionMonthsAhead, 0)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		stray, err := s.repos.Archive.CountDefaultRows(ctx, table)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if stray > 0 {
+			s.logger.Warn("rows outside every monthly partition; they are never archived", "table", table, "rows", stray)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.NewServiceError("PARTITION_MAINTENANCE_FAILED", "failed to maintain partitions", stdErrors.Join(errs...))
+	}
+	return nil
+}
+
+// ArchiveExpired archives table by table, oldest month first, and stops at the first
+// month that fails so months are always archived in order
+func (s *archiveService) ArchiveExpired(ctx context.Context) (int, error) {
+	if s.store == nil {
+		return 0, nil
+	}
+
+	archived := 0
+	for _, table := range repository.ArchivedTables {
+		months := s.retention[table]
+		if months <= 0 {
+			continue
+		}
+
+		partitioned, err := s.repos.Archive.IsPartitioned(ctx, table)
+		if err != nil {
+			return archived, errors.NewServiceError("ARCHIVE_FAILED", "failed to archive "+table, err)
+		}
+		if !partitioned {
+			continue
+		}
+
+		partitions, err := s.repos.Archive.ListPartitions(ctx, table)
+		if err != nil {
+			return archived, errors.NewServiceError("ARCHIVE_FAILED", "failed to archive "+table, err)
+		}
+
+		cutoff := archiveCutoff(time.Now(), months)
+		for _, partition := range partitions {
+			if partition.End.After(cutoff) {
+				break
+			}
+			if err := s.archivePartition(ctx, table, partition); err != nil {
+				s.logger.Error("failed to archive partition", "table", table, "partition", partition.Name, "error", err)
+				return archived, errors.NewServiceError("ARCHIVE_FAILED", "failed to archive "+partition.Name, err)
+			}
+			archived++
+		}
+	}
+
+	if archived > 0 {
+		s.logger.Info("archived months of bookings and payments", "months", archived)
+	}
+	return archived, nil
+}
+
+// archivePartition exports a month unless an earlier run already did, checks the
+// export reads back whole, records it and then drops the partition. The partition is
+// only dropped once the export is recorded, so a failure at any step is retried on
+// the next run.
+func (s *archiveService) archivePartition(ctx context.Context, table string, partition repository.Partition) error {
+	archive, err := s.repos.Archive.FindArchive(ctx, table, partition.Start)
+	if err != nil {
+		return err
+	}
+
+	if archive == nil {
+		key := models.DataArchiveKey(table, partition.Start)
+		rows, size, err := s.export(ctx, partition.Name, key)
+		if err != nil {
+			return err
+		}
+		if err := s.verify(ctx, key, rows); err != nil {
+			return err
+		}
+
+		archive = &models.DataArchive{
+			SourceTable: table,
+			PeriodStart: partition.Start,
+			PeriodEnd:   partition.End,
+			ObjectKey:   key,
+			RowCount:    rows,
+			SizeBytes:   size,
+			ArchivedAt:  time.Now(),
+		}
+		if err := s.repos.Archive.CreateArchive(ctx, archive); err != nil {
+			return err
+		}
+		s.logger.Info("exported partition to archive", "table", table, "partition", partition.Name, "key", key, "rows", rows, "bytes", size)
+	}
+
+	return s.repos.Archive.DropPartition(ctx, table, partition.Name)
+}
+
+// export streams the partition's rows into the store as gzipped JSON lines, returning
+// how many rows it wrote and the size of the stored object
+func (s *archiveService) export(ctx context.Context, partition, key string) (int64, int64, error) {
+	reader, writer := io.Pipe()
+
+	var rows int64
+	streamed := make(chan error, 1)
+	go func() {
+		gz := gzip.NewWriter(writer)
+		count, err := s.repos.Archive.StreamPartition(ctx, partition, func(row []byte) error {
+			if _, err := gz.Write(row); err != nil {
+				return err
+			}
+			_, err := gz.Write([]byte{'\n'})
+			return err
+		})
+		rows = count
+		if err == nil {
+			err = gz.Close()
+		}
+		writer.CloseWithError(err)
+		streamed <- err
+	}()
+
+	size, err := s.store.Put(ctx, key, "application/gzip", reader)
+	// Unblocks the writer when the store gave up before reading everything
+	reader.CloseWithError(err)
+	if streamErr := <-streamed; streamErr != nil {
+		return 0, 0, fmt.Errorf("failed to read %s: %w", partition, streamErr)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return rows, size, nil
+}
+
+// verify reads the stored export back and checks it holds the rows written
+func (s *archiveService) verify(ctx context.Context, key string, rows int64) error {
+	content, err := s.store.Open(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", key, err)
+	}
+	defer content.Close()
+
+	gz, err := gzip.NewReader(content)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	var lines int64
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) > 0 {
+			lines++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	if lines != rows {
+		return fmt.Errorf("%s holds %d rows, expected %d", key, lines, rows)
+	}
+	return nil
+}
+
+// archiveCutoff returns the start of the oldest month kept when the current month and
+// the given number of whole months before it are kept
+func archiveCutoff(now time.Time, months int) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -months, 0)
+}
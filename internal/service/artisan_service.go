@@ -27,11 +27,14 @@ type ArtisanService interface {
 	GetAvailableArtisans(ctx context.Context, tenantID uuid.UUID, page, pageSize int) (*dto.ArtisanListResponse, error)
 	GetArtisansBySpecialization(ctx context.Context, tenantID uuid.UUID, specialization string, page, pageSize int) (*dto.ArtisanListResponse, error)
 	GetTopRatedArtisans(ctx context.Context, tenantID uuid.UUID, limit int) ([]*dto.ArtisanResponse, error)
-	FindNearbyArtisans(ctx context.Context, tenantID uuid.UUID, latitude, longitude float64, radiusKm int) ([]*dto.ArtisanResponse, error)
+	FindNearbyArtisans(ctx context.Context, req *dto.NearbyArtisansRequest) ([]*dto.NearbyArtisanResponse, error)
 
 	// Availability Management
 	UpdateAvailability(ctx context.Context, artisanID uuid.UUID, available bool, note string) error
 
+	// Tier Management
+	UpdateTier(ctx context.Context, artisanID uuid.UUID, tier models.ArtisanTier) (*dto.ArtisanResponse, error)
+
 	// Rating Management
 	UpdateRating(ctx context.Context, artisanID uuid.UUID, newRating float64, reviewCount int) error
 
@@ -80,6 +83,7 @@ func (s *artisanService) CreateArtisan(ctx context.Context, req *dto.CreateArtis
 		YearsExperience:    req.YearsExperience,
 		Certifications:     req.Certifications,
 		CommissionRate:     req.CommissionRate,
+		Tier:               req.Tier,
 		AutoAcceptBookings: req.AutoAcceptBookings,
 		BookingLeadTime:    req.BookingLeadTime,
 		MaxAdvanceBooking:  req.MaxAdvanceBooking,
@@ -321,14 +325,36 @@ func (s *artisanService) GetTopRatedArtisans(ctx context.Context, tenantID uuid.
 	return dto.ToArtisanResponses(artisans), nil
 }
 
-// FindNearbyArtisans finds nearby artisans
-func (s *artisanService) FindNearbyArtisans(ctx context.Context, tenantID uuid.UUID, latitude, longitude float64, radiusKm int) ([]*dto.ArtisanResponse, error) {
-	artisans, err := s.repos.Artisan.FindNearby(ctx, tenantID, latitude, longitude, radiusKm)
+// FindNearbyArtisans finds the tenant's artisans around a point, the nearest first
+func (s *artisanService) FindNearbyArtisans(ctx context.Context, req *dto.NearbyArtisansRequest) ([]*dto.NearbyArtisanResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	query := repository.NearbyArtisanQuery{
+		Latitude:      req.Latitude,
+		Longitude:     req.Longitude,
+		RadiusKm:      req.RadiusKm,
+		AvailableOnly: req.AvailableOnly,
+		Limit:         req.Limit,
+	}
+	if b := req.Bounds; b != nil {
+		query.Bounds = &repository.GeoBounds{South: b.South, West: b.West, North: b.North, East: b.East}
+	}
+
+	nearby, err := s.repos.Artisan.FindNearby(ctx, req.TenantID, query)
 	if err != nil {
 		return nil, errors.NewServiceError("ARTISAN_NEARBY_FAILED", "failed to find nearby artisans", err)
 	}
 
-	return dto.ToArtisanResponses(artisans), nil
+	responses := make([]*dto.NearbyArtisanResponse, len(nearby))
+	for i, n := range nearby {
+		responses[i] = &dto.NearbyArtisanResponse{
+			ArtisanResponse: dto.ToArtisanResponse(n.Artisan),
+			DistanceKm:      n.DistanceKm,
+		}
+	}
+	return responses, nil
 }
 
 // UpdateAvailability updates artisan availability
@@ -344,6 +370,30 @@ func (s *artisanService) UpdateAvailability(ctx context.Context, artisanID uuid.
 	return nil
 }
 
+// UpdateTier moves an artisan to another tier; payments from then on use the tenant's
+// commission rules for that tier
+func (s *artisanService) UpdateTier(ctx context.Context, artisanID uuid.UUID, tier models.ArtisanTier) (*dto.ArtisanResponse, error) {
+	if !tier.IsValid() {
+		return nil, errors.NewValidationError("invalid artisan tier")
+	}
+
+	artisan, err := s.repos.Artisan.GetByID(ctx, artisanID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("artisan not found")
+		}
+		return nil, errors.NewServiceError("ARTISAN_GET_FAILED", "failed to get artisan", err)
+	}
+
+	artisan.Tier = tier
+	if err := s.repos.Artisan.Update(ctx, artisan); err != nil {
+		return nil, errors.NewServiceError("ARTISAN_UPDATE_FAILED", "failed to update artisan tier", err)
+	}
+
+	s.logger.Info("artisan tier updated", "artisan_id", artisanID, "tier", tier)
+	return dto.ToArtisanResponse(artisan), nil
+}
+
 // UpdateRating updates artisan rating
 func (s *artisanService) UpdateRating(ctx context.Context, artisanID uuid.UUID, newRating float64, reviewCount int) error {
 	if err := s.repos.Artisan.UpdateRating(ctx, artisanID, newRating, reviewCount); err != nil {
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/audit"
+	"Krafti_Vibe/internal/pkg/redact"
+	"Krafti_Vibe/internal/repository"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// auditSkippedTables are bookkeeping tables whose writes follow from changes already
+// audited, or are logs themselves
+var auditSkippedTables = map[string]bool{
+	"audit_logs":                true,
+	"outbox_events":             true,
+	"idempotency_keys":          true,
+	"analytics_events":          true,
+	"analytics_daily_rollups":   true,
+	"analytics_rollup_states":   true,
+	"tenant_usage_trackings":    true,
+	"webhook_events":            true,
+	"webhook_delivery_attempts": true,
+	"payment_webhook_events":    true,
+}
+
+// auditIgnoredFields change on every write, or on every authenticated request in the
+// case of last_login_at, so they are left out of update diffs
+var auditIgnoredFields = []string{"updated_at", "version", "last_login_at"}
+
+// auditTrail implements repository.AuditLogger
+type auditTrail struct {
+	logs   repository.AuditLogRepository
+	logger log.AllLogger
+}
+
+// NewAuditTrail creates the hook recording changes made by API requests in the
+// append-only audit log. Repositories configured with it report every entity services
+// create, update or delete while serving a mutating request; the entry names the
+// acting user and tenant, the fields changed with their old and new values, and the
+// request's IP address and ID. Changes outside requests, such as background jobs, are
+// not recorded.
+//
+// Entries are written outside the transaction of the change, and failures to write
+// them are logged without failing the change.
+func NewAuditTrail(logs repository.AuditLogRepository, logger log.AllLogger) repository.AuditLogger {
+	return &auditTrail{
+		logs:   logs,
+		logger: logger,
+	}
+}
+
+// LogCreate records the values an entity was created with
+func (a *auditTrail) LogCreate(ctx context.Context, entityType string, entityID uuid.UUID, entity any) {
+	values, _ := entity.(map[string]any)
+	a.record(ctx, models.AuditActionCreate, entityType, entityID, fmt.Sprintf("Created %s", entityType), nil, withoutEmpty(values), values)
+}
+
+// LogUpdate records the fields an update changed; updates changing nothing are skipped
+func (a *auditTrail) LogUpdate(ctx context.Context, entityType string, entityID uuid.UUID, oldValues, newValues map[string]any) {
+	before, after := audit.Diff(oldValues, newValues, auditIgnoredFields...)
+	if after == nil {
+		return
+	}
+	a.record(ctx, models.AuditActionUpdate, entityType, entityID, fmt.Sprintf("Updated %s", entityType), before, after, newValues)
+}
+
+// LogDelete records the last values of a deleted entity
+func (a *auditTrail) LogDelete(ctx context.Context, entityType string, entityID uuid.UUID, oldValues map[string]any) {
+	a.record(ctx, models.AuditActionDelete, entityType, entityID, fmt.Sprintf("Deleted %s", entityType), withoutEmpty(oldValues), nil, oldValues)
+}
+
+// LogAction records an action other than a write, such as an export. Its tenant is
+// taken from the tenant_id of metadata when set.
+func (a *auditTrail) LogAction(ctx context.Context, action models.AuditAction, entityType string, entityID uuid.UUID, description string, metadata map[string]any) {
+	a.recordEntry(ctx, &models.AuditLog{
+		Action:      action,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Description: description,
+		Metadata:    models.JSONB(redact.Map(metadata)),
+	}, metadata)
+}
+
+// record writes an entry for a change, taking its tenant from the entity's values
+func (a *auditTrail) record(ctx context.Context, action models.AuditAction, entityType string, entityID uuid.UUID, description string, oldValues, newValues, entity map[string]any) {
+	a.recordEntry(ctx, &models.AuditLog{
+		Action:      action,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Description: description,
+		OldValues:   auditValues(oldValues),
+		NewValues:   auditValues(newValues),
+	}, entity)
+}
+
+// recordEntry fills in the request and actor of an entry and writes it, when the
+// change is made in a mutating API request
+func (a *auditTrail) recordEntry(ctx context.Context, entry *models.AuditLog, entity map[string]any) {
+	if auditSkippedTables[entry.EntityType] || entry.EntityID == uuid.Nil {
+		return
+	}
+	request, ok := audit.FromContext(ctx)
+	if !ok {
+		return
+	}
+
+	entry.IPAddress = request.IPAddress
+	entry.UserAgent = clipAuditField(request.UserAgent, 500)
+	entry.RequestID = clipAuditField(request.ID, 100)
+	if entry.Metadata == nil {
+		entry.Metadata = models.JSONB{}
+	}
+	entry.Metadata["method"] = request.Method
+	entry.Metadata["path"] = request.Path
+
+	if actor := contextActor(ctx); actor != nil {
+		entry.UserID = &actor.ID
+		entry.UserEmail = actor.Email
+		entry.UserRole = actor.Role
+		entry.TenantID = actor.TenantID
+	}
+	if tenantID, ok := entityTenantID(entity); ok {
+		entry.TenantID = &tenantID
+	}
+
+	if err := a.logs.Create(ctx, entry); err != nil {
+		a.logger.Error("failed to write audit log", "entity_type", entry.EntityType, "entity_id", entry.EntityID, "action", entry.Action, "error", err)
+	}
+}
+
+// entityTenantID returns the tenant an entity belongs to, from its values
+func entityTenantID(values map[string]any) (uuid.UUID, bool) {
+	switch tenantID := values["tenant_id"].(type) {
+	case uuid.UUID:
+		return tenantID, tenantID != uuid.Nil
+	case *uuid.UUID:
+		if tenantID != nil && *tenantID != uuid.Nil {
+			return *tenantID, true
+		}
+	}
+	return uuid.Nil, false
+}
+
+// clipAuditField cuts client supplied values to the size of their column
+func clipAuditField(value string, size int) string {
+	if len(value) <= size {
+		return value
+	}
+	return strings.ToValidUTF8(value[:size], "")
+}
+
+// auditValues prepares changed values for storage, hiding payment and personal data
+func auditValues(values map[string]any) models.JSONB {
+	if len(values) == 0 {
+		return nil
+	}
+	return models.JSONB(redact.Map(values))
+}
+
+// withoutEmpty leaves out fields without a value, so created and deleted entities are
+// recorded by the values they held
+func withoutEmpty(values map[string]any) map[string]any {
+	result := make(map[string]any, len(values))
+	for field, value := range values {
+		if !audit.IsEmpty(value) {
+			result[field] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// Authorizer checks the user acting in a request may take an action on a resource
+type Authorizer interface {
+	// Can returns a forbidden error unless the context's actor may take the action on
+	// the resource. A context without an actor is the platform acting on its own, such
+	// as a background job, and is allowed.
+	Can(ctx context.Context, action authz.Action, resource authz.Resource) error
+}
+
+// authorizer implements Authorizer, granting the actions of the actor's custom roles
+// as stored in the tenant role repository
+type authorizer struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewAuthorizer creates a new Authorizer
+func NewAuthorizer(repos *repository.Repositories, logger log.AllLogger) Authorizer {
+	return &authorizer{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// Can implements Authorizer
+func (a *authorizer) Can(ctx context.Context, action authz.Action, resource authz.Resource) error {
+	actor, ok := authz.ActorFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	// Custom roles only grant actions in the actor's tenant
+	var grants []authz.Action
+	if actor.NeedsGrants() && actor.TenantID == resource.TenantID {
+		var err error
+		grants, err = actor.Grants(func() ([]authz.Action, error) {
+			return a.loadGrants(ctx, actor)
+		})
+		if err != nil {
+			a.logger.Error("failed to load role grants", "user_id", actor.UserID, "error", err)
+			return errors.NewServiceError("AUTHORIZATION_FAILED", "failed to check permissions", err)
+		}
+	}
+
+	if !authz.Can(actor, grants, action, resource) {
+		a.logger.Warn("action forbidden", "user_id", actor.UserID, "role", actor.Role, "action", action, "tenant_id", resource.TenantID)
+		return errors.NewForbiddenError("you don't have permission to " + string(action))
+	}
+	return nil
+}
+
+// loadGrants returns the actions granted by the custom roles assigned to the actor
+func (a *authorizer) loadGrants(ctx context.Context, actor *authz.Actor) ([]authz.Action, error) {
+	if a.repos == nil || a.repos.TenantRole == nil {
+		return nil, nil
+	}
+
+	roles, err := a.repos.TenantRole.FindRolesForUser(ctx, actor.TenantID, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []authz.Action
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			grants = append(grants, authz.Action(permission))
+		}
+	}
+	return grants, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/httpcache"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
 
@@ -36,15 +37,19 @@ type AvailabilityService interface {
 }
 
 type availabilityService struct {
-	repos  *repository.Repositories
-	logger log.AllLogger
+	repos     *repository.Repositories
+	responses httpcache.Invalidator
+	logger    log.AllLogger
+	favorites FavoriteService
 }
 
 // NewAvailabilityService creates a new availability service
-func NewAvailabilityService(repos *repository.Repositories, logger log.AllLogger) AvailabilityService {
+func NewAvailabilityService(repos *repository.Repositories, responses httpcache.Invalidator, logger log.AllLogger) AvailabilityService {
 	return &availabilityService{
-		repos:  repos,
-		logger: logger,
+		repos:     repos,
+		responses: responses,
+		logger:    logger,
+		favorites: NewFavoriteService(repos, logger),
 	}
 }
 
@@ -90,6 +95,8 @@ func (s *availabilityService) CreateAvailability(ctx context.Context, tenantID u
 		s.logger.Error("failed to create availability", "error", err)
 		return nil, errors.NewInternalError("failed to create availability", err)
 	}
+	s.responses.Invalidate(ctx, httpcache.TagAvailability)
+	s.notifyFavorites(ctx, req.ArtisanID, req.Type)
 
 	// Reload with relationships
 	created, err := s.repos.Availability.GetByID(ctx, availability.ID)
@@ -171,6 +178,7 @@ func (s *availabilityService) UpdateAvailability(ctx context.Context, id uuid.UU
 		s.logger.Error("failed to update availability", "error", err)
 		return nil, errors.NewInternalError("failed to update availability", err)
 	}
+	s.responses.Invalidate(ctx, httpcache.TagAvailability)
 
 	// Reload with relationships
 	updated, err := s.repos.Availability.GetByID(ctx, id)
@@ -199,6 +207,7 @@ func (s *availabilityService) DeleteAvailability(ctx context.Context, id uuid.UU
 		s.logger.Error("failed to delete availability", "error", err)
 		return errors.NewInternalError("failed to delete availability", err)
 	}
+	s.responses.Invalidate(ctx, httpcache.TagAvailability)
 
 	return nil
 }
@@ -350,6 +359,8 @@ func (s *availabilityService) BulkCreateAvailability(ctx context.Context, tenant
 		s.logger.Error("failed to bulk create availabilities", "error", err)
 		return nil, errors.NewInternalError("failed to create availabilities", err)
 	}
+	s.responses.Invalidate(ctx, httpcache.TagAvailability)
+	s.notifyFavorites(ctx, req.ArtisanID, req.Type)
 
 	return dto.ToAvailabilitySlotResponses(availabilities), nil
 }
@@ -359,6 +370,18 @@ func (s *availabilityService) DeleteByType(ctx context.Context, artisanID uuid.U
 		s.logger.Error("failed to delete availabilities by type", "error", err)
 		return errors.NewInternalError("failed to delete availabilities", err)
 	}
+	s.responses.Invalidate(ctx, httpcache.TagAvailability)
 
 	return nil
 }
+
+// notifyFavorites tells the customers following the artisan about newly opened regular
+// availability; breaks, time off and exceptions close time rather than open it
+func (s *availabilityService) notifyFavorites(ctx context.Context, artisanID uuid.UUID, availabilityType models.AvailabilityType) {
+	if availabilityType != models.AvailabilityTypeRegular {
+		return
+	}
+	if _, err := s.favorites.NotifyNewAvailability(ctx, artisanID); err != nil {
+		s.logger.Error("failed to notify favorites of new availability", "artisan_id", artisanID, "error", err)
+	}
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// BalanceCollectionService defines the interface for automatic balance collection
+type BalanceCollectionService interface {
+	// CollectDueBalances charges the saved payment method of every deposit booking whose
+	// balance is due. Failed and declined charges are dunned: retried on the tenant's
+	// schedule with escalating notices, and once retries run out the booking is flagged
+	// and, if the tenant's policy says so, cancelled.
+	CollectDueBalances(ctx context.Context) (*dto.BalanceCollectionRunResponse, error)
+}
+
+// balanceCollectionService implements BalanceCollectionService
+type balanceCollectionService struct {
+	repos         *repository.Repositories
+	logger        log.AllLogger
+	payments      PaymentService
+	notifications NotificationService
+	bookings      BookingService
+}
+
+// NewBalanceCollectionService creates a new BalanceCollectionService instance
+func NewBalanceCollectionService(repos *repository.Repositories, logger log.AllLogger, paymentService PaymentService, notificationService NotificationService, bookingService BookingService) BalanceCollectionService {
+	return &balanceCollectionService{
+		repos:         repos,
+		logger:        logger,
+		payments:      paymentService,
+		notifications: notificationService,
+		bookings:      bookingService,
+	}
+}
+
+// balanceCollectionBatchSize is the number of bookings charged per run
+const balanceCollectionBatchSize = 100
+
+// CollectDueBalances runs one pass of the balance collection worker
+func (s *balanceCollectionService) CollectDueBalances(ctx context.Context) (*dto.BalanceCollectionRunResponse, error) {
+	result := &dto.BalanceCollectionRunResponse{}
+
+	bookings, err := s.repos.Booking.GetBookingsWithBalanceDue(ctx, time.Now(), balanceCollectionBatchSize)
+	if err != nil {
+		return result, errors.NewServiceError("QUERY_FAILED", "failed to list bookings with balance due", err)
+	}
+	result.BookingsDue = len(bookings)
+
+	for _, booking := range bookings {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		// Another instance may have claimed the booking since it was listed
+		claimed, err := s.repos.Booking.ClaimBalanceCollection(ctx, booking.ID)
+		if err != nil {
+			s.logger.Error("failed to claim balance collection", "booking_id", booking.ID, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		amount := booking.BalanceDue()
+
+		// The provider declined a charge submitted on an earlier run; that attempt is
+		// already counted, so it only needs dunning
+		if booking.BalanceStatus == models.BalanceStatusDeclined {
+			s.handleFailure(ctx, booking, amount, booking.BalanceAttempts, booking.BalanceFailureReason, result)
+			continue
+		}
+
+		if err := s.chargeBalance(ctx, booking, amount); err != nil {
+			s.handleFailure(ctx, booking, amount, booking.BalanceAttempts+1, err.Error(), result)
+			continue
+		}
+
+		if err := s.repos.Booking.RecordBalanceAttempt(ctx, booking.ID, models.BalanceStatusCharged, nil, ""); err != nil {
+			s.logger.Error("failed to record balance charge", "booking_id", booking.ID, "error", err)
+		}
+		if amount > 0 {
+			if err := s.repos.Booking.UpdatePaymentStatus(ctx, booking.ID, models.PaymentStatusProcessing); err != nil {
+				s.logger.Error("failed to update booking payment status", "booking_id", booking.ID, "error", err)
+			}
+			result.BalancesCharged++
+			result.AmountCharged += amount
+		}
+	}
+
+	if result.BookingsDue > 0 {
+		s.logger.Info("balance collection completed",
+			"due", result.BookingsDue,
+			"charged", result.BalancesCharged,
+			"retrying", result.Retrying,
+			"flagged", result.Flagged,
+			"cancelled", result.Cancelled)
+	}
+
+	return result, nil
+}
+
+// chargeBalance charges the balance to the booking's saved payment method, falling back
+// to the customer's default one. Nothing is charged when the balance was already paid.
+func (s *balanceCollectionService) chargeBalance(ctx context.Context, booking *models.Booking, amount float64) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	paymentMethodID := booking.PaymentMethodID
+	if paymentMethodID == "" {
+		if customer, err := s.repos.Customer.GetByUserID(ctx, booking.CustomerID); err == nil {
+			paymentMethodID = customer.DefaultPaymentMethodID
+		}
+	}
+	if paymentMethodID == "" {
+		return errors.NewValidationError("no saved payment method")
+	}
+
+	tenant, err := s.repos.Tenant.GetByID(ctx, booking.TenantID)
+	if err != nil {
+		return err
+	}
+
+	artisanID := booking.ArtisanID
+	_, err = s.payments.CreatePayment(ctx, &dto.CreatePaymentRequest{
+		TenantID:       booking.TenantID,
+		BookingID:      booking.ID,
+		CustomerID:     booking.CustomerID,
+		ArtisanID:      &artisanID,
+		Amount:         amount,
+		Currency:       booking.Currency,
+		Method:         models.PaymentMethodCard, // Saved payment methods are cards
+		Type:           models.PaymentTypeBalance,
+		CommissionRate: tenant.Settings.PlatformCommissionRate,
+		Metadata: models.JSONB{
+			"reason":            "scheduled_balance",
+			"payment_method_id": paymentMethodID,
+			"deposit_paid":      booking.DepositPaid,
+			"attempt":           booking.BalanceAttempts + 1,
+		},
+	})
+	return err
+}
+
+// handleFailure dunns a failed balance charge. While the tenant's retry schedule has
+// retries left the next one is scheduled; otherwise the booking's payment is flagged as
+// failed and, under a tenant policy to cancel, an upcoming booking is cancelled. The
+// customer is told at every stage. failedAttempts includes this failure.
+func (s *balanceCollectionService) handleFailure(ctx context.Context, booking *models.Booking, amount float64, failedAttempts int, reason string, result *dto.BalanceCollectionRunResponse) {
+	s.logger.Warn("balance charge failed", "booking_id", booking.ID, "attempt", failedAttempts, "reason", reason)
+
+	// A charge declined after submission was counted when it was submitted
+	recordOutcome := s.repos.Booking.RecordBalanceAttempt
+	if booking.BalanceStatus == models.BalanceStatusDeclined {
+		recordOutcome = s.repos.Booking.UpdateBalanceStatus
+	}
+
+	var settings models.TenantSettings
+	if tenant, err := s.repos.Tenant.GetByID(ctx, booking.TenantID); err == nil {
+		settings = tenant.Settings
+	} else {
+		s.logger.Warn("failed to load tenant dunning policy; using defaults", "tenant_id", booking.TenantID, "error", err)
+	}
+
+	var nextAttempt *time.Time
+	next, stage, retrying := models.NextBalanceRetry(settings.BalanceRetryDays, failedAttempts, time.Now())
+	if retrying {
+		nextAttempt = &next
+		if err := recordOutcome(ctx, booking.ID, models.BalanceStatusScheduled, nextAttempt, reason); err != nil {
+			s.logger.Error("failed to schedule balance retry", "booking_id", booking.ID, "error", err)
+		}
+		result.Retrying++
+	} else {
+		if err := recordOutcome(ctx, booking.ID, models.BalanceStatusFailed, nil, reason); err != nil {
+			s.logger.Error("failed to flag balance failure", "booking_id", booking.ID, "error", err)
+		}
+		if err := s.repos.Booking.UpdatePaymentStatus(ctx, booking.ID, models.PaymentStatusFailed); err != nil {
+			s.logger.Error("failed to update booking payment status", "booking_id", booking.ID, "error", err)
+		}
+		result.Flagged++
+
+		if settings.CancelOnBalanceFailure && booking.CanBeCancelled() && s.cancelUnpaid(ctx, booking) {
+			stage = models.DunningStageCancelled
+			result.Cancelled++
+		}
+	}
+
+	if _, err := s.notifications.SendBalancePaymentFailedNotification(ctx, booking, amount, stage, nextAttempt); err != nil {
+		s.logger.Error("failed to send balance failure notification", "booking_id", booking.ID, "error", err)
+	}
+}
+
+// cancelUnpaid cancels a booking whose balance could not be collected, on the tenant's
+// behalf. The deposit is kept, and the dunning notice tells the customer, so the
+// cancellation itself is silent.
+func (s *balanceCollectionService) cancelUnpaid(ctx context.Context, booking *models.Booking) bool {
+	_, err := s.bookings.CancelBooking(ctx, booking.ID, &dto.CancelBookingRequest{
+		Reason:      "Balance payment failed after every retry",
+		CancelledBy: booking.TenantID,
+	})
+	if err != nil {
+		s.logger.Error("failed to cancel booking with unpaid balance", "booking_id", booking.ID, "error", err)
+		return false
+	}
+
+	s.logger.Info("booking cancelled for unpaid balance", "booking_id", booking.ID)
+	return true
+}
@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/storage"
+	"Krafti_Vibe/internal/pkg/background"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/xlsx"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// bookingExportAsyncThreshold is the number of matching bookings above which exports run in the background
+	bookingExportAsyncThreshold = 2000
+	// bookingExportBatchSize is the number of bookings loaded per page while writing the file
+	bookingExportBatchSize = 100
+	// bookingExportTimeout bounds how long a background export may run
+	bookingExportTimeout = 30 * time.Minute
+	// bookingExportRetention is how long generated files are kept
+	bookingExportRetention = 7 * 24 * time.Hour
+	// bookingExportLinkTTL is how long a signed download link stays valid
+	bookingExportLinkTTL = 24 * time.Hour
+)
+
+// bookingExportColumns is the header row of every export
+var bookingExportColumns = []string{
+	"booking_id", "status", "payment_status", "start_time", "end_time", "duration_minutes",
+	"customer_name", "customer_email", "artisan_name", "artisan_email", "service_name",
+	"base_price", "addons_price", "total_price", "deposit_paid", "currency",
+	"is_recurring", "notes", "customer_notes", "created_at",
+}
+
+// BookingExportDownload is an opened export file ready to be streamed to the client
+type BookingExportDownload struct {
+	Content     io.ReadCloser
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+// BookingExportService defines the interface for exporting bookings to files
+type BookingExportService interface {
+	// ExportBookings exports every booking matching the filter. Small exports complete inline;
+	// large ones and requests with Async set run in the background and email the requester when ready.
+	ExportBookings(ctx context.Context, req *dto.BookingExportRequest) (*dto.BookingExportResponse, error)
+	GetExport(ctx context.Context, id uuid.UUID, tenantID uuid.UUID, baseURL string) (*dto.BookingExportResponse, error)
+
+	// OpenDownload verifies a signed download link and opens the export file
+	OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (*BookingExportDownload, error)
+
+	// PurgeExpiredExports deletes stored files whose retention period has passed
+	PurgeExpiredExports(ctx context.Context) (int, error)
+
+	// FailInterruptedExports marks exports left unfinished by a stopped server as failed
+	FailInterruptedExports(ctx context.Context) (int64, error)
+}
+
+// bookingExportService implements BookingExportService
+type bookingExportService struct {
+	repos         *repository.Repositories
+	logger        log.AllLogger
+	store         storage.ObjectStore
+	signer        *storage.URLSigner
+	notifications NotificationService
+}
+
+// NewBookingExportService creates a new BookingExportService instance
+func NewBookingExportService(repos *repository.Repositories, logger log.AllLogger, store storage.ObjectStore, signingSecret string) BookingExportService {
+	return &bookingExportService{
+		repos:         repos,
+		logger:        logger,
+		store:         store,
+		signer:        storage.NewURLSigner(signingSecret),
+		notifications: NewNotificationService(repos, logger),
+	}
+}
+
+// ============================================================================
+// Export Operations
+// ============================================================================
+
+// ExportBookings creates an export record and generates the file inline or in the background
+func (s *bookingExportService) ExportBookings(ctx context.Context, req *dto.BookingExportRequest) (*dto.BookingExportResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+	if s.store == nil {
+		return nil, errors.NewServiceError("EXPORT_UNAVAILABLE", "file storage is not configured", nil)
+	}
+
+	// Exports are always scoped to the caller's tenant
+	req.Filter.TenantID = &req.TenantID
+	filter := toBookingRepoFilter(req.Filter)
+	filter.IncludeRelations = nil // Rows only show the customer, artisan and service
+
+	// A single-row page is enough to get the total without loading the bookings
+	_, counted, err := s.repos.Booking.FindByFilters(ctx, filter, repository.PaginationParams{Page: 1, PageSize: 1})
+	if err != nil {
+		return nil, errors.NewServiceError("EXPORT_COUNT_FAILED", "failed to count bookings", err)
+	}
+
+	job := &models.BookingExport{
+		TenantID:      req.TenantID,
+		RequestedByID: req.RequestedBy,
+		Format:        req.Format,
+		Filters:       bookingExportFilters(req.Filter),
+		Status:        models.BookingExportStatusPending,
+		Async:         req.Async || counted.TotalItems > bookingExportAsyncThreshold,
+	}
+	if err := s.repos.BookingExport.Create(ctx, job); err != nil {
+		s.logger.Error("failed to create booking export", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("EXPORT_CREATE_FAILED", "failed to create booking export", err)
+	}
+
+	if !job.Async {
+		s.process(ctx, job, filter)
+		return s.toResponse(job, req.BaseURL), nil
+	}
+
+	// Snapshot the response before the worker starts mutating the job
+	response := s.toResponse(job, req.BaseURL)
+
+	background.Go(ctx, "booking_export", func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, bookingExportTimeout)
+		defer cancel()
+
+		s.process(ctx, job, filter)
+		s.notifyRequester(ctx, job, req.BaseURL)
+	})
+
+	return response, nil
+}
+
+// GetExport retrieves an export, signing a fresh download link once it has completed
+func (s *bookingExportService) GetExport(ctx context.Context, id uuid.UUID, tenantID uuid.UUID, baseURL string) (*dto.BookingExportResponse, error) {
+	job, err := s.repos.BookingExport.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("booking export not found")
+		}
+		return nil, errors.NewServiceError("EXPORT_GET_FAILED", "failed to get booking export", err)
+	}
+	if job.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("booking export not found")
+	}
+
+	return s.toResponse(job, baseURL), nil
+}
+
+// OpenDownload checks the link signature against the stored file key and opens the file
+func (s *bookingExportService) OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (*BookingExportDownload, error) {
+	job, err := s.repos.BookingExport.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("booking export not found")
+		}
+		return nil, errors.NewServiceError("EXPORT_GET_FAILED", "failed to get booking export", err)
+	}
+	if !job.IsDownloadable() || s.store == nil {
+		return nil, errors.NewNotFoundError("booking export file not available")
+	}
+
+	if err := s.signer.Verify(job.FileKey, expires, signature); err != nil {
+		switch {
+		case stdErrors.Is(err, storage.ErrMissingSecret):
+			return nil, errors.NewServiceError("EXPORT_UNAVAILABLE", "download links are not configured", err)
+		case stdErrors.Is(err, storage.ErrSignatureExpired):
+			return nil, errors.NewForbiddenError("download link has expired")
+		default:
+			return nil, errors.NewForbiddenError("invalid download link")
+		}
+	}
+
+	content, err := s.store.Open(ctx, job.FileKey)
+	if err != nil {
+		if stdErrors.Is(err, storage.ErrObjectNotFound) {
+			return nil, errors.NewNotFoundError("booking export file not available")
+		}
+		return nil, errors.NewServiceError("EXPORT_OPEN_FAILED", "failed to open booking export", err)
+	}
+
+	return &BookingExportDownload{
+		Content:     content,
+		FileName:    job.FileName(),
+		ContentType: job.Format.ContentType(),
+		Size:        job.FileSize,
+	}, nil
+}
+
+// PurgeExpiredExports removes expired files from storage and clears their keys
+func (s *bookingExportService) PurgeExpiredExports(ctx context.Context) (int, error) {
+	if s.store == nil {
+		return 0, nil
+	}
+
+	expired, err := s.repos.BookingExport.FindExpired(ctx, time.Now(), 100)
+	if err != nil {
+		return 0, errors.NewServiceError("EXPORT_PURGE_FAILED", "failed to find expired booking exports", err)
+	}
+
+	purged := 0
+	for _, job := range expired {
+		if err := s.store.Delete(ctx, job.FileKey); err != nil {
+			s.logger.Error("failed to delete expired booking export", "export_id", job.ID, "error", err)
+			continue
+		}
+		job.FileKey = ""
+		s.save(ctx, job)
+		purged++
+	}
+
+	return purged, nil
+}
+
+// ============================================================================
+// Processing
+// ============================================================================
+
+// process writes the matching bookings page by page to a temporary file and uploads it
+func (s *bookingExportService) process(ctx context.Context, job *models.BookingExport, filter repository.BookingFilters) {
+	now := time.Now()
+	job.Status = models.BookingExportStatusProcessing
+	job.StartedAt = &now
+	s.save(ctx, job)
+
+	tmp, err := os.CreateTemp("", "booking-export-*."+string(job.Format))
+	if err != nil {
+		s.fail(ctx, job, "failed to create export file: "+err.Error())
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	rows, err := s.writeBookings(ctx, tmp, job.Format, filter)
+	if err != nil {
+		s.fail(ctx, job, err.Error())
+		return
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		s.fail(ctx, job, "failed to read export file: "+err.Error())
+		return
+	}
+
+	key := fmt.Sprintf("exports/%s/bookings/%s.%s", job.TenantID, job.ID, job.Format)
+	size, err := s.store.Put(ctx, key, job.Format.ContentType(), tmp)
+	if err != nil {
+		s.fail(ctx, job, "failed to store export file: "+err.Error())
+		return
+	}
+
+	completed := time.Now()
+	expires := completed.Add(bookingExportRetention)
+	job.Status = models.BookingExportStatusCompleted
+	job.RowCount = rows
+	job.FileKey = key
+	job.FileSize = size
+	job.CompletedAt = &completed
+	job.ExpiresAt = &expires
+	s.save(ctx, job)
+}
+
+// writeBookings streams the bookings into w using keyset pagination and returns the row count
+func (s *bookingExportService) writeBookings(ctx context.Context, w io.Writer, format models.BookingExportFormat, filter repository.BookingFilters) (int, error) {
+	rw, err := newBookingExportWriter(w, format)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start export file: %w", err)
+	}
+
+	header := make([]any, len(bookingExportColumns))
+	for i, column := range bookingExportColumns {
+		header[i] = column
+	}
+	if err := rw.WriteRow(header...); err != nil {
+		return 0, fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	rows := 0
+	pagination := repository.PaginationParams{Page: 1, PageSize: bookingExportBatchSize}
+	for {
+		bookings, result, err := s.repos.Booking.FindByFilters(ctx, filter, pagination)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load bookings: %w", err)
+		}
+
+		for _, booking := range bookings {
+			if err := rw.WriteRow(bookingExportRow(booking)...); err != nil {
+				return 0, fmt.Errorf("failed to write export file: %w", err)
+			}
+			rows++
+		}
+
+		if !result.HasMore {
+			break
+		}
+		pagination.Cursor = result.NextCursor
+	}
+
+	if err := rw.Close(); err != nil {
+		return 0, fmt.Errorf("failed to finish export file: %w", err)
+	}
+	return rows, nil
+}
+
+// notifyRequester emails the requester once a background export has finished
+func (s *bookingExportService) notifyRequester(ctx context.Context, job *models.BookingExport, baseURL string) {
+	downloadURL := ""
+	if response := s.toResponse(job, baseURL); response != nil {
+		downloadURL = response.DownloadURL
+	}
+
+	if _, err := s.notifications.SendBookingExportReadyNotification(ctx, job, downloadURL); err != nil {
+		s.logger.Error("failed to notify booking export requester", "export_id", job.ID, "error", err)
+	}
+}
+
+// toResponse converts the export and, when it can be downloaded, signs a link valid for
+// bookingExportLinkTTL or until the file expires, whichever comes first
+func (s *bookingExportService) toResponse(job *models.BookingExport, baseURL string) *dto.BookingExportResponse {
+	response := dto.ToBookingExportResponse(job)
+	if !job.IsDownloadable() {
+		return response
+	}
+
+	expires := time.Now().Add(bookingExportLinkTTL)
+	if job.ExpiresAt != nil && job.ExpiresAt.Before(expires) {
+		expires = *job.ExpiresAt
+	}
+
+	signature, err := s.signer.Sign(job.FileKey, expires)
+	if err != nil {
+		s.logger.Warn("booking export download link not signed", "export_id", job.ID, "error", err)
+		return response
+	}
+
+	response.DownloadURL = fmt.Sprintf("%s/api/v1/bookings/exports/%s/download?expires=%d&signature=%s",
+		strings.TrimRight(baseURL, "/"), job.ID, expires.Unix(), signature)
+	response.URLExpiresAt = &expires
+	return response
+}
+
+// FailInterruptedExports marks exports older than bookingExportTimeout that never
+// finished as failed. Any still running would have timed out, so they were interrupted.
+func (s *bookingExportService) FailInterruptedExports(ctx context.Context) (int64, error) {
+	failed, err := s.repos.BookingExport.FailUnfinished(ctx, time.Now().Add(-bookingExportTimeout), "export interrupted by a server restart")
+	if err != nil {
+		return 0, errors.NewServiceError("EXPORT_RECOVERY_FAILED", "failed to fail interrupted booking exports", err)
+	}
+	if failed > 0 {
+		s.logger.Warn("failed interrupted booking exports", "count", failed)
+	}
+	return failed, nil
+}
+
+func (s *bookingExportService) save(ctx context.Context, job *models.BookingExport) {
+	if err := s.repos.BookingExport.Update(ctx, job); err != nil {
+		s.logger.Error("failed to update booking export", "export_id", job.ID, "error", err)
+	}
+}
+
+// fail records the export as failed, even when ctx is done because it was interrupted
+func (s *bookingExportService) fail(ctx context.Context, job *models.BookingExport, message string) {
+	now := time.Now()
+	job.Status = models.BookingExportStatusFailed
+	job.ErrorMessage = message
+	job.CompletedAt = &now
+	s.save(context.WithoutCancel(ctx), job)
+	s.logger.Warn("booking export failed", "export_id", job.ID, "reason", message)
+}
+
+// ============================================================================
+// File Formats
+// ============================================================================
+
+// bookingExportWriter writes rows in one of the supported export formats
+type bookingExportWriter interface {
+	WriteRow(values ...any) error
+	Close() error
+}
+
+func newBookingExportWriter(w io.Writer, format models.BookingExportFormat) (bookingExportWriter, error) {
+	if format == models.BookingExportFormatXLSX {
+		return xlsx.NewWriter(w, "Bookings")
+	}
+	return &csvExportWriter{w: csv.NewWriter(w)}, nil
+}
+
+// csvExportWriter adapts encoding/csv to bookingExportWriter
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvExportWriter) WriteRow(values ...any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'f', 2, 64)
+		case time.Time:
+			record[i] = v.UTC().Format(time.RFC3339)
+		case string:
+			// Keep spreadsheet applications from evaluating user-supplied text as a formula
+			if v != "" && strings.ContainsRune("=+-@", rune(v[0])) {
+				v = "'" + v
+			}
+			record[i] = v
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvExportWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// bookingExportRow returns the values for one booking in bookingExportColumns order
+func bookingExportRow(b *models.Booking) []any {
+	var customerName, customerEmail, artisanName, artisanEmail, serviceName string
+	if b.Customer != nil {
+		customerName, customerEmail = b.Customer.FullName(), b.Customer.Email
+	}
+	if b.Artisan != nil {
+		artisanName, artisanEmail = b.Artisan.FullName(), b.Artisan.Email
+	}
+	if b.Service != nil {
+		serviceName = b.Service.Name
+	}
+
+	return []any{
+		b.ID.String(), string(b.Status), string(b.PaymentStatus), b.StartTime, b.EndTime, b.Duration,
+		customerName, customerEmail, artisanName, artisanEmail, serviceName,
+		b.BasePrice, b.AddonsPrice, b.TotalPrice, b.DepositPaid, b.Currency,
+		b.IsRecurring, b.Notes, b.CustomerNotes, b.CreatedAt,
+	}
+}
+
+// bookingExportFilters records the requested filter on the export for auditing
+func bookingExportFilters(filter dto.BookingFilter) models.JSONB {
+	filters := models.JSONB{}
+	data, err := json.Marshal(filter)
+	if err == nil {
+		_ = json.Unmarshal(data, &filters)
+	}
+	// Pagination is not part of the export definition
+	for _, key := range []string{"page", "page_size", "cursor", "sort_by", "sort_order", "include_relations"} {
+		delete(filters, key)
+	}
+	return filters
+}
@@ -0,0 +1,785 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/background"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// bookingImportAsyncThreshold is the file size above which imports run in the background
+	bookingImportAsyncThreshold = 512 * 1024
+	// bookingImportBatchSize is the number of valid rows inserted per batch
+	bookingImportBatchSize = 200
+	// bookingImportTimeout bounds how long a background import may run
+	bookingImportTimeout = 30 * time.Minute
+)
+
+// Accepted CSV columns. Headers are matched case-insensitively; unknown columns are ignored.
+const (
+	importColCustomerEmail = "customer_email"
+	importColArtisanEmail  = "artisan_email"
+	importColServiceID     = "service_id"
+	importColServiceName   = "service_name"
+	importColStartTime     = "start_time"
+	importColEndTime       = "end_time"
+	importColDuration      = "duration"
+	importColStatus        = "status"
+	importColPaymentStatus = "payment_status"
+	importColBasePrice     = "base_price"
+	importColTotalPrice    = "total_price"
+	importColDepositPaid   = "deposit_paid"
+	importColCurrency      = "currency"
+	importColNotes         = "notes"
+	importColInternalNotes = "internal_notes"
+)
+
+// importTimeLayouts are tried in order when parsing start_time and end_time
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// BookingImportService defines the interface for bulk booking imports
+type BookingImportService interface {
+	// ImportBookings validates and imports bookings from a CSV stream. Large files and
+	// requests with Async set are processed in the background; poll GetImport for the result.
+	ImportBookings(ctx context.Context, req *dto.BookingImportRequest, src io.Reader) (*dto.BookingImportResponse, error)
+	GetImport(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) (*dto.BookingImportResponse, error)
+	ListImports(ctx context.Context, tenantID uuid.UUID, page, pageSize int) (*dto.BookingImportListResponse, error)
+
+	// FailInterruptedImports marks imports left unfinished by a stopped server as failed
+	FailInterruptedImports(ctx context.Context) (int64, error)
+}
+
+// bookingImportService implements BookingImportService
+type bookingImportService struct {
+	repos    *repository.Repositories
+	logger   log.AllLogger
+	bookings BookingService // Checks future bookings against the artisans' schedules
+}
+
+// NewBookingImportService creates a new BookingImportService instance
+func NewBookingImportService(repos *repository.Repositories, logger log.AllLogger, bookings BookingService) BookingImportService {
+	return &bookingImportService{
+		repos:    repos,
+		logger:   logger,
+		bookings: bookings,
+	}
+}
+
+// ============================================================================
+// Import Operations
+// ============================================================================
+
+// ImportBookings creates an import record and processes the file inline or in the background
+func (s *bookingImportService) ImportBookings(ctx context.Context, req *dto.BookingImportRequest, src io.Reader) (*dto.BookingImportResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	job := &models.BookingImport{
+		TenantID:     req.TenantID,
+		UploadedByID: req.UploadedBy,
+		FileName:     req.FileName,
+		FileSize:     req.FileSize,
+		Status:       models.BookingImportStatusPending,
+		DryRun:       req.DryRun,
+		Timezone:     timezone,
+	}
+	if err := s.repos.BookingImport.Create(ctx, job); err != nil {
+		s.logger.Error("failed to create booking import", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("IMPORT_CREATE_FAILED", "failed to create booking import", err)
+	}
+
+	if !req.Async && req.FileSize <= bookingImportAsyncThreshold {
+		s.process(ctx, job, src)
+		return dto.ToBookingImportResponse(job), nil
+	}
+
+	// The upload is only readable for the lifetime of the request, so spool it to disk first
+	path, err := spoolImportFile(src)
+	if err != nil {
+		s.fail(ctx, job, "failed to buffer upload: "+err.Error())
+		return nil, errors.NewServiceError("IMPORT_BUFFER_FAILED", "failed to buffer import file", err)
+	}
+
+	// Snapshot the response before the worker starts mutating the job
+	response := dto.ToBookingImportResponse(job)
+
+	background.Go(ctx, "booking_import", func(ctx context.Context) {
+		defer os.Remove(path)
+
+		ctx, cancel := context.WithTimeout(ctx, bookingImportTimeout)
+		defer cancel()
+
+		f, err := os.Open(path)
+		if err != nil {
+			s.fail(ctx, job, "failed to open buffered upload: "+err.Error())
+			return
+		}
+		defer f.Close()
+
+		s.process(ctx, job, f)
+	})
+
+	return response, nil
+}
+
+// GetImport retrieves an import with its validation report
+func (s *bookingImportService) GetImport(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) (*dto.BookingImportResponse, error) {
+	job, err := s.repos.BookingImport.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("booking import not found")
+		}
+		return nil, errors.NewServiceError("IMPORT_GET_FAILED", "failed to get booking import", err)
+	}
+	if job.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("booking import not found")
+	}
+
+	return dto.ToBookingImportResponse(job), nil
+}
+
+// ListImports lists a tenant's imports, newest first
+func (s *bookingImportService) ListImports(ctx context.Context, tenantID uuid.UUID, page, pageSize int) (*dto.BookingImportListResponse, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	pagination := repository.PaginationParams{Page: page, PageSize: pageSize}
+	imports, result, err := s.repos.BookingImport.FindByTenantID(ctx, tenantID, pagination)
+	if err != nil {
+		return nil, errors.NewServiceError("IMPORT_LIST_FAILED", "failed to list booking imports", err)
+	}
+
+	return &dto.BookingImportListResponse{
+		Imports:     dto.ToBookingImportResponses(imports),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// ============================================================================
+// Processing
+// ============================================================================
+
+// process streams the CSV row by row, inserting valid rows in batches
+func (s *bookingImportService) process(ctx context.Context, job *models.BookingImport, src io.Reader) {
+	now := time.Now()
+	job.Status = models.BookingImportStatusProcessing
+	job.StartedAt = &now
+	s.save(ctx, job)
+
+	loc, err := time.LoadLocation(job.Timezone)
+	if err != nil {
+		s.fail(ctx, job, "invalid timezone: "+job.Timezone)
+		return
+	}
+
+	reader := csv.NewReader(bufio.NewReader(src))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		s.fail(ctx, job, "failed to read CSV header: "+err.Error())
+		return
+	}
+	columns, err := importColumns(header)
+	if err != nil {
+		s.fail(ctx, job, err.Error())
+		return
+	}
+
+	resolver := newBookingImportResolver(s.repos, job.TenantID)
+	schedule := newImportSchedule()
+	batch := make([]*models.Booking, 0, bookingImportBatchSize)
+	batchRows := make([]int, 0, bookingImportBatchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if !job.DryRun {
+			if err := s.repos.Booking.CreateBatch(ctx, batch); err != nil {
+				s.logger.Error("failed to insert booking import batch", "import_id", job.ID, "error", err)
+				for _, row := range batchRows {
+					job.AddRowError(models.BookingImportRowError{Row: row, Message: "failed to save booking"})
+				}
+				job.FailedRows += len(batch)
+				job.ValidRows -= len(batch)
+			} else {
+				job.ImportedRows += len(batch)
+				s.recordImportEvents(ctx, job, batch)
+			}
+		}
+		batch = batch[:0]
+		batchRows = batchRows[:0]
+		s.save(ctx, job)
+	}
+
+	for row := 2; ; row++ {
+		if ctx.Err() != nil {
+			s.fail(ctx, job, "import interrupted: "+ctx.Err().Error())
+			return
+		}
+
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		job.TotalRows++
+		if err != nil {
+			job.FailedRows++
+			job.AddRowError(models.BookingImportRowError{Row: row, Message: "malformed CSV row: " + err.Error()})
+			continue
+		}
+		if isBlankRecord(record) {
+			job.TotalRows--
+			continue
+		}
+
+		get := func(col string) string {
+			if i, ok := columns[col]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		booking, rowErrs := s.buildBooking(ctx, resolver, job.TenantID, get, loc)
+		if len(rowErrs) == 0 {
+			rowErrs = s.checkSchedule(ctx, schedule, booking, row)
+		}
+		if len(rowErrs) > 0 {
+			job.FailedRows++
+			for _, rowErr := range rowErrs {
+				rowErr.Row = row
+				job.AddRowError(rowErr)
+			}
+			continue
+		}
+
+		job.ValidRows++
+		batch = append(batch, booking)
+		batchRows = append(batchRows, row)
+		if len(batch) >= bookingImportBatchSize {
+			flush()
+		}
+	}
+	flush()
+
+	completed := time.Now()
+	job.Status = models.BookingImportStatusCompleted
+	job.CompletedAt = &completed
+	s.save(ctx, job)
+
+	s.logger.Info("booking import finished",
+		"import_id", job.ID,
+		"tenant_id", job.TenantID,
+		"dry_run", job.DryRun,
+		"total_rows", job.TotalRows,
+		"imported_rows", job.ImportedRows,
+		"failed_rows", job.FailedRows,
+	)
+}
+
+// buildBooking validates a row and resolves its references into a booking
+func (s *bookingImportService) buildBooking(ctx context.Context, resolver *bookingImportResolver, tenantID uuid.UUID, get func(string) string, loc *time.Location) (*models.Booking, []models.BookingImportRowError) {
+	var rowErrs []models.BookingImportRowError
+	addErr := func(field, msg string) {
+		rowErrs = append(rowErrs, models.BookingImportRowError{Field: field, Message: msg})
+	}
+
+	booking := &models.Booking{
+		TenantID:      tenantID,
+		Status:        models.BookingStatusPending,
+		PaymentStatus: models.PaymentStatusPending,
+		Notes:         get(importColNotes),
+		InternalNotes: get(importColInternalNotes),
+	}
+
+	// Parties
+	if id, err := resolver.user(ctx, get(importColCustomerEmail), false); err != nil {
+		addErr(importColCustomerEmail, err.Error())
+	} else {
+		booking.CustomerID = id
+	}
+	if id, err := resolver.user(ctx, get(importColArtisanEmail), true); err != nil {
+		addErr(importColArtisanEmail, err.Error())
+	} else {
+		booking.ArtisanID = id
+	}
+
+	svc, err := resolver.service(ctx, get(importColServiceID), get(importColServiceName))
+	if err != nil {
+		field := importColServiceID
+		if get(importColServiceID) == "" {
+			field = importColServiceName
+		}
+		addErr(field, err.Error())
+	}
+
+	// Timing
+	start, err := parseImportTime(get(importColStartTime), loc)
+	if err != nil {
+		addErr(importColStartTime, err.Error())
+	}
+	booking.StartTime = start
+
+	if v := get(importColDuration); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d <= 0 {
+			addErr(importColDuration, "duration must be a positive number of minutes")
+		}
+		booking.Duration = d
+	}
+	if v := get(importColEndTime); v != "" {
+		end, err := parseImportTime(v, loc)
+		if err != nil {
+			addErr(importColEndTime, err.Error())
+		} else if !start.IsZero() {
+			if !end.After(start) {
+				addErr(importColEndTime, "end_time must be after start_time")
+			} else if booking.Duration == 0 {
+				booking.Duration = int(end.Sub(start).Minutes())
+			}
+		}
+	}
+
+	// Status
+	if v := get(importColStatus); v != "" {
+		status := models.BookingStatus(strings.ToLower(v))
+		if !isImportBookingStatus(status) {
+			addErr(importColStatus, "unknown booking status: "+v)
+		}
+		booking.Status = status
+	}
+	if v := get(importColPaymentStatus); v != "" {
+		status := models.PaymentStatus(strings.ToLower(v))
+		if !isImportPaymentStatus(status) {
+			addErr(importColPaymentStatus, "unknown payment status: "+v)
+		}
+		booking.PaymentStatus = status
+	}
+
+	// Pricing
+	basePrice, hasBase := parseImportAmount(get(importColBasePrice), importColBasePrice, addErr)
+	totalPrice, hasTotal := parseImportAmount(get(importColTotalPrice), importColTotalPrice, addErr)
+	booking.DepositPaid, _ = parseImportAmount(get(importColDepositPaid), importColDepositPaid, addErr)
+	booking.Currency = strings.ToUpper(get(importColCurrency))
+	if booking.Currency != "" && len(booking.Currency) != 3 {
+		addErr(importColCurrency, "currency must be a 3-letter ISO code")
+	}
+
+	if len(rowErrs) > 0 || svc == nil {
+		return nil, rowErrs
+	}
+
+	// Fill gaps from the service catalogue
+	booking.ServiceID = svc.ID
+	if booking.Duration == 0 {
+		booking.Duration = svc.DurationMinutes
+	}
+	if !hasBase {
+		basePrice = svc.Price
+	}
+	if !hasTotal {
+		totalPrice = basePrice
+	}
+	if booking.Currency == "" {
+		booking.Currency = svc.Currency
+	}
+	booking.BasePrice = basePrice
+	booking.TotalPrice = totalPrice
+	booking.AddonsPrice = max(0, totalPrice-basePrice)
+	booking.EndTime = booking.StartTime.Add(time.Duration(booking.Duration) * time.Minute)
+
+	if booking.DepositPaid > booking.TotalPrice {
+		return nil, []models.BookingImportRowError{{Field: importColDepositPaid, Message: "deposit_paid exceeds total_price"}}
+	}
+
+	// Historical bookings keep their lifecycle timestamps consistent with their status
+	switch booking.Status {
+	case models.BookingStatusCompleted:
+		completedAt := booking.EndTime
+		booking.CompletedAt = &completedAt
+	case models.BookingStatusCancelled:
+		cancelledAt := time.Now()
+		booking.CancelledAt = &cancelledAt
+		booking.CancellationReason = "Imported"
+	}
+
+	booking.Metadata = models.JSONB{"source": "import"}
+	return booking, nil
+}
+
+// checkSchedule reports a row repeating a booking the tenant already has or an earlier
+// row, and a future booking overlapping another booking of its artisan, whether saved
+// or earlier in the file
+func (s *bookingImportService) checkSchedule(ctx context.Context, schedule *importSchedule, booking *models.Booking, row int) []models.BookingImportRowError {
+	rowErr := func(msg string) []models.BookingImportRowError {
+		return []models.BookingImportRowError{{Field: importColStartTime, Message: msg}}
+	}
+
+	key := importBookingKey{
+		artisanID:  booking.ArtisanID,
+		customerID: booking.CustomerID,
+		serviceID:  booking.ServiceID,
+		start:      booking.StartTime.Unix(),
+	}
+	if first, ok := schedule.rows[key]; ok {
+		return rowErr(fmt.Sprintf("duplicates row %d", first))
+	}
+
+	existing, err := s.repos.Booking.GetArtisanBookingsInRange(ctx, booking.ArtisanID, booking.StartTime, booking.StartTime)
+	if err != nil {
+		s.logger.Error("failed to check for an existing booking", "artisan_id", booking.ArtisanID, "error", err)
+		return rowErr("failed to check for an existing booking")
+	}
+	for _, b := range existing {
+		if b.CustomerID == booking.CustomerID && b.ServiceID == booking.ServiceID {
+			return rowErr("booking already exists")
+		}
+	}
+
+	if booking.IsUpcoming() || (booking.Status == models.BookingStatusInProgress && booking.EndTime.After(time.Now())) {
+		for _, slot := range schedule.slots[booking.ArtisanID] {
+			if booking.StartTime.Before(slot.end) && slot.start.Before(booking.EndTime) {
+				return rowErr(fmt.Sprintf("artisan is already booked by row %d", slot.row))
+			}
+		}
+
+		conflicted, conflicts, err := s.bookings.HasBookingConflicts(ctx, booking.ArtisanID, booking.StartTime, booking.EndTime, nil)
+		if err != nil {
+			s.logger.Error("failed to check artisan availability", "artisan_id", booking.ArtisanID, "error", err)
+			return rowErr("failed to check artisan availability")
+		}
+		if conflicted {
+			msg := "artisan is not available for this time slot"
+			if len(conflicts) > 0 {
+				msg = fmt.Sprintf("artisan already has a booking from %s to %s",
+					conflicts[0].StartTime.UTC().Format(time.RFC3339), conflicts[0].EndTime.UTC().Format(time.RFC3339))
+			}
+			return rowErr(msg)
+		}
+
+		schedule.slots[booking.ArtisanID] = append(schedule.slots[booking.ArtisanID],
+			importSlot{row: row, start: booking.StartTime, end: booking.EndTime})
+	}
+
+	schedule.rows[key] = row
+	return nil
+}
+
+// recordImportEvents starts the change log of each imported booking
+func (s *bookingImportService) recordImportEvents(ctx context.Context, job *models.BookingImport, bookings []*models.Booking) {
+	events := make([]*models.BookingEvent, len(bookings))
+	for i, booking := range bookings {
+		events[i] = &models.BookingEvent{
+			TenantID:  booking.TenantID,
+			BookingID: booking.ID,
+			ActorID:   &job.UploadedByID,
+			Source:    models.BookingEventSourceImport,
+			EventType: models.BookingEventCreated,
+			Changes:   createdBookingChanges(booking),
+			Note:      "Imported from " + job.FileName,
+		}
+	}
+
+	if err := s.repos.BookingEvent.CreateBatch(ctx, events); err != nil {
+		s.logger.Error("failed to record booking import events", "import_id", job.ID, "error", err)
+	}
+}
+
+// FailInterruptedImports marks imports older than bookingImportTimeout that never
+// finished as failed. Any still running would have timed out, so they were interrupted.
+func (s *bookingImportService) FailInterruptedImports(ctx context.Context) (int64, error) {
+	failed, err := s.repos.BookingImport.FailUnfinished(ctx, time.Now().Add(-bookingImportTimeout), "import interrupted by a server restart")
+	if err != nil {
+		return 0, errors.NewServiceError("IMPORT_RECOVERY_FAILED", "failed to fail interrupted booking imports", err)
+	}
+	if failed > 0 {
+		s.logger.Warn("failed interrupted booking imports", "count", failed)
+	}
+	return failed, nil
+}
+
+func (s *bookingImportService) save(ctx context.Context, job *models.BookingImport) {
+	if err := s.repos.BookingImport.Update(ctx, job); err != nil {
+		s.logger.Error("failed to update booking import", "import_id", job.ID, "error", err)
+	}
+}
+
+// fail records the import as failed, even when ctx is done because it was interrupted
+func (s *bookingImportService) fail(ctx context.Context, job *models.BookingImport, message string) {
+	now := time.Now()
+	job.Status = models.BookingImportStatusFailed
+	job.ErrorMessage = message
+	job.CompletedAt = &now
+	s.save(context.WithoutCancel(ctx), job)
+	s.logger.Warn("booking import failed", "import_id", job.ID, "reason", message)
+}
+
+// ============================================================================
+// Reference Resolution
+// ============================================================================
+
+// importBookingKey identifies a booking for duplicate detection
+type importBookingKey struct {
+	artisanID  uuid.UUID
+	customerID uuid.UUID
+	serviceID  uuid.UUID
+	start      int64
+}
+
+// importSlot is the time a future booking of an earlier row holds
+type importSlot struct {
+	row        int
+	start, end time.Time
+}
+
+// importSchedule tracks the rows accepted so far, which aren't saved until their batch is
+type importSchedule struct {
+	rows  map[importBookingKey]int   // First row of each booking
+	slots map[uuid.UUID][]importSlot // Future bookings, by artisan
+}
+
+func newImportSchedule() *importSchedule {
+	return &importSchedule{
+		rows:  make(map[importBookingKey]int),
+		slots: make(map[uuid.UUID][]importSlot),
+	}
+}
+
+// bookingImportResolver looks up users and services once per import
+type bookingImportResolver struct {
+	repos    *repository.Repositories
+	tenantID uuid.UUID
+	users    map[string]*models.User
+	services map[string]*models.Service
+}
+
+func newBookingImportResolver(repos *repository.Repositories, tenantID uuid.UUID) *bookingImportResolver {
+	return &bookingImportResolver{
+		repos:    repos,
+		tenantID: tenantID,
+		users:    make(map[string]*models.User),
+		services: make(map[string]*models.Service),
+	}
+}
+
+func (r *bookingImportResolver) user(ctx context.Context, email string, artisan bool) (uuid.UUID, error) {
+	email = strings.ToLower(email)
+	if email == "" {
+		return uuid.Nil, fmt.Errorf("email is required")
+	}
+
+	u, cached := r.users[email]
+	if !cached {
+		found, err := r.repos.User.GetByEmailWithTenant(ctx, email, r.tenantID)
+		if err != nil && !errors.IsNotFoundError(err) {
+			return uuid.Nil, fmt.Errorf("failed to look up %s", email)
+		}
+		u = found
+		r.users[email] = u
+	}
+
+	if u == nil {
+		return uuid.Nil, fmt.Errorf("no user with email %s in this tenant", email)
+	}
+	if artisan && u.Role == models.UserRoleCustomer {
+		return uuid.Nil, fmt.Errorf("%s is a customer, not an artisan", email)
+	}
+	return u.ID, nil
+}
+
+func (r *bookingImportResolver) service(ctx context.Context, id, name string) (*models.Service, error) {
+	key := "id:" + id
+	if id == "" {
+		if name == "" {
+			return nil, fmt.Errorf("service_id or service_name is required")
+		}
+		key = "name:" + strings.ToLower(name)
+	}
+
+	svc, cached := r.services[key]
+	if !cached {
+		var err error
+		svc, err = r.lookupService(ctx, id, name)
+		if err != nil {
+			return nil, err
+		}
+		r.services[key] = svc
+	}
+
+	if svc == nil {
+		return nil, fmt.Errorf("service not found in this tenant")
+	}
+	return svc, nil
+}
+
+func (r *bookingImportResolver) lookupService(ctx context.Context, id, name string) (*models.Service, error) {
+	if id != "" {
+		serviceID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service_id")
+		}
+		svc, err := r.repos.Service.GetByID(ctx, serviceID)
+		if err != nil {
+			if errors.IsNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to look up service")
+		}
+		if svc.TenantID != r.tenantID {
+			return nil, nil
+		}
+		return svc, nil
+	}
+
+	services, err := r.repos.Service.Find(ctx, map[string]any{"tenant_id": r.tenantID, "name": name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up service")
+	}
+	switch len(services) {
+	case 0:
+		return nil, nil
+	case 1:
+		return services[0], nil
+	default:
+		return nil, fmt.Errorf("service name %q is ambiguous, use service_id", name)
+	}
+}
+
+// ============================================================================
+// Parsing Helpers
+// ============================================================================
+
+// importColumns maps normalised header names to column indexes and checks required columns
+func importColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\uFEFF") // Excel writes a UTF-8 BOM
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.ReplaceAll(name, " ", "_")
+		if _, exists := columns[name]; !exists {
+			columns[name] = i
+		}
+	}
+
+	var missing []string
+	for _, col := range []string{importColCustomerEmail, importColArtisanEmail, importColStartTime} {
+		if _, ok := columns[col]; !ok {
+			missing = append(missing, col)
+		}
+	}
+	_, hasID := columns[importColServiceID]
+	_, hasName := columns[importColServiceName]
+	if !hasID && !hasName {
+		missing = append(missing, importColServiceID+" or "+importColServiceName)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required columns: %s", strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+func parseImportTime(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("time is required")
+	}
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised time %q, use RFC 3339 or YYYY-MM-DD HH:MM", value)
+}
+
+func parseImportAmount(value, field string, addErr func(field, msg string)) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount < 0 {
+		addErr(field, field+" must be a non-negative number")
+		return 0, false
+	}
+	return amount, true
+}
+
+func isImportBookingStatus(status models.BookingStatus) bool {
+	switch status {
+	case models.BookingStatusPending, models.BookingStatusConfirmed, models.BookingStatusInProgress,
+		models.BookingStatusCompleted, models.BookingStatusCancelled, models.BookingStatusNoShow,
+		models.BookingStatusRefunded:
+		return true
+	}
+	return false
+}
+
+func isImportPaymentStatus(status models.PaymentStatus) bool {
+	switch status {
+	case models.PaymentStatusPending, models.PaymentStatusProcessing, models.PaymentStatusPaid,
+		models.PaymentStatusFailed, models.PaymentStatusCancelled, models.PaymentStatusRefunded,
+		models.PaymentStatusPartialRefund:
+		return true
+	}
+	return false
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// spoolImportFile copies the upload to a temporary file for background processing
+func spoolImportFile(src io.Reader) (string, error) {
+	f, err := os.CreateTemp("", "booking-import-*.csv")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, src); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
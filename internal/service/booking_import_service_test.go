@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubImportUserRepository finds users by email; its other methods aren't used
+type stubImportUserRepository struct {
+	repository.UserRepository
+	users map[string]*models.User
+}
+
+func (r *stubImportUserRepository) GetByEmailWithTenant(ctx context.Context, email string, tenantID uuid.UUID) (*models.User, error) {
+	if u, ok := r.users[email]; ok && u.TenantID != nil && *u.TenantID == tenantID {
+		return u, nil
+	}
+	return nil, errors.NewNotFoundError("user")
+}
+
+// stubImportServiceRepository finds services by name; its other methods aren't used
+type stubImportServiceRepository struct {
+	repository.ServiceRepository
+	services []*models.Service
+}
+
+func (r *stubImportServiceRepository) Find(ctx context.Context, filters map[string]any) ([]*models.Service, error) {
+	var found []*models.Service
+	for _, svc := range r.services {
+		if svc.TenantID == filters["tenant_id"] && svc.Name == filters["name"] {
+			found = append(found, svc)
+		}
+	}
+	return found, nil
+}
+
+// stubImportBookingRepository holds the bookings the tenant already has; its other
+// methods aren't used
+type stubImportBookingRepository struct {
+	repository.BookingRepository
+	bookings []*models.Booking
+}
+
+func (r *stubImportBookingRepository) GetArtisanBookingsInRange(ctx context.Context, artisanID uuid.UUID, startDate, endDate time.Time) ([]*models.Booking, error) {
+	var found []*models.Booking
+	for _, b := range r.bookings {
+		if b.ArtisanID == artisanID && !b.StartTime.Before(startDate) && !b.StartTime.After(endDate) {
+			found = append(found, b)
+		}
+	}
+	return found, nil
+}
+
+// stubBookingImportRepository keeps imports in memory; its other methods aren't used
+type stubBookingImportRepository struct {
+	repository.BookingImportRepository
+}
+
+func (r *stubBookingImportRepository) Create(ctx context.Context, job *models.BookingImport) error {
+	job.ID = uuid.New()
+	return nil
+}
+
+func (r *stubBookingImportRepository) Update(ctx context.Context, job *models.BookingImport) error {
+	return nil
+}
+
+// stubSchedulingService reports the bookings of its artisans as conflicts; its other
+// methods aren't used
+type stubSchedulingService struct {
+	service.BookingService
+	bookings []*models.Booking
+}
+
+func (s *stubSchedulingService) HasBookingConflicts(ctx context.Context, artisanID uuid.UUID, startTime, endTime time.Time, excludeBookingID *uuid.UUID) (bool, []*dto.ConflictResponse, error) {
+	var conflicts []*dto.ConflictResponse
+	for _, b := range s.bookings {
+		if b.ArtisanID == artisanID && startTime.Before(b.EndTime) && b.StartTime.Before(endTime) {
+			conflicts = append(conflicts, &dto.ConflictResponse{ConflictType: "booking", StartTime: b.StartTime, EndTime: b.EndTime, BookingID: &b.ID})
+		}
+	}
+	return len(conflicts) > 0, conflicts, nil
+}
+
+func TestBookingImportService_ImportBookings(t *testing.T) {
+	tenantID := uuid.New()
+	customer := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: &tenantID, Email: "ada@example.com", Role: models.UserRoleCustomer}
+	artisan := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: &tenantID, Email: "bo@example.com", Role: models.UserRoleArtisan}
+	haircut := &models.Service{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: tenantID, Name: "Haircut", DurationMinutes: 60, Price: 40, Currency: "USD"}
+
+	future := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Hour)
+	at := func(t time.Time) string { return t.Format(time.RFC3339) }
+
+	// The artisan is booked by another customer two hours after future, and has a
+	// past booking of the customer ten days before
+	past := future.Add(-10 * 24 * time.Hour)
+	existing := []*models.Booking{
+		{BaseModel: models.BaseModel{ID: uuid.New()}, ArtisanID: artisan.ID, CustomerID: uuid.New(), ServiceID: haircut.ID,
+			StartTime: future.Add(2 * time.Hour), EndTime: future.Add(3 * time.Hour), Status: models.BookingStatusConfirmed},
+		{BaseModel: models.BaseModel{ID: uuid.New()}, ArtisanID: artisan.ID, CustomerID: customer.ID, ServiceID: haircut.ID,
+			StartTime: past, EndTime: past.Add(time.Hour), Status: models.BookingStatusCompleted},
+	}
+
+	const header = "customer_email,artisan_email,service_name,start_time,status\n"
+
+	tests := []struct {
+		name       string
+		csv        string
+		wantStatus models.BookingImportStatus
+		wantValid  int
+		wantErrs   []models.BookingImportRowError
+		wantFailed string // Message of a failed import
+	}{
+		{
+			name: "maps headers case-insensitively and ignores unknown columns",
+			csv: "\uFEFFCustomer Email,ARTISAN EMAIL,Service Name,Start Time,Source\n" +
+				"ada@example.com,bo@example.com,Haircut," + at(future) + ",legacy\n",
+			wantStatus: models.BookingImportStatusCompleted,
+			wantValid:  1,
+		},
+		{
+			name:       "fails without a required column",
+			csv:        "customer_email,artisan_email,start_time\nada@example.com,bo@example.com," + at(future) + "\n",
+			wantStatus: models.BookingImportStatusFailed,
+			wantFailed: "missing required columns: service_id or service_name",
+		},
+		{
+			name: "reports bad rows and keeps the good ones",
+			csv: header +
+				"nobody@example.com,bo@example.com,Haircut," + at(future) + ",\n" +
+				"ada@example.com,ada@example.com,Haircut," + at(future) + ",\n" +
+				"ada@example.com,bo@example.com,Haircut,next tuesday,\n" +
+				"ada@example.com,bo@example.com,Haircut," + at(future) + ",lost\n" +
+				"ada@example.com,bo@example.com,Haircut," + at(future) + ",pending\n",
+			wantStatus: models.BookingImportStatusCompleted,
+			wantValid:  1,
+			wantErrs: []models.BookingImportRowError{
+				{Row: 2, Field: "customer_email", Message: "no user with email nobody@example.com in this tenant"},
+				{Row: 3, Field: "artisan_email", Message: "ada@example.com is a customer, not an artisan"},
+				{Row: 4, Field: "start_time", Message: `unrecognised time "next tuesday", use RFC 3339 or YYYY-MM-DD HH:MM`},
+				{Row: 5, Field: "status", Message: "unknown booking status: lost"},
+			},
+		},
+		{
+			name: "rejects a row repeating an earlier row",
+			csv: header +
+				"ada@example.com,bo@example.com,Haircut," + at(future) + ",\n" +
+				"ada@example.com,bo@example.com,Haircut," + at(future) + ",\n",
+			wantStatus: models.BookingImportStatusCompleted,
+			wantValid:  1,
+			wantErrs:   []models.BookingImportRowError{{Row: 3, Field: "start_time", Message: "duplicates row 2"}},
+		},
+		{
+			name:       "rejects a row repeating a saved booking",
+			csv:        header + "ada@example.com,bo@example.com,Haircut," + at(past) + ",completed\n",
+			wantStatus: models.BookingImportStatusCompleted,
+			wantErrs:   []models.BookingImportRowError{{Row: 2, Field: "start_time", Message: "booking already exists"}},
+		},
+		{
+			name:       "rejects a future booking the artisan is not available for",
+			csv:        header + "ada@example.com,bo@example.com,Haircut," + at(future.Add(150*time.Minute)) + ",\n",
+			wantStatus: models.BookingImportStatusCompleted,
+			wantErrs: []models.BookingImportRowError{{Row: 2, Field: "start_time",
+				Message: "artisan already has a booking from " + at(future.Add(2*time.Hour)) + " to " + at(future.Add(3*time.Hour))}},
+		},
+		{
+			name: "rejects a future booking overlapping an earlier row",
+			csv: header +
+				"ada@example.com,bo@example.com,Haircut," + at(future) + ",\n" +
+				"ada@example.com,bo@example.com,Haircut," + at(future.Add(30*time.Minute)) + ",\n",
+			wantStatus: models.BookingImportStatusCompleted,
+			wantValid:  1,
+			wantErrs:   []models.BookingImportRowError{{Row: 3, Field: "start_time", Message: "artisan is already booked by row 2"}},
+		},
+		{
+			name:       "does not check the availability of cancelled bookings",
+			csv:        header + "ada@example.com,bo@example.com,Haircut," + at(future.Add(2*time.Hour)) + ",cancelled\n",
+			wantStatus: models.BookingImportStatusCompleted,
+			wantValid:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := &repository.Repositories{
+				User:          &stubImportUserRepository{users: map[string]*models.User{customer.Email: customer, artisan.Email: artisan}},
+				Service:       &stubImportServiceRepository{services: []*models.Service{haircut}},
+				Booking:       &stubImportBookingRepository{bookings: existing},
+				BookingImport: &stubBookingImportRepository{},
+			}
+			svc := service.NewBookingImportService(repos, &MockLogger{}, &stubSchedulingService{bookings: existing})
+
+			resp, err := svc.ImportBookings(context.Background(), &dto.BookingImportRequest{
+				TenantID:   tenantID,
+				UploadedBy: uuid.New(),
+				FileName:   "bookings.csv",
+				FileSize:   int64(len(tt.csv)),
+				DryRun:     true,
+			}, strings.NewReader(tt.csv))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			assert.Equal(t, tt.wantFailed, resp.ErrorMessage)
+			assert.Equal(t, tt.wantValid, resp.ValidRows)
+			assert.Equal(t, len(tt.wantErrs), resp.FailedRows)
+			assert.Equal(t, tt.wantErrs, resp.RowErrors)
+		})
+	}
+}
@@ -2,18 +2,25 @@ package service
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"maps"
-	"slices"
+	"math"
+	"sync"
 	"time"
 
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/events"
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
 	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/tracing"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // BookingService defines the interface for booking service operations
@@ -32,11 +39,18 @@ type BookingService interface {
 	GetBookingsByCustomer(ctx context.Context, customerID uuid.UUID, filter dto.BookingFilter) (*dto.BookingListResponse, error)
 	GetBookingsByService(ctx context.Context, serviceID uuid.UUID, filter dto.BookingFilter) (*dto.BookingListResponse, error)
 
+	// History
+	GetBookingHistory(ctx context.Context, id uuid.UUID) ([]*dto.BookingEventResponse, error)
+
+	// Projects
+	LinkBookingProject(ctx context.Context, tenantID, id uuid.UUID, req *dto.LinkBookingProjectRequest) (*dto.BookingResponse, error)
+
 	// Status Management
 	ConfirmBooking(ctx context.Context, id uuid.UUID) (*dto.BookingResponse, error)
 	StartBooking(ctx context.Context, id uuid.UUID) (*dto.BookingResponse, error)
 	CompleteBooking(ctx context.Context, id uuid.UUID, req *dto.CompleteBookingRequest) (*dto.BookingResponse, error)
 	CancelBooking(ctx context.Context, id uuid.UUID, req *dto.CancelBookingRequest) (*dto.BookingResponse, error)
+	RetryCancellationRefund(ctx context.Context, id uuid.UUID) (*dto.BookingResponse, error)
 	MarkAsNoShow(ctx context.Context, id uuid.UUID) (*dto.BookingResponse, error)
 	RescheduleBooking(ctx context.Context, id uuid.UUID, req *dto.RescheduleBookingRequest) (*dto.BookingResponse, error)
 
@@ -58,6 +72,8 @@ type BookingService interface {
 	GetRecurringBookingSeries(ctx context.Context, parentBookingID uuid.UUID) ([]*dto.BookingResponse, error)
 	UpdateRecurringSeries(ctx context.Context, parentBookingID uuid.UUID, req *dto.UpdateBookingRequest, updateFuture bool) ([]*dto.BookingResponse, error)
 	CancelRecurringSeries(ctx context.Context, parentBookingID uuid.UUID, reason string, cancelFuture bool) error
+	SkipOccurrence(ctx context.Context, id uuid.UUID, req *dto.SkipOccurrenceRequest) (*dto.BookingResponse, error)
+	MoveOccurrence(ctx context.Context, id uuid.UUID, req *dto.MoveOccurrenceRequest) (*dto.BookingResponse, error)
 
 	// Photo Management
 	AddBeforePhotos(ctx context.Context, bookingID uuid.UUID, photoURLs []string) (*dto.BookingResponse, error)
@@ -76,10 +92,10 @@ type BookingService interface {
 	GetBookingTrends(ctx context.Context, tenantID uuid.UUID, days int) ([]*dto.BookingTrendData, error)
 
 	// Bulk Operations
-	BulkConfirm(ctx context.Context, bookingIDs []uuid.UUID) ([]*dto.BookingResponse, error)
-	BulkCancel(ctx context.Context, bookingIDs []uuid.UUID, reason string) ([]*dto.BookingResponse, error)
+	BulkConfirm(ctx context.Context, tenantID uuid.UUID, bookingIDs []uuid.UUID) (*dto.BulkBookingResult, error)
+	BulkCancel(ctx context.Context, tenantID uuid.UUID, bookingIDs []uuid.UUID, reason string) (*dto.BulkBookingResult, error)
 	BulkReschedule(ctx context.Context, bookingIDs []uuid.UUID, newStartTime time.Time) ([]*dto.BookingResponse, error)
-	BulkUpdateStatus(ctx context.Context, bookingIDs []uuid.UUID, status models.BookingStatus) ([]*dto.BookingResponse, error)
+	BulkUpdateStatus(ctx context.Context, tenantID uuid.UUID, bookingIDs []uuid.UUID, status models.BookingStatus) (*dto.BulkBookingResult, error)
 
 	// Integration Points
 	NotifyBookingCreated(ctx context.Context, booking *models.Booking) error
@@ -92,21 +108,69 @@ type BookingService interface {
 	GetServiceMetrics(ctx context.Context) map[string]any
 }
 
+// RealtimePublisher pushes booking changes to connected clients
+type RealtimePublisher interface {
+	PublishBookingEvent(ctx context.Context, event *models.BookingEvent, booking *models.Booking)
+}
+
+// ScheduleLocker takes locks shared by every instance. It holds an artisan's schedule
+// while a booking is checked against it and written, so two instances can't both
+// give away the same slot.
+type ScheduleLocker interface {
+	WaitLock(ctx context.Context, name string, ttl, wait time.Duration) (*cache.Lock, error)
+}
+
+const (
+	// scheduleHoldTTL bounds how long a schedule stays held by an instance that dies
+	// holding it
+	scheduleHoldTTL = 15 * time.Second
+	// scheduleHoldWait is how long a booking waits for another one of the same artisan
+	scheduleHoldWait = 5 * time.Second
+)
+
+// bookingDetailRelations are the relations returned with bookings whose clients can't
+// choose them
+var bookingDetailRelations = []string{
+	repository.BookingRelationArtisan,
+	repository.BookingRelationCustomer,
+	repository.BookingRelationService,
+	repository.BookingRelationPayments,
+	repository.BookingRelationReview,
+	repository.BookingRelationConversation,
+}
+
 // bookingService implements BookingService
 type bookingService struct {
 	repos           *repository.Repositories
 	logger          log.AllLogger
 	customerService CustomerService
 	paymentService  PaymentService
-}
-
-// NewBookingService creates a new BookingService instance
-func NewBookingService(repos *repository.Repositories, logger log.AllLogger, customerService CustomerService, paymentService PaymentService) BookingService {
+	policies        CancellationPolicyService
+	taxes           TaxService
+	promos          PromoCodeService
+	pricing         PricingService
+	notifications   NotificationService
+	realtime        RealtimePublisher
+	locks           ScheduleLocker
+	authorizer      Authorizer
+}
+
+// NewBookingService creates a new BookingService instance; realtime and locks may be
+// nil, the latter for a single instance
+func NewBookingService(repos *repository.Repositories, logger log.AllLogger, customerService CustomerService, paymentService PaymentService, realtime RealtimePublisher, locks ScheduleLocker) BookingService {
 	return &bookingService{
 		repos:           repos,
 		logger:          logger,
 		customerService: customerService,
 		paymentService:  paymentService,
+		policies:        NewCancellationPolicyService(repos, logger),
+		taxes:           NewTaxService(repos, logger),
+		promos:          NewPromoCodeService(repos, logger),
+		pricing:         NewPricingService(repos, logger),
+		notifications:   NewNotificationService(repos, logger),
+		realtime:        realtime,
+		locks:           locks,
+		authorizer:      NewAuthorizer(repos, logger),
 	}
 }
 
@@ -115,80 +179,185 @@ func NewBookingService(repos *repository.Repositories, logger log.AllLogger, cus
 // ============================================================================
 
 // CreateBooking creates a new booking with comprehensive validation and business logic
-func (s *bookingService) CreateBooking(ctx context.Context, req *dto.CreateBookingRequest) (*dto.BookingResponse, error) {
+func (s *bookingService) CreateBooking(ctx context.Context, req *dto.CreateBookingRequest) (response *dto.BookingResponse, err error) {
+	ctx, span := tracing.Start(ctx, "booking.create", attribute.String("tenant.id", req.TenantID.String()))
+	defer func() { tracing.End(span, err) }()
+
 	if err := req.Validate(); err != nil {
 		return nil, errors.NewValidationError("invalid request: " + err.Error())
 	}
 
-	// Check artisan availability
+	// Customers may only book for themselves
+	if err := s.authorizer.Can(ctx, authz.ActionBookingCreate, authz.Resource{TenantID: req.TenantID, Owners: []uuid.UUID{req.CustomerID}}); err != nil {
+		return nil, err
+	}
+
+	// Only tenant owners and admins may knowingly double-book
+	actor := contextActor(ctx)
+	if req.OverrideAvailability && (actor == nil || !actor.CanManageTenant(req.TenantID)) {
+		return nil, errors.NewForbiddenError("only tenant owners and admins can override availability")
+	}
+
+	// Fetch service details for pricing
+	service, err := s.repos.Service.GetByID(ctx, req.ServiceID)
+	if err != nil {
+		return nil, errors.NewServiceError("SERVICE_NOT_FOUND", "service not found", err)
+	}
+	if !service.IsBookable() {
+		return nil, errors.NewValidationError("service is not available for booking")
+	}
+
+	// Validate the answers to the service's intake form
+	intakeAnswers, err := service.IntakeForm.ValidateAnswers(req.IntakeAnswers)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.checkServiceLocation(ctx, service, req.ArtisanID, req.ServiceLocation); err != nil {
+		return nil, err
+	}
+
+	tags, err := models.NormalizeBookingTags(req.Tags)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	// Dynamic pricing adjusts the service's price for when and with whom it is booked;
+	// addons priced as a percentage follow the adjusted price
+	basePrice, pricingAdjustments, err := s.pricing.PriceService(ctx, service, req.ArtisanID, req.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// Price addons; their duration extensions lengthen the booking
+	addons, err := s.priceAddons(ctx, req.TenantID, basePrice, dto.MergeAddonSelections(req.SelectedAddons, req.Addons))
+	if err != nil {
+		return nil, err
+	}
+	addonsPrice, addonMinutes := addons.Totals()
+	duration := req.Duration + addonMinutes
+	if duration > maxBookingDuration {
+		return nil, errors.NewValidationError("booking with addons cannot exceed 8 hours")
+	}
+
+	// Hold the artisan's schedule until the booking is written
+	release, err := s.holdSchedule(ctx, req.ArtisanID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Check artisan availability for the full duration
 	availabilityReq := &dto.AvailabilityRequest{
 		ArtisanID: req.ArtisanID,
 		Date:      req.StartTime,
-		Duration:  req.Duration,
+		Duration:  duration,
 		ServiceID: &req.ServiceID,
 	}
 	availability, err := s.CheckArtisanAvailability(ctx, availabilityReq)
 	if err != nil {
 		return nil, errors.NewServiceError("AVAILABILITY_CHECK_FAILED", "failed to check availability", err)
 	}
+	var overriddenConflicts []*dto.ConflictResponse
 	if !availability.IsAvailable {
-		return nil, errors.NewConflictError("artisan is not available for the requested time slot")
+		if !req.OverrideAvailability {
+			return nil, errors.NewConflictError("artisan is not available for the requested time slot")
+		}
+		overriddenConflicts = availability.Conflicts
 	}
 
-	// Fetch service details for pricing
-	service, err := s.repos.Service.GetByID(ctx, req.ServiceID)
-	if err != nil {
-		return nil, errors.NewServiceError("SERVICE_NOT_FOUND", "service not found", err)
+	// A deposit charged below is recorded by RecordDepositPayment; counting it here as well
+	// would understate the balance
+	depositPaid := req.DepositAmount
+	if req.RequiresDeposit && req.DepositAmount > 0 && req.PaymentMethodID != "" {
+		depositPaid = 0
 	}
 
-	// Calculate pricing with addons
-	totalPrice := service.Price
-	addonsPrice := float64(0)
-	for _, addonID := range req.SelectedAddons {
-		addon, err := s.repos.ServiceAddon.GetByID(ctx, addonID)
+	// Create booking model
+	booking := &models.Booking{
+		TenantID:           req.TenantID,
+		ArtisanID:          req.ArtisanID,
+		CustomerID:         req.CustomerID,
+		ServiceID:          req.ServiceID,
+		StartTime:          req.StartTime,
+		EndTime:            req.StartTime.Add(time.Duration(duration) * time.Minute),
+		Duration:           duration,
+		Status:             models.BookingStatusPending,
+		PaymentStatus:      models.PaymentStatusPending,
+		BasePrice:          basePrice,
+		PriceVersionID:     service.PriceVersionID,
+		ListPrice:          service.Price,
+		PricingAdjustments: pricingAdjustments,
+		AddonsPrice:        addonsPrice,
+		DepositPaid:        depositPaid,
+		Currency:           service.Currency,
+		Notes:              req.Notes,
+		CustomerNotes:      req.CustomerNotes,
+		SelectedAddons:     addons.IDs(),
+		Addons:             addons,
+		IntakeAnswers:      intakeAnswers,
+		Tags:               tags,
+		ServiceLocation:    req.ServiceLocation,
+		IsRecurring:        req.IsRecurring,
+		RecurrencePattern:  req.RecurrencePattern,
+		RecurrenceEndDate:  req.RecurrenceEndDate,
+		Metadata:           req.Metadata,
+	}
+	if req.ProjectID != nil {
+		project, err := s.bookingProject(ctx, booking, *req.ProjectID)
 		if err != nil {
-			s.logger.Warn("addon not found", "addon_id", addonID, "error", err)
-			continue
+			return nil, err
 		}
-		addonsPrice += addon.Price
+		booking.ProjectID = &project.ID
 	}
-	totalPrice += addonsPrice
 
-	// Create booking model
-	booking := &models.Booking{
-		TenantID:          req.TenantID,
-		ArtisanID:         req.ArtisanID,
-		CustomerID:        req.CustomerID,
-		ServiceID:         req.ServiceID,
-		StartTime:         req.StartTime,
-		EndTime:           req.StartTime.Add(time.Duration(req.Duration) * time.Minute),
-		Duration:          req.Duration,
-		Status:            models.BookingStatusPending,
-		PaymentStatus:     models.PaymentStatusPending,
-		BasePrice:         service.Price,
-		AddonsPrice:       addonsPrice,
-		TotalPrice:        totalPrice,
-		DepositPaid:       req.DepositAmount,
-		Currency:          service.Currency,
-		Notes:             req.Notes,
-		CustomerNotes:     req.CustomerNotes,
-		SelectedAddons:    req.SelectedAddons,
-		ServiceLocation:   req.ServiceLocation,
-		IsRecurring:       req.IsRecurring,
-		RecurrencePattern: req.RecurrencePattern,
-		RecurrenceEndDate: req.RecurrenceEndDate,
-		Metadata:          req.Metadata,
+	// A promo code comes off the subtotal before tax; its use is recorded against the
+	// booking, so the ID is assigned up front
+	if req.PromoCode != "" {
+		booking.ID = uuid.New()
+		if err := s.promos.RedeemForBooking(ctx, booking, req.PromoCode); err != nil {
+			return nil, err
+		}
 	}
 
+	// Tax is fixed when the booking is priced; TotalPrice includes any tax added on top
+	taxLines, err := s.taxes.CalculateTax(ctx, req.TenantID, service.TaxCategory, req.ServiceLocation, booking.Subtotal())
+	if err != nil {
+		s.releasePromoCode(ctx, booking)
+		return nil, err
+	}
+	booking.ApplyTax(taxLines)
+
 	// Auto-confirm if requested
 	if req.AutoConfirm {
 		booking.Status = models.BookingStatusConfirmed
 	}
 
+	// Schedule the remainder after the deposit to be charged before the start
+	if hours, ok := s.balanceDueHours(ctx, req); ok && booking.TotalPrice > req.DepositAmount {
+		booking.PaymentMethodID = req.PaymentMethodID
+		booking.ScheduleBalance(hours, time.Now())
+	}
+
+	// Keep a record of the double-booking on the booking itself
+	eventNote := ""
+	if len(overriddenConflicts) > 0 {
+		booking.Metadata = withAvailabilityOverride(booking.Metadata, actor, req.OverrideReason, overriddenConflicts)
+		eventNote = "Availability overridden"
+		if req.OverrideReason != "" {
+			eventNote += ": " + req.OverrideReason
+		}
+	}
+
 	// Create in repository
 	if err := s.repos.Booking.Create(ctx, booking); err != nil {
+		s.releasePromoCode(ctx, booking)
 		return nil, errors.NewServiceError("BOOKING_CREATE_FAILED", "failed to create booking", err)
 	}
+	s.recordBookingEvent(ctx, nil, booking, eventNote)
+	if len(overriddenConflicts) > 0 {
+		s.auditAvailabilityOverride(ctx, booking, actor, req.OverrideReason, overriddenConflicts)
+	}
 
 	// Handle recurring bookings
 	var recurringBookings []*models.Booking
@@ -199,6 +368,7 @@ func (s *bookingService) CreateBooking(ctx context.Context, req *dto.CreateBooki
 			// Continue with the main booking even if recurring creation fails
 		}
 	}
+	release()
 
 	// Process deposit payment if required
 	if req.RequiresDeposit && req.DepositAmount > 0 && req.PaymentMethodID != "" {
@@ -209,6 +379,12 @@ func (s *bookingService) CreateBooking(ctx context.Context, req *dto.CreateBooki
 		}
 	}
 
+	// Let the artisan know about the new booking and the customer's intake answers
+	booking.Service = service
+	if _, err := s.notifications.SendArtisanBookingNotification(ctx, booking); err != nil {
+		s.logger.Error("failed to notify artisan of new booking", "booking_id", booking.ID, "error", err)
+	}
+
 	// Send notifications if requested
 	if req.SendConfirmationEmail || req.SendConfirmationSMS {
 		if err := s.NotifyBookingCreated(ctx, booking); err != nil {
@@ -224,11 +400,11 @@ func (s *bookingService) CreateBooking(ctx context.Context, req *dto.CreateBooki
 	s.logger.Info("booking created", "booking_id", booking.ID, "tenant_id", req.TenantID, "artisan_id", req.ArtisanID, "customer_id", req.CustomerID)
 
 	// Load related entities for response
-	if err := s.loadBookingRelations(ctx, booking); err != nil {
+	if err := s.repos.Booking.LoadRelations(ctx, []*models.Booking{booking}, bookingDetailRelations...); err != nil {
 		s.logger.Warn("failed to load booking relations", "booking_id", booking.ID, "error", err)
 	}
 
-	response := dto.ToBookingResponse(booking)
+	response = dto.ToBookingResponse(booking, dto.ViewerFromContext(ctx))
 
 	// Add recurring booking count to metadata if applicable
 	if req.IsRecurring && len(recurringBookings) > 0 {
@@ -247,24 +423,28 @@ func (s *bookingService) GetBooking(ctx context.Context, id uuid.UUID) (*dto.Boo
 		return nil, errors.NewValidationError("booking ID is required")
 	}
 
-	booking, err := s.repos.Booking.GetByID(ctx, id)
+	booking, err := s.repos.Booking.GetByIDWithRelations(ctx, id, bookingDetailRelations...)
 	if err != nil {
 		if errors.IsNotFoundError(err) {
 			return nil, errors.NewNotFoundError("booking not found")
 		}
 		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
 	}
-
-	// Load related entities
-	if err := s.loadBookingRelations(ctx, booking); err != nil {
-		s.logger.Warn("failed to load booking relations", "booking_id", id, "error", err)
+	if err := s.authorizer.Can(ctx, authz.ActionBookingRead, authz.Booking(booking)); err != nil {
+		return nil, err
 	}
 
-	return dto.ToBookingResponse(booking), nil
+	return dto.ToBookingResponse(booking, dto.ViewerFromContext(ctx)), nil
 }
 
 // UpdateBooking updates an existing booking with validation
 func (s *bookingService) UpdateBooking(ctx context.Context, id uuid.UUID, req *dto.UpdateBookingRequest) (*dto.BookingResponse, error) {
+	return s.updateBooking(ctx, id, req, nil)
+}
+
+// updateBooking applies an update request, then apply, when set, to change what the
+// request cannot before the booking is saved
+func (s *bookingService) updateBooking(ctx context.Context, id uuid.UUID, req *dto.UpdateBookingRequest, apply func(*models.Booking)) (*dto.BookingResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, errors.NewValidationError("invalid request: " + err.Error())
 	}
@@ -277,12 +457,32 @@ func (s *bookingService) UpdateBooking(ctx context.Context, id uuid.UUID, req *d
 		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
 	}
 
-	// Store old status for notifications
+	// Cancelling is a permission of its own, which customers have without being able to
+	// edit; a cancellation that changes anything else needs both
+	actions := []authz.Action{authz.ActionBookingUpdate}
+	if req.IsCancellationOnly() {
+		actions = []authz.Action{authz.ActionBookingCancel}
+	} else if req.Status != nil && *req.Status == models.BookingStatusCancelled {
+		actions = append(actions, authz.ActionBookingCancel)
+	}
+	for _, action := range actions {
+		if err := s.authorizer.Can(ctx, action, authz.Booking(booking)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Reject edits made against a stale version so concurrent changes are not overwritten
+	if req.Version != nil && *req.Version != booking.Version {
+		return nil, errors.NewVersionConflictError("booking", *req.Version, booking.Version, bookingUpdateConflicts(booking, req))
+	}
+
+	// Store old status for notifications and the previous version for the change log
 	oldStatus := booking.Status
+	before := *booking
 
-	// Validate status transitions
-	if req.Status != nil {
-		if err := s.validateStatusTransition(booking.Status, *req.Status); err != nil {
+	// Validate status transitions (re-applying the current status is a no-op)
+	if req.Status != nil && *req.Status != booking.Status {
+		if err := s.validateStatusTransition(ctx, booking, *req.Status); err != nil {
 			return nil, errors.NewValidationError("invalid status transition: " + err.Error())
 		}
 		booking.Status = *req.Status
@@ -302,6 +502,8 @@ func (s *bookingService) UpdateBooking(ctx context.Context, id uuid.UUID, req *d
 			if req.CancellationReason != nil {
 				booking.CancellationReason = *req.CancellationReason
 			}
+		case models.BookingStatusRefunded:
+			booking.PaymentStatus = models.PaymentStatusRefunded
 		}
 	}
 
@@ -316,25 +518,82 @@ func (s *bookingService) UpdateBooking(ctx context.Context, id uuid.UUID, req *d
 		booking.InternalNotes = *req.InternalNotes
 	}
 	if req.ServiceLocation != nil {
+		service, err := s.repos.Service.GetByID(ctx, booking.ServiceID)
+		if err != nil {
+			return nil, errors.NewServiceError("SERVICE_NOT_FOUND", "service not found", err)
+		}
+		if err := s.checkServiceLocation(ctx, service, booking.ArtisanID, req.ServiceLocation); err != nil {
+			return nil, err
+		}
 		booking.ServiceLocation = req.ServiceLocation
 	}
+	if req.IntakeAnswers != nil {
+		service, err := s.repos.Service.GetByID(ctx, booking.ServiceID)
+		if err != nil {
+			return nil, errors.NewServiceError("SERVICE_NOT_FOUND", "service not found", err)
+		}
+		answers, err := service.IntakeForm.ValidateAnswers(req.IntakeAnswers)
+		if err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+		booking.IntakeAnswers = answers
+	}
+	if req.Tags != nil {
+		tags, err := models.NormalizeBookingTags(*req.Tags)
+		if err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+		booking.Tags = tags
+	}
 	if req.PaymentStatus != nil {
 		booking.PaymentStatus = *req.PaymentStatus
 	}
-	if len(req.SelectedAddons) > 0 {
-		// Recalculate pricing if addons changed
-		addonsPrice := float64(0)
-		for _, addonID := range req.SelectedAddons {
-			addon, err := s.repos.ServiceAddon.GetByID(ctx, addonID)
+	if req.HasAddonChanges() {
+		// Reprice the addons and re-derive the duration from the booking's base length
+		addons, err := s.priceAddons(ctx, booking.TenantID, booking.BasePrice, dto.MergeAddonSelections(req.SelectedAddons, req.Addons))
+		if err != nil {
+			return nil, err
+		}
+		_, oldAddonMinutes := booking.Addons.Totals()
+		addonsPrice, addonMinutes := addons.Totals()
+		duration := booking.Duration - oldAddonMinutes + addonMinutes
+		if duration > maxBookingDuration {
+			return nil, errors.NewValidationError("booking with addons cannot exceed 8 hours")
+		}
+
+		// A longer booking must still fit the artisan's schedule
+		if duration > booking.Duration {
+			availability, err := s.CheckArtisanAvailability(ctx, &dto.AvailabilityRequest{
+				ArtisanID:        booking.ArtisanID,
+				Date:             booking.StartTime,
+				Duration:         duration,
+				ServiceID:        &booking.ServiceID,
+				ExcludeBookingID: &booking.ID,
+			})
 			if err != nil {
-				s.logger.Warn("addon not found", "addon_id", addonID, "error", err)
-				continue
+				return nil, errors.NewServiceError("AVAILABILITY_CHECK_FAILED", "failed to check availability", err)
+			}
+			if !availability.IsAvailable {
+				return nil, errors.NewConflictError("artisan is not available for the extended booking")
 			}
-			addonsPrice += addon.Price
 		}
-		booking.SelectedAddons = req.SelectedAddons
+
+		booking.Addons = addons
+		booking.SelectedAddons = addons.IDs()
 		booking.AddonsPrice = addonsPrice
-		booking.TotalPrice = booking.BasePrice + addonsPrice
+		booking.Duration = duration
+		booking.EndTime = booking.StartTime.Add(time.Duration(duration) * time.Minute)
+
+		// Retax the new subtotal under the service's category
+		category := models.TaxCategoryStandard
+		if service, err := s.repos.Service.GetByID(ctx, booking.ServiceID); err == nil {
+			category = service.TaxCategory
+		}
+		taxLines, err := s.taxes.CalculateTax(ctx, booking.TenantID, category, booking.ServiceLocation, booking.Subtotal())
+		if err != nil {
+			return nil, err
+		}
+		booking.ApplyTax(taxLines)
 	}
 	if req.PaymentIntentID != nil {
 		booking.PaymentIntentID = *req.PaymentIntentID
@@ -360,12 +619,24 @@ func (s *bookingService) UpdateBooking(ctx context.Context, id uuid.UUID, req *d
 		}
 		maps.Copy(booking.Metadata, req.Metadata)
 	}
+	if apply != nil {
+		apply(booking)
+	}
 
 	// Update in repository
 	if err := s.repos.Booking.Update(ctx, booking); err != nil {
+		if errors.IsConflict(err) {
+			return nil, s.bookingVersionConflict(ctx, id, before.Version, req)
+		}
 		return nil, errors.NewServiceError("BOOKING_UPDATE_FAILED", "failed to update booking", err)
 	}
 
+	var note string
+	if req.CancellationReason != nil {
+		note = *req.CancellationReason
+	}
+	s.recordBookingEvent(ctx, &before, booking, note)
+
 	// Send notifications if status changed
 	if req.Status != nil && oldStatus != *req.Status {
 		if err := s.NotifyBookingUpdated(ctx, booking, oldStatus); err != nil {
@@ -384,11 +655,11 @@ func (s *bookingService) UpdateBooking(ctx context.Context, id uuid.UUID, req *d
 	s.logger.Info("booking updated", "booking_id", id)
 
 	// Load related entities for response
-	if err := s.loadBookingRelations(ctx, booking); err != nil {
+	if err := s.repos.Booking.LoadRelations(ctx, []*models.Booking{booking}, bookingDetailRelations...); err != nil {
 		s.logger.Warn("failed to load booking relations", "booking_id", id, "error", err)
 	}
 
-	return dto.ToBookingResponse(booking), nil
+	return dto.ToBookingResponse(booking, dto.ViewerFromContext(ctx)), nil
 }
 
 // DeleteBooking soft deletes a booking
@@ -400,6 +671,9 @@ func (s *bookingService) DeleteBooking(ctx context.Context, id uuid.UUID) error
 		}
 		return errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
 	}
+	if err := s.authorizer.Can(ctx, authz.ActionBookingDelete, authz.Booking(booking)); err != nil {
+		return err
+	}
 
 	// Validate that booking can be deleted
 	if booking.Status == models.BookingStatusInProgress {
@@ -416,16 +690,22 @@ func (s *bookingService) DeleteBooking(ctx context.Context, id uuid.UUID) error
 
 // ListBookings retrieves bookings with filters and pagination
 func (s *bookingService) ListBookings(ctx context.Context, filter dto.BookingFilter) (*dto.BookingListResponse, error) {
+	filter, err := resolveSavedBookingFilter(ctx, s.repos, filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	if err := filter.Validate(); err != nil {
 		return nil, errors.NewValidationError("invalid filter: " + err.Error())
 	}
 
 	// Convert DTO filter to repository filter
-	repoFilter := s.convertToRepoFilter(filter)
+	repoFilter := toBookingRepoFilter(filter)
 
 	pagination := repository.PaginationParams{
 		Page:     filter.Page,
 		PageSize: filter.PageSize,
+		Cursor:   filter.Cursor,
 	}
 
 	bookings, paginationResult, err := s.repos.Booking.FindByFilters(ctx, repoFilter, pagination)
@@ -433,26 +713,16 @@ func (s *bookingService) ListBookings(ctx context.Context, filter dto.BookingFil
 		return nil, errors.NewServiceError("BOOKINGS_LIST_FAILED", "failed to list bookings", err)
 	}
 
-	// Load related entities for included relations
-	for _, booking := range bookings {
-		if err := s.loadBookingRelationsSelective(ctx, booking, filter.IncludeRelations); err != nil {
-			s.logger.Warn("failed to load booking relations", "booking_id", booking.ID, "error", err)
-		}
-	}
-
-	// Calculate pagination manually
-	totalPages := int((paginationResult.TotalItems + int64(paginationResult.PageSize) - 1) / int64(paginationResult.PageSize))
-	hasNext := paginationResult.Page < totalPages
-	hasPrevious := paginationResult.Page > 1
-
 	return &dto.BookingListResponse{
-		Bookings:    dto.ToBookingResponses(bookings),
+		Bookings:    dto.ToBookingResponses(bookings, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
-		TotalPages:  totalPages,
-		HasNext:     hasNext,
-		HasPrevious: hasPrevious,
+		TotalPages:  paginationResult.TotalPages,
+		HasNext:     paginationResult.HasNext,
+		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -499,7 +769,10 @@ func (s *bookingService) CompleteBooking(ctx context.Context, id uuid.UUID, req
 }
 
 // CancelBooking cancels a booking with reason and refund processing
-func (s *bookingService) CancelBooking(ctx context.Context, id uuid.UUID, req *dto.CancelBookingRequest) (*dto.BookingResponse, error) {
+func (s *bookingService) CancelBooking(ctx context.Context, id uuid.UUID, req *dto.CancelBookingRequest) (response *dto.BookingResponse, err error) {
+	ctx, span := tracing.Start(ctx, "booking.cancel", attribute.String("booking.id", id.String()))
+	defer func() { tracing.End(span, err) }()
+
 	if err := req.Validate(); err != nil {
 		return nil, errors.NewValidationError("invalid request: " + err.Error())
 	}
@@ -511,35 +784,58 @@ func (s *bookingService) CancelBooking(ctx context.Context, id uuid.UUID, req *d
 		}
 		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
 	}
+	if err := s.authorizer.Can(ctx, authz.ActionBookingCancel, authz.Booking(booking)); err != nil {
+		return nil, err
+	}
 
-	// Check if booking can be cancelled
-	if !booking.CanBeCancelled() {
-		return nil, errors.NewConflictError("booking cannot be cancelled")
+	// Check the tenant's transition policy lets the booking be cancelled
+	if err := s.validateStatusTransition(ctx, booking, models.BookingStatusCancelled); err != nil {
+		return nil, errors.NewConflictError("booking cannot be cancelled: " + err.Error())
 	}
 
-	// Process refund if requested
-	if req.RefundRequested && booking.DepositPaid > 0 {
-		refundAmount := booking.CalculateRefundAmount()
-		if refundAmount > 0 {
-			_, err := s.ProcessRefund(ctx, id, refundAmount, req.Reason)
-			if err != nil {
-				s.logger.Error("failed to process refund", "booking_id", id, "error", err)
-				// Continue with cancellation even if refund fails
-			}
+	// Work out the refund the tenant's cancellation policy owes for cancelling now
+	var refund float64
+	if req.RefundRequested && booking.AmountPaid() > 0 {
+		breakdown, err := s.policies.EvaluateCancellation(ctx, booking, time.Now())
+		if err != nil {
+			s.logger.Error("failed to evaluate cancellation policy", "booking_id", id, "error", err)
+		} else {
+			refund = breakdown.RefundAmount
 		}
 	}
 
-	// Update booking status
+	// Save the cancellation, with the refund it is owed, against the version checked above,
+	// so a concurrent change or a second cancel fails here before anything is refunded
 	status := models.BookingStatusCancelled
+	version := booking.Version
 	updateReq := &dto.UpdateBookingRequest{
+		Version:            &version,
 		Status:             &status,
 		CancellationReason: &req.Reason,
 	}
-
-	response, err := s.UpdateBooking(ctx, id, updateReq)
+	response, err = s.updateBooking(ctx, id, updateReq, func(cancelled *models.Booking) {
+		if refund > 0 {
+			cancelled.RefundStatus = models.RefundStatusPending
+			cancelled.RefundAmount = refund
+		}
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.releasePromoCode(ctx, booking)
+
+	// The policy entitles whoever may cancel to the refund, so it is issued by the platform
+	// rather than needing the actor to be allowed to refund payments. A refund that fails
+	// stays on the booking for RetryCancellationRefund.
+	if refund > 0 {
+		refunded, err := s.issueCancellationRefund(authz.WithoutActor(ctx), id, req.Reason)
+		if err != nil {
+			s.logger.Error("failed to issue cancellation refund", "booking_id", id, "error", err)
+		}
+		if refunded != nil {
+			response = s.bookingResponse(ctx, refunded)
+		}
+	}
 
 	// Send notifications if requested
 	if req.NotifyCustomer || req.NotifyArtisan {
@@ -551,6 +847,91 @@ func (s *bookingService) CancelBooking(ctx context.Context, id uuid.UUID, req *d
 	return response, nil
 }
 
+// RetryCancellationRefund issues the part of a cancelled booking's refund that failed
+func (s *bookingService) RetryCancellationRefund(ctx context.Context, id uuid.UUID) (*dto.BookingResponse, error) {
+	booking, err := s.repos.Booking.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("booking not found")
+		}
+		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
+	}
+	if err := s.authorizer.Can(ctx, authz.ActionPaymentRefund, authz.Booking(booking)); err != nil {
+		return nil, err
+	}
+	if booking.RefundStatus != models.RefundStatusFailed && booking.RefundStatus != models.RefundStatusPending {
+		return nil, errors.NewConflictError("booking has no cancellation refund outstanding")
+	}
+
+	refunded, err := s.issueCancellationRefund(ctx, id, booking.CancellationReason)
+	if err != nil {
+		return nil, errors.NewServiceError("REFUND_FAILED", "failed to issue cancellation refund", err)
+	}
+	return s.bookingResponse(ctx, refunded), nil
+}
+
+// issueCancellationRefund issues the part of a cancelled booking's refund still
+// outstanding and records the outcome on the booking. The booking is returned once the
+// outcome is saved, along with any error issuing the refund.
+func (s *bookingService) issueCancellationRefund(ctx context.Context, id uuid.UUID, reason string) (*models.Booking, error) {
+	booking, err := s.repos.Booking.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
+	}
+	if reason == "" {
+		reason = "booking cancelled"
+	}
+
+	issued, refundErr := s.refundPayments(ctx, booking, booking.RefundOutstanding(), reason)
+	booking.RecordRefundAttempt(issued, refundErr)
+	if err := s.repos.Booking.Update(ctx, booking); err != nil {
+		s.logger.Error("failed to record cancellation refund", "booking_id", id, "issued", issued, "error", err)
+		return nil, errors.NewServiceError("BOOKING_UPDATE_FAILED", "failed to record cancellation refund", err)
+	}
+
+	s.logger.Info("cancellation refund attempted", "booking_id", id, "issued", issued, "status", booking.RefundStatus)
+	return booking, refundErr
+}
+
+// refundPayments refunds up to amount across the booking's refundable payments,
+// returning how much was refunded before any failure. Bookings paid outside the
+// platform have no payments, so their refund is only recorded.
+func (s *bookingService) refundPayments(ctx context.Context, booking *models.Booking, amount float64, reason string) (float64, error) {
+	if s.paymentService == nil {
+		return amount, nil
+	}
+
+	payments, err := s.repos.Payment.GetRefundablePayments(ctx, booking.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(payments) == 0 {
+		s.logger.Warn("booking has no refundable payments; recording refund only", "booking_id", booking.ID)
+		return amount, nil
+	}
+
+	var refunded float64
+	for _, payment := range payments {
+		part := math.Min(amount-refunded, payment.GetRefundableAmount())
+		if part < 0.01 {
+			continue
+		}
+		if _, err := s.paymentService.ProcessRefund(ctx, payment.ID, part, reason); err != nil {
+			return refunded, err
+		}
+		refunded += part
+	}
+	return refunded, nil
+}
+
+// bookingResponse renders a booking with its related entities for the request's viewer
+func (s *bookingService) bookingResponse(ctx context.Context, booking *models.Booking) *dto.BookingResponse {
+	if err := s.repos.Booking.LoadRelations(ctx, []*models.Booking{booking}, bookingDetailRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "booking_id", booking.ID, "error", err)
+	}
+	return dto.ToBookingResponse(booking, dto.ViewerFromContext(ctx))
+}
+
 // MarkAsNoShow marks a booking as no-show
 func (s *bookingService) MarkAsNoShow(ctx context.Context, id uuid.UUID) (*dto.BookingResponse, error) {
 	status := models.BookingStatusNoShow
@@ -561,7 +942,10 @@ func (s *bookingService) MarkAsNoShow(ctx context.Context, id uuid.UUID) (*dto.B
 }
 
 // RescheduleBooking reschedules a booking to a new time
-func (s *bookingService) RescheduleBooking(ctx context.Context, id uuid.UUID, req *dto.RescheduleBookingRequest) (*dto.BookingResponse, error) {
+func (s *bookingService) RescheduleBooking(ctx context.Context, id uuid.UUID, req *dto.RescheduleBookingRequest) (response *dto.BookingResponse, err error) {
+	ctx, span := tracing.Start(ctx, "booking.reschedule", attribute.String("booking.id", id.String()))
+	defer func() { tracing.End(span, err) }()
+
 	if err := req.Validate(); err != nil {
 		return nil, errors.NewValidationError("invalid request: " + err.Error())
 	}
@@ -573,11 +957,15 @@ func (s *bookingService) RescheduleBooking(ctx context.Context, id uuid.UUID, re
 		}
 		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
 	}
+	if err := s.authorizer.Can(ctx, authz.ActionBookingUpdate, authz.Booking(booking)); err != nil {
+		return nil, err
+	}
 
 	// Check if booking can be rescheduled
 	if booking.Status == models.BookingStatusCompleted || booking.Status == models.BookingStatusCancelled {
 		return nil, errors.NewConflictError("cannot reschedule completed or cancelled booking")
 	}
+	before := *booking
 
 	// Use new duration if provided, otherwise keep existing
 	duration := booking.Duration
@@ -585,6 +973,13 @@ func (s *bookingService) RescheduleBooking(ctx context.Context, id uuid.UUID, re
 		duration = *req.NewDuration
 	}
 
+	// Hold the artisan's schedule until the booking is moved
+	release, err := s.holdSchedule(ctx, booking.ArtisanID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Check availability for new time slot
 	availabilityReq := &dto.AvailabilityRequest{
 		ArtisanID:        booking.ArtisanID,
@@ -604,88 +999,479 @@ func (s *bookingService) RescheduleBooking(ctx context.Context, id uuid.UUID, re
 	booking.StartTime = req.NewStartTime
 	booking.EndTime = req.NewStartTime.Add(time.Duration(duration) * time.Minute)
 	booking.Duration = duration
+	booking.RescheduleBalance(time.Now())
+
+	// Recalculate pricing if duration changed and service pricing is duration-based
+	if req.NewDuration != nil {
+		// This would depend on your pricing model
+		// For now, we'll keep the same price
+	}
+
+	// Add reschedule reason to metadata
+	if booking.Metadata == nil {
+		booking.Metadata = make(map[string]any)
+	}
+	if req.Reason != "" {
+		booking.Metadata["reschedule_reason"] = req.Reason
+	}
+	booking.Metadata["reschedule_count"] = getIntFromMetadata(booking.Metadata, "reschedule_count") + 1
+
+	if err := s.repos.Booking.Update(ctx, booking); err != nil {
+		return nil, errors.NewServiceError("BOOKING_UPDATE_FAILED", "failed to reschedule booking", err)
+	}
+	release()
+	s.recordBookingEvent(ctx, &before, booking, req.Reason)
+
+	// Send notifications if requested
+	if req.NotifyCustomer || req.NotifyArtisan {
+		if err := s.NotifyBookingUpdated(ctx, booking, booking.Status); err != nil {
+			s.logger.Error("failed to send reschedule notifications", "booking_id", id, "error", err)
+		}
+	}
+
+	s.logger.Info("booking rescheduled", "booking_id", id, "new_start_time", req.NewStartTime)
+
+	// Load related entities for response
+	if err := s.repos.Booking.LoadRelations(ctx, []*models.Booking{booking}, bookingDetailRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "booking_id", id, "error", err)
+	}
+
+	return dto.ToBookingResponse(booking, dto.ViewerFromContext(ctx)), nil
+}
+
+// holdSchedule holds an artisan's schedule on every instance until the returned
+// release is called; release may be called more than once. Without a lock service, or
+// when it is unreachable, nothing is held and concurrent bookings are left to the
+// availability check.
+func (s *bookingService) holdSchedule(ctx context.Context, artisanID uuid.UUID) (func(), error) {
+	if s.locks == nil {
+		return func() {}, nil
+	}
+
+	lock, err := s.locks.WaitLock(ctx, "booking:artisan:"+artisanID.String(), scheduleHoldTTL, scheduleHoldWait)
+	if stderrors.Is(err, cache.ErrLockNotAcquired) {
+		return nil, errors.NewConflictError("another booking for this artisan is in progress, please retry")
+	}
+	if err != nil {
+		s.logger.Warn("failed to hold artisan schedule", "artisan_id", artisanID, "error", err)
+		return func() {}, nil
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if err := lock.Release(context.WithoutCancel(ctx)); err != nil && !stderrors.Is(err, cache.ErrLockLost) {
+				s.logger.Warn("failed to release artisan schedule", "artisan_id", artisanID, "error", err)
+			}
+		})
+	}, nil
+}
+
+// bookingVersionConflict builds the conflict error for an update that lost a race with another writer
+func (s *bookingService) bookingVersionConflict(ctx context.Context, id uuid.UUID, expectedVersion int, req *dto.UpdateBookingRequest) error {
+	current, err := s.repos.Booking.GetByID(ctx, id)
+	if err != nil {
+		return errors.NewConflictError("booking was modified by another user")
+	}
+	return errors.NewVersionConflictError("booking", expectedVersion, current.Version, bookingUpdateConflicts(current, req))
+}
+
+// bookingUpdateConflicts lists the submitted fields whose stored value differs from the submitted one
+func bookingUpdateConflicts(booking *models.Booking, req *dto.UpdateBookingRequest) []errors.FieldConflict {
+	var conflicts fieldConflicts
+	checkConflict(&conflicts, "status", req.Status, booking.Status)
+	checkConflict(&conflicts, "payment_status", req.PaymentStatus, booking.PaymentStatus)
+	checkConflict(&conflicts, "notes", req.Notes, booking.Notes)
+	checkConflict(&conflicts, "customer_notes", req.CustomerNotes, booking.CustomerNotes)
+	checkConflict(&conflicts, "internal_notes", req.InternalNotes, booking.InternalNotes)
+	checkConflict(&conflicts, "cancellation_reason", req.CancellationReason, booking.CancellationReason)
+	checkConflict(&conflicts, "payment_intent_id", req.PaymentIntentID, booking.PaymentIntentID)
+	checkConflict(&conflicts, "refund_id", req.RefundID, booking.RefundID)
+	checkConflict(&conflicts, "reminder_sent_24h", req.ReminderSent24h, booking.ReminderSent24h)
+	checkConflict(&conflicts, "reminder_sent_1h", req.ReminderSent1h, booking.ReminderSent1h)
+	if req.ServiceLocation != nil {
+		checkConflict(&conflicts, "service_location", &req.ServiceLocation, booking.ServiceLocation)
+	}
+	if len(req.SelectedAddons) > 0 {
+		checkConflict(&conflicts, "selected_addons", &req.SelectedAddons, booking.SelectedAddons)
+	}
+	if len(req.Addons) > 0 {
+		current := make([]dto.BookingAddonRequest, len(booking.Addons))
+		for i, addon := range booking.Addons {
+			current[i] = dto.BookingAddonRequest{AddonID: addon.AddonID, Quantity: addon.Quantity}
+		}
+		checkConflict(&conflicts, "addons", &req.Addons, current)
+	}
+	if req.Tags != nil {
+		tags, _ := models.NormalizeBookingTags(*req.Tags)
+		checkConflict(&conflicts, "tags", &tags, booking.Tags)
+	}
+	if len(req.BeforePhotoURLs) > 0 {
+		checkConflict(&conflicts, "before_photo_urls", &req.BeforePhotoURLs, booking.BeforePhotoURLs)
+	}
+	if len(req.AfterPhotoURLs) > 0 {
+		checkConflict(&conflicts, "after_photo_urls", &req.AfterPhotoURLs, booking.AfterPhotoURLs)
+	}
+	for key, value := range req.Metadata {
+		checkConflict(&conflicts, "metadata."+key, &value, booking.Metadata[key])
+	}
+	return conflicts
+}
+
+// ============================================================================
+// Booking History
+// ============================================================================
+
+// GetBookingHistory returns the booking's change log, oldest first
+func (s *bookingService) GetBookingHistory(ctx context.Context, id uuid.UUID) ([]*dto.BookingEventResponse, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("booking ID is required")
+	}
+
+	booking, err := s.repos.Booking.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("booking not found")
+		}
+		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
+	}
+	if err := s.authorizer.Can(ctx, authz.ActionBookingRead, authz.Booking(booking)); err != nil {
+		return nil, err
+	}
+
+	events, err := s.repos.BookingEvent.FindByBookingID(ctx, id)
+	if err != nil {
+		return nil, errors.NewServiceError("HISTORY_GET_FAILED", "failed to get booking history", err)
+	}
+
+	return dto.ToBookingEventResponses(events), nil
+}
+
+// ============================================================================
+// Projects
+// ============================================================================
+
+// LinkBookingProject links a booking to a project of the same customer, or unlinks it
+// when no project is given. The change is recorded in the booking's change log.
+func (s *bookingService) LinkBookingProject(ctx context.Context, tenantID, id uuid.UUID, req *dto.LinkBookingProjectRequest) (*dto.BookingResponse, error) {
+	booking, err := s.repos.Booking.GetByID(ctx, id)
+	if err != nil || booking.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("booking")
+	}
+
+	before := *booking
+	booking.ProjectID = nil
+	if req.ProjectID != nil {
+		project, err := s.bookingProject(ctx, booking, *req.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		booking.ProjectID = &project.ID
+	}
+
+	if err := s.repos.Booking.Update(ctx, booking); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("booking was modified by another request")
+		}
+		return nil, errors.NewServiceError("BOOKING_UPDATE_FAILED", "failed to update booking", err)
+	}
+	s.recordBookingEvent(ctx, &before, booking, "")
+
+	s.logger.Info("booking project link updated", "booking_id", id, "project_id", booking.ProjectID)
+	return dto.ToBookingResponse(booking, dto.ViewerFromContext(ctx)), nil
+}
+
+// bookingProject loads a project a booking can be linked to: one of the booking's
+// tenant that is still open and, when it has a customer, is for the booking's customer
+func (s *bookingService) bookingProject(ctx context.Context, booking *models.Booking, projectID uuid.UUID) (*models.Project, error) {
+	project, err := s.repos.Project.GetByID(ctx, projectID)
+	if err != nil || project.TenantID != booking.TenantID {
+		return nil, errors.NewNotFoundError("project")
+	}
+	if project.Status == models.ProjectStatusCancelled {
+		return nil, errors.NewValidationError("bookings cannot be linked to a cancelled project")
+	}
+
+	// Project customers are customer profiles; booking customers are users
+	if project.CustomerID != nil {
+		customer, err := s.repos.Customer.GetByID(ctx, *project.CustomerID)
+		if err != nil {
+			return nil, errors.NewServiceError("CUSTOMER_GET_FAILED", "failed to get project customer", err)
+		}
+		if customer.UserID != booking.CustomerID {
+			return nil, errors.NewValidationError("the project belongs to a different customer")
+		}
+	}
+	return project, nil
+}
+
+// recordBookingEvent writes a change log entry for the audited fields that changed,
+// together with the domain events of the change, and pushes it to realtime clients. A
+// nil before records the creation of the booking. Failures are logged, never returned.
+func (s *bookingService) recordBookingEvent(ctx context.Context, before, after *models.Booking, note string) {
+	var event *models.BookingEvent
+	if before == nil {
+		event = newBookingEvent(ctx, after, models.BookingEventCreated, createdBookingChanges(after), note)
+	} else {
+		changes := models.DiffBookings(before, after)
+		if len(changes) == 0 {
+			return
+		}
+		event = newBookingEvent(ctx, after, changes.EventType(), changes, note)
+	}
+
+	if err := s.saveBookingEvents(ctx, []*models.BookingEvent{event}, events.BookingChanges(before, after, note)); err != nil {
+		s.logger.Error("failed to record booking event", "booking_id", after.ID, "event_type", event.EventType, "error", err)
+	}
+
+	if s.realtime != nil {
+		s.realtime.PublishBookingEvent(context.WithoutCancel(ctx), event, after)
+	}
+}
+
+// trackBookingChanges records change log entries for bookings modified by a repository
+// operation that does not hand back the updated models
+func (s *bookingService) trackBookingChanges(ctx context.Context, ids []uuid.UUID, note string, apply func() error) error {
+	before := make(map[uuid.UUID]models.Booking, len(ids))
+	for _, id := range ids {
+		if booking, err := s.repos.Booking.GetByID(ctx, id); err == nil {
+			before[id] = *booking
+		}
+	}
+
+	if err := apply(); err != nil {
+		return err
+	}
+
+	for id, prev := range before {
+		after, err := s.repos.Booking.GetByID(ctx, id)
+		if err != nil {
+			s.logger.Warn("failed to reload booking for change log", "booking_id", id, "error", err)
+			continue
+		}
+		s.recordBookingEvent(ctx, &prev, after, note)
+	}
+	return nil
+}
+
+// newBookingEvent builds a change log entry attributed to the user behind the request
+func newBookingEvent(ctx context.Context, booking *models.Booking, eventType models.BookingEventType, changes models.BookingFieldChanges, note string) *models.BookingEvent {
+	event := &models.BookingEvent{
+		TenantID:  booking.TenantID,
+		BookingID: booking.ID,
+		Source:    models.BookingEventSourceSystem,
+		EventType: eventType,
+		Changes:   changes,
+		Note:      note,
+	}
+
+	if actor := contextActor(ctx); actor != nil {
+		event.ActorID = &actor.ID
+		event.ActorEmail = actor.Email
+		event.ActorRole = actor.Role
+		event.Source = models.BookingEventSourceAPI
+		if actor.CanManageTenant(booking.TenantID) {
+			event.Source = models.BookingEventSourceAdmin
+		}
+	}
+
+	return event
+}
+
+// contextActor returns the authenticated user making the request, if any. Handlers pass the
+// fasthttp request context, whose values are the Fiber locals populated by the auth middleware.
+func contextActor(ctx context.Context) *models.User {
+	if actor, ok := ctx.Value("db_user").(*models.User); ok && actor != nil {
+		return actor
+	}
+	return nil
+}
+
+// checkServiceLocation fails when a mobile service is booked without coordinates of
+// where to perform it, or for a place the artisan doesn't travel to
+func (s *bookingService) checkServiceLocation(ctx context.Context, service *models.Service, artisanID uuid.UUID, location *models.Location) error {
+	if !service.IsMobile {
+		return nil
+	}
+	if !location.HasCoordinates() {
+		return errors.NewValidationError("a mobile service needs a service location with coordinates")
+	}
+
+	serves, err := s.repos.Artisan.ServesLocation(ctx, artisanID, location.Latitude, location.Longitude)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return errors.NewNotFoundError("artisan")
+		}
+		return errors.NewServiceError("QUERY_FAILED", "failed to check the artisan's service area", err)
+	}
+	if !serves {
+		return errors.NewValidationError("the service location is outside the artisan's service area")
+	}
+	return nil
+}
+
+// withAvailabilityOverride copies the booking metadata and records who overrode which conflicts
+func withAvailabilityOverride(metadata models.JSONB, actor *models.User, reason string, conflicts []*dto.ConflictResponse) models.JSONB {
+	result := make(models.JSONB, len(metadata)+1)
+	for key, value := range metadata {
+		result[key] = value
+	}
 
-	// Recalculate pricing if duration changed and service pricing is duration-based
-	if req.NewDuration != nil {
-		// This would depend on your pricing model
-		// For now, we'll keep the same price
+	result["availability_override"] = map[string]any{
+		"overridden_by": actor.ID,
+		"overridden_at": time.Now().UTC(),
+		"reason":        reason,
+		"conflicts":     overrideConflicts(conflicts),
 	}
+	return result
+}
 
-	// Add reschedule reason to metadata
-	if booking.Metadata == nil {
-		booking.Metadata = make(map[string]any)
+// releasePromoCode gives back the promo code use of a booking that was not made or was
+// cancelled; the booking keeps its discount on record
+func (s *bookingService) releasePromoCode(ctx context.Context, booking *models.Booking) {
+	if booking.PromoCodeID == nil {
+		return
 	}
-	if req.Reason != "" {
-		booking.Metadata["reschedule_reason"] = req.Reason
+	if err := s.promos.ReleaseForBooking(ctx, booking.ID); err != nil {
+		s.logger.Error("failed to release promo code", "booking_id", booking.ID, "error", err)
 	}
-	booking.Metadata["reschedule_count"] = getIntFromMetadata(booking.Metadata, "reschedule_count") + 1
+}
 
-	if err := s.repos.Booking.Update(ctx, booking); err != nil {
-		return nil, errors.NewServiceError("BOOKING_UPDATE_FAILED", "failed to reschedule booking", err)
+// auditAvailabilityOverride writes an audit log entry for a booking created over conflicts
+func (s *bookingService) auditAvailabilityOverride(ctx context.Context, booking *models.Booking, actor *models.User, reason string, conflicts []*dto.ConflictResponse) {
+	entry := &models.AuditLog{
+		TenantID:    &booking.TenantID,
+		UserID:      &actor.ID,
+		UserEmail:   actor.Email,
+		UserRole:    actor.Role,
+		Action:      models.AuditActionOverride,
+		EntityType:  "bookings",
+		EntityID:    booking.ID,
+		Description: fmt.Sprintf("Booking created over %d conflicting booking(s)", len(conflicts)),
+		NewValues: models.JSONB{
+			"artisan_id": booking.ArtisanID,
+			"start_time": booking.StartTime,
+			"end_time":   booking.EndTime,
+		},
+		Metadata: models.JSONB{
+			"reason":    reason,
+			"conflicts": overrideConflicts(conflicts),
+		},
 	}
 
-	// Send notifications if requested
-	if req.NotifyCustomer || req.NotifyArtisan {
-		if err := s.NotifyBookingUpdated(ctx, booking, booking.Status); err != nil {
-			s.logger.Error("failed to send reschedule notifications", "booking_id", id, "error", err)
+	if err := s.repos.AuditLog.Create(ctx, entry); err != nil {
+		s.logger.Error("failed to audit availability override", "booking_id", booking.ID, "error", err)
+	}
+}
+
+// overrideConflicts summarises the overridden conflicts for metadata and audit entries
+func overrideConflicts(conflicts []*dto.ConflictResponse) []map[string]any {
+	summary := make([]map[string]any, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		entry := map[string]any{
+			"type":       conflict.ConflictType,
+			"start_time": conflict.StartTime,
+			"end_time":   conflict.EndTime,
+			"reason":     conflict.Reason,
+		}
+		if conflict.BookingID != nil {
+			entry["booking_id"] = *conflict.BookingID
 		}
+		summary = append(summary, entry)
 	}
+	return summary
+}
 
-	s.logger.Info("booking rescheduled", "booking_id", id, "new_start_time", req.NewStartTime)
+// createdBookingChanges lists the initial values of the audited fields
+func createdBookingChanges(booking *models.Booking) models.BookingFieldChanges {
+	changes := models.DiffBookings(&models.Booking{}, booking)
+	for field, change := range changes {
+		changes[field] = models.BookingFieldChange{To: change.To}
+	}
+	return changes
+}
 
-	// Load related entities for response
-	if err := s.loadBookingRelations(ctx, booking); err != nil {
-		s.logger.Warn("failed to load booking relations", "booking_id", id, "error", err)
+// balanceDueHours returns how long before the start the balance of a deposit booking is charged,
+// and false when the balance is left for manual collection
+func (s *bookingService) balanceDueHours(ctx context.Context, req *dto.CreateBookingRequest) (int, bool) {
+	if !req.RequiresDeposit || req.DepositAmount <= 0 {
+		return 0, false
+	}
+	if req.BalanceDueHoursBefore != nil {
+		return *req.BalanceDueHoursBefore, true
+	}
+
+	tenant, err := s.repos.Tenant.GetByID(ctx, req.TenantID)
+	if err != nil {
+		s.logger.Warn("failed to load tenant settings for balance collection", "tenant_id", req.TenantID, "error", err)
+		return 0, false
 	}
+	if !tenant.Settings.AutoCollectBalance {
+		return 0, false
+	}
+	return tenant.Settings.BalanceDueHoursBefore, true
+}
 
-	return dto.ToBookingResponse(booking), nil
+// maxBookingDuration is the longest booking, in minutes, including addon extensions
+const maxBookingDuration = 480
+
+// priceAddons quotes each selected addon against the service's base price
+func (s *bookingService) priceAddons(ctx context.Context, tenantID uuid.UUID, basePrice float64, selections []dto.BookingAddonRequest) (models.BookingAddons, error) {
+	addons := make(models.BookingAddons, 0, len(selections))
+	for _, selection := range selections {
+		addon, err := s.repos.ServiceAddon.GetByID(ctx, selection.AddonID)
+		if err != nil || addon.TenantID != tenantID {
+			return nil, errors.NewValidationError(fmt.Sprintf("addon %s not found", selection.AddonID))
+		}
+		line, err := addon.Quote(selection.Quantity, basePrice)
+		if err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+		addons = append(addons, line)
+	}
+	return addons, nil
 }
 
 // ============================================================================
 // Helper Methods
 // ============================================================================
 
-// validateStatusTransition validates if a status transition is allowed
-func (s *bookingService) validateStatusTransition(from, to models.BookingStatus) error {
-	validTransitions := map[models.BookingStatus][]models.BookingStatus{
-		models.BookingStatusPending: {
-			models.BookingStatusConfirmed,
-			models.BookingStatusCancelled,
-		},
-		models.BookingStatusConfirmed: {
-			models.BookingStatusInProgress,
-			models.BookingStatusCancelled,
-			models.BookingStatusNoShow,
-		},
-		models.BookingStatusInProgress: {
-			models.BookingStatusCompleted,
-			models.BookingStatusCancelled,
-		},
-		models.BookingStatusCompleted: {}, // Final state
-		models.BookingStatusCancelled: {}, // Final state
-		models.BookingStatusNoShow:    {}, // Final state
-	}
+// validateStatusTransition validates a status transition against the tenant's transition policy
+func (s *bookingService) validateStatusTransition(ctx context.Context, booking *models.Booking, to models.BookingStatus) error {
+	return s.bookingStateMachine(ctx, booking.TenantID).Validate(booking, to)
+}
 
-	allowed := validTransitions[from]
-	if !slices.Contains(allowed, to) {
-		return fmt.Errorf("cannot transition from %s to %s", from, to)
+// bookingStateMachine builds the state machine for a tenant, falling back to the default policy
+func (s *bookingService) bookingStateMachine(ctx context.Context, tenantID uuid.UUID) *models.BookingStateMachine {
+	policy := models.DefaultBookingTransitionPolicy()
+
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		s.logger.Warn("failed to load tenant transition policy, using default", "tenant_id", tenantID, "error", err)
+	} else {
+		policy = tenant.Settings.GetBookingTransitionPolicy()
 	}
 
-	return fmt.Errorf("cannot transition from %s to %s", from, to)
+	return models.NewBookingStateMachine(policy)
 }
 
-// convertToRepoFilter converts DTO filter to repository filter
-func (s *bookingService) convertToRepoFilter(filter dto.BookingFilter) repository.BookingFilters {
+// toBookingRepoFilter converts DTO filter to repository filter
+func toBookingRepoFilter(filter dto.BookingFilter) repository.BookingFilters {
 	var tenantID uuid.UUID
 	if filter.TenantID != nil {
 		tenantID = *filter.TenantID
 	}
 
+	// Match customer tags the way they are stored; the filter was validated
+	audience := filter.CustomerAudience()
+	_ = audience.Validate()
+
 	return repository.BookingFilters{
 		TenantID:        tenantID,
 		ArtisanIDs:      filter.ArtisanIDs,
 		CustomerIDs:     filter.CustomerIDs,
 		ServiceIDs:      filter.ServiceIDs,
+		ProjectIDs:      filter.ProjectIDs,
 		Statuses:        filter.Statuses,
 		PaymentStatuses: filter.PaymentStatuses,
 		StartDateFrom:   filter.StartDate,
@@ -693,108 +1479,28 @@ func (s *bookingService) convertToRepoFilter(filter dto.BookingFilter) repositor
 		MinPrice:        filter.MinAmount,
 		MaxPrice:        filter.MaxAmount,
 		IsRecurring:     filter.IsRecurring,
-	}
-}
-
-// loadBookingRelations loads all related entities for a booking
-func (s *bookingService) loadBookingRelations(ctx context.Context, booking *models.Booking) error {
-	return s.loadBookingRelationsSelective(ctx, booking, []string{"artisan", "customer", "service", "payments", "review"})
-}
-
-// loadBookingRelationsSelective loads only specified relations for a booking
-func (s *bookingService) loadBookingRelationsSelective(ctx context.Context, booking *models.Booking, relations []string) error {
-	if booking == nil || len(relations) == 0 {
-		return nil
-	}
-
-	for _, relation := range relations {
-		var err error
-		switch relation {
-		case "artisan":
-			if booking.ArtisanID != uuid.Nil && booking.Artisan == nil {
-				booking.Artisan, err = s.repos.User.GetByID(ctx, booking.ArtisanID)
-				if err != nil {
-					s.logger.Warn("failed to load artisan", "artisan_id", booking.ArtisanID, "booking_id", booking.ID, "error", err)
-				}
-			}
-
-		case "customer":
-			if booking.CustomerID != uuid.Nil && booking.Customer == nil {
-				booking.Customer, err = s.repos.User.GetByID(ctx, booking.CustomerID)
-				if err != nil {
-					s.logger.Warn("failed to load customer", "customer_id", booking.CustomerID, "booking_id", booking.ID, "error", err)
-				}
-			}
-
-		case "service":
-			if booking.ServiceID != uuid.Nil && booking.Service == nil {
-				booking.Service, err = s.repos.Service.GetByID(ctx, booking.ServiceID)
-				if err != nil {
-					s.logger.Warn("failed to load service", "service_id", booking.ServiceID, "booking_id", booking.ID, "error", err)
-				}
-			}
-
-		case "payments":
-			if booking.Payments == nil {
-				paymentsPtr, err := s.repos.Payment.GetByBookingID(ctx, booking.ID)
-				if err != nil {
-					s.logger.Warn("failed to load payments", "booking_id", booking.ID, "error", err)
-				} else if paymentsPtr != nil {
-					// Convert []*Payment to []Payment
-					booking.Payments = make([]models.Payment, len(paymentsPtr))
-					for i, p := range paymentsPtr {
-						if p != nil {
-							booking.Payments[i] = *p
-						}
-					}
-				}
-			}
-
-		case "review":
-			if booking.Review == nil {
-				review, err := s.repos.Review.FindByBookingID(ctx, booking.ID)
-				if err != nil {
-					// It's normal for bookings to not have reviews yet
-					if !errors.IsNotFoundError(err) {
-						s.logger.Warn("failed to load review", "booking_id", booking.ID, "error", err)
-					}
-				} else {
-					booking.Review = review
-				}
-			}
-
-		case "tenant":
-			if booking.TenantID != uuid.Nil && booking.Tenant == nil {
-				tenant, err := s.repos.Tenant.GetByID(ctx, booking.TenantID)
-				if err != nil {
-					s.logger.Warn("failed to load tenant", "tenant_id", booking.TenantID, "booking_id", booking.ID, "error", err)
-				} else {
-					booking.Tenant = tenant
-				}
-			}
-
-		case "parent":
-			if booking.ParentBookingID != nil && *booking.ParentBookingID != uuid.Nil && booking.ParentBooking == nil {
-				parent, err := s.repos.Booking.GetByID(ctx, *booking.ParentBookingID)
-				if err != nil {
-					s.logger.Warn("failed to load parent booking", "parent_id", *booking.ParentBookingID, "booking_id", booking.ID, "error", err)
-				} else {
-					booking.ParentBooking = parent
-				}
-			}
-
-		case "children":
-			// Note: Child bookings would typically be loaded via GORM preload
-			// or a custom repository method. For now, we skip this relation.
-			// To implement, add a FindByParentBookingID method to BookingRepository
-			s.logger.Debug("children relation not yet implemented", "booking_id", booking.ID)
+		MinDuration:     filter.MinDuration,
+		MaxDuration:     filter.MaxDuration,
+		HasDeposit:      filter.HasDeposit,
+		HasPhotos:       filter.HasPhotos,
+		HasLocation:     filter.HasLocation,
+		CreatedAfter:    filter.CreatedAfter,
+		CreatedBefore:   filter.CreatedBefore,
+		UpdatedAfter:    filter.UpdatedAfter,
+		UpdatedBefore:   filter.UpdatedBefore,
+		Tags:            normalizedFilterTags(filter.Tags),
+		AnyTags:         normalizedFilterTags(filter.AnyTags),
+		SearchQuery:     filter.SearchQuery,
 
-		default:
-			s.logger.Debug("unknown relation requested", "relation", relation, "booking_id", booking.ID)
-		}
+		CustomerAudience: audience,
+		IncludeRelations: filter.IncludeRelations,
 	}
+}
 
-	return nil
+// normalizedFilterTags matches filter tags the way tags are stored; the filter was validated
+func normalizedFilterTags(tags []string) []string {
+	normalized, _ := models.NormalizeBookingTags(tags)
+	return normalized
 }
 
 // createRecurringBookings creates recurring bookings based on the parent booking
@@ -807,6 +1513,23 @@ func (s *bookingService) createRecurringBookings(ctx context.Context, parentBook
 		maxOccurrences = *req.RecurrenceOccurrences - 1 // Minus 1 because parent is already created
 	}
 
+	// Dates already taken by an occurrence or recorded as an exception are not recreated
+	existing := make(map[int64]struct{})
+	if series, err := s.repos.Booking.GetRecurringBookings(ctx, parentBooking.ID); err == nil {
+		for _, occurrence := range series {
+			existing[occurrence.OccurrenceStart().Unix()] = struct{}{}
+		}
+	}
+
+	// Each occurrence redeems the parent's promo code itself, so the code's limits hold
+	// across the series; occurrences are retaxed under the service's category
+	taxCategory := models.TaxCategoryStandard
+	if parentBooking.PromoCodeID != nil {
+		if service, err := s.repos.Service.GetByID(ctx, parentBooking.ServiceID); err == nil {
+			taxCategory = service.TaxCategory
+		}
+	}
+
 	currentTime := req.StartTime
 	for i := 0; i < maxOccurrences; i++ {
 		// Calculate next occurrence time
@@ -826,11 +1549,18 @@ func (s *bookingService) createRecurringBookings(ctx context.Context, parentBook
 			break
 		}
 
+		if _, ok := existing[currentTime.Unix()]; ok {
+			continue
+		}
+		if _, ok := parentBooking.RecurrenceExceptions.Find(currentTime); ok {
+			continue
+		}
+
 		// Check availability for this time slot
 		availabilityReq := &dto.AvailabilityRequest{
 			ArtisanID: req.ArtisanID,
 			Date:      currentTime,
-			Duration:  req.Duration,
+			Duration:  parentBooking.Duration,
 			ServiceID: &req.ServiceID,
 		}
 		availability, err := s.CheckArtisanAvailability(ctx, availabilityReq)
@@ -840,33 +1570,52 @@ func (s *bookingService) createRecurringBookings(ctx context.Context, parentBook
 		}
 
 		// Create recurring booking
+		occurrenceStart := currentTime
 		recurringBooking := &models.Booking{
-			TenantID:          parentBooking.TenantID,
-			ArtisanID:         parentBooking.ArtisanID,
-			CustomerID:        parentBooking.CustomerID,
-			ServiceID:         parentBooking.ServiceID,
-			StartTime:         currentTime,
-			EndTime:           currentTime.Add(time.Duration(req.Duration) * time.Minute),
-			Duration:          parentBooking.Duration,
-			Status:            models.BookingStatusPending,
-			PaymentStatus:     models.PaymentStatusPending,
-			BasePrice:         parentBooking.BasePrice,
-			AddonsPrice:       parentBooking.AddonsPrice,
-			TotalPrice:        parentBooking.TotalPrice,
-			Currency:          parentBooking.Currency,
-			Notes:             parentBooking.Notes,
-			CustomerNotes:     parentBooking.CustomerNotes,
-			SelectedAddons:    parentBooking.SelectedAddons,
-			ServiceLocation:   parentBooking.ServiceLocation,
-			IsRecurring:       true,
-			RecurrencePattern: parentBooking.RecurrencePattern,
-			ParentBookingID:   &parentBooking.ID,
-			RecurrenceEndDate: parentBooking.RecurrenceEndDate,
-			Metadata:          parentBooking.Metadata,
+			TenantID:           parentBooking.TenantID,
+			ArtisanID:          parentBooking.ArtisanID,
+			CustomerID:         parentBooking.CustomerID,
+			ServiceID:          parentBooking.ServiceID,
+			StartTime:          currentTime,
+			EndTime:            currentTime.Add(time.Duration(parentBooking.Duration) * time.Minute),
+			Duration:           parentBooking.Duration,
+			Status:             models.BookingStatusPending,
+			PaymentStatus:      models.PaymentStatusPending,
+			BasePrice:          parentBooking.BasePrice,
+			PriceVersionID:     parentBooking.PriceVersionID,
+			ListPrice:          parentBooking.ListPrice,
+			PricingAdjustments: parentBooking.PricingAdjustments,
+			AddonsPrice:        parentBooking.AddonsPrice,
+			TaxAmount:          parentBooking.TaxAmount,
+			TaxLines:           parentBooking.TaxLines,
+			TotalPrice:         parentBooking.TotalPrice,
+			Currency:           parentBooking.Currency,
+			Notes:              parentBooking.Notes,
+			CustomerNotes:      parentBooking.CustomerNotes,
+			IntakeAnswers:      parentBooking.IntakeAnswers,
+			SelectedAddons:     parentBooking.SelectedAddons,
+			Addons:             parentBooking.Addons,
+			Tags:               parentBooking.Tags,
+			ServiceLocation:    parentBooking.ServiceLocation,
+			IsRecurring:        true,
+			RecurrencePattern:  parentBooking.RecurrencePattern,
+			ParentBookingID:    &parentBooking.ID,
+			ProjectID:          parentBooking.ProjectID,
+			RecurrenceEndDate:  parentBooking.RecurrenceEndDate,
+			OriginalStartTime:  &occurrenceStart,
+			Metadata:           parentBooking.Metadata,
+		}
+
+		if parentBooking.PromoCodeID != nil {
+			if err := s.discountOccurrence(ctx, recurringBooking, parentBooking.PromoCode, taxCategory); err != nil {
+				s.logger.Error("failed to price recurring booking", "time", currentTime, "error", err)
+				continue
+			}
 		}
 
 		if err := s.repos.Booking.Create(ctx, recurringBooking); err != nil {
 			s.logger.Error("failed to create recurring booking", "time", currentTime, "error", err)
+			s.releasePromoCode(ctx, recurringBooking)
 			continue
 		}
 
@@ -876,6 +1625,27 @@ func (s *bookingService) createRecurringBookings(ctx context.Context, parentBook
 	return recurringBookings, nil
 }
 
+// discountOccurrence redeems a recurring series' promo code for one of its occurrences
+// and retaxes the occurrence. An occurrence the code no longer applies to, such as one
+// past the code's per-customer limit, is priced without a discount.
+func (s *bookingService) discountOccurrence(ctx context.Context, occurrence *models.Booking, code string, category models.TaxCategory) error {
+	occurrence.ID = uuid.New()
+	if err := s.promos.RedeemForBooking(ctx, occurrence, code); err != nil {
+		if !errors.IsValidationError(err) {
+			return err
+		}
+		s.logger.Info("recurring booking priced without promo code", "time", occurrence.StartTime, "reason", err)
+	}
+
+	taxLines, err := s.taxes.CalculateTax(ctx, occurrence.TenantID, category, occurrence.ServiceLocation, occurrence.Subtotal())
+	if err != nil {
+		s.releasePromoCode(ctx, occurrence)
+		return err
+	}
+	occurrence.ApplyTax(taxLines)
+	return nil
+}
+
 // getIntFromMetadata safely gets an int value from metadata
 func getIntFromMetadata(metadata map[string]any, key string) int {
 	if metadata == nil {
@@ -1077,10 +1847,8 @@ func (s *bookingService) SearchBookings(ctx context.Context, req *dto.BookingSea
 	}
 
 	// Load related entities if requested
-	for _, booking := range bookings {
-		if err := s.loadBookingRelationsSelective(ctx, booking, req.Filters.IncludeRelations); err != nil {
-			s.logger.Warn("failed to load booking relations", "booking_id", booking.ID, "error", err)
-		}
+	if err := s.repos.Booking.LoadRelations(ctx, bookings, req.Filters.IncludeRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "count", len(bookings), "error", err)
 	}
 
 	totalPages := int((paginationResult.TotalItems + int64(paginationResult.PageSize) - 1) / int64(paginationResult.PageSize))
@@ -1088,7 +1856,7 @@ func (s *bookingService) SearchBookings(ctx context.Context, req *dto.BookingSea
 	hasPrevious := paginationResult.Page > 1
 
 	return &dto.BookingListResponse{
-		Bookings:    dto.ToBookingResponses(bookings),
+		Bookings:    dto.ToBookingResponses(bookings, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
@@ -1146,13 +1914,11 @@ func (s *bookingService) GetUpcomingBookings(ctx context.Context, tenantID uuid.
 	}
 
 	// Load related entities
-	for _, booking := range bookings {
-		if err := s.loadBookingRelations(ctx, booking); err != nil {
-			s.logger.Warn("failed to load booking relations", "booking_id", booking.ID, "error", err)
-		}
+	if err := s.repos.Booking.LoadRelations(ctx, bookings, bookingDetailRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "count", len(bookings), "error", err)
 	}
 
-	return dto.ToBookingResponses(bookings), nil
+	return dto.ToBookingResponses(bookings, dto.ViewerFromContext(ctx)), nil
 }
 
 // GetTodayBookings returns all bookings for today
@@ -1167,13 +1933,11 @@ func (s *bookingService) GetTodayBookings(ctx context.Context, tenantID uuid.UUI
 	}
 
 	// Load related entities
-	for _, booking := range bookings {
-		if err := s.loadBookingRelations(ctx, booking); err != nil {
-			s.logger.Warn("failed to load booking relations", "booking_id", booking.ID, "error", err)
-		}
+	if err := s.repos.Booking.LoadRelations(ctx, bookings, bookingDetailRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "count", len(bookings), "error", err)
 	}
 
-	return dto.ToBookingResponses(bookings), nil
+	return dto.ToBookingResponses(bookings, dto.ViewerFromContext(ctx)), nil
 }
 
 // GetBookingsInDateRange returns bookings within a date range
@@ -1204,13 +1968,11 @@ func (s *bookingService) GetPastDueBookings(ctx context.Context, tenantID uuid.U
 	}
 
 	// Load related entities
-	for _, booking := range bookings {
-		if err := s.loadBookingRelations(ctx, booking); err != nil {
-			s.logger.Warn("failed to load booking relations", "booking_id", booking.ID, "error", err)
-		}
+	if err := s.repos.Booking.LoadRelations(ctx, bookings, bookingDetailRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "count", len(bookings), "error", err)
 	}
 
-	return dto.ToBookingResponses(bookings), nil
+	return dto.ToBookingResponses(bookings, dto.ViewerFromContext(ctx)), nil
 }
 
 // GetBookingsNeedingReminders returns bookings that need reminders
@@ -1225,13 +1987,11 @@ func (s *bookingService) GetBookingsNeedingReminders(ctx context.Context, hoursA
 	}
 
 	// Load related entities
-	for _, booking := range bookings {
-		if err := s.loadBookingRelations(ctx, booking); err != nil {
-			s.logger.Warn("failed to load booking relations", "booking_id", booking.ID, "error", err)
-		}
+	if err := s.repos.Booking.LoadRelations(ctx, bookings, bookingDetailRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "count", len(bookings), "error", err)
 	}
 
-	return dto.ToBookingResponses(bookings), nil
+	return dto.ToBookingResponses(bookings, dto.ViewerFromContext(ctx)), nil
 }
 
 // ============================================================================
@@ -1375,12 +2135,12 @@ func (s *bookingService) CreateRecurringBookings(ctx context.Context, req *dto.C
 	}
 
 	// Convert all bookings to responses
+	if err := s.repos.Booking.LoadRelations(ctx, recurringBookings, bookingDetailRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "parent_id", booking.ID, "count", len(recurringBookings), "error", err)
+	}
 	responses := []*dto.BookingResponse{parentBooking}
 	for _, rb := range recurringBookings {
-		if err := s.loadBookingRelations(ctx, rb); err != nil {
-			s.logger.Warn("failed to load booking relations", "booking_id", rb.ID, "error", err)
-		}
-		responses = append(responses, dto.ToBookingResponse(rb))
+		responses = append(responses, dto.ToBookingResponse(rb, dto.ViewerFromContext(ctx)))
 	}
 
 	return responses, nil
@@ -1398,13 +2158,11 @@ func (s *bookingService) GetRecurringBookingSeries(ctx context.Context, parentBo
 	}
 
 	// Load related entities
-	for _, booking := range bookings {
-		if err := s.loadBookingRelations(ctx, booking); err != nil {
-			s.logger.Warn("failed to load booking relations", "booking_id", booking.ID, "error", err)
-		}
+	if err := s.repos.Booking.LoadRelations(ctx, bookings, bookingDetailRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "count", len(bookings), "error", err)
 	}
 
-	return dto.ToBookingResponses(bookings), nil
+	return dto.ToBookingResponses(bookings, dto.ViewerFromContext(ctx)), nil
 }
 
 // UpdateRecurringSeries updates all future bookings in a recurring series
@@ -1428,8 +2186,8 @@ func (s *bookingService) UpdateRecurringSeries(ctx context.Context, parentBookin
 			continue
 		}
 
-		// Skip completed or cancelled bookings
-		if booking.Status == models.BookingStatusCompleted || booking.Status == models.BookingStatusCancelled {
+		// Skip completed or cancelled bookings, and occurrences moved out of the series
+		if booking.Status == models.BookingStatusCompleted || booking.Status == models.BookingStatusCancelled || booking.DetachedFromSeries {
 			continue
 		}
 
@@ -1452,7 +2210,16 @@ func (s *bookingService) CancelRecurringSeries(ctx context.Context, parentBookin
 		return errors.NewValidationError("parent booking ID is required")
 	}
 
-	if err := s.repos.Booking.CancelRecurringSeries(ctx, parentBookingID, reason); err != nil {
+	var seriesIDs []uuid.UUID
+	if series, err := s.repos.Booking.GetRecurringBookings(ctx, parentBookingID); err == nil {
+		for _, b := range series {
+			seriesIDs = append(seriesIDs, b.ID)
+		}
+	}
+
+	if err := s.trackBookingChanges(ctx, seriesIDs, reason, func() error {
+		return s.repos.Booking.CancelRecurringSeries(ctx, parentBookingID, reason)
+	}); err != nil {
 		return errors.NewServiceError("CANCEL_FAILED", "failed to cancel recurring series", err)
 	}
 
@@ -1460,6 +2227,136 @@ func (s *bookingService) CancelRecurringSeries(ctx context.Context, parentBookin
 	return nil
 }
 
+// SkipOccurrence cancels a single occurrence and records the skipped date on the series
+// so the rest of the series is untouched and re-expansion leaves the date empty
+func (s *bookingService) SkipOccurrence(ctx context.Context, id uuid.UUID, req *dto.SkipOccurrenceRequest) (*dto.BookingResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	booking, err := s.getSeriesOccurrence(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.CancelBooking(ctx, id, &dto.CancelBookingRequest{
+		Reason:          req.Reason,
+		CancelledBy:     req.SkippedBy,
+		RefundRequested: req.RefundRequested,
+		NotifyCustomer:  req.NotifyCustomer,
+		NotifyArtisan:   req.NotifyArtisan,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.addRecurrenceException(ctx, booking, response, models.RecurrenceException{
+		OriginalStart: booking.OccurrenceStart(),
+		Type:          models.RecurrenceExceptionSkipped,
+		BookingID:     booking.ID,
+		Reason:        req.Reason,
+		CreatedBy:     &req.SkippedBy,
+		CreatedAt:     time.Now(),
+	})
+
+	s.logger.Info("recurring occurrence skipped", "booking_id", id, "series_id", booking.SeriesID())
+	return response, nil
+}
+
+// MoveOccurrence reschedules a single occurrence and detaches it, so later series-wide
+// updates leave it alone while the other occurrences keep their schedule
+func (s *bookingService) MoveOccurrence(ctx context.Context, id uuid.UUID, req *dto.MoveOccurrenceRequest) (*dto.BookingResponse, error) {
+	booking, err := s.getSeriesOccurrence(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	originalStart := booking.OccurrenceStart()
+
+	if _, err := s.RescheduleBooking(ctx, id, &req.RescheduleBookingRequest); err != nil {
+		return nil, err
+	}
+
+	// Reload after the reschedule bumped the version
+	booking, err = s.repos.Booking.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
+	}
+	booking.OriginalStartTime = &originalStart
+	booking.DetachedFromSeries = true
+	if err := s.repos.Booking.Update(ctx, booking); err != nil {
+		return nil, errors.NewServiceError("BOOKING_UPDATE_FAILED", "failed to detach occurrence from series", err)
+	}
+
+	if err := s.repos.Booking.LoadRelations(ctx, []*models.Booking{booking}, bookingDetailRelations...); err != nil {
+		s.logger.Warn("failed to load booking relations", "booking_id", id, "error", err)
+	}
+	response := dto.ToBookingResponse(booking, dto.ViewerFromContext(ctx))
+
+	var movedBy *uuid.UUID
+	if req.MovedBy != uuid.Nil {
+		movedBy = &req.MovedBy
+	}
+	newStart := booking.StartTime
+	s.addRecurrenceException(ctx, booking, response, models.RecurrenceException{
+		OriginalStart: originalStart,
+		Type:          models.RecurrenceExceptionMoved,
+		BookingID:     booking.ID,
+		NewStart:      &newStart,
+		Reason:        req.Reason,
+		CreatedBy:     movedBy,
+		CreatedAt:     time.Now(),
+	})
+
+	s.logger.Info("recurring occurrence moved", "booking_id", id, "series_id", booking.SeriesID(), "new_start_time", newStart)
+	return response, nil
+}
+
+// getSeriesOccurrence loads a booking and checks it belongs to a recurring series
+func (s *bookingService) getSeriesOccurrence(ctx context.Context, id uuid.UUID) (*models.Booking, error) {
+	booking, err := s.repos.Booking.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("booking not found")
+		}
+		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
+	}
+	if !booking.IsSeriesOccurrence() {
+		return nil, errors.NewValidationError("booking is not part of a recurring series")
+	}
+	return booking, nil
+}
+
+// seriesExceptionAttempts bounds retries when another writer updates the series root concurrently
+const seriesExceptionAttempts = 3
+
+// addRecurrenceException records the exception on the series root. The occurrence change
+// has already been saved, so a failure is logged rather than returned: re-expansion also
+// skips dates that still have an occurrence row.
+func (s *bookingService) addRecurrenceException(ctx context.Context, occurrence *models.Booking, response *dto.BookingResponse, exception models.RecurrenceException) {
+	seriesID := occurrence.SeriesID()
+
+	var err error
+	for range seriesExceptionAttempts {
+		var root *models.Booking
+		if root, err = s.repos.Booking.GetByID(ctx, seriesID); err != nil {
+			break
+		}
+		root.AddRecurrenceException(exception)
+		if err = s.repos.Booking.Update(ctx, root); err == nil {
+			if root.ID == occurrence.ID {
+				response.RecurrenceExceptions = root.RecurrenceExceptions
+				response.Version = root.Version
+			}
+			return
+		}
+		if !errors.IsConflict(err) {
+			break
+		}
+	}
+
+	s.logger.Error("failed to record recurrence exception", "series_id", seriesID, "booking_id", occurrence.ID, "error", err)
+}
+
 // ============================================================================
 // Photo Management Methods
 // ============================================================================
@@ -1506,7 +2403,9 @@ func (s *bookingService) UpdatePaymentStatus(ctx context.Context, bookingID uuid
 		return nil, errors.NewValidationError("booking ID is required")
 	}
 
-	if err := s.repos.Booking.UpdatePaymentStatus(ctx, bookingID, status); err != nil {
+	if err := s.trackBookingChanges(ctx, []uuid.UUID{bookingID}, "", func() error {
+		return s.repos.Booking.UpdatePaymentStatus(ctx, bookingID, status)
+	}); err != nil {
 		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update payment status", err)
 	}
 
@@ -1524,7 +2423,9 @@ func (s *bookingService) RecordDepositPayment(ctx context.Context, bookingID uui
 	}
 
 	// Record the deposit
-	if err := s.repos.Booking.RecordDepositPayment(ctx, bookingID, amount); err != nil {
+	if err := s.trackBookingChanges(ctx, []uuid.UUID{bookingID}, "", func() error {
+		return s.repos.Booking.RecordDepositPayment(ctx, bookingID, amount)
+	}); err != nil {
 		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to record deposit", err)
 	}
 
@@ -1557,9 +2458,16 @@ func (s *bookingService) ProcessRefund(ctx context.Context, bookingID uuid.UUID,
 	if err != nil {
 		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
 	}
+	if err := s.authorizer.Can(ctx, authz.ActionPaymentRefund, authz.Booking(booking)); err != nil {
+		return nil, err
+	}
 
-	// Validate refund amount
-	maxRefund := booking.CalculateRefundAmount()
+	// Validate refund amount against the cancellation policy
+	breakdown, err := s.policies.EvaluateCancellation(ctx, booking, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	maxRefund := breakdown.RefundAmount
 	if amount > maxRefund {
 		return nil, errors.NewValidationError(fmt.Sprintf("refund amount exceeds maximum refundable amount (%.2f)", maxRefund))
 	}
@@ -1645,6 +2553,7 @@ func (s *bookingService) GetBookingAnalytics(ctx context.Context, filter dto.Boo
 				Date:     trend.Date,
 				Bookings: trend.BookingCount,
 				Revenue:  trend.Revenue,
+				AsOf:     trend.AsOf,
 			}
 		}
 	}
@@ -1661,7 +2570,7 @@ func (s *bookingService) GetBookingAnalytics(ctx context.Context, filter dto.Boo
 				ServiceName:   svc.ServiceName,
 				BookingCount:  svc.Count,
 				TotalRevenue:  svc.Revenue,
-				AverageRating: 0, // Would need to calculate from reviews
+				AverageRating: svc.Rating,
 			}
 		}
 	}
@@ -1712,7 +2621,7 @@ func (s *bookingService) GetPopularServices(ctx context.Context, tenantID uuid.U
 			ServiceName:   svc.ServiceName,
 			BookingCount:  svc.Count,
 			TotalRevenue:  svc.Revenue,
-			AverageRating: 0, // Would need to fetch from reviews
+			AverageRating: svc.Rating,
 		}
 	}
 
@@ -1739,6 +2648,7 @@ func (s *bookingService) GetBookingTrends(ctx context.Context, tenantID uuid.UUI
 			Date:     trend.Date,
 			Bookings: trend.BookingCount,
 			Revenue:  trend.Revenue,
+			AsOf:     trend.AsOf,
 		}
 	}
 
@@ -1749,57 +2659,20 @@ func (s *bookingService) GetBookingTrends(ctx context.Context, tenantID uuid.UUI
 // Bulk Operations Methods
 // ============================================================================
 
-// BulkConfirm confirms multiple bookings
-func (s *bookingService) BulkConfirm(ctx context.Context, bookingIDs []uuid.UUID) ([]*dto.BookingResponse, error) {
-	if len(bookingIDs) == 0 {
-		return nil, errors.NewValidationError("at least one booking ID is required")
-	}
-
-	if err := s.repos.Booking.BulkConfirm(ctx, bookingIDs); err != nil {
-		return nil, errors.NewServiceError("BULK_UPDATE_FAILED", "failed to bulk confirm bookings", err)
-	}
-
-	// Fetch updated bookings
-	var responses []*dto.BookingResponse
-	for _, id := range bookingIDs {
-		booking, err := s.GetBooking(ctx, id)
-		if err != nil {
-			s.logger.Warn("failed to get booking after bulk confirm", "booking_id", id, "error", err)
-			continue
-		}
-		responses = append(responses, booking)
-	}
+// maxBulkBookings is the most bookings a single bulk operation may touch
+const maxBulkBookings = 500
 
-	s.logger.Info("bookings bulk confirmed", "count", len(bookingIDs))
-	return responses, nil
+// BulkConfirm confirms multiple bookings
+func (s *bookingService) BulkConfirm(ctx context.Context, tenantID uuid.UUID, bookingIDs []uuid.UUID) (*dto.BulkBookingResult, error) {
+	return s.bulkUpdateStatus(ctx, tenantID, bookingIDs, models.BookingStatusConfirmed, "")
 }
 
 // BulkCancel cancels multiple bookings
-func (s *bookingService) BulkCancel(ctx context.Context, bookingIDs []uuid.UUID, reason string) ([]*dto.BookingResponse, error) {
-	if len(bookingIDs) == 0 {
-		return nil, errors.NewValidationError("at least one booking ID is required")
-	}
+func (s *bookingService) BulkCancel(ctx context.Context, tenantID uuid.UUID, bookingIDs []uuid.UUID, reason string) (*dto.BulkBookingResult, error) {
 	if reason == "" {
 		return nil, errors.NewValidationError("cancellation reason is required")
 	}
-
-	if err := s.repos.Booking.BulkCancel(ctx, bookingIDs, reason); err != nil {
-		return nil, errors.NewServiceError("BULK_UPDATE_FAILED", "failed to bulk cancel bookings", err)
-	}
-
-	// Fetch updated bookings
-	var responses []*dto.BookingResponse
-	for _, id := range bookingIDs {
-		booking, err := s.GetBooking(ctx, id)
-		if err != nil {
-			s.logger.Warn("failed to get booking after bulk cancel", "booking_id", id, "error", err)
-			continue
-		}
-		responses = append(responses, booking)
-	}
-
-	s.logger.Info("bookings bulk cancelled", "count", len(bookingIDs))
-	return responses, nil
+	return s.bulkUpdateStatus(ctx, tenantID, bookingIDs, models.BookingStatusCancelled, reason)
 }
 
 // BulkReschedule reschedules multiple bookings to a new time
@@ -1842,30 +2715,145 @@ func (s *bookingService) BulkReschedule(ctx context.Context, bookingIDs []uuid.U
 }
 
 // BulkUpdateStatus updates the status of multiple bookings
-func (s *bookingService) BulkUpdateStatus(ctx context.Context, bookingIDs []uuid.UUID, status models.BookingStatus) ([]*dto.BookingResponse, error) {
+func (s *bookingService) BulkUpdateStatus(ctx context.Context, tenantID uuid.UUID, bookingIDs []uuid.UUID, status models.BookingStatus) (*dto.BulkBookingResult, error) {
+	return s.bulkUpdateStatus(ctx, tenantID, bookingIDs, status, "")
+}
+
+// bulkUpdateStatus validates every booking against the tenant's transition policy and
+// applies the valid ones in a single batched update. Bookings that are missing, cannot
+// make the transition or change concurrently are reported as failed.
+func (s *bookingService) bulkUpdateStatus(ctx context.Context, tenantID uuid.UUID, bookingIDs []uuid.UUID, status models.BookingStatus, reason string) (*dto.BulkBookingResult, error) {
 	if len(bookingIDs) == 0 {
 		return nil, errors.NewValidationError("at least one booking ID is required")
 	}
+	if len(bookingIDs) > maxBulkBookings {
+		return nil, errors.NewValidationError(fmt.Sprintf("at most %d bookings can be updated at once", maxBulkBookings))
+	}
 
-	var responses []*dto.BookingResponse
+	bookings, err := s.repos.Booking.FindByIDs(ctx, tenantID, bookingIDs)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to load bookings", err)
+	}
+	before := make(map[uuid.UUID]*models.Booking, len(bookings))
+	for _, booking := range bookings {
+		before[booking.ID] = booking
+	}
+
+	result := &dto.BulkBookingResult{Succeeded: []uuid.UUID{}, Failed: []dto.BulkBookingFailure{}}
+	fail := func(id uuid.UUID, reason string) {
+		result.Failed = append(result.Failed, dto.BulkBookingFailure{BookingID: id, Reason: reason})
+	}
 
-	// Update each booking individually to maintain validation
+	machine := s.bookingStateMachine(ctx, tenantID)
+	expected := make(map[uuid.UUID]int, len(bookings))
+	seen := make(map[uuid.UUID]bool, len(bookingIDs))
+	var candidates []uuid.UUID
 	for _, id := range bookingIDs {
-		updateReq := &dto.UpdateBookingRequest{
-			Status: &status,
+		booking, ok := before[id]
+		switch {
+		case seen[id]:
+			fail(id, "duplicate booking ID")
+		case !ok:
+			fail(id, "booking not found")
+		default:
+			if err := machine.Validate(booking, status); err != nil {
+				fail(id, err.Error())
+				break
+			}
+			expected[id] = booking.Version
+			candidates = append(candidates, id)
+		}
+		seen[id] = true
+	}
+
+	if len(candidates) == 0 {
+		return result, nil
+	}
+
+	updatedIDs, err := s.repos.Booking.BulkUpdateStatus(ctx, expected, status, reason)
+	if err != nil {
+		return nil, errors.NewServiceError("BULK_UPDATE_FAILED", "failed to bulk update booking status", err)
+	}
+	updated := make(map[uuid.UUID]bool, len(updatedIDs))
+	for _, id := range updatedIDs {
+		updated[id] = true
+	}
+	for _, id := range candidates {
+		if updated[id] {
+			result.Succeeded = append(result.Succeeded, id)
+		} else {
+			fail(id, "booking was modified by another request")
 		}
+	}
 
-		updated, err := s.UpdateBooking(ctx, id, updateReq)
-		if err != nil {
-			s.logger.Error("failed to update booking status", "booking_id", id, "error", err)
+	s.recordBulkBookingEvents(ctx, tenantID, before, result.Succeeded, reason)
+
+	s.logger.Info("bookings bulk status updated", "tenant_id", tenantID, "status", status,
+		"succeeded", len(result.Succeeded), "failed", len(result.Failed))
+	return result, nil
+}
+
+// recordBulkBookingEvents writes the change log of a bulk update with one read and one insert
+func (s *bookingService) recordBulkBookingEvents(ctx context.Context, tenantID uuid.UUID, before map[uuid.UUID]*models.Booking, ids []uuid.UUID, note string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	after, err := s.repos.Booking.FindByIDs(ctx, tenantID, ids)
+	if err != nil {
+		s.logger.Warn("failed to reload bookings for change log", "tenant_id", tenantID, "error", err)
+		return
+	}
+
+	entries := make([]*models.BookingEvent, 0, len(after))
+	var published []events.Event
+	for _, booking := range after {
+		prev, ok := before[booking.ID]
+		if !ok {
+			continue
+		}
+		changes := models.DiffBookings(prev, booking)
+		if len(changes) == 0 {
 			continue
 		}
+		entries = append(entries, newBookingEvent(ctx, booking, changes.EventType(), changes, note))
+		published = append(published, events.BookingChanges(prev, booking, note)...)
+	}
+	if len(entries) == 0 {
+		return
+	}
 
-		responses = append(responses, updated)
+	if err := s.saveBookingEvents(ctx, entries, published); err != nil {
+		s.logger.Error("failed to record booking events", "tenant_id", tenantID, "count", len(entries), "error", err)
+		return
 	}
 
-	s.logger.Info("bookings bulk status updated", "count", len(responses), "status", status)
-	return responses, nil
+	if s.realtime != nil {
+		byID := make(map[uuid.UUID]*models.Booking, len(after))
+		for _, booking := range after {
+			byID[booking.ID] = booking
+		}
+		for _, event := range entries {
+			s.realtime.PublishBookingEvent(context.WithoutCancel(ctx), event, byID[event.BookingID])
+		}
+	}
+}
+
+// saveBookingEvents writes change log entries of one tenant's bookings and publishes the
+// domain events of the changes in one transaction, so consumers hear of exactly the
+// changes the log holds
+func (s *bookingService) saveBookingEvents(ctx context.Context, entries []*models.BookingEvent, published []events.Event) error {
+	outbox, err := events.Outbox(entries[0].TenantID, published...)
+	if err != nil {
+		return err
+	}
+
+	return s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		if err := tx.BookingEvent.CreateBatch(ctx, entries); err != nil {
+			return err
+		}
+		return tx.Outbox.Append(ctx, outbox...)
+	})
 }
 
 // ============================================================================
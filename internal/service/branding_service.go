@@ -0,0 +1,434 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/storage"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// brandingAssetPath is where uploaded branding images are served, relative to the API
+// base URL, followed by the tenant ID and file name
+const brandingAssetPath = "/api/v1/branding/assets/"
+
+// dkimKeyBits is the size of the RSA keys notification emails are signed with
+const dkimKeyBits = 2048
+
+// brandingContentTypes are the image types accepted as branding assets, by the extension
+// they are stored under. SVG is left out as it can carry scripts.
+var brandingContentTypes = map[string]string{
+	"image/png":    ".png",
+	"image/jpeg":   ".jpg",
+	"image/webp":   ".webp",
+	"image/x-icon": ".ico",
+}
+
+// BrandingAssetDownload is an opened branding image
+type BrandingAssetDownload struct {
+	Content     io.ReadCloser
+	ContentType string
+}
+
+// BrandingService manages how a tenant's booking pages and notification emails look:
+// its name, logos and colors, the footer of its emails, and the domain they are sent
+// from once it publishes their DKIM key
+type BrandingService interface {
+	// Public Operations
+	GetBranding(ctx context.Context, tenantID uuid.UUID) (*dto.BrandingResponse, error)
+	OpenAsset(ctx context.Context, tenantID uuid.UUID, fileName string) (*BrandingAssetDownload, error)
+
+	// Settings
+	GetSettings(ctx context.Context, tenantID uuid.UUID) (*dto.BrandingSettingsResponse, error)
+	UpdateSettings(ctx context.Context, tenantID uuid.UUID, req *dto.UpdateBrandingSettingsRequest) (*dto.BrandingSettingsResponse, error)
+	UploadAsset(ctx context.Context, req *dto.UploadBrandingAssetRequest, content io.Reader) (*dto.BrandingSettingsResponse, error)
+
+	// Email Sender Domain
+	GetEmailDomain(ctx context.Context, tenantID uuid.UUID) (*dto.DomainVerificationResponse, error)
+	SetEmailDomain(ctx context.Context, tenantID uuid.UUID, req *dto.SetEmailDomainRequest) (*dto.DomainVerificationResponse, error)
+	VerifyEmailDomain(ctx context.Context, tenantID uuid.UUID) (*dto.DomainVerificationResponse, error)
+	RemoveEmailDomain(ctx context.Context, tenantID uuid.UUID) error
+}
+
+// brandingService implements BrandingService
+type brandingService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+	store  storage.ObjectStore
+	dns    DNSResolver
+}
+
+// NewBrandingService creates a new BrandingService instance; images cannot be uploaded
+// without a store, and dns defaults to net.DefaultResolver
+func NewBrandingService(repos *repository.Repositories, logger log.AllLogger, store storage.ObjectStore, dns DNSResolver) BrandingService {
+	if dns == nil {
+		dns = net.DefaultResolver
+	}
+	return &brandingService{
+		repos:  repos,
+		logger: logger,
+		store:  store,
+		dns:    dns,
+	}
+}
+
+// ============================================================================
+// Public Operations
+// ============================================================================
+
+// GetBranding returns how a tenant's booking pages look
+func (s *brandingService) GetBranding(ctx context.Context, tenantID uuid.UUID) (*dto.BrandingResponse, error) {
+	tenant, whitelabel, err := loadTenantBranding(ctx, s.repos, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Status == models.TenantStatusClosed {
+		return nil, errors.NewNotFoundError("tenant")
+	}
+	return dto.ToBrandingResponse(models.ResolveBranding(tenant, whitelabel)), nil
+}
+
+// OpenAsset opens a tenant's uploaded branding image
+func (s *brandingService) OpenAsset(ctx context.Context, tenantID uuid.UUID, fileName string) (*BrandingAssetDownload, error) {
+	contentType := ""
+	for candidate, ext := range brandingContentTypes {
+		if path.Ext(fileName) == ext {
+			contentType = candidate
+		}
+	}
+	if contentType == "" || path.Base(fileName) != fileName || s.store == nil {
+		return nil, errors.NewNotFoundError("branding asset")
+	}
+
+	content, err := s.store.Open(ctx, brandingAssetKey(tenantID, fileName))
+	if err != nil {
+		if stdErrors.Is(err, storage.ErrObjectNotFound) {
+			return nil, errors.NewNotFoundError("branding asset")
+		}
+		return nil, errors.NewServiceError("BRANDING_ASSET_OPEN_FAILED", "failed to open branding asset", err)
+	}
+	return &BrandingAssetDownload{Content: content, ContentType: contentType}, nil
+}
+
+// ============================================================================
+// Settings
+// ============================================================================
+
+// GetSettings returns a tenant's branding as its pages and emails show it, and the email
+// sender domain record it is to publish
+func (s *brandingService) GetSettings(ctx context.Context, tenantID uuid.UUID) (*dto.BrandingSettingsResponse, error) {
+	tenant, whitelabel, err := loadTenantBranding(ctx, s.repos, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToBrandingSettingsResponse(models.ResolveBranding(tenant, whitelabel), whitelabel), nil
+}
+
+// UpdateSettings updates a tenant's branding, which takes the custom branding or white
+// labeling feature. The settings are kept on the tenant's white label, set up on first use.
+func (s *brandingService) UpdateSettings(ctx context.Context, tenantID uuid.UUID, req *dto.UpdateBrandingSettingsRequest) (*dto.BrandingSettingsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	tenant, whitelabel, err := s.brandableTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if req.HidePoweredBy != nil && *req.HidePoweredBy && !tenant.Features.WhiteLabeling {
+		return nil, errors.NewForbiddenError("hiding the powered-by line takes the white labeling feature")
+	}
+	if req.EmailFromAddress != nil && *req.EmailFromAddress != "" {
+		_, domain, _ := strings.Cut(*req.EmailFromAddress, "@")
+		if whitelabel.EmailSenderDomain == "" || !strings.EqualFold(domain, whitelabel.EmailSenderDomain) {
+			return nil, errors.NewValidationError("the from address must be at the email sender domain; set the domain up first")
+		}
+	}
+
+	setString := func(field *string, value *string) {
+		if value != nil {
+			*field = strings.TrimSpace(*value)
+		}
+	}
+	setString(&whitelabel.CompanyName, req.CompanyName)
+	setString(&whitelabel.PrimaryColor, req.PrimaryColor)
+	setString(&whitelabel.SecondaryColor, req.SecondaryColor)
+	setString(&whitelabel.AccentColor, req.AccentColor)
+	setString(&whitelabel.FontFamily, req.FontFamily)
+	setString(&whitelabel.SupportEmail, req.SupportEmail)
+	setString(&whitelabel.SupportPhone, req.SupportPhone)
+	setString(&whitelabel.SupportURL, req.SupportURL)
+	setString(&whitelabel.EmailSettings.FromName, req.EmailFromName)
+	setString(&whitelabel.EmailSettings.FromEmail, req.EmailFromAddress)
+	setString(&whitelabel.EmailSettings.ReplyToEmail, req.EmailReplyTo)
+	setString(&whitelabel.EmailSettings.HeaderColor, req.EmailHeaderColor)
+	setString(&whitelabel.EmailSettings.ButtonColor, req.EmailButtonColor)
+	setString(&whitelabel.EmailSettings.FooterText, req.FooterText)
+	if req.HidePoweredBy != nil {
+		whitelabel.HidePoweredBy = *req.HidePoweredBy
+	}
+
+	if err := s.saveWhiteLabel(ctx, whitelabel); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("branding updated", "tenant_id", tenantID)
+	return dto.ToBrandingSettingsResponse(models.ResolveBranding(tenant, whitelabel), whitelabel), nil
+}
+
+// UploadAsset stores a logo or favicon image and brands the tenant's pages or emails
+// with it, deleting the image it replaces. The file type is detected from its content
+// rather than trusted from the upload.
+func (s *brandingService) UploadAsset(ctx context.Context, req *dto.UploadBrandingAssetRequest, content io.Reader) (*dto.BrandingSettingsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+	if s.store == nil {
+		return nil, errors.NewServiceError("BRANDING_UNAVAILABLE", "file storage is not configured", nil)
+	}
+
+	tenant, whitelabel, err := s.brandableTenant(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !stdErrors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, errors.NewValidationError("failed to read image file")
+	}
+	contentType := http.DetectContentType(head[:n])
+	ext, ok := brandingContentTypes[contentType]
+	if !ok {
+		return nil, errors.NewValidationError("images must be PNG, JPEG, WebP or ICO files")
+	}
+
+	fileName := fmt.Sprintf("%s-%s%s", req.Asset, uuid.New(), ext)
+	key := brandingAssetKey(req.TenantID, fileName)
+	if _, err := s.store.Put(ctx, key, contentType, io.MultiReader(bytes.NewReader(head[:n]), content)); err != nil {
+		s.logger.Error("failed to store branding asset", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("BRANDING_UPLOAD_FAILED", "failed to store image", err)
+	}
+
+	previous := whitelabel.AssetURL(req.Asset)
+	whitelabel.SetAssetURL(req.Asset, brandingAssetPath+req.TenantID.String()+"/"+fileName)
+	if err := s.saveWhiteLabel(ctx, whitelabel); err != nil {
+		if err := s.store.Delete(ctx, key); err != nil {
+			s.logger.Warn("failed to delete orphaned branding asset", "file_key", key, "error", err)
+		}
+		return nil, err
+	}
+
+	// Images uploaded before are ours to delete; URLs set through the white label are not
+	if previousFile, ok := strings.CutPrefix(previous, brandingAssetPath+req.TenantID.String()+"/"); ok {
+		if err := s.store.Delete(ctx, brandingAssetKey(req.TenantID, previousFile)); err != nil {
+			s.logger.Warn("failed to delete replaced branding asset", "tenant_id", req.TenantID, "file", previousFile, "error", err)
+		}
+	}
+
+	s.logger.Info("branding asset uploaded", "tenant_id", req.TenantID, "asset", req.Asset)
+	return dto.ToBrandingSettingsResponse(models.ResolveBranding(tenant, whitelabel), whitelabel), nil
+}
+
+// ============================================================================
+// Email Sender Domain
+// ============================================================================
+
+// GetEmailDomain returns the DKIM record the tenant's email sender domain is to publish
+func (s *brandingService) GetEmailDomain(ctx context.Context, tenantID uuid.UUID) (*dto.DomainVerificationResponse, error) {
+	_, whitelabel, err := loadTenantBranding(ctx, s.repos, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if whitelabel == nil || whitelabel.EmailSenderDomain == "" {
+		return nil, errors.NewNotFoundError("email sender domain")
+	}
+	return dto.ToEmailDomainResponse(whitelabel), nil
+}
+
+// SetEmailDomain sets the domain the tenant's emails are to be sent from, generating
+// the DKIM key they are signed with. Emails keep coming from the platform's address
+// until the domain publishes the key and is verified.
+func (s *brandingService) SetEmailDomain(ctx context.Context, tenantID uuid.UUID, req *dto.SetEmailDomainRequest) (*dto.DomainVerificationResponse, error) {
+	domain, err := models.NormalizeEmailDomain(req.Domain)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	_, whitelabel, err := s.brandableTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, dkimKeyBits)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate DKIM key", err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to encode DKIM key", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	// A new selector for every key, so a key can be rotated while the old one is published
+	selector := "kv" + time.Now().UTC().Format("20060102150405")
+	whitelabel.SetEmailSenderDomain(domain, selector, base64.StdEncoding.EncodeToString(publicKey), string(privateKey))
+	if _, fromDomain, _ := strings.Cut(whitelabel.EmailSettings.FromEmail, "@"); !strings.EqualFold(fromDomain, domain) {
+		whitelabel.EmailSettings.FromEmail = "notifications@" + domain
+	}
+
+	if err := s.saveWhiteLabel(ctx, whitelabel); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("email sender domain set", "tenant_id", tenantID, "domain", domain)
+	return dto.ToEmailDomainResponse(whitelabel), nil
+}
+
+// VerifyEmailDomain looks up the DKIM record of the tenant's email sender domain, and
+// sends its emails from the domain once the record publishes their key
+func (s *brandingService) VerifyEmailDomain(ctx context.Context, tenantID uuid.UUID) (*dto.DomainVerificationResponse, error) {
+	_, whitelabel, err := loadTenantBranding(ctx, s.repos, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if whitelabel == nil || whitelabel.EmailSenderDomain == "" {
+		return nil, errors.NewValidationError("set up an email sender domain first")
+	}
+	if whitelabel.EmailDomainVerified() {
+		return dto.ToEmailDomainResponse(whitelabel), nil
+	}
+
+	records, err := s.dns.LookupTXT(ctx, whitelabel.DKIMRecordName())
+	if err != nil {
+		var dnsErr *net.DNSError
+		if !stdErrors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			s.logger.Warn("failed to look up DKIM record", "tenant_id", tenantID, "record", whitelabel.DKIMRecordName(), "error", err)
+		}
+		records = nil
+	}
+
+	published := false
+	for _, record := range records {
+		published = published || whitelabel.MatchesDKIMRecord(record)
+	}
+	if !published {
+		return nil, errors.NewAppError("EMAIL_DOMAIN_NOT_VERIFIED",
+			"the DKIM TXT record was not found; DNS changes can take a while to propagate",
+			http.StatusUnprocessableEntity)
+	}
+
+	whitelabel.MarkEmailDomainVerified(time.Now())
+	if err := s.saveWhiteLabel(ctx, whitelabel); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("email sender domain verified", "tenant_id", tenantID, "domain", whitelabel.EmailSenderDomain)
+	return dto.ToEmailDomainResponse(whitelabel), nil
+}
+
+// RemoveEmailDomain goes back to sending the tenant's emails from the platform's address
+func (s *brandingService) RemoveEmailDomain(ctx context.Context, tenantID uuid.UUID) error {
+	_, whitelabel, err := loadTenantBranding(ctx, s.repos, tenantID)
+	if err != nil {
+		return err
+	}
+	if whitelabel == nil || whitelabel.EmailSenderDomain == "" {
+		return errors.NewNotFoundError("email sender domain")
+	}
+
+	whitelabel.ClearEmailSenderDomain()
+	whitelabel.EmailSettings.FromEmail = ""
+	if err := s.saveWhiteLabel(ctx, whitelabel); err != nil {
+		return err
+	}
+
+	s.logger.Info("email sender domain removed", "tenant_id", tenantID)
+	return nil
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+// brandableTenant returns a tenant that can brand its pages and emails and its white
+// label, a new unsaved one if it has none yet
+func (s *brandingService) brandableTenant(ctx context.Context, tenantID uuid.UUID) (*models.Tenant, *models.WhiteLabel, error) {
+	tenant, whitelabel, err := loadTenantBranding(ctx, s.repos, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !tenant.Features.CustomBranding && !tenant.Features.WhiteLabeling {
+		return nil, nil, errors.NewForbiddenError("custom branding is not enabled for your plan")
+	}
+
+	if whitelabel == nil {
+		// Start from the color the tenant already has rather than the default
+		whitelabel = &models.WhiteLabel{
+			TenantID:     tenantID,
+			PrimaryColor: tenant.PrimaryColor,
+			IsActive:     true,
+		}
+	}
+	return tenant, whitelabel, nil
+}
+
+// saveWhiteLabel creates or updates a white label
+func (s *brandingService) saveWhiteLabel(ctx context.Context, whitelabel *models.WhiteLabel) error {
+	var err error
+	if whitelabel.ID == uuid.Nil {
+		err = s.repos.WhiteLabel.Create(ctx, whitelabel)
+	} else {
+		err = s.repos.WhiteLabel.Update(ctx, whitelabel)
+	}
+	if err != nil {
+		s.logger.Error("failed to save branding", "tenant_id", whitelabel.TenantID, "error", err)
+		return errors.NewServiceError("BRANDING_UPDATE_FAILED", "failed to save branding", err)
+	}
+	return nil
+}
+
+// loadTenantBranding returns a tenant and its white label, which is nil if it has none
+func loadTenantBranding(ctx context.Context, repos *repository.Repositories, tenantID uuid.UUID) (*models.Tenant, *models.WhiteLabel, error) {
+	tenant, err := repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, errors.NewNotFoundError("tenant")
+		}
+		return nil, nil, errors.NewServiceError("TENANT_LOOKUP_FAILED", "failed to get tenant", err)
+	}
+
+	whitelabel, err := repos.WhiteLabel.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return tenant, nil, nil
+		}
+		return nil, nil, errors.NewServiceError("BRANDING_LOOKUP_FAILED", "failed to get branding", err)
+	}
+	return tenant, whitelabel, nil
+}
+
+// brandingAssetKey is the storage key of a tenant's branding image
+func brandingAssetKey(tenantID uuid.UUID, fileName string) string {
+	return "branding/" + tenantID.String() + "/" + fileName
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/ical"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	calendarProductID = "-//Krafti Vibe//Bookings//EN"
+
+	// Window of bookings included in subscription feeds
+	calendarFeedLookBack  = 30 * 24 * time.Hour
+	calendarFeedLookAhead = 180 * 24 * time.Hour
+)
+
+// CalendarService defines the interface for calendar export operations
+type CalendarService interface {
+	// Subscription Feeds
+	GetArtisanFeedInfo(ctx context.Context, artisanID uuid.UUID, baseURL string) (*dto.CalendarFeedResponse, error)
+	GetArtisanFeed(ctx context.Context, artisanID uuid.UUID, token string) ([]byte, error)
+
+	// RotateArtisanFeedToken revokes the artisan's subscription URL and returns a new one
+	RotateArtisanFeedToken(ctx context.Context, artisanID uuid.UUID, baseURL string) (*dto.CalendarFeedResponse, error)
+}
+
+// calendarService implements CalendarService
+type calendarService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+	signer *ical.FeedSigner
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(repos *repository.Repositories, logger log.AllLogger, feedSecret string) CalendarService {
+	return &calendarService{
+		repos:  repos,
+		logger: logger,
+		signer: ical.NewFeedSigner(feedSecret),
+	}
+}
+
+// ============================================================================
+// Subscription Feeds
+// ============================================================================
+
+// GetArtisanFeedInfo returns the signed subscription URL for an artisan's calendar.
+// Only the artisan and their tenant's owners and admins may get it; anyone else is
+// told the artisan doesn't exist.
+func (s *calendarService) GetArtisanFeedInfo(ctx context.Context, artisanID uuid.UUID, baseURL string) (*dto.CalendarFeedResponse, error) {
+	artisan, err := s.getFeedArtisan(ctx, artisanID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.feedInfo(artisanID, artisan.CalendarTokenVersion, baseURL)
+}
+
+// RotateArtisanFeedToken bumps the artisan's calendar token version, so the subscription
+// URL shared so far stops working, and returns the new URL. The same callers as
+// GetArtisanFeedInfo may rotate it.
+func (s *calendarService) RotateArtisanFeedToken(ctx context.Context, artisanID uuid.UUID, baseURL string) (*dto.CalendarFeedResponse, error) {
+	if _, err := s.getFeedArtisan(ctx, artisanID); err != nil {
+		return nil, err
+	}
+
+	version, err := s.repos.Artisan.RotateCalendarToken(ctx, artisanID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("artisan")
+		}
+		return nil, errors.NewServiceError("ROTATE_FAILED", "Failed to rotate calendar token", err)
+	}
+
+	s.logger.Info("calendar feed token rotated", "artisan_id", artisanID, "version", version)
+	return s.feedInfo(artisanID, version, baseURL)
+}
+
+// getFeedArtisan loads the artisan whose feed the request's actor may manage; anyone
+// else is told the artisan doesn't exist
+func (s *calendarService) getFeedArtisan(ctx context.Context, artisanID uuid.UUID) (*models.Artisan, error) {
+	artisan, err := s.repos.Artisan.GetByID(ctx, artisanID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("artisan")
+		}
+		return nil, errors.NewServiceError("GET_FAILED", "Failed to get artisan", err)
+	}
+	if !canGetArtisanFeed(ctx, artisan) {
+		return nil, errors.NewNotFoundError("artisan")
+	}
+	return artisan, nil
+}
+
+// feedInfo signs the subscription URL of an artisan's feed at a token version
+func (s *calendarService) feedInfo(artisanID uuid.UUID, version int, baseURL string) (*dto.CalendarFeedResponse, error) {
+	token, err := s.signer.Sign(artisanID, version)
+	if err != nil {
+		return nil, errors.NewServiceError("CALENDAR_UNAVAILABLE", "Calendar feeds are not configured", err)
+	}
+
+	feedURL := fmt.Sprintf("%s/api/v1/artisans/%s/calendar.ics?token=%s", strings.TrimRight(baseURL, "/"), artisanID, token)
+
+	return &dto.CalendarFeedResponse{
+		ArtisanID: artisanID,
+		Token:     token,
+		FeedURL:   feedURL,
+		WebcalURL: "webcal://" + strings.TrimPrefix(strings.TrimPrefix(feedURL, "https://"), "http://"),
+	}, nil
+}
+
+// canGetArtisanFeed checks the request's actor is the artisan or an owner or admin of
+// the artisan's tenant. A context without an actor is the platform acting on its own.
+func canGetArtisanFeed(ctx context.Context, artisan *models.Artisan) bool {
+	actor, ok := authz.ActorFromContext(ctx)
+	if !ok {
+		return true
+	}
+	if actor.ServiceAccount || actor.TenantID != artisan.TenantID {
+		return false
+	}
+	return actor.UserID == artisan.UserID ||
+		actor.Role == models.UserRoleTenantOwner || actor.Role == models.UserRoleTenantAdmin
+}
+
+// GetArtisanFeed renders an artisan's bookings as an iCalendar feed after verifying the
+// token against the artisan's current token version. An unknown artisan gets the same
+// answer as a wrong token.
+func (s *calendarService) GetArtisanFeed(ctx context.Context, artisanID uuid.UUID, token string) ([]byte, error) {
+	artisan, err := s.repos.Artisan.GetByID(ctx, artisanID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewUnauthorizedError("invalid calendar token")
+		}
+		return nil, errors.NewServiceError("GET_FAILED", "Failed to get artisan", err)
+	}
+
+	if err := s.signer.Verify(artisanID, artisan.CalendarTokenVersion, token); err != nil {
+		if stdErrors.Is(err, ical.ErrMissingSecret) {
+			return nil, errors.NewServiceError("CALENDAR_UNAVAILABLE", "Calendar feeds are not configured", err)
+		}
+		return nil, errors.NewUnauthorizedError("invalid calendar token")
+	}
+
+	now := time.Now()
+	bookings, err := s.repos.Booking.GetArtisanBookingsInRange(ctx, artisan.UserID, now.Add(-calendarFeedLookBack), now.Add(calendarFeedLookAhead))
+	if err != nil {
+		s.logger.Error("failed to load bookings for calendar feed", "artisan_id", artisanID, "error", err)
+		return nil, errors.NewServiceError("LIST_FAILED", "Failed to load bookings", err)
+	}
+
+	calendar := ical.NewCalendar(calendarProductID, "Krafti Vibe Bookings")
+	for _, booking := range bookings {
+		calendar.AddEvent(bookingEvent(booking))
+	}
+
+	return calendar.Bytes(), nil
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+// bookingCalendar builds a calendar invitation for a single booking
+func bookingCalendar(booking *models.Booking) *ical.Calendar {
+	calendar := ical.NewCalendar(calendarProductID, "")
+	calendar.Method = ical.MethodRequest
+	if booking.Status == models.BookingStatusCancelled {
+		calendar.Method = ical.MethodCancel
+	}
+	calendar.AddEvent(bookingEvent(booking))
+	return calendar
+}
+
+// bookingEvent maps a booking onto a VEVENT
+func bookingEvent(booking *models.Booking) ical.Event {
+	summary := "Booking"
+	if booking.Service != nil && booking.Service.Name != "" {
+		summary = booking.Service.Name
+	}
+
+	var description strings.Builder
+	fmt.Fprintf(&description, "Booking #%s", booking.ID.String()[:8])
+	if booking.Customer != nil {
+		fmt.Fprintf(&description, "\nCustomer: %s", booking.Customer.FullName())
+	}
+	if booking.Artisan != nil {
+		fmt.Fprintf(&description, "\nArtisan: %s", booking.Artisan.FullName())
+	}
+	if booking.CustomerNotes != "" {
+		fmt.Fprintf(&description, "\nNotes: %s", booking.CustomerNotes)
+	}
+
+	event := ical.Event{
+		UID:         fmt.Sprintf("booking-%s@kraftivibe", booking.ID),
+		Sequence:    booking.Version,
+		Summary:     summary,
+		Description: description.String(),
+		Start:       booking.StartTime,
+		End:         booking.EndTime,
+		Created:     booking.CreatedAt,
+		Modified:    booking.UpdatedAt,
+		Status:      bookingEventStatus(booking.Status),
+	}
+
+	if loc := booking.ServiceLocation; loc != nil {
+		parts := make([]string, 0, 4)
+		for _, p := range []string{loc.Address, loc.City, loc.State, loc.Country} {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+		event.Location = strings.Join(parts, ", ")
+	}
+
+	if booking.Artisan != nil {
+		event.Organizer = &ical.Attendee{Name: booking.Artisan.FullName(), Email: booking.Artisan.Email}
+	}
+	if booking.Customer != nil {
+		event.Attendees = append(event.Attendees, ical.Attendee{Name: booking.Customer.FullName(), Email: booking.Customer.Email})
+	}
+
+	return event
+}
+
+func bookingEventStatus(status models.BookingStatus) ical.EventStatus {
+	switch status {
+	case models.BookingStatusPending:
+		return ical.EventStatusTentative
+	case models.BookingStatusCancelled, models.BookingStatusNoShow:
+		return ical.EventStatusCancelled
+	default:
+		return ical.EventStatusConfirmed
+	}
+}
+
+// bookingICSAttachment encodes a booking invite as an email attachment descriptor
+func bookingICSAttachment(booking *models.Booking) map[string]any {
+	calendar := bookingCalendar(booking)
+	return map[string]any{
+		"filename":     fmt.Sprintf("booking-%s.ics", booking.ID.String()[:8]),
+		"content_type": ical.ContentType + "; method=" + string(calendar.Method),
+		"encoding":     "base64",
+		"content":      base64.StdEncoding.EncodeToString(calendar.Bytes()),
+	}
+}
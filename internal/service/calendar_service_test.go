@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubArtisanRepository returns a single artisan; its other methods aren't used
+type stubArtisanRepository struct {
+	repository.ArtisanRepository
+	artisan *models.Artisan
+}
+
+func (r *stubArtisanRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Artisan, error) {
+	if id != r.artisan.ID {
+		return nil, errors.NewNotFoundError("artisan")
+	}
+	return r.artisan, nil
+}
+
+func (r *stubArtisanRepository) RotateCalendarToken(ctx context.Context, id uuid.UUID) (int, error) {
+	if id != r.artisan.ID {
+		return 0, errors.NewNotFoundError("artisan")
+	}
+	r.artisan.CalendarTokenVersion++
+	return r.artisan.CalendarTokenVersion, nil
+}
+
+func TestCalendarService_GetArtisanFeedInfo(t *testing.T) {
+	artisan := &models.Artisan{UserID: uuid.New(), TenantID: uuid.New()}
+	artisan.ID = uuid.New()
+
+	repos := &repository.Repositories{Artisan: &stubArtisanRepository{artisan: artisan}}
+	svc := service.NewCalendarService(repos, &MockLogger{}, "feed-secret")
+
+	tests := []struct {
+		name    string
+		actor   *authz.Actor
+		allowed bool
+	}{
+		{name: "the artisan", actor: &authz.Actor{UserID: artisan.UserID, TenantID: artisan.TenantID, Role: models.UserRoleArtisan}, allowed: true},
+		{name: "an admin of the artisan's tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: artisan.TenantID, Role: models.UserRoleTenantAdmin}, allowed: true},
+		{name: "another artisan of the tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: artisan.TenantID, Role: models.UserRoleArtisan}},
+		{name: "a team member of the tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: artisan.TenantID, Role: models.UserRoleTeamMember}},
+		{name: "an owner of another tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: uuid.New(), Role: models.UserRoleTenantOwner}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.WithActor(context.Background(), tt.actor)
+			info, err := svc.GetArtisanFeedInfo(ctx, artisan.ID, "https://api.example.com")
+			if !tt.allowed {
+				assert.True(t, errors.IsNotFoundError(err))
+				assert.Nil(t, info)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, artisan.ID, info.ArtisanID)
+			assert.NotEmpty(t, info.Token)
+		})
+	}
+}
+
+func TestCalendarService_RotateArtisanFeedToken(t *testing.T) {
+	artisan := &models.Artisan{UserID: uuid.New(), TenantID: uuid.New(), CalendarTokenVersion: 1}
+	artisan.ID = uuid.New()
+
+	repos := &repository.Repositories{
+		Artisan: &stubArtisanRepository{artisan: artisan},
+		Booking: &stubImportBookingRepository{},
+	}
+	svc := service.NewCalendarService(repos, &MockLogger{}, "feed-secret")
+	ctx := authz.WithActor(context.Background(), &authz.Actor{UserID: artisan.UserID, TenantID: artisan.TenantID, Role: models.UserRoleArtisan})
+
+	old, err := svc.GetArtisanFeedInfo(ctx, artisan.ID, "https://api.example.com")
+	require.NoError(t, err)
+	_, err = svc.GetArtisanFeed(context.Background(), artisan.ID, old.Token)
+	require.NoError(t, err)
+
+	// Someone who may not see the feed may not rotate it either
+	other := authz.WithActor(context.Background(), &authz.Actor{UserID: uuid.New(), TenantID: artisan.TenantID, Role: models.UserRoleArtisan})
+	_, err = svc.RotateArtisanFeedToken(other, artisan.ID, "https://api.example.com")
+	assert.True(t, errors.IsNotFoundError(err))
+	assert.Equal(t, 1, artisan.CalendarTokenVersion)
+
+	rotated, err := svc.RotateArtisanFeedToken(ctx, artisan.ID, "https://api.example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, old.Token, rotated.Token)
+	assert.Contains(t, rotated.FeedURL, rotated.Token)
+
+	_, err = svc.GetArtisanFeed(context.Background(), artisan.ID, old.Token)
+	assert.ErrorContains(t, err, "invalid calendar token", "the old URL is revoked")
+	_, err = svc.GetArtisanFeed(context.Background(), artisan.ID, rotated.Token)
+	assert.NoError(t, err)
+
+	_, err = svc.GetArtisanFeed(context.Background(), uuid.New(), rotated.Token)
+	assert.ErrorContains(t, err, "invalid calendar token", "an unknown artisan looks like a wrong token")
+}
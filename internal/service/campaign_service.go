@@ -0,0 +1,515 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// campaignOpenPath is where the open tracking pixels of campaign emails lead,
+	// relative to the API base URL
+	campaignOpenPath = "/api/v1/campaigns/opens/"
+	// campaignStartBatchSize caps the due campaigns started per run
+	campaignStartBatchSize = 20
+)
+
+// CampaignService defines the interface for marketing campaign operations
+type CampaignService interface {
+	// Campaigns
+	CreateCampaign(ctx context.Context, tenantID, userID uuid.UUID, req *dto.CreateCampaignRequest) (*dto.CampaignResponse, error)
+	GetCampaign(ctx context.Context, tenantID, id uuid.UUID) (*dto.CampaignResponse, error)
+	ListCampaigns(ctx context.Context, tenantID uuid.UUID, status models.CampaignStatus, page, pageSize int) (*dto.CampaignListResponse, error)
+	UpdateCampaign(ctx context.Context, tenantID, id uuid.UUID, req *dto.UpdateCampaignRequest) (*dto.CampaignResponse, error)
+	DeleteCampaign(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// Sending
+	ScheduleCampaign(ctx context.Context, tenantID, id uuid.UUID, req *dto.ScheduleCampaignRequest) (*dto.CampaignResponse, error)
+	CancelCampaign(ctx context.Context, tenantID, id uuid.UUID) (*dto.CampaignResponse, error)
+	ProcessCampaigns(ctx context.Context) (int, error)
+
+	// Audience & Preview
+	CountAudience(ctx context.Context, tenantID uuid.UUID, req *dto.CampaignAudienceCountRequest) (*dto.CampaignAudienceCountResponse, error)
+	PreviewCampaign(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.CampaignPreviewResponse, error)
+
+	// Metrics
+	ListRecipients(ctx context.Context, tenantID, id uuid.UUID, status models.CampaignRecipientStatus, page, pageSize int) (*dto.CampaignRecipientListResponse, error)
+	RecordOpen(ctx context.Context, token string) error
+}
+
+// campaignService implements CampaignService
+type campaignService struct {
+	repos         *repository.Repositories
+	logger        log.AllLogger
+	notifications NotificationService
+}
+
+// NewCampaignService creates a new CampaignService instance
+func NewCampaignService(repos *repository.Repositories, logger log.AllLogger) CampaignService {
+	return &campaignService{
+		repos:         repos,
+		logger:        logger,
+		notifications: NewNotificationService(repos, logger),
+	}
+}
+
+// CreateCampaign creates a draft campaign for the tenant
+func (s *campaignService) CreateCampaign(ctx context.Context, tenantID, userID uuid.UUID, req *dto.CreateCampaignRequest) (*dto.CampaignResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	campaign := &models.Campaign{
+		TenantID:  tenantID,
+		CreatedBy: userID,
+		Name:      strings.TrimSpace(req.Name),
+		Audience:  req.Audience,
+		Channels:  req.Channels,
+		Subject:   req.Subject,
+		Body:      req.Body,
+		Status:    models.CampaignStatusDraft,
+		SendRate:  req.SendRate,
+	}
+	if campaign.SendRate == 0 {
+		campaign.SendRate = models.DefaultCampaignSendRate
+	}
+	if err := campaign.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.Campaign.Create(ctx, campaign); err != nil {
+		s.logger.Error("failed to create campaign", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewServiceError("CREATE_FAILED", "Failed to create campaign", err)
+	}
+
+	s.logger.Info("campaign created", "campaign_id", campaign.ID, "tenant_id", tenantID)
+	return dto.ToCampaignResponse(campaign), nil
+}
+
+// GetCampaign retrieves one of the tenant's campaigns with its metrics
+func (s *campaignService) GetCampaign(ctx context.Context, tenantID, id uuid.UUID) (*dto.CampaignResponse, error) {
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToCampaignResponse(campaign), nil
+}
+
+// ListCampaigns lists the tenant's campaigns, optionally only those in one status
+func (s *campaignService) ListCampaigns(ctx context.Context, tenantID uuid.UUID, status models.CampaignStatus, page, pageSize int) (*dto.CampaignListResponse, error) {
+	if status != "" && !status.IsValid() {
+		return nil, errors.NewValidationError("invalid campaign status")
+	}
+
+	campaigns, result, err := s.repos.Campaign.FindByTenant(ctx, tenantID, status, repository.PaginationParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to list campaigns", err)
+	}
+
+	return &dto.CampaignListResponse{
+		Campaigns:   dto.ToCampaignResponses(campaigns),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// UpdateCampaign changes a campaign that has not started sending. A scheduled campaign
+// stays scheduled.
+func (s *campaignService) UpdateCampaign(ctx context.Context, tenantID, id uuid.UUID, req *dto.UpdateCampaignRequest) (*dto.CampaignResponse, error) {
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.IsEditable() {
+		return nil, errors.NewConflictError("Only draft and scheduled campaigns can be changed")
+	}
+
+	if req.Name != nil {
+		campaign.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Audience != nil {
+		campaign.Audience = *req.Audience
+	}
+	if len(req.Channels) > 0 {
+		campaign.Channels = req.Channels
+	}
+	if req.Subject != nil {
+		campaign.Subject = *req.Subject
+	}
+	if req.Body != nil {
+		campaign.Body = *req.Body
+	}
+	if req.SendRate != nil {
+		campaign.SendRate = *req.SendRate
+	}
+	if err := campaign.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.Campaign.Update(ctx, campaign); err != nil {
+		s.logger.Error("failed to update campaign", "campaign_id", id, "error", err)
+		return nil, errors.NewServiceError("UPDATE_FAILED", "Failed to update campaign", err)
+	}
+
+	s.logger.Info("campaign updated", "campaign_id", id)
+	return dto.ToCampaignResponse(campaign), nil
+}
+
+// DeleteCampaign deletes a campaign that is not scheduled or being sent
+func (s *campaignService) DeleteCampaign(ctx context.Context, tenantID, id uuid.UUID) error {
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if campaign.Status == models.CampaignStatusScheduled || campaign.Status == models.CampaignStatusSending {
+		return errors.NewConflictError("Cancel the campaign before deleting it")
+	}
+
+	if err := s.repos.Campaign.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete campaign", "campaign_id", id, "error", err)
+		return errors.NewServiceError("DELETE_FAILED", "Failed to delete campaign", err)
+	}
+
+	s.logger.Info("campaign deleted", "campaign_id", id)
+	return nil
+}
+
+// ScheduleCampaign schedules a draft campaign, or reschedules a scheduled one, to be
+// sent at the requested time or right away. Its recipients are resolved when it starts.
+func (s *campaignService) ScheduleCampaign(ctx context.Context, tenantID, id uuid.UUID, req *dto.ScheduleCampaignRequest) (*dto.CampaignResponse, error) {
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.IsEditable() {
+		return nil, errors.NewConflictError("Only draft and scheduled campaigns can be scheduled")
+	}
+	if err := campaign.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	now := time.Now()
+	scheduledAt := now
+	if req.ScheduledAt != nil {
+		if req.ScheduledAt.Before(now) {
+			return nil, errors.NewValidationError("scheduled_at must be in the future")
+		}
+		scheduledAt = *req.ScheduledAt
+	}
+
+	count, err := s.repos.Campaign.CountAudience(ctx, tenantID, campaign.Audience)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to count campaign audience", err)
+	}
+	if count == 0 {
+		return nil, errors.NewValidationError("The campaign's audience selects no customers")
+	}
+
+	campaign.Status = models.CampaignStatusScheduled
+	campaign.ScheduledAt = &scheduledAt
+	if err := s.repos.Campaign.Update(ctx, campaign); err != nil {
+		s.logger.Error("failed to schedule campaign", "campaign_id", id, "error", err)
+		return nil, errors.NewServiceError("UPDATE_FAILED", "Failed to schedule campaign", err)
+	}
+
+	s.logger.Info("campaign scheduled", "campaign_id", id, "scheduled_at", scheduledAt, "audience", count)
+	return dto.ToCampaignResponse(campaign), nil
+}
+
+// CancelCampaign stops a campaign that has not finished; recipients not sent to yet are
+// skipped
+func (s *campaignService) CancelCampaign(ctx context.Context, tenantID, id uuid.UUID) (*dto.CampaignResponse, error) {
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.CanCancel() {
+		return nil, errors.NewConflictError("The campaign has already finished")
+	}
+
+	if err := s.repos.Campaign.Cancel(ctx, campaign, time.Now()); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewConflictError("The campaign has already finished")
+		}
+		s.logger.Error("failed to cancel campaign", "campaign_id", id, "error", err)
+		return nil, errors.NewServiceError("UPDATE_FAILED", "Failed to cancel campaign", err)
+	}
+
+	s.logger.Info("campaign cancelled", "campaign_id", id, "skipped", campaign.SkippedCount)
+	return dto.ToCampaignResponse(campaign), nil
+}
+
+// ProcessCampaigns starts the scheduled campaigns that are due and sends each campaign
+// being sent its next batch of at most its send rate, returning how many recipients were
+// sent to. Run every minute, this throttles campaigns to their send rate a minute.
+func (s *campaignService) ProcessCampaigns(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := s.repos.Campaign.FindDue(ctx, now, campaignStartBatchSize)
+	if err != nil {
+		return 0, errors.NewServiceError("CAMPAIGN_SEND_FAILED", "failed to find due campaigns", err)
+	}
+	for _, campaign := range due {
+		if err := s.startCampaign(ctx, campaign, now); err != nil {
+			s.logger.Error("failed to start campaign", "campaign_id", campaign.ID, "error", err)
+		}
+	}
+
+	sending, err := s.repos.Campaign.FindSending(ctx)
+	if err != nil {
+		return 0, errors.NewServiceError("CAMPAIGN_SEND_FAILED", "failed to find sending campaigns", err)
+	}
+
+	sent := 0
+	for _, campaign := range sending {
+		count, err := s.sendBatch(ctx, campaign)
+		if err != nil {
+			s.logger.Error("failed to send campaign batch", "campaign_id", campaign.ID, "error", err)
+			continue
+		}
+		sent += count
+	}
+
+	if sent > 0 {
+		s.logger.Info("campaign messages sent", "count", sent)
+	}
+	return sent, nil
+}
+
+// startCampaign resolves a due campaign's audience into its recipients, each with an
+// open tracking token, and moves it to sending
+func (s *campaignService) startCampaign(ctx context.Context, campaign *models.Campaign, now time.Time) error {
+	userIDs, err := s.repos.Campaign.FindAudienceUserIDs(ctx, campaign.TenantID, campaign.Audience)
+	if err != nil {
+		return err
+	}
+
+	recipients := make([]*models.CampaignRecipient, len(userIDs))
+	for i, userID := range userIDs {
+		token, err := newCampaignOpenToken()
+		if err != nil {
+			return err
+		}
+		recipients[i] = &models.CampaignRecipient{
+			TenantID:   campaign.TenantID,
+			CampaignID: campaign.ID,
+			CustomerID: userID,
+			Status:     models.CampaignRecipientStatusPending,
+			OpenToken:  token,
+		}
+	}
+
+	started, err := s.repos.Campaign.Start(ctx, campaign, recipients, now)
+	if err != nil || !started {
+		return err
+	}
+
+	s.logger.Info("campaign started", "campaign_id", campaign.ID, "recipients", campaign.RecipientCount)
+	return nil
+}
+
+// sendBatch sends the campaign to its next pending recipients and completes it once
+// none are left
+func (s *campaignService) sendBatch(ctx context.Context, campaign *models.Campaign) (int, error) {
+	recipients, err := s.repos.Campaign.FindPendingRecipients(ctx, campaign.ID, campaign.SendRate)
+	if err != nil {
+		return 0, err
+	}
+
+	tenantName := ""
+	if tenant, err := s.repos.Tenant.GetByID(ctx, campaign.TenantID); err == nil {
+		tenantName = tenant.Name
+	}
+
+	now := time.Now()
+	sent := 0
+	for _, recipient := range recipients {
+		channels, reason := s.recipientChannels(ctx, campaign, recipient)
+		if len(channels) == 0 {
+			recipient.Status = models.CampaignRecipientStatusSkipped
+			recipient.SkipReason = reason
+			continue
+		}
+
+		subject, body := campaign.Render(models.CampaignTemplateData{
+			FirstName:  recipient.Customer.FirstName,
+			LastName:   recipient.Customer.LastName,
+			TenantName: tenantName,
+		})
+		delivery, err := s.notifications.SendCampaignNotification(ctx, campaign, recipient, subject, body, channels, campaignOpenPath+recipient.OpenToken)
+		if err != nil {
+			s.logger.Error("failed to send campaign message", "campaign_id", campaign.ID, "customer_id", recipient.CustomerID, "error", err)
+			recipient.Status = models.CampaignRecipientStatusFailed
+			continue
+		}
+
+		recipient.Status = models.CampaignRecipientStatusSent
+		recipient.NotificationID = &delivery.NotificationID
+		recipient.SentAt = &now
+		sent++
+	}
+
+	if err := s.repos.Campaign.SaveRecipientResults(ctx, campaign.ID, recipients); err != nil {
+		return sent, err
+	}
+
+	if len(recipients) < campaign.SendRate {
+		completed, err := s.repos.Campaign.Complete(ctx, campaign.ID, now)
+		if err != nil {
+			return sent, err
+		}
+		if completed {
+			s.logger.Info("campaign sent", "campaign_id", campaign.ID)
+		}
+	}
+	return sent, nil
+}
+
+// recipientChannels returns the campaign's channels the recipient consented to marketing
+// on and can be reached by, or why there are none
+func (s *campaignService) recipientChannels(ctx context.Context, campaign *models.Campaign, recipient *models.CampaignRecipient) ([]models.NotificationChannel, string) {
+	user := recipient.Customer
+	if user == nil || !user.MarketingConsent {
+		return nil, models.CampaignSkipNoConsent
+	}
+
+	preferences, err := findCommunicationPreferences(ctx, s.repos, user.ID)
+	if err != nil {
+		s.logger.Error("failed to get communication preferences", "user_id", user.ID, "error", err)
+		return nil, models.CampaignSkipNoConsent
+	}
+
+	consented := false
+	channels := make([]models.NotificationChannel, 0, len(campaign.Channels))
+	for _, channel := range campaign.Channels {
+		if !preferences.Allows(channel, models.CommunicationCategoryMarketing) {
+			continue
+		}
+		consented = true
+		if (channel == models.NotificationChannelEmail && user.Email != "") ||
+			(channel == models.NotificationChannelSMS && user.PhoneNumber != "") {
+			channels = append(channels, channel)
+		}
+	}
+	if !consented {
+		return nil, models.CampaignSkipNoConsent
+	}
+	if len(channels) == 0 {
+		return nil, models.CampaignSkipNoContact
+	}
+	return channels, ""
+}
+
+// CountAudience counts the tenant's customers an audience selects, before a campaign
+// is saved
+func (s *campaignService) CountAudience(ctx context.Context, tenantID uuid.UUID, req *dto.CampaignAudienceCountRequest) (*dto.CampaignAudienceCountResponse, error) {
+	if err := req.Audience.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	count, err := s.repos.Campaign.CountAudience(ctx, tenantID, req.Audience)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to count campaign audience", err)
+	}
+
+	return &dto.CampaignAudienceCountResponse{Count: count}, nil
+}
+
+// PreviewCampaign renders the campaign for the requesting user and counts its audience
+func (s *campaignService) PreviewCampaign(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.CampaignPreviewResponse, error) {
+	campaign, err := s.findCampaign(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var data models.CampaignTemplateData
+	if user, err := s.repos.User.GetByID(ctx, userID); err == nil {
+		data.FirstName, data.LastName = user.FirstName, user.LastName
+	}
+	if tenant, err := s.repos.Tenant.GetByID(ctx, tenantID); err == nil {
+		data.TenantName = tenant.Name
+	}
+
+	count, err := s.repos.Campaign.CountAudience(ctx, tenantID, campaign.Audience)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to count campaign audience", err)
+	}
+
+	subject, body := campaign.Render(data)
+	rate := int64(max(campaign.SendRate, 1))
+	return &dto.CampaignPreviewResponse{
+		Subject:          subject,
+		Body:             body,
+		AudienceCount:    count,
+		EstimatedMinutes: int((count + rate - 1) / rate),
+	}, nil
+}
+
+// ListRecipients lists a campaign's recipients with how sending to them went
+func (s *campaignService) ListRecipients(ctx context.Context, tenantID, id uuid.UUID, status models.CampaignRecipientStatus, page, pageSize int) (*dto.CampaignRecipientListResponse, error) {
+	if _, err := s.findCampaign(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+
+	recipients, result, err := s.repos.Campaign.FindRecipients(ctx, id, status, repository.PaginationParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to list campaign recipients", err)
+	}
+
+	return &dto.CampaignRecipientListResponse{
+		Recipients:  dto.ToCampaignRecipientResponses(recipients),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// RecordOpen records that the campaign email with the open token was opened
+func (s *campaignService) RecordOpen(ctx context.Context, token string) error {
+	if token == "" {
+		return errors.NewNotFoundError("campaign recipient")
+	}
+
+	if _, err := s.repos.Campaign.RecordOpen(ctx, token, time.Now()); err != nil {
+		if errors.IsNotFoundError(err) {
+			return errors.NewNotFoundError("campaign recipient")
+		}
+		return errors.NewServiceError("UPDATE_FAILED", "Failed to record campaign open", err)
+	}
+	return nil
+}
+
+// findCampaign returns one of the tenant's campaigns
+func (s *campaignService) findCampaign(ctx context.Context, tenantID, id uuid.UUID) (*models.Campaign, error) {
+	campaign, err := s.repos.Campaign.GetByID(ctx, id)
+	if err != nil || campaign.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("campaign")
+	}
+	return campaign, nil
+}
+
+// newCampaignOpenToken returns a random token identifying a campaign recipient in the
+// open tracking pixel
+func newCampaignOpenToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// CancellationPolicyService defines the interface for cancellation policy operations
+type CancellationPolicyService interface {
+	// CRUD Operations
+	CreatePolicy(ctx context.Context, req *dto.CreateCancellationPolicyRequest) (*dto.CancellationPolicyResponse, error)
+	GetPolicy(ctx context.Context, id uuid.UUID) (*dto.CancellationPolicyResponse, error)
+	UpdatePolicy(ctx context.Context, id uuid.UUID, req *dto.UpdateCancellationPolicyRequest) (*dto.CancellationPolicyResponse, error)
+	DeletePolicy(ctx context.Context, id uuid.UUID) error
+	ListPolicies(ctx context.Context, tenantID uuid.UUID) ([]*dto.CancellationPolicyResponse, error)
+
+	// Evaluation
+	GetEffectivePolicy(ctx context.Context, tenantID, serviceID uuid.UUID) (*models.CancellationPolicy, error)
+	EvaluateCancellation(ctx context.Context, booking *models.Booking, at time.Time) (*models.CancellationBreakdown, error)
+	QuoteCancellation(ctx context.Context, bookingID uuid.UUID) (*dto.CancellationQuoteResponse, error)
+}
+
+// cancellationPolicyService implements CancellationPolicyService
+type cancellationPolicyService struct {
+	repos      *repository.Repositories
+	logger     log.AllLogger
+	authorizer Authorizer
+}
+
+// NewCancellationPolicyService creates a new CancellationPolicyService instance
+func NewCancellationPolicyService(repos *repository.Repositories, logger log.AllLogger) CancellationPolicyService {
+	return &cancellationPolicyService{
+		repos:      repos,
+		logger:     logger,
+		authorizer: NewAuthorizer(repos, logger),
+	}
+}
+
+// ============================================================================
+// CRUD Operations
+// ============================================================================
+
+// CreatePolicy creates a tenant-wide or service-specific cancellation policy
+func (s *cancellationPolicyService) CreatePolicy(ctx context.Context, req *dto.CreateCancellationPolicyRequest) (*dto.CancellationPolicyResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	if req.ServiceID != nil {
+		svc, err := s.repos.Service.GetByID(ctx, *req.ServiceID)
+		if err != nil {
+			if errors.IsNotFoundError(err) {
+				return nil, errors.NewNotFoundError("service not found")
+			}
+			return nil, errors.NewServiceError("SERVICE_GET_FAILED", "failed to get service", err)
+		}
+		if svc.TenantID != req.TenantID {
+			return nil, errors.NewValidationError("service does not belong to tenant")
+		}
+	}
+
+	policy := &models.CancellationPolicy{
+		TenantID:              req.TenantID,
+		ServiceID:             req.ServiceID,
+		Name:                  req.Name,
+		Description:           req.Description,
+		FreeCancellationHours: models.DefaultFreeCancellationHours,
+		RefundTiers:           models.RefundTierArray(req.RefundTiers),
+		CancellationFeeFixed:  req.CancellationFeeFixed,
+		NoShowFeePercentage:   models.DefaultNoShowFeePercentage,
+		IsActive:              true,
+	}
+	if req.FreeCancellationHours != nil {
+		policy.FreeCancellationHours = *req.FreeCancellationHours
+	}
+	if req.NoShowFeePercentage != nil {
+		policy.NoShowFeePercentage = *req.NoShowFeePercentage
+	}
+	if req.IsActive != nil {
+		policy.IsActive = *req.IsActive
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.CancellationPolicy.Create(ctx, policy); err != nil {
+		s.logger.Error("failed to create cancellation policy", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("POLICY_CREATE_FAILED", "failed to create cancellation policy", err)
+	}
+
+	s.logger.Info("cancellation policy created", "policy_id", policy.ID, "tenant_id", policy.TenantID)
+	return dto.ToCancellationPolicyResponse(policy), nil
+}
+
+// GetPolicy retrieves a cancellation policy by ID
+func (s *cancellationPolicyService) GetPolicy(ctx context.Context, id uuid.UUID) (*dto.CancellationPolicyResponse, error) {
+	policy, err := s.getPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToCancellationPolicyResponse(policy), nil
+}
+
+// UpdatePolicy updates a cancellation policy
+func (s *cancellationPolicyService) UpdatePolicy(ctx context.Context, id uuid.UUID, req *dto.UpdateCancellationPolicyRequest) (*dto.CancellationPolicyResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	policy, err := s.getPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		policy.Name = *req.Name
+	}
+	if req.Description != nil {
+		policy.Description = *req.Description
+	}
+	if req.FreeCancellationHours != nil {
+		policy.FreeCancellationHours = *req.FreeCancellationHours
+	}
+	if req.RefundTiers != nil {
+		policy.RefundTiers = models.RefundTierArray(req.RefundTiers)
+	}
+	if req.CancellationFeeFixed != nil {
+		policy.CancellationFeeFixed = *req.CancellationFeeFixed
+	}
+	if req.NoShowFeePercentage != nil {
+		policy.NoShowFeePercentage = *req.NoShowFeePercentage
+	}
+	if req.IsActive != nil {
+		policy.IsActive = *req.IsActive
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.CancellationPolicy.Update(ctx, policy); err != nil {
+		s.logger.Error("failed to update cancellation policy", "policy_id", id, "error", err)
+		return nil, errors.NewServiceError("POLICY_UPDATE_FAILED", "failed to update cancellation policy", err)
+	}
+
+	return dto.ToCancellationPolicyResponse(policy), nil
+}
+
+// DeletePolicy deletes a cancellation policy
+func (s *cancellationPolicyService) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getPolicy(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repos.CancellationPolicy.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete cancellation policy", "policy_id", id, "error", err)
+		return errors.NewServiceError("POLICY_DELETE_FAILED", "failed to delete cancellation policy", err)
+	}
+
+	return nil
+}
+
+// ListPolicies lists all cancellation policies of a tenant
+func (s *cancellationPolicyService) ListPolicies(ctx context.Context, tenantID uuid.UUID) ([]*dto.CancellationPolicyResponse, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	policies, err := s.repos.CancellationPolicy.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("POLICY_LIST_FAILED", "failed to list cancellation policies", err)
+	}
+
+	return dto.ToCancellationPolicyResponses(policies), nil
+}
+
+// ============================================================================
+// Evaluation
+// ============================================================================
+
+// GetEffectivePolicy returns the policy that applies to a service, falling back to the
+// refund rules in the tenant settings when no policy has been configured
+func (s *cancellationPolicyService) GetEffectivePolicy(ctx context.Context, tenantID, serviceID uuid.UUID) (*models.CancellationPolicy, error) {
+	policy, err := s.repos.CancellationPolicy.FindEffective(ctx, tenantID, serviceID)
+	if err == nil {
+		return policy, nil
+	}
+	if !errors.IsNotFoundError(err) {
+		return nil, errors.NewServiceError("POLICY_GET_FAILED", "failed to get cancellation policy", err)
+	}
+
+	settings := models.GetDefaultTenantSettings()
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		s.logger.Warn("failed to load tenant refund settings, using defaults", "tenant_id", tenantID, "error", err)
+	} else {
+		settings = tenant.Settings
+	}
+
+	return models.CancellationPolicyFromSettings(tenantID, settings), nil
+}
+
+// EvaluateCancellation computes the refund breakdown for cancelling the booking at the given time
+func (s *cancellationPolicyService) EvaluateCancellation(ctx context.Context, booking *models.Booking, at time.Time) (*models.CancellationBreakdown, error) {
+	policy, err := s.GetEffectivePolicy(ctx, booking.TenantID, booking.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := policy.EvaluateCancellation(booking, at)
+	return &breakdown, nil
+}
+
+// QuoteCancellation shows what the customer would get back if the booking were cancelled now
+func (s *cancellationPolicyService) QuoteCancellation(ctx context.Context, bookingID uuid.UUID) (*dto.CancellationQuoteResponse, error) {
+	booking, err := s.repos.Booking.GetByID(ctx, bookingID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("booking not found")
+		}
+		return nil, errors.NewServiceError("BOOKING_GET_FAILED", "failed to get booking", err)
+	}
+	if err := s.authorizer.Can(ctx, authz.ActionBookingRead, authz.Booking(booking)); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.GetEffectivePolicy(ctx, booking.TenantID, booking.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	breakdown := policy.EvaluateCancellation(booking, now)
+
+	return &dto.CancellationQuoteResponse{
+		BookingID:   booking.ID,
+		Policy:      dto.ToCancellationPolicyResponse(policy),
+		Breakdown:   breakdown,
+		Currency:    booking.Currency,
+		CanCancel:   booking.CanBeCancelled(),
+		QuotedAt:    now,
+		Explanation: explainCancellation(policy, breakdown, booking.Currency),
+	}, nil
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+func (s *cancellationPolicyService) getPolicy(ctx context.Context, id uuid.UUID) (*models.CancellationPolicy, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("policy ID is required")
+	}
+
+	policy, err := s.repos.CancellationPolicy.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("cancellation policy not found")
+		}
+		return nil, errors.NewServiceError("POLICY_GET_FAILED", "failed to get cancellation policy", err)
+	}
+
+	return policy, nil
+}
+
+// explainCancellation renders the breakdown as a sentence the customer can read before confirming
+func explainCancellation(policy *models.CancellationPolicy, b models.CancellationBreakdown, currency string) string {
+	if b.WithinFreeWindow {
+		return fmt.Sprintf("Free cancellation: cancelling at least %.0f hours before the start refunds %.2f %s in full.",
+			policy.FreeCancellationHours, b.RefundAmount, currency)
+	}
+
+	msg := fmt.Sprintf("Cancelling %.1f hours before the start refunds %.0f%% of the %.2f %s paid",
+		b.HoursBeforeStart, b.RefundPercentage, b.AmountPaid, currency)
+	if b.CancellationFee > 0 {
+		msg += fmt.Sprintf(", less a %.2f %s cancellation fee", b.CancellationFee, currency)
+	}
+	return msg + fmt.Sprintf(": %.2f %s.", b.RefundAmount, currency)
+}
@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCancellationPolicyRepository keeps policies in memory; its other methods aren't used
+type stubCancellationPolicyRepository struct {
+	repository.CancellationPolicyRepository
+	policies map[uuid.UUID]models.CancellationPolicy
+}
+
+func (r *stubCancellationPolicyRepository) Create(ctx context.Context, policy *models.CancellationPolicy) error {
+	policy.ID = uuid.New()
+	r.policies[policy.ID] = *policy
+	return nil
+}
+
+func (r *stubCancellationPolicyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CancellationPolicy, error) {
+	policy, ok := r.policies[id]
+	if !ok {
+		return nil, errors.NewNotFoundError("cancellation policy")
+	}
+	return &policy, nil
+}
+
+func (r *stubCancellationPolicyRepository) Update(ctx context.Context, policy *models.CancellationPolicy) error {
+	r.policies[policy.ID] = *policy
+	return nil
+}
+
+func (r *stubCancellationPolicyRepository) FindEffective(ctx context.Context, tenantID, serviceID uuid.UUID) (*models.CancellationPolicy, error) {
+	for _, policy := range r.policies {
+		if policy.TenantID == tenantID && policy.IsActive {
+			return &policy, nil
+		}
+	}
+	return nil, errors.NewNotFoundError("cancellation policy")
+}
+
+// stubBookingRepository returns a single booking; its other methods aren't used
+type stubBookingRepository struct {
+	repository.BookingRepository
+	booking *models.Booking
+}
+
+func (r *stubBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Booking, error) {
+	if id != r.booking.ID {
+		return nil, errors.NewNotFoundError("booking")
+	}
+	return r.booking, nil
+}
+
+func setupCancellationPolicyServiceTest() (*stubCancellationPolicyRepository, service.CancellationPolicyService) {
+	policyRepo := &stubCancellationPolicyRepository{policies: map[uuid.UUID]models.CancellationPolicy{}}
+	repos := &repository.Repositories{CancellationPolicy: policyRepo}
+	return policyRepo, service.NewCancellationPolicyService(repos, &MockLogger{})
+}
+
+func TestCancellationPolicyService_CreatePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("settings left out get their defaults", func(t *testing.T) {
+		policyRepo, svc := setupCancellationPolicyServiceTest()
+
+		resp, err := svc.CreatePolicy(ctx, &dto.CreateCancellationPolicyRequest{TenantID: uuid.New(), Name: "Standard"})
+		require.NoError(t, err)
+
+		stored := policyRepo.policies[resp.ID]
+		assert.Equal(t, float64(models.DefaultFreeCancellationHours), stored.FreeCancellationHours)
+		assert.Equal(t, float64(models.DefaultNoShowFeePercentage), stored.NoShowFeePercentage)
+		assert.True(t, stored.IsActive)
+	})
+
+	t.Run("zero settings are kept", func(t *testing.T) {
+		policyRepo, svc := setupCancellationPolicyServiceTest()
+		zero := 0.0
+		inactive := false
+
+		resp, err := svc.CreatePolicy(ctx, &dto.CreateCancellationPolicyRequest{
+			TenantID:              uuid.New(),
+			Name:                  "Lenient",
+			FreeCancellationHours: &zero,
+			NoShowFeePercentage:   &zero,
+			IsActive:              &inactive,
+		})
+		require.NoError(t, err)
+
+		stored := policyRepo.policies[resp.ID]
+		assert.Zero(t, stored.FreeCancellationHours)
+		assert.Zero(t, stored.NoShowFeePercentage)
+		assert.False(t, stored.IsActive)
+	})
+}
+
+func TestCancellationPolicyService_UpdatePolicy(t *testing.T) {
+	ctx := context.Background()
+	policyRepo, svc := setupCancellationPolicyServiceTest()
+
+	created, err := svc.CreatePolicy(ctx, &dto.CreateCancellationPolicyRequest{TenantID: uuid.New(), Name: "Standard"})
+	require.NoError(t, err)
+
+	zero := 0.0
+	inactive := false
+	resp, err := svc.UpdatePolicy(ctx, created.ID, &dto.UpdateCancellationPolicyRequest{
+		NoShowFeePercentage: &zero,
+		IsActive:            &inactive,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, resp.NoShowFeePercentage)
+	assert.False(t, resp.IsActive)
+
+	stored := policyRepo.policies[created.ID]
+	assert.Zero(t, stored.NoShowFeePercentage)
+	assert.False(t, stored.IsActive)
+}
+
+func TestCancellationPolicyService_QuoteCancellation(t *testing.T) {
+	booking := &models.Booking{
+		TenantID:   uuid.New(),
+		CustomerID: uuid.New(),
+		ArtisanID:  uuid.New(),
+		ServiceID:  uuid.New(),
+		StartTime:  time.Now().Add(72 * time.Hour),
+		Currency:   "USD",
+	}
+	booking.ID = uuid.New()
+
+	policyRepo := &stubCancellationPolicyRepository{policies: map[uuid.UUID]models.CancellationPolicy{}}
+	repos := &repository.Repositories{CancellationPolicy: policyRepo, Booking: &stubBookingRepository{booking: booking}}
+	svc := service.NewCancellationPolicyService(repos, &MockLogger{})
+
+	_, err := svc.CreatePolicy(context.Background(), &dto.CreateCancellationPolicyRequest{TenantID: booking.TenantID, Name: "Standard"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		actor   *authz.Actor
+		allowed bool
+	}{
+		{name: "the customer", actor: &authz.Actor{UserID: booking.CustomerID, TenantID: booking.TenantID, Role: models.UserRoleCustomer}, allowed: true},
+		{name: "the artisan", actor: &authz.Actor{UserID: booking.ArtisanID, TenantID: booking.TenantID, Role: models.UserRoleArtisan}, allowed: true},
+		{name: "an admin of the booking's tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: booking.TenantID, Role: models.UserRoleTenantAdmin}, allowed: true},
+		{name: "another customer of the tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: booking.TenantID, Role: models.UserRoleCustomer}},
+		{name: "a customer of another tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: uuid.New(), Role: models.UserRoleCustomer}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.WithActor(context.Background(), tt.actor)
+			quote, err := svc.QuoteCancellation(ctx, booking.ID)
+			if !tt.allowed {
+				assert.True(t, errors.IsForbidden(err))
+				assert.Nil(t, quote)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, booking.ID, quote.BookingID)
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// ChangeOrderService defines the interface for project change orders
+type ChangeOrderService interface {
+	// Staff
+	CreateChangeOrder(ctx context.Context, tenantID, userID uuid.UUID, req *dto.CreateChangeOrderRequest) (*dto.ChangeOrderResponse, error)
+	GetChangeOrder(ctx context.Context, tenantID, id uuid.UUID) (*dto.ChangeOrderResponse, error)
+	UpdateChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.UpdateChangeOrderRequest) (*dto.ChangeOrderResponse, error)
+	ListChangeOrders(ctx context.Context, filter dto.ChangeOrderFilter) (*dto.ChangeOrderListResponse, error)
+	SubmitChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.ChangeOrderResponse, error)
+	CancelChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.CancelChangeOrderRequest) (*dto.ChangeOrderResponse, error)
+	GetChangeOrderHistory(ctx context.Context, tenantID, id uuid.UUID) ([]*dto.ChangeOrderEventResponse, error)
+
+	// Customers
+	ListCustomerChangeOrders(ctx context.Context, tenantID, userID uuid.UUID, page, pageSize int) (*dto.ChangeOrderListResponse, error)
+	GetCustomerChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.ChangeOrderResponse, error)
+	ApproveChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.ChangeOrderApprovalResponse, error)
+	RejectChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.RejectChangeOrderRequest) (*dto.ChangeOrderResponse, error)
+}
+
+// changeOrderService implements ChangeOrderService
+type changeOrderService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewChangeOrderService creates a new ChangeOrderService instance
+func NewChangeOrderService(repos *repository.Repositories, logger log.AllLogger) ChangeOrderService {
+	return &changeOrderService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ============================================================================
+// Staff
+// ============================================================================
+
+// CreateChangeOrder drafts a change order for a project of the tenant
+func (s *changeOrderService) CreateChangeOrder(ctx context.Context, tenantID, userID uuid.UUID, req *dto.CreateChangeOrderRequest) (*dto.ChangeOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	project, err := s.getProject(ctx, tenantID, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Status == models.ProjectStatusCompleted || project.Status == models.ProjectStatusCancelled {
+		return nil, errors.NewValidationError("change orders cannot be raised on a " + string(project.Status) + " project")
+	}
+
+	changeOrder := &models.ChangeOrder{
+		TenantID:          tenantID,
+		ProjectID:         project.ID,
+		Title:             strings.TrimSpace(req.Title),
+		Description:       req.Description,
+		Reason:            req.Reason,
+		Status:            models.ChangeOrderStatusDraft,
+		PriceDelta:        req.PriceDelta,
+		ScheduleDeltaDays: req.ScheduleDeltaDays,
+		Currency:          project.Currency,
+		RequestedByID:     &userID,
+	}
+	if err := changeOrder.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	event := changeOrder.NewEvent(models.ChangeOrderActionCreated, "", &userID, "")
+	if err := s.repos.ChangeOrder.CreateWithEvent(ctx, changeOrder, event); err != nil {
+		s.logger.Error("failed to create change order", "project_id", project.ID, "error", err)
+		return nil, errors.NewServiceError("CHANGE_ORDER_CREATE_FAILED", "failed to create change order", err)
+	}
+
+	s.logger.Info("change order created", "change_order_id", changeOrder.ID, "project_id", project.ID, "number", changeOrder.Number)
+	return dto.ToChangeOrderResponse(changeOrder), nil
+}
+
+// GetChangeOrder retrieves a change order by ID
+func (s *changeOrderService) GetChangeOrder(ctx context.Context, tenantID, id uuid.UUID) (*dto.ChangeOrderResponse, error) {
+	changeOrder, err := s.getChangeOrder(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToChangeOrderResponse(changeOrder), nil
+}
+
+// UpdateChangeOrder edits a draft change order
+func (s *changeOrderService) UpdateChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.UpdateChangeOrderRequest) (*dto.ChangeOrderResponse, error) {
+	changeOrder, err := s.getChangeOrder(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !changeOrder.CanEdit() {
+		return nil, errors.NewValidationError("only draft change orders can be edited")
+	}
+
+	if req.Title != nil {
+		changeOrder.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		changeOrder.Description = *req.Description
+	}
+	if req.Reason != nil {
+		changeOrder.Reason = *req.Reason
+	}
+	if req.PriceDelta != nil {
+		changeOrder.PriceDelta = *req.PriceDelta
+	}
+	if req.ScheduleDeltaDays != nil {
+		changeOrder.ScheduleDeltaDays = *req.ScheduleDeltaDays
+	}
+	if err := changeOrder.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	event := changeOrder.NewEvent(models.ChangeOrderActionUpdated, models.ChangeOrderStatusDraft, &userID, "")
+	if err := s.save(ctx, changeOrder, models.ChangeOrderStatusDraft, event); err != nil {
+		return nil, err
+	}
+	return dto.ToChangeOrderResponse(changeOrder), nil
+}
+
+// ListChangeOrders lists a tenant's change orders matching the filter
+func (s *changeOrderService) ListChangeOrders(ctx context.Context, filter dto.ChangeOrderFilter) (*dto.ChangeOrderListResponse, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant_id is required")
+	}
+
+	return s.listChangeOrders(ctx, repository.ChangeOrderFilter{
+		TenantID:  filter.TenantID,
+		ProjectID: filter.ProjectID,
+		Statuses:  filter.Statuses,
+	}, filter.Page, filter.PageSize)
+}
+
+// SubmitChangeOrder sends a draft change order to the project's customer for approval
+func (s *changeOrderService) SubmitChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.ChangeOrderResponse, error) {
+	changeOrder, err := s.getChangeOrder(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.getProject(ctx, tenantID, changeOrder.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.CustomerID == nil {
+		return nil, errors.NewValidationError("the project has no customer to approve the change order")
+	}
+
+	if err := changeOrder.Submit(time.Now(), project); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	event := changeOrder.NewEvent(models.ChangeOrderActionSubmitted, models.ChangeOrderStatusDraft, &userID, "")
+	if err := s.save(ctx, changeOrder, models.ChangeOrderStatusDraft, event); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("change order submitted", "change_order_id", changeOrder.ID, "project_id", project.ID)
+	return dto.ToChangeOrderResponse(changeOrder), nil
+}
+
+// CancelChangeOrder withdraws a change order the customer has not answered
+func (s *changeOrderService) CancelChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.CancelChangeOrderRequest) (*dto.ChangeOrderResponse, error) {
+	changeOrder, err := s.getChangeOrder(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	loadedStatus := changeOrder.Status
+	if err := changeOrder.Cancel(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	event := changeOrder.NewEvent(models.ChangeOrderActionCancelled, loadedStatus, &userID, req.Note)
+	if err := s.save(ctx, changeOrder, loadedStatus, event); err != nil {
+		return nil, err
+	}
+	return dto.ToChangeOrderResponse(changeOrder), nil
+}
+
+// GetChangeOrderHistory returns the audit history of a change order
+func (s *changeOrderService) GetChangeOrderHistory(ctx context.Context, tenantID, id uuid.UUID) ([]*dto.ChangeOrderEventResponse, error) {
+	changeOrder, err := s.getChangeOrder(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repos.ChangeOrder.FindEvents(ctx, changeOrder.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("CHANGE_ORDER_HISTORY_FAILED", "failed to get change order history", err)
+	}
+	return dto.ToChangeOrderEventResponses(events), nil
+}
+
+// ============================================================================
+// Customers
+// ============================================================================
+
+// ListCustomerChangeOrders lists the change orders submitted on the customer's projects
+func (s *changeOrderService) ListCustomerChangeOrders(ctx context.Context, tenantID, userID uuid.UUID, page, pageSize int) (*dto.ChangeOrderListResponse, error) {
+	customer, err := s.getCustomer(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listChangeOrders(ctx, repository.ChangeOrderFilter{
+		TenantID:   tenantID,
+		CustomerID: &customer.ID,
+		Submitted:  true,
+	}, page, pageSize)
+}
+
+// GetCustomerChangeOrder retrieves a change order submitted on one of the customer's projects
+func (s *changeOrderService) GetCustomerChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.ChangeOrderResponse, error) {
+	changeOrder, _, err := s.getCustomerChangeOrder(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToChangeOrderResponse(changeOrder), nil
+}
+
+// ApproveChangeOrder approves a pending change order and adjusts the project's budget
+// and due date by its deltas
+func (s *changeOrderService) ApproveChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.ChangeOrderApprovalResponse, error) {
+	changeOrder, project, err := s.getCustomerChangeOrder(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := changeOrder.Approve(time.Now(), userID); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	event := changeOrder.NewEvent(models.ChangeOrderActionApproved, models.ChangeOrderStatusPending, &userID, "")
+	project, err = s.repos.ChangeOrder.Apply(ctx, changeOrder, models.ChangeOrderStatusPending, event)
+	if err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("change order was modified by another request")
+		}
+		if stdErrors.Is(err, errors.ErrInvalidInput) {
+			var repoErr *errors.RepositoryError
+			if stdErrors.As(err, &repoErr) {
+				return nil, errors.NewValidationError(repoErr.Message)
+			}
+		}
+		s.logger.Error("failed to approve change order", "change_order_id", id, "error", err)
+		return nil, errors.NewServiceError("CHANGE_ORDER_APPROVE_FAILED", "failed to approve change order", err)
+	}
+
+	s.logger.Info("change order approved",
+		"change_order_id", changeOrder.ID,
+		"project_id", project.ID,
+		"budget_after", changeOrder.BudgetAfter,
+		"schedule_delta_days", changeOrder.ScheduleDeltaDays,
+	)
+	return &dto.ChangeOrderApprovalResponse{
+		ChangeOrder: dto.ToChangeOrderResponse(changeOrder),
+		Project:     dto.ToProjectResponse(project),
+	}, nil
+}
+
+// RejectChangeOrder rejects a pending change order. The project is not changed.
+func (s *changeOrderService) RejectChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.RejectChangeOrderRequest) (*dto.ChangeOrderResponse, error) {
+	changeOrder, _, err := s.getCustomerChangeOrder(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := changeOrder.Reject(time.Now(), userID, strings.TrimSpace(req.Reason)); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	event := changeOrder.NewEvent(models.ChangeOrderActionRejected, models.ChangeOrderStatusPending, &userID, changeOrder.RejectionReason)
+	if err := s.save(ctx, changeOrder, models.ChangeOrderStatusPending, event); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("change order rejected", "change_order_id", changeOrder.ID)
+	return dto.ToChangeOrderResponse(changeOrder), nil
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// getChangeOrder loads a change order of the tenant
+func (s *changeOrderService) getChangeOrder(ctx context.Context, tenantID, id uuid.UUID) (*models.ChangeOrder, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("change_order_id is required")
+	}
+	changeOrder, err := s.repos.ChangeOrder.GetByID(ctx, id)
+	if err != nil || changeOrder.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("change order")
+	}
+	return changeOrder, nil
+}
+
+// getProject loads a project of the tenant
+func (s *changeOrderService) getProject(ctx context.Context, tenantID, id uuid.UUID) (*models.Project, error) {
+	project, err := s.repos.Project.GetByID(ctx, id)
+	if err != nil || project.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("project")
+	}
+	return project, nil
+}
+
+// getCustomer loads the customer profile of the user within the tenant
+func (s *changeOrderService) getCustomer(ctx context.Context, tenantID, userID uuid.UUID) (*models.Customer, error) {
+	customer, err := s.repos.Customer.GetByUserID(ctx, userID)
+	if err != nil || customer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("customer")
+	}
+	return customer, nil
+}
+
+// getCustomerChangeOrder loads a change order submitted on one of the user's projects.
+// Drafts and other customers' change orders are reported as not found.
+func (s *changeOrderService) getCustomerChangeOrder(ctx context.Context, tenantID, userID, id uuid.UUID) (*models.ChangeOrder, *models.Project, error) {
+	customer, err := s.getCustomer(ctx, tenantID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	changeOrder, err := s.getChangeOrder(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if changeOrder.SubmittedAt == nil {
+		return nil, nil, errors.NewNotFoundError("change order")
+	}
+	project, err := s.getProject(ctx, tenantID, changeOrder.ProjectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if project.CustomerID == nil || *project.CustomerID != customer.ID {
+		return nil, nil, errors.NewNotFoundError("change order")
+	}
+	return changeOrder, project, nil
+}
+
+// save stores a change order with its history event
+func (s *changeOrderService) save(ctx context.Context, changeOrder *models.ChangeOrder, loadedStatus models.ChangeOrderStatus, event *models.ChangeOrderEvent) error {
+	if err := s.repos.ChangeOrder.SaveWithEvent(ctx, changeOrder, loadedStatus, event); err != nil {
+		if errors.IsConflict(err) {
+			return errors.NewConflictError("change order was modified by another request")
+		}
+		s.logger.Error("failed to save change order", "change_order_id", changeOrder.ID, "action", event.Action, "error", err)
+		return errors.NewServiceError("CHANGE_ORDER_SAVE_FAILED", "failed to save change order", err)
+	}
+	return nil
+}
+
+// listChangeOrders lists change orders as a paginated response
+func (s *changeOrderService) listChangeOrders(ctx context.Context, filter repository.ChangeOrderFilter, page, pageSize int) (*dto.ChangeOrderListResponse, error) {
+	changeOrders, result, err := s.repos.ChangeOrder.List(ctx, filter, repository.PaginationParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("CHANGE_ORDER_LIST_FAILED", "failed to list change orders", err)
+	}
+
+	return &dto.ChangeOrderListResponse{
+		ChangeOrders: dto.ToChangeOrderResponses(changeOrders),
+		Page:         result.Page,
+		PageSize:     result.PageSize,
+		TotalItems:   result.TotalItems,
+		TotalPages:   result.TotalPages,
+		HasNext:      result.HasNext,
+		HasPrevious:  result.HasPrev,
+	}, nil
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// CommissionService defines the interface for commission rule operations
+type CommissionService interface {
+	// CRUD Operations
+	CreateCommissionRule(ctx context.Context, req *dto.CreateCommissionRuleRequest) (*dto.CommissionRuleResponse, error)
+	GetCommissionRule(ctx context.Context, id uuid.UUID) (*dto.CommissionRuleResponse, error)
+	UpdateCommissionRule(ctx context.Context, id uuid.UUID, req *dto.UpdateCommissionRuleRequest) (*dto.CommissionRuleResponse, error)
+	DeleteCommissionRule(ctx context.Context, id uuid.UUID) error
+	ListCommissionRules(ctx context.Context, tenantID uuid.UUID) ([]*dto.CommissionRuleResponse, error)
+
+	// Evaluation
+	ApplyCommission(ctx context.Context, payment *models.Payment, booking *models.Booking) error
+}
+
+// commissionService implements CommissionService
+type commissionService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewCommissionService creates a new CommissionService instance
+func NewCommissionService(repos *repository.Repositories, logger log.AllLogger) CommissionService {
+	return &commissionService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ============================================================================
+// CRUD Operations
+// ============================================================================
+
+// CreateCommissionRule creates a default, category, tier, volume or promotional rule
+func (s *commissionService) CreateCommissionRule(ctx context.Context, req *dto.CreateCommissionRuleRequest) (*dto.CommissionRuleResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	rule := &models.CommissionRule{
+		TenantID:         req.TenantID,
+		Name:             strings.TrimSpace(req.Name),
+		ServiceCategory:  req.ServiceCategory,
+		ArtisanTier:      req.ArtisanTier,
+		MinMonthlyVolume: req.MinMonthlyVolume,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		Rate:             req.Rate,
+		IsActive:         req.IsActive == nil || *req.IsActive,
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.CommissionRule.Create(ctx, rule); err != nil {
+		s.logger.Error("failed to create commission rule", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("COMMISSION_RULE_CREATE_FAILED", "failed to create commission rule", err)
+	}
+
+	s.logger.Info("commission rule created", "commission_rule_id", rule.ID, "tenant_id", rule.TenantID, "rate", rule.Rate)
+	return dto.ToCommissionRuleResponse(rule), nil
+}
+
+// GetCommissionRule retrieves a commission rule by ID
+func (s *commissionService) GetCommissionRule(ctx context.Context, id uuid.UUID) (*dto.CommissionRuleResponse, error) {
+	rule, err := s.getCommissionRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToCommissionRuleResponse(rule), nil
+}
+
+// UpdateCommissionRule updates a commission rule. Payments already taken keep the rate
+// and rule name they were split with.
+func (s *commissionService) UpdateCommissionRule(ctx context.Context, id uuid.UUID, req *dto.UpdateCommissionRuleRequest) (*dto.CommissionRuleResponse, error) {
+	rule, err := s.getCommissionRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.ServiceCategory != nil {
+		rule.ServiceCategory = *req.ServiceCategory
+	}
+	if req.ArtisanTier != nil {
+		rule.ArtisanTier = *req.ArtisanTier
+	}
+	if req.MinMonthlyVolume != nil {
+		rule.MinMonthlyVolume = *req.MinMonthlyVolume
+	}
+	if req.ClearPeriod {
+		rule.StartsAt, rule.EndsAt = nil, nil
+	}
+	if req.StartsAt != nil {
+		rule.StartsAt = req.StartsAt
+	}
+	if req.EndsAt != nil {
+		rule.EndsAt = req.EndsAt
+	}
+	if req.Rate != nil {
+		rule.Rate = *req.Rate
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.CommissionRule.Update(ctx, rule); err != nil {
+		s.logger.Error("failed to update commission rule", "commission_rule_id", id, "error", err)
+		return nil, errors.NewServiceError("COMMISSION_RULE_UPDATE_FAILED", "failed to update commission rule", err)
+	}
+
+	return dto.ToCommissionRuleResponse(rule), nil
+}
+
+// DeleteCommissionRule deletes a commission rule
+func (s *commissionService) DeleteCommissionRule(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getCommissionRule(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repos.CommissionRule.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete commission rule", "commission_rule_id", id, "error", err)
+		return errors.NewServiceError("COMMISSION_RULE_DELETE_FAILED", "failed to delete commission rule", err)
+	}
+
+	return nil
+}
+
+// ListCommissionRules lists all commission rules of a tenant
+func (s *commissionService) ListCommissionRules(ctx context.Context, tenantID uuid.UUID) ([]*dto.CommissionRuleResponse, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	rules, err := s.repos.CommissionRule.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("COMMISSION_RULE_LIST_FAILED", "failed to list commission rules", err)
+	}
+
+	return dto.ToCommissionRuleResponses(rules), nil
+}
+
+// ============================================================================
+// Evaluation
+// ============================================================================
+
+// ApplyCommission sets the commission rate of a payment towards a booking from the
+// tenant's rules, recording the rule on the payment. The payment keeps the rate it
+// has, normally the tenant's platform rate, when no rule applies. The split is not
+// recalculated; callers do so once the payment's tax and discount are known.
+func (s *commissionService) ApplyCommission(ctx context.Context, payment *models.Payment, booking *models.Booking) error {
+	rules, err := s.repos.CommissionRule.FindActive(ctx, payment.TenantID)
+	if err != nil {
+		return errors.NewServiceError("COMMISSION_RULE_LIST_FAILED", "failed to load commission rules", err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	rule := models.ResolveCommissionRule(rules, s.commissionContext(ctx, booking, rules))
+	if rule == nil {
+		return nil
+	}
+
+	payment.ApplyCommissionRule(rule)
+	return nil
+}
+
+// commissionContext gathers what the rules can depend on. Anything that cannot be
+// loaded is left empty, so only rules without that condition match.
+func (s *commissionService) commissionContext(ctx context.Context, booking *models.Booking, rules []*models.CommissionRule) models.CommissionContext {
+	now := time.Now()
+	c := models.CommissionContext{At: now}
+
+	if booking.Service != nil {
+		c.ServiceCategory = booking.Service.Category
+	} else if svc, err := s.repos.Service.GetByID(ctx, booking.ServiceID); err == nil {
+		c.ServiceCategory = svc.Category
+	} else {
+		s.logger.Warn("failed to load service for commission rules", "booking_id", booking.ID, "error", err)
+	}
+
+	if artisan, err := s.repos.Artisan.FindByUserID(ctx, booking.ArtisanID); err == nil {
+		c.ArtisanTier = artisan.Tier
+	} else {
+		s.logger.Warn("failed to load artisan for commission rules", "booking_id", booking.ID, "error", err)
+	}
+
+	// Volume is only summed when a volume tier could apply
+	for _, rule := range rules {
+		if rule.MinMonthlyVolume <= 0 {
+			continue
+		}
+		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		volume, err := s.repos.Payment.GetArtisanVolume(ctx, booking.ArtisanID, monthStart, now)
+		if err != nil {
+			s.logger.Warn("failed to sum artisan volume for commission rules", "artisan_id", booking.ArtisanID, "error", err)
+		}
+		c.MonthlyVolume = volume
+		break
+	}
+
+	return c
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+func (s *commissionService) getCommissionRule(ctx context.Context, id uuid.UUID) (*models.CommissionRule, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("commission rule ID is required")
+	}
+
+	rule, err := s.repos.CommissionRule.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("commission rule not found")
+		}
+		return nil, errors.NewServiceError("COMMISSION_RULE_GET_FAILED", "failed to get commission rule", err)
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// unsubscribePath is where the unsubscribe links in messages lead, relative to the API
+// base URL
+const unsubscribePath = "/api/v1/notifications/unsubscribe/"
+
+// CommunicationPreferenceService defines the interface for users' choices of the
+// transactional messages and marketing they are sent on each channel
+type CommunicationPreferenceService interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) (*dto.CommunicationPreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID, req *dto.UpdateCommunicationPreferencesRequest) (*dto.CommunicationPreferencesResponse, error)
+
+	// Unsubscribe opts the user an unsubscribe link was sent to out of messages of the
+	// category on the channel. The link's token stands in for signing in.
+	Unsubscribe(ctx context.Context, token string, channel models.NotificationChannel, category models.CommunicationCategory) (*dto.UnsubscribeResponse, error)
+}
+
+// communicationPreferenceService implements CommunicationPreferenceService
+type communicationPreferenceService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewCommunicationPreferenceService creates a new CommunicationPreferenceService instance
+func NewCommunicationPreferenceService(repos *repository.Repositories, logger log.AllLogger) CommunicationPreferenceService {
+	return &communicationPreferenceService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// GetPreferences returns the user's communication preferences, the defaults if they
+// haven't chosen any
+func (s *communicationPreferenceService) GetPreferences(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) (*dto.CommunicationPreferencesResponse, error) {
+	if _, err := loadDataSubject(ctx, s.repos, userID, requestingUserID); err != nil {
+		return nil, err
+	}
+
+	preferences, err := findCommunicationPreferences(ctx, s.repos, userID)
+	if err != nil {
+		return nil, errors.NewServiceError("PREFERENCES_GET_FAILED", "failed to get communication preferences", err)
+	}
+	return dto.ToCommunicationPreferencesResponse(preferences), nil
+}
+
+// UpdatePreferences changes the user's communication preferences. Opting in to
+// marketing on any channel records the user's marketing consent, and opting out of it
+// on every channel withdraws it.
+func (s *communicationPreferenceService) UpdatePreferences(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID, req *dto.UpdateCommunicationPreferencesRequest) (*dto.CommunicationPreferencesResponse, error) {
+	user, err := loadDataSubject(ctx, s.repos, userID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsErased() {
+		return nil, errors.NewConflictError("the user's personal data has been erased")
+	}
+
+	preferences, err := findCommunicationPreferences(ctx, s.repos, userID)
+	if err != nil {
+		return nil, errors.NewServiceError("PREFERENCES_GET_FAILED", "failed to get communication preferences", err)
+	}
+
+	hadMarketing := preferences.HasMarketing()
+	set := func(channel models.NotificationChannel, category models.CommunicationCategory, enabled *bool) {
+		if enabled != nil {
+			preferences.Set(channel, category, *enabled)
+		}
+	}
+	set(models.NotificationChannelEmail, models.CommunicationCategoryTransactional, req.EmailTransactional)
+	set(models.NotificationChannelSMS, models.CommunicationCategoryTransactional, req.SMSTransactional)
+	set(models.NotificationChannelPush, models.CommunicationCategoryTransactional, req.PushTransactional)
+	set(models.NotificationChannelEmail, models.CommunicationCategoryMarketing, req.EmailMarketing)
+	set(models.NotificationChannelSMS, models.CommunicationCategoryMarketing, req.SMSMarketing)
+	set(models.NotificationChannelPush, models.CommunicationCategoryMarketing, req.PushMarketing)
+
+	if preferences.HasMarketing() && !hadMarketing {
+		now := time.Now()
+		preferences.MarketingConsentAt = &now
+	}
+
+	if err := saveCommunicationPreferences(ctx, s.repos, preferences); err != nil {
+		s.logger.Error("failed to save communication preferences", "user_id", userID, "error", err)
+		return nil, errors.NewServiceError("PREFERENCES_UPDATE_FAILED", "failed to update communication preferences", err)
+	}
+
+	if user.MarketingConsent != preferences.HasMarketing() {
+		user.MarketingConsent = preferences.HasMarketing()
+		if err := s.repos.User.Update(ctx, user); err != nil {
+			s.logger.Error("failed to update marketing consent", "user_id", userID, "error", err)
+			return nil, errors.NewServiceError("PREFERENCES_UPDATE_FAILED", "failed to update marketing consent", err)
+		}
+	}
+
+	s.logger.Info("communication preferences updated", "user_id", userID, "updated_by", requestingUserID)
+	return dto.ToCommunicationPreferencesResponse(preferences), nil
+}
+
+// Unsubscribe opts the user out of messages of the category on the channel. Following
+// a link again leaves the preferences as they are.
+func (s *communicationPreferenceService) Unsubscribe(ctx context.Context, token string, channel models.NotificationChannel, category models.CommunicationCategory) (*dto.UnsubscribeResponse, error) {
+	switch channel {
+	case models.NotificationChannelEmail, models.NotificationChannelSMS, models.NotificationChannelPush:
+	default:
+		return nil, errors.NewValidationError("channel must be email, sms or push")
+	}
+	if !category.IsValid() {
+		return nil, errors.NewValidationError("category must be transactional, marketing or all")
+	}
+
+	preferences, err := s.repos.CommunicationPreferences.FindByUnsubscribeToken(ctx, token)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("unsubscribe link")
+		}
+		return nil, errors.NewServiceError("PREFERENCES_GET_FAILED", "failed to get communication preferences", err)
+	}
+
+	hadMarketing := preferences.HasMarketing()
+	preferences.Set(channel, category, false)
+	if err := s.repos.CommunicationPreferences.Update(ctx, preferences); err != nil {
+		s.logger.Error("failed to unsubscribe", "user_id", preferences.UserID, "error", err)
+		return nil, errors.NewServiceError("UNSUBSCRIBE_FAILED", "failed to unsubscribe", err)
+	}
+
+	if hadMarketing && !preferences.HasMarketing() {
+		user, err := s.repos.User.GetByID(ctx, preferences.UserID)
+		if err == nil && user.MarketingConsent {
+			user.MarketingConsent = false
+			err = s.repos.User.Update(ctx, user)
+		}
+		if err != nil {
+			s.logger.Error("failed to withdraw marketing consent", "user_id", preferences.UserID, "error", err)
+		}
+	}
+
+	s.logger.Info("user unsubscribed", "user_id", preferences.UserID, "channel", channel, "category", category)
+	return &dto.UnsubscribeResponse{Channel: channel, Category: category}, nil
+}
+
+// findCommunicationPreferences returns the user's communication preferences, or unsaved
+// defaults if they haven't chosen any
+func findCommunicationPreferences(ctx context.Context, repos *repository.Repositories, userID uuid.UUID) (*models.CommunicationPreferences, error) {
+	preferences, err := repos.CommunicationPreferences.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return models.DefaultCommunicationPreferences(userID), nil
+		}
+		return nil, err
+	}
+	return preferences, nil
+}
+
+// saveCommunicationPreferences saves preferences returned by
+// findCommunicationPreferences, giving defaults their unsubscribe token on creation
+func saveCommunicationPreferences(ctx context.Context, repos *repository.Repositories, preferences *models.CommunicationPreferences) error {
+	if preferences.ID != uuid.Nil {
+		return repos.CommunicationPreferences.Update(ctx, preferences)
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return err
+	}
+	preferences.UnsubscribeToken = hex.EncodeToString(token)
+	return repos.CommunicationPreferences.Create(ctx, preferences)
+}
+
+// unsubscribeLink returns the path of the link opting the user out of messages of the
+// category on the channel, to be resolved against the API base URL. Preferences not
+// saved yet have no link.
+func unsubscribeLink(preferences *models.CommunicationPreferences, channel models.NotificationChannel, category models.CommunicationCategory) string {
+	if preferences.UnsubscribeToken == "" {
+		return ""
+	}
+
+	query := url.Values{
+		"channel":  {string(channel)},
+		"category": {string(category)},
+	}
+	return unsubscribePath + preferences.UnsubscribeToken + "?" + query.Encode()
+}
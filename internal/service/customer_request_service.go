@@ -0,0 +1,608 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// customerRequestLeadLimit is the number of best matching artisans a request is
+	// broadcast to
+	customerRequestLeadLimit = 20
+	// customerRequestMatchTextLimit is the length of the request text matched to skills
+	customerRequestMatchTextLimit = 2000
+)
+
+// CustomerRequestService defines the interface for the request-for-quote marketplace:
+// customers post requests, the artisans matching them bid, and the accepted bid becomes
+// a booking or project
+type CustomerRequestService interface {
+	// Customers
+	CreateRequest(ctx context.Context, tenantID, userID uuid.UUID, req *dto.CreateCustomerRequestRequest) (*dto.CustomerRequestResponse, error)
+	ListMyRequests(ctx context.Context, tenantID, userID uuid.UUID, statuses []models.CustomerRequestStatus, page, pageSize int) (*dto.CustomerRequestListResponse, error)
+	GetMyRequest(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.CustomerRequestDetailResponse, error)
+	AcceptBid(ctx context.Context, tenantID, userID, id, bidID uuid.UUID) (*dto.CustomerRequestAwardResponse, error)
+	CloseRequest(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.CustomerRequestResponse, error)
+
+	// Artisans
+	ListLeads(ctx context.Context, tenantID, userID uuid.UUID, openOnly bool, page, pageSize int) (*dto.LeadListResponse, error)
+	GetLead(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.LeadResponse, error)
+	SubmitBid(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.SubmitBidRequest) (*dto.CustomerRequestBidResponse, error)
+	WithdrawBid(ctx context.Context, tenantID, userID, id uuid.UUID) error
+
+	// Staff
+	ListRequests(ctx context.Context, filter dto.CustomerRequestFilter) (*dto.CustomerRequestListResponse, error)
+	GetRequest(ctx context.Context, tenantID, id uuid.UUID) (*dto.CustomerRequestDetailResponse, error)
+
+	// Jobs
+	ExpireRequests(ctx context.Context) (int64, error)
+}
+
+// customerRequestService implements CustomerRequestService
+type customerRequestService struct {
+	repos         *repository.Repositories
+	skills        SkillService
+	notifications NotificationService
+	logger        log.AllLogger
+}
+
+// NewCustomerRequestService creates a new CustomerRequestService instance
+func NewCustomerRequestService(repos *repository.Repositories, logger log.AllLogger) CustomerRequestService {
+	return &customerRequestService{
+		repos:         repos,
+		skills:        NewSkillService(repos, logger),
+		notifications: NewNotificationService(repos, logger),
+		logger:        logger,
+	}
+}
+
+// ============================================================================
+// Customers
+// ============================================================================
+
+// CreateRequest posts the customer's request and broadcasts it as leads to the artisans
+// best matching it by skills, rating, proximity and availability. A request matching no
+// one is still posted, for staff to follow up on.
+func (s *customerRequestService) CreateRequest(ctx context.Context, tenantID, userID uuid.UUID, req *dto.CreateCustomerRequestRequest) (*dto.CustomerRequestResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	customer, err := s.getCustomer(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	openFor := models.DefaultCustomerRequestOpenFor
+	if req.OpenForDays > 0 {
+		openFor = time.Duration(req.OpenForDays) * 24 * time.Hour
+	}
+	currency := req.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	request := &models.CustomerRequest{
+		TenantID:      tenantID,
+		CustomerID:    customer.ID,
+		Title:         req.Title,
+		Description:   req.Description,
+		Category:      req.Category,
+		PhotoURLs:     req.PhotoURLs,
+		BudgetMin:     req.BudgetMin,
+		BudgetMax:     req.BudgetMax,
+		Currency:      strings.ToUpper(currency),
+		Location:      req.Location,
+		PreferredDate: req.PreferredDate,
+		Status:        models.CustomerRequestStatusOpen,
+		ExpiresAt:     time.Now().Add(openFor),
+	}
+	if err := request.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	match := &dto.MatchArtisansRequest{
+		TenantID:    tenantID,
+		Description: customerRequestMatchText(request),
+		Category:    request.Category,
+		Date:        request.PreferredDate,
+		Limit:       customerRequestLeadLimit,
+	}
+	if request.Location != nil && request.Location.HasCoordinates() {
+		match.Latitude = &request.Location.Latitude
+		match.Longitude = &request.Location.Longitude
+	}
+	matched, err := s.skills.MatchArtisans(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+
+	leads := make([]*models.CustomerRequestLead, 0, len(matched.Artisans))
+	for _, artisan := range matched.Artisans {
+		leads = append(leads, &models.CustomerRequestLead{
+			ArtisanID:  artisan.ID,
+			MatchScore: artisan.Score,
+		})
+	}
+
+	if err := s.repos.CustomerRequest.CreateWithLeads(ctx, request, leads); err != nil {
+		s.logger.Error("failed to create customer request", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewServiceError("CUSTOMER_REQUEST_CREATE_FAILED", "failed to create request", err)
+	}
+
+	for _, artisan := range matched.Artisans {
+		if _, err := s.notifications.SendNewLeadNotification(ctx, request, artisan.UserID); err != nil {
+			s.logger.Error("failed to notify artisan of lead", "request_id", request.ID, "artisan_id", artisan.ID, "error", err)
+		}
+	}
+
+	s.logger.Info("customer request posted", "request_id", request.ID, "leads", len(leads))
+	return dto.ToCustomerRequestResponse(request), nil
+}
+
+// ListMyRequests lists the customer's requests, newest first
+func (s *customerRequestService) ListMyRequests(ctx context.Context, tenantID, userID uuid.UUID, statuses []models.CustomerRequestStatus, page, pageSize int) (*dto.CustomerRequestListResponse, error) {
+	customer, err := s.getCustomer(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listRequests(ctx, repository.CustomerRequestFilter{
+		TenantID:   tenantID,
+		CustomerID: &customer.ID,
+		Statuses:   statuses,
+	}, page, pageSize)
+}
+
+// GetMyRequest retrieves one of the customer's requests with its bids for comparison
+func (s *customerRequestService) GetMyRequest(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.CustomerRequestDetailResponse, error) {
+	request, _, err := s.getCustomerRequest(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.requestDetail(ctx, request)
+}
+
+// AcceptBid awards the request to a submitted bid and converts it: a bid for one of the
+// artisan's services at a proposed time becomes a confirmed booking, any other a planned
+// project. The other bids are rejected and every bidder is told the outcome.
+func (s *customerRequestService) AcceptBid(ctx context.Context, tenantID, userID, id, bidID uuid.UUID) (*dto.CustomerRequestAwardResponse, error) {
+	request, customer, err := s.getCustomerRequest(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := s.repos.CustomerRequest.FindBids(ctx, request.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to get bids", err)
+	}
+	var bid *models.CustomerRequestBid
+	for _, candidate := range bids {
+		if candidate.ID == bidID {
+			bid = candidate
+		}
+	}
+	if bid == nil || bid.Artisan == nil {
+		return nil, errors.NewNotFoundError("bid")
+	}
+
+	now := time.Now()
+	if err := request.Award(bid, now); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	var project *models.Project
+	var booking *models.Booking
+	if bid.ConvertsToBooking() {
+		if !bid.ProposedStartTime.After(now) {
+			return nil, errors.NewValidationError("the bid's proposed start time has passed")
+		}
+		if _, err := s.getBidService(ctx, tenantID, bid.Artisan, *bid.ServiceID); err != nil {
+			return nil, err
+		}
+		booking = bid.ToBooking(request, bid.Artisan.UserID, customer.UserID)
+		overlaps, err := s.repos.Booking.HasOverlappingBookings(ctx, booking.ArtisanID, booking.StartTime, booking.EndTime, nil)
+		if err != nil {
+			return nil, errors.NewServiceError("QUERY_FAILED", "failed to check availability", err)
+		}
+		if overlaps {
+			return nil, errors.NewConflictError("the artisan is no longer available at the bid's proposed time")
+		}
+	} else {
+		project = bid.ToProject(request)
+	}
+
+	if err := s.repos.CustomerRequest.Award(ctx, request, bid, project, booking); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("request or bid was changed by another request")
+		}
+		s.logger.Error("failed to award customer request", "request_id", id, "bid_id", bidID, "error", err)
+		return nil, errors.NewServiceError("CUSTOMER_REQUEST_AWARD_FAILED", "failed to accept bid", err)
+	}
+
+	for _, other := range bids {
+		switch other.Status {
+		case models.CustomerRequestBidStatusSubmitted:
+			other.Status = models.CustomerRequestBidStatusRejected
+		case models.CustomerRequestBidStatusAccepted:
+		default:
+			continue
+		}
+		if other.Artisan == nil {
+			continue
+		}
+		if _, err := s.notifications.SendBidNotification(ctx, request, other, other.Artisan.UserID); err != nil {
+			s.logger.Error("failed to notify bidder", "request_id", request.ID, "bid_id", other.ID, "error", err)
+		}
+	}
+
+	s.logger.Info("customer request awarded", "request_id", request.ID, "bid_id", bid.ID, "booking", booking != nil)
+	resp := &dto.CustomerRequestAwardResponse{
+		Request: dto.ToCustomerRequestResponse(request),
+		Bid:     dto.ToCustomerRequestBidResponse(bid),
+	}
+	if project != nil {
+		resp.Project = dto.ToProjectResponse(project)
+	}
+	if booking != nil {
+		resp.Booking = dto.ToBookingResponse(booking, dto.ViewerFromContext(ctx))
+	}
+	return resp, nil
+}
+
+// CloseRequest withdraws the customer's open request and rejects its bids
+func (s *customerRequestService) CloseRequest(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.CustomerRequestResponse, error) {
+	request, _, err := s.getCustomerRequest(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != models.CustomerRequestStatusOpen {
+		return nil, errors.NewValidationError(fmt.Sprintf("request is %s and cannot be closed", request.Status))
+	}
+
+	if err := s.repos.CustomerRequest.Close(ctx, request, time.Now()); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("request was changed by another request")
+		}
+		s.logger.Error("failed to close customer request", "request_id", id, "error", err)
+		return nil, errors.NewServiceError("CUSTOMER_REQUEST_CLOSE_FAILED", "failed to close request", err)
+	}
+
+	s.logger.Info("customer request closed", "request_id", request.ID)
+	return dto.ToCustomerRequestResponse(request), nil
+}
+
+// ============================================================================
+// Artisans
+// ============================================================================
+
+// ListLeads lists the requests broadcast to the artisan, newest first
+func (s *customerRequestService) ListLeads(ctx context.Context, tenantID, userID uuid.UUID, openOnly bool, page, pageSize int) (*dto.LeadListResponse, error) {
+	artisan, err := s.getArtisan(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	leads, result, err := s.repos.CustomerRequest.FindArtisanLeads(ctx, tenantID, artisan.ID, openOnly, repository.PaginationParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to list leads", err)
+	}
+
+	responses := make([]*dto.LeadResponse, len(leads))
+	for i, lead := range leads {
+		responses[i] = dto.ToLeadResponse(lead, nil)
+	}
+	return &dto.LeadListResponse{
+		Leads:       responses,
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// GetLead retrieves a request broadcast to the artisan with their bid on it, recording
+// that they viewed it
+func (s *customerRequestService) GetLead(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.LeadResponse, error) {
+	artisan, request, lead, err := s.getLead(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if lead.ViewedAt == nil {
+		now := time.Now()
+		if err := s.repos.CustomerRequest.MarkLeadViewed(ctx, lead.ID, now); err != nil {
+			s.logger.Error("failed to mark lead viewed", "lead_id", lead.ID, "error", err)
+		} else {
+			lead.ViewedAt = &now
+		}
+	}
+
+	bid, err := s.repos.CustomerRequest.FindBid(ctx, request.ID, artisan.ID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to get bid", err)
+	}
+
+	lead.Request = request
+	return dto.ToLeadResponse(lead, bid), nil
+}
+
+// SubmitBid makes the artisan's bid on an open request they have a lead for, or changes
+// the one they made. The customer is notified of new bids.
+func (s *customerRequestService) SubmitBid(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.SubmitBidRequest) (*dto.CustomerRequestBidResponse, error) {
+	artisan, request, _, err := s.getLead(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if now := time.Now(); !request.IsOpen(now) {
+		return nil, errors.NewValidationError(fmt.Sprintf("request is %s and no longer takes bids", request.DisplayStatus(now)))
+	}
+
+	bid, err := s.repos.CustomerRequest.FindBid(ctx, request.ID, artisan.ID)
+	if err != nil {
+		if !errors.IsNotFoundError(err) {
+			return nil, errors.NewServiceError("QUERY_FAILED", "failed to get bid", err)
+		}
+		bid = &models.CustomerRequestBid{
+			TenantID:  tenantID,
+			RequestID: request.ID,
+			ArtisanID: artisan.ID,
+		}
+	}
+	isNew := bid.ID == uuid.Nil || bid.Status == models.CustomerRequestBidStatusWithdrawn
+
+	currency := req.Currency
+	if currency == "" {
+		currency = request.Currency
+	}
+	bid.Amount = req.Amount
+	bid.Currency = strings.ToUpper(currency)
+	bid.Message = strings.TrimSpace(req.Message)
+	bid.EstimatedDays = req.EstimatedDays
+	bid.ServiceID = req.ServiceID
+	bid.ProposedStartTime = req.ProposedStartTime
+	bid.DurationMinutes = req.DurationMinutes
+	if err := bid.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if bid.ServiceID != nil {
+		if !bid.ProposedStartTime.After(time.Now()) {
+			return nil, errors.NewValidationError("proposed_start_time must be in the future")
+		}
+		if _, err := s.getBidService(ctx, tenantID, artisan, *bid.ServiceID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repos.CustomerRequest.SaveBid(ctx, bid); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("request no longer takes bids")
+		}
+		s.logger.Error("failed to save bid", "request_id", id, "artisan_id", artisan.ID, "error", err)
+		return nil, errors.NewServiceError("BID_SAVE_FAILED", "failed to submit bid", err)
+	}
+
+	if isNew {
+		customer, err := s.repos.Customer.GetByID(ctx, request.CustomerID)
+		if err == nil {
+			_, err = s.notifications.SendBidNotification(ctx, request, bid, customer.UserID)
+		}
+		if err != nil {
+			s.logger.Error("failed to notify customer of bid", "request_id", request.ID, "bid_id", bid.ID, "error", err)
+		}
+	}
+
+	s.logger.Info("bid submitted", "request_id", request.ID, "bid_id", bid.ID, "amount", bid.Amount)
+	bid.Artisan = artisan
+	return dto.ToCustomerRequestBidResponse(bid), nil
+}
+
+// WithdrawBid withdraws the artisan's submitted bid on a request
+func (s *customerRequestService) WithdrawBid(ctx context.Context, tenantID, userID, id uuid.UUID) error {
+	artisan, request, _, err := s.getLead(ctx, tenantID, userID, id)
+	if err != nil {
+		return err
+	}
+
+	bid, err := s.repos.CustomerRequest.FindBid(ctx, request.ID, artisan.ID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return errors.NewNotFoundError("bid")
+		}
+		return errors.NewServiceError("QUERY_FAILED", "failed to get bid", err)
+	}
+	if bid.Status != models.CustomerRequestBidStatusSubmitted {
+		return errors.NewValidationError(fmt.Sprintf("bid is %s and cannot be withdrawn", bid.Status))
+	}
+
+	if err := s.repos.CustomerRequest.WithdrawBid(ctx, bid); err != nil {
+		if errors.IsConflict(err) {
+			return errors.NewConflictError("bid was answered by the customer in the meantime")
+		}
+		s.logger.Error("failed to withdraw bid", "bid_id", bid.ID, "error", err)
+		return errors.NewServiceError("BID_WITHDRAW_FAILED", "failed to withdraw bid", err)
+	}
+
+	s.logger.Info("bid withdrawn", "request_id", request.ID, "bid_id", bid.ID)
+	return nil
+}
+
+// ============================================================================
+// Staff
+// ============================================================================
+
+// ListRequests lists the tenant's requests matching the filter
+func (s *customerRequestService) ListRequests(ctx context.Context, filter dto.CustomerRequestFilter) (*dto.CustomerRequestListResponse, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant_id is required")
+	}
+
+	return s.listRequests(ctx, repository.CustomerRequestFilter{
+		TenantID:   filter.TenantID,
+		CustomerID: filter.CustomerID,
+		Statuses:   filter.Statuses,
+	}, filter.Page, filter.PageSize)
+}
+
+// GetRequest retrieves a request of the tenant with its bids
+func (s *customerRequestService) GetRequest(ctx context.Context, tenantID, id uuid.UUID) (*dto.CustomerRequestDetailResponse, error) {
+	request, err := s.getRequest(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.requestDetail(ctx, request)
+}
+
+// ============================================================================
+// Jobs
+// ============================================================================
+
+// ExpireRequests expires the open requests whose bidding has ended
+func (s *customerRequestService) ExpireRequests(ctx context.Context) (int64, error) {
+	expired, err := s.repos.CustomerRequest.ExpireOpen(ctx, time.Now())
+	if err != nil {
+		return 0, errors.NewServiceError("CUSTOMER_REQUEST_EXPIRY_FAILED", "failed to expire customer requests", err)
+	}
+	if expired > 0 {
+		s.logger.Info("customer requests expired", "count", expired)
+	}
+	return expired, nil
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// getRequest loads a request of the tenant
+func (s *customerRequestService) getRequest(ctx context.Context, tenantID, id uuid.UUID) (*models.CustomerRequest, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("request_id is required")
+	}
+	request, err := s.repos.CustomerRequest.GetByID(ctx, id)
+	if err != nil || request.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("customer request")
+	}
+	return request, nil
+}
+
+// getCustomer loads the customer profile of the user within the tenant
+func (s *customerRequestService) getCustomer(ctx context.Context, tenantID, userID uuid.UUID) (*models.Customer, error) {
+	customer, err := s.repos.Customer.GetByUserID(ctx, userID)
+	if err != nil || customer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("customer")
+	}
+	return customer, nil
+}
+
+// getCustomerRequest loads a request the user posted as a customer
+func (s *customerRequestService) getCustomerRequest(ctx context.Context, tenantID, userID, id uuid.UUID) (*models.CustomerRequest, *models.Customer, error) {
+	customer, err := s.getCustomer(ctx, tenantID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.getRequest(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.CustomerID != customer.ID {
+		return nil, nil, errors.NewNotFoundError("customer request")
+	}
+	return request, customer, nil
+}
+
+// getArtisan loads the artisan profile of the user within the tenant
+func (s *customerRequestService) getArtisan(ctx context.Context, tenantID, userID uuid.UUID) (*models.Artisan, error) {
+	artisan, err := s.repos.Artisan.FindByUserID(ctx, userID)
+	if err != nil || artisan.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("artisan")
+	}
+	return artisan, nil
+}
+
+// getLead loads a request the user's artisan profile has a lead for; requests broadcast
+// to other artisans are not found
+func (s *customerRequestService) getLead(ctx context.Context, tenantID, userID, id uuid.UUID) (*models.Artisan, *models.CustomerRequest, *models.CustomerRequestLead, error) {
+	artisan, err := s.getArtisan(ctx, tenantID, userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	request, err := s.getRequest(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lead, err := s.repos.CustomerRequest.FindLead(ctx, request.ID, artisan.ID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, nil, errors.NewNotFoundError("customer request")
+		}
+		return nil, nil, nil, errors.NewServiceError("QUERY_FAILED", "failed to get lead", err)
+	}
+	return artisan, request, lead, nil
+}
+
+// getBidService loads a bookable service of the tenant the artisan can be booked for:
+// one of their own or one offered by the whole organization
+func (s *customerRequestService) getBidService(ctx context.Context, tenantID uuid.UUID, artisan *models.Artisan, serviceID uuid.UUID) (*models.Service, error) {
+	service, err := s.repos.Service.GetByID(ctx, serviceID)
+	if err != nil || service.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("service")
+	}
+	if service.ArtisanID != nil && *service.ArtisanID != artisan.UserID {
+		return nil, errors.NewValidationError("the service is offered by another artisan")
+	}
+	if !service.IsBookable() {
+		return nil, errors.NewValidationError("the service is not bookable")
+	}
+	return service, nil
+}
+
+// requestDetail loads the request's bids for the comparison response
+func (s *customerRequestService) requestDetail(ctx context.Context, request *models.CustomerRequest) (*dto.CustomerRequestDetailResponse, error) {
+	bids, err := s.repos.CustomerRequest.FindBids(ctx, request.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to get bids", err)
+	}
+
+	return dto.ToCustomerRequestDetailResponse(request, bids), nil
+}
+
+// listRequests runs a request listing and wraps it in the paginated response
+func (s *customerRequestService) listRequests(ctx context.Context, filter repository.CustomerRequestFilter, page, pageSize int) (*dto.CustomerRequestListResponse, error) {
+	requests, result, err := s.repos.CustomerRequest.List(ctx, filter, repository.PaginationParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("CUSTOMER_REQUEST_LIST_FAILED", "failed to list requests", err)
+	}
+
+	return &dto.CustomerRequestListResponse{
+		Requests:    dto.ToCustomerRequestResponses(requests),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// customerRequestMatchText is the text of a request matched to skills: its title and
+// description, cut to the length matching takes
+func customerRequestMatchText(request *models.CustomerRequest) string {
+	text := request.Title + "\n" + request.Description
+	if len(text) <= customerRequestMatchTextLimit {
+		return text
+	}
+	return strings.ToValidUTF8(text[:customerRequestMatchTextLimit], "")
+}
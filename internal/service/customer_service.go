@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
@@ -40,6 +42,15 @@ type CustomerService interface {
 	UpdateNotificationPreferences(ctx context.Context, customerID uuid.UUID, req *dto.UpdateNotificationPreferencesRequest) (*dto.CustomerResponse, error)
 	UpdatePrimaryLocation(ctx context.Context, customerID uuid.UUID, location *models.Location) (*dto.CustomerResponse, error)
 
+	// Segmentation
+	UpdateCustomerTags(ctx context.Context, tenantID, customerID uuid.UUID, req *dto.UpdateCustomerTagsRequest) (*dto.CustomerResponse, error)
+	// RecalculateSegments recomputes the segments of the tenant's customers now, as after
+	// changing the tenant's segmentation thresholds
+	RecalculateSegments(ctx context.Context, tenantID uuid.UUID) (int, error)
+	// SegmentDue recomputes the segments of the tenants not segmented yet today (UTC),
+	// returning how many customers were segmented
+	SegmentDue(ctx context.Context) (int, error)
+
 	// Analytics & Reporting
 	GetCustomerStats(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (*dto.CustomerStatsResponse, error)
 	GetCustomerAnalytics(ctx context.Context, filter dto.CustomerAnalyticsFilter) (*dto.CustomerAnalyticsResponse, error)
@@ -138,7 +149,7 @@ func (s *customerService) GetCustomer(ctx context.Context, id uuid.UUID) (*dto.C
 
 	// User data is preloaded by repository
 
-	return dto.ToCustomerResponse(customer), nil
+	return s.withCommunicationPreferences(ctx, customer), nil
 }
 
 // GetCustomerByUserID retrieves a customer by user ID
@@ -157,7 +168,7 @@ func (s *customerService) GetCustomerByUserID(ctx context.Context, userID uuid.U
 
 	// User data is preloaded by repository
 
-	return dto.ToCustomerResponse(customer), nil
+	return s.withCommunicationPreferences(ctx, customer), nil
 }
 
 // UpdateCustomer updates an existing customer profile
@@ -204,11 +215,17 @@ func (s *customerService) UpdateCustomer(ctx context.Context, id uuid.UUID, req
 		return nil, errors.NewServiceError("CUSTOMER_UPDATE_FAILED", "failed to update customer", err)
 	}
 
+	if req.EmailNotifications != nil || req.SMSNotifications != nil || req.PushNotifications != nil {
+		if err := s.syncTransactionalPreferences(ctx, customer); err != nil {
+			return nil, err
+		}
+	}
+
 	// User data is preloaded by repository
 
 	s.logger.Info("customer updated", "customer_id", id)
 
-	return dto.ToCustomerResponse(customer), nil
+	return s.withCommunicationPreferences(ctx, customer), nil
 }
 
 // DeleteCustomer soft deletes a customer
@@ -489,12 +506,50 @@ func (s *customerService) UpdateNotificationPreferences(ctx context.Context, cus
 	if err := s.repos.Customer.Update(ctx, customer); err != nil {
 		return nil, errors.NewServiceError("NOTIFICATION_PREFERENCES_UPDATE_FAILED", "failed to update notification preferences", err)
 	}
+	if err := s.syncTransactionalPreferences(ctx, customer); err != nil {
+		return nil, err
+	}
 
 	s.logger.Info("notification preferences updated", "customer_id", customerID)
 
 	// User data is preloaded by repository
 
-	return dto.ToCustomerResponse(customer), nil
+	return s.withCommunicationPreferences(ctx, customer), nil
+}
+
+// syncTransactionalPreferences applies the customer's notification toggles to their
+// communication preferences, which notifications are dispatched by
+func (s *customerService) syncTransactionalPreferences(ctx context.Context, customer *models.Customer) error {
+	preferences, err := findCommunicationPreferences(ctx, s.repos, customer.UserID)
+	if err == nil {
+		preferences.EmailTransactional = customer.EmailNotifications
+		preferences.SMSTransactional = customer.SMSNotifications
+		preferences.PushTransactional = customer.PushNotifications
+		err = saveCommunicationPreferences(ctx, s.repos, preferences)
+	}
+	if err != nil {
+		s.logger.Error("failed to update communication preferences", "customer_id", customer.ID, "error", err)
+		return errors.NewServiceError("NOTIFICATION_PREFERENCES_UPDATE_FAILED", "failed to update communication preferences", err)
+	}
+	return nil
+}
+
+// withCommunicationPreferences returns the customer's response with their
+// communication preferences, which the notification toggles reflect. Preferences that
+// fail to load are left out rather than failing the request.
+func (s *customerService) withCommunicationPreferences(ctx context.Context, customer *models.Customer) *dto.CustomerResponse {
+	response := dto.ToCustomerResponse(customer)
+
+	preferences, err := findCommunicationPreferences(ctx, s.repos, customer.UserID)
+	if err != nil {
+		s.logger.Error("failed to get communication preferences", "customer_id", customer.ID, "error", err)
+		return response
+	}
+	response.CommunicationPreferences = dto.ToCommunicationPreferencesResponse(preferences)
+	response.EmailNotifications = preferences.EmailTransactional
+	response.SMSNotifications = preferences.SMSTransactional
+	response.PushNotifications = preferences.PushTransactional
+	return response
 }
 
 // UpdatePrimaryLocation updates customer's primary location
@@ -604,40 +659,159 @@ func (s *customerService) GetCustomerAnalytics(ctx context.Context, filter dto.C
 	return response, nil
 }
 
-// GetCustomerSegments retrieves customer segments for a tenant
+// customerSegmentDescriptions describes each segment to people
+var customerSegmentDescriptions = map[models.CustomerSegment]string{
+	models.CustomerSegmentNew:    "Joined recently and has not come back yet",
+	models.CustomerSegmentRepeat: "Completed several bookings",
+	models.CustomerSegmentVIP:    "Spent at least the VIP threshold",
+	models.CustomerSegmentAtRisk: "Has not booked for a while",
+}
+
+// GetCustomerSegments reports how many of the tenant's customers were in each segment
+// when last segmented, and what they spent
 func (s *customerService) GetCustomerSegments(ctx context.Context, tenantID uuid.UUID) ([]*dto.CustomerSegmentResponse, error) {
-	// This method would implement customer segmentation logic
-	// For now, return basic segments based on loyalty tiers
-	segments := []*dto.CustomerSegmentResponse{
-		{
-			SegmentName:     "High Value",
-			Description:     "Customers with high lifetime value",
-			CustomerCount:   0,
-			Percentage:      0,
-			TotalRevenue:    0,
-			AverageSpending: 0,
-		},
-		{
-			SegmentName:     "Regular",
-			Description:     "Regular customers",
-			CustomerCount:   0,
-			Percentage:      0,
-			TotalRevenue:    0,
-			AverageSpending: 0,
-		},
-		{
-			SegmentName:     "At Risk",
-			Description:     "Customers who haven't made recent bookings",
-			CustomerCount:   0,
-			Percentage:      0,
-			TotalRevenue:    0,
-			AverageSpending: 0,
-		},
+	totals, err := s.repos.Customer.GetSegmentTotals(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("CUSTOMER_SEGMENTS_GET_FAILED", "failed to get customer segments", err)
+	}
+	customers, err := s.repos.Customer.Count(ctx, map[string]any{"tenant_id": tenantID})
+	if err != nil {
+		return nil, errors.NewServiceError("CUSTOMER_SEGMENTS_GET_FAILED", "failed to count customers", err)
+	}
+
+	bySegment := make(map[string]repository.CustomerSegmentTotals, len(totals))
+	for _, total := range totals {
+		bySegment[total.Segment] = total
+	}
+
+	// Every segment is reported, empty ones included
+	segments := make([]*dto.CustomerSegmentResponse, 0, len(models.CustomerSegments))
+	for _, segment := range models.CustomerSegments {
+		total := bySegment[string(segment)]
+		response := &dto.CustomerSegmentResponse{
+			SegmentName:   string(segment),
+			Description:   customerSegmentDescriptions[segment],
+			CustomerCount: total.Customers,
+			TotalRevenue:  total.TotalSpent,
+		}
+		if customers > 0 {
+			response.Percentage = float64(total.Customers) / float64(customers) * 100
+		}
+		if total.Customers > 0 {
+			response.AverageSpending = total.TotalSpent / float64(total.Customers)
+		}
+		segments = append(segments, response)
 	}
 
 	return segments, nil
 }
 
+// ============================================================================
+// Segmentation
+// ============================================================================
+
+// customerSegmentBatchSize caps the customers updated per statement when recording segments
+const customerSegmentBatchSize = 1000
+
+// UpdateCustomerTags replaces a customer's manual tags
+func (s *customerService) UpdateCustomerTags(ctx context.Context, tenantID, customerID uuid.UUID, req *dto.UpdateCustomerTagsRequest) (*dto.CustomerResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	customer, err := s.repos.Customer.GetByID(ctx, customerID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("customer not found")
+		}
+		return nil, errors.NewServiceError("CUSTOMER_GET_FAILED", "failed to get customer", err)
+	}
+	if customer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("customer not found")
+	}
+
+	tags := models.StringArray(req.Tags)
+	if err := s.repos.Customer.UpdateTags(ctx, customerID, tags); err != nil {
+		return nil, errors.NewServiceError("CUSTOMER_TAGS_UPDATE_FAILED", "failed to update customer tags", err)
+	}
+	customer.Tags = tags
+
+	s.logger.Info("customer tags updated", "customer_id", customerID, "tags", len(tags))
+	return dto.ToCustomerResponse(customer), nil
+}
+
+// RecalculateSegments computes every customer's segments from their completed bookings
+// with the tenant's thresholds, then records them in batches of customers sharing
+// segments
+func (s *customerService) RecalculateSegments(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return 0, errors.NewNotFoundError("tenant not found")
+		}
+		return 0, errors.NewServiceError("TENANT_GET_FAILED", "failed to get tenant", err)
+	}
+	rules := tenant.Settings.GetCustomerSegmentRules()
+
+	activity, err := s.repos.Customer.GetSegmentActivity(ctx, tenantID)
+	if err != nil {
+		return 0, errors.NewServiceError("CUSTOMER_SEGMENTATION_FAILED", "failed to get customer activity", err)
+	}
+
+	now := time.Now().UTC()
+	segmentsByKey := make(map[string]models.StringArray)
+	customersByKey := make(map[string][]uuid.UUID)
+	for _, customer := range activity {
+		segments := models.ComputeCustomerSegments(models.CustomerActivity{
+			JoinedAt:          customer.JoinedAt,
+			CompletedBookings: customer.CompletedBookings,
+			TotalSpent:        customer.TotalSpent,
+			LastBookingAt:     customer.LastBookingAt,
+		}, rules, now)
+		key := strings.Join(segments, ",")
+		segmentsByKey[key] = segments
+		customersByKey[key] = append(customersByKey[key], customer.CustomerID)
+	}
+
+	for key, customerIDs := range customersByKey {
+		for batch := range slices.Chunk(customerIDs, customerSegmentBatchSize) {
+			if err := s.repos.Customer.SetSegments(ctx, batch, segmentsByKey[key], now); err != nil {
+				return 0, errors.NewServiceError("CUSTOMER_SEGMENTATION_FAILED", "failed to record customer segments", err)
+			}
+		}
+	}
+
+	s.logger.Info("customer segments recalculated", "tenant_id", tenantID, "customers", len(activity))
+	return len(activity), nil
+}
+
+// SegmentDue segments tenants one at a time, so a tenant that fails is retried on the
+// next run without holding back the others
+func (s *customerService) SegmentDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	tenantIDs, err := s.repos.Customer.FindTenantsToSegment(ctx, today)
+	if err != nil {
+		return 0, errors.NewServiceError("QUERY_FAILED", "failed to find tenants to segment customers of", err)
+	}
+
+	segmented := 0
+	for _, tenantID := range tenantIDs {
+		if err := ctx.Err(); err != nil {
+			return segmented, err
+		}
+		customers, err := s.RecalculateSegments(ctx, tenantID)
+		if err != nil {
+			s.logger.Error("failed to segment customers", "tenant_id", tenantID, "error", err)
+			continue
+		}
+		segmented += customers
+	}
+
+	return segmented, nil
+}
+
 // GetCustomerRetentionAnalysis retrieves customer retention analysis
 func (s *customerService) GetCustomerRetentionAnalysis(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) (map[string]interface{}, error) {
 	// Get retention rate using existing method
@@ -733,6 +907,10 @@ func (s *customerService) convertToRepoFilter(filter dto.CustomerFilter) reposit
 		tenantID = *filter.TenantID
 	}
 
+	// Match tags the way they are stored; the filter was validated
+	audience := filter.Audience()
+	_ = audience.Validate()
+
 	return repository.CustomerFilters{
 		TenantID:            tenantID,
 		LoyaltyTiers:        filter.LoyaltyTiers,
@@ -745,6 +923,7 @@ func (s *customerService) convertToRepoFilter(filter dto.CustomerFilter) reposit
 		PreferredArtisanIDs: filter.PreferredArtisans,
 		CreatedAfter:        filter.CreatedAfter,
 		CreatedBefore:       filter.CreatedBefore,
+		Audience:            audience,
 	}
 }
 
@@ -1,12 +1,18 @@
 package service
 
 import (
+	"archive/zip"
 	"context"
-	"errors"
+	"encoding/json"
+	stdErrors "errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/storage"
+	"Krafti_Vibe/internal/pkg/errors"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
 
@@ -17,16 +23,16 @@ import (
 // Data export related errors
 var (
 	// ErrExportRequestNotFound is returned when export request is not found
-	ErrExportRequestNotFound = errors.New("export request not found")
+	ErrExportRequestNotFound = stdErrors.New("export request not found")
 
 	// ErrExportAlreadyInProgress is returned when an export is already in progress
-	ErrExportAlreadyInProgress = errors.New("data export already in progress")
+	ErrExportAlreadyInProgress = stdErrors.New("data export already in progress")
 
 	// ErrExportCannotBeCancelled is returned when export cannot be cancelled
-	ErrExportCannotBeCancelled = errors.New("export cannot be cancelled in current state")
+	ErrExportCannotBeCancelled = stdErrors.New("export cannot be cancelled in current state")
 
 	// ErrExportExpired is returned when export download link has expired
-	ErrExportExpired = errors.New("export download link has expired")
+	ErrExportExpired = stdErrors.New("export download link has expired")
 )
 
 // Export status constants
@@ -45,18 +51,70 @@ const (
 	ExportTypeGDPR    = "gdpr"
 )
 
+const (
+	// dataExportBatchSize caps the exports generated per run of the processing job
+	dataExportBatchSize = 10
+	// dataExportLinkTTL is how long a signed download link stays valid
+	dataExportLinkTTL = 24 * time.Hour
+	// dataExportTimeout bounds how long generating one export may run
+	dataExportTimeout = time.Hour
+)
+
+// dataExportSkippedTables are bookkeeping tables left out of exports
+var dataExportSkippedTables = map[string]bool{
+	"data_export_requests":    true,
+	"idempotency_keys":        true,
+	"outbox_events":           true,
+	"analytics_rollup_states": true,
+}
+
+// dataExportOmittedColumns hold credentials, which are left out of exported rows
+var dataExportOmittedColumns = []string{
+	"password_hash", "mfa_secret", "two_factor_secret", "backup_codes", "session_token", "refresh_tokens",
+	"key_hash", "secret", "token",
+}
+
+// gdprExportTables hold a user's personal data, with the columns naming the user
+var gdprExportTables = []struct {
+	table   string
+	columns []string
+}{
+	{"users", []string{"id"}},
+	{"customers", []string{"user_id"}},
+	{"artisans", []string{"user_id"}},
+	{"bookings", []string{"customer_id", "artisan_id"}},
+	{"payments", []string{"customer_id", "artisan_id"}},
+	{"messages", []string{"sender_id", "receiver_id"}},
+	{"reviews", []string{"customer_id", "artisan_id"}},
+}
+
+// DataExportDownload is an opened export file ready to be streamed to the client
+type DataExportDownload struct {
+	Content  io.ReadCloser
+	FileName string
+}
+
 // DataExportService defines the interface for data export operations
 type DataExportService interface {
 	// Core Operations
 	RequestDataExport(ctx context.Context, req *dto.DataExportRequest, tenantID uuid.UUID, requestedBy uuid.UUID) (*dto.DataExportResponse, error)
-	GetDataExportStatus(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) (*dto.DataExportResponse, error)
-	ListDataExports(ctx context.Context, tenantID uuid.UUID, filter *dto.DataExportFilter) (*dto.DataExportListResponse, error)
+	GetDataExportStatus(ctx context.Context, id uuid.UUID, tenantID uuid.UUID, baseURL string) (*dto.DataExportResponse, error)
+	ListDataExports(ctx context.Context, tenantID uuid.UUID, filter *dto.DataExportFilter, baseURL string) (*dto.DataExportListResponse, error)
 	CancelDataExport(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) error
 
+	// Personal Data (GDPR)
+	RequestUserDataExport(ctx context.Context, userID uuid.UUID, requestedBy uuid.UUID) (*dto.DataExportResponse, error)
+	ListUserDataExports(ctx context.Context, userID uuid.UUID, requestedBy uuid.UUID, baseURL string) ([]*dto.DataExportResponse, error)
+
+	// OpenDownload verifies a signed download link and opens the export file
+	OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (*DataExportDownload, error)
+
 	// Processing Operations (for background workers)
+	ProcessPendingExports(ctx context.Context) (int, error)
 	StartProcessing(ctx context.Context, id uuid.UUID) error
 	MarkCompleted(ctx context.Context, id uuid.UUID, fileURL string, fileSize int64) error
 	MarkFailed(ctx context.Context, id uuid.UUID, errorMessage string) error
+	FailInterruptedExports(ctx context.Context) (int64, error)
 
 	// Query Operations
 	GetPendingExports(ctx context.Context) ([]*dto.DataExportResponse, error)
@@ -71,14 +129,18 @@ type DataExportService interface {
 type dataExportService struct {
 	repos           *repository.Repositories
 	logger          *zap.Logger
-	downloadExpiry  time.Duration // How long download links are valid
+	downloadExpiry  time.Duration // How long generated files are kept
 	maxExportPerDay int           // Maximum exports per tenant per day
+	store           storage.ObjectStore
+	signer          *storage.URLSigner
 }
 
 // DataExportServiceConfig holds configuration for the data export service
 type DataExportServiceConfig struct {
 	DownloadExpiry  time.Duration
 	MaxExportPerDay int
+	Store           storage.ObjectStore // Where generated files are kept; without it exports stay pending
+	SigningSecret   string              // Signs download links
 }
 
 // NewDataExportService creates a new data export service
@@ -90,8 +152,10 @@ func NewDataExportService(
 	// Default configuration
 	downloadExpiry := 24 * time.Hour
 	maxExportPerDay := 5
+	var cfg DataExportServiceConfig
 
 	if len(config) > 0 {
+		cfg = config[0]
 		if config[0].DownloadExpiry > 0 {
 			downloadExpiry = config[0].DownloadExpiry
 		}
@@ -105,6 +169,8 @@ func NewDataExportService(
 		logger:          logger,
 		downloadExpiry:  downloadExpiry,
 		maxExportPerDay: maxExportPerDay,
+		store:           cfg.Store,
+		signer:          storage.NewURLSigner(cfg.SigningSecret),
 	}
 }
 
@@ -124,14 +190,15 @@ func (s *dataExportService) RequestDataExport(ctx context.Context, req *dto.Data
 		zap.String("format", req.Format),
 	)
 
-	// Verify tenant exists and is active
+	// Verify tenant exists and is not closed; cancelled tenants export their data
+	// while being offboarded
 	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
 	if err != nil {
 		return nil, ErrTenantNotFound
 	}
 
-	if tenant.Status == models.TenantStatusCancelled {
-		return nil, ErrTenantCancelled
+	if tenant.Status == models.TenantStatusClosed {
+		return nil, ErrTenantClosed
 	}
 
 	// Check for existing in-progress export
@@ -158,14 +225,12 @@ func (s *dataExportService) RequestDataExport(ctx context.Context, req *dto.Data
 		zap.String("tenant_id", tenantID.String()),
 	)
 
-	// TODO: Queue export job asynchronously via job queue/message broker
-	// Example: s.jobQueue.Enqueue("data_export", exportRequest.ID)
-
+	// The file is generated by the data_export_processing job
 	return dto.ToDataExportResponse(exportRequest), nil
 }
 
 // GetDataExportStatus retrieves the status of a data export request
-func (s *dataExportService) GetDataExportStatus(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) (*dto.DataExportResponse, error) {
+func (s *dataExportService) GetDataExportStatus(ctx context.Context, id uuid.UUID, tenantID uuid.UUID, baseURL string) (*dto.DataExportResponse, error) {
 	exportRequest, err := s.repos.DataExport.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrExportRequestNotFound
@@ -176,21 +241,11 @@ func (s *dataExportService) GetDataExportStatus(ctx context.Context, id uuid.UUI
 		return nil, ErrExportRequestNotFound // Don't expose existence to other tenants
 	}
 
-	response := dto.ToDataExportResponse(exportRequest)
-
-	// Check if download link has expired
-	if exportRequest.Status == ExportStatusCompleted && exportRequest.ExpiresAt != nil {
-		if time.Now().After(*exportRequest.ExpiresAt) {
-			response.Status = "expired"
-			response.FileURL = "" // Don't return expired URL
-		}
-	}
-
-	return response, nil
+	return s.toResponse(exportRequest, baseURL), nil
 }
 
 // ListDataExports lists data export requests for a tenant
-func (s *dataExportService) ListDataExports(ctx context.Context, tenantID uuid.UUID, filter *dto.DataExportFilter) (*dto.DataExportListResponse, error) {
+func (s *dataExportService) ListDataExports(ctx context.Context, tenantID uuid.UUID, filter *dto.DataExportFilter, baseURL string) (*dto.DataExportListResponse, error) {
 	if filter == nil {
 		filter = &dto.DataExportFilter{
 			Page:     1,
@@ -219,7 +274,7 @@ func (s *dataExportService) ListDataExports(ctx context.Context, tenantID uuid.U
 
 	responses := make([]*dto.DataExportResponse, 0, len(exports))
 	for _, export := range exports {
-		response := dto.ToDataExportResponse(export)
+		response := s.toResponse(export, baseURL)
 
 		// Apply status filter if provided
 		if filter.Status != nil && *filter.Status != "" {
@@ -228,14 +283,6 @@ func (s *dataExportService) ListDataExports(ctx context.Context, tenantID uuid.U
 			}
 		}
 
-		// Mark expired downloads
-		if export.Status == ExportStatusCompleted && export.ExpiresAt != nil {
-			if time.Now().After(*export.ExpiresAt) {
-				response.Status = "expired"
-				response.FileURL = ""
-			}
-		}
-
 		responses = append(responses, response)
 	}
 
@@ -279,10 +326,195 @@ func (s *dataExportService) CancelDataExport(ctx context.Context, id uuid.UUID,
 	return nil
 }
 
+// ============================================================================
+// Personal Data (GDPR)
+// ============================================================================
+
+// RequestUserDataExport requests an export of the user's personal data across tenants:
+// their account and profiles, and the bookings, payments, messages and reviews naming
+// them
+func (s *dataExportService) RequestUserDataExport(ctx context.Context, userID uuid.UUID, requestedBy uuid.UUID) (*dto.DataExportResponse, error) {
+	user, err := loadDataSubject(ctx, s.repos, userID, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsErased() {
+		return nil, errors.NewConflictError("the user's personal data has been erased")
+	}
+
+	exports, err := s.repos.DataExport.FindBySubjectUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to find user exports", zap.Error(err))
+		return nil, errors.NewServiceError("EXPORT_REQUEST_FAILED", "failed to request data export", err)
+	}
+	for _, export := range exports {
+		if export.Status == ExportStatusPending || export.Status == ExportStatusProcessing {
+			return nil, errors.NewConflictError("an export of the user's data is already in progress")
+		}
+	}
+
+	var tenantID uuid.UUID
+	if user.TenantID != nil {
+		tenantID = *user.TenantID
+	}
+	exportRequest := &models.DataExportRequest{
+		TenantID:      tenantID,
+		RequestedBy:   requestedBy,
+		ExportType:    ExportTypeGDPR,
+		SubjectUserID: &user.ID,
+		Status:        ExportStatusPending,
+	}
+	if err := s.repos.DataExport.Create(ctx, exportRequest); err != nil {
+		s.logger.Error("failed to create user export request", zap.Error(err))
+		return nil, errors.NewServiceError("EXPORT_REQUEST_FAILED", "failed to request data export", err)
+	}
+
+	s.logger.Info("user data export requested",
+		zap.String("export_id", exportRequest.ID.String()),
+		zap.String("user_id", userID.String()),
+	)
+
+	// The file is generated by the data_export_processing job
+	return dto.ToDataExportResponse(exportRequest), nil
+}
+
+// ListUserDataExports lists the exports of the user's personal data, newest first
+func (s *dataExportService) ListUserDataExports(ctx context.Context, userID uuid.UUID, requestedBy uuid.UUID, baseURL string) ([]*dto.DataExportResponse, error) {
+	if _, err := loadDataSubject(ctx, s.repos, userID, requestedBy); err != nil {
+		return nil, err
+	}
+
+	exports, err := s.repos.DataExport.FindBySubjectUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to list user exports", zap.Error(err))
+		return nil, errors.NewServiceError("EXPORT_LIST_FAILED", "failed to list data exports", err)
+	}
+
+	responses := make([]*dto.DataExportResponse, 0, len(exports))
+	for _, export := range exports {
+		responses = append(responses, s.toResponse(export, baseURL))
+	}
+	return responses, nil
+}
+
+// OpenDownload checks the link signature against the stored file key and opens the file
+func (s *dataExportService) OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (*DataExportDownload, error) {
+	exportRequest, err := s.repos.DataExport.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("data export not found")
+		}
+		return nil, errors.NewServiceError("EXPORT_GET_FAILED", "failed to get data export", err)
+	}
+	if !isDownloadable(exportRequest) || s.store == nil {
+		return nil, errors.NewNotFoundError("data export file not available")
+	}
+
+	if err := s.signer.Verify(exportRequest.FileURL, expires, signature); err != nil {
+		switch {
+		case stdErrors.Is(err, storage.ErrMissingSecret):
+			return nil, errors.NewServiceError("EXPORT_UNAVAILABLE", "download links are not configured", err)
+		case stdErrors.Is(err, storage.ErrSignatureExpired):
+			return nil, errors.NewForbiddenError("download link has expired")
+		default:
+			return nil, errors.NewForbiddenError("invalid download link")
+		}
+	}
+
+	content, err := s.store.Open(ctx, exportRequest.FileURL)
+	if err != nil {
+		if stdErrors.Is(err, storage.ErrObjectNotFound) {
+			return nil, errors.NewNotFoundError("data export file not available")
+		}
+		return nil, errors.NewServiceError("EXPORT_OPEN_FAILED", "failed to open data export", err)
+	}
+
+	return &DataExportDownload{
+		Content:  content,
+		FileName: fmt.Sprintf("data-export-%s.zip", exportRequest.ID),
+	}, nil
+}
+
 // ============================================================================
 // Processing Operations (for background workers)
 // ============================================================================
 
+// ProcessPendingExports generates the files of pending full and GDPR exports: a zip
+// archive holding, for full exports, the tenant record and the rows of every table of
+// tenant data as JSON lines, and for GDPR exports the rows naming their user. Partial
+// exports are left pending for their own generator. It returns how many exports
+// completed; those that fail are marked failed.
+func (s *dataExportService) ProcessPendingExports(ctx context.Context) (int, error) {
+	if s.store == nil {
+		return 0, nil
+	}
+
+	pending, _, err := s.repos.DataExport.FindByStatus(ctx, ExportStatusPending, repository.PaginationParams{Page: 1, PageSize: dataExportBatchSize})
+	if err != nil {
+		s.logger.Error("failed to find pending exports", zap.Error(err))
+		return 0, fmt.Errorf("failed to find pending exports: %w", err)
+	}
+
+	completed := 0
+	for _, export := range pending {
+		var key string
+		switch {
+		case export.ExportType == ExportTypeFull:
+			key = fmt.Sprintf("exports/%s/data/%s.zip", export.TenantID, export.ID)
+		case export.ExportType == ExportTypeGDPR && export.SubjectUserID != nil:
+			key = fmt.Sprintf("exports/%s/users/%s/%s.zip", export.TenantID, *export.SubjectUserID, export.ID)
+		default:
+			continue
+		}
+		if err := s.StartProcessing(ctx, export.ID); err != nil {
+			continue
+		}
+
+		size, err := s.writeArchive(ctx, export, key)
+		if err != nil {
+			s.logger.Error("data export failed",
+				zap.String("export_id", export.ID.String()),
+				zap.Error(err),
+			)
+			// Record the failure even when the job was stopped mid-export
+			_ = s.MarkFailed(context.WithoutCancel(ctx), export.ID, err.Error())
+			continue
+		}
+
+		if err := s.MarkCompleted(ctx, export.ID, key, size); err != nil {
+			continue
+		}
+		completed++
+	}
+
+	return completed, nil
+}
+
+// writeArchive stores the archive of an export under key within dataExportTimeout
+func (s *dataExportService) writeArchive(ctx context.Context, export *models.DataExportRequest, key string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, dataExportTimeout)
+	defer cancel()
+
+	if export.SubjectUserID != nil {
+		return s.writeUserArchive(ctx, *export.SubjectUserID, key)
+	}
+	return s.writeTenantArchive(ctx, export.TenantID, key)
+}
+
+// FailInterruptedExports marks exports processing for longer than dataExportTimeout as
+// failed. Any still running would have timed out, so they were interrupted.
+func (s *dataExportService) FailInterruptedExports(ctx context.Context) (int64, error) {
+	failed, err := s.repos.DataExport.FailProcessing(ctx, time.Now().Add(-dataExportTimeout))
+	if err != nil {
+		s.logger.Error("failed to fail interrupted exports", zap.Error(err))
+		return 0, fmt.Errorf("failed to fail interrupted exports: %w", err)
+	}
+	if failed > 0 {
+		s.logger.Warn("failed interrupted exports", zap.Int64("count", failed))
+	}
+	return failed, nil
+}
+
 // StartProcessing marks an export as being processed
 func (s *dataExportService) StartProcessing(ctx context.Context, id uuid.UUID) error {
 	s.logger.Info("starting export processing", zap.String("export_id", id.String()))
@@ -437,14 +669,7 @@ func (s *dataExportService) DeleteExpiredExports(ctx context.Context) (int64, er
 
 	// Delete associated files from storage
 	for _, export := range expiredExports {
-		if export.FileURL != "" {
-			// TODO: Delete file from storage (S3, GCS, etc.)
-			// Example: s.storageService.DeleteFile(ctx, export.FileURL)
-			s.logger.Info("deleting export file",
-				zap.String("export_id", export.ID.String()),
-				zap.String("file_url", export.FileURL),
-			)
-		}
+		s.deleteFile(ctx, export)
 	}
 
 	// Delete the records
@@ -481,13 +706,7 @@ func (s *dataExportService) DeleteExportsByTenant(ctx context.Context, tenantID
 
 	// Delete associated files
 	for _, export := range exports {
-		if export.FileURL != "" {
-			// TODO: Delete file from storage
-			s.logger.Info("deleting export file",
-				zap.String("export_id", export.ID.String()),
-				zap.String("file_url", export.FileURL),
-			)
-		}
+		s.deleteFile(ctx, export)
 	}
 
 	// Delete all export records
@@ -499,3 +718,162 @@ func (s *dataExportService) DeleteExportsByTenant(ctx context.Context, tenantID
 	s.logger.Info("tenant exports deleted", zap.String("tenant_id", tenantID.String()))
 	return nil
 }
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// writeTenantArchive streams the tenant's data into the store as a zip archive under
+// key, returning the size of the stored object
+func (s *dataExportService) writeTenantArchive(ctx context.Context, tenantID uuid.UUID, key string) (int64, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load tenant: %w", err)
+	}
+	tables, err := s.repos.DataExport.ListTenantTables(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tenant tables: %w", err)
+	}
+
+	return s.storeArchive(ctx, key, func(archive *zip.Writer) error {
+		return s.writeTenantData(ctx, archive, tenant, tables)
+	})
+}
+
+// writeUserArchive streams the user's personal data into the store as a zip archive
+// under key, returning the size of the stored object
+func (s *dataExportService) writeUserArchive(ctx context.Context, userID uuid.UUID, key string) (int64, error) {
+	return s.storeArchive(ctx, key, func(archive *zip.Writer) error {
+		return s.writeUserData(ctx, archive, userID)
+	})
+}
+
+// storeArchive streams the zip archive write fills into the store under key, returning
+// the size of the stored object
+func (s *dataExportService) storeArchive(ctx context.Context, key string, write func(archive *zip.Writer) error) (int64, error) {
+	reader, writer := io.Pipe()
+	streamed := make(chan error, 1)
+	go func() {
+		archive := zip.NewWriter(writer)
+		err := write(archive)
+		if err == nil {
+			err = archive.Close()
+		}
+		writer.CloseWithError(err)
+		streamed <- err
+	}()
+
+	size, err := s.store.Put(ctx, key, "application/zip", reader)
+	// Unblocks the writer when the store gave up before reading everything
+	reader.CloseWithError(err)
+	if streamErr := <-streamed; streamErr != nil {
+		return 0, streamErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return size, nil
+}
+
+// writeTenantData writes the tenant record as tenant.json and the tenant's rows of
+// each table as <table>.jsonl
+func (s *dataExportService) writeTenantData(ctx context.Context, archive *zip.Writer, tenant *models.Tenant, tables []string) error {
+	w, err := archive.Create("tenant.json")
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(tenant); err != nil {
+		return fmt.Errorf("failed to write tenant: %w", err)
+	}
+
+	for _, table := range tables {
+		if dataExportSkippedTables[table] {
+			continue
+		}
+		w, err := archive.Create(table + ".jsonl")
+		if err != nil {
+			return err
+		}
+		if _, err := s.repos.DataExport.StreamTenantRows(ctx, table, tenant.ID, dataExportOmittedColumns, func(row []byte) error {
+			if _, err := w.Write(row); err != nil {
+				return err
+			}
+			_, err := w.Write([]byte{'\n'})
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to export %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// writeUserData writes the rows of each table of personal data naming the user as
+// <table>.jsonl
+func (s *dataExportService) writeUserData(ctx context.Context, archive *zip.Writer, userID uuid.UUID) error {
+	for _, source := range gdprExportTables {
+		w, err := archive.Create(source.table + ".jsonl")
+		if err != nil {
+			return err
+		}
+		if _, err := s.repos.DataExport.StreamUserRows(ctx, source.table, source.columns, userID, dataExportOmittedColumns, func(row []byte) error {
+			if _, err := w.Write(row); err != nil {
+				return err
+			}
+			_, err := w.Write([]byte{'\n'})
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to export %s: %w", source.table, err)
+		}
+	}
+	return nil
+}
+
+// toResponse converts the export, replacing the key of its file with a download link
+// valid for dataExportLinkTTL or until the file expires, whichever comes first
+func (s *dataExportService) toResponse(export *models.DataExportRequest, baseURL string) *dto.DataExportResponse {
+	response := dto.ToDataExportResponse(export)
+	response.FileURL = ""
+	if export.Status == ExportStatusCompleted && export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt) {
+		response.Status = "expired"
+		return response
+	}
+	if !isDownloadable(export) {
+		return response
+	}
+
+	expires := time.Now().Add(dataExportLinkTTL)
+	if export.ExpiresAt != nil && export.ExpiresAt.Before(expires) {
+		expires = *export.ExpiresAt
+	}
+
+	signature, err := s.signer.Sign(export.FileURL, expires)
+	if err != nil {
+		s.logger.Warn("data export download link not signed", zap.String("export_id", export.ID.String()), zap.Error(err))
+		return response
+	}
+
+	response.FileURL = fmt.Sprintf("%s/api/v1/data-exports/%s/download?expires=%d&signature=%s",
+		strings.TrimRight(baseURL, "/"), export.ID, expires.Unix(), signature)
+	return response
+}
+
+// deleteFile removes an export's file from storage; failures are logged
+func (s *dataExportService) deleteFile(ctx context.Context, export *models.DataExportRequest) {
+	if export.FileURL == "" || s.store == nil {
+		return
+	}
+	if err := s.store.Delete(ctx, export.FileURL); err != nil {
+		s.logger.Error("failed to delete export file",
+			zap.String("export_id", export.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// isDownloadable reports whether the export's file is ready and not yet expired
+func isDownloadable(export *models.DataExportRequest) bool {
+	return export.Status == ExportStatusCompleted && export.FileURL != "" &&
+		(export.ExpiresAt == nil || time.Now().Before(*export.ExpiresAt))
+}
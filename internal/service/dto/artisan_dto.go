@@ -23,6 +23,7 @@ type CreateArtisanRequest struct {
 	Certifications       []models.Certification `json:"certifications,omitempty"`
 	Portfolio            []models.PortfolioItem `json:"portfolio,omitempty"`
 	CommissionRate       float64                `json:"commission_rate" validate:"min=0,max=100"`
+	Tier                 models.ArtisanTier     `json:"tier,omitempty"` // Defaults to standard
 	PaymentAccountID     string                 `json:"payment_account_id,omitempty"`
 	AutoAcceptBookings   bool                   `json:"auto_accept_bookings"`
 	BookingLeadTime      int                    `json:"booking_lead_time" validate:"min=0"`
@@ -50,6 +51,9 @@ func (r *CreateArtisanRequest) Validate() error {
 	if r.CommissionRate < 0 || r.CommissionRate > 100 {
 		return fmt.Errorf("commission_rate must be between 0 and 100")
 	}
+	if r.Tier != "" && !r.Tier.IsValid() {
+		return fmt.Errorf("invalid artisan tier")
+	}
 	if r.BookingLeadTime < 0 {
 		return fmt.Errorf("booking_lead_time cannot be negative")
 	}
@@ -83,6 +87,11 @@ type UpdateArtisanRequest struct {
 	Metadata             map[string]any         `json:"metadata,omitempty"`
 }
 
+// UpdateArtisanTierRequest moves an artisan to another tier
+type UpdateArtisanTierRequest struct {
+	Tier models.ArtisanTier `json:"tier" validate:"required"`
+}
+
 // UpdateAvailabilityRequest represents a request to update artisan availability
 type UpdateAvailabilityRequest struct {
 	IsAvailable      bool   `json:"is_available"`
@@ -111,6 +120,58 @@ type SearchArtisanRequest struct {
 	PageSize int       `json:"page_size"`
 }
 
+// NearbyArtisansRequest finds a tenant's artisans around a point, within a radius of
+// it or, when Bounds is set, inside a box such as a map's viewport
+type NearbyArtisansRequest struct {
+	TenantID      uuid.UUID  `json:"tenant_id" validate:"required"`
+	Latitude      float64    `json:"latitude" validate:"latitude"`
+	Longitude     float64    `json:"longitude" validate:"longitude"`
+	RadiusKm      float64    `json:"radius_km,omitempty"` // Defaults to 10
+	Bounds        *GeoBounds `json:"bounds,omitempty"`
+	AvailableOnly bool       `json:"available_only"`
+	Limit         int        `json:"limit,omitempty"` // Defaults to 20
+}
+
+// GeoBounds is a box of latitudes and longitudes
+type GeoBounds struct {
+	South float64 `json:"south"`
+	West  float64 `json:"west"`
+	North float64 `json:"north"`
+	East  float64 `json:"east"`
+}
+
+// Validate validates the nearby artisans request, defaulting the radius and limit
+func (r *NearbyArtisansRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant_id is required")
+	}
+	if r.Latitude < -90 || r.Latitude > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if r.Longitude < -180 || r.Longitude > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	if r.RadiusKm == 0 {
+		r.RadiusKm = 10
+	}
+	if r.RadiusKm < 0 || r.RadiusKm > 500 {
+		return fmt.Errorf("radius_km must be between 0 and 500")
+	}
+	if b := r.Bounds; b != nil {
+		if b.South < -90 || b.North > 90 || b.South >= b.North {
+			return fmt.Errorf("bounds must have south below north, within -90 and 90")
+		}
+		if b.West < -180 || b.East > 180 || b.West >= b.East {
+			return fmt.Errorf("bounds must have west before east, within -180 and 180")
+		}
+	}
+	if r.Limit <= 0 {
+		r.Limit = 20
+	}
+	r.Limit = min(r.Limit, 100)
+	return nil
+}
+
 // AddCertificationRequest represents request to add a certification
 type AddCertificationRequest struct {
 	Name       string     `json:"name" validate:"required"`
@@ -143,12 +204,16 @@ type ArtisanResponse struct {
 	YearsExperience      int                    `json:"years_experience"`
 	Certifications       []models.Certification `json:"certifications,omitempty"`
 	Portfolio            []models.PortfolioItem `json:"portfolio,omitempty"`
+	IsVerified           bool                   `json:"is_verified"` // Approved, current identity document
+	IsInsured            bool                   `json:"is_insured"`  // Approved, current insurance certificate
 	Rating               float64                `json:"rating"`
 	ReviewCount          int                    `json:"review_count"`
 	TotalBookings        int                    `json:"total_bookings"`
+	FavoriteCount        int                    `json:"favorite_count"`
 	IsAvailable          bool                   `json:"is_available"`
 	AvailabilityNote     string                 `json:"availability_note,omitempty"`
 	CommissionRate       float64                `json:"commission_rate"`
+	Tier                 models.ArtisanTier     `json:"tier"`
 	PaymentAccountID     string                 `json:"payment_account_id,omitempty"`
 	AutoAcceptBookings   bool                   `json:"auto_accept_bookings"`
 	BookingLeadTime      int                    `json:"booking_lead_time"`
@@ -176,6 +241,12 @@ type ArtisanListResponse struct {
 	HasPrevious bool               `json:"has_previous"`
 }
 
+// NearbyArtisanResponse is an artisan found around a point
+type NearbyArtisanResponse struct {
+	*ArtisanResponse
+	DistanceKm float64 `json:"distance_km"`
+}
+
 // ArtisanStatsResponse represents artisan statistics
 type ArtisanStatsResponse struct {
 	ArtisanID         uuid.UUID       `json:"artisan_id"`
@@ -275,12 +346,16 @@ func ToArtisanResponse(artisan *models.Artisan) *ArtisanResponse {
 		YearsExperience:      artisan.YearsExperience,
 		Certifications:       artisan.Certifications,
 		Portfolio:            artisan.Portfolio,
+		IsVerified:           artisan.IsVerified,
+		IsInsured:            artisan.IsInsured,
 		Rating:               artisan.Rating,
 		ReviewCount:          artisan.ReviewCount,
 		TotalBookings:        artisan.TotalBookings,
+		FavoriteCount:        artisan.FavoriteCount,
 		IsAvailable:          artisan.IsAvailable,
 		AvailabilityNote:     artisan.AvailabilityNote,
 		CommissionRate:       artisan.CommissionRate,
+		Tier:                 artisan.Tier,
 		PaymentAccountID:     artisan.PaymentAccountID,
 		AutoAcceptBookings:   artisan.AutoAcceptBookings,
 		BookingLeadTime:      artisan.BookingLeadTime,
@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Audit Log Request DTOs
+// ============================================================================
+
+// AuditLogFilter represents filters for audit log queries
+type AuditLogFilter struct {
+	TenantID   uuid.UUID          `json:"tenant_id"`
+	EntityType string             `json:"entity_type,omitempty"` // Table of the resource, such as "bookings"
+	EntityID   *uuid.UUID         `json:"entity_id,omitempty"`
+	UserID     *uuid.UUID         `json:"user_id,omitempty"` // Acting user
+	Action     models.AuditAction `json:"action,omitempty"`
+	RequestID  string             `json:"request_id,omitempty"`
+	From       *time.Time         `json:"from,omitempty"` // Recorded at or after
+	To         *time.Time         `json:"to,omitempty"`   // Recorded before
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+}
+
+// ============================================================================
+// Audit Log Response DTOs
+// ============================================================================
+
+// AuditLogResponse represents an entry of the audit trail
+type AuditLogResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	TenantID    *uuid.UUID         `json:"tenant_id,omitempty"`
+	UserID      *uuid.UUID         `json:"user_id,omitempty"`
+	UserEmail   string             `json:"user_email,omitempty"`
+	UserRole    models.UserRole    `json:"user_role,omitempty"`
+	Action      models.AuditAction `json:"action"`
+	EntityType  string             `json:"entity_type"`
+	EntityID    uuid.UUID          `json:"entity_id"`
+	Description string             `json:"description,omitempty"`
+	OldValues   map[string]any     `json:"old_values,omitempty"`
+	NewValues   map[string]any     `json:"new_values,omitempty"`
+	IPAddress   string             `json:"ip_address,omitempty"`
+	UserAgent   string             `json:"user_agent,omitempty"`
+	RequestID   string             `json:"request_id,omitempty"`
+	Metadata    map[string]any     `json:"metadata,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// AuditLogListResponse represents a paginated list of audit log entries
+type AuditLogListResponse struct {
+	Entries     []*AuditLogResponse `json:"entries"`
+	Page        int                 `json:"page"`
+	PageSize    int                 `json:"page_size"`
+	TotalItems  int64               `json:"total_items"`
+	TotalPages  int                 `json:"total_pages"`
+	HasNext     bool                `json:"has_next"`
+	HasPrevious bool                `json:"has_previous"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToAuditLogResponse converts an AuditLog model to a response DTO
+func ToAuditLogResponse(entry *models.AuditLog) *AuditLogResponse {
+	if entry == nil {
+		return nil
+	}
+
+	return &AuditLogResponse{
+		ID:          entry.ID,
+		TenantID:    entry.TenantID,
+		UserID:      entry.UserID,
+		UserEmail:   entry.UserEmail,
+		UserRole:    entry.UserRole,
+		Action:      entry.Action,
+		EntityType:  entry.EntityType,
+		EntityID:    entry.EntityID,
+		Description: entry.Description,
+		OldValues:   entry.OldValues,
+		NewValues:   entry.NewValues,
+		IPAddress:   entry.IPAddress,
+		UserAgent:   entry.UserAgent,
+		RequestID:   entry.RequestID,
+		Metadata:    entry.Metadata,
+		CreatedAt:   entry.CreatedAt,
+	}
+}
+
+// ToAuditLogResponses converts multiple AuditLog models to response DTOs
+func ToAuditLogResponses(entries []*models.AuditLog) []*AuditLogResponse {
+	responses := make([]*AuditLogResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = ToAuditLogResponse(entry)
+	}
+	return responses
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 	"time"
@@ -17,27 +18,38 @@ import (
 
 // CreateBookingRequest represents the request to create a booking
 type CreateBookingRequest struct {
-	TenantID              uuid.UUID        `json:"tenant_id" validate:"required"`
-	ArtisanID             uuid.UUID        `json:"artisan_id" validate:"required"`
-	CustomerID            uuid.UUID        `json:"customer_id" validate:"required"`
-	ServiceID             uuid.UUID        `json:"service_id" validate:"required"`
-	StartTime             time.Time        `json:"start_time" validate:"required"`
-	Duration              int              `json:"duration" validate:"required,min=15,max=480"` // 15 min to 8 hours
-	Notes                 string           `json:"notes,omitempty"`
-	CustomerNotes         string           `json:"customer_notes,omitempty"`
-	SelectedAddons        []uuid.UUID      `json:"selected_addons,omitempty"`
-	ServiceLocation       *models.Location `json:"service_location,omitempty"`
-	PaymentMethodID       string           `json:"payment_method_id,omitempty"`
-	RequiresDeposit       bool             `json:"requires_deposit"`
-	DepositAmount         float64          `json:"deposit_amount"`
-	AutoConfirm           bool             `json:"auto_confirm"`
-	SendConfirmationEmail bool             `json:"send_confirmation_email"`
-	SendConfirmationSMS   bool             `json:"send_confirmation_sms"`
-	IsRecurring           bool             `json:"is_recurring"`
-	RecurrencePattern     string           `json:"recurrence_pattern,omitempty"` // weekly, biweekly, monthly
-	RecurrenceEndDate     *time.Time       `json:"recurrence_end_date,omitempty"`
-	RecurrenceOccurrences *int             `json:"recurrence_occurrences,omitempty"`
-	Metadata              map[string]any   `json:"metadata,omitempty"`
+	TenantID              uuid.UUID             `json:"tenant_id" validate:"required"`
+	ArtisanID             uuid.UUID             `json:"artisan_id" validate:"required"`
+	CustomerID            uuid.UUID             `json:"customer_id" validate:"required"`
+	ServiceID             uuid.UUID             `json:"service_id" validate:"required"`
+	ProjectID             *uuid.UUID            `json:"project_id,omitempty"` // Link the visit to a project of the same customer
+	StartTime             time.Time             `json:"start_time" validate:"required"`
+	Duration              int                   `json:"duration" validate:"required,min=15,max=480"` // 15 min to 8 hours
+	Notes                 string                `json:"notes,omitempty"`
+	CustomerNotes         string                `json:"customer_notes,omitempty"`
+	SelectedAddons        []uuid.UUID           `json:"selected_addons,omitempty"` // Each counts as quantity 1
+	Addons                []BookingAddonRequest `json:"addons,omitempty"`
+	IntakeAnswers         map[string]any        `json:"intake_answers,omitempty"` // Answers to the service's intake form, keyed by field key
+	Tags                  []string              `json:"tags,omitempty"`
+	ServiceLocation       *models.Location      `json:"service_location,omitempty"`
+	PromoCode             string                `json:"promo_code,omitempty"` // Discounts the service and addons price before tax
+	PaymentMethodID       string                `json:"payment_method_id,omitempty"`
+	RequiresDeposit       bool                  `json:"requires_deposit"`
+	DepositAmount         float64               `json:"deposit_amount"`
+	BalanceDueHoursBefore *int                  `json:"balance_due_hours_before,omitempty"` // Charge the remainder this long before the start; defaults to the tenant setting
+	AutoConfirm           bool                  `json:"auto_confirm"`
+	SendConfirmationEmail bool                  `json:"send_confirmation_email"`
+	SendConfirmationSMS   bool                  `json:"send_confirmation_sms"`
+	IsRecurring           bool                  `json:"is_recurring"`
+	RecurrencePattern     string                `json:"recurrence_pattern,omitempty"` // weekly, biweekly, monthly
+	RecurrenceEndDate     *time.Time            `json:"recurrence_end_date,omitempty"`
+	RecurrenceOccurrences *int                  `json:"recurrence_occurrences,omitempty"`
+	Metadata              map[string]any        `json:"metadata,omitempty"`
+
+	// Availability override: book the requested slot even when it conflicts (tenant owner/admin only).
+	// Conflicting recurring occurrences are still skipped.
+	OverrideAvailability bool   `json:"override_availability"`
+	OverrideReason       string `json:"override_reason,omitempty"`
 }
 
 // Validate validates the create booking request
@@ -72,6 +84,21 @@ func (r *CreateBookingRequest) Validate() error {
 	if r.RequiresDeposit && r.DepositAmount <= 0 {
 		return fmt.Errorf("deposit amount must be positive when deposit is required")
 	}
+	if r.BalanceDueHoursBefore != nil && (*r.BalanceDueHoursBefore < 0 || *r.BalanceDueHoursBefore > 720) {
+		return fmt.Errorf("balance due hours must be between 0 and 720")
+	}
+	if r.BalanceDueHoursBefore != nil && !r.RequiresDeposit {
+		return fmt.Errorf("balance collection requires a deposit")
+	}
+	if len(r.OverrideReason) > 500 {
+		return fmt.Errorf("override reason must be 500 characters or less")
+	}
+	if err := validateAddonSelections(r.SelectedAddons, r.Addons); err != nil {
+		return err
+	}
+	if _, err := models.NormalizeBookingTags(r.Tags); err != nil {
+		return err
+	}
 
 	// Validate recurrence settings
 	if r.IsRecurring {
@@ -98,13 +125,18 @@ func (r *CreateBookingRequest) Validate() error {
 
 // UpdateBookingRequest represents the request to update a booking
 type UpdateBookingRequest struct {
+	// Version the client edited; a stale version is rejected with 409 Conflict
+	Version            *int                  `json:"version,omitempty"`
 	Notes              *string               `json:"notes,omitempty"`
 	CustomerNotes      *string               `json:"customer_notes,omitempty"`
 	InternalNotes      *string               `json:"internal_notes,omitempty"`
 	ServiceLocation    *models.Location      `json:"service_location,omitempty"`
 	Status             *models.BookingStatus `json:"status,omitempty"`
 	PaymentStatus      *models.PaymentStatus `json:"payment_status,omitempty"`
-	SelectedAddons     []uuid.UUID           `json:"selected_addons,omitempty"`
+	SelectedAddons     []uuid.UUID           `json:"selected_addons,omitempty"` // Each counts as quantity 1
+	Addons             []BookingAddonRequest `json:"addons,omitempty"`          // With SelectedAddons, replaces the booking's addons when set
+	IntakeAnswers      map[string]any        `json:"intake_answers,omitempty"`  // Replaces all answers when set
+	Tags               *[]string             `json:"tags,omitempty"`            // Replaces all tags when set; empty clears them
 	CancellationReason *string               `json:"cancellation_reason,omitempty"`
 	PaymentIntentID    *string               `json:"payment_intent_id,omitempty"`
 	RefundID           *string               `json:"refund_id,omitempty"`
@@ -129,6 +161,60 @@ func (r *UpdateBookingRequest) Validate() error {
 	if r.CancellationReason != nil && len(*r.CancellationReason) > 500 {
 		return fmt.Errorf("cancellation reason must be 500 characters or less")
 	}
+	if r.Tags != nil {
+		if _, err := models.NormalizeBookingTags(*r.Tags); err != nil {
+			return err
+		}
+	}
+	return validateAddonSelections(r.SelectedAddons, r.Addons)
+}
+
+// HasAddonChanges reports whether the request replaces the booking's addons
+func (r *UpdateBookingRequest) HasAddonChanges() bool {
+	return len(r.SelectedAddons) > 0 || len(r.Addons) > 0
+}
+
+// IsCancellationOnly reports whether the request cancels the booking and changes nothing
+// else but the cancellation reason
+func (r *UpdateBookingRequest) IsCancellationOnly() bool {
+	if r.Status == nil || *r.Status != models.BookingStatusCancelled {
+		return false
+	}
+	rest := *r
+	rest.Version, rest.Status, rest.CancellationReason = nil, nil, nil
+	return reflect.DeepEqual(rest, UpdateBookingRequest{})
+}
+
+// BookingAddonRequest selects an addon with a quantity
+type BookingAddonRequest struct {
+	AddonID  uuid.UUID `json:"addon_id" validate:"required"`
+	Quantity int       `json:"quantity" validate:"min=1"`
+}
+
+// MergeAddonSelections combines quantity selections with plain addon IDs (quantity 1 each)
+func MergeAddonSelections(selectedAddons []uuid.UUID, addons []BookingAddonRequest) []BookingAddonRequest {
+	selections := make([]BookingAddonRequest, 0, len(selectedAddons)+len(addons))
+	selections = append(selections, addons...)
+	for _, id := range selectedAddons {
+		selections = append(selections, BookingAddonRequest{AddonID: id, Quantity: 1})
+	}
+	return selections
+}
+
+func validateAddonSelections(selectedAddons []uuid.UUID, addons []BookingAddonRequest) error {
+	seen := make(map[uuid.UUID]bool)
+	for _, addon := range MergeAddonSelections(selectedAddons, addons) {
+		if addon.AddonID == uuid.Nil {
+			return fmt.Errorf("addon ID is required")
+		}
+		if addon.Quantity < 1 {
+			return fmt.Errorf("addon quantity must be at least 1")
+		}
+		if seen[addon.AddonID] {
+			return fmt.Errorf("addon %s is selected more than once", addon.AddonID)
+		}
+		seen[addon.AddonID] = true
+	}
 	return nil
 }
 
@@ -182,6 +268,42 @@ func (r *CancelBookingRequest) Validate() error {
 	return nil
 }
 
+// SkipOccurrenceRequest cancels one occurrence of a recurring series and keeps the rest
+type SkipOccurrenceRequest struct {
+	Reason          string    `json:"reason" validate:"required"`
+	SkippedBy       uuid.UUID `json:"-"`
+	RefundRequested bool      `json:"refund_requested"`
+	NotifyCustomer  bool      `json:"notify_customer"`
+	NotifyArtisan   bool      `json:"notify_artisan"`
+}
+
+// Validate validates the skip occurrence request
+func (r *SkipOccurrenceRequest) Validate() error {
+	if r.SkippedBy == uuid.Nil {
+		return fmt.Errorf("skipped by user ID is required")
+	}
+	if r.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if len(r.Reason) > 500 {
+		return fmt.Errorf("reason must be 500 characters or less")
+	}
+	return nil
+}
+
+// MoveOccurrenceRequest reschedules one occurrence of a recurring series and detaches it
+// from later series-wide updates
+type MoveOccurrenceRequest struct {
+	RescheduleBookingRequest
+	MovedBy uuid.UUID `json:"-"`
+}
+
+// LinkBookingProjectRequest represents linking a booking to a project; a nil project
+// unlinks it
+type LinkBookingProjectRequest struct {
+	ProjectID *uuid.UUID `json:"project_id"`
+}
+
 // CompleteBookingRequest represents the request to complete a booking
 type CompleteBookingRequest struct {
 	CompletionNotes string   `json:"completion_notes,omitempty"`
@@ -216,6 +338,7 @@ type BookingFilter struct {
 	ArtisanIDs       []uuid.UUID            `json:"artisan_ids,omitempty"`
 	CustomerIDs      []uuid.UUID            `json:"customer_ids,omitempty"`
 	ServiceIDs       []uuid.UUID            `json:"service_ids,omitempty"`
+	ProjectIDs       []uuid.UUID            `json:"project_ids,omitempty"`
 	Statuses         []models.BookingStatus `json:"statuses,omitempty"`
 	PaymentStatuses  []models.PaymentStatus `json:"payment_statuses,omitempty"`
 	StartDate        *time.Time             `json:"start_date,omitempty"`
@@ -232,12 +355,28 @@ type BookingFilter struct {
 	CreatedBefore    *time.Time             `json:"created_before,omitempty"`
 	UpdatedAfter     *time.Time             `json:"updated_after,omitempty"`
 	UpdatedBefore    *time.Time             `json:"updated_before,omitempty"`
+	Tags             []string               `json:"tags,omitempty"`     // Bookings carrying every tag
+	AnyTags          []string               `json:"any_tags,omitempty"` // Bookings carrying at least one tag
 	Page             int                    `json:"page" validate:"min=1"`
 	PageSize         int                    `json:"page_size" validate:"min=1,max=100"`
+	Cursor           string                 `json:"cursor,omitempty"` // Keyset cursor; takes precedence over Page
 	SortBy           string                 `json:"sort_by,omitempty"`
 	SortOrder        string                 `json:"sort_order,omitempty"` // asc or desc
 	SearchQuery      string                 `json:"search_query,omitempty"`
-	IncludeRelations []string               `json:"include_relations,omitempty"` // artisan, customer, service, payments, review
+	IncludeRelations []string               `json:"include_relations,omitempty"` // artisan, customer, service, payments, review, tenant, conversation
+
+	// Customers whose bookings match: in any of the segments and carrying every tag
+	CustomerSegments []models.CustomerSegment `json:"customer_segments,omitempty"`
+	CustomerTags     []string                 `json:"customer_tags,omitempty"`
+
+	// Saved filter to apply; fields set on this filter take precedence over the saved ones
+	SavedFilterID *uuid.UUID `json:"-"`
+	UserID        uuid.UUID  `json:"-"` // Owner the saved filter is resolved for
+}
+
+// CustomerAudience returns the customers the filter restricts bookings to
+func (f *BookingFilter) CustomerAudience() models.CustomerAudience {
+	return models.CustomerAudience{Segments: f.CustomerSegments, Tags: f.CustomerTags}
 }
 
 // Validate validates the booking filter
@@ -276,6 +415,16 @@ func (f *BookingFilter) Validate() error {
 	if f.CreatedAfter != nil && f.CreatedBefore != nil && f.CreatedAfter.After(*f.CreatedBefore) {
 		return fmt.Errorf("created after cannot be after created before")
 	}
+	if _, err := models.NormalizeBookingTags(f.Tags); err != nil {
+		return err
+	}
+	if _, err := models.NormalizeBookingTags(f.AnyTags); err != nil {
+		return err
+	}
+	audience := f.CustomerAudience()
+	if err := audience.Validate(); err != nil {
+		return err
+	}
 
 	// Validate sort by fields
 	if f.SortBy != "" {
@@ -286,7 +435,7 @@ func (f *BookingFilter) Validate() error {
 	}
 
 	// Validate include relations
-	validRelations := []string{"artisan", "customer", "service", "payments", "review", "tenant"}
+	validRelations := []string{"artisan", "customer", "service", "payments", "review", "tenant", "conversation"}
 	for _, relation := range f.IncludeRelations {
 		if !slices.Contains(validRelations, relation) {
 			return fmt.Errorf("invalid relation: %s", relation)
@@ -382,41 +531,64 @@ func (r *AvailabilityRequest) Validate() error {
 
 // BookingResponse represents a booking response
 type BookingResponse struct {
-	ID                 uuid.UUID            `json:"id"`
-	TenantID           uuid.UUID            `json:"tenant_id"`
-	ArtisanID          uuid.UUID            `json:"artisan_id"`
-	CustomerID         uuid.UUID            `json:"customer_id"`
-	ServiceID          uuid.UUID            `json:"service_id"`
-	StartTime          time.Time            `json:"start_time"`
-	EndTime            time.Time            `json:"end_time"`
-	Duration           int                  `json:"duration"`
-	Status             models.BookingStatus `json:"status"`
-	PaymentStatus      models.PaymentStatus `json:"payment_status"`
-	BasePrice          float64              `json:"base_price"`
-	AddonsPrice        float64              `json:"addons_price"`
-	TotalPrice         float64              `json:"total_price"`
-	DepositPaid        float64              `json:"deposit_paid"`
-	Currency           string               `json:"currency"`
-	Notes              string               `json:"notes,omitempty"`
-	CustomerNotes      string               `json:"customer_notes,omitempty"`
-	InternalNotes      string               `json:"internal_notes,omitempty"`
-	SelectedAddons     []uuid.UUID          `json:"selected_addons,omitempty"`
-	ServiceLocation    *models.Location     `json:"service_location,omitempty"`
-	CancelledAt        *time.Time           `json:"cancelled_at,omitempty"`
-	CancelledBy        *uuid.UUID           `json:"cancelled_by,omitempty"`
-	CancellationReason string               `json:"cancellation_reason,omitempty"`
-	CompletedAt        *time.Time           `json:"completed_at,omitempty"`
-	BeforePhotoURLs    []string             `json:"before_photo_urls,omitempty"`
-	AfterPhotoURLs     []string             `json:"after_photo_urls,omitempty"`
-	PaymentIntentID    string               `json:"payment_intent_id,omitempty"`
-	RefundID           string               `json:"refund_id,omitempty"`
-	IsRecurring        bool                 `json:"is_recurring"`
-	RecurrencePattern  string               `json:"recurrence_pattern,omitempty"`
-	ParentBookingID    *uuid.UUID           `json:"parent_booking_id,omitempty"`
-	RecurrenceEndDate  *time.Time           `json:"recurrence_end_date,omitempty"`
-	ReminderSent24h    bool                 `json:"reminder_sent_24h"`
-	ReminderSent1h     bool                 `json:"reminder_sent_1h"`
-	Metadata           models.JSONB         `json:"metadata,omitempty"`
+	ID                   uuid.UUID                   `json:"id"`
+	Version              int                         `json:"version"`
+	TenantID             uuid.UUID                   `json:"tenant_id"`
+	ArtisanID            uuid.UUID                   `json:"artisan_id"`
+	CustomerID           uuid.UUID                   `json:"customer_id"`
+	ServiceID            uuid.UUID                   `json:"service_id"`
+	ProjectID            *uuid.UUID                  `json:"project_id,omitempty"`
+	StartTime            time.Time                   `json:"start_time"`
+	EndTime              time.Time                   `json:"end_time"`
+	Duration             int                         `json:"duration"`
+	Status               models.BookingStatus        `json:"status"`
+	PaymentStatus        models.PaymentStatus        `json:"payment_status"`
+	BasePrice            float64                     `json:"base_price"`
+	PriceVersionID       *uuid.UUID                  `json:"price_version_id,omitempty"` // Service price version ListPrice was taken from
+	ListPrice            float64                     `json:"list_price"`
+	PricingAdjustments   []models.PricingAdjustment  `json:"pricing_adjustments,omitempty"` // Pricing rules that took ListPrice to BasePrice
+	AddonsPrice          float64                     `json:"addons_price"`
+	PromoCode            string                      `json:"promo_code,omitempty"`
+	DiscountAmount       float64                     `json:"discount_amount"`
+	TaxAmount            float64                     `json:"tax_amount"`
+	TaxLines             []models.TaxLine            `json:"tax_lines,omitempty"`
+	TotalPrice           float64                     `json:"total_price"`
+	DepositPaid          float64                     `json:"deposit_paid"`
+	Currency             string                      `json:"currency"`
+	Notes                string                      `json:"notes,omitempty"`
+	CustomerNotes        string                      `json:"customer_notes,omitempty"`
+	InternalNotes        string                      `json:"internal_notes,omitempty"`
+	SelectedAddons       []uuid.UUID                 `json:"selected_addons,omitempty"`
+	Addons               models.BookingAddons        `json:"addons,omitempty"`
+	IntakeAnswers        models.JSONB                `json:"intake_answers,omitempty"`
+	Tags                 []string                    `json:"tags,omitempty"`
+	ServiceLocation      *models.Location            `json:"service_location,omitempty"`
+	CancelledAt          *time.Time                  `json:"cancelled_at,omitempty"`
+	CancelledBy          *uuid.UUID                  `json:"cancelled_by,omitempty"`
+	CancellationReason   string                      `json:"cancellation_reason,omitempty"`
+	CompletedAt          *time.Time                  `json:"completed_at,omitempty"`
+	BeforePhotoURLs      []string                    `json:"before_photo_urls,omitempty"`
+	AfterPhotoURLs       []string                    `json:"after_photo_urls,omitempty"`
+	PaymentIntentID      string                      `json:"payment_intent_id,omitempty"`
+	RefundID             string                      `json:"refund_id,omitempty"`
+	RefundStatus         models.RefundStatus         `json:"refund_status,omitempty"`
+	RefundOwed           float64                     `json:"refund_owed,omitempty"` // Owed by the cancellation, unlike the estimated RefundAmount
+	RefundIssued         float64                     `json:"refund_issued,omitempty"`
+	RefundFailureReason  string                      `json:"refund_failure_reason,omitempty"`
+	BalanceDue           float64                     `json:"balance_due"`
+	BalanceDueAt         *time.Time                  `json:"balance_due_at,omitempty"`
+	BalanceStatus        models.BalanceStatus        `json:"balance_status,omitempty"`
+	BalanceFailureReason string                      `json:"balance_failure_reason,omitempty"`
+	IsRecurring          bool                        `json:"is_recurring"`
+	RecurrencePattern    string                      `json:"recurrence_pattern,omitempty"`
+	ParentBookingID      *uuid.UUID                  `json:"parent_booking_id,omitempty"`
+	RecurrenceEndDate    *time.Time                  `json:"recurrence_end_date,omitempty"`
+	RecurrenceExceptions models.RecurrenceExceptions `json:"recurrence_exceptions,omitempty"`
+	OriginalStartTime    *time.Time                  `json:"original_start_time,omitempty"`
+	DetachedFromSeries   bool                        `json:"detached_from_series,omitempty"`
+	ReminderSent24h      bool                        `json:"reminder_sent_24h"`
+	ReminderSent1h       bool                        `json:"reminder_sent_1h"`
+	Metadata             models.JSONB                `json:"metadata,omitempty"`
 
 	// Related entities (populated based on include_relations)
 	Artisan  *ArtisanInfoResponse   `json:"artisan,omitempty"`
@@ -425,6 +597,9 @@ type BookingResponse struct {
 	Payments []*PaymentInfoResponse `json:"payments,omitempty"`
 	Review   *ReviewInfoResponse    `json:"review,omitempty"`
 
+	// Chat thread between customer and artisan
+	Conversation *ConversationInfoResponse `json:"conversation,omitempty"`
+
 	// Calculated fields
 	CanBeCancelled   bool    `json:"can_be_cancelled"`
 	CanBeRescheduled bool    `json:"can_be_rescheduled"`
@@ -495,6 +670,16 @@ type ReviewInfoResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// ConversationInfoResponse represents a booking's chat thread with per-participant unread counters
+type ConversationInfoResponse struct {
+	ID                  uuid.UUID  `json:"id"`
+	MessageCount        int        `json:"message_count"`
+	CustomerUnreadCount int        `json:"customer_unread_count"`
+	ArtisanUnreadCount  int        `json:"artisan_unread_count"`
+	LastMessageAt       *time.Time `json:"last_message_at,omitempty"`
+	LastMessagePreview  string     `json:"last_message_preview,omitempty"`
+}
+
 // BookingPaymentResponse represents a payment response for booking operations
 type BookingPaymentResponse struct {
 	ID          uuid.UUID            `json:"id"`
@@ -515,6 +700,8 @@ type BookingListResponse struct {
 	TotalPages  int                `json:"total_pages"`
 	HasNext     bool               `json:"has_next"`
 	HasPrevious bool               `json:"has_previous"`
+	HasMore     bool               `json:"has_more"`
+	NextCursor  string             `json:"next_cursor,omitempty"`
 }
 
 // TimeSlotResponse represents an available time slot
@@ -585,9 +772,10 @@ type BookingStatsResponse struct {
 
 // BookingTrendData represents booking trend data
 type BookingTrendData struct {
-	Date     time.Time `json:"date"`
-	Bookings int64     `json:"bookings"`
-	Revenue  float64   `json:"revenue"`
+	Date     time.Time  `json:"date"`
+	Bookings int64      `json:"bookings"`
+	Revenue  float64    `json:"revenue"`
+	AsOf     *time.Time `json:"as_of,omitempty"` // When the figures were last refreshed; unset when they are live
 }
 
 // RevenueTrendData represents revenue trend data
@@ -625,54 +813,124 @@ type TopCustomerData struct {
 	LoyaltyTier  string    `json:"loyalty_tier"`
 }
 
+// BookingEventResponse represents an entry in a booking's change log
+type BookingEventResponse struct {
+	ID         uuid.UUID                  `json:"id"`
+	BookingID  uuid.UUID                  `json:"booking_id"`
+	EventType  models.BookingEventType    `json:"event_type"`
+	Source     models.BookingEventSource  `json:"source"`
+	ActorID    *uuid.UUID                 `json:"actor_id,omitempty"`
+	ActorEmail string                     `json:"actor_email,omitempty"`
+	ActorRole  models.UserRole            `json:"actor_role,omitempty"`
+	Changes    models.BookingFieldChanges `json:"changes"`
+	Note       string                     `json:"note,omitempty"`
+	CreatedAt  time.Time                  `json:"created_at"`
+}
+
+// NoShowRunResponse summarises one run of the no-show automation
+type NoShowRunResponse struct {
+	TenantsChecked int     `json:"tenants_checked"`
+	BookingsMarked int     `json:"bookings_marked"`
+	FeesCharged    int     `json:"fees_charged"`
+	FeeTotal       float64 `json:"fee_total"`
+	Failures       int     `json:"failures"`
+}
+
+// BulkBookingResult reports a bulk booking operation row by row; valid rows are applied
+// even when others fail
+type BulkBookingResult struct {
+	Succeeded []uuid.UUID          `json:"succeeded"`
+	Failed    []BulkBookingFailure `json:"failed"`
+}
+
+// BulkBookingFailure explains why one booking in a bulk operation was not changed
+type BulkBookingFailure struct {
+	BookingID uuid.UUID `json:"booking_id"`
+	Reason    string    `json:"reason"`
+}
+
+// BalanceCollectionRunResponse summarises one run of the balance collection worker
+type BalanceCollectionRunResponse struct {
+	BookingsDue     int     `json:"bookings_due"`
+	BalancesCharged int     `json:"balances_charged"`
+	AmountCharged   float64 `json:"amount_charged"`
+	Retrying        int     `json:"retrying"`
+	Flagged         int     `json:"flagged"`
+	Cancelled       int     `json:"cancelled"`
+}
+
 // ============================================================================
 // Utility Functions
 // ============================================================================
 
-// ToBookingResponse converts a models.Booking to BookingResponse
-func ToBookingResponse(booking *models.Booking) *BookingResponse {
+// ToBookingResponse converts a models.Booking to BookingResponse, with the fields the
+// viewer may see
+func ToBookingResponse(booking *models.Booking, viewer Viewer) *BookingResponse {
 	if booking == nil {
 		return nil
 	}
 
 	response := &BookingResponse{
-		ID:                 booking.ID,
-		TenantID:           booking.TenantID,
-		ArtisanID:          booking.ArtisanID,
-		CustomerID:         booking.CustomerID,
-		ServiceID:          booking.ServiceID,
-		StartTime:          booking.StartTime,
-		EndTime:            booking.EndTime,
-		Duration:           booking.Duration,
-		Status:             booking.Status,
-		PaymentStatus:      booking.PaymentStatus,
-		BasePrice:          booking.BasePrice,
-		AddonsPrice:        booking.AddonsPrice,
-		TotalPrice:         booking.TotalPrice,
-		DepositPaid:        booking.DepositPaid,
-		Currency:           booking.Currency,
-		Notes:              booking.Notes,
-		CustomerNotes:      booking.CustomerNotes,
-		InternalNotes:      booking.InternalNotes,
-		SelectedAddons:     booking.SelectedAddons,
-		ServiceLocation:    booking.ServiceLocation,
-		CancelledAt:        booking.CancelledAt,
-		CancelledBy:        booking.CancelledBy,
-		CancellationReason: booking.CancellationReason,
-		CompletedAt:        booking.CompletedAt,
-		BeforePhotoURLs:    booking.BeforePhotoURLs,
-		AfterPhotoURLs:     booking.AfterPhotoURLs,
-		PaymentIntentID:    booking.PaymentIntentID,
-		RefundID:           booking.RefundID,
-		IsRecurring:        booking.IsRecurring,
-		RecurrencePattern:  booking.RecurrencePattern,
-		ParentBookingID:    booking.ParentBookingID,
-		RecurrenceEndDate:  booking.RecurrenceEndDate,
-		ReminderSent24h:    booking.ReminderSent24h,
-		ReminderSent1h:     booking.ReminderSent1h,
-		Metadata:           booking.Metadata,
-		CreatedAt:          booking.CreatedAt,
-		UpdatedAt:          booking.UpdatedAt,
+		ID:                   booking.ID,
+		Version:              booking.Version,
+		TenantID:             booking.TenantID,
+		ArtisanID:            booking.ArtisanID,
+		CustomerID:           booking.CustomerID,
+		ServiceID:            booking.ServiceID,
+		ProjectID:            booking.ProjectID,
+		StartTime:            booking.StartTime,
+		EndTime:              booking.EndTime,
+		Duration:             booking.Duration,
+		Status:               booking.Status,
+		PaymentStatus:        booking.PaymentStatus,
+		BasePrice:            booking.BasePrice,
+		PriceVersionID:       booking.PriceVersionID,
+		ListPrice:            booking.ListPrice,
+		PricingAdjustments:   booking.PricingAdjustments,
+		AddonsPrice:          booking.AddonsPrice,
+		PromoCode:            booking.PromoCode,
+		DiscountAmount:       booking.DiscountAmount,
+		TaxAmount:            booking.TaxAmount,
+		TaxLines:             booking.TaxLines,
+		TotalPrice:           booking.TotalPrice,
+		DepositPaid:          booking.DepositPaid,
+		Currency:             booking.Currency,
+		Notes:                booking.Notes,
+		CustomerNotes:        booking.CustomerNotes,
+		InternalNotes:        booking.InternalNotes,
+		SelectedAddons:       booking.SelectedAddons,
+		Addons:               booking.Addons,
+		IntakeAnswers:        booking.IntakeAnswers,
+		Tags:                 booking.Tags,
+		ServiceLocation:      booking.ServiceLocation,
+		CancelledAt:          booking.CancelledAt,
+		CancelledBy:          booking.CancelledBy,
+		CancellationReason:   booking.CancellationReason,
+		CompletedAt:          booking.CompletedAt,
+		BeforePhotoURLs:      booking.BeforePhotoURLs,
+		AfterPhotoURLs:       booking.AfterPhotoURLs,
+		PaymentIntentID:      booking.PaymentIntentID,
+		RefundID:             booking.RefundID,
+		RefundStatus:         booking.RefundStatus,
+		RefundOwed:           booking.RefundAmount,
+		RefundIssued:         booking.RefundIssued,
+		RefundFailureReason:  booking.RefundFailureReason,
+		BalanceDue:           booking.BalanceDue(),
+		BalanceDueAt:         booking.BalanceDueAt,
+		BalanceStatus:        booking.BalanceStatus,
+		BalanceFailureReason: booking.BalanceFailureReason,
+		IsRecurring:          booking.IsRecurring,
+		RecurrencePattern:    booking.RecurrencePattern,
+		ParentBookingID:      booking.ParentBookingID,
+		RecurrenceEndDate:    booking.RecurrenceEndDate,
+		RecurrenceExceptions: booking.RecurrenceExceptions,
+		OriginalStartTime:    booking.OriginalStartTime,
+		DetachedFromSeries:   booking.DetachedFromSeries,
+		ReminderSent24h:      booking.ReminderSent24h,
+		ReminderSent1h:       booking.ReminderSent1h,
+		Metadata:             booking.Metadata,
+		CreatedAt:            booking.CreatedAt,
+		UpdatedAt:            booking.UpdatedAt,
 	}
 
 	// Calculate derived fields
@@ -749,14 +1007,35 @@ func ToBookingResponse(booking *models.Booking) *BookingResponse {
 		}
 	}
 
-	return response
+	if booking.Conversation != nil {
+		response.Conversation = ToConversationInfoResponse(booking.Conversation)
+	}
+
+	return project(bookingFieldPolicy, viewer, response)
 }
 
-// ToBookingResponses converts multiple models.Booking to BookingResponse slice
-func ToBookingResponses(bookings []*models.Booking) []*BookingResponse {
+// ToConversationInfoResponse converts a BookingConversation model to ConversationInfoResponse
+func ToConversationInfoResponse(conversation *models.BookingConversation) *ConversationInfoResponse {
+	if conversation == nil {
+		return nil
+	}
+
+	return &ConversationInfoResponse{
+		ID:                  conversation.ID,
+		MessageCount:        conversation.MessageCount,
+		CustomerUnreadCount: conversation.CustomerUnreadCount,
+		ArtisanUnreadCount:  conversation.ArtisanUnreadCount,
+		LastMessageAt:       conversation.LastMessageAt,
+		LastMessagePreview:  conversation.LastMessagePreview,
+	}
+}
+
+// ToBookingResponses converts multiple models.Booking to BookingResponse slice, with the
+// fields the viewer may see
+func ToBookingResponses(bookings []*models.Booking, viewer Viewer) []*BookingResponse {
 	responses := make([]*BookingResponse, 0, len(bookings))
 	for _, booking := range bookings {
-		responses = append(responses, ToBookingResponse(booking))
+		responses = append(responses, ToBookingResponse(booking, viewer))
 	}
 	return responses
 }
@@ -776,6 +1055,8 @@ func getStatusColor(status models.BookingStatus) string {
 		return "red"
 	case models.BookingStatusNoShow:
 		return "purple"
+	case models.BookingStatusRefunded:
+		return "teal"
 	default:
 		return "gray"
 	}
@@ -795,7 +1076,29 @@ func getStatusLabel(status models.BookingStatus) string {
 		return "Cancelled"
 	case models.BookingStatusNoShow:
 		return "No Show"
+	case models.BookingStatusRefunded:
+		return "Refunded"
 	default:
 		return string(status)
 	}
 }
+
+// ToBookingEventResponses converts booking events to response DTOs
+func ToBookingEventResponses(events []*models.BookingEvent) []*BookingEventResponse {
+	responses := make([]*BookingEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = &BookingEventResponse{
+			ID:         event.ID,
+			BookingID:  event.BookingID,
+			EventType:  event.EventType,
+			Source:     event.Source,
+			ActorID:    event.ActorID,
+			ActorEmail: event.ActorEmail,
+			ActorRole:  event.ActorRole,
+			Changes:    event.Changes,
+			Note:       event.Note,
+			CreatedAt:  event.CreatedAt,
+		}
+	}
+	return responses
+}
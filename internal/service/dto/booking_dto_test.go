@@ -0,0 +1,37 @@
+package dto_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateBookingRequest_IsCancellationOnly(t *testing.T) {
+	cancelled := models.BookingStatusCancelled
+	confirmed := models.BookingStatusConfirmed
+	reason := "Plans changed"
+	notes := "Bring a ladder"
+	version := 3
+
+	tests := []struct {
+		name string
+		req  dto.UpdateBookingRequest
+		want bool
+	}{
+		{name: "cancellation", req: dto.UpdateBookingRequest{Status: &cancelled}, want: true},
+		{name: "cancellation with a reason and version", req: dto.UpdateBookingRequest{Status: &cancelled, CancellationReason: &reason, Version: &version}, want: true},
+		{name: "cancellation editing internal notes", req: dto.UpdateBookingRequest{Status: &cancelled, InternalNotes: &notes}},
+		{name: "cancellation editing metadata", req: dto.UpdateBookingRequest{Status: &cancelled, Metadata: map[string]any{"source": "csv"}}},
+		{name: "another status", req: dto.UpdateBookingRequest{Status: &confirmed}},
+		{name: "no status", req: dto.UpdateBookingRequest{CancellationReason: &reason}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.req.IsCancellationOnly())
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Booking Export Request DTOs
+// ============================================================================
+
+// BookingExportRequest describes a bookings export. Pagination and sorting in
+// Filter are ignored; every matching booking is exported.
+type BookingExportRequest struct {
+	TenantID    uuid.UUID                  `json:"-"`
+	RequestedBy uuid.UUID                  `json:"-"`
+	BaseURL     string                     `json:"-"`      // Used to build absolute download links
+	Format      models.BookingExportFormat `json:"format"` // csv (default) or xlsx
+	Async       bool                       `json:"async"`  // Force background generation
+	Filter      BookingFilter              `json:"filter"`
+}
+
+// Validate validates the booking export request
+func (r *BookingExportRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if r.RequestedBy == uuid.Nil {
+		return fmt.Errorf("requester ID is required")
+	}
+	if r.Format == "" {
+		r.Format = models.BookingExportFormatCSV
+	}
+	if !r.Format.IsValid() {
+		return fmt.Errorf("format must be 'csv' or 'xlsx'")
+	}
+	return r.Filter.Validate()
+}
+
+// ============================================================================
+// Booking Export Response DTOs
+// ============================================================================
+
+// BookingExportResponse represents the state of an export and, once completed, its download link
+type BookingExportResponse struct {
+	ID           uuid.UUID                  `json:"id"`
+	TenantID     uuid.UUID                  `json:"tenant_id"`
+	Format       models.BookingExportFormat `json:"format"`
+	Status       models.BookingExportStatus `json:"status"`
+	Async        bool                       `json:"async"`
+	RowCount     int                        `json:"row_count"`
+	FileSize     int64                      `json:"file_size"`
+	DownloadURL  string                     `json:"download_url,omitempty"`
+	URLExpiresAt *time.Time                 `json:"url_expires_at,omitempty"`
+	ExpiresAt    *time.Time                 `json:"expires_at,omitempty"`
+	ErrorMessage string                     `json:"error_message,omitempty"`
+	StartedAt    *time.Time                 `json:"started_at,omitempty"`
+	CompletedAt  *time.Time                 `json:"completed_at,omitempty"`
+	CreatedAt    time.Time                  `json:"created_at"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToBookingExportResponse converts a BookingExport model to a response DTO without a download link
+func ToBookingExportResponse(export *models.BookingExport) *BookingExportResponse {
+	if export == nil {
+		return nil
+	}
+
+	return &BookingExportResponse{
+		ID:           export.ID,
+		TenantID:     export.TenantID,
+		Format:       export.Format,
+		Status:       export.Status,
+		Async:        export.Async,
+		RowCount:     export.RowCount,
+		FileSize:     export.FileSize,
+		ExpiresAt:    export.ExpiresAt,
+		ErrorMessage: export.ErrorMessage,
+		StartedAt:    export.StartedAt,
+		CompletedAt:  export.CompletedAt,
+		CreatedAt:    export.CreatedAt,
+	}
+}
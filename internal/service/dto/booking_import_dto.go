@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Booking Import Request DTOs
+// ============================================================================
+
+// BookingImportRequest describes an uploaded booking import file
+type BookingImportRequest struct {
+	TenantID   uuid.UUID `json:"-"`
+	UploadedBy uuid.UUID `json:"-"`
+	FileName   string    `json:"file_name"`
+	FileSize   int64     `json:"file_size"`
+	DryRun     bool      `json:"dry_run"`  // Validate only, nothing is written
+	Async      bool      `json:"async"`    // Force background processing
+	Timezone   string    `json:"timezone"` // Applied to times without an offset, default UTC
+}
+
+// Validate validates the booking import request
+func (r *BookingImportRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if r.UploadedBy == uuid.Nil {
+		return fmt.Errorf("uploader ID is required")
+	}
+	if r.FileName == "" {
+		return fmt.Errorf("file name is required")
+	}
+	if r.Timezone != "" {
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone: %s", r.Timezone)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Booking Import Response DTOs
+// ============================================================================
+
+// BookingImportResponse represents the state and validation report of an import
+type BookingImportResponse struct {
+	ID           uuid.UUID                      `json:"id"`
+	TenantID     uuid.UUID                      `json:"tenant_id"`
+	FileName     string                         `json:"file_name"`
+	FileSize     int64                          `json:"file_size"`
+	Status       models.BookingImportStatus     `json:"status"`
+	DryRun       bool                           `json:"dry_run"`
+	Timezone     string                         `json:"timezone"`
+	TotalRows    int                            `json:"total_rows"`
+	ValidRows    int                            `json:"valid_rows"`
+	ImportedRows int                            `json:"imported_rows"`
+	FailedRows   int                            `json:"failed_rows"`
+	RowErrors    []models.BookingImportRowError `json:"row_errors,omitempty"`
+	ErrorMessage string                         `json:"error_message,omitempty"`
+	StartedAt    *time.Time                     `json:"started_at,omitempty"`
+	CompletedAt  *time.Time                     `json:"completed_at,omitempty"`
+	CreatedAt    time.Time                      `json:"created_at"`
+}
+
+// BookingImportListResponse represents a paginated list of imports
+type BookingImportListResponse struct {
+	Imports     []*BookingImportResponse `json:"imports"`
+	Page        int                      `json:"page"`
+	PageSize    int                      `json:"page_size"`
+	TotalItems  int64                    `json:"total_items"`
+	TotalPages  int                      `json:"total_pages"`
+	HasNext     bool                     `json:"has_next"`
+	HasPrevious bool                     `json:"has_previous"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToBookingImportResponse converts a BookingImport model to a response DTO
+func ToBookingImportResponse(imp *models.BookingImport) *BookingImportResponse {
+	if imp == nil {
+		return nil
+	}
+
+	return &BookingImportResponse{
+		ID:           imp.ID,
+		TenantID:     imp.TenantID,
+		FileName:     imp.FileName,
+		FileSize:     imp.FileSize,
+		Status:       imp.Status,
+		DryRun:       imp.DryRun,
+		Timezone:     imp.Timezone,
+		TotalRows:    imp.TotalRows,
+		ValidRows:    imp.ValidRows,
+		ImportedRows: imp.ImportedRows,
+		FailedRows:   imp.FailedRows,
+		RowErrors:    imp.RowErrors,
+		ErrorMessage: imp.ErrorMessage,
+		StartedAt:    imp.StartedAt,
+		CompletedAt:  imp.CompletedAt,
+		CreatedAt:    imp.CreatedAt,
+	}
+}
+
+// ToBookingImportResponses converts multiple BookingImport models to response DTOs
+func ToBookingImportResponses(imports []*models.BookingImport) []*BookingImportResponse {
+	responses := make([]*BookingImportResponse, len(imports))
+	for i, imp := range imports {
+		responses[i] = ToBookingImportResponse(imp)
+	}
+	return responses
+}
@@ -0,0 +1,219 @@
+package dto
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Branding DTOs
+// ============================================================================
+
+// UpdateBrandingSettingsRequest updates how a tenant's booking pages and notification
+// emails look; fields left out are unchanged and empty strings clear them
+type UpdateBrandingSettingsRequest struct {
+	CompanyName    *string `json:"company_name,omitempty" validate:"omitempty,max=255"`
+	PrimaryColor   *string `json:"primary_color,omitempty" validate:"omitempty,hexcolor"`
+	SecondaryColor *string `json:"secondary_color,omitempty" validate:"omitempty,hexcolor"`
+	AccentColor    *string `json:"accent_color,omitempty" validate:"omitempty,hexcolor"`
+	FontFamily     *string `json:"font_family,omitempty" validate:"omitempty,max=255"`
+	SupportEmail   *string `json:"support_email,omitempty" validate:"omitempty,email"`
+	SupportPhone   *string `json:"support_phone,omitempty" validate:"omitempty,max=20"`
+	SupportURL     *string `json:"support_url,omitempty" validate:"omitempty,url"`
+	HidePoweredBy  *bool   `json:"hide_powered_by,omitempty"` // Takes the white labeling feature
+
+	// Notification emails
+	EmailFromName    *string `json:"email_from_name,omitempty" validate:"omitempty,max=100"`
+	EmailFromAddress *string `json:"email_from_address,omitempty" validate:"omitempty,email"` // At the email sender domain
+	EmailReplyTo     *string `json:"email_reply_to,omitempty" validate:"omitempty,email"`
+	EmailHeaderColor *string `json:"email_header_color,omitempty" validate:"omitempty,hexcolor"`
+	EmailButtonColor *string `json:"email_button_color,omitempty" validate:"omitempty,hexcolor"`
+	FooterText       *string `json:"footer_text,omitempty" validate:"omitempty,max=1000"`
+}
+
+// Validate validates the update branding settings request
+func (r *UpdateBrandingSettingsRequest) Validate() error {
+	for name, value := range map[string]*string{
+		"company name": r.CompanyName,
+		"font family":  r.FontFamily,
+	} {
+		if value != nil && len(strings.TrimSpace(*value)) > 255 {
+			return fmt.Errorf("%s too long", name)
+		}
+	}
+	if r.EmailFromName != nil && len(strings.TrimSpace(*r.EmailFromName)) > 100 {
+		return fmt.Errorf("email from name too long")
+	}
+	for name, value := range map[string]*string{
+		"primary color":      r.PrimaryColor,
+		"secondary color":    r.SecondaryColor,
+		"accent color":       r.AccentColor,
+		"email header color": r.EmailHeaderColor,
+		"email button color": r.EmailButtonColor,
+	} {
+		if value != nil && *value != "" && !hexColorPattern.MatchString(*value) {
+			return fmt.Errorf("%s must be a hex color such as #3B82F6", name)
+		}
+	}
+	for name, value := range map[string]*string{
+		"support email":      r.SupportEmail,
+		"email from address": r.EmailFromAddress,
+		"email reply-to":     r.EmailReplyTo,
+	} {
+		if value != nil && *value != "" {
+			if address, err := mail.ParseAddress(*value); err != nil || address.Address != *value {
+				return fmt.Errorf("%s must be an email address", name)
+			}
+		}
+	}
+	if r.SupportURL != nil && *r.SupportURL != "" {
+		if u, err := url.Parse(*r.SupportURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("support URL must be an http or https URL")
+		}
+	}
+	if r.SupportPhone != nil && len(*r.SupportPhone) > 20 {
+		return fmt.Errorf("support phone too long")
+	}
+	if r.FooterText != nil && len(*r.FooterText) > models.MaxEmailFooterLength {
+		return fmt.Errorf("footer text must be at most %d characters", models.MaxEmailFooterLength)
+	}
+	return nil
+}
+
+// UploadBrandingAssetRequest describes an image a tenant uploads to brand its pages
+// and emails; the file itself is passed alongside
+type UploadBrandingAssetRequest struct {
+	TenantID uuid.UUID            `json:"-"` // Set from the auth context
+	Asset    models.BrandingAsset `json:"asset" validate:"required"`
+	FileSize int64                `json:"-"`
+}
+
+// Validate validates the upload branding asset request
+func (r *UploadBrandingAssetRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if !r.Asset.IsValid() {
+		return fmt.Errorf("asset must be logo, logo_dark, favicon or email_logo")
+	}
+	if r.FileSize <= 0 {
+		return fmt.Errorf("an image file is required")
+	}
+	return nil
+}
+
+// SetEmailDomainRequest sets the domain a tenant's notification emails are sent from
+type SetEmailDomainRequest struct {
+	Domain string `json:"domain" validate:"required,fqdn"`
+}
+
+// BrandingResponse is how a tenant's booking pages look
+type BrandingResponse struct {
+	TenantID          uuid.UUID `json:"tenant_id"`
+	Name              string    `json:"name"`
+	LogoURL           string    `json:"logo_url,omitempty"`
+	LogoDarkURL       string    `json:"logo_dark_url,omitempty"`
+	FaviconURL        string    `json:"favicon_url,omitempty"`
+	PrimaryColor      string    `json:"primary_color"`
+	SecondaryColor    string    `json:"secondary_color,omitempty"`
+	AccentColor       string    `json:"accent_color,omitempty"`
+	FontFamily        string    `json:"font_family,omitempty"`
+	SupportEmail      string    `json:"support_email,omitempty"`
+	SupportPhone      string    `json:"support_phone,omitempty"`
+	SupportURL        string    `json:"support_url,omitempty"`
+	TermsOfServiceURL string    `json:"terms_of_service_url,omitempty"`
+	PrivacyPolicyURL  string    `json:"privacy_policy_url,omitempty"`
+	ShowPoweredBy     bool      `json:"show_powered_by"`
+}
+
+// EmailBrandingResponse is how a tenant's notification emails are sent
+type EmailBrandingResponse struct {
+	FromName        string `json:"from_name"`
+	FromAddress     string `json:"from_address"`
+	ReplyTo         string `json:"reply_to,omitempty"`
+	LogoURL         string `json:"logo_url,omitempty"`
+	HeaderColor     string `json:"header_color"`
+	ButtonColor     string `json:"button_color"`
+	ButtonTextColor string `json:"button_text_color"`
+	FooterText      string `json:"footer_text,omitempty"`
+	// ConfiguredFromAddress is the tenant's own address, which FromAddress only becomes
+	// once the sender domain is verified
+	ConfiguredFromAddress string `json:"configured_from_address,omitempty"`
+	Signed                bool   `json:"signed"` // DKIM-signed for the tenant's domain
+}
+
+// BrandingSettingsResponse is a tenant's branding as its owners and admins manage it
+type BrandingSettingsResponse struct {
+	Branding      *BrandingResponse           `json:"branding"`
+	Email         *EmailBrandingResponse      `json:"email"`
+	EmailDomain   *DomainVerificationResponse `json:"email_domain,omitempty"`
+	HidePoweredBy bool                        `json:"hide_powered_by"` // As set, which takes white labeling to apply
+}
+
+// ToBrandingResponse converts resolved branding to a response
+func ToBrandingResponse(branding models.Branding) *BrandingResponse {
+	return &BrandingResponse{
+		TenantID:          branding.TenantID,
+		Name:              branding.Name,
+		LogoURL:           branding.LogoURL,
+		LogoDarkURL:       branding.LogoDarkURL,
+		FaviconURL:        branding.FaviconURL,
+		PrimaryColor:      branding.PrimaryColor,
+		SecondaryColor:    branding.SecondaryColor,
+		AccentColor:       branding.AccentColor,
+		FontFamily:        branding.FontFamily,
+		SupportEmail:      branding.SupportEmail,
+		SupportPhone:      branding.SupportPhone,
+		SupportURL:        branding.SupportURL,
+		TermsOfServiceURL: branding.TermsOfServiceURL,
+		PrivacyPolicyURL:  branding.PrivacyPolicyURL,
+		ShowPoweredBy:     branding.ShowPoweredBy,
+	}
+}
+
+// ToBrandingSettingsResponse converts a tenant's branding and white label, which may be
+// nil, to a settings response
+func ToBrandingSettingsResponse(branding models.Branding, whitelabel *models.WhiteLabel) *BrandingSettingsResponse {
+	response := &BrandingSettingsResponse{
+		Branding: ToBrandingResponse(branding),
+		Email: &EmailBrandingResponse{
+			FromName:        branding.Email.FromName,
+			FromAddress:     branding.Email.FromAddress,
+			ReplyTo:         branding.Email.ReplyTo,
+			LogoURL:         branding.Email.LogoURL,
+			HeaderColor:     branding.Email.HeaderColor,
+			ButtonColor:     branding.Email.ButtonColor,
+			ButtonTextColor: branding.Email.ButtonTextColor,
+			FooterText:      branding.FooterText,
+			Signed:          branding.Email.DKIMDomain != "",
+		},
+	}
+	if whitelabel != nil {
+		response.Email.ConfiguredFromAddress = whitelabel.EmailSettings.FromEmail
+		response.EmailDomain = ToEmailDomainResponse(whitelabel)
+		response.HidePoweredBy = whitelabel.HidePoweredBy
+	}
+	return response
+}
+
+// ToEmailDomainResponse converts a white label's email sender domain to the DKIM record
+// it is to publish, or nil without one
+func ToEmailDomainResponse(whitelabel *models.WhiteLabel) *DomainVerificationResponse {
+	if whitelabel.EmailSenderDomain == "" {
+		return nil
+	}
+	return &DomainVerificationResponse{
+		Domain:      whitelabel.EmailSenderDomain,
+		RecordType:  "TXT",
+		RecordName:  whitelabel.DKIMRecordName(),
+		RecordValue: whitelabel.DKIMRecordValue(),
+		Verified:    whitelabel.EmailDomainVerified(),
+		VerifiedAt:  whitelabel.EmailDomainVerifiedAt,
+	}
+}
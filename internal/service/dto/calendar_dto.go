@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Calendar Response DTOs
+// ============================================================================
+
+// CalendarFeedResponse describes an artisan's calendar subscription feed
+type CalendarFeedResponse struct {
+	ArtisanID uuid.UUID `json:"artisan_id"`
+	Token     string    `json:"token"`
+	FeedURL   string    `json:"feed_url"`
+	WebcalURL string    `json:"webcal_url"`
+}
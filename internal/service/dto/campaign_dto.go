@@ -0,0 +1,225 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Campaign Request DTOs
+// ============================================================================
+
+// CreateCampaignRequest creates a draft campaign
+type CreateCampaignRequest struct {
+	Name     string                       `json:"name" validate:"required,max=255"`
+	Audience models.CampaignAudience      `json:"audience"`
+	Channels []models.NotificationChannel `json:"channels" validate:"required,min=1"`
+	Subject  string                       `json:"subject" validate:"required,max=255"`
+	Body     string                       `json:"body" validate:"required"`
+	SendRate int                          `json:"send_rate,omitempty"` // Recipients per minute; defaults to DefaultCampaignSendRate
+}
+
+// Validate validates the create campaign request
+func (r *CreateCampaignRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(r.Channels) == 0 {
+		return fmt.Errorf("at least one channel is required")
+	}
+	if strings.TrimSpace(r.Subject) == "" || strings.TrimSpace(r.Body) == "" {
+		return fmt.Errorf("subject and body are required")
+	}
+	if r.SendRate < 0 {
+		return fmt.Errorf("send_rate cannot be negative")
+	}
+	return nil
+}
+
+// UpdateCampaignRequest changes a campaign that has not started sending
+type UpdateCampaignRequest struct {
+	Name     *string                      `json:"name,omitempty" validate:"omitempty,max=255"`
+	Audience *models.CampaignAudience     `json:"audience,omitempty"`
+	Channels []models.NotificationChannel `json:"channels,omitempty"`
+	Subject  *string                      `json:"subject,omitempty" validate:"omitempty,max=255"`
+	Body     *string                      `json:"body,omitempty"`
+	SendRate *int                         `json:"send_rate,omitempty"`
+}
+
+// ScheduleCampaignRequest schedules a campaign to be sent
+type ScheduleCampaignRequest struct {
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"` // Omitted to send now
+}
+
+// CampaignAudienceCountRequest counts the customers an audience selects
+type CampaignAudienceCountRequest struct {
+	Audience models.CampaignAudience `json:"audience"`
+}
+
+// ============================================================================
+// Campaign Response DTOs
+// ============================================================================
+
+// CampaignMetrics are a campaign's delivery and open counts
+type CampaignMetrics struct {
+	Recipients int     `json:"recipients"`
+	Pending    int     `json:"pending"`
+	Sent       int     `json:"sent"`
+	Skipped    int     `json:"skipped"`
+	Failed     int     `json:"failed"`
+	Opened     int     `json:"opened"`
+	OpenRate   float64 `json:"open_rate"` // Share of sent messages opened; emails only, a lower bound
+}
+
+// CampaignResponse represents a campaign
+type CampaignResponse struct {
+	ID          uuid.UUID                    `json:"id"`
+	TenantID    uuid.UUID                    `json:"tenant_id"`
+	Name        string                       `json:"name"`
+	Audience    models.CampaignAudience      `json:"audience"`
+	Channels    []models.NotificationChannel `json:"channels"`
+	Subject     string                       `json:"subject"`
+	Body        string                       `json:"body"`
+	Status      models.CampaignStatus        `json:"status"`
+	ScheduledAt *time.Time                   `json:"scheduled_at,omitempty"`
+	SendRate    int                          `json:"send_rate"`
+	StartedAt   *time.Time                   `json:"started_at,omitempty"`
+	CompletedAt *time.Time                   `json:"completed_at,omitempty"`
+	CancelledAt *time.Time                   `json:"cancelled_at,omitempty"`
+	Metrics     CampaignMetrics              `json:"metrics"`
+	CreatedBy   uuid.UUID                    `json:"created_by"`
+	CreatedAt   time.Time                    `json:"created_at"`
+	UpdatedAt   time.Time                    `json:"updated_at"`
+}
+
+// CampaignListResponse represents a page of campaigns
+type CampaignListResponse struct {
+	Campaigns   []*CampaignResponse `json:"campaigns"`
+	Page        int                 `json:"page"`
+	PageSize    int                 `json:"page_size"`
+	TotalItems  int64               `json:"total_items"`
+	TotalPages  int                 `json:"total_pages"`
+	HasNext     bool                `json:"has_next"`
+	HasPrevious bool                `json:"has_previous"`
+}
+
+// CampaignPreviewResponse shows a campaign as the requesting user would receive it, and
+// how many customers it would reach
+type CampaignPreviewResponse struct {
+	Subject          string `json:"subject"`
+	Body             string `json:"body"`
+	AudienceCount    int64  `json:"audience_count"`
+	EstimatedMinutes int    `json:"estimated_minutes"` // How long sending takes at the campaign's send rate
+}
+
+// CampaignAudienceCountResponse is how many customers an audience selects
+type CampaignAudienceCountResponse struct {
+	Count int64 `json:"count"`
+}
+
+// CampaignRecipientResponse represents a customer a campaign is sent to
+type CampaignRecipientResponse struct {
+	ID         uuid.UUID                      `json:"id"`
+	CustomerID uuid.UUID                      `json:"customer_id"`
+	Name       string                         `json:"name,omitempty"`
+	Email      string                         `json:"email,omitempty"`
+	Status     models.CampaignRecipientStatus `json:"status"`
+	SkipReason string                         `json:"skip_reason,omitempty"`
+	SentAt     *time.Time                     `json:"sent_at,omitempty"`
+	OpenedAt   *time.Time                     `json:"opened_at,omitempty"`
+}
+
+// CampaignRecipientListResponse represents a page of a campaign's recipients
+type CampaignRecipientListResponse struct {
+	Recipients  []*CampaignRecipientResponse `json:"recipients"`
+	Page        int                          `json:"page"`
+	PageSize    int                          `json:"page_size"`
+	TotalItems  int64                        `json:"total_items"`
+	TotalPages  int                          `json:"total_pages"`
+	HasNext     bool                         `json:"has_next"`
+	HasPrevious bool                         `json:"has_previous"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToCampaignResponse converts a Campaign model to a response DTO
+func ToCampaignResponse(campaign *models.Campaign) *CampaignResponse {
+	if campaign == nil {
+		return nil
+	}
+
+	pending := campaign.RecipientCount - campaign.SentCount - campaign.SkippedCount - campaign.FailedCount
+	return &CampaignResponse{
+		ID:          campaign.ID,
+		TenantID:    campaign.TenantID,
+		Name:        campaign.Name,
+		Audience:    campaign.Audience,
+		Channels:    campaign.Channels,
+		Subject:     campaign.Subject,
+		Body:        campaign.Body,
+		Status:      campaign.Status,
+		ScheduledAt: campaign.ScheduledAt,
+		SendRate:    campaign.SendRate,
+		StartedAt:   campaign.StartedAt,
+		CompletedAt: campaign.CompletedAt,
+		CancelledAt: campaign.CancelledAt,
+		Metrics: CampaignMetrics{
+			Recipients: campaign.RecipientCount,
+			Pending:    max(pending, 0),
+			Sent:       campaign.SentCount,
+			Skipped:    campaign.SkippedCount,
+			Failed:     campaign.FailedCount,
+			Opened:     campaign.OpenCount,
+			OpenRate:   campaign.OpenRate(),
+		},
+		CreatedBy: campaign.CreatedBy,
+		CreatedAt: campaign.CreatedAt,
+		UpdatedAt: campaign.UpdatedAt,
+	}
+}
+
+// ToCampaignResponses converts multiple Campaign models to response DTOs
+func ToCampaignResponses(campaigns []*models.Campaign) []*CampaignResponse {
+	responses := make([]*CampaignResponse, len(campaigns))
+	for i, campaign := range campaigns {
+		responses[i] = ToCampaignResponse(campaign)
+	}
+	return responses
+}
+
+// ToCampaignRecipientResponse converts a CampaignRecipient model to a response DTO
+func ToCampaignRecipientResponse(recipient *models.CampaignRecipient) *CampaignRecipientResponse {
+	if recipient == nil {
+		return nil
+	}
+
+	response := &CampaignRecipientResponse{
+		ID:         recipient.ID,
+		CustomerID: recipient.CustomerID,
+		Status:     recipient.Status,
+		SkipReason: recipient.SkipReason,
+		SentAt:     recipient.SentAt,
+		OpenedAt:   recipient.OpenedAt,
+	}
+	if recipient.Customer != nil {
+		response.Name = recipient.Customer.FullName()
+		response.Email = recipient.Customer.Email
+	}
+	return response
+}
+
+// ToCampaignRecipientResponses converts multiple CampaignRecipient models to response DTOs
+func ToCampaignRecipientResponses(recipients []*models.CampaignRecipient) []*CampaignRecipientResponse {
+	responses := make([]*CampaignRecipientResponse, len(recipients))
+	for i, recipient := range recipients {
+		responses[i] = ToCampaignRecipientResponse(recipient)
+	}
+	return responses
+}
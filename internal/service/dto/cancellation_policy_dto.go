@@ -0,0 +1,130 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Cancellation Policy Request DTOs
+// ============================================================================
+
+// CreateCancellationPolicyRequest represents a request to create a cancellation policy.
+// Settings left out get their defaults: 24 free cancellation hours, a 100% no-show fee
+// and an active policy.
+type CreateCancellationPolicyRequest struct {
+	TenantID              uuid.UUID           `json:"-"` // Set from the auth context
+	ServiceID             *uuid.UUID          `json:"service_id,omitempty"`
+	Name                  string              `json:"name" validate:"required,min=2,max=255"`
+	Description           string              `json:"description,omitempty"`
+	FreeCancellationHours *float64            `json:"free_cancellation_hours,omitempty" validate:"omitempty,min=0"`
+	RefundTiers           []models.RefundTier `json:"refund_tiers,omitempty"`
+	CancellationFeeFixed  float64             `json:"cancellation_fee_fixed" validate:"min=0"`
+	NoShowFeePercentage   *float64            `json:"no_show_fee_percentage,omitempty" validate:"omitempty,min=0,max=100"`
+	IsActive              *bool               `json:"is_active,omitempty"`
+}
+
+// Validate validates the create cancellation policy request
+func (r *CreateCancellationPolicyRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if len(r.Name) < 2 || len(r.Name) > 255 {
+		return fmt.Errorf("name must be between 2 and 255 characters")
+	}
+	return nil
+}
+
+// UpdateCancellationPolicyRequest represents a request to update a cancellation policy
+type UpdateCancellationPolicyRequest struct {
+	Name                  *string             `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	Description           *string             `json:"description,omitempty"`
+	FreeCancellationHours *float64            `json:"free_cancellation_hours,omitempty" validate:"omitempty,min=0"`
+	RefundTiers           []models.RefundTier `json:"refund_tiers,omitempty"`
+	CancellationFeeFixed  *float64            `json:"cancellation_fee_fixed,omitempty" validate:"omitempty,min=0"`
+	NoShowFeePercentage   *float64            `json:"no_show_fee_percentage,omitempty" validate:"omitempty,min=0,max=100"`
+	IsActive              *bool               `json:"is_active,omitempty"`
+}
+
+// Validate validates the update cancellation policy request
+func (r *UpdateCancellationPolicyRequest) Validate() error {
+	if r.Name != nil && (len(*r.Name) < 2 || len(*r.Name) > 255) {
+		return fmt.Errorf("name must be between 2 and 255 characters")
+	}
+	return nil
+}
+
+// ============================================================================
+// Cancellation Policy Response DTOs
+// ============================================================================
+
+// CancellationPolicyResponse represents a cancellation policy
+type CancellationPolicyResponse struct {
+	ID                    uuid.UUID           `json:"id"`
+	TenantID              uuid.UUID           `json:"tenant_id"`
+	ServiceID             *uuid.UUID          `json:"service_id,omitempty"`
+	Name                  string              `json:"name"`
+	Description           string              `json:"description,omitempty"`
+	FreeCancellationHours float64             `json:"free_cancellation_hours"`
+	RefundTiers           []models.RefundTier `json:"refund_tiers"`
+	CancellationFeeFixed  float64             `json:"cancellation_fee_fixed"`
+	NoShowFeePercentage   float64             `json:"no_show_fee_percentage"`
+	IsActive              bool                `json:"is_active"`
+	CreatedAt             time.Time           `json:"created_at"`
+	UpdatedAt             time.Time           `json:"updated_at"`
+}
+
+// CancellationQuoteResponse shows the customer what cancelling a booking now would cost
+type CancellationQuoteResponse struct {
+	BookingID   uuid.UUID                    `json:"booking_id"`
+	Policy      *CancellationPolicyResponse  `json:"policy"`
+	Breakdown   models.CancellationBreakdown `json:"breakdown"`
+	Currency    string                       `json:"currency"`
+	CanCancel   bool                         `json:"can_cancel"`
+	QuotedAt    time.Time                    `json:"quoted_at"`
+	Explanation string                       `json:"explanation"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToCancellationPolicyResponse converts a CancellationPolicy model to a response DTO
+func ToCancellationPolicyResponse(policy *models.CancellationPolicy) *CancellationPolicyResponse {
+	if policy == nil {
+		return nil
+	}
+
+	tiers := []models.RefundTier(policy.RefundTiers)
+	if tiers == nil {
+		tiers = []models.RefundTier{}
+	}
+
+	return &CancellationPolicyResponse{
+		ID:                    policy.ID,
+		TenantID:              policy.TenantID,
+		ServiceID:             policy.ServiceID,
+		Name:                  policy.Name,
+		Description:           policy.Description,
+		FreeCancellationHours: policy.FreeCancellationHours,
+		RefundTiers:           tiers,
+		CancellationFeeFixed:  policy.CancellationFeeFixed,
+		NoShowFeePercentage:   policy.NoShowFeePercentage,
+		IsActive:              policy.IsActive,
+		CreatedAt:             policy.CreatedAt,
+		UpdatedAt:             policy.UpdatedAt,
+	}
+}
+
+// ToCancellationPolicyResponses converts multiple CancellationPolicy models to response DTOs
+func ToCancellationPolicyResponses(policies []*models.CancellationPolicy) []*CancellationPolicyResponse {
+	responses := make([]*CancellationPolicyResponse, len(policies))
+	for i, policy := range policies {
+		responses[i] = ToCancellationPolicyResponse(policy)
+	}
+	return responses
+}
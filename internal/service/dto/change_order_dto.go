@@ -0,0 +1,187 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Change Order Request DTOs
+// ============================================================================
+
+// CreateChangeOrderRequest represents a request to draft a change order for a project
+type CreateChangeOrderRequest struct {
+	ProjectID         uuid.UUID `json:"project_id" validate:"required"`
+	Title             string    `json:"title" validate:"required,min=3,max=255"`
+	Description       string    `json:"description,omitempty"`
+	Reason            string    `json:"reason,omitempty"`
+	PriceDelta        float64   `json:"price_delta"`                   // Negative for reductions
+	ScheduleDeltaDays int       `json:"schedule_delta_days,omitempty"` // Negative to pull the due date in
+}
+
+// Validate validates the create change order request
+func (r *CreateChangeOrderRequest) Validate() error {
+	if r.ProjectID == uuid.Nil {
+		return fmt.Errorf("project_id is required")
+	}
+	return nil
+}
+
+// UpdateChangeOrderRequest represents a request to edit a draft change order
+type UpdateChangeOrderRequest struct {
+	Title             *string  `json:"title,omitempty" validate:"omitempty,min=3,max=255"`
+	Description       *string  `json:"description,omitempty"`
+	Reason            *string  `json:"reason,omitempty"`
+	PriceDelta        *float64 `json:"price_delta,omitempty"`
+	ScheduleDeltaDays *int     `json:"schedule_delta_days,omitempty"`
+}
+
+// RejectChangeOrderRequest represents a customer's rejection of a change order
+type RejectChangeOrderRequest struct {
+	Reason string `json:"reason,omitempty" validate:"omitempty,max=1000"`
+}
+
+// CancelChangeOrderRequest represents withdrawing a change order
+type CancelChangeOrderRequest struct {
+	Note string `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
+// ChangeOrderFilter represents filters for change order queries
+type ChangeOrderFilter struct {
+	TenantID  uuid.UUID                  `json:"tenant_id"`
+	ProjectID *uuid.UUID                 `json:"project_id,omitempty"`
+	Statuses  []models.ChangeOrderStatus `json:"statuses,omitempty"`
+	Page      int                        `json:"page"`
+	PageSize  int                        `json:"page_size"`
+}
+
+// ============================================================================
+// Change Order Response DTOs
+// ============================================================================
+
+// ChangeOrderResponse represents a change order
+type ChangeOrderResponse struct {
+	ID                uuid.UUID                `json:"id"`
+	TenantID          uuid.UUID                `json:"tenant_id"`
+	ProjectID         uuid.UUID                `json:"project_id"`
+	Number            int                      `json:"number"`
+	Title             string                   `json:"title"`
+	Description       string                   `json:"description,omitempty"`
+	Reason            string                   `json:"reason,omitempty"`
+	Status            models.ChangeOrderStatus `json:"status"`
+	PriceDelta        float64                  `json:"price_delta"`
+	ScheduleDeltaDays int                      `json:"schedule_delta_days"`
+	Currency          string                   `json:"currency"`
+	RequestedByID     *uuid.UUID               `json:"requested_by_id,omitempty"`
+	SubmittedAt       *time.Time               `json:"submitted_at,omitempty"`
+	RespondedByID     *uuid.UUID               `json:"responded_by_id,omitempty"`
+	RespondedAt       *time.Time               `json:"responded_at,omitempty"`
+	RejectionReason   string                   `json:"rejection_reason,omitempty"`
+	BudgetBefore      *float64                 `json:"budget_before,omitempty"` // Set once approved
+	BudgetAfter       *float64                 `json:"budget_after,omitempty"`
+	DueDateBefore     *time.Time               `json:"due_date_before,omitempty"`
+	DueDateAfter      *time.Time               `json:"due_date_after,omitempty"`
+	CreatedAt         time.Time                `json:"created_at"`
+	UpdatedAt         time.Time                `json:"updated_at"`
+}
+
+// ChangeOrderListResponse represents a paginated list of change orders
+type ChangeOrderListResponse struct {
+	ChangeOrders []*ChangeOrderResponse `json:"change_orders"`
+	Page         int                    `json:"page"`
+	PageSize     int                    `json:"page_size"`
+	TotalItems   int64                  `json:"total_items"`
+	TotalPages   int                    `json:"total_pages"`
+	HasNext      bool                   `json:"has_next"`
+	HasPrevious  bool                   `json:"has_previous"`
+}
+
+// ChangeOrderEventResponse represents an entry in a change order's history
+type ChangeOrderEventResponse struct {
+	ID                uuid.UUID                `json:"id"`
+	ActorID           *uuid.UUID               `json:"actor_id,omitempty"`
+	Action            models.ChangeOrderAction `json:"action"`
+	FromStatus        models.ChangeOrderStatus `json:"from_status,omitempty"`
+	ToStatus          models.ChangeOrderStatus `json:"to_status"`
+	Note              string                   `json:"note,omitempty"`
+	PriceDelta        float64                  `json:"price_delta"`
+	ScheduleDeltaDays int                      `json:"schedule_delta_days"`
+	CreatedAt         time.Time                `json:"created_at"`
+}
+
+// ChangeOrderApprovalResponse represents the outcome of approving a change order
+type ChangeOrderApprovalResponse struct {
+	ChangeOrder *ChangeOrderResponse `json:"change_order"`
+	Project     *ProjectResponse     `json:"project"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToChangeOrderResponse converts a ChangeOrder model to a response DTO
+func ToChangeOrderResponse(changeOrder *models.ChangeOrder) *ChangeOrderResponse {
+	if changeOrder == nil {
+		return nil
+	}
+
+	response := &ChangeOrderResponse{
+		ID:                changeOrder.ID,
+		TenantID:          changeOrder.TenantID,
+		ProjectID:         changeOrder.ProjectID,
+		Number:            changeOrder.Number,
+		Title:             changeOrder.Title,
+		Description:       changeOrder.Description,
+		Reason:            changeOrder.Reason,
+		Status:            changeOrder.Status,
+		PriceDelta:        changeOrder.PriceDelta,
+		ScheduleDeltaDays: changeOrder.ScheduleDeltaDays,
+		Currency:          changeOrder.Currency,
+		RequestedByID:     changeOrder.RequestedByID,
+		SubmittedAt:       changeOrder.SubmittedAt,
+		RespondedByID:     changeOrder.RespondedByID,
+		RespondedAt:       changeOrder.RespondedAt,
+		RejectionReason:   changeOrder.RejectionReason,
+		CreatedAt:         changeOrder.CreatedAt,
+		UpdatedAt:         changeOrder.UpdatedAt,
+	}
+	if changeOrder.Status == models.ChangeOrderStatusApproved {
+		response.BudgetBefore = &changeOrder.BudgetBefore
+		response.BudgetAfter = &changeOrder.BudgetAfter
+		response.DueDateBefore = changeOrder.DueDateBefore
+		response.DueDateAfter = changeOrder.DueDateAfter
+	}
+	return response
+}
+
+// ToChangeOrderResponses converts multiple ChangeOrder models to response DTOs
+func ToChangeOrderResponses(changeOrders []*models.ChangeOrder) []*ChangeOrderResponse {
+	responses := make([]*ChangeOrderResponse, len(changeOrders))
+	for i, changeOrder := range changeOrders {
+		responses[i] = ToChangeOrderResponse(changeOrder)
+	}
+	return responses
+}
+
+// ToChangeOrderEventResponses converts change order events to response DTOs
+func ToChangeOrderEventResponses(events []*models.ChangeOrderEvent) []*ChangeOrderEventResponse {
+	responses := make([]*ChangeOrderEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = &ChangeOrderEventResponse{
+			ID:                event.ID,
+			ActorID:           event.ActorID,
+			Action:            event.Action,
+			FromStatus:        event.FromStatus,
+			ToStatus:          event.ToStatus,
+			Note:              event.Note,
+			PriceDelta:        event.PriceDelta,
+			ScheduleDeltaDays: event.ScheduleDeltaDays,
+			CreatedAt:         event.CreatedAt,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Commission Rule Request DTOs
+// ============================================================================
+
+// CreateCommissionRuleRequest represents a request to create a commission rule
+type CreateCommissionRuleRequest struct {
+	TenantID         uuid.UUID              `json:"-"` // Set from the auth context
+	Name             string                 `json:"name" validate:"required,max=100"`
+	ServiceCategory  models.ServiceCategory `json:"service_category,omitempty"` // Empty applies to every category
+	ArtisanTier      models.ArtisanTier     `json:"artisan_tier,omitempty"`     // Empty applies to every tier
+	MinMonthlyVolume float64                `json:"min_monthly_volume" validate:"min=0"`
+	StartsAt         *time.Time             `json:"starts_at,omitempty"` // Promotional period
+	EndsAt           *time.Time             `json:"ends_at,omitempty"`
+	Rate             float64                `json:"rate" validate:"min=0,max=100"`
+	IsActive         *bool                  `json:"is_active,omitempty"` // Defaults to true
+}
+
+// Validate validates the create commission rule request
+func (r *CreateCommissionRuleRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	return nil
+}
+
+// UpdateCommissionRuleRequest represents a request to update a commission rule. A
+// promotional period is removed with ClearPeriod.
+type UpdateCommissionRuleRequest struct {
+	Name             *string                 `json:"name,omitempty" validate:"omitempty,max=100"`
+	ServiceCategory  *models.ServiceCategory `json:"service_category,omitempty"`
+	ArtisanTier      *models.ArtisanTier     `json:"artisan_tier,omitempty"`
+	MinMonthlyVolume *float64                `json:"min_monthly_volume,omitempty" validate:"omitempty,min=0"`
+	StartsAt         *time.Time              `json:"starts_at,omitempty"`
+	EndsAt           *time.Time              `json:"ends_at,omitempty"`
+	ClearPeriod      bool                    `json:"clear_period,omitempty"`
+	Rate             *float64                `json:"rate,omitempty" validate:"omitempty,min=0,max=100"`
+	IsActive         *bool                   `json:"is_active,omitempty"`
+}
+
+// ============================================================================
+// Commission Rule Response DTOs
+// ============================================================================
+
+// CommissionRuleResponse represents a commission rule
+type CommissionRuleResponse struct {
+	ID               uuid.UUID              `json:"id"`
+	TenantID         uuid.UUID              `json:"tenant_id"`
+	Name             string                 `json:"name"`
+	ServiceCategory  models.ServiceCategory `json:"service_category,omitempty"`
+	ArtisanTier      models.ArtisanTier     `json:"artisan_tier,omitempty"`
+	MinMonthlyVolume float64                `json:"min_monthly_volume"`
+	StartsAt         *time.Time             `json:"starts_at,omitempty"`
+	EndsAt           *time.Time             `json:"ends_at,omitempty"`
+	Rate             float64                `json:"rate"`
+	IsActive         bool                   `json:"is_active"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToCommissionRuleResponse converts a CommissionRule model to a response DTO
+func ToCommissionRuleResponse(rule *models.CommissionRule) *CommissionRuleResponse {
+	if rule == nil {
+		return nil
+	}
+
+	return &CommissionRuleResponse{
+		ID:               rule.ID,
+		TenantID:         rule.TenantID,
+		Name:             rule.Name,
+		ServiceCategory:  rule.ServiceCategory,
+		ArtisanTier:      rule.ArtisanTier,
+		MinMonthlyVolume: rule.MinMonthlyVolume,
+		StartsAt:         rule.StartsAt,
+		EndsAt:           rule.EndsAt,
+		Rate:             rule.Rate,
+		IsActive:         rule.IsActive,
+		CreatedAt:        rule.CreatedAt,
+		UpdatedAt:        rule.UpdatedAt,
+	}
+}
+
+// ToCommissionRuleResponses converts multiple CommissionRule models to response DTOs
+func ToCommissionRuleResponses(rules []*models.CommissionRule) []*CommissionRuleResponse {
+	responses := make([]*CommissionRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = ToCommissionRuleResponse(rule)
+	}
+	return responses
+}
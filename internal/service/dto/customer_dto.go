@@ -62,6 +62,21 @@ func (r *UpdateCustomerRequest) Validate() error {
 	return nil
 }
 
+// UpdateCustomerTagsRequest replaces a customer's manual tags
+type UpdateCustomerTagsRequest struct {
+	Tags []string `json:"tags" validate:"max=20,dive,max=50"`
+}
+
+// Validate normalizes the tags
+func (r *UpdateCustomerTagsRequest) Validate() error {
+	tags, err := models.NormalizeCustomerTags(r.Tags)
+	if err != nil {
+		return err
+	}
+	r.Tags = tags
+	return nil
+}
+
 // UpdateLoyaltyPointsRequest represents the request to update loyalty points
 type UpdateLoyaltyPointsRequest struct {
 	Points    int    `json:"points" validate:"required"`
@@ -126,6 +141,15 @@ type CustomerFilter struct {
 	SortBy             string      `json:"sort_by,omitempty"`
 	SortOrder          string      `json:"sort_order,omitempty"` // asc or desc
 	SearchQuery        string      `json:"search_query,omitempty"`
+
+	// Customers in any of the segments and carrying every tag
+	Segments []models.CustomerSegment `json:"segments,omitempty"`
+	Tags     []string                 `json:"tags,omitempty"`
+}
+
+// Audience returns the customers the filter selects by segment and tag
+func (f *CustomerFilter) Audience() models.CustomerAudience {
+	return models.CustomerAudience{Segments: f.Segments, Tags: f.Tags}
 }
 
 // Validate validates the customer filter
@@ -156,6 +180,11 @@ func (f *CustomerFilter) Validate() error {
 		return fmt.Errorf("max loyalty points cannot be negative")
 	}
 
+	audience := f.Audience()
+	if err := audience.Validate(); err != nil {
+		return err
+	}
+
 	// Validate loyalty tiers
 	if len(f.LoyaltyTiers) > 0 {
 		validTiers := []string{"Bronze", "Silver", "Gold", "Platinum"}
@@ -227,9 +256,17 @@ type CustomerResponse struct {
 	PushNotifications      bool             `json:"push_notifications"`
 	Metadata               models.JSONB     `json:"metadata,omitempty"`
 
+	// Segments computed nightly from the customer's bookings, and tags set by the tenant
+	Segments    models.StringArray `json:"segments"`
+	Tags        models.StringArray `json:"tags"`
+	SegmentedAt *time.Time         `json:"segmented_at,omitempty"`
+
 	// User information
 	User *UserInfoResponse `json:"user,omitempty"`
 
+	// Transactional messages and marketing the customer is sent on each channel
+	CommunicationPreferences *CommunicationPreferencesResponse `json:"communication_preferences,omitempty"`
+
 	// Calculated fields
 	BookingCompletionRate float64    `json:"booking_completion_rate"`
 	BookingCancelRate     float64    `json:"booking_cancel_rate"`
@@ -373,6 +410,9 @@ func ToCustomerResponse(customer *models.Customer) *CustomerResponse {
 		SMSNotifications:       customer.SMSNotifications,
 		PushNotifications:      customer.PushNotifications,
 		Metadata:               customer.Metadata,
+		Segments:               customer.Segments,
+		Tags:                   customer.Tags,
+		SegmentedAt:            customer.SegmentedAt,
 		CreatedAt:              customer.CreatedAt,
 		UpdatedAt:              customer.UpdatedAt,
 	}
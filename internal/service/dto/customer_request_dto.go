@@ -0,0 +1,301 @@
+package dto
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Customer Request DTOs
+// ============================================================================
+
+// CreateCustomerRequestRequest posts a job for quotes from the artisans whose skills match it
+type CreateCustomerRequestRequest struct {
+	Title         string                 `json:"title" validate:"required,max=255"`
+	Description   string                 `json:"description" validate:"required,max=5000"`
+	Category      models.ServiceCategory `json:"category,omitempty"` // Only match skills of the category
+	PhotoURLs     []string               `json:"photo_urls,omitempty"`
+	BudgetMin     float64                `json:"budget_min,omitempty" validate:"omitempty,min=0"`
+	BudgetMax     float64                `json:"budget_max,omitempty" validate:"omitempty,min=0"` // 0 for no limit
+	Currency      string                 `json:"currency,omitempty" validate:"omitempty,len=3"`   // Defaults to USD
+	Location      *models.Location       `json:"location,omitempty"`                              // Where the job is
+	PreferredDate *time.Time             `json:"preferred_date,omitempty"`
+	OpenForDays   int                    `json:"open_for_days,omitempty"` // How long bids are taken; defaults to 14, at most 60
+}
+
+// Validate validates the create customer request request
+func (r *CreateCustomerRequestRequest) Validate() error {
+	maxDays := int(models.MaxCustomerRequestOpenFor / (24 * time.Hour))
+	if r.OpenForDays < 0 || r.OpenForDays > maxDays {
+		return fmt.Errorf("open_for_days must be between 1 and %d", maxDays)
+	}
+	return nil
+}
+
+// SubmitBidRequest makes or changes an artisan's bid on a request. Naming one of their
+// services and a start time makes the bid a booking when accepted; otherwise it
+// becomes a project.
+type SubmitBidRequest struct {
+	Amount            float64    `json:"amount" validate:"required,gt=0"`
+	Currency          string     `json:"currency,omitempty" validate:"omitempty,len=3"` // Defaults to the request's
+	Message           string     `json:"message,omitempty" validate:"omitempty,max=2000"`
+	EstimatedDays     int        `json:"estimated_days,omitempty" validate:"omitempty,min=0"`
+	ServiceID         *uuid.UUID `json:"service_id,omitempty"`
+	ProposedStartTime *time.Time `json:"proposed_start_time,omitempty"`
+	DurationMinutes   int        `json:"duration_minutes,omitempty"`
+}
+
+// CustomerRequestFilter represents filters for customer request queries
+type CustomerRequestFilter struct {
+	TenantID   uuid.UUID                      `json:"tenant_id"`
+	CustomerID *uuid.UUID                     `json:"customer_id,omitempty"`
+	Statuses   []models.CustomerRequestStatus `json:"statuses,omitempty"`
+	Page       int                            `json:"page"`
+	PageSize   int                            `json:"page_size"`
+}
+
+// ============================================================================
+// Customer Request Response DTOs
+// ============================================================================
+
+// CustomerRequestResponse represents a customer's request for quotes
+type CustomerRequestResponse struct {
+	ID            uuid.UUID                    `json:"id"`
+	TenantID      uuid.UUID                    `json:"tenant_id"`
+	CustomerID    uuid.UUID                    `json:"customer_id"`
+	Title         string                       `json:"title"`
+	Description   string                       `json:"description"`
+	Category      models.ServiceCategory       `json:"category,omitempty"`
+	PhotoURLs     []string                     `json:"photo_urls"`
+	BudgetMin     float64                      `json:"budget_min"`
+	BudgetMax     float64                      `json:"budget_max"`
+	Currency      string                       `json:"currency"`
+	Location      *models.Location             `json:"location,omitempty"`
+	PreferredDate *time.Time                   `json:"preferred_date,omitempty"`
+	Status        models.CustomerRequestStatus `json:"status"`
+	ExpiresAt     time.Time                    `json:"expires_at"`
+	LeadCount     int                          `json:"lead_count"`
+	BidCount      int                          `json:"bid_count"`
+	AwardedBidID  *uuid.UUID                   `json:"awarded_bid_id,omitempty"`
+	AwardedAt     *time.Time                   `json:"awarded_at,omitempty"`
+	ProjectID     *uuid.UUID                   `json:"project_id,omitempty"`
+	BookingID     *uuid.UUID                   `json:"booking_id,omitempty"`
+	ClosedAt      *time.Time                   `json:"closed_at,omitempty"`
+	CreatedAt     time.Time                    `json:"created_at"`
+	UpdatedAt     time.Time                    `json:"updated_at"`
+}
+
+// CustomerRequestListResponse represents a paginated list of customer requests
+type CustomerRequestListResponse struct {
+	Requests    []*CustomerRequestResponse `json:"requests"`
+	Page        int                        `json:"page"`
+	PageSize    int                        `json:"page_size"`
+	TotalItems  int64                      `json:"total_items"`
+	TotalPages  int                        `json:"total_pages"`
+	HasNext     bool                       `json:"has_next"`
+	HasPrevious bool                       `json:"has_previous"`
+}
+
+// CustomerRequestBidResponse represents an artisan's bid on a request
+type CustomerRequestBidResponse struct {
+	ID                 uuid.UUID                       `json:"id"`
+	RequestID          uuid.UUID                       `json:"request_id"`
+	ArtisanID          uuid.UUID                       `json:"artisan_id"`
+	ArtisanName        string                          `json:"artisan_name,omitempty"`
+	ArtisanRating      float64                         `json:"artisan_rating"`
+	ArtisanReviewCount int                             `json:"artisan_review_count"`
+	Status             models.CustomerRequestBidStatus `json:"status"`
+	Amount             float64                         `json:"amount"`
+	Currency           string                          `json:"currency"`
+	Message            string                          `json:"message,omitempty"`
+	EstimatedDays      int                             `json:"estimated_days,omitempty"`
+	ServiceID          *uuid.UUID                      `json:"service_id,omitempty"`
+	ProposedStartTime  *time.Time                      `json:"proposed_start_time,omitempty"`
+	DurationMinutes    int                             `json:"duration_minutes,omitempty"`
+	ConvertsTo         string                          `json:"converts_to"` // booking or project
+	CreatedAt          time.Time                       `json:"created_at"`
+	UpdatedAt          time.Time                       `json:"updated_at"`
+}
+
+// BidComparison summarizes the standing bids on a request
+type BidComparison struct {
+	Count         int     `json:"count"`
+	LowestAmount  float64 `json:"lowest_amount"`
+	HighestAmount float64 `json:"highest_amount"`
+	AverageAmount float64 `json:"average_amount"`
+	WithinBudget  int     `json:"within_budget"` // Bids at or under the request's budget_max, or all without one
+}
+
+// CustomerRequestDetailResponse is a request with its bids, the cheapest first, for the
+// customer to compare
+type CustomerRequestDetailResponse struct {
+	*CustomerRequestResponse
+	Comparison BidComparison                 `json:"comparison"`
+	Bids       []*CustomerRequestBidResponse `json:"bids"`
+}
+
+// LeadResponse represents a request broadcast to an artisan, with their bid on it
+type LeadResponse struct {
+	ID         uuid.UUID                   `json:"id"`
+	MatchScore float64                     `json:"match_score"`
+	ViewedAt   *time.Time                  `json:"viewed_at,omitempty"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	Request    *CustomerRequestResponse    `json:"request"`
+	Bid        *CustomerRequestBidResponse `json:"bid,omitempty"`
+}
+
+// LeadListResponse represents a paginated list of an artisan's leads
+type LeadListResponse struct {
+	Leads       []*LeadResponse `json:"leads"`
+	Page        int             `json:"page"`
+	PageSize    int             `json:"page_size"`
+	TotalItems  int64           `json:"total_items"`
+	TotalPages  int             `json:"total_pages"`
+	HasNext     bool            `json:"has_next"`
+	HasPrevious bool            `json:"has_previous"`
+}
+
+// CustomerRequestAwardResponse represents the outcome of accepting a bid: the booking
+// or project it became
+type CustomerRequestAwardResponse struct {
+	Request *CustomerRequestResponse    `json:"request"`
+	Bid     *CustomerRequestBidResponse `json:"bid"`
+	Project *ProjectResponse            `json:"project,omitempty"`
+	Booking *BookingResponse            `json:"booking,omitempty"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToCustomerRequestResponse converts a CustomerRequest model to a response DTO
+func ToCustomerRequestResponse(request *models.CustomerRequest) *CustomerRequestResponse {
+	if request == nil {
+		return nil
+	}
+
+	photos := []string(request.PhotoURLs)
+	if photos == nil {
+		photos = []string{}
+	}
+
+	return &CustomerRequestResponse{
+		ID:            request.ID,
+		TenantID:      request.TenantID,
+		CustomerID:    request.CustomerID,
+		Title:         request.Title,
+		Description:   request.Description,
+		Category:      request.Category,
+		PhotoURLs:     photos,
+		BudgetMin:     request.BudgetMin,
+		BudgetMax:     request.BudgetMax,
+		Currency:      request.Currency,
+		Location:      request.Location,
+		PreferredDate: request.PreferredDate,
+		Status:        request.DisplayStatus(time.Now()),
+		ExpiresAt:     request.ExpiresAt,
+		LeadCount:     request.LeadCount,
+		BidCount:      request.BidCount,
+		AwardedBidID:  request.AwardedBidID,
+		AwardedAt:     request.AwardedAt,
+		ProjectID:     request.ProjectID,
+		BookingID:     request.BookingID,
+		ClosedAt:      request.ClosedAt,
+		CreatedAt:     request.CreatedAt,
+		UpdatedAt:     request.UpdatedAt,
+	}
+}
+
+// ToCustomerRequestResponses converts multiple CustomerRequest models to response DTOs
+func ToCustomerRequestResponses(requests []*models.CustomerRequest) []*CustomerRequestResponse {
+	responses := make([]*CustomerRequestResponse, len(requests))
+	for i, request := range requests {
+		responses[i] = ToCustomerRequestResponse(request)
+	}
+	return responses
+}
+
+// ToCustomerRequestBidResponse converts a CustomerRequestBid model to a response DTO
+func ToCustomerRequestBidResponse(bid *models.CustomerRequestBid) *CustomerRequestBidResponse {
+	if bid == nil {
+		return nil
+	}
+
+	resp := &CustomerRequestBidResponse{
+		ID:                bid.ID,
+		RequestID:         bid.RequestID,
+		ArtisanID:         bid.ArtisanID,
+		Status:            bid.Status,
+		Amount:            bid.Amount,
+		Currency:          bid.Currency,
+		Message:           bid.Message,
+		EstimatedDays:     bid.EstimatedDays,
+		ServiceID:         bid.ServiceID,
+		ProposedStartTime: bid.ProposedStartTime,
+		DurationMinutes:   bid.DurationMinutes,
+		ConvertsTo:        "project",
+		CreatedAt:         bid.CreatedAt,
+		UpdatedAt:         bid.UpdatedAt,
+	}
+	if bid.ConvertsToBooking() {
+		resp.ConvertsTo = "booking"
+	}
+	if bid.Artisan != nil {
+		resp.ArtisanRating = bid.Artisan.Rating
+		resp.ArtisanReviewCount = bid.Artisan.ReviewCount
+		if bid.Artisan.User != nil {
+			resp.ArtisanName = bid.Artisan.User.FullName()
+		}
+	}
+	return resp
+}
+
+// ToCustomerRequestDetailResponse converts a request and its bids to the comparison DTO.
+// Only submitted bids are compared.
+func ToCustomerRequestDetailResponse(request *models.CustomerRequest, bids []*models.CustomerRequestBid) *CustomerRequestDetailResponse {
+	resp := &CustomerRequestDetailResponse{
+		CustomerRequestResponse: ToCustomerRequestResponse(request),
+		Bids:                    make([]*CustomerRequestBidResponse, len(bids)),
+	}
+
+	total := 0.0
+	for i, bid := range bids {
+		resp.Bids[i] = ToCustomerRequestBidResponse(bid)
+		if bid.Status != models.CustomerRequestBidStatusSubmitted {
+			continue
+		}
+
+		c := &resp.Comparison
+		if c.Count == 0 || bid.Amount < c.LowestAmount {
+			c.LowestAmount = bid.Amount
+		}
+		c.HighestAmount = max(c.HighestAmount, bid.Amount)
+		if request.BudgetMax == 0 || bid.Amount <= request.BudgetMax {
+			c.WithinBudget++
+		}
+		c.Count++
+		total += bid.Amount
+	}
+	if resp.Comparison.Count > 0 {
+		resp.Comparison.AverageAmount = math.Round(total/float64(resp.Comparison.Count)*100) / 100
+	}
+	return resp
+}
+
+// ToLeadResponse converts a CustomerRequestLead model with its request, and the
+// artisan's bid when they made one, to a response DTO
+func ToLeadResponse(lead *models.CustomerRequestLead, bid *models.CustomerRequestBid) *LeadResponse {
+	return &LeadResponse{
+		ID:         lead.ID,
+		MatchScore: lead.MatchScore,
+		ViewedAt:   lead.ViewedAt,
+		CreatedAt:  lead.CreatedAt,
+		Request:    ToCustomerRequestResponse(lead.Request),
+		Bid:        ToCustomerRequestBidResponse(bid),
+	}
+}
@@ -0,0 +1,143 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Favorite Request DTOs
+// ============================================================================
+
+// AddFavoriteRequest saves an artisan or a service to the customer's favorites. Adding
+// one already saved updates whether the customer is notified about it.
+type AddFavoriteRequest struct {
+	Kind     models.FavoriteKind `json:"kind" validate:"required"`
+	TargetID uuid.UUID           `json:"target_id" validate:"required"` // Artisan profile or service ID
+	Notify   bool                `json:"notify"`                        // Artisans only: hear about new availability and services
+}
+
+// Validate validates the add favorite request
+func (r *AddFavoriteRequest) Validate() error {
+	if !r.Kind.IsValid() {
+		return fmt.Errorf("kind must be artisan or service")
+	}
+	if r.TargetID == uuid.Nil {
+		return fmt.Errorf("target_id is required")
+	}
+	if r.Notify && r.Kind != models.FavoriteKindArtisan {
+		return fmt.Errorf("notifications are only available for favorite artisans")
+	}
+	return nil
+}
+
+// ============================================================================
+// Favorite Response DTOs
+// ============================================================================
+
+// FavoriteArtisanSummary is the favorited artisan shown in a customer's favorites
+type FavoriteArtisanSummary struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name,omitempty"`
+	Avatar      string    `json:"avatar,omitempty"`
+	Rating      float64   `json:"rating"`
+	ReviewCount int       `json:"review_count"`
+	IsVerified  bool      `json:"is_verified"`
+	IsAvailable bool      `json:"is_available"`
+}
+
+// FavoriteServiceSummary is the favorited service shown in a customer's favorites
+type FavoriteServiceSummary struct {
+	ID        uuid.UUID              `json:"id"`
+	Name      string                 `json:"name"`
+	Category  models.ServiceCategory `json:"category"`
+	Price     float64                `json:"price"`
+	Currency  string                 `json:"currency"`
+	ImageURL  string                 `json:"image_url,omitempty"`
+	Rating    float64                `json:"rating"`
+	Bookable  bool                   `json:"bookable"`
+	ArtisanID *uuid.UUID             `json:"artisan_id,omitempty"`
+}
+
+// FavoriteResponse represents a customer's favorite
+type FavoriteResponse struct {
+	ID        uuid.UUID               `json:"id"`
+	Kind      models.FavoriteKind     `json:"kind"`
+	TargetID  uuid.UUID               `json:"target_id"`
+	Notify    bool                    `json:"notify"`
+	Artisan   *FavoriteArtisanSummary `json:"artisan,omitempty"`
+	Service   *FavoriteServiceSummary `json:"service,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// FavoriteListResponse represents a page of a customer's favorites
+type FavoriteListResponse struct {
+	Favorites   []*FavoriteResponse `json:"favorites"`
+	Page        int                 `json:"page"`
+	PageSize    int                 `json:"page_size"`
+	TotalItems  int64               `json:"total_items"`
+	TotalPages  int                 `json:"total_pages"`
+	HasNext     bool                `json:"has_next"`
+	HasPrevious bool                `json:"has_previous"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToFavoriteResponse converts a Favorite model to a response DTO
+func ToFavoriteResponse(favorite *models.Favorite) *FavoriteResponse {
+	if favorite == nil {
+		return nil
+	}
+
+	response := &FavoriteResponse{
+		ID:        favorite.ID,
+		Kind:      favorite.Kind(),
+		TargetID:  favorite.TargetID(),
+		Notify:    favorite.Notify,
+		CreatedAt: favorite.CreatedAt,
+	}
+	if artisan := favorite.Artisan; artisan != nil {
+		response.Artisan = &FavoriteArtisanSummary{
+			ID:          artisan.ID,
+			UserID:      artisan.UserID,
+			Rating:      artisan.Rating,
+			ReviewCount: artisan.ReviewCount,
+			IsVerified:  artisan.IsVerified,
+			IsAvailable: artisan.IsAvailable,
+		}
+		if artisan.User != nil {
+			response.Artisan.Name = artisan.User.FullName()
+			response.Artisan.Avatar = artisan.User.AvatarURL
+		}
+	}
+	if service := favorite.Service; service != nil {
+		response.Service = &FavoriteServiceSummary{
+			ID:        service.ID,
+			Name:      service.Name,
+			Category:  service.Category,
+			Price:     service.Price,
+			Currency:  service.Currency,
+			ImageURL:  service.ImageURL,
+			Rating:    service.Rating,
+			Bookable:  service.IsActive && service.IsBookable(),
+			ArtisanID: service.ArtisanID,
+		}
+	}
+	return response
+}
+
+// ToFavoriteResponses converts multiple Favorite models to response DTOs
+func ToFavoriteResponses(favorites []*models.Favorite) []*FavoriteResponse {
+	responses := make([]*FavoriteResponse, len(favorites))
+	for i, favorite := range favorites {
+		responses[i] = ToFavoriteResponse(favorite)
+	}
+	return responses
+}
@@ -81,6 +81,7 @@ type InvoiceResponse struct {
 	Currency        string                   `json:"currency"`
 	Status          models.InvoiceStatus     `json:"status"`
 	LineItems       []models.InvoiceLineItem `json:"line_items"`
+	TaxLines        []models.TaxLine         `json:"tax_lines,omitempty"`
 	Notes           string                   `json:"notes,omitempty"`
 	TermsConditions string                   `json:"terms_conditions,omitempty"`
 	PDFFileURL      string                   `json:"pdf_file_url,omitempty"`
@@ -118,6 +119,12 @@ type InvoiceStatsResponse struct {
 	OverdueAmount     float64   `json:"overdue_amount"`
 }
 
+// InvoicePDF is a rendered invoice document
+type InvoicePDF struct {
+	FileName string
+	Content  []byte
+}
+
 // ============================================================================
 // Conversion Functions
 // ============================================================================
@@ -146,6 +153,7 @@ func ToInvoiceResponse(invoice *models.Invoice) *InvoiceResponse {
 		Currency:        invoice.Currency,
 		Status:          invoice.Status,
 		LineItems:       invoice.LineItems,
+		TaxLines:        invoice.TaxLines,
 		Notes:           invoice.Notes,
 		TermsConditions: invoice.TermsConditions,
 		PDFFileURL:      invoice.PDFFileURL,
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
@@ -54,6 +56,32 @@ type ConversationRequest struct {
 	PageSize int       `json:"page_size"`
 }
 
+// BookingMessageRequest represents a message posted to a booking's chat thread.
+// The receiver is the other participant of the booking.
+type BookingMessageRequest struct {
+	Type            models.MessageType `json:"type"`
+	Content         string             `json:"content"`
+	FileURL         string             `json:"file_url,omitempty"`
+	ParentMessageID *uuid.UUID         `json:"parent_message_id,omitempty"`
+	Metadata        map[string]any     `json:"metadata,omitempty"`
+}
+
+// Validate validates the booking message request
+func (r *BookingMessageRequest) Validate() error {
+	if r.Type == "" {
+		r.Type = models.MessageTypeText
+	}
+	switch r.Type {
+	case models.MessageTypeText, models.MessageTypeImage, models.MessageTypeFile:
+	default:
+		return fmt.Errorf("invalid message type: %s", r.Type)
+	}
+	if strings.TrimSpace(r.Content) == "" && r.FileURL == "" {
+		return fmt.Errorf("content is required")
+	}
+	return nil
+}
+
 // ============================================================================
 // Message Response DTOs
 // ============================================================================
@@ -141,6 +169,21 @@ type ConversationStatsResponse struct {
 	AverageResponseSec  float64                      `json:"average_response_seconds"`
 }
 
+// BookingConversationResponse represents a page of a booking's chat thread
+type BookingConversationResponse struct {
+	ConversationID *uuid.UUID         `json:"conversation_id,omitempty"`
+	BookingID      uuid.UUID          `json:"booking_id"`
+	UnreadCount    int                `json:"unread_count"`
+	LastMessageAt  *time.Time         `json:"last_message_at,omitempty"`
+	Messages       []*MessageResponse `json:"messages"`
+	Page           int                `json:"page"`
+	PageSize       int                `json:"page_size"`
+	TotalItems     int64              `json:"total_items"`
+	TotalPages     int                `json:"total_pages"`
+	HasNext        bool               `json:"has_next"`
+	HasPrevious    bool               `json:"has_previous"`
+}
+
 // BookingSummary represents a minimal booking summary for messages
 type BookingSummary struct {
 	ID     uuid.UUID            `json:"id"`
@@ -18,8 +18,8 @@ type CreateNotificationRequest struct {
 	TenantID          uuid.UUID                    `json:"tenant_id" validate:"required"`
 	UserID            uuid.UUID                    `json:"user_id" validate:"required"`
 	Type              models.NotificationType      `json:"type" validate:"required"`
-	Title             string                       `json:"title" validate:"required,max=255"`
-	Message           string                       `json:"message" validate:"required"`
+	Title             string                       `json:"title" validate:"required_without=Template,max=255"`
+	Message           string                       `json:"message" validate:"required_without=Template"`
 	Channels          []models.NotificationChannel `json:"channels" validate:"required,min=1"`
 	ActionURL         string                       `json:"action_url,omitempty"`
 	ActionText        string                       `json:"action_text,omitempty"`
@@ -28,6 +28,12 @@ type CreateNotificationRequest struct {
 	Priority          int                          `json:"priority" validate:"min=1,max=10"`
 	ExpiresAt         *time.Time                   `json:"expires_at,omitempty"`
 	Metadata          map[string]any               `json:"metadata,omitempty"`
+
+	// Template names catalog messages the title, message and action text are rendered
+	// from in the recipient's language, with TemplateArgs for their placeholders, in
+	// place of the ones given
+	Template     string         `json:"template,omitempty"`
+	TemplateArgs map[string]any `json:"template_args,omitempty"`
 }
 
 // Validate validates the create notification request
@@ -38,10 +44,10 @@ func (r *CreateNotificationRequest) Validate() error {
 	if r.UserID == uuid.Nil {
 		return fmt.Errorf("user_id is required")
 	}
-	if r.Title == "" {
+	if r.Template == "" && r.Title == "" {
 		return fmt.Errorf("title is required")
 	}
-	if r.Message == "" {
+	if r.Template == "" && r.Message == "" {
 		return fmt.Errorf("message is required")
 	}
 	if len(r.Channels) == 0 {
@@ -195,6 +201,37 @@ type NotificationPreferencesResponse struct {
 	EmailDigestFrequency string                       `json:"email_digest_frequency,omitempty"`
 }
 
+// CommunicationPreferencesResponse represents the transactional messages and marketing
+// a user is sent on each channel
+type CommunicationPreferencesResponse struct {
+	UserID             uuid.UUID  `json:"user_id"`
+	EmailTransactional bool       `json:"email_transactional"`
+	SMSTransactional   bool       `json:"sms_transactional"`
+	PushTransactional  bool       `json:"push_transactional"`
+	EmailMarketing     bool       `json:"email_marketing"`
+	SMSMarketing       bool       `json:"sms_marketing"`
+	PushMarketing      bool       `json:"push_marketing"`
+	MarketingConsentAt *time.Time `json:"marketing_consent_at,omitempty"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"` // Unset until the user first chooses
+}
+
+// UpdateCommunicationPreferencesRequest represents a change of communication
+// preferences; omitted fields are left as they are
+type UpdateCommunicationPreferencesRequest struct {
+	EmailTransactional *bool `json:"email_transactional,omitempty"`
+	SMSTransactional   *bool `json:"sms_transactional,omitempty"`
+	PushTransactional  *bool `json:"push_transactional,omitempty"`
+	EmailMarketing     *bool `json:"email_marketing,omitempty"`
+	SMSMarketing       *bool `json:"sms_marketing,omitempty"`
+	PushMarketing      *bool `json:"push_marketing,omitempty"`
+}
+
+// UnsubscribeResponse represents the outcome of following an unsubscribe link
+type UnsubscribeResponse struct {
+	Channel  models.NotificationChannel   `json:"channel"`
+	Category models.CommunicationCategory `json:"category"`
+}
+
 // NotificationDeliveryResponse represents delivery status
 type NotificationDeliveryResponse struct {
 	NotificationID uuid.UUID `json:"notification_id"`
@@ -259,6 +296,28 @@ func ToNotificationResponse(notification *models.Notification) *NotificationResp
 	}
 }
 
+// ToCommunicationPreferencesResponse converts CommunicationPreferences to a response DTO
+func ToCommunicationPreferencesResponse(preferences *models.CommunicationPreferences) *CommunicationPreferencesResponse {
+	if preferences == nil {
+		return nil
+	}
+
+	response := &CommunicationPreferencesResponse{
+		UserID:             preferences.UserID,
+		EmailTransactional: preferences.EmailTransactional,
+		SMSTransactional:   preferences.SMSTransactional,
+		PushTransactional:  preferences.PushTransactional,
+		EmailMarketing:     preferences.EmailMarketing,
+		SMSMarketing:       preferences.SMSMarketing,
+		PushMarketing:      preferences.PushMarketing,
+		MarketingConsentAt: preferences.MarketingConsentAt,
+	}
+	if !preferences.UpdatedAt.IsZero() {
+		response.UpdatedAt = &preferences.UpdatedAt
+	}
+	return response
+}
+
 // ToNotificationResponses converts multiple Notification models to DTOs
 func ToNotificationResponses(notifications []*models.Notification) []*NotificationResponse {
 	if notifications == nil {
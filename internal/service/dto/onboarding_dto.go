@@ -0,0 +1,286 @@
+package dto
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// onboardingWeekdays are the keys of a tenant's business hours
+var onboardingWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+var (
+	currencyPattern  = regexp.MustCompile(`^[A-Z]{3}$`)
+	hexColorPattern  = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+	clockTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+)
+
+// ============================================================================
+// Request DTOs
+// ============================================================================
+
+// StartOnboardingRequest represents the first step of self-serve onboarding: the
+// business, its subdomain and the owner invited to run it
+type StartOnboardingRequest struct {
+	Name           string            `json:"name" validate:"required,min=2,max=100"`
+	Subdomain      string            `json:"subdomain" validate:"required,min=3,max=63"`
+	BusinessEmail  string            `json:"business_email,omitempty" validate:"omitempty,email"`
+	BusinessPhone  string            `json:"business_phone,omitempty"`
+	Plan           models.TenantPlan `json:"plan,omitempty"` // Defaults to solo
+	OwnerEmail     string            `json:"owner_email" validate:"required,email"`
+	OwnerFirstName string            `json:"owner_first_name" validate:"required,max=100"`
+	OwnerLastName  string            `json:"owner_last_name" validate:"required,max=100"`
+}
+
+// Validate validates the start onboarding request
+func (r *StartOnboardingRequest) Validate() error {
+	if len(r.Name) < 2 || len(r.Name) > 100 {
+		return fmt.Errorf("name must be between 2 and 100 characters")
+	}
+	if len(r.Subdomain) < 3 || len(r.Subdomain) > 63 {
+		return fmt.Errorf("subdomain must be between 3 and 63 characters")
+	}
+	if _, err := mail.ParseAddress(r.OwnerEmail); err != nil {
+		return fmt.Errorf("owner email is invalid")
+	}
+	if r.BusinessEmail != "" {
+		if _, err := mail.ParseAddress(r.BusinessEmail); err != nil {
+			return fmt.Errorf("business email is invalid")
+		}
+	}
+	if r.OwnerFirstName == "" || r.OwnerLastName == "" {
+		return fmt.Errorf("owner first and last name are required")
+	}
+	if len(r.OwnerFirstName) > 100 || len(r.OwnerLastName) > 100 {
+		return fmt.Errorf("owner names must be at most 100 characters")
+	}
+	switch r.Plan {
+	case models.TenantPlanSolo, models.TenantPlanSmall, models.TenantPlanCorporation, models.TenantPlanEnterprise:
+	default:
+		return fmt.Errorf("plan must be solo, small, corporation or enterprise")
+	}
+	return nil
+}
+
+// Sanitize sanitizes the start onboarding request
+func (r *StartOnboardingRequest) Sanitize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Subdomain = strings.ToLower(strings.TrimSpace(r.Subdomain))
+	r.BusinessEmail = strings.ToLower(strings.TrimSpace(r.BusinessEmail))
+	r.BusinessPhone = strings.TrimSpace(r.BusinessPhone)
+	r.OwnerEmail = strings.ToLower(strings.TrimSpace(r.OwnerEmail))
+	r.OwnerFirstName = strings.TrimSpace(r.OwnerFirstName)
+	r.OwnerLastName = strings.TrimSpace(r.OwnerLastName)
+	if r.Plan == "" {
+		r.Plan = models.TenantPlanSolo
+	}
+}
+
+// ResendOwnerInviteRequest represents the request to invite a tenant's owner again
+type ResendOwnerInviteRequest struct {
+	Subdomain string `json:"subdomain" validate:"required"`
+}
+
+// OnboardingPreferencesRequest represents the preferences step: the currency prices
+// are in, the timezone bookings are scheduled in, and the tenant's branding
+type OnboardingPreferencesRequest struct {
+	Currency     string `json:"currency" validate:"required,len=3"`
+	Timezone     string `json:"timezone" validate:"required"`
+	Language     string `json:"language,omitempty"`
+	LogoURL      string `json:"logo_url,omitempty" validate:"omitempty,url"`
+	PrimaryColor string `json:"primary_color,omitempty" validate:"omitempty,hexcolor"`
+}
+
+// Validate validates the preferences request
+func (r *OnboardingPreferencesRequest) Validate() error {
+	if !currencyPattern.MatchString(r.Currency) {
+		return fmt.Errorf("currency must be a 3-letter ISO 4217 code")
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil || r.Timezone == "" {
+		return fmt.Errorf("timezone must be an IANA time zone, such as Africa/Accra")
+	}
+	if r.LogoURL != "" {
+		if u, err := url.Parse(r.LogoURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("logo URL must be an http or https URL")
+		}
+	}
+	if r.PrimaryColor != "" && !hexColorPattern.MatchString(r.PrimaryColor) {
+		return fmt.Errorf("primary color must be a hex color, such as #3B82F6")
+	}
+	return nil
+}
+
+// Sanitize sanitizes the preferences request
+func (r *OnboardingPreferencesRequest) Sanitize() {
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	r.Timezone = strings.TrimSpace(r.Timezone)
+	r.Language = strings.ToLower(strings.TrimSpace(r.Language))
+	r.LogoURL = strings.TrimSpace(r.LogoURL)
+	r.PrimaryColor = strings.ToUpper(strings.TrimSpace(r.PrimaryColor))
+}
+
+// OnboardingServicesRequest represents the services step. A default service is seeded
+// for each category, unless the tenant already offers services; services listed are
+// added as given.
+type OnboardingServicesRequest struct {
+	Categories []models.ServiceCategory `json:"categories,omitempty" validate:"max=20"`
+	Services   []OnboardingServiceInput `json:"services,omitempty" validate:"max=50,dive"`
+}
+
+// OnboardingServiceInput is a service the tenant offers
+type OnboardingServiceInput struct {
+	Name            string                 `json:"name" validate:"required,min=2,max=255"`
+	Description     string                 `json:"description,omitempty"`
+	Category        models.ServiceCategory `json:"category" validate:"required"`
+	Price           float64                `json:"price" validate:"min=0"`
+	DurationMinutes int                    `json:"duration_minutes" validate:"required,min=5"`
+}
+
+// Validate validates the services request
+func (r *OnboardingServicesRequest) Validate() error {
+	if len(r.Categories) == 0 && len(r.Services) == 0 {
+		return fmt.Errorf("choose at least one category or service")
+	}
+	if len(r.Categories) > 20 {
+		return fmt.Errorf("at most 20 categories can be chosen")
+	}
+	if len(r.Services) > 50 {
+		return fmt.Errorf("at most 50 services can be added at once")
+	}
+	for _, category := range r.Categories {
+		if category == "" || len(category) > 50 {
+			return fmt.Errorf("invalid category %q", category)
+		}
+	}
+	for i, service := range r.Services {
+		if len(service.Name) < 2 || len(service.Name) > 255 {
+			return fmt.Errorf("services[%d]: name must be between 2 and 255 characters", i)
+		}
+		if service.Category == "" || len(service.Category) > 50 {
+			return fmt.Errorf("services[%d]: category is required", i)
+		}
+		if service.Price < 0 {
+			return fmt.Errorf("services[%d]: price cannot be negative", i)
+		}
+		if service.DurationMinutes < 5 {
+			return fmt.Errorf("services[%d]: duration must be at least 5 minutes", i)
+		}
+	}
+	return nil
+}
+
+// Sanitize sanitizes the services request
+func (r *OnboardingServicesRequest) Sanitize() {
+	for i := range r.Categories {
+		r.Categories[i] = models.ServiceCategory(strings.ToLower(strings.TrimSpace(string(r.Categories[i]))))
+	}
+	for i := range r.Services {
+		r.Services[i].Name = strings.TrimSpace(r.Services[i].Name)
+		r.Services[i].Description = strings.TrimSpace(r.Services[i].Description)
+		r.Services[i].Category = models.ServiceCategory(strings.ToLower(strings.TrimSpace(string(r.Services[i].Category))))
+	}
+}
+
+// OnboardingWorkingHoursRequest represents the working hours step, by weekday
+// ("monday" to "sunday"). Days left out are closed.
+type OnboardingWorkingHoursRequest struct {
+	BusinessHours map[string]models.TimeRange `json:"business_hours" validate:"required"`
+}
+
+// Validate validates the working hours request
+func (r *OnboardingWorkingHoursRequest) Validate() error {
+	if len(r.BusinessHours) == 0 {
+		return fmt.Errorf("business hours are required")
+	}
+	open := 0
+	for day, hours := range r.BusinessHours {
+		if !slices.Contains(onboardingWeekdays, day) {
+			return fmt.Errorf("unknown day %q; use monday to sunday", day)
+		}
+		if hours.Start == "closed" && hours.End == "closed" {
+			continue
+		}
+		if !clockTimePattern.MatchString(hours.Start) || !clockTimePattern.MatchString(hours.End) {
+			return fmt.Errorf("%s: times must be in HH:MM format", day)
+		}
+		// Zero-padded HH:MM times order as strings do
+		if hours.Start >= hours.End {
+			return fmt.Errorf("%s: opening time must be before closing time", day)
+		}
+		open++
+	}
+	if open == 0 {
+		return fmt.Errorf("the business must be open at least one day")
+	}
+	return nil
+}
+
+// Sanitize lowercases the weekdays of the working hours request
+func (r *OnboardingWorkingHoursRequest) Sanitize() {
+	hours := make(map[string]models.TimeRange, len(r.BusinessHours))
+	for day, hoursOfDay := range r.BusinessHours {
+		hours[strings.ToLower(strings.TrimSpace(day))] = hoursOfDay
+	}
+	r.BusinessHours = hours
+}
+
+// WeekHours returns the business hours for every weekday, with days left out closed
+func (r *OnboardingWorkingHoursRequest) WeekHours() map[string]models.TimeRange {
+	week := make(map[string]models.TimeRange, len(onboardingWeekdays))
+	for _, day := range onboardingWeekdays {
+		if hours, ok := r.BusinessHours[day]; ok {
+			week[day] = hours
+		} else {
+			week[day] = models.TimeRange{Start: "closed", End: "closed"}
+		}
+	}
+	return week
+}
+
+// ============================================================================
+// Response DTOs
+// ============================================================================
+
+// OnboardingStepStatus is a step of the onboarding wizard and whether it is done
+type OnboardingStepStatus struct {
+	Step      models.OnboardingStep `json:"step"`
+	Completed bool                  `json:"completed"`
+}
+
+// OnboardingProgressResponse is a tenant's progress through onboarding, from which
+// the wizard resumes at NextStep
+type OnboardingProgressResponse struct {
+	TenantID     uuid.UUID              `json:"tenant_id"`
+	Subdomain    string                 `json:"subdomain"`
+	Steps        []OnboardingStepStatus `json:"steps"`
+	NextStep     models.OnboardingStep  `json:"next_step,omitempty"`
+	OwnerJoined  bool                   `json:"owner_joined"` // The owner accepted the invite and signed in
+	Completed    bool                   `json:"completed"`
+	CompletedAt  *time.Time             `json:"completed_at,omitempty"`
+	ServiceCount int64                  `json:"service_count"`
+	Tenant       *TenantResponse        `json:"tenant,omitempty"` // Only shown to the tenant's signed-in users
+}
+
+// ToOnboardingProgressResponse converts onboarding progress to a response
+func ToOnboardingProgressResponse(onboarding *models.TenantOnboarding, tenant *models.Tenant) *OnboardingProgressResponse {
+	steps := make([]OnboardingStepStatus, len(models.OnboardingSteps))
+	for i, step := range models.OnboardingSteps {
+		steps[i] = OnboardingStepStatus{Step: step, Completed: onboarding.HasCompleted(step)}
+	}
+	return &OnboardingProgressResponse{
+		TenantID:    tenant.ID,
+		Subdomain:   tenant.Subdomain,
+		Steps:       steps,
+		NextStep:    onboarding.NextStep(),
+		Completed:   onboarding.CompletedAt != nil,
+		CompletedAt: onboarding.CompletedAt,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/redact"
 
 	"github.com/google/uuid"
 )
@@ -52,6 +53,40 @@ func (r *CreatePaymentRequest) Validate() error {
 	return nil
 }
 
+// InitiatePaymentRequest starts a payment with the tenant's payment provider
+type InitiatePaymentRequest struct {
+	TenantID        uuid.UUID          `json:"-"`
+	BookingID       uuid.UUID          `json:"booking_id" validate:"required"`
+	Amount          float64            `json:"amount" validate:"required,min=0"`
+	Type            models.PaymentType `json:"type,omitempty"`              // Defaults to full
+	Provider        string             `json:"provider,omitempty"`          // Overrides the tenant's provider, e.g. mpesa for mobile money
+	PaymentMethodID string             `json:"payment_method_id,omitempty"` // Saved method to charge off-session
+	PhoneNumber     string             `json:"phone_number,omitempty"`      // Mobile money payer; defaults to the customer's phone
+	ManualCapture   bool               `json:"manual_capture,omitempty"`    // Authorize now, capture later
+	ReturnURL       string             `json:"return_url,omitempty" validate:"omitempty,url"`
+	CancelURL       string             `json:"cancel_url,omitempty" validate:"omitempty,url"`
+}
+
+// Validate validates the initiate payment request
+func (r *InitiatePaymentRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return ErrTenantIDRequired
+	}
+	if r.BookingID == uuid.Nil {
+		return ErrBookingIDRequired
+	}
+	if r.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+	if r.Type == "" {
+		r.Type = models.PaymentTypeFull
+	}
+	if r.Type == models.PaymentTypeRefund {
+		return fmt.Errorf("refunds cannot be initiated as payments")
+	}
+	return nil
+}
+
 // PaymentFilter represents filters for payment queries
 type PaymentFilter struct {
 	TenantID        uuid.UUID              `json:"tenant_id"`
@@ -69,6 +104,12 @@ type PaymentFilter struct {
 	ProviderName    *string                `json:"provider_name"`
 	Page            int                    `json:"page"`
 	PageSize        int                    `json:"page_size"`
+	Cursor          string                 `json:"cursor,omitempty"`
+}
+
+// EscrowActionRequest releases or withholds a payment's artisan share by hand
+type EscrowActionRequest struct {
+	Note string `json:"note,omitempty" validate:"max=1000"` // Required to withhold
 }
 
 // ============================================================================
@@ -84,6 +125,32 @@ type PaymentListResponse struct {
 	TotalPages  int                `json:"totalPages"`
 	HasNext     bool               `json:"hasNext"`
 	HasPrevious bool               `json:"hasPrevious"`
+	HasMore     bool               `json:"hasMore"`
+	NextCursor  string             `json:"nextCursor,omitempty"`
+}
+
+// PaymentIntentResponse is a payment started with a provider. The client completes it
+// with ClientSecret (Stripe) or by sending the customer to RedirectURL (PayPal, Paystack).
+type PaymentIntentResponse struct {
+	Payment           *PaymentResponse `json:"payment"`
+	Provider          string           `json:"provider"`
+	ProviderPaymentID string           `json:"provider_payment_id"`
+	Status            string           `json:"status"`
+	ClientSecret      string           `json:"client_secret,omitempty"`
+	RedirectURL       string           `json:"redirect_url,omitempty"`
+}
+
+// PaymentSyncRunResponse summarises one run of the provider status poll
+type PaymentSyncRunResponse struct {
+	Checked int `json:"checked"`
+	Updated int `json:"updated"`
+	Errors  int `json:"errors"`
+}
+
+// EscrowReleaseResponse reports how many payments an escrow release freed for payout
+type EscrowReleaseResponse struct {
+	BookingID *uuid.UUID `json:"booking_id,omitempty"`
+	Released  int64      `json:"released"`
 }
 
 // RefundRecordResponse represents a refund record
@@ -142,9 +209,10 @@ type PaymentStatsResponse struct {
 
 // RevenueDataResponse represents revenue data for a period
 type RevenueDataResponse struct {
-	Period           time.Time `json:"period"`
-	Revenue          float64   `json:"revenue"`
-	TransactionCount int64     `json:"transaction_count"`
+	Period           time.Time  `json:"period"`
+	Revenue          float64    `json:"revenue"`
+	TransactionCount int64      `json:"transaction_count"`
+	AsOf             *time.Time `json:"as_of,omitempty"` // When the figures were last refreshed; unset when they are live
 }
 
 // CustomerPaymentSummaryResponse represents customer payment summary
@@ -189,13 +257,14 @@ type ProviderStatsResponse struct {
 // Payment Conversion Functions
 // ============================================================================
 
-// ToPaymentResponse converts a Payment model to PaymentResponse DTO
-func ToPaymentResponse(payment *models.Payment) *PaymentResponse {
+// ToPaymentResponse converts a Payment model to PaymentResponse DTO, with the fields the
+// viewer may see
+func ToPaymentResponse(payment *models.Payment, viewer Viewer) *PaymentResponse {
 	if payment == nil {
 		return nil
 	}
 
-	return &PaymentResponse{
+	return project(paymentFieldPolicy, viewer, &PaymentResponse{
 		ID:             payment.ID,
 		SubscriptionID: uuid.Nil, // Not applicable for booking payments
 		Amount:         payment.Amount,
@@ -206,18 +275,31 @@ func ToPaymentResponse(payment *models.Payment) *PaymentResponse {
 		FailureReason:  payment.FailureReason,
 		ProcessedAt:    payment.ProcessedAt,
 		CreatedAt:      payment.CreatedAt,
-	}
+
+		CommissionRate:     payment.CommissionRate,
+		CommissionRuleID:   payment.CommissionRuleID,
+		CommissionRuleName: payment.CommissionRuleName,
+
+		EscrowStatus:     payment.EscrowStatus,
+		EscrowReleasedAt: payment.EscrowReleasedAt,
+		EscrowNote:       payment.EscrowNote,
+
+		Provider:          payment.ProviderName,
+		ProviderReference: redact.ID(payment.ProviderPaymentID),
+		Metadata:          payment.RedactedMetadata(),
+	})
 }
 
-// ToPaymentResponses converts multiple Payment models to PaymentResponse DTOs
-func ToPaymentResponses(payments []*models.Payment) []*PaymentResponse {
+// ToPaymentResponses converts multiple Payment models to PaymentResponse DTOs, with the
+// fields the viewer may see
+func ToPaymentResponses(payments []*models.Payment, viewer Viewer) []*PaymentResponse {
 	if payments == nil {
 		return nil
 	}
 
 	responses := make([]*PaymentResponse, len(payments))
 	for i, payment := range payments {
-		responses[i] = ToPaymentResponse(payment)
+		responses[i] = ToPaymentResponse(payment, viewer)
 	}
 	return responses
 }
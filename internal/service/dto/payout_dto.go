@@ -0,0 +1,186 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Payout Request DTOs
+// ============================================================================
+
+// CreatePayoutBatchRequest pays out the tenant's unsettled artisan earnings in one currency
+type CreatePayoutBatchRequest struct {
+	TenantID      uuid.UUID           `json:"-"`
+	CreatedBy     uuid.UUID           `json:"-"`
+	Method        models.PayoutMethod `json:"method" validate:"required"`
+	Currency      string              `json:"currency" validate:"required,len=3"`
+	PeriodEnd     *time.Time          `json:"period_end,omitempty"`     // Defaults to now
+	MinimumAmount float64             `json:"minimum_amount,omitempty"` // Artisans owed less are carried to the next batch
+}
+
+// Validate validates the create payout batch request
+func (r *CreatePayoutBatchRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return ErrTenantIDRequired
+	}
+	if !r.Method.IsValid() {
+		return fmt.Errorf("method must be %s or %s", models.PayoutMethodStripeConnect, models.PayoutMethodBankTransfer)
+	}
+	if len(r.Currency) != 3 {
+		return ErrInvalidCurrency
+	}
+	r.Currency = strings.ToUpper(r.Currency)
+	if r.MinimumAmount < 0 {
+		return fmt.Errorf("minimum amount cannot be negative")
+	}
+	if r.PeriodEnd != nil && r.PeriodEnd.After(time.Now()) {
+		return fmt.Errorf("period end cannot be in the future")
+	}
+	return nil
+}
+
+// ============================================================================
+// Payout Response DTOs
+// ============================================================================
+
+// PayoutResponse represents one artisan's payout
+type PayoutResponse struct {
+	ID                 uuid.UUID           `json:"id"`
+	BatchID            uuid.UUID           `json:"batch_id"`
+	ArtisanID          uuid.UUID           `json:"artisan_id"`
+	ArtisanName        string              `json:"artisan_name,omitempty"`
+	Amount             float64             `json:"amount"`
+	Currency           string              `json:"currency"`
+	PaymentCount       int                 `json:"payment_count"`
+	Method             models.PayoutMethod `json:"method"`
+	Status             models.PayoutStatus `json:"status"`
+	Destination        string              `json:"destination,omitempty"`
+	ProviderTransferID string              `json:"provider_transfer_id,omitempty"`
+	FailureReason      string              `json:"failure_reason,omitempty"`
+	PaidAt             *time.Time          `json:"paid_at,omitempty"`
+	CreatedAt          time.Time           `json:"created_at"`
+}
+
+// PayoutBatchResponse represents a payout run and, when loaded, its payouts
+type PayoutBatchResponse struct {
+	ID          uuid.UUID           `json:"id"`
+	Method      models.PayoutMethod `json:"method"`
+	Status      models.PayoutStatus `json:"status"`
+	Currency    string              `json:"currency"`
+	PeriodEnd   time.Time           `json:"period_end"`
+	PayoutCount int                 `json:"payout_count"`
+	TotalAmount float64             `json:"total_amount"`
+	FailedCount int                 `json:"failed_count"`
+	CreatedBy   *uuid.UUID          `json:"created_by,omitempty"`
+	CompletedAt *time.Time          `json:"completed_at,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	Payouts     []*PayoutResponse   `json:"payouts,omitempty"`
+}
+
+// PayoutBatchListResponse represents a paginated list of payout batches
+type PayoutBatchListResponse struct {
+	Batches     []*PayoutBatchResponse `json:"batches"`
+	Page        int                    `json:"page"`
+	PageSize    int                    `json:"pageSize"`
+	TotalItems  int64                  `json:"totalItems"`
+	TotalPages  int                    `json:"totalPages"`
+	HasNext     bool                   `json:"hasNext"`
+	HasPrevious bool                   `json:"hasPrevious"`
+}
+
+// PayoutListResponse represents a paginated list of payouts
+type PayoutListResponse struct {
+	Payouts     []*PayoutResponse `json:"payouts"`
+	Page        int               `json:"page"`
+	PageSize    int               `json:"pageSize"`
+	TotalItems  int64             `json:"totalItems"`
+	TotalPages  int               `json:"totalPages"`
+	HasNext     bool              `json:"hasNext"`
+	HasPrevious bool              `json:"hasPrevious"`
+}
+
+// ArtisanPayoutSummaryResponse shows what an artisan has been paid and is still owed
+type ArtisanPayoutSummaryResponse struct {
+	ArtisanID      uuid.UUID `json:"artisan_id"`
+	Currency       string    `json:"currency"`
+	UnpaidEarnings float64   `json:"unpaid_earnings"` // Settled payments not yet in a payout
+	HeldEarnings   float64   `json:"held_earnings"`   // Settled payments held or withheld in escrow
+	TotalPaidOut   float64   `json:"total_paid_out"`
+}
+
+// PayoutBankFile is a bank transfer batch exported for the tenant's bank
+type PayoutBankFile struct {
+	FileName string
+	Content  []byte
+}
+
+// ============================================================================
+// Payout Conversion Functions
+// ============================================================================
+
+// ToPayoutResponse converts a Payout model to PayoutResponse DTO
+func ToPayoutResponse(payout *models.Payout) *PayoutResponse {
+	if payout == nil {
+		return nil
+	}
+
+	resp := &PayoutResponse{
+		ID:                 payout.ID,
+		BatchID:            payout.BatchID,
+		ArtisanID:          payout.ArtisanID,
+		Amount:             payout.Amount,
+		Currency:           payout.Currency,
+		PaymentCount:       payout.PaymentCount,
+		Method:             payout.Method,
+		Status:             payout.Status,
+		Destination:        payout.Destination,
+		ProviderTransferID: payout.ProviderTransferID,
+		FailureReason:      payout.FailureReason,
+		PaidAt:             payout.PaidAt,
+		CreatedAt:          payout.CreatedAt,
+	}
+	if payout.Artisan != nil {
+		resp.ArtisanName = payout.Artisan.FullName()
+	}
+	return resp
+}
+
+// ToPayoutResponses converts multiple Payout models to PayoutResponse DTOs
+func ToPayoutResponses(payouts []*models.Payout) []*PayoutResponse {
+	responses := make([]*PayoutResponse, len(payouts))
+	for i, payout := range payouts {
+		responses[i] = ToPayoutResponse(payout)
+	}
+	return responses
+}
+
+// ToPayoutBatchResponse converts a PayoutBatch model to PayoutBatchResponse DTO
+func ToPayoutBatchResponse(batch *models.PayoutBatch) *PayoutBatchResponse {
+	if batch == nil {
+		return nil
+	}
+
+	resp := &PayoutBatchResponse{
+		ID:          batch.ID,
+		Method:      batch.Method,
+		Status:      batch.Status,
+		Currency:    batch.Currency,
+		PeriodEnd:   batch.PeriodEnd,
+		PayoutCount: batch.PayoutCount,
+		TotalAmount: batch.TotalAmount,
+		FailedCount: batch.FailedCount,
+		CreatedBy:   batch.CreatedBy,
+		CompletedAt: batch.CompletedAt,
+		CreatedAt:   batch.CreatedAt,
+	}
+	if len(batch.Payouts) > 0 {
+		resp.Payouts = ToPayoutResponses(batch.Payouts)
+	}
+	return resp
+}
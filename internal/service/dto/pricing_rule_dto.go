@@ -0,0 +1,130 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Pricing Rule Request DTOs
+// ============================================================================
+
+// CreatePricingRuleRequest represents a request to create a pricing rule
+type CreatePricingRuleRequest struct {
+	TenantID        uuid.UUID              `json:"-"` // Set from the auth context
+	Name            string                 `json:"name" validate:"required,max=100"`
+	Kind            models.PricingRuleKind `json:"kind" validate:"required"`
+	ServiceID       *uuid.UUID             `json:"service_id,omitempty"`       // Empty applies to every service
+	ServiceCategory models.ServiceCategory `json:"service_category,omitempty"` // Empty applies to every category
+	ArtisanID       *uuid.UUID             `json:"artisan_id,omitempty"`       // Overrides the tenant's rules of the kind for the artisan
+	Weekdays        []int                  `json:"weekdays,omitempty"`         // 0=Sunday
+	StartHour       int                    `json:"start_hour" validate:"min=0,max=23"`
+	EndHour         int                    `json:"end_hour" validate:"min=0,max=24"`
+	Timezone        string                 `json:"timezone,omitempty"` // Defaults to UTC
+	LeadHours       int                    `json:"lead_hours" validate:"min=0"`
+	StartsAt        *time.Time             `json:"starts_at,omitempty"`
+	EndsAt          *time.Time             `json:"ends_at,omitempty"`
+	Multiplier      float64                `json:"multiplier" validate:"required,gt=0"`
+	IsActive        *bool                  `json:"is_active,omitempty"` // Defaults to true
+}
+
+// Validate validates the create pricing rule request
+func (r *CreatePricingRuleRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	return nil
+}
+
+// UpdatePricingRuleRequest represents a request to update a pricing rule. The service
+// and artisan scopes are removed with ClearServiceID and ClearArtisanID, and the period
+// with ClearPeriod.
+type UpdatePricingRuleRequest struct {
+	Name            *string                 `json:"name,omitempty" validate:"omitempty,max=100"`
+	ServiceID       *uuid.UUID              `json:"service_id,omitempty"`
+	ClearServiceID  bool                    `json:"clear_service_id,omitempty"`
+	ServiceCategory *models.ServiceCategory `json:"service_category,omitempty"`
+	ArtisanID       *uuid.UUID              `json:"artisan_id,omitempty"`
+	ClearArtisanID  bool                    `json:"clear_artisan_id,omitempty"`
+	Weekdays        *[]int                  `json:"weekdays,omitempty"`
+	StartHour       *int                    `json:"start_hour,omitempty" validate:"omitempty,min=0,max=23"`
+	EndHour         *int                    `json:"end_hour,omitempty" validate:"omitempty,min=0,max=24"`
+	Timezone        *string                 `json:"timezone,omitempty"`
+	LeadHours       *int                    `json:"lead_hours,omitempty" validate:"omitempty,min=0"`
+	StartsAt        *time.Time              `json:"starts_at,omitempty"`
+	EndsAt          *time.Time              `json:"ends_at,omitempty"`
+	ClearPeriod     bool                    `json:"clear_period,omitempty"`
+	Multiplier      *float64                `json:"multiplier,omitempty" validate:"omitempty,gt=0"`
+	IsActive        *bool                   `json:"is_active,omitempty"`
+}
+
+// ============================================================================
+// Pricing Rule Response DTOs
+// ============================================================================
+
+// PricingRuleResponse represents a pricing rule
+type PricingRuleResponse struct {
+	ID              uuid.UUID              `json:"id"`
+	TenantID        uuid.UUID              `json:"tenant_id"`
+	Name            string                 `json:"name"`
+	Kind            models.PricingRuleKind `json:"kind"`
+	ServiceID       *uuid.UUID             `json:"service_id,omitempty"`
+	ServiceCategory models.ServiceCategory `json:"service_category,omitempty"`
+	ArtisanID       *uuid.UUID             `json:"artisan_id,omitempty"`
+	Weekdays        []int                  `json:"weekdays,omitempty"`
+	StartHour       int                    `json:"start_hour"`
+	EndHour         int                    `json:"end_hour"`
+	Timezone        string                 `json:"timezone"`
+	LeadHours       int                    `json:"lead_hours"`
+	StartsAt        *time.Time             `json:"starts_at,omitempty"`
+	EndsAt          *time.Time             `json:"ends_at,omitempty"`
+	Multiplier      float64                `json:"multiplier"`
+	IsActive        bool                   `json:"is_active"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToPricingRuleResponse converts a PricingRule model to a response DTO
+func ToPricingRuleResponse(rule *models.PricingRule) *PricingRuleResponse {
+	if rule == nil {
+		return nil
+	}
+
+	return &PricingRuleResponse{
+		ID:              rule.ID,
+		TenantID:        rule.TenantID,
+		Name:            rule.Name,
+		Kind:            rule.Kind,
+		ServiceID:       rule.ServiceID,
+		ServiceCategory: rule.ServiceCategory,
+		ArtisanID:       rule.ArtisanID,
+		Weekdays:        rule.Weekdays,
+		StartHour:       rule.StartHour,
+		EndHour:         rule.EndHour,
+		Timezone:        rule.Timezone,
+		LeadHours:       rule.LeadHours,
+		StartsAt:        rule.StartsAt,
+		EndsAt:          rule.EndsAt,
+		Multiplier:      rule.Multiplier,
+		IsActive:        rule.IsActive,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
+
+// ToPricingRuleResponses converts multiple PricingRule models to response DTOs
+func ToPricingRuleResponses(rules []*models.PricingRule) []*PricingRuleResponse {
+	responses := make([]*PricingRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = ToPricingRuleResponse(rule)
+	}
+	return responses
+}
@@ -26,6 +26,7 @@ type CreateProjectRequest struct {
 	DueDate      *time.Time             `json:"due_date,omitempty"`
 	BudgetAmount float64                `json:"budget_amount,omitempty" validate:"min=0"`
 	Currency     string                 `json:"currency,omitempty" validate:"len=3"`
+	HourlyRate   float64                `json:"hourly_rate,omitempty" validate:"min=0"` // Default rate for billable time entries
 	Tags         []string               `json:"tags,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -47,11 +48,16 @@ func (r *CreateProjectRequest) Validate() error {
 	if r.BudgetAmount < 0 {
 		return fmt.Errorf("budget_amount cannot be negative")
 	}
+	if r.HourlyRate < 0 {
+		return fmt.Errorf("hourly_rate cannot be negative")
+	}
 	return nil
 }
 
 // UpdateProjectRequest represents a request to update a project
 type UpdateProjectRequest struct {
+	// Version the client edited; a stale version is rejected with 409 Conflict
+	Version      *int                    `json:"version,omitempty"`
 	Title        *string                 `json:"title,omitempty" validate:"omitempty,max=255"`
 	Description  *string                 `json:"description,omitempty"`
 	Priority     *models.ProjectPriority `json:"priority,omitempty"`
@@ -59,6 +65,7 @@ type UpdateProjectRequest struct {
 	DueDate      *time.Time              `json:"due_date,omitempty"`
 	BudgetAmount *float64                `json:"budget_amount,omitempty" validate:"omitempty,min=0"`
 	Currency     *string                 `json:"currency,omitempty" validate:"omitempty,len=3"`
+	HourlyRate   *float64                `json:"hourly_rate,omitempty" validate:"omitempty,min=0"`
 	Tags         []string                `json:"tags,omitempty"`
 	Metadata     map[string]interface{}  `json:"metadata,omitempty"`
 }
@@ -98,6 +105,7 @@ type BulkProjectUpdateRequest struct {
 // ProjectResponse represents a project
 type ProjectResponse struct {
 	ID                 uuid.UUID              `json:"id"`
+	Version            int                    `json:"version"`
 	TenantID           uuid.UUID              `json:"tenant_id"`
 	ArtisanID          uuid.UUID              `json:"artisan_id"`
 	CustomerID         *uuid.UUID             `json:"customer_id,omitempty"`
@@ -110,11 +118,17 @@ type ProjectResponse struct {
 	CompletedAt        *time.Time             `json:"completed_at,omitempty"`
 	BudgetAmount       float64                `json:"budget_amount"`
 	Currency           string                 `json:"currency"`
+	HourlyRate         float64                `json:"hourly_rate"`
 	ProgressPercent    int                    `json:"progress_percent"`
 	TasksTotal         int                    `json:"tasks_total"`
 	TasksCompleted     int                    `json:"tasks_completed"`
 	TasksOverdue       int                    `json:"tasks_overdue"`
 	ActiveBlockedTasks int                    `json:"active_blocked_tasks"`
+	EstimatedHours     float64                `json:"estimated_hours"`
+	ActualHours        float64                `json:"actual_hours"`
+	HealthScore        *int                   `json:"health_score,omitempty"`
+	RiskLevel          string                 `json:"risk_level,omitempty"`
+	HealthCheckedAt    *time.Time             `json:"health_checked_at,omitempty"`
 	Tags               []string               `json:"tags,omitempty"`
 	Metadata           models.JSONB           `json:"metadata,omitempty"`
 	ArtisanName        string                 `json:"artisan_name,omitempty"`
@@ -176,21 +190,57 @@ type CustomerProjectStatsResponse struct {
 
 // ProjectHealthResponse represents project health metrics
 type ProjectHealthResponse struct {
-	ProjectID          uuid.UUID `json:"project_id"`
-	HealthScore        int       `json:"health_score"`
-	IsOnTrack          bool      `json:"is_on_track"`
-	IsOverBudget       bool      `json:"is_over_budget"`
-	IsOverdue          bool      `json:"is_overdue"`
-	BlockedTasksCount  int       `json:"blocked_tasks_count"`
-	OverdueTasksCount  int       `json:"overdue_tasks_count"`
-	CompletionVelocity float64   `json:"completion_velocity"`
-	RiskLevel          string    `json:"risk_level"`
-	Recommendations    []string  `json:"recommendations"`
+	ProjectID          uuid.UUID  `json:"project_id"`
+	HealthScore        int        `json:"health_score"`
+	IsOnTrack          bool       `json:"is_on_track"`
+	IsOverBudget       bool       `json:"is_over_budget"`
+	IsOverdue          bool       `json:"is_overdue"`
+	BlockedTasksCount  int        `json:"blocked_tasks_count"`
+	OverdueTasksCount  int        `json:"overdue_tasks_count"`
+	CompletionVelocity float64    `json:"completion_velocity"`
+	CriticalPathHours  float64    `json:"critical_path_hours"`
+	ProjectedFinish    *time.Time `json:"projected_finish,omitempty"`
+	ScheduleDaysLate   int        `json:"schedule_days_late"`
+	RiskLevel          string     `json:"risk_level"`
+	Recommendations    []string   `json:"recommendations"`
+}
+
+// ProjectHealthPointResponse represents a recorded health score
+type ProjectHealthPointResponse struct {
+	RecordedAt        time.Time `json:"recorded_at"`
+	HealthScore       int       `json:"health_score"`
+	RiskLevel         string    `json:"risk_level"`
+	IsOverdue         bool      `json:"is_overdue"`
+	BlockedTasksCount int       `json:"blocked_tasks_count"`
+	OverdueTasksCount int       `json:"overdue_tasks_count"`
+	ScheduleDaysLate  int       `json:"schedule_days_late"`
+	ProgressPercent   int       `json:"progress_percent"`
+}
+
+// ProjectHealthHistoryResponse represents a project's health trend, oldest first
+type ProjectHealthHistoryResponse struct {
+	ProjectID uuid.UUID                     `json:"project_id"`
+	Since     time.Time                     `json:"since"`
+	Points    []*ProjectHealthPointResponse `json:"points"`
+}
+
+// ProjectHealthRunResponse represents the outcome of a health recalculation run
+type ProjectHealthRunResponse struct {
+	Checked        int `json:"checked"`
+	HighRiskAlerts int `json:"high_risk_alerts"`
+	OverdueAlerts  int `json:"overdue_alerts"`
+	Failed         int `json:"failed"`
 }
 
-// ProjectTimelineResponse represents timeline events
+// ProjectTimelineResponse represents a page of timeline events, most recent first
 type ProjectTimelineResponse struct {
-	Events []*TimelineEventResponse `json:"events"`
+	Events      []*TimelineEventResponse `json:"events"`
+	Page        int                      `json:"page"`
+	PageSize    int                      `json:"page_size"`
+	TotalItems  int64                    `json:"total_items"`
+	TotalPages  int                      `json:"total_pages"`
+	HasNext     bool                     `json:"has_next"`
+	HasPrevious bool                     `json:"has_previous"`
 }
 
 // TimelineEventResponse represents a timeline event
@@ -201,6 +251,8 @@ type TimelineEventResponse struct {
 	Description string     `json:"description"`
 	UserID      *uuid.UUID `json:"user_id,omitempty"`
 	UserName    string     `json:"user_name,omitempty"`
+	Source      string     `json:"source"`    // project, update, status_change, milestone, task or booking
+	EntityID    uuid.UUID  `json:"entity_id"` // ID of the record the event comes from
 }
 
 // ArtisanDashboardResponse represents artisan dashboard
@@ -254,6 +306,7 @@ func ToProjectResponse(project *models.Project) *ProjectResponse {
 
 	resp := &ProjectResponse{
 		ID:                 project.ID,
+		Version:            project.Version,
 		TenantID:           project.TenantID,
 		ArtisanID:          project.ArtisanID,
 		CustomerID:         project.CustomerID,
@@ -266,11 +319,17 @@ func ToProjectResponse(project *models.Project) *ProjectResponse {
 		CompletedAt:        project.CompletedAt,
 		BudgetAmount:       project.BudgetAmount,
 		Currency:           project.Currency,
+		HourlyRate:         project.HourlyRate,
 		ProgressPercent:    project.ProgressPercent,
 		TasksTotal:         project.TasksTotal,
 		TasksCompleted:     project.TasksCompleted,
 		TasksOverdue:       project.TasksOverdue,
 		ActiveBlockedTasks: project.ActiveBlockedTasks,
+		EstimatedHours:     project.EstimatedHours,
+		ActualHours:        project.ActualHours,
+		HealthScore:        project.HealthScore,
+		RiskLevel:          project.RiskLevel,
+		HealthCheckedAt:    project.HealthCheckedAt,
 		Tags:               project.Tags,
 		Metadata:           project.Metadata,
 		CreatedAt:          project.CreatedAt,
@@ -349,6 +408,28 @@ func ToCustomerProjectStatsResponse(customerID uuid.UUID, stats repository.Custo
 	}
 }
 
+// ToProjectHealthHistoryResponse converts health snapshots to a trend response
+func ToProjectHealthHistoryResponse(projectID uuid.UUID, since time.Time, snapshots []*models.ProjectHealthSnapshot) *ProjectHealthHistoryResponse {
+	points := make([]*ProjectHealthPointResponse, len(snapshots))
+	for i, snapshot := range snapshots {
+		points[i] = &ProjectHealthPointResponse{
+			RecordedAt:        snapshot.RecordedAt,
+			HealthScore:       snapshot.HealthScore,
+			RiskLevel:         snapshot.RiskLevel,
+			IsOverdue:         snapshot.IsOverdue,
+			BlockedTasksCount: snapshot.BlockedTasksCount,
+			OverdueTasksCount: snapshot.OverdueTasksCount,
+			ScheduleDaysLate:  snapshot.ScheduleDaysLate,
+			ProgressPercent:   snapshot.ProgressPercent,
+		}
+	}
+	return &ProjectHealthHistoryResponse{
+		ProjectID: projectID,
+		Since:     since,
+		Points:    points,
+	}
+}
+
 // ToProjectHealthResponse converts health to response DTO
 func ToProjectHealthResponse(health repository.ProjectHealth) *ProjectHealthResponse {
 	return &ProjectHealthResponse{
@@ -360,6 +441,9 @@ func ToProjectHealthResponse(health repository.ProjectHealth) *ProjectHealthResp
 		BlockedTasksCount:  health.BlockedTasksCount,
 		OverdueTasksCount:  health.OverdueTasksCount,
 		CompletionVelocity: health.CompletionVelocity,
+		CriticalPathHours:  health.CriticalPathHours,
+		ProjectedFinish:    health.ProjectedFinish,
+		ScheduleDaysLate:   health.ScheduleDaysLate,
 		RiskLevel:          health.RiskLevel,
 		Recommendations:    health.Recommendations,
 	}
@@ -376,6 +460,8 @@ func ToTimelineEventResponses(events []repository.TimelineEvent) []*TimelineEven
 			Description: event.Description,
 			UserID:      event.UserID,
 			UserName:    event.UserName,
+			Source:      event.Source,
+			EntityID:    event.EntityID,
 		}
 	}
 	return responses
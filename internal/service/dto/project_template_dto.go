@@ -0,0 +1,178 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Project Template Request DTOs
+// ============================================================================
+
+// CreateProjectTemplateRequest represents a request to create a project template
+type CreateProjectTemplateRequest struct {
+	Name                string                     `json:"name" validate:"required,min=3,max=255"`
+	Description         string                     `json:"description,omitempty"`
+	Category            string                     `json:"category,omitempty" validate:"omitempty,max=100"`
+	DefaultDurationDays int                        `json:"default_duration_days,omitempty" validate:"omitempty,min=0"`
+	DefaultBudget       float64                    `json:"default_budget,omitempty" validate:"omitempty,min=0"`
+	Currency            string                     `json:"currency,omitempty" validate:"omitempty,len=3"` // Defaults to USD
+	HourlyRate          float64                    `json:"hourly_rate,omitempty" validate:"omitempty,min=0"`
+	Priority            models.ProjectPriority     `json:"priority,omitempty" validate:"omitempty,oneof=low medium high"`
+	Tags                []string                   `json:"tags,omitempty"`
+	Milestones          []models.TemplateMilestone `json:"milestones,omitempty"`
+	Tasks               []models.TemplateTask      `json:"tasks,omitempty"`
+}
+
+// UpdateProjectTemplateRequest represents a request to edit a project template.
+// Milestones and tasks, when given, replace the template's whole plan.
+type UpdateProjectTemplateRequest struct {
+	Name                *string                    `json:"name,omitempty" validate:"omitempty,min=3,max=255"`
+	Description         *string                    `json:"description,omitempty"`
+	Category            *string                    `json:"category,omitempty" validate:"omitempty,max=100"`
+	IsActive            *bool                      `json:"is_active,omitempty"`
+	DefaultDurationDays *int                       `json:"default_duration_days,omitempty" validate:"omitempty,min=0"`
+	DefaultBudget       *float64                   `json:"default_budget,omitempty" validate:"omitempty,min=0"`
+	Currency            *string                    `json:"currency,omitempty" validate:"omitempty,len=3"`
+	HourlyRate          *float64                   `json:"hourly_rate,omitempty" validate:"omitempty,min=0"`
+	Priority            *models.ProjectPriority    `json:"priority,omitempty" validate:"omitempty,oneof=low medium high"`
+	Tags                []string                   `json:"tags,omitempty"`
+	Milestones          []models.TemplateMilestone `json:"milestones,omitempty"`
+	Tasks               []models.TemplateTask      `json:"tasks,omitempty"`
+}
+
+// CreateProjectFromTemplateRequest represents a request to start a project from a
+// template. Every milestone and task date is offset from StartDate.
+type CreateProjectFromTemplateRequest struct {
+	ArtisanID    uuid.UUID  `json:"artisan_id" validate:"required"`
+	CustomerID   *uuid.UUID `json:"customer_id,omitempty"`
+	StartDate    time.Time  `json:"start_date" validate:"required"`
+	Title        string     `json:"title,omitempty" validate:"omitempty,min=3,max=255"` // Defaults to the template name
+	Description  string     `json:"description,omitempty"`
+	BudgetAmount float64    `json:"budget_amount,omitempty" validate:"omitempty,min=0"` // Defaults to the template budget
+}
+
+// Validate validates the create project from template request
+func (r *CreateProjectFromTemplateRequest) Validate() error {
+	if r.ArtisanID == uuid.Nil {
+		return fmt.Errorf("artisan_id is required")
+	}
+	if r.StartDate.IsZero() {
+		return fmt.Errorf("start_date is required")
+	}
+	return nil
+}
+
+// ProjectTemplateFilter represents filters for project template queries
+type ProjectTemplateFilter struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	Category string    `json:"category,omitempty"`
+	IsActive *bool     `json:"is_active,omitempty"`
+	Search   string    `json:"search,omitempty"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
+}
+
+// ============================================================================
+// Project Template Response DTOs
+// ============================================================================
+
+// ProjectTemplateResponse represents a project template
+type ProjectTemplateResponse struct {
+	ID                  uuid.UUID                 `json:"id"`
+	TenantID            uuid.UUID                 `json:"tenant_id"`
+	Name                string                    `json:"name"`
+	Description         string                    `json:"description,omitempty"`
+	Category            string                    `json:"category,omitempty"`
+	IsActive            bool                      `json:"is_active"`
+	DefaultDurationDays int                       `json:"default_duration_days"`
+	PlannedDurationDays int                       `json:"planned_duration_days"` // Days until the last milestone or task is due
+	DefaultBudget       float64                   `json:"default_budget"`
+	Currency            string                    `json:"currency"`
+	HourlyRate          float64                   `json:"hourly_rate"`
+	Priority            models.ProjectPriority    `json:"priority"`
+	Tags                []string                  `json:"tags,omitempty"`
+	Milestones          models.TemplateMilestones `json:"milestones"`
+	Tasks               models.TemplateTasks      `json:"tasks"`
+	UsageCount          int                       `json:"usage_count"`
+	LastUsedAt          *time.Time                `json:"last_used_at,omitempty"`
+	CreatedAt           time.Time                 `json:"created_at"`
+	UpdatedAt           time.Time                 `json:"updated_at"`
+}
+
+// ProjectTemplateListResponse represents a paginated list of project templates
+type ProjectTemplateListResponse struct {
+	Templates   []*ProjectTemplateResponse `json:"templates"`
+	Page        int                        `json:"page"`
+	PageSize    int                        `json:"page_size"`
+	TotalItems  int64                      `json:"total_items"`
+	TotalPages  int                        `json:"total_pages"`
+	HasNext     bool                       `json:"has_next"`
+	HasPrevious bool                       `json:"has_previous"`
+}
+
+// TemplateProjectResponse represents a project created from a template
+type TemplateProjectResponse struct {
+	Project    *ProjectResponse     `json:"project"`
+	Milestones []*MilestoneResponse `json:"milestones"`
+	Tasks      []*TaskResponse      `json:"tasks"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToProjectTemplateResponse converts a ProjectTemplate model to a response DTO
+func ToProjectTemplateResponse(template *models.ProjectTemplate) *ProjectTemplateResponse {
+	if template == nil {
+		return nil
+	}
+
+	return &ProjectTemplateResponse{
+		ID:                  template.ID,
+		TenantID:            template.TenantID,
+		Name:                template.Name,
+		Description:         template.Description,
+		Category:            template.Category,
+		IsActive:            template.IsActive,
+		DefaultDurationDays: template.DefaultDurationDays,
+		PlannedDurationDays: template.PlannedDurationDays(),
+		DefaultBudget:       template.DefaultBudget,
+		Currency:            template.Currency,
+		HourlyRate:          template.HourlyRate,
+		Priority:            template.Priority,
+		Tags:                template.Tags,
+		Milestones:          template.Milestones,
+		Tasks:               template.Tasks,
+		UsageCount:          template.UsageCount,
+		LastUsedAt:          template.LastUsedAt,
+		CreatedAt:           template.CreatedAt,
+		UpdatedAt:           template.UpdatedAt,
+	}
+}
+
+// ToProjectTemplateResponses converts multiple ProjectTemplate models to response DTOs
+func ToProjectTemplateResponses(templates []*models.ProjectTemplate) []*ProjectTemplateResponse {
+	responses := make([]*ProjectTemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = ToProjectTemplateResponse(template)
+	}
+	return responses
+}
+
+// ToTemplateProjectResponse converts an instantiated template to a response DTO
+func ToTemplateProjectResponse(instance *models.TemplateProject) *TemplateProjectResponse {
+	if instance == nil {
+		return nil
+	}
+
+	return &TemplateProjectResponse{
+		Project:    ToProjectResponse(instance.Project),
+		Milestones: ToMilestoneResponses(instance.Milestones),
+		Tasks:      ToTaskResponses(instance.Tasks),
+	}
+}
@@ -0,0 +1,235 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Quote Request DTOs
+// ============================================================================
+
+// CreateQuoteRequest represents a request to draft a quote for a customer
+type CreateQuoteRequest struct {
+	ArtisanID          uuid.UUID                `json:"artisan_id" validate:"required"`
+	CustomerID         uuid.UUID                `json:"customer_id" validate:"required"`
+	Title              string                   `json:"title" validate:"required,min=3,max=255"`
+	Description        string                   `json:"description,omitempty"`
+	LineItems          []models.InvoiceLineItem `json:"line_items" validate:"required,min=1"` // Line totals are computed
+	DiscountAmount     float64                  `json:"discount_amount,omitempty" validate:"omitempty,min=0"`
+	TaxAmount          float64                  `json:"tax_amount,omitempty" validate:"omitempty,min=0"`
+	Currency           string                   `json:"currency,omitempty" validate:"omitempty,len=3"` // Defaults to USD
+	DepositPercent     float64                  `json:"deposit_percent,omitempty" validate:"omitempty,min=0,max=100"`
+	ValidUntil         time.Time                `json:"valid_until" validate:"required"`
+	EstimatedStartDate *time.Time               `json:"estimated_start_date,omitempty"`
+	EstimatedDueDate   *time.Time               `json:"estimated_due_date,omitempty"`
+	Notes              string                   `json:"notes,omitempty"`
+	TermsConditions    string                   `json:"terms_conditions,omitempty"`
+}
+
+// Validate validates the create quote request
+func (r *CreateQuoteRequest) Validate() error {
+	if r.ArtisanID == uuid.Nil {
+		return fmt.Errorf("artisan_id is required")
+	}
+	if r.CustomerID == uuid.Nil {
+		return fmt.Errorf("customer_id is required")
+	}
+	if r.ValidUntil.IsZero() {
+		return fmt.Errorf("valid_until is required")
+	}
+	return nil
+}
+
+// UpdateQuoteRequest represents a request to edit a quote. Editing a quote the
+// customer has already seen starts a new revision that has to be sent again.
+type UpdateQuoteRequest struct {
+	Title              *string                  `json:"title,omitempty" validate:"omitempty,min=3,max=255"`
+	Description        *string                  `json:"description,omitempty"`
+	LineItems          []models.InvoiceLineItem `json:"line_items,omitempty"`
+	DiscountAmount     *float64                 `json:"discount_amount,omitempty" validate:"omitempty,min=0"`
+	TaxAmount          *float64                 `json:"tax_amount,omitempty" validate:"omitempty,min=0"`
+	Currency           *string                  `json:"currency,omitempty" validate:"omitempty,len=3"`
+	DepositPercent     *float64                 `json:"deposit_percent,omitempty" validate:"omitempty,min=0,max=100"`
+	ValidUntil         *time.Time               `json:"valid_until,omitempty"`
+	EstimatedStartDate *time.Time               `json:"estimated_start_date,omitempty"`
+	EstimatedDueDate   *time.Time               `json:"estimated_due_date,omitempty"`
+	Notes              *string                  `json:"notes,omitempty"`
+	TermsConditions    *string                  `json:"terms_conditions,omitempty"`
+}
+
+// AcceptQuoteRequest represents a customer's acceptance of a quote
+type AcceptQuoteRequest struct {
+	Revision int `json:"revision,omitempty"` // The revision the customer reviewed; rejected if the quote changed since
+}
+
+// DeclineQuoteRequest represents a customer's refusal of a quote
+type DeclineQuoteRequest struct {
+	Revision int    `json:"revision,omitempty"` // The revision the customer reviewed; rejected if the quote changed since
+	Reason   string `json:"reason,omitempty" validate:"omitempty,max=1000"`
+}
+
+// QuoteFilter represents filters for quote queries
+type QuoteFilter struct {
+	TenantID   uuid.UUID            `json:"tenant_id"`
+	ArtisanID  *uuid.UUID           `json:"artisan_id,omitempty"`
+	CustomerID *uuid.UUID           `json:"customer_id,omitempty"`
+	Statuses   []models.QuoteStatus `json:"statuses,omitempty"`
+	Page       int                  `json:"page"`
+	PageSize   int                  `json:"page_size"`
+}
+
+// ============================================================================
+// Quote Response DTOs
+// ============================================================================
+
+// QuoteResponse represents a quote
+type QuoteResponse struct {
+	ID                 uuid.UUID               `json:"id"`
+	TenantID           uuid.UUID               `json:"tenant_id"`
+	ArtisanID          uuid.UUID               `json:"artisan_id"`
+	CustomerID         uuid.UUID               `json:"customer_id"`
+	Title              string                  `json:"title"`
+	Description        string                  `json:"description,omitempty"`
+	Status             models.QuoteStatus      `json:"status"`
+	Revision           int                     `json:"revision"`
+	LineItems          models.InvoiceLineItems `json:"line_items"`
+	SubtotalAmount     float64                 `json:"subtotal_amount"`
+	DiscountAmount     float64                 `json:"discount_amount"`
+	TaxAmount          float64                 `json:"tax_amount"`
+	TotalAmount        float64                 `json:"total_amount"`
+	Currency           string                  `json:"currency"`
+	DepositPercent     float64                 `json:"deposit_percent"`
+	DepositAmount      float64                 `json:"deposit_amount"`
+	ValidUntil         time.Time               `json:"valid_until"`
+	IsExpired          bool                    `json:"is_expired"`
+	EstimatedStartDate *time.Time              `json:"estimated_start_date,omitempty"`
+	EstimatedDueDate   *time.Time              `json:"estimated_due_date,omitempty"`
+	Notes              string                  `json:"notes,omitempty"`
+	TermsConditions    string                  `json:"terms_conditions,omitempty"`
+	SentAt             *time.Time              `json:"sent_at,omitempty"`
+	RespondedAt        *time.Time              `json:"responded_at,omitempty"`
+	AcceptedRevision   int                     `json:"accepted_revision,omitempty"`
+	DeclineReason      string                  `json:"decline_reason,omitempty"`
+	ProjectID          *uuid.UUID              `json:"project_id,omitempty"`
+	DepositInvoiceID   *uuid.UUID              `json:"deposit_invoice_id,omitempty"`
+	CreatedAt          time.Time               `json:"created_at"`
+	UpdatedAt          time.Time               `json:"updated_at"`
+}
+
+// QuoteListResponse represents a paginated list of quotes
+type QuoteListResponse struct {
+	Quotes      []*QuoteResponse `json:"quotes"`
+	Page        int              `json:"page"`
+	PageSize    int              `json:"page_size"`
+	TotalItems  int64            `json:"total_items"`
+	TotalPages  int              `json:"total_pages"`
+	HasNext     bool             `json:"has_next"`
+	HasPrevious bool             `json:"has_previous"`
+}
+
+// QuoteRevisionResponse represents a quote as it was sent to the customer
+type QuoteRevisionResponse struct {
+	Revision        int                     `json:"revision"`
+	Title           string                  `json:"title"`
+	Description     string                  `json:"description,omitempty"`
+	LineItems       models.InvoiceLineItems `json:"line_items"`
+	SubtotalAmount  float64                 `json:"subtotal_amount"`
+	DiscountAmount  float64                 `json:"discount_amount"`
+	TaxAmount       float64                 `json:"tax_amount"`
+	TotalAmount     float64                 `json:"total_amount"`
+	Currency        string                  `json:"currency"`
+	DepositPercent  float64                 `json:"deposit_percent"`
+	ValidUntil      time.Time               `json:"valid_until"`
+	Notes           string                  `json:"notes,omitempty"`
+	TermsConditions string                  `json:"terms_conditions,omitempty"`
+	SentAt          time.Time               `json:"sent_at"`
+}
+
+// QuoteAcceptanceResponse represents the outcome of accepting a quote
+type QuoteAcceptanceResponse struct {
+	Quote          *QuoteResponse   `json:"quote"`
+	Project        *ProjectResponse `json:"project"`
+	DepositInvoice *InvoiceResponse `json:"deposit_invoice,omitempty"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToQuoteResponse converts a Quote model to a response DTO
+func ToQuoteResponse(quote *models.Quote) *QuoteResponse {
+	if quote == nil {
+		return nil
+	}
+
+	return &QuoteResponse{
+		ID:                 quote.ID,
+		TenantID:           quote.TenantID,
+		ArtisanID:          quote.ArtisanID,
+		CustomerID:         quote.CustomerID,
+		Title:              quote.Title,
+		Description:        quote.Description,
+		Status:             quote.Status,
+		Revision:           quote.Revision,
+		LineItems:          quote.LineItems,
+		SubtotalAmount:     quote.SubtotalAmount,
+		DiscountAmount:     quote.DiscountAmount,
+		TaxAmount:          quote.TaxAmount,
+		TotalAmount:        quote.TotalAmount,
+		Currency:           quote.Currency,
+		DepositPercent:     quote.DepositPercent,
+		DepositAmount:      quote.DepositAmount(),
+		ValidUntil:         quote.ValidUntil,
+		IsExpired:          quote.Status == models.QuoteStatusExpired || quote.IsExpired(time.Now()),
+		EstimatedStartDate: quote.EstimatedStartDate,
+		EstimatedDueDate:   quote.EstimatedDueDate,
+		Notes:              quote.Notes,
+		TermsConditions:    quote.TermsConditions,
+		SentAt:             quote.SentAt,
+		RespondedAt:        quote.RespondedAt,
+		AcceptedRevision:   quote.AcceptedRevision,
+		DeclineReason:      quote.DeclineReason,
+		ProjectID:          quote.ProjectID,
+		DepositInvoiceID:   quote.DepositInvoiceID,
+		CreatedAt:          quote.CreatedAt,
+		UpdatedAt:          quote.UpdatedAt,
+	}
+}
+
+// ToQuoteResponses converts multiple Quote models to response DTOs
+func ToQuoteResponses(quotes []*models.Quote) []*QuoteResponse {
+	responses := make([]*QuoteResponse, len(quotes))
+	for i, quote := range quotes {
+		responses[i] = ToQuoteResponse(quote)
+	}
+	return responses
+}
+
+// ToQuoteRevisionResponses converts quote revisions to response DTOs
+func ToQuoteRevisionResponses(revisions []*models.QuoteRevision) []*QuoteRevisionResponse {
+	responses := make([]*QuoteRevisionResponse, len(revisions))
+	for i, rev := range revisions {
+		responses[i] = &QuoteRevisionResponse{
+			Revision:        rev.Revision,
+			Title:           rev.Title,
+			Description:     rev.Description,
+			LineItems:       rev.LineItems,
+			SubtotalAmount:  rev.SubtotalAmount,
+			DiscountAmount:  rev.DiscountAmount,
+			TaxAmount:       rev.TaxAmount,
+			TotalAmount:     rev.TotalAmount,
+			Currency:        rev.Currency,
+			DepositPercent:  rev.DepositPercent,
+			ValidUntil:      rev.ValidUntil,
+			Notes:           rev.Notes,
+			TermsConditions: rev.TermsConditions,
+			SentAt:          rev.SentAt,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+)
+
+// ReconciliationDayResponse is a day's payment reconciliation across providers
+type ReconciliationDayResponse struct {
+	Date             string                         `json:"date"`
+	MatchedCount     int                            `json:"matched_count"`
+	DiscrepancyCount int                            `json:"discrepancy_count"`
+	FailedProviders  []string                       `json:"failed_providers,omitempty"`
+	Reports          []*models.ReconciliationReport `json:"reports"`
+}
+
+// ToReconciliationDayResponse totals a day's reports
+func ToReconciliationDayResponse(date time.Time, reports []*models.ReconciliationReport) *ReconciliationDayResponse {
+	response := &ReconciliationDayResponse{
+		Date:    date.Format(time.DateOnly),
+		Reports: reports,
+	}
+	for _, report := range reports {
+		response.MatchedCount += report.MatchedCount
+		response.DiscrepancyCount += report.DiscrepancyCount
+		if report.Status == models.ReconciliationFailed {
+			response.FailedProviders = append(response.FailedProviders, report.Provider)
+		}
+	}
+	return response
+}
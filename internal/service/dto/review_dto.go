@@ -1,7 +1,10 @@
 package dto
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"Krafti_Vibe/internal/domain/models"
 
@@ -44,6 +47,59 @@ type RespondToReviewRequest struct {
 	ResponseText string `json:"response_text" validate:"required"`
 }
 
+// CreateReviewReplyRequest represents a reply in the thread under a review
+type CreateReviewReplyRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+// Validate validates the reply
+func (r *CreateReviewReplyRequest) Validate() error {
+	r.Body = strings.TrimSpace(r.Body)
+	if r.Body == "" {
+		return fmt.Errorf("body is required")
+	}
+	if utf8.RuneCountInString(r.Body) > 2000 {
+		return fmt.Errorf("body cannot exceed 2000 characters")
+	}
+	return nil
+}
+
+// RejectReviewRequest represents a moderator's rejection of a review
+type RejectReviewRequest struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+// FlagReviewRequest represents a report or dispute raised against a review
+type FlagReviewRequest struct {
+	Kind   models.ReviewFlagKind `json:"kind" validate:"required,oneof=report dispute"`
+	Reason string                `json:"reason" validate:"required,max=2000"`
+}
+
+// Validate validates the flag
+func (r *FlagReviewRequest) Validate() error {
+	if r.Kind == "" {
+		r.Kind = models.ReviewFlagKindReport
+	}
+	if !r.Kind.IsValid() {
+		return fmt.Errorf("kind must be report or dispute")
+	}
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if utf8.RuneCountInString(r.Reason) > 2000 {
+		return fmt.Errorf("reason cannot exceed 2000 characters")
+	}
+	return nil
+}
+
+// ResolveReviewFlagRequest represents a moderator's decision on a flag; upholding it
+// rejects the review
+type ResolveReviewFlagRequest struct {
+	Uphold bool   `json:"uphold"`
+	Note   string `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
 // ReviewFilter represents filters for review queries
 type ReviewFilter struct {
 	TenantID   uuid.UUID  `json:"tenant_id" validate:"required"`
@@ -92,6 +148,13 @@ type ReviewDetailResponse struct {
 	HasResponse           bool                  `json:"has_response"`
 	CreatedAt             time.Time             `json:"created_at"`
 	UpdatedAt             time.Time             `json:"updated_at"`
+
+	// Moderation
+	ModerationStatus  models.ReviewModerationStatus `json:"moderation_status"`
+	HoldReason        models.ReviewHoldReason       `json:"hold_reason,omitempty"`
+	RejectionReason   string                        `json:"rejection_reason,omitempty"`
+	ContainsProfanity bool                          `json:"contains_profanity"`
+	ModeratedAt       *time.Time                    `json:"moderated_at,omitempty"`
 }
 
 // ReviewListResponse represents a paginated list of reviews
@@ -105,6 +168,44 @@ type ReviewListResponse struct {
 	HasPrevious bool                    `json:"has_previous"`
 }
 
+// ReviewReplyResponse represents a reply in the thread under a review
+type ReviewReplyResponse struct {
+	ID         uuid.UUID                    `json:"id"`
+	ReviewID   uuid.UUID                    `json:"review_id"`
+	AuthorID   uuid.UUID                    `json:"author_id"`
+	AuthorRole models.ReviewReplyAuthorRole `json:"author_role"`
+	Author     *UserSummary                 `json:"author,omitempty"`
+	Body       string                       `json:"body"`
+	CreatedAt  time.Time                    `json:"created_at"`
+}
+
+// ReviewFlagResponse represents a report or dispute raised against a review
+type ReviewFlagResponse struct {
+	ID           uuid.UUID               `json:"id"`
+	TenantID     uuid.UUID               `json:"tenant_id"`
+	ReviewID     uuid.UUID               `json:"review_id"`
+	ReporterID   uuid.UUID               `json:"reporter_id"`
+	Kind         models.ReviewFlagKind   `json:"kind"`
+	Reason       string                  `json:"reason"`
+	Status       models.ReviewFlagStatus `json:"status"`
+	ResolvedByID *uuid.UUID              `json:"resolved_by_id,omitempty"`
+	ResolvedAt   *time.Time              `json:"resolved_at,omitempty"`
+	Resolution   string                  `json:"resolution,omitempty"`
+	Review       *ReviewDetailResponse   `json:"review,omitempty"`
+	CreatedAt    time.Time               `json:"created_at"`
+}
+
+// ReviewFlagListResponse represents a paginated list of review flags
+type ReviewFlagListResponse struct {
+	Flags       []*ReviewFlagResponse `json:"flags"`
+	Page        int                   `json:"page"`
+	PageSize    int                   `json:"page_size"`
+	TotalItems  int64                 `json:"total_items"`
+	TotalPages  int                   `json:"total_pages"`
+	HasNext     bool                  `json:"has_next"`
+	HasPrevious bool                  `json:"has_previous"`
+}
+
 // ReviewStatsResponse represents review statistics
 type ReviewStatsResponse struct {
 	ArtisanID          uuid.UUID     `json:"artisan_id,omitempty"`
@@ -161,6 +262,11 @@ func ToReviewDetailResponse(review *models.Review) *ReviewDetailResponse {
 		HasResponse:           review.HasResponse(),
 		CreatedAt:             review.CreatedAt,
 		UpdatedAt:             review.UpdatedAt,
+		ModerationStatus:      review.ModerationStatus(),
+		HoldReason:            review.HoldReason,
+		RejectionReason:       review.RejectionReason,
+		ContainsProfanity:     review.ContainsProfanity,
+		ModeratedAt:           review.ModeratedAt,
 	}
 
 	// Add artisan if available
@@ -209,3 +315,58 @@ func ToReviewDetailResponses(reviews []*models.Review) []*ReviewDetailResponse {
 	}
 	return responses
 }
+
+// ToReviewReplyResponses converts review replies to DTOs
+func ToReviewReplyResponses(replies []*models.ReviewReply) []*ReviewReplyResponse {
+	responses := make([]*ReviewReplyResponse, len(replies))
+	for i, reply := range replies {
+		responses[i] = &ReviewReplyResponse{
+			ID:         reply.ID,
+			ReviewID:   reply.ReviewID,
+			AuthorID:   reply.AuthorID,
+			AuthorRole: reply.AuthorRole,
+			Body:       reply.Body,
+			CreatedAt:  reply.CreatedAt,
+		}
+		if reply.Author != nil {
+			responses[i].Author = &UserSummary{
+				ID:        reply.Author.ID,
+				FirstName: reply.Author.FirstName,
+				LastName:  reply.Author.LastName,
+				AvatarURL: reply.Author.AvatarURL,
+			}
+		}
+	}
+	return responses
+}
+
+// ToReviewFlagResponse converts a ReviewFlag model to a DTO
+func ToReviewFlagResponse(flag *models.ReviewFlag) *ReviewFlagResponse {
+	if flag == nil {
+		return nil
+	}
+
+	return &ReviewFlagResponse{
+		ID:           flag.ID,
+		TenantID:     flag.TenantID,
+		ReviewID:     flag.ReviewID,
+		ReporterID:   flag.ReporterID,
+		Kind:         flag.Kind,
+		Reason:       flag.Reason,
+		Status:       flag.Status,
+		ResolvedByID: flag.ResolvedByID,
+		ResolvedAt:   flag.ResolvedAt,
+		Resolution:   flag.Resolution,
+		Review:       ToReviewDetailResponse(flag.Review),
+		CreatedAt:    flag.CreatedAt,
+	}
+}
+
+// ToReviewFlagResponses converts multiple ReviewFlag models to DTOs
+func ToReviewFlagResponses(flags []*models.ReviewFlag) []*ReviewFlagResponse {
+	responses := make([]*ReviewFlagResponse, len(flags))
+	for i, flag := range flags {
+		responses[i] = ToReviewFlagResponse(flag)
+	}
+	return responses
+}
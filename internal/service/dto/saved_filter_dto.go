@@ -0,0 +1,217 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Saved Filter Request DTOs
+// ============================================================================
+
+// CreateSavedFilterRequest saves a bookings list view for the requesting user
+type CreateSavedFilterRequest struct {
+	TenantID  uuid.UUID                   `json:"-"`
+	UserID    uuid.UUID                   `json:"-"`
+	Name      string                      `json:"name" validate:"required,max=100"`
+	Criteria  BookingFilter               `json:"criteria"`             // Pagination, sorting and tenant are not saved
+	DateRange models.SavedFilterDateRange `json:"date_range,omitempty"` // Relative window on the booking start time
+	TimeZone  string                      `json:"time_zone,omitempty"`  // IANA zone the date range is resolved in; defaults to UTC
+}
+
+// Validate validates the create saved filter request
+func (r *CreateSavedFilterRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if r.UserID == uuid.Nil {
+		return fmt.Errorf("user ID is required")
+	}
+	return validateSavedFilter(r.Name, &r.Criteria, r.DateRange, r.TimeZone)
+}
+
+// UpdateSavedFilterRequest changes a saved filter; Criteria replaces the saved criteria when set
+type UpdateSavedFilterRequest struct {
+	Name      *string                      `json:"name,omitempty"`
+	Criteria  *BookingFilter               `json:"criteria,omitempty"`
+	DateRange *models.SavedFilterDateRange `json:"date_range,omitempty"` // Empty string removes the date range
+	TimeZone  *string                      `json:"time_zone,omitempty"`
+}
+
+// Apply validates the changes and applies them to the saved filter
+func (r *UpdateSavedFilterRequest) Apply(filter *models.SavedFilter) error {
+	name, dateRange, timeZone := filter.Name, filter.DateRange, filter.TimeZone
+	if r.Name != nil {
+		name = *r.Name
+	}
+	if r.DateRange != nil {
+		dateRange = *r.DateRange
+	}
+	if r.TimeZone != nil {
+		timeZone = *r.TimeZone
+	}
+
+	criteria := r.Criteria
+	if criteria == nil {
+		saved, err := BookingFilterFromCriteria(filter.Criteria)
+		if err != nil {
+			return err
+		}
+		criteria = &saved
+	}
+	if err := validateSavedFilter(name, criteria, dateRange, timeZone); err != nil {
+		return err
+	}
+
+	filter.Name = strings.TrimSpace(name)
+	filter.DateRange = dateRange
+	filter.TimeZone = timeZone
+	if r.Criteria != nil {
+		filter.Criteria = criteria.SavedCriteria()
+	}
+	return nil
+}
+
+func validateSavedFilter(name string, criteria *BookingFilter, dateRange models.SavedFilterDateRange, timeZone string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(name) > 100 {
+		return fmt.Errorf("name must be 100 characters or less")
+	}
+	if dateRange != "" {
+		if !dateRange.IsValid() {
+			return fmt.Errorf("invalid date range: %s", dateRange)
+		}
+		if criteria.StartDate != nil || criteria.EndDate != nil {
+			return fmt.Errorf("date range cannot be combined with a fixed start or end date")
+		}
+	}
+	if timeZone != "" {
+		if _, err := time.LoadLocation(timeZone); err != nil {
+			return fmt.Errorf("invalid time zone: %s", timeZone)
+		}
+	}
+	if len(criteria.IncludeRelations) > 0 || criteria.SortBy != "" {
+		return fmt.Errorf("criteria cannot include relations or sorting")
+	}
+	if err := criteria.Validate(); err != nil {
+		return fmt.Errorf("invalid criteria: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// Saved Filter Response DTOs
+// ============================================================================
+
+// SavedFilterResponse represents a saved filter
+type SavedFilterResponse struct {
+	ID        uuid.UUID                   `json:"id"`
+	Name      string                      `json:"name"`
+	Resource  models.SavedFilterResource  `json:"resource"`
+	Criteria  models.JSONB                `json:"criteria"`
+	DateRange models.SavedFilterDateRange `json:"date_range,omitempty"`
+	TimeZone  string                      `json:"time_zone,omitempty"`
+	CreatedAt time.Time                   `json:"created_at"`
+	UpdatedAt time.Time                   `json:"updated_at"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// savedCriteriaOmit lists BookingFilter fields that belong to the request, not the view
+var savedCriteriaOmit = []string{"tenant_id", "page", "page_size", "cursor", "sort_by", "sort_order", "include_relations"}
+
+// SavedCriteria returns the filter's criteria as stored on a saved filter
+func (f BookingFilter) SavedCriteria() models.JSONB {
+	criteria := f.criteriaMap()
+	for _, key := range savedCriteriaOmit {
+		delete(criteria, key)
+	}
+	return criteria
+}
+
+// MergeSavedCriteria overlays the filter on saved criteria: every field set on the
+// filter, plus its pagination and sorting, wins over the saved value
+func (f BookingFilter) MergeSavedCriteria(saved models.JSONB) (BookingFilter, error) {
+	merged := models.JSONB{}
+	for key, value := range saved {
+		merged[key] = value
+	}
+	for key, value := range f.criteriaMap() {
+		merged[key] = value
+	}
+
+	result, err := BookingFilterFromCriteria(merged)
+	if err != nil {
+		return f, err
+	}
+	result.SavedFilterID = f.SavedFilterID
+	result.UserID = f.UserID
+	return result, nil
+}
+
+// BookingFilterFromCriteria decodes saved criteria into a BookingFilter
+func BookingFilterFromCriteria(criteria models.JSONB) (BookingFilter, error) {
+	var filter BookingFilter
+	data, err := json.Marshal(criteria)
+	if err != nil {
+		return filter, fmt.Errorf("invalid saved criteria: %w", err)
+	}
+	if err := json.Unmarshal(data, &filter); err != nil {
+		return filter, fmt.Errorf("invalid saved criteria: %w", err)
+	}
+	return filter, nil
+}
+
+// criteriaMap returns the filter's set fields keyed by their JSON names
+func (f BookingFilter) criteriaMap() models.JSONB {
+	criteria := models.JSONB{}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return criteria
+	}
+	_ = json.Unmarshal(data, &criteria)
+	return criteria
+}
+
+// ToSavedFilterResponse converts a SavedFilter model to a response DTO
+func ToSavedFilterResponse(filter *models.SavedFilter) *SavedFilterResponse {
+	if filter == nil {
+		return nil
+	}
+
+	criteria := filter.Criteria
+	if criteria == nil {
+		criteria = models.JSONB{}
+	}
+
+	return &SavedFilterResponse{
+		ID:        filter.ID,
+		Name:      filter.Name,
+		Resource:  filter.Resource,
+		Criteria:  criteria,
+		DateRange: filter.DateRange,
+		TimeZone:  filter.TimeZone,
+		CreatedAt: filter.CreatedAt,
+		UpdatedAt: filter.UpdatedAt,
+	}
+}
+
+// ToSavedFilterResponses converts a slice of SavedFilter models to response DTOs
+func ToSavedFilterResponses(filters []*models.SavedFilter) []*SavedFilterResponse {
+	responses := make([]*SavedFilterResponse, len(filters))
+	for i, filter := range filters {
+		responses[i] = ToSavedFilterResponse(filter)
+	}
+	return responses
+}
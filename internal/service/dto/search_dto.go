@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SearchType names the kinds of records a search can return
+type SearchType string
+
+const (
+	SearchTypeBooking  SearchType = "booking"
+	SearchTypeCustomer SearchType = "customer"
+	SearchTypeService  SearchType = "service"
+	SearchTypeProject  SearchType = "project"
+)
+
+// IsValid checks if the search type is valid
+func (t SearchType) IsValid() bool {
+	switch t {
+	case SearchTypeBooking, SearchTypeCustomer, SearchTypeService, SearchTypeProject:
+		return true
+	}
+	return false
+}
+
+// ============================================================================
+// Search Request DTOs
+// ============================================================================
+
+// SearchRequest searches a tenant's bookings, customers, services and projects
+type SearchRequest struct {
+	TenantID uuid.UUID    `json:"-"`
+	Query    string       `json:"q"`
+	Types    []SearchType `json:"types,omitempty"` // Empty searches every type
+	Limit    int          `json:"limit"`
+}
+
+// Validate validates the search request, searching for 20 hits when no limit is given
+func (r *SearchRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	r.Query = strings.TrimSpace(r.Query)
+	if len([]rune(r.Query)) < 2 {
+		return fmt.Errorf("query must be at least 2 characters")
+	}
+	for _, t := range r.Types {
+		if !t.IsValid() {
+			return fmt.Errorf("invalid search type: %s", t)
+		}
+	}
+	if r.Limit <= 0 {
+		r.Limit = 20
+	}
+	r.Limit = min(r.Limit, 50)
+	return nil
+}
+
+// ============================================================================
+// Search Response DTOs
+// ============================================================================
+
+// SearchHitResponse is one record matching a search
+type SearchHitResponse struct {
+	Type     SearchType `json:"type"`
+	ID       uuid.UUID  `json:"id"`
+	Title    string     `json:"title"`
+	Subtitle string     `json:"subtitle,omitempty"`
+	Date     *time.Time `json:"date,omitempty"` // When a booking starts or a project is due
+	Score    float64    `json:"score"`
+}
+
+// SearchResponse lists the records matching a search, the best matches first
+type SearchResponse struct {
+	Query string               `json:"query"`
+	Hits  []*SearchHitResponse `json:"hits"`
+}
@@ -0,0 +1,189 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Service Account Request DTOs
+// ============================================================================
+
+// CreateServiceAccountRequest represents a request to create a service account
+type CreateServiceAccountRequest struct {
+	TenantID    uuid.UUID `json:"-"` // Set from the auth context
+	CreatedByID uuid.UUID `json:"-"` // Set from the auth context
+	Name        string    `json:"name" validate:"required,max=100"`
+	Description string    `json:"description,omitempty" validate:"max=255"`
+	Scopes      []string  `json:"scopes" validate:"required,min=1"` // Actions granted, such as "bookings.read"
+}
+
+// Validate validates the create service account request
+func (r *CreateServiceAccountRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	return nil
+}
+
+// UpdateServiceAccountRequest represents a request to update a service account.
+// Scopes replaces the granted actions when set.
+type UpdateServiceAccountRequest struct {
+	Name        *string   `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description *string   `json:"description,omitempty" validate:"omitempty,max=255"`
+	Scopes      *[]string `json:"scopes,omitempty"`
+}
+
+// ============================================================================
+// Service Account Response DTOs
+// ============================================================================
+
+// ServiceAccountResponse represents a service account
+type ServiceAccountResponse struct {
+	ID              uuid.UUID                   `json:"id"`
+	TenantID        uuid.UUID                   `json:"tenant_id"`
+	Name            string                      `json:"name"`
+	Description     string                      `json:"description,omitempty"`
+	Scopes          []string                    `json:"scopes"`
+	Status          models.ServiceAccountStatus `json:"status"`
+	ClientID        string                      `json:"client_id,omitempty"`
+	CreatedByID     uuid.UUID                   `json:"created_by_id"`
+	SecretRotatedAt *time.Time                  `json:"secret_rotated_at,omitempty"`
+	LastUsedAt      *time.Time                  `json:"last_used_at,omitempty"`
+	RevokedAt       *time.Time                  `json:"revoked_at,omitempty"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+}
+
+// ServiceAccountCredentialsResponse represents a service account with the client
+// credentials it signs in with. The secret is only returned when it is generated.
+type ServiceAccountCredentialsResponse struct {
+	Account      *ServiceAccountResponse `json:"account"`
+	ClientID     string                  `json:"client_id"`
+	ClientSecret string                  `json:"client_secret"`
+}
+
+// ServiceAccountUsageResponse represents a service account's usage over a period, for
+// its dashboard
+type ServiceAccountUsageResponse struct {
+	ServiceAccountID uuid.UUID                   `json:"service_account_id"`
+	From             time.Time                   `json:"from"`
+	To               time.Time                   `json:"to"`
+	LastUsedAt       *time.Time                  `json:"last_used_at,omitempty"`
+	Totals           ServiceAccountUsageCounts   `json:"totals"`
+	Daily            []ServiceAccountUsageDay    `json:"daily"`
+	ByResource       []ServiceAccountUsageByKind `json:"by_resource"`
+}
+
+// ServiceAccountUsageCounts counts requests made by a service account
+type ServiceAccountUsageCounts struct {
+	Requests     int64 `json:"requests"`
+	Denied       int64 `json:"denied"`
+	ClientErrors int64 `json:"client_errors"`
+	ServerErrors int64 `json:"server_errors"`
+}
+
+// ServiceAccountUsageDay counts the requests of a day
+type ServiceAccountUsageDay struct {
+	Day string `json:"day"` // YYYY-MM-DD
+	ServiceAccountUsageCounts
+}
+
+// ServiceAccountUsageByKind counts the requests made to a kind of resource
+type ServiceAccountUsageByKind struct {
+	Resource string `json:"resource"`
+	ServiceAccountUsageCounts
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToServiceAccountResponse converts a ServiceAccount model to a response DTO
+func ToServiceAccountResponse(account *models.ServiceAccount) *ServiceAccountResponse {
+	if account == nil {
+		return nil
+	}
+
+	scopes := []string(account.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return &ServiceAccountResponse{
+		ID:              account.ID,
+		TenantID:        account.TenantID,
+		Name:            account.Name,
+		Description:     account.Description,
+		Scopes:          scopes,
+		Status:          account.Status,
+		ClientID:        account.ClientID,
+		CreatedByID:     account.CreatedByID,
+		SecretRotatedAt: account.SecretRotatedAt,
+		LastUsedAt:      account.LastUsedAt,
+		RevokedAt:       account.RevokedAt,
+		CreatedAt:       account.CreatedAt,
+		UpdatedAt:       account.UpdatedAt,
+	}
+}
+
+// ToServiceAccountResponses converts multiple ServiceAccount models to response DTOs
+func ToServiceAccountResponses(accounts []*models.ServiceAccount) []*ServiceAccountResponse {
+	responses := make([]*ServiceAccountResponse, len(accounts))
+	for i, account := range accounts {
+		responses[i] = ToServiceAccountResponse(account)
+	}
+	return responses
+}
+
+// ToServiceAccountUsageResponse sums an account's usage rows by day and by resource.
+// Days without requests are left out.
+func ToServiceAccountUsageResponse(account *models.ServiceAccount, from, to time.Time, usage []*models.ServiceAccountUsage) *ServiceAccountUsageResponse {
+	response := &ServiceAccountUsageResponse{
+		ServiceAccountID: account.ID,
+		From:             from,
+		To:               to,
+		LastUsedAt:       account.LastUsedAt,
+		Daily:            []ServiceAccountUsageDay{},
+		ByResource:       []ServiceAccountUsageByKind{},
+	}
+
+	days := map[string]int{}
+	resources := map[string]int{}
+	for _, row := range usage {
+		counts := ServiceAccountUsageCounts{
+			Requests:     row.Requests,
+			Denied:       row.Denied,
+			ClientErrors: row.ClientErrors,
+			ServerErrors: row.ServerErrors,
+		}
+		response.Totals.add(counts)
+
+		day := row.Day.Format(time.DateOnly)
+		if i, ok := days[day]; ok {
+			response.Daily[i].add(counts)
+		} else {
+			days[day] = len(response.Daily)
+			response.Daily = append(response.Daily, ServiceAccountUsageDay{Day: day, ServiceAccountUsageCounts: counts})
+		}
+
+		if i, ok := resources[row.Resource]; ok {
+			response.ByResource[i].add(counts)
+		} else {
+			resources[row.Resource] = len(response.ByResource)
+			response.ByResource = append(response.ByResource, ServiceAccountUsageByKind{Resource: row.Resource, ServiceAccountUsageCounts: counts})
+		}
+	}
+	return response
+}
+
+func (c *ServiceAccountUsageCounts) add(other ServiceAccountUsageCounts) {
+	c.Requests += other.Requests
+	c.Denied += other.Denied
+	c.ClientErrors += other.ClientErrors
+	c.ServerErrors += other.ServerErrors
+}
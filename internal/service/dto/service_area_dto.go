@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Service Area Request DTOs
+// ============================================================================
+
+// CreateServiceAreaRequest adds a region an artisan travels to for mobile services
+type CreateServiceAreaRequest struct {
+	TenantID  uuid.UUID         `json:"-"`
+	ArtisanID uuid.UUID         `json:"-"`
+	Name      string            `json:"name" validate:"required,max=100"`
+	Boundary  models.GeoPolygon `json:"boundary" validate:"required"` // GeoJSON Polygon of [longitude, latitude] positions
+	IsActive  *bool             `json:"is_active,omitempty"`          // Defaults to true
+}
+
+// Validate validates the create service area request
+func (r *CreateServiceAreaRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if r.ArtisanID == uuid.Nil {
+		return fmt.Errorf("artisan ID is required")
+	}
+	if err := validateServiceAreaName(r.Name); err != nil {
+		return err
+	}
+	return r.Boundary.Validate()
+}
+
+// UpdateServiceAreaRequest changes a service area; unset fields are left as they are
+type UpdateServiceAreaRequest struct {
+	Name     *string            `json:"name,omitempty"`
+	Boundary *models.GeoPolygon `json:"boundary,omitempty"`
+	IsActive *bool              `json:"is_active,omitempty"`
+}
+
+// Validate validates the update service area request
+func (r *UpdateServiceAreaRequest) Validate() error {
+	if r.Name != nil {
+		if err := validateServiceAreaName(*r.Name); err != nil {
+			return err
+		}
+	}
+	if r.Boundary != nil {
+		return r.Boundary.Validate()
+	}
+	return nil
+}
+
+func validateServiceAreaName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(name) > 100 {
+		return fmt.Errorf("name cannot exceed 100 characters")
+	}
+	return nil
+}
+
+// ============================================================================
+// Service Area Response DTOs
+// ============================================================================
+
+// ServiceAreaResponse is a region an artisan travels to
+type ServiceAreaResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	ArtisanID uuid.UUID         `json:"artisan_id"`
+	Name      string            `json:"name"`
+	Boundary  models.GeoPolygon `json:"boundary"`
+	IsActive  bool              `json:"is_active"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ToServiceAreaResponse converts a ServiceArea model to its response DTO
+func ToServiceAreaResponse(area *models.ServiceArea) *ServiceAreaResponse {
+	return &ServiceAreaResponse{
+		ID:        area.ID,
+		ArtisanID: area.ArtisanID,
+		Name:      area.Name,
+		Boundary:  area.Boundary,
+		IsActive:  area.IsActive,
+		CreatedAt: area.CreatedAt,
+		UpdatedAt: area.UpdatedAt,
+	}
+}
@@ -24,14 +24,21 @@ type CreateServiceRequest struct {
 	Price           float64                `json:"price" validate:"required,min=0"`
 	Currency        string                 `json:"currency" validate:"required,len=3"`
 	DepositAmount   float64                `json:"deposit_amount,omitempty"`
+	TaxCategory     models.TaxCategory     `json:"tax_category,omitempty"` // Defaults to standard
 	DurationMinutes int                    `json:"duration_minutes" validate:"required,min=5"`
 	BufferMinutes   int                    `json:"buffer_minutes" validate:"min=0"`
 	IsActive        bool                   `json:"is_active"`
 	MaxBookingsDay  int                    `json:"max_bookings_day" validate:"min=0"`
 	ImageURL        string                 `json:"image_url,omitempty"`
 	RequiresDeposit bool                   `json:"requires_deposit"`
+	IsMobile        bool                   `json:"is_mobile"` // Performed at the customer's location
 	Tags            []string               `json:"tags,omitempty"`
+	IntakeForm      *models.IntakeForm     `json:"intake_form,omitempty"`
 	Metadata        models.JSONB           `json:"metadata,omitempty"`
+
+	// Draft services are hidden from customers until published, at PublishAt when set
+	Status    models.ServiceStatus `json:"status,omitempty"` // draft or published (default)
+	PublishAt *time.Time           `json:"publish_at,omitempty"`
 }
 
 // Validate validates the create service request
@@ -51,6 +58,21 @@ func (r *CreateServiceRequest) Validate() error {
 	if r.Currency == "" {
 		r.Currency = "USD"
 	}
+	if r.TaxCategory == "" {
+		r.TaxCategory = models.TaxCategoryStandard
+	}
+	if !r.TaxCategory.IsValid() {
+		return fmt.Errorf("invalid tax category")
+	}
+	if r.Status == "" {
+		r.Status = models.ServiceStatusPublished
+	}
+	if r.Status != models.ServiceStatusDraft && r.Status != models.ServiceStatusPublished {
+		return fmt.Errorf("status must be draft or published")
+	}
+	if r.PublishAt != nil && r.Status != models.ServiceStatusDraft {
+		return fmt.Errorf("publish_at requires a draft service")
+	}
 	return nil
 }
 
@@ -62,13 +84,16 @@ type UpdateServiceRequest struct {
 	Price           *float64                `json:"price,omitempty"`
 	Currency        *string                 `json:"currency,omitempty"`
 	DepositAmount   *float64                `json:"deposit_amount,omitempty"`
+	TaxCategory     *models.TaxCategory     `json:"tax_category,omitempty"`
 	DurationMinutes *int                    `json:"duration_minutes,omitempty"`
 	BufferMinutes   *int                    `json:"buffer_minutes,omitempty"`
 	IsActive        *bool                   `json:"is_active,omitempty"`
 	MaxBookingsDay  *int                    `json:"max_bookings_day,omitempty"`
 	ImageURL        *string                 `json:"image_url,omitempty"`
 	RequiresDeposit *bool                   `json:"requires_deposit,omitempty"`
+	IsMobile        *bool                   `json:"is_mobile,omitempty"`
 	Tags            []string                `json:"tags,omitempty"`
+	IntakeForm      *models.IntakeForm      `json:"intake_form,omitempty"` // An empty field list removes the form
 	Metadata        models.JSONB            `json:"metadata,omitempty"`
 }
 
@@ -102,6 +127,7 @@ type ServiceFilterRequest struct {
 	MinDuration     *int                     `json:"min_duration,omitempty"`
 	MaxDuration     *int                     `json:"max_duration,omitempty"`
 	IsActive        *bool                    `json:"is_active,omitempty"`
+	Status          models.ServiceStatus     `json:"status,omitempty"`
 	ArtisanID       *uuid.UUID               `json:"artisan_id,omitempty"`
 	Tags            []string                 `json:"tags,omitempty"`
 	RequiresDeposit *bool                    `json:"requires_deposit,omitempty"`
@@ -111,6 +137,9 @@ type ServiceFilterRequest struct {
 
 // Validate validates the service filter request
 func (r *ServiceFilterRequest) Validate() error {
+	if r.Status != "" && !r.Status.IsValid() {
+		return fmt.Errorf("invalid status")
+	}
 	if r.Page < 1 {
 		r.Page = 1
 	}
@@ -136,6 +165,30 @@ func (r *BulkPriceUpdateRequest) Validate() error {
 	return nil
 }
 
+// SaveServiceDraftRequest holds changes to a published service, kept as its pending
+// changes until they are published, at PublishAt when set
+type SaveServiceDraftRequest struct {
+	models.ServiceChanges
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+}
+
+// Validate validates the save service draft request
+func (r *SaveServiceDraftRequest) Validate() error {
+	if err := r.ServiceChanges.Validate(); err != nil {
+		return err
+	}
+	if r.PublishAt != nil && !r.PublishAt.After(time.Now()) {
+		return fmt.Errorf("publish_at must be in the future")
+	}
+	return nil
+}
+
+// PublishServiceRequest publishes a draft service or a service's pending changes, now
+// or at PublishAt
+type PublishServiceRequest struct {
+	PublishAt *time.Time `json:"publish_at,omitempty"` // Publishes immediately when empty or past
+}
+
 // ============================================================================
 // Response DTOs
 // ============================================================================
@@ -152,6 +205,7 @@ type ServiceResponse struct {
 	Price           float64                `json:"price"`
 	Currency        string                 `json:"currency"`
 	DepositAmount   float64                `json:"deposit_amount"`
+	TaxCategory     models.TaxCategory     `json:"tax_category"`
 	DurationMinutes int                    `json:"duration_minutes"`
 	BufferMinutes   int                    `json:"buffer_minutes"`
 	TotalDuration   int                    `json:"total_duration"`
@@ -159,12 +213,44 @@ type ServiceResponse struct {
 	MaxBookingsDay  int                    `json:"max_bookings_day"`
 	ImageURL        string                 `json:"image_url,omitempty"`
 	RequiresDeposit bool                   `json:"requires_deposit"`
+	IsMobile        bool                   `json:"is_mobile"`
 	Tags            []string               `json:"tags,omitempty"`
+	IntakeForm      *models.IntakeForm     `json:"intake_form,omitempty"`
 	Metadata        models.JSONB           `json:"metadata,omitempty"`
+	Status          models.ServiceStatus   `json:"status"`
+	PublishAt       *time.Time             `json:"publish_at,omitempty"`
+	PublishedAt     *time.Time             `json:"published_at,omitempty"`
+	PendingChanges  *models.ServiceChanges `json:"pending_changes,omitempty"`
+	PriceVersionID  *uuid.UUID             `json:"price_version_id,omitempty"`
+	Rating          float64                `json:"rating"`
+	ReviewCount     int                    `json:"review_count"`
+	FavoriteCount   int                    `json:"favorite_count"`
 	CreatedAt       time.Time              `json:"created_at"`
 	UpdatedAt       time.Time              `json:"updated_at"`
 }
 
+// ServicePreviewResponse shows a service as publishing its draft would leave it
+type ServicePreviewResponse struct {
+	Current       *ServiceResponse `json:"current,omitempty"` // Nil while the service was never published
+	Proposed      *ServiceResponse `json:"proposed"`
+	ChangedFields []string         `json:"changed_fields"`
+	PriceChanged  bool             `json:"price_changed"` // Publishing starts a new price version
+	PublishAt     *time.Time       `json:"publish_at,omitempty"`
+}
+
+// ServicePriceVersionResponse represents a price a service was published at
+type ServicePriceVersionResponse struct {
+	ID            uuid.UUID          `json:"id"`
+	ServiceID     uuid.UUID          `json:"service_id"`
+	Number        int                `json:"number"`
+	Price         float64            `json:"price"`
+	Currency      string             `json:"currency"`
+	DepositAmount float64            `json:"deposit_amount"`
+	TaxCategory   models.TaxCategory `json:"tax_category"`
+	EffectiveFrom time.Time          `json:"effective_from"`
+	EffectiveTo   *time.Time         `json:"effective_to,omitempty"`
+}
+
 // ListServicesResponse represents a paginated list of services
 type ListServicesResponse struct {
 	Services   []*ServiceResponse `json:"services"`
@@ -176,14 +262,17 @@ type ListServicesResponse struct {
 
 // ServiceAddonResponse represents a service addon response
 type ServiceAddonResponse struct {
-	ID          uuid.UUID `json:"id"`
-	TenantID    uuid.UUID `json:"tenant_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	Price       float64   `json:"price"`
-	IsActive    bool      `json:"is_active"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID              uuid.UUID               `json:"id"`
+	TenantID        uuid.UUID               `json:"tenant_id"`
+	Name            string                  `json:"name"`
+	Description     string                  `json:"description,omitempty"`
+	Price           float64                 `json:"price"` // Percent of the service price for percentage pricing
+	PricingType     models.AddonPricingType `json:"pricing_type"`
+	DurationMinutes int                     `json:"duration_minutes"` // Added to the booking per unit
+	MaxQuantity     int                     `json:"max_quantity"`     // 0 means unlimited
+	IsActive        bool                    `json:"is_active"`
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
 }
 
 // ServiceStatistics represents service statistics
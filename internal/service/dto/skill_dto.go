@@ -0,0 +1,236 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Skill Request DTOs
+// ============================================================================
+
+// CreateSkillRequest adds a skill to the tenant's taxonomy
+type CreateSkillRequest struct {
+	TenantID    uuid.UUID              `json:"-"`
+	Category    models.ServiceCategory `json:"category" validate:"required"`
+	Name        string                 `json:"name" validate:"required,max=100"`
+	Description string                 `json:"description,omitempty"`
+	Keywords    []string               `json:"keywords,omitempty"`  // Other words customers use for it
+	IsActive    *bool                  `json:"is_active,omitempty"` // Defaults to true
+}
+
+// Validate validates the create skill request
+func (r *CreateSkillRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if strings.TrimSpace(string(r.Category)) == "" {
+		return fmt.Errorf("category is required")
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	return nil
+}
+
+// UpdateSkillRequest changes a skill; unset fields are left as they are
+type UpdateSkillRequest struct {
+	Category    *models.ServiceCategory `json:"category,omitempty"`
+	Name        *string                 `json:"name,omitempty"`
+	Description *string                 `json:"description,omitempty"`
+	Keywords    []string                `json:"keywords,omitempty"` // Replaces the keywords when set
+	IsActive    *bool                   `json:"is_active,omitempty"`
+}
+
+// ArtisanSkillInput tags an artisan with a skill
+type ArtisanSkillInput struct {
+	SkillID         uuid.UUID               `json:"skill_id" validate:"required"`
+	Proficiency     models.SkillProficiency `json:"proficiency" validate:"required"`
+	YearsExperience int                     `json:"years_experience,omitempty"`
+}
+
+// SetArtisanSkillsRequest replaces all of an artisan's skills
+type SetArtisanSkillsRequest struct {
+	Skills []ArtisanSkillInput `json:"skills"`
+}
+
+// Validate validates the set artisan skills request
+func (r *SetArtisanSkillsRequest) Validate() error {
+	if len(r.Skills) > models.MaxArtisanSkills {
+		return fmt.Errorf("an artisan can have at most %d skills", models.MaxArtisanSkills)
+	}
+	seen := make(map[uuid.UUID]bool, len(r.Skills))
+	for _, skill := range r.Skills {
+		if seen[skill.SkillID] {
+			return fmt.Errorf("skill %s is listed more than once", skill.SkillID)
+		}
+		seen[skill.SkillID] = true
+	}
+	return nil
+}
+
+// MatchArtisansRequest finds the artisans best suited to a service request
+type MatchArtisansRequest struct {
+	TenantID    uuid.UUID              `json:"-"`
+	Description string                 `json:"description" validate:"required,max=2000"` // What the customer needs, in their words
+	Category    models.ServiceCategory `json:"category,omitempty"`                       // Only match skills of the category
+	Latitude    *float64               `json:"latitude,omitempty"`
+	Longitude   *float64               `json:"longitude,omitempty"`
+	RadiusKm    float64                `json:"radius_km,omitempty"` // Defaults to 25 with a location
+	Date        *time.Time             `json:"date,omitempty"`      // Day the service is wanted on
+	Limit       int                    `json:"limit,omitempty"`     // Defaults to 10
+}
+
+// Validate validates the match artisans request, defaulting the radius and limit
+func (r *MatchArtisansRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	r.Description = strings.TrimSpace(r.Description)
+	if r.Description == "" {
+		return fmt.Errorf("description is required")
+	}
+	if len(r.Description) > 2000 {
+		return fmt.Errorf("description cannot exceed 2000 characters")
+	}
+	if (r.Latitude == nil) != (r.Longitude == nil) {
+		return fmt.Errorf("latitude and longitude must be given together")
+	}
+	if r.Latitude != nil {
+		if *r.Latitude < -90 || *r.Latitude > 90 {
+			return fmt.Errorf("latitude must be between -90 and 90")
+		}
+		if *r.Longitude < -180 || *r.Longitude > 180 {
+			return fmt.Errorf("longitude must be between -180 and 180")
+		}
+		if r.RadiusKm == 0 {
+			r.RadiusKm = 25
+		}
+		if r.RadiusKm < 0 || r.RadiusKm > 500 {
+			return fmt.Errorf("radius_km must be between 0 and 500")
+		}
+	} else {
+		r.RadiusKm = 0
+	}
+	if r.Limit <= 0 {
+		r.Limit = 10
+	}
+	r.Limit = min(r.Limit, 50)
+	return nil
+}
+
+// ============================================================================
+// Skill Response DTOs
+// ============================================================================
+
+// SkillResponse is an entry of the tenant's skill taxonomy
+type SkillResponse struct {
+	ID          uuid.UUID              `json:"id"`
+	Category    models.ServiceCategory `json:"category"`
+	Name        string                 `json:"name"`
+	Slug        string                 `json:"slug"`
+	Description string                 `json:"description,omitempty"`
+	Keywords    []string               `json:"keywords"`
+	IsActive    bool                   `json:"is_active"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// ArtisanSkillResponse is a skill an artisan is tagged with
+type ArtisanSkillResponse struct {
+	SkillID         uuid.UUID               `json:"skill_id"`
+	Name            string                  `json:"name,omitempty"`
+	Category        models.ServiceCategory  `json:"category,omitempty"`
+	Proficiency     models.SkillProficiency `json:"proficiency"`
+	YearsExperience int                     `json:"years_experience"`
+}
+
+// MatchedSkillResponse is a skill a service request mentions
+type MatchedSkillResponse struct {
+	SkillID   uuid.UUID `json:"skill_id"`
+	Name      string    `json:"name"`
+	Relevance float64   `json:"relevance"` // 1 when mentioned by name, less by a keyword
+}
+
+// ArtisanMatchBreakdown shows what an artisan's match score is made of, each part from 0 to 1
+type ArtisanMatchBreakdown struct {
+	Skills       float64  `json:"skills"`
+	Rating       float64  `json:"rating"`
+	Proximity    *float64 `json:"proximity,omitempty"` // Without a location to match near, left out
+	Availability float64  `json:"availability"`
+}
+
+// ArtisanMatchResponse is an artisan matched to a service request
+type ArtisanMatchResponse struct {
+	*ArtisanResponse
+	DistanceKm *float64                `json:"distance_km,omitempty"`
+	Score      float64                 `json:"score"`
+	Breakdown  ArtisanMatchBreakdown   `json:"breakdown"`
+	Skills     []*ArtisanSkillResponse `json:"matched_skills"` // The artisan's skills the request mentions
+}
+
+// MatchArtisansResponse is the artisans best suited to a service request, best first
+type MatchArtisansResponse struct {
+	MatchedSkills []*MatchedSkillResponse `json:"matched_skills"`
+	Artisans      []*ArtisanMatchResponse `json:"artisans"`
+}
+
+// ============================================================================
+// Skill Conversion Functions
+// ============================================================================
+
+// ToSkillResponse converts a Skill model to its response DTO
+func ToSkillResponse(skill *models.Skill) *SkillResponse {
+	keywords := []string(skill.Keywords)
+	if keywords == nil {
+		keywords = []string{}
+	}
+	return &SkillResponse{
+		ID:          skill.ID,
+		Category:    skill.Category,
+		Name:        skill.Name,
+		Slug:        skill.Slug,
+		Description: skill.Description,
+		Keywords:    keywords,
+		IsActive:    skill.IsActive,
+		CreatedAt:   skill.CreatedAt,
+		UpdatedAt:   skill.UpdatedAt,
+	}
+}
+
+// ToSkillResponses converts Skill models to response DTOs
+func ToSkillResponses(skills []*models.Skill) []*SkillResponse {
+	responses := make([]*SkillResponse, len(skills))
+	for i, skill := range skills {
+		responses[i] = ToSkillResponse(skill)
+	}
+	return responses
+}
+
+// ToArtisanSkillResponse converts an ArtisanSkill model to its response DTO
+func ToArtisanSkillResponse(skill *models.ArtisanSkill) *ArtisanSkillResponse {
+	resp := &ArtisanSkillResponse{
+		SkillID:         skill.SkillID,
+		Proficiency:     skill.Proficiency,
+		YearsExperience: skill.YearsExperience,
+	}
+	if skill.Skill != nil {
+		resp.Name = skill.Skill.Name
+		resp.Category = skill.Skill.Category
+	}
+	return resp
+}
+
+// ToArtisanSkillResponses converts ArtisanSkill models to response DTOs
+func ToArtisanSkillResponses(skills []*models.ArtisanSkill) []*ArtisanSkillResponse {
+	responses := make([]*ArtisanSkillResponse, len(skills))
+	for i, skill := range skills {
+		responses[i] = ToArtisanSkillResponse(skill)
+	}
+	return responses
+}
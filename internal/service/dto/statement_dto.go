@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Financial Statement Request DTOs
+// ============================================================================
+
+// StatementListRequest filters a tenant's financial statements
+type StatementListRequest struct {
+	TenantID  uuid.UUID            `json:"-"`
+	Kind      models.StatementKind `json:"kind,omitempty"`
+	SubjectID *uuid.UUID           `json:"subject_id,omitempty"` // Only this artisan's statements
+	Period    string               `json:"period,omitempty"`     // YYYY-MM
+}
+
+// Validate validates the statement list request
+func (r *StatementListRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if r.Kind != "" && !r.Kind.IsValid() {
+		return fmt.Errorf("kind must be artisan or tenant")
+	}
+	if r.Period != "" {
+		if _, err := ParseStatementPeriod(r.Period); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateStatementsRequest regenerates a tenant's statements for a month
+type GenerateStatementsRequest struct {
+	TenantID uuid.UUID `json:"-"`
+	Period   string    `json:"period" validate:"required"` // YYYY-MM
+}
+
+// ParseStatementPeriod parses a YYYY-MM month
+func ParseStatementPeriod(period string) (time.Time, error) {
+	start, err := time.Parse("2006-01", period)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("period must be a month as YYYY-MM")
+	}
+	return start, nil
+}
+
+// ============================================================================
+// Financial Statement Response DTOs
+// ============================================================================
+
+// FinancialStatementResponse represents a generated statement
+type FinancialStatementResponse struct {
+	ID          uuid.UUID              `json:"id"`
+	TenantID    uuid.UUID              `json:"tenant_id"`
+	Kind        models.StatementKind   `json:"kind"`
+	SubjectID   uuid.UUID              `json:"subject_id"`
+	Period      string                 `json:"period"` // YYYY-MM
+	PeriodStart time.Time              `json:"period_start"`
+	PeriodEnd   time.Time              `json:"period_end"`
+	Totals      models.StatementTotals `json:"totals"`
+	CSVSize     int64                  `json:"csv_size"`
+	PDFSize     int64                  `json:"pdf_size"`
+	GeneratedAt time.Time              `json:"generated_at"`
+}
+
+// StatementRunResponse summarises one statement generation run
+type StatementRunResponse struct {
+	Period     string `json:"period"`
+	Tenants    int    `json:"tenants"`
+	Statements int    `json:"statements"`
+	Failed     int    `json:"failed"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToFinancialStatementResponse converts a FinancialStatement model to a response DTO
+func ToFinancialStatementResponse(statement *models.FinancialStatement) *FinancialStatementResponse {
+	if statement == nil {
+		return nil
+	}
+
+	totals := statement.Totals
+	if totals == nil {
+		totals = models.StatementTotals{}
+	}
+
+	return &FinancialStatementResponse{
+		ID:          statement.ID,
+		TenantID:    statement.TenantID,
+		Kind:        statement.Kind,
+		SubjectID:   statement.SubjectID,
+		Period:      statement.PeriodStart.Format("2006-01"),
+		PeriodStart: statement.PeriodStart,
+		PeriodEnd:   statement.PeriodEnd,
+		Totals:      totals,
+		CSVSize:     statement.CSVSize,
+		PDFSize:     statement.PDFSize,
+		GeneratedAt: statement.GeneratedAt,
+	}
+}
+
+// ToFinancialStatementResponses converts multiple FinancialStatement models to response DTOs
+func ToFinancialStatementResponses(statements []*models.FinancialStatement) []*FinancialStatementResponse {
+	responses := make([]*FinancialStatementResponse, len(statements))
+	for i, statement := range statements {
+		responses[i] = ToFinancialStatementResponse(statement)
+	}
+	return responses
+}
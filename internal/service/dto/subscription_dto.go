@@ -15,6 +15,12 @@ import (
 // Request DTOs
 // ============================================================================
 
+// subscriptionPlans lists the plan codes tenants can be on
+var subscriptionPlans = []models.SubscriptionPlan{
+	models.PlanFree, models.PlanStarter, models.PlanPro,
+	models.PlanBusiness, models.PlanEnterprise,
+}
+
 // CreateSubscriptionRequest represents the request to create a subscription
 type CreateSubscriptionRequest struct {
 	TenantID        uuid.UUID               `json:"tenant_id" validate:"required"`
@@ -42,11 +48,7 @@ func (r *CreateSubscriptionRequest) Validate() error {
 	}
 
 	// Validate plan
-	validPlans := []models.SubscriptionPlan{
-		models.PlanFree, models.PlanStarter, models.PlanPro,
-		models.PlanBusiness, models.PlanEnterprise,
-	}
-	valid := slices.Contains(validPlans, r.Plan)
+	valid := slices.Contains(subscriptionPlans, r.Plan)
 	if !valid {
 		return fmt.Errorf("invalid subscription plan: %s", r.Plan)
 	}
@@ -209,6 +211,135 @@ func (r *UpdateUsageRequest) Validate() error {
 	return nil
 }
 
+// SubscribeRequest moves the tenant onto a plan, starting Stripe Billing for paid plans
+type SubscribeRequest struct {
+	Plan            models.SubscriptionPlan `json:"plan" validate:"required"`
+	BillingInterval models.BillingInterval  `json:"billing_interval,omitempty"` // monthly (default) or yearly
+	PaymentMethodID string                  `json:"payment_method_id,omitempty"`
+}
+
+// Validate validates the subscribe request
+func (r *SubscribeRequest) Validate() error {
+	if r.Plan == "" {
+		return fmt.Errorf("subscription plan is required")
+	}
+	if !slices.Contains(subscriptionPlans, r.Plan) {
+		return fmt.Errorf("invalid subscription plan: %s", r.Plan)
+	}
+	if r.BillingInterval == "" {
+		r.BillingInterval = models.BillingMonthly
+	}
+	if r.BillingInterval != models.BillingMonthly && r.BillingInterval != models.BillingYearly {
+		return fmt.Errorf("billing interval must be monthly or yearly")
+	}
+	return nil
+}
+
+// UpsertPlanRequest updates a platform plan; omitted fields keep their current value
+type UpsertPlanRequest struct {
+	Name                 *string                      `json:"name,omitempty"`
+	Description          *string                      `json:"description,omitempty"`
+	MonthlyPrice         *float64                     `json:"monthly_price,omitempty"`
+	YearlyPrice          *float64                     `json:"yearly_price,omitempty"`
+	Currency             *string                      `json:"currency,omitempty"`
+	StripeMonthlyPriceID *string                      `json:"stripe_monthly_price_id,omitempty"`
+	StripeYearlyPriceID  *string                      `json:"stripe_yearly_price_id,omitempty"`
+	MaxCustomers         *int                         `json:"max_customers,omitempty"`
+	MaxProjects          *int                         `json:"max_projects,omitempty"`
+	MaxStorageGB         *int                         `json:"max_storage_gb,omitempty"`
+	MaxArtisanSeats      *int                         `json:"max_artisan_seats,omitempty"`
+	MaxServicesListed    *int                         `json:"max_services_listed,omitempty"`
+	MaxBookingsPerMonth  *int                         `json:"max_bookings_per_month,omitempty"`
+	Features             *models.SubscriptionFeatures `json:"features,omitempty"`
+	TrialDays            *int                         `json:"trial_days,omitempty"`
+	GracePeriodDays      *int                         `json:"grace_period_days,omitempty"`
+	IsActive             *bool                        `json:"is_active,omitempty"`
+	SortOrder            *int                         `json:"sort_order,omitempty"`
+}
+
+// Validate validates the upsert plan request
+func (r *UpsertPlanRequest) Validate() error {
+	if r.Name != nil && strings.TrimSpace(*r.Name) == "" {
+		return fmt.Errorf("plan name cannot be empty")
+	}
+	if (r.MonthlyPrice != nil && *r.MonthlyPrice < 0) || (r.YearlyPrice != nil && *r.YearlyPrice < 0) {
+		return fmt.Errorf("plan prices cannot be negative")
+	}
+	if r.Currency != nil && len(*r.Currency) != 3 {
+		return fmt.Errorf("currency must be a 3-letter ISO code")
+	}
+	for _, limit := range []*int{r.MaxCustomers, r.MaxProjects, r.MaxStorageGB, r.MaxArtisanSeats, r.MaxServicesListed, r.MaxBookingsPerMonth} {
+		if limit != nil && *limit < -1 {
+			return fmt.Errorf("limits must be -1 (unlimited) or greater")
+		}
+	}
+	if r.TrialDays != nil && (*r.TrialDays < 0 || *r.TrialDays > 90) {
+		return fmt.Errorf("trial days must be between 0 and 90")
+	}
+	if r.GracePeriodDays != nil && (*r.GracePeriodDays < 0 || *r.GracePeriodDays > 60) {
+		return fmt.Errorf("grace period days must be between 0 and 60")
+	}
+	return nil
+}
+
+// Apply copies the request's fields onto plan
+func (r *UpsertPlanRequest) Apply(plan *models.Plan) {
+	if r.Name != nil {
+		plan.Name = strings.TrimSpace(*r.Name)
+	}
+	if r.Description != nil {
+		plan.Description = *r.Description
+	}
+	if r.MonthlyPrice != nil {
+		plan.MonthlyPrice = *r.MonthlyPrice
+	}
+	if r.YearlyPrice != nil {
+		plan.YearlyPrice = *r.YearlyPrice
+	}
+	if r.Currency != nil {
+		plan.Currency = strings.ToUpper(*r.Currency)
+	}
+	if r.StripeMonthlyPriceID != nil {
+		plan.StripeMonthlyPriceID = strings.TrimSpace(*r.StripeMonthlyPriceID)
+	}
+	if r.StripeYearlyPriceID != nil {
+		plan.StripeYearlyPriceID = strings.TrimSpace(*r.StripeYearlyPriceID)
+	}
+	if r.MaxCustomers != nil {
+		plan.MaxCustomers = *r.MaxCustomers
+	}
+	if r.MaxProjects != nil {
+		plan.MaxProjects = *r.MaxProjects
+	}
+	if r.MaxStorageGB != nil {
+		plan.MaxStorageGB = *r.MaxStorageGB
+	}
+	if r.MaxArtisanSeats != nil {
+		plan.MaxArtisanSeats = *r.MaxArtisanSeats
+	}
+	if r.MaxServicesListed != nil {
+		plan.MaxServicesListed = *r.MaxServicesListed
+	}
+	if r.MaxBookingsPerMonth != nil {
+		plan.MaxBookingsPerMonth = *r.MaxBookingsPerMonth
+	}
+	if r.Features != nil {
+		plan.Features = *r.Features
+	}
+	if r.TrialDays != nil {
+		plan.TrialDays = *r.TrialDays
+	}
+	if r.GracePeriodDays != nil {
+		plan.GracePeriodDays = *r.GracePeriodDays
+	}
+	if r.IsActive != nil {
+		plan.IsActive = *r.IsActive
+	}
+	if r.SortOrder != nil {
+		plan.SortOrder = *r.SortOrder
+	}
+}
+
 // ============================================================================
 // Filter DTOs
 // ============================================================================
@@ -314,6 +445,7 @@ type SubscriptionResponse struct {
 	CurrentPeriodEnd   time.Time  `json:"current_period_end"`
 	CanceledAt         *time.Time `json:"canceled_at,omitempty"`
 	CancelAtPeriodEnd  bool       `json:"cancel_at_period_end"`
+	GraceEndsAt        *time.Time `json:"grace_ends_at,omitempty"`
 
 	// Payment info
 	StripeSubscriptionID string `json:"stripe_subscription_id,omitempty"`
@@ -434,6 +566,19 @@ type PlanDetails struct {
 	Limits         map[string]int              `json:"limits"`
 	Popular        bool                        `json:"popular"`
 	Recommended    bool                        `json:"recommended"`
+	Currency       string                      `json:"currency"`
+	TrialDays      int                         `json:"trial_days"`
+	GraceDays      int                         `json:"grace_period_days"`
+	Active         bool                        `json:"is_active"`
+}
+
+// SubscribeResponse is returned when a tenant moves to a plan
+type SubscribeResponse struct {
+	Subscription *SubscriptionResponse `json:"subscription"`
+	// RequiresPayment is set while Stripe waits for the first invoice to be paid; the
+	// plan takes effect once it is, and ClientSecret confirms the payment client-side
+	RequiresPayment bool   `json:"requires_payment"`
+	ClientSecret    string `json:"client_secret,omitempty"`
 }
 
 // PaymentHistoryResponse represents payment history
@@ -457,6 +602,22 @@ type PaymentResponse struct {
 	FailureReason  string     `json:"failure_reason,omitempty"`
 	ProcessedAt    *time.Time `json:"processed_at,omitempty"`
 	CreatedAt      time.Time  `json:"created_at"`
+
+	// Commission split of booking payments, with the tenant rule that set the rate
+	CommissionRate     float64    `json:"commission_rate,omitempty"`
+	CommissionRuleID   *uuid.UUID `json:"commission_rule_id,omitempty"`
+	CommissionRuleName string     `json:"commission_rule_name,omitempty"`
+
+	// Escrow state of the artisan's share; empty when the payment was not held
+	EscrowStatus     models.EscrowStatus `json:"escrow_status,omitempty"`
+	EscrowReleasedAt *time.Time          `json:"escrow_released_at,omitempty"`
+	EscrowNote       string              `json:"escrow_note,omitempty"`
+
+	// Processor of booking payments, with its payment ID masked and payment or personal
+	// data redacted from the metadata
+	Provider          string       `json:"provider,omitempty"`
+	ProviderReference string       `json:"provider_reference,omitempty"`
+	Metadata          models.JSONB `json:"metadata,omitempty"`
 }
 
 // FeatureAccessResponse represents feature access information
@@ -513,6 +674,7 @@ func ToSubscriptionResponse(subscription *models.Subscription) *SubscriptionResp
 		CurrentPeriodEnd:     subscription.CurrentPeriodEnd,
 		CanceledAt:           subscription.CanceledAt,
 		CancelAtPeriodEnd:    subscription.CancelAtPeriodEnd,
+		GraceEndsAt:          subscription.GraceEndsAt,
 		StripeSubscriptionID: subscription.StripeSubscriptionID,
 		StripeCustomerID:     subscription.StripeCustomerID,
 		PaymentMethodID:      subscription.PaymentMethodID,
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"math"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
@@ -43,14 +44,19 @@ type UpdateTaskRequest struct {
 	EstimatedCost  *float64               `json:"estimated_cost,omitempty" validate:"omitempty,min=0"`
 	TrackedHours   *float64               `json:"tracked_hours,omitempty" validate:"omitempty,min=0"`
 	ActualCost     *float64               `json:"actual_cost,omitempty" validate:"omitempty,min=0"`
-	DependsOn      []uuid.UUID            `json:"depends_on,omitempty"`
-	BlockedBy      []uuid.UUID            `json:"blocked_by,omitempty"`
+	DependsOn      []uuid.UUID            `json:"depends_on,omitempty"` // Replaces the task's dependencies; blocked_by follows from them
 	BlockReason    *string                `json:"block_reason,omitempty"`
 	Checklist      []models.ChecklistItem `json:"checklist,omitempty"`
 	AttachmentURLs []string               `json:"attachment_urls,omitempty"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// AddTaskDependencyRequest represents a request to make a task wait on another task
+// of the same project
+type AddTaskDependencyRequest struct {
+	DependsOnTaskID uuid.UUID `json:"depends_on_task_id" validate:"required"`
+}
+
 // ReorderTasksRequest represents a request to reorder tasks
 type ReorderTasksRequest struct {
 	ProjectID  uuid.UUID      `json:"project_id" validate:"required"`
@@ -151,6 +157,46 @@ type MilestoneSummary struct {
 	DueDate *time.Time             `json:"due_date,omitempty"`
 }
 
+// TaskDependenciesResponse lists the tasks a task waits on and the tasks waiting on it
+type TaskDependenciesResponse struct {
+	TaskID     uuid.UUID       `json:"task_id"`
+	DependsOn  []*TaskResponse `json:"depends_on"`
+	BlockedBy  []*TaskResponse `json:"blocked_by"` // The unfinished subset of depends_on
+	Dependents []*TaskResponse `json:"dependents"`
+}
+
+// TaskScheduleResponse represents a task's place in the project schedule. Offsets are
+// working hours from the schedule start.
+type TaskScheduleResponse struct {
+	TaskID         uuid.UUID         `json:"task_id"`
+	Title          string            `json:"title"`
+	Status         models.TaskStatus `json:"status"`
+	RemainingHours float64           `json:"remaining_hours"`
+	EarliestStart  float64           `json:"earliest_start"`
+	EarliestFinish float64           `json:"earliest_finish"`
+	LatestStart    float64           `json:"latest_start"`
+	LatestFinish   float64           `json:"latest_finish"`
+	SlackHours     float64           `json:"slack_hours"`
+	Critical       bool              `json:"critical"`
+	StartDate      time.Time         `json:"start_date"`
+	FinishDate     time.Time         `json:"finish_date"`
+}
+
+// ProjectScheduleResponse represents the critical path schedule of a project's
+// remaining work
+type ProjectScheduleResponse struct {
+	ProjectID        uuid.UUID               `json:"project_id"`
+	Start            time.Time               `json:"start"`
+	HoursPerDay      int                     `json:"hours_per_day"`
+	TotalHours       float64                 `json:"total_hours"`
+	ProjectedFinish  time.Time               `json:"projected_finish"`
+	DueDate          *time.Time              `json:"due_date,omitempty"`
+	DaysLate         int                     `json:"days_late"` // Days the projected finish is past the due date
+	CriticalPath     []uuid.UUID             `json:"critical_path"`
+	UnestimatedTasks int                     `json:"unestimated_tasks"`
+	Tasks            []*TaskScheduleResponse `json:"tasks"`
+}
+
 // ============================================================================
 // Conversion Functions
 // ============================================================================
@@ -228,3 +274,41 @@ func ToTaskResponses(tasks []*models.ProjectTask) []*TaskResponse {
 	}
 	return responses
 }
+
+// ToProjectScheduleResponse converts a project schedule to a response DTO
+func ToProjectScheduleResponse(project *models.Project, schedule *models.ProjectSchedule) *ProjectScheduleResponse {
+	resp := &ProjectScheduleResponse{
+		ProjectID:        project.ID,
+		Start:            schedule.Start,
+		HoursPerDay:      models.ScheduleHoursPerDay,
+		TotalHours:       schedule.TotalHours,
+		ProjectedFinish:  schedule.ProjectedFinish,
+		DueDate:          project.DueDate,
+		CriticalPath:     schedule.CriticalPath,
+		UnestimatedTasks: schedule.UnestimatedTasks,
+		Tasks:            make([]*TaskScheduleResponse, len(schedule.Tasks)),
+	}
+	if resp.CriticalPath == nil {
+		resp.CriticalPath = []uuid.UUID{}
+	}
+	if project.DueDate != nil && schedule.ProjectedFinish.After(*project.DueDate) {
+		resp.DaysLate = int(math.Ceil(schedule.ProjectedFinish.Sub(*project.DueDate).Hours() / 24))
+	}
+	for i, task := range schedule.Tasks {
+		resp.Tasks[i] = &TaskScheduleResponse{
+			TaskID:         task.TaskID,
+			Title:          task.Title,
+			Status:         task.Status,
+			RemainingHours: task.RemainingHours,
+			EarliestStart:  task.EarliestStart,
+			EarliestFinish: task.EarliestFinish,
+			LatestStart:    task.LatestStart,
+			LatestFinish:   task.LatestFinish,
+			SlackHours:     task.SlackHours,
+			Critical:       task.Critical,
+			StartDate:      task.StartDate,
+			FinishDate:     task.FinishDate,
+		}
+	}
+	return resp
+}
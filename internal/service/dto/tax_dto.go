@@ -0,0 +1,130 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Tax Rule Request DTOs
+// ============================================================================
+
+// CreateTaxRuleRequest represents a request to create a tax rule
+type CreateTaxRuleRequest struct {
+	TenantID uuid.UUID          `json:"-"` // Set from the auth context
+	Name     string             `json:"name" validate:"required,max=100"`
+	Category models.TaxCategory `json:"category,omitempty"` // Empty applies to every category
+	Country  string             `json:"country,omitempty"`  // Empty applies everywhere
+	Region   string             `json:"region,omitempty"`
+	Rate     float64            `json:"rate" validate:"min=0,max=100"`
+	IsActive *bool              `json:"is_active,omitempty"` // Defaults to true
+}
+
+// Validate validates the create tax rule request
+func (r *CreateTaxRuleRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	return nil
+}
+
+// UpdateTaxRuleRequest represents a request to update a tax rule
+type UpdateTaxRuleRequest struct {
+	Name     *string             `json:"name,omitempty" validate:"omitempty,max=100"`
+	Category *models.TaxCategory `json:"category,omitempty"`
+	Country  *string             `json:"country,omitempty"`
+	Region   *string             `json:"region,omitempty"`
+	Rate     *float64            `json:"rate,omitempty" validate:"omitempty,min=0,max=100"`
+	IsActive *bool               `json:"is_active,omitempty"`
+}
+
+// TaxQuoteRequest asks what tax applies to an amount
+type TaxQuoteRequest struct {
+	TenantID  uuid.UUID          `json:"-"`                    // Set from the auth context
+	ServiceID *uuid.UUID         `json:"service_id,omitempty"` // Takes the category from the service
+	Category  models.TaxCategory `json:"category,omitempty"`
+	Amount    float64            `json:"amount" validate:"min=0"`
+	Country   string             `json:"country,omitempty"` // Defaults to the tenant's tax country
+	Region    string             `json:"region,omitempty"`
+}
+
+// Validate validates the tax quote request
+func (r *TaxQuoteRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if r.Amount < 0 {
+		return fmt.Errorf("amount cannot be negative")
+	}
+	if r.Category != "" && !r.Category.IsValid() {
+		return fmt.Errorf("invalid tax category")
+	}
+	return nil
+}
+
+// ============================================================================
+// Tax Rule Response DTOs
+// ============================================================================
+
+// TaxRuleResponse represents a tax rule
+type TaxRuleResponse struct {
+	ID        uuid.UUID          `json:"id"`
+	TenantID  uuid.UUID          `json:"tenant_id"`
+	Name      string             `json:"name"`
+	Category  models.TaxCategory `json:"category,omitempty"`
+	Country   string             `json:"country,omitempty"`
+	Region    string             `json:"region,omitempty"`
+	Rate      float64            `json:"rate"`
+	IsActive  bool               `json:"is_active"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// TaxQuoteResponse shows the tax charged on an amount. With tax-inclusive pricing the
+// amount already contains the tax and Total equals Amount.
+type TaxQuoteResponse struct {
+	Category  models.TaxCategory `json:"category"`
+	Country   string             `json:"country,omitempty"`
+	Region    string             `json:"region,omitempty"`
+	Amount    float64            `json:"amount"`
+	TaxLines  []models.TaxLine   `json:"tax_lines"`
+	TaxAmount float64            `json:"tax_amount"`
+	Total     float64            `json:"total"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToTaxRuleResponse converts a TaxRule model to a response DTO
+func ToTaxRuleResponse(rule *models.TaxRule) *TaxRuleResponse {
+	if rule == nil {
+		return nil
+	}
+
+	return &TaxRuleResponse{
+		ID:        rule.ID,
+		TenantID:  rule.TenantID,
+		Name:      rule.Name,
+		Category:  rule.Category,
+		Country:   rule.Country,
+		Region:    rule.Region,
+		Rate:      rule.Rate,
+		IsActive:  rule.IsActive,
+		CreatedAt: rule.CreatedAt,
+		UpdatedAt: rule.UpdatedAt,
+	}
+}
+
+// ToTaxRuleResponses converts multiple TaxRule models to response DTOs
+func ToTaxRuleResponses(rules []*models.TaxRule) []*TaxRuleResponse {
+	responses := make([]*TaxRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = ToTaxRuleResponse(rule)
+	}
+	return responses
+}
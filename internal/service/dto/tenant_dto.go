@@ -175,6 +175,29 @@ func (r *CancelTenantRequest) Validate() error {
 	return nil
 }
 
+// ChangeTenantStatusRequest represents a platform admin's request to move a tenant
+// through its lifecycle
+type ChangeTenantStatusRequest struct {
+	Status models.TenantStatus `json:"status" validate:"required,oneof=active suspended cancelled closed"`
+	Reason string              `json:"reason" validate:"required,min=5,max=500"`
+}
+
+// Validate validates the change tenant status request
+func (r *ChangeTenantStatusRequest) Validate() error {
+	switch r.Status {
+	case models.TenantStatusActive, models.TenantStatusSuspended, models.TenantStatusCancelled, models.TenantStatusClosed:
+	default:
+		return fmt.Errorf("status must be one of: active, suspended, cancelled, closed")
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if len(r.Reason) < 5 || len(r.Reason) > 500 {
+		return fmt.Errorf("reason must be between 5 and 500 characters")
+	}
+	return nil
+}
+
 // UpdateStorageUsageRequest represents the request to update storage usage
 type UpdateStorageUsageRequest struct {
 	BytesUsed int64 `json:"bytes_used" validate:"required,min=0"`
@@ -318,6 +341,28 @@ type UpdateTenantSettingsRequest struct {
 	EnableEmailReminders    *bool   `json:"enable_email_reminders,omitempty"`
 	EnableWaitlist          *bool   `json:"enable_waitlist,omitempty"`
 	EnableReviews           *bool   `json:"enable_reviews,omitempty"`
+
+	// Booking status transition policy
+	RequireDepositBeforeConfirm *bool `json:"require_deposit_before_confirm,omitempty"`
+	AllowRefundAfterCompletion  *bool `json:"allow_refund_after_completion,omitempty"`
+	AllowCancelInProgress       *bool `json:"allow_cancel_in_progress,omitempty"`
+
+	// Payment processor: stripe, paypal or paystack; empty uses the platform default
+	PaymentProvider *string `json:"payment_provider,omitempty"`
+
+	// Processor that takes payments while the payment provider is down; empty disables failover
+	FallbackPaymentProvider *string `json:"fallback_payment_provider,omitempty"`
+
+	// Multi-factor authentication requirements, replacing the current ones
+	MFAPolicy *models.MFAPolicy `json:"mfa_policy,omitempty"`
+
+	// Customer segmentation thresholds, replacing the current ones; applied from the next
+	// nightly recalculation
+	CustomerSegments *models.CustomerSegmentRules `json:"customer_segments,omitempty"`
+
+	// Review auto-publish rules, profanity filter and review requests, replacing the
+	// current ones; applied to reviews written from now on
+	ReviewModeration *models.ReviewModerationPolicy `json:"review_moderation,omitempty"`
 }
 
 // UpdateTenantFeaturesRequest represents the request to update tenant features
@@ -347,6 +392,7 @@ type TenantResponse struct {
 	Name              string                 `json:"name"`
 	Subdomain         string                 `json:"subdomain"`
 	Domain            string                 `json:"domain,omitempty"`
+	DomainVerifiedAt  *time.Time             `json:"domain_verified_at,omitempty"` // Requests are served at the domain once verified
 	BusinessName      string                 `json:"business_name"`
 	BusinessEmail     string                 `json:"business_email"`
 	BusinessPhone     string                 `json:"business_phone,omitempty"`
@@ -426,6 +472,17 @@ type DomainAvailabilityResponse struct {
 	Message   string `json:"message,omitempty"`
 }
 
+// DomainVerificationResponse is the TXT record a tenant adds to its custom domain's
+// DNS to prove it owns the domain, and whether it was found
+type DomainVerificationResponse struct {
+	Domain      string     `json:"domain"`
+	RecordType  string     `json:"record_type"`
+	RecordName  string     `json:"record_name"`
+	RecordValue string     `json:"record_value"`
+	Verified    bool       `json:"verified"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+}
+
 // TenantLimitsResponse represents tenant limits information
 type TenantLimitsResponse struct {
 	TenantID          uuid.UUID         `json:"tenant_id"`
@@ -453,6 +510,28 @@ type TenantActivityResponse struct {
 	IsActive      bool      `json:"is_active"`
 }
 
+// MFAComplianceResponse reports a tenant's staff against its multi-factor
+// authentication policy
+type MFAComplianceResponse struct {
+	TenantID          uuid.UUID            `json:"tenant_id"`
+	Policy            models.MFAPolicy     `json:"policy"`
+	StaffCount        int                  `json:"staff_count"`
+	NonCompliantCount int                  `json:"non_compliant_count"` // Required to use MFA but never seen doing so
+	WithoutMFA        []*MFAComplianceUser `json:"without_mfa"`
+}
+
+// MFAComplianceUser is a member of a tenant's staff never seen signed in with a second factor
+type MFAComplianceUser struct {
+	UserID      uuid.UUID       `json:"user_id"`
+	Email       string          `json:"email"`
+	FirstName   string          `json:"first_name"`
+	LastName    string          `json:"last_name"`
+	Role        models.UserRole `json:"role"`
+	Required    bool            `json:"required"` // Whether the policy requires MFA of their role
+	MFAEnabled  bool            `json:"mfa_enabled"`
+	LastLoginAt *time.Time      `json:"last_login_at,omitempty"`
+}
+
 // TenantHealthResponse represents tenant health check
 type TenantHealthResponse struct {
 	TenantID           uuid.UUID           `json:"tenant_id"`
@@ -478,31 +557,32 @@ func ToTenantResponse(tenant *models.Tenant) *TenantResponse {
 	}
 
 	response := &TenantResponse{
-		ID:             tenant.ID,
-		OwnerID:        tenant.OwnerID,
-		Name:           tenant.Name,
-		Subdomain:      tenant.Subdomain,
-		Domain:         tenant.Domain,
-		BusinessName:   tenant.BusinessName,
-		BusinessEmail:  tenant.BusinessEmail,
-		BusinessPhone:  tenant.BusinessPhone,
-		TaxID:          tenant.TaxID,
-		LogoURL:        tenant.LogoURL,
-		PrimaryColor:   tenant.PrimaryColor,
-		Plan:           tenant.Plan,
-		Status:         tenant.Status,
-		TrialEndsAt:    tenant.TrialEndsAt,
-		SubscriptionID: tenant.SubscriptionID, // string type matches
-		Settings:       tenant.Settings,
-		Features:       tenant.Features,
-		MaxUsers:       tenant.MaxUsers,
-		MaxArtisans:    tenant.MaxArtisans,
-		MaxStorage:     tenant.MaxStorage,
-		CurrentUsers:   tenant.CurrentUsers,
-		StorageUsed:    tenant.StorageUsed,
-		Metadata:       tenant.Metadata,
-		CreatedAt:      tenant.CreatedAt,
-		UpdatedAt:      tenant.UpdatedAt,
+		ID:               tenant.ID,
+		OwnerID:          tenant.OwnerID,
+		Name:             tenant.Name,
+		Subdomain:        tenant.Subdomain,
+		Domain:           tenant.Domain,
+		DomainVerifiedAt: tenant.DomainVerifiedAt,
+		BusinessName:     tenant.BusinessName,
+		BusinessEmail:    tenant.BusinessEmail,
+		BusinessPhone:    tenant.BusinessPhone,
+		TaxID:            tenant.TaxID,
+		LogoURL:          tenant.LogoURL,
+		PrimaryColor:     tenant.PrimaryColor,
+		Plan:             tenant.Plan,
+		Status:           tenant.Status,
+		TrialEndsAt:      tenant.TrialEndsAt,
+		SubscriptionID:   tenant.SubscriptionID, // string type matches
+		Settings:         tenant.Settings,
+		Features:         tenant.Features,
+		MaxUsers:         tenant.MaxUsers,
+		MaxArtisans:      tenant.MaxArtisans,
+		MaxStorage:       tenant.MaxStorage,
+		CurrentUsers:     tenant.CurrentUsers,
+		StorageUsed:      tenant.StorageUsed,
+		Metadata:         tenant.Metadata,
+		CreatedAt:        tenant.CreatedAt,
+		UpdatedAt:        tenant.UpdatedAt,
 	}
 
 	// Calculate trial expiry
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -29,6 +30,9 @@ func (r *CreateInvitationRequest) Validate() error {
 	if r.Role == "" {
 		return fmt.Errorf("role is required")
 	}
+	if !slices.Contains(models.InvitableRoles, r.Role) {
+		return fmt.Errorf("role must be tenant_admin, artisan or team_member")
+	}
 	if r.ExpiryDays < 0 || r.ExpiryDays > 30 {
 		return fmt.Errorf("expiry_days must be between 0 and 30")
 	}
@@ -40,9 +44,12 @@ func (r *CreateInvitationRequest) Sanitize() {
 	r.Email = strings.ToLower(strings.TrimSpace(r.Email))
 }
 
-// AcceptInvitationRequest represents the request to accept an invitation
+// AcceptInvitationRequest represents the request to accept an invitation. The name is
+// that of the account set up for invitees who have none.
 type AcceptInvitationRequest struct {
-	Token string `json:"token" validate:"required"`
+	Token     string `json:"token" validate:"required"`
+	FirstName string `json:"first_name,omitempty" validate:"omitempty,max=100"`
+	LastName  string `json:"last_name,omitempty" validate:"omitempty,max=100"`
 }
 
 // Validate validates the accept invitation request
@@ -50,9 +57,19 @@ func (r *AcceptInvitationRequest) Validate() error {
 	if strings.TrimSpace(r.Token) == "" {
 		return fmt.Errorf("token is required")
 	}
+	if len(r.FirstName) > 100 || len(r.LastName) > 100 {
+		return fmt.Errorf("names must be at most 100 characters")
+	}
 	return nil
 }
 
+// Sanitize sanitizes the accept invitation request
+func (r *AcceptInvitationRequest) Sanitize() {
+	r.Token = strings.TrimSpace(r.Token)
+	r.FirstName = strings.TrimSpace(r.FirstName)
+	r.LastName = strings.TrimSpace(r.LastName)
+}
+
 // InvitationFilter represents filters for listing invitations
 type InvitationFilter struct {
 	Status   *string `json:"status,omitempty"` // pending, accepted, expired
@@ -73,6 +90,10 @@ type InvitationResponse struct {
 	InvitedBy   uuid.UUID       `json:"invited_by"`
 	InviterName string          `json:"inviter_name,omitempty"`
 	CreatedAt   time.Time       `json:"created_at"`
+
+	// Token accepts the invitation. It is only returned when the invitation is created
+	// or resent, for the inviter to pass on as a link.
+	Token string `json:"token,omitempty"`
 }
 
 // InvitationListResponse represents a paginated list of invitations
@@ -92,6 +113,41 @@ type AcceptInvitationResponse struct {
 	TenantName string          `json:"tenant_name"`
 	UserID     uuid.UUID       `json:"user_id"`
 	Role       models.UserRole `json:"role"`
+	InviteSent bool            `json:"invite_sent"` // An email to set up the new account's sign-in was sent
+}
+
+// TeamMemberResponse is a member of a tenant's staff
+type TeamMemberResponse struct {
+	UserID      uuid.UUID         `json:"user_id"`
+	Email       string            `json:"email"`
+	FirstName   string            `json:"first_name"`
+	LastName    string            `json:"last_name"`
+	Role        models.UserRole   `json:"role"`
+	Status      models.UserStatus `json:"status"` // Pending until the member first signs in
+	LastLoginAt *time.Time        `json:"last_login_at,omitempty"`
+	JoinedAt    time.Time         `json:"joined_at"`
+}
+
+// TeamResponse is a tenant's staff, the invitations still open, and the seats they take
+type TeamResponse struct {
+	Members            []*TeamMemberResponse `json:"members"`
+	PendingInvitations []*InvitationResponse `json:"pending_invitations"`
+	SeatsUsed          int                   `json:"seats_used"`    // Members and pending invitations
+	SeatsAllowed       int                   `json:"seats_allowed"` // 0 is unlimited
+}
+
+// ToTeamMemberResponse converts a staff user to a team member response
+func ToTeamMemberResponse(user *models.User) *TeamMemberResponse {
+	return &TeamMemberResponse{
+		UserID:      user.ID,
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Role:        user.Role,
+		Status:      user.Status,
+		LastLoginAt: user.LastLoginAt,
+		JoinedAt:    user.CreatedAt,
+	}
 }
 
 // ToInvitationResponse converts a models.TenantInvitation to InvitationResponse
@@ -262,6 +318,7 @@ type DataExportResponse struct {
 	RequestedBy   uuid.UUID  `json:"requested_by"`
 	RequesterName string     `json:"requester_name,omitempty"`
 	ExportType    string     `json:"export_type"`
+	SubjectUserID *uuid.UUID `json:"subject_user_id,omitempty"` // The user a gdpr export is of
 	Status        string     `json:"status"`
 	FileURL       string     `json:"file_url,omitempty"`
 	FileSize      int64      `json:"file_size,omitempty"`
@@ -288,16 +345,17 @@ func ToDataExportResponse(export *models.DataExportRequest) *DataExportResponse
 	}
 
 	response := &DataExportResponse{
-		ID:          export.ID,
-		TenantID:    export.TenantID,
-		RequestedBy: export.RequestedBy,
-		ExportType:  export.ExportType,
-		Status:      export.Status,
-		FileURL:     export.FileURL,
-		ExpiresAt:   export.ExpiresAt,
-		CompletedAt: export.CompletedAt,
-		CreatedAt:   export.CreatedAt,
-		UpdatedAt:   export.UpdatedAt,
+		ID:            export.ID,
+		TenantID:      export.TenantID,
+		RequestedBy:   export.RequestedBy,
+		ExportType:    export.ExportType,
+		Status:        export.Status,
+		SubjectUserID: export.SubjectUserID,
+		FileURL:       export.FileURL,
+		ExpiresAt:     export.ExpiresAt,
+		CompletedAt:   export.CompletedAt,
+		CreatedAt:     export.CreatedAt,
+		UpdatedAt:     export.UpdatedAt,
 	}
 
 	if export.Requester != nil {
@@ -358,42 +416,3 @@ func (r *RemoveMemberRequest) Validate() error {
 	}
 	return nil
 }
-
-// ============================================================================
-// Audit Log DTOs
-// ============================================================================
-
-// TenantAuditLogResponse represents an audit log entry
-type TenantAuditLogResponse struct {
-	ID         uuid.UUID              `json:"id"`
-	TenantID   uuid.UUID              `json:"tenant_id"`
-	UserID     uuid.UUID              `json:"user_id"`
-	UserEmail  string                 `json:"user_email,omitempty"`
-	Action     string                 `json:"action"`
-	Resource   string                 `json:"resource"`
-	ResourceID string                 `json:"resource_id,omitempty"`
-	Changes    map[string]interface{} `json:"changes,omitempty"`
-	IPAddress  string                 `json:"ip_address,omitempty"`
-	UserAgent  string                 `json:"user_agent,omitempty"`
-	CreatedAt  time.Time              `json:"created_at"`
-}
-
-// TenantAuditLogListResponse represents a list of audit logs
-type TenantAuditLogListResponse struct {
-	Logs       []*TenantAuditLogResponse `json:"logs"`
-	Page       int                       `json:"page"`
-	PageSize   int                       `json:"page_size"`
-	TotalItems int64                     `json:"total_items"`
-	TotalPages int                       `json:"total_pages"`
-}
-
-// AuditLogFilter represents filters for listing audit logs
-type AuditLogFilter struct {
-	UserID    *uuid.UUID `json:"user_id,omitempty"`
-	Action    *string    `json:"action,omitempty"`
-	Resource  *string    `json:"resource,omitempty"`
-	StartDate *time.Time `json:"start_date,omitempty"`
-	EndDate   *time.Time `json:"end_date,omitempty"`
-	Page      int        `json:"page" validate:"min=1"`
-	PageSize  int        `json:"page_size" validate:"min=1,max=100"`
-}
@@ -0,0 +1,122 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Tenant Role Request DTOs
+// ============================================================================
+
+// CreateTenantRoleRequest represents a request to create a custom tenant role
+type CreateTenantRoleRequest struct {
+	TenantID    uuid.UUID `json:"-"` // Set from the auth context
+	Name        string    `json:"name" validate:"required,max=100"`
+	Description string    `json:"description,omitempty" validate:"max=255"`
+	Permissions []string  `json:"permissions"` // Actions granted, such as "bookings.update"
+}
+
+// Validate validates the create tenant role request
+func (r *CreateTenantRoleRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	return nil
+}
+
+// UpdateTenantRoleRequest represents a request to update a custom tenant role.
+// Permissions replaces the granted actions when set.
+type UpdateTenantRoleRequest struct {
+	Name        *string   `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description *string   `json:"description,omitempty" validate:"omitempty,max=255"`
+	Permissions *[]string `json:"permissions,omitempty"`
+}
+
+// AssignTenantRoleRequest represents a request to assign a custom role to a user
+type AssignTenantRoleRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// ============================================================================
+// Tenant Role Response DTOs
+// ============================================================================
+
+// TenantRoleResponse represents a custom tenant role
+type TenantRoleResponse struct {
+	ID          uuid.UUID `json:"id"`
+	TenantID    uuid.UUID `json:"tenant_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TenantRoleAssignmentResponse represents a user assigned a custom tenant role
+type TenantRoleAssignmentResponse struct {
+	RoleID     uuid.UUID       `json:"role_id"`
+	UserID     uuid.UUID       `json:"user_id"`
+	Email      string          `json:"email,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	BaseRole   models.UserRole `json:"base_role,omitempty"`
+	AssignedAt time.Time       `json:"assigned_at"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToTenantRoleResponse converts a TenantRole model to a response DTO
+func ToTenantRoleResponse(role *models.TenantRole) *TenantRoleResponse {
+	if role == nil {
+		return nil
+	}
+
+	permissions := []string(role.Permissions)
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return &TenantRoleResponse{
+		ID:          role.ID,
+		TenantID:    role.TenantID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+// ToTenantRoleResponses converts multiple TenantRole models to response DTOs
+func ToTenantRoleResponses(roles []*models.TenantRole) []*TenantRoleResponse {
+	responses := make([]*TenantRoleResponse, len(roles))
+	for i, role := range roles {
+		responses[i] = ToTenantRoleResponse(role)
+	}
+	return responses
+}
+
+// ToTenantRoleAssignmentResponses converts TenantRoleAssignment models, with their users
+// when loaded, to response DTOs
+func ToTenantRoleAssignmentResponses(assignments []*models.TenantRoleAssignment) []*TenantRoleAssignmentResponse {
+	responses := make([]*TenantRoleAssignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		responses[i] = &TenantRoleAssignmentResponse{
+			RoleID:     assignment.RoleID,
+			UserID:     assignment.UserID,
+			AssignedAt: assignment.CreatedAt,
+		}
+		if user := assignment.User; user != nil {
+			responses[i].Email = user.Email
+			responses[i].Name = user.FullName()
+			responses[i].BaseRole = user.Role
+		}
+	}
+	return responses
+}
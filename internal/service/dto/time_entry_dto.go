@@ -0,0 +1,218 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Time Entry Request DTOs
+// ============================================================================
+
+// StartTimerRequest represents a request to start a timer on a task. A timer the user
+// already has running is stopped first.
+type StartTimerRequest struct {
+	TaskID      uuid.UUID `json:"task_id" validate:"required"`
+	Description string    `json:"description,omitempty"`
+	Billable    *bool     `json:"billable,omitempty"`    // Defaults to true
+	HourlyRate  *float64  `json:"hourly_rate,omitempty"` // Defaults to the project's hourly rate
+}
+
+// Validate validates the start timer request
+func (r *StartTimerRequest) Validate() error {
+	if r.TaskID == uuid.Nil {
+		return fmt.Errorf("task_id is required")
+	}
+	if r.HourlyRate != nil && *r.HourlyRate < 0 {
+		return fmt.Errorf("hourly_rate cannot be negative")
+	}
+	return nil
+}
+
+// CreateTimeEntryRequest represents a request to log time after the fact
+type CreateTimeEntryRequest struct {
+	TaskID      uuid.UUID `json:"task_id" validate:"required"`
+	StartedAt   time.Time `json:"started_at" validate:"required"`
+	EndedAt     time.Time `json:"ended_at" validate:"required,gtfield=StartedAt"`
+	Description string    `json:"description,omitempty"`
+	Billable    *bool     `json:"billable,omitempty"`    // Defaults to true
+	HourlyRate  *float64  `json:"hourly_rate,omitempty"` // Defaults to the project's hourly rate
+}
+
+// Validate validates the create time entry request
+func (r *CreateTimeEntryRequest) Validate() error {
+	if r.TaskID == uuid.Nil {
+		return fmt.Errorf("task_id is required")
+	}
+	if r.StartedAt.IsZero() || r.EndedAt.IsZero() {
+		return fmt.Errorf("started_at and ended_at are required")
+	}
+	if r.HourlyRate != nil && *r.HourlyRate < 0 {
+		return fmt.Errorf("hourly_rate cannot be negative")
+	}
+	return nil
+}
+
+// UpdateTimeEntryRequest represents a request to correct a stopped time entry.
+// Invoiced entries cannot be changed.
+type UpdateTimeEntryRequest struct {
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Billable    *bool      `json:"billable,omitempty"`
+	HourlyRate  *float64   `json:"hourly_rate,omitempty" validate:"omitempty,min=0"`
+}
+
+// TimeEntryFilter represents filters for time entry queries
+type TimeEntryFilter struct {
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	ProjectID *uuid.UUID `json:"project_id,omitempty"`
+	TaskID    *uuid.UUID `json:"task_id,omitempty"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	Billable  *bool      `json:"billable,omitempty"`
+	Invoiced  *bool      `json:"invoiced,omitempty"`
+	From      *time.Time `json:"from,omitempty"`
+	To        *time.Time `json:"to,omitempty"`
+	Page      int        `json:"page"`
+	PageSize  int        `json:"page_size"`
+}
+
+// InvoiceTimeEntriesRequest represents a request to bill a project's uninvoiced
+// billable hours on a draft invoice
+type InvoiceTimeEntriesRequest struct {
+	From    *time.Time `json:"from,omitempty"`     // Entries started at or after
+	To      *time.Time `json:"to,omitempty"`       // Entries started before
+	DueDate *time.Time `json:"due_date,omitempty"` // Defaults to 30 days after issue
+	Notes   string     `json:"notes,omitempty"`
+}
+
+// ============================================================================
+// Time Entry Response DTOs
+// ============================================================================
+
+// TimeEntryResponse represents a time entry
+type TimeEntryResponse struct {
+	ID              uuid.UUID              `json:"id"`
+	TenantID        uuid.UUID              `json:"tenant_id"`
+	ProjectID       uuid.UUID              `json:"project_id"`
+	TaskID          uuid.UUID              `json:"task_id"`
+	TaskTitle       string                 `json:"task_title,omitempty"`
+	UserID          uuid.UUID              `json:"user_id"`
+	Description     string                 `json:"description,omitempty"`
+	Source          models.TimeEntrySource `json:"source"`
+	StartedAt       time.Time              `json:"started_at"`
+	EndedAt         *time.Time             `json:"ended_at,omitempty"`
+	IsRunning       bool                   `json:"is_running"`
+	DurationMinutes int                    `json:"duration_minutes"`
+	Hours           float64                `json:"hours"`
+	Billable        bool                   `json:"billable"`
+	HourlyRate      float64                `json:"hourly_rate"`
+	Amount          float64                `json:"amount"`
+	Currency        string                 `json:"currency"`
+	InvoiceID       *uuid.UUID             `json:"invoice_id,omitempty"`
+	InvoicedAt      *time.Time             `json:"invoiced_at,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// TimeEntryListResponse represents a paginated list of time entries
+type TimeEntryListResponse struct {
+	Entries     []*TimeEntryResponse `json:"entries"`
+	Page        int                  `json:"page"`
+	PageSize    int                  `json:"page_size"`
+	TotalItems  int64                `json:"total_items"`
+	TotalPages  int                  `json:"total_pages"`
+	HasNext     bool                 `json:"has_next"`
+	HasPrevious bool                 `json:"has_previous"`
+}
+
+// TaskHoursResponse compares a task's tracked hours with its estimate
+type TaskHoursResponse struct {
+	TaskID          uuid.UUID         `json:"task_id"`
+	Title           string            `json:"title"`
+	Status          models.TaskStatus `json:"status"`
+	EstimatedHours  float64           `json:"estimated_hours"`
+	TrackedHours    float64           `json:"tracked_hours"`
+	BillableHours   float64           `json:"billable_hours"`
+	UninvoicedHours float64           `json:"uninvoiced_hours"`
+	BillableAmount  float64           `json:"billable_amount"`
+	OverEstimate    bool              `json:"over_estimate"`
+}
+
+// ProjectHoursResponse compares a project's actual hours with its estimate
+type ProjectHoursResponse struct {
+	ProjectID       uuid.UUID            `json:"project_id"`
+	EstimatedHours  float64              `json:"estimated_hours"`
+	ActualHours     float64              `json:"actual_hours"`
+	RemainingHours  float64              `json:"remaining_hours"` // Negative once over the estimate
+	PercentUsed     float64              `json:"percent_used"`    // Actual as a percentage of estimated; 0 without an estimate
+	BillableHours   float64              `json:"billable_hours"`
+	UninvoicedHours float64              `json:"uninvoiced_hours"`
+	BillableAmount  float64              `json:"billable_amount"`
+	Currency        string               `json:"currency"`
+	Tasks           []*TaskHoursResponse `json:"tasks"`
+}
+
+// BillableHoursExport lists a project's uninvoiced billable hours, grouped into
+// invoice line items ready to be billed
+type BillableHoursExport struct {
+	ProjectID   uuid.UUID               `json:"project_id"`
+	Currency    string                  `json:"currency"`
+	From        *time.Time              `json:"from,omitempty"`
+	To          *time.Time              `json:"to,omitempty"`
+	TotalHours  float64                 `json:"total_hours"`
+	TotalAmount float64                 `json:"total_amount"`
+	LineItems   models.InvoiceLineItems `json:"line_items"`
+	Entries     []*TimeEntryResponse    `json:"entries"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToTimeEntryResponse converts a TimeEntry model to a response DTO
+func ToTimeEntryResponse(entry *models.TimeEntry) *TimeEntryResponse {
+	if entry == nil {
+		return nil
+	}
+
+	resp := &TimeEntryResponse{
+		ID:              entry.ID,
+		TenantID:        entry.TenantID,
+		ProjectID:       entry.ProjectID,
+		TaskID:          entry.TaskID,
+		UserID:          entry.UserID,
+		Description:     entry.Description,
+		Source:          entry.Source,
+		StartedAt:       entry.StartedAt,
+		EndedAt:         entry.EndedAt,
+		IsRunning:       entry.IsRunning(),
+		DurationMinutes: entry.DurationMinutes,
+		Hours:           entry.Hours(),
+		Billable:        entry.Billable,
+		HourlyRate:      entry.HourlyRate,
+		Amount:          entry.Amount(),
+		Currency:        entry.Currency,
+		InvoiceID:       entry.InvoiceID,
+		InvoicedAt:      entry.InvoicedAt,
+		CreatedAt:       entry.CreatedAt,
+		UpdatedAt:       entry.UpdatedAt,
+	}
+	if entry.Task != nil {
+		resp.TaskTitle = entry.Task.Title
+	}
+	return resp
+}
+
+// ToTimeEntryResponses converts multiple TimeEntry models to response DTOs
+func ToTimeEntryResponses(entries []*models.TimeEntry) []*TimeEntryResponse {
+	responses := make([]*TimeEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = ToTimeEntryResponse(entry)
+	}
+	return responses
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashKind names the kinds of records that go to the trash when deleted
+type TrashKind string
+
+const (
+	TrashKindBookings TrashKind = "bookings"
+	TrashKindServices TrashKind = "services"
+	TrashKindProjects TrashKind = "projects"
+)
+
+// IsValid checks if the trash kind is valid
+func (k TrashKind) IsValid() bool {
+	switch k {
+	case TrashKindBookings, TrashKindServices, TrashKindProjects:
+		return true
+	}
+	return false
+}
+
+// ============================================================================
+// Trash Request DTOs
+// ============================================================================
+
+// TrashListRequest lists a tenant's deleted records of one kind
+type TrashListRequest struct {
+	TenantID uuid.UUID `json:"-"`
+	Kind     TrashKind `json:"kind"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
+}
+
+// Validate validates the trash list request
+func (r *TrashListRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if !r.Kind.IsValid() {
+		return fmt.Errorf("kind must be bookings, services or projects")
+	}
+	return nil
+}
+
+// ============================================================================
+// Trash Response DTOs
+// ============================================================================
+
+// TrashItemResponse is a deleted record that can still be restored
+type TrashItemResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Kind      TrashKind  `json:"kind"`
+	Title     string     `json:"title,omitempty"`      // Service name or project title
+	StartTime *time.Time `json:"start_time,omitempty"` // When a booking was scheduled
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   time.Time  `json:"purge_at"` // When it is deleted for good
+}
+
+// TrashListResponse is a page of deleted records
+type TrashListResponse struct {
+	Items      []*TrashItemResponse `json:"items"`
+	Page       int                  `json:"page"`
+	PageSize   int                  `json:"page_size"`
+	TotalItems int64                `json:"total_items"`
+	TotalPages int                  `json:"total_pages"`
+}
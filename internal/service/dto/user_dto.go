@@ -219,10 +219,35 @@ type MFASetupResponse struct {
 	BackupCodes []string `json:"backup_codes"`
 }
 
+// ErasureResponse represents the erasure of a user's personal data
+type ErasureResponse struct {
+	UserID      uuid.UUID  `json:"user_id"`
+	Status      string     `json:"status"`                 // none, scheduled, erased
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"` // Cancellable until then
+	ErasedAt    *time.Time `json:"erased_at,omitempty"`
+}
+
 // ============================================================================
 // Conversion Functions
 // ============================================================================
 
+// ToErasureResponse converts a User model to the erasure of their personal data
+func ToErasureResponse(user *models.User) *ErasureResponse {
+	response := &ErasureResponse{
+		UserID: user.ID,
+		Status: "none",
+	}
+	switch {
+	case user.IsErased():
+		response.Status = "erased"
+		response.ErasedAt = user.ErasedAt
+	case user.MarkedForDeletion:
+		response.Status = "scheduled"
+		response.ScheduledAt = user.DeletionScheduledAt
+	}
+	return response
+}
+
 // ToUserResponse converts a User model to UserResponse DTO
 func ToUserResponse(user *models.User) *UserResponse {
 	if user == nil {
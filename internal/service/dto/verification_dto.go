@@ -0,0 +1,139 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Verification Request DTOs
+// ============================================================================
+
+// UploadVerificationDocumentRequest describes a document an artisan uploads for review;
+// the file itself is passed alongside
+type UploadVerificationDocumentRequest struct {
+	TenantID  uuid.UUID                       `json:"-"` // Set from the auth context
+	ArtisanID uuid.UUID                       `json:"-"` // Set from the path
+	Type      models.VerificationDocumentType `json:"type" validate:"required"`
+	Reference string                          `json:"reference,omitempty" validate:"max=100"` // License or policy number
+	Issuer    string                          `json:"issuer,omitempty" validate:"max=200"`
+	FileName  string                          `json:"-"`
+	FileSize  int64                           `json:"-"`
+}
+
+// Validate validates the upload verification document request
+func (r *UploadVerificationDocumentRequest) Validate() error {
+	if r.TenantID == uuid.Nil || r.ArtisanID == uuid.Nil {
+		return fmt.Errorf("tenant ID and artisan ID are required")
+	}
+	if !r.Type.IsValid() {
+		return fmt.Errorf("type must be id, license or insurance")
+	}
+	if len(r.Reference) > 100 || len(r.Issuer) > 200 {
+		return fmt.Errorf("reference or issuer too long")
+	}
+	if r.FileName == "" || r.FileSize <= 0 {
+		return fmt.Errorf("a document file is required")
+	}
+	return nil
+}
+
+// ApproveVerificationDocumentRequest represents a request to approve a verification
+// document; licenses and insurance need an expiry date
+type ApproveVerificationDocumentRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// RejectVerificationDocumentRequest represents a request to reject a verification document
+type RejectVerificationDocumentRequest struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+// ============================================================================
+// Verification Response DTOs
+// ============================================================================
+
+// VerificationDocumentResponse represents a verification document
+type VerificationDocumentResponse struct {
+	ID              uuid.UUID                       `json:"id"`
+	TenantID        uuid.UUID                       `json:"tenant_id"`
+	ArtisanID       uuid.UUID                       `json:"artisan_id"`
+	ArtisanName     string                          `json:"artisan_name,omitempty"`
+	Type            models.VerificationDocumentType `json:"type"`
+	Reference       string                          `json:"reference,omitempty"`
+	Issuer          string                          `json:"issuer,omitempty"`
+	FileName        string                          `json:"file_name"`
+	ContentType     string                          `json:"content_type"`
+	FileSize        int64                           `json:"file_size"`
+	Status          models.VerificationStatus       `json:"status"`
+	ExpiresAt       *time.Time                      `json:"expires_at,omitempty"`
+	ReviewedByID    *uuid.UUID                      `json:"reviewed_by_id,omitempty"`
+	ReviewedAt      *time.Time                      `json:"reviewed_at,omitempty"`
+	RejectionReason string                          `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time                       `json:"created_at"`
+}
+
+// VerificationDocumentListResponse represents a page of the verification review queue
+type VerificationDocumentListResponse struct {
+	Documents   []*VerificationDocumentResponse `json:"documents"`
+	Page        int                             `json:"page"`
+	PageSize    int                             `json:"page_size"`
+	TotalItems  int64                           `json:"total_items"`
+	TotalPages  int                             `json:"total_pages"`
+	HasNext     bool                            `json:"has_next"`
+	HasPrevious bool                            `json:"has_previous"`
+}
+
+// ArtisanVerificationResponse represents an artisan's badges and the documents behind them
+type ArtisanVerificationResponse struct {
+	ArtisanID  uuid.UUID                       `json:"artisan_id"`
+	IsVerified bool                            `json:"is_verified"`
+	IsInsured  bool                            `json:"is_insured"`
+	Documents  []*VerificationDocumentResponse `json:"documents"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToVerificationDocumentResponse converts a VerificationDocument model to a response DTO
+func ToVerificationDocumentResponse(document *models.VerificationDocument) *VerificationDocumentResponse {
+	if document == nil {
+		return nil
+	}
+
+	response := &VerificationDocumentResponse{
+		ID:              document.ID,
+		TenantID:        document.TenantID,
+		ArtisanID:       document.ArtisanID,
+		Type:            document.Type,
+		Reference:       document.Reference,
+		Issuer:          document.Issuer,
+		FileName:        document.FileName,
+		ContentType:     document.ContentType,
+		FileSize:        document.FileSize,
+		Status:          document.Status,
+		ExpiresAt:       document.ExpiresAt,
+		ReviewedByID:    document.ReviewedByID,
+		ReviewedAt:      document.ReviewedAt,
+		RejectionReason: document.RejectionReason,
+		CreatedAt:       document.CreatedAt,
+	}
+	if document.Artisan != nil && document.Artisan.User != nil {
+		response.ArtisanName = document.Artisan.User.FullName()
+	}
+	return response
+}
+
+// ToVerificationDocumentResponses converts multiple VerificationDocument models to response DTOs
+func ToVerificationDocumentResponses(documents []*models.VerificationDocument) []*VerificationDocumentResponse {
+	responses := make([]*VerificationDocumentResponse, len(documents))
+	for i, document := range documents {
+		responses[i] = ToVerificationDocumentResponse(document)
+	}
+	return responses
+}
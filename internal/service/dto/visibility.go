@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+)
+
+// Viewer is who a response is serialized for. Responses carry only the fields the
+// viewer's role may see, so sensitive fields are stripped in one place rather than by
+// each service or handler.
+type Viewer string
+
+const (
+	// ViewerTenant sees everything: tenant owners and admins, their service accounts,
+	// platform users, and the platform acting on its own
+	ViewerTenant Viewer = "tenant"
+
+	// ViewerStaff is artisans and team members, who don't see the platform commission
+	// taken on payments
+	ViewerStaff Viewer = "staff"
+
+	// ViewerCustomer is customers, who don't see commissions either, nor the notes,
+	// tags and metadata the tenant keeps for itself
+	ViewerCustomer Viewer = "customer"
+)
+
+// ViewerOf returns the viewer an actor's responses are serialized for. Work without an
+// actor, such as background jobs, sees everything.
+func ViewerOf(actor *authz.Actor) Viewer {
+	if actor == nil || actor.Platform || actor.ServiceAccount {
+		return ViewerTenant
+	}
+
+	switch actor.Role {
+	case models.UserRoleTenantOwner, models.UserRoleTenantAdmin:
+		return ViewerTenant
+	case models.UserRoleArtisan, models.UserRoleTeamMember:
+		return ViewerStaff
+	}
+	return ViewerCustomer
+}
+
+// ViewerFromContext returns the viewer of the context's actor
+func ViewerFromContext(ctx context.Context) Viewer {
+	actor, _ := authz.ActorFromContext(ctx)
+	return ViewerOf(actor)
+}
+
+// bookingFieldPolicy strips the booking fields each viewer may not see
+var bookingFieldPolicy = map[Viewer]func(*BookingResponse){
+	ViewerCustomer: func(r *BookingResponse) {
+		r.InternalNotes = ""
+		r.Tags = nil
+		r.Metadata = nil
+	},
+}
+
+// paymentFieldPolicy strips the payment fields each viewer may not see
+var paymentFieldPolicy = map[Viewer]func(*PaymentResponse){
+	ViewerStaff: stripCommission,
+	ViewerCustomer: func(r *PaymentResponse) {
+		stripCommission(r)
+		r.EscrowNote = ""
+		r.Metadata = nil
+	},
+}
+
+// stripCommission removes the platform commission taken on a payment
+func stripCommission(r *PaymentResponse) {
+	r.CommissionRate = 0
+	r.CommissionRuleID = nil
+	r.CommissionRuleName = ""
+}
+
+// project applies a field policy to a response for the viewer
+func project[T any](policy map[Viewer]func(*T), viewer Viewer, response *T) *T {
+	if strip, ok := policy[viewer]; ok && response != nil {
+		strip(response)
+	}
+	return response
+}
@@ -0,0 +1,132 @@
+package dto_test
+
+import (
+	"testing"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewerOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor *authz.Actor
+		want  dto.Viewer
+	}{
+		{name: "no actor", actor: nil, want: dto.ViewerTenant},
+		{name: "platform", actor: &authz.Actor{Role: models.UserRolePlatformSupport, Platform: true}, want: dto.ViewerTenant},
+		{name: "service account", actor: &authz.Actor{ServiceAccount: true}, want: dto.ViewerTenant},
+		{name: "tenant owner", actor: &authz.Actor{Role: models.UserRoleTenantOwner}, want: dto.ViewerTenant},
+		{name: "tenant admin", actor: &authz.Actor{Role: models.UserRoleTenantAdmin}, want: dto.ViewerTenant},
+		{name: "artisan", actor: &authz.Actor{Role: models.UserRoleArtisan}, want: dto.ViewerStaff},
+		{name: "team member", actor: &authz.Actor{Role: models.UserRoleTeamMember}, want: dto.ViewerStaff},
+		{name: "customer", actor: &authz.Actor{Role: models.UserRoleCustomer}, want: dto.ViewerCustomer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, dto.ViewerOf(tt.actor))
+		})
+	}
+}
+
+func TestToBookingResponse_StripsFieldsPerViewer(t *testing.T) {
+	booking := &models.Booking{
+		BaseModel:     models.BaseModel{ID: uuid.New()},
+		Status:        models.BookingStatusConfirmed,
+		CustomerNotes: "Side gate is open",
+		InternalNotes: "Pays late",
+		Tags:          models.StringArray{"vip"},
+		Metadata:      models.JSONB{"source": "csv"},
+	}
+
+	tests := []struct {
+		viewer       dto.Viewer
+		wantStripped bool
+	}{
+		{viewer: dto.ViewerTenant},
+		{viewer: dto.ViewerStaff},
+		{viewer: dto.ViewerCustomer, wantStripped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.viewer), func(t *testing.T) {
+			resp := dto.ToBookingResponse(booking, tt.viewer)
+			require.NotNil(t, resp)
+
+			assert.Equal(t, booking.ID, resp.ID)
+			assert.Equal(t, "Side gate is open", resp.CustomerNotes, "customer notes are visible to all")
+			if tt.wantStripped {
+				assert.Empty(t, resp.InternalNotes)
+				assert.Nil(t, resp.Tags)
+				assert.Nil(t, resp.Metadata)
+			} else {
+				assert.Equal(t, "Pays late", resp.InternalNotes)
+				assert.Equal(t, []string{"vip"}, resp.Tags)
+				assert.Equal(t, models.JSONB{"source": "csv"}, resp.Metadata)
+			}
+		})
+	}
+
+	assert.Equal(t, "Pays late", booking.InternalNotes, "the booking itself is left alone")
+}
+
+func TestToPaymentResponse_StripsFieldsPerViewer(t *testing.T) {
+	ruleID := uuid.New()
+	payment := &models.Payment{
+		BaseModel:          models.BaseModel{ID: uuid.New()},
+		Amount:             120,
+		Currency:           "USD",
+		Status:             models.PaymentStatusPaid,
+		CommissionRate:     12.5,
+		CommissionRuleID:   &ruleID,
+		CommissionRuleName: "Weekend rate",
+		EscrowStatus:       models.EscrowStatusWithheld,
+		EscrowNote:         "Disputed by the customer",
+		Metadata:           models.JSONB{"provider_status": "succeeded"},
+	}
+
+	tests := []struct {
+		viewer                dto.Viewer
+		wantCommission        bool
+		wantEscrowAndMetadata bool
+	}{
+		{viewer: dto.ViewerTenant, wantCommission: true, wantEscrowAndMetadata: true},
+		{viewer: dto.ViewerStaff, wantEscrowAndMetadata: true},
+		{viewer: dto.ViewerCustomer},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.viewer), func(t *testing.T) {
+			resp := dto.ToPaymentResponse(payment, tt.viewer)
+			require.NotNil(t, resp)
+
+			assert.Equal(t, payment.ID, resp.ID)
+			assert.Equal(t, 120.0, resp.Amount)
+			assert.Equal(t, models.EscrowStatusWithheld, resp.EscrowStatus, "escrow status is visible to all")
+
+			if tt.wantCommission {
+				assert.Equal(t, 12.5, resp.CommissionRate)
+				assert.Equal(t, &ruleID, resp.CommissionRuleID)
+				assert.Equal(t, "Weekend rate", resp.CommissionRuleName)
+			} else {
+				assert.Zero(t, resp.CommissionRate)
+				assert.Nil(t, resp.CommissionRuleID)
+				assert.Empty(t, resp.CommissionRuleName)
+			}
+
+			if tt.wantEscrowAndMetadata {
+				assert.Equal(t, "Disputed by the customer", resp.EscrowNote)
+				assert.Equal(t, models.JSONB{"provider_status": "succeeded"}, resp.Metadata)
+			} else {
+				assert.Empty(t, resp.EscrowNote)
+				assert.Nil(t, resp.Metadata)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Webhook Subscription Request DTOs
+// ============================================================================
+
+// CreateWebhookSubscriptionRequest represents a request to create a webhook subscription
+type CreateWebhookSubscriptionRequest struct {
+	TenantID    uuid.UUID `json:"-"` // Set from the auth context
+	URL         string    `json:"url" validate:"required,url,max=500"`
+	Description string    `json:"description,omitempty" validate:"max=255"`
+	EventTypes  []string  `json:"event_types,omitempty"` // Empty receives every event
+	IsActive    *bool     `json:"is_active,omitempty"`   // Defaults to true
+}
+
+// Validate validates the create webhook subscription request
+func (r *CreateWebhookSubscriptionRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return fmt.Errorf("tenant ID is required")
+	}
+	return nil
+}
+
+// UpdateWebhookSubscriptionRequest represents a request to update a webhook subscription.
+// EventTypes replaces the subscribed types when set; an empty list receives every event.
+type UpdateWebhookSubscriptionRequest struct {
+	URL         *string   `json:"url,omitempty" validate:"omitempty,url,max=500"`
+	Description *string   `json:"description,omitempty" validate:"omitempty,max=255"`
+	EventTypes  *[]string `json:"event_types,omitempty"`
+	IsActive    *bool     `json:"is_active,omitempty"`
+}
+
+// ============================================================================
+// Webhook Subscription Response DTOs
+// ============================================================================
+
+// WebhookSubscriptionResponse represents a webhook subscription. The secret is only
+// returned when the subscription is created and when it is rotated.
+type WebhookSubscriptionResponse struct {
+	ID          uuid.UUID `json:"id"`
+	TenantID    uuid.UUID `json:"tenant_id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description,omitempty"`
+	EventTypes  []string  `json:"event_types"`
+	IsActive    bool      `json:"is_active"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryAttemptResponse represents one attempt at delivering a webhook
+type WebhookDeliveryAttemptResponse struct {
+	Attempt      int       `json:"attempt"`
+	AttemptedAt  time.Time `json:"attempted_at"`
+	DurationMs   int64     `json:"duration_ms"`
+	Succeeded    bool      `json:"succeeded"`
+	ResponseCode int       `json:"response_code,omitempty"`
+	ResponseBody string    `json:"response_body,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// WebhookSubscriptionDeliveryResponse represents a delivery of an event to a
+// subscription, with its attempts when requested on its own
+type WebhookSubscriptionDeliveryResponse struct {
+	*WebhookEventResponse
+	SubscriptionID uuid.UUID                         `json:"subscription_id"`
+	DomainEventID  *uuid.UUID                        `json:"domain_event_id,omitempty"`
+	Attempts       []*WebhookDeliveryAttemptResponse `json:"attempts,omitempty"`
+}
+
+// WebhookSubscriptionDeliveryListResponse represents a paginated list of a
+// subscription's deliveries
+type WebhookSubscriptionDeliveryListResponse struct {
+	Deliveries  []*WebhookSubscriptionDeliveryResponse `json:"deliveries"`
+	Page        int                                    `json:"page"`
+	PageSize    int                                    `json:"page_size"`
+	TotalItems  int64                                  `json:"total_items"`
+	TotalPages  int                                    `json:"total_pages"`
+	HasNext     bool                                   `json:"has_next"`
+	HasPrevious bool                                   `json:"has_previous"`
+}
+
+// ============================================================================
+// Conversion Functions
+// ============================================================================
+
+// ToWebhookSubscriptionResponse converts a WebhookSubscription model to a response DTO,
+// leaving out its secret
+func ToWebhookSubscriptionResponse(subscription *models.WebhookSubscription) *WebhookSubscriptionResponse {
+	if subscription == nil {
+		return nil
+	}
+
+	eventTypes := []string(subscription.EventTypes)
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return &WebhookSubscriptionResponse{
+		ID:          subscription.ID,
+		TenantID:    subscription.TenantID,
+		URL:         subscription.URL,
+		Description: subscription.Description,
+		EventTypes:  eventTypes,
+		IsActive:    subscription.IsActive,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
+
+// ToWebhookSubscriptionResponses converts multiple WebhookSubscription models to response DTOs
+func ToWebhookSubscriptionResponses(subscriptions []*models.WebhookSubscription) []*WebhookSubscriptionResponse {
+	responses := make([]*WebhookSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = ToWebhookSubscriptionResponse(subscription)
+	}
+	return responses
+}
+
+// ToWebhookSubscriptionDeliveryResponse converts a subscription's WebhookEvent model and
+// its attempts to a response DTO
+func ToWebhookSubscriptionDeliveryResponse(event *models.WebhookEvent, attempts []*models.WebhookDeliveryAttempt) *WebhookSubscriptionDeliveryResponse {
+	if event == nil {
+		return nil
+	}
+
+	response := &WebhookSubscriptionDeliveryResponse{
+		WebhookEventResponse: ToWebhookEventResponse(event),
+		DomainEventID:        event.DomainEventID,
+	}
+	if event.SubscriptionID != nil {
+		response.SubscriptionID = *event.SubscriptionID
+	}
+	for _, attempt := range attempts {
+		response.Attempts = append(response.Attempts, &WebhookDeliveryAttemptResponse{
+			Attempt:      attempt.Attempt,
+			AttemptedAt:  attempt.AttemptedAt,
+			DurationMs:   attempt.DurationMs,
+			Succeeded:    attempt.Succeeded,
+			ResponseCode: attempt.ResponseCode,
+			ResponseBody: attempt.ResponseBody,
+			Error:        attempt.Error,
+		})
+	}
+	return response
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// EscrowService defines the interface for releasing escrowed artisan earnings. Payments
+// taken under a tenant hold period keep the artisan's share out of payouts until the
+// booking has been completed for the hold period or the customer confirms the work.
+type EscrowService interface {
+	// ReleaseDueEscrow releases every held payment whose hold period has passed
+	ReleaseDueEscrow(ctx context.Context) (*dto.EscrowReleaseResponse, error)
+
+	// ConfirmCompletion lets the customer of a completed booking release its held
+	// payments before the hold period ends
+	ConfirmCompletion(ctx context.Context, tenantID, bookingID, customerID uuid.UUID) (*dto.EscrowReleaseResponse, error)
+
+	// Manual release and withholding by tenant admins handling disputes
+	ReleasePayment(ctx context.Context, tenantID, paymentID uuid.UUID, req *dto.EscrowActionRequest) (*dto.PaymentResponse, error)
+	WithholdPayment(ctx context.Context, tenantID, paymentID uuid.UUID, req *dto.EscrowActionRequest) (*dto.PaymentResponse, error)
+}
+
+// escrowService implements EscrowService
+type escrowService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewEscrowService creates a new EscrowService instance
+func NewEscrowService(repos *repository.Repositories, logger log.AllLogger) EscrowService {
+	return &escrowService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ReleaseDueEscrow runs the escrow release job
+func (s *escrowService) ReleaseDueEscrow(ctx context.Context) (*dto.EscrowReleaseResponse, error) {
+	released, err := s.repos.Payment.ReleaseDueEscrow(ctx, time.Now())
+	if err != nil {
+		return nil, errors.NewServiceError("ESCROW_RELEASE_FAILED", "failed to release escrow", err)
+	}
+
+	if released > 0 {
+		s.logger.Info("escrow released", "payments", released)
+	}
+	return &dto.EscrowReleaseResponse{Released: released}, nil
+}
+
+// ConfirmCompletion releases a completed booking's held payments on the customer's word.
+// Withheld payments stay frozen.
+func (s *escrowService) ConfirmCompletion(ctx context.Context, tenantID, bookingID, customerID uuid.UUID) (*dto.EscrowReleaseResponse, error) {
+	booking, err := s.repos.Booking.GetByID(ctx, bookingID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("booking")
+		}
+		return nil, errors.NewServiceError("GET_FAILED", "failed to get booking", err)
+	}
+	if booking.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("booking")
+	}
+	if booking.CustomerID != customerID {
+		return nil, errors.NewForbiddenError("only the booking's customer can confirm its completion")
+	}
+	if booking.Status != models.BookingStatusCompleted {
+		return nil, errors.NewValidationError("booking has not been completed")
+	}
+
+	released, err := s.repos.Payment.ReleaseBookingEscrow(ctx, booking.ID, "confirmed by customer")
+	if err != nil {
+		return nil, errors.NewServiceError("ESCROW_RELEASE_FAILED", "failed to release escrow", err)
+	}
+
+	s.logger.Info("booking completion confirmed by customer", "booking_id", booking.ID, "released", released)
+	return &dto.EscrowReleaseResponse{BookingID: &booking.ID, Released: released}, nil
+}
+
+// ReleasePayment frees a held or withheld payment for the next payout
+func (s *escrowService) ReleasePayment(ctx context.Context, tenantID, paymentID uuid.UUID, req *dto.EscrowActionRequest) (*dto.PaymentResponse, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		note = "released by admin"
+	}
+
+	payment, err := s.updateEscrow(ctx, tenantID, paymentID, func(p *models.Payment) error {
+		return p.ReleaseEscrow(note)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("escrow released by admin", "payment_id", payment.ID, "note", note)
+	return dto.ToPaymentResponse(payment, dto.ViewerFromContext(ctx)), nil
+}
+
+// WithholdPayment freezes a payment's artisan share until it is released by hand
+func (s *escrowService) WithholdPayment(ctx context.Context, tenantID, paymentID uuid.UUID, req *dto.EscrowActionRequest) (*dto.PaymentResponse, error) {
+	reason := strings.TrimSpace(req.Note)
+
+	payment, err := s.updateEscrow(ctx, tenantID, paymentID, func(p *models.Payment) error {
+		if !p.IsSuccessful() {
+			return fmt.Errorf("only paid payments can be withheld")
+		}
+		return p.WithholdEscrow(reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("escrow withheld", "payment_id", payment.ID, "reason", reason)
+	return dto.ToPaymentResponse(payment, dto.ViewerFromContext(ctx)), nil
+}
+
+// updateEscrow changes the escrow state of one of the tenant's payments
+func (s *escrowService) updateEscrow(ctx context.Context, tenantID, paymentID uuid.UUID, mutate func(*models.Payment) error) (*models.Payment, error) {
+	if paymentID == uuid.Nil {
+		return nil, errors.NewValidationError("payment ID is required")
+	}
+
+	payment, err := s.repos.Payment.GetByID(ctx, paymentID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("payment")
+		}
+		return nil, errors.NewServiceError("GET_FAILED", "failed to get payment", err)
+	}
+	if payment.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("payment")
+	}
+
+	// Checked up front for a clear error; the locked update checks again
+	if err := mutate(payment.Clone()); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	payment, err = s.repos.Payment.UpdateEscrow(ctx, paymentID, mutate)
+	if err != nil {
+		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update payment escrow", err)
+	}
+	return payment, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"Krafti_Vibe/internal/domain/events"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const (
+	// eventDispatchBatchSize caps the outbox events delivered per run
+	eventDispatchBatchSize = 200
+	// eventMaxAttempts is how many times an event is delivered to a consumer that keeps
+	// failing it before it is given up on
+	eventMaxAttempts = 8
+	// eventRetryBaseDelay is the wait before the first retry; it doubles with every
+	// further attempt, up to eventRetryMaxDelay
+	eventRetryBaseDelay = 30 * time.Second
+	// eventRetryMaxDelay caps the wait between retries
+	eventRetryMaxDelay = time.Hour
+	// outboxRetention is how long dispatched events are kept for troubleshooting
+	outboxRetention = 7 * 24 * time.Hour
+)
+
+// EventDispatcher delivers the domain events in the outbox to the consumers
+// subscribed to them in the registry
+type EventDispatcher interface {
+	// DispatchDue delivers the events due for delivery, returning how many every
+	// consumer handled
+	DispatchDue(ctx context.Context) (int, error)
+
+	// PurgeDispatched deletes events dispatched longer ago than the retention,
+	// returning how many it deleted
+	PurgeDispatched(ctx context.Context) (int64, error)
+}
+
+// eventDispatcher implements EventDispatcher
+type eventDispatcher struct {
+	repos    *repository.Repositories
+	logger   log.AllLogger
+	registry *events.Registry
+}
+
+// NewEventDispatcher creates a new EventDispatcher instance
+func NewEventDispatcher(repos *repository.Repositories, logger log.AllLogger, registry *events.Registry) EventDispatcher {
+	return &eventDispatcher{
+		repos:    repos,
+		logger:   logger,
+		registry: registry,
+	}
+}
+
+// DispatchDue delivers events in the order they occurred. An event a consumer fails
+// is retried with backoff for the consumers that failed it only, while later events
+// carry on, so consumers must not rely on the order of events and must be idempotent.
+func (d *eventDispatcher) DispatchDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := d.repos.Outbox.FindDue(ctx, now, eventDispatchBatchSize)
+	if err != nil {
+		return 0, errors.NewServiceError("QUERY_FAILED", "failed to find due outbox events", err)
+	}
+
+	dispatched, failed := 0, 0
+	for _, event := range due {
+		if err := ctx.Err(); err != nil {
+			return dispatched, err
+		}
+
+		failures := d.deliver(ctx, event)
+		if len(failures) == 0 {
+			if err := d.repos.Outbox.MarkDispatched(ctx, event.ID, time.Now()); err != nil {
+				return dispatched, errors.NewServiceError("UPDATE_FAILED", "failed to mark outbox event dispatched", err)
+			}
+			dispatched++
+			continue
+		}
+
+		failed++
+		d.scheduleRetry(event, failures, time.Now())
+		if err := d.repos.Outbox.RecordFailure(ctx, event); err != nil {
+			return dispatched, errors.NewServiceError("UPDATE_FAILED", "failed to record outbox event failure", err)
+		}
+	}
+
+	if dispatched > 0 || failed > 0 {
+		d.logger.Debug("outbox events dispatched", "dispatched", dispatched, "failed", failed)
+	}
+	return dispatched, nil
+}
+
+// deliver hands an event to its consumers, only to those that failed it before when it
+// is a retry, and returns the errors of the consumers that failed it by name
+func (d *eventDispatcher) deliver(ctx context.Context, event *models.OutboxEvent) map[string]error {
+	envelope := events.NewEnvelope(event)
+
+	failures := make(map[string]error)
+	for _, subscription := range d.registry.Subscriptions(envelope.Type) {
+		if len(event.FailedConsumers) > 0 && !slices.Contains(event.FailedConsumers, subscription.Consumer) {
+			continue
+		}
+		if err := handleEvent(ctx, subscription.Handler, envelope); err != nil {
+			d.logger.Warn("event consumer failed",
+				"consumer", subscription.Consumer,
+				"event_id", event.ID,
+				"event_type", event.EventType,
+				"attempt", envelope.Attempt,
+				"error", err)
+			failures[subscription.Consumer] = err
+		}
+	}
+	return failures
+}
+
+// scheduleRetry records the consumers that failed an event and when it is next
+// delivered, or marks it failed once it ran out of attempts
+func (d *eventDispatcher) scheduleRetry(event *models.OutboxEvent, failures map[string]error, now time.Time) {
+	consumers := make([]string, 0, len(failures))
+	errs := make([]error, 0, len(failures))
+	for consumer := range failures {
+		consumers = append(consumers, consumer)
+	}
+	slices.Sort(consumers)
+	for _, consumer := range consumers {
+		errs = append(errs, fmt.Errorf("%s: %w", consumer, failures[consumer]))
+	}
+
+	event.Attempts++
+	event.FailedConsumers = consumers
+	event.LastError = stdErrors.Join(errs...).Error()
+	if event.Attempts >= eventMaxAttempts {
+		event.Status = models.OutboxStatusFailed
+		d.logger.Error("giving up on outbox event",
+			"event_id", event.ID,
+			"event_type", event.EventType,
+			"attempts", event.Attempts,
+			"consumers", consumers,
+			"error", event.LastError)
+		return
+	}
+	event.NextAttemptAt = now.Add(eventRetryDelay(event.Attempts))
+}
+
+// PurgeDispatched deletes the dispatched events past the retention
+func (d *eventDispatcher) PurgeDispatched(ctx context.Context) (int64, error) {
+	purged, err := d.repos.Outbox.PurgeDispatched(ctx, time.Now().Add(-outboxRetention))
+	if err != nil {
+		return 0, errors.NewServiceError("PURGE_FAILED", "failed to purge dispatched outbox events", err)
+	}
+	if purged > 0 {
+		d.logger.Info("purged dispatched outbox events", "count", purged)
+	}
+	return purged, nil
+}
+
+// handleEvent runs a consumer's handler, turning a panic into an error so one consumer
+// cannot stop the delivery of the batch
+func handleEvent(ctx context.Context, handler events.Handler, envelope *events.Envelope) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, envelope)
+}
+
+// eventRetryDelay returns the wait before the next delivery of an event that failed
+// the given number of attempts
+func eventRetryDelay(attempts int) time.Duration {
+	delay := eventRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= eventRetryMaxDelay {
+			return eventRetryMaxDelay
+		}
+	}
+	return delay
+}
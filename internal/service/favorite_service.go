@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// FavoriteService defines the interface for customer favorite operations
+type FavoriteService interface {
+	// Favorites
+	AddFavorite(ctx context.Context, tenantID, customerID uuid.UUID, req *dto.AddFavoriteRequest) (*dto.FavoriteResponse, error)
+	RemoveFavorite(ctx context.Context, tenantID, customerID uuid.UUID, kind models.FavoriteKind, targetID uuid.UUID) error
+	ListFavorites(ctx context.Context, tenantID, customerID uuid.UUID, kind models.FavoriteKind, page, pageSize int) (*dto.FavoriteListResponse, error)
+
+	// Notifications
+	NotifyNewAvailability(ctx context.Context, artisanID uuid.UUID) (int, error)
+	NotifyNewService(ctx context.Context, service *models.Service) (int, error)
+}
+
+// favoriteService implements FavoriteService
+type favoriteService struct {
+	repos         *repository.Repositories
+	logger        log.AllLogger
+	notifications NotificationService
+}
+
+// NewFavoriteService creates a new FavoriteService instance
+func NewFavoriteService(repos *repository.Repositories, logger log.AllLogger) FavoriteService {
+	return &favoriteService{
+		repos:         repos,
+		logger:        logger,
+		notifications: NewNotificationService(repos, logger),
+	}
+}
+
+// AddFavorite saves an artisan or a bookable service of the tenant to the customer's
+// favorites. Adding one already saved updates whether the customer is notified about it.
+func (s *favoriteService) AddFavorite(ctx context.Context, tenantID, customerID uuid.UUID, req *dto.AddFavoriteRequest) (*dto.FavoriteResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	favorite := &models.Favorite{
+		TenantID:   tenantID,
+		CustomerID: customerID,
+		Notify:     req.Notify,
+	}
+	switch req.Kind {
+	case models.FavoriteKindArtisan:
+		artisan, err := s.repos.Artisan.GetByID(ctx, req.TargetID)
+		if err != nil || artisan.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("artisan")
+		}
+		if artisan.UserID == customerID {
+			return nil, errors.NewValidationError("You cannot favorite yourself")
+		}
+		favorite.ArtisanID = &artisan.ID
+		favorite.Artisan = artisan
+	case models.FavoriteKindService:
+		service, err := s.repos.Service.GetByID(ctx, req.TargetID)
+		if err != nil || service.TenantID != tenantID || !service.IsBookable() {
+			return nil, errors.NewNotFoundError("service")
+		}
+		favorite.ServiceID = &service.ID
+		favorite.Service = service
+	}
+	if err := favorite.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	existing, err := s.repos.Favorite.FindByTarget(ctx, customerID, req.Kind, req.TargetID)
+	switch {
+	case err == nil:
+		if existing.Notify != req.Notify {
+			existing.Notify = req.Notify
+			if err := s.repos.Favorite.Update(ctx, existing); err != nil {
+				s.logger.Error("failed to update favorite", "favorite_id", existing.ID, "error", err)
+				return nil, errors.NewServiceError("UPDATE_FAILED", "Failed to update favorite", err)
+			}
+		}
+		existing.Artisan, existing.Service = favorite.Artisan, favorite.Service
+		return dto.ToFavoriteResponse(existing), nil
+	case !errors.IsNotFoundError(err):
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to get favorite", err)
+	}
+
+	if err := s.repos.Favorite.Add(ctx, favorite); err != nil {
+		if errors.IsDuplicate(err) {
+			return nil, errors.NewConflictError("This favorite was just added")
+		}
+		s.logger.Error("failed to add favorite", "customer_id", customerID, "kind", req.Kind, "error", err)
+		return nil, errors.NewServiceError("CREATE_FAILED", "Failed to add favorite", err)
+	}
+
+	s.logger.Info("favorite added", "favorite_id", favorite.ID, "kind", req.Kind, "target_id", req.TargetID)
+	return dto.ToFavoriteResponse(favorite), nil
+}
+
+// RemoveFavorite removes an artisan or a service from the customer's favorites
+func (s *favoriteService) RemoveFavorite(ctx context.Context, tenantID, customerID uuid.UUID, kind models.FavoriteKind, targetID uuid.UUID) error {
+	if !kind.IsValid() {
+		return errors.NewValidationError("kind must be artisan or service")
+	}
+
+	favorite, err := s.repos.Favorite.FindByTarget(ctx, customerID, kind, targetID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return errors.NewNotFoundError("favorite")
+		}
+		return errors.NewServiceError("QUERY_FAILED", "Failed to get favorite", err)
+	}
+	if favorite.TenantID != tenantID {
+		return errors.NewNotFoundError("favorite")
+	}
+
+	if err := s.repos.Favorite.Remove(ctx, favorite); err != nil {
+		if errors.IsNotFoundError(err) {
+			return errors.NewNotFoundError("favorite")
+		}
+		s.logger.Error("failed to remove favorite", "favorite_id", favorite.ID, "error", err)
+		return errors.NewServiceError("DELETE_FAILED", "Failed to remove favorite", err)
+	}
+
+	s.logger.Info("favorite removed", "favorite_id", favorite.ID)
+	return nil
+}
+
+// ListFavorites lists the customer's favorites in the tenant, optionally only artisans
+// or only services
+func (s *favoriteService) ListFavorites(ctx context.Context, tenantID, customerID uuid.UUID, kind models.FavoriteKind, page, pageSize int) (*dto.FavoriteListResponse, error) {
+	if kind != "" && !kind.IsValid() {
+		return nil, errors.NewValidationError("kind must be artisan or service")
+	}
+
+	favorites, result, err := s.repos.Favorite.FindByCustomer(ctx, tenantID, customerID, kind, repository.PaginationParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to list favorites", err)
+	}
+
+	return &dto.FavoriteListResponse{
+		Favorites:   dto.ToFavoriteResponses(favorites),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// NotifyNewAvailability tells the customers following an artisan profile that it opened
+// new availability, returning how many were notified
+func (s *favoriteService) NotifyNewAvailability(ctx context.Context, artisanID uuid.UUID) (int, error) {
+	artisan, err := s.repos.Artisan.GetByID(ctx, artisanID)
+	if err != nil {
+		return 0, errors.NewNotFoundError("artisan")
+	}
+	return s.notifyFollowers(ctx, artisan, nil)
+}
+
+// NotifyNewService tells the customers following a service's artisan that it was
+// published, returning how many were notified. Org-wide services notify no one.
+func (s *favoriteService) NotifyNewService(ctx context.Context, service *models.Service) (int, error) {
+	if service.ArtisanID == nil {
+		return 0, nil
+	}
+
+	artisan, err := s.repos.Artisan.FindByUserID(ctx, *service.ArtisanID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return 0, nil
+		}
+		return 0, errors.NewServiceError("QUERY_FAILED", "Failed to get artisan", err)
+	}
+	return s.notifyFollowers(ctx, artisan, service)
+}
+
+// notifyFollowers notifies the customers who favorited the artisan and asked to hear
+// about it, skipping those notified within the cooldown
+func (s *favoriteService) notifyFollowers(ctx context.Context, artisan *models.Artisan, service *models.Service) (int, error) {
+	now := time.Now()
+	favorites, err := s.repos.Favorite.FindToNotify(ctx, artisan.ID, now.Add(-models.FavoriteNotifyCooldown))
+	if err != nil {
+		return 0, errors.NewServiceError("QUERY_FAILED", "Failed to find favorites to notify", err)
+	}
+	if len(favorites) == 0 {
+		return 0, nil
+	}
+
+	if artisan.User == nil {
+		if user, err := s.repos.User.GetByID(ctx, artisan.UserID); err == nil {
+			artisan.User = user
+		}
+	}
+
+	notified := make([]uuid.UUID, 0, len(favorites))
+	for _, favorite := range favorites {
+		if !favorite.ShouldNotify(now) {
+			continue
+		}
+		if _, err := s.notifications.SendFavoriteArtisanNotification(ctx, favorite, artisan, service); err != nil {
+			s.logger.Error("failed to notify favorite", "favorite_id", favorite.ID, "error", err)
+			continue
+		}
+		notified = append(notified, favorite.ID)
+	}
+
+	if err := s.repos.Favorite.MarkNotified(ctx, notified, now); err != nil {
+		s.logger.Error("failed to mark favorites notified", "artisan_id", artisan.ID, "error", err)
+	}
+
+	s.logger.Info("favorite followers notified", "artisan_id", artisan.ID, "count", len(notified))
+	return len(notified), nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/pdf"
+	"Krafti_Vibe/internal/service/dto"
+)
+
+// Invoice layout, in points
+const (
+	invoiceMargin     = 50.0
+	invoiceRight      = pdf.PageWidth - invoiceMargin
+	invoiceBottom     = pdf.PageHeight - 70
+	invoiceRowHeight  = 18.0
+	invoiceFontSize   = 10.0
+	invoiceQtyRight   = 370.0
+	invoiceUnitRight  = 460.0
+	invoiceTotalsLeft = 340.0
+)
+
+// invoiceRenderer lays out an invoice top to bottom, starting a new page when a
+// page is full
+type invoiceRenderer struct {
+	doc     *pdf.Document
+	page    *pdf.Page
+	y       float64
+	invoice *models.Invoice
+}
+
+// renderInvoicePDF renders an invoice issued by tenant
+func renderInvoicePDF(invoice *models.Invoice, tenant *models.Tenant) []byte {
+	r := &invoiceRenderer{
+		doc:     pdf.New("Invoice " + invoice.InvoiceNumber),
+		invoice: invoice,
+	}
+	r.newPage()
+
+	r.header(tenant)
+	r.lineItems()
+	r.totals()
+	r.paragraph("Notes", invoice.Notes)
+	r.paragraph("Terms & Conditions", invoice.TermsConditions)
+
+	return r.doc.Bytes()
+}
+
+// invoicePDFAttachment encodes a rendered invoice as an email attachment descriptor
+func invoicePDFAttachment(file *dto.InvoicePDF) map[string]any {
+	return map[string]any{
+		"filename":     file.FileName,
+		"content_type": pdf.ContentType,
+		"encoding":     "base64",
+		"content":      base64.StdEncoding.EncodeToString(file.Content),
+	}
+}
+
+func (r *invoiceRenderer) newPage() {
+	r.page = r.doc.AddPage()
+	r.y = invoiceMargin
+}
+
+// ensure starts a new page unless height points still fit on this one
+func (r *invoiceRenderer) ensure(height float64) bool {
+	if r.y+height <= invoiceBottom {
+		return false
+	}
+	r.newPage()
+	return true
+}
+
+func (r *invoiceRenderer) header(tenant *models.Tenant) {
+	p := r.page
+	p.Text(invoiceMargin, r.y+22, pdf.Bold, 22, "INVOICE")
+
+	businessName := tenant.BusinessName
+	if businessName == "" {
+		businessName = tenant.Name
+	}
+	y := r.y + 12
+	p.TextRight(invoiceRight, y, pdf.Bold, 12, businessName)
+	for _, line := range []string{tenant.BusinessEmail, tenant.BusinessPhone, taxIDLine(tenant.TaxID)} {
+		if line == "" {
+			continue
+		}
+		y += 14
+		p.TextRight(invoiceRight, y, pdf.Regular, invoiceFontSize, line)
+	}
+
+	r.y += 70
+	details := [][2]string{
+		{"Invoice number", r.invoice.InvoiceNumber},
+		{"Issue date", r.invoice.IssueDate.Format("Jan 2, 2006")},
+		{"Due date", r.invoice.DueDate.Format("Jan 2, 2006")},
+		{"Status", strings.ToUpper(string(r.invoice.Status))},
+	}
+	for i, detail := range details {
+		y := r.y + float64(i)*14
+		p.Text(invoiceMargin, y, pdf.Bold, invoiceFontSize, detail[0])
+		p.Text(invoiceMargin+95, y, pdf.Regular, invoiceFontSize, detail[1])
+	}
+
+	if customer := r.invoice.Customer; customer != nil {
+		p.Text(invoiceTotalsLeft, r.y, pdf.Bold, invoiceFontSize, "Bill to")
+		p.Text(invoiceTotalsLeft, r.y+14, pdf.Regular, invoiceFontSize, customer.FullName())
+		p.Text(invoiceTotalsLeft, r.y+28, pdf.Regular, invoiceFontSize, customer.Email)
+	}
+
+	r.y += float64(len(details))*14 + 20
+}
+
+func taxIDLine(taxID string) string {
+	if taxID == "" {
+		return ""
+	}
+	return "Tax ID: " + taxID
+}
+
+func (r *invoiceRenderer) tableHeader() {
+	p := r.page
+	p.FillRect(invoiceMargin, r.y, invoiceRight-invoiceMargin, invoiceRowHeight, 0.92)
+	baseline := r.y + 12.5
+	p.Text(invoiceMargin+6, baseline, pdf.Bold, invoiceFontSize, "Description")
+	p.TextRight(invoiceQtyRight, baseline, pdf.Bold, invoiceFontSize, "Qty")
+	p.TextRight(invoiceUnitRight, baseline, pdf.Bold, invoiceFontSize, "Unit price")
+	p.TextRight(invoiceRight-6, baseline, pdf.Bold, invoiceFontSize, "Amount")
+	r.y += invoiceRowHeight
+}
+
+func (r *invoiceRenderer) lineItems() {
+	r.tableHeader()
+
+	descriptionWidth := invoiceQtyRight - 40 - invoiceMargin - 6
+	for _, item := range r.invoice.LineItems {
+		if r.ensure(invoiceRowHeight) {
+			r.tableHeader()
+		}
+
+		p := r.page
+		baseline := r.y + 12.5
+		p.Text(invoiceMargin+6, baseline, pdf.Regular, invoiceFontSize,
+			pdf.Truncate(pdf.Regular, invoiceFontSize, item.Description, descriptionWidth))
+		p.TextRight(invoiceQtyRight, baseline, pdf.Regular, invoiceFontSize, strconv.Itoa(item.Quantity))
+		p.TextRight(invoiceUnitRight, baseline, pdf.Regular, invoiceFontSize, r.money(item.UnitPrice))
+		p.TextRight(invoiceRight-6, baseline, pdf.Regular, invoiceFontSize, r.money(item.TotalPrice))
+		r.y += invoiceRowHeight
+		p.Line(invoiceMargin, r.y, invoiceRight, r.y, 0.5)
+	}
+
+	r.y += 10
+}
+
+func (r *invoiceRenderer) totals() {
+	invoice := r.invoice
+	rows := [][2]string{{"Subtotal", r.money(invoice.SubtotalAmount)}}
+	if invoice.DiscountAmount > 0 {
+		rows = append(rows, [2]string{"Discount", "-" + r.money(invoice.DiscountAmount)})
+	}
+	for _, tax := range invoice.TaxLines {
+		label := fmt.Sprintf("%s (%s%%)", tax.Name, strconv.FormatFloat(tax.Rate, 'f', -1, 64))
+		if tax.Inclusive {
+			label += " included"
+		}
+		rows = append(rows, [2]string{label, r.money(tax.Amount)})
+	}
+
+	r.ensure(float64(len(rows)+4) * invoiceRowHeight)
+	for _, row := range rows {
+		r.totalRow(pdf.Regular, row[0], row[1])
+	}
+
+	r.page.Line(invoiceTotalsLeft, r.y, invoiceRight, r.y, 1)
+	r.y += 4
+	r.totalRow(pdf.Bold, "Total", r.money(invoice.TotalAmount))
+	if invoice.PaidAmount > 0 {
+		r.totalRow(pdf.Regular, "Paid", r.money(invoice.PaidAmount))
+	}
+	r.totalRow(pdf.Bold, "Balance due", r.money(invoice.GetBalanceDue()))
+
+	r.y += 20
+}
+
+func (r *invoiceRenderer) totalRow(font pdf.Font, label, value string) {
+	baseline := r.y + 12.5
+	r.page.Text(invoiceTotalsLeft, baseline, font, invoiceFontSize, label)
+	r.page.TextRight(invoiceRight-6, baseline, font, invoiceFontSize, value)
+	r.y += invoiceRowHeight
+}
+
+func (r *invoiceRenderer) paragraph(title, text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	r.ensure(3 * invoiceRowHeight)
+	r.page.Text(invoiceMargin, r.y+12, pdf.Bold, invoiceFontSize, title)
+	r.y += invoiceRowHeight
+
+	for _, line := range pdf.Wrap(pdf.Regular, invoiceFontSize, text, invoiceRight-invoiceMargin) {
+		r.ensure(14)
+		r.page.Text(invoiceMargin, r.y+10, pdf.Regular, invoiceFontSize, line)
+		r.y += 14
+	}
+
+	r.y += 10
+}
+
+func (r *invoiceRenderer) money(amount float64) string {
+	return fmt.Sprintf("%s %.2f", r.invoice.Currency, amount)
+}
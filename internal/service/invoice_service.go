@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
@@ -34,6 +35,11 @@ type InvoiceService interface {
 	RecordPayment(ctx context.Context, id uuid.UUID, tenantID uuid.UUID, req *dto.RecordPaymentRequest) (*dto.InvoiceResponse, error)
 	CancelInvoice(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) error
 
+	// Booking Invoices
+	GenerateBookingInvoice(ctx context.Context, tenantID, bookingID uuid.UUID) (*dto.InvoiceResponse, error)
+	RecordBookingPayment(ctx context.Context, payment *models.Payment) (*dto.InvoiceResponse, error)
+	RenderInvoicePDF(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) (*dto.InvoicePDF, error)
+
 	// Statistics & Analytics
 	GetInvoiceStats(ctx context.Context, tenantID uuid.UUID) (*dto.InvoiceStatsResponse, error)
 }
@@ -66,13 +72,6 @@ func (s *invoiceService) CreateInvoice(ctx context.Context, tenantID uuid.UUID,
 		return nil, errors.NewValidationError("Customer does not belong to tenant")
 	}
 
-	// Generate invoice number
-	invoiceNumber, err := s.repos.Invoice.GenerateInvoiceNumber(ctx, tenantID)
-	if err != nil {
-		s.logger.Error("failed to generate invoice number", "error", err)
-		return nil, errors.NewServiceError("GENERATION_FAILED", "Failed to generate invoice number", err)
-	}
-
 	// Calculate amounts
 	var subtotal float64
 	for _, item := range req.LineItems {
@@ -84,7 +83,6 @@ func (s *invoiceService) CreateInvoice(ctx context.Context, tenantID uuid.UUID,
 	// Create invoice
 	invoice := &models.Invoice{
 		TenantID:        tenantID,
-		InvoiceNumber:   invoiceNumber,
 		BookingID:       req.BookingID,
 		CustomerID:      req.CustomerID,
 		IssueDate:       req.IssueDate,
@@ -102,7 +100,7 @@ func (s *invoiceService) CreateInvoice(ctx context.Context, tenantID uuid.UUID,
 		Metadata:        req.Metadata,
 	}
 
-	if err := s.repos.Invoice.Create(ctx, invoice); err != nil {
+	if err := s.repos.Invoice.CreateNumbered(ctx, invoice); err != nil {
 		s.logger.Error("failed to create invoice", "error", err)
 		return nil, errors.NewRepositoryError("CREATE_FAILED", "Failed to create invoice", err)
 	}
@@ -110,7 +108,7 @@ func (s *invoiceService) CreateInvoice(ctx context.Context, tenantID uuid.UUID,
 	// Load customer relationship
 	invoice.Customer = customer
 
-	s.logger.Info("invoice created", "invoice_id", invoice.ID, "invoice_number", invoiceNumber)
+	s.logger.Info("invoice created", "invoice_id", invoice.ID, "invoice_number", invoice.InvoiceNumber)
 	return dto.ToInvoiceResponse(invoice), nil
 }
 
@@ -140,17 +138,12 @@ func (s *invoiceService) GetInvoice(ctx context.Context, id uuid.UUID, tenantID
 
 // GetInvoiceByNumber retrieves an invoice by invoice number
 func (s *invoiceService) GetInvoiceByNumber(ctx context.Context, invoiceNumber string, tenantID uuid.UUID) (*dto.InvoiceResponse, error) {
-	invoice, err := s.repos.Invoice.GetByInvoiceNumber(ctx, invoiceNumber)
+	invoice, err := s.repos.Invoice.GetByInvoiceNumber(ctx, tenantID, invoiceNumber)
 	if err != nil {
 		s.logger.Error("failed to get invoice by number", "invoice_number", invoiceNumber, "error", err)
 		return nil, errors.NewNotFoundError("invoice")
 	}
 
-	// Verify tenant access
-	if invoice.TenantID != tenantID {
-		return nil, errors.NewNotFoundError("invoice")
-	}
-
 	// Load relationships
 	if invoice.CustomerID != uuid.Nil {
 		customer, err := s.repos.User.GetByID(ctx, invoice.CustomerID)
@@ -476,6 +469,162 @@ func (s *invoiceService) CancelInvoice(ctx context.Context, id uuid.UUID, tenant
 	return nil
 }
 
+// ============================================================================
+// Booking Invoices
+// ============================================================================
+
+// GenerateBookingInvoice returns the booking's invoice, issuing one from the booking's
+// service and addon lines and the tenant's tax settings if it has none yet
+func (s *invoiceService) GenerateBookingInvoice(ctx context.Context, tenantID, bookingID uuid.UUID) (*dto.InvoiceResponse, error) {
+	booking, err := s.repos.Booking.GetByID(ctx, bookingID)
+	if err != nil || booking.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("booking")
+	}
+
+	invoice, err := s.bookingInvoice(ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToInvoiceResponse(invoice), nil
+}
+
+// RecordBookingPayment applies a completed booking payment to the booking's invoice,
+// issuing the invoice first if needed. Redelivered payments are applied once.
+func (s *invoiceService) RecordBookingPayment(ctx context.Context, payment *models.Payment) (*dto.InvoiceResponse, error) {
+	if payment == nil {
+		return nil, errors.NewValidationError("payment is required")
+	}
+
+	switch payment.Type {
+	case models.PaymentTypeDeposit, models.PaymentTypeFull, models.PaymentTypeBalance:
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("%s payments are not invoiced", payment.Type))
+	}
+
+	booking, err := s.repos.Booking.GetByID(ctx, payment.BookingID)
+	if err != nil || booking.TenantID != payment.TenantID {
+		return nil, errors.NewNotFoundError("booking")
+	}
+
+	invoice, err := s.bookingInvoice(ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+
+	paidAt := time.Now()
+	if payment.ProcessedAt != nil {
+		paidAt = *payment.ProcessedAt
+	}
+
+	applied, err := s.repos.Invoice.ApplyPayment(ctx, invoice.ID, payment.ID, payment.Amount, paidAt)
+	if err != nil {
+		s.logger.Error("failed to apply payment to invoice", "invoice_id", invoice.ID, "payment_id", payment.ID, "error", err)
+		return nil, errors.NewServiceError("UPDATE_FAILED", "Failed to record payment on invoice", err)
+	}
+	if applied {
+		s.logger.Info("payment applied to invoice", "invoice_id", invoice.ID, "payment_id", payment.ID, "amount", payment.Amount)
+	}
+
+	updated, err := s.repos.Invoice.GetByID(ctx, invoice.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("FIND_FAILED", "Failed to retrieve updated invoice", err)
+	}
+
+	return dto.ToInvoiceResponse(updated), nil
+}
+
+// RenderInvoicePDF renders an invoice as a PDF document
+func (s *invoiceService) RenderInvoicePDF(ctx context.Context, id uuid.UUID, tenantID uuid.UUID) (*dto.InvoicePDF, error) {
+	invoice, err := s.repos.Invoice.GetByID(ctx, id)
+	if err != nil || invoice.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("invoice")
+	}
+
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("FIND_FAILED", "Failed to load tenant", err)
+	}
+
+	if invoice.Customer == nil && invoice.CustomerID != uuid.Nil {
+		if customer, err := s.repos.User.GetByID(ctx, invoice.CustomerID); err == nil {
+			invoice.Customer = customer
+		}
+	}
+
+	return &dto.InvoicePDF{
+		FileName: fmt.Sprintf("%s.pdf", invoice.InvoiceNumber),
+		Content:  renderInvoicePDF(invoice, tenant),
+	}, nil
+}
+
+// bookingInvoice returns the booking's open invoice or issues a new one
+func (s *invoiceService) bookingInvoice(ctx context.Context, booking *models.Booking) (*models.Invoice, error) {
+	existing, err := s.repos.Invoice.GetByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("FIND_FAILED", "Failed to load booking invoices", err)
+	}
+	for _, invoice := range existing {
+		if invoice.Status != models.InvoiceStatusCancelled {
+			return invoice, nil
+		}
+	}
+
+	serviceName := "Service"
+	if service, err := s.repos.Service.GetByID(ctx, booking.ServiceID); err == nil {
+		serviceName = service.Name
+	}
+
+	lineItems := models.InvoiceLineItems{{
+		Description: fmt.Sprintf("%s (%s)", serviceName, booking.StartTime.Format("Jan 2, 2006 3:04 PM")),
+		Quantity:    1,
+		UnitPrice:   booking.BasePrice,
+		TotalPrice:  booking.BasePrice,
+	}}
+	subtotal := booking.BasePrice
+	for _, addon := range booking.Addons {
+		lineItems = append(lineItems, models.InvoiceLineItem{
+			Description: addon.Name,
+			Quantity:    addon.Quantity,
+			UnitPrice:   addon.UnitPrice,
+			TotalPrice:  addon.Price,
+		})
+		subtotal += addon.Price
+	}
+
+	// The tax was fixed when the booking was priced
+	taxLines := booking.TaxLines
+	issueDate := time.Now()
+	dueDate := booking.StartTime
+	if dueDate.Before(issueDate) {
+		dueDate = issueDate
+	}
+
+	invoice := &models.Invoice{
+		TenantID:       booking.TenantID,
+		BookingID:      &booking.ID,
+		CustomerID:     booking.CustomerID,
+		IssueDate:      issueDate,
+		DueDate:        dueDate,
+		SubtotalAmount: subtotal,
+		DiscountAmount: booking.DiscountAmount,
+		TaxAmount:      taxLines.Exclusive(),
+		TotalAmount:    booking.Subtotal() + taxLines.Exclusive(),
+		Currency:       booking.Currency,
+		Status:         models.InvoiceStatusSent,
+		LineItems:      lineItems,
+		TaxLines:       taxLines,
+	}
+
+	if err := s.repos.Invoice.CreateNumbered(ctx, invoice); err != nil {
+		s.logger.Error("failed to create booking invoice", "booking_id", booking.ID, "error", err)
+		return nil, errors.NewServiceError("CREATE_FAILED", "Failed to create invoice", err)
+	}
+
+	s.logger.Info("booking invoice issued", "invoice_id", invoice.ID, "invoice_number", invoice.InvoiceNumber, "booking_id", booking.ID)
+	return invoice, nil
+}
+
 // GetInvoiceStats retrieves invoice statistics
 func (s *invoiceService) GetInvoiceStats(ctx context.Context, tenantID uuid.UUID) (*dto.InvoiceStatsResponse, error) {
 	stats, err := s.repos.Invoice.GetInvoiceStats(ctx, tenantID)
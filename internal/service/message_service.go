@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
@@ -27,6 +28,11 @@ type MessageService interface {
 	GetConversationByBooking(ctx context.Context, bookingID uuid.UUID, userID uuid.UUID, page, pageSize int) (*dto.MessageListResponse, error)
 	GetConversationList(ctx context.Context, userID uuid.UUID) (*dto.ConversationListResponse, error)
 
+	// Booking Conversations
+	PostBookingMessage(ctx context.Context, bookingID, senderID uuid.UUID, req *dto.BookingMessageRequest) (*dto.MessageResponse, error)
+	ListBookingMessages(ctx context.Context, bookingID, userID uuid.UUID, page, pageSize int) (*dto.BookingConversationResponse, error)
+	MarkBookingConversationRead(ctx context.Context, bookingID, userID uuid.UUID) error
+
 	// Message Queries
 	ListMessages(ctx context.Context, filter *dto.MessageFilter) (*dto.MessageListResponse, error)
 	ListUnreadMessages(ctx context.Context, userID uuid.UUID) ([]*dto.MessageResponse, error)
@@ -311,6 +317,165 @@ func (s *messageService) GetConversationByBooking(ctx context.Context, bookingID
 	}, nil
 }
 
+// ============================================================================
+// Booking Conversations
+// ============================================================================
+
+// maxMessagePreviewLength bounds the last-message preview stored on a conversation
+const maxMessagePreviewLength = 255
+
+// PostBookingMessage posts a message to a booking's chat thread, creating the thread on first use
+func (s *messageService) PostBookingMessage(ctx context.Context, bookingID, senderID uuid.UUID, req *dto.BookingMessageRequest) (*dto.MessageResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	booking, err := s.getBookingForParticipant(ctx, bookingID, senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ParentMessageID != nil {
+		parent, err := s.repos.Message.GetByID(ctx, *req.ParentMessageID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("parent message")
+		}
+		if parent.BookingID == nil || *parent.BookingID != bookingID {
+			return nil, errors.NewValidationError("Parent message not part of this booking conversation")
+		}
+	}
+
+	conversation, err := s.repos.BookingConversation.GetOrCreate(ctx, booking)
+	if err != nil {
+		s.logger.Error("failed to get booking conversation", "booking_id", bookingID, "error", err)
+		return nil, errors.NewServiceError("CONVERSATION_FAILED", "Failed to open booking conversation", err)
+	}
+
+	message := &models.Message{
+		TenantID:        booking.TenantID,
+		SenderID:        senderID,
+		ReceiverID:      conversation.OtherParticipant(senderID),
+		BookingID:       &booking.ID,
+		Type:            req.Type,
+		Content:         req.Content,
+		FileURL:         req.FileURL,
+		Status:          models.MessageStatusSent,
+		ParentMessageID: req.ParentMessageID,
+		Metadata:        req.Metadata,
+	}
+
+	if err := s.repos.Message.Create(ctx, message); err != nil {
+		s.logger.Error("failed to create booking message", "booking_id", bookingID, "error", err)
+		return nil, errors.NewRepositoryError("CREATE_FAILED", "Failed to send message", err)
+	}
+
+	// The message is delivered even if the counters fail; marking the thread read resets them
+	if err := s.repos.BookingConversation.RecordMessage(ctx, conversation.ID, message.ReceiverID, messagePreview(message), message.CreatedAt); err != nil {
+		s.logger.Warn("failed to update conversation counters", "conversation_id", conversation.ID, "error", err)
+	}
+
+	message.Sender, _ = s.repos.User.GetByID(ctx, message.SenderID)
+	message.Receiver, _ = s.repos.User.GetByID(ctx, message.ReceiverID)
+
+	s.logger.Info("booking message sent", "message_id", message.ID, "booking_id", bookingID, "sender_id", senderID)
+	return dto.ToMessageResponse(message), nil
+}
+
+// ListBookingMessages lists a booking's chat thread, newest first, with the caller's unread count
+func (s *messageService) ListBookingMessages(ctx context.Context, bookingID, userID uuid.UUID, page, pageSize int) (*dto.BookingConversationResponse, error) {
+	if _, err := s.getBookingForParticipant(ctx, bookingID, userID); err != nil {
+		return nil, err
+	}
+
+	pagination := repository.PaginationParams{
+		Page:     max(page, 1),
+		PageSize: min(max(pageSize, 1), 100),
+	}
+
+	messages, paginationResult, err := s.repos.Message.FindConversationByBooking(ctx, bookingID, pagination)
+	if err != nil {
+		s.logger.Error("failed to get booking conversation", "booking_id", bookingID, "error", err)
+		return nil, errors.NewServiceError("FIND_FAILED", "Failed to get booking conversation", err)
+	}
+
+	response := &dto.BookingConversationResponse{
+		BookingID:   bookingID,
+		Messages:    dto.ToMessageResponses(messages),
+		Page:        paginationResult.Page,
+		PageSize:    paginationResult.PageSize,
+		TotalItems:  paginationResult.TotalItems,
+		TotalPages:  paginationResult.TotalPages,
+		HasNext:     paginationResult.HasNext,
+		HasPrevious: paginationResult.HasPrev,
+	}
+
+	conversation, err := s.repos.BookingConversation.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		if !errors.IsNotFoundError(err) {
+			s.logger.Warn("failed to load booking conversation", "booking_id", bookingID, "error", err)
+		}
+		return response, nil
+	}
+
+	response.ConversationID = &conversation.ID
+	response.UnreadCount = conversation.UnreadCountFor(userID)
+	response.LastMessageAt = conversation.LastMessageAt
+	return response, nil
+}
+
+// MarkBookingConversationRead marks every message the user received in a booking's thread as read
+func (s *messageService) MarkBookingConversationRead(ctx context.Context, bookingID, userID uuid.UUID) error {
+	if _, err := s.getBookingForParticipant(ctx, bookingID, userID); err != nil {
+		return err
+	}
+
+	if err := s.repos.Message.MarkBookingMessagesAsRead(ctx, bookingID, userID); err != nil {
+		s.logger.Error("failed to mark booking messages as read", "booking_id", bookingID, "user_id", userID, "error", err)
+		return errors.NewRepositoryError("UPDATE_FAILED", "Failed to mark conversation as read", err)
+	}
+
+	conversation, err := s.repos.BookingConversation.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil
+		}
+		return errors.NewServiceError("CONVERSATION_FAILED", "Failed to load booking conversation", err)
+	}
+
+	if err := s.repos.BookingConversation.ResetUnread(ctx, conversation.ID, userID); err != nil {
+		return errors.NewServiceError("UPDATE_FAILED", "Failed to reset unread counter", err)
+	}
+
+	s.logger.Info("booking conversation marked as read", "booking_id", bookingID, "user_id", userID)
+	return nil
+}
+
+// getBookingForParticipant loads a booking and ensures the user is its customer or artisan
+func (s *messageService) getBookingForParticipant(ctx context.Context, bookingID, userID uuid.UUID) (*models.Booking, error) {
+	booking, err := s.repos.Booking.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("booking")
+	}
+
+	if booking.CustomerID != userID && booking.ArtisanID != userID {
+		return nil, errors.NewForbiddenError("Only the booking's customer and artisan can access its conversation")
+	}
+
+	return booking, nil
+}
+
+// messagePreview returns the conversation preview for a message
+func messagePreview(message *models.Message) string {
+	preview := strings.TrimSpace(message.Content)
+	if preview == "" {
+		return fmt.Sprintf("[%s]", message.Type)
+	}
+	if runes := []rune(preview); len(runes) > maxMessagePreviewLength {
+		preview = string(runes[:maxMessagePreviewLength-3]) + "..."
+	}
+	return preview
+}
+
 // GetConversationList retrieves all conversations for a user
 func (s *messageService) GetConversationList(ctx context.Context, userID uuid.UUID) (*dto.ConversationListResponse, error) {
 	conversations, err := s.repos.Message.GetConversationList(ctx, userID)
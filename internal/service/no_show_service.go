@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// NoShowService defines the interface for the no-show automation
+type NoShowService interface {
+	// ProcessNoShows marks confirmed bookings whose grace period has passed as no-show,
+	// charges the no-show fee where the tenant enabled it and notifies both parties
+	ProcessNoShows(ctx context.Context) (*dto.NoShowRunResponse, error)
+}
+
+// noShowService implements NoShowService
+type noShowService struct {
+	repos         *repository.Repositories
+	logger        log.AllLogger
+	bookings      BookingService
+	payments      PaymentService
+	notifications NotificationService
+	policies      CancellationPolicyService
+}
+
+// NewNoShowService creates a new NoShowService instance
+func NewNoShowService(repos *repository.Repositories, logger log.AllLogger, bookingService BookingService, paymentService PaymentService, notificationService NotificationService) NoShowService {
+	return &noShowService{
+		repos:         repos,
+		logger:        logger,
+		bookings:      bookingService,
+		payments:      paymentService,
+		notifications: notificationService,
+		policies:      NewCancellationPolicyService(repos, logger),
+	}
+}
+
+// noShowTenantStatuses are the tenants whose bookings are processed
+var noShowTenantStatuses = []models.TenantStatus{models.TenantStatusActive, models.TenantStatusTrial}
+
+// noShowTenantPageSize is the number of tenants loaded per query
+const noShowTenantPageSize = 100
+
+// ProcessNoShows runs the no-show automation over every operating tenant
+func (s *noShowService) ProcessNoShows(ctx context.Context) (*dto.NoShowRunResponse, error) {
+	result := &dto.NoShowRunResponse{}
+
+	for _, status := range noShowTenantStatuses {
+		pagination := repository.PaginationParams{Page: 1, PageSize: noShowTenantPageSize}
+		for {
+			tenants, page, err := s.repos.Tenant.FindByStatus(ctx, status, pagination)
+			if err != nil {
+				return result, errors.NewServiceError("QUERY_FAILED", "failed to list tenants", err)
+			}
+
+			for _, tenant := range tenants {
+				if ctx.Err() != nil {
+					return result, ctx.Err()
+				}
+				if !tenant.Settings.AutoMarkNoShow {
+					continue
+				}
+				result.TenantsChecked++
+				s.processTenant(ctx, tenant, result)
+			}
+
+			if !page.HasNext {
+				break
+			}
+			pagination.Page++
+		}
+	}
+
+	if result.BookingsMarked > 0 || result.Failures > 0 {
+		s.logger.Info("no-show automation completed",
+			"tenants", result.TenantsChecked,
+			"marked", result.BookingsMarked,
+			"fees_charged", result.FeesCharged,
+			"failures", result.Failures)
+	}
+
+	return result, nil
+}
+
+// processTenant marks the tenant's overdue confirmed bookings as no-show
+func (s *noShowService) processTenant(ctx context.Context, tenant *models.Tenant, result *dto.NoShowRunResponse) {
+	bookings, err := s.repos.Booking.GetPastDueBookings(ctx, tenant.ID)
+	if err != nil {
+		s.logger.Error("failed to get past due bookings", "tenant_id", tenant.ID, "error", err)
+		result.Failures++
+		return
+	}
+
+	grace := time.Duration(tenant.Settings.NoShowGraceMinutes) * time.Minute
+	cutoff := time.Now().Add(-grace)
+
+	for _, booking := range bookings {
+		// Pending bookings were never confirmed, so the customer cannot have missed them
+		if booking.Status != models.BookingStatusConfirmed || !booking.StartTime.Before(cutoff) {
+			continue
+		}
+
+		marked, err := s.markNoShow(ctx, booking)
+		if err != nil {
+			s.logger.Error("failed to mark booking as no-show", "booking_id", booking.ID, "error", err)
+			result.Failures++
+			continue
+		}
+		if !marked {
+			continue
+		}
+		result.BookingsMarked++
+
+		var fee float64
+		if tenant.Settings.ChargeNoShowFee {
+			fee, err = s.chargeNoShowFee(ctx, tenant, booking)
+			if err != nil {
+				s.logger.Error("failed to charge no-show fee", "booking_id", booking.ID, "error", err)
+				result.Failures++
+			} else if fee > 0 {
+				result.FeesCharged++
+				result.FeeTotal += fee
+			}
+		}
+
+		if err := s.notifications.SendNoShowNotifications(ctx, booking, fee); err != nil {
+			s.logger.Error("failed to send no-show notifications", "booking_id", booking.ID, "error", err)
+		}
+	}
+}
+
+// markNoShow moves the booking to no-show. The update is pinned to the version that was
+// read, so a booking changed in the meantime (or already processed by another instance)
+// is skipped rather than overwritten.
+func (s *noShowService) markNoShow(ctx context.Context, booking *models.Booking) (bool, error) {
+	status := models.BookingStatusNoShow
+	version := booking.Version
+
+	if _, err := s.bookings.UpdateBooking(ctx, booking.ID, &dto.UpdateBookingRequest{
+		Version: &version,
+		Status:  &status,
+	}); err != nil {
+		if errors.IsConflict(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	booking.Status = status
+	return true, nil
+}
+
+// chargeNoShowFee records the no-show fee owed on top of what the customer already paid
+func (s *noShowService) chargeNoShowFee(ctx context.Context, tenant *models.Tenant, booking *models.Booking) (float64, error) {
+	policy, err := s.policies.GetEffectivePolicy(ctx, booking.TenantID, booking.ServiceID)
+	if err != nil {
+		return 0, err
+	}
+
+	// A deposit that was already paid is retained towards the fee
+	breakdown := policy.EvaluateNoShow(booking)
+	fee := math.Round((breakdown.NoShowFee-breakdown.AmountPaid)*100) / 100
+	if fee <= 0 {
+		return 0, nil
+	}
+
+	artisanID := booking.ArtisanID
+	if _, err := s.payments.CreatePayment(ctx, &dto.CreatePaymentRequest{
+		TenantID:       booking.TenantID,
+		BookingID:      booking.ID,
+		CustomerID:     booking.CustomerID,
+		ArtisanID:      &artisanID,
+		Amount:         fee,
+		Currency:       booking.Currency,
+		Method:         s.paymentMethodFor(ctx, booking.CustomerID),
+		Type:           models.PaymentTypeNoShow,
+		CommissionRate: tenant.Settings.PlatformCommissionRate,
+		Metadata: models.JSONB{
+			"reason":                 "no_show_fee",
+			"no_show_fee_percentage": policy.NoShowFeePercentage,
+			"amount_retained":        breakdown.AmountPaid,
+		},
+	}); err != nil {
+		return 0, err
+	}
+
+	return fee, nil
+}
+
+// paymentMethodFor returns the customer's preferred payment method, defaulting to card
+func (s *noShowService) paymentMethodFor(ctx context.Context, customerID uuid.UUID) models.PaymentMethod {
+	methods, err := s.payments.GetPreferredPaymentMethods(ctx, customerID)
+	if err != nil || len(methods) == 0 {
+		return models.PaymentMethodCard
+	}
+	return methods[0]
+}
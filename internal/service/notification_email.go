@@ -0,0 +1,161 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"net/mail"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/i18n"
+)
+
+// notificationEmail is a notification rendered as an email in its tenant's branding
+type notificationEmail struct {
+	From         string
+	ReplyTo      string
+	Subject      string
+	HTML         string
+	DKIMDomain   string // Domain the email is signed for, with the tenant's key; empty for the platform's
+	DKIMSelector string
+	Attachments  []emailAttachment
+}
+
+// emailAttachment is a file attached to a notification email, such as a calendar
+// invite or an invoice
+type emailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// notificationEmailTemplate lays out notification emails with inline styles, which
+// email clients keep where they drop style sheets
+var notificationEmailTemplate = template.Must(template.New("notification_email").Parse(`<!DOCTYPE html>
+<html>
+<body style="margin:0;padding:0;background:#F3F4F6;font-family:{{with .FontFamily}}{{.}}{{else}}Arial, sans-serif{{end}};">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0"><tr><td align="center" style="padding:24px;">
+<table role="presentation" width="600" cellpadding="0" cellspacing="0" style="background:#FFFFFF;">
+<tr><td style="background:{{.Email.HeaderColor}};padding:20px;">
+{{- if .Email.LogoURL}}<img src="{{.Email.LogoURL}}" alt="{{.Name}}" height="40">{{else}}<span style="color:{{.Email.ButtonTextColor}};font-size:20px;font-weight:bold;">{{.Name}}</span>{{end -}}
+</td></tr>
+<tr><td style="padding:24px;color:#111827;">
+<h1 style="font-size:20px;margin:0 0 16px;">{{.Title}}</h1>
+{{range .Paragraphs}}<p style="font-size:15px;line-height:1.5;margin:0 0 12px;">{{.}}</p>
+{{end -}}
+{{if .ActionURL}}<p style="margin:24px 0 0;"><a href="{{.ActionURL}}" style="background:{{.Email.ButtonColor}};color:{{.Email.ButtonTextColor}};padding:12px 20px;text-decoration:none;display:inline-block;">{{.ActionText}}</a></p>
+{{end -}}
+</td></tr>
+<tr><td style="padding:16px 24px;font-size:12px;color:#6B7280;border-top:1px solid #E5E7EB;">
+{{- if .FooterText}}<p style="margin:0 0 8px;">{{.FooterText}}</p>{{end}}
+{{- if .SupportEmail}}<p style="margin:0 0 8px;">{{.Text.Questions}} <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>{{end}}
+{{- if .UnsubscribeLink}}<p style="margin:0 0 8px;"><a href="{{.UnsubscribeLink}}">{{.Text.Unsubscribe}}</a></p>{{end}}
+{{- if .ShowPoweredBy}}<p style="margin:0;">{{.Text.PoweredBy}}</p>{{end -}}
+</td></tr>
+</table>
+</td></tr></table>
+{{- if .OpenTrackingURL}}<img src="{{.OpenTrackingURL}}" width="1" height="1" alt="">{{end}}
+</body>
+</html>
+`))
+
+// notificationEmailText is the text emails add around a notification, in its
+// recipient's language
+type notificationEmailText struct {
+	Questions   string
+	Unsubscribe string
+	PoweredBy   string
+}
+
+// renderNotificationEmail renders a notification as an email in branding, with its
+// unsubscribe link and open tracking image where it has them, and the text around it
+// in the localizer's language
+func renderNotificationEmail(notification *models.Notification, branding models.Branding, localizer i18n.Localizer, unsubscribeLink, openTrackingURL string) (*notificationEmail, error) {
+	var paragraphs []string
+	for paragraph := range strings.SplitSeq(notification.Message, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	actionText := notification.ActionText
+	if actionText == "" {
+		actionText = localizer.T("email.view_details", nil)
+	}
+
+	var body bytes.Buffer
+	err := notificationEmailTemplate.Execute(&body, struct {
+		models.Branding
+		Title           string
+		Paragraphs      []string
+		ActionURL       string
+		ActionText      string
+		UnsubscribeLink string
+		OpenTrackingURL string
+		Text            notificationEmailText
+	}{
+		Branding:        branding,
+		Title:           notification.Title,
+		Paragraphs:      paragraphs,
+		ActionURL:       notification.ActionURL,
+		ActionText:      actionText,
+		UnsubscribeLink: unsubscribeLink,
+		OpenTrackingURL: openTrackingURL,
+		Text: notificationEmailText{
+			Questions:   localizer.T("email.questions", nil),
+			Unsubscribe: localizer.T("email.unsubscribe", nil),
+			PoweredBy:   localizer.T("email.powered_by", i18n.Args{"platform": models.PlatformName}),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &notificationEmail{
+		From:         (&mail.Address{Name: branding.Email.FromName, Address: branding.Email.FromAddress}).String(),
+		ReplyTo:      branding.Email.ReplyTo,
+		Subject:      notification.Title,
+		HTML:         body.String(),
+		DKIMDomain:   branding.Email.DKIMDomain,
+		DKIMSelector: branding.Email.DKIMSelector,
+		Attachments:  notificationAttachments(notification.Metadata),
+	}, nil
+}
+
+// notificationAttachments decodes the attachment descriptors in a notification's
+// metadata. They are a []map[string]any when set in memory and a []any once read back
+// from the database; descriptors that can't be decoded are skipped.
+func notificationAttachments(metadata models.JSONB) []emailAttachment {
+	var descriptors []map[string]any
+	switch list := metadata["attachments"].(type) {
+	case []map[string]any:
+		descriptors = list
+	case []any:
+		for _, item := range list {
+			if descriptor, ok := item.(map[string]any); ok {
+				descriptors = append(descriptors, descriptor)
+			}
+		}
+	}
+
+	attachments := make([]emailAttachment, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		filename, _ := descriptor["filename"].(string)
+		contentType, _ := descriptor["content_type"].(string)
+		content, _ := descriptor["content"].(string)
+		if filename == "" || contentType == "" {
+			continue
+		}
+
+		data := []byte(content)
+		if encoding, _ := descriptor["encoding"].(string); encoding == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(content)
+			if err != nil {
+				continue
+			}
+			data = decoded
+		}
+		attachments = append(attachments, emailAttachment{Filename: filename, ContentType: contentType, Content: data})
+	}
+	return attachments
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/i18n"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingConfirmationEmailCarriesCalendarInvite(t *testing.T) {
+	start := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	booking := &models.Booking{
+		BaseModel: models.BaseModel{ID: uuid.New()},
+		Status:    models.BookingStatusConfirmed,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Service:   &models.Service{Name: "Haircut"},
+	}
+	requested := bookingNotificationMetadata(booking, models.NotificationTypeBookingConfirmed)
+
+	tests := []struct {
+		name     string
+		metadata models.JSONB
+	}{
+		{name: "as requested", metadata: requested},
+		{name: "as stored", metadata: notificationMetadata(requested)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notification := &models.Notification{
+				Type:     models.NotificationTypeBookingConfirmed,
+				Title:    "Booking confirmed",
+				Message:  "See you soon.",
+				Metadata: tt.metadata,
+			}
+
+			email, err := renderNotificationEmail(notification, models.ResolveBranding(&models.Tenant{}, nil), i18n.New(), "", "")
+			require.NoError(t, err)
+
+			require.Len(t, email.Attachments, 1)
+			invite := email.Attachments[0]
+			assert.Equal(t, "text/calendar; charset=utf-8; method=REQUEST", invite.ContentType)
+			assert.Equal(t, "booking-"+booking.ID.String()[:8]+".ics", invite.Filename)
+			assert.Contains(t, string(invite.Content), "BEGIN:VCALENDAR")
+			assert.Contains(t, string(invite.Content), "SUMMARY:Haircut")
+		})
+	}
+}
+
+func TestBookingReminderEmailHasNoAttachments(t *testing.T) {
+	booking := &models.Booking{BaseModel: models.BaseModel{ID: uuid.New()}, StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)}
+	notification := &models.Notification{
+		Title:    "Booking reminder",
+		Metadata: notificationMetadata(bookingNotificationMetadata(booking, models.NotificationTypeBookingReminder)),
+	}
+
+	email, err := renderNotificationEmail(notification, models.ResolveBranding(&models.Tenant{}, nil), i18n.New(), "", "")
+	require.NoError(t, err)
+	assert.Empty(t, email.Attachments)
+}
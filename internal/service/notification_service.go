@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/background"
 	"Krafti_Vibe/internal/pkg/errors"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
@@ -35,8 +38,19 @@ type NotificationService interface {
 
 	// Business Event Notifications
 	SendBookingNotification(ctx context.Context, booking *models.Booking, notifType models.NotificationType) (*dto.NotificationDeliveryResponse, error)
-	SendPaymentNotification(ctx context.Context, payment *models.Payment, notifType models.NotificationType) (*dto.NotificationDeliveryResponse, error)
+	SendArtisanBookingNotification(ctx context.Context, booking *models.Booking) (*dto.NotificationDeliveryResponse, error)
+	SendNoShowNotifications(ctx context.Context, booking *models.Booking, fee float64) error
+	SendBookingExportReadyNotification(ctx context.Context, export *models.BookingExport, downloadURL string) (*dto.NotificationDeliveryResponse, error)
+	SendProjectHealthAlert(ctx context.Context, project *models.Project, userID uuid.UUID, notifType models.NotificationType, snapshot *models.ProjectHealthSnapshot) (*dto.NotificationDeliveryResponse, error)
+	SendPaymentNotification(ctx context.Context, payment *models.Payment, notifType models.NotificationType, attachments ...map[string]any) (*dto.NotificationDeliveryResponse, error)
+	SendBalancePaymentFailedNotification(ctx context.Context, booking *models.Booking, amount float64, stage models.DunningStage, nextAttempt *time.Time) (*dto.NotificationDeliveryResponse, error)
 	SendReviewNotification(ctx context.Context, review *models.Review, notifType models.NotificationType) (*dto.NotificationDeliveryResponse, error)
+	SendReviewRequestNotification(ctx context.Context, booking *models.Booking) (*dto.NotificationDeliveryResponse, error)
+	SendVerificationNotification(ctx context.Context, document *models.VerificationDocument, userID uuid.UUID) (*dto.NotificationDeliveryResponse, error)
+	SendFavoriteArtisanNotification(ctx context.Context, favorite *models.Favorite, artisan *models.Artisan, service *models.Service) (*dto.NotificationDeliveryResponse, error)
+	SendNewLeadNotification(ctx context.Context, request *models.CustomerRequest, artisanUserID uuid.UUID) (*dto.NotificationDeliveryResponse, error)
+	SendBidNotification(ctx context.Context, request *models.CustomerRequest, bid *models.CustomerRequestBid, userID uuid.UUID) (*dto.NotificationDeliveryResponse, error)
+	SendCampaignNotification(ctx context.Context, campaign *models.Campaign, recipient *models.CampaignRecipient, subject, body string, channels []models.NotificationChannel, openTrackingURL string) (*dto.NotificationDeliveryResponse, error)
 	SendSystemNotification(ctx context.Context, req *dto.SendSystemNotificationRequest) (*dto.BulkNotificationResponse, error)
 
 	// Query Operations
@@ -85,23 +99,34 @@ func (s *notificationService) CreateNotification(ctx context.Context, req *dto.C
 		return nil, errors.NewValidationError("invalid request: " + err.Error())
 	}
 
-	metadata := models.JSONB{}
-	if req.Metadata != nil {
-		metadataBytes, err := json.Marshal(req.Metadata)
-		if err == nil {
-			json.Unmarshal(metadataBytes, &metadata)
+	metadata := notificationMetadata(req.Metadata)
+
+	// Render templates in the recipient's language, falling back to their tenant's and
+	// then English for messages their language's catalog doesn't have
+	title, message, actionText := req.Title, req.Message, req.ActionText
+	if req.Template != "" {
+		localizer := s.recipientLocalizer(ctx, req.TenantID, req.UserID)
+		rendered, ok := renderNotificationTemplate(localizer, req.Template, req.TemplateArgs)
+		if !ok {
+			return nil, errors.NewValidationError("unknown notification template: " + req.Template)
+		}
+		title, message = rendered.Title, rendered.Message
+		if rendered.ActionText != "" {
+			actionText = rendered.ActionText
 		}
+		metadata["template"] = req.Template
+		metadata["locale"] = localizer.Locale()
 	}
 
 	notification := &models.Notification{
 		TenantID:          req.TenantID,
 		UserID:            req.UserID,
 		Type:              req.Type,
-		Title:             req.Title,
-		Message:           req.Message,
+		Title:             title,
+		Message:           message,
 		Channels:          req.Channels,
 		ActionURL:         req.ActionURL,
-		ActionText:        req.ActionText,
+		ActionText:        actionText,
 		RelatedEntityType: req.RelatedEntityType,
 		RelatedEntityID:   req.RelatedEntityID,
 		Priority:          req.Priority,
@@ -115,7 +140,9 @@ func (s *notificationService) CreateNotification(ctx context.Context, req *dto.C
 	}
 
 	// Asynchronously send via channels
-	go s.sendViaChannels(context.Background(), notification)
+	background.Go(ctx, "notification_delivery", func(ctx context.Context) {
+		s.sendViaChannels(ctx, notification)
+	})
 
 	s.logger.Info("notification created",
 		"notification_id", notification.ID,
@@ -125,6 +152,18 @@ func (s *notificationService) CreateNotification(ctx context.Context, req *dto.C
 	return dto.ToNotificationResponse(notification), nil
 }
 
+// notificationMetadata copies request metadata into the JSON form it is stored in
+func notificationMetadata(requested map[string]any) models.JSONB {
+	metadata := models.JSONB{}
+	if requested != nil {
+		metadataBytes, err := json.Marshal(requested)
+		if err == nil {
+			json.Unmarshal(metadataBytes, &metadata)
+		}
+	}
+	return metadata
+}
+
 // GetNotification retrieves a notification by ID
 func (s *notificationService) GetNotification(ctx context.Context, id uuid.UUID) (*dto.NotificationResponse, error) {
 	notification, err := s.repos.Notification.GetByID(ctx, id)
@@ -309,7 +348,9 @@ func (s *notificationService) SendBulkNotification(ctx context.Context, req *dto
 		response.SuccessCount++
 		response.CreatedIDs = append(response.CreatedIDs, notification.ID)
 
-		go s.sendViaChannels(context.Background(), notification)
+		background.Go(ctx, "notification_delivery", func(ctx context.Context) {
+			s.sendViaChannels(ctx, notification)
+		})
 	}
 
 	s.logger.Info("bulk notifications created",
@@ -363,34 +404,29 @@ func (s *notificationService) SendBookingNotification(ctx context.Context, booki
 		return nil, errors.NewValidationError("booking is required")
 	}
 
-	var title, message string
+	var template string
 	var userID uuid.UUID
 	var priority int = 5
 
 	switch notifType {
 	case models.NotificationTypeBookingCreated:
-		title = "New Booking Created"
-		message = fmt.Sprintf("Your booking #%s has been created successfully", booking.ID.String()[:8])
+		template = "booking_created"
 		userID = booking.CustomerID
 		priority = 6
 	case models.NotificationTypeBookingConfirmed:
-		title = "Booking Confirmed"
-		message = fmt.Sprintf("Your booking #%s has been confirmed", booking.ID.String()[:8])
+		template = "booking_confirmed"
 		userID = booking.CustomerID
 		priority = 7
 	case models.NotificationTypeBookingCancelled:
-		title = "Booking Cancelled"
-		message = fmt.Sprintf("Booking #%s has been cancelled", booking.ID.String()[:8])
+		template = "booking_cancelled"
 		userID = booking.CustomerID
 		priority = 8
 	case models.NotificationTypeBookingReminder:
-		title = "Booking Reminder"
-		message = fmt.Sprintf("Reminder: Your booking is scheduled for %s", booking.StartTime.Format("Jan 2, 2006 at 3:04 PM"))
+		template = "booking_reminder"
 		userID = booking.CustomerID
 		priority = 7
 	case models.NotificationTypeBookingCompleted:
-		title = "Booking Completed"
-		message = fmt.Sprintf("Your booking #%s has been completed", booking.ID.String()[:8])
+		template = "booking_completed"
 		userID = booking.CustomerID
 		priority = 5
 	default:
@@ -401,16 +437,17 @@ func (s *notificationService) SendBookingNotification(ctx context.Context, booki
 		TenantID:          booking.TenantID,
 		UserID:            userID,
 		Type:              notifType,
-		Title:             title,
-		Message:           message,
+		Template:          template,
+		TemplateArgs:      bookingTemplateArgs(booking),
 		Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
 		ActionURL:         fmt.Sprintf("/bookings/%s", booking.ID),
-		ActionText:        "View Booking",
 		RelatedEntityType: "booking",
 		RelatedEntityID:   &booking.ID,
 		Priority:          priority,
 	}
 
+	req.Metadata = bookingNotificationMetadata(booking, notifType)
+
 	notification, err := s.CreateNotification(ctx, req)
 	if err != nil {
 		return nil, err
@@ -425,8 +462,211 @@ func (s *notificationService) SendBookingNotification(ctx context.Context, booki
 	}, nil
 }
 
-// SendPaymentNotification sends notification for payment events
-func (s *notificationService) SendPaymentNotification(ctx context.Context, payment *models.Payment, notifType models.NotificationType) (*dto.NotificationDeliveryResponse, error) {
+// bookingNotificationMetadata attaches a calendar invite to booking confirmations and
+// cancellations, so the booking lands in, or leaves, the customer's calendar
+func bookingNotificationMetadata(booking *models.Booking, notifType models.NotificationType) map[string]any {
+	if notifType != models.NotificationTypeBookingConfirmed && notifType != models.NotificationTypeBookingCancelled {
+		return nil
+	}
+	return map[string]any{
+		"attachments": []map[string]any{bookingICSAttachment(booking)},
+	}
+}
+
+// SendArtisanBookingNotification tells the artisan about a new booking, including the
+// customer's intake answers when booking.Service is loaded
+func (s *notificationService) SendArtisanBookingNotification(ctx context.Context, booking *models.Booking) (*dto.NotificationDeliveryResponse, error) {
+	if booking == nil {
+		return nil, errors.NewValidationError("booking is required")
+	}
+
+	template := "artisan_booking_created"
+	args := bookingTemplateArgs(booking)
+	metadata := map[string]any{}
+
+	if booking.Service != nil {
+		if summary := booking.Service.IntakeForm.Summary(booking.IntakeAnswers); len(summary) > 0 {
+			template = "artisan_booking_created_intake"
+			args["intake_answers"] = strings.Join(summary, "\n")
+			metadata["intake_answers"] = booking.IntakeAnswers
+		}
+	}
+
+	notification, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+		TenantID:          booking.TenantID,
+		UserID:            booking.ArtisanID,
+		Type:              models.NotificationTypeBookingCreated,
+		Template:          template,
+		TemplateArgs:      args,
+		Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
+		ActionURL:         fmt.Sprintf("/bookings/%s", booking.ID),
+		RelatedEntityType: "booking",
+		RelatedEntityID:   &booking.ID,
+		Priority:          6,
+		Metadata:          metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
+// SendNoShowNotifications tells the customer and the artisan that a booking was marked as no-show.
+// A positive fee is mentioned in the customer's notification.
+func (s *notificationService) SendNoShowNotifications(ctx context.Context, booking *models.Booking, fee float64) error {
+	if booking == nil {
+		return errors.NewValidationError("booking is required")
+	}
+
+	args := bookingTemplateArgs(booking)
+	customerTemplate := "booking_no_show_customer"
+	if fee > 0 {
+		customerTemplate = "booking_no_show_customer_fee"
+		args["fee"] = fmt.Sprintf("%.2f", fee)
+		args["currency"] = booking.Currency
+	}
+
+	recipients := []struct {
+		userID   uuid.UUID
+		template string
+	}{
+		{booking.CustomerID, customerTemplate},
+		{booking.ArtisanID, "booking_no_show_artisan"},
+	}
+
+	var firstErr error
+	for _, recipient := range recipients {
+		_, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+			TenantID:          booking.TenantID,
+			UserID:            recipient.userID,
+			Type:              models.NotificationTypeBookingNoShow,
+			Template:          recipient.template,
+			TemplateArgs:      args,
+			Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
+			ActionURL:         fmt.Sprintf("/bookings/%s", booking.ID),
+			RelatedEntityType: "booking",
+			RelatedEntityID:   &booking.ID,
+			Priority:          7,
+			Metadata:          map[string]any{"no_show_fee": fee},
+		})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// bookingTemplateArgs returns the placeholders of booking notification templates: its
+// short reference and start time
+func bookingTemplateArgs(booking *models.Booking) map[string]any {
+	return map[string]any{
+		"ref":   booking.ID.String()[:8],
+		"start": booking.StartTime,
+	}
+}
+
+// SendBookingExportReadyNotification emails the requester a download link for a finished export
+func (s *notificationService) SendBookingExportReadyNotification(ctx context.Context, export *models.BookingExport, downloadURL string) (*dto.NotificationDeliveryResponse, error) {
+	if export == nil {
+		return nil, errors.NewValidationError("export is required")
+	}
+
+	title := "Your bookings export is ready"
+	message := fmt.Sprintf("Your export of %d bookings (%s) is ready to download.", export.RowCount, strings.ToUpper(string(export.Format)))
+	if export.Status == models.BookingExportStatusFailed {
+		title = "Your bookings export failed"
+		message = "We could not generate your bookings export: " + export.ErrorMessage
+		downloadURL = ""
+	}
+
+	notification, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+		TenantID:          export.TenantID,
+		UserID:            export.RequestedByID,
+		Type:              models.NotificationTypeExportReady,
+		Title:             title,
+		Message:           message,
+		Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
+		ActionURL:         downloadURL,
+		ActionText:        "Download",
+		RelatedEntityType: "booking_export",
+		RelatedEntityID:   &export.ID,
+		Priority:          5,
+		ExpiresAt:         export.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
+// SendProjectHealthAlert tells a user that a project dropped to high risk or went
+// past its due date
+func (s *notificationService) SendProjectHealthAlert(ctx context.Context, project *models.Project, userID uuid.UUID, notifType models.NotificationType, snapshot *models.ProjectHealthSnapshot) (*dto.NotificationDeliveryResponse, error) {
+	if project == nil || snapshot == nil {
+		return nil, errors.NewValidationError("project and health snapshot are required")
+	}
+
+	var title, message string
+	switch notifType {
+	case models.NotificationTypeProjectOverdue:
+		title = "Project is overdue"
+		message = fmt.Sprintf("%q is past its due date", project.Title)
+		if project.DueDate != nil {
+			message += " of " + project.DueDate.Format("Jan 2, 2006")
+		}
+		message += fmt.Sprintf(" and is %d%% complete.", snapshot.ProgressPercent)
+	case models.NotificationTypeProjectAtRisk:
+		title = "Project is at high risk"
+		message = fmt.Sprintf("The health score of %q dropped to %d.", project.Title, snapshot.HealthScore)
+		if snapshot.BlockedTasksCount > 0 {
+			message += fmt.Sprintf(" %d task(s) are blocked.", snapshot.BlockedTasksCount)
+		}
+		if snapshot.ScheduleDaysLate > 0 {
+			message += fmt.Sprintf(" The critical path finishes %d day(s) late.", snapshot.ScheduleDaysLate)
+		}
+	default:
+		return nil, errors.NewValidationError("unsupported project health notification type: " + string(notifType))
+	}
+
+	notification, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+		TenantID:          project.TenantID,
+		UserID:            userID,
+		Type:              notifType,
+		Title:             title,
+		Message:           message,
+		Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
+		ActionURL:         fmt.Sprintf("/projects/%s/health", project.ID),
+		ActionText:        "View Project Health",
+		RelatedEntityType: "project",
+		RelatedEntityID:   &project.ID,
+		Priority:          8,
+		Metadata: map[string]any{
+			"health_score": snapshot.HealthScore,
+			"risk_level":   snapshot.RiskLevel,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
+// SendPaymentNotification sends notification for payment events; attachments are
+// added to the email
+func (s *notificationService) SendPaymentNotification(ctx context.Context, payment *models.Payment, notifType models.NotificationType, attachments ...map[string]any) (*dto.NotificationDeliveryResponse, error) {
 	if payment == nil {
 		return nil, errors.NewValidationError("payment is required")
 	}
@@ -448,6 +688,9 @@ func (s *notificationService) SendPaymentNotification(ctx context.Context, payme
 		RelatedEntityID:   &payment.ID,
 		Priority:          6,
 	}
+	if len(attachments) > 0 {
+		req.Metadata = map[string]any{"attachments": attachments}
+	}
 
 	notification, err := s.CreateNotification(ctx, req)
 	if err != nil {
@@ -463,6 +706,97 @@ func (s *notificationService) SendPaymentNotification(ctx context.Context, payme
 	}, nil
 }
 
+// SendBalancePaymentFailedNotification tells the customer that the automatic charge of their
+// booking balance failed. The notice escalates with the dunning stage: later stages are
+// more urgent and add SMS, and once retries are exhausted the artisan is told as well.
+func (s *notificationService) SendBalancePaymentFailedNotification(ctx context.Context, booking *models.Booking, amount float64, stage models.DunningStage, nextAttempt *time.Time) (*dto.NotificationDeliveryResponse, error) {
+	if booking == nil {
+		return nil, errors.NewValidationError("booking is required")
+	}
+
+	message := fmt.Sprintf("We could not charge the remaining balance of %.2f %s for booking #%s on %s.",
+		amount, booking.Currency, booking.ID.String()[:8], booking.StartTime.Format("Jan 2, 2006 at 3:04 PM"))
+	retryAt := ""
+	if nextAttempt != nil {
+		retryAt = nextAttempt.Format("Jan 2, 2006")
+	}
+
+	title := "Balance Payment Failed"
+	channels := []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail}
+	priority := 6
+	switch stage {
+	case models.DunningStageReminder:
+		message += fmt.Sprintf(" We will try again on %s; please check your payment method.", retryAt)
+	case models.DunningStageWarning:
+		title = "Balance Payment Still Outstanding"
+		message += fmt.Sprintf(" Another attempt also failed. We will try again on %s; please update your payment method.", retryAt)
+		priority = 8
+	case models.DunningStageFinalNotice:
+		title = "Final Notice: Balance Payment"
+		message += fmt.Sprintf(" We will make a final attempt on %s. Please update your payment method or pay the balance now.", retryAt)
+		channels = append(channels, models.NotificationChannelSMS)
+		priority = 9
+	case models.DunningStageCancelled:
+		title = "Booking Cancelled: Balance Unpaid"
+		message += " Every attempt to collect the balance failed, so the booking has been cancelled."
+		channels = append(channels, models.NotificationChannelSMS)
+		priority = 10
+	default:
+		title = "Balance Payment Overdue"
+		message += " Every attempt to collect the balance failed. Please update your payment method and pay the balance now."
+		channels = append(channels, models.NotificationChannelSMS)
+		priority = 10
+	}
+
+	notification, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+		TenantID:          booking.TenantID,
+		UserID:            booking.CustomerID,
+		Type:              models.NotificationTypePaymentFailed,
+		Title:             title,
+		Message:           message,
+		Channels:          channels,
+		ActionURL:         fmt.Sprintf("/bookings/%s", booking.ID),
+		ActionText:        "Update Payment",
+		RelatedEntityType: "booking",
+		RelatedEntityID:   &booking.ID,
+		Priority:          priority,
+		Metadata:          map[string]any{"balance_due": amount, "dunning_stage": stage, "next_attempt": nextAttempt},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The artisan needs to know the balance will not arrive on its own
+	if stage == models.DunningStageFailed || stage == models.DunningStageCancelled {
+		artisanMessage := fmt.Sprintf("The customer's balance of %.2f %s for booking #%s could not be collected.",
+			amount, booking.Currency, booking.ID.String()[:8])
+		if stage == models.DunningStageCancelled {
+			artisanMessage += " The booking has been cancelled."
+		}
+		if _, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+			TenantID:          booking.TenantID,
+			UserID:            booking.ArtisanID,
+			Type:              models.NotificationTypePaymentFailed,
+			Title:             "Customer Balance Unpaid",
+			Message:           artisanMessage,
+			Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
+			ActionURL:         fmt.Sprintf("/bookings/%s", booking.ID),
+			ActionText:        "View Booking",
+			RelatedEntityType: "booking",
+			RelatedEntityID:   &booking.ID,
+			Priority:          8,
+			Metadata:          map[string]any{"balance_due": amount, "dunning_stage": stage},
+		}); err != nil {
+			s.logger.Error("failed to notify artisan of unpaid balance", "booking_id", booking.ID, "error", err)
+		}
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
 // SendReviewNotification sends notification for review events
 func (s *notificationService) SendReviewNotification(ctx context.Context, review *models.Review, notifType models.NotificationType) (*dto.NotificationDeliveryResponse, error) {
 	if review == nil {
@@ -500,6 +834,262 @@ func (s *notificationService) SendReviewNotification(ctx context.Context, review
 	}, nil
 }
 
+// SendReviewRequestNotification asks a booking's customer to review the completed service
+func (s *notificationService) SendReviewRequestNotification(ctx context.Context, booking *models.Booking) (*dto.NotificationDeliveryResponse, error) {
+	if booking == nil {
+		return nil, errors.NewValidationError("booking is required")
+	}
+
+	subject := "your booking"
+	if booking.Service != nil {
+		subject = booking.Service.Name
+	}
+	if booking.Artisan != nil {
+		subject += " with " + booking.Artisan.FirstName
+	}
+
+	notification, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+		TenantID:          booking.TenantID,
+		UserID:            booking.CustomerID,
+		Type:              models.NotificationTypeReviewRequest,
+		Title:             "How did it go?",
+		Message:           fmt.Sprintf("Tell others about %s by leaving a review.", subject),
+		Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
+		ActionURL:         fmt.Sprintf("/bookings/%s", booking.ID),
+		ActionText:        "Leave a Review",
+		RelatedEntityType: "booking",
+		RelatedEntityID:   &booking.ID,
+		Priority:          5,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
+// SendFavoriteArtisanNotification tells a customer that an artisan they favorited
+// published a new service or, without one, opened new availability
+func (s *notificationService) SendFavoriteArtisanNotification(ctx context.Context, favorite *models.Favorite, artisan *models.Artisan, service *models.Service) (*dto.NotificationDeliveryResponse, error) {
+	if favorite == nil || artisan == nil {
+		return nil, errors.NewValidationError("favorite and artisan are required")
+	}
+
+	name := "An artisan you follow"
+	if artisan.User != nil {
+		name = artisan.User.FullName()
+	}
+
+	req := &dto.CreateNotificationRequest{
+		TenantID:          favorite.TenantID,
+		UserID:            favorite.CustomerID,
+		Type:              models.NotificationTypeFavoriteUpdate,
+		Title:             "New availability from " + name,
+		Message:           fmt.Sprintf("%s just opened new availability.", name),
+		Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
+		ActionURL:         fmt.Sprintf("/artisans/%s", artisan.ID),
+		ActionText:        "Book Now",
+		RelatedEntityType: "artisan",
+		RelatedEntityID:   &artisan.ID,
+		Priority:          3,
+	}
+	if service != nil {
+		req.Title = "New service from " + name
+		req.Message = fmt.Sprintf("%s now offers %s.", name, service.Name)
+		req.ActionURL = fmt.Sprintf("/services/%s", service.ID)
+		req.ActionText = "View Service"
+		req.RelatedEntityType = "service"
+		req.RelatedEntityID = &service.ID
+	}
+
+	notification, err := s.CreateNotification(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
+// SendNewLeadNotification tells an artisan about a customer request matching their
+// skills that they can bid on
+func (s *notificationService) SendNewLeadNotification(ctx context.Context, request *models.CustomerRequest, artisanUserID uuid.UUID) (*dto.NotificationDeliveryResponse, error) {
+	if request == nil {
+		return nil, errors.NewValidationError("request is required")
+	}
+
+	message := fmt.Sprintf("A customer is looking for quotes: %s.", request.Title)
+	if request.BudgetMax > 0 {
+		message = fmt.Sprintf("A customer is looking for quotes: %s, with a budget of up to %.2f %s.", request.Title, request.BudgetMax, request.Currency)
+	}
+
+	notification, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+		TenantID:          request.TenantID,
+		UserID:            artisanUserID,
+		Type:              models.NotificationTypeNewLead,
+		Title:             "New lead: " + request.Title,
+		Message:           message,
+		Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail, models.NotificationChannelPush},
+		ActionURL:         fmt.Sprintf("/customer-requests/leads/%s", request.ID),
+		ActionText:        "View Lead",
+		RelatedEntityType: "customer_request",
+		RelatedEntityID:   &request.ID,
+		Priority:          2,
+		Metadata: map[string]any{
+			"expires_at": request.ExpiresAt,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
+// SendBidNotification tells the customer about a bid submitted on their request, or the
+// artisan whether their bid was accepted or rejected, going by the bid's status
+func (s *notificationService) SendBidNotification(ctx context.Context, request *models.CustomerRequest, bid *models.CustomerRequestBid, userID uuid.UUID) (*dto.NotificationDeliveryResponse, error) {
+	if request == nil || bid == nil {
+		return nil, errors.NewValidationError("request and bid are required")
+	}
+
+	req := &dto.CreateNotificationRequest{
+		TenantID:          request.TenantID,
+		UserID:            userID,
+		Type:              models.NotificationTypeBidUpdate,
+		Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
+		ActionURL:         fmt.Sprintf("/customer-requests/leads/%s", request.ID),
+		ActionText:        "View Lead",
+		RelatedEntityType: "customer_request",
+		RelatedEntityID:   &request.ID,
+		Priority:          3,
+		Metadata: map[string]any{
+			"bid_id": bid.ID.String(),
+			"amount": bid.Amount,
+		},
+	}
+	switch bid.Status {
+	case models.CustomerRequestBidStatusSubmitted:
+		req.Title = "New bid on " + request.Title
+		req.Message = fmt.Sprintf("You received a bid of %.2f %s on your request.", bid.Amount, bid.Currency)
+		req.ActionURL = fmt.Sprintf("/customer-requests/%s", request.ID)
+		req.ActionText = "Compare Bids"
+	case models.CustomerRequestBidStatusAccepted:
+		req.Title = "Your bid was accepted"
+		req.Message = fmt.Sprintf("The customer accepted your bid of %.2f %s for %s.", bid.Amount, bid.Currency, request.Title)
+		req.Channels = append(req.Channels, models.NotificationChannelPush)
+		req.Priority = 1
+	case models.CustomerRequestBidStatusRejected:
+		req.Title = "Your bid was not selected"
+		req.Message = fmt.Sprintf("The customer went with another offer for %s.", request.Title)
+		req.Channels = []models.NotificationChannel{models.NotificationChannelInApp}
+		req.Priority = 4
+	default:
+		return nil, errors.NewValidationError("no notification for a " + string(bid.Status) + " bid")
+	}
+
+	notification, err := s.CreateNotification(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
+// SendCampaignNotification sends a campaign's rendered subject and body to one of its
+// recipients as marketing, so it only goes out on the channels they opted in to. Emails
+// carry the open tracking pixel.
+func (s *notificationService) SendCampaignNotification(ctx context.Context, campaign *models.Campaign, recipient *models.CampaignRecipient, subject, body string, channels []models.NotificationChannel, openTrackingURL string) (*dto.NotificationDeliveryResponse, error) {
+	if campaign == nil || recipient == nil {
+		return nil, errors.NewValidationError("campaign and recipient are required")
+	}
+
+	notification, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+		TenantID:          campaign.TenantID,
+		UserID:            recipient.CustomerID,
+		Type:              models.NotificationTypeMarketing,
+		Title:             subject,
+		Message:           body,
+		Channels:          channels,
+		RelatedEntityType: "campaign",
+		RelatedEntityID:   &campaign.ID,
+		Priority:          8,
+		Metadata: map[string]any{
+			"campaign_id":       campaign.ID,
+			"open_tracking_url": openTrackingURL,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
+// SendVerificationNotification tells an artisan that one of their verification
+// documents was approved, rejected or expired
+func (s *notificationService) SendVerificationNotification(ctx context.Context, document *models.VerificationDocument, userID uuid.UUID) (*dto.NotificationDeliveryResponse, error) {
+	if document == nil {
+		return nil, errors.NewValidationError("verification document is required")
+	}
+
+	label := document.Type.Label()
+	var title, message string
+	switch document.Status {
+	case models.VerificationStatusApproved:
+		title = "Verification document approved"
+		message = fmt.Sprintf("Your %s was approved", label)
+		if document.ExpiresAt != nil {
+			message += " until " + document.ExpiresAt.Format("Jan 2, 2006")
+		}
+		message += "."
+	case models.VerificationStatusRejected:
+		title = "Verification document rejected"
+		message = fmt.Sprintf("Your %s was rejected: %s", label, document.RejectionReason)
+	case models.VerificationStatusExpired:
+		title = "Verification document expired"
+		message = fmt.Sprintf("Your %s expired. Upload a current one to keep your badge.", label)
+	default:
+		return nil, errors.NewValidationError("unsupported verification status: " + string(document.Status))
+	}
+
+	notification, err := s.CreateNotification(ctx, &dto.CreateNotificationRequest{
+		TenantID:          document.TenantID,
+		UserID:            userID,
+		Type:              models.NotificationTypeVerification,
+		Title:             title,
+		Message:           message,
+		Channels:          []models.NotificationChannel{models.NotificationChannelInApp, models.NotificationChannelEmail},
+		ActionURL:         fmt.Sprintf("/artisans/%s/verification", document.ArtisanID),
+		ActionText:        "View Verification",
+		RelatedEntityType: "verification_document",
+		RelatedEntityID:   &document.ID,
+		Priority:          7,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationDeliveryResponse{
+		NotificationID: notification.ID,
+		InAppSent:      true,
+	}, nil
+}
+
 // SendSystemNotification sends a system-wide notification
 func (s *notificationService) SendSystemNotification(ctx context.Context, req *dto.SendSystemNotificationRequest) (*dto.BulkNotificationResponse, error) {
 	if req.TenantID == uuid.Nil {
@@ -764,9 +1354,28 @@ func (s *notificationService) GetServiceMetrics(ctx context.Context) map[string]
 // Helper Methods
 // ============================================================================
 
-// sendViaChannels sends notification via configured channels
+// sendViaChannels sends notification via configured channels the recipient hasn't
+// opted out of. Marketing also needs their marketing consent; in-app notifications are
+// always delivered.
 func (s *notificationService) sendViaChannels(ctx context.Context, notification *models.Notification) {
+	category := notification.Type.Category()
+	preferences := s.recipientPreferences(ctx, notification.UserID)
+
+	consented := true
+	if category == models.CommunicationCategoryMarketing {
+		user, err := s.repos.User.GetByID(ctx, notification.UserID)
+		consented = err == nil && user.MarketingConsent
+	}
+
 	for _, channel := range notification.Channels {
+		if channel != models.NotificationChannelInApp && (!consented || !preferences.Allows(channel, category)) {
+			s.logger.Debug("notification channel opted out",
+				"notification_id", notification.ID,
+				"user_id", notification.UserID,
+				"channel", channel)
+			continue
+		}
+
 		switch channel {
 		case models.NotificationChannelInApp:
 			// In-app notifications are already stored in the database
@@ -776,7 +1385,7 @@ func (s *notificationService) sendViaChannels(ctx context.Context, notification
 
 		case models.NotificationChannelEmail:
 			// Send email notification
-			s.sendEmailNotification(ctx, notification)
+			s.sendEmailNotification(ctx, notification, unsubscribeLink(preferences, channel, category))
 
 		case models.NotificationChannelSMS:
 			// Send SMS notification
@@ -789,13 +1398,65 @@ func (s *notificationService) sendViaChannels(ctx context.Context, notification
 	}
 }
 
-// sendEmailNotification sends email notification (placeholder)
-func (s *notificationService) sendEmailNotification(ctx context.Context, notification *models.Notification) {
+// recipientPreferences returns the communication preferences of a notification's
+// recipient, saving the defaults of those who haven't chosen any so the messages they
+// are sent can carry an unsubscribe link
+func (s *notificationService) recipientPreferences(ctx context.Context, userID uuid.UUID) *models.CommunicationPreferences {
+	preferences, err := findCommunicationPreferences(ctx, s.repos, userID)
+	if err != nil {
+		s.logger.Error("failed to get communication preferences", "user_id", userID, "error", err)
+		return models.DefaultCommunicationPreferences(userID)
+	}
+	if preferences.ID != uuid.Nil {
+		return preferences
+	}
+
+	if err := saveCommunicationPreferences(ctx, s.repos, preferences); err != nil {
+		// Another notification may have saved them first
+		if saved, findErr := s.repos.CommunicationPreferences.FindByUserID(ctx, userID); findErr == nil {
+			return saved
+		}
+		s.logger.Error("failed to save communication preferences", "user_id", userID, "error", err)
+	}
+	return preferences
+}
+
+// sendEmailNotification sends email notification (placeholder). Emails are rendered in
+// the tenant's branding, their footer in the recipient's language, and sent from its verified domain, signed with its DKIM key, or
+// else from the platform's. They carry the unsubscribe link in their footer and
+// List-Unsubscribe header, and campaign emails an image loading their open tracking URL.
+func (s *notificationService) sendEmailNotification(ctx context.Context, notification *models.Notification, unsubscribeLink string) {
 	// This would integrate with an email service provider
+	openTrackingURL, _ := notification.Metadata["open_tracking_url"].(string)
+
+	branding := models.ResolveBranding(&models.Tenant{}, nil)
+	if tenant, whitelabel, err := loadTenantBranding(ctx, s.repos, notification.TenantID); err == nil {
+		branding = models.ResolveBranding(tenant, whitelabel)
+	} else {
+		s.logger.Warn("failed to load tenant branding; sending with the platform's",
+			"notification_id", notification.ID,
+			"tenant_id", notification.TenantID,
+			"error", err)
+	}
+
+	localizer := s.recipientLocalizer(ctx, notification.TenantID, notification.UserID)
+	email, err := renderNotificationEmail(notification, branding, localizer, unsubscribeLink, openTrackingURL)
+	if err != nil {
+		s.logger.Error("failed to render email notification", "notification_id", notification.ID, "error", err)
+		return
+	}
+
 	s.logger.Info("email notification would be sent",
 		"notification_id", notification.ID,
 		"user_id", notification.UserID,
-		"title", notification.Title)
+		"title", notification.Title,
+		"from", email.From,
+		"reply_to", email.ReplyTo,
+		"dkim_domain", email.DKIMDomain,
+		"body_bytes", len(email.HTML),
+		"attachments", len(email.Attachments),
+		"unsubscribe_link", unsubscribeLink,
+		"open_tracking_url", openTrackingURL)
 
 	// Mark as sent via email
 	// s.repos.Notification.MarkSentViaEmail(ctx, notification.ID)
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+// renderedNotification is a notification template rendered in a locale
+type renderedNotification struct {
+	Title      string
+	Message    string
+	ActionText string
+}
+
+// renderNotificationTemplate renders a notification template, whose catalog messages
+// are notifications.<template>.title, .message and, optionally, .action. It returns
+// false when no catalog has the template.
+func renderNotificationTemplate(localizer i18n.Localizer, template string, args map[string]any) (renderedNotification, bool) {
+	prefix := "notifications." + template + "."
+	title, ok := localizer.Lookup(prefix+"title", args)
+	if !ok {
+		return renderedNotification{}, false
+	}
+	message, ok := localizer.Lookup(prefix+"message", args)
+	if !ok {
+		return renderedNotification{}, false
+	}
+	actionText, _ := localizer.Lookup(prefix+"action", args)
+
+	return renderedNotification{
+		Title:      title,
+		Message:    message,
+		ActionText: actionText,
+	}, true
+}
+
+// recipientLocalizer returns the localizer of a notification's recipient: their
+// preferred language, then their tenant's default language, then English. Either is
+// left out when it can't be loaded.
+func (s *notificationService) recipientLocalizer(ctx context.Context, tenantID, userID uuid.UUID) i18n.Localizer {
+	var locales []string
+	if user, err := s.repos.User.GetByID(ctx, userID); err == nil {
+		locales = append(locales, user.Language)
+	}
+	if tenant, err := s.repos.Tenant.GetByID(ctx, tenantID); err == nil {
+		locales = append(locales, tenant.Settings.DefaultLanguage)
+	}
+	return i18n.New(locales...)
+}
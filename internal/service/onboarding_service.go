@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/auth"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// onboardingTrialDays is the length of the trial tenants onboarding themselves start with
+const onboardingTrialDays = 14
+
+// serviceCategoryLabels name the categories whose names don't read well capitalized
+var serviceCategoryLabels = map[models.ServiceCategory]string{
+	models.ServiceCategoryHVAC:             "HVAC",
+	models.ServiceCategoryITSupport:        "IT support",
+	models.ServiceCategoryCCTVInstallation: "CCTV installation",
+	models.ServiceCategoryHairBeauty:       "Hair and beauty",
+}
+
+// IdentityProvider manages the accounts users sign in with
+type IdentityProvider interface {
+	// CreateUser adds a user without a password and returns its ID; it fails with
+	// auth.ErrUserExists when the email already has an account
+	CreateUser(ctx context.Context, email, firstName, lastName string) (string, error)
+	// SendInvite emails the user an invitation to set up their sign-in
+	SendInvite(ctx context.Context, userID string) error
+	// DeleteUser removes a user
+	DeleteUser(ctx context.Context, userID string) error
+}
+
+// OnboardingService defines the interface for self-serve tenant onboarding. The first
+// step creates the tenant and invites its owner; the owner takes the remaining steps
+// once signed in.
+type OnboardingService interface {
+	// StartOnboarding creates a trial tenant with its owner, and invites the owner to sign in
+	StartOnboarding(ctx context.Context, req *dto.StartOnboardingRequest) (*dto.OnboardingProgressResponse, error)
+	// ResendOwnerInvite invites the owner of a tenant again, until they have signed in
+	ResendOwnerInvite(ctx context.Context, req *dto.ResendOwnerInviteRequest) (*dto.OnboardingProgressResponse, error)
+
+	// GetProgress returns the steps taken, and the step the wizard resumes at
+	GetProgress(ctx context.Context, tenantID uuid.UUID) (*dto.OnboardingProgressResponse, error)
+
+	// Wizard Steps
+	SetPreferences(ctx context.Context, tenantID uuid.UUID, req *dto.OnboardingPreferencesRequest) (*dto.OnboardingProgressResponse, error)
+	SetupServices(ctx context.Context, tenantID uuid.UUID, req *dto.OnboardingServicesRequest) (*dto.OnboardingProgressResponse, error)
+	SetWorkingHours(ctx context.Context, tenantID uuid.UUID, req *dto.OnboardingWorkingHoursRequest) (*dto.OnboardingProgressResponse, error)
+}
+
+// onboardingService implements OnboardingService
+type onboardingService struct {
+	repos    *repository.Repositories
+	logger   log.AllLogger
+	identity IdentityProvider
+}
+
+// NewOnboardingService creates a new OnboardingService instance; identity may be nil
+// when no Zitadel service user is configured, in which case onboarding can't start
+func NewOnboardingService(repos *repository.Repositories, logger log.AllLogger, identity IdentityProvider) OnboardingService {
+	return &onboardingService{
+		repos:    repos,
+		logger:   logger,
+		identity: identity,
+	}
+}
+
+// ============================================================================
+// Starting Onboarding
+// ============================================================================
+
+// StartOnboarding creates the owner's account in Zitadel, then the owner, the tenant
+// and its onboarding progress in one transaction, and finally sends the invite. The
+// account is removed again when the tenant can't be created, so the owner can retry.
+func (s *onboardingService) StartOnboarding(ctx context.Context, req *dto.StartOnboardingRequest) (*dto.OnboardingProgressResponse, error) {
+	req.Sanitize()
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+	if s.identity == nil {
+		return nil, errors.NewAppError("ONBOARDING_UNAVAILABLE", "self-serve onboarding is not available", http.StatusServiceUnavailable)
+	}
+
+	if !isValidSubdomain(req.Subdomain) {
+		return nil, errors.NewValidationError("subdomain may only contain lowercase letters, digits and hyphens, and is not reserved")
+	}
+	if existing, err := s.repos.Tenant.FindBySubdomain(ctx, req.Subdomain); err == nil && existing != nil {
+		return nil, errors.NewConflictError("subdomain is already taken")
+	}
+	if existing, err := s.repos.User.GetByEmail(ctx, req.OwnerEmail); err == nil && existing != nil {
+		return nil, errors.NewConflictError("an account already exists for this email; sign in to create an organization")
+	}
+
+	zitadelUserID, err := s.identity.CreateUser(ctx, req.OwnerEmail, req.OwnerFirstName, req.OwnerLastName)
+	if err != nil {
+		if stdErrors.Is(err, auth.ErrUserExists) {
+			return nil, errors.NewConflictError("an account already exists for this email; sign in to create an organization")
+		}
+		s.logger.Error("failed to create owner account", "email", req.OwnerEmail, "error", err)
+		return nil, errors.NewServiceError("IDENTITY_ERROR", "failed to create the owner's account", err)
+	}
+
+	var (
+		tenant     *models.Tenant
+		onboarding *models.TenantOnboarding
+	)
+	err = s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		owner := &models.User{
+			Email:         req.OwnerEmail,
+			FirstName:     req.OwnerFirstName,
+			LastName:      req.OwnerLastName,
+			Role:          models.UserRoleTenantOwner,
+			Status:        models.UserStatusPending, // Until the owner signs in
+			ZitadelUserID: zitadelUserID,
+			AuthProvider:  "zitadel",
+		}
+		if err := tx.User.Create(ctx, owner); err != nil {
+			return err
+		}
+
+		limits := getLimitsForPlan(req.Plan)
+		trialEnd := time.Now().AddDate(0, 0, onboardingTrialDays)
+		businessEmail := req.BusinessEmail
+		if businessEmail == "" {
+			businessEmail = req.OwnerEmail
+		}
+		tenant = &models.Tenant{
+			OwnerID:       owner.ID,
+			Name:          req.Name,
+			Subdomain:     req.Subdomain,
+			BusinessName:  req.Name,
+			BusinessEmail: businessEmail,
+			BusinessPhone: req.BusinessPhone,
+			Plan:          req.Plan,
+			Status:        models.TenantStatusTrial,
+			TrialEndsAt:   &trialEnd,
+			Settings:      models.GetDefaultTenantSettings(),
+			Features:      getFeaturesForPlan(req.Plan),
+			MaxUsers:      limits.MaxUsers,
+			MaxArtisans:   limits.MaxArtisans,
+			MaxStorage:    limits.MaxStorage,
+			CurrentUsers:  1, // The owner
+		}
+		if err := tx.Tenant.Create(ctx, tenant); err != nil {
+			return err
+		}
+
+		owner.TenantID = &tenant.ID
+		if err := tx.User.Update(ctx, owner); err != nil {
+			return err
+		}
+
+		onboarding = &models.TenantOnboarding{TenantID: tenant.ID}
+		onboarding.Complete(models.OnboardingStepTenant)
+		return tx.TenantOnboarding.Create(ctx, onboarding)
+	})
+	if err != nil {
+		if deleteErr := s.identity.DeleteUser(ctx, zitadelUserID); deleteErr != nil {
+			s.logger.Error("failed to remove owner account of failed onboarding", "zitadel_user_id", zitadelUserID, "error", deleteErr)
+		}
+		if errors.IsDuplicate(err) {
+			return nil, errors.NewConflictError("subdomain is already taken")
+		}
+		s.logger.Error("failed to create onboarding tenant", "subdomain", req.Subdomain, "error", err)
+		return nil, errors.NewInternalError("failed to create tenant", err)
+	}
+
+	s.logger.Info("tenant onboarding started", "tenant_id", tenant.ID, "subdomain", tenant.Subdomain)
+
+	// The tenant exists whether or not the invite goes out; a failed invite is left
+	// for ResendOwnerInvite
+	s.sendOwnerInvite(ctx, onboarding, zitadelUserID)
+	return dto.ToOnboardingProgressResponse(onboarding, tenant), nil
+}
+
+// ResendOwnerInvite sends the owner of the tenant with the subdomain a new invite,
+// unless the owner has signed in already
+func (s *onboardingService) ResendOwnerInvite(ctx context.Context, req *dto.ResendOwnerInviteRequest) (*dto.OnboardingProgressResponse, error) {
+	if s.identity == nil {
+		return nil, errors.NewAppError("ONBOARDING_UNAVAILABLE", "self-serve onboarding is not available", http.StatusServiceUnavailable)
+	}
+
+	tenant, err := s.repos.Tenant.FindBySubdomain(ctx, strings.ToLower(strings.TrimSpace(req.Subdomain)))
+	if err != nil {
+		return nil, errors.NewNotFoundError("tenant")
+	}
+	onboarding, err := s.repos.TenantOnboarding.FindByTenantID(ctx, tenant.ID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("onboarding")
+	}
+	owner, err := s.repos.User.GetByID(ctx, tenant.OwnerID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("tenant owner")
+	}
+	if owner.LastLoginAt != nil {
+		return nil, errors.NewConflictError("the owner has already signed in")
+	}
+
+	if !s.sendOwnerInvite(ctx, onboarding, owner.ZitadelUserID) {
+		return nil, errors.NewServiceError("IDENTITY_ERROR", "failed to send the invite", nil)
+	}
+	return dto.ToOnboardingProgressResponse(onboarding, tenant), nil
+}
+
+// sendOwnerInvite invites the owner and records the step; failures are logged
+func (s *onboardingService) sendOwnerInvite(ctx context.Context, onboarding *models.TenantOnboarding, zitadelUserID string) bool {
+	if err := s.identity.SendInvite(ctx, zitadelUserID); err != nil {
+		s.logger.Error("failed to invite tenant owner", "tenant_id", onboarding.TenantID, "error", err)
+		return false
+	}
+
+	onboarding.Complete(models.OnboardingStepOwnerInvite)
+	if err := s.repos.TenantOnboarding.Update(ctx, onboarding); err != nil {
+		s.logger.Error("failed to record owner invite", "tenant_id", onboarding.TenantID, "error", err)
+	}
+	return true
+}
+
+// ============================================================================
+// Progress
+// ============================================================================
+
+// GetProgress returns the tenant's onboarding progress
+func (s *onboardingService) GetProgress(ctx context.Context, tenantID uuid.UUID) (*dto.OnboardingProgressResponse, error) {
+	onboarding, tenant, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.progress(ctx, onboarding, tenant), nil
+}
+
+// progress builds the progress response shown to the tenant's users
+func (s *onboardingService) progress(ctx context.Context, onboarding *models.TenantOnboarding, tenant *models.Tenant) *dto.OnboardingProgressResponse {
+	resp := dto.ToOnboardingProgressResponse(onboarding, tenant)
+	resp.Tenant = dto.ToTenantResponse(tenant)
+
+	if owner, err := s.repos.User.GetByID(ctx, tenant.OwnerID); err == nil {
+		resp.OwnerJoined = owner.LastLoginAt != nil
+	}
+	if count, err := s.repos.Service.Count(ctx, map[string]any{"tenant_id": tenant.ID}); err == nil {
+		resp.ServiceCount = count
+	}
+	return resp
+}
+
+// ============================================================================
+// Wizard Steps
+// ============================================================================
+
+// SetPreferences sets the currency, timezone and language of the tenant, and its logo
+// and brand color
+func (s *onboardingService) SetPreferences(ctx context.Context, tenantID uuid.UUID, req *dto.OnboardingPreferencesRequest) (*dto.OnboardingProgressResponse, error) {
+	req.Sanitize()
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	onboarding, tenant, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant.Settings.DefaultCurrency = req.Currency
+	tenant.Settings.DefaultTimezone = req.Timezone
+	if req.Language != "" {
+		tenant.Settings.DefaultLanguage = req.Language
+	}
+	if req.LogoURL != "" {
+		tenant.LogoURL = req.LogoURL
+	}
+	if req.PrimaryColor != "" {
+		tenant.PrimaryColor = req.PrimaryColor
+	}
+	if err := s.repos.Tenant.Update(ctx, tenant); err != nil {
+		s.logger.Error("failed to save onboarding preferences", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewInternalError("failed to save preferences", err)
+	}
+
+	return s.completeStep(ctx, onboarding, tenant, models.OnboardingStepPreferences)
+}
+
+// SetupServices seeds a default service for each category chosen, unless the tenant
+// offers services already, and adds the services listed. Services are organization-wide
+// and priced in the tenant's currency.
+func (s *onboardingService) SetupServices(ctx context.Context, tenantID uuid.UUID, req *dto.OnboardingServicesRequest) (*dto.OnboardingProgressResponse, error) {
+	req.Sanitize()
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	onboarding, tenant, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := tenant.Settings.DefaultCurrency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	var services []*models.Service
+	existing, err := s.repos.Service.Count(ctx, map[string]any{"tenant_id": tenantID})
+	if err != nil {
+		return nil, errors.NewInternalError("failed to count services", err)
+	}
+	if existing == 0 {
+		seen := make(map[models.ServiceCategory]bool, len(req.Categories))
+		for _, category := range req.Categories {
+			if seen[category] {
+				continue
+			}
+			seen[category] = true
+			services = append(services, &models.Service{
+				TenantID:        tenantID,
+				Name:            serviceCategoryLabel(category),
+				Description:     "Priced on request. Set the price and duration to suit your business.",
+				Category:        category,
+				Currency:        currency,
+				DurationMinutes: 60,
+				IsActive:        true,
+				Metadata:        models.JSONB{"seeded_by": "onboarding"},
+			})
+		}
+	}
+	for _, input := range req.Services {
+		services = append(services, &models.Service{
+			TenantID:        tenantID,
+			Name:            input.Name,
+			Description:     input.Description,
+			Category:        input.Category,
+			Price:           input.Price,
+			Currency:        currency,
+			DurationMinutes: input.DurationMinutes,
+			IsActive:        true,
+		})
+	}
+
+	if len(services) > 0 {
+		if err := s.repos.Service.CreateBatch(ctx, services); err != nil {
+			s.logger.Error("failed to create onboarding services", "tenant_id", tenantID, "error", err)
+			return nil, errors.NewInternalError("failed to create services", err)
+		}
+	}
+	s.logger.Info("onboarding services set up", "tenant_id", tenantID, "created", len(services))
+
+	return s.completeStep(ctx, onboarding, tenant, models.OnboardingStepServices)
+}
+
+// SetWorkingHours sets the tenant's business hours for the week
+func (s *onboardingService) SetWorkingHours(ctx context.Context, tenantID uuid.UUID, req *dto.OnboardingWorkingHoursRequest) (*dto.OnboardingProgressResponse, error) {
+	req.Sanitize()
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	onboarding, tenant, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant.Settings.BusinessHours = req.WeekHours()
+	if err := s.repos.Tenant.UpdateSettings(ctx, tenantID, tenant.Settings); err != nil {
+		s.logger.Error("failed to save onboarding working hours", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewInternalError("failed to save working hours", err)
+	}
+
+	return s.completeStep(ctx, onboarding, tenant, models.OnboardingStepWorkingHours)
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+// load returns the tenant and its onboarding progress
+func (s *onboardingService) load(ctx context.Context, tenantID uuid.UUID) (*models.TenantOnboarding, *models.Tenant, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, nil, errors.NewNotFoundError("tenant")
+	}
+	onboarding, err := s.repos.TenantOnboarding.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, errors.NewNotFoundError("onboarding")
+		}
+		return nil, nil, errors.NewInternalError("failed to load onboarding", err)
+	}
+	return onboarding, tenant, nil
+}
+
+// completeStep records the step as completed and returns the progress
+func (s *onboardingService) completeStep(ctx context.Context, onboarding *models.TenantOnboarding, tenant *models.Tenant, step models.OnboardingStep) (*dto.OnboardingProgressResponse, error) {
+	onboarding.Complete(step)
+	if err := s.repos.TenantOnboarding.Update(ctx, onboarding); err != nil {
+		s.logger.Error("failed to record onboarding step", "tenant_id", tenant.ID, "step", step, "error", err)
+		return nil, errors.NewInternalError("failed to record onboarding progress", err)
+	}
+	if onboarding.CompletedAt != nil {
+		s.logger.Info("tenant onboarding completed", "tenant_id", tenant.ID)
+	}
+	return s.progress(ctx, onboarding, tenant), nil
+}
+
+// serviceCategoryLabel names a category, such as "Pest control" for pest_control
+func serviceCategoryLabel(category models.ServiceCategory) string {
+	if label, ok := serviceCategoryLabels[category]; ok {
+		return label
+	}
+	label := strings.ReplaceAll(string(category), "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
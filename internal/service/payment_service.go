@@ -2,16 +2,23 @@ package service
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"net/http"
 	"time"
 
+	"Krafti_Vibe/internal/domain/authz"
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/payments"
 	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/redact"
+	"Krafti_Vibe/internal/pkg/tracing"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // PaymentService defines the interface for payment service operations
@@ -25,6 +32,14 @@ type PaymentService interface {
 	GetPaymentsByTenant(ctx context.Context, tenantID uuid.UUID, pagination repository.PaginationParams) (*dto.PaymentListResponse, error)
 	GetPaymentByProviderID(ctx context.Context, providerPaymentID string) (*dto.PaymentResponse, error)
 
+	// Provider Payments
+	InitiatePayment(ctx context.Context, req *dto.InitiatePaymentRequest) (*dto.PaymentIntentResponse, error)
+	CapturePayment(ctx context.Context, tenantID, paymentID uuid.UUID) (*dto.PaymentResponse, error)
+	HandleProviderCallback(ctx context.Context, providerName string, payload []byte, headers http.Header) error
+	SyncPendingPayments(ctx context.Context) (*dto.PaymentSyncRunResponse, error)
+	GetProviderHealth(ctx context.Context) []payments.ProviderHealth
+	CheckProviderHealth(ctx context.Context) []payments.ProviderHealth
+
 	// Payment Status Operations
 	MarkPaymentAsPaid(ctx context.Context, paymentID uuid.UUID, providerPaymentID string) (*dto.PaymentResponse, error)
 	MarkPaymentAsFailed(ctx context.Context, paymentID uuid.UUID, reason string) (*dto.PaymentResponse, error)
@@ -85,17 +100,37 @@ type PaymentService interface {
 	BulkMarkAsFailed(ctx context.Context, paymentIDs []uuid.UUID, reason string) error
 }
 
+const (
+	// providerSyncMinAge gives webhooks and callbacks time to arrive before polling
+	providerSyncMinAge = 2 * time.Minute
+	// providerSyncMaxAge stops polling payments the customer has abandoned
+	providerSyncMaxAge = 24 * time.Hour
+	// providerSyncBatchSize bounds the provider calls made per run
+	providerSyncBatchSize = 100
+)
+
 // paymentService implements PaymentService
 type paymentService struct {
-	repos  *repository.Repositories
-	logger log.AllLogger
+	repos         *repository.Repositories
+	logger        log.AllLogger
+	providers     *payments.Registry
+	invoices      InvoiceService
+	notifications NotificationService
+	commissions   CommissionService
+	authorizer    Authorizer
 }
 
-// NewPaymentService creates a new PaymentService instance
-func NewPaymentService(repos *repository.Repositories, logger log.AllLogger) PaymentService {
+// NewPaymentService creates a new PaymentService instance; providers may be nil,
+// in which case payments are only recorded and never sent to a processor
+func NewPaymentService(repos *repository.Repositories, logger log.AllLogger, providers *payments.Registry) PaymentService {
 	return &paymentService{
-		repos:  repos,
-		logger: logger,
+		repos:         repos,
+		logger:        logger,
+		providers:     providers,
+		invoices:      NewInvoiceService(repos, logger),
+		notifications: NewNotificationService(repos, logger),
+		commissions:   NewCommissionService(repos, logger),
+		authorizer:    NewAuthorizer(repos, logger),
 	}
 }
 
@@ -104,7 +139,10 @@ func NewPaymentService(repos *repository.Repositories, logger log.AllLogger) Pay
 // ============================================================================
 
 // CreatePayment creates a new payment
-func (s *paymentService) CreatePayment(ctx context.Context, req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error) {
+func (s *paymentService) CreatePayment(ctx context.Context, req *dto.CreatePaymentRequest) (response *dto.PaymentResponse, err error) {
+	ctx, span := tracing.Start(ctx, "payment.create", attribute.String("tenant.id", req.TenantID.String()))
+	defer func() { tracing.End(span, err) }()
+
 	if err := req.Validate(); err != nil {
 		return nil, errors.NewValidationError("invalid payment request: " + err.Error())
 	}
@@ -125,10 +163,24 @@ func (s *paymentService) CreatePayment(ctx context.Context, req *dto.CreatePayme
 		CommissionRate:    req.CommissionRate,
 		Metadata:          req.Metadata,
 	}
+	if err := s.authorizer.Can(ctx, authz.ActionPaymentCreate, authz.Payment(payment)); err != nil {
+		return nil, err
+	}
 
-	// Calculate commission split
+	// Commission is split on the payment net of the booking's tax, at the rate of the
+	// tenant's commission rule for the booking when one applies
+	if booking, err := s.repos.Booking.GetByID(ctx, req.BookingID); err == nil {
+		payment.ApplyBookingDiscount(booking)
+		payment.ApplyBookingTax(booking)
+		s.applyCommissionRule(ctx, payment, booking)
+	}
 	payment.CalculateCommission()
 
+	// The artisan's share is held under the tenant's escrow hold period
+	if tenant, err := s.repos.Tenant.GetByID(ctx, req.TenantID); err == nil {
+		payment.HoldInEscrow(tenant.Settings.EscrowHoldDays)
+	}
+
 	// Validate payment
 	if err := payment.Validate(); err != nil {
 		return nil, errors.NewValidationError("payment validation failed: " + err.Error())
@@ -143,12 +195,12 @@ func (s *paymentService) CreatePayment(ctx context.Context, req *dto.CreatePayme
 	s.logger.Info("payment created", "payment_id", payment.ID, "amount", payment.Amount, "currency", payment.Currency)
 
 	// Load relationships
-	payment, err := s.repos.Payment.GetByID(ctx, payment.ID)
+	payment, err = s.repos.Payment.GetByID(ctx, payment.ID)
 	if err != nil {
 		s.logger.Warn("failed to load payment relationships", "payment_id", payment.ID, "error", err)
 	}
 
-	return dto.ToPaymentResponse(payment), nil
+	return dto.ToPaymentResponse(payment, dto.ViewerFromContext(ctx)), nil
 }
 
 // GetPayment retrieves a payment by ID
@@ -161,8 +213,11 @@ func (s *paymentService) GetPayment(ctx context.Context, paymentID uuid.UUID) (*
 	if err != nil {
 		return nil, errors.NewServiceError("GET_FAILED", "failed to get payment", err)
 	}
+	if err := s.authorizer.Can(ctx, authz.ActionPaymentRead, authz.Payment(payment)); err != nil {
+		return nil, err
+	}
 
-	return dto.ToPaymentResponse(payment), nil
+	return dto.ToPaymentResponse(payment, dto.ViewerFromContext(ctx)), nil
 }
 
 // GetPaymentsByBooking retrieves all payments for a booking
@@ -176,7 +231,7 @@ func (s *paymentService) GetPaymentsByBooking(ctx context.Context, bookingID uui
 		return nil, errors.NewServiceError("GET_FAILED", "failed to get payments for booking", err)
 	}
 
-	return dto.ToPaymentResponses(payments), nil
+	return dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)), nil
 }
 
 // GetPaymentsByCustomer retrieves all payments for a customer
@@ -191,13 +246,15 @@ func (s *paymentService) GetPaymentsByCustomer(ctx context.Context, customerID u
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -213,13 +270,15 @@ func (s *paymentService) GetPaymentsByArtisan(ctx context.Context, artisanID uui
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -235,13 +294,15 @@ func (s *paymentService) GetPaymentsByTenant(ctx context.Context, tenantID uuid.
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -256,7 +317,478 @@ func (s *paymentService) GetPaymentByProviderID(ctx context.Context, providerPay
 		return nil, errors.NewServiceError("GET_FAILED", "failed to get payment by provider ID", err)
 	}
 
-	return dto.ToPaymentResponse(payment), nil
+	return dto.ToPaymentResponse(payment, dto.ViewerFromContext(ctx)), nil
+}
+
+// ============================================================================
+// Provider Payments
+// ============================================================================
+
+// InitiatePayment records a pending payment for a booking and starts it with the
+// tenant's payment provider
+func (s *paymentService) InitiatePayment(ctx context.Context, req *dto.InitiatePaymentRequest) (*dto.PaymentIntentResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid payment request: " + err.Error())
+	}
+
+	booking, err := s.repos.Booking.GetByID(ctx, req.BookingID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("booking")
+		}
+		return nil, errors.NewServiceError("GET_FAILED", "failed to get booking", err)
+	}
+	if booking.TenantID != req.TenantID {
+		return nil, errors.NewNotFoundError("booking")
+	}
+	if err := s.authorizer.Can(ctx, authz.ActionPaymentCreate, authz.Booking(booking)); err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.repos.Tenant.GetByID(ctx, req.TenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("GET_FAILED", "failed to get tenant", err)
+	}
+
+	// Providers to try in order; a tenant's fallback takes over when its provider is down
+	var candidates []payments.Provider
+	if req.Provider != "" {
+		var provider payments.Provider
+		if provider, err = s.providers.Get(req.Provider); err == nil {
+			if !s.providers.Available(provider.Name()) {
+				err = fmt.Errorf("%w: %s", payments.ErrCircuitOpen, provider.Name())
+			}
+			candidates = []payments.Provider{provider}
+		}
+	} else {
+		candidates, err = s.providers.Route(tenant.Settings.PaymentProvider, tenant.Settings.FallbackPaymentProvider)
+	}
+	if stderrors.Is(err, payments.ErrCircuitOpen) {
+		return nil, errors.NewAppErrorWithErr("PROVIDER_UNAVAILABLE", "the payment provider is temporarily unavailable, please try again shortly", http.StatusServiceUnavailable, err)
+	}
+	if err != nil {
+		return nil, errors.NewAppErrorWithErr("PROVIDER_UNAVAILABLE", "no payment provider is configured for this tenant", http.StatusServiceUnavailable, err)
+	}
+	provider := candidates[0]
+
+	currency := booking.Currency
+	if currency == "" {
+		currency = tenant.Settings.DefaultCurrency
+	}
+
+	artisanID := booking.ArtisanID
+	payment := &models.Payment{
+		TenantID:       booking.TenantID,
+		BookingID:      booking.ID,
+		CustomerID:     booking.CustomerID,
+		ArtisanID:      &artisanID,
+		Amount:         req.Amount,
+		Currency:       currency,
+		Method:         models.PaymentMethodForProvider(provider.Name()),
+		Type:           req.Type,
+		Status:         models.PaymentStatusPending,
+		ProviderName:   provider.Name(),
+		CommissionRate: tenant.Settings.PlatformCommissionRate,
+	}
+	payment.ApplyBookingDiscount(booking)
+	payment.ApplyBookingTax(booking)
+	s.applyCommissionRule(ctx, payment, booking)
+	payment.CalculateCommission()
+	payment.HoldInEscrow(tenant.Settings.EscrowHoldDays)
+
+	if err := payment.Validate(); err != nil {
+		return nil, errors.NewValidationError("payment validation failed: " + err.Error())
+	}
+	if err := s.repos.Payment.Create(ctx, payment); err != nil {
+		s.logger.Error("failed to create payment", "error", err)
+		return nil, errors.NewServiceError("CREATE_FAILED", "failed to create payment", err)
+	}
+
+	var customerEmail string
+	phoneNumber := req.PhoneNumber
+	if customer, err := s.repos.User.GetByID(ctx, booking.CustomerID); err == nil {
+		customerEmail = customer.Email
+		if phoneNumber == "" {
+			phoneNumber = customer.PhoneNumber
+		}
+	}
+
+	intentReq := payments.IntentRequest{
+		Amount:          payment.Amount,
+		Currency:        payment.Currency,
+		Reference:       payment.ID.String(),
+		Description:     fmt.Sprintf("Booking %s", booking.ID),
+		CustomerEmail:   customerEmail,
+		PhoneNumber:     phoneNumber,
+		PaymentMethodID: req.PaymentMethodID,
+		ManualCapture:   req.ManualCapture,
+		ReturnURL:       req.ReturnURL,
+		CancelURL:       req.CancelURL,
+		Metadata: map[string]string{
+			"booking_id": booking.ID.String(),
+			"tenant_id":  booking.TenantID.String(),
+		},
+	}
+
+	var intent *payments.Intent
+	for i := range candidates {
+		if i > 0 {
+			// Same payment and reference, so a late success at the failed provider
+			// cannot be mistaken for a second payment
+			s.logger.Warn("payment provider unavailable, failing over",
+				"payment_id", payment.ID, "provider", provider.Name(), "fallback", candidates[i].Name(), "error", err)
+			if payment.Metadata == nil {
+				payment.Metadata = models.JSONB{}
+			}
+			payment.Metadata["failed_over_from"] = provider.Name()
+			provider = candidates[i]
+			payment.ProviderName = provider.Name()
+			payment.Method = models.PaymentMethodForProvider(provider.Name())
+		}
+
+		intent, err = provider.CreateIntent(ctx, intentReq)
+		s.providers.RecordResult(provider.Name(), err)
+		if err == nil || !payments.IsOutage(err) {
+			break
+		}
+	}
+	if err != nil {
+		payment.MarkAsFailed(err.Error())
+		if updateErr := s.repos.Payment.Update(ctx, payment); updateErr != nil {
+			s.logger.Error("failed to record payment failure", "payment_id", payment.ID, "error", updateErr)
+		}
+		s.logger.Warn("payment provider rejected payment", "payment_id", payment.ID, "provider", provider.Name(), "error", err)
+		return nil, providerError("payment provider rejected the payment", err)
+	}
+
+	payment.ProviderPaymentID = intent.ProviderPaymentID
+	applyIntentStatus(payment, intent)
+	if _, err := s.repos.Payment.ApplyProviderResult(ctx, payment, nil); err != nil {
+		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update payment", err)
+	}
+	s.repos.Booking.InvalidateCache(ctx, payment.BookingID)
+	if payment.IsSuccessful() {
+		s.paymentReceived(ctx, payment)
+	}
+
+	s.logger.Info("payment initiated",
+		"payment_id", payment.ID,
+		"provider", provider.Name(),
+		"provider_payment_id", redact.ID(intent.ProviderPaymentID),
+		"status", intent.Status)
+
+	return &dto.PaymentIntentResponse{
+		Payment:           dto.ToPaymentResponse(payment, dto.ViewerFromContext(ctx)),
+		Provider:          provider.Name(),
+		ProviderPaymentID: intent.ProviderPaymentID,
+		Status:            string(intent.Status),
+		ClientSecret:      intent.ClientSecret,
+		RedirectURL:       intent.RedirectURL,
+	}, nil
+}
+
+// CapturePayment settles an authorized or customer-approved payment with the provider
+// that started it
+func (s *paymentService) CapturePayment(ctx context.Context, tenantID, paymentID uuid.UUID) (response *dto.PaymentResponse, err error) {
+	ctx, span := tracing.Start(ctx, "payment.capture", attribute.String("payment.id", paymentID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	if paymentID == uuid.Nil {
+		return nil, errors.NewValidationError("payment ID is required")
+	}
+
+	payment, err := s.repos.Payment.GetByID(ctx, paymentID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("payment")
+		}
+		return nil, errors.NewServiceError("GET_FAILED", "failed to get payment", err)
+	}
+	if payment.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("payment")
+	}
+	if err := s.authorizer.Can(ctx, authz.ActionPaymentCreate, authz.Payment(payment)); err != nil {
+		return nil, err
+	}
+	if payment.ProviderPaymentID == "" {
+		return nil, errors.NewValidationError("payment was not started with a payment provider")
+	}
+	if !payment.IsPending() && !payment.IsProcessing() {
+		return nil, errors.NewValidationError(fmt.Sprintf("payment cannot be captured (status: %s)", payment.Status))
+	}
+
+	// Capture with the provider that started the payment, even if the tenant has since switched
+	provider, err := s.providers.Get(payment.ProviderName)
+	if err != nil {
+		return nil, errors.NewAppErrorWithErr("PROVIDER_UNAVAILABLE", "payment provider is not configured", http.StatusServiceUnavailable, err)
+	}
+
+	intent, err := provider.Capture(ctx, payment.ProviderPaymentID, payment.Amount, payment.Currency)
+	s.providers.RecordResult(provider.Name(), err)
+	if err != nil {
+		s.logger.Warn("payment capture failed", "payment_id", payment.ID, "provider", provider.Name(), "error", err)
+		return nil, providerError("payment provider rejected the capture", err)
+	}
+
+	applyIntentStatus(payment, intent)
+	if _, err := s.repos.Payment.ApplyProviderResult(ctx, payment, nil); err != nil {
+		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update payment", err)
+	}
+	s.repos.Booking.InvalidateCache(ctx, payment.BookingID)
+	if payment.IsSuccessful() {
+		s.paymentReceived(ctx, payment)
+	}
+
+	s.logger.Info("payment captured", "payment_id", payment.ID, "provider", provider.Name(), "status", intent.Status)
+
+	return dto.ToPaymentResponse(payment, dto.ViewerFromContext(ctx)), nil
+}
+
+// HandleProviderCallback verifies a webhook or callback from a payment provider and
+// applies the result it reports to the matching payment and its booking. Every event
+// is recorded by ID, so redeliveries are acknowledged without being applied twice.
+// Events for unknown payments are recorded as ignored so the provider stops retrying.
+func (s *paymentService) HandleProviderCallback(ctx context.Context, providerName string, payload []byte, headers http.Header) error {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return errors.NewNotFoundError("payment provider")
+	}
+
+	event, err := provider.VerifyWebhook(ctx, payload, headers)
+	if err != nil {
+		if stderrors.Is(err, payments.ErrInvalidSignature) {
+			return errors.NewAppErrorWithErr(errors.ErrCodeUnauthorized, "invalid webhook signature", http.StatusUnauthorized, err)
+		}
+		return errors.NewAppErrorWithErr(errors.ErrCodeValidation, "invalid webhook payload", http.StatusBadRequest, err)
+	}
+	if event.ID == "" {
+		return errors.NewValidationError("webhook event has no ID")
+	}
+
+	record := &models.PaymentWebhookEvent{
+		Provider:    provider.Name(),
+		EventID:     event.ID,
+		EventType:   event.Type,
+		ProviderRef: event.ProviderPaymentID,
+		Outcome:     models.PaymentWebhookIgnored,
+		ReceivedAt:  time.Now(),
+	}
+	if !event.OccurredAt.IsZero() {
+		record.OccurredAt = &event.OccurredAt
+	}
+
+	var changed *models.Payment
+	if event.Status != "" {
+		payment := s.findProviderPayment(ctx, event.ProviderPaymentID, event.Reference)
+		if payment == nil || payment.ProviderName != provider.Name() {
+			s.logger.Warn("payment provider event for unknown payment",
+				"provider", providerName,
+				"event_id", event.ID,
+				"provider_payment_id", redact.ID(event.ProviderPaymentID))
+		} else {
+			record.TenantID = &payment.TenantID
+			record.PaymentID = &payment.ID
+			if applyProviderStatus(payment, event.Status, event.FailureReason, event.ReceiptNumber) {
+				record.Outcome = models.PaymentWebhookApplied
+				changed = payment
+			}
+		}
+	}
+
+	recorded, err := s.repos.Payment.ApplyProviderResult(ctx, changed, record)
+	if err != nil {
+		if errors.IsConflict(err) {
+			// Nothing was recorded, so the provider's retry will be applied to the fresh payment
+			return errors.NewConflictError("payment is being updated; retry the event")
+		}
+		return errors.NewServiceError("UPDATE_FAILED", "failed to apply payment provider event", err)
+	}
+	if !recorded {
+		s.logger.Debug("duplicate payment provider event", "provider", providerName, "event_id", event.ID)
+		return nil
+	}
+
+	if changed != nil {
+		s.repos.Booking.InvalidateCache(ctx, changed.BookingID)
+		s.logger.Info("payment updated from provider event",
+			"payment_id", changed.ID,
+			"provider", providerName,
+			"event_id", event.ID,
+			"status", changed.Status)
+		if changed.IsSuccessful() {
+			s.paymentReceived(ctx, changed)
+		}
+	}
+	return nil
+}
+
+// SyncPendingPayments polls providers for payments still awaiting a result, catching
+// payments whose webhook or callback was lost (common with mobile money)
+func (s *paymentService) SyncPendingPayments(ctx context.Context) (*dto.PaymentSyncRunResponse, error) {
+	now := time.Now()
+	pending, err := s.repos.Payment.FindAwaitingProvider(ctx, now.Add(-providerSyncMaxAge), now.Add(-providerSyncMinAge), providerSyncBatchSize)
+	if err != nil {
+		return nil, errors.NewServiceError("FIND_FAILED", "failed to find payments awaiting provider", err)
+	}
+
+	result := &dto.PaymentSyncRunResponse{}
+	for _, payment := range pending {
+		provider, err := s.providers.Get(payment.ProviderName)
+		if err != nil {
+			continue
+		}
+		checker, ok := provider.(payments.StatusChecker)
+		if !ok {
+			continue
+		}
+
+		result.Checked++
+		intent, err := checker.Status(ctx, payment.ProviderPaymentID)
+		s.providers.RecordResult(payment.ProviderName, err)
+		if err != nil {
+			s.logger.Warn("payment status check failed", "payment_id", payment.ID, "provider", payment.ProviderName, "error", err)
+			result.Errors++
+			continue
+		}
+		if !applyProviderStatus(payment, intent.Status, intent.FailureReason, intent.ReceiptNumber) {
+			continue
+		}
+		if _, err := s.repos.Payment.ApplyProviderResult(ctx, payment, nil); err != nil {
+			s.logger.Error("failed to update polled payment", "payment_id", payment.ID, "error", err)
+			result.Errors++
+			continue
+		}
+		s.repos.Booking.InvalidateCache(ctx, payment.BookingID)
+		if payment.IsSuccessful() {
+			s.paymentReceived(ctx, payment)
+		}
+		result.Updated++
+	}
+
+	if result.Updated > 0 || result.Errors > 0 {
+		s.logger.Info("payment status sync completed", "checked", result.Checked, "updated", result.Updated, "errors", result.Errors)
+	}
+	return result, nil
+}
+
+// applyCommissionRule takes the payment's commission rate from the tenant's rules. A
+// failure to evaluate them keeps the platform rate rather than blocking the payment.
+func (s *paymentService) applyCommissionRule(ctx context.Context, payment *models.Payment, booking *models.Booking) {
+	if err := s.commissions.ApplyCommission(ctx, payment, booking); err != nil {
+		s.logger.Warn("failed to apply commission rules; using platform rate", "booking_id", booking.ID, "error", err)
+	}
+}
+
+// findProviderPayment looks a payment up by the provider's ID, falling back to our
+// payment ID when the provider echoes it as the reference
+func (s *paymentService) findProviderPayment(ctx context.Context, providerPaymentID, reference string) *models.Payment {
+	if providerPaymentID != "" {
+		if payment, err := s.repos.Payment.GetByProviderPaymentID(ctx, providerPaymentID); err == nil {
+			return payment
+		}
+	}
+	if id, err := uuid.Parse(reference); err == nil {
+		if payment, err := s.repos.Payment.GetByID(ctx, id); err == nil {
+			return payment
+		}
+	}
+	return nil
+}
+
+// paymentReceived records a completed booking payment on the booking's invoice and
+// emails the customer a receipt with the invoice attached. Failures are logged: the
+// payment itself has already been recorded.
+func (s *paymentService) paymentReceived(ctx context.Context, payment *models.Payment) {
+	var attachments []map[string]any
+	switch payment.Type {
+	case models.PaymentTypeDeposit, models.PaymentTypeFull, models.PaymentTypeBalance:
+		invoice, err := s.invoices.RecordBookingPayment(ctx, payment)
+		if err != nil {
+			s.logger.Error("failed to record payment on invoice", "payment_id", payment.ID, "error", err)
+			break
+		}
+		file, err := s.invoices.RenderInvoicePDF(ctx, invoice.ID, payment.TenantID)
+		if err != nil {
+			s.logger.Error("failed to render invoice", "invoice_id", invoice.ID, "error", err)
+			break
+		}
+		attachments = append(attachments, invoicePDFAttachment(file))
+	}
+
+	if _, err := s.notifications.SendPaymentNotification(ctx, payment, models.NotificationTypePaymentReceived, attachments...); err != nil {
+		s.logger.Warn("failed to send payment notification", "payment_id", payment.ID, "error", err)
+	}
+}
+
+// applyIntentStatus moves the payment to the state the provider reports
+func applyIntentStatus(payment *models.Payment, intent *payments.Intent) {
+	applyProviderStatus(payment, intent.Status, intent.FailureReason, intent.ReceiptNumber)
+}
+
+// applyProviderStatus moves the payment to the state a provider reports and reports
+// whether anything changed. Paid and refunded payments are final and left alone.
+func applyProviderStatus(payment *models.Payment, status payments.IntentStatus, failureReason, receipt string) bool {
+	switch payment.Status {
+	case models.PaymentStatusPaid, models.PaymentStatusRefunded, models.PaymentStatusPartialRefund:
+		return false
+	}
+
+	previous := payment.Status
+	switch status {
+	case payments.IntentSucceeded:
+		payment.MarkAsPaid()
+	case payments.IntentFailed:
+		payment.MarkAsFailed(failureReason)
+	case payments.IntentCanceled:
+		payment.MarkAsCancelled()
+	case payments.IntentAuthorized, payments.IntentProcessing:
+		payment.Status = models.PaymentStatusProcessing
+	}
+
+	if payment.Metadata == nil {
+		payment.Metadata = models.JSONB{}
+	}
+	changed := payment.Status != previous || payment.Metadata["provider_status"] != string(status)
+	payment.Metadata["provider_status"] = string(status)
+	if receipt != "" && payment.Metadata["receipt_number"] != receipt {
+		payment.Metadata["receipt_number"] = receipt
+		changed = true
+	}
+	return changed
+}
+
+// providerError maps a driver failure to an API error: declines and other client
+// errors reported by the processor are 402s, everything else is a gateway failure
+func providerError(message string, err error) error {
+	var perr *payments.ProviderError
+	if stderrors.As(err, &perr) && perr.StatusCode >= 400 && perr.StatusCode < 500 {
+		return errors.NewAppErrorWithErr("PAYMENT_DECLINED", message+": "+perr.Message, http.StatusPaymentRequired, err)
+	}
+	if stderrors.Is(err, payments.ErrUnsupported) {
+		return errors.NewAppErrorWithErr(errors.ErrCodeValidation, message+": operation not supported by provider", http.StatusBadRequest, err)
+	}
+	return errors.NewAppErrorWithErr("PROVIDER_ERROR", message, http.StatusBadGateway, err)
+}
+
+// GetProviderHealth reports the circuit state of each configured payment provider
+func (s *paymentService) GetProviderHealth(ctx context.Context) []payments.ProviderHealth {
+	return s.providers.Health()
+}
+
+// CheckProviderHealth probes each payment provider and logs the ones whose circuit is
+// not closed, so outages and recoveries are noticed between payments
+func (s *paymentService) CheckProviderHealth(ctx context.Context) []payments.ProviderHealth {
+	health := s.providers.CheckHealth(ctx)
+	for _, h := range health {
+		if h.State != payments.CircuitClosed {
+			s.logger.Warn("payment provider unhealthy",
+				"provider", h.Provider,
+				"state", h.State,
+				"consecutive_failures", h.ConsecutiveFailures,
+				"error", h.LastError)
+		}
+	}
+	return health
 }
 
 // ============================================================================
@@ -273,7 +805,11 @@ func (s *paymentService) MarkPaymentAsPaid(ctx context.Context, paymentID uuid.U
 		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to mark payment as paid", err)
 	}
 
-	s.logger.Info("payment marked as paid", "payment_id", paymentID, "provider_payment_id", providerPaymentID)
+	s.logger.Info("payment marked as paid", "payment_id", paymentID, "provider_payment_id", redact.ID(providerPaymentID))
+
+	if payment, err := s.repos.Payment.GetByID(ctx, paymentID); err == nil {
+		s.paymentReceived(ctx, payment)
+	}
 
 	return s.GetPayment(ctx, paymentID)
 }
@@ -333,13 +869,15 @@ func (s *paymentService) GetPendingPayments(ctx context.Context, tenantID uuid.U
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -355,13 +893,15 @@ func (s *paymentService) GetFailedPayments(ctx context.Context, tenantID uuid.UU
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -377,13 +917,15 @@ func (s *paymentService) GetSuccessfulPayments(ctx context.Context, tenantID uui
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -392,7 +934,10 @@ func (s *paymentService) GetSuccessfulPayments(ctx context.Context, tenantID uui
 // ============================================================================
 
 // ProcessRefund processes a full or partial refund
-func (s *paymentService) ProcessRefund(ctx context.Context, paymentID uuid.UUID, amount float64, reason string) (*dto.PaymentResponse, error) {
+func (s *paymentService) ProcessRefund(ctx context.Context, paymentID uuid.UUID, amount float64, reason string) (response *dto.PaymentResponse, err error) {
+	ctx, span := tracing.Start(ctx, "payment.refund", attribute.String("payment.id", paymentID.String()))
+	defer func() { tracing.End(span, err) }()
+
 	if paymentID == uuid.Nil {
 		return nil, errors.NewValidationError("payment ID is required")
 	}
@@ -408,6 +953,9 @@ func (s *paymentService) ProcessRefund(ctx context.Context, paymentID uuid.UUID,
 	if err != nil {
 		return nil, errors.NewServiceError("GET_FAILED", "failed to get payment", err)
 	}
+	if err := s.authorizer.Can(ctx, authz.ActionPaymentRefund, authz.Payment(payment)); err != nil {
+		return nil, err
+	}
 
 	// Validate refund
 	if !payment.CanBeRefunded() {
@@ -419,6 +967,33 @@ func (s *paymentService) ProcessRefund(ctx context.Context, paymentID uuid.UUID,
 		return nil, errors.NewValidationError(fmt.Sprintf("refund amount (%.2f) exceeds refundable amount (%.2f)", amount, maxRefund))
 	}
 
+	// Return the money through the processor before recording the refund. Payments
+	// from providers that are no longer configured are only recorded.
+	if payment.ProviderName != "" && payment.ProviderPaymentID != "" {
+		if provider, err := s.providers.Get(payment.ProviderName); err == nil {
+			refund, err := provider.Refund(ctx, payments.RefundRequest{
+				ProviderPaymentID: payment.ProviderPaymentID,
+				Amount:            amount,
+				Currency:          payment.Currency,
+				Reason:            reason,
+				Reference:         fmt.Sprintf("%s-%.2f", payment.ID, payment.RefundedAmount),
+			})
+			s.providers.RecordResult(provider.Name(), err)
+			switch {
+			case stderrors.Is(err, payments.ErrUnsupported):
+				// e.g. M-Pesa reversals are issued outside the API; record the refund only
+				s.logger.Warn("provider cannot refund via API; recording refund only", "payment_id", paymentID, "provider", payment.ProviderName)
+			case err != nil:
+				s.logger.Warn("provider refund failed", "payment_id", paymentID, "provider", payment.ProviderName, "error", err)
+				return nil, providerError("payment provider rejected the refund", err)
+			default:
+				s.logger.Info("provider refund issued", "payment_id", paymentID, "provider_refund_id", redact.ID(refund.ProviderRefundID), "status", refund.Status)
+			}
+		} else if payment.IsThirdPartyPayment() {
+			s.logger.Warn("payment provider not configured; recording refund only", "payment_id", paymentID, "provider", payment.ProviderName)
+		}
+	}
+
 	// Process refund
 	if err := s.repos.Payment.CreateRefund(ctx, paymentID, amount, reason); err != nil {
 		return nil, errors.NewServiceError("REFUND_FAILED", "failed to process refund", err)
@@ -445,7 +1020,7 @@ func (s *paymentService) GetRefundablePayments(ctx context.Context, bookingID uu
 		return nil, errors.NewServiceError("GET_FAILED", "failed to get refundable payments", err)
 	}
 
-	return dto.ToPaymentResponses(payments), nil
+	return dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)), nil
 }
 
 // GetRefundedPayments retrieves all refunded payments
@@ -460,13 +1035,15 @@ func (s *paymentService) GetRefundedPayments(ctx context.Context, tenantID uuid.
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -620,13 +1197,15 @@ func (s *paymentService) GetPaymentsByMethod(ctx context.Context, method models.
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -703,6 +1282,7 @@ func (s *paymentService) GetRevenueByPeriod(ctx context.Context, tenantID uuid.U
 			Period:           data.Period,
 			Revenue:          data.Revenue,
 			TransactionCount: data.TransactionCount,
+			AsOf:             data.AsOf,
 		}
 	}
 
@@ -858,13 +1438,15 @@ func (s *paymentService) SearchPayments(ctx context.Context, query string, tenan
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -893,13 +1475,15 @@ func (s *paymentService) FilterPayments(ctx context.Context, filters dto.Payment
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -918,13 +1502,15 @@ func (s *paymentService) GetRecentPayments(ctx context.Context, tenantID uuid.UU
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -947,13 +1533,15 @@ func (s *paymentService) GetPaymentsByProvider(ctx context.Context, providerName
 	}
 
 	return &dto.PaymentListResponse{
-		Payments:    dto.ToPaymentResponses(payments),
+		Payments:    dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)),
 		Page:        paginationResult.Page,
 		PageSize:    paginationResult.PageSize,
 		TotalItems:  paginationResult.TotalItems,
 		TotalPages:  paginationResult.TotalPages,
 		HasNext:     paginationResult.HasNext,
 		HasPrevious: paginationResult.HasPrev,
+		HasMore:     paginationResult.HasMore,
+		NextCursor:  paginationResult.NextCursor,
 	}, nil
 }
 
@@ -997,7 +1585,7 @@ func (s *paymentService) GetFailedPaymentsByProvider(ctx context.Context, provid
 		return nil, errors.NewServiceError("GET_FAILED", "failed to get failed payments by provider", err)
 	}
 
-	return dto.ToPaymentResponses(payments), nil
+	return dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)), nil
 }
 
 // ============================================================================
@@ -1015,7 +1603,7 @@ func (s *paymentService) GetUnreconciledPayments(ctx context.Context, tenantID u
 		return nil, errors.NewServiceError("GET_FAILED", "failed to get unreconciled payments", err)
 	}
 
-	return dto.ToPaymentResponses(payments), nil
+	return dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)), nil
 }
 
 // MarkPaymentsAsReconciled marks payments as reconciled
@@ -1044,7 +1632,7 @@ func (s *paymentService) GetPaymentsForReconciliation(ctx context.Context, tenan
 		return nil, errors.NewServiceError("GET_FAILED", "failed to get payments for reconciliation", err)
 	}
 
-	return dto.ToPaymentResponses(payments), nil
+	return dto.ToPaymentResponses(payments, dto.ViewerFromContext(ctx)), nil
 }
 
 // ============================================================================
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/payments"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// PayoutService defines the interface for artisan payout operations
+type PayoutService interface {
+	// Batches
+	CreatePayoutBatch(ctx context.Context, req *dto.CreatePayoutBatchRequest) (*dto.PayoutBatchResponse, error)
+	GetPayoutBatch(ctx context.Context, tenantID, batchID uuid.UUID) (*dto.PayoutBatchResponse, error)
+	ListPayoutBatches(ctx context.Context, tenantID uuid.UUID, pagination repository.PaginationParams) (*dto.PayoutBatchListResponse, error)
+	ExportBankFile(ctx context.Context, tenantID, batchID uuid.UUID) (*dto.PayoutBankFile, error)
+	MarkBatchPaid(ctx context.Context, tenantID, batchID uuid.UUID) (*dto.PayoutBatchResponse, error)
+	MarkPayoutFailed(ctx context.Context, tenantID, payoutID uuid.UUID, reason string) (*dto.PayoutResponse, error)
+
+	// Artisan History
+	GetArtisanPayouts(ctx context.Context, tenantID, artisanID uuid.UUID, pagination repository.PaginationParams) (*dto.PayoutListResponse, error)
+	GetArtisanPayoutSummary(ctx context.Context, tenantID, artisanID uuid.UUID, currency string) (*dto.ArtisanPayoutSummaryResponse, error)
+}
+
+// payoutService implements PayoutService
+type payoutService struct {
+	repos     *repository.Repositories
+	logger    log.AllLogger
+	providers *payments.Registry
+}
+
+// NewPayoutService creates a new PayoutService instance; providers may be nil, in which
+// case only bank transfer batches can be created
+func NewPayoutService(repos *repository.Repositories, logger log.AllLogger, providers *payments.Registry) PayoutService {
+	return &payoutService{
+		repos:     repos,
+		logger:    logger,
+		providers: providers,
+	}
+}
+
+// CreatePayoutBatch groups the tenant's unsettled artisan earnings into one payout per
+// artisan and sends them. Stripe Connect payouts are transferred immediately; bank
+// transfer payouts wait in processing until the tenant pays the exported bank file and
+// marks the batch paid.
+func (s *payoutService) CreatePayoutBatch(ctx context.Context, req *dto.CreatePayoutBatchRequest) (*dto.PayoutBatchResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	// Resolve the provider before claiming any earnings
+	var transferer payments.Transferer
+	if req.Method == models.PayoutMethodStripeConnect {
+		provider, err := s.providers.Get(payments.ProviderStripe)
+		if err != nil {
+			return nil, errors.NewAppErrorWithErr("PROVIDER_UNAVAILABLE", "Stripe is not configured", http.StatusServiceUnavailable, err)
+		}
+		var ok bool
+		if transferer, ok = provider.(payments.Transferer); !ok {
+			return nil, errors.NewAppErrorWithErr("PROVIDER_UNAVAILABLE", "Stripe transfers are not supported", http.StatusServiceUnavailable, payments.ErrUnsupported)
+		}
+	}
+
+	periodEnd := time.Now()
+	if req.PeriodEnd != nil {
+		periodEnd = *req.PeriodEnd
+	}
+	batch := &models.PayoutBatch{
+		TenantID:  req.TenantID,
+		Method:    req.Method,
+		Currency:  req.Currency,
+		PeriodEnd: periodEnd,
+	}
+	if req.CreatedBy != uuid.Nil {
+		batch.CreatedBy = &req.CreatedBy
+	}
+
+	payouts, err := s.repos.Payout.CreatePayoutBatch(ctx, batch, req.MinimumAmount)
+	if err != nil {
+		return nil, errors.NewServiceError("CREATE_FAILED", "failed to create payout batch", err)
+	}
+	if len(payouts) == 0 {
+		return nil, errors.NewValidationError("no unpaid artisan earnings to pay out")
+	}
+	batch.Payouts = payouts
+
+	for _, payout := range payouts {
+		if transferer != nil {
+			s.sendTransfer(ctx, transferer, payout)
+		} else {
+			payout.Status = models.PayoutStatusProcessing
+		}
+		if err := s.repos.Payout.SettlePayout(ctx, payout); err != nil {
+			// The payout stays pending with its payments linked; it is visible in the batch for follow-up
+			s.logger.Error("failed to save payout result", "payout_id", payout.ID, "error", err)
+		}
+	}
+
+	s.refreshBatchStatus(batch)
+	if err := s.repos.Payout.UpdateBatchStatus(ctx, batch); err != nil {
+		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update payout batch", err)
+	}
+
+	s.logger.Info("payout batch created", "batch_id", batch.ID, "tenant_id", batch.TenantID,
+		"method", batch.Method, "payouts", batch.PayoutCount, "failed", batch.FailedCount, "total", batch.TotalAmount)
+
+	return dto.ToPayoutBatchResponse(batch), nil
+}
+
+// sendTransfer moves a payout to the artisan's connected Stripe account. The payout ID
+// is the transfer's idempotency reference, so a retried payout is never sent twice.
+func (s *payoutService) sendTransfer(ctx context.Context, transferer payments.Transferer, payout *models.Payout) {
+	artisan, err := s.repos.Artisan.FindByUserID(ctx, payout.ArtisanID)
+	if err != nil {
+		payout.MarkAsFailed("artisan profile not found")
+		return
+	}
+	if artisan.PaymentAccountID == "" {
+		payout.MarkAsFailed("artisan has no connected payment account")
+		return
+	}
+	payout.Destination = artisan.PaymentAccountID
+
+	transfer, err := transferer.Transfer(ctx, payments.TransferRequest{
+		Destination: artisan.PaymentAccountID,
+		Amount:      payout.Amount,
+		Currency:    payout.Currency,
+		Reference:   payout.ID.String(),
+		Description: fmt.Sprintf("Payout for %d payments", payout.PaymentCount),
+	})
+	if err != nil {
+		s.logger.Warn("payout transfer failed", "payout_id", payout.ID, "artisan_id", payout.ArtisanID, "error", err)
+		payout.MarkAsFailed(err.Error())
+		return
+	}
+
+	switch transfer.Status {
+	case payments.IntentSucceeded:
+		payout.MarkAsPaid(transfer.ProviderTransferID)
+	case payments.IntentFailed, payments.IntentCanceled:
+		payout.ProviderTransferID = transfer.ProviderTransferID
+		payout.MarkAsFailed(fmt.Sprintf("transfer %s", transfer.Status))
+	default:
+		payout.ProviderTransferID = transfer.ProviderTransferID
+		payout.Status = models.PayoutStatusProcessing
+	}
+}
+
+// refreshBatchStatus derives a batch's status and failure count from its payouts. A batch
+// is complete once no payout is waiting; it is failed only if every payout failed.
+func (s *payoutService) refreshBatchStatus(batch *models.PayoutBatch) {
+	waiting, failed := 0, 0
+	for _, payout := range batch.Payouts {
+		switch payout.Status {
+		case models.PayoutStatusPending, models.PayoutStatusProcessing:
+			waiting++
+		case models.PayoutStatusFailed:
+			failed++
+		}
+	}
+
+	batch.FailedCount = failed
+	switch {
+	case waiting > 0:
+		batch.Status = models.PayoutStatusProcessing
+		batch.CompletedAt = nil
+		return
+	case failed == len(batch.Payouts):
+		batch.Status = models.PayoutStatusFailed
+	default:
+		batch.Status = models.PayoutStatusPaid
+	}
+	if batch.CompletedAt == nil {
+		now := time.Now()
+		batch.CompletedAt = &now
+	}
+}
+
+// GetPayoutBatch retrieves a payout batch with its payouts
+func (s *payoutService) GetPayoutBatch(ctx context.Context, tenantID, batchID uuid.UUID) (*dto.PayoutBatchResponse, error) {
+	batch, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToPayoutBatchResponse(batch), nil
+}
+
+func (s *payoutService) getBatch(ctx context.Context, tenantID, batchID uuid.UUID) (*models.PayoutBatch, error) {
+	if batchID == uuid.Nil {
+		return nil, errors.NewValidationError("batch ID is required")
+	}
+
+	batch, err := s.repos.Payout.GetBatch(ctx, tenantID, batchID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("payout batch")
+		}
+		return nil, errors.NewServiceError("GET_FAILED", "failed to get payout batch", err)
+	}
+	return batch, nil
+}
+
+// ListPayoutBatches lists a tenant's payout batches
+func (s *payoutService) ListPayoutBatches(ctx context.Context, tenantID uuid.UUID, pagination repository.PaginationParams) (*dto.PayoutBatchListResponse, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	batches, paginationResult, err := s.repos.Payout.ListBatches(ctx, tenantID, pagination)
+	if err != nil {
+		return nil, errors.NewServiceError("GET_FAILED", "failed to list payout batches", err)
+	}
+
+	responses := make([]*dto.PayoutBatchResponse, len(batches))
+	for i, batch := range batches {
+		responses[i] = dto.ToPayoutBatchResponse(batch)
+	}
+
+	return &dto.PayoutBatchListResponse{
+		Batches:     responses,
+		Page:        paginationResult.Page,
+		PageSize:    paginationResult.PageSize,
+		TotalItems:  paginationResult.TotalItems,
+		TotalPages:  paginationResult.TotalPages,
+		HasNext:     paginationResult.HasNext,
+		HasPrevious: paginationResult.HasPrev,
+	}, nil
+}
+
+// ExportBankFile renders a bank transfer batch as CSV, one row per payout still to be paid.
+// The payout ID is the payment reference the tenant gives their bank.
+func (s *payoutService) ExportBankFile(ctx context.Context, tenantID, batchID uuid.UUID) (*dto.PayoutBankFile, error) {
+	batch, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Method != models.PayoutMethodBankTransfer {
+		return nil, errors.NewValidationError("only bank transfer batches can be exported")
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"reference", "artisan_name", "artisan_email", "artisan_phone", "amount", "currency", "payment_count"})
+	for _, payout := range batch.Payouts {
+		if payout.Status != models.PayoutStatusProcessing {
+			continue
+		}
+		var name, email, phone string
+		if payout.Artisan != nil {
+			name, email, phone = payout.Artisan.FullName(), payout.Artisan.Email, payout.Artisan.PhoneNumber
+		}
+		_ = w.Write([]string{
+			payout.ID.String(),
+			name,
+			email,
+			phone,
+			strconv.FormatFloat(payout.Amount, 'f', 2, 64),
+			payout.Currency,
+			strconv.Itoa(payout.PaymentCount),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, errors.NewServiceError("EXPORT_FAILED", "failed to write bank file", err)
+	}
+
+	return &dto.PayoutBankFile{
+		FileName: fmt.Sprintf("payouts-%s-%s.csv", batch.CreatedAt.Format("20060102"), batch.ID.String()[:8]),
+		Content:  buf.Bytes(),
+	}, nil
+}
+
+// MarkBatchPaid confirms that the tenant has paid a bank transfer batch, settling every
+// payout still in processing
+func (s *payoutService) MarkBatchPaid(ctx context.Context, tenantID, batchID uuid.UUID) (*dto.PayoutBatchResponse, error) {
+	batch, err := s.getBatch(ctx, tenantID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Method != models.PayoutMethodBankTransfer {
+		return nil, errors.NewValidationError("only bank transfer batches can be marked paid")
+	}
+	if batch.Status != models.PayoutStatusProcessing {
+		return nil, errors.NewValidationError(fmt.Sprintf("payout batch is already %s", batch.Status))
+	}
+
+	for _, payout := range batch.Payouts {
+		if payout.Status != models.PayoutStatusProcessing {
+			continue
+		}
+		payout.MarkAsPaid("")
+		if err := s.repos.Payout.SettlePayout(ctx, payout); err != nil {
+			return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update payout", err)
+		}
+	}
+
+	s.refreshBatchStatus(batch)
+	if err := s.repos.Payout.UpdateBatchStatus(ctx, batch); err != nil {
+		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update payout batch", err)
+	}
+
+	s.logger.Info("payout batch marked paid", "batch_id", batch.ID, "tenant_id", tenantID)
+
+	return dto.ToPayoutBatchResponse(batch), nil
+}
+
+// MarkPayoutFailed records that a bank transfer payout was rejected. Its payments are
+// released so their earnings are included in the next batch.
+func (s *payoutService) MarkPayoutFailed(ctx context.Context, tenantID, payoutID uuid.UUID, reason string) (*dto.PayoutResponse, error) {
+	if payoutID == uuid.Nil {
+		return nil, errors.NewValidationError("payout ID is required")
+	}
+	if reason == "" {
+		return nil, errors.NewValidationError("reason is required")
+	}
+
+	payout, err := s.repos.Payout.GetByID(ctx, payoutID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("payout")
+		}
+		return nil, errors.NewServiceError("GET_FAILED", "failed to get payout", err)
+	}
+	if payout.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("payout")
+	}
+	if payout.Status != models.PayoutStatusProcessing && payout.Status != models.PayoutStatusPending {
+		return nil, errors.NewValidationError(fmt.Sprintf("payout cannot be marked failed (status: %s)", payout.Status))
+	}
+
+	payout.MarkAsFailed(reason)
+	if err := s.repos.Payout.SettlePayout(ctx, payout); err != nil {
+		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update payout", err)
+	}
+
+	batch, err := s.getBatch(ctx, tenantID, payout.BatchID)
+	if err != nil {
+		return nil, err
+	}
+	s.refreshBatchStatus(batch)
+	if err := s.repos.Payout.UpdateBatchStatus(ctx, batch); err != nil {
+		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update payout batch", err)
+	}
+
+	s.logger.Info("payout marked failed", "payout_id", payout.ID, "batch_id", batch.ID, "reason", reason)
+
+	return dto.ToPayoutResponse(payout), nil
+}
+
+// GetArtisanPayouts retrieves an artisan's payout history
+func (s *payoutService) GetArtisanPayouts(ctx context.Context, tenantID, artisanID uuid.UUID, pagination repository.PaginationParams) (*dto.PayoutListResponse, error) {
+	if artisanID == uuid.Nil {
+		return nil, errors.NewValidationError("artisan ID is required")
+	}
+
+	payouts, paginationResult, err := s.repos.Payout.ListByArtisan(ctx, tenantID, artisanID, pagination)
+	if err != nil {
+		return nil, errors.NewServiceError("GET_FAILED", "failed to get artisan payouts", err)
+	}
+
+	return &dto.PayoutListResponse{
+		Payouts:     dto.ToPayoutResponses(payouts),
+		Page:        paginationResult.Page,
+		PageSize:    paginationResult.PageSize,
+		TotalItems:  paginationResult.TotalItems,
+		TotalPages:  paginationResult.TotalPages,
+		HasNext:     paginationResult.HasNext,
+		HasPrevious: paginationResult.HasPrev,
+	}, nil
+}
+
+// GetArtisanPayoutSummary reports an artisan's unpaid and escrowed earnings and total paid out
+func (s *payoutService) GetArtisanPayoutSummary(ctx context.Context, tenantID, artisanID uuid.UUID, currency string) (*dto.ArtisanPayoutSummaryResponse, error) {
+	if artisanID == uuid.Nil {
+		return nil, errors.NewValidationError("artisan ID is required")
+	}
+	if len(currency) != 3 {
+		return nil, errors.NewValidationError(dto.ErrInvalidCurrency.Error())
+	}
+
+	unpaid, err := s.repos.Payout.GetUnsettledTotal(ctx, tenantID, artisanID, currency)
+	if err != nil {
+		return nil, errors.NewServiceError("CALCULATION_FAILED", "failed to get unpaid earnings", err)
+	}
+	paid, err := s.repos.Payout.GetPaidTotal(ctx, tenantID, artisanID, currency)
+	if err != nil {
+		return nil, errors.NewServiceError("CALCULATION_FAILED", "failed to get paid out total", err)
+	}
+	held, err := s.repos.Payout.GetHeldTotal(ctx, tenantID, artisanID, currency)
+	if err != nil {
+		return nil, errors.NewServiceError("CALCULATION_FAILED", "failed to get held earnings", err)
+	}
+
+	return &dto.ArtisanPayoutSummaryResponse{
+		ArtisanID:      artisanID,
+		Currency:       currency,
+		UnpaidEarnings: unpaid,
+		HeldEarnings:   held,
+		TotalPaidOut:   paid,
+	}, nil
+}
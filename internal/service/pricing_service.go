@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// PricingService defines the interface for dynamic pricing rule operations
+type PricingService interface {
+	// CRUD Operations
+	CreatePricingRule(ctx context.Context, req *dto.CreatePricingRuleRequest) (*dto.PricingRuleResponse, error)
+	GetPricingRule(ctx context.Context, id uuid.UUID) (*dto.PricingRuleResponse, error)
+	UpdatePricingRule(ctx context.Context, id uuid.UUID, req *dto.UpdatePricingRuleRequest) (*dto.PricingRuleResponse, error)
+	DeletePricingRule(ctx context.Context, id uuid.UUID) error
+	ListPricingRules(ctx context.Context, tenantID uuid.UUID) ([]*dto.PricingRuleResponse, error)
+
+	// Evaluation
+	PriceService(ctx context.Context, service *models.Service, artisanID uuid.UUID, startTime time.Time) (float64, models.PricingAdjustments, error)
+}
+
+// pricingService implements PricingService
+type pricingService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewPricingService creates a new PricingService instance
+func NewPricingService(repos *repository.Repositories, logger log.AllLogger) PricingService {
+	return &pricingService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ============================================================================
+// CRUD Operations
+// ============================================================================
+
+// CreatePricingRule creates a peak, off-peak, last-minute or seasonal rule
+func (s *pricingService) CreatePricingRule(ctx context.Context, req *dto.CreatePricingRuleRequest) (*dto.PricingRuleResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	rule := &models.PricingRule{
+		TenantID:        req.TenantID,
+		Name:            strings.TrimSpace(req.Name),
+		Kind:            req.Kind,
+		ServiceID:       req.ServiceID,
+		ServiceCategory: req.ServiceCategory,
+		ArtisanID:       req.ArtisanID,
+		Weekdays:        req.Weekdays,
+		StartHour:       req.StartHour,
+		EndHour:         req.EndHour,
+		Timezone:        strings.TrimSpace(req.Timezone),
+		LeadHours:       req.LeadHours,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		Multiplier:      req.Multiplier,
+		IsActive:        req.IsActive == nil || *req.IsActive,
+	}
+	if rule.Timezone == "" {
+		rule.Timezone = "UTC"
+	}
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.repos.PricingRule.Create(ctx, rule); err != nil {
+		s.logger.Error("failed to create pricing rule", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("PRICING_RULE_CREATE_FAILED", "failed to create pricing rule", err)
+	}
+
+	s.logger.Info("pricing rule created", "pricing_rule_id", rule.ID, "tenant_id", rule.TenantID, "kind", rule.Kind, "multiplier", rule.Multiplier)
+	return dto.ToPricingRuleResponse(rule), nil
+}
+
+// GetPricingRule retrieves a pricing rule by ID
+func (s *pricingService) GetPricingRule(ctx context.Context, id uuid.UUID) (*dto.PricingRuleResponse, error) {
+	rule, err := s.getPricingRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToPricingRuleResponse(rule), nil
+}
+
+// UpdatePricingRule updates a pricing rule. Bookings already made keep the prices and
+// adjustments they were made with.
+func (s *pricingService) UpdatePricingRule(ctx context.Context, id uuid.UUID, req *dto.UpdatePricingRuleRequest) (*dto.PricingRuleResponse, error) {
+	rule, err := s.getPricingRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.ClearServiceID {
+		rule.ServiceID = nil
+	}
+	if req.ServiceID != nil {
+		rule.ServiceID = req.ServiceID
+	}
+	if req.ServiceCategory != nil {
+		rule.ServiceCategory = *req.ServiceCategory
+	}
+	if req.ClearArtisanID {
+		rule.ArtisanID = nil
+	}
+	if req.ArtisanID != nil {
+		rule.ArtisanID = req.ArtisanID
+	}
+	if req.Weekdays != nil {
+		rule.Weekdays = *req.Weekdays
+	}
+	if req.StartHour != nil {
+		rule.StartHour = *req.StartHour
+	}
+	if req.EndHour != nil {
+		rule.EndHour = *req.EndHour
+	}
+	if req.Timezone != nil {
+		rule.Timezone = strings.TrimSpace(*req.Timezone)
+	}
+	if req.LeadHours != nil {
+		rule.LeadHours = *req.LeadHours
+	}
+	if req.ClearPeriod {
+		rule.StartsAt, rule.EndsAt = nil, nil
+	}
+	if req.StartsAt != nil {
+		rule.StartsAt = req.StartsAt
+	}
+	if req.EndsAt != nil {
+		rule.EndsAt = req.EndsAt
+	}
+	if req.Multiplier != nil {
+		rule.Multiplier = *req.Multiplier
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.repos.PricingRule.Update(ctx, rule); err != nil {
+		s.logger.Error("failed to update pricing rule", "pricing_rule_id", id, "error", err)
+		return nil, errors.NewServiceError("PRICING_RULE_UPDATE_FAILED", "failed to update pricing rule", err)
+	}
+
+	return dto.ToPricingRuleResponse(rule), nil
+}
+
+// DeletePricingRule deletes a pricing rule
+func (s *pricingService) DeletePricingRule(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getPricingRule(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repos.PricingRule.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete pricing rule", "pricing_rule_id", id, "error", err)
+		return errors.NewServiceError("PRICING_RULE_DELETE_FAILED", "failed to delete pricing rule", err)
+	}
+
+	return nil
+}
+
+// ListPricingRules lists all pricing rules of a tenant
+func (s *pricingService) ListPricingRules(ctx context.Context, tenantID uuid.UUID) ([]*dto.PricingRuleResponse, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	rules, err := s.repos.PricingRule.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("PRICING_RULE_LIST_FAILED", "failed to list pricing rules", err)
+	}
+
+	return dto.ToPricingRuleResponses(rules), nil
+}
+
+// ============================================================================
+// Evaluation
+// ============================================================================
+
+// PriceService prices a booking of the service with the artisan starting at startTime
+// from the service's list price and the tenant's pricing rules. It returns the price,
+// which addons, discounts and tax are then priced on, and the rules' adjustments to
+// record on the booking.
+func (s *pricingService) PriceService(ctx context.Context, service *models.Service, artisanID uuid.UUID, startTime time.Time) (float64, models.PricingAdjustments, error) {
+	rules, err := s.repos.PricingRule.FindActive(ctx, service.TenantID)
+	if err != nil {
+		return 0, nil, errors.NewServiceError("PRICING_RULE_LIST_FAILED", "failed to load pricing rules", err)
+	}
+
+	price, adjustments := models.ApplyPricingRules(rules, models.PricingContext{
+		ServiceID:       service.ID,
+		ServiceCategory: service.Category,
+		ArtisanID:       artisanID,
+		StartTime:       startTime,
+		BookedAt:        time.Now(),
+	}, service.Price)
+	return price, adjustments, nil
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+func (s *pricingService) getPricingRule(ctx context.Context, id uuid.UUID) (*models.PricingRule, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("pricing rule ID is required")
+	}
+
+	rule, err := s.repos.PricingRule.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("pricing rule not found")
+		}
+		return nil, errors.NewServiceError("PRICING_RULE_GET_FAILED", "failed to get pricing rule", err)
+	}
+
+	return rule, nil
+}
+
+// validateRule checks the rule's values and that the service and artisan it is scoped
+// to belong to its tenant
+func (s *pricingService) validateRule(ctx context.Context, rule *models.PricingRule) error {
+	if err := rule.Validate(); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if rule.ServiceID != nil {
+		service, err := s.repos.Service.GetByID(ctx, *rule.ServiceID)
+		if err != nil || service.TenantID != rule.TenantID {
+			return errors.NewValidationError("service not found")
+		}
+	}
+	if rule.ArtisanID != nil {
+		artisan, err := s.repos.User.GetByID(ctx, *rule.ArtisanID)
+		if err != nil || artisan.TenantID == nil || *artisan.TenantID != rule.TenantID || !artisan.IsArtisan() {
+			return errors.NewValidationError("artisan not found")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// projectHealthBatchSize caps the projects recalculated per run
+	projectHealthBatchSize = 200
+	// projectHealthMaxAge is how long a project's health score is kept before it is
+	// recalculated
+	projectHealthMaxAge = 6 * time.Hour
+	// projectHealthHistoryDays is the default trend window
+	projectHealthHistoryDays = 30
+	// projectHealthMaxHistoryDays caps the trend window
+	projectHealthMaxHistoryDays = 365
+)
+
+// ProjectHealthService defines the interface for tracking project health over time
+type ProjectHealthService interface {
+	// RecalculateHealth scores active projects whose health is stale, records the
+	// scores and alerts when a project drops to high risk or becomes overdue
+	RecalculateHealth(ctx context.Context) (*dto.ProjectHealthRunResponse, error)
+
+	// GetHealthHistory returns a project's recorded health scores over the last days
+	GetHealthHistory(ctx context.Context, tenantID, projectID uuid.UUID, days int) (*dto.ProjectHealthHistoryResponse, error)
+}
+
+// projectHealthService implements ProjectHealthService
+type projectHealthService struct {
+	repos               *repository.Repositories
+	logger              log.AllLogger
+	notificationService NotificationService
+	webhookService      WebhookRepository
+	authorizer          Authorizer
+}
+
+// NewProjectHealthService creates a new ProjectHealthService instance
+func NewProjectHealthService(repos *repository.Repositories, logger log.AllLogger, notificationService NotificationService, webhookService WebhookRepository) ProjectHealthService {
+	return &projectHealthService{
+		repos:               repos,
+		logger:              logger,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+		authorizer:          NewAuthorizer(repos, logger),
+	}
+}
+
+// RecalculateHealth recalculates the health of active projects
+func (s *projectHealthService) RecalculateHealth(ctx context.Context) (*dto.ProjectHealthRunResponse, error) {
+	result := &dto.ProjectHealthRunResponse{}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	projects, err := s.repos.Project.FindDueForHealthCheck(ctx, now.Add(-projectHealthMaxAge), projectHealthBatchSize)
+	if err != nil {
+		return result, errors.NewServiceError("QUERY_FAILED", "failed to list projects due for a health check", err)
+	}
+
+	for _, project := range projects {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		health, err := s.repos.Project.GetProjectHealth(ctx, project.ID)
+		if err != nil {
+			s.logger.Error("failed to calculate project health", "project_id", project.ID, "error", err)
+			result.Failed++
+			continue
+		}
+
+		snapshot := &models.ProjectHealthSnapshot{
+			TenantID:          project.TenantID,
+			ProjectID:         project.ID,
+			HealthScore:       health.HealthScore,
+			RiskLevel:         health.RiskLevel,
+			IsOnTrack:         health.IsOnTrack,
+			IsOverdue:         health.IsOverdue,
+			IsOverBudget:      health.IsOverBudget,
+			BlockedTasksCount: health.BlockedTasksCount,
+			OverdueTasksCount: health.OverdueTasksCount,
+			ScheduleDaysLate:  health.ScheduleDaysLate,
+			ProgressPercent:   project.ProgressPercent,
+			RecordedAt:        now,
+		}
+
+		previous, err := s.repos.Project.RecordHealthSnapshot(ctx, project, snapshot)
+		if err != nil {
+			// Another instance recorded this project first and sends its alerts
+			if errors.IsConflict(err) {
+				continue
+			}
+			result.Failed++
+			continue
+		}
+		result.Checked++
+
+		if snapshot.BecameHighRisk(previous) {
+			s.alert(ctx, project, snapshot, models.NotificationTypeProjectAtRisk, models.WebhookEventProjectHighRisk)
+			result.HighRiskAlerts++
+		}
+		if snapshot.BecameOverdue(previous) {
+			s.alert(ctx, project, snapshot, models.NotificationTypeProjectOverdue, models.WebhookEventProjectOverdue)
+			result.OverdueAlerts++
+		}
+	}
+
+	if result.Checked > 0 || result.Failed > 0 {
+		s.logger.Info("project health recalculated",
+			"checked", result.Checked,
+			"high_risk_alerts", result.HighRiskAlerts,
+			"overdue_alerts", result.OverdueAlerts,
+			"failed", result.Failed,
+		)
+	}
+	return result, nil
+}
+
+// GetHealthHistory retrieves a project's health trend
+func (s *projectHealthService) GetHealthHistory(ctx context.Context, tenantID, projectID uuid.UUID, days int) (*dto.ProjectHealthHistoryResponse, error) {
+	if projectID == uuid.Nil {
+		return nil, errors.NewValidationError("project_id is required")
+	}
+	if days <= 0 {
+		days = projectHealthHistoryDays
+	}
+	if days > projectHealthMaxHistoryDays {
+		return nil, errors.NewValidationError("days cannot exceed 365")
+	}
+
+	project, err := s.repos.Project.GetByID(ctx, projectID)
+	if err != nil || project.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("project")
+	}
+	if err := authorizeProject(ctx, s.repos, s.authorizer, authz.ActionProjectRead, project); err != nil {
+		return nil, err
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	snapshots, err := s.repos.Project.GetHealthHistory(ctx, project.ID, since)
+	if err != nil {
+		return nil, errors.NewServiceError("HEALTH_HISTORY_FAILED", "failed to get project health history", err)
+	}
+
+	return dto.ToProjectHealthHistoryResponse(project.ID, since, snapshots), nil
+}
+
+// alert notifies the project's artisan and queues a webhook for the tenant. Failures
+// are logged; the recorded snapshot stands either way.
+func (s *projectHealthService) alert(ctx context.Context, project *models.Project, snapshot *models.ProjectHealthSnapshot, notifType models.NotificationType, eventType models.WebhookEventType) {
+	artisan, err := s.repos.Artisan.GetByID(ctx, project.ArtisanID)
+	if err != nil {
+		s.logger.Error("failed to find project artisan for health alert", "project_id", project.ID, "artisan_id", project.ArtisanID, "error", err)
+	} else if _, err := s.notificationService.SendProjectHealthAlert(ctx, project, artisan.UserID, notifType, snapshot); err != nil {
+		s.logger.Error("failed to send project health notification", "project_id", project.ID, "type", notifType, "error", err)
+	}
+
+	if err := s.webhookService.TriggerProjectEvent(ctx, project.TenantID, eventType, map[string]any{
+		"project_id":          project.ID,
+		"title":               project.Title,
+		"status":              project.Status,
+		"due_date":            project.DueDate,
+		"health_score":        snapshot.HealthScore,
+		"risk_level":          snapshot.RiskLevel,
+		"is_overdue":          snapshot.IsOverdue,
+		"blocked_tasks_count": snapshot.BlockedTasksCount,
+		"overdue_tasks_count": snapshot.OverdueTasksCount,
+		"schedule_days_late":  snapshot.ScheduleDaysLate,
+		"progress_percent":    snapshot.ProgressPercent,
+	}); err != nil {
+		s.logger.Error("failed to queue project health webhook", "project_id", project.ID, "event_type", eventType, "error", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"Krafti_Vibe/internal/domain/authz"
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
 	"Krafti_Vibe/internal/repository"
@@ -48,7 +49,8 @@ type ProjectService interface {
 	GetArtisanProjectStats(ctx context.Context, artisanID uuid.UUID) (*dto.ArtisanProjectStatsResponse, error)
 	GetCustomerProjectStats(ctx context.Context, customerID uuid.UUID) (*dto.CustomerProjectStatsResponse, error)
 	GetProjectHealth(ctx context.Context, id uuid.UUID) (*dto.ProjectHealthResponse, error)
-	GetProjectTimeline(ctx context.Context, id uuid.UUID) (*dto.ProjectTimelineResponse, error)
+	GetProjectSchedule(ctx context.Context, id uuid.UUID) (*dto.ProjectScheduleResponse, error)
+	GetProjectTimeline(ctx context.Context, id uuid.UUID, page, pageSize int) (*dto.ProjectTimelineResponse, error)
 
 	// Dashboard
 	GetArtisanDashboard(ctx context.Context, artisanID uuid.UUID) (*dto.ArtisanDashboardResponse, error)
@@ -65,15 +67,17 @@ type ProjectService interface {
 
 // projectServiceEnhanced implements ProjectServiceEnhanced
 type projectService struct {
-	repos  *repository.Repositories
-	logger log.AllLogger
+	repos      *repository.Repositories
+	logger     log.AllLogger
+	authorizer Authorizer
 }
 
 // NewProjectServiceEnhanced creates a new ProjectServiceEnhanced instance
 func NewProjectService(repos *repository.Repositories, logger log.AllLogger) ProjectService {
 	return &projectService{
-		repos:  repos,
-		logger: logger,
+		repos:      repos,
+		logger:     logger,
+		authorizer: NewAuthorizer(repos, logger),
 	}
 }
 
@@ -97,6 +101,11 @@ func (s *projectService) CreateProject(ctx context.Context, req *dto.CreateProje
 		return nil, errors.NewValidationError("artisan does not belong to tenant")
 	}
 
+	// Artisans may only create projects of their own
+	if err := s.authorizer.Can(ctx, authz.ActionProjectCreate, authz.Resource{TenantID: req.TenantID, Owners: []uuid.UUID{artisan.UserID}}); err != nil {
+		return nil, err
+	}
+
 	// Verify customer if provided
 	if req.CustomerID != nil {
 		customer, err := s.repos.Customer.GetByID(ctx, *req.CustomerID)
@@ -140,6 +149,7 @@ func (s *projectService) CreateProject(ctx context.Context, req *dto.CreateProje
 		DueDate:      req.DueDate,
 		BudgetAmount: req.BudgetAmount,
 		Currency:     currency,
+		HourlyRate:   req.HourlyRate,
 		Tags:         req.Tags,
 		Metadata:     metadata,
 	}
@@ -171,6 +181,9 @@ func (s *projectService) GetProject(ctx context.Context, id uuid.UUID) (*dto.Pro
 		s.logger.Error("failed to get project", "project_id", id, "error", err)
 		return nil, errors.NewNotFoundError("project not found")
 	}
+	if err := s.authorizeProject(ctx, authz.ActionProjectRead, project); err != nil {
+		return nil, err
+	}
 
 	return dto.ToProjectResponse(project), nil
 }
@@ -186,6 +199,9 @@ func (s *projectService) GetProjectWithDetails(ctx context.Context, id uuid.UUID
 		s.logger.Error("failed to get project with details", "project_id", id, "error", err)
 		return nil, errors.NewNotFoundError("project not found")
 	}
+	if err := s.authorizeProject(ctx, authz.ActionProjectRead, project); err != nil {
+		return nil, err
+	}
 
 	return dto.ToProjectResponse(project), nil
 }
@@ -202,6 +218,15 @@ func (s *projectService) UpdateProject(ctx context.Context, id uuid.UUID, req *d
 		s.logger.Error("failed to find project", "project_id", id, "error", err)
 		return nil, errors.NewNotFoundError("project not found")
 	}
+	if err := s.authorizeProject(ctx, authz.ActionProjectUpdate, existing); err != nil {
+		return nil, err
+	}
+
+	// Reject edits made against a stale version so concurrent changes are not overwritten
+	if req.Version != nil && *req.Version != existing.Version {
+		return nil, errors.NewVersionConflictError("project", *req.Version, existing.Version, projectUpdateConflicts(existing, req))
+	}
+	loadedVersion := existing.Version
 
 	// Apply updates
 	if req.Title != nil {
@@ -236,6 +261,12 @@ func (s *projectService) UpdateProject(ctx context.Context, id uuid.UUID, req *d
 	if req.Currency != nil {
 		existing.Currency = *req.Currency
 	}
+	if req.HourlyRate != nil {
+		if *req.HourlyRate < 0 {
+			return nil, errors.NewValidationError("hourly_rate cannot be negative")
+		}
+		existing.HourlyRate = *req.HourlyRate
+	}
 	if req.Tags != nil {
 		existing.Tags = req.Tags
 	}
@@ -252,6 +283,12 @@ func (s *projectService) UpdateProject(ctx context.Context, id uuid.UUID, req *d
 	}
 
 	if err := s.repos.Project.Update(ctx, existing); err != nil {
+		if errors.IsConflict(err) {
+			if current, getErr := s.repos.Project.GetByID(ctx, id); getErr == nil {
+				return nil, errors.NewVersionConflictError("project", loadedVersion, current.Version, projectUpdateConflicts(current, req))
+			}
+			return nil, errors.NewConflictError("project was modified by another user")
+		}
 		s.logger.Error("failed to update project", "project_id", id, "error", err)
 		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update project", err)
 	}
@@ -267,13 +304,82 @@ func (s *projectService) UpdateProject(ctx context.Context, id uuid.UUID, req *d
 	return dto.ToProjectResponse(updated), nil
 }
 
-// DeleteProject deletes a project
+// getAuthorizedProject loads a project, checking the request's actor may take the action on it
+func (s *projectService) getAuthorizedProject(ctx context.Context, id uuid.UUID, action authz.Action) (*models.Project, error) {
+	project, err := s.repos.Project.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("project not found")
+		}
+		return nil, errors.NewServiceError("FIND_FAILED", "failed to find project", err)
+	}
+	if err := s.authorizeProject(ctx, action, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// authorizeProject checks the request's actor may take the action on the project
+func (s *projectService) authorizeProject(ctx context.Context, action authz.Action, project *models.Project) error {
+	return authorizeProject(ctx, s.repos, s.authorizer, action, project)
+}
+
+// authorizeProject checks the request's actor may take the action on the project. The
+// project's owners are the users of its artisan and customer profiles, loaded on a copy
+// when missing so saving the project afterwards doesn't write them back.
+func authorizeProject(ctx context.Context, repos *repository.Repositories, authorizer Authorizer, action authz.Action, project *models.Project) error {
+	if _, ok := authz.ActorFromContext(ctx); !ok {
+		return nil
+	}
+
+	owned := *project
+	if owned.Artisan == nil {
+		if artisan, err := repos.Artisan.GetByID(ctx, owned.ArtisanID); err == nil {
+			owned.Artisan = artisan
+		}
+	}
+	if owned.Customer == nil && owned.CustomerID != nil {
+		if customer, err := repos.Customer.GetByID(ctx, *owned.CustomerID); err == nil {
+			owned.Customer = customer
+		}
+	}
+	return authorizer.Can(ctx, action, authz.Project(&owned))
+}
+
+// projectUpdateConflicts lists the submitted fields whose stored value differs from the submitted one
+func projectUpdateConflicts(project *models.Project, req *dto.UpdateProjectRequest) []errors.FieldConflict {
+	var conflicts fieldConflicts
+	checkConflict(&conflicts, "title", req.Title, project.Title)
+	checkConflict(&conflicts, "description", req.Description, project.Description)
+	checkConflict(&conflicts, "priority", req.Priority, project.Priority)
+	checkConflict(&conflicts, "budget_amount", req.BudgetAmount, project.BudgetAmount)
+	checkConflict(&conflicts, "currency", req.Currency, project.Currency)
+	checkConflict(&conflicts, "hourly_rate", req.HourlyRate, project.HourlyRate)
+	if req.StartDate != nil {
+		checkConflict(&conflicts, "start_date", &req.StartDate, project.StartDate)
+	}
+	if req.DueDate != nil {
+		checkConflict(&conflicts, "due_date", &req.DueDate, project.DueDate)
+	}
+	if req.Tags != nil {
+		checkConflict(&conflicts, "tags", &req.Tags, project.Tags)
+	}
+	for key, value := range req.Metadata {
+		checkConflict(&conflicts, "metadata."+key, &value, project.Metadata[key])
+	}
+	return conflicts
+}
+
+// DeleteProject moves a project to the trash, from which it can be restored until it is purged
 func (s *projectService) DeleteProject(ctx context.Context, id uuid.UUID) error {
 	if id == uuid.Nil {
 		return errors.NewValidationError("project_id is required")
 	}
+	if _, err := s.getAuthorizedProject(ctx, id, authz.ActionProjectDelete); err != nil {
+		return err
+	}
 
-	if err := s.repos.Project.Delete(ctx, id); err != nil {
+	if err := s.repos.Project.SoftDelete(ctx, id); err != nil {
 		s.logger.Error("failed to delete project", "project_id", id, "error", err)
 		return errors.NewServiceError("DELETE_FAILED", "failed to delete project", err)
 	}
@@ -482,6 +588,9 @@ func (s *projectService) StartProject(ctx context.Context, id uuid.UUID) (*dto.P
 	if id == uuid.Nil {
 		return nil, errors.NewValidationError("project_id is required")
 	}
+	if _, err := s.getAuthorizedProject(ctx, id, authz.ActionProjectUpdate); err != nil {
+		return nil, err
+	}
 
 	if err := s.repos.Project.StartProject(ctx, id); err != nil {
 		s.logger.Error("failed to start project", "project_id", id, "error", err)
@@ -503,6 +612,9 @@ func (s *projectService) PauseProject(ctx context.Context, id uuid.UUID) (*dto.P
 	if id == uuid.Nil {
 		return nil, errors.NewValidationError("project_id is required")
 	}
+	if _, err := s.getAuthorizedProject(ctx, id, authz.ActionProjectUpdate); err != nil {
+		return nil, err
+	}
 
 	if err := s.repos.Project.PauseProject(ctx, id); err != nil {
 		s.logger.Error("failed to pause project", "project_id", id, "error", err)
@@ -524,6 +636,9 @@ func (s *projectService) CompleteProject(ctx context.Context, id uuid.UUID) (*dt
 	if id == uuid.Nil {
 		return nil, errors.NewValidationError("project_id is required")
 	}
+	if _, err := s.getAuthorizedProject(ctx, id, authz.ActionProjectUpdate); err != nil {
+		return nil, err
+	}
 
 	if err := s.repos.Project.CompleteProject(ctx, id); err != nil {
 		s.logger.Error("failed to complete project", "project_id", id, "error", err)
@@ -545,6 +660,9 @@ func (s *projectService) CancelProject(ctx context.Context, id uuid.UUID, reason
 	if id == uuid.Nil {
 		return nil, errors.NewValidationError("project_id is required")
 	}
+	if _, err := s.getAuthorizedProject(ctx, id, authz.ActionProjectUpdate); err != nil {
+		return nil, err
+	}
 
 	if err := s.repos.Project.CancelProject(ctx, id, reason); err != nil {
 		s.logger.Error("failed to cancel project", "project_id", id, "error", err)
@@ -566,6 +684,9 @@ func (s *projectService) ResumeProject(ctx context.Context, id uuid.UUID) (*dto.
 	if id == uuid.Nil {
 		return nil, errors.NewValidationError("project_id is required")
 	}
+	if _, err := s.getAuthorizedProject(ctx, id, authz.ActionProjectUpdate); err != nil {
+		return nil, err
+	}
 
 	if err := s.repos.Project.ResumeProject(ctx, id); err != nil {
 		s.logger.Error("failed to resume project", "project_id", id, "error", err)
@@ -662,6 +783,9 @@ func (s *projectService) GetProjectHealth(ctx context.Context, id uuid.UUID) (*d
 	if id == uuid.Nil {
 		return nil, errors.NewValidationError("project_id is required")
 	}
+	if _, err := s.getAuthorizedProject(ctx, id, authz.ActionProjectRead); err != nil {
+		return nil, err
+	}
 
 	health, err := s.repos.Project.GetProjectHealth(ctx, id)
 	if err != nil {
@@ -672,20 +796,50 @@ func (s *projectService) GetProjectHealth(ctx context.Context, id uuid.UUID) (*d
 	return dto.ToProjectHealthResponse(health), nil
 }
 
-// GetProjectTimeline retrieves project timeline
-func (s *projectService) GetProjectTimeline(ctx context.Context, id uuid.UUID) (*dto.ProjectTimelineResponse, error) {
+// GetProjectSchedule calculates the critical path and earliest finish of a project's
+// remaining work from task estimates, tracked hours and dependencies
+func (s *projectService) GetProjectSchedule(ctx context.Context, id uuid.UUID) (*dto.ProjectScheduleResponse, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("project_id is required")
+	}
+
+	project, err := s.getAuthorizedProject(ctx, id, authz.ActionProjectRead)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.repos.ProjectTask.GetProjectSchedule(ctx, project, time.Now())
+	if err != nil {
+		s.logger.Error("failed to schedule project", "project_id", id, "error", err)
+		return nil, errors.NewServiceError("SCHEDULE_FAILED", "failed to calculate project schedule", err)
+	}
+
+	return dto.ToProjectScheduleResponse(project, schedule), nil
+}
+
+// GetProjectTimeline retrieves a page of the project timeline, most recent first
+func (s *projectService) GetProjectTimeline(ctx context.Context, id uuid.UUID, page, pageSize int) (*dto.ProjectTimelineResponse, error) {
 	if id == uuid.Nil {
 		return nil, errors.NewValidationError("project_id is required")
 	}
+	if _, err := s.getAuthorizedProject(ctx, id, authz.ActionProjectRead); err != nil {
+		return nil, err
+	}
 
-	events, err := s.repos.Project.GetProjectTimeline(ctx, id)
+	events, result, err := s.repos.Project.GetProjectTimeline(ctx, id, repository.PaginationParams{Page: page, PageSize: pageSize})
 	if err != nil {
 		s.logger.Error("failed to get project timeline", "project_id", id, "error", err)
 		return nil, errors.NewServiceError("TIMELINE_FAILED", "failed to get project timeline", err)
 	}
 
 	return &dto.ProjectTimelineResponse{
-		Events: dto.ToTimelineEventResponses(events),
+		Events:      dto.ToTimelineEventResponses(events),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
 	}, nil
 }
 
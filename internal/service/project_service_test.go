@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubProjectRepository returns a single project and empty health, history and timeline data;
+// its other methods aren't used
+type stubProjectRepository struct {
+	repository.ProjectRepository
+	project *models.Project
+}
+
+func (r *stubProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	if id != r.project.ID {
+		return nil, errors.NewNotFoundError("project")
+	}
+	return r.project, nil
+}
+
+func (r *stubProjectRepository) GetProjectHealth(ctx context.Context, projectID uuid.UUID) (repository.ProjectHealth, error) {
+	return repository.ProjectHealth{ProjectID: projectID}, nil
+}
+
+func (r *stubProjectRepository) GetProjectTimeline(ctx context.Context, projectID uuid.UUID, pagination repository.PaginationParams) ([]repository.TimelineEvent, repository.PaginationResult, error) {
+	return nil, repository.PaginationResult{Page: pagination.Page, PageSize: pagination.PageSize}, nil
+}
+
+func (r *stubProjectRepository) GetHealthHistory(ctx context.Context, projectID uuid.UUID, since time.Time) ([]*models.ProjectHealthSnapshot, error) {
+	return nil, nil
+}
+
+// stubCustomerRepository returns a single customer; its other methods aren't used
+type stubCustomerRepository struct {
+	repository.CustomerRepository
+	customer *models.Customer
+}
+
+func (r *stubCustomerRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Customer, error) {
+	if id != r.customer.ID {
+		return nil, errors.NewNotFoundError("customer")
+	}
+	return r.customer, nil
+}
+
+func TestProjectService_ProjectReads(t *testing.T) {
+	tenantID := uuid.New()
+	artisan := &models.Artisan{UserID: uuid.New(), TenantID: tenantID}
+	artisan.ID = uuid.New()
+	customer := &models.Customer{UserID: uuid.New(), TenantID: tenantID}
+	customer.ID = uuid.New()
+	project := &models.Project{TenantID: tenantID, ArtisanID: artisan.ID, CustomerID: &customer.ID}
+	project.ID = uuid.New()
+
+	repos := &repository.Repositories{
+		Project:  &stubProjectRepository{project: project},
+		Artisan:  &stubArtisanRepository{artisan: artisan},
+		Customer: &stubCustomerRepository{customer: customer},
+	}
+	svc := service.NewProjectService(repos, &MockLogger{})
+	healthSvc := service.NewProjectHealthService(repos, &MockLogger{}, nil, nil)
+
+	reads := map[string]func(ctx context.Context) error{
+		"health": func(ctx context.Context) error {
+			_, err := svc.GetProjectHealth(ctx, project.ID)
+			return err
+		},
+		"health history": func(ctx context.Context) error {
+			_, err := healthSvc.GetHealthHistory(ctx, tenantID, project.ID, 30)
+			return err
+		},
+		"timeline": func(ctx context.Context) error {
+			_, err := svc.GetProjectTimeline(ctx, project.ID, 1, 20)
+			return err
+		},
+	}
+
+	tests := []struct {
+		name    string
+		actor   *authz.Actor
+		allowed bool
+	}{
+		{name: "the project's customer", actor: &authz.Actor{UserID: customer.UserID, TenantID: tenantID, Role: models.UserRoleCustomer}, allowed: true},
+		{name: "the project's artisan", actor: &authz.Actor{UserID: artisan.UserID, TenantID: tenantID, Role: models.UserRoleArtisan}, allowed: true},
+		{name: "an owner of the project's tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: tenantID, Role: models.UserRoleTenantOwner}, allowed: true},
+		{name: "another customer of the tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: tenantID, Role: models.UserRoleCustomer}},
+		{name: "a customer of another tenant", actor: &authz.Actor{UserID: uuid.New(), TenantID: uuid.New(), Role: models.UserRoleCustomer}},
+	}
+
+	for read, call := range reads {
+		for _, tt := range tests {
+			t.Run(read+" by "+tt.name, func(t *testing.T) {
+				err := call(authz.WithActor(context.Background(), tt.actor))
+				if !tt.allowed {
+					assert.True(t, errors.IsForbidden(err) || errors.IsNotFoundError(err))
+					return
+				}
+				require.NoError(t, err)
+			})
+		}
+	}
+}
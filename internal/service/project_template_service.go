@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// ProjectTemplateService defines the interface for project templates and creating
+// projects from them
+type ProjectTemplateService interface {
+	CreateTemplate(ctx context.Context, tenantID uuid.UUID, req *dto.CreateProjectTemplateRequest) (*dto.ProjectTemplateResponse, error)
+	GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*dto.ProjectTemplateResponse, error)
+	UpdateTemplate(ctx context.Context, tenantID, id uuid.UUID, req *dto.UpdateProjectTemplateRequest) (*dto.ProjectTemplateResponse, error)
+	DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error
+	ListTemplates(ctx context.Context, filter dto.ProjectTemplateFilter) (*dto.ProjectTemplateListResponse, error)
+
+	// CreateProjectFromTemplate creates a project with the template's milestones, tasks
+	// and task dependencies, dated from the requested start date
+	CreateProjectFromTemplate(ctx context.Context, tenantID, id uuid.UUID, req *dto.CreateProjectFromTemplateRequest) (*dto.TemplateProjectResponse, error)
+}
+
+// projectTemplateService implements ProjectTemplateService
+type projectTemplateService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewProjectTemplateService creates a new ProjectTemplateService instance
+func NewProjectTemplateService(repos *repository.Repositories, logger log.AllLogger) ProjectTemplateService {
+	return &projectTemplateService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// CreateTemplate creates a project template for the tenant
+func (s *projectTemplateService) CreateTemplate(ctx context.Context, tenantID uuid.UUID, req *dto.CreateProjectTemplateRequest) (*dto.ProjectTemplateResponse, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	priority := req.Priority
+	if priority == "" {
+		priority = models.ProjectPriorityMedium
+	}
+
+	template := &models.ProjectTemplate{
+		TenantID:            tenantID,
+		Name:                strings.TrimSpace(req.Name),
+		Description:         req.Description,
+		Category:            strings.TrimSpace(req.Category),
+		IsActive:            true,
+		DefaultDurationDays: req.DefaultDurationDays,
+		DefaultBudget:       req.DefaultBudget,
+		Currency:            strings.ToUpper(currency),
+		HourlyRate:          req.HourlyRate,
+		Priority:            priority,
+		Tags:                req.Tags,
+		Milestones:          append(models.TemplateMilestones(nil), req.Milestones...),
+		Tasks:               append(models.TemplateTasks(nil), req.Tasks...),
+	}
+	if err := template.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.ProjectTemplate.Create(ctx, template); err != nil {
+		s.logger.Error("failed to create project template", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewServiceError("PROJECT_TEMPLATE_CREATE_FAILED", "failed to create project template", err)
+	}
+
+	s.logger.Info("project template created", "template_id", template.ID, "tenant_id", tenantID)
+	return dto.ToProjectTemplateResponse(template), nil
+}
+
+// GetTemplate retrieves a project template by ID
+func (s *projectTemplateService) GetTemplate(ctx context.Context, tenantID, id uuid.UUID) (*dto.ProjectTemplateResponse, error) {
+	template, err := s.getTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToProjectTemplateResponse(template), nil
+}
+
+// UpdateTemplate edits a project template. Projects already created from it are
+// not affected.
+func (s *projectTemplateService) UpdateTemplate(ctx context.Context, tenantID, id uuid.UUID, req *dto.UpdateProjectTemplateRequest) (*dto.ProjectTemplateResponse, error) {
+	template, err := s.getTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		template.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if req.Category != nil {
+		template.Category = strings.TrimSpace(*req.Category)
+	}
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+	if req.DefaultDurationDays != nil {
+		template.DefaultDurationDays = *req.DefaultDurationDays
+	}
+	if req.DefaultBudget != nil {
+		template.DefaultBudget = *req.DefaultBudget
+	}
+	if req.Currency != nil {
+		template.Currency = strings.ToUpper(*req.Currency)
+	}
+	if req.HourlyRate != nil {
+		template.HourlyRate = *req.HourlyRate
+	}
+	if req.Priority != nil {
+		template.Priority = *req.Priority
+	}
+	if req.Tags != nil {
+		template.Tags = req.Tags
+	}
+	if req.Milestones != nil {
+		template.Milestones = append(models.TemplateMilestones(nil), req.Milestones...)
+	}
+	if req.Tasks != nil {
+		template.Tasks = append(models.TemplateTasks(nil), req.Tasks...)
+	}
+	if err := template.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.ProjectTemplate.Update(ctx, template); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("project template was modified by another request")
+		}
+		s.logger.Error("failed to update project template", "template_id", id, "error", err)
+		return nil, errors.NewServiceError("PROJECT_TEMPLATE_UPDATE_FAILED", "failed to update project template", err)
+	}
+
+	return dto.ToProjectTemplateResponse(template), nil
+}
+
+// DeleteTemplate deletes a project template. Projects created from it are kept.
+func (s *projectTemplateService) DeleteTemplate(ctx context.Context, tenantID, id uuid.UUID) error {
+	if _, err := s.getTemplate(ctx, tenantID, id); err != nil {
+		return err
+	}
+
+	if err := s.repos.ProjectTemplate.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete project template", "template_id", id, "error", err)
+		return errors.NewServiceError("PROJECT_TEMPLATE_DELETE_FAILED", "failed to delete project template", err)
+	}
+	return nil
+}
+
+// ListTemplates lists a tenant's project templates matching the filter
+func (s *projectTemplateService) ListTemplates(ctx context.Context, filter dto.ProjectTemplateFilter) (*dto.ProjectTemplateListResponse, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant_id is required")
+	}
+
+	templates, result, err := s.repos.ProjectTemplate.List(ctx, repository.ProjectTemplateFilter{
+		TenantID: filter.TenantID,
+		Category: filter.Category,
+		IsActive: filter.IsActive,
+		Search:   strings.TrimSpace(filter.Search),
+	}, repository.PaginationParams{Page: filter.Page, PageSize: filter.PageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("PROJECT_TEMPLATE_LIST_FAILED", "failed to list project templates", err)
+	}
+
+	return &dto.ProjectTemplateListResponse{
+		Templates:   dto.ToProjectTemplateResponses(templates),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// CreateProjectFromTemplate instantiates an active template for an artisan and
+// optional customer of the tenant
+func (s *projectTemplateService) CreateProjectFromTemplate(ctx context.Context, tenantID, id uuid.UUID, req *dto.CreateProjectFromTemplateRequest) (*dto.TemplateProjectResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	template, err := s.getTemplate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !template.IsActive {
+		return nil, errors.NewValidationError("project template is inactive")
+	}
+	// Templates are validated on save; re-check in case the rules tightened since
+	if err := template.Validate(); err != nil {
+		return nil, errors.NewValidationError("project template is invalid: " + err.Error())
+	}
+
+	artisan, err := s.repos.Artisan.GetByID(ctx, req.ArtisanID)
+	if err != nil || artisan.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("artisan")
+	}
+	if req.CustomerID != nil {
+		customer, err := s.repos.Customer.GetByID(ctx, *req.CustomerID)
+		if err != nil || customer.TenantID != tenantID {
+			return nil, errors.NewNotFoundError("customer")
+		}
+	}
+
+	instance := template.Instantiate(artisan.ID, req.CustomerID, req.StartDate, req.BudgetAmount)
+	if title := strings.TrimSpace(req.Title); title != "" {
+		instance.Project.Title = title
+	}
+	if req.Description != "" {
+		instance.Project.Description = req.Description
+	}
+
+	if err := s.repos.ProjectTemplate.CreateProject(ctx, template, instance); err != nil {
+		s.logger.Error("failed to create project from template", "template_id", id, "error", err)
+		return nil, errors.NewServiceError("PROJECT_TEMPLATE_INSTANTIATE_FAILED", "failed to create project from template", err)
+	}
+
+	s.logger.Info("project created from template",
+		"project_id", instance.Project.ID,
+		"template_id", template.ID,
+		"milestones", len(instance.Milestones),
+		"tasks", len(instance.Tasks),
+	)
+	return dto.ToTemplateProjectResponse(instance), nil
+}
+
+// getTemplate loads a project template of the tenant
+func (s *projectTemplateService) getTemplate(ctx context.Context, tenantID, id uuid.UUID) (*models.ProjectTemplate, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("template_id is required")
+	}
+	template, err := s.repos.ProjectTemplate.GetByID(ctx, id)
+	if err != nil || template.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("project template")
+	}
+	return template, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	stdErrors "errors"
 	"fmt"
 	"maps"
 	"strings"
@@ -38,6 +39,10 @@ type PromoCodeService interface {
 	IncrementUsage(ctx context.Context, promoCodeID uuid.UUID) error
 	CanUserUsePromoCode(ctx context.Context, promoCodeID, userID uuid.UUID) (bool, error)
 
+	// Booking Discounts
+	RedeemForBooking(ctx context.Context, booking *models.Booking, code string) error
+	ReleaseForBooking(ctx context.Context, bookingID uuid.UUID) error
+
 	// Status Operations
 	ActivatePromoCode(ctx context.Context, id uuid.UUID) error
 	DeactivatePromoCode(ctx context.Context, id uuid.UUID) error
@@ -485,6 +490,73 @@ func (s *promoCodeService) CanUserUsePromoCode(ctx context.Context, promoCodeID,
 	return canUse, nil
 }
 
+// RedeemForBooking discounts the booking's base and addons price with the code and
+// records the use against the booking's customer. The booking must have its ID and
+// prices set; the caller reapplies tax and should ReleaseForBooking if the booking is
+// not saved.
+func (s *promoCodeService) RedeemForBooking(ctx context.Context, booking *models.Booking, code string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return errors.NewValidationError("Promo code cannot be empty")
+	}
+
+	amount := booking.BasePrice + booking.AddonsPrice
+	promoCode, discount, err := s.repos.PromoCode.ValidateCode(ctx, code, &booking.TenantID, amount, &booking.ServiceID, &booking.ArtisanID)
+	if err != nil {
+		if message, ok := promoRejection(err); ok {
+			return errors.NewValidationError(message)
+		}
+		return errors.NewServiceError("VALIDATION_FAILED", "Failed to validate promo code", err)
+	}
+	if promoCode.TenantID != nil && *promoCode.TenantID != booking.TenantID {
+		return errors.NewValidationError("promo code not applicable for this tenant")
+	}
+	if discount <= 0 {
+		return errors.NewValidationError("promo code gives no discount on this booking")
+	}
+
+	redemption := &models.PromoCodeRedemption{
+		PromoCodeID:    promoCode.ID,
+		TenantID:       booking.TenantID,
+		UserID:         booking.CustomerID,
+		BookingID:      booking.ID,
+		OrderAmount:    amount,
+		DiscountAmount: discount,
+	}
+	if err := s.repos.PromoCode.Redeem(ctx, redemption); err != nil {
+		if message, ok := promoRejection(err); ok {
+			return errors.NewValidationError(message)
+		}
+		s.logger.Error("failed to redeem promo code", "promo_code_id", promoCode.ID, "booking_id", booking.ID, "error", err)
+		return errors.NewServiceError("REDEEM_FAILED", "Failed to redeem promo code", err)
+	}
+
+	booking.ApplyDiscount(promoCode, discount)
+	s.logger.Info("promo code redeemed", "promo_code_id", promoCode.ID, "booking_id", booking.ID, "discount", discount)
+	return nil
+}
+
+// ReleaseForBooking gives back the promo code use recorded for a booking
+func (s *promoCodeService) ReleaseForBooking(ctx context.Context, bookingID uuid.UUID) error {
+	if err := s.repos.PromoCode.ReleaseRedemption(ctx, bookingID); err != nil {
+		s.logger.Error("failed to release promo code redemption", "booking_id", bookingID, "error", err)
+		return errors.NewServiceError("RELEASE_FAILED", "Failed to release promo code", err)
+	}
+	return nil
+}
+
+// promoRejection returns the customer-facing reason a promo code was refused
+func promoRejection(err error) (string, bool) {
+	if errors.IsNotFound(err) {
+		return "promo code not found", true
+	}
+	var repoErr *errors.RepositoryError
+	if stdErrors.As(err, &repoErr) && repoErr.Code == "INVALID_PROMO" {
+		return repoErr.Message, true
+	}
+	return "", false
+}
+
 // ActivatePromoCode activates a promo code
 func (s *promoCodeService) ActivatePromoCode(ctx context.Context, id uuid.UUID) error {
 	s.logger.Info("activating promo code", "promo_code_id", id)
@@ -0,0 +1,442 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// quoteDepositPaymentTerms is the due date of the deposit invoice raised when a quote
+// is accepted
+const quoteDepositPaymentTerms = 7 * 24 * time.Hour
+
+// QuoteService defines the interface for quotes and their conversion into projects
+type QuoteService interface {
+	// Staff
+	CreateQuote(ctx context.Context, tenantID uuid.UUID, req *dto.CreateQuoteRequest) (*dto.QuoteResponse, error)
+	GetQuote(ctx context.Context, tenantID, id uuid.UUID) (*dto.QuoteResponse, error)
+	UpdateQuote(ctx context.Context, tenantID, id uuid.UUID, req *dto.UpdateQuoteRequest) (*dto.QuoteResponse, error)
+	DeleteQuote(ctx context.Context, tenantID, id uuid.UUID) error
+	ListQuotes(ctx context.Context, filter dto.QuoteFilter) (*dto.QuoteListResponse, error)
+	SendQuote(ctx context.Context, tenantID, id uuid.UUID) (*dto.QuoteResponse, error)
+	ListRevisions(ctx context.Context, tenantID, id uuid.UUID) ([]*dto.QuoteRevisionResponse, error)
+
+	// Customers
+	ListCustomerQuotes(ctx context.Context, tenantID, userID uuid.UUID, page, pageSize int) (*dto.QuoteListResponse, error)
+	GetCustomerQuote(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.QuoteResponse, error)
+	AcceptQuote(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.AcceptQuoteRequest) (*dto.QuoteAcceptanceResponse, error)
+	DeclineQuote(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.DeclineQuoteRequest) (*dto.QuoteResponse, error)
+
+	// Jobs
+	ExpireQuotes(ctx context.Context) (int64, error)
+}
+
+// quoteService implements QuoteService
+type quoteService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewQuoteService creates a new QuoteService instance
+func NewQuoteService(repos *repository.Repositories, logger log.AllLogger) QuoteService {
+	return &quoteService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ============================================================================
+// Staff
+// ============================================================================
+
+// CreateQuote drafts a quote from an artisan to a customer of the tenant
+func (s *quoteService) CreateQuote(ctx context.Context, tenantID uuid.UUID, req *dto.CreateQuoteRequest) (*dto.QuoteResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	artisan, err := s.repos.Artisan.GetByID(ctx, req.ArtisanID)
+	if err != nil || artisan.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("artisan")
+	}
+	customer, err := s.repos.Customer.GetByID(ctx, req.CustomerID)
+	if err != nil || customer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("customer")
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	quote := &models.Quote{
+		TenantID:           tenantID,
+		ArtisanID:          artisan.ID,
+		CustomerID:         customer.ID,
+		Title:              strings.TrimSpace(req.Title),
+		Description:        req.Description,
+		Status:             models.QuoteStatusDraft,
+		Revision:           1,
+		LineItems:          append(models.InvoiceLineItems(nil), req.LineItems...),
+		DiscountAmount:     req.DiscountAmount,
+		TaxAmount:          req.TaxAmount,
+		Currency:           strings.ToUpper(currency),
+		DepositPercent:     req.DepositPercent,
+		ValidUntil:         req.ValidUntil,
+		EstimatedStartDate: req.EstimatedStartDate,
+		EstimatedDueDate:   req.EstimatedDueDate,
+		Notes:              req.Notes,
+		TermsConditions:    req.TermsConditions,
+	}
+	quote.Recalculate()
+	if err := quote.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.Quote.Create(ctx, quote); err != nil {
+		s.logger.Error("failed to create quote", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewServiceError("QUOTE_CREATE_FAILED", "failed to create quote", err)
+	}
+
+	s.logger.Info("quote created", "quote_id", quote.ID, "tenant_id", tenantID, "customer_id", customer.ID)
+	return dto.ToQuoteResponse(quote), nil
+}
+
+// GetQuote retrieves a quote by ID
+func (s *quoteService) GetQuote(ctx context.Context, tenantID, id uuid.UUID) (*dto.QuoteResponse, error) {
+	quote, err := s.getQuote(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToQuoteResponse(quote), nil
+}
+
+// UpdateQuote edits a quote. A quote the customer has already seen becomes a new
+// draft revision that has to be sent again.
+func (s *quoteService) UpdateQuote(ctx context.Context, tenantID, id uuid.UUID, req *dto.UpdateQuoteRequest) (*dto.QuoteResponse, error) {
+	quote, err := s.getQuote(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := quote.Revise(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if req.Title != nil {
+		quote.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		quote.Description = *req.Description
+	}
+	if req.LineItems != nil {
+		quote.LineItems = append(models.InvoiceLineItems(nil), req.LineItems...)
+	}
+	if req.DiscountAmount != nil {
+		quote.DiscountAmount = *req.DiscountAmount
+	}
+	if req.TaxAmount != nil {
+		quote.TaxAmount = *req.TaxAmount
+	}
+	if req.Currency != nil {
+		quote.Currency = strings.ToUpper(*req.Currency)
+	}
+	if req.DepositPercent != nil {
+		quote.DepositPercent = *req.DepositPercent
+	}
+	if req.ValidUntil != nil {
+		quote.ValidUntil = *req.ValidUntil
+	}
+	if req.EstimatedStartDate != nil {
+		quote.EstimatedStartDate = req.EstimatedStartDate
+	}
+	if req.EstimatedDueDate != nil {
+		quote.EstimatedDueDate = req.EstimatedDueDate
+	}
+	if req.Notes != nil {
+		quote.Notes = *req.Notes
+	}
+	if req.TermsConditions != nil {
+		quote.TermsConditions = *req.TermsConditions
+	}
+	quote.Recalculate()
+	if err := quote.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.Quote.Update(ctx, quote); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("quote was modified by another request")
+		}
+		s.logger.Error("failed to update quote", "quote_id", id, "error", err)
+		return nil, errors.NewServiceError("QUOTE_UPDATE_FAILED", "failed to update quote", err)
+	}
+
+	return dto.ToQuoteResponse(quote), nil
+}
+
+// DeleteQuote deletes a quote that was never sent
+func (s *quoteService) DeleteQuote(ctx context.Context, tenantID, id uuid.UUID) error {
+	quote, err := s.getQuote(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if quote.Status != models.QuoteStatusDraft || quote.Revision > 1 {
+		return errors.NewValidationError("only quotes that were never sent can be deleted")
+	}
+
+	if err := s.repos.Quote.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete quote", "quote_id", id, "error", err)
+		return errors.NewServiceError("QUOTE_DELETE_FAILED", "failed to delete quote", err)
+	}
+	return nil
+}
+
+// ListQuotes lists a tenant's quotes matching the filter
+func (s *quoteService) ListQuotes(ctx context.Context, filter dto.QuoteFilter) (*dto.QuoteListResponse, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant_id is required")
+	}
+
+	return s.listQuotes(ctx, repository.QuoteFilter{
+		TenantID:   filter.TenantID,
+		ArtisanID:  filter.ArtisanID,
+		CustomerID: filter.CustomerID,
+		Statuses:   filter.Statuses,
+	}, filter.Page, filter.PageSize)
+}
+
+// SendQuote sends a draft quote to the customer and records it as a revision
+func (s *quoteService) SendQuote(ctx context.Context, tenantID, id uuid.UUID) (*dto.QuoteResponse, error) {
+	quote, err := s.getQuote(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := quote.Send(time.Now())
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.Quote.Send(ctx, quote, revision); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("quote was modified by another request")
+		}
+		s.logger.Error("failed to send quote", "quote_id", id, "error", err)
+		return nil, errors.NewServiceError("QUOTE_SEND_FAILED", "failed to send quote", err)
+	}
+
+	s.logger.Info("quote sent", "quote_id", quote.ID, "revision", quote.Revision)
+	return dto.ToQuoteResponse(quote), nil
+}
+
+// ListRevisions returns the revisions of a quote that were sent to the customer
+func (s *quoteService) ListRevisions(ctx context.Context, tenantID, id uuid.UUID) ([]*dto.QuoteRevisionResponse, error) {
+	quote, err := s.getQuote(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repos.Quote.FindRevisions(ctx, quote.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("QUOTE_REVISIONS_FAILED", "failed to list quote revisions", err)
+	}
+	return dto.ToQuoteRevisionResponses(revisions), nil
+}
+
+// ============================================================================
+// Customers
+// ============================================================================
+
+// ListCustomerQuotes lists the quotes sent to the customer
+func (s *quoteService) ListCustomerQuotes(ctx context.Context, tenantID, userID uuid.UUID, page, pageSize int) (*dto.QuoteListResponse, error) {
+	customer, err := s.getCustomer(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listQuotes(ctx, repository.QuoteFilter{
+		TenantID:   tenantID,
+		CustomerID: &customer.ID,
+		Statuses: []models.QuoteStatus{
+			models.QuoteStatusSent,
+			models.QuoteStatusAccepted,
+			models.QuoteStatusDeclined,
+			models.QuoteStatusExpired,
+		},
+	}, page, pageSize)
+}
+
+// GetCustomerQuote retrieves a quote sent to the customer
+func (s *quoteService) GetCustomerQuote(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.QuoteResponse, error) {
+	quote, _, err := s.getCustomerQuote(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToQuoteResponse(quote), nil
+}
+
+// AcceptQuote records the customer's acceptance and converts the quote into a project,
+// with a draft deposit invoice when the quote asks for one
+func (s *quoteService) AcceptQuote(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.AcceptQuoteRequest) (*dto.QuoteAcceptanceResponse, error) {
+	quote, customer, err := s.getCustomerQuote(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := quote.Accept(now, req.Revision); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	project, tasks := quote.ToProject()
+
+	var deposit *models.Invoice
+	if amount := quote.DepositAmount(); amount > 0 {
+		deposit = &models.Invoice{
+			TenantID:       tenantID,
+			CustomerID:     customer.UserID,
+			IssueDate:      now,
+			DueDate:        now.Add(quoteDepositPaymentTerms),
+			SubtotalAmount: amount,
+			TotalAmount:    amount,
+			Currency:       quote.Currency,
+			Status:         models.InvoiceStatusDraft,
+			LineItems: models.InvoiceLineItems{{
+				Description: fmt.Sprintf("Deposit (%g%%) for quote: %s", quote.DepositPercent, quote.Title),
+				Quantity:    1,
+				UnitPrice:   amount,
+				TotalPrice:  amount,
+			}},
+			Metadata: models.JSONB{
+				"source":         "quote_deposit",
+				"quote_id":       quote.ID.String(),
+				"quote_revision": quote.Revision,
+			},
+		}
+	}
+
+	if err := s.repos.Quote.Convert(ctx, quote, project, tasks, deposit); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("quote was changed or answered by another request")
+		}
+		s.logger.Error("failed to convert accepted quote", "quote_id", id, "error", err)
+		return nil, errors.NewServiceError("QUOTE_ACCEPT_FAILED", "failed to accept quote", err)
+	}
+
+	s.logger.Info("quote accepted", "quote_id", quote.ID, "revision", quote.Revision, "project_id", project.ID)
+	resp := &dto.QuoteAcceptanceResponse{
+		Quote:   dto.ToQuoteResponse(quote),
+		Project: dto.ToProjectResponse(project),
+	}
+	if deposit != nil {
+		resp.DepositInvoice = dto.ToInvoiceResponse(deposit)
+	}
+	return resp, nil
+}
+
+// DeclineQuote records the customer's refusal of a quote
+func (s *quoteService) DeclineQuote(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.DeclineQuoteRequest) (*dto.QuoteResponse, error) {
+	quote, _, err := s.getCustomerQuote(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := quote.Decline(time.Now(), req.Revision, strings.TrimSpace(req.Reason)); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.Quote.Update(ctx, quote); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("quote was changed or answered by another request")
+		}
+		s.logger.Error("failed to decline quote", "quote_id", id, "error", err)
+		return nil, errors.NewServiceError("QUOTE_DECLINE_FAILED", "failed to decline quote", err)
+	}
+
+	s.logger.Info("quote declined", "quote_id", quote.ID, "revision", quote.Revision)
+	return dto.ToQuoteResponse(quote), nil
+}
+
+// ============================================================================
+// Jobs
+// ============================================================================
+
+// ExpireQuotes expires sent quotes that were not answered before their validity ended
+func (s *quoteService) ExpireQuotes(ctx context.Context) (int64, error) {
+	expired, err := s.repos.Quote.ExpireSent(ctx, time.Now())
+	if err != nil {
+		return 0, errors.NewServiceError("QUOTE_EXPIRY_FAILED", "failed to expire quotes", err)
+	}
+	if expired > 0 {
+		s.logger.Info("quotes expired", "count", expired)
+	}
+	return expired, nil
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// getQuote loads a quote of the tenant
+func (s *quoteService) getQuote(ctx context.Context, tenantID, id uuid.UUID) (*models.Quote, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("quote_id is required")
+	}
+	quote, err := s.repos.Quote.GetByID(ctx, id)
+	if err != nil || quote.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("quote")
+	}
+	return quote, nil
+}
+
+// getCustomer loads the customer profile of the user within the tenant
+func (s *quoteService) getCustomer(ctx context.Context, tenantID, userID uuid.UUID) (*models.Customer, error) {
+	customer, err := s.repos.Customer.GetByUserID(ctx, userID)
+	if err != nil || customer.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("customer")
+	}
+	return customer, nil
+}
+
+// getCustomerQuote loads a quote that was sent to the user. Drafts and other
+// customers' quotes are reported as not found.
+func (s *quoteService) getCustomerQuote(ctx context.Context, tenantID, userID, id uuid.UUID) (*models.Quote, *models.Customer, error) {
+	customer, err := s.getCustomer(ctx, tenantID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	quote, err := s.getQuote(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if quote.CustomerID != customer.ID || quote.Status == models.QuoteStatusDraft {
+		return nil, nil, errors.NewNotFoundError("quote")
+	}
+	return quote, customer, nil
+}
+
+// listQuotes runs a quote listing and wraps it in the paginated response
+func (s *quoteService) listQuotes(ctx context.Context, filter repository.QuoteFilter, page, pageSize int) (*dto.QuoteListResponse, error) {
+	quotes, result, err := s.repos.Quote.List(ctx, filter, repository.PaginationParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("QUOTE_LIST_FAILED", "failed to list quotes", err)
+	}
+
+	return &dto.QuoteListResponse{
+		Quotes:      dto.ToQuoteResponses(quotes),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
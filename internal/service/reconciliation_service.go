@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/payments"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// ReconciliationService matches recorded payments against the balance transactions of
+// the payment providers and keeps a daily report of what did not line up
+type ReconciliationService interface {
+	// Reconcile runs (or re-runs) reconciliation for a UTC day with every provider that
+	// can list its balance
+	Reconcile(ctx context.Context, date time.Time) (*dto.ReconciliationDayResponse, error)
+
+	// ReconcilePrevious reconciles yesterday unless every provider already has a completed report
+	ReconcilePrevious(ctx context.Context) (bool, error)
+
+	// GetReconciliation returns the stored reports for a UTC day
+	GetReconciliation(ctx context.Context, date time.Time) (*dto.ReconciliationDayResponse, error)
+}
+
+// reconciliationService implements ReconciliationService
+type reconciliationService struct {
+	repos     *repository.Repositories
+	logger    log.AllLogger
+	providers *payments.Registry
+}
+
+// NewReconciliationService creates a new ReconciliationService instance; with no
+// providers configured there is nothing to reconcile against
+func NewReconciliationService(repos *repository.Repositories, logger log.AllLogger, providers *payments.Registry) ReconciliationService {
+	return &reconciliationService{
+		repos:     repos,
+		logger:    logger,
+		providers: providers,
+	}
+}
+
+// Reconcile matches the day's provider charges to payments by provider reference.
+// Matched payments are marked reconciled; everything else becomes a discrepancy on the
+// provider's report. A provider that cannot be read gets a failed report instead.
+func (s *reconciliationService) Reconcile(ctx context.Context, date time.Time) (*dto.ReconciliationDayResponse, error) {
+	day := reconciliationDay(date)
+	if !day.Before(reconciliationDay(time.Now())) {
+		return nil, errors.NewValidationError("only past days can be reconciled")
+	}
+
+	listers := s.balanceListers()
+	reports := make([]*models.ReconciliationReport, 0, len(listers))
+	for _, name := range slices.Sorted(maps.Keys(listers)) {
+		report := s.reconcileProvider(ctx, name, listers[name], day)
+		if err := s.repos.Reconciliation.Save(ctx, report); err != nil {
+			return nil, errors.NewServiceError("RECONCILIATION_SAVE_FAILED", "failed to save reconciliation report", err)
+		}
+		reports = append(reports, report)
+
+		s.logger.Info("payments reconciled", "date", day.Format(time.DateOnly), "provider", name,
+			"status", report.Status, "matched", report.MatchedCount, "discrepancies", report.DiscrepancyCount)
+	}
+
+	return dto.ToReconciliationDayResponse(day, reports), nil
+}
+
+// reconcileProvider builds one provider's report for day
+func (s *reconciliationService) reconcileProvider(ctx context.Context, name string, lister payments.BalanceLister, day time.Time) *models.ReconciliationReport {
+	report := &models.ReconciliationReport{
+		Date:     day,
+		Provider: name,
+		Status:   models.ReconciliationCompleted,
+		RunAt:    time.Now(),
+	}
+	next := day.AddDate(0, 0, 1)
+
+	transactions, err := lister.ListBalanceTransactions(ctx, day, next)
+	if err != nil {
+		report.Fail(err)
+		return report
+	}
+
+	var charges []payments.BalanceTransaction
+	var refs []string
+	for _, txn := range transactions {
+		if txn.Type != payments.BalanceCharge {
+			continue
+		}
+		charges = append(charges, txn)
+		if txn.ProviderPaymentID != "" {
+			refs = append(refs, txn.ProviderPaymentID)
+		}
+	}
+
+	known, err := s.repos.Payment.FindByProviderPaymentIDs(ctx, name, refs)
+	if err != nil {
+		report.Fail(err)
+		return report
+	}
+	settled, err := s.repos.Payment.FindSettledByProvider(ctx, name, day, next)
+	if err != nil {
+		report.Fail(err)
+		return report
+	}
+
+	byRef := make(map[string]*models.Payment, len(known))
+	for _, payment := range known {
+		byRef[payment.ProviderPaymentID] = payment
+	}
+
+	seen := make(map[uuid.UUID]bool, len(charges))
+	var matched []uuid.UUID
+	for _, txn := range charges {
+		report.ProviderTransactions++
+		payment := byRef[txn.ProviderPaymentID]
+		if payment == nil {
+			report.AddDiscrepancy(models.ReconciliationDiscrepancy{
+				Kind:                  models.DiscrepancyMissingLocally,
+				ProviderTransactionID: txn.ID,
+				ProviderPaymentID:     txn.ProviderPaymentID,
+				ProviderAmount:        txn.Amount,
+				Currency:              txn.Currency,
+			})
+			continue
+		}
+		seen[payment.ID] = true
+
+		discrepancy := discrepancyFor(payment, txn)
+		switch {
+		case !isSettledPayment(payment):
+			discrepancy.Kind = models.DiscrepancyStatusMismatch
+			report.AddDiscrepancy(discrepancy)
+		case math.Abs(payment.Amount-txn.Amount) >= 0.005 || !currencyMatches(payment.Currency, txn.Currency):
+			discrepancy.Kind = models.DiscrepancyAmountMismatch
+			report.AddDiscrepancy(discrepancy)
+		default:
+			matched = append(matched, payment.ID)
+		}
+	}
+
+	for _, payment := range settled {
+		report.LocalPayments++
+		if !seen[payment.ID] {
+			discrepancy := discrepancyFor(payment, payments.BalanceTransaction{})
+			discrepancy.Kind = models.DiscrepancyMissingAtProvider
+			report.AddDiscrepancy(discrepancy)
+		}
+	}
+
+	if err := s.repos.Payment.MarkAsReconciled(ctx, matched); err != nil {
+		report.Fail(err)
+		return report
+	}
+	report.MatchedCount = len(matched)
+	return report
+}
+
+// ReconcilePrevious is run by the nightly job; it is a no-op once yesterday is done, so
+// the job can run often and still retry providers that failed
+func (s *reconciliationService) ReconcilePrevious(ctx context.Context) (bool, error) {
+	yesterday := reconciliationDay(time.Now()).AddDate(0, 0, -1)
+
+	listers := s.balanceListers()
+	if len(listers) == 0 {
+		return false, nil
+	}
+
+	reports, err := s.repos.Reconciliation.ListByDate(ctx, yesterday)
+	if err != nil {
+		return false, errors.NewServiceError("RECONCILIATION_GET_FAILED", "failed to get reconciliation reports", err)
+	}
+	completed := 0
+	for _, report := range reports {
+		if _, ok := listers[report.Provider]; ok && report.Status == models.ReconciliationCompleted {
+			completed++
+		}
+	}
+	if completed == len(listers) {
+		return false, nil
+	}
+
+	if _, err := s.Reconcile(ctx, yesterday); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetReconciliation returns a day's stored reports
+func (s *reconciliationService) GetReconciliation(ctx context.Context, date time.Time) (*dto.ReconciliationDayResponse, error) {
+	day := reconciliationDay(date)
+	reports, err := s.repos.Reconciliation.ListByDate(ctx, day)
+	if err != nil {
+		return nil, errors.NewServiceError("RECONCILIATION_GET_FAILED", "failed to get reconciliation reports", err)
+	}
+	if len(reports) == 0 {
+		return nil, errors.NewNotFoundError("reconciliation report")
+	}
+	return dto.ToReconciliationDayResponse(day, reports), nil
+}
+
+// balanceListers returns the configured providers that can list their balance
+func (s *reconciliationService) balanceListers() map[string]payments.BalanceLister {
+	listers := map[string]payments.BalanceLister{}
+	if s.providers == nil {
+		return listers
+	}
+	for _, name := range s.providers.Names() {
+		provider, err := s.providers.Get(name)
+		if err != nil {
+			continue
+		}
+		if lister, ok := provider.(payments.BalanceLister); ok {
+			listers[name] = lister
+		}
+	}
+	return listers
+}
+
+// reconciliationDay truncates t to the start of its UTC day
+func reconciliationDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// isSettledPayment reports whether we recorded the payment as collected
+func isSettledPayment(payment *models.Payment) bool {
+	switch payment.Status {
+	case models.PaymentStatusPaid, models.PaymentStatusPartialRefund, models.PaymentStatusRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+func currencyMatches(local, provider string) bool {
+	return local == "" || provider == "" || strings.EqualFold(local, provider)
+}
+
+// discrepancyFor describes both sides of a payment for a discrepancy line
+func discrepancyFor(payment *models.Payment, txn payments.BalanceTransaction) models.ReconciliationDiscrepancy {
+	tenantID, paymentID := payment.TenantID, payment.ID
+	currency := payment.Currency
+	if currency == "" {
+		currency = txn.Currency
+	}
+	return models.ReconciliationDiscrepancy{
+		TenantID:              &tenantID,
+		PaymentID:             &paymentID,
+		ProviderTransactionID: txn.ID,
+		ProviderPaymentID:     payment.ProviderPaymentID,
+		LocalAmount:           payment.Amount,
+		LocalStatus:           payment.Status,
+		ProviderAmount:        txn.Amount,
+		Currency:              strings.ToUpper(currency),
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"maps"
+	"time"
 
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
@@ -13,6 +14,14 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	// reviewRequestBatchSize caps the review requests sent per tenant per run
+	reviewRequestBatchSize = 200
+	// reviewRequestWindow is how recently a booking must have completed for its customer
+	// to be asked for a review, so turning requests on doesn't ask every past customer
+	reviewRequestWindow = 30 * 24 * time.Hour
+)
+
 // ReviewService defines the interface for review service operations
 type ReviewService interface {
 	// CRUD Operations
@@ -27,16 +36,20 @@ type ReviewService interface {
 	GetArtisanReviews(ctx context.Context, artisanID uuid.UUID) ([]*dto.ReviewDetailResponse, error)
 	GetCustomerReviews(ctx context.Context, customerID uuid.UUID) ([]*dto.ReviewDetailResponse, error)
 
-	// Review Management
+	// Response Threads
 	RespondToReview(ctx context.Context, reviewID uuid.UUID, artisanID uuid.UUID, req *dto.RespondToReviewRequest) (*dto.ReviewDetailResponse, error)
-	PublishReview(ctx context.Context, reviewID uuid.UUID, moderatorID uuid.UUID) error
-	UnpublishReview(ctx context.Context, reviewID uuid.UUID, moderatorID uuid.UUID) error
-	FlagReview(ctx context.Context, reviewID uuid.UUID, reason string, moderatorID uuid.UUID) error
-	UnflagReview(ctx context.Context, reviewID uuid.UUID, moderatorID uuid.UUID) error
+	ReplyToReview(ctx context.Context, reviewID uuid.UUID, authorID uuid.UUID, req *dto.CreateReviewReplyRequest) (*dto.ReviewReplyResponse, error)
+	GetReviewReplies(ctx context.Context, reviewID uuid.UUID, userID uuid.UUID) ([]*dto.ReviewReplyResponse, error)
 
 	// Moderation
 	GetPendingModeration(ctx context.Context, tenantID uuid.UUID) ([]*dto.ReviewDetailResponse, error)
-	GetFlaggedReviews(ctx context.Context, tenantID uuid.UUID) ([]*dto.ReviewDetailResponse, error)
+	PublishReview(ctx context.Context, tenantID, reviewID, moderatorID uuid.UUID) (*dto.ReviewDetailResponse, error)
+	RejectReview(ctx context.Context, tenantID, reviewID, moderatorID uuid.UUID, req *dto.RejectReviewRequest) (*dto.ReviewDetailResponse, error)
+
+	// Flags & Disputes
+	FlagReview(ctx context.Context, tenantID, reviewID, reporterID uuid.UUID, req *dto.FlagReviewRequest) (*dto.ReviewFlagResponse, error)
+	ListFlags(ctx context.Context, tenantID uuid.UUID, status models.ReviewFlagStatus, page, pageSize int) (*dto.ReviewFlagListResponse, error)
+	ResolveFlag(ctx context.Context, tenantID, flagID, moderatorID uuid.UUID, req *dto.ResolveReviewFlagRequest) (*dto.ReviewFlagResponse, error)
 
 	// Voting
 	MarkHelpful(ctx context.Context, reviewID uuid.UUID, userID uuid.UUID) error
@@ -46,19 +59,25 @@ type ReviewService interface {
 	GetReviewStats(ctx context.Context, artisanID uuid.UUID) (*dto.ReviewStatsResponse, error)
 	GetAverageRating(ctx context.Context, artisanID uuid.UUID) (float64, error)
 	GetRatingDistribution(ctx context.Context, artisanID uuid.UUID) (map[int]int64, error)
+
+	// Background Operations
+	SendReviewRequests(ctx context.Context) (int, error)
+	ReconcileRatings(ctx context.Context) (int64, error)
 }
 
 // reviewService implements ReviewService
 type reviewService struct {
-	repos  *repository.Repositories
-	logger log.AllLogger
+	repos         *repository.Repositories
+	logger        log.AllLogger
+	notifications NotificationService
 }
 
 // NewReviewService creates a new ReviewService instance
 func NewReviewService(repos *repository.Repositories, logger log.AllLogger) ReviewService {
 	return &reviewService{
-		repos:  repos,
-		logger: logger,
+		repos:         repos,
+		logger:        logger,
+		notifications: NewNotificationService(repos, logger),
 	}
 }
 
@@ -96,6 +115,11 @@ func (s *reviewService) CreateReview(ctx context.Context, tenantID, customerID u
 		return nil, errors.NewValidationError("Can only review completed bookings")
 	}
 
+	policy, requireApproval, err := s.moderationPolicy(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create review model
 	review := &models.Review{
 		TenantID:              tenantID,
@@ -112,19 +136,24 @@ func (s *reviewService) CreateReview(ctx context.Context, tenantID, customerID u
 		TimelinessRating:      req.TimelinessRating,
 		PhotoURLs:             req.PhotoURLs,
 		Metadata:              req.Metadata,
-		IsPublished:           true, // Auto-publish by default
 		IsFlagged:             false,
 		HelpfulCount:          0,
 		NotHelpfulCount:       0,
 	}
+	review.ApplyModeration(policy, requireApproval)
 
 	// Save to database
-	if err := s.repos.Review.Create(ctx, review); err != nil {
+	if err := s.saveReview(ctx, review, func(tx *repository.Repositories) error {
+		return tx.Review.Create(ctx, review)
+	}); err != nil {
 		s.logger.Error("failed to create review", "error", err)
 		return nil, errors.NewServiceError("CREATE_FAILED", "Failed to create review", err)
 	}
 
-	s.logger.Info("review created", "review_id", review.ID)
+	s.logger.Info("review created", "review_id", review.ID, "published", review.IsPublished, "hold_reason", review.HoldReason)
+	if review.IsPublished {
+		s.notifyPublished(ctx, review)
+	}
 
 	// Load with relationships
 	created, err := s.repos.Review.GetByID(ctx, review.ID)
@@ -187,6 +216,11 @@ func (s *reviewService) UpdateReview(ctx context.Context, id uuid.UUID, customer
 		return nil, errors.NewValidationError("Cannot update review after artisan has responded")
 	}
 
+	// Rejected reviews stay rejected
+	if review.ModerationStatus() == models.ReviewModerationRejected {
+		return nil, errors.NewValidationError("Cannot update a rejected review")
+	}
+
 	// Update fields
 	if req.Rating != nil {
 		review.Rating = *req.Rating
@@ -227,8 +261,19 @@ func (s *reviewService) UpdateReview(ctx context.Context, id uuid.UUID, customer
 		maps.Copy(review.Metadata, req.Metadata)
 	}
 
+	// Changed content is moderated again
+	if req.Rating != nil || req.Title != nil || req.Comment != nil || req.PhotoURLs != nil {
+		policy, requireApproval, err := s.moderationPolicy(ctx, review.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		review.ApplyModeration(policy, requireApproval)
+	}
+
 	// Save changes
-	if err := s.repos.Review.Update(ctx, review); err != nil {
+	if err := s.saveReview(ctx, review, func(tx *repository.Repositories) error {
+		return tx.Review.Update(ctx, review)
+	}); err != nil {
 		s.logger.Error("failed to update review", "review_id", id, "error", err)
 		return nil, errors.NewServiceError("UPDATE_FAILED", "Failed to update review", err)
 	}
@@ -263,7 +308,9 @@ func (s *reviewService) DeleteReview(ctx context.Context, id uuid.UUID, userID u
 		return errors.NewValidationError("Only the reviewer or admin can delete this review")
 	}
 
-	if err := s.repos.Review.Delete(ctx, id); err != nil {
+	if err := s.saveReview(ctx, review, func(tx *repository.Repositories) error {
+		return tx.Review.Delete(ctx, id)
+	}); err != nil {
 		s.logger.Error("failed to delete review", "review_id", id, "error", err)
 		return errors.NewServiceError("DELETE_FAILED", "Failed to delete review", err)
 	}
@@ -414,7 +461,7 @@ func (s *reviewService) GetCustomerReviews(ctx context.Context, customerID uuid.
 	return dto.ToReviewDetailResponses(reviewPtrs), nil
 }
 
-// RespondToReview adds an artisan's response to a review
+// RespondToReview opens the thread under a review with the artisan's response
 func (s *reviewService) RespondToReview(ctx context.Context, reviewID uuid.UUID, artisanID uuid.UUID, req *dto.RespondToReviewRequest) (*dto.ReviewDetailResponse, error) {
 	s.logger.Info("responding to review", "review_id", reviewID, "artisan_id", artisanID)
 
@@ -433,16 +480,16 @@ func (s *reviewService) RespondToReview(ctx context.Context, reviewID uuid.UUID,
 
 	// Check if already responded
 	if review.ResponseText != "" {
-		return nil, errors.NewValidationError("Review already has a response")
+		return nil, errors.NewValidationError("Review already has a response; reply in its thread instead")
 	}
 
-	// Add response
-	if err := s.repos.Review.AddResponse(ctx, reviewID, req.ResponseText, artisanID); err != nil {
-		s.logger.Error("failed to add response", "review_id", reviewID, "error", err)
-		return nil, errors.NewServiceError("RESPONSE_FAILED", "Failed to add response", err)
+	reply := &dto.CreateReviewReplyRequest{Body: req.ResponseText}
+	if err := reply.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if _, err := s.addReply(ctx, review, artisanID, reply.Body); err != nil {
+		return nil, err
 	}
-
-	s.logger.Info("response added to review", "review_id", reviewID)
 
 	// Reload review
 	updated, err := s.repos.Review.GetByID(ctx, reviewID)
@@ -453,136 +500,243 @@ func (s *reviewService) RespondToReview(ctx context.Context, reviewID uuid.UUID,
 	return dto.ToReviewDetailResponse(updated), nil
 }
 
-// PublishReview publishes a review (makes it public)
-func (s *reviewService) PublishReview(ctx context.Context, reviewID uuid.UUID, moderatorID uuid.UUID) error {
-	s.logger.Info("publishing review", "review_id", reviewID, "moderator_id", moderatorID)
+// ReplyToReview adds a reply to the thread under a published review. The reviewed
+// artisan opens the thread; the reviewer can reply once they have.
+func (s *reviewService) ReplyToReview(ctx context.Context, reviewID uuid.UUID, authorID uuid.UUID, req *dto.CreateReviewReplyRequest) (*dto.ReviewReplyResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
 
-	// Verify review exists
-	_, err := s.repos.Review.GetByID(ctx, reviewID)
+	review, err := s.repos.Review.GetByID(ctx, reviewID)
 	if err != nil {
 		s.logger.Error("review not found", "review_id", reviewID, "error", err)
-		return errors.NewNotFoundError("review")
+		return nil, errors.NewNotFoundError("review")
 	}
 
-	if err := s.repos.Review.UpdatePublishStatus(ctx, reviewID, true); err != nil {
-		s.logger.Error("failed to publish review", "review_id", reviewID, "error", err)
-		return errors.NewServiceError("PUBLISH_FAILED", "Failed to publish review", err)
+	reply, err := s.addReply(ctx, review, authorID, req.Body)
+	if err != nil {
+		return nil, err
 	}
 
-	s.logger.Info("review published", "review_id", reviewID)
-	return nil
+	return dto.ToReviewReplyResponses([]*models.ReviewReply{reply})[0], nil
 }
 
-// UnpublishReview unpublishes a review (makes it private)
-func (s *reviewService) UnpublishReview(ctx context.Context, reviewID uuid.UUID, moderatorID uuid.UUID) error {
-	s.logger.Info("unpublishing review", "review_id", reviewID, "moderator_id", moderatorID)
+// GetReviewReplies retrieves the thread under a review
+func (s *reviewService) GetReviewReplies(ctx context.Context, reviewID uuid.UUID, userID uuid.UUID) ([]*dto.ReviewReplyResponse, error) {
+	if _, err := s.GetReview(ctx, reviewID, userID); err != nil {
+		return nil, err
+	}
 
-	// Verify review exists
-	_, err := s.repos.Review.GetByID(ctx, reviewID)
+	replies, err := s.repos.ReviewReply.FindByReview(ctx, reviewID)
 	if err != nil {
-		s.logger.Error("review not found", "review_id", reviewID, "error", err)
-		return errors.NewNotFoundError("review")
+		s.logger.Error("failed to get review replies", "review_id", reviewID, "error", err)
+		return nil, errors.NewServiceError("GET_FAILED", "Failed to get review replies", err)
 	}
 
-	if err := s.repos.Review.UpdatePublishStatus(ctx, reviewID, false); err != nil {
-		s.logger.Error("failed to unpublish review", "review_id", reviewID, "error", err)
-		return errors.NewServiceError("UNPUBLISH_FAILED", "Failed to unpublish review", err)
+	return dto.ToReviewReplyResponses(replies), nil
+}
+
+// GetPendingModeration retrieves reviews held for a moderator
+func (s *reviewService) GetPendingModeration(ctx context.Context, tenantID uuid.UUID) ([]*dto.ReviewDetailResponse, error) {
+	s.logger.Info("getting pending moderation reviews", "tenant_id", tenantID)
+
+	reviews, err := s.repos.Review.FindPendingModeration(ctx, tenantID)
+	if err != nil {
+		s.logger.Error("failed to get pending moderation reviews", "error", err)
+		return nil, errors.NewServiceError("GET_FAILED", "Failed to get pending moderation reviews", err)
 	}
 
-	s.logger.Info("review unpublished", "review_id", reviewID)
-	return nil
+	// Convert to pointers
+	reviewPtrs := make([]*models.Review, len(reviews))
+	for i := range reviews {
+		reviewPtrs[i] = &reviews[i]
+	}
+
+	return dto.ToReviewDetailResponses(reviewPtrs), nil
 }
 
-// FlagReview flags a review for moderation
-func (s *reviewService) FlagReview(ctx context.Context, reviewID uuid.UUID, reason string, moderatorID uuid.UUID) error {
-	s.logger.Info("flagging review", "review_id", reviewID, "moderator_id", moderatorID, "reason", reason)
+// PublishReview publishes a held or rejected review
+func (s *reviewService) PublishReview(ctx context.Context, tenantID, reviewID, moderatorID uuid.UUID) (*dto.ReviewDetailResponse, error) {
+	s.logger.Info("publishing review", "review_id", reviewID, "moderator_id", moderatorID)
 
-	// Get existing review
-	review, err := s.repos.Review.GetByID(ctx, reviewID)
+	review, err := s.getTenantReview(ctx, tenantID, reviewID)
 	if err != nil {
-		s.logger.Error("review not found", "review_id", reviewID, "error", err)
-		return errors.NewNotFoundError("review")
+		return nil, err
 	}
 
-	if review.IsFlagged {
-		return errors.NewValidationError("Review is already flagged")
+	if err := review.Approve(moderatorID, time.Now()); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if err := s.saveReview(ctx, review, func(tx *repository.Repositories) error {
+		return tx.Review.Update(ctx, review)
+	}); err != nil {
+		s.logger.Error("failed to publish review", "review_id", reviewID, "error", err)
+		return nil, errors.NewServiceError("PUBLISH_FAILED", "Failed to publish review", err)
 	}
 
-	review.IsFlagged = true
-	review.FlaggedReason = reason
+	s.logger.Info("review published", "review_id", reviewID)
+	s.notifyPublished(ctx, review)
+	return dto.ToReviewDetailResponse(review), nil
+}
 
-	if err := s.repos.Review.Update(ctx, review); err != nil {
-		s.logger.Error("failed to flag review", "review_id", reviewID, "error", err)
-		return errors.NewServiceError("FLAG_FAILED", "Failed to flag review", err)
+// RejectReview hides a held or published review, recording why
+func (s *reviewService) RejectReview(ctx context.Context, tenantID, reviewID, moderatorID uuid.UUID, req *dto.RejectReviewRequest) (*dto.ReviewDetailResponse, error) {
+	s.logger.Info("rejecting review", "review_id", reviewID, "moderator_id", moderatorID)
+
+	review, err := s.getTenantReview(ctx, tenantID, reviewID)
+	if err != nil {
+		return nil, err
 	}
 
-	s.logger.Info("review flagged", "review_id", reviewID)
-	return nil
+	if err := review.Reject(moderatorID, req.Reason, time.Now()); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if err := s.saveReview(ctx, review, func(tx *repository.Repositories) error {
+		return tx.Review.Update(ctx, review)
+	}); err != nil {
+		s.logger.Error("failed to reject review", "review_id", reviewID, "error", err)
+		return nil, errors.NewServiceError("REJECT_FAILED", "Failed to reject review", err)
+	}
+
+	s.logger.Info("review rejected", "review_id", reviewID)
+	return dto.ToReviewDetailResponse(review), nil
 }
 
-// UnflagReview removes flag from a review
-func (s *reviewService) UnflagReview(ctx context.Context, reviewID uuid.UUID, moderatorID uuid.UUID) error {
-	s.logger.Info("unflagging review", "review_id", reviewID, "moderator_id", moderatorID)
+// FlagReview raises a report or, for the reviewed artisan, a dispute against a review.
+// The review stays as it is until a moderator resolves the flag.
+func (s *reviewService) FlagReview(ctx context.Context, tenantID, reviewID, reporterID uuid.UUID, req *dto.FlagReviewRequest) (*dto.ReviewFlagResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	s.logger.Info("flagging review", "review_id", reviewID, "reporter_id", reporterID, "kind", req.Kind)
 
-	// Get existing review
-	review, err := s.repos.Review.GetByID(ctx, reviewID)
+	review, err := s.getTenantReview(ctx, tenantID, reviewID)
 	if err != nil {
-		s.logger.Error("review not found", "review_id", reviewID, "error", err)
-		return errors.NewNotFoundError("review")
+		return nil, err
 	}
 
-	if !review.IsFlagged {
-		return errors.NewValidationError("Review is not flagged")
+	if req.Kind == models.ReviewFlagKindDispute && review.ArtisanID != reporterID {
+		return nil, errors.NewForbiddenError("Only the reviewed artisan can dispute a review")
+	}
+	if review.CustomerID == reporterID {
+		return nil, errors.NewValidationError("Reviewers cannot flag their own review")
 	}
 
-	review.IsFlagged = false
-	review.FlaggedReason = ""
+	exists, err := s.repos.ReviewFlag.HasOpenFlag(ctx, reviewID, reporterID)
+	if err != nil {
+		return nil, errors.NewServiceError("FLAG_FAILED", "Failed to flag review", err)
+	}
+	if exists {
+		return nil, errors.NewConflictError("You already have an open flag on this review")
+	}
 
+	flag := &models.ReviewFlag{
+		TenantID:   tenantID,
+		ReviewID:   reviewID,
+		ReporterID: reporterID,
+		Kind:       req.Kind,
+		Reason:     req.Reason,
+		Status:     models.ReviewFlagStatusOpen,
+	}
+	if err := s.repos.ReviewFlag.Create(ctx, flag); err != nil {
+		s.logger.Error("failed to create review flag", "review_id", reviewID, "error", err)
+		return nil, errors.NewServiceError("FLAG_FAILED", "Failed to flag review", err)
+	}
+
+	review.IsFlagged = true
+	review.FlaggedReason = req.Reason
 	if err := s.repos.Review.Update(ctx, review); err != nil {
-		s.logger.Error("failed to unflag review", "review_id", reviewID, "error", err)
-		return errors.NewServiceError("UNFLAG_FAILED", "Failed to unflag review", err)
+		s.logger.Error("failed to flag review", "review_id", reviewID, "error", err)
+		return nil, errors.NewServiceError("FLAG_FAILED", "Failed to flag review", err)
 	}
 
-	s.logger.Info("review unflagged", "review_id", reviewID)
-	return nil
+	s.logger.Info("review flagged", "review_id", reviewID, "flag_id", flag.ID)
+	flag.Review = review
+	return dto.ToReviewFlagResponse(flag), nil
 }
 
-// GetPendingModeration retrieves reviews pending moderation
-func (s *reviewService) GetPendingModeration(ctx context.Context, tenantID uuid.UUID) ([]*dto.ReviewDetailResponse, error) {
-	s.logger.Info("getting pending moderation reviews", "tenant_id", tenantID)
-
-	reviews, err := s.repos.Review.FindPendingModeration(ctx, tenantID)
-	if err != nil {
-		s.logger.Error("failed to get pending moderation reviews", "error", err)
-		return nil, errors.NewServiceError("GET_FAILED", "Failed to get pending moderation reviews", err)
+// ListFlags lists the tenant's review flags in a status, open ones forming the queue
+func (s *reviewService) ListFlags(ctx context.Context, tenantID uuid.UUID, status models.ReviewFlagStatus, page, pageSize int) (*dto.ReviewFlagListResponse, error) {
+	if status == "" {
+		status = models.ReviewFlagStatusOpen
+	}
+	if !status.IsValid() {
+		return nil, errors.NewValidationError("invalid flag status")
 	}
 
-	// Convert to pointers
-	reviewPtrs := make([]*models.Review, len(reviews))
-	for i := range reviews {
-		reviewPtrs[i] = &reviews[i]
+	flags, result, err := s.repos.ReviewFlag.FindByStatus(ctx, tenantID, status, repository.PaginationParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to list review flags", err)
 	}
 
-	return dto.ToReviewDetailResponses(reviewPtrs), nil
+	return &dto.ReviewFlagListResponse{
+		Flags:       dto.ToReviewFlagResponses(flags),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
 }
 
-// GetFlaggedReviews retrieves flagged reviews
-func (s *reviewService) GetFlaggedReviews(ctx context.Context, tenantID uuid.UUID) ([]*dto.ReviewDetailResponse, error) {
-	s.logger.Info("getting flagged reviews", "tenant_id", tenantID)
+// ResolveFlag closes an open flag. Upholding it rejects the review; the review stays
+// flagged while other flags on it are open.
+func (s *reviewService) ResolveFlag(ctx context.Context, tenantID, flagID, moderatorID uuid.UUID, req *dto.ResolveReviewFlagRequest) (*dto.ReviewFlagResponse, error) {
+	s.logger.Info("resolving review flag", "flag_id", flagID, "moderator_id", moderatorID, "uphold", req.Uphold)
 
-	reviews, err := s.repos.Review.FindFlagged(ctx, tenantID)
+	flag, err := s.repos.ReviewFlag.GetByID(ctx, flagID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("review flag")
+		}
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to get review flag", err)
+	}
+	if flag.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("review flag")
+	}
+	review, err := s.getTenantReview(ctx, tenantID, flag.ReviewID)
 	if err != nil {
-		s.logger.Error("failed to get flagged reviews", "error", err)
-		return nil, errors.NewServiceError("GET_FAILED", "Failed to get flagged reviews", err)
+		return nil, err
 	}
 
-	// Convert to pointers
-	reviewPtrs := make([]*models.Review, len(reviews))
-	for i := range reviews {
-		reviewPtrs[i] = &reviews[i]
+	now := time.Now()
+	if err := flag.Resolve(moderatorID, req.Uphold, req.Note, now); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if err := s.repos.ReviewFlag.Update(ctx, flag); err != nil {
+		s.logger.Error("failed to resolve review flag", "flag_id", flagID, "error", err)
+		return nil, errors.NewServiceError("RESOLVE_FAILED", "Failed to resolve review flag", err)
 	}
 
-	return dto.ToReviewDetailResponses(reviewPtrs), nil
+	if req.Uphold && review.ModerationStatus() != models.ReviewModerationRejected {
+		reason := flag.Resolution
+		if reason == "" {
+			reason = flag.Reason
+		}
+		if err := review.Reject(moderatorID, reason, now); err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+	}
+
+	open, err := s.repos.ReviewFlag.CountOpen(ctx, review.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("RESOLVE_FAILED", "Failed to resolve review flag", err)
+	}
+	if open == 0 {
+		review.IsFlagged = false
+		review.FlaggedReason = ""
+	}
+	if err := s.saveReview(ctx, review, func(tx *repository.Repositories) error {
+		return tx.Review.Update(ctx, review)
+	}); err != nil {
+		s.logger.Error("failed to update flagged review", "review_id", review.ID, "error", err)
+		return nil, errors.NewServiceError("RESOLVE_FAILED", "Failed to resolve review flag", err)
+	}
+
+	s.logger.Info("review flag resolved", "flag_id", flagID, "status", flag.Status)
+	flag.Review = review
+	return dto.ToReviewFlagResponse(flag), nil
 }
 
 // MarkHelpful marks a review as helpful
@@ -718,3 +872,167 @@ func (s *reviewService) GetRatingDistribution(ctx context.Context, artisanID uui
 
 	return distribution, nil
 }
+
+// ============================================================================
+// Background Operations
+// ============================================================================
+
+// SendReviewRequests asks customers of recently completed bookings without a review to
+// leave one, once their tenant's delay has passed, returning how many were asked
+func (s *reviewService) SendReviewRequests(ctx context.Context) (int, error) {
+	now := time.Now()
+	since := now.Add(-reviewRequestWindow)
+	tenantIDs, err := s.repos.Booking.FindTenantsAwaitingReviewRequests(ctx, since)
+	if err != nil {
+		return 0, errors.NewServiceError("REVIEW_REQUESTS_FAILED", "failed to find tenants awaiting review requests", err)
+	}
+
+	sent := 0
+	for _, tenantID := range tenantIDs {
+		tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+		if err != nil {
+			s.logger.Error("failed to get tenant for review requests", "tenant_id", tenantID, "error", err)
+			continue
+		}
+		policy := tenant.Settings.GetReviewModerationPolicy()
+		if !tenant.Settings.EnableCustomerReviews || policy.RequestAfterHours == 0 {
+			continue
+		}
+
+		cutoff := now.Add(-time.Duration(policy.RequestAfterHours) * time.Hour)
+		bookings, err := s.repos.Booking.FindAwaitingReviewRequest(ctx, tenantID, since, cutoff, reviewRequestBatchSize)
+		if err != nil {
+			s.logger.Error("failed to find bookings awaiting review requests", "tenant_id", tenantID, "error", err)
+			continue
+		}
+		for _, booking := range bookings {
+			if _, err := s.notifications.SendReviewRequestNotification(ctx, booking); err != nil {
+				s.logger.Error("failed to send review request", "booking_id", booking.ID, "error", err)
+				continue
+			}
+			if err := s.repos.Booking.MarkReviewRequested(ctx, booking.ID, now); err != nil {
+				s.logger.Error("failed to mark review requested", "booking_id", booking.ID, "error", err)
+				continue
+			}
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		s.logger.Info("review requests sent", "count", sent)
+	}
+	return sent, nil
+}
+
+// ReconcileRatings corrects artisan and service ratings that drifted from their
+// published reviews, such as reviews changed outside this service, returning how many
+// were corrected
+func (s *reviewService) ReconcileRatings(ctx context.Context) (int64, error) {
+	corrected, err := s.repos.Review.ReconcileRatings(ctx)
+	if err != nil {
+		return 0, errors.NewServiceError("RECONCILE_FAILED", "failed to reconcile ratings", err)
+	}
+
+	if corrected > 0 {
+		s.logger.Warn("corrected drifted ratings", "count", corrected)
+	}
+	return corrected, nil
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+// saveReview writes a review change and recalculates the ratings of the reviewed artisan
+// and service in one transaction, so they always reflect the published reviews
+func (s *reviewService) saveReview(ctx context.Context, review *models.Review, write func(tx *repository.Repositories) error) error {
+	return s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		if err := write(tx); err != nil {
+			return err
+		}
+		return tx.Review.RecalculateRatings(ctx, review.ArtisanID, review.ServiceID)
+	})
+}
+
+// moderationPolicy returns the tenant's review moderation policy and whether the tenant
+// approves every review
+func (s *reviewService) moderationPolicy(ctx context.Context, tenantID uuid.UUID) (models.ReviewModerationPolicy, bool, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		s.logger.Error("failed to get tenant for review moderation", "tenant_id", tenantID, "error", err)
+		return models.ReviewModerationPolicy{}, false, errors.NewServiceError("QUERY_FAILED", "Failed to get tenant", err)
+	}
+	return tenant.Settings.GetReviewModerationPolicy(), tenant.Settings.ReviewsRequireApproval, nil
+}
+
+// getTenantReview retrieves a review of the tenant
+func (s *reviewService) getTenantReview(ctx context.Context, tenantID, reviewID uuid.UUID) (*models.Review, error) {
+	review, err := s.repos.Review.GetByID(ctx, reviewID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("review")
+		}
+		return nil, errors.NewServiceError("QUERY_FAILED", "Failed to get review", err)
+	}
+	if review.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("review")
+	}
+	return review, nil
+}
+
+// addReply adds a reply with profanity masked to a published review's thread. The
+// artisan's first reply is also recorded as the review's response.
+func (s *reviewService) addReply(ctx context.Context, review *models.Review, authorID uuid.UUID, body string) (*models.ReviewReply, error) {
+	if review.ModerationStatus() != models.ReviewModerationPublished {
+		return nil, errors.NewValidationError("Only published reviews can be replied to")
+	}
+
+	var role models.ReviewReplyAuthorRole
+	switch authorID {
+	case review.ArtisanID:
+		role = models.ReviewReplyAuthorArtisan
+	case review.CustomerID:
+		role = models.ReviewReplyAuthorCustomer
+		if !review.HasResponse() {
+			return nil, errors.NewValidationError("The artisan has not responded to this review yet")
+		}
+	default:
+		return nil, errors.NewForbiddenError("Only the reviewer and the reviewed artisan can reply")
+	}
+
+	policy, _, err := s.moderationPolicy(ctx, review.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	masked, profane := models.MaskProfanity(body, policy.BlockedWords)
+
+	reply := &models.ReviewReply{
+		TenantID:          review.TenantID,
+		ReviewID:          review.ID,
+		AuthorID:          authorID,
+		AuthorRole:        role,
+		Body:              masked,
+		ContainsProfanity: profane,
+	}
+	if err := s.repos.ReviewReply.Create(ctx, reply); err != nil {
+		s.logger.Error("failed to create review reply", "review_id", review.ID, "error", err)
+		return nil, errors.NewServiceError("RESPONSE_FAILED", "Failed to add reply", err)
+	}
+
+	if role == models.ReviewReplyAuthorArtisan && !review.HasResponse() {
+		if err := s.repos.Review.AddResponse(ctx, review.ID, masked, authorID); err != nil {
+			s.logger.Error("failed to add response", "review_id", review.ID, "error", err)
+			return nil, errors.NewServiceError("RESPONSE_FAILED", "Failed to add response", err)
+		}
+	}
+
+	s.logger.Info("review reply added", "review_id", review.ID, "reply_id", reply.ID, "author_role", role)
+	return reply, nil
+}
+
+// notifyPublished tells the artisan a review of theirs was published
+func (s *reviewService) notifyPublished(ctx context.Context, review *models.Review) {
+	if _, err := s.notifications.SendReviewNotification(ctx, review, models.NotificationTypeReviewReceived); err != nil {
+		s.logger.Error("failed to send review notification", "review_id", review.ID, "error", err)
+	}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// SavedFilterService defines the interface for per-user saved booking filters
+type SavedFilterService interface {
+	CreateFilter(ctx context.Context, req *dto.CreateSavedFilterRequest) (*dto.SavedFilterResponse, error)
+	GetFilter(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.SavedFilterResponse, error)
+	UpdateFilter(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.UpdateSavedFilterRequest) (*dto.SavedFilterResponse, error)
+	DeleteFilter(ctx context.Context, tenantID, userID, id uuid.UUID) error
+	ListFilters(ctx context.Context, tenantID, userID uuid.UUID) ([]*dto.SavedFilterResponse, error)
+}
+
+// savedFilterService implements SavedFilterService
+type savedFilterService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewSavedFilterService creates a new SavedFilterService instance
+func NewSavedFilterService(repos *repository.Repositories, logger log.AllLogger) SavedFilterService {
+	return &savedFilterService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// maxSavedFiltersPerUser caps how many views one user can keep
+const maxSavedFiltersPerUser = 50
+
+// ============================================================================
+// CRUD Operations
+// ============================================================================
+
+// CreateFilter saves a bookings view for the requesting user
+func (s *savedFilterService) CreateFilter(ctx context.Context, req *dto.CreateSavedFilterRequest) (*dto.SavedFilterResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	existing, err := s.repos.SavedFilter.FindByUser(ctx, req.TenantID, req.UserID, models.SavedFilterResourceBookings)
+	if err != nil {
+		return nil, errors.NewServiceError("SAVED_FILTER_LIST_FAILED", "failed to list saved filters", err)
+	}
+	if len(existing) >= maxSavedFiltersPerUser {
+		return nil, errors.NewValidationError("saved filter limit reached")
+	}
+
+	filter := &models.SavedFilter{
+		TenantID:  req.TenantID,
+		UserID:    req.UserID,
+		Name:      strings.TrimSpace(req.Name),
+		Resource:  models.SavedFilterResourceBookings,
+		Criteria:  req.Criteria.SavedCriteria(),
+		DateRange: req.DateRange,
+		TimeZone:  req.TimeZone,
+	}
+	if err := s.ensureUniqueName(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	if err := s.repos.SavedFilter.Create(ctx, filter); err != nil {
+		s.logger.Error("failed to create saved filter", "user_id", req.UserID, "error", err)
+		return nil, errors.NewServiceError("SAVED_FILTER_CREATE_FAILED", "failed to create saved filter", err)
+	}
+
+	return dto.ToSavedFilterResponse(filter), nil
+}
+
+// GetFilter retrieves one of the user's saved filters
+func (s *savedFilterService) GetFilter(ctx context.Context, tenantID, userID, id uuid.UUID) (*dto.SavedFilterResponse, error) {
+	filter, err := s.getFilter(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToSavedFilterResponse(filter), nil
+}
+
+// UpdateFilter renames a saved filter or replaces its criteria
+func (s *savedFilterService) UpdateFilter(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.UpdateSavedFilterRequest) (*dto.SavedFilterResponse, error) {
+	filter, err := s.getFilter(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.Apply(filter); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+	if err := s.ensureUniqueName(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	if err := s.repos.SavedFilter.Update(ctx, filter); err != nil {
+		s.logger.Error("failed to update saved filter", "filter_id", id, "error", err)
+		return nil, errors.NewServiceError("SAVED_FILTER_UPDATE_FAILED", "failed to update saved filter", err)
+	}
+
+	return dto.ToSavedFilterResponse(filter), nil
+}
+
+// DeleteFilter removes one of the user's saved filters
+func (s *savedFilterService) DeleteFilter(ctx context.Context, tenantID, userID, id uuid.UUID) error {
+	if _, err := s.getFilter(ctx, tenantID, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.repos.SavedFilter.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete saved filter", "filter_id", id, "error", err)
+		return errors.NewServiceError("SAVED_FILTER_DELETE_FAILED", "failed to delete saved filter", err)
+	}
+
+	return nil
+}
+
+// ListFilters lists the user's saved booking filters
+func (s *savedFilterService) ListFilters(ctx context.Context, tenantID, userID uuid.UUID) ([]*dto.SavedFilterResponse, error) {
+	filters, err := s.repos.SavedFilter.FindByUser(ctx, tenantID, userID, models.SavedFilterResourceBookings)
+	if err != nil {
+		return nil, errors.NewServiceError("SAVED_FILTER_LIST_FAILED", "failed to list saved filters", err)
+	}
+	return dto.ToSavedFilterResponses(filters), nil
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+func (s *savedFilterService) getFilter(ctx context.Context, tenantID, userID, id uuid.UUID) (*models.SavedFilter, error) {
+	filter, err := s.repos.SavedFilter.GetForUser(ctx, tenantID, userID, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("saved filter not found")
+		}
+		return nil, errors.NewServiceError("SAVED_FILTER_GET_FAILED", "failed to get saved filter", err)
+	}
+	return filter, nil
+}
+
+func (s *savedFilterService) ensureUniqueName(ctx context.Context, filter *models.SavedFilter) error {
+	exists, err := s.repos.SavedFilter.ExistsByName(ctx, filter.TenantID, filter.UserID, filter.Resource, filter.Name, filter.ID)
+	if err != nil {
+		return errors.NewServiceError("SAVED_FILTER_CHECK_FAILED", "failed to check saved filter name", err)
+	}
+	if exists {
+		return errors.NewConflictError("a saved filter with this name already exists")
+	}
+	return nil
+}
+
+// resolveSavedBookingFilter merges the user's saved filter into the list filter. Fields
+// set on the request win; the saved date range applies unless the request sets dates.
+func resolveSavedBookingFilter(ctx context.Context, repos *repository.Repositories, filter dto.BookingFilter, now time.Time) (dto.BookingFilter, error) {
+	if filter.SavedFilterID == nil {
+		return filter, nil
+	}
+
+	var tenantID uuid.UUID
+	if filter.TenantID != nil {
+		tenantID = *filter.TenantID
+	}
+
+	saved, err := repos.SavedFilter.GetForUser(ctx, tenantID, filter.UserID, *filter.SavedFilterID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return filter, errors.NewNotFoundError("saved filter not found")
+		}
+		return filter, errors.NewServiceError("SAVED_FILTER_GET_FAILED", "failed to get saved filter", err)
+	}
+
+	resolved, err := filter.MergeSavedCriteria(saved.Criteria)
+	if err != nil {
+		return filter, errors.NewValidationError(err.Error())
+	}
+
+	if resolved.StartDate == nil && resolved.EndDate == nil {
+		if from, to, ok := saved.DateRange.Bounds(now, saved.Location()); ok {
+			// The repository bound is inclusive; stop just before the exclusive end
+			end := to.Add(-time.Microsecond)
+			resolved.StartDate = &from
+			resolved.EndDate = &end
+		}
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// SearchService searches across a tenant's bookings, customers, services and projects
+type SearchService interface {
+	// Search finds the tenant's records matching every word of the query, the best
+	// matches first
+	Search(ctx context.Context, req *dto.SearchRequest) (*dto.SearchResponse, error)
+}
+
+// searchService implements SearchService
+type searchService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewSearchService creates a new SearchService instance
+func NewSearchService(repos *repository.Repositories, logger log.AllLogger) SearchService {
+	return &searchService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// Search runs the search and maps the repository's hits to typed responses
+func (s *searchService) Search(ctx context.Context, req *dto.SearchRequest) (*dto.SearchResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	kinds := make([]repository.SearchKind, len(req.Types))
+	for i, t := range req.Types {
+		kinds[i] = repository.SearchKind(t)
+	}
+
+	hits, err := s.repos.Search.Search(ctx, req.TenantID, req.Query, kinds, req.Limit)
+	if err != nil {
+		s.logger.Error("failed to search", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("SEARCH_FAILED", "failed to search", err)
+	}
+
+	resp := &dto.SearchResponse{
+		Query: req.Query,
+		Hits:  make([]*dto.SearchHitResponse, len(hits)),
+	}
+	for i, hit := range hits {
+		resp.Hits[i] = &dto.SearchHitResponse{
+			Type:     dto.SearchType(hit.Kind),
+			ID:       hit.ID,
+			Title:    hit.Title,
+			Subtitle: hit.Subtitle,
+			Date:     hit.Date,
+			Score:    hit.Rank,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultServiceAccountUsageDays is how far back usage dashboards look by default
+	defaultServiceAccountUsageDays = 30
+
+	// maxServiceAccountUsageDays is the longest period usage is reported for at once
+	maxServiceAccountUsageDays = 366
+)
+
+// MachineIdentityProvider manages the Zitadel machine users service accounts sign in as
+type MachineIdentityProvider interface {
+	// CreateMachineUser adds a machine user and returns its ID
+	CreateMachineUser(ctx context.Context, username, name, description string) (string, error)
+	// GenerateClientSecret gives a machine user a new client secret, replacing any it
+	// had, and returns its client ID and the secret
+	GenerateClientSecret(ctx context.Context, userID string) (clientID, clientSecret string, err error)
+	// DeleteUser removes a user
+	DeleteUser(ctx context.Context, userID string) error
+}
+
+// ServiceAccountService defines the interface for the service accounts tenants create
+// for their integrations. Each signs in with client credentials as a Zitadel machine
+// user, and may only take the actions of its scopes in its tenant.
+type ServiceAccountService interface {
+	// CRUD Operations
+	CreateServiceAccount(ctx context.Context, req *dto.CreateServiceAccountRequest) (*dto.ServiceAccountCredentialsResponse, error)
+	GetServiceAccount(ctx context.Context, id uuid.UUID) (*dto.ServiceAccountResponse, error)
+	UpdateServiceAccount(ctx context.Context, id uuid.UUID, req *dto.UpdateServiceAccountRequest) (*dto.ServiceAccountResponse, error)
+	ListServiceAccounts(ctx context.Context, tenantID uuid.UUID) ([]*dto.ServiceAccountResponse, error)
+
+	// Credentials
+	RotateSecret(ctx context.Context, id uuid.UUID) (*dto.ServiceAccountCredentialsResponse, error)
+	RevokeServiceAccount(ctx context.Context, id uuid.UUID) error
+
+	// GetUsage returns the account's requests on the days from from to to, inclusive;
+	// zero times default to the last 30 days
+	GetUsage(ctx context.Context, id uuid.UUID, from, to time.Time) (*dto.ServiceAccountUsageResponse, error)
+
+	// ListScopes returns the actions service accounts may be granted
+	ListScopes() []string
+
+	// ServiceAccountBySubject returns the service account signing in as the Zitadel
+	// user, or nil when the user is not one
+	ServiceAccountBySubject(ctx context.Context, zitadelUserID string) (*models.ServiceAccount, error)
+
+	// RecordServiceAccountUsage adds a request to the account's usage
+	RecordServiceAccountUsage(ctx context.Context, usage *models.ServiceAccountUsage, at time.Time) error
+}
+
+// serviceAccountService implements ServiceAccountService
+type serviceAccountService struct {
+	repos      *repository.Repositories
+	logger     log.AllLogger
+	authorizer Authorizer
+	identity   MachineIdentityProvider
+}
+
+// NewServiceAccountService creates a new ServiceAccountService instance. Service accounts
+// can't be created or given new secrets without identity.
+func NewServiceAccountService(repos *repository.Repositories, logger log.AllLogger, identity MachineIdentityProvider) ServiceAccountService {
+	return &serviceAccountService{
+		repos:      repos,
+		logger:     logger,
+		authorizer: NewAuthorizer(repos, logger),
+		identity:   identity,
+	}
+}
+
+// ============================================================================
+// CRUD Operations
+// ============================================================================
+
+// CreateServiceAccount creates a service account with its Zitadel machine user, and
+// returns the client credentials it signs in with. The secret isn't shown again.
+func (s *serviceAccountService) CreateServiceAccount(ctx context.Context, req *dto.CreateServiceAccountRequest) (*dto.ServiceAccountCredentialsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+	if err := s.authorizer.Can(ctx, authz.ActionRoleManage, authz.Tenant(req.TenantID)); err != nil {
+		return nil, err
+	}
+	if s.identity == nil {
+		return nil, serviceAccountsUnavailable()
+	}
+
+	scopes, err := normalizeRolePermissions(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &models.ServiceAccount{
+		TenantID:    req.TenantID,
+		CreatedByID: req.CreatedByID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Scopes:      scopes,
+		Status:      models.ServiceAccountStatusActive,
+	}
+	if err := account.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if err := s.checkNameAvailable(ctx, account); err != nil {
+		return nil, err
+	}
+
+	// The account's ID names its machine user, so it is known ahead of saving
+	account.ID = uuid.New()
+	account.ZitadelUserID, err = s.identity.CreateMachineUser(ctx, "sa-"+account.ID.String(), account.Name, account.Description)
+	if err != nil {
+		s.logger.Error("failed to create service account machine user", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("SERVICE_ACCOUNT_CREATE_FAILED", "failed to create service account", err)
+	}
+
+	clientID, clientSecret, err := s.identity.GenerateClientSecret(ctx, account.ZitadelUserID)
+	if err == nil {
+		now := time.Now()
+		account.ClientID = clientID
+		account.SecretRotatedAt = &now
+		err = s.repos.ServiceAccount.Create(ctx, account)
+	}
+	if err != nil {
+		if deleteErr := s.identity.DeleteUser(ctx, account.ZitadelUserID); deleteErr != nil {
+			s.logger.Error("failed to remove machine user of service account not created", "zitadel_user_id", account.ZitadelUserID, "error", deleteErr)
+		}
+		if errors.IsDuplicate(err) {
+			return nil, errors.NewConflictError("a service account with this name already exists")
+		}
+		s.logger.Error("failed to create service account", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("SERVICE_ACCOUNT_CREATE_FAILED", "failed to create service account", err)
+	}
+
+	s.logger.Info("service account created", "service_account_id", account.ID, "tenant_id", account.TenantID, "scopes", account.Scopes)
+	return &dto.ServiceAccountCredentialsResponse{
+		Account:      dto.ToServiceAccountResponse(account),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}, nil
+}
+
+// GetServiceAccount retrieves a service account by ID
+func (s *serviceAccountService) GetServiceAccount(ctx context.Context, id uuid.UUID) (*dto.ServiceAccountResponse, error) {
+	account, err := s.getAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToServiceAccountResponse(account), nil
+}
+
+// UpdateServiceAccount updates a service account. Changed scopes apply to its requests
+// within a minute.
+func (s *serviceAccountService) UpdateServiceAccount(ctx context.Context, id uuid.UUID, req *dto.UpdateServiceAccountRequest) (*dto.ServiceAccountResponse, error) {
+	account, err := s.getActiveAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := account.Name
+	if req.Name != nil {
+		account.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		account.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Scopes != nil {
+		scopes, err := normalizeRolePermissions(*req.Scopes)
+		if err != nil {
+			return nil, err
+		}
+		account.Scopes = scopes
+	}
+
+	if err := account.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if account.Name != name {
+		if err := s.checkNameAvailable(ctx, account); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repos.ServiceAccount.Update(ctx, account); err != nil {
+		if errors.IsDuplicate(err) {
+			return nil, errors.NewConflictError("a service account with this name already exists")
+		}
+		s.logger.Error("failed to update service account", "service_account_id", id, "error", err)
+		return nil, errors.NewServiceError("SERVICE_ACCOUNT_UPDATE_FAILED", "failed to update service account", err)
+	}
+
+	s.logger.Info("service account updated", "service_account_id", id, "scopes", account.Scopes)
+	return dto.ToServiceAccountResponse(account), nil
+}
+
+// ListServiceAccounts lists a tenant's service accounts, revoked ones included
+func (s *serviceAccountService) ListServiceAccounts(ctx context.Context, tenantID uuid.UUID) ([]*dto.ServiceAccountResponse, error) {
+	if err := s.authorizer.Can(ctx, authz.ActionRoleManage, authz.Tenant(tenantID)); err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.repos.ServiceAccount.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("SERVICE_ACCOUNT_LIST_FAILED", "failed to list service accounts", err)
+	}
+	return dto.ToServiceAccountResponses(accounts), nil
+}
+
+// ============================================================================
+// Credentials
+// ============================================================================
+
+// RotateSecret gives the account a new client secret. The previous secret stops working
+// at once, though tokens already issued with it last until they expire.
+func (s *serviceAccountService) RotateSecret(ctx context.Context, id uuid.UUID) (*dto.ServiceAccountCredentialsResponse, error) {
+	account, err := s.getActiveAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.identity == nil {
+		return nil, serviceAccountsUnavailable()
+	}
+
+	clientID, clientSecret, err := s.identity.GenerateClientSecret(ctx, account.ZitadelUserID)
+	if err != nil {
+		s.logger.Error("failed to rotate service account secret", "service_account_id", id, "error", err)
+		return nil, errors.NewServiceError("SERVICE_ACCOUNT_ROTATE_FAILED", "failed to rotate client secret", err)
+	}
+
+	now := time.Now()
+	account.ClientID = clientID
+	account.SecretRotatedAt = &now
+	if err := s.repos.ServiceAccount.Update(ctx, account); err != nil {
+		s.logger.Error("failed to save rotated service account secret", "service_account_id", id, "error", err)
+		return nil, errors.NewServiceError("SERVICE_ACCOUNT_ROTATE_FAILED", "failed to rotate client secret", err)
+	}
+
+	s.logger.Info("service account secret rotated", "service_account_id", id)
+	return &dto.ServiceAccountCredentialsResponse{
+		Account:      dto.ToServiceAccountResponse(account),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}, nil
+}
+
+// RevokeServiceAccount revokes an account for good and removes its machine user. Its
+// requests are refused within a minute; its usage stays for the dashboards.
+func (s *serviceAccountService) RevokeServiceAccount(ctx context.Context, id uuid.UUID) error {
+	account, err := s.getActiveAccount(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	account.Status = models.ServiceAccountStatusRevoked
+	account.RevokedAt = &now
+	if err := s.repos.ServiceAccount.Update(ctx, account); err != nil {
+		s.logger.Error("failed to revoke service account", "service_account_id", id, "error", err)
+		return errors.NewServiceError("SERVICE_ACCOUNT_REVOKE_FAILED", "failed to revoke service account", err)
+	}
+
+	// The account is refused once revoked, so a machine user left behind can't act
+	if s.identity != nil && account.ZitadelUserID != "" {
+		if err := s.identity.DeleteUser(ctx, account.ZitadelUserID); err != nil {
+			s.logger.Error("failed to remove machine user of revoked service account", "service_account_id", id, "zitadel_user_id", account.ZitadelUserID, "error", err)
+		}
+	}
+
+	s.logger.Info("service account revoked", "service_account_id", id)
+	return nil
+}
+
+// ============================================================================
+// Usage
+// ============================================================================
+
+// GetUsage returns the account's usage over the period, by day and by resource
+func (s *serviceAccountService) GetUsage(ctx context.Context, id uuid.UUID, from, to time.Time) (*dto.ServiceAccountUsageResponse, error) {
+	account, err := s.getAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(defaultServiceAccountUsageDays - 1))
+	}
+	if from.After(to) {
+		return nil, errors.NewValidationError("from must not be after to")
+	}
+	if to.Sub(from) > maxServiceAccountUsageDays*24*time.Hour {
+		return nil, errors.NewValidationError("usage can be reported for at most 366 days at once")
+	}
+
+	usage, err := s.repos.ServiceAccount.FindUsage(ctx, account.ID, from, to)
+	if err != nil {
+		return nil, errors.NewServiceError("SERVICE_ACCOUNT_USAGE_FAILED", "failed to get service account usage", err)
+	}
+	return dto.ToServiceAccountUsageResponse(account, from, to, usage), nil
+}
+
+// ListScopes returns the actions service accounts may be granted, the same custom
+// roles may grant
+func (s *serviceAccountService) ListScopes() []string {
+	var scopes []string
+	for _, action := range authz.Actions() {
+		if action.IsGrantable() {
+			scopes = append(scopes, string(action))
+		}
+	}
+	return scopes
+}
+
+// ServiceAccountBySubject returns the service account of the Zitadel user, if any
+func (s *serviceAccountService) ServiceAccountBySubject(ctx context.Context, zitadelUserID string) (*models.ServiceAccount, error) {
+	account, err := s.repos.ServiceAccount.FindByZitadelUserID(ctx, zitadelUserID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+// RecordServiceAccountUsage adds a request to the account's usage of its day
+func (s *serviceAccountService) RecordServiceAccountUsage(ctx context.Context, usage *models.ServiceAccountUsage, at time.Time) error {
+	return s.repos.ServiceAccount.RecordUsage(ctx, usage, at)
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// getAccount loads a service account, checking the request's actor may manage its
+// tenant's service accounts, as they may its roles
+func (s *serviceAccountService) getAccount(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("service account ID is required")
+	}
+
+	account, err := s.repos.ServiceAccount.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("service account not found")
+		}
+		return nil, errors.NewServiceError("SERVICE_ACCOUNT_GET_FAILED", "failed to get service account", err)
+	}
+	if err := s.authorizer.Can(ctx, authz.ActionRoleManage, authz.Tenant(account.TenantID)); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// getActiveAccount loads a service account that hasn't been revoked
+func (s *serviceAccountService) getActiveAccount(ctx context.Context, id uuid.UUID) (*models.ServiceAccount, error) {
+	account, err := s.getAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsActive() {
+		return nil, errors.NewConflictError("service account has been revoked")
+	}
+	return account, nil
+}
+
+// checkNameAvailable fails when another of the tenant's service accounts has the name,
+// before a machine user is created for nothing
+func (s *serviceAccountService) checkNameAvailable(ctx context.Context, account *models.ServiceAccount) error {
+	accounts, err := s.repos.ServiceAccount.FindByTenantID(ctx, account.TenantID)
+	if err != nil {
+		return errors.NewServiceError("SERVICE_ACCOUNT_LIST_FAILED", "failed to list service accounts", err)
+	}
+	for _, other := range accounts {
+		if other.ID != account.ID && other.Name == account.Name {
+			return errors.NewConflictError("a service account with this name already exists")
+		}
+	}
+	return nil
+}
+
+func serviceAccountsUnavailable() error {
+	return errors.NewAppError("SERVICE_ACCOUNTS_UNAVAILABLE", "service accounts are not available", http.StatusServiceUnavailable)
+}
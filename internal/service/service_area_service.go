@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// ServiceAreaService manages the regions artisans travel to for mobile services
+type ServiceAreaService interface {
+	// ListServiceAreas lists an artisan's service areas, ordered by name
+	ListServiceAreas(ctx context.Context, tenantID, artisanID uuid.UUID) ([]*dto.ServiceAreaResponse, error)
+
+	// CreateServiceArea adds a service area to an artisan
+	CreateServiceArea(ctx context.Context, req *dto.CreateServiceAreaRequest) (*dto.ServiceAreaResponse, error)
+
+	// UpdateServiceArea changes an artisan's service area
+	UpdateServiceArea(ctx context.Context, tenantID, artisanID, areaID uuid.UUID, req *dto.UpdateServiceAreaRequest) (*dto.ServiceAreaResponse, error)
+
+	// DeleteServiceArea removes an artisan's service area
+	DeleteServiceArea(ctx context.Context, tenantID, artisanID, areaID uuid.UUID) error
+}
+
+// serviceAreaService implements ServiceAreaService
+type serviceAreaService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewServiceAreaService creates a new ServiceAreaService instance
+func NewServiceAreaService(repos *repository.Repositories, logger log.AllLogger) ServiceAreaService {
+	return &serviceAreaService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ListServiceAreas retrieves the service areas of an artisan of the tenant
+func (s *serviceAreaService) ListServiceAreas(ctx context.Context, tenantID, artisanID uuid.UUID) ([]*dto.ServiceAreaResponse, error) {
+	if _, err := s.getArtisan(ctx, tenantID, artisanID); err != nil {
+		return nil, err
+	}
+
+	areas, err := s.repos.ServiceArea.FindByArtisan(ctx, tenantID, artisanID)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to list service areas", err)
+	}
+
+	responses := make([]*dto.ServiceAreaResponse, len(areas))
+	for i, area := range areas {
+		responses[i] = dto.ToServiceAreaResponse(area)
+	}
+	return responses, nil
+}
+
+// CreateServiceArea adds an active service area unless the request says otherwise
+func (s *serviceAreaService) CreateServiceArea(ctx context.Context, req *dto.CreateServiceAreaRequest) (*dto.ServiceAreaResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	artisan, err := s.getArtisan(ctx, req.TenantID, req.ArtisanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, artisan); err != nil {
+		return nil, err
+	}
+
+	area := &models.ServiceArea{
+		TenantID:  req.TenantID,
+		ArtisanID: req.ArtisanID,
+		Name:      strings.TrimSpace(req.Name),
+		Boundary:  req.Boundary,
+		IsActive:  req.IsActive == nil || *req.IsActive,
+	}
+	if err := s.repos.ServiceArea.Create(ctx, area); err != nil {
+		s.logger.Error("failed to create service area", "artisan_id", req.ArtisanID, "error", err)
+		return nil, errors.NewServiceError("CREATE_FAILED", "failed to create service area", err)
+	}
+
+	s.logger.Info("service area created", "area_id", area.ID, "artisan_id", area.ArtisanID)
+	return dto.ToServiceAreaResponse(area), nil
+}
+
+// UpdateServiceArea applies the set fields of the request
+func (s *serviceAreaService) UpdateServiceArea(ctx context.Context, tenantID, artisanID, areaID uuid.UUID, req *dto.UpdateServiceAreaRequest) (*dto.ServiceAreaResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	area, err := s.getServiceArea(ctx, tenantID, artisanID, areaID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil || req.Boundary != nil {
+		if req.Name != nil {
+			area.Name = strings.TrimSpace(*req.Name)
+		}
+		if req.Boundary != nil {
+			area.Boundary = *req.Boundary
+		}
+		if err := s.repos.ServiceArea.Update(ctx, area); err != nil {
+			s.logger.Error("failed to update service area", "area_id", areaID, "error", err)
+			return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update service area", err)
+		}
+	}
+	if req.IsActive != nil && *req.IsActive != area.IsActive {
+		if err := s.repos.ServiceArea.SetActive(ctx, areaID, *req.IsActive); err != nil {
+			s.logger.Error("failed to update service area", "area_id", areaID, "error", err)
+			return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update service area", err)
+		}
+		area.IsActive = *req.IsActive
+	}
+
+	s.logger.Info("service area updated", "area_id", areaID, "artisan_id", artisanID)
+	return dto.ToServiceAreaResponse(area), nil
+}
+
+// DeleteServiceArea deletes the service area
+func (s *serviceAreaService) DeleteServiceArea(ctx context.Context, tenantID, artisanID, areaID uuid.UUID) error {
+	if _, err := s.getServiceArea(ctx, tenantID, artisanID, areaID); err != nil {
+		return err
+	}
+
+	if err := s.repos.ServiceArea.Delete(ctx, areaID); err != nil {
+		s.logger.Error("failed to delete service area", "area_id", areaID, "error", err)
+		return errors.NewServiceError("DELETE_FAILED", "failed to delete service area", err)
+	}
+
+	s.logger.Info("service area deleted", "area_id", areaID, "artisan_id", artisanID)
+	return nil
+}
+
+// getArtisan retrieves an artisan of the tenant
+func (s *serviceAreaService) getArtisan(ctx context.Context, tenantID, artisanID uuid.UUID) (*models.Artisan, error) {
+	artisan, err := s.repos.Artisan.GetByID(ctx, artisanID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("artisan")
+		}
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to get artisan", err)
+	}
+	if artisan.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("artisan")
+	}
+	return artisan, nil
+}
+
+// getServiceArea retrieves a service area of an artisan of the tenant the caller may change
+func (s *serviceAreaService) getServiceArea(ctx context.Context, tenantID, artisanID, areaID uuid.UUID) (*models.ServiceArea, error) {
+	artisan, err := s.getArtisan(ctx, tenantID, artisanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, artisan); err != nil {
+		return nil, err
+	}
+
+	area, err := s.repos.ServiceArea.GetByID(ctx, areaID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("service area")
+		}
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to get service area", err)
+	}
+	if area.TenantID != tenantID || area.ArtisanID != artisanID {
+		return nil, errors.NewNotFoundError("service area")
+	}
+	return area, nil
+}
+
+// authorize lets artisans change only their own service areas; tenant owners and
+// admins change anyone's
+func (s *serviceAreaService) authorize(ctx context.Context, artisan *models.Artisan) error {
+	actor := contextActor(ctx)
+	if actor == nil || actor.ID == artisan.UserID || actor.CanManageTenant(artisan.TenantID) {
+		return nil
+	}
+	return errors.NewForbiddenError("only the artisan or a tenant owner or admin can change service areas")
+}
@@ -6,6 +6,7 @@ import (
 
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/httpcache"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/repository/types"
 	"Krafti_Vibe/internal/service/dto"
@@ -67,27 +68,48 @@ type ServiceService interface {
 	// Advanced Filtering
 	FilterServices(ctx context.Context, tenantID uuid.UUID, filters *dto.ServiceFilterRequest) (*dto.ListServicesResponse, error)
 	GetCategoriesWithCount(ctx context.Context, tenantID uuid.UUID) ([]*dto.CategoryCountResponse, error)
+
+	// Publishing Workflow
+	SaveDraft(ctx context.Context, serviceID uuid.UUID, req *dto.SaveServiceDraftRequest) (*dto.ServiceResponse, error)
+	DiscardDraft(ctx context.Context, serviceID uuid.UUID) error
+	PreviewService(ctx context.Context, serviceID uuid.UUID) (*dto.ServicePreviewResponse, error)
+	PublishService(ctx context.Context, serviceID uuid.UUID, req *dto.PublishServiceRequest) (*dto.ServiceResponse, error)
+	ArchiveService(ctx context.Context, serviceID uuid.UUID) error
+	GetPriceVersions(ctx context.Context, serviceID uuid.UUID) ([]*dto.ServicePriceVersionResponse, error)
+	PublishDue(ctx context.Context) (int, error)
 }
 
+// publicationBatchSize caps the services PublishDue publishes per run
+const publicationBatchSize = 100
+
 // serviceService implements the ServiceService interface
 type serviceService struct {
 	serviceRepo repository.ServiceRepository
+	priceRepo   repository.ServicePriceVersionRepository
 	tenantRepo  repository.TenantRepository
 	userRepo    repository.UserRepository
+	favorites   FavoriteService
+	responses   httpcache.Invalidator
 	logger      log.AllLogger
 }
 
 // NewServiceService creates a new service service instance
 func NewServiceService(
 	serviceRepo repository.ServiceRepository,
+	priceRepo repository.ServicePriceVersionRepository,
 	tenantRepo repository.TenantRepository,
 	userRepo repository.UserRepository,
+	favorites FavoriteService,
+	responses httpcache.Invalidator,
 	logger log.AllLogger,
 ) ServiceService {
 	return &serviceService{
 		serviceRepo: serviceRepo,
+		priceRepo:   priceRepo,
 		tenantRepo:  tenantRepo,
 		userRepo:    userRepo,
+		favorites:   favorites,
+		responses:   responses,
 		logger:      logger,
 	}
 }
@@ -98,6 +120,11 @@ func (s *serviceService) CreateService(ctx context.Context, req *dto.CreateServi
 	if err := req.Validate(); err != nil {
 		return nil, errors.NewValidationError("invalid service data")
 	}
+	if req.IntakeForm != nil {
+		if err := req.IntakeForm.Validate(); err != nil {
+			return nil, errors.NewValidationError("invalid intake form: " + err.Error())
+		}
+	}
 
 	// Verify tenant exists
 	tenant, err := s.tenantRepo.GetByID(ctx, req.TenantID)
@@ -135,14 +162,25 @@ func (s *serviceService) CreateService(ctx context.Context, req *dto.CreateServi
 		Price:           req.Price,
 		Currency:        req.Currency,
 		DepositAmount:   req.DepositAmount,
+		TaxCategory:     req.TaxCategory,
 		DurationMinutes: req.DurationMinutes,
 		BufferMinutes:   req.BufferMinutes,
 		IsActive:        req.IsActive,
 		MaxBookingsDay:  req.MaxBookingsDay,
 		ImageURL:        req.ImageURL,
 		RequiresDeposit: req.RequiresDeposit,
+		IsMobile:        req.IsMobile,
 		Tags:            req.Tags,
 		Metadata:        req.Metadata,
+		Status:          req.Status,
+		PublishAt:       req.PublishAt,
+	}
+	if req.IntakeForm != nil && len(req.IntakeForm.Fields) > 0 {
+		service.IntakeForm = req.IntakeForm
+	}
+	if service.Status == models.ServiceStatusPublished {
+		now := time.Now()
+		service.PublishedAt = &now
 	}
 
 	// Create service
@@ -151,7 +189,16 @@ func (s *serviceService) CreateService(ctx context.Context, req *dto.CreateServi
 		return nil, errors.NewInternalError("failed to create service", err)
 	}
 
+	// Published services start with their first price version
+	if err := s.savePriceVersion(ctx, service); err != nil {
+		return nil, err
+	}
+
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service created successfully", "service_id", service.ID, "tenant_id", req.TenantID)
+	if service.Status == models.ServiceStatusPublished {
+		s.notifyFavorites(ctx, service)
+	}
 
 	return s.toServiceResponse(service), nil
 }
@@ -203,6 +250,12 @@ func (s *serviceService) UpdateService(ctx context.Context, serviceID uuid.UUID,
 	if req.Currency != nil {
 		(*service).Currency = *req.Currency
 	}
+	if req.TaxCategory != nil {
+		if !req.TaxCategory.IsValid() {
+			return nil, errors.NewValidationError("invalid tax category")
+		}
+		(*service).TaxCategory = *req.TaxCategory
+	}
 	if req.DepositAmount != nil {
 		if *req.DepositAmount < 0 {
 			return nil, errors.NewValidationError("deposit amount cannot be negative")
@@ -230,19 +283,37 @@ func (s *serviceService) UpdateService(ctx context.Context, serviceID uuid.UUID,
 	if req.RequiresDeposit != nil {
 		(*service).RequiresDeposit = *req.RequiresDeposit
 	}
+	if req.IsMobile != nil {
+		(*service).IsMobile = *req.IsMobile
+	}
 	if req.Tags != nil {
 		(*service).Tags = req.Tags
 	}
+	if req.IntakeForm != nil {
+		if err := req.IntakeForm.Validate(); err != nil {
+			return nil, errors.NewValidationError("invalid intake form: " + err.Error())
+		}
+		(*service).IntakeForm = req.IntakeForm
+		if len(req.IntakeForm.Fields) == 0 {
+			(*service).IntakeForm = nil
+		}
+	}
 	if req.Metadata != nil {
 		(*service).Metadata = req.Metadata
 	}
 
+	// A changed price of a published service takes effect as a new price version
+	if _, err := s.versionPrice(ctx, service); err != nil {
+		return nil, err
+	}
+
 	// Update service
 	if err := s.serviceRepo.Update(ctx, service); err != nil {
 		s.logger.Error("failed to update service", "service_id", serviceID, "error", err)
 		return nil, errors.NewInternalError("failed to update service", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service updated successfully", "service_id", serviceID)
 
 	return s.toServiceResponse(service), nil
@@ -261,11 +332,12 @@ func (s *serviceService) DeleteService(ctx context.Context, serviceID uuid.UUID)
 	}
 
 	// Soft delete
-	if err := s.serviceRepo.Delete(ctx, serviceID); err != nil {
+	if err := s.serviceRepo.SoftDelete(ctx, serviceID); err != nil {
 		s.logger.Error("failed to delete service", "service_id", serviceID, "error", err)
 		return errors.NewInternalError("failed to delete service", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service deleted successfully", "service_id", serviceID, "tenant_id", (*service).TenantID)
 
 	return nil
@@ -518,6 +590,7 @@ func (s *serviceService) ActivateService(ctx context.Context, serviceID uuid.UUI
 		return errors.NewInternalError("failed to activate service", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service activated", "service_id", serviceID)
 	return nil
 }
@@ -533,6 +606,7 @@ func (s *serviceService) DeactivateService(ctx context.Context, serviceID uuid.U
 		return errors.NewInternalError("failed to deactivate service", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service deactivated", "service_id", serviceID)
 	return nil
 }
@@ -548,6 +622,7 @@ func (s *serviceService) ToggleServiceAvailability(ctx context.Context, serviceI
 		return errors.NewInternalError("failed to toggle service availability", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service availability toggled", "service_id", serviceID, "is_active", isActive)
 	return nil
 }
@@ -566,7 +641,11 @@ func (s *serviceService) UpdateServicePrice(ctx context.Context, serviceID uuid.
 		s.logger.Error("failed to update service price", "service_id", serviceID, "error", err)
 		return errors.NewInternalError("failed to update service price", err)
 	}
+	if err := s.versionPrices(ctx, serviceID); err != nil {
+		return err
+	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service price updated", "service_id", serviceID, "new_price", newPrice)
 	return nil
 }
@@ -585,7 +664,11 @@ func (s *serviceService) UpdateServiceDeposit(ctx context.Context, serviceID uui
 		s.logger.Error("failed to update service deposit", "service_id", serviceID, "error", err)
 		return errors.NewInternalError("failed to update service deposit", err)
 	}
+	if err := s.versionPrices(ctx, serviceID); err != nil {
+		return err
+	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service deposit updated", "service_id", serviceID, "deposit_amount", depositAmount)
 	return nil
 }
@@ -600,7 +683,11 @@ func (s *serviceService) BulkUpdatePrices(ctx context.Context, req *dto.BulkPric
 		s.logger.Error("failed to bulk update prices", "error", err)
 		return errors.NewInternalError("failed to bulk update prices", err)
 	}
+	if err := s.versionPrices(ctx, req.ServiceIDs...); err != nil {
+		return err
+	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("bulk price update completed", "service_count", len(req.ServiceIDs))
 	return nil
 }
@@ -616,6 +703,7 @@ func (s *serviceService) AddServiceAddon(ctx context.Context, serviceID, addonID
 		return errors.NewInternalError("failed to add service addon", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service addon added", "service_id", serviceID, "addon_id", addonID)
 	return nil
 }
@@ -631,6 +719,7 @@ func (s *serviceService) RemoveServiceAddon(ctx context.Context, serviceID, addo
 		return errors.NewInternalError("failed to remove service addon", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("service addon removed", "service_id", serviceID, "addon_id", addonID)
 	return nil
 }
@@ -650,14 +739,17 @@ func (s *serviceService) GetServiceAddons(ctx context.Context, serviceID uuid.UU
 	responses := make([]*dto.ServiceAddonResponse, len(addons))
 	for i, addon := range addons {
 		responses[i] = &dto.ServiceAddonResponse{
-			ID:          addon.ID,
-			TenantID:    addon.TenantID,
-			Name:        addon.Name,
-			Description: addon.Description,
-			Price:       addon.Price,
-			IsActive:    addon.IsActive,
-			CreatedAt:   addon.CreatedAt,
-			UpdatedAt:   addon.UpdatedAt,
+			ID:              addon.ID,
+			TenantID:        addon.TenantID,
+			Name:            addon.Name,
+			Description:     addon.Description,
+			Price:           addon.Price,
+			PricingType:     addon.PricingType,
+			DurationMinutes: addon.DurationMinutes,
+			MaxQuantity:     addon.MaxQuantity,
+			IsActive:        addon.IsActive,
+			CreatedAt:       addon.CreatedAt,
+			UpdatedAt:       addon.UpdatedAt,
 		}
 	}
 
@@ -820,6 +912,7 @@ func (s *serviceService) BulkActivateServices(ctx context.Context, serviceIDs []
 		return errors.NewInternalError("failed to bulk activate services", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("bulk activate completed", "service_count", len(serviceIDs))
 	return nil
 }
@@ -835,6 +928,7 @@ func (s *serviceService) BulkDeactivateServices(ctx context.Context, serviceIDs
 		return errors.NewInternalError("failed to bulk deactivate services", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("bulk deactivate completed", "service_count", len(serviceIDs))
 	return nil
 }
@@ -850,6 +944,7 @@ func (s *serviceService) BulkUpdateCategory(ctx context.Context, serviceIDs []uu
 		return errors.NewInternalError("failed to bulk update category", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("bulk category update completed", "service_count", len(serviceIDs), "category", category)
 	return nil
 }
@@ -865,6 +960,7 @@ func (s *serviceService) BulkDeleteServices(ctx context.Context, serviceIDs []uu
 		return errors.NewInternalError("failed to bulk delete services", err)
 	}
 
+	s.responses.Invalidate(ctx, httpcache.TagServices)
 	s.logger.Info("bulk delete completed", "service_count", len(serviceIDs))
 	return nil
 }
@@ -886,6 +982,7 @@ func (s *serviceService) FilterServices(ctx context.Context, tenantID uuid.UUID,
 		MinDuration:     filters.MinDuration,
 		MaxDuration:     filters.MaxDuration,
 		IsActive:        filters.IsActive,
+		Status:          filters.Status,
 		ArtisanID:       filters.ArtisanID,
 		Tags:            filters.Tags,
 		RequiresDeposit: filters.RequiresDeposit,
@@ -934,8 +1031,293 @@ func (s *serviceService) GetCategoriesWithCount(ctx context.Context, tenantID uu
 	return responses, nil
 }
 
+// ============================================================================
+// Publishing Workflow
+// ============================================================================
+
+// SaveDraft replaces the pending changes of a published service, which customers
+// don't see until they are published. Draft services are edited directly instead.
+func (s *serviceService) SaveDraft(ctx context.Context, serviceID uuid.UUID, req *dto.SaveServiceDraftRequest) (*dto.ServiceResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid draft: " + err.Error())
+	}
+
+	service, err := s.getService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if service.Status != models.ServiceStatusPublished {
+		return nil, errors.NewConflictError("only published services keep pending changes; edit " + string(service.Status) + " services directly")
+	}
+
+	changes := req.ServiceChanges
+	service.PendingChanges = &changes
+	service.PublishAt = req.PublishAt
+	if err := s.serviceRepo.Update(ctx, service); err != nil {
+		s.logger.Error("failed to save service draft", "service_id", serviceID, "error", err)
+		return nil, errors.NewInternalError("failed to save service draft", err)
+	}
+
+	s.logger.Info("service draft saved", "service_id", serviceID, "publish_at", req.PublishAt)
+	return s.toServiceResponse(service), nil
+}
+
+// DiscardDraft drops the pending changes of a service and their scheduled publication
+func (s *serviceService) DiscardDraft(ctx context.Context, serviceID uuid.UUID) error {
+	service, err := s.getService(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	if service.PendingChanges == nil {
+		return errors.NewNotFoundError("pending changes")
+	}
+
+	service.PendingChanges = nil
+	service.PublishAt = nil
+	if err := s.serviceRepo.Update(ctx, service); err != nil {
+		s.logger.Error("failed to discard service draft", "service_id", serviceID, "error", err)
+		return errors.NewInternalError("failed to discard service draft", err)
+	}
+
+	s.logger.Info("service draft discarded", "service_id", serviceID)
+	return nil
+}
+
+// PreviewService shows the service as publishing it now would leave it, next to the
+// version customers currently see
+func (s *serviceService) PreviewService(ctx context.Context, serviceID uuid.UUID) (*dto.ServicePreviewResponse, error) {
+	service, err := s.getService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	proposed := *service
+	changed := []string{}
+	if service.PendingChanges != nil {
+		changed = service.PendingChanges.Apply(&proposed)
+	}
+	proposed.Status = models.ServiceStatusPublished
+	proposed.PendingChanges = nil
+	proposed.PublishAt = nil
+
+	preview := &dto.ServicePreviewResponse{
+		Proposed:      s.toServiceResponse(&proposed),
+		ChangedFields: changed,
+		PriceChanged:  service.PriceVersionID == nil || !pricingMatches(service, &proposed),
+		PublishAt:     service.PublishAt,
+	}
+	if service.Status == models.ServiceStatusPublished {
+		preview.Current = s.toServiceResponse(service)
+	}
+	return preview, nil
+}
+
+// PublishService publishes a draft or archived service, or the pending changes of a
+// published one, now or at the time requested
+func (s *serviceService) PublishService(ctx context.Context, serviceID uuid.UUID, req *dto.PublishServiceRequest) (*dto.ServiceResponse, error) {
+	service, err := s.getService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if service.Status == models.ServiceStatusPublished && service.PendingChanges == nil {
+		return nil, errors.NewConflictError("service has no changes to publish")
+	}
+
+	now := time.Now()
+	if req != nil && req.PublishAt != nil && req.PublishAt.After(now) {
+		service.PublishAt = req.PublishAt
+		if err := s.serviceRepo.Update(ctx, service); err != nil {
+			s.logger.Error("failed to schedule service publication", "service_id", serviceID, "error", err)
+			return nil, errors.NewInternalError("failed to schedule service publication", err)
+		}
+		s.logger.Info("service publication scheduled", "service_id", serviceID, "publish_at", *req.PublishAt)
+		return s.toServiceResponse(service), nil
+	}
+
+	if err := s.publish(ctx, service, now); err != nil {
+		return nil, err
+	}
+	return s.toServiceResponse(service), nil
+}
+
+// ArchiveService withdraws a service from the catalog; bookings referencing it keep it
+func (s *serviceService) ArchiveService(ctx context.Context, serviceID uuid.UUID) error {
+	service, err := s.getService(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	if service.Status == models.ServiceStatusArchived {
+		return nil
+	}
+
+	service.Status = models.ServiceStatusArchived
+	service.PendingChanges = nil
+	service.PublishAt = nil
+	if err := s.serviceRepo.Update(ctx, service); err != nil {
+		s.logger.Error("failed to archive service", "service_id", serviceID, "error", err)
+		return errors.NewInternalError("failed to archive service", err)
+	}
+
+	s.responses.Invalidate(ctx, httpcache.TagServices)
+	s.logger.Info("service archived", "service_id", serviceID)
+	return nil
+}
+
+// GetPriceVersions lists the prices a service was published at, newest first
+func (s *serviceService) GetPriceVersions(ctx context.Context, serviceID uuid.UUID) ([]*dto.ServicePriceVersionResponse, error) {
+	if _, err := s.getService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.priceRepo.FindByServiceID(ctx, serviceID)
+	if err != nil {
+		s.logger.Error("failed to get service price versions", "service_id", serviceID, "error", err)
+		return nil, errors.NewInternalError("failed to get service price versions", err)
+	}
+
+	responses := make([]*dto.ServicePriceVersionResponse, len(versions))
+	for i, version := range versions {
+		responses[i] = &dto.ServicePriceVersionResponse{
+			ID:            version.ID,
+			ServiceID:     version.ServiceID,
+			Number:        version.Number,
+			Price:         version.Price,
+			Currency:      version.Currency,
+			DepositAmount: version.DepositAmount,
+			TaxCategory:   version.TaxCategory,
+			EffectiveFrom: version.EffectiveFrom,
+			EffectiveTo:   version.EffectiveTo,
+		}
+	}
+	return responses, nil
+}
+
+// PublishDue publishes the draft services and pending changes whose publish time has
+// passed, returning how many were published
+func (s *serviceService) PublishDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	services, err := s.serviceRepo.FindDueForPublication(ctx, now, publicationBatchSize)
+	if err != nil {
+		return 0, errors.NewServiceError("SERVICE_PUBLICATION_FAILED", "failed to find services due for publication", err)
+	}
+
+	published := 0
+	for _, service := range services {
+		if err := s.publish(ctx, service, now); err != nil {
+			s.logger.Error("failed to publish scheduled service", "service_id", service.ID, "error", err)
+			continue
+		}
+		published++
+	}
+	return published, nil
+}
+
 // Helper methods
 
+// getService loads a service by ID
+func (s *serviceService) getService(ctx context.Context, serviceID uuid.UUID) (*models.Service, error) {
+	if serviceID == uuid.Nil {
+		return nil, errors.NewValidationError("service ID is required")
+	}
+
+	service, err := s.serviceRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		s.logger.Error("failed to find service", "service_id", serviceID, "error", err)
+		return nil, errors.NewNotFoundError("service not found")
+	}
+	return service, nil
+}
+
+// publish applies the service's pending changes and makes it published, starting a new
+// price version when its pricing changed
+func (s *serviceService) publish(ctx context.Context, service *models.Service, now time.Time) error {
+	if service.PendingChanges != nil {
+		service.PendingChanges.Apply(service)
+		service.PendingChanges = nil
+	}
+	firstPublication := service.PublishedAt == nil
+	service.Status = models.ServiceStatusPublished
+	service.PublishAt = nil
+	service.PublishedAt = &now
+
+	if _, err := s.versionPrice(ctx, service); err != nil {
+		return err
+	}
+	if err := s.serviceRepo.Update(ctx, service); err != nil {
+		s.logger.Error("failed to publish service", "service_id", service.ID, "error", err)
+		return errors.NewInternalError("failed to publish service", err)
+	}
+
+	s.responses.Invalidate(ctx, httpcache.TagServices)
+	s.logger.Info("service published", "service_id", service.ID, "price_version_id", service.PriceVersionID)
+	if firstPublication {
+		s.notifyFavorites(ctx, service)
+	}
+	return nil
+}
+
+// notifyFavorites tells the customers following the service's artisan that it is new
+func (s *serviceService) notifyFavorites(ctx context.Context, service *models.Service) {
+	if _, err := s.favorites.NotifyNewService(ctx, service); err != nil {
+		s.logger.Error("failed to notify favorites of new service", "service_id", service.ID, "error", err)
+	}
+}
+
+// versionPrice records the pricing of a published service as its price version in
+// effect, so bookings made from now on reference it while earlier ones keep theirs. It
+// reports whether the service's PriceVersionID changed and needs saving.
+func (s *serviceService) versionPrice(ctx context.Context, service *models.Service) (bool, error) {
+	if service.Status != models.ServiceStatusPublished {
+		return false, nil
+	}
+
+	version, err := s.priceRepo.Record(ctx, service, time.Now())
+	if err != nil {
+		s.logger.Error("failed to record service price version", "service_id", service.ID, "error", err)
+		return false, errors.NewInternalError("failed to record service price version", err)
+	}
+	if service.PriceVersionID != nil && *service.PriceVersionID == version.ID {
+		return false, nil
+	}
+	service.PriceVersionID = &version.ID
+	return true, nil
+}
+
+// savePriceVersion versions the service's price and saves the reference to the version
+func (s *serviceService) savePriceVersion(ctx context.Context, service *models.Service) error {
+	changed, err := s.versionPrice(ctx, service)
+	if err != nil || !changed {
+		return err
+	}
+	if err := s.serviceRepo.Update(ctx, service); err != nil {
+		s.logger.Error("failed to update service price version", "service_id", service.ID, "error", err)
+		return errors.NewInternalError("failed to update service price version", err)
+	}
+	return nil
+}
+
+// versionPrices versions the prices of services whose pricing was updated in place
+func (s *serviceService) versionPrices(ctx context.Context, serviceIDs ...uuid.UUID) error {
+	for _, serviceID := range serviceIDs {
+		service, err := s.getService(ctx, serviceID)
+		if err != nil {
+			return err
+		}
+		if err := s.savePriceVersion(ctx, service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pricingMatches reports whether two versions of a service are priced alike
+func pricingMatches(a, b *models.Service) bool {
+	return a.Price == b.Price &&
+		a.Currency == b.Currency &&
+		a.DepositAmount == b.DepositAmount &&
+		a.TaxCategory == b.TaxCategory
+}
+
 func (s *serviceService) toServiceResponse(service *models.Service) *dto.ServiceResponse {
 	if service == nil {
 		return nil
@@ -951,6 +1333,7 @@ func (s *serviceService) toServiceResponse(service *models.Service) *dto.Service
 		Price:           service.Price,
 		Currency:        service.Currency,
 		DepositAmount:   service.DepositAmount,
+		TaxCategory:     service.TaxCategory,
 		DurationMinutes: service.DurationMinutes,
 		BufferMinutes:   service.BufferMinutes,
 		TotalDuration:   service.GetTotalDuration(),
@@ -958,8 +1341,18 @@ func (s *serviceService) toServiceResponse(service *models.Service) *dto.Service
 		MaxBookingsDay:  service.MaxBookingsDay,
 		ImageURL:        service.ImageURL,
 		RequiresDeposit: service.RequiresDeposit,
+		IsMobile:        service.IsMobile,
 		Tags:            service.Tags,
+		IntakeForm:      service.IntakeForm,
 		Metadata:        service.Metadata,
+		Status:          service.Status,
+		PublishAt:       service.PublishAt,
+		PublishedAt:     service.PublishedAt,
+		PendingChanges:  service.PendingChanges,
+		PriceVersionID:  service.PriceVersionID,
+		Rating:          service.Rating,
+		ReviewCount:     service.ReviewCount,
+		FavoriteCount:   service.FavoriteCount,
 		CreatedAt:       service.CreatedAt,
 		UpdatedAt:       service.UpdatedAt,
 	}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// matchCandidateLimit caps the artisans scored for a service request; they come best
+// covering and rated first, so the ones left out would rank low anyway
+const matchCandidateLimit = 200
+
+// SkillService manages the tenant's skill taxonomy, the skills artisans are tagged with,
+// and matching service requests to artisans by them
+type SkillService interface {
+	// ListSkills lists the tenant's skills, optionally only one category's or the active ones
+	ListSkills(ctx context.Context, tenantID uuid.UUID, category models.ServiceCategory, activeOnly bool) ([]*dto.SkillResponse, error)
+
+	// CreateSkill adds a skill to the tenant's taxonomy
+	CreateSkill(ctx context.Context, req *dto.CreateSkillRequest) (*dto.SkillResponse, error)
+
+	// UpdateSkill changes a skill of the tenant's taxonomy
+	UpdateSkill(ctx context.Context, tenantID, skillID uuid.UUID, req *dto.UpdateSkillRequest) (*dto.SkillResponse, error)
+
+	// DeleteSkill removes a skill from the tenant's taxonomy
+	DeleteSkill(ctx context.Context, tenantID, skillID uuid.UUID) error
+
+	// ListArtisanSkills lists the skills an artisan is tagged with
+	ListArtisanSkills(ctx context.Context, tenantID, artisanID uuid.UUID) ([]*dto.ArtisanSkillResponse, error)
+
+	// SetArtisanSkills replaces the skills an artisan is tagged with
+	SetArtisanSkills(ctx context.Context, tenantID, artisanID uuid.UUID, req *dto.SetArtisanSkillsRequest) ([]*dto.ArtisanSkillResponse, error)
+
+	// MatchArtisans ranks the tenant's artisans for a service request by the skills it
+	// mentions, their rating, proximity and availability
+	MatchArtisans(ctx context.Context, req *dto.MatchArtisansRequest) (*dto.MatchArtisansResponse, error)
+}
+
+// skillService implements SkillService
+type skillService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewSkillService creates a new SkillService instance
+func NewSkillService(repos *repository.Repositories, logger log.AllLogger) SkillService {
+	return &skillService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ListSkills retrieves the tenant's skills by category and name
+func (s *skillService) ListSkills(ctx context.Context, tenantID uuid.UUID, category models.ServiceCategory, activeOnly bool) ([]*dto.SkillResponse, error) {
+	skills, err := s.repos.Skill.FindByTenant(ctx, tenantID, category, activeOnly)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to list skills", err)
+	}
+
+	return dto.ToSkillResponses(skills), nil
+}
+
+// CreateSkill adds an active skill unless the request says otherwise. Names are unique
+// within the tenant by their slug.
+func (s *skillService) CreateSkill(ctx context.Context, req *dto.CreateSkillRequest) (*dto.SkillResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	skill := &models.Skill{
+		TenantID:    req.TenantID,
+		Category:    req.Category,
+		Name:        req.Name,
+		Description: strings.TrimSpace(req.Description),
+		Keywords:    req.Keywords,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+	}
+	if err := skill.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.ensureSlugAvailable(ctx, skill); err != nil {
+		return nil, err
+	}
+	if err := s.repos.Skill.Create(ctx, skill); err != nil {
+		if errors.IsDuplicate(err) {
+			return nil, errors.NewConflictError("a skill with this name already exists")
+		}
+		s.logger.Error("failed to create skill", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("CREATE_FAILED", "failed to create skill", err)
+	}
+
+	s.logger.Info("skill created", "skill_id", skill.ID, "tenant_id", skill.TenantID)
+	return dto.ToSkillResponse(skill), nil
+}
+
+// UpdateSkill applies the set fields of the request
+func (s *skillService) UpdateSkill(ctx context.Context, tenantID, skillID uuid.UUID, req *dto.UpdateSkillRequest) (*dto.SkillResponse, error) {
+	skill, err := s.getSkill(ctx, tenantID, skillID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug := skill.Slug
+	if req.Category != nil {
+		skill.Category = *req.Category
+	}
+	if req.Name != nil {
+		skill.Name = *req.Name
+	}
+	if req.Description != nil {
+		skill.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Keywords != nil {
+		skill.Keywords = req.Keywords
+	}
+	if req.IsActive != nil {
+		skill.IsActive = *req.IsActive
+	}
+	if err := skill.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if skill.Slug != slug {
+		if err := s.ensureSlugAvailable(ctx, skill); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repos.Skill.Update(ctx, skill); err != nil {
+		if errors.IsDuplicate(err) {
+			return nil, errors.NewConflictError("a skill with this name already exists")
+		}
+		s.logger.Error("failed to update skill", "skill_id", skillID, "error", err)
+		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to update skill", err)
+	}
+
+	s.logger.Info("skill updated", "skill_id", skillID, "tenant_id", tenantID)
+	return dto.ToSkillResponse(skill), nil
+}
+
+// DeleteSkill deletes the skill. Artisans keep their tags of it, but they no longer
+// show or match; deactivating the skill instead keeps them shown.
+func (s *skillService) DeleteSkill(ctx context.Context, tenantID, skillID uuid.UUID) error {
+	if _, err := s.getSkill(ctx, tenantID, skillID); err != nil {
+		return err
+	}
+
+	if err := s.repos.Skill.Delete(ctx, skillID); err != nil {
+		s.logger.Error("failed to delete skill", "skill_id", skillID, "error", err)
+		return errors.NewServiceError("DELETE_FAILED", "failed to delete skill", err)
+	}
+
+	s.logger.Info("skill deleted", "skill_id", skillID, "tenant_id", tenantID)
+	return nil
+}
+
+// ListArtisanSkills retrieves the skills of an artisan of the tenant, ordered by name
+func (s *skillService) ListArtisanSkills(ctx context.Context, tenantID, artisanID uuid.UUID) ([]*dto.ArtisanSkillResponse, error) {
+	if _, err := s.getArtisan(ctx, tenantID, artisanID); err != nil {
+		return nil, err
+	}
+
+	skills, err := s.repos.Skill.FindArtisanSkills(ctx, artisanID)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to list artisan skills", err)
+	}
+
+	return dto.ToArtisanSkillResponses(skills), nil
+}
+
+// SetArtisanSkills replaces the artisan's skills with the requested ones, which must be
+// active skills of the tenant's taxonomy
+func (s *skillService) SetArtisanSkills(ctx context.Context, tenantID, artisanID uuid.UUID, req *dto.SetArtisanSkillsRequest) ([]*dto.ArtisanSkillResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	artisan, err := s.getArtisan(ctx, tenantID, artisanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, artisan); err != nil {
+		return nil, err
+	}
+
+	taxonomy, err := s.repos.Skill.FindByTenant(ctx, tenantID, "", true)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to list skills", err)
+	}
+	byID := make(map[uuid.UUID]*models.Skill, len(taxonomy))
+	for _, skill := range taxonomy {
+		byID[skill.ID] = skill
+	}
+
+	skills := make([]*models.ArtisanSkill, len(req.Skills))
+	for i, input := range req.Skills {
+		skill := &models.ArtisanSkill{
+			TenantID:        tenantID,
+			ArtisanID:       artisanID,
+			SkillID:         input.SkillID,
+			Proficiency:     input.Proficiency,
+			YearsExperience: input.YearsExperience,
+		}
+		if err := skill.Validate(); err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+		if skill.Skill = byID[input.SkillID]; skill.Skill == nil {
+			return nil, errors.NewValidationError("skill " + input.SkillID.String() + " is not an active skill of the tenant")
+		}
+		skills[i] = skill
+	}
+
+	if err := s.repos.Skill.ReplaceArtisanSkills(ctx, tenantID, artisanID, skills); err != nil {
+		s.logger.Error("failed to set artisan skills", "artisan_id", artisanID, "error", err)
+		return nil, errors.NewServiceError("UPDATE_FAILED", "failed to set artisan skills", err)
+	}
+
+	slices.SortFunc(skills, func(a, b *models.ArtisanSkill) int {
+		return strings.Compare(a.Skill.Name, b.Skill.Name)
+	})
+
+	s.logger.Info("artisan skills set", "artisan_id", artisanID, "skills", len(skills))
+	return dto.ToArtisanSkillResponses(skills), nil
+}
+
+// MatchArtisans finds the active skills the request's description mentions, then scores
+// the artisans tagged with any of them. A description mentioning no skill matches no one.
+func (s *skillService) MatchArtisans(ctx context.Context, req *dto.MatchArtisansRequest) (*dto.MatchArtisansResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	taxonomy, err := s.repos.Skill.FindByTenant(ctx, req.TenantID, req.Category, true)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to list skills", err)
+	}
+	matches := models.MatchSkills(req.Description, taxonomy)
+
+	resp := &dto.MatchArtisansResponse{
+		MatchedSkills: make([]*dto.MatchedSkillResponse, len(matches)),
+		Artisans:      []*dto.ArtisanMatchResponse{},
+	}
+	skillIDs := make([]uuid.UUID, len(matches))
+	for i, match := range matches {
+		skillIDs[i] = match.Skill.ID
+		resp.MatchedSkills[i] = &dto.MatchedSkillResponse{
+			SkillID:   match.Skill.ID,
+			Name:      match.Skill.Name,
+			Relevance: match.Relevance,
+		}
+	}
+	if len(matches) == 0 {
+		return resp, nil
+	}
+
+	candidates, err := s.repos.Skill.FindMatchCandidates(ctx, req.TenantID, repository.SkillMatchQuery{
+		SkillIDs:  skillIDs,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		RadiusKm:  req.RadiusKm,
+		Date:      req.Date,
+		Limit:     matchCandidateLimit,
+	})
+	if err != nil {
+		s.logger.Error("failed to find artisans to match", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to match artisans", err)
+	}
+
+	skillsByID := make(map[uuid.UUID]*models.Skill, len(matches))
+	for _, match := range matches {
+		skillsByID[match.Skill.ID] = match.Skill
+	}
+	for _, candidate := range candidates {
+		proficiencies := make(map[uuid.UUID]models.SkillProficiency, len(candidate.Skills))
+		skills := make([]*dto.ArtisanSkillResponse, 0, len(candidate.Skills))
+		for _, skill := range candidate.Skills {
+			proficiencies[skill.SkillID] = skill.Proficiency
+			skill.Skill = skillsByID[skill.SkillID]
+			skills = append(skills, dto.ToArtisanSkillResponse(skill))
+		}
+
+		score := models.ScoreArtisanMatch(models.ArtisanMatchFactors{
+			SkillCoverage: models.SkillCoverage(matches, proficiencies),
+			Rating:        candidate.Artisan.Rating,
+			ReviewCount:   candidate.Artisan.ReviewCount,
+			DistanceKm:    candidate.DistanceKm,
+			RadiusKm:      req.RadiusKm,
+			IsAvailable:   candidate.Artisan.IsAvailable,
+			HasOpenings:   candidate.HasOpenings,
+		})
+		resp.Artisans = append(resp.Artisans, &dto.ArtisanMatchResponse{
+			ArtisanResponse: dto.ToArtisanResponse(candidate.Artisan),
+			DistanceKm:      candidate.DistanceKm,
+			Score:           score.Total,
+			Breakdown: dto.ArtisanMatchBreakdown{
+				Skills:       score.Skills,
+				Rating:       score.Rating,
+				Proximity:    score.Proximity,
+				Availability: score.Availability,
+			},
+			Skills: skills,
+		})
+	}
+
+	slices.SortStableFunc(resp.Artisans, func(a, b *dto.ArtisanMatchResponse) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	if len(resp.Artisans) > req.Limit {
+		resp.Artisans = resp.Artisans[:req.Limit]
+	}
+	return resp, nil
+}
+
+// ensureSlugAvailable checks no other skill of the tenant has the skill's slug
+func (s *skillService) ensureSlugAvailable(ctx context.Context, skill *models.Skill) error {
+	skills, err := s.repos.Skill.FindByTenant(ctx, skill.TenantID, "", false)
+	if err != nil {
+		return errors.NewServiceError("QUERY_FAILED", "failed to list skills", err)
+	}
+	for _, other := range skills {
+		if other.Slug == skill.Slug && other.ID != skill.ID {
+			return errors.NewConflictError("a skill with this name already exists")
+		}
+	}
+	return nil
+}
+
+// getSkill retrieves a skill of the tenant
+func (s *skillService) getSkill(ctx context.Context, tenantID, skillID uuid.UUID) (*models.Skill, error) {
+	skill, err := s.repos.Skill.GetByID(ctx, skillID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("skill")
+		}
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to get skill", err)
+	}
+	if skill.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("skill")
+	}
+	return skill, nil
+}
+
+// getArtisan retrieves an artisan of the tenant
+func (s *skillService) getArtisan(ctx context.Context, tenantID, artisanID uuid.UUID) (*models.Artisan, error) {
+	artisan, err := s.repos.Artisan.GetByID(ctx, artisanID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("artisan")
+		}
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to get artisan", err)
+	}
+	if artisan.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("artisan")
+	}
+	return artisan, nil
+}
+
+// authorize lets artisans change only their own skills; tenant owners and admins change
+// anyone's
+func (s *skillService) authorize(ctx context.Context, artisan *models.Artisan) error {
+	actor := contextActor(ctx)
+	if actor == nil || actor.ID == artisan.UserID || actor.CanManageTenant(artisan.TenantID) {
+		return nil
+	}
+	return errors.NewForbiddenError("only the artisan or a tenant owner or admin can change their skills")
+}
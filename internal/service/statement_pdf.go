@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/pdf"
+)
+
+// Statement layout, in points
+const (
+	statementMargin      = 50.0
+	statementRight       = pdf.PageWidth - statementMargin
+	statementBottom      = pdf.PageHeight - 70
+	statementRowHeight   = 18.0
+	statementFontSize    = 9.0
+	statementTypeLeft    = 190.0
+	statementAmountRight = 360.0
+	statementFeeRight    = 450.0
+)
+
+// statementRenderer lays out a statement top to bottom, starting a new page when a
+// page is full
+type statementRenderer struct {
+	doc       *pdf.Document
+	page      *pdf.Page
+	y         float64
+	statement *models.FinancialStatement
+}
+
+// renderStatementPDF renders a statement of payments issued by tenant; subject names
+// the artisan of an earnings statement
+func renderStatementPDF(statement *models.FinancialStatement, tenant *models.Tenant, subject string, payments []*models.Payment) []byte {
+	r := &statementRenderer{
+		doc:       pdf.New(statementTitle(statement) + " " + statement.PeriodStart.Format("January 2006")),
+		statement: statement,
+	}
+	r.newPage()
+
+	r.header(tenant, subject)
+	r.totals()
+	r.payments(payments)
+
+	return r.doc.Bytes()
+}
+
+func statementTitle(statement *models.FinancialStatement) string {
+	if statement.Kind == models.StatementKindArtisan {
+		return "Earnings Statement"
+	}
+	return "Revenue Statement"
+}
+
+func (r *statementRenderer) newPage() {
+	r.page = r.doc.AddPage()
+	r.y = statementMargin
+}
+
+// ensure starts a new page unless height points still fit on this one
+func (r *statementRenderer) ensure(height float64) bool {
+	if r.y+height <= statementBottom {
+		return false
+	}
+	r.newPage()
+	return true
+}
+
+func (r *statementRenderer) header(tenant *models.Tenant, subject string) {
+	p := r.page
+	p.Text(statementMargin, r.y+20, pdf.Bold, 18, statementTitle(r.statement))
+
+	businessName := tenant.BusinessName
+	if businessName == "" {
+		businessName = tenant.Name
+	}
+	p.TextRight(statementRight, r.y+12, pdf.Bold, 12, businessName)
+	if tenant.BusinessEmail != "" {
+		p.TextRight(statementRight, r.y+26, pdf.Regular, statementFontSize, tenant.BusinessEmail)
+	}
+
+	r.y += 50
+	details := [][2]string{
+		{"Period", r.statement.PeriodStart.Format("January 2006")},
+		{"Generated", r.statement.GeneratedAt.Format("Jan 2, 2006")},
+	}
+	if subject != "" {
+		details = append([][2]string{{"Artisan", subject}}, details...)
+	}
+	for i, detail := range details {
+		y := r.y + float64(i)*14
+		p.Text(statementMargin, y, pdf.Bold, statementFontSize, detail[0])
+		p.Text(statementMargin+70, y, pdf.Regular, statementFontSize, detail[1])
+	}
+
+	r.y += float64(len(details))*14 + 20
+}
+
+func (r *statementRenderer) totals() {
+	if len(r.statement.Totals) == 0 {
+		r.page.Text(statementMargin, r.y+12, pdf.Regular, statementFontSize, "No payments were settled in this period.")
+		r.y += 2 * statementRowHeight
+		return
+	}
+
+	for _, total := range r.statement.Totals {
+		rows := [][2]string{
+			{"Payments", strconv.Itoa(total.Payments)},
+			{"Collected", statementMoney(total.Currency, total.Gross)},
+			{"Refunded", statementMoney(total.Currency, total.Refunded)},
+			{"Tax", statementMoney(total.Currency, total.Tax)},
+			{"Platform commission", statementMoney(total.Currency, total.Commission)},
+		}
+
+		r.ensure(float64(len(rows)+2) * statementRowHeight)
+		r.page.Text(statementMargin, r.y+12, pdf.Bold, 11, "Summary ("+total.Currency+")")
+		r.y += statementRowHeight
+		for _, row := range rows {
+			r.summaryRow(pdf.Regular, row[0], row[1])
+		}
+		r.page.Line(statementMargin, r.y, statementRight, r.y, 1)
+		r.y += 4
+		r.summaryRow(pdf.Bold, "Artisan earnings", statementMoney(total.Currency, total.Earnings))
+		r.y += 12
+	}
+}
+
+func (r *statementRenderer) summaryRow(font pdf.Font, label, value string) {
+	baseline := r.y + 12.5
+	r.page.Text(statementMargin, baseline, font, statementFontSize, label)
+	r.page.TextRight(statementRight-6, baseline, font, statementFontSize, value)
+	r.y += statementRowHeight
+}
+
+func (r *statementRenderer) tableHeader() {
+	p := r.page
+	p.FillRect(statementMargin, r.y, statementRight-statementMargin, statementRowHeight, 0.92)
+	baseline := r.y + 12.5
+	p.Text(statementMargin+6, baseline, pdf.Bold, statementFontSize, "Date")
+	p.Text(statementMargin+70, baseline, pdf.Bold, statementFontSize, "Booking")
+	p.Text(statementTypeLeft, baseline, pdf.Bold, statementFontSize, "Type")
+	p.TextRight(statementAmountRight, baseline, pdf.Bold, statementFontSize, "Amount")
+	p.TextRight(statementFeeRight, baseline, pdf.Bold, statementFontSize, "Commission")
+	p.TextRight(statementRight-6, baseline, pdf.Bold, statementFontSize, "Earnings")
+	r.y += statementRowHeight
+}
+
+func (r *statementRenderer) payments(payments []*models.Payment) {
+	if len(payments) == 0 {
+		return
+	}
+
+	r.ensure(3 * statementRowHeight)
+	r.page.Text(statementMargin, r.y+12, pdf.Bold, 11, "Payments")
+	r.y += statementRowHeight
+	r.tableHeader()
+
+	for _, payment := range payments {
+		if r.ensure(statementRowHeight) {
+			r.tableHeader()
+		}
+
+		p := r.page
+		baseline := r.y + 12.5
+		var date string
+		if payment.ProcessedAt != nil {
+			date = payment.ProcessedAt.Format("Jan 2")
+		}
+		p.Text(statementMargin+6, baseline, pdf.Regular, statementFontSize, date)
+		p.Text(statementMargin+70, baseline, pdf.Regular, statementFontSize, "#"+payment.BookingID.String()[:8])
+		p.Text(statementTypeLeft, baseline, pdf.Regular, statementFontSize, string(payment.Type))
+		p.TextRight(statementAmountRight, baseline, pdf.Regular, statementFontSize, statementMoney(payment.Currency, payment.Amount))
+		p.TextRight(statementFeeRight, baseline, pdf.Regular, statementFontSize, statementMoney(payment.Currency, payment.PlatformAmount))
+		p.TextRight(statementRight-6, baseline, pdf.Regular, statementFontSize, statementMoney(payment.Currency, payment.ArtisanAmount))
+		r.y += statementRowHeight
+		p.Line(statementMargin, r.y, statementRight, r.y, 0.5)
+	}
+}
+
+func statementMoney(currency string, amount float64) string {
+	return fmt.Sprintf("%s %.2f", currency, amount)
+}
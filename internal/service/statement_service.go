@@ -0,0 +1,327 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/storage"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// statementColumns is the header row of every statement CSV
+var statementColumns = []string{
+	"payment_id", "processed_at", "booking_id", "artisan_id", "type", "method", "status", "currency",
+	"amount", "refunded_amount", "tax_amount", "commission_rate", "commission_rule",
+	"platform_commission", "artisan_earnings",
+}
+
+// StatementDownload is an opened statement file ready to be streamed to the client
+type StatementDownload struct {
+	Content     io.ReadCloser
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+// StatementService defines the interface for monthly financial statements
+type StatementService interface {
+	// GenerateStatements renders the tenant's revenue statement and each paid artisan's
+	// earnings statement for the month of period, replacing earlier ones
+	GenerateStatements(ctx context.Context, req *dto.GenerateStatementsRequest) (*dto.StatementRunResponse, error)
+
+	// GeneratePreviousMonth generates last month's statements for every tenant with
+	// settled payments that does not have them yet
+	GeneratePreviousMonth(ctx context.Context) (*dto.StatementRunResponse, error)
+
+	ListStatements(ctx context.Context, req *dto.StatementListRequest) ([]*dto.FinancialStatementResponse, error)
+
+	// OpenStatement opens a statement file of the tenant. With subjectID set only that
+	// artisan's statements can be opened.
+	OpenStatement(ctx context.Context, tenantID, id uuid.UUID, subjectID *uuid.UUID, format models.StatementFormat) (*StatementDownload, error)
+}
+
+// statementService implements StatementService
+type statementService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+	store  storage.ObjectStore
+}
+
+// NewStatementService creates a new StatementService instance; store may be nil, in
+// which case statements cannot be generated
+func NewStatementService(repos *repository.Repositories, logger log.AllLogger, store storage.ObjectStore) StatementService {
+	return &statementService{
+		repos:  repos,
+		logger: logger,
+		store:  store,
+	}
+}
+
+// GenerateStatements regenerates a tenant's statements for a month on request
+func (s *statementService) GenerateStatements(ctx context.Context, req *dto.GenerateStatementsRequest) (*dto.StatementRunResponse, error) {
+	if req.TenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+	period, err := dto.ParseStatementPeriod(req.Period)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	start, end := models.StatementPeriod(period)
+	if !end.Before(time.Now()) {
+		return nil, errors.NewValidationError("statements can only be generated for a month that has ended")
+	}
+	if s.store == nil {
+		return nil, errors.NewServiceError("STATEMENTS_UNAVAILABLE", "file storage is not configured", nil)
+	}
+
+	count, err := s.generate(ctx, req.TenantID, start, end)
+	if err != nil {
+		s.logger.Error("failed to generate financial statements", "tenant_id", req.TenantID, "period", req.Period, "error", err)
+		return nil, err
+	}
+	return &dto.StatementRunResponse{Period: start.Format("2006-01"), Tenants: 1, Statements: count}, nil
+}
+
+// GeneratePreviousMonth runs the monthly statement job. Tenants whose revenue statement
+// exists are skipped, so the job can run often and catches up after failures.
+func (s *statementService) GeneratePreviousMonth(ctx context.Context) (*dto.StatementRunResponse, error) {
+	start, end := models.StatementPeriod(time.Now().UTC().AddDate(0, -1, 0))
+	result := &dto.StatementRunResponse{Period: start.Format("2006-01")}
+	if s.store == nil {
+		return result, nil
+	}
+
+	tenantIDs, err := s.repos.Payment.FindTenantsWithSettledPayments(ctx, start, end)
+	if err != nil {
+		return result, errors.NewServiceError("QUERY_FAILED", "failed to list tenants with settled payments", err)
+	}
+
+	for _, tenantID := range tenantIDs {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		exists, err := s.repos.Statement.HasTenantStatement(ctx, tenantID, start)
+		if err != nil {
+			s.logger.Error("failed to check financial statement", "tenant_id", tenantID, "error", err)
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		result.Tenants++
+		count, err := s.generate(ctx, tenantID, start, end)
+		result.Statements += count
+		if err != nil {
+			s.logger.Error("failed to generate financial statements", "tenant_id", tenantID, "period", result.Period, "error", err)
+			result.Failed++
+		}
+	}
+
+	if result.Tenants > 0 {
+		s.logger.Info("financial statements generated",
+			"period", result.Period,
+			"tenants", result.Tenants,
+			"statements", result.Statements,
+			"failed", result.Failed)
+	}
+	return result, nil
+}
+
+// generate writes a tenant's statements for [start, end). Artisan statements are written
+// before the tenant's, whose existence marks the month as done.
+func (s *statementService) generate(ctx context.Context, tenantID uuid.UUID, start, end time.Time) (int, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return 0, errors.NewNotFoundError("tenant")
+		}
+		return 0, errors.NewServiceError("TENANT_GET_FAILED", "failed to get tenant", err)
+	}
+
+	payments, err := s.repos.Payment.FindSettledByTenant(ctx, tenantID, start, end)
+	if err != nil {
+		return 0, errors.NewServiceError("QUERY_FAILED", "failed to list settled payments", err)
+	}
+
+	byArtisan := make(map[uuid.UUID][]*models.Payment)
+	for _, payment := range payments {
+		if payment.ArtisanID != nil {
+			byArtisan[*payment.ArtisanID] = append(byArtisan[*payment.ArtisanID], payment)
+		}
+	}
+	artisanIDs := make([]uuid.UUID, 0, len(byArtisan))
+	for artisanID := range byArtisan {
+		artisanIDs = append(artisanIDs, artisanID)
+	}
+	sort.Slice(artisanIDs, func(i, j int) bool { return artisanIDs[i].String() < artisanIDs[j].String() })
+
+	count := 0
+	for _, artisanID := range artisanIDs {
+		var name string
+		if user, err := s.repos.User.GetByID(ctx, artisanID); err == nil {
+			name = user.FullName()
+		}
+		statement := newStatement(tenant.ID, models.StatementKindArtisan, artisanID, start, end)
+		if err := s.save(ctx, statement, tenant, name, byArtisan[artisanID]); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	statement := newStatement(tenant.ID, models.StatementKindTenant, tenant.ID, start, end)
+	if err := s.save(ctx, statement, tenant, "", payments); err != nil {
+		return count, err
+	}
+	return count + 1, nil
+}
+
+func newStatement(tenantID uuid.UUID, kind models.StatementKind, subjectID uuid.UUID, start, end time.Time) *models.FinancialStatement {
+	return &models.FinancialStatement{
+		TenantID:    tenantID,
+		Kind:        kind,
+		SubjectID:   subjectID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Totals:      models.StatementTotals{},
+		GeneratedAt: time.Now(),
+	}
+}
+
+// save renders a statement's files, stores them and records the statement
+func (s *statementService) save(ctx context.Context, statement *models.FinancialStatement, tenant *models.Tenant, subject string, payments []*models.Payment) error {
+	for _, payment := range payments {
+		statement.Totals.Add(payment)
+	}
+
+	csvFile, err := renderStatementCSV(payments)
+	if err != nil {
+		return errors.NewServiceError("STATEMENT_RENDER_FAILED", "failed to render statement CSV", err)
+	}
+	pdfFile := renderStatementPDF(statement, tenant, subject, payments)
+
+	base := fmt.Sprintf("statements/%s/%s/%s-%s", statement.TenantID, statement.PeriodStart.Format("2006-01"), statement.Kind, statement.SubjectID)
+	statement.CSVKey = base + ".csv"
+	if statement.CSVSize, err = s.store.Put(ctx, statement.CSVKey, models.StatementFormatCSV.ContentType(), bytes.NewReader(csvFile)); err != nil {
+		return errors.NewServiceError("STATEMENT_STORE_FAILED", "failed to store statement CSV", err)
+	}
+	statement.PDFKey = base + ".pdf"
+	if statement.PDFSize, err = s.store.Put(ctx, statement.PDFKey, models.StatementFormatPDF.ContentType(), bytes.NewReader(pdfFile)); err != nil {
+		return errors.NewServiceError("STATEMENT_STORE_FAILED", "failed to store statement PDF", err)
+	}
+
+	if err := s.repos.Statement.Save(ctx, statement); err != nil {
+		return errors.NewServiceError("STATEMENT_SAVE_FAILED", "failed to save statement", err)
+	}
+	return nil
+}
+
+// renderStatementCSV writes one row per payment
+func renderStatementCSV(payments []*models.Payment) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(statementColumns); err != nil {
+		return nil, err
+	}
+
+	for _, p := range payments {
+		var processedAt, artisanID string
+		if p.ProcessedAt != nil {
+			processedAt = p.ProcessedAt.UTC().Format(time.RFC3339)
+		}
+		if p.ArtisanID != nil {
+			artisanID = p.ArtisanID.String()
+		}
+		if err := w.Write([]string{
+			p.ID.String(), processedAt, p.BookingID.String(), artisanID,
+			string(p.Type), string(p.Method), string(p.Status), p.Currency,
+			formatAmount(p.Amount), formatAmount(p.RefundedAmount), formatAmount(p.TaxAmount),
+			strconv.FormatFloat(p.CommissionRate, 'f', -1, 64), p.CommissionRuleName,
+			formatAmount(p.PlatformAmount), formatAmount(p.ArtisanAmount),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// ListStatements lists a tenant's statements, newest month first
+func (s *statementService) ListStatements(ctx context.Context, req *dto.StatementListRequest) ([]*dto.FinancialStatementResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	filter := repository.StatementFilter{Kind: req.Kind, SubjectID: req.SubjectID}
+	if req.Period != "" {
+		start, _ := dto.ParseStatementPeriod(req.Period)
+		filter.PeriodStart = &start
+	}
+
+	statements, err := s.repos.Statement.FindByTenant(ctx, req.TenantID, filter)
+	if err != nil {
+		return nil, errors.NewServiceError("STATEMENT_LIST_FAILED", "failed to list statements", err)
+	}
+	return dto.ToFinancialStatementResponses(statements), nil
+}
+
+// OpenStatement opens a stored statement file
+func (s *statementService) OpenStatement(ctx context.Context, tenantID, id uuid.UUID, subjectID *uuid.UUID, format models.StatementFormat) (*StatementDownload, error) {
+	if !format.IsValid() {
+		return nil, errors.NewValidationError("format must be csv or pdf")
+	}
+
+	statement, err := s.repos.Statement.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("statement")
+		}
+		return nil, errors.NewServiceError("STATEMENT_GET_FAILED", "failed to get statement", err)
+	}
+	if statement.TenantID != tenantID || (subjectID != nil && (statement.Kind != models.StatementKindArtisan || statement.SubjectID != *subjectID)) {
+		return nil, errors.NewNotFoundError("statement")
+	}
+
+	key := statement.FileKey(format)
+	if key == "" || s.store == nil {
+		return nil, errors.NewNotFoundError("statement file not available")
+	}
+
+	content, err := s.store.Open(ctx, key)
+	if err != nil {
+		if stdErrors.Is(err, storage.ErrObjectNotFound) {
+			return nil, errors.NewNotFoundError("statement file not available")
+		}
+		return nil, errors.NewServiceError("STATEMENT_OPEN_FAILED", "failed to open statement file", err)
+	}
+
+	size := statement.CSVSize
+	if format == models.StatementFormatPDF {
+		size = statement.PDFSize
+	}
+	return &StatementDownload{
+		Content:     content,
+		FileName:    statement.FileName(format),
+		ContentType: format.ContentType(),
+		Size:        size,
+	}, nil
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/payments"
 	"Krafti_Vibe/internal/pkg/errors"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
@@ -26,6 +30,13 @@ type SubscriptionService interface {
 	ChangePlan(ctx context.Context, tenantID uuid.UUID, req *dto.ChangePlanRequest) (*dto.BillingPreviewResponse, error)
 	PreviewPlanChange(ctx context.Context, tenantID uuid.UUID, req *dto.ChangePlanRequest) (*dto.BillingPreviewResponse, error)
 	GetPlanComparison(ctx context.Context) (*dto.PlanComparisonResponse, error)
+	UpsertPlan(ctx context.Context, code models.SubscriptionPlan, req *dto.UpsertPlanRequest) (*dto.PlanDetails, error)
+
+	// Plan Billing
+	Subscribe(ctx context.Context, tenantID uuid.UUID, req *dto.SubscribeRequest) (*dto.SubscribeResponse, error)
+	HandleBillingWebhook(ctx context.Context, payload []byte, headers http.Header) error
+	EnforcePlan(ctx context.Context, tenantID uuid.UUID, limit models.PlanLimit) error
+	RateLimitPlan(ctx context.Context, tenantID uuid.UUID) (models.SubscriptionPlan, error)
 
 	// Subscription Lifecycle
 	StartTrial(ctx context.Context, tenantID uuid.UUID, trialDays int) (*dto.SubscriptionResponse, error)
@@ -65,6 +76,7 @@ type SubscriptionService interface {
 	ProcessFailedPayments(ctx context.Context) error
 	ProcessSubscriptionRenewals(ctx context.Context) error
 	CleanupExpiredSubscriptions(ctx context.Context) error
+	ProcessGracePeriods(ctx context.Context) (int, error)
 
 	// Health & Monitoring
 	HealthCheck(ctx context.Context) error
@@ -75,14 +87,16 @@ type SubscriptionService interface {
 type subscriptionService struct {
 	repos          *repository.Repositories
 	paymentService PaymentService
+	billing        payments.Billing // Optional; paid plans cannot be subscribed to without it
 	logger         log.AllLogger
 }
 
 // NewSubscriptionService creates a new SubscriptionService instance
-func NewSubscriptionService(repos *repository.Repositories, paymentService PaymentService, logger log.AllLogger) SubscriptionService {
+func NewSubscriptionService(repos *repository.Repositories, paymentService PaymentService, logger log.AllLogger, billing payments.Billing) SubscriptionService {
 	return &subscriptionService{
 		repos:          repos,
 		paymentService: paymentService,
+		billing:        billing,
 		logger:         logger,
 	}
 }
@@ -232,10 +246,11 @@ func (s *subscriptionService) DeleteSubscription(ctx context.Context, tenantID u
 		return errors.NewServiceError("SUBSCRIPTION_GET_FAILED", "failed to get subscription", err)
 	}
 
-	// Cancel with Stripe if needed
-	if subscription.StripeSubscriptionID != "" {
-		// In real implementation, cancel with Stripe
-		s.logger.Info("would cancel Stripe subscription", "stripe_id", subscription.StripeSubscriptionID)
+	// Stop Stripe billing before the local record goes
+	if subscription.StripeSubscriptionID != "" && s.billing != nil {
+		if _, err := s.billing.CancelSubscription(ctx, subscription.StripeSubscriptionID, false); err != nil {
+			return errors.NewAppErrorWithErr(errBillingProvider, "failed to cancel the billing subscription", http.StatusBadGateway, err)
+		}
 	}
 
 	if err := s.repos.Subscription.Delete(ctx, subscription.ID); err != nil {
@@ -311,37 +326,545 @@ func (s *subscriptionService) PreviewPlanChange(ctx context.Context, tenantID uu
 
 // GetPlanComparison returns comparison of all available plans
 func (s *subscriptionService) GetPlanComparison(ctx context.Context) (*dto.PlanComparisonResponse, error) {
-	plans := []models.SubscriptionPlan{
-		models.PlanFree, models.PlanStarter, models.PlanPro,
-		models.PlanBusiness, models.PlanEnterprise,
+	plans, err := s.listPlans(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	var planDetails []dto.PlanDetails
+	planDetails := make([]dto.PlanDetails, 0, len(plans))
 	for _, plan := range plans {
-		details := dto.PlanDetails{
-			Plan:         plan,
-			Name:         s.getPlanName(plan),
-			Description:  s.getPlanDescription(plan),
-			MonthlyPrice: s.getPlanPricing(plan, models.BillingMonthly),
-			YearlyPrice:  s.getPlanPricing(plan, models.BillingYearly),
-			Features:     models.GetDefaultFeaturesForPlan(plan),
-			Limits:       models.GetDefaultLimitsForPlan(plan),
-			Popular:      plan == models.PlanPro,
-			Recommended:  plan == models.PlanBusiness,
+		if !plan.IsActive {
+			continue
 		}
+		planDetails = append(planDetails, *toPlanDetails(plan))
+	}
+
+	return &dto.PlanComparisonResponse{Plans: planDetails}, nil
+}
 
-		// Calculate yearly discount
-		if details.MonthlyPrice > 0 && details.YearlyPrice > 0 {
-			monthlyTotal := details.MonthlyPrice * 12
-			if monthlyTotal > details.YearlyPrice {
-				details.YearlyDiscount = ((monthlyTotal - details.YearlyPrice) / monthlyTotal) * 100
+// UpsertPlan updates a plan's price, Stripe prices and limits, storing the built-in
+// defaults first if the plan has never been edited
+func (s *subscriptionService) UpsertPlan(ctx context.Context, code models.SubscriptionPlan, req *dto.UpsertPlanRequest) (*dto.PlanDetails, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	plan, err := s.repos.Plan.GetByCode(ctx, code)
+	stored := err == nil
+	if err != nil {
+		if !errors.IsNotFoundError(err) {
+			return nil, errors.NewServiceError("PLAN_GET_FAILED", "failed to get plan", err)
+		}
+		if plan = s.defaultPlan(code); plan == nil {
+			return nil, errors.NewNotFoundError("plan")
+		}
+	}
+
+	req.Apply(plan)
+	if !plan.IsFree() && plan.StripeMonthlyPriceID == "" && plan.StripeYearlyPriceID == "" && plan.IsActive {
+		s.logger.Warn("paid plan has no Stripe prices; tenants cannot subscribe to it", "plan", code)
+	}
+
+	if stored {
+		err = s.repos.Plan.Update(ctx, plan)
+	} else {
+		err = s.repos.Plan.Create(ctx, plan)
+	}
+	if err != nil {
+		return nil, errors.NewServiceError("PLAN_SAVE_FAILED", "failed to save plan", err)
+	}
+
+	s.logger.Info("plan updated", "plan", code)
+	return toPlanDetails(plan), nil
+}
+
+// ============================================================================
+// Plan Billing
+// ============================================================================
+
+const errBillingProvider errors.ErrorCode = "BILLING_PROVIDER_ERROR"
+
+// Subscribe moves the tenant onto a plan. Free plans apply at once; paid plans are
+// billed through Stripe and apply once Stripe reports the subscription active, which is
+// immediate for plan changes and trials and after the first payment otherwise.
+func (s *subscriptionService) Subscribe(ctx context.Context, tenantID uuid.UUID, req *dto.SubscribeRequest) (*dto.SubscribeResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	plan, err := s.resolvePlan(ctx, req.Plan)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("plan %s is not available", plan.Code))
+	}
+
+	subscription, err := s.repos.Subscription.GetByTenantID(ctx, tenantID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, errors.NewServiceError("SUBSCRIPTION_GET_FAILED", "failed to get subscription", err)
+	}
+	isNew := subscription == nil
+	if !isNew && subscription.Plan == req.Plan && subscription.BillingInterval == req.BillingInterval &&
+		subscription.HasAccess(time.Now()) && (plan.IsFree() || subscription.StripeSubscriptionID != "") {
+		return nil, errors.NewValidationError("already on the requested plan")
+	}
+	if isNew {
+		if subscription, err = s.freeSubscription(ctx, tenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &dto.SubscribeResponse{}
+	if plan.IsFree() {
+		// Dropping to the free plan ends Stripe billing straight away
+		if subscription.StripeSubscriptionID != "" && s.billing != nil {
+			if _, err := s.billing.CancelSubscription(ctx, subscription.StripeSubscriptionID, false); err != nil {
+				return nil, errors.NewAppErrorWithErr(errBillingProvider, "failed to cancel the billing subscription", http.StatusBadGateway, err)
 			}
+			subscription.StripeSubscriptionID = ""
+		}
+		subscription.BillingInterval = models.BillingMonthly
+		plan.ApplyTo(subscription)
+		subscription.Status = models.SubStatusActive
+		subscription.CancelAtPeriodEnd = false
+		subscription.EndGracePeriod()
+	} else {
+		billed, err := s.startBilling(ctx, tenantID, subscription, plan, req)
+		if err != nil {
+			return nil, err
+		}
+		if billed.Status == payments.BillingIncomplete {
+			response.RequiresPayment = true
+			response.ClientSecret = billed.ClientSecret
+		} else {
+			subscription.BillingInterval = req.BillingInterval
+			plan.ApplyTo(subscription)
 		}
+		s.syncBillingState(subscription, billed, plan.GraceDays())
+	}
 
-		planDetails = append(planDetails, details)
+	if isNew {
+		err = s.repos.Subscription.Create(ctx, subscription)
+	} else {
+		err = s.repos.Subscription.SaveBillingState(ctx, subscription)
+	}
+	if err != nil {
+		return nil, errors.NewServiceError("SUBSCRIPTION_SAVE_FAILED", "failed to save subscription", err)
 	}
 
-	return &dto.PlanComparisonResponse{Plans: planDetails}, nil
+	s.logger.Info("tenant subscribed to plan", "tenant_id", tenantID, "plan", plan.Code, "interval", req.BillingInterval, "requires_payment", response.RequiresPayment)
+	response.Subscription = dto.ToSubscriptionResponse(subscription)
+	return response, nil
+}
+
+// startBilling creates or reprices the tenant's Stripe subscription for plan
+func (s *subscriptionService) startBilling(ctx context.Context, tenantID uuid.UUID, subscription *models.Subscription, plan *models.Plan, req *dto.SubscribeRequest) (*payments.BillingSubscription, error) {
+	if s.billing == nil {
+		return nil, errors.NewAppError("BILLING_NOT_CONFIGURED", "subscription billing is not configured", http.StatusServiceUnavailable)
+	}
+	priceID := plan.StripePriceID(req.BillingInterval)
+	if priceID == "" {
+		return nil, errors.NewValidationError(fmt.Sprintf("plan %s is not sold with %s billing", plan.Code, req.BillingInterval))
+	}
+
+	if subscription.StripeSubscriptionID != "" {
+		billed, err := s.billing.ChangePrice(ctx, subscription.StripeSubscriptionID, priceID)
+		if err != nil {
+			return nil, errors.NewAppErrorWithErr(errBillingProvider, "billing provider rejected the plan change", http.StatusBadGateway, err)
+		}
+		return billed, nil
+	}
+
+	if subscription.StripeCustomerID == "" {
+		tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+		if err != nil {
+			return nil, errors.NewServiceError("TENANT_GET_FAILED", "failed to get tenant", err)
+		}
+		name := tenant.BusinessName
+		if name == "" {
+			name = tenant.Name
+		}
+		customerID, err := s.billing.CreateCustomer(ctx, payments.BillingCustomerRequest{
+			Email:     tenant.BusinessEmail,
+			Name:      name,
+			Reference: tenantID.String(),
+		})
+		if err != nil {
+			return nil, errors.NewAppErrorWithErr(errBillingProvider, "billing provider rejected the customer", http.StatusBadGateway, err)
+		}
+		subscription.StripeCustomerID = customerID
+	}
+
+	// Trials are offered once, to tenants that have never had one
+	trialDays := 0
+	if subscription.TrialEndsAt == nil {
+		trialDays = plan.TrialDays
+	}
+	billed, err := s.billing.CreateSubscription(ctx, payments.BillingSubscriptionRequest{
+		CustomerID:      subscription.StripeCustomerID,
+		PriceID:         priceID,
+		TrialDays:       trialDays,
+		PaymentMethodID: req.PaymentMethodID,
+		Reference:       tenantID.String(),
+	})
+	if err != nil {
+		return nil, errors.NewAppErrorWithErr(errBillingProvider, "billing provider rejected the subscription", http.StatusBadGateway, err)
+	}
+	if req.PaymentMethodID != "" {
+		subscription.PaymentMethodID = req.PaymentMethodID
+	}
+	return billed, nil
+}
+
+// HandleBillingWebhook applies a verified Stripe Billing event to the tenant's
+// subscription. Every outcome is a state assignment, so redelivered events are harmless.
+func (s *subscriptionService) HandleBillingWebhook(ctx context.Context, payload []byte, headers http.Header) error {
+	if s.billing == nil {
+		return errors.NewNotFoundError("billing provider")
+	}
+
+	event, err := s.billing.VerifyWebhook(ctx, payload, headers)
+	if err != nil {
+		if stderrors.Is(err, payments.ErrInvalidSignature) {
+			return errors.NewAppErrorWithErr(errors.ErrCodeUnauthorized, "invalid webhook signature", http.StatusUnauthorized, err)
+		}
+		return errors.NewAppErrorWithErr(errors.ErrCodeValidation, "invalid webhook payload", http.StatusBadRequest, err)
+	}
+	if event.Type == "" {
+		return nil
+	}
+
+	subscription, err := s.findBilledSubscription(ctx, event)
+	if err != nil {
+		return err
+	}
+	if subscription == nil {
+		s.logger.Warn("billing webhook for unknown subscription", "event_id", event.ID, "subscription_id", event.SubscriptionID, "customer_id", event.CustomerID)
+		return nil
+	}
+
+	current, err := s.resolvePlan(ctx, subscription.Plan)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	switch event.Type {
+	case payments.BillingEventInvoicePaid:
+		subscription.EndGracePeriod()
+		if event.AmountPaid > 0 {
+			paidAt := event.OccurredAt
+			if paidAt.IsZero() {
+				paidAt = now
+			}
+			subscription.LastPaymentDate = &paidAt
+			subscription.LastPaymentAmount = event.AmountPaid
+		}
+
+	case payments.BillingEventInvoiceFailed:
+		if event.AttemptCount > 0 {
+			subscription.FailedPayments = event.AttemptCount
+		} else {
+			subscription.FailedPayments++
+		}
+		subscription.StartGracePeriod(now, current.GraceDays())
+
+	case payments.BillingEventSubscriptionUpdated:
+		billed := event.Subscription
+		if billed == nil {
+			return nil
+		}
+		// A price change made in Stripe, or the first payment on a new subscription,
+		// moves the tenant onto the plan sold at that price
+		if billed.Status != payments.BillingIncomplete {
+			plan, interval, err := s.planForPrice(ctx, billed.PriceID)
+			if err != nil {
+				return err
+			}
+			if plan != nil && (plan.Code != subscription.Plan || interval != subscription.BillingInterval) {
+				subscription.BillingInterval = interval
+				plan.ApplyTo(subscription)
+				current = plan
+			}
+		}
+		s.syncBillingState(subscription, billed, current.GraceDays())
+
+	case payments.BillingEventSubscriptionCanceled:
+		// The paid plan has ended; the tenant carries on with the free plan's limits
+		free, err := s.resolvePlan(ctx, models.PlanFree)
+		if err != nil {
+			return err
+		}
+		subscription.BillingInterval = models.BillingMonthly
+		free.ApplyTo(subscription)
+		subscription.StripeSubscriptionID = ""
+		subscription.Status = models.SubStatusActive
+		subscription.CancelAtPeriodEnd = false
+		subscription.CanceledAt = &now
+		subscription.EndGracePeriod()
+	}
+
+	if err := s.repos.Subscription.SaveBillingState(ctx, subscription); err != nil {
+		return errors.NewServiceError("SUBSCRIPTION_SAVE_FAILED", "failed to save subscription", err)
+	}
+
+	s.logger.Info("billing webhook applied", "event_id", event.ID, "type", event.Type, "tenant_id", subscription.TenantID, "status", subscription.Status)
+	return nil
+}
+
+// findBilledSubscription locates the subscription a billing event is about
+func (s *subscriptionService) findBilledSubscription(ctx context.Context, event *payments.BillingEvent) (*models.Subscription, error) {
+	lookups := []func() (*models.Subscription, error){}
+	if event.SubscriptionID != "" {
+		lookups = append(lookups, func() (*models.Subscription, error) {
+			return s.repos.Subscription.GetByStripeSubscriptionID(ctx, event.SubscriptionID)
+		})
+	}
+	if event.CustomerID != "" {
+		lookups = append(lookups, func() (*models.Subscription, error) {
+			return s.repos.Subscription.GetByStripeCustomerID(ctx, event.CustomerID)
+		})
+	}
+	if event.Subscription != nil {
+		if tenantID, err := uuid.Parse(event.Subscription.Reference); err == nil {
+			lookups = append(lookups, func() (*models.Subscription, error) {
+				return s.repos.Subscription.GetByTenantID(ctx, tenantID)
+			})
+		}
+	}
+
+	for _, lookup := range lookups {
+		subscription, err := lookup()
+		if err == nil {
+			return subscription, nil
+		}
+		if !errors.IsNotFoundError(err) {
+			return nil, errors.NewServiceError("SUBSCRIPTION_GET_FAILED", "failed to get subscription", err)
+		}
+	}
+	return nil, nil
+}
+
+// syncBillingState copies Stripe's view of the subscription onto ours
+func (s *subscriptionService) syncBillingState(subscription *models.Subscription, billed *payments.BillingSubscription, graceDays int) {
+	subscription.StripeSubscriptionID = billed.ID
+	if billed.CustomerID != "" {
+		subscription.StripeCustomerID = billed.CustomerID
+	}
+	if !billed.CurrentPeriodEnd.IsZero() {
+		subscription.CurrentPeriodStart = billed.CurrentPeriodStart
+		subscription.CurrentPeriodEnd = billed.CurrentPeriodEnd
+		next := billed.CurrentPeriodEnd
+		subscription.NextBillingDate = &next
+	}
+	subscription.CancelAtPeriodEnd = billed.CancelAtPeriodEnd
+	if billed.TrialEndsAt != nil {
+		subscription.TrialEndsAt = billed.TrialEndsAt
+	}
+
+	switch billed.Status {
+	case payments.BillingActive:
+		subscription.EndGracePeriod()
+		subscription.Status = models.SubStatusActive
+	case payments.BillingTrialing:
+		subscription.Status = models.SubStatusTrialing
+	case payments.BillingPastDue:
+		subscription.StartGracePeriod(time.Now(), graceDays)
+	}
+}
+
+// EnforcePlan checks that the tenant's subscription is in good standing and, when limit
+// is set, that it has room for one more of it. Refusals are 402 errors naming the plan,
+// the limit and current usage so clients can offer an upgrade. Tenants without a
+// subscription are held to the free plan.
+func (s *subscriptionService) EnforcePlan(ctx context.Context, tenantID uuid.UUID, limit models.PlanLimit) error {
+	subscription, err := s.repos.Subscription.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		if !errors.IsNotFoundError(err) {
+			return errors.NewServiceError("SUBSCRIPTION_GET_FAILED", "failed to get subscription", err)
+		}
+		if subscription, err = s.freeSubscription(ctx, tenantID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	planName := s.getPlanName(subscription.Plan)
+	if !subscription.HasAccess(now) {
+		return errors.NewPaymentRequiredError(errors.ErrCodeSubscriptionInactive,
+			fmt.Sprintf("Your %s subscription is %s; update your billing details to continue", planName, subscription.Status),
+			&errors.PlanLimitError{Plan: string(subscription.Plan), Status: string(subscription.Status)})
+	}
+	if limit == "" {
+		return nil
+	}
+
+	used, err := s.countUsage(ctx, tenantID, limit, now)
+	if err != nil {
+		return err
+	}
+	if subscription.AllowsAnother(limit, used) {
+		return nil
+	}
+
+	allowed := subscription.Limit(limit)
+	return errors.NewPaymentRequiredError(errors.ErrCodePlanLimitReached,
+		fmt.Sprintf("Your %s plan includes %d %s and %d are in use; upgrade your plan to add more", planName, allowed, limit.Label(), used),
+		&errors.PlanLimitError{
+			Plan:    string(subscription.Plan),
+			Status:  string(subscription.Status),
+			Limit:   string(limit),
+			Used:    used,
+			Allowed: allowed,
+		})
+}
+
+// RateLimitPlan returns the plan whose API rate limit applies to the tenant. Tenants
+// without a subscription, or whose subscription is not in good standing, get the free
+// plan's limit.
+func (s *subscriptionService) RateLimitPlan(ctx context.Context, tenantID uuid.UUID) (models.SubscriptionPlan, error) {
+	subscription, err := s.repos.Subscription.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return models.PlanFree, nil
+		}
+		return "", errors.NewServiceError("SUBSCRIPTION_GET_FAILED", "failed to get subscription", err)
+	}
+	if !subscription.HasAccess(time.Now()) {
+		return models.PlanFree, nil
+	}
+	return subscription.Plan, nil
+}
+
+// countUsage counts what a tenant currently uses of a metered limit
+func (s *subscriptionService) countUsage(ctx context.Context, tenantID uuid.UUID, limit models.PlanLimit, now time.Time) (int64, error) {
+	var (
+		used int64
+		err  error
+	)
+	switch limit {
+	case models.PlanLimitBookingsPerMonth:
+		now = now.UTC()
+		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		used, err = s.repos.Booking.CountCreatedSince(ctx, tenantID, monthStart)
+	case models.PlanLimitArtisanSeats:
+		// Artisans invited hold their seat until the invitation is accepted or expires
+		var invited int64
+		if used, err = s.repos.Artisan.CountByTenant(ctx, tenantID); err == nil {
+			invited, err = s.repos.TenantInvitation.CountPendingByTenantAndRole(ctx, tenantID, models.UserRoleArtisan)
+			used += invited
+		}
+	default:
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.NewServiceError("USAGE_COUNT_FAILED", "failed to count plan usage", err)
+	}
+	return used, nil
+}
+
+// freeSubscription returns an unsaved free-plan subscription for a tenant that has none
+func (s *subscriptionService) freeSubscription(ctx context.Context, tenantID uuid.UUID) (*models.Subscription, error) {
+	plan, err := s.resolvePlan(ctx, models.PlanFree)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	subscription := &models.Subscription{
+		TenantID:           tenantID,
+		Status:             models.SubStatusActive,
+		BillingInterval:    models.BillingMonthly,
+		CurrentPeriodStart: now,
+		CurrentPeriodEnd:   now.AddDate(0, 1, 0),
+	}
+	plan.ApplyTo(subscription)
+	return subscription, nil
+}
+
+// resolvePlan returns the stored plan for a code, or its built-in defaults if it has
+// never been edited
+func (s *subscriptionService) resolvePlan(ctx context.Context, code models.SubscriptionPlan) (*models.Plan, error) {
+	plan, err := s.repos.Plan.GetByCode(ctx, code)
+	if err == nil {
+		return plan, nil
+	}
+	if !errors.IsNotFoundError(err) {
+		return nil, errors.NewServiceError("PLAN_GET_FAILED", "failed to get plan", err)
+	}
+	if plan = s.defaultPlan(code); plan == nil {
+		return nil, errors.NewNotFoundError("plan")
+	}
+	return plan, nil
+}
+
+// listPlans returns every plan in display order, stored plans replacing the built-in ones
+func (s *subscriptionService) listPlans(ctx context.Context) ([]*models.Plan, error) {
+	stored, err := s.repos.Plan.List(ctx, false)
+	if err != nil {
+		return nil, errors.NewServiceError("PLAN_LIST_FAILED", "failed to list plans", err)
+	}
+	byCode := make(map[models.SubscriptionPlan]*models.Plan, len(stored))
+	for _, plan := range stored {
+		byCode[plan.Code] = plan
+	}
+
+	plans := make([]*models.Plan, 0, len(builtInPlans))
+	for _, code := range builtInPlans {
+		if plan, ok := byCode[code]; ok {
+			plans = append(plans, plan)
+		} else {
+			plans = append(plans, s.defaultPlan(code))
+		}
+	}
+	slices.SortStableFunc(plans, func(a, b *models.Plan) int {
+		return a.SortOrder - b.SortOrder
+	})
+	return plans, nil
+}
+
+// planForPrice finds the plan and interval a Stripe price is sold under
+func (s *subscriptionService) planForPrice(ctx context.Context, priceID string) (*models.Plan, models.BillingInterval, error) {
+	if priceID == "" {
+		return nil, "", nil
+	}
+	plans, err := s.repos.Plan.List(ctx, false)
+	if err != nil {
+		return nil, "", errors.NewServiceError("PLAN_LIST_FAILED", "failed to list plans", err)
+	}
+	for _, plan := range plans {
+		switch priceID {
+		case plan.StripeMonthlyPriceID:
+			return plan, models.BillingMonthly, nil
+		case plan.StripeYearlyPriceID:
+			return plan, models.BillingYearly, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// ProcessGracePeriods suspends tenants whose grace period after a failed renewal ran
+// out without a successful payment
+func (s *subscriptionService) ProcessGracePeriods(ctx context.Context) (int, error) {
+	expired, err := s.repos.Subscription.GetGracePeriodExpired(ctx, time.Now())
+	if err != nil {
+		return 0, errors.NewServiceError("GRACE_PERIODS_GET_FAILED", "failed to get expired grace periods", err)
+	}
+
+	suspended := 0
+	for _, sub := range expired {
+		if err := s.repos.Subscription.SuspendSubscription(ctx, sub.TenantID, "Grace period ended without payment"); err != nil {
+			s.logger.Error("failed to suspend subscription", "tenant_id", sub.TenantID, "error", err)
+			continue
+		}
+		suspended++
+	}
+
+	if suspended > 0 {
+		s.logger.Info("suspended subscriptions after grace period", "count", suspended)
+	}
+	return suspended, nil
 }
 
 // ============================================================================
@@ -388,6 +911,20 @@ func (s *subscriptionService) CancelSubscription(ctx context.Context, tenantID u
 		return nil, errors.NewValidationError("invalid request: " + err.Error())
 	}
 
+	// Stop Stripe billing first so a failure leaves the tenant's plan untouched
+	current, err := s.repos.Subscription.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("subscription not found")
+		}
+		return nil, errors.NewServiceError("SUBSCRIPTION_GET_FAILED", "failed to get subscription", err)
+	}
+	if current.StripeSubscriptionID != "" && s.billing != nil {
+		if _, err := s.billing.CancelSubscription(ctx, current.StripeSubscriptionID, req.CancelAtPeriodEnd); err != nil {
+			return nil, errors.NewAppErrorWithErr(errBillingProvider, "failed to cancel the billing subscription", http.StatusBadGateway, err)
+		}
+	}
+
 	if err := s.repos.Subscription.CancelSubscription(ctx, tenantID, req.CancelAtPeriodEnd); err != nil {
 		return nil, errors.NewServiceError("SUBSCRIPTION_CANCEL_FAILED", "failed to cancel subscription", err)
 	}
@@ -446,16 +983,28 @@ func (s *subscriptionService) GetUsage(ctx context.Context, tenantID uuid.UUID)
 		return nil, errors.NewServiceError("USAGE_GET_FAILED", "failed to get usage statistics", err)
 	}
 
-	// Get additional usage data (team members, services, bookings)
-	teamMembersUsed := 0   // TODO: Get from user repository
-	servicesUsed := 0      // TODO: Get from service repository
-	bookingsThisMonth := 0 // TODO: Get from booking repository
-
 	subscription, err := s.repos.Subscription.GetByTenantID(ctx, tenantID)
 	if err != nil {
 		return nil, errors.NewServiceError("SUBSCRIPTION_GET_FAILED", "failed to get subscription", err)
 	}
 
+	// Metered limits are counted live rather than from the usage counters
+	now := time.Now()
+	seats, err := s.countUsage(ctx, tenantID, models.PlanLimitArtisanSeats, now)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := s.countUsage(ctx, tenantID, models.PlanLimitBookingsPerMonth, now)
+	if err != nil {
+		return nil, err
+	}
+	teamMembersUsed := int(seats)
+	bookingsThisMonth := int(bookings)
+	servicesUsed := 0
+	if serviceStats, err := s.repos.Service.GetServiceStats(ctx, tenantID); err == nil {
+		servicesUsed = int(serviceStats.TotalServices)
+	}
+
 	response := &dto.UsageResponse{
 		TenantID:            tenantID,
 		Plan:                usageStats.Plan,
@@ -625,6 +1174,75 @@ func (s *subscriptionService) getPlanPricing(plan models.SubscriptionPlan, inter
 	return 0
 }
 
+// builtInPlans lists the plans offered before any has been edited, in display order
+var builtInPlans = []models.SubscriptionPlan{
+	models.PlanFree, models.PlanStarter, models.PlanPro,
+	models.PlanBusiness, models.PlanEnterprise,
+}
+
+// defaultPlan returns a built-in plan's price, limits and features, or nil for an unknown code
+func (s *subscriptionService) defaultPlan(code models.SubscriptionPlan) *models.Plan {
+	order := slices.Index(builtInPlans, code)
+	if order < 0 {
+		return nil
+	}
+
+	limits := models.GetDefaultLimitsForPlan(code)
+	return &models.Plan{
+		Code:                code,
+		Name:                s.getPlanName(code),
+		Description:         s.getPlanDescription(code),
+		MonthlyPrice:        s.getPlanPricing(code, models.BillingMonthly),
+		YearlyPrice:         s.getPlanPricing(code, models.BillingYearly),
+		Currency:            "USD",
+		MaxCustomers:        limits["max_customers"],
+		MaxProjects:         limits["max_projects"],
+		MaxStorageGB:        limits["max_storage_gb"],
+		MaxArtisanSeats:     limits["max_team_members"],
+		MaxServicesListed:   limits["max_services_listed"],
+		MaxBookingsPerMonth: limits["max_bookings_per_month"],
+		Features:            models.GetDefaultFeaturesForPlan(code),
+		GracePeriodDays:     models.DefaultGracePeriodDays,
+		IsActive:            true,
+		SortOrder:           order,
+	}
+}
+
+// toPlanDetails converts a plan to its catalogue entry
+func toPlanDetails(plan *models.Plan) *dto.PlanDetails {
+	details := &dto.PlanDetails{
+		Plan:         plan.Code,
+		Name:         plan.Name,
+		Description:  plan.Description,
+		MonthlyPrice: plan.MonthlyPrice,
+		YearlyPrice:  plan.YearlyPrice,
+		Features:     plan.Features,
+		Limits: map[string]int{
+			"max_customers":          plan.MaxCustomers,
+			"max_projects":           plan.MaxProjects,
+			"max_storage_gb":         plan.MaxStorageGB,
+			"max_team_members":       plan.MaxArtisanSeats,
+			"max_services_listed":    plan.MaxServicesListed,
+			"max_bookings_per_month": plan.MaxBookingsPerMonth,
+		},
+		Popular:     plan.Code == models.PlanPro,
+		Recommended: plan.Code == models.PlanBusiness,
+		Currency:    plan.Currency,
+		TrialDays:   plan.TrialDays,
+		GraceDays:   plan.GraceDays(),
+		Active:      plan.IsActive,
+	}
+
+	// Calculate yearly discount
+	if details.MonthlyPrice > 0 && details.YearlyPrice > 0 {
+		monthlyTotal := details.MonthlyPrice * 12
+		if monthlyTotal > details.YearlyPrice {
+			details.YearlyDiscount = ((monthlyTotal - details.YearlyPrice) / monthlyTotal) * 100
+		}
+	}
+	return details
+}
+
 // getPlanName returns the display name for a plan
 func (s *subscriptionService) getPlanName(plan models.SubscriptionPlan) string {
 	names := map[models.SubscriptionPlan]string{
@@ -1118,7 +1736,8 @@ func (s *subscriptionService) ProcessFailedPayments(ctx context.Context) error {
 	processed := 0
 	for _, sub := range failedSubs {
 		// Retry payment or suspend subscription based on failure count
-		if sub.FailedPayments >= 3 {
+		// Tenants billed through Stripe keep access until their grace period runs out
+		if sub.FailedPayments >= 3 && !sub.InGracePeriod(time.Now()) {
 			err := s.repos.Subscription.SuspendSubscription(ctx, sub.TenantID, "Payment failures")
 			if err != nil {
 				s.logger.Error("failed to suspend subscription", "tenant_id", sub.TenantID, "error", err)
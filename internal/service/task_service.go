@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
@@ -44,6 +45,11 @@ type TaskService interface {
 	// Order Operations
 	ReorderTasks(ctx context.Context, req *dto.ReorderTasksRequest) error
 
+	// Dependencies
+	AddDependency(ctx context.Context, id uuid.UUID, req *dto.AddTaskDependencyRequest) (*dto.TaskDependenciesResponse, error)
+	RemoveDependency(ctx context.Context, id, dependsOnID uuid.UUID) (*dto.TaskDependenciesResponse, error)
+	GetDependencies(ctx context.Context, id uuid.UUID) (*dto.TaskDependenciesResponse, error)
+
 	// Statistics
 	GetTaskStats(ctx context.Context, projectID uuid.UUID) (*dto.TaskStatsResponse, error)
 	GetUserTaskStats(ctx context.Context, userID uuid.UUID) (*dto.TaskStatsResponse, error)
@@ -105,6 +111,11 @@ func (s *taskService) CreateTask(ctx context.Context, req *dto.CreateTaskRequest
 		}
 	}
 
+	// Verify dependencies; a new task cannot close a cycle as nothing depends on it yet
+	if _, err := s.validateDependencies(ctx, project.ID, uuid.Nil, req.DependsOn, false); err != nil {
+		return nil, err
+	}
+
 	task := &models.ProjectTask{
 		TenantID:       project.TenantID,
 		ProjectID:      req.ProjectID,
@@ -118,7 +129,6 @@ func (s *taskService) CreateTask(ctx context.Context, req *dto.CreateTaskRequest
 		DueDate:        req.DueDate,
 		EstimatedHours: req.EstimatedHours,
 		EstimatedCost:  req.EstimatedCost,
-		DependsOn:      req.DependsOn,
 		Checklist:      req.Checklist,
 		Metadata:       metadata,
 	}
@@ -130,6 +140,13 @@ func (s *taskService) CreateTask(ctx context.Context, req *dto.CreateTaskRequest
 
 	s.logger.Info("task created", "task_id", task.ID, "project_id", req.ProjectID)
 
+	// Record dependencies, blocking the task until they are done
+	for _, dependsOnID := range req.DependsOn {
+		if err := s.addDependency(ctx, task.ID, dependsOnID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Reload with relationships
 	created, err := s.repos.ProjectTask.GetByID(ctx, task.ID)
 	if err != nil {
@@ -167,6 +184,23 @@ func (s *taskService) UpdateTask(ctx context.Context, id uuid.UUID, req *dto.Upd
 		return nil, errors.NewNotFoundError("task not found")
 	}
 
+	var prerequisites []*models.ProjectTask
+	if req.DependsOn != nil {
+		if prerequisites, err = s.validateDependencies(ctx, existing.ProjectID, id, req.DependsOn, true); err != nil {
+			return nil, err
+		}
+	}
+	statusChanged := req.Status != nil && *req.Status != existing.Status
+	if statusChanged && requiresDependenciesDone(*req.Status) {
+		if req.DependsOn != nil {
+			if err := dependenciesPending(prerequisites); err != nil {
+				return nil, err
+			}
+		} else if err := s.checkDependenciesDone(ctx, id, *req.Status); err != nil {
+			return nil, err
+		}
+	}
+
 	// Apply updates
 	if req.Title != nil {
 		existing.Title = *req.Title
@@ -204,12 +238,6 @@ func (s *taskService) UpdateTask(ctx context.Context, id uuid.UUID, req *dto.Upd
 	if req.ActualCost != nil {
 		existing.ActualCost = *req.ActualCost
 	}
-	if req.DependsOn != nil {
-		existing.DependsOn = req.DependsOn
-	}
-	if req.BlockedBy != nil {
-		existing.BlockedBy = req.BlockedBy
-	}
 	if req.BlockReason != nil {
 		existing.BlockReason = *req.BlockReason
 	}
@@ -238,6 +266,18 @@ func (s *taskService) UpdateTask(ctx context.Context, id uuid.UUID, req *dto.Upd
 
 	s.logger.Info("task updated", "task_id", id)
 
+	// Dependencies and blocked status are maintained through the dependency table
+	if req.DependsOn != nil {
+		if err := s.replaceDependencies(ctx, existing, req.DependsOn); err != nil {
+			return nil, err
+		}
+	}
+	if statusChanged || req.DependsOn != nil {
+		if err := s.repos.ProjectTask.SyncDependencyStatus(ctx, id); err != nil {
+			s.logger.Error("failed to sync dependency status", "task_id", id, "error", err)
+		}
+	}
+
 	// Get updated task
 	updated, err := s.repos.ProjectTask.GetByID(ctx, id)
 	if err != nil {
@@ -258,6 +298,11 @@ func (s *taskService) DeleteTask(ctx context.Context, id uuid.UUID) error {
 		return errors.NewServiceError("DELETE_FAILED", "failed to delete task", err)
 	}
 
+	// Unblock the tasks that were waiting on it
+	if err := s.repos.ProjectTask.DetachDependencies(ctx, id); err != nil {
+		s.logger.Error("failed to detach task dependencies", "task_id", id, "error", err)
+	}
+
 	s.logger.Info("task deleted", "task_id", id)
 	return nil
 }
@@ -371,6 +416,10 @@ func (s *taskService) UpdateTaskStatus(ctx context.Context, id uuid.UUID, status
 		return errors.NewValidationError("task_id is required")
 	}
 
+	if err := s.checkDependenciesDone(ctx, id, status); err != nil {
+		return err
+	}
+
 	if err := s.repos.ProjectTask.UpdateTaskStatus(ctx, id, status); err != nil {
 		s.logger.Error("failed to update task status", "task_id", id, "error", err)
 		return errors.NewServiceError("UPDATE_FAILED", "failed to update status", err)
@@ -483,6 +532,212 @@ func (s *taskService) ReorderTasks(ctx context.Context, req *dto.ReorderTasksReq
 	return nil
 }
 
+// AddDependency makes a task wait on another task of the same project, blocking it
+// until that task is done
+func (s *taskService) AddDependency(ctx context.Context, id uuid.UUID, req *dto.AddTaskDependencyRequest) (*dto.TaskDependenciesResponse, error) {
+	if id == uuid.Nil || req.DependsOnTaskID == uuid.Nil {
+		return nil, errors.NewValidationError("task_id and depends_on_task_id are required")
+	}
+
+	task, err := s.repos.ProjectTask.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewNotFoundError("task not found")
+	}
+	if _, err := s.validateDependencies(ctx, task.ProjectID, id, []uuid.UUID{req.DependsOnTaskID}, false); err != nil {
+		return nil, err
+	}
+
+	if err := s.addDependency(ctx, id, req.DependsOnTaskID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("task dependency added", "task_id", id, "depends_on", req.DependsOnTaskID)
+	return s.GetDependencies(ctx, id)
+}
+
+// RemoveDependency stops a task waiting on another task, unblocking it if nothing else
+// is unfinished
+func (s *taskService) RemoveDependency(ctx context.Context, id, dependsOnID uuid.UUID) (*dto.TaskDependenciesResponse, error) {
+	if id == uuid.Nil || dependsOnID == uuid.Nil {
+		return nil, errors.NewValidationError("task_id and depends_on_task_id are required")
+	}
+
+	if err := s.repos.ProjectTask.RemoveDependency(ctx, id, dependsOnID); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("dependency not found")
+		}
+		s.logger.Error("failed to remove task dependency", "task_id", id, "depends_on", dependsOnID, "error", err)
+		return nil, errors.NewServiceError("DEPENDENCY_FAILED", "failed to remove dependency", err)
+	}
+
+	s.logger.Info("task dependency removed", "task_id", id, "depends_on", dependsOnID)
+	return s.GetDependencies(ctx, id)
+}
+
+// GetDependencies lists the tasks a task waits on and the tasks waiting on it
+func (s *taskService) GetDependencies(ctx context.Context, id uuid.UUID) (*dto.TaskDependenciesResponse, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("task_id is required")
+	}
+	if _, err := s.repos.ProjectTask.GetByID(ctx, id); err != nil {
+		return nil, errors.NewNotFoundError("task not found")
+	}
+
+	dependsOn, err := s.repos.ProjectTask.GetDependencies(ctx, id)
+	if err != nil {
+		return nil, errors.NewServiceError("FIND_FAILED", "failed to get dependencies", err)
+	}
+	dependents, err := s.repos.ProjectTask.GetDependents(ctx, id)
+	if err != nil {
+		return nil, errors.NewServiceError("FIND_FAILED", "failed to get dependent tasks", err)
+	}
+
+	resp := &dto.TaskDependenciesResponse{
+		TaskID:     id,
+		DependsOn:  dto.ToTaskResponses(dependsOn),
+		BlockedBy:  []*dto.TaskResponse{},
+		Dependents: dto.ToTaskResponses(dependents),
+	}
+	for _, task := range dependsOn {
+		if !task.IsCompleted() {
+			resp.BlockedBy = append(resp.BlockedBy, dto.ToTaskResponse(task))
+		}
+	}
+	return resp, nil
+}
+
+// validateDependencies checks that the tasks a task should depend on exist, belong to
+// the same project and do not close a cycle. With replace, the task's current
+// dependencies are ignored as the list replaces them. It returns the prerequisites.
+func (s *taskService) validateDependencies(ctx context.Context, projectID, taskID uuid.UUID, dependsOn []uuid.UUID, replace bool) ([]*models.ProjectTask, error) {
+	if len(dependsOn) == 0 {
+		return nil, nil
+	}
+
+	prerequisites := make([]*models.ProjectTask, 0, len(dependsOn))
+	seen := make(map[uuid.UUID]bool, len(dependsOn))
+	for _, dependsOnID := range dependsOn {
+		if seen[dependsOnID] {
+			continue
+		}
+		seen[dependsOnID] = true
+		if dependsOnID == taskID {
+			return nil, errors.NewValidationError("a task cannot depend on itself")
+		}
+		prerequisite, err := s.repos.ProjectTask.GetByID(ctx, dependsOnID)
+		if err != nil {
+			return nil, errors.NewNotFoundError("dependency task not found")
+		}
+		if prerequisite.ProjectID != projectID {
+			return nil, errors.NewValidationError(fmt.Sprintf("dependency %s belongs to another project", dependsOnID))
+		}
+		prerequisites = append(prerequisites, prerequisite)
+	}
+	if taskID == uuid.Nil {
+		return prerequisites, nil
+	}
+
+	existing, err := s.repos.ProjectTask.FindProjectDependencies(ctx, projectID)
+	if err != nil {
+		return nil, errors.NewServiceError("FIND_FAILED", "failed to get project dependencies", err)
+	}
+	deps := make([]*models.TaskDependency, 0, len(existing)+len(prerequisites))
+	for _, dep := range existing {
+		if !replace || dep.TaskID != taskID {
+			deps = append(deps, dep)
+		}
+	}
+	for _, prerequisite := range prerequisites {
+		if models.DependencyCreatesCycle(deps, taskID, prerequisite.ID) {
+			return nil, errors.NewValidationError(fmt.Sprintf("depending on %q would create a dependency cycle", prerequisite.Title))
+		}
+		deps = append(deps, &models.TaskDependency{TaskID: taskID, DependsOnTaskID: prerequisite.ID})
+	}
+	return prerequisites, nil
+}
+
+// replaceDependencies makes the task's dependencies match dependsOn
+func (s *taskService) replaceDependencies(ctx context.Context, task *models.ProjectTask, dependsOn []uuid.UUID) error {
+	current, err := s.repos.ProjectTask.GetDependencies(ctx, task.ID)
+	if err != nil {
+		return errors.NewServiceError("FIND_FAILED", "failed to get dependencies", err)
+	}
+
+	wanted := make(map[uuid.UUID]bool, len(dependsOn))
+	for _, id := range dependsOn {
+		wanted[id] = true
+	}
+	for _, prerequisite := range current {
+		if wanted[prerequisite.ID] {
+			delete(wanted, prerequisite.ID)
+			continue
+		}
+		if err := s.repos.ProjectTask.RemoveDependency(ctx, task.ID, prerequisite.ID); err != nil && !errors.IsNotFound(err) {
+			s.logger.Error("failed to remove task dependency", "task_id", task.ID, "depends_on", prerequisite.ID, "error", err)
+			return errors.NewServiceError("DEPENDENCY_FAILED", "failed to update dependencies", err)
+		}
+	}
+	for _, id := range dependsOn {
+		if !wanted[id] {
+			continue
+		}
+		delete(wanted, id)
+		if err := s.addDependency(ctx, task.ID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addDependency records a validated dependency
+func (s *taskService) addDependency(ctx context.Context, taskID, dependsOnID uuid.UUID) error {
+	if err := s.repos.ProjectTask.AddDependency(ctx, taskID, dependsOnID); err != nil {
+		if errors.IsConflict(err) {
+			return errors.NewConflictError("dependency would create a cycle with a change made by another request")
+		}
+		s.logger.Error("failed to add task dependency", "task_id", taskID, "depends_on", dependsOnID, "error", err)
+		return errors.NewServiceError("DEPENDENCY_FAILED", "failed to add dependency", err)
+	}
+	return nil
+}
+
+// requiresDependenciesDone reports whether a task can only move to status once the
+// tasks it depends on are done
+func requiresDependenciesDone(status models.TaskStatus) bool {
+	switch status {
+	case models.TaskStatusInProgress, models.TaskStatusReview, models.TaskStatusDone:
+		return true
+	}
+	return false
+}
+
+// checkDependenciesDone rejects starting or finishing a task that still waits on
+// unfinished tasks
+func (s *taskService) checkDependenciesDone(ctx context.Context, id uuid.UUID, status models.TaskStatus) error {
+	if !requiresDependenciesDone(status) {
+		return nil
+	}
+	blockers, err := s.repos.ProjectTask.GetBlockedBy(ctx, id)
+	if err != nil {
+		return errors.NewServiceError("FIND_FAILED", "failed to check dependencies", err)
+	}
+	return dependenciesPending(blockers)
+}
+
+// dependenciesPending returns a validation error if any of the prerequisites is unfinished
+func dependenciesPending(prerequisites []*models.ProjectTask) error {
+	pending := 0
+	for _, prerequisite := range prerequisites {
+		if !prerequisite.IsCompleted() {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return errors.NewValidationError(fmt.Sprintf("task is waiting on %d unfinished task(s)", pending))
+	}
+	return nil
+}
+
 // GetTaskStats retrieves task statistics for a project
 func (s *taskService) GetTaskStats(ctx context.Context, projectID uuid.UUID) (*dto.TaskStatsResponse, error) {
 	if projectID == uuid.Nil {
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// TaxService defines the interface for tax rule and tax calculation operations
+type TaxService interface {
+	// CRUD Operations
+	CreateTaxRule(ctx context.Context, req *dto.CreateTaxRuleRequest) (*dto.TaxRuleResponse, error)
+	GetTaxRule(ctx context.Context, id uuid.UUID) (*dto.TaxRuleResponse, error)
+	UpdateTaxRule(ctx context.Context, id uuid.UUID, req *dto.UpdateTaxRuleRequest) (*dto.TaxRuleResponse, error)
+	DeleteTaxRule(ctx context.Context, id uuid.UUID) error
+	ListTaxRules(ctx context.Context, tenantID uuid.UUID) ([]*dto.TaxRuleResponse, error)
+
+	// Calculation
+	CalculateTax(ctx context.Context, tenantID uuid.UUID, category models.TaxCategory, location *models.Location, amount float64) (models.TaxLines, error)
+	QuoteTax(ctx context.Context, req *dto.TaxQuoteRequest) (*dto.TaxQuoteResponse, error)
+}
+
+// taxService implements TaxService
+type taxService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewTaxService creates a new TaxService instance
+func NewTaxService(repos *repository.Repositories, logger log.AllLogger) TaxService {
+	return &taxService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ============================================================================
+// CRUD Operations
+// ============================================================================
+
+// CreateTaxRule creates a default, category, national or regional tax rule
+func (s *taxService) CreateTaxRule(ctx context.Context, req *dto.CreateTaxRuleRequest) (*dto.TaxRuleResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	rule := &models.TaxRule{
+		TenantID: req.TenantID,
+		Name:     strings.TrimSpace(req.Name),
+		Category: req.Category,
+		Country:  strings.ToUpper(strings.TrimSpace(req.Country)),
+		Region:   strings.TrimSpace(req.Region),
+		Rate:     req.Rate,
+		IsActive: req.IsActive == nil || *req.IsActive,
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.TaxRule.Create(ctx, rule); err != nil {
+		s.logger.Error("failed to create tax rule", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("TAX_RULE_CREATE_FAILED", "failed to create tax rule", err)
+	}
+
+	s.logger.Info("tax rule created", "tax_rule_id", rule.ID, "tenant_id", rule.TenantID, "rate", rule.Rate)
+	return dto.ToTaxRuleResponse(rule), nil
+}
+
+// GetTaxRule retrieves a tax rule by ID
+func (s *taxService) GetTaxRule(ctx context.Context, id uuid.UUID) (*dto.TaxRuleResponse, error) {
+	rule, err := s.getTaxRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToTaxRuleResponse(rule), nil
+}
+
+// UpdateTaxRule updates a tax rule. Bookings already priced keep the tax they were charged.
+func (s *taxService) UpdateTaxRule(ctx context.Context, id uuid.UUID, req *dto.UpdateTaxRuleRequest) (*dto.TaxRuleResponse, error) {
+	rule, err := s.getTaxRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Category != nil {
+		rule.Category = *req.Category
+	}
+	if req.Country != nil {
+		rule.Country = strings.ToUpper(strings.TrimSpace(*req.Country))
+	}
+	if req.Region != nil {
+		rule.Region = strings.TrimSpace(*req.Region)
+	}
+	if req.Rate != nil {
+		rule.Rate = *req.Rate
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.TaxRule.Update(ctx, rule); err != nil {
+		s.logger.Error("failed to update tax rule", "tax_rule_id", id, "error", err)
+		return nil, errors.NewServiceError("TAX_RULE_UPDATE_FAILED", "failed to update tax rule", err)
+	}
+
+	return dto.ToTaxRuleResponse(rule), nil
+}
+
+// DeleteTaxRule deletes a tax rule
+func (s *taxService) DeleteTaxRule(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getTaxRule(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repos.TaxRule.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete tax rule", "tax_rule_id", id, "error", err)
+		return errors.NewServiceError("TAX_RULE_DELETE_FAILED", "failed to delete tax rule", err)
+	}
+
+	return nil
+}
+
+// ListTaxRules lists all tax rules of a tenant
+func (s *taxService) ListTaxRules(ctx context.Context, tenantID uuid.UUID) ([]*dto.TaxRuleResponse, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	rules, err := s.repos.TaxRule.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("TAX_RULE_LIST_FAILED", "failed to list tax rules", err)
+	}
+
+	return dto.ToTaxRuleResponses(rules), nil
+}
+
+// ============================================================================
+// Calculation
+// ============================================================================
+
+// CalculateTax returns the tax a tenant charges on amount for a service of the given
+// category. The place of supply is the service location when it has a country, else
+// the tenant's tax country. Tenants without tax rules fall back to the tax rate in
+// their settings, and the tenant's IncludeTaxInPrice setting decides whether amount
+// already contains the tax.
+func (s *taxService) CalculateTax(ctx context.Context, tenantID uuid.UUID, category models.TaxCategory, location *models.Location, amount float64) (models.TaxLines, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("TENANT_GET_FAILED", "failed to get tenant", err)
+	}
+
+	country, region := tenant.Settings.TaxCountry, tenant.Settings.TaxRegion
+	if location != nil && location.Country != "" {
+		country, region = location.Country, location.State
+	}
+
+	return s.calculate(ctx, tenant, category, country, region, amount)
+}
+
+// QuoteTax previews the tax on an amount, for price displays and checkout
+func (s *taxService) QuoteTax(ctx context.Context, req *dto.TaxQuoteRequest) (*dto.TaxQuoteResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	category := req.Category
+	if req.ServiceID != nil {
+		svc, err := s.repos.Service.GetByID(ctx, *req.ServiceID)
+		if err != nil || svc.TenantID != req.TenantID {
+			return nil, errors.NewNotFoundError("service")
+		}
+		category = svc.TaxCategory
+	}
+	if category == "" {
+		category = models.TaxCategoryStandard
+	}
+
+	tenant, err := s.repos.Tenant.GetByID(ctx, req.TenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("TENANT_GET_FAILED", "failed to get tenant", err)
+	}
+
+	country, region := req.Country, req.Region
+	if country == "" {
+		country, region = tenant.Settings.TaxCountry, tenant.Settings.TaxRegion
+	}
+
+	lines, err := s.calculate(ctx, tenant, category, country, region, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if lines == nil {
+		lines = models.TaxLines{}
+	}
+
+	return &dto.TaxQuoteResponse{
+		Category:  category,
+		Country:   strings.ToUpper(country),
+		Region:    region,
+		Amount:    req.Amount,
+		TaxLines:  lines,
+		TaxAmount: lines.Total(),
+		Total:     req.Amount + lines.Exclusive(),
+	}, nil
+}
+
+// calculate applies the tenant's rule for the category and place to amount
+func (s *taxService) calculate(ctx context.Context, tenant *models.Tenant, category models.TaxCategory, country, region string, amount float64) (models.TaxLines, error) {
+	if amount <= 0 {
+		return nil, nil
+	}
+	if category == "" {
+		category = models.TaxCategoryStandard
+	}
+
+	rules, err := s.repos.TaxRule.FindActive(ctx, tenant.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("TAX_RULE_LIST_FAILED", "failed to load tax rules", err)
+	}
+	if len(rules) == 0 {
+		if rule := models.TaxRuleFromSettings(tenant.ID, tenant.Settings); rule != nil {
+			rules = append(rules, rule)
+		}
+	}
+
+	rule := models.ResolveTaxRule(rules, category, country, region)
+	if rule == nil {
+		return nil, nil
+	}
+
+	return models.TaxLines{rule.Apply(amount, tenant.Settings.IncludeTaxInPrice)}, nil
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+func (s *taxService) getTaxRule(ctx context.Context, id uuid.UUID) (*models.TaxRule, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("tax rule ID is required")
+	}
+
+	rule, err := s.repos.TaxRule.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("tax rule not found")
+		}
+		return nil, errors.NewServiceError("TAX_RULE_GET_FAILED", "failed to get tax rule", err)
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	stdErrors "errors"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// domainVerificationLabel is the name under a custom domain of the TXT record
+	// proving a tenant owns it
+	domainVerificationLabel = "_kraftivibe-verification"
+	// domainVerificationPrefix starts the value of the TXT record, followed by the
+	// tenant's verification token
+	domainVerificationPrefix = "kraftivibe-verification="
+)
+
+const (
+	// tenantHostTTL is how long the tenant served at a host is cached, so a tenant's
+	// old domain is served for up to this long after it changes
+	tenantHostTTL = 10 * time.Minute
+	// unknownHostTTL is how long hosts serving no tenant are cached, shorter so new
+	// tenants and domains are served soon
+	unknownHostTTL = time.Minute
+	// noTenant is cached for hosts serving no tenant
+	noTenant = "none"
+)
+
+// DNSResolver looks up DNS records; net.DefaultResolver is one
+type DNSResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// TenantDomainService defines the interface for serving tenants at their own hosts:
+// a subdomain of the platform's base domain, and a custom domain once the tenant proves
+// it owns it with a TXT record
+type TenantDomainService interface {
+	// TenantByHost returns the tenant served at host. tenantHost reports whether the
+	// host is a tenant's subdomain or custom domain, which is false for the API's own
+	// hosts; tenant subdomains that no tenant has return uuid.Nil and true.
+	TenantByHost(ctx context.Context, host string) (tenantID uuid.UUID, tenantHost bool, err error)
+
+	// Custom Domain Verification
+	GetDomainVerification(ctx context.Context, tenantID uuid.UUID) (*dto.DomainVerificationResponse, error)
+	VerifyDomain(ctx context.Context, tenantID uuid.UUID) (*dto.DomainVerificationResponse, error)
+}
+
+// tenantDomainService implements TenantDomainService
+type tenantDomainService struct {
+	repos      *repository.Repositories
+	logger     log.AllLogger
+	cache      cache.Cache
+	baseDomain string
+	dns        DNSResolver
+}
+
+// NewTenantDomainService creates a new TenantDomainService instance serving tenant
+// subdomains of baseDomain. Hosts are resolved to tenants through the cache, which may
+// be nil to look them up every time; dns defaults to net.DefaultResolver.
+func NewTenantDomainService(repos *repository.Repositories, logger log.AllLogger, hostCache cache.Cache, baseDomain string, dns DNSResolver) TenantDomainService {
+	if dns == nil {
+		dns = net.DefaultResolver
+	}
+	return &tenantDomainService{
+		repos:      repos,
+		logger:     logger,
+		cache:      hostCache,
+		baseDomain: normalizeHost(baseDomain),
+		dns:        dns,
+	}
+}
+
+// ============================================================================
+// Host Resolution
+// ============================================================================
+
+// TenantByHost returns the tenant served at host
+func (s *tenantDomainService) TenantByHost(ctx context.Context, host string) (uuid.UUID, bool, error) {
+	host = normalizeHost(host)
+	if host == "" || net.ParseIP(host) != nil || !strings.Contains(host, ".") || host == s.baseDomain {
+		return uuid.Nil, false, nil
+	}
+
+	// Subdomains of the base domain are tenants' unless reserved, such as api or www
+	subdomain, isSubdomain := strings.CutSuffix(host, "."+s.baseDomain)
+	if isSubdomain && (strings.Contains(subdomain, ".") || !isValidSubdomain(subdomain)) {
+		return uuid.Nil, false, nil
+	}
+
+	key := tenantHostKey(host)
+	if s.cache != nil {
+		cached, err := s.cache.Get(ctx, key)
+		switch {
+		case err == nil && cached == noTenant:
+			return uuid.Nil, isSubdomain, nil
+		case err == nil:
+			if tenantID, parseErr := uuid.Parse(cached); parseErr == nil {
+				return tenantID, true, nil
+			}
+		case !stdErrors.Is(err, cache.ErrCacheMiss):
+			s.logger.Warn("failed to read cached tenant host; looking it up", "host", host, "error", err)
+		}
+	}
+
+	var tenant *models.Tenant
+	var err error
+	if isSubdomain {
+		tenant, err = s.repos.Tenant.FindBySubdomain(ctx, subdomain)
+	} else {
+		tenant, err = s.repos.Tenant.FindByDomain(ctx, host)
+		// Custom domains are only served once their ownership is proven
+		if err == nil && tenant.DomainVerifiedAt == nil {
+			tenant = nil
+		}
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return uuid.Nil, false, errors.NewServiceError("TENANT_HOST_LOOKUP_FAILED", "failed to resolve tenant host", err)
+	}
+
+	if tenant == nil || err != nil {
+		s.cacheHost(ctx, key, noTenant, unknownHostTTL)
+		return uuid.Nil, isSubdomain, nil
+	}
+	s.cacheHost(ctx, key, tenant.ID.String(), tenantHostTTL)
+	return tenant.ID, true, nil
+}
+
+func (s *tenantDomainService) cacheHost(ctx context.Context, key, value string, ttl time.Duration) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Set(ctx, key, value, ttl); err != nil {
+		s.logger.Warn("failed to cache tenant host", "key", key, "error", err)
+	}
+}
+
+// ============================================================================
+// Custom Domain Verification
+// ============================================================================
+
+// GetDomainVerification returns the TXT record the tenant proves it owns its custom
+// domain with, issuing its verification token the first time
+func (s *tenantDomainService) GetDomainVerification(ctx context.Context, tenantID uuid.UUID) (*dto.DomainVerificationResponse, error) {
+	tenant, err := s.loadDomainTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenant.DomainVerificationToken == "" {
+		token := make([]byte, 16)
+		if _, err := rand.Read(token); err != nil {
+			return nil, errors.NewInternalError("failed to generate domain verification token", err)
+		}
+		tenant.DomainVerificationToken = hex.EncodeToString(token)
+		if err := s.repos.Tenant.Update(ctx, tenant); err != nil {
+			s.logger.Error("failed to save domain verification token", "tenant_id", tenantID, "error", err)
+			return nil, errors.NewServiceError("DOMAIN_VERIFICATION_FAILED", "failed to issue domain verification token", err)
+		}
+	}
+
+	return domainVerificationResponse(tenant), nil
+}
+
+// VerifyDomain looks up the TXT record of the tenant's custom domain, and serves the
+// tenant at the domain once the record carries its verification token
+func (s *tenantDomainService) VerifyDomain(ctx context.Context, tenantID uuid.UUID) (*dto.DomainVerificationResponse, error) {
+	tenant, err := s.loadDomainTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.DomainVerifiedAt != nil {
+		return domainVerificationResponse(tenant), nil
+	}
+	if tenant.DomainVerificationToken == "" {
+		return nil, errors.NewValidationError("get the domain's verification record and add it to its DNS first")
+	}
+
+	response := domainVerificationResponse(tenant)
+	records, err := s.dns.LookupTXT(ctx, response.RecordName)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if !stdErrors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			s.logger.Warn("failed to look up domain verification record", "tenant_id", tenantID, "record", response.RecordName, "error", err)
+		}
+		records = nil
+	}
+	if !slices.Contains(records, response.RecordValue) {
+		return nil, errors.NewAppError("DOMAIN_NOT_VERIFIED",
+			"the verification TXT record was not found; DNS changes can take a while to propagate",
+			http.StatusUnprocessableEntity)
+	}
+
+	now := time.Now()
+	tenant.DomainVerifiedAt = &now
+	if err := s.repos.Tenant.Update(ctx, tenant); err != nil {
+		s.logger.Error("failed to save domain verification", "tenant_id", tenantID, "error", err)
+		return nil, errors.NewServiceError("DOMAIN_VERIFICATION_FAILED", "failed to save domain verification", err)
+	}
+
+	// Serve the tenant at the domain right away rather than once the miss expires
+	if s.cache != nil {
+		if err := s.cache.Delete(ctx, tenantHostKey(tenant.Domain)); err != nil {
+			s.logger.Warn("failed to clear cached tenant host", "host", tenant.Domain, "error", err)
+		}
+	}
+
+	s.logger.Info("custom domain verified", "tenant_id", tenantID, "domain", tenant.Domain)
+	return domainVerificationResponse(tenant), nil
+}
+
+// loadDomainTenant returns the tenant, which is to have a custom domain
+func (s *tenantDomainService) loadDomainTenant(ctx context.Context, tenantID uuid.UUID) (*models.Tenant, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("tenant")
+		}
+		return nil, errors.NewServiceError("TENANT_LOOKUP_FAILED", "failed to get tenant", err)
+	}
+	if tenant.Domain == "" {
+		return nil, errors.NewValidationError("the organization has no custom domain; set one first")
+	}
+	return tenant, nil
+}
+
+func domainVerificationResponse(tenant *models.Tenant) *dto.DomainVerificationResponse {
+	return &dto.DomainVerificationResponse{
+		Domain:      tenant.Domain,
+		RecordType:  "TXT",
+		RecordName:  domainVerificationLabel + "." + tenant.Domain,
+		RecordValue: domainVerificationPrefix + tenant.DomainVerificationToken,
+		Verified:    tenant.DomainVerifiedAt != nil,
+		VerifiedAt:  tenant.DomainVerifiedAt,
+	}
+}
+
+// tenantHostKey is the cache key of the tenant served at host
+func tenantHostKey(host string) string {
+	return "tenant_host:" + host
+}
+
+// normalizeHost lowercases a host and drops its port and trailing dot
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
@@ -0,0 +1,34 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantByHost_NotTenantHosts(t *testing.T) {
+	// Hosts that are no tenant's are told apart without a lookup
+	domains := service.NewTenantDomainService(&repository.Repositories{}, &MockLogger{}, nil, "kraftivibe.com", nil)
+
+	for _, host := range []string{
+		"",
+		"localhost:8080",
+		"127.0.0.1:3000",
+		"kraftivibe.com",
+		"KraftiVibe.com.",
+		"api.kraftivibe.com",
+		"www.kraftivibe.com:443",
+		"a.b.kraftivibe.com",
+	} {
+		tenantID, tenantHost, err := domains.TenantByHost(context.Background(), host)
+		require.NoError(t, err, host)
+		assert.Equal(t, uuid.Nil, tenantID, host)
+		assert.False(t, tenantHost, host)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"time"
 
+	"Krafti_Vibe/internal/auth"
 	"Krafti_Vibe/internal/domain/models"
+	pkgErrors "Krafti_Vibe/internal/pkg/errors"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
 
@@ -37,6 +39,13 @@ var (
 	ErrUserBelongsToAnotherTenant = errors.New("user already belongs to another tenant")
 )
 
+// PlanEnforcer holds tenants to the limits of their subscription plan
+type PlanEnforcer interface {
+	// EnforcePlan returns a 402 AppError wrapping a PlanLimitError when the tenant has
+	// reached the limit
+	EnforcePlan(ctx context.Context, tenantID uuid.UUID, limit models.PlanLimit) error
+}
+
 // TenantInvitationService defines the interface for tenant invitation operations
 type TenantInvitationService interface {
 	// Core CRUD Operations
@@ -53,6 +62,7 @@ type TenantInvitationService interface {
 	// Query Operations
 	GetPendingInvitations(ctx context.Context, tenantID uuid.UUID) ([]*dto.InvitationResponse, error)
 	GetInvitationsByEmail(ctx context.Context, email string) ([]*dto.InvitationResponse, error)
+	GetTeam(ctx context.Context, tenantID uuid.UUID) (*dto.TeamResponse, error)
 
 	// Cleanup Operations
 	DeleteExpiredInvitations(ctx context.Context) (int64, error)
@@ -60,18 +70,27 @@ type TenantInvitationService interface {
 
 // tenantInvitationService implements TenantInvitationService
 type tenantInvitationService struct {
-	repos  *repository.Repositories
-	logger *zap.Logger
+	repos    *repository.Repositories
+	logger   *zap.Logger
+	plans    PlanEnforcer
+	identity IdentityProvider
 }
 
-// NewTenantInvitationService creates a new tenant invitation service
+// NewTenantInvitationService creates a new tenant invitation service. Artisans are
+// invited within the plan's artisan seats when plans is set; invitees without an
+// account get one in Zitadel on accepting when identity is set, and otherwise link
+// theirs by email on first sign-in.
 func NewTenantInvitationService(
 	repos *repository.Repositories,
 	logger *zap.Logger,
+	plans PlanEnforcer,
+	identity IdentityProvider,
 ) TenantInvitationService {
 	return &tenantInvitationService{
-		repos:  repos,
-		logger: logger,
+		repos:    repos,
+		logger:   logger,
+		plans:    plans,
+		identity: identity,
 	}
 }
 
@@ -114,9 +133,9 @@ func (s *tenantInvitationService) CreateInvitation(ctx context.Context, req *dto
 		return nil, err
 	}
 
-	// Check user limit
-	if tenant.MaxUsers > 0 && tenant.CurrentUsers >= tenant.MaxUsers {
-		return nil, ErrTenantLimitReached
+	// Check seats, which pending invitations hold
+	if err := s.checkSeats(ctx, tenant, req.Role); err != nil {
+		return nil, err
 	}
 
 	// Generate secure token
@@ -155,7 +174,9 @@ func (s *tenantInvitationService) CreateInvitation(ctx context.Context, req *dto
 
 	// TODO: Send invitation email asynchronously via email service
 
-	return dto.ToInvitationResponse(invitation, tenant), nil
+	response := dto.ToInvitationResponse(invitation, tenant)
+	response.Token = invitation.Token
+	return response, nil
 }
 
 // GetInvitation retrieves an invitation by ID
@@ -245,6 +266,7 @@ func (s *tenantInvitationService) ListInvitations(ctx context.Context, tenantID
 
 // AcceptInvitation accepts a tenant invitation
 func (s *tenantInvitationService) AcceptInvitation(ctx context.Context, req *dto.AcceptInvitationRequest) (*dto.AcceptInvitationResponse, error) {
+	req.Sanitize()
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
@@ -276,30 +298,34 @@ func (s *tenantInvitationService) AcceptInvitation(ctx context.Context, req *dto
 		return nil, err
 	}
 
-	// Check user limit
-	if tenant.MaxUsers > 0 && tenant.CurrentUsers >= tenant.MaxUsers {
-		return nil, ErrTenantLimitReached
-	}
+	// The invitation has held its seat since it was created, so seats aren't checked
+	// again here
 
 	// Handle user creation or update
-	user, err := s.handleUserForInvitation(ctx, invitation)
+	user, zitadelUserID, err := s.handleUserForInvitation(ctx, invitation, req)
 	if err != nil {
 		return nil, err
 	}
 
-	// Mark invitation as accepted
-	now := time.Now()
-	invitation.AcceptedAt = &now
-	if err := s.repos.TenantInvitation.Update(ctx, invitation); err != nil {
-		s.logger.Error("failed to update invitation", zap.Error(err))
-		// Continue - user is already added
-	}
-
 	// Increment user count
 	if err := s.repos.Tenant.IncrementUserCount(ctx, tenant.ID); err != nil {
 		s.logger.Warn("failed to increment user count", zap.Error(err))
 	}
 
+	// Invite new accounts to set up their sign-in; a failed invite leaves them to
+	// reset their password from the sign-in page
+	inviteSent := false
+	if zitadelUserID != "" {
+		if err := s.identity.SendInvite(ctx, zitadelUserID); err != nil {
+			s.logger.Error("failed to send account invite",
+				zap.String("user_id", user.ID.String()),
+				zap.Error(err),
+			)
+		} else {
+			inviteSent = true
+		}
+	}
+
 	s.logger.Info("invitation accepted successfully",
 		zap.String("invitation_id", invitation.ID.String()),
 		zap.String("user_id", user.ID.String()),
@@ -313,6 +339,7 @@ func (s *tenantInvitationService) AcceptInvitation(ctx context.Context, req *dto
 		TenantName: tenant.Name,
 		UserID:     user.ID,
 		Role:       user.Role,
+		InviteSent: inviteSent,
 	}, nil
 }
 
@@ -388,7 +415,9 @@ func (s *tenantInvitationService) ResendInvitation(ctx context.Context, id uuid.
 	tenant, _ := s.repos.Tenant.GetByID(ctx, tenantID)
 
 	s.logger.Info("invitation resent successfully", zap.String("invitation_id", id.String()))
-	return dto.ToInvitationResponse(invitation, tenant), nil
+	response := dto.ToInvitationResponse(invitation, tenant)
+	response.Token = invitation.Token
+	return response, nil
 }
 
 // ============================================================================
@@ -434,6 +463,41 @@ func (s *tenantInvitationService) GetInvitationsByEmail(ctx context.Context, ema
 	return responses, nil
 }
 
+// GetTeam retrieves a tenant's staff and the invitations still open
+func (s *tenantInvitationService) GetTeam(ctx context.Context, tenantID uuid.UUID) (*dto.TeamResponse, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, ErrTenantNotFound
+	}
+
+	filters := repository.UserFilters{
+		Roles:     []models.UserRole{models.UserRoleTenantOwner, models.UserRoleTenantAdmin, models.UserRoleArtisan, models.UserRoleTeamMember},
+		TenantIDs: []uuid.UUID{tenantID},
+	}
+	users, _, err := s.repos.User.FindByFilters(ctx, filters, repository.PaginationParams{Page: 1, PageSize: 100})
+	if err != nil {
+		s.logger.Error("failed to get team members", zap.Error(err))
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	invitations, err := s.GetPendingInvitations(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*dto.TeamMemberResponse, 0, len(users))
+	for _, user := range users {
+		members = append(members, dto.ToTeamMemberResponse(user))
+	}
+
+	return &dto.TeamResponse{
+		Members:            members,
+		PendingInvitations: invitations,
+		SeatsUsed:          len(members) + len(invitations),
+		SeatsAllowed:       tenant.MaxUsers,
+	}, nil
+}
+
 // ============================================================================
 // Cleanup Operations
 // ============================================================================
@@ -464,6 +528,43 @@ func (s *tenantInvitationService) validateTenantStatus(tenant *models.Tenant) er
 	if tenant.Status == models.TenantStatusCancelled {
 		return ErrTenantCancelled
 	}
+	if tenant.Status == models.TenantStatusClosed {
+		return ErrTenantClosed
+	}
+	return nil
+}
+
+// checkSeats refuses an invitation the tenant has no seat left for. Every member takes
+// one of the tenant's user seats, and artisans also one of the plan's artisan seats;
+// pending invitations hold theirs until accepted or expired.
+func (s *tenantInvitationService) checkSeats(ctx context.Context, tenant *models.Tenant, role models.UserRole) error {
+	if tenant.MaxUsers > 0 {
+		pending, err := s.repos.TenantInvitation.FindPendingByTenant(ctx, tenant.ID)
+		if err != nil {
+			s.logger.Error("failed to count pending invitations", zap.Error(err))
+			return fmt.Errorf("failed to count pending invitations: %w", err)
+		}
+		if tenant.CurrentUsers+len(pending) >= tenant.MaxUsers {
+			return ErrTenantLimitReached
+		}
+	}
+
+	if role != models.UserRoleArtisan || s.plans == nil {
+		return nil
+	}
+
+	// Failures to evaluate the plan let the invitation through, as with RequirePlan
+	err := s.plans.EnforcePlan(ctx, tenant.ID, models.PlanLimitArtisanSeats)
+	var planErr *pkgErrors.PlanLimitError
+	if errors.As(err, &planErr) {
+		return err
+	}
+	if err != nil {
+		s.logger.Warn("failed to check artisan seats; inviting anyway",
+			zap.String("tenant_id", tenant.ID.String()),
+			zap.Error(err),
+		)
+	}
 	return nil
 }
 
@@ -483,39 +584,108 @@ func (s *tenantInvitationService) checkExistingInvitation(ctx context.Context, t
 	return nil
 }
 
-// handleUserForInvitation handles user creation or update during invitation acceptance
-func (s *tenantInvitationService) handleUserForInvitation(ctx context.Context, invitation *models.TenantInvitation) (*models.User, error) {
+// handleUserForInvitation handles user creation or update during invitation acceptance,
+// marking the invitation accepted along with it. New users get an account in Zitadel,
+// whose ID is returned for inviting them to set up their sign-in; it is empty when
+// the user is to link an account they have already, and artisans get their profile.
+func (s *tenantInvitationService) handleUserForInvitation(ctx context.Context, invitation *models.TenantInvitation, req *dto.AcceptInvitationRequest) (*models.User, string, error) {
+	now := time.Now()
+	invitation.AcceptedAt = &now
+
 	existingUser, err := s.repos.User.GetByEmail(ctx, invitation.Email)
 
 	if err == nil && existingUser != nil {
 		// Existing user - update their tenant
 		if existingUser.TenantID != nil && *existingUser.TenantID != invitation.TenantID {
-			return nil, ErrUserBelongsToAnotherTenant
+			return nil, "", ErrUserBelongsToAnotherTenant
 		}
 
 		existingUser.TenantID = &invitation.TenantID
 		existingUser.Role = invitation.Role
 
-		if err := s.repos.User.Update(ctx, existingUser); err != nil {
-			return nil, fmt.Errorf("failed to update user: %w", err)
+		err := s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+			if err := tx.User.Update(ctx, existingUser); err != nil {
+				return fmt.Errorf("failed to update user: %w", err)
+			}
+			if err := s.createArtisanProfile(ctx, tx, existingUser); err != nil {
+				return err
+			}
+			return tx.TenantInvitation.Update(ctx, invitation)
+		})
+		if err != nil {
+			return nil, "", err
 		}
 
-		return existingUser, nil
+		return existingUser, "", nil
+	}
+
+	// New user - create their account, unless they have one already, which links to
+	// them by email when they first sign in
+	var zitadelUserID string
+	if s.identity != nil {
+		zitadelUserID, err = s.identity.CreateUser(ctx, invitation.Email, req.FirstName, req.LastName)
+		if err != nil && !errors.Is(err, auth.ErrUserExists) {
+			s.logger.Error("failed to create invitee account", zap.String("email", invitation.Email), zap.Error(err))
+			return nil, "", fmt.Errorf("failed to create account: %w", err)
+		}
 	}
 
-	// New user - create account with pending status
 	user := &models.User{
-		Email:    invitation.Email,
-		TenantID: &invitation.TenantID,
-		Role:     invitation.Role,
-		Status:   models.UserStatusPending, // They need to complete registration
+		Email:         invitation.Email,
+		FirstName:     req.FirstName,
+		LastName:      req.LastName,
+		TenantID:      &invitation.TenantID,
+		Role:          invitation.Role,
+		Status:        models.UserStatusPending, // Until they first sign in
+		ZitadelUserID: zitadelUserID,
+	}
+	if zitadelUserID != "" {
+		user.AuthProvider = "zitadel"
 	}
 
-	if err := s.repos.User.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+	err = s.repos.Transaction(ctx, func(tx *repository.Repositories) error {
+		if err := tx.User.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if err := s.createArtisanProfile(ctx, tx, user); err != nil {
+			return err
+		}
+		return tx.TenantInvitation.Update(ctx, invitation)
+	})
+	if err != nil {
+		if zitadelUserID != "" {
+			if deleteErr := s.identity.DeleteUser(ctx, zitadelUserID); deleteErr != nil {
+				s.logger.Error("failed to remove account of failed invitation acceptance",
+					zap.String("zitadel_user_id", zitadelUserID),
+					zap.Error(deleteErr),
+				)
+			}
+		}
+		return nil, "", err
 	}
 
-	return user, nil
+	return user, zitadelUserID, nil
+}
+
+// createArtisanProfile gives users invited as artisans their profile, which takes the
+// artisan seat their invitation held
+func (s *tenantInvitationService) createArtisanProfile(ctx context.Context, tx *repository.Repositories, user *models.User) error {
+	if user.Role != models.UserRoleArtisan {
+		return nil
+	}
+	if existing, err := tx.Artisan.FindByUserID(ctx, user.ID); err == nil && existing != nil {
+		return nil
+	}
+
+	artisan := &models.Artisan{
+		UserID:      user.ID,
+		TenantID:    *user.TenantID,
+		IsAvailable: true,
+	}
+	if err := tx.Artisan.Create(ctx, artisan); err != nil {
+		return fmt.Errorf("failed to create artisan profile: %w", err)
+	}
+	return nil
 }
 
 // generateSecureToken generates a cryptographically secure random token
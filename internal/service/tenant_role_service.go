@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/authz"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// TenantRoleService defines the interface for managing a tenant's custom roles
+type TenantRoleService interface {
+	// CRUD Operations
+	CreateRole(ctx context.Context, req *dto.CreateTenantRoleRequest) (*dto.TenantRoleResponse, error)
+	GetRole(ctx context.Context, id uuid.UUID) (*dto.TenantRoleResponse, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, req *dto.UpdateTenantRoleRequest) (*dto.TenantRoleResponse, error)
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	ListRoles(ctx context.Context, tenantID uuid.UUID) ([]*dto.TenantRoleResponse, error)
+
+	// Assignments
+	AssignRole(ctx context.Context, roleID, userID uuid.UUID) error
+	UnassignRole(ctx context.Context, roleID, userID uuid.UUID) error
+	ListAssignments(ctx context.Context, roleID uuid.UUID) ([]*dto.TenantRoleAssignmentResponse, error)
+
+	// ListPermissions returns the actions custom roles may grant
+	ListPermissions() []string
+}
+
+// tenantRoleService implements TenantRoleService
+type tenantRoleService struct {
+	repos      *repository.Repositories
+	logger     log.AllLogger
+	authorizer Authorizer
+}
+
+// NewTenantRoleService creates a new TenantRoleService instance
+func NewTenantRoleService(repos *repository.Repositories, logger log.AllLogger) TenantRoleService {
+	return &tenantRoleService{
+		repos:      repos,
+		logger:     logger,
+		authorizer: NewAuthorizer(repos, logger),
+	}
+}
+
+// ============================================================================
+// CRUD Operations
+// ============================================================================
+
+// CreateRole creates a custom role for the tenant
+func (s *tenantRoleService) CreateRole(ctx context.Context, req *dto.CreateTenantRoleRequest) (*dto.TenantRoleResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+	if err := s.authorizer.Can(ctx, authz.ActionRoleManage, authz.Tenant(req.TenantID)); err != nil {
+		return nil, err
+	}
+
+	permissions, err := normalizeRolePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &models.TenantRole{
+		TenantID:    req.TenantID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+	}
+	if err := role.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.TenantRole.Create(ctx, role); err != nil {
+		if errors.IsDuplicate(err) {
+			return nil, errors.NewConflictError("a role with this name already exists")
+		}
+		s.logger.Error("failed to create tenant role", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("TENANT_ROLE_CREATE_FAILED", "failed to create role", err)
+	}
+
+	s.logger.Info("tenant role created", "tenant_role_id", role.ID, "tenant_id", role.TenantID)
+	return dto.ToTenantRoleResponse(role), nil
+}
+
+// GetRole retrieves a custom role by ID
+func (s *tenantRoleService) GetRole(ctx context.Context, id uuid.UUID) (*dto.TenantRoleResponse, error) {
+	role, err := s.getRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToTenantRoleResponse(role), nil
+}
+
+// UpdateRole updates a custom role. Changed permissions apply to its users from their
+// next request.
+func (s *tenantRoleService) UpdateRole(ctx context.Context, id uuid.UUID, req *dto.UpdateTenantRoleRequest) (*dto.TenantRoleResponse, error) {
+	role, err := s.getRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		role.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		role.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Permissions != nil {
+		permissions, err := normalizeRolePermissions(*req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		role.Permissions = permissions
+	}
+
+	if err := role.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.TenantRole.Update(ctx, role); err != nil {
+		if errors.IsDuplicate(err) {
+			return nil, errors.NewConflictError("a role with this name already exists")
+		}
+		s.logger.Error("failed to update tenant role", "tenant_role_id", id, "error", err)
+		return nil, errors.NewServiceError("TENANT_ROLE_UPDATE_FAILED", "failed to update role", err)
+	}
+
+	s.logger.Info("tenant role updated", "tenant_role_id", id)
+	return dto.ToTenantRoleResponse(role), nil
+}
+
+// DeleteRole deletes a custom role, taking it away from every user it was assigned to
+func (s *tenantRoleService) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getRole(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repos.TenantRole.DeleteRole(ctx, id); err != nil {
+		if errors.IsNotFoundError(err) {
+			return errors.NewNotFoundError("role not found")
+		}
+		s.logger.Error("failed to delete tenant role", "tenant_role_id", id, "error", err)
+		return errors.NewServiceError("TENANT_ROLE_DELETE_FAILED", "failed to delete role", err)
+	}
+
+	s.logger.Info("tenant role deleted", "tenant_role_id", id)
+	return nil
+}
+
+// ListRoles lists a tenant's custom roles
+func (s *tenantRoleService) ListRoles(ctx context.Context, tenantID uuid.UUID) ([]*dto.TenantRoleResponse, error) {
+	if err := s.authorizer.Can(ctx, authz.ActionRoleManage, authz.Tenant(tenantID)); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.repos.TenantRole.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("TENANT_ROLE_LIST_FAILED", "failed to list roles", err)
+	}
+	return dto.ToTenantRoleResponses(roles), nil
+}
+
+// ============================================================================
+// Assignments
+// ============================================================================
+
+// AssignRole gives a user of the role's tenant the role; assigning it again does nothing
+func (s *tenantRoleService) AssignRole(ctx context.Context, roleID, userID uuid.UUID) error {
+	role, err := s.getRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return errors.NewNotFoundError("user not found")
+		}
+		return errors.NewServiceError("USER_GET_FAILED", "failed to get user", err)
+	}
+	if user.IsPlatformUser || user.TenantID == nil || *user.TenantID != role.TenantID {
+		return errors.NewValidationError("user does not belong to the role's tenant")
+	}
+
+	if err := s.repos.TenantRole.Assign(ctx, &models.TenantRoleAssignment{
+		TenantID: role.TenantID,
+		RoleID:   role.ID,
+		UserID:   user.ID,
+	}); err != nil {
+		s.logger.Error("failed to assign tenant role", "tenant_role_id", roleID, "user_id", userID, "error", err)
+		return errors.NewServiceError("TENANT_ROLE_ASSIGN_FAILED", "failed to assign role", err)
+	}
+
+	s.logger.Info("tenant role assigned", "tenant_role_id", roleID, "user_id", userID)
+	return nil
+}
+
+// UnassignRole takes a role away from a user
+func (s *tenantRoleService) UnassignRole(ctx context.Context, roleID, userID uuid.UUID) error {
+	if _, err := s.getRole(ctx, roleID); err != nil {
+		return err
+	}
+
+	if err := s.repos.TenantRole.Unassign(ctx, roleID, userID); err != nil {
+		if errors.IsNotFoundError(err) {
+			return errors.NewNotFoundError("user is not assigned this role")
+		}
+		s.logger.Error("failed to unassign tenant role", "tenant_role_id", roleID, "user_id", userID, "error", err)
+		return errors.NewServiceError("TENANT_ROLE_UNASSIGN_FAILED", "failed to unassign role", err)
+	}
+
+	s.logger.Info("tenant role unassigned", "tenant_role_id", roleID, "user_id", userID)
+	return nil
+}
+
+// ListAssignments lists the users a role is assigned to
+func (s *tenantRoleService) ListAssignments(ctx context.Context, roleID uuid.UUID) ([]*dto.TenantRoleAssignmentResponse, error) {
+	if _, err := s.getRole(ctx, roleID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.repos.TenantRole.FindAssignments(ctx, roleID)
+	if err != nil {
+		return nil, errors.NewServiceError("TENANT_ROLE_ASSIGNMENTS_FAILED", "failed to list role assignments", err)
+	}
+	return dto.ToTenantRoleAssignmentResponses(assignments), nil
+}
+
+// ListPermissions returns the actions custom roles may grant
+func (s *tenantRoleService) ListPermissions() []string {
+	var permissions []string
+	for _, action := range authz.Actions() {
+		if action.IsGrantable() {
+			permissions = append(permissions, string(action))
+		}
+	}
+	return permissions
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// getRole loads a role, checking the request's actor may manage its tenant's roles
+func (s *tenantRoleService) getRole(ctx context.Context, id uuid.UUID) (*models.TenantRole, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("role ID is required")
+	}
+
+	role, err := s.repos.TenantRole.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("role not found")
+		}
+		return nil, errors.NewServiceError("TENANT_ROLE_GET_FAILED", "failed to get role", err)
+	}
+	if err := s.authorizer.Can(ctx, authz.ActionRoleManage, authz.Tenant(role.TenantID)); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// normalizeRolePermissions trims and de-duplicates permissions, in the order actions
+// are listed, rejecting any custom roles may not grant
+func normalizeRolePermissions(permissions []string) (models.StringArray, error) {
+	granted := make(map[authz.Action]bool, len(permissions))
+	for _, permission := range permissions {
+		action := authz.Action(strings.TrimSpace(permission))
+		if !action.IsGrantable() {
+			return nil, errors.NewValidationError("unknown or non-grantable permission: " + permission)
+		}
+		granted[action] = true
+	}
+
+	normalized := models.StringArray{}
+	for _, action := range authz.Actions() {
+		if granted[action] {
+			normalized = append(normalized, string(action))
+		}
+	}
+	return normalized, nil
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
+	"Krafti_Vibe/internal/infrastructure/payments"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
 
@@ -39,6 +41,9 @@ var (
 	// ErrTenantCancelled is returned when tenant is cancelled
 	ErrTenantCancelled = errors.New("tenant is cancelled")
 
+	// ErrTenantClosed is returned when tenant is closed
+	ErrTenantClosed = errors.New("tenant is closed")
+
 	// ErrOwnerAlreadyHasTenant is returned when user already owns a tenant
 	ErrOwnerAlreadyHasTenant = errors.New("user already belongs to a tenant")
 
@@ -47,6 +52,18 @@ var (
 
 	// ErrInvalidStatusTransition is returned when status transition is not allowed
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+	// ErrInvalidPaymentProvider is returned when a tenant selects an unknown payment provider
+	ErrInvalidPaymentProvider = errors.New("invalid payment provider")
+
+	// ErrInvalidMFAPolicy is returned when a tenant's MFA policy is out of range
+	ErrInvalidMFAPolicy = errors.New("invalid MFA policy")
+
+	// ErrInvalidCustomerSegmentRules is returned when a tenant's segmentation thresholds are out of range
+	ErrInvalidCustomerSegmentRules = errors.New("invalid customer segment rules")
+
+	// ErrInvalidReviewModerationPolicy is returned when a tenant's review moderation policy is out of range
+	ErrInvalidReviewModerationPolicy = errors.New("invalid review moderation policy")
 )
 
 // TenantService defines the interface for core tenant operations
@@ -67,6 +84,11 @@ type TenantService interface {
 	ActivateTenant(ctx context.Context, id uuid.UUID) error
 	SuspendTenant(ctx context.Context, req *dto.SuspendTenantRequest, tenantID uuid.UUID) error
 	CancelTenant(ctx context.Context, req *dto.CancelTenantRequest, tenantID uuid.UUID) error
+	ChangeTenantStatus(ctx context.Context, tenantID uuid.UUID, req *dto.ChangeTenantStatusRequest) (*dto.TenantResponse, error)
+	// TenantStatus returns the status a tenant's access is decided by
+	TenantStatus(ctx context.Context, tenantID uuid.UUID) (models.TenantStatus, error)
+	// TenantLanguage returns the language a tenant's users are served in by default
+	TenantLanguage(ctx context.Context, tenantID uuid.UUID) (string, error)
 
 	// Plan and Settings Management
 	UpdateTenantPlan(ctx context.Context, id uuid.UUID, req *dto.UpdateTenantPlanRequest) error
@@ -84,9 +106,16 @@ type TenantService interface {
 	GetTenantLimits(ctx context.Context, id uuid.UUID) (*dto.TenantLimitsResponse, error)
 	GetTenantHealth(ctx context.Context, id uuid.UUID) (*dto.TenantHealthResponse, error)
 
+	// Multi-factor Authentication
+	// TenantMFAPolicy returns the tenant's multi-factor authentication requirements
+	TenantMFAPolicy(ctx context.Context, tenantID uuid.UUID) (models.MFAPolicy, error)
+	// GetMFACompliance reports the tenant's staff never seen signed in with a second factor
+	GetMFACompliance(ctx context.Context, tenantID uuid.UUID) (*dto.MFAComplianceResponse, error)
+
 	// Trial Management
 	GetExpiredTrials(ctx context.Context) ([]*dto.TenantResponse, error)
 	ExtendTrial(ctx context.Context, id uuid.UUID, days int) error
+	ExpireTrials(ctx context.Context) (int, error)
 
 	// Usage Counter Management
 	IncrementUserCount(ctx context.Context, id uuid.UUID) error
@@ -96,18 +125,22 @@ type TenantService interface {
 
 // tenantService implements TenantService
 type tenantService struct {
-	repos  *repository.Repositories
-	logger *zap.Logger
+	repos   *repository.Repositories
+	logger  *zap.Logger
+	revoker TokenRevoker
 }
 
-// NewTenantService creates a new tenant service
+// NewTenantService creates a new tenant service; revoker may be nil without Redis, in
+// which case the sessions of closed tenants last until their tokens expire
 func NewTenantService(
 	repos *repository.Repositories,
 	logger *zap.Logger,
+	revoker TokenRevoker,
 ) TenantService {
 	return &tenantService{
-		repos:  repos,
-		logger: logger,
+		repos:   repos,
+		logger:  logger,
+		revoker: revoker,
 	}
 }
 
@@ -260,7 +293,10 @@ func (s *tenantService) UpdateTenant(ctx context.Context, id uuid.UUID, req *dto
 		if err == nil && existing != nil && existing.ID != id {
 			return nil, ErrDomainTaken
 		}
+		// A new domain is served only once its ownership is proven again
 		tenant.Domain = *req.Domain
+		tenant.DomainVerificationToken = ""
+		tenant.DomainVerifiedAt = nil
 	}
 
 	// Update fields if provided
@@ -319,6 +355,8 @@ func (s *tenantService) DeleteTenant(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to delete tenant: %w", err)
 	}
 
+	s.denySessions(ctx, id)
+
 	s.logger.Info("tenant deleted successfully", zap.String("tenant_id", id.String()))
 	return nil
 }
@@ -406,9 +444,14 @@ func (s *tenantService) SearchTenants(ctx context.Context, req *dto.SearchTenant
 
 // ActivateTenant activates a tenant
 func (s *tenantService) ActivateTenant(ctx context.Context, id uuid.UUID) error {
+	return s.activate(ctx, id, "")
+}
+
+// activate activates a tenant, recording reason when given
+func (s *tenantService) activate(ctx context.Context, id uuid.UUID, reason string) error {
 	s.logger.Info("activating tenant", zap.String("tenant_id", id.String()))
 
-	if err := s.repos.Tenant.ActivateTenant(ctx, id); err != nil {
+	if err := s.repos.Tenant.ActivateTenant(ctx, id, reason); err != nil {
 		s.logger.Error("failed to activate tenant", zap.Error(err))
 		return fmt.Errorf("failed to activate tenant: %w", err)
 	}
@@ -417,7 +460,7 @@ func (s *tenantService) ActivateTenant(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
-// SuspendTenant suspends a tenant
+// SuspendTenant suspends a tenant, leaving its users read-only access
 func (s *tenantService) SuspendTenant(ctx context.Context, req *dto.SuspendTenantRequest, tenantID uuid.UUID) error {
 	if err := req.Validate(); err != nil {
 		return fmt.Errorf("validation error: %w", err)
@@ -437,7 +480,8 @@ func (s *tenantService) SuspendTenant(ctx context.Context, req *dto.SuspendTenan
 	return nil
 }
 
-// CancelTenant cancels a tenant
+// CancelTenant cancels a tenant and starts its offboarding: its users keep read-only
+// access, and a full export of its data is generated for them to download
 func (s *tenantService) CancelTenant(ctx context.Context, req *dto.CancelTenantRequest, tenantID uuid.UUID) error {
 	if err := req.Validate(); err != nil {
 		return fmt.Errorf("validation error: %w", err)
@@ -453,10 +497,116 @@ func (s *tenantService) CancelTenant(ctx context.Context, req *dto.CancelTenantR
 		return fmt.Errorf("failed to cancel tenant: %w", err)
 	}
 
+	s.requestOffboardingExport(ctx, tenantID)
+
 	s.logger.Info("tenant cancelled successfully", zap.String("tenant_id", tenantID.String()))
 	return nil
 }
 
+// closeTenant closes a cancelled tenant for good and signs its users out
+func (s *tenantService) closeTenant(ctx context.Context, tenantID uuid.UUID, reason string) error {
+	s.logger.Warn("closing tenant",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("reason", reason),
+	)
+
+	if err := s.repos.Tenant.CloseTenant(ctx, tenantID, reason); err != nil {
+		s.logger.Error("failed to close tenant", zap.Error(err))
+		return fmt.Errorf("failed to close tenant: %w", err)
+	}
+
+	s.denySessions(ctx, tenantID)
+
+	s.logger.Info("tenant closed successfully", zap.String("tenant_id", tenantID.String()))
+	return nil
+}
+
+// ChangeTenantStatus moves a tenant to another state of its lifecycle, recording the
+// reason in its metadata:
+//
+//   - active: full access
+//   - suspended: read-only, except for settling the subscription and exporting data
+//   - cancelled: read-only while offboarding, with a full data export generated
+//   - closed: no access, and every session signed out
+//
+// Trials become active or suspended, and only cancelled tenants can be closed.
+func (s *tenantService) ChangeTenantStatus(ctx context.Context, tenantID uuid.UUID, req *dto.ChangeTenantStatusRequest) (*dto.TenantResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	var err error
+	switch req.Status {
+	case models.TenantStatusActive:
+		err = s.activate(ctx, tenantID, req.Reason)
+	case models.TenantStatusSuspended:
+		err = s.SuspendTenant(ctx, &dto.SuspendTenantRequest{Reason: req.Reason}, tenantID)
+	case models.TenantStatusCancelled:
+		err = s.CancelTenant(ctx, &dto.CancelTenantRequest{Reason: req.Reason}, tenantID)
+	case models.TenantStatusClosed:
+		err = s.closeTenant(ctx, tenantID, req.Reason)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTenant(ctx, tenantID)
+}
+
+// TenantStatus returns the tenant's effective status, which counts trials past their
+// end as suspended
+func (s *tenantService) TenantStatus(ctx context.Context, tenantID uuid.UUID) (models.TenantStatus, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	return tenant.EffectiveStatus(), nil
+}
+
+// TenantLanguage returns the tenant's default language
+func (s *tenantService) TenantLanguage(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	return tenant.Settings.DefaultLanguage, nil
+}
+
+// requestOffboardingExport queues a full export of the tenant's data on behalf of its
+// owner, unless one is already under way. Failures are logged rather than returned,
+// as the status change is already made.
+func (s *tenantService) requestOffboardingExport(ctx context.Context, tenantID uuid.UUID) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		s.logger.Error("failed to load tenant for offboarding export", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+		return
+	}
+	if pending, err := s.repos.DataExport.FindPendingByTenant(ctx, tenantID); err == nil && len(pending) > 0 {
+		return
+	}
+
+	export := &models.DataExportRequest{
+		TenantID:    tenantID,
+		RequestedBy: tenant.OwnerID,
+		ExportType:  ExportTypeFull,
+		Status:      ExportStatusPending,
+	}
+	if err := s.repos.DataExport.Create(ctx, export); err != nil {
+		s.logger.Error("failed to request offboarding export", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+	}
+}
+
+// denySessions refuses every session of the tenant's users. Failures are logged rather
+// than returned, as the status change is already made.
+func (s *tenantService) denySessions(ctx context.Context, tenantID uuid.UUID) {
+	if s.revoker == nil {
+		return
+	}
+	if err := s.revoker.Deny(ctx, cache.TenantTokens(tenantID)); err != nil {
+		s.logger.Error("failed to deny tenant sessions", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+	}
+}
+
 // ============================================================================
 // Plan and Settings Management
 // ============================================================================
@@ -555,6 +705,54 @@ func (s *tenantService) UpdateTenantSettings(ctx context.Context, id uuid.UUID,
 	if req.EnableReviews != nil {
 		settings.EnableCustomerReviews = *req.EnableReviews
 	}
+	if req.RequireDepositBeforeConfirm != nil || req.AllowRefundAfterCompletion != nil || req.AllowCancelInProgress != nil {
+		policy := settings.GetBookingTransitionPolicy()
+		if req.RequireDepositBeforeConfirm != nil {
+			policy.RequireDepositBeforeConfirm = *req.RequireDepositBeforeConfirm
+		}
+		if req.AllowRefundAfterCompletion != nil {
+			policy.AllowRefundAfterCompletion = *req.AllowRefundAfterCompletion
+		}
+		if req.AllowCancelInProgress != nil {
+			policy.AllowCancelInProgress = *req.AllowCancelInProgress
+		}
+		settings.BookingTransitions = &policy
+	}
+	if req.PaymentProvider != nil {
+		provider := strings.ToLower(strings.TrimSpace(*req.PaymentProvider))
+		if provider != "" && !payments.IsKnownProvider(provider) {
+			return ErrInvalidPaymentProvider
+		}
+		settings.PaymentProvider = provider
+	}
+	if req.FallbackPaymentProvider != nil {
+		provider := strings.ToLower(strings.TrimSpace(*req.FallbackPaymentProvider))
+		if provider != "" && !payments.IsKnownProvider(provider) {
+			return ErrInvalidPaymentProvider
+		}
+		settings.FallbackPaymentProvider = provider
+	}
+	if req.MFAPolicy != nil {
+		if req.MFAPolicy.StepUpMaxAgeMinutes < 0 || req.MFAPolicy.StepUpMaxAgeMinutes > 1440 {
+			return ErrInvalidMFAPolicy
+		}
+		policy := *req.MFAPolicy
+		settings.MFA = &policy
+	}
+	if req.CustomerSegments != nil {
+		if err := req.CustomerSegments.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCustomerSegmentRules, err)
+		}
+		rules := *req.CustomerSegments
+		settings.CustomerSegments = &rules
+	}
+	if req.ReviewModeration != nil {
+		policy := *req.ReviewModeration
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidReviewModerationPolicy, err)
+		}
+		settings.ReviewModeration = &policy
+	}
 
 	if err := s.repos.Tenant.UpdateSettings(ctx, id, settings); err != nil {
 		s.logger.Error("failed to update tenant settings", zap.Error(err))
@@ -832,6 +1030,9 @@ func (s *tenantService) GetTenantHealth(ctx context.Context, id uuid.UUID) (*dto
 	} else if tenant.Status == models.TenantStatusCancelled {
 		issues = append(issues, "Tenant is cancelled")
 		health.SubscriptionHealth = "cancelled"
+	} else if tenant.Status == models.TenantStatusClosed {
+		issues = append(issues, "Tenant is closed")
+		health.SubscriptionHealth = "closed"
 	} else {
 		health.SubscriptionHealth = "active"
 	}
@@ -847,6 +1048,69 @@ func (s *tenantService) GetTenantHealth(ctx context.Context, id uuid.UUID) (*dto
 	return health, nil
 }
 
+// TenantMFAPolicy returns the tenant's multi-factor authentication requirements
+func (s *tenantService) TenantMFAPolicy(ctx context.Context, tenantID uuid.UUID) (models.MFAPolicy, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return models.MFAPolicy{}, err
+	}
+	return tenant.Settings.GetMFAPolicy(), nil
+}
+
+// GetMFACompliance reports the tenant's active staff never seen signed in with a
+// second factor, marking those the policy requires it of
+func (s *tenantService) GetMFACompliance(ctx context.Context, tenantID uuid.UUID) (*dto.MFAComplianceResponse, error) {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, ErrTenantNotFound
+	}
+	policy := tenant.Settings.GetMFAPolicy()
+
+	filters := repository.UserFilters{
+		Roles:     []models.UserRole{models.UserRoleTenantOwner, models.UserRoleTenantAdmin, models.UserRoleArtisan, models.UserRoleTeamMember},
+		Statuses:  []models.UserStatus{models.UserStatusActive},
+		TenantIDs: []uuid.UUID{tenantID},
+	}
+	response := &dto.MFAComplianceResponse{
+		TenantID:   tenantID,
+		Policy:     policy,
+		WithoutMFA: []*dto.MFAComplianceUser{},
+	}
+	for page := 1; ; page++ {
+		users, pagination, err := s.repos.User.FindByFilters(ctx, filters, repository.PaginationParams{Page: page, PageSize: 100})
+		if err != nil {
+			s.logger.Error("failed to get staff for MFA compliance", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+			return nil, fmt.Errorf("failed to get staff: %w", err)
+		}
+
+		response.StaffCount += len(users)
+		for _, user := range users {
+			if user.LastMFAAt != nil {
+				continue
+			}
+			required := policy.Requires(user.Role)
+			if required {
+				response.NonCompliantCount++
+			}
+			response.WithoutMFA = append(response.WithoutMFA, &dto.MFAComplianceUser{
+				UserID:      user.ID,
+				Email:       user.Email,
+				FirstName:   user.FirstName,
+				LastName:    user.LastName,
+				Role:        user.Role,
+				Required:    required,
+				MFAEnabled:  user.RequiresMFA(),
+				LastLoginAt: user.LastLoginAt,
+			})
+		}
+		if !pagination.HasNext {
+			break
+		}
+	}
+
+	return response, nil
+}
+
 // ============================================================================
 // Trial Management
 // ============================================================================
@@ -896,6 +1160,30 @@ func (s *tenantService) ExtendTrial(ctx context.Context, id uuid.UUID, days int)
 	return nil
 }
 
+// ExpireTrials suspends the tenants whose trial ended without them subscribing,
+// returning how many were suspended
+func (s *tenantService) ExpireTrials(ctx context.Context) (int, error) {
+	tenants, err := s.repos.Tenant.FindExpiredTrials(ctx)
+	if err != nil {
+		s.logger.Error("failed to find expired trials", zap.Error(err))
+		return 0, fmt.Errorf("failed to find expired trials: %w", err)
+	}
+
+	suspended := 0
+	for _, tenant := range tenants {
+		if err := s.repos.Tenant.SuspendTenant(ctx, tenant.ID, "Trial ended"); err != nil {
+			s.logger.Error("failed to suspend tenant after trial", zap.String("tenant_id", tenant.ID.String()), zap.Error(err))
+			continue
+		}
+		suspended++
+	}
+
+	if suspended > 0 {
+		s.logger.Info("suspended tenants after trial", zap.Int("count", suspended))
+	}
+	return suspended, nil
+}
+
 // ============================================================================
 // Usage Counter Management
 // ============================================================================
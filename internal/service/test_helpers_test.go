@@ -195,6 +195,11 @@ func (m *MockUserRepository) MarkForDeletion(ctx context.Context, userID uuid.UU
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) EraseUser(ctx context.Context, userID uuid.UUID, erasedAt time.Time) error {
+	args := m.Called(ctx, userID, erasedAt)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) GetUsersMarkedForDeletion(ctx context.Context) ([]*models.User, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -213,6 +218,11 @@ func (m *MockUserRepository) RecordLogin(ctx context.Context, userID uuid.UUID)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) RecordMFA(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, at)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) RecordFailedLogin(ctx context.Context, userID uuid.UUID) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
@@ -283,6 +293,12 @@ func (m *MockUserRepository) GetByIDWithTenant(ctx context.Context, id uuid.UUID
 }
 func (m *MockUserRepository) SoftDelete(ctx context.Context, id uuid.UUID) error { return nil }
 func (m *MockUserRepository) Restore(ctx context.Context, id uuid.UUID) error    { return nil }
+func (m *MockUserRepository) FindDeleted(ctx context.Context, filters map[string]any, pagination repository.PaginationParams) ([]*models.User, repository.PaginationResult, error) {
+	return nil, repository.PaginationResult{}, nil
+}
+func (m *MockUserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
 func (m *MockUserRepository) Find(ctx context.Context, filters map[string]any) ([]*models.User, error) {
 	return nil, nil
 }
@@ -296,13 +312,17 @@ func (m *MockUserRepository) Exists(ctx context.Context, id uuid.UUID) (bool, er
 	return false, nil
 }
 func (m *MockUserRepository) InvalidateCache(ctx context.Context, id uuid.UUID) error { return nil }
-func (m *MockUserRepository) InvalidateCachePattern(ctx context.Context, pattern string) error {
+func (m *MockUserRepository) InvalidateCacheMany(ctx context.Context, ids []uuid.UUID) error {
 	return nil
 }
+func (m *MockUserRepository) RepositoryCache() *repository.RepositoryCache { return nil }
 func (m *MockUserRepository) WithTransaction(ctx context.Context, fn func(*gorm.DB) error) error {
 	return nil
 }
 func (m *MockUserRepository) GetDB() *gorm.DB { return nil }
+func (m *MockUserRepository) WithQueryTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	return ctx, func() {}
+}
 func (m *MockUserRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*models.User, error) {
 	return nil, nil
 }
@@ -400,8 +420,8 @@ func (m *MockTenantRepository) FindByDomain(ctx context.Context, domain string)
 	return args.Get(0).(*models.Tenant), args.Error(1)
 }
 
-func (m *MockTenantRepository) ActivateTenant(ctx context.Context, tenantID uuid.UUID) error {
-	args := m.Called(ctx, tenantID)
+func (m *MockTenantRepository) ActivateTenant(ctx context.Context, tenantID uuid.UUID, reason string) error {
+	args := m.Called(ctx, tenantID, reason)
 	return args.Error(0)
 }
 
@@ -415,6 +435,11 @@ func (m *MockTenantRepository) CancelTenant(ctx context.Context, tenantID uuid.U
 	return args.Error(0)
 }
 
+func (m *MockTenantRepository) CloseTenant(ctx context.Context, tenantID uuid.UUID, reason string) error {
+	args := m.Called(ctx, tenantID, reason)
+	return args.Error(0)
+}
+
 func (m *MockTenantRepository) IncrementUserCount(ctx context.Context, tenantID uuid.UUID) error {
 	args := m.Called(ctx, tenantID)
 	return args.Error(0)
@@ -474,6 +499,12 @@ func (m *MockTenantRepository) GetByIDWithTenant(ctx context.Context, id uuid.UU
 func (m *MockTenantRepository) Delete(ctx context.Context, id uuid.UUID) error     { return nil }
 func (m *MockTenantRepository) SoftDelete(ctx context.Context, id uuid.UUID) error { return nil }
 func (m *MockTenantRepository) Restore(ctx context.Context, id uuid.UUID) error    { return nil }
+func (m *MockTenantRepository) FindDeleted(ctx context.Context, filters map[string]any, pagination repository.PaginationParams) ([]*models.Tenant, repository.PaginationResult, error) {
+	return nil, repository.PaginationResult{}, nil
+}
+func (m *MockTenantRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
 func (m *MockTenantRepository) Find(ctx context.Context, filters map[string]any) ([]*models.Tenant, error) {
 	return nil, nil
 }
@@ -487,13 +518,17 @@ func (m *MockTenantRepository) Exists(ctx context.Context, id uuid.UUID) (bool,
 	return false, nil
 }
 func (m *MockTenantRepository) InvalidateCache(ctx context.Context, id uuid.UUID) error { return nil }
-func (m *MockTenantRepository) InvalidateCachePattern(ctx context.Context, pattern string) error {
+func (m *MockTenantRepository) InvalidateCacheMany(ctx context.Context, ids []uuid.UUID) error {
 	return nil
 }
+func (m *MockTenantRepository) RepositoryCache() *repository.RepositoryCache { return nil }
 func (m *MockTenantRepository) WithTransaction(ctx context.Context, fn func(*gorm.DB) error) error {
 	return nil
 }
 func (m *MockTenantRepository) GetDB() *gorm.DB { return nil }
+func (m *MockTenantRepository) WithQueryTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	return ctx, func() {}
+}
 func (m *MockTenantRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*models.Tenant, error) {
 	return nil, nil
 }
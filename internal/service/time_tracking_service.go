@@ -0,0 +1,609 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"math"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// timeInvoicePaymentTerms is the due date of invoices billed from time entries when
+// the request does not set one
+const timeInvoicePaymentTerms = 30 * 24 * time.Hour
+
+// billableHoursColumns is the header row of the billable hours CSV
+var billableHoursColumns = []string{
+	"entry_id", "task_id", "task", "user_id", "description", "started_at", "ended_at",
+	"hours", "hourly_rate", "amount", "currency",
+}
+
+// TimeTrackingService defines the interface for project time tracking
+type TimeTrackingService interface {
+	// Timers
+	StartTimer(ctx context.Context, tenantID, userID uuid.UUID, req *dto.StartTimerRequest) (*dto.TimeEntryResponse, error)
+	StopTimer(ctx context.Context, tenantID, userID uuid.UUID) (*dto.TimeEntryResponse, error)
+	GetRunningTimer(ctx context.Context, tenantID, userID uuid.UUID) (*dto.TimeEntryResponse, error)
+
+	// Entries
+	CreateTimeEntry(ctx context.Context, tenantID, userID uuid.UUID, req *dto.CreateTimeEntryRequest) (*dto.TimeEntryResponse, error)
+	GetTimeEntry(ctx context.Context, tenantID, id uuid.UUID) (*dto.TimeEntryResponse, error)
+	UpdateTimeEntry(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.UpdateTimeEntryRequest) (*dto.TimeEntryResponse, error)
+	DeleteTimeEntry(ctx context.Context, tenantID, userID, id uuid.UUID) error
+	ListTimeEntries(ctx context.Context, filter dto.TimeEntryFilter) (*dto.TimeEntryListResponse, error)
+
+	// Reporting and billing
+	GetProjectHours(ctx context.Context, tenantID, projectID uuid.UUID) (*dto.ProjectHoursResponse, error)
+	ExportBillableHours(ctx context.Context, tenantID, projectID uuid.UUID, from, to *time.Time) (*dto.BillableHoursExport, error)
+	ExportBillableHoursCSV(ctx context.Context, tenantID, projectID uuid.UUID, from, to *time.Time) ([]byte, error)
+	InvoiceBillableHours(ctx context.Context, tenantID, projectID uuid.UUID, req *dto.InvoiceTimeEntriesRequest) (*dto.InvoiceResponse, error)
+}
+
+// timeTrackingService implements TimeTrackingService
+type timeTrackingService struct {
+	repos  *repository.Repositories
+	logger log.AllLogger
+}
+
+// NewTimeTrackingService creates a new TimeTrackingService instance
+func NewTimeTrackingService(repos *repository.Repositories, logger log.AllLogger) TimeTrackingService {
+	return &timeTrackingService{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// ============================================================================
+// Timers
+// ============================================================================
+
+// StartTimer starts a timer on a task, stopping the user's running timer first
+func (s *timeTrackingService) StartTimer(ctx context.Context, tenantID, userID uuid.UUID, req *dto.StartTimerRequest) (*dto.TimeEntryResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	task, project, err := s.getTaskAndProject(ctx, tenantID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if _, err := s.stopRunning(ctx, userID, now); err != nil {
+		return nil, err
+	}
+
+	entry := s.newEntry(task, project, userID, req.Billable, req.HourlyRate)
+	entry.Source = models.TimeEntrySourceTimer
+	entry.StartedAt = now
+	entry.Description = strings.TrimSpace(req.Description)
+	if err := entry.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.TimeEntry.Create(ctx, entry); err != nil {
+		if errors.IsDuplicate(err) {
+			return nil, errors.NewConflictError("a timer is already running")
+		}
+		s.logger.Error("failed to start timer", "task_id", task.ID, "user_id", userID, "error", err)
+		return nil, errors.NewServiceError("TIMER_START_FAILED", "failed to start timer", err)
+	}
+
+	s.logger.Info("timer started", "time_entry_id", entry.ID, "task_id", task.ID, "user_id", userID)
+	entry.Task = task
+	return dto.ToTimeEntryResponse(entry), nil
+}
+
+// StopTimer stops the user's running timer and rolls its time up into the task
+func (s *timeTrackingService) StopTimer(ctx context.Context, tenantID, userID uuid.UUID) (*dto.TimeEntryResponse, error) {
+	running, err := s.repos.TimeEntry.FindRunning(ctx, userID)
+	if err != nil {
+		return nil, errors.NewServiceError("TIMER_FIND_FAILED", "failed to find running timer", err)
+	}
+	if running == nil || running.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("running timer")
+	}
+
+	entry, err := s.stopRunning(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToTimeEntryResponse(entry), nil
+}
+
+// GetRunningTimer returns the user's running timer
+func (s *timeTrackingService) GetRunningTimer(ctx context.Context, tenantID, userID uuid.UUID) (*dto.TimeEntryResponse, error) {
+	running, err := s.repos.TimeEntry.FindRunning(ctx, userID)
+	if err != nil {
+		return nil, errors.NewServiceError("TIMER_FIND_FAILED", "failed to find running timer", err)
+	}
+	if running == nil || running.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("running timer")
+	}
+	return dto.ToTimeEntryResponse(running), nil
+}
+
+// stopRunning stops the user's running timer, if any, and returns it
+func (s *timeTrackingService) stopRunning(ctx context.Context, userID uuid.UUID, at time.Time) (*models.TimeEntry, error) {
+	running, err := s.repos.TimeEntry.FindRunning(ctx, userID)
+	if err != nil {
+		return nil, errors.NewServiceError("TIMER_FIND_FAILED", "failed to find running timer", err)
+	}
+	if running == nil {
+		return nil, nil
+	}
+
+	if err := running.Stop(at); err != nil {
+		// A timer started less than a second ago ends with no duration
+		if err := running.SetPeriod(running.StartedAt, running.StartedAt.Add(time.Second)); err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+	}
+	if err := s.repos.TimeEntry.Update(ctx, running); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("the timer was changed by another request")
+		}
+		s.logger.Error("failed to stop timer", "time_entry_id", running.ID, "error", err)
+		return nil, errors.NewServiceError("TIMER_STOP_FAILED", "failed to stop timer", err)
+	}
+
+	s.logger.Info("timer stopped", "time_entry_id", running.ID, "duration_minutes", running.DurationMinutes)
+	s.recalculateHours(ctx, running.TaskID)
+	return running, nil
+}
+
+// ============================================================================
+// Entries
+// ============================================================================
+
+// CreateTimeEntry logs a manual time entry on a task
+func (s *timeTrackingService) CreateTimeEntry(ctx context.Context, tenantID, userID uuid.UUID, req *dto.CreateTimeEntryRequest) (*dto.TimeEntryResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	task, project, err := s.getTaskAndProject(ctx, tenantID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := s.newEntry(task, project, userID, req.Billable, req.HourlyRate)
+	entry.Source = models.TimeEntrySourceManual
+	entry.Description = strings.TrimSpace(req.Description)
+	if err := entry.SetPeriod(req.StartedAt, req.EndedAt); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.TimeEntry.Create(ctx, entry); err != nil {
+		s.logger.Error("failed to create time entry", "task_id", task.ID, "user_id", userID, "error", err)
+		return nil, errors.NewServiceError("TIME_ENTRY_CREATE_FAILED", "failed to create time entry", err)
+	}
+
+	s.logger.Info("time entry logged", "time_entry_id", entry.ID, "task_id", task.ID, "duration_minutes", entry.DurationMinutes)
+	s.recalculateHours(ctx, task.ID)
+	entry.Task = task
+	return dto.ToTimeEntryResponse(entry), nil
+}
+
+// GetTimeEntry retrieves a time entry by ID
+func (s *timeTrackingService) GetTimeEntry(ctx context.Context, tenantID, id uuid.UUID) (*dto.TimeEntryResponse, error) {
+	entry, err := s.getEntry(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToTimeEntryResponse(entry), nil
+}
+
+// UpdateTimeEntry corrects one of the user's stopped, uninvoiced entries
+func (s *timeTrackingService) UpdateTimeEntry(ctx context.Context, tenantID, userID, id uuid.UUID, req *dto.UpdateTimeEntryRequest) (*dto.TimeEntryResponse, error) {
+	entry, err := s.getEditableEntry(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.IsRunning() && (req.StartedAt != nil || req.EndedAt != nil) {
+		return nil, errors.NewValidationError("stop the timer before changing its times")
+	}
+
+	if req.StartedAt != nil || req.EndedAt != nil {
+		startedAt, endedAt := entry.StartedAt, *entry.EndedAt
+		if req.StartedAt != nil {
+			startedAt = *req.StartedAt
+		}
+		if req.EndedAt != nil {
+			endedAt = *req.EndedAt
+		}
+		if err := entry.SetPeriod(startedAt, endedAt); err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+	}
+	if req.Description != nil {
+		entry.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Billable != nil {
+		entry.Billable = *req.Billable
+	}
+	if req.HourlyRate != nil {
+		entry.HourlyRate = *req.HourlyRate
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.TimeEntry.Update(ctx, entry); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("time entry was modified by another request")
+		}
+		s.logger.Error("failed to update time entry", "time_entry_id", id, "error", err)
+		return nil, errors.NewServiceError("TIME_ENTRY_UPDATE_FAILED", "failed to update time entry", err)
+	}
+
+	s.recalculateHours(ctx, entry.TaskID)
+	return dto.ToTimeEntryResponse(entry), nil
+}
+
+// DeleteTimeEntry deletes one of the user's uninvoiced entries
+func (s *timeTrackingService) DeleteTimeEntry(ctx context.Context, tenantID, userID, id uuid.UUID) error {
+	entry, err := s.getEditableEntry(ctx, tenantID, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repos.TimeEntry.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete time entry", "time_entry_id", id, "error", err)
+		return errors.NewServiceError("TIME_ENTRY_DELETE_FAILED", "failed to delete time entry", err)
+	}
+
+	s.recalculateHours(ctx, entry.TaskID)
+	return nil
+}
+
+// ListTimeEntries lists a tenant's time entries matching the filter
+func (s *timeTrackingService) ListTimeEntries(ctx context.Context, filter dto.TimeEntryFilter) (*dto.TimeEntryListResponse, error) {
+	if filter.TenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant_id is required")
+	}
+
+	entries, result, err := s.repos.TimeEntry.List(ctx, repository.TimeEntryFilter{
+		TenantID:  filter.TenantID,
+		ProjectID: filter.ProjectID,
+		TaskID:    filter.TaskID,
+		UserID:    filter.UserID,
+		Billable:  filter.Billable,
+		Invoiced:  filter.Invoiced,
+		From:      filter.From,
+		To:        filter.To,
+	}, repository.PaginationParams{Page: filter.Page, PageSize: filter.PageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("TIME_ENTRY_LIST_FAILED", "failed to list time entries", err)
+	}
+
+	return &dto.TimeEntryListResponse{
+		Entries:     dto.ToTimeEntryResponses(entries),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// ============================================================================
+// Reporting and billing
+// ============================================================================
+
+// GetProjectHours compares a project's tracked hours with its estimates, per task
+func (s *timeTrackingService) GetProjectHours(ctx context.Context, tenantID, projectID uuid.UUID) (*dto.ProjectHoursResponse, error) {
+	project, err := s.getProject(ctx, tenantID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.projectTasks(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	summaries, err := s.repos.TimeEntry.SummarizeByTask(ctx, projectID)
+	if err != nil {
+		return nil, errors.NewServiceError("PROJECT_HOURS_FAILED", "failed to summarize project hours", err)
+	}
+	byTask := make(map[uuid.UUID]repository.TaskTimeSummary, len(summaries))
+	for _, summary := range summaries {
+		byTask[summary.TaskID] = summary
+	}
+
+	resp := &dto.ProjectHoursResponse{
+		ProjectID: project.ID,
+		Currency:  project.Currency,
+		Tasks:     make([]*dto.TaskHoursResponse, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		summary := byTask[task.ID]
+		taskHours := &dto.TaskHoursResponse{
+			TaskID:          task.ID,
+			Title:           task.Title,
+			Status:          task.Status,
+			EstimatedHours:  task.EstimatedHours,
+			TrackedHours:    minutesToHours(summary.TotalMinutes),
+			BillableHours:   minutesToHours(summary.BillableMinutes),
+			UninvoicedHours: minutesToHours(summary.UninvoicedMinutes),
+			BillableAmount:  summary.BillableAmount,
+		}
+		taskHours.OverEstimate = task.EstimatedHours > 0 && taskHours.TrackedHours > task.EstimatedHours
+		resp.Tasks = append(resp.Tasks, taskHours)
+
+		resp.EstimatedHours += taskHours.EstimatedHours
+		resp.ActualHours += taskHours.TrackedHours
+		resp.BillableHours += taskHours.BillableHours
+		resp.UninvoicedHours += taskHours.UninvoicedHours
+		resp.BillableAmount += taskHours.BillableAmount
+	}
+
+	resp.EstimatedHours = roundHours(resp.EstimatedHours)
+	resp.ActualHours = roundHours(resp.ActualHours)
+	resp.BillableHours = roundHours(resp.BillableHours)
+	resp.UninvoicedHours = roundHours(resp.UninvoicedHours)
+	resp.BillableAmount = math.Round(resp.BillableAmount*100) / 100
+	resp.RemainingHours = roundHours(resp.EstimatedHours - resp.ActualHours)
+	if resp.EstimatedHours > 0 {
+		resp.PercentUsed = math.Round(resp.ActualHours/resp.EstimatedHours*1000) / 10
+	}
+	return resp, nil
+}
+
+// ExportBillableHours groups a project's uninvoiced billable hours into invoice line items
+func (s *timeTrackingService) ExportBillableHours(ctx context.Context, tenantID, projectID uuid.UUID, from, to *time.Time) (*dto.BillableHoursExport, error) {
+	project, err := s.getProject(ctx, tenantID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	export, _, err := s.billableHours(ctx, project, from, to)
+	return export, err
+}
+
+// ExportBillableHoursCSV renders a project's uninvoiced billable hours, one row per entry
+func (s *timeTrackingService) ExportBillableHoursCSV(ctx context.Context, tenantID, projectID uuid.UUID, from, to *time.Time) ([]byte, error) {
+	export, err := s.ExportBillableHours(ctx, tenantID, projectID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := &csvExportWriter{w: csv.NewWriter(&buf)}
+	header := make([]any, len(billableHoursColumns))
+	for i, column := range billableHoursColumns {
+		header[i] = column
+	}
+	if err := w.WriteRow(header...); err != nil {
+		return nil, errors.NewServiceError("BILLABLE_HOURS_EXPORT_FAILED", "failed to render billable hours", err)
+	}
+	for _, e := range export.Entries {
+		if err := w.WriteRow(
+			e.ID.String(), e.TaskID.String(), e.TaskTitle, e.UserID.String(), e.Description,
+			e.StartedAt, *e.EndedAt, e.Hours, e.HourlyRate, e.Amount, e.Currency,
+		); err != nil {
+			return nil, errors.NewServiceError("BILLABLE_HOURS_EXPORT_FAILED", "failed to render billable hours", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.NewServiceError("BILLABLE_HOURS_EXPORT_FAILED", "failed to render billable hours", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// InvoiceBillableHours bills a project's uninvoiced billable hours to its customer on a
+// draft invoice and marks the entries as invoiced
+func (s *timeTrackingService) InvoiceBillableHours(ctx context.Context, tenantID, projectID uuid.UUID, req *dto.InvoiceTimeEntriesRequest) (*dto.InvoiceResponse, error) {
+	project, err := s.getProject(ctx, tenantID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.CustomerID == nil {
+		return nil, errors.NewValidationError("project has no customer to invoice")
+	}
+	customer, err := s.repos.Customer.GetByID(ctx, *project.CustomerID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("customer")
+	}
+
+	export, entryIDs, err := s.billableHours(ctx, project, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	if len(entryIDs) == 0 {
+		return nil, errors.NewValidationError("no uninvoiced billable hours to invoice")
+	}
+
+	now := time.Now()
+	dueDate := now.Add(timeInvoicePaymentTerms)
+	if req.DueDate != nil {
+		if !req.DueDate.After(now) {
+			return nil, errors.NewValidationError("due_date must be in the future")
+		}
+		dueDate = *req.DueDate
+	}
+
+	invoice := &models.Invoice{
+		TenantID:       tenantID,
+		CustomerID:     customer.UserID,
+		IssueDate:      now,
+		DueDate:        dueDate,
+		SubtotalAmount: export.TotalAmount,
+		TotalAmount:    export.TotalAmount,
+		Currency:       export.Currency,
+		Status:         models.InvoiceStatusDraft,
+		LineItems:      export.LineItems,
+		Notes:          req.Notes,
+		Metadata: models.JSONB{
+			"source":      "time_entries",
+			"project_id":  project.ID.String(),
+			"total_hours": export.TotalHours,
+		},
+	}
+
+	if err := s.repos.TimeEntry.InvoiceEntries(ctx, invoice, entryIDs); err != nil {
+		if errors.IsConflict(err) {
+			return nil, errors.NewConflictError("some of the hours were invoiced by another request")
+		}
+		s.logger.Error("failed to invoice billable hours", "project_id", projectID, "error", err)
+		return nil, errors.NewServiceError("TIME_INVOICE_FAILED", "failed to invoice billable hours", err)
+	}
+
+	s.logger.Info("billable hours invoiced", "project_id", projectID, "invoice_id", invoice.ID, "entries", len(entryIDs), "hours", export.TotalHours)
+	return dto.ToInvoiceResponse(invoice), nil
+}
+
+// billableHours collects a project's uninvoiced billable entries and their line items
+func (s *timeTrackingService) billableHours(ctx context.Context, project *models.Project, from, to *time.Time) (*dto.BillableHoursExport, []uuid.UUID, error) {
+	if from != nil && to != nil && !to.After(*from) {
+		return nil, nil, errors.NewValidationError("to must be after from")
+	}
+
+	entries, err := s.repos.TimeEntry.FindBillable(ctx, project.ID, from, to)
+	if err != nil {
+		return nil, nil, errors.NewServiceError("BILLABLE_HOURS_FAILED", "failed to find billable hours", err)
+	}
+	tasks, err := s.projectTasks(ctx, project.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	titles := make(map[uuid.UUID]string, len(tasks))
+	for _, task := range tasks {
+		titles[task.ID] = task.Title
+	}
+
+	export := &dto.BillableHoursExport{
+		ProjectID: project.ID,
+		Currency:  project.Currency,
+		From:      from,
+		To:        to,
+		LineItems: models.BillableLineItems(entries, titles),
+		Entries:   make([]*dto.TimeEntryResponse, 0, len(entries)),
+	}
+	entryIDs := make([]uuid.UUID, 0, len(entries))
+	var minutes int64
+	for _, entry := range entries {
+		if entry.Currency != "" && entry.Currency != project.Currency {
+			return nil, nil, errors.NewValidationError("billable hours were logged in " + entry.Currency + " but the project bills in " + project.Currency)
+		}
+		resp := dto.ToTimeEntryResponse(entry)
+		resp.TaskTitle = titles[entry.TaskID]
+		export.Entries = append(export.Entries, resp)
+		entryIDs = append(entryIDs, entry.ID)
+		minutes += int64(entry.DurationMinutes)
+	}
+	for _, item := range export.LineItems {
+		export.TotalAmount += item.TotalPrice
+	}
+	export.TotalAmount = math.Round(export.TotalAmount*100) / 100
+	export.TotalHours = minutesToHours(minutes)
+	return export, entryIDs, nil
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+func (s *timeTrackingService) newEntry(task *models.ProjectTask, project *models.Project, userID uuid.UUID, billable *bool, hourlyRate *float64) *models.TimeEntry {
+	entry := &models.TimeEntry{
+		TenantID:   task.TenantID,
+		ProjectID:  task.ProjectID,
+		TaskID:     task.ID,
+		UserID:     userID,
+		Billable:   billable == nil || *billable,
+		HourlyRate: project.HourlyRate,
+		Currency:   project.Currency,
+	}
+	if hourlyRate != nil {
+		entry.HourlyRate = *hourlyRate
+	}
+	return entry
+}
+
+func (s *timeTrackingService) getTaskAndProject(ctx context.Context, tenantID, taskID uuid.UUID) (*models.ProjectTask, *models.Project, error) {
+	task, err := s.repos.ProjectTask.GetByID(ctx, taskID)
+	if err != nil || task.TenantID != tenantID {
+		return nil, nil, errors.NewNotFoundError("task")
+	}
+	project, err := s.getProject(ctx, tenantID, task.ProjectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, project, nil
+}
+
+func (s *timeTrackingService) getProject(ctx context.Context, tenantID, projectID uuid.UUID) (*models.Project, error) {
+	project, err := s.repos.Project.GetByID(ctx, projectID)
+	if err != nil || project.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("project")
+	}
+	return project, nil
+}
+
+// projectTasks loads every task of a project
+func (s *timeTrackingService) projectTasks(ctx context.Context, projectID uuid.UUID) ([]*models.ProjectTask, error) {
+	var tasks []*models.ProjectTask
+	pagination := repository.PaginationParams{Page: 1, PageSize: 100}
+	for {
+		page, result, err := s.repos.ProjectTask.FindByProjectID(ctx, projectID, pagination)
+		if err != nil {
+			return nil, errors.NewServiceError("TASK_LIST_FAILED", "failed to list project tasks", err)
+		}
+		tasks = append(tasks, page...)
+		if !result.HasNext {
+			return tasks, nil
+		}
+		pagination.Page++
+	}
+}
+
+func (s *timeTrackingService) getEntry(ctx context.Context, tenantID, id uuid.UUID) (*models.TimeEntry, error) {
+	entry, err := s.repos.TimeEntry.GetByID(ctx, id)
+	if err != nil || entry.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("time entry")
+	}
+	return entry, nil
+}
+
+// getEditableEntry loads an entry the user may change: their own, not yet invoiced
+func (s *timeTrackingService) getEditableEntry(ctx context.Context, tenantID, userID, id uuid.UUID) (*models.TimeEntry, error) {
+	entry, err := s.getEntry(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID != userID {
+		return nil, errors.NewForbiddenError("only the person who logged the time can change it")
+	}
+	if entry.IsInvoiced() {
+		return nil, errors.NewValidationError("invoiced time entries cannot be changed")
+	}
+	return entry, nil
+}
+
+// recalculateHours refreshes the task and project hour rollups. The entry is already
+// saved, so a failure is logged rather than returned; the next change recomputes them.
+func (s *timeTrackingService) recalculateHours(ctx context.Context, taskID uuid.UUID) {
+	if err := s.repos.TimeEntry.RecalculateHours(ctx, taskID); err != nil {
+		s.logger.Error("failed to recalculate tracked hours", "task_id", taskID, "error", err)
+	}
+}
+
+func minutesToHours(minutes int64) float64 {
+	return roundHours(float64(minutes) / 60)
+}
+
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
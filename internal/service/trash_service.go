@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// defaultTrashRetention is how long deleted records stay restorable when no retention
+// is configured
+const defaultTrashRetention = 30 * 24 * time.Hour
+
+// TrashService lists and restores deleted bookings, services and projects, and purges
+// them once their retention has passed
+type TrashService interface {
+	// ListDeleted lists a tenant's deleted records of one kind, the most recently
+	// deleted first
+	ListDeleted(ctx context.Context, req *dto.TrashListRequest) (*dto.TrashListResponse, error)
+
+	// Restore brings a tenant's deleted record back
+	Restore(ctx context.Context, tenantID uuid.UUID, kind dto.TrashKind, id uuid.UUID) (*dto.TrashItemResponse, error)
+
+	// PurgeExpired permanently deletes records deleted longer ago than the retention,
+	// returning how many were deleted
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// trashService implements TrashService
+type trashService struct {
+	repos     *repository.Repositories
+	logger    log.AllLogger
+	retention time.Duration
+}
+
+// NewTrashService creates a new TrashService instance. A zero retention keeps deleted
+// records for 30 days.
+func NewTrashService(repos *repository.Repositories, logger log.AllLogger, retention time.Duration) TrashService {
+	if retention <= 0 {
+		retention = defaultTrashRetention
+	}
+	return &trashService{
+		repos:     repos,
+		logger:    logger,
+		retention: retention,
+	}
+}
+
+// ListDeleted retrieves a page of the tenant's trash
+func (s *trashService) ListDeleted(ctx context.Context, req *dto.TrashListRequest) (*dto.TrashListResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	filters := map[string]any{"tenant_id": req.TenantID}
+	pagination := repository.PaginationParams{Page: req.Page, PageSize: req.PageSize}
+
+	var items []*dto.TrashItemResponse
+	var page repository.PaginationResult
+	var err error
+	switch req.Kind {
+	case dto.TrashKindBookings:
+		items, page, err = findDeleted(ctx, s.repos.Booking, filters, pagination, s.bookingItem)
+	case dto.TrashKindServices:
+		items, page, err = findDeleted(ctx, s.repos.Service, filters, pagination, s.serviceItem)
+	case dto.TrashKindProjects:
+		items, page, err = findDeleted(ctx, s.repos.Project, filters, pagination, s.projectItem)
+	}
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to list deleted "+string(req.Kind), err)
+	}
+
+	return &dto.TrashListResponse{
+		Items:      items,
+		Page:       page.Page,
+		PageSize:   page.PageSize,
+		TotalItems: page.TotalItems,
+		TotalPages: page.TotalPages,
+	}, nil
+}
+
+// Restore undeletes a record of the tenant's trash. A booking whose time slot has been
+// taken since it was deleted is left in the trash, as restoring it would double-book
+// the artisan.
+func (s *trashService) Restore(ctx context.Context, tenantID uuid.UUID, kind dto.TrashKind, id uuid.UUID) (*dto.TrashItemResponse, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+	if !kind.IsValid() {
+		return nil, errors.NewValidationError("kind must be bookings, services or projects")
+	}
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("id is required")
+	}
+
+	filters := map[string]any{"tenant_id": tenantID, "id": id}
+	pagination := repository.PaginationParams{Page: 1, PageSize: 1}
+
+	var items []*dto.TrashItemResponse
+	var err error
+	switch kind {
+	case dto.TrashKindBookings:
+		var bookings []*models.Booking
+		bookings, _, err = s.repos.Booking.FindDeleted(ctx, filters, pagination)
+		if err == nil && len(bookings) == 1 {
+			if err := s.checkBookingSlot(ctx, bookings[0]); err != nil {
+				return nil, err
+			}
+			items = []*dto.TrashItemResponse{s.bookingItem(bookings[0])}
+			err = s.repos.Booking.Restore(ctx, id)
+		}
+	case dto.TrashKindServices:
+		items, _, err = findDeleted(ctx, s.repos.Service, filters, pagination, s.serviceItem)
+		if err == nil && len(items) == 1 {
+			err = s.repos.Service.Restore(ctx, id)
+		}
+	case dto.TrashKindProjects:
+		items, _, err = findDeleted(ctx, s.repos.Project, filters, pagination, s.projectItem)
+		if err == nil && len(items) == 1 {
+			err = s.repos.Project.Restore(ctx, id)
+		}
+	}
+	if err != nil {
+		s.logger.Error("failed to restore deleted record", "kind", kind, "id", id, "error", err)
+		return nil, errors.NewServiceError("RESTORE_FAILED", "failed to restore "+string(kind), err)
+	}
+	if len(items) == 0 {
+		return nil, errors.NewNotFoundError("deleted record")
+	}
+
+	s.logger.Info("deleted record restored", "kind", kind, "id", id, "tenant_id", tenantID)
+	return items[0], nil
+}
+
+// PurgeExpired purges each kind in turn, carrying on past a kind that fails so its
+// error doesn't hold back the others
+func (s *trashService) PurgeExpired(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-s.retention)
+
+	purges := []struct {
+		kind  dto.TrashKind
+		purge func(context.Context, time.Time) (int64, error)
+	}{
+		{dto.TrashKindBookings, s.repos.Booking.PurgeDeleted},
+		{dto.TrashKindServices, s.repos.Service.PurgeDeleted},
+		{dto.TrashKindProjects, s.repos.Project.PurgeDeleted},
+	}
+
+	var purged int64
+	var firstErr error
+	for _, p := range purges {
+		count, err := p.purge(ctx, before)
+		if err != nil {
+			s.logger.Error("failed to purge deleted records", "kind", p.kind, "error", err)
+			if firstErr == nil {
+				firstErr = errors.NewServiceError("PURGE_FAILED", "failed to purge deleted "+string(p.kind), err)
+			}
+			continue
+		}
+		purged += count
+	}
+
+	if purged > 0 {
+		s.logger.Info("trash purged", "count", purged, "deleted_before", before)
+	}
+	return purged, firstErr
+}
+
+// checkBookingSlot fails when a booking that would still take up its time slot
+// overlaps a booking made since it was deleted
+func (s *trashService) checkBookingSlot(ctx context.Context, booking *models.Booking) error {
+	if booking.Status == models.BookingStatusCancelled || booking.Status == models.BookingStatusNoShow {
+		return nil
+	}
+
+	overlaps, err := s.repos.Booking.HasOverlappingBookings(ctx, booking.ArtisanID, booking.StartTime, booking.EndTime, &booking.ID)
+	if err != nil {
+		return errors.NewServiceError("QUERY_FAILED", "failed to check booking availability", err)
+	}
+	if overlaps {
+		return errors.NewConflictError("the booking's time slot has been booked since it was deleted")
+	}
+	return nil
+}
+
+func (s *trashService) bookingItem(booking *models.Booking) *dto.TrashItemResponse {
+	item := s.item(dto.TrashKindBookings, booking.ID, booking.DeletedAt.Time)
+	item.StartTime = &booking.StartTime
+	return item
+}
+
+func (s *trashService) serviceItem(service *models.Service) *dto.TrashItemResponse {
+	item := s.item(dto.TrashKindServices, service.ID, service.DeletedAt.Time)
+	item.Title = service.Name
+	return item
+}
+
+func (s *trashService) projectItem(project *models.Project) *dto.TrashItemResponse {
+	item := s.item(dto.TrashKindProjects, project.ID, project.DeletedAt.Time)
+	item.Title = project.Title
+	return item
+}
+
+func (s *trashService) item(kind dto.TrashKind, id uuid.UUID, deletedAt time.Time) *dto.TrashItemResponse {
+	return &dto.TrashItemResponse{
+		ID:        id,
+		Kind:      kind,
+		DeletedAt: deletedAt,
+		PurgeAt:   deletedAt.Add(s.retention),
+	}
+}
+
+// findDeleted retrieves a page of deleted records and maps them to trash items
+func findDeleted[T any](ctx context.Context, repo repository.BaseRepository[T], filters map[string]any, pagination repository.PaginationParams, toItem func(*T) *dto.TrashItemResponse) ([]*dto.TrashItemResponse, repository.PaginationResult, error) {
+	records, page, err := repo.FindDeleted(ctx, filters, pagination)
+	if err != nil {
+		return nil, page, err
+	}
+
+	items := make([]*dto.TrashItemResponse, len(records))
+	for i, record := range records {
+		items[i] = toItem(record)
+	}
+	return items, page, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// erasureGracePeriod is how long users have to change their mind once the erasure of
+// their personal data is requested
+const erasureGracePeriod = 30 * 24 * time.Hour
+
+// erasureBatchSize caps the users erased per run of the erasure job
+const erasureBatchSize = 50
+
+// UserErasureService defines the interface for erasing users' personal data (GDPR right
+// to erasure). Erasure is scheduled after a grace period, during which it can be
+// cancelled; the user is then anonymized in place, keeping the bookings, payments and
+// ratings referencing them.
+type UserErasureService interface {
+	RequestErasure(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) (*dto.ErasureResponse, error)
+	GetErasure(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) (*dto.ErasureResponse, error)
+	CancelErasure(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) (*dto.ErasureResponse, error)
+
+	// ProcessDueErasures erases the users whose grace period has ended, including those
+	// marked for deletion otherwise, and returns how many were erased
+	ProcessDueErasures(ctx context.Context) (int, error)
+}
+
+// userErasureService implements UserErasureService
+type userErasureService struct {
+	repos    *repository.Repositories
+	logger   log.AllLogger
+	revoker  TokenRevoker
+	identity IdentityProvider
+}
+
+// NewUserErasureService creates a new UserErasureService instance. Erased users are
+// signed out through revoker and their Zitadel account removed through identity; either
+// may be nil, in which case sessions last until their tokens expire and accounts are
+// left for an administrator to remove.
+func NewUserErasureService(repos *repository.Repositories, logger log.AllLogger, revoker TokenRevoker, identity IdentityProvider) UserErasureService {
+	return &userErasureService{
+		repos:    repos,
+		logger:   logger,
+		revoker:  revoker,
+		identity: identity,
+	}
+}
+
+// RequestErasure schedules the erasure of the user's personal data after the grace
+// period; requesting it again leaves the schedule as it is
+func (s *userErasureService) RequestErasure(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) (*dto.ErasureResponse, error) {
+	user, err := loadDataSubject(ctx, s.repos, userID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsErased() {
+		return nil, errors.NewConflictError("the user's personal data has already been erased")
+	}
+	if user.IsTenantOwner() {
+		return nil, errors.NewValidationError("transfer ownership of the organization or close it before erasing its owner")
+	}
+	if user.MarkedForDeletion {
+		return dto.ToErasureResponse(user), nil
+	}
+
+	scheduledAt := time.Now().Add(erasureGracePeriod)
+	user.MarkedForDeletion = true
+	user.DeletionScheduledAt = &scheduledAt
+	if err := s.repos.User.Update(ctx, user); err != nil {
+		s.logger.Error("failed to schedule user erasure", "user_id", userID, "error", err)
+		return nil, errors.NewServiceError("ERASURE_FAILED", "failed to schedule erasure", err)
+	}
+
+	s.logger.Info("user erasure scheduled", "user_id", userID, "scheduled_at", scheduledAt, "requested_by", requestingUserID)
+	return dto.ToErasureResponse(user), nil
+}
+
+// GetErasure returns whether the user's personal data is scheduled to be erased, or has been
+func (s *userErasureService) GetErasure(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) (*dto.ErasureResponse, error) {
+	user, err := loadDataSubject(ctx, s.repos, userID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToErasureResponse(user), nil
+}
+
+// CancelErasure cancels a scheduled erasure during its grace period
+func (s *userErasureService) CancelErasure(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) (*dto.ErasureResponse, error) {
+	user, err := loadDataSubject(ctx, s.repos, userID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsErased() {
+		return nil, errors.NewConflictError("the user's personal data has already been erased")
+	}
+	if !user.MarkedForDeletion {
+		return dto.ToErasureResponse(user), nil
+	}
+
+	user.MarkedForDeletion = false
+	user.DeletionScheduledAt = nil
+	if err := s.repos.User.Update(ctx, user); err != nil {
+		s.logger.Error("failed to cancel user erasure", "user_id", userID, "error", err)
+		return nil, errors.NewServiceError("ERASURE_FAILED", "failed to cancel erasure", err)
+	}
+
+	s.logger.Info("user erasure cancelled", "user_id", userID, "cancelled_by", requestingUserID)
+	return dto.ToErasureResponse(user), nil
+}
+
+// ProcessDueErasures anonymizes the users due for erasure, then signs them out and
+// removes their Zitadel account. Each erasure is recorded in the audit trail, which
+// jobs otherwise don't write to.
+func (s *userErasureService) ProcessDueErasures(ctx context.Context) (int, error) {
+	users, err := s.repos.User.GetUsersMarkedForDeletion(ctx)
+	if err != nil {
+		s.logger.Error("failed to find users due for erasure", "error", err)
+		return 0, errors.NewServiceError("ERASURE_FAILED", "failed to find users due for erasure", err)
+	}
+	if len(users) > erasureBatchSize {
+		users = users[:erasureBatchSize]
+	}
+
+	erased := 0
+	for _, user := range users {
+		now := time.Now()
+		if err := s.repos.User.EraseUser(ctx, user.ID, now); err != nil {
+			s.logger.Error("failed to erase user", "user_id", user.ID, "error", err)
+			continue
+		}
+		erased++
+
+		if s.revoker != nil {
+			if err := s.revoker.Deny(ctx, cache.UserTokens(user.ID)); err != nil {
+				s.logger.Error("failed to deny erased user's sessions", "user_id", user.ID, "error", err)
+			}
+		}
+		if s.identity != nil && user.ZitadelUserID != "" {
+			if err := s.identity.DeleteUser(ctx, user.ZitadelUserID); err != nil {
+				s.logger.Error("failed to remove erased user's account", "user_id", user.ID, "error", err)
+			}
+		}
+
+		entry := &models.AuditLog{
+			TenantID:    user.TenantID,
+			Action:      models.AuditActionErase,
+			EntityType:  "users",
+			EntityID:    user.ID,
+			Description: "Erased personal data",
+			Metadata: models.JSONB{
+				"scheduled_at": user.DeletionScheduledAt,
+				"erased_at":    now,
+			},
+		}
+		if err := s.repos.AuditLog.Create(ctx, entry); err != nil {
+			s.logger.Error("failed to record user erasure", "user_id", user.ID, "error", err)
+		}
+
+		s.logger.Info("user erased", "user_id", user.ID)
+	}
+
+	return erased, nil
+}
+
+// loadDataSubject returns the user whose personal data is requested, which users may
+// request of themselves, platform admins of anyone, and tenant owners and admins of
+// users in their tenant. M2M tokens have no requesting user; their scopes are checked
+// instead.
+func loadDataSubject(ctx context.Context, repos *repository.Repositories, userID uuid.UUID, requestingUserID uuid.UUID) (*models.User, error) {
+	user, err := repos.User.GetByID(ctx, userID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFoundError("user")
+		}
+		return nil, errors.NewServiceError("USER_LOOKUP_FAILED", "failed to get user", err)
+	}
+	if requestingUserID == uuid.Nil || requestingUserID == userID {
+		return user, nil
+	}
+
+	requestingUser, err := repos.User.GetByID(ctx, requestingUserID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("requesting user")
+	}
+	if requestingUser.IsPlatformAdmin() {
+		return user, nil
+	}
+	if (requestingUser.IsTenantOwner() || requestingUser.IsTenantAdmin()) && user.TenantID != nil && requestingUser.CanManageTenant(*user.TenantID) {
+		return user, nil
+	}
+
+	// Don't expose users of other tenants
+	return nil, errors.NewNotFoundError("user")
+}
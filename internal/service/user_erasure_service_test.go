@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserErasureService_RequestAndCancel(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	erasures := service.NewUserErasureService(&repository.Repositories{User: userRepo}, &MockLogger{}, nil, nil)
+	ctx := context.Background()
+
+	tenantID := uuid.New()
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: &tenantID, Role: models.UserRoleCustomer}
+	userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	userRepo.On("Update", ctx, mock.AnythingOfType("*models.User")).Return(nil)
+
+	erasure, err := erasures.RequestErasure(ctx, user.ID, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "scheduled", erasure.Status)
+	require.NotNil(t, erasure.ScheduledAt)
+	scheduledAt := *erasure.ScheduledAt
+
+	// Requesting it again keeps the grace period running from the first request
+	erasure, err = erasures.RequestErasure(ctx, user.ID, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, scheduledAt, *erasure.ScheduledAt)
+
+	erasure, err = erasures.CancelErasure(ctx, user.ID, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "none", erasure.Status)
+	assert.False(t, user.MarkedForDeletion)
+}
+
+func TestUserErasureService_RequestErasureAccess(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	erasures := service.NewUserErasureService(&repository.Repositories{User: userRepo}, &MockLogger{}, nil, nil)
+	ctx := context.Background()
+
+	tenantID, otherTenantID := uuid.New(), uuid.New()
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: &tenantID, Role: models.UserRoleCustomer}
+	otherAdmin := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: &otherTenantID, Role: models.UserRoleTenantAdmin}
+	owner := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: &tenantID, Role: models.UserRoleTenantOwner}
+	userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	userRepo.On("GetByID", ctx, otherAdmin.ID).Return(otherAdmin, nil)
+	userRepo.On("GetByID", ctx, owner.ID).Return(owner, nil)
+
+	// Admins of other tenants don't see the user
+	_, err := erasures.RequestErasure(ctx, user.ID, otherAdmin.ID)
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.HTTPStatus)
+
+	// Owners hand over their organization first
+	_, err = erasures.RequestErasure(ctx, owner.ID, owner.ID)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.HTTPStatus)
+	userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
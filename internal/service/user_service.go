@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
 	"Krafti_Vibe/internal/pkg/errors"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
@@ -45,6 +46,7 @@ type UserService interface {
 	RecordLogin(ctx context.Context, userID uuid.UUID) error
 	RecordFailedLogin(ctx context.Context, userID uuid.UUID) error
 	UnlockUser(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) error
+	RevokeSessions(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) error
 	GetLockedUsers(ctx context.Context, requestingUserID uuid.UUID) ([]*dto.UserResponse, error)
 
 	// MFA Management
@@ -85,17 +87,31 @@ type UserService interface {
 	UpdateUserStatusFromWebhook(ctx context.Context, userID uuid.UUID, status models.UserStatus) error
 }
 
+// TokenRevoker refuses access tokens before they expire, across every API instance.
+// Subjects name whose tokens are refused, such as cache.UserTokens(id).
+type TokenRevoker interface {
+	// Revoke refuses the tokens issued to subject so far, so it has to sign in again
+	Revoke(ctx context.Context, subject string) error
+	// Deny refuses every token of subject, including those issued later, until Release
+	Deny(ctx context.Context, subject string) error
+	// Release lifts a denial; tokens issued before it stay refused
+	Release(ctx context.Context, subject string) error
+}
+
 // userService implements UserService
 type userService struct {
-	repos  *repository.Repositories
-	logger log.AllLogger
+	repos   *repository.Repositories
+	logger  log.AllLogger
+	revoker TokenRevoker
 }
 
-// NewUserService creates a new user service
-func NewUserService(repos *repository.Repositories, logger log.AllLogger) UserService {
+// NewUserService creates a new user service; revoker may be nil without Redis, in
+// which case sessions last until their tokens expire
+func NewUserService(repos *repository.Repositories, logger log.AllLogger, revoker TokenRevoker) UserService {
 	return &userService{
-		repos:  repos,
-		logger: logger,
+		repos:   repos,
+		logger:  logger,
+		revoker: revoker,
 	}
 }
 
@@ -367,6 +383,8 @@ func (s *userService) DeleteUser(ctx context.Context, userID uuid.UUID, requesti
 		return errors.NewServiceError("DELETE_FAILED", "Failed to delete user", err)
 	}
 
+	s.denySessions(ctx, userID)
+
 	s.logger.Info("user deleted successfully", "user_id", userID)
 	return nil
 }
@@ -722,6 +740,48 @@ func (s *userService) UnlockUser(ctx context.Context, userID uuid.UUID, requesti
 	return nil
 }
 
+// RevokeSessions signs a user out of every session by revoking the tokens issued to
+// them so far; they have to sign in again
+func (s *userService) RevokeSessions(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) error {
+	s.logger.Info("revoking user sessions", "user_id", userID, "requesting_user_id", requestingUserID)
+
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return errors.NewNotFoundError("user")
+	}
+
+	// M2M tokens bypass user-level permission checks (already validated by scopes)
+	if requestingUserID != uuid.Nil {
+		// Check permissions for user tokens
+		requestingUser, err := s.repos.User.GetByID(ctx, requestingUserID)
+		if err != nil {
+			return errors.NewNotFoundError("requesting user")
+		}
+
+		// Platform admins can sign out any user, tenant admins the users of their tenant
+		if !requestingUser.IsPlatformAdmin() {
+			if !(requestingUser.IsTenantAdmin() || requestingUser.IsTenantOwner()) {
+				return errors.NewValidationError("You don't have permission to revoke sessions")
+			}
+
+			if user.TenantID == nil || requestingUser.TenantID == nil || *user.TenantID != *requestingUser.TenantID {
+				return errors.NewValidationError("You can only revoke sessions of users in your tenant")
+			}
+		}
+	}
+
+	if s.revoker == nil {
+		return errors.NewServiceError("REVOCATION_UNAVAILABLE", "Session revocation is not available", nil)
+	}
+	if err := s.revoker.Revoke(ctx, cache.UserTokens(userID)); err != nil {
+		s.logger.Error("failed to revoke user sessions", "user_id", userID, "error", err)
+		return errors.NewServiceError("REVOCATION_FAILED", "Failed to revoke user sessions", err)
+	}
+
+	s.logger.Info("user sessions revoked successfully", "user_id", userID)
+	return nil
+}
+
 // GetLockedUsers retrieves all locked users
 func (s *userService) GetLockedUsers(ctx context.Context, requestingUserID uuid.UUID) ([]*dto.UserResponse, error) {
 	s.logger.Info("getting locked users")
@@ -862,6 +922,9 @@ func (s *userService) UpdateRole(ctx context.Context, userID uuid.UUID, newRole
 		return errors.NewServiceError("UPDATE_FAILED", "Failed to update user role", err)
 	}
 
+	// Tokens carry the roles they were issued with
+	s.revokeSessions(ctx, userID)
+
 	s.logger.Info("user role updated successfully", "user_id", userID, "new_role", newRole)
 	return nil
 }
@@ -888,6 +951,8 @@ func (s *userService) UpdateStatus(ctx context.Context, userID uuid.UUID, newSta
 		return errors.NewServiceError("UPDATE_FAILED", "Failed to update user status", err)
 	}
 
+	s.applyStatusToSessions(ctx, userID, newStatus)
+
 	s.logger.Info("user status updated successfully", "user_id", userID, "new_status", newStatus)
 	return nil
 }
@@ -924,6 +989,8 @@ func (s *userService) SuspendUser(ctx context.Context, userID uuid.UUID, req *dt
 		return errors.NewServiceError("SUSPEND_FAILED", "Failed to suspend user", err)
 	}
 
+	s.denySessions(ctx, userID)
+
 	s.logger.Info("user suspended successfully", "user_id", userID)
 	return nil
 }
@@ -1093,6 +1160,8 @@ func (s *userService) PermanentlyDeleteUser(ctx context.Context, userID uuid.UUI
 		return errors.NewServiceError("DELETE_FAILED", "Failed to permanently delete user", err)
 	}
 
+	s.denySessions(ctx, userID)
+
 	s.logger.Info("user permanently deleted successfully", "user_id", userID)
 	return nil
 }
@@ -1375,6 +1444,8 @@ func (s *userService) DeleteUserFromWebhook(ctx context.Context, userID uuid.UUI
 		return errors.NewServiceError("DELETE_FAILED", "Failed to delete user from webhook", err)
 	}
 
+	s.denySessions(ctx, userID)
+
 	s.logger.Info("user deleted from webhook successfully", "user_id", userID, "user_email", user.Email)
 	return nil
 }
@@ -1388,6 +1459,52 @@ func (s *userService) UpdateUserStatusFromWebhook(ctx context.Context, userID uu
 		return errors.NewServiceError("UPDATE_FAILED", "Failed to update user status from webhook", err)
 	}
 
+	s.applyStatusToSessions(ctx, userID, status)
+
 	s.logger.Info("user status updated from webhook successfully", "user_id", userID, "status", status)
 	return nil
 }
+
+// ============================================================================
+// Session Revocation
+// ============================================================================
+
+// revokeSessions signs the user out of the sessions they have. Failures are logged
+// rather than returned, as the change calling for it is already made.
+func (s *userService) revokeSessions(ctx context.Context, userID uuid.UUID) {
+	if s.revoker == nil {
+		return
+	}
+	if err := s.revoker.Revoke(ctx, cache.UserTokens(userID)); err != nil {
+		s.logger.Error("failed to revoke user sessions", "user_id", userID, "error", err)
+	}
+}
+
+// denySessions refuses every session of the user until they are activated again
+func (s *userService) denySessions(ctx context.Context, userID uuid.UUID) {
+	if s.revoker == nil {
+		return
+	}
+	if err := s.revoker.Deny(ctx, cache.UserTokens(userID)); err != nil {
+		s.logger.Error("failed to deny user sessions", "user_id", userID, "error", err)
+	}
+}
+
+// applyStatusToSessions refuses the sessions of users deactivated or suspended, and
+// lets activated users sign in again
+func (s *userService) applyStatusToSessions(ctx context.Context, userID uuid.UUID, status models.UserStatus) {
+	if s.revoker == nil {
+		return
+	}
+
+	var err error
+	switch status {
+	case models.UserStatusInactive, models.UserStatusSuspended:
+		err = s.revoker.Deny(ctx, cache.UserTokens(userID))
+	case models.UserStatusActive:
+		err = s.revoker.Release(ctx, cache.UserTokens(userID))
+	}
+	if err != nil {
+		s.logger.Error("failed to update user sessions for status", "user_id", userID, "status", status, "error", err)
+	}
+}
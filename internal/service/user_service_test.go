@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/cache"
 	"Krafti_Vibe/internal/pkg/errors"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service"
@@ -29,7 +30,7 @@ func setupUserServiceTest() (*repository.Repositories, service.UserService) {
 		User: userRepo,
 	}
 
-	svc := service.NewUserService(repos, logger)
+	svc := service.NewUserService(repos, logger, nil)
 
 	return repos, svc
 }
@@ -354,3 +355,58 @@ func TestUserService_UpdateRole(t *testing.T) {
 		userRepo.AssertExpectations(t)
 	})
 }
+
+// recordingRevoker records the token revocations asked of it
+type recordingRevoker struct {
+	calls []string
+}
+
+func (r *recordingRevoker) Revoke(ctx context.Context, subject string) error {
+	r.calls = append(r.calls, "revoke "+subject)
+	return nil
+}
+
+func (r *recordingRevoker) Deny(ctx context.Context, subject string) error {
+	r.calls = append(r.calls, "deny "+subject)
+	return nil
+}
+
+func (r *recordingRevoker) Release(ctx context.Context, subject string) error {
+	r.calls = append(r.calls, "release "+subject)
+	return nil
+}
+
+func TestUserService_SessionRevocation(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	revoker := &recordingRevoker{}
+	svc := service.NewUserService(&repository.Repositories{User: userRepo}, &MockLogger{}, revoker)
+	ctx := context.Background()
+
+	tenantID := uuid.New()
+	admin := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: &tenantID, Role: models.UserRoleTenantAdmin}
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: &tenantID, Role: models.UserRoleTeamMember}
+	subject := cache.UserTokens(user.ID)
+
+	userRepo.On("GetByID", ctx, admin.ID).Return(admin, nil)
+	userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	userRepo.On("UpdateRole", ctx, user.ID, models.UserRoleArtisan).Return(nil)
+	userRepo.On("UpdateStatus", ctx, user.ID, models.UserStatusInactive).Return(nil)
+	userRepo.On("UpdateStatus", ctx, user.ID, models.UserStatusActive).Return(nil)
+
+	require.NoError(t, svc.UpdateRole(ctx, user.ID, models.UserRoleArtisan, admin.ID))
+	require.NoError(t, svc.DeactivateUser(ctx, user.ID, admin.ID))
+	require.NoError(t, svc.ActivateUser(ctx, user.ID, admin.ID))
+	require.NoError(t, svc.RevokeSessions(ctx, user.ID, admin.ID))
+
+	assert.Equal(t, []string{"revoke " + subject, "deny " + subject, "release " + subject, "revoke " + subject}, revoker.calls)
+
+	t.Run("only users of the admin's tenant", func(t *testing.T) {
+		otherTenantID := uuid.New()
+		other := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, TenantID: &otherTenantID, Role: models.UserRoleCustomer}
+		userRepo.On("GetByID", ctx, other.ID).Return(other, nil)
+
+		err := svc.RevokeSessions(ctx, other.ID, admin.ID)
+		require.Error(t, err)
+		assert.Len(t, revoker.calls, 4)
+	})
+}
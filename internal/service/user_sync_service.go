@@ -71,8 +71,7 @@ func (s *UserSyncService) updateExistingUser(ctx context.Context, user *models.U
 		zap.String("zitadel_user_id", user.ZitadelUserID),
 	)
 
-	now := time.Now()
-	user.LastLoginAt = &now
+	recordSignIn(user)
 
 	// Update basic info from Zitadel if changed
 	if authCtx.Email != "" && authCtx.Email != user.Email {
@@ -124,7 +123,7 @@ func (s *UserSyncService) linkExistingUser(ctx context.Context, user *models.Use
 	user.AuthProvider = "zitadel"
 	user.MigrationStatus = "completed"
 	user.MigratedAt = &now
-	user.LastLoginAt = &now
+	recordSignIn(user)
 
 	// Check for platform roles
 	zitadelRoles := extractZitadelRoles(authCtx)
@@ -271,14 +270,23 @@ func parseUsername(username, email string) (string, string) {
 	return "User", ""
 }
 
+// recordSignIn updates the user's last login. Users invited ahead of their first
+// sign-in, such as the owners of onboarded tenants, become active with it.
+func recordSignIn(user *models.User) {
+	now := time.Now()
+	user.LastLoginAt = &now
+	if user.Status == models.UserStatusPending {
+		user.Status = models.UserStatusActive
+	}
+}
+
 // GetOrSyncUser gets a user from DB or syncs from Zitadel if needed
 func (s *UserSyncService) GetOrSyncUser(ctx context.Context, zitadelUserID string, authCtx *oauth.IntrospectionContext) (interface{}, error) {
 	// Try to get from database first
 	user, err := s.userRepo.GetByZitadelID(ctx, zitadelUserID)
 	if err == nil && user != nil {
 		// User exists, update last login
-		now := time.Now()
-		user.LastLoginAt = &now
+		recordSignIn(user)
 		_ = s.userRepo.Update(ctx, user)
 		return user, nil
 	}
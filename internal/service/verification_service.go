@@ -0,0 +1,385 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/infrastructure/storage"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// verificationExpiryBatchSize caps the expired documents processed per run
+const verificationExpiryBatchSize = 200
+
+// verificationContentTypes are the file types accepted as verification documents,
+// by the extension they are stored under
+var verificationContentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+}
+
+// VerificationDocumentDownload is an opened verification document file
+type VerificationDocumentDownload struct {
+	Content     io.ReadCloser
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+// VerificationService manages artisan verification documents and the badges they earn
+type VerificationService interface {
+	// Artisan Operations
+	UploadDocument(ctx context.Context, req *dto.UploadVerificationDocumentRequest, content io.Reader) (*dto.VerificationDocumentResponse, error)
+	GetArtisanVerification(ctx context.Context, tenantID, artisanID uuid.UUID) (*dto.ArtisanVerificationResponse, error)
+	OpenDocument(ctx context.Context, tenantID, documentID uuid.UUID) (*VerificationDocumentDownload, error)
+
+	// Review Operations
+	ListDocuments(ctx context.Context, tenantID uuid.UUID, status models.VerificationStatus, page, pageSize int) (*dto.VerificationDocumentListResponse, error)
+	ApproveDocument(ctx context.Context, tenantID, documentID uuid.UUID, req *dto.ApproveVerificationDocumentRequest) (*dto.VerificationDocumentResponse, error)
+	RejectDocument(ctx context.Context, tenantID, documentID uuid.UUID, req *dto.RejectVerificationDocumentRequest) (*dto.VerificationDocumentResponse, error)
+
+	// Background Operations
+	ExpireDocuments(ctx context.Context) (int, error)
+}
+
+// verificationService implements VerificationService
+type verificationService struct {
+	repos         *repository.Repositories
+	logger        log.AllLogger
+	store         storage.ObjectStore
+	notifications NotificationService
+}
+
+// NewVerificationService creates a new VerificationService instance; documents cannot
+// be uploaded without a store
+func NewVerificationService(repos *repository.Repositories, logger log.AllLogger, store storage.ObjectStore) VerificationService {
+	return &verificationService{
+		repos:         repos,
+		logger:        logger,
+		store:         store,
+		notifications: NewNotificationService(repos, logger),
+	}
+}
+
+// ============================================================================
+// Artisan Operations
+// ============================================================================
+
+// UploadDocument stores a PDF or image document and queues it for review. The file type
+// is detected from its content rather than trusted from the upload.
+func (s *verificationService) UploadDocument(ctx context.Context, req *dto.UploadVerificationDocumentRequest, content io.Reader) (*dto.VerificationDocumentResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+	if s.store == nil {
+		return nil, errors.NewServiceError("VERIFICATION_UNAVAILABLE", "file storage is not configured", nil)
+	}
+
+	artisan, err := s.getArtisan(ctx, req.TenantID, req.ArtisanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, artisan); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !stdErrors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, errors.NewValidationError("failed to read document file")
+	}
+	contentType := http.DetectContentType(head[:n])
+	ext, ok := verificationContentTypes[contentType]
+	if !ok {
+		return nil, errors.NewValidationError("documents must be PDF, JPEG, PNG or WebP files")
+	}
+
+	document := &models.VerificationDocument{
+		TenantID:    req.TenantID,
+		ArtisanID:   req.ArtisanID,
+		Type:        req.Type,
+		Reference:   strings.TrimSpace(req.Reference),
+		Issuer:      strings.TrimSpace(req.Issuer),
+		FileName:    filepath.Base(req.FileName),
+		ContentType: contentType,
+		Status:      models.VerificationStatusPending,
+	}
+	document.ID = uuid.New()
+	document.FileKey = fmt.Sprintf("verification/%s/%s/%s%s", req.TenantID, req.ArtisanID, document.ID, ext)
+
+	size, err := s.store.Put(ctx, document.FileKey, contentType, io.MultiReader(bytes.NewReader(head[:n]), content))
+	if err != nil {
+		s.logger.Error("failed to store verification document", "artisan_id", req.ArtisanID, "error", err)
+		return nil, errors.NewServiceError("VERIFICATION_UPLOAD_FAILED", "failed to store document", err)
+	}
+	document.FileSize = size
+
+	if err := s.repos.Verification.Create(ctx, document); err != nil {
+		s.logger.Error("failed to create verification document", "artisan_id", req.ArtisanID, "error", err)
+		if err := s.store.Delete(ctx, document.FileKey); err != nil {
+			s.logger.Warn("failed to delete orphaned verification document", "file_key", document.FileKey, "error", err)
+		}
+		return nil, errors.NewServiceError("VERIFICATION_UPLOAD_FAILED", "failed to create document", err)
+	}
+
+	s.logger.Info("verification document uploaded", "document_id", document.ID, "artisan_id", document.ArtisanID, "type", document.Type)
+	return dto.ToVerificationDocumentResponse(document), nil
+}
+
+// GetArtisanVerification returns an artisan's badges and documents, to the artisan and
+// the tenant's owners and admins
+func (s *verificationService) GetArtisanVerification(ctx context.Context, tenantID, artisanID uuid.UUID) (*dto.ArtisanVerificationResponse, error) {
+	artisan, err := s.getArtisan(ctx, tenantID, artisanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, artisan); err != nil {
+		return nil, err
+	}
+
+	documents, err := s.repos.Verification.FindByArtisan(ctx, tenantID, artisanID)
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to list verification documents", err)
+	}
+
+	return &dto.ArtisanVerificationResponse{
+		ArtisanID:  artisan.ID,
+		IsVerified: artisan.IsVerified,
+		IsInsured:  artisan.IsInsured,
+		Documents:  dto.ToVerificationDocumentResponses(documents),
+	}, nil
+}
+
+// OpenDocument opens a document's file for the artisan or a tenant owner or admin
+func (s *verificationService) OpenDocument(ctx context.Context, tenantID, documentID uuid.UUID) (*VerificationDocumentDownload, error) {
+	document, err := s.getDocument(ctx, tenantID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	artisan, err := s.getArtisan(ctx, tenantID, document.ArtisanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, artisan); err != nil {
+		return nil, err
+	}
+	if s.store == nil {
+		return nil, errors.NewNotFoundError("document file not available")
+	}
+
+	content, err := s.store.Open(ctx, document.FileKey)
+	if err != nil {
+		if stdErrors.Is(err, storage.ErrObjectNotFound) {
+			return nil, errors.NewNotFoundError("document file not available")
+		}
+		return nil, errors.NewServiceError("VERIFICATION_OPEN_FAILED", "failed to open document file", err)
+	}
+
+	return &VerificationDocumentDownload{
+		Content:     content,
+		FileName:    document.FileName,
+		ContentType: document.ContentType,
+		Size:        document.FileSize,
+	}, nil
+}
+
+// ============================================================================
+// Review Operations
+// ============================================================================
+
+// ListDocuments lists the tenant's documents in a status, pending ones forming the
+// review queue
+func (s *verificationService) ListDocuments(ctx context.Context, tenantID uuid.UUID, status models.VerificationStatus, page, pageSize int) (*dto.VerificationDocumentListResponse, error) {
+	if status == "" {
+		status = models.VerificationStatusPending
+	}
+	if !status.IsValid() {
+		return nil, errors.NewValidationError("invalid verification status")
+	}
+
+	documents, result, err := s.repos.Verification.FindByStatus(ctx, tenantID, status, repository.PaginationParams{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to list verification documents", err)
+	}
+
+	return &dto.VerificationDocumentListResponse{
+		Documents:   dto.ToVerificationDocumentResponses(documents),
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// ApproveDocument approves a pending document and awards the badges it earns
+func (s *verificationService) ApproveDocument(ctx context.Context, tenantID, documentID uuid.UUID, req *dto.ApproveVerificationDocumentRequest) (*dto.VerificationDocumentResponse, error) {
+	return s.review(ctx, tenantID, documentID, func(document *models.VerificationDocument, reviewerID uuid.UUID, now time.Time) error {
+		return document.Approve(reviewerID, req.ExpiresAt, now)
+	})
+}
+
+// RejectDocument rejects a pending document, telling the artisan why
+func (s *verificationService) RejectDocument(ctx context.Context, tenantID, documentID uuid.UUID, req *dto.RejectVerificationDocumentRequest) (*dto.VerificationDocumentResponse, error) {
+	return s.review(ctx, tenantID, documentID, func(document *models.VerificationDocument, reviewerID uuid.UUID, now time.Time) error {
+		return document.Reject(reviewerID, req.Reason, now)
+	})
+}
+
+// review records a reviewer's decision on a document, refreshes the artisan's badges
+// and tells the artisan. Artisans cannot review their own documents.
+func (s *verificationService) review(ctx context.Context, tenantID, documentID uuid.UUID, decide func(*models.VerificationDocument, uuid.UUID, time.Time) error) (*dto.VerificationDocumentResponse, error) {
+	actor := contextActor(ctx)
+	if actor == nil || !actor.CanManageTenant(tenantID) {
+		return nil, errors.NewForbiddenError("only tenant owners and admins can review verification documents")
+	}
+
+	document, err := s.getDocument(ctx, tenantID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	artisan, err := s.getArtisan(ctx, tenantID, document.ArtisanID)
+	if err != nil {
+		return nil, err
+	}
+	if artisan.UserID == actor.ID {
+		return nil, errors.NewForbiddenError("artisans cannot review their own verification documents")
+	}
+
+	now := time.Now()
+	if err := decide(document, actor.ID, now); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+	if err := s.repos.Verification.Update(ctx, document); err != nil {
+		s.logger.Error("failed to update verification document", "document_id", documentID, "error", err)
+		return nil, errors.NewServiceError("VERIFICATION_REVIEW_FAILED", "failed to review document", err)
+	}
+
+	s.logger.Info("verification document reviewed", "document_id", document.ID, "artisan_id", artisan.ID, "status", document.Status, "reviewed_by", actor.ID)
+	s.refreshBadges(ctx, artisan, now)
+	s.notify(ctx, document, artisan)
+	return dto.ToVerificationDocumentResponse(document), nil
+}
+
+// ============================================================================
+// Background Operations
+// ============================================================================
+
+// ExpireDocuments marks approved documents past their expiry date as expired and
+// revokes the badges they earned, returning how many expired
+func (s *verificationService) ExpireDocuments(ctx context.Context) (int, error) {
+	now := time.Now()
+	documents, err := s.repos.Verification.FindExpired(ctx, now, verificationExpiryBatchSize)
+	if err != nil {
+		return 0, errors.NewServiceError("VERIFICATION_EXPIRY_FAILED", "failed to find expired verification documents", err)
+	}
+
+	expired := 0
+	for _, document := range documents {
+		if !document.Expire(now) {
+			continue
+		}
+		if err := s.repos.Verification.Update(ctx, document); err != nil {
+			s.logger.Error("failed to expire verification document", "document_id", document.ID, "error", err)
+			continue
+		}
+		expired++
+
+		artisan, err := s.getArtisan(ctx, document.TenantID, document.ArtisanID)
+		if err != nil {
+			s.logger.Error("failed to find artisan of expired verification document", "document_id", document.ID, "error", err)
+			continue
+		}
+		s.refreshBadges(ctx, artisan, now)
+		s.notify(ctx, document, artisan)
+	}
+	return expired, nil
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+// getArtisan retrieves an artisan of the tenant
+func (s *verificationService) getArtisan(ctx context.Context, tenantID, artisanID uuid.UUID) (*models.Artisan, error) {
+	artisan, err := s.repos.Artisan.GetByID(ctx, artisanID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("artisan")
+		}
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to get artisan", err)
+	}
+	if artisan.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("artisan")
+	}
+	return artisan, nil
+}
+
+// getDocument retrieves a verification document of the tenant
+func (s *verificationService) getDocument(ctx context.Context, tenantID, documentID uuid.UUID) (*models.VerificationDocument, error) {
+	document, err := s.repos.Verification.GetByID(ctx, documentID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("verification document")
+		}
+		return nil, errors.NewServiceError("QUERY_FAILED", "failed to get verification document", err)
+	}
+	if document.TenantID != tenantID {
+		return nil, errors.NewNotFoundError("verification document")
+	}
+	return document, nil
+}
+
+// authorize lets artisans see and upload only their own documents; tenant owners and
+// admins handle anyone's
+func (s *verificationService) authorize(ctx context.Context, artisan *models.Artisan) error {
+	actor := contextActor(ctx)
+	if actor == nil || actor.ID == artisan.UserID || actor.CanManageTenant(artisan.TenantID) {
+		return nil
+	}
+	return errors.NewForbiddenError("only the artisan or a tenant owner or admin can manage verification documents")
+}
+
+// refreshBadges recalculates the artisan's badges from their documents. A failure is
+// logged; the badges are recalculated at the artisan's next review or expiry.
+func (s *verificationService) refreshBadges(ctx context.Context, artisan *models.Artisan, now time.Time) {
+	documents, err := s.repos.Verification.FindByArtisan(ctx, artisan.TenantID, artisan.ID)
+	if err != nil {
+		s.logger.Error("failed to load verification documents for badges", "artisan_id", artisan.ID, "error", err)
+		return
+	}
+
+	badges := models.ArtisanBadges(documents, now)
+	if badges.Verified == artisan.IsVerified && badges.Insured == artisan.IsInsured {
+		return
+	}
+	if err := s.repos.Artisan.UpdateBadges(ctx, artisan.ID, badges); err != nil {
+		s.logger.Error("failed to update artisan badges", "artisan_id", artisan.ID, "error", err)
+		return
+	}
+	artisan.IsVerified, artisan.IsInsured = badges.Verified, badges.Insured
+	s.logger.Info("artisan badges updated", "artisan_id", artisan.ID, "verified", badges.Verified, "insured", badges.Insured)
+}
+
+// notify tells the artisan about a decision on their document
+func (s *verificationService) notify(ctx context.Context, document *models.VerificationDocument, artisan *models.Artisan) {
+	if _, err := s.notifications.SendVerificationNotification(ctx, document, artisan.UserID); err != nil {
+		s.logger.Error("failed to send verification notification", "document_id", document.ID, "status", document.Status, "error", err)
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"reflect"
+	"time"
+
+	"Krafti_Vibe/internal/pkg/errors"
+)
+
+// fieldConflicts collects submitted fields whose stored value differs from the submitted one.
+// It is returned with optimistic locking failures so clients can merge and retry.
+type fieldConflicts []errors.FieldConflict
+
+// checkConflict records field when a value was submitted and differs from the stored one
+func checkConflict[T any](c *fieldConflicts, field string, requested *T, current T) {
+	if requested == nil || sameValue(*requested, current) {
+		return
+	}
+	*c = append(*c, errors.FieldConflict{Field: field, Current: current, Requested: *requested})
+}
+
+// sameValue compares values deeply, treating times as equal when they denote the same instant
+func sameValue(a, b any) bool {
+	switch at := a.(type) {
+	case time.Time:
+		if bt, ok := b.(time.Time); ok {
+			return at.Equal(bt)
+		}
+	case *time.Time:
+		if bt, ok := b.(*time.Time); ok {
+			if at == nil || bt == nil {
+				return at == bt
+			}
+			return at.Equal(*bt)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
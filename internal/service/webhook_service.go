@@ -7,15 +7,21 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"time"
 
 	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/pkg/tracing"
 	"Krafti_Vibe/internal/repository"
 	"Krafti_Vibe/internal/service/dto"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 )
 
 // webhookRepository defines extended webhook operations using the repository
@@ -51,6 +57,7 @@ type WebhookRepository interface {
 	TriggerBookingEvent(ctx context.Context, tenantID uuid.UUID, eventType models.WebhookEventType, booking any) error
 	TriggerPaymentEvent(ctx context.Context, tenantID uuid.UUID, eventType models.WebhookEventType, payment any) error
 	TriggerUserEvent(ctx context.Context, tenantID uuid.UUID, eventType models.WebhookEventType, user any) error
+	TriggerProjectEvent(ctx context.Context, tenantID uuid.UUID, eventType models.WebhookEventType, data map[string]any) error
 
 	// Background Processing
 	ProcessPendingWebhooks(ctx context.Context, batchSize int) (*dto.WebhookRetryResponse, error)
@@ -215,8 +222,29 @@ func (s *webhookRepository) DeliverWebhook(ctx context.Context, eventID uuid.UUI
 		s.logger.Error("failed to increment attempt count", "event_id", eventID, "error", err)
 	}
 
-	// Attempt delivery
-	responseCode, responseBody, err := s.sendWebhook(ctx, event.WebhookURL, event.Payload)
+	// Attempt delivery; events of a subscription go to its current URL, signed with
+	// its secret
+	attemptedAt := time.Now()
+	var (
+		responseCode int
+		responseBody string
+	)
+	if event.SubscriptionID != nil {
+		subscription, subErr := s.repos.WebhookSubscription.GetByID(ctx, *event.SubscriptionID)
+		switch {
+		case subErr != nil && errors.IsNotFoundError(subErr):
+			err = fmt.Errorf("webhook subscription was deleted")
+		case subErr != nil:
+			err = fmt.Errorf("failed to load webhook subscription: %w", subErr)
+		case !subscription.IsActive:
+			err = fmt.Errorf("webhook subscription is disabled")
+		default:
+			responseCode, responseBody, err = s.sendWebhook(ctx, event, subscription.URL, subscription.Secret)
+		}
+	} else {
+		responseCode, responseBody, err = s.sendWebhook(ctx, event, event.WebhookURL, "")
+	}
+	s.recordAttempt(ctx, event, attemptedAt, responseCode, responseBody, err)
 
 	response := &dto.WebhookDeliveryResponse{
 		WebhookEventID: event.ID,
@@ -697,6 +725,37 @@ func (s *webhookRepository) TriggerUserEvent(ctx context.Context, tenantID uuid.
 	return nil
 }
 
+// TriggerProjectEvent queues a webhook event for a project change to the tenant's
+// webhook URL. Nothing is queued when the tenant has no webhook URL or did not
+// subscribe to the event type; an empty subscription list receives every event.
+func (s *webhookRepository) TriggerProjectEvent(ctx context.Context, tenantID uuid.UUID, eventType models.WebhookEventType, data map[string]any) error {
+	tenant, err := s.repos.Tenant.GetByID(ctx, tenantID)
+	if err != nil {
+		return errors.NewServiceError("WEBHOOK_TRIGGER_FAILED", "failed to get tenant", err)
+	}
+
+	settings := tenant.Settings
+	if settings.WebhookURL == "" {
+		return nil
+	}
+	if len(settings.WebhookEvents) > 0 && !slices.Contains(settings.WebhookEvents, string(eventType)) {
+		return nil
+	}
+
+	_, err = s.CreateWebhookEvent(ctx, &dto.CreateWebhookEventRequest{
+		TenantID:   tenantID,
+		EventType:  eventType,
+		WebhookURL: settings.WebhookURL,
+		Payload: map[string]any{
+			"event":       eventType,
+			"tenant_id":   tenantID,
+			"occurred_at": time.Now().UTC(),
+			"data":        data,
+		},
+	})
+	return err
+}
+
 // ============================================================================
 // Background Processing
 // ============================================================================
@@ -788,9 +847,22 @@ func (s *webhookRepository) GetServiceMetrics(ctx context.Context) map[string]an
 // Helper Methods
 // ============================================================================
 
-// sendWebhook sends the webhook HTTP request
-func (s *webhookRepository) sendWebhook(ctx context.Context, url string, payload models.JSONB) (int, string, error) {
-	payloadBytes, err := json.Marshal(payload)
+// sendWebhook sends the webhook HTTP request. The delivery and event type are named in
+// headers, and with a secret the payload is signed (see SignWebhookPayload). The request
+// carries W3C trace context, so receivers can continue the delivery's trace.
+func (s *webhookRepository) sendWebhook(ctx context.Context, event *models.WebhookEvent, url, secret string) (statusCode int, body string, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.deliver",
+		attribute.String("webhook.event_id", event.ID.String()),
+		attribute.String("webhook.event_type", string(event.EventType)),
+	)
+	defer func() {
+		if statusCode != 0 {
+			span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+		}
+		tracing.End(span, err)
+	}()
+
+	payloadBytes, err := json.Marshal(event.Payload)
 	if err != nil {
 		return 0, "", fmt.Errorf("failed to marshal payload: %w", err)
 	}
@@ -799,9 +871,15 @@ func (s *webhookRepository) sendWebhook(ctx context.Context, url string, payload
 	if err != nil {
 		return 0, "", fmt.Errorf("failed to create request: %w", err)
 	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "Krafti_Vibe-Webhook/1.0")
+	req.Header.Set(WebhookIDHeader, event.ID.String())
+	req.Header.Set(WebhookEventHeader, string(event.EventType))
+	if secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, time.Now(), payloadBytes))
+	}
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
@@ -824,6 +902,32 @@ func (s *webhookRepository) sendWebhook(ctx context.Context, url string, payload
 	return resp.StatusCode, string(bodyBytes), nil
 }
 
+// recordAttempt logs a delivery attempt for the tenant. A failure to log it does not
+// fail the delivery.
+func (s *webhookRepository) recordAttempt(ctx context.Context, event *models.WebhookEvent, attemptedAt time.Time, responseCode int, responseBody string, deliveryErr error) {
+	if len(responseBody) > webhookAttemptBodyLimit {
+		responseBody = responseBody[:webhookAttemptBodyLimit]
+	}
+
+	attempt := &models.WebhookDeliveryAttempt{
+		TenantID:       event.TenantID,
+		WebhookEventID: event.ID,
+		Attempt:        event.AttemptCount + 1,
+		AttemptedAt:    attemptedAt,
+		DurationMs:     time.Since(attemptedAt).Milliseconds(),
+		Succeeded:      deliveryErr == nil,
+		ResponseCode:   responseCode,
+		ResponseBody:   responseBody,
+	}
+	if deliveryErr != nil {
+		attempt.Error = deliveryErr.Error()
+	}
+
+	if err := s.repos.WebhookEvent.RecordAttempt(ctx, attempt); err != nil {
+		s.logger.Error("failed to record webhook delivery attempt", "event_id", event.ID, "error", err)
+	}
+}
+
 // calculateNextRetryTime calculates the next retry time with exponential backoff
 func (s *webhookRepository) calculateNextRetryTime(attemptCount int) time.Time {
 	// Exponential backoff: 1min, 5min, 15min, 30min, 1hr, 2hr, 4hr
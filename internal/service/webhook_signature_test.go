@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"Krafti_Vibe/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	secret, err := service.GenerateWebhookSecret()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, "whsec_"))
+
+	other, err := service.GenerateWebhookSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	sentAt := time.Unix(1750000000, 0)
+	payload := []byte(`{"type":"payment.succeeded"}`)
+	header := service.SignWebhookPayload(secret, sentAt, payload)
+	assert.True(t, strings.HasPrefix(header, "t=1750000000,v1="))
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		payload []byte
+		now     time.Time
+		valid   bool
+	}{
+		{"valid signature", secret, header, payload, sentAt.Add(time.Minute), true},
+		{"another signature alongside", secret, header + ",v1=deadbeef", payload, sentAt, true},
+		{"tampered payload", secret, header, []byte(`{"type":"payment.failed"}`), sentAt, false},
+		{"wrong secret", other, header, payload, sentAt, false},
+		{"outside tolerance", secret, header, payload, sentAt.Add(10 * time.Minute), false},
+		{"missing timestamp", secret, strings.TrimPrefix(header, "t=1750000000,"), payload, sentAt, false},
+		{"malformed", secret, "garbage", payload, sentAt, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.VerifyWebhookSignature(tt.secret, tt.header, tt.payload, 5*time.Minute, tt.now)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, service.ErrInvalidWebhookSignature)
+			}
+		})
+	}
+}
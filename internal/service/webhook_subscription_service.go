@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"Krafti_Vibe/internal/domain/events"
+	"Krafti_Vibe/internal/domain/models"
+	"Krafti_Vibe/internal/pkg/errors"
+	"Krafti_Vibe/internal/repository"
+	"Krafti_Vibe/internal/service/dto"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// webhookSubscriptionMaxAttempts is how many times a delivery to a subscription is
+// attempted before it is given up on; with the webhook backoff the last attempt is
+// made about eight hours after the first
+const webhookSubscriptionMaxAttempts = 8
+
+// WebhookSubscriptionService defines the interface for tenant webhook subscription operations
+type WebhookSubscriptionService interface {
+	// CRUD Operations
+	CreateSubscription(ctx context.Context, req *dto.CreateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (*dto.WebhookSubscriptionResponse, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, req *dto.UpdateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	ListSubscriptions(ctx context.Context, tenantID uuid.UUID) ([]*dto.WebhookSubscriptionResponse, error)
+	RotateSecret(ctx context.Context, id uuid.UUID) (*dto.WebhookSubscriptionResponse, error)
+
+	// Deliveries
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, page, pageSize int) (*dto.WebhookSubscriptionDeliveryListResponse, error)
+	GetDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*dto.WebhookSubscriptionDeliveryResponse, error)
+	Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*dto.WebhookDeliveryResponse, error)
+
+	// Event Bus
+	QueueDeliveries(ctx context.Context, envelope *events.Envelope) error
+}
+
+// webhookSubscriptionService implements WebhookSubscriptionService
+type webhookSubscriptionService struct {
+	repos          *repository.Repositories
+	logger         log.AllLogger
+	webhookService WebhookRepository
+}
+
+// NewWebhookSubscriptionService creates a new WebhookSubscriptionService instance. The
+// webhook service delivers the queued events.
+func NewWebhookSubscriptionService(repos *repository.Repositories, logger log.AllLogger, webhookService WebhookRepository) WebhookSubscriptionService {
+	return &webhookSubscriptionService{
+		repos:          repos,
+		logger:         logger,
+		webhookService: webhookService,
+	}
+}
+
+// ============================================================================
+// CRUD Operations
+// ============================================================================
+
+// CreateSubscription creates a subscription with a new signing secret, which is
+// returned this once
+func (s *webhookSubscriptionService) CreateSubscription(ctx context.Context, req *dto.CreateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("invalid request: " + err.Error())
+	}
+
+	eventTypes, err := normalizeWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		return nil, errors.NewServiceError("WEBHOOK_SECRET_FAILED", "failed to generate webhook secret", err)
+	}
+
+	subscription := &models.WebhookSubscription{
+		TenantID:    req.TenantID,
+		URL:         strings.TrimSpace(req.URL),
+		Description: strings.TrimSpace(req.Description),
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+	}
+	if err := subscription.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.WebhookSubscription.Create(ctx, subscription); err != nil {
+		s.logger.Error("failed to create webhook subscription", "tenant_id", req.TenantID, "error", err)
+		return nil, errors.NewServiceError("WEBHOOK_SUBSCRIPTION_CREATE_FAILED", "failed to create webhook subscription", err)
+	}
+
+	s.logger.Info("webhook subscription created", "webhook_subscription_id", subscription.ID, "tenant_id", subscription.TenantID)
+
+	response := dto.ToWebhookSubscriptionResponse(subscription)
+	response.Secret = secret
+	return response, nil
+}
+
+// GetSubscription retrieves a webhook subscription by ID
+func (s *webhookSubscriptionService) GetSubscription(ctx context.Context, id uuid.UUID) (*dto.WebhookSubscriptionResponse, error) {
+	subscription, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToWebhookSubscriptionResponse(subscription), nil
+}
+
+// UpdateSubscription updates a webhook subscription. Deliveries already queued go to
+// the subscription's URL at the time they are attempted.
+func (s *webhookSubscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, req *dto.UpdateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error) {
+	subscription, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		subscription.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Description != nil {
+		subscription.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.EventTypes != nil {
+		eventTypes, err := normalizeWebhookEventTypes(*req.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		subscription.EventTypes = eventTypes
+	}
+	if req.IsActive != nil {
+		subscription.IsActive = *req.IsActive
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := s.repos.WebhookSubscription.Update(ctx, subscription); err != nil {
+		s.logger.Error("failed to update webhook subscription", "webhook_subscription_id", id, "error", err)
+		return nil, errors.NewServiceError("WEBHOOK_SUBSCRIPTION_UPDATE_FAILED", "failed to update webhook subscription", err)
+	}
+
+	return dto.ToWebhookSubscriptionResponse(subscription), nil
+}
+
+// DeleteSubscription deletes a webhook subscription. Its queued deliveries fail until
+// they run out of attempts, and its delivery logs are kept.
+func (s *webhookSubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repos.WebhookSubscription.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete webhook subscription", "webhook_subscription_id", id, "error", err)
+		return errors.NewServiceError("WEBHOOK_SUBSCRIPTION_DELETE_FAILED", "failed to delete webhook subscription", err)
+	}
+
+	return nil
+}
+
+// ListSubscriptions lists all webhook subscriptions of a tenant
+func (s *webhookSubscriptionService) ListSubscriptions(ctx context.Context, tenantID uuid.UUID) ([]*dto.WebhookSubscriptionResponse, error) {
+	if tenantID == uuid.Nil {
+		return nil, errors.NewValidationError("tenant ID is required")
+	}
+
+	subscriptions, err := s.repos.WebhookSubscription.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, errors.NewServiceError("WEBHOOK_SUBSCRIPTION_LIST_FAILED", "failed to list webhook subscriptions", err)
+	}
+
+	return dto.ToWebhookSubscriptionResponses(subscriptions), nil
+}
+
+// RotateSecret replaces a subscription's signing secret, returning the new one. Every
+// delivery attempted afterwards, including retries, is signed with the new secret.
+func (s *webhookSubscriptionService) RotateSecret(ctx context.Context, id uuid.UUID) (*dto.WebhookSubscriptionResponse, error) {
+	subscription, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		return nil, errors.NewServiceError("WEBHOOK_SECRET_FAILED", "failed to generate webhook secret", err)
+	}
+	subscription.Secret = secret
+
+	if err := s.repos.WebhookSubscription.Update(ctx, subscription); err != nil {
+		s.logger.Error("failed to rotate webhook subscription secret", "webhook_subscription_id", id, "error", err)
+		return nil, errors.NewServiceError("WEBHOOK_SUBSCRIPTION_UPDATE_FAILED", "failed to rotate webhook secret", err)
+	}
+
+	s.logger.Info("webhook subscription secret rotated", "webhook_subscription_id", id, "tenant_id", subscription.TenantID)
+
+	response := dto.ToWebhookSubscriptionResponse(subscription)
+	response.Secret = secret
+	return response, nil
+}
+
+// ============================================================================
+// Deliveries
+// ============================================================================
+
+// ListDeliveries lists a subscription's deliveries, most recent first
+func (s *webhookSubscriptionService) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, page, pageSize int) (*dto.WebhookSubscriptionDeliveryListResponse, error) {
+	if subscriptionID == uuid.Nil {
+		return nil, errors.NewValidationError("webhook subscription ID is required")
+	}
+
+	pagination := repository.PaginationParams{Page: page, PageSize: pageSize}
+	webhooks, result, err := s.repos.WebhookEvent.GetBySubscriptionID(ctx, subscriptionID, pagination)
+	if err != nil {
+		return nil, errors.NewServiceError("WEBHOOK_DELIVERY_LIST_FAILED", "failed to list webhook deliveries", err)
+	}
+
+	deliveries := make([]*dto.WebhookSubscriptionDeliveryResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		deliveries[i] = dto.ToWebhookSubscriptionDeliveryResponse(webhook, nil)
+	}
+
+	return &dto.WebhookSubscriptionDeliveryListResponse{
+		Deliveries:  deliveries,
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrev,
+	}, nil
+}
+
+// GetDelivery retrieves a delivery of a subscription with the log of its attempts
+func (s *webhookSubscriptionService) GetDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*dto.WebhookSubscriptionDeliveryResponse, error) {
+	webhook, err := s.getDelivery(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.repos.WebhookEvent.GetAttempts(ctx, webhook.ID)
+	if err != nil {
+		return nil, errors.NewServiceError("WEBHOOK_DELIVERY_GET_FAILED", "failed to get webhook delivery attempts", err)
+	}
+
+	return dto.ToWebhookSubscriptionDeliveryResponse(webhook, attempts), nil
+}
+
+// Redeliver sends a delivery again now, whether it was delivered, is waiting for a
+// retry or ran out of attempts. It gets a fresh set of attempts, and keeps its ID so
+// receivers can recognise it.
+func (s *webhookSubscriptionService) Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*dto.WebhookDeliveryResponse, error) {
+	if _, err := s.getDelivery(ctx, subscriptionID, deliveryID); err != nil {
+		return nil, err
+	}
+
+	response, err := s.webhookService.RetryWebhook(ctx, &dto.RetryWebhookRequest{
+		WebhookEventID: deliveryID,
+		ResetAttempts:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("webhook redelivered",
+		"webhook_subscription_id", subscriptionID,
+		"webhook_event_id", deliveryID,
+		"delivered", response.Delivered)
+	return response, nil
+}
+
+// ============================================================================
+// Event Bus
+// ============================================================================
+
+// QueueDeliveries queues a domain event for every active subscription of its tenant
+// that receives its type; the webhook_delivery job sends them. An event is queued at
+// most once per subscription, so the event bus can hand it over again safely.
+func (s *webhookSubscriptionService) QueueDeliveries(ctx context.Context, envelope *events.Envelope) error {
+	if envelope.TenantID == nil {
+		return nil
+	}
+	tenantID := *envelope.TenantID
+
+	subscriptions, err := s.repos.WebhookSubscription.FindActiveForEvent(ctx, tenantID, string(envelope.Type))
+	if err != nil {
+		return errors.NewServiceError("WEBHOOK_SUBSCRIPTION_LIST_FAILED", "failed to find webhook subscriptions", err)
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	var data any
+	if err := json.Unmarshal(envelope.Payload, &data); err != nil {
+		return errors.NewServiceError("WEBHOOK_PAYLOAD_INVALID", "failed to decode event payload", err)
+	}
+	payload := models.JSONB{
+		"id":           envelope.ID,
+		"type":         envelope.Type,
+		"tenant_id":    tenantID,
+		"aggregate_id": envelope.AggregateID,
+		"occurred_at":  envelope.OccurredAt.UTC(),
+		"data":         data,
+	}
+
+	webhooks := make([]*models.WebhookEvent, len(subscriptions))
+	for i, subscription := range subscriptions {
+		webhooks[i] = &models.WebhookEvent{
+			TenantID:       tenantID,
+			SubscriptionID: &subscription.ID,
+			DomainEventID:  &envelope.ID,
+			EventType:      models.WebhookEventType(envelope.Type),
+			Payload:        payload,
+			WebhookURL:     subscription.URL,
+			MaxAttempts:    webhookSubscriptionMaxAttempts,
+		}
+	}
+
+	queued, err := s.repos.WebhookEvent.CreateForSubscriptions(ctx, webhooks)
+	if err != nil {
+		return errors.NewServiceError("WEBHOOK_CREATE_FAILED", "failed to queue webhook deliveries", err)
+	}
+
+	if queued > 0 {
+		s.logger.Debug("webhook deliveries queued", "event_id", envelope.ID, "event_type", envelope.Type, "count", queued)
+	}
+	return nil
+}
+
+// ============================================================================
+// Helper Methods
+// ============================================================================
+
+func (s *webhookSubscriptionService) getSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewValidationError("webhook subscription ID is required")
+	}
+
+	subscription, err := s.repos.WebhookSubscription.GetByID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("webhook subscription not found")
+		}
+		return nil, errors.NewServiceError("WEBHOOK_SUBSCRIPTION_GET_FAILED", "failed to get webhook subscription", err)
+	}
+
+	return subscription, nil
+}
+
+// getDelivery loads a webhook event, reporting it not found unless it was queued for
+// the given subscription
+func (s *webhookSubscriptionService) getDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*models.WebhookEvent, error) {
+	if deliveryID == uuid.Nil {
+		return nil, errors.NewValidationError("webhook delivery ID is required")
+	}
+
+	webhook, err := s.repos.WebhookEvent.GetByID(ctx, deliveryID)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.NewNotFoundError("webhook delivery not found")
+		}
+		return nil, errors.NewServiceError("WEBHOOK_DELIVERY_GET_FAILED", "failed to get webhook delivery", err)
+	}
+	if webhook.SubscriptionID == nil || *webhook.SubscriptionID != subscriptionID {
+		return nil, errors.NewNotFoundError("webhook delivery not found")
+	}
+
+	return webhook, nil
+}
+
+// normalizeWebhookEventTypes checks the event types are known, dropping duplicates
+func normalizeWebhookEventTypes(eventTypes []string) (models.StringArray, error) {
+	normalized := make(models.StringArray, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !events.Type(eventType).IsValid() {
+			return nil, errors.NewValidationError("unknown event type: " + eventType)
+		}
+		if !slices.Contains(normalized, eventType) {
+			normalized = append(normalized, eventType)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
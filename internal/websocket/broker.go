@@ -0,0 +1,180 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Booking event types pushed to realtime clients
+const (
+	EventBookingCreated   = "booking.created"
+	EventBookingUpdated   = "booking.updated"
+	EventBookingCancelled = "booking.cancelled"
+)
+
+// bookingEventsChannel is the Redis channel booking events are fanned out on
+const bookingEventsChannel = "realtime:bookings"
+
+// bookingStaffRoles receive every booking event of their tenant;
+// artisans and customers only receive events for their own bookings
+var bookingStaffRoles = []models.UserRole{
+	models.UserRoleTenantOwner,
+	models.UserRoleTenantAdmin,
+	models.UserRoleTeamMember,
+}
+
+// PubSub is the subset of the Redis client used to fan events out across instances
+type PubSub interface {
+	Publish(ctx context.Context, channel string, message interface{}) error
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+}
+
+// Broker publishes realtime events to every API instance through Redis pub/sub.
+// Each instance subscribes and delivers the events to its own hub. Without Redis,
+// events are delivered to the local hub only.
+type Broker struct {
+	hub    *Hub
+	pubsub PubSub
+	logger log.AllLogger
+}
+
+// envelope is the wire format published to Redis
+type envelope struct {
+	Audience Audience `json:"audience"`
+	Message  *Message `json:"message"`
+}
+
+// NewBroker creates a new Broker; pubsub may be nil for single-instance deployments
+func NewBroker(hub *Hub, pubsub PubSub, logger log.AllLogger) *Broker {
+	return &Broker{
+		hub:    hub,
+		pubsub: pubsub,
+		logger: logger,
+	}
+}
+
+// Publish sends a message to the audience on every instance
+func (b *Broker) Publish(ctx context.Context, audience Audience, msgType string, data map[string]interface{}) {
+	if b.pubsub == nil {
+		b.hub.BroadcastToAudience(audience, msgType, data)
+		return
+	}
+
+	payload, err := json.Marshal(envelope{
+		Audience: audience,
+		Message: &Message{
+			Type:      msgType,
+			Target:    "audience",
+			TargetID:  &audience.TenantID,
+			Data:      data,
+			Timestamp: currentTimestamp(),
+		},
+	})
+	if err != nil {
+		b.logger.Error("failed to encode realtime event", "type", msgType, "error", err)
+		return
+	}
+
+	if err := b.pubsub.Publish(ctx, bookingEventsChannel, payload); err != nil {
+		// Fall back to this instance's clients rather than dropping the event
+		b.logger.Warn("failed to publish realtime event, delivering locally", "type", msgType, "error", err)
+		b.hub.BroadcastToAudience(audience, msgType, data)
+	}
+}
+
+// Run relays events received from Redis to the local hub until the context is cancelled,
+// resubscribing after connection failures
+func (b *Broker) Run(ctx context.Context) {
+	if b.pubsub == nil {
+		return
+	}
+
+	for ctx.Err() == nil {
+		b.relay(ctx)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// relay consumes one subscription until it fails or the context is cancelled
+func (b *Broker) relay(ctx context.Context) {
+	sub := b.pubsub.Subscribe(ctx, bookingEventsChannel)
+	defer sub.Close()
+
+	if _, err := sub.Receive(ctx); err != nil {
+		if ctx.Err() == nil {
+			b.logger.Warn("failed to subscribe to realtime events", "error", err)
+		}
+		return
+	}
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			var env envelope
+			if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil || env.Message == nil {
+				b.logger.Warn("discarding malformed realtime event", "error", err)
+				continue
+			}
+			env.Message.Audience = &env.Audience
+			b.hub.broadcast <- env.Message
+		}
+	}
+}
+
+// PublishBookingEvent pushes a booking change to tenant staff and the booking's participants
+func (b *Broker) PublishBookingEvent(ctx context.Context, event *models.BookingEvent, booking *models.Booking) {
+	audience := Audience{
+		TenantID: booking.TenantID,
+		Roles:    bookingStaffRoles,
+		UserIDs:  []uuid.UUID{booking.ArtisanID, booking.CustomerID},
+	}
+
+	data := map[string]interface{}{
+		"booking_id":     booking.ID,
+		"tenant_id":      booking.TenantID,
+		"artisan_id":     booking.ArtisanID,
+		"customer_id":    booking.CustomerID,
+		"service_id":     booking.ServiceID,
+		"status":         booking.Status,
+		"payment_status": booking.PaymentStatus,
+		"start_time":     booking.StartTime,
+		"end_time":       booking.EndTime,
+		"change_type":    event.EventType,
+		"changes":        event.Changes,
+		"source":         event.Source,
+	}
+	if event.ActorID != nil {
+		data["actor_id"] = *event.ActorID
+	}
+
+	b.Publish(ctx, audience, bookingMessageType(event), data)
+}
+
+// bookingMessageType maps a change log entry to the realtime event type
+func bookingMessageType(event *models.BookingEvent) string {
+	if event.EventType == models.BookingEventCreated {
+		return EventBookingCreated
+	}
+	if change, ok := event.Changes["status"]; ok && change.To == string(models.BookingStatusCancelled) {
+		return EventBookingCancelled
+	}
+	return EventBookingUpdated
+}
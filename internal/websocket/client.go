@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"time"
 
+	"Krafti_Vibe/internal/domain/models"
+
 	"github.com/gofiber/contrib/websocket"
 	"github.com/google/uuid"
 )
@@ -24,7 +26,7 @@ const (
 
 // Client represents a WebSocket client connection
 type Client struct {
-	// The WebSocket connection
+	// The WebSocket connection (nil for server-sent event streams)
 	conn *websocket.Conn
 
 	// Hub that manages this client
@@ -39,19 +41,23 @@ type Client struct {
 	// Tenant ID associated with this connection
 	TenantID uuid.UUID
 
+	// Role of the connected user, used to scope audience messages
+	Role models.UserRole
+
 	// Client metadata
 	ConnectedAt time.Time
 	LastPingAt  time.Time
 }
 
 // NewClient creates a new WebSocket client
-func NewClient(conn *websocket.Conn, hub *Hub, userID, tenantID uuid.UUID) *Client {
+func NewClient(conn *websocket.Conn, hub *Hub, userID, tenantID uuid.UUID, role models.UserRole) *Client {
 	return &Client{
 		conn:        conn,
 		hub:         hub,
 		send:        make(chan *Message, 256),
 		UserID:      userID,
 		TenantID:    tenantID,
+		Role:        role,
 		ConnectedAt: time.Now(),
 		LastPingAt:  time.Now(),
 	}
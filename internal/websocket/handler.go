@@ -1,6 +1,12 @@
 package websocket
 
 import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Krafti_Vibe/internal/domain/models"
 	"Krafti_Vibe/internal/middleware"
 
 	"github.com/gofiber/contrib/websocket"
@@ -52,8 +58,14 @@ func (h *Handler) HandleConnection(c *websocket.Conn) {
 		return
 	}
 
+	// Role scopes audience messages such as booking events
+	var role models.UserRole
+	if user, ok := c.Locals("db_user").(*models.User); ok && user != nil {
+		role = user.Role
+	}
+
 	// Create new client
-	client := NewClient(c, h.hub, authCtx.UserID, authCtx.TenantID)
+	client := NewClient(c, h.hub, authCtx.UserID, authCtx.TenantID, role)
 
 	// Register client with hub
 	h.hub.register <- client
@@ -70,6 +82,89 @@ func (h *Handler) HandleConnection(c *websocket.Conn) {
 	client.ReadPump()
 }
 
+// Stream godoc
+// @Summary Realtime event stream
+// @Description Server-sent event stream of realtime events (booking.created, booking.updated, booking.cancelled) scoped to the caller's tenant and role. Tenant owners, admins and team members receive every booking of the tenant; artisans and customers receive their own bookings.
+// @Tags Realtime
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {string} string "event stream"
+// @Failure 401 {object} map[string]interface{}
+// @Router /stream [get]
+func (h *Handler) Stream(c *fiber.Ctx) error {
+	authCtx, ok := middleware.GetAuthContext(c)
+	if !ok || authCtx == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Unauthorized",
+		})
+	}
+
+	var role models.UserRole
+	if user, ok := middleware.GetDatabaseUser(c); ok {
+		role = user.Role
+	}
+
+	client := NewClient(nil, h.hub, authCtx.UserID, authCtx.TenantID, role)
+	h.hub.register <- client
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ticker := time.NewTicker(pingPeriod)
+		defer func() {
+			ticker.Stop()
+			h.hub.unregister <- client
+		}()
+
+		connected := &Message{
+			Type:      "connected",
+			Data:      map[string]interface{}{"user_id": authCtx.UserID, "tenant_id": authCtx.TenantID},
+			Timestamp: currentTimestamp(),
+		}
+		if err := writeEvent(w, connected); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case message, ok := <-client.send:
+				if !ok {
+					return
+				}
+				if err := writeEvent(w, message); err != nil {
+					return
+				}
+
+			case <-ticker.C:
+				// Comments keep proxies from closing idle streams and detect disconnects
+				if _, err := w.WriteString(": keep-alive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// writeEvent writes a message as a server-sent event and flushes it
+func writeEvent(w *bufio.Writer, message *Message) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", message.Type, payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
 // GetStats returns WebSocket statistics
 func (h *Handler) GetStats(c *fiber.Ctx) error {
 	stats := fiber.Map{
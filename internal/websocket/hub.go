@@ -1,9 +1,12 @@
 package websocket
 
 import (
+	"slices"
 	"sync"
 	"time"
 
+	"Krafti_Vibe/internal/domain/models"
+
 	"github.com/google/uuid"
 )
 
@@ -34,10 +37,28 @@ type Hub struct {
 // Message represents a WebSocket message
 type Message struct {
 	Type      string                 `json:"type"`
-	Target    string                 `json:"target,omitempty"` // user, tenant, broadcast
+	Target    string                 `json:"target,omitempty"` // user, tenant, audience, broadcast
 	TargetID  *uuid.UUID             `json:"target_id,omitempty"`
 	Data      map[string]interface{} `json:"data"`
 	Timestamp int64                  `json:"timestamp"`
+
+	// Audience scopes "audience" messages; it is never sent to clients
+	Audience *Audience `json:"-"`
+}
+
+// Audience restricts a message to the clients of a tenant allowed to see it
+type Audience struct {
+	TenantID uuid.UUID         `json:"tenant_id"`
+	Roles    []models.UserRole `json:"roles,omitempty"`    // Roles that receive the message
+	UserIDs  []uuid.UUID       `json:"user_ids,omitempty"` // Users that receive it regardless of role
+}
+
+// Includes reports whether the client is part of the audience
+func (a *Audience) Includes(client *Client) bool {
+	if client.TenantID != a.TenantID {
+		return false
+	}
+	return slices.Contains(a.Roles, client.Role) || slices.Contains(a.UserIDs, client.UserID)
 }
 
 // NewHub creates a new Hub
@@ -137,6 +158,10 @@ func (h *Hub) broadcastMessage(message *Message) {
 		if message.TargetID != nil {
 			h.sendToTenant(*message.TargetID, message)
 		}
+	case "audience":
+		if message.Audience != nil {
+			h.sendToAudience(message.Audience, message)
+		}
 	case "broadcast":
 		h.sendToAll(message)
 	default:
@@ -174,6 +199,20 @@ func (h *Hub) sendToTenant(tenantID uuid.UUID, message *Message) {
 	}
 }
 
+// sendToAudience sends a message to the tenant's clients included in the audience.
+// Clients with a full buffer miss the message rather than blocking the hub.
+func (h *Hub) sendToAudience(audience *Audience, message *Message) {
+	for client := range h.tenantClients[audience.TenantID] {
+		if !audience.Includes(client) {
+			continue
+		}
+		select {
+		case client.send <- message:
+		default:
+		}
+	}
+}
+
 // sendToAll sends a message to all connected clients
 func (h *Hub) sendToAll(message *Message) {
 	for client := range h.clients {
@@ -210,6 +249,19 @@ func (h *Hub) BroadcastToTenant(tenantID uuid.UUID, msgType string, data map[str
 	h.broadcast <- message
 }
 
+// BroadcastToAudience sends a message to the clients of a tenant included in the audience
+func (h *Hub) BroadcastToAudience(audience Audience, msgType string, data map[string]interface{}) {
+	message := &Message{
+		Type:      msgType,
+		Target:    "audience",
+		TargetID:  &audience.TenantID,
+		Data:      data,
+		Timestamp: currentTimestamp(),
+		Audience:  &audience,
+	}
+	h.broadcast <- message
+}
+
 // BroadcastToAll sends a message to all connected clients
 func (h *Hub) BroadcastToAll(msgType string, data map[string]interface{}) {
 	message := &Message{